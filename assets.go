@@ -0,0 +1,10 @@
+// Package workshop embeds the files served from the site root so the server binary
+// can be deployed on its own.
+package workshop
+
+import "embed"
+
+// StaticFiles holds the static directory (scripts, styles, service worker, icons).
+//
+//go:embed static
+var StaticFiles embed.FS
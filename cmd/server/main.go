@@ -3,55 +3,110 @@ package main
 import (
 	"context"
 	"database/sql"
+	"io/fs"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	_ "modernc.org/sqlite"
 
+	"workshop"
+	backupPkg "workshop/internal/adapters/backup"
 	emailPkg "workshop/internal/adapters/email"
 	web "workshop/internal/adapters/http"
+	"workshop/internal/adapters/http/middleware"
 	"workshop/internal/adapters/http/perf"
+	"workshop/internal/adapters/oidc"
 	"workshop/internal/adapters/storage"
 	accountStore "workshop/internal/adapters/storage/account"
 	attendanceStore "workshop/internal/adapters/storage/attendance"
 	auditStorePkg "workshop/internal/adapters/storage/audit"
+	authSessionStorePkg "workshop/internal/adapters/storage/authsession"
+	availabilityStorePkg "workshop/internal/adapters/storage/availability"
 	bugboxStorePkg "workshop/internal/adapters/storage/bugbox"
 	calendarStorePkg "workshop/internal/adapters/storage/calendar"
+	celebrationStorePkg "workshop/internal/adapters/storage/celebration"
+	classPackStorePkg "workshop/internal/adapters/storage/classpack"
 	classTypeStore "workshop/internal/adapters/storage/classtype"
 	clipStorePkg "workshop/internal/adapters/storage/clip"
 	consentStorePkg "workshop/internal/adapters/storage/consent"
+	contactCheckStorePkg "workshop/internal/adapters/storage/contactcheck"
 	deletionStorePkg "workshop/internal/adapters/storage/deletion"
 	emailStorePkg "workshop/internal/adapters/storage/email"
 	estimatedHoursStorePkg "workshop/internal/adapters/storage/estimatedhours"
+	exportStorePkg "workshop/internal/adapters/storage/export"
 	featureFlagStorePkg "workshop/internal/adapters/storage/featureflag"
+	feedbackStorePkg "workshop/internal/adapters/storage/feedback"
 	gradingStore "workshop/internal/adapters/storage/grading"
 	holidayStore "workshop/internal/adapters/storage/holiday"
 	injuryStore "workshop/internal/adapters/storage/injury"
+	inventoryStorePkg "workshop/internal/adapters/storage/inventory"
+	jobStorePkg "workshop/internal/adapters/storage/job"
+	kioskStorePkg "workshop/internal/adapters/storage/kiosk"
+	kpiStorePkg "workshop/internal/adapters/storage/kpi"
+	locationStorePkg "workshop/internal/adapters/storage/location"
 	memberStore "workshop/internal/adapters/storage/member"
+	memberTagStorePkg "workshop/internal/adapters/storage/membertag"
 	messageStore "workshop/internal/adapters/storage/message"
 	milestoneStore "workshop/internal/adapters/storage/milestone"
 	noticeStore "workshop/internal/adapters/storage/notice"
+	notificationStorePkg "workshop/internal/adapters/storage/notification"
 	observationStore "workshop/internal/adapters/storage/observation"
 	outboxStorePkg "workshop/internal/adapters/storage/outbox"
+	perfStatsStorePkg "workshop/internal/adapters/storage/perfstats"
+	permissionStorePkg "workshop/internal/adapters/storage/permission"
 	personalgoalStorePkg "workshop/internal/adapters/storage/personalgoal"
 	programStore "workshop/internal/adapters/storage/program"
+	quickCaptureStorePkg "workshop/internal/adapters/storage/quickcapture"
+	reengagementStorePkg "workshop/internal/adapters/storage/reengagement"
+	referralStorePkg "workshop/internal/adapters/storage/referral"
 	rotorStorePkg "workshop/internal/adapters/storage/rotor"
+	rubricStorePkg "workshop/internal/adapters/storage/rubric"
 	scheduleStore "workshop/internal/adapters/storage/schedule"
+	searchStorePkg "workshop/internal/adapters/storage/search"
+	sessionLogStorePkg "workshop/internal/adapters/storage/sessionlog"
+	statusChangeStorePkg "workshop/internal/adapters/storage/statuschange"
 	termStore "workshop/internal/adapters/storage/term"
 	themeStorePkg "workshop/internal/adapters/storage/theme"
+	timesheetStorePkg "workshop/internal/adapters/storage/timesheet"
 	trainingGoalStore "workshop/internal/adapters/storage/traininggoal"
+	visitorStorePkg "workshop/internal/adapters/storage/visitor"
 	waiverStore "workshop/internal/adapters/storage/waiver"
+	"workshop/internal/adapters/webpush"
 	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	"workshop/internal/application/trace"
+	"workshop/internal/config"
+	backupDomain "workshop/internal/domain/backup"
 )
 
 // version is set at build time via -ldflags "-X main.version=..."
 var version = "dev"
 
 func main() {
+	// Structured logs; lines written while handling a request carry its request_id
+	slog.SetDefault(slog.New(trace.NewHandler(slog.NewTextHandler(os.Stderr, nil))))
+
+	// Load and validate every setting before touching the database
+	appConfig, err := config.Load()
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+	for _, warning := range appConfig.Warnings {
+		log.Printf("WARNING: %s", warning)
+	}
+	web.SetConfig(appConfig)
+	middleware.SetSlowRequestThreshold(appConfig.SlowRequest)
+	storage.SetSlowQueryThreshold(appConfig.SlowQuery)
+	storage.SetQueryPlanGuard(appConfig.QueryPlanRows)
+
 	// Initialize database with WAL mode, foreign keys, and busy timeout per DB_GUIDE
-	dbPath := "workshop.db"
+	dbPath := appConfig.DBPath
 	dsn := dbPath + "?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_pragma=foreign_keys(ON)&_pragma=synchronous(NORMAL)"
 	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
@@ -90,19 +145,28 @@ func main() {
 		WaiverStore:              waiverStore.NewSQLiteStore(timedDB),
 		InjuryStore:              injuryStore.NewSQLiteStore(timedDB),
 		AttendanceStore:          attendanceStore.NewSQLiteStore(timedDB),
+		MakeupCreditStore:        attendanceStore.NewMakeupCreditSQLiteStore(timedDB),
 		ProgramStore:             progStore,
 		ClassTypeStore:           ctStore,
+		ClassTypeApprovalStore:   classTypeStore.NewApprovalSQLiteStore(timedDB),
 		ScheduleStore:            scheduleStore.NewSQLiteStore(timedDB),
+		OccurrenceChangeStore:    scheduleStore.NewOccurrenceChangeSQLiteStore(timedDB),
 		TermStore:                termStore.NewSQLiteStore(timedDB),
+		TermRolloverStore:        termStore.NewRolloverSQLiteStore(timedDB),
+		TermReportStore:          termStore.NewReportSQLiteStore(timedDB),
 		HolidayStore:             holidayStore.NewSQLiteStore(timedDB),
 		NoticeStore:              noticeStore.NewSQLiteStore(timedDB),
 		GradingRecordStore:       gradingStore.NewRecordSQLiteStore(timedDB),
 		GradingConfigStore:       gradingStore.NewConfigSQLiteStore(timedDB),
 		GradingProposalStore:     gradingStore.NewProposalSQLiteStore(timedDB),
+		GradingCeremonyStore:     gradingStore.NewCeremonySQLiteStore(timedDB),
 		GradingNoteStore:         gradingStore.NewNoteSQLiteStore(timedDB),
+		ProposalCommentStore:     gradingStore.NewProposalCommentSQLiteStore(timedDB),
 		GradingMemberConfigStore: gradingStore.NewMemberConfigSQLiteStore(timedDB),
+		GradingRuleStore:         gradingStore.NewRuleSQLiteStore(timedDB),
 		MessageStore:             messageStore.NewSQLiteStore(timedDB),
 		ObservationStore:         observationStore.NewSQLiteStore(timedDB),
+		QuickCaptureStore:        quickCaptureStorePkg.NewSQLiteStore(timedDB),
 		MilestoneStore:           milestoneStore.NewSQLiteStore(timedDB),
 		MemberMilestoneStore:     milestoneStore.NewMemberMilestoneSQLiteStore(timedDB),
 		TrainingGoalStore:        trainingGoalStore.NewSQLiteStore(timedDB),
@@ -111,6 +175,8 @@ func main() {
 		ClipTagStore:             clipStorePkg.NewSQLiteTagStore(timedDB),
 		ClipComparisonStore:      clipStorePkg.NewSQLiteComparisonStore(timedDB),
 		EmailStore:               emailStorePkg.NewSQLiteStore(timedDB),
+		EmailPreferenceStore:     emailStorePkg.NewPreferenceSQLiteStore(timedDB),
+		EmailLibraryStore:        emailStorePkg.NewLibrarySQLiteStore(timedDB),
 		EstimatedHoursStore:      estimatedHoursStorePkg.NewSQLiteStore(timedDB),
 		RotorStore:               rotorStorePkg.NewSQLiteStore(timedDB),
 		CalendarEventStore:       calendarStorePkg.NewSQLiteStore(timedDB),
@@ -118,16 +184,56 @@ func main() {
 		BugBoxStore:              bugboxStorePkg.NewSQLiteStore(timedDB),
 		OutboxStore:              outboxStorePkg.NewSQLiteStore(timedDB),
 		PersonalGoalStore:        personalgoalStorePkg.NewSQLiteStore(timedDB),
+		GoalCheckInStore:         personalgoalStorePkg.NewCheckInSQLiteStore(timedDB),
+		GoalAnnotationStore:      personalgoalStorePkg.NewAnnotationSQLiteStore(timedDB),
 		DeletionRequestStore:     deletionStorePkg.NewSQLiteStore(timedDB),
 		AuditStore:               auditStorePkg.NewSQLiteStore(timedDB),
 		ConsentStore:             consentStorePkg.NewSQLiteStore(timedDB),
+		LocationStore:            locationStorePkg.NewSQLiteStore(timedDB),
+		NotificationStore:        notificationStorePkg.NewSQLiteStore(timedDB),
+		SessionLogStore:          sessionLogStorePkg.NewSQLiteStore(timedDB),
+		PermissionStore:          permissionStorePkg.NewSQLiteStore(timedDB),
+		AuthSessionStore:         authSessionStorePkg.NewSQLiteStore(timedDB),
+		KPISnapshotStore:         kpiStorePkg.NewSQLiteStore(timedDB),
+		RubricTemplateStore:      rubricStorePkg.NewTemplateSQLiteStore(timedDB),
+		RubricScoreStore:         rubricStorePkg.NewScoreSQLiteStore(timedDB),
+		BeltInventoryStore:       inventoryStorePkg.NewSQLiteStore(timedDB),
+		VisitorStore:             visitorStorePkg.NewSQLiteStore(timedDB),
+		ReengagementStore:        reengagementStorePkg.NewSQLiteStore(timedDB),
+		TimesheetStore:           timesheetStorePkg.NewSQLiteStore(timedDB),
+		ExportStore:              exportStorePkg.NewSQLiteStore(timedDB),
+		KioskDeviceStore:         kioskStorePkg.NewSQLiteStore(timedDB),
+		StatusChangeStore:        statusChangeStorePkg.NewSQLiteStore(timedDB),
+		AvailabilityStore:        availabilityStorePkg.NewSQLiteStore(timedDB),
+		MemberTagStore:           memberTagStorePkg.NewSQLiteStore(timedDB),
+		MemberMergeStore:         memberStore.NewMergeSQLiteStore(timedDB),
+		PerfBucketStore:          perfStatsStorePkg.NewSQLiteStore(db), // untimed: flushing timings should not add to them
+		ReferralStore:            referralStorePkg.NewSQLiteStore(timedDB),
+		JobStore:                 jobStorePkg.NewSQLiteStore(db), // untimed: the scheduler polls it every few seconds
+		AccountEmailChangeStore:  accountStore.NewEmailChangeSQLiteStore(timedDB),
+		ClassFeedbackStore:       feedbackStorePkg.NewSQLiteStore(timedDB),
+		CelebrationStore:         celebrationStorePkg.NewSQLiteStore(timedDB),
+		ContactCheckStore:        contactCheckStorePkg.NewSQLiteStore(timedDB),
+		ClassPackStore:           classPackStorePkg.NewSQLiteStore(timedDB),
+	}
+
+	// Full-text search: keep the index in step with saves, and rebuild it on startup so
+	// rows written before the index existed (or by other tools) are searchable.
+	searchIndex := searchStorePkg.NewSQLiteStore(timedDB)
+	stores.SearchStore = searchIndex
+	stores.MemberStore = searchStorePkg.IndexedMemberStore{Store: stores.MemberStore, Index: searchIndex}
+	stores.NoticeStore = searchStorePkg.IndexedNoticeStore{Store: stores.NoticeStore, Index: searchIndex}
+	stores.ClipStore = searchStorePkg.IndexedClipStore{Store: stores.ClipStore, Index: searchIndex}
+	stores.MessageStore = searchStorePkg.IndexedMessageStore{Store: stores.MessageStore, Index: searchIndex}
+	stores.RotorStore = searchStorePkg.IndexedRotorStore{Store: stores.RotorStore, Index: searchIndex}
+	if err := searchIndex.Rebuild(context.Background()); err != nil {
+		log.Printf("WARNING: search index rebuild failed: %v", err)
 	}
 
 	// Seed default admin account if no accounts exist
-	adminEmail := envOrDefault("WORKSHOP_ADMIN_EMAIL", "info@workshopjiujitsu.co.nz")
-	adminPassword := envOrDefault("WORKSHOP_ADMIN_PASSWORD", "Umami monster")
+	adminEmail := appConfig.AdminEmail
 	seedDeps := orchestrators.CreateAccountDeps{AccountStore: acctStore}
-	if err := orchestrators.ExecuteSeedAdmin(context.Background(), seedDeps, adminEmail, adminPassword); err != nil {
+	if err := orchestrators.ExecuteSeedAdmin(context.Background(), seedDeps, adminEmail, appConfig.AdminPassword); err != nil {
 		log.Fatalf("failed to seed admin: %v", err)
 	}
 
@@ -143,6 +249,12 @@ func main() {
 		log.Fatalf("failed to seed competitions: %v", err)
 	}
 
+	// Seed the clip library taxonomy (positions, technique types, gi/nogi)
+	seedTaxonomyDeps := orchestrators.SeedClipTaxonomyDeps{TagStore: stores.ClipTagStore}
+	if err := orchestrators.ExecuteSeedClipTaxonomy(context.Background(), seedTaxonomyDeps); err != nil {
+		log.Fatalf("failed to seed clip taxonomy: %v", err)
+	}
+
 	// Seed test accounts for each role (all environments, idempotent)
 	testAcctDeps := orchestrators.TestAccountSeedDeps{
 		AccountStore: acctStore,
@@ -153,7 +265,7 @@ func main() {
 	}
 
 	// Seed synthetic data for development only
-	if os.Getenv("WORKSHOP_ENV") != "production" {
+	if !appConfig.IsProduction() {
 		adminAcct, err := acctStore.GetByEmail(context.Background(), adminEmail)
 		if err != nil {
 			log.Fatalf("failed to get admin account for seeding: %v", err)
@@ -186,42 +298,309 @@ func main() {
 	}
 
 	// Configure email sender
-	resendKey := os.Getenv("WORKSHOP_RESEND_KEY")
-	emailFrom := envOrDefault("WORKSHOP_RESEND_FROM", "Workshop Jiu Jitsu <noreply@workshopjiujitsu.co.nz>")
-	emailReply := envOrDefault("WORKSHOP_REPLY_TO", "info@workshopjiujitsu.co.nz")
+	resendKey := appConfig.Email.ResendKey
+	emailFrom := appConfig.Email.From
+	emailReply := appConfig.Email.ReplyTo
+	var sender emailPkg.Sender
 	if resendKey != "" {
-		web.SetEmailSender(emailPkg.NewResendSender(resendKey, emailFrom), emailFrom, emailReply)
+		sender = emailPkg.NewResendSender(resendKey, emailFrom)
+		web.SetEmailSender(sender, emailFrom, emailReply)
 		log.Println("Email sender configured (Resend)")
 	} else {
-		web.SetEmailSender(emailPkg.NewNoopSender(), emailFrom, emailReply)
-		if os.Getenv("WORKSHOP_ENV") == "production" {
-			log.Println("WARNING: WORKSHOP_RESEND_KEY is not set — email delivery is DISABLED in production")
-		} else {
-			log.Println("Email sender configured (noop — set WORKSHOP_RESEND_KEY for real delivery)")
+		sender = emailPkg.NewNoopSender()
+		web.SetEmailSender(sender, emailFrom, emailReply)
+		log.Println("Email sender configured (noop — set WORKSHOP_RESEND_KEY for real delivery)")
+	}
+
+	// Resend delivery webhooks (open/click/bounce tracking) need the endpoint signing secret
+	if secret := appConfig.Email.WebhookSecret; secret != "" {
+		web.SetResendWebhookSecret(secret)
+		log.Println("Resend webhooks enabled at /api/webhooks/resend")
+	}
+
+	// Web Push needs a VAPID key; without one members only get in-app and email notifications
+	if key := appConfig.Push.VAPIDKey; key != nil {
+		pushSender, err := webpush.NewVAPIDSender(key, appConfig.Push.Subject)
+		if err != nil {
+			log.Fatalf("Failed to configure push: %v", err)
+		}
+		web.SetPushSender(pushSender, pushSender.PublicKey())
+		log.Println("Push notifications enabled (Web Push)")
+	}
+
+	// Single sign-on sits beside password login; without a client ID the login page offers passwords only
+	if appConfig.OIDC.Enabled() {
+		provider, err := oidc.NewProvider(oidc.Config{
+			Issuer:       appConfig.OIDC.Issuer,
+			ClientID:     appConfig.OIDC.ClientID,
+			ClientSecret: appConfig.OIDC.ClientSecret,
+			RedirectURL:  appConfig.OIDC.RedirectURL,
+			Name:         appConfig.OIDC.Name,
+		})
+		if err != nil {
+			log.Fatalf("Failed to configure single sign-on: %v", err)
+		}
+		web.SetSSOProvider(provider)
+		log.Printf("Single sign-on enabled (%s)", appConfig.OIDC.Name)
+	}
+
+	// Background jobs run on cron schedules stored in the database (/admin/jobs) and report
+	// run health to the monitor (GET /api/admin/workers)
+	workerMonitor := orchestrators.NewWorkerMonitor(time.Now)
+	web.SetWorkerMonitor(workerMonitor)
+	jobScheduler := orchestrators.NewJobScheduler(stores.JobStore, workerMonitor, time.Now)
+	web.SetJobScheduler(jobScheduler)
+	workersStopCh := make(chan struct{})
+	registerJob := func(def orchestrators.JobDefinition) {
+		if err := jobScheduler.Register(def); err != nil {
+			log.Fatalf("Failed to register job %s: %v", def.Name, err)
+		}
+	}
+
+	// Outbox worker retries failed external integrations
+	outboxProcessor := orchestrators.NewOutboxProcessor(stores.OutboxStore, web.OutboxExecutors(stores, appConfig.Email.PublicURL))
+	registerJob(orchestrators.JobDefinition{Name: "outbox", Description: "Retries failed external integrations", DefaultSchedule: "* * * * *", Timeout: 5 * time.Minute, Run: outboxProcessor.ProcessPending})
+
+	// Export expiry worker deletes member data exports once their download window closes
+	registerJob(orchestrators.JobDefinition{Name: "export_expiry", Description: "Deletes member data exports once their download window closes", DefaultSchedule: "@hourly", Timeout: 5 * time.Minute, Run: func(ctx context.Context) error {
+		_, err := orchestrators.ExecuteExpireMemberExports(ctx, web.ExpireMemberExportsDeps(stores, time.Now))
+		return err
+	}})
+
+	// Scheduled email worker sends emails whose scheduled time has arrived
+	registerJob(orchestrators.JobDefinition{Name: "scheduled_emails", Description: "Sends emails whose scheduled time has arrived", DefaultSchedule: "* * * * *", Timeout: 5 * time.Minute, Run: func(ctx context.Context) error {
+		_, err := orchestrators.ExecuteDispatchScheduledEmails(ctx, orchestrators.DispatchScheduledEmailsDeps{
+			EmailStore:      stores.EmailStore,
+			EmailSender:     sender,
+			Now:             time.Now,
+			FromAddress:     emailFrom,
+			ReplyTo:         emailReply,
+			PreferenceStore: stores.EmailPreferenceStore,
+			UnsubscribeURL:  web.UnsubscribeLinks(appConfig.Email.PublicURL, appConfig.Email.UnsubscribeKey),
+		})
+		return err
+	}})
+
+	// Re-engagement worker emails and flags coach calls for members who have stopped training
+	registerJob(orchestrators.JobDefinition{Name: "reengagement", Description: "Emails members who have stopped training and flags coach calls", DefaultSchedule: "0 9 * * *", Timeout: 10 * time.Minute, Run: func(ctx context.Context) error {
+		_, err := orchestrators.ExecuteRunReengagement(ctx, web.ReengagementDeps(stores, appConfig.Email.PublicURL, appConfig.Email.UnsubscribeKey, time.Now))
+		return err
+	}})
+
+	// Weekly digest worker emails opted-in members a summary of last week's training on Monday morning
+	registerJob(orchestrators.JobDefinition{Name: "weekly_digests", Description: "Sends last week's training digests once Monday's send hour has passed", DefaultSchedule: "@hourly", Timeout: 10 * time.Minute, Run: func(ctx context.Context) error {
+		_, err := orchestrators.ExecuteSendWeeklyDigests(ctx, web.WeeklyDigestDeps(stores, appConfig.Email.PublicURL, appConfig.Email.UnsubscribeKey, time.Now))
+		return err
+	}})
+
+	// Term report worker emails kids' progress reports to their guardians once a term ends; off until an admin enables it
+	registerJob(orchestrators.JobDefinition{Name: "term_reports", Description: "Emails kids' term progress reports to their guardians after the term ends", DefaultSchedule: "0 10 * * *", Timeout: 10 * time.Minute, DefaultDisabled: true, Run: func(ctx context.Context) error {
+		_, err := orchestrators.ExecuteSendDueTermReports(ctx, web.TermReportsDeps(stores, time.Now))
+		return err
+	}})
+
+	// Celebration workers post a notice and email members on birthdays, training anniversaries and class milestones; off until an admin enables them
+	registerJob(orchestrators.JobDefinition{Name: "celebration_notices", Description: "Publishes a notice naming today's birthdays, anniversaries and class milestones", DefaultSchedule: "0 7 * * *", Timeout: 5 * time.Minute, DefaultDisabled: true, Run: func(ctx context.Context) error {
+		_, _, err := orchestrators.ExecutePublishCelebrationNotice(ctx, web.CelebrationNoticeDeps(stores, time.Now))
+		return err
+	}})
+	registerJob(orchestrators.JobDefinition{Name: "celebration_emails", Description: "Emails members on their birthday, training anniversary or class milestone", DefaultSchedule: "0 8 * * *", Timeout: 10 * time.Minute, DefaultDisabled: true, Run: func(ctx context.Context) error {
+		_, err := orchestrators.ExecuteSendCelebrationEmails(ctx, web.CelebrationEmailDeps(stores, appConfig.Email.PublicURL, appConfig.Email.UnsubscribeKey, time.Now))
+		return err
+	}})
+
+	// Class reminder worker pushes members a heads-up an hour before their usual classes
+	registerJob(orchestrators.JobDefinition{Name: "class_reminders", Description: "Pushes reminders an hour before members' usual classes", DefaultSchedule: "*/5 * * * *", Timeout: 2 * time.Minute, Run: func(ctx context.Context) error {
+		_, err := orchestrators.ExecuteSendClassReminders(ctx, web.ClassRemindersDeps(stores, time.Now))
+		return err
+	}})
+
+	// Capacity alert worker warns the coach and admins when a class goes over its mat capacity
+	registerJob(orchestrators.JobDefinition{Name: "capacity_alerts", Description: "Warns the coach and admins when a class goes over its mat capacity", DefaultSchedule: "* * * * *", Timeout: 30 * time.Second, Run: func(ctx context.Context) error {
+		_, err := orchestrators.ExecuteSendCapacityAlerts(ctx, web.CapacityAlertsDeps(stores, time.Now))
+		return err
+	}})
+
+	// Status change worker applies scheduled suspensions, reinstatements, freezes and unfreezes on their effective date
+	registerJob(orchestrators.JobDefinition{Name: "status_changes", Description: "Applies scheduled suspensions, reinstatements, freezes and unfreezes", DefaultSchedule: "@hourly", Timeout: 5 * time.Minute, Run: func(ctx context.Context) error {
+		_, err := orchestrators.ExecuteApplyDueStatusChanges(ctx, web.StatusChangeDeps(stores, time.Now))
+		return err
+	}})
+
+	// Rotor worker moves auto-mode rotors on to the next topic once the current one's weeks are up
+	registerJob(orchestrators.JobDefinition{Name: "rotor_advance", Description: "Moves auto-mode rotors on to their next topic", DefaultSchedule: "@hourly", Timeout: 5 * time.Minute, Run: func(ctx context.Context) error {
+		_, err := orchestrators.ExecuteAutoAdvanceRotors(ctx, orchestrators.AutoAdvanceRotorsDeps{
+			ClassTypeStore: stores.ClassTypeStore,
+			RotorStore:     stores.RotorStore,
+			NoticeStore:    stores.NoticeStore,
+			GenerateID:     uuid.NewString,
+			Now:            time.Now,
+		})
+		return err
+	}})
+
+	// Personal goals worker refreshes attendance-tracked progress and closes goals that reached their target
+	registerJob(orchestrators.JobDefinition{Name: "personal_goals", Description: "Refreshes attendance-tracked goals and closes reached ones", DefaultSchedule: "@hourly", Timeout: 5 * time.Minute, Run: func(ctx context.Context) error {
+		_, err := orchestrators.ExecuteCloseReachedGoals(ctx, orchestrators.CloseReachedGoalsDeps{
+			GoalStore:       stores.PersonalGoalStore,
+			AttendanceStore: stores.AttendanceStore,
+			Now:             time.Now,
+		})
+		return err
+	}})
+
+	// Perf workers persist each finished minute of request and query timings for /admin/perf,
+	// and drop history older than WORKSHOP_PERF_RETENTION_DAYS
+	registerJob(orchestrators.JobDefinition{Name: "perf_flush", Description: "Saves the last minute of request and query timings", DefaultSchedule: "* * * * *", Timeout: 30 * time.Second, Run: func(ctx context.Context) error {
+		return stores.PerfBucketStore.SaveBuckets(ctx, collector.TakeBuckets(time.Now()))
+	}})
+	registerJob(orchestrators.JobDefinition{Name: "perf_prune", Description: "Drops perf timings older than the retention window", DefaultSchedule: "@hourly", Timeout: 5 * time.Minute, Run: func(ctx context.Context) error {
+		pruned, err := stores.PerfBucketStore.DeleteBefore(ctx, time.Now().Add(-appConfig.PerfRetention))
+		if pruned > 0 {
+			log.Printf("Pruned %d perf timing buckets", pruned)
+		}
+		return err
+	}})
+
+	// Session worker deletes expired logins (active sessions are checked on every request anyway)
+	registerJob(orchestrators.JobDefinition{Name: "session_prune", Description: "Deletes expired logins", DefaultSchedule: "@hourly", Timeout: 5 * time.Minute, Run: func(ctx context.Context) error {
+		pruned, err := stores.AuthSessionStore.DeleteExpired(ctx, time.Now())
+		if pruned > 0 {
+			log.Printf("Pruned %d expired sessions", pruned)
+		}
+		return err
+	}})
+
+	// Notice worker archives published notices past their end date so the published list stays small
+	registerJob(orchestrators.JobDefinition{Name: "notice_cleanup", Description: "Archives notices past their end date", DefaultSchedule: "@hourly", Timeout: 5 * time.Minute, Run: func(ctx context.Context) error {
+		archived, err := stores.NoticeStore.ArchiveExpired(ctx, time.Now())
+		if len(archived) > 0 {
+			log.Printf("Archived %d expired notices", len(archived))
+		}
+		return err
+	}})
+
+	// KPI worker keeps today's dashboard snapshot current; the day's last run becomes its record
+	registerJob(orchestrators.JobDefinition{Name: "kpi_snapshots", Description: "Saves today's dashboard KPI snapshot", DefaultSchedule: "55 * * * *", Timeout: 5 * time.Minute, Run: func(ctx context.Context) error {
+		snapshot, err := projections.QueryGetAdminStats(ctx, projections.GetAdminStatsQuery{Date: time.Now().Format("2006-01-02")}, projections.GetAdminStatsDeps{
+			MemberStore:     stores.MemberStore,
+			AttendanceStore: stores.AttendanceStore,
+			SnapshotStore:   stores.KPISnapshotStore,
+			Now:             time.Now,
+		})
+		if err != nil {
+			return err
+		}
+		return stores.KPISnapshotStore.Save(ctx, snapshot)
+	}})
+
+	// Database backups to a local directory (default ./backups) or an S3-compatible bucket
+	if target, err := newBackupTarget(appConfig.Backup); err != nil {
+		log.Printf("WARNING: backups disabled: %v", err)
+	} else {
+		backupDeps := orchestrators.BackupDeps{
+			Database:     storage.NewBackupDB(db),
+			Target:       target,
+			Retention:    appConfig.Backup.Retention,
+			LatestSchema: storage.LatestSchemaVersion(),
+			Now:          time.Now,
 		}
+		backupInterval := appConfig.Backup.Interval
+		web.SetBackups(backupDeps, backupInterval)
+		backupSchedule := "@every " + backupInterval.String()
+		registerJob(orchestrators.JobDefinition{Name: "backups", Description: "Backs up the database", DefaultSchedule: backupSchedule, Timeout: 30 * time.Minute, Run: func(ctx context.Context) error {
+			_, err := orchestrators.ExecuteCreateBackup(ctx, orchestrators.CreateBackupInput{Trigger: backupDomain.TriggerScheduled}, backupDeps)
+			return err
+		}})
+		log.Printf("Backups to %s, by default every %s (see /admin/jobs)", target.Describe(), backupInterval)
 	}
 
-	// Start outbox background worker for retrying failed external integrations
-	outboxStopCh := make(chan struct{})
-	outboxProcessor := orchestrators.NewOutboxProcessor(stores.OutboxStore, nil) // Executors wired later
-	orchestrators.StartBackgroundWorker(outboxProcessor, 1*time.Minute, outboxStopCh)
-	defer close(outboxStopCh)
+	// Check for due jobs every few seconds; each job's stored schedule decides when it runs
+	if err := jobScheduler.Start(workersStopCh, 15*time.Second); err != nil {
+		log.Fatalf("Failed to start job scheduler: %v", err)
+	}
+
+	// Brute-force limits on /login, /api/activate and /change-password
+	web.AuthLimitConfig = appConfig.AuthLimit
 
 	// Create HTTP handler with middleware (pass collector for timing + dashboard)
-	mux := web.NewMux("static", stores, collector)
+	mux := web.NewMux(staticFiles(appConfig), stores, collector)
+
+	// Start server; SIGINT/SIGTERM begin a graceful shutdown
+	srv := newHTTPServer(appConfig.Addr, appConfig.Server, mux)
+	log.Printf("Workshop %s starting on %s (env=%s, schema=%d)", version, appConfig.Addr, appConfig.Env, storage.LatestSchemaVersion())
 
-	// Start server
-	addr := envOrDefault("WORKSHOP_ADDR", ":8080")
-	log.Printf("Workshop %s starting on %s (env=%s, schema=%d)", version, addr, envOrDefault("WORKSHOP_ENV", "development"), storage.LatestSchemaVersion())
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.ListenAndServe() }()
 
-	if err := http.ListenAndServe(addr, mux); err != nil {
+	select {
+	case err := <-serveErr:
 		log.Fatalf("Server failed: %v", err)
+	case <-signalCtx.Done():
+	}
+	stopSignals() // a second signal kills the process immediately
+
+	// Drain in-flight requests, then let workers finish their current run, all within one deadline
+	shutdownTimeout := appConfig.Server.ShutdownTimeout
+	log.Printf("Shutting down (waiting up to %s for requests and workers)", shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("WARNING: HTTP shutdown incomplete: %v", err)
+	}
+	close(workersStopCh)
+	if err := workerMonitor.Wait(shutdownCtx); err != nil {
+		log.Printf("WARNING: background workers still running at shutdown: %v", err)
+	}
+	// Keep the minute in progress too, so a restart leaves no gap in the timing history
+	if err := stores.PerfBucketStore.SaveBuckets(shutdownCtx, collector.TakeBuckets(time.Now().Add(time.Minute))); err != nil {
+		log.Printf("WARNING: perf timings not saved at shutdown: %v", err)
+	}
+	log.Println("Shutdown complete")
+}
+
+// staticFiles returns the embedded static directory. A development server started from the
+// repository root serves static/ from disk instead so edits show up without a rebuild.
+func staticFiles(appConfig config.Config) fs.FS {
+	if !appConfig.IsProduction() {
+		if info, err := os.Stat("static"); err == nil && info.IsDir() {
+			return os.DirFS("static")
+		}
+	}
+	embedded, err := fs.Sub(workshop.StaticFiles, "static")
+	if err != nil {
+		log.Fatalf("embedded static files: %v", err)
+	}
+	return embedded
+}
+
+// newHTTPServer builds the HTTP server with the configured timeouts.
+// Write timeout bounds whole responses, so it must cover the slowest export or backup download.
+func newHTTPServer(addr string, limits config.Server, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: limits.ReadHeaderTimeout,
+		ReadTimeout:       limits.ReadTimeout,
+		WriteTimeout:      limits.WriteTimeout,
+		IdleTimeout:       limits.IdleTimeout,
+		MaxHeaderBytes:    limits.MaxHeaderBytes,
 	}
 }
 
-func envOrDefault(key, fallback string) string {
-	if v := os.Getenv(key); v != "" {
-		return v
+// newBackupTarget returns the S3 target when a bucket is configured, else a local directory.
+func newBackupTarget(backup config.Backup) (backupPkg.Target, error) {
+	if backup.S3.Bucket != "" {
+		return backupPkg.NewS3Target(backupPkg.S3Config{
+			Endpoint:  backup.S3.Endpoint,
+			Region:    backup.S3.Region,
+			Bucket:    backup.S3.Bucket,
+			Prefix:    backup.S3.Prefix,
+			AccessKey: backup.S3.AccessKey,
+			SecretKey: backup.S3.SecretKey,
+		})
 	}
-	return fallback
+	return backupPkg.NewDirTarget(backup.Dir)
 }
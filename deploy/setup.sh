@@ -107,8 +107,6 @@ if ! id -u workshop &>/dev/null; then
 fi
 
 echo "=== 9. Create application directory ==="
-mkdir -p /opt/workshop/internal/adapters/http/templates
-mkdir -p /opt/workshop/static
 mkdir -p /opt/workshop/backups
 mkdir -p /var/log/caddy
 chown -R workshop:workshop /opt/workshop
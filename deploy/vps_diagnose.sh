@@ -58,7 +58,7 @@ echo "5. Checking environment configuration..."
 if [ -f /opt/workshop/.env ]; then
     echo "   ✓ .env file exists"
     # Check for required vars (don't show values)
-    for var in WORKSHOP_ENV WORKSHOP_CSRF_KEY WORKSHOP_RESEND_KEY; do
+    for var in WORKSHOP_ENV WORKSHOP_CSRF_KEY WORKSHOP_QR_KEY WORKSHOP_UNSUBSCRIBE_KEY WORKSHOP_RESEND_KEY; do
         if grep -q "$var=" /opt/workshop/.env; then
             echo "   ✓ $var is set"
         else
@@ -0,0 +1,109 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	domain "workshop/internal/domain/backup"
+)
+
+// DirTarget stores backups as files in a local directory.
+type DirTarget struct {
+	dir string
+}
+
+// NewDirTarget creates the directory if needed and returns a target for it.
+// PRE: dir is a writable path
+// POST: dir exists with owner-only permissions
+func NewDirTarget(dir string) (*DirTarget, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create backup dir: %w", err)
+	}
+	return &DirTarget{dir: dir}, nil
+}
+
+// Put writes to a temporary file and renames it, so a crash never leaves a partial backup.
+// PRE: name passed domain.ParseName
+// POST: The file dir/name holds the full contents of r
+func (t *DirTarget) Put(ctx context.Context, name string, r io.Reader, size int64) error {
+	upload, err := os.CreateTemp(t.dir, ".upload-*")
+	if err != nil {
+		return fmt.Errorf("put %s: %w", name, err)
+	}
+	defer os.Remove(upload.Name())
+	if _, err := io.Copy(upload, r); err != nil {
+		upload.Close()
+		return fmt.Errorf("put %s: %w", name, err)
+	}
+	if err := upload.Sync(); err != nil {
+		upload.Close()
+		return fmt.Errorf("put %s: %w", name, err)
+	}
+	if err := upload.Close(); err != nil {
+		return fmt.Errorf("put %s: %w", name, err)
+	}
+	if err := os.Rename(upload.Name(), filepath.Join(t.dir, name)); err != nil {
+		return fmt.Errorf("put %s: %w", name, err)
+	}
+	return nil
+}
+
+// Get copies the named file into w.
+// PRE: name passed domain.ParseName
+// POST: w has received the whole file, or an error is returned
+func (t *DirTarget) Get(ctx context.Context, name string, w io.Writer) error {
+	f, err := os.Open(filepath.Join(t.dir, name))
+	if err != nil {
+		return fmt.Errorf("get %s: %w", name, err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(w, f); err != nil {
+		return fmt.Errorf("get %s: %w", name, err)
+	}
+	return nil
+}
+
+// List returns the backups in the directory.
+// PRE: none
+// POST: Returns one entry per file whose name parses as a backup
+func (t *DirTarget) List(ctx context.Context) ([]domain.Backup, error) {
+	entries, err := os.ReadDir(t.dir)
+	if err != nil {
+		return nil, fmt.Errorf("list backups: %w", err)
+	}
+	var backups []domain.Backup
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		b, err := domain.ParseName(e.Name())
+		if err != nil {
+			continue
+		}
+		if info, err := e.Info(); err == nil {
+			b.Size = info.Size()
+		}
+		backups = append(backups, b)
+	}
+	return backups, nil
+}
+
+// Delete removes the named file.
+// PRE: name passed domain.ParseName
+// POST: dir/name no longer exists
+func (t *DirTarget) Delete(ctx context.Context, name string) error {
+	if err := os.Remove(filepath.Join(t.dir, name)); err != nil {
+		return fmt.Errorf("delete %s: %w", name, err)
+	}
+	return nil
+}
+
+// Describe returns the directory path.
+// PRE: none
+// POST: Returns "dir:" followed by the path
+func (t *DirTarget) Describe() string {
+	return "dir:" + t.dir
+}
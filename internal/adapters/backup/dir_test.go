@@ -0,0 +1,54 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	domain "workshop/internal/domain/backup"
+)
+
+// TestDirTarget_RoundTrip verifies put, list, get and delete against a directory.
+func TestDirTarget_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	target, err := NewDirTarget(dir)
+	if err != nil {
+		t.Fatalf("NewDirTarget: %v", err)
+	}
+	name := domain.NewName(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), domain.TriggerManual)
+	if err := target.Put(ctx, name, strings.NewReader("snapshot"), 8); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	// Files that are not backups are ignored
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	list, err := target.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != name || list[0].Size != 8 {
+		t.Fatalf("List = %+v, want one 8-byte %s", list, name)
+	}
+
+	var buf bytes.Buffer
+	if err := target.Get(ctx, name, &buf); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if buf.String() != "snapshot" {
+		t.Errorf("Get = %q, want snapshot", buf.String())
+	}
+
+	if err := target.Delete(ctx, name); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if list, _ := target.List(ctx); len(list) != 0 {
+		t.Errorf("List after delete = %+v, want empty", list)
+	}
+}
@@ -0,0 +1,273 @@
+package backup
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	domain "workshop/internal/domain/backup"
+)
+
+// S3Config identifies an S3-compatible bucket (AWS S3, Backblaze B2, Cloudflare R2, MinIO).
+type S3Config struct {
+	Endpoint  string // e.g. "https://s3.ap-southeast-2.amazonaws.com"
+	Region    string // e.g. "ap-southeast-2"; "auto" for R2
+	Bucket    string
+	Prefix    string // optional key prefix, e.g. "workshop/"
+	AccessKey string
+	SecretKey string
+}
+
+// S3Target stores backups as objects in an S3-compatible bucket.
+// Requests use path-style URLs and AWS Signature Version 4, so no SDK is needed.
+type S3Target struct {
+	config S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// emptyPayloadHash is the SHA-256 of an empty body.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// NewS3Target returns a target for the configured bucket.
+// PRE: config has Endpoint, Region, Bucket, AccessKey and SecretKey set
+// POST: Returns a target; no request is made until first use
+func NewS3Target(config S3Config) (*S3Target, error) {
+	if config.Endpoint == "" || config.Region == "" || config.Bucket == "" || config.AccessKey == "" || config.SecretKey == "" {
+		return nil, fmt.Errorf("s3 backup target needs endpoint, region, bucket, access key and secret key")
+	}
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	return &S3Target{config: config, client: &http.Client{Timeout: 10 * time.Minute}, now: time.Now}, nil
+}
+
+// Put uploads the backup with a single PUT.
+// PRE: name passed domain.ParseName; size is the exact length of r
+// POST: The object Prefix+name holds the contents of r
+func (t *S3Target) Put(ctx context.Context, name string, r io.Reader, size int64) error {
+	req, err := t.newRequest(ctx, "PUT", t.config.Prefix+name, nil, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	resp, err := t.do(req, "UNSIGNED-PAYLOAD")
+	if err != nil {
+		return fmt.Errorf("put %s: %w", name, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Get downloads the backup into w.
+// PRE: name passed domain.ParseName
+// POST: w has received the whole object, or an error is returned
+func (t *S3Target) Get(ctx context.Context, name string, w io.Writer) error {
+	req, err := t.newRequest(ctx, "GET", t.config.Prefix+name, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := t.do(req, emptyPayloadHash)
+	if err != nil {
+		return fmt.Errorf("get %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("get %s: %w", name, err)
+	}
+	return nil
+}
+
+// listBucketResult is the subset of the ListObjectsV2 response we use.
+type listBucketResult struct {
+	Contents []struct {
+		Key  string `xml:"Key"`
+		Size int64  `xml:"Size"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List pages through ListObjectsV2 under the configured prefix.
+// PRE: none
+// POST: Returns one entry per object whose key (minus Prefix) parses as a backup
+func (t *S3Target) List(ctx context.Context) ([]domain.Backup, error) {
+	var backups []domain.Backup
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {t.config.Prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		req, err := t.newRequest(ctx, "GET", "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := t.do(req, emptyPayloadHash)
+		if err != nil {
+			return nil, fmt.Errorf("list backups: %w", err)
+		}
+		var page listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("list backups: decode: %w", err)
+		}
+		for _, obj := range page.Contents {
+			b, err := domain.ParseName(strings.TrimPrefix(obj.Key, t.config.Prefix))
+			if err != nil {
+				continue
+			}
+			b.Size = obj.Size
+			backups = append(backups, b)
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return backups, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// Delete removes the backup object.
+// PRE: name passed domain.ParseName
+// POST: The object Prefix+name no longer exists
+func (t *S3Target) Delete(ctx context.Context, name string) error {
+	req, err := t.newRequest(ctx, "DELETE", t.config.Prefix+name, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := t.do(req, emptyPayloadHash)
+	if err != nil {
+		return fmt.Errorf("delete %s: %w", name, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Describe returns the bucket location (never the credentials).
+// PRE: none
+// POST: Returns "s3:" followed by endpoint, bucket and prefix
+func (t *S3Target) Describe() string {
+	return "s3:" + t.config.Endpoint + "/" + t.config.Bucket + "/" + t.config.Prefix
+}
+
+// newRequest builds a path-style request for key (empty key addresses the bucket itself).
+func (t *S3Target) newRequest(ctx context.Context, method, key string, query url.Values, body io.Reader) (*http.Request, error) {
+	path := "/" + t.config.Bucket
+	if key != "" {
+		path += "/" + key
+	}
+	u, err := url.Parse(t.config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("s3 endpoint: %w", err)
+	}
+	u.RawPath = uriEncode(path, false)
+	u.Path = path
+	u.RawQuery = canonicalQuery(query)
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
+}
+
+// do signs and sends the request, turning non-2xx responses into errors.
+func (t *S3Target) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	t.sign(req, payloadHash)
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+// sign adds AWS Signature Version 4 headers to req.
+func (t *S3Target) sign(req *http.Request, payloadHash string) {
+	now := t.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + t.config.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+	signature := hex.EncodeToString(hmacSHA256(signingKey(t.config.SecretKey, day, t.config.Region, "s3"), stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+t.config.AccessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// signingKey derives the SigV4 key for a day, region and service.
+func signingKey(secret, day, region, service string) []byte {
+	k := hmacSHA256([]byte("AWS4"+secret), day)
+	k = hmacSHA256(k, region)
+	k = hmacSHA256(k, service)
+	return hmacSHA256(k, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalQuery encodes query parameters sorted by key, as SigV4 requires.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		for _, v := range query[k] {
+			parts = append(parts, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes everything except unreserved characters (and '/' unless encodeSlash).
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
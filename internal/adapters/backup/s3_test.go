@@ -0,0 +1,130 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	domain "workshop/internal/domain/backup"
+)
+
+// TestSigningKey checks key derivation against the AWS SigV4 documentation example.
+func TestSigningKey(t *testing.T) {
+	got := hex.EncodeToString(signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam"))
+	want := "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d"
+	if got != want {
+		t.Errorf("signingKey = %s, want %s", got, want)
+	}
+}
+
+// fakeS3 is a minimal path-style bucket that requires signed requests.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+// ServeHTTP handles ListObjectsV2 and object PUT/GET/DELETE.
+// PRE: none
+// POST: Unsigned requests get 403
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/20260301/test-1/s3/aws4_request") || r.Header.Get("x-amz-date") != "20260301T090000Z" {
+		http.Error(w, "unsigned", http.StatusForbidden)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.URL.Path == "/bucket" && r.URL.Query().Get("list-type") == "2" {
+		prefix := r.URL.Query().Get("prefix")
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		fmt.Fprint(w, `<ListBucketResult><IsTruncated>false</IsTruncated>`)
+		for _, k := range keys {
+			fmt.Fprintf(w, `<Contents><Key>%s</Key><Size>%d</Size></Contents>`, k, len(f.objects[k]))
+		}
+		fmt.Fprint(w, `</ListBucketResult>`)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+	switch r.Method {
+	case "PUT":
+		body, _ := io.ReadAll(r.Body)
+		f.objects[key] = body
+	case "GET":
+		body, ok := f.objects[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(body)
+	case "DELETE":
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// TestS3Target_RoundTrip verifies put, list, get and delete against a fake bucket.
+func TestS3Target_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeS3{objects: map[string][]byte{"workshop/unrelated.txt": []byte("x")}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	target, err := NewS3Target(S3Config{Endpoint: srv.URL, Region: "test-1", Bucket: "bucket", Prefix: "workshop/", AccessKey: "AKID", SecretKey: "secret"})
+	if err != nil {
+		t.Fatalf("NewS3Target: %v", err)
+	}
+	target.now = func() time.Time { return time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC) }
+
+	name := domain.NewName(target.now(), domain.TriggerScheduled)
+	if err := target.Put(ctx, name, strings.NewReader("snapshot"), 8); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if _, ok := fake.objects["workshop/"+name]; !ok {
+		t.Fatalf("object not stored under prefix; have %v", fake.objects)
+	}
+
+	list, err := target.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != name || list[0].Size != 8 {
+		t.Fatalf("List = %+v, want one 8-byte %s", list, name)
+	}
+
+	var buf bytes.Buffer
+	if err := target.Get(ctx, name, &buf); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if buf.String() != "snapshot" {
+		t.Errorf("Get = %q, want snapshot", buf.String())
+	}
+
+	if err := target.Delete(ctx, name); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := target.Get(ctx, name, &buf); err == nil {
+		t.Error("expected error getting deleted backup")
+	}
+}
+
+// TestNewS3Target_RequiresConfig verifies missing settings are rejected.
+func TestNewS3Target_RequiresConfig(t *testing.T) {
+	if _, err := NewS3Target(S3Config{Endpoint: "https://s3.example.com", Bucket: "b"}); err == nil {
+		t.Error("expected error for incomplete config")
+	}
+}
@@ -0,0 +1,23 @@
+package backup
+
+import (
+	"context"
+	"io"
+
+	domain "workshop/internal/domain/backup"
+)
+
+// Target is where database backups are stored.
+// Names are always validated with domain.ParseName before reaching a Target.
+type Target interface {
+	// Put stores the contents of r under name, replacing any existing object.
+	Put(ctx context.Context, name string, r io.Reader, size int64) error
+	// Get streams the named backup into w.
+	Get(ctx context.Context, name string, w io.Writer) error
+	// List returns every backup in the target; unrecognised objects are skipped.
+	List(ctx context.Context) ([]domain.Backup, error)
+	// Delete removes the named backup.
+	Delete(ctx context.Context, name string) error
+	// Describe returns a human-readable location for logs and the admin page.
+	Describe() string
+}
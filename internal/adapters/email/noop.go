@@ -20,7 +20,7 @@ func NewNoopSender() *NoopSender {
 // PRE: req is a valid SendRequest
 // POST: Returns a noop result without actual delivery
 func (s *NoopSender) Send(_ context.Context, req SendRequest) (SendResult, error) {
-	slog.Info("noop_email_send", "to", req.To, "subject", req.Subject)
+	slog.Info("noop_email_send", "to", req.To, "subject", req.Subject, "attachments", len(req.Attachments))
 	return SendResult{
 		MessageID: fmt.Sprintf("noop-%d", time.Now().UnixNano()),
 		SentAt:    time.Now(),
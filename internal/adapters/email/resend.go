@@ -35,10 +35,11 @@ func (s *ResendSender) Send(ctx context.Context, req SendRequest) (SendResult, e
 	}
 
 	params := &resend.SendEmailRequest{
-		From:    from,
-		To:      req.To,
-		Subject: req.Subject,
-		Html:    req.HTML,
+		From:        from,
+		To:          req.To,
+		Subject:     req.Subject,
+		Html:        req.HTML,
+		Attachments: resendAttachments(req.Attachments),
 	}
 	if req.ReplyTo != "" {
 		params.ReplyTo = req.ReplyTo
@@ -46,11 +47,11 @@ func (s *ResendSender) Send(ctx context.Context, req SendRequest) (SendResult, e
 
 	sent, err := s.client.Emails.SendWithContext(ctx, params)
 	if err != nil {
-		slog.Error("resend_send_failed", "error", err, "to", req.To, "subject", req.Subject)
+		slog.ErrorContext(ctx, "resend_send_failed", "error", err, "to", req.To, "subject", req.Subject)
 		return SendResult{}, fmt.Errorf("resend send failed: %w", err)
 	}
 
-	slog.Info("resend_sent", "message_id", sent.Id, "to", req.To, "subject", req.Subject)
+	slog.InfoContext(ctx, "resend_sent", "message_id", sent.Id, "to", req.To, "subject", req.Subject)
 	return SendResult{
 		MessageID: sent.Id,
 		SentAt:    time.Now(),
@@ -96,7 +97,7 @@ func (s *ResendSender) SendBatch(ctx context.Context, reqs []SendRequest) ([]Sen
 
 		resp, err := s.client.Batch.SendWithContext(ctx, batchParams)
 		if err != nil {
-			slog.Error("resend_batch_failed", "error", err, "batch_size", len(chunk))
+			slog.ErrorContext(ctx, "resend_batch_failed", "error", err, "batch_size", len(chunk))
 			return allResults, fmt.Errorf("resend batch send failed: %w", err)
 		}
 
@@ -107,8 +108,25 @@ func (s *ResendSender) SendBatch(ctx context.Context, reqs []SendRequest) ([]Sen
 			})
 		}
 
-		slog.Info("resend_batch_sent", "count", len(chunk), "total_sent", len(allResults))
+		slog.InfoContext(ctx, "resend_batch_sent", "count", len(chunk), "total_sent", len(allResults))
 	}
 
 	return allResults, nil
 }
+
+// resendAttachments converts attachments to the Resend request format.
+func resendAttachments(attachments []Attachment) []*resend.Attachment {
+	if len(attachments) == 0 {
+		return nil
+	}
+	out := make([]*resend.Attachment, 0, len(attachments))
+	for _, a := range attachments {
+		out = append(out, &resend.Attachment{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Content:     a.Content,
+			ContentId:   a.ContentID,
+		})
+	}
+	return out
+}
@@ -12,6 +12,16 @@ type SendRequest struct {
 	Subject string
 	HTML    string // HTML body
 	ReplyTo string // Reply-to address
+
+	Attachments []Attachment // optional, Send only (batches drop them); inline files are referenced as cid:<ContentID>
+}
+
+// Attachment is a file sent with an email.
+type Attachment struct {
+	Filename    string
+	ContentType string // e.g. "image/png"; derived from Filename by the provider when empty
+	Content     []byte
+	ContentID   string // optional: set to embed the file inline in the HTML body
 }
 
 // SendResult contains the response from the email provider.
@@ -0,0 +1,53 @@
+package email
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testWebhookSecret = "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"
+
+// TestVerifyWebhookSignature tests acceptance of signed payloads and rejection of tampering and replays.
+func TestVerifyWebhookSignature(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	body := []byte(`{"type":"email.delivered","data":{"email_id":"msg-1"}}`)
+	sig, err := SignWebhook(testWebhookSecret, "evt-1", now, body)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	header := func(ts time.Time, signature string) http.Header {
+		h := http.Header{}
+		h.Set("svix-id", "evt-1")
+		h.Set("svix-timestamp", strconv.FormatInt(ts.Unix(), 10))
+		h.Set("svix-signature", signature)
+		return h
+	}
+
+	if err := VerifyWebhookSignature(testWebhookSecret, header(now, "v1,bm9wZQ== "+sig), body, now); err != nil {
+		t.Errorf("expected valid signature among several, got %v", err)
+	}
+	if err := VerifyWebhookSignature(testWebhookSecret, header(now, sig), []byte(`{"type":"email.bounced"}`), now); !errors.Is(err, ErrWebhookSignature) {
+		t.Errorf("expected ErrWebhookSignature for tampered body, got %v", err)
+	}
+	if err := VerifyWebhookSignature(testWebhookSecret, header(now, sig), body, now.Add(10*time.Minute)); !errors.Is(err, ErrWebhookTimestamp) {
+		t.Errorf("expected ErrWebhookTimestamp for stale request, got %v", err)
+	}
+	if err := VerifyWebhookSignature(testWebhookSecret, http.Header{}, body, now); !errors.Is(err, ErrWebhookMissingHeaders) {
+		t.Errorf("expected ErrWebhookMissingHeaders, got %v", err)
+	}
+}
+
+// TestParseWebhookEvent tests decoding of a Resend bounce payload.
+func TestParseWebhookEvent(t *testing.T) {
+	ev, err := ParseWebhookEvent([]byte(`{"type":"email.bounced","created_at":"2026-03-01T12:00:00.000Z",
+		"data":{"email_id":"msg-1","to":["jo@example.com"],"bounce":{"type":"Permanent","message":"No such user"}}}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if ev.Type != "email.bounced" || ev.MessageID != "msg-1" || ev.BounceType != "Permanent" || ev.CreatedAt.IsZero() {
+		t.Errorf("unexpected event: %+v", ev)
+	}
+}
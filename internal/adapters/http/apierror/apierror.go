@@ -0,0 +1,142 @@
+// Package apierror writes JSON error responses for the /api endpoints.
+//
+// Every error has the same shape so clients can branch on the code rather than
+// parse messages:
+//
+//	{"error":{"code":"validation","message":"Name is required","fields":{"Name":"required"}}}
+//
+// fields is always present; it is empty unless a validation error names inputs.
+// message is translated into the response's Content-Language when the catalogue has it.
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"workshop/internal/adapters/http/i18n"
+)
+
+// Code classifies an error for API clients.
+type Code string
+
+// Error codes. Each maps to one HTTP status (see Status).
+const (
+	CodeValidation       Code = "validation"
+	CodeUnauthorized     Code = "unauthorized"
+	CodeForbidden        Code = "forbidden"
+	CodeNotFound         Code = "not_found"
+	CodeMethodNotAllowed Code = "method_not_allowed"
+	CodeConflict         Code = "conflict"
+	CodeTooLarge         Code = "too_large"
+	CodeRateLimited      Code = "rate_limited"
+	CodeInternal         Code = "internal"
+	CodeUnavailable      Code = "unavailable"
+)
+
+// statusByCode is the HTTP status sent for each code.
+var statusByCode = map[Code]int{
+	CodeValidation:       http.StatusBadRequest,
+	CodeUnauthorized:     http.StatusUnauthorized,
+	CodeForbidden:        http.StatusForbidden,
+	CodeNotFound:         http.StatusNotFound,
+	CodeMethodNotAllowed: http.StatusMethodNotAllowed,
+	CodeConflict:         http.StatusConflict,
+	CodeTooLarge:         http.StatusRequestEntityTooLarge,
+	CodeRateLimited:      http.StatusTooManyRequests,
+	CodeInternal:         http.StatusInternalServerError,
+	CodeUnavailable:      http.StatusServiceUnavailable,
+}
+
+// Status returns the HTTP status for code; unknown codes are internal errors.
+// INVARIANT: Pure function, no side effects
+func Status(code Code) int {
+	if status, ok := statusByCode[code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Detail is the body of the "error" member.
+type Detail struct {
+	Code    Code              `json:"code"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields"`
+}
+
+// Response is the JSON document written for every API error.
+type Response struct {
+	Error Detail `json:"error"`
+}
+
+// Write sends an error response with the status that belongs to code.
+// PRE: nothing has been written to w yet
+// POST: Status, JSON content type and body written; fields is rendered as {} when nil
+func Write(w http.ResponseWriter, code Code, message string, fields map[string]string) {
+	if fields == nil {
+		fields = map[string]string{}
+	}
+	h := w.Header()
+	if locale := h.Get("Content-Language"); locale != "" {
+		message = i18n.Message(locale, message)
+	}
+	h.Del("Content-Length")
+	h.Set("Content-Type", "application/json; charset=utf-8")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(Status(code))
+	json.NewEncoder(w).Encode(Response{Error: Detail{Code: code, Message: message, Fields: fields}})
+}
+
+// Validation reports a malformed or invalid request (400).
+func Validation(w http.ResponseWriter, message string) {
+	Write(w, CodeValidation, message, nil)
+}
+
+// ValidationFields reports invalid inputs by name (400), e.g. {"Email": "required"}.
+func ValidationFields(w http.ResponseWriter, message string, fields map[string]string) {
+	Write(w, CodeValidation, message, fields)
+}
+
+// Unauthorized reports a missing or invalid session or credential (401).
+func Unauthorized(w http.ResponseWriter, message string) {
+	Write(w, CodeUnauthorized, message, nil)
+}
+
+// Forbidden reports that the caller may not perform the request (403).
+func Forbidden(w http.ResponseWriter, message string) {
+	Write(w, CodeForbidden, message, nil)
+}
+
+// NotFound reports that the addressed resource does not exist (404).
+func NotFound(w http.ResponseWriter, message string) {
+	Write(w, CodeNotFound, message, nil)
+}
+
+// MethodNotAllowed reports an unsupported HTTP method (405).
+func MethodNotAllowed(w http.ResponseWriter) {
+	Write(w, CodeMethodNotAllowed, "method not allowed", nil)
+}
+
+// Conflict reports that the request clashes with current state (409).
+func Conflict(w http.ResponseWriter, message string) {
+	Write(w, CodeConflict, message, nil)
+}
+
+// TooLarge reports a request body or upload over its limit (413).
+func TooLarge(w http.ResponseWriter, message string) {
+	Write(w, CodeTooLarge, message, nil)
+}
+
+// RateLimited reports that the caller must slow down (429).
+func RateLimited(w http.ResponseWriter, message string) {
+	Write(w, CodeRateLimited, message, nil)
+}
+
+// Internal reports an unexpected server failure (500). Details belong in the log, not the response.
+func Internal(w http.ResponseWriter) {
+	Write(w, CodeInternal, "internal server error", nil)
+}
+
+// Unavailable reports that a dependency is not configured or not reachable (503).
+func Unavailable(w http.ResponseWriter, message string) {
+	Write(w, CodeUnavailable, message, nil)
+}
@@ -0,0 +1,81 @@
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestWrite_Shape verifies every helper writes the documented JSON shape and status.
+func TestWrite_Shape(t *testing.T) {
+	tests := []struct {
+		name       string
+		write      func(http.ResponseWriter)
+		wantStatus int
+		wantCode   Code
+		wantFields map[string]string
+	}{
+		{name: "validation", write: func(w http.ResponseWriter) { Validation(w, "bad") }, wantStatus: 400, wantCode: CodeValidation},
+		{name: "validation fields", write: func(w http.ResponseWriter) {
+			ValidationFields(w, "bad", map[string]string{"Name": "required"})
+		}, wantStatus: 400, wantCode: CodeValidation, wantFields: map[string]string{"Name": "required"}},
+		{name: "unauthorized", write: func(w http.ResponseWriter) { Unauthorized(w, "no") }, wantStatus: 401, wantCode: CodeUnauthorized},
+		{name: "forbidden", write: func(w http.ResponseWriter) { Forbidden(w, "no") }, wantStatus: 403, wantCode: CodeForbidden},
+		{name: "not found", write: func(w http.ResponseWriter) { NotFound(w, "gone") }, wantStatus: 404, wantCode: CodeNotFound},
+		{name: "method", write: MethodNotAllowed, wantStatus: 405, wantCode: CodeMethodNotAllowed},
+		{name: "conflict", write: func(w http.ResponseWriter) { Conflict(w, "dup") }, wantStatus: 409, wantCode: CodeConflict},
+		{name: "too large", write: func(w http.ResponseWriter) { TooLarge(w, "big") }, wantStatus: 413, wantCode: CodeTooLarge},
+		{name: "rate limited", write: func(w http.ResponseWriter) { RateLimited(w, "slow") }, wantStatus: 429, wantCode: CodeRateLimited},
+		{name: "internal", write: Internal, wantStatus: 500, wantCode: CodeInternal},
+		{name: "unavailable", write: func(w http.ResponseWriter) { Unavailable(w, "off") }, wantStatus: 503, wantCode: CodeUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.write(rec)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			var body Response
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v (%s)", err, rec.Body.String())
+			}
+			if body.Error.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", body.Error.Code, tt.wantCode)
+			}
+			if body.Error.Message == "" {
+				t.Error("message is empty")
+			}
+			if body.Error.Fields == nil {
+				t.Error("fields missing; clients expect an object")
+			}
+			for k, v := range tt.wantFields {
+				if body.Error.Fields[k] != v {
+					t.Errorf("fields[%s] = %q, want %q", k, body.Error.Fields[k], v)
+				}
+			}
+		})
+	}
+}
+
+// TestWrite_EmptyFieldsIsObject verifies fields serialises as {} rather than null.
+func TestWrite_EmptyFieldsIsObject(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NotFound(rec, "member not found")
+	want := `{"error":{"code":"not_found","message":"member not found","fields":{}}}`
+	if got := strings.TrimSpace(rec.Body.String()); got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
+
+// TestStatus_UnknownCode verifies unknown codes fall back to 500.
+func TestStatus_UnknownCode(t *testing.T) {
+	if got := Status("nonsense"); got != http.StatusInternalServerError {
+		t.Errorf("Status(unknown) = %d", got)
+	}
+}
@@ -0,0 +1,168 @@
+package events
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// DefaultHistorySize is how many recent events a hub keeps for clients that reconnect.
+const DefaultHistorySize = 256
+
+// subscriberBuffer is how many undelivered events a subscriber may fall behind by before
+// it is dropped. A dropped client reconnects and catches up from the history.
+const subscriberBuffer = 32
+
+// Event is one live update. Roles and AccountIDs address it: an event reaches a
+// subscriber only if the subscriber's role is in Roles and its account is in AccountIDs,
+// where an empty list matches everyone.
+type Event struct {
+	ID         uint64
+	Type       string
+	Data       json.RawMessage
+	Roles      []string
+	AccountIDs []string
+	At         time.Time
+}
+
+// Filter describes who a subscriber is and which event types it wants.
+type Filter struct {
+	AccountID string
+	Role      string
+	Types     []string // empty receives every type
+}
+
+// Matches reports whether the event is addressed to the subscriber and of a wanted type.
+// PRE: none
+// POST: Returns true when role, account and type all match
+func (f Filter) Matches(e Event) bool {
+	return allows(e.Roles, f.Role) && allows(e.AccountIDs, f.AccountID) && allows(f.Types, e.Type)
+}
+
+// allows reports whether v is in list; an empty list allows everything.
+func allows(list []string, v string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscription is a subscriber's stream of events. C is closed when the subscription is
+// cancelled or the subscriber falls too far behind.
+type Subscription struct {
+	C      <-chan Event
+	Replay []Event // events after the requested ID still in the history, oldest first
+	Missed bool    // events after the requested ID were lost: they left the history, or the server restarted
+
+	hub    *Hub
+	ch     chan Event
+	filter Filter
+}
+
+// Cancel stops delivery and releases the subscription. It is safe to call more than once.
+// PRE: none
+// POST: The subscription is removed from its hub and C is closed
+func (s *Subscription) Cancel() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s)
+}
+
+// Hub fans published events out to subscribers and keeps a short history so a client
+// that reconnects with its last event ID misses nothing.
+// Publishing never blocks: a subscriber whose buffer is full is dropped.
+type Hub struct {
+	mu      sync.Mutex
+	subs    map[*Subscription]struct{}
+	history []Event
+	size    int
+	lastID  uint64
+	now     func() time.Time
+}
+
+// NewHub creates a hub keeping the given number of recent events.
+// PRE: none; a non-positive size uses DefaultHistorySize
+// POST: Returns an empty hub
+func NewHub(size int) *Hub {
+	if size <= 0 {
+		size = DefaultHistorySize
+	}
+	return &Hub{subs: map[*Subscription]struct{}{}, size: size, now: time.Now}
+}
+
+// Publish assigns the event the next ID and delivers it to every matching subscriber.
+// PRE: e.Type is non-empty
+// POST: Returns the event as published; it is in the history
+func (h *Hub) Publish(e Event) Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastID++
+	e.ID = h.lastID
+	if e.At.IsZero() {
+		e.At = h.now()
+	}
+	h.history = append(h.history, e)
+	if len(h.history) > h.size {
+		h.history = h.history[len(h.history)-h.size:]
+	}
+
+	for s := range h.subs {
+		if !s.filter.Matches(e) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			h.remove(s)
+		}
+	}
+	return e
+}
+
+// Subscribe registers a subscriber. Events published after lastID that match the filter
+// and are still in the history are returned in Replay; later events arrive on C.
+// PRE: none; lastID 0 replays nothing
+// POST: Returns a live subscription; the caller must Cancel it
+func (h *Hub) Subscribe(filter Filter, lastID uint64) *Subscription {
+	ch := make(chan Event, subscriberBuffer)
+	s := &Subscription{C: ch, hub: h, ch: ch, filter: filter}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if lastID > 0 {
+		// An ID beyond the last one was issued before a restart.
+		s.Missed = lastID > h.lastID || (lastID < h.lastID && h.history[0].ID > lastID+1)
+		for _, e := range h.history {
+			if e.ID > lastID && filter.Matches(e) {
+				s.Replay = append(s.Replay, e)
+			}
+		}
+	}
+	h.subs[s] = struct{}{}
+	return s
+}
+
+// Subscribers returns the number of live subscriptions.
+// PRE: none
+// POST: Returns the count
+func (h *Hub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs)
+}
+
+// remove drops a subscription and closes its channel.
+// PRE: h.mu is held
+func (h *Hub) remove(s *Subscription) {
+	if _, ok := h.subs[s]; !ok {
+		return
+	}
+	delete(h.subs, s)
+	close(s.ch)
+}
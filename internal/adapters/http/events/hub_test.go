@@ -0,0 +1,112 @@
+package events
+
+import (
+	"testing"
+)
+
+// TestFilterMatches verifies events reach only the roles, accounts and types they are for.
+func TestFilterMatches(t *testing.T) {
+	checkin := Event{Type: "attendance.checkin", Roles: []string{"admin", "coach"}}
+	message := Event{Type: "message.new", AccountIDs: []string{"acc-1"}}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		event  Event
+		want   bool
+	}{
+		{"coach sees check-ins", Filter{Role: "coach", AccountID: "acc-9"}, checkin, true},
+		{"member does not see check-ins", Filter{Role: "member", AccountID: "acc-1"}, checkin, false},
+		{"recipient sees message", Filter{Role: "member", AccountID: "acc-1"}, message, true},
+		{"other account does not", Filter{Role: "admin", AccountID: "acc-2"}, message, false},
+		{"type filter excludes", Filter{Role: "coach", Types: []string{"notice.published"}}, checkin, false},
+		{"type filter includes", Filter{Role: "coach", Types: []string{"notice.published", "attendance.checkin"}}, checkin, true},
+		{"unaddressed reaches everyone", Filter{Role: "trial"}, Event{Type: "notice.published"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(tt.event); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestHubPublish verifies subscribers receive matching events with increasing IDs.
+func TestHubPublish(t *testing.T) {
+	h := NewHub(10)
+	coach := h.Subscribe(Filter{Role: "coach"}, 0)
+	defer coach.Cancel()
+	member := h.Subscribe(Filter{Role: "member"}, 0)
+	defer member.Cancel()
+
+	h.Publish(Event{Type: "attendance.checkin", Roles: []string{"coach"}})
+	h.Publish(Event{Type: "notice.published"})
+
+	if e := <-coach.C; e.ID != 1 || e.Type != "attendance.checkin" {
+		t.Errorf("coach first event = %+v", e)
+	}
+	if e := <-coach.C; e.ID != 2 || e.At.IsZero() {
+		t.Errorf("coach second event = %+v", e)
+	}
+	if e := <-member.C; e.Type != "notice.published" {
+		t.Errorf("member got %+v, want only the notice", e)
+	}
+	if len(member.C) != 0 {
+		t.Errorf("member has %d undelivered events, want 0", len(member.C))
+	}
+}
+
+// TestHubReplay verifies a reconnecting subscriber catches up from its last event ID,
+// and learns when events it missed are gone.
+func TestHubReplay(t *testing.T) {
+	h := NewHub(3)
+	for i := 0; i < 5; i++ {
+		h.Publish(Event{Type: "notice.published"})
+	}
+
+	s := h.Subscribe(Filter{}, 3)
+	defer s.Cancel()
+	if len(s.Replay) != 2 || s.Replay[0].ID != 4 || s.Replay[1].ID != 5 || s.Missed {
+		t.Errorf("from 3: replay %+v missed %v, want events 4 and 5", s.Replay, s.Missed)
+	}
+
+	old := h.Subscribe(Filter{}, 1)
+	defer old.Cancel()
+	if len(old.Replay) != 3 || !old.Missed {
+		t.Errorf("from 1: replay %d events missed %v, want 3 and missed", len(old.Replay), old.Missed)
+	}
+
+	restarted := h.Subscribe(Filter{}, 99)
+	defer restarted.Cancel()
+	if len(restarted.Replay) != 0 || !restarted.Missed {
+		t.Errorf("from 99: replay %+v missed %v, want none and missed", restarted.Replay, restarted.Missed)
+	}
+
+	current := h.Subscribe(Filter{}, 5)
+	defer current.Cancel()
+	if len(current.Replay) != 0 || current.Missed {
+		t.Errorf("from 5: replay %+v missed %v, want nothing", current.Replay, current.Missed)
+	}
+}
+
+// TestHubDropsSlowSubscriber verifies a subscriber that stops reading is dropped rather
+// than blocking publishers.
+func TestHubDropsSlowSubscriber(t *testing.T) {
+	h := NewHub(0)
+	s := h.Subscribe(Filter{}, 0)
+	for i := 0; i < subscriberBuffer+1; i++ {
+		h.Publish(Event{Type: "notice.published"})
+	}
+	if h.Subscribers() != 0 {
+		t.Fatalf("expected the slow subscriber dropped, %d remain", h.Subscribers())
+	}
+	n := 0
+	for range s.C {
+		n++
+	}
+	if n != subscriberBuffer {
+		t.Errorf("drained %d events before close, want %d", n, subscriberBuffer)
+	}
+	s.Cancel() // already dropped: must not panic
+}
@@ -3,15 +3,18 @@ package web
 import (
 	"bytes"
 	"context"
-	"encoding/csv"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -22,7 +25,12 @@ import (
 	"github.com/yuin/goldmark"
 	goldmarkHTML "github.com/yuin/goldmark/renderer/html"
 
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/i18n"
 	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/adapters/http/perf"
+	"workshop/internal/adapters/oidc"
+	"workshop/internal/adapters/spreadsheet"
 	accountStore "workshop/internal/adapters/storage/account"
 	emailStoreImport "workshop/internal/adapters/storage/email"
 	memberStore "workshop/internal/adapters/storage/member"
@@ -30,6 +38,7 @@ import (
 	"workshop/internal/application/listutil"
 	"workshop/internal/application/orchestrators"
 	"workshop/internal/application/projections"
+	"workshop/internal/application/trace"
 	accountDomain "workshop/internal/domain/account"
 	"workshop/internal/domain/attendance"
 	calendarDomain "workshop/internal/domain/calendar"
@@ -40,15 +49,24 @@ import (
 	featureflagDomain "workshop/internal/domain/featureflag"
 	gradingDomain "workshop/internal/domain/grading"
 	holidayDomain "workshop/internal/domain/holiday"
+	injuryDomain "workshop/internal/domain/injury"
 	memberDomain "workshop/internal/domain/member"
 	messageDomain "workshop/internal/domain/message"
 	milestoneDomain "workshop/internal/domain/milestone"
 	noticeDomain "workshop/internal/domain/notice"
+	notificationDomain "workshop/internal/domain/notification"
+	observationDomain "workshop/internal/domain/observation"
+	outboxDomain "workshop/internal/domain/outbox"
+	perfstatsDomain "workshop/internal/domain/perfstats"
+	permissionDomain "workshop/internal/domain/permission"
 	rotorDomain "workshop/internal/domain/rotor"
+	rubricDomain "workshop/internal/domain/rubric"
 	scheduleDomain "workshop/internal/domain/schedule"
+	statusChangeDomain "workshop/internal/domain/statuschange"
 	termDomain "workshop/internal/domain/term"
 	themeDomain "workshop/internal/domain/theme"
 	trainingGoalDomain "workshop/internal/domain/traininggoal"
+	waiverDomain "workshop/internal/domain/waiver"
 )
 
 // timeNow is a variable for testability.
@@ -67,39 +85,28 @@ func generateID() string {
 	return uuid.New().String()
 }
 
+// csvSafeCell mitigates CSV formula injection for hand-written CSV exports; see spreadsheet.SafeText.
 func csvSafeCell(s string) string {
-	// Mitigate CSV formula injection when opening exports in Excel/Sheets.
-	// Any cell starting with these characters may be treated as a formula.
-	// Prefix with a single quote to force literal interpretation.
-	if s == "" {
-		return s
-	}
-	switch s[0] {
-	case '=', '+', '-', '@':
-		return "'" + s
-	default:
-		return s
-	}
+	return spreadsheet.SafeText(s)
 }
 
 // handleMembersExportCSV handles GET /api/members/export
-// Exports the members list as CSV, respecting the same search/filter/sort params as /members.
+// Exports the members list as CSV (or XLSX with format=xlsx), respecting the same search/filter/sort params as /members.
 func handleMembersExportCSV(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
-	sess, ok := middleware.GetSessionFromContext(r.Context())
+	sess, ok := requirePermission(w, r, permissionDomain.ActionMembersExport)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
 		return
 	}
-	if !middleware.IsCoachOrAdmin(r.Context()) {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if !requireFeatureAPI(w, r, sess, "member_mgmt") {
 		return
 	}
-	if !requireFeatureAPI(w, r, sess, "member_mgmt") {
+	format, ok := parseExportFormat(w, r)
+	if !ok {
 		return
 	}
 
@@ -109,10 +116,17 @@ func handleMembersExportCSV(w http.ResponseWriter, r *http.Request) {
 		[]string{"program", "status"},
 	)
 
+	tag, segmentIDs, err := memberTagFilters(r)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
 	filter := memberStore.ListFilter{
 		Program: lp.Filters["program"],
 		Status:  lp.Filters["status"],
 		Search:  lp.Search,
+		Tag:     tag,
+		IDs:     segmentIDs,
 		Sort:    lp.Sort,
 		Dir:     lp.Dir,
 		Limit:   100000,
@@ -125,58 +139,7 @@ func handleMembersExportCSV(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	filename := fmt.Sprintf("members-%s.csv", timeNow().Format("2006-01-02"))
-	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
-	w.Header().Set("Cache-Control", "no-store")
-
-	cw := csv.NewWriter(w)
-	cw.UseCRLF = true
-
-	// Note: we call Flush explicitly and check cw.Error() so write failures don't get swallowed.
-
-	if err := cw.Write([]string{"ID", "AccountID", "Name", "Email", "Program", "Status", "Fee", "Frequency", "GradingMetric"}); err != nil {
-		internalError(w, err)
-		return
-	}
-	for _, m := range members {
-		rec := []string{
-			csvSafeCell(m.ID),
-			csvSafeCell(m.AccountID),
-			csvSafeCell(m.Name),
-			csvSafeCell(m.Email),
-			csvSafeCell(m.Program),
-			csvSafeCell(m.Status),
-			csvSafeCell(strconv.Itoa(m.Fee)),
-			csvSafeCell(m.Frequency),
-			csvSafeCell(m.GradingMetric),
-		}
-		if err := cw.Write(rec); err != nil {
-			internalError(w, err)
-			return
-		}
-	}
-
-	cw.Flush()
-	if err := cw.Error(); err != nil {
-		// Response may be partially written; log only.
-		slog.Error("csv_export_flush_error", "error", err.Error())
-	}
-
-	// Log audit event for export
-	slog.Info("audit_event",
-		"actor_id", sess.AccountID,
-		"actor_role", sess.Role,
-		"action", "admin.members.export_csv",
-		"row_count", len(members),
-		"filters", map[string]string{
-			"program": lp.Filters["program"],
-			"status":  lp.Filters["status"],
-			"search":  lp.Search,
-			"sort":    lp.Sort,
-			"dir":     lp.Dir,
-		},
-	)
+	writeExport(w, r, sess, format, "members-"+timeNow().Format("2006-01-02"), "Members", "admin.members.export_csv", memberExportColumns, slices.Values(members))
 }
 
 // importCSVResult is the JSON response shape for /api/members/import.
@@ -202,17 +165,17 @@ const importCSVMaxBytes = 5 << 20 // 5 MB
 // Delegates all business logic to orchestrators.ExecuteImportMembers.
 func handleMembersImportCSV(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
 	sess, ok := middleware.GetSessionFromContext(r.Context())
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	if !middleware.IsAdmin(r.Context()) {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+		apierror.Forbidden(w, "Forbidden")
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "member_mgmt") {
@@ -224,13 +187,13 @@ func handleMembersImportCSV(w http.ResponseWriter, r *http.Request) {
 
 	r.Body = http.MaxBytesReader(w, r.Body, importCSVMaxBytes)
 	if err := r.ParseMultipartForm(importCSVMaxBytes); err != nil {
-		http.Error(w, "file too large or invalid form", http.StatusBadRequest)
+		apierror.Validation(w, "file too large or invalid form")
 		return
 	}
 
 	file, fh, err := r.FormFile("file")
 	if err != nil {
-		http.Error(w, "missing file field", http.StatusBadRequest)
+		apierror.Validation(w, "missing file field")
 		return
 	}
 	defer file.Close()
@@ -241,7 +204,7 @@ func handleMembersImportCSV(w http.ResponseWriter, r *http.Request) {
 	ct := strings.ToLower(strings.SplitN(fh.Header.Get("Content-Type"), ";", 2)[0])
 	if ct != "" && ct != "text/csv" && ct != "text/plain" && ct != "application/csv" &&
 		ct != "application/octet-stream" {
-		http.Error(w, "file must be a CSV (text/csv)", http.StatusBadRequest)
+		apierror.Validation(w, "file must be a CSV (text/csv)")
 		return
 	}
 
@@ -260,7 +223,7 @@ func handleMembersImportCSV(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		var ve *orchestrators.ImportMembersValidationError
 		if errors.As(err, &ve) {
-			http.Error(w, ve.Error(), http.StatusBadRequest)
+			apierror.Validation(w, ve.Error())
 			return
 		}
 		internalError(w, err)
@@ -288,12 +251,12 @@ func handleMembersImportCSV(w http.ResponseWriter, r *http.Request) {
 // Used by the member training log page to render attendance volume graphs.
 func handleGetTrainingVolume(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	sess, ok := middleware.GetSessionFromContext(r.Context())
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "training_log") {
@@ -307,17 +270,17 @@ func handleGetTrainingVolume(w http.ResponseWriter, r *http.Request) {
 	if sess.Role == accountDomain.RoleMember || sess.Role == accountDomain.RoleTrial {
 		m, err := stores.MemberStore.GetByEmail(ctx, sess.Email)
 		if err != nil {
-			http.Error(w, "member not found", http.StatusForbidden)
+			apierror.Forbidden(w, "member not found")
 			return
 		}
 		if memberID != "" && memberID != m.ID {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+			apierror.Forbidden(w, "Forbidden")
 			return
 		}
 		memberID = m.ID
 	}
 	if memberID == "" {
-		http.Error(w, "member_id is required", http.StatusBadRequest)
+		apierror.Validation(w, "member_id is required")
 		return
 	}
 
@@ -338,7 +301,7 @@ func handleGetTrainingVolume(w http.ResponseWriter, r *http.Request) {
 	}
 	result, err := projections.QueryGetTrainingVolume(ctx, query, deps)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
@@ -347,10 +310,11 @@ func handleGetTrainingVolume(w http.ResponseWriter, r *http.Request) {
 }
 
 // internalError logs the real error and returns a generic message to the client.
-// This prevents leaking internal details per OWASP A05.
+// This prevents leaking internal details per OWASP A05. The log line carries the request ID
+// the client gets back in X-Request-ID, so a reported failure can be found in the logs.
 func internalError(w http.ResponseWriter, err error) {
-	slog.Error("internal_error", "error", err.Error())
-	http.Error(w, "internal server error", http.StatusInternalServerError)
+	slog.Error("internal_error", "error", err.Error(), trace.LogKey, w.Header().Get(middleware.HeaderRequestID))
+	apierror.Internal(w)
 }
 
 // strictDecode decodes JSON from the request body, rejecting unknown fields.
@@ -360,39 +324,68 @@ func strictDecode(r *http.Request, v any) error {
 	return dec.Decode(v)
 }
 
-const templatesDir = "internal/adapters/http/templates"
-
 func isHTMLRequest(r *http.Request) bool {
 	accept := r.Header.Get("Accept")
 	return strings.Contains(accept, "text/html") || strings.Contains(accept, "application/xhtml+xml")
 }
 
 func renderTemplate(w http.ResponseWriter, r *http.Request, templateName string, data any) {
-	sess, ok := middleware.GetSessionFromContext(r.Context())
+	tpl, err := templates.forRequest(templateName, true, r)
+	if err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := tpl.Execute(w, data); err != nil {
+		http.Error(w, "Render error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// templateFuncs returns the helpers available to page templates.
+// Session-dependent helpers close over r; with a nil r they behave as for a logged-out
+// visitor, which is what templates are parsed with before being cached.
+func templateFuncs(r *http.Request) template.FuncMap {
+	var sess middleware.Session
+	ok := false
+	flagsByKey := map[string]featureflagDomain.FeatureFlag{}
+	if r != nil {
+		sess, ok = middleware.GetSessionFromContext(r.Context())
+		flagsByKey = mergedFeatureFlagsByKey(r.Context())
+	}
 	role := ""
 	email := ""
 	if ok {
 		role = sess.Role
 		email = sess.Email
 	}
+	locale := i18n.DefaultLocale
+	if r != nil {
+		locale = i18n.FromContext(r.Context())
+	}
 
 	impersonating := false
+	impersonatingAccount := false
+	var impersonationEndsAt time.Time
 	realRole := ""
 	isRealAdmin := false
 	if ok && sess.IsImpersonating() {
 		impersonating = true
+		impersonatingAccount = sess.IsImpersonatingAccount()
+		impersonationEndsAt = sess.ImpersonationExpiresAt.Local()
 		realRole = sess.RealRole
 		isRealAdmin = sess.RealRole == "admin"
 	} else if ok {
 		isRealAdmin = sess.Role == "admin"
 	}
 
-	flagsByKey := mergedFeatureFlagsByKey(r.Context())
-
-	funcMap := template.FuncMap{
-		"currentRole":  func() string { return role },
-		"currentEmail": func() string { return email },
-		"isLoggedIn":   func() bool { return role != "" },
+	return template.FuncMap{
+		"currentRole":   func() string { return role },
+		"currentEmail":  func() string { return email },
+		"isLoggedIn":    func() bool { return role != "" },
+		"t":             func(key string, args ...any) string { return i18n.T(locale, key, args...) },
+		"currentLocale": func() string { return locale },
+		"locales":       i18n.Locales,
 		"featureEnabled": func(key string) bool {
 			if !ok {
 				return false
@@ -401,13 +394,27 @@ func renderTemplate(w http.ResponseWriter, r *http.Request, templateName string,
 			if !exists {
 				return true
 			}
-			return ff.EnabledForRole(sess.Role, sess.BetaTester)
+			return ff.Evaluate(flagSubject(sess), timeNow()).Enabled
+		},
+		"csrfToken": func() string {
+			if r == nil {
+				return ""
+			}
+			return csrf.Token(r)
+		},
+		"isImpersonating":        func() bool { return impersonating },
+		"isImpersonatingAccount": func() bool { return impersonatingAccount },
+		"impersonationEndsAt":    func() time.Time { return impersonationEndsAt },
+		"realRole":               func() string { return realRole },
+		"isRealAdmin":            func() bool { return isRealAdmin },
+		"list":                   func(items ...string) []string { return items },
+		"date":                   func(layout string, t time.Time) string { return t.Format(layout) },
+		"navigation": func() projections.Navigation {
+			if !ok {
+				return projections.Navigation{}
+			}
+			return sessionNavigation(r, sess)
 		},
-		"csrfToken":       func() string { return csrf.Token(r) },
-		"isImpersonating": func() bool { return impersonating },
-		"realRole":        func() string { return realRole },
-		"isRealAdmin":     func() bool { return isRealAdmin },
-		"list":            func(items ...string) []string { return items },
 		"renderMarkdown": func(md string) template.HTML {
 			var buf bytes.Buffer
 			if err := mdRenderer.Convert([]byte(md), &buf); err != nil {
@@ -430,7 +437,7 @@ func renderTemplate(w http.ResponseWriter, r *http.Request, templateName string,
 		},
 		"add": func(a, b int) int { return a + b },
 		"sub": func(a, b int) int { return a - b },
-		"sortHeaderArgs": func(col, label, activeSort, activeDir, search, program, status string, perPage int) map[string]string {
+		"sortHeaderArgs": func(col, label, activeSort, activeDir, search, program, status string, perPage int, tag, segment string) map[string]string {
 			nextDir := "asc"
 			if col == activeSort && activeDir == "asc" {
 				nextDir = "desc"
@@ -440,9 +447,10 @@ func renderTemplate(w http.ResponseWriter, r *http.Request, templateName string,
 				"ActiveSort": activeSort, "ActiveDir": activeDir, "NextDir": nextDir,
 				"Search": search, "Program": program, "Status": status,
 				"PerPage": fmt.Sprintf("%d", perPage),
+				"Tag":     tag, "Segment": segment,
 			}
 		},
-		"paginationQuery": func(page int, sort, dir, search, program, status string, perPage int) template.URL {
+		"paginationQuery": func(page int, sort, dir, search, program, status string, perPage int, tag, segment string) template.URL {
 			q := fmt.Sprintf("page=%d", page)
 			if sort != "" {
 				q += "&sort=" + sort
@@ -462,9 +470,15 @@ func renderTemplate(w http.ResponseWriter, r *http.Request, templateName string,
 			if perPage != 0 {
 				q += fmt.Sprintf("&per_page=%d", perPage)
 			}
+			if tag != "" {
+				q += "&tag=" + url.QueryEscape(tag)
+			}
+			if segment != "" {
+				q += "&segment=" + url.QueryEscape(segment)
+			}
 			return template.URL(q)
 		},
-		"exportMembersQuery": func(sort, dir, search, program, status string) template.URL {
+		"exportMembersQuery": func(sort, dir, search, program, status, tag, segment string) template.URL {
 			v := url.Values{}
 			if sort != "" {
 				v.Set("sort", sort)
@@ -481,22 +495,15 @@ func renderTemplate(w http.ResponseWriter, r *http.Request, templateName string,
 			if status != "" {
 				v.Set("status", status)
 			}
+			if tag != "" {
+				v.Set("tag", tag)
+			}
+			if segment != "" {
+				v.Set("segment", segment)
+			}
 			return template.URL(v.Encode())
 		},
 	}
-
-	layoutPath := filepath.Join(templatesDir, "layout.html")
-	pagePath := filepath.Join(templatesDir, templateName)
-	tpl, err := template.New("layout.html").Funcs(funcMap).ParseFiles(layoutPath, pagePath)
-	if err != nil {
-		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := tpl.Execute(w, data); err != nil {
-		http.Error(w, "Render error: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
 }
 
 func mergedFeatureFlagsByKey(ctx context.Context) map[string]featureflagDomain.FeatureFlag {
@@ -513,15 +520,7 @@ func mergedFeatureFlagsByKey(ctx context.Context) map[string]featureflagDomain.F
 	}
 	for _, p := range persisted {
 		if d, ok := m[p.Key]; ok {
-			if p.Description != "" {
-				d.Description = p.Description
-			}
-			d.EnabledAdmin = p.EnabledAdmin
-			d.EnabledCoach = p.EnabledCoach
-			d.EnabledMember = p.EnabledMember
-			d.EnabledTrial = p.EnabledTrial
-			d.BetaOverride = p.BetaOverride
-			m[p.Key] = d
+			m[p.Key] = overlayFeatureFlag(d, p)
 		} else {
 			m[p.Key] = p
 		}
@@ -529,12 +528,26 @@ func mergedFeatureFlagsByKey(ctx context.Context) map[string]featureflagDomain.F
 	return m
 }
 
+// overlayFeatureFlag applies a persisted flag's settings over its default,
+// keeping the default description when none was saved.
+func overlayFeatureFlag(d, p featureflagDomain.FeatureFlag) featureflagDomain.FeatureFlag {
+	if p.Description == "" {
+		p.Description = d.Description
+	}
+	return p
+}
+
+// flagSubject is the account a session's feature flags are evaluated for.
+func flagSubject(sess middleware.Session) featureflagDomain.Subject {
+	return featureflagDomain.Subject{AccountID: sess.AccountID, Role: sess.Role, BetaTester: sess.BetaTester}
+}
+
 func featureEnabledForSession(ctx context.Context, sess middleware.Session, featureKey string) bool {
 	ff, ok := mergedFeatureFlagsByKey(ctx)[featureKey]
 	if !ok {
 		return true
 	}
-	return ff.EnabledForRole(sess.Role, sess.BetaTester)
+	return ff.Evaluate(flagSubject(sess), timeNow()).Enabled
 }
 
 func requireFeaturePage(w http.ResponseWriter, r *http.Request, sess middleware.Session, featureKey string) bool {
@@ -549,7 +562,7 @@ func requireFeatureAPI(w http.ResponseWriter, r *http.Request, sess middleware.S
 	if featureEnabledForSession(r.Context(), sess, featureKey) {
 		return true
 	}
-	http.Error(w, "Forbidden", http.StatusForbidden)
+	apierror.Forbidden(w, "Forbidden")
 	return false
 }
 
@@ -576,10 +589,17 @@ func handleMembers(w http.ResponseWriter, r *http.Request) {
 			[]string{"program", "status"},
 		)
 
+		tag, segmentIDs, err := memberTagFilters(r)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
 		query := projections.GetMemberListQuery{
 			Program: lp.Filters["program"],
 			Status:  lp.Filters["status"],
 			Search:  lp.Search,
+			Tag:     tag,
+			IDs:     segmentIDs,
 			Sort:    lp.Sort,
 			Dir:     lp.Dir,
 			Page:    lp.Page,
@@ -598,7 +618,7 @@ func handleMembers(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if isHTML {
-			renderTemplate(w, r, "get_member_list.html", map[string]any{
+			data := map[string]any{
 				"Members":        result.Members,
 				"PageInfo":       result.PageInfo,
 				"Sort":           lp.Sort,
@@ -606,9 +626,29 @@ func handleMembers(w http.ResponseWriter, r *http.Request) {
 				"Search":         lp.Search,
 				"Program":        lp.Filters["program"],
 				"Status":         lp.Filters["status"],
+				"Tag":            tag,
+				"Segment":        "",
 				"PerPageOptions": listutil.PerPageOptions,
-				"HasFilters":     lp.Search != "" || lp.Filters["program"] != "" || lp.Filters["status"] != "",
-			})
+				"HasFilters":     lp.Search != "" || lp.Filters["program"] != "" || lp.Filters["status"] != "" || tag != "" || segmentIDs != nil,
+			}
+			if segmentIDs != nil {
+				data["Segment"] = r.URL.Query().Get("segment")
+			}
+			if sess, ok := middleware.GetSessionFromContext(ctx); ok && featureEnabledForSession(ctx, sess, "member_tags") {
+				tags, err := stores.MemberTagStore.ListTags(ctx)
+				if err != nil {
+					internalError(w, err)
+					return
+				}
+				segments, err := stores.MemberTagStore.ListSegments(ctx)
+				if err != nil {
+					internalError(w, err)
+					return
+				}
+				data["Tags"] = tags
+				data["Segments"] = segments
+			}
+			renderTemplate(w, r, "get_member_list.html", data)
 			return
 		}
 
@@ -629,6 +669,7 @@ func handleMembers(w http.ResponseWriter, r *http.Request) {
 			input.Email = r.FormValue("Email")
 			input.Name = r.FormValue("Name")
 			input.Program = r.FormValue("Program")
+			input.ReferredBy = orchestrators.ReferredBy{MemberID: r.FormValue("ReferredByMemberID"), Name: r.FormValue("ReferredByName")}
 		} else {
 			if err := strictDecode(r, &input); err != nil {
 				http.Error(w, "Invalid request", http.StatusBadRequest)
@@ -637,13 +678,29 @@ func handleMembers(w http.ResponseWriter, r *http.Request) {
 		}
 
 		deps := orchestrators.RegisterMemberDeps{
-			MemberStore: stores.MemberStore,
+			MemberStore:   stores.MemberStore,
+			ReferralStore: referralStoreFor(ctx),
+		}
+		if deps.ReferralStore == nil {
+			input.ReferredBy = orchestrators.ReferredBy{}
+		}
+		err := checkReferrer(ctx, input.ReferredBy)
+		var memberID string
+		if err == nil {
+			memberID, err = orchestrators.ExecuteRegisterMember(ctx, input, deps)
+		}
+		if isReferralError(err) {
+			apierror.Validation(w, err.Error())
+			return
 		}
-		_, err := orchestrators.ExecuteRegisterMember(ctx, input, deps)
 		if err != nil {
 			internalError(w, err)
 			return
 		}
+		// Registered members are active straight away, so their referrer is thanked now
+		if deps.ReferralStore != nil {
+			convertReferral(ctx, memberID)
+		}
 
 		if isHTML {
 			http.Redirect(w, r, "/", http.StatusSeeOther)
@@ -656,7 +713,18 @@ func handleMembers(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusMethodNotAllowed)
 }
 
+// checkInRequest is the body of POST /checkin.
+type checkInRequest struct {
+	MemberID   string `json:"MemberID"`
+	ScheduleID string `json:"ScheduleID"`
+	ClassDate  string `json:"ClassDate"`
+	LocationID string `json:"LocationID"`
+	Override   bool   `json:"Override"` // staff only: check in despite the class's prerequisites
+}
+
 // handlePostCheckinCheckInMember handles POST /checkin
+// A member who does not meet the class's prerequisites is refused with 403 and the reason;
+// a coach or admin session can repeat the request with Override to let them in.
 func handlePostCheckinCheckInMember(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	isHTML := isHTMLRequest(r)
@@ -666,7 +734,8 @@ func handlePostCheckinCheckInMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if sess, ok := middleware.GetSessionFromContext(ctx); ok {
+	sess, hasSession := middleware.GetSessionFromContext(ctx)
+	if hasSession {
 		if isHTML {
 			if !requireFeaturePage(w, r, sess, "attendance") {
 				return
@@ -678,27 +747,49 @@ func handlePostCheckinCheckInMember(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	input := orchestrators.CheckInMemberInput{}
-
+	var req checkInRequest
 	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
 		if err := r.ParseForm(); err != nil {
 			http.Error(w, "Invalid form submission", http.StatusBadRequest)
 			return
 		}
-		input.MemberID = r.FormValue("MemberID")
-		input.ScheduleID = r.FormValue("ScheduleID")
-		input.ClassDate = r.FormValue("ClassDate")
+		req.MemberID = r.FormValue("MemberID")
+		req.ScheduleID = r.FormValue("ScheduleID")
+		req.ClassDate = r.FormValue("ClassDate")
+		req.Override = r.FormValue("Override") == "true"
 	} else {
-		if err := strictDecode(r, &input); err != nil {
+		if err := strictDecode(r, &req); err != nil {
 			http.Error(w, "Invalid request", http.StatusBadRequest)
 			return
 		}
 	}
+	input := orchestrators.CheckInMemberInput{
+		MemberID:   req.MemberID,
+		ScheduleID: req.ScheduleID,
+		ClassDate:  req.ClassDate,
+		LocationID: req.LocationID,
+	}
+	if req.Override {
+		if !hasSession || !isStaffSession(sess) {
+			apierror.Forbidden(w, "only a coach can override class prerequisites")
+			return
+		}
+		input.OverrideBy = sess.AccountID
+	}
+	if input.LocationID == "" {
+		input.LocationID = sessionLocationID(ctx)
+	}
 
 	deps := orchestrators.CheckInMemberDeps{
 		MemberStore:     stores.MemberStore,
 		AttendanceStore: stores.AttendanceStore,
 		ScheduleStore:   stores.ScheduleStore,
+		TopicDeps:       attendanceTopicDeps(),
+		PackDeps:        classPackDeps(),
+		EligibilityDeps: classEligibilityDeps(),
+	}
+	if stores.OccurrenceChangeStore != nil {
+		deps.ChangeStore = stores.OccurrenceChangeStore
 	}
 	if stores.GradingRecordStore != nil && stores.GradingConfigStore != nil {
 		deps.InferStripeDeps = &orchestrators.InferStripeDeps{
@@ -707,13 +798,31 @@ func handlePostCheckinCheckInMember(w http.ResponseWriter, r *http.Request) {
 			EstimatedHoursStore: stores.EstimatedHoursStore,
 			GradingRecordStore:  stores.GradingRecordStore,
 			GradingConfigStore:  stores.GradingConfigStore,
+			StockStore:          stores.BeltInventoryStore,
 		}
 	}
 	err := orchestrators.ExecuteCheckInMember(ctx, input, deps)
+	if errors.Is(err, attendance.ErrDuplicateCheckIn) || errors.Is(err, attendance.ErrOverlappingCheckIn) || errors.Is(err, orchestrators.ErrClassCancelled) {
+		if isHTML {
+			http.Error(w, err.Error(), http.StatusConflict)
+		} else {
+			apierror.Conflict(w, err.Error())
+		}
+		return
+	}
+	if isPrerequisiteError(err) {
+		if isHTML {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			apierror.Forbidden(w, err.Error())
+		}
+		return
+	}
 	if err != nil {
 		internalError(w, err)
 		return
 	}
+	publishAttendance(eventAttendanceCheckIn, attendanceEvent{MemberID: input.MemberID, ScheduleID: input.ScheduleID, ClassDate: input.ClassDate})
 
 	if isHTML {
 		http.Redirect(w, r, "/", http.StatusSeeOther)
@@ -744,7 +853,7 @@ func handleGetAttendanceGetAttendanceToday(w http.ResponseWriter, r *http.Reques
 	}
 
 	dateParam := r.URL.Query().Get("date")
-	query := projections.GetAttendanceTodayQuery{Date: dateParam}
+	query := projections.GetAttendanceTodayQuery{Date: dateParam, LocationID: sessionLocationID(ctx)}
 	deps := projections.GetAttendanceTodayDeps{
 		AttendanceStore:    stores.AttendanceStore,
 		MemberStore:        stores.MemberStore,
@@ -795,7 +904,7 @@ func handleGetAttendanceGetAttendanceToday(w http.ResponseWriter, r *http.Reques
 // Returns today's check-ins for a specific member (used by kiosk for un-check-in).
 func handleMemberAttendanceToday(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if sess, ok := middleware.GetSessionFromContext(r.Context()); ok {
@@ -806,7 +915,7 @@ func handleMemberAttendanceToday(w http.ResponseWriter, r *http.Request) {
 
 	memberID := r.URL.Query().Get("member_id")
 	if memberID == "" {
-		http.Error(w, "member_id is required", http.StatusBadRequest)
+		apierror.Validation(w, "member_id is required")
 		return
 	}
 
@@ -824,11 +933,16 @@ func handleMemberAttendanceToday(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(records)
 }
 
+// attendanceIDRequest names the record acted on by DELETE /api/attendance/undo and POST /api/attendance/checkout.
+type attendanceIDRequest struct {
+	AttendanceID string `json:"AttendanceID"`
+}
+
 // handleUndoCheckIn handles DELETE /api/attendance/undo
 // Removes an attendance record (only today's check-ins).
 func handleUndoCheckIn(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "DELETE" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if sess, ok := middleware.GetSessionFromContext(r.Context()); ok {
@@ -837,28 +951,28 @@ func handleUndoCheckIn(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	var input struct {
-		AttendanceID string `json:"AttendanceID"`
-	}
+	var input attendanceIDRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "Invalid request", http.StatusBadRequest)
+		apierror.Validation(w, "Invalid request")
 		return
 	}
 
 	deps := orchestrators.UndoCheckInDeps{
 		AttendanceStore: stores.AttendanceStore,
+		PackDeps:        classPackDeps(),
 	}
 	err := orchestrators.ExecuteUndoCheckIn(r.Context(), orchestrators.UndoCheckInInput{
 		AttendanceID: input.AttendanceID,
 	}, deps)
 	if err != nil {
 		if err.Error() == "can only undo today's check-ins" || err.Error() == "attendance record not found" {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		internalError(w, err)
 		return
 	}
+	publishAttendance(eventAttendanceUndo, attendanceEvent{})
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -867,7 +981,7 @@ func handleUndoCheckIn(w http.ResponseWriter, r *http.Request) {
 // Sets CheckOutTime and calculates MatHours for an active check-in.
 func handleCheckOut(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if sess, ok := middleware.GetSessionFromContext(r.Context()); ok {
@@ -876,27 +990,25 @@ func handleCheckOut(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	var input struct {
-		AttendanceID string `json:"AttendanceID"`
-	}
+	var input attendanceIDRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "Invalid request", http.StatusBadRequest)
+		apierror.Validation(w, "Invalid request")
 		return
 	}
 	if input.AttendanceID == "" {
-		http.Error(w, "AttendanceID is required", http.StatusBadRequest)
+		apierror.Validation(w, "AttendanceID is required")
 		return
 	}
 
 	ctx := r.Context()
 	record, err := stores.AttendanceStore.GetByID(ctx, input.AttendanceID)
 	if err != nil {
-		http.Error(w, "attendance record not found", http.StatusNotFound)
+		apierror.NotFound(w, "attendance record not found")
 		return
 	}
 
 	if record.IsCheckedOut() {
-		http.Error(w, "already checked out", http.StatusConflict)
+		apierror.Conflict(w, "already checked out")
 		return
 	}
 
@@ -905,7 +1017,7 @@ func handleCheckOut(w http.ResponseWriter, r *http.Request) {
 	record.MatHours = now.Sub(record.CheckInTime).Hours()
 
 	if err := record.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
@@ -918,23 +1030,27 @@ func handleCheckOut(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(record)
 }
 
+// estimatedHoursCreateRequest is the body of POST /api/estimated-hours.
+type estimatedHoursCreateRequest struct {
+	MemberID    string  `json:"MemberID"`
+	StartDate   string  `json:"StartDate"`
+	EndDate     string  `json:"EndDate"`
+	WeeklyHours float64 `json:"WeeklyHours"`
+	Note        string  `json:"Note"`
+	OverlapMode string  `json:"OverlapMode"`
+}
+
 // handleEstimatedHours handles GET/POST for /api/estimated-hours
 func handleEstimatedHours(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	if r.Method == "GET" {
-		sess, ok := middleware.GetSessionFromContext(ctx)
-		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
-			return
-		}
-		if sess.Role != "admin" && sess.Role != "coach" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+		if _, ok := requirePermission(w, r, permissionDomain.ActionTrainingHoursReview); !ok {
 			return
 		}
 		memberID := r.URL.Query().Get("member_id")
 		if memberID == "" {
-			http.Error(w, "member_id is required", http.StatusBadRequest)
+			apierror.Validation(w, "member_id is required")
 			return
 		}
 		entries, err := stores.EstimatedHoursStore.ListByMemberID(ctx, memberID)
@@ -952,25 +1068,13 @@ func handleEstimatedHours(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "POST" {
-		sess, ok := middleware.GetSessionFromContext(ctx)
+		sess, ok := requirePermission(w, r, permissionDomain.ActionTrainingHoursReview)
 		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
-			return
-		}
-		if sess.Role != "admin" && sess.Role != "coach" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
 			return
 		}
-		var input struct {
-			MemberID    string  `json:"MemberID"`
-			StartDate   string  `json:"StartDate"`
-			EndDate     string  `json:"EndDate"`
-			WeeklyHours float64 `json:"WeeklyHours"`
-			Note        string  `json:"Note"`
-			OverlapMode string  `json:"OverlapMode"`
-		}
+		var input estimatedHoursCreateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		orchInput := orchestrators.BulkAddEstimatedHoursInput{
@@ -990,7 +1094,7 @@ func handleEstimatedHours(w http.ResponseWriter, r *http.Request) {
 		}
 		entry, err := orchestrators.ExecuteBulkAddEstimatedHours(ctx, orchInput, orchDeps)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -1002,16 +1106,16 @@ func handleEstimatedHours(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "DELETE" {
 		sess, ok := middleware.GetSessionFromContext(ctx)
 		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
+			apierror.Unauthorized(w, "not authenticated")
 			return
 		}
 		if sess.Role != "admin" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+			apierror.Forbidden(w, "Forbidden")
 			return
 		}
 		id := r.URL.Query().Get("id")
 		if id == "" {
-			http.Error(w, "id is required", http.StatusBadRequest)
+			apierror.Validation(w, "id is required")
 			return
 		}
 		if err := stores.EstimatedHoursStore.Delete(ctx, id); err != nil {
@@ -1022,29 +1126,23 @@ func handleEstimatedHours(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
 }
 
 // handleEstimatedHoursCheckOverlap handles GET /api/estimated-hours/check-overlap
 func handleEstimatedHoursCheckOverlap(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
-	sess, ok := middleware.GetSessionFromContext(r.Context())
-	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if _, ok := requirePermission(w, r, permissionDomain.ActionTrainingHoursReview); !ok {
 		return
 	}
 	memberID := r.URL.Query().Get("member_id")
 	startDate := r.URL.Query().Get("start_date")
 	endDate := r.URL.Query().Get("end_date")
 	if memberID == "" || startDate == "" || endDate == "" {
-		http.Error(w, "member_id, start_date, and end_date are required", http.StatusBadRequest)
+		apierror.Validation(w, "member_id, start_date, and end_date are required")
 		return
 	}
 	result, err := orchestrators.CheckEstimatedHoursOverlap(r.Context(), memberID, startDate, endDate, stores.AttendanceStore)
@@ -1056,31 +1154,38 @@ func handleEstimatedHoursCheckOverlap(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(result)
 }
 
+// selfEstimateRequest is the body of POST /api/self-estimates.
+type selfEstimateRequest struct {
+	StartDate   string  `json:"StartDate"`
+	EndDate     string  `json:"EndDate"`
+	WeeklyHours float64 `json:"WeeklyHours"`
+	Note        string  `json:"Note"`
+}
+
 // handleSelfEstimates handles POST /api/self-estimates ΓÇö member submits a self-estimate.
 func handleSelfEstimates(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		handleMySelfEstimates(w, r)
+		return
+	}
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	sess, ok := middleware.GetSessionFromContext(r.Context())
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	// Look up the member record for the logged-in user
 	m, err := stores.MemberStore.GetByEmail(r.Context(), sess.Email)
 	if err != nil {
-		http.Error(w, "no member record found for this account", http.StatusForbidden)
+		apierror.Forbidden(w, "no member record found for this account")
 		return
 	}
-	var input struct {
-		StartDate   string  `json:"StartDate"`
-		EndDate     string  `json:"EndDate"`
-		WeeklyHours float64 `json:"WeeklyHours"`
-		Note        string  `json:"Note"`
-	}
+	var input selfEstimateRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 	orchInput := orchestrators.SubmitSelfEstimateInput{
@@ -1092,12 +1197,13 @@ func handleSelfEstimates(w http.ResponseWriter, r *http.Request) {
 	}
 	orchDeps := orchestrators.SubmitSelfEstimateDeps{
 		EstimatedHoursStore: stores.EstimatedHoursStore,
+		ReviewLog:           stores.EstimatedHoursStore,
 		GenerateID:          generateID,
 		Now:                 timeNow,
 	}
 	entry, err := orchestrators.ExecuteSubmitSelfEstimate(r.Context(), orchInput, orchDeps)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -1105,19 +1211,27 @@ func handleSelfEstimates(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(entry)
 }
 
+// pendingEntry is one self-estimate still under review in GET /api/self-estimates/pending.
+type pendingEntry struct {
+	ID          string  `json:"ID"`
+	MemberID    string  `json:"MemberID"`
+	MemberName  string  `json:"MemberName"`
+	StartDate   string  `json:"StartDate"`
+	EndDate     string  `json:"EndDate"`
+	WeeklyHours float64 `json:"WeeklyHours"`
+	TotalHours  float64 `json:"TotalHours"`
+	Status      string  `json:"Status"` // pending, or needs_info while waiting on the member
+	Note        string  `json:"Note"`
+	CreatedAt   string  `json:"CreatedAt"`
+}
+
 // handleSelfEstimatesPending handles GET /api/self-estimates/pending ΓÇö admin/coach review queue.
 func handleSelfEstimatesPending(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		return
-	}
-	sess, ok := middleware.GetSessionFromContext(r.Context())
-	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.MethodNotAllowed(w)
 		return
 	}
-	if sess.Role != "admin" && sess.Role != "coach" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if _, ok := requirePermission(w, r, permissionDomain.ActionTrainingHoursReview); !ok {
 		return
 	}
 	entries, err := stores.EstimatedHoursStore.ListPending(r.Context())
@@ -1126,17 +1240,6 @@ func handleSelfEstimatesPending(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// Enrich with member names
-	type pendingEntry struct {
-		ID          string  `json:"ID"`
-		MemberID    string  `json:"MemberID"`
-		MemberName  string  `json:"MemberName"`
-		StartDate   string  `json:"StartDate"`
-		EndDate     string  `json:"EndDate"`
-		WeeklyHours float64 `json:"WeeklyHours"`
-		TotalHours  float64 `json:"TotalHours"`
-		Note        string  `json:"Note"`
-		CreatedAt   string  `json:"CreatedAt"`
-	}
 	result := make([]pendingEntry, 0, len(entries))
 	for _, e := range entries {
 		name := ""
@@ -1151,6 +1254,7 @@ func handleSelfEstimatesPending(w http.ResponseWriter, r *http.Request) {
 			EndDate:     e.EndDate,
 			WeeklyHours: e.WeeklyHours,
 			TotalHours:  e.TotalHours,
+			Status:      e.Status,
 			Note:        e.Note,
 			CreatedAt:   e.CreatedAt.Format(time.RFC3339),
 		})
@@ -1163,33 +1267,31 @@ func handleSelfEstimatesPending(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(result)
 }
 
-// handleSelfEstimatesReview handles POST /api/self-estimates/review ΓÇö admin/coach approves or rejects.
+// selfEstimateReviewRequest is the body of POST /api/self-estimates/review.
+type selfEstimateReviewRequest struct {
+	ID            string  `json:"ID"`
+	Action        string  `json:"Action"`
+	AdjustedHours float64 `json:"AdjustedHours"`
+	ReviewNote    string  `json:"ReviewNote"`
+}
+
+// handleSelfEstimatesReview handles POST /api/self-estimates/review ΓÇö admin/coach approves, rejects or asks for more info.
 func handleSelfEstimatesReview(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
-	sess, ok := middleware.GetSessionFromContext(r.Context())
+	sess, ok := requirePermission(w, r, permissionDomain.ActionTrainingHoursReview)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
-	var input struct {
-		ID            string  `json:"ID"`
-		Action        string  `json:"Action"`
-		AdjustedHours float64 `json:"AdjustedHours"`
-		ReviewNote    string  `json:"ReviewNote"`
-	}
+	var input selfEstimateReviewRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
-	if input.ID == "" || (input.Action != "approve" && input.Action != "reject") {
-		http.Error(w, "ID and Action (approve/reject) are required", http.StatusBadRequest)
+	if input.ID == "" || (input.Action != "approve" && input.Action != "reject" && input.Action != "needs_info") {
+		apierror.Validation(w, "ID and Action (approve/reject/needs_info) are required")
 		return
 	}
 	orchInput := orchestrators.ReviewSelfEstimateInput{
@@ -1201,13 +1303,16 @@ func handleSelfEstimatesReview(w http.ResponseWriter, r *http.Request) {
 	}
 	orchDeps := orchestrators.ReviewSelfEstimateDeps{
 		EstimatedHoursStore: stores.EstimatedHoursStore,
+		ReviewLog:           stores.EstimatedHoursStore,
+		GenerateID:          generateID,
 		Now:                 timeNow,
 	}
 	entry, err := orchestrators.ExecuteReviewSelfEstimate(r.Context(), orchInput, orchDeps)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
+	notifySelfEstimateReviewed(r.Context(), entry)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(entry)
 }
@@ -1223,13 +1328,162 @@ func handleSelfEstimatesPage(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/login", http.StatusSeeOther)
 		return
 	}
-	if sess.Role != "admin" && sess.Role != "coach" {
+	if !permissionAllowed(r.Context(), sess, permissionDomain.ActionTrainingHoursReview) {
 		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
 	renderTemplate(w, r, "admin_self_estimates.html", nil)
 }
 
+// selfEstimateView is one of the member's self-estimates in GET /api/self-estimates.
+type selfEstimateView struct {
+	estimatedHoursDomain.EstimatedHours
+	SubmittedHours float64                            `json:"SubmittedHours"` // total before any reviewer adjustment
+	History        []estimatedHoursDomain.ReviewEvent `json:"History"`        // oldest first
+}
+
+// selfEstimateRespondRequest is the body of POST /api/self-estimates/respond.
+type selfEstimateRespondRequest struct {
+	ID       string `json:"ID"`
+	Response string `json:"Response"`
+}
+
+// handleMySelfEstimates handles GET /api/self-estimates
+// Lists the member's own self-estimates with their review status and history.
+func handleMySelfEstimates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	m, err := stores.MemberStore.GetByEmail(ctx, sess.Email)
+	if err != nil {
+		apierror.Forbidden(w, "no member record found for this account")
+		return
+	}
+	entries, err := stores.EstimatedHoursStore.ListByMemberID(ctx, m.ID)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	views := make([]selfEstimateView, 0, len(entries))
+	for _, e := range entries {
+		if e.Source != estimatedHoursDomain.SourceSelfEstimate {
+			continue
+		}
+		history, err := stores.EstimatedHoursStore.ListReviewEvents(ctx, e.ID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if history == nil {
+			history = []estimatedHoursDomain.ReviewEvent{}
+		}
+		views = append(views, selfEstimateView{EstimatedHours: e, SubmittedHours: e.SubmittedHours(), History: history})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(views)
+}
+
+// handleSelfEstimateRespond handles POST /api/self-estimates/respond
+// The member answers a reviewer's question, which puts the estimate back in the review queue.
+func handleSelfEstimateRespond(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	m, err := stores.MemberStore.GetByEmail(ctx, sess.Email)
+	if err != nil {
+		apierror.Forbidden(w, "no member record found for this account")
+		return
+	}
+	var input selfEstimateRespondRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	entry, err := orchestrators.ExecuteRespondSelfEstimate(ctx, orchestrators.RespondSelfEstimateInput{
+		ID:       input.ID,
+		MemberID: m.ID,
+		Response: input.Response,
+	}, orchestrators.RespondSelfEstimateDeps{
+		EstimatedHoursStore: stores.EstimatedHoursStore,
+		ReviewLog:           stores.EstimatedHoursStore,
+		GenerateID:          generateID,
+		Now:                 timeNow,
+	})
+	if errors.Is(err, orchestrators.ErrSelfEstimateNotFound) {
+		apierror.NotFound(w, err.Error())
+		return
+	}
+	if err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry)
+}
+
+// handleSelfEstimateHistory handles GET /api/self-estimates/history?id=
+// Returns the review history of one self-estimate for reviewers.
+func handleSelfEstimateHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	if _, ok := requirePermission(w, r, permissionDomain.ActionTrainingHoursReview); !ok {
+		return
+	}
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		apierror.Validation(w, "id is required")
+		return
+	}
+	history, err := stores.EstimatedHoursStore.ListReviewEvents(r.Context(), id)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if history == nil {
+		w.Write([]byte("[]"))
+		return
+	}
+	json.NewEncoder(w).Encode(history)
+}
+
+// notifySelfEstimateReviewed tells the member when a reviewer asks them a question
+// or approves a different total from the one they submitted.
+func notifySelfEstimateReviewed(ctx context.Context, e estimatedHoursDomain.EstimatedHours) {
+	input := orchestrators.NotifyInput{Kind: notificationDomain.KindHoursReviewed, Link: "/training-log"}
+	switch {
+	case e.Status == estimatedHoursDomain.StatusNeedsInfo:
+		input.Title = "Question about your estimated hours"
+		input.Body = e.ReviewNote
+	case e.Adjusted():
+		input.Title = "Your estimated hours were adjusted"
+		input.Body = fmt.Sprintf("%s to %s was approved for %sh; you submitted %sh.", e.StartDate, e.EndDate, formatHours(e.TotalHours), formatHours(e.SubmittedHours()))
+		if e.ReviewNote != "" {
+			input.Body += " " + e.ReviewNote
+		}
+	default:
+		return
+	}
+	notifyMember(ctx, e.MemberID, input)
+}
+
+// formatHours prints hours without trailing zeros, e.g. 12 or 7.5.
+func formatHours(h float64) string {
+	return strconv.FormatFloat(h, 'f', -1, 64)
+}
+
 // handlePostInjuriesReportInjury handles POST /injuries
 func handlePostInjuriesReportInjury(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -1250,6 +1504,7 @@ func handlePostInjuriesReportInjury(w http.ResponseWriter, r *http.Request) {
 		input.MemberID = r.FormValue("MemberID")
 		input.BodyPart = strings.ToLower(r.FormValue("BodyPart"))
 		input.Description = r.FormValue("Description")
+		input.Severity = strings.ToLower(r.FormValue("Severity"))
 	} else {
 		if err := strictDecode(r, &input); err != nil {
 			http.Error(w, "Invalid request", http.StatusBadRequest)
@@ -1294,19 +1549,30 @@ func handlePostWaiversSignWaiver(w http.ResponseWriter, r *http.Request) {
 		input.MemberName = r.FormValue("MemberName")
 		input.Email = r.FormValue("Email")
 		input.AcceptedTerms = r.FormValue("AcceptedTerms") == "true"
+		input.SignatureImage = r.FormValue("SignatureImage")
+		input.GuardianName = r.FormValue("GuardianName")
+		input.GuardianRelationship = r.FormValue("GuardianRelationship")
+		input.GuardianEmail = r.FormValue("GuardianEmail")
+		input.GuardianPhone = r.FormValue("GuardianPhone")
 	} else {
 		if err := strictDecode(r, &input); err != nil {
 			http.Error(w, "Invalid request", http.StatusBadRequest)
 			return
 		}
 	}
+	input.IPAddress = r.RemoteAddr
 
 	deps := orchestrators.SignWaiverDeps{
 		WaiverStore: stores.WaiverStore,
 		MemberStore: stores.MemberStore,
 	}
 	err := orchestrators.ExecuteSignWaiver(ctx, input, deps)
-	if err != nil {
+	switch {
+	case errors.Is(err, waiverDomain.ErrGuardianRequired), errors.Is(err, waiverDomain.ErrGuardianIncomplete),
+		errors.Is(err, waiverDomain.ErrSignatureRequired), errors.Is(err, waiverDomain.ErrInvalidSignature):
+		apierror.Validation(w, err.Error())
+		return
+	case err != nil:
 		internalError(w, err)
 		return
 	}
@@ -1354,7 +1620,9 @@ func handleGetWaiverForm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	renderTemplate(w, r, "form_sign_waiver.html", map[string]any{
-		"CSRFToken": csrf.Token(r),
+		"CSRFToken":   csrf.Token(r),
+		"WaiverTitle": waiverDomain.Title,
+		"WaiverTerms": waiverDomain.Terms,
 	})
 }
 
@@ -1373,7 +1641,7 @@ func handleGetMemberProfile(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "not authenticated", http.StatusUnauthorized)
 		return
 	}
-	if sess.Role != "admin" && sess.Role != "coach" {
+	if !permissionAllowed(r.Context(), sess, permissionDomain.ActionMembersView) {
 		if isHTMLRequest(r) {
 			http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
 			return
@@ -1431,6 +1699,7 @@ func handleLogin(w http.ResponseWriter, r *http.Request) {
 		}
 		renderTemplate(w, r, "login.html", map[string]any{
 			"CSRFToken": csrf.Token(r),
+			"SSOName":   ssoName(),
 		})
 		return
 	}
@@ -1454,23 +1723,15 @@ func handleLogin(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			renderTemplate(w, r, "login.html", map[string]any{
 				"CSRFToken": csrf.Token(r),
-				"Error":     err.Error(),
+				"SSOName":   ssoName(),
+				"Error":     i18n.Message(i18n.FromContext(r.Context()), err.Error()),
 			})
 			return
 		}
 
-		// Create session
-		betaTester := false
-		if acct, err := stores.AccountStore.GetByID(r.Context(), result.AccountID); err == nil {
-			betaTester = acct.BetaTester
-		}
-		token, err := sessions.Create(result.AccountID, result.Email, result.Role, result.PasswordChangeRequired, betaTester)
-		if err != nil {
-			http.Error(w, "Session error", http.StatusInternalServerError)
+		if !startSession(w, r, result) {
 			return
 		}
-
-		middleware.SetSessionCookie(w, token)
 		if result.PasswordChangeRequired {
 			http.Redirect(w, r, "/change-password", http.StatusSeeOther)
 			return
@@ -1482,6 +1743,149 @@ func handleLogin(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusMethodNotAllowed)
 }
 
+// startSession creates a session for a successful login and sets its cookie.
+// Location-scoped accounts start with their own location selected, and every
+// account with a chosen language keeps it across devices.
+// PRE: result came from a successful login
+// POST: The session cookie is set, or a 500 is written and false returned
+func startSession(w http.ResponseWriter, r *http.Request, result orchestrators.LoginResult) bool {
+	betaTester := false
+	locationID := ""
+	locale := ""
+	if acct, err := stores.AccountStore.GetByID(r.Context(), result.AccountID); err == nil {
+		betaTester = acct.BetaTester
+		locationID = acct.LocationID
+		locale = acct.Locale
+	}
+	token, err := sessions.Create(r.Context(), result.AccountID, result.Email, result.Role, result.PasswordChangeRequired, betaTester)
+	if err != nil {
+		http.Error(w, "Session error", http.StatusInternalServerError)
+		return false
+	}
+	if locationID != "" || locale != "" {
+		if sess, ok := sessions.Get(r.Context(), token); ok {
+			sess.LocationID = locationID
+			sess.Locale = locale
+			sessions.Update(r.Context(), token, sess)
+		}
+	}
+	middleware.SetSessionCookie(w, token)
+	return true
+}
+
+// ssoName is the sign-in provider shown on the login page, or "" when single sign-on is off.
+func ssoName() string {
+	if ssoProvider == nil {
+		return ""
+	}
+	return ssoProvider.Name()
+}
+
+// oidcCookieName holds the state, nonce and PKCE verifier of a sign-in in flight.
+const oidcCookieName = "workshop_oidc"
+
+// handleOIDCStart handles GET /auth/oidc/start
+// Sends the browser to the single sign-on provider. 404 when single sign-on is off.
+func handleOIDCStart(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if ssoProvider == nil {
+		http.NotFound(w, r)
+		return
+	}
+	state, nonce, verifier := oidc.RandomToken(), oidc.RandomToken(), oidc.RandomToken()
+	authURL, err := ssoProvider.AuthURL(r.Context(), state, nonce, verifier)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "security_event", "event", "sso_start_failed", "error", err)
+		renderSSOError(w, r, http.StatusBadGateway, i18n.T(i18n.FromContext(r.Context()), "login.sso_failed", ssoProvider.Name()))
+		return
+	}
+	// Lax, not Strict: the provider redirects back cross-site and the callback needs this cookie.
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookieName,
+		Value:    state + "." + nonce + "." + verifier,
+		HttpOnly: true,
+		Secure:   middleware.SecureCookies,
+		SameSite: http.SameSiteLaxMode,
+		Path:     "/auth/oidc/",
+		MaxAge:   600,
+	})
+	http.Redirect(w, r, authURL, http.StatusSeeOther)
+}
+
+// handleOIDCCallback handles GET /auth/oidc/callback
+// Verifies the provider's answer and signs in the account with the same verified email.
+// Nobody gets an account this way; unknown emails are turned away.
+func handleOIDCCallback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if ssoProvider == nil {
+		http.NotFound(w, r)
+		return
+	}
+	locale := i18n.FromContext(r.Context())
+	failed := i18n.T(locale, "login.sso_failed", ssoProvider.Name())
+
+	var state, nonce, verifier string
+	if cookie, err := r.Cookie(oidcCookieName); err == nil {
+		if parts := strings.Split(cookie.Value, "."); len(parts) == 3 {
+			state, nonce, verifier = parts[0], parts[1], parts[2]
+		}
+	}
+	http.SetCookie(w, &http.Cookie{Name: oidcCookieName, Value: "", HttpOnly: true, Secure: middleware.SecureCookies, SameSite: http.SameSiteLaxMode, Path: "/auth/oidc/", MaxAge: -1})
+
+	query := r.URL.Query()
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(query.Get("state"))) != 1 {
+		slog.WarnContext(r.Context(), "security_event", "event", "sso_state_mismatch", "ip", middleware.ClientIP(r))
+		renderSSOError(w, r, http.StatusBadRequest, failed)
+		return
+	}
+	if providerErr := query.Get("error"); providerErr != "" || query.Get("code") == "" {
+		// access_denied is the member pressing cancel; anything else is worth a look.
+		slog.InfoContext(r.Context(), "security_event", "event", "sso_declined", "error", providerErr)
+		renderSSOError(w, r, http.StatusOK, failed)
+		return
+	}
+	identity, err := ssoProvider.Exchange(r.Context(), query.Get("code"), verifier, nonce)
+	if err != nil {
+		slog.WarnContext(r.Context(), "security_event", "event", "sso_exchange_failed", "ip", middleware.ClientIP(r), "error", err)
+		renderSSOError(w, r, http.StatusBadGateway, failed)
+		return
+	}
+	result, err := orchestrators.ExecuteSSOLogin(r.Context(), orchestrators.SSOLoginInput{
+		Provider:      identity.Issuer,
+		Subject:       identity.Subject,
+		Email:         identity.Email,
+		EmailVerified: identity.EmailVerified,
+	}, orchestrators.LoginDeps{AccountStore: stores.AccountStore})
+	if err != nil {
+		renderSSOError(w, r, http.StatusOK, i18n.Message(locale, err.Error()))
+		return
+	}
+	if !startSession(w, r, result) {
+		return
+	}
+	// The session cookie is SameSite=Strict, so a redirect chained from the provider's
+	// cross-site hop would arrive without it. Navigating from our own page sends it.
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, `<!DOCTYPE html><meta http-equiv="refresh" content="0;url=/dashboard"><a href="/dashboard">Continue</a>`)
+}
+
+// renderSSOError shows the login page with a single sign-on failure.
+func renderSSOError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	renderTemplate(w, r, "login.html", map[string]any{
+		"CSRFToken": csrf.Token(r),
+		"SSOName":   ssoName(),
+		"Error":     message,
+	})
+}
+
 // handleLogout handles POST /logout
 func handleLogout(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
@@ -1492,8 +1896,37 @@ func handleLogout(w http.ResponseWriter, r *http.Request) {
 	// Delete session
 	cookie, err := r.Cookie("workshop_session")
 	if err == nil {
-		sessions.Delete(cookie.Value)
+		sessions.Delete(r.Context(), cookie.Value)
+	}
+
+	middleware.ClearSessionCookie(w)
+	http.Redirect(w, r, "/login", http.StatusSeeOther)
+}
+
+// handleLogoutAll handles POST /logout/all
+// Signs the account out on every device, including this one. An impersonating admin
+// signs out their own account.
+func handleLogoutAll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	accountID := sess.AccountID
+	if sess.IsImpersonating() {
+		accountID = sess.RealAccountID
+	}
+	revoked, err := sessions.RevokeAccount(r.Context(), accountID)
+	if err != nil {
+		internalError(w, err)
+		return
 	}
+	slog.InfoContext(r.Context(), "security_event", "event", "logout_all", "account_id", accountID, "sessions", revoked)
 
 	middleware.ClearSessionCookie(w)
 	http.Redirect(w, r, "/login", http.StatusSeeOther)
@@ -1554,7 +1987,7 @@ func handleChangePassword(w http.ResponseWriter, r *http.Request) {
 		cookie, err := r.Cookie("workshop_session")
 		if err == nil {
 			session.PasswordChangeRequired = false
-			sessions.Update(cookie.Value, session)
+			sessions.Update(r.Context(), cookie.Value, session)
 		}
 
 		http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
@@ -1565,13 +1998,15 @@ func handleChangePassword(w http.ResponseWriter, r *http.Request) {
 }
 
 // handleMemberSearch handles GET /api/members/search?q=<name>
+// Returns member cards with belt, stripes and initials; staff also see an active-injury flag.
 func handleMemberSearch(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
-	if sess, ok := middleware.GetSessionFromContext(r.Context()); ok {
+	sess, hasSession := middleware.GetSessionFromContext(r.Context())
+	if hasSession {
 		if !requireFeatureAPI(w, r, sess, "member_mgmt") {
 			return
 		}
@@ -1584,7 +2019,15 @@ func handleMemberSearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	results, err := stores.MemberStore.SearchByName(r.Context(), query, 10)
+	results, err := projections.QueryGetMemberCards(r.Context(), projections.GetMemberCardsQuery{
+		Name:            query,
+		Limit:           10,
+		IncludeInjuries: hasSession && isStaffSession(sess),
+	}, projections.GetMemberCardsDeps{
+		MemberStore:        stores.MemberStore,
+		GradingRecordStore: stores.GradingRecordStore,
+		InjuryStore:        stores.InjuryStore,
+	})
 	if err != nil {
 		internalError(w, err)
 		return
@@ -1597,7 +2040,7 @@ func handleMemberSearch(w http.ResponseWriter, r *http.Request) {
 // handleArchiveMember handles POST /api/members/archive
 func handleArchiveMember(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
@@ -1617,7 +2060,7 @@ func handleArchiveMember(w http.ResponseWriter, r *http.Request) {
 
 	deps := orchestrators.ArchiveMemberDeps{MemberStore: stores.MemberStore}
 	if err := orchestrators.ExecuteArchiveMember(r.Context(), input, deps); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
@@ -1627,7 +2070,7 @@ func handleArchiveMember(w http.ResponseWriter, r *http.Request) {
 // handleRestoreMember handles POST /api/members/restore
 func handleRestoreMember(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
@@ -1647,7 +2090,7 @@ func handleRestoreMember(w http.ResponseWriter, r *http.Request) {
 
 	deps := orchestrators.RestoreMemberDeps{MemberStore: stores.MemberStore}
 	if err := orchestrators.ExecuteRestoreMember(r.Context(), input, deps); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
@@ -1657,7 +2100,7 @@ func handleRestoreMember(w http.ResponseWriter, r *http.Request) {
 // handleGuestCheckIn handles POST /api/guest/checkin
 func handleGuestCheckIn(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
@@ -1666,23 +2109,40 @@ func handleGuestCheckIn(w http.ResponseWriter, r *http.Request) {
 		r.ParseForm()
 		input.Name = r.FormValue("Name")
 		input.Email = r.FormValue("Email")
+		input.HomeGym = r.FormValue("HomeGym")
 		input.AcceptedTerms = r.FormValue("AcceptedTerms") == "true"
 		input.ScheduleID = r.FormValue("ScheduleID")
 		input.ClassDate = r.FormValue("ClassDate")
+		input.ReferredBy = orchestrators.ReferredBy{MemberID: r.FormValue("ReferredByMemberID"), Name: r.FormValue("ReferredByName")}
 	} else {
 		strictDecode(r, &input)
 	}
 	input.IPAddress = r.RemoteAddr
+	input.SenderID = ""
 
 	deps := orchestrators.GuestCheckInDeps{
 		MemberStore:     stores.MemberStore,
 		WaiverStore:     stores.WaiverStore,
 		AttendanceStore: stores.AttendanceStore,
+		ReferralStore:   referralStoreFor(r.Context()),
+	}
+	if deps.ReferralStore == nil {
+		input.ReferredBy = orchestrators.ReferredBy{}
+	} else if err := checkReferrer(r.Context(), input.ReferredBy); err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	// Visitors are remembered when the kiosk's account has the feature; the follow-up
+	// email goes out in that account's name.
+	if sess, ok := middleware.GetSessionFromContext(r.Context()); ok && stores.VisitorStore != nil && featureEnabledForSession(r.Context(), sess, "visitors") {
+		deps.VisitorStore = stores.VisitorStore
+		deps.EmailStore = stores.EmailStore
+		input.SenderID = sess.AccountID
 	}
 
 	result, err := orchestrators.ExecuteGuestCheckIn(r.Context(), input, deps)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
@@ -1693,7 +2153,7 @@ func handleGuestCheckIn(w http.ResponseWriter, r *http.Request) {
 // handleTodaysClasses handles GET /api/classes/today
 func handleTodaysClasses(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
@@ -1703,9 +2163,10 @@ func handleTodaysClasses(w http.ResponseWriter, r *http.Request) {
 		HolidayStore:   stores.HolidayStore,
 		ClassTypeStore: stores.ClassTypeStore,
 		ProgramStore:   stores.ProgramStore,
+		ChangeStore:    stores.OccurrenceChangeStore,
 	}
 
-	results, err := projections.QueryGetTodaysClasses(r.Context(), timeNow(), deps)
+	results, err := projections.QueryGetTodaysClassesAtLocation(r.Context(), timeNow(), sessionLocationID(r.Context()), deps)
 	if err != nil {
 		internalError(w, err)
 		return
@@ -1719,25 +2180,44 @@ func handleTodaysClasses(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(results)
 }
 
+// kioskLaunchRequest is the optional body of POST /api/kiosk/launch.
+type kioskLaunchRequest struct {
+	DeviceID   string `json:"DeviceID"`   // registered device relaunching kiosk mode
+	DeviceName string `json:"DeviceName"` // registers this device under a name if DeviceID is empty or unknown
+}
+
 // handleKioskLaunch handles POST /api/kiosk/launch
 func handleKioskLaunch(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
 	sess, ok := middleware.GetSessionFromContext(r.Context())
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+
+	// The body is optional: without a device the kiosk runs unregistered.
+	var req kioskLaunchRequest
+	if err := strictDecode(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 
-	deps := orchestrators.LaunchKioskDeps{AccountStore: stores.AccountStore}
-	input := orchestrators.LaunchKioskInput{AccountID: sess.AccountID}
+	deps := orchestrators.LaunchKioskDeps{AccountStore: stores.AccountStore, DeviceStore: stores.KioskDeviceStore, Now: timeNow}
+	input := orchestrators.LaunchKioskInput{
+		AccountID:     sess.AccountID,
+		LocationID:    sess.LocationID,
+		DeviceID:      req.DeviceID,
+		DeviceName:    req.DeviceName,
+		AuthSessionID: sess.ID,
+	}
 
 	session, err := orchestrators.ExecuteLaunchKiosk(r.Context(), input, deps)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusForbidden)
+		apierror.Forbidden(w, err.Error())
 		return
 	}
 
@@ -1748,7 +2228,7 @@ func handleKioskLaunch(w http.ResponseWriter, r *http.Request) {
 // handleKioskExit handles POST /api/kiosk/exit
 func handleKioskExit(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
@@ -1757,13 +2237,21 @@ func handleKioskExit(w http.ResponseWriter, r *http.Request) {
 		r.ParseForm()
 		input.AccountID = r.FormValue("AccountID")
 		input.Password = r.FormValue("Password")
+		input.DeviceID = r.FormValue("DeviceID")
+		input.PIN = r.FormValue("PIN")
 	} else {
 		strictDecode(r, &input)
 	}
+	// The password is the launching account's; the kiosk runs under its session.
+	if input.AccountID == "" {
+		if sess, ok := middleware.GetSessionFromContext(r.Context()); ok {
+			input.AccountID = sess.AccountID
+		}
+	}
 
-	deps := orchestrators.ExitKioskDeps{AccountStore: stores.AccountStore}
+	deps := orchestrators.ExitKioskDeps{AccountStore: stores.AccountStore, DeviceStore: stores.KioskDeviceStore, Now: timeNow}
 	if err := orchestrators.ExecuteExitKiosk(r.Context(), input, deps); err != nil {
-		http.Error(w, err.Error(), http.StatusForbidden)
+		apierror.Forbidden(w, err.Error())
 		return
 	}
 
@@ -1772,15 +2260,19 @@ func handleKioskExit(w http.ResponseWriter, r *http.Request) {
 
 // --- Layer 1b: Engagement API Handlers ---
 
-// handleGetTrainingLog handles GET /api/training-log?member_id=<id>
+// maxTrainingLogLimit caps the entries one GET /api/training-log page returns.
+const maxTrainingLogLimit = 500
+
+// handleGetTrainingLog handles GET /api/training-log?member_id=<id>&from=&to=&limit=&offset=
+// Totals cover the member's whole history; entries are one page, newest first.
 func handleGetTrainingLog(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	sess, ok := middleware.GetSessionFromContext(r.Context())
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "training_log") {
@@ -1791,27 +2283,51 @@ func handleGetTrainingLog(w http.ResponseWriter, r *http.Request) {
 	if sess.Role == accountDomain.RoleMember || sess.Role == accountDomain.RoleTrial {
 		m, err := stores.MemberStore.GetByEmail(r.Context(), sess.Email)
 		if err != nil {
-			http.Error(w, "member not found", http.StatusForbidden)
+			apierror.Forbidden(w, "member not found")
 			return
 		}
 		if memberID != "" && memberID != m.ID {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+			apierror.Forbidden(w, "Forbidden")
 			return
 		}
 		memberID = m.ID
 	}
 	if memberID == "" {
-		http.Error(w, "member_id is required", http.StatusBadRequest)
+		apierror.Validation(w, "member_id is required")
 		return
 	}
 
-	query := projections.GetTrainingLogQuery{MemberID: memberID}
+	q := r.URL.Query()
+	query := projections.GetTrainingLogQuery{MemberID: memberID, From: q.Get("from"), To: q.Get("to")}
+	for _, d := range []string{query.From, query.To} {
+		if _, err := time.Parse("2006-01-02", d); d != "" && err != nil {
+			apierror.Validation(w, "from and to must be YYYY-MM-DD")
+			return
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxTrainingLogLimit {
+			apierror.Validation(w, fmt.Sprintf("limit must be between 1 and %d", maxTrainingLogLimit))
+			return
+		}
+		query.Limit = n
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			apierror.Validation(w, "offset must be a whole number")
+			return
+		}
+		query.Offset = n
+	}
 	deps := projections.GetTrainingLogDeps{
 		AttendanceStore:     stores.AttendanceStore,
 		MemberStore:         stores.MemberStore,
 		GradingRecordStore:  stores.GradingRecordStore,
 		GradingConfigStore:  stores.GradingConfigStore,
 		EstimatedHoursStore: stores.EstimatedHoursStore,
+		ObservationStore:    stores.ObservationStore,
 	}
 	result, err := projections.QueryGetTrainingLog(r.Context(), query, deps)
 	if err != nil {
@@ -1825,23 +2341,13 @@ func handleGetTrainingLog(w http.ResponseWriter, r *http.Request) {
 			result.GradingMetric = memberDomain.MetricSessions
 		}
 		kidsQuery := projections.GetKidsTermReadinessQuery{Now: time.Now()}
-		kidsDeps := projections.GetKidsTermReadinessDeps{
-			TermStore:          stores.TermStore,
-			ProgramStore:       stores.ProgramStore,
-			ClassTypeStore:     stores.ClassTypeStore,
-			ScheduleStore:      stores.ScheduleStore,
-			HolidayStore:       stores.HolidayStore,
-			MemberStore:        stores.MemberStore,
-			AttendanceStore:    stores.AttendanceStore,
-			GradingRecordStore: stores.GradingRecordStore,
-			GradingConfigStore: stores.GradingConfigStore,
-		}
-		kidsResult, err := projections.QueryGetKidsTermReadiness(r.Context(), kidsQuery, kidsDeps)
+		kidsResult, err := projections.QueryGetKidsTermReadiness(r.Context(), kidsQuery, kidsTermReadinessDeps(stores))
 		if err == nil {
 			result.TermName = kidsResult.TermName
 			for _, e := range kidsResult.Entries {
 				if e.MemberID == memberID {
 					result.TermAttended = e.Attended
+					result.TermMakeupCredits = e.MakeupCredits
 					result.TermTotal = e.TotalSessions
 					result.TermAttendancePct = e.AttendancePct
 					result.TermThresholdPct = e.ThresholdPct
@@ -1856,19 +2362,13 @@ func handleGetTrainingLog(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(result)
 }
 
-// handleGetInactiveMembers handles GET /api/members/inactive?days=<n>
+// handleGetInactiveMembers handles GET /api/members/inactive?days=<n>[&tag=][&segment=]
 func handleGetInactiveMembers(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		return
-	}
-	sess, ok := middleware.GetSessionFromContext(r.Context())
-	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.MethodNotAllowed(w)
 		return
 	}
-	if sess.Role != "admin" && sess.Role != "coach" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if _, ok := requirePermission(w, r, permissionDomain.ActionMembersView); !ok {
 		return
 	}
 	days := 30
@@ -1876,7 +2376,12 @@ func handleGetInactiveMembers(w http.ResponseWriter, r *http.Request) {
 		fmt.Sscanf(d, "%d", &days)
 	}
 
-	query := projections.GetInactiveMembersQuery{DaysSinceLastCheckIn: days}
+	tag, segmentIDs, err := memberTagFilters(r)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	query := projections.GetInactiveMembersQuery{DaysSinceLastCheckIn: days, Tag: tag, IDs: segmentIDs}
 	deps := projections.GetInactiveMembersDeps{
 		MemberStore:     stores.MemberStore,
 		AttendanceStore: stores.AttendanceStore,
@@ -1894,13 +2399,26 @@ func handleGetInactiveMembers(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(results)
 }
 
+// noticeCreateRequest is the body of POST /api/notices.
+type noticeCreateRequest struct {
+	Type         string `json:"Type"`
+	Title        string `json:"Title"`
+	Content      string `json:"Content"`
+	TargetID     string `json:"TargetID"`
+	AuthorName   string `json:"AuthorName"`
+	ShowAuthor   bool   `json:"ShowAuthor"`
+	Color        string `json:"Color"`
+	VisibleFrom  string `json:"VisibleFrom"`
+	VisibleUntil string `json:"VisibleUntil"`
+}
+
 // handleNotices handles GET/POST for /api/notices
 func handleNotices(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	if r.Method == "GET" {
 		if _, ok := middleware.GetSessionFromContext(ctx); !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
+			apierror.Unauthorized(w, "not authenticated")
 			return
 		}
 		noticeType := r.URL.Query().Get("type")
@@ -1938,19 +2456,9 @@ func handleNotices(w http.ResponseWriter, r *http.Request) {
 		if !ok {
 			return
 		}
-		var input struct {
-			Type         string `json:"Type"`
-			Title        string `json:"Title"`
-			Content      string `json:"Content"`
-			TargetID     string `json:"TargetID"`
-			AuthorName   string `json:"AuthorName"`
-			ShowAuthor   bool   `json:"ShowAuthor"`
-			Color        string `json:"Color"`
-			VisibleFrom  string `json:"VisibleFrom"`
-			VisibleUntil string `json:"VisibleUntil"`
-		}
+		var input noticeCreateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		orchInput := orchestrators.CreateNoticeInput{
@@ -1979,7 +2487,7 @@ func handleNotices(w http.ResponseWriter, r *http.Request) {
 			Now:         timeNow,
 		})
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -1988,19 +2496,33 @@ func handleNotices(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// gradingProposalRequest is the body of POST /api/grading/proposals.
+type gradingProposalRequest struct {
+	MemberID   string `json:"MemberID"`
+	TargetBelt string `json:"TargetBelt"`
+	Notes      string `json:"Notes"`
 }
 
 // handleGradingProposals handles GET/POST for /api/grading/proposals
+// GET lists open proposals, or with ?event_id= every proposal booked onto that grading day.
 func handleGradingProposals(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	if r.Method == "GET" {
 		if _, ok := middleware.GetSessionFromContext(ctx); !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
+			apierror.Unauthorized(w, "not authenticated")
 			return
 		}
-		proposals, err := stores.GradingProposalStore.ListPending(ctx)
+		var proposals []gradingDomain.Proposal
+		var err error
+		if eventID := r.URL.Query().Get("event_id"); eventID != "" {
+			proposals, err = stores.GradingProposalStore.ListByEventID(ctx, eventID)
+		} else {
+			proposals, err = stores.GradingProposalStore.ListOpen(ctx)
+		}
 		if err != nil {
 			internalError(w, err)
 			return
@@ -2017,16 +2539,12 @@ func handleGradingProposals(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "POST" {
 		sess, ok := middleware.GetSessionFromContext(ctx)
 		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
+			apierror.Unauthorized(w, "not authenticated")
 			return
 		}
-		var input struct {
-			MemberID   string `json:"MemberID"`
-			TargetBelt string `json:"TargetBelt"`
-			Notes      string `json:"Notes"`
-		}
+		var input gradingProposalRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		proposal := gradingDomain.Proposal{
@@ -2039,34 +2557,95 @@ func handleGradingProposals(w http.ResponseWriter, r *http.Request) {
 			CreatedAt:  timeNow(),
 		}
 		if err := proposal.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err := orchestrators.CheckGradingInjuryRestriction(ctx, proposal.MemberID, stores.InjuryStore); err != nil {
+			if errors.Is(err, injuryDomain.ErrGradingRestricted) {
+				apierror.Conflict(w, err.Error())
+				return
+			}
+			internalError(w, err)
 			return
 		}
+		// Coaches can only propose members who meet the belt's eligibility rule;
+		// admins can bypass it (PRD §4.7).
+		if sess.Role != accountDomain.RoleAdmin {
+			if _, err := stores.MemberStore.GetByID(ctx, proposal.MemberID); err != nil {
+				apierror.NotFound(w, "member not found")
+				return
+			}
+			entry, err := memberEligibility(ctx, proposal.MemberID, proposal.TargetBelt)
+			if err != nil {
+				internalError(w, err)
+				return
+			}
+			if !entry.Eligible {
+				apierror.Conflict(w, "not yet eligible for "+proposal.TargetBelt+": "+describeUnmet(entry))
+				return
+			}
+		}
 		if err := stores.GradingProposalStore.Save(ctx, proposal); err != nil {
 			internalError(w, err)
 			return
 		}
+		notifyMember(ctx, proposal.MemberID, orchestrators.NotifyInput{
+			Kind:  notificationDomain.KindGradingProposed,
+			Title: "You've been proposed for " + proposal.TargetBelt + " belt",
+			Link:  "/training-log",
+		})
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
 		json.NewEncoder(w).Encode(proposal)
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// gradingNoteRequest is the body of POST /api/grading/notes.
+type gradingNoteRequest struct {
+	MemberID   string         `json:"MemberID"`
+	Content    string         `json:"Content"`
+	Visibility string         `json:"Visibility"` // coaches (default), admin or shared
+	RubricID   string         `json:"RubricID"`   // optional rubric template to score against
+	Scores     map[string]int `json:"Scores"`     // criterion key -> value; requires RubricID
+}
+
+// noteListMemberID resolves whose observations or grading notes a GET may list.
+// Staff name any member; everyone else gets their own, and is refused another member's.
+// Returns false when the request has been answered.
+func noteListMemberID(w http.ResponseWriter, r *http.Request, sess middleware.Session) (string, bool) {
+	memberID := r.URL.Query().Get("member_id")
+	if !isStaffSession(sess) {
+		own := sessionMemberID(r.Context(), sess)
+		if own == "" || (memberID != "" && memberID != own) {
+			apierror.Forbidden(w, "Forbidden")
+			return "", false
+		}
+		memberID = own
+	}
+	if memberID == "" {
+		apierror.Validation(w, "member_id is required")
+		return "", false
+	}
+	return memberID, true
 }
 
 // handleGradingNotes handles GET/POST for /api/grading/notes
+// GET returns the notes the requester may see: admins all, coaches all but admin-only,
+// members their own shared notes. Only coaches and admins may write notes.
 func handleGradingNotes(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	if r.Method == "GET" {
-		if _, ok := middleware.GetSessionFromContext(ctx); !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
+		sess, ok := middleware.GetSessionFromContext(ctx)
+		if !ok {
+			apierror.Unauthorized(w, "not authenticated")
 			return
 		}
-		memberID := r.URL.Query().Get("member_id")
-		if memberID == "" {
-			http.Error(w, "member_id is required", http.StatusBadRequest)
+		memberID, ok := noteListMemberID(w, r, sess)
+		if !ok {
 			return
 		}
 		notes, err := stores.GradingNoteStore.ListByMemberID(ctx, memberID)
@@ -2074,42 +2653,47 @@ func handleGradingNotes(w http.ResponseWriter, r *http.Request) {
 			internalError(w, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		if notes == nil {
-			w.Write([]byte("[]"))
-			return
+		visible := make([]gradingDomain.Note, 0, len(notes))
+		for _, n := range notes {
+			if n.VisibleTo(sess.Role, !isStaffSession(sess)) {
+				visible = append(visible, n)
+			}
 		}
-		json.NewEncoder(w).Encode(notes)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(visible)
 		return
 	}
 
 	if r.Method == "POST" {
 		sess, ok := middleware.GetSessionFromContext(ctx)
 		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
+			apierror.Unauthorized(w, "not authenticated")
 			return
 		}
-		var input struct {
-			MemberID string `json:"MemberID"`
-			Content  string `json:"Content"`
-		}
-		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+		if !isStaffSession(sess) {
+			apierror.Forbidden(w, "Forbidden")
 			return
 		}
-		note := gradingDomain.Note{
-			ID:        generateID(),
-			MemberID:  input.MemberID,
-			Content:   input.Content,
-			CreatedBy: sess.AccountID,
-			CreatedAt: timeNow(),
-		}
-		if err := note.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		var input gradingNoteRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
-		if err := stores.GradingNoteStore.Save(ctx, note); err != nil {
-			internalError(w, err)
+		note, err := orchestrators.ExecuteCreateGradingNote(ctx, orchestrators.CreateGradingNoteInput{
+			MemberID:   input.MemberID,
+			Content:    input.Content,
+			Visibility: input.Visibility,
+			AuthorID:   sess.AccountID,
+			Rubric:     orchestrators.RubricScoresInput{TemplateID: input.RubricID, Scores: input.Scores},
+		}, orchestrators.CreateGradingNoteDeps{
+			NoteStore:           stores.GradingNoteStore,
+			RubricTemplateStore: stores.RubricTemplateStore,
+			RubricScoreStore:    stores.RubricScoreStore,
+			GenerateID:          generateID,
+			Now:                 timeNow,
+		})
+		if err != nil {
+			apierror.Validation(w, err.Error())
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -2118,7 +2702,15 @@ func handleGradingNotes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// messageCreateRequest is the body of POST /api/messages.
+type messageCreateRequest struct {
+	ReceiverID  string                     `json:"ReceiverID"`
+	Subject     string                     `json:"Subject"`
+	Content     string                     `json:"Content"`
+	Attachments []messageAttachmentRequest `json:"Attachments"` // staff only
 }
 
 // handleMessages handles GET/POST for /api/messages
@@ -2128,7 +2720,7 @@ func handleMessages(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "GET" {
 		sess, ok := middleware.GetSessionFromContext(ctx)
 		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
+			apierror.Unauthorized(w, "not authenticated")
 			return
 		}
 		if !requireFeatureAPI(w, r, sess, "messages") {
@@ -2136,7 +2728,7 @@ func handleMessages(w http.ResponseWriter, r *http.Request) {
 		}
 		memberID := r.URL.Query().Get("member_id")
 		if memberID == "" {
-			http.Error(w, "member_id is required", http.StatusBadRequest)
+			apierror.Validation(w, "member_id is required")
 			return
 		}
 		messages, err := stores.MessageStore.ListByReceiverID(ctx, memberID)
@@ -2156,55 +2748,78 @@ func handleMessages(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "POST" {
 		sess, ok := middleware.GetSessionFromContext(ctx)
 		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
+			apierror.Unauthorized(w, "not authenticated")
 			return
 		}
-		var input struct {
-			ReceiverID string `json:"ReceiverID"`
-			Subject    string `json:"Subject"`
-			Content    string `json:"Content"`
-		}
+		var input messageCreateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		if len(input.Attachments) > 0 && !isStaffSession(sess) {
+			apierror.Forbidden(w, orchestrators.ErrMemberAttachment.Error())
+			return
+		}
+		attachments, err := resolveMessageAttachments(ctx, input.Attachments)
+		if err != nil {
+			apierror.Validation(w, err.Error())
 			return
 		}
 		msg := messageDomain.Message{
-			ID:         generateID(),
-			SenderID:   sess.AccountID,
-			ReceiverID: input.ReceiverID,
-			Subject:    input.Subject,
-			Content:    input.Content,
-			CreatedAt:  timeNow(),
+			ID:          generateID(),
+			SenderID:    sess.AccountID,
+			ReceiverID:  input.ReceiverID,
+			Subject:     input.Subject,
+			Content:     input.Content,
+			Attachments: attachments,
+			CreatedAt:   timeNow(),
 		}
 		if err := msg.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.MessageStore.Save(ctx, msg); err != nil {
 			internalError(w, err)
 			return
 		}
+		notifyMember(ctx, msg.ReceiverID, orchestrators.NotifyInput{
+			Kind:  notificationDomain.KindMessageReceived,
+			Title: "New message",
+			Body:  msg.Subject,
+			Link:  "/messages",
+		})
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
 		json.NewEncoder(w).Encode(msg)
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// observationCreateRequest is the body of POST /api/observations.
+type observationCreateRequest struct {
+	MemberID   string         `json:"MemberID"`
+	Content    string         `json:"Content"`
+	Visibility string         `json:"Visibility"` // coaches (default), admin or shared
+	RubricID   string         `json:"RubricID"`   // optional rubric template to score against
+	Scores     map[string]int `json:"Scores"`     // criterion key -> value; requires RubricID
 }
 
 // handleObservations handles GET/POST for /api/observations
+// GET returns the observations the requester may see: admins all, coaches all but
+// admin-only, members their own shared feedback. Only coaches and admins may write them.
 func handleObservations(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	if r.Method == "GET" {
-		if _, ok := middleware.GetSessionFromContext(ctx); !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
+		sess, ok := middleware.GetSessionFromContext(ctx)
+		if !ok {
+			apierror.Unauthorized(w, "not authenticated")
 			return
 		}
-		memberID := r.URL.Query().Get("member_id")
-		if memberID == "" {
-			http.Error(w, "member_id is required", http.StatusBadRequest)
+		memberID, ok := noteListMemberID(w, r, sess)
+		if !ok {
 			return
 		}
 		obs, err := stores.ObservationStore.ListByMemberID(ctx, memberID)
@@ -2212,40 +2827,47 @@ func handleObservations(w http.ResponseWriter, r *http.Request) {
 			internalError(w, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		if obs == nil {
-			w.Write([]byte("[]"))
-			return
+		visible := make([]observationDomain.Observation, 0, len(obs))
+		for _, o := range obs {
+			if o.VisibleTo(sess.Role, !isStaffSession(sess)) {
+				visible = append(visible, o)
+			}
 		}
-		json.NewEncoder(w).Encode(obs)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(visible)
 		return
 	}
 
 	if r.Method == "POST" {
 		sess, ok := middleware.GetSessionFromContext(ctx)
 		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
+			apierror.Unauthorized(w, "not authenticated")
 			return
 		}
-		var input struct {
-			MemberID string `json:"MemberID"`
-			Content  string `json:"Content"`
+		if !isStaffSession(sess) {
+			apierror.Forbidden(w, "Forbidden")
+			return
 		}
+		var input observationCreateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		obs, err := orchestrators.ExecuteCreateObservation(ctx, orchestrators.CreateObservationInput{
-			MemberID: input.MemberID,
-			Content:  input.Content,
-			AuthorID: sess.AccountID,
+			MemberID:   input.MemberID,
+			Content:    input.Content,
+			Visibility: input.Visibility,
+			AuthorID:   sess.AccountID,
+			Rubric:     orchestrators.RubricScoresInput{TemplateID: input.RubricID, Scores: input.Scores},
 		}, orchestrators.CreateObservationDeps{
-			ObservationStore: stores.ObservationStore,
-			GenerateID:       generateID,
-			Now:              timeNow,
+			ObservationStore:    stores.ObservationStore,
+			RubricTemplateStore: stores.RubricTemplateStore,
+			RubricScoreStore:    stores.RubricScoreStore,
+			GenerateID:          generateID,
+			Now:                 timeNow,
 		})
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -2254,7 +2876,60 @@ func handleObservations(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// observationVisibilityRequest is the body of POST /api/observations/visibility.
+type observationVisibilityRequest struct {
+	ID         string `json:"ID"`
+	Visibility string `json:"Visibility"` // coaches, admin or shared
+}
+
+// handleObservationVisibility handles POST /api/observations/visibility
+// Coaches and admins change who may read an observation, e.g. sharing it with the member
+// as feedback in their training log. Coaches cannot change admin-only observations.
+func handleObservationVisibility(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !isStaffSession(sess) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+	var input observationVisibilityRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	obs, err := orchestrators.ExecuteSetObservationVisibility(ctx, orchestrators.SetObservationVisibilityInput{
+		ObservationID: input.ID,
+		Visibility:    input.Visibility,
+		ActorRole:     sess.Role,
+		ActorID:       sess.AccountID,
+	}, orchestrators.SetObservationVisibilityDeps{
+		ObservationStore: stores.ObservationStore,
+		Now:              timeNow,
+	})
+	switch {
+	case errors.Is(err, orchestrators.ErrObservationNotFound):
+		apierror.NotFound(w, err.Error())
+		return
+	case errors.Is(err, observationDomain.ErrInvalidVisibility):
+		apierror.Validation(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(obs)
 }
 
 // --- Phase 1: Admin CRUD API Handlers ---
@@ -2264,19 +2939,30 @@ func handleObservations(w http.ResponseWriter, r *http.Request) {
 func requireAdmin(w http.ResponseWriter, r *http.Request) (middleware.Session, bool) {
 	sess, ok := middleware.GetSessionFromContext(r.Context())
 	if !ok {
-		slog.Warn("auth_denied", "path", r.URL.Path, "reason", "no session")
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		slog.WarnContext(r.Context(), "auth_denied", "path", r.URL.Path, "reason", "no session")
+		apierror.Unauthorized(w, "not authenticated")
 		return middleware.Session{}, false
 	}
 	if sess.Role != "admin" {
-		slog.Warn("auth_denied", "path", r.URL.Path, "account_id", sess.AccountID, "role", sess.Role, "required", "admin")
-		http.Error(w, "Forbidden", http.StatusForbidden)
+		slog.WarnContext(r.Context(), "auth_denied", "path", r.URL.Path, "account_id", sess.AccountID, "role", sess.Role, "required", "admin")
+		apierror.Forbidden(w, "Forbidden")
 		return middleware.Session{}, false
 	}
 	return sess, true
 }
 
+// scheduleCreateRequest is the body of POST /api/schedules.
+type scheduleCreateRequest struct {
+	ClassTypeID string `json:"ClassTypeID"`
+	Day         string `json:"Day"`
+	StartTime   string `json:"StartTime"`
+	EndTime     string `json:"EndTime"`
+	LocationID  string `json:"LocationID"`
+	Mat         string `json:"Mat"`
+}
+
 // handleSchedules handles GET/POST/DELETE for /api/schedules
+// POST rejects a class that overlaps another in the same space with 409.
 func handleSchedules(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -2296,6 +2982,7 @@ func handleSchedules(w http.ResponseWriter, r *http.Request) {
 			internalError(w, err)
 			return
 		}
+		schedules = filterSchedulesByLocation(schedules, requestLocationID(r))
 		w.Header().Set("Content-Type", "application/json")
 		if schedules == nil {
 			w.Write([]byte("[]"))
@@ -2309,14 +2996,9 @@ func handleSchedules(w http.ResponseWriter, r *http.Request) {
 		if _, ok := requireAdmin(w, r); !ok {
 			return
 		}
-		var input struct {
-			ClassTypeID string `json:"ClassTypeID"`
-			Day         string `json:"Day"`
-			StartTime   string `json:"StartTime"`
-			EndTime     string `json:"EndTime"`
-		}
+		var input scheduleCreateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		sched := scheduleDomain.Schedule{
@@ -2325,9 +3007,14 @@ func handleSchedules(w http.ResponseWriter, r *http.Request) {
 			Day:         strings.ToLower(input.Day),
 			StartTime:   input.StartTime,
 			EndTime:     input.EndTime,
+			LocationID:  input.LocationID,
+			Mat:         strings.TrimSpace(input.Mat),
 		}
 		if err := sched.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if !checkScheduleConflicts(w, r, sched) {
 			return
 		}
 		if err := stores.ScheduleStore.Save(ctx, sched); err != nil {
@@ -2346,7 +3033,7 @@ func handleSchedules(w http.ResponseWriter, r *http.Request) {
 		}
 		id := r.URL.Query().Get("id")
 		if id == "" {
-			http.Error(w, "id is required", http.StatusBadRequest)
+			apierror.Validation(w, "id is required")
 			return
 		}
 		if err := stores.ScheduleStore.Delete(ctx, id); err != nil {
@@ -2357,7 +3044,14 @@ func handleSchedules(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// holidayCreateRequest is the body of POST /api/holidays.
+type holidayCreateRequest struct {
+	Name      string `json:"Name"`
+	StartDate string `json:"StartDate"`
+	EndDate   string `json:"EndDate"`
 }
 
 // handleHolidays handles GET/POST/DELETE for /api/holidays
@@ -2387,23 +3081,19 @@ func handleHolidays(w http.ResponseWriter, r *http.Request) {
 		if !ok {
 			return
 		}
-		var input struct {
-			Name      string `json:"Name"`
-			StartDate string `json:"StartDate"`
-			EndDate   string `json:"EndDate"`
-		}
+		var input holidayCreateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		startDate, err := time.Parse("2006-01-02", input.StartDate)
 		if err != nil {
-			http.Error(w, "StartDate must be YYYY-MM-DD", http.StatusBadRequest)
+			apierror.Validation(w, "StartDate must be YYYY-MM-DD")
 			return
 		}
 		endDate, err := time.Parse("2006-01-02", input.EndDate)
 		if err != nil {
-			http.Error(w, "EndDate must be YYYY-MM-DD", http.StatusBadRequest)
+			apierror.Validation(w, "EndDate must be YYYY-MM-DD")
 			return
 		}
 		h := holidayDomain.Holiday{
@@ -2413,7 +3103,7 @@ func handleHolidays(w http.ResponseWriter, r *http.Request) {
 			EndDate:   endDate,
 		}
 		if err := h.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.HolidayStore.Save(ctx, h); err != nil {
@@ -2444,7 +3134,7 @@ func handleHolidays(w http.ResponseWriter, r *http.Request) {
 		}
 		id := r.URL.Query().Get("id")
 		if id == "" {
-			http.Error(w, "id is required", http.StatusBadRequest)
+			apierror.Validation(w, "id is required")
 			return
 		}
 		if err := stores.HolidayStore.Delete(ctx, id); err != nil {
@@ -2455,7 +3145,14 @@ func handleHolidays(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// termCreateRequest is the body of POST /api/terms.
+type termCreateRequest struct {
+	Name      string `json:"Name"`
+	StartDate string `json:"StartDate"`
+	EndDate   string `json:"EndDate"`
 }
 
 // handleTerms handles GET/POST/DELETE for /api/terms
@@ -2484,23 +3181,19 @@ func handleTerms(w http.ResponseWriter, r *http.Request) {
 		if _, ok := requireAdmin(w, r); !ok {
 			return
 		}
-		var input struct {
-			Name      string `json:"Name"`
-			StartDate string `json:"StartDate"`
-			EndDate   string `json:"EndDate"`
-		}
+		var input termCreateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		startDate, err := time.Parse("2006-01-02", input.StartDate)
 		if err != nil {
-			http.Error(w, "StartDate must be YYYY-MM-DD", http.StatusBadRequest)
+			apierror.Validation(w, "StartDate must be YYYY-MM-DD")
 			return
 		}
 		endDate, err := time.Parse("2006-01-02", input.EndDate)
 		if err != nil {
-			http.Error(w, "EndDate must be YYYY-MM-DD", http.StatusBadRequest)
+			apierror.Validation(w, "EndDate must be YYYY-MM-DD")
 			return
 		}
 		t := termDomain.Term{
@@ -2510,7 +3203,7 @@ func handleTerms(w http.ResponseWriter, r *http.Request) {
 			EndDate:   endDate,
 		}
 		if err := t.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.TermStore.Save(ctx, t); err != nil {
@@ -2529,7 +3222,7 @@ func handleTerms(w http.ResponseWriter, r *http.Request) {
 		}
 		id := r.URL.Query().Get("id")
 		if id == "" {
-			http.Error(w, "id is required", http.StatusBadRequest)
+			apierror.Validation(w, "id is required")
 			return
 		}
 		if err := stores.TermStore.Delete(ctx, id); err != nil {
@@ -2540,7 +3233,25 @@ func handleTerms(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// accountView is an account as listed by GET /api/accounts, without credentials.
+type accountView struct {
+	ID              string `json:"ID"`
+	Email           string `json:"Email"`
+	Role            string `json:"Role"`
+	Status          string `json:"Status"`
+	Locked          bool   `json:"Locked"`
+	FailedLogins    int    `json:"FailedLogins"`
+	SuspendedReason string `json:"SuspendedReason,omitempty"`
+}
+
+// accountCreateRequest is the body of POST /api/accounts.
+type accountCreateRequest struct {
+	Email    string `json:"Email"`
+	Password string `json:"Password"`
+	Role     string `json:"Role"`
 }
 
 // handleAccounts handles GET/POST for /api/accounts
@@ -2562,15 +3273,9 @@ func handleAccounts(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		// Strip password hashes from response
-		type safeAccount struct {
-			ID     string `json:"ID"`
-			Email  string `json:"Email"`
-			Role   string `json:"Role"`
-			Status string `json:"Status"`
-		}
-		var safe []safeAccount
+		var safe []accountView
 		for _, a := range accounts {
-			safe = append(safe, safeAccount{ID: a.ID, Email: a.Email, Role: a.Role, Status: a.Status})
+			safe = append(safe, accountView{ID: a.ID, Email: a.Email, Role: a.Role, Status: a.Status, Locked: a.IsLocked(), FailedLogins: a.FailedLogins, SuspendedReason: a.SuspendedReason})
 		}
 		w.Header().Set("Content-Type", "application/json")
 		if safe == nil {
@@ -2585,13 +3290,9 @@ func handleAccounts(w http.ResponseWriter, r *http.Request) {
 		if _, ok := requireAdmin(w, r); !ok {
 			return
 		}
-		var input struct {
-			Email    string `json:"Email"`
-			Password string `json:"Password"`
-			Role     string `json:"Role"`
-		}
+		var input accountCreateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		acct := accountDomain.Account{
@@ -2601,7 +3302,7 @@ func handleAccounts(w http.ResponseWriter, r *http.Request) {
 			CreatedAt: timeNow(),
 		}
 		if err := acct.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 
@@ -2626,7 +3327,7 @@ func handleAccounts(w http.ResponseWriter, r *http.Request) {
 				ID:        generateID(),
 				AccountID: acct.ID,
 				Token:     tokenStr,
-				ExpiresAt: timeNow().Add(72 * time.Hour),
+				ExpiresAt: timeNow().Add(accountDomain.ActivationTokenLifetime),
 				CreatedAt: timeNow(),
 			}
 			if err := stores.AccountStore.SaveActivationToken(ctx, tok); err != nil {
@@ -2635,11 +3336,11 @@ func handleAccounts(w http.ResponseWriter, r *http.Request) {
 			}
 			response["Status"] = accountDomain.StatusPendingActivation
 			response["ActivationToken"] = tokenStr
-			slog.Info("auth_event", "event", "account_created_pending", "email", acct.Email, "role", acct.Role)
+			slog.InfoContext(ctx, "auth_event", "event", "account_created_pending", "email", acct.Email, "role", acct.Role)
 		} else {
 			// Admin accounts require a password and are active immediately
 			if err := acct.SetPassword(input.Password); err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
+				apierror.Validation(w, err.Error())
 				return
 			}
 			acct.Status = accountDomain.StatusActive
@@ -2648,7 +3349,7 @@ func handleAccounts(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			response["Status"] = accountDomain.StatusActive
-			slog.Info("auth_event", "event", "account_created", "email", acct.Email, "role", acct.Role)
+			slog.InfoContext(ctx, "auth_event", "event", "account_created", "email", acct.Email, "role", acct.Role)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -2657,38 +3358,41 @@ func handleAccounts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// changeRoleRequest is the body of POST /api/accounts/role.
+type changeRoleRequest struct {
+	AccountID string `json:"AccountID"`
+	NewRole   string `json:"NewRole"`
 }
 
 // handleChangeRole handles POST /api/accounts/role
 func handleChangeRole(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
 		return
 	}
-	var input struct {
-		AccountID string `json:"AccountID"`
-		NewRole   string `json:"NewRole"`
-	}
+	var input changeRoleRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 	if input.AccountID == "" || input.NewRole == "" {
-		http.Error(w, "AccountID and NewRole are required", http.StatusBadRequest)
+		apierror.Validation(w, "AccountID and NewRole are required")
 		return
 	}
 	acct, err := stores.AccountStore.GetByID(r.Context(), input.AccountID)
 	if err != nil {
-		http.Error(w, "account not found", http.StatusNotFound)
+		apierror.NotFound(w, "account not found")
 		return
 	}
 	acct.Role = input.NewRole
 	if err := acct.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 	if err := stores.AccountStore.Save(r.Context(), acct); err != nil {
@@ -2703,6 +3407,275 @@ func handleChangeRole(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// authRateLimitedPaths are the credential endpoints throttled per IP and per email. There is
+// no separate password reset endpoint: /change-password is where passwords are reset.
+var authRateLimitedPaths = []string{"/login", "/api/activate", "/change-password", "/api/kiosk/exit", "/api/account/email", "/api/account/email/confirm"}
+
+// accountIDRequest names the account acted on by POST /api/accounts/unlock and POST /api/admin/resend-activation.
+type accountIDRequest struct {
+	AccountID string `json:"AccountID"`
+}
+
+// handleUnlockAccount handles POST /api/accounts/unlock
+// Clears a brute-force lockout (and the email's rate limit) so the member can log in again. Admin only.
+func handleUnlockAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	var input accountIDRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if input.AccountID == "" {
+		apierror.Validation(w, "AccountID is required")
+		return
+	}
+	if _, err := stores.AccountStore.GetByID(r.Context(), input.AccountID); err != nil {
+		apierror.NotFound(w, "account not found")
+		return
+	}
+	acct, err := orchestrators.ExecuteUnlockAccount(r.Context(), orchestrators.UnlockAccountInput{
+		AccountID:  input.AccountID,
+		UnlockedBy: sess.AccountID,
+	}, orchestrators.UnlockAccountDeps{AccountStore: stores.AccountStore})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	if authLimiter != nil {
+		authLimiter.ResetEmail(acct.Email)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"ID":    acct.ID,
+		"Email": acct.Email,
+	})
+}
+
+// StatusChangeDeps wires the status change orchestrators to the stores. Suspending an
+// account deletes its sessions, so the member is signed out on every device.
+func StatusChangeDeps(s *Stores, now func() time.Time) orchestrators.StatusChangeDeps {
+	return orchestrators.StatusChangeDeps{
+		ChangeStore:    s.StatusChangeStore,
+		AccountStore:   s.AccountStore,
+		MemberStore:    s.MemberStore,
+		RevokeSessions: s.AuthSessionStore.DeleteByAccountID,
+		GenerateID:     uuid.NewString,
+		Now:            now,
+	}
+}
+
+// statusChangeRequest is the body of POST /api/status-changes.
+type statusChangeRequest struct {
+	Action        string `json:"Action"`        // suspend, reinstate, freeze or unfreeze
+	SubjectID     string `json:"SubjectID"`     // account ID to suspend or reinstate, member ID to freeze or unfreeze
+	Reason        string `json:"Reason"`        // required to suspend; shown at sign-in
+	EffectiveDate string `json:"EffectiveDate"` // optional: YYYY-MM-DD, defaults to today
+	UntilDate     string `json:"UntilDate"`     // optional: suspend and freeze only; undone on this date
+}
+
+// handleStatusChanges handles GET/POST/DELETE for /api/status-changes
+// GET ?subject_type=account|member&subject_id= lists an account's or member's changes, newest first.
+// POST suspends, reinstates, freezes or unfreezes now, or schedules it for a later date.
+// DELETE ?id= cancels a change that has not taken effect yet.
+func handleStatusChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		subjectType, subjectID := r.URL.Query().Get("subject_type"), r.URL.Query().Get("subject_id")
+		if subjectType != statusChangeDomain.SubjectAccount && subjectType != statusChangeDomain.SubjectMember {
+			apierror.Validation(w, "subject_type must be account or member")
+			return
+		}
+		if subjectID == "" {
+			apierror.Validation(w, "subject_id is required")
+			return
+		}
+		changes, err := stores.StatusChangeStore.ListBySubject(ctx, subjectType, subjectID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if changes == nil {
+			changes = []statusChangeDomain.Change{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(changes)
+
+	case "POST":
+		var input statusChangeRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		result, err := orchestrators.ExecuteChangeStatus(ctx, orchestrators.ChangeStatusInput{
+			Action:        input.Action,
+			SubjectID:     input.SubjectID,
+			Reason:        input.Reason,
+			EffectiveDate: input.EffectiveDate,
+			UntilDate:     input.UntilDate,
+			ActorID:       sess.AccountID,
+		}, StatusChangeDeps(stores, timeNow))
+		if errors.Is(err, orchestrators.ErrStatusSubjectNotFound) {
+			apierror.NotFound(w, err.Error())
+			return
+		}
+		if err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(result)
+
+	case "DELETE":
+		c, err := stores.StatusChangeStore.GetByID(ctx, r.URL.Query().Get("id"))
+		if err != nil {
+			apierror.NotFound(w, "status change not found")
+			return
+		}
+		if !c.IsScheduled() {
+			apierror.Validation(w, statusChangeDomain.ErrAlreadyApplied.Error())
+			return
+		}
+		if err := stores.StatusChangeStore.Delete(ctx, c.ID); err != nil {
+			internalError(w, err)
+			return
+		}
+		slog.InfoContext(ctx, "status_event", "event", "status_change_cancelled", "change_id", c.ID, "action", c.Action, "subject_id", c.SubjectID, "by", sess.AccountID)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// flagDTO is a feature flag as read and written by /api/admin/feature-flags.
+type flagDTO struct {
+	Key           string `json:"Key"`
+	Description   string `json:"Description"`
+	EnabledAdmin  bool   `json:"EnabledAdmin"`
+	EnabledCoach  bool   `json:"EnabledCoach"`
+	EnabledMember bool   `json:"EnabledMember"`
+	EnabledTrial  bool   `json:"EnabledTrial"`
+	BetaOverride  bool   `json:"BetaOverride"`
+
+	// Targeting. Account lists hold account IDs; on save, entries containing '@'
+	// are looked up as emails. Times are RFC 3339; empty means unbounded.
+	RolloutPercent int      `json:"RolloutPercent"`
+	AllowAccounts  []string `json:"AllowAccounts"`
+	DenyAccounts   []string `json:"DenyAccounts"`
+	StartsAt       string   `json:"StartsAt"`
+	EndsAt         string   `json:"EndsAt"`
+}
+
+// newFlagDTO converts a feature flag for /api/admin/feature-flags.
+func newFlagDTO(ff featureflagDomain.FeatureFlag) flagDTO {
+	dto := flagDTO{
+		Key:            ff.Key,
+		Description:    ff.Description,
+		EnabledAdmin:   ff.EnabledAdmin,
+		EnabledCoach:   ff.EnabledCoach,
+		EnabledMember:  ff.EnabledMember,
+		EnabledTrial:   ff.EnabledTrial,
+		BetaOverride:   ff.BetaOverride,
+		RolloutPercent: ff.RolloutPercent,
+		AllowAccounts:  ff.AllowAccounts,
+		DenyAccounts:   ff.DenyAccounts,
+	}
+	if dto.AllowAccounts == nil {
+		dto.AllowAccounts = []string{}
+	}
+	if dto.DenyAccounts == nil {
+		dto.DenyAccounts = []string{}
+	}
+	if !ff.StartsAt.IsZero() {
+		dto.StartsAt = ff.StartsAt.UTC().Format(time.RFC3339)
+	}
+	if !ff.EndsAt.IsZero() {
+		dto.EndsAt = ff.EndsAt.UTC().Format(time.RFC3339)
+	}
+	return dto
+}
+
+// toFlag converts a saved flag, resolving account emails and parsing the window.
+func (dto flagDTO) toFlag(ctx context.Context) (featureflagDomain.FeatureFlag, error) {
+	ff := featureflagDomain.FeatureFlag{
+		Key:            strings.TrimSpace(dto.Key),
+		Description:    strings.TrimSpace(dto.Description),
+		EnabledAdmin:   dto.EnabledAdmin,
+		EnabledCoach:   dto.EnabledCoach,
+		EnabledMember:  dto.EnabledMember,
+		EnabledTrial:   dto.EnabledTrial,
+		BetaOverride:   dto.BetaOverride,
+		RolloutPercent: dto.RolloutPercent,
+	}
+	var err error
+	if ff.AllowAccounts, err = resolveFlagAccounts(ctx, dto.AllowAccounts); err != nil {
+		return ff, err
+	}
+	if ff.DenyAccounts, err = resolveFlagAccounts(ctx, dto.DenyAccounts); err != nil {
+		return ff, err
+	}
+	if ff.StartsAt, err = parseFlagTime(dto.StartsAt); err != nil {
+		return ff, fmt.Errorf("%s: invalid StartsAt", ff.Key)
+	}
+	if ff.EndsAt, err = parseFlagTime(dto.EndsAt); err != nil {
+		return ff, fmt.Errorf("%s: invalid EndsAt", ff.Key)
+	}
+	return ff, nil
+}
+
+// resolveFlagAccounts returns the account IDs for a targeting list, looking up
+// entries that contain '@' by email and dropping blanks and duplicates.
+func resolveFlagAccounts(ctx context.Context, entries []string) ([]string, error) {
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, e := range entries {
+		id := strings.TrimSpace(e)
+		if id == "" {
+			continue
+		}
+		if strings.Contains(id, "@") {
+			acct, err := stores.AccountStore.GetByEmail(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("no account with email %s", id)
+			}
+			id = acct.ID
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// parseFlagTime parses an RFC 3339 time; empty is the zero time.
+func parseFlagTime(v string) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+// featureFlagsUpdateRequest is the body of POST /api/admin/feature-flags.
+type featureFlagsUpdateRequest struct {
+	Flags []flagDTO `json:"Flags"`
+}
+
 // handleAdminFeatureFlags handles GET/POST /api/admin/feature-flags
 func handleAdminFeatureFlags(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -2710,16 +3683,6 @@ func handleAdminFeatureFlags(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	type flagDTO struct {
-		Key           string `json:"Key"`
-		Description   string `json:"Description"`
-		EnabledAdmin  bool   `json:"EnabledAdmin"`
-		EnabledCoach  bool   `json:"EnabledCoach"`
-		EnabledMember bool   `json:"EnabledMember"`
-		EnabledTrial  bool   `json:"EnabledTrial"`
-		BetaOverride  bool   `json:"BetaOverride"`
-	}
-
 	if r.Method == "GET" {
 		persisted, err := stores.FeatureFlagStore.List(ctx)
 		if err != nil {
@@ -2738,14 +3701,7 @@ func handleAdminFeatureFlags(w http.ResponseWriter, r *http.Request) {
 		for _, d := range defaults {
 			if p, ok := persistedByKey[d.Key]; ok {
 				// Persisted values override defaults.
-				if p.Description != "" {
-					d.Description = p.Description
-				}
-				d.EnabledAdmin = p.EnabledAdmin
-				d.EnabledCoach = p.EnabledCoach
-				d.EnabledMember = p.EnabledMember
-				d.EnabledTrial = p.EnabledTrial
-				d.BetaOverride = p.BetaOverride
+				d = overlayFeatureFlag(d, p)
 			}
 			seen[d.Key] = true
 			merged = append(merged, d)
@@ -2759,15 +3715,7 @@ func handleAdminFeatureFlags(w http.ResponseWriter, r *http.Request) {
 
 		out := make([]flagDTO, 0, len(merged))
 		for _, ff := range merged {
-			out = append(out, flagDTO{
-				Key:           ff.Key,
-				Description:   ff.Description,
-				EnabledAdmin:  ff.EnabledAdmin,
-				EnabledCoach:  ff.EnabledCoach,
-				EnabledMember: ff.EnabledMember,
-				EnabledTrial:  ff.EnabledTrial,
-				BetaOverride:  ff.BetaOverride,
-			})
+			out = append(out, newFlagDTO(ff))
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -2776,30 +3724,28 @@ func handleAdminFeatureFlags(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "POST" {
-		var input struct {
-			Flags []flagDTO `json:"Flags"`
-		}
+		var input featureFlagsUpdateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		if input.Flags == nil {
 			input.Flags = []flagDTO{}
 		}
+		flags := make([]featureflagDomain.FeatureFlag, 0, len(input.Flags))
 		for _, dto := range input.Flags {
-			ff := featureflagDomain.FeatureFlag{
-				Key:           strings.TrimSpace(dto.Key),
-				Description:   strings.TrimSpace(dto.Description),
-				EnabledAdmin:  dto.EnabledAdmin,
-				EnabledCoach:  dto.EnabledCoach,
-				EnabledMember: dto.EnabledMember,
-				EnabledTrial:  dto.EnabledTrial,
-				BetaOverride:  dto.BetaOverride,
+			ff, err := dto.toFlag(ctx)
+			if err != nil {
+				apierror.Validation(w, err.Error())
+				return
 			}
 			if err := ff.Validate(); err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
+				apierror.Validation(w, fmt.Sprintf("%s: %s", ff.Key, err.Error()))
 				return
 			}
+			flags = append(flags, ff)
+		}
+		for _, ff := range flags {
 			if err := stores.FeatureFlagStore.Save(ctx, ff); err != nil {
 				internalError(w, err)
 				return
@@ -2807,7 +3753,7 @@ func handleAdminFeatureFlags(w http.ResponseWriter, r *http.Request) {
 		}
 
 		sess, _ := middleware.GetSessionFromContext(ctx)
-		slog.Info("audit_event",
+		slog.InfoContext(ctx, "audit_event",
 			"actor_id", sess.AccountID,
 			"actor_role", sess.Role,
 			"action", "admin.feature_flags.save",
@@ -2819,7 +3765,79 @@ func handleAdminFeatureFlags(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// featureFlagTraceView is the response of GET /api/admin/feature-flags/trace.
+type featureFlagTraceView struct {
+	AccountID  string `json:"AccountID"`
+	Email      string `json:"Email"`
+	Role       string `json:"Role"`
+	BetaTester bool   `json:"BetaTester"`
+	featureflagDomain.Evaluation
+}
+
+// handleAdminFeatureFlagTrace handles GET /api/admin/feature-flags/trace?key=&email= (or &account_id=)
+// Explains whether an account sees a feature: every targeting rule checked, in order,
+// and the one that decided. Evaluated for the account's own role, not an impersonated one.
+func handleAdminFeatureFlagTrace(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	if _, ok := requireAdmin(w, r); !ok {
+		return
+	}
+	ctx := r.Context()
+	q := r.URL.Query()
+	key := strings.TrimSpace(q.Get("key"))
+	accountID := strings.TrimSpace(q.Get("account_id"))
+	email := strings.TrimSpace(q.Get("email"))
+	if key == "" || (accountID == "" && email == "") {
+		apierror.Validation(w, "key and either email or account_id are required")
+		return
+	}
+
+	ff, ok := mergedFeatureFlagsByKey(ctx)[key]
+	if !ok {
+		apierror.NotFound(w, "feature flag not found")
+		return
+	}
+	var acct accountDomain.Account
+	var err error
+	if accountID != "" {
+		acct, err = stores.AccountStore.GetByID(ctx, accountID)
+	} else {
+		acct, err = stores.AccountStore.GetByEmail(ctx, email)
+	}
+	if err != nil {
+		apierror.NotFound(w, "account not found")
+		return
+	}
+
+	subject := featureflagDomain.Subject{AccountID: acct.ID, Role: acct.Role, BetaTester: acct.BetaTester}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(featureFlagTraceView{
+		AccountID:  acct.ID,
+		Email:      acct.Email,
+		Role:       acct.Role,
+		BetaTester: acct.BetaTester,
+		Evaluation: ff.Evaluate(subject, timeNow()),
+	})
+}
+
+// betaTesterView is an account listed by /api/admin/beta-testers.
+type betaTesterView struct {
+	ID    string `json:"ID"`
+	Email string `json:"Email"`
+	Role  string `json:"Role"`
+}
+
+// betaTesterRequest is the body of POST /api/admin/beta-testers.
+type betaTesterRequest struct {
+	Email     string `json:"Email"`
+	AccountID string `json:"AccountID"`
+	Beta      bool   `json:"Beta"`
 }
 
 // handleAdminBetaTesters handles GET/POST /api/admin/beta-testers
@@ -2829,22 +3847,16 @@ func handleAdminBetaTesters(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	type safeAccount struct {
-		ID    string `json:"ID"`
-		Email string `json:"Email"`
-		Role  string `json:"Role"`
-	}
-
 	if r.Method == "GET" {
 		accounts, err := stores.AccountStore.List(ctx, accountStore.ListFilter{Limit: 1000})
 		if err != nil {
 			internalError(w, err)
 			return
 		}
-		var out []safeAccount
+		var out []betaTesterView
 		for _, a := range accounts {
 			if a.BetaTester {
-				out = append(out, safeAccount{ID: a.ID, Email: a.Email, Role: a.Role})
+				out = append(out, betaTesterView{ID: a.ID, Email: a.Email, Role: a.Role})
 			}
 		}
 		sort.Slice(out, func(i, j int) bool { return strings.ToLower(out[i].Email) < strings.ToLower(out[j].Email) })
@@ -2858,17 +3870,13 @@ func handleAdminBetaTesters(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "POST" {
-		var input struct {
-			Email     string `json:"Email"`
-			AccountID string `json:"AccountID"`
-			Beta      bool   `json:"Beta"`
-		}
+		var input betaTesterRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		if input.Email == "" && input.AccountID == "" {
-			http.Error(w, "Email or AccountID is required", http.StatusBadRequest)
+			apierror.Validation(w, "Email or AccountID is required")
 			return
 		}
 		var acct accountDomain.Account
@@ -2879,7 +3887,7 @@ func handleAdminBetaTesters(w http.ResponseWriter, r *http.Request) {
 			acct, err = stores.AccountStore.GetByEmail(ctx, input.Email)
 		}
 		if err != nil {
-			http.Error(w, "account not found", http.StatusNotFound)
+			apierror.NotFound(w, "account not found")
 			return
 		}
 		acct.BetaTester = input.Beta
@@ -2889,7 +3897,7 @@ func handleAdminBetaTesters(w http.ResponseWriter, r *http.Request) {
 		}
 
 		sess, _ := middleware.GetSessionFromContext(ctx)
-		slog.Info("audit_event",
+		slog.InfoContext(ctx, "audit_event",
 			"actor_id", sess.AccountID,
 			"actor_role", sess.Role,
 			"action", "admin.beta_tester.set",
@@ -2898,30 +3906,33 @@ func handleAdminBetaTesters(w http.ResponseWriter, r *http.Request) {
 		)
 
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(safeAccount{ID: acct.ID, Email: acct.Email, Role: acct.Role})
+		json.NewEncoder(w).Encode(betaTesterView{ID: acct.ID, Email: acct.Email, Role: acct.Role})
 		return
 	}
 
-	http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
 }
 
 // --- Phase 2: Engagement Workflow Handlers ---
 
+// noticeIDRequest is the body of POST /api/notices/publish.
+type noticeIDRequest struct {
+	NoticeID string `json:"NoticeID"`
+}
+
 // handleNoticePublish handles POST /api/notices/publish
 func handleNoticePublish(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	sess, ok := requireAdmin(w, r)
 	if !ok {
 		return
 	}
-	var input struct {
-		NoticeID string `json:"NoticeID"`
-	}
+	var input noticeIDRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 	n, err := orchestrators.ExecutePublishNotice(r.Context(), orchestrators.PublishNoticeInput{
@@ -2932,35 +3943,39 @@ func handleNoticePublish(w http.ResponseWriter, r *http.Request) {
 		Now:         timeNow,
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
+	notifyNoticePublished(r.Context(), n)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(n)
 }
 
+// noticeEditRequest is the body of POST /api/notices/edit.
+type noticeEditRequest struct {
+	NoticeID     string `json:"NoticeID"`
+	Title        string `json:"Title"`
+	Content      string `json:"Content"`
+	Type         string `json:"Type"`
+	AuthorName   string `json:"AuthorName"`
+	ShowAuthor   bool   `json:"ShowAuthor"`
+	Color        string `json:"Color"`
+	VisibleFrom  string `json:"VisibleFrom"`
+	VisibleUntil string `json:"VisibleUntil"`
+}
+
 // handleNoticeEdit handles POST /api/notices/edit
 func handleNoticeEdit(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
 		return
 	}
-	var input struct {
-		NoticeID     string `json:"NoticeID"`
-		Title        string `json:"Title"`
-		Content      string `json:"Content"`
-		Type         string `json:"Type"`
-		AuthorName   string `json:"AuthorName"`
-		ShowAuthor   bool   `json:"ShowAuthor"`
-		Color        string `json:"Color"`
-		VisibleFrom  string `json:"VisibleFrom"`
-		VisibleUntil string `json:"VisibleUntil"`
-	}
+	var input noticeEditRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 	orchInput := orchestrators.EditNoticeInput{
@@ -2977,127 +3992,243 @@ func handleNoticeEdit(w http.ResponseWriter, r *http.Request) {
 			orchInput.VisibleFrom = t
 		}
 	}
-	if input.VisibleUntil != "" {
-		if t, err := time.Parse(time.RFC3339, input.VisibleUntil); err == nil {
-			orchInput.VisibleUntil = t
-		}
+	if input.VisibleUntil != "" {
+		if t, err := time.Parse(time.RFC3339, input.VisibleUntil); err == nil {
+			orchInput.VisibleUntil = t
+		}
+	}
+	n, err := orchestrators.ExecuteEditNotice(r.Context(), orchInput, orchestrators.EditNoticeDeps{
+		NoticeStore: stores.NoticeStore,
+		Now:         timeNow,
+	})
+	if err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(n)
+}
+
+// noticePinRequest is the body of POST /api/notices/pin.
+type noticePinRequest struct {
+	NoticeID string `json:"NoticeID"`
+	Pinned   bool   `json:"Pinned"`
+}
+
+// handleNoticePin handles POST /api/notices/pin (toggle pin/unpin)
+func handleNoticePin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	if _, ok := requireAdmin(w, r); !ok {
+		return
+	}
+	var input noticePinRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	n, err := orchestrators.ExecutePinNotice(r.Context(), orchestrators.PinNoticeInput{
+		NoticeID: input.NoticeID,
+		Pinned:   input.Pinned,
+	}, orchestrators.PinNoticeDeps{
+		NoticeStore: stores.NoticeStore,
+		Now:         timeNow,
+	})
+	if err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(n)
+}
+
+// noticeImageDir holds images uploaded for notice bodies, one file per image ID.
+// Tests point it at a temporary directory.
+var noticeImageDir = filepath.Join("uploads", "notices")
+
+// noticeImageUploadResponse is the response of POST /api/notices/images.
+type noticeImageUploadResponse struct {
+	ImageID     string `json:"ImageID"`
+	ContentType string `json:"ContentType"`
+	Size        int64  `json:"Size"`
+	URL         string `json:"URL"`      // where the image is served from
+	Markdown    string `json:"Markdown"` // ready to insert into the notice body
+}
+
+// noticeImagePath returns where an uploaded notice image is stored.
+// Image IDs are generated UUIDs; anything else is rejected so an ID can never escape the directory.
+func noticeImagePath(imageID string) (string, bool) {
+	if imageID == "" || imageID != filepath.Base(imageID) || imageID == "." || imageID == ".." {
+		return "", false
+	}
+	return filepath.Join(noticeImageDir, imageID), true
+}
+
+// handleNoticeImageUpload handles POST /api/notices/images
+// Stores an image (multipart field "image") for a notice body and returns the markdown that shows it.
+// The type is sniffed from the file's bytes; only png, jpeg, webp and gif under 5 MB are kept.
+func handleNoticeImageUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, noticeDomain.MaxImageBytes+1<<20)
+	if err := r.ParseMultipartForm(noticeDomain.MaxImageBytes); err != nil {
+		apierror.Validation(w, "request too large or malformed")
+		return
+	}
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		apierror.Validation(w, "image is required")
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, noticeDomain.MaxImageBytes+1))
+	if err != nil {
+		apierror.Validation(w, "could not read image")
+		return
+	}
+	contentType := http.DetectContentType(data)
+	if err := noticeDomain.CheckImage(contentType, int64(len(data))); err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+
+	imageID := generateID()
+	path, _ := noticeImagePath(imageID)
+	if err := os.MkdirAll(noticeImageDir, 0o750); err != nil {
+		internalError(w, err)
+		return
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		internalError(w, err)
+		return
+	}
+	slog.InfoContext(r.Context(), "notice_event", "event", "notice_image_uploaded", "image_id", imageID, "account_id", sess.AccountID, "content_type", contentType, "size", len(data))
+
+	imageURL := "/api/notices/image?id=" + imageID
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(noticeImageUploadResponse{
+		ImageID:     imageID,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		URL:         imageURL,
+		Markdown:    "![](" + imageURL + ")",
+	})
+}
+
+// handleNoticeImage handles GET /api/notices/image?id=
+// Serves an image uploaded for a notice body to anyone signed in, as notices are.
+func handleNoticeImage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	if _, ok := middleware.GetSessionFromContext(r.Context()); !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	path, ok := noticeImagePath(r.URL.Query().Get("id"))
+	if !ok {
+		apierror.NotFound(w, "image not found")
+		return
 	}
-	n, err := orchestrators.ExecuteEditNotice(r.Context(), orchInput, orchestrators.EditNoticeDeps{
-		NoticeStore: stores.NoticeStore,
-		Now:         timeNow,
-	})
+	data, err := os.ReadFile(path)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.NotFound(w, "image not found")
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(n)
+	w.Header().Set("Content-Type", http.DetectContentType(data))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.Write(data)
 }
 
-// handleNoticePin handles POST /api/notices/pin (toggle pin/unpin)
-func handleNoticePin(w http.ResponseWriter, r *http.Request) {
+// noticePreviewRequest is the body of POST /api/notices/preview.
+type noticePreviewRequest struct {
+	Content string `json:"Content"`
+}
+
+// noticePreviewResponse is the response of POST /api/notices/preview.
+type noticePreviewResponse struct {
+	HTML string `json:"HTML"`
+}
+
+// handleNoticePreview handles POST /api/notices/preview
+// Renders notice markdown exactly as the notice board will, so the editor can show it before saving.
+func handleNoticePreview(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
 		return
 	}
-	var input struct {
-		NoticeID string `json:"NoticeID"`
-		Pinned   bool   `json:"Pinned"`
-	}
+	var input noticePreviewRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
-	n, err := orchestrators.ExecutePinNotice(r.Context(), orchestrators.PinNoticeInput{
-		NoticeID: input.NoticeID,
-		Pinned:   input.Pinned,
-	}, orchestrators.PinNoticeDeps{
-		NoticeStore: stores.NoticeStore,
-		Now:         timeNow,
-	})
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if len(input.Content) > noticeDomain.MaxContentLength {
+		apierror.Validation(w, "notice content cannot exceed 10000 characters")
+		return
+	}
+	var buf bytes.Buffer
+	if err := mdRenderer.Convert([]byte(input.Content), &buf); err != nil {
+		apierror.Validation(w, "could not render markdown")
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(n)
+	json.NewEncoder(w).Encode(noticePreviewResponse{HTML: buf.String()})
+}
+
+// gradingDecisionRequest is the body of POST /api/grading/proposals/decide.
+type gradingDecisionRequest struct {
+	ProposalID string `json:"ProposalID"`
+	Decision   string `json:"Decision"` // "approve" or "reject"
 }
 
 // handleGradingDecide handles POST /api/grading/proposals/decide
 func handleGradingDecide(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	sess, ok := requireAdmin(w, r)
 	if !ok {
 		return
 	}
-	var input struct {
-		ProposalID string `json:"ProposalID"`
-		Decision   string `json:"Decision"` // "approve" or "reject"
-	}
+	var input gradingDecisionRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
-		return
-	}
-	if input.ProposalID == "" {
-		http.Error(w, "ProposalID is required", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
-	proposal, err := stores.GradingProposalStore.GetByID(r.Context(), input.ProposalID)
+	proposal, err := decideProposal(r.Context(), sess.AccountID, input.ProposalID, input.Decision)
 	if err != nil {
-		http.Error(w, "proposal not found", http.StatusNotFound)
-		return
-	}
-
-	ctx := r.Context()
-	switch input.Decision {
-	case "approve":
-		if err := proposal.Approve(sess.AccountID); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		if err := stores.GradingProposalStore.Save(ctx, proposal); err != nil {
-			internalError(w, err)
-			return
-		}
-		// Create the official grading record
-		record := gradingDomain.Record{
-			ID:         generateID(),
-			MemberID:   proposal.MemberID,
-			Belt:       proposal.TargetBelt,
-			Stripe:     0,
-			PromotedAt: timeNow(),
-			ProposedBy: proposal.ProposedBy,
-			ApprovedBy: sess.AccountID,
-			Method:     gradingDomain.MethodStandard,
-		}
-		if err := stores.GradingRecordStore.Save(ctx, record); err != nil {
-			internalError(w, err)
-			return
-		}
-	case "reject":
-		if err := proposal.Reject(sess.AccountID); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		if err := stores.GradingProposalStore.Save(ctx, proposal); err != nil {
-			internalError(w, err)
-			return
-		}
-	default:
-		http.Error(w, "Decision must be 'approve' or 'reject'", http.StatusBadRequest)
+		writeDecisionError(w, err)
 		return
 	}
-
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(proposal)
 }
 
+// gradingConfigRequest is the body of POST /api/grading/config.
+type gradingConfigRequest struct {
+	Program         string  `json:"Program"`
+	Belt            string  `json:"Belt"`
+	FlightTimeHours float64 `json:"FlightTimeHours"`
+	AttendancePct   float64 `json:"AttendancePct"`
+	StripeCount     int     `json:"StripeCount"`
+}
+
 // handleGradingConfig handles GET/POST for /api/grading/config
 func handleGradingConfig(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -3124,15 +4255,9 @@ func handleGradingConfig(w http.ResponseWriter, r *http.Request) {
 		if _, ok := requireAdmin(w, r); !ok {
 			return
 		}
-		var input struct {
-			Program         string  `json:"Program"`
-			Belt            string  `json:"Belt"`
-			FlightTimeHours float64 `json:"FlightTimeHours"`
-			AttendancePct   float64 `json:"AttendancePct"`
-			StripeCount     int     `json:"StripeCount"`
-		}
+		var input gradingConfigRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		config := gradingDomain.Config{
@@ -3144,7 +4269,7 @@ func handleGradingConfig(w http.ResponseWriter, r *http.Request) {
 			StripeCount:     input.StripeCount,
 		}
 		if err := config.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.GradingConfigStore.Save(ctx, config); err != nil {
@@ -3157,166 +4282,119 @@ func handleGradingConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// adultReadinessEntry is a member's progress towards their next belt, graded by mat hours.
+type adultReadinessEntry struct {
+	MemberID     string                `json:"MemberID"`
+	MemberName   string                `json:"MemberName"`
+	Program      string                `json:"Program"`
+	CurrentBelt  string                `json:"CurrentBelt"`
+	TargetBelt   string                `json:"TargetBelt"`
+	MatHours     float64               `json:"MatHours"`
+	RequiredHrs  float64               `json:"RequiredHours"` // 0 when the belt's rule sets no hours
+	PercentReady float64               `json:"PercentReady"`  // the least-met criterion
+	Eligible     bool                  `json:"Eligible"`
+	Checks       []gradingDomain.Check `json:"Checks"`
+	RubricPct    float64               `json:"RubricPct"`         // recent rubric scores as a percentage of scale
+	RubricCount  int                   `json:"RubricAssessments"` // rubrics behind RubricPct; 0 means unscored
+}
+
+// kidsReadinessEntry is a child's progress towards their next belt by term attendance.
+type kidsReadinessEntry struct {
+	MemberID          string                `json:"MemberID"`
+	MemberName        string                `json:"MemberName"`
+	CurrentBelt       string                `json:"CurrentBelt"`
+	TargetBelt        string                `json:"TargetBelt"`
+	Attended          int                   `json:"Attended"`
+	MakeupCredits     int                   `json:"MakeupCredits"`
+	TotalSessions     int                   `json:"TotalSessions"`
+	CancelledSessions int                   `json:"CancelledSessions"`
+	AttendancePct     float64               `json:"AttendancePct"`
+	ThresholdPct      float64               `json:"ThresholdPct"`
+	Eligible          bool                  `json:"Eligible"`
+	Checks            []gradingDomain.Check `json:"Checks"`
+	RubricPct         float64               `json:"RubricPct"`         // recent rubric scores as a percentage of scale
+	RubricCount       int                   `json:"RubricAssessments"` // rubrics behind RubricPct; 0 means unscored
+}
+
+// readinessResponse is the body of GET /api/grading/readiness.
+type readinessResponse struct {
+	Adults   []adultReadinessEntry `json:"Adults"`
+	Kids     []kidsReadinessEntry  `json:"Kids"`
+	TermName string                `json:"TermName"`
 }
 
 // handleGradingReadiness handles GET /api/grading/readiness
+// Members graded by hours are listed once halfway to every criterion of their next belt's
+// rule; kids graded by sessions are listed with their term attendance.
 func handleGradingReadiness(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
-	sess, ok := middleware.GetSessionFromContext(r.Context())
-	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if _, ok := requirePermission(w, r, permissionDomain.ActionGradingManage); !ok {
 		return
 	}
 
 	ctx := r.Context()
-
-	// Get all grading configs
-	configs, err := stores.GradingConfigStore.List(ctx)
-	if err != nil {
-		internalError(w, err)
-		return
-	}
-
-	// Get all active members
-	members, err := stores.MemberStore.List(ctx, memberStore.ListFilter{Limit: 10000})
+	result, err := projections.QueryGetGradingEligibility(ctx, projections.GetGradingEligibilityQuery{Now: timeNow()}, gradingEligibilityDeps())
 	if err != nil {
 		internalError(w, err)
 		return
 	}
 
-	type adultEntry struct {
-		MemberID     string  `json:"MemberID"`
-		MemberName   string  `json:"MemberName"`
-		Program      string  `json:"Program"`
-		CurrentBelt  string  `json:"CurrentBelt"`
-		TargetBelt   string  `json:"TargetBelt"`
-		MatHours     float64 `json:"MatHours"`
-		RequiredHrs  float64 `json:"RequiredHours"`
-		PercentReady float64 `json:"PercentReady"`
-	}
-
-	type kidsEntry struct {
-		MemberID      string  `json:"MemberID"`
-		MemberName    string  `json:"MemberName"`
-		CurrentBelt   string  `json:"CurrentBelt"`
-		TargetBelt    string  `json:"TargetBelt"`
-		Attended      int     `json:"Attended"`
-		TotalSessions int     `json:"TotalSessions"`
-		AttendancePct float64 `json:"AttendancePct"`
-		ThresholdPct  float64 `json:"ThresholdPct"`
-		Eligible      bool    `json:"Eligible"`
-	}
-
-	type readinessResponse struct {
-		Adults   []adultEntry `json:"Adults"`
-		Kids     []kidsEntry  `json:"Kids"`
-		TermName string       `json:"TermName"`
-	}
-
-	var adults []adultEntry
-	for _, m := range members {
-		if m.Status != "active" {
-			continue
-		}
-		// Skip kids in sessions mode ΓÇö they appear in kids term attendance section
-		if m.Program == "kids" && m.GradingMetric != memberDomain.MetricHours {
-			continue
-		}
-		// Get member's latest grading record to find current belt
-		records, err := stores.GradingRecordStore.ListByMemberID(ctx, m.ID)
-		if err != nil {
-			continue
-		}
-		currentBelt := "white"
-		if len(records) > 0 {
-			currentBelt = records[len(records)-1].Belt
-		}
-
-		// Find the config for their program + next belt
-		nextBelt := nextBeltFor(currentBelt, m.Program)
-		if nextBelt == "" {
-			continue // already at highest belt
-		}
-
-		var requiredHours float64
-		for _, c := range configs {
-			if c.Program == m.Program && c.Belt == nextBelt {
-				requiredHours = c.FlightTimeHours
-				break
+	var adults []adultReadinessEntry
+	var kids []kidsReadinessEntry
+	for _, e := range result.Entries {
+		if !e.ByAttendance {
+			if e.Progress < 50 { // only show members at 50%+ readiness
+				continue
 			}
-		}
-		if requiredHours <= 0 {
-			continue // no config for this belt
-		}
-
-		// Get training log for mat hours
-		query := projections.GetTrainingLogQuery{MemberID: m.ID}
-		deps := projections.GetTrainingLogDeps{
-			AttendanceStore: stores.AttendanceStore,
-			MemberStore:     stores.MemberStore,
-		}
-		log, err := projections.QueryGetTrainingLog(ctx, query, deps)
-		if err != nil {
+			entry := adultReadinessEntry{
+				MemberID:     e.MemberID,
+				MemberName:   e.MemberName,
+				Program:      e.Program,
+				CurrentBelt:  e.CurrentBelt,
+				TargetBelt:   e.TargetBelt,
+				PercentReady: e.Progress,
+				Eligible:     e.Eligible,
+				Checks:       e.Checks,
+			}
+			for _, c := range e.Checks {
+				if c.Kind == gradingDomain.CriterionMatHours {
+					entry.MatHours, entry.RequiredHrs = c.Actual, c.Min
+				}
+			}
+			entry.RubricPct, entry.RubricCount = recentRubricAverage(ctx, e.MemberID)
+			adults = append(adults, entry)
 			continue
 		}
-
-		pct := (log.TotalMatHours / requiredHours) * 100
-		if pct > 100 {
-			pct = 100
-		}
-		if pct >= 50 { // only show members at 50%+ readiness
-			adults = append(adults, adultEntry{
-				MemberID:     m.ID,
-				MemberName:   m.Name,
-				Program:      m.Program,
-				CurrentBelt:  currentBelt,
-				TargetBelt:   nextBelt,
-				MatHours:     log.TotalMatHours,
-				RequiredHrs:  requiredHours,
-				PercentReady: pct,
-			})
-		}
-	}
-
-	// Kids term attendance readiness
-	var kids []kidsEntry
-	termName := ""
-	kidsQuery := projections.GetKidsTermReadinessQuery{Now: time.Now()}
-	kidsDeps := projections.GetKidsTermReadinessDeps{
-		TermStore:          stores.TermStore,
-		ProgramStore:       stores.ProgramStore,
-		ClassTypeStore:     stores.ClassTypeStore,
-		ScheduleStore:      stores.ScheduleStore,
-		HolidayStore:       stores.HolidayStore,
-		MemberStore:        stores.MemberStore,
-		AttendanceStore:    stores.AttendanceStore,
-		GradingRecordStore: stores.GradingRecordStore,
-		GradingConfigStore: stores.GradingConfigStore,
-	}
-	kidsResult, err := projections.QueryGetKidsTermReadiness(ctx, kidsQuery, kidsDeps)
-	if err == nil {
-		termName = kidsResult.TermName
-		for _, e := range kidsResult.Entries {
-			kids = append(kids, kidsEntry{
-				MemberID:      e.MemberID,
-				MemberName:    e.MemberName,
-				CurrentBelt:   e.CurrentBelt,
-				TargetBelt:    e.TargetBelt,
-				Attended:      e.Attended,
-				TotalSessions: e.TotalSessions,
-				AttendancePct: e.AttendancePct,
-				ThresholdPct:  e.ThresholdPct,
-				Eligible:      e.Eligible,
-			})
+		if e.Term == nil {
+			continue // no current term to measure attendance against
+		}
+		entry := kidsReadinessEntry{
+			MemberID:          e.MemberID,
+			MemberName:        e.MemberName,
+			CurrentBelt:       e.CurrentBelt,
+			TargetBelt:        e.TargetBelt,
+			Attended:          e.Term.Attended,
+			MakeupCredits:     e.Term.MakeupCredits,
+			TotalSessions:     e.Term.TotalSessions,
+			CancelledSessions: e.Term.CancelledSessions,
+			AttendancePct:     e.Term.AttendancePct,
+			ThresholdPct:      e.Term.ThresholdPct,
+			Eligible:          e.Eligible,
+			Checks:            e.Checks,
+		}
+		for _, c := range e.Checks {
+			if c.Kind == gradingDomain.CriterionAttendancePct {
+				entry.ThresholdPct = c.Min
+			}
 		}
+		entry.RubricPct, entry.RubricCount = recentRubricAverage(ctx, e.MemberID)
+		kids = append(kids, entry)
 	}
 
 	// Sort by proximity to eligibility (highest % first) ΓÇö #63
@@ -3327,36 +4405,55 @@ func handleGradingReadiness(w http.ResponseWriter, r *http.Request) {
 		return kids[i].AttendancePct > kids[j].AttendancePct
 	})
 
-	resp := readinessResponse{Adults: adults, Kids: kids, TermName: termName}
+	resp := readinessResponse{Adults: adults, Kids: kids, TermName: result.TermName}
 	if resp.Adults == nil {
-		resp.Adults = []adultEntry{}
+		resp.Adults = []adultReadinessEntry{}
 	}
 	if resp.Kids == nil {
-		resp.Kids = []kidsEntry{}
+		resp.Kids = []kidsReadinessEntry{}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
 
+// recentRubricAverage returns a member's rubric scores over rubricDomain.ReadinessWindow as a
+// percentage of scale, and how many rubrics that covers. Errors read as unscored so readiness still loads.
+func recentRubricAverage(ctx context.Context, memberID string) (float64, int) {
+	if stores.RubricScoreStore == nil {
+		return 0, 0
+	}
+	history, err := projections.QueryGetRubricHistory(ctx, projections.GetRubricHistoryQuery{
+		MemberID: memberID,
+		Since:    timeNow().Add(-rubricDomain.ReadinessWindow),
+	}, projections.GetRubricHistoryDeps{ScoreStore: stores.RubricScoreStore})
+	if err != nil {
+		return 0, 0
+	}
+	return history.OverallPct, len(history.Assessments)
+}
+
+// gradingForcePromoteRequest is the body of POST /api/grading/force-promote.
+type gradingForcePromoteRequest struct {
+	MemberID   string `json:"MemberID"`
+	TargetBelt string `json:"TargetBelt"`
+	Reason     string `json:"Reason"`
+}
+
 // handleGradingForcePromote handles POST /api/grading/force-promote
 // Allows admin to immediately promote a member, bypassing the proposal flow.
 func handleGradingForcePromote(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	sess, ok := requireAdmin(w, r)
 	if !ok {
 		return
 	}
-	var input struct {
-		MemberID   string `json:"MemberID"`
-		TargetBelt string `json:"TargetBelt"`
-		Reason     string `json:"Reason"`
-	}
+	var input gradingForcePromoteRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 	record := gradingDomain.Record{
@@ -3370,36 +4467,39 @@ func handleGradingForcePromote(w http.ResponseWriter, r *http.Request) {
 		Method:     gradingDomain.MethodOverride,
 	}
 	if err := record.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 	if err := stores.GradingRecordStore.Save(r.Context(), record); err != nil {
 		internalError(w, err)
 		return
 	}
+	takeGradingStock(r.Context(), record)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(record)
 }
 
+// gradingMemberConfigRequest is the body of POST /api/grading/member-config.
+type gradingMemberConfigRequest struct {
+	MemberID        string  `json:"MemberID"`
+	Belt            string  `json:"Belt"`
+	FlightTimeHours float64 `json:"FlightTimeHours"`
+	AttendancePct   float64 `json:"AttendancePct"`
+}
+
 // handleGradingMemberConfig handles GET/POST for /api/grading/member-config
 // Allows admin to set per-member threshold overrides for grading eligibility.
 func handleGradingMemberConfig(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	if r.Method == "GET" {
-		sess, ok := middleware.GetSessionFromContext(ctx)
-		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
-			return
-		}
-		if sess.Role != "admin" && sess.Role != "coach" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+		if _, ok := requirePermission(w, r, permissionDomain.ActionGradingManage); !ok {
 			return
 		}
 		memberID := r.URL.Query().Get("member_id")
 		if memberID == "" {
-			http.Error(w, "member_id is required", http.StatusBadRequest)
+			apierror.Validation(w, "member_id is required")
 			return
 		}
 		configs, err := stores.GradingMemberConfigStore.ListByMemberID(ctx, memberID)
@@ -3422,14 +4522,9 @@ func handleGradingMemberConfig(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		_ = sess
-		var input struct {
-			MemberID        string  `json:"MemberID"`
-			Belt            string  `json:"Belt"`
-			FlightTimeHours float64 `json:"FlightTimeHours"`
-			AttendancePct   float64 `json:"AttendancePct"`
-		}
+		var input gradingMemberConfigRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		mc := gradingDomain.MemberConfig{
@@ -3440,7 +4535,7 @@ func handleGradingMemberConfig(w http.ResponseWriter, r *http.Request) {
 			AttendancePct:   input.AttendancePct,
 		}
 		if err := mc.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.GradingMemberConfigStore.Save(ctx, mc); err != nil {
@@ -3453,35 +4548,38 @@ func handleGradingMemberConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// gradingCreditRequest is the body of POST /api/grading/credit.
+type gradingCreditRequest struct {
+	MemberID string  `json:"MemberID"`
+	Hours    float64 `json:"Hours"`
+	Reason   string  `json:"Reason"`
 }
 
 // handleGradingCredit handles POST /api/grading/credit
 // Allows admin to add a direct mat hours credit to a member's record.
 func handleGradingCredit(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	sess, ok := requireAdmin(w, r)
 	if !ok {
 		return
 	}
-	var input struct {
-		MemberID string  `json:"MemberID"`
-		Hours    float64 `json:"Hours"`
-		Reason   string  `json:"Reason"`
-	}
+	var input gradingCreditRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 	if input.MemberID == "" {
-		http.Error(w, "MemberID is required", http.StatusBadRequest)
+		apierror.Validation(w, "MemberID is required")
 		return
 	}
 	if input.Hours <= 0 || input.Hours > 1000 {
-		http.Error(w, "Hours must be between 0 and 1000", http.StatusBadRequest)
+		apierror.Validation(w, "Hours must be between 0 and 1000")
 		return
 	}
 	today := timeNow().Format("2006-01-02")
@@ -3499,7 +4597,7 @@ func handleGradingCredit(w http.ResponseWriter, r *http.Request) {
 		CreatedAt:   timeNow(),
 	}
 	if err := entry.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 	if err := stores.EstimatedHoursStore.Save(r.Context(), entry); err != nil {
@@ -3511,44 +4609,41 @@ func handleGradingCredit(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(entry)
 }
 
+// gradingMetricRequest is the body of POST /api/grading/metric.
+type gradingMetricRequest struct {
+	MemberID string `json:"MemberID"`
+	Metric   string `json:"Metric"`
+}
+
 // handleGradingMetricToggle handles POST /api/grading/metric
 // Toggles a kid's grading metric between "sessions" and "hours".
 func handleGradingMetricToggle(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
-		return
-	}
-	sess, ok := middleware.GetSessionFromContext(r.Context())
-	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.MethodNotAllowed(w)
 		return
 	}
-	if sess.Role != "admin" && sess.Role != "coach" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if _, ok := requirePermission(w, r, permissionDomain.ActionGradingManage); !ok {
 		return
 	}
 
-	var body struct {
-		MemberID string `json:"MemberID"`
-		Metric   string `json:"Metric"`
-	}
+	var body gradingMetricRequest
 	if err := strictDecode(r, &body); err != nil {
-		http.Error(w, "Invalid request", http.StatusBadRequest)
+		apierror.Validation(w, "Invalid request")
 		return
 	}
 	if body.Metric != memberDomain.MetricSessions && body.Metric != memberDomain.MetricHours {
-		http.Error(w, "Metric must be 'sessions' or 'hours'", http.StatusBadRequest)
+		apierror.Validation(w, "Metric must be 'sessions' or 'hours'")
 		return
 	}
 
 	ctx := r.Context()
 	m, err := stores.MemberStore.GetByID(ctx, body.MemberID)
 	if err != nil {
-		http.Error(w, "Member not found", http.StatusNotFound)
+		apierror.NotFound(w, "Member not found")
 		return
 	}
 	if m.Program != memberDomain.ProgramKids {
-		http.Error(w, "Metric toggle is only for kids", http.StatusBadRequest)
+		apierror.Validation(w, "Metric toggle is only for kids")
 		return
 	}
 
@@ -3577,20 +4672,27 @@ func nextBeltFor(current, program string) string {
 	return ""
 }
 
+// trainingGoalCreateRequest is the body of POST /api/training-goals.
+type trainingGoalCreateRequest struct {
+	MemberID string `json:"MemberID"`
+	Target   int    `json:"Target"`
+	Period   string `json:"Period"`
+}
+
 // handleTrainingGoals handles GET/POST/DELETE for /api/training-goals
 func handleTrainingGoals(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	sess, ok := middleware.GetSessionFromContext(ctx)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 
 	if r.Method == "GET" {
 		memberID := r.URL.Query().Get("member_id")
 		if memberID == "" {
-			http.Error(w, "member_id is required", http.StatusBadRequest)
+			apierror.Validation(w, "member_id is required")
 			return
 		}
 		goals, err := stores.TrainingGoalStore.ListByMemberID(ctx, memberID)
@@ -3608,13 +4710,9 @@ func handleTrainingGoals(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "POST" {
-		var input struct {
-			MemberID string `json:"MemberID"`
-			Target   int    `json:"Target"`
-			Period   string `json:"Period"`
-		}
+		var input trainingGoalCreateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		goal := trainingGoalDomain.TrainingGoal{
@@ -3626,9 +4724,17 @@ func handleTrainingGoals(w http.ResponseWriter, r *http.Request) {
 			Active:    true,
 		}
 		if err := goal.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
+		// A member has one active goal; a new one replaces it.
+		if current, err := stores.TrainingGoalStore.GetActiveByMemberID(ctx, goal.MemberID); err == nil && current.ID != "" {
+			current.Active = false
+			if err := stores.TrainingGoalStore.Save(ctx, current); err != nil {
+				internalError(w, err)
+				return
+			}
+		}
 		if err := stores.TrainingGoalStore.Save(ctx, goal); err != nil {
 			internalError(w, err)
 			return
@@ -3642,7 +4748,7 @@ func handleTrainingGoals(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "DELETE" {
 		id := r.URL.Query().Get("id")
 		if id == "" {
-			http.Error(w, "id is required", http.StatusBadRequest)
+			apierror.Validation(w, "id is required")
 			return
 		}
 		if err := stores.TrainingGoalStore.Delete(ctx, id); err != nil {
@@ -3654,7 +4760,15 @@ func handleTrainingGoals(w http.ResponseWriter, r *http.Request) {
 	}
 
 	_ = sess // used for auth check
-	http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// milestoneCreateRequest is the body of POST /api/milestones.
+type milestoneCreateRequest struct {
+	Name      string  `json:"Name"`
+	Metric    string  `json:"Metric"`
+	Threshold float64 `json:"Threshold"`
+	BadgeIcon string  `json:"BadgeIcon"`
 }
 
 // handleMilestones handles GET/POST/DELETE for /api/milestones
@@ -3663,7 +4777,7 @@ func handleMilestones(w http.ResponseWriter, r *http.Request) {
 
 	if r.Method == "GET" {
 		if _, ok := middleware.GetSessionFromContext(ctx); !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
+			apierror.Unauthorized(w, "not authenticated")
 			return
 		}
 		milestones, err := stores.MilestoneStore.List(ctx)
@@ -3684,14 +4798,9 @@ func handleMilestones(w http.ResponseWriter, r *http.Request) {
 		if _, ok := requireAdmin(w, r); !ok {
 			return
 		}
-		var input struct {
-			Name      string  `json:"Name"`
-			Metric    string  `json:"Metric"`
-			Threshold float64 `json:"Threshold"`
-			BadgeIcon string  `json:"BadgeIcon"`
-		}
+		var input milestoneCreateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		ms := milestoneDomain.Milestone{
@@ -3702,7 +4811,7 @@ func handleMilestones(w http.ResponseWriter, r *http.Request) {
 			BadgeIcon: input.BadgeIcon,
 		}
 		if err := ms.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.MilestoneStore.Save(ctx, ms); err != nil {
@@ -3721,7 +4830,7 @@ func handleMilestones(w http.ResponseWriter, r *http.Request) {
 		}
 		id := r.URL.Query().Get("id")
 		if id == "" {
-			http.Error(w, "id is required", http.StatusBadRequest)
+			apierror.Validation(w, "id is required")
 			return
 		}
 		if err := stores.MilestoneStore.Delete(ctx, id); err != nil {
@@ -3732,23 +4841,23 @@ func handleMilestones(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
 }
 
 // handleMemberMilestones handles GET /api/member-milestones?member_id=<id>
 // Returns earned milestones for the member, evaluating current training stats.
 func handleMemberMilestones(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := middleware.GetSessionFromContext(r.Context()); !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	memberID := r.URL.Query().Get("member_id")
 	if memberID == "" {
-		http.Error(w, "member_id is required", http.StatusBadRequest)
+		apierror.Validation(w, "member_id is required")
 		return
 	}
 
@@ -3779,6 +4888,15 @@ func handleMemberMilestones(w http.ResponseWriter, r *http.Request) {
 		internalError(w, err)
 		return
 	}
+	for _, e := range earned {
+		if e.New {
+			notifyMember(r.Context(), memberID, orchestrators.NotifyInput{
+				Kind:  notificationDomain.KindMilestoneEarned,
+				Title: "Milestone earned: " + e.Milestone.Name,
+				Link:  "/training-log",
+			})
+		}
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	if earned == nil {
@@ -3788,26 +4906,29 @@ func handleMemberMilestones(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(earned)
 }
 
+// milestoneDismissRequest is the body of POST /api/member-milestones/dismiss.
+type milestoneDismissRequest struct {
+	ID string `json:"ID"`
+}
+
 // handleMemberMilestoneDismiss handles POST /api/member-milestones/dismiss
 // Marks a milestone notification as seen.
 func handleMemberMilestoneDismiss(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := middleware.GetSessionFromContext(r.Context()); !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
-	var input struct {
-		ID string `json:"ID"`
-	}
+	var input milestoneDismissRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 	if input.ID == "" {
-		http.Error(w, "ID is required", http.StatusBadRequest)
+		apierror.Validation(w, "ID is required")
 		return
 	}
 	if err := stores.MemberMilestoneStore.MarkNotified(r.Context(), input.ID); err != nil {
@@ -3817,30 +4938,40 @@ func handleMemberMilestoneDismiss(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// messageReadRequest is the body of POST /api/messages/read; set MessageID or ThreadID.
+type messageReadRequest struct {
+	MessageID string `json:"MessageID"`
+	ThreadID  string `json:"ThreadID"`
+}
+
 // handleMessageRead handles POST /api/messages/read
+// With ThreadID, marks every message in the thread that is waiting for the caller's side as read.
 func handleMessageRead(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
-	if _, ok := middleware.GetSessionFromContext(r.Context()); !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
-	var input struct {
-		MessageID string `json:"MessageID"`
-	}
+	var input messageReadRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if input.ThreadID != "" {
+		markThreadRead(w, r, sess, input.ThreadID)
 		return
 	}
 	if input.MessageID == "" {
-		http.Error(w, "MessageID is required", http.StatusBadRequest)
+		apierror.Validation(w, "MessageID is required")
 		return
 	}
 	msg, err := stores.MessageStore.GetByID(r.Context(), input.MessageID)
 	if err != nil {
-		http.Error(w, "message not found", http.StatusNotFound)
+		apierror.NotFound(w, "message not found")
 		return
 	}
 	msg.MarkRead()
@@ -3854,6 +4985,35 @@ func handleMessageRead(w http.ResponseWriter, r *http.Request) {
 
 // --- Admin Page Handlers ---
 
+// classTypeCreateRequest is the body of POST /api/class-types.
+type classTypeCreateRequest struct {
+	ProgramID   string `json:"ProgramID"`
+	Name        string `json:"Name"`
+	Description string `json:"Description"`
+	Attire      string `json:"Attire"`
+	Level       string `json:"Level"`
+	LocationID  string `json:"LocationID"`
+	MatCapacity int    `json:"MatCapacity"` // 0 = no limit
+
+	MinBelt          string `json:"MinBelt"`          // optional: members below this belt need a coach's approval
+	RequiresApproval bool   `json:"RequiresApproval"` // only approved members may check in
+}
+
+// classTypeUpdateRequest is the body of PUT /api/class-types.
+type classTypeUpdateRequest struct {
+	ID          string `json:"ID"`
+	ProgramID   string `json:"ProgramID"`
+	Name        string `json:"Name"`
+	Description string `json:"Description"`
+	Attire      string `json:"Attire"`
+	Level       string `json:"Level"`
+	LocationID  string `json:"LocationID"`
+	MatCapacity int    `json:"MatCapacity"` // 0 = no limit
+
+	MinBelt          string `json:"MinBelt"`          // optional: members below this belt need a coach's approval
+	RequiresApproval bool   `json:"RequiresApproval"` // only approved members may check in
+}
+
 // handleClassTypes handles GET /api/class-types
 func handleClassTypes(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -3873,6 +5033,7 @@ func handleClassTypes(w http.ResponseWriter, r *http.Request) {
 			internalError(w, err)
 			return
 		}
+		types = filterClassTypesByLocation(types, requestLocationID(r))
 		w.Header().Set("Content-Type", "application/json")
 		if types == nil {
 			w.Write([]byte("[]"))
@@ -3888,15 +5049,9 @@ func handleClassTypes(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "POST" {
-		var input struct {
-			ProgramID   string `json:"ProgramID"`
-			Name        string `json:"Name"`
-			Description string `json:"Description"`
-			Attire      string `json:"Attire"`
-			Level       string `json:"Level"`
-		}
+		var input classTypeCreateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		ct := classTypeDomain.ClassType{
@@ -3906,9 +5061,18 @@ func handleClassTypes(w http.ResponseWriter, r *http.Request) {
 			Description: input.Description,
 			Attire:      input.Attire,
 			Level:       input.Level,
+			LocationID:  input.LocationID,
+			MatCapacity: input.MatCapacity,
+
+			MinBelt:          input.MinBelt,
+			RequiresApproval: input.RequiresApproval,
 		}
 		if err := ct.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if !validMinBelt(ct.MinBelt) {
+			apierror.Validation(w, "unknown belt")
 			return
 		}
 		if err := stores.ClassTypeStore.Save(ctx, ct); err != nil {
@@ -3922,20 +5086,13 @@ func handleClassTypes(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "PUT" {
-		var input struct {
-			ID          string `json:"ID"`
-			ProgramID   string `json:"ProgramID"`
-			Name        string `json:"Name"`
-			Description string `json:"Description"`
-			Attire      string `json:"Attire"`
-			Level       string `json:"Level"`
-		}
+		var input classTypeUpdateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		if input.ID == "" {
-			http.Error(w, "ID is required", http.StatusBadRequest)
+			apierror.Validation(w, "ID is required")
 			return
 		}
 		ct := classTypeDomain.ClassType{
@@ -3945,9 +5102,18 @@ func handleClassTypes(w http.ResponseWriter, r *http.Request) {
 			Description: input.Description,
 			Attire:      input.Attire,
 			Level:       input.Level,
+			LocationID:  input.LocationID,
+			MatCapacity: input.MatCapacity,
+
+			MinBelt:          input.MinBelt,
+			RequiresApproval: input.RequiresApproval,
 		}
 		if err := ct.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if !validMinBelt(ct.MinBelt) {
+			apierror.Validation(w, "unknown belt")
 			return
 		}
 		if err := stores.ClassTypeStore.Save(ctx, ct); err != nil {
@@ -3962,7 +5128,7 @@ func handleClassTypes(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "DELETE" {
 		id := r.URL.Query().Get("id")
 		if id == "" {
-			http.Error(w, "id is required", http.StatusBadRequest)
+			apierror.Validation(w, "id is required")
 			return
 		}
 		if err := stores.ClassTypeStore.Delete(ctx, id); err != nil {
@@ -3973,14 +5139,14 @@ func handleClassTypes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
 }
 
 // handlePrograms handles GET /api/programs (admin-only).
 func handlePrograms(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
@@ -4095,45 +5261,245 @@ func handleAdminGradingPage(w http.ResponseWriter, r *http.Request) {
 	renderTemplate(w, r, "admin_grading.html", nil)
 }
 
-// handleAdminInactivePage handles GET /admin/inactive
-func handleAdminInactivePage(w http.ResponseWriter, r *http.Request) {
+// handleAdminInactivePage handles GET /admin/inactive
+func handleAdminInactivePage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, ok := requireAdmin(w, r); !ok {
+		return
+	}
+	renderTemplate(w, r, "admin_inactive.html", nil)
+}
+
+// handleAdminMilestonesPage handles GET /admin/milestones
+func handleAdminMilestonesPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, ok := requireAdmin(w, r); !ok {
+		return
+	}
+	renderTemplate(w, r, "admin_milestones.html", nil)
+}
+
+// perfPageData is the performance dashboard, with the entries of one request when ?request_id= is given.
+type perfPageData struct {
+	perf.Snapshot
+	RequestID string
+	Trace     []perf.Entry
+	Plans     []perf.QueryPlan // full scans found by the query plan guard; empty when it is off
+}
+
+// handleAdminPerfPage handles GET /admin/perf
+func handleAdminPerfPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, ok := requireAdmin(w, r); !ok {
+		return
+	}
+	if perfCollector == nil {
+		renderTemplate(w, r, "admin_perf.html", nil)
+		return
+	}
+	data := perfPageData{
+		Snapshot:  perfCollector.Snapshot(time.Now().Add(-1*time.Hour), 10),
+		RequestID: strings.TrimSpace(r.URL.Query().Get("request_id")),
+		Plans:     perfCollector.QueryPlans(),
+	}
+	if data.RequestID != "" {
+		data.Trace = perfCollector.Trace(data.RequestID)
+	}
+	renderTemplate(w, r, "admin_perf.html", data)
+}
+
+// perfHistoryWindows are the preset windows of GET /api/admin/perf/history.
+var perfHistoryWindows = map[string]time.Duration{
+	"1h":  time.Hour,
+	"6h":  6 * time.Hour,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// perfHistoryMaxPoints caps the chart resolution; longer windows use wider steps.
+const perfHistoryMaxPoints = 120
+
+// perfHistoryView is request and query latency over a window, from persisted minute buckets.
+type perfHistoryView struct {
+	Since       time.Time
+	Until       time.Time
+	StepSeconds int
+	Requests    perfstatsDomain.WindowStat   // all requests in the window
+	Queries     perfstatsDomain.WindowStat   // all queries in the window
+	Points      []perfstatsDomain.Point      // request latency per step, for the chart
+	Routes      []perfstatsDomain.WindowStat // slowest routes by P95
+	Operations  []perfstatsDomain.WindowStat // slowest query operations by P95
+}
+
+// handleAdminPerfHistory handles GET /api/admin/perf/history
+// Returns P50/P95/P99 latency over a preset window (?window=1h|6h|24h|7d|30d, default 24h) or
+// between ?from= and ?to= (RFC 3339). History is saved a minute at a time, so the minute in
+// progress is not included yet.
+func handleAdminPerfHistory(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
 		return
 	}
-	renderTemplate(w, r, "admin_inactive.html", nil)
+	until := timeNow().UTC()
+	since := until.Add(-perfHistoryWindows["24h"])
+	q := r.URL.Query()
+	if q.Get("from") != "" || q.Get("to") != "" {
+		from, errFrom := time.Parse(time.RFC3339, q.Get("from"))
+		to, errTo := time.Parse(time.RFC3339, q.Get("to"))
+		if errFrom != nil || errTo != nil || !from.Before(to) {
+			apierror.Validation(w, "from and to must be RFC 3339 times with from before to")
+			return
+		}
+		since, until = from.UTC(), to.UTC()
+	} else if name := q.Get("window"); name != "" {
+		window, ok := perfHistoryWindows[name]
+		if !ok {
+			apierror.Validation(w, "window must be one of 1h, 6h, 24h, 7d or 30d")
+			return
+		}
+		since = until.Add(-window)
+	}
+	step := (until.Sub(since)/perfHistoryMaxPoints + perfstatsDomain.BucketWidth - 1).Truncate(perfstatsDomain.BucketWidth)
+	if step < perfstatsDomain.BucketWidth {
+		step = perfstatsDomain.BucketWidth
+	}
+	since = since.Truncate(step)
+
+	var buckets []perfstatsDomain.Bucket
+	if stores != nil && stores.PerfBucketStore != nil {
+		var err error
+		buckets, err = stores.PerfBucketStore.ListBuckets(r.Context(), since, until)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+	}
+	view := perfHistoryView{Since: since, Until: until, StepSeconds: int(step.Seconds())}
+	view.Requests, view.Routes = perfstatsDomain.Summarise(buckets, perfstatsDomain.KindRequest, 10)
+	view.Queries, view.Operations = perfstatsDomain.Summarise(buckets, perfstatsDomain.KindQuery, 10)
+	view.Points = perfstatsDomain.Series(buckets, perfstatsDomain.KindRequest, since, until, step)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(view)
 }
 
-// handleAdminMilestonesPage handles GET /admin/milestones
-func handleAdminMilestonesPage(w http.ResponseWriter, r *http.Request) {
+// handleMetrics handles GET /metrics
+// Exposes request and query latency, outbox depth and worker health in Prometheus text format.
+// Authenticated by the WORKSHOP_METRICS_TOKEN bearer token; the endpoint is hidden when it is unset.
+func handleMetrics(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
-	if _, ok := requireAdmin(w, r); !ok {
+	if metricsToken == "" {
+		http.NotFound(w, r)
 		return
 	}
-	renderTemplate(w, r, "admin_milestones.html", nil)
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(metricsToken)) != 1 {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var outboxCounts map[string]int
+	if stores != nil && stores.OutboxStore != nil {
+		counts, err := stores.OutboxStore.CountByStatus(r.Context())
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		outboxCounts = counts
+	}
+
+	var buf bytes.Buffer
+	if perfCollector != nil {
+		perf.WriteHistogram(&buf, "workshop_http_request_duration_seconds", "HTTP request latency by route pattern.",
+			perfCollector.RequestHistograms(), func(s perf.HistogramSample) []perf.Label {
+				return []perf.Label{{Name: "method", Value: s.Method}, {Name: "route", Value: s.Route}, {Name: "code", Value: strconv.Itoa(s.StatusCode)}}
+			})
+		perf.WriteHistogram(&buf, "workshop_db_query_duration_seconds", "Database call latency by operation.",
+			perfCollector.QueryHistograms(), func(s perf.HistogramSample) []perf.Label {
+				return []perf.Label{{Name: "op", Value: s.Op}}
+			})
+	}
+	if outboxCounts != nil {
+		writeOutboxMetrics(&buf, outboxCounts)
+	}
+	if workerMonitor != nil {
+		writeWorkerMetrics(&buf)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(buf.Bytes())
 }
 
-// handleAdminPerfPage handles GET /admin/perf
-func handleAdminPerfPage(w http.ResponseWriter, r *http.Request) {
-	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
-		return
+// outboxStatuses lists every outbox status so each series exists even at zero.
+var outboxStatuses = []string{
+	outboxDomain.StatusPending,
+	outboxDomain.StatusRetrying,
+	outboxDomain.StatusDone,
+	outboxDomain.StatusFailed,
+	outboxDomain.StatusAbandoned,
+}
+
+// writeOutboxMetrics writes the queue depth and per-status entry counts.
+func writeOutboxMetrics(buf *bytes.Buffer, counts map[string]int) {
+	depth := counts[outboxDomain.StatusPending] + counts[outboxDomain.StatusRetrying]
+	perf.WriteMetric(buf, "workshop_outbox_queue_depth", "Outbox entries waiting to be sent (pending or retrying).", "gauge",
+		[]perf.Sample{{Value: float64(depth)}})
+
+	samples := make([]perf.Sample, 0, len(outboxStatuses))
+	for _, status := range outboxStatuses {
+		samples = append(samples, perf.Sample{Labels: []perf.Label{{Name: "status", Value: status}}, Value: float64(counts[status])})
 	}
-	if _, ok := requireAdmin(w, r); !ok {
-		return
+	perf.WriteMetric(buf, "workshop_outbox_entries", "Outbox entries by status.", "gauge", samples)
+}
+
+// writeWorkerMetrics writes lag, health and run counters for each background worker.
+func writeWorkerMetrics(buf *bytes.Buffer) {
+	now := timeNow()
+	var lag, healthy, running, runs, failures, duration []perf.Sample
+	for _, s := range workerMonitor.Snapshot() {
+		labels := []perf.Label{{Name: "worker", Value: s.Name}}
+		last := s.LastSuccessAt
+		if last.IsZero() {
+			last = s.StartedAt
+		}
+		lag = append(lag, perf.Sample{Labels: labels, Value: now.Sub(last).Seconds()})
+		healthy = append(healthy, perf.Sample{Labels: labels, Value: boolMetric(s.Healthy)})
+		running = append(running, perf.Sample{Labels: labels, Value: boolMetric(s.Running)})
+		runs = append(runs, perf.Sample{Labels: labels, Value: float64(s.Runs)})
+		failures = append(failures, perf.Sample{Labels: labels, Value: float64(s.Failures)})
+		duration = append(duration, perf.Sample{Labels: labels, Value: s.LastDuration.Seconds()})
 	}
-	if perfCollector == nil {
-		renderTemplate(w, r, "admin_perf.html", nil)
-		return
+	perf.WriteMetric(buf, "workshop_worker_lag_seconds", "Seconds since the worker last succeeded, or started if it never has.", "gauge", lag)
+	perf.WriteMetric(buf, "workshop_worker_healthy", "1 if the worker succeeded within three intervals and is still looping.", "gauge", healthy)
+	perf.WriteMetric(buf, "workshop_worker_running", "1 while a worker run is in progress.", "gauge", running)
+	perf.WriteMetric(buf, "workshop_worker_runs_total", "Worker runs since startup.", "counter", runs)
+	perf.WriteMetric(buf, "workshop_worker_failures_total", "Failed worker runs since startup.", "counter", failures)
+	perf.WriteMetric(buf, "workshop_worker_last_duration_seconds", "Duration of the worker's most recent run.", "gauge", duration)
+}
+
+// boolMetric renders a boolean as a 0/1 gauge value.
+func boolMetric(b bool) float64 {
+	if b {
+		return 1
 	}
-	snap := perfCollector.Snapshot(time.Now().Add(-1*time.Hour), 10)
-	renderTemplate(w, r, "admin_perf.html", snap)
+	return 0
 }
 
 // handleTrainingLogPage handles GET /training-log
@@ -4205,12 +5571,12 @@ func handleMemberInboxPage(w http.ResponseWriter, r *http.Request) {
 // handleMemberInboxAPI handles GET /api/inbox?member_id=...
 func handleMemberInboxAPI(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	sess, ok := middleware.GetSessionFromContext(r.Context())
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 
@@ -4229,7 +5595,7 @@ func handleMemberInboxAPI(w http.ResponseWriter, r *http.Request) {
 		// Non-admin trying to view another member's inbox
 		m, err := stores.MemberStore.GetByEmail(r.Context(), sess.Email)
 		if err != nil || m.ID != memberID {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+			apierror.Forbidden(w, "Forbidden")
 			return
 		}
 	}
@@ -4274,6 +5640,7 @@ func handleDashboard(w http.ResponseWriter, r *http.Request) {
 			HolidayStore:   stores.HolidayStore,
 			ClassTypeStore: stores.ClassTypeStore,
 			ProgramStore:   stores.ProgramStore,
+			ChangeStore:    stores.OccurrenceChangeStore,
 		},
 		AttendanceDeps: projections.GetAttendanceTodayDeps{
 			AttendanceStore:    stores.AttendanceStore,
@@ -4291,13 +5658,16 @@ func handleDashboard(w http.ResponseWriter, r *http.Request) {
 			AttendanceStore: stores.AttendanceStore,
 			MemberStore:     stores.MemberStore,
 		},
-		NoticeStore:        stores.NoticeStore,
-		ProposalStore:      stores.GradingProposalStore,
-		MessageStore:       stores.MessageStore,
-		TrainingGoalStore:  stores.TrainingGoalStore,
-		MemberStore:        stores.MemberStore,
-		GradingRecordStore: stores.GradingRecordStore,
-		WaiverStore:        stores.WaiverStore,
+		NoticeStore:         stores.NoticeStore,
+		ProposalStore:       stores.GradingProposalStore,
+		MessageStore:        stores.MessageStore,
+		TrainingGoalStore:   stores.TrainingGoalStore,
+		MemberStore:         stores.MemberStore,
+		GradingRecordStore:  stores.GradingRecordStore,
+		WaiverStore:         stores.WaiverStore,
+		PersonalGoalStore:   stores.PersonalGoalStore,
+		MemberProposalStore: stores.GradingProposalStore,
+		ApprovalStore:       stores.ClassTypeApprovalStore,
 	}
 
 	result, err := projections.QueryGetDashboard(ctx, query, deps, timeNow())
@@ -4330,14 +5700,13 @@ func handleKioskPage(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/login", http.StatusSeeOther)
 		return
 	}
-	if sess.Role != "admin" && sess.Role != "coach" {
+	if !permissionAllowed(r.Context(), sess, permissionDomain.ActionAttendanceKiosk) {
 		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
 
 	// Kiosk is a standalone template (no layout.html)
-	kioskPath := filepath.Join(templatesDir, "kiosk.html")
-	tpl, err := template.ParseFiles(kioskPath)
+	tpl, err := templates.forRequest("kiosk.html", false, r)
 	if err != nil {
 		internalError(w, err)
 		return
@@ -4347,18 +5716,24 @@ func handleKioskPage(w http.ResponseWriter, r *http.Request) {
 
 // --- Layer 2: Spine Handlers ---
 
+// themeCreateRequest is the body of POST /api/themes.
+type themeCreateRequest struct {
+	Name         string `json:"Name"`
+	Description  string `json:"Description"`
+	Program      string `json:"Program"`
+	StartDate    string `json:"StartDate"`
+	EndDate      string `json:"EndDate"`
+	MinBelt      string `json:"MinBelt"`      // optional: members below this belt see the theme locked
+	RotorThemeID string `json:"RotorThemeID"` // optional: shows the theme's clips on that rotor theme's carousel slide
+}
+
 // handleThemes handles GET/POST for /api/themes
 func handleThemes(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	if r.Method == "GET" {
-		sess, ok := middleware.GetSessionFromContext(ctx)
+		sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryView)
 		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
-			return
-		}
-		if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
 			return
 		}
 		if !requireFeatureAPI(w, r, sess, "library") {
@@ -4377,60 +5752,57 @@ func handleThemes(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
-		if themes == nil {
-			w.Write([]byte("[]"))
-			return
-		}
-		json.NewEncoder(w).Encode(themes)
+		json.NewEncoder(w).Encode(projections.GateThemes(themes, viewerBeltGate(ctx, sess)))
 		return
 	}
 
 	if r.Method == "POST" {
-		sess, ok := middleware.GetSessionFromContext(ctx)
+		sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryEdit)
 		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
-			return
-		}
-		if sess.Role != "admin" && sess.Role != "coach" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
 			return
 		}
 		if !requireFeatureAPI(w, r, sess, "library") {
 			return
 		}
-		var input struct {
-			Name        string `json:"Name"`
-			Description string `json:"Description"`
-			Program     string `json:"Program"`
-			StartDate   string `json:"StartDate"`
-			EndDate     string `json:"EndDate"`
-		}
+		var input themeCreateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		startDate, err := time.Parse("2006-01-02", input.StartDate)
 		if err != nil {
-			http.Error(w, "invalid start date format (use YYYY-MM-DD)", http.StatusBadRequest)
+			apierror.Validation(w, "invalid start date format (use YYYY-MM-DD)")
 			return
 		}
 		endDate, err := time.Parse("2006-01-02", input.EndDate)
 		if err != nil {
-			http.Error(w, "invalid end date format (use YYYY-MM-DD)", http.StatusBadRequest)
+			apierror.Validation(w, "invalid end date format (use YYYY-MM-DD)")
 			return
 		}
+		if !validMinBelt(input.MinBelt) {
+			apierror.Validation(w, "unknown belt")
+			return
+		}
+		if input.RotorThemeID != "" {
+			if _, err := stores.RotorStore.GetRotorTheme(ctx, input.RotorThemeID); err != nil {
+				apierror.Validation(w, "unknown rotor theme")
+				return
+			}
+		}
 		theme := themeDomain.Theme{
-			ID:          generateID(),
-			Name:        input.Name,
-			Description: input.Description,
-			Program:     input.Program,
-			StartDate:   startDate,
-			EndDate:     endDate,
-			CreatedBy:   sess.AccountID,
-			CreatedAt:   timeNow(),
+			ID:           generateID(),
+			Name:         input.Name,
+			Description:  input.Description,
+			Program:      input.Program,
+			StartDate:    startDate,
+			EndDate:      endDate,
+			CreatedBy:    sess.AccountID,
+			MinBelt:      input.MinBelt,
+			RotorThemeID: input.RotorThemeID,
+			CreatedAt:    timeNow(),
 		}
 		if err := theme.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.ThemeStore.Save(ctx, theme); err != nil {
@@ -4443,7 +5815,18 @@ func handleThemes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// clipCreateRequest is the body of POST /api/clips.
+type clipCreateRequest struct {
+	ThemeID      string `json:"ThemeID"`
+	Title        string `json:"Title"`
+	YouTubeURL   string `json:"YouTubeURL"`
+	StartSeconds int    `json:"StartSeconds"`
+	EndSeconds   int    `json:"EndSeconds"`
+	Notes        string `json:"Notes"`
+	MinBelt      string `json:"MinBelt"` // optional: members below this belt see a locked placeholder
 }
 
 // handleClips handles GET/POST for /api/clips
@@ -4451,13 +5834,8 @@ func handleClips(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	if r.Method == "GET" {
-		sess, ok := middleware.GetSessionFromContext(ctx)
+		sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryView)
 		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
-			return
-		}
-		if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
 			return
 		}
 		if !requireFeatureAPI(w, r, sess, "library") {
@@ -4477,45 +5855,42 @@ func handleClips(w http.ResponseWriter, r *http.Request) {
 		} else if themeID != "" {
 			clips, err = stores.ClipStore.ListByThemeID(ctx, themeID)
 		} else {
-			http.Error(w, "theme_id or promoted=true or q is required", http.StatusBadRequest)
+			apierror.Validation(w, "theme_id or promoted=true or q is required")
 			return
 		}
 		if err != nil {
 			internalError(w, err)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json")
-		if clips == nil {
-			w.Write([]byte("[]"))
+		gated, err := gateClipsForViewer(ctx, sess, clips)
+		if err != nil {
+			internalError(w, err)
 			return
 		}
-		json.NewEncoder(w).Encode(clips)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(gated)
 		return
 	}
 
 	if r.Method == "POST" {
-		sess, ok := middleware.GetSessionFromContext(ctx)
+		sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryView)
 		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
 			return
 		}
-		if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+		if !requireFeatureAPI(w, r, sess, "library") {
 			return
 		}
-		if !requireFeatureAPI(w, r, sess, "library") {
+		var input clipCreateRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
-		var input struct {
-			ThemeID      string `json:"ThemeID"`
-			Title        string `json:"Title"`
-			YouTubeURL   string `json:"YouTubeURL"`
-			StartSeconds int    `json:"StartSeconds"`
-			EndSeconds   int    `json:"EndSeconds"`
-			Notes        string `json:"Notes"`
+		if input.MinBelt != "" && !permissionAllowed(ctx, sess, permissionDomain.ActionLibraryEdit) {
+			apierror.Forbidden(w, "only coaches can set a minimum belt")
+			return
 		}
-		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+		if !validMinBelt(input.MinBelt) {
+			apierror.Validation(w, "unknown belt")
 			return
 		}
 		clip := clipDomain.Clip{
@@ -4527,14 +5902,15 @@ func handleClips(w http.ResponseWriter, r *http.Request) {
 			EndSeconds:   input.EndSeconds,
 			Notes:        input.Notes,
 			CreatedBy:    sess.AccountID,
+			MinBelt:      input.MinBelt,
 			CreatedAt:    timeNow(),
 		}
 		if err := clip.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := clip.ExtractYouTubeID(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.ClipStore.Save(ctx, clip); err != nil {
@@ -4547,45 +5923,43 @@ func handleClips(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// clipIDRequest is the body of POST /api/clips/promote.
+type clipIDRequest struct {
+	ClipID string `json:"ClipID"`
 }
 
 // handleClipPromote handles POST /api/clips/promote
 func handleClipPromote(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
-	sess, ok := middleware.GetSessionFromContext(r.Context())
+	sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryEdit)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "library") {
 		return
 	}
-	var input struct {
-		ClipID string `json:"ClipID"`
-	}
+	var input clipIDRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 	if input.ClipID == "" {
-		http.Error(w, "ClipID is required", http.StatusBadRequest)
+		apierror.Validation(w, "ClipID is required")
 		return
 	}
 	clip, err := stores.ClipStore.GetByID(r.Context(), input.ClipID)
 	if err != nil {
-		http.Error(w, "clip not found", http.StatusNotFound)
+		apierror.NotFound(w, "clip not found")
 		return
 	}
 	if err := clip.Promote(sess.AccountID); err != nil {
-		http.Error(w, err.Error(), http.StatusConflict)
+		apierror.Conflict(w, err.Error())
 		return
 	}
 	if err := stores.ClipStore.Save(r.Context(), clip); err != nil {
@@ -4598,7 +5972,20 @@ func handleClipPromote(w http.ResponseWriter, r *http.Request) {
 
 // --- Clip Tag Handlers ---
 
-// handleClipTags handles GET/POST for /api/clips/tags
+// clipTagCreateRequest is the body of POST /api/clips/tags.
+type clipTagCreateRequest struct {
+	Name     string `json:"Name"`
+	Category string `json:"Category"`
+}
+
+// clipTagUpdateRequest is the body of PUT /api/clips/tags.
+type clipTagUpdateRequest struct {
+	ID       string `json:"ID"`
+	Category string `json:"Category"`
+}
+
+// handleClipTags handles GET/POST/PUT for /api/clips/tags
+// PUT moves a tag to another taxonomy category.
 func handleClipTags(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -4618,43 +6005,40 @@ func handleClipTags(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "POST" {
-		sess, ok := middleware.GetSessionFromContext(ctx)
+		sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryView)
 		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
-			return
-		}
-		if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
 			return
 		}
 		if !requireFeatureAPI(w, r, sess, "library") {
 			return
 		}
-		var input struct {
-			Name string `json:"Name"`
-		}
+		var input clipTagCreateRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		if input.Name == "" {
-			http.Error(w, "Name is required", http.StatusBadRequest)
+			apierror.Validation(w, "Name is required")
 			return
 		}
+		if input.Category == "" {
+			input.Category = clipDomain.CategoryGeneral
+		}
 		// Check if tag already exists
 		existing, _ := stores.ClipTagStore.GetTagByName(ctx, input.Name)
 		if existing.ID != "" {
-			http.Error(w, "tag already exists", http.StatusConflict)
+			apierror.Conflict(w, "tag already exists")
 			return
 		}
 		tag := clipDomain.Tag{
 			ID:        generateID(),
 			Name:      input.Name,
+			Category:  input.Category,
 			CreatedBy: sess.AccountID,
 			CreatedAt: timeNow(),
 		}
 		if err := tag.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.ClipTagStore.SaveTag(ctx, tag); err != nil {
@@ -4667,19 +6051,51 @@ func handleClipTags(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	if r.Method == "PUT" {
+		sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryEdit)
+		if !ok {
+			return
+		}
+		if !requireFeatureAPI(w, r, sess, "library") {
+			return
+		}
+		var input clipTagUpdateRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		tag, err := stores.ClipTagStore.GetTagByID(ctx, input.ID)
+		if err != nil {
+			apierror.NotFound(w, "tag not found")
+			return
+		}
+		tag.Category = input.Category
+		if err := tag.Validate(); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err := stores.ClipTagStore.SaveTag(ctx, tag); err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(tag)
+		return
+	}
+
+	apierror.MethodNotAllowed(w)
+}
+
+// clipTagAttachRequest is the body of POST /api/clips/{clipID}/tags.
+type clipTagAttachRequest struct {
+	TagID string `json:"TagID"`
 }
 
 // handleClipTag handles POST/DELETE for /api/clips/{clipID}/tags
 func handleClipTag(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	sess, ok := middleware.GetSessionFromContext(ctx)
+	sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryView)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "library") {
@@ -4688,31 +6104,29 @@ func handleClipTag(w http.ResponseWriter, r *http.Request) {
 
 	clipID := r.PathValue("clipID")
 	if clipID == "" {
-		http.Error(w, "clipID is required", http.StatusBadRequest)
+		apierror.Validation(w, "clipID is required")
 		return
 	}
 
 	// Verify clip exists
 	if _, err := stores.ClipStore.GetByID(ctx, clipID); err != nil {
-		http.Error(w, "clip not found", http.StatusNotFound)
+		apierror.NotFound(w, "clip not found")
 		return
 	}
 
 	if r.Method == "POST" {
-		var input struct {
-			TagID string `json:"TagID"`
-		}
+		var input clipTagAttachRequest
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		if input.TagID == "" {
-			http.Error(w, "TagID is required", http.StatusBadRequest)
+			apierror.Validation(w, "TagID is required")
 			return
 		}
 		// Verify tag exists
 		if _, err := stores.ClipTagStore.GetTagByID(ctx, input.TagID); err != nil {
-			http.Error(w, "tag not found", http.StatusNotFound)
+			apierror.NotFound(w, "tag not found")
 			return
 		}
 		clipTag := clipDomain.ClipTag{
@@ -4732,7 +6146,7 @@ func handleClipTag(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "DELETE" {
 		tagID := r.URL.Query().Get("tagID")
 		if tagID == "" {
-			http.Error(w, "tagID is required", http.StatusBadRequest)
+			apierror.Validation(w, "tagID is required")
 			return
 		}
 		if err := stores.ClipTagStore.RemoveTagFromClip(ctx, clipID, tagID); err != nil {
@@ -4743,19 +6157,19 @@ func handleClipTag(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
 }
 
 // handleClipTagsGet handles GET for /api/clips/{clipID}/tags
 func handleClipTagsGet(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	ctx := r.Context()
 	clipID := r.PathValue("clipID")
 	if clipID == "" {
-		http.Error(w, "clipID is required", http.StatusBadRequest)
+		apierror.Validation(w, "clipID is required")
 		return
 	}
 	tags, err := stores.ClipTagStore.GetTagsForClip(ctx, clipID)
@@ -4774,13 +6188,20 @@ func handleClipTagsGet(w http.ResponseWriter, r *http.Request) {
 // handleClipsSearchByTags handles GET for /api/clips/search-by-tags
 func handleClipsSearchByTags(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryView)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "library") {
 		return
 	}
 	ctx := r.Context()
 	tagIDs := r.URL.Query()["tagID"]
 	if len(tagIDs) == 0 {
-		http.Error(w, "tagID is required", http.StatusBadRequest)
+		apierror.Validation(w, "tagID is required")
 		return
 	}
 	clips, err := stores.ClipTagStore.SearchClipsByTags(ctx, tagIDs)
@@ -4788,12 +6209,13 @@ func handleClipsSearchByTags(w http.ResponseWriter, r *http.Request) {
 		internalError(w, err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	if clips == nil {
-		w.Write([]byte("[]"))
+	gated, err := gateClipsForViewer(ctx, sess, clips)
+	if err != nil {
+		internalError(w, err)
 		return
 	}
-	json.NewEncoder(w).Encode(clips)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(gated)
 }
 
 // --- 4-Up Comparison Handlers ---
@@ -4801,13 +6223,8 @@ func handleClipsSearchByTags(w http.ResponseWriter, r *http.Request) {
 // handleComparisonSessions handles GET/POST for /api/comparisons
 func handleComparisonSessions(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	sess, ok := middleware.GetSessionFromContext(ctx)
+	sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryView)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "library") {
@@ -4835,17 +6252,17 @@ func handleComparisonSessions(w http.ResponseWriter, r *http.Request) {
 			ClipIDs []string `json:"ClipIDs"`
 		}
 		if err := strictDecode(r, &input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		if len(input.ClipIDs) == 0 || len(input.ClipIDs) > 4 {
-			http.Error(w, "must provide 1-4 clip IDs", http.StatusBadRequest)
+			apierror.Validation(w, "must provide 1-4 clip IDs")
 			return
 		}
 		// Verify all clips exist
 		for _, clipID := range input.ClipIDs {
 			if _, err := stores.ClipStore.GetByID(ctx, clipID); err != nil {
-				http.Error(w, "clip not found: "+clipID, http.StatusNotFound)
+				apierror.NotFound(w, "clip not found: "+clipID)
 				return
 			}
 		}
@@ -4857,7 +6274,7 @@ func handleComparisonSessions(w http.ResponseWriter, r *http.Request) {
 			CreatedAt: timeNow(),
 		}
 		if err := session.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.ClipComparisonStore.SaveSession(ctx, session); err != nil {
@@ -4870,19 +6287,14 @@ func handleComparisonSessions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
 }
 
 // handleComparisonSession handles GET/PUT/DELETE for /api/comparisons/{id}
 func handleComparisonSession(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	sess, ok := middleware.GetSessionFromContext(ctx)
+	sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryView)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "library") {
@@ -4891,13 +6303,13 @@ func handleComparisonSession(w http.ResponseWriter, r *http.Request) {
 
 	id := r.PathValue("id")
 	if id == "" {
-		http.Error(w, "id is required", http.StatusBadRequest)
+		apierror.Validation(w, "id is required")
 		return
 	}
 
 	session, err := stores.ClipComparisonStore.GetSessionByID(ctx, id)
 	if err != nil {
-		http.Error(w, "comparison session not found", http.StatusNotFound)
+		apierror.NotFound(w, "comparison session not found")
 		return
 	}
 
@@ -4909,19 +6321,30 @@ func handleComparisonSession(w http.ResponseWriter, r *http.Request) {
 			YouTubeID    string `json:"YouTubeID"`
 			StartSeconds int    `json:"StartSeconds"`
 			EndSeconds   int    `json:"EndSeconds"`
+			Locked       bool   `json:"Locked"`
 		}
-		var clips []clipDetail
+		var found []clipDomain.Clip
 		for _, clipID := range session.ClipIDs {
 			clip, err := stores.ClipStore.GetByID(ctx, clipID)
 			if err != nil {
 				continue
 			}
+			found = append(found, clip)
+		}
+		gated, err := gateClipsForViewer(ctx, sess, found)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		var clips []clipDetail
+		for _, clip := range gated {
 			clips = append(clips, clipDetail{
 				ID:           clip.ID,
 				Title:        clip.Title,
 				YouTubeID:    clip.YouTubeID,
 				StartSeconds: clip.StartSeconds,
 				EndSeconds:   clip.EndSeconds,
+				Locked:       clip.Locked,
 			})
 		}
 		// Get research note if exists
@@ -4938,7 +6361,7 @@ func handleComparisonSession(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "DELETE" {
 		// Only creator or admin can delete
 		if session.CreatedBy != sess.AccountID && sess.Role != "admin" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+			apierror.Forbidden(w, "Forbidden")
 			return
 		}
 		if err := stores.ClipComparisonStore.DeleteSession(ctx, id); err != nil {
@@ -4949,7 +6372,7 @@ func handleComparisonSession(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
 }
 
 // handle4UpPage handles GET /library/4up
@@ -4958,13 +6381,8 @@ func handle4UpPage(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
 		return
 	}
-	sess, ok := middleware.GetSessionFromContext(r.Context())
+	sess, ok := requirePermissionPage(w, r, permissionDomain.ActionLibraryView)
 	if !ok {
-		http.Redirect(w, r, "/login", http.StatusSeeOther)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-		http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
 		return
 	}
 	if !requireFeaturePage(w, r, sess, "library") {
@@ -4987,13 +6405,8 @@ func handleThemesPage(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
 		return
 	}
-	sess, ok := middleware.GetSessionFromContext(r.Context())
+	sess, ok := requirePermissionPage(w, r, permissionDomain.ActionLibraryView)
 	if !ok {
-		http.Redirect(w, r, "/login", http.StatusSeeOther)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-		http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
 		return
 	}
 	if !requireFeaturePage(w, r, sess, "library") {
@@ -5011,13 +6424,8 @@ func handleLibraryPage(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
 		return
 	}
-	sess, ok := middleware.GetSessionFromContext(r.Context())
+	sess, ok := requirePermissionPage(w, r, permissionDomain.ActionLibraryView)
 	if !ok {
-		http.Redirect(w, r, "/login", http.StatusSeeOther)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-		http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
 		return
 	}
 	if !requireFeaturePage(w, r, sess, "library") {
@@ -5034,23 +6442,27 @@ func handleLibraryPage(w http.ResponseWriter, r *http.Request) {
 // handleDevModeImpersonate handles POST /api/devmode/impersonate
 func handleDevModeImpersonate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
 	sess, ok := middleware.GetSessionFromContext(r.Context())
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 
 	if !middleware.IsRealAdmin(r.Context()) {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+	if sess.IsImpersonatingAccount() {
+		apierror.Validation(w, "stop impersonating "+sess.Email+" before switching role")
 		return
 	}
 
 	if err := r.ParseForm(); err != nil {
-		http.Error(w, "Form error", http.StatusBadRequest)
+		apierror.Validation(w, "Form error")
 		return
 	}
 
@@ -5067,14 +6479,14 @@ func handleDevModeImpersonate(w http.ResponseWriter, r *http.Request) {
 
 	result, err := orchestrators.ExecuteDevModeImpersonate(input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
 	// Update session in-place
 	cookie, err := r.Cookie("workshop_session")
 	if err != nil {
-		http.Error(w, "session error", http.StatusInternalServerError)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 
@@ -5093,9 +6505,9 @@ func handleDevModeImpersonate(w http.ResponseWriter, r *http.Request) {
 		sess.RealRole = ""
 	}
 
-	sessions.Update(cookie.Value, sess)
+	sessions.Update(r.Context(), cookie.Value, sess)
 
-	slog.Info("devmode_event",
+	slog.InfoContext(r.Context(), "devmode_event",
 		"event", "impersonate",
 		"admin_account_id", func() string {
 			if result.RealAccountID != "" {
@@ -5112,13 +6524,13 @@ func handleDevModeImpersonate(w http.ResponseWriter, r *http.Request) {
 // handleDevModeRestore handles POST /api/devmode/restore
 func handleDevModeRestore(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
 	sess, ok := middleware.GetSessionFromContext(r.Context())
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 
@@ -5131,27 +6543,28 @@ func handleDevModeRestore(w http.ResponseWriter, r *http.Request) {
 
 	result, err := orchestrators.ExecuteDevModeRestore(input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
 	// Update session in-place
 	cookie, err := r.Cookie("workshop_session")
 	if err != nil {
-		http.Error(w, "session error", http.StatusInternalServerError)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 
-	sess.AccountID = result.AccountID
-	sess.Email = result.Email
-	sess.Role = result.Role
-	sess.RealAccountID = ""
-	sess.RealEmail = ""
-	sess.RealRole = ""
+	if sess.IsImpersonatingAccount() {
+		orchestrators.ExecuteEndAccountImpersonation(r.Context(), orchestrators.EndAccountImpersonationInput{
+			AccountID: sess.AccountID,
+			Email:     sess.Email,
+			Actor:     impersonationActor(r, result.AccountID, result.Email, result.Role),
+		}, stores.AuditStore)
+	}
 
-	sessions.Update(cookie.Value, sess)
+	sessions.Update(r.Context(), cookie.Value, sess.WithoutImpersonation())
 
-	slog.Info("devmode_event",
+	slog.InfoContext(r.Context(), "devmode_event",
 		"event", "restore",
 		"admin_account_id", result.AccountID,
 	)
@@ -5226,10 +6639,19 @@ func handleAdminComposeEmailPage(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// emailComposeRequest is the body of POST /api/emails/compose; an empty EmailID starts a new draft.
+type emailComposeRequest struct {
+	EmailID   string   `json:"EmailID"`
+	Subject   string   `json:"Subject"`
+	Body      string   `json:"Body"`
+	Category  string   `json:"Category"` // announcements (default), grading, billing or account
+	MemberIDs []string `json:"MemberIDs"`
+}
+
 // handleEmailCompose handles POST /api/emails/compose (save draft)
 func handleEmailCompose(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	sess, ok := requireAdmin(w, r)
@@ -5237,14 +6659,9 @@ func handleEmailCompose(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var input struct {
-		EmailID   string   `json:"EmailID"`
-		Subject   string   `json:"Subject"`
-		Body      string   `json:"Body"`
-		MemberIDs []string `json:"MemberIDs"`
-	}
+	var input emailComposeRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 
@@ -5252,6 +6669,7 @@ func handleEmailCompose(w http.ResponseWriter, r *http.Request) {
 		EmailID:   input.EmailID,
 		Subject:   input.Subject,
 		Body:      input.Body,
+		Category:  input.Category,
 		SenderID:  sess.AccountID,
 		MemberIDs: input.MemberIDs,
 	}, orchestrators.ComposeEmailDeps{
@@ -5261,7 +6679,7 @@ func handleEmailCompose(w http.ResponseWriter, r *http.Request) {
 		Now:          timeNow,
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
@@ -5270,10 +6688,15 @@ func handleEmailCompose(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(em)
 }
 
+// emailIDRequest names the email acted on by POST /api/emails/send and POST /api/emails/cancel.
+type emailIDRequest struct {
+	EmailID string `json:"EmailID"`
+}
+
 // handleEmailSend handles POST /api/emails/send
 func handleEmailSend(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	sess, ok := requireAdmin(w, r)
@@ -5281,16 +6704,14 @@ func handleEmailSend(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var input struct {
-		EmailID string `json:"EmailID"`
-	}
+	var input emailIDRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 
 	if emailSender == nil {
-		http.Error(w, "email sending is not configured", http.StatusServiceUnavailable)
+		apierror.Unavailable(w, "email sending is not configured")
 		return
 	}
 
@@ -5298,14 +6719,16 @@ func handleEmailSend(w http.ResponseWriter, r *http.Request) {
 		EmailID:  input.EmailID,
 		SenderID: sess.AccountID,
 	}, orchestrators.SendEmailDeps{
-		EmailStore:  stores.EmailStore,
-		EmailSender: emailSender,
-		Now:         timeNow,
-		FromAddress: emailFromAddress,
-		ReplyTo:     emailReplyTo,
+		EmailStore:      stores.EmailStore,
+		EmailSender:     emailSender,
+		PreferenceStore: stores.EmailPreferenceStore,
+		UnsubscribeURL:  UnsubscribeLinks(emailLinkBaseURL(r), unsubscribeKey),
+		Now:             timeNow,
+		FromAddress:     emailFromAddress,
+		ReplyTo:         emailReplyTo,
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
@@ -5313,27 +6736,30 @@ func handleEmailSend(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(em)
 }
 
+// emailTestSendRequest is the body of POST /api/emails/test-send.
+type emailTestSendRequest struct {
+	EmailID     string `json:"EmailID"`
+	TestAddress string `json:"TestAddress"`
+}
+
 // handleEmailTestSend handles POST /api/emails/test-send
 func handleEmailTestSend(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
 		return
 	}
 
-	var input struct {
-		EmailID     string `json:"EmailID"`
-		TestAddress string `json:"TestAddress"`
-	}
+	var input emailTestSendRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 
 	if emailSender == nil {
-		http.Error(w, "email sending is not configured", http.StatusServiceUnavailable)
+		apierror.Unavailable(w, "email sending is not configured")
 		return
 	}
 
@@ -5347,7 +6773,7 @@ func handleEmailTestSend(w http.ResponseWriter, r *http.Request) {
 		ReplyTo:     emailReplyTo,
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
@@ -5358,7 +6784,7 @@ func handleEmailTestSend(w http.ResponseWriter, r *http.Request) {
 // handleEmailList handles GET /api/emails
 func handleEmailList(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
@@ -5384,7 +6810,7 @@ func handleEmailList(w http.ResponseWriter, r *http.Request) {
 // handleEmailDetail handles GET /api/emails/detail?id=...
 func handleEmailDetail(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
@@ -5393,13 +6819,13 @@ func handleEmailDetail(w http.ResponseWriter, r *http.Request) {
 
 	id := r.URL.Query().Get("id")
 	if id == "" {
-		http.Error(w, "id is required", http.StatusBadRequest)
+		apierror.Validation(w, "id is required")
 		return
 	}
 
 	em, err := stores.EmailStore.GetByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, "email not found", http.StatusNotFound)
+		apierror.NotFound(w, "email not found")
 		return
 	}
 
@@ -5409,13 +6835,14 @@ func handleEmailDetail(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]any{
 		"Email":      em,
 		"Recipients": recipients,
+		"Delivery":   emailDomain.SummarizeDelivery(recipients),
 	})
 }
 
 // handleEmailDelete handles DELETE /api/emails?id=...
 func handleEmailDelete(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "DELETE" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
@@ -5424,17 +6851,17 @@ func handleEmailDelete(w http.ResponseWriter, r *http.Request) {
 
 	id := r.URL.Query().Get("id")
 	if id == "" {
-		http.Error(w, "id is required", http.StatusBadRequest)
+		apierror.Validation(w, "id is required")
 		return
 	}
 
 	em, err := stores.EmailStore.GetByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, "email not found", http.StatusNotFound)
+		apierror.NotFound(w, "email not found")
 		return
 	}
 	if !em.IsDraft() {
-		http.Error(w, "only draft emails can be deleted", http.StatusBadRequest)
+		apierror.Validation(w, "only draft emails can be deleted")
 		return
 	}
 
@@ -5446,10 +6873,16 @@ func handleEmailDelete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// emailScheduleRequest is the body of POST /api/emails/schedule and POST /api/emails/reschedule.
+type emailScheduleRequest struct {
+	EmailID     string `json:"EmailID"`
+	ScheduledAt string `json:"ScheduledAt"` // RFC3339
+}
+
 // handleEmailSchedule handles POST /api/emails/schedule
 func handleEmailSchedule(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	sess, ok := requireAdmin(w, r)
@@ -5457,22 +6890,19 @@ func handleEmailSchedule(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var input struct {
-		EmailID     string `json:"EmailID"`
-		ScheduledAt string `json:"ScheduledAt"` // RFC3339
-	}
+	var input emailScheduleRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 	if input.EmailID == "" || input.ScheduledAt == "" {
-		http.Error(w, "EmailID and ScheduledAt are required", http.StatusBadRequest)
+		apierror.Validation(w, "EmailID and ScheduledAt are required")
 		return
 	}
 
 	scheduledAt, err := time.Parse(time.RFC3339, input.ScheduledAt)
 	if err != nil {
-		http.Error(w, "ScheduledAt must be in RFC3339 format", http.StatusBadRequest)
+		apierror.Validation(w, "ScheduledAt must be in RFC3339 format")
 		return
 	}
 
@@ -5485,7 +6915,7 @@ func handleEmailSchedule(w http.ResponseWriter, r *http.Request) {
 		Now:        timeNow,
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
@@ -5496,22 +6926,20 @@ func handleEmailSchedule(w http.ResponseWriter, r *http.Request) {
 // handleEmailCancel handles POST /api/emails/cancel
 func handleEmailCancel(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
 		return
 	}
 
-	var input struct {
-		EmailID string `json:"EmailID"`
-	}
+	var input emailIDRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 	if input.EmailID == "" {
-		http.Error(w, "EmailID is required", http.StatusBadRequest)
+		apierror.Validation(w, "EmailID is required")
 		return
 	}
 
@@ -5522,7 +6950,7 @@ func handleEmailCancel(w http.ResponseWriter, r *http.Request) {
 		Now:        timeNow,
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
@@ -5533,29 +6961,26 @@ func handleEmailCancel(w http.ResponseWriter, r *http.Request) {
 // handleEmailReschedule handles POST /api/emails/reschedule
 func handleEmailReschedule(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
 		return
 	}
 
-	var input struct {
-		EmailID     string `json:"EmailID"`
-		ScheduledAt string `json:"ScheduledAt"` // RFC3339
-	}
+	var input emailScheduleRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 	if input.EmailID == "" || input.ScheduledAt == "" {
-		http.Error(w, "EmailID and ScheduledAt are required", http.StatusBadRequest)
+		apierror.Validation(w, "EmailID and ScheduledAt are required")
 		return
 	}
 
 	scheduledAt, err := time.Parse(time.RFC3339, input.ScheduledAt)
 	if err != nil {
-		http.Error(w, "ScheduledAt must be in RFC3339 format", http.StatusBadRequest)
+		apierror.Validation(w, "ScheduledAt must be in RFC3339 format")
 		return
 	}
 
@@ -5567,7 +6992,7 @@ func handleEmailReschedule(w http.ResponseWriter, r *http.Request) {
 		Now:        timeNow,
 	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
@@ -5590,7 +7015,7 @@ func handleEmailTemplatePage(w http.ResponseWriter, r *http.Request) {
 // handleEmailTemplateGet handles GET /api/emails/template
 func handleEmailTemplateGet(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
@@ -5609,22 +7034,25 @@ func handleEmailTemplateGet(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(t)
 }
 
+// emailTemplateRequest is the body of POST /api/emails/template.
+type emailTemplateRequest struct {
+	Header string `json:"Header"`
+	Footer string `json:"Footer"`
+}
+
 // handleEmailTemplateSave handles POST /api/emails/template
 func handleEmailTemplateSave(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
 		return
 	}
 
-	var input struct {
-		Header string `json:"Header"`
-		Footer string `json:"Footer"`
-	}
+	var input emailTemplateRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 
@@ -5641,26 +7069,29 @@ func handleEmailTemplateSave(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	slog.Info("email_event", "event", "template_saved", "template_id", t.ID)
+	slog.InfoContext(r.Context(), "email_event", "event", "template_saved", "template_id", t.ID)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(t)
 }
 
+// emailPreviewRequest is the body of POST /api/emails/preview.
+type emailPreviewRequest struct {
+	Body string `json:"Body"`
+}
+
 // handleEmailPreview handles POST /api/emails/preview ΓÇö wraps body with active template
 func handleEmailPreview(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
 		return
 	}
 
-	var input struct {
-		Body string `json:"Body"`
-	}
+	var input emailPreviewRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 
@@ -5677,10 +7108,17 @@ func handleEmailPreview(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"HTML": wrapped})
 }
 
-// handleMemberFilterForEmail handles GET /api/emails/recipients/filter?program=...
+// memberResult is a recipient candidate returned by the /api/emails/recipients endpoints.
+type memberResult struct {
+	ID    string `json:"ID"`
+	Name  string `json:"Name"`
+	Email string `json:"Email"`
+}
+
+// handleMemberFilterForEmail handles GET /api/emails/recipients/filter?program=...&tag=...
 func handleMemberFilterForEmail(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
@@ -5688,9 +7126,16 @@ func handleMemberFilterForEmail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	program := r.URL.Query().Get("program")
+	tag, segmentIDs, err := memberTagFilters(r)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
 
 	filter := memberStore.ListFilter{
 		Status: "active",
+		Tag:    tag,
+		IDs:    segmentIDs,
 	}
 	if program != "" {
 		filter.Program = program
@@ -5702,11 +7147,6 @@ func handleMemberFilterForEmail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	type memberResult struct {
-		ID    string `json:"ID"`
-		Name  string `json:"Name"`
-		Email string `json:"Email"`
-	}
 	var results []memberResult
 	for _, m := range members {
 		results = append(results, memberResult{ID: m.ID, Name: m.Name, Email: m.Email})
@@ -5723,7 +7163,7 @@ func handleMemberFilterForEmail(w http.ResponseWriter, r *http.Request) {
 // handleMemberSearchForEmail handles GET /api/emails/recipients/search?q=...
 func handleMemberSearchForEmail(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
@@ -5743,11 +7183,6 @@ func handleMemberSearchForEmail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	type memberResult struct {
-		ID    string `json:"ID"`
-		Name  string `json:"Name"`
-		Email string `json:"Email"`
-	}
 	var results []memberResult
 	for _, m := range members {
 		results = append(results, memberResult{ID: m.ID, Name: m.Name, Email: m.Email})
@@ -5764,7 +7199,7 @@ func handleMemberSearchForEmail(w http.ResponseWriter, r *http.Request) {
 // handleRecipientsFilterBySession handles GET /api/emails/recipients/by-session?scheduleID=...&date=...
 func handleRecipientsFilterBySession(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
@@ -5774,7 +7209,7 @@ func handleRecipientsFilterBySession(w http.ResponseWriter, r *http.Request) {
 	scheduleID := r.URL.Query().Get("scheduleID")
 	classDate := r.URL.Query().Get("date")
 	if scheduleID == "" || classDate == "" {
-		http.Error(w, "scheduleID and date are required", http.StatusBadRequest)
+		apierror.Validation(w, "scheduleID and date are required")
 		return
 	}
 
@@ -5784,11 +7219,6 @@ func handleRecipientsFilterBySession(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	type memberResult struct {
-		ID    string `json:"ID"`
-		Name  string `json:"Name"`
-		Email string `json:"Email"`
-	}
 	var results []memberResult
 	for _, id := range memberIDs {
 		m, err := stores.MemberStore.GetByID(r.Context(), id)
@@ -5809,7 +7239,7 @@ func handleRecipientsFilterBySession(w http.ResponseWriter, r *http.Request) {
 // handleRecipientsFilterByClassType handles GET /api/emails/recipients/by-class-type?classTypeID=...&days=30
 func handleRecipientsFilterByClassType(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
@@ -5819,7 +7249,7 @@ func handleRecipientsFilterByClassType(w http.ResponseWriter, r *http.Request) {
 	classTypeID := r.URL.Query().Get("classTypeID")
 	daysStr := r.URL.Query().Get("days")
 	if classTypeID == "" {
-		http.Error(w, "classTypeID is required", http.StatusBadRequest)
+		apierror.Validation(w, "classTypeID is required")
 		return
 	}
 	days := 30
@@ -5851,11 +7281,6 @@ func handleRecipientsFilterByClassType(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	type memberResult struct {
-		ID    string `json:"ID"`
-		Name  string `json:"Name"`
-		Email string `json:"Email"`
-	}
 	var results []memberResult
 	for _, id := range memberIDs {
 		m, err := stores.MemberStore.GetByID(r.Context(), id)
@@ -5873,10 +7298,17 @@ func handleRecipientsFilterByClassType(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(results)
 }
 
+// sessionInfo is a recent class session listed by GET /api/schedules/recent-sessions.
+type sessionInfo struct {
+	ScheduleID string `json:"ScheduleID"`
+	ClassDate  string `json:"ClassDate"`
+	Label      string `json:"Label"`
+}
+
 // handleRecentSessions handles GET /api/schedules/recent-sessions ΓÇö lists recent class sessions for the filter dropdown.
 func handleRecentSessions(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
@@ -5899,12 +7331,6 @@ func handleRecentSessions(w http.ResponseWriter, r *http.Request) {
 		ctMap[ct.ID] = ct.Name
 	}
 
-	type sessionInfo struct {
-		ScheduleID string `json:"ScheduleID"`
-		ClassDate  string `json:"ClassDate"`
-		Label      string `json:"Label"`
-	}
-
 	// Generate sessions for the last 14 days
 	var sessions []sessionInfo
 	now := time.Now()
@@ -5964,37 +7390,40 @@ func handleActivatePage(w http.ResponseWriter, r *http.Request) {
 	renderTemplate(w, r, "activate.html", map[string]any{"Token": token})
 }
 
+// activateAccountRequest is the body of POST /api/activate.
+type activateAccountRequest struct {
+	Token    string `json:"Token"`
+	Password string `json:"Password"`
+}
+
 // handleActivateAccount handles POST /api/activate ΓÇö sets password and activates account.
 func handleActivateAccount(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
-	var input struct {
-		Token    string `json:"Token"`
-		Password string `json:"Password"`
-	}
+	var input activateAccountRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 	if input.Token == "" || input.Password == "" {
-		http.Error(w, "Token and Password are required", http.StatusBadRequest)
+		apierror.Validation(w, "Token and Password are required")
 		return
 	}
 
 	tok, err := stores.AccountStore.GetActivationTokenByToken(r.Context(), input.Token)
 	if err != nil {
-		http.Error(w, "Invalid activation token", http.StatusBadRequest)
+		apierror.Validation(w, "Invalid activation token")
 		return
 	}
 	if tok.Used {
-		http.Error(w, "This activation link has already been used", http.StatusBadRequest)
+		apierror.Validation(w, "This activation link has already been used")
 		return
 	}
 	if tok.IsExpired(timeNow()) {
-		http.Error(w, "Link expired - contact your gym to resend", http.StatusBadRequest)
+		apierror.Validation(w, "Link expired - contact your gym to resend")
 		return
 	}
 
@@ -6005,12 +7434,12 @@ func handleActivateAccount(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := acct.Activate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
 	if err := acct.SetPassword(input.Password); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 	acct.PasswordChangeRequired = false
@@ -6024,7 +7453,10 @@ func handleActivateAccount(w http.ResponseWriter, r *http.Request) {
 	stores.AccountStore.SaveActivationToken(r.Context(), tok)
 	stores.AccountStore.InvalidateTokensForAccount(r.Context(), tok.AccountID)
 
-	slog.Info("auth_event", "event", "account_activated", "account_id", acct.ID, "email", acct.Email)
+	slog.InfoContext(r.Context(), "auth_event", "event", "account_activated", "account_id", acct.ID, "email", acct.Email)
+	if m, err := stores.MemberStore.GetByAccountID(r.Context(), acct.ID); err == nil {
+		sendTemplatedEmail(r.Context(), emailDomain.TemplateWelcome, m.ID, nil)
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{"status": "activated"})
@@ -6033,32 +7465,30 @@ func handleActivateAccount(w http.ResponseWriter, r *http.Request) {
 // handleResendActivation handles POST /api/admin/resend-activation ΓÇö admin resends activation email.
 func handleResendActivation(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	if _, ok := requireAdmin(w, r); !ok {
 		return
 	}
 
-	var input struct {
-		AccountID string `json:"AccountID"`
-	}
+	var input accountIDRequest
 	if err := strictDecode(r, &input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 	if input.AccountID == "" {
-		http.Error(w, "AccountID is required", http.StatusBadRequest)
+		apierror.Validation(w, "AccountID is required")
 		return
 	}
 
 	acct, err := stores.AccountStore.GetByID(r.Context(), input.AccountID)
 	if err != nil {
-		http.Error(w, "Account not found", http.StatusNotFound)
+		apierror.NotFound(w, "Account not found")
 		return
 	}
 	if acct.Status != accountDomain.StatusPendingActivation {
-		http.Error(w, "Account is already activated", http.StatusBadRequest)
+		apierror.Validation(w, "Account is already activated")
 		return
 	}
 
@@ -6069,7 +7499,7 @@ func handleResendActivation(w http.ResponseWriter, r *http.Request) {
 		ID:        generateID(),
 		AccountID: acct.ID,
 		Token:     tokenStr,
-		ExpiresAt: timeNow().Add(72 * time.Hour),
+		ExpiresAt: timeNow().Add(accountDomain.ActivationTokenLifetime),
 		CreatedAt: timeNow(),
 	}
 	if err := stores.AccountStore.SaveActivationToken(r.Context(), tok); err != nil {
@@ -6077,7 +7507,7 @@ func handleResendActivation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	slog.Info("auth_event", "event", "activation_resent", "account_id", acct.ID, "email", acct.Email)
+	slog.InfoContext(r.Context(), "auth_event", "event", "activation_resent", "account_id", acct.ID, "email", acct.Email)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{"status": "sent", "token": tokenStr})
@@ -6091,13 +7521,8 @@ func handleCurriculumPage(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
 		return
 	}
-	sess, ok := middleware.GetSessionFromContext(r.Context())
+	sess, ok := requirePermissionPage(w, r, permissionDomain.ActionCurriculumView)
 	if !ok {
-		http.Redirect(w, r, "/login", http.StatusSeeOther)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-		http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
 		return
 	}
 	if !requireFeaturePage(w, r, sess, "curriculum") {
@@ -6108,12 +7533,18 @@ func handleCurriculumPage(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// rotorCreateRequest is the body of POST /api/rotors.
+type rotorCreateRequest struct {
+	ClassTypeID string `json:"class_type_id"`
+	Name        string `json:"name"`
+}
+
 // handleRotors handles GET/POST for /api/rotors
 func handleRotors(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	sess, ok := middleware.GetSessionFromContext(ctx)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "curriculum") {
@@ -6121,13 +7552,13 @@ func handleRotors(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "GET" {
-		if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+		if !permissionAllowed(r.Context(), sess, permissionDomain.ActionCurriculumView) {
+			apierror.Forbidden(w, "Forbidden")
 			return
 		}
 		classTypeID := r.URL.Query().Get("class_type_id")
 		if classTypeID == "" {
-			http.Error(w, "class_type_id is required", http.StatusBadRequest)
+			apierror.Validation(w, "class_type_id is required")
 			return
 		}
 		rotors, err := stores.RotorStore.ListRotorsByClassType(ctx, classTypeID)
@@ -6144,17 +7575,14 @@ func handleRotors(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "POST" {
-		if sess.Role != "admin" && sess.Role != "coach" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+		if !permissionAllowed(r.Context(), sess, permissionDomain.ActionCurriculumEdit) {
+			apierror.Forbidden(w, "Forbidden")
 			return
 		}
 
-		var input struct {
-			ClassTypeID string `json:"class_type_id"`
-			Name        string `json:"name"`
-		}
+		var input rotorCreateRequest
 		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 
@@ -6177,7 +7605,7 @@ func handleRotors(w http.ResponseWriter, r *http.Request) {
 			CreatedAt:   timeNow(),
 		}
 		if err := rotor.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.RotorStore.SaveRotor(ctx, rotor); err != nil {
@@ -6190,7 +7618,12 @@ func handleRotors(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// rotorRenameRequest is the body of PUT /api/rotors/by-id.
+type rotorRenameRequest struct {
+	Name string `json:"name"`
 }
 
 // handleRotorByID handles GET/DELETE for /api/rotors/by-id?id=<id>
@@ -6198,7 +7631,7 @@ func handleRotorByID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	sess, ok := middleware.GetSessionFromContext(ctx)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "curriculum") {
@@ -6206,18 +7639,18 @@ func handleRotorByID(w http.ResponseWriter, r *http.Request) {
 	}
 	id := r.URL.Query().Get("id")
 	if id == "" {
-		http.Error(w, "id is required", http.StatusBadRequest)
+		apierror.Validation(w, "id is required")
 		return
 	}
 
 	if r.Method == "GET" {
-		if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+		if !permissionAllowed(r.Context(), sess, permissionDomain.ActionCurriculumView) {
+			apierror.Forbidden(w, "Forbidden")
 			return
 		}
 		rotor, err := stores.RotorStore.GetRotor(ctx, id)
 		if err != nil {
-			http.Error(w, "Rotor not found", http.StatusNotFound)
+			apierror.NotFound(w, "Rotor not found")
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -6226,24 +7659,22 @@ func handleRotorByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "PUT" {
-		if sess.Role != "admin" && sess.Role != "coach" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+		if !permissionAllowed(r.Context(), sess, permissionDomain.ActionCurriculumEdit) {
+			apierror.Forbidden(w, "Forbidden")
 			return
 		}
-		var input struct {
-			Name string `json:"name"`
-		}
+		var input rotorRenameRequest
 		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		rotor, err := stores.RotorStore.GetRotor(ctx, id)
 		if err != nil {
-			http.Error(w, "Rotor not found", http.StatusNotFound)
+			apierror.NotFound(w, "Rotor not found")
 			return
 		}
 		if err := rotor.Rename(input.Name); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.RotorStore.SaveRotor(ctx, rotor); err != nil {
@@ -6256,17 +7687,17 @@ func handleRotorByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "DELETE" {
-		if sess.Role != "admin" && sess.Role != "coach" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+		if !permissionAllowed(r.Context(), sess, permissionDomain.ActionCurriculumEdit) {
+			apierror.Forbidden(w, "Forbidden")
 			return
 		}
 		rotor, err := stores.RotorStore.GetRotor(ctx, id)
 		if err != nil {
-			http.Error(w, "Rotor not found", http.StatusNotFound)
+			apierror.NotFound(w, "Rotor not found")
 			return
 		}
 		if rotor.IsActive() {
-			http.Error(w, "cannot delete an active rotor", http.StatusBadRequest)
+			apierror.Validation(w, "cannot delete an active rotor")
 			return
 		}
 		if err := stores.RotorStore.DeleteRotor(ctx, id); err != nil {
@@ -6277,40 +7708,38 @@ func handleRotorByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// rotorIDRequest is the body of POST /api/rotors/activate.
+type rotorIDRequest struct {
+	ID string `json:"id"`
 }
 
 // handleRotorActivate handles POST /api/rotors/activate
 func handleRotorActivate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	ctx := r.Context()
-	sess, ok := middleware.GetSessionFromContext(ctx)
+	sess, ok := requirePermission(w, r, permissionDomain.ActionCurriculumEdit)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "curriculum") {
 		return
 	}
 
-	var input struct {
-		ID string `json:"id"`
-	}
+	var input rotorIDRequest
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 
 	rotor, err := stores.RotorStore.GetRotor(ctx, input.ID)
 	if err != nil {
-		http.Error(w, "Rotor not found", http.StatusNotFound)
+		apierror.NotFound(w, "Rotor not found")
 		return
 	}
 
@@ -6322,7 +7751,7 @@ func handleRotorActivate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := rotor.Activate(timeNow()); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 	if err := stores.RotorStore.SaveRotor(ctx, rotor); err != nil {
@@ -6334,41 +7763,131 @@ func handleRotorActivate(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(rotor)
 }
 
+// rotorPreviewRequest is the body of POST /api/rotors/preview.
+type rotorPreviewRequest struct {
+	ID        string `json:"id"`
+	PreviewOn bool   `json:"preview_on"`
+}
+
 // handleRotorPreview handles POST /api/rotors/preview (toggle preview on/off)
 func handleRotorPreview(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	ctx := r.Context()
-	sess, ok := middleware.GetSessionFromContext(ctx)
+	sess, ok := requirePermission(w, r, permissionDomain.ActionCurriculumEdit)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
 		return
 	}
-	if sess.Role != "admin" && sess.Role != "coach" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+	if !requireFeatureAPI(w, r, sess, "curriculum") {
+		return
+	}
+	var input rotorPreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+
+	rotor, err := stores.RotorStore.GetRotor(ctx, input.ID)
+	if err != nil {
+		apierror.NotFound(w, "Rotor not found")
+		return
+	}
+
+	rotor.PreviewOn = input.PreviewOn
+	if err := stores.RotorStore.SaveRotor(ctx, rotor); err != nil {
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rotor)
+}
+
+// rotorAdvanceModeRequest is the body of POST /api/rotors/advance-mode.
+type rotorAdvanceModeRequest struct {
+	ID            string `json:"id"`
+	ManualAdvance bool   `json:"manual_advance"`
+}
+
+// handleRotorAdvanceMode handles POST /api/rotors/advance-mode (auto vs manual topic advance)
+func handleRotorAdvanceMode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := requirePermission(w, r, permissionDomain.ActionCurriculumEdit)
+	if !ok {
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "curriculum") {
 		return
 	}
-	var input struct {
-		ID        string `json:"id"`
-		PreviewOn bool   `json:"preview_on"`
+	var input rotorAdvanceModeRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+
+	rotor, err := stores.RotorStore.GetRotor(ctx, input.ID)
+	if err != nil {
+		apierror.NotFound(w, "Rotor not found")
+		return
+	}
+
+	rotor.ManualAdvance = input.ManualAdvance
+	if err := stores.RotorStore.SaveRotor(ctx, rotor); err != nil {
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rotor)
+}
+
+// rotorVoteRulesRequest is the body of POST /api/rotors/vote-rules.
+type rotorVoteRulesRequest struct {
+	ID          string `json:"id"`
+	OnePerTheme bool   `json:"one_per_theme"`
+	ExpiryWeeks int    `json:"expiry_weeks"`
+	CoachWeight int    `json:"coach_weight"`
+}
+
+// handleRotorVoteRules handles POST /api/rotors/vote-rules (one vote per theme, expiry, coach weight)
+// Expiry and weight apply to votes cast from now on.
+func handleRotorVoteRules(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := requirePermission(w, r, permissionDomain.ActionCurriculumEdit)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "curriculum") {
+		return
 	}
+	var input rotorVoteRulesRequest
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	rules := rotorDomain.VoteRules{OnePerTheme: input.OnePerTheme, ExpiryWeeks: input.ExpiryWeeks, CoachWeight: input.CoachWeight}
+	if err := rules.Validate(); err != nil {
+		apierror.Validation(w, err.Error())
 		return
 	}
 
 	rotor, err := stores.RotorStore.GetRotor(ctx, input.ID)
 	if err != nil {
-		http.Error(w, "Rotor not found", http.StatusNotFound)
+		apierror.NotFound(w, "Rotor not found")
 		return
 	}
 
-	rotor.PreviewOn = input.PreviewOn
+	rotor.VoteRules = rules
 	if err := stores.RotorStore.SaveRotor(ctx, rotor); err != nil {
 		internalError(w, err)
 		return
@@ -6378,12 +7897,20 @@ func handleRotorPreview(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(rotor)
 }
 
+// rotorThemeCreateRequest is the body of POST /api/rotors/themes.
+type rotorThemeCreateRequest struct {
+	RotorID  string `json:"rotor_id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+	Hidden   bool   `json:"hidden"`
+}
+
 // handleRotorThemes handles GET/POST/DELETE for /api/rotors/themes
 func handleRotorThemes(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	sess, ok := middleware.GetSessionFromContext(ctx)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "curriculum") {
@@ -6391,13 +7918,13 @@ func handleRotorThemes(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "GET" {
-		if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+		if !permissionAllowed(r.Context(), sess, permissionDomain.ActionCurriculumView) {
+			apierror.Forbidden(w, "Forbidden")
 			return
 		}
 		rotorID := r.URL.Query().Get("rotor_id")
 		if rotorID == "" {
-			http.Error(w, "rotor_id is required", http.StatusBadRequest)
+			apierror.Validation(w, "rotor_id is required")
 			return
 		}
 		themes, err := stores.RotorStore.ListThemesByRotor(ctx, rotorID)
@@ -6414,25 +7941,20 @@ func handleRotorThemes(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "POST" {
-		var input struct {
-			RotorID  string `json:"rotor_id"`
-			Name     string `json:"name"`
-			Position int    `json:"position"`
-			Hidden   bool   `json:"hidden"`
-		}
+		var input rotorThemeCreateRequest
 		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 
 		// Verify rotor exists and is in draft
 		rotor, err := stores.RotorStore.GetRotor(ctx, input.RotorID)
 		if err != nil {
-			http.Error(w, "Rotor not found", http.StatusNotFound)
+			apierror.NotFound(w, "Rotor not found")
 			return
 		}
 		if !rotor.IsDraft() {
-			http.Error(w, "can only add themes to draft rotors", http.StatusBadRequest)
+			apierror.Validation(w, "can only add themes to draft rotors")
 			return
 		}
 
@@ -6444,7 +7966,7 @@ func handleRotorThemes(w http.ResponseWriter, r *http.Request) {
 			Hidden:   input.Hidden,
 		}
 		if err := theme.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.RotorStore.SaveRotorTheme(ctx, theme); err != nil {
@@ -6460,7 +7982,7 @@ func handleRotorThemes(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "DELETE" {
 		id := r.URL.Query().Get("id")
 		if id == "" {
-			http.Error(w, "id is required", http.StatusBadRequest)
+			apierror.Validation(w, "id is required")
 			return
 		}
 		if err := stores.RotorStore.DeleteRotorTheme(ctx, id); err != nil {
@@ -6471,7 +7993,27 @@ func handleRotorThemes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// topicCreateRequest is the body of POST /api/rotors/topics.
+type topicCreateRequest struct {
+	RotorThemeID  string `json:"rotor_theme_id"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	DurationWeeks int    `json:"duration_weeks"`
+	Position      int    `json:"position"`
+	SharedTopicID string `json:"shared_topic_id"` // optional: follow a library topic, taking its name and description
+	MinBelt       string `json:"min_belt"`        // optional: members below this belt see the topic locked
+}
+
+// topicUpdateRequest is the body of PUT /api/rotors/topics; nil fields are left unchanged.
+// Changing the name or description of a topic that follows the shared library unlinks it.
+type topicUpdateRequest struct {
+	Name          *string `json:"name"`
+	Description   *string `json:"description"`
+	DurationWeeks *int    `json:"duration_weeks"`
+	MinBelt       *string `json:"min_belt"` // "" clears the gate
 }
 
 // handleTopics handles GET/POST/DELETE for /api/rotors/topics
@@ -6479,7 +8021,7 @@ func handleTopics(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	sess, ok := middleware.GetSessionFromContext(ctx)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "curriculum") {
@@ -6487,13 +8029,13 @@ func handleTopics(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "GET" {
-		if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+		if !permissionAllowed(r.Context(), sess, permissionDomain.ActionCurriculumView) {
+			apierror.Forbidden(w, "Forbidden")
 			return
 		}
 		themeID := r.URL.Query().Get("theme_id")
 		if themeID == "" {
-			http.Error(w, "theme_id is required", http.StatusBadRequest)
+			apierror.Validation(w, "theme_id is required")
 			return
 		}
 		topics, err := stores.RotorStore.ListTopicsByTheme(ctx, themeID)
@@ -6501,29 +8043,40 @@ func handleTopics(w http.ResponseWriter, r *http.Request) {
 			internalError(w, err)
 			return
 		}
-		if topics == nil {
-			topics = []rotorDomain.Topic{}
+		gate := viewerBeltGate(ctx, sess)
+		gated := make([]projections.GatedTopic, 0, len(topics))
+		for _, tp := range topics {
+			gated = append(gated, projections.GateTopic(tp, gate))
 		}
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(topics)
+		json.NewEncoder(w).Encode(gated)
 		return
 	}
 
 	if r.Method == "POST" {
-		var input struct {
-			RotorThemeID  string `json:"rotor_theme_id"`
-			Name          string `json:"name"`
-			Description   string `json:"description"`
-			DurationWeeks int    `json:"duration_weeks"`
-			Position      int    `json:"position"`
-		}
+		var input topicCreateRequest
 		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
+		if input.SharedTopicID != "" {
+			st, err := stores.RotorStore.GetSharedTopic(ctx, input.SharedTopicID)
+			if err != nil {
+				apierror.NotFound(w, "shared topic not found")
+				return
+			}
+			if input.DurationWeeks == 0 {
+				input.DurationWeeks = st.DurationWeeks
+			}
+			input.Name, input.Description = st.Name, st.Description
+		}
 		if input.DurationWeeks == 0 {
 			input.DurationWeeks = 1
 		}
+		if !validMinBelt(input.MinBelt) {
+			apierror.Validation(w, "unknown belt")
+			return
+		}
 
 		topic := rotorDomain.Topic{
 			ID:            generateID(),
@@ -6532,9 +8085,11 @@ func handleTopics(w http.ResponseWriter, r *http.Request) {
 			Description:   input.Description,
 			DurationWeeks: input.DurationWeeks,
 			Position:      input.Position,
+			SharedTopicID: input.SharedTopicID,
+			MinBelt:       input.MinBelt,
 		}
 		if err := topic.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.RotorStore.SaveTopic(ctx, topic); err != nil {
@@ -6550,34 +8105,39 @@ func handleTopics(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "PUT" {
 		id := r.URL.Query().Get("id")
 		if id == "" {
-			http.Error(w, "id is required", http.StatusBadRequest)
+			apierror.Validation(w, "id is required")
 			return
 		}
-		var input struct {
-			Name          *string `json:"name"`
-			Description   *string `json:"description"`
-			DurationWeeks *int    `json:"duration_weeks"`
-		}
+		var input topicUpdateRequest
 		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		topic, err := stores.RotorStore.GetTopic(ctx, id)
 		if err != nil {
-			http.Error(w, "topic not found", http.StatusNotFound)
+			apierror.NotFound(w, "topic not found")
 			return
 		}
-		if input.Name != nil {
+		if input.Name != nil && *input.Name != topic.Name {
 			topic.Name = *input.Name
+			topic.SharedTopicID = ""
 		}
-		if input.Description != nil {
+		if input.Description != nil && *input.Description != topic.Description {
 			topic.Description = *input.Description
+			topic.SharedTopicID = ""
 		}
 		if input.DurationWeeks != nil {
 			topic.DurationWeeks = *input.DurationWeeks
 		}
+		if input.MinBelt != nil {
+			if !validMinBelt(*input.MinBelt) {
+				apierror.Validation(w, "unknown belt")
+				return
+			}
+			topic.MinBelt = *input.MinBelt
+		}
 		if err := topic.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.RotorStore.SaveTopic(ctx, topic); err != nil {
@@ -6592,7 +8152,7 @@ func handleTopics(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "DELETE" {
 		id := r.URL.Query().Get("id")
 		if id == "" {
-			http.Error(w, "id is required", http.StatusBadRequest)
+			apierror.Validation(w, "id is required")
 			return
 		}
 		if err := stores.RotorStore.DeleteTopic(ctx, id); err != nil {
@@ -6603,39 +8163,36 @@ func handleTopics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// topicReorderRequest is the body of POST /api/rotors/topics/reorder.
+type topicReorderRequest struct {
+	RotorThemeID string   `json:"rotor_theme_id"`
+	TopicIDs     []string `json:"topic_ids"`
 }
 
 // handleTopicReorder handles POST /api/rotors/topics/reorder
 func handleTopicReorder(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
-	ctx := r.Context()
-	sess, ok := middleware.GetSessionFromContext(ctx)
+	sess, ok := requirePermission(w, r, permissionDomain.ActionCurriculumEdit)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "curriculum") {
 		return
 	}
 
-	var input struct {
-		RotorThemeID string   `json:"rotor_theme_id"`
-		TopicIDs     []string `json:"topic_ids"`
-	}
+	var input topicReorderRequest
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 	if input.RotorThemeID == "" || len(input.TopicIDs) == 0 {
-		http.Error(w, "rotor_theme_id and topic_ids are required", http.StatusBadRequest)
+		apierror.Validation(w, "rotor_theme_id and topic_ids are required")
 		return
 	}
 
@@ -6646,34 +8203,32 @@ func handleTopicReorder(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// topicScheduleActionRequest is the body of POST /api/rotors/schedule/action.
+type topicScheduleActionRequest struct {
+	Action       string `json:"action"` // activate, complete, skip, extend
+	TopicID      string `json:"topic_id"`
+	RotorThemeID string `json:"rotor_theme_id"`
+	ExtendWeeks  int    `json:"extend_weeks"`
+}
+
 // handleTopicScheduleAction handles POST /api/rotors/schedule/action
 // Actions: "activate" (start a topic), "complete", "skip", "extend"
 func handleTopicScheduleAction(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	ctx := r.Context()
-	sess, ok := middleware.GetSessionFromContext(ctx)
+	sess, ok := requirePermission(w, r, permissionDomain.ActionCurriculumEdit)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "curriculum") {
 		return
 	}
-	var input struct {
-		Action       string `json:"action"` // activate, complete, skip, extend
-		TopicID      string `json:"topic_id"`
-		RotorThemeID string `json:"rotor_theme_id"`
-		ExtendWeeks  int    `json:"extend_weeks"`
-	}
+	var input topicScheduleActionRequest
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 
@@ -6698,7 +8253,7 @@ func handleTopicScheduleAction(w http.ResponseWriter, r *http.Request) {
 
 		topic, err := stores.RotorStore.GetTopic(ctx, input.TopicID)
 		if err != nil {
-			http.Error(w, "Topic not found", http.StatusNotFound)
+			apierror.NotFound(w, "Topic not found")
 			return
 		}
 
@@ -6718,54 +8273,31 @@ func handleTopicScheduleAction(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(sched)
 
 	case "complete":
-		sched, err := stores.RotorStore.GetActiveScheduleForTheme(ctx, input.RotorThemeID)
-		if err != nil {
-			http.Error(w, "No active schedule for theme", http.StatusNotFound)
+		// Completing advances the theme the same way the scheduled auto-advance does.
+		result, err := orchestrators.ExecuteAdvanceTopic(ctx, orchestrators.AdvanceTopicInput{RotorThemeID: input.RotorThemeID}, orchestrators.AdvanceTopicDeps{
+			RotorStore: stores.RotorStore,
+			GenerateID: generateID,
+			Now:        timeNow,
+		})
+		if errors.Is(err, orchestrators.ErrNoActiveTopic) {
+			apierror.NotFound(w, "No active schedule for theme")
 			return
 		}
-		sched.Status = rotorDomain.ScheduleStatusCompleted
-		sched.EndDate = now
-		if err := stores.RotorStore.SaveTopicSchedule(ctx, sched); err != nil {
+		if err != nil {
 			internalError(w, err)
 			return
 		}
-		// Update last_covered
-		topic, topicErr := stores.RotorStore.GetTopic(ctx, sched.TopicID)
-		if topicErr == nil {
-			topic.LastCovered = now
-			stores.RotorStore.SaveTopic(ctx, topic)
-		}
-		// Clear votes for the completed topic
-		stores.RotorStore.DeleteVotesForTopic(ctx, sched.TopicID)
-
-		// Auto-advance: activate the next topic in queue order (wraps around)
-		var nextSched *rotorDomain.TopicSchedule
-		allTopics, _ := stores.RotorStore.ListTopicsByTheme(ctx, input.RotorThemeID)
-		nextTopic := rotorDomain.NextTopicInQueue(allTopics, sched.TopicID)
-		if nextTopic != nil {
-			ns := rotorDomain.TopicSchedule{
-				ID:           generateID(),
-				TopicID:      nextTopic.ID,
-				RotorThemeID: input.RotorThemeID,
-				StartDate:    now,
-				EndDate:      now.AddDate(0, 0, nextTopic.DurationWeeks*7),
-				Status:       rotorDomain.ScheduleStatusActive,
-			}
-			if err := stores.RotorStore.SaveTopicSchedule(ctx, ns); err == nil {
-				nextSched = &ns
-			}
-		}
 
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
-			"completed":    sched,
-			"next_started": nextSched,
+			"completed":    result.Completed,
+			"next_started": result.Next,
 		})
 
 	case "skip":
 		sched, err := stores.RotorStore.GetActiveScheduleForTheme(ctx, input.RotorThemeID)
 		if err != nil {
-			http.Error(w, "No active schedule for theme", http.StatusNotFound)
+			apierror.NotFound(w, "No active schedule for theme")
 			return
 		}
 		sched.Status = rotorDomain.ScheduleStatusSkipped
@@ -6780,7 +8312,7 @@ func handleTopicScheduleAction(w http.ResponseWriter, r *http.Request) {
 	case "extend":
 		sched, err := stores.RotorStore.GetActiveScheduleForTheme(ctx, input.RotorThemeID)
 		if err != nil {
-			http.Error(w, "No active schedule for theme", http.StatusNotFound)
+			apierror.NotFound(w, "No active schedule for theme")
 			return
 		}
 		weeks := input.ExtendWeeks
@@ -6796,16 +8328,22 @@ func handleTopicScheduleAction(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(sched)
 
 	default:
-		http.Error(w, "invalid action: must be activate, complete, skip, or extend", http.StatusBadRequest)
+		apierror.Validation(w, "invalid action: must be activate, complete, skip, or extend")
 	}
 }
 
-// handleVotes handles POST /api/votes (cast a vote) and GET /api/votes?topic_id=<id> (get vote count)
+// voteRequest is the body of POST /api/votes.
+type voteRequest struct {
+	TopicID string `json:"topic_id"`
+}
+
+// handleVotes handles POST /api/votes (cast a vote under the rotor's vote rules) and
+// GET /api/votes?topic_id=<id> (the topic's weighted count of active votes)
 func handleVotes(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	sess, ok := middleware.GetSessionFromContext(ctx)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "curriculum") {
@@ -6815,10 +8353,10 @@ func handleVotes(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "GET" {
 		topicID := r.URL.Query().Get("topic_id")
 		if topicID == "" {
-			http.Error(w, "topic_id is required", http.StatusBadRequest)
+			apierror.Validation(w, "topic_id is required")
 			return
 		}
-		count, err := stores.RotorStore.CountVotesForTopic(ctx, topicID)
+		count, err := stores.RotorStore.CountVotesForTopic(ctx, topicID, timeNow())
 		if err != nil {
 			internalError(w, err)
 			return
@@ -6829,77 +8367,118 @@ func handleVotes(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "POST" {
-		session, ok := middleware.GetSessionFromContext(ctx)
-		if !ok {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		var input struct {
-			TopicID string `json:"topic_id"`
-		}
+		var input voteRequest
 		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		if input.TopicID == "" {
-			http.Error(w, "topic_id is required", http.StatusBadRequest)
+			apierror.Validation(w, "topic_id is required")
+			return
+		}
+		if _, err := stores.RotorStore.GetTopic(ctx, input.TopicID); err != nil {
+			apierror.NotFound(w, "Topic not found")
 			return
 		}
 
-		vote := rotorDomain.Vote{
-			ID:        generateID(),
+		result, err := orchestrators.ExecuteCastTopicVote(ctx, orchestrators.CastTopicVoteInput{
 			TopicID:   input.TopicID,
-			AccountID: session.AccountID,
-			CreatedAt: timeNow(),
-		}
-		if err := stores.RotorStore.SaveVote(ctx, vote); err != nil {
-			if err == rotorDomain.ErrAlreadyVoted {
-				http.Error(w, err.Error(), http.StatusConflict)
+			AccountID: sess.AccountID,
+			Staff:     isStaffSession(sess),
+		}, orchestrators.CastTopicVoteDeps{
+			RotorStore: stores.RotorStore,
+			GenerateID: generateID,
+			Now:        timeNow,
+		})
+		if err != nil {
+			if errors.Is(err, rotorDomain.ErrAlreadyVoted) {
+				apierror.Conflict(w, err.Error())
 				return
 			}
 			internalError(w, err)
 			return
 		}
+		if result.Replaced == nil {
+			result.Replaced = []string{}
+		}
 
-		count, _ := stores.RotorStore.CountVotesForTopic(ctx, input.TopicID)
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
-		json.NewEncoder(w).Encode(map[string]interface{}{"status": "voted", "votes": count})
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "voted", "votes": result.Votes, "weight": result.Vote.Weight, "replaced": result.Replaced})
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// handleMyVotes handles GET /api/votes/mine
+// Returns the signed-in account's votes that still count, with their weight and expiry.
+func handleMyVotes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "curriculum") {
+		return
+	}
+	votes, err := projections.QueryGetTopicVotes(r.Context(), projections.GetTopicVotesQuery{
+		AccountID: sess.AccountID,
+		Now:       timeNow(),
+	}, projections.GetTopicVotesDeps{RotorStore: stores.RotorStore})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"votes": votes})
+}
+
+// topicBumpRequest is the body of POST /api/rotors/topics/bump.
+type topicBumpRequest struct {
+	TopicID      string `json:"topic_id"`
+	RotorThemeID string `json:"rotor_theme_id"`
 }
 
 // handleTopicBump handles POST /api/rotors/topics/bump ΓÇö bumps a voted topic to current position
 func handleTopicBump(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionCurriculumEdit)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "curriculum") {
 		return
 	}
 	ctx := r.Context()
 
-	var input struct {
-		TopicID      string `json:"topic_id"`
-		RotorThemeID string `json:"rotor_theme_id"`
-	}
+	var input topicBumpRequest
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 
 	topic, err := stores.RotorStore.GetTopic(ctx, input.TopicID)
 	if err != nil {
-		http.Error(w, "Topic not found", http.StatusNotFound)
+		apierror.NotFound(w, "Topic not found")
 		return
 	}
 
-	// Complete current active schedule if any
+	// Put the current topic back in the queue; it resumes once the bumped topic ends
 	activeSched, err := stores.RotorStore.GetActiveScheduleForTheme(ctx, input.RotorThemeID)
 	if err == nil {
-		activeSched.Status = rotorDomain.ScheduleStatusCompleted
-		activeSched.EndDate = timeNow()
+		activeSched.Status = rotorDomain.ScheduleStatusScheduled
+		if activeSched.TopicID == topic.ID {
+			activeSched.Status = rotorDomain.ScheduleStatusCompleted
+			activeSched.EndDate = timeNow()
+		}
 		stores.RotorStore.SaveTopicSchedule(ctx, activeSched)
 	}
 
@@ -6912,6 +8491,7 @@ func handleTopicBump(w http.ResponseWriter, r *http.Request) {
 		StartDate:    now,
 		EndDate:      now.AddDate(0, 0, topic.DurationWeeks*7),
 		Status:       rotorDomain.ScheduleStatusActive,
+		Bumped:       true,
 	}
 	if err := stores.RotorStore.SaveTopicSchedule(ctx, sched); err != nil {
 		internalError(w, err)
@@ -6929,17 +8509,11 @@ func handleTopicBump(w http.ResponseWriter, r *http.Request) {
 // Returns the active curriculum across all class types for the Theme Carousel page.
 func handleCurriculumOverview(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
-	ctx := r.Context()
-	sess, ok := middleware.GetSessionFromContext(ctx)
+	sess, ok := requirePermission(w, r, permissionDomain.ActionCurriculumView)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "curriculum") {
@@ -6947,6 +8521,8 @@ func handleCurriculumOverview(w http.ResponseWriter, r *http.Request) {
 	}
 	query := projections.GetCurriculumOverviewQuery{
 		Role: sess.Role,
+		Gate: viewerBeltGate(r.Context(), sess),
+		Now:  timeNow(),
 	}
 	deps := projections.GetCurriculumOverviewDeps{
 		ClassTypeStore: stores.ClassTypeStore,
@@ -6966,17 +8542,12 @@ func handleCurriculumOverview(w http.ResponseWriter, r *http.Request) {
 // Returns the full curriculum state for a class: active rotor, themes, topics, schedules, votes.
 func handleCurriculumView(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 	ctx := r.Context()
-	sess, ok := middleware.GetSessionFromContext(ctx)
+	sess, ok := requirePermission(w, r, permissionDomain.ActionCurriculumView)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "curriculum") {
@@ -6984,7 +8555,7 @@ func handleCurriculumView(w http.ResponseWriter, r *http.Request) {
 	}
 	classTypeID := r.URL.Query().Get("class_type_id")
 	if classTypeID == "" {
-		http.Error(w, "class_type_id is required", http.StatusBadRequest)
+		apierror.Validation(w, "class_type_id is required")
 		return
 	}
 
@@ -7005,6 +8576,7 @@ func handleCurriculumView(w http.ResponseWriter, r *http.Request) {
 		rotorDomain.Topic
 		Votes    int  `json:"votes"`
 		IsActive bool `json:"is_active"`
+		Locked   bool `json:"locked"`
 	}
 	type themeView struct {
 		rotorDomain.RotorTheme
@@ -7012,6 +8584,7 @@ func handleCurriculumView(w http.ResponseWriter, r *http.Request) {
 		ActiveSchedule *rotorDomain.TopicSchedule `json:"active_schedule"`
 	}
 
+	gate := viewerBeltGate(ctx, sess)
 	var themeViews []themeView
 	for _, th := range themes {
 		tv := themeView{RotorTheme: th}
@@ -7021,9 +8594,10 @@ func handleCurriculumView(w http.ResponseWriter, r *http.Request) {
 			tv.ActiveSchedule = &activeSched
 		}
 		for _, tp := range topics {
-			votes, _ := stores.RotorStore.CountVotesForTopic(ctx, tp.ID)
+			votes, _ := stores.RotorStore.CountVotesForTopic(ctx, tp.ID, timeNow())
 			isActive := tv.ActiveSchedule != nil && tv.ActiveSchedule.TopicID == tp.ID
-			tv.Topics = append(tv.Topics, topicWithVotes{Topic: tp, Votes: votes, IsActive: isActive})
+			gated := projections.GateTopic(tp, gate)
+			tv.Topics = append(tv.Topics, topicWithVotes{Topic: gated.Topic, Votes: votes, IsActive: isActive, Locked: gated.Locked})
 		}
 		if tv.Topics == nil {
 			tv.Topics = []topicWithVotes{}
@@ -7049,13 +8623,8 @@ func handleCalendarPage(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
 		return
 	}
-	sess, ok := middleware.GetSessionFromContext(r.Context())
+	sess, ok := requirePermissionPage(w, r, permissionDomain.ActionCalendarView)
 	if !ok {
-		http.Redirect(w, r, "/login", http.StatusSeeOther)
-		return
-	}
-	if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" && sess.Role != "trial" {
-		http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
 		return
 	}
 	if !requireFeaturePage(w, r, sess, "calendar") {
@@ -7066,12 +8635,23 @@ func handleCalendarPage(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// calendarEventCreateRequest is the body of POST /api/calendar/events.
+type calendarEventCreateRequest struct {
+	Title           string `json:"title"`
+	Type            string `json:"type"`
+	Description     string `json:"description"`
+	Location        string `json:"location"`
+	StartDate       string `json:"start_date"`
+	EndDate         string `json:"end_date"`
+	RegistrationURL string `json:"registration_url"`
+}
+
 // handleCalendarEvents handles GET/POST/PUT/DELETE for /api/calendar/events
 func handleCalendarEvents(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	sess, ok := middleware.GetSessionFromContext(ctx)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "calendar") {
@@ -7079,14 +8659,14 @@ func handleCalendarEvents(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method == "GET" {
-		if sess.Role != "admin" && sess.Role != "coach" && sess.Role != "member" && sess.Role != "trial" {
-			http.Error(w, "Forbidden", http.StatusForbidden)
+		if !permissionAllowed(r.Context(), sess, permissionDomain.ActionCalendarView) {
+			apierror.Forbidden(w, "Forbidden")
 			return
 		}
 		from := r.URL.Query().Get("from")
 		to := r.URL.Query().Get("to")
 		if from == "" || to == "" {
-			http.Error(w, "from and to date params required (YYYY-MM-DD)", http.StatusBadRequest)
+			apierror.Validation(w, "from and to date params required (YYYY-MM-DD)")
 			return
 		}
 		events, err := stores.CalendarEventStore.ListByDateRange(ctx, from, to)
@@ -7104,32 +8684,24 @@ func handleCalendarEvents(w http.ResponseWriter, r *http.Request) {
 
 	if r.Method == "POST" {
 		if !middleware.IsRole(ctx, "admin") {
-			http.Error(w, "admin only", http.StatusForbidden)
+			apierror.Forbidden(w, "admin only")
 			return
 		}
-		var input struct {
-			Title           string `json:"title"`
-			Type            string `json:"type"`
-			Description     string `json:"description"`
-			Location        string `json:"location"`
-			StartDate       string `json:"start_date"`
-			EndDate         string `json:"end_date"`
-			RegistrationURL string `json:"registration_url"`
-		}
+		var input calendarEventCreateRequest
 		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		startDate, err := time.Parse("2006-01-02", input.StartDate)
 		if err != nil {
-			http.Error(w, "invalid start_date format (use YYYY-MM-DD)", http.StatusBadRequest)
+			apierror.Validation(w, "invalid start_date format (use YYYY-MM-DD)")
 			return
 		}
 		var endDate time.Time
 		if input.EndDate != "" {
 			endDate, err = time.Parse("2006-01-02", input.EndDate)
 			if err != nil {
-				http.Error(w, "invalid end_date format (use YYYY-MM-DD)", http.StatusBadRequest)
+				apierror.Validation(w, "invalid end_date format (use YYYY-MM-DD)")
 				return
 			}
 		}
@@ -7138,7 +8710,7 @@ func handleCalendarEvents(w http.ResponseWriter, r *http.Request) {
 		}
 		sess, ok := middleware.GetSessionFromContext(ctx)
 		if !ok {
-			http.Error(w, "not authenticated", http.StatusUnauthorized)
+			apierror.Unauthorized(w, "not authenticated")
 			return
 		}
 		event := calendarDomain.Event{
@@ -7154,7 +8726,7 @@ func handleCalendarEvents(w http.ResponseWriter, r *http.Request) {
 			CreatedAt:       time.Now(),
 		}
 		if err := event.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 		if err := stores.CalendarEventStore.Save(ctx, event); err != nil {
@@ -7169,12 +8741,12 @@ func handleCalendarEvents(w http.ResponseWriter, r *http.Request) {
 
 	if r.Method == "DELETE" {
 		if !middleware.IsRole(ctx, "admin") {
-			http.Error(w, "admin only", http.StatusForbidden)
+			apierror.Forbidden(w, "admin only")
 			return
 		}
 		id := r.URL.Query().Get("id")
 		if id == "" {
-			http.Error(w, "id is required", http.StatusBadRequest)
+			apierror.Validation(w, "id is required")
 			return
 		}
 		if err := stores.CalendarEventStore.Delete(ctx, id); err != nil {
@@ -7185,5 +8757,5 @@ func handleCalendarEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
 }
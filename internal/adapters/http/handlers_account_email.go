@@ -0,0 +1,175 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	accountDomain "workshop/internal/domain/account"
+)
+
+// accountEmailRequest is the body of POST /api/account/email.
+type accountEmailRequest struct {
+	Password string `json:"Password"`
+	NewEmail string `json:"NewEmail"`
+}
+
+// confirmEmailRequest is the body of POST /api/account/email/confirm.
+type confirmEmailRequest struct {
+	Token string `json:"Token"`
+}
+
+// changeEmailDeps wires the email change orchestrators to the server's stores and sender.
+func changeEmailDeps(r *http.Request) orchestrators.ChangeEmailDeps {
+	return orchestrators.ChangeEmailDeps{
+		AccountStore:  stores.AccountStore,
+		MemberStore:   stores.MemberStore,
+		ChangeStore:   stores.AccountEmailChangeStore,
+		EmailSender:   emailSender,
+		FromAddress:   emailFromAddress,
+		ReplyTo:       emailReplyTo,
+		BaseURL:       emailLinkBaseURL(r),
+		GenerateID:    generateID,
+		GenerateToken: generateID,
+		Now:           timeNow,
+	}
+}
+
+// handleAccountEmail handles POST /api/account/email
+// Checks the caller's password and emails a confirmation link to the new address. The account
+// keeps its current email until that link is confirmed.
+func handleAccountEmail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "email_change") {
+		return
+	}
+	if sess.IsImpersonating() {
+		apierror.Forbidden(w, "stop impersonating to change an account's email")
+		return
+	}
+	var input accountEmailRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if len(input.NewEmail) > accountDomain.MaxEmailLength {
+		apierror.Validation(w, "email cannot exceed 254 characters")
+		return
+	}
+	if emailSender == nil {
+		apierror.Unavailable(w, "email sending is not configured")
+		return
+	}
+
+	change, err := orchestrators.ExecuteRequestEmailChange(r.Context(), orchestrators.RequestEmailChangeInput{
+		AccountID: sess.AccountID,
+		Password:  input.Password,
+		NewEmail:  input.NewEmail,
+	}, changeEmailDeps(r))
+	switch {
+	case errors.Is(err, accountDomain.ErrEmailTaken):
+		apierror.Conflict(w, err.Error())
+		return
+	case errors.Is(err, orchestrators.ErrEmailChangeFieldsRequired),
+		errors.Is(err, orchestrators.ErrCurrentPasswordWrong),
+		errors.Is(err, accountDomain.ErrSameEmail),
+		errors.Is(err, accountDomain.ErrEmptyEmail),
+		errors.Is(err, accountDomain.ErrInvalidEmail):
+		apierror.Validation(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"status": "sent", "new_email": change.NewEmail})
+}
+
+// handleConfirmEmailPage handles GET /account/email/confirm?token=...
+// Shows a button that confirms the change; following the link alone changes nothing, so mail
+// scanners that open links can't confirm on the member's behalf.
+func handleConfirmEmailPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		http.Error(w, "Missing confirmation token", http.StatusBadRequest)
+		return
+	}
+
+	change, err := stores.AccountEmailChangeStore.GetEmailChangeByToken(r.Context(), token)
+	if err != nil {
+		renderTemplate(w, r, "confirm_email.html", map[string]any{"Error": "Invalid confirmation link."})
+		return
+	}
+	if err := change.CheckUsable(timeNow()); err != nil {
+		msg := "This confirmation link has already been used."
+		if errors.Is(err, accountDomain.ErrEmailChangeExpired) {
+			msg = "This confirmation link has expired. Sign in with your current email and ask again."
+		}
+		renderTemplate(w, r, "confirm_email.html", map[string]any{"Error": msg})
+		return
+	}
+
+	renderTemplate(w, r, "confirm_email.html", map[string]any{"Token": token, "NewEmail": change.NewEmail})
+}
+
+// handleConfirmEmail handles POST /api/account/email/confirm
+// Moves the account, its member profiles and unsent emails to the new address, then signs the
+// account out everywhere so every device signs in again with it.
+func handleConfirmEmail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	var input confirmEmailRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if input.Token == "" {
+		apierror.Validation(w, "Token is required")
+		return
+	}
+
+	result, err := orchestrators.ExecuteConfirmEmailChange(r.Context(), input.Token, changeEmailDeps(r))
+	switch {
+	case errors.Is(err, accountDomain.ErrEmailTaken):
+		apierror.Conflict(w, err.Error())
+		return
+	case errors.Is(err, accountDomain.ErrEmailChangeInvalid),
+		errors.Is(err, accountDomain.ErrEmailChangeUsed),
+		errors.Is(err, accountDomain.ErrEmailChangeExpired):
+		apierror.Validation(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+
+	revoked, err := sessions.RevokeAccount(r.Context(), result.Change.AccountID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "security_event", "event", "email_change_revoke_failed", "account_id", result.Change.AccountID, "error", err)
+	}
+	slog.InfoContext(r.Context(), "security_event", "event", "logout_all", "account_id", result.Change.AccountID, "sessions", revoked)
+	middleware.ClearSessionCookie(w)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "changed", "email": result.Change.NewEmail})
+}
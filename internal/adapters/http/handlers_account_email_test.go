@@ -0,0 +1,114 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	emailAdapter "workshop/internal/adapters/email"
+	"workshop/internal/adapters/http/middleware"
+	accountStore "workshop/internal/adapters/storage/account"
+	accountDomain "workshop/internal/domain/account"
+)
+
+type mockEmailChangeStore struct {
+	changes map[string]accountDomain.EmailChange // by token
+}
+
+// SaveEmailChange stores a pending change.
+// PRE: value has a token
+// POST: The change is stored by token
+func (m *mockEmailChangeStore) SaveEmailChange(_ context.Context, value accountDomain.EmailChange) error {
+	m.changes[value.Token] = value
+	return nil
+}
+
+// GetEmailChangeByToken returns a change by token.
+// PRE: token is non-empty
+// POST: Returns the change or an error
+func (m *mockEmailChangeStore) GetEmailChangeByToken(_ context.Context, token string) (accountDomain.EmailChange, error) {
+	if c, ok := m.changes[token]; ok {
+		return c, nil
+	}
+	return accountDomain.EmailChange{}, errors.New("not found")
+}
+
+// ApplyEmailChange moves the account to the new address.
+// PRE: value is usable
+// POST: The account uses NewEmail and the change is used
+func (m *mockEmailChangeStore) ApplyEmailChange(ctx context.Context, value accountDomain.EmailChange, confirmedAt time.Time) (accountStore.EmailChangeApplied, error) {
+	acct, err := stores.AccountStore.GetByID(ctx, value.AccountID)
+	if err != nil {
+		return accountStore.EmailChangeApplied{}, err
+	}
+	acct.Email = value.NewEmail
+	value.Used = true
+	value.ConfirmedAt = confirmedAt
+	m.changes[value.Token] = value
+	return accountStore.EmailChangeApplied{}, stores.AccountStore.Save(ctx, acct)
+}
+
+// TestAccountEmailChange verifies a signed-in member can ask to move their account to a new
+// address, and confirming the emailed link changes it and signs them out everywhere.
+func TestAccountEmailChange(t *testing.T) {
+	stores = newFullStores()
+	changes := &mockEmailChangeStore{changes: map[string]accountDomain.EmailChange{}}
+	stores.AccountEmailChangeStore = changes
+	sessions = middleware.NewSessionStore(newMockAuthSessionStore())
+	ctx := context.Background()
+	acct := accountDomain.Account{ID: memberSession.AccountID, Email: memberSession.Email, Role: "member"}
+	if err := acct.SetPassword("correct horse battery"); err != nil {
+		t.Fatal(err)
+	}
+	stores.AccountStore.Save(ctx, acct)
+	token, err := sessions.Create(ctx, acct.ID, acct.Email, "member", false, false)
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+
+	body := `{"Password":"correct horse battery","NewEmail":"marcus@new.test"}`
+	rec := httptest.NewRecorder()
+	handleAccountEmail(rec, authRequest("POST", "/api/account/email", body, memberSession))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("without email: expected 503, got %d", rec.Code)
+	}
+
+	SetEmailSender(emailAdapter.NewNoopSender(), "gym@test.com", "")
+	t.Cleanup(func() { SetEmailSender(nil, "", "") })
+	rec = httptest.NewRecorder()
+	handleAccountEmail(rec, authRequest("POST", "/api/account/email", `{"Password":"wrong","NewEmail":"marcus@new.test"}`, memberSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("wrong password: expected 400, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleAccountEmail(rec, authRequest("POST", "/api/account/email", body, memberSession))
+	if rec.Code != http.StatusAccepted || len(changes.changes) != 1 {
+		t.Fatalf("expected 202 and a pending change, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var link string
+	for tok := range changes.changes {
+		link = tok
+	}
+
+	rec = httptest.NewRecorder()
+	handleConfirmEmail(rec, httptest.NewRequest("POST", "/api/account/email/confirm", strings.NewReader(`{"Token":"`+link+`"}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("confirm: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got, _ := stores.AccountStore.GetByID(ctx, acct.ID); got.Email != "marcus@new.test" {
+		t.Errorf("account email = %q, want marcus@new.test", got.Email)
+	}
+	if _, ok := sessions.Get(ctx, token); ok {
+		t.Error("expected the account's sessions to be revoked")
+	}
+
+	rec = httptest.NewRecorder()
+	handleConfirmEmail(rec, httptest.NewRequest("POST", "/api/account/email/confirm", strings.NewReader(`{"Token":"`+link+`"}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("reused link: expected 400, got %d", rec.Code)
+	}
+}
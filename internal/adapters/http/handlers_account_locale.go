@@ -0,0 +1,85 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/i18n"
+	"workshop/internal/adapters/http/middleware"
+	accountDomain "workshop/internal/domain/account"
+)
+
+// accountLocaleRequest is the body of PUT /api/account/locale.
+type accountLocaleRequest struct {
+	Locale string `json:"Locale"` // empty = follow the browser
+}
+
+// accountLocaleResponse describes the caller's language and the choices offered.
+type accountLocaleResponse struct {
+	Locale    string        // chosen locale; empty = follow the browser
+	Effective string        // locale pages are shown in for this request
+	Locales   []i18n.Locale // supported locales, default first
+}
+
+// handleAccountLocale handles GET/PUT for /api/account/locale
+// GET returns the caller's chosen language; PUT saves a new one on the account and switches
+// the current session to it. Other signed-in devices pick it up at their next sign-in.
+func handleAccountLocale(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "languages") {
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(accountLocaleResponse{Locale: sess.Locale, Effective: i18n.FromContext(ctx), Locales: i18n.Locales()})
+
+	case "PUT":
+		var input accountLocaleRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		acct, err := stores.AccountStore.GetByID(ctx, sess.AccountID)
+		if err != nil {
+			apierror.NotFound(w, "account not found")
+			return
+		}
+		if err := acct.SetLocale(input.Locale); err != nil {
+			if errors.Is(err, accountDomain.ErrInvalidLocale) {
+				apierror.Validation(w, err.Error())
+				return
+			}
+			internalError(w, err)
+			return
+		}
+		if err := stores.AccountStore.Save(ctx, acct); err != nil {
+			internalError(w, err)
+			return
+		}
+		if cookie, err := r.Cookie("workshop_session"); err == nil {
+			sess.Locale = acct.Locale
+			sessions.Update(ctx, cookie.Value, sess)
+		}
+		slog.InfoContext(ctx, "auth_event", "event", "locale_changed", "account_id", acct.ID, "locale", acct.Locale)
+
+		effective := i18n.Match(acct.Locale)
+		if effective == "" {
+			effective = i18n.Negotiate(r.Header.Get("Accept-Language"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(accountLocaleResponse{Locale: acct.Locale, Effective: effective, Locales: i18n.Locales()})
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
@@ -0,0 +1,52 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/adapters/http/middleware"
+	accountDomain "workshop/internal/domain/account"
+)
+
+// TestHandleAccountLocale_SavesAccountAndSession verifies a chosen language is kept on the
+// account for future sign-ins and applied to the current session straight away.
+func TestHandleAccountLocale_SavesAccountAndSession(t *testing.T) {
+	stores = newFullStores()
+	sessions = middleware.NewSessionStore(newMockAuthSessionStore())
+	ctx := context.Background()
+	stores.AccountStore.Save(ctx, accountDomain.Account{ID: memberSession.AccountID, Email: memberSession.Email, Role: "member"})
+	token, err := sessions.Create(ctx, memberSession.AccountID, memberSession.Email, "member", false, false)
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+
+	req := authRequest("PUT", "/api/account/locale", `{"Locale":"fr"}`, memberSession)
+	req.AddCookie(&http.Cookie{Name: "workshop_session", Value: token})
+	rec := httptest.NewRecorder()
+	handleAccountLocale(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("unsupported locale: expected 400, got %d", rec.Code)
+	}
+
+	req = authRequest("PUT", "/api/account/locale", `{"Locale":"mi"}`, memberSession)
+	req.AddCookie(&http.Cookie{Name: "workshop_session", Value: token})
+	rec = httptest.NewRecorder()
+	handleAccountLocale(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var got accountLocaleResponse
+	json.NewDecoder(rec.Body).Decode(&got)
+	if got.Locale != "mi" || got.Effective != "mi" || len(got.Locales) != len(accountDomain.ValidLocales) {
+		t.Errorf("unexpected response: %+v", got)
+	}
+	if acct, _ := stores.AccountStore.GetByID(ctx, memberSession.AccountID); acct.Locale != "mi" {
+		t.Errorf("account Locale = %q, want mi", acct.Locale)
+	}
+	if sess, _ := sessions.Get(ctx, token); sess.Locale != "mi" {
+		t.Errorf("session Locale = %q, want mi", sess.Locale)
+	}
+}
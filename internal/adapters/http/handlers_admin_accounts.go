@@ -0,0 +1,64 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+)
+
+// handleBulkProvisionAccounts handles POST /api/admin/accounts/bulk-provision
+// Creates a pending-activation account for every unarchived member without one and
+// queues their activation emails. ?dry_run=true reports the outcome without writing.
+// Returns per-member outcomes; a rejected member does not fail the request. Admin only.
+func handleBulkProvisionAccounts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "member_mgmt") {
+		return
+	}
+
+	result, err := orchestrators.ExecuteBulkProvisionAccounts(r.Context(), orchestrators.BulkProvisionInput{
+		ActivationBaseURL: requestBaseURL(r),
+		DryRun:            r.URL.Query().Get("dry_run") == "true",
+		Actor: orchestrators.BackfillActor{
+			AccountID: sess.AccountID,
+			Email:     sess.Email,
+			Role:      sess.Role,
+			IPAddress: middleware.ClientIP(r),
+			UserAgent: r.UserAgent(),
+		},
+	}, orchestrators.BulkProvisionDeps{
+		MemberStore:  stores.MemberStore,
+		AccountStore: stores.AccountStore,
+		EmailStore:   stores.EmailStore,
+		AuditStore:   stores.AuditStore,
+		GenerateID:   generateID,
+		Now:          timeNow,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// requestBaseURL returns the scheme and host the request arrived on, for links in emails.
+// Production always sits behind TLS, so it is reported as https even when a proxy terminates it.
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || appConfig.IsProduction() {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
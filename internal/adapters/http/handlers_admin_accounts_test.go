@@ -0,0 +1,51 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/application/orchestrators"
+	memberDomain "workshop/internal/domain/member"
+)
+
+// TestHandleBulkProvisionAccounts_DryRun verifies an admin dry run reports members without accounts.
+func TestHandleBulkProvisionAccounts_DryRun(t *testing.T) {
+	stores = newFullStores()
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", Name: "Alice", Email: "alice@test.com", Program: "adults", Status: memberDomain.StatusActive})
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m2", Name: "Bob", Email: "bob@test.com", Program: "adults", Status: memberDomain.StatusActive, AccountID: "acct-bob"})
+
+	rec := httptest.NewRecorder()
+	handleBulkProvisionAccounts(rec, authRequest("POST", "/api/admin/accounts/bulk-provision?dry_run=true", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result orchestrators.BulkProvisionResult
+	json.NewDecoder(rec.Body).Decode(&result)
+	if !result.DryRun || result.Created != 1 || len(result.Results) != 1 || result.Results[0].MemberID != "m1" {
+		t.Errorf("expected a dry run creating Alice's account only, got %+v", result)
+	}
+	if _, err := stores.AccountStore.GetByEmail(ctx, "alice@test.com"); err == nil {
+		t.Error("dry run must not create accounts")
+	}
+}
+
+// TestHandleBulkProvisionAccounts_Errors verifies method and admin checks.
+func TestHandleBulkProvisionAccounts_Errors(t *testing.T) {
+	stores = newFullStores()
+
+	rec := httptest.NewRecorder()
+	handleBulkProvisionAccounts(rec, authRequest("POST", "/api/admin/accounts/bulk-provision", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleBulkProvisionAccounts(rec, authRequest("GET", "/api/admin/accounts/bulk-provision", "", adminSession))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: expected 405, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,171 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+	backupDomain "workshop/internal/domain/backup"
+)
+
+// backupDeps configures database backups; nil until main wires up a target.
+var backupDeps *orchestrators.BackupDeps
+
+// backupInterval is how often the scheduled backup worker runs (shown on the admin page).
+var backupInterval time.Duration
+
+// SetBackups configures the target and schedule used by /admin/backups.
+func SetBackups(deps orchestrators.BackupDeps, interval time.Duration) {
+	backupDeps = &deps
+	backupInterval = interval
+}
+
+// backupView is the JSON shape of one stored backup.
+type backupView struct {
+	Name      string    `json:"name"`
+	Trigger   string    `json:"trigger"`
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size"`
+}
+
+func toBackupView(b backupDomain.Backup) backupView {
+	return backupView{Name: b.Name, Trigger: b.Trigger, CreatedAt: b.CreatedAt, Size: b.Size}
+}
+
+// handleAdminBackupsPage handles GET /admin/backups
+func handleAdminBackupsPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "backups") {
+		return
+	}
+	data := map[string]any{"Configured": backupDeps != nil}
+	if backupDeps != nil {
+		data["Target"] = backupDeps.Target.Describe()
+		data["Interval"] = backupInterval.String()
+		if job, ok := scheduledJob(r.Context(), "backups"); ok {
+			data["Schedule"] = job.Schedule
+		}
+		data["KeepLast"] = backupDeps.Retention.KeepLast
+		data["KeepDays"] = backupDeps.Retention.KeepDays
+	}
+	renderTemplate(w, r, "admin_backups.html", data)
+}
+
+// handleAdminBackups handles GET/POST /api/admin/backups
+// GET lists stored backups newest first; POST takes a backup now. Admin only.
+func handleAdminBackups(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "backups") {
+		return
+	}
+	if backupDeps == nil {
+		apierror.Unavailable(w, "backups are not configured")
+		return
+	}
+
+	if r.Method == "POST" {
+		result, err := orchestrators.ExecuteCreateBackup(r.Context(), orchestrators.CreateBackupInput{
+			Trigger:     backupDomain.TriggerManual,
+			RequestedBy: sess.AccountID,
+		}, *backupDeps)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{
+			"backup": toBackupView(result.Backup),
+			"pruned": result.Pruned,
+		})
+		return
+	}
+
+	list, err := backupDeps.Target.List(r.Context())
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	backupDomain.SortNewestFirst(list)
+	views := make([]backupView, 0, len(list))
+	for _, b := range list {
+		views = append(views, toBackupView(b))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(views)
+}
+
+// backupRestoreRequest is the body of POST /api/admin/backups/restore.
+type backupRestoreRequest struct {
+	Name    string `json:"Name"`
+	Confirm string `json:"Confirm"`
+}
+
+// handleAdminBackupRestore handles POST /api/admin/backups/restore
+// Replaces the live database with a stored backup. The caller must repeat the
+// backup name in Confirm; a pre_restore backup is taken first. Admin only.
+func handleAdminBackupRestore(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "backups") {
+		return
+	}
+	if backupDeps == nil {
+		apierror.Unavailable(w, "backups are not configured")
+		return
+	}
+	var input backupRestoreRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+
+	result, err := orchestrators.ExecuteRestoreBackup(r.Context(), orchestrators.RestoreBackupInput{
+		Name:        input.Name,
+		Confirm:     input.Confirm,
+		RequestedBy: sess.AccountID,
+	}, *backupDeps)
+	switch {
+	case errors.Is(err, backupDomain.ErrInvalidName), errors.Is(err, backupDomain.ErrConfirmMismatch):
+		apierror.Validation(w, err.Error())
+		return
+	case errors.Is(err, backupDomain.ErrNotFound):
+		apierror.NotFound(w, err.Error())
+		return
+	case errors.Is(err, backupDomain.ErrNewerSchema), errors.Is(err, backupDomain.ErrCorruptBackup):
+		apierror.Conflict(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"restored":       toBackupView(result.Restored),
+		"safety_backup":  toBackupView(result.SafetyBackup),
+		"schema_version": result.SchemaVersion,
+	})
+}
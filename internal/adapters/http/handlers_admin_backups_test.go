@@ -0,0 +1,103 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	backupAdapter "workshop/internal/adapters/backup"
+	"workshop/internal/application/orchestrators"
+	backupDomain "workshop/internal/domain/backup"
+)
+
+// fakeBackupDatabase writes a placeholder snapshot and records restores.
+type fakeBackupDatabase struct {
+	restored bool
+}
+
+// Snapshot writes an 8-byte placeholder.
+// PRE: none
+// POST: path exists
+func (f *fakeBackupDatabase) Snapshot(ctx context.Context, path string) error {
+	return os.WriteFile(path, []byte("snapshot"), 0o600)
+}
+
+// Inspect reports every backup as intact at schema 1.
+// PRE: none
+// POST: Never fails
+func (f *fakeBackupDatabase) Inspect(ctx context.Context, path string) (string, int, error) {
+	return "ok", 1, nil
+}
+
+// Restore records that a restore happened.
+// PRE: none
+// POST: restored is true
+func (f *fakeBackupDatabase) Restore(ctx context.Context, path string) error {
+	f.restored = true
+	return nil
+}
+
+// TestHandleAdminBackups verifies listing, manual backups and the restore confirmation guard.
+func TestHandleAdminBackups(t *testing.T) {
+	stores = newFullStores()
+
+	rec := httptest.NewRecorder()
+	handleAdminBackups(rec, authRequest("GET", "/api/admin/backups", "", adminSession))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("unconfigured: expected 503, got %d", rec.Code)
+	}
+
+	target, err := backupAdapter.NewDirTarget(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDirTarget: %v", err)
+	}
+	db := &fakeBackupDatabase{}
+	SetBackups(orchestrators.BackupDeps{
+		Database:     db,
+		Target:       target,
+		Retention:    backupDomain.DefaultRetention,
+		LatestSchema: 1,
+		TempDir:      t.TempDir(),
+		Now:          time.Now,
+	}, 24*time.Hour)
+	defer func() { backupDeps = nil }()
+
+	rec = httptest.NewRecorder()
+	handleAdminBackups(rec, authRequest("POST", "/api/admin/backups", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAdminBackups(rec, authRequest("POST", "/api/admin/backups", "", adminSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleAdminBackups(rec, authRequest("GET", "/api/admin/backups", "", adminSession))
+	var list []backupView
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(list) != 1 || list[0].Trigger != backupDomain.TriggerManual || list[0].Size != 8 {
+		t.Fatalf("unexpected backups: %+v", list)
+	}
+	name := list[0].Name
+
+	rec = httptest.NewRecorder()
+	handleAdminBackupRestore(rec, authRequest("POST", "/api/admin/backups/restore", `{"Name":"`+name+`","Confirm":"yes"}`, adminSession))
+	if rec.Code != http.StatusBadRequest || db.restored {
+		t.Errorf("unconfirmed restore: expected 400 and no restore, got %d (restored=%v)", rec.Code, db.restored)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAdminBackupRestore(rec, authRequest("POST", "/api/admin/backups/restore", `{"Name":"`+name+`","Confirm":"`+name+`"}`, adminSession))
+	if rec.Code != http.StatusOK || !db.restored {
+		t.Errorf("confirmed restore: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,41 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/config"
+)
+
+// handleAdminConfig handles GET /api/admin/config
+// Shows every server setting and where it came from, with secrets redacted. Admin only.
+func handleAdminConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "config") {
+		return
+	}
+
+	settings := appConfig.Settings()
+	if settings == nil {
+		settings = []config.Setting{}
+	}
+	warnings := appConfig.Warnings
+	if warnings == nil {
+		warnings = []string{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(map[string]any{
+		"Env":      appConfig.Env,
+		"Settings": settings,
+		"Warnings": warnings,
+	})
+}
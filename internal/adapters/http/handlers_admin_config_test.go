@@ -0,0 +1,59 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"workshop/internal/config"
+)
+
+// TestHandleAdminConfig verifies admins see redacted settings and others are refused.
+func TestHandleAdminConfig(t *testing.T) {
+	stores = newFullStores()
+	previous := appConfig
+	defer func() { appConfig = previous }()
+	loaded, err := config.Parse(func(key string) (string, bool) {
+		if key == "WORKSHOP_METRICS_TOKEN" {
+			return "super-secret-scrape-token", true
+		}
+		return "", false
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetConfig(loaded)
+
+	rec := httptest.NewRecorder()
+	handleAdminConfig(rec, authRequest("GET", "/api/admin/config", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAdminConfig(rec, authRequest("GET", "/api/admin/config", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("admin: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), "super-secret-scrape-token") {
+		t.Fatal("response leaks a secret")
+	}
+	var got struct {
+		Env      string
+		Settings []config.Setting
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	found := false
+	for _, s := range got.Settings {
+		if s.Key == "WORKSHOP_METRICS_TOKEN" {
+			found = s.Value == "(set)" && s.Secret
+		}
+	}
+	if got.Env != config.EnvDevelopment || !found {
+		t.Errorf("unexpected view: %+v", got)
+	}
+}
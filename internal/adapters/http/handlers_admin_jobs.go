@@ -0,0 +1,198 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+	jobDomain "workshop/internal/domain/job"
+)
+
+// jobScheduler runs background jobs on their stored schedules; nil until main wires it up.
+var jobScheduler *orchestrators.JobScheduler
+
+// SetJobScheduler configures the scheduler managed from /admin/jobs.
+func SetJobScheduler(s *orchestrators.JobScheduler) {
+	jobScheduler = s
+}
+
+// jobView is the JSON shape of one background job's schedule and last run.
+type jobView struct {
+	Name            string     `json:"name"`
+	Description     string     `json:"description"`
+	Schedule        string     `json:"schedule"`
+	DefaultSchedule string     `json:"default_schedule"`
+	Enabled         bool       `json:"enabled"`
+	Running         bool       `json:"running"`
+	NextRunAt       *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt       *time.Time `json:"last_run_at,omitempty"`
+	LastDurationMS  int64      `json:"last_duration_ms"`
+	LastStatus      string     `json:"last_status,omitempty"`
+	LastError       string     `json:"last_error,omitempty"`
+	UpdatedBy       string     `json:"updated_by,omitempty"`
+}
+
+// jobUpdateRequest is the body of PUT /api/admin/jobs.
+type jobUpdateRequest struct {
+	Name     string `json:"name"`
+	Schedule string `json:"schedule"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// jobRunRequest is the body of POST /api/admin/jobs/run.
+type jobRunRequest struct {
+	Name string `json:"name"`
+}
+
+func toJobView(s orchestrators.JobStatus) jobView {
+	v := jobView{
+		Name:            s.Name,
+		Description:     s.Description,
+		Schedule:        s.Schedule,
+		DefaultSchedule: s.DefaultSchedule,
+		Enabled:         s.Enabled,
+		Running:         s.Running,
+		LastDurationMS:  s.LastDuration.Milliseconds(),
+		LastStatus:      s.LastStatus,
+		LastError:       s.LastError,
+		UpdatedBy:       s.UpdatedBy,
+	}
+	if !s.NextRunAt.IsZero() {
+		v.NextRunAt = &s.NextRunAt
+	}
+	if !s.LastRunAt.IsZero() {
+		v.LastRunAt = &s.LastRunAt
+	}
+	return v
+}
+
+// scheduledJob returns the named job's status, or false when the scheduler is not running it.
+func scheduledJob(ctx context.Context, name string) (orchestrators.JobStatus, bool) {
+	if jobScheduler == nil {
+		return orchestrators.JobStatus{}, false
+	}
+	list, err := jobScheduler.List(ctx)
+	if err != nil {
+		return orchestrators.JobStatus{}, false
+	}
+	for _, s := range list {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return orchestrators.JobStatus{}, false
+}
+
+// handleAdminJobsPage handles GET /admin/jobs
+func handleAdminJobsPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "jobs") {
+		return
+	}
+	renderTemplate(w, r, "admin_jobs.html", nil)
+}
+
+// handleAdminJobs handles GET/PUT /api/admin/jobs
+// GET lists background jobs with their schedules and last runs; PUT changes a job's cron
+// schedule or turns it on or off. Admin only.
+func handleAdminJobs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "PUT" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "jobs") {
+		return
+	}
+
+	if r.Method == "GET" {
+		views := []jobView{}
+		if jobScheduler != nil {
+			list, err := jobScheduler.List(r.Context())
+			if err != nil {
+				internalError(w, err)
+				return
+			}
+			for _, s := range list {
+				views = append(views, toJobView(s))
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(views)
+		return
+	}
+
+	var input jobUpdateRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if jobScheduler == nil {
+		apierror.NotFound(w, jobDomain.ErrNotFound.Error())
+		return
+	}
+	status, err := jobScheduler.Update(r.Context(), input.Name, input.Schedule, input.Enabled, sess.AccountID)
+	switch {
+	case errors.Is(err, jobDomain.ErrNotFound):
+		apierror.NotFound(w, err.Error())
+		return
+	case errors.Is(err, jobDomain.ErrInvalidSchedule):
+		apierror.Validation(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toJobView(status))
+}
+
+// handleAdminJobRun handles POST /api/admin/jobs/run
+// Starts a job now, outside its schedule; refused while a run is in progress. Admin only.
+func handleAdminJobRun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "jobs") {
+		return
+	}
+	var input jobRunRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if jobScheduler == nil {
+		apierror.NotFound(w, jobDomain.ErrNotFound.Error())
+		return
+	}
+	err := jobScheduler.RunNow(r.Context(), input.Name, sess.AccountID)
+	switch {
+	case errors.Is(err, jobDomain.ErrNotFound):
+		apierror.NotFound(w, err.Error())
+	case errors.Is(err, jobDomain.ErrAlreadyRunning):
+		apierror.Conflict(w, err.Error())
+	case err != nil:
+		internalError(w, err)
+	default:
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
@@ -0,0 +1,171 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"workshop/internal/application/orchestrators"
+	jobDomain "workshop/internal/domain/job"
+)
+
+type mockJobStore struct {
+	mu   sync.Mutex
+	jobs map[string]jobDomain.Job
+}
+
+// GetByName implements job.Store for testing.
+// PRE: name is non-empty
+// POST: Returns the job or an error wrapping sql.ErrNoRows
+func (m *mockJobStore) GetByName(_ context.Context, name string) (jobDomain.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[name]
+	if !ok {
+		return jobDomain.Job{}, fmt.Errorf("job not found: %w", sql.ErrNoRows)
+	}
+	return j, nil
+}
+
+// List implements job.Store for testing.
+// PRE: none
+// POST: Returns every job ordered by name
+func (m *mockJobStore) List(_ context.Context) ([]jobDomain.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []jobDomain.Job
+	for _, j := range m.jobs {
+		out = append(out, j)
+	}
+	sort.Slice(out, func(i, k int) bool { return out[i].Name < out[k].Name })
+	return out, nil
+}
+
+// Save implements job.Store for testing.
+// PRE: value has been validated
+// POST: The job is stored
+func (m *mockJobStore) Save(_ context.Context, value jobDomain.Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[value.Name] = value
+	return nil
+}
+
+// Claim implements job.Store for testing.
+// PRE: none
+// POST: Takes the lease unless one is held
+func (m *mockJobStore) Claim(_ context.Context, name string, now, leaseUntil time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[name]
+	if !ok || j.IsRunning(now) {
+		return false, nil
+	}
+	j.RunningUntil, j.LastRunAt = leaseUntil, now
+	m.jobs[name] = j
+	return true, nil
+}
+
+// RecordRun implements job.Store for testing.
+// PRE: the run was claimed
+// POST: Stores the outcome and releases the lease
+func (m *mockJobStore) RecordRun(_ context.Context, value jobDomain.Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j := m.jobs[value.Name]
+	j.LastDuration, j.LastStatus, j.LastError, j.NextRunAt, j.RunningUntil =
+		value.LastDuration, value.LastStatus, value.LastError, value.NextRunAt, time.Time{}
+	m.jobs[value.Name] = j
+	return nil
+}
+
+// setupJobScheduler wires a scheduler with one "tidy" job that blocks until release closes.
+func setupJobScheduler(t *testing.T) (release chan struct{}, monitor *orchestrators.WorkerMonitor) {
+	t.Helper()
+	stores = newFullStores()
+	monitor = orchestrators.NewWorkerMonitor(time.Now)
+	s := orchestrators.NewJobScheduler(stores.JobStore, monitor, time.Now)
+	release = make(chan struct{})
+	s.Register(orchestrators.JobDefinition{Name: "tidy", Description: "Tidies up", DefaultSchedule: "@hourly", Timeout: time.Minute, Run: func(context.Context) error {
+		<-release
+		return nil
+	}})
+	if err := s.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	SetJobScheduler(s)
+	t.Cleanup(func() { SetJobScheduler(nil) })
+	return release, monitor
+}
+
+// TestHandleAdminJobs verifies admins list jobs and change their schedule, bad schedules
+// are rejected, and other roles are refused.
+func TestHandleAdminJobs(t *testing.T) {
+	setupJobScheduler(t)
+	do := func(method, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handleAdminJobs(rec, authRequest(method, "/api/admin/jobs", body, adminSession))
+		return rec
+	}
+
+	rec := httptest.NewRecorder()
+	handleAdminJobs(rec, authRequest("GET", "/api/admin/jobs", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+
+	var list []jobView
+	json.NewDecoder(do("GET", "").Body).Decode(&list)
+	if len(list) != 1 || list[0].Schedule != "@hourly" || !list[0].Enabled || list[0].NextRunAt == nil {
+		t.Fatalf("jobs = %+v, want tidy on its default schedule", list)
+	}
+
+	if rec := do("PUT", `{"name":"tidy","schedule":"every tuesday","enabled":true}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("bad schedule: expected 400, got %d", rec.Code)
+	}
+	if rec := do("PUT", `{"name":"gone","schedule":"@daily","enabled":true}`); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown job: expected 404, got %d", rec.Code)
+	}
+	rec = do("PUT", `{"name":"tidy","schedule":"0 3 * * *","enabled":false}`)
+	var updated jobView
+	json.NewDecoder(rec.Body).Decode(&updated)
+	if rec.Code != http.StatusOK || updated.Schedule != "0 3 * * *" || updated.Enabled || updated.NextRunAt != nil || updated.UpdatedBy != adminSession.AccountID {
+		t.Errorf("update: %d %+v, want paused on the new schedule", rec.Code, updated)
+	}
+}
+
+// TestHandleAdminJobRun verifies a job can be started now, and not again while it runs.
+func TestHandleAdminJobRun(t *testing.T) {
+	release, monitor := setupJobScheduler(t)
+	run := func(body string) int {
+		rec := httptest.NewRecorder()
+		handleAdminJobRun(rec, authRequest("POST", "/api/admin/jobs/run", body, adminSession))
+		return rec.Code
+	}
+
+	if code := run(`{"name":"tidy"}`); code != http.StatusAccepted {
+		t.Fatalf("run: expected 202, got %d", code)
+	}
+	if code := run(`{"name":"tidy"}`); code != http.StatusConflict {
+		t.Errorf("already running: expected 409, got %d", code)
+	}
+	if code := run(`{"name":"gone"}`); code != http.StatusNotFound {
+		t.Errorf("unknown job: expected 404, got %d", code)
+	}
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := monitor.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if j, _ := stores.JobStore.GetByName(context.Background(), "tidy"); j.LastStatus != jobDomain.StatusOK {
+		t.Errorf("last status = %q, want ok", j.LastStatus)
+	}
+}
@@ -72,7 +72,7 @@ func handleAdminOutbox(w http.ResponseWriter, r *http.Request) {
 		entryID := parts[2]
 		action := parts[3]
 
-		processor := orchestrators.NewOutboxProcessor(stores.OutboxStore, nil)
+		processor := orchestrators.NewOutboxProcessor(stores.OutboxStore, OutboxExecutors(stores, emailLinkBaseURL(r)))
 
 		switch action {
 		case "retry":
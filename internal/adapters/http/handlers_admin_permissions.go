@@ -0,0 +1,186 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// permissionMatrix returns the defaults merged with any admin overrides.
+// A store error falls back to the defaults, which match the old hardcoded checks.
+func permissionMatrix(ctx context.Context) map[string]permissionDomain.Permission {
+	var overrides []permissionDomain.Permission
+	if stores != nil && stores.PermissionStore != nil {
+		list, err := stores.PermissionStore.List(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "permission_overrides_unavailable", "error", err)
+		} else {
+			overrides = list
+		}
+	}
+	m := make(map[string]permissionDomain.Permission)
+	for _, p := range permissionDomain.Merge(overrides) {
+		m[p.Action] = p
+	}
+	return m
+}
+
+// permissionAllowed reports whether the session's role may perform action.
+// Unknown actions are denied.
+func permissionAllowed(ctx context.Context, sess middleware.Session, action string) bool {
+	p, ok := permissionMatrix(ctx)[action]
+	if !ok {
+		slog.ErrorContext(ctx, "permission_unknown_action", "action", action)
+		return false
+	}
+	return p.Allows(sess.Role)
+}
+
+// requirePermission checks the session may perform action, writing 401/403 if not.
+// It is the permission-matrix counterpart of requireAdmin for API handlers.
+func requirePermission(w http.ResponseWriter, r *http.Request, action string) (middleware.Session, bool) {
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		slog.WarnContext(r.Context(), "auth_denied", "path", r.URL.Path, "reason", "no session")
+		apierror.Unauthorized(w, "not authenticated")
+		return middleware.Session{}, false
+	}
+	if !permissionAllowed(r.Context(), sess, action) {
+		slog.WarnContext(r.Context(), "auth_denied", "path", r.URL.Path, "account_id", sess.AccountID, "role", sess.Role, "required", action)
+		apierror.Forbidden(w, "Forbidden")
+		return middleware.Session{}, false
+	}
+	return sess, true
+}
+
+// requirePermissionPage is requirePermission for HTML pages: it redirects to
+// /login without a session and to /dashboard when the role is not allowed.
+func requirePermissionPage(w http.ResponseWriter, r *http.Request, action string) (middleware.Session, bool) {
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return middleware.Session{}, false
+	}
+	if !permissionAllowed(r.Context(), sess, action) {
+		http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+		return middleware.Session{}, false
+	}
+	return sess, true
+}
+
+// handleAdminPermissionsPage handles GET /admin/permissions
+func handleAdminPermissionsPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "permissions") {
+		return
+	}
+	renderTemplate(w, r, "admin_permissions.html", map[string]any{
+		"Title": "Permissions",
+	})
+}
+
+// permissionsUpdateRequest is the body of POST /api/admin/permissions.
+type permissionsUpdateRequest struct {
+	Permissions []struct {
+		Action      string `json:"Action"`
+		AllowCoach  bool   `json:"AllowCoach"`
+		AllowMember bool   `json:"AllowMember"`
+		AllowTrial  bool   `json:"AllowTrial"`
+	} `json:"Permissions"`
+}
+
+// handleAdminPermissions handles GET/POST /api/admin/permissions
+// GET returns the merged matrix. POST saves {"Permissions": [...]}; an entry that
+// matches its default removes the override instead of storing it.
+func handleAdminPermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "permissions") {
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(permissionDomain.Merge(listPermissionOverrides(ctx)))
+
+	case "POST":
+		var input permissionsUpdateRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		if stores.PermissionStore == nil {
+			apierror.Unavailable(w, "permissions are not configurable")
+			return
+		}
+		changes := make([]permissionDomain.Permission, 0, len(input.Permissions))
+		for _, dto := range input.Permissions {
+			p := permissionDomain.Permission{
+				Action:      dto.Action,
+				AllowCoach:  dto.AllowCoach,
+				AllowMember: dto.AllowMember,
+				AllowTrial:  dto.AllowTrial,
+				UpdatedBy:   sess.AccountID,
+				UpdatedAt:   timeNow(),
+			}
+			if err := p.Validate(); err != nil {
+				apierror.Validation(w, err.Error()+": "+dto.Action)
+				return
+			}
+			changes = append(changes, p)
+		}
+		for _, p := range changes {
+			var err error
+			if p.IsDefault() {
+				err = stores.PermissionStore.Delete(ctx, p.Action)
+			} else {
+				err = stores.PermissionStore.Save(ctx, p)
+			}
+			if err != nil {
+				internalError(w, err)
+				return
+			}
+		}
+
+		slog.InfoContext(ctx, "audit_event",
+			"actor_id", sess.AccountID,
+			"actor_role", sess.Role,
+			"action", "admin.permissions.save",
+			"count", len(changes),
+		)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(permissionDomain.Merge(listPermissionOverrides(ctx)))
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// listPermissionOverrides returns the stored overrides, or none if there is no store.
+func listPermissionOverrides(ctx context.Context) []permissionDomain.Permission {
+	if stores.PermissionStore == nil {
+		return nil
+	}
+	list, err := stores.PermissionStore.List(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "permission_overrides_unavailable", "error", err)
+		return nil
+	}
+	return list
+}
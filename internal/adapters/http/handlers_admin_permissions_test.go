@@ -0,0 +1,143 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/adapters/http/middleware"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// --- Mock Permission store ---
+
+type mockPermissionStore struct {
+	overrides map[string]permissionDomain.Permission
+}
+
+// List implements permission.Store for testing.
+// PRE: none
+// POST: Returns all stored overrides
+func (m *mockPermissionStore) List(_ context.Context) ([]permissionDomain.Permission, error) {
+	var list []permissionDomain.Permission
+	for _, p := range m.overrides {
+		list = append(list, p)
+	}
+	return list, nil
+}
+
+// Save implements permission.Store for testing.
+// PRE: p has been validated
+// POST: Override for p.Action is upserted
+func (m *mockPermissionStore) Save(_ context.Context, p permissionDomain.Permission) error {
+	m.overrides[p.Action] = p
+	return nil
+}
+
+// Delete implements permission.Store for testing.
+// PRE: action is non-empty
+// POST: Override for action is removed if present
+func (m *mockPermissionStore) Delete(_ context.Context, action string) error {
+	delete(m.overrides, action)
+	return nil
+}
+
+// TestRequirePermission_DefaultsAndOverrides verifies defaults apply, overrides win, and admins are never denied.
+func TestRequirePermission_DefaultsAndOverrides(t *testing.T) {
+	stores = newFullStores()
+	stores.PermissionStore = &mockPermissionStore{overrides: map[string]permissionDomain.Permission{
+		permissionDomain.ActionMembersExport: {Action: permissionDomain.ActionMembersExport},
+		permissionDomain.ActionLibraryEdit:   {Action: permissionDomain.ActionLibraryEdit, AllowCoach: true, AllowMember: true},
+	}}
+
+	tests := []struct {
+		name   string
+		sess   middleware.Session
+		action string
+		want   int
+	}{
+		{"coach default allowed", coachSession, permissionDomain.ActionMembersView, http.StatusOK},
+		{"member default denied", memberSession, permissionDomain.ActionMembersView, http.StatusForbidden},
+		{"coach revoked by override", coachSession, permissionDomain.ActionMembersExport, http.StatusForbidden},
+		{"admin ignores override", adminSession, permissionDomain.ActionMembersExport, http.StatusOK},
+		{"member granted by override", memberSession, permissionDomain.ActionLibraryEdit, http.StatusOK},
+		{"unknown action denied", coachSession, "nope.nothing", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			requirePermission(rec, authRequest("GET", "/api/test", "", tt.sess), tt.action)
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+}
+
+// TestHandleMembersExportCSV_CoachRevoked verifies a converted handler honours an override.
+func TestHandleMembersExportCSV_CoachRevoked(t *testing.T) {
+	stores = newFullStores()
+	stores.PermissionStore = &mockPermissionStore{overrides: map[string]permissionDomain.Permission{
+		permissionDomain.ActionMembersExport: {Action: permissionDomain.ActionMembersExport},
+	}}
+
+	rec := httptest.NewRecorder()
+	handleMembersExportCSV(rec, authRequest("GET", "/api/members/export", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleAdminPermissions_SaveAndReset verifies POST stores overrides and drops ones that match the default.
+func TestHandleAdminPermissions_SaveAndReset(t *testing.T) {
+	stores = newFullStores()
+	perms := &mockPermissionStore{overrides: map[string]permissionDomain.Permission{
+		permissionDomain.ActionLibraryView: {Action: permissionDomain.ActionLibraryView, AllowCoach: true},
+	}}
+	stores.PermissionStore = perms
+
+	body := `{"Permissions":[
+		{"Action":"members.export","AllowCoach":false},
+		{"Action":"library.view","AllowCoach":true,"AllowMember":true}
+	]}`
+	rec := httptest.NewRecorder()
+	handleAdminPermissions(rec, authRequest("POST", "/api/admin/permissions", body, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	saved, ok := perms.overrides[permissionDomain.ActionMembersExport]
+	if !ok || saved.AllowCoach || saved.UpdatedBy != adminSession.AccountID {
+		t.Errorf("expected members.export override by %s, got %+v", adminSession.AccountID, saved)
+	}
+	if _, ok := perms.overrides[permissionDomain.ActionLibraryView]; ok {
+		t.Error("expected library.view override to be removed once it matches the default")
+	}
+
+	var matrix []permissionDomain.Permission
+	if err := json.NewDecoder(rec.Body).Decode(&matrix); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(matrix) != len(permissionDomain.Defaults()) {
+		t.Errorf("expected %d permissions, got %d", len(permissionDomain.Defaults()), len(matrix))
+	}
+}
+
+// TestHandleAdminPermissions_Rejects verifies non-admins and unknown actions are refused.
+func TestHandleAdminPermissions_Rejects(t *testing.T) {
+	stores = newFullStores()
+	stores.PermissionStore = &mockPermissionStore{overrides: map[string]permissionDomain.Permission{}}
+
+	rec := httptest.NewRecorder()
+	handleAdminPermissions(rec, authRequest("GET", "/api/admin/permissions", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAdminPermissions(rec, authRequest("POST", "/api/admin/permissions", `{"Permissions":[{"Action":"accounts.delete","AllowCoach":true}]}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown action: expected 400, got %d", rec.Code)
+	}
+}
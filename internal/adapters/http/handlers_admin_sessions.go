@@ -0,0 +1,145 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/domain/authsession"
+)
+
+// sessionView is the JSON shape of one signed-in session.
+type sessionView struct {
+	ID         string    `json:"id"`
+	AccountID  string    `json:"account_id"`
+	Email      string    `json:"email"`
+	Role       string    `json:"role"`
+	RealEmail  string    `json:"real_email,omitempty"` // the admin behind an impersonation
+	LocationID string    `json:"location_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"` // the caller's own session
+}
+
+// handleAdminSessionsPage handles GET /admin/sessions
+func handleAdminSessionsPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "sessions") {
+		return
+	}
+	renderTemplate(w, r, "admin_sessions.html", map[string]any{
+		"IdleTimeout": authsession.IdleTimeout.String(),
+		"MaxLifetime": authsession.MaxLifetime.String(),
+	})
+}
+
+// handleAdminSessions handles GET /api/admin/sessions
+// Lists every signed-in session, most recently used first. Admin only.
+func handleAdminSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "sessions") {
+		return
+	}
+
+	list, err := sessions.List(r.Context())
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	views := make([]sessionView, 0, len(list))
+	for _, s := range list {
+		views = append(views, toSessionView(s, sess.ID))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(views)
+}
+
+// sessionRevokeRequest is the body of POST /api/admin/sessions/revoke; set exactly one field.
+type sessionRevokeRequest struct {
+	ID        string `json:"ID"`
+	AccountID string `json:"AccountID"`
+}
+
+// handleAdminSessionRevoke handles POST /api/admin/sessions/revoke
+// Signs out one session (ID) or every session of an account (AccountID). Admin only.
+func handleAdminSessionRevoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "sessions") {
+		return
+	}
+	var input sessionRevokeRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if (input.ID == "") == (input.AccountID == "") {
+		apierror.Validation(w, "exactly one of ID or AccountID is required")
+		return
+	}
+
+	revoked := 1
+	if input.ID != "" {
+		err := sessions.Revoke(r.Context(), input.ID)
+		if errors.Is(err, authsession.ErrNotFound) {
+			apierror.NotFound(w, "session not found")
+			return
+		}
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+	} else {
+		n, err := sessions.RevokeAccount(r.Context(), input.AccountID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		revoked = n
+	}
+
+	slog.InfoContext(r.Context(), "security_event", "event", "session_revoked",
+		"admin_account_id", sess.AccountID, "session_id", input.ID, "account_id", input.AccountID, "sessions", revoked)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{"revoked": revoked})
+}
+
+func toSessionView(s middleware.Session, currentID string) sessionView {
+	return sessionView{
+		ID:         s.ID,
+		AccountID:  s.AccountID,
+		Email:      s.Email,
+		Role:       s.Role,
+		RealEmail:  s.RealEmail,
+		LocationID: s.LocationID,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		ExpiresAt:  s.ExpiresAt,
+		Current:    s.ID != "" && s.ID == currentID,
+	}
+}
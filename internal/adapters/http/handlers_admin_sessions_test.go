@@ -0,0 +1,281 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	authsessionDomain "workshop/internal/domain/authsession"
+)
+
+// --- Mock session store ---
+
+type mockAuthSessionStore struct {
+	sessions map[string]authsessionDomain.Session
+}
+
+func newMockAuthSessionStore() *mockAuthSessionStore {
+	return &mockAuthSessionStore{sessions: make(map[string]authsessionDomain.Session)}
+}
+
+// GetByID implements authsession.Store for testing.
+// PRE: id is non-empty
+// POST: Returns the session or ErrNotFound
+func (m *mockAuthSessionStore) GetByID(_ context.Context, id string) (authsessionDomain.Session, error) {
+	s, ok := m.sessions[id]
+	if !ok {
+		return authsessionDomain.Session{}, authsessionDomain.ErrNotFound
+	}
+	return s, nil
+}
+
+// GetByTokenHash implements authsession.Store for testing.
+// PRE: tokenHash is non-empty
+// POST: Returns the session or ErrNotFound
+func (m *mockAuthSessionStore) GetByTokenHash(_ context.Context, tokenHash string) (authsessionDomain.Session, error) {
+	for _, s := range m.sessions {
+		if s.TokenHash == tokenHash {
+			return s, nil
+		}
+	}
+	return authsessionDomain.Session{}, authsessionDomain.ErrNotFound
+}
+
+// Save implements authsession.Store for testing.
+// PRE: value has been validated
+// POST: Session is upserted
+func (m *mockAuthSessionStore) Save(_ context.Context, value authsessionDomain.Session) error {
+	m.sessions[value.ID] = value
+	return nil
+}
+
+// Delete implements authsession.Store for testing.
+// PRE: id is non-empty
+// POST: Session is removed
+func (m *mockAuthSessionStore) Delete(_ context.Context, id string) error {
+	delete(m.sessions, id)
+	return nil
+}
+
+// DeleteByAccountID implements authsession.Store for testing.
+// PRE: accountID is non-empty
+// POST: Sessions of the account (directly or as impersonator) are removed
+func (m *mockAuthSessionStore) DeleteByAccountID(_ context.Context, accountID string) (int, error) {
+	n := 0
+	for id, s := range m.sessions {
+		if s.AccountID == accountID || s.RealAccountID == accountID {
+			delete(m.sessions, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// DeleteExpired implements authsession.Store for testing.
+// PRE: none
+// POST: Expired sessions are removed
+func (m *mockAuthSessionStore) DeleteExpired(_ context.Context, now time.Time) (int, error) {
+	n := 0
+	for id, s := range m.sessions {
+		if s.Expired(now) {
+			delete(m.sessions, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// ListActive implements authsession.Store for testing.
+// PRE: none
+// POST: Returns unexpired sessions, most recently used first
+func (m *mockAuthSessionStore) ListActive(_ context.Context, now time.Time) ([]authsessionDomain.Session, error) {
+	var list []authsessionDomain.Session
+	for _, s := range m.sessions {
+		if !s.Expired(now) {
+			list = append(list, s)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].LastSeenAt.After(list[j].LastSeenAt) })
+	return list, nil
+}
+
+// seedSessions signs in one admin and two member devices, returning the tokens.
+func seedSessions(t *testing.T) (store *mockAuthSessionStore, adminToken string, memberTokens []string) {
+	t.Helper()
+	stores = newFullStores()
+	store = newMockAuthSessionStore()
+	stores.AuthSessionStore = store
+	sessions = middleware.NewSessionStore(store)
+	ctx := context.Background()
+
+	var err error
+	adminToken, err = sessions.Create(ctx, adminSession.AccountID, adminSession.Email, "admin", false, false)
+	if err != nil {
+		t.Fatalf("create admin session: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		token, err := sessions.Create(ctx, memberSession.AccountID, memberSession.Email, "member", false, false)
+		if err != nil {
+			t.Fatalf("create member session: %v", err)
+		}
+		memberTokens = append(memberTokens, token)
+	}
+	return store, adminToken, memberTokens
+}
+
+// listSessions calls GET /api/admin/sessions as the signed-in admin.
+func listSessions(t *testing.T, adminToken string) []sessionView {
+	t.Helper()
+	current, ok := sessions.Get(context.Background(), adminToken)
+	if !ok {
+		t.Fatal("admin session missing")
+	}
+	rec := httptest.NewRecorder()
+	handleAdminSessions(rec, authRequest("GET", "/api/admin/sessions", "", current))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var views []sessionView
+	if err := json.NewDecoder(rec.Body).Decode(&views); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return views
+}
+
+// TestHandleAdminSessions_ListAndRevoke verifies admins can list sessions and sign out one or all of an account's.
+func TestHandleAdminSessions_ListAndRevoke(t *testing.T) {
+	_, adminToken, memberTokens := seedSessions(t)
+
+	views := listSessions(t, adminToken)
+	if len(views) != 3 {
+		t.Fatalf("expected 3 sessions, got %d", len(views))
+	}
+	var memberID string
+	currentCount := 0
+	for _, v := range views {
+		if v.Current {
+			currentCount++
+			if v.AccountID != adminSession.AccountID {
+				t.Errorf("current session belongs to %s", v.AccountID)
+			}
+		}
+		if v.AccountID == memberSession.AccountID {
+			memberID = v.ID
+		}
+	}
+	if currentCount != 1 {
+		t.Errorf("expected exactly one current session, got %d", currentCount)
+	}
+
+	rec := httptest.NewRecorder()
+	handleAdminSessionRevoke(rec, authRequest("POST", "/api/admin/sessions/revoke", fmt.Sprintf(`{"ID":%q}`, memberID), adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("revoke by ID: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := len(listSessions(t, adminToken)); got != 2 {
+		t.Errorf("after revoking one session: expected 2, got %d", got)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAdminSessionRevoke(rec, authRequest("POST", "/api/admin/sessions/revoke", fmt.Sprintf(`{"AccountID":%q}`, memberSession.AccountID), adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("revoke by account: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result map[string]int
+	json.NewDecoder(rec.Body).Decode(&result)
+	if result["revoked"] != 1 {
+		t.Errorf("expected 1 remaining member session revoked, got %d", result["revoked"])
+	}
+	for _, token := range memberTokens {
+		if _, ok := sessions.Get(context.Background(), token); ok {
+			t.Error("revoked member token still resolves")
+		}
+	}
+	if _, ok := sessions.Get(context.Background(), adminToken); !ok {
+		t.Error("admin session was revoked along with the member's")
+	}
+}
+
+// TestHandleAdminSessionRevoke_Validation verifies input errors and access control
+// come back as structured API errors.
+func TestHandleAdminSessionRevoke_Validation(t *testing.T) {
+	seedSessions(t)
+	tests := []struct {
+		name string
+		body string
+		sess middleware.Session
+		want int
+		code apierror.Code
+	}{
+		{name: "neither ID nor AccountID", body: `{}`, sess: adminSession, want: http.StatusBadRequest, code: apierror.CodeValidation},
+		{name: "both ID and AccountID", body: `{"ID":"x","AccountID":"y"}`, sess: adminSession, want: http.StatusBadRequest, code: apierror.CodeValidation},
+		{name: "unknown session", body: `{"ID":"missing"}`, sess: adminSession, want: http.StatusNotFound, code: apierror.CodeNotFound},
+		{name: "non-admin", body: `{"AccountID":"admin-001"}`, sess: memberSession, want: http.StatusForbidden, code: apierror.CodeForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleAdminSessionRevoke(rec, authRequest("POST", "/api/admin/sessions/revoke", tt.body, tt.sess))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+			var resp apierror.Response
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode error body: %v", err)
+			}
+			if resp.Error.Code != tt.code || resp.Error.Message == "" {
+				t.Errorf("expected code %q with a message, got %+v", tt.code, resp.Error)
+			}
+		})
+	}
+}
+
+// TestHandleLogoutAll verifies a user can sign out every device without touching other accounts.
+func TestHandleLogoutAll(t *testing.T) {
+	_, adminToken, memberTokens := seedSessions(t)
+
+	req := authRequest("POST", "/logout/all", "", memberSession)
+	req.AddCookie(&http.Cookie{Name: "workshop_session", Value: memberTokens[0]})
+	rec := httptest.NewRecorder()
+	handleLogoutAll(rec, req)
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/login" {
+		t.Fatalf("expected redirect to /login, got %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+	for _, token := range memberTokens {
+		if _, ok := sessions.Get(context.Background(), token); ok {
+			t.Error("member device still signed in")
+		}
+	}
+	if _, ok := sessions.Get(context.Background(), adminToken); !ok {
+		t.Error("another account was signed out")
+	}
+}
+
+// TestSessions_ImpersonationSurvivesRestart verifies session state is read back from the store.
+func TestSessions_ImpersonationSurvivesRestart(t *testing.T) {
+	store, adminToken, _ := seedSessions(t)
+	ctx := context.Background()
+
+	sess, _ := sessions.Get(ctx, adminToken)
+	sess.RealAccountID, sess.RealEmail, sess.RealRole = sess.AccountID, sess.Email, "admin"
+	sess.Role = "coach"
+	if !sessions.Update(ctx, adminToken, sess) {
+		t.Fatal("update failed")
+	}
+
+	sessions = middleware.NewSessionStore(store) // a restarted server sees only the store
+	restored, ok := sessions.Get(ctx, adminToken)
+	if !ok {
+		t.Fatal("session lost across restart")
+	}
+	if restored.Role != "coach" || restored.RealRole != "admin" || !restored.IsImpersonating() {
+		t.Errorf("impersonation not preserved: role=%q realRole=%q", restored.Role, restored.RealRole)
+	}
+}
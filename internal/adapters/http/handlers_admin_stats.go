@@ -0,0 +1,54 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/projections"
+	"workshop/internal/domain/kpi"
+)
+
+// adminStatsView is the response of GET /api/admin/stats.
+type adminStatsView struct {
+	Current kpi.Snapshot                `json:"Current"` // live figures for today
+	Trends  []projections.KPITrendPoint `json:"Trends"`  // one point per week, oldest first
+}
+
+// handleAdminStats handles GET /api/admin/stats
+// Returns today's KPIs computed live and weekly trends from the daily snapshots. Admin only.
+func handleAdminStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "dashboard_stats") {
+		return
+	}
+	ctx := r.Context()
+	today := timeNow().Format("2006-01-02")
+	deps := projections.GetAdminStatsDeps{
+		MemberStore:     stores.MemberStore,
+		AttendanceStore: stores.AttendanceStore,
+		SnapshotStore:   stores.KPISnapshotStore,
+		Now:             timeNow,
+	}
+
+	current, err := projections.QueryGetAdminStats(ctx, projections.GetAdminStatsQuery{Date: today}, deps)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	trends, err := projections.QueryGetKPITrends(ctx, projections.GetKPITrendsQuery{Date: today}, deps)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(adminStatsView{Current: current, Trends: trends.Weeks})
+}
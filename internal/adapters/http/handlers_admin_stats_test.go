@@ -0,0 +1,80 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/domain/kpi"
+	memberDomain "workshop/internal/domain/member"
+)
+
+type mockKPISnapshotStore struct {
+	snapshots []kpi.Snapshot // oldest first
+}
+
+// Save implements kpi.Store for testing.
+// PRE: value has been validated
+// POST: the snapshot is appended
+func (m *mockKPISnapshotStore) Save(_ context.Context, value kpi.Snapshot) error {
+	m.snapshots = append(m.snapshots, value)
+	return nil
+}
+
+// GetLatestBefore implements kpi.Store for testing.
+// PRE: date is YYYY-MM-DD
+// POST: returns the newest snapshot before date or sql.ErrNoRows
+func (m *mockKPISnapshotStore) GetLatestBefore(_ context.Context, date string) (kpi.Snapshot, error) {
+	for i := len(m.snapshots) - 1; i >= 0; i-- {
+		if m.snapshots[i].Date < date {
+			return m.snapshots[i], nil
+		}
+	}
+	return kpi.Snapshot{}, sql.ErrNoRows
+}
+
+// ListByDateRange implements kpi.Store for testing.
+// PRE: dates are YYYY-MM-DD
+// POST: returns snapshots within the inclusive range
+func (m *mockKPISnapshotStore) ListByDateRange(_ context.Context, startDate, endDate string) ([]kpi.Snapshot, error) {
+	var out []kpi.Snapshot
+	for _, s := range m.snapshots {
+		if s.Date >= startDate && s.Date <= endDate {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// TestHandleAdminStats verifies admins get live KPIs and a 12-week trend.
+func TestHandleAdminStats(t *testing.T) {
+	stores = newFullStores()
+	yesterday := timeNow().AddDate(0, 0, -1).Format("2006-01-02")
+	stores.KPISnapshotStore = &mockKPISnapshotStore{snapshots: []kpi.Snapshot{{Date: yesterday, TotalMembers: 1, ActiveMembers: 1}}}
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", Name: "Alice", Email: "alice@test.com", Program: "adults", Status: memberDomain.StatusActive, Fee: 150})
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m2", Name: "Bob", Email: "bob@test.com", Program: "adults", Status: memberDomain.StatusActive, Fee: 100})
+
+	rec := httptest.NewRecorder()
+	handleAdminStats(rec, authRequest("GET", "/api/admin/stats", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var view adminStatsView
+	json.NewDecoder(rec.Body).Decode(&view)
+	if view.Current.ActiveMembers != 2 || view.Current.NewMembers != 1 || view.Current.MonthlyRevenue != 250 {
+		t.Errorf("current = %+v, want 2 active, 1 new and $250 a month", view.Current)
+	}
+	if len(view.Trends) != kpi.TrendWeeks {
+		t.Errorf("expected %d trend weeks, got %d", kpi.TrendWeeks, len(view.Trends))
+	}
+
+	rec = httptest.NewRecorder()
+	handleAdminStats(rec, authRequest("GET", "/api/admin/stats", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+}
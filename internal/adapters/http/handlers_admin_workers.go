@@ -0,0 +1,77 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+)
+
+// workerMonitor tracks background worker health; nil until main wires it up.
+var workerMonitor *orchestrators.WorkerMonitor
+
+// SetWorkerMonitor configures the monitor reported by GET /api/admin/workers.
+func SetWorkerMonitor(m *orchestrators.WorkerMonitor) {
+	workerMonitor = m
+}
+
+// workerStatusView is the JSON shape of one worker's health.
+type workerStatusView struct {
+	Name            string     `json:"name"`
+	Healthy         bool       `json:"healthy"`
+	Running         bool       `json:"running"`
+	Stopped         bool       `json:"stopped"`
+	IntervalSeconds float64    `json:"interval_seconds"`
+	Runs            int        `json:"runs"`
+	Failures        int        `json:"failures"`
+	StartedAt       time.Time  `json:"started_at"`
+	LastRunAt       *time.Time `json:"last_run_at,omitempty"`
+	LastSuccessAt   *time.Time `json:"last_success_at,omitempty"`
+	LastDurationMS  int64      `json:"last_duration_ms"`
+	LastError       string     `json:"last_error,omitempty"`
+}
+
+// handleAdminWorkers handles GET /api/admin/workers
+// Reports the health of background workers (outbox retries, scheduled emails). Admin only.
+func handleAdminWorkers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "outbox") {
+		return
+	}
+
+	views := []workerStatusView{}
+	if workerMonitor != nil {
+		for _, s := range workerMonitor.Snapshot() {
+			v := workerStatusView{
+				Name:            s.Name,
+				Healthy:         s.Healthy,
+				Running:         s.Running,
+				Stopped:         s.Stopped,
+				IntervalSeconds: s.Interval.Seconds(),
+				Runs:            s.Runs,
+				Failures:        s.Failures,
+				StartedAt:       s.StartedAt,
+				LastDurationMS:  s.LastDuration.Milliseconds(),
+				LastError:       s.LastError,
+			}
+			if !s.LastRunAt.IsZero() {
+				v.LastRunAt = &s.LastRunAt
+			}
+			if !s.LastSuccessAt.IsZero() {
+				v.LastSuccessAt = &s.LastSuccessAt
+			}
+			views = append(views, v)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(views)
+}
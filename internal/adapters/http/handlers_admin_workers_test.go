@@ -0,0 +1,39 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/application/orchestrators"
+)
+
+// TestHandleAdminWorkers verifies admins see registered workers and others are refused.
+func TestHandleAdminWorkers(t *testing.T) {
+	stores = newFullStores()
+	monitor := orchestrators.NewWorkerMonitor(time.Now)
+	monitor.Register("scheduled_emails", time.Minute)
+	SetWorkerMonitor(monitor)
+	defer SetWorkerMonitor(nil)
+
+	rec := httptest.NewRecorder()
+	handleAdminWorkers(rec, authRequest("GET", "/api/admin/workers", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAdminWorkers(rec, authRequest("GET", "/api/admin/workers", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("admin: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var got []workerStatusView
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "scheduled_emails" || !got[0].Healthy {
+		t.Errorf("unexpected workers: %+v", got)
+	}
+}
@@ -0,0 +1,46 @@
+package web
+
+import (
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+)
+
+// handleAPIDocsPage handles GET /admin/api-docs
+func handleAPIDocsPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "api_docs") {
+		return
+	}
+	renderTemplate(w, r, "admin_api_docs.html", nil)
+}
+
+// handleOpenAPISpec handles GET /api/openapi.json
+// Serves the OpenAPI document built from apiOperations. Admin only.
+func handleOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "api_docs") {
+		return
+	}
+	spec, err := openAPISpec()
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(spec)
+}
@@ -0,0 +1,95 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"workshop/internal/adapters/http/openapi"
+)
+
+// recordingMux records the patterns registerRoutes registers.
+type recordingMux struct {
+	patterns []string
+}
+
+// HandleFunc records pattern.
+// PRE: pattern is a ServeMux pattern
+// POST: pattern is appended to patterns
+func (m *recordingMux) HandleFunc(pattern string, _ func(http.ResponseWriter, *http.Request)) {
+	m.patterns = append(m.patterns, pattern)
+}
+
+// TestAPIRoutesDocumented verifies every registered /api route is in apiOperations and vice versa.
+func TestAPIRoutesDocumented(t *testing.T) {
+	mux := &recordingMux{}
+	registerRoutes(mux)
+
+	registered := make(map[string]bool)
+	for _, p := range mux.patterns {
+		if strings.HasPrefix(p, "/api/") {
+			registered[p] = true
+		}
+	}
+	documented := make(map[string]bool)
+	for _, op := range apiOperations {
+		documented[op.Path] = true
+	}
+
+	for p := range registered {
+		if !documented[p] {
+			t.Errorf("%s is registered but not documented in apiOperations", p)
+		}
+	}
+	for p := range documented {
+		if !registered[p] {
+			t.Errorf("%s is documented but not registered in routes.go", p)
+		}
+	}
+}
+
+// TestOpenAPIFileCurrent verifies the committed openapi.json matches the registry.
+func TestOpenAPIFileCurrent(t *testing.T) {
+	want, err := OpenAPIDocument()
+	if err != nil {
+		t.Fatalf("OpenAPIDocument: %v", err)
+	}
+	got, err := os.ReadFile("../../../openapi.json")
+	if err != nil {
+		t.Fatalf("read openapi.json: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("openapi.json is stale; run go generate ./internal/adapters/http")
+	}
+}
+
+// TestHandleOpenAPISpec verifies the document is served to admins only.
+func TestHandleOpenAPISpec(t *testing.T) {
+	stores = newFullStores()
+
+	rec := httptest.NewRecorder()
+	handleOpenAPISpec(rec, authRequest("GET", "/api/openapi.json", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var doc openapi.Document
+	if err := json.NewDecoder(rec.Body).Decode(&doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if doc.OpenAPI != openapi.Version {
+		t.Errorf("expected openapi %s, got %q", openapi.Version, doc.OpenAPI)
+	}
+	if doc.Paths["/api/admin/sessions/revoke"]["post"] == nil {
+		t.Error("POST /api/admin/sessions/revoke missing from the served document")
+	}
+
+	rec = httptest.NewRecorder()
+	handleOpenAPISpec(rec, authRequest("GET", "/api/openapi.json", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member: expected 403, got %d", rec.Code)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -32,6 +33,7 @@ import (
 	termStore "workshop/internal/adapters/storage/term"
 	trainingGoalStore "workshop/internal/adapters/storage/traininggoal"
 	waiverStore "workshop/internal/adapters/storage/waiver"
+	"workshop/internal/application/projections"
 
 	accountDomain "workshop/internal/domain/account"
 	attendanceDomain "workshop/internal/domain/attendance"
@@ -40,12 +42,14 @@ import (
 	gradingDomain "workshop/internal/domain/grading"
 	holidayDomain "workshop/internal/domain/holiday"
 	injuryDomain "workshop/internal/domain/injury"
+	jobDomain "workshop/internal/domain/job"
 	memberDomain "workshop/internal/domain/member"
 	messageDomain "workshop/internal/domain/message"
 	milestoneDomain "workshop/internal/domain/milestone"
 	noticeDomain "workshop/internal/domain/notice"
 	observationDomain "workshop/internal/domain/observation"
 	programDomain "workshop/internal/domain/program"
+	referralDomain "workshop/internal/domain/referral"
 	scheduleDomain "workshop/internal/domain/schedule"
 	termDomain "workshop/internal/domain/term"
 	themeDomain "workshop/internal/domain/theme"
@@ -206,6 +210,38 @@ func TestHandleGetTrainingVolume_AdminCanRequestOtherMember(t *testing.T) {
 	}
 }
 
+// TestHandleMemberSearch_ReturnsCardsWithInjuryForStaffOnly verifies search results carry
+// the member's belt and initials, and only staff see the injury flag.
+func TestHandleMemberSearch_ReturnsCardsWithInjuryForStaffOnly(t *testing.T) {
+	stores = newFullStores()
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", Name: "Kiri Ngata", Email: "kiri@test.com", Program: "adults", Status: "active"})
+	stores.GradingRecordStore.Save(ctx, gradingDomain.Record{ID: "g1", MemberID: "m1", Belt: gradingDomain.BeltPurple, Stripe: 2, PromotedAt: time.Now().AddDate(0, -1, 0), Method: gradingDomain.MethodStandard})
+	stores.InjuryStore.Save(ctx, injuryDomain.Injury{ID: "i1", MemberID: "m1", BodyPart: injuryDomain.BodyPartKnee, Status: injuryDomain.StatusActive, ReportedAt: time.Now()})
+
+	search := func(req *http.Request) []projections.MemberCard {
+		rec := httptest.NewRecorder()
+		handleMemberSearch(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("got %d, want 200. Body: %s", rec.Code, rec.Body.String())
+		}
+		var cards []projections.MemberCard
+		json.NewDecoder(rec.Body).Decode(&cards)
+		if len(cards) != 1 {
+			t.Fatalf("cards = %+v, want one", cards)
+		}
+		return cards
+	}
+
+	c := search(authRequest("GET", "/api/members/search?q=kiri", "", coachSession))[0]
+	if c.Name != "Kiri Ngata" || c.Belt != gradingDomain.BeltPurple || c.Stripe != 2 || c.Initials != "KN" || !c.HasInjury {
+		t.Errorf("coach card = %+v, want purple belt 2 stripes, KN, injured", c)
+	}
+	if c := search(httptest.NewRequest("GET", "/api/members/search?q=kiri", nil))[0]; c.HasInjury || c.Belt != gradingDomain.BeltPurple {
+		t.Errorf("signed-out card = %+v, want belt without the injury flag", c)
+	}
+}
+
 // TestHandleMembersExportCSV_FeatureFlagDisabled_Blocks verifies feature gating blocks coaches when member_mgmt is disabled.
 // PRE: authenticated coach session and member_mgmt disabled for coach.
 // POST: response is 403.
@@ -751,6 +787,22 @@ func (m *mockNoticeStore) ListPublished(ctx context.Context, noticeType string,
 	return list, nil
 }
 
+// ArchiveExpired implements the mock NoticeStore for testing.
+// PRE: valid parameters
+// POST: published notices past VisibleUntil are archived
+func (m *mockNoticeStore) ArchiveExpired(ctx context.Context, now time.Time) ([]string, error) {
+	var ids []string
+	for id, n := range m.notices {
+		if n.Status == noticeDomain.StatusPublished && n.IsExpired(now) {
+			n.Status = noticeDomain.StatusArchived
+			n.UpdatedAt = now
+			m.notices[id] = n
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
 type mockMessageStore struct {
 	messages map[string]messageDomain.Message
 }
@@ -803,13 +855,40 @@ func (m *mockMessageStore) ListByReceiverID(ctx context.Context, receiverID stri
 func (m *mockMessageStore) CountUnread(ctx context.Context, receiverID string) (int, error) {
 	count := 0
 	for _, msg := range m.messages {
-		if msg.ReceiverID == receiverID && msg.ReadAt.IsZero() {
+		if msg.ReceiverID == receiverID && !msg.FromMember && msg.ReadAt.IsZero() {
 			count++
 		}
 	}
 	return count, nil
 }
 
+// ListByThreadID implements the mock MessageStore for testing.
+// PRE: valid parameters
+// POST: returns the thread oldest first
+func (m *mockMessageStore) ListByThreadID(ctx context.Context, threadID string) ([]messageDomain.Message, error) {
+	var list []messageDomain.Message
+	for _, msg := range m.messages {
+		if msg.ID == threadID || msg.ParentID == threadID {
+			list = append(list, msg)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list, nil
+}
+
+// ListUnreadReplies implements the mock MessageStore for testing.
+// PRE: valid parameters
+// POST: returns unread member replies
+func (m *mockMessageStore) ListUnreadReplies(ctx context.Context) ([]messageDomain.Message, error) {
+	var list []messageDomain.Message
+	for _, msg := range m.messages {
+		if msg.FromMember && msg.ReadAt.IsZero() {
+			list = append(list, msg)
+		}
+	}
+	return list, nil
+}
+
 type mockObservationStore struct {
 	observations map[string]observationDomain.Observation
 }
@@ -857,7 +936,8 @@ func (m *mockObservationStore) ListByMemberID(ctx context.Context, memberID stri
 }
 
 type mockGradingRecordStore struct {
-	records map[string]gradingDomain.Record
+	records    map[string]gradingDomain.Record
+	awardNotes []gradingDomain.Note // notes saved by SaveAwards
 }
 
 // GetByID implements the mock GradingRecordStore for testing.
@@ -881,16 +961,76 @@ func (m *mockGradingRecordStore) Save(ctx context.Context, r gradingDomain.Recor
 	return nil
 }
 
+// SaveAwards implements the mock GradingRecordStore for testing.
+// PRE: valid parameters
+// POST: stores the records and keeps the notes
+func (m *mockGradingRecordStore) SaveAwards(ctx context.Context, records []gradingDomain.Record, notes []gradingDomain.Note) error {
+	for _, r := range records {
+		m.Save(ctx, r)
+	}
+	m.awardNotes = append(m.awardNotes, notes...)
+	return nil
+}
+
+// List implements the mock GradingRecordStore for testing.
+// PRE: valid parameters
+// POST: returns one page of the matching effective records, newest first unless Dir is "asc"
+func (m *mockGradingRecordStore) List(ctx context.Context, filter gradingStore.RecordFilter) ([]gradingDomain.Record, error) {
+	var list []gradingDomain.Record
+	for _, r := range m.records {
+		day := r.PromotedAt.Format("2006-01-02")
+		if r.IsEffective() && (filter.MemberID == "" || r.MemberID == filter.MemberID) && (filter.From == "" || day >= filter.From) && (filter.To == "" || day <= filter.To) {
+			list = append(list, r)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if filter.Dir == "asc" {
+			return list[i].PromotedAt.Before(list[j].PromotedAt)
+		}
+		return list[i].PromotedAt.After(list[j].PromotedAt)
+	})
+	if filter.Offset >= len(list) {
+		return nil, nil
+	}
+	list = list[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(list) {
+		list = list[:filter.Limit]
+	}
+	return list, nil
+}
+
 // ListByMemberID implements the mock GradingRecordStore for testing.
 // PRE: valid parameters
-// POST: returns expected result
+// POST: returns the member's effective records
 func (m *mockGradingRecordStore) ListByMemberID(ctx context.Context, memberID string) ([]gradingDomain.Record, error) {
+	list, _ := m.ListHistoryByMemberID(ctx, memberID)
+	return gradingDomain.EffectiveRecords(list), nil
+}
+
+// ListHistoryByMemberID implements the mock GradingRecordStore for testing.
+// PRE: valid parameters
+// POST: returns every record for the member, newest first
+func (m *mockGradingRecordStore) ListHistoryByMemberID(ctx context.Context, memberID string) ([]gradingDomain.Record, error) {
 	var list []gradingDomain.Record
 	for _, r := range m.records {
 		if r.MemberID == memberID {
 			list = append(list, r)
 		}
 	}
+	sort.Slice(list, func(i, j int) bool { return list[i].PromotedAt.After(list[j].PromotedAt) })
+	return list, nil
+}
+
+// ListByDateRange implements the mock GradingRecordStore for testing.
+// PRE: startDate and endDate are YYYY-MM-DD
+// POST: returns records promoted on or between the dates
+func (m *mockGradingRecordStore) ListByDateRange(ctx context.Context, startDate string, endDate string) ([]gradingDomain.Record, error) {
+	var list []gradingDomain.Record
+	for _, r := range m.records {
+		if day := r.PromotedAt.Format("2006-01-02"); r.IsEffective() && day >= startDate && day <= endDate {
+			list = append(list, r)
+		}
+	}
 	return list, nil
 }
 
@@ -1033,16 +1173,31 @@ func (m *mockGradingProposalStore) Save(ctx context.Context, p gradingDomain.Pro
 	return nil
 }
 
-// ListPending implements the mock GradingProposalStore for testing.
+// ListOpen implements the mock GradingProposalStore for testing.
 // PRE: valid parameters
-// POST: returns expected result
-func (m *mockGradingProposalStore) ListPending(ctx context.Context) ([]gradingDomain.Proposal, error) {
+// POST: returns pending and scheduled proposals
+func (m *mockGradingProposalStore) ListOpen(ctx context.Context) ([]gradingDomain.Proposal, error) {
 	var list []gradingDomain.Proposal
 	for _, p := range m.proposals {
-		if p.Status == gradingDomain.ProposalPending {
+		if p.IsOpen() {
 			list = append(list, p)
 		}
 	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list, nil
+}
+
+// ListByEventID implements the mock GradingProposalStore for testing.
+// PRE: valid parameters
+// POST: returns proposals booked onto the event
+func (m *mockGradingProposalStore) ListByEventID(ctx context.Context, eventID string) ([]gradingDomain.Proposal, error) {
+	var list []gradingDomain.Proposal
+	for _, p := range m.proposals {
+		if p.EventID == eventID {
+			list = append(list, p)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
 	return list, nil
 }
 
@@ -1238,6 +1393,16 @@ func (m *mockScheduleStore) Save(ctx context.Context, s scheduleDomain.Schedule)
 	return nil
 }
 
+// SaveAll implements the mock ScheduleStore for testing.
+// PRE: valid parameters
+// POST: every schedule is stored
+func (m *mockScheduleStore) SaveAll(ctx context.Context, list []scheduleDomain.Schedule) error {
+	for _, s := range list {
+		m.Save(ctx, s)
+	}
+	return nil
+}
+
 // Delete implements the mock ScheduleStore for testing.
 // PRE: valid parameters
 // POST: returns expected result
@@ -1632,6 +1797,7 @@ func newFullStores() *Stores {
 		AttendanceStore:          &mockAttendanceStore{attendances: make(map[string]attendanceDomain.Attendance)},
 		ProgramStore:             &mockProgramStore{programs: make(map[string]programDomain.Program)},
 		ClassTypeStore:           &mockClassTypeStore{classTypes: make(map[string]classTypeDomain.ClassType)},
+		ClassTypeApprovalStore:   &mockClassTypeApprovalStore{},
 		ScheduleStore:            &mockScheduleStore{schedules: make(map[string]scheduleDomain.Schedule)},
 		TermStore:                &mockTermStore{terms: make(map[string]termDomain.Term)},
 		HolidayStore:             &mockHolidayStore{holidays: make(map[string]holidayDomain.Holiday)},
@@ -1639,6 +1805,7 @@ func newFullStores() *Stores {
 		GradingRecordStore:       &mockGradingRecordStore{records: make(map[string]gradingDomain.Record)},
 		GradingConfigStore:       &mockGradingConfigStore{configs: make(map[string]gradingDomain.Config)},
 		GradingProposalStore:     &mockGradingProposalStore{proposals: make(map[string]gradingDomain.Proposal)},
+		GradingCeremonyStore:     &mockGradingCeremonyStore{ceremonies: make(map[string]gradingDomain.Ceremony)},
 		GradingNoteStore:         &mockGradingNoteStore{notes: make(map[string]gradingDomain.Note)},
 		GradingMemberConfigStore: &mockGradingMemberConfigStore{configs: make(map[string]gradingDomain.MemberConfig)},
 		MessageStore:             &mockMessageStore{messages: make(map[string]messageDomain.Message)},
@@ -1649,6 +1816,11 @@ func newFullStores() *Stores {
 		ThemeStore:               &mockThemeStore{themes: make(map[string]themeDomain.Theme)},
 		ClipStore:                &mockClipStore{clips: make(map[string]clipDomain.Clip)},
 		BugBoxStore:              &mockBugBoxStore{submissions: make(map[string]bugboxDomain.Submission)},
+		MemberTagStore:           newMockMemberTagStore(),
+		MemberMergeStore:         &mockMemberMergeStore{owned: map[string]map[string]int{}},
+		PerfBucketStore:          &mockPerfBucketStore{},
+		ReferralStore:            &mockReferralStore{referrals: map[string]referralDomain.Referral{}},
+		JobStore:                 &mockJobStore{jobs: map[string]jobDomain.Job{}},
 	}
 }
 
@@ -1853,6 +2025,104 @@ func TestHandleAdminFeatureFlags_POST_SavesFlags(t *testing.T) {
 	}
 }
 
+// TestHandleAdminFeatureFlags_POST_SavesTargeting verifies targeting fields are saved,
+// emails in account lists are resolved to IDs, and an invalid flag saves nothing.
+func TestHandleAdminFeatureFlags_POST_SavesTargeting(t *testing.T) {
+	stores = newFullStores()
+	ctx := context.Background()
+	stores.AccountStore.Save(ctx, accountDomain.Account{ID: "a1", Email: "alpha@test.com", Role: "member"})
+
+	body := `{"Flags":[{"Key":"library","EnabledAdmin":true,"EnabledMember":true,"RolloutPercent":30,"AllowAccounts":["alpha@test.com"," ","alpha@test.com"],"DenyAccounts":["a9"],"StartsAt":"2026-03-01T00:00:00Z","EndsAt":""}]}`
+	rec := httptest.NewRecorder()
+	handleAdminFeatureFlags(rec, authRequest("POST", "/api/admin/feature-flags", body, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	ff, _ := stores.FeatureFlagStore.GetByKey(ctx, "library")
+	if ff.RolloutPercent != 30 || len(ff.AllowAccounts) != 1 || ff.AllowAccounts[0] != "a1" || len(ff.DenyAccounts) != 1 || ff.StartsAt.IsZero() || !ff.EndsAt.IsZero() {
+		t.Fatalf("unexpected saved flag: %+v", ff)
+	}
+
+	for _, body := range []string{
+		`{"Flags":[{"Key":"messages","EnabledAdmin":true},{"Key":"library","RolloutPercent":150}]}`,
+		`{"Flags":[{"Key":"messages","EnabledAdmin":true,"AllowAccounts":["nobody@test.com"]}]}`,
+		`{"Flags":[{"Key":"messages","EnabledAdmin":true,"StartsAt":"tomorrow"}]}`,
+	} {
+		rec = httptest.NewRecorder()
+		handleAdminFeatureFlags(rec, authRequest("POST", "/api/admin/feature-flags", body, adminSession))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status=%d, want 400", body, rec.Code)
+		}
+	}
+	if _, err := stores.FeatureFlagStore.GetByKey(ctx, "messages"); err == nil {
+		t.Error("expected no flag saved from a rejected request")
+	}
+}
+
+// TestFeatureEnabledForSession_Targeting verifies gating honours account lists and the window.
+func TestFeatureEnabledForSession_Targeting(t *testing.T) {
+	stores = newFullStores()
+	ctx := context.Background()
+	timeNow = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+
+	stores.FeatureFlagStore.Save(ctx, featureflagDomain.FeatureFlag{Key: "library", EnabledAdmin: true, EnabledCoach: true, AllowAccounts: []string{memberSession.AccountID}, DenyAccounts: []string{coachSession.AccountID}})
+	if !featureEnabledForSession(ctx, memberSession, "library") {
+		t.Error("expected allow-listed member to see library")
+	}
+	if featureEnabledForSession(ctx, coachSession, "library") {
+		t.Error("expected deny-listed coach not to see library")
+	}
+
+	stores.FeatureFlagStore.Save(ctx, featureflagDomain.FeatureFlag{Key: "library", EnabledAdmin: true, StartsAt: timeNow().Add(time.Hour)})
+	if featureEnabledForSession(ctx, adminSession, "library") {
+		t.Error("expected library off before its start time")
+	}
+}
+
+// TestHandleAdminFeatureFlagTrace verifies the trace explains the rule that decided.
+func TestHandleAdminFeatureFlagTrace(t *testing.T) {
+	stores = newFullStores()
+	ctx := context.Background()
+	stores.AccountStore.Save(ctx, accountDomain.Account{ID: "a1", Email: "alpha@test.com", Role: "member"})
+	stores.FeatureFlagStore.Save(ctx, featureflagDomain.FeatureFlag{Key: "library", EnabledAdmin: true, AllowAccounts: []string{"a1"}})
+
+	rec := httptest.NewRecorder()
+	handleAdminFeatureFlagTrace(rec, authRequest("GET", "/api/admin/feature-flags/trace?key=library&email=alpha@test.com", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	var out featureFlagTraceView
+	if err := json.NewDecoder(rec.Body).Decode(&out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	last := out.Steps[len(out.Steps)-1]
+	if !out.Enabled || out.AccountID != "a1" || last.Rule != featureflagDomain.RuleAllow {
+		t.Fatalf("unexpected trace: %+v", out)
+	}
+
+	tests := []struct {
+		name    string
+		url     string
+		session middleware.Session
+		want    int
+	}{
+		{"member forbidden", "/api/admin/feature-flags/trace?key=library&account_id=a1", memberSession, http.StatusForbidden},
+		{"missing account", "/api/admin/feature-flags/trace?key=library", adminSession, http.StatusBadRequest},
+		{"unknown flag", "/api/admin/feature-flags/trace?key=nope&account_id=a1", adminSession, http.StatusNotFound},
+		{"unknown account", "/api/admin/feature-flags/trace?key=library&account_id=zz", adminSession, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleAdminFeatureFlagTrace(rec, authRequest("GET", tt.url, "", tt.session))
+			if rec.Code != tt.want {
+				t.Errorf("status=%d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
 // TestHandleAdminBetaTesters_GET_ListsOnlyBetaTesters verifies only beta testers are returned.
 func TestHandleAdminBetaTesters_GET_ListsOnlyBetaTesters(t *testing.T) {
 	stores = newFullStores()
@@ -2165,6 +2435,74 @@ func TestHandleNoticeEdit_NonAdmin(t *testing.T) {
 	}
 }
 
+// TestHandleNoticeImages tests that an admin can upload an image for a notice body, that any
+// signed-in user can load it, and that only real images are kept.
+func TestHandleNoticeImages(t *testing.T) {
+	stores = newFullStores()
+	noticeImageDir = t.TempDir()
+
+	upload := func(data []byte, sess middleware.Session) *httptest.ResponseRecorder {
+		req := buildMessageImageUpload(t, data, sess)
+		req.URL.Path = "/api/notices/images"
+		rec := httptest.NewRecorder()
+		handleNoticeImageUpload(rec, req)
+		return rec
+	}
+	if rec := upload(pngHeader, memberSession); rec.Code != http.StatusForbidden {
+		t.Errorf("member upload: got %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	if rec := upload([]byte("<svg onload=alert(1)>"), adminSession); rec.Code != http.StatusBadRequest {
+		t.Errorf("svg upload: got %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec := upload(pngHeader, adminSession)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("upload: got %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	var img noticeImageUploadResponse
+	json.NewDecoder(rec.Body).Decode(&img)
+	if img.Markdown != "![]("+img.URL+")" || img.URL != "/api/notices/image?id="+img.ImageID {
+		t.Errorf("upload response = %+v", img)
+	}
+
+	rec = httptest.NewRecorder()
+	handleNoticeImage(rec, authRequest("GET", img.URL, "", memberSession))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/png" || rec.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Errorf("member image: got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	rec = httptest.NewRecorder()
+	handleNoticeImage(rec, authRequest("GET", "/api/notices/image?id=../notices", "", memberSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("path escape: got %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+// TestHandleNoticePreview tests that notice markdown renders as the board shows it, with raw HTML escaped.
+func TestHandleNoticePreview(t *testing.T) {
+	stores = newFullStores()
+	rec := httptest.NewRecorder()
+	handleNoticePreview(rec, authRequest("POST", "/api/notices/preview", `{"Content":"**Open mat** <script>alert(1)</script>"}`, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got %d, want %d: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var preview noticePreviewResponse
+	json.NewDecoder(rec.Body).Decode(&preview)
+	if !strings.Contains(preview.HTML, "<strong>Open mat</strong>") || strings.Contains(preview.HTML, "<script>") {
+		t.Errorf("HTML = %q", preview.HTML)
+	}
+
+	rec = httptest.NewRecorder()
+	handleNoticePreview(rec, authRequest("POST", "/api/notices/preview", `{"Content":"x"}`, memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member: got %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	rec = httptest.NewRecorder()
+	handleNoticePreview(rec, authRequest("POST", "/api/notices/preview", `{"Content":"`+strings.Repeat("a", 10001)+`"}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("too long: got %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
 // --- Tests: /api/messages ---
 
 // TestHandleMessages_GET_MissingMemberID tests the corresponding handler.
@@ -2303,6 +2641,74 @@ func TestHandleObservations_POST_MissingContent(t *testing.T) {
 	}
 }
 
+// TestHandleObservations_VisibilityTiers verifies members see only their own shared
+// observations, coaches do not see admin-only notes, and only staff may write or share.
+func TestHandleObservations_VisibilityTiers(t *testing.T) {
+	stores = newFullStores()
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", AccountID: "member-001", Name: "Marcus", Email: "marcus@test.com", Program: "adults", Status: "active"})
+	for _, o := range []observationDomain.Observation{
+		{ID: "o1", MemberID: "m1", AuthorID: "coach-001", Content: "Keep elbows tight", Visibility: observationDomain.VisibilityShared, CreatedAt: time.Now()},
+		{ID: "o2", MemberID: "m1", AuthorID: "coach-001", Content: "Ready for stripe soon", Visibility: observationDomain.VisibilityCoaches, CreatedAt: time.Now()},
+		{ID: "o3", MemberID: "m1", AuthorID: "admin-001", Content: "Fees in arrears", Visibility: observationDomain.VisibilityAdmin, CreatedAt: time.Now()},
+	} {
+		stores.ObservationStore.Save(ctx, o)
+	}
+	count := func(sess middleware.Session, url string) (int, int) {
+		rec := httptest.NewRecorder()
+		handleObservations(rec, authRequest("GET", url, "", sess))
+		var obs []observationDomain.Observation
+		json.NewDecoder(rec.Body).Decode(&obs)
+		return rec.Code, len(obs)
+	}
+
+	if code, n := count(adminSession, "/api/observations?member_id=m1"); code != http.StatusOK || n != 3 {
+		t.Errorf("admin: got %d with %d observations, want 3", code, n)
+	}
+	if code, n := count(coachSession, "/api/observations?member_id=m1"); code != http.StatusOK || n != 2 {
+		t.Errorf("coach: got %d with %d observations, want 2", code, n)
+	}
+	if code, n := count(memberSession, "/api/observations"); code != http.StatusOK || n != 1 {
+		t.Errorf("member: got %d with %d observations, want only the shared one", code, n)
+	}
+	if code, _ := count(memberSession, "/api/observations?member_id=m2"); code != http.StatusForbidden {
+		t.Errorf("member reading another member: got %d, want 403", code)
+	}
+
+	rec := httptest.NewRecorder()
+	handleObservations(rec, authRequest("POST", "/api/observations", `{"MemberID":"m1","Content":"self note"}`, memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member POST: got %d, want 403", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleObservationVisibility(rec, authRequest("POST", "/api/observations/visibility", `{"ID":"o2","Visibility":"shared"}`, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("share: got %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	if _, n := count(memberSession, "/api/observations"); n != 2 {
+		t.Errorf("member after share: got %d observations, want 2", n)
+	}
+
+	rec = httptest.NewRecorder()
+	handleObservationVisibility(rec, authRequest("POST", "/api/observations/visibility", `{"ID":"o3","Visibility":"shared"}`, coachSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("coach sharing an admin-only note: got %d, want 404", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleObservationVisibility(rec, authRequest("POST", "/api/observations/visibility", `{"ID":"o1","Visibility":"public"}`, coachSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown visibility: got %d, want 400", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleObservationVisibility(rec, authRequest("POST", "/api/observations/visibility", `{"ID":"o1","Visibility":"coaches"}`, memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member changing visibility: got %d, want 403", rec.Code)
+	}
+}
+
 // --- Tests: /api/grading/proposals ---
 
 // TestHandleGradingProposals_GET_Empty tests the corresponding handler.
@@ -2345,6 +2751,7 @@ func TestHandleGradingProposals_GET_WithPending(t *testing.T) {
 // TestHandleGradingProposals_POST_Valid tests the corresponding handler.
 func TestHandleGradingProposals_POST_Valid(t *testing.T) {
 	stores = newFullStores()
+	stores.MemberStore.Save(context.Background(), memberDomain.Member{ID: "member-001", Name: "Ana", Program: memberDomain.ProgramAdults, Status: "active"})
 	body := `{"MemberID":"member-001","TargetBelt":"blue","Notes":"Ready for promotion"}`
 	req := authRequest("POST", "/api/grading/proposals", body, coachSession)
 	rec := httptest.NewRecorder()
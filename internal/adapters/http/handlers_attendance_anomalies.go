@@ -0,0 +1,145 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	attendanceDomain "workshop/internal/domain/attendance"
+)
+
+// anomalyFixRequest is the body of POST /api/attendance/anomalies/fix.
+type anomalyFixRequest struct {
+	Action       string `json:"Action"` // merge, delete or reassign
+	AttendanceID string `json:"AttendanceID"`
+	OtherID      string `json:"OtherID"`    // merge: the check-in folded into AttendanceID
+	ScheduleID   string `json:"ScheduleID"` // reassign: the class the check-in was really for
+}
+
+// handleAttendanceAnomaliesPage handles GET /attendance/anomalies
+// Lists duplicate and overlapping check-ins with one-click fixes.
+func handleAttendanceAnomaliesPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "attendance") {
+		return
+	}
+	renderTemplate(w, r, "attendance_anomalies.html", map[string]any{
+		"Title":      "Check-in Anomalies",
+		"WindowDays": projections.CheckInAnomalyWindowDays,
+	})
+}
+
+// handleAttendanceAnomalies handles GET /api/attendance/anomalies
+// Lists duplicate and overlapping check-ins between ?from= and ?to= (YYYY-MM-DD), by
+// default over the last 30 days. Admin only.
+func handleAttendanceAnomalies(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "attendance") {
+		return
+	}
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	q := r.URL.Query()
+	for _, date := range []string{q.Get("from"), q.Get("to")} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			apierror.Validation(w, "dates must be YYYY-MM-DD")
+			return
+		}
+	}
+
+	result, err := projections.QueryGetCheckInAnomalies(r.Context(), projections.GetCheckInAnomaliesQuery{
+		From: q.Get("from"),
+		To:   q.Get("to"),
+	}, timeNow(), projections.GetCheckInAnomaliesDeps{
+		AttendanceStore: stores.AttendanceStore,
+		MemberStore:     stores.MemberStore,
+		ScheduleStore:   stores.ScheduleStore,
+		ClassTypeStore:  stores.ClassTypeStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// handleAttendanceAnomalyFix handles POST /api/attendance/anomalies/fix
+// Merges, deletes or reassigns a flagged check-in. Admin only; every fix is audited.
+func handleAttendanceAnomalyFix(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "attendance") {
+		return
+	}
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	var input anomalyFixRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+
+	kept, err := orchestrators.ExecuteFixCheckInAnomaly(r.Context(), orchestrators.FixCheckInAnomalyInput{
+		Action:       input.Action,
+		AttendanceID: input.AttendanceID,
+		OtherID:      input.OtherID,
+		ScheduleID:   input.ScheduleID,
+		Actor: orchestrators.BackfillActor{
+			AccountID: sess.AccountID,
+			Email:     sess.Email,
+			Role:      sess.Role,
+			IPAddress: middleware.ClientIP(r),
+			UserAgent: r.UserAgent(),
+		},
+	}, orchestrators.FixCheckInAnomalyDeps{
+		AttendanceStore: stores.AttendanceStore,
+		ScheduleStore:   stores.ScheduleStore,
+		AuditStore:      stores.AuditStore,
+		PackDeps:        classPackDeps(),
+	})
+	switch {
+	case errors.Is(err, orchestrators.ErrAnomalyFixNotFound), errors.Is(err, orchestrators.ErrAnomalyFixNoSchedule):
+		apierror.NotFound(w, err.Error())
+		return
+	case errors.Is(err, orchestrators.ErrAnomalyFixAction), errors.Is(err, orchestrators.ErrAnomalyFixNoOther), errors.Is(err, attendanceDomain.ErrMergeMismatch):
+		apierror.Validation(w, err.Error())
+		return
+	case errors.Is(err, attendanceDomain.ErrDuplicateCheckIn), errors.Is(err, attendanceDomain.ErrOverlappingCheckIn):
+		apierror.Conflict(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+	if kept.ID == "" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(kept)
+}
@@ -0,0 +1,94 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/application/projections"
+	attendanceDomain "workshop/internal/domain/attendance"
+	classTypeDomain "workshop/internal/domain/classtype"
+	memberDomain "workshop/internal/domain/member"
+	scheduleDomain "workshop/internal/domain/schedule"
+)
+
+// setupAnomalyStores gives the stores one member, two overlapping classes today and a
+// duplicate check-in into the first.
+func setupAnomalyStores(t *testing.T) {
+	t.Helper()
+	stores = newFullStores()
+	stores.AuditStore = &mockAuditStore{}
+	ctx := context.Background()
+	today := time.Now()
+	day := strings.ToLower(today.Weekday().String())
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", Name: "Alice", Email: "alice@test.com", Program: "adults", Status: memberDomain.StatusActive})
+	stores.ClassTypeStore.Save(ctx, classTypeDomain.ClassType{ID: "ct1", Name: "Fundamentals"})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s1", ClassTypeID: "ct1", Day: day, StartTime: "00:00", EndTime: "23:00"})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s2", ClassTypeID: "ct1", Day: day, StartTime: "12:00", EndTime: "13:00"})
+	stores.AttendanceStore.Save(ctx, attendanceDomain.Attendance{ID: "a1", MemberID: "m1", ScheduleID: "s1", CheckInTime: today, MatHours: 1})
+	stores.AttendanceStore.Save(ctx, attendanceDomain.Attendance{ID: "a2", MemberID: "m1", ScheduleID: "s1", CheckInTime: today, MatHours: 1})
+}
+
+// TestHandleCheckIn_RejectsDuplicates verifies the kiosk gets 409 for the same class twice
+// and for a class running at the same time.
+func TestHandleCheckIn_RejectsDuplicates(t *testing.T) {
+	setupAnomalyStores(t)
+
+	for _, scheduleID := range []string{"s1", "s2"} {
+		req := httptest.NewRequest("POST", "/checkin", strings.NewReader(`{"MemberID":"m1","ScheduleID":"`+scheduleID+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handlePostCheckinCheckInMember(rec, req)
+		if rec.Code != http.StatusConflict {
+			t.Errorf("check-in to %s: expected 409, got %d: %s", scheduleID, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+// TestHandleAttendanceAnomalies verifies admins see the duplicate and can merge it, and
+// coaches cannot.
+func TestHandleAttendanceAnomalies(t *testing.T) {
+	setupAnomalyStores(t)
+
+	rec := httptest.NewRecorder()
+	handleAttendanceAnomalies(rec, authRequest("GET", "/api/attendance/anomalies", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var report projections.GetCheckInAnomaliesResult
+	json.NewDecoder(rec.Body).Decode(&report)
+	if len(report.Anomalies) != 1 || report.Anomalies[0].Kind != attendanceDomain.AnomalyDuplicate || report.Anomalies[0].MemberName != "Alice" {
+		t.Fatalf("anomalies = %+v, want Alice's duplicate", report.Anomalies)
+	}
+	if len(report.Anomalies[0].ReassignTo) != 2 {
+		t.Errorf("ReassignTo = %+v, want today's two classes", report.Anomalies[0].ReassignTo)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAttendanceAnomalyFix(rec, authRequest("POST", "/api/attendance/anomalies/fix", `{"Action":"merge","AttendanceID":"a1","OtherID":"a2"}`, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach fix: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAttendanceAnomalyFix(rec, authRequest("POST", "/api/attendance/anomalies/fix", `{"Action":"reassign","AttendanceID":"a2","ScheduleID":"s2"}`, adminSession))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("reassign into an overlap: expected 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleAttendanceAnomalyFix(rec, authRequest("POST", "/api/attendance/anomalies/fix", `{"Action":"merge","AttendanceID":"a1","OtherID":"a2"}`, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("merge: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if a, _ := stores.AttendanceStore.GetByID(context.Background(), "a2"); a.ID != "" {
+		t.Error("expected the merged check-in to be removed")
+	}
+	if n := len(stores.AuditStore.(*mockAuditStore).events); n != 2 {
+		t.Errorf("expected 2 audit events, got %d", n)
+	}
+}
@@ -0,0 +1,156 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	permissionDomain "workshop/internal/domain/permission"
+	scheduleDomain "workshop/internal/domain/schedule"
+)
+
+// handleAttendanceBackfillPage handles GET /attendance/backfill
+// Lets coaches add attendance for past classes the kiosk missed.
+func handleAttendanceBackfillPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := requirePermissionPage(w, r, permissionDomain.ActionAttendanceBackfill)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "attendance") {
+		return
+	}
+
+	classes, err := listClassOptions(ctx, requestLocationID(r))
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	renderTemplate(w, r, "attendance_backfill.html", map[string]interface{}{
+		"Title":      "Backfill Attendance",
+		"Classes":    classes,
+		"MaxEntries": orchestrators.MaxBackfillEntries,
+		"IsAdmin":    middleware.IsAdmin(ctx),
+	})
+}
+
+// classOption is a weekly class in a class picker.
+type classOption struct {
+	ID    string
+	Day   string
+	Label string
+}
+
+// listClassOptions returns the weekly classes at locationID, ordered by weekday then start time.
+func listClassOptions(ctx context.Context, locationID string) ([]classOption, error) {
+	schedules, err := stores.ScheduleStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	schedules = filterSchedulesByLocation(schedules, locationID)
+	dayOrder := make(map[string]int, len(scheduleDomain.ValidDays))
+	for i, d := range scheduleDomain.ValidDays {
+		dayOrder[d] = i
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		if schedules[i].Day != schedules[j].Day {
+			return dayOrder[schedules[i].Day] < dayOrder[schedules[j].Day]
+		}
+		return schedules[i].StartTime < schedules[j].StartTime
+	})
+
+	classes := make([]classOption, 0, len(schedules))
+	for _, s := range schedules {
+		name := s.ClassTypeID
+		if ct, err := stores.ClassTypeStore.GetByID(ctx, s.ClassTypeID); err == nil {
+			name = ct.Name
+		}
+		classes = append(classes, classOption{ID: s.ID, Day: s.Day, Label: s.Day + " " + s.StartTime + "–" + s.EndTime + " · " + name})
+	}
+	return classes, nil
+}
+
+// attendanceBackfillRequest is the body of POST /api/attendance/backfill.
+type attendanceBackfillRequest struct {
+	ScheduleID string   `json:"ScheduleID"`
+	MemberIDs  []string `json:"MemberIDs"`
+	Dates      []string `json:"Dates"`
+}
+
+// handleAttendanceBackfill handles POST /api/attendance/backfill
+// Adds every listed member to the class on every listed date. Coaches and admins only.
+// Returns per-entry outcomes; a rejected entry does not fail the request.
+func handleAttendanceBackfill(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "attendance") {
+		return
+	}
+	if !permissionAllowed(ctx, sess, permissionDomain.ActionAttendanceBackfill) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+
+	var input attendanceBackfillRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+
+	result, err := orchestrators.ExecuteBackfillAttendance(ctx, orchestrators.BackfillAttendanceInput{
+		ScheduleID: input.ScheduleID,
+		MemberIDs:  input.MemberIDs,
+		Dates:      input.Dates,
+		Actor: orchestrators.BackfillActor{
+			AccountID: sess.AccountID,
+			Email:     sess.Email,
+			Role:      sess.Role,
+			IPAddress: middleware.ClientIP(r),
+			UserAgent: r.UserAgent(),
+		},
+	}, orchestrators.BackfillAttendanceDeps{
+		MemberStore:     stores.MemberStore,
+		AttendanceStore: stores.AttendanceStore,
+		ScheduleStore:   stores.ScheduleStore,
+		TermStore:       stores.TermStore,
+		HolidayStore:    stores.HolidayStore,
+		AuditStore:      stores.AuditStore,
+		GenerateID:      generateID,
+		Now:             timeNow,
+	})
+	switch {
+	case errors.Is(err, orchestrators.ErrBackfillTooLarge):
+		apierror.TooLarge(w, err.Error())
+		return
+	case errors.Is(err, orchestrators.ErrBackfillEmpty), errors.Is(err, orchestrators.ErrBackfillScheduleNeeded):
+		apierror.Validation(w, err.Error())
+		return
+	case errors.Is(err, orchestrators.ErrBackfillScheduleAbsent):
+		apierror.NotFound(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
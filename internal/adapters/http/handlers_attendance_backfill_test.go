@@ -0,0 +1,121 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	auditStore "workshop/internal/adapters/storage/audit"
+	"workshop/internal/application/orchestrators"
+	auditDomain "workshop/internal/domain/audit"
+	memberDomain "workshop/internal/domain/member"
+	scheduleDomain "workshop/internal/domain/schedule"
+	termDomain "workshop/internal/domain/term"
+)
+
+// --- Mock Audit store ---
+
+type mockAuditStore struct {
+	events []auditDomain.Event
+}
+
+// Save implements audit.Store for testing.
+// PRE: event is valid
+// POST: Event is persisted
+func (m *mockAuditStore) Save(_ context.Context, event auditDomain.Event) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+// List implements audit.Store for testing.
+// PRE: limit > 0
+// POST: Returns events ordered by timestamp desc
+func (m *mockAuditStore) List(_ context.Context, _ auditStore.Filter, _ int) ([]auditDomain.Event, error) {
+	return m.events, nil
+}
+
+// GetByID implements audit.Store for testing.
+// PRE: id is non-empty
+// POST: Returns the event or error if not found
+func (m *mockAuditStore) GetByID(_ context.Context, id string) (auditDomain.Event, error) {
+	for _, e := range m.events {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return auditDomain.Event{}, errors.New("not found")
+}
+
+// TestHandleAttendanceBackfill_MemberForbidden verifies only coaches and admins can backfill.
+func TestHandleAttendanceBackfill_MemberForbidden(t *testing.T) {
+	stores = newFullStores()
+
+	rec := httptest.NewRecorder()
+	handleAttendanceBackfill(rec, authRequest("POST", "/api/attendance/backfill", `{"ScheduleID":"s1","MemberIDs":["m1"],"Dates":["2026-01-01"]}`, memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleAttendanceBackfill_CreatesAndAudits verifies a backfilled record is saved and attributed to the coach.
+func TestHandleAttendanceBackfill_CreatesAndAudits(t *testing.T) {
+	stores = newFullStores()
+	audits := &mockAuditStore{}
+	stores.AuditStore = audits
+	ctx := context.Background()
+	lastWeek := time.Now().AddDate(0, 0, -7)
+	date := lastWeek.Format("2006-01-02")
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", Name: "Alice", Email: "alice@test.com", Program: "adults", Status: memberDomain.StatusActive})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s1", ClassTypeID: "ct1", Day: strings.ToLower(lastWeek.Weekday().String()), StartTime: "18:00", EndTime: "19:30"})
+	stores.TermStore.Save(ctx, termDomain.Term{ID: "t1", Name: "Term", StartDate: lastWeek.AddDate(0, -1, 0), EndDate: lastWeek.AddDate(0, 1, 0)})
+
+	body := `{"ScheduleID":"s1","MemberIDs":["m1"],"Dates":["` + date + `"]}`
+	rec := httptest.NewRecorder()
+	handleAttendanceBackfill(rec, authRequest("POST", "/api/attendance/backfill", body, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result orchestrators.BackfillAttendanceResult
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if result.Created != 1 {
+		t.Fatalf("expected 1 created, got %+v", result)
+	}
+
+	saved, err := stores.AttendanceStore.ListByMemberIDAndDate(ctx, "m1", date)
+	if err != nil || len(saved) != 1 || saved[0].MatHours != 1.5 {
+		t.Fatalf("expected one 1.5h attendance on %s, got %+v (err %v)", date, saved, err)
+	}
+	if len(audits.events) != 1 || audits.events[0].ActorID != coachSession.AccountID || audits.events[0].ResourceID != saved[0].ID {
+		t.Errorf("expected audit event by %s for %s, got %+v", coachSession.AccountID, saved[0].ID, audits.events)
+	}
+}
+
+// TestHandleAttendanceBackfill_BadRequests verifies request-level validation.
+func TestHandleAttendanceBackfill_BadRequests(t *testing.T) {
+	stores = newFullStores()
+	stores.AuditStore = &mockAuditStore{}
+
+	tests := []struct {
+		body string
+		want int
+	}{
+		{`{"MemberIDs":["m1"],"Dates":["2026-01-01"]}`, http.StatusBadRequest},
+		{`{"ScheduleID":"s1","MemberIDs":[],"Dates":["2026-01-01"]}`, http.StatusBadRequest},
+		{`{"ScheduleID":"missing","MemberIDs":["m1"],"Dates":["2026-01-01"]}`, http.StatusNotFound},
+		{`{"ScheduleID":"s1","Unknown":true}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handleAttendanceBackfill(rec, authRequest("POST", "/api/attendance/backfill", tt.body, adminSession))
+		if rec.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.body, tt.want, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+)
+
+const attendanceImportMaxBytes = 20 << 20 // 20 MB
+
+// handleAttendanceImport handles POST /api/attendance/import?dry_run=true|false
+// Imports legacy attendance from a multipart CSV upload (field "file"). The optional
+// "mappings" field is a JSON object of legacy name to member ID, chosen by the admin when
+// reviewing names the import could not match; an empty ID skips the name. Admin only.
+func handleAttendanceImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "attendance") {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, attendanceImportMaxBytes)
+	if err := r.ParseMultipartForm(attendanceImportMaxBytes); err != nil {
+		apierror.Validation(w, "file too large or invalid form")
+		return
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		apierror.Validation(w, "missing file field")
+		return
+	}
+	defer file.Close()
+	var mappings map[string]string
+	if raw := r.FormValue("mappings"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mappings); err != nil {
+			apierror.Validation(w, "mappings must be a JSON object of name to member ID")
+			return
+		}
+	}
+
+	result, err := orchestrators.ExecuteImportAttendance(r.Context(), orchestrators.ImportAttendanceInput{
+		Reader:   file,
+		Mappings: mappings,
+		DryRun:   r.URL.Query().Get("dry_run") == "true",
+		ActorID:  sess.AccountID,
+	}, orchestrators.ImportAttendanceDeps{
+		MemberStore:     stores.MemberStore,
+		ClassTypeStore:  stores.ClassTypeStore,
+		ScheduleStore:   stores.ScheduleStore,
+		AttendanceStore: stores.AttendanceStore,
+		GenerateID:      generateID,
+		Now:             timeNow,
+	})
+	switch {
+	case errors.Is(err, orchestrators.ErrInvalidAttendanceImport):
+		apierror.Validation(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,99 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	classTypeDomain "workshop/internal/domain/classtype"
+	memberDomain "workshop/internal/domain/member"
+	scheduleDomain "workshop/internal/domain/schedule"
+)
+
+// buildAttendanceImport builds a multipart POST to /api/attendance/import.
+func buildAttendanceImport(t *testing.T, csvContent, mappings string, dryRun bool, sess middleware.Session) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	fw, err := w.CreateFormFile("file", "attendance.csv")
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	fw.Write([]byte(csvContent))
+	if mappings != "" {
+		w.WriteField("mappings", mappings)
+	}
+	w.Close()
+
+	url := "/api/attendance/import?dry_run=false"
+	if dryRun {
+		url = "/api/attendance/import?dry_run=true"
+	}
+	req := authRequest("POST", url, "", sess)
+	req.Body = io.NopCloser(body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.ContentLength = int64(body.Len())
+	return req
+}
+
+// TestHandleAttendanceImport_ReviewThenImport verifies an unknown name is held for review,
+// the chosen mapping imports it, a re-run adds nothing, and only admins may import.
+func TestHandleAttendanceImport_ReviewThenImport(t *testing.T) {
+	stores = newFullStores()
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", Name: "Aroha Ngata", Email: "aroha@test.com", Program: "adults", Status: "active"})
+	stores.ClassTypeStore.Save(ctx, classTypeDomain.ClassType{ID: "ct1", Name: "Fundamentals Gi"})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s1", ClassTypeID: "ct1", Day: scheduleDomain.Monday, StartTime: "18:00", EndTime: "19:00"})
+	csv := "NAME,DATE,CLASS\nAroha Ngata,2024-03-04,Fundamentals Gi\nA. N.,11/3/2024,Fundamentals Gi\n"
+
+	rec := httptest.NewRecorder()
+	handleAttendanceImport(rec, buildAttendanceImport(t, csv, "", true, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAttendanceImport(rec, buildAttendanceImport(t, csv, "", true, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("preview: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var preview orchestrators.ImportAttendanceResult
+	json.NewDecoder(rec.Body).Decode(&preview)
+	if preview.Created != 1 || preview.Unmatched != 1 || len(preview.Review) != 1 || preview.Review[0].Name != "A. N." {
+		t.Fatalf("expected one row to add and A. N. to review, got %+v", preview)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAttendanceImport(rec, buildAttendanceImport(t, csv, `{"A. N.":"m1"}`, false, adminSession))
+	var result orchestrators.ImportAttendanceResult
+	json.NewDecoder(rec.Body).Decode(&result)
+	if rec.Code != http.StatusOK || result.Created != 2 {
+		t.Fatalf("import: expected 2 created, got %d: %+v", rec.Code, result)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAttendanceImport(rec, buildAttendanceImport(t, csv, `{"A. N.":"m1"}`, false, adminSession))
+	json.NewDecoder(rec.Body).Decode(&result)
+	if result.Created != 0 || result.Duplicate != 2 {
+		t.Errorf("re-run: expected both rows already recorded, got %+v", result)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAttendanceImport(rec, buildAttendanceImport(t, "NAME,DATE\nx,2024-01-01\n", "", true, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("missing CLASS column: expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAttendanceImport(rec, buildAttendanceImport(t, csv, `["m1"]`, true, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("bad mappings: expected 400, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,165 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// handleAttendanceRollCallPage handles GET /attendance/rollcall
+// Lets a coach tick off who is on the mat for one class session.
+func handleAttendanceRollCallPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := requirePermissionPage(w, r, permissionDomain.ActionAttendanceRollCall)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "attendance") {
+		return
+	}
+
+	classes, err := listClassOptions(ctx, requestLocationID(r))
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	renderTemplate(w, r, "attendance_rollcall.html", map[string]interface{}{
+		"Title":         "Roll Call",
+		"Classes":       classes,
+		"Today":         timeNow().Format("2006-01-02"),
+		"MaxMarks":      orchestrators.MaxRollCallMarks,
+		"LookbackWeeks": projections.RollCallLookbackWeeks,
+	})
+}
+
+// rollCallRequest is the body of POST /api/attendance/rollcall.
+type rollCallRequest struct {
+	ScheduleID string                       `json:"ScheduleID"`
+	ClassDate  string                       `json:"ClassDate"`
+	Marks      []orchestrators.RollCallMark `json:"Marks"`
+}
+
+// handleAttendanceRollCall handles GET/POST /api/attendance/rollcall
+// GET ?schedule_id=&date= lists the class's regulars and who is already present.
+// POST marks members present or absent for the session. Coaches and admins only.
+// Returns per-member outcomes; a rejected member does not fail the request.
+func handleAttendanceRollCall(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "attendance") {
+		return
+	}
+	if !permissionAllowed(ctx, sess, permissionDomain.ActionAttendanceRollCall) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		scheduleID := r.URL.Query().Get("schedule_id")
+		date := r.URL.Query().Get("date")
+		if scheduleID == "" {
+			apierror.Validation(w, "schedule_id is required")
+			return
+		}
+		if date == "" {
+			date = timeNow().Format("2006-01-02")
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			apierror.Validation(w, "date must be YYYY-MM-DD")
+			return
+		}
+		if _, err := stores.ScheduleStore.GetByID(ctx, scheduleID); err != nil {
+			apierror.NotFound(w, "class not found")
+			return
+		}
+
+		result, err := projections.QueryGetRollCall(ctx, projections.GetRollCallQuery{
+			ScheduleID: scheduleID,
+			ClassDate:  date,
+		}, projections.GetRollCallDeps{
+			ScheduleStore:      stores.ScheduleStore,
+			ClassTypeStore:     stores.ClassTypeStore,
+			AttendanceStore:    stores.AttendanceStore,
+			MemberStore:        stores.MemberStore,
+			GradingRecordStore: stores.GradingRecordStore,
+		})
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+
+	case "POST":
+		var input rollCallRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+
+		result, err := orchestrators.ExecuteRollCall(ctx, orchestrators.RollCallInput{
+			ScheduleID: input.ScheduleID,
+			ClassDate:  input.ClassDate,
+			Marks:      input.Marks,
+			Actor: orchestrators.BackfillActor{
+				AccountID: sess.AccountID,
+				Email:     sess.Email,
+				Role:      sess.Role,
+				IPAddress: middleware.ClientIP(r),
+				UserAgent: r.UserAgent(),
+			},
+		}, orchestrators.RollCallDeps{
+			MemberStore:     stores.MemberStore,
+			AttendanceStore: stores.AttendanceStore,
+			ScheduleStore:   stores.ScheduleStore,
+			AuditStore:      stores.AuditStore,
+			TopicDeps:       attendanceTopicDeps(),
+			PackDeps:        classPackDeps(),
+			GenerateID:      generateID,
+			Now:             timeNow,
+		})
+		switch {
+		case errors.Is(err, orchestrators.ErrRollCallTooLarge):
+			apierror.TooLarge(w, err.Error())
+			return
+		case errors.Is(err, orchestrators.ErrRollCallEmpty), errors.Is(err, orchestrators.ErrRollCallScheduleNeeded), errors.Is(err, orchestrators.ErrRollCallDate):
+			apierror.Validation(w, err.Error())
+			return
+		case errors.Is(err, orchestrators.ErrRollCallScheduleAbsent):
+			apierror.NotFound(w, err.Error())
+			return
+		case err != nil:
+			internalError(w, err)
+			return
+		}
+		if result.Created > 0 {
+			publishAttendance(eventAttendanceCheckIn, attendanceEvent{ScheduleID: result.ScheduleID, ClassDate: result.ClassDate, Count: result.Created})
+		}
+		if result.Removed > 0 {
+			publishAttendance(eventAttendanceUndo, attendanceEvent{ScheduleID: result.ScheduleID, ClassDate: result.ClassDate, Count: result.Removed})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
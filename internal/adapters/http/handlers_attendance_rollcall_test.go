@@ -0,0 +1,97 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	attendanceDomain "workshop/internal/domain/attendance"
+	memberDomain "workshop/internal/domain/member"
+	scheduleDomain "workshop/internal/domain/schedule"
+)
+
+// TestHandleAttendanceRollCall verifies a coach can load a class's regulars and mark them.
+func TestHandleAttendanceRollCall(t *testing.T) {
+	stores = newFullStores()
+	stores.AuditStore = &mockAuditStore{}
+	ctx := context.Background()
+	today := time.Now()
+	date := today.Format("2006-01-02")
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", Name: "Alice", Email: "alice@test.com", Program: "adults", Status: memberDomain.StatusActive})
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m2", Name: "Bob", Email: "bob@test.com", Program: "adults", Status: memberDomain.StatusActive})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s1", ClassTypeID: "ct1", Day: strings.ToLower(today.Weekday().String()), StartTime: "06:00", EndTime: "07:00"})
+	for _, weeksAgo := range []int{1, 2} {
+		day := today.AddDate(0, 0, -7*weeksAgo)
+		stores.AttendanceStore.Save(ctx, attendanceDomain.Attendance{ID: "a1-" + day.Format("0102"), MemberID: "m1", ScheduleID: "s1", ClassDate: day.Format("2006-01-02"), CheckInTime: day})
+	}
+	stores.AttendanceStore.Save(ctx, attendanceDomain.Attendance{ID: "a2-today", MemberID: "m2", ScheduleID: "s1", ClassDate: date, CheckInTime: today})
+
+	rec := httptest.NewRecorder()
+	handleAttendanceRollCall(rec, authRequest("GET", "/api/attendance/rollcall?schedule_id=s1&date="+date, "", coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("roster: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var roster projections.GetRollCallResult
+	json.NewDecoder(rec.Body).Decode(&roster)
+	if len(roster.Entries) != 2 || !roster.Entries[0].Regular || roster.Entries[0].Present || !roster.Entries[1].Present {
+		t.Fatalf("expected regular Alice absent and Bob present, got %+v", roster.Entries)
+	}
+
+	body := `{"ScheduleID":"s1","ClassDate":"` + date + `","Marks":[{"MemberID":"m1","Present":true},{"MemberID":"m2","Present":false}]}`
+	rec = httptest.NewRecorder()
+	handleAttendanceRollCall(rec, authRequest("POST", "/api/attendance/rollcall", body, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("roll call: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result orchestrators.RollCallResult
+	json.NewDecoder(rec.Body).Decode(&result)
+	if result.Created != 1 || result.Removed != 1 {
+		t.Errorf("expected 1 created and 1 removed, got %+v", result)
+	}
+	present, _ := stores.AttendanceStore.ListDistinctMemberIDsByScheduleAndDate(ctx, "s1", date)
+	if len(present) != 1 || present[0] != "m1" {
+		t.Errorf("expected only m1 present after roll call, got %v", present)
+	}
+	if n := len(stores.AuditStore.(*mockAuditStore).events); n != 2 {
+		t.Errorf("expected 2 audit events, got %d", n)
+	}
+}
+
+// TestHandleAttendanceRollCall_Errors verifies permission and request validation.
+func TestHandleAttendanceRollCall_Errors(t *testing.T) {
+	stores = newFullStores()
+	stores.ScheduleStore.Save(context.Background(), scheduleDomain.Schedule{ID: "s1", ClassTypeID: "ct1", Day: scheduleDomain.Monday, StartTime: "18:00", EndTime: "19:00"})
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		sess   middleware.Session
+		want   int
+	}{
+		{"member forbidden", "GET", "/api/attendance/rollcall?schedule_id=s1", "", memberSession, http.StatusForbidden},
+		{"missing schedule_id", "GET", "/api/attendance/rollcall", "", coachSession, http.StatusBadRequest},
+		{"unknown class", "GET", "/api/attendance/rollcall?schedule_id=nope", "", coachSession, http.StatusNotFound},
+		{"bad date", "GET", "/api/attendance/rollcall?schedule_id=s1&date=yesterday", "", coachSession, http.StatusBadRequest},
+		{"no marks", "POST", "/api/attendance/rollcall", `{"ScheduleID":"s1","ClassDate":"2026-01-05","Marks":[]}`, coachSession, http.StatusBadRequest},
+		{"future date", "POST", "/api/attendance/rollcall", `{"ScheduleID":"s1","ClassDate":"2999-01-07","Marks":[{"MemberID":"m1","Present":true}]}`, coachSession, http.StatusBadRequest},
+		{"wrong method", "DELETE", "/api/attendance/rollcall", "", coachSession, http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleAttendanceRollCall(rec, authRequest(tt.method, tt.url, tt.body, tt.sess))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
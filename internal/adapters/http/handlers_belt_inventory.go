@@ -0,0 +1,218 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	gradingDomain "workshop/internal/domain/grading"
+	inventoryDomain "workshop/internal/domain/inventory"
+)
+
+// takeGradingStock takes the belt and stripes a new grading record hands out from the
+// belt inventory. Like notifications it is best-effort: the promotion has happened
+// whether or not the stock count keeps up.
+func takeGradingStock(ctx context.Context, record gradingDomain.Record) {
+	if stores.BeltInventoryStore == nil {
+		return
+	}
+	result, err := orchestrators.ExecuteConsumeGradingStock(ctx, orchestrators.ConsumeGradingStockInput{Record: record}, orchestrators.ConsumeGradingStockDeps{
+		StockStore:  stores.BeltInventoryStore,
+		RecordStore: stores.GradingRecordStore,
+		Now:         timeNow,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "inventory_event", "event", "stock_failed", "record_id", record.ID, "error", err)
+		return
+	}
+	for _, use := range result.Missing {
+		slog.WarnContext(ctx, "inventory_event", "event", "stock_missing", "record_id", record.ID, "kind", use.Kind, "color", use.Color, "size", use.Size, "quantity", use.Quantity)
+	}
+	for _, item := range result.Low {
+		slog.WarnContext(ctx, "inventory_event", "event", "stock_low", "kind", item.Kind, "color", item.Color, "size", item.Size, "quantity", item.Quantity)
+	}
+}
+
+// beltInventoryView is the body returned by GET /api/grading/inventory.
+type beltInventoryView struct {
+	Items []inventoryDomain.Item
+	Low   []inventoryDomain.Item // items at or below their low-stock level
+	Sizes []string               // sizes an item or member may have, kids then adults
+}
+
+// beltInventoryRequest is the body of POST /api/grading/inventory.
+type beltInventoryRequest struct {
+	Kind     string `json:"Kind"`
+	Color    string `json:"Color"`
+	Size     string `json:"Size"`
+	Quantity int    `json:"Quantity"`
+	LowStock int    `json:"LowStock"`
+}
+
+// beltSizeRequest is the body of POST /api/grading/belt-sizes.
+type beltSizeRequest struct {
+	MemberID string `json:"MemberID"`
+	Size     string `json:"Size"`
+}
+
+// handleBeltInventory handles GET/POST for /api/grading/inventory
+// GET lists belt and stripe stock with the items running low. POST sets the count and
+// low-stock level of one colour and size, adding it if new. Admin only.
+func handleBeltInventory(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "belt_inventory") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		items, err := stores.BeltInventoryStore.ListItems(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		sort.SliceStable(items, func(i, j int) bool {
+			a, b := items[i], items[j]
+			if a.Kind != b.Kind {
+				return a.Kind == inventoryDomain.KindBelt
+			}
+			if a.Color != b.Color {
+				return inventoryDomain.ColorRank(a.Color) < inventoryDomain.ColorRank(b.Color)
+			}
+			return inventoryDomain.SizeRank(a.Size) < inventoryDomain.SizeRank(b.Size)
+		})
+		view := beltInventoryView{
+			Items: []inventoryDomain.Item{},
+			Low:   []inventoryDomain.Item{},
+			Sizes: append(append([]string{}, inventoryDomain.KidsSizes...), inventoryDomain.AdultSizes...),
+		}
+		for _, item := range items {
+			view.Items = append(view.Items, item)
+			if item.IsLow() {
+				view.Low = append(view.Low, item)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(view)
+
+	case "POST":
+		var input beltInventoryRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		item := inventoryDomain.Item{
+			ID:        generateID(),
+			Kind:      input.Kind,
+			Color:     input.Color,
+			Size:      input.Size,
+			Quantity:  input.Quantity,
+			LowStock:  input.LowStock,
+			UpdatedAt: timeNow(),
+		}
+		if err := item.Validate(); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err := stores.BeltInventoryStore.SaveItem(ctx, item); err != nil {
+			internalError(w, err)
+			return
+		}
+		// The store keeps the first ID for a colour and size; return what it holds.
+		saved, err := stores.BeltInventoryStore.GetItem(ctx, item.Kind, item.Color, item.Size)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(saved)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleBeltSizes handles POST /api/grading/belt-sizes
+// Records the belt size a member wears, so promotions take the right belt from stock
+// and the pick list can name it. Admin only.
+func handleBeltSizes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "belt_inventory") {
+		return
+	}
+	var input beltSizeRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	size := inventoryDomain.MemberSize{MemberID: input.MemberID, Size: input.Size}
+	if err := size.Validate(); err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	if _, err := stores.MemberStore.GetByID(r.Context(), size.MemberID); err != nil {
+		apierror.NotFound(w, "member not found")
+		return
+	}
+	if err := stores.BeltInventoryStore.SaveMemberSize(r.Context(), size); err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(size)
+}
+
+// handleGradingPickList handles GET /api/grading/pick-list?event_id=
+// The belts to bring to a grading day, from its approved proposals, by colour and size.
+// Admin only.
+func handleGradingPickList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "belt_inventory") {
+		return
+	}
+	eventID := r.URL.Query().Get("event_id")
+	if eventID == "" {
+		apierror.Validation(w, "event_id is required")
+		return
+	}
+	if _, err := stores.CalendarEventStore.GetByID(r.Context(), eventID); err != nil {
+		apierror.NotFound(w, "grading day not found")
+		return
+	}
+
+	result, err := projections.QueryGetGradingPickList(r.Context(), eventID, projections.GetGradingPickListDeps{
+		EventStore:    stores.CalendarEventStore,
+		ProposalStore: stores.GradingProposalStore,
+		MemberStore:   stores.MemberStore,
+		StockStore:    stores.BeltInventoryStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,208 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/application/projections"
+	gradingDomain "workshop/internal/domain/grading"
+	inventoryDomain "workshop/internal/domain/inventory"
+)
+
+// --- Mock stores ---
+
+type mockBeltInventoryStore struct {
+	items map[[3]string]inventoryDomain.Item
+	sizes map[string]string
+}
+
+func newMockBeltInventoryStore() *mockBeltInventoryStore {
+	return &mockBeltInventoryStore{items: map[[3]string]inventoryDomain.Item{}, sizes: map[string]string{}}
+}
+
+// GetItem implements inventory.Store for testing.
+// PRE: kind and color are non-empty
+// POST: Returns the item or sql.ErrNoRows
+func (m *mockBeltInventoryStore) GetItem(_ context.Context, kind, color, size string) (inventoryDomain.Item, error) {
+	item, ok := m.items[[3]string{kind, color, size}]
+	if !ok {
+		return inventoryDomain.Item{}, sql.ErrNoRows
+	}
+	return item, nil
+}
+
+// SaveItem implements inventory.Store for testing.
+// PRE: item has been validated
+// POST: Item is upserted by kind, colour and size, keeping the first ID
+func (m *mockBeltInventoryStore) SaveItem(_ context.Context, item inventoryDomain.Item) error {
+	key := [3]string{item.Kind, item.Color, item.Size}
+	if existing, ok := m.items[key]; ok {
+		item.ID = existing.ID
+	}
+	m.items[key] = item
+	return nil
+}
+
+// ListItems implements inventory.Store for testing.
+// PRE: none
+// POST: Returns every item
+func (m *mockBeltInventoryStore) ListItems(_ context.Context) ([]inventoryDomain.Item, error) {
+	var list []inventoryDomain.Item
+	for _, item := range m.items {
+		list = append(list, item)
+	}
+	return list, nil
+}
+
+// GetMemberSize implements inventory.Store for testing.
+// PRE: memberID is non-empty
+// POST: Returns the size or sql.ErrNoRows
+func (m *mockBeltInventoryStore) GetMemberSize(_ context.Context, memberID string) (inventoryDomain.MemberSize, error) {
+	size, ok := m.sizes[memberID]
+	if !ok {
+		return inventoryDomain.MemberSize{}, sql.ErrNoRows
+	}
+	return inventoryDomain.MemberSize{MemberID: memberID, Size: size}, nil
+}
+
+// SaveMemberSize implements inventory.Store for testing.
+// PRE: size has been validated
+// POST: Size is upserted
+func (m *mockBeltInventoryStore) SaveMemberSize(_ context.Context, size inventoryDomain.MemberSize) error {
+	m.sizes[size.MemberID] = size.Size
+	return nil
+}
+
+// newBeltInventoryTestStores returns grading stores with an empty belt inventory.
+func newBeltInventoryTestStores() (*Stores, *mockBeltInventoryStore) {
+	s := newGradingProposalTestStores()
+	inv := newMockBeltInventoryStore()
+	s.BeltInventoryStore = inv
+	return s, inv
+}
+
+// TestHandleBeltInventory verifies stock can be set and listed with low items flagged.
+func TestHandleBeltInventory(t *testing.T) {
+	var inv *mockBeltInventoryStore
+	stores, inv = newBeltInventoryTestStores()
+
+	for _, tt := range []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "belt", body: `{"Kind":"belt","Color":"blue","Size":"A2","Quantity":1,"LowStock":2}`, want: http.StatusOK},
+		{name: "stripe tape", body: `{"Kind":"stripe","Color":"white","Quantity":40,"LowStock":10}`, want: http.StatusOK},
+		{name: "belt without size", body: `{"Kind":"belt","Color":"blue","Quantity":1}`, want: http.StatusBadRequest},
+		{name: "negative stock", body: `{"Kind":"stripe","Color":"white","Quantity":-1}`, want: http.StatusBadRequest},
+		{name: "unknown field", body: `{"Kind":"stripe","Color":"white","Colour":"red"}`, want: http.StatusBadRequest},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleBeltInventory(rec, authRequest("POST", "/api/grading/inventory", tt.body, adminSession))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	// Re-saving the same colour and size updates it in place.
+	rec := httptest.NewRecorder()
+	handleBeltInventory(rec, authRequest("POST", "/api/grading/inventory", `{"Kind":"belt","Color":"blue","Size":"A2","Quantity":2,"LowStock":2}`, adminSession))
+	if len(inv.items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(inv.items))
+	}
+
+	rec = httptest.NewRecorder()
+	handleBeltInventory(rec, authRequest("GET", "/api/grading/inventory", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var view beltInventoryView
+	json.NewDecoder(rec.Body).Decode(&view)
+	if len(view.Items) != 2 || view.Items[0].Kind != inventoryDomain.KindBelt {
+		t.Errorf("expected the belt then the tape, got %+v", view.Items)
+	}
+	if len(view.Low) != 1 || view.Low[0].Color != gradingDomain.BeltBlue {
+		t.Errorf("expected the blue belt to be low, got %+v", view.Low)
+	}
+
+	rec = httptest.NewRecorder()
+	handleBeltInventory(rec, authRequest("GET", "/api/grading/inventory", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+}
+
+// TestBeltInventory_ApprovalTakesStock verifies approving a proposal takes the member's
+// belt from stock and the grading day's pick list counts it.
+func TestBeltInventory_ApprovalTakesStock(t *testing.T) {
+	var inv *mockBeltInventoryStore
+	stores, inv = newBeltInventoryTestStores()
+	inv.SaveItem(context.Background(), inventoryDomain.Item{ID: "blue-a2", Kind: inventoryDomain.KindBelt, Color: gradingDomain.BeltBlue, Size: "A2", Quantity: 3, LowStock: 2})
+
+	rec := httptest.NewRecorder()
+	handleBeltSizes(rec, authRequest("POST", "/api/grading/belt-sizes", `{"MemberID":"member-001","Size":"A2"}`, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("belt size: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	handleBeltSizes(rec, authRequest("POST", "/api/grading/belt-sizes", `{"MemberID":"member-001","Size":"XL"}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("bad size: expected 400, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleBeltSizes(rec, authRequest("POST", "/api/grading/belt-sizes", `{"MemberID":"nobody","Size":"A2"}`, adminSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown member: expected 404, got %d", rec.Code)
+	}
+
+	err := stores.GradingProposalStore.Save(context.Background(), gradingDomain.Proposal{
+		ID: "p1", MemberID: "member-001", TargetBelt: gradingDomain.BeltBlue, EventID: "grading-day",
+		ProposedBy: coachSession.AccountID, Status: gradingDomain.ProposalScheduled, CreatedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("save proposal: %v", err)
+	}
+	rec = httptest.NewRecorder()
+	handleGradingDecide(rec, authRequest("POST", "/api/grading/proposals/decide", `{"ProposalID":"p1","Decision":"approve"}`, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("approve: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if item, _ := inv.GetItem(context.Background(), inventoryDomain.KindBelt, gradingDomain.BeltBlue, "A2"); item.Quantity != 2 {
+		t.Errorf("expected 2 blue A2 belts left, got %d", item.Quantity)
+	}
+
+	rec = httptest.NewRecorder()
+	handleGradingPickList(rec, authRequest("GET", "/api/grading/pick-list?event_id=grading-day", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("pick list: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var list projections.GradingPickListResult
+	json.NewDecoder(rec.Body).Decode(&list)
+	if len(list.Lines) != 1 || list.Lines[0].Count != 1 || list.Lines[0].Remaining != 2 || !list.Lines[0].Low {
+		t.Errorf("expected one low blue A2 line with 2 remaining, got %+v", list.Lines)
+	}
+
+	for _, tt := range []struct {
+		name string
+		url  string
+		want int
+	}{
+		{name: "no event", url: "/api/grading/pick-list", want: http.StatusBadRequest},
+		{name: "unknown event", url: "/api/grading/pick-list?event_id=nope", want: http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleGradingPickList(rec, authRequest("GET", tt.url, "", adminSession))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+}
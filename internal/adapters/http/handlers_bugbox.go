@@ -10,8 +10,10 @@ import (
 	"path/filepath"
 	"strings"
 
+	"workshop/internal/adapters/http/apierror"
 	"workshop/internal/adapters/http/middleware"
 	"workshop/internal/application/orchestrators"
+	permissionDomain "workshop/internal/domain/permission"
 )
 
 // handleBugBoxSubmit handles POST /api/admin/bugbox.
@@ -21,18 +23,13 @@ import (
 // POST: GitHub issue created, submission persisted; returns JSON with issue URL.
 func handleBugBoxSubmit(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
 	ctx := r.Context()
-	sess, ok := middleware.GetSessionFromContext(ctx)
+	sess, ok := requirePermission(w, r, permissionDomain.ActionBugBoxSubmit)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
-		return
-	}
-	if !middleware.IsCoachOrAdmin(ctx) {
-		http.Error(w, "Forbidden", http.StatusForbidden)
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "bugbox") {
@@ -41,14 +38,14 @@ func handleBugBoxSubmit(w http.ResponseWriter, r *http.Request) {
 
 	const maxUpload = 6 << 20 // 6 MB to allow for 5 MB image + form overhead
 	if err := r.ParseMultipartForm(maxUpload); err != nil {
-		http.Error(w, "request too large or malformed", http.StatusBadRequest)
+		apierror.Validation(w, "request too large or malformed")
 		return
 	}
 
 	summary := strings.TrimSpace(r.FormValue("summary"))
 	description := strings.TrimSpace(r.FormValue("description"))
 	if summary == "" || description == "" {
-		http.Error(w, "summary and description are required", http.StatusBadRequest)
+		apierror.Validation(w, "summary and description are required")
 		return
 	}
 
@@ -58,18 +55,18 @@ func handleBugBoxSubmit(w http.ResponseWriter, r *http.Request) {
 		defer file.Close()
 		const maxScreenshot = 5 << 20 // 5 MB
 		if header.Size > maxScreenshot {
-			http.Error(w, "screenshot must be under 5 MB", http.StatusBadRequest)
+			apierror.Validation(w, "screenshot must be under 5 MB")
 			return
 		}
 		ct := header.Header.Get("Content-Type")
 		if ct != "image/png" && ct != "image/jpeg" && ct != "image/webp" && ct != "image/gif" {
-			http.Error(w, "screenshot must be an image (png, jpeg, webp, gif)", http.StatusBadRequest)
+			apierror.Validation(w, "screenshot must be an image (png, jpeg, webp, gif)")
 			return
 		}
 		submissionID := generateID()
 		screenshotPath = "bugbox/" + submissionID + "-screenshot"
 		if saveErr := saveBugBoxScreenshot(screenshotPath, file); saveErr != nil {
-			slog.Error("bugbox_screenshot_save_failed", "error", saveErr.Error())
+			slog.ErrorContext(ctx, "bugbox_screenshot_save_failed", "error", saveErr.Error())
 			screenshotPath = ""
 		}
 	}
@@ -92,6 +89,7 @@ func handleBugBoxSubmit(w http.ResponseWriter, r *http.Request) {
 		Role:             sess.Role,
 		ImpersonatedRole: impersonatedRole,
 		ScreenshotPath:   screenshotPath,
+		ReporterID:       bugReporterID(sess),
 		GitHubToken:      os.Getenv("GITHUB_TOKEN"),
 		GitHubRepo:       os.Getenv("GITHUB_REPO"),
 	}
@@ -102,8 +100,9 @@ func handleBugBoxSubmit(w http.ResponseWriter, r *http.Request) {
 
 	result, err := orchestrators.ExecuteSubmitBugBox(ctx, cmd, deps)
 	if err != nil {
-		slog.Error("bugbox_submit_failed", "error", err.Error())
-		http.Error(w, "Failed to submit bug report: "+err.Error(), http.StatusInternalServerError)
+		slog.ErrorContext(ctx, "bugbox_submit_failed", "error", err.Error())
+		// Staff-only endpoint: the cause (usually missing GitHub settings) is shown so they can act on it.
+		apierror.Write(w, apierror.CodeInternal, "Failed to submit bug report: "+err.Error(), nil)
 		return
 	}
 
@@ -122,18 +121,18 @@ func handleBugBoxSubmit(w http.ResponseWriter, r *http.Request) {
 // POST: returns image bytes or 404.
 func handleBugBoxScreenshot(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
 	ctx := r.Context()
 	sess2, ok2 := middleware.GetSessionFromContext(ctx)
 	if !ok2 {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	if !middleware.IsAdmin(ctx) {
-		http.Error(w, "admin only", http.StatusForbidden)
+		apierror.Forbidden(w, "admin only")
 		return
 	}
 	if !requireFeatureAPI(w, r, sess2, "bugbox") {
@@ -142,23 +141,23 @@ func handleBugBoxScreenshot(w http.ResponseWriter, r *http.Request) {
 
 	id := r.URL.Query().Get("id")
 	if id == "" {
-		http.Error(w, "id is required", http.StatusBadRequest)
+		apierror.Validation(w, "id is required")
 		return
 	}
 
 	sub, err := stores.BugBoxStore.GetByID(ctx, id)
 	if err != nil {
-		http.Error(w, "not found", http.StatusNotFound)
+		apierror.NotFound(w, "not found")
 		return
 	}
 	if sub.ScreenshotPath == "" {
-		http.Error(w, "no screenshot for this submission", http.StatusNotFound)
+		apierror.NotFound(w, "no screenshot for this submission")
 		return
 	}
 
 	data, err := loadBugBoxScreenshot(sub.ScreenshotPath)
 	if err != nil {
-		http.Error(w, "screenshot not available", http.StatusNotFound)
+		apierror.NotFound(w, "screenshot not available")
 		return
 	}
 
@@ -8,10 +8,12 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"sort"
 	"strings"
 	"testing"
 
 	"workshop/internal/adapters/http/middleware"
+	bugboxStore "workshop/internal/adapters/storage/bugbox"
 	bugboxDomain "workshop/internal/domain/bugbox"
 	featureflagDomain "workshop/internal/domain/featureflag"
 )
@@ -20,6 +22,7 @@ import (
 
 type mockBugBoxStore struct {
 	submissions map[string]bugboxDomain.Submission
+	comments    []bugboxDomain.Comment
 	saveErr     error
 }
 
@@ -47,6 +50,41 @@ func (m *mockBugBoxStore) GetByID(_ context.Context, id string) (bugboxDomain.Su
 	return bugboxDomain.Submission{}, fmt.Errorf("not found: %s", id)
 }
 
+// List implements bugbox.Store for testing.
+// PRE: none
+// POST: returns submissions matching the filter, newest first
+func (m *mockBugBoxStore) List(_ context.Context, filter bugboxStore.ListFilter) ([]bugboxDomain.Submission, error) {
+	var list []bugboxDomain.Submission
+	for _, s := range m.submissions {
+		if (filter.Status == "" || s.Status == filter.Status) && (filter.ReporterID == "" || s.ReporterID == filter.ReporterID) {
+			list = append(list, s)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].SubmittedAt.After(list[j].SubmittedAt) })
+	return list, nil
+}
+
+// SaveComment implements bugbox.Store for testing.
+// PRE: c has been validated
+// POST: comment is appended
+func (m *mockBugBoxStore) SaveComment(_ context.Context, c bugboxDomain.Comment) error {
+	m.comments = append(m.comments, c)
+	return nil
+}
+
+// ListComments implements bugbox.Store for testing.
+// PRE: submissionID is non-empty
+// POST: returns the submission's comments, oldest first
+func (m *mockBugBoxStore) ListComments(_ context.Context, submissionID string) ([]bugboxDomain.Comment, error) {
+	var list []bugboxDomain.Comment
+	for _, c := range m.comments {
+		if c.SubmissionID == submissionID {
+			list = append(list, c)
+		}
+	}
+	return list, nil
+}
+
 // multipartBody builds a multipart/form-data body with the given fields.
 func multipartBody(fields map[string]string) (*bytes.Buffer, string) {
 	var buf bytes.Buffer
@@ -0,0 +1,287 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	accountStore "workshop/internal/adapters/storage/account"
+	bugboxStore "workshop/internal/adapters/storage/bugbox"
+	"workshop/internal/application/orchestrators"
+	accountDomain "workshop/internal/domain/account"
+	bugboxDomain "workshop/internal/domain/bugbox"
+	notificationDomain "workshop/internal/domain/notification"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// bugReport is one report as listed by GET /api/admin/bugbox/list.
+type bugReport struct {
+	bugboxDomain.Submission
+	StatusLabel   string
+	ReporterEmail string
+	AssigneeEmail string
+}
+
+// bugTriageRequest is the body of POST /api/admin/bugbox/triage.
+type bugTriageRequest struct {
+	SubmissionID string `json:"SubmissionID"`
+	Status       string `json:"Status"`      // empty leaves the status as it is
+	DuplicateOf  string `json:"DuplicateOf"` // closes the report as a duplicate of this one
+	Assign       bool   `json:"Assign"`      // apply AssigneeID, even when empty
+	AssigneeID   string `json:"AssigneeID"`
+}
+
+// bugCommentRequest is the body of POST /api/admin/bugbox/comments.
+type bugCommentRequest struct {
+	SubmissionID string `json:"SubmissionID"`
+	Body         string `json:"Body"`
+}
+
+// bugReporterID returns the account a report is filed under: the admin's own account when
+// they are impersonating someone, so replies reach the person who actually saw the bug.
+func bugReporterID(sess middleware.Session) string {
+	if sess.IsImpersonating() {
+		return sess.RealAccountID
+	}
+	return sess.AccountID
+}
+
+// canSeeBugReport reports whether the session may read and comment on a report:
+// admins see every report, everyone else only their own.
+func canSeeBugReport(sess middleware.Session, sub bugboxDomain.Submission) bool {
+	return sess.Role == accountDomain.RoleAdmin || sub.ReporterID == bugReporterID(sess)
+}
+
+// bugAccountEmail returns an account's email, or "" when it cannot be found.
+func bugAccountEmail(ctx context.Context, cache map[string]string, id string) string {
+	if id == "" {
+		return ""
+	}
+	if email, ok := cache[id]; ok {
+		return email
+	}
+	a, err := stores.AccountStore.GetByID(ctx, id)
+	if err == nil {
+		cache[id] = a.Email
+	}
+	return cache[id]
+}
+
+// handleBugBoxPage handles GET /bugbox
+// Lists the caller's bug reports with their status and replies. Admins see every report
+// and can triage them.
+func handleBugBoxPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requirePermissionPage(w, r, permissionDomain.ActionBugBoxSubmit)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "bugbox") {
+		return
+	}
+	data := map[string]any{"IsAdmin": sess.Role == accountDomain.RoleAdmin, "Statuses": bugboxDomain.ValidStatuses}
+	if sess.Role == accountDomain.RoleAdmin {
+		admins, err := stores.AccountStore.List(r.Context(), accountStore.ListFilter{Limit: 1000, Role: accountDomain.RoleAdmin})
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		data["Admins"] = admins
+	}
+	renderTemplate(w, r, "bugbox.html", data)
+}
+
+// handleBugBoxList handles GET /api/admin/bugbox/list?status=
+// Returns bug reports, newest first. Admins get every report; everyone else gets their own.
+func handleBugBoxList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionBugBoxSubmit)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "bugbox") {
+		return
+	}
+	ctx := r.Context()
+	filter := bugboxStore.ListFilter{Status: r.URL.Query().Get("status"), Limit: 500}
+	if filter.Status != "" && !bugboxDomain.IsValidStatus(filter.Status) {
+		apierror.Validation(w, bugboxDomain.ErrInvalidStatus.Error())
+		return
+	}
+	if sess.Role != accountDomain.RoleAdmin {
+		filter.ReporterID = bugReporterID(sess)
+	}
+	subs, err := stores.BugBoxStore.List(ctx, filter)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	emails := map[string]string{}
+	reports := make([]bugReport, 0, len(subs))
+	for _, s := range subs {
+		reports = append(reports, bugReport{
+			Submission:    s,
+			StatusLabel:   bugboxDomain.StatusLabel(s.Status),
+			ReporterEmail: bugAccountEmail(ctx, emails, s.ReporterID),
+			AssigneeEmail: bugAccountEmail(ctx, emails, s.AssigneeID),
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reports)
+}
+
+// handleBugBoxTriage handles POST /api/admin/bugbox/triage
+// Changes a report's status or assignee, or closes it as a duplicate of another report.
+// The reporter is notified when the status changes. Admin only.
+func handleBugBoxTriage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "bugbox") {
+		return
+	}
+	var input bugTriageRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	ctx := r.Context()
+	result, err := orchestrators.ExecuteTriageBugReport(ctx, orchestrators.TriageBugReportInput{
+		SubmissionID: input.SubmissionID,
+		Status:       input.Status,
+		DuplicateOf:  input.DuplicateOf,
+		Assign:       input.Assign,
+		AssigneeID:   input.AssigneeID,
+		TriagedBy:    sess.AccountID,
+	}, orchestrators.TriageBugReportDeps{
+		BugBoxStore:  stores.BugBoxStore,
+		AccountStore: stores.AccountStore,
+		Now:          timeNow,
+	})
+	if errors.Is(err, orchestrators.ErrBugReportNotFound) {
+		apierror.NotFound(w, err.Error())
+		return
+	}
+	if errors.Is(err, orchestrators.ErrAssigneeNotAdmin) || errors.Is(err, bugboxDomain.ErrInvalidStatus) ||
+		errors.Is(err, bugboxDomain.ErrSameStatus) || errors.Is(err, bugboxDomain.ErrBackToNew) ||
+		errors.Is(err, bugboxDomain.ErrSelfDuplicate) {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	sub := result.Submission
+	if result.StatusChanged && sub.ReporterID != "" && sub.ReporterID != sess.AccountID {
+		title := "Your bug report is now " + bugboxDomain.StatusLabel(sub.Status)
+		if sub.DuplicateOf != "" {
+			title = "Your bug report was already reported"
+		}
+		notify(ctx, orchestrators.NotifyInput{
+			AccountIDs: []string{sub.ReporterID},
+			Kind:       notificationDomain.KindBugReportUpdated,
+			Title:      title,
+			Body:       sub.Summary,
+			Link:       "/bugbox",
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sub)
+}
+
+// handleBugBoxComments handles GET/POST for /api/admin/bugbox/comments
+// GET ?submission_id= lists a report's comments, oldest first. POST adds one and tells the
+// reporter, or the assigned admin when the reporter replies. Admins and the reporter only.
+func handleBugBoxComments(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requirePermission(w, r, permissionDomain.ActionBugBoxSubmit)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "bugbox") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		sub, err := stores.BugBoxStore.GetByID(ctx, r.URL.Query().Get("submission_id"))
+		if err != nil || !canSeeBugReport(sess, sub) {
+			apierror.NotFound(w, orchestrators.ErrBugReportNotFound.Error())
+			return
+		}
+		comments, err := stores.BugBoxStore.ListComments(ctx, sub.ID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if comments == nil {
+			comments = []bugboxDomain.Comment{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(comments)
+
+	case "POST":
+		var input bugCommentRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		sub, err := stores.BugBoxStore.GetByID(ctx, input.SubmissionID)
+		if err != nil || !canSeeBugReport(sess, sub) {
+			apierror.NotFound(w, orchestrators.ErrBugReportNotFound.Error())
+			return
+		}
+		author := sess.AccountID
+		if sub.ReporterID == bugReporterID(sess) {
+			author = sub.ReporterID
+		}
+		result, err := orchestrators.ExecuteCommentOnBugReport(ctx, orchestrators.CommentOnBugReportInput{
+			SubmissionID: sub.ID,
+			AuthorID:     author,
+			Body:         input.Body,
+		}, orchestrators.CommentOnBugReportDeps{
+			BugBoxStore: stores.BugBoxStore,
+			GenerateID:  generateID,
+			Now:         timeNow,
+		})
+		if errors.Is(err, bugboxDomain.ErrEmptyComment) || errors.Is(err, bugboxDomain.ErrCommentTooLong) {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if result.NotifyID != "" {
+			notify(ctx, orchestrators.NotifyInput{
+				AccountIDs: []string{result.NotifyID},
+				Kind:       notificationDomain.KindBugReportUpdated,
+				Title:      "New reply on a bug report",
+				Body:       sub.Summary,
+				Link:       "/bugbox",
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(result.Comment)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
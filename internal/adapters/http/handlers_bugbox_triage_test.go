@@ -0,0 +1,161 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	accountDomain "workshop/internal/domain/account"
+	bugboxDomain "workshop/internal/domain/bugbox"
+	notificationDomain "workshop/internal/domain/notification"
+)
+
+// newBugTriageTestStores returns stores with two new reports from coach-001 (bug-1, bug-2)
+// and one from admin-001 (bug-3).
+func newBugTriageTestStores() (*Stores, *mockBugBoxStore) {
+	s := newNotificationTestStores()
+	ctx := context.Background()
+	s.AccountStore.Save(ctx, accountDomain.Account{ID: "admin-001", Email: "admin@test.com", Role: accountDomain.RoleAdmin})
+	s.AccountStore.Save(ctx, accountDomain.Account{ID: "coach-001", Email: "coach@test.com", Role: accountDomain.RoleCoach})
+	bugs := &mockBugBoxStore{submissions: map[string]bugboxDomain.Submission{}}
+	submitted := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	for i, reporter := range []string{"coach-001", "coach-001", "admin-001"} {
+		id := fmt.Sprintf("bug-%d", i+1)
+		bugs.submissions[id] = bugboxDomain.Submission{ID: id, Summary: "Broken " + id, Description: "It broke", ReporterID: reporter,
+			Status: bugboxDomain.StatusNew, SubmittedAt: submitted.Add(time.Duration(i) * time.Hour)}
+	}
+	s.BugBoxStore = bugs
+	return s, bugs
+}
+
+// bugNotifications returns the bug report notifications sent to an account.
+func bugNotifications(accountID string) []notificationDomain.Notification {
+	var list []notificationDomain.Notification
+	for _, n := range stores.NotificationStore.(*mockNotificationStore).items {
+		if n.AccountID == accountID && n.Kind == notificationDomain.KindBugReportUpdated {
+			list = append(list, n)
+		}
+	}
+	return list
+}
+
+// TestHandleBugBoxTriage_NotifiesReporter verifies an admin can acknowledge, assign and
+// close reports as duplicates, and the reporter hears about each status change.
+func TestHandleBugBoxTriage_NotifiesReporter(t *testing.T) {
+	var bugs *mockBugBoxStore
+	stores, bugs = newBugTriageTestStores()
+
+	for _, tt := range []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "acknowledge and assign", body: `{"SubmissionID":"bug-1","Status":"ack","Assign":true,"AssigneeID":"admin-001"}`, want: http.StatusOK},
+		{name: "duplicate", body: `{"SubmissionID":"bug-2","DuplicateOf":"bug-1"}`, want: http.StatusOK},
+		{name: "assign to a coach", body: `{"SubmissionID":"bug-1","Assign":true,"AssigneeID":"coach-001"}`, want: http.StatusBadRequest},
+		{name: "back to new", body: `{"SubmissionID":"bug-1","Status":"new"}`, want: http.StatusBadRequest},
+		{name: "unknown report", body: `{"SubmissionID":"bug-9","Status":"fixed"}`, want: http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleBugBoxTriage(rec, authRequest("POST", "/api/admin/bugbox/triage", tt.body, adminSession))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	if b := bugs.submissions["bug-1"]; b.Status != bugboxDomain.StatusAcknowledged || b.AssigneeID != "admin-001" {
+		t.Errorf("expected bug-1 acknowledged and assigned, got %+v", b)
+	}
+	if b := bugs.submissions["bug-2"]; b.DuplicateOf != "bug-1" || b.Status != bugboxDomain.StatusWontFix {
+		t.Errorf("expected bug-2 closed as a duplicate, got %+v", b)
+	}
+	if got := len(bugNotifications("coach-001")); got != 2 {
+		t.Errorf("expected the reporter notified twice, got %d", got)
+	}
+
+	// An admin triaging their own report is not notified.
+	rec := httptest.NewRecorder()
+	handleBugBoxTriage(rec, authRequest("POST", "/api/admin/bugbox/triage", `{"SubmissionID":"bug-3","Status":"fixed"}`, adminSession))
+	if rec.Code != http.StatusOK || len(bugNotifications("admin-001")) != 0 {
+		t.Errorf("expected no self-notification, got %d and %d notifications", rec.Code, len(bugNotifications("admin-001")))
+	}
+}
+
+// TestHandleBugBoxList_ReportersSeeTheirOwn verifies coaches only see and comment on their own
+// reports, while admins see every report.
+func TestHandleBugBoxList_ReportersSeeTheirOwn(t *testing.T) {
+	var bugs *mockBugBoxStore
+	stores, bugs = newBugTriageTestStores()
+	b1 := bugs.submissions["bug-1"]
+	b1.AssigneeID = "admin-001"
+	bugs.submissions["bug-1"] = b1
+
+	for _, tt := range []struct {
+		name string
+		url  string
+		sess middleware.Session
+		want int
+	}{
+		{name: "admin", url: "/api/admin/bugbox/list", sess: adminSession, want: 3},
+		{name: "admin fixed only", url: "/api/admin/bugbox/list?status=fixed", sess: adminSession, want: 0},
+		{name: "coach", url: "/api/admin/bugbox/list", sess: coachSession, want: 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleBugBoxList(rec, authRequest("GET", tt.url, "", tt.sess))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+			var reports []bugReport
+			json.NewDecoder(rec.Body).Decode(&reports)
+			if len(reports) != tt.want {
+				t.Errorf("expected %d reports, got %d", tt.want, len(reports))
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	handleBugBoxList(rec, authRequest("GET", "/api/admin/bugbox/list", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member list: expected 403, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleBugBoxTriage(rec, authRequest("POST", "/api/admin/bugbox/triage", `{"SubmissionID":"bug-1","Status":"fixed"}`, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach triage: expected 403, got %d", rec.Code)
+	}
+
+	// The reporter's reply reaches the assigned admin; an admin's reply reaches the reporter.
+	rec = httptest.NewRecorder()
+	handleBugBoxComments(rec, authRequest("POST", "/api/admin/bugbox/comments", `{"SubmissionID":"bug-1","Body":"Happens on my phone too"}`, coachSession))
+	if rec.Code != http.StatusCreated || len(bugNotifications("admin-001")) != 1 {
+		t.Errorf("reporter comment: expected 201 and the assignee notified, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	handleBugBoxComments(rec, authRequest("POST", "/api/admin/bugbox/comments", `{"SubmissionID":"bug-1","Body":"Fixed in the next release"}`, adminSession))
+	if rec.Code != http.StatusCreated || len(bugNotifications("coach-001")) != 1 {
+		t.Errorf("admin comment: expected 201 and the reporter notified, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	handleBugBoxComments(rec, authRequest("GET", "/api/admin/bugbox/comments?submission_id=bug-1", "", coachSession))
+	var comments []bugboxDomain.Comment
+	json.NewDecoder(rec.Body).Decode(&comments)
+	if rec.Code != http.StatusOK || len(comments) != 2 {
+		t.Errorf("expected both comments, got %d: %+v", rec.Code, comments)
+	}
+
+	for _, method := range []string{"GET", "POST"} {
+		rec = httptest.NewRecorder()
+		handleBugBoxComments(rec, authRequest(method, "/api/admin/bugbox/comments?submission_id=bug-3", `{"SubmissionID":"bug-3","Body":"Hi"}`, coachSession))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("%s someone else's report: expected 404, got %d", method, rec.Code)
+		}
+	}
+}
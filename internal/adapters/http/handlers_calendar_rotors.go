@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"workshop/internal/adapters/http/apierror"
 	"workshop/internal/adapters/http/middleware"
 	rotorDomain "workshop/internal/domain/rotor"
 )
@@ -14,7 +15,7 @@ func handleCalendarRotors(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	sess, ok := middleware.GetSessionFromContext(ctx)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "calendar") {
@@ -22,7 +23,7 @@ func handleCalendarRotors(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method != "GET" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
@@ -0,0 +1,218 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	"workshop/internal/domain/celebration"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// celebrationDefaultDays is how far ahead GET /api/celebrations looks when no days are given.
+const celebrationDefaultDays = 7
+
+// celebrationResponse is one entry of GET /api/celebrations.
+type celebrationResponse struct {
+	celebration.Celebration
+	Label string `json:"Label"`
+}
+
+// celebrationProfileResponse is the body returned by /api/celebrations/profile.
+type celebrationProfileResponse struct {
+	MemberID    string `json:"MemberID"`
+	DateOfBirth string `json:"DateOfBirth"` // YYYY-MM-DD, or "" when not given
+	JoinedOn    string `json:"JoinedOn"`    // YYYY-MM-DD, or "" to count from the first check-in
+	OptOut      bool   `json:"OptOut"`
+}
+
+// celebrationProfileRequest is the body of PUT /api/celebrations/profile.
+type celebrationProfileRequest struct {
+	DateOfBirth string `json:"DateOfBirth"`
+	JoinedOn    string `json:"JoinedOn"` // admin only; members' requests keep the recorded date
+	OptOut      bool   `json:"OptOut"`
+}
+
+// CelebrationsDeps finds the celebrations falling on one day, for the celebration jobs.
+func CelebrationsDeps(s *Stores) orchestrators.FindCelebrations {
+	return func(ctx context.Context, day time.Time) ([]celebration.Celebration, error) {
+		return projections.QueryGetCelebrations(ctx, projections.GetCelebrationsQuery{From: day}, projections.GetCelebrationsDeps{
+			MemberStore:     s.MemberStore,
+			ProfileStore:    s.CelebrationStore,
+			AttendanceStore: s.AttendanceStore,
+		})
+	}
+}
+
+// CelebrationNoticeDeps wires the celebration notice worker.
+func CelebrationNoticeDeps(s *Stores, now func() time.Time) orchestrators.PublishCelebrationNoticeDeps {
+	return orchestrators.PublishCelebrationNoticeDeps{
+		NoticeStore:      s.NoticeStore,
+		FindCelebrations: CelebrationsDeps(s),
+		Now:              now,
+	}
+}
+
+// CelebrationEmailDeps wires the celebration email worker: the birthday and training_milestone
+// library templates, with the member's next class filled in from their program's timetable.
+func CelebrationEmailDeps(s *Stores, baseURL string, key []byte, now func() time.Time) orchestrators.SendCelebrationEmailsDeps {
+	nextClass := NextClassDates(s, now)
+	return orchestrators.SendCelebrationEmailsDeps{
+		Store:            s.CelebrationStore,
+		FindCelebrations: CelebrationsDeps(s),
+		NextClassDate: func(ctx context.Context, memberID string) string {
+			m, err := s.MemberStore.GetByID(ctx, memberID)
+			if err != nil {
+				return ""
+			}
+			return nextClass(ctx, m.Program)
+		},
+		Send: TemplatedEmailDeps(s, baseURL, key, now),
+		Now:  now,
+	}
+}
+
+// handleCelebrations handles GET /api/celebrations?days=
+// Lists birthdays, training anniversaries and class milestones from today for the dashboard
+// widget, over the next 7 days by default. Members who opted out are never listed.
+func handleCelebrations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionMembersView)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "celebrations") {
+		return
+	}
+	days := celebrationDefaultDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > celebration.MaxWindowDays {
+			apierror.Validation(w, celebration.ErrInvalidWindow.Error())
+			return
+		}
+		days = n
+	}
+
+	list, err := projections.QueryGetCelebrations(r.Context(), projections.GetCelebrationsQuery{From: timeNow(), Days: days}, projections.GetCelebrationsDeps{
+		MemberStore:     stores.MemberStore,
+		ProfileStore:    stores.CelebrationStore,
+		AttendanceStore: stores.AttendanceStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	result := make([]celebrationResponse, 0, len(list))
+	for _, c := range list {
+		result = append(result, celebrationResponse{Celebration: c, Label: c.Label()})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// handleCelebrationProfile handles GET/PUT for /api/celebrations/profile?member_id=
+// Members read and change their own date of birth and opt-out. Admins may name any member
+// and also set the join date anniversaries count from.
+func handleCelebrationProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "celebrations") {
+		return
+	}
+	memberID := r.URL.Query().Get("member_id")
+	if memberID != "" && sess.Role != "admin" {
+		apierror.Forbidden(w, "only admins can change another member's celebrations")
+		return
+	}
+	if memberID == "" {
+		m, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID)
+		if err != nil {
+			apierror.NotFound(w, "member not found")
+			return
+		}
+		memberID = m.ID
+	} else if _, err := stores.MemberStore.GetByID(ctx, memberID); err != nil {
+		apierror.NotFound(w, "member not found")
+		return
+	}
+	profile, err := stores.CelebrationStore.GetProfile(ctx, memberID)
+	if err != nil {
+		profile = celebration.Profile{MemberID: memberID}
+	}
+
+	switch r.Method {
+	case "GET":
+	case "PUT":
+		if sess.IsImpersonating() {
+			apierror.Forbidden(w, "stop impersonating to change celebrations")
+			return
+		}
+		var input celebrationProfileRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		dob, err := parseOptionalDate(input.DateOfBirth)
+		if err != nil {
+			apierror.Validation(w, "DateOfBirth must be YYYY-MM-DD")
+			return
+		}
+		profile.DateOfBirth, profile.OptOut = dob, input.OptOut
+		if sess.Role == "admin" {
+			if profile.JoinedOn, err = parseOptionalDate(input.JoinedOn); err != nil {
+				apierror.Validation(w, "JoinedOn must be YYYY-MM-DD")
+				return
+			}
+		}
+		profile.UpdatedBy, profile.UpdatedAt = sess.AccountID, timeNow()
+		if err := profile.Validate(timeNow()); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err := stores.CelebrationStore.SaveProfile(ctx, profile); err != nil {
+			internalError(w, err)
+			return
+		}
+	default:
+		apierror.MethodNotAllowed(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(celebrationProfileResponse{
+		MemberID:    profile.MemberID,
+		DateOfBirth: formatOptionalDate(profile.DateOfBirth),
+		JoinedOn:    formatOptionalDate(profile.JoinedOn),
+		OptOut:      profile.OptOut,
+	})
+}
+
+// parseOptionalDate parses a YYYY-MM-DD date, treating "" as no date.
+func parseOptionalDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
+
+// formatOptionalDate formats a date as YYYY-MM-DD, or "" when it is zero.
+func formatOptionalDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,133 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	celebrationDomain "workshop/internal/domain/celebration"
+	memberDomain "workshop/internal/domain/member"
+)
+
+type mockCelebrationStore struct {
+	profiles map[string]celebrationDomain.Profile
+	sent     map[string]bool
+}
+
+// GetProfile returns a member's profile.
+// PRE: memberID is non-empty
+// POST: Returns the profile or an error
+func (m *mockCelebrationStore) GetProfile(_ context.Context, memberID string) (celebrationDomain.Profile, error) {
+	p, ok := m.profiles[memberID]
+	if !ok {
+		return celebrationDomain.Profile{}, errors.New("not found")
+	}
+	return p, nil
+}
+
+// SaveProfile stores a member's profile, replacing any earlier one.
+// PRE: value is valid
+// POST: The profile is stored
+func (m *mockCelebrationStore) SaveProfile(_ context.Context, value celebrationDomain.Profile) error {
+	m.profiles[value.MemberID] = value
+	return nil
+}
+
+// ListProfiles returns every profile.
+// PRE: none
+// POST: Returns the profiles ordered by member ID
+func (m *mockCelebrationStore) ListProfiles(_ context.Context) ([]celebrationDomain.Profile, error) {
+	var list []celebrationDomain.Profile
+	for _, p := range m.profiles {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].MemberID < list[j].MemberID })
+	return list, nil
+}
+
+// MarkEmailSent records a celebration email.
+// PRE: memberID, kind and date are non-empty
+// POST: Returns true the first time
+func (m *mockCelebrationStore) MarkEmailSent(_ context.Context, memberID, kind, date string, _ time.Time) (bool, error) {
+	key := memberID + "/" + kind + "/" + date
+	if m.sent[key] {
+		return false, nil
+	}
+	m.sent[key] = true
+	return true, nil
+}
+
+// TestCelebrations verifies members set their own birthday and opt-out, only admins reach
+// other members or the join date, and staff see the week's celebrations without opted-out members.
+func TestCelebrations(t *testing.T) {
+	stores = newFullStores()
+	stores.CelebrationStore = &mockCelebrationStore{profiles: map[string]celebrationDomain.Profile{}, sent: map[string]bool{}}
+	timeNow = func() time.Time { return time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", AccountID: memberSession.AccountID, Name: "Marcus", Email: memberSession.Email, Program: "adults", Status: memberDomain.StatusActive})
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m2", Name: "Yuki", Email: "yuki@test.com", Program: "adults", Status: memberDomain.StatusActive})
+
+	profile := func(path, body string, sess middleware.Session) (int, celebrationProfileResponse) {
+		t.Helper()
+		method := "GET"
+		if body != "" {
+			method = "PUT"
+		}
+		rec := httptest.NewRecorder()
+		handleCelebrationProfile(rec, authRequest(method, path, body, sess))
+		var got celebrationProfileResponse
+		json.NewDecoder(rec.Body).Decode(&got)
+		return rec.Code, got
+	}
+
+	if code, got := profile("/api/celebrations/profile", `{"DateOfBirth":"1990-03-04","JoinedOn":"2020-01-01","OptOut":false}`, memberSession); code != http.StatusOK || got.DateOfBirth != "1990-03-04" || got.JoinedOn != "" {
+		t.Fatalf("member save: %d %+v; want the birthday saved and the join date ignored", code, got)
+	}
+	if code, _ := profile("/api/celebrations/profile", `{"DateOfBirth":"2030-01-01"}`, memberSession); code != http.StatusBadRequest {
+		t.Errorf("future birthday: expected 400, got %d", code)
+	}
+	if code, _ := profile("/api/celebrations/profile?member_id=m2", "", memberSession); code != http.StatusForbidden {
+		t.Errorf("member reading another member: expected 403, got %d", code)
+	}
+	if code, got := profile("/api/celebrations/profile?member_id=m2", `{"JoinedOn":"2025-03-03","OptOut":true}`, adminSession); code != http.StatusOK || got.JoinedOn != "2025-03-03" || !got.OptOut {
+		t.Fatalf("admin save: %d %+v", code, got)
+	}
+
+	list := func() []celebrationResponse {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handleCelebrations(rec, authRequest("GET", "/api/celebrations?days=7", "", coachSession))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("list: expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got []celebrationResponse
+		json.NewDecoder(rec.Body).Decode(&got)
+		return got
+	}
+	if got := list(); len(got) != 1 || got[0].MemberID != "m1" || got[0].Label != "Birthday" || got[0].Date != "2026-03-04" {
+		t.Errorf("celebrations = %+v, want Marcus's birthday only", got)
+	}
+
+	profile("/api/celebrations/profile?member_id=m2", `{"JoinedOn":"2025-03-03","OptOut":false}`, adminSession)
+	if got := list(); len(got) != 2 || got[0].MemberID != "m2" || got[0].Label != "1 year training" {
+		t.Errorf("after opting back in = %+v, want Yuki's anniversary first", got)
+	}
+
+	rec := httptest.NewRecorder()
+	handleCelebrations(rec, authRequest("GET", "/api/celebrations?days=60", "", coachSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("60 days: expected 400, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleCelebrations(rec, authRequest("GET", "/api/celebrations", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member list: expected 403, got %d", rec.Code)
+	}
+}
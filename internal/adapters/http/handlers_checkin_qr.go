@@ -0,0 +1,233 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/adapters/qrcode"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	kioskDomain "workshop/internal/domain/kiosk"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// qrCheckInMember is the member summary returned to the kiosk after a scan.
+type qrCheckInMember struct {
+	ID      string
+	Name    string
+	Program string
+	Status  string
+}
+
+// checkInQRRequest is the body of POST /api/checkin/qr.
+type checkInQRRequest struct {
+	Token string `json:"Token"`
+}
+
+// handleCheckInQR handles POST /api/checkin/qr
+// Checks the member in a scanned QR code into the class that matches today's schedule.
+// A valid code with no open class returns 409 with the member so the kiosk can offer the class list.
+func handleCheckInQR(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "kiosk") {
+		return
+	}
+	if !permissionAllowed(ctx, sess, permissionDomain.ActionAttendanceKiosk) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+	var input checkInQRRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+
+	locationID := sessionLocationID(ctx)
+	classes, err := projections.QueryGetTodaysClassesAtLocation(ctx, timeNow(), locationID, projections.GetTodaysClassesDeps{
+		ScheduleStore:  stores.ScheduleStore,
+		TermStore:      stores.TermStore,
+		HolidayStore:   stores.HolidayStore,
+		ClassTypeStore: stores.ClassTypeStore,
+		ProgramStore:   stores.ProgramStore,
+		ChangeStore:    stores.OccurrenceChangeStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	slots := make([]kioskDomain.ClassSlot, 0, len(classes))
+	classNames := make(map[string]string, len(classes))
+	for _, c := range classes {
+		slots = append(slots, kioskDomain.ClassSlot{ScheduleID: c.ScheduleID, ProgramType: c.ProgramType, StartTime: c.StartTime, EndTime: c.EndTime})
+		classNames[c.ScheduleID] = c.ClassTypeName
+	}
+
+	deps := orchestrators.QRCheckInDeps{
+		Key:             checkInQRKey,
+		MemberStore:     stores.MemberStore,
+		AttendanceStore: stores.AttendanceStore,
+		ScheduleStore:   stores.ScheduleStore,
+		TopicDeps:       attendanceTopicDeps(),
+		PackDeps:        classPackDeps(),
+		EligibilityDeps: classEligibilityDeps(),
+		GenerateID:      generateID,
+		Now:             timeNow,
+	}
+	if stores.OccurrenceChangeStore != nil {
+		deps.ChangeStore = stores.OccurrenceChangeStore
+	}
+	if stores.GradingRecordStore != nil && stores.GradingConfigStore != nil {
+		deps.InferStripeDeps = &orchestrators.InferStripeDeps{
+			MemberStore:         stores.MemberStore,
+			AttendanceStore:     stores.AttendanceStore,
+			EstimatedHoursStore: stores.EstimatedHoursStore,
+			GradingRecordStore:  stores.GradingRecordStore,
+			GradingConfigStore:  stores.GradingConfigStore,
+			StockStore:          stores.BeltInventoryStore,
+		}
+	}
+	result, err := orchestrators.ExecuteQRCheckIn(ctx, orchestrators.QRCheckInInput{
+		Token:      input.Token,
+		Classes:    slots,
+		LocationID: locationID,
+	}, deps)
+
+	m := result.Member
+	member := qrCheckInMember{ID: m.ID, Name: m.Name, Program: m.Program, Status: m.Status}
+	switch {
+	case errors.Is(err, kioskDomain.ErrInvalidCheckInToken):
+		apierror.Validation(w, "Check-in code not recognised")
+		return
+	case errors.Is(err, orchestrators.ErrQRCheckInArchived), isPrerequisiteError(err):
+		apierror.Forbidden(w, err.Error())
+		return
+	case errors.Is(err, orchestrators.ErrQRCheckInNoClass):
+		// The kiosk falls back to the class picker for Member, so it rides alongside the error.
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(apierror.Status(apierror.CodeConflict))
+		json.NewEncoder(w).Encode(struct {
+			apierror.Response
+			Member qrCheckInMember
+		}{
+			Response: apierror.Response{Error: apierror.Detail{Code: apierror.CodeConflict, Message: err.Error(), Fields: map[string]string{}}},
+			Member:   member,
+		})
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+	if !result.AlreadyCheckedIn {
+		publishAttendance(eventAttendanceCheckIn, attendanceEvent{MemberID: m.ID, ScheduleID: result.ClassSlot.ScheduleID})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"Member":           member,
+		"AttendanceID":     result.Attendance.ID,
+		"ScheduleID":       result.ClassSlot.ScheduleID,
+		"ClassTypeName":    classNames[result.ClassSlot.ScheduleID],
+		"StartTime":        result.ClassSlot.StartTime,
+		"AlreadyCheckedIn": result.AlreadyCheckedIn,
+	})
+}
+
+// handleMemberCheckInQR handles GET /api/members/checkin-qr?member_id=&format=svg|png
+// Renders the member's personal check-in QR code for the profile page or printing.
+func handleMemberCheckInQR(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	memberID, ok := viewableMemberID(w, r, sess, r.URL.Query().Get("member_id"))
+	if !ok {
+		return
+	}
+
+	code, err := qrcode.Encode(kioskDomain.SignCheckInToken(checkInQRKey, memberID))
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "private, no-store")
+	if r.URL.Query().Get("format") == "png" {
+		qrImage, err := code.PNG(8)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Content-Disposition", `attachment; filename="checkin-qr.png"`)
+		w.Write(qrImage)
+		return
+	}
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Write([]byte(code.SVG()))
+}
+
+// checkInQREmailRequest is the body of POST /api/members/checkin-qr/email.
+type checkInQREmailRequest struct {
+	MemberID string `json:"MemberID"`
+}
+
+// handleMemberCheckInQREmail handles POST /api/members/checkin-qr/email
+// Emails the member their check-in QR code.
+func handleMemberCheckInQREmail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	var input checkInQREmailRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	memberID, ok := viewableMemberID(w, r, sess, input.MemberID)
+	if !ok {
+		return
+	}
+	if emailSender == nil {
+		apierror.Unavailable(w, "email sending is not configured")
+		return
+	}
+
+	err := orchestrators.ExecuteEmailCheckInQR(r.Context(), orchestrators.EmailCheckInQRInput{MemberID: memberID}, orchestrators.EmailCheckInQRDeps{
+		Key:         checkInQRKey,
+		MemberStore: stores.MemberStore,
+		EmailSender: emailSender,
+		FromAddress: emailFromAddress,
+		ReplyTo:     emailReplyTo,
+	})
+	if errors.Is(err, orchestrators.ErrQRCheckInNoEmail) {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"Status": "sent"})
+}
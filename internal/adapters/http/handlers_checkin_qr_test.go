@@ -0,0 +1,79 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kioskDomain "workshop/internal/domain/kiosk"
+)
+
+// seedCheckInQR seeds members m1 (linked to memberSession) and m2 with a fixed QR key.
+func seedCheckInQR(t *testing.T) {
+	t.Helper()
+	seedMessageThread(t)
+	checkInQRKey = []byte("0123456789abcdef0123456789abcdef")
+}
+
+// TestHandleCheckInQR_MemberForbidden verifies only kiosk operators can submit scans.
+func TestHandleCheckInQR_MemberForbidden(t *testing.T) {
+	seedCheckInQR(t)
+	body := `{"Token":"` + kioskDomain.SignCheckInToken(checkInQRKey, "m1") + `"}`
+
+	rec := httptest.NewRecorder()
+	handleCheckInQR(rec, authRequest("POST", "/api/checkin/qr", body, memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleCheckInQR_Outcomes verifies forged codes are rejected and valid codes without a class return the member.
+func TestHandleCheckInQR_Outcomes(t *testing.T) {
+	seedCheckInQR(t)
+
+	rec := httptest.NewRecorder()
+	handleCheckInQR(rec, authRequest("POST", "/api/checkin/qr", `{"Token":"wsci1.m1.forged"}`, coachSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("forged token: expected 400, got %d", rec.Code)
+	}
+
+	// No schedules are seeded, so no class is open.
+	body := `{"Token":"` + kioskDomain.SignCheckInToken(checkInQRKey, "m1") + `"}`
+	rec = httptest.NewRecorder()
+	handleCheckInQR(rec, authRequest("POST", "/api/checkin/qr", body, coachSession))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("no class: expected 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result struct {
+		Member qrCheckInMember
+	}
+	json.NewDecoder(rec.Body).Decode(&result)
+	if result.Member.ID != "m1" || result.Member.Name != "Marcus" {
+		t.Errorf("expected member m1 in response, got %+v", result.Member)
+	}
+}
+
+// TestHandleMemberCheckInQR_Access verifies members get their own code and staff may render anyone's.
+func TestHandleMemberCheckInQR_Access(t *testing.T) {
+	seedCheckInQR(t)
+
+	rec := httptest.NewRecorder()
+	handleMemberCheckInQR(rec, authRequest("GET", "/api/members/checkin-qr", "", memberSession))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/svg+xml" || !strings.HasPrefix(rec.Body.String(), "<svg") {
+		t.Fatalf("own code: got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	rec = httptest.NewRecorder()
+	handleMemberCheckInQR(rec, authRequest("GET", "/api/members/checkin-qr?member_id=m2", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("other member: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMemberCheckInQR(rec, authRequest("GET", "/api/members/checkin-qr?member_id=m2&format=png", "", coachSession))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/png" {
+		t.Errorf("staff png: got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+}
@@ -0,0 +1,107 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	accountStore "workshop/internal/adapters/storage/account"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	accountDomain "workshop/internal/domain/account"
+)
+
+// liveClassesDeps wires the live headcount projection.
+func liveClassesDeps(s *Stores) projections.GetLiveClassesDeps {
+	return projections.GetLiveClassesDeps{
+		TodaysClasses: projections.GetTodaysClassesDeps{
+			ScheduleStore:  s.ScheduleStore,
+			TermStore:      s.TermStore,
+			HolidayStore:   s.HolidayStore,
+			ClassTypeStore: s.ClassTypeStore,
+			ProgramStore:   s.ProgramStore,
+			ChangeStore:    s.OccurrenceChangeStore,
+		},
+		AttendanceStore: s.AttendanceStore,
+	}
+}
+
+// handleLiveClasses handles GET /api/classes/live
+// Lists the classes running now at the caller's location, with how many people are
+// checked in and whether that is over the class type's mat capacity. Staff only.
+func handleLiveClasses(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireStaff(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "attendance") {
+		return
+	}
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	classes, err := projections.QueryGetLiveClasses(r.Context(), timeNow(), sessionLocationID(r.Context()), liveClassesDeps(stores))
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(classes)
+}
+
+// CapacityAlertsDeps wires the overcrowding alert worker to the stores and notification channels.
+func CapacityAlertsDeps(s *Stores, now func() time.Time) orchestrators.SendCapacityAlertsDeps {
+	return orchestrators.SendCapacityAlertsDeps{
+		LiveClasses: func(ctx context.Context, at time.Time) ([]orchestrators.LiveClass, error) {
+			classes, err := projections.QueryGetLiveClasses(ctx, at, "", liveClassesDeps(s))
+			if err != nil {
+				return nil, err
+			}
+			live := make([]orchestrators.LiveClass, 0, len(classes))
+			for _, c := range classes {
+				live = append(live, orchestrators.LiveClass{
+					ScheduleID:    c.ScheduleID,
+					ClassTypeID:   c.ClassTypeID,
+					ClassTypeName: c.ClassTypeName,
+					StartTime:     c.StartTime,
+					EndTime:       c.EndTime,
+					CoachID:       c.CoachID,
+					Headcount:     c.Headcount,
+					MatCapacity:   c.MatCapacity,
+				})
+			}
+			return live, nil
+		},
+		AdminAccountIDs: func(ctx context.Context) ([]string, error) {
+			admins, err := s.AccountStore.List(ctx, accountStore.ListFilter{Role: accountDomain.RoleAdmin, Limit: 1000})
+			if err != nil {
+				return nil, err
+			}
+			ids := []string{}
+			for _, a := range admins {
+				if a.Status == accountDomain.StatusActive {
+					ids = append(ids, a.ID)
+				}
+			}
+			return ids, nil
+		},
+		AlertStore:  s.NotificationStore,
+		NoticeStore: s.NoticeStore,
+		Notify: orchestrators.NotifyDeps{
+			NotificationStore: s.NotificationStore,
+			AccountStore:      s.AccountStore,
+			EmailSender:       emailSender,
+			EmailFrom:         emailFromAddress,
+			EmailReplyTo:      emailReplyTo,
+			PushStore:         s.NotificationStore,
+			PushSender:        pushSender,
+			GenerateID:        generateID,
+			Now:               now,
+		},
+		GenerateID: generateID,
+		Now:        now,
+	}
+}
@@ -0,0 +1,31 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/application/projections"
+)
+
+// TestHandleLiveClasses_StaffOnly verifies members cannot see live headcounts while staff get a list.
+func TestHandleLiveClasses_StaffOnly(t *testing.T) {
+	stores = newFullStores()
+
+	rec := httptest.NewRecorder()
+	handleLiveClasses(rec, authRequest("GET", "/api/classes/live", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleLiveClasses(rec, authRequest("GET", "/api/classes/live", "", coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("coach: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var live []projections.LiveClassResult
+	if err := json.NewDecoder(rec.Body).Decode(&live); err != nil || live == nil {
+		t.Errorf("expected a JSON list, got %q (%v)", rec.Body.String(), err)
+	}
+}
@@ -0,0 +1,239 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	permissionDomain "workshop/internal/domain/permission"
+	scheduleDomain "workshop/internal/domain/schedule"
+)
+
+// classChangesDefaultDays is how far ahead GET /api/classes/changes looks when no range is given.
+const classChangesDefaultDays = 60
+
+// handleClassChangesPage handles GET /attendance/changes
+// Lets a coach cancel, move or hand one class to a substitute, add a one-off session, and undo any of them.
+func handleClassChangesPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requirePermissionPage(w, r, permissionDomain.ActionClassesChange)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "attendance") {
+		return
+	}
+
+	locationID := requestLocationID(r)
+	classes, err := listClassOptions(r.Context(), locationID)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	classTypes, err := stores.ClassTypeStore.List(r.Context())
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	renderTemplate(w, r, "class_changes.html", map[string]interface{}{
+		"Title":         "Class Changes",
+		"Classes":       classes,
+		"ClassTypes":    classTypes,
+		"LocationID":    locationID,
+		"Today":         timeNow().Format("2006-01-02"),
+		"RecentDays":    scheduleDomain.RecentAttendeeDays,
+		"MaxReason":     scheduleDomain.MaxReasonLength,
+		"MaxSubstitute": scheduleDomain.MaxSubstituteLength,
+		"MaxMat":        scheduleDomain.MaxMatLength,
+	})
+}
+
+// classChangeRequest is the body of POST /api/classes/changes.
+type classChangeRequest struct {
+	ScheduleID  string `json:"ScheduleID"`
+	ClassDate   string `json:"ClassDate"`
+	Kind        string `json:"Kind"` // cancelled, substitute, moved or added
+	Substitute  string `json:"Substitute"`
+	Reason      string `json:"Reason"`
+	ClassTypeID string `json:"ClassTypeID"` // added sessions only
+	StartTime   string `json:"StartTime"`   // moved and added
+	EndTime     string `json:"EndTime"`     // moved and added
+	LocationID  string `json:"LocationID"`  // added sessions only
+	Mat         string `json:"Mat"`         // moved and added
+}
+
+// classChangeView is an occurrence change with the class it applies to.
+type classChangeView struct {
+	scheduleDomain.OccurrenceChange
+	ClassLabel string
+}
+
+// handleClassChanges handles GET/POST/DELETE /api/classes/changes
+// GET ?from=&to= lists cancellations, substitutes, moves and added sessions, today to 60 days out by default.
+// POST records one change; a notice is published and, unless a session is being added, recent attendees are emailed.
+// DELETE ?id= undoes a change. Coaches and admins only.
+func handleClassChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "attendance") {
+		return
+	}
+	if !permissionAllowed(ctx, sess, permissionDomain.ActionClassesChange) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		today := timeNow().Format("2006-01-02")
+		from, to := r.URL.Query().Get("from"), r.URL.Query().Get("to")
+		if from == "" {
+			from = today
+		}
+		if to == "" {
+			to = timeNow().AddDate(0, 0, classChangesDefaultDays).Format("2006-01-02")
+		}
+		for _, d := range []string{from, to} {
+			if _, err := time.Parse("2006-01-02", d); err != nil {
+				apierror.Validation(w, "from and to must be YYYY-MM-DD")
+				return
+			}
+		}
+		changes, err := stores.OccurrenceChangeStore.ListByDateRange(ctx, from, to)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		classes, err := listClassOptions(ctx, "")
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		labels := make(map[string]string, len(classes))
+		for _, c := range classes {
+			labels[c.ID] = c.Label
+		}
+		views := make([]classChangeView, 0, len(changes))
+		for _, c := range changes {
+			label := labels[c.ScheduleID]
+			if c.IsAdded() {
+				label = c.ClassTypeID
+				if ct, err := stores.ClassTypeStore.GetByID(ctx, c.ClassTypeID); err == nil {
+					label = ct.Name
+				}
+				label = c.StartTime + "–" + c.EndTime + " · " + label
+			}
+			views = append(views, classChangeView{OccurrenceChange: c, ClassLabel: label})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(views)
+
+	case "POST":
+		var input classChangeRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		if input.ScheduleID != "" && input.Kind != scheduleDomain.ChangeAdded {
+			if _, err := stores.ScheduleStore.GetByID(ctx, input.ScheduleID); err != nil {
+				apierror.NotFound(w, "class not found")
+				return
+			}
+		}
+
+		result, err := orchestrators.ExecuteChangeClassOccurrence(ctx, orchestrators.ChangeClassOccurrenceInput{
+			ScheduleID:  input.ScheduleID,
+			ClassDate:   input.ClassDate,
+			Kind:        input.Kind,
+			Substitute:  input.Substitute,
+			Reason:      input.Reason,
+			ClassTypeID: input.ClassTypeID,
+			StartTime:   input.StartTime,
+			EndTime:     input.EndTime,
+			LocationID:  input.LocationID,
+			Mat:         input.Mat,
+			CreatedBy:   sess.AccountID,
+		}, orchestrators.ChangeClassOccurrenceDeps{
+			ChangeStore:     stores.OccurrenceChangeStore,
+			ScheduleStore:   stores.ScheduleStore,
+			ClassTypeStore:  stores.ClassTypeStore,
+			AttendanceStore: stores.AttendanceStore,
+			NoticeStore:     stores.NoticeStore,
+			EmailStore:      stores.EmailStore,
+			MemberLookup:    &memberLookupAdapter{},
+			GenerateID:      generateID,
+			Now:             timeNow,
+		})
+		switch {
+		case errors.Is(err, orchestrators.ErrOccurrenceAlreadyChanged):
+			apierror.Conflict(w, err.Error())
+			return
+		case errors.Is(err, orchestrators.ErrUnknownClassType):
+			apierror.NotFound(w, err.Error())
+			return
+		case errors.Is(err, orchestrators.ErrOccurrenceInPast), isOccurrenceChangeInvalid(err):
+			apierror.Validation(w, err.Error())
+			return
+		case err != nil:
+			internalError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(result)
+
+	case "DELETE":
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			apierror.Validation(w, "id is required")
+			return
+		}
+		if _, err := stores.OccurrenceChangeStore.GetByID(ctx, id); err != nil {
+			apierror.NotFound(w, "class change not found")
+			return
+		}
+		change, err := orchestrators.ExecuteRevertClassOccurrence(ctx, id, orchestrators.RevertClassOccurrenceDeps{
+			ChangeStore: stores.OccurrenceChangeStore,
+			NoticeStore: stores.NoticeStore,
+			EmailStore:  stores.EmailStore,
+			Now:         timeNow,
+		})
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(change)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// isOccurrenceChangeInvalid reports whether err is one of OccurrenceChange's validation errors.
+func isOccurrenceChangeInvalid(err error) bool {
+	for _, target := range []error{
+		scheduleDomain.ErrEmptyScheduleID, scheduleDomain.ErrInvalidClassDate, scheduleDomain.ErrWrongDay,
+		scheduleDomain.ErrInvalidChange, scheduleDomain.ErrEmptySubstitute, scheduleDomain.ErrSubstituteTooLong,
+		scheduleDomain.ErrReasonTooLong, scheduleDomain.ErrEmptyCreatedBy, scheduleDomain.ErrNothingMoved,
+		scheduleDomain.ErrEmptyClassTypeID, scheduleDomain.ErrInvalidTime, scheduleDomain.ErrMatTooLong,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	noticeDomain "workshop/internal/domain/notice"
+	scheduleDomain "workshop/internal/domain/schedule"
+)
+
+type mockOccurrenceChangeStore struct {
+	changes map[string]scheduleDomain.OccurrenceChange
+}
+
+// GetByID implements schedule.OccurrenceChangeStore for testing.
+// PRE: id is non-empty
+// POST: Returns the change or sql.ErrNoRows
+func (m *mockOccurrenceChangeStore) GetByID(_ context.Context, id string) (scheduleDomain.OccurrenceChange, error) {
+	c, ok := m.changes[id]
+	if !ok {
+		return scheduleDomain.OccurrenceChange{}, sql.ErrNoRows
+	}
+	return c, nil
+}
+
+// GetByOccurrence implements schedule.OccurrenceChangeStore for testing.
+// PRE: scheduleID and classDate are non-empty
+// POST: Returns the occurrence's change or sql.ErrNoRows
+func (m *mockOccurrenceChangeStore) GetByOccurrence(_ context.Context, scheduleID, classDate string) (scheduleDomain.OccurrenceChange, error) {
+	for _, c := range m.changes {
+		if c.ScheduleID == scheduleID && c.ClassDate == classDate {
+			return c, nil
+		}
+	}
+	return scheduleDomain.OccurrenceChange{}, sql.ErrNoRows
+}
+
+// Save implements schedule.OccurrenceChangeStore for testing.
+// PRE: value has been validated
+// POST: Change is upserted
+func (m *mockOccurrenceChangeStore) Save(_ context.Context, value scheduleDomain.OccurrenceChange) error {
+	m.changes[value.ID] = value
+	return nil
+}
+
+// Delete implements schedule.OccurrenceChangeStore for testing.
+// PRE: id is non-empty
+// POST: Change is removed
+func (m *mockOccurrenceChangeStore) Delete(_ context.Context, id string) error {
+	delete(m.changes, id)
+	return nil
+}
+
+// ListByDateRange implements schedule.OccurrenceChangeStore for testing.
+// PRE: from and to are YYYY-MM-DD
+// POST: Returns changes dated within the range
+func (m *mockOccurrenceChangeStore) ListByDateRange(_ context.Context, from, to string) ([]scheduleDomain.OccurrenceChange, error) {
+	var list []scheduleDomain.OccurrenceChange
+	for _, c := range m.changes {
+		if c.ClassDate >= from && c.ClassDate <= to {
+			list = append(list, c)
+		}
+	}
+	return list, nil
+}
+
+// TestHandleClassChanges verifies a coach cancels today's class with a notice, cannot change it twice, and can undo it.
+func TestHandleClassChanges(t *testing.T) {
+	stores = newFullStores()
+	stores.OccurrenceChangeStore = &mockOccurrenceChangeStore{changes: map[string]scheduleDomain.OccurrenceChange{}}
+	ctx := context.Background()
+	today := time.Now()
+	date := today.Format("2006-01-02")
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s1", ClassTypeID: "ct1", Day: strings.ToLower(today.Weekday().String()), StartTime: "18:00", EndTime: "19:00"})
+
+	rec := httptest.NewRecorder()
+	handleClassChanges(rec, authRequest("POST", "/api/classes/changes",
+		`{"ScheduleID":"s1","ClassDate":"`+date+`","Kind":"cancelled","Reason":"Coach is unwell."}`, coachSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("cancel: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result orchestrators.ChangeClassOccurrenceResult
+	json.NewDecoder(rec.Body).Decode(&result)
+	if result.Recipients != 0 || result.Change.EmailID != "" {
+		t.Errorf("expected no email when nobody attended recently, got %+v", result)
+	}
+	n, err := stores.NoticeStore.GetByID(ctx, result.Change.NoticeID)
+	if err != nil || n.Status != noticeDomain.StatusPublished || !strings.Contains(n.Content, "Coach is unwell.") {
+		t.Errorf("expected a published notice with the reason, got %+v (%v)", n, err)
+	}
+
+	rec = httptest.NewRecorder()
+	handleClassChanges(rec, authRequest("POST", "/api/classes/changes",
+		`{"ScheduleID":"s1","ClassDate":"`+date+`","Kind":"substitute","Substitute":"Coach Sam"}`, coachSession))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("second change: expected 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleClassChanges(rec, authRequest("GET", "/api/classes/changes", "", coachSession))
+	var views []classChangeView
+	json.NewDecoder(rec.Body).Decode(&views)
+	if len(views) != 1 || views[0].ID != result.Change.ID || views[0].ClassLabel == "" {
+		t.Errorf("expected the change listed with its class, got %+v", views)
+	}
+
+	rec = httptest.NewRecorder()
+	handleClassChanges(rec, authRequest("DELETE", "/api/classes/changes?id="+result.Change.ID, "", coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("undo: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if _, err := stores.OccurrenceChangeStore.GetByID(ctx, result.Change.ID); err == nil {
+		t.Error("expected the change removed")
+	}
+}
+
+// TestHandleClassChanges_Errors verifies permission and request validation.
+func TestHandleClassChanges_Errors(t *testing.T) {
+	stores = newFullStores()
+	stores.OccurrenceChangeStore = &mockOccurrenceChangeStore{changes: map[string]scheduleDomain.OccurrenceChange{}}
+	stores.ScheduleStore.Save(context.Background(), scheduleDomain.Schedule{ID: "s1", ClassTypeID: "ct1", Day: scheduleDomain.Monday, StartTime: "18:00", EndTime: "19:00"})
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		sess   middleware.Session
+		want   int
+	}{
+		{"member forbidden", "GET", "/api/classes/changes", "", memberSession, http.StatusForbidden},
+		{"bad range", "GET", "/api/classes/changes?from=soon", "", coachSession, http.StatusBadRequest},
+		{"unknown class", "POST", "/api/classes/changes", `{"ScheduleID":"nope","ClassDate":"2999-01-07","Kind":"cancelled"}`, coachSession, http.StatusNotFound},
+		{"wrong weekday", "POST", "/api/classes/changes", `{"ScheduleID":"s1","ClassDate":"2999-01-08","Kind":"cancelled"}`, coachSession, http.StatusBadRequest},
+		{"past class", "POST", "/api/classes/changes", `{"ScheduleID":"s1","ClassDate":"2026-01-05","Kind":"cancelled"}`, coachSession, http.StatusBadRequest},
+		{"substitute missing", "POST", "/api/classes/changes", `{"ScheduleID":"s1","ClassDate":"2999-01-07","Kind":"substitute"}`, coachSession, http.StatusBadRequest},
+		{"undo unknown", "DELETE", "/api/classes/changes?id=nope", "", coachSession, http.StatusNotFound},
+		{"wrong method", "PUT", "/api/classes/changes", "", coachSession, http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleClassChanges(rec, authRequest(tt.method, tt.url, tt.body, tt.sess))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	feedbackDomain "workshop/internal/domain/feedback"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// classFeedbackDefaultDays is how far back GET /api/feedback/report looks when no range is given.
+const classFeedbackDefaultDays = 28
+
+// classFeedbackRequest is the body of POST /api/feedback.
+type classFeedbackRequest struct {
+	AttendanceID string `json:"AttendanceID"`
+	Score        int    `json:"Score"`
+	Comment      string `json:"Comment"`
+}
+
+// handleClassFeedback handles POST /api/feedback
+// Rates one of the signed-in member's classes within 24 hours of checking in. The rating is
+// stored against the class, not the member, and each check-in can be rated once.
+func handleClassFeedback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "class_feedback") {
+		return
+	}
+	if sess.IsImpersonating() {
+		apierror.Forbidden(w, "stop impersonating to rate a class")
+		return
+	}
+	var input classFeedbackRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	member, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID)
+	if err != nil {
+		apierror.NotFound(w, "member not found")
+		return
+	}
+
+	_, err = orchestrators.ExecuteRateClass(ctx, orchestrators.RateClassInput{
+		MemberID:     member.ID,
+		AttendanceID: input.AttendanceID,
+		Score:        input.Score,
+		Comment:      input.Comment,
+	}, orchestrators.RateClassDeps{
+		AttendanceStore: stores.AttendanceStore,
+		ScheduleStore:   stores.ScheduleStore,
+		OccurrenceStore: stores.OccurrenceChangeStore,
+		FeedbackStore:   stores.ClassFeedbackStore,
+		GenerateID:      generateID,
+		Now:             timeNow,
+	})
+	switch {
+	case errors.Is(err, feedbackDomain.ErrAlreadyRated):
+		apierror.Conflict(w, err.Error())
+		return
+	case errors.Is(err, orchestrators.ErrRatingAttendanceNotFound):
+		apierror.NotFound(w, err.Error())
+		return
+	case errors.Is(err, feedbackDomain.ErrRatingClosed),
+		errors.Is(err, feedbackDomain.ErrNoClass),
+		errors.Is(err, feedbackDomain.ErrInvalidScore),
+		errors.Is(err, feedbackDomain.ErrCommentTooLong),
+		errors.Is(err, feedbackDomain.ErrAttendanceRequired):
+		apierror.Validation(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{"status": "rated"})
+}
+
+// handleClassFeedbackPending handles GET /api/feedback/pending
+// Lists the signed-in member's classes from the last 24 hours they can still rate.
+// Accounts without a member profile get an empty list.
+func handleClassFeedbackPending(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "class_feedback") {
+		return
+	}
+	pending := []projections.PendingClassRating{}
+	if member, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID); err == nil {
+		pending, err = projections.QueryGetPendingClassRatings(ctx, projections.GetPendingClassRatingsQuery{
+			MemberID: member.ID,
+			Now:      timeNow(),
+		}, projections.GetPendingClassRatingsDeps{
+			AttendanceStore: stores.AttendanceStore,
+			FeedbackStore:   stores.ClassFeedbackStore,
+			ScheduleStore:   stores.ScheduleStore,
+			ClassTypeStore:  stores.ClassTypeStore,
+		})
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pending)
+}
+
+// handleClassFeedbackPage handles GET /feedback
+// Shows the anonymised class feedback report: coaches see their own classes, admins every coach's.
+func handleClassFeedbackPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requirePermissionPage(w, r, permissionDomain.ActionFeedbackView)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "class_feedback") {
+		return
+	}
+	renderTemplate(w, r, "feedback_report.html", map[string]interface{}{
+		"Title":        "Class Feedback",
+		"IsAdmin":      sess.Role == "admin",
+		"MinRatings":   feedbackDomain.MinRatingsForAverage,
+		"MinClassSize": feedbackDomain.MinClassSizeForComments,
+	})
+}
+
+// handleClassFeedbackReport handles GET /api/feedback/report?from=&to=&coach_id=
+// Aggregates ratings by class type, coach and rotor topic over the last 28 days by default.
+// Coaches only ever see their own classes; admins may pick a coach or see everyone.
+func handleClassFeedbackReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := requirePermission(w, r, permissionDomain.ActionFeedbackView)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "class_feedback") {
+		return
+	}
+	q := r.URL.Query()
+	from, to := q.Get("from"), q.Get("to")
+	if to == "" {
+		to = timeNow().Format("2006-01-02")
+	}
+	if from == "" {
+		from = timeNow().AddDate(0, 0, -classFeedbackDefaultDays).Format("2006-01-02")
+	}
+	for _, d := range []string{from, to} {
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			apierror.Validation(w, "from and to must be YYYY-MM-DD")
+			return
+		}
+	}
+	coachID := q.Get("coach_id")
+	if sess.Role != "admin" {
+		coachID = sess.AccountID
+	}
+
+	report, err := projections.QueryGetClassFeedbackReport(ctx, projections.GetClassFeedbackReportQuery{
+		From:    from,
+		To:      to,
+		CoachID: coachID,
+	}, projections.GetClassFeedbackReportDeps{
+		FeedbackStore:   stores.ClassFeedbackStore,
+		AttendanceStore: stores.AttendanceStore,
+		ClassTypeStore:  stores.ClassTypeStore,
+		AccountStore:    stores.AccountStore,
+		MemberStore:     stores.MemberStore,
+		TopicStore:      stores.RotorStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
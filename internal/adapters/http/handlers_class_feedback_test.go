@@ -0,0 +1,128 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	feedbackStore "workshop/internal/adapters/storage/feedback"
+	"workshop/internal/application/projections"
+	attendanceDomain "workshop/internal/domain/attendance"
+	classTypeDomain "workshop/internal/domain/classtype"
+	feedbackDomain "workshop/internal/domain/feedback"
+	memberDomain "workshop/internal/domain/member"
+	scheduleDomain "workshop/internal/domain/schedule"
+)
+
+type mockClassFeedbackStore struct {
+	ratings []feedbackDomain.Rating
+}
+
+// Save appends a rating, refusing a second for the same attendance.
+// PRE: value is valid
+// POST: The rating is stored
+func (m *mockClassFeedbackStore) Save(_ context.Context, value feedbackDomain.Rating) error {
+	for _, r := range m.ratings {
+		if r.AttendanceID == value.AttendanceID {
+			return feedbackDomain.ErrAlreadyRated
+		}
+	}
+	m.ratings = append(m.ratings, value)
+	return nil
+}
+
+// GetByAttendanceID returns the rating given for a check-in.
+// PRE: attendanceID is non-empty
+// POST: Returns the rating or an error
+func (m *mockClassFeedbackStore) GetByAttendanceID(_ context.Context, attendanceID string) (feedbackDomain.Rating, error) {
+	for _, r := range m.ratings {
+		if r.AttendanceID == attendanceID {
+			return r, nil
+		}
+	}
+	return feedbackDomain.Rating{}, errors.New("not found")
+}
+
+// List returns the ratings for the filter's coach.
+// PRE: none
+// POST: Returns matching ratings
+func (m *mockClassFeedbackStore) List(_ context.Context, filter feedbackStore.ListFilter) ([]feedbackDomain.Rating, error) {
+	var result []feedbackDomain.Rating
+	for _, r := range m.ratings {
+		if filter.CoachID == "" || r.CoachID == filter.CoachID {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// TestClassFeedback verifies a member can rate a class they checked in to once, and the
+// coach sees it in their report while members can't see the report at all.
+func TestClassFeedback(t *testing.T) {
+	stores = newFullStores()
+	ratings := &mockClassFeedbackStore{}
+	stores.ClassFeedbackStore = ratings
+	ctx := context.Background()
+	checkIn := time.Now().Add(-time.Hour)
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", AccountID: memberSession.AccountID, Name: "Marcus", Email: memberSession.Email, Program: "adults", Status: memberDomain.StatusActive})
+	stores.ClassTypeStore.Save(ctx, classTypeDomain.ClassType{ID: "ct1", Name: "Fundamentals"})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s1", ClassTypeID: "ct1", CoachID: coachSession.AccountID, StartTime: "18:00", EndTime: "19:00"})
+	stores.AttendanceStore.Save(ctx, attendanceDomain.Attendance{ID: "a1", MemberID: "m1", ScheduleID: "s1", ClassDate: checkIn.Format("2006-01-02"), CheckInTime: checkIn})
+
+	pending := func() []projections.PendingClassRating {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handleClassFeedbackPending(rec, authRequest("GET", "/api/feedback/pending", "", memberSession))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("pending: expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var list []projections.PendingClassRating
+		json.NewDecoder(rec.Body).Decode(&list)
+		return list
+	}
+	if list := pending(); len(list) != 1 || list[0].AttendanceID != "a1" || list[0].ClassName != "Fundamentals" {
+		t.Fatalf("pending = %+v, want the Fundamentals check-in", list)
+	}
+
+	rate := func(body string) int {
+		rec := httptest.NewRecorder()
+		handleClassFeedback(rec, authRequest("POST", "/api/feedback", body, memberSession))
+		return rec.Code
+	}
+	if code := rate(`{"AttendanceID":"a1","Score":6}`); code != http.StatusBadRequest {
+		t.Errorf("score 6: expected 400, got %d", code)
+	}
+	if code := rate(`{"AttendanceID":"a1","Score":4,"Comment":"Good drilling"}`); code != http.StatusCreated {
+		t.Fatalf("rate: expected 201, got %d", code)
+	}
+	if code := rate(`{"AttendanceID":"a1","Score":5}`); code != http.StatusConflict {
+		t.Errorf("second rating: expected 409, got %d", code)
+	}
+	if code := rate(`{"AttendanceID":"nope","Score":5}`); code != http.StatusNotFound {
+		t.Errorf("unknown check-in: expected 404, got %d", code)
+	}
+	if list := pending(); len(list) != 0 {
+		t.Errorf("pending after rating = %+v, want none", list)
+	}
+
+	rec := httptest.NewRecorder()
+	handleClassFeedbackReport(rec, authRequest("GET", "/api/feedback/report?coach_id=someone-else", "", coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("coach report: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var report projections.ClassFeedbackReport
+	json.NewDecoder(rec.Body).Decode(&report)
+	if report.Ratings != 1 || report.Average != nil || len(report.Comments) != 0 || report.WithheldComments != 1 {
+		t.Errorf("coach report = %+v; want their one rating, no average and the comment withheld", report)
+	}
+
+	rec = httptest.NewRecorder()
+	handleClassFeedbackReport(rec, authRequest("GET", "/api/feedback/report", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member report: expected 403, got %d", rec.Code)
+	}
+}
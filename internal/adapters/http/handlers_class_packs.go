@@ -0,0 +1,175 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	classPackDomain "workshop/internal/domain/classpack"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// classPackRequest is the body of POST /api/class-packs.
+type classPackRequest struct {
+	MemberID   string `json:"MemberID"`
+	Classes    int    `json:"Classes"`
+	PriceCents int    `json:"PriceCents"`
+	ExpiresOn  string `json:"ExpiresOn"` // optional YYYY-MM-DD; empty never expires
+	Note       string `json:"Note"`
+}
+
+// kioskClassPackResponse tells the kiosk whether to warn a member about their class pack.
+type kioskClassPackResponse struct {
+	Warn      bool
+	Status    string // active, exhausted or expired; empty for a member without packs
+	Remaining int    // classes left on active packs
+	ExpiresOn string // soonest expiry among active packs
+}
+
+// classPackDeps wires check-ins to the class pack store; nil leaves packs untouched.
+func classPackDeps() *orchestrators.UseClassPackDeps {
+	if stores.ClassPackStore == nil {
+		return nil
+	}
+	return &orchestrators.UseClassPackDeps{PackStore: stores.ClassPackStore}
+}
+
+// handleAdminClassPacksPage handles GET /admin/class-packs
+func handleAdminClassPacksPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "class_packs") {
+		return
+	}
+	renderTemplate(w, r, "admin_class_packs.html", nil)
+}
+
+// handleClassPacks handles GET/POST for /api/class-packs
+// GET is the class pack report: outstanding and expired packs, and pack revenue earned, owed
+// and forfeited, by class type. POST records a pack sold to a member. Admin only.
+func handleClassPacks(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "class_packs") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		report, err := projections.QueryGetClassPackReport(ctx, timeNow(), projections.GetClassPackReportDeps{
+			PackStore:       stores.ClassPackStore,
+			MemberStore:     stores.MemberStore,
+			AttendanceStore: stores.AttendanceStore,
+			ScheduleStore:   stores.ScheduleStore,
+			ClassTypeStore:  stores.ClassTypeStore,
+		})
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(report)
+
+	case "POST":
+		var input classPackRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		p, err := orchestrators.ExecuteAddClassPack(ctx, orchestrators.AddClassPackInput{
+			MemberID:   input.MemberID,
+			Classes:    input.Classes,
+			PriceCents: input.PriceCents,
+			ExpiresOn:  input.ExpiresOn,
+			Note:       input.Note,
+			Actor: orchestrators.BackfillActor{
+				AccountID: sess.AccountID,
+				Email:     sess.Email,
+				Role:      sess.Role,
+				IPAddress: middleware.ClientIP(r),
+				UserAgent: r.UserAgent(),
+			},
+		}, orchestrators.AddClassPackDeps{
+			MemberStore: stores.MemberStore,
+			PackStore:   stores.ClassPackStore,
+			AuditStore:  stores.AuditStore,
+			GenerateID:  generateID,
+			Now:         timeNow,
+		})
+		switch {
+		case err == nil:
+		case errors.Is(err, orchestrators.ErrClassPackMemberNotFound):
+			apierror.NotFound(w, err.Error())
+			return
+		case errors.Is(err, orchestrators.ErrClassPackMemberArchived), isClassPackValidationError(err):
+			apierror.Validation(w, err.Error())
+			return
+		default:
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(p)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// isClassPackValidationError reports whether err is a pack the admin entered wrongly.
+func isClassPackValidationError(err error) bool {
+	for _, target := range []error{
+		classPackDomain.ErrEmptyMemberID, classPackDomain.ErrInvalidClasses, classPackDomain.ErrNegativePrice,
+		classPackDomain.ErrInvalidExpiry, classPackDomain.ErrExpiryBeforeSale, classPackDomain.ErrNoteTooLong,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleKioskClassPack handles GET /api/kiosk/class-pack?member_id=
+// Returns what a member has left on their class packs and whether the kiosk should warn them:
+// their packs are used up or expired, or they are on their last class. Needs the kiosk
+// permission.
+func handleKioskClassPack(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionAttendanceKiosk)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "class_packs") {
+		return
+	}
+	memberID := r.URL.Query().Get("member_id")
+	if memberID == "" {
+		apierror.Validation(w, "member_id is required")
+		return
+	}
+	packs, err := stores.ClassPackStore.ListByMemberID(r.Context(), memberID)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	b := classPackDomain.MemberBalance(packs, timeNow())
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(kioskClassPackResponse{Warn: b.Warn(), Status: b.Status, Remaining: b.Remaining, ExpiresOn: b.ExpiresOn})
+}
@@ -0,0 +1,203 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/application/projections"
+	classPackDomain "workshop/internal/domain/classpack"
+	memberDomain "workshop/internal/domain/member"
+)
+
+// mockClassPackStore implements the class pack store for testing.
+type mockClassPackStore struct {
+	packs map[string]classPackDomain.ClassPack
+	uses  map[string]classPackDomain.Use
+}
+
+// GetByID implements classpack.Store for testing.
+// PRE: none
+// POST: Returns the pack or an error
+func (m *mockClassPackStore) GetByID(_ context.Context, id string) (classPackDomain.ClassPack, error) {
+	p, ok := m.packs[id]
+	if !ok {
+		return classPackDomain.ClassPack{}, errors.New("not found")
+	}
+	return p, nil
+}
+
+// Save implements classpack.Store for testing.
+// PRE: none
+// POST: The pack is stored
+func (m *mockClassPackStore) Save(_ context.Context, value classPackDomain.ClassPack) error {
+	m.packs[value.ID] = value
+	return nil
+}
+
+// List implements classpack.Store for testing.
+// PRE: none
+// POST: Returns every pack
+func (m *mockClassPackStore) List(_ context.Context) ([]classPackDomain.ClassPack, error) {
+	var list []classPackDomain.ClassPack
+	for _, p := range m.packs {
+		list = append(list, p)
+	}
+	return list, nil
+}
+
+// ListByMemberID implements classpack.Store for testing.
+// PRE: none
+// POST: Returns the member's packs
+func (m *mockClassPackStore) ListByMemberID(_ context.Context, memberID string) ([]classPackDomain.ClassPack, error) {
+	var list []classPackDomain.ClassPack
+	for _, p := range m.packs {
+		if p.MemberID == memberID {
+			list = append(list, p)
+		}
+	}
+	return list, nil
+}
+
+// SaveUse implements classpack.Store for testing.
+// PRE: none
+// POST: The use is stored
+func (m *mockClassPackStore) SaveUse(_ context.Context, value classPackDomain.Use) error {
+	m.uses[value.AttendanceID] = value
+	return nil
+}
+
+// GetUse implements classpack.Store for testing.
+// PRE: none
+// POST: Returns the use or an error
+func (m *mockClassPackStore) GetUse(_ context.Context, attendanceID string) (classPackDomain.Use, error) {
+	u, ok := m.uses[attendanceID]
+	if !ok {
+		return classPackDomain.Use{}, errors.New("not found")
+	}
+	return u, nil
+}
+
+// DeleteUse implements classpack.Store for testing.
+// PRE: none
+// POST: The use is removed
+func (m *mockClassPackStore) DeleteUse(_ context.Context, attendanceID string) error {
+	delete(m.uses, attendanceID)
+	return nil
+}
+
+// UseClass implements classpack.Store for testing.
+// PRE: none
+// POST: The pack is debited and the use stored, unless the check-in already used one or the pack is used up
+func (m *mockClassPackStore) UseClass(_ context.Context, value classPackDomain.Use) error {
+	if _, ok := m.uses[value.AttendanceID]; ok {
+		return classPackDomain.ErrAlreadyUsed
+	}
+	p := m.packs[value.PackID]
+	if p.Used >= p.Classes {
+		return classPackDomain.ErrPackUsedUp
+	}
+	p.Used++
+	m.packs[p.ID] = p
+	m.uses[value.AttendanceID] = value
+	return nil
+}
+
+// RefundClass implements classpack.Store for testing.
+// PRE: none
+// POST: The use is removed and its pack credited
+func (m *mockClassPackStore) RefundClass(_ context.Context, attendanceID string) (classPackDomain.Use, bool, error) {
+	u, ok := m.uses[attendanceID]
+	if !ok {
+		return classPackDomain.Use{}, false, nil
+	}
+	delete(m.uses, attendanceID)
+	p := m.packs[u.PackID]
+	p.Refund()
+	m.packs[p.ID] = p
+	return u, true, nil
+}
+
+// ListUses implements classpack.Store for testing.
+// PRE: none
+// POST: Returns every use
+func (m *mockClassPackStore) ListUses(_ context.Context) ([]classPackDomain.Use, error) {
+	var list []classPackDomain.Use
+	for _, u := range m.uses {
+		list = append(list, u)
+	}
+	return list, nil
+}
+
+// TestClassPacks verifies an admin sells a pack, it appears in the report, and the kiosk
+// warns the member once they are down to their last class.
+func TestClassPacks(t *testing.T) {
+	stores = newFullStores()
+	packs := &mockClassPackStore{packs: map[string]classPackDomain.ClassPack{}, uses: map[string]classPackDomain.Use{}}
+	stores.ClassPackStore = packs
+	stores.AuditStore = &mockAuditStore{}
+	timeNow = func() time.Time { return time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+	stores.MemberStore.Save(context.Background(), memberDomain.Member{ID: "m1", Name: "Jordan", Program: "adults", Status: memberDomain.StatusActive})
+
+	body := `{"MemberID":"m1","Classes":3,"PriceCents":4500,"ExpiresOn":"2026-04-01"}`
+	rec := httptest.NewRecorder()
+	handleClassPacks(rec, authRequest("POST", "/api/class-packs", body, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach sale: expected 403, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleClassPacks(rec, authRequest("POST", "/api/class-packs", `{"MemberID":"m1","Classes":0}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("empty pack: expected 400, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleClassPacks(rec, authRequest("POST", "/api/class-packs", `{"MemberID":"nobody","Classes":3}`, adminSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown member: expected 404, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleClassPacks(rec, authRequest("POST", "/api/class-packs", body, adminSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("admin sale: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var sold classPackDomain.ClassPack
+	json.NewDecoder(rec.Body).Decode(&sold)
+
+	rec = httptest.NewRecorder()
+	handleClassPacks(rec, authRequest("GET", "/api/class-packs", "", adminSession))
+	var report projections.ClassPackReport
+	json.NewDecoder(rec.Body).Decode(&report)
+	if len(report.Packs) != 1 || report.Packs[0].MemberName != "Jordan" || report.UnearnedCents != 4500 {
+		t.Errorf("report = %+v, want Jordan's pack with $45 owed", report)
+	}
+
+	kiosk := func() kioskClassPackResponse {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handleKioskClassPack(rec, authRequest("GET", "/api/kiosk/class-pack?member_id=m1", "", coachSession))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("kiosk GET: expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got kioskClassPackResponse
+		json.NewDecoder(rec.Body).Decode(&got)
+		return got
+	}
+	if got := kiosk(); got.Warn || got.Remaining != 3 {
+		t.Errorf("fresh pack: got %+v, want 3 left and no warning", got)
+	}
+	sold.Used = 2
+	packs.packs[sold.ID] = sold
+	if got := kiosk(); !got.Warn || got.Remaining != 1 {
+		t.Errorf("last class: got %+v, want a warning with 1 left", got)
+	}
+	sold.Used = 3
+	packs.packs[sold.ID] = sold
+	if got := kiosk(); !got.Warn || got.Status != classPackDomain.StatusExhausted {
+		t.Errorf("used up: got %+v, want an exhausted warning", got)
+	}
+}
@@ -0,0 +1,139 @@
+package web
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+	classTypeDomain "workshop/internal/domain/classtype"
+)
+
+// classEligibilityDeps wires the check-in prerequisite check to the stores, or returns nil
+// so check-in skips it when approvals are not configured.
+func classEligibilityDeps() *orchestrators.ClassEligibilityDeps {
+	if stores.ClassTypeApprovalStore == nil || stores.ClassTypeStore == nil || stores.GradingRecordStore == nil {
+		return nil
+	}
+	return &orchestrators.ClassEligibilityDeps{
+		ScheduleStore:      stores.ScheduleStore,
+		ClassTypeStore:     stores.ClassTypeStore,
+		ApprovalStore:      stores.ClassTypeApprovalStore,
+		GradingRecordStore: stores.GradingRecordStore,
+	}
+}
+
+// isPrerequisiteError reports whether a check-in was refused by the class's prerequisites.
+func isPrerequisiteError(err error) bool {
+	return errors.Is(err, classTypeDomain.ErrBeltRequired) || errors.Is(err, classTypeDomain.ErrApprovalRequired)
+}
+
+// classTypeApprovalView is an approval with the member's name for display.
+type classTypeApprovalView struct {
+	ClassTypeID string    `json:"ClassTypeID"`
+	MemberID    string    `json:"MemberID"`
+	MemberName  string    `json:"MemberName"`
+	ApprovedBy  string    `json:"ApprovedBy"`
+	ApprovedAt  time.Time `json:"ApprovedAt"`
+}
+
+// classTypeApprovalRequest is the body of POST /api/class-types/approvals.
+type classTypeApprovalRequest struct {
+	ClassTypeID string `json:"ClassTypeID"`
+	MemberID    string `json:"MemberID"`
+}
+
+// handleClassTypeApprovals handles GET/POST/DELETE for /api/class-types/approvals
+// Lists the approvals for a class type (?class_type_id=) or a member (?member_id=), approves
+// a member for a class type whose prerequisites they do not meet, or withdraws an approval
+// (?class_type_id=&member_id=). Coaches and admins.
+func handleClassTypeApprovals(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireStaff(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "attendance") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		classTypeID, memberID := r.URL.Query().Get("class_type_id"), r.URL.Query().Get("member_id")
+		var (
+			approvals []classTypeDomain.Approval
+			err       error
+		)
+		switch {
+		case classTypeID != "":
+			approvals, err = stores.ClassTypeApprovalStore.ListByClassTypeID(ctx, classTypeID)
+		case memberID != "":
+			approvals, err = stores.ClassTypeApprovalStore.ListByMemberID(ctx, memberID)
+		default:
+			apierror.Validation(w, "class_type_id or member_id is required")
+			return
+		}
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		views := make([]classTypeApprovalView, 0, len(approvals))
+		for _, a := range approvals {
+			v := classTypeApprovalView{ClassTypeID: a.ClassTypeID, MemberID: a.MemberID, ApprovedBy: a.ApprovedBy, ApprovedAt: a.ApprovedAt}
+			if m, err := stores.MemberStore.GetByID(ctx, a.MemberID); err == nil {
+				v.MemberName = m.Name
+			}
+			views = append(views, v)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(views)
+	case "POST":
+		var input classTypeApprovalRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		a := classTypeDomain.Approval{ClassTypeID: input.ClassTypeID, MemberID: input.MemberID, ApprovedBy: sess.AccountID, ApprovedAt: timeNow()}
+		if err := a.Validate(); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if _, err := stores.ClassTypeStore.GetByID(ctx, a.ClassTypeID); errors.Is(err, sql.ErrNoRows) {
+			apierror.NotFound(w, "class type not found")
+			return
+		} else if err != nil {
+			internalError(w, err)
+			return
+		}
+		if _, err := stores.MemberStore.GetByID(ctx, a.MemberID); err != nil {
+			apierror.NotFound(w, "member not found")
+			return
+		}
+		if err := stores.ClassTypeApprovalStore.Save(ctx, a); err != nil {
+			internalError(w, err)
+			return
+		}
+		slog.InfoContext(ctx, "checkin_event", "event", "class_approval_granted", "class_type_id", a.ClassTypeID, "member_id", a.MemberID, "approved_by", a.ApprovedBy)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(a)
+	case "DELETE":
+		classTypeID, memberID := r.URL.Query().Get("class_type_id"), r.URL.Query().Get("member_id")
+		if classTypeID == "" || memberID == "" {
+			apierror.Validation(w, "class_type_id and member_id are required")
+			return
+		}
+		if err := stores.ClassTypeApprovalStore.Delete(ctx, classTypeID, memberID); err != nil {
+			internalError(w, err)
+			return
+		}
+		slog.InfoContext(ctx, "checkin_event", "event", "class_approval_withdrawn", "class_type_id", classTypeID, "member_id", memberID, "withdrawn_by", sess.AccountID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
@@ -0,0 +1,192 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	classTypeDomain "workshop/internal/domain/classtype"
+	gradingDomain "workshop/internal/domain/grading"
+	memberDomain "workshop/internal/domain/member"
+	scheduleDomain "workshop/internal/domain/schedule"
+)
+
+type mockClassTypeApprovalStore struct {
+	approvals []classTypeDomain.Approval
+}
+
+// Save implements classtype.ApprovalStore for testing.
+// PRE: value has been validated
+// POST: The approval is upserted
+func (m *mockClassTypeApprovalStore) Save(ctx context.Context, value classTypeDomain.Approval) error {
+	m.Delete(ctx, value.ClassTypeID, value.MemberID)
+	m.approvals = append(m.approvals, value)
+	return nil
+}
+
+// Delete implements classtype.ApprovalStore for testing.
+// PRE: classTypeID and memberID are non-empty
+// POST: No approval remains for the pair
+func (m *mockClassTypeApprovalStore) Delete(_ context.Context, classTypeID, memberID string) error {
+	kept := m.approvals[:0]
+	for _, a := range m.approvals {
+		if a.ClassTypeID != classTypeID || a.MemberID != memberID {
+			kept = append(kept, a)
+		}
+	}
+	m.approvals = kept
+	return nil
+}
+
+// IsApproved implements classtype.ApprovalStore for testing.
+// PRE: classTypeID and memberID are non-empty
+// POST: Returns true when the pair was approved
+func (m *mockClassTypeApprovalStore) IsApproved(_ context.Context, classTypeID, memberID string) (bool, error) {
+	for _, a := range m.approvals {
+		if a.ClassTypeID == classTypeID && a.MemberID == memberID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ListByClassTypeID implements classtype.ApprovalStore for testing.
+// PRE: classTypeID is non-empty
+// POST: Returns the class type's approvals
+func (m *mockClassTypeApprovalStore) ListByClassTypeID(_ context.Context, classTypeID string) ([]classTypeDomain.Approval, error) {
+	var out []classTypeDomain.Approval
+	for _, a := range m.approvals {
+		if a.ClassTypeID == classTypeID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+// ListByMemberID implements classtype.ApprovalStore for testing.
+// PRE: memberID is non-empty
+// POST: Returns the member's approvals
+func (m *mockClassTypeApprovalStore) ListByMemberID(_ context.Context, memberID string) ([]classTypeDomain.Approval, error) {
+	var out []classTypeDomain.Approval
+	for _, a := range m.approvals {
+		if a.MemberID == memberID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+// setupPrerequisiteStores gives the stores a white belt, a competition class needing blue
+// belt and a fight-team class needing a coach's approval, both today at different times.
+func setupPrerequisiteStores(t *testing.T) {
+	t.Helper()
+	stores = newFullStores()
+	ctx := context.Background()
+	day := strings.ToLower(time.Now().Weekday().String())
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", Name: "Wen", Email: "wen@test.com", Program: memberDomain.ProgramAdults, Status: memberDomain.StatusActive})
+	stores.GradingRecordStore.Save(ctx, gradingDomain.Record{ID: "r1", MemberID: "m1", Belt: gradingDomain.BeltWhite, PromotedAt: time.Now().AddDate(-1, 0, 0)})
+	stores.ClassTypeStore.Save(ctx, classTypeDomain.ClassType{ID: "ct-comp", ProgramID: "p1", Name: "Competition", MinBelt: gradingDomain.BeltBlue})
+	stores.ClassTypeStore.Save(ctx, classTypeDomain.ClassType{ID: "ct-team", ProgramID: "p1", Name: "Fight team", RequiresApproval: true})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s-comp", ClassTypeID: "ct-comp", Day: day, StartTime: "06:00", EndTime: "07:00"})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s-team", ClassTypeID: "ct-team", Day: day, StartTime: "08:00", EndTime: "09:00"})
+}
+
+// checkInAs posts a kiosk check-in, with a session when sess is non-nil.
+func checkInAs(body string, sess *middleware.Session) *httptest.ResponseRecorder {
+	var req *http.Request
+	if sess != nil {
+		req = authRequest("POST", "/checkin", body, *sess)
+	} else {
+		req = httptest.NewRequest("POST", "/checkin", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	handlePostCheckinCheckInMember(rec, req)
+	return rec
+}
+
+// TestHandleCheckIn_Prerequisites verifies an ineligible member is refused with the reason,
+// only a coach's session can override, and a coach's approval lets them in.
+func TestHandleCheckIn_Prerequisites(t *testing.T) {
+	setupPrerequisiteStores(t)
+	coach := coachSession
+
+	rec := checkInAs(`{"MemberID":"m1","ScheduleID":"s-comp"}`, nil)
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "higher belt") {
+		t.Fatalf("below minimum belt: expected 403 with the reason, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := checkInAs(`{"MemberID":"m1","ScheduleID":"s-comp","Override":true}`, nil); rec.Code != http.StatusForbidden {
+		t.Errorf("override without a coach: expected 403, got %d", rec.Code)
+	}
+	if rec := checkInAs(`{"MemberID":"m1","ScheduleID":"s-comp","Override":true}`, &coach); rec.Code != http.StatusNoContent {
+		t.Fatalf("coach override: expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := checkInAs(`{"MemberID":"m1","ScheduleID":"s-team"}`, &coach); rec.Code != http.StatusForbidden {
+		t.Fatalf("not approved: expected 403, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleClassTypeApprovals(rec, authRequest("POST", "/api/class-types/approvals", `{"ClassTypeID":"ct-team","MemberID":"m1"}`, coachSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("approve: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := checkInAs(`{"MemberID":"m1","ScheduleID":"s-team"}`, nil); rec.Code != http.StatusNoContent {
+		t.Errorf("approved: expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+// TestHandleClassTypeApprovals verifies coaches list and withdraw approvals, members cannot
+// manage them, and unknown class types are rejected.
+func TestHandleClassTypeApprovals(t *testing.T) {
+	setupPrerequisiteStores(t)
+	do := func(method, url, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handleClassTypeApprovals(rec, authRequest(method, url, body, coachSession))
+		return rec
+	}
+
+	rec := httptest.NewRecorder()
+	handleClassTypeApprovals(rec, authRequest("POST", "/api/class-types/approvals", `{"ClassTypeID":"ct-team","MemberID":"m1"}`, memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member: expected 403, got %d", rec.Code)
+	}
+	if rec := do("POST", "/api/class-types/approvals", `{"ClassTypeID":"ct-gone","MemberID":"m1"}`); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown class type: expected 404, got %d", rec.Code)
+	}
+	if rec := do("POST", "/api/class-types/approvals", `{"ClassTypeID":"ct-team","MemberID":"m1"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("approve: expected 201, got %d", rec.Code)
+	}
+
+	rec = do("GET", "/api/class-types/approvals?class_type_id=ct-team", "")
+	var list []classTypeApprovalView
+	json.NewDecoder(rec.Body).Decode(&list)
+	if len(list) != 1 || list[0].MemberName != "Wen" || list[0].ApprovedBy != coachSession.AccountID {
+		t.Fatalf("approvals = %+v, want Wen approved by the coach", list)
+	}
+	if rec := do("GET", "/api/class-types/approvals", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("no filter: expected 400, got %d", rec.Code)
+	}
+
+	if rec := do("DELETE", "/api/class-types/approvals?class_type_id=ct-team&member_id=m1", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("withdraw: expected 204, got %d", rec.Code)
+	}
+	rec = do("GET", "/api/class-types/approvals?member_id=m1", "")
+	if strings.TrimSpace(rec.Body.String()) != "[]" {
+		t.Errorf("after withdrawal: %s, want []", rec.Body.String())
+	}
+}
+
+// TestHandleClassTypes_RejectsUnknownMinBelt verifies a class type's minimum belt must be a real belt.
+func TestHandleClassTypes_RejectsUnknownMinBelt(t *testing.T) {
+	stores = newFullStores()
+	rec := httptest.NewRecorder()
+	handleClassTypes(rec, authRequest("POST", "/api/class-types", `{"ProgramID":"p1","Name":"Comp","MinBelt":"gold"}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
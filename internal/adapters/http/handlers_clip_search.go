@@ -0,0 +1,94 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/projections"
+	clipDomain "workshop/internal/domain/clip"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// handleClipsSearch handles GET /api/clips/search?tag=&q=&promoted=true
+// tag may repeat and accepts a tag name (case-insensitive) or ID; clips must carry every tag.
+// q matches clip titles and notes. At least one of tag or q is required.
+func handleClipsSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryView)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "library") {
+		return
+	}
+	ctx := r.Context()
+	params := r.URL.Query()
+	tags := params["tag"]
+	query := params.Get("q")
+	promotedOnly := params.Get("promoted") == "true"
+	if len(tags) == 0 && query == "" {
+		apierror.Validation(w, "tag or q is required")
+		return
+	}
+
+	tagIDs := make([]string, 0, len(tags))
+	for _, ref := range tags {
+		tag, err := stores.ClipTagStore.GetTagByID(ctx, ref)
+		if err != nil {
+			tag, err = stores.ClipTagStore.GetTagByName(ctx, ref)
+		}
+		if err != nil {
+			// An unknown tag can never match, so the AND search is empty.
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte("[]"))
+			return
+		}
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	var clips []clipDomain.Clip
+	var err error
+	if len(tagIDs) > 0 {
+		clips, err = stores.ClipTagStore.SearchClips(ctx, tagIDs, query, promotedOnly)
+	} else {
+		clips, err = stores.ClipStore.Search(ctx, query, "", promotedOnly)
+	}
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	gated, err := gateClipsForViewer(ctx, sess, clips)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(gated)
+}
+
+// handleClipTaxonomy handles GET /api/clips/taxonomy
+// Returns tags grouped by category (position, technique, attire, general) with clip counts.
+func handleClipTaxonomy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryView)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "library") {
+		return
+	}
+	taxonomy, err := projections.QueryGetClipTaxonomy(r.Context(), projections.GetClipTaxonomyDeps{TagStore: stores.ClipTagStore})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(taxonomy)
+}
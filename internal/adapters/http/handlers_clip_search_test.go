@@ -0,0 +1,287 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/application/projections"
+	clipDomain "workshop/internal/domain/clip"
+)
+
+// mockClipTagStore is an in-memory ClipTagStore that searches the clips held by a mockClipStore.
+type mockClipTagStore struct {
+	tags  map[string]clipDomain.Tag
+	links map[string]map[string]bool // clipID -> tagID set
+	clips *mockClipStore
+}
+
+// SaveTag stores a tag.
+// PRE: tag has a non-empty ID
+// POST: tag is stored
+func (m *mockClipTagStore) SaveTag(_ context.Context, tag clipDomain.Tag) error {
+	m.tags[tag.ID] = tag
+	return nil
+}
+
+// GetTagByID returns a tag by ID.
+// PRE: id is non-empty
+// POST: returns the tag or an error if not found
+func (m *mockClipTagStore) GetTagByID(_ context.Context, id string) (clipDomain.Tag, error) {
+	if t, ok := m.tags[id]; ok {
+		return t, nil
+	}
+	return clipDomain.Tag{}, errors.New("tag not found")
+}
+
+// GetTagByName returns a tag by case-insensitive name.
+// PRE: name is non-empty
+// POST: returns the tag or an error if not found
+func (m *mockClipTagStore) GetTagByName(_ context.Context, name string) (clipDomain.Tag, error) {
+	for _, t := range m.tags {
+		if strings.EqualFold(t.Name, name) {
+			return t, nil
+		}
+	}
+	return clipDomain.Tag{}, errors.New("tag not found")
+}
+
+// ListTags returns every tag.
+// PRE: none
+// POST: returns all stored tags
+func (m *mockClipTagStore) ListTags(_ context.Context) ([]clipDomain.Tag, error) {
+	var list []clipDomain.Tag
+	for _, t := range m.tags {
+		list = append(list, t)
+	}
+	return list, nil
+}
+
+// DeleteTag removes a tag.
+// PRE: id is non-empty
+// POST: tag is removed
+func (m *mockClipTagStore) DeleteTag(_ context.Context, id string) error {
+	delete(m.tags, id)
+	return nil
+}
+
+// AddTagToClip links a tag to a clip.
+// PRE: ClipID and TagID are non-empty
+// POST: link is stored
+func (m *mockClipTagStore) AddTagToClip(_ context.Context, clipTag clipDomain.ClipTag) error {
+	if m.links[clipTag.ClipID] == nil {
+		m.links[clipTag.ClipID] = make(map[string]bool)
+	}
+	m.links[clipTag.ClipID][clipTag.TagID] = true
+	return nil
+}
+
+// RemoveTagFromClip unlinks a tag from a clip.
+// PRE: clipID and tagID are non-empty
+// POST: link is removed
+func (m *mockClipTagStore) RemoveTagFromClip(_ context.Context, clipID, tagID string) error {
+	delete(m.links[clipID], tagID)
+	return nil
+}
+
+// GetTagsForClip returns the tags linked to a clip.
+// PRE: clipID is non-empty
+// POST: returns linked tags
+func (m *mockClipTagStore) GetTagsForClip(_ context.Context, clipID string) ([]clipDomain.Tag, error) {
+	var list []clipDomain.Tag
+	for tagID := range m.links[clipID] {
+		list = append(list, m.tags[tagID])
+	}
+	return list, nil
+}
+
+// GetClipsForTag returns the clip IDs linked to a tag.
+// PRE: tagID is non-empty
+// POST: returns linked clip IDs
+func (m *mockClipTagStore) GetClipsForTag(_ context.Context, tagID string) ([]string, error) {
+	var list []string
+	for clipID, set := range m.links {
+		if set[tagID] {
+			list = append(list, clipID)
+		}
+	}
+	return list, nil
+}
+
+// SearchClipsByTags returns clips carrying every tag.
+// PRE: none
+// POST: returns matching clips
+func (m *mockClipTagStore) SearchClipsByTags(ctx context.Context, tagIDs []string) ([]clipDomain.Clip, error) {
+	return m.SearchClips(ctx, tagIDs, "", false)
+}
+
+// SearchClips returns clips carrying every tag that also match the text query.
+// PRE: none
+// POST: returns matching clips
+func (m *mockClipTagStore) SearchClips(ctx context.Context, tagIDs []string, query string, promotedOnly bool) ([]clipDomain.Clip, error) {
+	candidates, _ := m.clips.Search(ctx, query, "", promotedOnly)
+	var out []clipDomain.Clip
+	for _, c := range candidates {
+		all := true
+		for _, id := range tagIDs {
+			if !m.links[c.ID][id] {
+				all = false
+			}
+		}
+		if all {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// CountClipsByTag returns the number of clips per tag.
+// PRE: none
+// POST: returns counts keyed by tag ID
+func (m *mockClipTagStore) CountClipsByTag(_ context.Context) (map[string]int, error) {
+	counts := make(map[string]int)
+	for _, set := range m.links {
+		for tagID := range set {
+			counts[tagID]++
+		}
+	}
+	return counts, nil
+}
+
+// seedClipTaxonomy stores three clips tagged with half guard / mount and sweep / pass.
+func seedClipTaxonomy(t *testing.T) {
+	t.Helper()
+	stores = newFullStores()
+	clips := stores.ClipStore.(*mockClipStore)
+	tags := &mockClipTagStore{tags: make(map[string]clipDomain.Tag), links: make(map[string]map[string]bool), clips: clips}
+	stores.ClipTagStore = tags
+	ctx := context.Background()
+	now := time.Now()
+	for _, tag := range []clipDomain.Tag{
+		{ID: "hg", Name: "Half Guard", Category: clipDomain.CategoryPosition},
+		{ID: "mt", Name: "Mount", Category: clipDomain.CategoryPosition},
+		{ID: "sw", Name: "Sweep", Category: clipDomain.CategoryTechnique},
+		{ID: "nogi", Name: "Nogi", Category: clipDomain.CategoryAttire},
+	} {
+		tags.SaveTag(ctx, tag)
+	}
+	for _, c := range []clipDomain.Clip{
+		{ID: "c1", ThemeID: "t1", Title: "Lucas Leite sweep", Promoted: true, CreatedAt: now},
+		{ID: "c2", ThemeID: "t1", Title: "Old school sweep", CreatedAt: now},
+		{ID: "c3", ThemeID: "t2", Title: "Mount escape", CreatedAt: now},
+	} {
+		clips.Save(ctx, c)
+	}
+	for _, link := range [][2]string{{"c1", "hg"}, {"c1", "sw"}, {"c2", "hg"}, {"c2", "sw"}, {"c2", "nogi"}, {"c3", "mt"}} {
+		tags.AddTagToClip(ctx, clipDomain.ClipTag{ClipID: link[0], TagID: link[1]})
+	}
+}
+
+// searchClipIDs calls the search handler and returns the matching clip IDs.
+func searchClipIDs(t *testing.T, url string) map[string]bool {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handleClipsSearch(rec, authRequest("GET", url, "", memberSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: expected 200, got %d: %s", url, rec.Code, rec.Body.String())
+	}
+	var clips []clipDomain.Clip
+	json.NewDecoder(rec.Body).Decode(&clips)
+	ids := make(map[string]bool)
+	for _, c := range clips {
+		ids[c.ID] = true
+	}
+	return ids
+}
+
+// TestHandleClipsSearch_TagsAndQuery verifies tag names AND together and combine with the text query.
+func TestHandleClipsSearch_TagsAndQuery(t *testing.T) {
+	seedClipTaxonomy(t)
+
+	if got := searchClipIDs(t, "/api/clips/search?tag=half+guard&tag=Sweep"); len(got) != 2 || !got["c1"] || !got["c2"] {
+		t.Errorf("half guard + sweep: got %v", got)
+	}
+	if got := searchClipIDs(t, "/api/clips/search?tag=hg&tag=nogi"); len(got) != 1 || !got["c2"] {
+		t.Errorf("half guard + nogi by ID: got %v", got)
+	}
+	if got := searchClipIDs(t, "/api/clips/search?tag=Half+Guard&q=leite"); len(got) != 1 || !got["c1"] {
+		t.Errorf("half guard + q=leite: got %v", got)
+	}
+	if got := searchClipIDs(t, "/api/clips/search?q=escape"); len(got) != 1 || !got["c3"] {
+		t.Errorf("q only: got %v", got)
+	}
+	if got := searchClipIDs(t, "/api/clips/search?tag=Rubber+Guard"); len(got) != 0 {
+		t.Errorf("unknown tag: got %v", got)
+	}
+}
+
+// TestHandleClipsSearch_RequiresFilter verifies an unfiltered search is rejected.
+func TestHandleClipsSearch_RequiresFilter(t *testing.T) {
+	seedClipTaxonomy(t)
+	rec := httptest.NewRecorder()
+	handleClipsSearch(rec, authRequest("GET", "/api/clips/search", "", memberSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
+
+// TestHandleClipTaxonomy groups tags by category with clip counts.
+func TestHandleClipTaxonomy(t *testing.T) {
+	seedClipTaxonomy(t)
+	rec := httptest.NewRecorder()
+	handleClipTaxonomy(rec, authRequest("GET", "/api/clips/taxonomy", "", memberSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var taxonomy []projections.TaxonomyCategory
+	json.NewDecoder(rec.Body).Decode(&taxonomy)
+	if len(taxonomy) == 0 || taxonomy[0].Category != clipDomain.CategoryPosition {
+		t.Fatalf("expected position group first, got %+v", taxonomy)
+	}
+	positions := taxonomy[0].Tags
+	if len(positions) != 2 || positions[0].Name != "Half Guard" || positions[0].ClipCount != 2 {
+		t.Errorf("unexpected position tags: %+v", positions)
+	}
+}
+
+// TestHandleClipTags_CategoryLifecycle verifies tags are created in a category and can be recategorised by staff only.
+func TestHandleClipTags_CategoryLifecycle(t *testing.T) {
+	seedClipTaxonomy(t)
+
+	rec := httptest.NewRecorder()
+	handleClipTags(rec, authRequest("POST", "/api/clips/tags", `{"Name":"Competition"}`, memberSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var tag clipDomain.Tag
+	json.NewDecoder(rec.Body).Decode(&tag)
+	if tag.Category != clipDomain.CategoryGeneral {
+		t.Errorf("default category = %q, want general", tag.Category)
+	}
+
+	rec = httptest.NewRecorder()
+	handleClipTags(rec, authRequest("POST", "/api/clips/tags", `{"Name":"Gi Chokes","Category":"belt"}`, memberSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid category: expected 400, got %d", rec.Code)
+	}
+
+	body := `{"ID":"` + tag.ID + `","Category":"technique"}`
+	rec = httptest.NewRecorder()
+	handleClipTags(rec, authRequest("PUT", "/api/clips/tags", body, memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member recategorise: expected 403, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleClipTags(rec, authRequest("PUT", "/api/clips/tags", body, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("coach recategorise: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := stores.ClipTagStore.(*mockClipTagStore).tags[tag.ID].Category; got != clipDomain.CategoryTechnique {
+		t.Errorf("category after PUT = %q, want technique", got)
+	}
+}
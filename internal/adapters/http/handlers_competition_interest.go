@@ -1,82 +1,124 @@
 package web
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
-	"time"
+	"strconv"
 
+	"workshop/internal/adapters/http/apierror"
 	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
 	calendarDomain "workshop/internal/domain/calendar"
+	permissionDomain "workshop/internal/domain/permission"
 )
 
+// competitionInterestRequest is the body of POST /api/calendar/interest.
+type competitionInterestRequest struct {
+	EventID     string `json:"event_id"`
+	Status      string `json:"status"`
+	WeightClass string `json:"weight_class"`
+}
+
+// competitionInterestEntry is a member's interest as listed by GET /api/calendar/interest.
+type competitionInterestEntry struct {
+	MemberID    string `json:"member_id"`
+	MemberName  string `json:"member_name"`
+	Status      string `json:"status"`
+	WeightClass string `json:"weight_class"`
+	CreatedAt   string `json:"created_at"`
+}
+
 // handleCompetitionInterest handles POST/DELETE/GET for /api/calendar/interest
-// Members can register/unregister interest in competitions. Admin/Coach can view who's interested.
+// Members say they are interested in or registered for a competition (optionally with
+// their weight class), or withdraw. Admin/Coach can view who has responded.
 func handleCompetitionInterest(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	sess, ok := middleware.GetSessionFromContext(ctx)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "calendar") {
 		return
 	}
 
-	// POST: Register interest (member only)
+	// POST: Record interest or registration (any member, for themselves)
 	if r.Method == "POST" {
 		if sess.Role != "member" && sess.Role != "admin" && sess.Role != "coach" {
-			http.Error(w, "members only", http.StatusForbidden)
+			apierror.Forbidden(w, "members only")
 			return
 		}
-		var input struct {
-			EventID string `json:"event_id"`
-		}
+		var input competitionInterestRequest
 		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 		if input.EventID == "" {
-			http.Error(w, "event_id required", http.StatusBadRequest)
+			apierror.Validation(w, "event_id required")
 			return
 		}
 
 		// Get member ID for current account
 		member, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID)
 		if err != nil {
-			http.Error(w, "member not found", http.StatusNotFound)
+			apierror.NotFound(w, "member not found")
 			return
 		}
-
-		ci := calendarDomain.CompetitionInterest{
-			ID:        generateID(),
-			EventID:   input.EventID,
-			MemberID:  member.ID,
-			CreatedAt: time.Now(),
+		candidate := calendarDomain.CompetitionInterest{EventID: input.EventID, MemberID: member.ID, Status: input.Status, WeightClass: input.WeightClass}
+		if err := candidate.Validate(); err != nil {
+			apierror.Validation(w, err.Error())
+			return
 		}
-		if err := stores.CompetitionInterestStore.SaveInterest(ctx, ci); err != nil {
+		if _, err := stores.CalendarEventStore.GetByID(ctx, input.EventID); err != nil {
+			apierror.NotFound(w, "event not found")
+			return
+		}
+
+		ci, err := orchestrators.ExecuteRespondToCompetition(ctx, orchestrators.RespondToCompetitionInput{
+			EventID:     input.EventID,
+			MemberID:    member.ID,
+			Status:      input.Status,
+			WeightClass: input.WeightClass,
+		}, orchestrators.RespondToCompetitionDeps{
+			EventStore:    stores.CalendarEventStore,
+			InterestStore: stores.CompetitionInterestStore,
+			GenerateID:    generateID,
+			Now:           timeNow,
+		})
+		if err != nil {
+			if errors.Is(err, calendarDomain.ErrNotCompetition) {
+				apierror.Validation(w, err.Error())
+				return
+			}
 			internalError(w, err)
 			return
 		}
+		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
-		json.NewEncoder(w).Encode(map[string]string{"status": "registered"})
+		json.NewEncoder(w).Encode(map[string]string{"status": ci.Status, "weight_class": ci.WeightClass})
 		return
 	}
 
 	// DELETE: Unregister interest (member only, their own)
 	if r.Method == "DELETE" {
 		if sess.Role != "member" && sess.Role != "admin" && sess.Role != "coach" {
-			http.Error(w, "members only", http.StatusForbidden)
+			apierror.Forbidden(w, "members only")
 			return
 		}
 		eventID := r.URL.Query().Get("event_id")
 		if eventID == "" {
-			http.Error(w, "event_id required", http.StatusBadRequest)
+			apierror.Validation(w, "event_id required")
 			return
 		}
 
 		member, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID)
 		if err != nil {
-			http.Error(w, "member not found", http.StatusNotFound)
+			apierror.NotFound(w, "member not found")
 			return
 		}
 
@@ -90,13 +132,13 @@ func handleCompetitionInterest(w http.ResponseWriter, r *http.Request) {
 
 	// GET: List interested members for an event (admin/coach only)
 	if r.Method == "GET" {
-		if sess.Role != "admin" && sess.Role != "coach" {
-			http.Error(w, "admin/coach only", http.StatusForbidden)
+		if !permissionAllowed(r.Context(), sess, permissionDomain.ActionCompetitionsRoster) {
+			apierror.Forbidden(w, "admin/coach only")
 			return
 		}
 		eventID := r.URL.Query().Get("event_id")
 		if eventID == "" {
-			http.Error(w, "event_id required", http.StatusBadRequest)
+			apierror.Validation(w, "event_id required")
 			return
 		}
 
@@ -107,21 +149,18 @@ func handleCompetitionInterest(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Enrich with member names
-		type result struct {
-			MemberID   string `json:"member_id"`
-			MemberName string `json:"member_name"`
-			CreatedAt  string `json:"created_at"`
-		}
-		var results []result
+		var results []competitionInterestEntry
 		for _, ci := range interests {
 			member, err := stores.MemberStore.GetByID(ctx, ci.MemberID)
 			if err != nil {
 				continue // Skip if member not found
 			}
-			results = append(results, result{
-				MemberID:   ci.MemberID,
-				MemberName: member.Name,
-				CreatedAt:  ci.CreatedAt.Format("2006-01-02"),
+			results = append(results, competitionInterestEntry{
+				MemberID:    ci.MemberID,
+				MemberName:  member.Name,
+				Status:      ci.Status,
+				WeightClass: ci.WeightClass,
+				CreatedAt:   ci.CreatedAt.Format("2006-01-02"),
 			})
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -129,5 +168,155 @@ func handleCompetitionInterest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusMethodNotAllowed)
+	apierror.MethodNotAllowed(w)
+}
+
+// competitionInterestSummary is the body of GET /api/calendar/interest/summary.
+type competitionInterestSummary struct {
+	Interested    int    `json:"interested"`
+	Registered    int    `json:"registered"`
+	MyStatus      string `json:"my_status"`
+	MyWeightClass string `json:"my_weight_class"`
+}
+
+// handleCompetitionInterestSummary handles GET /api/calendar/interest/summary?event_id=
+// Returns how many members are interested and registered, plus the caller's own response,
+// so every role can render the competition card without seeing who else is going.
+func handleCompetitionInterestSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "calendar") {
+		return
+	}
+	eventID := r.URL.Query().Get("event_id")
+	if eventID == "" {
+		apierror.Validation(w, "event_id required")
+		return
+	}
+
+	interests, err := stores.CompetitionInterestStore.GetInterestsByEvent(ctx, eventID)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	myMemberID := ""
+	if member, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID); err == nil {
+		myMemberID = member.ID
+	}
+
+	var summary competitionInterestSummary
+	for _, ci := range interests {
+		if ci.IsRegistered() {
+			summary.Registered++
+		} else {
+			summary.Interested++
+		}
+		if ci.MemberID == myMemberID {
+			summary.MyStatus = ci.Status
+			summary.MyWeightClass = ci.WeightClass
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}
+
+// handleCompetitionRoster handles GET /api/calendar/roster?event_id=[&format=csv]
+// Aggregated team roster for a competition with belt and weight class. With format=csv
+// it downloads a sheet for registration day. Admin/Coach only.
+func handleCompetitionRoster(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "calendar") {
+		return
+	}
+	if !permissionAllowed(ctx, sess, permissionDomain.ActionCompetitionsRoster) {
+		apierror.Forbidden(w, "admin/coach only")
+		return
+	}
+	eventID := r.URL.Query().Get("event_id")
+	if eventID == "" {
+		apierror.Validation(w, "event_id required")
+		return
+	}
+	if _, err := stores.CalendarEventStore.GetByID(ctx, eventID); err != nil {
+		apierror.NotFound(w, "event not found")
+		return
+	}
+
+	roster, err := projections.QueryGetCompetitionRoster(ctx, eventID, projections.GetCompetitionRosterDeps{
+		EventStore:         stores.CalendarEventStore,
+		InterestStore:      stores.CompetitionInterestStore,
+		MemberStore:        stores.MemberStore,
+		GradingRecordStore: stores.GradingRecordStore,
+	})
+	if err != nil {
+		if errors.Is(err, calendarDomain.ErrNotCompetition) {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	if r.URL.Query().Get("format") != "csv" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(roster)
+		return
+	}
+
+	filename := fmt.Sprintf("roster-%s-%s.csv", roster.EventDate, eventID)
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	w.Header().Set("Cache-Control", "no-store")
+
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+	if err := cw.Write([]string{"Name", "Email", "Program", "Belt", "Stripe", "WeightClass", "Status", "RespondedAt"}); err != nil {
+		internalError(w, err)
+		return
+	}
+	for _, e := range roster.Entries {
+		rec := []string{
+			csvSafeCell(e.MemberName),
+			csvSafeCell(e.MemberEmail),
+			csvSafeCell(e.Program),
+			csvSafeCell(e.Belt),
+			strconv.Itoa(e.Stripe),
+			csvSafeCell(e.WeightClass),
+			csvSafeCell(e.Status),
+			csvSafeCell(e.RespondedAt),
+		}
+		if err := cw.Write(rec); err != nil {
+			internalError(w, err)
+			return
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		slog.ErrorContext(ctx, "csv_export_flush_error", "error", err.Error())
+	}
+
+	slog.InfoContext(ctx, "audit_event",
+		"actor_id", sess.AccountID,
+		"actor_role", sess.Role,
+		"action", "calendar.roster.export_csv",
+		"event_id", eventID,
+		"row_count", len(roster.Entries),
+	)
 }
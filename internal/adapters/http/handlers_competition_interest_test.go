@@ -0,0 +1,20 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestHandleCompetitionRoster_CoachOnly verifies members cannot view or export a roster.
+func TestHandleCompetitionRoster_CoachOnly(t *testing.T) {
+	stores = newFullStores()
+
+	for _, path := range []string{"/api/calendar/roster?event_id=e1", "/api/calendar/roster?event_id=e1&format=csv"} {
+		rec := httptest.NewRecorder()
+		handleCompetitionRoster(rec, authRequest("GET", path, "", memberSession))
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s: expected 403, got %d", path, rec.Code)
+		}
+	}
+}
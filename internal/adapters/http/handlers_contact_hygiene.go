@@ -0,0 +1,163 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	accountDomain "workshop/internal/domain/account"
+	"workshop/internal/domain/contactcheck"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// contactHygieneRequest is the body of POST /api/emails/contact-hygiene.
+type contactHygieneRequest struct {
+	MemberIDs []string `json:"MemberIDs"`
+}
+
+// contactHygieneRequestResponse reports how many members were asked to confirm their email.
+type contactHygieneRequestResponse struct {
+	Requested int
+}
+
+// handleContactHygiene handles GET/POST for /api/emails/contact-hygiene
+// GET is the bad contact details report: members whose current email bounced, failed or is
+// suppressed. POST asks the chosen members to confirm their email at their next kiosk
+// check-in. Admin only.
+func handleContactHygiene(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "emails") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		list, err := projections.QueryGetContactHygiene(ctx, projections.GetContactHygieneDeps{
+			EmailStore:  stores.EmailStore,
+			MemberStore: stores.MemberStore,
+			CheckStore:  stores.ContactCheckStore,
+		})
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(list)
+
+	case "POST":
+		var input contactHygieneRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		n, err := orchestrators.ExecuteRequestContactChecks(ctx, orchestrators.RequestContactChecksInput{
+			MemberIDs:   input.MemberIDs,
+			RequestedBy: sess.AccountID,
+		}, contactCheckDeps())
+		if err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(contactHygieneRequestResponse{Requested: n})
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// kioskContactCheckRequest is the body of POST /api/kiosk/contact-check.
+type kioskContactCheckRequest struct {
+	MemberID string `json:"MemberID"`
+	Email    string `json:"Email"` // empty confirms the address on file
+}
+
+// kioskContactCheckResponse tells the kiosk whether to ask the member about their email.
+type kioskContactCheckResponse struct {
+	Pending bool
+	Masked  string // the address on file with most of the name hidden, for the shared screen
+}
+
+// kioskContactCheckResult reports a member's answer back to the kiosk.
+type kioskContactCheckResult struct {
+	Resolution string // confirmed, corrected or proposed
+}
+
+// handleKioskContactCheck handles GET/POST for /api/kiosk/contact-check
+// GET ?member_id= says whether the member has been asked to confirm their email. POST records
+// their answer: the address on file is confirmed, or replaced by the one they typed.
+// Needs the kiosk permission.
+func handleKioskContactCheck(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requirePermission(w, r, permissionDomain.ActionAttendanceKiosk)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "attendance") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		memberID := r.URL.Query().Get("member_id")
+		if memberID == "" {
+			apierror.Validation(w, "member_id is required")
+			return
+		}
+		var resp kioskContactCheckResponse
+		if c, err := stores.ContactCheckStore.Get(ctx, memberID); err == nil && c.Open() {
+			resp = kioskContactCheckResponse{Pending: true, Masked: contactcheck.MaskAddress(c.Address)}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+
+	case "POST":
+		var input kioskContactCheckRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		if input.MemberID == "" {
+			apierror.Validation(w, "MemberID is required")
+			return
+		}
+		c, err := orchestrators.ExecuteResolveContactCheck(ctx, orchestrators.ResolveContactCheckInput{
+			MemberID: input.MemberID,
+			Address:  input.Email,
+		}, contactCheckDeps())
+		switch {
+		case err == nil:
+		case errors.Is(err, orchestrators.ErrNoContactCheck):
+			apierror.NotFound(w, err.Error())
+			return
+		case errors.Is(err, contactcheck.ErrAlreadyResolved), errors.Is(err, contactcheck.ErrInvalidAddress),
+			errors.Is(err, accountDomain.ErrEmailTaken):
+			apierror.Validation(w, err.Error())
+			return
+		default:
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(kioskContactCheckResult{Resolution: c.Resolution})
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// contactCheckDeps wires the contact check orchestrators to the stores.
+func contactCheckDeps() orchestrators.ContactCheckDeps {
+	return orchestrators.ContactCheckDeps{
+		MemberStore: stores.MemberStore,
+		CheckStore:  stores.ContactCheckStore,
+		Now:         timeNow,
+	}
+}
@@ -0,0 +1,111 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	contactCheckDomain "workshop/internal/domain/contactcheck"
+	memberDomain "workshop/internal/domain/member"
+)
+
+type mockContactCheckStore struct {
+	checks map[string]contactCheckDomain.Check
+}
+
+// Get returns a member's contact check.
+// PRE: memberID is non-empty
+// POST: Returns the check or an error
+func (m *mockContactCheckStore) Get(_ context.Context, memberID string) (contactCheckDomain.Check, error) {
+	c, ok := m.checks[memberID]
+	if !ok {
+		return contactCheckDomain.Check{}, errors.New("not found")
+	}
+	return c, nil
+}
+
+// Save stores a member's contact check, replacing any earlier one.
+// PRE: value has a MemberID
+// POST: The check is stored
+func (m *mockContactCheckStore) Save(_ context.Context, value contactCheckDomain.Check) error {
+	m.checks[value.MemberID] = value
+	return nil
+}
+
+// List returns every contact check.
+// PRE: none
+// POST: Returns the checks in no particular order
+func (m *mockContactCheckStore) List(_ context.Context) ([]contactCheckDomain.Check, error) {
+	var list []contactCheckDomain.Check
+	for _, c := range m.checks {
+		list = append(list, c)
+	}
+	return list, nil
+}
+
+// TestContactCheckKioskFlow verifies an admin asks a member to confirm their email, the kiosk
+// sees the masked address, and the member's correction updates their record once.
+func TestContactCheckKioskFlow(t *testing.T) {
+	stores = newFullStores()
+	stores.ContactCheckStore = &mockContactCheckStore{checks: map[string]contactCheckDomain.Check{}}
+	timeNow = func() time.Time { return time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+	stores.MemberStore.Save(context.Background(), memberDomain.Member{ID: "m1", Name: "Jordan", Email: "jordan@example.com", Program: "adults", Status: memberDomain.StatusActive})
+
+	rec := httptest.NewRecorder()
+	handleContactHygiene(rec, authRequest("POST", "/api/emails/contact-hygiene", `{"MemberIDs":["m1"]}`, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach request: expected 403, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleContactHygiene(rec, authRequest("POST", "/api/emails/contact-hygiene", `{"MemberIDs":["m1"]}`, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("admin request: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	pending := func() kioskContactCheckResponse {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handleKioskContactCheck(rec, authRequest("GET", "/api/kiosk/contact-check?member_id=m1", "", coachSession))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("kiosk GET: expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var got kioskContactCheckResponse
+		json.NewDecoder(rec.Body).Decode(&got)
+		return got
+	}
+	if got := pending(); !got.Pending || got.Masked != "jo•••@example.com" {
+		t.Errorf("kiosk GET = %+v, want the masked address pending", got)
+	}
+
+	answer := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handleKioskContactCheck(rec, authRequest("POST", "/api/kiosk/contact-check", body, coachSession))
+		return rec
+	}
+	if rec := answer(`{"MemberID":"m1","Email":"jordan@"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid address: expected 400, got %d", rec.Code)
+	}
+	if rec := answer(`{"MemberID":"m1","Email":"jordan@example.org"}`); rec.Code != http.StatusOK {
+		t.Fatalf("correct: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if m, _ := stores.MemberStore.GetByID(context.Background(), "m1"); m.Email != "jordan@example.org" {
+		t.Errorf("member email = %q, want the corrected address", m.Email)
+	}
+	if got := pending(); got.Pending || got.Masked != "" {
+		t.Errorf("after answering = %+v, want nothing pending", got)
+	}
+	if rec := answer(`{"MemberID":"m1"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("second answer: expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleKioskContactCheck(rec, authRequest("GET", "/api/kiosk/contact-check?member_id=m1", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member GET: expected 403, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,342 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	availabilityDomain "workshop/internal/domain/availability"
+	scheduleDomain "workshop/internal/domain/schedule"
+	timesheetDomain "workshop/internal/domain/timesheet"
+)
+
+// availabilityWindowRequest is the body of POST /api/coach-availability.
+type availabilityWindowRequest struct {
+	CoachID   string `json:"CoachID"` // admin only; defaults to the caller
+	Day       string `json:"Day"`
+	StartTime string `json:"StartTime"`
+	EndTime   string `json:"EndTime"`
+}
+
+// availabilityExceptionRequest is the body of POST /api/coach-availability/exceptions.
+type availabilityExceptionRequest struct {
+	CoachID   string `json:"CoachID"` // admin only; defaults to the caller
+	Date      string `json:"Date"`
+	Available bool   `json:"Available"`
+	StartTime string `json:"StartTime"` // empty with EndTime for the whole day
+	EndTime   string `json:"EndTime"`
+	Reason    string `json:"Reason"`
+}
+
+// coverageAssignRequest is the body of POST /api/coverage/assign.
+type coverageAssignRequest struct {
+	ScheduleID string `json:"ScheduleID"`
+	ClassDate  string `json:"ClassDate"`
+	CoachID    string `json:"CoachID"`
+}
+
+// coachAvailabilityView is a coach's calendar as returned by GET /api/coach-availability.
+type coachAvailabilityView struct {
+	CoachID    string
+	Windows    []availabilityDomain.Window
+	Exceptions []availabilityDomain.Exception // today onwards
+}
+
+// availabilityDeps builds the deps for the coach availability orchestrators.
+func availabilityDeps() orchestrators.AvailabilityDeps {
+	return orchestrators.AvailabilityDeps{
+		AvailabilityStore: stores.AvailabilityStore,
+		AccountStore:      stores.AccountStore,
+		GenerateID:        generateID,
+		Now:               timeNow,
+	}
+}
+
+// requireStaff checks the session belongs to a coach or admin.
+// Returns false if the request should not proceed.
+func requireStaff(w http.ResponseWriter, r *http.Request) (middleware.Session, bool) {
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return middleware.Session{}, false
+	}
+	if !isStaffSession(sess) {
+		slog.WarnContext(r.Context(), "auth_denied", "path", r.URL.Path, "account_id", sess.AccountID, "role", sess.Role, "required", "coach")
+		apierror.Forbidden(w, "Forbidden")
+		return middleware.Session{}, false
+	}
+	return sess, true
+}
+
+// availabilityCoachID returns the coach whose calendar the caller is working on: admins may
+// name any coach, everyone else gets their own.
+func availabilityCoachID(sess middleware.Session, requested string) string {
+	if sess.Role == "admin" && requested != "" {
+		return requested
+	}
+	return sess.AccountID
+}
+
+// writeAvailabilityError maps coach availability errors to API responses.
+func writeAvailabilityError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, orchestrators.ErrAvailabilityNotFound):
+		apierror.NotFound(w, err.Error())
+	case errors.Is(err, orchestrators.ErrNotACoach),
+		errors.Is(err, availabilityDomain.ErrEmptyCoachID),
+		errors.Is(err, availabilityDomain.ErrInvalidDay),
+		errors.Is(err, availabilityDomain.ErrInvalidTime),
+		errors.Is(err, availabilityDomain.ErrInvalidDate),
+		errors.Is(err, availabilityDomain.ErrPartialTimes),
+		errors.Is(err, availabilityDomain.ErrReasonTooLong),
+		errors.Is(err, availabilityDomain.ErrExceptionInPast):
+		apierror.Validation(w, err.Error())
+	default:
+		internalError(w, err)
+	}
+}
+
+// handleCoachAvailabilityPage handles GET /availability
+func handleCoachAvailabilityPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+	if !isStaffSession(sess) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "coverage") {
+		return
+	}
+	renderTemplate(w, r, "coach_availability.html", map[string]any{"Today": timeNow().Format("2006-01-02")})
+}
+
+// handleCoachAvailability handles GET/POST/DELETE /api/coach-availability
+// GET ?coach_id= returns the coach's weekly windows and upcoming exceptions; POST adds a
+// window; DELETE ?id= removes one. Coaches manage their own calendar; admins may name any coach.
+func handleCoachAvailability(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireStaff(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "coverage") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		coachID := availabilityCoachID(sess, r.URL.Query().Get("coach_id"))
+		windows, err := stores.AvailabilityStore.ListWindowsByCoach(ctx, coachID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		exceptions, err := stores.AvailabilityStore.ListExceptionsByCoach(ctx, coachID, timeNow().Format("2006-01-02"))
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if windows == nil {
+			windows = []availabilityDomain.Window{}
+		}
+		if exceptions == nil {
+			exceptions = []availabilityDomain.Exception{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(coachAvailabilityView{CoachID: coachID, Windows: windows, Exceptions: exceptions})
+
+	case "POST":
+		var input availabilityWindowRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		window, err := orchestrators.ExecuteAddAvailabilityWindow(ctx, orchestrators.AddAvailabilityWindowInput{
+			CoachID:   availabilityCoachID(sess, input.CoachID),
+			Day:       input.Day,
+			StartTime: input.StartTime,
+			EndTime:   input.EndTime,
+			ActorID:   sess.AccountID,
+		}, availabilityDeps())
+		if err != nil {
+			writeAvailabilityError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(window)
+
+	case "DELETE":
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			apierror.Validation(w, "id is required")
+			return
+		}
+		err := orchestrators.ExecuteDeleteAvailabilityWindow(ctx, orchestrators.DeleteAvailabilityInput{
+			ID: id, ActorID: sess.AccountID, IsAdmin: sess.Role == "admin",
+		}, availabilityDeps())
+		if err != nil {
+			writeAvailabilityError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleCoachAvailabilityExceptions handles POST/DELETE /api/coach-availability/exceptions
+// POST marks the coach away, or free, on one date (the whole day or a time range); DELETE ?id=
+// removes the exception. Coaches manage their own calendar; admins may name any coach.
+func handleCoachAvailabilityExceptions(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireStaff(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "coverage") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "POST":
+		var input availabilityExceptionRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		exception, err := orchestrators.ExecuteAddAvailabilityException(ctx, orchestrators.AddAvailabilityExceptionInput{
+			CoachID:   availabilityCoachID(sess, input.CoachID),
+			Date:      input.Date,
+			Available: input.Available,
+			StartTime: input.StartTime,
+			EndTime:   input.EndTime,
+			Reason:    input.Reason,
+			ActorID:   sess.AccountID,
+		}, availabilityDeps())
+		if err != nil {
+			writeAvailabilityError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(exception)
+
+	case "DELETE":
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			apierror.Validation(w, "id is required")
+			return
+		}
+		err := orchestrators.ExecuteDeleteAvailabilityException(ctx, orchestrators.DeleteAvailabilityInput{
+			ID: id, ActorID: sess.AccountID, IsAdmin: sess.Role == "admin",
+		}, availabilityDeps())
+		if err != nil {
+			writeAvailabilityError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleCoverage handles GET /api/coverage
+// Returns the classes in the next four weeks that have no coach, or whose coach is away or
+// outside their availability, each with the coaches free to take it. Admin only.
+func handleCoverage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "coverage") {
+		return
+	}
+	result, err := projections.QueryGetClassCoverage(r.Context(), timeNow(), projections.GetClassCoverageDeps{
+		ScheduleStore:     stores.ScheduleStore,
+		TermStore:         stores.TermStore,
+		HolidayStore:      stores.HolidayStore,
+		ChangeStore:       stores.OccurrenceChangeStore,
+		ClassTypeStore:    stores.ClassTypeStore,
+		OverrideStore:     stores.TimesheetStore,
+		AvailabilityStore: stores.AvailabilityStore,
+		AccountStore:      stores.AccountStore,
+		MemberStore:       stores.MemberStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// handleCoverageAssign handles POST /api/coverage/assign
+// Hands one uncovered class occurrence to a coach, recorded as that date's coach override. Admin only.
+func handleCoverageAssign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "coverage") {
+		return
+	}
+	var input coverageAssignRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if input.ClassDate == "" || input.CoachID == "" {
+		apierror.Validation(w, "ClassDate and CoachID are required")
+		return
+	}
+	if _, err := stores.ScheduleStore.GetByID(r.Context(), input.ScheduleID); err != nil {
+		apierror.NotFound(w, "class not found")
+		return
+	}
+
+	err := orchestrators.ExecuteAssignCoach(r.Context(), orchestrators.AssignCoachInput{
+		ScheduleID: input.ScheduleID,
+		ClassDate:  input.ClassDate,
+		CoachID:    input.CoachID,
+		SetBy:      sess.AccountID,
+	}, orchestrators.AssignCoachDeps{
+		ScheduleStore:  stores.ScheduleStore,
+		TimesheetStore: stores.TimesheetStore,
+		AccountStore:   stores.AccountStore,
+		GenerateID:     generateID,
+		Now:            timeNow,
+	})
+	if errors.Is(err, orchestrators.ErrNotACoach) || errors.Is(err, scheduleDomain.ErrWrongDay) ||
+		errors.Is(err, timesheetDomain.ErrInvalidClassDate) {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,225 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/application/projections"
+	availabilityDomain "workshop/internal/domain/availability"
+)
+
+// --- Mock availability store ---
+
+type mockAvailabilityStore struct {
+	windows    map[string]availabilityDomain.Window
+	exceptions map[string]availabilityDomain.Exception
+}
+
+func newMockAvailabilityStore() *mockAvailabilityStore {
+	return &mockAvailabilityStore{windows: map[string]availabilityDomain.Window{}, exceptions: map[string]availabilityDomain.Exception{}}
+}
+
+// GetWindow implements availability.Store for testing.
+// PRE: id is non-empty
+// POST: Returns the window or sql.ErrNoRows
+func (m *mockAvailabilityStore) GetWindow(_ context.Context, id string) (availabilityDomain.Window, error) {
+	w, ok := m.windows[id]
+	if !ok {
+		return availabilityDomain.Window{}, sql.ErrNoRows
+	}
+	return w, nil
+}
+
+// SaveWindow implements availability.Store for testing.
+// PRE: value has been validated
+// POST: Window is upserted
+func (m *mockAvailabilityStore) SaveWindow(_ context.Context, value availabilityDomain.Window) error {
+	m.windows[value.ID] = value
+	return nil
+}
+
+// DeleteWindow implements availability.Store for testing.
+// PRE: id is non-empty
+// POST: Window is removed
+func (m *mockAvailabilityStore) DeleteWindow(_ context.Context, id string) error {
+	delete(m.windows, id)
+	return nil
+}
+
+// ListWindows implements availability.Store for testing.
+// PRE: none
+// POST: Returns every window
+func (m *mockAvailabilityStore) ListWindows(_ context.Context) ([]availabilityDomain.Window, error) {
+	var list []availabilityDomain.Window
+	for _, w := range m.windows {
+		list = append(list, w)
+	}
+	return list, nil
+}
+
+// ListWindowsByCoach implements availability.Store for testing.
+// PRE: coachID is non-empty
+// POST: Returns the coach's windows
+func (m *mockAvailabilityStore) ListWindowsByCoach(_ context.Context, coachID string) ([]availabilityDomain.Window, error) {
+	var list []availabilityDomain.Window
+	for _, w := range m.windows {
+		if w.CoachID == coachID {
+			list = append(list, w)
+		}
+	}
+	return list, nil
+}
+
+// GetException implements availability.Store for testing.
+// PRE: id is non-empty
+// POST: Returns the exception or sql.ErrNoRows
+func (m *mockAvailabilityStore) GetException(_ context.Context, id string) (availabilityDomain.Exception, error) {
+	e, ok := m.exceptions[id]
+	if !ok {
+		return availabilityDomain.Exception{}, sql.ErrNoRows
+	}
+	return e, nil
+}
+
+// SaveException implements availability.Store for testing.
+// PRE: value has been validated
+// POST: Exception is upserted
+func (m *mockAvailabilityStore) SaveException(_ context.Context, value availabilityDomain.Exception) error {
+	m.exceptions[value.ID] = value
+	return nil
+}
+
+// DeleteException implements availability.Store for testing.
+// PRE: id is non-empty
+// POST: Exception is removed
+func (m *mockAvailabilityStore) DeleteException(_ context.Context, id string) error {
+	delete(m.exceptions, id)
+	return nil
+}
+
+// ListExceptions implements availability.Store for testing.
+// PRE: from and to are YYYY-MM-DD
+// POST: Returns exceptions dated within the range
+func (m *mockAvailabilityStore) ListExceptions(_ context.Context, from, to string) ([]availabilityDomain.Exception, error) {
+	var list []availabilityDomain.Exception
+	for _, e := range m.exceptions {
+		if e.Date >= from && e.Date <= to {
+			list = append(list, e)
+		}
+	}
+	return list, nil
+}
+
+// ListExceptionsByCoach implements availability.Store for testing.
+// PRE: coachID is non-empty; from is YYYY-MM-DD
+// POST: Returns the coach's exceptions from the date on
+func (m *mockAvailabilityStore) ListExceptionsByCoach(_ context.Context, coachID, from string) ([]availabilityDomain.Exception, error) {
+	var list []availabilityDomain.Exception
+	for _, e := range m.exceptions {
+		if e.CoachID == coachID && e.Date >= from {
+			list = append(list, e)
+		}
+	}
+	return list, nil
+}
+
+// TestHandleCoverage_AvailabilityAndAssign verifies a coach's weekly availability makes them
+// a suggestion for an uncovered class, an away day takes them off it, and the admin's one-click
+// assign covers the class.
+func TestHandleCoverage_AvailabilityAndAssign(t *testing.T) {
+	var ts *mockTimesheetStore
+	stores, ts = newTimesheetTestStores()
+	stores.AvailabilityStore = newMockAvailabilityStore()
+	timeNow = func() time.Time { return time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+
+	rec := httptest.NewRecorder()
+	handleCoachAvailability(rec, authRequest("POST", "/api/coach-availability", `{"Day":"monday","StartTime":"17:00","EndTime":"21:00"}`, coachSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("add window: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	handleCoachAvailabilityExceptions(rec, authRequest("POST", "/api/coach-availability/exceptions", `{"Date":"2026-03-16","Reason":"Seminar"}`, coachSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("add exception: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	handleCoachAvailabilityExceptions(rec, authRequest("POST", "/api/coach-availability/exceptions", `{"Date":"2026-03-01"}`, coachSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("past exception: expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleCoverage(rec, authRequest("GET", "/api/coverage", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("coverage: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result projections.ClassCoverageResult
+	json.NewDecoder(rec.Body).Decode(&result)
+	// Four Mondays without a regular coach; coach-001 is free on all but the 16th.
+	if result.Classes != 4 || len(result.Uncovered) != 4 {
+		t.Fatalf("expected four uncovered Mondays, got %+v", result)
+	}
+	for _, u := range result.Uncovered {
+		want := 1
+		if u.ClassDate == "2026-03-16" {
+			want = 0
+		}
+		if len(u.Suggestions) != want {
+			t.Errorf("%s: expected %d suggestions, got %+v", u.ClassDate, want, u.Suggestions)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	handleCoverageAssign(rec, authRequest("POST", "/api/coverage/assign", `{"ScheduleID":"s-mon","ClassDate":"2026-03-09","CoachID":"coach-001"}`, adminSession))
+	if rec.Code != http.StatusNoContent || ts.overrides["s-mon|2026-03-09"].CoachID != "coach-001" {
+		t.Fatalf("assign: expected 204 and the override saved, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	handleCoverageAssign(rec, authRequest("POST", "/api/coverage/assign", `{"ScheduleID":"s-mon","CoachID":"coach-001"}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("assign without date: expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleCoverage(rec, authRequest("GET", "/api/coverage", "", adminSession))
+	json.NewDecoder(rec.Body).Decode(&result)
+	if len(result.Uncovered) != 3 {
+		t.Errorf("expected three uncovered after assigning, got %+v", result.Uncovered)
+	}
+}
+
+// TestHandleCoverage_Access verifies members cannot keep a calendar, coaches cannot edit each
+// other's or see the planner.
+func TestHandleCoverage_Access(t *testing.T) {
+	stores, _ = newTimesheetTestStores()
+	avail := newMockAvailabilityStore()
+	avail.windows["w-admin"] = availabilityDomain.Window{ID: "w-admin", CoachID: "admin-001", Day: "monday", StartTime: "17:00", EndTime: "21:00"}
+	stores.AvailabilityStore = avail
+
+	rec := httptest.NewRecorder()
+	handleCoachAvailability(rec, authRequest("POST", "/api/coach-availability", `{"Day":"monday","StartTime":"17:00","EndTime":"21:00"}`, memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member: expected 403, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleCoachAvailability(rec, authRequest("DELETE", "/api/coach-availability?id=w-admin", "", coachSession))
+	if rec.Code != http.StatusNotFound || len(avail.windows) != 1 {
+		t.Errorf("other coach's window: expected 404, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleCoverage(rec, authRequest("GET", "/api/coverage", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach planner: expected 403, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleCoachAvailability(rec, authRequest("DELETE", "/api/coach-availability?id=w-admin", "", adminSession))
+	if rec.Code != http.StatusNoContent || len(avail.windows) != 0 {
+		t.Errorf("admin: expected 204, got %d", rec.Code)
+	}
+}
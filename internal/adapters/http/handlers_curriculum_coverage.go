@@ -0,0 +1,59 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// attendanceTopicDeps wires check-ins to the rotor topics being taught; nil when rotors are not set up.
+func attendanceTopicDeps() *orchestrators.LinkAttendanceTopicsDeps {
+	if stores.RotorStore == nil || stores.ScheduleStore == nil || stores.AttendanceStore == nil {
+		return nil
+	}
+	return &orchestrators.LinkAttendanceTopicsDeps{
+		ScheduleStore: stores.ScheduleStore,
+		RotorStore:    stores.RotorStore,
+		LinkStore:     stores.AttendanceStore,
+	}
+}
+
+// handleCurriculumCoverage handles GET /api/curriculum/coverage?rotor_id=
+// Reports how many members were on the mat for each topic of a rotor, and which topics reached nobody.
+func handleCurriculumCoverage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionCurriculumEdit)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "curriculum") {
+		return
+	}
+	rotorID := r.URL.Query().Get("rotor_id")
+	if rotorID == "" {
+		apierror.Validation(w, "rotor_id is required")
+		return
+	}
+	ctx := r.Context()
+	if _, err := stores.RotorStore.GetRotor(ctx, rotorID); err != nil {
+		apierror.NotFound(w, "rotor not found")
+		return
+	}
+	result, err := projections.QueryGetTopicCoverage(ctx, projections.GetTopicCoverageQuery{RotorID: rotorID}, projections.GetTopicCoverageDeps{
+		RotorStore: stores.RotorStore,
+		LinkStore:  stores.AttendanceStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,71 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/projections"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// handleCurriculumHistory handles GET /api/curriculum/history?class_type_id=&from=
+// Lists a class's completed topics since from (90 days back by default) with their clips and
+// the classes logged while each ran, including coach notes marked shareable. Members see
+// which of those classes they attended, so they can catch up on what they missed.
+func handleCurriculumHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionCurriculumView)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "curriculum") {
+		return
+	}
+	q := r.URL.Query()
+	classTypeID := q.Get("class_type_id")
+	if classTypeID == "" {
+		apierror.Validation(w, "class_type_id is required")
+		return
+	}
+	now := timeNow()
+	from := now.AddDate(0, 0, -projections.CurriculumHistoryDefaultDays).Format("2006-01-02")
+	if v := q.Get("from"); v != "" {
+		if _, err := time.Parse("2006-01-02", v); err != nil {
+			apierror.Validation(w, "from must be YYYY-MM-DD")
+			return
+		}
+		from = v
+	}
+
+	ctx := r.Context()
+	query := projections.GetCurriculumHistoryQuery{ClassTypeID: classTypeID, From: from, Now: now, Gate: viewerBeltGate(ctx, sess)}
+	if m, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID); err == nil {
+		query.MemberID = m.ID
+	}
+	result, err := projections.QueryGetCurriculumHistory(ctx, query, projections.GetCurriculumHistoryDeps{
+		ClassTypeStore:  stores.ClassTypeStore,
+		ProgramStore:    stores.ProgramStore,
+		RotorStore:      stores.RotorStore,
+		ScheduleStore:   stores.ScheduleStore,
+		SessionLogStore: stores.SessionLogStore,
+		ThemeStore:      stores.ThemeStore,
+		ClipStore:       stores.ClipStore,
+		AttendanceStore: stores.AttendanceStore,
+	})
+	if errors.Is(err, projections.ErrCurriculumHistoryClassType) {
+		apierror.NotFound(w, err.Error())
+		return
+	}
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,43 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	classTypeDomain "workshop/internal/domain/classtype"
+)
+
+// TestHandleCurriculumHistory verifies the history needs a class type and a valid from date,
+// and hides class types outside the member's program.
+func TestHandleCurriculumHistory(t *testing.T) {
+	stores = newFullStores()
+	stores.ClassTypeStore.Save(context.Background(), classTypeDomain.ClassType{ID: "ct-kids", ProgramID: "p-kids", Name: "Kids"})
+
+	tests := []struct {
+		name string
+		url  string
+		want int
+	}{
+		{"missing class type", "/api/curriculum/history", http.StatusBadRequest},
+		{"bad from", "/api/curriculum/history?class_type_id=ct-kids&from=March", http.StatusBadRequest},
+		{"unknown class type", "/api/curriculum/history?class_type_id=missing", http.StatusNotFound},
+		{"another program", "/api/curriculum/history?class_type_id=ct-kids", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleCurriculumHistory(rec, authRequest("GET", tt.url, "", memberSession))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	handleCurriculumHistory(rec, authRequest("POST", "/api/curriculum/history?class_type_id=ct-kids", "", memberSession))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST: expected 405, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,61 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/projections"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// handleCurriculumPreview handles GET /api/curriculum/preview?class_type_id=&topics=
+// Returns the running topic of each theme in the class type's active rotor and the dates
+// the next topics are projected to run, skipping holiday weeks.
+// Members only get projections when the rotor has preview on.
+func handleCurriculumPreview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionCurriculumView)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "curriculum") {
+		return
+	}
+	q := r.URL.Query()
+	classTypeID := q.Get("class_type_id")
+	if classTypeID == "" {
+		apierror.Validation(w, "class_type_id is required")
+		return
+	}
+	topics := projections.RotorPreviewDefaultTopics
+	if v := q.Get("topics"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > projections.RotorPreviewMaxTopics {
+			apierror.Validation(w, "topics must be between 1 and "+strconv.Itoa(projections.RotorPreviewMaxTopics))
+			return
+		}
+		topics = n
+	}
+
+	ctx := r.Context()
+	result, err := projections.QueryGetRotorPreview(ctx, projections.GetRotorPreviewQuery{
+		ClassTypeID: classTypeID,
+		Topics:      topics,
+		Gate:        viewerBeltGate(ctx, sess),
+		Now:         timeNow(),
+	}, projections.GetRotorPreviewDeps{
+		RotorStore:   stores.RotorStore,
+		HolidayStore: stores.HolidayStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,116 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+
+	emailAdapter "workshop/internal/adapters/email"
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+	emailDomain "workshop/internal/domain/email"
+)
+
+// resendWebhookMaxBytes caps the size of a webhook body.
+const resendWebhookMaxBytes = 256 << 10 // 256 KB
+
+// resendWebhookSecret is the Resend endpoint signing secret ("whsec_...").
+// Webhooks are rejected while it is empty.
+var resendWebhookSecret string
+
+// SetResendWebhookSecret configures the signing secret used to verify Resend webhooks.
+func SetResendWebhookSecret(secret string) {
+	resendWebhookSecret = secret
+}
+
+// handleResendWebhook handles POST /api/webhooks/resend
+// Resend calls this (unauthenticated, Svix-signed) for email.* events; each event
+// updates the matching recipient's delivery status. Hard bounces and complaints
+// suppress the address for future sends.
+func handleResendWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	if resendWebhookSecret == "" {
+		apierror.Unavailable(w, "webhooks not configured")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, resendWebhookMaxBytes))
+	if err != nil {
+		apierror.TooLarge(w, "payload too large")
+		return
+	}
+	if err := emailAdapter.VerifyWebhookSignature(resendWebhookSecret, r.Header, body, timeNow()); err != nil {
+		slog.WarnContext(r.Context(), "email_event", "event", "webhook_rejected", "reason", err.Error(), "remote", r.RemoteAddr)
+		apierror.Unauthorized(w, "invalid signature")
+		return
+	}
+	ev, err := emailAdapter.ParseWebhookEvent(body)
+	if err != nil {
+		apierror.Validation(w, "invalid payload")
+		return
+	}
+
+	if _, err := orchestrators.ExecuteApplyEmailDelivery(r.Context(), orchestrators.ApplyEmailDeliveryInput{
+		EventType:  ev.Type,
+		MessageID:  ev.MessageID,
+		OccurredAt: ev.CreatedAt,
+		BounceType: ev.BounceType,
+		Detail:     ev.BounceMessage,
+	}, orchestrators.ApplyEmailDeliveryDeps{
+		EmailStore: stores.EmailStore,
+		Now:        timeNow,
+	}); err != nil {
+		// A 5xx makes Resend retry the event later.
+		internalError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleEmailSuppressions handles GET/DELETE for /api/emails/suppressions
+// GET lists suppressed addresses; DELETE ?address= lets an admin lift a suppression
+// after the member fixes their address. Admin only.
+func handleEmailSuppressions(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "emails") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		list, err := stores.EmailStore.ListSuppressions(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if list == nil {
+			list = []emailDomain.Suppression{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(list)
+
+	case "DELETE":
+		address := emailDomain.NormalizeAddress(r.URL.Query().Get("address"))
+		if address == "" {
+			apierror.Validation(w, "address is required")
+			return
+		}
+		if err := stores.EmailStore.DeleteSuppression(ctx, address); err != nil {
+			internalError(w, err)
+			return
+		}
+		slog.InfoContext(ctx, "email_event", "event", "suppression_lifted", "address", address, "by", sess.AccountID)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
@@ -0,0 +1,45 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestHandleResendWebhook_RequiresSignature verifies webhooks are refused when unconfigured or unsigned.
+func TestHandleResendWebhook_RequiresSignature(t *testing.T) {
+	stores = newFullStores()
+	body := `{"type":"email.delivered","data":{"email_id":"msg-1"}}`
+
+	SetResendWebhookSecret("")
+	rec := httptest.NewRecorder()
+	handleResendWebhook(rec, httptest.NewRequest("POST", "/api/webhooks/resend", strings.NewReader(body)))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("unconfigured: expected 503, got %d", rec.Code)
+	}
+
+	SetResendWebhookSecret("whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw")
+	defer SetResendWebhookSecret("")
+	req := httptest.NewRequest("POST", "/api/webhooks/resend", strings.NewReader(body))
+	req.Header.Set("svix-id", "evt-1")
+	req.Header.Set("svix-timestamp", strconv.FormatInt(timeNow().Unix(), 10))
+	req.Header.Set("svix-signature", "v1,bm90LWEtc2lnbmF0dXJl")
+	rec = httptest.NewRecorder()
+	handleResendWebhook(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("bad signature: expected 401, got %d", rec.Code)
+	}
+}
+
+// TestHandleEmailSuppressions_AdminOnly verifies non-admins cannot view the suppression list.
+func TestHandleEmailSuppressions_AdminOnly(t *testing.T) {
+	stores = newFullStores()
+
+	rec := httptest.NewRecorder()
+	handleEmailSuppressions(rec, authRequest("GET", "/api/emails/suppressions", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
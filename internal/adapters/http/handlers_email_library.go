@@ -0,0 +1,272 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	emailDomain "workshop/internal/domain/email"
+)
+
+// nextClassSearchDays is how far ahead {{NextClassDate}} looks for a class.
+const nextClassSearchDays = 14
+
+// noNextClass fills {{NextClassDate}} when nothing is scheduled in the search window.
+const noNextClass = "listed on the timetable"
+
+// emailLibraryRequest is the body of POST /api/emails/library.
+type emailLibraryRequest struct {
+	Key      string `json:"Key"` // optional: empty derives a key from Name for a new template
+	Name     string `json:"Name"`
+	Category string `json:"Category"`
+	Subject  string `json:"Subject"`
+	Body     string `json:"Body"`
+	Default  bool   `json:"Default"`
+}
+
+// emailLibraryPreviewRequest is the body of POST /api/emails/library/preview.
+type emailLibraryPreviewRequest struct {
+	Subject string `json:"Subject"`
+	Body    string `json:"Body"`
+}
+
+// handleEmailLibrary handles GET/POST/DELETE for /api/emails/library
+// GET lists the template library: the built-in templates, then the gym's own. POST saves a
+// template, creating it when the key is new. DELETE ?key= removes a template; for a built-in
+// it restores the system's wording. Admin only.
+func handleEmailLibrary(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "emails") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		saved, err := stores.EmailLibraryStore.List(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"Templates": emailDomain.Library(saved),
+			"Variables": emailDomain.Variables,
+		})
+
+	case "POST":
+		var input emailLibraryRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		t := emailDomain.MessageTemplate{
+			ID:        generateID(),
+			Key:       input.Key,
+			Name:      strings.TrimSpace(input.Name),
+			Category:  input.Category,
+			Subject:   strings.TrimSpace(input.Subject),
+			Body:      input.Body,
+			Default:   input.Default,
+			UpdatedBy: sess.AccountID,
+			UpdatedAt: timeNow(),
+		}
+		if t.Key == "" {
+			t.Key = templateKeyFromName(t.Name)
+			if _, err := stores.EmailLibraryStore.GetByKey(ctx, t.Key); err == nil {
+				apierror.Conflict(w, "a template with this name already exists")
+				return
+			}
+		}
+		if builtIn, ok := emailDomain.BuiltInTemplate(t.Key); ok {
+			t.Category = builtIn.Category // the system sends it under a fixed category
+		}
+		if existing, err := stores.EmailLibraryStore.GetByKey(ctx, t.Key); err == nil {
+			t.ID = existing.ID
+		}
+		if err := t.Validate(); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err := stores.EmailLibraryStore.Save(ctx, t); err != nil {
+			internalError(w, err)
+			return
+		}
+		_, t.BuiltIn = emailDomain.BuiltInTemplate(t.Key)
+		slog.InfoContext(ctx, "email_event", "event", "library_template_saved", "key", t.Key, "account_id", sess.AccountID)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(t)
+
+	case "DELETE":
+		key := r.URL.Query().Get("key")
+		if key == "" {
+			apierror.Validation(w, "key is required")
+			return
+		}
+		if _, err := stores.EmailLibraryStore.GetByKey(ctx, key); err != nil {
+			apierror.NotFound(w, "template not found")
+			return
+		}
+		if err := stores.EmailLibraryStore.DeleteByKey(ctx, key); err != nil {
+			internalError(w, err)
+			return
+		}
+		slog.InfoContext(ctx, "email_event", "event", "library_template_deleted", "key", key, "account_id", sess.AccountID)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleEmailLibraryPreview handles POST /api/emails/library/preview
+// Renders a template's subject and body with sample member data inside the active
+// header and footer. Admin only.
+func handleEmailLibraryPreview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "emails") {
+		return
+	}
+
+	var input emailLibraryPreviewRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	// Validate with placeholder metadata so only the subject and body are checked.
+	t := emailDomain.MessageTemplate{Key: "preview", Name: "Preview", Category: emailDomain.CategoryAnnouncements, Subject: input.Subject, Body: input.Body}
+	if err := t.Validate(); err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+
+	subject, body := t.Render(emailDomain.SampleVars())
+	var wrapper emailDomain.EmailTemplate
+	if active, err := stores.EmailStore.GetActiveTemplate(r.Context()); err == nil {
+		wrapper = active
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"Subject": subject, "HTML": wrapper.WrapBody(body)})
+}
+
+// templateKeyFromName derives a library key from a template name: lowercase words joined
+// by underscores, e.g. "Competition reminder" becomes "competition_reminder".
+func templateKeyFromName(name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, c := range strings.ToLower(name) {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(c)
+			underscore = false
+		default:
+			underscore = true
+		}
+	}
+	key := b.String()
+	if len(key) > 50 {
+		key = strings.TrimRight(key[:50], "_")
+	}
+	return key
+}
+
+// sendTemplatedEmail sends a library email to one member, if email is configured. Failures
+// are logged and never block the action that triggered the email.
+func sendTemplatedEmail(ctx context.Context, key, memberID string, vars emailDomain.Vars) {
+	if emailSender == nil || stores.EmailLibraryStore == nil || memberID == "" {
+		return
+	}
+	m, err := stores.MemberStore.GetByID(ctx, memberID)
+	if err != nil {
+		return
+	}
+	if vars == nil {
+		vars = emailDomain.Vars{}
+	}
+	if vars[emailDomain.VarNextClassDate] == "" {
+		vars[emailDomain.VarNextClassDate] = NextClassDates(stores, timeNow)(ctx, m.Program)
+	}
+	res, err := orchestrators.ExecuteSendTemplatedEmail(ctx, orchestrators.SendTemplatedEmailInput{
+		TemplateKey: key,
+		MemberID:    memberID,
+		Vars:        vars,
+	}, TemplatedEmailDeps(stores, appConfig.Email.PublicURL, unsubscribeKey, timeNow))
+	if err != nil {
+		slog.WarnContext(ctx, "email_event", "event", "templated_email_failed", "template", key, "member_id", memberID, "error", err)
+		return
+	}
+	if res.Skipped != "" {
+		slog.InfoContext(ctx, "email_event", "event", "templated_email_skipped", "template", key, "member_id", memberID, "reason", res.Skipped)
+	}
+}
+
+// TemplatedEmailDeps returns the dependencies for sending library emails through the
+// configured sender, with unsubscribe links pointing at baseURL.
+func TemplatedEmailDeps(s *Stores, baseURL string, key []byte, now func() time.Time) orchestrators.SendTemplatedEmailDeps {
+	return orchestrators.SendTemplatedEmailDeps{
+		LibraryStore:    s.EmailLibraryStore,
+		EmailStore:      s.EmailStore,
+		MemberLookup:    &memberLookupAdapter{},
+		EmailSender:     emailSender,
+		PreferenceStore: s.EmailPreferenceStore,
+		UnsubscribeURL:  UnsubscribeLinks(baseURL, key),
+		GenerateID:      generateID,
+		Now:             now,
+		FromAddress:     emailFromAddress,
+		ReplyTo:         emailReplyTo,
+	}
+}
+
+// NextClassDates returns the {{NextClassDate}} lookup: the day of the next class on the
+// timetable for a program within two weeks, e.g. "Tuesday 3 February at 18:00".
+func NextClassDates(s *Stores, now func() time.Time) func(ctx context.Context, program string) string {
+	return func(ctx context.Context, program string) string {
+		deps := projections.GetTodaysClassesDeps{
+			ScheduleStore:  s.ScheduleStore,
+			TermStore:      s.TermStore,
+			HolidayStore:   s.HolidayStore,
+			ClassTypeStore: s.ClassTypeStore,
+			ProgramStore:   s.ProgramStore,
+			ChangeStore:    s.OccurrenceChangeStore,
+		}
+		start := now()
+		for i := 0; i < nextClassSearchDays; i++ {
+			day := start.AddDate(0, 0, i)
+			classes, err := projections.QueryGetTodaysClasses(ctx, day, deps)
+			if err != nil {
+				return noNextClass
+			}
+			sort.Slice(classes, func(a, b int) bool { return classes[a].StartTime < classes[b].StartTime })
+			for _, c := range classes {
+				if program != "" && c.ProgramType != program {
+					continue
+				}
+				if i == 0 && c.StartTime <= start.Format("15:04") {
+					continue // already started today
+				}
+				return day.Format("Monday 2 January") + " at " + c.StartTime
+			}
+		}
+		return noNextClass
+	}
+}
@@ -0,0 +1,159 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	emailDomain "workshop/internal/domain/email"
+)
+
+type mockEmailLibraryStore struct {
+	templates map[string]emailDomain.MessageTemplate
+}
+
+// Save implements email.LibraryStore for testing.
+// PRE: t has been validated
+// POST: The template is upserted by key; a default clears its category's other defaults
+func (m *mockEmailLibraryStore) Save(_ context.Context, t emailDomain.MessageTemplate) error {
+	if t.Default {
+		for k, other := range m.templates {
+			if other.Category == t.Category {
+				other.Default = false
+				m.templates[k] = other
+			}
+		}
+	}
+	m.templates[t.Key] = t
+	return nil
+}
+
+// GetByKey implements email.LibraryStore for testing.
+// PRE: key is non-empty
+// POST: Returns the template or sql.ErrNoRows
+func (m *mockEmailLibraryStore) GetByKey(_ context.Context, key string) (emailDomain.MessageTemplate, error) {
+	t, ok := m.templates[key]
+	if !ok {
+		return emailDomain.MessageTemplate{}, sql.ErrNoRows
+	}
+	return t, nil
+}
+
+// List implements email.LibraryStore for testing.
+// PRE: none
+// POST: Returns the saved templates
+func (m *mockEmailLibraryStore) List(_ context.Context) ([]emailDomain.MessageTemplate, error) {
+	var out []emailDomain.MessageTemplate
+	for _, t := range m.templates {
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// DeleteByKey implements email.LibraryStore for testing.
+// PRE: key is non-empty
+// POST: The template is removed
+func (m *mockEmailLibraryStore) DeleteByKey(_ context.Context, key string) error {
+	delete(m.templates, key)
+	return nil
+}
+
+// setupEmailLibraryStores gives the stores an empty template library.
+func setupEmailLibraryStores() *mockEmailLibraryStore {
+	stores = newFullStores()
+	library := &mockEmailLibraryStore{templates: map[string]emailDomain.MessageTemplate{}}
+	stores.EmailLibraryStore = library
+	return library
+}
+
+// TestHandleEmailLibrary verifies admins see the built-ins, can add their own template under
+// a key derived from its name, reword a built-in and restore it.
+func TestHandleEmailLibrary(t *testing.T) {
+	library := setupEmailLibraryStores()
+
+	rec := httptest.NewRecorder()
+	handleEmailLibrary(rec, authRequest("GET", "/api/emails/library", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var got struct {
+		Templates []emailDomain.MessageTemplate
+		Variables []string
+	}
+	json.NewDecoder(rec.Body).Decode(&got)
+	if len(got.Templates) != 9 || got.Templates[0].Key != emailDomain.TemplateWelcome || len(got.Variables) != len(emailDomain.Variables) {
+		t.Errorf("GET = %+v, want the seven built-ins and every variable", got)
+	}
+
+	rec = httptest.NewRecorder()
+	handleEmailLibrary(rec, authRequest("POST", "/api/emails/library",
+		`{"Name":"Competition reminder","Category":"announcements","Subject":"Good luck {{MemberName}}","Body":"<p>See you there</p>","Default":true}`, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST new: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if saved, ok := library.templates["competition_reminder"]; !ok || !saved.Default || saved.UpdatedBy != adminSession.AccountID {
+		t.Errorf("saved = %+v, want competition_reminder as the announcements default", saved)
+	}
+
+	rec = httptest.NewRecorder()
+	handleEmailLibrary(rec, authRequest("POST", "/api/emails/library",
+		`{"Name":"Competition Reminder!","Category":"announcements","Subject":"Again","Body":"<p>Again</p>"}`, adminSession))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("POST duplicate name: expected 409, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleEmailLibrary(rec, authRequest("POST", "/api/emails/library",
+		`{"Key":"grading_congratulation","Name":"Promotion","Category":"announcements","Subject":"Well done {{Belt}}","Body":"<p>{{Rank}}</p>"}`, adminSession))
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "{{Rank}}") {
+		t.Errorf("POST unknown variable: expected 400 naming it, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleEmailLibrary(rec, authRequest("POST", "/api/emails/library",
+		`{"Key":"grading_congratulation","Name":"Promotion","Category":"announcements","Subject":"Well done, {{Belt}} belt","Body":"<p>Onwards</p>"}`, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST built-in: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if saved := library.templates[emailDomain.TemplateGradingCongratulation]; saved.Category != emailDomain.CategoryGrading {
+		t.Errorf("built-in category = %q, want it kept as grading", saved.Category)
+	}
+
+	rec = httptest.NewRecorder()
+	handleEmailLibrary(rec, authRequest("DELETE", "/api/emails/library?key=grading_congratulation", "", adminSession))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("DELETE: expected 204, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleEmailLibrary(rec, authRequest("GET", "/api/emails/library", "", adminSession))
+	json.NewDecoder(rec.Body).Decode(&got)
+	if len(got.Templates) != 10 || !strings.HasPrefix(got.Templates[2].Subject, "Congratulations") {
+		t.Errorf("after DELETE = %+v, want the built-in wording restored", got.Templates)
+	}
+}
+
+// TestHandleEmailLibrary_AdminOnly verifies coaches cannot read or edit the library.
+func TestHandleEmailLibrary_AdminOnly(t *testing.T) {
+	setupEmailLibraryStores()
+
+	rec := httptest.NewRecorder()
+	handleEmailLibrary(rec, authRequest("GET", "/api/emails/library", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleEmailLibraryPreview_UnknownVariable verifies a preview names a misspelt variable.
+func TestHandleEmailLibraryPreview_UnknownVariable(t *testing.T) {
+	setupEmailLibraryStores()
+
+	rec := httptest.NewRecorder()
+	handleEmailLibraryPreview(rec, authRequest("POST", "/api/emails/library/preview", `{"Subject":"Hi","Body":"<p>{{MemberNmae}}</p>"}`, adminSession))
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "{{MemberNmae}}") {
+		t.Errorf("expected 400 naming the variable, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,279 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	emailDomain "workshop/internal/domain/email"
+)
+
+// UnsubscribeLinks returns the builder for per-recipient unsubscribe links pointing at baseURL.
+// It returns nil when baseURL is empty, so emails go out without links rather than with broken ones.
+func UnsubscribeLinks(baseURL string, key []byte) func(memberID, category string) string {
+	if baseURL == "" {
+		return nil
+	}
+	return func(memberID, category string) string {
+		return emailDomain.UnsubscribeURL(baseURL, key, memberID, category)
+	}
+}
+
+// emailLinkBaseURL is the origin for links in emails sent while handling r:
+// WORKSHOP_PUBLIC_URL when configured, otherwise the host the admin is using.
+func emailLinkBaseURL(r *http.Request) string {
+	if appConfig.Email.PublicURL != "" {
+		return appConfig.Email.PublicURL
+	}
+	return requestBaseURL(r)
+}
+
+// categoryLabels names each optional category on the unsubscribe page.
+var categoryLabels = map[string]string{
+	emailDomain.CategoryAnnouncements: "Announcements",
+	emailDomain.CategoryGrading:       "Grading",
+	emailDomain.CategoryBilling:       "Billing",
+	emailDomain.CategoryDigest:        "Weekly digest",
+}
+
+// communicationPreferencesRequest is the body of PUT /api/communication-preferences.
+type communicationPreferencesRequest struct {
+	Announcements bool `json:"Announcements"`
+	Grading       bool `json:"Grading"`
+	Billing       bool `json:"Billing"`
+	Digest        bool `json:"Digest"`
+}
+
+// changes lists every optional category with the requested setting.
+func (p communicationPreferencesRequest) changes() map[string]bool {
+	return map[string]bool{
+		emailDomain.CategoryAnnouncements: p.Announcements,
+		emailDomain.CategoryGrading:       p.Grading,
+		emailDomain.CategoryBilling:       p.Billing,
+		emailDomain.CategoryDigest:        p.Digest,
+	}
+}
+
+// unsubscribeRequest is the body of POST /api/unsubscribe.
+type unsubscribeRequest struct {
+	Token string `json:"Token"`
+	communicationPreferencesRequest
+}
+
+// WeeklyDigestDeps wires the weekly digest worker: each subscriber's week is summarised from
+// their training log and the curriculum of the classes they attend, then sent with the
+// weekly_digest library template.
+func WeeklyDigestDeps(s *Stores, baseURL string, key []byte, now func() time.Time) orchestrators.SendWeeklyDigestsDeps {
+	return orchestrators.SendWeeklyDigestsDeps{
+		PreferenceStore: s.EmailPreferenceStore,
+		MemberStore:     s.MemberStore,
+		Summarise: func(ctx context.Context, memberID string, weekStart time.Time) (orchestrators.WeeklyDigest, error) {
+			result, err := projections.QueryGetWeeklyDigest(ctx, projections.GetWeeklyDigestQuery{
+				MemberID:  memberID,
+				WeekStart: weekStart,
+				Now:       now(),
+			}, projections.GetWeeklyDigestDeps{
+				TrainingLog: projections.GetTrainingLogDeps{
+					AttendanceStore:     s.AttendanceStore,
+					MemberStore:         s.MemberStore,
+					GradingRecordStore:  s.GradingRecordStore,
+					GradingConfigStore:  s.GradingConfigStore,
+					EstimatedHoursStore: s.EstimatedHoursStore,
+				},
+				ScheduleStore: s.ScheduleStore,
+				Curriculum: projections.GetThemeCarouselDeps{
+					ClassTypeStore: s.ClassTypeStore,
+					ProgramStore:   s.ProgramStore,
+					RotorStore:     s.RotorStore,
+				},
+			})
+			if err != nil {
+				return orchestrators.WeeklyDigest{}, err
+			}
+			digest := orchestrators.WeeklyDigest{
+				Classes:       result.Classes,
+				MatHours:      result.MatHours,
+				Streak:        result.Streak,
+				Belt:          result.Belt,
+				NextBelt:      result.NextBelt,
+				ProgressPct:   result.ProgressPct,
+				RequiredHours: result.RequiredHours,
+			}
+			for _, c := range result.Topics {
+				digest.Topics = append(digest.Topics, orchestrators.WeeklyDigestClassTopics{ClassTypeName: c.ClassTypeName, Topics: c.Topics})
+			}
+			return digest, nil
+		},
+		NextClassDate: NextClassDates(s, now),
+		Send:          TemplatedEmailDeps(s, baseURL, key, now),
+		Now:           now,
+	}
+}
+
+// memberPreferences returns a member's saved preferences, or the defaults if they never changed them.
+func memberPreferences(r *http.Request, memberID string) (emailDomain.Preferences, error) {
+	prefs, err := stores.EmailPreferenceStore.GetByMemberID(r.Context(), memberID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return emailDomain.DefaultPreferences(memberID), nil
+	}
+	return prefs, err
+}
+
+// handleUnsubscribePage handles GET /unsubscribe?token=
+// The link in every non-account email. Opens without logging in: the signed token names
+// the member and category, which is switched off straight away. The page then offers
+// the member's other categories.
+func handleUnsubscribePage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token := r.URL.Query().Get("token")
+	memberID, category, err := emailDomain.VerifyUnsubscribeToken(unsubscribeKey, token)
+	if err != nil {
+		renderTemplate(w, r, "unsubscribe.html", map[string]any{"Error": "This unsubscribe link is not valid. Log in to change your email preferences."})
+		return
+	}
+	if _, err := stores.MemberStore.GetByID(r.Context(), memberID); err != nil {
+		renderTemplate(w, r, "unsubscribe.html", map[string]any{"Error": "This unsubscribe link is no longer valid."})
+		return
+	}
+
+	prefs, err := orchestrators.ExecuteUpdateCommunicationPreferences(r.Context(), orchestrators.UpdateCommunicationPreferencesInput{
+		MemberID: memberID,
+		Changes:  map[string]bool{category: false},
+		Source:   "unsubscribe_link",
+	}, orchestrators.UpdateCommunicationPreferencesDeps{
+		PreferenceStore: stores.EmailPreferenceStore,
+		Now:             timeNow,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	renderTemplate(w, r, "unsubscribe.html", map[string]any{
+		"Token":       token,
+		"Category":    categoryLabels[category],
+		"Preferences": prefs,
+	})
+}
+
+// handleUnsubscribe handles POST /api/unsubscribe
+// Saves the categories chosen on the unsubscribe page. Authenticated by the link's token, not a session.
+func handleUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	var input unsubscribeRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	memberID, _, err := emailDomain.VerifyUnsubscribeToken(unsubscribeKey, input.Token)
+	if err != nil {
+		apierror.Unauthorized(w, err.Error())
+		return
+	}
+	if _, err := stores.MemberStore.GetByID(r.Context(), memberID); err != nil {
+		apierror.NotFound(w, "member not found")
+		return
+	}
+
+	prefs, err := orchestrators.ExecuteUpdateCommunicationPreferences(r.Context(), orchestrators.UpdateCommunicationPreferencesInput{
+		MemberID: memberID,
+		Changes:  input.changes(),
+		Source:   "unsubscribe_page",
+	}, orchestrators.UpdateCommunicationPreferencesDeps{
+		PreferenceStore: stores.EmailPreferenceStore,
+		Now:             timeNow,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(prefs)
+}
+
+// handleCommunicationPreferences handles GET/PUT for /api/communication-preferences
+// The logged-in member reads or changes which optional email categories they receive.
+// Account emails (activation, password resets) are always sent.
+func handleCommunicationPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	member, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID)
+	if err != nil {
+		apierror.NotFound(w, "member not found")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		prefs, err := memberPreferences(r, member.ID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(prefs)
+
+	case "PUT":
+		var input communicationPreferencesRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		prefs, err := orchestrators.ExecuteUpdateCommunicationPreferences(ctx, orchestrators.UpdateCommunicationPreferencesInput{
+			MemberID: member.ID,
+			Changes:  input.changes(),
+			Source:   "settings",
+		}, orchestrators.UpdateCommunicationPreferencesDeps{
+			PreferenceStore: stores.EmailPreferenceStore,
+			Now:             timeNow,
+		})
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(prefs)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleEmailUnsubscribes handles GET /api/emails/unsubscribes
+// Reports how many members have opted out of each optional category. Admin only.
+func handleEmailUnsubscribes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "emails") {
+		return
+	}
+	counts, err := stores.EmailPreferenceStore.CountUnsubscribed(r.Context())
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(counts)
+}
@@ -0,0 +1,190 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	emailDomain "workshop/internal/domain/email"
+	memberDomain "workshop/internal/domain/member"
+)
+
+type mockEmailPreferenceStore struct {
+	prefs       map[string]emailDomain.Preferences
+	digestsSent map[string]bool // member ID + week start
+}
+
+// GetByMemberID implements email.PreferenceStore for testing.
+// PRE: memberID is non-empty
+// POST: Returns the saved preferences or sql.ErrNoRows
+func (m *mockEmailPreferenceStore) GetByMemberID(_ context.Context, memberID string) (emailDomain.Preferences, error) {
+	p, ok := m.prefs[memberID]
+	if !ok {
+		return emailDomain.Preferences{}, sql.ErrNoRows
+	}
+	return p, nil
+}
+
+// Save implements email.PreferenceStore for testing.
+// PRE: p.MemberID is non-empty
+// POST: Preferences are upserted
+func (m *mockEmailPreferenceStore) Save(_ context.Context, p emailDomain.Preferences) error {
+	m.prefs[p.MemberID] = p
+	return nil
+}
+
+// CountUnsubscribed implements email.PreferenceStore for testing.
+// PRE: none
+// POST: Returns opt-outs per optional category
+func (m *mockEmailPreferenceStore) CountUnsubscribed(_ context.Context) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, c := range emailDomain.OptionalCategories {
+		counts[c] = 0
+	}
+	for _, p := range m.prefs {
+		for _, c := range emailDomain.OptionalCategories {
+			if !p.Allows(c) {
+				counts[c]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// ListDigestSubscribers implements email.PreferenceStore for testing.
+// PRE: none
+// POST: Returns the members opted in to the digest
+func (m *mockEmailPreferenceStore) ListDigestSubscribers(_ context.Context) ([]string, error) {
+	var ids []string
+	for id, p := range m.prefs {
+		if p.Digest {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// MarkDigestSent implements email.PreferenceStore for testing.
+// PRE: memberID and weekStart are non-empty
+// POST: Returns true the first time a member's week is marked
+func (m *mockEmailPreferenceStore) MarkDigestSent(_ context.Context, memberID, weekStart string, _ time.Time) (bool, error) {
+	if m.digestsSent == nil {
+		m.digestsSent = map[string]bool{}
+	}
+	key := memberID + "/" + weekStart
+	if m.digestsSent[key] {
+		return false, nil
+	}
+	m.digestsSent[key] = true
+	return true, nil
+}
+
+// setupEmailPreferenceStores gives the member session a member record and an empty preference store.
+func setupEmailPreferenceStores() *mockEmailPreferenceStore {
+	stores = newFullStores()
+	prefs := &mockEmailPreferenceStore{prefs: map[string]emailDomain.Preferences{}}
+	stores.EmailPreferenceStore = prefs
+	unsubscribeKey = []byte("0123456789abcdef0123456789abcdef")
+	stores.MemberStore.Save(context.Background(), memberDomain.Member{ID: "m1", AccountID: memberSession.AccountID, Name: "Marcus", Email: memberSession.Email, Program: "adults", Status: "active"})
+	return prefs
+}
+
+// TestHandleUnsubscribe verifies the emailed link opts the member out without logging in,
+// and the page's form can then change the other categories with the same token.
+func TestHandleUnsubscribe(t *testing.T) {
+	prefs := setupEmailPreferenceStores()
+	link := UnsubscribeLinks("https://gym.example.com", unsubscribeKey)("m1", emailDomain.CategoryGrading)
+	parsed, _ := url.Parse(link)
+	token := parsed.Query().Get("token")
+
+	rec := httptest.NewRecorder()
+	handleUnsubscribePage(rec, httptest.NewRequest("GET", "/unsubscribe?"+parsed.RawQuery, nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "unsubscribed from Grading emails") {
+		t.Fatalf("page: expected confirmation, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if p := prefs.prefs["m1"]; p.Grading || !p.Announcements || !p.Billing {
+		t.Fatalf("expected only grading switched off, got %+v", p)
+	}
+
+	req := httptest.NewRequest("POST", "/api/unsubscribe", strings.NewReader(`{"Token":"`+token+`","Announcements":false,"Grading":false,"Billing":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	handleUnsubscribe(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("save: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if p := prefs.prefs["m1"]; p.Announcements || p.Grading || !p.Billing {
+		t.Errorf("expected announcements and grading off, got %+v", p)
+	}
+
+	rec = httptest.NewRecorder()
+	handleEmailUnsubscribes(rec, authRequest("GET", "/api/emails/unsubscribes", "", adminSession))
+	var counts map[string]int
+	json.NewDecoder(rec.Body).Decode(&counts)
+	if rec.Code != http.StatusOK || counts[emailDomain.CategoryAnnouncements] != 1 || counts[emailDomain.CategoryBilling] != 0 {
+		t.Errorf("report: expected one announcements opt-out, got %d %v", rec.Code, counts)
+	}
+}
+
+// TestHandleUnsubscribe_InvalidToken verifies forged or unknown links change nothing.
+func TestHandleUnsubscribe_InvalidToken(t *testing.T) {
+	prefs := setupEmailPreferenceStores()
+	forged := emailDomain.SignUnsubscribeToken([]byte("another key"), "m1", emailDomain.CategoryGrading)
+	unknownMember := emailDomain.SignUnsubscribeToken(unsubscribeKey, "m9", emailDomain.CategoryGrading)
+
+	for _, token := range []string{"", forged, unknownMember} {
+		rec := httptest.NewRecorder()
+		handleUnsubscribePage(rec, httptest.NewRequest("GET", "/unsubscribe?token="+url.QueryEscape(token), nil))
+		if !strings.Contains(rec.Body.String(), "not valid") && !strings.Contains(rec.Body.String(), "no longer valid") {
+			t.Errorf("token %q: expected an error page, got %s", token, rec.Body.String())
+		}
+	}
+
+	req := httptest.NewRequest("POST", "/api/unsubscribe", strings.NewReader(`{"Token":"`+forged+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handleUnsubscribe(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a forged token, got %d", rec.Code)
+	}
+	if len(prefs.prefs) != 0 {
+		t.Errorf("expected no preferences saved, got %+v", prefs.prefs)
+	}
+}
+
+// TestHandleCommunicationPreferences verifies members read defaults and save their own choices.
+func TestHandleCommunicationPreferences(t *testing.T) {
+	prefs := setupEmailPreferenceStores()
+
+	rec := httptest.NewRecorder()
+	handleCommunicationPreferences(rec, authRequest("GET", "/api/communication-preferences", "", memberSession))
+	var got emailDomain.Preferences
+	json.NewDecoder(rec.Body).Decode(&got)
+	if rec.Code != http.StatusOK || !got.Announcements || !got.Grading || !got.Billing || got.Digest {
+		t.Fatalf("expected everything but the digest on by default, got %d %+v", rec.Code, got)
+	}
+
+	rec = httptest.NewRecorder()
+	handleCommunicationPreferences(rec, authRequest("PUT", "/api/communication-preferences", `{"Announcements":true,"Grading":true,"Billing":false}`, memberSession))
+	if rec.Code != http.StatusOK || prefs.prefs["m1"].Billing {
+		t.Fatalf("expected billing off, got %d %+v", rec.Code, prefs.prefs["m1"])
+	}
+
+	rec = httptest.NewRecorder()
+	handleCommunicationPreferences(rec, authRequest("PUT", "/api/communication-preferences", `{"Announcements":true,"Grading":true,"Billing":false,"Digest":true}`, memberSession))
+	if subscribers, _ := prefs.ListDigestSubscribers(context.Background()); rec.Code != http.StatusOK || len(subscribers) != 1 {
+		t.Fatalf("expected the member opted in to the digest, got %d %v", rec.Code, subscribers)
+	}
+
+	rec = httptest.NewRecorder()
+	handleEmailUnsubscribes(rec, authRequest("GET", "/api/emails/unsubscribes", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("report: expected 403 for a member, got %d", rec.Code)
+	}
+}
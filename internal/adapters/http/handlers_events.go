@@ -0,0 +1,201 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/events"
+	"workshop/internal/adapters/http/middleware"
+	accountDomain "workshop/internal/domain/account"
+	notificationDomain "workshop/internal/domain/notification"
+)
+
+// Live event types sent on GET /api/events/stream.
+const (
+	eventAttendanceCheckIn = "attendance.checkin"
+	eventAttendanceUndo    = "attendance.undo"
+	eventMessageNew        = "message.new"
+	eventNoticePublished   = "notice.published"
+	eventNotification      = "notification" // any other notification for the account
+)
+
+// liveEventTypes are the types a client may ask for with ?types=.
+var liveEventTypes = []string{eventAttendanceCheckIn, eventAttendanceUndo, eventMessageNew, eventNoticePublished, eventNotification}
+
+// liveEventRetry is the reconnect delay, in milliseconds, the stream asks browsers to use.
+const liveEventRetry = 3000
+
+// liveEventKeepAlive is how long the stream may sit idle before it sends a comment, so
+// proxies do not close the connection.
+var liveEventKeepAlive = 25 * time.Second
+
+// staffRoles receive attendance events: members do not see who else has checked in.
+var staffRoles = []string{accountDomain.RoleAdmin, accountDomain.RoleCoach}
+
+// attendanceEvent is the data of attendance.checkin and attendance.undo. Count is set
+// instead of MemberID when one request recorded several check-ins.
+type attendanceEvent struct {
+	MemberID   string `json:",omitempty"`
+	ScheduleID string `json:",omitempty"`
+	ClassDate  string `json:",omitempty"`
+	Count      int    `json:",omitempty"`
+}
+
+// notificationEvent is the data of message.new, notice.published and notification.
+type notificationEvent struct {
+	Kind  string
+	Title string
+	Body  string `json:",omitempty"`
+	Link  string `json:",omitempty"`
+}
+
+// publishEvent sends a live event to the subscribers it is addressed to. Empty roles or
+// accountIDs address everyone.
+func publishEvent(typ string, data any, roles, accountIDs []string) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		slog.Error("live_event", "event", "marshal_failed", "type", typ, "error", err)
+		return
+	}
+	eventHub.Publish(events.Event{Type: typ, Data: raw, Roles: roles, AccountIDs: accountIDs, At: timeNow()})
+}
+
+// publishAttendance tells staff an attendance record was added or removed.
+func publishAttendance(typ string, data attendanceEvent) {
+	if data.ClassDate == "" {
+		data.ClassDate = timeNow().Format("2006-01-02")
+	}
+	publishEvent(typ, data, staffRoles, nil)
+}
+
+// publishNotification mirrors a notification to its recipients' open pages.
+func publishNotification(accountIDs []string, kind, title, body, link string) {
+	typ := eventNotification
+	switch kind {
+	case notificationDomain.KindMessageReceived:
+		typ = eventMessageNew
+	case notificationDomain.KindNoticePublished:
+		typ = eventNoticePublished
+	}
+	publishEvent(typ, notificationEvent{Kind: kind, Title: title, Body: body, Link: link}, nil, accountIDs)
+}
+
+// parseEventTypes reads the comma-separated ?types= filter.
+func parseEventTypes(raw string) ([]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var types []string
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.TrimSpace(t)
+		if !isLiveEventType(t) {
+			return nil, fmt.Errorf("unknown event type %q", t)
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+// isLiveEventType reports whether t is a known live event type.
+func isLiveEventType(t string) bool {
+	for _, known := range liveEventTypes {
+		if known == t {
+			return true
+		}
+	}
+	return false
+}
+
+// writeLiveEvent writes one event in server-sent events format.
+func writeLiveEvent(w http.ResponseWriter, e events.Event) error {
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data)
+	return err
+}
+
+// handleEventsStream handles GET /api/events/stream
+// Server-sent events for open pages: check-ins for staff, and new messages, published
+// notices and other notifications for their recipients. ?types= narrows the stream.
+// A browser that reconnects sends Last-Event-ID and is sent what it missed; if that is
+// no longer known, a "reset" event tells the page to reload its data.
+func handleEventsStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "live_updates") {
+		return
+	}
+	types, err := parseEventTypes(r.URL.Query().Get("types"))
+	if err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	var lastID uint64
+	if v := r.Header.Get("Last-Event-ID"); v != "" {
+		if lastID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			apierror.Validation(w, "Last-Event-ID must be a number")
+			return
+		}
+	}
+
+	sub := eventHub.Subscribe(events.Filter{AccountID: sess.AccountID, Role: sess.Role, Types: types}, lastID)
+	defer sub.Cancel()
+
+	ctx := r.Context()
+	rc := http.NewResponseController(w)
+	// The server's write timeout would cut the stream off; pages stay open for hours.
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", liveEventRetry)
+	if sub.Missed {
+		fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+	}
+	for _, e := range sub.Replay {
+		if writeLiveEvent(w, e) != nil {
+			return
+		}
+	}
+	if rc.Flush() != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(liveEventKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-sub.C:
+			if !ok {
+				// Dropped for falling behind; the browser reconnects and catches up.
+				return
+			}
+			if writeLiveEvent(w, e) != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		if rc.Flush() != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"workshop/internal/adapters/http/events"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	notificationDomain "workshop/internal/domain/notification"
+)
+
+// useEventHub gives the test its own event hub.
+func useEventHub(t *testing.T) {
+	t.Helper()
+	saved := eventHub
+	eventHub = events.NewHub(10)
+	t.Cleanup(func() { eventHub = saved })
+}
+
+// streamEvents connects to the event stream with a Last-Event-ID and returns what it
+// sends before the (already cancelled) request ends.
+func streamEvents(t *testing.T, url, lastID string, sess middleware.Session) *httptest.ResponseRecorder {
+	t.Helper()
+	req := authRequest("GET", url, "", sess)
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+	ctx, cancel := context.WithCancel(req.Context())
+	cancel()
+	rec := httptest.NewRecorder()
+	handleEventsStream(rec, req.WithContext(ctx))
+	return rec
+}
+
+// TestHandleEventsStream_FiltersByRoleAndAccount verifies staff see check-ins, and messages
+// reach only their recipient.
+func TestHandleEventsStream_FiltersByRoleAndAccount(t *testing.T) {
+	stores = newFullStores()
+	useEventHub(t)
+
+	publishEvent("notice.published", notificationEvent{Title: "before"}, nil, nil) // ID 1, already seen
+	publishAttendance(eventAttendanceCheckIn, attendanceEvent{MemberID: "m1", ScheduleID: "s1"})
+	notify(context.Background(), orchestrators.NotifyInput{
+		AccountIDs: []string{"member-001"},
+		Kind:       notificationDomain.KindMessageReceived,
+		Title:      "New message",
+		Link:       "/messages",
+	})
+
+	rec := streamEvents(t, "/api/events/stream", "1", coachSession)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, "retry: 3000\n\n") {
+		t.Errorf("expected a retry hint first, got %q", body)
+	}
+	if !strings.Contains(body, "id: 2\nevent: attendance.checkin\ndata: {\"MemberID\":\"m1\",\"ScheduleID\":\"s1\"") {
+		t.Errorf("coach: expected the check-in, got %q", body)
+	}
+	if strings.Contains(body, "before") || strings.Contains(body, "message.new") {
+		t.Errorf("coach: expected neither the seen notice nor another account's message, got %q", body)
+	}
+
+	body = streamEvents(t, "/api/events/stream", "1", memberSession).Body.String()
+	if strings.Contains(body, "attendance.checkin") || !strings.Contains(body, "id: 3\nevent: message.new\n") {
+		t.Errorf("member: expected only their message, got %q", body)
+	}
+}
+
+// TestHandleEventsStream_TypesAndReset verifies the ?types= filter, and that a client
+// resuming from an ID the server no longer knows is told to reset.
+func TestHandleEventsStream_TypesAndReset(t *testing.T) {
+	stores = newFullStores()
+	useEventHub(t)
+	publishAttendance(eventAttendanceCheckIn, attendanceEvent{MemberID: "m1"})
+	publishAttendance(eventAttendanceUndo, attendanceEvent{})
+
+	body := streamEvents(t, "/api/events/stream?types=attendance.undo", "0", adminSession).Body.String()
+	if strings.Contains(body, "event:") {
+		t.Errorf("Last-Event-ID 0: expected no replay, got %q", body)
+	}
+
+	body = streamEvents(t, "/api/events/stream?types=attendance.undo", "1", adminSession).Body.String()
+	if strings.Contains(body, "attendance.checkin") || !strings.Contains(body, "event: attendance.undo") {
+		t.Errorf("expected only the undo, got %q", body)
+	}
+
+	body = streamEvents(t, "/api/events/stream", "500", adminSession).Body.String()
+	if !strings.Contains(body, "event: reset\n") {
+		t.Errorf("expected a reset after a restart, got %q", body)
+	}
+
+	if rec := streamEvents(t, "/api/events/stream?types=everything", "", adminSession); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown type: expected 400, got %d", rec.Code)
+	}
+	if rec := streamEvents(t, "/api/events/stream", "abc", adminSession); rec.Code != http.StatusBadRequest {
+		t.Errorf("bad Last-Event-ID: expected 400, got %d", rec.Code)
+	}
+	if eventHub.Subscribers() != 0 {
+		t.Errorf("expected closed streams to unsubscribe, %d remain", eventHub.Subscribers())
+	}
+}
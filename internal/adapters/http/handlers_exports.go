@@ -0,0 +1,439 @@
+package web
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/adapters/spreadsheet"
+	memberStore "workshop/internal/adapters/storage/member"
+	attendanceDomain "workshop/internal/domain/attendance"
+	emailDomain "workshop/internal/domain/email"
+	estimatedHoursDomain "workshop/internal/domain/estimatedhours"
+	gradingDomain "workshop/internal/domain/grading"
+	memberDomain "workshop/internal/domain/member"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// exportRange is the optional from/to date range of an export, as YYYY-MM-DD.
+type exportRange struct {
+	From string
+	To   string
+}
+
+// exportMaxDays caps the date range of an attendance export.
+const exportMaxDays = 366
+
+// parseExportRange reads the from and to query parameters.
+// A missing bound is filled from the defaults; an empty default leaves the range open on that side.
+// PRE: defaultFrom, defaultTo are empty or YYYY-MM-DD
+// POST: returns the range, or an error message for the client
+func parseExportRange(r *http.Request, defaultFrom, defaultTo string) (exportRange, string) {
+	rng := exportRange{From: r.URL.Query().Get("from"), To: r.URL.Query().Get("to")}
+	if rng.From == "" {
+		rng.From = defaultFrom
+	}
+	if rng.To == "" {
+		rng.To = defaultTo
+	}
+	for _, d := range []string{rng.From, rng.To} {
+		if d == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			return exportRange{}, "from and to must be YYYY-MM-DD"
+		}
+	}
+	if rng.From != "" && rng.To != "" && rng.From > rng.To {
+		return exportRange{}, "from must not be after to"
+	}
+	return rng, ""
+}
+
+// closed returns the range with open bounds replaced by the earliest and latest dates.
+// INVARIANT: rng is not mutated
+func (rng exportRange) closed() (string, string) {
+	from, to := rng.From, rng.To
+	if from == "" {
+		from = "0001-01-01"
+	}
+	if to == "" {
+		to = "9999-12-31"
+	}
+	return from, to
+}
+
+// filenameSuffix names the range in a download filename, e.g. "2026-03-01-to-2026-03-31".
+// INVARIANT: rng is not mutated
+func (rng exportRange) filenameSuffix(now time.Time) string {
+	switch {
+	case rng.From == "" && rng.To == "":
+		return now.Format("2006-01-02")
+	case rng.From == "":
+		return "to-" + rng.To
+	case rng.To == "":
+		return rng.From + "-on"
+	}
+	return rng.From + "-to-" + rng.To
+}
+
+// parseExportFormat reads the format query parameter, writing a 400 if it is unknown.
+// PRE: none
+// POST: returns the format and true, or writes an error and returns false
+func parseExportFormat(w http.ResponseWriter, r *http.Request) (spreadsheet.Format, bool) {
+	format, err := spreadsheet.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		apierror.Validation(w, err.Error())
+		return "", false
+	}
+	return format, true
+}
+
+// writeExport streams rows as a file download and logs an audit event with the row count.
+// PRE: format is valid; nothing has been written to w
+// POST: the file is written; a failure after the headers are sent is logged only
+func writeExport[T any](w http.ResponseWriter, r *http.Request, sess middleware.Session, format spreadsheet.Format, base, sheet, action string, columns []spreadsheet.Column[T], rows iter.Seq[T]) {
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", format.Filename(base)))
+	w.Header().Set("Cache-Control", "no-store")
+
+	n, err := spreadsheet.Write(w, format, sheet, columns, rows)
+	if err != nil {
+		// Response may be partially written; log only.
+		slog.ErrorContext(r.Context(), "export_write_error", "action", action, "error", err.Error())
+	}
+
+	slog.InfoContext(r.Context(), "audit_event",
+		"actor_id", sess.AccountID,
+		"actor_role", sess.Role,
+		"action", action,
+		"format", string(format),
+		"row_count", n,
+		"query", r.URL.RawQuery,
+	)
+}
+
+// exportMemberNames returns every member's name by ID, for exports that list member IDs.
+// PRE: none
+// POST: returns a map from member ID to name
+func exportMemberNames(r *http.Request) (map[string]string, error) {
+	members, err := stores.MemberStore.List(r.Context(), memberStore.ListFilter{Limit: 100000})
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(members))
+	for _, m := range members {
+		names[m.ID] = m.Name
+	}
+	return names, nil
+}
+
+// memberExportColumns are the columns of GET /api/members/export.
+var memberExportColumns = []spreadsheet.Column[memberDomain.Member]{
+	{Header: "ID", Value: func(m memberDomain.Member) string { return m.ID }},
+	{Header: "AccountID", Value: func(m memberDomain.Member) string { return m.AccountID }},
+	{Header: "Name", Value: func(m memberDomain.Member) string { return m.Name }},
+	{Header: "Email", Value: func(m memberDomain.Member) string { return m.Email }},
+	{Header: "Program", Value: func(m memberDomain.Member) string { return m.Program }},
+	{Header: "Status", Value: func(m memberDomain.Member) string { return m.Status }},
+	{Header: "Fee", Number: true, Value: func(m memberDomain.Member) string { return strconv.Itoa(m.Fee) }},
+	{Header: "Frequency", Value: func(m memberDomain.Member) string { return m.Frequency }},
+	{Header: "GradingMetric", Value: func(m memberDomain.Member) string { return m.GradingMetric }},
+}
+
+// attendanceExportRow is one check-in with its member and class resolved to names.
+type attendanceExportRow struct {
+	attendanceDomain.Attendance
+	MemberName string
+	ClassName  string
+}
+
+// attendanceExportColumns are the columns of GET /api/attendance/export.
+var attendanceExportColumns = []spreadsheet.Column[attendanceExportRow]{
+	{Header: "Date", Value: func(a attendanceExportRow) string { return a.ClassDate }},
+	{Header: "CheckIn", Value: func(a attendanceExportRow) string { return exportTime(a.CheckInTime) }},
+	{Header: "CheckOut", Value: func(a attendanceExportRow) string { return exportTime(a.CheckOutTime) }},
+	{Header: "MemberID", Value: func(a attendanceExportRow) string { return a.MemberID }},
+	{Header: "Member", Value: func(a attendanceExportRow) string { return a.MemberName }},
+	{Header: "ScheduleID", Value: func(a attendanceExportRow) string { return a.ScheduleID }},
+	{Header: "Class", Value: func(a attendanceExportRow) string { return a.ClassName }},
+	{Header: "MatHours", Number: true, Value: func(a attendanceExportRow) string { return spreadsheet.FormatFloat(a.MatHours, 2) }},
+	{Header: "LocationID", Value: func(a attendanceExportRow) string { return a.LocationID }},
+}
+
+// gradingExportRow is one promotion with its member resolved to a name.
+type gradingExportRow struct {
+	gradingDomain.Record
+	MemberName string
+}
+
+// gradingExportColumns are the columns of GET /api/grading/records/export.
+var gradingExportColumns = []spreadsheet.Column[gradingExportRow]{
+	{Header: "PromotedAt", Value: func(g gradingExportRow) string { return g.PromotedAt.Format("2006-01-02") }},
+	{Header: "MemberID", Value: func(g gradingExportRow) string { return g.MemberID }},
+	{Header: "Member", Value: func(g gradingExportRow) string { return g.MemberName }},
+	{Header: "Belt", Value: func(g gradingExportRow) string { return g.Belt }},
+	{Header: "Stripe", Number: true, Value: func(g gradingExportRow) string { return strconv.Itoa(g.Stripe) }},
+	{Header: "Method", Value: func(g gradingExportRow) string { return g.Method }},
+	{Header: "ProposedBy", Value: func(g gradingExportRow) string { return g.ProposedBy }},
+	{Header: "ApprovedBy", Value: func(g gradingExportRow) string { return g.ApprovedBy }},
+}
+
+// estimatedHoursExportRow is one estimated-hours entry with its member resolved to a name.
+type estimatedHoursExportRow struct {
+	estimatedHoursDomain.EstimatedHours
+	MemberName string
+}
+
+// estimatedHoursExportColumns are the columns of GET /api/estimated-hours/export.
+var estimatedHoursExportColumns = []spreadsheet.Column[estimatedHoursExportRow]{
+	{Header: "MemberID", Value: func(e estimatedHoursExportRow) string { return e.MemberID }},
+	{Header: "Member", Value: func(e estimatedHoursExportRow) string { return e.MemberName }},
+	{Header: "StartDate", Value: func(e estimatedHoursExportRow) string { return e.StartDate }},
+	{Header: "EndDate", Value: func(e estimatedHoursExportRow) string { return e.EndDate }},
+	{Header: "WeeklyHours", Number: true, Value: func(e estimatedHoursExportRow) string { return spreadsheet.FormatFloat(e.WeeklyHours, 1) }},
+	{Header: "TotalHours", Number: true, Value: func(e estimatedHoursExportRow) string { return spreadsheet.FormatFloat(e.TotalHours, 1) }},
+	{Header: "Source", Value: func(e estimatedHoursExportRow) string { return e.Source }},
+	{Header: "Status", Value: func(e estimatedHoursExportRow) string { return e.Status }},
+	{Header: "Note", Value: func(e estimatedHoursExportRow) string { return e.Note }},
+	{Header: "CreatedBy", Value: func(e estimatedHoursExportRow) string { return e.CreatedBy }},
+	{Header: "ReviewedBy", Value: func(e estimatedHoursExportRow) string { return e.ReviewedBy }},
+	{Header: "ReviewNote", Value: func(e estimatedHoursExportRow) string { return e.ReviewNote }},
+}
+
+// emailRecipientExportColumns are the columns of GET /api/emails/recipients/export.
+var emailRecipientExportColumns = []spreadsheet.Column[emailDomain.Recipient]{
+	{Header: "MemberID", Value: func(e emailDomain.Recipient) string { return e.MemberID }},
+	{Header: "Name", Value: func(e emailDomain.Recipient) string { return e.MemberName }},
+	{Header: "Email", Value: func(e emailDomain.Recipient) string { return e.MemberEmail }},
+	{Header: "DeliveryStatus", Value: func(e emailDomain.Recipient) string { return e.DeliveryStatus }},
+	{Header: "DeliveryUpdatedAt", Value: func(e emailDomain.Recipient) string { return exportTime(e.DeliveryUpdatedAt) }},
+	{Header: "OpenedAt", Value: func(e emailDomain.Recipient) string { return exportTime(e.OpenedAt) }},
+	{Header: "ClickedAt", Value: func(e emailDomain.Recipient) string { return exportTime(e.ClickedAt) }},
+	{Header: "BounceReason", Value: func(e emailDomain.Recipient) string { return e.BounceReason }},
+}
+
+// exportTime formats a timestamp cell; the zero time is empty.
+func exportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// handleAttendanceExport handles GET /api/attendance/export
+// Downloads check-ins between from and to (YYYY-MM-DD, default this month so far) as CSV or XLSX.
+func handleAttendanceExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionReportsExport)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "attendance") {
+		return
+	}
+	format, ok := parseExportFormat(w, r)
+	if !ok {
+		return
+	}
+	now := timeNow()
+	rng, msg := parseExportRange(r, now.Format("2006-01")+"-01", now.Format("2006-01-02"))
+	if msg != "" {
+		apierror.Validation(w, msg)
+		return
+	}
+	from, _ := time.Parse("2006-01-02", rng.From)
+	to, _ := time.Parse("2006-01-02", rng.To)
+	if to.Sub(from) > exportMaxDays*24*time.Hour {
+		apierror.Validation(w, fmt.Sprintf("date range must be at most %d days", exportMaxDays))
+		return
+	}
+
+	ctx := r.Context()
+	records, err := stores.AttendanceStore.ListByDateRange(ctx, rng.From, rng.To)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	memberNames, err := exportMemberNames(r)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	classNames, err := scheduleClassNames(r)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	slices.SortFunc(records, func(a, b attendanceDomain.Attendance) int {
+		return cmp.Or(cmp.Compare(a.ClassDate, b.ClassDate), a.CheckInTime.Compare(b.CheckInTime))
+	})
+
+	rows := func(yield func(attendanceExportRow) bool) {
+		for _, a := range records {
+			if !yield(attendanceExportRow{Attendance: a, MemberName: memberNames[a.MemberID], ClassName: classNames[a.ScheduleID]}) {
+				return
+			}
+		}
+	}
+	writeExport(w, r, sess, format, "attendance-"+rng.filenameSuffix(now), "Attendance", "attendance.export", attendanceExportColumns, rows)
+}
+
+// scheduleClassNames returns each schedule's class type name by schedule ID.
+// PRE: none
+// POST: returns a map from schedule ID to class name
+func scheduleClassNames(r *http.Request) (map[string]string, error) {
+	ctx := r.Context()
+	types, err := stores.ClassTypeStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	typeNames := make(map[string]string, len(types))
+	for _, ct := range types {
+		typeNames[ct.ID] = ct.Name
+	}
+	schedules, err := stores.ScheduleStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(schedules))
+	for _, s := range schedules {
+		names[s.ID] = typeNames[s.ClassTypeID]
+	}
+	return names, nil
+}
+
+// handleGradingRecordsExport handles GET /api/grading/records/export
+// Downloads promotions, optionally between from and to (YYYY-MM-DD), as CSV or XLSX.
+func handleGradingRecordsExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionReportsExport)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	format, ok := parseExportFormat(w, r)
+	if !ok {
+		return
+	}
+	rng, msg := parseExportRange(r, "", "")
+	if msg != "" {
+		apierror.Validation(w, msg)
+		return
+	}
+
+	from, to := rng.closed()
+	records, err := stores.GradingRecordStore.ListByDateRange(r.Context(), from, to)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	memberNames, err := exportMemberNames(r)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	rows := func(yield func(gradingExportRow) bool) {
+		for _, g := range records {
+			if !yield(gradingExportRow{Record: g, MemberName: memberNames[g.MemberID]}) {
+				return
+			}
+		}
+	}
+	writeExport(w, r, sess, format, "grading-"+rng.filenameSuffix(timeNow()), "Grading", "grading.records.export", gradingExportColumns, rows)
+}
+
+// handleEstimatedHoursExport handles GET /api/estimated-hours/export
+// Downloads estimated-hours entries overlapping from and to (YYYY-MM-DD, optional) as CSV or XLSX.
+func handleEstimatedHoursExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionReportsExport)
+	if !ok {
+		return
+	}
+	format, ok := parseExportFormat(w, r)
+	if !ok {
+		return
+	}
+	rng, msg := parseExportRange(r, "", "")
+	if msg != "" {
+		apierror.Validation(w, msg)
+		return
+	}
+
+	from, to := rng.closed()
+	entries, err := stores.EstimatedHoursStore.ListByDateRange(r.Context(), from, to)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	memberNames, err := exportMemberNames(r)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	rows := func(yield func(estimatedHoursExportRow) bool) {
+		for _, e := range entries {
+			if !yield(estimatedHoursExportRow{EstimatedHours: e, MemberName: memberNames[e.MemberID]}) {
+				return
+			}
+		}
+	}
+	writeExport(w, r, sess, format, "estimated-hours-"+rng.filenameSuffix(timeNow()), "Estimated hours", "estimated_hours.export", estimatedHoursExportColumns, rows)
+}
+
+// handleEmailRecipientsExport handles GET /api/emails/recipients/export
+// Downloads one email's recipients with their delivery status as CSV or XLSX. Admin only.
+func handleEmailRecipientsExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "emails") {
+		return
+	}
+	format, ok := parseExportFormat(w, r)
+	if !ok {
+		return
+	}
+	id := r.URL.Query().Get("email_id")
+	if id == "" {
+		apierror.Validation(w, "email_id is required")
+		return
+	}
+	ctx := r.Context()
+	if _, err := stores.EmailStore.GetByID(ctx, id); err != nil {
+		apierror.NotFound(w, "email not found")
+		return
+	}
+	recipients, err := stores.EmailStore.GetRecipients(ctx, id)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	writeExport(w, r, sess, format, "email-recipients-"+id, "Recipients", "emails.recipients.export", emailRecipientExportColumns, slices.Values(recipients))
+}
@@ -0,0 +1,116 @@
+package web
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	attendanceDomain "workshop/internal/domain/attendance"
+	classTypeDomain "workshop/internal/domain/classtype"
+	gradingDomain "workshop/internal/domain/grading"
+	memberDomain "workshop/internal/domain/member"
+	scheduleDomain "workshop/internal/domain/schedule"
+)
+
+// seedExportData stores a member, a class and a check-in and promotion for them in March 2026.
+func seedExportData(t *testing.T) {
+	t.Helper()
+	stores = newFullStores()
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", Name: "=Ana Silva", Email: "ana@example.com", Program: "adults", Status: "active"})
+	stores.ClassTypeStore.Save(ctx, classTypeDomain.ClassType{ID: "ct1", ProgramID: "adults", Name: "Fundamentals"})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s1", ClassTypeID: "ct1", Day: "monday", StartTime: "18:00", EndTime: "19:30"})
+	checkIn := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	stores.AttendanceStore.Save(ctx, attendanceDomain.Attendance{ID: "a1", MemberID: "m1", ScheduleID: "s1", ClassDate: "2026-03-02", CheckInTime: checkIn, MatHours: 1.5})
+	stores.GradingRecordStore.Save(ctx, gradingDomain.Record{ID: "g1", MemberID: "m1", Belt: "blue", Stripe: 0, PromotedAt: checkIn, ApprovedBy: "admin-1", Method: "standard"})
+	timeNow = func() time.Time { return time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { timeNow = time.Now })
+}
+
+// TestHandleAttendanceExport verifies the attendance export resolves names, defaults to this month
+// and validates its range and format.
+func TestHandleAttendanceExport(t *testing.T) {
+	seedExportData(t)
+
+	rec := httptest.NewRecorder()
+	handleAttendanceExport(rec, authRequest("GET", "/api/attendance/export", "", coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if cd := rec.Header().Get("Content-Disposition"); !strings.Contains(cd, `filename="attendance-2026-03-01-to-2026-03-20.csv"`) {
+		t.Errorf("Content-Disposition = %q", cd)
+	}
+	want := "Date,CheckIn,CheckOut,MemberID,Member,ScheduleID,Class,MatHours,LocationID\r\n" +
+		"2026-03-02,2026-03-02T18:00:00Z,,m1,'=Ana Silva,s1,Fundamentals,1.50,\r\n"
+	if rec.Body.String() != want {
+		t.Errorf("body =\n%q\nwant\n%q", rec.Body.String(), want)
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		want int
+	}{
+		{"earlier month", "/api/attendance/export?from=2026-02-01&to=2026-02-28", http.StatusOK},
+		{"bad date", "/api/attendance/export?from=March", http.StatusBadRequest},
+		{"reversed", "/api/attendance/export?from=2026-03-10&to=2026-03-01", http.StatusBadRequest},
+		{"over a year", "/api/attendance/export?from=2024-01-01&to=2026-03-01", http.StatusBadRequest},
+		{"bad format", "/api/attendance/export?format=pdf", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handleAttendanceExport(rec, authRequest("GET", tt.url, "", coachSession))
+		if rec.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, rec.Code, rec.Body.String())
+		}
+		if tt.name == "earlier month" && strings.Count(rec.Body.String(), "\r\n") != 1 {
+			t.Errorf("earlier month: expected only the header, got %q", rec.Body.String())
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	handleAttendanceExport(rec, authRequest("GET", "/api/attendance/export", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member: expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleGradingRecordsExport_XLSX verifies the grading export downloads as a workbook.
+func TestHandleGradingRecordsExport_XLSX(t *testing.T) {
+	seedExportData(t)
+
+	rec := httptest.NewRecorder()
+	handleGradingRecordsExport(rec, authRequest("GET", "/api/grading/records/export?format=xlsx&from=2026-03-01", "", coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.Contains(ct, "spreadsheetml") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); !strings.Contains(cd, `filename="grading-2026-03-01-on.xlsx"`) {
+		t.Errorf("Content-Disposition = %q", cd)
+	}
+	body := rec.Body.Bytes()
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("not a workbook: %v", err)
+	}
+	var sheet bytes.Buffer
+	for _, f := range zr.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, _ := f.Open()
+			sheet.ReadFrom(rc)
+			rc.Close()
+		}
+	}
+	for _, want := range []string{"PromotedAt", "2026-03-02", "=Ana Silva", "blue"} {
+		if !strings.Contains(sheet.String(), want) {
+			t.Errorf("sheet missing %q: %s", want, sheet.String())
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+	emailDomain "workshop/internal/domain/email"
+	gradingDomain "workshop/internal/domain/grading"
+	notificationDomain "workshop/internal/domain/notification"
+)
+
+// gradingCeremonyDeps wires the ceremony orchestrators to the stores, notifications and email.
+func gradingCeremonyDeps() orchestrators.GradingCeremonyDeps {
+	return orchestrators.GradingCeremonyDeps{
+		CeremonyStore: stores.GradingCeremonyStore,
+		ProposalStore: stores.GradingProposalStore,
+		CalendarStore: stores.CalendarEventStore,
+		NoticeStore:   stores.NoticeStore,
+		RecordStore:   stores.GradingRecordStore,
+		Invite:        inviteToCeremony,
+		Award: func(ctx context.Context, _ gradingDomain.Ceremony, r gradingDomain.Record) {
+			awardPromotion(ctx, r)
+		},
+		GenerateID: generateID,
+		Now:        timeNow,
+	}
+}
+
+// plannedCeremonyFor returns the planned ceremony that owns a calendar event, if there is one.
+func plannedCeremonyFor(ctx context.Context, eventID string) (gradingDomain.Ceremony, bool) {
+	if stores.GradingCeremonyStore == nil || eventID == "" {
+		return gradingDomain.Ceremony{}, false
+	}
+	c, err := stores.GradingCeremonyStore.GetByEventID(ctx, eventID)
+	if err != nil {
+		return gradingDomain.Ceremony{}, false
+	}
+	return c, c.IsPlanned()
+}
+
+// ceremonyWhen describes when and where a ceremony is, e.g. "Saturday 14 November at the main gym".
+func ceremonyWhen(c gradingDomain.Ceremony) string {
+	when := c.Date.Format("Monday 2 January")
+	if c.Location != "" {
+		when += " at " + c.Location
+	}
+	return when
+}
+
+// inviteToCeremony tells a member their promotion has been booked onto a ceremony.
+func inviteToCeremony(ctx context.Context, c gradingDomain.Ceremony, p gradingDomain.Proposal) {
+	notifyMember(ctx, p.MemberID, orchestrators.NotifyInput{
+		Kind:  notificationDomain.KindGradingProposed,
+		Title: "Promotion ceremony for " + p.TargetBelt + " belt: " + c.Date.Format("Mon 2 Jan"),
+		Body:  c.Title,
+		Link:  "/dashboard",
+	})
+	sendTemplatedEmail(ctx, emailDomain.TemplateCeremonyInvite, p.MemberID, emailDomain.Vars{
+		emailDomain.VarBelt:     p.TargetBelt,
+		emailDomain.VarCeremony: ceremonyWhen(c),
+	})
+}
+
+// writeCeremonyError maps a ceremony orchestrator error to its API response.
+func writeCeremonyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, orchestrators.ErrCeremonyNotFound), errors.Is(err, orchestrators.ErrCeremonyProposalNotFound):
+		apierror.NotFound(w, err.Error())
+	case errors.Is(err, gradingDomain.ErrCeremonyCompleted), errors.Is(err, gradingDomain.ErrNotAttachable):
+		apierror.Conflict(w, err.Error())
+	case errors.Is(err, gradingDomain.ErrEmptyCeremonyTitle), errors.Is(err, gradingDomain.ErrCeremonyTitleLength),
+		errors.Is(err, gradingDomain.ErrEmptyCeremonyDate), errors.Is(err, gradingDomain.ErrEmptyProposalID):
+		apierror.Validation(w, err.Error())
+	default:
+		internalError(w, err)
+	}
+}
+
+// ceremonyView is a ceremony with the proposals booked onto it.
+type ceremonyView struct {
+	ID          string                   `json:"ID"`
+	Title       string                   `json:"Title"`
+	Location    string                   `json:"Location"`
+	Date        string                   `json:"Date"` // YYYY-MM-DD
+	EventID     string                   `json:"EventID"`
+	NoticeID    string                   `json:"NoticeID"`
+	Status      string                   `json:"Status"`
+	CompletedAt time.Time                `json:"CompletedAt"`
+	Proposals   []gradingDomain.Proposal `json:"Proposals"`
+}
+
+// toCeremonyView adds the proposals booked onto a ceremony's calendar event.
+func toCeremonyView(ctx context.Context, c gradingDomain.Ceremony) (ceremonyView, error) {
+	v := ceremonyView{
+		ID: c.ID, Title: c.Title, Location: c.Location, Date: c.Date.Format("2006-01-02"),
+		EventID: c.EventID, NoticeID: c.NoticeID, Status: c.Status, CompletedAt: c.CompletedAt,
+		Proposals: []gradingDomain.Proposal{},
+	}
+	proposals, err := stores.GradingProposalStore.ListByEventID(ctx, c.EventID)
+	if err != nil {
+		return v, err
+	}
+	v.Proposals = append(v.Proposals, proposals...)
+	return v, nil
+}
+
+// gradingCeremonyRequest is the body of POST /api/grading/ceremonies.
+type gradingCeremonyRequest struct {
+	Title       string   `json:"Title"`
+	Location    string   `json:"Location"`
+	Date        string   `json:"Date"`        // YYYY-MM-DD
+	ProposalIDs []string `json:"ProposalIDs"` // open proposals to be awarded on the day
+}
+
+// handleGradingCeremonies handles GET/POST for /api/grading/ceremonies
+// Lists promotion ceremonies with their proposals, or plans one: the calendar event, the
+// notice for invited members and their invitations are created together. Admin only.
+func handleGradingCeremonies(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		ceremonies, err := stores.GradingCeremonyStore.List(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		views := make([]ceremonyView, 0, len(ceremonies))
+		for _, c := range ceremonies {
+			v, err := toCeremonyView(ctx, c)
+			if err != nil {
+				internalError(w, err)
+				return
+			}
+			views = append(views, v)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(views)
+	case "POST":
+		var input gradingCeremonyRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		if len(input.ProposalIDs) > maxBatchDecisions {
+			apierror.Validation(w, "too many proposals for one ceremony")
+			return
+		}
+		date, err := time.Parse("2006-01-02", input.Date)
+		if err != nil {
+			apierror.Validation(w, "Date must be YYYY-MM-DD")
+			return
+		}
+		c, err := orchestrators.ExecutePlanGradingCeremony(ctx, orchestrators.PlanGradingCeremonyInput{
+			Title:       input.Title,
+			Location:    input.Location,
+			Date:        date,
+			ProposalIDs: input.ProposalIDs,
+			CreatedBy:   sess.AccountID,
+		}, gradingCeremonyDeps())
+		if err != nil {
+			writeCeremonyError(w, err)
+			return
+		}
+		v, err := toCeremonyView(ctx, c)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(v)
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// ceremonyProposalRequest is the body of POST /api/grading/ceremonies/attach.
+type ceremonyProposalRequest struct {
+	CeremonyID string `json:"CeremonyID"`
+	ProposalID string `json:"ProposalID"`
+}
+
+// handleGradingCeremonyAttach handles POST /api/grading/ceremonies/attach
+// Books one more open proposal onto a planned ceremony and invites the member. To take a
+// proposal off again, unschedule it from its grading day. Admin only.
+func handleGradingCeremonyAttach(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	var input ceremonyProposalRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	proposal, err := orchestrators.ExecuteAttachCeremonyProposal(r.Context(), orchestrators.AttachCeremonyProposalInput{
+		CeremonyID: input.CeremonyID,
+		ProposalID: input.ProposalID,
+	}, gradingCeremonyDeps())
+	if err != nil {
+		writeCeremonyError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(proposal)
+}
+
+// ceremonyCompleteRequest is the body of POST /api/grading/ceremonies/complete.
+type ceremonyCompleteRequest struct {
+	CeremonyID string `json:"CeremonyID"`
+}
+
+// ceremonyCompleteResponse is the body returned by POST /api/grading/ceremonies/complete.
+type ceremonyCompleteResponse struct {
+	Ceremony ceremonyView `json:"Ceremony"`
+	Promoted int          `json:"Promoted"` // grading records created
+}
+
+// handleGradingCeremonyComplete handles POST /api/grading/ceremonies/complete
+// Records the ceremony's promotions in one action: open proposals on it are approved and
+// every approved proposal gets a grading record dated the ceremony day. Admin only.
+func handleGradingCeremonyComplete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	var input ceremonyCompleteRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	ctx := r.Context()
+	result, err := orchestrators.ExecuteCompleteGradingCeremony(ctx, orchestrators.CompleteGradingCeremonyInput{
+		CeremonyID: input.CeremonyID,
+		AdminID:    sess.AccountID,
+	}, gradingCeremonyDeps())
+	if err != nil {
+		writeCeremonyError(w, err)
+		return
+	}
+	v, err := toCeremonyView(ctx, result.Ceremony)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ceremonyCompleteResponse{Ceremony: v, Promoted: len(result.Records)})
+}
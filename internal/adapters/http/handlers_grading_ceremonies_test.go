@@ -0,0 +1,161 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	gradingDomain "workshop/internal/domain/grading"
+	noticeDomain "workshop/internal/domain/notice"
+	notificationDomain "workshop/internal/domain/notification"
+)
+
+type mockGradingCeremonyStore struct {
+	ceremonies map[string]gradingDomain.Ceremony
+}
+
+// Save implements grading.CeremonyStore for testing.
+// PRE: value has been validated
+// POST: Ceremony is upserted
+func (m *mockGradingCeremonyStore) Save(_ context.Context, value gradingDomain.Ceremony) error {
+	m.ceremonies[value.ID] = value
+	return nil
+}
+
+// GetByID implements grading.CeremonyStore for testing.
+// PRE: id is non-empty
+// POST: Returns the ceremony or sql.ErrNoRows
+func (m *mockGradingCeremonyStore) GetByID(_ context.Context, id string) (gradingDomain.Ceremony, error) {
+	c, ok := m.ceremonies[id]
+	if !ok {
+		return gradingDomain.Ceremony{}, sql.ErrNoRows
+	}
+	return c, nil
+}
+
+// GetByEventID implements grading.CeremonyStore for testing.
+// PRE: eventID is non-empty
+// POST: Returns the ceremony owning the event or sql.ErrNoRows
+func (m *mockGradingCeremonyStore) GetByEventID(_ context.Context, eventID string) (gradingDomain.Ceremony, error) {
+	for _, c := range m.ceremonies {
+		if c.EventID == eventID {
+			return c, nil
+		}
+	}
+	return gradingDomain.Ceremony{}, sql.ErrNoRows
+}
+
+// List implements grading.CeremonyStore for testing.
+// PRE: none
+// POST: Returns every ceremony, latest date first
+func (m *mockGradingCeremonyStore) List(_ context.Context) ([]gradingDomain.Ceremony, error) {
+	var list []gradingDomain.Ceremony
+	for _, c := range m.ceremonies {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Date.After(list[j].Date) })
+	return list, nil
+}
+
+// TestGradingCeremony_PlanAndComplete verifies planning a ceremony creates its calendar event
+// and notice and invites the members, approval on a ceremony waits for it, and completing
+// records every promotion on the ceremony day.
+func TestGradingCeremony_PlanAndComplete(t *testing.T) {
+	stores = newGradingProposalTestStores()
+	saveProposal(t, "p1", gradingDomain.ProposalPending)
+	saveProposal(t, "p2", gradingDomain.ProposalPending)
+
+	post := func(handler http.HandlerFunc, url, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler(rec, authRequest("POST", url, body, adminSession))
+		return rec
+	}
+	if rec := post(handleGradingCeremonies, "/api/grading/ceremonies", `{"Title":"Summer promotions","Date":"14/11/2026"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("bad date: expected 400, got %d", rec.Code)
+	}
+	if rec := post(handleGradingCeremonies, "/api/grading/ceremonies", `{"Title":"Summer promotions","Date":"2026-11-14","ProposalIDs":["nope"]}`); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown proposal: expected 404, got %d", rec.Code)
+	}
+	rec := post(handleGradingCeremonies, "/api/grading/ceremonies", `{"Title":"Summer promotions","Location":"Main mat","Date":"2026-11-14","ProposalIDs":["p1","p2"]}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("plan: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var planned ceremonyView
+	json.NewDecoder(rec.Body).Decode(&planned)
+	if planned.Status != gradingDomain.CeremonyPlanned || planned.Date != "2026-11-14" || len(planned.Proposals) != 2 {
+		t.Fatalf("planned = %+v, want a planned ceremony with two proposals", planned)
+	}
+	if e, err := stores.CalendarEventStore.GetByID(context.Background(), planned.EventID); err != nil || e.Title != "Summer promotions" || e.Location != "Main mat" {
+		t.Errorf("calendar event = %+v, %v", e, err)
+	}
+	if n, err := stores.NoticeStore.GetByID(context.Background(), planned.NoticeID); err != nil || n.Type != noticeDomain.TypeGrading || n.TargetID != planned.EventID {
+		t.Errorf("notice = %+v, %v; want a grading notice for the ceremony", n, err)
+	}
+
+	// Approving ahead of the day leaves the promotion to the ceremony
+	if _, err := decideProposal(context.Background(), adminSession.AccountID, "p1", "approve"); err != nil {
+		t.Fatalf("approve p1: %v", err)
+	}
+	if records, _ := stores.GradingRecordStore.ListByMemberID(context.Background(), "member-001"); len(records) != 0 {
+		t.Fatalf("records before the ceremony = %+v, want none", records)
+	}
+
+	rec = post(handleGradingCeremonyComplete, "/api/grading/ceremonies/complete", `{"CeremonyID":"`+planned.ID+`"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("complete: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var done ceremonyCompleteResponse
+	json.NewDecoder(rec.Body).Decode(&done)
+	if done.Promoted != 2 || done.Ceremony.Status != gradingDomain.CeremonyCompleted {
+		t.Errorf("complete = %+v, want two promotions and a completed ceremony", done)
+	}
+	records, _ := stores.GradingRecordStore.ListByMemberID(context.Background(), "member-001")
+	if len(records) != 2 {
+		t.Fatalf("records = %+v, want two", records)
+	}
+	for _, r := range records {
+		if r.PromotedAt.Format("2006-01-02") != "2026-11-14" {
+			t.Errorf("record %s promoted %v, want the ceremony day", r.ID, r.PromotedAt)
+		}
+	}
+
+	if rec := post(handleGradingCeremonyComplete, "/api/grading/ceremonies/complete", `{"CeremonyID":"`+planned.ID+`"}`); rec.Code != http.StatusConflict {
+		t.Errorf("second completion: expected 409, got %d", rec.Code)
+	}
+	saveProposal(t, "p3", gradingDomain.ProposalPending)
+	if rec := post(handleGradingCeremonyAttach, "/api/grading/ceremonies/attach", `{"CeremonyID":"`+planned.ID+`","ProposalID":"p3"}`); rec.Code != http.StatusConflict {
+		t.Errorf("attach after completion: expected 409, got %d", rec.Code)
+	}
+
+	list, _ := fetchNotifications(t)
+	kinds := map[string]int{}
+	for _, n := range list {
+		kinds[n.Kind]++
+	}
+	if kinds[notificationDomain.KindGradingProposed] != 2 || kinds[notificationDomain.KindGradingApproved] != 2 {
+		t.Errorf("notifications = %v, want two invitations and two promotions", kinds)
+	}
+}
+
+// TestGradingCeremonies_AdminOnly verifies coaches cannot plan or complete ceremonies.
+func TestGradingCeremonies_AdminOnly(t *testing.T) {
+	stores = newGradingProposalTestStores()
+	for _, tt := range []struct {
+		handler http.HandlerFunc
+		url     string
+	}{
+		{handleGradingCeremonies, "/api/grading/ceremonies"},
+		{handleGradingCeremonyAttach, "/api/grading/ceremonies/attach"},
+		{handleGradingCeremonyComplete, "/api/grading/ceremonies/complete"},
+	} {
+		rec := httptest.NewRecorder()
+		tt.handler(rec, authRequest("POST", tt.url, `{}`, coachSession))
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s as coach: expected 403, got %d", tt.url, rec.Code)
+		}
+	}
+}
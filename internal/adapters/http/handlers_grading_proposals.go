@@ -0,0 +1,386 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	calendarDomain "workshop/internal/domain/calendar"
+	emailDomain "workshop/internal/domain/email"
+	gradingDomain "workshop/internal/domain/grading"
+	notificationDomain "workshop/internal/domain/notification"
+)
+
+// maxBatchDecisions caps how many proposals one grading-day batch may decide.
+const maxBatchDecisions = 200
+
+var (
+	errProposalNotFound = errors.New("proposal not found")
+	errUnknownDecision  = errors.New("Decision must be 'approve' or 'reject'")
+)
+
+// decideProposal approves or rejects an open proposal. Approval records the promotion,
+// notifies the member and emails them the grading congratulation, unless the proposal is
+// booked onto a planned ceremony: its promotion is then recorded when the ceremony completes.
+func decideProposal(ctx context.Context, adminID, proposalID, decision string) (gradingDomain.Proposal, error) {
+	if proposalID == "" {
+		return gradingDomain.Proposal{}, gradingDomain.ErrEmptyProposalID
+	}
+	proposal, err := stores.GradingProposalStore.GetByID(ctx, proposalID)
+	if err != nil {
+		return gradingDomain.Proposal{}, errProposalNotFound
+	}
+
+	switch decision {
+	case "approve":
+		if err := proposal.Approve(adminID); err != nil {
+			return proposal, err
+		}
+		if err := stores.GradingProposalStore.Save(ctx, proposal); err != nil {
+			return proposal, err
+		}
+		if _, ok := plannedCeremonyFor(ctx, proposal.EventID); ok {
+			return proposal, nil
+		}
+		record := gradingDomain.Record{
+			ID:         generateID(),
+			MemberID:   proposal.MemberID,
+			Belt:       proposal.TargetBelt,
+			Stripe:     0,
+			PromotedAt: timeNow(),
+			ProposedBy: proposal.ProposedBy,
+			ApprovedBy: adminID,
+			Method:     gradingDomain.MethodStandard,
+		}
+		if err := stores.GradingRecordStore.Save(ctx, record); err != nil {
+			return proposal, err
+		}
+		awardPromotion(ctx, record)
+	case "reject":
+		if err := proposal.Reject(adminID); err != nil {
+			return proposal, err
+		}
+		if err := stores.GradingProposalStore.Save(ctx, proposal); err != nil {
+			return proposal, err
+		}
+	default:
+		return proposal, errUnknownDecision
+	}
+	return proposal, nil
+}
+
+// awardPromotion follows up a recorded promotion: it takes the belt from stock, notifies the
+// member and emails them the grading congratulation.
+func awardPromotion(ctx context.Context, record gradingDomain.Record) {
+	takeGradingStock(ctx, record)
+	notifyMember(ctx, record.MemberID, orchestrators.NotifyInput{
+		Kind:  notificationDomain.KindGradingApproved,
+		Title: "Grading approved: " + record.Belt + " belt",
+		Link:  "/training-log",
+	})
+	sendTemplatedEmail(ctx, emailDomain.TemplateGradingCongratulation, record.MemberID, emailDomain.Vars{emailDomain.VarBelt: record.Belt})
+}
+
+// writeDecisionError maps a decideProposal error to its API response.
+func writeDecisionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gradingDomain.ErrEmptyProposalID):
+		apierror.Validation(w, "ProposalID is required")
+	case errors.Is(err, errProposalNotFound):
+		apierror.NotFound(w, err.Error())
+	case errors.Is(err, errUnknownDecision), errors.Is(err, gradingDomain.ErrAlreadyDecided):
+		apierror.Validation(w, err.Error())
+	default:
+		internalError(w, err)
+	}
+}
+
+// gradingBatchDecisionRequest is the body of POST /api/grading/proposals/decide-batch.
+type gradingBatchDecisionRequest struct {
+	Decisions []gradingDecisionRequest `json:"Decisions"`
+}
+
+// batchDecisionResult reports the outcome for one proposal in a batch.
+type batchDecisionResult struct {
+	ProposalID string `json:"ProposalID"`
+	Status     string `json:"Status,omitempty"` // the proposal's status afterwards
+	Error      string `json:"Error,omitempty"`
+}
+
+// batchDecisionResponse is the body returned by POST /api/grading/proposals/decide-batch.
+type batchDecisionResponse struct {
+	Approved int                   `json:"Approved"`
+	Rejected int                   `json:"Rejected"`
+	Failed   int                   `json:"Failed"`
+	Results  []batchDecisionResult `json:"Results"`
+}
+
+// handleGradingDecideBatch handles POST /api/grading/proposals/decide-batch
+// Decides many proposals at once on grading day. Each decision stands alone: one
+// failure is reported in its result without undoing the others. Admin only.
+func handleGradingDecideBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	var input gradingBatchDecisionRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if len(input.Decisions) == 0 {
+		apierror.Validation(w, "Decisions is required")
+		return
+	}
+	if len(input.Decisions) > maxBatchDecisions {
+		apierror.Validation(w, "too many decisions in one batch")
+		return
+	}
+
+	resp := batchDecisionResponse{Results: make([]batchDecisionResult, 0, len(input.Decisions))}
+	for _, d := range input.Decisions {
+		result := batchDecisionResult{ProposalID: d.ProposalID}
+		proposal, err := decideProposal(r.Context(), sess.AccountID, d.ProposalID, d.Decision)
+		switch {
+		case err != nil:
+			result.Error = err.Error()
+			resp.Failed++
+		case proposal.Status == gradingDomain.ProposalApproved:
+			resp.Approved++
+		default:
+			resp.Rejected++
+		}
+		result.Status = proposal.Status
+		resp.Results = append(resp.Results, result)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// gradingScheduleRequest is the body of POST /api/grading/proposals/schedule.
+type gradingScheduleRequest struct {
+	ProposalID string `json:"ProposalID"`
+	EventID    string `json:"EventID"` // grading day; empty takes the proposal off its grading day
+}
+
+// handleGradingProposalSchedule handles POST /api/grading/proposals/schedule
+// Books an open proposal onto a grading day (a club calendar event) and tells the
+// member, or takes it off again. Admin only.
+func handleGradingProposalSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	var input gradingScheduleRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if input.ProposalID == "" {
+		apierror.Validation(w, "ProposalID is required")
+		return
+	}
+	ctx := r.Context()
+	proposal, err := stores.GradingProposalStore.GetByID(ctx, input.ProposalID)
+	if err != nil {
+		apierror.NotFound(w, "proposal not found")
+		return
+	}
+
+	var event calendarDomain.Event
+	if input.EventID == "" {
+		err = proposal.Unschedule()
+	} else {
+		event, err = stores.CalendarEventStore.GetByID(ctx, input.EventID)
+		if err != nil {
+			apierror.NotFound(w, "grading day event not found")
+			return
+		}
+		if event.Type != calendarDomain.TypeEvent {
+			apierror.Validation(w, "grading day must be a club event, not a competition")
+			return
+		}
+		err = proposal.Schedule(event.ID)
+	}
+	if err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	if err := stores.GradingProposalStore.Save(ctx, proposal); err != nil {
+		internalError(w, err)
+		return
+	}
+	if input.EventID != "" {
+		notifyMember(ctx, proposal.MemberID, orchestrators.NotifyInput{
+			Kind:  notificationDomain.KindGradingProposed,
+			Title: "Grading day for " + proposal.TargetBelt + " belt: " + event.StartDate.Format("Mon 2 Jan"),
+			Body:  event.Title,
+			Link:  "/training-log",
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(proposal)
+}
+
+// proposalCommentRequest is the body of POST /api/grading/proposals/comments.
+type proposalCommentRequest struct {
+	ProposalID string `json:"ProposalID"`
+	Content    string `json:"Content"`
+}
+
+// proposalCommentView is one comment in a proposal's discussion.
+type proposalCommentView struct {
+	ID          string    `json:"ID"`
+	ProposalID  string    `json:"ProposalID"`
+	AuthorID    string    `json:"AuthorID"`
+	AuthorEmail string    `json:"AuthorEmail"`
+	Content     string    `json:"Content"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+}
+
+// toProposalCommentView adds the author's email to a comment.
+func toProposalCommentView(ctx context.Context, c gradingDomain.ProposalComment) proposalCommentView {
+	v := proposalCommentView{ID: c.ID, ProposalID: c.ProposalID, AuthorID: c.AuthorID, Content: c.Content, CreatedAt: c.CreatedAt}
+	if a, err := stores.AccountStore.GetByID(ctx, c.AuthorID); err == nil {
+		v.AuthorEmail = a.Email
+	}
+	return v
+}
+
+// handleGradingProposalComments handles GET/POST for /api/grading/proposals/comments
+// The coach/admin discussion of a proposal; members never see it.
+func handleGradingProposalComments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !isStaffSession(sess) {
+		apierror.Forbidden(w, "coach or admin only")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		proposalID := r.URL.Query().Get("proposal_id")
+		if proposalID == "" {
+			apierror.Validation(w, "proposal_id is required")
+			return
+		}
+		comments, err := stores.ProposalCommentStore.ListByProposalID(ctx, proposalID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		views := make([]proposalCommentView, 0, len(comments))
+		for _, c := range comments {
+			views = append(views, toProposalCommentView(ctx, c))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(views)
+	case "POST":
+		var input proposalCommentRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		comment := gradingDomain.ProposalComment{
+			ID:         generateID(),
+			ProposalID: input.ProposalID,
+			AuthorID:   sess.AccountID,
+			Content:    input.Content,
+			CreatedAt:  timeNow(),
+		}
+		if err := comment.Validate(); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if _, err := stores.GradingProposalStore.GetByID(ctx, comment.ProposalID); err != nil {
+			apierror.NotFound(w, "proposal not found")
+			return
+		}
+		if err := stores.ProposalCommentStore.Save(ctx, comment); err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(toProposalCommentView(ctx, comment))
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// memberProposalView is what a member sees of a proposal for their own promotion:
+// no coach notes and no discussion.
+type memberProposalView struct {
+	ID         string    `json:"ID"`
+	TargetBelt string    `json:"TargetBelt"`
+	Status     string    `json:"Status"`
+	EventTitle string    `json:"EventTitle,omitempty"`
+	EventDate  string    `json:"EventDate,omitempty"` // YYYY-MM-DD
+	CreatedAt  time.Time `json:"CreatedAt"`
+	DecidedAt  time.Time `json:"DecidedAt"`
+}
+
+// handleMyGradingProposals handles GET /api/grading/proposals/mine
+// Lists the proposals for the caller's own promotion, newest first.
+func handleMyGradingProposals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "training_log") {
+		return
+	}
+
+	views := []memberProposalView{}
+	if memberID := sessionMemberID(ctx, sess); memberID != "" {
+		proposals, err := stores.GradingProposalStore.ListByMemberID(ctx, memberID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		for _, p := range proposals {
+			v := memberProposalView{ID: p.ID, TargetBelt: p.TargetBelt, Status: p.Status, CreatedAt: p.CreatedAt, DecidedAt: p.DecidedAt}
+			if p.EventID != "" {
+				if e, err := stores.CalendarEventStore.GetByID(ctx, p.EventID); err == nil {
+					v.EventTitle = e.Title
+					v.EventDate = e.StartDate.Format("2006-01-02")
+				}
+			}
+			views = append(views, v)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(views)
+}
@@ -0,0 +1,266 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	accountDomain "workshop/internal/domain/account"
+	calendarDomain "workshop/internal/domain/calendar"
+	gradingDomain "workshop/internal/domain/grading"
+	notificationDomain "workshop/internal/domain/notification"
+)
+
+// --- Mock stores ---
+
+type mockProposalCommentStore struct {
+	comments []gradingDomain.ProposalComment
+}
+
+// Save implements grading.ProposalCommentStore for testing.
+// PRE: value has been validated
+// POST: Comment is appended
+func (m *mockProposalCommentStore) Save(_ context.Context, value gradingDomain.ProposalComment) error {
+	m.comments = append(m.comments, value)
+	return nil
+}
+
+// ListByProposalID implements grading.ProposalCommentStore for testing.
+// PRE: proposalID is non-empty
+// POST: Returns the proposal's comments in insertion order
+func (m *mockProposalCommentStore) ListByProposalID(_ context.Context, proposalID string) ([]gradingDomain.ProposalComment, error) {
+	var list []gradingDomain.ProposalComment
+	for _, c := range m.comments {
+		if c.ProposalID == proposalID {
+			list = append(list, c)
+		}
+	}
+	return list, nil
+}
+
+type mockCalendarEventStore struct {
+	events map[string]calendarDomain.Event
+}
+
+// Save implements calendar.Store for testing.
+// PRE: e has been validated
+// POST: Event is upserted
+func (m *mockCalendarEventStore) Save(_ context.Context, e calendarDomain.Event) error {
+	m.events[e.ID] = e
+	return nil
+}
+
+// GetByID implements calendar.Store for testing.
+// PRE: id is non-empty
+// POST: Returns the event or sql.ErrNoRows
+func (m *mockCalendarEventStore) GetByID(_ context.Context, id string) (calendarDomain.Event, error) {
+	e, ok := m.events[id]
+	if !ok {
+		return calendarDomain.Event{}, sql.ErrNoRows
+	}
+	return e, nil
+}
+
+// ListByDateRange implements calendar.Store for testing.
+// PRE: from and to are YYYY-MM-DD
+// POST: Returns every event ordered by start date
+func (m *mockCalendarEventStore) ListByDateRange(_ context.Context, from, to string) ([]calendarDomain.Event, error) {
+	var list []calendarDomain.Event
+	for _, e := range m.events {
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].StartDate.Before(list[j].StartDate) })
+	return list, nil
+}
+
+// Delete implements calendar.Store for testing.
+// PRE: id is non-empty
+// POST: Event is removed
+func (m *mockCalendarEventStore) Delete(_ context.Context, id string) error {
+	delete(m.events, id)
+	return nil
+}
+
+// newGradingProposalTestStores returns stores with a linked member, a grading day and a competition.
+func newGradingProposalTestStores() *Stores {
+	s := newNotificationTestStores()
+	s.ProposalCommentStore = &mockProposalCommentStore{}
+	s.CalendarEventStore = &mockCalendarEventStore{events: map[string]calendarDomain.Event{
+		"grading-day": {ID: "grading-day", Title: "Winter grading", Type: calendarDomain.TypeEvent, StartDate: time.Date(2026, 11, 28, 0, 0, 0, 0, time.UTC)},
+		"comp":        {ID: "comp", Title: "Nationals", Type: calendarDomain.TypeCompetition, StartDate: time.Date(2026, 12, 5, 0, 0, 0, 0, time.UTC)},
+	}}
+	s.AccountStore.Save(context.Background(), accountDomain.Account{ID: coachSession.AccountID, Email: coachSession.Email, Role: "coach"})
+	return s
+}
+
+func saveProposal(t *testing.T, id, status string) {
+	t.Helper()
+	err := stores.GradingProposalStore.Save(context.Background(), gradingDomain.Proposal{
+		ID: id, MemberID: "member-001", TargetBelt: gradingDomain.BeltBlue,
+		ProposedBy: coachSession.AccountID, Status: status, CreatedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("save proposal: %v", err)
+	}
+}
+
+// TestGradingProposals_ScheduleAndMemberView verifies a proposal can be booked onto a grading
+// day and the member sees its status without the coach's notes.
+func TestGradingProposals_ScheduleAndMemberView(t *testing.T) {
+	stores = newGradingProposalTestStores()
+
+	rec := httptest.NewRecorder()
+	handleGradingProposals(rec, authRequest("POST", "/api/grading/proposals", `{"MemberID":"member-001","TargetBelt":"blue","Notes":"private"}`, coachSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("propose: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var proposal gradingDomain.Proposal
+	json.NewDecoder(rec.Body).Decode(&proposal)
+
+	schedule := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handleGradingProposalSchedule(rec, authRequest("POST", "/api/grading/proposals/schedule", body, adminSession))
+		return rec
+	}
+	if rec := schedule(`{"ProposalID":"` + proposal.ID + `","EventID":"comp"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("competition as grading day: expected 400, got %d", rec.Code)
+	}
+	if rec := schedule(`{"ProposalID":"` + proposal.ID + `","EventID":"missing"}`); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown event: expected 404, got %d", rec.Code)
+	}
+	if rec := schedule(`{"ProposalID":"` + proposal.ID + `","EventID":"grading-day"}`); rec.Code != http.StatusOK {
+		t.Fatalf("schedule: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleGradingProposals(rec, authRequest("GET", "/api/grading/proposals?event_id=grading-day", "", adminSession))
+	var booked []gradingDomain.Proposal
+	json.NewDecoder(rec.Body).Decode(&booked)
+	if len(booked) != 1 || booked[0].Status != gradingDomain.ProposalScheduled {
+		t.Fatalf("expected one scheduled proposal on the grading day, got %+v", booked)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMyGradingProposals(rec, authRequest("GET", "/api/grading/proposals/mine", "", memberSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("mine: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var raw []map[string]any
+	json.NewDecoder(rec.Body).Decode(&raw)
+	if len(raw) != 1 {
+		t.Fatalf("expected 1 proposal for the member, got %d", len(raw))
+	}
+	if raw[0]["Status"] != gradingDomain.ProposalScheduled || raw[0]["EventTitle"] != "Winter grading" || raw[0]["EventDate"] != "2026-11-28" {
+		t.Errorf("unexpected member view: %+v", raw[0])
+	}
+	if _, leaked := raw[0]["Notes"]; leaked {
+		t.Error("member view exposes coach notes")
+	}
+
+	list, _ := fetchNotifications(t)
+	kinds := map[string]int{}
+	for _, n := range list {
+		kinds[n.Kind]++
+	}
+	if kinds[notificationDomain.KindGradingProposed] != 2 {
+		t.Errorf("expected proposed and scheduled notifications, got %v", kinds)
+	}
+
+	if rec := schedule(`{"ProposalID":"` + proposal.ID + `","EventID":""}`); rec.Code != http.StatusOK {
+		t.Fatalf("unschedule: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	got, _ := stores.GradingProposalStore.GetByID(context.Background(), proposal.ID)
+	if got.Status != gradingDomain.ProposalPending || got.EventID != "" {
+		t.Errorf("expected pending without event after unschedule, got %s/%q", got.Status, got.EventID)
+	}
+}
+
+// TestGradingProposalComments verifies staff can discuss a proposal and members cannot.
+func TestGradingProposalComments(t *testing.T) {
+	stores = newGradingProposalTestStores()
+	saveProposal(t, "p1", gradingDomain.ProposalPending)
+
+	tests := []struct {
+		name string
+		body string
+		sess middleware.Session
+		want int
+	}{
+		{name: "coach comments", body: `{"ProposalID":"p1","Content":"Strong guard passing lately"}`, sess: coachSession, want: http.StatusCreated},
+		{name: "empty content", body: `{"ProposalID":"p1","Content":""}`, sess: coachSession, want: http.StatusBadRequest},
+		{name: "unknown proposal", body: `{"ProposalID":"nope","Content":"x"}`, sess: adminSession, want: http.StatusNotFound},
+		{name: "member", body: `{"ProposalID":"p1","Content":"pick me"}`, sess: memberSession, want: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleGradingProposalComments(rec, authRequest("POST", "/api/grading/proposals/comments", tt.body, tt.sess))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	handleGradingProposalComments(rec, authRequest("GET", "/api/grading/proposals/comments?proposal_id=p1", "", adminSession))
+	var comments []proposalCommentView
+	json.NewDecoder(rec.Body).Decode(&comments)
+	if len(comments) != 1 || comments[0].AuthorEmail != coachSession.Email {
+		t.Errorf("expected the coach's comment with author email, got %+v", comments)
+	}
+}
+
+// TestHandleGradingDecideBatch verifies each decision in a batch stands alone.
+func TestHandleGradingDecideBatch(t *testing.T) {
+	stores = newGradingProposalTestStores()
+	saveProposal(t, "p1", gradingDomain.ProposalPending)
+	saveProposal(t, "p2", gradingDomain.ProposalPending)
+	saveProposal(t, "p3", gradingDomain.ProposalApproved)
+
+	body := `{"Decisions":[
+		{"ProposalID":"p1","Decision":"approve"},
+		{"ProposalID":"p2","Decision":"reject"},
+		{"ProposalID":"p3","Decision":"approve"},
+		{"ProposalID":"missing","Decision":"approve"}]}`
+	rec := httptest.NewRecorder()
+	handleGradingDecideBatch(rec, authRequest("POST", "/api/grading/proposals/decide-batch", body, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp batchDecisionResponse
+	json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Approved != 1 || resp.Rejected != 1 || resp.Failed != 2 {
+		t.Errorf("expected 1 approved, 1 rejected, 2 failed; got %+v", resp)
+	}
+	if len(resp.Results) != 4 || resp.Results[2].Error == "" || resp.Results[3].Error == "" {
+		t.Errorf("expected per-proposal errors for p3 and missing, got %+v", resp.Results)
+	}
+	records, _ := stores.GradingRecordStore.ListByMemberID(context.Background(), "member-001")
+	if len(records) != 1 {
+		t.Errorf("expected one promotion recorded, got %d", len(records))
+	}
+
+	for _, tt := range []struct {
+		name string
+		body string
+		sess middleware.Session
+		want int
+	}{
+		{name: "empty batch", body: `{"Decisions":[]}`, sess: adminSession, want: http.StatusBadRequest},
+		{name: "coach", body: `{"Decisions":[{"ProposalID":"p1","Decision":"approve"}]}`, sess: coachSession, want: http.StatusForbidden},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleGradingDecideBatch(rec, authRequest("POST", "/api/grading/proposals/decide-batch", tt.body, tt.sess))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+}
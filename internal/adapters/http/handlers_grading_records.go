@@ -0,0 +1,319 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	gradingDomain "workshop/internal/domain/grading"
+)
+
+// gradingRecordAmendRequest is the body of POST /api/grading/records/amend.
+type gradingRecordAmendRequest struct {
+	RecordID   string `json:"RecordID"`
+	Belt       string `json:"Belt"`
+	Stripe     int    `json:"Stripe"`
+	PromotedAt string `json:"PromotedAt"` // YYYY-MM-DD; empty keeps the original date
+	Reason     string `json:"Reason"`
+}
+
+// gradingRecordVoidRequest is the body of POST /api/grading/records/void.
+type gradingRecordVoidRequest struct {
+	RecordID string `json:"RecordID"`
+	Reason   string `json:"Reason"`
+}
+
+// gradingBulkAwardRequest is the body of POST /api/grading/bulk-award.
+type gradingBulkAwardRequest struct {
+	MemberIDs      []string `json:"MemberIDs"`
+	Kind           string   `json:"Kind"`           // stripe or belt
+	Belt           string   `json:"Belt"`           // the belt awarded; only for Kind belt
+	Note           string   `json:"Note"`           // optional: saved on every member
+	NoteVisibility string   `json:"NoteVisibility"` // coaches (default), admin or shared
+	Date           string   `json:"Date"`           // YYYY-MM-DD; empty awards today
+	Preview        bool     `json:"Preview"`        // true returns each member's current and new rank without saving
+}
+
+// correctGradingRecordDeps wires the grading record correction orchestrators.
+func correctGradingRecordDeps() orchestrators.CorrectGradingRecordDeps {
+	return orchestrators.CorrectGradingRecordDeps{
+		RecordStore: stores.GradingRecordStore,
+		AuditStore:  stores.AuditStore,
+		GenerateID:  generateID,
+		Now:         timeNow,
+	}
+}
+
+// handleGradingRecords handles GET /api/grading/records?member_id=
+// Returns a member's full grading history, newest first, including amended and voided
+// records so the correction chain can be reviewed. Admin only.
+func handleGradingRecords(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	memberID := r.URL.Query().Get("member_id")
+	if memberID == "" {
+		apierror.Validation(w, "member_id is required")
+		return
+	}
+	records, err := stores.GradingRecordStore.ListHistoryByMemberID(r.Context(), memberID)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	if records == nil {
+		records = []gradingDomain.Record{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(records)
+}
+
+// handleGradingRecordAmend handles POST /api/grading/records/amend
+// Corrects the belt, stripe or date of a grading record. The original is kept and
+// superseded by a new record carrying the reason. Admin only; every amendment is audited.
+func handleGradingRecordAmend(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	var input gradingRecordAmendRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	var promotedAt time.Time
+	if input.PromotedAt != "" {
+		date, err := time.Parse("2006-01-02", input.PromotedAt)
+		if err != nil {
+			apierror.Validation(w, "PromotedAt must be YYYY-MM-DD")
+			return
+		}
+		promotedAt = date
+	}
+
+	record, err := orchestrators.ExecuteAmendGradingRecord(r.Context(), orchestrators.AmendGradingRecordInput{
+		RecordID:   input.RecordID,
+		Belt:       input.Belt,
+		Stripe:     input.Stripe,
+		PromotedAt: promotedAt,
+		Reason:     input.Reason,
+		Actor:      gradingCorrectionActor(r, sess),
+	}, correctGradingRecordDeps())
+	if writeGradingCorrectionError(w, err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(record)
+}
+
+// handleGradingRecordVoid handles POST /api/grading/records/void
+// Voids a grading record entered in error; it stays in the history but no longer counts
+// towards the member's belt or readiness. Admin only; every void is audited.
+func handleGradingRecordVoid(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	var input gradingRecordVoidRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+
+	record, err := orchestrators.ExecuteVoidGradingRecord(r.Context(), orchestrators.VoidGradingRecordInput{
+		RecordID: input.RecordID,
+		Reason:   input.Reason,
+		Actor:    gradingCorrectionActor(r, sess),
+	}, correctGradingRecordDeps())
+	if writeGradingCorrectionError(w, err) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(record)
+}
+
+// handleGradingBulkAward handles POST /api/grading/bulk-award
+// Gives many members a stripe or a belt at once after a grading day, with an optional note
+// and date. A preview lists each member's current and new rank; the award itself is saved
+// in one transaction, so one member who cannot take it stops the lot. Admin only; audited.
+func handleGradingBulkAward(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	var input gradingBulkAwardRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	var date time.Time
+	if input.Date != "" {
+		d, err := time.Parse("2006-01-02", input.Date)
+		if err != nil {
+			apierror.Validation(w, "Date must be YYYY-MM-DD")
+			return
+		}
+		date = d
+	}
+
+	result, err := orchestrators.ExecuteBulkAward(r.Context(), orchestrators.BulkAwardInput{
+		MemberIDs:      input.MemberIDs,
+		Kind:           input.Kind,
+		Belt:           input.Belt,
+		Note:           input.Note,
+		NoteVisibility: input.NoteVisibility,
+		Date:           date,
+		Preview:        input.Preview,
+		Actor:          gradingCorrectionActor(r, sess),
+	}, orchestrators.BulkAwardDeps{
+		MemberStore: stores.MemberStore,
+		RecordStore: stores.GradingRecordStore,
+		AuditStore:  stores.AuditStore,
+		GenerateID:  generateID,
+		Now:         timeNow,
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, orchestrators.ErrBulkAwardRejected), errors.Is(err, gradingDomain.ErrInvalidAwardKind),
+		errors.Is(err, gradingDomain.ErrInvalidBelt), errors.Is(err, gradingDomain.ErrNoAwardMembers),
+		errors.Is(err, gradingDomain.ErrTooManyAwards), errors.Is(err, gradingDomain.ErrAwardNoteTooLong),
+		errors.Is(err, gradingDomain.ErrInvalidNoteVisibility), errors.Is(err, gradingDomain.ErrAwardInFuture):
+		apierror.Validation(w, err.Error())
+		return
+	default:
+		internalError(w, err)
+		return
+	}
+	for _, record := range result.Records {
+		takeGradingStock(r.Context(), record)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if !result.Preview {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(result)
+}
+
+const gradingImportMaxBytes = 5 << 20 // 5 MB
+
+// handleGradingImport handles POST /api/grading/import?dry_run=true|false
+// Imports the belts and stripes members earned before the club used the app from a
+// multipart CSV upload (field "file"), as backdated grading records. Each row is reported as
+// created, a duplicate of a record already held, or rejected with the reason; a dry run
+// reports the same without saving. Stock is not consumed. Admin only; audited.
+func handleGradingImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, gradingImportMaxBytes)
+	if err := r.ParseMultipartForm(gradingImportMaxBytes); err != nil {
+		apierror.Validation(w, "file too large or invalid form")
+		return
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		apierror.Validation(w, "missing file field")
+		return
+	}
+	defer file.Close()
+	rows, err := orchestrators.ReadBeltImportCSV(file)
+	if err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+
+	result, err := orchestrators.ExecuteImportBelts(r.Context(), orchestrators.ImportBeltsInput{
+		Rows:   rows,
+		DryRun: r.URL.Query().Get("dry_run") == "true",
+		Actor:  gradingCorrectionActor(r, sess),
+	}, orchestrators.ImportBeltsDeps{
+		MemberStore: stores.MemberStore,
+		RecordStore: stores.GradingRecordStore,
+		AuditStore:  stores.AuditStore,
+		GenerateID:  generateID,
+		Now:         timeNow,
+	})
+	switch {
+	case errors.Is(err, orchestrators.ErrInvalidBeltImport):
+		apierror.Validation(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// gradingCorrectionActor captures who made a correction and from where, for the audit log.
+func gradingCorrectionActor(r *http.Request, sess middleware.Session) orchestrators.BackfillActor {
+	return orchestrators.BackfillActor{
+		AccountID: sess.AccountID,
+		Email:     sess.Email,
+		Role:      sess.Role,
+		IPAddress: middleware.ClientIP(r),
+		UserAgent: r.UserAgent(),
+	}
+}
+
+// writeGradingCorrectionError maps correction errors to API responses, reporting whether
+// one was written.
+func writeGradingCorrectionError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, orchestrators.ErrGradingRecordNotFound):
+		apierror.NotFound(w, err.Error())
+	case errors.Is(err, gradingDomain.ErrRecordNotEffective):
+		apierror.Conflict(w, err.Error())
+	case errors.Is(err, gradingDomain.ErrEmptyCorrectionReason), errors.Is(err, gradingDomain.ErrCorrectionUnchanged),
+		errors.Is(err, gradingDomain.ErrInvalidBelt), errors.Is(err, gradingDomain.ErrInvalidStripe):
+		apierror.Validation(w, err.Error())
+	default:
+		internalError(w, err)
+	}
+	return true
+}
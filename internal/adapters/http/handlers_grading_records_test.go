@@ -0,0 +1,210 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	gradingDomain "workshop/internal/domain/grading"
+	memberDomain "workshop/internal/domain/member"
+)
+
+// newGradingRecordTestStores returns stores holding one blue belt promotion for member-1.
+func newGradingRecordTestStores() *mockGradingRecordStore {
+	stores = newFullStores()
+	stores.AuditStore = &mockAuditStore{}
+	records := stores.GradingRecordStore.(*mockGradingRecordStore)
+	records.records["gr-1"] = gradingDomain.Record{
+		ID: "gr-1", MemberID: "member-1", Belt: gradingDomain.BeltBlue, Stripe: 1,
+		PromotedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Method: gradingDomain.MethodStandard,
+	}
+	return records
+}
+
+// TestHandleGradingRecordAmend_SupersedesOriginal verifies an amendment keeps the original
+// in the history while only the corrected record counts.
+func TestHandleGradingRecordAmend_SupersedesOriginal(t *testing.T) {
+	records := newGradingRecordTestStores()
+
+	rec := httptest.NewRecorder()
+	handleGradingRecordAmend(rec, authRequest("POST", "/api/grading/records/amend",
+		`{"RecordID":"gr-1","Belt":"blue","Stripe":1,"PromotedAt":"2026-01-15","Reason":"Graded a fortnight earlier"}`, adminSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var amended gradingDomain.Record
+	json.NewDecoder(rec.Body).Decode(&amended)
+	if amended.Supersedes != "gr-1" || amended.PromotedAt.Format("2006-01-02") != "2026-01-15" || amended.CorrectedBy != "admin-001" {
+		t.Errorf("unexpected amended record: %+v", amended)
+	}
+
+	effective, _ := records.ListByMemberID(context.Background(), "member-1")
+	if len(effective) != 1 || effective[0].ID != amended.ID {
+		t.Errorf("expected only the amended record to count, got %+v", effective)
+	}
+	rec = httptest.NewRecorder()
+	handleGradingRecords(rec, authRequest("GET", "/api/grading/records?member_id=member-1", "", adminSession))
+	var history []gradingDomain.Record
+	json.NewDecoder(rec.Body).Decode(&history)
+	if len(history) != 2 {
+		t.Fatalf("expected original and amendment in the history, got %+v", history)
+	}
+	if n := len(stores.AuditStore.(*mockAuditStore).events); n != 1 {
+		t.Errorf("expected 1 audit event, got %d", n)
+	}
+
+	rec = httptest.NewRecorder()
+	handleGradingRecordAmend(rec, authRequest("POST", "/api/grading/records/amend",
+		`{"RecordID":"gr-1","Belt":"purple","Reason":"again"}`, adminSession))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("amending a superseded record: expected 409, got %d", rec.Code)
+	}
+}
+
+// TestHandleGradingRecordVoid verifies a void needs a reason and removes the record from the
+// effective history.
+func TestHandleGradingRecordVoid(t *testing.T) {
+	records := newGradingRecordTestStores()
+
+	rec := httptest.NewRecorder()
+	handleGradingRecordVoid(rec, authRequest("POST", "/api/grading/records/void", `{"RecordID":"gr-1","Reason":""}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("void without reason: expected 400, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleGradingRecordVoid(rec, authRequest("POST", "/api/grading/records/void", `{"RecordID":"gr-1","Reason":"Wrong member"}`, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if effective, _ := records.ListByMemberID(context.Background(), "member-1"); len(effective) != 0 {
+		t.Errorf("expected no effective records, got %+v", effective)
+	}
+	if !records.records["gr-1"].Voided {
+		t.Error("voided record should be kept")
+	}
+}
+
+// TestHandleGradingRecords_AdminOnly verifies coaches cannot correct grading records.
+func TestHandleGradingRecords_AdminOnly(t *testing.T) {
+	newGradingRecordTestStores()
+
+	rec := httptest.NewRecorder()
+	handleGradingRecordVoid(rec, authRequest("POST", "/api/grading/records/void", `{"RecordID":"gr-1","Reason":"Wrong member"}`, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleGradingBulkAward verifies the preview shows each member's current and new rank
+// without saving, the award saves every member at once, and admins alone may award.
+func TestHandleGradingBulkAward(t *testing.T) {
+	records := newGradingRecordTestStores()
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "member-1", Name: "Alex", Program: "adults", Status: memberDomain.StatusActive})
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "member-2", Name: "Kim", Program: "adults", Status: memberDomain.StatusActive})
+	body := `{"MemberIDs":["member-1","member-2"],"Kind":"stripe","Note":"Grading day","Date":"2026-03-01"%s}`
+
+	rec := httptest.NewRecorder()
+	handleGradingBulkAward(rec, authRequest("POST", "/api/grading/bulk-award", fmt.Sprintf(body, `,"Preview":true`), adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("preview: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var preview orchestrators.BulkAwardResult
+	json.NewDecoder(rec.Body).Decode(&preview)
+	if len(preview.Rows) != 2 || preview.Rows[0].Current.Belt != gradingDomain.BeltBlue || preview.Rows[0].New.Stripe != 2 || preview.Rows[1].New.Stripe != 1 {
+		t.Fatalf("preview rows = %+v", preview.Rows)
+	}
+	if len(records.records) != 1 {
+		t.Errorf("preview saved records: %+v", records.records)
+	}
+
+	rec = httptest.NewRecorder()
+	handleGradingBulkAward(rec, authRequest("POST", "/api/grading/bulk-award", fmt.Sprintf(body, ""), adminSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("award: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(records.records) != 3 || len(records.awardNotes) != 2 {
+		t.Errorf("records = %d, notes = %d; want two awards with their notes", len(records.records), len(records.awardNotes))
+	}
+
+	rec = httptest.NewRecorder()
+	handleGradingBulkAward(rec, authRequest("POST", "/api/grading/bulk-award", `{"MemberIDs":["member-1"],"Kind":"belt","Belt":"white"}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("demotion: expected 400, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleGradingBulkAward(rec, authRequest("POST", "/api/grading/bulk-award", fmt.Sprintf(body, ""), coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+}
+
+// buildGradingImport builds a multipart POST to /api/grading/import.
+func buildGradingImport(t *testing.T, csvContent string, dryRun bool, sess middleware.Session) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	fw, err := w.CreateFormFile("file", "belts.csv")
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	fw.Write([]byte(csvContent))
+	w.Close()
+
+	req := authRequest("POST", fmt.Sprintf("/api/grading/import?dry_run=%t", dryRun), "", sess)
+	req.Body = io.NopCloser(body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.ContentLength = int64(body.Len())
+	return req
+}
+
+// TestHandleGradingImport verifies a preview saves nothing, the import saves backdated
+// records, a file without a BELT column is refused, and only admins may import.
+func TestHandleGradingImport(t *testing.T) {
+	records := newGradingRecordTestStores()
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "member-1", Name: "Alex Smith", Program: "adults", Status: memberDomain.StatusActive})
+	csv := "NAME,BELT,STRIPE,DATE\nAlex Smith,white,4,2025-06-01\nAlex Smith,blue,1,2026-02-01\nAlex Smith,purple,0,2020-01-01\n"
+
+	rec := httptest.NewRecorder()
+	handleGradingImport(rec, buildGradingImport(t, csv, false, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleGradingImport(rec, buildGradingImport(t, "NAME,DATE\nAlex Smith,2025-06-01\n", true, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("missing BELT column: expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleGradingImport(rec, buildGradingImport(t, csv, true, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("preview: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var preview orchestrators.ImportBeltsResult
+	json.NewDecoder(rec.Body).Decode(&preview)
+	if preview.Created != 1 || preview.Duplicate != 1 || preview.Rejected != 1 || len(records.records) != 1 {
+		t.Fatalf("preview = %+v with %d records; want one new, one held already, one out of order, nothing saved", preview, len(records.records))
+	}
+
+	rec = httptest.NewRecorder()
+	handleGradingImport(rec, buildGradingImport(t, csv, false, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("import: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result orchestrators.ImportBeltsResult
+	json.NewDecoder(rec.Body).Decode(&result)
+	if len(result.Records) != 1 || records.records[result.Records[0].ID].Method != gradingDomain.MethodImport {
+		t.Errorf("import records = %+v, want the white belt saved as an import", result.Records)
+	}
+}
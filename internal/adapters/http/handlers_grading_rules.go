@@ -0,0 +1,199 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/projections"
+	gradingDomain "workshop/internal/domain/grading"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// gradingRuleRequest is the body of POST /api/grading/rules.
+type gradingRuleRequest struct {
+	Program  string                    `json:"Program"`
+	Belt     string                    `json:"Belt"`
+	Criteria []gradingDomain.Criterion `json:"Criteria"`
+}
+
+// gradingEligibilityDeps wires the eligibility projection that readiness and proposals share.
+func gradingEligibilityDeps() projections.GetGradingEligibilityDeps {
+	return projections.GetGradingEligibilityDeps{
+		MemberStore:       stores.MemberStore,
+		ConfigStore:       stores.GradingConfigStore,
+		RuleStore:         stores.GradingRuleStore,
+		MemberConfigStore: stores.GradingMemberConfigStore,
+		RecordStore:       stores.GradingRecordStore,
+		AttendanceStore:   stores.AttendanceStore,
+		SessionLogStore:   stores.SessionLogStore,
+		TrainingLogDeps: projections.GetTrainingLogDeps{
+			AttendanceStore:     stores.AttendanceStore,
+			MemberStore:         stores.MemberStore,
+			EstimatedHoursStore: stores.EstimatedHoursStore,
+		},
+		KidsTermDeps: kidsTermReadinessDeps(stores),
+	}
+}
+
+// memberEligibility evaluates one member against the rule for a belt.
+// PRE: memberID is non-empty; an empty targetBelt means the member's next belt
+// POST: Returns the member's entry or an error if the member does not exist
+func memberEligibility(ctx context.Context, memberID, targetBelt string) (projections.GradingEligibilityEntry, error) {
+	result, err := projections.QueryGetGradingEligibility(ctx, projections.GetGradingEligibilityQuery{
+		MemberID:   memberID,
+		TargetBelt: targetBelt,
+		Now:        timeNow(),
+	}, gradingEligibilityDeps())
+	if err != nil {
+		return projections.GradingEligibilityEntry{}, err
+	}
+	if len(result.Entries) == 0 {
+		return projections.GradingEligibilityEntry{}, fmt.Errorf("no eligibility for member %s", memberID)
+	}
+	return result.Entries[0], nil
+}
+
+// describeUnmet lists the criteria a member has not met, for a refused proposal.
+func describeUnmet(entry projections.GradingEligibilityEntry) string {
+	var parts []string
+	for _, c := range entry.Checks {
+		if c.Met {
+			continue
+		}
+		switch c.Kind {
+		case gradingDomain.CriterionMatHours:
+			parts = append(parts, fmt.Sprintf("%.1f of %g mat hours", c.Actual, c.Min))
+		case gradingDomain.CriterionAttendancePct:
+			parts = append(parts, fmt.Sprintf("%.0f%% of %g%% term attendance", c.Actual, c.Min))
+		case gradingDomain.CriterionMonthsAtBelt:
+			parts = append(parts, fmt.Sprintf("%g of %g months at belt", c.Actual, c.Min))
+		case gradingDomain.CriterionSessionsWithCoach:
+			name := c.CoachID
+			if a, err := stores.AccountStore.GetByID(context.Background(), c.CoachID); err == nil {
+				name = a.Email
+			}
+			parts = append(parts, fmt.Sprintf("%g of %g classes with %s", c.Actual, c.Min, name))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// handleGradingRules handles GET/POST/DELETE for /api/grading/rules
+// GET lists the eligibility rules. POST sets the criteria for a program and belt, replacing
+// any rule it had. DELETE (?id=) returns the belt to its grading config. Admin only.
+func handleGradingRules(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		rules, err := stores.GradingRuleStore.List(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if rules == nil {
+			rules = []gradingDomain.Rule{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(rules)
+
+	case "POST":
+		var input gradingRuleRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		rule := gradingDomain.Rule{
+			ID:        generateID(),
+			Program:   input.Program,
+			Belt:      input.Belt,
+			Criteria:  input.Criteria,
+			UpdatedBy: sess.AccountID,
+			UpdatedAt: timeNow(),
+		}
+		if err := rule.Validate(); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		for _, c := range rule.Criteria {
+			if c.Kind != gradingDomain.CriterionSessionsWithCoach {
+				continue
+			}
+			if _, err := stores.AccountStore.GetByID(ctx, c.CoachID); err != nil {
+				apierror.NotFound(w, "coach not found")
+				return
+			}
+		}
+		if err := stores.GradingRuleStore.Save(ctx, rule); err != nil {
+			internalError(w, err)
+			return
+		}
+		// The store keeps the first ID for a program and belt; return what it holds.
+		saved, err := stores.GradingRuleStore.GetByProgramAndBelt(ctx, rule.Program, rule.Belt)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(saved)
+
+	case "DELETE":
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			apierror.Validation(w, "id is required")
+			return
+		}
+		if err := stores.GradingRuleStore.Delete(ctx, id); err != nil {
+			internalError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleGradingEligibility handles GET /api/grading/eligibility?member_id=&belt=
+// How a member stands against every criterion for a belt (their next belt by default),
+// as proposals are checked.
+func handleGradingEligibility(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionGradingManage)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	memberID := r.URL.Query().Get("member_id")
+	if memberID == "" {
+		apierror.Validation(w, "member_id is required")
+		return
+	}
+	if _, err := stores.MemberStore.GetByID(r.Context(), memberID); err != nil {
+		apierror.NotFound(w, "member not found")
+		return
+	}
+	entry, err := memberEligibility(r.Context(), memberID, r.URL.Query().Get("belt"))
+	if err != nil {
+		apierror.Validation(w, "member is already at the highest belt")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry)
+}
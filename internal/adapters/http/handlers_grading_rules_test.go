@@ -0,0 +1,218 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	accountDomain "workshop/internal/domain/account"
+	attendanceDomain "workshop/internal/domain/attendance"
+	gradingDomain "workshop/internal/domain/grading"
+	memberDomain "workshop/internal/domain/member"
+)
+
+// --- Mock stores ---
+
+type mockGradingRuleStore struct {
+	rules map[string]gradingDomain.Rule // key = program|belt
+}
+
+// Save implements grading.RuleStore for testing.
+// PRE: r has been validated
+// POST: The rule for its program and belt is replaced, keeping the first ID
+func (m *mockGradingRuleStore) Save(_ context.Context, r gradingDomain.Rule) error {
+	key := r.Program + "|" + r.Belt
+	if existing, ok := m.rules[key]; ok {
+		r.ID = existing.ID
+	}
+	m.rules[key] = r
+	return nil
+}
+
+// GetByProgramAndBelt implements grading.RuleStore for testing.
+// PRE: program and belt are non-empty
+// POST: Returns the rule or sql.ErrNoRows
+func (m *mockGradingRuleStore) GetByProgramAndBelt(_ context.Context, program, belt string) (gradingDomain.Rule, error) {
+	r, ok := m.rules[program+"|"+belt]
+	if !ok {
+		return gradingDomain.Rule{}, sql.ErrNoRows
+	}
+	return r, nil
+}
+
+// List implements grading.RuleStore for testing.
+// PRE: none
+// POST: Returns every rule
+func (m *mockGradingRuleStore) List(_ context.Context) ([]gradingDomain.Rule, error) {
+	var list []gradingDomain.Rule
+	for _, r := range m.rules {
+		list = append(list, r)
+	}
+	return list, nil
+}
+
+// Delete implements grading.RuleStore for testing.
+// PRE: id is non-empty
+// POST: The rule is removed if present
+func (m *mockGradingRuleStore) Delete(_ context.Context, id string) error {
+	for k, r := range m.rules {
+		if r.ID == id {
+			delete(m.rules, k)
+		}
+	}
+	return nil
+}
+
+// newGradingRuleTestStores returns stores with an adult white belt, member-001, who has
+// trained four two-hour classes, and a rule that blue belt needs 10 mat hours.
+func newGradingRuleTestStores() *Stores {
+	s := newFullStores()
+	ctx := context.Background()
+	rules := &mockGradingRuleStore{rules: map[string]gradingDomain.Rule{}}
+	rules.Save(ctx, gradingDomain.Rule{ID: "rule-blue", Program: memberDomain.ProgramAdults, Belt: gradingDomain.BeltBlue, Criteria: []gradingDomain.Criterion{
+		{Kind: gradingDomain.CriterionMatHours, Min: 10},
+	}})
+	s.GradingRuleStore = rules
+	s.MemberStore.Save(ctx, memberDomain.Member{ID: "member-001", Name: "Ana", Email: "ana@test.com", Program: memberDomain.ProgramAdults, Status: "active"})
+	s.AccountStore.Save(ctx, accountDomain.Account{ID: "coach-001", Email: "coach@test.com", Role: accountDomain.RoleCoach})
+	start := time.Date(2025, 1, 6, 18, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		in := start.AddDate(0, 0, 7*i)
+		s.AttendanceStore.Save(ctx, attendanceDomain.Attendance{ID: "att-" + string(rune('a'+i)), MemberID: "member-001", CheckInTime: in, CheckOutTime: in.Add(2 * time.Hour), ClassDate: in.Format("2006-01-02")})
+	}
+	return s
+}
+
+// TestHandleGradingRules_AdminOnly verifies coaches cannot change eligibility rules.
+func TestHandleGradingRules_AdminOnly(t *testing.T) {
+	stores = newGradingRuleTestStores()
+	rec := httptest.NewRecorder()
+	handleGradingRules(rec, authRequest("POST", "/api/grading/rules", `{"Program":"adults","Belt":"blue","Criteria":[{"Kind":"mat_hours","Min":5}]}`, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("got %d, want 403", rec.Code)
+	}
+}
+
+// TestHandleGradingRules_SaveListDelete verifies a rule is replaced, listed and removed.
+func TestHandleGradingRules_SaveListDelete(t *testing.T) {
+	stores = newGradingRuleTestStores()
+	body := `{"Program":"adults","Belt":"blue","Criteria":[{"Kind":"mat_hours","Min":150},{"Kind":"months_at_belt","Min":18},{"Kind":"sessions_with_coach","Min":10,"CoachID":"coach-001"}]}`
+	rec := httptest.NewRecorder()
+	handleGradingRules(rec, authRequest("POST", "/api/grading/rules", body, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var saved gradingDomain.Rule
+	json.NewDecoder(rec.Body).Decode(&saved)
+	if saved.ID != "rule-blue" || len(saved.Criteria) != 3 || saved.UpdatedBy != "admin-001" {
+		t.Errorf("saved = %+v, want rule-blue replaced with three criteria", saved)
+	}
+
+	rec = httptest.NewRecorder()
+	handleGradingRules(rec, authRequest("GET", "/api/grading/rules", "", adminSession))
+	var list []gradingDomain.Rule
+	json.NewDecoder(rec.Body).Decode(&list)
+	if len(list) != 1 {
+		t.Errorf("got %d rules, want 1", len(list))
+	}
+
+	rec = httptest.NewRecorder()
+	handleGradingRules(rec, authRequest("DELETE", "/api/grading/rules?id=rule-blue", "", adminSession))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("got %d, want 204", rec.Code)
+	}
+	if _, err := stores.GradingRuleStore.GetByProgramAndBelt(context.Background(), "adults", "blue"); err == nil {
+		t.Error("rule still stored after delete")
+	}
+}
+
+// TestHandleGradingRules_Invalid verifies bad criteria and unknown coaches are rejected.
+func TestHandleGradingRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"attendance for adults", `{"Program":"adults","Belt":"blue","Criteria":[{"Kind":"attendance_pct","Min":80}]}`, http.StatusBadRequest},
+		{"no criteria", `{"Program":"adults","Belt":"blue","Criteria":[]}`, http.StatusBadRequest},
+		{"unknown coach", `{"Program":"adults","Belt":"blue","Criteria":[{"Kind":"sessions_with_coach","Min":5,"CoachID":"nobody"}]}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stores = newGradingRuleTestStores()
+			rec := httptest.NewRecorder()
+			handleGradingRules(rec, authRequest("POST", "/api/grading/rules", tt.body, adminSession))
+			if rec.Code != tt.want {
+				t.Errorf("got %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+// TestHandleGradingEligibility verifies a member's checks against their next belt's rule.
+func TestHandleGradingEligibility(t *testing.T) {
+	stores = newGradingRuleTestStores()
+	rec := httptest.NewRecorder()
+	handleGradingEligibility(rec, authRequest("GET", "/api/grading/eligibility?member_id=member-001", "", coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var entry struct {
+		TargetBelt string
+		RuleID     string
+		Eligible   bool
+		Checks     []gradingDomain.Check
+	}
+	json.NewDecoder(rec.Body).Decode(&entry)
+	if entry.TargetBelt != "blue" || entry.RuleID != "rule-blue" || entry.Eligible || len(entry.Checks) != 1 || entry.Checks[0].Actual != 8 {
+		t.Errorf("entry = %+v, want 8 of 10 hours for blue", entry)
+	}
+
+	rec = httptest.NewRecorder()
+	handleGradingEligibility(rec, authRequest("GET", "/api/grading/eligibility?member_id=member-001", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member got %d, want 403", rec.Code)
+	}
+}
+
+// TestHandleGradingProposals_RequiresEligibility verifies coaches cannot propose a member
+// short of the rule, while admins can override it.
+func TestHandleGradingProposals_RequiresEligibility(t *testing.T) {
+	stores = newGradingRuleTestStores()
+	body := `{"MemberID":"member-001","TargetBelt":"blue","Notes":"Ready"}`
+
+	rec := httptest.NewRecorder()
+	handleGradingProposals(rec, authRequest("POST", "/api/grading/proposals", body, coachSession))
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "8.0 of 10 mat hours") {
+		t.Fatalf("coach got %d: %s, want 409 naming the hours", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleGradingProposals(rec, authRequest("POST", "/api/grading/proposals", body, adminSession))
+	if rec.Code != http.StatusCreated {
+		t.Errorf("admin got %d, want 201: %s", rec.Code, rec.Body.String())
+	}
+}
+
+// TestHandleGradingReadiness_UsesRule verifies readiness reports the rule's criteria.
+func TestHandleGradingReadiness_UsesRule(t *testing.T) {
+	stores = newGradingRuleTestStores()
+	rec := httptest.NewRecorder()
+	handleGradingReadiness(rec, authRequest("GET", "/api/grading/readiness", "", coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var resp readinessResponse
+	json.NewDecoder(rec.Body).Decode(&resp)
+	if len(resp.Adults) != 1 {
+		t.Fatalf("Adults = %+v, want member-001", resp.Adults)
+	}
+	if a := resp.Adults[0]; a.MatHours != 8 || a.RequiredHrs != 10 || a.PercentReady != 80 || a.Eligible {
+		t.Errorf("entry = %+v, want 8 of 10 hours, 80%% ready", a)
+	}
+}
@@ -0,0 +1,144 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+)
+
+// impersonateAccountRequest is the body of POST /api/admin/accounts/impersonate.
+type impersonateAccountRequest struct {
+	AccountID string `json:"AccountID"`
+}
+
+// handleImpersonateAccount handles POST /api/admin/accounts/impersonate
+// Signs the admin in as another account (its member record, messages and goals) for
+// authsession.ImpersonationLimit. The admin's identity is kept on the session so
+// POST /api/devmode/restore, or the limit running out, brings it back. Admin only.
+func handleImpersonateAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "member_mgmt") {
+		return
+	}
+	var input impersonateAccountRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	cookie, err := r.Cookie("workshop_session")
+	if err != nil {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+
+	result, err := orchestrators.ExecuteImpersonateAccount(r.Context(), orchestrators.ImpersonateAccountInput{
+		AccountID:     input.AccountID,
+		Actor:         impersonationActor(r, sess.AccountID, sess.Email, sess.Role),
+		Impersonating: sess.IsImpersonating(),
+	}, orchestrators.ImpersonateAccountDeps{
+		AccountStore: stores.AccountStore,
+		AuditStore:   stores.AuditStore,
+		Now:          timeNow,
+	})
+	switch {
+	case errors.Is(err, orchestrators.ErrImpersonateNotFound):
+		apierror.NotFound(w, err.Error())
+		return
+	case errors.Is(err, orchestrators.ErrImpersonateAdmin):
+		apierror.Forbidden(w, err.Error())
+		return
+	case err != nil:
+		apierror.Validation(w, err.Error())
+		return
+	}
+
+	sess.RealAccountID = sess.AccountID
+	sess.RealEmail = sess.Email
+	sess.RealRole = sess.Role
+	sess.AccountID = result.AccountID
+	sess.Email = result.Email
+	sess.Role = result.Role
+	sess.ImpersonationExpiresAt = result.ExpiresAt
+	sessions.Update(r.Context(), cookie.Value, sess)
+
+	slog.InfoContext(r.Context(), "devmode_event",
+		"event", "impersonate_account",
+		"admin_account_id", sess.RealAccountID,
+		"account_id", result.AccountID,
+		"expires_at", result.ExpiresAt,
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"redirect": "/dashboard", "expires_at": result.ExpiresAt})
+}
+
+// guardImpersonation ends an account impersonation that has run past its limit and audits
+// every change an admin makes while signed in as another account. Reads are not audited.
+// An expired impersonation never runs the request: pages go back to the accounts page and
+// API calls are refused, so nothing meant for the impersonated account runs as the admin.
+func guardImpersonation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sess, ok := middleware.GetSessionFromContext(r.Context())
+		if !ok || !sess.IsImpersonatingAccount() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		actor := impersonationActor(r, sess.RealAccountID, sess.RealEmail, sess.RealRole)
+		if sess.ImpersonationExpired(timeNow()) {
+			if cookie, err := r.Cookie("workshop_session"); err == nil {
+				sessions.Update(r.Context(), cookie.Value, sess.WithoutImpersonation())
+			}
+			orchestrators.ExecuteEndAccountImpersonation(r.Context(), orchestrators.EndAccountImpersonationInput{
+				AccountID: sess.AccountID,
+				Email:     sess.Email,
+				Expired:   true,
+				Actor:     actor,
+			}, stores.AuditStore)
+			slog.InfoContext(r.Context(), "devmode_event",
+				"event", "impersonation_expired",
+				"admin_account_id", sess.RealAccountID,
+				"account_id", sess.AccountID,
+			)
+			if strings.HasPrefix(r.URL.Path, "/api/") {
+				apierror.Forbidden(w, "impersonation has ended; you are signed in as yourself again")
+				return
+			}
+			http.Redirect(w, r, "/admin/accounts", http.StatusSeeOther)
+			return
+		}
+		if r.Method != "GET" && r.Method != "HEAD" && r.URL.Path != "/api/devmode/restore" {
+			orchestrators.ExecuteRecordImpersonatedRequest(r.Context(), orchestrators.ImpersonatedRequestInput{
+				AccountID: sess.AccountID,
+				Email:     sess.Email,
+				Method:    r.Method,
+				Path:      r.URL.Path,
+				Actor:     actor,
+			}, stores.AuditStore)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// impersonationActor identifies the admin behind an impersonation for the audit log.
+func impersonationActor(r *http.Request, accountID, email, role string) orchestrators.BackfillActor {
+	return orchestrators.BackfillActor{
+		AccountID: accountID,
+		Email:     email,
+		Role:      role,
+		IPAddress: middleware.ClientIP(r),
+		UserAgent: r.UserAgent(),
+	}
+}
@@ -0,0 +1,97 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	accountDomain "workshop/internal/domain/account"
+	auditDomain "workshop/internal/domain/audit"
+	authsessionDomain "workshop/internal/domain/authsession"
+)
+
+// TestImpersonateAccount verifies an admin can sign in as a member's account, that changes
+// made meanwhile are audited, and that the admin is restored once the time limit passes.
+func TestImpersonateAccount(t *testing.T) {
+	_, adminToken, _ := seedSessions(t)
+	audits := &mockAuditStore{}
+	stores.AuditStore = audits
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+	ctx := context.Background()
+	stores.AccountStore.Save(ctx, accountDomain.Account{ID: memberSession.AccountID, Email: memberSession.Email, Role: accountDomain.RoleMember, Status: accountDomain.StatusActive})
+	stores.AccountStore.Save(ctx, accountDomain.Account{ID: "admin-2", Email: "other@example.com", Role: accountDomain.RoleAdmin, Status: accountDomain.StatusActive})
+
+	withCookie := func(req *http.Request) *http.Request {
+		req.AddCookie(&http.Cookie{Name: "workshop_session", Value: adminToken})
+		return req
+	}
+	current := func() middleware.Session {
+		t.Helper()
+		sess, ok := sessions.Get(ctx, adminToken)
+		if !ok {
+			t.Fatal("admin session missing")
+		}
+		return sess
+	}
+
+	rec := httptest.NewRecorder()
+	handleImpersonateAccount(rec, withCookie(authRequest("POST", "/api/admin/accounts/impersonate", `{"AccountID":"admin-2"}`, current())))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("impersonating an admin: expected 403, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleImpersonateAccount(rec, withCookie(authRequest("POST", "/api/admin/accounts/impersonate", `{"AccountID":"`+memberSession.AccountID+`"}`, memberSession)))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member impersonating: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleImpersonateAccount(rec, withCookie(authRequest("POST", "/api/admin/accounts/impersonate", `{"AccountID":"`+memberSession.AccountID+`"}`, current())))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("impersonate: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	sess := current()
+	if sess.AccountID != memberSession.AccountID || sess.Role != "member" || sess.RealAccountID != adminSession.AccountID || !sess.IsImpersonatingAccount() {
+		t.Fatalf("session = %+v, want the member's account with the admin behind it", sess)
+	}
+	if !sess.ImpersonationExpiresAt.Equal(now.Add(authsessionDomain.ImpersonationLimit)) {
+		t.Errorf("ImpersonationExpiresAt = %v", sess.ImpersonationExpiresAt)
+	}
+
+	rec = httptest.NewRecorder()
+	handleDevModeImpersonate(rec, withCookie(authRequest("POST", "/api/devmode/impersonate", "role=coach", sess)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("role switch while impersonating an account: expected 400, got %d", rec.Code)
+	}
+
+	reached := false
+	guarded := guardImpersonation(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { reached = true }))
+	rec = httptest.NewRecorder()
+	guarded.ServeHTTP(rec, withCookie(authRequest("POST", "/api/goals", `{}`, sess)))
+	if !reached {
+		t.Fatal("request under a live impersonation did not reach the handler")
+	}
+	last := audits.events[len(audits.events)-1]
+	if last.Action != auditDomain.ActionImpersonate || last.ActorID != adminSession.AccountID || last.Description != "POST /api/goals as "+memberSession.Email {
+		t.Errorf("change audit = %+v", last)
+	}
+
+	now = now.Add(authsessionDomain.ImpersonationLimit)
+	reached = false
+	rec = httptest.NewRecorder()
+	guarded.ServeHTTP(rec, withCookie(authRequest("GET", "/dashboard", "", sess)))
+	if reached || rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/admin/accounts" {
+		t.Errorf("expired impersonation: reached=%v code=%d location=%q; want a redirect to the accounts page", reached, rec.Code, rec.Header().Get("Location"))
+	}
+	if restored := current(); restored.AccountID != adminSession.AccountID || restored.Role != "admin" || restored.IsImpersonating() {
+		t.Errorf("after expiry session = %+v, want the admin restored", restored)
+	}
+	if got := audits.events[len(audits.events)-1].Description; got != "Impersonation of "+memberSession.Email+" reached its time limit" {
+		t.Errorf("expiry audit = %q", got)
+	}
+}
@@ -0,0 +1,101 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	injuryDomain "workshop/internal/domain/injury"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// injuryUpdateRequest is the body of PUT /api/injuries; nil fields are left unchanged.
+type injuryUpdateRequest struct {
+	ID                string  `json:"ID"`
+	Status            string  `json:"Status"`
+	Severity          *string `json:"Severity"`
+	ResolutionNotes   *string `json:"ResolutionNotes"`
+	GradingRestricted *bool   `json:"GradingRestricted"`
+}
+
+// handleInjuries handles GET/PUT for /api/injuries
+// GET lists a member's injuries (?member_id=); PUT moves an injury through its lifecycle.
+// Coaches and admins only.
+func handleInjuries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "member_mgmt") {
+		return
+	}
+	if !permissionAllowed(ctx, sess, permissionDomain.ActionInjuriesView) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		memberID := r.URL.Query().Get("member_id")
+		if memberID == "" {
+			apierror.Validation(w, "member_id is required")
+			return
+		}
+		injuries, err := stores.InjuryStore.ListByMemberID(ctx, memberID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if injuries == nil {
+			injuries = []injuryDomain.Injury{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(injuries)
+
+	case "PUT":
+		var input injuryUpdateRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		if input.ID == "" {
+			apierror.Validation(w, "ID is required")
+			return
+		}
+		if _, err := stores.InjuryStore.GetByID(ctx, input.ID); err != nil {
+			apierror.NotFound(w, "injury not found")
+			return
+		}
+
+		inj, err := orchestrators.ExecuteUpdateInjury(ctx, orchestrators.UpdateInjuryInput{
+			InjuryID:          input.ID,
+			Status:            input.Status,
+			Severity:          input.Severity,
+			ResolutionNotes:   input.ResolutionNotes,
+			GradingRestricted: input.GradingRestricted,
+			UpdatedBy:         sess.AccountID,
+		}, orchestrators.UpdateInjuryDeps{
+			InjuryStore: stores.InjuryStore,
+			Now:         timeNow,
+		})
+		if errors.Is(err, injuryDomain.ErrInvalidStatus) || errors.Is(err, injuryDomain.ErrInvalidSeverity) ||
+			errors.Is(err, injuryDomain.ErrResolutionNotesTooLong) {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(inj)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
@@ -0,0 +1,101 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	injuryDomain "workshop/internal/domain/injury"
+	memberDomain "workshop/internal/domain/member"
+)
+
+func newInjuryTestStores() *Stores {
+	s := newFullStores()
+	s.MemberStore.Save(context.Background(), memberDomain.Member{ID: "member-001", Name: "Ana", Program: memberDomain.ProgramAdults, Status: "active"})
+	s.InjuryStore.Save(context.Background(), injuryDomain.Injury{
+		ID: "inj-1", MemberID: "member-001", BodyPart: "knee",
+		Status: injuryDomain.StatusActive, ReportedAt: time.Now(),
+	})
+	return s
+}
+
+// TestHandleInjuries_MemberForbidden verifies members cannot manage injuries.
+func TestHandleInjuries_MemberForbidden(t *testing.T) {
+	stores = newInjuryTestStores()
+
+	rec := httptest.NewRecorder()
+	handleInjuries(rec, authRequest("GET", "/api/injuries?member_id=member-001", "", memberSession))
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleInjuries_CoachUpdatesLifecycle verifies a coach can resolve an injury with notes.
+func TestHandleInjuries_CoachUpdatesLifecycle(t *testing.T) {
+	stores = newInjuryTestStores()
+
+	body := `{"ID":"inj-1","Status":"resolved","Severity":"high","ResolutionNotes":"Cleared by physio"}`
+	rec := httptest.NewRecorder()
+	handleInjuries(rec, authRequest("PUT", "/api/injuries", body, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	got, _ := stores.InjuryStore.GetByID(context.Background(), "inj-1")
+	if got.Status != injuryDomain.StatusResolved || got.ResolvedAt.IsZero() {
+		t.Errorf("expected resolved injury with ResolvedAt, got %+v", got)
+	}
+	if got.ResolutionNotes != "Cleared by physio" || got.Severity != injuryDomain.SeverityHigh {
+		t.Errorf("expected notes and severity to be saved, got %+v", got)
+	}
+
+	rec = httptest.NewRecorder()
+	handleInjuries(rec, authRequest("PUT", "/api/injuries", `{"ID":"inj-1","Status":"healed"}`, coachSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid status, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleInjuries(rec, authRequest("GET", "/api/injuries?member_id=member-001", "", coachSession))
+	var list []injuryDomain.Injury
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(list) != 1 {
+		t.Errorf("expected 1 injury, got %d", len(list))
+	}
+}
+
+// TestHandleGradingProposals_BlockedByRestrictedInjury verifies grading-restricting injuries block proposals.
+func TestHandleGradingProposals_BlockedByRestrictedInjury(t *testing.T) {
+	stores = newInjuryTestStores()
+
+	rec := httptest.NewRecorder()
+	handleInjuries(rec, authRequest("PUT", "/api/injuries", `{"ID":"inj-1","GradingRestricted":true}`, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	body := `{"MemberID":"member-001","TargetBelt":"blue","Notes":"Ready"}`
+	rec = httptest.NewRecorder()
+	handleGradingProposals(rec, authRequest("POST", "/api/grading/proposals", body, coachSession))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleInjuries(rec, authRequest("PUT", "/api/injuries", `{"ID":"inj-1","Status":"resolved"}`, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleGradingProposals(rec, authRequest("POST", "/api/grading/proposals", body, coachSession))
+	if rec.Code != http.StatusCreated {
+		t.Errorf("expected 201 once resolved, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,191 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/events"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/projections"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// kioskBoardRefresh is how often the board stream rebuilds the board. Check-ins made
+// through the app rebuild it at once; this catches everything else.
+var kioskBoardRefresh = 5 * time.Second
+
+// kioskBoardKeepAlive is how many unchanged refreshes pass before the stream sends a
+// comment, so proxies do not close an idle connection.
+const kioskBoardKeepAlive = 6
+
+// kioskBoardView is the board as sent to the display, with the server's clock so the
+// countdown is right even if the TV's clock is not.
+type kioskBoardView struct {
+	Now time.Time
+	projections.KioskBoardResult
+}
+
+// kioskBoard builds the display board for the session's location.
+func kioskBoard(ctx context.Context) (kioskBoardView, error) {
+	now := timeNow()
+	board, err := projections.QueryGetKioskBoard(ctx, projections.GetKioskBoardQuery{LocationID: sessionLocationID(ctx)}, projections.GetKioskBoardDeps{
+		TodaysClassesDeps: projections.GetTodaysClassesDeps{
+			ScheduleStore:  stores.ScheduleStore,
+			TermStore:      stores.TermStore,
+			HolidayStore:   stores.HolidayStore,
+			ClassTypeStore: stores.ClassTypeStore,
+			ProgramStore:   stores.ProgramStore,
+			ChangeStore:    stores.OccurrenceChangeStore,
+		},
+		AttendanceStore: stores.AttendanceStore,
+		MemberStore:     stores.MemberStore,
+		RotorStore:      stores.RotorStore,
+		NoticeStore:     stores.NoticeStore,
+	}, now)
+	return kioskBoardView{Now: now, KioskBoardResult: board}, err
+}
+
+// requireKioskBoard checks the session may run the board: the kiosk feature and the
+// kiosk permission, as for the check-in kiosk itself.
+func requireKioskBoard(w http.ResponseWriter, r *http.Request, page bool) bool {
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		if page {
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+		} else {
+			apierror.Unauthorized(w, "not authenticated")
+		}
+		return false
+	}
+	if page {
+		if !requireFeaturePage(w, r, sess, "kiosk") {
+			return false
+		}
+	} else if !requireFeatureAPI(w, r, sess, "kiosk") {
+		return false
+	}
+	if !permissionAllowed(r.Context(), sess, permissionDomain.ActionAttendanceKiosk) {
+		if page {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		} else {
+			apierror.Forbidden(w, "Forbidden")
+		}
+		return false
+	}
+	return true
+}
+
+// handleKioskBoardPage handles GET /kiosk/board
+// A read-only display for a TV on the gym wall: the class members are arriving for with
+// a countdown to its start, who has checked in, today's rotor topics and notices.
+func handleKioskBoardPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !requireKioskBoard(w, r, true) {
+		return
+	}
+	// The board is a standalone template (no layout.html), like the kiosk
+	tpl, err := templates.forRequest("kiosk_board.html", false, r)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	tpl.Execute(w, nil)
+}
+
+// handleKioskBoard handles GET /api/kiosk/board
+// The board's current contents, for displays that cannot use the event stream.
+func handleKioskBoard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	if !requireKioskBoard(w, r, false) {
+		return
+	}
+	board, err := kioskBoard(r.Context())
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(board)
+}
+
+// handleKioskBoardEvents handles GET /kiosk/board/events
+// Server-sent events for the board: a "board" event with the full board on connect and
+// whenever it changes. Live attendance events wake the stream early, so a check-in shows
+// on the board straight away. The connection stays open until the display goes away.
+func handleKioskBoardEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	if !requireKioskBoard(w, r, false) {
+		return
+	}
+	ctx := r.Context()
+	sess, _ := middleware.GetSessionFromContext(ctx)
+	sub := eventHub.Subscribe(events.Filter{AccountID: sess.AccountID, Role: sess.Role, Types: []string{eventAttendanceCheckIn, eventAttendanceUndo}}, 0)
+	defer sub.Cancel()
+	wake := sub.C
+
+	rc := http.NewResponseController(w)
+	// The server's write timeout would cut the stream off; the board runs all day.
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	var last []byte
+	idle := 0
+	ticker := time.NewTicker(kioskBoardRefresh)
+	defer ticker.Stop()
+	for {
+		board, err := kioskBoard(ctx)
+		if err != nil && ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "kiosk_board_error", "error", err.Error())
+		}
+		// Now is left out of the comparison so an unchanged board is not resent every tick.
+		data, _ := json.Marshal(board.KioskBoardResult)
+		if err == nil && !bytes.Equal(data, last) {
+			full, _ := json.Marshal(board)
+			if _, err := fmt.Fprintf(w, "event: board\ndata: %s\n\n", full); err != nil {
+				return
+			}
+			last, idle = data, 0
+		} else if idle++; idle >= kioskBoardKeepAlive {
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			idle = 0
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case _, ok := <-wake:
+			if !ok {
+				// Dropped for falling behind: keep refreshing on the ticker alone.
+				wake = nil
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	attendanceDomain "workshop/internal/domain/attendance"
+	classTypeDomain "workshop/internal/domain/classtype"
+	memberDomain "workshop/internal/domain/member"
+	programDomain "workshop/internal/domain/program"
+	scheduleDomain "workshop/internal/domain/schedule"
+	termDomain "workshop/internal/domain/term"
+)
+
+// seedKioskBoard seeds an 18:00 class on Monday 2 March 2026 with one check-in,
+// and sets the clock to 17:30 that day.
+func seedKioskBoard(t *testing.T) {
+	t.Helper()
+	stores = newFullStores()
+	ctx := context.Background()
+	now := time.Date(2026, 3, 2, 17, 30, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	stores.TermStore.Save(ctx, termDomain.Term{ID: "t1", Name: "Term 1", StartDate: now.AddDate(0, -1, 0), EndDate: now.AddDate(0, 1, 0)})
+	stores.ProgramStore.Save(ctx, programDomain.Program{ID: "p1", Name: "Adults", Type: "adults"})
+	stores.ClassTypeStore.Save(ctx, classTypeDomain.ClassType{ID: "ct1", ProgramID: "p1", Name: "Fundamentals"})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s1", ClassTypeID: "ct1", Day: scheduleDomain.Monday, StartTime: "18:00", EndTime: "19:00"})
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", Name: "Marcus Buffett", Email: "marcus@test.com", Program: "adults", Status: "active"})
+	stores.AttendanceStore.Save(ctx, attendanceDomain.Attendance{ID: "a1", MemberID: "m1", ScheduleID: "s1", ClassDate: "2026-03-02", CheckInTime: now})
+}
+
+// TestHandleKioskBoard verifies the board snapshot shows the class members are arriving for.
+func TestHandleKioskBoard(t *testing.T) {
+	seedKioskBoard(t)
+
+	rec := httptest.NewRecorder()
+	handleKioskBoard(rec, authRequest("GET", "/api/kiosk/board", "", coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var board kioskBoardView
+	json.NewDecoder(rec.Body).Decode(&board)
+	if board.Current == nil || board.Current.ClassName != "Fundamentals" || !board.Open {
+		t.Fatalf("expected Fundamentals open for check-in, got %+v", board)
+	}
+	if len(board.CheckedIn) != 1 || board.CheckedIn[0] != "Marcus B." {
+		t.Errorf("CheckedIn = %v, want [Marcus B.]", board.CheckedIn)
+	}
+	if got := board.StartsAt.Sub(board.Now); got != 30*time.Minute {
+		t.Errorf("expected the class to start in 30 minutes, got %v", got)
+	}
+
+	rec = httptest.NewRecorder()
+	handleKioskBoard(rec, authRequest("GET", "/api/kiosk/board", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member: expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleKioskBoardEvents verifies the stream sends the board as soon as it connects.
+func TestHandleKioskBoardEvents(t *testing.T) {
+	seedKioskBoard(t)
+
+	// A cancelled request stops the stream after its first event.
+	req := authRequest("GET", "/kiosk/board/events", "", coachSession)
+	ctx, cancel := context.WithCancel(req.Context())
+	cancel()
+	rec := httptest.NewRecorder()
+	handleKioskBoardEvents(rec, req.WithContext(ctx))
+
+	if rec.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %q", rec.Header().Get("Content-Type"))
+	}
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, "event: board\ndata: ") || !strings.Contains(body, `"Marcus B."`) || !strings.HasSuffix(body, "\n\n") {
+		t.Errorf("unexpected stream %q", body)
+	}
+
+	rec = httptest.NewRecorder()
+	handleKioskBoardEvents(rec, authRequest("GET", "/kiosk/board/events", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member: expected 403, got %d", rec.Code)
+	}
+}
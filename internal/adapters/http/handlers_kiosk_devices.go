@@ -0,0 +1,204 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+	kioskDomain "workshop/internal/domain/kiosk"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// kioskDeviceView is the JSON shape of one registered kiosk device on the admin accounts page.
+type kioskDeviceView struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	LocationID     string    `json:"location_id,omitempty"`
+	RegisteredBy   string    `json:"registered_by"`
+	RegisteredAt   time.Time `json:"registered_at"`
+	LastSeenAt     time.Time `json:"last_seen_at"`
+	InKiosk        bool      `json:"in_kiosk"`
+	KioskStartedAt time.Time `json:"kiosk_started_at,omitempty"`
+	PIN            string    `json:"pin"`            // current exit PIN
+	PINExpiresAt   time.Time `json:"pin_expires_at"` // when the next PIN is shown; this one still works for one more period
+}
+
+// kioskDeviceRequest is the body of PUT /api/admin/kiosk-devices and POST /api/admin/kiosk-devices/end.
+type kioskDeviceRequest struct {
+	ID   string `json:"ID"`
+	Name string `json:"Name"` // PUT only
+}
+
+// kioskHeartbeatRequest is the body of POST /api/kiosk/heartbeat.
+type kioskHeartbeatRequest struct {
+	DeviceID string `json:"DeviceID"`
+}
+
+// handleKioskHeartbeat handles POST /api/kiosk/heartbeat
+// Records that a registered kiosk device is still on. Once an admin ends the device's kiosk
+// session, its sign-in session is gone and this returns 401, which sends the tablet to login.
+func handleKioskHeartbeat(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionAttendanceKiosk)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "kiosk") {
+		return
+	}
+	var input kioskHeartbeatRequest
+	if err := strictDecode(r, &input); err != nil || input.DeviceID == "" {
+		apierror.Validation(w, "DeviceID is required")
+		return
+	}
+	ctx := r.Context()
+	if _, err := stores.KioskDeviceStore.GetDevice(ctx, input.DeviceID); err != nil {
+		apierror.NotFound(w, "kiosk device not found")
+		return
+	}
+	if err := stores.KioskDeviceStore.TouchDevice(ctx, input.DeviceID, timeNow()); err != nil {
+		internalError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleAdminKioskDevices handles GET/PUT/DELETE /api/admin/kiosk-devices
+// GET lists registered devices with their current exit PIN; PUT renames one; DELETE ?id=
+// removes one, signing out its kiosk session. Admin only.
+func handleAdminKioskDevices(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "kiosk") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		devices, err := stores.KioskDeviceStore.ListDevices(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		now := timeNow()
+		views := make([]kioskDeviceView, 0, len(devices))
+		for _, d := range devices {
+			views = append(views, kioskDeviceView{
+				ID:             d.ID,
+				Name:           d.Name,
+				LocationID:     d.LocationID,
+				RegisteredBy:   d.RegisteredBy,
+				RegisteredAt:   d.RegisteredAt,
+				LastSeenAt:     d.LastSeenAt,
+				InKiosk:        d.InKiosk(),
+				KioskStartedAt: d.KioskStartedAt,
+				PIN:            d.PIN(now),
+				PINExpiresAt:   kioskDomain.PINExpiresAt(now),
+			})
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(views)
+
+	case "PUT":
+		var input kioskDeviceRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		device, err := stores.KioskDeviceStore.GetDevice(ctx, input.ID)
+		if err != nil {
+			apierror.NotFound(w, "kiosk device not found")
+			return
+		}
+		device.Name = strings.TrimSpace(input.Name)
+		if err := device.Validate(); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err := stores.KioskDeviceStore.SaveDevice(ctx, device); err != nil {
+			internalError(w, err)
+			return
+		}
+		slog.InfoContext(ctx, "kiosk_event", "event", "kiosk_device_renamed", "device_id", device.ID, "account_id", sess.AccountID)
+		w.WriteHeader(http.StatusNoContent)
+
+	case "DELETE":
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			apierror.Validation(w, "id is required")
+			return
+		}
+		device, err := stores.KioskDeviceStore.GetDevice(ctx, id)
+		if err != nil {
+			apierror.NotFound(w, "kiosk device not found")
+			return
+		}
+		if device.InKiosk() {
+			if err := sessions.Revoke(ctx, device.AuthSessionID); err != nil {
+				slog.WarnContext(ctx, "kiosk_event", "event", "kiosk_session_revoke_failed", "device_id", device.ID, "error", err)
+			}
+		}
+		if err := stores.KioskDeviceStore.DeleteDevice(ctx, id); err != nil {
+			internalError(w, err)
+			return
+		}
+		slog.InfoContext(ctx, "kiosk_event", "event", "kiosk_device_removed", "device_id", id, "account_id", sess.AccountID)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleAdminKioskDeviceEnd handles POST /api/admin/kiosk-devices/end
+// Ends a device's kiosk session remotely by signing out the session it runs under. Admin only.
+func handleAdminKioskDeviceEnd(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "kiosk") {
+		return
+	}
+	var input kioskDeviceRequest
+	if err := strictDecode(r, &input); err != nil || input.ID == "" {
+		apierror.Validation(w, "ID is required")
+		return
+	}
+	ctx := r.Context()
+	if _, err := stores.KioskDeviceStore.GetDevice(ctx, input.ID); err != nil {
+		apierror.NotFound(w, "kiosk device not found")
+		return
+	}
+	err := orchestrators.ExecuteEndKioskSession(ctx, orchestrators.EndKioskSessionInput{
+		DeviceID:  input.ID,
+		AccountID: sess.AccountID,
+	}, orchestrators.EndKioskSessionDeps{
+		DeviceStore:   stores.KioskDeviceStore,
+		RevokeSession: sessions.Revoke,
+	})
+	switch {
+	case errors.Is(err, kioskDomain.ErrNotActive):
+		apierror.Validation(w, "device is not in kiosk mode")
+	case err != nil:
+		internalError(w, err)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,175 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	accountDomain "workshop/internal/domain/account"
+	kioskDomain "workshop/internal/domain/kiosk"
+)
+
+// --- Mock kiosk device store ---
+
+type mockKioskDeviceStore struct {
+	devices map[string]kioskDomain.Device
+}
+
+// GetDevice implements kiosk.Store for testing.
+// PRE: id is non-empty
+// POST: Returns the device or an error if unknown
+func (m *mockKioskDeviceStore) GetDevice(_ context.Context, id string) (kioskDomain.Device, error) {
+	d, ok := m.devices[id]
+	if !ok {
+		return kioskDomain.Device{}, errors.New("kiosk device not found")
+	}
+	return d, nil
+}
+
+// SaveDevice implements kiosk.Store for testing.
+// PRE: value has been validated
+// POST: Device is upserted
+func (m *mockKioskDeviceStore) SaveDevice(_ context.Context, value kioskDomain.Device) error {
+	m.devices[value.ID] = value
+	return nil
+}
+
+// DeleteDevice implements kiosk.Store for testing.
+// PRE: id is non-empty
+// POST: Device is removed
+func (m *mockKioskDeviceStore) DeleteDevice(_ context.Context, id string) error {
+	delete(m.devices, id)
+	return nil
+}
+
+// ListDevices implements kiosk.Store for testing.
+// PRE: none
+// POST: Returns every device
+func (m *mockKioskDeviceStore) ListDevices(_ context.Context) ([]kioskDomain.Device, error) {
+	var list []kioskDomain.Device
+	for _, d := range m.devices {
+		list = append(list, d)
+	}
+	return list, nil
+}
+
+// TouchDevice implements kiosk.Store for testing.
+// PRE: id is non-empty
+// POST: LastSeenAt is seenAt
+func (m *mockKioskDeviceStore) TouchDevice(_ context.Context, id string, seenAt time.Time) error {
+	if d, ok := m.devices[id]; ok {
+		d.LastSeenAt = seenAt
+		m.devices[id] = d
+	}
+	return nil
+}
+
+// launchKioskDevice signs a coach in, launches kiosk mode registering a device, and returns
+// the coach's token and the device's ID.
+func launchKioskDevice(t *testing.T) (coachToken, deviceID string) {
+	t.Helper()
+	stores = newFullStores()
+	stores.KioskDeviceStore = &mockKioskDeviceStore{devices: map[string]kioskDomain.Device{}}
+	stores.AccountStore.Save(context.Background(), accountDomain.Account{ID: coachSession.AccountID, Email: coachSession.Email, Role: accountDomain.RoleCoach})
+	sessions = middleware.NewSessionStore(newMockAuthSessionStore())
+	coachToken, err := sessions.Create(context.Background(), coachSession.AccountID, coachSession.Email, "coach", false, false)
+	if err != nil {
+		t.Fatalf("create coach session: %v", err)
+	}
+	coach, _ := sessions.Get(context.Background(), coachToken)
+
+	rec := httptest.NewRecorder()
+	handleKioskLaunch(rec, authRequest("POST", "/api/kiosk/launch", `{"DeviceName":"Front desk"}`, coach))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("launch: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var session kioskDomain.Session
+	json.NewDecoder(rec.Body).Decode(&session)
+	if session.DeviceID == "" {
+		t.Fatal("launch did not register the device")
+	}
+	return coachToken, session.DeviceID
+}
+
+// TestHandleKioskDevices_PINExitAndRemoteEnd verifies the admin sees a registered device's PIN,
+// the kiosk exits with it, and ending a device's kiosk session signs the tablet out.
+func TestHandleKioskDevices_PINExitAndRemoteEnd(t *testing.T) {
+	coachToken, deviceID := launchKioskDevice(t)
+	coach, _ := sessions.Get(context.Background(), coachToken)
+
+	rec := httptest.NewRecorder()
+	handleAdminKioskDevices(rec, authRequest("GET", "/api/admin/kiosk-devices", "", adminSession))
+	var views []kioskDeviceView
+	json.NewDecoder(rec.Body).Decode(&views)
+	if rec.Code != http.StatusOK || len(views) != 1 || views[0].Name != "Front desk" || !views[0].InKiosk || len(views[0].PIN) != kioskDomain.PINDigits {
+		t.Fatalf("list: got %d %+v", rec.Code, views)
+	}
+
+	rec = httptest.NewRecorder()
+	handleKioskExit(rec, authRequest("POST", "/api/kiosk/exit", fmt.Sprintf(`{"DeviceID":%q,"PIN":%q}`, deviceID, views[0].PIN), coach))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("PIN exit: expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleKioskLaunch(rec, authRequest("POST", "/api/kiosk/launch", fmt.Sprintf(`{"DeviceID":%q}`, deviceID), coach))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("relaunch: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	handleAdminKioskDeviceEnd(rec, authRequest("POST", "/api/admin/kiosk-devices/end", fmt.Sprintf(`{"ID":%q}`, deviceID), adminSession))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("end: expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if _, ok := sessions.Get(context.Background(), coachToken); ok {
+		t.Error("kiosk session still signed in after remote end")
+	}
+
+	rec = httptest.NewRecorder()
+	handleAdminKioskDeviceEnd(rec, authRequest("POST", "/api/admin/kiosk-devices/end", fmt.Sprintf(`{"ID":%q}`, deviceID), adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("end again: expected 400, got %d", rec.Code)
+	}
+}
+
+// TestHandleKioskDevices_RenameRemoveAndHeartbeat verifies device naming, last-seen tracking and removal.
+func TestHandleKioskDevices_RenameRemoveAndHeartbeat(t *testing.T) {
+	_, deviceID := launchKioskDevice(t)
+	seen := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return seen }
+	defer func() { timeNow = time.Now }()
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		req     *http.Request
+		want    int
+	}{
+		{"rename", handleAdminKioskDevices, authRequest("PUT", "/api/admin/kiosk-devices", fmt.Sprintf(`{"ID":%q,"Name":"Mat 2 tablet"}`, deviceID), adminSession), http.StatusNoContent},
+		{"rename blank", handleAdminKioskDevices, authRequest("PUT", "/api/admin/kiosk-devices", fmt.Sprintf(`{"ID":%q,"Name":" "}`, deviceID), adminSession), http.StatusBadRequest},
+		{"rename as coach", handleAdminKioskDevices, authRequest("PUT", "/api/admin/kiosk-devices", fmt.Sprintf(`{"ID":%q,"Name":"x"}`, deviceID), coachSession), http.StatusForbidden},
+		{"heartbeat", handleKioskHeartbeat, authRequest("POST", "/api/kiosk/heartbeat", fmt.Sprintf(`{"DeviceID":%q}`, deviceID), coachSession), http.StatusNoContent},
+		{"heartbeat as member", handleKioskHeartbeat, authRequest("POST", "/api/kiosk/heartbeat", fmt.Sprintf(`{"DeviceID":%q}`, deviceID), memberSession), http.StatusForbidden},
+		{"remove", handleAdminKioskDevices, authRequest("DELETE", "/api/admin/kiosk-devices?id="+deviceID, "", adminSession), http.StatusNoContent},
+		{"heartbeat after removal", handleKioskHeartbeat, authRequest("POST", "/api/kiosk/heartbeat", fmt.Sprintf(`{"DeviceID":%q}`, deviceID), coachSession), http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		tt.handler(rec, tt.req)
+		if rec.Code != tt.want {
+			t.Fatalf("%s: expected %d, got %d: %s", tt.name, tt.want, rec.Code, rec.Body.String())
+		}
+		if tt.name == "heartbeat" {
+			d, _ := stores.KioskDeviceStore.GetDevice(context.Background(), deviceID)
+			if d.Name != "Mat 2 tablet" || !d.LastSeenAt.Equal(seen) {
+				t.Errorf("device = %+v, want renamed and seen at %v", d, seen)
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// bulkSyncRequest is the body of POST /api/attendance/bulk-sync.
+type bulkSyncRequest struct {
+	Records []orchestrators.BulkSyncRecord `json:"Records"`
+}
+
+// handleAttendanceBulkSync handles POST /api/attendance/bulk-sync
+// Replays check-ins queued by a kiosk while it was offline and reports per-record outcomes.
+func handleAttendanceBulkSync(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "kiosk") {
+		return
+	}
+	if !permissionAllowed(ctx, sess, permissionDomain.ActionAttendanceKiosk) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+
+	var input bulkSyncRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+
+	deps := orchestrators.BulkSyncDeps{
+		MemberStore:     stores.MemberStore,
+		AttendanceStore: stores.AttendanceStore,
+		ScheduleStore:   stores.ScheduleStore,
+		TopicDeps:       attendanceTopicDeps(),
+		PackDeps:        classPackDeps(),
+		GenerateID:      generateID,
+		Now:             timeNow,
+	}
+	result, err := orchestrators.ExecuteBulkSyncCheckIns(ctx, orchestrators.BulkSyncInput{
+		Records:    input.Records,
+		LocationID: sess.LocationID,
+	}, deps)
+	if errors.Is(err, orchestrators.ErrBulkSyncTooLarge) {
+		apierror.TooLarge(w, err.Error())
+		return
+	}
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	if result.Created > 0 {
+		publishAttendance(eventAttendanceCheckIn, attendanceEvent{Count: result.Created})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,59 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/application/orchestrators"
+	memberDomain "workshop/internal/domain/member"
+)
+
+// TestHandleAttendanceBulkSync_MemberForbidden verifies only coaches and admins can sync kiosk queues.
+func TestHandleAttendanceBulkSync_MemberForbidden(t *testing.T) {
+	stores = newFullStores()
+
+	rec := httptest.NewRecorder()
+	handleAttendanceBulkSync(rec, authRequest("POST", "/api/attendance/bulk-sync", `{"Records":[]}`, memberSession))
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleAttendanceBulkSync_ReportsPerRecordOutcome verifies created and duplicate results are returned.
+func TestHandleAttendanceBulkSync_ReportsPerRecordOutcome(t *testing.T) {
+	stores = newFullStores()
+	stores.MemberStore.Save(context.Background(), memberDomain.Member{
+		ID: "m1", Name: "Alice", Email: "alice@test.com", Program: "adults", Status: memberDomain.StatusActive,
+	})
+	checkIn := time.Now().Add(-30 * time.Minute).UTC().Format(time.RFC3339)
+	body := `{"Records":[
+		{"ClientID":"c1","MemberID":"m1","ScheduleID":"s1","CheckInTime":"` + checkIn + `"},
+		{"ClientID":"c2","MemberID":"m1","ScheduleID":"s1","CheckInTime":"` + checkIn + `"},
+		{"ClientID":"c3","MemberID":"ghost","CheckInTime":"` + checkIn + `"}
+	]}`
+
+	rec := httptest.NewRecorder()
+	handleAttendanceBulkSync(rec, authRequest("POST", "/api/attendance/bulk-sync", body, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var result orchestrators.BulkSyncResult
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := []string{orchestrators.BulkSyncStatusCreated, orchestrators.BulkSyncStatusDuplicate, orchestrators.BulkSyncStatusRejected}
+	if len(result.Results) != len(want) {
+		t.Fatalf("expected %d results, got %d", len(want), len(result.Results))
+	}
+	for i, status := range want {
+		if result.Results[i].Status != status {
+			t.Errorf("result[%d].Status = %q, want %q", i, result.Results[i].Status, status)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	classTypeDomain "workshop/internal/domain/classtype"
+	locationDomain "workshop/internal/domain/location"
+	permissionDomain "workshop/internal/domain/permission"
+	scheduleDomain "workshop/internal/domain/schedule"
+)
+
+// locationSaveRequest is the body of POST /api/locations; an empty ID creates a location.
+type locationSaveRequest struct {
+	ID      string `json:"ID"`
+	Name    string `json:"Name"`
+	Address string `json:"Address"`
+}
+
+// handleLocations handles GET/POST/DELETE for /api/locations
+// GET is available to coaches and admins (for the location picker); writes are admin-only.
+func handleLocations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "locations") {
+		return
+	}
+	if !permissionAllowed(ctx, sess, permissionDomain.ActionLocationsView) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		locations, err := stores.LocationStore.List(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if locations == nil {
+			locations = []locationDomain.Location{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(locations)
+
+	case "POST":
+		if _, ok := requireAdmin(w, r); !ok {
+			return
+		}
+		var input locationSaveRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		loc := locationDomain.Location{
+			ID:        input.ID,
+			Name:      strings.TrimSpace(input.Name),
+			Address:   strings.TrimSpace(input.Address),
+			CreatedAt: timeNow(),
+		}
+		status := http.StatusCreated
+		if loc.ID == "" {
+			loc.ID = generateID()
+		} else if existing, err := stores.LocationStore.GetByID(ctx, loc.ID); err == nil {
+			loc.CreatedAt = existing.CreatedAt
+			status = http.StatusOK
+		}
+		if err := loc.Validate(); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err := stores.LocationStore.Save(ctx, loc); err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(loc)
+
+	case "DELETE":
+		if _, ok := requireAdmin(w, r); !ok {
+			return
+		}
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			apierror.Validation(w, "id is required")
+			return
+		}
+		if err := stores.LocationStore.Delete(ctx, id); err != nil {
+			internalError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// locationAssignRequest is the body of POST /api/locations/assign.
+type locationAssignRequest struct {
+	AccountID  string `json:"AccountID"`
+	LocationID string `json:"LocationID"`
+}
+
+// handleLocationAssign handles POST /api/locations/assign
+// Scopes an account to a single location (location-scoped role). An empty LocationID removes the scope.
+func handleLocationAssign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "locations") {
+		return
+	}
+
+	var input locationAssignRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if input.AccountID == "" {
+		apierror.Validation(w, "AccountID is required")
+		return
+	}
+
+	ctx := r.Context()
+	if input.LocationID != "" {
+		if _, err := stores.LocationStore.GetByID(ctx, input.LocationID); err != nil {
+			apierror.NotFound(w, "location not found")
+			return
+		}
+	}
+	acct, err := stores.AccountStore.GetByID(ctx, input.AccountID)
+	if err != nil {
+		apierror.NotFound(w, "account not found")
+		return
+	}
+	acct.LocationID = input.LocationID
+	if err := stores.AccountStore.Save(ctx, acct); err != nil {
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"AccountID":  acct.ID,
+		"LocationID": acct.LocationID,
+	})
+}
+
+// sessionLocationRequest is the body of POST /api/session/location.
+type sessionLocationRequest struct {
+	LocationID string `json:"LocationID"`
+}
+
+// handleSessionLocation handles GET/POST for /api/session/location
+// GET returns the location selected in the current session; POST switches it.
+// Location-scoped accounts may only select their own location.
+func handleSessionLocation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "locations") {
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"LocationID": sess.LocationID})
+
+	case "POST":
+		var input sessionLocationRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		if input.LocationID != "" {
+			if _, err := stores.LocationStore.GetByID(ctx, input.LocationID); err != nil {
+				apierror.NotFound(w, "location not found")
+				return
+			}
+		}
+		acct, err := stores.AccountStore.GetByID(ctx, sess.AccountID)
+		if err != nil {
+			apierror.NotFound(w, "account not found")
+			return
+		}
+		if acct.LocationID != "" && input.LocationID != acct.LocationID {
+			apierror.Forbidden(w, locationDomain.ErrOutsideLocation.Error())
+			return
+		}
+
+		cookie, err := r.Cookie("workshop_session")
+		if err != nil {
+			apierror.Unauthorized(w, "not authenticated")
+			return
+		}
+		sess.LocationID = input.LocationID
+		sessions.Update(ctx, cookie.Value, sess)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"LocationID": sess.LocationID})
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// sessionLocationID returns the location selected in the current session, or "" for all locations.
+func sessionLocationID(ctx context.Context) string {
+	if sess, ok := middleware.GetSessionFromContext(ctx); ok {
+		return sess.LocationID
+	}
+	return ""
+}
+
+// requestLocationID returns the location_id query parameter, falling back to the session's location.
+func requestLocationID(r *http.Request) string {
+	if id := r.URL.Query().Get("location_id"); id != "" {
+		return id
+	}
+	return sessionLocationID(r.Context())
+}
+
+// filterSchedulesByLocation keeps schedules offered at the given location (shared schedules included).
+func filterSchedulesByLocation(schedules []scheduleDomain.Schedule, locationID string) []scheduleDomain.Schedule {
+	if locationID == "" {
+		return schedules
+	}
+	var out []scheduleDomain.Schedule
+	for _, s := range schedules {
+		if locationDomain.Matches(s.LocationID, locationID) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// filterClassTypesByLocation keeps class types offered at the given location (shared class types included).
+func filterClassTypesByLocation(types []classTypeDomain.ClassType, locationID string) []classTypeDomain.ClassType {
+	if locationID == "" {
+		return types
+	}
+	var out []classTypeDomain.ClassType
+	for _, ct := range types {
+		if locationDomain.Matches(ct.LocationID, locationID) {
+			out = append(out, ct)
+		}
+	}
+	return out
+}
@@ -0,0 +1,181 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/adapters/http/middleware"
+	accountDomain "workshop/internal/domain/account"
+	locationDomain "workshop/internal/domain/location"
+	scheduleDomain "workshop/internal/domain/schedule"
+)
+
+// --- Mock Location store ---
+
+type mockLocationStore struct {
+	locations map[string]locationDomain.Location
+}
+
+// GetByID implements location.Store for testing.
+// PRE: id is non-empty
+// POST: returns the location or an error if not found
+func (m *mockLocationStore) GetByID(_ context.Context, id string) (locationDomain.Location, error) {
+	if l, ok := m.locations[id]; ok {
+		return l, nil
+	}
+	return locationDomain.Location{}, fmt.Errorf("not found: %s", id)
+}
+
+// Save implements location.Store for testing.
+// PRE: location has a valid ID
+// POST: location is stored in memory
+func (m *mockLocationStore) Save(_ context.Context, l locationDomain.Location) error {
+	m.locations[l.ID] = l
+	return nil
+}
+
+// Delete implements location.Store for testing.
+// PRE: id is non-empty
+// POST: location is removed
+func (m *mockLocationStore) Delete(_ context.Context, id string) error {
+	delete(m.locations, id)
+	return nil
+}
+
+// List implements location.Store for testing.
+// PRE: none
+// POST: returns all stored locations
+func (m *mockLocationStore) List(_ context.Context) ([]locationDomain.Location, error) {
+	var out []locationDomain.Location
+	for _, l := range m.locations {
+		out = append(out, l)
+	}
+	return out, nil
+}
+
+func newLocationTestStores() *Stores {
+	s := newFullStores()
+	s.LocationStore = &mockLocationStore{locations: map[string]locationDomain.Location{
+		"central": {ID: "central", Name: "Central"},
+		"north":   {ID: "north", Name: "North Shore"},
+	}}
+	return s
+}
+
+// TestHandleLocations_MemberForbidden verifies members cannot list locations.
+func TestHandleLocations_MemberForbidden(t *testing.T) {
+	stores = newLocationTestStores()
+
+	rec := httptest.NewRecorder()
+	handleLocations(rec, authRequest("GET", "/api/locations", "", memberSession))
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleLocations_CoachCanListButNotCreate verifies coaches read locations while writes are admin-only.
+func TestHandleLocations_CoachCanListButNotCreate(t *testing.T) {
+	stores = newLocationTestStores()
+
+	rec := httptest.NewRecorder()
+	handleLocations(rec, authRequest("GET", "/api/locations", "", coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var locations []locationDomain.Location
+	if err := json.NewDecoder(rec.Body).Decode(&locations); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(locations) != 2 {
+		t.Errorf("expected 2 locations, got %d", len(locations))
+	}
+
+	rec = httptest.NewRecorder()
+	handleLocations(rec, authRequest("POST", "/api/locations", `{"Name":"South"}`, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for coach create, got %d", rec.Code)
+	}
+}
+
+// TestHandleLocations_AdminCreates verifies admins can create a location.
+func TestHandleLocations_AdminCreates(t *testing.T) {
+	stores = newLocationTestStores()
+
+	rec := httptest.NewRecorder()
+	handleLocations(rec, authRequest("POST", "/api/locations", `{"Name":"South","Address":"9 Beach Rd"}`, adminSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleLocations(rec, authRequest("POST", "/api/locations", `{"Name":""}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for empty name, got %d", rec.Code)
+	}
+}
+
+// TestHandleSessionLocation_ScopedAccountCannotSwitch verifies location-scoped accounts stay at their location.
+func TestHandleSessionLocation_ScopedAccountCannotSwitch(t *testing.T) {
+	stores = newLocationTestStores()
+	sessions = middleware.NewSessionStore(newMockAuthSessionStore())
+	stores.AccountStore.Save(context.Background(), accountDomain.Account{
+		ID: coachSession.AccountID, Email: coachSession.Email, Role: "coach", LocationID: "central",
+	})
+	token, err := sessions.Create(context.Background(), coachSession.AccountID, coachSession.Email, "coach", false, false)
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+
+	req := authRequest("POST", "/api/session/location", `{"LocationID":"north"}`, coachSession)
+	req.AddCookie(&http.Cookie{Name: "workshop_session", Value: token})
+	rec := httptest.NewRecorder()
+	handleSessionLocation(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403 switching to another location, got %d", rec.Code)
+	}
+
+	req = authRequest("POST", "/api/session/location", `{"LocationID":"central"}`, coachSession)
+	req.AddCookie(&http.Cookie{Name: "workshop_session", Value: token})
+	rec = httptest.NewRecorder()
+	handleSessionLocation(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 selecting own location, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if sess, _ := sessions.Get(context.Background(), token); sess.LocationID != "central" {
+		t.Errorf("expected session LocationID=central, got %q", sess.LocationID)
+	}
+}
+
+// TestHandleSchedules_FiltersBySessionLocation verifies schedules are scoped to the selected location.
+func TestHandleSchedules_FiltersBySessionLocation(t *testing.T) {
+	stores = newLocationTestStores()
+	ctx := context.Background()
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s1", ClassTypeID: "ct1", Day: "monday", StartTime: "06:00", EndTime: "07:00"})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s2", ClassTypeID: "ct1", Day: "monday", StartTime: "12:00", EndTime: "13:00", LocationID: "central"})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s3", ClassTypeID: "ct1", Day: "monday", StartTime: "18:00", EndTime: "19:00", LocationID: "north"})
+
+	sess := adminSession
+	sess.LocationID = "north"
+	rec := httptest.NewRecorder()
+	handleSchedules(rec, authRequest("GET", "/api/schedules", "", sess))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var got []scheduleDomain.Schedule
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected shared + north schedules, got %d", len(got))
+	}
+	for _, s := range got {
+		if s.LocationID == "central" {
+			t.Errorf("central schedule %s leaked into north view", s.ID)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"workshop/internal/adapters/http/apierror"
+	attendanceDomain "workshop/internal/domain/attendance"
+	permissionDomain "workshop/internal/domain/permission"
+	termDomain "workshop/internal/domain/term"
+)
+
+// makeupCreditRequest is the body of POST /api/grading/makeup-credits.
+type makeupCreditRequest struct {
+	MemberID  string `json:"MemberID"`
+	TermID    string `json:"TermID"`    // optional: defaults to the current term
+	ClassDate string `json:"ClassDate"` // optional: the missed class
+	Reason    string `json:"Reason"`
+}
+
+// handleMakeupCredits handles GET/POST/DELETE /api/grading/makeup-credits
+// GET ?member_id= lists a member's credits, newest first.
+// POST awards one credit, which counts as an attended session towards the term's kids readiness.
+// DELETE ?id= withdraws a credit. Coaches and admins with grading permission only.
+func handleMakeupCredits(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requirePermission(w, r, permissionDomain.ActionGradingManage)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		memberID := r.URL.Query().Get("member_id")
+		if memberID == "" {
+			apierror.Validation(w, "member_id is required")
+			return
+		}
+		credits, err := stores.MakeupCreditStore.ListByMemberID(ctx, memberID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if credits == nil {
+			credits = []attendanceDomain.MakeupCredit{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(credits)
+
+	case "POST":
+		var input makeupCreditRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		if input.MemberID != "" {
+			if _, err := stores.MemberStore.GetByID(ctx, input.MemberID); err != nil {
+				apierror.NotFound(w, "member not found")
+				return
+			}
+		}
+
+		terms, err := stores.TermStore.List(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		var target *termDomain.Term
+		for i, t := range terms {
+			if (input.TermID != "" && t.ID == input.TermID) || (input.TermID == "" && t.Contains(timeNow())) {
+				target = &terms[i]
+				break
+			}
+		}
+		if target == nil {
+			if input.TermID != "" {
+				apierror.NotFound(w, "term not found")
+			} else {
+				apierror.Validation(w, "no term is running today; choose a term")
+			}
+			return
+		}
+
+		credit := attendanceDomain.MakeupCredit{
+			ID:        generateID(),
+			MemberID:  input.MemberID,
+			TermID:    target.ID,
+			ClassDate: input.ClassDate,
+			Reason:    strings.TrimSpace(input.Reason),
+			AwardedBy: sess.AccountID,
+			AwardedAt: timeNow(),
+		}
+		if err := credit.Validate(); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err := stores.MakeupCreditStore.Save(ctx, credit); err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(credit)
+
+	case "DELETE":
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			apierror.Validation(w, "id is required")
+			return
+		}
+		if _, err := stores.MakeupCreditStore.GetByID(ctx, id); err != nil {
+			apierror.NotFound(w, "makeup credit not found")
+			return
+		}
+		if err := stores.MakeupCreditStore.Delete(ctx, id); err != nil {
+			internalError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
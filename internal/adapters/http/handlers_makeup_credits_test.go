@@ -0,0 +1,136 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	attendanceDomain "workshop/internal/domain/attendance"
+	memberDomain "workshop/internal/domain/member"
+	termDomain "workshop/internal/domain/term"
+)
+
+type mockMakeupCreditStore struct {
+	credits map[string]attendanceDomain.MakeupCredit
+}
+
+// Save implements attendance.MakeupCreditStore for testing.
+// PRE: value has been validated
+// POST: Credit is upserted
+func (m *mockMakeupCreditStore) Save(_ context.Context, value attendanceDomain.MakeupCredit) error {
+	m.credits[value.ID] = value
+	return nil
+}
+
+// GetByID implements attendance.MakeupCreditStore for testing.
+// PRE: id is non-empty
+// POST: Returns the credit or sql.ErrNoRows
+func (m *mockMakeupCreditStore) GetByID(_ context.Context, id string) (attendanceDomain.MakeupCredit, error) {
+	c, ok := m.credits[id]
+	if !ok {
+		return attendanceDomain.MakeupCredit{}, sql.ErrNoRows
+	}
+	return c, nil
+}
+
+// Delete implements attendance.MakeupCreditStore for testing.
+// PRE: id is non-empty
+// POST: Credit is removed
+func (m *mockMakeupCreditStore) Delete(_ context.Context, id string) error {
+	delete(m.credits, id)
+	return nil
+}
+
+// ListByTermID implements attendance.MakeupCreditStore for testing.
+// PRE: termID is non-empty
+// POST: Returns the term's credits
+func (m *mockMakeupCreditStore) ListByTermID(_ context.Context, termID string) ([]attendanceDomain.MakeupCredit, error) {
+	var list []attendanceDomain.MakeupCredit
+	for _, c := range m.credits {
+		if c.TermID == termID {
+			list = append(list, c)
+		}
+	}
+	return list, nil
+}
+
+// ListByMemberID implements attendance.MakeupCreditStore for testing.
+// PRE: memberID is non-empty
+// POST: Returns the member's credits
+func (m *mockMakeupCreditStore) ListByMemberID(_ context.Context, memberID string) ([]attendanceDomain.MakeupCredit, error) {
+	var list []attendanceDomain.MakeupCredit
+	for _, c := range m.credits {
+		if c.MemberID == memberID {
+			list = append(list, c)
+		}
+	}
+	return list, nil
+}
+
+// TestHandleMakeupCredits verifies a coach can award, list and withdraw makeup credits.
+func TestHandleMakeupCredits(t *testing.T) {
+	stores = newFullStores()
+	stores.MakeupCreditStore = &mockMakeupCreditStore{credits: map[string]attendanceDomain.MakeupCredit{}}
+	ctx := context.Background()
+	now := time.Now()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "kid1", Name: "Alice Kid", Email: "alice@test.com", Program: "kids", Status: memberDomain.StatusActive})
+	stores.TermStore.Save(ctx, termDomain.Term{ID: "current", Name: "This term", StartDate: now.AddDate(0, -1, 0), EndDate: now.AddDate(0, 1, 0)})
+
+	rec := httptest.NewRecorder()
+	handleMakeupCredits(rec, authRequest("POST", "/api/grading/makeup-credits", `{"MemberID":"kid1","Reason":"Private lesson"}`, coachSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("award: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var credit attendanceDomain.MakeupCredit
+	json.NewDecoder(rec.Body).Decode(&credit)
+	if credit.TermID != "current" || credit.AwardedBy != coachSession.AccountID {
+		t.Errorf("expected the current term awarded by the coach, got %+v", credit)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMakeupCredits(rec, authRequest("GET", "/api/grading/makeup-credits?member_id=kid1", "", coachSession))
+	var list []attendanceDomain.MakeupCredit
+	json.NewDecoder(rec.Body).Decode(&list)
+	if len(list) != 1 {
+		t.Fatalf("expected 1 credit, got %d", len(list))
+	}
+
+	rec = httptest.NewRecorder()
+	handleMakeupCredits(rec, authRequest("DELETE", "/api/grading/makeup-credits?id="+credit.ID, "", coachSession))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("withdraw: expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if left, _ := stores.MakeupCreditStore.ListByMemberID(ctx, "kid1"); len(left) != 0 {
+		t.Errorf("expected no credits after withdrawal, got %d", len(left))
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		sess   middleware.Session
+		want   int
+	}{
+		{"member forbidden", "POST", "/api/grading/makeup-credits", `{"MemberID":"kid1","Reason":"x"}`, memberSession, http.StatusForbidden},
+		{"no reason", "POST", "/api/grading/makeup-credits", `{"MemberID":"kid1","Reason":" "}`, coachSession, http.StatusBadRequest},
+		{"unknown member", "POST", "/api/grading/makeup-credits", `{"MemberID":"ghost","Reason":"x"}`, coachSession, http.StatusNotFound},
+		{"unknown term", "POST", "/api/grading/makeup-credits", `{"MemberID":"kid1","TermID":"old","Reason":"x"}`, coachSession, http.StatusNotFound},
+		{"list without member", "GET", "/api/grading/makeup-credits", "", coachSession, http.StatusBadRequest},
+		{"withdraw unknown", "DELETE", "/api/grading/makeup-credits?id=nope", "", coachSession, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleMakeupCredits(rec, authRequest(tt.method, tt.url, tt.body, tt.sess))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
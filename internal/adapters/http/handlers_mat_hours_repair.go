@@ -0,0 +1,80 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+)
+
+// matHoursRepairRequest is the body of POST /api/attendance/mat-hours/repair.
+type matHoursRepairRequest struct {
+	From   string `json:"From"` // optional YYYY-MM-DD; empty scans from the first check-in
+	To     string `json:"To"`   // optional YYYY-MM-DD; empty scans to today
+	DryRun bool   `json:"DryRun"`
+}
+
+// handleMatHoursRepair handles POST /api/attendance/mat-hours/repair
+// Recomputes mat hours from class durations, correcting negative, absurd, missing and stale
+// values. With DryRun the corrections are only listed. Admin only; every correction is
+// audited.
+func handleMatHoursRepair(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "attendance") {
+		return
+	}
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	var input matHoursRepairRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	for _, date := range []string{input.From, input.To} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			apierror.Validation(w, "dates must be YYYY-MM-DD")
+			return
+		}
+	}
+
+	result, err := orchestrators.ExecuteRepairMatHours(r.Context(), orchestrators.RepairMatHoursInput{
+		From:   input.From,
+		To:     input.To,
+		DryRun: input.DryRun,
+		Actor: orchestrators.BackfillActor{
+			AccountID: sess.AccountID,
+			Email:     sess.Email,
+			Role:      sess.Role,
+			IPAddress: middleware.ClientIP(r),
+			UserAgent: r.UserAgent(),
+		},
+	}, orchestrators.RepairMatHoursDeps{
+		AttendanceStore: stores.AttendanceStore,
+		ScheduleStore:   stores.ScheduleStore,
+		ChangeStore:     stores.OccurrenceChangeStore,
+		AuditStore:      stores.AuditStore,
+		Now:             timeNow,
+	})
+	switch {
+	case errors.Is(err, orchestrators.ErrRepairMatHoursRange):
+		apierror.Validation(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,50 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/application/orchestrators"
+	attendanceDomain "workshop/internal/domain/attendance"
+)
+
+// TestHandleMatHoursRepair verifies admins can preview and then apply a correction, and
+// coaches cannot.
+func TestHandleMatHoursRepair(t *testing.T) {
+	setupAnomalyStores(t)
+	ctx := context.Background()
+	a, _ := stores.AttendanceStore.GetByID(ctx, "a2")
+	a.ScheduleID, a.MatHours = "s2", -2
+	stores.AttendanceStore.Save(ctx, a)
+
+	rec := httptest.NewRecorder()
+	handleMatHoursRepair(rec, authRequest("POST", "/api/attendance/mat-hours/repair", `{"DryRun":true}`, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+
+	for _, dryRun := range []string{"true", "false"} {
+		rec = httptest.NewRecorder()
+		handleMatHoursRepair(rec, authRequest("POST", "/api/attendance/mat-hours/repair", `{"DryRun":`+dryRun+`}`, adminSession))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("DryRun %s: expected 200, got %d: %s", dryRun, rec.Code, rec.Body.String())
+		}
+		var result orchestrators.RepairMatHoursResult
+		json.NewDecoder(rec.Body).Decode(&result)
+		if len(result.Corrections) != 1 || result.Corrections[0].AttendanceID != "a2" || result.Corrections[0].Problem != attendanceDomain.MatHoursNegative || result.Corrections[0].To != 1 {
+			t.Errorf("DryRun %s: corrections = %+v", dryRun, result.Corrections)
+		}
+	}
+	if a, _ := stores.AttendanceStore.GetByID(ctx, "a2"); a.MatHours != 1 {
+		t.Errorf("a2 mat hours = %v, want 1", a.MatHours)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMatHoursRepair(rec, authRequest("POST", "/api/attendance/mat-hours/repair", `{"From":"yesterday"}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("bad date: expected 400, got %d", rec.Code)
+	}
+}
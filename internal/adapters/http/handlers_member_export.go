@@ -0,0 +1,246 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	"workshop/internal/domain/export"
+	"workshop/internal/domain/outbox"
+)
+
+// memberExportView is one export as listed by GET /api/me/export. The file path and the
+// requester's IP address stay server-side.
+type memberExportView struct {
+	ID          string
+	Status      string
+	RequestedAt time.Time
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time
+	FileSize    int64
+	CanDownload bool
+}
+
+// newMemberExportView converts an export request for the API.
+func newMemberExportView(r export.Request) memberExportView {
+	return memberExportView{
+		ID:          r.ID,
+		Status:      r.Status,
+		RequestedAt: r.RequestedAt,
+		CompletedAt: r.CompletedAt,
+		ExpiresAt:   r.ExpiredAt,
+		FileSize:    r.FileSize,
+		CanDownload: r.CanDownload(),
+	}
+}
+
+// OutboxExecutors returns the executors for the outbox action types the app queues.
+// baseURL is the public site URL used in emailed links.
+func OutboxExecutors(s *Stores, baseURL string) map[string]orchestrators.ActionExecutor {
+	return map[string]orchestrators.ActionExecutor{
+		outbox.ActionTypeMemberExport: &orchestrators.MemberExportExecutor{
+			ExportStore: s.ExportStore,
+			Assemble:    MemberDataExport(s, time.Now),
+			WriteFile:   saveMemberExport,
+			GenerateID:  generateID,
+			EmailSender: emailSender,
+			FromAddress: emailFromAddress,
+			ReplyTo:     emailReplyTo,
+			BaseURL:     baseURL,
+		},
+	}
+}
+
+// ExpireMemberExportsDeps returns the dependencies of the export expiry worker.
+func ExpireMemberExportsDeps(s *Stores, now func() time.Time) orchestrators.ExpireMemberExportsDeps {
+	return orchestrators.ExpireMemberExportsDeps{
+		ExportStore: s.ExportStore,
+		RemoveFile:  removeMemberExport,
+		Now:         now,
+	}
+}
+
+// MemberDataExport returns a function that gathers everything held about a member.
+func MemberDataExport(s *Stores, now func() time.Time) func(ctx context.Context, memberID string) (export.Data, error) {
+	return func(ctx context.Context, memberID string) (export.Data, error) {
+		return projections.QueryGetMemberDataExport(ctx, projections.GetMemberDataExportQuery{MemberID: memberID}, now(), projections.GetMemberDataExportDeps{
+			MemberStore:          s.MemberStore,
+			AccountStore:         s.AccountStore,
+			AttendanceStore:      s.AttendanceStore,
+			ScheduleStore:        s.ScheduleStore,
+			ClassTypeStore:       s.ClassTypeStore,
+			InjuryStore:          s.InjuryStore,
+			WaiverStore:          s.WaiverStore,
+			ConsentStore:         s.ConsentStore,
+			GradingStore:         s.GradingRecordStore,
+			MessageStore:         s.MessageStore,
+			MilestoneStore:       s.MilestoneStore,
+			EarnedMilestoneStore: s.MemberMilestoneStore,
+			TrainingGoalStore:    s.TrainingGoalStore,
+			PersonalGoalStore:    s.PersonalGoalStore,
+			BugReportStore:       s.BugBoxStore,
+			CelebrationStore:     s.CelebrationStore,
+		})
+	}
+}
+
+// saveMemberExport writes an export archive under the uploads directory.
+// PRE: relPath is a relative path under "uploads/"
+// POST: file created at uploads/<relPath>, readable only by the server
+func saveMemberExport(relPath string, data []byte) error {
+	fullPath := filepath.Join("uploads", relPath)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o750); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+	return os.WriteFile(fullPath, data, 0o600)
+}
+
+// removeMemberExport deletes an export archive; one that is already gone is not an error.
+// PRE: relPath is a relative path under "uploads/"
+// POST: uploads/<relPath> no longer exists
+func removeMemberExport(relPath string) error {
+	if err := os.Remove(filepath.Join("uploads", relPath)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// handlePrivacyExportPage handles GET /privacy/export
+// Lets a member ask for a copy of their data and download it once it is ready.
+func handlePrivacyExportPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "privacy") {
+		return
+	}
+	renderTemplate(w, r, "privacy_export.html", map[string]any{
+		"HasMember":    sessionMemberID(r.Context(), sess) != "",
+		"WindowInDays": int(export.DownloadWindow.Hours() / 24),
+	})
+}
+
+// handleMyExports handles GET/POST for /api/me/export
+// GET lists the caller's recent exports, newest first. POST asks for a new one, which is
+// built in the background and emailed as a link when ready (202 Accepted).
+func handleMyExports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "privacy") {
+		return
+	}
+	memberID := sessionMemberID(ctx, sess)
+	if memberID == "" {
+		apierror.NotFound(w, "no member record for this account")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		requests, err := stores.ExportStore.ListByMemberID(ctx, memberID, 10)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		views := make([]memberExportView, 0, len(requests))
+		for _, req := range requests {
+			views = append(views, newMemberExportView(req))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(views)
+
+	case "POST":
+		req, err := orchestrators.ExecuteRequestMemberExport(ctx, orchestrators.RequestMemberExportInput{
+			MemberID:  memberID,
+			IPAddress: r.RemoteAddr,
+			UserAgent: r.UserAgent(),
+		}, orchestrators.RequestMemberExportDeps{
+			ExportStore: stores.ExportStore,
+			OutboxStore: stores.OutboxStore,
+			GenerateID:  generateID,
+			Now:         timeNow,
+		})
+		if errors.Is(err, export.ErrInProgress) {
+			apierror.Conflict(w, err.Error())
+			return
+		}
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(newMemberExportView(req))
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleMyExportDownload handles GET /api/me/export/download?id=
+// Streams one of the caller's finished exports as a ZIP. It can be downloaded again until
+// it expires; every download is logged.
+func handleMyExportDownload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "privacy") {
+		return
+	}
+	req, err := stores.ExportStore.GetByID(ctx, r.URL.Query().Get("id"))
+	if err != nil || req.MemberID == "" || req.MemberID != sessionMemberID(ctx, sess) {
+		apierror.NotFound(w, "export not found")
+		return
+	}
+	if !req.CanDownload() {
+		apierror.Conflict(w, export.ErrNotReady.Error())
+		return
+	}
+	data, err := os.ReadFile(filepath.Join("uploads", req.FilePath))
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	if err := req.MarkDownloaded(); err != nil {
+		apierror.Conflict(w, err.Error())
+		return
+	}
+	if err := stores.ExportStore.Save(ctx, req); err != nil {
+		internalError(w, err)
+		return
+	}
+	slog.InfoContext(ctx, "privacy_event", "event", "export_downloaded", "export_id", req.ID, "member_id", req.MemberID,
+		"account_id", sess.AccountID, "ip", r.RemoteAddr)
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"workshop-data-%s.zip\"", req.RequestedAt.Format("2006-01-02")))
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(data)
+}
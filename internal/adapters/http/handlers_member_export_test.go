@@ -0,0 +1,297 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	consentDomain "workshop/internal/domain/consent"
+	exportDomain "workshop/internal/domain/export"
+	memberDomain "workshop/internal/domain/member"
+	outboxDomain "workshop/internal/domain/outbox"
+	personalGoalDomain "workshop/internal/domain/personalgoal"
+)
+
+// --- Mock export and outbox stores ---
+
+type mockExportStore struct {
+	requests map[string]exportDomain.Request
+}
+
+// GetByID returns the export request.
+// PRE: id is non-empty
+// POST: Returns the request or an error
+func (m *mockExportStore) GetByID(_ context.Context, id string) (exportDomain.Request, error) {
+	r, ok := m.requests[id]
+	if !ok {
+		return exportDomain.Request{}, errors.New("not found")
+	}
+	return r, nil
+}
+
+// Save stores the export request.
+// PRE: r is valid
+// POST: The request is stored
+func (m *mockExportStore) Save(_ context.Context, r exportDomain.Request) error {
+	m.requests[r.ID] = r
+	return nil
+}
+
+// ListByMemberID returns the member's requests, newest first.
+// PRE: memberID is non-empty
+// POST: Returns up to limit requests
+func (m *mockExportStore) ListByMemberID(_ context.Context, memberID string, limit int) ([]exportDomain.Request, error) {
+	var list []exportDomain.Request
+	for _, r := range m.requests {
+		if r.MemberID == memberID {
+			list = append(list, r)
+		}
+	}
+	for i := 1; i < len(list); i++ {
+		for j := i; j > 0 && list[j].RequestedAt.After(list[j-1].RequestedAt); j-- {
+			list[j], list[j-1] = list[j-1], list[j]
+		}
+	}
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
+
+// ListExpired returns no requests.
+// PRE: limit > 0
+// POST: Returns an empty list
+func (m *mockExportStore) ListExpired(_ context.Context, _ time.Time, _ int) ([]exportDomain.Request, error) {
+	return nil, nil
+}
+
+type mockOutboxStore struct {
+	entries map[string]outboxDomain.Entry
+}
+
+// GetByID returns the outbox entry.
+// PRE: id is non-empty
+// POST: Returns the entry or an error
+func (m *mockOutboxStore) GetByID(_ context.Context, id string) (outboxDomain.Entry, error) {
+	e, ok := m.entries[id]
+	if !ok {
+		return outboxDomain.Entry{}, errors.New("not found")
+	}
+	return e, nil
+}
+
+// Save stores the outbox entry.
+// PRE: e is valid
+// POST: The entry is stored
+func (m *mockOutboxStore) Save(_ context.Context, e outboxDomain.Entry) error {
+	m.entries[e.ID] = e
+	return nil
+}
+
+// ListPending returns the pending entries.
+// PRE: limit > 0
+// POST: Returns the pending entries
+func (m *mockOutboxStore) ListPending(_ context.Context, _ int) ([]outboxDomain.Entry, error) {
+	var list []outboxDomain.Entry
+	for _, e := range m.entries {
+		if e.Status == outboxDomain.StatusPending || e.Status == outboxDomain.StatusRetrying {
+			list = append(list, e)
+		}
+	}
+	return list, nil
+}
+
+// ListFailed returns no entries.
+// PRE: limit > 0
+// POST: Returns an empty list
+func (m *mockOutboxStore) ListFailed(_ context.Context, _ int) ([]outboxDomain.Entry, error) {
+	return nil, nil
+}
+
+// ListByActionType returns no entries.
+// PRE: actionType is non-empty
+// POST: Returns an empty list
+func (m *mockOutboxStore) ListByActionType(_ context.Context, _, _ string, _ int) ([]outboxDomain.Entry, error) {
+	return nil, nil
+}
+
+// CountByStatus returns no counts.
+// PRE: none
+// POST: Returns an empty map
+func (m *mockOutboxStore) CountByStatus(_ context.Context) (map[string]int, error) {
+	return map[string]int{}, nil
+}
+
+// Delete removes the outbox entry.
+// PRE: id is non-empty
+// POST: The entry is removed
+func (m *mockOutboxStore) Delete(_ context.Context, id string) error {
+	delete(m.entries, id)
+	return nil
+}
+
+type mockConsentStore struct {
+	consents []consentDomain.Consent
+}
+
+// Save records the consent.
+// PRE: c is valid
+// POST: The consent is recorded
+func (m *mockConsentStore) Save(_ context.Context, c consentDomain.Consent) error {
+	m.consents = append(m.consents, c)
+	return nil
+}
+
+// GetByMemberID returns the member's consents.
+// PRE: memberID is non-empty
+// POST: Returns the consents
+func (m *mockConsentStore) GetByMemberID(_ context.Context, memberID string) ([]consentDomain.Consent, error) {
+	var list []consentDomain.Consent
+	for _, c := range m.consents {
+		if c.MemberID == memberID {
+			list = append(list, c)
+		}
+	}
+	return list, nil
+}
+
+// GetByType returns the member's latest consent of a type.
+// PRE: memberID and consentType are non-empty
+// POST: Returns the consent or an error
+func (m *mockConsentStore) GetByType(_ context.Context, memberID string, consentType consentDomain.Type) (consentDomain.Consent, error) {
+	for i := len(m.consents) - 1; i >= 0; i-- {
+		if c := m.consents[i]; c.MemberID == memberID && c.Type == consentType {
+			return c, nil
+		}
+	}
+	return consentDomain.Consent{}, errors.New("not found")
+}
+
+// HasValidConsent reports whether the member's latest consent of a type is granted.
+// PRE: memberID and consentType are non-empty
+// POST: Returns true when granted
+func (m *mockConsentStore) HasValidConsent(ctx context.Context, memberID string, consentType consentDomain.Type) (bool, error) {
+	c, err := m.GetByType(ctx, memberID, consentType)
+	return err == nil && c.Granted, nil
+}
+
+// newMemberExportTestStores returns stores where member-001 belongs to memberSession and
+// member-002 to someone else.
+func newMemberExportTestStores() (*Stores, *mockExportStore, *mockOutboxStore) {
+	s := newNotificationTestStores()
+	s.MemberStore.Save(context.Background(), memberDomain.Member{ID: "member-002", AccountID: "other-001", Name: "Other", Email: "other@test.com", Program: "adults", Status: memberDomain.StatusActive})
+	exports := &mockExportStore{requests: map[string]exportDomain.Request{}}
+	outbox := &mockOutboxStore{entries: map[string]outboxDomain.Entry{}}
+	s.ExportStore = exports
+	s.ConsentStore = &mockConsentStore{}
+	s.PersonalGoalStore = &mockPersonalGoalStore{goals: map[string]personalGoalDomain.PersonalGoal{}}
+	s.OutboxStore = outbox
+	return s, exports, outbox
+}
+
+// TestHandleMyExports_QueuesOnePerMember verifies a member's export is queued on the outbox
+// with 202, and a second request is refused while the first is being prepared.
+func TestHandleMyExports_QueuesOnePerMember(t *testing.T) {
+	var outbox *mockOutboxStore
+	stores, _, outbox = newMemberExportTestStores()
+
+	rec := httptest.NewRecorder()
+	handleMyExports(rec, authRequest("POST", "/api/me/export", "", memberSession))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var queued memberExportView
+	json.NewDecoder(rec.Body).Decode(&queued)
+	if queued.Status != exportDomain.StatusPending || queued.CanDownload {
+		t.Errorf("expected a pending export, got %+v", queued)
+	}
+	if len(outbox.entries) != 1 {
+		t.Fatalf("expected one outbox entry, got %d", len(outbox.entries))
+	}
+	for _, e := range outbox.entries {
+		if e.ActionType != outboxDomain.ActionTypeMemberExport {
+			t.Errorf("expected a member_export entry, got %q", e.ActionType)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	handleMyExports(rec, authRequest("POST", "/api/me/export", "", memberSession))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("second request: expected 409, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMyExports(rec, authRequest("GET", "/api/me/export", "", memberSession))
+	var list []memberExportView
+	json.NewDecoder(rec.Body).Decode(&list)
+	if rec.Code != http.StatusOK || len(list) != 1 || list[0].ID != queued.ID {
+		t.Errorf("expected the queued export listed, got %d: %+v", rec.Code, list)
+	}
+
+	// An account without a member record has nothing to export.
+	rec = httptest.NewRecorder()
+	handleMyExports(rec, authRequest("POST", "/api/me/export", "", adminSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("admin without a member record: expected 404, got %d", rec.Code)
+	}
+}
+
+// TestHandleMyExportDownload_OwnReadyExportOnly verifies the outbox executor's file can be
+// downloaded by its member while it is ready, and by nobody else.
+func TestHandleMyExportDownload_OwnReadyExportOnly(t *testing.T) {
+	t.Chdir(t.TempDir())
+	var exports *mockExportStore
+	var outbox *mockOutboxStore
+	stores, exports, outbox = newMemberExportTestStores()
+
+	rec := httptest.NewRecorder()
+	handleMyExports(rec, authRequest("POST", "/api/me/export", "", memberSession))
+	var queued memberExportView
+	json.NewDecoder(rec.Body).Decode(&queued)
+
+	rec = httptest.NewRecorder()
+	handleMyExportDownload(rec, authRequest("GET", "/api/me/export/download?id="+queued.ID, "", memberSession))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("before it is built: expected 409, got %d", rec.Code)
+	}
+
+	executors := OutboxExecutors(stores, "https://gym.example")
+	for _, e := range outbox.entries {
+		if _, err := executors[e.ActionType].Execute(context.Background(), e.Payload); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	}
+	if got := exports.requests[queued.ID]; got.Status != exportDomain.StatusReady {
+		t.Fatalf("expected the export ready, got %+v", got)
+	}
+
+	exports.requests["other-export"] = exportDomain.Request{ID: "other-export", MemberID: "member-002", Status: exportDomain.StatusReady, FilePath: exports.requests[queued.ID].FilePath}
+	for _, tt := range []struct {
+		name string
+		id   string
+		want int
+	}{
+		{name: "own export", id: queued.ID, want: http.StatusOK},
+		{name: "again", id: queued.ID, want: http.StatusOK},
+		{name: "someone else's", id: "other-export", want: http.StatusNotFound},
+		{name: "unknown", id: "nope", want: http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleMyExportDownload(rec, authRequest("GET", "/api/me/export/download?id="+tt.id, "", memberSession))
+			if rec.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+			if tt.want == http.StatusOK && (rec.Header().Get("Content-Type") != "application/zip" || rec.Body.Len() == 0) {
+				t.Errorf("expected a ZIP body, got %q with %d bytes", rec.Header().Get("Content-Type"), rec.Body.Len())
+			}
+		})
+	}
+	if got := exports.requests[queued.ID]; got.Status != exportDomain.StatusDownloaded || got.DownloadedAt == nil {
+		t.Errorf("expected the download recorded, got %+v", got)
+	}
+}
@@ -0,0 +1,97 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	memberDomain "workshop/internal/domain/member"
+)
+
+// memberMergeRequest is the body of POST /api/members/merge.
+type memberMergeRequest struct {
+	SurvivorID  string `json:"SurvivorID"`
+	DuplicateID string `json:"DuplicateID"`
+	DryRun      bool   `json:"DryRun"` // report what would move without changing anything
+}
+
+// handleMemberDuplicates handles GET /api/members/duplicates
+// Lists pairs of members that look like the same person, with a suggested survivor. Admin only.
+func handleMemberDuplicates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "member_mgmt") {
+		return
+	}
+	pairs, err := projections.QueryGetMemberDuplicates(r.Context(), projections.GetMemberDuplicatesDeps{
+		MemberStore: stores.MemberStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pairs)
+}
+
+// handleMemberMerge handles POST /api/members/merge
+// Folds a duplicate member into the survivor, or with DryRun reports what would move. Admin only.
+func handleMemberMerge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "member_mgmt") {
+		return
+	}
+	var input memberMergeRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	result, err := orchestrators.ExecuteMergeMembers(r.Context(), orchestrators.MergeMembersInput{
+		SurvivorID:  input.SurvivorID,
+		DuplicateID: input.DuplicateID,
+		DryRun:      input.DryRun,
+		Actor: orchestrators.BackfillActor{
+			AccountID: sess.AccountID,
+			Email:     sess.Email,
+			Role:      sess.Role,
+			IPAddress: middleware.ClientIP(r),
+			UserAgent: r.UserAgent(),
+		},
+	}, orchestrators.MergeMembersDeps{
+		MemberStore: stores.MemberStore,
+		RecordStore: stores.MemberMergeStore,
+		AuditStore:  stores.AuditStore,
+	})
+	switch {
+	case errors.Is(err, orchestrators.ErrMergeMemberNotFound):
+		apierror.NotFound(w, err.Error())
+		return
+	case errors.Is(err, orchestrators.ErrMergeIDsRequired),
+		errors.Is(err, memberDomain.ErrMergeSameMember),
+		errors.Is(err, memberDomain.ErrMergeArchivedSurvivor):
+		apierror.Validation(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
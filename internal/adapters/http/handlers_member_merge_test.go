@@ -0,0 +1,153 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	memberDomain "workshop/internal/domain/member"
+)
+
+// --- Mock member merge store ---
+
+type mockMemberMergeStore struct {
+	owned     map[string]map[string]int // member ID -> record kind -> count
+	conflicts map[string]int            // record kind -> count the survivor already holds
+}
+
+// CountRecords implements member.MergeStore for testing.
+// PRE: none
+// POST: Returns what would move and the conflicts without changing anything
+func (m *mockMemberMergeStore) CountRecords(_ context.Context, fromID, _ string) (map[string]int, map[string]int, error) {
+	out := map[string]int{}
+	for kind, n := range m.owned[fromID] {
+		out[kind] = n - m.conflicts[kind]
+	}
+	return out, m.conflicts, nil
+}
+
+// ReassignRecords implements member.MergeStore for testing.
+// PRE: none
+// POST: fromID's counts, less the conflicts, are added to toID's
+func (m *mockMemberMergeStore) ReassignRecords(ctx context.Context, fromID, toID string) (map[string]int, map[string]int, error) {
+	moved, conflicts, _ := m.CountRecords(ctx, fromID, toID)
+	if m.owned[toID] == nil {
+		m.owned[toID] = map[string]int{}
+	}
+	left := map[string]int{}
+	for kind, n := range moved {
+		m.owned[toID][kind] += n
+		if conflicts[kind] > 0 {
+			left[kind] = conflicts[kind]
+		}
+	}
+	m.owned[fromID] = left
+	return moved, conflicts, nil
+}
+
+func newMemberMergeTestStores() (*Stores, *mockMemberMergeStore) {
+	s := newFullStores()
+	members := s.MemberStore.(*mockMemberStore)
+	for _, m := range []memberDomain.Member{
+		{ID: "m1", AccountID: "acct-1", Name: "Aroha Ngata", Email: "aroha@test.com", Program: memberDomain.ProgramAdults, Status: memberDomain.StatusActive},
+		{ID: "guest", Name: "Aroha Ngata", Email: "aroha.guest@test.com", Program: memberDomain.ProgramAdults, Status: memberDomain.StatusInactive},
+		{ID: "m2", Name: "Ben Cole", Email: "ben@test.com", Program: memberDomain.ProgramAdults, Status: memberDomain.StatusActive},
+	} {
+		members.members[m.ID] = m
+	}
+	merge := &mockMemberMergeStore{owned: map[string]map[string]int{
+		"m1":    {"attendance": 20},
+		"guest": {"attendance": 2, "waivers": 1},
+	}}
+	s.MemberMergeStore = merge
+	s.AuditStore = &mockAuditStore{}
+	return s, merge
+}
+
+// TestHandleMemberMerge_DetectDryRunAndMerge verifies an admin sees the duplicate pair,
+// previews the merge without changes, then merges and archives the duplicate.
+func TestHandleMemberMerge_DetectDryRunAndMerge(t *testing.T) {
+	var merge *mockMemberMergeStore
+	stores, merge = newMemberMergeTestStores()
+
+	rec := httptest.NewRecorder()
+	handleMemberDuplicates(rec, authRequest("GET", "/api/members/duplicates", "", adminSession))
+	var pairs []projections.DuplicateMemberPair
+	json.NewDecoder(rec.Body).Decode(&pairs)
+	if rec.Code != http.StatusOK || len(pairs) != 1 || pairs[0].Survivor.ID != "m1" || pairs[0].Duplicate.ID != "guest" {
+		t.Fatalf("expected m1 surviving guest, got %d %+v", rec.Code, pairs)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMemberMerge(rec, authRequest("POST", "/api/members/merge", `{"SurvivorID":"m1","DuplicateID":"guest","DryRun":true}`, adminSession))
+	var report orchestrators.MergeMembersResult
+	json.NewDecoder(rec.Body).Decode(&report)
+	if rec.Code != http.StatusOK || !report.DryRun || report.Records["attendance"] != 2 {
+		t.Fatalf("dry run: got %d %+v", rec.Code, report)
+	}
+	if merge.owned["guest"]["attendance"] != 2 {
+		t.Fatal("dry run should not move records")
+	}
+
+	rec = httptest.NewRecorder()
+	handleMemberMerge(rec, authRequest("POST", "/api/members/merge", `{"SurvivorID":"m1","DuplicateID":"guest"}`, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("merge: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if merge.owned["m1"]["attendance"] != 22 || merge.owned["m1"]["waivers"] != 1 {
+		t.Errorf("records not moved: %+v", merge.owned)
+	}
+	if got, _ := stores.MemberStore.GetByID(context.Background(), "guest"); got.Status != memberDomain.StatusArchived {
+		t.Errorf("duplicate should be archived, got %q", got.Status)
+	}
+	if n := len(stores.AuditStore.(*mockAuditStore).events); n != 1 {
+		t.Errorf("expected one audit event, got %d", n)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMemberDuplicates(rec, authRequest("GET", "/api/members/duplicates", "", adminSession))
+	pairs = nil
+	json.NewDecoder(rec.Body).Decode(&pairs)
+	if len(pairs) != 0 {
+		t.Errorf("archived duplicate should no longer be listed, got %+v", pairs)
+	}
+}
+
+// TestHandleMemberMerge_Errors verifies bad merges are rejected and coaches are refused.
+func TestHandleMemberMerge_Errors(t *testing.T) {
+	stores, _ = newMemberMergeTestStores()
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"missing IDs", `{"SurvivorID":"m1"}`, http.StatusBadRequest},
+		{"same member", `{"SurvivorID":"m1","DuplicateID":"m1"}`, http.StatusBadRequest},
+		{"unknown member", `{"SurvivorID":"m1","DuplicateID":"nobody"}`, http.StatusNotFound},
+		{"unknown field", `{"SurvivorID":"m1","DuplicateID":"guest","Force":true}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleMemberMerge(rec, authRequest("POST", "/api/members/merge", tt.body, adminSession))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	handleMemberMerge(rec, authRequest("POST", "/api/members/merge", `{"SurvivorID":"m1","DuplicateID":"guest"}`, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach merge: expected 403, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleMemberDuplicates(rec, authRequest("GET", "/api/members/duplicates", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach duplicates: expected 403, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,76 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/projections"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// viewableMemberID resolves which member the caller may view. Staff with members.view
+// may name any member; everyone else gets their own. Writes the error response on failure.
+func viewableMemberID(w http.ResponseWriter, r *http.Request, sess middleware.Session, requested string) (string, bool) {
+	ctx := r.Context()
+	memberID := requested
+	if !permissionAllowed(ctx, sess, permissionDomain.ActionMembersView) {
+		own := sessionMemberID(ctx, sess)
+		if own == "" || (requested != "" && requested != own) {
+			apierror.Forbidden(w, "Forbidden")
+			return "", false
+		}
+		memberID = own
+	}
+	if memberID == "" {
+		apierror.Validation(w, "member_id is required")
+		return "", false
+	}
+	if _, err := stores.MemberStore.GetByID(ctx, memberID); err != nil {
+		apierror.NotFound(w, "member not found")
+		return "", false
+	}
+	return memberID, true
+}
+
+// handleMemberProgression handles GET /api/members/progression?member_id=
+// Returns the member's belt timeline for the profile chart. Staff with members.view
+// may view any member; everyone else only sees their own progression.
+func handleMemberProgression(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "training_log") {
+		return
+	}
+
+	memberID, ok := viewableMemberID(w, r, sess, r.URL.Query().Get("member_id"))
+	if !ok {
+		return
+	}
+
+	result, err := projections.QueryGetMemberProgression(ctx, projections.GetMemberProgressionQuery{
+		MemberID: memberID,
+		Now:      timeNow(),
+	}, projections.GetMemberProgressionDeps{
+		MemberStore:          stores.MemberStore,
+		GradingRecordStore:   stores.GradingRecordStore,
+		MilestoneStore:       stores.MilestoneStore,
+		MemberMilestoneStore: stores.MemberMilestoneStore,
+		EstimatedHoursStore:  stores.EstimatedHoursStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,66 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/application/projections"
+	gradingDomain "workshop/internal/domain/grading"
+)
+
+// TestHandleMemberProgression_MemberSeesOwnTimeline verifies members get their own timeline without member_id.
+func TestHandleMemberProgression_MemberSeesOwnTimeline(t *testing.T) {
+	seedMessageThread(t)
+	ctx := context.Background()
+	stores.GradingRecordStore.Save(ctx, gradingDomain.Record{ID: "g1", MemberID: "m1", Belt: "white", PromotedAt: time.Now().AddDate(-1, 0, 0), Method: gradingDomain.MethodStandard})
+	stores.GradingRecordStore.Save(ctx, gradingDomain.Record{ID: "g2", MemberID: "m1", Belt: "blue", PromotedAt: time.Now().AddDate(0, -1, 0), Method: gradingDomain.MethodStandard})
+
+	rec := httptest.NewRecorder()
+	handleMemberProgression(rec, authRequest("GET", "/api/members/progression", "", memberSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result projections.MemberProgressionResult
+	json.NewDecoder(rec.Body).Decode(&result)
+	if result.MemberID != "m1" || result.CurrentBelt != "blue" || len(result.Events) != 2 || len(result.Belts) != 2 {
+		t.Errorf("unexpected progression: %+v", result)
+	}
+}
+
+// TestHandleMemberProgression_MemberCannotViewOthers verifies members are refused another member's timeline.
+func TestHandleMemberProgression_MemberCannotViewOthers(t *testing.T) {
+	seedMessageThread(t)
+
+	rec := httptest.NewRecorder()
+	handleMemberProgression(rec, authRequest("GET", "/api/members/progression?member_id=m2", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleMemberProgression_StaffViewsAnyMember verifies coaches can load any member and need member_id.
+func TestHandleMemberProgression_StaffViewsAnyMember(t *testing.T) {
+	seedMessageThread(t)
+
+	rec := httptest.NewRecorder()
+	handleMemberProgression(rec, authRequest("GET", "/api/members/progression?member_id=m2", "", coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleMemberProgression(rec, authRequest("GET", "/api/members/progression", "", coachSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("missing member_id: expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMemberProgression(rec, authRequest("GET", "/api/members/progression?member_id=nope", "", coachSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown member: expected 404, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,398 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	memberDomain "workshop/internal/domain/member"
+	"workshop/internal/domain/membertag"
+)
+
+// memberTagRequest is the body of POST /api/member-tags.
+type memberTagRequest struct {
+	MemberID string `json:"MemberID"`
+	Tag      string `json:"Tag"`
+}
+
+// renameTagRequest is the body of PUT /api/member-tags/catalog.
+type renameTagRequest struct {
+	From string `json:"From"`
+	To   string `json:"To"`
+}
+
+// memberSegmentRequest is the body of POST /api/member-segments.
+type memberSegmentRequest struct {
+	ID                 string   `json:"ID"` // optional: empty creates a new segment
+	Name               string   `json:"Name"`
+	Tags               []string `json:"Tags"`
+	Program            string   `json:"Program"`
+	Status             string   `json:"Status"`
+	AttendedWithinDays int      `json:"AttendedWithinDays"`
+	AbsentForDays      int      `json:"AbsentForDays"`
+}
+
+// memberTagDeps builds the deps for the member tag orchestrators.
+func memberTagDeps() orchestrators.MemberTagDeps {
+	return orchestrators.MemberTagDeps{
+		TagStore:    stores.MemberTagStore,
+		MemberStore: stores.MemberStore,
+		GenerateID:  generateID,
+		Now:         timeNow,
+	}
+}
+
+// resolveSegment returns the members a saved segment matches right now.
+func resolveSegment(ctx context.Context, segment membertag.Segment) ([]memberDomain.Member, error) {
+	return projections.QueryResolveSegment(ctx, segment, projections.ResolveSegmentDeps{
+		MemberStore:     stores.MemberStore,
+		TagStore:        stores.MemberTagStore,
+		AttendanceStore: stores.AttendanceStore,
+		Now:             timeNow,
+	})
+}
+
+// memberTagFilters reads the ?tag= and ?segment= params shared by the members list, the
+// inactive report and the email recipient filter. It returns the tag to filter on and, for a
+// segment, the IDs of its members (empty, not nil, when nothing matches). The params are
+// ignored for callers without the member_tags feature.
+func memberTagFilters(r *http.Request) (tag string, ids []string, err error) {
+	ctx := r.Context()
+	rawTag, segmentID := r.URL.Query().Get("tag"), r.URL.Query().Get("segment")
+	if rawTag == "" && segmentID == "" {
+		return "", nil, nil
+	}
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok || !featureEnabledForSession(ctx, sess, "member_tags") {
+		return "", nil, nil
+	}
+	if rawTag != "" {
+		if tag, err = membertag.NormalizeTag(rawTag); err != nil {
+			// No member can carry a malformed tag.
+			return "", []string{}, nil
+		}
+	}
+	if segmentID != "" {
+		segment, err := stores.MemberTagStore.GetSegment(ctx, segmentID)
+		if err != nil {
+			return tag, []string{}, nil
+		}
+		members, err := resolveSegment(ctx, segment)
+		if err != nil {
+			return "", nil, err
+		}
+		ids = projections.SegmentMemberIDs(members)
+	}
+	return tag, ids, nil
+}
+
+// writeMemberTagError maps member tag and segment errors to API responses.
+func writeMemberTagError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, orchestrators.ErrTagMemberMissing),
+		errors.Is(err, orchestrators.ErrSegmentNotFound):
+		apierror.NotFound(w, err.Error())
+	case errors.Is(err, orchestrators.ErrTagInSegment):
+		apierror.Conflict(w, err.Error())
+	case errors.Is(err, orchestrators.ErrSameTag),
+		errors.Is(err, membertag.ErrEmptyTag),
+		errors.Is(err, membertag.ErrTagTooLong),
+		errors.Is(err, membertag.ErrInvalidTag),
+		errors.Is(err, membertag.ErrEmptySegmentName),
+		errors.Is(err, membertag.ErrSegmentNameTooLong),
+		errors.Is(err, membertag.ErrTooManyTags),
+		errors.Is(err, membertag.ErrInvalidDays),
+		errors.Is(err, membertag.ErrConflictingDays),
+		errors.Is(err, membertag.ErrEmptySegment):
+		apierror.Validation(w, err.Error())
+	default:
+		internalError(w, err)
+	}
+}
+
+// handleAdminMemberTagsPage handles GET /admin/member-tags
+func handleAdminMemberTagsPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "member_tags") {
+		return
+	}
+	renderTemplate(w, r, "admin_member_tags.html", nil)
+}
+
+// handleMemberTags handles GET/POST/DELETE /api/member-tags
+// GET ?member_id= returns a member's tags; POST tags a member, creating the tag on first use;
+// DELETE ?member_id=&tag= takes a tag off a member. Admin only.
+func handleMemberTags(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "member_tags") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		memberID := r.URL.Query().Get("member_id")
+		if memberID == "" {
+			apierror.Validation(w, "member_id is required")
+			return
+		}
+		tags, err := stores.MemberTagStore.ListByMember(ctx, memberID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if tags == nil {
+			tags = []string{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(tags)
+
+	case "POST":
+		var input memberTagRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		tag, err := orchestrators.ExecuteTagMember(ctx, orchestrators.TagMemberInput{
+			MemberID: input.MemberID, Tag: input.Tag, ActorID: sess.AccountID,
+		}, memberTagDeps())
+		if err != nil {
+			writeMemberTagError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"MemberID": input.MemberID, "Tag": tag})
+
+	case "DELETE":
+		q := r.URL.Query()
+		if q.Get("member_id") == "" || q.Get("tag") == "" {
+			apierror.Validation(w, "member_id and tag are required")
+			return
+		}
+		err := orchestrators.ExecuteUntagMember(ctx, orchestrators.TagMemberInput{
+			MemberID: q.Get("member_id"), Tag: q.Get("tag"), ActorID: sess.AccountID,
+		}, memberTagDeps())
+		if err != nil {
+			writeMemberTagError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleMemberTagCatalog handles GET/PUT/DELETE /api/member-tags/catalog
+// GET lists every tag in use with its member count; PUT renames (or merges) a tag on every
+// member and segment; DELETE ?tag= removes a tag from every member. Admin only.
+func handleMemberTagCatalog(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "member_tags") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		tags, err := stores.MemberTagStore.ListTags(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if tags == nil {
+			tags = []membertag.TagCount{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(tags)
+
+	case "PUT":
+		var input renameTagRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		tag, err := orchestrators.ExecuteRenameTag(ctx, orchestrators.RenameTagInput{
+			From: input.From, To: input.To, ActorID: sess.AccountID,
+		}, memberTagDeps())
+		if err != nil {
+			writeMemberTagError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"Tag": tag})
+
+	case "DELETE":
+		tag := r.URL.Query().Get("tag")
+		if tag == "" {
+			apierror.Validation(w, "tag is required")
+			return
+		}
+		if err := orchestrators.ExecuteDeleteTag(ctx, orchestrators.DeleteTagInput{Tag: tag, ActorID: sess.AccountID}, memberTagDeps()); err != nil {
+			writeMemberTagError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleMemberSegments handles GET/POST/DELETE /api/member-segments
+// GET lists saved segments; POST creates one, or edits it when ID is given; DELETE ?id=
+// removes one. Admin only.
+func handleMemberSegments(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "member_tags") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		segments, err := stores.MemberTagStore.ListSegments(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if segments == nil {
+			segments = []membertag.Segment{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(segments)
+
+	case "POST":
+		var input memberSegmentRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		segment, err := orchestrators.ExecuteSaveSegment(ctx, orchestrators.SaveSegmentInput{
+			ID:                 input.ID,
+			Name:               input.Name,
+			Tags:               input.Tags,
+			Program:            input.Program,
+			Status:             input.Status,
+			AttendedWithinDays: input.AttendedWithinDays,
+			AbsentForDays:      input.AbsentForDays,
+			ActorID:            sess.AccountID,
+		}, memberTagDeps())
+		if err != nil {
+			writeMemberTagError(w, err)
+			return
+		}
+		status := http.StatusCreated
+		if input.ID != "" {
+			status = http.StatusOK
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(segment)
+
+	case "DELETE":
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			apierror.Validation(w, "id is required")
+			return
+		}
+		if err := orchestrators.ExecuteDeleteSegment(ctx, orchestrators.DeleteSegmentInput{ID: id, ActorID: sess.AccountID}, memberTagDeps()); err != nil {
+			writeMemberTagError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleMemberSegmentMembers handles GET /api/member-segments/members?id=
+// Returns the members a saved segment matches right now. Admin only.
+func handleMemberSegmentMembers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "member_tags") {
+		return
+	}
+	results, ok := segmentRecipients(w, r, r.URL.Query().Get("id"))
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// handleRecipientsFilterBySegment handles GET /api/emails/recipients/by-segment?segmentID=...
+// Returns a saved segment's members as email recipient candidates. Admin only.
+func handleRecipientsFilterBySegment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "member_tags") {
+		return
+	}
+	results, ok := segmentRecipients(w, r, r.URL.Query().Get("segmentID"))
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// segmentRecipients resolves a segment to recipient candidates, writing the error response
+// and returning false when the segment is missing or cannot be resolved.
+func segmentRecipients(w http.ResponseWriter, r *http.Request, segmentID string) ([]memberResult, bool) {
+	if segmentID == "" {
+		apierror.Validation(w, "segment ID is required")
+		return nil, false
+	}
+	segment, err := stores.MemberTagStore.GetSegment(r.Context(), segmentID)
+	if err != nil {
+		apierror.NotFound(w, "segment not found")
+		return nil, false
+	}
+	members, err := resolveSegment(r.Context(), segment)
+	if err != nil {
+		internalError(w, err)
+		return nil, false
+	}
+	results := []memberResult{}
+	for _, m := range members {
+		results = append(results, memberResult{ID: m.ID, Name: m.Name, Email: m.Email})
+	}
+	return results, true
+}
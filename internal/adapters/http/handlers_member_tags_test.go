@@ -0,0 +1,278 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	memberDomain "workshop/internal/domain/member"
+	membertagDomain "workshop/internal/domain/membertag"
+)
+
+// --- Mock member tag store ---
+
+type mockMemberTagStore struct {
+	tags     map[string]map[string]bool // tag -> member IDs
+	segments map[string]membertagDomain.Segment
+}
+
+func newMockMemberTagStore() *mockMemberTagStore {
+	return &mockMemberTagStore{tags: map[string]map[string]bool{}, segments: map[string]membertagDomain.Segment{}}
+}
+
+// AddTag implements membertag.Store for testing.
+// PRE: tag is normalised
+// POST: The member carries the tag
+func (m *mockMemberTagStore) AddTag(_ context.Context, memberID, tag string, _ time.Time) error {
+	if m.tags[tag] == nil {
+		m.tags[tag] = map[string]bool{}
+	}
+	m.tags[tag][memberID] = true
+	return nil
+}
+
+// RemoveTag implements membertag.Store for testing.
+// PRE: none
+// POST: The member no longer carries the tag
+func (m *mockMemberTagStore) RemoveTag(_ context.Context, memberID, tag string) error {
+	delete(m.tags[tag], memberID)
+	if len(m.tags[tag]) == 0 {
+		delete(m.tags, tag)
+	}
+	return nil
+}
+
+// ListByMember implements membertag.Store for testing.
+// PRE: none
+// POST: Returns the member's tags in order
+func (m *mockMemberTagStore) ListByMember(_ context.Context, memberID string) ([]string, error) {
+	var list []string
+	for tag, ids := range m.tags {
+		if ids[memberID] {
+			list = append(list, tag)
+		}
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+// ListMemberIDs implements membertag.Store for testing.
+// PRE: none
+// POST: Returns the members carrying the tag
+func (m *mockMemberTagStore) ListMemberIDs(_ context.Context, tag string) ([]string, error) {
+	var list []string
+	for id := range m.tags[tag] {
+		list = append(list, id)
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+// ListTags implements membertag.Store for testing.
+// PRE: none
+// POST: Returns tags with member counts in order
+func (m *mockMemberTagStore) ListTags(_ context.Context) ([]membertagDomain.TagCount, error) {
+	var list []membertagDomain.TagCount
+	for tag, ids := range m.tags {
+		list = append(list, membertagDomain.TagCount{Tag: tag, Members: len(ids)})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Tag < list[j].Tag })
+	return list, nil
+}
+
+// RenameTag implements membertag.Store for testing.
+// PRE: from and to differ
+// POST: Members carrying from now carry to
+func (m *mockMemberTagStore) RenameTag(ctx context.Context, from, to string) error {
+	for id := range m.tags[from] {
+		m.AddTag(ctx, id, to, time.Time{})
+	}
+	delete(m.tags, from)
+	return nil
+}
+
+// DeleteTag implements membertag.Store for testing.
+// PRE: none
+// POST: No member carries the tag
+func (m *mockMemberTagStore) DeleteTag(_ context.Context, tag string) error {
+	delete(m.tags, tag)
+	return nil
+}
+
+// GetSegment implements membertag.Store for testing.
+// PRE: id is non-empty
+// POST: Returns the segment or sql.ErrNoRows
+func (m *mockMemberTagStore) GetSegment(_ context.Context, id string) (membertagDomain.Segment, error) {
+	s, ok := m.segments[id]
+	if !ok {
+		return membertagDomain.Segment{}, sql.ErrNoRows
+	}
+	return s, nil
+}
+
+// SaveSegment implements membertag.Store for testing.
+// PRE: value has been validated
+// POST: Segment is upserted
+func (m *mockMemberTagStore) SaveSegment(_ context.Context, value membertagDomain.Segment) error {
+	m.segments[value.ID] = value
+	return nil
+}
+
+// DeleteSegment implements membertag.Store for testing.
+// PRE: id is non-empty
+// POST: Segment is removed
+func (m *mockMemberTagStore) DeleteSegment(_ context.Context, id string) error {
+	delete(m.segments, id)
+	return nil
+}
+
+// ListSegments implements membertag.Store for testing.
+// PRE: none
+// POST: Returns every segment
+func (m *mockMemberTagStore) ListSegments(_ context.Context) ([]membertagDomain.Segment, error) {
+	var list []membertagDomain.Segment
+	for _, s := range m.segments {
+		list = append(list, s)
+	}
+	return list, nil
+}
+
+// newMemberTagTestStores returns stores with three active members.
+func newMemberTagTestStores() (*Stores, *mockMemberTagStore) {
+	s := newFullStores()
+	members := s.MemberStore.(*mockMemberStore)
+	for _, m := range []memberDomain.Member{
+		{ID: "m1", Name: "Ana", Email: "ana@test.com", Program: memberDomain.ProgramAdults, Status: memberDomain.StatusActive},
+		{ID: "m2", Name: "Ben", Email: "ben@test.com", Program: memberDomain.ProgramAdults, Status: memberDomain.StatusActive},
+		{ID: "m3", Name: "Cy", Email: "cy@test.com", Program: memberDomain.ProgramKids, Status: memberDomain.StatusActive},
+	} {
+		members.members[m.ID] = m
+	}
+	tags := newMockMemberTagStore()
+	s.MemberTagStore = tags
+	return s, tags
+}
+
+// TestHandleMemberTags_TagSegmentAndRecipients verifies an admin can tag members, save a
+// segment over the tags, and pick the segment's members as email recipients.
+func TestHandleMemberTags_TagSegmentAndRecipients(t *testing.T) {
+	var tags *mockMemberTagStore
+	stores, tags = newMemberTagTestStores()
+
+	for _, body := range []string{
+		`{"MemberID":"m1","Tag":"Competitor"}`,
+		`{"MemberID":"m2","Tag":"competitor"}`,
+		`{"MemberID":"m2","Tag":"Needs Follow Up"}`,
+	} {
+		rec := httptest.NewRecorder()
+		handleMemberTags(rec, authRequest("POST", "/api/member-tags", body, adminSession))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("tag %s: expected 201, got %d: %s", body, rec.Code, rec.Body.String())
+		}
+	}
+	rec := httptest.NewRecorder()
+	handleMemberTags(rec, authRequest("POST", "/api/member-tags", `{"MemberID":"nobody","Tag":"injured"}`, adminSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown member: expected 404, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMemberTagCatalog(rec, authRequest("GET", "/api/member-tags/catalog", "", adminSession))
+	var catalog []membertagDomain.TagCount
+	json.NewDecoder(rec.Body).Decode(&catalog)
+	if len(catalog) != 2 || catalog[0].Tag != "competitor" || catalog[0].Members != 2 {
+		t.Fatalf("expected competitor (2) and needs-follow-up (1), got %+v", catalog)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMemberSegments(rec, authRequest("POST", "/api/member-segments", `{"Name":"Comp follow-ups","Tags":["competitor","needs-follow-up"]}`, adminSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("save segment: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var segment membertagDomain.Segment
+	json.NewDecoder(rec.Body).Decode(&segment)
+
+	rec = httptest.NewRecorder()
+	handleRecipientsFilterBySegment(rec, authRequest("GET", "/api/emails/recipients/by-segment?segmentID="+segment.ID, "", adminSession))
+	var recipients []memberResult
+	json.NewDecoder(rec.Body).Decode(&recipients)
+	if rec.Code != http.StatusOK || len(recipients) != 1 || recipients[0].ID != "m2" {
+		t.Fatalf("by-segment: expected only m2, got %d %+v", rec.Code, recipients)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMemberFilterForEmail(rec, authRequest("GET", "/api/emails/recipients/filter?segment="+segment.ID, "", adminSession))
+	json.NewDecoder(rec.Body).Decode(&recipients)
+	if len(recipients) != 1 || recipients[0].ID != "m2" {
+		t.Errorf("filter by segment: expected only m2, got %+v", recipients)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMemberTagCatalog(rec, authRequest("DELETE", "/api/member-tags/catalog?tag=competitor", "", adminSession))
+	if rec.Code != http.StatusConflict || len(tags.tags["competitor"]) != 2 {
+		t.Errorf("delete tag used by a segment: expected 409, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleMemberTagCatalog(rec, authRequest("PUT", "/api/member-tags/catalog", `{"From":"competitor","To":"comp-team"}`, adminSession))
+	if rec.Code != http.StatusOK || tags.segments[segment.ID].Tags[0] != "comp-team" {
+		t.Errorf("rename: expected 200 and segment updated, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleMemberTags(rec, authRequest("DELETE", "/api/member-tags?member_id=m2&tag=needs-follow-up", "", adminSession))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("untag: expected 204, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleMemberSegmentMembers(rec, authRequest("GET", "/api/member-segments/members?id="+segment.ID, "", adminSession))
+	if rec.Body.String() != "[]\n" {
+		t.Errorf("segment after untag: expected no members, got %s", rec.Body.String())
+	}
+}
+
+// TestHandleMembers_TagFilter verifies the members list narrows to a tag's members.
+func TestHandleMembers_TagFilter(t *testing.T) {
+	var tags *mockMemberTagStore
+	stores, tags = newMemberTagTestStores()
+	tags.AddTag(context.Background(), "m3", "injured", time.Time{})
+	tags.segments["seg-1"] = membertagDomain.Segment{ID: "seg-1", Name: "Injured", Tags: []string{"injured"}}
+
+	rec := httptest.NewRecorder()
+	handleMembers(rec, authRequest("GET", "/members?segment=seg-1", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result struct{ Members []struct{ ID string } }
+	json.NewDecoder(rec.Body).Decode(&result)
+	if len(result.Members) != 1 || result.Members[0].ID != "m3" {
+		t.Errorf("expected only m3, got %+v", result.Members)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMembers(rec, authRequest("GET", "/members?segment=missing", "", adminSession))
+	json.NewDecoder(rec.Body).Decode(&result)
+	if len(result.Members) != 0 {
+		t.Errorf("unknown segment: expected no members, got %+v", result.Members)
+	}
+}
+
+// TestHandleMemberTags_AdminOnly verifies coaches cannot manage tags or segments.
+func TestHandleMemberTags_AdminOnly(t *testing.T) {
+	stores, _ = newMemberTagTestStores()
+
+	rec := httptest.NewRecorder()
+	handleMemberTags(rec, authRequest("POST", "/api/member-tags", `{"MemberID":"m1","Tag":"competitor"}`, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach tagging: expected 403, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleMemberSegments(rec, authRequest("GET", "/api/member-segments", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach segments: expected 403, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,170 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	messageDomain "workshop/internal/domain/message"
+)
+
+// messageImageDir holds uploaded message images, one file per image ID.
+// Tests point it at a temporary directory.
+var messageImageDir = filepath.Join("uploads", "messages")
+
+// messageAttachmentRequest is one attachment in a message, reply or broadcast body.
+type messageAttachmentRequest struct {
+	Kind    string `json:"Kind"`    // clip, image or link
+	ClipID  string `json:"ClipID"`  // clip
+	ImageID string `json:"ImageID"` // image: from POST /api/messages/images
+	URL     string `json:"URL"`     // link
+	Title   string `json:"Title"`   // link: optional preview title
+}
+
+// messageImageUploadResponse is the response of POST /api/messages/images.
+type messageImageUploadResponse struct {
+	ImageID     string `json:"ImageID"`
+	ContentType string `json:"ContentType"`
+	Size        int64  `json:"Size"`
+}
+
+// resolveMessageAttachments checks requested attachments and copies clip details from the library.
+// PRE: none
+// POST: Returns the attachments to store, or a validation error
+func resolveMessageAttachments(ctx context.Context, reqs []messageAttachmentRequest) ([]messageDomain.Attachment, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+	inputs := make([]orchestrators.MessageAttachmentInput, len(reqs))
+	for i, a := range reqs {
+		inputs[i] = orchestrators.MessageAttachmentInput{Kind: a.Kind, ClipID: a.ClipID, ImageID: a.ImageID, URL: a.URL, Title: a.Title}
+	}
+	return orchestrators.ExecuteResolveMessageAttachments(ctx, inputs, orchestrators.ResolveMessageAttachmentsDeps{
+		ClipStore:   stores.ClipStore,
+		ImageExists: messageImageExists,
+	})
+}
+
+// messageImagePath returns where an uploaded message image is stored.
+// Image IDs are generated UUIDs; anything else is rejected so an ID can never escape the directory.
+func messageImagePath(imageID string) (string, bool) {
+	if imageID == "" || imageID != filepath.Base(imageID) || imageID == "." || imageID == ".." {
+		return "", false
+	}
+	return filepath.Join(messageImageDir, imageID), true
+}
+
+// messageImageExists reports whether an image with this ID has been uploaded.
+func messageImageExists(imageID string) bool {
+	path, ok := messageImagePath(imageID)
+	if !ok {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// handleMessageImageUpload handles POST /api/messages/images
+// Stores an image (multipart field "image") to attach to a message and returns its ID.
+// The type is sniffed from the file's bytes; only png, jpeg, webp and gif under 5 MB are kept.
+func handleMessageImageUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !isStaffSession(sess) {
+		apierror.Forbidden(w, orchestrators.ErrMemberAttachment.Error())
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "messages") {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, messageDomain.MaxImageBytes+1<<20)
+	if err := r.ParseMultipartForm(messageDomain.MaxImageBytes); err != nil {
+		apierror.Validation(w, "request too large or malformed")
+		return
+	}
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		apierror.Validation(w, "image is required")
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, messageDomain.MaxImageBytes+1))
+	if err != nil {
+		apierror.Validation(w, "could not read image")
+		return
+	}
+	contentType := http.DetectContentType(data)
+	if err := messageDomain.CheckImage(contentType, int64(len(data))); err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+
+	imageID := generateID()
+	path, _ := messageImagePath(imageID)
+	if err := os.MkdirAll(messageImageDir, 0o750); err != nil {
+		internalError(w, err)
+		return
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		internalError(w, err)
+		return
+	}
+	slog.InfoContext(r.Context(), "message_event", "event", "message_image_uploaded", "image_id", imageID, "sender_id", sess.AccountID, "content_type", contentType, "size", len(data))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(messageImageUploadResponse{ImageID: imageID, ContentType: contentType, Size: int64(len(data))})
+}
+
+// handleMessageImage handles GET /api/messages/image?message_id=&image_id=
+// Serves an image attached to a message to the people in its thread: staff, or the member it was sent to.
+func handleMessageImage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "messages") {
+		return
+	}
+	messageID, imageID := r.URL.Query().Get("message_id"), r.URL.Query().Get("image_id")
+	msg, err := stores.MessageStore.GetByID(ctx, messageID)
+	if err != nil || !msg.HasImage(imageID) || (!isStaffSession(sess) && msg.ReceiverID != sessionMemberID(ctx, sess)) {
+		apierror.NotFound(w, "image not found")
+		return
+	}
+	path, ok := messageImagePath(imageID)
+	if !ok {
+		apierror.NotFound(w, "image not found")
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		apierror.NotFound(w, "image not found")
+		return
+	}
+	w.Header().Set("Content-Type", http.DetectContentType(data))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.Write(data)
+}
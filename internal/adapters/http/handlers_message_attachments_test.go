@@ -0,0 +1,124 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/adapters/http/middleware"
+	clipDomain "workshop/internal/domain/clip"
+	memberDomain "workshop/internal/domain/member"
+	messageDomain "workshop/internal/domain/message"
+)
+
+// pngHeader is enough of a PNG for content sniffing.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// buildMessageImageUpload builds a multipart POST to /api/messages/images.
+func buildMessageImageUpload(t *testing.T, data []byte, sess middleware.Session) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	fw, err := w.CreateFormFile("image", "photo.png")
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	fw.Write(data)
+	w.Close()
+
+	req := authRequest("POST", "/api/messages/images", "", sess)
+	req.Body = io.NopCloser(body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.ContentLength = int64(body.Len())
+	return req
+}
+
+// TestHandleMessageAttachments verifies a coach can send a clip, an uploaded image and a link,
+// and only the member the message went to can load the image.
+func TestHandleMessageAttachments(t *testing.T) {
+	stores = newFullStores()
+	messageImageDir = t.TempDir()
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", AccountID: memberSession.AccountID, Name: "Marcus", Email: memberSession.Email, Program: "adults", Status: "active"})
+	stores.ClipStore.Save(ctx, clipDomain.Clip{ID: "c1", ThemeID: "t1", Title: "Armbar", YouTubeURL: "https://youtu.be/dQw4w9WgXcQ", YouTubeID: "dQw4w9WgXcQ", StartSeconds: 10, EndSeconds: 25})
+
+	tests := []struct {
+		name string
+		data []byte
+		sess middleware.Session
+		want int
+	}{
+		{"member", pngHeader, memberSession, http.StatusForbidden},
+		{"not an image", []byte("<svg onload=alert(1)>"), coachSession, http.StatusBadRequest},
+		{"too large", append(append([]byte{}, pngHeader...), make([]byte, messageDomain.MaxImageBytes)...), coachSession, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handleMessageImageUpload(rec, buildMessageImageUpload(t, tt.data, tt.sess))
+		if rec.Code != tt.want {
+			t.Errorf("upload %s: expected %d, got %d: %s", tt.name, tt.want, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handleMessageImageUpload(rec, buildMessageImageUpload(t, pngHeader, coachSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("upload: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var upload messageImageUploadResponse
+	json.NewDecoder(rec.Body).Decode(&upload)
+	if upload.ContentType != "image/png" {
+		t.Errorf("upload content type = %q", upload.ContentType)
+	}
+
+	body := fmt.Sprintf(`{"ReceiverID":"m1","Subject":"Homework","Content":"Drill these","Attachments":[
+		{"Kind":"clip","ClipID":"c1"},{"Kind":"image","ImageID":%q},{"Kind":"link","URL":"https://example.com/notes"}]}`, upload.ImageID)
+	rec = httptest.NewRecorder()
+	handleMessages(rec, authRequest("POST", "/api/messages", body, coachSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("send: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var msg messageDomain.Message
+	json.NewDecoder(rec.Body).Decode(&msg)
+	if len(msg.Attachments) != 3 || msg.Attachments[0].YouTubeID != "dQw4w9WgXcQ" || msg.Attachments[2].Title != "example.com" {
+		t.Fatalf("attachments = %+v", msg.Attachments)
+	}
+
+	imageURL := fmt.Sprintf("/api/messages/image?message_id=%s&image_id=%s", msg.ID, upload.ImageID)
+	rec = httptest.NewRecorder()
+	handleMessageImage(rec, authRequest("GET", imageURL, "", memberSession))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/png" {
+		t.Errorf("member image: got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	other := middleware.Session{AccountID: "member-002", Email: "other@test.com", Role: "member"}
+	rec = httptest.NewRecorder()
+	handleMessageImage(rec, authRequest("GET", imageURL, "", other))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("other member image: expected 404, got %d", rec.Code)
+	}
+
+	sendTests := []struct {
+		name    string
+		handler http.HandlerFunc
+		body    string
+		sess    middleware.Session
+		want    int
+	}{
+		{"image not uploaded", handleMessages, `{"ReceiverID":"m1","Content":"x","Attachments":[{"Kind":"image","ImageID":"../../etc/passwd"}]}`, coachSession, http.StatusBadRequest},
+		{"unknown clip", handleMessages, `{"ReceiverID":"m1","Content":"x","Attachments":[{"Kind":"clip","ClipID":"nope"}]}`, coachSession, http.StatusBadRequest},
+		{"member attaches", handleMessageReply, fmt.Sprintf(`{"ParentID":%q,"Content":"x","Attachments":[{"Kind":"link","URL":"https://example.com"}]}`, msg.ID), memberSession, http.StatusForbidden},
+	}
+	for _, tt := range sendTests {
+		rec := httptest.NewRecorder()
+		tt.handler(rec, authRequest("POST", "/api/messages", tt.body, tt.sess))
+		if rec.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	messageDomain "workshop/internal/domain/message"
+	notificationDomain "workshop/internal/domain/notification"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// isStaffSession reports whether the session is a coach or admin.
+// Staff are one side of every message thread; the member is the other.
+func isStaffSession(sess middleware.Session) bool {
+	return sess.Role == "admin" || sess.Role == "coach"
+}
+
+// sessionMemberID returns the member linked to the session's account, or "" if none.
+func sessionMemberID(ctx context.Context, sess middleware.Session) string {
+	m, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID)
+	if err != nil {
+		return ""
+	}
+	return m.ID
+}
+
+// handleMessageThreads handles GET /api/messages/threads?member_id=
+// Members always get their own threads. Staff get one member's threads, or without
+// member_id the threads that have unread member replies.
+func handleMessageThreads(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "messages") {
+		return
+	}
+
+	query := projections.GetMessageThreadsQuery{MemberID: r.URL.Query().Get("member_id")}
+	if !isStaffSession(sess) {
+		query.MemberID = sessionMemberID(ctx, sess)
+		query.ForMember = true
+		if query.MemberID == "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte("[]"))
+			return
+		}
+	}
+
+	threads, err := projections.QueryGetMessageThreads(ctx, query, projections.GetMessageThreadsDeps{MessageStore: stores.MessageStore})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(threads)
+}
+
+// handleMessageThread handles GET /api/messages/thread?id=
+// Returns the conversation oldest first. Members may only open their own threads.
+func handleMessageThread(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "messages") {
+		return
+	}
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		apierror.Validation(w, "id is required")
+		return
+	}
+
+	root, err := stores.MessageStore.GetByID(ctx, id)
+	if err != nil {
+		apierror.NotFound(w, "message not found")
+		return
+	}
+	if !isStaffSession(sess) && root.ReceiverID != sessionMemberID(ctx, sess) {
+		apierror.NotFound(w, "message not found")
+		return
+	}
+	messages, err := stores.MessageStore.ListByThreadID(ctx, root.ThreadID())
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"ThreadID": root.ThreadID(),
+		"MemberID": root.ReceiverID,
+		"Messages": messages,
+	})
+}
+
+// messageReplyRequest is the body of POST /api/messages/reply.
+type messageReplyRequest struct {
+	ParentID    string                     `json:"ParentID"`
+	Content     string                     `json:"Content"`
+	Attachments []messageAttachmentRequest `json:"Attachments"` // staff only
+}
+
+// handleMessageReply handles POST /api/messages/reply
+// Adds a reply to a thread and notifies the other side.
+func handleMessageReply(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "messages") {
+		return
+	}
+	var input messageReplyRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+
+	attachments, err := resolveMessageAttachments(ctx, input.Attachments)
+	if err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	replyInput := orchestrators.ReplyToMessageInput{ParentID: input.ParentID, SenderID: sess.AccountID, Content: input.Content, Attachments: attachments}
+	if !isStaffSession(sess) {
+		replyInput.MemberID = sessionMemberID(ctx, sess)
+		if replyInput.MemberID == "" {
+			apierror.Forbidden(w, "no member profile linked to this account")
+			return
+		}
+	}
+	reply, err := orchestrators.ExecuteReplyToMessage(ctx, replyInput, orchestrators.ReplyToMessageDeps{
+		MessageStore: stores.MessageStore,
+		GenerateID:   generateID,
+		Now:          timeNow,
+	})
+	switch {
+	case errors.Is(err, orchestrators.ErrMessageThreadNotFound), errors.Is(err, orchestrators.ErrMessageNotParticipant):
+		// Members learn nothing about threads that are not theirs.
+		apierror.NotFound(w, "message not found")
+		return
+	case errors.Is(err, orchestrators.ErrMemberAttachment):
+		apierror.Forbidden(w, err.Error())
+		return
+	case isMessageValidationError(err):
+		apierror.Validation(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+
+	notification := orchestrators.NotifyInput{
+		Kind:  notificationDomain.KindMessageReceived,
+		Title: "New reply",
+		Body:  reply.Subject,
+		Link:  "/messages",
+	}
+	if reply.FromMember {
+		if root, err := stores.MessageStore.GetByID(ctx, reply.ParentID); err == nil {
+			notification.AccountIDs = []string{root.SenderID}
+			notify(ctx, notification)
+		}
+	} else {
+		notifyMember(ctx, reply.ReceiverID, notification)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(reply)
+}
+
+// messageBroadcastRequest is the body of POST /api/messages/broadcast.
+type messageBroadcastRequest struct {
+	Program     string                     `json:"Program"`
+	Subject     string                     `json:"Subject"`
+	Content     string                     `json:"Content"`
+	Attachments []messageAttachmentRequest `json:"Attachments"`
+}
+
+// handleMessageBroadcast handles POST /api/messages/broadcast
+// Sends a message to every active member in a program; each member gets their own thread.
+func handleMessageBroadcast(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := requirePermission(w, r, permissionDomain.ActionMessagesBroadcast)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "messages") {
+		return
+	}
+	var input messageBroadcastRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+
+	attachments, err := resolveMessageAttachments(ctx, input.Attachments)
+	if err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	result, err := orchestrators.ExecuteBroadcastMessage(ctx, orchestrators.BroadcastMessageInput{
+		SenderID:    sess.AccountID,
+		Program:     input.Program,
+		Subject:     input.Subject,
+		Content:     input.Content,
+		Attachments: attachments,
+	}, orchestrators.BroadcastMessageDeps{
+		MemberStore:  stores.MemberStore,
+		MessageStore: stores.MessageStore,
+		GenerateID:   generateID,
+		Now:          timeNow,
+	})
+	switch {
+	case errors.Is(err, orchestrators.ErrBroadcastProgramNeeded), isMessageValidationError(err):
+		apierror.Validation(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+
+	notify(ctx, orchestrators.NotifyInput{
+		AccountIDs: result.AccountIDs,
+		Kind:       notificationDomain.KindMessageReceived,
+		Title:      "New message",
+		Body:       input.Subject,
+		Link:       "/messages",
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]int{"Sent": result.Sent})
+}
+
+// isMessageValidationError reports whether err came from Message.Validate or attachment resolution.
+func isMessageValidationError(err error) bool {
+	for _, target := range []error{
+		messageDomain.ErrEmptySenderID, messageDomain.ErrEmptyReceiverID, messageDomain.ErrEmptyContent,
+		messageDomain.ErrParentIsSelf, messageDomain.ErrSubjectTooLong, messageDomain.ErrContentTooLong,
+		messageDomain.ErrTooManyAttachments, messageDomain.ErrUnknownAttachmentKind, messageDomain.ErrInvalidClipAttachment,
+		messageDomain.ErrEmptyImageAttachment, messageDomain.ErrInvalidLinkURL, messageDomain.ErrLinkTitleTooLong,
+		orchestrators.ErrMessageClipNotFound, orchestrators.ErrMessageImageNotFound,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// markThreadRead marks the thread's messages addressed to the caller's side as read.
+// Members only read staff messages in their own threads; staff read member replies.
+func markThreadRead(w http.ResponseWriter, r *http.Request, sess middleware.Session, threadID string) {
+	ctx := r.Context()
+	staff := isStaffSession(sess)
+	messages, err := stores.MessageStore.ListByThreadID(ctx, threadID)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	if len(messages) == 0 || (!staff && messages[0].ReceiverID != sessionMemberID(ctx, sess)) {
+		apierror.NotFound(w, "message not found")
+		return
+	}
+	marked := 0
+	for _, m := range messages {
+		if !m.IsUnreadBy(!staff) {
+			continue
+		}
+		m.MarkRead()
+		if err := stores.MessageStore.Save(ctx, m); err != nil {
+			internalError(w, err)
+			return
+		}
+		marked++
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{"Marked": marked})
+}
@@ -0,0 +1,120 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/application/projections"
+	memberDomain "workshop/internal/domain/member"
+	messageDomain "workshop/internal/domain/message"
+)
+
+// seedMessageThread stores a member linked to memberSession and a coach thread to them.
+func seedMessageThread(t *testing.T) {
+	t.Helper()
+	stores = newFullStores()
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", AccountID: memberSession.AccountID, Name: "Marcus", Email: memberSession.Email, Program: "adults", Status: memberDomain.StatusActive})
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m2", Name: "Other", Email: "other@test.com", Program: "adults", Status: memberDomain.StatusActive})
+	now := time.Now()
+	stores.MessageStore.Save(ctx, messageDomain.Message{ID: "t1", SenderID: coachSession.AccountID, ReceiverID: "m1", Subject: "Comp prep", Content: "Ready?", CreatedAt: now.Add(-time.Hour)})
+	stores.MessageStore.Save(ctx, messageDomain.Message{ID: "t2", SenderID: coachSession.AccountID, ReceiverID: "m2", Subject: "Fees", Content: "Due", CreatedAt: now.Add(-time.Hour)})
+}
+
+// TestHandleMessageReply_MemberThenStaffSees verifies a member reply reaches the staff overview and clears once read.
+func TestHandleMessageReply_MemberThenStaffSees(t *testing.T) {
+	seedMessageThread(t)
+
+	rec := httptest.NewRecorder()
+	handleMessageReply(rec, authRequest("POST", "/api/messages/reply", `{"ParentID":"t1","Content":"Yes!"}`, memberSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var reply messageDomain.Message
+	json.NewDecoder(rec.Body).Decode(&reply)
+	if reply.ParentID != "t1" || !reply.FromMember || reply.ReceiverID != "m1" {
+		t.Errorf("unexpected reply: %+v", reply)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMessageThreads(rec, authRequest("GET", "/api/messages/threads", "", coachSession))
+	var threads []projections.MessageThread
+	json.NewDecoder(rec.Body).Decode(&threads)
+	if len(threads) != 1 || threads[0].ThreadID != "t1" || threads[0].Unread != 1 {
+		t.Fatalf("expected t1 with one unread reply, got %+v", threads)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMessageRead(rec, authRequest("POST", "/api/messages/read", `{"ThreadID":"t1"}`, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("mark read: expected 200, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleMessageThreads(rec, authRequest("GET", "/api/messages/threads", "", coachSession))
+	threads = nil
+	json.NewDecoder(rec.Body).Decode(&threads)
+	if len(threads) != 0 {
+		t.Errorf("expected no threads awaiting staff after reading, got %+v", threads)
+	}
+
+	// The member's own unread count only includes the coach's message.
+	count, _ := stores.MessageStore.CountUnread(context.Background(), "m1")
+	if count != 1 {
+		t.Errorf("expected member unread count 1, got %d", count)
+	}
+}
+
+// TestHandleMessageThread_MemberCannotOpenOthers verifies members only see their own conversations.
+func TestHandleMessageThread_MemberCannotOpenOthers(t *testing.T) {
+	seedMessageThread(t)
+
+	rec := httptest.NewRecorder()
+	handleMessageThread(rec, authRequest("GET", "/api/messages/thread?id=t2", "", memberSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("open: expected 404, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleMessageReply(rec, authRequest("POST", "/api/messages/reply", `{"ParentID":"t2","Content":"Hi"}`, memberSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("reply: expected 404, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMessageThread(rec, authRequest("GET", "/api/messages/thread?id=t1", "", memberSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("own thread: expected 200, got %d", rec.Code)
+	}
+}
+
+// TestHandleMessageBroadcast verifies only permitted roles can broadcast and each member gets a thread.
+func TestHandleMessageBroadcast(t *testing.T) {
+	seedMessageThread(t)
+	body := `{"Program":"adults","Subject":"Grading","Content":"Saturday 10am"}`
+
+	rec := httptest.NewRecorder()
+	handleMessageBroadcast(rec, authRequest("POST", "/api/messages/broadcast", body, memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMessageBroadcast(rec, authRequest("POST", "/api/messages/broadcast", body, coachSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("coach: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result struct{ Sent int }
+	json.NewDecoder(rec.Body).Decode(&result)
+	if result.Sent != 2 {
+		t.Errorf("expected 2 sent, got %d", result.Sent)
+	}
+
+	rec = httptest.NewRecorder()
+	handleMessageBroadcast(rec, authRequest("POST", "/api/messages/broadcast", `{"Program":"seniors","Content":"x"}`, coachSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("bad program: expected 400, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,191 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/perf"
+	"workshop/internal/application/orchestrators"
+	perfstatsDomain "workshop/internal/domain/perfstats"
+)
+
+// TestHandleMetrics_Token verifies the endpoint is hidden without a token and rejects wrong tokens.
+func TestHandleMetrics_Token(t *testing.T) {
+	stores = newFullStores()
+	defer func() { metricsToken = "" }()
+
+	metricsToken = ""
+	rec := httptest.NewRecorder()
+	handleMetrics(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("no token configured: expected 404, got %d", rec.Code)
+	}
+
+	metricsToken = "scrape-secret"
+	for _, header := range []string{"", "Bearer wrong", "scrape-secret"} {
+		req := httptest.NewRequest("GET", "/metrics", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec = httptest.NewRecorder()
+		handleMetrics(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: expected 401, got %d", header, rec.Code)
+		}
+	}
+}
+
+// TestHandleMetrics_Exposition verifies request, query and worker metrics are exposed.
+func TestHandleMetrics_Exposition(t *testing.T) {
+	stores = newFullStores()
+	metricsToken = "scrape-secret"
+	defer func() { metricsToken = "" }()
+	perfCollector = perf.NewCollector(100)
+	defer func() { perfCollector = nil }()
+	monitor := orchestrators.NewWorkerMonitor(time.Now)
+	monitor.Register("outbox", time.Minute)
+	SetWorkerMonitor(monitor)
+	defer SetWorkerMonitor(nil)
+
+	perfCollector.Record(perf.Entry{Kind: perf.KindRequest, Path: "GET /api/members/search", Method: "GET", Route: "/api/members/search", StatusCode: 200, DurationMs: 12})
+	perfCollector.Record(perf.Entry{Kind: perf.KindQuery, Path: "QueryContext", DurationMs: 3})
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer scrape-secret")
+	rec := httptest.NewRecorder()
+	handleMetrics(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`workshop_http_request_duration_seconds_bucket{method="GET",route="/api/members/search",code="200",le="0.025"} 1`,
+		`workshop_http_request_duration_seconds_count{method="GET",route="/api/members/search",code="200"} 1`,
+		`workshop_db_query_duration_seconds_bucket{op="QueryContext",le="0.005"} 1`,
+		`workshop_worker_healthy{worker="outbox"} 1`,
+		`# TYPE workshop_worker_lag_seconds gauge`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in:\n%s", want, body)
+		}
+	}
+}
+
+type mockPerfBucketStore struct {
+	buckets []perfstatsDomain.Bucket
+}
+
+// SaveBuckets implements perfstats.Store for testing.
+// PRE: none
+// POST: The buckets are appended
+func (m *mockPerfBucketStore) SaveBuckets(_ context.Context, buckets []perfstatsDomain.Bucket) error {
+	m.buckets = append(m.buckets, buckets...)
+	return nil
+}
+
+// ListBuckets implements perfstats.Store for testing.
+// PRE: none
+// POST: Returns the buckets starting in [since, until)
+func (m *mockPerfBucketStore) ListBuckets(_ context.Context, since, until time.Time) ([]perfstatsDomain.Bucket, error) {
+	var out []perfstatsDomain.Bucket
+	for _, b := range m.buckets {
+		if !b.Minute.Before(since) && b.Minute.Before(until) {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+// DeleteBefore implements perfstats.Store for testing.
+// PRE: none
+// POST: Buckets starting before cutoff are removed
+func (m *mockPerfBucketStore) DeleteBefore(_ context.Context, cutoff time.Time) (int, error) {
+	kept := m.buckets[:0]
+	for _, b := range m.buckets {
+		if !b.Minute.Before(cutoff) {
+			kept = append(kept, b)
+		}
+	}
+	removed := len(m.buckets) - len(kept)
+	m.buckets = kept
+	return removed, nil
+}
+
+// TestHandleAdminPerfHistory verifies saved buckets are summarised over the chosen window and
+// bad windows are rejected.
+func TestHandleAdminPerfHistory(t *testing.T) {
+	stores = newFullStores()
+	now := timeNow().UTC()
+	collector := perf.NewCollector(10)
+	collector.Record(perf.Entry{Kind: perf.KindRequest, Method: "GET", Route: "/api/members", DurationMs: 20, Timestamp: now.Add(-30 * time.Minute)})
+	collector.Record(perf.Entry{Kind: perf.KindRequest, Method: "GET", Route: "/api/members", DurationMs: 40, Timestamp: now.Add(-3 * time.Hour)})
+	collector.Record(perf.Entry{Kind: perf.KindQuery, Path: "QueryContext", DurationMs: 1, Timestamp: now.Add(-30 * time.Minute)})
+	stores.PerfBucketStore.SaveBuckets(context.Background(), collector.TakeBuckets(now))
+
+	rec := httptest.NewRecorder()
+	handleAdminPerfHistory(rec, authRequest("GET", "/api/admin/perf/history?window=1h", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var view perfHistoryView
+	json.NewDecoder(rec.Body).Decode(&view)
+	if view.Requests.Count != 1 || view.Queries.Count != 1 || len(view.Routes) != 1 || view.Routes[0].Name != "GET /api/members" {
+		t.Errorf("1h view = %+v, want only the request from half an hour ago", view)
+	}
+	if view.StepSeconds != 60 || len(view.Points) < 60 {
+		t.Errorf("1h view has %d points of %ds, want a point a minute", len(view.Points), view.StepSeconds)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAdminPerfHistory(rec, authRequest("GET", "/api/admin/perf/history?window=24h", "", adminSession))
+	json.NewDecoder(rec.Body).Decode(&view)
+	if view.Requests.Count != 2 || len(view.Points) > perfHistoryMaxPoints+1 {
+		t.Errorf("24h view = %d requests over %d points, want both requests", view.Requests.Count, len(view.Points))
+	}
+
+	for _, url := range []string{"/api/admin/perf/history?window=2y", "/api/admin/perf/history?from=2026-03-14T10:00:00Z&to=2026-03-14T09:00:00Z"} {
+		rec = httptest.NewRecorder()
+		handleAdminPerfHistory(rec, authRequest("GET", url, "", adminSession))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", url, rec.Code)
+		}
+	}
+	rec = httptest.NewRecorder()
+	handleAdminPerfHistory(rec, authRequest("GET", "/api/admin/perf/history", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleAdminPerfPage_SuggestedIndexes verifies full scans found by the query plan guard
+// are listed with their suggested index, and the section is hidden when there are none.
+func TestHandleAdminPerfPage_SuggestedIndexes(t *testing.T) {
+	stores = newFullStores()
+	perfCollector = perf.NewCollector(10)
+	defer func() { perfCollector = nil }()
+
+	render := func() string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handleAdminPerfPage(rec, authRequest("GET", "/admin/perf", "", adminSession))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+		return rec.Body.String()
+	}
+	if body := render(); strings.Contains(body, "Suggested Indexes") {
+		t.Error("expected no suggested indexes before the guard finds any")
+	}
+	perfCollector.RecordPlan(perf.QueryPlan{
+		Query: "SELECT id FROM member WHERE email = ?", Table: "member", Rows: 5000, Detail: "SCAN member",
+		Suggestion: "CREATE INDEX idx_member_email ON member(email);", Count: 1, MaxMs: 90,
+	})
+	if body := render(); !strings.Contains(body, "Suggested Indexes") || !strings.Contains(body, "CREATE INDEX idx_member_email ON member(email);") {
+		t.Error("expected the member scan with its suggested index")
+	}
+}
@@ -0,0 +1,137 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/projections"
+	clipDomain "workshop/internal/domain/clip"
+	gradingDomain "workshop/internal/domain/grading"
+	permissionDomain "workshop/internal/domain/permission"
+	searchDomain "workshop/internal/domain/search"
+)
+
+// clipMinBeltRequest is the body of POST /api/clips/min-belt.
+type clipMinBeltRequest struct {
+	ClipID  string `json:"ClipID"`
+	MinBelt string `json:"MinBelt"` // empty clears the gate
+}
+
+// themeMinBeltRequest is the body of POST /api/themes/min-belt.
+type themeMinBeltRequest struct {
+	ThemeID string `json:"ThemeID"`
+	MinBelt string `json:"MinBelt"` // empty clears the gate; also gates the theme's clips
+}
+
+// viewerBeltGate resolves which belt-gated content the session may open.
+func viewerBeltGate(ctx context.Context, sess middleware.Session) projections.BeltGate {
+	return projections.ResolveBeltGate(ctx, sess.Role, sess.AccountID, projections.ResolveBeltGateDeps{
+		MemberStore:        stores.MemberStore,
+		GradingRecordStore: stores.GradingRecordStore,
+	})
+}
+
+// gateClipsForViewer locks the clips the session has not reached, by the clip's own
+// minimum belt or its theme's.
+func gateClipsForViewer(ctx context.Context, sess middleware.Session, clips []clipDomain.Clip) ([]projections.GatedClip, error) {
+	gate := viewerBeltGate(ctx, sess)
+	themeMinBelts := map[string]string{}
+	if !gate.Staff {
+		themes, err := stores.ThemeStore.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range themes {
+			themeMinBelts[t.ID] = t.MinBelt
+		}
+	}
+	return projections.GateClips(clips, themeMinBelts, gate), nil
+}
+
+// validMinBelt reports whether minBelt is empty or a known belt.
+func validMinBelt(minBelt string) bool {
+	return minBelt == "" || gradingDomain.IsValidBelt(minBelt)
+}
+
+// handleClipMinBelt handles POST /api/clips/min-belt
+// Sets or clears the minimum belt needed to watch a clip.
+func handleClipMinBelt(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryEdit)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "library") {
+		return
+	}
+	var input clipMinBeltRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if !validMinBelt(input.MinBelt) {
+		apierror.Validation(w, "unknown belt")
+		return
+	}
+	ctx := r.Context()
+	clip, err := stores.ClipStore.GetByID(ctx, input.ClipID)
+	if err != nil {
+		apierror.NotFound(w, "clip not found")
+		return
+	}
+	clip.MinBelt = input.MinBelt
+	if err := stores.ClipStore.Save(ctx, clip); err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(clip)
+}
+
+// handleThemeMinBelt handles POST /api/themes/min-belt
+// Sets or clears the minimum belt needed to open a theme and its clips.
+func handleThemeMinBelt(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryEdit)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "library") {
+		return
+	}
+	var input themeMinBeltRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if !validMinBelt(input.MinBelt) {
+		apierror.Validation(w, "unknown belt")
+		return
+	}
+	ctx := r.Context()
+	theme, err := stores.ThemeStore.GetByID(ctx, input.ThemeID)
+	if err != nil {
+		apierror.NotFound(w, "theme not found")
+		return
+	}
+	theme.MinBelt = input.MinBelt
+	if err := stores.ThemeStore.Save(ctx, theme); err != nil {
+		internalError(w, err)
+		return
+	}
+	// The theme's gate changes who may find its clips in search.
+	if stores.SearchStore != nil {
+		stores.SearchStore.Reindex(ctx, searchDomain.KindClip, "")
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(theme)
+}
@@ -0,0 +1,92 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/projections"
+	clipDomain "workshop/internal/domain/clip"
+	gradingDomain "workshop/internal/domain/grading"
+	memberDomain "workshop/internal/domain/member"
+	themeDomain "workshop/internal/domain/theme"
+)
+
+// TestHandleClipMinBelt verifies coaches can gate a clip and a blue belt member then sees it
+// locked, without the video, while the coach still sees it open.
+func TestHandleClipMinBelt(t *testing.T) {
+	stores = newFullStores()
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", AccountID: memberSession.AccountID, Name: "Marcus", Email: "marcus@test.com", Program: "adults", Status: memberDomain.StatusActive})
+	stores.GradingRecordStore.Save(ctx, gradingDomain.Record{ID: "g1", MemberID: "m1", Belt: gradingDomain.BeltBlue, PromotedAt: time.Now()})
+	stores.ThemeStore.Save(ctx, themeDomain.Theme{ID: "t1", Name: "Leg Locks", Program: "adults"})
+	stores.ClipStore.Save(ctx, clipDomain.Clip{ID: "c1", ThemeID: "t1", Title: "Heel Hook", YouTubeID: "abcdefghijk", Notes: "Hide the heel"})
+
+	rec := httptest.NewRecorder()
+	handleClipMinBelt(rec, authRequest("POST", "/api/clips/min-belt", `{"ClipID":"c1","MinBelt":"rainbow"}`, coachSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown belt: expected 400, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleClipMinBelt(rec, authRequest("POST", "/api/clips/min-belt", `{"ClipID":"c1","MinBelt":"brown"}`, memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member: expected 403, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleClipMinBelt(rec, authRequest("POST", "/api/clips/min-belt", `{"ClipID":"c1","MinBelt":"brown"}`, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("coach: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	listAs := func(sess middleware.Session) projections.GatedClip {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handleClips(rec, authRequest("GET", "/api/clips?theme_id=t1", "", sess))
+		var clips []projections.GatedClip
+		json.NewDecoder(rec.Body).Decode(&clips)
+		if len(clips) != 1 {
+			t.Fatalf("clips = %+v, want one", clips)
+		}
+		return clips[0]
+	}
+	if c := listAs(memberSession); !c.Locked || c.YouTubeID != "" || c.Notes != "" || c.Title != "Heel Hook" {
+		t.Errorf("member sees %+v, want a locked placeholder", c)
+	}
+	if c := listAs(coachSession); c.Locked || c.YouTubeID == "" {
+		t.Errorf("coach sees %+v, want the clip open", c)
+	}
+}
+
+// TestHandleThemeMinBelt verifies a theme's minimum belt locks the theme and its clips.
+func TestHandleThemeMinBelt(t *testing.T) {
+	stores = newFullStores()
+	ctx := context.Background()
+	stores.ThemeStore.Save(ctx, themeDomain.Theme{ID: "t1", Name: "Leg Locks", Description: "Entries", Program: "adults"})
+	stores.ClipStore.Save(ctx, clipDomain.Clip{ID: "c1", ThemeID: "t1", Title: "Heel Hook", YouTubeID: "abcdefghijk"})
+
+	rec := httptest.NewRecorder()
+	handleThemeMinBelt(rec, authRequest("POST", "/api/themes/min-belt", `{"ThemeID":"t1","MinBelt":"purple"}`, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleThemes(rec, authRequest("GET", "/api/themes", "", memberSession))
+	var themes []projections.GatedTheme
+	json.NewDecoder(rec.Body).Decode(&themes)
+	if len(themes) != 1 || !themes[0].Locked || themes[0].Description != "" {
+		t.Errorf("themes = %+v, want Leg Locks locked", themes)
+	}
+
+	rec = httptest.NewRecorder()
+	handleClips(rec, authRequest("GET", "/api/clips?theme_id=t1", "", memberSession))
+	var clips []projections.GatedClip
+	json.NewDecoder(rec.Body).Decode(&clips)
+	if len(clips) != 1 || !clips[0].Locked || clips[0].YouTubeID != "" {
+		t.Errorf("clips = %+v, want the theme's clip locked", clips)
+	}
+}
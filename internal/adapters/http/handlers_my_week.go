@@ -0,0 +1,69 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/projections"
+)
+
+// handleMyWeek handles GET /api/me/week
+// Lists the signed-in member's likely classes over the next seven days, taken from the
+// classes they have been attending, with attire, mat, coach and the rotor's current topic.
+// An account without a member record gets an empty week.
+func handleMyWeek(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "training_log") {
+		return
+	}
+	ctx := r.Context()
+	now := timeNow()
+	m, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(projections.MyWeekResult{
+			From:    now.Format("2006-01-02"),
+			To:      now.AddDate(0, 0, projections.MyWeekDays-1).Format("2006-01-02"),
+			Classes: []projections.MyWeekClass{},
+		})
+		return
+	}
+
+	result, err := projections.QueryGetMyWeek(ctx, projections.GetMyWeekQuery{
+		MemberID: m.ID,
+		Gate:     viewerBeltGate(ctx, sess),
+		Now:      now,
+	}, projections.GetMyWeekDeps{
+		AttendanceStore: stores.AttendanceStore,
+		MemberStore:     stores.MemberStore,
+		Classes: projections.GetTodaysClassesDeps{
+			ScheduleStore:  stores.ScheduleStore,
+			TermStore:      stores.TermStore,
+			HolidayStore:   stores.HolidayStore,
+			ClassTypeStore: stores.ClassTypeStore,
+			ProgramStore:   stores.ProgramStore,
+			ChangeStore:    stores.OccurrenceChangeStore,
+		},
+		Curriculum: projections.GetThemeCarouselDeps{
+			ClassTypeStore: stores.ClassTypeStore,
+			ProgramStore:   stores.ProgramStore,
+			RotorStore:     stores.RotorStore,
+		},
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,59 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/application/projections"
+	attendanceDomain "workshop/internal/domain/attendance"
+	classTypeDomain "workshop/internal/domain/classtype"
+	memberDomain "workshop/internal/domain/member"
+	programDomain "workshop/internal/domain/program"
+	scheduleDomain "workshop/internal/domain/schedule"
+	termDomain "workshop/internal/domain/term"
+)
+
+// TestHandleMyWeek verifies a member sees the classes they keep attending with what to wear,
+// and an account without a member record gets an empty week.
+func TestHandleMyWeek(t *testing.T) {
+	stores = newFullStores()
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) // Monday
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", AccountID: memberSession.AccountID, Name: "Marcus", Email: memberSession.Email, Program: "adults", Status: memberDomain.StatusActive})
+	stores.ProgramStore.Save(ctx, programDomain.Program{ID: "p1", Name: "Adults", Type: "adults"})
+	stores.ClassTypeStore.Save(ctx, classTypeDomain.ClassType{ID: "ct1", ProgramID: "p1", Name: "No-Gi", Attire: classTypeDomain.AttireNoGi})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s1", ClassTypeID: "ct1", Day: "wednesday", StartTime: "18:00", EndTime: "19:00"})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s2", ClassTypeID: "ct1", Day: "friday", StartTime: "18:00", EndTime: "19:00"})
+	stores.TermStore.Save(ctx, termDomain.Term{ID: "t1", Name: "Term 1", StartDate: now.AddDate(0, -1, 0), EndDate: now.AddDate(0, 1, 0)})
+	for _, day := range []string{"2026-02-18", "2026-02-25"} {
+		checkIn, _ := time.Parse("2006-01-02", day)
+		stores.AttendanceStore.Save(ctx, attendanceDomain.Attendance{ID: "a" + day, MemberID: "m1", ScheduleID: "s1", ClassDate: day, CheckInTime: checkIn.Add(18 * time.Hour)})
+	}
+
+	week := func() (int, projections.MyWeekResult) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handleMyWeek(rec, authRequest("GET", "/api/me/week", "", memberSession))
+		var got projections.MyWeekResult
+		json.NewDecoder(rec.Body).Decode(&got)
+		return rec.Code, got
+	}
+	code, got := week()
+	if code != http.StatusOK || len(got.Classes) != 1 {
+		t.Fatalf("got %d %+v; want Wednesday's class", code, got)
+	}
+	if c := got.Classes[0]; c.ScheduleID != "s1" || c.Date != "2026-03-04" || c.Attire != classTypeDomain.AttireNoGi {
+		t.Errorf("class = %+v", c)
+	}
+
+	stores.MemberStore = newFullStores().MemberStore
+	if code, got := week(); code != http.StatusOK || len(got.Classes) != 0 || got.From != "2026-03-02" {
+		t.Errorf("no member record: got %d %+v; want an empty week", code, got)
+	}
+}
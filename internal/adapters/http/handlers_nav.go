@@ -0,0 +1,86 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/i18n"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/projections"
+)
+
+// handleNav handles GET /api/nav
+// Returns the global navigation for the current session: the links its role sees, less those
+// behind a disabled feature or a route it may not reach. The layout renders the same menu
+// whether or not the navigation feature is on.
+func handleNav(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "navigation") {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sessionNavigation(r, sess))
+}
+
+// sessionNavigation builds the menu for sess, with labels in the request's language.
+// Feature flags are read once and every link is checked against its route's policy, so
+// the menu never offers a page the session would be turned away from.
+func sessionNavigation(r *http.Request, sess middleware.Session) projections.Navigation {
+	ctx := r.Context()
+	flags := mergedFeatureFlagsByKey(ctx)
+	featureEnabled := func(key string) bool {
+		ff, ok := flags[key]
+		return !ok || ff.Evaluate(flagSubject(sess), timeNow()).Enabled
+	}
+	nav := projections.QueryGetNavigation(projections.GetNavigationQuery{
+		Role:     sess.Role,
+		RealRole: sess.RealRole,
+		Kiosk:    sessionInKiosk(r, sess),
+	}, projections.GetNavigationDeps{
+		FeatureEnabled: featureEnabled,
+		CanVisit: func(href string) bool {
+			policy, ok := routePolicies[href]
+			return ok && policy.allows(r, sess) && (policy.Feature == "" || featureEnabled(policy.Feature))
+		},
+	})
+
+	locale := i18n.FromContext(ctx)
+	translate := func(items []projections.NavItem) {
+		for i, item := range items {
+			if item.LabelKey != "" {
+				items[i].Label = i18n.T(locale, item.LabelKey)
+			}
+		}
+	}
+	translate(nav.Primary)
+	for _, g := range nav.More {
+		translate(g.Items)
+	}
+	return nav
+}
+
+// sessionInKiosk reports whether sess is running kiosk mode on a registered device.
+func sessionInKiosk(r *http.Request, sess middleware.Session) bool {
+	if stores == nil || stores.KioskDeviceStore == nil || sess.ID == "" {
+		return false
+	}
+	devices, err := stores.KioskDeviceStore.ListDevices(r.Context())
+	if err != nil {
+		return false
+	}
+	for _, d := range devices {
+		if d.AuthSessionID == sess.ID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/projections"
+	featureflagDomain "workshop/internal/domain/featureflag"
+	kioskDomain "workshop/internal/domain/kiosk"
+)
+
+// navHrefs lists every link in a menu, bar and More alike.
+func navHrefs(nav projections.Navigation) map[string]bool {
+	hrefs := map[string]bool{}
+	for _, i := range nav.Primary {
+		hrefs[i.Href] = true
+	}
+	for _, g := range nav.More {
+		for _, i := range g.Items {
+			hrefs[i.Href] = true
+		}
+	}
+	return hrefs
+}
+
+// getNav calls GET /api/nav as sess and decodes the menu.
+func getNav(t *testing.T, sess middleware.Session) projections.Navigation {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handleNav(rec, authRequest("GET", "/api/nav", "", sess))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /api/nav as %s: expected 200, got %d: %s", sess.Role, rec.Code, rec.Body.String())
+	}
+	var nav projections.Navigation
+	json.NewDecoder(rec.Body).Decode(&nav)
+	return nav
+}
+
+// TestHandleNav verifies each role only gets links its route policies let it follow, an
+// impersonating admin gets the impersonated role's menu, and a kiosk session only the kiosk.
+func TestHandleNav(t *testing.T) {
+	stores = newFullStores()
+
+	admin := navHrefs(getNav(t, adminSession))
+	for _, href := range []string{"/members", "/admin/grading", "/admin/schedules", "/admin/accounts", "/kiosk"} {
+		if !admin[href] {
+			t.Errorf("admin nav missing %s", href)
+		}
+	}
+
+	coach := navHrefs(getNav(t, coachSession))
+	for _, href := range []string{"/members", "/curriculum", "/session-logs", "/kiosk"} {
+		if !coach[href] {
+			t.Errorf("coach nav missing %s", href)
+		}
+	}
+	for _, href := range []string{"/admin/grading", "/admin/schedules", "/admin/notices", "/admin/accounts"} {
+		if coach[href] {
+			t.Errorf("coach nav has admin-only %s", href)
+		}
+	}
+
+	viewing := adminSession
+	viewing.Role, viewing.RealRole = "member", "admin"
+	nav := getNav(t, viewing)
+	if !nav.Impersonating || nav.RealRole != "admin" || len(nav.More) != 0 || !navHrefs(nav)["/training-log"] || navHrefs(nav)["/members"] {
+		t.Errorf("impersonated member nav = %+v", nav)
+	}
+	if nav.Primary[0].Label != "Training Log" {
+		t.Errorf("translated label = %q", nav.Primary[0].Label)
+	}
+
+	kioskSession := coachSession
+	kioskSession.ID = "auth-1"
+	stores.KioskDeviceStore = &mockKioskDeviceStore{devices: map[string]kioskDomain.Device{"d1": {ID: "d1", AuthSessionID: "auth-1"}}}
+	nav = getNav(t, kioskSession)
+	if hrefs := navHrefs(nav); !nav.Kiosk || len(hrefs) != 1 || !hrefs["/kiosk"] {
+		t.Errorf("kiosk nav = %+v", nav)
+	}
+
+	stores.FeatureFlagStore.Save(context.Background(), featureflagDomain.FeatureFlag{Key: "navigation", EnabledAdmin: true})
+	rec := httptest.NewRecorder()
+	handleNav(rec, authRequest("GET", "/api/nav", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("navigation off for members: expected 403, got %d", rec.Code)
+	}
+}
+
+// TestNavRegistry_HasRoutes verifies every page in the navigation is a registered route, so
+// its policy can decide who sees the link.
+func TestNavRegistry_HasRoutes(t *testing.T) {
+	for _, href := range projections.NavHrefs() {
+		if _, ok := routePolicies[href]; !ok {
+			t.Errorf("nav link %s has no route policy", href)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	noticeDomain "workshop/internal/domain/notice"
+	notificationDomain "workshop/internal/domain/notification"
+)
+
+// maxNotificationsListed caps how many notifications GET /api/notifications returns.
+const maxNotificationsListed = 50
+
+// handleNotifications handles GET /api/notifications
+// Returns the caller's most recent notifications and their unread count.
+func handleNotifications(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "notifications") {
+		return
+	}
+
+	limit := maxNotificationsListed
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			apierror.Validation(w, "limit must be a non-negative integer")
+			return
+		}
+		if n < limit {
+			limit = n
+		}
+	}
+
+	unread, err := stores.NotificationStore.CountUnread(ctx, sess.AccountID)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	list := []notificationDomain.Notification{}
+	if limit > 0 {
+		found, err := stores.NotificationStore.ListByAccountID(ctx, sess.AccountID, limit)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if found != nil {
+			list = found
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"Notifications": list,
+		"Unread":        unread,
+	})
+}
+
+// notificationsReadRequest is the body of POST /api/notifications/read; set ID or All.
+type notificationsReadRequest struct {
+	ID  string `json:"ID"`
+	All bool   `json:"All"`
+}
+
+// handleNotificationsRead handles POST /api/notifications/read
+// Marks one notification ({"ID": "..."}) or all of the caller's notifications ({"All": true}) as read.
+func handleNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "notifications") {
+		return
+	}
+
+	var input notificationsReadRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+
+	switch {
+	case input.All:
+		if err := stores.NotificationStore.MarkAllRead(ctx, sess.AccountID, timeNow()); err != nil {
+			internalError(w, err)
+			return
+		}
+	case input.ID != "":
+		n, err := stores.NotificationStore.GetByID(ctx, input.ID)
+		if err != nil || n.AccountID != sess.AccountID {
+			apierror.NotFound(w, "notification not found")
+			return
+		}
+		n.MarkRead(timeNow())
+		if err := stores.NotificationStore.Save(ctx, n); err != nil {
+			internalError(w, err)
+			return
+		}
+	default:
+		apierror.Validation(w, "ID or All is required")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// notificationPreferenceRequest is the body of PUT /api/notifications/preferences.
+type notificationPreferenceRequest struct {
+	Kind  string `json:"Kind"`
+	InApp bool   `json:"InApp"`
+	Email bool   `json:"Email"`
+	Push  bool   `json:"Push"`
+}
+
+// handleNotificationPreferences handles GET/PUT for /api/notifications/preferences
+// GET returns one preference per notification kind (defaults filled in); PUT saves one kind.
+func handleNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "notifications") {
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		stored, err := stores.NotificationStore.ListPreferences(ctx, sess.AccountID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(notificationDomain.ResolvePreferences(sess.AccountID, stored))
+
+	case "PUT":
+		var input notificationPreferenceRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		pref := notificationDomain.Preference{
+			AccountID: sess.AccountID,
+			Kind:      input.Kind,
+			InApp:     input.InApp,
+			Email:     input.Email,
+			Push:      input.Push,
+		}
+		if err := pref.Validate(); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err := stores.NotificationStore.SavePreference(ctx, pref); err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pref)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// notify fans a domain event out to recipients, and to their open pages as a live
+// event. Delivery is best-effort: failures are logged and never fail the request that
+// raised the event.
+func notify(ctx context.Context, input orchestrators.NotifyInput) {
+	if len(input.AccountIDs) == 0 {
+		return
+	}
+	publishNotification(input.AccountIDs, input.Kind, input.Title, input.Body, input.Link)
+	if stores.NotificationStore == nil {
+		return
+	}
+	_, err := orchestrators.ExecuteNotify(ctx, input, orchestrators.NotifyDeps{
+		NotificationStore: stores.NotificationStore,
+		AccountStore:      stores.AccountStore,
+		EmailSender:       emailSender,
+		EmailFrom:         emailFromAddress,
+		EmailReplyTo:      emailReplyTo,
+		PushStore:         stores.NotificationStore,
+		PushSender:        pushSender,
+		GenerateID:        generateID,
+		Now:               timeNow,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "notification_event", "event", "notify_failed", "kind", input.Kind, "error", err)
+	}
+}
+
+// notifyMember notifies the account linked to a member, if any.
+func notifyMember(ctx context.Context, memberID string, input orchestrators.NotifyInput) {
+	m, err := stores.MemberStore.GetByID(ctx, memberID)
+	if err != nil || m.AccountID == "" {
+		return
+	}
+	input.AccountIDs = []string{m.AccountID}
+	notify(ctx, input)
+}
+
+// notifyNoticePublished notifies the members a published notice is aimed at.
+func notifyNoticePublished(ctx context.Context, n noticeDomain.Notice) {
+	if stores.NotificationStore == nil {
+		return
+	}
+	recipients, err := orchestrators.ResolveNoticeAudience(ctx, n, orchestrators.NoticeAudienceDeps{
+		MemberStore:    stores.MemberStore,
+		ClassTypeStore: stores.ClassTypeStore,
+		ProgramStore:   stores.ProgramStore,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "notification_event", "event", "notice_audience_failed", "notice_id", n.ID, "error", err)
+		return
+	}
+	notify(ctx, orchestrators.NotifyInput{
+		AccountIDs: recipients,
+		Kind:       notificationDomain.KindNoticePublished,
+		Title:      n.Title,
+		Link:       "/dashboard",
+	})
+}
@@ -0,0 +1,337 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/webpush"
+	gradingDomain "workshop/internal/domain/grading"
+	memberDomain "workshop/internal/domain/member"
+	notificationDomain "workshop/internal/domain/notification"
+)
+
+// --- Mock Notification store ---
+
+type mockNotificationStore struct {
+	items map[string]notificationDomain.Notification
+	prefs map[string]notificationDomain.Preference
+	subs  map[string]notificationDomain.PushSubscription // by endpoint
+}
+
+// GetByID implements notification.Store for testing.
+// PRE: id is non-empty
+// POST: returns the notification or an error if not found
+func (m *mockNotificationStore) GetByID(_ context.Context, id string) (notificationDomain.Notification, error) {
+	if n, ok := m.items[id]; ok {
+		return n, nil
+	}
+	return notificationDomain.Notification{}, fmt.Errorf("not found: %s", id)
+}
+
+// Save implements notification.Store for testing.
+// PRE: notification has a valid ID
+// POST: notification is stored in memory
+func (m *mockNotificationStore) Save(_ context.Context, n notificationDomain.Notification) error {
+	m.items[n.ID] = n
+	return nil
+}
+
+// ListByAccountID implements notification.Store for testing.
+// PRE: accountID is non-empty
+// POST: returns the account's notifications, newest first
+func (m *mockNotificationStore) ListByAccountID(_ context.Context, accountID string, limit int) ([]notificationDomain.Notification, error) {
+	var out []notificationDomain.Notification
+	for _, n := range m.items {
+		if n.AccountID == accountID {
+			out = append(out, n)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// CountUnread implements notification.Store for testing.
+// PRE: accountID is non-empty
+// POST: returns the number of unread notifications
+func (m *mockNotificationStore) CountUnread(_ context.Context, accountID string) (int, error) {
+	count := 0
+	for _, n := range m.items {
+		if n.AccountID == accountID && !n.IsRead() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// MarkAllRead implements notification.Store for testing.
+// PRE: accountID is non-empty
+// POST: all of the account's notifications are read
+func (m *mockNotificationStore) MarkAllRead(_ context.Context, accountID string, readAt time.Time) error {
+	for id, n := range m.items {
+		if n.AccountID == accountID {
+			n.MarkRead(readAt)
+			m.items[id] = n
+		}
+	}
+	return nil
+}
+
+// ListPreferences implements notification.Store for testing.
+// PRE: accountID is non-empty
+// POST: returns stored preferences for the account
+func (m *mockNotificationStore) ListPreferences(_ context.Context, accountID string) ([]notificationDomain.Preference, error) {
+	var out []notificationDomain.Preference
+	for _, p := range m.prefs {
+		if p.AccountID == accountID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// SavePreference implements notification.Store for testing.
+// PRE: preference is valid
+// POST: preference is stored in memory
+func (m *mockNotificationStore) SavePreference(_ context.Context, p notificationDomain.Preference) error {
+	m.prefs[p.AccountID+"|"+p.Kind] = p
+	return nil
+}
+
+// SavePushSubscription implements notification.Store for testing.
+// PRE: subscription is valid
+// POST: subscription is stored by endpoint
+func (m *mockNotificationStore) SavePushSubscription(_ context.Context, sub notificationDomain.PushSubscription) error {
+	m.subs[sub.Endpoint] = sub
+	return nil
+}
+
+// ListPushSubscriptions implements notification.Store for testing.
+// PRE: accountID is non-empty
+// POST: returns the account's subscriptions
+func (m *mockNotificationStore) ListPushSubscriptions(_ context.Context, accountID string) ([]notificationDomain.PushSubscription, error) {
+	var out []notificationDomain.PushSubscription
+	for _, sub := range m.subs {
+		if sub.AccountID == accountID {
+			out = append(out, sub)
+		}
+	}
+	return out, nil
+}
+
+// DeletePushSubscription implements notification.Store for testing.
+// PRE: endpoint is non-empty
+// POST: the subscription is removed
+func (m *mockNotificationStore) DeletePushSubscription(_ context.Context, endpoint string) error {
+	delete(m.subs, endpoint)
+	return nil
+}
+
+// MarkClassReminderSent implements notification.Store for testing.
+// PRE: keys are non-empty
+// POST: always reports a first send
+func (m *mockNotificationStore) MarkClassReminderSent(_ context.Context, _, _, _ string, _ time.Time) (bool, error) {
+	return true, nil
+}
+
+// MarkCapacityAlertSent implements notification.Store for testing.
+// PRE: keys are non-empty
+// POST: always reports a first alert
+func (m *mockNotificationStore) MarkCapacityAlertSent(_ context.Context, _, _ string, _ int, _ time.Time) (bool, error) {
+	return true, nil
+}
+
+// DeleteClassRemindersBefore implements notification.Store for testing.
+// PRE: classDate is YYYY-MM-DD
+// POST: no-op
+func (m *mockNotificationStore) DeleteClassRemindersBefore(_ context.Context, _ string) error {
+	return nil
+}
+
+func newNotificationTestStores() *Stores {
+	s := newFullStores()
+	s.NotificationStore = &mockNotificationStore{
+		items: make(map[string]notificationDomain.Notification),
+		prefs: make(map[string]notificationDomain.Preference),
+		subs:  make(map[string]notificationDomain.PushSubscription),
+	}
+	s.MemberStore.Save(context.Background(), memberDomain.Member{
+		ID: "member-001", AccountID: memberSession.AccountID, Name: "Member", Email: memberSession.Email,
+		Program: "adults", Status: memberDomain.StatusActive,
+	})
+	return s
+}
+
+func fetchNotifications(t *testing.T) (list []notificationDomain.Notification, unread int) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handleNotifications(rec, authRequest("GET", "/api/notifications", "", memberSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		Notifications []notificationDomain.Notification
+		Unread        int
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return body.Notifications, body.Unread
+}
+
+// TestNotifications_MessageAndGradingEvents verifies domain events create notifications and mark-all-read clears them.
+func TestNotifications_MessageAndGradingEvents(t *testing.T) {
+	stores = newNotificationTestStores()
+	ctx := context.Background()
+
+	rec := httptest.NewRecorder()
+	handleMessages(rec, authRequest("POST", "/api/messages", `{"ReceiverID":"member-001","Subject":"Hi","Content":"See you Monday"}`, adminSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", rec.Code)
+	}
+
+	stores.GradingProposalStore.Save(ctx, gradingDomain.Proposal{
+		ID: "p1", MemberID: "member-001", TargetBelt: gradingDomain.BeltBlue,
+		ProposedBy: "coach-001", Status: gradingDomain.ProposalPending, CreatedAt: time.Now(),
+	})
+	rec = httptest.NewRecorder()
+	handleGradingDecide(rec, authRequest("POST", "/api/grading/proposals/decide", `{"ProposalID":"p1","Decision":"approve"}`, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	list, unread := fetchNotifications(t)
+	if len(list) != 2 || unread != 2 {
+		t.Fatalf("expected 2 unread notifications, got %d (%d unread)", len(list), unread)
+	}
+
+	rec = httptest.NewRecorder()
+	handleNotificationsRead(rec, authRequest("POST", "/api/notifications/read", `{"All":true}`, memberSession))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rec.Code)
+	}
+	if _, unread := fetchNotifications(t); unread != 0 {
+		t.Errorf("expected 0 unread after mark-all-read, got %d", unread)
+	}
+}
+
+// TestNotifications_PreferenceDisablesInApp verifies a member can turn off in-app notifications per kind.
+func TestNotifications_PreferenceDisablesInApp(t *testing.T) {
+	stores = newNotificationTestStores()
+
+	rec := httptest.NewRecorder()
+	handleNotificationPreferences(rec, authRequest("PUT", "/api/notifications/preferences", `{"Kind":"message_received","InApp":false,"Email":false}`, memberSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleMessages(rec, authRequest("POST", "/api/messages", `{"ReceiverID":"member-001","Content":"Quiet"}`, adminSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", rec.Code)
+	}
+	if list, _ := fetchNotifications(t); len(list) != 0 {
+		t.Errorf("expected no in-app notification, got %d", len(list))
+	}
+
+	rec = httptest.NewRecorder()
+	handleNotificationPreferences(rec, authRequest("PUT", "/api/notifications/preferences", `{"Kind":"birthday","InApp":true}`, memberSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for unknown kind, got %d", rec.Code)
+	}
+}
+
+// TestNotificationsRead_OtherAccountNotFound verifies members cannot mark someone else's notification.
+func TestNotificationsRead_OtherAccountNotFound(t *testing.T) {
+	stores = newNotificationTestStores()
+	stores.NotificationStore.Save(context.Background(), notificationDomain.Notification{
+		ID: "n1", AccountID: "someone-else", Kind: notificationDomain.KindMessageReceived, Title: "x", CreatedAt: time.Now(),
+	})
+
+	rec := httptest.NewRecorder()
+	handleNotificationsRead(rec, authRequest("POST", "/api/notifications/read", `{"ID":"n1"}`, memberSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+}
+
+type recordingPushSender struct {
+	sent []webpush.Subscription
+}
+
+// Send implements webpush.Sender for testing.
+// PRE: none
+// POST: records the subscription pushed to
+func (r *recordingPushSender) Send(_ context.Context, sub webpush.Subscription, _ webpush.Message) error {
+	r.sent = append(r.sent, sub)
+	return nil
+}
+
+// TestPushSubscriptions verifies a member can register a browser, receives pushes for kinds
+// they opted into, and cannot unsubscribe someone else's browser.
+func TestPushSubscriptions(t *testing.T) {
+	stores = newNotificationTestStores()
+	t.Cleanup(func() { SetPushSender(nil, "") })
+
+	rec := httptest.NewRecorder()
+	handlePushKey(rec, authRequest("GET", "/api/push/key", "", memberSession))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 without a VAPID key, got %d", rec.Code)
+	}
+
+	sender := &recordingPushSender{}
+	SetPushSender(sender, "BPublicKey")
+	rec = httptest.NewRecorder()
+	handlePushKey(rec, authRequest("GET", "/api/push/key", "", memberSession))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "BPublicKey") {
+		t.Fatalf("expected the public key, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handlePushSubscriptions(rec, authRequest("POST", "/api/push/subscriptions", `{"Endpoint":"http://push.example.com/1","P256dh":"k","Auth":"a"}`, memberSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a non-https endpoint, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handlePushSubscriptions(rec, authRequest("POST", "/api/push/subscriptions", `{"Endpoint":"https://push.example.com/1","P256dh":"k","Auth":"a"}`, memberSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleNotificationPreferences(rec, authRequest("PUT", "/api/notifications/preferences", `{"Kind":"message_received","InApp":true,"Push":true}`, memberSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	handleMessages(rec, authRequest("POST", "/api/messages", `{"ReceiverID":"member-001","Content":"See you tonight"}`, adminSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", rec.Code)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].Endpoint != "https://push.example.com/1" {
+		t.Errorf("expected one push to the member's browser, got %+v", sender.sent)
+	}
+
+	rec = httptest.NewRecorder()
+	handlePushSubscriptions(rec, authRequest("DELETE", "/api/push/subscriptions", `{"Endpoint":"https://push.example.com/1"}`, adminSession))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rec.Code)
+	}
+	if subs, _ := stores.NotificationStore.ListPushSubscriptions(context.Background(), memberSession.AccountID); len(subs) != 1 {
+		t.Errorf("another account removed the member's subscription")
+	}
+	rec = httptest.NewRecorder()
+	handlePushSubscriptions(rec, authRequest("DELETE", "/api/push/subscriptions", `{"Endpoint":"https://push.example.com/1"}`, memberSession))
+	if subs, _ := stores.NotificationStore.ListPushSubscriptions(context.Background(), memberSession.AccountID); rec.Code != http.StatusNoContent || len(subs) != 0 {
+		t.Errorf("expected the subscription removed, got %d and %d left", rec.Code, len(subs))
+	}
+}
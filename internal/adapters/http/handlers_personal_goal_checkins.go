@@ -0,0 +1,241 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	permissionDomain "workshop/internal/domain/permission"
+	domain "workshop/internal/domain/personalgoal"
+)
+
+// handlePersonalGoalFeedback handles GET /api/personal-goals/feedback
+// Without member_id, returns the caller's goals with their check-ins and coach annotations.
+// With ?member_id=, returns that member's; coaches and admins only.
+func handlePersonalGoalFeedback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "calendar") {
+		return
+	}
+
+	memberID := r.URL.Query().Get("member_id")
+	if memberID == "" {
+		member, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID)
+		if err != nil {
+			apierror.NotFound(w, "member not found")
+			return
+		}
+		memberID = member.ID
+	} else if !permissionAllowed(ctx, sess, permissionDomain.ActionGoalsCoach) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+
+	feedback, err := projections.QueryGetPersonalGoalFeedback(ctx, projections.GetPersonalGoalFeedbackQuery{
+		MemberID: memberID,
+		Now:      timeNow(),
+	}, projections.GetPersonalGoalFeedbackDeps{
+		GoalStore:       stores.PersonalGoalStore,
+		CheckInStore:    stores.GoalCheckInStore,
+		AnnotationStore: stores.GoalAnnotationStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(feedback)
+}
+
+// handlePersonalGoalCheckInsDue handles GET /api/personal-goals/check-ins/due
+// Returns the caller's goals that are waiting for a check-in.
+func handlePersonalGoalCheckInsDue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "calendar") {
+		return
+	}
+	member, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID)
+	if err != nil {
+		apierror.NotFound(w, "member not found")
+		return
+	}
+
+	due, err := projections.QueryGetGoalCheckInsDue(ctx, member.ID, timeNow(), stores.PersonalGoalStore)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(due)
+}
+
+// personalGoalCheckInRequest is the body of POST /api/personal-goals/check-ins.
+type personalGoalCheckInRequest struct {
+	GoalID   string `json:"goal_id"`
+	Progress int    `json:"progress"` // ignored for hours and sessions goals
+	Status   string `json:"status"`   // on_track, at_risk or off_track
+	Note     string `json:"note"`
+}
+
+// handlePersonalGoalCheckIns handles POST /api/personal-goals/check-ins
+// Records the caller's check-in on one of their goals; reaching the target completes the goal.
+func handlePersonalGoalCheckIns(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "calendar") {
+		return
+	}
+
+	var input personalGoalCheckInRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	member, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID)
+	if err != nil {
+		apierror.NotFound(w, "member not found")
+		return
+	}
+	if input.GoalID == "" {
+		apierror.Validation(w, "goal_id is required")
+		return
+	}
+	if _, err := stores.PersonalGoalStore.GetByID(ctx, input.GoalID); err != nil {
+		apierror.NotFound(w, "goal not found")
+		return
+	}
+
+	result, err := orchestrators.ExecuteGoalCheckIn(ctx, orchestrators.GoalCheckInInput{
+		GoalID:   input.GoalID,
+		MemberID: member.ID,
+		Progress: input.Progress,
+		Status:   input.Status,
+		Note:     input.Note,
+	}, orchestrators.GoalCheckInDeps{
+		GoalStore:       stores.PersonalGoalStore,
+		CheckInStore:    stores.GoalCheckInStore,
+		AttendanceStore: stores.AttendanceStore,
+		GenerateID:      generateID,
+		Now:             timeNow,
+	})
+	switch {
+	case errors.Is(err, orchestrators.ErrGoalNotOwned):
+		apierror.Forbidden(w, "forbidden")
+		return
+	case errors.Is(err, domain.ErrGoalCompleted):
+		apierror.Conflict(w, err.Error())
+		return
+	case errors.Is(err, domain.ErrInvalidCheckInState), errors.Is(err, domain.ErrNegativeProgress), errors.Is(err, domain.ErrNoteTooLong):
+		apierror.Validation(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(result)
+}
+
+// personalGoalAnnotationRequest is the body of POST /api/personal-goals/annotations.
+type personalGoalAnnotationRequest struct {
+	GoalID  string `json:"goal_id"`
+	Content string `json:"content"`
+}
+
+// handlePersonalGoalAnnotations handles POST /api/personal-goals/annotations
+// Adds a coach's comment to a member's goal. Coaches and admins only.
+func handlePersonalGoalAnnotations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "calendar") {
+		return
+	}
+	if !permissionAllowed(ctx, sess, permissionDomain.ActionGoalsCoach) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+
+	var input personalGoalAnnotationRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if input.GoalID == "" {
+		apierror.Validation(w, "goal_id is required")
+		return
+	}
+	if _, err := stores.PersonalGoalStore.GetByID(ctx, input.GoalID); err != nil {
+		apierror.NotFound(w, "goal not found")
+		return
+	}
+
+	// Members see the coach's name; fall back to the login email for staff without a member record.
+	authorName := sess.Email
+	if author, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID); err == nil && author.Name != "" {
+		authorName = author.Name
+	}
+
+	annotation, err := orchestrators.ExecuteAnnotateGoal(ctx, orchestrators.AnnotateGoalInput{
+		GoalID:     input.GoalID,
+		AuthorID:   sess.AccountID,
+		AuthorName: authorName,
+		Content:    input.Content,
+	}, orchestrators.AnnotateGoalDeps{
+		GoalStore:       stores.PersonalGoalStore,
+		AnnotationStore: stores.GoalAnnotationStore,
+		GenerateID:      generateID,
+		Now:             timeNow,
+	})
+	switch {
+	case errors.Is(err, domain.ErrEmptyContent), errors.Is(err, domain.ErrNoteTooLong):
+		apierror.Validation(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(annotation)
+}
@@ -0,0 +1,232 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	memberDomain "workshop/internal/domain/member"
+	personalGoalDomain "workshop/internal/domain/personalgoal"
+)
+
+type mockPersonalGoalStore struct {
+	goals map[string]personalGoalDomain.PersonalGoal
+}
+
+// GetByID implements personalgoal.Store for testing.
+// PRE: id is non-empty
+// POST: Returns the goal or sql.ErrNoRows
+func (m *mockPersonalGoalStore) GetByID(_ context.Context, id string) (personalGoalDomain.PersonalGoal, error) {
+	g, ok := m.goals[id]
+	if !ok {
+		return personalGoalDomain.PersonalGoal{}, sql.ErrNoRows
+	}
+	return g, nil
+}
+
+// Save implements personalgoal.Store for testing.
+// PRE: goal has been validated
+// POST: Goal is upserted
+func (m *mockPersonalGoalStore) Save(_ context.Context, goal personalGoalDomain.PersonalGoal) error {
+	m.goals[goal.ID] = goal
+	return nil
+}
+
+// Delete implements personalgoal.Store for testing.
+// PRE: id is non-empty
+// POST: Goal is removed
+func (m *mockPersonalGoalStore) Delete(_ context.Context, id string) error {
+	delete(m.goals, id)
+	return nil
+}
+
+// ListByMemberID implements personalgoal.Store for testing.
+// PRE: memberID is non-empty
+// POST: Returns the member's goals
+func (m *mockPersonalGoalStore) ListByMemberID(_ context.Context, memberID string) ([]personalGoalDomain.PersonalGoal, error) {
+	var list []personalGoalDomain.PersonalGoal
+	for _, g := range m.goals {
+		if g.MemberID == memberID {
+			list = append(list, g)
+		}
+	}
+	return list, nil
+}
+
+// ListByDateRange implements personalgoal.Store for testing.
+// PRE: from and to are YYYY-MM-DD
+// POST: Returns the member's goals overlapping the range
+func (m *mockPersonalGoalStore) ListByDateRange(ctx context.Context, memberID, from, to string) ([]personalGoalDomain.PersonalGoal, error) {
+	all, _ := m.ListByMemberID(ctx, memberID)
+	var list []personalGoalDomain.PersonalGoal
+	for _, g := range all {
+		if g.StartDate.Format("2006-01-02") <= to && g.EndDate.Format("2006-01-02") >= from {
+			list = append(list, g)
+		}
+	}
+	return list, nil
+}
+
+// ListActive implements personalgoal.Store for testing.
+// PRE: none
+// POST: Returns goals not yet completed
+func (m *mockPersonalGoalStore) ListActive(_ context.Context) ([]personalGoalDomain.PersonalGoal, error) {
+	var list []personalGoalDomain.PersonalGoal
+	for _, g := range m.goals {
+		if !g.IsCompleted() {
+			list = append(list, g)
+		}
+	}
+	return list, nil
+}
+
+type mockGoalCheckInStore struct {
+	checkIns []personalGoalDomain.CheckIn
+}
+
+// Save implements personalgoal.CheckInStore for testing.
+// PRE: value has been validated
+// POST: Check-in is appended
+func (m *mockGoalCheckInStore) Save(_ context.Context, value personalGoalDomain.CheckIn) error {
+	m.checkIns = append(m.checkIns, value)
+	return nil
+}
+
+// ListByGoalID implements personalgoal.CheckInStore for testing.
+// PRE: goalID is non-empty
+// POST: Returns the goal's check-ins
+func (m *mockGoalCheckInStore) ListByGoalID(_ context.Context, goalID string) ([]personalGoalDomain.CheckIn, error) {
+	var list []personalGoalDomain.CheckIn
+	for _, c := range m.checkIns {
+		if c.GoalID == goalID {
+			list = append(list, c)
+		}
+	}
+	return list, nil
+}
+
+type mockGoalAnnotationStore struct {
+	annotations []personalGoalDomain.Annotation
+}
+
+// Save implements personalgoal.AnnotationStore for testing.
+// PRE: value has been validated
+// POST: Annotation is appended
+func (m *mockGoalAnnotationStore) Save(_ context.Context, value personalGoalDomain.Annotation) error {
+	m.annotations = append(m.annotations, value)
+	return nil
+}
+
+// ListByGoalID implements personalgoal.AnnotationStore for testing.
+// PRE: goalID is non-empty
+// POST: Returns the goal's annotations
+func (m *mockGoalAnnotationStore) ListByGoalID(_ context.Context, goalID string) ([]personalGoalDomain.Annotation, error) {
+	var list []personalGoalDomain.Annotation
+	for _, a := range m.annotations {
+		if a.GoalID == goalID {
+			list = append(list, a)
+		}
+	}
+	return list, nil
+}
+
+// setupPersonalGoalStores gives the member session a member record and one manual goal that is due a check-in.
+func setupPersonalGoalStores() {
+	stores = newFullStores()
+	stores.PersonalGoalStore = &mockPersonalGoalStore{goals: map[string]personalGoalDomain.PersonalGoal{}}
+	stores.GoalCheckInStore = &mockGoalCheckInStore{}
+	stores.GoalAnnotationStore = &mockGoalAnnotationStore{}
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", AccountID: memberSession.AccountID, Name: "Marcus", Email: memberSession.Email, Program: "adults", Status: "active"})
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "coach-m", AccountID: coachSession.AccountID, Name: "Coach Sam", Email: coachSession.Email, Program: "adults", Status: "active"})
+	now := time.Now()
+	stores.PersonalGoalStore.Save(ctx, personalGoalDomain.PersonalGoal{
+		ID: "g1", MemberID: "m1", Title: "50 armbars", Target: 50, Unit: "armbars", Type: personalGoalDomain.TypeManual,
+		StartDate: now.AddDate(0, 0, -10), EndDate: now.AddDate(0, 0, 20), Status: personalGoalDomain.StatusActive,
+	})
+}
+
+// TestHandlePersonalGoalCheckIns verifies the check-in loop: a due prompt, the member's check-in, a coach annotation, and the combined feedback.
+func TestHandlePersonalGoalCheckIns(t *testing.T) {
+	setupPersonalGoalStores()
+
+	rec := httptest.NewRecorder()
+	handlePersonalGoalCheckInsDue(rec, authRequest("GET", "/api/personal-goals/check-ins/due", "", memberSession))
+	var due []personalGoalDomain.PersonalGoal
+	json.NewDecoder(rec.Body).Decode(&due)
+	if rec.Code != http.StatusOK || len(due) != 1 || due[0].ID != "g1" {
+		t.Fatalf("due: expected g1, got %d %+v", rec.Code, due)
+	}
+
+	rec = httptest.NewRecorder()
+	handlePersonalGoalCheckIns(rec, authRequest("POST", "/api/personal-goals/check-ins",
+		`{"goal_id":"g1","progress":50,"status":"on_track","note":"Done early"}`, memberSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("check-in: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result orchestrators.GoalCheckInResult
+	json.NewDecoder(rec.Body).Decode(&result)
+	if result.Goal.Status != personalGoalDomain.StatusCompleted || result.CheckIn.Note != "Done early" {
+		t.Errorf("expected the goal completed by reaching its target, got %+v", result)
+	}
+
+	rec = httptest.NewRecorder()
+	handlePersonalGoalAnnotations(rec, authRequest("POST", "/api/personal-goals/annotations",
+		`{"goal_id":"g1","content":"Great work. Next: triangles."}`, coachSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("annotate: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handlePersonalGoalFeedback(rec, authRequest("GET", "/api/personal-goals/feedback?member_id=m1", "", coachSession))
+	var feedback []projections.PersonalGoalFeedback
+	json.NewDecoder(rec.Body).Decode(&feedback)
+	if rec.Code != http.StatusOK || len(feedback) != 1 || len(feedback[0].CheckIns) != 1 || len(feedback[0].Annotations) != 1 {
+		t.Fatalf("feedback: expected one check-in and one annotation, got %d %+v", rec.Code, feedback)
+	}
+	if feedback[0].Annotations[0].AuthorName != "Coach Sam" || feedback[0].CheckInDue {
+		t.Errorf("expected the coach named and no check-in due on a completed goal, got %+v", feedback[0])
+	}
+}
+
+// TestHandlePersonalGoalCheckIns_Errors verifies ownership, permission and validation.
+func TestHandlePersonalGoalCheckIns_Errors(t *testing.T) {
+	setupPersonalGoalStores()
+	stores.PersonalGoalStore.Save(context.Background(), personalGoalDomain.PersonalGoal{
+		ID: "other", MemberID: "m2", Title: "Not yours", Target: 5, StartDate: time.Now(), EndDate: time.Now().AddDate(0, 1, 0),
+	})
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		method  string
+		url     string
+		body    string
+		sess    middleware.Session
+		want    int
+	}{
+		{"someone else's goal", handlePersonalGoalCheckIns, "POST", "/api/personal-goals/check-ins", `{"goal_id":"other","status":"on_track"}`, memberSession, http.StatusForbidden},
+		{"unknown goal", handlePersonalGoalCheckIns, "POST", "/api/personal-goals/check-ins", `{"goal_id":"nope","status":"on_track"}`, memberSession, http.StatusNotFound},
+		{"bad status", handlePersonalGoalCheckIns, "POST", "/api/personal-goals/check-ins", `{"goal_id":"g1","status":"meh"}`, memberSession, http.StatusBadRequest},
+		{"member annotating", handlePersonalGoalAnnotations, "POST", "/api/personal-goals/annotations", `{"goal_id":"g1","content":"x"}`, memberSession, http.StatusForbidden},
+		{"empty annotation", handlePersonalGoalAnnotations, "POST", "/api/personal-goals/annotations", `{"goal_id":"g1","content":" "}`, coachSession, http.StatusBadRequest},
+		{"member viewing another member", handlePersonalGoalFeedback, "GET", "/api/personal-goals/feedback?member_id=m2", "", memberSession, http.StatusForbidden},
+		{"wrong method", handlePersonalGoalCheckIns, "GET", "/api/personal-goals/check-ins", "", memberSession, http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handler(rec, authRequest(tt.method, tt.url, tt.body, tt.sess))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -7,16 +7,31 @@ import (
 
 	"github.com/google/uuid"
 
+	"workshop/internal/adapters/http/apierror"
 	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
 	domain "workshop/internal/domain/personalgoal"
 )
 
+// personalGoalCreateRequest is the body of POST /api/personal-goals.
+type personalGoalCreateRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Target      int    `json:"target"`
+	Unit        string `json:"unit"`
+	Type        string `json:"type"`
+	StartDate   string `json:"start_date"`
+	EndDate     string `json:"end_date"`
+	Color       string `json:"color"`
+	Progress    int    `json:"progress"`
+}
+
 // handlePersonalGoals handles GET/POST/DELETE for /api/personal-goals
 func handlePersonalGoals(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	sess, ok := middleware.GetSessionFromContext(ctx)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "calendar") {
@@ -26,7 +41,7 @@ func handlePersonalGoals(w http.ResponseWriter, r *http.Request) {
 	// Get member ID for the current user
 	member, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID)
 	if err != nil {
-		http.Error(w, "member not found", http.StatusNotFound)
+		apierror.NotFound(w, "member not found")
 		return
 	}
 
@@ -41,13 +56,11 @@ func handlePersonalGoals(w http.ResponseWriter, r *http.Request) {
 			goals = []domain.PersonalGoal{}
 		}
 
-		// Calculate auto-progress for hours-type goals
+		// Calculate auto-progress for hours and sessions goals
 		for i, g := range goals {
 			if g.IsAutoTracked() {
-				hours, err := stores.AttendanceStore.SumMatHoursByMemberIDAndDateRange(ctx, member.ID,
-					g.StartDate.Format("2006-01-02"), g.EndDate.Format("2006-01-02"))
-				if err == nil {
-					goals[i].Progress = int(hours)
+				if progress, err := orchestrators.GoalAutoProgress(ctx, g, stores.AttendanceStore); err == nil {
+					goals[i].Progress = progress
 				}
 			}
 		}
@@ -56,30 +69,20 @@ func handlePersonalGoals(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(goals)
 
 	case "POST":
-		var input struct {
-			Title       string `json:"title"`
-			Description string `json:"description"`
-			Target      int    `json:"target"`
-			Unit        string `json:"unit"`
-			Type        string `json:"type"`
-			StartDate   string `json:"start_date"`
-			EndDate     string `json:"end_date"`
-			Color       string `json:"color"`
-			Progress    int    `json:"progress"`
-		}
+		var input personalGoalCreateRequest
 		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			apierror.Validation(w, "invalid JSON")
 			return
 		}
 
 		startDate, err := time.Parse("2006-01-02", input.StartDate)
 		if err != nil {
-			http.Error(w, "invalid start_date format (use YYYY-MM-DD)", http.StatusBadRequest)
+			apierror.Validation(w, "invalid start_date format (use YYYY-MM-DD)")
 			return
 		}
 		endDate, err := time.Parse("2006-01-02", input.EndDate)
 		if err != nil {
-			http.Error(w, "invalid end_date format (use YYYY-MM-DD)", http.StatusBadRequest)
+			apierror.Validation(w, "invalid end_date format (use YYYY-MM-DD)")
 			return
 		}
 
@@ -96,6 +99,7 @@ func handlePersonalGoals(w http.ResponseWriter, r *http.Request) {
 			EndDate:     endDate,
 			Color:       input.Color,
 			Progress:    input.Progress,
+			Status:      domain.StatusActive,
 			CreatedAt:   now,
 			UpdatedAt:   now,
 		}
@@ -103,7 +107,7 @@ func handlePersonalGoals(w http.ResponseWriter, r *http.Request) {
 		goal.SetDefaultType()
 
 		if err := goal.Validate(); err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Validation(w, err.Error())
 			return
 		}
 
@@ -119,18 +123,18 @@ func handlePersonalGoals(w http.ResponseWriter, r *http.Request) {
 	case "DELETE":
 		id := r.URL.Query().Get("id")
 		if id == "" {
-			http.Error(w, "id is required", http.StatusBadRequest)
+			apierror.Validation(w, "id is required")
 			return
 		}
 
 		// Verify ownership before deleting
 		goal, err := stores.PersonalGoalStore.GetByID(ctx, id)
 		if err != nil {
-			http.Error(w, "goal not found", http.StatusNotFound)
+			apierror.NotFound(w, "goal not found")
 			return
 		}
 		if goal.MemberID != member.ID && sess.Role != "admin" {
-			http.Error(w, "forbidden", http.StatusForbidden)
+			apierror.Forbidden(w, "forbidden")
 			return
 		}
 
@@ -141,55 +145,62 @@ func handlePersonalGoals(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNoContent)
 
 	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 	}
 }
 
+// personalGoalProgressRequest is the body of PUT /api/personal-goals/progress.
+type personalGoalProgressRequest struct {
+	ID       string `json:"id"`
+	Progress int    `json:"progress"`
+}
+
 // handlePersonalGoalProgress handles PUT for /api/personal-goals/progress
+// Progress that reaches the target completes the goal.
 func handlePersonalGoalProgress(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "PUT" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
 	ctx := r.Context()
 	sess, ok := middleware.GetSessionFromContext(ctx)
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 	if !requireFeatureAPI(w, r, sess, "calendar") {
 		return
 	}
 
-	var input struct {
-		ID       string `json:"id"`
-		Progress int    `json:"progress"`
-	}
+	var input personalGoalProgressRequest
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		apierror.Validation(w, "invalid JSON")
 		return
 	}
 
 	// Get member ID for the current user
 	member, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID)
 	if err != nil {
-		http.Error(w, "member not found", http.StatusNotFound)
+		apierror.NotFound(w, "member not found")
 		return
 	}
 
 	// Verify ownership
 	goal, err := stores.PersonalGoalStore.GetByID(ctx, input.ID)
 	if err != nil {
-		http.Error(w, "goal not found", http.StatusNotFound)
+		apierror.NotFound(w, "goal not found")
 		return
 	}
 	if goal.MemberID != member.ID && sess.Role != "admin" {
-		http.Error(w, "forbidden", http.StatusForbidden)
+		apierror.Forbidden(w, "forbidden")
 		return
 	}
 
 	goal.UpdateProgress(input.Progress)
+	if goal.Reached() && !goal.IsCompleted() {
+		goal.Complete(timeNow())
+	}
 	if err := stores.PersonalGoalStore.Save(ctx, goal); err != nil {
 		internalError(w, err)
 		return
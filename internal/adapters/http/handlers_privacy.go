@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"workshop/internal/adapters/http/apierror"
 	"workshop/internal/adapters/http/middleware"
 	"workshop/internal/domain/consent"
 	"workshop/internal/domain/deletion"
@@ -64,20 +65,20 @@ func handlePrivacyDeletePage(w http.ResponseWriter, r *http.Request) {
 // POST: Creates a deletion request and returns confirmation
 func handlePrivacyDeleteRequest(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
 	sess, ok := middleware.GetSessionFromContext(r.Context())
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 
 	// Only members and trials can request deletion of their own data
 	// Coaches and admins cannot use this endpoint (they have other tools)
 	if sess.Role != "member" && sess.Role != "trial" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+		apierror.Forbidden(w, "Forbidden")
 		return
 	}
 
@@ -86,14 +87,14 @@ func handlePrivacyDeleteRequest(w http.ResponseWriter, r *http.Request) {
 	// Get member info
 	member, err := stores.MemberStore.GetByEmail(ctx, sess.Email)
 	if err != nil {
-		http.Error(w, "member not found", http.StatusNotFound)
+		apierror.NotFound(w, "member not found")
 		return
 	}
 
 	// Check if there's already a pending request
 	existing, err := stores.DeletionRequestStore.GetByMemberID(ctx, member.ID)
 	if err == nil && !existing.IsTerminal() {
-		http.Error(w, "deletion request already pending", http.StatusConflict)
+		apierror.Conflict(w, "deletion request already pending")
 		return
 	}
 
@@ -110,7 +111,7 @@ func handlePrivacyDeleteRequest(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := req.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
@@ -120,7 +121,7 @@ func handlePrivacyDeleteRequest(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Log audit event
-	slog.Info("privacy_deletion_requested",
+	slog.InfoContext(ctx, "privacy_deletion_requested",
 		"request_id", req.ID,
 		"member_id", member.ID,
 		"email", member.Email,
@@ -141,19 +142,19 @@ func handlePrivacyDeleteRequest(w http.ResponseWriter, r *http.Request) {
 // POST: Cancels the deletion request and returns confirmation
 func handlePrivacyDeleteCancel(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
 	sess, ok := middleware.GetSessionFromContext(r.Context())
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 
 	// Only members and trials can cancel their own deletion requests
 	if sess.Role != "member" && sess.Role != "trial" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+		apierror.Forbidden(w, "Forbidden")
 		return
 	}
 
@@ -162,26 +163,26 @@ func handlePrivacyDeleteCancel(w http.ResponseWriter, r *http.Request) {
 	// Get member info
 	member, err := stores.MemberStore.GetByEmail(ctx, sess.Email)
 	if err != nil {
-		http.Error(w, "member not found", http.StatusNotFound)
+		apierror.NotFound(w, "member not found")
 		return
 	}
 
 	// Get existing request
 	existing, err := stores.DeletionRequestStore.GetByMemberID(ctx, member.ID)
 	if err != nil {
-		http.Error(w, "no deletion request found", http.StatusNotFound)
+		apierror.NotFound(w, "no deletion request found")
 		return
 	}
 
 	// Check if request can be cancelled
 	if !existing.CanCancel() {
-		http.Error(w, "deletion request cannot be cancelled at this stage", http.StatusConflict)
+		apierror.Conflict(w, "deletion request cannot be cancelled at this stage")
 		return
 	}
 
 	// Cancel the request
 	if err := existing.MarkCancelled(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
@@ -191,7 +192,7 @@ func handlePrivacyDeleteCancel(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Log audit event
-	slog.Info("privacy_deletion_cancelled",
+	slog.InfoContext(ctx, "privacy_deletion_cancelled",
 		"request_id", existing.ID,
 		"member_id", member.ID,
 		"email", member.Email,
@@ -250,18 +251,18 @@ func handlePrivacyConsentPage(w http.ResponseWriter, r *http.Request) {
 // POST: Revokes marketing consent for the member
 func handlePrivacyConsentRevoke(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		apierror.MethodNotAllowed(w)
 		return
 	}
 
 	sess, ok := middleware.GetSessionFromContext(r.Context())
 	if !ok {
-		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		apierror.Unauthorized(w, "not authenticated")
 		return
 	}
 
 	if sess.Role != "member" && sess.Role != "trial" {
-		http.Error(w, "Forbidden", http.StatusForbidden)
+		apierror.Forbidden(w, "Forbidden")
 		return
 	}
 
@@ -269,7 +270,7 @@ func handlePrivacyConsentRevoke(w http.ResponseWriter, r *http.Request) {
 
 	member, err := stores.MemberStore.GetByEmail(ctx, sess.Email)
 	if err != nil {
-		http.Error(w, "member not found", http.StatusNotFound)
+		apierror.NotFound(w, "member not found")
 		return
 	}
 
@@ -280,12 +281,12 @@ func handlePrivacyConsentRevoke(w http.ResponseWriter, r *http.Request) {
 
 	consent, err := stores.ConsentStore.GetByType(ctx, member.ID, consent.Type(consentType))
 	if err != nil {
-		http.Error(w, "no consent found to revoke", http.StatusNotFound)
+		apierror.NotFound(w, "no consent found to revoke")
 		return
 	}
 
 	if err := consent.Revoke(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Validation(w, err.Error())
 		return
 	}
 
@@ -294,7 +295,7 @@ func handlePrivacyConsentRevoke(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	slog.Info("consent_revoked",
+	slog.InfoContext(ctx, "consent_revoked",
 		"member_id", member.ID,
 		"consent_type", consentType,
 		"email", member.Email,
@@ -0,0 +1,158 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	notificationDomain "workshop/internal/domain/notification"
+)
+
+// pushKeyResponse is the body of GET /api/push/key.
+type pushKeyResponse struct {
+	PublicKey string `json:"PublicKey"` // applicationServerKey for PushManager.subscribe
+}
+
+// pushSubscriptionRequest is the body of POST and DELETE /api/push/subscriptions.
+// The fields come from the browser's PushSubscription: endpoint, keys.p256dh and keys.auth.
+type pushSubscriptionRequest struct {
+	Endpoint string `json:"Endpoint"`
+	P256dh   string `json:"P256dh"` // POST only
+	Auth     string `json:"Auth"`   // POST only
+}
+
+// requirePush gets the caller's session and checks notifications are on and push is configured.
+func requirePush(w http.ResponseWriter, r *http.Request) (middleware.Session, bool) {
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return sess, false
+	}
+	if !requireFeatureAPI(w, r, sess, "notifications") {
+		return sess, false
+	}
+	if pushSender == nil {
+		apierror.Unavailable(w, "push notifications are not configured")
+		return sess, false
+	}
+	return sess, true
+}
+
+// handlePushKey handles GET /api/push/key
+// Returns the server's VAPID public key so the browser can subscribe.
+func handlePushKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	if _, ok := requirePush(w, r); !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pushKeyResponse{PublicKey: pushPublicKey})
+}
+
+// handlePushSubscriptions handles POST/DELETE for /api/push/subscriptions
+// POST saves this browser's subscription for the caller; DELETE removes it.
+// Which notifications are pushed is chosen per kind at /api/notifications/preferences.
+func handlePushSubscriptions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" && r.Method != "DELETE" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePush(w, r)
+	if !ok {
+		return
+	}
+	var input pushSubscriptionRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	ctx := r.Context()
+
+	if r.Method == "DELETE" {
+		subs, err := stores.NotificationStore.ListPushSubscriptions(ctx, sess.AccountID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		for _, sub := range subs {
+			if sub.Endpoint == input.Endpoint {
+				if err := stores.NotificationStore.DeletePushSubscription(ctx, sub.Endpoint); err != nil {
+					internalError(w, err)
+					return
+				}
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	userAgent := r.UserAgent()
+	if len(userAgent) > notificationDomain.MaxKeyLength {
+		userAgent = userAgent[:notificationDomain.MaxKeyLength]
+	}
+	sub := notificationDomain.PushSubscription{
+		ID:        generateID(),
+		AccountID: sess.AccountID,
+		Endpoint:  input.Endpoint,
+		P256dh:    input.P256dh,
+		Auth:      input.Auth,
+		UserAgent: userAgent,
+		CreatedAt: timeNow(),
+	}
+	if err := sub.Validate(); err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	if err := stores.NotificationStore.SavePushSubscription(ctx, sub); err != nil {
+		internalError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// ClassRemindersDeps wires the class reminder worker to the stores and the push channel.
+func ClassRemindersDeps(s *Stores, now func() time.Time) orchestrators.SendClassRemindersDeps {
+	return orchestrators.SendClassRemindersDeps{
+		TodaysClasses: func(ctx context.Context, at time.Time) ([]orchestrators.UpcomingClass, error) {
+			classes, err := projections.QueryGetTodaysClasses(ctx, at, projections.GetTodaysClassesDeps{
+				ScheduleStore:  s.ScheduleStore,
+				TermStore:      s.TermStore,
+				HolidayStore:   s.HolidayStore,
+				ClassTypeStore: s.ClassTypeStore,
+				ProgramStore:   s.ProgramStore,
+				ChangeStore:    s.OccurrenceChangeStore,
+			})
+			if err != nil {
+				return nil, err
+			}
+			upcoming := make([]orchestrators.UpcomingClass, 0, len(classes))
+			for _, c := range classes {
+				upcoming = append(upcoming, orchestrators.UpcomingClass{ScheduleID: c.ScheduleID, ClassTypeName: c.ClassTypeName, StartTime: c.StartTime})
+			}
+			return upcoming, nil
+		},
+		AttendanceStore: s.AttendanceStore,
+		MemberStore:     s.MemberStore,
+		ReminderStore:   s.NotificationStore,
+		Notify: orchestrators.NotifyDeps{
+			NotificationStore: s.NotificationStore,
+			AccountStore:      s.AccountStore,
+			EmailSender:       emailSender,
+			EmailFrom:         emailFromAddress,
+			EmailReplyTo:      emailReplyTo,
+			PushStore:         s.NotificationStore,
+			PushSender:        pushSender,
+			GenerateID:        generateID,
+			Now:               now,
+		},
+		Now: now,
+	}
+}
@@ -0,0 +1,143 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	quickCaptureDomain "workshop/internal/domain/quickcapture"
+)
+
+// quickCaptureRequest is the body of POST /api/observations/capture.
+type quickCaptureRequest struct {
+	Items []orchestrators.QuickCaptureItem `json:"Items"`
+}
+
+// quickCaptureResolveRequest is the body of POST /api/observations/capture/resolve.
+type quickCaptureResolveRequest struct {
+	ID       string `json:"ID"`
+	MemberID string `json:"MemberID"` // empty discards the note
+}
+
+// handleQuickCapture handles GET/POST for /api/observations/capture
+// POST takes a batch of notes a coach captured mid-class, naming members as typed or dictated,
+// and reports per note whether it was saved as an observation, queued for the coach to choose
+// the member, a duplicate of a note sent before, or rejected. Phones queue notes while offline
+// and resend the batch until they get a response. GET returns the coach's queued notes.
+// Coaches and admins only.
+func handleQuickCapture(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !isStaffSession(sess) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "quick_capture") {
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		queued, err := stores.QuickCaptureStore.ListQueuedByAuthorID(ctx, sess.AccountID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if queued == nil {
+			queued = []quickCaptureDomain.Capture{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(queued)
+
+	case "POST":
+		var input quickCaptureRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		result, err := orchestrators.ExecuteQuickCapture(ctx, orchestrators.QuickCaptureInput{
+			Items:    input.Items,
+			AuthorID: sess.AccountID,
+		}, orchestrators.QuickCaptureDeps{
+			MemberStore:      stores.MemberStore,
+			CaptureStore:     stores.QuickCaptureStore,
+			ObservationStore: stores.ObservationStore,
+			GenerateID:       generateID,
+			Now:              timeNow,
+		})
+		if errors.Is(err, orchestrators.ErrQuickCaptureTooLarge) {
+			apierror.TooLarge(w, err.Error())
+			return
+		}
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleQuickCaptureResolve handles POST /api/observations/capture/resolve
+// Settles one of the coach's queued notes: saves it as an observation about the chosen
+// member, or discards it when no member is given.
+func handleQuickCaptureResolve(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !isStaffSession(sess) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "quick_capture") {
+		return
+	}
+	var input quickCaptureResolveRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+
+	c, err := orchestrators.ExecuteResolveQuickCapture(ctx, orchestrators.ResolveQuickCaptureInput{
+		CaptureID: input.ID,
+		MemberID:  input.MemberID,
+		AuthorID:  sess.AccountID,
+	}, orchestrators.ResolveQuickCaptureDeps{
+		MemberStore:      stores.MemberStore,
+		CaptureStore:     stores.QuickCaptureStore,
+		ObservationStore: stores.ObservationStore,
+		GenerateID:       generateID,
+		Now:              timeNow,
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, orchestrators.ErrQuickCaptureNotFound), errors.Is(err, orchestrators.ErrQuickCaptureMemberNotFound):
+		apierror.NotFound(w, err.Error())
+		return
+	case errors.Is(err, quickCaptureDomain.ErrNotQueued):
+		apierror.Conflict(w, err.Error())
+		return
+	default:
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
+}
@@ -0,0 +1,133 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/application/orchestrators"
+	featureflagDomain "workshop/internal/domain/featureflag"
+	memberDomain "workshop/internal/domain/member"
+	quickCaptureDomain "workshop/internal/domain/quickcapture"
+)
+
+// mockQuickCaptureStore implements the quick capture store for testing.
+type mockQuickCaptureStore struct {
+	captures map[string]quickCaptureDomain.Capture
+}
+
+// GetByID implements quickcapture.Store for testing.
+// PRE: none
+// POST: Returns the capture or an error
+func (m *mockQuickCaptureStore) GetByID(_ context.Context, id string) (quickCaptureDomain.Capture, error) {
+	c, ok := m.captures[id]
+	if !ok {
+		return quickCaptureDomain.Capture{}, errors.New("not found")
+	}
+	return c, nil
+}
+
+// GetByClientID implements quickcapture.Store for testing.
+// PRE: none
+// POST: Returns the author's capture with the client ID or an error
+func (m *mockQuickCaptureStore) GetByClientID(_ context.Context, authorID, clientID string) (quickCaptureDomain.Capture, error) {
+	for _, c := range m.captures {
+		if c.AuthorID == authorID && c.ClientID == clientID {
+			return c, nil
+		}
+	}
+	return quickCaptureDomain.Capture{}, errors.New("not found")
+}
+
+// Save implements quickcapture.Store for testing.
+// PRE: none
+// POST: The capture is stored
+func (m *mockQuickCaptureStore) Save(_ context.Context, value quickCaptureDomain.Capture) error {
+	m.captures[value.ID] = value
+	return nil
+}
+
+// ListQueuedByAuthorID implements quickcapture.Store for testing.
+// PRE: none
+// POST: Returns the author's queued captures
+func (m *mockQuickCaptureStore) ListQueuedByAuthorID(_ context.Context, authorID string) ([]quickCaptureDomain.Capture, error) {
+	var list []quickCaptureDomain.Capture
+	for _, c := range m.captures {
+		if c.AuthorID == authorID && c.Status == quickCaptureDomain.StatusQueued {
+			list = append(list, c)
+		}
+	}
+	return list, nil
+}
+
+// TestQuickCapture verifies a coach's batch is saved or queued per note, a member cannot
+// send one, and a queued note is settled by choosing the member.
+func TestQuickCapture(t *testing.T) {
+	stores = newFullStores()
+	stores.QuickCaptureStore = &mockQuickCaptureStore{captures: map[string]quickCaptureDomain.Capture{}}
+	timeNow = func() time.Time { return time.Date(2026, 3, 2, 19, 30, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", Name: "Sam Smith", Program: "adults", Status: memberDomain.StatusActive})
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m2", Name: "Jordan Lee", Program: "adults", Status: memberDomain.StatusActive})
+
+	body := `{"Items":[
+		{"ClientID":"p1","Member":"sam","Content":"Flaring elbows in closed guard","CapturedAt":"2026-03-02T18:45:00Z"},
+		{"ClientID":"p2","Member":"Jo","Content":"Great framing","CapturedAt":"2026-03-02T18:50:00Z"}
+	]}`
+	rec := httptest.NewRecorder()
+	handleQuickCapture(rec, authRequest("POST", "/api/observations/capture", body, memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member capture: expected 403, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleQuickCapture(rec, authRequest("POST", "/api/observations/capture", body, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("coach capture: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result orchestrators.QuickCaptureResult
+	json.NewDecoder(rec.Body).Decode(&result)
+	if result.Saved != 1 || result.Queued != 1 || result.Results[0].MemberID != "m1" || result.Results[1].Status != orchestrators.QuickCaptureStatusQueued {
+		t.Fatalf("result = %+v, want Sam's note saved and the other queued", result)
+	}
+
+	rec = httptest.NewRecorder()
+	handleQuickCapture(rec, authRequest("GET", "/api/observations/capture", "", coachSession))
+	var queued []quickCaptureDomain.Capture
+	json.NewDecoder(rec.Body).Decode(&queued)
+	if len(queued) != 1 || queued[0].MemberRef != "Jo" {
+		t.Fatalf("queued = %+v, want the note about Jo", queued)
+	}
+
+	resolve := `{"ID":"` + queued[0].ID + `","MemberID":"m2"}`
+	rec = httptest.NewRecorder()
+	handleQuickCaptureResolve(rec, authRequest("POST", "/api/observations/capture/resolve", resolve, adminSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("another author resolving: expected 404, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleQuickCaptureResolve(rec, authRequest("POST", "/api/observations/capture/resolve", resolve, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("resolve: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	obs, _ := stores.ObservationStore.ListByMemberID(ctx, "m2")
+	if len(obs) != 1 || obs[0].Content != "Great framing" {
+		t.Errorf("Jordan's observations = %+v, want the resolved note", obs)
+	}
+	rec = httptest.NewRecorder()
+	handleQuickCaptureResolve(rec, authRequest("POST", "/api/observations/capture/resolve", resolve, coachSession))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("resolving twice: expected 409, got %d", rec.Code)
+	}
+
+	stores.FeatureFlagStore.Save(ctx, featureflagDomain.FeatureFlag{Key: "quick_capture", EnabledAdmin: true})
+	rec = httptest.NewRecorder()
+	handleQuickCapture(rec, authRequest("POST", "/api/observations/capture", body, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("quick capture off for coaches: expected 403, got %d", rec.Code)
+	}
+}
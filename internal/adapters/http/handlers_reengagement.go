@@ -0,0 +1,354 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	reengagementStore "workshop/internal/adapters/storage/reengagement"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	emailDomain "workshop/internal/domain/email"
+	permissionDomain "workshop/internal/domain/permission"
+	reengagementDomain "workshop/internal/domain/reengagement"
+)
+
+// leavingReason pauses re-engagement for a member who told a coach they are not coming back.
+const leavingReason = "Told a coach they are not coming back"
+
+// reengagementRuleRequest is the body of POST /api/reengagement/rules.
+type reengagementRuleRequest struct {
+	ID           string `json:"ID"` // optional: empty creates a rule
+	Name         string `json:"Name"`
+	DaysInactive int    `json:"DaysInactive"`
+	Action       string `json:"Action"`
+	TemplateKey  string `json:"TemplateKey"`
+	Enabled      bool   `json:"Enabled"`
+}
+
+// reengagementOutcomeRequest is the body of POST /api/reengagement/actions/outcome.
+type reengagementOutcomeRequest struct {
+	ActionID string `json:"ActionID"`
+	Outcome  string `json:"Outcome"`
+	Note     string `json:"Note"`
+}
+
+// reengagementSuppressionRequest is the body of POST /api/reengagement/suppressions.
+type reengagementSuppressionRequest struct {
+	MemberID string `json:"MemberID"`
+	Reason   string `json:"Reason"`
+	Until    string `json:"Until"` // optional: YYYY-MM-DD; empty pauses until lifted
+}
+
+// reengagementActionEntry is an action with the member's name, for the call queue.
+type reengagementActionEntry struct {
+	reengagementDomain.Action
+	MemberName string
+}
+
+// handleReengagementRules handles GET/POST/DELETE for /api/reengagement/rules
+// GET lists the rules, lowest threshold first. POST creates or updates a rule; an email
+// rule must name a template in the library. DELETE ?id= removes a rule, keeping the
+// actions it took. Admin only.
+func handleReengagementRules(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "reengagement") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		list, err := stores.ReengagementStore.ListRules(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if list == nil {
+			list = []reengagementDomain.Rule{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(list)
+
+	case "POST":
+		var input reengagementRuleRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		rule := reengagementDomain.Rule{
+			ID:           input.ID,
+			Name:         strings.TrimSpace(input.Name),
+			DaysInactive: input.DaysInactive,
+			Action:       input.Action,
+			TemplateKey:  input.TemplateKey,
+			Enabled:      input.Enabled,
+			UpdatedBy:    sess.AccountID,
+			UpdatedAt:    timeNow(),
+		}
+		if rule.ID == "" {
+			rule.ID = generateID()
+		} else if _, err := stores.ReengagementStore.GetRule(ctx, rule.ID); err != nil {
+			apierror.NotFound(w, "rule not found")
+			return
+		}
+		if err := rule.Validate(); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if rule.Action == reengagementDomain.ActionEmail && !libraryTemplateExists(ctx, rule.TemplateKey) {
+			apierror.Validation(w, "template not found in the email library")
+			return
+		}
+		if err := stores.ReengagementStore.SaveRule(ctx, rule); err != nil {
+			internalError(w, err)
+			return
+		}
+		slog.InfoContext(ctx, "reengagement_event", "event", "rule_saved", "rule_id", rule.ID, "account_id", sess.AccountID)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(rule)
+
+	case "DELETE":
+		id := r.URL.Query().Get("id")
+		if _, err := stores.ReengagementStore.GetRule(ctx, id); err != nil {
+			apierror.NotFound(w, "rule not found")
+			return
+		}
+		if err := stores.ReengagementStore.DeleteRule(ctx, id); err != nil {
+			internalError(w, err)
+			return
+		}
+		slog.InfoContext(ctx, "reengagement_event", "event", "rule_deleted", "rule_id", id, "account_id", sess.AccountID)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleReengagementActions handles GET /api/reengagement/actions
+// Lists the actions taken, most recent first: one member's history (?member_id=), or the
+// gym's filtered by ?outcome= and ?kind=, e.g. the calls still to make. Admin and coach.
+func handleReengagementActions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionMembersView)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "reengagement") {
+		return
+	}
+	ctx := r.Context()
+
+	q := r.URL.Query()
+	list, err := stores.ReengagementStore.ListActions(ctx, reengagementStore.ActionFilter{
+		MemberID: q.Get("member_id"),
+		Outcome:  q.Get("outcome"),
+		Kind:     q.Get("kind"),
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	names := map[string]string{}
+	entries := []reengagementActionEntry{}
+	for _, a := range list {
+		name, ok := names[a.MemberID]
+		if !ok {
+			if m, err := stores.MemberStore.GetByID(ctx, a.MemberID); err == nil {
+				name = m.Name
+			}
+			names[a.MemberID] = name
+		}
+		entries = append(entries, reengagementActionEntry{Action: a, MemberName: name})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// handleReengagementOutcome handles POST /api/reengagement/actions/outcome
+// Records how a coach call went. A member who says they are leaving is paused so the
+// automation leaves them alone. Admin and coach.
+func handleReengagementOutcome(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionMembersView)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "reengagement") {
+		return
+	}
+	ctx := r.Context()
+
+	var input reengagementOutcomeRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	a, err := stores.ReengagementStore.GetAction(ctx, input.ActionID)
+	if err != nil {
+		apierror.NotFound(w, "action not found")
+		return
+	}
+	now := timeNow()
+	if err := a.RecordOutcome(input.Outcome, input.Note, sess.AccountID, now); err != nil {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	if err := stores.ReengagementStore.SaveAction(ctx, a); err != nil {
+		internalError(w, err)
+		return
+	}
+	if a.Outcome == reengagementDomain.OutcomeLeaving {
+		pause := reengagementDomain.Suppression{MemberID: a.MemberID, Reason: leavingReason, CreatedBy: sess.AccountID, CreatedAt: now}
+		if err := stores.ReengagementStore.SaveSuppression(ctx, pause); err != nil {
+			internalError(w, err)
+			return
+		}
+	}
+	slog.InfoContext(ctx, "reengagement_event", "event", "call_outcome_recorded", "action_id", a.ID, "outcome", a.Outcome, "account_id", sess.AccountID)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(a)
+}
+
+// handleReengagementSuppressions handles GET/POST/DELETE for /api/reengagement/suppressions
+// GET returns one member's pause (?member_id=), or null, or lists every pause. POST pauses
+// a member, e.g. while injured or travelling. DELETE ?member_id= resumes them. Admin and coach.
+func handleReengagementSuppressions(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requirePermission(w, r, permissionDomain.ActionMembersView)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "reengagement") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		w.Header().Set("Content-Type", "application/json")
+		if memberID := r.URL.Query().Get("member_id"); memberID != "" {
+			s, err := stores.ReengagementStore.GetSuppression(ctx, memberID)
+			if err != nil || !s.Active(timeNow()) {
+				w.Write([]byte("null"))
+				return
+			}
+			json.NewEncoder(w).Encode(s)
+			return
+		}
+		list, err := stores.ReengagementStore.ListSuppressions(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if list == nil {
+			list = []reengagementDomain.Suppression{}
+		}
+		json.NewEncoder(w).Encode(list)
+
+	case "POST":
+		var input reengagementSuppressionRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		if _, err := stores.MemberStore.GetByID(ctx, input.MemberID); err != nil {
+			apierror.NotFound(w, "member not found")
+			return
+		}
+		s := reengagementDomain.Suppression{
+			MemberID:  input.MemberID,
+			Reason:    strings.TrimSpace(input.Reason),
+			CreatedBy: sess.AccountID,
+			CreatedAt: timeNow(),
+		}
+		if input.Until != "" {
+			until, err := time.Parse("2006-01-02", input.Until)
+			if err != nil {
+				apierror.Validation(w, "Until must be YYYY-MM-DD")
+				return
+			}
+			s.Until = until
+		}
+		if err := s.Validate(s.CreatedAt); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err := stores.ReengagementStore.SaveSuppression(ctx, s); err != nil {
+			internalError(w, err)
+			return
+		}
+		slog.InfoContext(ctx, "reengagement_event", "event", "member_paused", "member_id", s.MemberID, "account_id", sess.AccountID)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s)
+
+	case "DELETE":
+		memberID := r.URL.Query().Get("member_id")
+		if _, err := stores.ReengagementStore.GetSuppression(ctx, memberID); err != nil {
+			apierror.NotFound(w, "member is not paused")
+			return
+		}
+		if err := stores.ReengagementStore.DeleteSuppression(ctx, memberID); err != nil {
+			internalError(w, err)
+			return
+		}
+		slog.InfoContext(ctx, "reengagement_event", "event", "member_resumed", "member_id", memberID, "account_id", sess.AccountID)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// libraryTemplateExists reports whether key names a built-in or saved library template.
+func libraryTemplateExists(ctx context.Context, key string) bool {
+	if _, ok := emailDomain.BuiltInTemplate(key); ok {
+		return true
+	}
+	if stores.EmailLibraryStore == nil {
+		return false
+	}
+	_, err := stores.EmailLibraryStore.GetByKey(ctx, key)
+	return err == nil
+}
+
+// ReengagementDeps returns the dependencies for the re-engagement worker: inactive members
+// come from the inactive radar, and emails go out through the template library.
+func ReengagementDeps(s *Stores, baseURL string, key []byte, now func() time.Time) orchestrators.RunReengagementDeps {
+	return orchestrators.RunReengagementDeps{
+		Store:           s.ReengagementStore,
+		AttendanceStore: s.AttendanceStore,
+		FindInactive: func(ctx context.Context, days int) ([]orchestrators.ReengagementCandidate, error) {
+			results, err := projections.QueryGetInactiveMembers(ctx, projections.GetInactiveMembersQuery{DaysSinceLastCheckIn: days}, projections.GetInactiveMembersDeps{
+				MemberStore:     s.MemberStore,
+				AttendanceStore: s.AttendanceStore,
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]orchestrators.ReengagementCandidate, 0, len(results))
+			for _, m := range results {
+				last, _ := time.Parse("2006-01-02", m.LastCheckIn) // "never" leaves it zero
+				candidates = append(candidates, orchestrators.ReengagementCandidate{MemberID: m.MemberID, Program: m.Program, Status: m.Status, LastCheckIn: last})
+			}
+			return candidates, nil
+		},
+		NextClassDate: NextClassDates(s, now),
+		Send:          TemplatedEmailDeps(s, baseURL, key, now),
+		GenerateID:    generateID,
+		Now:           now,
+	}
+}
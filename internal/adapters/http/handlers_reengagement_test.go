@@ -0,0 +1,254 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	reengagementStore "workshop/internal/adapters/storage/reengagement"
+	memberDomain "workshop/internal/domain/member"
+	reengagementDomain "workshop/internal/domain/reengagement"
+)
+
+type mockReengagementStore struct {
+	rules        map[string]reengagementDomain.Rule
+	actions      map[string]reengagementDomain.Action
+	suppressions map[string]reengagementDomain.Suppression
+}
+
+// GetRule implements reengagement.Store for testing.
+// PRE: id is non-empty
+// POST: Returns the rule or sql.ErrNoRows
+func (m *mockReengagementStore) GetRule(_ context.Context, id string) (reengagementDomain.Rule, error) {
+	r, ok := m.rules[id]
+	if !ok {
+		return reengagementDomain.Rule{}, sql.ErrNoRows
+	}
+	return r, nil
+}
+
+// SaveRule implements reengagement.Store for testing.
+// PRE: value has been validated
+// POST: The rule is stored
+func (m *mockReengagementStore) SaveRule(_ context.Context, value reengagementDomain.Rule) error {
+	m.rules[value.ID] = value
+	return nil
+}
+
+// DeleteRule implements reengagement.Store for testing.
+// PRE: id is non-empty
+// POST: The rule is removed
+func (m *mockReengagementStore) DeleteRule(_ context.Context, id string) error {
+	delete(m.rules, id)
+	return nil
+}
+
+// ListRules implements reengagement.Store for testing.
+// PRE: none
+// POST: Returns the rules, lowest threshold first
+func (m *mockReengagementStore) ListRules(_ context.Context) ([]reengagementDomain.Rule, error) {
+	var out []reengagementDomain.Rule
+	for _, r := range m.rules {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].DaysInactive < out[j].DaysInactive })
+	return out, nil
+}
+
+// GetAction implements reengagement.Store for testing.
+// PRE: id is non-empty
+// POST: Returns the action or sql.ErrNoRows
+func (m *mockReengagementStore) GetAction(_ context.Context, id string) (reengagementDomain.Action, error) {
+	a, ok := m.actions[id]
+	if !ok {
+		return reengagementDomain.Action{}, sql.ErrNoRows
+	}
+	return a, nil
+}
+
+// SaveAction implements reengagement.Store for testing.
+// PRE: value has been validated
+// POST: The action is stored
+func (m *mockReengagementStore) SaveAction(_ context.Context, value reengagementDomain.Action) error {
+	m.actions[value.ID] = value
+	return nil
+}
+
+// ListActions implements reengagement.Store for testing.
+// PRE: none
+// POST: Returns the actions matching the filter
+func (m *mockReengagementStore) ListActions(_ context.Context, filter reengagementStore.ActionFilter) ([]reengagementDomain.Action, error) {
+	var out []reengagementDomain.Action
+	for _, a := range m.actions {
+		if (filter.MemberID == "" || a.MemberID == filter.MemberID) && (filter.Outcome == "" || a.Outcome == filter.Outcome) && (filter.Kind == "" || a.Kind == filter.Kind) {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+// ListAwaitingReturn implements reengagement.Store for testing.
+// PRE: none
+// POST: Returns the actions whose member has not returned
+func (m *mockReengagementStore) ListAwaitingReturn(_ context.Context, since time.Time) ([]reengagementDomain.Action, error) {
+	var out []reengagementDomain.Action
+	for _, a := range m.actions {
+		if a.ReturnedAt.IsZero() && !a.CreatedAt.Before(since) {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+// GetSuppression implements reengagement.Store for testing.
+// PRE: memberID is non-empty
+// POST: Returns the suppression or sql.ErrNoRows
+func (m *mockReengagementStore) GetSuppression(_ context.Context, memberID string) (reengagementDomain.Suppression, error) {
+	s, ok := m.suppressions[memberID]
+	if !ok {
+		return reengagementDomain.Suppression{}, sql.ErrNoRows
+	}
+	return s, nil
+}
+
+// SaveSuppression implements reengagement.Store for testing.
+// PRE: value has been validated
+// POST: The suppression is stored
+func (m *mockReengagementStore) SaveSuppression(_ context.Context, value reengagementDomain.Suppression) error {
+	m.suppressions[value.MemberID] = value
+	return nil
+}
+
+// DeleteSuppression implements reengagement.Store for testing.
+// PRE: memberID is non-empty
+// POST: The suppression is removed
+func (m *mockReengagementStore) DeleteSuppression(_ context.Context, memberID string) error {
+	delete(m.suppressions, memberID)
+	return nil
+}
+
+// ListSuppressions implements reengagement.Store for testing.
+// PRE: none
+// POST: Returns the suppressions
+func (m *mockReengagementStore) ListSuppressions(_ context.Context) ([]reengagementDomain.Suppression, error) {
+	var out []reengagementDomain.Suppression
+	for _, s := range m.suppressions {
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// setupReengagementStores gives the stores an empty re-engagement store and one member.
+func setupReengagementStores() *mockReengagementStore {
+	stores = newFullStores()
+	store := &mockReengagementStore{
+		rules:        map[string]reengagementDomain.Rule{},
+		actions:      map[string]reengagementDomain.Action{},
+		suppressions: map[string]reengagementDomain.Suppression{},
+	}
+	stores.ReengagementStore = store
+	stores.MemberStore.Save(context.Background(), memberDomain.Member{ID: "m1", Name: "Marcus Almeida", Email: "marcus@example.com", Program: memberDomain.ProgramAdults, Status: "active"})
+	return store
+}
+
+// TestHandleReengagementRules verifies admins can add an email rule only for a library
+// template, and coaches cannot edit rules.
+func TestHandleReengagementRules(t *testing.T) {
+	store := setupReengagementStores()
+
+	rec := httptest.NewRecorder()
+	handleReengagementRules(rec, authRequest("POST", "/api/reengagement/rules",
+		`{"Name":"We miss you","DaysInactive":21,"Action":"email","TemplateKey":"inactive_follow_up","Enabled":true}`, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(store.rules) != 1 {
+		t.Errorf("rules = %+v, want one", store.rules)
+	}
+
+	rec = httptest.NewRecorder()
+	handleReengagementRules(rec, authRequest("POST", "/api/reengagement/rules",
+		`{"Name":"Nudge","DaysInactive":10,"Action":"email","TemplateKey":"no_such_template","Enabled":true}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("POST unknown template: expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleReengagementRules(rec, authRequest("POST", "/api/reengagement/rules",
+		`{"Name":"Coach call","DaysInactive":45,"Action":"coach_call","Enabled":true}`, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach POST: expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleReengagementOutcome verifies a coach records a call's outcome, and a member who
+// is leaving is paused.
+func TestHandleReengagementOutcome(t *testing.T) {
+	store := setupReengagementStores()
+	store.actions["a1"] = reengagementDomain.Action{ID: "a1", MemberID: "m1", Kind: reengagementDomain.ActionCoachCall, Outcome: reengagementDomain.OutcomePending}
+	store.actions["a2"] = reengagementDomain.Action{ID: "a2", MemberID: "m1", Kind: reengagementDomain.ActionEmail, Outcome: reengagementDomain.OutcomeSent}
+
+	rec := httptest.NewRecorder()
+	handleReengagementActions(rec, authRequest("GET", "/api/reengagement/actions?outcome=pending&kind=coach_call", "", coachSession))
+	var queue []reengagementActionEntry
+	json.NewDecoder(rec.Body).Decode(&queue)
+	if rec.Code != http.StatusOK || len(queue) != 1 || queue[0].MemberName != "Marcus Almeida" {
+		t.Fatalf("GET calls: got %d %+v, want Marcus's call", rec.Code, queue)
+	}
+
+	rec = httptest.NewRecorder()
+	handleReengagementOutcome(rec, authRequest("POST", "/api/reengagement/actions/outcome", `{"ActionID":"a2","Outcome":"reached"}`, coachSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("outcome on an email: expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleReengagementOutcome(rec, authRequest("POST", "/api/reengagement/actions/outcome", `{"ActionID":"a1","Outcome":"leaving","Note":"Moving to Wellington"}`, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST outcome: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if a := store.actions["a1"]; a.Outcome != reengagementDomain.OutcomeLeaving || a.RecordedBy != coachSession.AccountID {
+		t.Errorf("action = %+v, want leaving recorded by the coach", a)
+	}
+	if _, ok := store.suppressions["m1"]; !ok {
+		t.Error("expected the leaving member to be paused")
+	}
+}
+
+// TestHandleReengagementSuppressions verifies pausing a member until a date and resuming.
+func TestHandleReengagementSuppressions(t *testing.T) {
+	store := setupReengagementStores()
+	until := timeNow().AddDate(0, 2, 0).Format("2006-01-02")
+
+	rec := httptest.NewRecorder()
+	handleReengagementSuppressions(rec, authRequest("POST", "/api/reengagement/suppressions", `{"MemberID":"m1","Reason":"Knee surgery","Until":"`+until+`"}`, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if s := store.suppressions["m1"]; s.Reason != "Knee surgery" || s.Until.Format("2006-01-02") != until {
+		t.Errorf("suppression = %+v", s)
+	}
+
+	rec = httptest.NewRecorder()
+	handleReengagementSuppressions(rec, authRequest("POST", "/api/reengagement/suppressions", `{"MemberID":"m1","Reason":"Travel","Until":"2001-01-01"}`, coachSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("past date: expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleReengagementSuppressions(rec, authRequest("DELETE", "/api/reengagement/suppressions?member_id=m1", "", coachSession))
+	if rec.Code != http.StatusNoContent || len(store.suppressions) != 0 {
+		t.Errorf("DELETE: expected 204 and no pause, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleReengagementSuppressions(rec, authRequest("GET", "/api/reengagement/suppressions?member_id=m1", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member GET: expected 403, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,190 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	emailDomain "workshop/internal/domain/email"
+	referralDomain "workshop/internal/domain/referral"
+)
+
+// errReferrerNotFound is returned when the member picked as a referrer does not exist.
+var errReferrerNotFound = errors.New("referrer not found")
+
+// referralStoreFor returns the referral store when the signed-in account has referrals
+// turned on, or nil so registrations and check-ins record no referral.
+func referralStoreFor(ctx context.Context) orchestrators.ReferralStore {
+	if stores.ReferralStore == nil {
+		return nil
+	}
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok || !featureEnabledForSession(ctx, sess, "referrals") {
+		return nil
+	}
+	return stores.ReferralStore
+}
+
+// checkReferrer confirms a referrer picked from the member list exists. Free-text
+// referrers are checked by the orchestrator.
+func checkReferrer(ctx context.Context, by orchestrators.ReferredBy) error {
+	if by.MemberID == "" {
+		return nil
+	}
+	if _, err := stores.MemberStore.GetByID(ctx, by.MemberID); err != nil {
+		return errReferrerNotFound
+	}
+	return nil
+}
+
+// isReferralError reports whether err is a problem with the referrer given, not the server.
+func isReferralError(err error) bool {
+	for _, target := range []error{errReferrerNotFound, referralDomain.ErrBothReferrers, referralDomain.ErrReferrerNameTooLong, referralDomain.ErrSelfReferral} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertReferral records that a referred person is now an active member and rewards their
+// referrer. Failures are logged: the sign-up has already happened.
+func convertReferral(ctx context.Context, memberID string) {
+	if stores.ReferralStore == nil {
+		return
+	}
+	_, _, err := orchestrators.ExecuteConvertReferral(ctx, orchestrators.ConvertReferralInput{MemberID: memberID}, orchestrators.ConvertReferralDeps{
+		ReferralStore: stores.ReferralStore,
+		Reward:        rewardReferrer,
+		Now:           timeNow,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "referral_event", "event", "referral_convert_failed", "member_id", memberID, "error", err)
+	}
+}
+
+// rewardReferrer emails the referrer their thank-you or credit note.
+func rewardReferrer(ctx context.Context, r referralDomain.Referral) {
+	referred := "someone you sent our way"
+	if m, err := stores.MemberStore.GetByID(ctx, r.MemberID); err == nil {
+		referred = m.Name
+	}
+	key, vars := emailDomain.TemplateReferralThanks, emailDomain.Vars{emailDomain.VarReferredName: referred}
+	if r.Reward == referralDomain.RewardCredit {
+		key = emailDomain.TemplateReferralCredit
+		vars[emailDomain.VarCredit] = "$" + strconv.Itoa(r.CreditAmount)
+	}
+	sendTemplatedEmail(ctx, key, r.ReferrerMemberID, vars)
+}
+
+// handleAdminReferralsPage handles GET /admin/referrals
+func handleAdminReferralsPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "referrals") {
+		return
+	}
+	renderTemplate(w, r, "admin_referrals.html", map[string]any{"MaxCreditAmount": referralDomain.MaxCreditAmount})
+}
+
+// handleReferralLeaderboard handles GET /api/referrals/leaderboard
+// Ranks referrers by how many of the people they sent joined, with the latest referrals. Admin only.
+func handleReferralLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "referrals") {
+		return
+	}
+	result, err := projections.QueryGetReferralLeaderboard(r.Context(), projections.GetReferralLeaderboardDeps{
+		ReferralStore: stores.ReferralStore,
+		MemberStore:   stores.MemberStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// referralSettingsRequest is the body of PUT /api/referrals/settings.
+type referralSettingsRequest struct {
+	Enabled      bool   `json:"Enabled"`
+	Reward       string `json:"Reward"`
+	CreditAmount int    `json:"CreditAmount"`
+}
+
+// handleReferralSettings handles GET/PUT for /api/referrals/settings
+// Chooses whether referrers are rewarded when the person they referred joins, and whether
+// with a thank-you email or a credit note. Admin only.
+func handleReferralSettings(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "referrals") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		settings, err := stores.ReferralStore.GetSettings(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(settings)
+
+	case "PUT":
+		var input referralSettingsRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		settings := referralDomain.Settings{
+			Enabled:      input.Enabled,
+			Reward:       strings.TrimSpace(input.Reward),
+			CreditAmount: input.CreditAmount,
+			UpdatedBy:    sess.AccountID,
+			UpdatedAt:    timeNow(),
+		}
+		if settings.Reward == referralDomain.RewardThankYou && settings.CreditAmount == 0 {
+			settings.CreditAmount = referralDomain.DefaultCreditAmount
+		}
+		if err := settings.Validate(); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err := stores.ReferralStore.SaveSettings(ctx, settings); err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(settings)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
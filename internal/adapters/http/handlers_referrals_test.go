@@ -0,0 +1,168 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/projections"
+	memberDomain "workshop/internal/domain/member"
+	referralDomain "workshop/internal/domain/referral"
+)
+
+type mockReferralStore struct {
+	referrals map[string]referralDomain.Referral // by member ID
+	settings  *referralDomain.Settings
+}
+
+// Save implements referral.Store for testing.
+// PRE: value has been validated
+// POST: The referral is stored by member ID
+func (m *mockReferralStore) Save(_ context.Context, value referralDomain.Referral) error {
+	m.referrals[value.MemberID] = value
+	return nil
+}
+
+// GetByMemberID implements referral.Store for testing.
+// PRE: memberID is non-empty
+// POST: Returns the referral or sql.ErrNoRows
+func (m *mockReferralStore) GetByMemberID(_ context.Context, memberID string) (referralDomain.Referral, error) {
+	r, ok := m.referrals[memberID]
+	if !ok {
+		return referralDomain.Referral{}, sql.ErrNoRows
+	}
+	return r, nil
+}
+
+// List implements referral.Store for testing.
+// PRE: none
+// POST: Returns every referral
+func (m *mockReferralStore) List(_ context.Context) ([]referralDomain.Referral, error) {
+	var list []referralDomain.Referral
+	for _, r := range m.referrals {
+		list = append(list, r)
+	}
+	return list, nil
+}
+
+// GetSettings implements referral.Store for testing.
+// PRE: none
+// POST: Returns the saved settings or the defaults
+func (m *mockReferralStore) GetSettings(_ context.Context) (referralDomain.Settings, error) {
+	if m.settings == nil {
+		return referralDomain.DefaultSettings, nil
+	}
+	return *m.settings, nil
+}
+
+// SaveSettings implements referral.Store for testing.
+// PRE: value has been validated
+// POST: The settings are replaced
+func (m *mockReferralStore) SaveSettings(_ context.Context, value referralDomain.Settings) error {
+	m.settings = &value
+	return nil
+}
+
+// registerReferred posts the registration form for a new member referred by referrer.
+func registerReferred(t *testing.T, email, referrerID, referrerName string) int {
+	t.Helper()
+	form := url.Values{"Name": {"Ana Ngata"}, "Email": {email}, "Program": {"adults"},
+		"ReferredByMemberID": {referrerID}, "ReferredByName": {referrerName}}
+	req := httptest.NewRequest("POST", "/members", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = req.WithContext(middleware.ContextWithSession(req.Context(), adminSession))
+	rec := httptest.NewRecorder()
+	handleMembers(rec, req)
+	return rec.Code
+}
+
+// TestReferrals_RegisterAndLeaderboard verifies a referrer picked at registration is recorded
+// and converted straight away, an unknown referrer is rejected, and the leaderboard counts it.
+func TestReferrals_RegisterAndLeaderboard(t *testing.T) {
+	stores = newFullStores()
+	stores.MemberStore.Save(context.Background(), memberDomain.Member{ID: "ref-1", Name: "Hemi Walker", Email: "hemi@example.com", Program: "adults", Status: memberDomain.StatusActive})
+	referrals := stores.ReferralStore.(*mockReferralStore)
+
+	if code := registerReferred(t, "ana@example.com", "nobody", ""); code != http.StatusBadRequest {
+		t.Errorf("unknown referrer: expected 400, got %d", code)
+	}
+	if code := registerReferred(t, "ana@example.com", "ref-1", ""); code != http.StatusNoContent {
+		t.Fatalf("register: expected 204, got %d", code)
+	}
+	if code := registerReferred(t, "tui@example.com", "", "Aunty Mere"); code != http.StatusNoContent {
+		t.Fatalf("register with a free-text referrer: expected 204, got %d", code)
+	}
+	if len(referrals.referrals) != 2 {
+		t.Fatalf("referrals = %+v, want two", referrals.referrals)
+	}
+	for _, r := range referrals.referrals {
+		if !r.IsConverted() {
+			t.Errorf("referral %+v not converted; registered members are active at once", r)
+		}
+		if (r.ReferrerMemberID == "ref-1") != (r.Reward == referralDomain.RewardThankYou) {
+			t.Errorf("referral %+v: want a thank-you only for the member referrer", r)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handleReferralLeaderboard(rec, authRequest("GET", "/api/referrals/leaderboard", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("leaderboard: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var board projections.ReferralLeaderboardResult
+	json.NewDecoder(rec.Body).Decode(&board)
+	if board.Referred != 2 || board.Converted != 2 || len(board.Leaders) != 2 || board.Leaders[0].ReferrerName != "Aunty Mere" {
+		t.Errorf("leaderboard = %+v, want two referrers ranked by name on a tie", board)
+	}
+}
+
+// TestReferralSettings verifies admins can switch referrers to a credit note and bad values are rejected.
+func TestReferralSettings(t *testing.T) {
+	stores = newFullStores()
+	put := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handleReferralSettings(rec, authRequest("PUT", "/api/referrals/settings", body, adminSession))
+		return rec
+	}
+	if rec := put(`{"Enabled":true,"Reward":"credit","CreditAmount":0}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("zero credit: expected 400, got %d", rec.Code)
+	}
+	if rec := put(`{"Enabled":true,"Reward":"voucher"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown reward: expected 400, got %d", rec.Code)
+	}
+	if rec := put(`{"Enabled":true,"Reward":"credit","CreditAmount":25}`); rec.Code != http.StatusOK {
+		t.Fatalf("credit: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec := httptest.NewRecorder()
+	handleReferralSettings(rec, authRequest("GET", "/api/referrals/settings", "", adminSession))
+	var got referralDomain.Settings
+	json.NewDecoder(rec.Body).Decode(&got)
+	if got.Reward != referralDomain.RewardCredit || got.CreditAmount != 25 || got.UpdatedBy != adminSession.AccountID {
+		t.Errorf("settings = %+v, want a $25 credit set by the admin", got)
+	}
+}
+
+// TestReferrals_AdminOnly verifies coaches cannot see the leaderboard or change rewards.
+func TestReferrals_AdminOnly(t *testing.T) {
+	stores = newFullStores()
+	for _, tt := range []struct {
+		handler http.HandlerFunc
+		method  string
+		url     string
+	}{
+		{handleReferralLeaderboard, "GET", "/api/referrals/leaderboard"},
+		{handleReferralSettings, "PUT", "/api/referrals/settings"},
+	} {
+		rec := httptest.NewRecorder()
+		tt.handler(rec, authRequest(tt.method, tt.url, `{}`, coachSession))
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s as coach: expected 403, got %d", tt.url, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	permissionDomain "workshop/internal/domain/permission"
+	rotorDomain "workshop/internal/domain/rotor"
+)
+
+// rotorThemeCloneRequest is the body of POST /api/rotors/themes/clone.
+type rotorThemeCloneRequest struct {
+	ThemeID string `json:"theme_id"`
+	RotorID string `json:"rotor_id"` // the draft rotor receiving the copy
+}
+
+// handleRotorThemeClone handles POST /api/rotors/themes/clone
+// Copies a theme and its topics into another class's draft rotor, after its existing
+// themes. Topics linked to the shared library stay linked. Coaches and admins only.
+func handleRotorThemeClone(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "curriculum") {
+		return
+	}
+	if !permissionAllowed(ctx, sess, permissionDomain.ActionCurriculumEdit) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+	var input rotorThemeCloneRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if input.ThemeID == "" || input.RotorID == "" {
+		apierror.Validation(w, "theme_id and rotor_id are required")
+		return
+	}
+	if _, err := stores.RotorStore.GetRotorTheme(ctx, input.ThemeID); err != nil {
+		apierror.NotFound(w, "theme not found")
+		return
+	}
+	if _, err := stores.RotorStore.GetRotor(ctx, input.RotorID); err != nil {
+		apierror.NotFound(w, "Rotor not found")
+		return
+	}
+
+	result, err := orchestrators.ExecuteCloneRotorTheme(ctx, orchestrators.CloneRotorThemeInput{
+		ThemeID:  input.ThemeID,
+		RotorID:  input.RotorID,
+		ClonedBy: sess.AccountID,
+	}, orchestrators.CloneRotorThemeDeps{
+		RotorStore: stores.RotorStore,
+		GenerateID: generateID,
+	})
+	if errors.Is(err, rotorDomain.ErrNotDraft) {
+		apierror.Validation(w, "can only add themes to draft rotors")
+		return
+	}
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(result)
+}
+
+// sharedTopicView is one library topic in GET /api/rotors/shared-topics.
+type sharedTopicView struct {
+	rotorDomain.SharedTopic
+	Links int // class topics that follow this entry
+}
+
+// sharedTopicRequest is the body of POST /api/rotors/shared-topics.
+type sharedTopicRequest struct {
+	ID            string `json:"id"`             // empty adds a new library topic
+	Name          string `json:"name"`           // empty keeps the current name
+	Description   string `json:"description"`    // replaces the current description
+	DurationWeeks int    `json:"duration_weeks"` // 0 keeps the current length
+	FromTopicID   string `json:"from_topic_id"`  // share a class topic: fills blank fields and links it
+}
+
+// handleSharedTopics handles GET/POST/DELETE for /api/rotors/shared-topics
+// The shared topic library: topics any class rotor can follow, so one description edit
+// reaches every class teaching it. Saving rewrites the name and description of every
+// linked topic; deleting unlinks them and leaves their wording as it was.
+func handleSharedTopics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "curriculum") {
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		if !permissionAllowed(ctx, sess, permissionDomain.ActionCurriculumView) {
+			apierror.Forbidden(w, "Forbidden")
+			return
+		}
+		list, err := stores.RotorStore.ListSharedTopics(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		links, err := stores.RotorStore.CountSharedTopicLinks(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		view := []sharedTopicView{}
+		for _, st := range list {
+			view = append(view, sharedTopicView{SharedTopic: st, Links: links[st.ID]})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(view)
+
+	case "POST":
+		if !permissionAllowed(ctx, sess, permissionDomain.ActionCurriculumEdit) {
+			apierror.Forbidden(w, "Forbidden")
+			return
+		}
+		var input sharedTopicRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		if input.ID != "" {
+			if _, err := stores.RotorStore.GetSharedTopic(ctx, input.ID); err != nil {
+				apierror.NotFound(w, "shared topic not found")
+				return
+			}
+		}
+		if input.FromTopicID != "" {
+			if _, err := stores.RotorStore.GetTopic(ctx, input.FromTopicID); err != nil {
+				apierror.NotFound(w, "topic not found")
+				return
+			}
+		}
+		st, err := orchestrators.ExecuteSaveSharedTopic(ctx, orchestrators.SaveSharedTopicInput{
+			ID:            input.ID,
+			Name:          input.Name,
+			Description:   input.Description,
+			DurationWeeks: input.DurationWeeks,
+			FromTopicID:   input.FromTopicID,
+			SavedBy:       sess.AccountID,
+		}, orchestrators.SaveSharedTopicDeps{
+			RotorStore: stores.RotorStore,
+			GenerateID: generateID,
+			Now:        timeNow,
+		})
+		if err != nil {
+			if isSharedTopicValidationError(err) {
+				apierror.Validation(w, err.Error())
+				return
+			}
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if input.ID == "" {
+			w.WriteHeader(http.StatusCreated)
+		}
+		json.NewEncoder(w).Encode(st)
+
+	case "DELETE":
+		if !permissionAllowed(ctx, sess, permissionDomain.ActionCurriculumEdit) {
+			apierror.Forbidden(w, "Forbidden")
+			return
+		}
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			apierror.Validation(w, "id is required")
+			return
+		}
+		if err := stores.RotorStore.DeleteSharedTopic(ctx, id); err != nil {
+			internalError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// isSharedTopicValidationError reports whether err is a library topic the caller got wrong.
+func isSharedTopicValidationError(err error) bool {
+	for _, e := range []error{rotorDomain.ErrEmptyTopicName, rotorDomain.ErrTopicNameTooLong, rotorDomain.ErrInvalidDuration, rotorDomain.ErrTopicDescriptionTooLong} {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestHandleRotorLibrary_MemberForbidden verifies members cannot clone themes or change the shared library.
+func TestHandleRotorLibrary_MemberForbidden(t *testing.T) {
+	stores = newFullStores()
+
+	rec := httptest.NewRecorder()
+	handleRotorThemeClone(rec, authRequest("POST", "/api/rotors/themes/clone", `{"theme_id":"t1","rotor_id":"r2"}`, memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("clone: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleSharedTopics(rec, authRequest("POST", "/api/rotors/shared-topics", `{"name":"Double Leg"}`, memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("save: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleSharedTopics(rec, authRequest("DELETE", "/api/rotors/shared-topics?id=st1", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("delete: expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleRotorThemeClone_RequiresIDs verifies a clone names both the theme and the target rotor.
+func TestHandleRotorThemeClone_RequiresIDs(t *testing.T) {
+	stores = newFullStores()
+
+	rec := httptest.NewRecorder()
+	handleRotorThemeClone(rec, authRequest("POST", "/api/rotors/themes/clone", `{"theme_id":"t1"}`, coachSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,182 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	permissionDomain "workshop/internal/domain/permission"
+	rotorDomain "workshop/internal/domain/rotor"
+)
+
+// rotorImportMaxBytes caps the size of an uploaded rotor document.
+const rotorImportMaxBytes = 1 << 20 // 1 MB
+
+// wantsRotorYAML reports whether the request asks for the YAML document format,
+// via ?format=yaml or a YAML media type in the given header.
+func wantsRotorYAML(r *http.Request, header string) bool {
+	if f := r.URL.Query().Get("format"); f != "" {
+		return f == "yaml" || f == "yml"
+	}
+	return strings.Contains(r.Header.Get(header), "yaml")
+}
+
+// handleRotorExport handles GET /api/rotors/export?id=<id>[&format=json|yaml]
+// Downloads the rotor's themes and topics as a portable document. Coaches and admins only.
+func handleRotorExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "curriculum") {
+		return
+	}
+	if !permissionAllowed(r.Context(), sess, permissionDomain.ActionCurriculumEdit) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		apierror.Validation(w, "id is required")
+		return
+	}
+
+	if _, err := stores.RotorStore.GetRotor(ctx, id); err != nil {
+		apierror.NotFound(w, "Rotor not found")
+		return
+	}
+	doc, err := projections.QueryGetRotorDocument(ctx, id, projections.GetRotorDocumentDeps{
+		RotorStore: stores.RotorStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	if wantsRotorYAML(r, "Accept") {
+		w.Header().Set("Content-Type", "application/yaml; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"rotor-%s.yaml\"", id))
+		w.Write(doc.EncodeYAML())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"rotor-%s.json\"", id))
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(doc)
+}
+
+// handleRotorImport handles POST /api/rotors/import[?class_type_id=<id>][&format=json|yaml]
+// The body is a document produced by export, sent as JSON or YAML (Content-Type: application/yaml).
+// The target class type comes from the query string, falling back to the document's class_type_id.
+// The rotor is created as a draft at the class type's next version. Coaches and admins only.
+func handleRotorImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "curriculum") {
+		return
+	}
+	if !permissionAllowed(r.Context(), sess, permissionDomain.ActionCurriculumEdit) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, rotorImportMaxBytes))
+	if err != nil {
+		apierror.TooLarge(w, "rotor document too large")
+		return
+	}
+	var doc rotorDomain.Document
+	if wantsRotorYAML(r, "Content-Type") {
+		doc, err = rotorDomain.DecodeDocumentYAML(body)
+		if err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+	} else {
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&doc); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+	}
+
+	classTypeID := r.URL.Query().Get("class_type_id")
+	if classTypeID == "" {
+		classTypeID = doc.ClassTypeID
+	}
+	if classTypeID == "" {
+		apierror.Validation(w, "class_type_id is required")
+		return
+	}
+	if _, err := stores.ClassTypeStore.GetByID(ctx, classTypeID); err != nil {
+		apierror.NotFound(w, "class type not found")
+		return
+	}
+
+	rotor, err := orchestrators.ExecuteImportRotor(ctx, orchestrators.ImportRotorInput{
+		ClassTypeID: classTypeID,
+		CreatedBy:   sess.AccountID,
+		Document:    doc,
+	}, orchestrators.ImportRotorDeps{
+		RotorStore: stores.RotorStore,
+		GenerateID: generateID,
+		Now:        timeNow,
+	})
+	if err != nil {
+		if isRotorDocumentError(err) {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(rotor)
+}
+
+// isRotorDocumentError reports whether err is a document validation failure the caller can fix.
+func isRotorDocumentError(err error) bool {
+	for _, target := range []error{
+		rotorDomain.ErrUnsupportedDocumentVersion,
+		rotorDomain.ErrTooManyDocumentThemes,
+		rotorDomain.ErrTooManyDocumentTopics,
+		rotorDomain.ErrEmptyName,
+		rotorDomain.ErrRotorNameTooLong,
+		rotorDomain.ErrEmptyThemeName,
+		rotorDomain.ErrThemeNameTooLong,
+		rotorDomain.ErrEmptyTopicName,
+		rotorDomain.ErrTopicNameTooLong,
+		rotorDomain.ErrTopicDescriptionTooLong,
+		rotorDomain.ErrInvalidDuration,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
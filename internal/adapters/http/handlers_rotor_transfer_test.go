@@ -0,0 +1,55 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestHandleRotorTransfer_MemberForbidden verifies members cannot export or import rotors.
+func TestHandleRotorTransfer_MemberForbidden(t *testing.T) {
+	stores = newFullStores()
+
+	rec := httptest.NewRecorder()
+	handleRotorExport(rec, authRequest("GET", "/api/rotors/export?id=r1", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("export: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleRotorImport(rec, authRequest("POST", "/api/rotors/import?class_type_id=ct1", `{"format_version":1}`, memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("import: expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleRotorImport_RejectsBadInput verifies malformed documents and unknown class types fail before any write.
+func TestHandleRotorImport_RejectsBadInput(t *testing.T) {
+	stores = newFullStores()
+
+	req := authRequest("POST", "/api/rotors/import?class_type_id=ct1", "name: [broken]\n", coachSession)
+	req.Header.Set("Content-Type", "application/yaml")
+	rec := httptest.NewRecorder()
+	handleRotorImport(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("yaml: expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleRotorImport(rec, authRequest("POST", "/api/rotors/import", `{"format_version":1,"name":"x","colour":"red"}`, coachSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown field: expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleRotorImport(rec, authRequest("POST", "/api/rotors/import", `{"format_version":1,"name":"x","themes":[]}`, coachSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("missing class type: expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleRotorImport(rec, authRequest("POST", "/api/rotors/import?class_type_id=missing", `{"format_version":1,"name":"x","themes":[]}`, coachSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown class type: expected 404, got %d", rec.Code)
+	}
+}
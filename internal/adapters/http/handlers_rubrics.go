@@ -0,0 +1,125 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	permissionDomain "workshop/internal/domain/permission"
+	rubricDomain "workshop/internal/domain/rubric"
+)
+
+// rubricTemplateRequest is the body of POST /api/grading/rubrics.
+type rubricTemplateRequest struct {
+	ID       string                   `json:"ID"` // empty creates a new template
+	Name     string                   `json:"Name"`
+	Criteria []rubricDomain.Criterion `json:"Criteria"` // omit Key for new criteria
+	Archived bool                     `json:"Archived"`
+}
+
+// handleRubricTemplates handles GET/POST /api/grading/rubrics
+// GET lists every rubric template, archived included, for coaches to score against.
+// POST creates or updates a template; admin only. Archive rather than delete so history keeps its names.
+func handleRubricTemplates(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requirePermission(w, r, permissionDomain.ActionGradingManage)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		templates, err := stores.RubricTemplateStore.List(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if templates == nil {
+			templates = []rubricDomain.Template{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(templates)
+
+	case "POST":
+		if sess.Role != "admin" {
+			apierror.Forbidden(w, "admin access required")
+			return
+		}
+		var input rubricTemplateRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		if input.ID != "" {
+			if _, err := stores.RubricTemplateStore.GetByID(ctx, input.ID); err != nil {
+				apierror.NotFound(w, "rubric template not found")
+				return
+			}
+		}
+		for i := range input.Criteria {
+			input.Criteria[i].Label = strings.TrimSpace(input.Criteria[i].Label)
+		}
+		tmpl, err := orchestrators.ExecuteSaveRubricTemplate(ctx, orchestrators.SaveRubricTemplateInput{
+			ID:        input.ID,
+			Name:      strings.TrimSpace(input.Name),
+			Criteria:  input.Criteria,
+			Archived:  input.Archived,
+			CreatedBy: sess.AccountID,
+		}, orchestrators.SaveRubricTemplateDeps{
+			TemplateStore: stores.RubricTemplateStore,
+			GenerateID:    generateID,
+			Now:           timeNow,
+		})
+		if err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if input.ID == "" {
+			w.WriteHeader(http.StatusCreated)
+		}
+		json.NewEncoder(w).Encode(tmpl)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleRubricHistory handles GET /api/grading/rubrics/history?member_id=
+// Returns every rubric a member has been scored on, newest first, with per-criterion averages.
+// Coaches and admins with grading permission only; scores are as private as the notes they came with.
+func handleRubricHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionGradingManage)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	memberID := r.URL.Query().Get("member_id")
+	if memberID == "" {
+		apierror.Validation(w, "member_id is required")
+		return
+	}
+
+	history, err := projections.QueryGetRubricHistory(r.Context(), projections.GetRubricHistoryQuery{MemberID: memberID}, projections.GetRubricHistoryDeps{
+		ScoreStore:    stores.RubricScoreStore,
+		TemplateStore: stores.RubricTemplateStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
@@ -0,0 +1,146 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/projections"
+	rubricDomain "workshop/internal/domain/rubric"
+)
+
+type mockRubricTemplateStore struct {
+	templates map[string]rubricDomain.Template
+}
+
+// GetByID implements rubric.TemplateStore for testing.
+// PRE: id is non-empty
+// POST: Returns the template or sql.ErrNoRows
+func (m *mockRubricTemplateStore) GetByID(_ context.Context, id string) (rubricDomain.Template, error) {
+	t, ok := m.templates[id]
+	if !ok {
+		return rubricDomain.Template{}, sql.ErrNoRows
+	}
+	return t, nil
+}
+
+// Save implements rubric.TemplateStore for testing.
+// PRE: value has been validated
+// POST: Template is upserted
+func (m *mockRubricTemplateStore) Save(_ context.Context, value rubricDomain.Template) error {
+	m.templates[value.ID] = value
+	return nil
+}
+
+// List implements rubric.TemplateStore for testing.
+// PRE: none
+// POST: Returns every template
+func (m *mockRubricTemplateStore) List(_ context.Context) ([]rubricDomain.Template, error) {
+	var list []rubricDomain.Template
+	for _, t := range m.templates {
+		list = append(list, t)
+	}
+	return list, nil
+}
+
+type mockRubricScoreStore struct {
+	scores []rubricDomain.Score
+}
+
+// SaveAll implements rubric.ScoreStore for testing.
+// PRE: values were built by Template.NewScores
+// POST: Scores are appended
+func (m *mockRubricScoreStore) SaveAll(_ context.Context, values []rubricDomain.Score) error {
+	m.scores = append(m.scores, values...)
+	return nil
+}
+
+// ListByMemberID implements rubric.ScoreStore for testing.
+// PRE: memberID is non-empty
+// POST: Returns the member's scores
+func (m *mockRubricScoreStore) ListByMemberID(_ context.Context, memberID string) ([]rubricDomain.Score, error) {
+	var list []rubricDomain.Score
+	for _, s := range m.scores {
+		if s.MemberID == memberID {
+			list = append(list, s)
+		}
+	}
+	return list, nil
+}
+
+// TestHandleRubrics verifies an admin defines a rubric, a coach scores an observation and a
+// grading note against it, and the member's history averages both.
+func TestHandleRubrics(t *testing.T) {
+	stores = newFullStores()
+	stores.RubricTemplateStore = &mockRubricTemplateStore{templates: map[string]rubricDomain.Template{}}
+	stores.RubricScoreStore = &mockRubricScoreStore{}
+
+	rec := httptest.NewRecorder()
+	handleRubricTemplates(rec, authRequest("POST", "/api/grading/rubrics",
+		`{"Name":"Guard game","Criteria":[{"Label":"Guard retention","Min":1,"Max":5},{"Label":"Escapes","Min":1,"Max":5}]}`, adminSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var tmpl rubricDomain.Template
+	json.NewDecoder(rec.Body).Decode(&tmpl)
+	retention, escapes := tmpl.Criteria[0].Key, tmpl.Criteria[1].Key
+
+	rec = httptest.NewRecorder()
+	handleObservations(rec, authRequest("POST", "/api/observations",
+		`{"MemberID":"m1","Content":"Solid frames","RubricID":"`+tmpl.ID+`","Scores":{"`+retention+`":4,"`+escapes+`":2}}`, coachSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("observation: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	handleGradingNotes(rec, authRequest("POST", "/api/grading/notes",
+		`{"MemberID":"m1","Content":"Ready soon","RubricID":"`+tmpl.ID+`","Scores":{"`+retention+`":5}}`, coachSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("grading note: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleRubricHistory(rec, authRequest("GET", "/api/grading/rubrics/history?member_id=m1", "", coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("history: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var history projections.GetRubricHistoryResult
+	json.NewDecoder(rec.Body).Decode(&history)
+	if len(history.Assessments) != 2 || len(history.Averages) != 2 {
+		t.Fatalf("expected 2 assessments over 2 criteria, got %+v", history)
+	}
+	if a := history.Averages[0]; a.Label != "Guard retention" || a.Average != 4.5 || a.Count != 2 {
+		t.Errorf("retention average = %+v, want 4.5 over 2", a)
+	}
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		method  string
+		url     string
+		body    string
+		sess    middleware.Session
+		want    int
+	}{
+		{"coach lists templates", handleRubricTemplates, "GET", "/api/grading/rubrics", "", coachSession, http.StatusOK},
+		{"coach cannot create", handleRubricTemplates, "POST", "/api/grading/rubrics", `{"Name":"x","Criteria":[{"Label":"a","Min":1,"Max":5}]}`, coachSession, http.StatusForbidden},
+		{"member cannot list", handleRubricTemplates, "GET", "/api/grading/rubrics", "", memberSession, http.StatusForbidden},
+		{"update unknown", handleRubricTemplates, "POST", "/api/grading/rubrics", `{"ID":"nope","Name":"x","Criteria":[{"Label":"a","Min":1,"Max":5}]}`, adminSession, http.StatusNotFound},
+		{"invalid scale", handleRubricTemplates, "POST", "/api/grading/rubrics", `{"Name":"x","Criteria":[{"Label":"a","Min":5,"Max":1}]}`, adminSession, http.StatusBadRequest},
+		{"score out of range", handleObservations, "POST", "/api/observations", `{"MemberID":"m1","Content":"x","RubricID":"` + tmpl.ID + `","Scores":{"` + escapes + `":9}}`, coachSession, http.StatusBadRequest},
+		{"member cannot read history", handleRubricHistory, "GET", "/api/grading/rubrics/history?member_id=m1", "", memberSession, http.StatusForbidden},
+		{"history without member", handleRubricHistory, "GET", "/api/grading/rubrics/history", "", coachSession, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handler(rec, authRequest(tt.method, tt.url, tt.body, tt.sess))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
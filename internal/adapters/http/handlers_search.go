@@ -0,0 +1,143 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/projections"
+	permissionDomain "workshop/internal/domain/permission"
+	"workshop/internal/domain/search"
+)
+
+// searchKindFeatures maps each searchable kind to the feature that must be enabled for
+// the caller to see it. Notices have no feature of their own and are always searched.
+var searchKindFeatures = map[string]string{
+	search.KindMember:  "member_mgmt",
+	search.KindClip:    "library",
+	search.KindTopic:   "curriculum",
+	search.KindMessage: "messages",
+}
+
+// searchKindLabels are the group headings shown in the search dropdown.
+var searchKindLabels = map[string]string{
+	search.KindMember:  "Members",
+	search.KindNotice:  "Notices",
+	search.KindClip:    "Clips",
+	search.KindTopic:   "Topics",
+	search.KindMessage: "Messages",
+}
+
+// searchResultURL returns the page a result links to for the caller's role.
+func searchResultURL(kind, refID string, staff bool) string {
+	switch kind {
+	case search.KindMember:
+		return "/members/profile?id=" + refID
+	case search.KindClip:
+		return "/library"
+	case search.KindTopic:
+		return "/curriculum"
+	case search.KindMessage:
+		if staff {
+			return "/messages"
+		}
+		return "/inbox"
+	default:
+		return "/dashboard"
+	}
+}
+
+// handleSearch handles GET /api/search?q=&limit=
+// Returns hits grouped by kind, filtered to what the caller's role and features allow:
+// members are staff-only and need the members.view permission, draft notices and hidden topics need staff, and messages are
+// limited to the caller's own conversations.
+func handleSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "search") {
+		return
+	}
+	if stores.SearchStore == nil {
+		apierror.Unavailable(w, "search is not configured")
+		return
+	}
+
+	staff := sess.Role == "admin" || sess.Role == "coach"
+	input := projections.SearchInput{
+		Text:         r.URL.Query().Get("q"),
+		Staff:        staff,
+		Participants: []string{sess.AccountID},
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > search.MaxResultsLimit {
+			apierror.Validation(w, "limit must be between 1 and 20")
+			return
+		}
+		input.PerKind = n
+	}
+	for _, kind := range search.Kinds {
+		if feature, gated := searchKindFeatures[kind]; gated && !featureEnabledForSession(ctx, sess, feature) {
+			continue
+		}
+		if kind == search.KindMember && !permissionAllowed(ctx, sess, permissionDomain.ActionMembersView) {
+			continue
+		}
+		input.Kinds = append(input.Kinds, kind)
+	}
+	if m, err := stores.MemberStore.GetByAccountID(ctx, sess.AccountID); err == nil {
+		input.Participants = append(input.Participants, m.ID)
+	}
+
+	result, err := projections.QuerySearch(ctx, input, projections.SearchDeps{SearchStore: stores.SearchStore})
+	if errors.Is(err, search.ErrQueryTooShort) || errors.Is(err, search.ErrQueryTooLong) {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	type resultJSON struct {
+		ID      string
+		Title   string
+		Snippet string
+		URL     string
+	}
+	type groupJSON struct {
+		Kind    string
+		Label   string
+		Results []resultJSON
+	}
+	groups := make([]groupJSON, 0, len(result.Groups))
+	for _, g := range result.Groups {
+		out := groupJSON{Kind: g.Kind, Label: searchKindLabels[g.Kind]}
+		for _, hit := range g.Results {
+			out.Results = append(out.Results, resultJSON{
+				ID:      hit.RefID,
+				Title:   hit.Title,
+				Snippet: hit.Snippet,
+				URL:     searchResultURL(hit.Kind, hit.RefID, staff),
+			})
+		}
+		groups = append(groups, out)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"Query":  result.Query,
+		"Groups": groups,
+	})
+}
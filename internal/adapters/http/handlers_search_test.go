@@ -0,0 +1,146 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/adapters/http/middleware"
+	featureflagDomain "workshop/internal/domain/featureflag"
+	memberDomain "workshop/internal/domain/member"
+	"workshop/internal/domain/search"
+)
+
+// --- Mock Search store ---
+
+type mockSearchStore struct {
+	last search.Query
+}
+
+// Reindex implements search.Store for testing.
+// PRE: kind is a valid search kind
+// POST: no-op
+func (m *mockSearchStore) Reindex(_ context.Context, _, _ string) error { return nil }
+
+// Remove implements search.Store for testing.
+// PRE: kind and refID are non-empty
+// POST: no-op
+func (m *mockSearchStore) Remove(_ context.Context, _, _ string) error { return nil }
+
+// Rebuild implements search.Store for testing.
+// PRE: none
+// POST: no-op
+func (m *mockSearchStore) Rebuild(_ context.Context) error { return nil }
+
+// Search implements search.Store for testing.
+// PRE: q has been validated
+// POST: records q and returns one hit per requested kind
+func (m *mockSearchStore) Search(_ context.Context, q search.Query) ([]search.Result, error) {
+	m.last = q
+	var hits []search.Result
+	for _, k := range q.Kinds {
+		hits = append(hits, search.Result{Kind: k, RefID: k + "-1", Title: "Armbar", Snippet: "[Armbar]"})
+	}
+	return hits, nil
+}
+
+type searchResponse struct {
+	Query  string
+	Groups []struct {
+		Kind    string
+		Label   string
+		Results []struct{ ID, Title, Snippet, URL string }
+	}
+}
+
+func doSearch(t *testing.T, url string, sess middleware.Session) (*httptest.ResponseRecorder, searchResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handleSearch(rec, authRequest("GET", url, "", sess))
+	var resp searchResponse
+	if rec.Code == http.StatusOK {
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+	}
+	return rec, resp
+}
+
+// TestHandleSearch_StaffSeesMembers verifies staff get member results linking to the profile.
+func TestHandleSearch_StaffSeesMembers(t *testing.T) {
+	stores = newFullStores()
+	idx := &mockSearchStore{}
+	stores.SearchStore = idx
+
+	rec, resp := doSearch(t, "/api/search?q=arm", coachSession)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !idx.last.Staff {
+		t.Error("expected staff query")
+	}
+	if len(resp.Groups) != len(search.Kinds) || resp.Groups[0].Kind != search.KindMember {
+		t.Fatalf("expected every kind with members first, got %+v", resp.Groups)
+	}
+	if got := resp.Groups[0].Results[0].URL; got != "/members/profile?id=member-1" {
+		t.Errorf("member URL = %q", got)
+	}
+	if resp.Groups[0].Label != "Members" {
+		t.Errorf("label = %q", resp.Groups[0].Label)
+	}
+}
+
+// TestHandleSearch_MemberFiltering verifies members never search members, disabled
+// features drop their kind, and the caller's member ID is a message participant.
+func TestHandleSearch_MemberFiltering(t *testing.T) {
+	stores = newFullStores()
+	idx := &mockSearchStore{}
+	stores.SearchStore = idx
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m-42", AccountID: memberSession.AccountID, Name: "Marcus", Email: memberSession.Email, Program: "adults", Status: "active"})
+	stores.FeatureFlagStore.Save(ctx, featureflagDomain.FeatureFlag{Key: "library", EnabledAdmin: true, EnabledCoach: true})
+
+	rec, resp := doSearch(t, "/api/search?q=arm&limit=3", memberSession)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	want := []string{search.KindNotice, search.KindTopic, search.KindMessage}
+	if len(resp.Groups) != len(want) {
+		t.Fatalf("expected %v, got %+v", want, resp.Groups)
+	}
+	for i, k := range want {
+		if resp.Groups[i].Kind != k {
+			t.Errorf("group %d = %s, want %s", i, resp.Groups[i].Kind, k)
+		}
+	}
+	if idx.last.Staff || idx.last.PerKind != 3 {
+		t.Errorf("query = %+v, want non-staff with PerKind 3", idx.last)
+	}
+	if len(idx.last.Participants) != 2 || idx.last.Participants[1] != "m-42" {
+		t.Errorf("participants = %v, want account and member IDs", idx.last.Participants)
+	}
+	if got := resp.Groups[2].Results[0].URL; got != "/inbox" {
+		t.Errorf("member message URL = %q, want /inbox", got)
+	}
+}
+
+// TestHandleSearch_BadRequests verifies query and limit validation.
+func TestHandleSearch_BadRequests(t *testing.T) {
+	stores = newFullStores()
+	stores.SearchStore = &mockSearchStore{}
+
+	for _, url := range []string{"/api/search?q=a", "/api/search?q=armbar&limit=0", "/api/search?q=armbar&limit=x"} {
+		rec, _ := doSearch(t, url, adminSession)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", url, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handleSearch(rec, httptest.NewRequest("GET", "/api/search?q=armbar", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without session, got %d", rec.Code)
+	}
+}
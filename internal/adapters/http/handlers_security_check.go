@@ -0,0 +1,101 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/config"
+)
+
+// securityCheck is one protection reported by GET /api/admin/security-check.
+type securityCheck struct {
+	Name   string
+	Active bool
+	Detail string
+}
+
+// securityCheckPaths are the pages whose headers the self-check shows: an ordinary page and
+// the kiosk board, which may be framed.
+var securityCheckPaths = []string{"/dashboard", "/kiosk/board"}
+
+// handleAdminSecurityCheck handles GET /api/admin/security-check
+// Reports which security protections are active and the headers sample pages get. Admin only.
+func handleAdminSecurityCheck(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "config") {
+		return
+	}
+
+	policy := securityPolicy()
+	page := policy.Headers("/dashboard")
+	csrfKeySource := config.SourceDefault
+	for _, s := range appConfig.Settings() {
+		if s.Key == "WORKSHOP_CSRF_KEY" {
+			csrfKeySource = s.Source
+		}
+	}
+	kiosk := strings.Join(appConfig.Security.FrameAncestors, " ")
+	if kiosk == "" {
+		kiosk = "'none'"
+	}
+
+	checks := []securityCheck{
+		{"Content-Security-Policy", page.Get("Content-Security-Policy") != "", "scripts, styles and frames are limited to the site and its known embeds"},
+		{"Strict-Transport-Security", policy.HSTSMaxAge > 0, hstsDetail(policy)},
+		{"X-Content-Type-Options", page.Get("X-Content-Type-Options") == "nosniff", "browsers may not guess content types"},
+		{"Referrer-Policy", page.Get("Referrer-Policy") != "", page.Get("Referrer-Policy")},
+		{"Framing", page.Get("X-Frame-Options") == "DENY", "pages refuse to be framed; the kiosk allows " + kiosk},
+		{"CSRF tokens", true, "forms and scripts must send the session's token"},
+		{"Cross-site JSON refused", true, "JSON writes from another site's page are refused"},
+		{"Secure cookies", middleware.SecureCookies, "cookies are only sent over HTTPS"},
+		{"Persistent CSRF key", csrfKeySource != config.SourceDefault, "WORKSHOP_CSRF_KEY from " + csrfKeySource + "; a random key logs everyone out on restart"},
+		{"Rate limiting", RateLimitPerSecond > 0, fmt.Sprintf("%d requests per second per IP", RateLimitPerSecond)},
+		{"Sign-in rate limiting", AuthLimitConfig.PerIP > 0 && AuthLimitConfig.PerEmail > 0,
+			fmt.Sprintf("%d attempts per IP and %d per email every %s", AuthLimitConfig.PerIP, AuthLimitConfig.PerEmail, AuthLimitConfig.Window)},
+		{"HTTPS public URL", strings.HasPrefix(appConfig.Email.PublicURL, "https://"), "WORKSHOP_PUBLIC_URL is " + valueOr(appConfig.Email.PublicURL, "unset")},
+	}
+	headers := make(map[string]map[string]string, len(securityCheckPaths))
+	for _, path := range securityCheckPaths {
+		h := policy.Headers(path)
+		flat := make(map[string]string, len(h))
+		for key := range h {
+			flat[key] = h.Get(key)
+		}
+		headers[path] = flat
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(map[string]any{
+		"Env":     appConfig.Env,
+		"Checks":  checks,
+		"Headers": headers,
+	})
+}
+
+// hstsDetail describes the Strict-Transport-Security setting.
+func hstsDetail(policy middleware.SecurityPolicy) string {
+	if policy.HSTSMaxAge <= 0 {
+		return "off; set WORKSHOP_HSTS_MAX_AGE_DAYS once the site is only served over HTTPS"
+	}
+	return fmt.Sprintf("browsers keep to HTTPS for %d days", int(policy.HSTSMaxAge.Hours()/24))
+}
+
+// valueOr returns value, or fallback when it is empty.
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,57 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/config"
+)
+
+// TestHandleAdminSecurityCheck verifies admins see which protections are active and the
+// kiosk board's framing allowance, and others are refused.
+func TestHandleAdminSecurityCheck(t *testing.T) {
+	stores = newFullStores()
+	previous := appConfig
+	defer func() { appConfig = previous }()
+	loaded, err := config.Parse(func(key string) (string, bool) {
+		if key == "WORKSHOP_HSTS_MAX_AGE_DAYS" {
+			return "365", true
+		}
+		return "", false
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetConfig(loaded)
+
+	rec := httptest.NewRecorder()
+	handleAdminSecurityCheck(rec, authRequest("GET", "/api/admin/security-check", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleAdminSecurityCheck(rec, authRequest("GET", "/api/admin/security-check", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("admin: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var got struct {
+		Checks  []securityCheck
+		Headers map[string]map[string]string
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	active := map[string]bool{}
+	for _, c := range got.Checks {
+		active[c.Name] = c.Active
+	}
+	if !active["Strict-Transport-Security"] || !active["Content-Security-Policy"] || active["Persistent CSRF key"] {
+		t.Errorf("unexpected checks: %+v", got.Checks)
+	}
+	if got.Headers["/dashboard"]["X-Frame-Options"] != "DENY" || got.Headers["/kiosk/board"]["X-Frame-Options"] != "SAMEORIGIN" {
+		t.Errorf("unexpected framing headers: %+v", got.Headers)
+	}
+}
@@ -0,0 +1,186 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	permissionDomain "workshop/internal/domain/permission"
+	sessionLogDomain "workshop/internal/domain/sessionlog"
+)
+
+// defaultSessionLogHistoryLimit caps GET /api/session-logs when no limit is given.
+const defaultSessionLogHistoryLimit = 100
+
+// sessionLogCreateRequest is the body of POST /api/session-logs.
+type sessionLogCreateRequest struct {
+	ScheduleID      string   `json:"ScheduleID"`
+	ClassDate       string   `json:"ClassDate"`
+	TopicIDs        []string `json:"TopicIDs"`
+	RoundStructure  string   `json:"RoundStructure"`
+	AttendanceNotes string   `json:"AttendanceNotes"`
+	Notes           string   `json:"Notes"`
+	Shareable       bool     `json:"Shareable"` // members may read Notes in the curriculum history
+}
+
+// handleSessionLogs handles GET/POST/DELETE for /api/session-logs
+// GET ?id= returns one log; otherwise returns history filtered by schedule_id, topic_id, from, to.
+// POST creates or updates the log for a schedule + class date. Coaches and admins only.
+func handleSessionLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "curriculum") {
+		return
+	}
+	if !permissionAllowed(ctx, sess, permissionDomain.ActionSessionLogsManage) {
+		apierror.Forbidden(w, "Forbidden")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		q := r.URL.Query()
+		if id := q.Get("id"); id != "" {
+			l, err := stores.SessionLogStore.GetByID(ctx, id)
+			if err != nil {
+				apierror.NotFound(w, "session log not found")
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(l)
+			return
+		}
+		limit := defaultSessionLogHistoryLimit
+		if v := q.Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				apierror.Validation(w, "limit must be a positive integer")
+				return
+			}
+			limit = n
+		}
+		entries, err := projections.QueryGetSessionLogHistory(ctx, projections.GetSessionLogHistoryQuery{
+			ScheduleID: q.Get("schedule_id"),
+			TopicID:    q.Get("topic_id"),
+			From:       q.Get("from"),
+			To:         q.Get("to"),
+			Limit:      limit,
+		}, projections.GetSessionLogHistoryDeps{
+			SessionLogStore: stores.SessionLogStore,
+			ScheduleStore:   stores.ScheduleStore,
+			ClassTypeStore:  stores.ClassTypeStore,
+			RotorStore:      stores.RotorStore,
+		})
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(entries)
+
+	case "POST":
+		var input sessionLogCreateRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		if input.ScheduleID == "" {
+			apierror.Validation(w, sessionLogDomain.ErrEmptyScheduleID.Error())
+			return
+		}
+		if _, err := stores.ScheduleStore.GetByID(ctx, input.ScheduleID); err != nil {
+			apierror.NotFound(w, "schedule not found")
+			return
+		}
+		if input.ClassDate == "" {
+			input.ClassDate = timeNow().Format(sessionLogDomain.DateLayout)
+		}
+
+		l, err := orchestrators.ExecuteSaveSessionLog(ctx, orchestrators.SaveSessionLogInput{
+			ScheduleID:      input.ScheduleID,
+			ClassDate:       input.ClassDate,
+			CoachID:         sess.AccountID,
+			TopicIDs:        input.TopicIDs,
+			RoundStructure:  input.RoundStructure,
+			AttendanceNotes: input.AttendanceNotes,
+			Notes:           input.Notes,
+			Shareable:       input.Shareable,
+		}, orchestrators.SaveSessionLogDeps{
+			SessionLogStore: stores.SessionLogStore,
+			ScheduleStore:   stores.ScheduleStore,
+			RotorStore:      stores.RotorStore,
+			GenerateID:      generateID,
+			Now:             timeNow,
+		})
+		if err != nil {
+			if isSessionLogInputError(err) {
+				apierror.Validation(w, err.Error())
+				return
+			}
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(l)
+
+	case "DELETE":
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			apierror.Validation(w, "id is required")
+			return
+		}
+		if err := stores.SessionLogStore.Delete(ctx, id); err != nil {
+			internalError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// isSessionLogInputError reports whether err is a validation failure the caller can fix.
+func isSessionLogInputError(err error) bool {
+	for _, target := range []error{
+		sessionLogDomain.ErrInvalidClassDate,
+		sessionLogDomain.ErrClassDateInFuture,
+		sessionLogDomain.ErrUnknownTopic,
+		sessionLogDomain.ErrTooManyTopics,
+		sessionLogDomain.ErrRoundStructureTooLong,
+		sessionLogDomain.ErrAttendanceNotesTooLong,
+		sessionLogDomain.ErrNotesTooLong,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleSessionLogsPage handles GET /session-logs — coach session log history.
+func handleSessionLogsPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requirePermissionPage(w, r, permissionDomain.ActionSessionLogsManage)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "curriculum") {
+		return
+	}
+	renderTemplate(w, r, "session_logs.html", map[string]interface{}{
+		"Title": "Session Logs",
+	})
+}
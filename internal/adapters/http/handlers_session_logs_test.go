@@ -0,0 +1,130 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sessionLogStore "workshop/internal/adapters/storage/sessionlog"
+	"workshop/internal/application/projections"
+	classTypeDomain "workshop/internal/domain/classtype"
+	scheduleDomain "workshop/internal/domain/schedule"
+	sessionLogDomain "workshop/internal/domain/sessionlog"
+)
+
+// --- Mock SessionLog store ---
+
+type mockSessionLogStore struct {
+	logs map[string]sessionLogDomain.SessionLog
+}
+
+// GetByID implements sessionlog.Store for testing.
+// PRE: id is non-empty
+// POST: returns the log or an error if not found
+func (m *mockSessionLogStore) GetByID(_ context.Context, id string) (sessionLogDomain.SessionLog, error) {
+	if l, ok := m.logs[id]; ok {
+		return l, nil
+	}
+	return sessionLogDomain.SessionLog{}, fmt.Errorf("not found: %s", id)
+}
+
+// GetByScheduleAndDate implements sessionlog.Store for testing.
+// PRE: scheduleID and classDate are non-empty
+// POST: returns the log for the class occurrence or an error
+func (m *mockSessionLogStore) GetByScheduleAndDate(_ context.Context, scheduleID, classDate string) (sessionLogDomain.SessionLog, error) {
+	for _, l := range m.logs {
+		if l.ScheduleID == scheduleID && l.ClassDate == classDate {
+			return l, nil
+		}
+	}
+	return sessionLogDomain.SessionLog{}, fmt.Errorf("not found: %s/%s", scheduleID, classDate)
+}
+
+// Save implements sessionlog.Store for testing.
+// PRE: log has a valid ID
+// POST: log is stored in memory
+func (m *mockSessionLogStore) Save(_ context.Context, l sessionLogDomain.SessionLog) error {
+	m.logs[l.ID] = l
+	return nil
+}
+
+// Delete implements sessionlog.Store for testing.
+// PRE: id is non-empty
+// POST: log is removed
+func (m *mockSessionLogStore) Delete(_ context.Context, id string) error {
+	delete(m.logs, id)
+	return nil
+}
+
+// List implements sessionlog.Store for testing.
+// PRE: none
+// POST: returns logs matching the schedule filter
+func (m *mockSessionLogStore) List(_ context.Context, filter sessionLogStore.ListFilter) ([]sessionLogDomain.SessionLog, error) {
+	var out []sessionLogDomain.SessionLog
+	for _, l := range m.logs {
+		if filter.ScheduleID == "" || l.ScheduleID == filter.ScheduleID {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func newSessionLogTestStores() *Stores {
+	s := newFullStores()
+	s.SessionLogStore = &mockSessionLogStore{logs: make(map[string]sessionLogDomain.SessionLog)}
+	ctx := context.Background()
+	s.ClassTypeStore.Save(ctx, classTypeDomain.ClassType{ID: "ct1", ProgramID: "p1", Name: "Adults Gi"})
+	s.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s1", ClassTypeID: "ct1", Day: "monday", StartTime: "18:00", EndTime: "19:00"})
+	return s
+}
+
+// TestHandleSessionLogs_MemberForbidden verifies members cannot read coach session logs.
+func TestHandleSessionLogs_MemberForbidden(t *testing.T) {
+	stores = newSessionLogTestStores()
+
+	rec := httptest.NewRecorder()
+	handleSessionLogs(rec, authRequest("GET", "/api/session-logs", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rec.Code)
+	}
+}
+
+// TestHandleSessionLogs_CoachSavesAndLists verifies a coach can log a class and see it in history.
+func TestHandleSessionLogs_CoachSavesAndLists(t *testing.T) {
+	stores = newSessionLogTestStores()
+
+	body := `{"ScheduleID":"s1","ClassDate":"2020-01-06","TopicIDs":[],"RoundStructure":"5x5","Notes":"Guard retention"}`
+	rec := httptest.NewRecorder()
+	handleSessionLogs(rec, authRequest("POST", "/api/session-logs", body, coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handleSessionLogs(rec, authRequest("GET", "/api/session-logs?schedule_id=s1", "", coachSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var entries []projections.SessionLogHistoryEntry
+	if err := json.NewDecoder(rec.Body).Decode(&entries); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ClassTypeName != "Adults Gi" || entries[0].CoachID != coachSession.AccountID {
+		t.Errorf("unexpected history: %+v", entries)
+	}
+
+	rec = httptest.NewRecorder()
+	handleSessionLogs(rec, authRequest("POST", "/api/session-logs", `{"ScheduleID":"s1","ClassDate":"2999-01-01","TopicIDs":[]}`, coachSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for future class, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleSessionLogs(rec, authRequest("POST", "/api/session-logs", `{"ScheduleID":"missing","ClassDate":"2020-01-06"}`, coachSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown schedule, got %d", rec.Code)
+	}
+}
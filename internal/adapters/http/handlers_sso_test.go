@@ -0,0 +1,146 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/adapters/oidc"
+	accountDomain "workshop/internal/domain/account"
+)
+
+// fakeSSOProvider answers every code with a fixed identity.
+type fakeSSOProvider struct {
+	identity oidc.Identity
+	nonce    string // nonce passed to the last Exchange
+	verifier string // verifier passed to the last Exchange
+}
+
+// Name implements oidc.Authenticator for testing.
+// PRE: none
+// POST: Returns "Google"
+func (f *fakeSSOProvider) Name() string { return "Google" }
+
+// AuthURL implements oidc.Authenticator for testing.
+// PRE: none
+// POST: Returns a provider URL carrying the state
+func (f *fakeSSOProvider) AuthURL(_ context.Context, state, _, _ string) (string, error) {
+	return "https://accounts.example/authorize?state=" + state, nil
+}
+
+// Exchange implements oidc.Authenticator for testing.
+// PRE: none
+// POST: Returns the configured identity and records the nonce and verifier
+func (f *fakeSSOProvider) Exchange(_ context.Context, _, verifier, nonce string) (oidc.Identity, error) {
+	f.nonce, f.verifier = nonce, verifier
+	return f.identity, nil
+}
+
+// startSSO runs /auth/oidc/start and returns the state cookie it set.
+func startSSO(t *testing.T) *http.Cookie {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handleOIDCStart(rec, httptest.NewRequest("GET", "/auth/oidc/start", nil))
+	if rec.Code != http.StatusSeeOther || !strings.HasPrefix(rec.Header().Get("Location"), "https://accounts.example/authorize?state=") {
+		t.Fatalf("expected redirect to provider, got %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == oidcCookieName {
+			if c.SameSite != http.SameSiteLaxMode || !c.HttpOnly {
+				t.Errorf("state cookie should be HttpOnly and SameSite=Lax: %+v", c)
+			}
+			return c
+		}
+	}
+	t.Fatal("no state cookie set")
+	return nil
+}
+
+// callbackSSO runs /auth/oidc/callback with the given state and cookie.
+func callbackSSO(state string, cookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/auth/oidc/callback?code=c1&state="+state, nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	handleOIDCCallback(rec, req)
+	return rec
+}
+
+// TestHandleOIDC_SignsInLinkedAccount verifies a verified Google email signs in the account
+// with that email, keeping its role.
+func TestHandleOIDC_SignsInLinkedAccount(t *testing.T) {
+	stores = newFullStores()
+	sessions = middleware.NewSessionStore(newMockAuthSessionStore())
+	provider := &fakeSSOProvider{identity: oidc.Identity{Subject: "g-1", Email: "coach@test.com", EmailVerified: true}}
+	ssoProvider = provider
+	defer func() { ssoProvider = nil }()
+	stores.AccountStore.Save(context.Background(), accountDomain.Account{ID: "a1", Email: "coach@test.com", Role: accountDomain.RoleCoach, Status: accountDomain.StatusActive})
+
+	cookie := startSSO(t)
+	parts := strings.Split(cookie.Value, ".")
+	rec := callbackSSO(parts[0], cookie)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `url=/dashboard`) {
+		t.Fatalf("expected hand-off page to /dashboard, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if provider.nonce != parts[1] || provider.verifier != parts[2] {
+		t.Errorf("exchange should use the stored nonce and verifier")
+	}
+	var token string
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == "workshop_session" {
+			token = c.Value
+		}
+	}
+	sess, ok := sessions.Get(context.Background(), token)
+	if !ok || sess.AccountID != "a1" || sess.Role != accountDomain.RoleCoach {
+		t.Errorf("expected coach session for a1, got %+v (ok=%v)", sess, ok)
+	}
+}
+
+// TestHandleOIDC_Rejections verifies forged state, unknown and unverified emails are turned away
+// without a session, and the routes 404 when single sign-on is off.
+func TestHandleOIDC_Rejections(t *testing.T) {
+	stores = newFullStores()
+	sessions = middleware.NewSessionStore(newMockAuthSessionStore())
+	provider := &fakeSSOProvider{identity: oidc.Identity{Subject: "g-2", Email: "stranger@test.com", EmailVerified: true}}
+	ssoProvider = provider
+	defer func() { ssoProvider = nil }()
+
+	cookie := startSSO(t)
+	if rec := callbackSSO("forged", cookie); rec.Code != http.StatusBadRequest {
+		t.Errorf("forged state: expected 400, got %d", rec.Code)
+	}
+	if rec := callbackSSO(strings.Split(cookie.Value, ".")[0], nil); rec.Code != http.StatusBadRequest {
+		t.Errorf("missing cookie: expected 400, got %d", rec.Code)
+	}
+
+	cookie = startSSO(t)
+	rec := callbackSSO(strings.Split(cookie.Value, ".")[0], cookie)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "no account uses this email address") {
+		t.Errorf("unknown email: expected login page with error, got %d", rec.Code)
+	}
+
+	provider.identity = oidc.Identity{Subject: "g-3", Email: "coach@test.com"}
+	stores.AccountStore.Save(context.Background(), accountDomain.Account{ID: "a1", Email: "coach@test.com", Role: accountDomain.RoleCoach, Status: accountDomain.StatusActive})
+	cookie = startSSO(t)
+	rec = callbackSSO(strings.Split(cookie.Value, ".")[0], cookie)
+	if !strings.Contains(rec.Body.String(), "has not verified this email address") {
+		t.Errorf("unverified email: expected error on login page, got %d", rec.Code)
+	}
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == "workshop_session" {
+			t.Error("no session should be created for a rejected sign-in")
+		}
+	}
+
+	ssoProvider = nil
+	rec = httptest.NewRecorder()
+	handleOIDCStart(rec, httptest.NewRequest("GET", "/auth/oidc/start", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("disabled: expected 404, got %d", rec.Code)
+	}
+}
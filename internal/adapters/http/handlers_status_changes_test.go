@@ -0,0 +1,151 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/application/orchestrators"
+	accountDomain "workshop/internal/domain/account"
+	authsessionDomain "workshop/internal/domain/authsession"
+	memberDomain "workshop/internal/domain/member"
+	statusChangeDomain "workshop/internal/domain/statuschange"
+)
+
+// --- Mock status change store ---
+
+type mockStatusChangeStore struct {
+	changes map[string]statusChangeDomain.Change
+}
+
+// Save implements statuschange.Store for testing.
+// PRE: value has been validated
+// POST: Change is upserted
+func (m *mockStatusChangeStore) Save(_ context.Context, value statusChangeDomain.Change) error {
+	m.changes[value.ID] = value
+	return nil
+}
+
+// GetByID implements statuschange.Store for testing.
+// PRE: id is non-empty
+// POST: Returns the change or an error
+func (m *mockStatusChangeStore) GetByID(_ context.Context, id string) (statusChangeDomain.Change, error) {
+	c, ok := m.changes[id]
+	if !ok {
+		return statusChangeDomain.Change{}, errors.New("not found")
+	}
+	return c, nil
+}
+
+// ListDue implements statuschange.Store for testing.
+// PRE: today is YYYY-MM-DD
+// POST: Returns scheduled changes effective on or before today
+func (m *mockStatusChangeStore) ListDue(_ context.Context, today string) ([]statusChangeDomain.Change, error) {
+	var due []statusChangeDomain.Change
+	for _, c := range m.changes {
+		if c.IsDue(today) {
+			due = append(due, c)
+		}
+	}
+	return due, nil
+}
+
+// ListBySubject implements statuschange.Store for testing.
+// PRE: none
+// POST: Returns the subject's changes
+func (m *mockStatusChangeStore) ListBySubject(_ context.Context, subjectType string, subjectID string) ([]statusChangeDomain.Change, error) {
+	var out []statusChangeDomain.Change
+	for _, c := range m.changes {
+		if c.SubjectType == subjectType && c.SubjectID == subjectID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// Delete implements statuschange.Store for testing.
+// PRE: id is non-empty
+// POST: Change is removed
+func (m *mockStatusChangeStore) Delete(_ context.Context, id string) error {
+	delete(m.changes, id)
+	return nil
+}
+
+// TestHandleStatusChanges verifies an admin can suspend an account (signing it out), schedule a
+// freeze and cancel it, and that other roles are turned away.
+func TestHandleStatusChanges(t *testing.T) {
+	stores = newFullStores()
+	stores.StatusChangeStore = &mockStatusChangeStore{changes: map[string]statusChangeDomain.Change{}}
+	authSessions := newMockAuthSessionStore()
+	stores.AuthSessionStore = authSessions
+	ctx := context.Background()
+	stores.AccountStore.Save(ctx, accountDomain.Account{ID: "a1", Email: "ana@test.com", Role: accountDomain.RoleMember, Status: accountDomain.StatusActive})
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "m1", AccountID: "a1", Name: "Ana", Email: "ana@test.com", Program: "adults", Status: memberDomain.StatusActive})
+	authSessions.Save(ctx, authsessionDomain.Session{ID: "s1", AccountID: "a1"})
+
+	rec := httptest.NewRecorder()
+	handleStatusChanges(rec, authRequest("POST", "/api/status-changes", `{"Action":"suspend","SubjectID":"a1","Reason":"Unpaid fees"}`, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleStatusChanges(rec, authRequest("POST", "/api/status-changes", `{"Action":"suspend","SubjectID":"a1","Reason":"Unpaid fees"}`, adminSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("suspend: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if acct, _ := stores.AccountStore.GetByID(ctx, "a1"); !acct.IsSuspended() || acct.SuspendedReason != "Unpaid fees" {
+		t.Errorf("account = %+v", acct)
+	}
+	if len(authSessions.sessions) != 0 {
+		t.Errorf("suspended account still has %d sessions", len(authSessions.sessions))
+	}
+
+	from := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	rec = httptest.NewRecorder()
+	handleStatusChanges(rec, authRequest("POST", "/api/status-changes", fmt.Sprintf(`{"Action":"freeze","SubjectID":"m1","EffectiveDate":%q}`, from), adminSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("freeze: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var result orchestrators.ChangeStatusResult
+	json.NewDecoder(rec.Body).Decode(&result)
+	if m, _ := stores.MemberStore.GetByID(ctx, "m1"); m.IsFrozen() {
+		t.Error("member frozen before the effective date")
+	}
+
+	rec = httptest.NewRecorder()
+	handleStatusChanges(rec, authRequest("GET", "/api/status-changes?subject_type=member&subject_id=m1", "", adminSession))
+	var listed []statusChangeDomain.Change
+	json.NewDecoder(rec.Body).Decode(&listed)
+	if len(listed) != 1 || !listed[0].IsScheduled() {
+		t.Fatalf("listed = %+v", listed)
+	}
+
+	rec = httptest.NewRecorder()
+	handleStatusChanges(rec, authRequest("DELETE", "/api/status-changes?id="+result.Change.ID, "", adminSession))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("cancel: expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"unknown member", `{"Action":"freeze","SubjectID":"ghost"}`, http.StatusNotFound},
+		{"suspend without reason", `{"Action":"suspend","SubjectID":"a1"}`, http.StatusBadRequest},
+		{"already suspended", `{"Action":"suspend","SubjectID":"a1","Reason":"Again"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handleStatusChanges(rec, authRequest("POST", "/api/status-changes", tt.body, adminSession))
+		if rec.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/pdf"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	termDomain "workshop/internal/domain/term"
+)
+
+// termReportView is one kid's term report with whether it has been emailed.
+type termReportView struct {
+	projections.TermReport
+	EmailedTo string     // "" until the report has been emailed
+	EmailedAt *time.Time // nil until the report has been emailed
+}
+
+// termReportsView is the body of GET /api/terms/reports.
+type termReportsView struct {
+	TermID   string
+	TermName string
+	Reports  []termReportView
+}
+
+// termReportsEmailRequest is the body of POST /api/terms/reports/email.
+type termReportsEmailRequest struct {
+	TermID   string `json:"TermID"`
+	MemberID string `json:"MemberID"` // optional: "" emails every kid's report
+	Resend   bool   `json:"Resend"`   // email reports that have already been sent
+}
+
+// termReportsDeps wires the term reports projection.
+func termReportsDeps(s *Stores) projections.GetTermReportsDeps {
+	return projections.GetTermReportsDeps{
+		Readiness:            kidsTermReadinessDeps(s),
+		ObservationStore:     s.ObservationStore,
+		MilestoneStore:       s.MilestoneStore,
+		MemberMilestoneStore: s.MemberMilestoneStore,
+		GoalStore:            s.PersonalGoalStore,
+	}
+}
+
+// TermReportsDeps returns the dependencies for emailing kids' term reports, used by the
+// admin's send button and the end-of-term job.
+func TermReportsDeps(s *Stores, now func() time.Time) orchestrators.SendTermReportsDeps {
+	return orchestrators.SendTermReportsDeps{
+		TermStore:     s.TermStore,
+		DeliveryStore: s.TermReportStore,
+		MemberStore:   s.MemberStore,
+		WaiverStore:   s.WaiverStore,
+		Render: func(ctx context.Context, termID, memberID string) ([]orchestrators.TermReportDocument, error) {
+			result, err := projections.QueryGetTermReports(ctx, projections.GetTermReportsQuery{TermID: termID, MemberID: memberID}, termReportsDeps(s))
+			if err != nil {
+				return nil, err
+			}
+			docs := make([]orchestrators.TermReportDocument, 0, len(result.Reports))
+			for _, report := range result.Reports {
+				doc := pdf.New(result.TermName + " report: " + report.MemberName)
+				writeTermReport(doc, result, report)
+				docs = append(docs, orchestrators.TermReportDocument{MemberID: report.MemberID, MemberName: report.MemberName, PDF: doc.Bytes()})
+			}
+			return docs, nil
+		},
+		EmailSender: emailSender,
+		FromAddress: emailFromAddress,
+		ReplyTo:     emailReplyTo,
+		Now:         now,
+	}
+}
+
+// handleTermReports handles GET /api/terms/reports?term_id=&member_id=
+// Returns the kids' end-of-term progress reports and whether each has been emailed. Staff only.
+func handleTermReports(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	result, ok := queryTermReports(w, r)
+	if !ok {
+		return
+	}
+	deliveries, err := stores.TermReportStore.ListDeliveries(r.Context(), result.TermID)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	sent := make(map[string]termDomain.ReportDelivery, len(deliveries))
+	for _, d := range deliveries {
+		sent[d.MemberID] = d
+	}
+
+	view := termReportsView{TermID: result.TermID, TermName: result.TermName, Reports: []termReportView{}}
+	for _, report := range result.Reports {
+		v := termReportView{TermReport: report}
+		if d, ok := sent[report.MemberID]; ok {
+			v.EmailedTo = d.Recipient
+			v.EmailedAt = &d.SentAt
+		}
+		view.Reports = append(view.Reports, v)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(view)
+}
+
+// handleTermReportsPDF handles GET /api/terms/reports/pdf?term_id=&member_id=
+// Downloads one kid's report, or every kid's report with one per page. Staff only.
+func handleTermReportsPDF(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	result, ok := queryTermReports(w, r)
+	if !ok {
+		return
+	}
+	memberName := ""
+	if r.URL.Query().Get("member_id") != "" {
+		if len(result.Reports) == 0 {
+			apierror.NotFound(w, "no report for this member in the term")
+			return
+		}
+		memberName = result.Reports[0].MemberName
+	}
+
+	doc := pdf.New(strings.TrimSpace(result.TermName + " report " + memberName))
+	for i, report := range result.Reports {
+		if i > 0 {
+			doc.PageBreak()
+		}
+		writeTermReport(doc, result, report)
+	}
+	if len(result.Reports) == 0 {
+		doc.Heading(result.TermName)
+		doc.Note("No kids have a report for this term.")
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", termDomain.ReportFilename(result.TermName, memberName)))
+	w.Write(doc.Bytes())
+}
+
+// handleTermReportsEmail handles POST /api/terms/reports/email
+// Emails term reports to the kids' guardians, skipping those already sent unless Resend is set. Admin only.
+func handleTermReportsEmail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	var input termReportsEmailRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if _, err := stores.TermStore.GetByID(r.Context(), input.TermID); err != nil {
+		apierror.NotFound(w, "term not found")
+		return
+	}
+	if emailSender == nil {
+		apierror.Unavailable(w, "email sending is not configured")
+		return
+	}
+
+	result, err := orchestrators.ExecuteSendTermReports(r.Context(), orchestrators.SendTermReportsInput{
+		TermID:   input.TermID,
+		MemberID: input.MemberID,
+		Resend:   input.Resend,
+		SentBy:   sess.AccountID,
+	}, TermReportsDeps(stores, time.Now))
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	if result.NoAddress == nil {
+		result.NoAddress = []string{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// queryTermReports checks access and builds the reports asked for by the term_id and
+// member_id query parameters, writing the error response when it returns false.
+func queryTermReports(w http.ResponseWriter, r *http.Request) (projections.TermReportsResult, bool) {
+	sess, ok := requireStaff(w, r)
+	if !ok {
+		return projections.TermReportsResult{}, false
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return projections.TermReportsResult{}, false
+	}
+	q := r.URL.Query()
+	result, err := projections.QueryGetTermReports(r.Context(), projections.GetTermReportsQuery{TermID: q.Get("term_id"), MemberID: q.Get("member_id")}, termReportsDeps(stores))
+	if err != nil {
+		internalError(w, err)
+		return projections.TermReportsResult{}, false
+	}
+	if result.TermID == "" {
+		apierror.NotFound(w, "term not found")
+		return projections.TermReportsResult{}, false
+	}
+	return result, true
+}
+
+// writeTermReport lays out one kid's report, for their whānau to read at home.
+func writeTermReport(doc *pdf.Document, result projections.TermReportsResult, report projections.TermReport) {
+	doc.Heading(report.MemberName)
+	doc.Note(fmt.Sprintf("%s progress report, %s to %s", result.TermName, result.StartDate.Format("2 Jan"), result.EndDate.Format("2 Jan 2006")))
+
+	doc.Subheading("Attendance")
+	attendance := fmt.Sprintf("Came to %d of %d classes (%.0f%%).", report.Attended, report.TotalSessions, report.AttendancePct)
+	if report.MakeupCredits > 0 {
+		attendance = fmt.Sprintf("Came to %d of %d classes, plus %d make-up credit(s) (%.0f%%).", report.Attended, report.TotalSessions, report.MakeupCredits, report.AttendancePct)
+	}
+	doc.Paragraph(attendance)
+
+	doc.Subheading("Belt progress")
+	belt := report.Belt + " belt"
+	if report.Stripe > 0 {
+		belt = fmt.Sprintf("%s belt with %d stripe(s)", report.Belt, report.Stripe)
+	}
+	doc.Paragraph("Now: " + belt + ".")
+	for _, p := range report.Promotions {
+		doc.Paragraph(fmt.Sprintf("%s: awarded %s belt, stripe %d.", p.PromotedAt.Format("2 Jan"), p.Belt, p.Stripe))
+	}
+	if report.TargetBelt != "" {
+		if report.GradingReady {
+			doc.Paragraph(fmt.Sprintf("Attendance is on track for %s belt grading (%.0f%% needed).", report.TargetBelt, report.ThresholdPct))
+		} else {
+			doc.Paragraph(fmt.Sprintf("Working toward %s belt: %.0f%% attendance is needed for grading.", report.TargetBelt, report.ThresholdPct))
+		}
+	}
+
+	doc.Subheading("Coach feedback")
+	for _, o := range report.Observations {
+		doc.Paragraph(o.CreatedAt.Format("2 Jan") + ": " + o.Content)
+	}
+	if len(report.Observations) == 0 {
+		doc.Note("No feedback was shared this term.")
+	}
+
+	doc.Subheading("Milestones")
+	for _, m := range report.Milestones {
+		doc.Paragraph(fmt.Sprintf("%s, earned %s.", m.Name, m.EarnedAt.Format("2 Jan")))
+	}
+	if len(report.Milestones) == 0 {
+		doc.Note("No milestones were reached this term.")
+	}
+
+	doc.Subheading("Goals for next term")
+	for _, g := range report.Goals {
+		doc.Paragraph(fmt.Sprintf("%s: %d of %d %s by %s.", g.Title, g.Progress, g.Target, g.Unit, g.EndDate.Format("2 Jan 2006")))
+	}
+	if len(report.Goals) == 0 {
+		doc.Note("No goals have been set yet.")
+	}
+}
@@ -0,0 +1,94 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	classTypeDomain "workshop/internal/domain/classtype"
+	memberDomain "workshop/internal/domain/member"
+	programDomain "workshop/internal/domain/program"
+	scheduleDomain "workshop/internal/domain/schedule"
+	termDomain "workshop/internal/domain/term"
+)
+
+type mockTermReportStore struct {
+	deliveries []termDomain.ReportDelivery
+}
+
+// SaveDelivery records a delivery.
+// PRE: value is valid
+// POST: The delivery is appended
+func (m *mockTermReportStore) SaveDelivery(_ context.Context, value termDomain.ReportDelivery) error {
+	m.deliveries = append(m.deliveries, value)
+	return nil
+}
+
+// ListDeliveries returns a term's deliveries.
+// PRE: termID is non-empty
+// POST: Returns the term's deliveries
+func (m *mockTermReportStore) ListDeliveries(_ context.Context, termID string) ([]termDomain.ReportDelivery, error) {
+	var result []termDomain.ReportDelivery
+	for _, d := range m.deliveries {
+		if d.TermID == termID {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+// TestHandleTermReports_ListAndDownload verifies coaches can list a term's kids reports with
+// their email status and download them as a PDF, and only admins can email them.
+func TestHandleTermReports_ListAndDownload(t *testing.T) {
+	stores = newFullStores()
+	reports := &mockTermReportStore{}
+	stores.TermReportStore = reports
+	ctx := context.Background()
+	stores.TermStore.Save(ctx, termDomain.Term{ID: "t1", Name: "Term 1 2026",
+		StartDate: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC)})
+	stores.ProgramStore.Save(ctx, programDomain.Program{ID: "pk", Name: "Kids", Type: "kids"})
+	stores.ClassTypeStore.Save(ctx, classTypeDomain.ClassType{ID: "ctk", ProgramID: "pk", Name: "Kids BJJ"})
+	stores.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "sk", ClassTypeID: "ctk", Day: "monday", StartTime: "16:00", EndTime: "17:00"})
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "k1", Name: "Aroha", Email: "aroha@test.com", Program: memberDomain.ProgramKids, Status: memberDomain.StatusActive})
+	reports.SaveDelivery(ctx, termDomain.ReportDelivery{TermID: "t1", MemberID: "k1", Recipient: "mere@test.com", SentAt: time.Date(2026, 4, 3, 9, 0, 0, 0, time.UTC)})
+
+	rec := httptest.NewRecorder()
+	handleTermReports(rec, authRequest("GET", "/api/terms/reports?term_id=t1", "", coachSession))
+	var view termReportsView
+	json.NewDecoder(rec.Body).Decode(&view)
+	if rec.Code != http.StatusOK || len(view.Reports) != 1 || view.Reports[0].MemberName != "Aroha" || view.Reports[0].EmailedTo != "mere@test.com" {
+		t.Fatalf("reports: %d %+v", rec.Code, view)
+	}
+
+	rec = httptest.NewRecorder()
+	handleTermReportsPDF(rec, authRequest("GET", "/api/terms/reports/pdf?term_id=t1&member_id=k1", "", coachSession))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/pdf" || !bytes.HasPrefix(rec.Body.Bytes(), []byte("%PDF-")) ||
+		rec.Header().Get("Content-Disposition") != `attachment; filename="term-1-2026-aroha-report.pdf"` {
+		t.Fatalf("pdf: %d %v", rec.Code, rec.Header())
+	}
+
+	rec = httptest.NewRecorder()
+	handleTermReportsPDF(rec, authRequest("GET", "/api/terms/reports/pdf?term_id=t1&member_id=nobody", "", coachSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown kid: expected 404, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleTermReports(rec, authRequest("GET", "/api/terms/reports?term_id=nope", "", coachSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown term: expected 404, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleTermReports(rec, authRequest("GET", "/api/terms/reports?term_id=t1", "", memberSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("member: expected 403, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleTermReportsEmail(rec, authRequest("POST", "/api/terms/reports/email", `{"TermID":"t1"}`, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach emailing: expected 403, got %d", rec.Code)
+	}
+}
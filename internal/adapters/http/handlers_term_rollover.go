@@ -0,0 +1,219 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	holidayDomain "workshop/internal/domain/holiday"
+	termDomain "workshop/internal/domain/term"
+)
+
+// termRolloverHoliday is a holiday confirmed in the rollover wizard.
+type termRolloverHoliday struct {
+	Name      string `json:"Name"`
+	StartDate string `json:"StartDate"` // YYYY-MM-DD
+	EndDate   string `json:"EndDate"`   // YYYY-MM-DD
+}
+
+// termRolloverThreshold is a kids attendance threshold confirmed in the rollover wizard.
+type termRolloverThreshold struct {
+	Belt          string  `json:"Belt"`
+	AttendancePct float64 `json:"AttendancePct"`
+}
+
+// termRolloverRequest is the body of POST /api/terms/rollover.
+type termRolloverRequest struct {
+	Name       string                  `json:"Name"`
+	StartDate  string                  `json:"StartDate"` // YYYY-MM-DD
+	EndDate    string                  `json:"EndDate"`   // YYYY-MM-DD
+	Holidays   []termRolloverHoliday   `json:"Holidays"`
+	Thresholds []termRolloverThreshold `json:"Thresholds"`
+}
+
+// termReadinessView is a term's kids readiness: archived when the term was rolled over,
+// otherwise worked out from today's records.
+type termReadinessView struct {
+	TermID     string
+	TermName   string
+	Archived   bool
+	ArchivedAt *time.Time
+	Entries    []termDomain.ReadinessEntry
+}
+
+// kidsTermReadinessDeps wires the kids readiness projection.
+func kidsTermReadinessDeps(s *Stores) projections.GetKidsTermReadinessDeps {
+	return projections.GetKidsTermReadinessDeps{
+		TermStore:          s.TermStore,
+		ProgramStore:       s.ProgramStore,
+		ClassTypeStore:     s.ClassTypeStore,
+		ScheduleStore:      s.ScheduleStore,
+		HolidayStore:       s.HolidayStore,
+		MemberStore:        s.MemberStore,
+		AttendanceStore:    s.AttendanceStore,
+		GradingRecordStore: s.GradingRecordStore,
+		GradingConfigStore: s.GradingConfigStore,
+		MakeupCreditStore:  s.MakeupCreditStore,
+		ThresholdStore:     s.TermRolloverStore,
+	}
+}
+
+// liveTermReadiness works out a term's kids readiness from the current records.
+func liveTermReadiness(ctx context.Context, termID string) ([]termDomain.ReadinessEntry, error) {
+	result, err := projections.QueryGetKidsTermReadiness(ctx, projections.GetKidsTermReadinessQuery{TermID: termID}, kidsTermReadinessDeps(stores))
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]termDomain.ReadinessEntry, 0, len(result.Entries))
+	for _, e := range result.Entries {
+		entries = append(entries, termDomain.ReadinessEntry{
+			MemberID:      e.MemberID,
+			MemberName:    e.MemberName,
+			CurrentBelt:   e.CurrentBelt,
+			TargetBelt:    e.TargetBelt,
+			Attended:      e.Attended,
+			MakeupCredits: e.MakeupCredits,
+			TotalSessions: e.TotalSessions,
+			AttendancePct: e.AttendancePct,
+			ThresholdPct:  e.ThresholdPct,
+			Eligible:      e.Eligible,
+		})
+	}
+	return entries, nil
+}
+
+// handleTermRollover handles GET/POST for /api/terms/rollover
+// GET proposes the next term: NZ school term dates, last year's holidays in that term and
+// the kids attendance thresholds to carry over. POST creates the confirmed term, archiving
+// the previous term's readiness first. Admin only.
+func handleTermRollover(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		proposal, err := projections.QueryGetTermRolloverProposal(ctx, projections.GetTermRolloverProposalDeps{
+			TermStore:          stores.TermStore,
+			HolidayStore:       stores.HolidayStore,
+			RolloverStore:      stores.TermRolloverStore,
+			GradingConfigStore: stores.GradingConfigStore,
+		})
+		if errors.Is(err, termDomain.ErrNoPreviousTerm) {
+			apierror.NotFound(w, err.Error())
+			return
+		}
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(proposal)
+
+	case "POST":
+		var input termRolloverRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		start, err1 := time.Parse("2006-01-02", input.StartDate)
+		end, err2 := time.Parse("2006-01-02", input.EndDate)
+		if err1 != nil || err2 != nil {
+			apierror.Validation(w, "StartDate and EndDate must be YYYY-MM-DD")
+			return
+		}
+		holidays := make([]holidayDomain.Holiday, 0, len(input.Holidays))
+		for _, h := range input.Holidays {
+			hs, err1 := time.Parse("2006-01-02", h.StartDate)
+			he, err2 := time.Parse("2006-01-02", h.EndDate)
+			if err1 != nil || err2 != nil {
+				apierror.Validation(w, "holiday dates must be YYYY-MM-DD")
+				return
+			}
+			holidays = append(holidays, holidayDomain.Holiday{Name: h.Name, StartDate: hs, EndDate: he})
+		}
+		thresholds := make([]termDomain.Threshold, 0, len(input.Thresholds))
+		for _, th := range input.Thresholds {
+			thresholds = append(thresholds, termDomain.Threshold{Belt: th.Belt, AttendancePct: th.AttendancePct})
+		}
+
+		result, err := orchestrators.ExecuteTermRollover(ctx, orchestrators.TermRolloverInput{
+			Term:       termDomain.Term{Name: input.Name, StartDate: start, EndDate: end},
+			Holidays:   holidays,
+			Thresholds: thresholds,
+			ActorID:    sess.AccountID,
+		}, orchestrators.TermRolloverDeps{
+			TermStore:     stores.TermStore,
+			HolidayStore:  stores.HolidayStore,
+			RolloverStore: stores.TermRolloverStore,
+			Readiness:     liveTermReadiness,
+			GenerateID:    generateID,
+			Now:           timeNow,
+		})
+		switch {
+		case errors.Is(err, termDomain.ErrOverlap):
+			apierror.Conflict(w, err.Error())
+			return
+		case errors.Is(err, orchestrators.ErrInvalidRollover):
+			apierror.Validation(w, err.Error())
+			return
+		case err != nil:
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(result)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleTermReadiness handles GET /api/terms/readiness?term_id=
+// Returns a term's kids grading readiness: the archived snapshot for a rolled-over term,
+// otherwise the live figures. Admin only.
+func handleTermReadiness(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "grading") {
+		return
+	}
+	ctx := r.Context()
+	t, err := stores.TermStore.GetByID(ctx, r.URL.Query().Get("term_id"))
+	if err != nil {
+		apierror.NotFound(w, "term not found")
+		return
+	}
+
+	view := termReadinessView{TermID: t.ID, TermName: t.Name}
+	if snap, err := stores.TermRolloverStore.GetSnapshot(ctx, t.ID); err == nil {
+		view.Archived = true
+		view.ArchivedAt = &snap.ArchivedAt
+		view.Entries = snap.Entries
+	} else if view.Entries, err = liveTermReadiness(ctx, t.ID); err != nil {
+		internalError(w, err)
+		return
+	}
+	if view.Entries == nil {
+		view.Entries = []termDomain.ReadinessEntry{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(view)
+}
@@ -0,0 +1,126 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/application/projections"
+	termDomain "workshop/internal/domain/term"
+)
+
+type mockTermRolloverStore struct {
+	thresholds map[string][]termDomain.Threshold
+	snapshots  map[string]termDomain.ReadinessSnapshot
+}
+
+// SaveThresholds replaces the term's thresholds.
+// PRE: termID is non-empty
+// POST: The thresholds are stored
+func (m *mockTermRolloverStore) SaveThresholds(_ context.Context, termID string, values []termDomain.Threshold) error {
+	m.thresholds[termID] = values
+	return nil
+}
+
+// ListThresholds returns the term's thresholds.
+// PRE: termID is non-empty
+// POST: Returns the thresholds
+func (m *mockTermRolloverStore) ListThresholds(_ context.Context, termID string) ([]termDomain.Threshold, error) {
+	return m.thresholds[termID], nil
+}
+
+// SaveSnapshot stores the snapshot.
+// PRE: value is valid
+// POST: The snapshot is stored
+func (m *mockTermRolloverStore) SaveSnapshot(_ context.Context, value termDomain.ReadinessSnapshot) error {
+	m.snapshots[value.TermID] = value
+	return nil
+}
+
+// GetSnapshot returns the term's archived readiness.
+// PRE: termID is non-empty
+// POST: Returns the snapshot or an error
+func (m *mockTermRolloverStore) GetSnapshot(_ context.Context, termID string) (termDomain.ReadinessSnapshot, error) {
+	s, ok := m.snapshots[termID]
+	if !ok {
+		return termDomain.ReadinessSnapshot{}, errors.New("not found")
+	}
+	return s, nil
+}
+
+// TestHandleTermRollover_ProposeConfirmAndArchive verifies the wizard proposes the next
+// term, creating it archives the previous term's readiness, and an overlapping term is refused.
+func TestHandleTermRollover_ProposeConfirmAndArchive(t *testing.T) {
+	stores = newFullStores()
+	rollover := &mockTermRolloverStore{thresholds: map[string][]termDomain.Threshold{}, snapshots: map[string]termDomain.ReadinessSnapshot{}}
+	stores.TermRolloverStore = rollover
+
+	rec := httptest.NewRecorder()
+	handleTermRollover(rec, authRequest("GET", "/api/terms/rollover", "", adminSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("no terms yet: expected 404, got %d", rec.Code)
+	}
+
+	stores.TermStore.Save(context.Background(), termDomain.Term{ID: "t1", Name: "Term 1 2026",
+		StartDate: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC)})
+
+	rec = httptest.NewRecorder()
+	handleTermRollover(rec, authRequest("GET", "/api/terms/rollover", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleTermRollover(rec, authRequest("GET", "/api/terms/rollover", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var proposal projections.TermRolloverProposal
+	json.NewDecoder(rec.Body).Decode(&proposal)
+	if proposal.Term.Name != "Term 2 2026" || proposal.Term.StartDate.Format("2006-01-02") != "2026-04-20" {
+		t.Errorf("expected Term 2 2026 from 20 April, got %+v", proposal.Term)
+	}
+
+	body := `{"Name":"Term 2 2026","StartDate":"2026-04-20","EndDate":"2026-06-26",
+		"Holidays":[{"Name":"King's Birthday","StartDate":"2026-06-01","EndDate":"2026-06-01"}],
+		"Thresholds":[{"Belt":"grey","AttendancePct":70}]}`
+	rec = httptest.NewRecorder()
+	handleTermRollover(rec, authRequest("POST", "/api/terms/rollover", body, adminSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if _, ok := rollover.snapshots["t1"]; !ok {
+		t.Error("expected Term 1's readiness archived")
+	}
+
+	rec = httptest.NewRecorder()
+	handleTermRollover(rec, authRequest("POST", "/api/terms/rollover", body, adminSession))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("same dates again: expected 409, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleTermRollover(rec, authRequest("POST", "/api/terms/rollover",
+		`{"Name":"Term 3 2026","StartDate":"2026-07-20","EndDate":"2026-09-25","Thresholds":[{"Belt":"grey","AttendancePct":0}]}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("zero threshold: expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleTermReadiness(rec, authRequest("GET", "/api/terms/readiness?term_id=t1", "", adminSession))
+	var view termReadinessView
+	json.NewDecoder(rec.Body).Decode(&view)
+	if rec.Code != http.StatusOK || !view.Archived || view.TermName != "Term 1 2026" {
+		t.Errorf("expected Term 1's archived readiness, got %d: %+v", rec.Code, view)
+	}
+
+	rec = httptest.NewRecorder()
+	handleTermReadiness(rec, authRequest("GET", "/api/terms/readiness?term_id=nope", "", adminSession))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown term: expected 404, got %d", rec.Code)
+	}
+}
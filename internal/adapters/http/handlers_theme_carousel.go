@@ -0,0 +1,43 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/projections"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// handleThemeCarousel handles GET /api/themes/carousel
+// Returns what each class is working on now, drawn from the active rotors, with the
+// library themes whose clips belong to each rotor theme.
+func handleThemeCarousel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requirePermission(w, r, permissionDomain.ActionLibraryView)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "library") {
+		return
+	}
+	ctx := r.Context()
+	result, err := projections.QueryGetThemeCarousel(ctx, projections.GetThemeCarouselQuery{
+		Gate: viewerBeltGate(ctx, sess),
+		Now:  timeNow(),
+	}, projections.GetThemeCarouselDeps{
+		ClassTypeStore: stores.ClassTypeStore,
+		ProgramStore:   stores.ProgramStore,
+		RotorStore:     stores.RotorStore,
+		ThemeStore:     stores.ThemeStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,290 @@
+package web
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"workshop/internal/adapters/http/apierror"
+	accountStore "workshop/internal/adapters/storage/account"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	accountDomain "workshop/internal/domain/account"
+	scheduleDomain "workshop/internal/domain/schedule"
+	timesheetDomain "workshop/internal/domain/timesheet"
+)
+
+// timesheetAssignRequest is the body of POST /api/timesheets/assign.
+type timesheetAssignRequest struct {
+	ScheduleID string `json:"ScheduleID"`
+	ClassDate  string `json:"ClassDate"` // empty sets the class's regular coach
+	CoachID    string `json:"CoachID"`   // empty clears the regular coach or the day's override
+}
+
+// timesheetRateRequest is the body of POST /api/timesheets/rates.
+type timesheetRateRequest struct {
+	CoachID    string `json:"CoachID"`
+	HourlyRate int    `json:"HourlyRate"` // cents per hour
+}
+
+// timesheetCoach is one coach and their hourly rate, as listed by GET /api/timesheets/rates.
+type timesheetCoach struct {
+	CoachID    string
+	Email      string
+	Role       string
+	HourlyRate int // cents per hour; 0 when no rate is set
+}
+
+// timesheetMonth returns ?month=, defaulting to the current month.
+func timesheetMonth(r *http.Request) string {
+	if month := r.URL.Query().Get("month"); month != "" {
+		return month
+	}
+	return timeNow().Format("2006-01")
+}
+
+// queryCoachTimesheet runs the coach timesheet projection for a month.
+func queryCoachTimesheet(r *http.Request, month string) (projections.CoachTimesheetResult, error) {
+	return projections.QueryGetCoachTimesheet(r.Context(), projections.GetCoachTimesheetQuery{Month: month}, timeNow(), projections.GetCoachTimesheetDeps{
+		ScheduleStore:  stores.ScheduleStore,
+		TermStore:      stores.TermStore,
+		HolidayStore:   stores.HolidayStore,
+		ChangeStore:    stores.OccurrenceChangeStore,
+		ClassTypeStore: stores.ClassTypeStore,
+		TimesheetStore: stores.TimesheetStore,
+		AccountStore:   stores.AccountStore,
+		MemberStore:    stores.MemberStore,
+	})
+}
+
+// handleAdminTimesheetsPage handles GET /admin/timesheets
+func handleAdminTimesheetsPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "timesheets") {
+		return
+	}
+	renderTemplate(w, r, "admin_timesheets.html", map[string]any{"Month": timeNow().Format("2006-01")})
+}
+
+// handleTimesheets handles GET /api/timesheets?month=YYYY-MM
+// Returns every class that ran in the month with the coach who ran it, and hours and pay
+// per coach. Classes later in the current month are not counted. Admin only.
+func handleTimesheets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "timesheets") {
+		return
+	}
+	result, err := queryCoachTimesheet(r, timesheetMonth(r))
+	if errors.Is(err, timesheetDomain.ErrInvalidMonth) {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// handleTimesheetAssign handles POST /api/timesheets/assign
+// Sets a class's regular coach, or with a ClassDate hands that one occurrence to another
+// coach. An empty CoachID clears the regular coach or removes the day's override. Admin only.
+func handleTimesheetAssign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "timesheets") {
+		return
+	}
+	var input timesheetAssignRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if _, err := stores.ScheduleStore.GetByID(r.Context(), input.ScheduleID); err != nil {
+		apierror.NotFound(w, "class not found")
+		return
+	}
+
+	err := orchestrators.ExecuteAssignCoach(r.Context(), orchestrators.AssignCoachInput{
+		ScheduleID: input.ScheduleID,
+		ClassDate:  input.ClassDate,
+		CoachID:    input.CoachID,
+		SetBy:      sess.AccountID,
+	}, orchestrators.AssignCoachDeps{
+		ScheduleStore:  stores.ScheduleStore,
+		TimesheetStore: stores.TimesheetStore,
+		AccountStore:   stores.AccountStore,
+		GenerateID:     generateID,
+		Now:            timeNow,
+	})
+	if errors.Is(err, orchestrators.ErrNotACoach) || errors.Is(err, scheduleDomain.ErrWrongDay) ||
+		errors.Is(err, timesheetDomain.ErrInvalidClassDate) {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleTimesheetRates handles GET/POST for /api/timesheets/rates
+// GET lists coaches and admins with their hourly rates. POST sets one coach's rate in
+// cents per hour. Admin only.
+func handleTimesheetRates(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "timesheets") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		rates, err := stores.TimesheetStore.ListRates(ctx)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		byCoach := make(map[string]int, len(rates))
+		for _, rate := range rates {
+			byCoach[rate.CoachID] = rate.HourlyRate
+		}
+		coaches := []timesheetCoach{}
+		for _, role := range []string{accountDomain.RoleCoach, accountDomain.RoleAdmin} {
+			accounts, err := stores.AccountStore.List(ctx, accountStore.ListFilter{Limit: 1000, Role: role})
+			if err != nil {
+				internalError(w, err)
+				return
+			}
+			for _, a := range accounts {
+				coaches = append(coaches, timesheetCoach{CoachID: a.ID, Email: a.Email, Role: a.Role, HourlyRate: byCoach[a.ID]})
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(coaches)
+
+	case "POST":
+		var input timesheetRateRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		rate, err := orchestrators.ExecuteSetCoachRate(ctx, orchestrators.SetCoachRateInput{
+			CoachID:    input.CoachID,
+			HourlyRate: input.HourlyRate,
+			UpdatedBy:  sess.AccountID,
+		}, orchestrators.SetCoachRateDeps{
+			TimesheetStore: stores.TimesheetStore,
+			AccountStore:   stores.AccountStore,
+			Now:            timeNow,
+		})
+		if errors.Is(err, orchestrators.ErrNotACoach) || errors.Is(err, timesheetDomain.ErrInvalidRate) ||
+			errors.Is(err, timesheetDomain.ErrEmptyCoachID) {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(rate)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleTimesheetExport handles GET /api/timesheets/export?month=YYYY-MM
+// Downloads the month's payroll as CSV: one row per coach with sessions, hours, hourly
+// rate and pay in dollars. Admin only.
+func handleTimesheetExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "timesheets") {
+		return
+	}
+	month := timesheetMonth(r)
+	result, err := queryCoachTimesheet(r, month)
+	if errors.Is(err, timesheetDomain.ErrInvalidMonth) {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"payroll-%s.csv\"", month))
+	w.Header().Set("Cache-Control", "no-store")
+
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+	if err := cw.Write([]string{"Coach", "Email", "Sessions", "Hours", "HourlyRate", "Pay"}); err != nil {
+		internalError(w, err)
+		return
+	}
+	for _, t := range result.Totals {
+		rec := []string{
+			csvSafeCell(t.Name),
+			csvSafeCell(t.Email),
+			strconv.Itoa(t.Sessions),
+			strconv.FormatFloat(t.Hours, 'f', 2, 64),
+			timesheetDomain.FormatCents(t.HourlyRate),
+			timesheetDomain.FormatCents(t.Pay),
+		}
+		if err := cw.Write(rec); err != nil {
+			internalError(w, err)
+			return
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		slog.ErrorContext(r.Context(), "csv_export_flush_error", "error", err.Error())
+	}
+
+	slog.InfoContext(r.Context(), "audit_event",
+		"actor_id", sess.AccountID,
+		"actor_role", sess.Role,
+		"action", "timesheets.payroll.export_csv",
+		"month", month,
+		"row_count", len(result.Totals),
+		"unassigned", result.Unassigned,
+	)
+}
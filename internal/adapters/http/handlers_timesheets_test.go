@@ -0,0 +1,217 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/projections"
+	accountDomain "workshop/internal/domain/account"
+	scheduleDomain "workshop/internal/domain/schedule"
+	termDomain "workshop/internal/domain/term"
+	timesheetDomain "workshop/internal/domain/timesheet"
+)
+
+// --- Mock stores ---
+
+type mockTimesheetStore struct {
+	overrides map[string]timesheetDomain.Override // keyed by schedule ID and class date
+	rates     map[string]timesheetDomain.Rate
+}
+
+func newMockTimesheetStore() *mockTimesheetStore {
+	return &mockTimesheetStore{overrides: map[string]timesheetDomain.Override{}, rates: map[string]timesheetDomain.Rate{}}
+}
+
+// GetOverride implements timesheet.Store for testing.
+// PRE: scheduleID and classDate are non-empty
+// POST: Returns the override or sql.ErrNoRows
+func (m *mockTimesheetStore) GetOverride(_ context.Context, scheduleID, classDate string) (timesheetDomain.Override, error) {
+	o, ok := m.overrides[scheduleID+"|"+classDate]
+	if !ok {
+		return timesheetDomain.Override{}, sql.ErrNoRows
+	}
+	return o, nil
+}
+
+// SaveOverride implements timesheet.Store for testing.
+// PRE: value has been validated
+// POST: Override is upserted for its occurrence
+func (m *mockTimesheetStore) SaveOverride(_ context.Context, value timesheetDomain.Override) error {
+	m.overrides[value.ScheduleID+"|"+value.ClassDate] = value
+	return nil
+}
+
+// DeleteOverride implements timesheet.Store for testing.
+// PRE: scheduleID and classDate are non-empty
+// POST: Override is removed
+func (m *mockTimesheetStore) DeleteOverride(_ context.Context, scheduleID, classDate string) error {
+	delete(m.overrides, scheduleID+"|"+classDate)
+	return nil
+}
+
+// ListOverrides implements timesheet.Store for testing.
+// PRE: from and to are YYYY-MM-DD
+// POST: Returns overrides dated within the range
+func (m *mockTimesheetStore) ListOverrides(_ context.Context, from, to string) ([]timesheetDomain.Override, error) {
+	var list []timesheetDomain.Override
+	for _, o := range m.overrides {
+		if o.ClassDate >= from && o.ClassDate <= to {
+			list = append(list, o)
+		}
+	}
+	return list, nil
+}
+
+// GetRate implements timesheet.Store for testing.
+// PRE: coachID is non-empty
+// POST: Returns the rate or sql.ErrNoRows
+func (m *mockTimesheetStore) GetRate(_ context.Context, coachID string) (timesheetDomain.Rate, error) {
+	r, ok := m.rates[coachID]
+	if !ok {
+		return timesheetDomain.Rate{}, sql.ErrNoRows
+	}
+	return r, nil
+}
+
+// SaveRate implements timesheet.Store for testing.
+// PRE: value has been validated
+// POST: Rate is upserted
+func (m *mockTimesheetStore) SaveRate(_ context.Context, value timesheetDomain.Rate) error {
+	m.rates[value.CoachID] = value
+	return nil
+}
+
+// ListRates implements timesheet.Store for testing.
+// PRE: none
+// POST: Returns every rate
+func (m *mockTimesheetStore) ListRates(_ context.Context) ([]timesheetDomain.Rate, error) {
+	var list []timesheetDomain.Rate
+	for _, r := range m.rates {
+		list = append(list, r)
+	}
+	return list, nil
+}
+
+// newTimesheetTestStores returns stores with a Monday class in a term covering March 2026,
+// and the admin, coach and member accounts of the test sessions.
+func newTimesheetTestStores() (*Stores, *mockTimesheetStore) {
+	s := newFullStores()
+	ts := newMockTimesheetStore()
+	s.TimesheetStore = ts
+	ctx := context.Background()
+	s.AccountStore.Save(ctx, accountDomain.Account{ID: "admin-001", Email: "admin@test.com", Role: accountDomain.RoleAdmin})
+	s.AccountStore.Save(ctx, accountDomain.Account{ID: "coach-001", Email: "coach@test.com", Role: accountDomain.RoleCoach})
+	s.AccountStore.Save(ctx, accountDomain.Account{ID: "member-001", Email: "marcus@test.com", Role: accountDomain.RoleMember})
+	s.ScheduleStore.Save(ctx, scheduleDomain.Schedule{ID: "s-mon", ClassTypeID: "ct1", Day: scheduleDomain.Monday, StartTime: "18:00", EndTime: "19:30"})
+	s.TermStore.Save(ctx, termDomain.Term{ID: "t1", Name: "Term 1", StartDate: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)})
+	return s, ts
+}
+
+// TestHandleTimesheets_AssignRateAndExport verifies an admin can assign a class's regular
+// coach and a one-day override, set a rate, and download the month's payroll.
+func TestHandleTimesheets_AssignRateAndExport(t *testing.T) {
+	var ts *mockTimesheetStore
+	stores, ts = newTimesheetTestStores()
+	timeNow = func() time.Time { return time.Date(2026, 4, 2, 9, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+
+	for _, tt := range []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "regular coach", body: `{"ScheduleID":"s-mon","CoachID":"coach-001"}`, want: http.StatusNoContent},
+		{name: "one date", body: `{"ScheduleID":"s-mon","ClassDate":"2026-03-16","CoachID":"admin-001"}`, want: http.StatusNoContent},
+		{name: "wrong weekday", body: `{"ScheduleID":"s-mon","ClassDate":"2026-03-17","CoachID":"coach-001"}`, want: http.StatusBadRequest},
+		{name: "member", body: `{"ScheduleID":"s-mon","CoachID":"member-001"}`, want: http.StatusBadRequest},
+		{name: "unknown class", body: `{"ScheduleID":"nope","CoachID":"coach-001"}`, want: http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleTimesheetAssign(rec, authRequest("POST", "/api/timesheets/assign", tt.body, adminSession))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+	if ts.overrides["s-mon|2026-03-16"].CoachID != "admin-001" {
+		t.Errorf("expected the override saved, got %+v", ts.overrides)
+	}
+
+	rec := httptest.NewRecorder()
+	handleTimesheetRates(rec, authRequest("POST", "/api/timesheets/rates", `{"CoachID":"coach-001","HourlyRate":4000}`, adminSession))
+	if rec.Code != http.StatusOK || ts.rates["coach-001"].HourlyRate != 4000 {
+		t.Fatalf("set rate: expected 200 and the rate saved, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	handleTimesheetRates(rec, authRequest("POST", "/api/timesheets/rates", `{"CoachID":"coach-001","HourlyRate":-1}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("negative rate: expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleTimesheets(rec, authRequest("GET", "/api/timesheets?month=2026-03", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("timesheet: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var sheet projections.CoachTimesheetResult
+	json.NewDecoder(rec.Body).Decode(&sheet)
+	if len(sheet.Sessions) != 5 || len(sheet.Totals) != 2 {
+		t.Errorf("expected five Mondays split between two coaches, got %+v", sheet)
+	}
+
+	rec = httptest.NewRecorder()
+	handleTimesheetExport(rec, authRequest("GET", "/api/timesheets/export?month=2026-03", "", adminSession))
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/csv") {
+		t.Fatalf("export: expected CSV, got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	rows, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	// Header, then admin-001 (one Monday, no rate) and coach-001 (four Mondays at $40).
+	if len(rows) != 3 || rows[2][1] != "coach@test.com" || rows[2][2] != "4" || rows[2][3] != "6.00" || rows[2][5] != "240.00" {
+		t.Errorf("unexpected payroll %v", rows)
+	}
+
+	rec = httptest.NewRecorder()
+	handleTimesheetExport(rec, authRequest("GET", "/api/timesheets/export?month=March", "", adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("bad month: expected 400, got %d", rec.Code)
+	}
+}
+
+// TestHandleTimesheets_AdminOnly verifies coaches and members cannot see timesheets or pay.
+func TestHandleTimesheets_AdminOnly(t *testing.T) {
+	stores, _ = newTimesheetTestStores()
+	for _, tt := range []struct {
+		name    string
+		handler http.HandlerFunc
+		method  string
+		url     string
+		body    string
+	}{
+		{name: "timesheet", handler: handleTimesheets, method: "GET", url: "/api/timesheets"},
+		{name: "assign", handler: handleTimesheetAssign, method: "POST", url: "/api/timesheets/assign", body: `{"ScheduleID":"s-mon","CoachID":"coach-001"}`},
+		{name: "rates", handler: handleTimesheetRates, method: "GET", url: "/api/timesheets/rates"},
+		{name: "export", handler: handleTimesheetExport, method: "GET", url: "/api/timesheets/export"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, sess := range []middleware.Session{coachSession, memberSession} {
+				rec := httptest.NewRecorder()
+				tt.handler(rec, authRequest(tt.method, tt.url, tt.body, sess))
+				if rec.Code != http.StatusForbidden {
+					t.Errorf("%s: expected 403, got %d", sess.Role, rec.Code)
+				}
+			}
+		})
+	}
+}
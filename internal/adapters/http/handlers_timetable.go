@@ -0,0 +1,126 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	scheduleDomain "workshop/internal/domain/schedule"
+)
+
+// scheduleMoveRequest is one class moved on the timetable.
+type scheduleMoveRequest struct {
+	ID        string `json:"ID"`
+	Day       string `json:"Day"`
+	StartTime string `json:"StartTime"`
+	EndTime   string `json:"EndTime"`
+	Mat       string `json:"Mat"`
+}
+
+// scheduleBulkRequest is the body of POST /api/schedules/bulk.
+type scheduleBulkRequest struct {
+	Changes []scheduleMoveRequest `json:"Changes"`
+}
+
+// handleScheduleTimetable handles GET /api/schedules/timetable?location_id=
+// Returns the weekly schedule as a Monday-to-Sunday grid with clashing classes flagged,
+// for the admin timetable editor. Admin only.
+func handleScheduleTimetable(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "timetable") {
+		return
+	}
+	tt, err := projections.QueryGetTimetable(r.Context(), projections.GetTimetableQuery{LocationID: requestLocationID(r)}, projections.GetTimetableDeps{
+		ScheduleStore:  stores.ScheduleStore,
+		ClassTypeStore: stores.ClassTypeStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tt)
+}
+
+// handleSchedulesBulk handles POST /api/schedules/bulk
+// Applies the moves from one timetable drag-and-drop edit together: if any move is invalid
+// or leaves classes overlapping in the same space, nothing is saved. Admin only.
+func handleSchedulesBulk(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "timetable") {
+		return
+	}
+	var input scheduleBulkRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	changes := make([]orchestrators.ScheduleChange, 0, len(input.Changes))
+	for _, c := range input.Changes {
+		changes = append(changes, orchestrators.ScheduleChange(c))
+	}
+	updated, err := orchestrators.ExecuteBulkUpdateSchedules(r.Context(), orchestrators.BulkUpdateSchedulesInput{
+		Changes: changes,
+		ActorID: sess.AccountID,
+	}, orchestrators.BulkUpdateSchedulesDeps{ScheduleStore: stores.ScheduleStore})
+	var changeErr *orchestrators.ScheduleChangeError
+	var conflictErr *orchestrators.ScheduleConflictError
+	switch {
+	case errors.As(err, &conflictErr):
+		writeScheduleConflict(w, conflictErr)
+		return
+	case errors.As(err, &changeErr):
+		apierror.ValidationFields(w, changeErr.Error(), map[string]string{changeErr.ScheduleID: changeErr.Err.Error()})
+		return
+	case errors.Is(err, orchestrators.ErrScheduleNotFound):
+		apierror.NotFound(w, err.Error())
+		return
+	case err != nil:
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+// checkScheduleConflicts writes a 409 and returns false when s overlaps another class in
+// the same space.
+func checkScheduleConflicts(w http.ResponseWriter, r *http.Request, s scheduleDomain.Schedule) bool {
+	all, err := stores.ScheduleStore.List(r.Context())
+	if err != nil {
+		internalError(w, err)
+		return false
+	}
+	clashes := scheduleDomain.Conflicts(s, all)
+	if len(clashes) == 0 {
+		return true
+	}
+	ids := make([]string, 0, len(clashes))
+	for _, c := range clashes {
+		ids = append(ids, c.ID)
+	}
+	writeScheduleConflict(w, &orchestrators.ScheduleConflictError{Conflicts: map[string][]string{s.ID: ids}})
+	return false
+}
+
+// writeScheduleConflict sends a 409 naming, per class, the classes it would overlap.
+func writeScheduleConflict(w http.ResponseWriter, err *orchestrators.ScheduleConflictError) {
+	apierror.Write(w, apierror.CodeConflict, err.Error(), err.Fields())
+}
@@ -0,0 +1,112 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/projections"
+	scheduleDomain "workshop/internal/domain/schedule"
+)
+
+// newTimetableTestStores returns stores with two back-to-back Monday evening classes.
+func newTimetableTestStores() *Stores {
+	s := newFullStores()
+	for _, sched := range []scheduleDomain.Schedule{
+		{ID: "gi", ClassTypeID: "ct-gi", Day: scheduleDomain.Monday, StartTime: "18:00", EndTime: "19:00"},
+		{ID: "nogi", ClassTypeID: "ct-nogi", Day: scheduleDomain.Monday, StartTime: "19:00", EndTime: "20:00"},
+	} {
+		s.ScheduleStore.Save(context.Background(), sched)
+	}
+	return s
+}
+
+// TestHandleScheduleTimetable verifies the week grid is admin-only and places classes by day.
+func TestHandleScheduleTimetable(t *testing.T) {
+	stores = newTimetableTestStores()
+
+	rec := httptest.NewRecorder()
+	handleScheduleTimetable(rec, authRequest("GET", "/api/schedules/timetable", "", coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleScheduleTimetable(rec, authRequest("GET", "/api/schedules/timetable", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var tt projections.Timetable
+	json.NewDecoder(rec.Body).Decode(&tt)
+	if len(tt.Days) != 7 || len(tt.Days[0].Slots) != 2 || tt.Days[0].Slots[0].ScheduleID != "gi" || tt.Conflicts != 0 {
+		t.Errorf("expected two clear Monday classes, got %+v", tt)
+	}
+}
+
+// TestHandleSchedulesBulk verifies a clashing edit is refused with the clash named and
+// nothing saved, while a clean edit is saved in full.
+func TestHandleSchedulesBulk(t *testing.T) {
+	stores = newTimetableTestStores()
+
+	rec := httptest.NewRecorder()
+	handleSchedulesBulk(rec, authRequest("POST", "/api/schedules/bulk",
+		`{"Changes":[{"ID":"gi","Day":"monday","StartTime":"17:30","EndTime":"18:30"},{"ID":"nogi","Day":"monday","StartTime":"18:00","EndTime":"19:00"}]}`, adminSession))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp apierror.Response
+	json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Error.Fields["nogi"] != "overlaps gi" || resp.Error.Fields["gi"] != "overlaps nogi" {
+		t.Errorf("expected the clash named, got %+v", resp.Error)
+	}
+	if got, _ := stores.ScheduleStore.GetByID(context.Background(), "gi"); got.StartTime != "18:00" {
+		t.Errorf("expected nothing saved, got %+v", got)
+	}
+
+	// Swapping the two classes is fine once both moves are considered together.
+	rec = httptest.NewRecorder()
+	handleSchedulesBulk(rec, authRequest("POST", "/api/schedules/bulk",
+		`{"Changes":[{"ID":"gi","Day":"monday","StartTime":"19:00","EndTime":"20:00"},{"ID":"nogi","Day":"monday","StartTime":"18:00","EndTime":"19:00","Mat":"Mat 2"}]}`, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("swap: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got, _ := stores.ScheduleStore.GetByID(context.Background(), "nogi"); got.StartTime != "18:00" || got.Mat != "Mat 2" {
+		t.Errorf("expected the swap saved, got %+v", got)
+	}
+
+	rec = httptest.NewRecorder()
+	handleSchedulesBulk(rec, authRequest("POST", "/api/schedules/bulk", `{"Changes":[{"ID":"gi","Day":"someday","StartTime":"19:00","EndTime":"20:00"}]}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("bad day: expected 400, got %d", rec.Code)
+	}
+}
+
+// TestHandleSchedules_POST_Conflict verifies a new class cannot be added over another in the
+// same space, but can go on a different mat.
+func TestHandleSchedules_POST_Conflict(t *testing.T) {
+	stores = newTimetableTestStores()
+	stores.ScheduleStore.Save(context.Background(), scheduleDomain.Schedule{ID: "kids", ClassTypeID: "ct-kids", Day: scheduleDomain.Friday, StartTime: "16:00", EndTime: "17:00", Mat: "Mat 1"})
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "over an evening class", body: `{"ClassTypeID":"ct-gi","Day":"Monday","StartTime":"18:30","EndTime":"19:30"}`, want: http.StatusConflict},
+		{name: "same mat", body: `{"ClassTypeID":"ct-gi","Day":"friday","StartTime":"16:30","EndTime":"17:30","Mat":"mat 1"}`, want: http.StatusConflict},
+		{name: "other mat", body: `{"ClassTypeID":"ct-gi","Day":"friday","StartTime":"16:30","EndTime":"17:30","Mat":"Mat 2"}`, want: http.StatusCreated},
+		{name: "after the last class", body: `{"ClassTypeID":"ct-gi","Day":"monday","StartTime":"20:00","EndTime":"21:00"}`, want: http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleSchedules(rec, authRequest("POST", "/api/schedules", tt.body, adminSession))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/projections"
+)
+
+// handleTrainingGoalSuggest handles GET /api/training-goals/suggest?member_id=
+// Suggests a weekly training goal from the member's last eight weeks of attendance.
+// Staff with members.view may ask for any member; everyone else gets their own.
+func handleTrainingGoalSuggest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	ctx := r.Context()
+	sess, ok := middleware.GetSessionFromContext(ctx)
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "training_log") {
+		return
+	}
+
+	memberID, ok := viewableMemberID(w, r, sess, r.URL.Query().Get("member_id"))
+	if !ok {
+		return
+	}
+
+	result, err := projections.QueryGetTrainingGoalSuggestion(ctx, projections.GetTrainingGoalSuggestionQuery{
+		MemberID: memberID,
+		Now:      timeNow(),
+	}, projections.GetTrainingGoalSuggestionDeps{
+		AttendanceStore: stores.AttendanceStore,
+		GoalStore:       stores.TrainingGoalStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,84 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/projections"
+	attendanceDomain "workshop/internal/domain/attendance"
+	trainingGoalDomain "workshop/internal/domain/traininggoal"
+)
+
+// TestHandleTrainingGoalSuggest_AcceptReplacesGoal verifies a member gets a suggestion from
+// their own attendance, and accepting it replaces their active goal.
+func TestHandleTrainingGoalSuggest_AcceptReplacesGoal(t *testing.T) {
+	seedMessageThread(t)
+	ctx := context.Background()
+	now := time.Date(2026, 6, 17, 9, 0, 0, 0, time.UTC) // a Wednesday
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	// Three sessions a week for the last eight full weeks.
+	for w := 1; w <= 8; w++ {
+		monday := time.Date(2026, 6, 15, 18, 0, 0, 0, time.UTC).AddDate(0, 0, -7*w)
+		for _, d := range []int{0, 2, 4} {
+			day := monday.AddDate(0, 0, d)
+			stores.AttendanceStore.Save(ctx, attendanceDomain.Attendance{ID: fmt.Sprintf("a-%d-%d", w, d), MemberID: "m1", CheckInTime: day, ClassDate: day.Format("2006-01-02")})
+		}
+	}
+	stores.TrainingGoalStore.Save(ctx, trainingGoalDomain.TrainingGoal{ID: "old", MemberID: "m1", Target: 1, Period: trainingGoalDomain.PeriodWeekly, Active: true})
+
+	rec := httptest.NewRecorder()
+	handleTrainingGoalSuggest(rec, authRequest("GET", "/api/training-goals/suggest", "", memberSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var suggestion projections.GetTrainingGoalSuggestionResult
+	json.NewDecoder(rec.Body).Decode(&suggestion)
+	if suggestion.MemberID != "m1" || suggestion.Target != 3 || suggestion.CurrentTarget != 1 || suggestion.ActiveWeeks != 8 {
+		t.Fatalf("unexpected suggestion: %+v", suggestion)
+	}
+
+	body := fmt.Sprintf(`{"MemberID":%q,"Target":%d,"Period":%q}`, suggestion.MemberID, suggestion.Target, suggestion.Period)
+	rec = httptest.NewRecorder()
+	handleTrainingGoals(rec, authRequest("POST", "/api/training-goals", body, memberSession))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("accept: expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if old, _ := stores.TrainingGoalStore.GetByID(ctx, "old"); old.Active {
+		t.Error("expected the previous goal to be retired")
+	}
+	if active, _ := stores.TrainingGoalStore.GetActiveByMemberID(ctx, "m1"); active.Target != 3 {
+		t.Errorf("expected the active goal to be 3x weekly, got %+v", active)
+	}
+}
+
+// TestHandleTrainingGoalSuggest_Access verifies members only see their own suggestion.
+func TestHandleTrainingGoalSuggest_Access(t *testing.T) {
+	seedMessageThread(t)
+
+	for _, tt := range []struct {
+		name string
+		url  string
+		sess middleware.Session
+		want int
+	}{
+		{name: "member asks for another", url: "/api/training-goals/suggest?member_id=m2", sess: memberSession, want: http.StatusForbidden},
+		{name: "coach names a member", url: "/api/training-goals/suggest?member_id=m2", sess: coachSession, want: http.StatusOK},
+		{name: "coach without member_id", url: "/api/training-goals/suggest", sess: coachSession, want: http.StatusBadRequest},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleTrainingGoalSuggest(rec, authRequest("GET", tt.url, "", tt.sess))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+}
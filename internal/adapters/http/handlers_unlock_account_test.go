@@ -0,0 +1,36 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	accountDomain "workshop/internal/domain/account"
+)
+
+// TestHandleUnlockAccount verifies admins can clear a lockout and coaches cannot.
+func TestHandleUnlockAccount(t *testing.T) {
+	stores = newFullStores()
+	acctStore := stores.AccountStore.(*mockAccountStore)
+	acctStore.accounts["locked-1"] = accountDomain.Account{
+		ID: "locked-1", Email: "yuki@example.com", Role: accountDomain.RoleMember,
+		FailedLogins: accountDomain.MaxFailedLogins, LockedUntil: time.Now().Add(accountDomain.LockoutDuration),
+	}
+	body := `{"AccountID":"locked-1"}`
+
+	rec := httptest.NewRecorder()
+	handleUnlockAccount(rec, authRequest("POST", "/api/accounts/unlock", body, coachSession))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("coach: expected 403, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleUnlockAccount(rec, authRequest("POST", "/api/accounts/unlock", body, adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("admin: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if a := acctStore.accounts["locked-1"]; a.IsLocked() || a.FailedLogins != 0 {
+		t.Errorf("expected account unlocked, got %+v", a)
+	}
+}
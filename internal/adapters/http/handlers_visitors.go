@@ -0,0 +1,238 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	visitorDomain "workshop/internal/domain/visitor"
+)
+
+// visitorUpdateRequest is the body of POST /api/visitors.
+type visitorUpdateRequest struct {
+	ID        string `json:"ID"`
+	HomeGym   string `json:"HomeGym"`
+	DropInFee int    `json:"DropInFee"` // dollars per visit; 0 charges the default
+}
+
+// visitPaidRequest is the body of POST /api/visitors/visits.
+type visitPaidRequest struct {
+	ID   string `json:"ID"`
+	Paid bool   `json:"Paid"`
+}
+
+// visitorConvertRequest is the body of POST /api/visitors/convert.
+type visitorConvertRequest struct {
+	VisitorID string `json:"VisitorID"`
+	To        string `json:"To"` // trial or member
+}
+
+// handleAdminVisitorsPage handles GET /admin/visitors
+func handleAdminVisitorsPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "visitors") {
+		return
+	}
+	renderTemplate(w, r, "admin_visitors.html", map[string]any{"DefaultDropInFee": visitorDomain.DefaultDropInFee})
+}
+
+// handleVisitors handles GET/POST for /api/visitors
+// GET lists drop-in visitors, most recent visit first, optionally by ?status=. POST sets a
+// visitor's home gym and drop-in rate. Admin only.
+func handleVisitors(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "visitors") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		list, err := stores.VisitorStore.ListVisitors(ctx, r.URL.Query().Get("status"))
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if list == nil {
+			list = []visitorDomain.Visitor{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(list)
+
+	case "POST":
+		var input visitorUpdateRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		v, err := stores.VisitorStore.GetVisitor(ctx, input.ID)
+		if err != nil {
+			apierror.NotFound(w, "visitor not found")
+			return
+		}
+		v.HomeGym = strings.TrimSpace(input.HomeGym)
+		v.DropInFee = input.DropInFee
+		if err := v.Validate(); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err := stores.VisitorStore.SaveVisitor(ctx, v); err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleVisitorVisits handles GET/POST for /api/visitors/visits
+// GET lists one visitor's visits (?visitor_id=), most recent first. POST marks a visit's
+// drop-in fee paid or unpaid. Admin only.
+func handleVisitorVisits(w http.ResponseWriter, r *http.Request) {
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "visitors") {
+		return
+	}
+	ctx := r.Context()
+
+	switch r.Method {
+	case "GET":
+		visitorID := r.URL.Query().Get("visitor_id")
+		if visitorID == "" {
+			apierror.Validation(w, "visitor_id is required")
+			return
+		}
+		visits, err := stores.VisitorStore.ListVisitsByVisitor(ctx, visitorID)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if visits == nil {
+			visits = []visitorDomain.Visit{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(visits)
+
+	case "POST":
+		var input visitPaidRequest
+		if err := strictDecode(r, &input); err != nil {
+			apierror.Validation(w, "invalid JSON")
+			return
+		}
+		visit, err := stores.VisitorStore.GetVisit(ctx, input.ID)
+		if err != nil {
+			apierror.NotFound(w, "visit not found")
+			return
+		}
+		if err := visit.SetPaid(input.Paid); err != nil {
+			apierror.Validation(w, err.Error())
+			return
+		}
+		if err := stores.VisitorStore.SaveVisit(ctx, visit); err != nil {
+			internalError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(visit)
+
+	default:
+		apierror.MethodNotAllowed(w)
+	}
+}
+
+// handleVisitorConvert handles POST /api/visitors/convert
+// Signs a visitor up as a trial or member and withdraws their follow-up email if it has
+// not gone out. Admin only.
+func handleVisitorConvert(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "visitors") {
+		return
+	}
+	var input visitorConvertRequest
+	if err := strictDecode(r, &input); err != nil {
+		apierror.Validation(w, "invalid JSON")
+		return
+	}
+	if _, err := stores.VisitorStore.GetVisitor(r.Context(), input.VisitorID); err != nil {
+		apierror.NotFound(w, "visitor not found")
+		return
+	}
+
+	v, err := orchestrators.ExecuteConvertVisitor(r.Context(), orchestrators.ConvertVisitorInput{
+		VisitorID:   input.VisitorID,
+		To:          input.To,
+		ConvertedBy: sess.AccountID,
+	}, orchestrators.ConvertVisitorDeps{
+		VisitorStore: stores.VisitorStore,
+		MemberStore:  stores.MemberStore,
+		AccountStore: stores.AccountStore,
+		EmailStore:   stores.EmailStore,
+		Now:          timeNow,
+	})
+	if errors.Is(err, visitorDomain.ErrInvalidConversion) || errors.Is(err, visitorDomain.ErrAlreadyConverted) {
+		apierror.Validation(w, err.Error())
+		return
+	}
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	if v.Status == visitorDomain.StatusMember && referralStoreFor(r.Context()) != nil {
+		convertReferral(r.Context(), v.MemberID)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// handleVisitorReport handles GET /api/visitors/report
+// Drop-in numbers for the admin dashboard: visits, returning visitors, fees and sign-ups
+// over the last 30 days. Admin only.
+func handleVisitorReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "visitors") {
+		return
+	}
+	result, err := projections.QueryGetVisitorReport(r.Context(), timeNow(), projections.GetVisitorReportDeps{
+		VisitorStore: stores.VisitorStore,
+	})
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
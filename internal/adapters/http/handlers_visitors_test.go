@@ -0,0 +1,246 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/application/projections"
+	memberDomain "workshop/internal/domain/member"
+	visitorDomain "workshop/internal/domain/visitor"
+)
+
+// --- Mock stores ---
+
+type mockVisitorStore struct {
+	visitors map[string]visitorDomain.Visitor
+	visits   map[string]visitorDomain.Visit
+}
+
+func newMockVisitorStore() *mockVisitorStore {
+	return &mockVisitorStore{visitors: map[string]visitorDomain.Visitor{}, visits: map[string]visitorDomain.Visit{}}
+}
+
+// GetVisitor implements visitor.Store for testing.
+// PRE: id is non-empty
+// POST: Returns the visitor or sql.ErrNoRows
+func (m *mockVisitorStore) GetVisitor(_ context.Context, id string) (visitorDomain.Visitor, error) {
+	v, ok := m.visitors[id]
+	if !ok {
+		return visitorDomain.Visitor{}, sql.ErrNoRows
+	}
+	return v, nil
+}
+
+// GetVisitorByEmail implements visitor.Store for testing.
+// PRE: email is non-empty
+// POST: Returns the visitor or sql.ErrNoRows
+func (m *mockVisitorStore) GetVisitorByEmail(_ context.Context, email string) (visitorDomain.Visitor, error) {
+	for _, v := range m.visitors {
+		if v.Email == email {
+			return v, nil
+		}
+	}
+	return visitorDomain.Visitor{}, sql.ErrNoRows
+}
+
+// SaveVisitor implements visitor.Store for testing.
+// PRE: v has been validated
+// POST: Visitor is upserted
+func (m *mockVisitorStore) SaveVisitor(_ context.Context, v visitorDomain.Visitor) error {
+	m.visitors[v.ID] = v
+	return nil
+}
+
+// ListVisitors implements visitor.Store for testing.
+// PRE: none
+// POST: Returns visitors with the status, or every visitor for ""
+func (m *mockVisitorStore) ListVisitors(_ context.Context, status string) ([]visitorDomain.Visitor, error) {
+	var list []visitorDomain.Visitor
+	for _, v := range m.visitors {
+		if status == "" || v.Status == status {
+			list = append(list, v)
+		}
+	}
+	return list, nil
+}
+
+// GetVisit implements visitor.Store for testing.
+// PRE: id is non-empty
+// POST: Returns the visit or sql.ErrNoRows
+func (m *mockVisitorStore) GetVisit(_ context.Context, id string) (visitorDomain.Visit, error) {
+	v, ok := m.visits[id]
+	if !ok {
+		return visitorDomain.Visit{}, sql.ErrNoRows
+	}
+	return v, nil
+}
+
+// SaveVisit implements visitor.Store for testing.
+// PRE: v has been validated
+// POST: Visit is upserted
+func (m *mockVisitorStore) SaveVisit(_ context.Context, v visitorDomain.Visit) error {
+	m.visits[v.ID] = v
+	return nil
+}
+
+// ListVisitsByVisitor implements visitor.Store for testing.
+// PRE: visitorID is non-empty
+// POST: Returns the visitor's visits
+func (m *mockVisitorStore) ListVisitsByVisitor(_ context.Context, visitorID string) ([]visitorDomain.Visit, error) {
+	var list []visitorDomain.Visit
+	for _, v := range m.visits {
+		if v.VisitorID == visitorID {
+			list = append(list, v)
+		}
+	}
+	return list, nil
+}
+
+// ListVisitsSince implements visitor.Store for testing.
+// PRE: none
+// POST: Returns visits at or after since
+func (m *mockVisitorStore) ListVisitsSince(_ context.Context, since time.Time) ([]visitorDomain.Visit, error) {
+	var list []visitorDomain.Visit
+	for _, v := range m.visits {
+		if !v.VisitedAt.Before(since) {
+			list = append(list, v)
+		}
+	}
+	return list, nil
+}
+
+// newVisitorTestStores returns stores holding one first-time visitor with an unpaid visit.
+func newVisitorTestStores() (*Stores, *mockVisitorStore) {
+	s := newFullStores()
+	vs := newMockVisitorStore()
+	s.VisitorStore = vs
+	now := time.Now()
+	s.MemberStore.Save(context.Background(), memberDomain.Member{ID: "guest-1", Name: "Sam Visitor", Email: "sam@example.com", Program: memberDomain.ProgramAdults, Status: memberDomain.StatusActive})
+	vs.visitors["v1"] = visitorDomain.Visitor{ID: "v1", MemberID: "guest-1", Name: "Sam Visitor", Email: "sam@example.com", Status: visitorDomain.StatusVisiting, FirstVisit: now, LastVisit: now, VisitCount: 1, CreatedAt: now}
+	vs.visits["visit-1"] = visitorDomain.Visit{ID: "visit-1", VisitorID: "v1", VisitedAt: now, Fee: visitorDomain.DefaultDropInFee}
+	return s, vs
+}
+
+// TestHandleVisitors verifies an admin can list visitors, set their home gym and fee, and
+// mark visits paid.
+func TestHandleVisitors(t *testing.T) {
+	var vs *mockVisitorStore
+	stores, vs = newVisitorTestStores()
+
+	rec := httptest.NewRecorder()
+	handleVisitors(rec, authRequest("GET", "/api/visitors?status=visiting", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var list []visitorDomain.Visitor
+	json.NewDecoder(rec.Body).Decode(&list)
+	if len(list) != 1 || list[0].ID != "v1" {
+		t.Errorf("expected the one visitor, got %+v", list)
+	}
+
+	for _, tt := range []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "home gym and fee", body: `{"ID":"v1","HomeGym":" Gracie Barra ","DropInFee":30}`, want: http.StatusOK},
+		{name: "negative fee", body: `{"ID":"v1","DropInFee":-5}`, want: http.StatusBadRequest},
+		{name: "unknown visitor", body: `{"ID":"nope","HomeGym":"Elsewhere"}`, want: http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleVisitors(rec, authRequest("POST", "/api/visitors", tt.body, adminSession))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+	if v := vs.visitors["v1"]; v.HomeGym != "Gracie Barra" || v.DropInFee != 30 {
+		t.Errorf("expected home gym and fee saved, got %+v", v)
+	}
+
+	rec = httptest.NewRecorder()
+	handleVisitorVisits(rec, authRequest("POST", "/api/visitors/visits", `{"ID":"visit-1","Paid":true}`, adminSession))
+	if rec.Code != http.StatusOK || !vs.visits["visit-1"].Paid {
+		t.Fatalf("mark paid: expected 200 and paid, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	handleVisitorVisits(rec, authRequest("POST", "/api/visitors/visits", `{"ID":"visit-1","Paid":true}`, adminSession))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("paid twice: expected 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleVisitorReport(rec, authRequest("GET", "/api/visitors/report", "", adminSession))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("report: expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var report projections.VisitorReportResult
+	json.NewDecoder(rec.Body).Decode(&report)
+	if report.Visits != 1 || report.NewVisitors != 1 || report.DropInRevenue != visitorDomain.DefaultDropInFee {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+// TestHandleVisitorConvert verifies a visitor can be signed up once, and only as a trial
+// or member.
+func TestHandleVisitorConvert(t *testing.T) {
+	var vs *mockVisitorStore
+	stores, vs = newVisitorTestStores()
+
+	for _, tt := range []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "unknown visitor", body: `{"VisitorID":"nope","To":"trial"}`, want: http.StatusNotFound},
+		{name: "bad status", body: `{"VisitorID":"v1","To":"coach"}`, want: http.StatusBadRequest},
+		{name: "trial", body: `{"VisitorID":"v1","To":"trial"}`, want: http.StatusOK},
+		{name: "trial again", body: `{"VisitorID":"v1","To":"trial"}`, want: http.StatusBadRequest},
+		{name: "member", body: `{"VisitorID":"v1","To":"member"}`, want: http.StatusOK},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handleVisitorConvert(rec, authRequest("POST", "/api/visitors/convert", tt.body, adminSession))
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+	if v := vs.visitors["v1"]; v.Status != visitorDomain.StatusMember {
+		t.Errorf("expected the visitor to be a member, got %q", v.Status)
+	}
+}
+
+// TestHandleVisitors_AdminOnly verifies coaches and members cannot see or change visitors.
+func TestHandleVisitors_AdminOnly(t *testing.T) {
+	stores, _ = newVisitorTestStores()
+	for _, tt := range []struct {
+		name    string
+		handler http.HandlerFunc
+		method  string
+		url     string
+		body    string
+	}{
+		{name: "list", handler: handleVisitors, method: "GET", url: "/api/visitors"},
+		{name: "visits", handler: handleVisitorVisits, method: "GET", url: "/api/visitors/visits?visitor_id=v1"},
+		{name: "convert", handler: handleVisitorConvert, method: "POST", url: "/api/visitors/convert", body: `{"VisitorID":"v1","To":"trial"}`},
+		{name: "report", handler: handleVisitorReport, method: "GET", url: "/api/visitors/report"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, sess := range []middleware.Session{coachSession, memberSession} {
+				rec := httptest.NewRecorder()
+				tt.handler(rec, authRequest(tt.method, tt.url, tt.body, sess))
+				if rec.Code != http.StatusForbidden {
+					t.Errorf("%s: expected 403, got %d", sess.Role, rec.Code)
+				}
+			}
+		})
+	}
+}
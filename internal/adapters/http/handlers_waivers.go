@@ -0,0 +1,129 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	waiverDomain "workshop/internal/domain/waiver"
+)
+
+// waiverView is the JSON shape of one signed waiver in a member's waiver history. The
+// signed document itself is fetched separately from /members/waiver.
+type waiverView struct {
+	ID                   string    `json:"id"`
+	SignedAt             time.Time `json:"signed_at"`
+	Valid                bool      `json:"valid"`
+	SignerName           string    `json:"signer_name,omitempty"`
+	GuardianSigned       bool      `json:"guardian_signed"`
+	GuardianName         string    `json:"guardian_name,omitempty"`
+	GuardianRelationship string    `json:"guardian_relationship,omitempty"`
+	GuardianEmail        string    `json:"guardian_email,omitempty"`
+	GuardianPhone        string    `json:"guardian_phone,omitempty"`
+	HasSignature         bool      `json:"has_signature"`
+	HasDocument          bool      `json:"has_document"`
+	DocumentHash         string    `json:"document_hash,omitempty"`
+	DocumentIntact       bool      `json:"document_intact"`
+}
+
+func toWaiverView(w waiverDomain.Waiver) waiverView {
+	return waiverView{
+		ID:                   w.ID,
+		SignedAt:             w.SignedAt,
+		Valid:                w.IsValid(),
+		SignerName:           w.SignerName,
+		GuardianSigned:       w.IsGuardianSigned(),
+		GuardianName:         w.Guardian.Name,
+		GuardianRelationship: w.Guardian.Relationship,
+		GuardianEmail:        w.Guardian.Email,
+		GuardianPhone:        w.Guardian.Phone,
+		HasSignature:         w.SignatureImage != "",
+		HasDocument:          w.Document != "",
+		DocumentHash:         w.DocumentHash,
+		DocumentIntact:       w.DocumentIntact(),
+	}
+}
+
+// handleMemberWaivers handles GET /api/members/waivers?member_id=
+// Lists every waiver the member has signed, newest first, with who signed and whether the
+// stored document still matches its hash. Staff with members.view may view any member.
+func handleMemberWaivers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		apierror.Unauthorized(w, "not authenticated")
+		return
+	}
+	if !requireFeatureAPI(w, r, sess, "member_mgmt") {
+		return
+	}
+	memberID, ok := viewableMemberID(w, r, sess, r.URL.Query().Get("member_id"))
+	if !ok {
+		return
+	}
+	waivers, err := stores.WaiverStore.ListByMemberID(r.Context(), memberID)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	views := make([]waiverView, 0, len(waivers))
+	for _, wv := range waivers {
+		views = append(views, toWaiverView(wv))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(views)
+}
+
+// handleWaiverDocument handles GET /members/waiver?id=<waiverID>[&download=1]
+// Serves the signed waiver exactly as it was sealed, for insurance audits. Refuses a
+// document whose hash no longer matches rather than present an altered copy as signed.
+func handleWaiverDocument(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		apierror.MethodNotAllowed(w)
+		return
+	}
+	sess, ok := middleware.GetSessionFromContext(r.Context())
+	if !ok {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+	if !requireFeaturePage(w, r, sess, "member_mgmt") {
+		return
+	}
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		apierror.Validation(w, "id is required")
+		return
+	}
+	signed, err := stores.WaiverStore.GetByID(r.Context(), id)
+	if err != nil || signed.ID == "" {
+		apierror.NotFound(w, "waiver not found")
+		return
+	}
+	if _, ok := viewableMemberID(w, r, sess, signed.MemberID); !ok {
+		return
+	}
+	if signed.Document == "" {
+		apierror.NotFound(w, "no signed copy is stored for this waiver")
+		return
+	}
+	if !signed.DocumentIntact() {
+		apierror.Conflict(w, waiverDomain.ErrDocumentTampered.Error())
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, no-store")
+	// The snapshot is static: no scripts, and images only from its own data URLs.
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; img-src data:; style-src 'unsafe-inline'")
+	w.Header().Set("X-Document-SHA256", signed.DocumentHash)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if r.URL.Query().Get("download") == "1" {
+		w.Header().Set("Content-Disposition", `attachment; filename="waiver-`+signed.ID+`.html"`)
+	}
+	w.Write([]byte(signed.Document))
+}
@@ -0,0 +1,67 @@
+package web
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	memberDomain "workshop/internal/domain/member"
+	waiverDomain "workshop/internal/domain/waiver"
+)
+
+// TestHandleWaivers_GuardianSignsAndStaffRetrievesDocument verifies a kids waiver needs a
+// guardian, and the signed copy can be listed and retrieved intact from the profile.
+func TestHandleWaivers_GuardianSignsAndStaffRetrievesDocument(t *testing.T) {
+	stores = newFullStores()
+	ctx := context.Background()
+	stores.MemberStore.Save(ctx, memberDomain.Member{ID: "k1", Name: "Tama", Email: "tama@test.com", Program: memberDomain.ProgramKids, Status: memberDomain.StatusActive})
+	sign := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handlePostWaiversSignWaiver(rec, authRequest("POST", "/waivers", body, adminSession))
+		return rec
+	}
+
+	if rec := sign(`{"AcceptedTerms":true,"Email":"tama@test.com","MemberName":"Tama"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("kid without guardian: expected 400, got %d", rec.Code)
+	}
+	signature := waiverDomain.SignatureImagePrefix + base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n\x1a\nstrokes"))
+	body := fmt.Sprintf(`{"AcceptedTerms":true,"Email":"tama@test.com","MemberName":"Tama","SignatureImage":%q,"GuardianName":"Mere","GuardianRelationship":"mother","GuardianPhone":"021 555 0101"}`, signature)
+	if rec := sign(body); rec.Code != http.StatusNoContent {
+		t.Fatalf("guardian signs: expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec := httptest.NewRecorder()
+	handleMemberWaivers(rec, authRequest("GET", "/api/members/waivers?member_id=k1", "", adminSession))
+	var list []waiverView
+	json.NewDecoder(rec.Body).Decode(&list)
+	if rec.Code != http.StatusOK || len(list) != 1 || !list[0].GuardianSigned || list[0].GuardianName != "Mere" || !list[0].DocumentIntact || !list[0].HasSignature {
+		t.Fatalf("waivers: %d %+v, want one intact guardian-signed waiver", rec.Code, list)
+	}
+
+	rec = httptest.NewRecorder()
+	handleWaiverDocument(rec, authRequest("GET", "/members/waiver?id="+list[0].ID+"&download=1", "", adminSession))
+	if rec.Code != http.StatusOK || rec.Header().Get("X-Document-SHA256") != list[0].DocumentHash || !strings.Contains(rec.Body.String(), "Mere") ||
+		!strings.Contains(rec.Header().Get("Content-Disposition"), "attachment") {
+		t.Fatalf("document: %d %v", rec.Code, rec.Header())
+	}
+
+	rec = httptest.NewRecorder()
+	handleWaiverDocument(rec, authRequest("GET", "/members/waiver?id="+list[0].ID, "", memberSession))
+	if rec.Code == http.StatusOK {
+		t.Error("a member should not be able to read another member's waiver")
+	}
+
+	tampered, _ := stores.WaiverStore.GetByID(ctx, list[0].ID)
+	tampered.Document = strings.Replace(tampered.Document, "Mere", "Someone else", 1)
+	stores.WaiverStore.Save(ctx, tampered)
+	rec = httptest.NewRecorder()
+	handleWaiverDocument(rec, authRequest("GET", "/members/waiver?id="+list[0].ID, "", adminSession))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("tampered document: expected 409, got %d", rec.Code)
+	}
+}
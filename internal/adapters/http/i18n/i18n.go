@@ -0,0 +1,185 @@
+// Package i18n translates member-facing page text and API validation messages.
+//
+// Each locale in account.ValidLocales has a file in locales/ with two sections:
+//
+//	{"name": "English (New Zealand)",
+//	 "messages": {"nav.messages": "Messages", "dashboard.welcome_back": "Welcome back, %s"},
+//	 "errors": {"title is required": "title is required"}}
+//
+// messages holds page text by key; values may take fmt verbs. errors maps the English text of a
+// domain validation error to what the caller should read. en-NZ is the source: every other
+// locale lists the same keys, and an empty value is a placeholder awaiting translation that
+// falls back to en-NZ.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	accountDomain "workshop/internal/domain/account"
+)
+
+// DefaultLocale is used when neither the account nor the browser names a supported locale.
+const DefaultLocale = accountDomain.LocaleEnglishNZ
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalog is one locale file.
+type catalog struct {
+	Name     string            `json:"name"`
+	Messages map[string]string `json:"messages"`
+	Errors   map[string]string `json:"errors"`
+}
+
+// catalogs holds every supported locale by tag, loaded once at start-up.
+var catalogs = mustLoadCatalogs()
+
+// mustLoadCatalogs reads the locale file of each valid locale.
+func mustLoadCatalogs() map[string]catalog {
+	out := make(map[string]catalog, len(accountDomain.ValidLocales))
+	for _, tag := range accountDomain.ValidLocales {
+		data, err := localeFiles.ReadFile("locales/" + tag + ".json")
+		if err != nil {
+			panic(fmt.Sprintf("i18n: missing locale file for %s: %v", tag, err))
+		}
+		var c catalog
+		if err := json.Unmarshal(data, &c); err != nil {
+			panic(fmt.Sprintf("i18n: invalid locale file for %s: %v", tag, err))
+		}
+		out[tag] = c
+	}
+	return out
+}
+
+// Locale describes a supported locale for pickers.
+type Locale struct {
+	Tag  string
+	Name string // in its own language
+}
+
+// Locales returns the supported locales, default first.
+// INVARIANT: Pure function, no side effects
+func Locales() []Locale {
+	out := make([]Locale, 0, len(accountDomain.ValidLocales))
+	for _, tag := range accountDomain.ValidLocales {
+		out = append(out, Locale{Tag: tag, Name: catalogs[tag].Name})
+	}
+	return out
+}
+
+// Match returns the supported locale for a language tag, or "" when there is none.
+// Tags compare case-insensitively, and a tag matches a supported locale of the same
+// language, so "mi-NZ" gives "mi" and "en-GB" gives "en-NZ".
+// INVARIANT: Pure function, no side effects
+func Match(tag string) string {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return ""
+	}
+	for _, supported := range accountDomain.ValidLocales {
+		if strings.EqualFold(tag, supported) {
+			return supported
+		}
+	}
+	language := baseLanguage(tag)
+	for _, supported := range accountDomain.ValidLocales {
+		if strings.EqualFold(language, baseLanguage(supported)) {
+			return supported
+		}
+	}
+	return ""
+}
+
+// Negotiate picks the supported locale the browser prefers from an Accept-Language header.
+// INVARIANT: Pure function, no side effects
+func Negotiate(acceptLanguage string) string {
+	type preference struct {
+		tag     string
+		quality float64
+	}
+	var prefs []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = v
+			}
+		}
+		if tag != "" && tag != "*" && quality > 0 {
+			prefs = append(prefs, preference{tag: tag, quality: quality})
+		}
+	}
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].quality > prefs[j].quality })
+	for _, p := range prefs {
+		if locale := Match(p.tag); locale != "" {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// T returns the page text for key in locale, formatted with args when given.
+// Placeholders and unknown locales fall back to DefaultLocale; an unknown key is returned
+// as-is so a missing translation shows up on the page rather than as a blank.
+// INVARIANT: Pure function, no side effects
+func T(locale, key string, args ...any) string {
+	text := catalogs[locale].Messages[key]
+	if text == "" {
+		text = catalogs[DefaultLocale].Messages[key]
+	}
+	if text == "" {
+		return key
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// Message returns a validation message in locale. Messages not in the catalogue are
+// returned unchanged.
+// INVARIANT: Pure function, no side effects
+func Message(locale, message string) string {
+	if text := catalogs[locale].Errors[message]; text != "" {
+		return text
+	}
+	if text := catalogs[DefaultLocale].Errors[message]; text != "" {
+		return text
+	}
+	return message
+}
+
+// HasKey reports whether key is defined in the default locale.
+// INVARIANT: Pure function, no side effects
+func HasKey(key string) bool {
+	return catalogs[DefaultLocale].Messages[key] != ""
+}
+
+type contextKey struct{}
+
+// WithLocale returns a context carrying the request's locale.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, contextKey{}, locale)
+}
+
+// FromContext returns the request's locale, or DefaultLocale when none was set.
+func FromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(contextKey{}).(string); ok && locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// baseLanguage returns the language subtag of a tag ("mi" for "mi-NZ").
+func baseLanguage(tag string) string {
+	language, _, _ := strings.Cut(tag, "-")
+	language, _, _ = strings.Cut(language, "_")
+	return language
+}
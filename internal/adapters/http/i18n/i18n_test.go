@@ -0,0 +1,185 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestMatch tests mapping language tags onto supported locales.
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"en-NZ", "en-NZ"},
+		{"EN-nz", "en-NZ"},
+		{"en-GB", "en-NZ"},
+		{"en", "en-NZ"},
+		{"mi", "mi"},
+		{"mi-NZ", "mi"},
+		{"fr-FR", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.tag); got != tt.want {
+			t.Errorf("Match(%q) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+}
+
+// TestNegotiate tests picking a locale from an Accept-Language header.
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", DefaultLocale},
+		{"fr-FR, de;q=0.8", DefaultLocale},
+		{"mi-NZ, en-NZ;q=0.9", "mi"},
+		{"en-NZ;q=0.5, mi;q=0.9", "mi"},
+		{"fr, mi;q=0.2", "mi"},
+		{"mi;q=0, en-AU", "en-NZ"},
+	}
+	for _, tt := range tests {
+		if got := Negotiate(tt.header); got != tt.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+// TestT tests lookup, formatting and fallback of page text.
+func TestT(t *testing.T) {
+	if got := T("mi", "nav.logout"); got != "Takiputa" {
+		t.Errorf("translated text = %q", got)
+	}
+	if got := T("mi", "dashboard.welcome_back", "Hemi"); got != "Nau mai anō, Hemi" {
+		t.Errorf("formatted text = %q", got)
+	}
+	if got := T("mi", "dashboard.quick_links"); got != "Quick Links" {
+		t.Errorf("placeholder should fall back to en-NZ, got %q", got)
+	}
+	if got := T("xx", "nav.logout"); got != "Logout" {
+		t.Errorf("unknown locale should fall back to en-NZ, got %q", got)
+	}
+	if got := T("mi", "no.such.key"); got != "no.such.key" {
+		t.Errorf("unknown key should be returned as-is, got %q", got)
+	}
+}
+
+// TestMessage tests that validation messages outside the catalogue pass through unchanged.
+func TestMessage(t *testing.T) {
+	if got := Message("mi", "title is required"); got != "title is required" {
+		t.Errorf("placeholder should fall back to en-NZ, got %q", got)
+	}
+	if got := Message("mi", "something else went wrong"); got != "something else went wrong" {
+		t.Errorf("unknown message = %q", got)
+	}
+}
+
+// fmtVerb matches the formatting verbs a translation must keep.
+var fmtVerb = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+// verbs returns the formatting verbs in s, in order.
+func verbs(s string) []string {
+	return fmtVerb.FindAllString(s, -1)
+}
+
+// TestLocaleFiles_MatchDefault verifies every locale lists exactly the en-NZ keys, that en-NZ has
+// text for each, and that translations keep the same formatting verbs.
+func TestLocaleFiles_MatchDefault(t *testing.T) {
+	source := catalogs[DefaultLocale]
+	for key, text := range source.Messages {
+		if text == "" {
+			t.Errorf("%s: message %q has no text", DefaultLocale, key)
+		}
+	}
+	for msg, text := range source.Errors {
+		if text == "" {
+			t.Errorf("%s: error %q has no text", DefaultLocale, msg)
+		}
+	}
+	for _, l := range Locales() {
+		c := catalogs[l.Tag]
+		if c.Name == "" {
+			t.Errorf("%s: locale file has no name", l.Tag)
+		}
+		for key, text := range source.Messages {
+			translated, ok := c.Messages[key]
+			if !ok {
+				t.Errorf("%s: message %q is missing; add it (an empty value is a placeholder)", l.Tag, key)
+				continue
+			}
+			if translated != "" && !slices.Equal(verbs(translated), verbs(text)) {
+				t.Errorf("%s: message %q uses %v, want %v", l.Tag, key, verbs(translated), verbs(text))
+			}
+		}
+		for key := range c.Messages {
+			if _, ok := source.Messages[key]; !ok {
+				t.Errorf("%s: message %q is not in %s", l.Tag, key, DefaultLocale)
+			}
+		}
+		for msg := range source.Errors {
+			if _, ok := c.Errors[msg]; !ok {
+				t.Errorf("%s: error %q is missing; add it (an empty value is a placeholder)", l.Tag, msg)
+			}
+		}
+		for msg := range c.Errors {
+			if _, ok := source.Errors[msg]; !ok {
+				t.Errorf("%s: error %q is not in %s", l.Tag, msg, DefaultLocale)
+			}
+		}
+	}
+}
+
+// TestLocaleFiles_ErrorsExist verifies each catalogued validation message is still produced by
+// an errors.New in the domain or application layer, so reworded errors are not silently untranslated.
+func TestLocaleFiles_ErrorsExist(t *testing.T) {
+	produced := map[string]bool{}
+	for _, dir := range []string{"../../../domain", "../../../application"} {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return err
+			}
+			file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+			if err != nil {
+				return err
+			}
+			ast.Inspect(file, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || len(call.Args) != 1 {
+					return true
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok || sel.Sel.Name != "New" {
+					return true
+				}
+				if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "errors" {
+					return true
+				}
+				if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					if msg, err := strconv.Unquote(lit.Value); err == nil {
+						produced[msg] = true
+					}
+				}
+				return true
+			})
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for msg := range catalogs[DefaultLocale].Errors {
+		if !produced[msg] {
+			t.Errorf("error %q is not produced by any errors.New; update or remove it in every locale file", msg)
+		}
+	}
+}
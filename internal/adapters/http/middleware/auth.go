@@ -4,11 +4,16 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
+	"log/slog"
 	"net/http"
-	"sync"
 	"time"
 
+	authsessionStore "workshop/internal/adapters/storage/authsession"
 	domainAccount "workshop/internal/domain/account"
+	"workshop/internal/domain/authsession"
+
+	"github.com/google/uuid"
 )
 
 // contextKey is an unexported type for context keys in this package.
@@ -18,99 +23,237 @@ const accountContextKey contextKey = "account"
 
 // Session represents an authenticated session.
 type Session struct {
+	ID                     string // stable identifier for listing and revoking; not the cookie token
 	AccountID              string
 	Email                  string
 	Role                   string
 	BetaTester             bool
 	CreatedAt              time.Time
+	LastSeenAt             time.Time
+	ExpiresAt              time.Time
 	PasswordChangeRequired bool
+	LocationID             string // selected location; empty means all locations
+	Locale                 string // account's chosen language; empty means follow the browser
 
 	// DevMode impersonation fields — populated only when an admin is impersonating another role.
 	RealAccountID string
 	RealEmail     string
 	RealRole      string
+
+	// ImpersonationExpiresAt is set only while an admin is signed in as another account.
+	ImpersonationExpiresAt time.Time
 }
 
 // IsImpersonating returns true if this session is currently impersonating another role.
 // INVARIANT: Session fields are not mutated
 func (s Session) IsImpersonating() bool {
-	return s.RealRole != ""
+	return s.impersonation().IsImpersonating()
 }
 
-// SessionStore is an in-memory session store.
-type SessionStore struct {
-	mu       sync.RWMutex
-	sessions map[string]Session
+// IsImpersonatingAccount returns true if an admin is signed in as another account.
+// INVARIANT: Session fields are not mutated
+func (s Session) IsImpersonatingAccount() bool {
+	return s.impersonation().IsImpersonatingAccount()
+}
+
+// ImpersonationExpired reports whether an account impersonation has run past its limit at now.
+// INVARIANT: Session fields are not mutated
+func (s Session) ImpersonationExpired(now time.Time) bool {
+	return s.impersonation().ImpersonationExpired(now)
+}
+
+// WithoutImpersonation returns the session as the admin behind an impersonation.
+// PRE: IsImpersonating is true
+// POST: AccountID, Email and Role are the admin's; impersonation fields are cleared
+func (s Session) WithoutImpersonation() Session {
+	record := s.impersonation()
+	record.EndImpersonation()
+	s.AccountID, s.Email, s.Role = record.AccountID, record.Email, record.Role
+	s.RealAccountID, s.RealEmail, s.RealRole = record.RealAccountID, record.RealEmail, record.RealRole
+	s.ImpersonationExpiresAt = record.ImpersonationExpiresAt
+	return s
 }
 
-// NewSessionStore creates a new in-memory session store.
-func NewSessionStore() *SessionStore {
-	return &SessionStore{
-		sessions: make(map[string]Session),
+// impersonation returns the identity fields as a stored session, so the impersonation rules
+// are the ones in domain/authsession.
+func (s Session) impersonation() authsession.Session {
+	return authsession.Session{
+		AccountID:              s.AccountID,
+		Email:                  s.Email,
+		Role:                   s.Role,
+		RealAccountID:          s.RealAccountID,
+		RealEmail:              s.RealEmail,
+		RealRole:               s.RealRole,
+		ImpersonationExpiresAt: s.ImpersonationExpiresAt,
 	}
 }
 
+// SessionStore issues and resolves session tokens, persisting sessions so they
+// survive restarts and can be revoked. Expiry slides with use (see domain/authsession).
+type SessionStore struct {
+	store authsessionStore.Store
+	now   func() time.Time
+}
+
+// NewSessionStore creates a session store backed by store.
+func NewSessionStore(store authsessionStore.Store) *SessionStore {
+	return &SessionStore{store: store, now: time.Now}
+}
+
 // Create stores a new session and returns the token.
 // PRE: accountID, email, role are non-empty
 // POST: Session is stored, token is returned
-func (ss *SessionStore) Create(accountID, email, role string, passwordChangeRequired bool, betaTester bool) (string, error) {
+func (ss *SessionStore) Create(ctx context.Context, accountID, email, role string, passwordChangeRequired bool, betaTester bool) (string, error) {
 	token, err := generateToken()
 	if err != nil {
 		return "", err
 	}
-	ss.mu.Lock()
-	defer ss.mu.Unlock()
-	ss.sessions[token] = Session{
+	record := authsession.Session{
+		ID:                     uuid.NewString(),
+		TokenHash:              authsession.HashToken(token),
 		AccountID:              accountID,
 		Email:                  email,
 		Role:                   role,
 		BetaTester:             betaTester,
-		CreatedAt:              time.Now(),
 		PasswordChangeRequired: passwordChangeRequired,
 	}
+	record.Start(ss.now())
+	if err := record.Validate(); err != nil {
+		return "", err
+	}
+	if err := ss.store.Save(ctx, record); err != nil {
+		return "", err
+	}
 	return token, nil
 }
 
-// Get retrieves a session by token.
+// Get retrieves a session by token, sliding its expiry forward.
 // PRE: token is non-empty
-// POST: Returns session if valid and not expired
-func (ss *SessionStore) Get(token string) (Session, bool) {
-	ss.mu.RLock()
-	defer ss.mu.RUnlock()
-	session, ok := ss.sessions[token]
+// POST: Returns session if valid and not expired; expired sessions are removed
+func (ss *SessionStore) Get(ctx context.Context, token string) (Session, bool) {
+	record, ok := ss.lookup(ctx, token)
 	if !ok {
 		return Session{}, false
 	}
-	// Sessions expire after 24 hours
-	if time.Since(session.CreatedAt) > 24*time.Hour {
-		delete(ss.sessions, token)
-		return Session{}, false
+	if record.Touch(ss.now()) {
+		if err := ss.store.Save(ctx, record); err != nil {
+			slog.WarnContext(ctx, "session_touch_failed", "session_id", record.ID, "error", err)
+		}
 	}
-	return session, true
+	return toSession(record), true
 }
 
 // Delete removes a session by token.
 // PRE: token is non-empty
 // POST: Session with given token is removed
-func (ss *SessionStore) Delete(token string) {
-	ss.mu.Lock()
-	defer ss.mu.Unlock()
-	delete(ss.sessions, token)
+func (ss *SessionStore) Delete(ctx context.Context, token string) {
+	record, err := ss.store.GetByTokenHash(ctx, authsession.HashToken(token))
+	if err != nil {
+		return
+	}
+	if err := ss.store.Delete(ctx, record.ID); err != nil {
+		slog.WarnContext(ctx, "session_delete_failed", "session_id", record.ID, "error", err)
+	}
 }
 
-// Update replaces the session for a given token in-place.
+// Update replaces the identity, role, location, locale and impersonation state of the session for a token.
+// Timestamps are kept; they only move through Get.
 // PRE: token exists in the store
 // POST: Session is replaced with the new value
-func (ss *SessionStore) Update(token string, session Session) bool {
-	ss.mu.Lock()
-	defer ss.mu.Unlock()
-	if _, ok := ss.sessions[token]; !ok {
+func (ss *SessionStore) Update(ctx context.Context, token string, session Session) bool {
+	record, ok := ss.lookup(ctx, token)
+	if !ok {
+		return false
+	}
+	record.AccountID = session.AccountID
+	record.Email = session.Email
+	record.Role = session.Role
+	record.BetaTester = session.BetaTester
+	record.PasswordChangeRequired = session.PasswordChangeRequired
+	record.LocationID = session.LocationID
+	record.Locale = session.Locale
+	record.RealAccountID = session.RealAccountID
+	record.RealEmail = session.RealEmail
+	record.RealRole = session.RealRole
+	record.ImpersonationExpiresAt = session.ImpersonationExpiresAt
+	if err := ss.store.Save(ctx, record); err != nil {
+		slog.WarnContext(ctx, "session_update_failed", "session_id", record.ID, "error", err)
 		return false
 	}
-	ss.sessions[token] = session
 	return true
 }
 
+// List returns every unexpired session, most recently used first.
+// PRE: none
+// POST: Returns active sessions
+func (ss *SessionStore) List(ctx context.Context) ([]Session, error) {
+	records, err := ss.store.ListActive(ctx, ss.now())
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Session, 0, len(records))
+	for _, record := range records {
+		out = append(out, toSession(record))
+	}
+	return out, nil
+}
+
+// Revoke removes the session with the given ID.
+// PRE: id is non-empty
+// POST: Session is removed; returns an error wrapping authsession.ErrNotFound if it does not exist
+func (ss *SessionStore) Revoke(ctx context.Context, id string) error {
+	if _, err := ss.store.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return ss.store.Delete(ctx, id)
+}
+
+// RevokeAccount removes every session of an account, signing it out on all devices.
+// PRE: accountID is non-empty
+// POST: Returns the number of sessions removed
+func (ss *SessionStore) RevokeAccount(ctx context.Context, accountID string) (int, error) {
+	return ss.store.DeleteByAccountID(ctx, accountID)
+}
+
+// lookup loads the unexpired record for a token, removing it if it has expired.
+func (ss *SessionStore) lookup(ctx context.Context, token string) (authsession.Session, bool) {
+	record, err := ss.store.GetByTokenHash(ctx, authsession.HashToken(token))
+	if err != nil {
+		if !errors.Is(err, authsession.ErrNotFound) {
+			slog.WarnContext(ctx, "session_lookup_failed", "error", err)
+		}
+		return authsession.Session{}, false
+	}
+	if record.Expired(ss.now()) {
+		if err := ss.store.Delete(ctx, record.ID); err != nil {
+			slog.WarnContext(ctx, "session_delete_failed", "session_id", record.ID, "error", err)
+		}
+		return authsession.Session{}, false
+	}
+	return record, true
+}
+
+// toSession converts a stored record to the request-scoped Session.
+func toSession(record authsession.Session) Session {
+	return Session{
+		ID:                     record.ID,
+		AccountID:              record.AccountID,
+		Email:                  record.Email,
+		Role:                   record.Role,
+		BetaTester:             record.BetaTester,
+		CreatedAt:              record.CreatedAt,
+		LastSeenAt:             record.LastSeenAt,
+		ExpiresAt:              record.ExpiresAt,
+		PasswordChangeRequired: record.PasswordChangeRequired,
+		LocationID:             record.LocationID,
+		Locale:                 record.Locale,
+		RealAccountID:          record.RealAccountID,
+		RealEmail:              record.RealEmail,
+		RealRole:               record.RealRole,
+		ImpersonationExpiresAt: record.ImpersonationExpiresAt,
+	}
+}
+
 const sessionCookieName = "workshop_session"
 
 // SecureCookies controls the Secure flag on session cookies.
@@ -126,14 +269,14 @@ func Auth(sessions *SessionStore) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			cookie, err := r.Cookie(sessionCookieName)
 			if err == nil && cookie.Value != "" {
-				if session, ok := sessions.Get(cookie.Value); ok {
+				if session, ok := sessions.Get(r.Context(), cookie.Value); ok {
 					ctx := context.WithValue(r.Context(), accountContextKey, session)
 					r = r.WithContext(ctx)
 
 					// Force password change redirect
 					if session.PasswordChangeRequired {
 						path := r.URL.Path
-						if path != "/change-password" && path != "/logout" && path != "/login" {
+						if path != "/change-password" && path != "/logout" && path != "/logout/all" && path != "/login" {
 							http.Redirect(w, r, "/change-password", http.StatusSeeOther)
 							return
 						}
@@ -193,7 +336,7 @@ func SetSessionCookie(w http.ResponseWriter, token string) {
 		Secure:   SecureCookies,
 		SameSite: http.SameSiteStrictMode,
 		Path:     "/",
-		MaxAge:   86400, // 24 hours
+		MaxAge:   int(authsession.MaxLifetime.Seconds()), // the server enforces the shorter idle timeout
 	})
 }
 
@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuthLimitConfig sets the token bucket thresholds for credential endpoints.
+// Each bucket holds up to its limit and refills that many tokens per Window.
+type AuthLimitConfig struct {
+	PerIP    int           // attempts per Window from one client IP
+	PerEmail int           // attempts per Window against one email address
+	Window   time.Duration // refill period
+}
+
+// DefaultAuthLimitConfig allows short bursts of typos but stops scripted guessing.
+var DefaultAuthLimitConfig = AuthLimitConfig{PerIP: 20, PerEmail: 5, Window: 5 * time.Minute}
+
+// bucket is a continuously refilling token bucket.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// AuthLimiter rate limits login, activation and password attempts per IP and per email.
+// INVARIANT: safe for concurrent use.
+type AuthLimiter struct {
+	mu      sync.Mutex
+	config  AuthLimitConfig
+	now     func() time.Time
+	ips     map[string]*bucket
+	emails  map[string]*bucket
+	lastGC  time.Time
+	gcEvery time.Duration
+}
+
+// NewAuthLimiter creates a limiter with the given thresholds.
+// PRE: config limits and Window are positive; now is non-nil
+// POST: Returns a limiter with empty buckets
+func NewAuthLimiter(config AuthLimitConfig, now func() time.Time) *AuthLimiter {
+	return &AuthLimiter{
+		config:  config,
+		now:     now,
+		ips:     make(map[string]*bucket),
+		emails:  make(map[string]*bucket),
+		lastGC:  now(),
+		gcEvery: config.Window,
+	}
+}
+
+// Allow takes one token from the IP bucket and, if email is non-empty, the email bucket.
+// PRE: ip is non-empty
+// POST: Returns true if both buckets had a token; otherwise false and how long until one refills
+func (l *AuthLimiter) Allow(ip, email string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.gc(now)
+
+	ipWait := l.peek(l.ips, ip, l.config.PerIP, now)
+	var emailWait time.Duration
+	if email != "" {
+		emailWait = l.peek(l.emails, email, l.config.PerEmail, now)
+	}
+	if ipWait > 0 || emailWait > 0 {
+		return false, max(ipWait, emailWait)
+	}
+	l.ips[ip].tokens--
+	if email != "" {
+		l.emails[email].tokens--
+	}
+	return true, 0
+}
+
+// ResetEmail refills the bucket for an email address, e.g. after an admin unlock.
+// PRE: none
+// POST: The next attempt for email is not limited by earlier ones
+func (l *AuthLimiter) ResetEmail(email string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.emails, NormalizeEmail(email))
+}
+
+// peek refills key's bucket and returns 0 if a token is available, else the wait for one.
+func (l *AuthLimiter) peek(buckets map[string]*bucket, key string, limit int, now time.Time) time.Duration {
+	b, ok := buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit), last: now}
+		buckets[key] = b
+	}
+	perToken := l.config.Window / time.Duration(limit)
+	b.tokens = math.Min(float64(limit), b.tokens+float64(now.Sub(b.last))/float64(perToken))
+	b.last = now
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) * float64(perToken))
+}
+
+// gc drops buckets that have been idle long enough to be full again.
+func (l *AuthLimiter) gc(now time.Time) {
+	if now.Sub(l.lastGC) < l.gcEvery {
+		return
+	}
+	l.lastGC = now
+	for _, m := range []map[string]*bucket{l.ips, l.emails} {
+		for k, b := range m {
+			if now.Sub(b.last) >= l.config.Window {
+				delete(m, k)
+			}
+		}
+	}
+}
+
+// NormalizeEmail lowercases and trims an email so buckets are shared across spellings.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// TrustedProxies are the peers, such as the local reverse proxy, whose X-Forwarded-For and
+// X-Real-IP headers are believed. Set before NewMux; empty trusts no forwarding headers.
+var TrustedProxies []netip.Prefix
+
+// ClientIP returns the address of the client that sent the request. Behind a trusted proxy
+// it is the nearest untrusted hop in X-Forwarded-For, or X-Real-IP; a header sent by any
+// other peer is ignored so clients cannot choose their own address.
+func ClientIP(r *http.Request) string {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		ip = host
+	}
+	if !isTrustedProxy(ip) {
+		return ip
+	}
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		hops := strings.Split(strings.Join(forwarded, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if _, err := netip.ParseAddr(hop); err != nil {
+				break // a malformed hop: keep the last address a trusted proxy vouched for
+			}
+			ip = hop
+			if !isTrustedProxy(hop) {
+				break
+			}
+		}
+		return ip
+	}
+	if real := strings.TrimSpace(r.Header.Get("X-Real-IP")); real != "" {
+		if _, err := netip.ParseAddr(real); err == nil {
+			return real
+		}
+	}
+	return ip
+}
+
+// isTrustedProxy reports whether ip falls in one of TrustedProxies.
+func isTrustedProxy(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range TrustedProxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthRateLimit returns middleware that limits POSTs to the given credential paths.
+// The email bucket is keyed on the form's Email field when present (the login form).
+func AuthRateLimit(limiter *AuthLimiter, paths ...string) func(http.Handler) http.Handler {
+	limited := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		limited[p] = true
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "POST" || !limited[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ip := ClientIP(r)
+			email := ""
+			if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+				// ParseForm is idempotent, so the handler still sees the fields.
+				if err := r.ParseForm(); err == nil {
+					email = NormalizeEmail(r.PostFormValue("Email"))
+				}
+			}
+			if ok, wait := limiter.Allow(ip, email); !ok {
+				slog.WarnContext(r.Context(), "security_event", "event", "auth_rate_limited", "path", r.URL.Path, "ip", ip, "email", email, "retry_after", wait.Round(time.Second).String())
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				tooManyRequests(w, r, "Too many attempts. Please wait a few minutes and try again.")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
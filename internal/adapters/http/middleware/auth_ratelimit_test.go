@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestAuthLimiter_PerEmailAndRefill verifies the email bucket limits across IPs and refills over time.
+func TestAuthLimiter_PerEmailAndRefill(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	l := NewAuthLimiter(AuthLimitConfig{PerIP: 100, PerEmail: 3, Window: 3 * time.Minute}, func() time.Time { return now })
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.Allow("10.0.0."+string(rune('1'+i)), "yuki@example.com"); !ok {
+			t.Fatalf("attempt %d should be allowed", i+1)
+		}
+	}
+	ok, wait := l.Allow("10.0.0.9", "yuki@example.com")
+	if ok || wait != time.Minute {
+		t.Errorf("4th attempt: ok=%v wait=%v, want blocked for 1m", ok, wait)
+	}
+	if ok, _ := l.Allow("10.0.0.9", "other@example.com"); !ok {
+		t.Error("other emails must not share the bucket")
+	}
+
+	now = now.Add(time.Minute)
+	if ok, _ := l.Allow("10.0.0.9", "yuki@example.com"); !ok {
+		t.Error("expected one token to refill after a minute")
+	}
+
+	l.Allow("10.0.0.9", "yuki@example.com")
+	l.ResetEmail("Yuki@Example.com ")
+	if ok, _ := l.Allow("10.0.0.9", "yuki@example.com"); !ok {
+		t.Error("expected ResetEmail to lift the limit")
+	}
+}
+
+// TestAuthRateLimit_Middleware verifies only POSTs to listed paths are limited and 429 carries Retry-After.
+func TestAuthRateLimit_Middleware(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	l := NewAuthLimiter(AuthLimitConfig{PerIP: 1, PerEmail: 5, Window: time.Minute}, func() time.Time { return now })
+	h := AuthRateLimit(l, "/login")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	post := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, strings.NewReader(url.Values{"Email": {"a@b.c"}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.RemoteAddr = "192.0.2.1:5555"
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := post("/login"); rec.Code != http.StatusOK {
+		t.Fatalf("first login: expected 200, got %d", rec.Code)
+	}
+	rec := post("/login")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "60" {
+		t.Errorf("second login: got %d Retry-After=%q, want 429 and 60", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	if rec := post("/api/members"); rec.Code != http.StatusOK {
+		t.Errorf("unlisted path should not be limited, got %d", rec.Code)
+	}
+}
+
+// TestAuthRateLimit_BehindProxy verifies clients behind a trusted proxy get their own IP
+// bucket, and forwarding headers from any other peer are ignored.
+func TestAuthRateLimit_BehindProxy(t *testing.T) {
+	TrustedProxies = []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32"), netip.MustParsePrefix("::1/128")}
+	defer func() { TrustedProxies = nil }()
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	l := NewAuthLimiter(AuthLimitConfig{PerIP: 1, PerEmail: 5, Window: time.Minute}, func() time.Time { return now })
+	h := AuthRateLimit(l, "/login")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	post := func(remote string, header http.Header) int {
+		req := httptest.NewRequest("POST", "/login", nil)
+		req.RemoteAddr = remote
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := post("127.0.0.1:40000", http.Header{"X-Forwarded-For": {"198.51.100.7"}}); code != http.StatusOK {
+		t.Fatalf("first client: expected 200, got %d", code)
+	}
+	if code := post("127.0.0.1:40001", http.Header{"X-Forwarded-For": {"203.0.113.9, 127.0.0.1"}}); code != http.StatusOK {
+		t.Errorf("second client through the same proxy: expected 200, got %d", code)
+	}
+	if code := post("[::1]:40002", http.Header{"X-Real-Ip": {"192.0.2.44"}}); code != http.StatusOK {
+		t.Errorf("third client via X-Real-IP: expected 200, got %d", code)
+	}
+	if code := post("127.0.0.1:40003", http.Header{"X-Forwarded-For": {"198.51.100.7"}}); code != http.StatusTooManyRequests {
+		t.Errorf("first client again: expected 429, got %d", code)
+	}
+
+	// An untrusted peer cannot pick a fresh bucket by forging the header.
+	if code := post("192.0.2.200:5555", http.Header{"X-Forwarded-For": {"10.9.9.1"}}); code != http.StatusOK {
+		t.Fatalf("direct client: expected 200, got %d", code)
+	}
+	if code := post("192.0.2.200:5556", http.Header{"X-Forwarded-For": {"10.9.9.2"}}); code != http.StatusTooManyRequests {
+		t.Errorf("direct client with a forged header: expected 429, got %d", code)
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"workshop/internal/adapters/http/i18n"
+)
+
+// Locale returns middleware that picks the language for each request: the signed-in account's
+// chosen locale, otherwise the best match for the browser's Accept-Language, otherwise the
+// default. The locale is carried in the request context for templates and echoed in the
+// Content-Language header, which API error responses translate their message into.
+// Must run inside Auth so the session is available.
+func Locale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := ""
+		if sess, ok := GetSessionFromContext(r.Context()); ok {
+			locale = i18n.Match(sess.Locale)
+		}
+		if locale == "" {
+			locale = i18n.Negotiate(r.Header.Get("Accept-Language"))
+		}
+		w.Header().Set("Content-Language", locale)
+		next.ServeHTTP(w, r.WithContext(i18n.WithLocale(r.Context(), locale)))
+	})
+}
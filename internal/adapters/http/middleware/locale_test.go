@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/adapters/http/i18n"
+)
+
+// TestLocale verifies the account's chosen language wins over the browser's, which wins over
+// the default, and that the choice reaches both the context and Content-Language.
+func TestLocale(t *testing.T) {
+	var seen string
+	handler := Locale(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = i18n.FromContext(r.Context())
+	}))
+
+	tests := []struct {
+		name    string
+		session *Session
+		accept  string
+		want    string
+	}{
+		{"default", nil, "", "en-NZ"},
+		{"browser", nil, "mi-NZ,en;q=0.8", "mi"},
+		{"account beats browser", &Session{Locale: "en-NZ"}, "mi", "en-NZ"},
+		{"account without choice follows browser", &Session{}, "mi", "mi"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/dashboard", nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept-Language", tt.accept)
+		}
+		if tt.session != nil {
+			req = req.WithContext(context.WithValue(req.Context(), accountContextKey, *tt.session))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if seen != tt.want || rec.Header().Get("Content-Language") != tt.want {
+			t.Errorf("%s: context %q, header %q, want %q", tt.name, seen, rec.Header().Get("Content-Language"), tt.want)
+		}
+	}
+}
@@ -3,10 +3,14 @@ package middleware
 import (
 	"log/slog"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
+	"workshop/internal/adapters/http/apierror"
+
 	"github.com/gorilla/csrf"
 )
 
@@ -82,7 +86,7 @@ func RateLimit(limiter *RateLimiter) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ip := r.RemoteAddr
 			if !limiter.Allow(ip) {
-				http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+				tooManyRequests(w, r, "Too Many Requests")
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -90,41 +94,42 @@ func RateLimit(limiter *RateLimiter) func(http.Handler) http.Handler {
 	}
 }
 
-// SecurityHeaders adds OWASP recommended headers.
-func SecurityHeaders(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Content Security Policy (Basic)
-		// Allow scripts/styles from 'self' and inline (for now, scaffold simplicity)
-		// In production, use nonces/hashes.
-		w.Header().Set("Content-Security-Policy", "default-src 'self'; style-src 'self' 'unsafe-inline' https://fonts.googleapis.com; font-src https://fonts.gstatic.com; script-src 'self' 'unsafe-inline'; img-src 'self' https://img.youtube.com; frame-src https://www.youtube.com; connect-src 'self'")
-		w.Header().Set("X-Frame-Options", "DENY")
-		w.Header().Set("X-Content-Type-Options", "nosniff")
-		w.Header().Set("Referrer-Policy", "strict-origin-when-cross-origin")
-		next.ServeHTTP(w, r)
-	})
+// tooManyRequests writes a 429, as a structured error for /api/ paths and plain text elsewhere.
+func tooManyRequests(w http.ResponseWriter, r *http.Request, message string) {
+	if strings.HasPrefix(r.URL.Path, "/api/") {
+		apierror.RateLimited(w, message)
+		return
+	}
+	http.Error(w, message, http.StatusTooManyRequests)
 }
 
 // ExtraTrustedOrigins allows tests to add origins (e.g. "127.0.0.1:12345")
 // before CSRF() is called. Not for production use.
 var ExtraTrustedOrigins []string
 
-// CSRFMiddleware returns a handler that protects against CSRF attacks.
+// CSRF returns a handler that protects against CSRF attacks.
 // It assumes an encryption key is passed (32 bytes).
-// JSON API requests (Content-Type: application/json) are exempted from CSRF.
+// JSON API requests (Content-Type: application/json) are exempt from the token check: a page on
+// another site cannot send them without a CORS preflight, which is never granted. Writes whose
+// Origin names another site are refused anyway, in case a browser gets that wrong.
 func CSRF(authKey []byte) func(http.Handler) http.Handler {
 	origins := []string{"localhost:8080", "127.0.0.1:8080"}
 	origins = append(origins, ExtraTrustedOrigins...)
 	csrfProtect := csrf.Protect(
 		authKey,
-		csrf.Secure(false), // Allow HTTP for local development
+		csrf.Secure(SecureCookies), // HTTP is allowed for local development
 		csrf.Path("/"),
 		csrf.TrustedOrigins(origins),
 	)
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Exempt JSON API requests from CSRF protection
 			if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+				if isWrite(r.Method) && crossSiteOrigin(r, origins) {
+					slog.WarnContext(r.Context(), "csrf_denied", "path", r.URL.Path, "origin", r.Header.Get("Origin"))
+					apierror.Forbidden(w, "cross-site request refused")
+					return
+				}
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -134,6 +139,26 @@ func CSRF(authKey []byte) func(http.Handler) http.Handler {
 	}
 }
 
+// isWrite reports whether method can change state.
+func isWrite(method string) bool {
+	return method != http.MethodGet && method != http.MethodHead && method != http.MethodOptions
+}
+
+// crossSiteOrigin reports whether the request's Origin header names a host other than the one
+// it was sent to or a trusted origin. Requests without an Origin, such as from scripts and
+// webhooks, are not browser page requests and pass.
+func crossSiteOrigin(r *http.Request, trusted []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return false
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return true
+	}
+	return u.Host != r.Host && !slices.Contains(trusted, u.Host)
+}
+
 // Chain applies middlewares in order (outer to inner).
 func Chain(h http.Handler, middlewares ...func(http.Handler) http.Handler) http.Handler {
 	for _, m := range middlewares {
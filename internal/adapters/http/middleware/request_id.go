@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	"workshop/internal/application/trace"
+)
+
+// HeaderRequestID is the header a request ID is read from and echoed back in.
+const HeaderRequestID = "X-Request-ID"
+
+// RequestID returns middleware that gives every request an ID. A well-formed X-Request-ID from
+// the client (e.g. a proxy) is kept; otherwise a new one is generated. The ID is set on the
+// response header and carried in the request context for logs, perf entries and errors.
+// Must be the outermost middleware so everything downstream sees the ID.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(HeaderRequestID)
+		if !trace.ValidRequestID(id) {
+			id = trace.NewRequestID()
+		}
+		w.Header().Set(HeaderRequestID, id)
+		next.ServeHTTP(w, r.WithContext(trace.WithRequestID(r.Context(), id)))
+	})
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"workshop/internal/application/trace"
+)
+
+// TestRequestID verifies each request gets an ID in its context and response header, and that a
+// well-formed client ID is kept while a malformed one is replaced.
+func TestRequestID(t *testing.T) {
+	var seen string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = trace.RequestID(r.Context())
+	}))
+
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{"generated", "", false},
+		{"from proxy", "edge-42.abc", true},
+		{"malformed", "bad id<script>", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/api/members", nil)
+		if tt.header != "" {
+			req.Header.Set(HeaderRequestID, tt.header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		got := rec.Header().Get(HeaderRequestID)
+		if got == "" || got != seen {
+			t.Errorf("%s: header %q, context %q", tt.name, got, seen)
+		}
+		if (got == tt.header) != tt.keep {
+			t.Errorf("%s: header %q, keep = %v", tt.name, got, tt.keep)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// contentSecurityPolicy is the CSP every page gets, before frame-ancestors is added.
+// Inline scripts and styles are still allowed: the templates use them throughout.
+const contentSecurityPolicy = "default-src 'self'; base-uri 'self'; form-action 'self'; object-src 'none'; " +
+	"style-src 'self' 'unsafe-inline' https://fonts.googleapis.com; font-src https://fonts.gstatic.com; " +
+	"script-src 'self' 'unsafe-inline'; img-src 'self' https://img.youtube.com; frame-src https://www.youtube.com; connect-src 'self'"
+
+// permissionsPolicy turns off browser features the app does not use. The camera stays
+// available to the site itself for the kiosk's QR scanner.
+const permissionsPolicy = "camera=(self), microphone=(), geolocation=(), payment=(), usb=()"
+
+// SecurityPolicy configures the headers set by SecurityHeaders.
+type SecurityPolicy struct {
+	HSTSMaxAge time.Duration // 0 leaves Strict-Transport-Security off, as over plain HTTP in development
+	Frames     []FrameRule   // pages other sites may embed; every other page refuses to be framed
+}
+
+// FrameRule lets the pages under a path be shown in a frame, such as the TV board on a
+// signage player.
+type FrameRule struct {
+	Path    string   // exact path, or every path under it when it ends in "/"
+	Sources []string // CSP frame-ancestors sources, e.g. "'self'" or "https://signage.example.com"
+}
+
+// Matches reports whether the rule covers path.
+// PRE: none
+// POST: Returns true for the exact path, or any path under a Path ending in "/"
+func (f FrameRule) Matches(path string) bool {
+	if strings.HasSuffix(f.Path, "/") {
+		return strings.HasPrefix(path, f.Path)
+	}
+	return path == f.Path
+}
+
+// Headers returns the security headers a response for path gets.
+// PRE: none
+// POST: Returns CSP, framing, sniffing, referrer and permissions headers, plus HSTS when enabled
+func (p SecurityPolicy) Headers(path string) http.Header {
+	ancestors := []string{"'none'"}
+	for _, rule := range p.Frames {
+		if rule.Matches(path) && len(rule.Sources) > 0 {
+			ancestors = rule.Sources
+			break
+		}
+	}
+
+	h := http.Header{}
+	h.Set("Content-Security-Policy", contentSecurityPolicy+"; frame-ancestors "+strings.Join(ancestors, " "))
+	// X-Frame-Options is for browsers without frame-ancestors; it cannot name other sites.
+	switch {
+	case ancestors[0] == "'none'":
+		h.Set("X-Frame-Options", "DENY")
+	case len(ancestors) == 1 && ancestors[0] == "'self'":
+		h.Set("X-Frame-Options", "SAMEORIGIN")
+	}
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Set("Referrer-Policy", "strict-origin-when-cross-origin")
+	h.Set("Permissions-Policy", permissionsPolicy)
+	h.Set("Cross-Origin-Opener-Policy", "same-origin")
+	if p.HSTSMaxAge > 0 {
+		h.Set("Strict-Transport-Security", fmt.Sprintf("max-age=%d; includeSubDomains", int(p.HSTSMaxAge.Seconds())))
+	}
+	return h
+}
+
+// SecurityHeaders returns middleware that adds the policy's OWASP recommended headers.
+// Handlers may still replace them, as the signed waiver view does with a stricter CSP.
+func SecurityHeaders(policy SecurityPolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for key, values := range policy.Headers(r.URL.Path) {
+				w.Header()[key] = values
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSecurityHeaders_FrameOverrides verifies pages refuse framing unless a rule lets them be
+// embedded, and HSTS is only sent when enabled.
+func TestSecurityHeaders_FrameOverrides(t *testing.T) {
+	policy := SecurityPolicy{
+		HSTSMaxAge: 730 * 24 * time.Hour,
+		Frames: []FrameRule{
+			{Path: "/kiosk", Sources: []string{"'self'"}},
+			{Path: "/kiosk/", Sources: []string{"'self'", "https://signage.example.com"}},
+		},
+	}
+	handler := SecurityHeaders(policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	get := func(path string) http.Header {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec.Header()
+	}
+
+	tests := []struct {
+		path, ancestors, frameOptions string
+	}{
+		{"/dashboard", "frame-ancestors 'none'", "DENY"},
+		{"/kiosk", "frame-ancestors 'self'", "SAMEORIGIN"},
+		{"/kiosk/board", "frame-ancestors 'self' https://signage.example.com", ""},
+		{"/kioskish", "frame-ancestors 'none'", "DENY"},
+	}
+	for _, tt := range tests {
+		h := get(tt.path)
+		if csp := h.Get("Content-Security-Policy"); !strings.HasSuffix(csp, tt.ancestors) || !strings.Contains(csp, "object-src 'none'") {
+			t.Errorf("%s CSP = %q, want it to end with %q", tt.path, csp, tt.ancestors)
+		}
+		if got := h.Get("X-Frame-Options"); got != tt.frameOptions {
+			t.Errorf("%s X-Frame-Options = %q, want %q", tt.path, got, tt.frameOptions)
+		}
+		if h.Get("X-Content-Type-Options") != "nosniff" || h.Get("Referrer-Policy") == "" {
+			t.Errorf("%s missing nosniff or referrer policy: %v", tt.path, h)
+		}
+	}
+	if got := get("/dashboard").Get("Strict-Transport-Security"); got != "max-age=63072000; includeSubDomains" {
+		t.Errorf("HSTS = %q", got)
+	}
+	if got := (SecurityPolicy{}).Headers("/dashboard").Get("Strict-Transport-Security"); got != "" {
+		t.Errorf("HSTS without a max age = %q, want none", got)
+	}
+}
+
+// TestCSRF_RefusesCrossSiteJSON verifies JSON writes from another site's page are refused
+// while same-site pages, scripts without an Origin and reads still pass.
+func TestCSRF_RefusesCrossSiteJSON(t *testing.T) {
+	handler := CSRF(make([]byte, 32))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	tests := []struct {
+		name, method, origin string
+		want                 int
+	}{
+		{"same site", "POST", "https://gym.example.com", http.StatusNoContent},
+		{"no origin", "POST", "", http.StatusNoContent},
+		{"other site", "POST", "https://evil.example.com", http.StatusForbidden},
+		{"opaque origin", "DELETE", "null", http.StatusForbidden},
+		{"other site read", "GET", "https://evil.example.com", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "https://gym.example.com/api/members", strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		if tt.origin != "" {
+			req.Header.Set("Origin", tt.origin)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, rec.Code, tt.want)
+		}
+	}
+}
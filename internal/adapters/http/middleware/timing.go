@@ -3,45 +3,47 @@ package middleware
 import (
 	"log/slog"
 	"net/http"
-	"os"
-	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"workshop/internal/adapters/http/perf"
+	"workshop/internal/application/trace"
 )
 
 // DefaultSlowRequestMs is the default threshold for slow request warnings.
 const DefaultSlowRequestMs = 200
 
-// slowRequestMs is the cached threshold (read via atomic after first load).
-var slowRequestMs int64
+// slowRequestMs is the slow-request threshold; Timing reads it when the middleware is built.
+var slowRequestMs int64 = DefaultSlowRequestMs
 
-var slowRequestOnce sync.Once
+// SetSlowRequestThreshold changes the slow-request warning threshold.
+// Call before building the Timing middleware.
+func SetSlowRequestThreshold(d time.Duration) {
+	atomic.StoreInt64(&slowRequestMs, d.Milliseconds())
+}
 
 // getSlowRequestThreshold returns the slow-request threshold in milliseconds.
 func getSlowRequestThreshold() float64 {
-	slowRequestOnce.Do(func() {
-		ms := DefaultSlowRequestMs
-		if v := os.Getenv("WORKSHOP_SLOW_REQUEST_MS"); v != "" {
-			if n, err := strconv.Atoi(v); err == nil && n > 0 {
-				ms = n
-			}
-		}
-		atomic.StoreInt64(&slowRequestMs, int64(ms))
-	})
 	return float64(atomic.LoadInt64(&slowRequestMs))
 }
 
-// requestIDCounter is an atomic counter for request IDs.
-var requestIDCounter uint64
+// unmatchedRoute labels requests that never reached the mux (e.g. redirected or rate limited).
+const unmatchedRoute = "unmatched"
 
-// statusWriter wraps http.ResponseWriter to capture the status code.
+// statusWriter wraps http.ResponseWriter to capture the status code and matched route.
 type statusWriter struct {
 	http.ResponseWriter
 	status int
+	route  string // set by CaptureRoute once the mux has matched a pattern
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+// PRE: none
+// POST: returns the wrapped writer
+func (sw *statusWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
 }
 
 // WriteHeader captures the status code and delegates to the underlying ResponseWriter.
@@ -77,25 +79,24 @@ func Timing(collector *perf.Collector) func(http.Handler) http.Handler {
 			}
 
 			start := time.Now()
-			reqID := atomic.AddUint64(&requestIDCounter, 1)
+			reqID := trace.RequestID(r.Context())
 
 			sw := statusWriterPool.Get().(*statusWriter)
 			sw.ResponseWriter = w
 			sw.status = http.StatusOK
+			sw.route = ""
 			defer func() {
 				durationMs := float64(time.Since(start).Microseconds()) / 1000.0
 
 				if durationMs >= threshold {
-					slog.Warn("slow_request",
-						"request_id", reqID,
+					slog.WarnContext(r.Context(), "slow_request",
 						"method", r.Method,
 						"path", path,
 						"status", sw.status,
 						"duration_ms", durationMs,
 					)
 				} else {
-					slog.Debug("request",
-						"request_id", reqID,
+					slog.DebugContext(r.Context(), "request",
 						"method", r.Method,
 						"path", path,
 						"status", sw.status,
@@ -104,9 +105,16 @@ func Timing(collector *perf.Collector) func(http.Handler) http.Handler {
 				}
 
 				if collector != nil {
+					route := sw.route
+					if route == "" {
+						route = unmatchedRoute
+					}
 					collector.Record(perf.Entry{
 						Kind:       perf.KindRequest,
 						Path:       r.Method + " " + path,
+						Method:     r.Method,
+						Route:      route,
+						RequestID:  reqID,
 						StatusCode: sw.status,
 						DurationMs: durationMs,
 						Timestamp:  start,
@@ -121,3 +129,23 @@ func Timing(collector *perf.Collector) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// CaptureRoute wraps the mux so Timing can label requests by route pattern rather than raw path.
+// Raw paths are unbounded (anyone can request /anything); patterns are fixed at startup.
+// Must be the innermost handler so r is the request the mux matched.
+func CaptureRoute(mux http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r)
+		for {
+			if sw, ok := w.(*statusWriter); ok {
+				sw.route = r.Pattern
+				return
+			}
+			u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+			if !ok {
+				return
+			}
+			w = u.Unwrap()
+		}
+	})
+}
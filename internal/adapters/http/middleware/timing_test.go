@@ -44,6 +44,26 @@ func TestTimingMiddleware_SkipsStatic(t *testing.T) {
 	}
 }
 
+// TestTimingMiddleware_LabelsByRoutePattern verifies histograms use the mux pattern, not the raw path.
+func TestTimingMiddleware_LabelsByRoutePattern(t *testing.T) {
+	collector := perf.NewCollector(100)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/members/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	handler := Chain(CaptureRoute(mux), SecurityHeaders(SecurityPolicy{}), Timing(collector))
+
+	for _, path := range []string{"/api/members/1", "/api/members/2", "/nowhere"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	got := map[string]uint64{}
+	for _, s := range collector.RequestHistograms() {
+		got[s.Route] += s.Count
+	}
+	if got["/api/members/{id}"] != 2 || got[unmatchedRoute] != 1 || len(got) != 2 {
+		t.Errorf("unexpected route counts: %v", got)
+	}
+}
+
 // TestTimingMiddleware_CapturesStatusCode verifies the status code is captured.
 func TestTimingMiddleware_CapturesStatusCode(t *testing.T) {
 	collector := perf.NewCollector(100)
@@ -254,3 +274,17 @@ func BenchmarkTimingMiddleware_Parallel(b *testing.B) {
 		}
 	})
 }
+
+// TestTimingMiddleware_RecordsRequestID verifies the perf entry carries the ID sent back in X-Request-ID.
+func TestTimingMiddleware_RecordsRequestID(t *testing.T) {
+	collector := perf.NewCollector(100)
+	handler := Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), Timing(collector), RequestID)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/test", nil))
+
+	id := rr.Header().Get(HeaderRequestID)
+	if trace := collector.Trace(id); id == "" || len(trace) != 1 || trace[0].Path != "GET /api/test" {
+		t.Errorf("Trace(%q) = %+v", id, trace)
+	}
+}
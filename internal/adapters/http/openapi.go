@@ -0,0 +1,499 @@
+package web
+
+//go:generate go run ../../../tools/openapigen -out ../../../openapi.json
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	availabilityDomain "workshop/internal/domain/availability"
+
+	"workshop/internal/adapters/http/openapi"
+	"workshop/internal/application/orchestrators"
+	"workshop/internal/application/projections"
+	"workshop/internal/domain/attendance"
+	bugboxDomain "workshop/internal/domain/bugbox"
+	calendarDomain "workshop/internal/domain/calendar"
+	classPackDomain "workshop/internal/domain/classpack"
+	classTypeDomain "workshop/internal/domain/classtype"
+	clipDomain "workshop/internal/domain/clip"
+	emailDomain "workshop/internal/domain/email"
+	estimatedHoursDomain "workshop/internal/domain/estimatedhours"
+	gradingDomain "workshop/internal/domain/grading"
+	holidayDomain "workshop/internal/domain/holiday"
+	injuryDomain "workshop/internal/domain/injury"
+	inventoryDomain "workshop/internal/domain/inventory"
+	kioskDomain "workshop/internal/domain/kiosk"
+	locationDomain "workshop/internal/domain/location"
+	membertagDomain "workshop/internal/domain/membertag"
+	messageDomain "workshop/internal/domain/message"
+	milestoneDomain "workshop/internal/domain/milestone"
+	noticeDomain "workshop/internal/domain/notice"
+	notificationDomain "workshop/internal/domain/notification"
+	observationDomain "workshop/internal/domain/observation"
+	permissionDomain "workshop/internal/domain/permission"
+	personalGoalDomain "workshop/internal/domain/personalgoal"
+	programDomain "workshop/internal/domain/program"
+	quickCaptureDomain "workshop/internal/domain/quickcapture"
+	reengagementDomain "workshop/internal/domain/reengagement"
+	referralDomain "workshop/internal/domain/referral"
+	rotorDomain "workshop/internal/domain/rotor"
+	rubricDomain "workshop/internal/domain/rubric"
+	scheduleDomain "workshop/internal/domain/schedule"
+	sessionLogDomain "workshop/internal/domain/sessionlog"
+	statusChangeDomain "workshop/internal/domain/statuschange"
+	termDomain "workshop/internal/domain/term"
+	themeDomain "workshop/internal/domain/theme"
+	timesheetDomain "workshop/internal/domain/timesheet"
+	trainingGoalDomain "workshop/internal/domain/traininggoal"
+	visitorDomain "workshop/internal/domain/visitor"
+)
+
+// jsonObject documents a response whose fields vary; see the handler for its keys.
+type jsonObject = map[string]any
+
+// Common query parameters.
+var (
+	queryID       = openapi.Param{Name: "id", Required: true}
+	queryMemberID = openapi.Param{Name: "member_id", Description: "defaults to the caller's own member record"}
+)
+
+// apiOperations documents every /api route. routes_test.go fails when a registered
+// /api route is missing here, and openapi_test.go fails when openapi.json is stale;
+// run `go generate ./internal/adapters/http` after changing this list.
+var apiOperations = []openapi.Operation{
+	// Auth
+	{Method: "POST", Path: "/api/activate", Tag: "Auth", Summary: "Activate an account and set its password", Request: activateAccountRequest{}, Response: map[string]string{}},
+	{Method: "POST", Path: "/api/unsubscribe", Tag: "Email", Summary: "Save email categories from an unsubscribe link (token, no session)", Request: unsubscribeRequest{}, Response: emailDomain.Preferences{}},
+	{Method: "POST", Path: "/api/admin/resend-activation", Tag: "Auth", Summary: "Email a fresh activation link (admin)", Request: accountIDRequest{}, Response: map[string]string{}},
+	{Method: "POST", Path: "/api/devmode/impersonate", Tag: "Auth", Summary: "View the app as another role (admin); redirects to the dashboard", RequestType: "application/x-www-form-urlencoded", Status: http.StatusSeeOther},
+	{Method: "POST", Path: "/api/devmode/restore", Tag: "Auth", Summary: "Stop impersonating; redirects to the dashboard", Status: http.StatusSeeOther},
+	{Method: "GET", Path: "/api/session/location", Tag: "Auth", Summary: "Get the location selected for this session", Response: map[string]string{}},
+	{Method: "POST", Path: "/api/session/location", Tag: "Auth", Summary: "Select a location for this session", Request: sessionLocationRequest{}, Response: map[string]string{}},
+	{Method: "GET", Path: "/api/nav", Tag: "Auth", Summary: "The navigation menu for this session, by role, feature flags, permissions, kiosk mode and impersonation", Response: projections.Navigation{}},
+	{Method: "GET", Path: "/api/account/locale", Tag: "Auth", Summary: "Get the caller's language and the supported locales", Response: accountLocaleResponse{}},
+	{Method: "PUT", Path: "/api/account/locale", Tag: "Auth", Summary: "Choose the language for the caller's pages and messages (empty follows the browser)", Request: accountLocaleRequest{}, Response: accountLocaleResponse{}},
+	{Method: "POST", Path: "/api/account/email", Tag: "Auth", Summary: "Confirm the password and email a link that moves the caller's account to a new address", Request: accountEmailRequest{}, Response: map[string]string{}, Status: http.StatusAccepted},
+	{Method: "POST", Path: "/api/account/email/confirm", Tag: "Auth", Summary: "Confirm an email change from its link (token, no session); signs the account out everywhere", Request: confirmEmailRequest{}, Response: map[string]string{}},
+
+	// Members
+	{Method: "GET", Path: "/api/members/search", Tag: "Members", Summary: "Search members by name; returns cards with belt, stripes and initials, plus an active-injury flag for staff", Query: []openapi.Param{{Name: "q", Required: true}}, Response: []projections.MemberCard{}},
+	{Method: "GET", Path: "/api/members/export", Tag: "Members", Summary: "Download the member list as CSV or XLSX", Query: []openapi.Param{{Name: "format", Description: "csv (default) or xlsx"}, {Name: "tag", Description: "only members carrying this tag"}, {Name: "segment", Description: "only members of this saved segment"}}, ResponseType: "text/csv"},
+	{Method: "POST", Path: "/api/members/import", Tag: "Members", Summary: "Import members from a CSV upload", Query: []openapi.Param{{Name: "dry_run", Description: "true to validate without saving"}, {Name: "update_mode", Description: "how to treat rows matching existing members"}}, RequestType: "multipart/form-data", Response: importCSVResult{}},
+	{Method: "POST", Path: "/api/members/archive", Tag: "Members", Summary: "Archive a member", Request: orchestrators.ArchiveMemberInput{}},
+	{Method: "POST", Path: "/api/members/restore", Tag: "Members", Summary: "Restore an archived member", Request: orchestrators.RestoreMemberInput{}},
+	{Method: "GET", Path: "/api/members/duplicates", Tag: "Members", Summary: "Pairs of members that look like the same person, with a suggested survivor (admin)", Response: []projections.DuplicateMemberPair{}},
+	{Method: "POST", Path: "/api/members/merge", Tag: "Members", Summary: "Merge a duplicate member into the survivor, or report what would move with DryRun (admin)", Request: memberMergeRequest{}, Response: orchestrators.MergeMembersResult{}},
+	{Method: "GET", Path: "/api/members/progression", Tag: "Members", Summary: "Belt and stripe progression for a member", Query: []openapi.Param{queryMemberID}, Response: projections.MemberProgressionResult{}},
+	{Method: "GET", Path: "/api/members/inactive", Tag: "Members", Summary: "Members who have not trained recently", Query: []openapi.Param{{Name: "days", Description: "inactivity threshold in days"}, {Name: "tag", Description: "only members carrying this tag"}, {Name: "segment", Description: "only members of this saved segment"}}, Response: []projections.InactiveMemberResult{}},
+	{Method: "GET", Path: "/api/member-tags", Tag: "Members", Summary: "A member's tags (admin)", Query: []openapi.Param{{Name: "member_id", Required: true}}, Response: []string{}},
+	{Method: "POST", Path: "/api/member-tags", Tag: "Members", Summary: "Tag a member, creating the tag on first use (admin)", Request: memberTagRequest{}, Response: jsonObject{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/member-tags", Tag: "Members", Summary: "Take a tag off a member (admin)", Query: []openapi.Param{{Name: "member_id", Required: true}, {Name: "tag", Required: true}}},
+	{Method: "GET", Path: "/api/member-tags/catalog", Tag: "Members", Summary: "Every tag in use with its member count (admin)", Response: []membertagDomain.TagCount{}},
+	{Method: "PUT", Path: "/api/member-tags/catalog", Tag: "Members", Summary: "Rename or merge a tag across members and segments (admin)", Request: renameTagRequest{}, Response: jsonObject{}},
+	{Method: "DELETE", Path: "/api/member-tags/catalog", Tag: "Members", Summary: "Remove a tag from every member (admin)", Query: []openapi.Param{{Name: "tag", Required: true}}},
+	{Method: "GET", Path: "/api/member-segments", Tag: "Members", Summary: "Saved smart segments (admin)", Response: []membertagDomain.Segment{}},
+	{Method: "POST", Path: "/api/member-segments", Tag: "Members", Summary: "Create a segment, or edit it when ID is given (admin)", Request: memberSegmentRequest{}, Response: membertagDomain.Segment{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/member-segments", Tag: "Members", Summary: "Delete a saved segment (admin)", Query: []openapi.Param{queryID}},
+	{Method: "GET", Path: "/api/member-segments/members", Tag: "Members", Summary: "Members a segment matches right now (admin)", Query: []openapi.Param{queryID}, Response: []memberResult{}},
+	{Method: "GET", Path: "/api/reengagement/rules", Tag: "Members", Summary: "Re-engagement rules, lowest threshold first (admin)", Response: []reengagementDomain.Rule{}},
+	{Method: "POST", Path: "/api/reengagement/rules", Tag: "Members", Summary: "Create or update a re-engagement rule (admin)", Request: reengagementRuleRequest{}, Response: reengagementDomain.Rule{}},
+	{Method: "DELETE", Path: "/api/reengagement/rules", Tag: "Members", Summary: "Delete a re-engagement rule, keeping its history (admin)", Query: []openapi.Param{queryID}},
+	{Method: "GET", Path: "/api/reengagement/actions", Tag: "Members", Summary: "Re-engagement emails and coach calls, most recent first", Query: []openapi.Param{{Name: "member_id", Description: "one member's history"}, {Name: "outcome", Description: "sent, skipped, pending, reached, no_answer or leaving"}, {Name: "kind", Description: "email or coach_call"}}, Response: []reengagementActionEntry{}},
+	{Method: "POST", Path: "/api/reengagement/actions/outcome", Tag: "Members", Summary: "Record how a coach call went", Request: reengagementOutcomeRequest{}, Response: reengagementDomain.Action{}},
+	{Method: "GET", Path: "/api/reengagement/suppressions", Tag: "Members", Summary: "Members paused from re-engagement, or one member's pause", Query: []openapi.Param{{Name: "member_id", Description: "returns this member's active pause or null"}}, Response: []reengagementDomain.Suppression{}},
+	{Method: "POST", Path: "/api/reengagement/suppressions", Tag: "Members", Summary: "Pause re-engagement for a member", Request: reengagementSuppressionRequest{}, Response: reengagementDomain.Suppression{}},
+	{Method: "DELETE", Path: "/api/reengagement/suppressions", Tag: "Members", Summary: "Resume re-engagement for a member", Query: []openapi.Param{{Name: "member_id", Required: true}}},
+	{Method: "GET", Path: "/api/members/waivers", Tag: "Members", Summary: "Waivers a member has signed, newest first, with guardian details and whether the stored document is intact", Query: []openapi.Param{queryMemberID}, Response: []waiverView{}},
+	{Method: "GET", Path: "/api/members/checkin-qr", Tag: "Members", Summary: "A member's check-in QR code", Query: []openapi.Param{queryMemberID, {Name: "format", Description: "png (default) or svg"}}, ResponseType: "image/png"},
+	{Method: "POST", Path: "/api/members/checkin-qr/email", Tag: "Members", Summary: "Email a member their check-in QR code", Request: checkInQREmailRequest{}, Response: map[string]string{}},
+
+	// Attendance
+	{Method: "POST", Path: "/api/guest/checkin", Tag: "Attendance", Summary: "Check in a guest; returning guests are recognised by email", Request: orchestrators.GuestCheckInInput{}, Response: orchestrators.GuestCheckInResult{}},
+	{Method: "GET", Path: "/api/visitors", Tag: "Attendance", Summary: "Drop-in visitors, most recent visit first (admin)", Query: []openapi.Param{{Name: "status", Description: "visiting, trial or member"}}, Response: []visitorDomain.Visitor{}},
+	{Method: "POST", Path: "/api/visitors", Tag: "Attendance", Summary: "Set a visitor's home gym and drop-in rate (admin)", Request: visitorUpdateRequest{}, Response: visitorDomain.Visitor{}},
+	{Method: "GET", Path: "/api/visitors/visits", Tag: "Attendance", Summary: "A visitor's visit history (admin)", Query: []openapi.Param{{Name: "visitor_id", Required: true}}, Response: []visitorDomain.Visit{}},
+	{Method: "POST", Path: "/api/visitors/visits", Tag: "Attendance", Summary: "Mark a visit's drop-in fee paid or unpaid (admin)", Request: visitPaidRequest{}, Response: visitorDomain.Visit{}},
+	{Method: "POST", Path: "/api/visitors/convert", Tag: "Attendance", Summary: "Sign a visitor up as a trial or member (admin)", Request: visitorConvertRequest{}, Response: visitorDomain.Visitor{}},
+	{Method: "GET", Path: "/api/visitors/report", Tag: "Attendance", Summary: "Visits, returns, fees and sign-ups over the last 30 days (admin)", Response: projections.VisitorReportResult{}},
+	{Method: "GET", Path: "/api/referrals/leaderboard", Tag: "Members", Summary: "Referrers ranked by people who joined, with the latest referrals (admin)", Response: projections.ReferralLeaderboardResult{}},
+	{Method: "GET", Path: "/api/referrals/settings", Tag: "Members", Summary: "How referrers are rewarded when the person they referred joins (admin)", Response: referralDomain.Settings{}},
+	{Method: "PUT", Path: "/api/referrals/settings", Tag: "Members", Summary: "Choose a thank-you email or credit note for referrers, or turn rewards off (admin)", Request: referralSettingsRequest{}, Response: referralDomain.Settings{}},
+	{Method: "GET", Path: "/api/attendance/member", Tag: "Attendance", Summary: "A member's check-ins today", Query: []openapi.Param{{Name: "member_id", Required: true}}, Response: []attendance.Attendance{}},
+	{Method: "DELETE", Path: "/api/attendance/undo", Tag: "Attendance", Summary: "Undo one of today's check-ins", Request: attendanceIDRequest{}},
+	{Method: "POST", Path: "/api/attendance/checkout", Tag: "Attendance", Summary: "Record a check-out", Request: attendanceIDRequest{}, Response: attendance.Attendance{}},
+	{Method: "POST", Path: "/api/attendance/bulk-sync", Tag: "Attendance", Summary: "Upload check-ins recorded while the kiosk was offline", Request: bulkSyncRequest{}, Response: orchestrators.BulkSyncResult{}},
+	{Method: "POST", Path: "/api/attendance/backfill", Tag: "Attendance", Summary: "Record past attendance for several members and dates", Request: attendanceBackfillRequest{}, Response: orchestrators.BackfillAttendanceResult{}},
+	{Method: "POST", Path: "/api/attendance/import", Tag: "Attendance", Summary: "Import legacy attendance from a CSV upload, matching names to members", Query: []openapi.Param{{Name: "dry_run", Description: "true to preview without saving"}}, RequestType: "multipart/form-data", Response: orchestrators.ImportAttendanceResult{}},
+	{Method: "GET", Path: "/api/attendance/rollcall", Tag: "Attendance", Summary: "A class's regulars and who is already present", Query: []openapi.Param{{Name: "schedule_id", Required: true}, {Name: "date", Description: "YYYY-MM-DD; defaults to today"}}, Response: projections.GetRollCallResult{}},
+	{Method: "POST", Path: "/api/attendance/rollcall", Tag: "Attendance", Summary: "Mark members present or absent for one class session", Request: rollCallRequest{}, Response: orchestrators.RollCallResult{}},
+	{Method: "GET", Path: "/api/attendance/anomalies", Tag: "Attendance", Summary: "Duplicate and overlapping check-ins", Query: []openapi.Param{{Name: "from", Description: "YYYY-MM-DD; defaults to 30 days ago"}, {Name: "to", Description: "YYYY-MM-DD; defaults to today"}}, Response: projections.GetCheckInAnomaliesResult{}},
+	{Method: "POST", Path: "/api/attendance/anomalies/fix", Tag: "Attendance", Summary: "Merge, delete or reassign a flagged check-in", Request: anomalyFixRequest{}, Response: attendance.Attendance{}},
+	{Method: "POST", Path: "/api/attendance/mat-hours/repair", Tag: "Attendance", Summary: "Recompute mat hours from class durations, or list the corrections with DryRun", Request: matHoursRepairRequest{}, Response: orchestrators.RepairMatHoursResult{}},
+	{Method: "GET", Path: "/api/attendance/export", Tag: "Attendance", Summary: "Download check-ins in a date range as CSV or XLSX", Query: []openapi.Param{{Name: "from", Description: "YYYY-MM-DD; defaults to the start of this month"}, {Name: "to", Description: "YYYY-MM-DD; defaults to today; at most 366 days after from"}, {Name: "format", Description: "csv (default) or xlsx"}}, ResponseType: "text/csv"},
+	{Method: "POST", Path: "/api/checkin/qr", Tag: "Attendance", Summary: "Check in by scanning a member's QR code", Request: checkInQRRequest{}, Response: jsonObject{}},
+	{Method: "GET", Path: "/api/classes/today", Tag: "Attendance", Summary: "Today's classes", Response: []projections.TodaysClassResult{}},
+	{Method: "GET", Path: "/api/classes/live", Tag: "Attendance", Summary: "Classes running now with live headcount against mat capacity", Response: []projections.LiveClassResult{}},
+	{Method: "GET", Path: "/api/classes/changes", Tag: "Attendance", Summary: "Cancelled, moved and substituted classes, and one-off sessions", Query: []openapi.Param{{Name: "from", Description: "YYYY-MM-DD; defaults to today"}, {Name: "to", Description: "YYYY-MM-DD; defaults to 60 days out"}}, Response: []classChangeView{}},
+	{Method: "POST", Path: "/api/classes/changes", Tag: "Attendance", Summary: "Cancel, move or substitute one class, or add a one-off session, notifying members", Request: classChangeRequest{}, Response: orchestrators.ChangeClassOccurrenceResult{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/classes/changes", Tag: "Attendance", Summary: "Undo a class change or remove a one-off session", Query: []openapi.Param{queryID}, Response: scheduleDomain.OccurrenceChange{}},
+	{Method: "POST", Path: "/api/kiosk/launch", Tag: "Attendance", Summary: "Lock this device into kiosk mode, registering it if named", Request: kioskLaunchRequest{}, Response: kioskDomain.Session{}},
+	{Method: "POST", Path: "/api/kiosk/exit", Tag: "Attendance", Summary: "Leave kiosk mode with the launching account's password or the device's PIN", Request: orchestrators.ExitKioskInput{}},
+	{Method: "POST", Path: "/api/kiosk/heartbeat", Tag: "Attendance", Summary: "Report that a registered kiosk device is still on", Request: kioskHeartbeatRequest{}},
+	{Method: "GET", Path: "/api/kiosk/board", Tag: "Attendance", Summary: "The display board: current class, check-ins, rotor topics and notices", Response: kioskBoardView{}},
+	{Method: "GET", Path: "/api/kiosk/contact-check", Tag: "Attendance", Summary: "Whether a member has been asked to confirm their email at the kiosk", Query: []openapi.Param{{Name: "member_id", Required: true}}, Response: kioskContactCheckResponse{}},
+	{Method: "POST", Path: "/api/kiosk/contact-check", Tag: "Attendance", Summary: "Confirm or correct a member's email at the kiosk", Request: kioskContactCheckRequest{}, Response: kioskContactCheckResult{}},
+	{Method: "GET", Path: "/api/kiosk/class-pack", Tag: "Attendance", Summary: "Classes a member has left on their class packs and whether to warn them at the kiosk", Query: []openapi.Param{{Name: "member_id", Required: true}}, Response: kioskClassPackResponse{}},
+
+	// Training hours
+	{Method: "GET", Path: "/api/estimated-hours", Tag: "Training Hours", Summary: "A member's estimated training hours", Query: []openapi.Param{{Name: "member_id", Required: true}}, Response: []estimatedHoursDomain.EstimatedHours{}},
+	{Method: "POST", Path: "/api/estimated-hours", Tag: "Training Hours", Summary: "Add an estimated-hours period", Request: estimatedHoursCreateRequest{}, Response: estimatedHoursDomain.EstimatedHours{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/estimated-hours", Tag: "Training Hours", Summary: "Delete an estimated-hours period", Query: []openapi.Param{queryID}},
+	{Method: "GET", Path: "/api/estimated-hours/check-overlap", Tag: "Training Hours", Summary: "Check a period against recorded attendance and estimates", Query: []openapi.Param{{Name: "member_id", Required: true}, {Name: "start_date", Required: true}, {Name: "end_date", Required: true}}, Response: orchestrators.OverlapCheckResult{}},
+	{Method: "GET", Path: "/api/estimated-hours/export", Tag: "Training Hours", Summary: "Download estimated-hours periods as CSV or XLSX", Query: []openapi.Param{{Name: "from", Description: "YYYY-MM-DD; periods ending before are left out"}, {Name: "to", Description: "YYYY-MM-DD; periods starting after are left out"}, {Name: "format", Description: "csv (default) or xlsx"}}, ResponseType: "text/csv"},
+	{Method: "GET", Path: "/api/self-estimates", Tag: "Training Hours", Summary: "Your own self-estimates with their review status and history", Response: []selfEstimateView{}},
+	{Method: "POST", Path: "/api/self-estimates", Tag: "Training Hours", Summary: "Submit your own hours estimate for review", Request: selfEstimateRequest{}, Response: estimatedHoursDomain.EstimatedHours{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/self-estimates/pending", Tag: "Training Hours", Summary: "Self-estimates under review, including those waiting on the member", Response: []pendingEntry{}},
+	{Method: "POST", Path: "/api/self-estimates/review", Tag: "Training Hours", Summary: "Approve, adjust, reject or ask the member about a self-estimate", Request: selfEstimateReviewRequest{}, Response: estimatedHoursDomain.EstimatedHours{}},
+	{Method: "POST", Path: "/api/self-estimates/respond", Tag: "Training Hours", Summary: "Answer a reviewer's question about your self-estimate", Request: selfEstimateRespondRequest{}, Response: estimatedHoursDomain.EstimatedHours{}},
+	{Method: "GET", Path: "/api/self-estimates/history", Tag: "Training Hours", Summary: "A self-estimate's review history", Query: []openapi.Param{queryID}, Response: []estimatedHoursDomain.ReviewEvent{}},
+	{Method: "GET", Path: "/api/training-log", Tag: "Training Hours", Summary: "A member's training log: whole-history totals and one page of entries, newest first", Query: []openapi.Param{queryMemberID, {Name: "from", Description: "YYYY-MM-DD; entries on or after"}, {Name: "to", Description: "YYYY-MM-DD; entries on or before"}, {Name: "limit", Description: "entries per page, up to 500; defaults to 50"}, {Name: "offset", Description: "entries to skip"}}, Response: projections.TrainingLogResult{}},
+	{Method: "GET", Path: "/api/training-volume", Tag: "Training Hours", Summary: "A member's training volume over time", Query: []openapi.Param{queryMemberID, {Name: "range"}, {Name: "compare"}}, Response: projections.GetTrainingVolumeResult{}},
+	{Method: "GET", Path: "/api/me/week", Tag: "Training Hours", Summary: "Your likely classes over the next seven days, from the classes you have been attending, with attire, mat, coach and current topic", Response: projections.MyWeekResult{}},
+
+	// Notices
+	{Method: "GET", Path: "/api/notices", Tag: "Notices", Summary: "List notices", Query: []openapi.Param{{Name: "type"}}, Response: []noticeDomain.Notice{}},
+	{Method: "POST", Path: "/api/notices", Tag: "Notices", Summary: "Create a draft notice (admin)", Request: noticeCreateRequest{}, Response: noticeDomain.Notice{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/notices/publish", Tag: "Notices", Summary: "Publish a draft notice", Request: noticeIDRequest{}, Response: noticeDomain.Notice{}},
+	{Method: "POST", Path: "/api/notices/edit", Tag: "Notices", Summary: "Edit a notice", Request: noticeEditRequest{}, Response: noticeDomain.Notice{}},
+	{Method: "POST", Path: "/api/notices/pin", Tag: "Notices", Summary: "Pin or unpin a notice", Request: noticePinRequest{}, Response: noticeDomain.Notice{}},
+	{Method: "POST", Path: "/api/notices/images", Tag: "Notices", Summary: "Upload an image for a notice body (admin)", RequestType: "multipart/form-data", Response: noticeImageUploadResponse{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/notices/image", Tag: "Notices", Summary: "An image uploaded for a notice body", Query: []openapi.Param{{Name: "id", Required: true}}, ResponseType: "image/*"},
+	{Method: "POST", Path: "/api/notices/preview", Tag: "Notices", Summary: "Render notice markdown as the board will show it (admin)", Request: noticePreviewRequest{}, Response: noticePreviewResponse{}},
+
+	// Grading
+	{Method: "GET", Path: "/api/grading/proposals", Tag: "Grading", Summary: "Open promotion proposals, or those booked onto one grading day", Query: []openapi.Param{{Name: "event_id", Description: "grading day; includes decided proposals"}}, Response: []gradingDomain.Proposal{}},
+	{Method: "POST", Path: "/api/grading/proposals", Tag: "Grading", Summary: "Propose a member for promotion", Request: gradingProposalRequest{}, Response: gradingDomain.Proposal{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/grading/proposals/decide", Tag: "Grading", Summary: "Approve or reject a proposal (admin)", Request: gradingDecisionRequest{}, Response: gradingDomain.Proposal{}},
+	{Method: "POST", Path: "/api/grading/proposals/decide-batch", Tag: "Grading", Summary: "Approve or reject many proposals on grading day (admin)", Request: gradingBatchDecisionRequest{}, Response: batchDecisionResponse{}},
+	{Method: "POST", Path: "/api/grading/proposals/schedule", Tag: "Grading", Summary: "Book a proposal onto a grading day, or take it off (admin)", Request: gradingScheduleRequest{}, Response: gradingDomain.Proposal{}},
+	{Method: "GET", Path: "/api/grading/proposals/comments", Tag: "Grading", Summary: "Coach and admin discussion of a proposal", Query: []openapi.Param{{Name: "proposal_id", Required: true}}, Response: []proposalCommentView{}},
+	{Method: "POST", Path: "/api/grading/proposals/comments", Tag: "Grading", Summary: "Comment on a proposal", Request: proposalCommentRequest{}, Response: proposalCommentView{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/grading/proposals/mine", Tag: "Grading", Summary: "Proposals for your own promotion", Response: []memberProposalView{}},
+	{Method: "GET", Path: "/api/grading/ceremonies", Tag: "Grading", Summary: "Promotion ceremonies with their proposals (admin)", Response: []ceremonyView{}},
+	{Method: "POST", Path: "/api/grading/ceremonies", Tag: "Grading", Summary: "Plan a promotion ceremony: calendar event, notice and invitations (admin)", Request: gradingCeremonyRequest{}, Response: ceremonyView{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/grading/ceremonies/attach", Tag: "Grading", Summary: "Book another proposal onto a planned ceremony (admin)", Request: ceremonyProposalRequest{}, Response: gradingDomain.Proposal{}},
+	{Method: "POST", Path: "/api/grading/ceremonies/complete", Tag: "Grading", Summary: "Complete a ceremony, recording its promotions (admin)", Request: ceremonyCompleteRequest{}, Response: ceremonyCompleteResponse{}},
+	{Method: "GET", Path: "/api/grading/config", Tag: "Grading", Summary: "Promotion thresholds per program and belt", Response: []gradingDomain.Config{}},
+	{Method: "POST", Path: "/api/grading/config", Tag: "Grading", Summary: "Set a promotion threshold", Request: gradingConfigRequest{}, Response: gradingDomain.Config{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/grading/credit", Tag: "Grading", Summary: "Credit a member with training hours", Request: gradingCreditRequest{}, Response: estimatedHoursDomain.EstimatedHours{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/grading/force-promote", Tag: "Grading", Summary: "Promote a member without a proposal", Request: gradingForcePromoteRequest{}, Response: gradingDomain.Record{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/grading/member-config", Tag: "Grading", Summary: "A member's threshold overrides", Query: []openapi.Param{{Name: "member_id", Required: true}}, Response: []gradingDomain.MemberConfig{}},
+	{Method: "POST", Path: "/api/grading/member-config", Tag: "Grading", Summary: "Override a member's threshold (admin)", Request: gradingMemberConfigRequest{}, Response: gradingDomain.MemberConfig{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/grading/readiness", Tag: "Grading", Summary: "Members approaching their next belt", Response: readinessResponse{}},
+	{Method: "GET", Path: "/api/grading/eligibility", Tag: "Grading", Summary: "A member's standing against every criterion for a belt", Query: []openapi.Param{{Name: "member_id", Required: true}, {Name: "belt", Description: "target belt; defaults to the member's next belt"}}, Response: projections.GradingEligibilityEntry{}},
+	{Method: "GET", Path: "/api/grading/rules", Tag: "Grading", Summary: "Eligibility rules by program and belt (admin)", Response: []gradingDomain.Rule{}},
+	{Method: "POST", Path: "/api/grading/rules", Tag: "Grading", Summary: "Set the criteria for a program and belt (admin)", Request: gradingRuleRequest{}, Response: gradingDomain.Rule{}},
+	{Method: "DELETE", Path: "/api/grading/rules", Tag: "Grading", Summary: "Return a belt to its grading config thresholds (admin)", Query: []openapi.Param{queryID}},
+	{Method: "GET", Path: "/api/grading/makeup-credits", Tag: "Grading", Summary: "A member's makeup credits", Query: []openapi.Param{{Name: "member_id", Required: true}}, Response: []attendance.MakeupCredit{}},
+	{Method: "POST", Path: "/api/grading/makeup-credits", Tag: "Grading", Summary: "Award a makeup credit towards term attendance", Request: makeupCreditRequest{}, Response: attendance.MakeupCredit{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/grading/makeup-credits", Tag: "Grading", Summary: "Withdraw a makeup credit", Query: []openapi.Param{queryID}},
+	{Method: "POST", Path: "/api/grading/metric", Tag: "Grading", Summary: "Switch a member between hours and attendance readiness", Request: gradingMetricRequest{}},
+	{Method: "GET", Path: "/api/grading/notes", Tag: "Grading", Summary: "A member's grading notes the requester may see; members get only their own shared notes", Query: []openapi.Param{{Name: "member_id", Description: "required for coaches and admins; members always get their own"}}, Response: []gradingDomain.Note{}},
+	{Method: "POST", Path: "/api/grading/notes", Tag: "Grading", Summary: "Add a coach note, optionally scored against a rubric", Request: gradingNoteRequest{}, Response: gradingDomain.Note{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/grading/rubrics", Tag: "Grading", Summary: "Rubric templates, archived included", Response: []rubricDomain.Template{}},
+	{Method: "POST", Path: "/api/grading/rubrics", Tag: "Grading", Summary: "Create or update a rubric template (admin)", Request: rubricTemplateRequest{}, Response: rubricDomain.Template{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/grading/rubrics/history", Tag: "Grading", Summary: "A member's rubric scores and per-criterion averages", Query: []openapi.Param{{Name: "member_id", Required: true}}, Response: projections.GetRubricHistoryResult{}},
+	{Method: "GET", Path: "/api/grading/inventory", Tag: "Grading", Summary: "Belt and stripe stock, with items running low (admin)", Response: beltInventoryView{}},
+	{Method: "POST", Path: "/api/grading/inventory", Tag: "Grading", Summary: "Set the stock of a belt colour and size, or of stripe tape (admin)", Request: beltInventoryRequest{}, Response: inventoryDomain.Item{}},
+	{Method: "POST", Path: "/api/grading/belt-sizes", Tag: "Grading", Summary: "Record the belt size a member wears (admin)", Request: beltSizeRequest{}, Response: inventoryDomain.MemberSize{}},
+	{Method: "GET", Path: "/api/grading/pick-list", Tag: "Grading", Summary: "Belts to bring to a grading day, by colour and size (admin)", Query: []openapi.Param{{Name: "event_id", Required: true}}, Response: projections.GradingPickListResult{}},
+	{Method: "GET", Path: "/api/grading/records", Tag: "Grading", Summary: "List a member's grading history including amended and voided records", Query: []openapi.Param{{Name: "member_id", Required: true}}, Response: []gradingDomain.Record{}},
+	{Method: "POST", Path: "/api/grading/records/amend", Tag: "Grading", Summary: "Correct a grading record, superseding the original", Request: gradingRecordAmendRequest{}, Response: gradingDomain.Record{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/grading/records/void", Tag: "Grading", Summary: "Void a grading record entered in error", Request: gradingRecordVoidRequest{}, Response: gradingDomain.Record{}},
+	{Method: "POST", Path: "/api/grading/bulk-award", Tag: "Grading", Summary: "Award many members a stripe or belt at once, or preview their current and new ranks", Request: gradingBulkAwardRequest{}, Response: orchestrators.BulkAwardResult{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/grading/import", Tag: "Grading", Summary: "Import historic belts and stripes from a CSV upload as backdated grading records", Query: []openapi.Param{{Name: "dry_run", Description: "true to preview without saving"}}, RequestType: "multipart/form-data", Response: orchestrators.ImportBeltsResult{}},
+	{Method: "GET", Path: "/api/grading/records/export", Tag: "Grading", Summary: "Download promotions as CSV or XLSX", Query: []openapi.Param{{Name: "from", Description: "YYYY-MM-DD; defaults to all time"}, {Name: "to", Description: "YYYY-MM-DD"}, {Name: "format", Description: "csv (default) or xlsx"}}, ResponseType: "text/csv"},
+
+	// Injuries and observations
+	{Method: "GET", Path: "/api/injuries", Tag: "Injuries", Summary: "List reported injuries", Query: []openapi.Param{{Name: "member_id"}}, Response: []injuryDomain.Injury{}},
+	{Method: "PUT", Path: "/api/injuries", Tag: "Injuries", Summary: "Update an injury's status", Request: injuryUpdateRequest{}, Response: injuryDomain.Injury{}},
+	{Method: "GET", Path: "/api/observations", Tag: "Injuries", Summary: "A member's observations the requester may see; members get only their own shared feedback", Query: []openapi.Param{{Name: "member_id", Description: "required for coaches and admins; members always get their own"}}, Response: []observationDomain.Observation{}},
+	{Method: "POST", Path: "/api/observations", Tag: "Injuries", Summary: "Record an observation, optionally scored against a rubric", Request: observationCreateRequest{}, Response: observationDomain.Observation{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/observations/visibility", Tag: "Injuries", Summary: "Change who may read an observation: coaches, admin or shared with the member", Request: observationVisibilityRequest{}, Response: observationDomain.Observation{}},
+	{Method: "GET", Path: "/api/observations/capture", Tag: "Injuries", Summary: "The caller's captured notes still waiting for a member to be chosen", Response: []quickCaptureDomain.Capture{}},
+	{Method: "POST", Path: "/api/observations/capture", Tag: "Injuries", Summary: "Send a batch of notes captured mid-class by member name; each is saved, queued, a duplicate or rejected", Request: quickCaptureRequest{}, Response: orchestrators.QuickCaptureResult{}},
+	{Method: "POST", Path: "/api/observations/capture/resolve", Tag: "Injuries", Summary: "Save a queued note as an observation about the chosen member, or discard it", Request: quickCaptureResolveRequest{}, Response: quickCaptureDomain.Capture{}},
+
+	// Messages
+	{Method: "GET", Path: "/api/messages", Tag: "Messages", Summary: "Messages for a member", Query: []openapi.Param{queryMemberID}, Response: []messageDomain.Message{}},
+	{Method: "POST", Path: "/api/messages", Tag: "Messages", Summary: "Send a message", Request: messageCreateRequest{}, Response: messageDomain.Message{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/messages/read", Tag: "Messages", Summary: "Mark a message or thread read", Request: messageReadRequest{}, Response: messageDomain.Message{}},
+	{Method: "GET", Path: "/api/messages/threads", Tag: "Messages", Summary: "Message threads", Query: []openapi.Param{{Name: "member_id"}}, Response: []projections.MessageThread{}},
+	{Method: "GET", Path: "/api/messages/thread", Tag: "Messages", Summary: "One thread with its messages", Query: []openapi.Param{queryID}, Response: jsonObject{}},
+	{Method: "POST", Path: "/api/messages/reply", Tag: "Messages", Summary: "Reply in a thread", Request: messageReplyRequest{}, Response: messageDomain.Message{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/messages/broadcast", Tag: "Messages", Summary: "Message every member of a program", Request: messageBroadcastRequest{}, Response: map[string]int{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/messages/images", Tag: "Messages", Summary: "Upload an image to attach to a message (coach/admin)", RequestType: "multipart/form-data", Response: messageImageUploadResponse{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/messages/image", Tag: "Messages", Summary: "An image attached to a message in one of your threads", Query: []openapi.Param{{Name: "message_id", Required: true}, {Name: "image_id", Required: true}}, ResponseType: "image/*"},
+	{Method: "GET", Path: "/api/inbox", Tag: "Messages", Summary: "Emails sent to a member", Query: []openapi.Param{queryMemberID}, Response: []emailDomain.Email{}},
+
+	// Notifications and search
+	{Method: "GET", Path: "/api/notifications", Tag: "Notifications", Summary: "Recent notifications and the unread count", Query: []openapi.Param{{Name: "limit"}}, Response: jsonObject{}},
+	{Method: "POST", Path: "/api/notifications/read", Tag: "Notifications", Summary: "Mark one or all notifications read", Request: notificationsReadRequest{}},
+	{Method: "GET", Path: "/api/notifications/preferences", Tag: "Notifications", Summary: "Your notification channels per kind", Response: []notificationDomain.Preference{}},
+	{Method: "PUT", Path: "/api/notifications/preferences", Tag: "Notifications", Summary: "Set the channels for one kind", Request: notificationPreferenceRequest{}, Response: notificationDomain.Preference{}},
+	{Method: "GET", Path: "/api/push/key", Tag: "Notifications", Summary: "The VAPID public key browsers subscribe with (503 when push is not configured)", Response: pushKeyResponse{}},
+	{Method: "POST", Path: "/api/push/subscriptions", Tag: "Notifications", Summary: "Register this browser for push notifications", Request: pushSubscriptionRequest{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/push/subscriptions", Tag: "Notifications", Summary: "Stop push notifications to this browser", Request: pushSubscriptionRequest{}},
+	{Method: "GET", Path: "/api/events/stream", Tag: "Notifications", Summary: "Live check-ins, messages and notices as server-sent events; resumes from Last-Event-ID", Query: []openapi.Param{{Name: "types", Description: "comma-separated event types; default all"}}, ResponseType: "text/event-stream"},
+	{Method: "GET", Path: "/api/communication-preferences", Tag: "Email", Summary: "Email categories you receive", Response: emailDomain.Preferences{}},
+	{Method: "PUT", Path: "/api/communication-preferences", Tag: "Email", Summary: "Choose the email categories you receive", Request: communicationPreferencesRequest{}, Response: emailDomain.Preferences{}},
+	{Method: "GET", Path: "/api/search", Tag: "Notifications", Summary: "Search members, notices, clips, topics and messages", Query: []openapi.Param{{Name: "q", Required: true}, {Name: "limit"}}, Response: jsonObject{}},
+
+	// Schedule
+	{Method: "GET", Path: "/api/schedules", Tag: "Schedule", Summary: "Weekly class schedule", Query: []openapi.Param{{Name: "day"}}, Response: []scheduleDomain.Schedule{}},
+	{Method: "POST", Path: "/api/schedules", Tag: "Schedule", Summary: "Add a class to the schedule", Request: scheduleCreateRequest{}, Response: scheduleDomain.Schedule{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/schedules", Tag: "Schedule", Summary: "Remove a class from the schedule", Query: []openapi.Param{queryID}},
+	{Method: "GET", Path: "/api/schedules/timetable", Tag: "Schedule", Summary: "Week grid of classes with clashes flagged, for the timetable editor (admin)", Query: []openapi.Param{{Name: "location_id"}}, Response: projections.Timetable{}},
+	{Method: "POST", Path: "/api/schedules/bulk", Tag: "Schedule", Summary: "Move several classes at once; all or nothing, 409 on a clash (admin)", Request: scheduleBulkRequest{}, Response: []scheduleDomain.Schedule{}},
+	{Method: "GET", Path: "/api/schedules/recent-sessions", Tag: "Schedule", Summary: "Recently held class sessions", Response: []sessionInfo{}},
+	{Method: "GET", Path: "/api/timesheets", Tag: "Schedule", Summary: "Classes run in a month, who coached them and hours and pay per coach (admin)", Query: []openapi.Param{{Name: "month", Description: "YYYY-MM; defaults to this month"}}, Response: projections.CoachTimesheetResult{}},
+	{Method: "POST", Path: "/api/timesheets/assign", Tag: "Schedule", Summary: "Set a class's regular coach, or the coach for one date (admin)", Request: timesheetAssignRequest{}},
+	{Method: "GET", Path: "/api/timesheets/rates", Tag: "Schedule", Summary: "Coaches and their hourly rates (admin)", Response: []timesheetCoach{}},
+	{Method: "POST", Path: "/api/timesheets/rates", Tag: "Schedule", Summary: "Set a coach's hourly rate in cents (admin)", Request: timesheetRateRequest{}, Response: timesheetDomain.Rate{}},
+	{Method: "GET", Path: "/api/timesheets/export", Tag: "Schedule", Summary: "Download a month's payroll as CSV (admin)", Query: []openapi.Param{{Name: "month", Description: "YYYY-MM; defaults to this month"}}, ResponseType: "text/csv"},
+	{Method: "GET", Path: "/api/class-packs", Tag: "Schedule", Summary: "Outstanding and expired class packs with revenue earned, owed and forfeited by class type (admin)", Response: projections.ClassPackReport{}},
+	{Method: "POST", Path: "/api/class-packs", Tag: "Schedule", Summary: "Record a class pack sold to a member (admin)", Request: classPackRequest{}, Response: classPackDomain.ClassPack{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/coach-availability", Tag: "Schedule", Summary: "A coach's weekly availability and upcoming exceptions (coaches see their own)", Query: []openapi.Param{{Name: "coach_id", Description: "admin only; defaults to you"}}, Response: coachAvailabilityView{}},
+	{Method: "POST", Path: "/api/coach-availability", Tag: "Schedule", Summary: "Add a weekly availability window", Request: availabilityWindowRequest{}, Response: availabilityDomain.Window{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/coach-availability", Tag: "Schedule", Summary: "Remove a weekly availability window", Query: []openapi.Param{queryID}},
+	{Method: "POST", Path: "/api/coach-availability/exceptions", Tag: "Schedule", Summary: "Mark a coach away, or free, on one date", Request: availabilityExceptionRequest{}, Response: availabilityDomain.Exception{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/coach-availability/exceptions", Tag: "Schedule", Summary: "Remove an availability exception", Query: []openapi.Param{queryID}},
+	{Method: "GET", Path: "/api/coverage", Tag: "Schedule", Summary: "Classes in the next 4 weeks without an available coach, with coaches free to cover (admin)", Response: projections.ClassCoverageResult{}},
+	{Method: "POST", Path: "/api/coverage/assign", Tag: "Schedule", Summary: "Assign a coach to one uncovered class (admin)", Request: coverageAssignRequest{}},
+	{Method: "GET", Path: "/api/holidays", Tag: "Schedule", Summary: "List holidays", Response: []holidayDomain.Holiday{}},
+	{Method: "POST", Path: "/api/holidays", Tag: "Schedule", Summary: "Add a holiday", Request: holidayCreateRequest{}, Response: holidayDomain.Holiday{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/holidays", Tag: "Schedule", Summary: "Delete a holiday", Query: []openapi.Param{queryID}},
+	{Method: "GET", Path: "/api/terms", Tag: "Schedule", Summary: "List terms", Response: []termDomain.Term{}},
+	{Method: "POST", Path: "/api/terms", Tag: "Schedule", Summary: "Add a term", Request: termCreateRequest{}, Response: termDomain.Term{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/terms", Tag: "Schedule", Summary: "Delete a term", Query: []openapi.Param{queryID}},
+	{Method: "GET", Path: "/api/terms/rollover", Tag: "Schedule", Summary: "Propose the next term's dates, carried-over holidays and kids thresholds (admin)", Response: projections.TermRolloverProposal{}},
+	{Method: "POST", Path: "/api/terms/rollover", Tag: "Schedule", Summary: "Create the next term and archive the previous term's kids readiness (admin)", Request: termRolloverRequest{}, Response: orchestrators.TermRolloverResult{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/terms/readiness", Tag: "Schedule", Summary: "A term's kids grading readiness, archived once the term is rolled over (admin)", Query: []openapi.Param{{Name: "term_id", Required: true}}, Response: termReadinessView{}},
+	{Method: "GET", Path: "/api/terms/reports", Tag: "Schedule", Summary: "Kids' end-of-term progress reports and whether each was emailed (staff)", Query: []openapi.Param{{Name: "term_id", Required: true}, {Name: "member_id", Description: "one kid's report; omit for every kid"}}, Response: termReportsView{}},
+	{Method: "GET", Path: "/api/terms/reports/pdf", Tag: "Schedule", Summary: "Download term reports as a PDF, one kid per page (staff)", Query: []openapi.Param{{Name: "term_id", Required: true}, {Name: "member_id", Description: "one kid's report; omit for every kid"}}, ResponseType: "application/pdf"},
+	{Method: "POST", Path: "/api/terms/reports/email", Tag: "Schedule", Summary: "Email term reports to the kids' guardians (admin)", Request: termReportsEmailRequest{}, Response: orchestrators.SendTermReportsResult{}},
+	{Method: "GET", Path: "/api/class-types", Tag: "Schedule", Summary: "List class types", Query: []openapi.Param{{Name: "program_id"}}, Response: []classTypeDomain.ClassType{}},
+	{Method: "POST", Path: "/api/class-types", Tag: "Schedule", Summary: "Add a class type", Request: classTypeCreateRequest{}, Response: classTypeDomain.ClassType{}, Status: http.StatusCreated},
+	{Method: "PUT", Path: "/api/class-types", Tag: "Schedule", Summary: "Update a class type", Request: classTypeUpdateRequest{}, Response: classTypeDomain.ClassType{}},
+	{Method: "DELETE", Path: "/api/class-types", Tag: "Schedule", Summary: "Delete a class type", Query: []openapi.Param{queryID}},
+	{Method: "GET", Path: "/api/class-types/approvals", Tag: "Schedule", Summary: "Members a coach has approved for class types with prerequisites", Query: []openapi.Param{{Name: "class_type_id"}, {Name: "member_id"}}, Response: []classTypeApprovalView{}},
+	{Method: "POST", Path: "/api/class-types/approvals", Tag: "Schedule", Summary: "Approve a member for a class type regardless of its prerequisites", Request: classTypeApprovalRequest{}, Response: classTypeDomain.Approval{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/class-types/approvals", Tag: "Schedule", Summary: "Withdraw a member's approval for a class type", Query: []openapi.Param{{Name: "class_type_id", Required: true}, {Name: "member_id", Required: true}}},
+	{Method: "GET", Path: "/api/programs", Tag: "Schedule", Summary: "List programs", Response: []programDomain.Program{}},
+
+	// Accounts and administration
+	{Method: "GET", Path: "/api/accounts", Tag: "Admin", Summary: "List accounts", Query: []openapi.Param{{Name: "role"}}, Response: []accountView{}},
+	{Method: "POST", Path: "/api/accounts", Tag: "Admin", Summary: "Create an account", Request: accountCreateRequest{}, Response: map[string]string{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/accounts/role", Tag: "Admin", Summary: "Change an account's role", Request: changeRoleRequest{}, Response: map[string]string{}},
+	{Method: "POST", Path: "/api/accounts/unlock", Tag: "Admin", Summary: "Unlock an account after failed sign-ins", Request: accountIDRequest{}, Response: map[string]string{}},
+	{Method: "GET", Path: "/api/status-changes", Tag: "Admin", Summary: "Suspensions and membership freezes of an account or member, applied and scheduled", Query: []openapi.Param{{Name: "subject_type", Required: true, Description: "account or member"}, {Name: "subject_id", Required: true}}, Response: []statusChangeDomain.Change{}},
+	{Method: "POST", Path: "/api/status-changes", Tag: "Admin", Summary: "Suspend or reinstate an account, or freeze or unfreeze a membership, now or from a date", Request: statusChangeRequest{}, Response: orchestrators.ChangeStatusResult{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/status-changes", Tag: "Admin", Summary: "Cancel a scheduled status change", Query: []openapi.Param{queryID}},
+	{Method: "POST", Path: "/api/admin/accounts/impersonate", Tag: "Admin", Summary: "Sign in as another account for up to an hour, with every change audited; stop with /api/devmode/restore", Request: impersonateAccountRequest{}, Response: map[string]any{}},
+	{Method: "POST", Path: "/api/admin/accounts/bulk-provision", Tag: "Admin", Summary: "Create pending accounts for members without one and queue activation emails", Query: []openapi.Param{{Name: "dry_run", Description: "true to report outcomes without saving"}}, Response: orchestrators.BulkProvisionResult{}},
+	{Method: "GET", Path: "/api/admin/feature-flags", Tag: "Admin", Summary: "List feature flags", Response: []flagDTO{}},
+	{Method: "POST", Path: "/api/admin/feature-flags", Tag: "Admin", Summary: "Update feature flags", Request: featureFlagsUpdateRequest{}, Response: map[string]bool{}},
+	{Method: "GET", Path: "/api/admin/feature-flags/trace", Tag: "Admin", Summary: "Explain whether an account sees a feature", Query: []openapi.Param{{Name: "key", Required: true}, {Name: "email"}, {Name: "account_id"}}, Response: featureFlagTraceView{}},
+	{Method: "GET", Path: "/api/admin/permissions", Tag: "Admin", Summary: "Effective permission for every action", Response: []permissionDomain.Permission{}},
+	{Method: "POST", Path: "/api/admin/permissions", Tag: "Admin", Summary: "Override permissions", Request: permissionsUpdateRequest{}, Response: []permissionDomain.Permission{}},
+	{Method: "GET", Path: "/api/admin/beta-testers", Tag: "Admin", Summary: "List beta testers", Response: []betaTesterView{}},
+	{Method: "POST", Path: "/api/admin/beta-testers", Tag: "Admin", Summary: "Add or remove a beta tester", Request: betaTesterRequest{}, Response: betaTesterView{}},
+	{Method: "GET", Path: "/api/admin/workers", Tag: "Admin", Summary: "Background worker health", Response: []workerStatusView{}},
+	{Method: "GET", Path: "/api/admin/jobs", Tag: "Admin", Summary: "Background jobs with their cron schedules, next run and last run", Response: []jobView{}},
+	{Method: "PUT", Path: "/api/admin/jobs", Tag: "Admin", Summary: "Change a job's cron schedule or turn it on or off", Request: jobUpdateRequest{}, Response: jobView{}},
+	{Method: "POST", Path: "/api/admin/jobs/run", Tag: "Admin", Summary: "Run a job now, outside its schedule (409 while it is running)", Request: jobRunRequest{}, Status: http.StatusAccepted},
+	{Method: "GET", Path: "/api/admin/perf/history", Tag: "Admin", Summary: "Request and query latency percentiles over a window, from saved per-minute timings", Query: []openapi.Param{{Name: "window", Description: "1h, 6h, 24h (default), 7d or 30d"}, {Name: "from", Description: "RFC 3339 start; with to, replaces window"}, {Name: "to", Description: "RFC 3339 end"}}, Response: perfHistoryView{}},
+	{Method: "GET", Path: "/api/admin/stats", Tag: "Admin", Summary: "Dashboard KPIs for today and weekly trends from daily snapshots", Response: adminStatsView{}},
+	{Method: "GET", Path: "/api/admin/config", Tag: "Admin", Summary: "Settings in effect and where each came from", Response: jsonObject{}},
+	{Method: "GET", Path: "/api/admin/security-check", Tag: "Admin", Summary: "Which security protections are active and the headers pages get", Response: jsonObject{}},
+	{Method: "GET", Path: "/api/admin/backups", Tag: "Admin", Summary: "List database backups", Response: []backupView{}},
+	{Method: "POST", Path: "/api/admin/backups", Tag: "Admin", Summary: "Back up the database now", Response: jsonObject{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/admin/backups/restore", Tag: "Admin", Summary: "Restore a backup", Request: backupRestoreRequest{}, Response: jsonObject{}},
+	{Method: "GET", Path: "/api/admin/sessions", Tag: "Admin", Summary: "Signed-in sessions", Response: []sessionView{}},
+	{Method: "POST", Path: "/api/admin/sessions/revoke", Tag: "Admin", Summary: "Sign out a session or every session of an account", Request: sessionRevokeRequest{}, Response: map[string]int{}},
+	{Method: "GET", Path: "/api/admin/kiosk-devices", Tag: "Admin", Summary: "Registered kiosk devices with their current exit PINs", Response: []kioskDeviceView{}},
+	{Method: "PUT", Path: "/api/admin/kiosk-devices", Tag: "Admin", Summary: "Rename a kiosk device", Request: kioskDeviceRequest{}},
+	{Method: "DELETE", Path: "/api/admin/kiosk-devices", Tag: "Admin", Summary: "Remove a kiosk device, signing out its kiosk session", Query: []openapi.Param{queryID}},
+	{Method: "POST", Path: "/api/admin/kiosk-devices/end", Tag: "Admin", Summary: "End a device's kiosk session remotely", Request: kioskDeviceRequest{}},
+	{Method: "POST", Path: "/api/admin/bugbox", Tag: "Admin", Summary: "File a bug report with an optional screenshot", RequestType: "multipart/form-data", Response: jsonObject{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/admin/bugbox/screenshot", Tag: "Admin", Summary: "A bug report's screenshot", Query: []openapi.Param{queryID}, ResponseType: "image/png"},
+	{Method: "GET", Path: "/api/admin/bugbox/list", Tag: "Admin", Summary: "Bug reports, newest first: every report for admins, your own for everyone else", Query: []openapi.Param{{Name: "status", Description: "new, ack, in_progress, fixed or wont_fix"}}, Response: []bugReport{}},
+	{Method: "POST", Path: "/api/admin/bugbox/triage", Tag: "Admin", Summary: "Change a bug report's status or assignee, or close it as a duplicate (admin)", Request: bugTriageRequest{}, Response: bugboxDomain.Submission{}},
+	{Method: "GET", Path: "/api/admin/bugbox/comments", Tag: "Admin", Summary: "Comments on a bug report (admins and the reporter)", Query: []openapi.Param{{Name: "submission_id", Required: true}}, Response: []bugboxDomain.Comment{}},
+	{Method: "POST", Path: "/api/admin/bugbox/comments", Tag: "Admin", Summary: "Comment on a bug report (admins and the reporter)", Request: bugCommentRequest{}, Response: bugboxDomain.Comment{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/openapi.json", Tag: "Admin", Summary: "This document", ResponseType: "application/json"},
+
+	// Privacy
+	{Method: "POST", Path: "/api/privacy/delete", Tag: "Privacy", Summary: "Request deletion of your data", Response: jsonObject{}},
+	{Method: "POST", Path: "/api/privacy/delete/cancel", Tag: "Privacy", Summary: "Cancel a pending deletion request", Response: jsonObject{}},
+	{Method: "POST", Path: "/api/privacy/consent/revoke", Tag: "Privacy", Summary: "Revoke a consent", Query: []openapi.Param{{Name: "type", Required: true}}, Response: jsonObject{}},
+	{Method: "GET", Path: "/api/me/export", Tag: "Privacy", Summary: "Your recent data exports, newest first", Response: []memberExportView{}},
+	{Method: "POST", Path: "/api/me/export", Tag: "Privacy", Summary: "Ask for a copy of your data; it is built in the background and emailed as a link (409 while one is being prepared)", Response: memberExportView{}, Status: http.StatusAccepted},
+	{Method: "GET", Path: "/api/me/export/download", Tag: "Privacy", Summary: "Download a finished data export as a ZIP", Query: []openapi.Param{queryID}, ResponseType: "application/zip"},
+
+	// Goals and milestones
+	{Method: "GET", Path: "/api/training-goals", Tag: "Goals", Summary: "A member's training goals", Query: []openapi.Param{queryMemberID}, Response: []trainingGoalDomain.TrainingGoal{}},
+	{Method: "POST", Path: "/api/training-goals", Tag: "Goals", Summary: "Set a training goal", Request: trainingGoalCreateRequest{}, Response: trainingGoalDomain.TrainingGoal{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/training-goals", Tag: "Goals", Summary: "Delete a training goal", Query: []openapi.Param{queryID}},
+	{Method: "GET", Path: "/api/training-goals/suggest", Tag: "Goals", Summary: "A weekly training goal suggested from the last eight weeks of attendance", Query: []openapi.Param{queryMemberID}, Response: projections.GetTrainingGoalSuggestionResult{}},
+	{Method: "GET", Path: "/api/milestones", Tag: "Goals", Summary: "List milestones", Response: []milestoneDomain.Milestone{}},
+	{Method: "POST", Path: "/api/milestones", Tag: "Goals", Summary: "Add a milestone (admin)", Request: milestoneCreateRequest{}, Response: milestoneDomain.Milestone{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/milestones", Tag: "Goals", Summary: "Delete a milestone", Query: []openapi.Param{queryID}},
+	{Method: "GET", Path: "/api/member-milestones", Tag: "Goals", Summary: "Milestones a member has earned", Query: []openapi.Param{queryMemberID}, Response: []projections.EarnedMilestone{}},
+	{Method: "POST", Path: "/api/member-milestones/dismiss", Tag: "Goals", Summary: "Dismiss an earned milestone", Request: milestoneDismissRequest{}},
+	{Method: "GET", Path: "/api/personal-goals", Tag: "Goals", Summary: "Your personal goals", Response: []personalGoalDomain.PersonalGoal{}},
+	{Method: "POST", Path: "/api/personal-goals", Tag: "Goals", Summary: "Add a personal goal", Request: personalGoalCreateRequest{}, Response: personalGoalDomain.PersonalGoal{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/personal-goals", Tag: "Goals", Summary: "Delete a personal goal", Query: []openapi.Param{queryID}},
+	{Method: "PUT", Path: "/api/personal-goals/progress", Tag: "Goals", Summary: "Record progress on a personal goal", Request: personalGoalProgressRequest{}, Response: personalGoalDomain.PersonalGoal{}},
+	{Method: "GET", Path: "/api/personal-goals/feedback", Tag: "Goals", Summary: "Personal goals with check-ins and coach annotations", Query: []openapi.Param{queryMemberID}, Response: []projections.PersonalGoalFeedback{}},
+	{Method: "GET", Path: "/api/personal-goals/check-ins/due", Tag: "Goals", Summary: "Your personal goals awaiting a check-in", Response: []personalGoalDomain.PersonalGoal{}},
+	{Method: "POST", Path: "/api/personal-goals/check-ins", Tag: "Goals", Summary: "Check in on a personal goal", Request: personalGoalCheckInRequest{}, Response: orchestrators.GoalCheckInResult{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/personal-goals/annotations", Tag: "Goals", Summary: "Annotate a member's personal goal", Request: personalGoalAnnotationRequest{}, Response: personalGoalDomain.Annotation{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/feedback/pending", Tag: "Attendance", Summary: "The caller's classes from the last 24 hours they can still rate", Response: []projections.PendingClassRating{}},
+	{Method: "POST", Path: "/api/feedback", Tag: "Attendance", Summary: "Rate one of the caller's classes 1-5 with an optional comment, once, within 24 hours of checking in", Request: classFeedbackRequest{}, Response: map[string]string{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/feedback/report", Tag: "Attendance", Summary: "Anonymised class ratings by class type, coach and topic; coaches see only their own classes", Query: []openapi.Param{{Name: "from", Description: "YYYY-MM-DD; defaults to 28 days ago"}, {Name: "to", Description: "YYYY-MM-DD; defaults to today"}, {Name: "coach_id", Description: "admins only: one coach's classes"}}, Response: projections.ClassFeedbackReport{}},
+	{Method: "GET", Path: "/api/celebrations", Tag: "Members", Summary: "Birthdays, training anniversaries and class milestones from today; members who opted out are left out", Query: []openapi.Param{{Name: "days", Description: "1-31; defaults to 7"}}, Response: []celebrationResponse{}},
+	{Method: "GET", Path: "/api/celebrations/profile", Tag: "Members", Summary: "Your date of birth, join date and celebrations opt-out", Query: []openapi.Param{{Name: "member_id", Description: "admins only: another member's"}}, Response: celebrationProfileResponse{}},
+	{Method: "PUT", Path: "/api/celebrations/profile", Tag: "Members", Summary: "Set your date of birth and celebrations opt-out; admins may also set a member's join date", Query: []openapi.Param{{Name: "member_id", Description: "admins only: another member's"}}, Request: celebrationProfileRequest{}, Response: celebrationProfileResponse{}},
+
+	// Library
+	{Method: "GET", Path: "/api/themes", Tag: "Library", Summary: "List themes; themes above the viewer's belt are locked", Query: []openapi.Param{{Name: "program"}}, Response: []projections.GatedTheme{}},
+	{Method: "GET", Path: "/api/themes/carousel", Tag: "Library", Summary: "What each class is working on, from the active rotors; members see their own program", Response: projections.ThemeCarouselResult{}},
+	{Method: "POST", Path: "/api/themes/min-belt", Tag: "Library", Summary: "Set or clear a theme's minimum belt", Request: themeMinBeltRequest{}, Response: themeDomain.Theme{}},
+	{Method: "POST", Path: "/api/themes", Tag: "Library", Summary: "Add a theme", Request: themeCreateRequest{}, Response: themeDomain.Theme{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/clips", Tag: "Library", Summary: "List clips; clips above the viewer's belt are locked", Query: []openapi.Param{{Name: "theme_id"}, {Name: "promoted", Description: "true for promoted clips only"}, {Name: "q"}}, Response: []projections.GatedClip{}},
+	{Method: "POST", Path: "/api/clips", Tag: "Library", Summary: "Add a clip", Request: clipCreateRequest{}, Response: clipDomain.Clip{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/clips/promote", Tag: "Library", Summary: "Promote a clip to the member library", Request: clipIDRequest{}, Response: clipDomain.Clip{}},
+	{Method: "POST", Path: "/api/clips/min-belt", Tag: "Library", Summary: "Set or clear a clip's minimum belt", Request: clipMinBeltRequest{}, Response: clipDomain.Clip{}},
+	{Method: "GET", Path: "/api/clips/tags", Tag: "Library", Summary: "List clip tags", Response: []clipDomain.Tag{}},
+	{Method: "POST", Path: "/api/clips/tags", Tag: "Library", Summary: "Add a clip tag", Request: clipTagCreateRequest{}, Response: clipDomain.Tag{}, Status: http.StatusCreated},
+	{Method: "PUT", Path: "/api/clips/tags", Tag: "Library", Summary: "Move a tag to another category", Request: clipTagUpdateRequest{}, Response: clipDomain.Tag{}},
+	{Method: "POST", Path: "/api/clips/{clipID}/tags", Tag: "Library", Summary: "Tag a clip", Request: clipTagAttachRequest{}},
+	{Method: "DELETE", Path: "/api/clips/{clipID}/tags", Tag: "Library", Summary: "Untag a clip", Query: []openapi.Param{{Name: "tagID", Required: true}}},
+	{Method: "GET", Path: "/api/clips/search-by-tags", Tag: "Library", Summary: "Clips carrying every given tag", Query: []openapi.Param{{Name: "tagID", Required: true, Description: "repeatable"}}, Response: []projections.GatedClip{}},
+	{Method: "GET", Path: "/api/clips/search", Tag: "Library", Summary: "Search clips by tag name or ID and text", Query: []openapi.Param{{Name: "tag", Description: "repeatable; clips must carry every tag"}, {Name: "q"}, {Name: "promoted"}}, Response: []projections.GatedClip{}},
+	{Method: "GET", Path: "/api/clips/taxonomy", Tag: "Library", Summary: "Tags grouped by category", Response: []projections.TaxonomyCategory{}},
+
+	// Curriculum
+	{Method: "GET", Path: "/api/rotors", Tag: "Curriculum", Summary: "Rotors for a class type", Query: []openapi.Param{{Name: "class_type_id", Required: true}}, Response: []rotorDomain.Rotor{}},
+	{Method: "POST", Path: "/api/rotors", Tag: "Curriculum", Summary: "Create a draft rotor", Request: rotorCreateRequest{}, Response: rotorDomain.Rotor{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/rotors/by-id", Tag: "Curriculum", Summary: "Get a rotor", Query: []openapi.Param{queryID}, Response: rotorDomain.Rotor{}},
+	{Method: "PUT", Path: "/api/rotors/by-id", Tag: "Curriculum", Summary: "Rename a rotor", Query: []openapi.Param{queryID}, Request: rotorRenameRequest{}, Response: rotorDomain.Rotor{}},
+	{Method: "DELETE", Path: "/api/rotors/by-id", Tag: "Curriculum", Summary: "Delete a draft rotor", Query: []openapi.Param{queryID}},
+	{Method: "POST", Path: "/api/rotors/activate", Tag: "Curriculum", Summary: "Make a rotor the active one for its class type", Request: rotorIDRequest{}, Response: rotorDomain.Rotor{}},
+	{Method: "POST", Path: "/api/rotors/preview", Tag: "Curriculum", Summary: "Show or hide upcoming topics to members", Request: rotorPreviewRequest{}, Response: rotorDomain.Rotor{}},
+	{Method: "POST", Path: "/api/rotors/advance-mode", Tag: "Curriculum", Summary: "Switch between automatic and manual topic advance", Request: rotorAdvanceModeRequest{}, Response: rotorDomain.Rotor{}},
+	{Method: "POST", Path: "/api/rotors/vote-rules", Tag: "Curriculum", Summary: "Set a rotor's vote rules: one per theme, expiry and coach weight", Request: rotorVoteRulesRequest{}, Response: rotorDomain.Rotor{}},
+	{Method: "GET", Path: "/api/rotors/export", Tag: "Curriculum", Summary: "Download a rotor as JSON or YAML", Query: []openapi.Param{queryID, {Name: "format", Description: "json (default) or yaml"}}, Response: rotorDomain.Document{}},
+	{Method: "POST", Path: "/api/rotors/import", Tag: "Curriculum", Summary: "Create a draft rotor from an exported document", Query: []openapi.Param{{Name: "class_type_id", Description: "defaults to the document's class type"}, {Name: "format", Description: "json (default) or yaml"}}, Request: rotorDomain.Document{}, Response: rotorDomain.Rotor{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/rotors/themes", Tag: "Curriculum", Summary: "A rotor's themes", Query: []openapi.Param{{Name: "rotor_id", Required: true}}, Response: []rotorDomain.RotorTheme{}},
+	{Method: "POST", Path: "/api/rotors/themes", Tag: "Curriculum", Summary: "Add a theme to a rotor", Request: rotorThemeCreateRequest{}, Response: rotorDomain.RotorTheme{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/rotors/themes", Tag: "Curriculum", Summary: "Remove a theme from a rotor", Query: []openapi.Param{queryID}},
+	{Method: "POST", Path: "/api/rotors/themes/clone", Tag: "Curriculum", Summary: "Copy a theme and its topics into a draft rotor", Request: rotorThemeCloneRequest{}, Response: orchestrators.CloneRotorThemeResult{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/rotors/shared-topics", Tag: "Curriculum", Summary: "The shared topic library with how many class topics follow each entry", Response: []sharedTopicView{}},
+	{Method: "POST", Path: "/api/rotors/shared-topics", Tag: "Curriculum", Summary: "Add or edit a shared topic; edits reach every linked class topic (201 when adding)", Request: sharedTopicRequest{}, Response: rotorDomain.SharedTopic{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/rotors/shared-topics", Tag: "Curriculum", Summary: "Remove a shared topic, unlinking the class topics that follow it", Query: []openapi.Param{queryID}},
+	{Method: "GET", Path: "/api/rotors/topics", Tag: "Curriculum", Summary: "A theme's topics; topics above the viewer's belt are locked", Query: []openapi.Param{{Name: "theme_id", Required: true}}, Response: []projections.GatedTopic{}},
+	{Method: "POST", Path: "/api/rotors/topics", Tag: "Curriculum", Summary: "Add a topic", Request: topicCreateRequest{}, Response: rotorDomain.Topic{}, Status: http.StatusCreated},
+	{Method: "PUT", Path: "/api/rotors/topics", Tag: "Curriculum", Summary: "Update a topic", Query: []openapi.Param{queryID}, Request: topicUpdateRequest{}, Response: rotorDomain.Topic{}},
+	{Method: "DELETE", Path: "/api/rotors/topics", Tag: "Curriculum", Summary: "Delete a topic", Query: []openapi.Param{queryID}},
+	{Method: "POST", Path: "/api/rotors/topics/reorder", Tag: "Curriculum", Summary: "Reorder a theme's topics", Request: topicReorderRequest{}},
+	{Method: "POST", Path: "/api/rotors/topics/bump", Tag: "Curriculum", Summary: "Move a topic to the front of the queue", Request: topicBumpRequest{}, Response: rotorDomain.TopicSchedule{}},
+	{Method: "POST", Path: "/api/rotors/schedule/action", Tag: "Curriculum", Summary: "Skip, extend or complete the current topic", Request: topicScheduleActionRequest{}, Response: rotorDomain.TopicSchedule{}},
+	{Method: "GET", Path: "/api/votes", Tag: "Curriculum", Summary: "Weighted count of a topic's active votes", Query: []openapi.Param{{Name: "topic_id", Required: true}}, Response: map[string]int{}},
+	{Method: "POST", Path: "/api/votes", Tag: "Curriculum", Summary: "Vote for a topic", Request: voteRequest{}, Response: jsonObject{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/votes/mine", Tag: "Curriculum", Summary: "The signed-in account's active votes", Response: jsonObject{}},
+	{Method: "GET", Path: "/api/curriculum/view", Tag: "Curriculum", Summary: "The active rotor of a class type with its schedule", Query: []openapi.Param{{Name: "class_type_id", Required: true}}, Response: jsonObject{}},
+	{Method: "GET", Path: "/api/curriculum/overview", Tag: "Curriculum", Summary: "What every class type is working on", Response: projections.CurriculumOverviewResult{}},
+	{Method: "GET", Path: "/api/curriculum/preview", Tag: "Curriculum", Summary: "Projected dates for the next topics in each theme of a class type's active rotor", Query: []openapi.Param{{Name: "class_type_id", Required: true}, {Name: "topics", Description: "upcoming topics per theme, 1-12; default 4"}}, Response: projections.RotorPreviewResult{}},
+	{Method: "GET", Path: "/api/curriculum/coverage", Tag: "Curriculum", Summary: "Attendance headcount per topic of a rotor, with topics that reached nobody", Query: []openapi.Param{{Name: "rotor_id", Required: true}}, Response: projections.TopicCoverageResult{}},
+	{Method: "GET", Path: "/api/curriculum/history", Tag: "Curriculum", Summary: "Completed topics of a class with clips, shared session notes and which classes you attended", Query: []openapi.Param{{Name: "class_type_id", Required: true}, {Name: "from", Description: "YYYY-MM-DD; defaults to 90 days ago"}}, Response: projections.CurriculumHistoryResult{}},
+	{Method: "GET", Path: "/api/session-logs", Tag: "Curriculum", Summary: "Session log history, or one log by id", Query: []openapi.Param{{Name: "id"}, {Name: "schedule_id"}, {Name: "topic_id"}, {Name: "from"}, {Name: "to"}, {Name: "limit"}}, Response: []projections.SessionLogHistoryEntry{}},
+	{Method: "POST", Path: "/api/session-logs", Tag: "Curriculum", Summary: "Save the log for a class session", Request: sessionLogCreateRequest{}, Response: sessionLogDomain.SessionLog{}},
+	{Method: "DELETE", Path: "/api/session-logs", Tag: "Curriculum", Summary: "Delete a session log", Query: []openapi.Param{queryID}},
+
+	// Calendar
+	{Method: "GET", Path: "/api/calendar/events", Tag: "Calendar", Summary: "Club events in a date range", Query: []openapi.Param{{Name: "from", Required: true, Description: "YYYY-MM-DD"}, {Name: "to", Required: true, Description: "YYYY-MM-DD"}}, Response: []calendarDomain.Event{}},
+	{Method: "POST", Path: "/api/calendar/events", Tag: "Calendar", Summary: "Add an event", Request: calendarEventCreateRequest{}, Response: calendarDomain.Event{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/calendar/events", Tag: "Calendar", Summary: "Delete an event", Query: []openapi.Param{queryID}},
+	{Method: "GET", Path: "/api/calendar/interest", Tag: "Calendar", Summary: "Who is interested in a competition", Query: []openapi.Param{{Name: "event_id", Required: true}}, Response: []competitionInterestEntry{}},
+	{Method: "POST", Path: "/api/calendar/interest", Tag: "Calendar", Summary: "Register interest in a competition", Request: competitionInterestRequest{}, Response: map[string]string{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/calendar/interest", Tag: "Calendar", Summary: "Withdraw interest", Query: []openapi.Param{{Name: "event_id", Required: true}}},
+	{Method: "GET", Path: "/api/calendar/interest/summary", Tag: "Calendar", Summary: "Interest counts and your own response", Query: []openapi.Param{{Name: "event_id", Required: true}}, Response: competitionInterestSummary{}},
+	{Method: "GET", Path: "/api/calendar/roster", Tag: "Calendar", Summary: "Competition roster", Query: []openapi.Param{{Name: "event_id", Required: true}, {Name: "format", Description: "json (default) or csv"}}, Response: projections.CompetitionRosterResult{}},
+	{Method: "GET", Path: "/api/calendar/rotors", Tag: "Calendar", Summary: "Upcoming curriculum topics as calendar entries", Response: []rotorCalendarDTO{}},
+
+	// Locations
+	{Method: "GET", Path: "/api/locations", Tag: "Locations", Summary: "List locations", Response: []locationDomain.Location{}},
+	{Method: "POST", Path: "/api/locations", Tag: "Locations", Summary: "Create or update a location (admin); 200 when updating", Request: locationSaveRequest{}, Response: locationDomain.Location{}, Status: http.StatusCreated},
+	{Method: "DELETE", Path: "/api/locations", Tag: "Locations", Summary: "Delete a location", Query: []openapi.Param{queryID}},
+	{Method: "POST", Path: "/api/locations/assign", Tag: "Locations", Summary: "Set an account's home location", Request: locationAssignRequest{}, Response: map[string]string{}},
+
+	// Email
+	{Method: "GET", Path: "/api/emails", Tag: "Email", Summary: "List emails", Query: []openapi.Param{{Name: "status"}, {Name: "q"}}, Response: []emailDomain.Email{}},
+	{Method: "GET", Path: "/api/emails/detail", Tag: "Email", Summary: "An email with its recipients", Query: []openapi.Param{queryID}, Response: jsonObject{}},
+	{Method: "GET", Path: "/api/emails/recipients/export", Tag: "Email", Summary: "Download an email's recipients and delivery status as CSV or XLSX (admin)", Query: []openapi.Param{{Name: "email_id", Required: true}, {Name: "format", Description: "csv (default) or xlsx"}}, ResponseType: "text/csv"},
+	{Method: "POST", Path: "/api/emails/compose", Tag: "Email", Summary: "Save a draft", Request: emailComposeRequest{}, Response: emailDomain.Email{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/emails/send", Tag: "Email", Summary: "Send a draft now", Request: emailIDRequest{}, Response: emailDomain.Email{}},
+	{Method: "POST", Path: "/api/emails/test-send", Tag: "Email", Summary: "Send a draft to a test address", Request: emailTestSendRequest{}, Response: map[string]string{}},
+	{Method: "POST", Path: "/api/emails/schedule", Tag: "Email", Summary: "Schedule a draft", Request: emailScheduleRequest{}, Response: emailDomain.Email{}},
+	{Method: "POST", Path: "/api/emails/cancel", Tag: "Email", Summary: "Cancel a scheduled email", Request: emailIDRequest{}, Response: emailDomain.Email{}},
+	{Method: "POST", Path: "/api/emails/reschedule", Tag: "Email", Summary: "Move a scheduled email", Request: emailScheduleRequest{}, Response: emailDomain.Email{}},
+	{Method: "DELETE", Path: "/api/emails/delete", Tag: "Email", Summary: "Delete a draft", Query: []openapi.Param{queryID}},
+	{Method: "GET", Path: "/api/emails/suppressions", Tag: "Email", Summary: "Addresses that bounced or complained", Response: []emailDomain.Suppression{}},
+	{Method: "DELETE", Path: "/api/emails/suppressions", Tag: "Email", Summary: "Lift a suppression", Query: []openapi.Param{{Name: "address", Required: true}}},
+	{Method: "GET", Path: "/api/emails/contact-hygiene", Tag: "Email", Summary: "Bad contact details: members whose email bounced, failed or is suppressed", Response: []projections.ContactHygieneMember{}},
+	{Method: "POST", Path: "/api/emails/contact-hygiene", Tag: "Email", Summary: "Ask members to confirm their email at their next kiosk check-in", Request: contactHygieneRequest{}, Response: contactHygieneRequestResponse{}},
+	{Method: "GET", Path: "/api/emails/unsubscribes", Tag: "Email", Summary: "Members opted out of each email category", Response: map[string]int{}},
+	{Method: "GET", Path: "/api/emails/recipients/search", Tag: "Email", Summary: "Find recipients by name", Query: []openapi.Param{{Name: "q", Required: true}}, Response: []memberResult{}},
+	{Method: "GET", Path: "/api/emails/recipients/filter", Tag: "Email", Summary: "Active recipients in a program or carrying a tag", Query: []openapi.Param{{Name: "program"}, {Name: "tag", Description: "only members carrying this tag"}, {Name: "segment", Description: "only members of this saved segment"}}, Response: []memberResult{}},
+	{Method: "GET", Path: "/api/emails/recipients/by-session", Tag: "Email", Summary: "Recipients who attended a session", Query: []openapi.Param{{Name: "scheduleID", Required: true}, {Name: "date", Required: true}}, Response: []memberResult{}},
+	{Method: "GET", Path: "/api/emails/recipients/by-class-type", Tag: "Email", Summary: "Recipients who recently attended a class type", Query: []openapi.Param{{Name: "classTypeID", Required: true}, {Name: "days"}}, Response: []memberResult{}},
+	{Method: "GET", Path: "/api/emails/recipients/by-segment", Tag: "Email", Summary: "Recipients in a saved segment (admin)", Query: []openapi.Param{{Name: "segmentID", Required: true}}, Response: []memberResult{}},
+	{Method: "GET", Path: "/api/emails/template", Tag: "Email", Summary: "The active header and footer", Response: emailDomain.EmailTemplate{}},
+	{Method: "POST", Path: "/api/emails/template", Tag: "Email", Summary: "Replace the active header and footer", Request: emailTemplateRequest{}, Response: emailDomain.EmailTemplate{}},
+	{Method: "POST", Path: "/api/emails/preview", Tag: "Email", Summary: "Render a body inside the active template", Request: emailPreviewRequest{}, Response: map[string]string{}},
+	{Method: "GET", Path: "/api/emails/library", Tag: "Email", Summary: "The template library and its merge variables", Response: map[string]any{}},
+	{Method: "POST", Path: "/api/emails/library", Tag: "Email", Summary: "Save a library template", Request: emailLibraryRequest{}, Response: emailDomain.MessageTemplate{}},
+	{Method: "DELETE", Path: "/api/emails/library", Tag: "Email", Summary: "Remove a library template, or restore a built-in's wording", Query: []openapi.Param{{Name: "key", Required: true}}},
+	{Method: "POST", Path: "/api/emails/library/preview", Tag: "Email", Summary: "Render a library template with sample member data", Request: emailLibraryPreviewRequest{}, Response: map[string]string{}},
+	{Method: "POST", Path: "/api/webhooks/resend", Tag: "Email", Summary: "Delivery events from Resend (signed, no session)", RequestType: "application/json"},
+}
+
+// apiInfo describes the API in the generated document.
+var apiInfo = openapi.Info{
+	Title:       "Workshop API",
+	Version:     "1",
+	Description: "JSON API behind the Workshop web app. Requests are authenticated by the session cookie set at /login. JSON bodies are exempt from CSRF checks; form and multipart uploads need the CSRF token. Failures return the apierror body.",
+}
+
+// OpenAPIDocument returns the OpenAPI document for every /api route, indented.
+// PRE: none
+// POST: Returns the same bytes on every call, or the registry error
+func OpenAPIDocument() ([]byte, error) {
+	return openAPISpec()
+}
+
+// openAPISpec builds the document once; the registry is fixed at compile time.
+var openAPISpec = sync.OnceValues(func() ([]byte, error) {
+	doc, err := openapi.Build(apiInfo, apiOperations)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+})
@@ -0,0 +1,217 @@
+// Package openapi builds an OpenAPI 3 document from a registry of API operations.
+//
+// Each Operation names its method and path and carries zero values of its JSON
+// request and response types; their schemas are reflected from the Go types using
+// the same field rules as encoding/json. Named struct types become shared
+// components, and every operation documents the apierror body for failures.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"workshop/internal/adapters/http/apierror"
+)
+
+// Version is the OpenAPI version of generated documents.
+const Version = "3.0.3"
+
+// Operation documents one method on one API path.
+type Operation struct {
+	Method       string  // GET, POST, PUT, PATCH or DELETE
+	Path         string  // mux pattern; {name} segments become path parameters
+	Tag          string  // groups operations in the docs
+	Summary      string  // one line describing what the operation does
+	Query        []Param // query string parameters
+	Request      any     // zero value of the JSON request body; nil when there is none
+	RequestType  string  // content type of a non-JSON request body, e.g. multipart/form-data
+	Response     any     // zero value of the JSON response body; nil when there is none
+	ResponseType string  // content type of a non-JSON response, e.g. text/csv
+	Status       int     // success status; 0 means 200, or 204 when there is no body
+}
+
+// Param documents a query string parameter.
+type Param struct {
+	Name        string
+	Description string
+	Required    bool
+}
+
+// Info describes the API as a whole.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Document is an OpenAPI 3 document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// PathItem maps a lower-case HTTP method to its operation.
+type PathItem map[string]*OperationObject
+
+// OperationObject is the OpenAPI form of an Operation.
+type OperationObject struct {
+	Tags        []string            `json:"tags,omitempty"`
+	Summary     string              `json:"summary,omitempty"`
+	OperationID string              `json:"operationId"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter is a path or query parameter.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes the body an operation accepts.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes one response status of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType pairs a content type with the schema of its body.
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Components holds the schemas shared between operations.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is the subset of JSON Schema used by generated documents.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+var methods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+var pathParam = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
+// Build returns the OpenAPI document for ops.
+// PRE: each operation has a supported Method and a Path starting with /
+// POST: Returns an error naming the first invalid or duplicate operation
+func Build(info Info, ops []Operation) (Document, error) {
+	doc := Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]PathItem),
+	}
+	reflector := newReflector()
+	errorBody := reflector.schemaOf(apierror.Response{})
+
+	for _, op := range ops {
+		if !methods[op.Method] {
+			return Document{}, fmt.Errorf("%s %s: unsupported method", op.Method, op.Path)
+		}
+		if !strings.HasPrefix(op.Path, "/") {
+			return Document{}, fmt.Errorf("%s %s: path must start with /", op.Method, op.Path)
+		}
+		item := doc.Paths[op.Path]
+		if item == nil {
+			item = make(PathItem)
+			doc.Paths[op.Path] = item
+		}
+		key := strings.ToLower(op.Method)
+		if item[key] != nil {
+			return Document{}, fmt.Errorf("%s %s: documented twice", op.Method, op.Path)
+		}
+
+		obj := &OperationObject{
+			Summary:     op.Summary,
+			OperationID: operationID(op.Method, op.Path),
+			Responses: map[string]Response{
+				"default": {Description: "Error", Content: jsonContent(errorBody)},
+			},
+		}
+		if op.Tag != "" {
+			obj.Tags = []string{op.Tag}
+		}
+		for _, m := range pathParam.FindAllStringSubmatch(op.Path, -1) {
+			obj.Parameters = append(obj.Parameters, Parameter{Name: m[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+		for _, q := range op.Query {
+			obj.Parameters = append(obj.Parameters, Parameter{Name: q.Name, In: "query", Description: q.Description, Required: q.Required, Schema: &Schema{Type: "string"}})
+		}
+
+		switch {
+		case op.Request != nil:
+			obj.RequestBody = &RequestBody{Required: true, Content: jsonContent(reflector.schemaOf(op.Request))}
+		case op.RequestType != "":
+			obj.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{op.RequestType: {}}}
+		}
+
+		status := op.Status
+		var success Response
+		switch {
+		case op.Response != nil:
+			success = Response{Description: "OK", Content: jsonContent(reflector.schemaOf(op.Response))}
+		case op.ResponseType != "":
+			success = Response{Description: "OK", Content: map[string]MediaType{op.ResponseType: {}}}
+		default:
+			success = Response{Description: "No Content"}
+			if status == 0 {
+				status = http.StatusNoContent
+			}
+		}
+		if status == 0 {
+			status = http.StatusOK
+		}
+		success.Description = http.StatusText(status)
+		obj.Responses[fmt.Sprint(status)] = success
+		item[key] = obj
+	}
+
+	doc.Components.Schemas = reflector.components
+	return doc, nil
+}
+
+func jsonContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: s}}
+}
+
+// operationID derives a stable camelCase ID, e.g. POST /api/clips/{clipID}/tags
+// becomes postClipsClipIDTags.
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, seg := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '-' || r == '_' }) {
+		seg = strings.Trim(seg, "{}")
+		if seg == "" || seg == "api" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(seg[:1]) + seg[1:])
+	}
+	return b.String()
+}
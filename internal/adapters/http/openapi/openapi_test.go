@@ -0,0 +1,195 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/openapi"
+)
+
+type widget struct {
+	ID       string   `json:"id"`
+	Count    int      `json:"count,omitempty"`
+	Big      int64    `json:"big,string"`
+	Price    float64  `json:"price"`
+	Active   bool     `json:"active"`
+	Tags     []string `json:"tags"`
+	Labels   map[string]int
+	Note     *string   `json:"note"`
+	Created  time.Time `json:"created"`
+	Parent   *widget   `json:"parent"`
+	Secret   string    `json:"-"`
+	internal string
+}
+
+type named struct {
+	Name string
+}
+
+type withEmbedded struct {
+	named
+	Name  int // shadows the promoted Name
+	Extra string
+}
+
+func build(t *testing.T, ops ...openapi.Operation) openapi.Document {
+	t.Helper()
+	doc, err := openapi.Build(openapi.Info{Title: "Test", Version: "1"}, ops)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	return doc
+}
+
+// TestBuild_ReflectsStructs verifies field naming, types and component references.
+func TestBuild_ReflectsStructs(t *testing.T) {
+	doc := build(t, openapi.Operation{Method: "GET", Path: "/api/widgets", Response: []widget{}})
+
+	op := doc.Paths["/api/widgets"]["get"]
+	if op == nil {
+		t.Fatal("GET /api/widgets missing")
+	}
+	list := op.Responses["200"].Content["application/json"].Schema
+	if list.Type != "array" || list.Items.Ref != "#/components/schemas/openapi_test.widget" {
+		t.Fatalf("unexpected list schema: %+v", list)
+	}
+
+	props := doc.Components.Schemas["openapi_test.widget"].Properties
+	tests := []struct {
+		field  string
+		typ    string
+		format string
+	}{
+		{field: "id", typ: "string"},
+		{field: "count", typ: "integer"},
+		{field: "big", typ: "string"},
+		{field: "price", typ: "number"},
+		{field: "active", typ: "boolean"},
+		{field: "tags", typ: "array"},
+		{field: "Labels", typ: "object"},
+		{field: "note", typ: "string"},
+		{field: "created", typ: "string", format: "date-time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			s := props[tt.field]
+			if s == nil {
+				t.Fatalf("field %q missing", tt.field)
+			}
+			if s.Type != tt.typ || s.Format != tt.format {
+				t.Errorf("expected %s/%s, got %s/%s", tt.typ, tt.format, s.Type, s.Format)
+			}
+		})
+	}
+	if !props["note"].Nullable {
+		t.Error("pointer field should be nullable")
+	}
+	if props["parent"].Ref != "#/components/schemas/openapi_test.widget" {
+		t.Errorf("self reference should use a $ref, got %+v", props["parent"])
+	}
+	for _, hidden := range []string{"Secret", "internal"} {
+		if _, ok := props[hidden]; ok {
+			t.Errorf("field %q should not be documented", hidden)
+		}
+	}
+}
+
+// TestBuild_EmbeddedFields verifies promoted fields and shadowing follow encoding/json.
+func TestBuild_EmbeddedFields(t *testing.T) {
+	doc := build(t, openapi.Operation{Method: "POST", Path: "/api/things", Request: withEmbedded{}})
+
+	props := doc.Components.Schemas["openapi_test.withEmbedded"].Properties
+	if props["Name"].Type != "integer" {
+		t.Errorf("outer Name should shadow the promoted one, got %+v", props["Name"])
+	}
+	if props["Extra"] == nil {
+		t.Error("Extra missing")
+	}
+	if _, ok := doc.Components.Schemas["openapi_test.named"]; ok {
+		t.Error("embedded struct should be flattened, not referenced")
+	}
+}
+
+// TestBuild_Responses verifies success statuses, parameters and the shared error body.
+func TestBuild_Responses(t *testing.T) {
+	doc := build(t,
+		openapi.Operation{Method: "DELETE", Path: "/api/widgets", Query: []openapi.Param{{Name: "id", Required: true}}},
+		openapi.Operation{Method: "POST", Path: "/api/widgets", Request: struct{ Name string }{}, Response: widget{}, Status: 201},
+		openapi.Operation{Method: "GET", Path: "/api/widgets/export", ResponseType: "text/csv"},
+		openapi.Operation{Method: "POST", Path: "/api/clips/{clipID}/tags", RequestType: "multipart/form-data"},
+	)
+
+	tests := []struct {
+		name   string
+		path   string
+		method string
+		status string
+	}{
+		{name: "no body is 204", path: "/api/widgets", method: "delete", status: "204"},
+		{name: "explicit 201", path: "/api/widgets", method: "post", status: "201"},
+		{name: "non-JSON body is 200", path: "/api/widgets/export", method: "get", status: "200"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := doc.Paths[tt.path][tt.method]
+			if _, ok := op.Responses[tt.status]; !ok {
+				t.Errorf("expected status %s, got %v", tt.status, op.Responses)
+			}
+			errBody := op.Responses["default"].Content["application/json"].Schema
+			if errBody.Ref != "#/components/schemas/apierror.Response" {
+				t.Errorf("expected apierror body on default response, got %+v", errBody)
+			}
+		})
+	}
+
+	del := doc.Paths["/api/widgets"]["delete"]
+	if len(del.Parameters) != 1 || del.Parameters[0].In != "query" || !del.Parameters[0].Required {
+		t.Errorf("unexpected query parameters: %+v", del.Parameters)
+	}
+	tags := doc.Paths["/api/clips/{clipID}/tags"]["post"]
+	if len(tags.Parameters) != 1 || tags.Parameters[0].Name != "clipID" || tags.Parameters[0].In != "path" {
+		t.Errorf("expected clipID path parameter, got %+v", tags.Parameters)
+	}
+	if tags.OperationID != "postClipsClipIDTags" {
+		t.Errorf("unexpected operationId %q", tags.OperationID)
+	}
+	if _, ok := tags.RequestBody.Content["multipart/form-data"]; !ok {
+		t.Errorf("expected multipart request body, got %+v", tags.RequestBody)
+	}
+}
+
+// TestBuild_RejectsInvalidOperations verifies the registry is checked.
+func TestBuild_RejectsInvalidOperations(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  []openapi.Operation
+		want string
+	}{
+		{name: "unknown method", ops: []openapi.Operation{{Method: "FETCH", Path: "/api/x"}}, want: "unsupported method"},
+		{name: "relative path", ops: []openapi.Operation{{Method: "GET", Path: "api/x"}}, want: "must start with /"},
+		{name: "duplicate", ops: []openapi.Operation{{Method: "GET", Path: "/api/x"}, {Method: "GET", Path: "/api/x"}}, want: "documented twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := openapi.Build(openapi.Info{}, tt.ops)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestBuild_Deterministic verifies the encoded document is stable between builds.
+func TestBuild_Deterministic(t *testing.T) {
+	ops := []openapi.Operation{
+		{Method: "GET", Path: "/api/widgets", Response: []widget{}},
+		{Method: "POST", Path: "/api/things", Request: withEmbedded{}},
+	}
+	first, _ := json.Marshal(build(t, ops...))
+	second, _ := json.Marshal(build(t, ops...))
+	if string(first) != string(second) {
+		t.Error("encoding differs between builds")
+	}
+}
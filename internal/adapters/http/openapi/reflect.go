@@ -0,0 +1,165 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// invalidComponentChars matches characters OpenAPI does not allow in component names
+// (generic type arguments, mostly).
+var invalidComponentChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// reflector turns Go types into schemas, collecting named structs as components.
+type reflector struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+	taken      map[string]reflect.Type
+}
+
+func newReflector() *reflector {
+	return &reflector{
+		components: make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+		taken:      make(map[string]reflect.Type),
+	}
+}
+
+func (g *reflector) schemaOf(v any) *Schema {
+	return g.schema(reflect.TypeOf(v))
+}
+
+func (g *reflector) schema(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		s := g.schema(t.Elem())
+		if s.Ref == "" {
+			s.Nullable = true
+		}
+		return s
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		// A custom encoding can produce anything.
+		return &Schema{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + g.component(t)}
+	default:
+		// Interfaces, and anything else encoding/json would reject.
+		return &Schema{}
+	}
+}
+
+// component registers a named struct type and returns its component name.
+func (g *reflector) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	base := invalidComponentChars.ReplaceAllString(path.Base(t.PkgPath())+"."+t.Name(), "_")
+	name := base
+	for i := 2; g.taken[name] != nil; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	g.names[t] = name
+	g.taken[name] = t
+	// Reserve the name before reflecting fields so self-referencing types terminate.
+	g.components[name] = &Schema{}
+	*g.components[name] = *g.object(t)
+	return name
+}
+
+// object reflects a struct's fields the way encoding/json encodes them.
+func (g *reflector) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.fields(t, s.Properties)
+	if len(s.Properties) == 0 {
+		s.Properties = nil
+	}
+	return s
+}
+
+// fields adds t's encoded fields to props. Fields promoted from embedded structs
+// are added after t's own, so the shallower field wins a name clash as in encoding/json.
+func (g *reflector) fields(t reflect.Type, props map[string]*Schema) {
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if strings.Contains(","+opts+",", ",string,") {
+			props[name] = &Schema{Type: "string"}
+			continue
+		}
+		props[name] = g.schema(f.Type)
+	}
+	for _, ft := range embedded {
+		promoted := make(map[string]*Schema)
+		g.fields(ft, promoted)
+		for name, s := range promoted {
+			if _, exists := props[name]; !exists {
+				props[name] = s
+			}
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"workshop/internal/domain/perfstats"
 )
 
 // DefaultRingSize is the default capacity of the ring buffer.
@@ -23,7 +25,10 @@ const (
 type Entry struct {
 	Kind       EntryKind
 	Path       string // HTTP path or "store.Method"
+	Method     string // HTTP method (requests only)
+	Route      string // matched route pattern (requests only); bounds metric cardinality
 	StatusCode int    // HTTP status (0 for queries)
+	RequestID  string // request that made the entry; empty for queries run by workers
 	DurationMs float64
 	Timestamp  time.Time
 }
@@ -37,6 +42,13 @@ type Collector struct {
 	size    int
 	pos     int
 	count   int64 // total entries ever written (atomic for stats)
+
+	requests *histogramSet // lifetime request latency for /metrics
+	queries  *histogramSet // lifetime query latency for /metrics
+	minutes  *bucketSet    // per-minute timings waiting to be persisted
+
+	planMu sync.Mutex
+	plans  map[string]*QueryPlan // full scans flagged by the query plan guard, by SQL
 }
 
 // NewCollector creates a collector with the given ring buffer capacity.
@@ -47,12 +59,17 @@ func NewCollector(size int) *Collector {
 		size = DefaultRingSize
 	}
 	return &Collector{
-		entries: make([]Entry, size),
-		size:    size,
+		entries:  make([]Entry, size),
+		size:     size,
+		requests: newHistogramSet(DefaultBuckets),
+		queries:  newHistogramSet(DefaultBuckets),
+		minutes:  newBucketSet(),
+		plans:    make(map[string]*QueryPlan),
 	}
 }
 
-// Record appends an entry to the ring buffer.
+// Record appends an entry to the ring buffer, its latency histogram and its minute bucket.
+// Entries without a timestamp are left out of the minute buckets.
 // PRE: e is a valid Entry
 // POST: Entry stored; if buffer full, oldest entry overwritten
 // Lock hold time: single index increment + struct copy (~nanoseconds).
@@ -62,6 +79,43 @@ func (c *Collector) Record(e Entry) {
 	c.pos = (c.pos + 1) % c.size
 	c.mu.Unlock()
 	atomic.AddInt64(&c.count, 1)
+
+	seconds := e.DurationMs / 1000
+	switch e.Kind {
+	case KindRequest:
+		c.requests.observe(seriesKey{method: e.Method, route: e.Route, status: e.StatusCode}, seconds)
+		if !e.Timestamp.IsZero() {
+			c.minutes.observe(KindRequest, e.Method, e.Route, e.Timestamp, e.DurationMs)
+		}
+	case KindQuery:
+		c.queries.observe(seriesKey{op: e.Path}, seconds)
+		if !e.Timestamp.IsZero() {
+			c.minutes.observe(KindQuery, "", e.Path, e.Timestamp, e.DurationMs)
+		}
+	}
+}
+
+// TakeBuckets removes and returns the minute buckets that ended at or before cutoff, oldest
+// first. Pass the current time to take only finished minutes, or a later time at shutdown
+// to take the minute in progress too.
+// PRE: none
+// POST: The returned buckets are no longer held by the collector
+func (c *Collector) TakeBuckets(cutoff time.Time) []perfstats.Bucket {
+	return c.minutes.take(cutoff)
+}
+
+// RequestHistograms returns lifetime request latency per method, route and status.
+// PRE: none
+// POST: Returns cumulative samples sorted by route, method and status
+func (c *Collector) RequestHistograms() []HistogramSample {
+	return c.requests.snapshot()
+}
+
+// QueryHistograms returns lifetime query latency per TimedDB operation.
+// PRE: none
+// POST: Returns cumulative samples sorted by operation
+func (c *Collector) QueryHistograms() []HistogramSample {
+	return c.queries.snapshot()
 }
 
 // TotalRecorded returns the total number of entries ever recorded.
@@ -160,6 +214,23 @@ func (c *Collector) Snapshot(since time.Time, topN int) Snapshot {
 	return snap
 }
 
+// Trace returns the entries still in the ring buffer that were recorded for one request,
+// oldest first: its queries, then the request itself once it finished.
+// PRE: requestID is non-empty
+// POST: Returns nil when no entry carries the ID
+func (c *Collector) Trace(requestID string) []Entry {
+	c.mu.Lock()
+	var out []Entry
+	for i := 0; i < c.size; i++ {
+		e := c.entries[(c.pos+i)%c.size]
+		if e.RequestID == requestID && !e.Timestamp.IsZero() {
+			out = append(out, e)
+		}
+	}
+	c.mu.Unlock()
+	return out
+}
+
 // percentile returns the p-th percentile from a sorted slice.
 func percentile(sorted []float64, p float64) float64 {
 	if len(sorted) == 0 {
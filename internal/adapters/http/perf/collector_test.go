@@ -332,3 +332,21 @@ func BenchmarkCollectorSnapshot(b *testing.B) {
 		c.Snapshot(since, 10)
 	}
 }
+
+// TestCollector_Trace verifies a request's entries are returned oldest first and others are left out.
+func TestCollector_Trace(t *testing.T) {
+	c := NewCollector(3)
+	now := time.Now()
+	c.Record(Entry{Kind: KindQuery, Path: "QueryContext", RequestID: "gone", Timestamp: now})
+	c.Record(Entry{Kind: KindQuery, Path: "QueryContext", RequestID: "abc", Timestamp: now})
+	c.Record(Entry{Kind: KindQuery, Path: "ExecContext", RequestID: "other", Timestamp: now})
+	c.Record(Entry{Kind: KindRequest, Path: "POST /api/x", RequestID: "abc", Timestamp: now})
+
+	trace := c.Trace("abc")
+	if len(trace) != 2 || trace[0].Kind != KindQuery || trace[1].Kind != KindRequest {
+		t.Errorf("Trace = %+v, want the query then the request", trace)
+	}
+	if got := c.Trace("gone"); got != nil {
+		t.Errorf("overwritten entry still traced: %+v", got)
+	}
+}
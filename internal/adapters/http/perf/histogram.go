@@ -0,0 +1,100 @@
+package perf
+
+import (
+	"sort"
+	"sync"
+)
+
+// DefaultBuckets are the histogram upper bounds in seconds, from 1ms to 10s.
+var DefaultBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// seriesKey identifies one labelled histogram series.
+// Comparable so map lookups on the hot path do not allocate.
+type seriesKey struct {
+	method string
+	route  string
+	status int
+	op     string
+}
+
+// series holds the per-bucket counts of one histogram series.
+type series struct {
+	buckets []uint64 // non-cumulative count per bucket; last slot is +Inf
+	count   uint64
+	sum     float64
+}
+
+// histogramSet accumulates latency histograms for the process lifetime.
+// Unlike the ring buffer it never forgets, which is what Prometheus counters require.
+type histogramSet struct {
+	mu     sync.Mutex
+	bounds []float64
+	series map[seriesKey]*series
+}
+
+// newHistogramSet creates an empty set using the given bucket bounds.
+func newHistogramSet(bounds []float64) *histogramSet {
+	return &histogramSet{bounds: bounds, series: make(map[seriesKey]*series)}
+}
+
+// observe adds one duration (in seconds) to the series for key.
+// Allocates only the first time a series is seen.
+func (h *histogramSet) observe(key seriesKey, seconds float64) {
+	i := sort.SearchFloat64s(h.bounds, seconds)
+	h.mu.Lock()
+	s, ok := h.series[key]
+	if !ok {
+		s = &series{buckets: make([]uint64, len(h.bounds)+1)}
+		h.series[key] = s
+	}
+	s.buckets[i]++
+	s.count++
+	s.sum += seconds
+	h.mu.Unlock()
+}
+
+// HistogramSample is a cumulative histogram series ready for exposition.
+type HistogramSample struct {
+	Method     string    // request histograms only
+	Route      string    // request histograms only: the matched mux pattern
+	StatusCode int       // request histograms only
+	Op         string    // query histograms only: the TimedDB operation
+	Bounds     []float64 // bucket upper bounds in seconds
+	Cumulative []uint64  // observations <= each bound, same length as Bounds
+	Count      uint64
+	Sum        float64 // seconds
+}
+
+// snapshot copies every series with cumulative bucket counts, in a stable order.
+func (h *histogramSet) snapshot() []HistogramSample {
+	h.mu.Lock()
+	out := make([]HistogramSample, 0, len(h.series))
+	for k, s := range h.series {
+		cumulative := make([]uint64, len(h.bounds))
+		var running uint64
+		for i := range h.bounds {
+			running += s.buckets[i]
+			cumulative[i] = running
+		}
+		out = append(out, HistogramSample{
+			Method: k.method, Route: k.route, StatusCode: k.status, Op: k.op,
+			Bounds: h.bounds, Cumulative: cumulative, Count: s.count, Sum: s.sum,
+		})
+	}
+	h.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Route != b.Route {
+			return a.Route < b.Route
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		if a.StatusCode != b.StatusCode {
+			return a.StatusCode < b.StatusCode
+		}
+		return a.Op < b.Op
+	})
+	return out
+}
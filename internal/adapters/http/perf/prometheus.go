@@ -0,0 +1,90 @@
+package perf
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Label is one name="value" pair on a Prometheus sample.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is one labelled value of a gauge or counter.
+type Sample struct {
+	Labels []Label
+	Value  float64
+}
+
+// WriteMetric writes a gauge or counter family in the Prometheus text exposition format.
+// PRE: name is a valid metric name; kind is "gauge" or "counter"
+// POST: HELP, TYPE and one line per sample written to w
+func WriteMetric(w io.Writer, name, help, kind string, samples []Sample) error {
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind); err != nil {
+		return err
+	}
+	for _, s := range samples {
+		if _, err := fmt.Fprintf(w, "%s%s %s\n", name, formatLabels(s.Labels), formatFloat(s.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteHistogram writes a histogram family in the Prometheus text exposition format.
+// labels maps each sample to its identifying labels (excluding "le").
+// PRE: name is a valid metric name without the _bucket/_sum/_count suffix
+// POST: HELP, TYPE and bucket, sum and count lines for every sample written to w
+func WriteHistogram(w io.Writer, name, help string, samples []HistogramSample, labels func(HistogramSample) []Label) error {
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name); err != nil {
+		return err
+	}
+	for _, s := range samples {
+		base := labels(s)
+		for i, bound := range s.Bounds {
+			le := append(base[:len(base):len(base)], Label{Name: "le", Value: formatFloat(bound)})
+			if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n", name, formatLabels(le), s.Cumulative[i]); err != nil {
+				return err
+			}
+		}
+		inf := append(base[:len(base):len(base)], Label{Name: "le", Value: "+Inf"})
+		if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n%s_sum%s %s\n%s_count%s %d\n",
+			name, formatLabels(inf), s.Count,
+			name, formatLabels(base), formatFloat(s.Sum),
+			name, formatLabels(base), s.Count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// labelEscaper escapes label values as the exposition format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatLabels renders {a="1",b="2"}, or nothing when there are no labels.
+func formatLabels(labels []Label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, l := range labels {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(l.Name)
+		b.WriteString(`="`)
+		b.WriteString(labelEscaper.Replace(l.Value))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// formatFloat renders a value in the shortest form Prometheus parses back exactly.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,45 @@
+package perf
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestWriteHistogram verifies cumulative buckets, +Inf, sum and count lines.
+func TestWriteHistogram(t *testing.T) {
+	c := NewCollector(10)
+	for _, ms := range []float64{0.5, 20, 20000} {
+		c.Record(Entry{Kind: KindQuery, Path: "ExecContext", DurationMs: ms})
+	}
+
+	var b strings.Builder
+	err := WriteHistogram(&b, "db_seconds", "Query latency.", c.QueryHistograms(), func(s HistogramSample) []Label {
+		return []Label{{Name: "op", Value: s.Op}}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"# TYPE db_seconds histogram\n",
+		`db_seconds_bucket{op="ExecContext",le="0.001"} 1` + "\n",
+		`db_seconds_bucket{op="ExecContext",le="0.025"} 2` + "\n",
+		`db_seconds_bucket{op="ExecContext",le="10"} 2` + "\n",
+		`db_seconds_bucket{op="ExecContext",le="+Inf"} 3` + "\n",
+		`db_seconds_sum{op="ExecContext"} 20.0205` + "\n",
+		`db_seconds_count{op="ExecContext"} 3` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+// TestWriteMetric_EscapesLabels verifies label values are escaped.
+func TestWriteMetric_EscapesLabels(t *testing.T) {
+	var b strings.Builder
+	WriteMetric(&b, "up", "Up.", "gauge", []Sample{{Labels: []Label{{Name: "path", Value: "a\"b\\c\nd"}}, Value: 1}})
+	if want := `up{path="a\"b\\c\nd"} 1`; !strings.Contains(b.String(), want) {
+		t.Errorf("expected %q in %q", want, b.String())
+	}
+}
@@ -0,0 +1,65 @@
+package perf
+
+import (
+	"sort"
+	"time"
+)
+
+// MaxQueryPlans caps the distinct queries the collector keeps plan findings for.
+const MaxQueryPlans = 100
+
+// QueryPlan is a slow query whose plan scans a whole large table, found by the query plan guard.
+type QueryPlan struct {
+	Query      string // the SQL as the store sent it
+	Table      string
+	Rows       int64  // rows in the table when the plan was read
+	Detail     string // the EXPLAIN QUERY PLAN step, e.g. "SCAN members"
+	Suggestion string // a CREATE INDEX statement covering the filtered columns; empty when none were found
+	Count      int    // slow runs since the query was first flagged
+	MaxMs      float64
+	LastSeen   time.Time
+}
+
+// RecordPlan adds a slow run of a flagged query. The first run of a query stores its plan;
+// later runs only add to its count and maximum. Queries beyond MaxQueryPlans are dropped.
+// PRE: p.Query is non-empty; p.Count and p.MaxMs describe this run
+// POST: The finding for p.Query reflects this run
+func (c *Collector) RecordPlan(p QueryPlan) {
+	c.planMu.Lock()
+	defer c.planMu.Unlock()
+	existing, ok := c.plans[p.Query]
+	if !ok {
+		if len(c.plans) >= MaxQueryPlans {
+			return
+		}
+		p.Count = 0
+		existing = &p
+		c.plans[p.Query] = existing
+	}
+	existing.Count++
+	if p.MaxMs > existing.MaxMs {
+		existing.MaxMs = p.MaxMs
+	}
+	if p.LastSeen.After(existing.LastSeen) {
+		existing.LastSeen = p.LastSeen
+	}
+}
+
+// QueryPlans returns the flagged queries, the largest tables first.
+// PRE: none
+// POST: Returns copies; empty when the guard is off or found nothing
+func (c *Collector) QueryPlans() []QueryPlan {
+	c.planMu.Lock()
+	list := make([]QueryPlan, 0, len(c.plans))
+	for _, p := range c.plans {
+		list = append(list, *p)
+	}
+	c.planMu.Unlock()
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Rows != list[j].Rows {
+			return list[i].Rows > list[j].Rows
+		}
+		return list[i].Query < list[j].Query
+	})
+	return list
+}
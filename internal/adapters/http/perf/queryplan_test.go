@@ -0,0 +1,32 @@
+package perf
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestCollector_RecordPlan verifies repeat runs of a flagged query add to one finding, the
+// largest tables come first, and findings stop at MaxQueryPlans.
+func TestCollector_RecordPlan(t *testing.T) {
+	c := NewCollector(10)
+	at := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	c.RecordPlan(QueryPlan{Query: "SELECT * FROM member WHERE email = ?", Table: "member", Rows: 500, Count: 1, MaxMs: 80, LastSeen: at})
+	c.RecordPlan(QueryPlan{Query: "SELECT * FROM member WHERE email = ?", Table: "member", Rows: 500, Count: 1, MaxMs: 60, LastSeen: at.Add(time.Minute)})
+	c.RecordPlan(QueryPlan{Query: "SELECT * FROM attendance WHERE class_date = ?", Table: "attendance", Rows: 9000, Count: 1, MaxMs: 120, LastSeen: at})
+
+	plans := c.QueryPlans()
+	if len(plans) != 2 || plans[0].Table != "attendance" {
+		t.Fatalf("plans = %+v, want attendance first", plans)
+	}
+	if p := plans[1]; p.Count != 2 || p.MaxMs != 80 || !p.LastSeen.Equal(at.Add(time.Minute)) {
+		t.Errorf("member finding = %+v, want 2 runs, max 80ms, last seen a minute later", p)
+	}
+
+	for i := 0; i < MaxQueryPlans; i++ {
+		c.RecordPlan(QueryPlan{Query: fmt.Sprintf("SELECT %d", i), Count: 1})
+	}
+	if got := len(c.QueryPlans()); got != MaxQueryPlans {
+		t.Errorf("kept %d findings, want %d", got, MaxQueryPlans)
+	}
+}
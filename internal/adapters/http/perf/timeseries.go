@@ -0,0 +1,77 @@
+package perf
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"workshop/internal/domain/perfstats"
+)
+
+// bucketKey identifies one series in one minute.
+// Method and route are kept apart so lookups on the hot path do not build a string.
+type bucketKey struct {
+	kind   EntryKind
+	method string // requests only
+	name   string // route pattern or TimedDB operation
+	minute int64  // unix seconds of the minute start
+}
+
+// bucketSet accumulates minute buckets until they are flushed to storage.
+type bucketSet struct {
+	mu      sync.Mutex
+	buckets map[bucketKey]*perfstats.Bucket
+}
+
+// newBucketSet creates an empty set.
+func newBucketSet() *bucketSet {
+	return &bucketSet{buckets: make(map[bucketKey]*perfstats.Bucket)}
+}
+
+// observe adds one entry to its minute bucket; method is empty for queries.
+// Allocates only the first time a series is seen in a minute.
+func (s *bucketSet) observe(kind EntryKind, method, name string, at time.Time, durationMs float64) {
+	minute := at.Truncate(perfstats.BucketWidth).Unix()
+	i := sort.SearchFloat64s(perfstats.LatencyBoundsMs, durationMs)
+	key := bucketKey{kind: kind, method: method, name: name, minute: minute}
+	s.mu.Lock()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &perfstats.Bucket{Kind: perfstats.KindQuery, Name: name, Minute: time.Unix(minute, 0).UTC(), Counts: perfstats.NewCounts()}
+		if kind == KindRequest {
+			b.Kind, b.Name = perfstats.KindRequest, method+" "+name
+		}
+		s.buckets[key] = b
+	}
+	b.Counts[i]++
+	b.Count++
+	b.SumMs += durationMs
+	if durationMs > b.MaxMs {
+		b.MaxMs = durationMs
+	}
+	s.mu.Unlock()
+}
+
+// take removes and returns every bucket whose minute ended at or before cutoff, oldest first.
+func (s *bucketSet) take(cutoff time.Time) []perfstats.Bucket {
+	var out []perfstats.Bucket
+	s.mu.Lock()
+	for key, b := range s.buckets {
+		if !b.Minute.Add(perfstats.BucketWidth).After(cutoff) {
+			out = append(out, *b)
+			delete(s.buckets, key)
+		}
+	}
+	s.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if !a.Minute.Equal(b.Minute) {
+			return a.Minute.Before(b.Minute)
+		}
+		if a.Kind != b.Kind {
+			return a.Kind > b.Kind // requests before queries
+		}
+		return a.Name < b.Name
+	})
+	return out
+}
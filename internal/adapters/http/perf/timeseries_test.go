@@ -0,0 +1,39 @@
+package perf
+
+import (
+	"testing"
+	"time"
+
+	"workshop/internal/domain/perfstats"
+)
+
+// TestTakeBuckets verifies entries are grouped per minute and series, and only finished
+// minutes are taken unless the cutoff is later.
+func TestTakeBuckets(t *testing.T) {
+	c := NewCollector(100)
+	minute := time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+
+	c.Record(Entry{Kind: KindRequest, Method: "GET", Route: "/api/members", DurationMs: 4, Timestamp: minute.Add(5 * time.Second)})
+	c.Record(Entry{Kind: KindRequest, Method: "GET", Route: "/api/members", DurationMs: 12, Timestamp: minute.Add(50 * time.Second)})
+	c.Record(Entry{Kind: KindQuery, Path: "QueryContext", DurationMs: 0.4, Timestamp: minute.Add(10 * time.Second)})
+	c.Record(Entry{Kind: KindRequest, Method: "GET", Route: "/api/members", DurationMs: 7, Timestamp: minute.Add(70 * time.Second)})
+	c.Record(Entry{Kind: KindRequest, Method: "GET", Route: "/api/members", DurationMs: 7}) // no timestamp
+
+	got := c.TakeBuckets(minute.Add(90 * time.Second))
+	if len(got) != 2 {
+		t.Fatalf("buckets = %+v, want the request and query series of the finished minute", got)
+	}
+	req := got[0]
+	if req.Kind != perfstats.KindRequest || req.Name != "GET /api/members" || !req.Minute.Equal(minute) || req.Count != 2 || req.SumMs != 16 || req.MaxMs != 12 {
+		t.Errorf("request bucket = %+v", req)
+	}
+	if got[1].Kind != perfstats.KindQuery || got[1].Name != "QueryContext" || got[1].Count != 1 {
+		t.Errorf("query bucket = %+v", got[1])
+	}
+	if again := c.TakeBuckets(minute.Add(90 * time.Second)); len(again) != 0 {
+		t.Errorf("second take = %+v, want nothing left of that minute", again)
+	}
+	if rest := c.TakeBuckets(minute.Add(3 * time.Minute)); len(rest) != 1 || rest[0].Count != 1 {
+		t.Errorf("later take = %+v, want the next minute's request", rest)
+	}
+}
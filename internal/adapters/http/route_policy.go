@@ -0,0 +1,490 @@
+package web
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"workshop/internal/adapters/http/apierror"
+	"workshop/internal/adapters/http/middleware"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// routeAccess is the least a caller must be to reach a route. Handlers still make their
+// own, finer checks (per method, per record); the policy is the floor every request clears first.
+type routeAccess int
+
+// Route access levels, from most to least open.
+const (
+	accessPublic   routeAccess = iota // no session needed: sign-in, activation, signed webhooks
+	accessSignedIn                    // any signed-in account
+	accessStaff                       // coaches and admins
+	accessAdmin                       // admins only
+)
+
+// routePolicy declares who may call a route.
+type routePolicy struct {
+	Access      routeAccess
+	Permissions []string // permission-matrix actions, any one of which lets the caller in
+	Feature     string   // feature flag the route sits behind; "" for none
+}
+
+// allows reports whether sess clears the policy's role and permission floor.
+// Feature flags are checked separately so a denial can say which it was.
+func (p routePolicy) allows(r *http.Request, sess middleware.Session) bool {
+	switch p.Access {
+	case accessStaff:
+		if !isStaffSession(sess) {
+			return false
+		}
+	case accessAdmin:
+		if sess.Role != "admin" {
+			return false
+		}
+	}
+	if len(p.Permissions) == 0 {
+		return true
+	}
+	for _, action := range p.Permissions {
+		if permissionAllowed(r.Context(), sess, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// routePolicies is the registry of every route registerRoutes serves, keyed by pattern.
+// NewMux refuses to start with a route missing from it, and the route policy tests
+// probe every entry with each role.
+var routePolicies = map[string]routePolicy{
+	// Auth routes (no auth required)
+	"/login":                       {Access: accessPublic},
+	"/auth/oidc/start":             {Access: accessPublic},
+	"/auth/oidc/callback":          {Access: accessPublic},
+	"/logout":                      {Access: accessPublic},
+	"/logout/all":                  {Access: accessSignedIn},
+	"/change-password":             {Access: accessSignedIn},
+	"/activate":                    {Access: accessPublic},
+	"/api/activate":                {Access: accessPublic},
+	"/unsubscribe":                 {Access: accessPublic},
+	"/api/unsubscribe":             {Access: accessPublic},
+	"/api/admin/resend-activation": {Access: accessAdmin},
+
+	// Existing routes
+	"/attendance":           {Access: accessSignedIn, Feature: "attendance"},
+	"/attendance/backfill":  {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionAttendanceBackfill}, Feature: "attendance"},
+	"/attendance/rollcall":  {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionAttendanceRollCall}, Feature: "attendance"},
+	"/attendance/changes":   {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionClassesChange}, Feature: "attendance"},
+	"/attendance/anomalies": {Access: accessAdmin, Feature: "attendance"},
+	"/checkin":              {Access: accessSignedIn, Feature: "attendance"},
+	"/checkin/form":         {Access: accessStaff},
+	"/injuries":             {Access: accessSignedIn},
+	"/injuries/form":        {Access: accessSignedIn},
+	"/members":              {Access: accessSignedIn, Feature: "member_mgmt"},
+	"/members/profile":      {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionMembersView}, Feature: "member_mgmt"},
+	"/members/register":     {Access: accessStaff},
+	"/members/waiver":       {Access: accessSignedIn, Feature: "member_mgmt"},
+	"/waivers":              {Access: accessSignedIn},
+	"/waivers/form":         {Access: accessSignedIn},
+
+	// Layer 1a API routes
+	"/api/members/search":                {Access: accessSignedIn, Feature: "member_mgmt"},
+	"/api/members/export":                {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionMembersExport}, Feature: "member_mgmt"},
+	"/api/members/import":                {Access: accessAdmin, Feature: "member_mgmt"},
+	"/api/members/archive":               {Access: accessSignedIn, Feature: "member_mgmt"},
+	"/api/members/restore":               {Access: accessSignedIn, Feature: "member_mgmt"},
+	"/api/members/duplicates":            {Access: accessAdmin, Feature: "member_mgmt"},
+	"/api/members/merge":                 {Access: accessAdmin, Feature: "member_mgmt"},
+	"/api/members/progression":           {Access: accessSignedIn, Feature: "training_log"},
+	"/api/members/checkin-qr":            {Access: accessSignedIn},
+	"/api/members/checkin-qr/email":      {Access: accessSignedIn},
+	"/api/members/waivers":               {Access: accessSignedIn, Feature: "member_mgmt"},
+	"/api/guest/checkin":                 {Access: accessSignedIn},
+	"/api/visitors":                      {Access: accessAdmin, Feature: "visitors"},
+	"/api/visitors/visits":               {Access: accessAdmin, Feature: "visitors"},
+	"/api/visitors/convert":              {Access: accessAdmin, Feature: "visitors"},
+	"/api/visitors/report":               {Access: accessAdmin, Feature: "visitors"},
+	"/api/referrals/leaderboard":         {Access: accessAdmin, Feature: "referrals"},
+	"/api/referrals/settings":            {Access: accessAdmin, Feature: "referrals"},
+	"/api/timesheets":                    {Access: accessAdmin, Feature: "timesheets"},
+	"/api/timesheets/assign":             {Access: accessAdmin, Feature: "timesheets"},
+	"/api/timesheets/rates":              {Access: accessAdmin, Feature: "timesheets"},
+	"/api/timesheets/export":             {Access: accessAdmin, Feature: "timesheets"},
+	"/api/class-packs":                   {Access: accessAdmin, Feature: "class_packs"},
+	"/api/coach-availability":            {Access: accessStaff, Feature: "coverage"},
+	"/api/coach-availability/exceptions": {Access: accessStaff, Feature: "coverage"},
+	"/api/coverage":                      {Access: accessAdmin, Feature: "coverage"},
+	"/api/coverage/assign":               {Access: accessAdmin, Feature: "coverage"},
+	"/api/attendance/member":             {Access: accessSignedIn, Feature: "attendance"},
+	"/api/attendance/undo":               {Access: accessSignedIn, Feature: "attendance"},
+	"/api/attendance/checkout":           {Access: accessSignedIn, Feature: "attendance"},
+	"/api/attendance/bulk-sync":          {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionAttendanceKiosk}, Feature: "kiosk"},
+	"/api/attendance/backfill":           {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionAttendanceBackfill}, Feature: "attendance"},
+	"/api/attendance/import":             {Access: accessAdmin, Feature: "attendance"},
+	"/api/attendance/rollcall":           {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionAttendanceRollCall}, Feature: "attendance"},
+	"/api/attendance/anomalies":          {Access: accessAdmin, Feature: "attendance"},
+	"/api/attendance/anomalies/fix":      {Access: accessAdmin, Feature: "attendance"},
+	"/api/attendance/mat-hours/repair":   {Access: accessAdmin, Feature: "attendance"},
+	"/api/attendance/export":             {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionReportsExport}, Feature: "attendance"},
+	"/api/classes/changes":               {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionClassesChange}, Feature: "attendance"},
+	"/api/estimated-hours":               {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionTrainingHoursReview}},
+	"/api/estimated-hours/check-overlap": {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionTrainingHoursReview}},
+	"/api/estimated-hours/export":        {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionReportsExport}},
+	"/api/self-estimates":                {Access: accessSignedIn},
+	"/api/self-estimates/pending":        {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionTrainingHoursReview}},
+	"/api/self-estimates/review":         {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionTrainingHoursReview}},
+	"/api/self-estimates/respond":        {Access: accessSignedIn},
+	"/api/self-estimates/history":        {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionTrainingHoursReview}},
+	"/api/classes/today":                 {Access: accessSignedIn},
+	"/api/classes/live":                  {Access: accessStaff, Feature: "attendance"},
+	"/api/kiosk/launch":                  {Access: accessSignedIn},
+	"/api/kiosk/exit":                    {Access: accessSignedIn},
+	"/api/kiosk/heartbeat":               {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionAttendanceKiosk}, Feature: "kiosk"},
+	"/api/kiosk/board":                   {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionAttendanceKiosk}, Feature: "kiosk"},
+	"/api/kiosk/contact-check":           {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionAttendanceKiosk}, Feature: "attendance"},
+	"/api/kiosk/class-pack":              {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionAttendanceKiosk}, Feature: "class_packs"},
+	"/api/checkin/qr":                    {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionAttendanceKiosk}, Feature: "kiosk"},
+
+	// Layer 1b API routes
+	"/api/training-log":                   {Access: accessSignedIn, Feature: "training_log"},
+	"/api/training-volume":                {Access: accessSignedIn, Feature: "training_log"},
+	"/api/me/week":                        {Access: accessSignedIn, Feature: "training_log"},
+	"/api/members/inactive":               {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionMembersView}},
+	"/api/member-tags":                    {Access: accessAdmin, Feature: "member_tags"},
+	"/api/member-tags/catalog":            {Access: accessAdmin, Feature: "member_tags"},
+	"/api/member-segments":                {Access: accessAdmin, Feature: "member_tags"},
+	"/api/member-segments/members":        {Access: accessAdmin, Feature: "member_tags"},
+	"/api/reengagement/rules":             {Access: accessAdmin, Feature: "reengagement"},
+	"/api/reengagement/actions":           {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionMembersView}, Feature: "reengagement"},
+	"/api/reengagement/actions/outcome":   {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionMembersView}, Feature: "reengagement"},
+	"/api/reengagement/suppressions":      {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionMembersView}, Feature: "reengagement"},
+	"/api/notices":                        {Access: accessSignedIn},
+	"/api/grading/proposals":              {Access: accessSignedIn},
+	"/api/grading/proposals/mine":         {Access: accessSignedIn, Feature: "training_log"},
+	"/api/grading/proposals/schedule":     {Access: accessAdmin, Feature: "grading"},
+	"/api/grading/proposals/comments":     {Access: accessStaff, Feature: "grading"},
+	"/api/grading/proposals/decide-batch": {Access: accessAdmin, Feature: "grading"},
+	"/api/grading/ceremonies":             {Access: accessAdmin, Feature: "grading"},
+	"/api/grading/ceremonies/attach":      {Access: accessAdmin, Feature: "grading"},
+	"/api/grading/ceremonies/complete":    {Access: accessAdmin, Feature: "grading"},
+	"/api/injuries":                       {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionInjuriesView}, Feature: "member_mgmt"},
+	"/api/messages":                       {Access: accessSignedIn, Feature: "messages"},
+	"/api/notifications":                  {Access: accessSignedIn, Feature: "notifications"},
+	"/api/notifications/read":             {Access: accessSignedIn, Feature: "notifications"},
+	"/api/notifications/preferences":      {Access: accessSignedIn, Feature: "notifications"},
+	"/api/push/key":                       {Access: accessSignedIn, Feature: "notifications"},
+	"/api/push/subscriptions":             {Access: accessSignedIn, Feature: "notifications"},
+	"/api/events/stream":                  {Access: accessSignedIn, Feature: "live_updates"},
+	"/api/communication-preferences":      {Access: accessSignedIn},
+	"/api/observations":                   {Access: accessStaff},
+	"/api/observations/visibility":        {Access: accessStaff},
+	"/api/observations/capture":           {Access: accessStaff, Feature: "quick_capture"},
+	"/api/observations/capture/resolve":   {Access: accessStaff, Feature: "quick_capture"},
+	"/api/search":                         {Access: accessSignedIn, Feature: "search"},
+
+	// Admin CRUD API routes
+	"/api/schedules":                     {Access: accessAdmin},
+	"/api/schedules/timetable":           {Access: accessAdmin, Feature: "timetable"},
+	"/api/schedules/bulk":                {Access: accessAdmin, Feature: "timetable"},
+	"/api/holidays":                      {Access: accessAdmin},
+	"/api/terms":                         {Access: accessAdmin},
+	"/api/terms/rollover":                {Access: accessAdmin, Feature: "grading"},
+	"/api/terms/readiness":               {Access: accessAdmin, Feature: "grading"},
+	"/api/terms/reports":                 {Access: accessStaff, Feature: "grading"},
+	"/api/terms/reports/pdf":             {Access: accessStaff, Feature: "grading"},
+	"/api/terms/reports/email":           {Access: accessAdmin, Feature: "grading"},
+	"/api/accounts":                      {Access: accessAdmin},
+	"/api/accounts/role":                 {Access: accessAdmin},
+	"/api/accounts/unlock":               {Access: accessAdmin},
+	"/api/status-changes":                {Access: accessAdmin},
+	"/api/admin/accounts/bulk-provision": {Access: accessAdmin, Feature: "member_mgmt"},
+	"/api/admin/accounts/impersonate":    {Access: accessAdmin, Feature: "member_mgmt"},
+	"/api/admin/feature-flags":           {Access: accessAdmin},
+	"/api/admin/feature-flags/trace":     {Access: accessAdmin},
+	"/api/admin/permissions":             {Access: accessAdmin, Feature: "permissions"},
+	"/api/admin/beta-testers":            {Access: accessAdmin},
+	"/api/admin/workers":                 {Access: accessAdmin, Feature: "outbox"},
+	"/api/admin/jobs":                    {Access: accessAdmin, Feature: "jobs"},
+	"/api/admin/jobs/run":                {Access: accessAdmin, Feature: "jobs"},
+	"/api/admin/perf/history":            {Access: accessAdmin},
+	"/api/admin/stats":                   {Access: accessAdmin, Feature: "dashboard_stats"},
+	"/api/admin/config":                  {Access: accessAdmin, Feature: "config"},
+	"/api/admin/security-check":          {Access: accessAdmin, Feature: "config"},
+	"/api/admin/backups":                 {Access: accessAdmin, Feature: "backups"},
+	"/api/admin/backups/restore":         {Access: accessAdmin, Feature: "backups"},
+	"/api/admin/sessions":                {Access: accessAdmin, Feature: "sessions"},
+	"/api/admin/sessions/revoke":         {Access: accessAdmin, Feature: "sessions"},
+	"/api/admin/kiosk-devices":           {Access: accessAdmin, Feature: "kiosk"},
+	"/api/admin/kiosk-devices/end":       {Access: accessAdmin, Feature: "kiosk"},
+	"/api/openapi.json":                  {Access: accessAdmin, Feature: "api_docs"},
+
+	// Dashboard & Kiosk
+	"/dashboard":          {Access: accessSignedIn},
+	"/kiosk":              {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionAttendanceKiosk}},
+	"/kiosk/board":        {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionAttendanceKiosk}, Feature: "kiosk"},
+	"/kiosk/board/events": {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionAttendanceKiosk}, Feature: "kiosk"},
+
+	// Admin management pages
+	"/admin/schedules":      {Access: accessAdmin},
+	"/admin/class-types":    {Access: accessAdmin},
+	"/admin/holidays":       {Access: accessAdmin},
+	"/admin/terms":          {Access: accessAdmin},
+	"/admin/accounts":       {Access: accessAdmin},
+	"/admin/features":       {Access: accessAdmin},
+	"/admin/permissions":    {Access: accessAdmin, Feature: "permissions"},
+	"/admin/notices":        {Access: accessAdmin},
+	"/admin/grading":        {Access: accessAdmin},
+	"/admin/inactive":       {Access: accessAdmin},
+	"/admin/member-tags":    {Access: accessAdmin, Feature: "member_tags"},
+	"/admin/visitors":       {Access: accessAdmin, Feature: "visitors"},
+	"/admin/referrals":      {Access: accessAdmin, Feature: "referrals"},
+	"/admin/timesheets":     {Access: accessAdmin, Feature: "timesheets"},
+	"/admin/class-packs":    {Access: accessAdmin, Feature: "class_packs"},
+	"/availability":         {Access: accessStaff, Feature: "coverage"},
+	"/admin/milestones":     {Access: accessAdmin},
+	"/admin/perf":           {Access: accessAdmin},
+	"/metrics":              {Access: accessPublic},
+	"/admin/backups":        {Access: accessAdmin, Feature: "backups"},
+	"/admin/jobs":           {Access: accessAdmin, Feature: "jobs"},
+	"/admin/sessions":       {Access: accessAdmin, Feature: "sessions"},
+	"/admin/api-docs":       {Access: accessAdmin, Feature: "api_docs"},
+	"/admin/self-estimates": {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionTrainingHoursReview}},
+
+	// Member pages
+	"/training-log": {Access: accessSignedIn},
+	"/messages":     {Access: accessSignedIn},
+	"/inbox":        {Access: accessSignedIn},
+	"/api/inbox":    {Access: accessSignedIn},
+
+	// Privacy routes (GDPR compliance)
+	"/privacy/delete":             {Access: accessSignedIn, Feature: "privacy"},
+	"/api/privacy/delete":         {Access: accessSignedIn},
+	"/api/privacy/delete/cancel":  {Access: accessSignedIn},
+	"/privacy/consent":            {Access: accessSignedIn},
+	"/api/privacy/consent/revoke": {Access: accessSignedIn},
+	"/privacy/export":             {Access: accessSignedIn, Feature: "privacy"},
+	"/api/me/export":              {Access: accessSignedIn, Feature: "privacy"},
+	"/api/me/export/download":     {Access: accessSignedIn, Feature: "privacy"},
+
+	// Class types API
+	"/api/class-types":           {Access: accessSignedIn},
+	"/api/class-types/approvals": {Access: accessStaff, Feature: "attendance"},
+	"/api/programs":              {Access: accessAdmin},
+
+	// Layer 1b workflow routes
+	"/api/notices/publish":           {Access: accessAdmin},
+	"/api/notices/edit":              {Access: accessAdmin},
+	"/api/notices/pin":               {Access: accessAdmin},
+	"/api/notices/images":            {Access: accessAdmin},
+	"/api/notices/image":             {Access: accessSignedIn},
+	"/api/notices/preview":           {Access: accessAdmin},
+	"/api/grading/proposals/decide":  {Access: accessAdmin},
+	"/api/grading/config":            {Access: accessAdmin},
+	"/api/grading/credit":            {Access: accessAdmin},
+	"/api/grading/force-promote":     {Access: accessAdmin},
+	"/api/grading/member-config":     {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionGradingManage}},
+	"/api/grading/readiness":         {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionGradingManage}},
+	"/api/grading/eligibility":       {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionGradingManage}, Feature: "grading"},
+	"/api/grading/rules":             {Access: accessAdmin, Feature: "grading"},
+	"/api/grading/makeup-credits":    {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionGradingManage}, Feature: "grading"},
+	"/api/grading/metric":            {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionGradingManage}},
+	"/api/grading/notes":             {Access: accessStaff},
+	"/api/grading/rubrics":           {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionGradingManage}, Feature: "grading"},
+	"/api/grading/rubrics/history":   {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionGradingManage}, Feature: "grading"},
+	"/api/grading/inventory":         {Access: accessAdmin, Feature: "belt_inventory"},
+	"/api/grading/belt-sizes":        {Access: accessAdmin, Feature: "belt_inventory"},
+	"/api/grading/pick-list":         {Access: accessAdmin, Feature: "belt_inventory"},
+	"/api/grading/records":           {Access: accessAdmin, Feature: "grading"},
+	"/api/grading/records/amend":     {Access: accessAdmin, Feature: "grading"},
+	"/api/grading/records/void":      {Access: accessAdmin, Feature: "grading"},
+	"/api/grading/bulk-award":        {Access: accessAdmin, Feature: "grading"},
+	"/api/grading/import":            {Access: accessAdmin, Feature: "grading"},
+	"/api/grading/records/export":    {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionReportsExport}, Feature: "grading"},
+	"/api/training-goals":            {Access: accessSignedIn},
+	"/api/training-goals/suggest":    {Access: accessSignedIn, Feature: "training_log"},
+	"/api/milestones":                {Access: accessSignedIn},
+	"/api/member-milestones":         {Access: accessSignedIn},
+	"/api/member-milestones/dismiss": {Access: accessSignedIn},
+	"/api/messages/read":             {Access: accessSignedIn},
+	"/api/messages/threads":          {Access: accessSignedIn, Feature: "messages"},
+	"/api/messages/thread":           {Access: accessSignedIn, Feature: "messages"},
+	"/api/messages/reply":            {Access: accessSignedIn, Feature: "messages"},
+	"/api/messages/broadcast":        {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionMessagesBroadcast}, Feature: "messages"},
+	"/api/messages/images":           {Access: accessStaff, Feature: "messages"},
+	"/api/messages/image":            {Access: accessSignedIn, Feature: "messages"},
+
+	// Layer 2: Spine API routes
+	"/api/themes":               {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionLibraryEdit, permissionDomain.ActionLibraryView}, Feature: "library"},
+	"/api/themes/min-belt":      {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionLibraryEdit}, Feature: "library"},
+	"/api/themes/carousel":      {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionLibraryView}, Feature: "library"},
+	"/api/clips":                {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionLibraryEdit, permissionDomain.ActionLibraryView}, Feature: "library"},
+	"/api/clips/promote":        {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionLibraryEdit}, Feature: "library"},
+	"/api/clips/min-belt":       {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionLibraryEdit}, Feature: "library"},
+	"/api/clips/tags":           {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionLibraryView, permissionDomain.ActionLibraryEdit}, Feature: "library"},
+	"/api/clips/{clipID}/tags":  {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionLibraryView}, Feature: "library"},
+	"/api/clips/search-by-tags": {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionLibraryView}, Feature: "library"},
+	"/api/clips/search":         {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionLibraryView}, Feature: "library"},
+	"/api/clips/taxonomy":       {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionLibraryView}, Feature: "library"},
+
+	// Layer 2: Spine pages
+	"/themes":  {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionLibraryView}, Feature: "library"},
+	"/library": {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionLibraryView}, Feature: "library"},
+
+	// Curriculum rotor system routes
+	"/curriculum":                 {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumView}, Feature: "curriculum"},
+	"/session-logs":               {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionSessionLogsManage}, Feature: "curriculum"},
+	"/api/rotors":                 {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumEdit, permissionDomain.ActionCurriculumView}, Feature: "curriculum"},
+	"/api/rotors/by-id":           {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumEdit, permissionDomain.ActionCurriculumView}, Feature: "curriculum"},
+	"/api/rotors/activate":        {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumEdit}, Feature: "curriculum"},
+	"/api/rotors/preview":         {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumEdit}, Feature: "curriculum"},
+	"/api/rotors/advance-mode":    {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumEdit}, Feature: "curriculum"},
+	"/api/rotors/vote-rules":      {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumEdit}, Feature: "curriculum"},
+	"/api/rotors/export":          {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumEdit}, Feature: "curriculum"},
+	"/api/rotors/import":          {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumEdit}, Feature: "curriculum"},
+	"/api/rotors/themes":          {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumView}, Feature: "curriculum"},
+	"/api/rotors/themes/clone":    {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumEdit}, Feature: "curriculum"},
+	"/api/rotors/shared-topics":   {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumEdit, permissionDomain.ActionCurriculumView}, Feature: "curriculum"},
+	"/api/rotors/topics":          {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumView}, Feature: "curriculum"},
+	"/api/rotors/topics/reorder":  {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumEdit}, Feature: "curriculum"},
+	"/api/rotors/topics/bump":     {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumEdit}, Feature: "curriculum"},
+	"/api/rotors/schedule/action": {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumEdit}, Feature: "curriculum"},
+	"/api/votes":                  {Access: accessSignedIn, Feature: "curriculum"},
+	"/api/votes/mine":             {Access: accessSignedIn, Feature: "curriculum"},
+	"/api/curriculum/view":        {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumView}, Feature: "curriculum"},
+	"/api/curriculum/overview":    {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumView}, Feature: "curriculum"},
+	"/api/curriculum/preview":     {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumView}, Feature: "curriculum"},
+	"/api/curriculum/coverage":    {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumEdit}, Feature: "curriculum"},
+	"/api/curriculum/history":     {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCurriculumView}, Feature: "curriculum"},
+	"/api/session-logs":           {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionSessionLogsManage}, Feature: "curriculum"},
+
+	// Calendar routes
+	"/calendar":                      {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCalendarView}, Feature: "calendar"},
+	"/api/calendar/events":           {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCalendarView}, Feature: "calendar"},
+	"/api/calendar/interest":         {Access: accessSignedIn, Feature: "calendar"},
+	"/api/calendar/interest/summary": {Access: accessSignedIn, Feature: "calendar"},
+	"/api/calendar/roster":           {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionCompetitionsRoster}, Feature: "calendar"},
+	"/api/calendar/rotors":           {Access: accessSignedIn, Feature: "calendar"},
+
+	// Personal goals routes
+	"/api/personal-goals":               {Access: accessSignedIn, Feature: "calendar"},
+	"/api/personal-goals/progress":      {Access: accessSignedIn, Feature: "calendar"},
+	"/api/personal-goals/feedback":      {Access: accessSignedIn, Feature: "calendar"},
+	"/api/personal-goals/check-ins":     {Access: accessSignedIn, Feature: "calendar"},
+	"/api/personal-goals/check-ins/due": {Access: accessSignedIn, Feature: "calendar"},
+	"/api/personal-goals/annotations":   {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionGoalsCoach}, Feature: "calendar"},
+
+	// Class feedback (members rate, coaches review)
+	"/feedback":             {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionFeedbackView}, Feature: "class_feedback"},
+	"/api/feedback":         {Access: accessSignedIn, Feature: "class_feedback"},
+	"/api/feedback/pending": {Access: accessSignedIn, Feature: "class_feedback"},
+	"/api/feedback/report":  {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionFeedbackView}, Feature: "class_feedback"},
+
+	// Celebrations (birthdays, training anniversaries and class milestones)
+	"/api/celebrations":         {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionMembersView}, Feature: "celebrations"},
+	"/api/celebrations/profile": {Access: accessSignedIn, Feature: "celebrations"},
+
+	// Locations (multi-branch)
+	"/api/locations":             {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionLocationsView}, Feature: "locations"},
+	"/api/locations/assign":      {Access: accessAdmin, Feature: "locations"},
+	"/api/session/location":      {Access: accessSignedIn, Feature: "locations"},
+	"/api/nav":                   {Access: accessSignedIn, Feature: "navigation"},
+	"/api/account/locale":        {Access: accessSignedIn, Feature: "languages"},
+	"/api/account/email":         {Access: accessSignedIn, Feature: "email_change"},
+	"/account/email/confirm":     {Access: accessPublic},
+	"/api/account/email/confirm": {Access: accessPublic},
+
+	// Bug Box routes (Admin + Coach)
+	"/api/admin/bugbox":            {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionBugBoxSubmit}, Feature: "bugbox"},
+	"/api/admin/bugbox/screenshot": {Access: accessAdmin, Feature: "bugbox"},
+	"/api/admin/bugbox/list":       {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionBugBoxSubmit}, Feature: "bugbox"},
+	"/api/admin/bugbox/triage":     {Access: accessAdmin, Feature: "bugbox"},
+	"/api/admin/bugbox/comments":   {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionBugBoxSubmit}, Feature: "bugbox"},
+	"/bugbox":                      {Access: accessSignedIn, Permissions: []string{permissionDomain.ActionBugBoxSubmit}, Feature: "bugbox"},
+
+	// DevMode routes (admin-only impersonation)
+	"/api/devmode/impersonate": {Access: accessAdmin},
+	"/api/devmode/restore":     {Access: accessSignedIn},
+
+	// Email system routes
+	"/admin/emails":                        {Access: accessAdmin},
+	"/admin/emails/compose":                {Access: accessAdmin},
+	"/api/emails":                          {Access: accessAdmin},
+	"/api/emails/compose":                  {Access: accessAdmin},
+	"/api/emails/send":                     {Access: accessAdmin},
+	"/api/emails/test-send":                {Access: accessAdmin},
+	"/api/emails/detail":                   {Access: accessAdmin},
+	"/api/emails/recipients/export":        {Access: accessAdmin, Feature: "emails"},
+	"/api/emails/suppressions":             {Access: accessAdmin, Feature: "emails"},
+	"/api/emails/contact-hygiene":          {Access: accessAdmin, Feature: "emails"},
+	"/api/emails/unsubscribes":             {Access: accessAdmin, Feature: "emails"},
+	"/api/webhooks/resend":                 {Access: accessPublic},
+	"/api/emails/delete":                   {Access: accessAdmin},
+	"/api/emails/schedule":                 {Access: accessAdmin},
+	"/api/emails/cancel":                   {Access: accessAdmin},
+	"/api/emails/reschedule":               {Access: accessAdmin},
+	"/api/emails/recipients/search":        {Access: accessAdmin},
+	"/api/emails/recipients/filter":        {Access: accessAdmin},
+	"/api/emails/recipients/by-session":    {Access: accessAdmin},
+	"/api/emails/recipients/by-class-type": {Access: accessAdmin},
+	"/api/emails/recipients/by-segment":    {Access: accessAdmin, Feature: "member_tags"},
+	"/api/schedules/recent-sessions":       {Access: accessAdmin},
+	"/admin/emails/template":               {Access: accessAdmin},
+	"/api/emails/template":                 {Access: accessAdmin},
+	"/api/emails/preview":                  {Access: accessAdmin},
+	"/api/emails/library":                  {Access: accessAdmin, Feature: "emails"},
+	"/api/emails/library/preview":          {Access: accessAdmin, Feature: "emails"},
+}
+
+// policyMux registers each route behind its policy.
+type policyMux struct {
+	mux routeRegistrar
+}
+
+// HandleFunc registers handler behind the policy declared for pattern.
+// PRE: pattern is in routePolicies
+// POST: The route is registered; panics for an undeclared route so it cannot ship unguarded
+func (m policyMux) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	policy, ok := routePolicies[pattern]
+	if !ok {
+		panic(fmt.Sprintf("route %s has no entry in routePolicies", pattern))
+	}
+	m.mux.HandleFunc(pattern, enforceRoutePolicy(policy, handler))
+}
+
+// enforceRoutePolicy wraps handler so requests that fail policy never reach it. API routes
+// answer 401/403; pages redirect to /login or /dashboard like the page helpers do.
+func enforceRoutePolicy(policy routePolicy, handler func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+	if policy.Access == accessPublic {
+		return handler
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		api := strings.HasPrefix(r.URL.Path, "/api/")
+		sess, ok := middleware.GetSessionFromContext(r.Context())
+		if !ok {
+			if api {
+				apierror.Unauthorized(w, "not authenticated")
+			} else {
+				http.Redirect(w, r, "/login", http.StatusSeeOther)
+			}
+			return
+		}
+		reason := ""
+		switch {
+		case !policy.allows(r, sess):
+			reason = "role"
+		case policy.Feature != "" && !featureEnabledForSession(r.Context(), sess, policy.Feature):
+			reason = "feature"
+		}
+		if reason != "" {
+			slog.WarnContext(r.Context(), "auth_denied", "path", r.URL.Path, "account_id", sess.AccountID, "role", sess.Role, "reason", "route_policy_"+reason)
+			if api {
+				apierror.Forbidden(w, "Forbidden")
+			} else {
+				http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+			}
+			return
+		}
+		handler(w, r)
+	}
+}
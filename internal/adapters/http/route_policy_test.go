@@ -0,0 +1,198 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"workshop/internal/adapters/http/middleware"
+	featureflagDomain "workshop/internal/domain/featureflag"
+	permissionDomain "workshop/internal/domain/permission"
+)
+
+// handlerMux records the handlers registered per pattern.
+type handlerMux struct {
+	handlers map[string]func(http.ResponseWriter, *http.Request)
+}
+
+// HandleFunc records handler under pattern.
+// PRE: pattern is a ServeMux pattern
+// POST: handler is stored under pattern
+func (m *handlerMux) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	m.handlers[pattern] = handler
+}
+
+// policyTestSessions are the signed-in roles every route is probed with.
+var policyTestSessions = map[string]middleware.Session{
+	"trial":  {AccountID: "trial-001", Email: "trial@test.com", Role: "trial"},
+	"member": memberSession,
+	"coach":  coachSession,
+	"admin":  adminSession,
+}
+
+// wantAllowed works out from the default permission matrix whether role clears policy.
+func wantAllowed(policy routePolicy, role string) bool {
+	switch policy.Access {
+	case accessStaff:
+		if role != "admin" && role != "coach" {
+			return false
+		}
+	case accessAdmin:
+		if role != "admin" {
+			return false
+		}
+	}
+	if len(policy.Permissions) == 0 {
+		return true
+	}
+	for _, action := range policy.Permissions {
+		if p, _ := permissionDomain.DefaultByAction(action); p.Allows(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// policyRequest builds a request for pattern, filling in any path wildcards.
+func policyRequest(pattern string, sess *middleware.Session) *http.Request {
+	path := strings.NewReplacer("{clipID}", "clip-1").Replace(pattern)
+	if sess == nil {
+		return httptest.NewRequest("GET", path, nil)
+	}
+	return authRequest("GET", path, "", *sess)
+}
+
+// isRejected reports whether rec is the policy's refusal: 401/403 for the API and a
+// redirect to /login or /dashboard for pages.
+func isRejected(pattern string, rec *httptest.ResponseRecorder, anonymous bool) bool {
+	if strings.HasPrefix(pattern, "/api/") {
+		if anonymous {
+			return rec.Code == http.StatusUnauthorized
+		}
+		return rec.Code == http.StatusForbidden
+	}
+	want := "/dashboard"
+	if anonymous {
+		want = "/login"
+	}
+	return rec.Code == http.StatusSeeOther && rec.Header().Get("Location") == want
+}
+
+// enableAllFlags turns every feature flag on for every role, so only the role and
+// permission floor decide.
+func enableAllFlags(t *testing.T) {
+	t.Helper()
+	for _, f := range featureflagDomain.DefaultFlags() {
+		f.EnabledAdmin, f.EnabledCoach, f.EnabledMember, f.EnabledTrial = true, true, true, true
+		if err := stores.FeatureFlagStore.Save(context.Background(), f); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestRoutePolicies_CoverEveryRoute verifies every registered route has a policy, every
+// policy has a route, and policies only name known permissions.
+func TestRoutePolicies_CoverEveryRoute(t *testing.T) {
+	mux := &recordingMux{}
+	registerRoutes(mux)
+	registered := map[string]bool{}
+	for _, pattern := range mux.patterns {
+		registered[pattern] = true
+		if _, ok := routePolicies[pattern]; !ok {
+			t.Errorf("route %s has no entry in routePolicies", pattern)
+		}
+	}
+	for pattern, policy := range routePolicies {
+		if !registered[pattern] {
+			t.Errorf("routePolicies has %s but no such route is registered", pattern)
+		}
+		for _, action := range policy.Permissions {
+			if _, ok := permissionDomain.DefaultByAction(action); !ok {
+				t.Errorf("%s: unknown permission %q", pattern, action)
+			}
+		}
+		if policy.Access == accessPublic && (len(policy.Permissions) > 0 || policy.Feature != "") {
+			t.Errorf("%s: a public route cannot require a permission or feature", pattern)
+		}
+	}
+}
+
+// TestRoutePolicies_RejectAnonymousAndForbiddenRoles probes every registered route, as
+// wired by NewMux, with no session and with each role. Refused callers must get the
+// policy's refusal without reaching the handler; allowed callers must get through.
+func TestRoutePolicies_RejectAnonymousAndForbiddenRoles(t *testing.T) {
+	stores = newFullStores()
+	enableAllFlags(t)
+	wired := &handlerMux{handlers: map[string]func(http.ResponseWriter, *http.Request){}}
+	registerRoutes(policyMux{mux: wired})
+
+	for pattern, policy := range routePolicies {
+		t.Run(pattern, func(t *testing.T) {
+			if policy.Access != accessPublic {
+				rec := httptest.NewRecorder()
+				wired.handlers[pattern](rec, policyRequest(pattern, nil))
+				if !isRejected(pattern, rec, true) {
+					t.Errorf("anonymous: expected refusal, got %d %q", rec.Code, rec.Header().Get("Location"))
+				}
+			}
+			for role, sess := range policyTestSessions {
+				reached := false
+				probe := enforceRoutePolicy(policy, func(w http.ResponseWriter, r *http.Request) { reached = true })
+				rec := httptest.NewRecorder()
+				probe(rec, policyRequest(pattern, &sess))
+				if want := wantAllowed(policy, role); reached != want {
+					t.Errorf("%s: reached handler = %v, want %v", role, reached, want)
+					continue
+				}
+				if reached {
+					continue
+				}
+				if !isRejected(pattern, rec, false) {
+					t.Errorf("%s: expected refusal, got %d %q", role, rec.Code, rec.Header().Get("Location"))
+				}
+				// The wired handler must refuse the same way before doing any work.
+				rec = httptest.NewRecorder()
+				wired.handlers[pattern](rec, policyRequest(pattern, &sess))
+				if !isRejected(pattern, rec, false) {
+					t.Errorf("%s: wired route did not refuse, got %d", role, rec.Code)
+				}
+			}
+		})
+	}
+}
+
+// TestRoutePolicies_FeatureFlagOff verifies a route behind a feature flag refuses even an
+// admin when the flag is off for admins.
+func TestRoutePolicies_FeatureFlagOff(t *testing.T) {
+	stores = newFullStores()
+	for _, policy := range routePolicies {
+		if policy.Feature != "" {
+			stores.FeatureFlagStore.Save(context.Background(), featureflagDomain.FeatureFlag{Key: policy.Feature, EnabledCoach: true, EnabledMember: true, EnabledTrial: true})
+		}
+	}
+	for pattern, policy := range routePolicies {
+		if policy.Feature == "" {
+			continue
+		}
+		reached := false
+		probe := enforceRoutePolicy(policy, func(w http.ResponseWriter, r *http.Request) { reached = true })
+		rec := httptest.NewRecorder()
+		probe(rec, policyRequest(pattern, &adminSession))
+		if reached || !isRejected(pattern, rec, false) {
+			t.Errorf("%s: admin should be refused with %s off, got %d", pattern, policy.Feature, rec.Code)
+		}
+	}
+}
+
+// TestPolicyMux_PanicsOnUndeclaredRoute verifies a route missing from the registry cannot
+// be served.
+func TestPolicyMux_PanicsOnUndeclaredRoute(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an undeclared route")
+		}
+	}()
+	policyMux{mux: &recordingMux{}}.HandleFunc("/api/not-declared", func(http.ResponseWriter, *http.Request) {})
+}
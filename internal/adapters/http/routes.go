@@ -6,17 +6,32 @@ import (
 	"net/http"
 )
 
-func registerRoutes(mux *http.ServeMux) {
+// routeRegistrar is the part of *http.ServeMux that registerRoutes uses, so tests
+// can record the registered patterns.
+type routeRegistrar interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
+func registerRoutes(mux routeRegistrar) {
 	// Auth routes (no auth required)
 	mux.HandleFunc("/login", handleLogin)
+	mux.HandleFunc("/auth/oidc/start", handleOIDCStart)
+	mux.HandleFunc("/auth/oidc/callback", handleOIDCCallback)
 	mux.HandleFunc("/logout", handleLogout)
+	mux.HandleFunc("/logout/all", handleLogoutAll)
 	mux.HandleFunc("/change-password", handleChangePassword)
 	mux.HandleFunc("/activate", handleActivatePage)
 	mux.HandleFunc("/api/activate", handleActivateAccount)
+	mux.HandleFunc("/unsubscribe", handleUnsubscribePage)
+	mux.HandleFunc("/api/unsubscribe", handleUnsubscribe)
 	mux.HandleFunc("/api/admin/resend-activation", handleResendActivation)
 
 	// Existing routes
 	mux.HandleFunc("/attendance", handleGetAttendanceGetAttendanceToday)
+	mux.HandleFunc("/attendance/backfill", handleAttendanceBackfillPage)
+	mux.HandleFunc("/attendance/rollcall", handleAttendanceRollCallPage)
+	mux.HandleFunc("/attendance/changes", handleClassChangesPage)
+	mux.HandleFunc("/attendance/anomalies", handleAttendanceAnomaliesPage)
 	mux.HandleFunc("/checkin", handlePostCheckinCheckInMember)
 	mux.HandleFunc("/checkin/form", handleGetCheckInForm)
 	mux.HandleFunc("/injuries", handlePostInjuriesReportInjury)
@@ -24,6 +39,7 @@ func registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/members", handleMembers)
 	mux.HandleFunc("/members/profile", handleGetMemberProfile)
 	mux.HandleFunc("/members/register", handleGetMembersRegisterForm)
+	mux.HandleFunc("/members/waiver", handleWaiverDocument)
 	mux.HandleFunc("/waivers", handlePostWaiversSignWaiver)
 	mux.HandleFunc("/waivers/form", handleGetWaiverForm)
 
@@ -33,40 +49,135 @@ func registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/members/import", handleMembersImportCSV)
 	mux.HandleFunc("/api/members/archive", handleArchiveMember)
 	mux.HandleFunc("/api/members/restore", handleRestoreMember)
+	mux.HandleFunc("/api/members/duplicates", handleMemberDuplicates)
+	mux.HandleFunc("/api/members/merge", handleMemberMerge)
+	mux.HandleFunc("/api/members/progression", handleMemberProgression)
+	mux.HandleFunc("/api/members/checkin-qr", handleMemberCheckInQR)
+	mux.HandleFunc("/api/members/checkin-qr/email", handleMemberCheckInQREmail)
+	mux.HandleFunc("/api/members/waivers", handleMemberWaivers)
 	mux.HandleFunc("/api/guest/checkin", handleGuestCheckIn)
+	mux.HandleFunc("/api/visitors", handleVisitors)
+	mux.HandleFunc("/api/visitors/visits", handleVisitorVisits)
+	mux.HandleFunc("/api/visitors/convert", handleVisitorConvert)
+	mux.HandleFunc("/api/visitors/report", handleVisitorReport)
+	mux.HandleFunc("/api/referrals/leaderboard", handleReferralLeaderboard)
+	mux.HandleFunc("/api/referrals/settings", handleReferralSettings)
+	mux.HandleFunc("/api/timesheets", handleTimesheets)
+	mux.HandleFunc("/api/timesheets/assign", handleTimesheetAssign)
+	mux.HandleFunc("/api/timesheets/rates", handleTimesheetRates)
+	mux.HandleFunc("/api/timesheets/export", handleTimesheetExport)
+	mux.HandleFunc("/api/class-packs", handleClassPacks)
+	mux.HandleFunc("/api/coach-availability", handleCoachAvailability)
+	mux.HandleFunc("/api/coach-availability/exceptions", handleCoachAvailabilityExceptions)
+	mux.HandleFunc("/api/coverage", handleCoverage)
+	mux.HandleFunc("/api/coverage/assign", handleCoverageAssign)
 	mux.HandleFunc("/api/attendance/member", handleMemberAttendanceToday)
 	mux.HandleFunc("/api/attendance/undo", handleUndoCheckIn)
 	mux.HandleFunc("/api/attendance/checkout", handleCheckOut)
+	mux.HandleFunc("/api/attendance/bulk-sync", handleAttendanceBulkSync)
+	mux.HandleFunc("/api/attendance/backfill", handleAttendanceBackfill)
+	mux.HandleFunc("/api/attendance/import", handleAttendanceImport)
+	mux.HandleFunc("/api/attendance/rollcall", handleAttendanceRollCall)
+	mux.HandleFunc("/api/attendance/anomalies", handleAttendanceAnomalies)
+	mux.HandleFunc("/api/attendance/anomalies/fix", handleAttendanceAnomalyFix)
+	mux.HandleFunc("/api/attendance/mat-hours/repair", handleMatHoursRepair)
+	mux.HandleFunc("/api/attendance/export", handleAttendanceExport)
+	mux.HandleFunc("/api/classes/changes", handleClassChanges)
 	mux.HandleFunc("/api/estimated-hours", handleEstimatedHours)
 	mux.HandleFunc("/api/estimated-hours/check-overlap", handleEstimatedHoursCheckOverlap)
+	mux.HandleFunc("/api/estimated-hours/export", handleEstimatedHoursExport)
 	mux.HandleFunc("/api/self-estimates", handleSelfEstimates)
 	mux.HandleFunc("/api/self-estimates/pending", handleSelfEstimatesPending)
 	mux.HandleFunc("/api/self-estimates/review", handleSelfEstimatesReview)
+	mux.HandleFunc("/api/self-estimates/respond", handleSelfEstimateRespond)
+	mux.HandleFunc("/api/self-estimates/history", handleSelfEstimateHistory)
 	mux.HandleFunc("/api/classes/today", handleTodaysClasses)
+	mux.HandleFunc("/api/classes/live", handleLiveClasses)
 	mux.HandleFunc("/api/kiosk/launch", handleKioskLaunch)
 	mux.HandleFunc("/api/kiosk/exit", handleKioskExit)
+	mux.HandleFunc("/api/kiosk/heartbeat", handleKioskHeartbeat)
+	mux.HandleFunc("/api/kiosk/board", handleKioskBoard)
+	mux.HandleFunc("/api/kiosk/contact-check", handleKioskContactCheck)
+	mux.HandleFunc("/api/kiosk/class-pack", handleKioskClassPack)
+	mux.HandleFunc("/api/checkin/qr", handleCheckInQR)
 
 	// Layer 1b API routes
 	mux.HandleFunc("/api/training-log", handleGetTrainingLog)
 	mux.HandleFunc("/api/training-volume", handleGetTrainingVolume)
+	mux.HandleFunc("/api/me/week", handleMyWeek)
 	mux.HandleFunc("/api/members/inactive", handleGetInactiveMembers)
+	mux.HandleFunc("/api/member-tags", handleMemberTags)
+	mux.HandleFunc("/api/member-tags/catalog", handleMemberTagCatalog)
+	mux.HandleFunc("/api/member-segments", handleMemberSegments)
+	mux.HandleFunc("/api/member-segments/members", handleMemberSegmentMembers)
+	mux.HandleFunc("/api/reengagement/rules", handleReengagementRules)
+	mux.HandleFunc("/api/reengagement/actions", handleReengagementActions)
+	mux.HandleFunc("/api/reengagement/actions/outcome", handleReengagementOutcome)
+	mux.HandleFunc("/api/reengagement/suppressions", handleReengagementSuppressions)
 	mux.HandleFunc("/api/notices", handleNotices)
 	mux.HandleFunc("/api/grading/proposals", handleGradingProposals)
+	mux.HandleFunc("/api/grading/proposals/mine", handleMyGradingProposals)
+	mux.HandleFunc("/api/grading/proposals/schedule", handleGradingProposalSchedule)
+	mux.HandleFunc("/api/grading/proposals/comments", handleGradingProposalComments)
+	mux.HandleFunc("/api/grading/proposals/decide-batch", handleGradingDecideBatch)
+	mux.HandleFunc("/api/grading/ceremonies", handleGradingCeremonies)
+	mux.HandleFunc("/api/grading/ceremonies/attach", handleGradingCeremonyAttach)
+	mux.HandleFunc("/api/grading/ceremonies/complete", handleGradingCeremonyComplete)
+	mux.HandleFunc("/api/injuries", handleInjuries)
 	mux.HandleFunc("/api/messages", handleMessages)
+	mux.HandleFunc("/api/notifications", handleNotifications)
+	mux.HandleFunc("/api/notifications/read", handleNotificationsRead)
+	mux.HandleFunc("/api/notifications/preferences", handleNotificationPreferences)
+	mux.HandleFunc("/api/push/key", handlePushKey)
+	mux.HandleFunc("/api/push/subscriptions", handlePushSubscriptions)
+	mux.HandleFunc("/api/events/stream", handleEventsStream)
+	mux.HandleFunc("/api/communication-preferences", handleCommunicationPreferences)
 	mux.HandleFunc("/api/observations", handleObservations)
+	mux.HandleFunc("/api/observations/visibility", handleObservationVisibility)
+	mux.HandleFunc("/api/observations/capture", handleQuickCapture)
+	mux.HandleFunc("/api/observations/capture/resolve", handleQuickCaptureResolve)
+	mux.HandleFunc("/api/search", handleSearch)
 
 	// Admin CRUD API routes
 	mux.HandleFunc("/api/schedules", handleSchedules)
+	mux.HandleFunc("/api/schedules/timetable", handleScheduleTimetable)
+	mux.HandleFunc("/api/schedules/bulk", handleSchedulesBulk)
 	mux.HandleFunc("/api/holidays", handleHolidays)
 	mux.HandleFunc("/api/terms", handleTerms)
+	mux.HandleFunc("/api/terms/rollover", handleTermRollover)
+	mux.HandleFunc("/api/terms/readiness", handleTermReadiness)
+	mux.HandleFunc("/api/terms/reports", handleTermReports)
+	mux.HandleFunc("/api/terms/reports/pdf", handleTermReportsPDF)
+	mux.HandleFunc("/api/terms/reports/email", handleTermReportsEmail)
 	mux.HandleFunc("/api/accounts", handleAccounts)
 	mux.HandleFunc("/api/accounts/role", handleChangeRole)
+	mux.HandleFunc("/api/accounts/unlock", handleUnlockAccount)
+	mux.HandleFunc("/api/status-changes", handleStatusChanges)
+	mux.HandleFunc("/api/admin/accounts/bulk-provision", handleBulkProvisionAccounts)
+	mux.HandleFunc("/api/admin/accounts/impersonate", handleImpersonateAccount)
 	mux.HandleFunc("/api/admin/feature-flags", handleAdminFeatureFlags)
+	mux.HandleFunc("/api/admin/feature-flags/trace", handleAdminFeatureFlagTrace)
+	mux.HandleFunc("/api/admin/permissions", handleAdminPermissions)
 	mux.HandleFunc("/api/admin/beta-testers", handleAdminBetaTesters)
+	mux.HandleFunc("/api/admin/workers", handleAdminWorkers)
+	mux.HandleFunc("/api/admin/jobs", handleAdminJobs)
+	mux.HandleFunc("/api/admin/jobs/run", handleAdminJobRun)
+	mux.HandleFunc("/api/admin/stats", handleAdminStats)
+	mux.HandleFunc("/api/admin/config", handleAdminConfig)
+	mux.HandleFunc("/api/admin/security-check", handleAdminSecurityCheck)
+	mux.HandleFunc("/api/admin/backups", handleAdminBackups)
+	mux.HandleFunc("/api/admin/backups/restore", handleAdminBackupRestore)
+	mux.HandleFunc("/api/admin/sessions", handleAdminSessions)
+	mux.HandleFunc("/api/admin/sessions/revoke", handleAdminSessionRevoke)
+	mux.HandleFunc("/api/admin/kiosk-devices", handleAdminKioskDevices)
+	mux.HandleFunc("/api/admin/kiosk-devices/end", handleAdminKioskDeviceEnd)
+	mux.HandleFunc("/api/openapi.json", handleOpenAPISpec)
 
 	// Dashboard & Kiosk
 	mux.HandleFunc("/dashboard", handleDashboard)
 	mux.HandleFunc("/kiosk", handleKioskPage)
+	mux.HandleFunc("/kiosk/board", handleKioskBoardPage)
+	mux.HandleFunc("/kiosk/board/events", handleKioskBoardEvents)
 
 	// Admin management pages
 	mux.HandleFunc("/admin/schedules", handleAdminSchedulesPage)
@@ -75,11 +186,24 @@ func registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/admin/terms", handleAdminTermsPage)
 	mux.HandleFunc("/admin/accounts", handleAdminAccountsPage)
 	mux.HandleFunc("/admin/features", handleAdminFeaturesPage)
+	mux.HandleFunc("/admin/permissions", handleAdminPermissionsPage)
 	mux.HandleFunc("/admin/notices", handleAdminNoticesPage)
 	mux.HandleFunc("/admin/grading", handleAdminGradingPage)
 	mux.HandleFunc("/admin/inactive", handleAdminInactivePage)
+	mux.HandleFunc("/admin/member-tags", handleAdminMemberTagsPage)
+	mux.HandleFunc("/admin/visitors", handleAdminVisitorsPage)
+	mux.HandleFunc("/admin/referrals", handleAdminReferralsPage)
+	mux.HandleFunc("/admin/timesheets", handleAdminTimesheetsPage)
+	mux.HandleFunc("/admin/class-packs", handleAdminClassPacksPage)
+	mux.HandleFunc("/availability", handleCoachAvailabilityPage)
 	mux.HandleFunc("/admin/milestones", handleAdminMilestonesPage)
 	mux.HandleFunc("/admin/perf", handleAdminPerfPage)
+	mux.HandleFunc("/api/admin/perf/history", handleAdminPerfHistory)
+	mux.HandleFunc("/metrics", handleMetrics)
+	mux.HandleFunc("/admin/backups", handleAdminBackupsPage)
+	mux.HandleFunc("/admin/jobs", handleAdminJobsPage)
+	mux.HandleFunc("/admin/sessions", handleAdminSessionsPage)
+	mux.HandleFunc("/admin/api-docs", handleAPIDocsPage)
 	mux.HandleFunc("/admin/self-estimates", handleSelfEstimatesPage)
 
 	// Member pages
@@ -94,36 +218,69 @@ func registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/privacy/delete/cancel", handlePrivacyDeleteCancel)
 	mux.HandleFunc("/privacy/consent", handlePrivacyConsentPage)
 	mux.HandleFunc("/api/privacy/consent/revoke", handlePrivacyConsentRevoke)
+	mux.HandleFunc("/privacy/export", handlePrivacyExportPage)
+	mux.HandleFunc("/api/me/export", handleMyExports)
+	mux.HandleFunc("/api/me/export/download", handleMyExportDownload)
 
 	// Class types API
 	mux.HandleFunc("/api/class-types", handleClassTypes)
+	mux.HandleFunc("/api/class-types/approvals", handleClassTypeApprovals)
 	mux.HandleFunc("/api/programs", handlePrograms)
 
 	// Layer 1b workflow routes
 	mux.HandleFunc("/api/notices/publish", handleNoticePublish)
 	mux.HandleFunc("/api/notices/edit", handleNoticeEdit)
 	mux.HandleFunc("/api/notices/pin", handleNoticePin)
+	mux.HandleFunc("/api/notices/images", handleNoticeImageUpload)
+	mux.HandleFunc("/api/notices/image", handleNoticeImage)
+	mux.HandleFunc("/api/notices/preview", handleNoticePreview)
 	mux.HandleFunc("/api/grading/proposals/decide", handleGradingDecide)
 	mux.HandleFunc("/api/grading/config", handleGradingConfig)
 	mux.HandleFunc("/api/grading/credit", handleGradingCredit)
 	mux.HandleFunc("/api/grading/force-promote", handleGradingForcePromote)
 	mux.HandleFunc("/api/grading/member-config", handleGradingMemberConfig)
 	mux.HandleFunc("/api/grading/readiness", handleGradingReadiness)
+	mux.HandleFunc("/api/grading/eligibility", handleGradingEligibility)
+	mux.HandleFunc("/api/grading/rules", handleGradingRules)
+	mux.HandleFunc("/api/grading/makeup-credits", handleMakeupCredits)
 	mux.HandleFunc("/api/grading/metric", handleGradingMetricToggle)
 	mux.HandleFunc("/api/grading/notes", handleGradingNotes)
+	mux.HandleFunc("/api/grading/rubrics", handleRubricTemplates)
+	mux.HandleFunc("/api/grading/rubrics/history", handleRubricHistory)
+	mux.HandleFunc("/api/grading/inventory", handleBeltInventory)
+	mux.HandleFunc("/api/grading/belt-sizes", handleBeltSizes)
+	mux.HandleFunc("/api/grading/pick-list", handleGradingPickList)
+	mux.HandleFunc("/api/grading/records", handleGradingRecords)
+	mux.HandleFunc("/api/grading/records/amend", handleGradingRecordAmend)
+	mux.HandleFunc("/api/grading/records/void", handleGradingRecordVoid)
+	mux.HandleFunc("/api/grading/bulk-award", handleGradingBulkAward)
+	mux.HandleFunc("/api/grading/import", handleGradingImport)
+	mux.HandleFunc("/api/grading/records/export", handleGradingRecordsExport)
 	mux.HandleFunc("/api/training-goals", handleTrainingGoals)
+	mux.HandleFunc("/api/training-goals/suggest", handleTrainingGoalSuggest)
 	mux.HandleFunc("/api/milestones", handleMilestones)
 	mux.HandleFunc("/api/member-milestones", handleMemberMilestones)
 	mux.HandleFunc("/api/member-milestones/dismiss", handleMemberMilestoneDismiss)
 	mux.HandleFunc("/api/messages/read", handleMessageRead)
+	mux.HandleFunc("/api/messages/threads", handleMessageThreads)
+	mux.HandleFunc("/api/messages/thread", handleMessageThread)
+	mux.HandleFunc("/api/messages/reply", handleMessageReply)
+	mux.HandleFunc("/api/messages/broadcast", handleMessageBroadcast)
+	mux.HandleFunc("/api/messages/images", handleMessageImageUpload)
+	mux.HandleFunc("/api/messages/image", handleMessageImage)
 
 	// Layer 2: Spine API routes
 	mux.HandleFunc("/api/themes", handleThemes)
+	mux.HandleFunc("/api/themes/min-belt", handleThemeMinBelt)
+	mux.HandleFunc("/api/themes/carousel", handleThemeCarousel)
 	mux.HandleFunc("/api/clips", handleClips)
 	mux.HandleFunc("/api/clips/promote", handleClipPromote)
+	mux.HandleFunc("/api/clips/min-belt", handleClipMinBelt)
 	mux.HandleFunc("/api/clips/tags", handleClipTags)
 	mux.HandleFunc("/api/clips/{clipID}/tags", handleClipTag)
 	mux.HandleFunc("/api/clips/search-by-tags", handleClipsSearchByTags)
+	mux.HandleFunc("/api/clips/search", handleClipsSearch)
+	mux.HandleFunc("/api/clips/taxonomy", handleClipTaxonomy)
 
 	// Layer 2: Spine pages
 	mux.HandleFunc("/themes", handleThemesPage)
@@ -131,32 +288,74 @@ func registerRoutes(mux *http.ServeMux) {
 
 	// Curriculum rotor system routes
 	mux.HandleFunc("/curriculum", handleCurriculumPage)
+	mux.HandleFunc("/session-logs", handleSessionLogsPage)
 	mux.HandleFunc("/api/rotors", handleRotors)
 	mux.HandleFunc("/api/rotors/by-id", handleRotorByID)
 	mux.HandleFunc("/api/rotors/activate", handleRotorActivate)
 	mux.HandleFunc("/api/rotors/preview", handleRotorPreview)
+	mux.HandleFunc("/api/rotors/advance-mode", handleRotorAdvanceMode)
+	mux.HandleFunc("/api/rotors/vote-rules", handleRotorVoteRules)
+	mux.HandleFunc("/api/rotors/export", handleRotorExport)
+	mux.HandleFunc("/api/rotors/import", handleRotorImport)
 	mux.HandleFunc("/api/rotors/themes", handleRotorThemes)
+	mux.HandleFunc("/api/rotors/themes/clone", handleRotorThemeClone)
+	mux.HandleFunc("/api/rotors/shared-topics", handleSharedTopics)
 	mux.HandleFunc("/api/rotors/topics", handleTopics)
 	mux.HandleFunc("/api/rotors/topics/reorder", handleTopicReorder)
 	mux.HandleFunc("/api/rotors/topics/bump", handleTopicBump)
 	mux.HandleFunc("/api/rotors/schedule/action", handleTopicScheduleAction)
 	mux.HandleFunc("/api/votes", handleVotes)
+	mux.HandleFunc("/api/votes/mine", handleMyVotes)
 	mux.HandleFunc("/api/curriculum/view", handleCurriculumView)
 	mux.HandleFunc("/api/curriculum/overview", handleCurriculumOverview)
+	mux.HandleFunc("/api/curriculum/preview", handleCurriculumPreview)
+	mux.HandleFunc("/api/curriculum/coverage", handleCurriculumCoverage)
+	mux.HandleFunc("/api/curriculum/history", handleCurriculumHistory)
+	mux.HandleFunc("/api/session-logs", handleSessionLogs)
 
 	// Calendar routes
 	mux.HandleFunc("/calendar", handleCalendarPage)
 	mux.HandleFunc("/api/calendar/events", handleCalendarEvents)
 	mux.HandleFunc("/api/calendar/interest", handleCompetitionInterest)
+	mux.HandleFunc("/api/calendar/interest/summary", handleCompetitionInterestSummary)
+	mux.HandleFunc("/api/calendar/roster", handleCompetitionRoster)
 	mux.HandleFunc("/api/calendar/rotors", handleCalendarRotors)
 
 	// Personal goals routes
 	mux.HandleFunc("/api/personal-goals", handlePersonalGoals)
 	mux.HandleFunc("/api/personal-goals/progress", handlePersonalGoalProgress)
+	mux.HandleFunc("/api/personal-goals/feedback", handlePersonalGoalFeedback)
+	mux.HandleFunc("/api/personal-goals/check-ins", handlePersonalGoalCheckIns)
+	mux.HandleFunc("/api/personal-goals/check-ins/due", handlePersonalGoalCheckInsDue)
+	mux.HandleFunc("/api/personal-goals/annotations", handlePersonalGoalAnnotations)
+
+	// Class feedback (members rate, coaches review)
+	mux.HandleFunc("/feedback", handleClassFeedbackPage)
+	mux.HandleFunc("/api/feedback", handleClassFeedback)
+	mux.HandleFunc("/api/feedback/pending", handleClassFeedbackPending)
+	mux.HandleFunc("/api/feedback/report", handleClassFeedbackReport)
+
+	// Celebrations (birthdays, training anniversaries and class milestones)
+	mux.HandleFunc("/api/celebrations", handleCelebrations)
+	mux.HandleFunc("/api/celebrations/profile", handleCelebrationProfile)
+
+	// Locations (multi-branch)
+	mux.HandleFunc("/api/locations", handleLocations)
+	mux.HandleFunc("/api/locations/assign", handleLocationAssign)
+	mux.HandleFunc("/api/session/location", handleSessionLocation)
+	mux.HandleFunc("/api/nav", handleNav)
+	mux.HandleFunc("/api/account/locale", handleAccountLocale)
+	mux.HandleFunc("/api/account/email", handleAccountEmail)
+	mux.HandleFunc("/account/email/confirm", handleConfirmEmailPage)
+	mux.HandleFunc("/api/account/email/confirm", handleConfirmEmail)
 
 	// Bug Box routes (Admin + Coach)
 	mux.HandleFunc("/api/admin/bugbox", handleBugBoxSubmit)
 	mux.HandleFunc("/api/admin/bugbox/screenshot", handleBugBoxScreenshot)
+	mux.HandleFunc("/api/admin/bugbox/list", handleBugBoxList)
+	mux.HandleFunc("/api/admin/bugbox/triage", handleBugBoxTriage)
+	mux.HandleFunc("/api/admin/bugbox/comments", handleBugBoxComments)
+	mux.HandleFunc("/bugbox", handleBugBoxPage)
 
 	// DevMode routes (admin-only impersonation)
 	mux.HandleFunc("/api/devmode/impersonate", handleDevModeImpersonate)
@@ -170,6 +369,11 @@ func registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/emails/send", handleEmailSend)
 	mux.HandleFunc("/api/emails/test-send", handleEmailTestSend)
 	mux.HandleFunc("/api/emails/detail", handleEmailDetail)
+	mux.HandleFunc("/api/emails/recipients/export", handleEmailRecipientsExport)
+	mux.HandleFunc("/api/emails/suppressions", handleEmailSuppressions)
+	mux.HandleFunc("/api/emails/contact-hygiene", handleContactHygiene)
+	mux.HandleFunc("/api/emails/unsubscribes", handleEmailUnsubscribes)
+	mux.HandleFunc("/api/webhooks/resend", handleResendWebhook)
 	mux.HandleFunc("/api/emails/delete", handleEmailDelete)
 	mux.HandleFunc("/api/emails/schedule", handleEmailSchedule)
 	mux.HandleFunc("/api/emails/cancel", handleEmailCancel)
@@ -178,6 +382,7 @@ func registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/emails/recipients/filter", handleMemberFilterForEmail)
 	mux.HandleFunc("/api/emails/recipients/by-session", handleRecipientsFilterBySession)
 	mux.HandleFunc("/api/emails/recipients/by-class-type", handleRecipientsFilterByClassType)
+	mux.HandleFunc("/api/emails/recipients/by-segment", handleRecipientsFilterBySegment)
 	mux.HandleFunc("/api/schedules/recent-sessions", handleRecentSessions)
 	mux.HandleFunc("/admin/emails/template", handleEmailTemplatePage)
 	mux.HandleFunc("/api/emails/template", func(w http.ResponseWriter, r *http.Request) {
@@ -188,4 +393,6 @@ func registerRoutes(mux *http.ServeMux) {
 		}
 	})
 	mux.HandleFunc("/api/emails/preview", handleEmailPreview)
+	mux.HandleFunc("/api/emails/library", handleEmailLibrary)
+	mux.HandleFunc("/api/emails/library/preview", handleEmailLibraryPreview)
 }
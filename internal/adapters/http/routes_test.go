@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
+	"sort"
 	"strings"
 	"testing"
 
@@ -100,6 +102,9 @@ func (m *mockMemberStore) SearchByName(ctx context.Context, query string, limit
 func (m *mockMemberStore) List(ctx context.Context, filter memberStore.ListFilter) ([]memberDomain.Member, error) {
 	var list []memberDomain.Member
 	for _, mem := range m.members {
+		if filter.IDs != nil && !slices.Contains(filter.IDs, mem.ID) {
+			continue
+		}
 		list = append(list, mem)
 	}
 	return list, nil
@@ -109,7 +114,8 @@ func (m *mockMemberStore) List(ctx context.Context, filter memberStore.ListFilte
 // PRE: filter has valid parameters
 // POST: Returns count of matching entities
 func (m *mockMemberStore) Count(ctx context.Context, filter memberStore.ListFilter) (int, error) {
-	return len(m.members), nil
+	list, _ := m.List(ctx, filter)
+	return len(list), nil
 }
 
 type mockAttendanceStore struct {
@@ -174,15 +180,39 @@ func (m *mockAttendanceStore) ListByMemberID(ctx context.Context, memberID strin
 
 // List implements the attendance store interface for testing.
 // PRE: filter has valid parameters
-// POST: Returns matching entities
+// POST: Returns one page of matching entities, newest first unless Dir is "asc"
 func (m *mockAttendanceStore) List(ctx context.Context, filter attendanceStore.ListFilter) ([]attendanceDomain.Attendance, error) {
 	var list []attendanceDomain.Attendance
 	for _, a := range m.attendances {
-		list = append(list, a)
+		d := a.CheckInTime.Format("2006-01-02")
+		if (filter.MemberID == "" || a.MemberID == filter.MemberID) && (filter.From == "" || d >= filter.From) && (filter.To == "" || d <= filter.To) {
+			list = append(list, a)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if filter.Dir == "asc" {
+			return list[i].CheckInTime.Before(list[j].CheckInTime)
+		}
+		return list[i].CheckInTime.After(list[j].CheckInTime)
+	})
+	if filter.Offset >= len(list) {
+		return nil, nil
+	}
+	list = list[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(list) {
+		list = list[:filter.Limit]
 	}
 	return list, nil
 }
 
+// TotalsByMemberID implements the attendance store interface for testing.
+// PRE: memberID is non-empty
+// POST: Returns the member's totals
+func (m *mockAttendanceStore) TotalsByMemberID(ctx context.Context, memberID string) (attendanceDomain.Totals, error) {
+	list, _ := m.ListByMemberID(ctx, memberID)
+	return attendanceDomain.Summarise(list), nil
+}
+
 // ListByMemberIDAndDate implements the attendance store interface for testing.
 // PRE: memberID is non-empty, date is YYYY-MM-DD
 // POST: Returns records matching memberID and date
@@ -196,6 +226,19 @@ func (m *mockAttendanceStore) ListByMemberIDAndDate(ctx context.Context, memberI
 	return list, nil
 }
 
+// ListByMemberScheduleAndClassDate implements the attendance store interface for testing.
+// PRE: memberID is non-empty, classDate is YYYY-MM-DD
+// POST: Returns the member's records for the class on its class date
+func (m *mockAttendanceStore) ListByMemberScheduleAndClassDate(ctx context.Context, memberID string, scheduleID string, classDate string) ([]attendanceDomain.Attendance, error) {
+	var list []attendanceDomain.Attendance
+	for _, a := range m.attendances {
+		if a.MemberID == memberID && a.ScheduleID == scheduleID && a.Date() == classDate {
+			list = append(list, a)
+		}
+	}
+	return list, nil
+}
+
 // ListDistinctMemberIDsByScheduleAndDate returns distinct member IDs for a specific session.
 // PRE: scheduleID and classDate are non-empty
 // POST: Returns distinct member IDs
@@ -230,6 +273,26 @@ func (m *mockAttendanceStore) ListDistinctMemberIDsByScheduleIDsSince(ctx contex
 	return ids, nil
 }
 
+// CountByScheduleIDAndDateRange implements the attendance store interface for testing.
+// PRE: scheduleID is non-empty, startDate and endDate are YYYY-MM-DD
+// POST: Returns distinct class dates per member within the range
+func (m *mockAttendanceStore) CountByScheduleIDAndDateRange(ctx context.Context, scheduleID string, startDate string, endDate string) (map[string]int, error) {
+	dates := map[string]map[string]bool{}
+	for _, a := range m.attendances {
+		if a.ScheduleID == scheduleID && a.ClassDate >= startDate && a.ClassDate <= endDate {
+			if dates[a.MemberID] == nil {
+				dates[a.MemberID] = map[string]bool{}
+			}
+			dates[a.MemberID][a.ClassDate] = true
+		}
+	}
+	counts := make(map[string]int, len(dates))
+	for id, d := range dates {
+		counts[id] = len(d)
+	}
+	return counts, nil
+}
+
 // ListByMemberIDAndDateRange implements the attendance store interface for testing.
 // PRE: memberID, startDate, endDate are non-empty
 // POST: Returns records within the date range
@@ -294,6 +357,20 @@ func (m *mockAttendanceStore) SumMatHoursByMemberIDAndDateRange(ctx context.Cont
 	return total, nil
 }
 
+// SaveTopicLinks implements the attendance store interface for testing.
+// PRE: links are complete
+// POST: no-op
+func (m *mockAttendanceStore) SaveTopicLinks(ctx context.Context, links []attendanceDomain.TopicLink) error {
+	return nil
+}
+
+// ListTopicLinksByRotor implements the attendance store interface for testing.
+// PRE: rotorID is non-empty
+// POST: Returns no links
+func (m *mockAttendanceStore) ListTopicLinksByRotor(ctx context.Context, rotorID string) ([]attendanceDomain.TopicLink, error) {
+	return nil, nil
+}
+
 type mockInjuryStore struct {
 	injuries map[string]injuryDomain.Injury
 }
@@ -338,6 +415,19 @@ func (m *mockInjuryStore) List(ctx context.Context, filter injuryStore.ListFilte
 	return list, nil
 }
 
+// ListByMemberID implements the injury store interface for testing.
+// PRE: memberID is non-empty
+// POST: Returns the member's injuries
+func (m *mockInjuryStore) ListByMemberID(ctx context.Context, memberID string) ([]injuryDomain.Injury, error) {
+	var list []injuryDomain.Injury
+	for _, i := range m.injuries {
+		if i.MemberID == memberID {
+			list = append(list, i)
+		}
+	}
+	return list, nil
+}
+
 type mockWaiverStore struct {
 	waivers map[string]waiverDomain.Waiver
 }
@@ -372,6 +462,20 @@ func (m *mockWaiverStore) GetByMemberID(ctx context.Context, memberID string) (w
 	return waiverDomain.Waiver{}, fmt.Errorf("waiver not found")
 }
 
+// ListByMemberID implements the waiver store interface for testing.
+// PRE: memberID is non-empty
+// POST: Returns the member's waivers, newest first
+func (m *mockWaiverStore) ListByMemberID(ctx context.Context, memberID string) ([]waiverDomain.Waiver, error) {
+	list := []waiverDomain.Waiver{}
+	for _, w := range m.waivers {
+		if w.MemberID == memberID {
+			list = append(list, w)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].SignedAt.After(list[j].SignedAt) })
+	return list, nil
+}
+
 // Save implements the waiver store interface for testing.
 // PRE: entity has been validated
 // POST: Entity is persisted
@@ -0,0 +1,105 @@
+package web
+
+import (
+	"embed"
+	"html/template"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// embeddedTemplates ships the page templates inside the binary.
+//
+//go:embed templates
+var embeddedTemplates embed.FS
+
+// templatesDir is the template directory on disk, relative to the repository root.
+const templatesDir = "internal/adapters/http/templates"
+
+// templateSet parses each page once and hands out per-request clones.
+// Cloning lets session-dependent helpers be rebound without reparsing.
+type templateSet struct {
+	source fs.FS
+	reload bool // reparse on every request so template edits show up without a restart
+
+	mu     sync.Mutex
+	parsed map[string]*template.Template
+}
+
+// templates serves the pages (replaced by configureTemplates in NewMux).
+var templates = newTemplateSet(embeddedTemplateFS(), false)
+
+// newTemplateSet creates an empty template set reading from source.
+func newTemplateSet(source fs.FS, reload bool) *templateSet {
+	return &templateSet{source: source, reload: reload, parsed: make(map[string]*template.Template)}
+}
+
+// embeddedTemplateFS returns the embedded templates rooted at the templates directory.
+func embeddedTemplateFS() fs.FS {
+	sub, err := fs.Sub(embeddedTemplates, "templates")
+	if err != nil {
+		panic(err) // "templates" is a fixed, valid path
+	}
+	return sub
+}
+
+// configureTemplates picks where templates come from. Production always uses the embedded
+// copy; a development server started from the repository root reads and reparses them from
+// disk so edits show up on the next request.
+func configureTemplates(production bool) {
+	if !production {
+		if info, err := os.Stat(templatesDir); err == nil && info.IsDir() {
+			templates = newTemplateSet(os.DirFS(templatesDir), true)
+			log.Printf("Templates reload from %s on every request (development)", templatesDir)
+			return
+		}
+	}
+	templates = newTemplateSet(embeddedTemplateFS(), false)
+}
+
+// forRequest returns a template ready to execute for r, with its helpers bound to r's session.
+// Pages are wrapped in layout.html when withLayout is true; otherwise they stand alone.
+func (s *templateSet) forRequest(name string, withLayout bool, r *http.Request) (*template.Template, error) {
+	base, err := s.lookup(name, withLayout)
+	if err != nil {
+		return nil, err
+	}
+	tpl, err := base.Clone()
+	if err != nil {
+		return nil, err
+	}
+	return tpl.Funcs(templateFuncs(r)), nil
+}
+
+// lookup returns the parsed template, parsing and caching it on first use.
+func (s *templateSet) lookup(name string, withLayout bool) (*template.Template, error) {
+	key := name
+	if withLayout {
+		key = "layout.html+" + name
+	}
+	if s.reload {
+		return s.parse(name, withLayout)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tpl, ok := s.parsed[key]; ok {
+		return tpl, nil
+	}
+	tpl, err := s.parse(name, withLayout)
+	if err != nil {
+		return nil, err
+	}
+	s.parsed[key] = tpl
+	return tpl, nil
+}
+
+// parse reads the page (and layout) from the source.
+func (s *templateSet) parse(name string, withLayout bool) (*template.Template, error) {
+	if withLayout {
+		return template.New("layout.html").Funcs(templateFuncs(nil)).ParseFS(s.source, "layout.html", name)
+	}
+	return template.New(name).Funcs(templateFuncs(nil)).ParseFS(s.source, name)
+}
@@ -0,0 +1,209 @@
+package web
+
+import (
+	"bytes"
+	"io/fs"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"text/template/parse"
+	"unicode"
+
+	"workshop/internal/adapters/http/i18n"
+	"workshop/internal/adapters/http/middleware"
+)
+
+// TestEmbeddedTemplates_AllParse verifies every embedded page parses with the layout.
+func TestEmbeddedTemplates_AllParse(t *testing.T) {
+	set := newTemplateSet(embeddedTemplateFS(), false)
+	names, err := fs.Glob(set.source, "*.html")
+	if err != nil || len(names) == 0 {
+		t.Fatalf("no embedded templates: %v", err)
+	}
+	for _, name := range names {
+		if name == "layout.html" {
+			continue
+		}
+		if _, err := set.lookup(name, name != "kiosk.html"); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+// TestTemplateSet_CachesAndRebindsHelpers verifies pages parse once yet see each request's session.
+func TestTemplateSet_CachesAndRebindsHelpers(t *testing.T) {
+	stores = newFullStores()
+	source := fstest.MapFS{
+		"layout.html": {Data: []byte(`{{ template "content" . }}`)},
+		"page.html":   {Data: []byte(`{{ define "content" }}role={{ currentRole }}{{ end }}`)},
+	}
+	set := newTemplateSet(source, false)
+
+	render := func(sess middleware.Session) string {
+		t.Helper()
+		tpl, err := set.forRequest("page.html", true, authRequest("GET", "/", "", sess))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, nil); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	if got := render(adminSession); got != "role=admin" {
+		t.Errorf("admin render = %q", got)
+	}
+	if got := render(memberSession); got != "role=member" {
+		t.Errorf("member render = %q", got)
+	}
+
+	// A cached set ignores later edits; a reloading set picks them up.
+	source["page.html"] = &fstest.MapFile{Data: []byte(`{{ define "content" }}edited{{ end }}`)}
+	if got := render(adminSession); !strings.HasPrefix(got, "role=") {
+		t.Errorf("cached set reparsed: %q", got)
+	}
+	set.reload = true
+	if got := render(adminSession); got != "edited" {
+		t.Errorf("reloading set served stale template: %q", got)
+	}
+}
+
+// translatedTemplates are the member-facing pages whose text all goes through the t helper.
+// New text on them must be added to every locale file under i18n/locales (an empty value is
+// a placeholder until it is translated).
+var translatedTemplates = []string{"login.html", "dashboard_member.html"}
+
+// untranslatedMarkup matches what is not page text: scripts, styles, comments, tags and entities.
+var untranslatedMarkup = regexp.MustCompile(`(?s)<script.*?</script>|<style.*?</style>|<!--.*?-->|<[^>]*>|&[#a-zA-Z0-9]+;`)
+
+// templateText walks a parsed template, collecting its literal text and the keys passed to t.
+func templateText(node parse.Node, text *strings.Builder, keys *[]string) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			templateText(child, text, keys)
+		}
+	case *parse.TextNode:
+		text.Write(n.Text)
+	case *parse.ActionNode:
+		text.WriteString(" ")
+		templateText(n.Pipe, text, keys)
+	case *parse.PipeNode:
+		for _, cmd := range n.Cmds {
+			templateText(cmd, text, keys)
+		}
+	case *parse.CommandNode:
+		if len(n.Args) > 1 {
+			if fn, ok := n.Args[0].(*parse.IdentifierNode); ok && fn.Ident == "t" {
+				if key, ok := n.Args[1].(*parse.StringNode); ok {
+					*keys = append(*keys, key.Text)
+				}
+			}
+		}
+		for _, arg := range n.Args {
+			templateText(arg, text, keys)
+		}
+	case *parse.IfNode:
+		templateText(n.Pipe, text, keys)
+		templateText(n.List, text, keys)
+		templateText(n.ElseList, text, keys)
+	case *parse.RangeNode:
+		templateText(n.Pipe, text, keys)
+		templateText(n.List, text, keys)
+		templateText(n.ElseList, text, keys)
+	case *parse.WithNode:
+		templateText(n.Pipe, text, keys)
+		templateText(n.List, text, keys)
+		templateText(n.ElseList, text, keys)
+	}
+}
+
+// parseTemplateText returns the literal text of a page and the translation keys it uses.
+func parseTemplateText(t *testing.T, name string) (string, []string) {
+	t.Helper()
+	data, err := fs.ReadFile(embeddedTemplateFS(), name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := parse.New(name)
+	tree.Mode = parse.SkipFuncCheck
+	trees := map[string]*parse.Tree{}
+	if _, err := tree.Parse(string(data), "{{", "}}", trees); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	var text strings.Builder
+	var keys []string
+	for _, tree := range trees {
+		templateText(tree.Root, &text, &keys)
+	}
+	return text.String(), keys
+}
+
+// TestTemplates_TranslationKeysExist verifies every key a page passes to t is in the locale files.
+func TestTemplates_TranslationKeysExist(t *testing.T) {
+	names, err := fs.Glob(embeddedTemplateFS(), "*.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		_, keys := parseTemplateText(t, name)
+		for _, key := range keys {
+			if !i18n.HasKey(key) {
+				t.Errorf("%s: translation key %q is not in locales/%s.json", name, key, i18n.DefaultLocale)
+			}
+		}
+	}
+}
+
+// TestTranslatedTemplates_NoRawText verifies member-facing pages have no text outside t,
+// so new strings cannot land without a translation placeholder.
+func TestTranslatedTemplates_NoRawText(t *testing.T) {
+	for _, name := range translatedTemplates {
+		text, _ := parseTemplateText(t, name)
+		for _, line := range strings.Split(untranslatedMarkup.ReplaceAllString(text, " "), "\n") {
+			line = strings.TrimSpace(line)
+			if strings.IndexFunc(line, unicode.IsLetter) >= 0 {
+				t.Errorf("%s: untranslated text %q; move it into the locale files and use {{ t \"key\" }}", name, line)
+			}
+		}
+	}
+}
+
+// TestTemplateSet_RendersRequestLocale verifies pages render in the locale chosen for the request.
+func TestTemplateSet_RendersRequestLocale(t *testing.T) {
+	stores = newFullStores()
+	source := fstest.MapFS{
+		"layout.html": {Data: []byte(`<html lang="{{ currentLocale }}">{{ template "content" . }}</html>`)},
+		"page.html":   {Data: []byte(`{{ define "content" }}{{ t "nav.logout" }}|{{ t "dashboard.welcome_back" "Aroha" }}|{{ t "dashboard.quick_links" }}{{ end }}`)},
+	}
+	set := newTemplateSet(source, false)
+
+	render := func(locale string) string {
+		t.Helper()
+		r := authRequest("GET", "/", "", memberSession)
+		r = r.WithContext(i18n.WithLocale(r.Context(), locale))
+		tpl, err := set.forRequest("page.html", true, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, nil); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	if got := render("en-NZ"); got != `<html lang="en-NZ">Logout|Welcome back, Aroha|Quick Links</html>` {
+		t.Errorf("en-NZ render = %q", got)
+	}
+	// Untranslated placeholders fall back to en-NZ.
+	if got := render("mi"); got != `<html lang="mi">Takiputa|Nau mai anō, Aroha|Quick Links</html>` {
+		t.Errorf("mi render = %q", got)
+	}
+}
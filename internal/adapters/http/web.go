@@ -1,45 +1,72 @@
 package web
 
 import (
-	"crypto/rand"
-	"encoding/hex"
+	"io/fs"
 	"log"
 	"net/http"
-	"os"
 	"time"
 
 	"workshop/internal/adapters/email"
+	"workshop/internal/adapters/http/events"
 	"workshop/internal/adapters/http/middleware"
 	"workshop/internal/adapters/http/perf"
+	"workshop/internal/adapters/oidc"
 	accountStore "workshop/internal/adapters/storage/account"
 	attendanceStore "workshop/internal/adapters/storage/attendance"
 	auditStore "workshop/internal/adapters/storage/audit"
+	authSessionStore "workshop/internal/adapters/storage/authsession"
+	availabilityStore "workshop/internal/adapters/storage/availability"
 	bugboxStore "workshop/internal/adapters/storage/bugbox"
 	calendarStore "workshop/internal/adapters/storage/calendar"
+	celebrationStore "workshop/internal/adapters/storage/celebration"
+	classPackStore "workshop/internal/adapters/storage/classpack"
 	classTypeStore "workshop/internal/adapters/storage/classtype"
 	clipStore "workshop/internal/adapters/storage/clip"
 	consentStore "workshop/internal/adapters/storage/consent"
+	contactCheckStore "workshop/internal/adapters/storage/contactcheck"
 	deletionStore "workshop/internal/adapters/storage/deletion"
 	emailStore "workshop/internal/adapters/storage/email"
 	estimatedHoursStore "workshop/internal/adapters/storage/estimatedhours"
+	exportStore "workshop/internal/adapters/storage/export"
 	featureFlagStore "workshop/internal/adapters/storage/featureflag"
+	feedbackStore "workshop/internal/adapters/storage/feedback"
 	gradingStore "workshop/internal/adapters/storage/grading"
 	holidayStore "workshop/internal/adapters/storage/holiday"
 	injuryStore "workshop/internal/adapters/storage/injury"
+	inventoryStore "workshop/internal/adapters/storage/inventory"
+	jobStore "workshop/internal/adapters/storage/job"
+	kioskStore "workshop/internal/adapters/storage/kiosk"
+	kpiStore "workshop/internal/adapters/storage/kpi"
+	locationStore "workshop/internal/adapters/storage/location"
 	memberStore "workshop/internal/adapters/storage/member"
+	memberTagStore "workshop/internal/adapters/storage/membertag"
 	messageStore "workshop/internal/adapters/storage/message"
 	milestoneStore "workshop/internal/adapters/storage/milestone"
 	noticeStore "workshop/internal/adapters/storage/notice"
+	notificationStore "workshop/internal/adapters/storage/notification"
 	observationStore "workshop/internal/adapters/storage/observation"
 	outboxStore "workshop/internal/adapters/storage/outbox"
+	perfStatsStore "workshop/internal/adapters/storage/perfstats"
+	permissionStore "workshop/internal/adapters/storage/permission"
 	personalgoalStore "workshop/internal/adapters/storage/personalgoal"
 	programStore "workshop/internal/adapters/storage/program"
+	quickCaptureStore "workshop/internal/adapters/storage/quickcapture"
+	reengagementStore "workshop/internal/adapters/storage/reengagement"
+	referralStore "workshop/internal/adapters/storage/referral"
 	rotorStore "workshop/internal/adapters/storage/rotor"
+	rubricStore "workshop/internal/adapters/storage/rubric"
 	scheduleStore "workshop/internal/adapters/storage/schedule"
+	searchStore "workshop/internal/adapters/storage/search"
+	sessionLogStore "workshop/internal/adapters/storage/sessionlog"
+	statusChangeStore "workshop/internal/adapters/storage/statuschange"
 	termStore "workshop/internal/adapters/storage/term"
 	themeStore "workshop/internal/adapters/storage/theme"
+	timesheetStore "workshop/internal/adapters/storage/timesheet"
 	trainingGoalStore "workshop/internal/adapters/storage/traininggoal"
+	visitorStore "workshop/internal/adapters/storage/visitor"
 	waiverStore "workshop/internal/adapters/storage/waiver"
+	"workshop/internal/adapters/webpush"
+	"workshop/internal/config"
 )
 
 // Stores holds all storage dependencies.
@@ -50,19 +77,28 @@ type Stores struct {
 	WaiverStore              waiverStore.Store
 	InjuryStore              injuryStore.Store
 	AttendanceStore          attendanceStore.Store
+	MakeupCreditStore        attendanceStore.MakeupCreditStore
 	ProgramStore             programStore.Store
 	ClassTypeStore           classTypeStore.Store
+	ClassTypeApprovalStore   classTypeStore.ApprovalStore
 	ScheduleStore            scheduleStore.Store
+	OccurrenceChangeStore    scheduleStore.OccurrenceChangeStore
 	TermStore                termStore.Store
+	TermRolloverStore        termStore.RolloverStore
+	TermReportStore          termStore.ReportStore
 	HolidayStore             holidayStore.Store
 	NoticeStore              noticeStore.Store
 	GradingRecordStore       gradingStore.RecordStore
 	GradingConfigStore       gradingStore.ConfigStore
 	GradingProposalStore     gradingStore.ProposalStore
+	GradingCeremonyStore     gradingStore.CeremonyStore
 	GradingNoteStore         gradingStore.NoteStore
+	ProposalCommentStore     gradingStore.ProposalCommentStore
 	GradingMemberConfigStore gradingStore.MemberConfigStore
+	GradingRuleStore         gradingStore.RuleStore
 	MessageStore             messageStore.Store
 	ObservationStore         observationStore.Store
+	QuickCaptureStore        quickCaptureStore.Store
 	MilestoneStore           milestoneStore.Store
 	MemberMilestoneStore     milestoneStore.MemberMilestoneStore
 	TrainingGoalStore        trainingGoalStore.Store
@@ -71,39 +107,80 @@ type Stores struct {
 	ClipTagStore             clipStore.TagStore
 	ClipComparisonStore      clipStore.ComparisonStore
 	EmailStore               emailStore.Store
+	EmailPreferenceStore     emailStore.PreferenceStore
+	EmailLibraryStore        emailStore.LibraryStore
 	EstimatedHoursStore      estimatedHoursStore.Store
 	RotorStore               rotorStore.Store
 	CalendarEventStore       calendarStore.Store
-	CompetitionInterestStore *calendarStore.SQLiteStore
+	CompetitionInterestStore calendarStore.InterestStore
 	BugBoxStore              bugboxStore.Store
 	OutboxStore              outboxStore.Store
 	PersonalGoalStore        personalgoalStore.Store
+	GoalCheckInStore         personalgoalStore.CheckInStore
+	GoalAnnotationStore      personalgoalStore.AnnotationStore
 	DeletionRequestStore     deletionStore.Store
 	ConsentStore             consentStore.Store
 	AuditStore               auditStore.Store
+	LocationStore            locationStore.Store
+	NotificationStore        notificationStore.Store
+	SessionLogStore          sessionLogStore.Store
+	SearchStore              searchStore.Store
+	PermissionStore          permissionStore.Store
+	AuthSessionStore         authSessionStore.Store
+	KPISnapshotStore         kpiStore.Store
+	RubricTemplateStore      rubricStore.TemplateStore
+	RubricScoreStore         rubricStore.ScoreStore
+	BeltInventoryStore       inventoryStore.Store
+	VisitorStore             visitorStore.Store
+	ReengagementStore        reengagementStore.Store
+	TimesheetStore           timesheetStore.Store
+	ExportStore              exportStore.Store
+	KioskDeviceStore         kioskStore.Store
+	StatusChangeStore        statusChangeStore.Store
+	AvailabilityStore        availabilityStore.Store
+	MemberTagStore           memberTagStore.Store
+	MemberMergeStore         memberStore.MergeStore
+	PerfBucketStore          perfStatsStore.Store
+	ReferralStore            referralStore.Store
+	JobStore                 jobStore.Store
+	AccountEmailChangeStore  accountStore.EmailChangeStore
+	ClassFeedbackStore       feedbackStore.Store
+	CelebrationStore         celebrationStore.Store
+	ContactCheckStore        contactCheckStore.Store
+	ClassPackStore           classPackStore.Store
 }
 
-// loadCSRFKey reads the CSRF secret from WORKSHOP_CSRF_KEY (hex-encoded, 32 bytes).
-// In production, the key MUST be set. In development, a random key is generated per startup.
-func loadCSRFKey() []byte {
-	if keyHex := os.Getenv("WORKSHOP_CSRF_KEY"); keyHex != "" {
-		key, err := hex.DecodeString(keyHex)
-		if err != nil || len(key) != 32 {
-			log.Fatal("WORKSHOP_CSRF_KEY must be 64 hex characters (32 bytes)")
-		}
-		return key
-	}
-	if os.Getenv("WORKSHOP_ENV") == "production" {
-		log.Fatal("WORKSHOP_CSRF_KEY is required in production")
-	}
-	key := make([]byte, 32)
-	if _, err := rand.Read(key); err != nil {
-		log.Fatalf("failed to generate CSRF key: %v", err)
+// appConfig is the validated server configuration (set by SetConfig).
+var appConfig config.Config
+
+// SetConfig supplies the configuration NewMux and GET /api/admin/config use.
+// Without it NewMux falls back to development defaults with random keys.
+func SetConfig(c config.Config) {
+	appConfig = c
+}
+
+// securityPolicy returns the security headers policy for the configuration in effect.
+// The kiosk pages may be framed by the configured ancestors, e.g. a signage player showing the TV board.
+func securityPolicy() middleware.SecurityPolicy {
+	kiosk := appConfig.Security.FrameAncestors
+	return middleware.SecurityPolicy{
+		HSTSMaxAge: appConfig.Security.HSTSMaxAge,
+		Frames: []middleware.FrameRule{
+			{Path: "/kiosk", Sources: kiosk},
+			{Path: "/kiosk/", Sources: kiosk},
+		},
 	}
-	log.Println("WARNING: using random CSRF key (sessions won't survive restart). Set WORKSHOP_CSRF_KEY for production.")
-	return key
 }
 
+// Global check-in QR signing key (set by NewMux)
+var checkInQRKey []byte
+
+// Global unsubscribe link signing key (set by NewMux)
+var unsubscribeKey []byte
+
+// Global bearer token for GET /metrics (set by NewMux); empty disables the endpoint
+var metricsToken string
+
 // Global stores instance (set by NewMux)
 var stores *Stores
 
@@ -113,9 +190,19 @@ var sessions *middleware.SessionStore
 // RateLimitPerSecond controls the per-IP rate limit. Tests can increase this.
 var RateLimitPerSecond = 10
 
+// AuthLimitConfig controls the per-IP and per-email limits on credential endpoints.
+// Set before NewMux; tests can increase this.
+var AuthLimitConfig = middleware.DefaultAuthLimitConfig
+
+// authLimiter throttles credential endpoints (set by NewMux); admin unlock resets it.
+var authLimiter *middleware.AuthLimiter
+
 // Global perf collector (set by NewMux)
 var perfCollector *perf.Collector
 
+// Global live event hub; GET /api/events/stream subscribes to it
+var eventHub = events.NewHub(events.DefaultHistorySize)
+
 // Global email sender instance (set by SetEmailSender)
 var emailSender email.Sender
 
@@ -130,29 +217,67 @@ func SetEmailSender(sender email.Sender, from, replyTo string) {
 	emailReplyTo = replyTo
 }
 
-// NewMux wires HTTP handlers for the app.
-func NewMux(staticDir string, s *Stores, collector *perf.Collector) http.Handler {
+// Global Web Push sender (set by SetPushSender); nil disables the push channel
+var pushSender webpush.Sender
+
+// pushPublicKey is the VAPID public key browsers subscribe with
+var pushPublicKey string
+
+// SetPushSender enables the push notification channel.
+func SetPushSender(sender webpush.Sender, publicKey string) {
+	pushSender = sender
+	pushPublicKey = publicKey
+}
+
+// Global single sign-on provider (set by SetSSOProvider); nil keeps password login only
+var ssoProvider oidc.Authenticator
+
+// SetSSOProvider offers sign-in through an OpenID Connect provider on the login page.
+func SetSSOProvider(provider oidc.Authenticator) {
+	ssoProvider = provider
+}
+
+// NewMux wires HTTP handlers for the app. static is served from the site root.
+func NewMux(static fs.FS, s *Stores, collector *perf.Collector) http.Handler {
 	stores = s
 	perfCollector = collector
-	sessions = middleware.NewSessionStore()
-	middleware.SecureCookies = os.Getenv("WORKSHOP_ENV") == "production"
+	sessions = middleware.NewSessionStore(s.AuthSessionStore)
+	if appConfig.CSRFKey == nil {
+		defaults, err := config.Parse(func(string) (string, bool) { return "", false }, nil)
+		if err != nil {
+			log.Fatalf("default config: %v", err)
+		}
+		appConfig = defaults
+	}
+	middleware.SecureCookies = appConfig.IsProduction()
+	middleware.TrustedProxies = appConfig.Security.TrustedProxies
+	configureTemplates(appConfig.IsProduction())
 
 	mux := http.NewServeMux()
-	mux.Handle("/", http.FileServer(http.Dir(staticDir)))
-	registerRoutes(mux)
+	mux.Handle("/", http.FileServerFS(static))
+	registerRoutes(policyMux{mux: mux})
 
-	// CSRF key: 32-byte hex-encoded secret from env var
-	csrfKey := loadCSRFKey()
+	csrfKey := appConfig.CSRFKey
+	checkInQRKey = appConfig.QRKey
+	unsubscribeKey = appConfig.Email.UnsubscribeKey
+	metricsToken = appConfig.MetricsToken
 
 	// Rate limiter: configurable requests per second per IP (OWASP A04)
 	limiter := middleware.NewRateLimiter(RateLimitPerSecond, time.Second)
 
-	// Apply middleware: Timing -> Auth -> CSRF -> SecurityHeaders -> RateLimit -> Mux
-	return middleware.Chain(mux,
-		middleware.SecurityHeaders,
+	// Credential endpoints get a much stricter per-IP and per-email budget (OWASP A07)
+	authLimiter = middleware.NewAuthLimiter(AuthLimitConfig, time.Now)
+
+	// Apply middleware: RequestID -> Timing -> RateLimit -> AuthRateLimit -> Auth -> Locale -> CSRF -> SecurityHeaders -> guardImpersonation -> Mux
+	return middleware.Chain(middleware.CaptureRoute(mux),
+		guardImpersonation,
+		middleware.SecurityHeaders(securityPolicy()),
 		middleware.CSRF(csrfKey),
+		middleware.Locale,
 		middleware.Auth(sessions),
+		middleware.AuthRateLimit(authLimiter, authRateLimitedPaths...),
 		middleware.RateLimit(limiter),
 		middleware.Timing(collector),
+		middleware.RequestID,
 	)
 }
@@ -0,0 +1,361 @@
+// Package oidc signs people in through an OpenID Connect provider such as Google, using the
+// authorization code flow with PKCE. Discovery, the token exchange and RS256 ID token checks
+// are done with the standard library, so no SDK is needed.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GoogleIssuer is the issuer used when none is configured.
+const GoogleIssuer = "https://accounts.google.com"
+
+// clockSkew is how far the provider's clock may drift from ours when checking token times.
+const clockSkew = 2 * time.Minute
+
+// keyRefreshInterval limits how often an unknown key ID triggers a fresh JWKS fetch.
+const keyRefreshInterval = time.Minute
+
+// ErrInvalidToken means the ID token failed a signature, issuer, audience, expiry or nonce check.
+var ErrInvalidToken = errors.New("sign-in provider returned an invalid ID token")
+
+// Identity is who the provider says signed in.
+type Identity struct {
+	Issuer        string
+	Subject       string // stable user ID at the issuer
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Config identifies the client registered with the provider.
+type Config struct {
+	Issuer       string // e.g. GoogleIssuer
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string // must match the redirect URI registered with the provider
+	Name         string // shown on the sign-in button, e.g. "Google"
+}
+
+// Authenticator is the interface for signing in through an external provider.
+type Authenticator interface {
+	// Name is the provider's display name.
+	Name() string
+	// AuthURL returns where to send the browser to sign in.
+	AuthURL(ctx context.Context, state, nonce, verifier string) (string, error)
+	// Exchange redeems the code from the callback and returns the verified identity.
+	Exchange(ctx context.Context, code, verifier, nonce string) (Identity, error)
+}
+
+// discovery is the subset of the provider's openid-configuration document we use.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider is an Authenticator for one OpenID Connect issuer.
+type Provider struct {
+	config Config
+	client *http.Client
+	now    func() time.Time
+
+	mu          sync.Mutex
+	discovered  *discovery
+	keys        map[string]*rsa.PublicKey
+	keysFetched time.Time
+}
+
+// NewProvider returns a provider for the configured client.
+// PRE: config has Issuer, ClientID, ClientSecret and RedirectURL set
+// POST: Returns a provider; discovery happens on first use
+func NewProvider(config Config) (*Provider, error) {
+	if config.Issuer == "" || config.ClientID == "" || config.ClientSecret == "" || config.RedirectURL == "" {
+		return nil, fmt.Errorf("oidc provider needs issuer, client ID, client secret and redirect URL")
+	}
+	config.Issuer = strings.TrimRight(config.Issuer, "/")
+	if config.Name == "" {
+		config.Name = config.Issuer
+	}
+	return &Provider{config: config, client: &http.Client{Timeout: 10 * time.Second}, now: time.Now}, nil
+}
+
+// Name returns the provider's display name.
+// PRE: none
+// POST: Returns a non-empty name
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// AuthURL returns the provider's authorization URL for a sign-in attempt.
+// PRE: state, nonce and verifier are fresh random values kept by the caller until the callback
+// POST: Returns a URL requesting the openid, email and profile scopes with an S256 PKCE challenge
+func (p *Provider) AuthURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {"openid email profile"},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {Challenge(verifier)},
+		"code_challenge_method": {"S256"},
+		"prompt":                {"select_account"},
+	}
+	separator := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return d.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// tokenResponse is the subset of the token endpoint response we use.
+type tokenResponse struct {
+	IDToken string `json:"id_token"`
+}
+
+// Exchange redeems an authorization code and verifies the returned ID token.
+// PRE: code came from the callback whose state matched; verifier and nonce are the ones sent
+// POST: Returns the identity, or ErrInvalidToken when the ID token fails any check
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (Identity, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return Identity{}, err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"client_id":     {p.config.ClientID},
+		"client_secret": {p.config.ClientSecret},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Identity{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	var token tokenResponse
+	if err := p.doJSON(req, &token); err != nil {
+		return Identity{}, fmt.Errorf("token exchange: %w", err)
+	}
+	if token.IDToken == "" {
+		return Identity{}, ErrInvalidToken
+	}
+	return p.verify(ctx, d, token.IDToken, nonce)
+}
+
+// idTokenHeader is the JOSE header of an ID token.
+type idTokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// idTokenClaims are the ID token claims we check or use.
+type idTokenClaims struct {
+	Issuer        string          `json:"iss"`
+	Subject       string          `json:"sub"`
+	Audience      json.RawMessage `json:"aud"` // a string or an array of strings
+	Expiry        int64           `json:"exp"`
+	IssuedAt      int64           `json:"iat"`
+	Nonce         string          `json:"nonce"`
+	Email         string          `json:"email"`
+	EmailVerified json.RawMessage `json:"email_verified"` // true, or "true" from some providers
+	Name          string          `json:"name"`
+}
+
+// verify checks an ID token's RS256 signature against the provider's keys, then its issuer,
+// audience, expiry and nonce.
+func (p *Provider) verify(ctx context.Context, d *discovery, raw, nonce string) (Identity, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return Identity{}, ErrInvalidToken
+	}
+	var header idTokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil || header.Algorithm != "RS256" {
+		return Identity{}, ErrInvalidToken
+	}
+	key, err := p.key(ctx, d, header.KeyID)
+	if err != nil {
+		return Identity{}, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Identity{}, ErrInvalidToken
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) != nil {
+		return Identity{}, ErrInvalidToken
+	}
+
+	var claims idTokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return Identity{}, ErrInvalidToken
+	}
+	now := p.now()
+	switch {
+	case claims.Issuer != d.Issuer,
+		!hasAudience(claims.Audience, p.config.ClientID),
+		claims.Subject == "",
+		now.After(time.Unix(claims.Expiry, 0).Add(clockSkew)),
+		claims.IssuedAt != 0 && time.Unix(claims.IssuedAt, 0).After(now.Add(clockSkew)),
+		nonce == "" || claims.Nonce != nonce:
+		return Identity{}, ErrInvalidToken
+	}
+	return Identity{
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: string(claims.EmailVerified) == "true" || string(claims.EmailVerified) == `"true"`,
+		Name:          claims.Name,
+	}, nil
+}
+
+// hasAudience reports whether the aud claim names clientID.
+func hasAudience(raw json.RawMessage, clientID string) bool {
+	var single string
+	if json.Unmarshal(raw, &single) == nil {
+		return single == clientID
+	}
+	var many []string
+	if json.Unmarshal(raw, &many) == nil {
+		for _, aud := range many {
+			if aud == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// discover fetches and caches the provider's openid-configuration document.
+func (p *Provider) discover(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovered != nil {
+		return p.discovered, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", p.config.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	var d discovery
+	if err := p.doJSON(req, &d); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	if d.Issuer != p.config.Issuer || d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc discovery: incomplete document or issuer %q does not match %q", d.Issuer, p.config.Issuer)
+	}
+	p.discovered = &d
+	return p.discovered, nil
+}
+
+// jsonWebKey is one RSA key from the provider's JWKS.
+type jsonWebKey struct {
+	KeyType  string `json:"kty"`
+	KeyID    string `json:"kid"`
+	Modulus  string `json:"n"`
+	Exponent string `json:"e"`
+}
+
+// key returns the signing key with the given ID, refetching the JWKS when the ID is unknown
+// (providers rotate keys) but at most once per keyRefreshInterval.
+func (p *Provider) key(ctx context.Context, d *discovery, keyID string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.keys[keyID]; ok {
+		return key, nil
+	}
+	if !p.keysFetched.IsZero() && p.now().Sub(p.keysFetched) < keyRefreshInterval {
+		return nil, ErrInvalidToken
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", d.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.doJSON(req, &set); err != nil {
+		return nil, fmt.Errorf("oidc keys: %w", err)
+	}
+	p.keys = make(map[string]*rsa.PublicKey, len(set.Keys))
+	p.keysFetched = p.now()
+	for _, k := range set.Keys {
+		if k.KeyType != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.Modulus)
+		e, errE := base64.RawURLEncoding.DecodeString(k.Exponent)
+		if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+			continue
+		}
+		p.keys[k.KeyID] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	if key, ok := p.keys[keyID]; ok {
+		return key, nil
+	}
+	return nil, ErrInvalidToken
+}
+
+// doJSON sends req and decodes a 200 JSON response into out.
+func (p *Provider) doJSON(req *http.Request, out any) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Host, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(out)
+}
+
+// decodeSegment decodes one base64url JSON segment of a JWT.
+func decodeSegment(segment string, out any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// RandomToken returns a URL-safe random value for state, nonce or a PKCE verifier.
+// PRE: none
+// POST: Returns 43 characters carrying 256 bits of randomness
+func RandomToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("oidc: crypto/rand failed: %v", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Challenge returns the S256 PKCE challenge for a verifier (RFC 7636).
+// INVARIANT: Pure function, no side effects
+func Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
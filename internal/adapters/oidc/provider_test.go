@@ -0,0 +1,179 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeIssuer is an OpenID Connect provider that answers every code with a token built by idToken.
+type fakeIssuer struct {
+	server  *httptest.Server
+	key     *rsa.PrivateKey
+	idToken func(issuer string) map[string]any
+	signer  *rsa.PrivateKey // signs tokens; defaults to key
+	form    url.Values      // last token request
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeIssuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 f.server.URL,
+			"authorization_endpoint": f.server.URL + "/authorize",
+			"token_endpoint":         f.server.URL + "/token",
+			"jwks_uri":               f.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		f.form = r.PostForm
+		json.NewEncoder(w).Encode(map[string]string{"id_token": f.sign(t, f.idToken(f.server.URL))})
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// sign builds an RS256 JWT with key ID k1.
+func (f *fakeIssuer) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "k1"})
+	payload, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signer := f.signer
+	if signer == nil {
+		signer = f.key
+	}
+	signature, err := rsa.SignPKCS1v15(rand.Reader, signer, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// validClaims returns the claims of a good token for client "client-1" and nonce "n1".
+func validClaims(issuer string) map[string]any {
+	now := time.Now()
+	return map[string]any{
+		"iss":            issuer,
+		"sub":            "10769150350006150715113082367",
+		"aud":            "client-1",
+		"exp":            now.Add(time.Hour).Unix(),
+		"iat":            now.Unix(),
+		"nonce":          "n1",
+		"email":          "aroha@example.com",
+		"email_verified": true,
+		"name":           "Aroha Ngata",
+	}
+}
+
+func newTestProvider(t *testing.T, f *fakeIssuer) *Provider {
+	t.Helper()
+	p, err := NewProvider(Config{Issuer: f.server.URL, ClientID: "client-1", ClientSecret: "secret", RedirectURL: "https://gym.example/auth/oidc/callback", Name: "Google"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// TestProvider_AuthURL verifies the authorization request carries the client, state, nonce
+// and S256 challenge.
+func TestProvider_AuthURL(t *testing.T) {
+	f := newFakeIssuer(t)
+	p := newTestProvider(t, f)
+
+	raw, err := p.AuthURL(context.Background(), "s1", "n1", "verifier-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	u, _ := url.Parse(raw)
+	q := u.Query()
+	if !strings.HasPrefix(raw, f.server.URL+"/authorize?") || q.Get("client_id") != "client-1" || q.Get("state") != "s1" ||
+		q.Get("nonce") != "n1" || q.Get("code_challenge") != Challenge("verifier-1") || q.Get("code_challenge_method") != "S256" ||
+		q.Get("scope") != "openid email profile" {
+		t.Errorf("unexpected auth URL: %s", raw)
+	}
+}
+
+// TestProvider_Exchange verifies a good token yields the identity and each failed check is rejected.
+func TestProvider_Exchange(t *testing.T) {
+	tests := []struct {
+		name    string
+		claims  func(issuer string) map[string]any
+		wrongly bool // sign with a key the issuer does not publish
+		wantErr bool
+	}{
+		{name: "valid", claims: validClaims},
+		{name: "audience list", claims: func(iss string) map[string]any {
+			c := validClaims(iss)
+			c["aud"] = []string{"other", "client-1"}
+			c["email_verified"] = "true"
+			return c
+		}},
+		{name: "wrong nonce", claims: func(iss string) map[string]any { c := validClaims(iss); c["nonce"] = "n2"; return c }, wantErr: true},
+		{name: "wrong audience", claims: func(iss string) map[string]any { c := validClaims(iss); c["aud"] = "client-2"; return c }, wantErr: true},
+		{name: "wrong issuer", claims: func(iss string) map[string]any { c := validClaims(iss); c["iss"] = "https://evil.example"; return c }, wantErr: true},
+		{name: "expired", claims: func(iss string) map[string]any {
+			c := validClaims(iss)
+			c["exp"] = time.Now().Add(-time.Hour).Unix()
+			return c
+		}, wantErr: true},
+		{name: "bad signature", claims: validClaims, wrongly: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeIssuer(t)
+			f.idToken = tt.claims
+			if tt.wrongly {
+				other, _ := rsa.GenerateKey(rand.Reader, 2048)
+				f.signer = other
+			}
+			p := newTestProvider(t, f)
+
+			identity, err := p.Exchange(context.Background(), "code-1", "verifier-1", "n1")
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Errorf("expected ErrInvalidToken, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if identity.Email != "aroha@example.com" || !identity.EmailVerified || identity.Subject == "" || identity.Issuer != f.server.URL {
+				t.Errorf("unexpected identity: %+v", identity)
+			}
+			if f.form.Get("code") != "code-1" || f.form.Get("code_verifier") != "verifier-1" || f.form.Get("client_secret") != "secret" {
+				t.Errorf("unexpected token request: %v", f.form)
+			}
+		})
+	}
+}
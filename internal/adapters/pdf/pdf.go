@@ -0,0 +1,293 @@
+// Package pdf writes simple text documents as PDF 1.4: A4 pages of headings and wrapped
+// paragraphs in Helvetica. Helvetica is one of the standard fonts every PDF reader has
+// built in, so nothing is embedded and a report is a few kilobytes.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page size and margins, in points.
+const (
+	pageWidth    = 595.28
+	pageHeight   = 841.89
+	margin       = 56.0
+	contentWidth = pageWidth - 2*margin
+	lineSpacing  = 1.35 // line height as a multiple of the font size
+)
+
+// Text styles used by the document builder.
+var (
+	styleHeading    = style{size: 18, bold: true}
+	styleSubheading = style{size: 13, bold: true}
+	styleBody       = style{size: 11}
+	styleNote       = style{size: 9, gray: true}
+)
+
+// style is a font size, weight and colour for a run of lines.
+type style struct {
+	size float64
+	bold bool
+	gray bool
+}
+
+// line is one laid-out line of text on a page.
+type line struct {
+	text  []byte // WinAnsi-encoded
+	style style
+	y     float64 // baseline, from the bottom of the page
+}
+
+// Document is a PDF under construction. Text flows down the page and onto new pages as needed.
+type Document struct {
+	title string
+	pages [][]line
+	y     float64 // baseline of the next line on the last page
+}
+
+// New starts an empty document whose metadata title is title.
+// PRE: none
+// POST: Returns a document with one blank page
+func New(title string) *Document {
+	d := &Document{title: title}
+	d.PageBreak()
+	return d
+}
+
+// Heading adds a large bold line, such as the name at the top of a report.
+// PRE: none
+// POST: The text is laid out after the previous content
+func (d *Document) Heading(text string) {
+	d.write(text, styleHeading, 0)
+}
+
+// Subheading adds a bold section title with some space above it.
+// PRE: none
+// POST: The text is laid out after the previous content
+func (d *Document) Subheading(text string) {
+	d.write(text, styleSubheading, styleSubheading.size*0.8)
+}
+
+// Paragraph adds body text, wrapped to the page width. Newlines in text start new lines.
+// PRE: none
+// POST: The text is laid out after the previous content
+func (d *Document) Paragraph(text string) {
+	d.write(text, styleBody, 0)
+}
+
+// Note adds small grey text, such as a footnote or an empty-section message.
+// PRE: none
+// POST: The text is laid out after the previous content
+func (d *Document) Note(text string) {
+	d.write(text, styleNote, 0)
+}
+
+// PageBreak starts a new page; a batch of reports puts each one on its own page.
+// PRE: none
+// POST: Later content starts at the top of a new page
+func (d *Document) PageBreak() {
+	d.pages = append(d.pages, nil)
+	d.y = pageHeight - margin
+}
+
+// Pages returns the number of pages laid out so far.
+// INVARIANT: d is not mutated
+func (d *Document) Pages() int {
+	return len(d.pages)
+}
+
+// write wraps text in the style and adds its lines, after space points of extra spacing.
+func (d *Document) write(text string, s style, space float64) {
+	height := s.size * lineSpacing
+	if len(d.pages[len(d.pages)-1]) > 0 {
+		d.y -= space
+	}
+	for _, para := range strings.Split(text, "\n") {
+		for _, l := range wrap(encode(para), s, contentWidth) {
+			if d.y-height < margin {
+				d.PageBreak()
+			}
+			d.y -= height
+			d.pages[len(d.pages)-1] = append(d.pages[len(d.pages)-1], line{text: l, style: s, y: d.y})
+		}
+	}
+}
+
+// Bytes renders the document as a PDF file.
+// PRE: none
+// POST: Returns a complete PDF with one page per laid-out page
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// Objects 1-5 are fixed; each page then takes a page object and a content stream.
+	const firstPage = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	obj(fmt.Sprintf("<< /Title %s /Producer (Workshop) >>", literal(encode(d.title))))
+	for i, page := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, firstPage+2*i+1))
+		content := pageContent(page)
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+// pageContent draws a page's lines.
+func pageContent(lines []line) string {
+	var b strings.Builder
+	for _, l := range lines {
+		font := "F1"
+		if l.style.bold {
+			font = "F2"
+		}
+		gray := 0.0
+		if l.style.gray {
+			gray = 0.4
+		}
+		fmt.Fprintf(&b, "BT /%s %g Tf %g g %.2f %.2f Td %s Tj ET\n", font, l.style.size, gray, margin, l.y, literal(l.text))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// literal writes encoded text as a PDF string, escaping the delimiters.
+func literal(text []byte) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, c := range text {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// wrap breaks text into lines no wider than width at spaces. A word wider than the line is
+// broken wherever it overflows.
+func wrap(text []byte, s style, width float64) [][]byte {
+	var lines [][]byte
+	var cur []byte
+	for _, word := range bytes.Fields(text) {
+		candidate := word
+		if len(cur) > 0 {
+			candidate = append(append(append([]byte{}, cur...), ' '), word...)
+		}
+		if textWidth(candidate, s) <= width {
+			cur = candidate
+			continue
+		}
+		if len(cur) > 0 {
+			lines = append(lines, cur)
+		}
+		cur = word
+		for textWidth(cur, s) > width {
+			n := 1
+			for n < len(cur) && textWidth(cur[:n+1], s) <= width {
+				n++
+			}
+			lines = append(lines, cur[:n])
+			cur = cur[n:]
+		}
+	}
+	if len(cur) > 0 || len(lines) == 0 {
+		lines = append(lines, cur)
+	}
+	return lines
+}
+
+// textWidth is the width of encoded text in points.
+func textWidth(text []byte, s style) float64 {
+	widths := helveticaWidths
+	if s.bold {
+		widths = helveticaBoldWidths
+	}
+	total := 0
+	for _, c := range text {
+		if c >= 32 && c <= 126 {
+			total += widths[c-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * s.size / 1000
+}
+
+// winAnsiExtras maps the characters WinAnsiEncoding places in 0x80-0x9F.
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B,
+	'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// macronVowels are the te reo Māori vowels WinAnsiEncoding lacks; they are written without the macron.
+var macronVowels = map[rune]byte{
+	'ā': 'a', 'ē': 'e', 'ī': 'i', 'ō': 'o', 'ū': 'u',
+	'Ā': 'A', 'Ē': 'E', 'Ī': 'I', 'Ō': 'O', 'Ū': 'U',
+}
+
+// encode converts text to WinAnsiEncoding. Characters the standard fonts cannot show become '?'.
+func encode(text string) []byte {
+	out := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r == '\t':
+			out = append(out, ' ')
+		case r >= 32 && r <= 126, r >= 0xA0 && r <= 0xFF:
+			out = append(out, byte(r))
+		case winAnsiExtras[r] != 0:
+			out = append(out, winAnsiExtras[r])
+		case macronVowels[r] != 0:
+			out = append(out, macronVowels[r])
+		case r < 32:
+			// control characters are dropped
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+// Glyph widths of printable ASCII (32-126) in thousandths of the font size, from the
+// Adobe font metrics of the standard fonts.
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
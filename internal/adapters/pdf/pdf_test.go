@@ -0,0 +1,84 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestDocument_Bytes verifies the file is well formed: the cross-reference table points at
+// every object and the trailer points at the table.
+func TestDocument_Bytes(t *testing.T) {
+	d := New("Term 1 report")
+	d.Heading("Aroha (Kids)")
+	d.Paragraph(`Attendance 90% \ well done`)
+	out := d.Bytes()
+
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatalf("missing header or trailer:\n%s", out)
+	}
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	if m == nil {
+		t.Fatal("no startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(out[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	if len(entries) != 7 {
+		t.Fatalf("xref entries = %d, want 7 for one page", len(entries))
+	}
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		if want := strconv.Itoa(i+1) + " 0 obj"; !bytes.HasPrefix(out[off:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q, want %q", i+1, out[off:off+10], want)
+		}
+	}
+	for _, want := range []string{`(Aroha \(Kids\)) Tj`, `(Attendance 90% \\ well done) Tj`, "/Title (Term 1 report)", "/Count 1"} {
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
+
+// TestDocument_FlowsOntoNewPages verifies long text wraps within the margins and continues on new pages.
+func TestDocument_FlowsOntoNewPages(t *testing.T) {
+	d := New("long")
+	d.Paragraph(strings.Repeat("kia kaha ", 2000))
+	if d.Pages() < 2 {
+		t.Fatalf("pages = %d, want the text to flow onto more pages", d.Pages())
+	}
+	for _, page := range d.pages {
+		for _, l := range page {
+			if w := textWidth(l.text, l.style); w > contentWidth {
+				t.Fatalf("line %q is %.1fpt wide, over %.1fpt", l.text, w, contentWidth)
+			}
+			if l.y < margin {
+				t.Fatalf("line at y=%.1f is in the bottom margin", l.y)
+			}
+		}
+	}
+	d.PageBreak()
+	if !bytes.Contains(d.Bytes(), []byte("/Count "+strconv.Itoa(d.Pages()))) {
+		t.Error("page count not written")
+	}
+}
+
+// TestEncode verifies text is converted to WinAnsiEncoding for the standard fonts.
+func TestEncode(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"plain", "plain"},
+		{"Māori – “kōrero”", "Maori \x96 \x93korero\x94"},
+		{"café", "caf\xe9"},
+		{"emoji 🥋", "emoji ?"},
+		{"tab\there", "tab here"},
+	}
+	for _, tt := range tests {
+		if got := string(encode(tt.in)); got != tt.want {
+			t.Errorf("encode(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,527 @@
+// Package qrcode encodes short strings as QR codes (ISO/IEC 18004) and renders them as SVG or PNG.
+// It supports byte mode at error correction level M for versions 1-10 (up to 213 bytes),
+// which is plenty for check-in tokens and links.
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+// ErrTooLong is returned when the text does not fit in the largest supported version.
+var ErrTooLong = errors.New("qrcode: text too long")
+
+// quietZone is the light border, in modules, required around the symbol.
+const quietZone = 4
+
+// versionInfo describes the level M block structure of one QR version.
+type versionInfo struct {
+	ecPerBlock int   // error correction codewords per block
+	blocks     []int // data codewords in each block, short blocks first
+	alignment  []int // alignment pattern centre coordinates
+	remainder  int   // remainder bits after the final codeword
+}
+
+// versions holds level M parameters for versions 1-10 (index 0 is version 1).
+var versions = []versionInfo{
+	{10, []int{16}, nil, 0},
+	{16, []int{28}, []int{6, 18}, 7},
+	{26, []int{44}, []int{6, 22}, 7},
+	{18, []int{32, 32}, []int{6, 26}, 7},
+	{24, []int{43, 43}, []int{6, 30}, 7},
+	{16, []int{27, 27, 27, 27}, []int{6, 34}, 7},
+	{18, []int{31, 31, 31, 31}, []int{6, 22, 38}, 0},
+	{22, []int{38, 38, 39, 39}, []int{6, 24, 42}, 0},
+	{22, []int{36, 36, 36, 37, 37}, []int{6, 26, 46}, 0},
+	{26, []int{43, 43, 43, 43, 44}, []int{6, 28, 50}, 0},
+}
+
+// dataCapacity returns the number of data codewords in a version.
+func (v versionInfo) dataCapacity() int {
+	n := 0
+	for _, b := range v.blocks {
+		n += b
+	}
+	return n
+}
+
+// Code is an encoded QR symbol.
+type Code struct {
+	version  int
+	size     int
+	modules  [][]bool // [y][x], true is dark
+	function [][]bool // [y][x], true for finder, timing, alignment and format modules
+}
+
+// Encode builds the smallest QR code that holds text.
+// PRE: text is at most 213 bytes
+// POST: Returns a masked symbol with format and version information, or ErrTooLong
+func Encode(text string) (*Code, error) {
+	data := []byte(text)
+	version := 0
+	for i, v := range versions {
+		countBits := 8
+		if i+1 >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) <= 8*v.dataCapacity() {
+			version = i + 1
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	c := newCode(version)
+	c.drawFunctionPatterns()
+	c.drawCodewords(c.addErrorCorrection(c.encodeData(data)))
+
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if p := c.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		c.applyMask(mask) // XOR again to undo
+	}
+	c.applyMask(best)
+	c.drawFormatBits(best)
+	return c, nil
+}
+
+// newCode allocates an empty symbol of the given version.
+func newCode(version int) *Code {
+	size := version*4 + 17
+	c := &Code{version: version, size: size, modules: make([][]bool, size), function: make([][]bool, size)}
+	for i := range c.modules {
+		c.modules[i] = make([]bool, size)
+		c.function[i] = make([]bool, size)
+	}
+	return c
+}
+
+// Size returns the width of the symbol in modules, excluding the quiet zone.
+// PRE: none
+// POST: Returns version*4 + 17
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Dark reports whether the module at column x, row y is dark. Out-of-range modules are light.
+// PRE: none
+// POST: Returns false for coordinates outside the symbol
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.size || y >= c.size {
+		return false
+	}
+	return c.modules[y][x]
+}
+
+// SVG renders the symbol as a scalable SVG document with a quiet zone.
+// PRE: none
+// POST: Returns a self-contained <svg> element sized by its viewBox
+func (c *Code) SVG() string {
+	full := c.size + 2*quietZone
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, full, full)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, full, full)
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			if c.modules[y][x] {
+				fmt.Fprintf(&b, "M%d %dh1v1h-1z", x+quietZone, y+quietZone)
+			}
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return b.String()
+}
+
+// PNG renders the symbol as a PNG image with scale pixels per module.
+// PRE: scale >= 1
+// POST: Returns the encoded image bytes
+func (c *Code) PNG(scale int) ([]byte, error) {
+	if scale < 1 {
+		scale = 1
+	}
+	full := (c.size + 2*quietZone) * scale
+	img := image.NewGray(image.Rect(0, 0, full, full))
+	for py := 0; py < full; py++ {
+		for px := 0; px < full; px++ {
+			shade := color.Gray{Y: 255}
+			if c.Dark(px/scale-quietZone, py/scale-quietZone) {
+				shade = color.Gray{Y: 0}
+			}
+			img.SetGray(px, py, shade)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// --- Function patterns ---
+
+// setFunction sets a function module and marks it reserved.
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.function[y][x] = true
+}
+
+// drawFunctionPatterns draws finders, separators, timing and alignment patterns
+// and reserves the format and version areas.
+func (c *Code) drawFunctionPatterns() {
+	for i := 0; i < c.size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+	c.drawFinder(3, 3)
+	c.drawFinder(c.size-4, 3)
+	c.drawFinder(3, c.size-4)
+
+	align := versions[c.version-1].alignment
+	last := len(align) - 1
+	for i, ax := range align {
+		for j, ay := range align {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue // overlaps a finder
+			}
+			c.drawAlignment(ax, ay)
+		}
+	}
+
+	c.drawFormatBits(0) // reserve; overwritten once the mask is chosen
+	c.drawVersion()
+}
+
+// drawFinder draws a finder pattern and its separator centred on (x, y).
+func (c *Code) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= c.size || yy >= c.size {
+				continue
+			}
+			d := max(abs(dx), abs(dy))
+			c.setFunction(xx, yy, d != 2 && d != 4)
+		}
+	}
+}
+
+// drawAlignment draws a 5x5 alignment pattern centred on (x, y).
+func (c *Code) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits writes both copies of the 15-bit format information for level M and mask.
+func (c *Code) drawFormatBits(mask int) {
+	const levelM = 0 // format bits for error correction level M
+	data := levelM<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(bits, i))
+	}
+	c.setFunction(8, 7, bit(bits, 6))
+	c.setFunction(8, 8, bit(bits, 7))
+	c.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.size-15+i, bit(bits, i))
+	}
+	c.setFunction(8, c.size-8, true) // the dark module
+}
+
+// drawVersion writes both copies of the 18-bit version information (versions 7 and up).
+func (c *Code) drawVersion() {
+	if c.version < 7 {
+		return
+	}
+	rem := c.version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := c.version<<12 | rem
+	for i := 0; i < 18; i++ {
+		a, b := c.size-11+i%3, i/3
+		c.setFunction(a, b, bit(bits, i))
+		c.setFunction(b, a, bit(bits, i))
+	}
+}
+
+// --- Data ---
+
+// encodeData builds the padded data codewords for byte mode.
+func (c *Code) encodeData(data []byte) []byte {
+	capacity := versions[c.version-1].dataCapacity()
+	var bb bitBuffer
+	bb.append(0x4, 4) // byte mode
+	if c.version >= 10 {
+		bb.append(len(data), 16)
+	} else {
+		bb.append(len(data), 8)
+	}
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+	bb.append(0, min(4, capacity*8-len(bb))) // terminator
+	bb.append(0, (8-len(bb)%8)%8)
+	for pad := 0xEC; len(bb) < capacity*8; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+
+	out := make([]byte, capacity)
+	for i, set := range bb {
+		if set {
+			out[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return out
+}
+
+// addErrorCorrection splits data into blocks, appends Reed-Solomon codewords
+// and interleaves the result.
+func (c *Code) addErrorCorrection(data []byte) []byte {
+	v := versions[c.version-1]
+	divisor := reedSolomonDivisor(v.ecPerBlock)
+	var dataBlocks, ecBlocks [][]byte
+	offset := 0
+	for _, n := range v.blocks {
+		block := data[offset : offset+n]
+		offset += n
+		dataBlocks = append(dataBlocks, block)
+		ecBlocks = append(ecBlocks, reedSolomonRemainder(block, divisor))
+	}
+
+	var out []byte
+	longest := v.blocks[len(v.blocks)-1]
+	for i := 0; i < longest; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				out = append(out, block[i])
+			}
+		}
+	}
+	for i := 0; i < v.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			out = append(out, block[i])
+		}
+	}
+	return out
+}
+
+// drawCodewords places codewords in the two-column zigzag, skipping function modules.
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	total := len(codewords) * 8
+	for right := c.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < c.size; vert++ {
+			y := vert
+			if upward {
+				y = c.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if c.function[y][x] || i >= total {
+					continue
+				}
+				c.modules[y][x] = codewords[i>>3]>>(7-i&7)&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+// --- Masking ---
+
+// applyMask XORs the data modules with mask pattern 0-7. Applying it twice undoes it.
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			if c.function[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores the symbol with the four standard mask evaluation rules; lower is better.
+func (c *Code) penalty() int {
+	score := 0
+	finderLike := [][]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+	for pass := 0; pass < 2; pass++ { // rows, then columns
+		for a := 0; a < c.size; a++ {
+			line := make([]bool, c.size)
+			for b := 0; b < c.size; b++ {
+				if pass == 0 {
+					line[b] = c.modules[a][b]
+				} else {
+					line[b] = c.modules[b][a]
+				}
+			}
+			run := 1
+			for b := 1; b <= c.size; b++ {
+				if b < c.size && line[b] == line[b-1] {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += 3 + run - 5
+				}
+				run = 1
+			}
+			for b := 0; b+11 <= c.size; b++ {
+				for _, pattern := range finderLike {
+					match := true
+					for k, want := range pattern {
+						if line[b+k] != want {
+							match = false
+							break
+						}
+					}
+					if match {
+						score += 40
+					}
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+			if x+1 < c.size && y+1 < c.size {
+				v := c.modules[y][x]
+				if c.modules[y][x+1] == v && c.modules[y+1][x] == v && c.modules[y+1][x+1] == v {
+					score += 3
+				}
+			}
+		}
+	}
+	total := c.size * c.size
+	deviation := abs(dark*20 - total*10) // distance from 50% in units of 5%, times total
+	score += (deviation + total - 1) / total * 10
+	return score
+}
+
+// --- Reed-Solomon over GF(2^8) with polynomial 0x11D ---
+
+// reedSolomonDivisor returns the generator polynomial of the given degree, highest term omitted.
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// reedSolomonRemainder returns the error correction codewords for data.
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= gfMultiply(d, factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies two elements of GF(2^8).
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
+
+// --- Helpers ---
+
+// bitBuffer is a growable sequence of bits.
+type bitBuffer []bool
+
+// append adds the low n bits of value, most significant first.
+func (bb *bitBuffer) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*bb = append(*bb, value>>i&1 == 1)
+	}
+}
+
+// bit reports whether bit i of x is set.
+func bit(x, i int) bool {
+	return x>>i&1 == 1
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,214 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// decode reads a symbol back to its text: format bits, unmasking, de-interleaving,
+// Reed-Solomon syndrome checks and the byte-mode segment.
+func decode(t *testing.T, c *Code) string {
+	t.Helper()
+	var first, second int
+	for i := 0; i <= 5; i++ {
+		first |= b2i(c.Dark(8, i)) << i
+	}
+	first |= b2i(c.Dark(8, 7))<<6 | b2i(c.Dark(8, 8))<<7 | b2i(c.Dark(7, 8))<<8
+	for i := 9; i < 15; i++ {
+		first |= b2i(c.Dark(14-i, 8)) << i
+	}
+	for i := 0; i < 8; i++ {
+		second |= b2i(c.Dark(c.size-1-i, 8)) << i
+	}
+	for i := 8; i < 15; i++ {
+		second |= b2i(c.Dark(8, c.size-15+i)) << i
+	}
+	if first != second {
+		t.Fatalf("format copies differ: %015b vs %015b", first, second)
+	}
+	format := (first ^ 0x5412) >> 10
+	if format>>3 != 0 {
+		t.Fatalf("expected level M, got format %05b", format)
+	}
+	mask := format & 7
+
+	c.applyMask(mask)
+	defer c.applyMask(mask)
+	v := versions[c.version-1]
+	total := v.dataCapacity() + v.ecPerBlock*len(v.blocks)
+	raw := make([]byte, total)
+	i := 0
+	for right := c.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < c.size; vert++ {
+			y := vert
+			if upward {
+				y = c.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if c.function[y][x] || i >= total*8 {
+					continue
+				}
+				if c.modules[y][x] {
+					raw[i>>3] |= 1 << (7 - i&7)
+				}
+				i++
+			}
+		}
+	}
+
+	blocks := make([][]byte, len(v.blocks))
+	pos := 0
+	for k := 0; k < v.blocks[len(v.blocks)-1]; k++ {
+		for b, n := range v.blocks {
+			if k < n {
+				blocks[b] = append(blocks[b], raw[pos])
+				pos++
+			}
+		}
+	}
+	for k := 0; k < v.ecPerBlock; k++ {
+		for b := range blocks {
+			blocks[b] = append(blocks[b], raw[pos])
+			pos++
+		}
+	}
+	var data []byte
+	for b, block := range blocks {
+		// Every root of the generator must be a root of a valid codeword.
+		root := byte(1)
+		for r := 0; r < v.ecPerBlock; r++ {
+			var sum byte
+			for _, cw := range block {
+				sum = gfMultiply(sum, root) ^ cw
+			}
+			if sum != 0 {
+				t.Fatalf("block %d syndrome %d is %d", b, r, sum)
+			}
+			root = gfMultiply(root, 2)
+		}
+		data = append(data, block[:v.blocks[b]]...)
+	}
+
+	readBits := func(offset, n int) int {
+		val := 0
+		for k := 0; k < n; k++ {
+			val = val<<1 | int(data[(offset+k)>>3]>>(7-(offset+k)&7)&1)
+		}
+		return val
+	}
+	if mode := readBits(0, 4); mode != 4 {
+		t.Fatalf("expected byte mode, got %d", mode)
+	}
+	countBits := 8
+	if c.version >= 10 {
+		countBits = 16
+	}
+	n := readBits(4, countBits)
+	out := make([]byte, n)
+	for k := range out {
+		out[k] = byte(readBits(4+countBits+8*k, 8))
+	}
+	return string(out)
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// TestEncode_RoundTrip verifies symbols of every supported version decode to their input.
+func TestEncode_RoundTrip(t *testing.T) {
+	for _, n := range []int{1, 14, 26, 42, 62, 84, 106, 122, 152, 180, 213} {
+		text := strings.Repeat("wsci1.0123456789abcdef-", 10)[:n]
+		c, err := Encode(text)
+		if err != nil {
+			t.Fatalf("len %d: %v", n, err)
+		}
+		if c.Size() != c.version*4+17 {
+			t.Errorf("len %d: size %d does not match version %d", n, c.Size(), c.version)
+		}
+		if got := decode(t, c); got != text {
+			t.Errorf("len %d (version %d): decoded %q", n, c.version, got)
+		}
+	}
+}
+
+// TestEncode_SmallestVersion verifies the encoder picks the smallest version that fits.
+func TestEncode_SmallestVersion(t *testing.T) {
+	tests := []struct {
+		length  int
+		version int
+	}{
+		{14, 1}, {15, 2}, {26, 2}, {27, 3}, {213, 10},
+	}
+	for _, tt := range tests {
+		c, err := Encode(strings.Repeat("a", tt.length))
+		if err != nil {
+			t.Fatalf("len %d: %v", tt.length, err)
+		}
+		if c.version != tt.version {
+			t.Errorf("len %d: version %d, want %d", tt.length, c.version, tt.version)
+		}
+	}
+}
+
+// TestEncode_TooLong verifies oversized input is rejected.
+func TestEncode_TooLong(t *testing.T) {
+	if _, err := Encode(strings.Repeat("a", 214)); !errors.Is(err, ErrTooLong) {
+		t.Errorf("expected ErrTooLong, got %v", err)
+	}
+}
+
+// TestEncode_FinderPatterns verifies the three finder patterns and the dark module.
+func TestEncode_FinderPatterns(t *testing.T) {
+	c, err := Encode("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, corner := range [][2]int{{0, 0}, {c.size - 7, 0}, {0, c.size - 7}} {
+		for d := 0; d < 7; d++ {
+			x, y := corner[0], corner[1]
+			if !c.Dark(x+d, y) || !c.Dark(x, y+d) || !c.Dark(x+6, y+d) || !c.Dark(x+d, y+6) {
+				t.Errorf("finder at %v has a light border module", corner)
+			}
+		}
+		if c.Dark(corner[0]+1, corner[1]+1) || !c.Dark(corner[0]+3, corner[1]+3) {
+			t.Errorf("finder at %v has the wrong centre", corner)
+		}
+	}
+	if !c.Dark(8, c.size-8) {
+		t.Error("dark module is light")
+	}
+}
+
+// TestRender verifies the SVG and PNG outputs include the quiet zone.
+func TestRender(t *testing.T) {
+	c, err := Encode("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svg := c.SVG(); !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, `viewBox="0 0 29 29"`) {
+		t.Errorf("unexpected SVG: %.80s", svg)
+	}
+	data, err := c.PNG(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := img.Bounds().Dx(); w != 29*4 {
+		t.Errorf("expected width %d, got %d", 29*4, w)
+	}
+}
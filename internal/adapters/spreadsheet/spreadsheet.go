@@ -0,0 +1,180 @@
+// Package spreadsheet streams tabular exports as CSV or XLSX.
+// Each resource declares its columns once; rows are written as they are produced,
+// so large exports are never held in memory as a whole file.
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// Format is an export file format.
+type Format string
+
+// Supported export formats.
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ErrUnknownFormat is returned for a format other than csv or xlsx.
+var ErrUnknownFormat = errors.New("format must be csv or xlsx")
+
+// ParseFormat reads a format query parameter; empty means CSV.
+// PRE: none
+// POST: returns the format, or ErrUnknownFormat
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(s))) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// ContentType returns the MIME type of the format.
+// INVARIANT: f is not mutated
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Filename returns base with the format's extension, e.g. "attendance-2026-03.csv".
+// INVARIANT: f is not mutated
+func (f Format) Filename(base string) string {
+	return base + "." + string(f)
+}
+
+// Column defines one exported column of a resource.
+type Column[T any] struct {
+	Header string
+	Number bool // written as a number in XLSX; the value must be a decimal or empty
+	Value  func(T) string
+}
+
+// Write streams a header row and one row per item to w in the given format.
+// In CSV, text cells that a spreadsheet would run as a formula are neutralised (see SafeText);
+// XLSX text cells are inline strings, which are never evaluated.
+// PRE: columns is non-empty; format is FormatCSV or FormatXLSX
+// POST: returns the number of rows written (excluding the header), or the first write error
+func Write[T any](w io.Writer, format Format, sheet string, columns []Column[T], rows iter.Seq[T]) (int, error) {
+	var out rowWriter
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		cw.UseCRLF = true
+		out = &csvWriter{w: cw}
+	case FormatXLSX:
+		xw, err := newXLSXWriter(w, sheet)
+		if err != nil {
+			return 0, err
+		}
+		out = xw
+	default:
+		return 0, ErrUnknownFormat
+	}
+
+	headers := make([]Cell, len(columns))
+	for i, c := range columns {
+		headers[i] = Cell{Value: c.Header}
+	}
+	if err := out.WriteRow(headers); err != nil {
+		return 0, err
+	}
+	n := 0
+	cells := make([]Cell, len(columns))
+	for item := range rows {
+		for i, c := range columns {
+			cells[i] = Cell{Value: c.Value(item), Number: c.Number}
+		}
+		if err := out.WriteRow(cells); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, out.Close()
+}
+
+// Cell is one value in a row.
+type Cell struct {
+	Value  string
+	Number bool
+}
+
+// rowWriter writes rows in one format.
+type rowWriter interface {
+	WriteRow(cells []Cell) error
+	Close() error
+}
+
+// csvWriter writes CSV rows with CRLF line endings.
+type csvWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+// WriteRow writes one CSV record.
+// PRE: none
+// POST: the record is buffered; returns the writer's error, if any
+func (c *csvWriter) WriteRow(cells []Cell) error {
+	c.record = c.record[:0]
+	for _, cell := range cells {
+		if cell.Number && isNumber(cell.Value) {
+			c.record = append(c.record, cell.Value)
+		} else {
+			c.record = append(c.record, SafeText(cell.Value))
+		}
+	}
+	return c.w.Write(c.record)
+}
+
+// Close flushes buffered records.
+// PRE: none
+// POST: returns the first write error, if any
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// SafeText mitigates formula injection when a CSV export is opened in Excel or Sheets.
+// A cell starting with =, +, - or @ may be run as a formula, so it is prefixed with a single
+// quote to force a literal.
+// PRE: none
+// POST: returns s, quoted if it could be read as a formula
+func SafeText(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '=', '+', '-', '@':
+		return "'" + s
+	default:
+		return s
+	}
+}
+
+// isNumber reports whether s is a plain decimal number.
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil && !strings.ContainsAny(s, "xXpPnN_")
+}
+
+// FormatFloat formats a number cell with the given decimals.
+// PRE: decimals >= 0
+// POST: returns a decimal string
+func FormatFloat(v float64, decimals int) string {
+	return strconv.FormatFloat(v, 'f', decimals, 64)
+}
+
+// errorf wraps a write error with the part of the file being written.
+func errorf(part string, err error) error {
+	return fmt.Errorf("spreadsheet: write %s: %w", part, err)
+}
@@ -0,0 +1,96 @@
+package spreadsheet_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"workshop/internal/adapters/spreadsheet"
+)
+
+type row struct {
+	Name  string
+	Hours float64
+}
+
+var columns = []spreadsheet.Column[row]{
+	{Header: "Name", Value: func(r row) string { return r.Name }},
+	{Header: "Hours", Number: true, Value: func(r row) string { return spreadsheet.FormatFloat(r.Hours, 1) }},
+}
+
+var rows = []row{{"Ana <Silva>", 1.5}, {"=HYPERLINK(\"x\")", -2}}
+
+// TestWrite_CSV verifies CSV output has a header, neutralises formulas in text cells and keeps numbers.
+func TestWrite_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := spreadsheet.Write(&buf, spreadsheet.FormatCSV, "Hours", columns, slices.Values(rows))
+	if err != nil || n != 2 {
+		t.Fatalf("Write() = %d, %v; want 2 rows", n, err)
+	}
+	want := "Name,Hours\r\nAna <Silva>,1.5\r\n\"'=HYPERLINK(\"\"x\"\")\",-2.0\r\n"
+	if buf.String() != want {
+		t.Errorf("CSV =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+// TestWrite_XLSX verifies the XLSX output is a workbook whose sheet holds escaped text and numeric cells.
+// Text is written as inline strings, which are never evaluated, so it is kept verbatim.
+func TestWrite_XLSX(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := spreadsheet.Write(&buf, spreadsheet.FormatXLSX, "Hours: March/April", columns, slices.Values(rows))
+	if err != nil || n != 2 {
+		t.Fatalf("Write() = %d, %v; want 2 rows", n, err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("not a zip: %v", err)
+	}
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(b)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+	if !strings.Contains(parts["xl/workbook.xml"], `name="Hours- March-April"`) {
+		t.Errorf("sheet name not sanitised: %s", parts["xl/workbook.xml"])
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{"Ana &lt;Silva&gt;", "<v>1.5</v>", "<v>-2.0</v>", `<t xml:space="preserve">=HYPERLINK(&#34;x&#34;)</t>`} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %q:\n%s", want, sheet)
+		}
+	}
+}
+
+// TestParseFormat verifies format parsing and its default.
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in   string
+		want spreadsheet.Format
+		err  error
+	}{
+		{"", spreadsheet.FormatCSV, nil},
+		{"CSV", spreadsheet.FormatCSV, nil},
+		{" xlsx ", spreadsheet.FormatXLSX, nil},
+		{"pdf", "", spreadsheet.ErrUnknownFormat},
+	}
+	for _, tt := range tests {
+		got, err := spreadsheet.ParseFormat(tt.in)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q, %v", tt.in, got, err, tt.want, tt.err)
+		}
+	}
+}
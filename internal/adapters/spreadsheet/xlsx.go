@@ -0,0 +1,132 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// The fixed parts of a one-sheet workbook. Cells are inline strings or numbers, so the
+// workbook needs no shared-strings table and the sheet can be streamed row by row.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// maxSheetName is Excel's limit on sheet name length.
+const maxSheetName = 31
+
+// xlsxWriter streams a single-sheet workbook into a zip archive.
+type xlsxWriter struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+}
+
+// newXLSXWriter writes the workbook parts and opens the sheet for rows.
+func newXLSXWriter(w io.Writer, sheet string) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", workbookXML(sheet)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return nil, errorf(p.name, err)
+		}
+		if _, err := io.WriteString(f, p.body); err != nil {
+			return nil, errorf(p.name, err)
+		}
+	}
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, errorf("sheet", err)
+	}
+	x := &xlsxWriter{zw: zw, sheet: bufio.NewWriter(f)}
+	if _, err := x.sheet.WriteString(xlsxSheetStart); err != nil {
+		return nil, errorf("sheet", err)
+	}
+	return x, nil
+}
+
+// WriteRow writes one sheet row. Number cells that do not parse are written as text.
+// PRE: none
+// POST: the row is buffered; returns the first write error
+func (x *xlsxWriter) WriteRow(cells []Cell) error {
+	x.sheet.WriteString("<row>")
+	for _, c := range cells {
+		switch {
+		case c.Value == "":
+			x.sheet.WriteString("<c/>")
+		case c.Number && isNumber(c.Value):
+			x.sheet.WriteString("<c><v>")
+			x.sheet.WriteString(c.Value)
+			x.sheet.WriteString("</v></c>")
+		default:
+			x.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+			xml.EscapeText(x.sheet, []byte(c.Value))
+			x.sheet.WriteString("</t></is></c>")
+		}
+	}
+	if _, err := x.sheet.WriteString("</row>"); err != nil {
+		return errorf("row", err)
+	}
+	return nil
+}
+
+// Close ends the sheet and the archive.
+// PRE: none
+// POST: the workbook is complete; returns the first write error
+func (x *xlsxWriter) Close() error {
+	x.sheet.WriteString(xlsxSheetEnd)
+	if err := x.sheet.Flush(); err != nil {
+		return errorf("sheet", err)
+	}
+	if err := x.zw.Close(); err != nil {
+		return errorf("archive", err)
+	}
+	return nil
+}
+
+// workbookXML names the single sheet. Excel rejects names over 31 characters and
+// the characters []:*?/\ in sheet names.
+func workbookXML(sheet string) string {
+	sheet = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(sheet))
+	if sheet == "" {
+		sheet = "Sheet1"
+	}
+	if r := []rune(sheet); len(r) > maxSheetName {
+		sheet = string(r[:maxSheetName])
+	}
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="`)
+	xml.EscapeText(&b, []byte(sheet))
+	b.WriteString(`" sheetId="1" r:id="rId1"/></sheets></workbook>`)
+	return b.String()
+}
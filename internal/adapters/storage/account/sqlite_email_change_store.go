@@ -0,0 +1,147 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/account"
+	emailDomain "workshop/internal/domain/email"
+)
+
+// EmailChangeSQLiteStore implements EmailChangeStore using SQLite.
+type EmailChangeSQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewEmailChangeSQLiteStore creates a new EmailChangeSQLiteStore.
+func NewEmailChangeSQLiteStore(db storage.SQLDB) *EmailChangeSQLiteStore {
+	return &EmailChangeSQLiteStore{db: db}
+}
+
+// SaveEmailChange records a new email change, retiring the account's earlier pending changes
+// so only the latest link works.
+// PRE: value came from domain.NewEmailChange
+// POST: value is the account's only unused change
+func (s *EmailChangeSQLiteStore) SaveEmailChange(ctx context.Context, value domain.EmailChange) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE account_email_change SET used = 1 WHERE account_id = ? AND used = 0`, value.AccountID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO account_email_change (id, account_id, old_email, new_email, token, expires_at, created_at, used, confirmed_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		value.ID, value.AccountID, value.OldEmail, value.NewEmail, value.Token,
+		value.ExpiresAt.Format(time.RFC3339), value.CreatedAt.Format(time.RFC3339), boolInt(value.Used), formatOptionalTime(value.ConfirmedAt)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetEmailChangeByToken retrieves an email change by its confirmation token.
+// PRE: token is non-empty
+// POST: Returns the change or sql.ErrNoRows
+func (s *EmailChangeSQLiteStore) GetEmailChangeByToken(ctx context.Context, token string) (domain.EmailChange, error) {
+	var c domain.EmailChange
+	var expiresAt, createdAt, confirmedAt string
+	var used int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, account_id, old_email, new_email, token, expires_at, created_at, used, confirmed_at
+		 FROM account_email_change WHERE token = ?`, token).
+		Scan(&c.ID, &c.AccountID, &c.OldEmail, &c.NewEmail, &c.Token, &expiresAt, &createdAt, &used, &confirmedAt)
+	if err != nil {
+		return domain.EmailChange{}, err
+	}
+	c.ExpiresAt, _ = parseTime(expiresAt)
+	c.CreatedAt, _ = parseTime(createdAt)
+	if confirmedAt != "" {
+		c.ConfirmedAt, _ = parseTime(confirmedAt)
+	}
+	c.Used = used != 0
+	return c, nil
+}
+
+// ApplyEmailChange moves the account, its linked member records and the recipients of its
+// unsent emails to the new address in one transaction, and retires the account's changes.
+// PRE: value is usable and unchanged since it was read
+// POST: Either every record uses NewEmail or nothing changed; domain.ErrEmailTaken if another
+// account or member already has NewEmail
+func (s *EmailChangeSQLiteStore) ApplyEmailChange(ctx context.Context, value domain.EmailChange, confirmedAt time.Time) (EmailChangeApplied, error) {
+	var applied EmailChangeApplied
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return applied, err
+	}
+	defer tx.Rollback()
+
+	var taken int
+	if err := tx.QueryRowContext(ctx,
+		`SELECT (SELECT COUNT(*) FROM account WHERE email = ? AND id != ?) +
+		        (SELECT COUNT(*) FROM member WHERE email = ? AND (account_id IS NULL OR account_id != ?))`,
+		value.NewEmail, value.AccountID, value.NewEmail, value.AccountID).Scan(&taken); err != nil {
+		return applied, err
+	}
+	if taken > 0 {
+		return applied, domain.ErrEmailTaken
+	}
+
+	res, err := tx.ExecContext(ctx, `UPDATE account SET email = ? WHERE id = ? AND email = ?`,
+		value.NewEmail, value.AccountID, value.OldEmail)
+	if err != nil {
+		return applied, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		// The account's address changed some other way after this request was made.
+		return applied, domain.ErrEmailChangeInvalid
+	}
+	if res, err = tx.ExecContext(ctx, `UPDATE member SET email = ? WHERE account_id = ?`,
+		value.NewEmail, value.AccountID); err != nil {
+		return applied, err
+	}
+	members, _ := res.RowsAffected()
+	if res, err = tx.ExecContext(ctx,
+		`UPDATE email_recipient SET member_email = ?
+		 WHERE member_email = ?
+		   AND member_id IN (SELECT id FROM member WHERE account_id = ?)
+		   AND email_id IN (SELECT id FROM email WHERE status IN (?, ?, ?))`,
+		value.NewEmail, value.OldEmail, value.AccountID,
+		emailDomain.StatusDraft, emailDomain.StatusQueued, emailDomain.StatusScheduled); err != nil {
+		return applied, err
+	}
+	recipients, _ := res.RowsAffected()
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE account_email_change SET used = 1, confirmed_at = CASE WHEN id = ? THEN ? ELSE confirmed_at END
+		 WHERE account_id = ? AND used = 0`,
+		value.ID, confirmedAt.Format(time.RFC3339), value.AccountID); err != nil {
+		return applied, err
+	}
+	if err := tx.Commit(); err != nil {
+		return applied, err
+	}
+	applied.Members = int(members)
+	applied.Recipients = int(recipients)
+	return applied, nil
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// Verify interface compliance at compile time.
+var _ EmailChangeStore = (*EmailChangeSQLiteStore)(nil)
@@ -25,7 +25,7 @@ func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
 // PRE: id is non-empty
 // POST: Returns the entity or an error if not found
 func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Account, error) {
-	query := "SELECT id, email, password_hash, role, status, created_at, failed_logins, locked_until, password_change_required, beta_tester FROM account WHERE id = ?"
+	query := "SELECT id, email, password_hash, role, status, created_at, failed_logins, locked_until, password_change_required, beta_tester, location_id, suspended_reason, locale FROM account WHERE id = ?"
 	row := s.db.QueryRowContext(ctx, query, id)
 
 	entity, err := scanAccount(row.Scan)
@@ -39,7 +39,7 @@ func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Account, e
 // PRE: email is non-empty
 // POST: Returns the entity or an error if not found
 func (s *SQLiteStore) GetByEmail(ctx context.Context, email string) (domain.Account, error) {
-	query := "SELECT id, email, password_hash, role, status, created_at, failed_logins, locked_until, password_change_required, beta_tester FROM account WHERE email = ?"
+	query := "SELECT id, email, password_hash, role, status, created_at, failed_logins, locked_until, password_change_required, beta_tester, location_id, suspended_reason, locale FROM account WHERE email = ?"
 	row := s.db.QueryRowContext(ctx, query, email)
 
 	entity, err := scanAccount(row.Scan)
@@ -59,8 +59,8 @@ func (s *SQLiteStore) Save(ctx context.Context, entity domain.Account) error {
 	}
 	defer tx.Rollback()
 
-	fields := []string{"id", "email", "password_hash", "role", "status", "created_at", "failed_logins", "locked_until", "password_change_required", "beta_tester"}
-	placeholders := []string{"?", "?", "?", "?", "?", "?", "?", "?", "?", "?"}
+	fields := []string{"id", "email", "password_hash", "role", "status", "created_at", "failed_logins", "locked_until", "password_change_required", "beta_tester", "location_id", "suspended_reason", "locale"}
+	placeholders := []string{"?", "?", "?", "?", "?", "?", "?", "?", "?", "?", "?", "?", "?"}
 	updates := []string{
 		"email=excluded.email",
 		"password_hash=excluded.password_hash",
@@ -70,6 +70,9 @@ func (s *SQLiteStore) Save(ctx context.Context, entity domain.Account) error {
 		"locked_until=excluded.locked_until",
 		"password_change_required=excluded.password_change_required",
 		"beta_tester=excluded.beta_tester",
+		"location_id=excluded.location_id",
+		"suspended_reason=excluded.suspended_reason",
+		"locale=excluded.locale",
 	}
 
 	query := fmt.Sprintf(
@@ -110,6 +113,9 @@ func (s *SQLiteStore) Save(ctx context.Context, entity domain.Account) error {
 		lockedUntil,
 		passwordChangeRequired,
 		betaTester,
+		entity.LocationID,
+		entity.SuspendedReason,
+		entity.Locale,
 	)
 	if err != nil {
 		return err
@@ -143,7 +149,7 @@ func (s *SQLiteStore) List(ctx context.Context, filter ListFilter) ([]domain.Acc
 	var queryBuilder strings.Builder
 	var args []interface{}
 
-	queryBuilder.WriteString("SELECT id, email, password_hash, role, status, created_at, failed_logins, locked_until, password_change_required, beta_tester FROM account")
+	queryBuilder.WriteString("SELECT id, email, password_hash, role, status, created_at, failed_logins, locked_until, password_change_required, beta_tester, location_id, suspended_reason, locale FROM account")
 
 	if filter.Role != "" {
 		queryBuilder.WriteString(" WHERE role = ?")
@@ -198,6 +204,9 @@ func scanAccount(scan func(dest ...interface{}) error) (domain.Account, error) {
 		&lockedUntil,
 		&passwordChangeRequired,
 		&betaTester,
+		&entity.LocationID,
+		&entity.SuspendedReason,
+		&entity.Locale,
 	)
 	if err != nil {
 		return domain.Account{}, err
@@ -2,6 +2,7 @@ package account
 
 import (
 	"context"
+	"time"
 
 	domain "workshop/internal/domain/account"
 )
@@ -19,6 +20,19 @@ type Store interface {
 	InvalidateTokensForAccount(ctx context.Context, accountID string) error
 }
 
+// EmailChangeStore persists pending email changes and applies confirmed ones.
+type EmailChangeStore interface {
+	SaveEmailChange(ctx context.Context, value domain.EmailChange) error // replaces the account's other pending changes
+	GetEmailChangeByToken(ctx context.Context, token string) (domain.EmailChange, error)
+	ApplyEmailChange(ctx context.Context, value domain.EmailChange, confirmedAt time.Time) (EmailChangeApplied, error)
+}
+
+// EmailChangeApplied counts the records moved to the new address by ApplyEmailChange.
+type EmailChangeApplied struct {
+	Members    int // member records linked to the account
+	Recipients int // recipients of emails not yet sent
+}
+
 // ListFilter carries filtering parameters for List operations.
 type ListFilter struct {
 	Limit  int
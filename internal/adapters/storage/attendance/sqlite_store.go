@@ -21,47 +21,18 @@ func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
 	return &SQLiteStore{db: db}
 }
 
+// attendanceColumns is the shared column list for attendance SELECTs; order matches scanAttendance.
+const attendanceColumns = "id, check_in_time, check_out_time, member_id, schedule_id, class_date, mat_hours, location_id"
+
 // GetByID retrieves a Attendance by its ID.
 // PRE: id is non-empty
 // POST: Returns the entity or an error if not found
 func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Attendance, error) {
-	query := "SELECT id, check_in_time, check_out_time, member_id, schedule_id, class_date, mat_hours FROM attendance WHERE id = ?"
+	query := "SELECT " + attendanceColumns + " FROM attendance WHERE id = ?"
 
 	row := s.db.QueryRowContext(ctx, query, id)
 
-	var entity domain.Attendance
-	var checkInStr string
-	var checkOutStr, scheduleID, classDate sql.NullString
-	err := row.Scan(
-		&entity.ID,
-		&checkInStr,
-		&checkOutStr,
-		&entity.MemberID,
-		&scheduleID,
-		&classDate,
-		&entity.MatHours,
-	)
-	if scheduleID.Valid {
-		entity.ScheduleID = scheduleID.String
-	}
-	if classDate.Valid {
-		entity.ClassDate = classDate.String
-	}
-	if err == nil {
-		// Parse check-in time (required)
-		entity.CheckInTime, err = parseStoredTime(checkInStr)
-		if err != nil {
-			return domain.Attendance{}, fmt.Errorf("failed to parse check_in_time: %w", err)
-		}
-		// Parse check-out time (optional)
-		if checkOutStr.Valid {
-			parsedTime, parseErr := parseStoredTime(checkOutStr.String)
-			if parseErr != nil {
-				return domain.Attendance{}, fmt.Errorf("failed to parse check_out_time: %w", parseErr)
-			}
-			entity.CheckOutTime = parsedTime
-		}
-	}
+	entity, err := scanAttendance(row.Scan)
 	if err == sql.ErrNoRows {
 		return domain.Attendance{}, fmt.Errorf("attendance not found: %w", err)
 	}
@@ -79,9 +50,9 @@ func (s *SQLiteStore) Save(ctx context.Context, entity domain.Attendance) error
 	defer tx.Rollback()
 
 	// Upsert implementation
-	fields := []string{"id", "check_in_time", "check_out_time", "member_id", "schedule_id", "class_date", "mat_hours"}
-	placeholders := []string{"?", "?", "?", "?", "?", "?", "?"}
-	updates := []string{"check_in_time=excluded.check_in_time", "check_out_time=excluded.check_out_time", "member_id=excluded.member_id", "schedule_id=excluded.schedule_id", "class_date=excluded.class_date", "mat_hours=excluded.mat_hours"}
+	fields := []string{"id", "check_in_time", "check_out_time", "member_id", "schedule_id", "class_date", "mat_hours", "location_id"}
+	placeholders := []string{"?", "?", "?", "?", "?", "?", "?", "?"}
+	updates := []string{"check_in_time=excluded.check_in_time", "check_out_time=excluded.check_out_time", "member_id=excluded.member_id", "schedule_id=excluded.schedule_id", "class_date=excluded.class_date", "mat_hours=excluded.mat_hours", "location_id=excluded.location_id"}
 
 	query := fmt.Sprintf(
 		"INSERT INTO attendance (%s) VALUES (%s) ON CONFLICT(id) DO UPDATE SET %s",
@@ -112,6 +83,7 @@ func (s *SQLiteStore) Save(ctx context.Context, entity domain.Attendance) error
 		scheduleIDVal,
 		classDateVal,
 		entity.MatHours,
+		entity.LocationID,
 	)
 	if err != nil {
 		return err
@@ -128,212 +100,89 @@ func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
 	return err
 }
 
-// List retrieves a list of Attendances based on the filter.
-// PRE: filter has valid parameters
-// POST: Returns matching entities
+// List retrieves a page of Attendances matching the filter, ordered by check-in time.
+// PRE: From and To, when set, are YYYY-MM-DD
+// POST: Returns at most filter.Limit records (1000 when unset), newest first unless Dir is "asc"
 func (s *SQLiteStore) List(ctx context.Context, filter ListFilter) ([]domain.Attendance, error) {
-	query := "SELECT id, check_in_time, check_out_time, member_id, schedule_id, class_date, mat_hours FROM attendance LIMIT ? OFFSET ?"
-
-	rows, err := s.db.QueryContext(ctx, query, filter.Limit, filter.Offset)
-	if err != nil {
-		return nil, err
+	var where []string
+	var args []interface{}
+	if filter.MemberID != "" {
+		where = append(where, "member_id = ?")
+		args = append(args, filter.MemberID)
+	}
+	if filter.From != "" {
+		where = append(where, "SUBSTR(check_in_time, 1, 10) >= ?")
+		args = append(args, filter.From)
+	}
+	if filter.To != "" {
+		where = append(where, "SUBSTR(check_in_time, 1, 10) <= ?")
+		args = append(args, filter.To)
 	}
-	defer rows.Close()
 
-	var results []domain.Attendance
-	for rows.Next() {
-		var entity domain.Attendance
-		var checkInStr string
-		var checkOutStr, scheduleID, classDate sql.NullString
-		if err := rows.Scan(
-			&entity.ID,
-			&checkInStr,
-			&checkOutStr,
-			&entity.MemberID,
-			&scheduleID,
-			&classDate,
-			&entity.MatHours,
-		); err != nil {
-			return nil, err
-		}
-		if scheduleID.Valid {
-			entity.ScheduleID = scheduleID.String
-		}
-		if classDate.Valid {
-			entity.ClassDate = classDate.String
-		}
-		// Parse check-in time (required)
-		entity.CheckInTime, err = parseStoredTime(checkInStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse check_in_time: %w", err)
-		}
-		// Parse check-out time (optional)
-		if checkOutStr.Valid {
-			parsedTime, parseErr := parseStoredTime(checkOutStr.String)
-			if parseErr != nil {
-				return nil, fmt.Errorf("failed to parse check_out_time: %w", parseErr)
-			}
-			entity.CheckOutTime = parsedTime
-		}
-		results = append(results, entity)
+	query := "SELECT " + attendanceColumns + " FROM attendance"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	dir := "DESC"
+	if filter.Dir == "asc" {
+		dir = "ASC"
 	}
-	return results, nil
+	query += " ORDER BY check_in_time " + dir + ", id " + dir + " LIMIT ? OFFSET ?"
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+	args = append(args, limit, filter.Offset)
+
+	return s.queryAttendance(ctx, query, args...)
 }
 
 // ListByMemberID retrieves all attendance records for a given member, ordered by check-in time descending.
 // PRE: memberID is non-empty
 // POST: Returns records for the given member
 func (s *SQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]domain.Attendance, error) {
-	query := "SELECT id, check_in_time, check_out_time, member_id, schedule_id, class_date, mat_hours FROM attendance WHERE member_id = ? ORDER BY check_in_time DESC"
+	query := "SELECT " + attendanceColumns + " FROM attendance WHERE member_id = ? ORDER BY check_in_time DESC"
 
-	rows, err := s.db.QueryContext(ctx, query, memberID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var results []domain.Attendance
-	for rows.Next() {
-		var entity domain.Attendance
-		var checkInStr string
-		var checkOutStr, scheduleID, classDate sql.NullString
-		if err := rows.Scan(
-			&entity.ID,
-			&checkInStr,
-			&checkOutStr,
-			&entity.MemberID,
-			&scheduleID,
-			&classDate,
-			&entity.MatHours,
-		); err != nil {
-			return nil, err
-		}
-		if scheduleID.Valid {
-			entity.ScheduleID = scheduleID.String
-		}
-		if classDate.Valid {
-			entity.ClassDate = classDate.String
-		}
-		entity.CheckInTime, err = parseStoredTime(checkInStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse check_in_time: %w", err)
-		}
-		if checkOutStr.Valid {
-			parsedTime, parseErr := parseStoredTime(checkOutStr.String)
-			if parseErr != nil {
-				return nil, fmt.Errorf("failed to parse check_out_time: %w", parseErr)
-			}
-			entity.CheckOutTime = parsedTime
-		}
-		results = append(results, entity)
-	}
-	return results, rows.Err()
+	return s.queryAttendance(ctx, query, memberID)
 }
 
 // ListByDateRange retrieves attendance records across all members within a date range.
 // PRE: startDate and endDate are YYYY-MM-DD format
 // POST: Returns records where check_in_time falls within the range (inclusive)
 func (s *SQLiteStore) ListByDateRange(ctx context.Context, startDate string, endDate string) ([]domain.Attendance, error) {
-	query := `SELECT id, check_in_time, check_out_time, member_id, schedule_id, class_date, mat_hours
+	query := `SELECT ` + attendanceColumns + `
 		FROM attendance
 		WHERE SUBSTR(check_in_time, 1, 10) >= ? AND SUBSTR(check_in_time, 1, 10) <= ?
 		ORDER BY check_in_time ASC`
 
-	rows, err := s.db.QueryContext(ctx, query, startDate, endDate)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var results []domain.Attendance
-	for rows.Next() {
-		var entity domain.Attendance
-		var checkInStr string
-		var checkOutStr, scheduleID, classDate sql.NullString
-		if err := rows.Scan(
-			&entity.ID,
-			&checkInStr,
-			&checkOutStr,
-			&entity.MemberID,
-			&scheduleID,
-			&classDate,
-			&entity.MatHours,
-		); err != nil {
-			return nil, err
-		}
-		if scheduleID.Valid {
-			entity.ScheduleID = scheduleID.String
-		}
-		if classDate.Valid {
-			entity.ClassDate = classDate.String
-		}
-		entity.CheckInTime, err = parseStoredTime(checkInStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse check_in_time: %w", err)
-		}
-		if checkOutStr.Valid {
-			parsedTime, parseErr := parseStoredTime(checkOutStr.String)
-			if parseErr != nil {
-				return nil, fmt.Errorf("failed to parse check_out_time: %w", parseErr)
-			}
-			entity.CheckOutTime = parsedTime
-		}
-		results = append(results, entity)
-	}
-	return results, rows.Err()
+	return s.queryAttendance(ctx, query, startDate, endDate)
 }
 
 // ListByMemberIDAndDate retrieves attendance records for a member on a specific date.
 // PRE: memberID is non-empty, date is YYYY-MM-DD format
 // POST: Returns records matching memberID and date, ordered by check-in time desc
 func (s *SQLiteStore) ListByMemberIDAndDate(ctx context.Context, memberID string, date string) ([]domain.Attendance, error) {
-	query := `SELECT id, check_in_time, check_out_time, member_id, schedule_id, class_date, mat_hours
+	query := `SELECT ` + attendanceColumns + `
 		FROM attendance
 		WHERE member_id = ? AND SUBSTR(check_in_time, 1, 10) = ?
 		ORDER BY check_in_time DESC`
 
-	rows, err := s.db.QueryContext(ctx, query, memberID, date)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+	return s.queryAttendance(ctx, query, memberID, date)
+}
 
-	var results []domain.Attendance
-	for rows.Next() {
-		var entity domain.Attendance
-		var checkInStr string
-		var checkOutStr, scheduleID, classDate sql.NullString
-		if err := rows.Scan(
-			&entity.ID,
-			&checkInStr,
-			&checkOutStr,
-			&entity.MemberID,
-			&scheduleID,
-			&classDate,
-			&entity.MatHours,
-		); err != nil {
-			return nil, err
-		}
-		if scheduleID.Valid {
-			entity.ScheduleID = scheduleID.String
-		}
-		if classDate.Valid {
-			entity.ClassDate = classDate.String
-		}
-		entity.CheckInTime, err = parseStoredTime(checkInStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse check_in_time: %w", err)
-		}
-		if checkOutStr.Valid {
-			parsedTime, parseErr := parseStoredTime(checkOutStr.String)
-			if parseErr != nil {
-				return nil, fmt.Errorf("failed to parse check_out_time: %w", parseErr)
-			}
-			entity.CheckOutTime = parsedTime
-		}
-		results = append(results, entity)
-	}
-	return results, rows.Err()
+// ListByMemberScheduleAndClassDate retrieves a member's check-ins for one class on the day it
+// ran. Records without a class date fall back to their check-in date; an empty scheduleID
+// matches check-ins for no class.
+// PRE: memberID is non-empty, classDate is YYYY-MM-DD format
+// POST: Returns matching records, ordered by check-in time desc
+func (s *SQLiteStore) ListByMemberScheduleAndClassDate(ctx context.Context, memberID string, scheduleID string, classDate string) ([]domain.Attendance, error) {
+	query := `SELECT ` + attendanceColumns + `
+		FROM attendance
+		WHERE member_id = ? AND COALESCE(schedule_id, '') = ?
+		  AND COALESCE(NULLIF(class_date, ''), SUBSTR(check_in_time, 1, 10)) = ?
+		ORDER BY check_in_time DESC`
+
+	return s.queryAttendance(ctx, query, memberID, scheduleID, classDate)
 }
 
 // ListDistinctMemberIDsByScheduleAndDate returns distinct member IDs who attended a specific class session.
@@ -394,57 +243,41 @@ func (s *SQLiteStore) ListDistinctMemberIDsByScheduleIDsSince(ctx context.Contex
 	return ids, rows.Err()
 }
 
-// ListByMemberIDAndDateRange retrieves attendance records for a member within a date range.
-// PRE: memberID is non-empty, startDate and endDate are YYYY-MM-DD format
-// POST: Returns records where check_in_time falls within the range (inclusive)
-func (s *SQLiteStore) ListByMemberIDAndDateRange(ctx context.Context, memberID string, startDate string, endDate string) ([]domain.Attendance, error) {
-	query := `SELECT id, check_in_time, check_out_time, member_id, schedule_id, class_date, mat_hours
-		FROM attendance
-		WHERE member_id = ? AND SUBSTR(check_in_time, 1, 10) >= ? AND SUBSTR(check_in_time, 1, 10) <= ?
-		ORDER BY check_in_time DESC`
-
-	rows, err := s.db.QueryContext(ctx, query, memberID, startDate, endDate)
+// CountByScheduleIDAndDateRange counts the sessions of one class each member attended within a date range.
+// PRE: scheduleID is non-empty, startDate and endDate are YYYY-MM-DD format
+// POST: Returns attendance counts keyed by member ID; members who never attended are absent
+func (s *SQLiteStore) CountByScheduleIDAndDateRange(ctx context.Context, scheduleID string, startDate string, endDate string) (map[string]int, error) {
+	query := `SELECT member_id, COUNT(DISTINCT class_date) FROM attendance
+		WHERE schedule_id = ? AND class_date >= ? AND class_date <= ?
+		GROUP BY member_id`
+	rows, err := s.db.QueryContext(ctx, query, scheduleID, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var results []domain.Attendance
+	counts := make(map[string]int)
 	for rows.Next() {
-		var entity domain.Attendance
-		var checkInStr string
-		var checkOutStr, scheduleID, classDate sql.NullString
-		if err := rows.Scan(
-			&entity.ID,
-			&checkInStr,
-			&checkOutStr,
-			&entity.MemberID,
-			&scheduleID,
-			&classDate,
-			&entity.MatHours,
-		); err != nil {
+		var id string
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
 			return nil, err
 		}
-		if scheduleID.Valid {
-			entity.ScheduleID = scheduleID.String
-		}
-		if classDate.Valid {
-			entity.ClassDate = classDate.String
-		}
-		entity.CheckInTime, err = parseStoredTime(checkInStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse check_in_time: %w", err)
-		}
-		if checkOutStr.Valid {
-			parsedTime, parseErr := parseStoredTime(checkOutStr.String)
-			if parseErr != nil {
-				return nil, fmt.Errorf("failed to parse check_out_time: %w", parseErr)
-			}
-			entity.CheckOutTime = parsedTime
-		}
-		results = append(results, entity)
+		counts[id] = n
 	}
-	return results, rows.Err()
+	return counts, rows.Err()
+}
+
+// ListByMemberIDAndDateRange retrieves attendance records for a member within a date range.
+// PRE: memberID is non-empty, startDate and endDate are YYYY-MM-DD format
+// POST: Returns records where check_in_time falls within the range (inclusive)
+func (s *SQLiteStore) ListByMemberIDAndDateRange(ctx context.Context, memberID string, startDate string, endDate string) ([]domain.Attendance, error) {
+	query := `SELECT ` + attendanceColumns + `
+		FROM attendance
+		WHERE member_id = ? AND SUBSTR(check_in_time, 1, 10) >= ? AND SUBSTR(check_in_time, 1, 10) <= ?
+		ORDER BY check_in_time DESC`
+
+	return s.queryAttendance(ctx, query, memberID, startDate, endDate)
 }
 
 // DeleteByMemberIDAndDateRange deletes attendance records for a member within a date range.
@@ -484,6 +317,43 @@ func (s *SQLiteStore) SumMatHoursByMemberID(ctx context.Context, memberID string
 	return total.Float64, nil
 }
 
+// TotalsByMemberID summarises a member's whole attendance history in one query, using the
+// same rules as domain.Summarise.
+// PRE: memberID is non-empty
+// POST: Returns the totals; a member with no check-ins gets zero totals
+func (s *SQLiteStore) TotalsByMemberID(ctx context.Context, memberID string) (domain.Totals, error) {
+	var totals domain.Totals
+	var recorded sql.NullFloat64
+	var unrecorded sql.NullInt64
+	var first, last sql.NullString
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*),
+			SUM(CASE
+				WHEN check_out_time IS NOT NULL AND check_out_time != '' AND julianday(check_out_time) > julianday(check_in_time)
+				THEN (julianday(check_out_time) - julianday(check_in_time)) * 24.0
+				ELSE 0
+			END),
+			SUM(CASE WHEN check_out_time IS NULL OR check_out_time = '' THEN 1 ELSE 0 END),
+			MIN(check_in_time), MAX(check_in_time)
+		FROM attendance WHERE member_id = ?`, memberID).Scan(&totals.Classes, &recorded, &unrecorded, &first, &last)
+	if err != nil {
+		return domain.Totals{}, err
+	}
+	totals.RecordedHours = recorded.Float64
+	totals.Unrecorded = int(unrecorded.Int64)
+	if first.Valid {
+		if totals.FirstCheckIn, err = parseStoredTime(first.String); err != nil {
+			return domain.Totals{}, fmt.Errorf("failed to parse check_in_time: %w", err)
+		}
+	}
+	if last.Valid {
+		if totals.LastCheckIn, err = parseStoredTime(last.String); err != nil {
+			return domain.Totals{}, fmt.Errorf("failed to parse check_in_time: %w", err)
+		}
+	}
+	return totals, nil
+}
+
 // SumMatHoursByMemberIDAndDateRange returns total mat hours for a member within a date range.
 // Uses checkout-based duration where available, else defaults to 1.5h per session.
 // PRE: memberID is non-empty, startDate and endDate are YYYY-MM-DD format
@@ -508,6 +378,110 @@ func (s *SQLiteStore) SumMatHoursByMemberIDAndDateRange(ctx context.Context, mem
 	return total.Float64, nil
 }
 
+// queryAttendance runs a SELECT over attendanceColumns and scans every row.
+func (s *SQLiteStore) queryAttendance(ctx context.Context, query string, args ...interface{}) ([]domain.Attendance, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []domain.Attendance
+	for rows.Next() {
+		entity, err := scanAttendance(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, entity)
+	}
+	return results, rows.Err()
+}
+
+// SaveTopicLinks records the rotor topics taught during attendances; existing links are kept.
+// PRE: each link has AttendanceID, TopicID, RotorThemeID and RotorID
+// POST: links are persisted in one transaction
+func (s *SQLiteStore) SaveTopicLinks(ctx context.Context, links []domain.TopicLink) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, l := range links {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT OR IGNORE INTO attendance_topic (attendance_id, topic_id, rotor_theme_id, rotor_id) VALUES (?, ?, ?, ?)`,
+			l.AttendanceID, l.TopicID, l.RotorThemeID, l.RotorID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ListTopicLinksByRotor lists the topic links of a rotor with each attendance's member and class date.
+// PRE: rotorID is non-empty
+// POST: Returns links ordered by class date; attendances without a class date use their check-in date
+func (s *SQLiteStore) ListTopicLinksByRotor(ctx context.Context, rotorID string) ([]domain.TopicLink, error) {
+	query := `SELECT t.attendance_id, t.topic_id, t.rotor_theme_id, t.rotor_id, a.member_id,
+			COALESCE(NULLIF(a.class_date, ''), SUBSTR(a.check_in_time, 1, 10))
+		FROM attendance_topic t JOIN attendance a ON a.id = t.attendance_id
+		WHERE t.rotor_id = ?
+		ORDER BY 6, t.attendance_id`
+	rows, err := s.db.QueryContext(ctx, query, rotorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []domain.TopicLink
+	for rows.Next() {
+		var l domain.TopicLink
+		if err := rows.Scan(&l.AttendanceID, &l.TopicID, &l.RotorThemeID, &l.RotorID, &l.MemberID, &l.ClassDate); err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+	return links, rows.Err()
+}
+
+// scanAttendance extracts an Attendance from a row scanner function.
+func scanAttendance(scan func(dest ...interface{}) error) (domain.Attendance, error) {
+	var entity domain.Attendance
+	var checkInStr string
+	var checkOutStr, scheduleID, classDate sql.NullString
+	if err := scan(
+		&entity.ID,
+		&checkInStr,
+		&checkOutStr,
+		&entity.MemberID,
+		&scheduleID,
+		&classDate,
+		&entity.MatHours,
+		&entity.LocationID,
+	); err != nil {
+		return domain.Attendance{}, err
+	}
+	if scheduleID.Valid {
+		entity.ScheduleID = scheduleID.String
+	}
+	if classDate.Valid {
+		entity.ClassDate = classDate.String
+	}
+	// Parse check-in time (required)
+	var err error
+	entity.CheckInTime, err = parseStoredTime(checkInStr)
+	if err != nil {
+		return domain.Attendance{}, fmt.Errorf("failed to parse check_in_time: %w", err)
+	}
+	// Parse check-out time (optional)
+	if checkOutStr.Valid {
+		parsedTime, parseErr := parseStoredTime(checkOutStr.String)
+		if parseErr != nil {
+			return domain.Attendance{}, fmt.Errorf("failed to parse check_out_time: %w", parseErr)
+		}
+		entity.CheckOutTime = parsedTime
+	}
+	return entity, nil
+}
+
 func parseStoredTime(value string) (time.Time, error) {
 	if idx := strings.Index(value, " m="); idx != -1 {
 		value = value[:idx]
@@ -527,3 +501,91 @@ func parseStoredTime(value string) (time.Time, error) {
 	}
 	return time.Time{}, fmt.Errorf("unsupported time format: %q", value)
 }
+
+// MakeupCreditSQLiteStore implements MakeupCreditStore using SQLite.
+type MakeupCreditSQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewMakeupCreditSQLiteStore creates a new MakeupCreditSQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new MakeupCreditSQLiteStore instance
+func NewMakeupCreditSQLiteStore(db storage.SQLDB) *MakeupCreditSQLiteStore {
+	return &MakeupCreditSQLiteStore{db: db}
+}
+
+// makeupCreditColumns is the shared column list for makeup_credit SELECTs; order matches scanMakeupCredit.
+const makeupCreditColumns = "id, member_id, term_id, class_date, reason, awarded_by, awarded_at"
+
+// Save inserts or updates a makeup credit.
+// PRE: value has been validated
+// POST: Credit is persisted
+func (s *MakeupCreditSQLiteStore) Save(ctx context.Context, value domain.MakeupCredit) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO makeup_credit (`+makeupCreditColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET
+		   member_id=excluded.member_id, term_id=excluded.term_id, class_date=excluded.class_date,
+		   reason=excluded.reason, awarded_by=excluded.awarded_by, awarded_at=excluded.awarded_at`,
+		value.ID, value.MemberID, value.TermID, value.ClassDate, value.Reason, value.AwardedBy,
+		value.AwardedAt.Format(time.RFC3339))
+	return err
+}
+
+// GetByID retrieves a makeup credit by ID.
+// PRE: id is non-empty
+// POST: Returns the credit or sql.ErrNoRows
+func (s *MakeupCreditSQLiteStore) GetByID(ctx context.Context, id string) (domain.MakeupCredit, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+makeupCreditColumns+" FROM makeup_credit WHERE id = ?", id)
+	return scanMakeupCredit(row.Scan)
+}
+
+// Delete removes a makeup credit.
+// PRE: id is non-empty
+// POST: Credit with the given id is removed
+func (s *MakeupCreditSQLiteStore) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM makeup_credit WHERE id = ?", id)
+	return err
+}
+
+// ListByTermID returns every credit awarded for a term, oldest first.
+// PRE: termID is non-empty
+// POST: Returns credits or an empty slice
+func (s *MakeupCreditSQLiteStore) ListByTermID(ctx context.Context, termID string) ([]domain.MakeupCredit, error) {
+	return s.list(ctx, "SELECT "+makeupCreditColumns+" FROM makeup_credit WHERE term_id = ? ORDER BY awarded_at", termID)
+}
+
+// ListByMemberID returns a member's credits across all terms, newest first.
+// PRE: memberID is non-empty
+// POST: Returns credits or an empty slice
+func (s *MakeupCreditSQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]domain.MakeupCredit, error) {
+	return s.list(ctx, "SELECT "+makeupCreditColumns+" FROM makeup_credit WHERE member_id = ? ORDER BY awarded_at DESC", memberID)
+}
+
+func (s *MakeupCreditSQLiteStore) list(ctx context.Context, query string, args ...any) ([]domain.MakeupCredit, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.MakeupCredit
+	for rows.Next() {
+		c, err := scanMakeupCredit(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+	return list, rows.Err()
+}
+
+// scanMakeupCredit extracts a MakeupCredit from a row scanner function.
+func scanMakeupCredit(scan func(dest ...interface{}) error) (domain.MakeupCredit, error) {
+	var c domain.MakeupCredit
+	var awardedAt string
+	if err := scan(&c.ID, &c.MemberID, &c.TermID, &c.ClassDate, &c.Reason, &c.AwardedBy, &awardedAt); err != nil {
+		return domain.MakeupCredit{}, err
+	}
+	c.AwardedAt, _ = time.Parse(time.RFC3339, awardedAt)
+	return c, nil
+}
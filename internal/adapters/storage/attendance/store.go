@@ -14,17 +14,38 @@ type Store interface {
 	List(ctx context.Context, filter ListFilter) ([]domain.Attendance, error)
 	ListByMemberID(ctx context.Context, memberID string) ([]domain.Attendance, error)
 	ListByMemberIDAndDate(ctx context.Context, memberID string, date string) ([]domain.Attendance, error)
+	ListByMemberScheduleAndClassDate(ctx context.Context, memberID string, scheduleID string, classDate string) ([]domain.Attendance, error)
 	ListByDateRange(ctx context.Context, startDate string, endDate string) ([]domain.Attendance, error)
 	ListDistinctMemberIDsByScheduleAndDate(ctx context.Context, scheduleID string, classDate string) ([]string, error)
 	ListDistinctMemberIDsByScheduleIDsSince(ctx context.Context, scheduleIDs []string, since string) ([]string, error)
+	CountByScheduleIDAndDateRange(ctx context.Context, scheduleID string, startDate string, endDate string) (map[string]int, error)
 	ListByMemberIDAndDateRange(ctx context.Context, memberID string, startDate string, endDate string) ([]domain.Attendance, error)
 	DeleteByMemberIDAndDateRange(ctx context.Context, memberID string, startDate string, endDate string) (int, error)
 	SumMatHoursByMemberID(ctx context.Context, memberID string) (float64, error)
 	SumMatHoursByMemberIDAndDateRange(ctx context.Context, memberID string, startDate string, endDate string) (float64, error)
+	TotalsByMemberID(ctx context.Context, memberID string) (domain.Totals, error)
+
+	// Rotor topics taught during each attendance
+	SaveTopicLinks(ctx context.Context, links []domain.TopicLink) error
+	ListTopicLinksByRotor(ctx context.Context, rotorID string) ([]domain.TopicLink, error)
 }
 
-// ListFilter carries filtering parameters for List operations.
+// ListFilter carries filtering and paging parameters for List operations.
+// Empty fields are ignored.
 type ListFilter struct {
-	Limit  int
-	Offset int
+	MemberID string
+	From     string // first check-in date, inclusive YYYY-MM-DD
+	To       string // last check-in date, inclusive YYYY-MM-DD
+	Dir      string // "asc" for oldest first; newest first otherwise
+	Limit    int    // 0 = 1000
+	Offset   int
+}
+
+// MakeupCreditStore persists MakeupCredit state.
+type MakeupCreditStore interface {
+	Save(ctx context.Context, value domain.MakeupCredit) error
+	GetByID(ctx context.Context, id string) (domain.MakeupCredit, error)
+	Delete(ctx context.Context, id string) error
+	ListByTermID(ctx context.Context, termID string) ([]domain.MakeupCredit, error)
+	ListByMemberID(ctx context.Context, memberID string) ([]domain.MakeupCredit, error)
 }
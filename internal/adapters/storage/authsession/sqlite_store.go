@@ -0,0 +1,168 @@
+package authsession
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/authsession"
+)
+
+// dateLayout is fixed-width and always UTC so expires_at compares correctly as text.
+const dateLayout = "2006-01-02T15:04:05.000000000Z"
+
+const sessionColumns = `id, token_hash, account_id, email, role, beta_tester, password_change_required, location_id, locale,
+	real_account_id, real_email, real_role, impersonation_expires_at, created_at, last_seen_at, expires_at`
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new session store.
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// GetByID retrieves a Session by its ID.
+// PRE: id is non-empty
+// POST: Returns the entity or an error wrapping ErrNotFound
+func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Session, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+sessionColumns+" FROM auth_session WHERE id = ?", id)
+	return scanSession(row.Scan)
+}
+
+// GetByTokenHash retrieves the Session whose cookie token hashes to tokenHash.
+// PRE: tokenHash is non-empty
+// POST: Returns the entity or an error wrapping ErrNotFound
+func (s *SQLiteStore) GetByTokenHash(ctx context.Context, tokenHash string) (domain.Session, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+sessionColumns+" FROM auth_session WHERE token_hash = ?", tokenHash)
+	return scanSession(row.Scan)
+}
+
+// Save persists a Session to the database.
+// PRE: entity has been validated
+// POST: Entity is persisted (insert or update)
+func (s *SQLiteStore) Save(ctx context.Context, entity domain.Session) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO auth_session (`+sessionColumns+`)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET
+		   account_id=excluded.account_id, email=excluded.email, role=excluded.role,
+		   beta_tester=excluded.beta_tester, password_change_required=excluded.password_change_required,
+		   location_id=excluded.location_id, locale=excluded.locale, real_account_id=excluded.real_account_id,
+		   real_email=excluded.real_email, real_role=excluded.real_role,
+		   impersonation_expires_at=excluded.impersonation_expires_at,
+		   last_seen_at=excluded.last_seen_at, expires_at=excluded.expires_at`,
+		entity.ID, entity.TokenHash, entity.AccountID, entity.Email, entity.Role,
+		boolInt(entity.BetaTester), boolInt(entity.PasswordChangeRequired), entity.LocationID, entity.Locale,
+		entity.RealAccountID, entity.RealEmail, entity.RealRole, formatOptionalTime(entity.ImpersonationExpiresAt),
+		formatTime(entity.CreatedAt), formatTime(entity.LastSeenAt), formatTime(entity.ExpiresAt),
+	)
+	return err
+}
+
+// Delete removes a Session from the database.
+// PRE: id is non-empty
+// POST: Session with given id is removed
+func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM auth_session WHERE id = ?", id)
+	return err
+}
+
+// DeleteByAccountID removes every Session belonging to an account, including ones
+// where the account is the admin behind an impersonation.
+// PRE: accountID is non-empty
+// POST: Returns the number of sessions removed
+func (s *SQLiteStore) DeleteByAccountID(ctx context.Context, accountID string) (int, error) {
+	result, err := s.db.ExecContext(ctx,
+		"DELETE FROM auth_session WHERE account_id = ? OR real_account_id = ?", accountID, accountID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+// DeleteExpired removes sessions that expired at or before now.
+// PRE: none
+// POST: Returns the number of sessions removed
+func (s *SQLiteStore) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM auth_session WHERE expires_at <= ?", formatTime(now))
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+// ListActive retrieves sessions that have not expired at now, most recently used first.
+// PRE: none
+// POST: Returns all unexpired sessions
+func (s *SQLiteStore) ListActive(ctx context.Context, now time.Time) ([]domain.Session, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+sessionColumns+" FROM auth_session WHERE expires_at > ? ORDER BY last_seen_at DESC", formatTime(now))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []domain.Session
+	for rows.Next() {
+		entity, err := scanSession(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, entity)
+	}
+	return results, rows.Err()
+}
+
+// scanSession reads one row in sessionColumns order.
+func scanSession(scan func(dest ...any) error) (domain.Session, error) {
+	var entity domain.Session
+	var betaTester, passwordChangeRequired int
+	var impersonationExpiresAt, createdAt, lastSeenAt, expiresAt string
+	err := scan(&entity.ID, &entity.TokenHash, &entity.AccountID, &entity.Email, &entity.Role,
+		&betaTester, &passwordChangeRequired, &entity.LocationID, &entity.Locale,
+		&entity.RealAccountID, &entity.RealEmail, &entity.RealRole, &impersonationExpiresAt,
+		&createdAt, &lastSeenAt, &expiresAt)
+	if err == sql.ErrNoRows {
+		return domain.Session{}, fmt.Errorf("%w: %w", domain.ErrNotFound, err)
+	}
+	if err != nil {
+		return domain.Session{}, err
+	}
+	entity.BetaTester = betaTester == 1
+	entity.PasswordChangeRequired = passwordChangeRequired == 1
+	if impersonationExpiresAt != "" {
+		entity.ImpersonationExpiresAt, _ = time.Parse(dateLayout, impersonationExpiresAt)
+	}
+	entity.CreatedAt, _ = time.Parse(dateLayout, createdAt)
+	entity.LastSeenAt, _ = time.Parse(dateLayout, lastSeenAt)
+	entity.ExpiresAt, _ = time.Parse(dateLayout, expiresAt)
+	return entity, nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateLayout)
+}
+
+// formatOptionalTime stores a zero time as an empty string.
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return formatTime(t)
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+var _ Store = (*SQLiteStore)(nil)
@@ -0,0 +1,19 @@
+package authsession
+
+import (
+	"context"
+	"time"
+
+	domain "workshop/internal/domain/authsession"
+)
+
+// Store persists signed-in sessions.
+type Store interface {
+	GetByID(ctx context.Context, id string) (domain.Session, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (domain.Session, error)
+	Save(ctx context.Context, value domain.Session) error
+	Delete(ctx context.Context, id string) error
+	DeleteByAccountID(ctx context.Context, accountID string) (int, error)
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+	ListActive(ctx context.Context, now time.Time) ([]domain.Session, error)
+}
@@ -0,0 +1,181 @@
+package availability
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/availability"
+)
+
+// windowColumns is the shared column list for window SELECTs; order matches scanWindow.
+const windowColumns = "id, coach_id, day, start_time, end_time"
+
+// exceptionColumns is the shared column list for exception SELECTs; order matches scanException.
+const exceptionColumns = "id, coach_id, date, available, start_time, end_time, reason, created_at"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// GetWindow retrieves a recurring availability window by ID.
+// PRE: id is non-empty
+// POST: Returns the window or an error if not found
+func (s *SQLiteStore) GetWindow(ctx context.Context, id string) (domain.Window, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+windowColumns+" FROM coach_availability WHERE id = ?", id)
+	w, err := scanWindow(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Window{}, fmt.Errorf("availability window not found: %w", err)
+	}
+	return w, err
+}
+
+// SaveWindow persists a window (insert or update).
+// PRE: value has been validated
+// POST: The window is persisted
+func (s *SQLiteStore) SaveWindow(ctx context.Context, value domain.Window) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO coach_availability (`+windowColumns+`) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET day = excluded.day, start_time = excluded.start_time, end_time = excluded.end_time`,
+		value.ID, value.CoachID, value.Day, value.StartTime, value.EndTime)
+	return err
+}
+
+// DeleteWindow removes a window.
+// PRE: id is non-empty
+// POST: The window is gone
+func (s *SQLiteStore) DeleteWindow(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM coach_availability WHERE id = ?", id)
+	return err
+}
+
+// ListWindows returns every coach's recurring availability.
+// PRE: none
+// POST: Returns windows ordered by coach, day and start time, or an empty slice
+func (s *SQLiteStore) ListWindows(ctx context.Context) ([]domain.Window, error) {
+	return s.queryWindows(ctx, "SELECT "+windowColumns+" FROM coach_availability ORDER BY coach_id, day, start_time")
+}
+
+// ListWindowsByCoach returns one coach's recurring availability.
+// PRE: coachID is non-empty
+// POST: Returns windows ordered by day and start time, or an empty slice
+func (s *SQLiteStore) ListWindowsByCoach(ctx context.Context, coachID string) ([]domain.Window, error) {
+	return s.queryWindows(ctx, "SELECT "+windowColumns+" FROM coach_availability WHERE coach_id = ? ORDER BY day, start_time", coachID)
+}
+
+// GetException retrieves an exception by ID.
+// PRE: id is non-empty
+// POST: Returns the exception or an error if not found
+func (s *SQLiteStore) GetException(ctx context.Context, id string) (domain.Exception, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+exceptionColumns+" FROM coach_availability_exception WHERE id = ?", id)
+	e, err := scanException(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Exception{}, fmt.Errorf("availability exception not found: %w", err)
+	}
+	return e, err
+}
+
+// SaveException persists an exception (insert or update).
+// PRE: value has been validated
+// POST: The exception is persisted
+func (s *SQLiteStore) SaveException(ctx context.Context, value domain.Exception) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO coach_availability_exception (`+exceptionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET date = excluded.date, available = excluded.available, start_time = excluded.start_time,
+		end_time = excluded.end_time, reason = excluded.reason`,
+		value.ID, value.CoachID, value.Date, value.Available, value.StartTime, value.EndTime, value.Reason, value.CreatedAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+// DeleteException removes an exception.
+// PRE: id is non-empty
+// POST: The exception is gone
+func (s *SQLiteStore) DeleteException(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM coach_availability_exception WHERE id = ?", id)
+	return err
+}
+
+// ListExceptions returns every coach's exceptions between two dates, inclusive.
+// PRE: from and to are YYYY-MM-DD
+// POST: Returns exceptions ordered by date, or an empty slice
+func (s *SQLiteStore) ListExceptions(ctx context.Context, from, to string) ([]domain.Exception, error) {
+	return s.queryExceptions(ctx, "SELECT "+exceptionColumns+" FROM coach_availability_exception WHERE date BETWEEN ? AND ? ORDER BY date, start_time", from, to)
+}
+
+// ListExceptionsByCoach returns one coach's exceptions from a date on.
+// PRE: coachID is non-empty; from is YYYY-MM-DD
+// POST: Returns exceptions ordered by date, or an empty slice
+func (s *SQLiteStore) ListExceptionsByCoach(ctx context.Context, coachID, from string) ([]domain.Exception, error) {
+	return s.queryExceptions(ctx, "SELECT "+exceptionColumns+" FROM coach_availability_exception WHERE coach_id = ? AND date >= ? ORDER BY date, start_time", coachID, from)
+}
+
+// queryWindows runs a window SELECT and scans every row.
+func (s *SQLiteStore) queryWindows(ctx context.Context, query string, args ...any) ([]domain.Window, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Window
+	for rows.Next() {
+		w, err := scanWindow(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, w)
+	}
+	return list, rows.Err()
+}
+
+// queryExceptions runs an exception SELECT and scans every row.
+func (s *SQLiteStore) queryExceptions(ctx context.Context, query string, args ...any) ([]domain.Exception, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Exception
+	for rows.Next() {
+		e, err := scanException(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+	return list, rows.Err()
+}
+
+// scanWindow extracts a Window from a row scanner function.
+func scanWindow(scan func(dest ...interface{}) error) (domain.Window, error) {
+	var w domain.Window
+	if err := scan(&w.ID, &w.CoachID, &w.Day, &w.StartTime, &w.EndTime); err != nil {
+		return domain.Window{}, err
+	}
+	return w, nil
+}
+
+// scanException extracts an Exception from a row scanner function.
+func scanException(scan func(dest ...interface{}) error) (domain.Exception, error) {
+	var e domain.Exception
+	var createdAt string
+	if err := scan(&e.ID, &e.CoachID, &e.Date, &e.Available, &e.StartTime, &e.EndTime, &e.Reason, &createdAt); err != nil {
+		return domain.Exception{}, err
+	}
+	e.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return e, nil
+}
+
+// Ensure interface compliance at compile time.
+var _ Store = (*SQLiteStore)(nil)
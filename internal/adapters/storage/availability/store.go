@@ -0,0 +1,21 @@
+package availability
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/availability"
+)
+
+// Store persists coaches' recurring availability and their one-off exceptions.
+type Store interface {
+	GetWindow(ctx context.Context, id string) (domain.Window, error)
+	SaveWindow(ctx context.Context, value domain.Window) error
+	DeleteWindow(ctx context.Context, id string) error
+	ListWindows(ctx context.Context) ([]domain.Window, error)
+	ListWindowsByCoach(ctx context.Context, coachID string) ([]domain.Window, error)
+	GetException(ctx context.Context, id string) (domain.Exception, error)
+	SaveException(ctx context.Context, value domain.Exception) error
+	DeleteException(ctx context.Context, id string) error
+	ListExceptions(ctx context.Context, from, to string) ([]domain.Exception, error)
+	ListExceptionsByCoach(ctx context.Context, coachID, from string) ([]domain.Exception, error)
+}
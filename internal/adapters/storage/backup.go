@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"modernc.org/sqlite"
+)
+
+// BackupDB takes and restores whole-database snapshots of the live SQLite database.
+// It is used by the backup orchestrators; stores never need it.
+type BackupDB struct {
+	db *sql.DB
+}
+
+// NewBackupDB wraps the raw (untimed) database handle.
+func NewBackupDB(db *sql.DB) *BackupDB {
+	return &BackupDB{db: db}
+}
+
+// Snapshot writes a consistent, compacted copy of the database to path with VACUUM INTO.
+// PRE: path does not exist (VACUUM INTO refuses to overwrite)
+// POST: path is a standalone SQLite database; the live database is not locked for writers
+func (b *BackupDB) Snapshot(ctx context.Context, path string) error {
+	if _, err := b.db.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("snapshot database: %w", err)
+	}
+	return nil
+}
+
+// Inspect opens a backup file read-only and reports its integrity and schema version.
+// PRE: path is a local file
+// POST: Returns the PRAGMA quick_check result ("ok" when intact) and the backup's schema version
+func (b *BackupDB) Inspect(ctx context.Context, path string) (string, int, error) {
+	snap, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return "", 0, fmt.Errorf("open backup: %w", err)
+	}
+	defer snap.Close()
+
+	var integrity string
+	if err := snap.QueryRowContext(ctx, "PRAGMA quick_check").Scan(&integrity); err != nil {
+		return "", 0, fmt.Errorf("check backup: %w", err)
+	}
+	version, err := SchemaVersion(snap)
+	if err != nil {
+		return "", 0, err
+	}
+	return integrity, version, nil
+}
+
+// restorer is the part of the modernc.org/sqlite driver connection used for restores.
+type restorer interface {
+	NewRestore(srcURI string) (*sqlite.Backup, error)
+}
+
+// Restore replaces the live database contents with the backup at path using SQLite's
+// online backup API, then migrates the result to the latest schema.
+// Other connections in the pool see the restored data on their next query.
+// PRE: path passed Inspect with a schema version no newer than LatestSchemaVersion
+// POST: The live database matches the backup, migrated to the latest schema
+func (b *BackupDB) Restore(ctx context.Context, path string) error {
+	conn, err := b.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("restore: acquire connection: %w", err)
+	}
+	err = conn.Raw(func(driverConn any) error {
+		r, ok := driverConn.(restorer)
+		if !ok {
+			return fmt.Errorf("restore: driver %T does not support online backup", driverConn)
+		}
+		bk, err := r.NewRestore("file:" + path + "?mode=ro")
+		if err != nil {
+			return err
+		}
+		for {
+			more, err := bk.Step(-1)
+			if err != nil {
+				bk.Finish()
+				return err
+			}
+			if !more {
+				break
+			}
+		}
+		return bk.Finish()
+	})
+	conn.Close()
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+	slog.InfoContext(ctx, "schema_restored", "from", path)
+
+	// An older backup is brought forward so it matches the running code.
+	return MigrateDB(b.db, "")
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// openFileTestDB opens a WAL-mode database file like the server does.
+func openFileTestDB(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_pragma=foreign_keys(ON)")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := MigrateDB(db, ""); err != nil {
+		t.Fatalf("MigrateDB failed: %v", err)
+	}
+	return db
+}
+
+// TestBackupDB_SnapshotAndRestore verifies a snapshot can be inspected and restored over later changes.
+func TestBackupDB_SnapshotAndRestore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db := openFileTestDB(t, filepath.Join(dir, "live.db"))
+	b := NewBackupDB(db)
+
+	if _, err := db.Exec(`INSERT INTO member (id, email, name, program, status) VALUES ('m1', 'a@test.com', 'Before', 'adults', 'active')`); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	snapPath := filepath.Join(dir, "snap.db")
+	if err := b.Snapshot(ctx, snapPath); err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+
+	integrity, version, err := b.Inspect(ctx, snapPath)
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	if integrity != "ok" {
+		t.Errorf("integrity = %q, want ok", integrity)
+	}
+	if version != LatestSchemaVersion() {
+		t.Errorf("version = %d, want %d", version, LatestSchemaVersion())
+	}
+
+	// Changes after the snapshot are rolled back by the restore
+	if _, err := db.Exec(`UPDATE member SET name = 'After' WHERE id = 'm1'`); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO member (id, email, name, program, status) VALUES ('m2', 'b@test.com', 'New', 'adults', 'active')`); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if err := b.Restore(ctx, snapPath); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+
+	var name string
+	if err := db.QueryRow(`SELECT name FROM member WHERE id = 'm1'`).Scan(&name); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if name != "Before" {
+		t.Errorf("name = %q, want Before", name)
+	}
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM member`).Scan(&count); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("member count = %d, want 1", count)
+	}
+}
+
+// TestBackupDB_InspectRejectsGarbage verifies a non-database file fails inspection.
+func TestBackupDB_InspectRejectsGarbage(t *testing.T) {
+	dir := t.TempDir()
+	db := openFileTestDB(t, filepath.Join(dir, "live.db"))
+	garbage := filepath.Join(dir, "garbage.db")
+	if err := os.WriteFile(garbage, []byte("this is not a database file at all, just some text padding it out"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, _, err := NewBackupDB(db).Inspect(context.Background(), garbage); err == nil {
+		t.Error("expected error inspecting a non-database file")
+	}
+}
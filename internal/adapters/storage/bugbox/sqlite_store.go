@@ -10,6 +10,12 @@ import (
 	domain "workshop/internal/domain/bugbox"
 )
 
+// submissionColumns is the shared column list for submission SELECTs; order matches scanSubmission.
+const submissionColumns = `id, summary, description, steps, expected, actual,
+	route, user_agent, viewport, role, impersonated_role,
+	submitted_at, screenshot_path, github_issue_number, github_issue_url,
+	reporter_id, status, assignee_id, duplicate_of, updated_at`
+
 type sqliteStore struct {
 	db storage.SQLDB
 }
@@ -19,16 +25,24 @@ func NewSQLiteStore(db storage.SQLDB) Store {
 	return &sqliteStore{db: db}
 }
 
-// Save persists a Submission.
-// PRE: s.ID is non-empty and unique
-// POST: row inserted into bugbox_submission
+// Save persists a Submission (insert or update).
+// PRE: s.ID is non-empty
+// POST: row upserted into bugbox_submission
 func (s *sqliteStore) Save(ctx context.Context, sub domain.Submission) error {
+	status := sub.Status
+	if status == "" {
+		status = domain.StatusNew
+	}
+	updatedAt := ""
+	if !sub.UpdatedAt.IsZero() {
+		updatedAt = sub.UpdatedAt.UTC().Format(time.RFC3339)
+	}
 	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO bugbox_submission (
-			id, summary, description, steps, expected, actual,
-			route, user_agent, viewport, role, impersonated_role,
-			submitted_at, screenshot_path, github_issue_number, github_issue_url
-		) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+		INSERT INTO bugbox_submission (`+submissionColumns+`)
+		VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)
+		ON CONFLICT(id) DO UPDATE SET
+			status = excluded.status, assignee_id = excluded.assignee_id,
+			duplicate_of = excluded.duplicate_of, updated_at = excluded.updated_at`,
 		sub.ID,
 		sub.Summary,
 		sub.Description,
@@ -44,6 +58,11 @@ func (s *sqliteStore) Save(ctx context.Context, sub domain.Submission) error {
 		sub.ScreenshotPath,
 		sub.GitHubIssueNumber,
 		sub.GitHubIssueURL,
+		sub.ReporterID,
+		status,
+		sub.AssigneeID,
+		sub.DuplicateOf,
+		updatedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("bugbox save: %w", err)
@@ -55,15 +74,96 @@ func (s *sqliteStore) Save(ctx context.Context, sub domain.Submission) error {
 // PRE: id is non-empty
 // POST: returns domain.Submission or error if not found
 func (s *sqliteStore) GetByID(ctx context.Context, id string) (domain.Submission, error) {
-	row := s.db.QueryRowContext(ctx, `
-		SELECT id, summary, description, steps, expected, actual,
-		       route, user_agent, viewport, role, impersonated_role,
-		       submitted_at, screenshot_path, github_issue_number, github_issue_url
-		FROM bugbox_submission WHERE id = ?`, id)
+	row := s.db.QueryRowContext(ctx, `SELECT `+submissionColumns+` FROM bugbox_submission WHERE id = ?`, id)
+	sub, err := scanSubmission(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Submission{}, fmt.Errorf("bugbox submission not found: %s", id)
+	}
+	if err != nil {
+		return domain.Submission{}, fmt.Errorf("bugbox get: %w", err)
+	}
+	return sub, nil
+}
+
+// List returns submissions matching the filter, newest first.
+// PRE: none
+// POST: Returns matching submissions or an empty slice
+func (s *sqliteStore) List(ctx context.Context, filter ListFilter) ([]domain.Submission, error) {
+	query := `SELECT ` + submissionColumns + ` FROM bugbox_submission WHERE 1=1`
+	var args []interface{}
+	if filter.Status != "" {
+		query += ` AND status = ?`
+		args = append(args, filter.Status)
+	}
+	if filter.ReporterID != "" {
+		query += ` AND reporter_id = ?`
+		args = append(args, filter.ReporterID)
+	}
+	query += ` ORDER BY submitted_at DESC`
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("bugbox list: %w", err)
+	}
+	defer rows.Close()
 
+	var list []domain.Submission
+	for rows.Next() {
+		sub, err := scanSubmission(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("bugbox list: %w", err)
+		}
+		list = append(list, sub)
+	}
+	return list, rows.Err()
+}
+
+// SaveComment persists a comment on a submission.
+// PRE: c has been validated
+// POST: row inserted into bugbox_comment
+func (s *sqliteStore) SaveComment(ctx context.Context, c domain.Comment) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO bugbox_comment (id, submission_id, author_id, body, created_at) VALUES (?, ?, ?, ?, ?)`,
+		c.ID, c.SubmissionID, c.AuthorID, c.Body, c.CreatedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("bugbox save comment: %w", err)
+	}
+	return nil
+}
+
+// ListComments returns a submission's comments, oldest first.
+// PRE: submissionID is non-empty
+// POST: Returns the comments or an empty slice
+func (s *sqliteStore) ListComments(ctx context.Context, submissionID string) ([]domain.Comment, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, submission_id, author_id, body, created_at FROM bugbox_comment WHERE submission_id = ? ORDER BY created_at, id`, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("bugbox list comments: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.Comment
+	for rows.Next() {
+		var c domain.Comment
+		var createdAt string
+		if err := rows.Scan(&c.ID, &c.SubmissionID, &c.AuthorID, &c.Body, &createdAt); err != nil {
+			return nil, fmt.Errorf("bugbox list comments: %w", err)
+		}
+		c.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		list = append(list, c)
+	}
+	return list, rows.Err()
+}
+
+// scanSubmission extracts a Submission from a row scanner function.
+func scanSubmission(scan func(dest ...interface{}) error) (domain.Submission, error) {
 	var sub domain.Submission
-	var submittedAt string
-	err := row.Scan(
+	var submittedAt, updatedAt string
+	err := scan(
 		&sub.ID,
 		&sub.Summary,
 		&sub.Description,
@@ -79,13 +179,16 @@ func (s *sqliteStore) GetByID(ctx context.Context, id string) (domain.Submission
 		&sub.ScreenshotPath,
 		&sub.GitHubIssueNumber,
 		&sub.GitHubIssueURL,
+		&sub.ReporterID,
+		&sub.Status,
+		&sub.AssigneeID,
+		&sub.DuplicateOf,
+		&updatedAt,
 	)
-	if err == sql.ErrNoRows {
-		return domain.Submission{}, fmt.Errorf("bugbox submission not found: %s", id)
-	}
 	if err != nil {
-		return domain.Submission{}, fmt.Errorf("bugbox get: %w", err)
+		return domain.Submission{}, err
 	}
 	sub.SubmittedAt, _ = time.Parse(time.RFC3339, submittedAt)
+	sub.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
 	return sub, nil
 }
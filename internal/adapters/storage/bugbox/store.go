@@ -6,8 +6,18 @@ import (
 	domain "workshop/internal/domain/bugbox"
 )
 
-// Store persists BugBox Submission state.
+// Store persists BugBox Submission state and the comments on each report.
 type Store interface {
 	Save(ctx context.Context, s domain.Submission) error
 	GetByID(ctx context.Context, id string) (domain.Submission, error)
+	List(ctx context.Context, filter ListFilter) ([]domain.Submission, error)
+	SaveComment(ctx context.Context, c domain.Comment) error
+	ListComments(ctx context.Context, submissionID string) ([]domain.Comment, error)
+}
+
+// ListFilter carries filtering parameters for List operations.
+type ListFilter struct {
+	Status     string // empty = every status
+	ReporterID string // empty = every reporter
+	Limit      int    // 0 = no limit
 }
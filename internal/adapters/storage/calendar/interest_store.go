@@ -8,10 +8,10 @@ import (
 
 // InterestStore persists CompetitionInterest state.
 type InterestStore interface {
-	Save(ctx context.Context, ci domain.CompetitionInterest) error
-	Delete(ctx context.Context, eventID, memberID string) error
-	GetByEvent(ctx context.Context, eventID string) ([]domain.CompetitionInterest, error)
-	GetByMember(ctx context.Context, memberID string) ([]domain.CompetitionInterest, error)
-	CountByEvent(ctx context.Context, eventID string) (int, error)
+	SaveInterest(ctx context.Context, ci domain.CompetitionInterest) error
+	DeleteInterest(ctx context.Context, eventID, memberID string) error
+	GetInterest(ctx context.Context, eventID, memberID string) (domain.CompetitionInterest, error)
+	GetInterestsByEvent(ctx context.Context, eventID string) ([]domain.CompetitionInterest, error)
+	CountInterestsByEvent(ctx context.Context, eventID string) (int, error)
 	IsInterested(ctx context.Context, eventID, memberID string) (bool, error)
 }
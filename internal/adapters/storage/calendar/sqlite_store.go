@@ -115,16 +115,23 @@ func parseDate(s string) time.Time {
 	return t
 }
 
-// SaveInterest inserts a competition interest record.
+// SaveInterest inserts a competition interest record, or updates the status and
+// weight class if the member already has one for the event.
 // PRE: ci.ID, ci.EventID, ci.MemberID are non-empty; ci.CreatedAt is valid.
-// POST: The interest is persisted (idempotent on conflict).
+// POST: The interest is persisted; the original ID and CreatedAt are kept on conflict.
 // INVARIANT: Database connection is valid.
 func (s *SQLiteStore) SaveInterest(ctx context.Context, ci domain.CompetitionInterest) error {
+	status := ci.Status
+	if status == "" {
+		status = domain.InterestStatusInterested
+	}
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO competition_interest (id, event_id, member_id, created_at)
-		 VALUES (?, ?, ?, ?)
-		 ON CONFLICT(event_id, member_id) DO NOTHING`,
-		ci.ID, ci.EventID, ci.MemberID, ci.CreatedAt,
+		`INSERT INTO competition_interest (id, event_id, member_id, status, weight_class, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(event_id, member_id) DO UPDATE SET
+		   status = excluded.status,
+		   weight_class = excluded.weight_class`,
+		ci.ID, ci.EventID, ci.MemberID, status, ci.WeightClass, ci.CreatedAt,
 	)
 	return err
 }
@@ -141,13 +148,23 @@ func (s *SQLiteStore) DeleteInterest(ctx context.Context, eventID, memberID stri
 	return err
 }
 
+// interestColumns lists the competition_interest columns read by scanInterest.
+const interestColumns = `id, event_id, member_id, status, weight_class, created_at`
+
+// scanInterest reads one competition_interest row.
+func scanInterest(scan func(dest ...interface{}) error) (domain.CompetitionInterest, error) {
+	var ci domain.CompetitionInterest
+	err := scan(&ci.ID, &ci.EventID, &ci.MemberID, &ci.Status, &ci.WeightClass, &ci.CreatedAt)
+	return ci, err
+}
+
 // GetInterestsByEvent returns all interest records for an event.
 // PRE: eventID is non-empty.
 // POST: Returns slice of interests (empty if none found), ordered by creation time.
 // INVARIANT: Database connection is valid.
 func (s *SQLiteStore) GetInterestsByEvent(ctx context.Context, eventID string) ([]domain.CompetitionInterest, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, event_id, member_id, created_at FROM competition_interest WHERE event_id = ?`,
+		`SELECT `+interestColumns+` FROM competition_interest WHERE event_id = ? ORDER BY created_at`,
 		eventID,
 	)
 	if err != nil {
@@ -157,8 +174,8 @@ func (s *SQLiteStore) GetInterestsByEvent(ctx context.Context, eventID string) (
 
 	var interests []domain.CompetitionInterest
 	for rows.Next() {
-		var ci domain.CompetitionInterest
-		if err := rows.Scan(&ci.ID, &ci.EventID, &ci.MemberID, &ci.CreatedAt); err != nil {
+		ci, err := scanInterest(rows.Scan)
+		if err != nil {
 			return nil, err
 		}
 		interests = append(interests, ci)
@@ -166,6 +183,17 @@ func (s *SQLiteStore) GetInterestsByEvent(ctx context.Context, eventID string) (
 	return interests, rows.Err()
 }
 
+// GetInterest returns a member's interest record for an event.
+// PRE: eventID and memberID are non-empty.
+// POST: Returns the record, or sql.ErrNoRows if the member has not responded.
+// INVARIANT: Database connection is valid.
+func (s *SQLiteStore) GetInterest(ctx context.Context, eventID, memberID string) (domain.CompetitionInterest, error) {
+	return scanInterest(s.db.QueryRowContext(ctx,
+		`SELECT `+interestColumns+` FROM competition_interest WHERE event_id = ? AND member_id = ?`,
+		eventID, memberID,
+	).Scan)
+}
+
 // CountInterestsByEvent returns the number of interested members for an event.
 // PRE: eventID is non-empty.
 // POST: Returns count >= 0.
@@ -0,0 +1,122 @@
+package celebration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/celebration"
+)
+
+// profileColumns is the shared column list for profile SELECTs; order matches scanProfile.
+const profileColumns = "member_id, date_of_birth, joined_on, opt_out, updated_by, updated_at"
+
+// dateLayout is how dates of birth and join dates are stored.
+const dateLayout = "2006-01-02"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// GetProfile retrieves a member's celebration profile.
+// PRE: memberID is non-empty
+// POST: Returns the profile or an error if the member has none
+func (s *SQLiteStore) GetProfile(ctx context.Context, memberID string) (domain.Profile, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+profileColumns+" FROM celebration_profile WHERE member_id = ?", memberID)
+	p, err := scanProfile(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Profile{}, fmt.Errorf("celebration profile not found: %w", err)
+	}
+	return p, err
+}
+
+// SaveProfile persists a member's celebration profile, replacing any earlier one.
+// PRE: value has been validated
+// POST: The profile is persisted
+func (s *SQLiteStore) SaveProfile(ctx context.Context, value domain.Profile) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO celebration_profile (`+profileColumns+`) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(member_id) DO UPDATE SET date_of_birth = excluded.date_of_birth, joined_on = excluded.joined_on,
+			opt_out = excluded.opt_out, updated_by = excluded.updated_by, updated_at = excluded.updated_at`,
+		value.MemberID, formatDate(value.DateOfBirth), formatDate(value.JoinedOn), boolInt(value.OptOut),
+		value.UpdatedBy, value.UpdatedAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+// ListProfiles returns every member's celebration profile.
+// PRE: none
+// POST: Returns profiles or an empty slice
+func (s *SQLiteStore) ListProfiles(ctx context.Context) ([]domain.Profile, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+profileColumns+" FROM celebration_profile ORDER BY member_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Profile
+	for rows.Next() {
+		p, err := scanProfile(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, p)
+	}
+	return list, rows.Err()
+}
+
+// MarkEmailSent records that a member was emailed about one celebration.
+// PRE: memberID, kind and date (YYYY-MM-DD) are non-empty
+// POST: Returns true if this is the first email for the celebration, false if one was already recorded
+func (s *SQLiteStore) MarkEmailSent(ctx context.Context, memberID, kind, date string, sentAt time.Time) (bool, error) {
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO celebration_email_sent (member_id, kind, celebration_date, sent_at) VALUES (?, ?, ?, ?)
+		 ON CONFLICT(member_id, kind, celebration_date) DO NOTHING`,
+		memberID, kind, date, sentAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// scanProfile extracts a Profile from a row scanner function.
+func scanProfile(scan func(dest ...interface{}) error) (domain.Profile, error) {
+	var p domain.Profile
+	var dateOfBirth, joinedOn, updatedAt string
+	var optOut int
+	if err := scan(&p.MemberID, &dateOfBirth, &joinedOn, &optOut, &p.UpdatedBy, &updatedAt); err != nil {
+		return domain.Profile{}, err
+	}
+	p.DateOfBirth, _ = time.Parse(dateLayout, dateOfBirth)
+	p.JoinedOn, _ = time.Parse(dateLayout, joinedOn)
+	p.OptOut = optOut == 1
+	p.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	return p, nil
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Ensure interface compliance at compile time.
+var _ Store = (*SQLiteStore)(nil)
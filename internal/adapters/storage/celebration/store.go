@@ -0,0 +1,16 @@
+package celebration
+
+import (
+	"context"
+	"time"
+
+	domain "workshop/internal/domain/celebration"
+)
+
+// Store persists members' celebration profiles and the celebration emails already sent.
+type Store interface {
+	GetProfile(ctx context.Context, memberID string) (domain.Profile, error)
+	SaveProfile(ctx context.Context, value domain.Profile) error
+	ListProfiles(ctx context.Context) ([]domain.Profile, error)
+	MarkEmailSent(ctx context.Context, memberID, kind, date string, sentAt time.Time) (bool, error)
+}
@@ -0,0 +1,226 @@
+package classpack
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/classpack"
+)
+
+// packColumns is the shared column list for pack SELECTs; order matches scanPack.
+const packColumns = "id, member_id, classes, used, price_cents, purchased_at, expires_on, created_by, note"
+
+// useColumns is the shared column list for use SELECTs; order matches scanUse.
+const useColumns = "attendance_id, pack_id, member_id, used_at"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// GetByID retrieves a class pack by its ID.
+// PRE: id is non-empty
+// POST: Returns the pack or an error if not found
+func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.ClassPack, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+packColumns+" FROM class_pack WHERE id = ?", id)
+	p, err := scanPack(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.ClassPack{}, fmt.Errorf("class pack not found: %w", err)
+	}
+	return p, err
+}
+
+// Save persists a class pack, replacing it if it exists.
+// PRE: value has been validated
+// POST: The pack is persisted
+func (s *SQLiteStore) Save(ctx context.Context, value domain.ClassPack) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO class_pack (`+packColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET classes = excluded.classes, used = excluded.used,
+			price_cents = excluded.price_cents, expires_on = excluded.expires_on, note = excluded.note`,
+		value.ID, value.MemberID, value.Classes, value.Used, value.PriceCents,
+		value.PurchasedAt.UTC().Format(time.RFC3339), value.ExpiresOn, value.CreatedBy, value.Note)
+	return err
+}
+
+// List returns every class pack.
+// PRE: none
+// POST: Returns packs newest purchase first, or an empty slice
+func (s *SQLiteStore) List(ctx context.Context) ([]domain.ClassPack, error) {
+	return s.queryPacks(ctx, "SELECT "+packColumns+" FROM class_pack ORDER BY purchased_at DESC")
+}
+
+// ListByMemberID returns a member's class packs.
+// PRE: memberID is non-empty
+// POST: Returns packs newest purchase first, or an empty slice
+func (s *SQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]domain.ClassPack, error) {
+	return s.queryPacks(ctx, "SELECT "+packColumns+" FROM class_pack WHERE member_id = ? ORDER BY purchased_at DESC", memberID)
+}
+
+// SaveUse records that a check-in used a class from a pack.
+// PRE: value.AttendanceID and value.PackID are non-empty
+// POST: The use is persisted; a check-in uses at most one class
+func (s *SQLiteStore) SaveUse(ctx context.Context, value domain.Use) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO class_pack_use (`+useColumns+`) VALUES (?, ?, ?, ?)
+		ON CONFLICT(attendance_id) DO UPDATE SET pack_id = excluded.pack_id, member_id = excluded.member_id, used_at = excluded.used_at`,
+		value.AttendanceID, value.PackID, value.MemberID, value.UsedAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+// GetUse retrieves the class a check-in used.
+// PRE: attendanceID is non-empty
+// POST: Returns the use or an error if the check-in used no pack
+func (s *SQLiteStore) GetUse(ctx context.Context, attendanceID string) (domain.Use, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+useColumns+" FROM class_pack_use WHERE attendance_id = ?", attendanceID)
+	u, err := scanUse(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Use{}, fmt.Errorf("class pack use not found: %w", err)
+	}
+	return u, err
+}
+
+// DeleteUse removes the record of a check-in's class.
+// PRE: attendanceID is non-empty
+// POST: The use is removed if it existed
+func (s *SQLiteStore) DeleteUse(ctx context.Context, attendanceID string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM class_pack_use WHERE attendance_id = ?", attendanceID)
+	return err
+}
+
+// UseClass takes one class off a pack and records the check-in that used it, in one
+// transaction. The pack is only debited while it has a class left, so two check-ins at once
+// cannot both take the last one.
+// PRE: value.AttendanceID and value.PackID are non-empty
+// POST: The pack's used count is one higher and the use is saved; domain.ErrAlreadyUsed when
+// the check-in already used a class, domain.ErrPackUsedUp when none was left; nothing changes on error
+func (s *SQLiteStore) UseClass(ctx context.Context, value domain.Use) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO class_pack_use (`+useColumns+`) VALUES (?, ?, ?, ?) ON CONFLICT(attendance_id) DO NOTHING`,
+		value.AttendanceID, value.PackID, value.MemberID, value.UsedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrAlreadyUsed
+	}
+	res, err = tx.ExecContext(ctx, `UPDATE class_pack SET used = used + 1 WHERE id = ? AND used < classes`, value.PackID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrPackUsedUp
+	}
+	return tx.Commit()
+}
+
+// RefundClass gives back the class a check-in used and removes the use, in one transaction.
+// PRE: attendanceID is non-empty
+// POST: Returns the removed use and true, or false when the check-in used no pack
+func (s *SQLiteStore) RefundClass(ctx context.Context, attendanceID string) (domain.Use, bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.Use{}, false, err
+	}
+	defer tx.Rollback()
+
+	u, err := scanUse(tx.QueryRowContext(ctx, "SELECT "+useColumns+" FROM class_pack_use WHERE attendance_id = ?", attendanceID).Scan)
+	if err == sql.ErrNoRows {
+		return domain.Use{}, false, nil
+	}
+	if err != nil {
+		return domain.Use{}, false, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM class_pack_use WHERE attendance_id = ?", attendanceID); err != nil {
+		return domain.Use{}, false, err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE class_pack SET used = used - 1 WHERE id = ? AND used > 0`, u.PackID); err != nil {
+		return domain.Use{}, false, err
+	}
+	return u, true, tx.Commit()
+}
+
+// ListUses returns every class used from a pack.
+// PRE: none
+// POST: Returns uses oldest first, or an empty slice
+func (s *SQLiteStore) ListUses(ctx context.Context) ([]domain.Use, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+useColumns+" FROM class_pack_use ORDER BY used_at")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Use
+	for rows.Next() {
+		u, err := scanUse(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, u)
+	}
+	return list, rows.Err()
+}
+
+// queryPacks runs a pack SELECT and scans every row.
+func (s *SQLiteStore) queryPacks(ctx context.Context, query string, args ...interface{}) ([]domain.ClassPack, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.ClassPack
+	for rows.Next() {
+		p, err := scanPack(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, p)
+	}
+	return list, rows.Err()
+}
+
+// scanPack extracts a ClassPack from a row scanner function.
+func scanPack(scan func(dest ...interface{}) error) (domain.ClassPack, error) {
+	var p domain.ClassPack
+	var purchasedAt string
+	if err := scan(&p.ID, &p.MemberID, &p.Classes, &p.Used, &p.PriceCents, &purchasedAt, &p.ExpiresOn, &p.CreatedBy, &p.Note); err != nil {
+		return domain.ClassPack{}, err
+	}
+	p.PurchasedAt, _ = time.Parse(time.RFC3339, purchasedAt)
+	return p, nil
+}
+
+// scanUse extracts a Use from a row scanner function.
+func scanUse(scan func(dest ...interface{}) error) (domain.Use, error) {
+	var u domain.Use
+	var usedAt string
+	if err := scan(&u.AttendanceID, &u.PackID, &u.MemberID, &usedAt); err != nil {
+		return domain.Use{}, err
+	}
+	u.UsedAt, _ = time.Parse(time.RFC3339, usedAt)
+	return u, nil
+}
+
+// Ensure interface compliance at compile time.
+var _ Store = (*SQLiteStore)(nil)
@@ -0,0 +1,61 @@
+package classpack
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/classpack"
+
+	_ "modernc.org/sqlite"
+)
+
+// TestUseClass_GuardsLastClass verifies the pack is only debited while it has a class left,
+// a check-in uses at most one class, and a failed use leaves nothing behind.
+func TestUseClass_GuardsLastClass(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test db: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	if err := storage.MigrateDB(db, ":memory:"); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	store := NewSQLiteStore(db)
+	ctx := context.Background()
+	at := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	if err := store.Save(ctx, domain.ClassPack{ID: "p1", MemberID: "m1", Classes: 1, PurchasedAt: at}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	if err := store.UseClass(ctx, domain.Use{AttendanceID: "a1", PackID: "p1", MemberID: "m1", UsedAt: at}); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if err := store.UseClass(ctx, domain.Use{AttendanceID: "a1", PackID: "p1", MemberID: "m1", UsedAt: at}); !errors.Is(err, domain.ErrAlreadyUsed) {
+		t.Errorf("same check-in again: err = %v, want ErrAlreadyUsed", err)
+	}
+	if err := store.UseClass(ctx, domain.Use{AttendanceID: "a2", PackID: "p1", MemberID: "m1", UsedAt: at}); !errors.Is(err, domain.ErrPackUsedUp) {
+		t.Errorf("past the last class: err = %v, want ErrPackUsedUp", err)
+	}
+	if _, err := store.GetUse(ctx, "a2"); err == nil {
+		t.Error("a refused use was saved")
+	}
+	if p, _ := store.GetByID(ctx, "p1"); p.Used != 1 {
+		t.Errorf("used = %d, want 1", p.Used)
+	}
+
+	use, ok, err := store.RefundClass(ctx, "a1")
+	if err != nil || !ok || use.PackID != "p1" {
+		t.Fatalf("RefundClass() = %+v %v %v", use, ok, err)
+	}
+	if p, _ := store.GetByID(ctx, "p1"); p.Used != 0 {
+		t.Errorf("after refund used = %d, want 0", p.Used)
+	}
+	if _, ok, err := store.RefundClass(ctx, "a1"); ok || err != nil {
+		t.Errorf("refunding twice: ok = %v err = %v", ok, err)
+	}
+}
@@ -0,0 +1,21 @@
+package classpack
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/classpack"
+)
+
+// Store persists class packs and the check-ins that used them.
+type Store interface {
+	GetByID(ctx context.Context, id string) (domain.ClassPack, error)
+	Save(ctx context.Context, value domain.ClassPack) error
+	List(ctx context.Context) ([]domain.ClassPack, error)
+	ListByMemberID(ctx context.Context, memberID string) ([]domain.ClassPack, error)
+	SaveUse(ctx context.Context, value domain.Use) error
+	GetUse(ctx context.Context, attendanceID string) (domain.Use, error)
+	DeleteUse(ctx context.Context, attendanceID string) error
+	UseClass(ctx context.Context, value domain.Use) error
+	RefundClass(ctx context.Context, attendanceID string) (domain.Use, bool, error)
+	ListUses(ctx context.Context) ([]domain.Use, error)
+}
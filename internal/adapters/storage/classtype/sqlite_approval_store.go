@@ -0,0 +1,83 @@
+package classtype
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/classtype"
+)
+
+// ApprovalSQLiteStore implements ApprovalStore using SQLite.
+type ApprovalSQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewApprovalSQLiteStore creates a new ApprovalSQLiteStore.
+func NewApprovalSQLiteStore(db storage.SQLDB) *ApprovalSQLiteStore {
+	return &ApprovalSQLiteStore{db: db}
+}
+
+// Save records an approval, replacing an earlier one for the same member and class type.
+// PRE: value has been validated
+// POST: The approval is persisted
+func (s *ApprovalSQLiteStore) Save(ctx context.Context, value domain.Approval) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO class_type_approval (class_type_id, member_id, approved_by, approved_at) VALUES (?, ?, ?, ?)
+		 ON CONFLICT(class_type_id, member_id) DO UPDATE SET approved_by=excluded.approved_by, approved_at=excluded.approved_at`,
+		value.ClassTypeID, value.MemberID, value.ApprovedBy, value.ApprovedAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+// Delete withdraws a member's approval for a class type.
+// PRE: classTypeID and memberID are non-empty
+// POST: No approval remains for the pair; withdrawing a missing approval is not an error
+func (s *ApprovalSQLiteStore) Delete(ctx context.Context, classTypeID, memberID string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM class_type_approval WHERE class_type_id = ? AND member_id = ?", classTypeID, memberID)
+	return err
+}
+
+// IsApproved reports whether a member has been approved for a class type.
+// PRE: classTypeID and memberID are non-empty
+// POST: Returns true when an approval exists
+func (s *ApprovalSQLiteStore) IsApproved(ctx context.Context, classTypeID, memberID string) (bool, error) {
+	var n int
+	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM class_type_approval WHERE class_type_id = ? AND member_id = ?", classTypeID, memberID).Scan(&n)
+	return n > 0, err
+}
+
+// ListByClassTypeID returns the members approved for a class type, most recent first.
+// PRE: classTypeID is non-empty
+// POST: Returns the approvals for the class type
+func (s *ApprovalSQLiteStore) ListByClassTypeID(ctx context.Context, classTypeID string) ([]domain.Approval, error) {
+	return s.list(ctx, "WHERE class_type_id = ?", classTypeID)
+}
+
+// ListByMemberID returns the class types a member has been approved for.
+// PRE: memberID is non-empty
+// POST: Returns the approvals for the member
+func (s *ApprovalSQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]domain.Approval, error) {
+	return s.list(ctx, "WHERE member_id = ?", memberID)
+}
+
+// list runs a filtered approval query, most recent first.
+func (s *ApprovalSQLiteStore) list(ctx context.Context, where string, arg string) ([]domain.Approval, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT class_type_id, member_id, approved_by, approved_at FROM class_type_approval "+where+" ORDER BY approved_at DESC", arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Approval
+	for rows.Next() {
+		var a domain.Approval
+		var approvedAt string
+		if err := rows.Scan(&a.ClassTypeID, &a.MemberID, &a.ApprovedBy, &approvedAt); err != nil {
+			return nil, err
+		}
+		a.ApprovedAt, _ = time.Parse(time.RFC3339, approvedAt)
+		list = append(list, a)
+	}
+	return list, rows.Err()
+}
@@ -19,13 +19,16 @@ func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
 	return &SQLiteStore{db: db}
 }
 
+// classTypeColumns is the shared column list for class_type queries; order matches the Scan calls.
+const classTypeColumns = "id, program_id, name, description, attire, level, location_id, mat_capacity, min_belt, requires_approval"
+
 // GetByID retrieves a ClassType by its ID.
 // PRE: id is non-empty
 // POST: Returns the entity or an error if not found
 func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.ClassType, error) {
-	row := s.db.QueryRowContext(ctx, "SELECT id, program_id, name, description, attire, level FROM class_type WHERE id = ?", id)
+	row := s.db.QueryRowContext(ctx, "SELECT "+classTypeColumns+" FROM class_type WHERE id = ?", id)
 	var entity domain.ClassType
-	err := row.Scan(&entity.ID, &entity.ProgramID, &entity.Name, &entity.Description, &entity.Attire, &entity.Level)
+	err := row.Scan(&entity.ID, &entity.ProgramID, &entity.Name, &entity.Description, &entity.Attire, &entity.Level, &entity.LocationID, &entity.MatCapacity, &entity.MinBelt, &entity.RequiresApproval)
 	if err == sql.ErrNoRows {
 		return domain.ClassType{}, fmt.Errorf("class type not found: %w", err)
 	}
@@ -37,8 +40,8 @@ func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.ClassType,
 // POST: Entity is persisted (insert or update)
 func (s *SQLiteStore) Save(ctx context.Context, entity domain.ClassType) error {
 	_, err := s.db.ExecContext(ctx,
-		"INSERT INTO class_type (id, program_id, name, description, attire, level) VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT(id) DO UPDATE SET program_id=excluded.program_id, name=excluded.name, description=excluded.description, attire=excluded.attire, level=excluded.level",
-		entity.ID, entity.ProgramID, entity.Name, entity.Description, entity.Attire, entity.Level,
+		"INSERT INTO class_type ("+classTypeColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT(id) DO UPDATE SET program_id=excluded.program_id, name=excluded.name, description=excluded.description, attire=excluded.attire, level=excluded.level, location_id=excluded.location_id, mat_capacity=excluded.mat_capacity, min_belt=excluded.min_belt, requires_approval=excluded.requires_approval",
+		entity.ID, entity.ProgramID, entity.Name, entity.Description, entity.Attire, entity.Level, entity.LocationID, entity.MatCapacity, entity.MinBelt, entity.RequiresApproval,
 	)
 	return err
 }
@@ -55,7 +58,7 @@ func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
 // PRE: filter has valid parameters
 // POST: Returns matching entities
 func (s *SQLiteStore) List(ctx context.Context) ([]domain.ClassType, error) {
-	rows, err := s.db.QueryContext(ctx, "SELECT id, program_id, name, description, attire, level FROM class_type ORDER BY name")
+	rows, err := s.db.QueryContext(ctx, "SELECT "+classTypeColumns+" FROM class_type ORDER BY name")
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +67,7 @@ func (s *SQLiteStore) List(ctx context.Context) ([]domain.ClassType, error) {
 	var results []domain.ClassType
 	for rows.Next() {
 		var entity domain.ClassType
-		if err := rows.Scan(&entity.ID, &entity.ProgramID, &entity.Name, &entity.Description, &entity.Attire, &entity.Level); err != nil {
+		if err := rows.Scan(&entity.ID, &entity.ProgramID, &entity.Name, &entity.Description, &entity.Attire, &entity.Level, &entity.LocationID, &entity.MatCapacity, &entity.MinBelt, &entity.RequiresApproval); err != nil {
 			return nil, err
 		}
 		results = append(results, entity)
@@ -76,7 +79,7 @@ func (s *SQLiteStore) List(ctx context.Context) ([]domain.ClassType, error) {
 // PRE: programID is non-empty
 // POST: Returns class types for the given program
 func (s *SQLiteStore) ListByProgramID(ctx context.Context, programID string) ([]domain.ClassType, error) {
-	rows, err := s.db.QueryContext(ctx, "SELECT id, program_id, name, description, attire, level FROM class_type WHERE program_id = ? ORDER BY name", programID)
+	rows, err := s.db.QueryContext(ctx, "SELECT "+classTypeColumns+" FROM class_type WHERE program_id = ? ORDER BY name", programID)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +88,7 @@ func (s *SQLiteStore) ListByProgramID(ctx context.Context, programID string) ([]
 	var results []domain.ClassType
 	for rows.Next() {
 		var entity domain.ClassType
-		if err := rows.Scan(&entity.ID, &entity.ProgramID, &entity.Name, &entity.Description, &entity.Attire, &entity.Level); err != nil {
+		if err := rows.Scan(&entity.ID, &entity.ProgramID, &entity.Name, &entity.Description, &entity.Attire, &entity.Level, &entity.LocationID, &entity.MatCapacity, &entity.MinBelt, &entity.RequiresApproval); err != nil {
 			return nil, err
 		}
 		results = append(results, entity)
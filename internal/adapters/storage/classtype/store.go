@@ -14,3 +14,13 @@ type Store interface {
 	List(ctx context.Context) ([]domain.ClassType, error)
 	ListByProgramID(ctx context.Context, programID string) ([]domain.ClassType, error)
 }
+
+// ApprovalStore persists coaches' standing approvals for members to attend class types
+// whose prerequisites they do not otherwise meet.
+type ApprovalStore interface {
+	Save(ctx context.Context, value domain.Approval) error
+	Delete(ctx context.Context, classTypeID, memberID string) error
+	IsApproved(ctx context.Context, classTypeID, memberID string) (bool, error)
+	ListByClassTypeID(ctx context.Context, classTypeID string) ([]domain.Approval, error)
+	ListByMemberID(ctx context.Context, memberID string) ([]domain.Approval, error)
+}
@@ -28,6 +28,7 @@ func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
 		created_by TEXT NOT NULL DEFAULT '',
 		promoted INTEGER NOT NULL DEFAULT 0,
 		promoted_by TEXT NOT NULL DEFAULT '',
+		min_belt TEXT NOT NULL DEFAULT '',
 		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		FOREIGN KEY (theme_id) REFERENCES themes(id)
 	)`)
@@ -41,8 +42,8 @@ func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Clip, erro
 	var c domain.Clip
 	var promoted int
 	err := s.db.QueryRowContext(ctx,
-		`SELECT id, theme_id, title, youtube_url, youtube_id, start_seconds, end_seconds, notes, created_by, promoted, promoted_by, created_at FROM clips WHERE id = ?`, id,
-	).Scan(&c.ID, &c.ThemeID, &c.Title, &c.YouTubeURL, &c.YouTubeID, &c.StartSeconds, &c.EndSeconds, &c.Notes, &c.CreatedBy, &promoted, &c.PromotedBy, &c.CreatedAt)
+		`SELECT id, theme_id, title, youtube_url, youtube_id, start_seconds, end_seconds, notes, created_by, promoted, promoted_by, min_belt, created_at FROM clips WHERE id = ?`, id,
+	).Scan(&c.ID, &c.ThemeID, &c.Title, &c.YouTubeURL, &c.YouTubeID, &c.StartSeconds, &c.EndSeconds, &c.Notes, &c.CreatedBy, &promoted, &c.PromotedBy, &c.MinBelt, &c.CreatedAt)
 	c.Promoted = promoted == 1
 	return c, err
 }
@@ -56,12 +57,12 @@ func (s *SQLiteStore) Save(ctx context.Context, value domain.Clip) error {
 		promoted = 1
 	}
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO clips (id, theme_id, title, youtube_url, youtube_id, start_seconds, end_seconds, notes, created_by, promoted, promoted_by, created_at)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`INSERT INTO clips (id, theme_id, title, youtube_url, youtube_id, start_seconds, end_seconds, notes, created_by, promoted, promoted_by, min_belt, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		 ON CONFLICT(id) DO UPDATE SET theme_id=excluded.theme_id, title=excluded.title, youtube_url=excluded.youtube_url,
 		 youtube_id=excluded.youtube_id, start_seconds=excluded.start_seconds, end_seconds=excluded.end_seconds,
-		 notes=excluded.notes, promoted=excluded.promoted, promoted_by=excluded.promoted_by`,
-		value.ID, value.ThemeID, value.Title, value.YouTubeURL, value.YouTubeID, value.StartSeconds, value.EndSeconds, value.Notes, value.CreatedBy, promoted, value.PromotedBy, value.CreatedAt,
+		 notes=excluded.notes, promoted=excluded.promoted, promoted_by=excluded.promoted_by, min_belt=excluded.min_belt`,
+		value.ID, value.ThemeID, value.Title, value.YouTubeURL, value.YouTubeID, value.StartSeconds, value.EndSeconds, value.Notes, value.CreatedBy, promoted, value.PromotedBy, value.MinBelt, value.CreatedAt,
 	)
 	return err
 }
@@ -79,7 +80,7 @@ func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
 // POST: returns matching clips or empty slice
 func (s *SQLiteStore) ListByThemeID(ctx context.Context, themeID string) ([]domain.Clip, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, theme_id, title, youtube_url, youtube_id, start_seconds, end_seconds, notes, created_by, promoted, promoted_by, created_at FROM clips WHERE theme_id = ? ORDER BY created_at DESC`, themeID)
+		`SELECT id, theme_id, title, youtube_url, youtube_id, start_seconds, end_seconds, notes, created_by, promoted, promoted_by, min_belt, created_at FROM clips WHERE theme_id = ? ORDER BY created_at DESC`, themeID)
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +89,7 @@ func (s *SQLiteStore) ListByThemeID(ctx context.Context, themeID string) ([]doma
 	for rows.Next() {
 		var c domain.Clip
 		var promoted int
-		if err := rows.Scan(&c.ID, &c.ThemeID, &c.Title, &c.YouTubeURL, &c.YouTubeID, &c.StartSeconds, &c.EndSeconds, &c.Notes, &c.CreatedBy, &promoted, &c.PromotedBy, &c.CreatedAt); err != nil {
+		if err := rows.Scan(&c.ID, &c.ThemeID, &c.Title, &c.YouTubeURL, &c.YouTubeID, &c.StartSeconds, &c.EndSeconds, &c.Notes, &c.CreatedBy, &promoted, &c.PromotedBy, &c.MinBelt, &c.CreatedAt); err != nil {
 			return nil, err
 		}
 		c.Promoted = promoted == 1
@@ -102,7 +103,7 @@ func (s *SQLiteStore) ListByThemeID(ctx context.Context, themeID string) ([]doma
 // POST: returns promoted clips or empty slice
 func (s *SQLiteStore) ListPromoted(ctx context.Context) ([]domain.Clip, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, theme_id, title, youtube_url, youtube_id, start_seconds, end_seconds, notes, created_by, promoted, promoted_by, created_at FROM clips WHERE promoted = 1 ORDER BY created_at DESC`)
+		`SELECT id, theme_id, title, youtube_url, youtube_id, start_seconds, end_seconds, notes, created_by, promoted, promoted_by, min_belt, created_at FROM clips WHERE promoted = 1 ORDER BY created_at DESC`)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +112,7 @@ func (s *SQLiteStore) ListPromoted(ctx context.Context) ([]domain.Clip, error) {
 	for rows.Next() {
 		var c domain.Clip
 		var promoted int
-		if err := rows.Scan(&c.ID, &c.ThemeID, &c.Title, &c.YouTubeURL, &c.YouTubeID, &c.StartSeconds, &c.EndSeconds, &c.Notes, &c.CreatedBy, &promoted, &c.PromotedBy, &c.CreatedAt); err != nil {
+		if err := rows.Scan(&c.ID, &c.ThemeID, &c.Title, &c.YouTubeURL, &c.YouTubeID, &c.StartSeconds, &c.EndSeconds, &c.Notes, &c.CreatedBy, &promoted, &c.PromotedBy, &c.MinBelt, &c.CreatedAt); err != nil {
 			return nil, err
 		}
 		c.Promoted = promoted == 1
@@ -124,7 +125,7 @@ func (s *SQLiteStore) ListPromoted(ctx context.Context) ([]domain.Clip, error) {
 // PRE: none
 // POST: returns matching clips ordered by creation time (most recent first)
 func (s *SQLiteStore) Search(ctx context.Context, query string, themeID string, promotedOnly bool) ([]domain.Clip, error) {
-	sql := `SELECT id, theme_id, title, youtube_url, youtube_id, start_seconds, end_seconds, notes, created_by, promoted, promoted_by, created_at FROM clips WHERE 1=1`
+	sql := `SELECT id, theme_id, title, youtube_url, youtube_id, start_seconds, end_seconds, notes, created_by, promoted, promoted_by, min_belt, created_at FROM clips WHERE 1=1`
 	var args []interface{}
 	if query != "" {
 		sql += ` AND (title LIKE ? OR notes LIKE ?)`
@@ -148,7 +149,7 @@ func (s *SQLiteStore) Search(ctx context.Context, query string, themeID string,
 	for rows.Next() {
 		var c domain.Clip
 		var promoted int
-		if err := rows.Scan(&c.ID, &c.ThemeID, &c.Title, &c.YouTubeURL, &c.YouTubeID, &c.StartSeconds, &c.EndSeconds, &c.Notes, &c.CreatedBy, &promoted, &c.PromotedBy, &c.CreatedAt); err != nil {
+		if err := rows.Scan(&c.ID, &c.ThemeID, &c.Title, &c.YouTubeURL, &c.YouTubeID, &c.StartSeconds, &c.EndSeconds, &c.Notes, &c.CreatedBy, &promoted, &c.PromotedBy, &c.MinBelt, &c.CreatedAt); err != nil {
 			return nil, err
 		}
 		c.Promoted = promoted == 1
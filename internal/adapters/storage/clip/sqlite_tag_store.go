@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"workshop/internal/adapters/storage"
 	domain "workshop/internal/domain/clip"
@@ -19,6 +20,7 @@ func NewSQLiteTagStore(db storage.SQLDB) *SQLiteTagStore {
 	db.ExecContext(context.Background(), `CREATE TABLE IF NOT EXISTS clip_tags (
 		id TEXT PRIMARY KEY,
 		name TEXT NOT NULL UNIQUE,
+		category TEXT NOT NULL DEFAULT 'general',
 		created_by TEXT NOT NULL DEFAULT '',
 		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 	)`)
@@ -33,6 +35,7 @@ func NewSQLiteTagStore(db storage.SQLDB) *SQLiteTagStore {
 	)`)
 	db.ExecContext(context.Background(), `CREATE INDEX IF NOT EXISTS idx_clip_tag_clip ON clip_tag_associations(clip_id)`)
 	db.ExecContext(context.Background(), `CREATE INDEX IF NOT EXISTS idx_clip_tag_tag ON clip_tag_associations(tag_id)`)
+	db.ExecContext(context.Background(), `CREATE INDEX IF NOT EXISTS idx_clip_tags_category ON clip_tags(category)`)
 	return &SQLiteTagStore{db: db}
 }
 
@@ -41,9 +44,9 @@ func NewSQLiteTagStore(db storage.SQLDB) *SQLiteTagStore {
 // POST: tag persisted to database; error if database operation fails
 func (s *SQLiteTagStore) SaveTag(ctx context.Context, tag domain.Tag) error {
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO clip_tags (id, name, created_by, created_at) VALUES (?, ?, ?, ?)
-		 ON CONFLICT(id) DO UPDATE SET name=excluded.name`,
-		tag.ID, tag.Name, tag.CreatedBy, tag.CreatedAt,
+		`INSERT INTO clip_tags (id, name, category, created_by, created_at) VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET name=excluded.name, category=excluded.category`,
+		tag.ID, tag.Name, tag.Category, tag.CreatedBy, tag.CreatedAt,
 	)
 	return err
 }
@@ -54,8 +57,8 @@ func (s *SQLiteTagStore) SaveTag(ctx context.Context, tag domain.Tag) error {
 func (s *SQLiteTagStore) GetTagByID(ctx context.Context, id string) (domain.Tag, error) {
 	var t domain.Tag
 	err := s.db.QueryRowContext(ctx,
-		`SELECT id, name, created_by, created_at FROM clip_tags WHERE id = ?`, id,
-	).Scan(&t.ID, &t.Name, &t.CreatedBy, &t.CreatedAt)
+		`SELECT id, name, category, created_by, created_at FROM clip_tags WHERE id = ?`, id,
+	).Scan(&t.ID, &t.Name, &t.Category, &t.CreatedBy, &t.CreatedAt)
 	if errors.Is(err, sql.ErrNoRows) {
 		return domain.Tag{}, errors.New("tag not found")
 	}
@@ -68,20 +71,20 @@ func (s *SQLiteTagStore) GetTagByID(ctx context.Context, id string) (domain.Tag,
 func (s *SQLiteTagStore) GetTagByName(ctx context.Context, name string) (domain.Tag, error) {
 	var t domain.Tag
 	err := s.db.QueryRowContext(ctx,
-		`SELECT id, name, created_by, created_at FROM clip_tags WHERE LOWER(name) = LOWER(?)`, name,
-	).Scan(&t.ID, &t.Name, &t.CreatedBy, &t.CreatedAt)
+		`SELECT id, name, category, created_by, created_at FROM clip_tags WHERE LOWER(name) = LOWER(?)`, name,
+	).Scan(&t.ID, &t.Name, &t.Category, &t.CreatedBy, &t.CreatedAt)
 	if errors.Is(err, sql.ErrNoRows) {
 		return domain.Tag{}, errors.New("tag not found")
 	}
 	return t, err
 }
 
-// ListTags returns all tags ordered by name.
+// ListTags returns all tags ordered by category, then name.
 // PRE: none
 // POST: returns all tags; error if database fails
 func (s *SQLiteTagStore) ListTags(ctx context.Context) ([]domain.Tag, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, name, created_by, created_at FROM clip_tags ORDER BY name ASC`)
+		`SELECT id, name, category, created_by, created_at FROM clip_tags ORDER BY category ASC, name ASC`)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +92,7 @@ func (s *SQLiteTagStore) ListTags(ctx context.Context) ([]domain.Tag, error) {
 	var list []domain.Tag
 	for rows.Next() {
 		var t domain.Tag
-		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedBy, &t.CreatedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.Name, &t.Category, &t.CreatedBy, &t.CreatedAt); err != nil {
 			return nil, err
 		}
 		list = append(list, t)
@@ -133,7 +136,7 @@ func (s *SQLiteTagStore) RemoveTagFromClip(ctx context.Context, clipID, tagID st
 // POST: returns tags for clip; error if database fails
 func (s *SQLiteTagStore) GetTagsForClip(ctx context.Context, clipID string) ([]domain.Tag, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT t.id, t.name, t.created_by, t.created_at 
+		`SELECT t.id, t.name, t.category, t.created_by, t.created_at 
 		 FROM clip_tags t
 		 JOIN clip_tag_associations a ON t.id = a.tag_id
 		 WHERE a.clip_id = ?
@@ -145,7 +148,7 @@ func (s *SQLiteTagStore) GetTagsForClip(ctx context.Context, clipID string) ([]d
 	var list []domain.Tag
 	for rows.Next() {
 		var t domain.Tag
-		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedBy, &t.CreatedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.Name, &t.Category, &t.CreatedBy, &t.CreatedAt); err != nil {
 			return nil, err
 		}
 		list = append(list, t)
@@ -181,26 +184,37 @@ func (s *SQLiteTagStore) SearchClipsByTags(ctx context.Context, tagIDs []string)
 	if len(tagIDs) == 0 {
 		return nil, nil
 	}
-	// Build query for AND search: clip must have all specified tags
-	query := `SELECT c.id, c.theme_id, c.title, c.youtube_url, c.youtube_id, c.start_seconds, c.end_seconds, c.notes, c.created_by, c.promoted, c.promoted_by, c.created_at
-			  FROM clips c
-			  JOIN clip_tag_associations a ON c.id = a.clip_id
-			  WHERE a.tag_id IN (`
-	params := make([]interface{}, len(tagIDs))
-	for i, id := range tagIDs {
-		if i > 0 {
-			query += ","
+	return s.SearchClips(ctx, tagIDs, "", false)
+}
+
+// SearchClips returns clips that have ALL of the specified tags and whose title or notes match query.
+// Either filter may be empty; with both empty every clip matches.
+// PRE: none
+// POST: returns matching clips, newest first; error if database fails
+func (s *SQLiteTagStore) SearchClips(ctx context.Context, tagIDs []string, query string, promotedOnly bool) ([]domain.Clip, error) {
+	sql := `SELECT c.id, c.theme_id, c.title, c.youtube_url, c.youtube_id, c.start_seconds, c.end_seconds, c.notes, c.created_by, c.promoted, c.promoted_by, c.min_belt, c.created_at
+			FROM clips c WHERE 1=1`
+	var args []interface{}
+	if len(tagIDs) > 0 {
+		// AND search: the clip must carry every requested tag
+		sql += ` AND c.id IN (SELECT clip_id FROM clip_tag_associations WHERE tag_id IN (?` + strings.Repeat(",?", len(tagIDs)-1) + `)
+				GROUP BY clip_id HAVING COUNT(DISTINCT tag_id) = ?)`
+		for _, id := range tagIDs {
+			args = append(args, id)
 		}
-		query += "?"
-		params[i] = id
+		args = append(args, len(tagIDs))
+	}
+	if query != "" {
+		sql += ` AND (c.title LIKE ? OR c.notes LIKE ?)`
+		pattern := "%" + query + "%"
+		args = append(args, pattern, pattern)
+	}
+	if promotedOnly {
+		sql += ` AND c.promoted = 1`
 	}
-	query += `)
-			  GROUP BY c.id
-			  HAVING COUNT(DISTINCT a.tag_id) = ?
-			  ORDER BY c.created_at DESC`
-	params = append(params, len(tagIDs))
+	sql += ` ORDER BY c.created_at DESC`
 
-	rows, err := s.db.QueryContext(ctx, query, params...)
+	rows, err := s.db.QueryContext(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +223,7 @@ func (s *SQLiteTagStore) SearchClipsByTags(ctx context.Context, tagIDs []string)
 	for rows.Next() {
 		var c domain.Clip
 		var promoted int
-		if err := rows.Scan(&c.ID, &c.ThemeID, &c.Title, &c.YouTubeURL, &c.YouTubeID, &c.StartSeconds, &c.EndSeconds, &c.Notes, &c.CreatedBy, &promoted, &c.PromotedBy, &c.CreatedAt); err != nil {
+		if err := rows.Scan(&c.ID, &c.ThemeID, &c.Title, &c.YouTubeURL, &c.YouTubeID, &c.StartSeconds, &c.EndSeconds, &c.Notes, &c.CreatedBy, &promoted, &c.PromotedBy, &c.MinBelt, &c.CreatedAt); err != nil {
 			return nil, err
 		}
 		c.Promoted = promoted == 1
@@ -217,3 +231,25 @@ func (s *SQLiteTagStore) SearchClipsByTags(ctx context.Context, tagIDs []string)
 	}
 	return list, rows.Err()
 }
+
+// CountClipsByTag returns the number of clips carrying each tag, keyed by tag ID.
+// PRE: none
+// POST: tags with no clips are absent from the map; error if database fails
+func (s *SQLiteTagStore) CountClipsByTag(ctx context.Context) (map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT tag_id, COUNT(*) FROM clip_tag_associations GROUP BY tag_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	counts := make(map[string]int)
+	for rows.Next() {
+		var tagID string
+		var n int
+		if err := rows.Scan(&tagID, &n); err != nil {
+			return nil, err
+		}
+		counts[tagID] = n
+	}
+	return counts, rows.Err()
+}
@@ -21,4 +21,6 @@ type TagStore interface {
 	GetTagsForClip(ctx context.Context, clipID string) ([]domain.Tag, error)
 	GetClipsForTag(ctx context.Context, tagID string) ([]string, error)
 	SearchClipsByTags(ctx context.Context, tagIDs []string) ([]domain.Clip, error)
+	SearchClips(ctx context.Context, tagIDs []string, query string, promotedOnly bool) ([]domain.Clip, error)
+	CountClipsByTag(ctx context.Context) (map[string]int, error)
 }
@@ -0,0 +1,95 @@
+package contactcheck
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/contactcheck"
+)
+
+// checkColumns is the shared column list for check SELECTs; order matches scanCheck.
+const checkColumns = "member_id, address, requested_by, requested_at, resolution, new_address, resolved_at"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// Get retrieves a member's contact check.
+// PRE: memberID is non-empty
+// POST: Returns the check or an error if the member has none
+func (s *SQLiteStore) Get(ctx context.Context, memberID string) (domain.Check, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+checkColumns+" FROM member_contact_check WHERE member_id = ?", memberID)
+	c, err := scanCheck(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Check{}, fmt.Errorf("contact check not found: %w", err)
+	}
+	return c, err
+}
+
+// Save persists a member's contact check, replacing any earlier one.
+// PRE: value has been validated
+// POST: The check is persisted
+func (s *SQLiteStore) Save(ctx context.Context, value domain.Check) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO member_contact_check (`+checkColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(member_id) DO UPDATE SET address = excluded.address, requested_by = excluded.requested_by,
+			requested_at = excluded.requested_at, resolution = excluded.resolution,
+			new_address = excluded.new_address, resolved_at = excluded.resolved_at`,
+		value.MemberID, value.Address, value.RequestedBy, formatTime(value.RequestedAt),
+		value.Resolution, value.NewAddress, formatTime(value.ResolvedAt))
+	return err
+}
+
+// List returns every contact check, open or answered.
+// PRE: none
+// POST: Returns checks newest request first, or an empty slice
+func (s *SQLiteStore) List(ctx context.Context) ([]domain.Check, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+checkColumns+" FROM member_contact_check ORDER BY requested_at DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Check
+	for rows.Next() {
+		c, err := scanCheck(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+	return list, rows.Err()
+}
+
+// scanCheck extracts a Check from a row scanner function.
+func scanCheck(scan func(dest ...interface{}) error) (domain.Check, error) {
+	var c domain.Check
+	var requestedAt, resolvedAt string
+	if err := scan(&c.MemberID, &c.Address, &c.RequestedBy, &requestedAt, &c.Resolution, &c.NewAddress, &resolvedAt); err != nil {
+		return domain.Check{}, err
+	}
+	c.RequestedAt, _ = time.Parse(time.RFC3339, requestedAt)
+	c.ResolvedAt, _ = time.Parse(time.RFC3339, resolvedAt)
+	return c, nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Ensure interface compliance at compile time.
+var _ Store = (*SQLiteStore)(nil)
@@ -0,0 +1,14 @@
+package contactcheck
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/contactcheck"
+)
+
+// Store persists the contact checks members answer at the kiosk.
+type Store interface {
+	Get(ctx context.Context, memberID string) (domain.Check, error)
+	Save(ctx context.Context, value domain.Check) error
+	List(ctx context.Context) ([]domain.Check, error)
+}
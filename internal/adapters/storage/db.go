@@ -41,6 +41,68 @@ var migrations = []migration{
 	{version: 21, description: "outbox for external integrations", apply: migrate21},
 	{version: 23, description: "log truncation settings", apply: migrate23},
 	{version: 24, description: "privacy deletion and export requests", apply: migrate24},
+	{version: 25, description: "multi-location support", apply: migrate25},
+	{version: 26, description: "injury status lifecycle", apply: migrate26},
+	{version: 27, description: "notification center", apply: migrate27},
+	{version: 28, description: "coach session logs", apply: migrate28},
+	{version: 29, description: "email delivery tracking and suppression", apply: migrate29},
+	{version: 30, description: "competition registration status and weight class", apply: migrate30},
+	{version: 31, description: "full-text search index", apply: migrate31},
+	{version: 32, description: "permission matrix overrides", apply: migrate32},
+	{version: 33, description: "message threads and member replies", apply: migrate33},
+	{version: 34, description: "rotor auto-advance mode and bumped schedules", apply: migrate34},
+	{version: 35, description: "persistent login sessions", apply: migrate35},
+	{version: 36, description: "grading proposal scheduling and comments", apply: migrate36},
+	{version: 37, description: "makeup credits", apply: migrate37},
+	{version: 38, description: "daily kpi snapshots", apply: migrate38},
+	{version: 39, description: "rubric templates and scores", apply: migrate39},
+	{version: 40, description: "class occurrence changes", apply: migrate40},
+	{version: 41, description: "personal goal check-ins and annotations", apply: migrate41},
+	{version: 42, description: "email categories and communication preferences", apply: migrate42},
+	{version: 43, description: "feature flag targeting", apply: migrate43},
+	{version: 44, description: "belt inventory", apply: migrate44},
+	{version: 45, description: "shared topic library", apply: migrate45},
+	{version: 46, description: "visitors", apply: migrate46},
+	{version: 47, description: "grading eligibility rules", apply: migrate47},
+	{version: 48, description: "email template library", apply: migrate48},
+	{version: 49, description: "inactive member re-engagement", apply: migrate49},
+	{version: 50, description: "belt-gated rotor topics", apply: migrate50},
+	{version: 51, description: "coach timesheets", apply: migrate51},
+	{version: 52, description: "bugbox triage", apply: migrate52},
+	{version: 53, description: "schedule mat space", apply: migrate53},
+	{version: 54, description: "term rollover", apply: migrate54},
+	{version: 55, description: "note visibility", apply: migrate55},
+	{version: 56, description: "web push", apply: migrate56},
+	{version: 57, description: "theme archive on rotors", apply: migrate57},
+	{version: 58, description: "attendance topics", apply: migrate58},
+	{version: 59, description: "kiosk devices", apply: migrate59},
+	{version: 60, description: "message attachments", apply: migrate60},
+	{version: 61, description: "estimated hours review history", apply: migrate61},
+	{version: 62, description: "account suspension and scheduled status changes", apply: migrate62},
+	{version: 63, description: "coach availability", apply: migrate63},
+	{version: 64, description: "member tags and smart segments", apply: migrate64},
+	{version: 65, description: "grading record corrections", apply: migrate65},
+	{version: 66, description: "mat capacity and overcrowding alerts", apply: migrate66},
+	{version: 67, description: "account and session locale", apply: migrate67},
+	{version: 68, description: "weekly digest emails", apply: migrate68},
+	{version: 69, description: "grading ceremonies", apply: migrate69},
+	{version: 70, description: "perf timing buckets", apply: migrate70},
+	{version: 71, description: "referrals", apply: migrate71},
+	{version: 72, description: "class type prerequisites", apply: migrate72},
+	{version: 73, description: "job schedules", apply: migrate73},
+	{version: 74, description: "guardian-signed waivers", apply: migrate74},
+	{version: 75, description: "schedule exceptions", apply: migrate75},
+	{version: 76, description: "term report deliveries", apply: migrate76},
+	{version: 77, description: "topic vote rules", apply: migrate77},
+	{version: 78, description: "account email changes", apply: migrate78},
+	{version: 79, description: "class feedback", apply: migrate79},
+	{version: 80, description: "account impersonation limit", apply: migrate80},
+	{version: 81, description: "notice status index", apply: migrate81},
+	{version: 82, description: "celebrations", apply: migrate82},
+	{version: 83, description: "shareable session log notes", apply: migrate83},
+	{version: 84, description: "member contact checks", apply: migrate84},
+	{version: 85, description: "class packs", apply: migrate85},
+	{version: 86, description: "observation quick captures", apply: migrate86},
 }
 
 // SchemaVersion returns the current schema version of the database.
@@ -833,3 +895,1353 @@ func migrate24(tx *sql.Tx) error {
 	`)
 	return err
 }
+
+// --- Migration 25: Multi-location support ---
+// Creates location table and scopes schedules, class types, attendance and accounts to a location.
+// An empty location_id means the record is shared by all locations.
+func migrate25(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS location (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		address TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL
+	);
+
+	ALTER TABLE schedule ADD COLUMN location_id TEXT NOT NULL DEFAULT '';
+	ALTER TABLE class_type ADD COLUMN location_id TEXT NOT NULL DEFAULT '';
+	ALTER TABLE attendance ADD COLUMN location_id TEXT NOT NULL DEFAULT '';
+	ALTER TABLE account ADD COLUMN location_id TEXT NOT NULL DEFAULT '';
+
+	CREATE INDEX IF NOT EXISTS idx_schedule_location ON schedule(location_id);
+	CREATE INDEX IF NOT EXISTS idx_attendance_location ON attendance(location_id);
+	`)
+	return err
+}
+
+// --- Migration 26: Injury status lifecycle ---
+// Adds status, severity, resolution and grading-restriction columns to injury.
+// Existing rows keep an empty status and fall back to the 7-day active window.
+func migrate26(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE injury ADD COLUMN status TEXT NOT NULL DEFAULT '';
+	ALTER TABLE injury ADD COLUMN severity TEXT NOT NULL DEFAULT '';
+	ALTER TABLE injury ADD COLUMN resolution_notes TEXT NOT NULL DEFAULT '';
+	ALTER TABLE injury ADD COLUMN resolved_at TEXT;
+	ALTER TABLE injury ADD COLUMN updated_at TEXT;
+	ALTER TABLE injury ADD COLUMN grading_restricted INTEGER NOT NULL DEFAULT 0;
+
+	CREATE INDEX IF NOT EXISTS idx_injury_member ON injury(member_id);
+	`)
+	return err
+}
+
+// --- Migration 27: Notification center ---
+// Creates notification (per-account in-app alerts) and notification_preference
+// (per-kind in-app/email channel choices) tables.
+func migrate27(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS notification (
+		id TEXT PRIMARY KEY,
+		account_id TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		title TEXT NOT NULL,
+		body TEXT NOT NULL DEFAULT '',
+		link TEXT NOT NULL DEFAULT '',
+		read_at TEXT,
+		created_at TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_notification_account ON notification(account_id, created_at);
+
+	CREATE TABLE IF NOT EXISTS notification_preference (
+		account_id TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		in_app INTEGER NOT NULL DEFAULT 1,
+		email INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (account_id, kind)
+	);
+	`)
+	return err
+}
+
+// --- Migration 28: Coach session logs ---
+// Creates session_log (what was taught per schedule + class date) and
+// session_log_topic (rotor topics covered by each log).
+func migrate28(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS session_log (
+		id TEXT PRIMARY KEY,
+		schedule_id TEXT NOT NULL,
+		class_date TEXT NOT NULL,
+		coach_id TEXT NOT NULL,
+		round_structure TEXT NOT NULL DEFAULT '',
+		attendance_notes TEXT NOT NULL DEFAULT '',
+		notes TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL,
+		updated_at TEXT,
+		UNIQUE (schedule_id, class_date)
+	);
+	CREATE INDEX IF NOT EXISTS idx_session_log_date ON session_log(class_date);
+
+	CREATE TABLE IF NOT EXISTS session_log_topic (
+		session_log_id TEXT NOT NULL REFERENCES session_log(id) ON DELETE CASCADE,
+		topic_id TEXT NOT NULL,
+		PRIMARY KEY (session_log_id, topic_id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_session_log_topic_topic ON session_log_topic(topic_id);
+	`)
+	return err
+}
+
+// --- Migration 29: Email delivery tracking ---
+// Adds per-recipient provider message IDs and delivery timestamps so Resend
+// webhooks can update each copy, and an email_suppression list for addresses
+// that hard-bounced or complained.
+func migrate29(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE email_recipient ADD COLUMN resend_message_id TEXT NOT NULL DEFAULT '';
+	ALTER TABLE email_recipient ADD COLUMN delivery_updated_at TEXT NOT NULL DEFAULT '';
+	ALTER TABLE email_recipient ADD COLUMN opened_at TEXT NOT NULL DEFAULT '';
+	ALTER TABLE email_recipient ADD COLUMN clicked_at TEXT NOT NULL DEFAULT '';
+	ALTER TABLE email_recipient ADD COLUMN bounce_reason TEXT NOT NULL DEFAULT '';
+	CREATE INDEX IF NOT EXISTS idx_email_recipient_message ON email_recipient(resend_message_id);
+
+	CREATE TABLE IF NOT EXISTS email_suppression (
+		address TEXT PRIMARY KEY,
+		reason TEXT NOT NULL,
+		email_id TEXT NOT NULL DEFAULT '',
+		detail TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL
+	);
+	`)
+	return err
+}
+
+// --- Migration 30: Competition registration status ---
+// Lets members say whether they are only interested in a competition or have
+// registered, and record the weight class they entered, for the coach roster.
+func migrate30(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE competition_interest ADD COLUMN status TEXT NOT NULL DEFAULT 'interested';
+	ALTER TABLE competition_interest ADD COLUMN weight_class TEXT NOT NULL DEFAULT '';
+	`)
+	return err
+}
+
+// --- Migration 31: Full-text search index ---
+// One FTS5 table covers members, notices, clips, topics and messages. Rows are
+// derived from the source tables (see storage/search) and can be rebuilt at any time.
+func migrate31(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE VIRTUAL TABLE IF NOT EXISTS search_index USING fts5(
+		title,
+		body,
+		kind UNINDEXED,
+		ref_id UNINDEXED,
+		visibility UNINDEXED,
+		participants UNINDEXED,
+		tokenize = 'porter unicode61 remove_diacritics 2'
+	);
+	`)
+	return err
+}
+
+// --- Migration 32: Permission matrix overrides ---
+// Defaults live in code (domain/permission); this table only holds the actions
+// an admin has changed, so new actions pick up their defaults automatically.
+func migrate32(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS permission_override (
+		action TEXT PRIMARY KEY,
+		allow_coach INTEGER NOT NULL DEFAULT 0,
+		allow_member INTEGER NOT NULL DEFAULT 0,
+		allow_trial INTEGER NOT NULL DEFAULT 0,
+		updated_by TEXT NOT NULL DEFAULT '',
+		updated_at TEXT NOT NULL
+	);
+	`)
+	return err
+}
+
+// --- Migration 33: Message threads ---
+// Replies point at the thread's first message. from_member marks member replies so
+// each side's unread count only includes messages written by the other side.
+func migrate33(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE message ADD COLUMN parent_id TEXT NOT NULL DEFAULT '';
+	ALTER TABLE message ADD COLUMN from_member INTEGER NOT NULL DEFAULT 0;
+	CREATE INDEX IF NOT EXISTS idx_message_parent ON message(parent_id);
+	`)
+	return err
+}
+
+// migrate34 adds the per-rotor advance mode and marks schedules inserted by a vote bump.
+// Rotors auto-advance unless manual_advance is set (§5.4).
+func migrate34(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE rotor ADD COLUMN manual_advance INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE topic_schedule ADD COLUMN bumped INTEGER NOT NULL DEFAULT 0;
+	`)
+	return err
+}
+
+// --- Migration 35: Persistent login sessions ---
+// Sessions survive restarts and can be revoked. token_hash is the SHA-256 of the
+// cookie value; the raw token is never stored.
+func migrate35(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS auth_session (
+		id TEXT PRIMARY KEY,
+		token_hash TEXT NOT NULL UNIQUE,
+		account_id TEXT NOT NULL,
+		email TEXT NOT NULL DEFAULT '',
+		role TEXT NOT NULL,
+		beta_tester INTEGER NOT NULL DEFAULT 0,
+		password_change_required INTEGER NOT NULL DEFAULT 0,
+		location_id TEXT NOT NULL DEFAULT '',
+		real_account_id TEXT NOT NULL DEFAULT '',
+		real_email TEXT NOT NULL DEFAULT '',
+		real_role TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL,
+		last_seen_at TEXT NOT NULL,
+		expires_at TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_auth_session_account ON auth_session(account_id);
+	CREATE INDEX IF NOT EXISTS idx_auth_session_expires ON auth_session(expires_at);
+	`)
+	return err
+}
+
+// --- Migration 36: Grading proposal scheduling and comments ---
+// event_id links a proposal to the calendar event of its grading day (empty until
+// scheduled); grading_proposal_comment holds the coach/admin discussion.
+func migrate36(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE grading_proposal ADD COLUMN event_id TEXT NOT NULL DEFAULT '';
+	CREATE INDEX IF NOT EXISTS idx_grading_proposal_event ON grading_proposal(event_id);
+
+	CREATE TABLE IF NOT EXISTS grading_proposal_comment (
+		id TEXT PRIMARY KEY,
+		proposal_id TEXT NOT NULL,
+		author_id TEXT NOT NULL,
+		content TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		FOREIGN KEY (proposal_id) REFERENCES grading_proposal(id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_grading_proposal_comment_proposal ON grading_proposal_comment(proposal_id, created_at);
+	`)
+	return err
+}
+
+// --- Migration 37: Makeup credits ---
+// A makeup credit counts as one attended session towards a kid's term attendance.
+func migrate37(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS makeup_credit (
+		id TEXT PRIMARY KEY,
+		member_id TEXT NOT NULL,
+		term_id TEXT NOT NULL,
+		class_date TEXT NOT NULL DEFAULT '',
+		reason TEXT NOT NULL,
+		awarded_by TEXT NOT NULL,
+		awarded_at TEXT NOT NULL,
+		FOREIGN KEY (member_id) REFERENCES member(id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_makeup_credit_term ON makeup_credit(term_id, member_id);
+	`)
+	return err
+}
+
+// --- Migration 38: Daily KPI snapshots ---
+// One row per day, written by the kpi_snapshots worker and charted on the admin dashboard.
+func migrate38(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS kpi_snapshot (
+		date TEXT PRIMARY KEY,
+		total_members INTEGER NOT NULL DEFAULT 0,
+		active_members INTEGER NOT NULL DEFAULT 0,
+		archived_members INTEGER NOT NULL DEFAULT 0,
+		new_members INTEGER NOT NULL DEFAULT 0,
+		churned_members INTEGER NOT NULL DEFAULT 0,
+		weekly_active_members INTEGER NOT NULL DEFAULT 0,
+		classes_held INTEGER NOT NULL DEFAULT 0,
+		avg_class_size REAL NOT NULL DEFAULT 0,
+		monthly_revenue INTEGER NOT NULL DEFAULT 0,
+		computed_at TEXT NOT NULL
+	);
+	`)
+	return err
+}
+
+// --- Migration 39: Rubric templates and scores ---
+// Criteria are stored as JSON on the template; scores are one row per criterion so
+// per-member averages can be taken without decoding anything.
+func migrate39(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS rubric_template (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		criteria TEXT NOT NULL,
+		archived INTEGER NOT NULL DEFAULT 0,
+		created_by TEXT NOT NULL,
+		created_at TEXT NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS rubric_score (
+		id TEXT PRIMARY KEY,
+		template_id TEXT NOT NULL,
+		member_id TEXT NOT NULL,
+		source TEXT NOT NULL,
+		source_id TEXT NOT NULL,
+		criterion_key TEXT NOT NULL,
+		criterion_label TEXT NOT NULL,
+		value INTEGER NOT NULL,
+		min_value INTEGER NOT NULL,
+		max_value INTEGER NOT NULL,
+		author_id TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		FOREIGN KEY (template_id) REFERENCES rubric_template(id),
+		FOREIGN KEY (member_id) REFERENCES member(id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_rubric_score_member ON rubric_score(member_id, created_at);
+	`)
+	return err
+}
+
+// --- Migration 40: Class occurrence changes ---
+// A cancellation or substitute coach for one date of a recurring schedule.
+// At most one change per occurrence; the notice and email it generated are kept for retraction.
+func migrate40(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS class_occurrence_change (
+		id TEXT PRIMARY KEY,
+		schedule_id TEXT NOT NULL,
+		class_date TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		substitute TEXT NOT NULL DEFAULT '',
+		reason TEXT NOT NULL DEFAULT '',
+		notice_id TEXT NOT NULL DEFAULT '',
+		email_id TEXT NOT NULL DEFAULT '',
+		created_by TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		UNIQUE (schedule_id, class_date)
+	);
+	CREATE INDEX IF NOT EXISTS idx_class_occurrence_change_date ON class_occurrence_change(class_date);
+	`)
+	return err
+}
+
+// --- Migration 41: Personal goal check-ins and annotations ---
+// Goals gain a status so reached goals can be closed, and the time of the last check-in
+// so members are prompted weekly. Check-ins and coach annotations go with their goal.
+func migrate41(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE personal_goal ADD COLUMN status TEXT NOT NULL DEFAULT 'active';
+	ALTER TABLE personal_goal ADD COLUMN completed_at TEXT NOT NULL DEFAULT '';
+	ALTER TABLE personal_goal ADD COLUMN last_check_in_at TEXT NOT NULL DEFAULT '';
+	CREATE TABLE IF NOT EXISTS personal_goal_check_in (
+		id TEXT PRIMARY KEY,
+		goal_id TEXT NOT NULL,
+		member_id TEXT NOT NULL,
+		progress INTEGER NOT NULL,
+		status TEXT NOT NULL,
+		note TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL,
+		FOREIGN KEY (goal_id) REFERENCES personal_goal(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_personal_goal_check_in_goal ON personal_goal_check_in(goal_id, created_at);
+	CREATE TABLE IF NOT EXISTS personal_goal_annotation (
+		id TEXT PRIMARY KEY,
+		goal_id TEXT NOT NULL,
+		author_id TEXT NOT NULL,
+		author_name TEXT NOT NULL DEFAULT '',
+		content TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		FOREIGN KEY (goal_id) REFERENCES personal_goal(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_personal_goal_annotation_goal ON personal_goal_annotation(goal_id, created_at);
+	`)
+	return err
+}
+
+// --- Migration 42: Email categories and communication preferences ---
+// Each email has a category so members can opt out of announcements, grading or billing
+// mail. A member without a preference row receives everything.
+func migrate42(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE email ADD COLUMN category TEXT NOT NULL DEFAULT 'announcements';
+	CREATE TABLE IF NOT EXISTS communication_preference (
+		member_id TEXT PRIMARY KEY,
+		announcements INTEGER NOT NULL DEFAULT 1,
+		grading INTEGER NOT NULL DEFAULT 1,
+		billing INTEGER NOT NULL DEFAULT 1,
+		updated_at TEXT NOT NULL,
+		FOREIGN KEY (member_id) REFERENCES member(id) ON DELETE CASCADE
+	);
+	`)
+	return err
+}
+
+// --- Migration 43: Feature flag targeting ---
+// Percentage rollouts, allow/deny account lists (JSON arrays of account IDs) and an
+// optional activation window. Existing flags keep their role-only behaviour.
+func migrate43(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE feature_flag ADD COLUMN rollout_percent INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE feature_flag ADD COLUMN allow_accounts TEXT NOT NULL DEFAULT '[]';
+	ALTER TABLE feature_flag ADD COLUMN deny_accounts TEXT NOT NULL DEFAULT '[]';
+	ALTER TABLE feature_flag ADD COLUMN starts_at TEXT;
+	ALTER TABLE feature_flag ADD COLUMN ends_at TEXT;
+	`)
+	return err
+}
+
+// --- Migration 44: Belt inventory ---
+// Stock of belts by colour and size and of stripe tape by colour, taken down as grading
+// records are created, and the belt size each member wears for the grading-day pick list.
+func migrate44(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS belt_inventory (
+		id TEXT PRIMARY KEY,
+		kind TEXT NOT NULL,
+		color TEXT NOT NULL,
+		size TEXT NOT NULL DEFAULT '',
+		quantity INTEGER NOT NULL DEFAULT 0,
+		low_stock INTEGER NOT NULL DEFAULT 0,
+		updated_at TEXT NOT NULL,
+		UNIQUE (kind, color, size)
+	);
+	CREATE TABLE IF NOT EXISTS belt_size (
+		member_id TEXT PRIMARY KEY,
+		size TEXT NOT NULL,
+		FOREIGN KEY (member_id) REFERENCES member(id) ON DELETE CASCADE
+	);
+	`)
+	return err
+}
+
+// --- Migration 45: Shared topic library ---
+// Topics written once and reused across class rotors. A topic linked to a library entry
+// takes its name and description whenever the entry is edited.
+func migrate45(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS shared_topic (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		duration_weeks INTEGER NOT NULL DEFAULT 1,
+		created_by TEXT NOT NULL,
+		updated_at TEXT NOT NULL
+	);
+	ALTER TABLE topic ADD COLUMN shared_topic_id TEXT NOT NULL DEFAULT '';
+	CREATE INDEX IF NOT EXISTS idx_topic_shared ON topic(shared_topic_id);
+	`)
+	return err
+}
+
+// --- Migration 46: Visitors ---
+// Drop-in guests remembered by email across visits, with each visit's fee and whether it
+// was paid, so returning guests are recognised and can be followed up and converted.
+func migrate46(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS visitor (
+		id TEXT PRIMARY KEY,
+		member_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		email TEXT NOT NULL UNIQUE,
+		home_gym TEXT NOT NULL DEFAULT '',
+		drop_in_fee INTEGER NOT NULL DEFAULT 0,
+		status TEXT NOT NULL DEFAULT 'visiting',
+		first_visit TEXT,
+		last_visit TEXT,
+		visit_count INTEGER NOT NULL DEFAULT 0,
+		follow_up_email_id TEXT NOT NULL DEFAULT '',
+		converted_at TEXT,
+		created_at TEXT NOT NULL,
+		FOREIGN KEY (member_id) REFERENCES member(id) ON DELETE CASCADE
+	);
+	CREATE TABLE IF NOT EXISTS visit (
+		id TEXT PRIMARY KEY,
+		visitor_id TEXT NOT NULL,
+		attendance_id TEXT NOT NULL DEFAULT '',
+		visited_at TEXT NOT NULL,
+		fee INTEGER NOT NULL DEFAULT 0,
+		paid INTEGER NOT NULL DEFAULT 0,
+		FOREIGN KEY (visitor_id) REFERENCES visitor(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_visit_visitor ON visit(visitor_id, visited_at);
+	CREATE INDEX IF NOT EXISTS idx_visit_visited_at ON visit(visited_at);
+	`)
+	return err
+}
+
+// --- Migration 47: Grading eligibility rules ---
+// Admin-composed criteria per program and belt (mat hours, term attendance, months at
+// belt, classes with a coach), all of which must be met. The criteria are a JSON array.
+// A belt without a rule keeps using its grading_config thresholds.
+func migrate47(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS grading_rule (
+		id TEXT PRIMARY KEY,
+		program TEXT NOT NULL,
+		belt TEXT NOT NULL,
+		criteria TEXT NOT NULL DEFAULT '[]',
+		updated_by TEXT NOT NULL DEFAULT '',
+		updated_at TEXT NOT NULL,
+		UNIQUE(program, belt)
+	);
+	`)
+	return err
+}
+
+// --- Migration 48: Email template library ---
+// Named emails with merge variables, one row per key. Built-in keys (welcome, inactive
+// follow-up, grading congratulation) only get a row once reworded. Emails sent from a
+// template record its key, so automatic follow-ups are not repeated.
+func migrate48(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS email_message_template (
+		id TEXT PRIMARY KEY,
+		key TEXT NOT NULL UNIQUE,
+		name TEXT NOT NULL,
+		category TEXT NOT NULL,
+		subject TEXT NOT NULL,
+		body TEXT NOT NULL,
+		is_default INTEGER NOT NULL DEFAULT 0,
+		updated_by TEXT NOT NULL DEFAULT '',
+		updated_at TEXT NOT NULL
+	);
+	ALTER TABLE email ADD COLUMN template_key TEXT NOT NULL DEFAULT '';
+	`)
+	return err
+}
+
+// --- Migration 49: Inactive member re-engagement ---
+// Rules that act once a member has been away for a number of days (send a library email
+// or flag a coach call), the actions taken for each member with their outcomes, and
+// per-member pauses. Seeds the default steps: a "we miss you" email at 21 days and a
+// coach call at 45.
+func migrate49(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS reengagement_rule (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		days_inactive INTEGER NOT NULL,
+		action TEXT NOT NULL,
+		template_key TEXT NOT NULL DEFAULT '',
+		enabled INTEGER NOT NULL DEFAULT 1,
+		updated_by TEXT NOT NULL DEFAULT '',
+		updated_at TEXT NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS reengagement_action (
+		id TEXT PRIMARY KEY,
+		member_id TEXT NOT NULL,
+		rule_id TEXT NOT NULL DEFAULT '',
+		rule_name TEXT NOT NULL DEFAULT '',
+		kind TEXT NOT NULL,
+		days_inactive INTEGER NOT NULL DEFAULT 0,
+		last_check_in TEXT,
+		email_id TEXT NOT NULL DEFAULT '',
+		outcome TEXT NOT NULL,
+		note TEXT NOT NULL DEFAULT '',
+		recorded_by TEXT NOT NULL DEFAULT '',
+		recorded_at TEXT,
+		returned_at TEXT,
+		created_at TEXT NOT NULL,
+		FOREIGN KEY (member_id) REFERENCES member(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_reengagement_action_member ON reengagement_action(member_id, created_at);
+	CREATE INDEX IF NOT EXISTS idx_reengagement_action_outcome ON reengagement_action(outcome);
+	CREATE TABLE IF NOT EXISTS reengagement_suppression (
+		member_id TEXT PRIMARY KEY,
+		reason TEXT NOT NULL,
+		until TEXT,
+		created_by TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL,
+		FOREIGN KEY (member_id) REFERENCES member(id) ON DELETE CASCADE
+	);
+
+	-- Default steps
+	INSERT OR IGNORE INTO reengagement_rule (id, name, days_inactive, action, template_key, enabled, updated_at)
+	VALUES ('reengagement-email-21', 'We miss you email', 21, 'email', 'inactive_follow_up', 1, strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+		('reengagement-call-45', 'Coach call', 45, 'coach_call', '', 1, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'));
+	`)
+	return err
+}
+
+// --- Migration 50: Belt-gated rotor topics ---
+// Adds min_belt to topic so members below a belt see the topic locked, and to library clips
+// and themes. Also brings clip_tags up to the tag taxonomy with its category column. Those
+// three tables belong to their stores, which create them with every column; on a fresh
+// database they do not exist yet and are skipped.
+func migrate50(tx *sql.Tx) error {
+	if _, err := tx.Exec(`ALTER TABLE topic ADD COLUMN min_belt TEXT NOT NULL DEFAULT ''`); err != nil {
+		return err
+	}
+	for _, c := range []struct{ table, column, definition string }{
+		{"themes", "min_belt", "TEXT NOT NULL DEFAULT ''"},
+		{"clips", "min_belt", "TEXT NOT NULL DEFAULT ''"},
+		{"clip_tags", "category", "TEXT NOT NULL DEFAULT 'general'"},
+	} {
+		if err := addStoreColumn(tx, c.table, c.column, c.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addStoreColumn adds a column to a table a store creates, if the table exists and lacks it.
+func addStoreColumn(tx *sql.Tx, table, column, definition string) error {
+	var exists, has int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&exists); err != nil || exists == 0 {
+		return err
+	}
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&has); err != nil || has > 0 {
+		return err
+	}
+	_, err := tx.Exec(`ALTER TABLE ` + table + ` ADD COLUMN ` + column + ` ` + definition)
+	return err
+}
+
+// --- Migration 51: Coach timesheets ---
+// Adds the regular coach to each schedule, per-date overrides for whoever actually ran a
+// class, and each coach's hourly rate (in cents) for the payroll export.
+func migrate51(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE schedule ADD COLUMN coach_id TEXT NOT NULL DEFAULT '';
+	CREATE TABLE IF NOT EXISTS coach_session_override (
+		id TEXT PRIMARY KEY,
+		schedule_id TEXT NOT NULL,
+		class_date TEXT NOT NULL,
+		coach_id TEXT NOT NULL,
+		set_by TEXT NOT NULL,
+		set_at TEXT NOT NULL,
+		UNIQUE(schedule_id, class_date),
+		FOREIGN KEY (schedule_id) REFERENCES schedule(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_coach_session_override_date ON coach_session_override(class_date);
+	CREATE TABLE IF NOT EXISTS coach_rate (
+		coach_id TEXT PRIMARY KEY,
+		hourly_rate INTEGER NOT NULL DEFAULT 0,
+		updated_by TEXT NOT NULL,
+		updated_at TEXT NOT NULL
+	);
+	`)
+	return err
+}
+
+// --- Migration 52: BugBox triage ---
+// Records who filed each bug report and tracks its triage: status, assigned admin and the
+// report it duplicates. Comments let admins and the reporter talk about a report.
+func migrate52(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE bugbox_submission ADD COLUMN reporter_id TEXT NOT NULL DEFAULT '';
+	ALTER TABLE bugbox_submission ADD COLUMN status TEXT NOT NULL DEFAULT 'new';
+	ALTER TABLE bugbox_submission ADD COLUMN assignee_id TEXT NOT NULL DEFAULT '';
+	ALTER TABLE bugbox_submission ADD COLUMN duplicate_of TEXT NOT NULL DEFAULT '';
+	ALTER TABLE bugbox_submission ADD COLUMN updated_at TEXT NOT NULL DEFAULT '';
+	CREATE INDEX IF NOT EXISTS idx_bugbox_submission_reporter ON bugbox_submission(reporter_id);
+	CREATE TABLE IF NOT EXISTS bugbox_comment (
+		id TEXT PRIMARY KEY,
+		submission_id TEXT NOT NULL,
+		author_id TEXT NOT NULL,
+		body TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		FOREIGN KEY (submission_id) REFERENCES bugbox_submission(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_bugbox_comment_submission ON bugbox_comment(submission_id);
+	`)
+	return err
+}
+
+// --- Migration 53: Schedule mat space ---
+// Records which mat a class runs on so classes on different mats at the same time are not
+// reported as conflicts. Empty means the class uses the whole floor.
+func migrate53(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE schedule ADD COLUMN mat TEXT NOT NULL DEFAULT ''`)
+	return err
+}
+
+// --- Migration 54: Term rollover ---
+// Kids attendance thresholds set per term, so the rollover wizard can carry them into the
+// next term, and a frozen copy of each finished term's kids readiness.
+func migrate54(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS term_threshold (
+		term_id TEXT NOT NULL,
+		belt TEXT NOT NULL,
+		attendance_pct REAL NOT NULL,
+		PRIMARY KEY (term_id, belt),
+		FOREIGN KEY (term_id) REFERENCES term(id) ON DELETE CASCADE
+	);
+	CREATE TABLE IF NOT EXISTS term_readiness_snapshot (
+		term_id TEXT PRIMARY KEY,
+		term_name TEXT NOT NULL,
+		entries TEXT NOT NULL,
+		archived_by TEXT NOT NULL DEFAULT '',
+		archived_at TEXT NOT NULL,
+		FOREIGN KEY (term_id) REFERENCES term(id) ON DELETE CASCADE
+	);
+	`)
+	return err
+}
+
+// --- Migration 55: Note visibility ---
+// Observations and grading notes get a visibility level; existing notes stay coaches-only.
+func migrate55(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE coach_observation ADD COLUMN visibility TEXT NOT NULL DEFAULT 'coaches';
+	ALTER TABLE grading_note ADD COLUMN visibility TEXT NOT NULL DEFAULT 'coaches';
+	`)
+	return err
+}
+
+// --- Migration 56: Web push ---
+// Creates push_subscription (one row per subscribed browser) and class_reminder_sent
+// (so each member is reminded of a class occurrence once), and adds the push channel
+// to notification preferences.
+func migrate56(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS push_subscription (
+		id TEXT PRIMARY KEY,
+		account_id TEXT NOT NULL,
+		endpoint TEXT NOT NULL UNIQUE,
+		p256dh TEXT NOT NULL,
+		auth TEXT NOT NULL,
+		user_agent TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_push_subscription_account ON push_subscription(account_id);
+
+	CREATE TABLE IF NOT EXISTS class_reminder_sent (
+		account_id TEXT NOT NULL,
+		schedule_id TEXT NOT NULL,
+		class_date TEXT NOT NULL,
+		sent_at TEXT NOT NULL,
+		PRIMARY KEY (account_id, schedule_id, class_date)
+	);
+
+	ALTER TABLE notification_preference ADD COLUMN push INTEGER NOT NULL DEFAULT 0;
+	`)
+	return err
+}
+
+// --- Migration 57: Theme archive on rotors ---
+// Moves the standalone 4-week themes under the rotor system so the theme carousel can be
+// drawn from rotors alone. Each program with themes gets an archived "Theme archive" rotor
+// (version 0) on its first class type. Every theme becomes one of its rotor themes, with a
+// single topic and a completed schedule over the theme's dates. themes.rotor_theme_id links
+// the theme to it, so its library clips stay reachable. The themes table belongs to the theme
+// store; on a fresh database it does not exist yet and there is nothing to move.
+func migrate57(tx *sql.Tx) error {
+	var exists int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'themes'`).Scan(&exists); err != nil {
+		return err
+	}
+	if exists == 0 {
+		return nil
+	}
+	if err := addStoreColumn(tx, "themes", "rotor_theme_id", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`
+	INSERT OR IGNORE INTO rotor (id, class_type_id, name, version, status, preview_on, created_by, created_at, activated_at)
+	SELECT 'theme-archive-' || p.program,
+		(SELECT ct.id FROM class_type ct JOIN program pr ON pr.id = ct.program_id WHERE pr.type = p.program ORDER BY ct.name, ct.id LIMIT 1),
+		'Theme archive', 0, 'archived', 0,
+		(SELECT id FROM account ORDER BY role <> 'admin', created_at LIMIT 1),
+		strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), ''
+	FROM (SELECT DISTINCT program FROM themes) p
+	WHERE EXISTS (SELECT 1 FROM class_type ct JOIN program pr ON pr.id = ct.program_id WHERE pr.type = p.program)
+		AND EXISTS (SELECT 1 FROM account);
+
+	INSERT OR IGNORE INTO rotor_theme (id, rotor_id, name, position, hidden)
+	SELECT 'theme-archive-' || t.id, 'theme-archive-' || t.program, t.name,
+		ROW_NUMBER() OVER (PARTITION BY t.program ORDER BY t.start_date, t.id) - 1, 0
+	FROM themes t
+	WHERE EXISTS (SELECT 1 FROM rotor r WHERE r.id = 'theme-archive-' || t.program);
+
+	INSERT OR IGNORE INTO topic (id, rotor_theme_id, name, description, duration_weeks, position, last_covered, min_belt)
+	SELECT 'theme-archive-topic-' || t.id, 'theme-archive-' || t.id, t.name, t.description,
+		MAX(1, CAST(ROUND((julianday(substr(t.end_date, 1, 10)) - julianday(substr(t.start_date, 1, 10)) + 1) / 7.0) AS INTEGER)),
+		0, strftime('%Y-%m-%dT%H:%M:%SZ', substr(t.end_date, 1, 10)), t.min_belt
+	FROM themes t
+	WHERE EXISTS (SELECT 1 FROM rotor_theme rt WHERE rt.id = 'theme-archive-' || t.id);
+
+	INSERT OR IGNORE INTO topic_schedule (id, topic_id, rotor_theme_id, start_date, end_date, status, bumped)
+	SELECT 'theme-archive-schedule-' || t.id, 'theme-archive-topic-' || t.id, 'theme-archive-' || t.id,
+		strftime('%Y-%m-%dT%H:%M:%SZ', substr(t.start_date, 1, 10)), strftime('%Y-%m-%dT%H:%M:%SZ', substr(t.end_date, 1, 10)),
+		'completed', 0
+	FROM themes t
+	WHERE EXISTS (SELECT 1 FROM topic tp WHERE tp.id = 'theme-archive-topic-' || t.id);
+
+	UPDATE themes SET rotor_theme_id = 'theme-archive-' || id
+	WHERE rotor_theme_id = '' AND EXISTS (SELECT 1 FROM rotor_theme rt WHERE rt.id = 'theme-archive-' || themes.id);
+	`)
+	return err
+}
+
+// --- Migration 58: Attendance topics ---
+// Links each check-in to the rotor topics being taught in that class at the time, so
+// curriculum coverage can count who was on the mat for each topic.
+func migrate58(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS attendance_topic (
+		attendance_id TEXT NOT NULL,
+		topic_id TEXT NOT NULL,
+		rotor_theme_id TEXT NOT NULL,
+		rotor_id TEXT NOT NULL,
+		PRIMARY KEY (attendance_id, topic_id),
+		FOREIGN KEY (attendance_id) REFERENCES attendance(id) ON DELETE CASCADE,
+		FOREIGN KEY (topic_id) REFERENCES topic(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_attendance_topic_rotor ON attendance_topic(rotor_id);
+	`)
+	return err
+}
+
+// --- Migration 59: Kiosk devices ---
+// Registers each tablet that runs kiosk mode. pin_secret (hex) seeds the device's rotating
+// exit PIN; auth_session_id is the sign-in session kiosk mode runs under, so an admin can end it.
+func migrate59(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS kiosk_device (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		location_id TEXT NOT NULL DEFAULT '',
+		registered_by TEXT NOT NULL,
+		pin_secret TEXT NOT NULL,
+		registered_at TEXT NOT NULL,
+		last_seen_at TEXT NOT NULL DEFAULT '',
+		auth_session_id TEXT NOT NULL DEFAULT '',
+		kiosk_started_at TEXT NOT NULL DEFAULT ''
+	);
+	`)
+	return err
+}
+
+// --- Migration 60: Message attachments ---
+// Clips, images and links sent with a message, as a JSON array; empty when there are none.
+func migrate60(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE message ADD COLUMN attachments TEXT NOT NULL DEFAULT ''`)
+	return err
+}
+
+// --- Migration 61: Estimated hours review history ---
+// One row per step in a self-estimate's review: submission, question, answer, decision.
+func migrate61(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS estimated_hours_review (
+		id TEXT PRIMARY KEY,
+		entry_id TEXT NOT NULL,
+		actor_id TEXT NOT NULL DEFAULT '',
+		action TEXT NOT NULL,
+		note TEXT NOT NULL DEFAULT '',
+		hours REAL NOT NULL DEFAULT 0,
+		created_at TEXT NOT NULL,
+		FOREIGN KEY (entry_id) REFERENCES estimated_hours(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_estimated_hours_review_entry ON estimated_hours_review(entry_id, created_at);
+	`)
+	return err
+}
+
+// --- Migration 62: Account suspension and scheduled status changes ---
+// suspended_reason is shown at sign-in while an account is suspended. status_change holds
+// suspensions, reinstatements, freezes and unfreezes, scheduled or applied; applied_at is
+// empty until the change takes effect on effective_date.
+func migrate62(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE account ADD COLUMN suspended_reason TEXT NOT NULL DEFAULT '';
+	CREATE TABLE IF NOT EXISTS status_change (
+		id TEXT PRIMARY KEY,
+		subject_type TEXT NOT NULL,
+		subject_id TEXT NOT NULL,
+		action TEXT NOT NULL,
+		reason TEXT NOT NULL DEFAULT '',
+		effective_date TEXT NOT NULL,
+		created_by TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		applied_at TEXT NOT NULL DEFAULT '',
+		error TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX IF NOT EXISTS idx_status_change_subject ON status_change(subject_type, subject_id);
+	CREATE INDEX IF NOT EXISTS idx_status_change_due ON status_change(applied_at, effective_date);
+	`)
+	return err
+}
+
+// --- Migration 63: Coach availability ---
+// coach_availability holds each coach's recurring weekly windows; coach_availability_exception
+// takes a date (or part of it) away from a coach, or adds one outside their windows.
+// Empty start and end times mean the whole day.
+func migrate63(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS coach_availability (
+		id TEXT PRIMARY KEY,
+		coach_id TEXT NOT NULL,
+		day TEXT NOT NULL,
+		start_time TEXT NOT NULL,
+		end_time TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_coach_availability_coach ON coach_availability(coach_id);
+	CREATE TABLE IF NOT EXISTS coach_availability_exception (
+		id TEXT PRIMARY KEY,
+		coach_id TEXT NOT NULL,
+		date TEXT NOT NULL,
+		available INTEGER NOT NULL DEFAULT 0,
+		start_time TEXT NOT NULL DEFAULT '',
+		end_time TEXT NOT NULL DEFAULT '',
+		reason TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_coach_availability_exception_date ON coach_availability_exception(date);
+	CREATE INDEX IF NOT EXISTS idx_coach_availability_exception_coach ON coach_availability_exception(coach_id, date);
+	`)
+	return err
+}
+
+// --- Migration 64: Member tags and smart segments ---
+// member_tag holds free-form labels on members (e.g. "competitor", "injured"); member_segment
+// saves a filter over tags, program, status and attendance. Segment tags are comma-separated.
+func migrate64(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS member_tag (
+		member_id TEXT NOT NULL,
+		tag TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		PRIMARY KEY (member_id, tag)
+	);
+	CREATE INDEX IF NOT EXISTS idx_member_tag_tag ON member_tag(tag);
+	CREATE TABLE IF NOT EXISTS member_segment (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		tags TEXT NOT NULL DEFAULT '',
+		program TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL DEFAULT '',
+		attended_within_days INTEGER NOT NULL DEFAULT 0,
+		absent_for_days INTEGER NOT NULL DEFAULT 0,
+		created_by TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL
+	);
+	`)
+	return err
+}
+
+// --- Migration 65: Grading record corrections ---
+// Amending a grading record saves a new row whose supersedes points at the original and sets
+// the original's superseded_by; voiding sets voided. Neither deletes the original row.
+// corrected_by, corrected_at and correction_reason describe the amendment or void.
+func migrate65(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE grading_record ADD COLUMN supersedes TEXT NOT NULL DEFAULT '';
+	ALTER TABLE grading_record ADD COLUMN superseded_by TEXT NOT NULL DEFAULT '';
+	ALTER TABLE grading_record ADD COLUMN voided INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE grading_record ADD COLUMN corrected_by TEXT NOT NULL DEFAULT '';
+	ALTER TABLE grading_record ADD COLUMN corrected_at TEXT NOT NULL DEFAULT '';
+	ALTER TABLE grading_record ADD COLUMN correction_reason TEXT NOT NULL DEFAULT '';
+	`)
+	return err
+}
+
+// --- Migration 66: Mat capacity and overcrowding alerts ---
+// mat_capacity caps the people on the mat for a class type (0 = no limit).
+// class_capacity_alert records each class occurrence that went over, so staff are alerted once.
+func migrate66(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE class_type ADD COLUMN mat_capacity INTEGER NOT NULL DEFAULT 0;
+	CREATE TABLE IF NOT EXISTS class_capacity_alert (
+		schedule_id TEXT NOT NULL,
+		class_date TEXT NOT NULL,
+		headcount INTEGER NOT NULL,
+		sent_at TEXT NOT NULL,
+		PRIMARY KEY (schedule_id, class_date)
+	);
+	`)
+	return err
+}
+
+// --- Migration 67: Account and session locale ---
+// locale is the language tag an account chose for pages and messages (empty = follow the browser).
+// Sessions carry a copy so each request can pick its language without loading the account.
+func migrate67(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE account ADD COLUMN locale TEXT NOT NULL DEFAULT '';
+	ALTER TABLE auth_session ADD COLUMN locale TEXT NOT NULL DEFAULT '';
+	`)
+	return err
+}
+
+// --- Migration 68: Weekly digest emails ---
+// digest is the opt-in weekly training summary, off for everyone until they switch it on.
+// weekly_digest_sent records the weeks each member has been sent so a digest goes out once.
+func migrate68(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE communication_preference ADD COLUMN digest INTEGER NOT NULL DEFAULT 0;
+	CREATE TABLE IF NOT EXISTS weekly_digest_sent (
+		member_id TEXT NOT NULL,
+		week_start TEXT NOT NULL,
+		sent_at TEXT NOT NULL,
+		PRIMARY KEY (member_id, week_start)
+	);
+	`)
+	return err
+}
+
+// --- Migration 69: Grading ceremonies ---
+// grading_ceremony is a planned promotion ceremony. event_id is the calendar event it created,
+// onto which the proposals being awarded are scheduled; notice_id is the notice for invitees.
+func migrate69(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS grading_ceremony (
+		id TEXT PRIMARY KEY,
+		title TEXT NOT NULL,
+		location TEXT NOT NULL DEFAULT '',
+		ceremony_date TEXT NOT NULL,
+		event_id TEXT NOT NULL UNIQUE,
+		notice_id TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL,
+		created_by TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		completed_by TEXT NOT NULL DEFAULT '',
+		completed_at TEXT NOT NULL DEFAULT ''
+	);
+	`)
+	return err
+}
+
+// --- Migration 70: Perf timing buckets ---
+// perf_bucket holds one minute of request or query timings for one route or operation, so
+// percentiles survive restarts. counts is the comma-separated latency histogram. Rows are
+// never updated: a minute flushed twice (e.g. across a restart) is merged when read.
+func migrate70(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS perf_bucket (
+		minute TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		name TEXT NOT NULL,
+		count INTEGER NOT NULL,
+		sum_ms REAL NOT NULL,
+		max_ms REAL NOT NULL,
+		counts TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_perf_bucket_minute ON perf_bucket(minute);
+	`)
+	return err
+}
+
+// --- Migration 71: Referrals ---
+// referral records who referred a member, either another member or a free-text name; each
+// member is referred at most once. converted_at and reward are set when they become active.
+// referral_settings is a single row choosing how referrers are rewarded.
+func migrate71(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS referral (
+		id TEXT PRIMARY KEY,
+		member_id TEXT NOT NULL UNIQUE,
+		referrer_member_id TEXT NOT NULL DEFAULT '',
+		referrer_name TEXT NOT NULL DEFAULT '',
+		source TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		converted_at TEXT NOT NULL DEFAULT '',
+		reward TEXT NOT NULL DEFAULT '',
+		credit_amount INTEGER NOT NULL DEFAULT 0
+	);
+	CREATE INDEX IF NOT EXISTS idx_referral_referrer ON referral(referrer_member_id);
+
+	CREATE TABLE IF NOT EXISTS referral_settings (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		enabled INTEGER NOT NULL,
+		reward TEXT NOT NULL,
+		credit_amount INTEGER NOT NULL,
+		updated_by TEXT NOT NULL DEFAULT '',
+		updated_at TEXT NOT NULL DEFAULT ''
+	);
+	`)
+	return err
+}
+
+// --- Migration 72: Class type prerequisites ---
+// min_belt and requires_approval are checked at check-in. class_type_approval holds a
+// coach's standing approval for a member to attend a class type regardless of them.
+func migrate72(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE class_type ADD COLUMN min_belt TEXT NOT NULL DEFAULT '';
+	ALTER TABLE class_type ADD COLUMN requires_approval INTEGER NOT NULL DEFAULT 0;
+
+	CREATE TABLE IF NOT EXISTS class_type_approval (
+		class_type_id TEXT NOT NULL,
+		member_id TEXT NOT NULL,
+		approved_by TEXT NOT NULL,
+		approved_at TEXT NOT NULL,
+		PRIMARY KEY (class_type_id, member_id)
+	);
+	`)
+	return err
+}
+
+// --- Migration 73: Job schedules ---
+// job holds each background task's cron schedule and last-run status; the task itself is
+// code registered by name. running_until is a lease taken at the start of a run so a run
+// never overlaps the previous one, and expires if the process dies mid-run.
+func migrate73(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS job (
+		name TEXT PRIMARY KEY,
+		schedule TEXT NOT NULL,
+		enabled INTEGER NOT NULL,
+		next_run_at TEXT NOT NULL DEFAULT '',
+		last_run_at TEXT NOT NULL DEFAULT '',
+		last_duration_ms INTEGER NOT NULL DEFAULT 0,
+		last_status TEXT NOT NULL DEFAULT '',
+		last_error TEXT NOT NULL DEFAULT '',
+		running_until TEXT NOT NULL DEFAULT '',
+		updated_by TEXT NOT NULL DEFAULT '',
+		updated_at TEXT NOT NULL DEFAULT ''
+	);
+	`)
+	return err
+}
+
+// --- Migration 74: Guardian-signed waivers ---
+// A waiver now records who signed (the member or a parent/guardian for a minor), the drawn
+// signature as a PNG data URL, and an HTML snapshot of the signed document with its SHA-256
+// so it can be produced unaltered for an insurance audit. Older rows keep empty values.
+func migrate74(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE waiver ADD COLUMN signer_name TEXT NOT NULL DEFAULT '';
+	ALTER TABLE waiver ADD COLUMN signature_image TEXT NOT NULL DEFAULT '';
+	ALTER TABLE waiver ADD COLUMN guardian_name TEXT NOT NULL DEFAULT '';
+	ALTER TABLE waiver ADD COLUMN guardian_relationship TEXT NOT NULL DEFAULT '';
+	ALTER TABLE waiver ADD COLUMN guardian_email TEXT NOT NULL DEFAULT '';
+	ALTER TABLE waiver ADD COLUMN guardian_phone TEXT NOT NULL DEFAULT '';
+	ALTER TABLE waiver ADD COLUMN document TEXT NOT NULL DEFAULT '';
+	ALTER TABLE waiver ADD COLUMN document_hash TEXT NOT NULL DEFAULT '';
+	`)
+	return err
+}
+
+// --- Migration 75: Schedule exceptions ---
+// A class occurrence change can now move one date's class to another time or mat, or add a
+// one-off session. An added session keeps its own class type, times, location and mat here
+// and uses its own ID as schedule_id, so the (schedule_id, class_date) key still holds.
+func migrate75(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE class_occurrence_change ADD COLUMN class_type_id TEXT NOT NULL DEFAULT '';
+	ALTER TABLE class_occurrence_change ADD COLUMN start_time TEXT NOT NULL DEFAULT '';
+	ALTER TABLE class_occurrence_change ADD COLUMN end_time TEXT NOT NULL DEFAULT '';
+	ALTER TABLE class_occurrence_change ADD COLUMN location_id TEXT NOT NULL DEFAULT '';
+	ALTER TABLE class_occurrence_change ADD COLUMN mat TEXT NOT NULL DEFAULT '';
+	`)
+	return err
+}
+
+// --- Migration 76: Term report deliveries ---
+// One row per kid whose term report has been emailed to their guardian, so the end-of-term
+// run sends each report once.
+func migrate76(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS term_report_delivery (
+		term_id TEXT NOT NULL,
+		member_id TEXT NOT NULL,
+		recipient TEXT NOT NULL,
+		sent_by TEXT NOT NULL DEFAULT '',
+		sent_at TEXT NOT NULL,
+		PRIMARY KEY (term_id, member_id),
+		FOREIGN KEY (term_id) REFERENCES term(id) ON DELETE CASCADE
+	);
+	`)
+	return err
+}
+
+// --- Migration 77: Topic vote rules ---
+// A rotor can limit members to one active vote per theme, expire votes after some weeks and
+// weight coaches' votes. Each vote keeps its theme, weight and expiry from when it was cast;
+// existing votes count once and never expire, as before.
+func migrate77(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE rotor ADD COLUMN vote_one_per_theme INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE rotor ADD COLUMN vote_expiry_weeks INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE rotor ADD COLUMN coach_vote_weight INTEGER NOT NULL DEFAULT 1;
+	ALTER TABLE vote ADD COLUMN rotor_theme_id TEXT NOT NULL DEFAULT '';
+	ALTER TABLE vote ADD COLUMN weight INTEGER NOT NULL DEFAULT 1;
+	ALTER TABLE vote ADD COLUMN expires_at TEXT NOT NULL DEFAULT '';
+	UPDATE vote SET rotor_theme_id = COALESCE((SELECT rotor_theme_id FROM topic WHERE topic.id = vote.topic_id), '');
+	CREATE INDEX IF NOT EXISTS idx_vote_account ON vote(account_id);
+	`)
+	return err
+}
+
+// --- Migration 78: Account email changes ---
+// A request to change an account's email waits here until the link sent to the new address
+// is confirmed. A newer request or a confirmation marks the account's other rows used.
+func migrate78(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS account_email_change (
+		id TEXT PRIMARY KEY,
+		account_id TEXT NOT NULL,
+		old_email TEXT NOT NULL,
+		new_email TEXT NOT NULL,
+		token TEXT NOT NULL UNIQUE,
+		expires_at TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		used INTEGER NOT NULL DEFAULT 0,
+		confirmed_at TEXT NOT NULL DEFAULT '',
+		FOREIGN KEY (account_id) REFERENCES account(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_account_email_change_account ON account_email_change(account_id);
+	`)
+	return err
+}
+
+// --- Migration 79: Class feedback ---
+// One 1-5 rating per attendance. Rows point at the attendance, not the member, and copy the
+// class's schedule, class type, regular coach and date so reports don't need to look them up.
+func migrate79(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS class_feedback (
+		id TEXT PRIMARY KEY,
+		attendance_id TEXT NOT NULL UNIQUE,
+		schedule_id TEXT NOT NULL,
+		class_type_id TEXT NOT NULL,
+		coach_id TEXT NOT NULL DEFAULT '',
+		class_date TEXT NOT NULL,
+		score INTEGER NOT NULL CHECK (score BETWEEN 1 AND 5),
+		comment TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL,
+		FOREIGN KEY (attendance_id) REFERENCES attendance(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_class_feedback_date ON class_feedback(class_date);
+	`)
+	return err
+}
+
+// --- Migration 80: Account impersonation limit ---
+// impersonation_expires_at ends an admin's session as another account (empty = role-only DevMode
+// or not impersonating); the admin's own identity is restored when it passes.
+func migrate80(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE auth_session ADD COLUMN impersonation_expires_at TEXT NOT NULL DEFAULT '';
+	`)
+	return err
+}
+
+// --- Migration 81: Notice status index ---
+// Expired notices are archived by the notice_cleanup job, so the published list the
+// dashboard and kiosk read stays small; the index serves those status and type lookups.
+func migrate81(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE INDEX IF NOT EXISTS idx_notice_status_type ON notice(status, type);
+	`)
+	return err
+}
+
+// --- Migration 82: Celebrations ---
+// A member's date of birth and join date drive birthday and training anniversary
+// celebrations; empty dates are unknown, and opt_out keeps the member off them entirely.
+// celebration_email_sent records each celebration emailed so a rerun never sends twice.
+func migrate82(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS celebration_profile (
+		member_id TEXT PRIMARY KEY,
+		date_of_birth TEXT NOT NULL DEFAULT '',
+		joined_on TEXT NOT NULL DEFAULT '',
+		opt_out INTEGER NOT NULL DEFAULT 0,
+		updated_by TEXT NOT NULL DEFAULT '',
+		updated_at TEXT NOT NULL,
+		FOREIGN KEY (member_id) REFERENCES member(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS celebration_email_sent (
+		member_id TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		celebration_date TEXT NOT NULL,
+		sent_at TEXT NOT NULL,
+		PRIMARY KEY (member_id, kind, celebration_date),
+		FOREIGN KEY (member_id) REFERENCES member(id) ON DELETE CASCADE
+	);
+	`)
+	return err
+}
+
+// --- Migration 83: Shareable session log notes ---
+// shareable marks a session log whose notes members may read in the curriculum history.
+func migrate83(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	ALTER TABLE session_log ADD COLUMN shareable INTEGER NOT NULL DEFAULT 0;
+	`)
+	return err
+}
+
+// --- Migration 84: Member contact checks ---
+// member_contact_check asks a member to confirm their email at the kiosk after mail to it
+// bounced; one row per member, replaced when an admin asks again.
+func migrate84(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS member_contact_check (
+		member_id TEXT PRIMARY KEY,
+		address TEXT NOT NULL,
+		requested_by TEXT NOT NULL DEFAULT '',
+		requested_at TEXT NOT NULL,
+		resolution TEXT NOT NULL DEFAULT '',
+		new_address TEXT NOT NULL DEFAULT '',
+		resolved_at TEXT NOT NULL DEFAULT '',
+		FOREIGN KEY (member_id) REFERENCES member(id) ON DELETE CASCADE
+	);
+	`)
+	return err
+}
+
+// --- Migration 85: Class packs ---
+// class_pack is a set of classes a member paid for up front; class_pack_use records which
+// check-in took each class, so undoing the check-in gives it back.
+func migrate85(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS class_pack (
+		id TEXT PRIMARY KEY,
+		member_id TEXT NOT NULL,
+		classes INTEGER NOT NULL,
+		used INTEGER NOT NULL DEFAULT 0,
+		price_cents INTEGER NOT NULL DEFAULT 0,
+		purchased_at TEXT NOT NULL,
+		expires_on TEXT NOT NULL DEFAULT '',
+		created_by TEXT NOT NULL DEFAULT '',
+		note TEXT NOT NULL DEFAULT '',
+		FOREIGN KEY (member_id) REFERENCES member(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_class_pack_member ON class_pack(member_id);
+
+	CREATE TABLE IF NOT EXISTS class_pack_use (
+		attendance_id TEXT PRIMARY KEY,
+		pack_id TEXT NOT NULL,
+		member_id TEXT NOT NULL,
+		used_at TEXT NOT NULL,
+		FOREIGN KEY (pack_id) REFERENCES class_pack(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_class_pack_use_pack ON class_pack_use(pack_id);
+	`)
+	return err
+}
+
+// --- Migration 86: Observation quick captures ---
+// observation_capture holds notes coaches jot down mid-class by member name. A note whose
+// name matched no single member waits as queued until the coach chooses one.
+func migrate86(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	CREATE TABLE IF NOT EXISTS observation_capture (
+		id TEXT PRIMARY KEY,
+		author_id TEXT NOT NULL,
+		client_id TEXT NOT NULL,
+		member_ref TEXT NOT NULL,
+		content TEXT NOT NULL,
+		captured_at TEXT NOT NULL,
+		status TEXT NOT NULL,
+		member_id TEXT NOT NULL DEFAULT '',
+		observation_id TEXT NOT NULL DEFAULT '',
+		created_at TEXT NOT NULL,
+		resolved_at TEXT NOT NULL DEFAULT '',
+		UNIQUE (author_id, client_id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_observation_capture_author_status ON observation_capture(author_id, status);
+	`)
+	return err
+}
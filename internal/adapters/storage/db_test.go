@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	_ "modernc.org/sqlite"
 )
@@ -71,46 +72,111 @@ func normalizeSQL(s string) string {
 // expectedTables is the sorted list of tables after all migrations.
 var expectedTables = []string{
 	"account",
+	"account_email_change",
 	"activation_token",
 	"attendance",
+	"attendance_topic",
+	"auth_session",
+	"belt_inventory",
+	"belt_size",
+	"bugbox_comment",
 	"bugbox_submission",
 	"calendar_event",
+	"celebration_email_sent",
+	"celebration_profile",
+	"class_capacity_alert",
+	"class_feedback",
+	"class_occurrence_change",
+	"class_pack",
+	"class_pack_use",
+	"class_reminder_sent",
 	"class_type",
+	"class_type_approval",
+	"coach_availability",
+	"coach_availability_exception",
 	"coach_observation",
+	"coach_rate",
+	"coach_session_override",
+	"communication_preference",
 	"competition_interest",
 	"deletion_request",
 	"email",
+	"email_message_template",
 	"email_recipient",
+	"email_suppression",
 	"email_template",
 	"estimated_hours",
+	"estimated_hours_review",
 	"export_request",
 	"feature_flag",
+	"grading_ceremony",
 	"grading_config",
 	"grading_member_config",
 	"grading_note",
 	"grading_proposal",
+	"grading_proposal_comment",
 	"grading_record",
+	"grading_rule",
 	"holiday",
 	"injury",
+	"job",
+	"kiosk_device",
+	"kpi_snapshot",
+	"location",
 	"log_truncation_settings",
+	"makeup_credit",
 	"member",
+	"member_contact_check",
 	"member_milestone",
+	"member_segment",
+	"member_tag",
 	"message",
 	"milestone",
 	"notice",
+	"notification",
+	"notification_preference",
+	"observation_capture",
 	"outbox",
+	"perf_bucket",
+	"permission_override",
 	"personal_goal",
+	"personal_goal_annotation",
+	"personal_goal_check_in",
 	"program",
+	"push_subscription",
+	"reengagement_action",
+	"reengagement_rule",
+	"reengagement_suppression",
+	"referral",
+	"referral_settings",
 	"rotor",
 	"rotor_theme",
+	"rubric_score",
+	"rubric_template",
 	"schedule",
 	"schema_version",
+	"search_index", // FTS5 virtual table and its shadow tables
+	"search_index_config",
+	"search_index_content",
+	"search_index_data",
+	"search_index_docsize",
+	"search_index_idx",
+	"session_log",
+	"session_log_topic",
+	"shared_topic",
+	"status_change",
 	"term",
+	"term_readiness_snapshot",
+	"term_report_delivery",
+	"term_threshold",
 	"topic",
 	"topic_schedule",
 	"training_goal",
+	"visit",
+	"visitor",
 	"vote",
 	"waiver",
+	"weekly_digest_sent",
 }
 
 // TestMigrateDB_Fresh verifies all migrations apply cleanly to an empty database.
@@ -305,3 +371,113 @@ func TestMigrateDB_ExistingDB(t *testing.T) {
 		t.Errorf("version = %d, want %d", v, LatestSchemaVersion())
 	}
 }
+
+// TestMigrate57_ArchivesThemes verifies standalone themes move under an archived rotor with their
+// dates and belt gate, and stay unlinked when their program has no class type to hang them on.
+func TestMigrate57_ArchivesThemes(t *testing.T) {
+	db := openTestDB(t)
+	if err := MigrateDB(db, ":memory:"); err != nil {
+		t.Fatalf("MigrateDB failed: %v", err)
+	}
+	// A themes table as it stood after migration 50 gave it min_belt
+	if _, err := db.Exec(`
+	INSERT INTO account (id, email, role, created_at) VALUES ('coach1', 'coach@test.com', 'coach', '2025-01-01T00:00:00Z'), ('admin1', 'admin@test.com', 'admin', '2025-06-01T00:00:00Z');
+	INSERT INTO program (id, name, type) VALUES ('p1', 'Adults', 'adults');
+	INSERT INTO class_type (id, program_id, name) VALUES ('ct-nogi', 'p1', 'No-Gi'), ('ct-fund', 'p1', 'Fundamentals');
+	CREATE TABLE themes (id TEXT PRIMARY KEY, name TEXT NOT NULL, description TEXT NOT NULL DEFAULT '', program TEXT NOT NULL,
+		start_date DATETIME NOT NULL, end_date DATETIME NOT NULL, created_by TEXT NOT NULL DEFAULT '', min_belt TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP)`); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	insert := `INSERT INTO themes (id, name, description, program, start_date, end_date) VALUES (?, ?, ?, ?, ?, ?)`
+	if _, err := db.Exec(insert, "th-lasso", "Leg Lasso Series", "Lasso entries", "adults", start, start.AddDate(0, 0, 27)); err != nil {
+		t.Fatalf("insert theme: %v", err)
+	}
+	if _, err := db.Exec(insert, "th-kids", "Animal Drills", "", "kids", start, start.AddDate(0, 0, 27)); err != nil {
+		t.Fatalf("insert theme: %v", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if err := migrate57(tx); err != nil {
+		tx.Rollback()
+		t.Fatalf("migrate57: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	var classType, status, createdBy string
+	var version int
+	if err := db.QueryRow(`SELECT class_type_id, status, version, created_by FROM rotor WHERE id = 'theme-archive-adults'`).Scan(&classType, &status, &version, &createdBy); err != nil {
+		t.Fatalf("archive rotor: %v", err)
+	}
+	if classType != "ct-fund" || status != "archived" || version != 0 || createdBy != "admin1" {
+		t.Errorf("archive rotor = %s %s v%d by %s, want ct-fund archived v0 by admin1", classType, status, version, createdBy)
+	}
+	var topic string
+	var weeks int
+	var from, to, schedStatus string
+	if err := db.QueryRow(`SELECT tp.name, tp.duration_weeks, ts.start_date, ts.end_date, ts.status FROM topic tp
+		JOIN topic_schedule ts ON ts.topic_id = tp.id WHERE tp.rotor_theme_id = 'theme-archive-th-lasso'`).Scan(&topic, &weeks, &from, &to, &schedStatus); err != nil {
+		t.Fatalf("archived topic: %v", err)
+	}
+	if topic != "Leg Lasso Series" || weeks != 4 || from != "2025-09-01T00:00:00Z" || to != "2025-09-28T00:00:00Z" || schedStatus != "completed" {
+		t.Errorf("archived topic = %s %dw %s..%s %s", topic, weeks, from, to, schedStatus)
+	}
+
+	links := map[string]string{}
+	rows, err := db.Query(`SELECT id, rotor_theme_id FROM themes`)
+	if err != nil {
+		t.Fatalf("themes: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, link string
+		rows.Scan(&id, &link)
+		links[id] = link
+	}
+	if links["th-lasso"] != "theme-archive-th-lasso" || links["th-kids"] != "" {
+		t.Errorf("links = %v, want only the adults theme linked", links)
+	}
+}
+
+// TestAddStoreColumn verifies a store-owned table gains a missing column once, and a table its
+// store has not created yet is left alone.
+func TestAddStoreColumn(t *testing.T) {
+	db := openTestDB(t)
+	if _, err := db.Exec(`CREATE TABLE clip_tags (id TEXT PRIMARY KEY, name TEXT NOT NULL UNIQUE);
+		INSERT INTO clip_tags (id, name) VALUES ('t1', 'guard')`); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := addStoreColumn(tx, "clip_tags", "category", "TEXT NOT NULL DEFAULT 'general'"); err != nil {
+			tx.Rollback()
+			t.Fatalf("addStoreColumn pass %d: %v", i+1, err)
+		}
+	}
+	if err := addStoreColumn(tx, "clips", "min_belt", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		tx.Rollback()
+		t.Fatalf("addStoreColumn on a missing table: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	var category string
+	if err := db.QueryRow(`SELECT category FROM clip_tags WHERE id = 't1'`).Scan(&category); err != nil || category != "general" {
+		t.Errorf("category = %q, %v; want general", category, err)
+	}
+	var clips int
+	db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'clips'`).Scan(&clips)
+	if clips != 0 {
+		t.Error("clips table was created")
+	}
+}
@@ -0,0 +1,101 @@
+package email
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/email"
+)
+
+// LibrarySQLiteStore implements LibraryStore using SQLite.
+type LibrarySQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewLibrarySQLiteStore creates a new LibrarySQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new LibrarySQLiteStore instance
+func NewLibrarySQLiteStore(db storage.SQLDB) *LibrarySQLiteStore {
+	return &LibrarySQLiteStore{db: db}
+}
+
+// libraryColumns is the shared column list for email_message_template SELECTs; order matches scanMessageTemplate.
+const libraryColumns = "id, key, name, category, subject, body, is_default, updated_by, updated_at"
+
+// Save inserts or replaces the template with t.Key, keeping the first ID. A default
+// template takes over as its category's default.
+// PRE: t has been validated
+// POST: The template is persisted; no other template in its category is the default
+func (s *LibrarySQLiteStore) Save(ctx context.Context, t domain.MessageTemplate) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if t.Default {
+		if _, err := tx.ExecContext(ctx, `UPDATE email_message_template SET is_default = 0 WHERE category = ? AND key != ?`, t.Category, t.Key); err != nil {
+			return err
+		}
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO email_message_template (`+libraryColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(key) DO UPDATE SET
+		   name=excluded.name, category=excluded.category, subject=excluded.subject, body=excluded.body,
+		   is_default=excluded.is_default, updated_by=excluded.updated_by, updated_at=excluded.updated_at`,
+		t.ID, t.Key, t.Name, t.Category, t.Subject, t.Body, t.Default, t.UpdatedBy, t.UpdatedAt.Format(timeLayout))
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetByKey retrieves the saved template with a key.
+// PRE: key is non-empty
+// POST: Returns the template or sql.ErrNoRows
+func (s *LibrarySQLiteStore) GetByKey(ctx context.Context, key string) (domain.MessageTemplate, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+libraryColumns+" FROM email_message_template WHERE key = ?", key)
+	return scanMessageTemplate(row.Scan)
+}
+
+// List returns every saved template by name.
+// PRE: none
+// POST: Returns templates or an empty slice
+func (s *LibrarySQLiteStore) List(ctx context.Context) ([]domain.MessageTemplate, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+libraryColumns+" FROM email_message_template ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.MessageTemplate
+	for rows.Next() {
+		t, err := scanMessageTemplate(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, t)
+	}
+	return list, rows.Err()
+}
+
+// DeleteByKey removes a saved template; a built-in key goes back to the system's wording.
+// PRE: key is non-empty
+// POST: No template with key is stored
+func (s *LibrarySQLiteStore) DeleteByKey(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM email_message_template WHERE key = ?`, key)
+	return err
+}
+
+// scanMessageTemplate reads one row in libraryColumns order.
+func scanMessageTemplate(scan func(dest ...any) error) (domain.MessageTemplate, error) {
+	var t domain.MessageTemplate
+	var updatedAt string
+	if err := scan(&t.ID, &t.Key, &t.Name, &t.Category, &t.Subject, &t.Body, &t.Default, &t.UpdatedBy, &updatedAt); err != nil {
+		return domain.MessageTemplate{}, err
+	}
+	t.UpdatedAt, _ = time.Parse(timeLayout, updatedAt)
+	_, t.BuiltIn = domain.BuiltInTemplate(t.Key)
+	return t, nil
+}
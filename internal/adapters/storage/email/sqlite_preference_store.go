@@ -0,0 +1,108 @@
+package email
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/email"
+)
+
+// PreferenceSQLiteStore implements PreferenceStore using SQLite.
+type PreferenceSQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewPreferenceSQLiteStore creates a new PreferenceSQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new PreferenceSQLiteStore instance
+func NewPreferenceSQLiteStore(db storage.SQLDB) *PreferenceSQLiteStore {
+	return &PreferenceSQLiteStore{db: db}
+}
+
+// GetByMemberID retrieves a member's saved preferences.
+// PRE: memberID is non-empty
+// POST: Returns the preferences, or sql.ErrNoRows if the member never changed them
+func (s *PreferenceSQLiteStore) GetByMemberID(ctx context.Context, memberID string) (domain.Preferences, error) {
+	var p domain.Preferences
+	var updatedAt string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT member_id, announcements, grading, billing, digest, updated_at
+		 FROM communication_preference WHERE member_id = ?`, memberID).
+		Scan(&p.MemberID, &p.Announcements, &p.Grading, &p.Billing, &p.Digest, &updatedAt)
+	if err != nil {
+		return domain.Preferences{}, err
+	}
+	p.UpdatedAt, _ = time.Parse(timeLayout, updatedAt)
+	return p, nil
+}
+
+// Save upserts a member's preferences.
+// PRE: p.MemberID is non-empty
+// POST: The preferences are persisted
+func (s *PreferenceSQLiteStore) Save(ctx context.Context, p domain.Preferences) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO communication_preference (member_id, announcements, grading, billing, digest, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(member_id) DO UPDATE SET
+		   announcements=excluded.announcements, grading=excluded.grading,
+		   billing=excluded.billing, digest=excluded.digest, updated_at=excluded.updated_at`,
+		p.MemberID, p.Announcements, p.Grading, p.Billing, p.Digest, p.UpdatedAt.Format(timeLayout))
+	return err
+}
+
+// CountUnsubscribed counts the members opted out of each optional category.
+// PRE: none
+// POST: Returns a count for every optional category, zero included
+func (s *PreferenceSQLiteStore) CountUnsubscribed(ctx context.Context) (map[string]int, error) {
+	var announcements, grading, billing, digest int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(announcements = 0), 0), COALESCE(SUM(grading = 0), 0), COALESCE(SUM(billing = 0), 0),
+		        COALESCE(SUM(digest = 0), 0)
+		 FROM communication_preference`).
+		Scan(&announcements, &grading, &billing, &digest)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int{
+		domain.CategoryAnnouncements: announcements,
+		domain.CategoryGrading:       grading,
+		domain.CategoryBilling:       billing,
+		domain.CategoryDigest:        digest,
+	}, nil
+}
+
+// ListDigestSubscribers returns the members who opted in to the weekly digest.
+// PRE: none
+// POST: Returns member IDs in no particular order
+func (s *PreferenceSQLiteStore) ListDigestSubscribers(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT member_id FROM communication_preference WHERE digest = 1`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// MarkDigestSent records that a member was sent the digest for one week.
+// PRE: memberID and weekStart (YYYY-MM-DD, a Monday) are non-empty
+// POST: Returns true if this is the first digest for the week, false if one was already recorded
+func (s *PreferenceSQLiteStore) MarkDigestSent(ctx context.Context, memberID, weekStart string, sentAt time.Time) (bool, error) {
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO weekly_digest_sent (member_id, week_start, sent_at) VALUES (?, ?, ?)
+		 ON CONFLICT(member_id, week_start) DO NOTHING`,
+		memberID, weekStart, sentAt.Format(timeLayout))
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
@@ -27,7 +27,7 @@ func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
 func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Email, error) {
 	row := s.db.QueryRowContext(ctx,
 		`SELECT id, subject, body, sender_id, status, scheduled_at, sent_at,
-		        created_at, updated_at, resend_message_id, template_version_id
+		        created_at, updated_at, resend_message_id, template_version_id, category, template_key
 		 FROM email WHERE id = ?`, id)
 	return scanEmail(row)
 }
@@ -38,17 +38,18 @@ func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Email, err
 func (s *SQLiteStore) Save(ctx context.Context, e domain.Email) error {
 	_, err := s.db.ExecContext(ctx,
 		`INSERT INTO email (id, subject, body, sender_id, status, scheduled_at, sent_at,
-		                    created_at, updated_at, resend_message_id, template_version_id)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		                    created_at, updated_at, resend_message_id, template_version_id, category, template_key)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		 ON CONFLICT(id) DO UPDATE SET
 		   subject=excluded.subject, body=excluded.body, sender_id=excluded.sender_id,
 		   status=excluded.status, scheduled_at=excluded.scheduled_at, sent_at=excluded.sent_at,
 		   created_at=excluded.created_at, updated_at=excluded.updated_at,
-		   resend_message_id=excluded.resend_message_id, template_version_id=excluded.template_version_id`,
+		   resend_message_id=excluded.resend_message_id, template_version_id=excluded.template_version_id,
+		   category=excluded.category, template_key=excluded.template_key`,
 		e.ID, e.Subject, e.Body, e.SenderID, e.Status,
 		nullTime(e.ScheduledAt), nullTime(e.SentAt),
 		e.CreatedAt.Format(timeLayout), nullTime(e.UpdatedAt),
-		nullStr(e.ResendMessageID), nullStr(e.TemplateVersionID))
+		nullStr(e.ResendMessageID), nullStr(e.TemplateVersionID), e.CategoryFor(), e.TemplateKey)
 	return err
 }
 
@@ -65,7 +66,7 @@ func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
 // POST: Returns matching emails sorted by created_at DESC
 func (s *SQLiteStore) List(ctx context.Context, filter ListFilter) ([]domain.Email, error) {
 	query := `SELECT id, subject, body, sender_id, status, scheduled_at, sent_at,
-	                 created_at, updated_at, resend_message_id, template_version_id
+	                 created_at, updated_at, resend_message_id, template_version_id, category, template_key
 	          FROM email WHERE 1=1`
 	var args []interface{}
 
@@ -92,6 +93,10 @@ func (s *SQLiteStore) List(ctx context.Context, filter ListFilter) ([]domain.Ema
 	return scanEmails(rows)
 }
 
+// recipientColumns is the column list scanned by scanRecipient.
+const recipientColumns = `email_id, member_id, member_name, member_email, delivery_status,
+	resend_message_id, delivery_updated_at, opened_at, clicked_at, bounce_reason`
+
 // SaveRecipients saves the recipient list for an email, replacing any existing.
 // PRE: emailID exists
 // POST: Recipients are persisted
@@ -107,15 +112,17 @@ func (s *SQLiteStore) SaveRecipients(ctx context.Context, emailID string, recipi
 	}
 
 	stmt, err := tx.PrepareContext(ctx,
-		`INSERT INTO email_recipient (email_id, member_id, member_name, member_email, delivery_status)
-		 VALUES (?, ?, ?, ?, ?)`)
+		`INSERT INTO email_recipient (`+recipientColumns+`)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
 	for _, r := range recipients {
-		if _, err := stmt.ExecContext(ctx, r.EmailID, r.MemberID, r.MemberName, r.MemberEmail, r.DeliveryStatus); err != nil {
+		if _, err := stmt.ExecContext(ctx, r.EmailID, r.MemberID, r.MemberName, r.MemberEmail, r.DeliveryStatus,
+			r.ResendMessageID, formatOptionalTime(r.DeliveryUpdatedAt), formatOptionalTime(r.OpenedAt),
+			formatOptionalTime(r.ClickedAt), r.BounceReason); err != nil {
 			return err
 		}
 	}
@@ -128,8 +135,54 @@ func (s *SQLiteStore) SaveRecipients(ctx context.Context, emailID string, recipi
 // POST: Returns recipient list
 func (s *SQLiteStore) GetRecipients(ctx context.Context, emailID string) ([]domain.Recipient, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT email_id, member_id, member_name, member_email, delivery_status
-		 FROM email_recipient WHERE email_id = ?`, emailID)
+		`SELECT `+recipientColumns+` FROM email_recipient WHERE email_id = ?`, emailID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipients []domain.Recipient
+	for rows.Next() {
+		r, err := scanRecipient(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, r)
+	}
+	return recipients, rows.Err()
+}
+
+// GetRecipientByMessageID finds the recipient whose copy was sent with the given provider message ID.
+// PRE: messageID is non-empty
+// POST: Returns the recipient or sql.ErrNoRows
+func (s *SQLiteStore) GetRecipientByMessageID(ctx context.Context, messageID string) (domain.Recipient, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+recipientColumns+` FROM email_recipient WHERE resend_message_id = ? LIMIT 1`, messageID)
+	return scanRecipient(row.Scan)
+}
+
+// UpdateRecipientDelivery persists a recipient's delivery tracking fields.
+// PRE: the recipient row (EmailID, MemberID) exists
+// POST: Delivery status, timestamps and bounce reason are updated
+func (s *SQLiteStore) UpdateRecipientDelivery(ctx context.Context, r domain.Recipient) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE email_recipient
+		 SET delivery_status = ?, delivery_updated_at = ?, opened_at = ?, clicked_at = ?, bounce_reason = ?
+		 WHERE email_id = ? AND member_id = ?`,
+		r.DeliveryStatus, formatOptionalTime(r.DeliveryUpdatedAt), formatOptionalTime(r.OpenedAt),
+		formatOptionalTime(r.ClickedAt), r.BounceReason, r.EmailID, r.MemberID)
+	return err
+}
+
+// ListUndeliveredRecipients returns every recipient whose copy bounced, drew a spam complaint
+// or was rejected by the provider, for the bad contact details report.
+// PRE: none
+// POST: Returns recipients newest failure first, or an empty slice
+func (s *SQLiteStore) ListUndeliveredRecipients(ctx context.Context) ([]domain.Recipient, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+recipientColumns+` FROM email_recipient WHERE delivery_status IN (?, ?, ?)
+		 ORDER BY delivery_updated_at DESC`,
+		domain.DeliveryBounced, domain.DeliveryComplained, domain.DeliveryFailed)
 	if err != nil {
 		return nil, err
 	}
@@ -137,8 +190,8 @@ func (s *SQLiteStore) GetRecipients(ctx context.Context, emailID string) ([]doma
 
 	var recipients []domain.Recipient
 	for rows.Next() {
-		var r domain.Recipient
-		if err := rows.Scan(&r.EmailID, &r.MemberID, &r.MemberName, &r.MemberEmail, &r.DeliveryStatus); err != nil {
+		r, err := scanRecipient(rows.Scan)
+		if err != nil {
 			return nil, err
 		}
 		recipients = append(recipients, r)
@@ -146,13 +199,82 @@ func (s *SQLiteStore) GetRecipients(ctx context.Context, emailID string) ([]doma
 	return recipients, rows.Err()
 }
 
+func scanRecipient(scan func(dest ...interface{}) error) (domain.Recipient, error) {
+	var r domain.Recipient
+	var updatedAt, openedAt, clickedAt string
+	if err := scan(&r.EmailID, &r.MemberID, &r.MemberName, &r.MemberEmail, &r.DeliveryStatus,
+		&r.ResendMessageID, &updatedAt, &openedAt, &clickedAt, &r.BounceReason); err != nil {
+		return domain.Recipient{}, err
+	}
+	r.DeliveryUpdatedAt = parseOptionalTime(updatedAt)
+	r.OpenedAt = parseOptionalTime(openedAt)
+	r.ClickedAt = parseOptionalTime(clickedAt)
+	return r, nil
+}
+
+// --- Suppression list ---
+
+// SaveSuppression adds an address to the suppression list. Re-suppressing keeps the original entry.
+// PRE: sup.Address is normalized and non-empty
+// POST: The address is suppressed
+func (s *SQLiteStore) SaveSuppression(ctx context.Context, sup domain.Suppression) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO email_suppression (address, reason, email_id, detail, created_at)
+		 VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT(address) DO NOTHING`,
+		sup.Address, sup.Reason, sup.EmailID, sup.Detail, sup.CreatedAt.Format(timeLayout))
+	return err
+}
+
+// IsSuppressed reports whether an address is on the suppression list.
+// PRE: address is normalized
+// POST: Returns true if suppressed
+func (s *SQLiteStore) IsSuppressed(ctx context.Context, address string) (bool, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM email_suppression WHERE address = ?`, address).Scan(&count)
+	return count > 0, err
+}
+
+// ListSuppressions returns all suppressed addresses, newest first.
+// PRE: none
+// POST: Returns suppressions or an empty slice
+func (s *SQLiteStore) ListSuppressions(ctx context.Context) ([]domain.Suppression, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT address, reason, email_id, detail, created_at FROM email_suppression ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.Suppression
+	for rows.Next() {
+		var sup domain.Suppression
+		var createdAt string
+		if err := rows.Scan(&sup.Address, &sup.Reason, &sup.EmailID, &sup.Detail, &createdAt); err != nil {
+			return nil, err
+		}
+		sup.CreatedAt, _ = time.Parse(timeLayout, createdAt)
+		result = append(result, sup)
+	}
+	return result, rows.Err()
+}
+
+// DeleteSuppression removes an address from the suppression list.
+// PRE: address is normalized
+// POST: The address can be emailed again
+func (s *SQLiteStore) DeleteSuppression(ctx context.Context, address string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM email_suppression WHERE address = ?`, address)
+	return err
+}
+
 // ListByRecipientMemberID retrieves emails sent to a specific member.
 // PRE: memberID is non-empty
 // POST: Returns emails for the member's inbox, sorted by created_at DESC
 func (s *SQLiteStore) ListByRecipientMemberID(ctx context.Context, memberID string) ([]domain.Email, error) {
 	rows, err := s.db.QueryContext(ctx,
 		`SELECT e.id, e.subject, e.body, e.sender_id, e.status, e.scheduled_at, e.sent_at,
-		        e.created_at, e.updated_at, e.resend_message_id, e.template_version_id
+		        e.created_at, e.updated_at, e.resend_message_id, e.template_version_id, e.category, e.template_key
 		 FROM email e
 		 JOIN email_recipient er ON e.id = er.email_id
 		 WHERE er.member_id = ? AND e.status = 'sent'
@@ -169,7 +291,7 @@ func scanEmail(row *sql.Row) (domain.Email, error) {
 	var scheduledAt, sentAt, updatedAt, resendID, templateID sql.NullString
 	var createdAt string
 	err := row.Scan(&e.ID, &e.Subject, &e.Body, &e.SenderID, &e.Status,
-		&scheduledAt, &sentAt, &createdAt, &updatedAt, &resendID, &templateID)
+		&scheduledAt, &sentAt, &createdAt, &updatedAt, &resendID, &templateID, &e.Category, &e.TemplateKey)
 	if err != nil {
 		return domain.Email{}, err
 	}
@@ -199,7 +321,7 @@ func scanEmails(rows *sql.Rows) ([]domain.Email, error) {
 		var scheduledAt, sentAt, updatedAt, resendID, templateID sql.NullString
 		var createdAt string
 		err := rows.Scan(&e.ID, &e.Subject, &e.Body, &e.SenderID, &e.Status,
-			&scheduledAt, &sentAt, &createdAt, &updatedAt, &resendID, &templateID)
+			&scheduledAt, &sentAt, &createdAt, &updatedAt, &resendID, &templateID, &e.Category, &e.TemplateKey)
 		if err != nil {
 			return nil, err
 		}
@@ -302,3 +424,18 @@ func nullTime(t time.Time) interface{} {
 	}
 	return t.Format(timeLayout)
 }
+
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(timeLayout)
+}
+
+func parseOptionalTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	t, _ := time.Parse(timeLayout, s)
+	return t
+}
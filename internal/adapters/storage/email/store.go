@@ -2,6 +2,7 @@ package email
 
 import (
 	"context"
+	"time"
 
 	domain "workshop/internal/domain/email"
 )
@@ -14,10 +15,17 @@ type Store interface {
 	List(ctx context.Context, filter ListFilter) ([]domain.Email, error)
 	SaveRecipients(ctx context.Context, emailID string, recipients []domain.Recipient) error
 	GetRecipients(ctx context.Context, emailID string) ([]domain.Recipient, error)
+	GetRecipientByMessageID(ctx context.Context, messageID string) (domain.Recipient, error)
+	UpdateRecipientDelivery(ctx context.Context, r domain.Recipient) error
+	ListUndeliveredRecipients(ctx context.Context) ([]domain.Recipient, error)
 	ListByRecipientMemberID(ctx context.Context, memberID string) ([]domain.Email, error)
 	SaveTemplate(ctx context.Context, t domain.EmailTemplate) error
 	GetActiveTemplate(ctx context.Context) (domain.EmailTemplate, error)
 	GetTemplateByID(ctx context.Context, id string) (domain.EmailTemplate, error)
+	SaveSuppression(ctx context.Context, s domain.Suppression) error
+	IsSuppressed(ctx context.Context, address string) (bool, error)
+	ListSuppressions(ctx context.Context) ([]domain.Suppression, error)
+	DeleteSuppression(ctx context.Context, address string) error
 }
 
 // ListFilter specifies criteria for listing emails.
@@ -26,3 +34,20 @@ type ListFilter struct {
 	SenderID string // Filter by sender (empty = all)
 	Search   string // Keyword search in subject/body (empty = all)
 }
+
+// PreferenceStore persists members' communication preferences.
+type PreferenceStore interface {
+	GetByMemberID(ctx context.Context, memberID string) (domain.Preferences, error)
+	Save(ctx context.Context, p domain.Preferences) error
+	CountUnsubscribed(ctx context.Context) (map[string]int, error)
+	ListDigestSubscribers(ctx context.Context) ([]string, error)
+	MarkDigestSent(ctx context.Context, memberID, weekStart string, sentAt time.Time) (bool, error)
+}
+
+// LibraryStore persists the email template library, one template per key.
+type LibraryStore interface {
+	Save(ctx context.Context, t domain.MessageTemplate) error
+	GetByKey(ctx context.Context, key string) (domain.MessageTemplate, error)
+	List(ctx context.Context) ([]domain.MessageTemplate, error)
+	DeleteByKey(ctx context.Context, key string) error
+}
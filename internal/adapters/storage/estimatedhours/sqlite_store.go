@@ -89,13 +89,27 @@ func (s *SQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]do
 	return scanEstimatedHoursRows(rows)
 }
 
-// ListPending returns all pending estimated hours entries, ordered by created_at asc.
+// ListPending returns entries still under review (pending, or waiting on the member), ordered by created_at asc.
 // PRE: none
-// POST: returns pending entries or empty slice
+// POST: returns pending and needs_info entries or empty slice
 func (s *SQLiteStore) ListPending(ctx context.Context) ([]domain.EstimatedHours, error) {
 	rows, err := s.db.QueryContext(ctx,
 		`SELECT id, member_id, start_date, end_date, weekly_hours, total_hours, source, status, note, created_by, created_at, reviewed_by, reviewed_at, review_note
-		 FROM estimated_hours WHERE status = 'pending' ORDER BY created_at ASC`)
+		 FROM estimated_hours WHERE status IN ('pending', 'needs_info') ORDER BY created_at ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanEstimatedHoursRows(rows)
+}
+
+// ListByDateRange returns estimated hours entries whose period overlaps two dates, ordered by start date.
+// PRE: startDate and endDate are YYYY-MM-DD
+// POST: returns overlapping entries of any status, or empty slice
+func (s *SQLiteStore) ListByDateRange(ctx context.Context, startDate string, endDate string) ([]domain.EstimatedHours, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, member_id, start_date, end_date, weekly_hours, total_hours, source, status, note, created_by, created_at, reviewed_by, reviewed_at, review_note
+		 FROM estimated_hours WHERE start_date <= ? AND end_date >= ? ORDER BY start_date ASC, member_id`, endDate, startDate)
 	if err != nil {
 		return nil, err
 	}
@@ -145,5 +159,39 @@ func (s *SQLiteStore) SumApprovedByMemberID(ctx context.Context, memberID string
 	return total.Float64, nil
 }
 
+// SaveReviewEvent appends a step to an entry's review history.
+// PRE: ev is a valid ReviewEvent for an existing entry
+// POST: event is persisted
+func (s *SQLiteStore) SaveReviewEvent(ctx context.Context, ev domain.ReviewEvent) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO estimated_hours_review (id, entry_id, actor_id, action, note, hours, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		ev.ID, ev.EntryID, ev.ActorID, ev.Action, ev.Note, ev.Hours, formatTime(ev.CreatedAt))
+	return err
+}
+
+// ListReviewEvents returns an entry's review history, oldest first.
+// PRE: entryID is non-empty
+// POST: returns events or empty slice
+func (s *SQLiteStore) ListReviewEvents(ctx context.Context, entryID string) ([]domain.ReviewEvent, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, entry_id, actor_id, action, note, hours, created_at
+		 FROM estimated_hours_review WHERE entry_id = ? ORDER BY created_at ASC, rowid ASC`, entryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []domain.ReviewEvent
+	for rows.Next() {
+		var ev domain.ReviewEvent
+		var createdAt string
+		if err := rows.Scan(&ev.ID, &ev.EntryID, &ev.ActorID, &ev.Action, &ev.Note, &ev.Hours, &createdAt); err != nil {
+			return nil, err
+		}
+		ev.CreatedAt = parseTime(createdAt)
+		result = append(result, ev)
+	}
+	return result, rows.Err()
+}
+
 // Verify interface compliance at compile time.
 var _ Store = (*SQLiteStore)(nil)
@@ -12,6 +12,9 @@ type Store interface {
 	GetByID(ctx context.Context, id string) (domain.EstimatedHours, error)
 	ListByMemberID(ctx context.Context, memberID string) ([]domain.EstimatedHours, error)
 	ListPending(ctx context.Context) ([]domain.EstimatedHours, error)
+	ListByDateRange(ctx context.Context, startDate string, endDate string) ([]domain.EstimatedHours, error)
 	Delete(ctx context.Context, id string) error
 	SumApprovedByMemberID(ctx context.Context, memberID string) (float64, error)
+	SaveReviewEvent(ctx context.Context, ev domain.ReviewEvent) error
+	ListReviewEvents(ctx context.Context, entryID string) ([]domain.ReviewEvent, error)
 }
@@ -0,0 +1,130 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	storage "workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/export"
+)
+
+// requestColumns is the shared column list for export_request SELECTs; order matches scanRequest.
+const requestColumns = `id, member_id, status, format, requested_at, completed_at, downloaded_at,
+	expired_at, file_path, file_size, ip_address, user_agent`
+
+type sqliteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore returns a Store backed by SQLite.
+func NewSQLiteStore(db storage.SQLDB) Store {
+	return &sqliteStore{db: db}
+}
+
+// GetByID retrieves an export request by its ID.
+// PRE: id is non-empty
+// POST: Returns the request or sql.ErrNoRows
+func (s *sqliteStore) GetByID(ctx context.Context, id string) (domain.Request, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+requestColumns+` FROM export_request WHERE id = ?`, id)
+	return scanRequest(row.Scan)
+}
+
+// Save persists an export request (insert or update).
+// PRE: entity has been validated
+// POST: Row upserted into export_request
+func (s *sqliteStore) Save(ctx context.Context, r domain.Request) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO export_request (`+requestColumns+`)
+		VALUES (?,?,?,?,?,?,?,?,?,?,?,?)
+		ON CONFLICT(id) DO UPDATE SET
+			status = excluded.status, completed_at = excluded.completed_at,
+			downloaded_at = excluded.downloaded_at, expired_at = excluded.expired_at,
+			file_path = excluded.file_path, file_size = excluded.file_size`,
+		r.ID, r.MemberID, r.Status, r.Format, r.RequestedAt.UTC().Format(time.RFC3339),
+		nullableTime(r.CompletedAt), nullableTime(r.DownloadedAt), nullableTime(r.ExpiredAt),
+		r.FilePath, r.FileSize, r.IPAddress, r.UserAgent,
+	)
+	if err != nil {
+		return fmt.Errorf("export request save: %w", err)
+	}
+	return nil
+}
+
+// ListByMemberID returns a member's export requests, newest first.
+// PRE: memberID is non-empty, limit > 0
+// POST: Returns up to limit requests
+func (s *sqliteStore) ListByMemberID(ctx context.Context, memberID string, limit int) ([]domain.Request, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+requestColumns+` FROM export_request
+		WHERE member_id = ? ORDER BY requested_at DESC LIMIT ?`, memberID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanRequests(rows)
+}
+
+// ListExpired returns finished exports whose download window closed before now.
+// PRE: limit > 0
+// POST: Returns up to limit ready or downloaded requests, oldest expiry first
+func (s *sqliteStore) ListExpired(ctx context.Context, now time.Time, limit int) ([]domain.Request, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+requestColumns+` FROM export_request
+		WHERE status IN (?, ?) AND expired_at IS NOT NULL AND expired_at <= ?
+		ORDER BY expired_at ASC LIMIT ?`,
+		domain.StatusReady, domain.StatusDownloaded, now.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanRequests(rows)
+}
+
+// nullableTime formats an optional timestamp, or NULL when it is unset.
+func nullableTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// parseNullableTime parses an optional timestamp column.
+func parseNullableTime(v sql.NullString) *time.Time {
+	if !v.Valid || v.String == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, v.String)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// scanRequest scans one export_request row selected with requestColumns.
+func scanRequest(scan func(dest ...interface{}) error) (domain.Request, error) {
+	var r domain.Request
+	var requestedAt string
+	var completedAt, downloadedAt, expiredAt sql.NullString
+	if err := scan(&r.ID, &r.MemberID, &r.Status, &r.Format, &requestedAt, &completedAt, &downloadedAt,
+		&expiredAt, &r.FilePath, &r.FileSize, &r.IPAddress, &r.UserAgent); err != nil {
+		return domain.Request{}, err
+	}
+	r.RequestedAt, _ = time.Parse(time.RFC3339, requestedAt)
+	r.CompletedAt = parseNullableTime(completedAt)
+	r.DownloadedAt = parseNullableTime(downloadedAt)
+	r.ExpiredAt = parseNullableTime(expiredAt)
+	return r, nil
+}
+
+// scanRequests scans every row into a slice of requests.
+func scanRequests(rows *sql.Rows) ([]domain.Request, error) {
+	var list []domain.Request
+	for rows.Next() {
+		r, err := scanRequest(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, r)
+	}
+	return list, rows.Err()
+}
@@ -0,0 +1,31 @@
+package export
+
+import (
+	"context"
+	"time"
+
+	domain "workshop/internal/domain/export"
+)
+
+// Store defines the interface for data export request persistence.
+type Store interface {
+	// GetByID retrieves an export request by its ID.
+	// PRE: id is non-empty
+	// POST: Returns the request or sql.ErrNoRows
+	GetByID(ctx context.Context, id string) (domain.Request, error)
+
+	// Save persists an export request (insert or update).
+	// PRE: entity has been validated
+	// POST: Entity is persisted
+	Save(ctx context.Context, r domain.Request) error
+
+	// ListByMemberID returns a member's export requests, newest first.
+	// PRE: memberID is non-empty, limit > 0
+	// POST: Returns up to limit requests
+	ListByMemberID(ctx context.Context, memberID string, limit int) ([]domain.Request, error)
+
+	// ListExpired returns finished exports whose download window closed before now.
+	// PRE: limit > 0
+	// POST: Returns up to limit ready or downloaded requests, oldest expiry first
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]domain.Request, error)
+}
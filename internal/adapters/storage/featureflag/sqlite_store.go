@@ -3,12 +3,16 @@ package featureflag
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"workshop/internal/adapters/storage"
 	domain "workshop/internal/domain/featureflag"
 )
 
+const timeLayout = time.RFC3339
+
 // SQLiteStore implements Store using SQLite.
 type SQLiteStore struct {
 	db storage.SQLDB
@@ -25,7 +29,8 @@ func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
 // INVARIANT: Store state is not mutated
 func (s *SQLiteStore) GetByKey(ctx context.Context, key string) (domain.FeatureFlag, error) {
 	row := s.db.QueryRowContext(ctx, `
-		SELECT key, description, enabled_admin, enabled_coach, enabled_member, enabled_trial, beta_override
+		SELECT key, description, enabled_admin, enabled_coach, enabled_member, enabled_trial, beta_override,
+			rollout_percent, allow_accounts, deny_accounts, starts_at, ends_at
 		FROM feature_flag
 		WHERE key = ?
 	`, key)
@@ -38,7 +43,8 @@ func (s *SQLiteStore) GetByKey(ctx context.Context, key string) (domain.FeatureF
 // INVARIANT: Store state is not mutated
 func (s *SQLiteStore) List(ctx context.Context) ([]domain.FeatureFlag, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT key, description, enabled_admin, enabled_coach, enabled_member, enabled_trial, beta_override
+		SELECT key, description, enabled_admin, enabled_coach, enabled_member, enabled_trial, beta_override,
+			rollout_percent, allow_accounts, deny_accounts, starts_at, ends_at
 		FROM feature_flag
 		ORDER BY key
 	`)
@@ -70,6 +76,15 @@ func (s *SQLiteStore) Save(ctx context.Context, value domain.FeatureFlag) error
 		return err
 	}
 
+	allow, err := json.Marshal(nonNil(value.AllowAccounts))
+	if err != nil {
+		return err
+	}
+	deny, err := json.Marshal(nonNil(value.DenyAccounts))
+	if err != nil {
+		return err
+	}
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -80,15 +95,21 @@ func (s *SQLiteStore) Save(ctx context.Context, value domain.FeatureFlag) error
 		INSERT INTO feature_flag (
 			key, description,
 			enabled_admin, enabled_coach, enabled_member, enabled_trial,
-			beta_override
-		) VALUES (?, ?, ?, ?, ?, ?, ?)
+			beta_override,
+			rollout_percent, allow_accounts, deny_accounts, starts_at, ends_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(key) DO UPDATE SET
 			description=excluded.description,
 			enabled_admin=excluded.enabled_admin,
 			enabled_coach=excluded.enabled_coach,
 			enabled_member=excluded.enabled_member,
 			enabled_trial=excluded.enabled_trial,
-			beta_override=excluded.beta_override
+			beta_override=excluded.beta_override,
+			rollout_percent=excluded.rollout_percent,
+			allow_accounts=excluded.allow_accounts,
+			deny_accounts=excluded.deny_accounts,
+			starts_at=excluded.starts_at,
+			ends_at=excluded.ends_at
 	`,
 		value.Key,
 		value.Description,
@@ -97,6 +118,11 @@ func (s *SQLiteStore) Save(ctx context.Context, value domain.FeatureFlag) error
 		boolToInt(value.EnabledMember),
 		boolToInt(value.EnabledTrial),
 		boolToInt(value.BetaOverride),
+		value.RolloutPercent,
+		string(allow),
+		string(deny),
+		nullTime(value.StartsAt),
+		nullTime(value.EndsAt),
 	)
 	if err != nil {
 		return fmt.Errorf("save feature_flag: %w", err)
@@ -108,6 +134,8 @@ func (s *SQLiteStore) Save(ctx context.Context, value domain.FeatureFlag) error
 func scanFlag(scan func(dest ...any) error) (domain.FeatureFlag, error) {
 	var ff domain.FeatureFlag
 	var enabledAdmin, enabledCoach, enabledMember, enabledTrial, betaOverride int
+	var allow, deny string
+	var startsAt, endsAt sql.NullString
 	if err := scan(
 		&ff.Key,
 		&ff.Description,
@@ -116,6 +144,11 @@ func scanFlag(scan func(dest ...any) error) (domain.FeatureFlag, error) {
 		&enabledMember,
 		&enabledTrial,
 		&betaOverride,
+		&ff.RolloutPercent,
+		&allow,
+		&deny,
+		&startsAt,
+		&endsAt,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return domain.FeatureFlag{}, fmt.Errorf("feature flag not found: %w", err)
@@ -127,9 +160,35 @@ func scanFlag(scan func(dest ...any) error) (domain.FeatureFlag, error) {
 	ff.EnabledMember = enabledMember != 0
 	ff.EnabledTrial = enabledTrial != 0
 	ff.BetaOverride = betaOverride != 0
+	if err := json.Unmarshal([]byte(allow), &ff.AllowAccounts); err != nil {
+		return domain.FeatureFlag{}, fmt.Errorf("feature flag %s allow_accounts: %w", ff.Key, err)
+	}
+	if err := json.Unmarshal([]byte(deny), &ff.DenyAccounts); err != nil {
+		return domain.FeatureFlag{}, fmt.Errorf("feature flag %s deny_accounts: %w", ff.Key, err)
+	}
+	if startsAt.Valid {
+		ff.StartsAt, _ = time.Parse(timeLayout, startsAt.String)
+	}
+	if endsAt.Valid {
+		ff.EndsAt, _ = time.Parse(timeLayout, endsAt.String)
+	}
 	return ff, nil
 }
 
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(timeLayout)
+}
+
+func nonNil(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
+
 func boolToInt(v bool) int {
 	if v {
 		return 1
@@ -0,0 +1,105 @@
+package feedback
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/feedback"
+)
+
+const timeLayout = "2006-01-02T15:04:05Z07:00"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// Save inserts a rating.
+// PRE: value has been validated
+// POST: The rating is persisted; returns domain.ErrAlreadyRated if its attendance already has one
+func (s *SQLiteStore) Save(ctx context.Context, value domain.Rating) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO class_feedback (id, attendance_id, schedule_id, class_type_id, coach_id, class_date, score, comment, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		value.ID, value.AttendanceID, value.ScheduleID, value.ClassTypeID, value.CoachID, value.ClassDate,
+		value.Score, value.Comment, value.CreatedAt.Format(timeLayout))
+	if err != nil && strings.Contains(err.Error(), "UNIQUE constraint") {
+		return domain.ErrAlreadyRated
+	}
+	return err
+}
+
+// GetByAttendanceID retrieves the rating given for an attendance.
+// PRE: attendanceID is non-empty
+// POST: Returns the rating or sql.ErrNoRows if the class has not been rated
+func (s *SQLiteStore) GetByAttendanceID(ctx context.Context, attendanceID string) (domain.Rating, error) {
+	var r domain.Rating
+	var createdAt string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, attendance_id, schedule_id, class_type_id, coach_id, class_date, score, comment, created_at
+		 FROM class_feedback WHERE attendance_id = ?`, attendanceID).Scan(
+		&r.ID, &r.AttendanceID, &r.ScheduleID, &r.ClassTypeID, &r.CoachID, &r.ClassDate, &r.Score, &r.Comment, &createdAt)
+	if err != nil {
+		return domain.Rating{}, err
+	}
+	r.CreatedAt, _ = time.Parse(timeLayout, createdAt)
+	return r, nil
+}
+
+// List retrieves ratings, each with the rotor topics taught in its class.
+// PRE: filter dates, when set, are YYYY-MM-DD
+// POST: Returns matching ratings ordered by class date, then schedule
+func (s *SQLiteStore) List(ctx context.Context, filter ListFilter) ([]domain.Rating, error) {
+	query := `SELECT f.id, f.attendance_id, f.schedule_id, f.class_type_id, f.coach_id, f.class_date, f.score, f.comment, f.created_at,
+			COALESCE(GROUP_CONCAT(t.topic_id), '')
+		FROM class_feedback f LEFT JOIN attendance_topic t ON t.attendance_id = f.attendance_id
+		WHERE 1=1`
+	var args []interface{}
+	if filter.From != "" {
+		query += ` AND f.class_date >= ?`
+		args = append(args, filter.From)
+	}
+	if filter.To != "" {
+		query += ` AND f.class_date <= ?`
+		args = append(args, filter.To)
+	}
+	if filter.CoachID != "" {
+		query += ` AND f.coach_id = ?`
+		args = append(args, filter.CoachID)
+	}
+	query += ` GROUP BY f.id ORDER BY f.class_date, f.schedule_id, f.id`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ratings []domain.Rating
+	for rows.Next() {
+		var r domain.Rating
+		var createdAt string
+		var topics sql.NullString
+		if err := rows.Scan(&r.ID, &r.AttendanceID, &r.ScheduleID, &r.ClassTypeID, &r.CoachID, &r.ClassDate,
+			&r.Score, &r.Comment, &createdAt, &topics); err != nil {
+			return nil, err
+		}
+		r.CreatedAt, _ = time.Parse(timeLayout, createdAt)
+		if topics.String != "" {
+			r.TopicIDs = strings.Split(topics.String, ",")
+		}
+		ratings = append(ratings, r)
+	}
+	return ratings, rows.Err()
+}
+
+// Interface compliance
+var _ Store = (*SQLiteStore)(nil)
@@ -0,0 +1,21 @@
+package feedback
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/feedback"
+)
+
+// Store persists class feedback ratings.
+type Store interface {
+	Save(ctx context.Context, value domain.Rating) error
+	GetByAttendanceID(ctx context.Context, attendanceID string) (domain.Rating, error)
+	List(ctx context.Context, filter ListFilter) ([]domain.Rating, error)
+}
+
+// ListFilter carries filtering parameters for List operations.
+type ListFilter struct {
+	From    string // first class date, YYYY-MM-DD; empty = no lower bound
+	To      string // last class date, YYYY-MM-DD; empty = no upper bound
+	CoachID string // only classes this account coached; empty = every coach
+}
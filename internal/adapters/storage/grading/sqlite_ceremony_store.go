@@ -0,0 +1,94 @@
+package grading
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/grading"
+)
+
+// CeremonySQLiteStore implements CeremonyStore using SQLite.
+type CeremonySQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewCeremonySQLiteStore creates a new CeremonySQLiteStore.
+func NewCeremonySQLiteStore(db storage.SQLDB) *CeremonySQLiteStore {
+	return &CeremonySQLiteStore{db: db}
+}
+
+// ceremonyColumns is the shared column list for grading_ceremony SELECTs; order matches scanCeremony.
+const ceremonyColumns = "id, title, location, ceremony_date, event_id, notice_id, status, created_by, created_at, completed_by, completed_at"
+
+// Save inserts or updates a ceremony.
+// PRE: value has been validated
+// POST: The ceremony is persisted
+func (s *CeremonySQLiteStore) Save(ctx context.Context, value domain.Ceremony) error {
+	completedAt := ""
+	if !value.CompletedAt.IsZero() {
+		completedAt = value.CompletedAt.Format(timeLayout)
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO grading_ceremony (`+ceremonyColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET
+		   title=excluded.title, location=excluded.location, ceremony_date=excluded.ceremony_date,
+		   event_id=excluded.event_id, notice_id=excluded.notice_id, status=excluded.status,
+		   completed_by=excluded.completed_by, completed_at=excluded.completed_at`,
+		value.ID, value.Title, value.Location, value.Date.Format("2006-01-02"), value.EventID, value.NoticeID,
+		value.Status, value.CreatedBy, value.CreatedAt.Format(timeLayout), value.CompletedBy, completedAt)
+	return err
+}
+
+// GetByID retrieves a ceremony by its ID.
+// PRE: id is non-empty
+// POST: Returns the ceremony or sql.ErrNoRows
+func (s *CeremonySQLiteStore) GetByID(ctx context.Context, id string) (domain.Ceremony, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+ceremonyColumns+" FROM grading_ceremony WHERE id = ?", id)
+	return scanCeremony(row.Scan)
+}
+
+// GetByEventID retrieves the ceremony that owns a calendar event.
+// PRE: eventID is non-empty
+// POST: Returns the ceremony or sql.ErrNoRows when the event is not a ceremony
+func (s *CeremonySQLiteStore) GetByEventID(ctx context.Context, eventID string) (domain.Ceremony, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+ceremonyColumns+" FROM grading_ceremony WHERE event_id = ?", eventID)
+	return scanCeremony(row.Scan)
+}
+
+// List returns every ceremony, the most recent date first.
+// PRE: none
+// POST: Returns ceremonies or an empty slice
+func (s *CeremonySQLiteStore) List(ctx context.Context) ([]domain.Ceremony, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+ceremonyColumns+" FROM grading_ceremony ORDER BY ceremony_date DESC, created_at DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Ceremony
+	for rows.Next() {
+		c, err := scanCeremony(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+	return list, rows.Err()
+}
+
+// scanCeremony extracts a Ceremony from a row scanner function.
+func scanCeremony(scan func(dest ...interface{}) error) (domain.Ceremony, error) {
+	var c domain.Ceremony
+	var date, createdAt, completedAt string
+	if err := scan(&c.ID, &c.Title, &c.Location, &date, &c.EventID, &c.NoticeID, &c.Status,
+		&c.CreatedBy, &createdAt, &c.CompletedBy, &completedAt); err != nil {
+		return domain.Ceremony{}, err
+	}
+	c.Date, _ = time.Parse("2006-01-02", date)
+	c.CreatedAt, _ = time.Parse(timeLayout, createdAt)
+	if completedAt != "" {
+		c.CompletedAt, _ = time.Parse(timeLayout, completedAt)
+	}
+	return c, nil
+}
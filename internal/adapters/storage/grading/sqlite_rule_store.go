@@ -0,0 +1,90 @@
+package grading
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/grading"
+)
+
+// RuleSQLiteStore implements RuleStore using SQLite.
+type RuleSQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewRuleSQLiteStore creates a new RuleSQLiteStore.
+func NewRuleSQLiteStore(db storage.SQLDB) *RuleSQLiteStore {
+	return &RuleSQLiteStore{db: db}
+}
+
+// ruleColumns is the shared column list for grading_rule SELECTs; order matches scanRule.
+const ruleColumns = "id, program, belt, criteria, updated_by, updated_at"
+
+// Save inserts or replaces the rule for a program and belt, keeping the first ID.
+// PRE: value has been validated
+// POST: The rule is persisted
+func (s *RuleSQLiteStore) Save(ctx context.Context, value domain.Rule) error {
+	criteria, err := json.Marshal(value.Criteria)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO grading_rule (`+ruleColumns+`) VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(program, belt) DO UPDATE SET
+		   criteria=excluded.criteria, updated_by=excluded.updated_by, updated_at=excluded.updated_at`,
+		value.ID, value.Program, value.Belt, string(criteria), value.UpdatedBy, value.UpdatedAt.Format(timeLayout))
+	return err
+}
+
+// GetByProgramAndBelt retrieves the rule for a program and belt.
+// PRE: program and belt are non-empty
+// POST: Returns the rule or sql.ErrNoRows
+func (s *RuleSQLiteStore) GetByProgramAndBelt(ctx context.Context, program, belt string) (domain.Rule, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+ruleColumns+" FROM grading_rule WHERE program = ? AND belt = ?", program, belt)
+	return scanRule(row.Scan)
+}
+
+// List returns every rule by program and belt.
+// PRE: none
+// POST: Returns rules or an empty slice
+func (s *RuleSQLiteStore) List(ctx context.Context) ([]domain.Rule, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+ruleColumns+" FROM grading_rule ORDER BY program, belt")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Rule
+	for rows.Next() {
+		r, err := scanRule(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, r)
+	}
+	return list, rows.Err()
+}
+
+// Delete removes a rule, returning its belt to the grading config thresholds.
+// PRE: id is non-empty
+// POST: The rule no longer exists
+func (s *RuleSQLiteStore) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM grading_rule WHERE id = ?", id)
+	return err
+}
+
+// scanRule extracts a Rule from a row scanner function.
+func scanRule(scan func(dest ...interface{}) error) (domain.Rule, error) {
+	var r domain.Rule
+	var criteria, updatedAt string
+	if err := scan(&r.ID, &r.Program, &r.Belt, &criteria, &r.UpdatedBy, &updatedAt); err != nil {
+		return domain.Rule{}, err
+	}
+	if err := json.Unmarshal([]byte(criteria), &r.Criteria); err != nil {
+		return domain.Rule{}, err
+	}
+	r.UpdatedAt, _ = time.Parse(timeLayout, updatedAt)
+	return r, nil
+}
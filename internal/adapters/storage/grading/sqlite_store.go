@@ -3,6 +3,7 @@ package grading
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	"workshop/internal/adapters/storage"
@@ -21,73 +22,190 @@ func NewRecordSQLiteStore(db storage.SQLDB) *RecordSQLiteStore {
 	return &RecordSQLiteStore{db: db}
 }
 
+// recordColumns lists the grading_record columns in scanRecordFields order.
+const recordColumns = `id, member_id, belt, stripe, promoted_at, proposed_by, approved_by, method,
+	supersedes, superseded_by, voided, corrected_by, corrected_at, correction_reason`
+
+// effectiveRecord restricts a grading_record query to records not amended or voided.
+const effectiveRecord = `superseded_by = '' AND voided = 0`
+
 // GetByID retrieves a grading Record by its ID.
 // PRE: id is non-empty
 // POST: Returns the entity or an error if not found
 func (s *RecordSQLiteStore) GetByID(ctx context.Context, id string) (domain.Record, error) {
 	row := s.db.QueryRowContext(ctx,
-		`SELECT id, member_id, belt, stripe, promoted_at, proposed_by, approved_by, method
-		 FROM grading_record WHERE id = ?`, id)
-	return scanRecord(row)
+		`SELECT `+recordColumns+` FROM grading_record WHERE id = ?`, id)
+	return scanRecordFields(row.Scan)
 }
 
 // Save persists a grading Record to the database.
 // PRE: entity has been validated
 // POST: Entity is persisted (insert or update)
 func (s *RecordSQLiteStore) Save(ctx context.Context, r domain.Record) error {
+	var correctedAt string
+	if !r.CorrectedAt.IsZero() {
+		correctedAt = r.CorrectedAt.Format(timeLayout)
+	}
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO grading_record (id, member_id, belt, stripe, promoted_at, proposed_by, approved_by, method)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`INSERT INTO grading_record (`+recordColumns+`)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		 ON CONFLICT(id) DO UPDATE SET
 		   member_id=excluded.member_id, belt=excluded.belt, stripe=excluded.stripe,
 		   promoted_at=excluded.promoted_at, proposed_by=excluded.proposed_by,
-		   approved_by=excluded.approved_by, method=excluded.method`,
+		   approved_by=excluded.approved_by, method=excluded.method,
+		   supersedes=excluded.supersedes, superseded_by=excluded.superseded_by,
+		   voided=excluded.voided, corrected_by=excluded.corrected_by,
+		   corrected_at=excluded.corrected_at, correction_reason=excluded.correction_reason`,
 		r.ID, r.MemberID, r.Belt, r.Stripe, r.PromotedAt.Format(timeLayout),
-		nullStr(r.ProposedBy), nullStr(r.ApprovedBy), r.Method)
+		nullStr(r.ProposedBy), nullStr(r.ApprovedBy), r.Method,
+		r.Supersedes, r.SupersededBy, r.Voided, r.CorrectedBy, correctedAt, r.CorrectionReason)
 	return err
 }
 
-// ListByMemberID retrieves grading Records for a member.
+// SaveAwards inserts the records and notes of a bulk award in one transaction.
+// PRE: records and notes are valid and carry fresh IDs
+// POST: either every record and note is persisted or nothing is
+func (s *RecordSQLiteStore) SaveAwards(ctx context.Context, records []domain.Record, notes []domain.Note) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, r := range records {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO grading_record (id, member_id, belt, stripe, promoted_at, proposed_by, approved_by, method)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			r.ID, r.MemberID, r.Belt, r.Stripe, r.PromotedAt.Format(timeLayout),
+			nullStr(r.ProposedBy), nullStr(r.ApprovedBy), r.Method); err != nil {
+			return err
+		}
+	}
+	for _, n := range notes {
+		visibility := n.Visibility
+		if visibility == "" {
+			visibility = domain.NoteVisibilityCoaches
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO grading_note (id, member_id, content, visibility, created_by, created_at)
+			 VALUES (?, ?, ?, ?, ?, ?)`,
+			n.ID, n.MemberID, n.Content, visibility, n.CreatedBy, n.CreatedAt.Format(timeLayout)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// List retrieves effective grading Records matching the filter, ordered by promotion date.
+// PRE: From and To, when set, are YYYY-MM-DD
+// POST: Returns at most filter.Limit records (1000 when unset); amended and voided records are excluded
+func (s *RecordSQLiteStore) List(ctx context.Context, filter RecordFilter) ([]domain.Record, error) {
+	where := []string{effectiveRecord}
+	var args []interface{}
+	if filter.MemberID != "" {
+		where = append(where, "member_id = ?")
+		args = append(args, filter.MemberID)
+	}
+	if filter.From != "" {
+		where = append(where, "SUBSTR(promoted_at, 1, 10) >= ?")
+		args = append(args, filter.From)
+	}
+	if filter.To != "" {
+		where = append(where, "SUBSTR(promoted_at, 1, 10) <= ?")
+		args = append(args, filter.To)
+	}
+	dir := "DESC"
+	if filter.Dir == "asc" {
+		dir = "ASC"
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+	args = append(args, limit, filter.Offset)
+
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+recordColumns+` FROM grading_record
+		 WHERE `+strings.Join(where, " AND ")+`
+		 ORDER BY promoted_at `+dir+`, id `+dir+` LIMIT ? OFFSET ?`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanRecordRows(rows)
+}
+
+// ListByMemberID retrieves a member's effective grading Records, newest first.
 // PRE: memberID is non-empty
-// POST: Returns records for the given member
+// POST: Returns records for the given member; amended and voided records are excluded
 func (s *RecordSQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]domain.Record, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, member_id, belt, stripe, promoted_at, proposed_by, approved_by, method
-		 FROM grading_record WHERE member_id = ? ORDER BY promoted_at DESC`, memberID)
+		`SELECT `+recordColumns+` FROM grading_record
+		 WHERE member_id = ? AND `+effectiveRecord+` ORDER BY promoted_at DESC`, memberID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanRecordRows(rows)
+}
+
+// ListHistoryByMemberID retrieves every grading Record for a member, including amended and
+// voided ones, newest first.
+// PRE: memberID is non-empty
+// POST: Returns the member's full correction chain
+func (s *RecordSQLiteStore) ListHistoryByMemberID(ctx context.Context, memberID string) ([]domain.Record, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+recordColumns+` FROM grading_record
+		 WHERE member_id = ? ORDER BY promoted_at DESC, corrected_at DESC`, memberID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanRecordRows(rows)
+}
+
+// ListByDateRange retrieves effective grading Records promoted between two dates, oldest first.
+// PRE: startDate and endDate are YYYY-MM-DD
+// POST: Returns records promoted on or between the dates; amended and voided records are excluded
+func (s *RecordSQLiteStore) ListByDateRange(ctx context.Context, startDate string, endDate string) ([]domain.Record, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+recordColumns+` FROM grading_record
+		 WHERE SUBSTR(promoted_at, 1, 10) >= ? AND SUBSTR(promoted_at, 1, 10) <= ? AND `+effectiveRecord+`
+		 ORDER BY promoted_at ASC`, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	return scanRecordRows(rows)
+}
 
+// scanRecordRows scans rows into a Record slice.
+func scanRecordRows(rows *sql.Rows) ([]domain.Record, error) {
 	var records []domain.Record
 	for rows.Next() {
-		var r domain.Record
-		var promotedAt string
-		var proposedBy, approvedBy sql.NullString
-		if err := rows.Scan(&r.ID, &r.MemberID, &r.Belt, &r.Stripe, &promotedAt, &proposedBy, &approvedBy, &r.Method); err != nil {
+		r, err := scanRecordFields(rows.Scan)
+		if err != nil {
 			return nil, err
 		}
-		r.PromotedAt, _ = time.Parse(timeLayout, promotedAt)
-		if proposedBy.Valid {
-			r.ProposedBy = proposedBy.String
-		}
-		if approvedBy.Valid {
-			r.ApprovedBy = approvedBy.String
-		}
 		records = append(records, r)
 	}
 	return records, rows.Err()
 }
 
-func scanRecord(row *sql.Row) (domain.Record, error) {
+// scanRecordFields scans recordColumns into a Record.
+func scanRecordFields(scan func(dest ...interface{}) error) (domain.Record, error) {
 	var r domain.Record
-	var promotedAt string
+	var promotedAt, correctedAt string
 	var proposedBy, approvedBy sql.NullString
-	err := row.Scan(&r.ID, &r.MemberID, &r.Belt, &r.Stripe, &promotedAt, &proposedBy, &approvedBy, &r.Method)
+	err := scan(&r.ID, &r.MemberID, &r.Belt, &r.Stripe, &promotedAt, &proposedBy, &approvedBy, &r.Method,
+		&r.Supersedes, &r.SupersededBy, &r.Voided, &r.CorrectedBy, &correctedAt, &r.CorrectionReason)
 	if err != nil {
 		return domain.Record{}, err
 	}
 	r.PromotedAt, _ = time.Parse(timeLayout, promotedAt)
+	if correctedAt != "" {
+		r.CorrectedAt, _ = time.Parse(timeLayout, correctedAt)
+	}
 	if proposedBy.Valid {
 		r.ProposedBy = proposedBy.String
 	}
@@ -179,13 +297,14 @@ func NewProposalSQLiteStore(db storage.SQLDB) *ProposalSQLiteStore {
 	return &ProposalSQLiteStore{db: db}
 }
 
+const proposalColumns = `id, member_id, target_belt, notes, proposed_by, approved_by, status, event_id, created_at, decided_at`
+
 // GetByID retrieves a grading Proposal by its ID.
 // PRE: id is non-empty
 // POST: Returns the entity or an error if not found
 func (s *ProposalSQLiteStore) GetByID(ctx context.Context, id string) (domain.Proposal, error) {
 	row := s.db.QueryRowContext(ctx,
-		`SELECT id, member_id, target_belt, notes, proposed_by, approved_by, status, created_at, decided_at
-		 FROM grading_proposal WHERE id = ?`, id)
+		`SELECT `+proposalColumns+` FROM grading_proposal WHERE id = ?`, id)
 	return scanProposal(row)
 }
 
@@ -194,50 +313,68 @@ func (s *ProposalSQLiteStore) GetByID(ctx context.Context, id string) (domain.Pr
 // POST: Entity is persisted (insert or update)
 func (s *ProposalSQLiteStore) Save(ctx context.Context, p domain.Proposal) error {
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO grading_proposal (id, member_id, target_belt, notes, proposed_by, approved_by, status, created_at, decided_at)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`INSERT INTO grading_proposal (`+proposalColumns+`)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		 ON CONFLICT(id) DO UPDATE SET
 		   member_id=excluded.member_id, target_belt=excluded.target_belt, notes=excluded.notes,
 		   proposed_by=excluded.proposed_by, approved_by=excluded.approved_by, status=excluded.status,
-		   created_at=excluded.created_at, decided_at=excluded.decided_at`,
+		   event_id=excluded.event_id, created_at=excluded.created_at, decided_at=excluded.decided_at`,
 		p.ID, p.MemberID, p.TargetBelt, nullStr(p.Notes), p.ProposedBy,
-		nullStr(p.ApprovedBy), p.Status, p.CreatedAt.Format(timeLayout), nullTime(p.DecidedAt))
+		nullStr(p.ApprovedBy), p.Status, p.EventID, p.CreatedAt.Format(timeLayout), nullTime(p.DecidedAt))
 	return err
 }
 
-// ListPending retrieves all pending grading Proposals.
+// ListOpen retrieves grading Proposals awaiting a decision, scheduled or not.
 // PRE: none
-// POST: Returns pending proposals ordered by creation time
-func (s *ProposalSQLiteStore) ListPending(ctx context.Context) ([]domain.Proposal, error) {
-	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, member_id, target_belt, notes, proposed_by, approved_by, status, created_at, decided_at
-		 FROM grading_proposal WHERE status = 'pending' ORDER BY created_at ASC`)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	return scanProposals(rows)
+// POST: Returns pending and scheduled proposals ordered by creation time
+func (s *ProposalSQLiteStore) ListOpen(ctx context.Context) ([]domain.Proposal, error) {
+	return s.query(ctx,
+		`SELECT `+proposalColumns+` FROM grading_proposal WHERE status IN ('pending', 'scheduled') ORDER BY created_at ASC`)
+}
+
+// ListByEventID retrieves the grading Proposals booked onto a grading day, decided or not.
+// PRE: eventID is non-empty
+// POST: Returns proposals for the event ordered by creation time
+func (s *ProposalSQLiteStore) ListByEventID(ctx context.Context, eventID string) ([]domain.Proposal, error) {
+	return s.query(ctx,
+		`SELECT `+proposalColumns+` FROM grading_proposal WHERE event_id = ? ORDER BY created_at ASC`, eventID)
 }
 
 // ListByMemberID retrieves grading Proposals for a member.
 // PRE: memberID is non-empty
 // POST: Returns proposals for the given member
 func (s *ProposalSQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]domain.Proposal, error) {
-	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, member_id, target_belt, notes, proposed_by, approved_by, status, created_at, decided_at
-		 FROM grading_proposal WHERE member_id = ? ORDER BY created_at DESC`, memberID)
+	return s.query(ctx,
+		`SELECT `+proposalColumns+` FROM grading_proposal WHERE member_id = ? ORDER BY created_at DESC`, memberID)
+}
+
+func (s *ProposalSQLiteStore) query(ctx context.Context, query string, args ...any) ([]domain.Proposal, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	return scanProposals(rows)
+	var proposals []domain.Proposal
+	for rows.Next() {
+		p, err := scanProposal(rows)
+		if err != nil {
+			return nil, err
+		}
+		proposals = append(proposals, p)
+	}
+	return proposals, rows.Err()
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
 }
 
-func scanProposal(row *sql.Row) (domain.Proposal, error) {
+func scanProposal(row rowScanner) (domain.Proposal, error) {
 	var p domain.Proposal
 	var notes, approvedBy, decidedAt sql.NullString
 	var createdAt string
-	err := row.Scan(&p.ID, &p.MemberID, &p.TargetBelt, &notes, &p.ProposedBy, &approvedBy, &p.Status, &createdAt, &decidedAt)
+	err := row.Scan(&p.ID, &p.MemberID, &p.TargetBelt, &notes, &p.ProposedBy, &approvedBy, &p.Status, &p.EventID, &createdAt, &decidedAt)
 	if err != nil {
 		return domain.Proposal{}, err
 	}
@@ -254,29 +391,52 @@ func scanProposal(row *sql.Row) (domain.Proposal, error) {
 	return p, nil
 }
 
-func scanProposals(rows *sql.Rows) ([]domain.Proposal, error) {
-	var proposals []domain.Proposal
+// --- ProposalCommentSQLiteStore ---
+
+// ProposalCommentSQLiteStore implements ProposalCommentStore using SQLite.
+type ProposalCommentSQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewProposalCommentSQLiteStore creates a new ProposalCommentSQLiteStore.
+func NewProposalCommentSQLiteStore(db storage.SQLDB) *ProposalCommentSQLiteStore {
+	return &ProposalCommentSQLiteStore{db: db}
+}
+
+// Save persists a proposal comment to the database.
+// PRE: entity has been validated
+// POST: Entity is persisted (insert or update)
+func (s *ProposalCommentSQLiteStore) Save(ctx context.Context, c domain.ProposalComment) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO grading_proposal_comment (id, proposal_id, author_id, content, created_at)
+		 VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET content=excluded.content`,
+		c.ID, c.ProposalID, c.AuthorID, c.Content, c.CreatedAt.Format(timeLayout))
+	return err
+}
+
+// ListByProposalID retrieves the discussion of a proposal.
+// PRE: proposalID is non-empty
+// POST: Returns comments oldest first
+func (s *ProposalCommentSQLiteStore) ListByProposalID(ctx context.Context, proposalID string) ([]domain.ProposalComment, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, proposal_id, author_id, content, created_at
+		 FROM grading_proposal_comment WHERE proposal_id = ? ORDER BY created_at ASC`, proposalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var comments []domain.ProposalComment
 	for rows.Next() {
-		var p domain.Proposal
-		var notes, approvedBy, decidedAt sql.NullString
+		var c domain.ProposalComment
 		var createdAt string
-		err := rows.Scan(&p.ID, &p.MemberID, &p.TargetBelt, &notes, &p.ProposedBy, &approvedBy, &p.Status, &createdAt, &decidedAt)
-		if err != nil {
+		if err := rows.Scan(&c.ID, &c.ProposalID, &c.AuthorID, &c.Content, &createdAt); err != nil {
 			return nil, err
 		}
-		p.CreatedAt, _ = time.Parse(timeLayout, createdAt)
-		if notes.Valid {
-			p.Notes = notes.String
-		}
-		if approvedBy.Valid {
-			p.ApprovedBy = approvedBy.String
-		}
-		if decidedAt.Valid {
-			p.DecidedAt, _ = time.Parse(timeLayout, decidedAt.String)
-		}
-		proposals = append(proposals, p)
+		c.CreatedAt, _ = time.Parse(timeLayout, createdAt)
+		comments = append(comments, c)
 	}
-	return proposals, rows.Err()
+	return comments, rows.Err()
 }
 
 // --- NoteSQLiteStore ---
@@ -295,11 +455,15 @@ func NewNoteSQLiteStore(db storage.SQLDB) *NoteSQLiteStore {
 // PRE: entity has been validated
 // POST: Entity is persisted (insert or update)
 func (s *NoteSQLiteStore) Save(ctx context.Context, n domain.Note) error {
+	visibility := n.Visibility
+	if visibility == "" {
+		visibility = domain.NoteVisibilityCoaches
+	}
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO grading_note (id, member_id, content, created_by, created_at)
-		 VALUES (?, ?, ?, ?, ?)
-		 ON CONFLICT(id) DO UPDATE SET content=excluded.content`,
-		n.ID, n.MemberID, n.Content, n.CreatedBy, n.CreatedAt.Format(timeLayout))
+		`INSERT INTO grading_note (id, member_id, content, visibility, created_by, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET content=excluded.content, visibility=excluded.visibility`,
+		n.ID, n.MemberID, n.Content, visibility, n.CreatedBy, n.CreatedAt.Format(timeLayout))
 	return err
 }
 
@@ -308,7 +472,7 @@ func (s *NoteSQLiteStore) Save(ctx context.Context, n domain.Note) error {
 // POST: Returns notes for the given member ordered by creation time desc
 func (s *NoteSQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]domain.Note, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, member_id, content, created_by, created_at
+		`SELECT id, member_id, content, visibility, created_by, created_at
 		 FROM grading_note WHERE member_id = ? ORDER BY created_at DESC`, memberID)
 	if err != nil {
 		return nil, err
@@ -318,7 +482,7 @@ func (s *NoteSQLiteStore) ListByMemberID(ctx context.Context, memberID string) (
 	for rows.Next() {
 		var n domain.Note
 		var createdAt string
-		if err := rows.Scan(&n.ID, &n.MemberID, &n.Content, &n.CreatedBy, &createdAt); err != nil {
+		if err := rows.Scan(&n.ID, &n.MemberID, &n.Content, &n.Visibility, &n.CreatedBy, &createdAt); err != nil {
 			return nil, err
 		}
 		n.CreatedAt, _ = time.Parse(timeLayout, createdAt)
@@ -6,11 +6,28 @@ import (
 	domain "workshop/internal/domain/grading"
 )
 
-// RecordStore persists GradingRecord state.
+// RecordStore persists GradingRecord state. List, ListByMemberID and ListByDateRange return
+// only effective records, so belt history and readiness follow the corrected chain;
+// ListHistoryByMemberID includes amended and voided records.
 type RecordStore interface {
 	GetByID(ctx context.Context, id string) (domain.Record, error)
 	Save(ctx context.Context, value domain.Record) error
+	List(ctx context.Context, filter RecordFilter) ([]domain.Record, error)
 	ListByMemberID(ctx context.Context, memberID string) ([]domain.Record, error)
+	ListHistoryByMemberID(ctx context.Context, memberID string) ([]domain.Record, error)
+	ListByDateRange(ctx context.Context, startDate string, endDate string) ([]domain.Record, error)
+	SaveAwards(ctx context.Context, records []domain.Record, notes []domain.Note) error
+}
+
+// RecordFilter carries filtering and paging parameters for RecordStore.List.
+// Empty fields are ignored.
+type RecordFilter struct {
+	MemberID string
+	From     string // first promotion date, inclusive YYYY-MM-DD
+	To       string // last promotion date, inclusive YYYY-MM-DD
+	Dir      string // "asc" for oldest first; newest first otherwise
+	Limit    int    // 0 = 1000
+	Offset   int
 }
 
 // ConfigStore persists GradingConfig state.
@@ -38,6 +55,30 @@ type MemberConfigStore interface {
 type ProposalStore interface {
 	GetByID(ctx context.Context, id string) (domain.Proposal, error)
 	Save(ctx context.Context, value domain.Proposal) error
-	ListPending(ctx context.Context) ([]domain.Proposal, error)
+	ListOpen(ctx context.Context) ([]domain.Proposal, error)
+	ListByEventID(ctx context.Context, eventID string) ([]domain.Proposal, error)
 	ListByMemberID(ctx context.Context, memberID string) ([]domain.Proposal, error)
 }
+
+// CeremonyStore persists promotion ceremonies. GetByEventID finds the ceremony that owns a
+// calendar event, so a proposal's grading day can be recognised as a ceremony.
+type CeremonyStore interface {
+	Save(ctx context.Context, value domain.Ceremony) error
+	GetByID(ctx context.Context, id string) (domain.Ceremony, error)
+	GetByEventID(ctx context.Context, eventID string) (domain.Ceremony, error)
+	List(ctx context.Context) ([]domain.Ceremony, error)
+}
+
+// ProposalCommentStore persists the discussion on grading proposals.
+type ProposalCommentStore interface {
+	Save(ctx context.Context, value domain.ProposalComment) error
+	ListByProposalID(ctx context.Context, proposalID string) ([]domain.ProposalComment, error)
+}
+
+// RuleStore persists grading eligibility rules, one per program and belt.
+type RuleStore interface {
+	Save(ctx context.Context, value domain.Rule) error
+	GetByProgramAndBelt(ctx context.Context, program, belt string) (domain.Rule, error)
+	List(ctx context.Context) ([]domain.Rule, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -21,29 +21,18 @@ func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
 	return &SQLiteStore{db: db}
 }
 
+// injuryColumns is the shared column list for injury SELECTs; order matches scanInjury.
+const injuryColumns = "id, body_part, description, member_id, reported_at, status, severity, resolution_notes, resolved_at, updated_at, grading_restricted"
+
 // GetByID retrieves a Injury by its ID.
 // PRE: id is non-empty
 // POST: Returns the entity or an error if not found
 func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Injury, error) {
-	query := "SELECT id, body_part, description, member_id, reported_at FROM injury WHERE id = ?"
+	query := "SELECT " + injuryColumns + " FROM injury WHERE id = ?"
 
 	row := s.db.QueryRowContext(ctx, query, id)
 
-	var entity domain.Injury
-	var reportedAtStr string
-	err := row.Scan(
-		&entity.ID,
-		&entity.BodyPart,
-		&entity.Description,
-		&entity.MemberID,
-		&reportedAtStr,
-	)
-	if err == nil {
-		entity.ReportedAt, err = parseStoredTime(reportedAtStr)
-		if err != nil {
-			return domain.Injury{}, fmt.Errorf("failed to parse reported_at: %w", err)
-		}
-	}
+	entity, err := scanInjury(row.Scan)
 	if err == sql.ErrNoRows {
 		return domain.Injury{}, fmt.Errorf("injury not found: %w", err)
 	}
@@ -61,9 +50,10 @@ func (s *SQLiteStore) Save(ctx context.Context, entity domain.Injury) error {
 	defer tx.Rollback()
 
 	// Upsert implementation
-	fields := []string{"id", "body_part", "description", "member_id", "reported_at"}
-	placeholders := []string{"?", "?", "?", "?", "?"}
-	updates := []string{"id=excluded.id", "body_part=excluded.body_part", "description=excluded.description", "member_id=excluded.member_id", "reported_at=excluded.reported_at"}
+	fields := []string{"id", "body_part", "description", "member_id", "reported_at", "status", "severity", "resolution_notes", "resolved_at", "updated_at", "grading_restricted"}
+	placeholders := []string{"?", "?", "?", "?", "?", "?", "?", "?", "?", "?", "?"}
+	updates := []string{"id=excluded.id", "body_part=excluded.body_part", "description=excluded.description", "member_id=excluded.member_id", "reported_at=excluded.reported_at",
+		"status=excluded.status", "severity=excluded.severity", "resolution_notes=excluded.resolution_notes", "resolved_at=excluded.resolved_at", "updated_at=excluded.updated_at", "grading_restricted=excluded.grading_restricted"}
 
 	query := fmt.Sprintf(
 		"INSERT INTO injury (%s) VALUES (%s) ON CONFLICT(id) DO UPDATE SET %s",
@@ -72,12 +62,30 @@ func (s *SQLiteStore) Save(ctx context.Context, entity domain.Injury) error {
 		strings.Join(updates, ", "),
 	)
 
+	var resolvedAt, updatedAt interface{}
+	if !entity.ResolvedAt.IsZero() {
+		resolvedAt = entity.ResolvedAt.Format(time.RFC3339Nano)
+	}
+	if !entity.UpdatedAt.IsZero() {
+		updatedAt = entity.UpdatedAt.Format(time.RFC3339Nano)
+	}
+	gradingRestricted := 0
+	if entity.GradingRestricted {
+		gradingRestricted = 1
+	}
+
 	_, err = tx.ExecContext(ctx, query,
 		entity.ID,
 		entity.BodyPart,
 		entity.Description,
 		entity.MemberID,
 		entity.ReportedAt.Format(time.RFC3339Nano),
+		entity.Status,
+		entity.Severity,
+		entity.ResolutionNotes,
+		resolvedAt,
+		updatedAt,
+		gradingRestricted,
 	)
 	if err != nil {
 		return err
@@ -98,9 +106,21 @@ func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
 // PRE: filter has valid parameters
 // POST: Returns matching entities
 func (s *SQLiteStore) List(ctx context.Context, filter ListFilter) ([]domain.Injury, error) {
-	query := "SELECT id, body_part, description, member_id, reported_at FROM injury LIMIT ? OFFSET ?"
+	query := "SELECT " + injuryColumns + " FROM injury LIMIT ? OFFSET ?"
+	return s.queryInjuries(ctx, query, filter.Limit, filter.Offset)
+}
+
+// ListByMemberID retrieves all injuries for a member, newest first.
+// PRE: memberID is non-empty
+// POST: Returns the member's injuries ordered by reported_at descending
+func (s *SQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]domain.Injury, error) {
+	query := "SELECT " + injuryColumns + " FROM injury WHERE member_id = ? ORDER BY reported_at DESC"
+	return s.queryInjuries(ctx, query, memberID)
+}
 
-	rows, err := s.db.QueryContext(ctx, query, filter.Limit, filter.Offset)
+// queryInjuries runs a SELECT over injuryColumns and scans every row.
+func (s *SQLiteStore) queryInjuries(ctx context.Context, query string, args ...interface{}) ([]domain.Injury, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -108,24 +128,49 @@ func (s *SQLiteStore) List(ctx context.Context, filter ListFilter) ([]domain.Inj
 
 	var results []domain.Injury
 	for rows.Next() {
-		var entity domain.Injury
-		var reportedAtStr string
-		if err := rows.Scan(
-			&entity.ID,
-			&entity.BodyPart,
-			&entity.Description,
-			&entity.MemberID,
-			&reportedAtStr,
-		); err != nil {
-			return nil, err
-		}
-		entity.ReportedAt, err = parseStoredTime(reportedAtStr)
+		entity, err := scanInjury(rows.Scan)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse reported_at: %w", err)
+			return nil, err
 		}
 		results = append(results, entity)
 	}
-	return results, nil
+	return results, rows.Err()
+}
+
+// scanInjury extracts an Injury from a row scanner function.
+func scanInjury(scan func(dest ...interface{}) error) (domain.Injury, error) {
+	var entity domain.Injury
+	var reportedAtStr string
+	var resolvedAt, updatedAt sql.NullString
+	var gradingRestricted int
+	if err := scan(
+		&entity.ID,
+		&entity.BodyPart,
+		&entity.Description,
+		&entity.MemberID,
+		&reportedAtStr,
+		&entity.Status,
+		&entity.Severity,
+		&entity.ResolutionNotes,
+		&resolvedAt,
+		&updatedAt,
+		&gradingRestricted,
+	); err != nil {
+		return domain.Injury{}, err
+	}
+	var err error
+	entity.ReportedAt, err = parseStoredTime(reportedAtStr)
+	if err != nil {
+		return domain.Injury{}, fmt.Errorf("failed to parse reported_at: %w", err)
+	}
+	if resolvedAt.Valid && resolvedAt.String != "" {
+		entity.ResolvedAt, _ = parseStoredTime(resolvedAt.String)
+	}
+	if updatedAt.Valid && updatedAt.String != "" {
+		entity.UpdatedAt, _ = parseStoredTime(updatedAt.String)
+	}
+	entity.GradingRestricted = gradingRestricted != 0
+	return entity, nil
 }
 
 func parseStoredTime(value string) (time.Time, error) {
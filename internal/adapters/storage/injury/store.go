@@ -12,6 +12,7 @@ type Store interface {
 	Save(ctx context.Context, value domain.Injury) error
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, filter ListFilter) ([]domain.Injury, error)
+	ListByMemberID(ctx context.Context, memberID string) ([]domain.Injury, error)
 }
 
 // ListFilter carries filtering parameters for List operations.
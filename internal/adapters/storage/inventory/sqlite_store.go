@@ -0,0 +1,109 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/inventory"
+)
+
+const timeLayout = "2006-01-02T15:04:05Z07:00"
+
+// itemColumns is the shared column list for belt_inventory SELECTs; order matches scanItem.
+const itemColumns = "id, kind, color, size, quantity, low_stock, updated_at"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// GetItem retrieves the stock of one kind, colour and size.
+// PRE: kind and color are non-empty; size is empty for stripes
+// POST: Returns the item or an error if none is stocked
+func (s *SQLiteStore) GetItem(ctx context.Context, kind, color, size string) (domain.Item, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+itemColumns+" FROM belt_inventory WHERE kind = ? AND color = ? AND size = ?", kind, color, size)
+	item, err := scanItem(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Item{}, fmt.Errorf("inventory item not found: %w", err)
+	}
+	return item, err
+}
+
+// SaveItem persists an item. Kind, colour and size identify it: saving the same three
+// again updates the quantity and low-stock level and keeps the first ID.
+// PRE: value has been validated
+// POST: The item is persisted (insert or update)
+func (s *SQLiteStore) SaveItem(ctx context.Context, value domain.Item) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO belt_inventory (`+itemColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(kind, color, size) DO UPDATE SET quantity = excluded.quantity, low_stock = excluded.low_stock, updated_at = excluded.updated_at`,
+		value.ID, value.Kind, value.Color, value.Size, value.Quantity, value.LowStock, value.UpdatedAt.Format(timeLayout))
+	return err
+}
+
+// ListItems returns all stock ordered by kind and colour. Callers sort sizes.
+// PRE: none
+// POST: Returns items or an empty slice
+func (s *SQLiteStore) ListItems(ctx context.Context) ([]domain.Item, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+itemColumns+" FROM belt_inventory ORDER BY kind, color, size")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Item
+	for rows.Next() {
+		item, err := scanItem(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+	return list, rows.Err()
+}
+
+// GetMemberSize retrieves the belt size a member wears.
+// PRE: memberID is non-empty
+// POST: Returns the size or an error if none is recorded
+func (s *SQLiteStore) GetMemberSize(ctx context.Context, memberID string) (domain.MemberSize, error) {
+	var m domain.MemberSize
+	err := s.db.QueryRowContext(ctx, "SELECT member_id, size FROM belt_size WHERE member_id = ?", memberID).Scan(&m.MemberID, &m.Size)
+	if err == sql.ErrNoRows {
+		return domain.MemberSize{}, fmt.Errorf("belt size not found: %w", err)
+	}
+	return m, err
+}
+
+// SaveMemberSize records or replaces a member's belt size.
+// PRE: value has been validated
+// POST: The size is persisted
+func (s *SQLiteStore) SaveMemberSize(ctx context.Context, value domain.MemberSize) error {
+	_, err := s.db.ExecContext(ctx,
+		"INSERT INTO belt_size (member_id, size) VALUES (?, ?) ON CONFLICT(member_id) DO UPDATE SET size = excluded.size",
+		value.MemberID, value.Size)
+	return err
+}
+
+// scanItem extracts an Item from a row scanner function.
+func scanItem(scan func(dest ...interface{}) error) (domain.Item, error) {
+	var item domain.Item
+	var updatedAt string
+	if err := scan(&item.ID, &item.Kind, &item.Color, &item.Size, &item.Quantity, &item.LowStock, &updatedAt); err != nil {
+		return domain.Item{}, err
+	}
+	item.UpdatedAt, _ = time.Parse(timeLayout, updatedAt)
+	return item, nil
+}
+
+// Ensure interface compliance at compile time.
+var _ Store = (*SQLiteStore)(nil)
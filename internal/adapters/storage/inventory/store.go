@@ -0,0 +1,16 @@
+package inventory
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/inventory"
+)
+
+// Store persists belt and stripe stock, and the belt size each member wears.
+type Store interface {
+	GetItem(ctx context.Context, kind, color, size string) (domain.Item, error)
+	SaveItem(ctx context.Context, value domain.Item) error
+	ListItems(ctx context.Context) ([]domain.Item, error)
+	GetMemberSize(ctx context.Context, memberID string) (domain.MemberSize, error)
+	SaveMemberSize(ctx context.Context, value domain.MemberSize) error
+}
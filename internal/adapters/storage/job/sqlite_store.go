@@ -0,0 +1,137 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/job"
+)
+
+// jobColumns is the shared column list for job SELECTs; order matches scanJob.
+const jobColumns = "name, schedule, enabled, next_run_at, last_run_at, last_duration_ms, last_status, last_error, running_until, updated_by, updated_at"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// GetByName retrieves a job by name.
+// PRE: name is non-empty
+// POST: Returns the job or an error wrapping sql.ErrNoRows if it has never been saved
+func (s *SQLiteStore) GetByName(ctx context.Context, name string) (domain.Job, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+jobColumns+" FROM job WHERE name = ?", name)
+	j, err := scanJob(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Job{}, fmt.Errorf("job not found: %w", err)
+	}
+	return j, err
+}
+
+// List returns every saved job ordered by name.
+// PRE: none
+// POST: Returns jobs or an empty slice
+func (s *SQLiteStore) List(ctx context.Context) ([]domain.Job, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+jobColumns+" FROM job ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Job
+	for rows.Next() {
+		j, err := scanJob(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, j)
+	}
+	return list, rows.Err()
+}
+
+// Save persists a job's schedule (insert or update). Run status is left to Claim and
+// RecordRun so a schedule change during a run does not lose its outcome.
+// PRE: value has been validated
+// POST: The job's schedule, enabled flag and next run are persisted
+func (s *SQLiteStore) Save(ctx context.Context, value domain.Job) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO job (`+jobColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET schedule = excluded.schedule, enabled = excluded.enabled,
+			next_run_at = excluded.next_run_at, updated_by = excluded.updated_by, updated_at = excluded.updated_at`,
+		value.Name, value.Schedule, boolInt(value.Enabled), formatTime(value.NextRunAt), formatTime(value.LastRunAt),
+		value.LastDuration.Milliseconds(), value.LastStatus, value.LastError, formatTime(value.RunningUntil),
+		value.UpdatedBy, formatTime(value.UpdatedAt))
+	return err
+}
+
+// Claim takes the job's run lease unless another run holds it, recording the run's start.
+// The check and the update are one statement, so two processes cannot both claim a run.
+// PRE: the job has been saved; leaseUntil is after now
+// POST: Returns true and sets running_until and last_run_at when claimed; false when a
+// lease is still held or the job does not exist
+func (s *SQLiteStore) Claim(ctx context.Context, name string, now, leaseUntil time.Time) (bool, error) {
+	res, err := s.db.ExecContext(ctx,
+		"UPDATE job SET running_until = ?, last_run_at = ? WHERE name = ? AND running_until <= ?",
+		formatTime(leaseUntil), formatTime(now), name, formatTime(now))
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// RecordRun stores the outcome of a claimed run, releases the lease and sets the next run.
+// PRE: the run was claimed; value.Name is non-empty
+// POST: Last duration, status, error and next run are persisted; running_until is cleared
+func (s *SQLiteStore) RecordRun(ctx context.Context, value domain.Job) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE job SET last_duration_ms = ?, last_status = ?, last_error = ?, next_run_at = ?, running_until = ''
+		WHERE name = ?`,
+		value.LastDuration.Milliseconds(), value.LastStatus, value.LastError, formatTime(value.NextRunAt), value.Name)
+	return err
+}
+
+// scanJob extracts a Job from a row scanner function.
+func scanJob(scan func(dest ...interface{}) error) (domain.Job, error) {
+	var j domain.Job
+	var enabled int
+	var durationMS int64
+	var nextRunAt, lastRunAt, runningUntil, updatedAt string
+	if err := scan(&j.Name, &j.Schedule, &enabled, &nextRunAt, &lastRunAt, &durationMS,
+		&j.LastStatus, &j.LastError, &runningUntil, &j.UpdatedBy, &updatedAt); err != nil {
+		return domain.Job{}, err
+	}
+	j.Enabled = enabled == 1
+	j.LastDuration = time.Duration(durationMS) * time.Millisecond
+	j.NextRunAt, _ = time.Parse(time.RFC3339, nextRunAt)
+	j.LastRunAt, _ = time.Parse(time.RFC3339, lastRunAt)
+	j.RunningUntil, _ = time.Parse(time.RFC3339, runningUntil)
+	j.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	return j, nil
+}
+
+// boolInt converts a bool to SQLite's 0/1.
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// formatTime formats a time as UTC RFC 3339, or "" for the zero time. The fixed-width UTC
+// form sorts as text, which Claim relies on to compare leases.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,17 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	domain "workshop/internal/domain/job"
+)
+
+// Store persists job schedules and their run status.
+type Store interface {
+	GetByName(ctx context.Context, name string) (domain.Job, error)
+	List(ctx context.Context) ([]domain.Job, error)
+	Save(ctx context.Context, value domain.Job) error
+	Claim(ctx context.Context, name string, now, leaseUntil time.Time) (bool, error)
+	RecordRun(ctx context.Context, value domain.Job) error
+}
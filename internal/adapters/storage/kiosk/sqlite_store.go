@@ -0,0 +1,108 @@
+package kiosk
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/kiosk"
+)
+
+const deviceColumns = "id, name, location_id, registered_by, pin_secret, registered_at, last_seen_at, auth_session_id, kiosk_started_at"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new KioskDeviceStore.
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// GetDevice retrieves a kiosk Device by its ID.
+// PRE: id is non-empty
+// POST: Returns the device or an error if not found
+func (s *SQLiteStore) GetDevice(ctx context.Context, id string) (domain.Device, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+deviceColumns+" FROM kiosk_device WHERE id = ?", id)
+	d, err := scanDevice(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Device{}, fmt.Errorf("kiosk device not found: %w", err)
+	}
+	return d, err
+}
+
+// SaveDevice persists a kiosk Device.
+// PRE: value has been validated
+// POST: Device is persisted (insert or update)
+func (s *SQLiteStore) SaveDevice(ctx context.Context, value domain.Device) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO kiosk_device (`+deviceColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET name=excluded.name, location_id=excluded.location_id, last_seen_at=excluded.last_seen_at,
+		auth_session_id=excluded.auth_session_id, kiosk_started_at=excluded.kiosk_started_at`,
+		value.ID, value.Name, value.LocationID, value.RegisteredBy, hex.EncodeToString(value.PINSecret),
+		value.RegisteredAt.Format(time.RFC3339), formatTime(value.LastSeenAt), value.AuthSessionID, formatTime(value.KioskStartedAt),
+	)
+	return err
+}
+
+// DeleteDevice removes a kiosk Device.
+// PRE: id is non-empty
+// POST: Device with given id is removed
+func (s *SQLiteStore) DeleteDevice(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM kiosk_device WHERE id = ?", id)
+	return err
+}
+
+// ListDevices retrieves all kiosk Devices ordered by name.
+// PRE: none
+// POST: Returns every registered device
+func (s *SQLiteStore) ListDevices(ctx context.Context) ([]domain.Device, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+deviceColumns+" FROM kiosk_device ORDER BY name, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []domain.Device
+	for rows.Next() {
+		d, err := scanDevice(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, d)
+	}
+	return results, rows.Err()
+}
+
+// TouchDevice records that a kiosk Device was seen.
+// PRE: id is non-empty
+// POST: last_seen_at is seenAt; unknown ids are ignored
+func (s *SQLiteStore) TouchDevice(ctx context.Context, id string, seenAt time.Time) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE kiosk_device SET last_seen_at = ? WHERE id = ?", seenAt.Format(time.RFC3339), id)
+	return err
+}
+
+// scanDevice reads one kiosk_device row in deviceColumns order.
+func scanDevice(scan func(dest ...interface{}) error) (domain.Device, error) {
+	var d domain.Device
+	var secret, registeredAt, lastSeenAt, startedAt string
+	if err := scan(&d.ID, &d.Name, &d.LocationID, &d.RegisteredBy, &secret, &registeredAt, &lastSeenAt, &d.AuthSessionID, &startedAt); err != nil {
+		return domain.Device{}, err
+	}
+	d.PINSecret, _ = hex.DecodeString(secret)
+	d.RegisteredAt, _ = time.Parse(time.RFC3339, registeredAt)
+	d.LastSeenAt, _ = time.Parse(time.RFC3339, lastSeenAt)
+	d.KioskStartedAt, _ = time.Parse(time.RFC3339, startedAt)
+	return d, nil
+}
+
+// formatTime formats t as RFC3339, or empty for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
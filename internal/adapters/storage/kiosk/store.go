@@ -0,0 +1,17 @@
+package kiosk
+
+import (
+	"context"
+	"time"
+
+	domain "workshop/internal/domain/kiosk"
+)
+
+// Store persists registered kiosk devices.
+type Store interface {
+	GetDevice(ctx context.Context, id string) (domain.Device, error)
+	SaveDevice(ctx context.Context, value domain.Device) error
+	DeleteDevice(ctx context.Context, id string) error
+	ListDevices(ctx context.Context) ([]domain.Device, error)
+	TouchDevice(ctx context.Context, id string, seenAt time.Time) error
+}
@@ -0,0 +1,84 @@
+package kpi
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/kpi"
+)
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// snapshotColumns is the shared column list for kpi_snapshot SELECTs; order matches scanSnapshot.
+const snapshotColumns = "date, total_members, active_members, archived_members, new_members, churned_members, weekly_active_members, classes_held, avg_class_size, monthly_revenue, computed_at"
+
+// Save inserts or replaces the snapshot for its date.
+// PRE: value has been validated
+// POST: The day's snapshot is persisted
+func (s *SQLiteStore) Save(ctx context.Context, value domain.Snapshot) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO kpi_snapshot (`+snapshotColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(date) DO UPDATE SET
+		   total_members=excluded.total_members, active_members=excluded.active_members,
+		   archived_members=excluded.archived_members, new_members=excluded.new_members,
+		   churned_members=excluded.churned_members, weekly_active_members=excluded.weekly_active_members,
+		   classes_held=excluded.classes_held, avg_class_size=excluded.avg_class_size,
+		   monthly_revenue=excluded.monthly_revenue, computed_at=excluded.computed_at`,
+		value.Date, value.TotalMembers, value.ActiveMembers, value.ArchivedMembers, value.NewMembers,
+		value.ChurnedMembers, value.WeeklyActiveMembers, value.ClassesHeld, value.AvgClassSize,
+		value.MonthlyRevenue, value.ComputedAt.Format(time.RFC3339))
+	return err
+}
+
+// GetLatestBefore returns the most recent snapshot dated before date.
+// PRE: date is YYYY-MM-DD
+// POST: Returns the snapshot or sql.ErrNoRows
+func (s *SQLiteStore) GetLatestBefore(ctx context.Context, date string) (domain.Snapshot, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+snapshotColumns+" FROM kpi_snapshot WHERE date < ? ORDER BY date DESC LIMIT 1", date)
+	return scanSnapshot(row.Scan)
+}
+
+// ListByDateRange returns snapshots dated from startDate to endDate inclusive, oldest first.
+// PRE: dates are YYYY-MM-DD
+// POST: Returns snapshots or an empty slice
+func (s *SQLiteStore) ListByDateRange(ctx context.Context, startDate string, endDate string) ([]domain.Snapshot, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+snapshotColumns+" FROM kpi_snapshot WHERE date >= ? AND date <= ? ORDER BY date", startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Snapshot
+	for rows.Next() {
+		snap, err := scanSnapshot(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, snap)
+	}
+	return list, rows.Err()
+}
+
+// scanSnapshot extracts a Snapshot from a row scanner function.
+func scanSnapshot(scan func(dest ...interface{}) error) (domain.Snapshot, error) {
+	var snap domain.Snapshot
+	var computedAt string
+	if err := scan(&snap.Date, &snap.TotalMembers, &snap.ActiveMembers, &snap.ArchivedMembers, &snap.NewMembers,
+		&snap.ChurnedMembers, &snap.WeeklyActiveMembers, &snap.ClassesHeld, &snap.AvgClassSize,
+		&snap.MonthlyRevenue, &computedAt); err != nil {
+		return domain.Snapshot{}, err
+	}
+	snap.ComputedAt, _ = time.Parse(time.RFC3339, computedAt)
+	return snap, nil
+}
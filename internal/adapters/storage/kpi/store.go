@@ -0,0 +1,14 @@
+package kpi
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/kpi"
+)
+
+// Store persists daily KPI snapshots.
+type Store interface {
+	Save(ctx context.Context, value domain.Snapshot) error
+	GetLatestBefore(ctx context.Context, date string) (domain.Snapshot, error)
+	ListByDateRange(ctx context.Context, startDate string, endDate string) ([]domain.Snapshot, error)
+}
@@ -0,0 +1,83 @@
+package location
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/location"
+)
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new LocationStore.
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// GetByID retrieves a Location by its ID.
+// PRE: id is non-empty
+// POST: Returns the entity or an error if not found
+func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Location, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT id, name, address, created_at FROM location WHERE id = ?", id)
+	var entity domain.Location
+	var createdAt string
+	err := row.Scan(&entity.ID, &entity.Name, &entity.Address, &createdAt)
+	if err == sql.ErrNoRows {
+		return domain.Location{}, fmt.Errorf("location not found: %w", err)
+	}
+	if err != nil {
+		return domain.Location{}, err
+	}
+	entity.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return entity, nil
+}
+
+// Save persists a Location to the database.
+// PRE: entity has been validated
+// POST: Entity is persisted (insert or update)
+func (s *SQLiteStore) Save(ctx context.Context, entity domain.Location) error {
+	_, err := s.db.ExecContext(ctx,
+		"INSERT INTO location (id, name, address, created_at) VALUES (?, ?, ?, ?) ON CONFLICT(id) DO UPDATE SET name=excluded.name, address=excluded.address",
+		entity.ID, entity.Name, entity.Address, entity.CreatedAt.Format(time.RFC3339),
+	)
+	return err
+}
+
+// Delete removes a Location from the database.
+// PRE: id is non-empty
+// POST: Entity with given id is removed
+func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM location WHERE id = ?", id)
+	return err
+}
+
+// List retrieves all Locations ordered by name.
+// PRE: none
+// POST: Returns all locations
+func (s *SQLiteStore) List(ctx context.Context) ([]domain.Location, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id, name, address, created_at FROM location ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []domain.Location
+	for rows.Next() {
+		var entity domain.Location
+		var createdAt string
+		if err := rows.Scan(&entity.ID, &entity.Name, &entity.Address, &createdAt); err != nil {
+			return nil, err
+		}
+		entity.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		results = append(results, entity)
+	}
+	return results, rows.Err()
+}
+
+var _ Store = (*SQLiteStore)(nil)
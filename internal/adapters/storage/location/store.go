@@ -0,0 +1,15 @@
+package location
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/location"
+)
+
+// Store persists Location state.
+type Store interface {
+	GetByID(ctx context.Context, id string) (domain.Location, error)
+	Save(ctx context.Context, value domain.Location) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]domain.Location, error)
+}
@@ -0,0 +1,190 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+
+	"workshop/internal/adapters/storage"
+)
+
+// Record kinds moved by a merge.
+const (
+	KindAttendance         = "attendance"
+	KindMakeupCredits      = "makeup_credits"
+	KindWaivers            = "waivers"
+	KindGradingRecords     = "grading_records"
+	KindGradingProposals   = "grading_proposals"
+	KindGradingNotes       = "grading_notes"
+	KindGradingConfig      = "grading_config"
+	KindRubricScores       = "rubric_scores"
+	KindMilestones         = "milestones"
+	KindEstimatedHours     = "estimated_hours"
+	KindObservations       = "observations"
+	KindQuickCaptures      = "quick_captures"
+	KindInjuries           = "injuries"
+	KindMessages           = "messages"
+	KindEmailsReceived     = "emails_received"
+	KindPersonalGoals      = "personal_goals"
+	KindGoalCheckIns       = "goal_check_ins"
+	KindTrainingGoals      = "training_goals"
+	KindCompetitions       = "competitions"
+	KindTags               = "tags"
+	KindBeltSize           = "belt_size"
+	KindClassApprovals     = "class_approvals"
+	KindClassPacks         = "class_packs"
+	KindClassPackUses      = "class_pack_uses"
+	KindReferral           = "referral"
+	KindReferralsMade      = "referrals_made"
+	KindVisits             = "visits"
+	KindPreferences        = "preferences"
+	KindContactCheck       = "contact_check"
+	KindCelebrations       = "celebrations"
+	KindCelebrationEmails  = "celebration_emails"
+	KindDigestsSent        = "digests_sent"
+	KindTermReports        = "term_reports"
+	KindReengagement       = "reengagement"
+	KindReengagementSnooze = "reengagement_snooze"
+	KindExportRequests     = "export_requests"
+	KindDeletionRequests   = "deletion_requests"
+	KindStatusChanges      = "status_changes"
+)
+
+// ownedTable names the table and column holding one kind of member-owned record.
+type ownedTable struct {
+	kind   string
+	table  string
+	column string
+	where  string // optional: narrows a column shared with other subjects to members
+}
+
+// match is the WHERE clause selecting one member's records.
+func (t ownedTable) match() string {
+	if t.where == "" {
+		return t.column + ` = ?`
+	}
+	return t.column + ` = ? AND ` + t.where
+}
+
+// ownedTables lists every record kind a merge re-parents, in report order. It covers every
+// column in the schema that holds a member ID; a new one must be added here, or the merge
+// tests fail. Table and column names are constants, never caller input.
+var ownedTables = []ownedTable{
+	{KindAttendance, "attendance", "member_id", ""},
+	{KindMakeupCredits, "makeup_credit", "member_id", ""},
+	{KindWaivers, "waiver", "member_id", ""},
+	{KindGradingRecords, "grading_record", "member_id", ""},
+	{KindGradingProposals, "grading_proposal", "member_id", ""},
+	{KindGradingNotes, "grading_note", "member_id", ""},
+	{KindGradingConfig, "grading_member_config", "member_id", ""},
+	{KindRubricScores, "rubric_score", "member_id", ""},
+	{KindMilestones, "member_milestone", "member_id", ""},
+	{KindEstimatedHours, "estimated_hours", "member_id", ""},
+	{KindObservations, "coach_observation", "member_id", ""},
+	{KindQuickCaptures, "observation_capture", "member_id", ""},
+	{KindInjuries, "injury", "member_id", ""},
+	{KindMessages, "message", "receiver_id", ""},
+	{KindEmailsReceived, "email_recipient", "member_id", ""},
+	{KindPersonalGoals, "personal_goal", "member_id", ""},
+	{KindGoalCheckIns, "personal_goal_check_in", "member_id", ""},
+	{KindTrainingGoals, "training_goal", "member_id", ""},
+	{KindCompetitions, "competition_interest", "member_id", ""},
+	{KindTags, "member_tag", "member_id", ""},
+	{KindBeltSize, "belt_size", "member_id", ""},
+	{KindClassApprovals, "class_type_approval", "member_id", ""},
+	{KindClassPacks, "class_pack", "member_id", ""},
+	{KindClassPackUses, "class_pack_use", "member_id", ""},
+	{KindReferral, "referral", "member_id", ""},
+	{KindReferralsMade, "referral", "referrer_member_id", ""},
+	{KindVisits, "visitor", "member_id", ""},
+	{KindPreferences, "communication_preference", "member_id", ""},
+	{KindContactCheck, "member_contact_check", "member_id", ""},
+	{KindCelebrations, "celebration_profile", "member_id", ""},
+	{KindCelebrationEmails, "celebration_email_sent", "member_id", ""},
+	{KindDigestsSent, "weekly_digest_sent", "member_id", ""},
+	{KindTermReports, "term_report_delivery", "member_id", ""},
+	{KindReengagement, "reengagement_action", "member_id", ""},
+	{KindReengagementSnooze, "reengagement_suppression", "member_id", ""},
+	{KindExportRequests, "export_request", "member_id", ""},
+	{KindDeletionRequests, "deletion_request", "member_id", ""},
+	{KindStatusChanges, "status_change", "subject_id", "subject_type = 'member'"},
+}
+
+// MergeSQLiteStore implements MergeStore using SQLite.
+type MergeSQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewMergeSQLiteStore creates a new member merge store.
+func NewMergeSQLiteStore(db storage.SQLDB) *MergeSQLiteStore {
+	return &MergeSQLiteStore{db: db}
+}
+
+// CountRecords reports what merging fromID into toID would do: how many records of each kind
+// would move, and how many would clash with a record toID already holds, such as the same
+// tag or a check-in for the same class. The merge is rehearsed in a transaction that is
+// rolled back, so the report matches what ReassignRecords does.
+// PRE: fromID and toID are non-empty and differ
+// POST: Returns a count for every kind, zero included, and the kinds with clashes; nothing is changed
+func (s *MergeSQLiteStore) CountRecords(ctx context.Context, fromID, toID string) (map[string]int, map[string]int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+	return reassign(ctx, tx, fromID, toID)
+}
+
+// ReassignRecords moves every record of each kind from one member to another in a single
+// transaction. When both members have an active training goal, the one moved across is
+// deactivated so the survivor keeps a single active goal. A record that would clash with
+// one the survivor already holds, such as the same tag or their own belt size, is not moved
+// or deleted: it stays on fromID and is reported as a conflict.
+// PRE: fromID and toID are non-empty and differ
+// POST: Only conflicting records still belong to fromID; returns how many of each kind moved, and the conflicts
+func (s *MergeSQLiteStore) ReassignRecords(ctx context.Context, fromID, toID string) (map[string]int, map[string]int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+	moved, conflicts, err := reassign(ctx, tx, fromID, toID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return moved, conflicts, tx.Commit()
+}
+
+// reassign moves fromID's records to toID within tx, skipping any a unique constraint
+// refuses, and counts the moved and the skipped by kind.
+func reassign(ctx context.Context, tx *sql.Tx, fromID, toID string) (map[string]int, map[string]int, error) {
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE training_goal SET active = 0
+		 WHERE member_id = ? AND active = 1
+		   AND EXISTS (SELECT 1 FROM training_goal WHERE member_id = ? AND active = 1)`, fromID, toID); err != nil {
+		return nil, nil, err
+	}
+
+	moved := make(map[string]int, len(ownedTables))
+	conflicts := make(map[string]int)
+	for _, t := range ownedTables {
+		res, err := tx.ExecContext(ctx,
+			`UPDATE OR IGNORE `+t.table+` SET `+t.column+` = ? WHERE `+t.match(), toID, fromID)
+		if err != nil {
+			return nil, nil, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, nil, err
+		}
+		moved[t.kind] += int(n)
+		var left int
+		if err := tx.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM `+t.table+` WHERE `+t.match(), fromID).Scan(&left); err != nil {
+			return nil, nil, err
+		}
+		if left > 0 {
+			conflicts[t.kind] += left
+		}
+	}
+	return moved, conflicts, nil
+}
@@ -0,0 +1,138 @@
+package member
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"workshop/internal/adapters/storage"
+
+	_ "modernc.org/sqlite"
+)
+
+// openMergeTestDB returns an in-memory database with every migration applied.
+func openMergeTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test db: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	if err := storage.MigrateDB(db, ":memory:"); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// otherIDColumns are the *_id column names that never hold a member ID. A new *_id column
+// must be listed here or in ownedTables, so a merge cannot silently leave records behind.
+var otherIDColumns = map[string]bool{
+	"account_id": true, "actor_id": true, "assignee_id": true, "attendance_id": true,
+	"auth_session_id": true, "author_id": true, "class_type_id": true, "client_id": true,
+	"coach_id": true, "email_id": true, "entry_id": true, "event_id": true,
+	"external_id": true, "follow_up_email_id": true, "goal_id": true, "location_id": true,
+	"milestone_id": true, "notice_id": true, "observation_id": true, "pack_id": true,
+	"parent_id": true, "program_id": true, "proposal_id": true, "real_account_id": true,
+	"ref_id": true, "reporter_id": true, "resend_message_id": true, "rotor_id": true,
+	"rotor_theme_id": true, "rule_id": true, "schedule_id": true, "sender_id": true,
+	"session_log_id": true, "shared_topic_id": true, "source_id": true, "submission_id": true,
+	"target_id": true, "template_id": true, "template_version_id": true, "term_id": true,
+	"topic_id": true, "visitor_id": true,
+}
+
+// TestOwnedTables_CoverSchema verifies a merge re-parents every column in the schema that
+// holds a member ID, whatever it is called, so a migration adding one cannot leave records
+// behind on the archived duplicate.
+func TestOwnedTables_CoverSchema(t *testing.T) {
+	db := openMergeTestDB(t)
+	covered := map[string]bool{}
+	for _, o := range ownedTables {
+		var n int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, o.table, o.column).Scan(&n); err != nil {
+			t.Fatalf("table info %s: %v", o.table, err)
+		}
+		if n != 1 {
+			t.Errorf("ownedTables lists %s.%s, which is not in the schema", o.table, o.column)
+		}
+		covered[o.table+"."+o.column] = true
+	}
+
+	rows, err := db.Query(`SELECT m.name, p.name FROM sqlite_master m JOIN pragma_table_info(m.name) p
+		WHERE m.type = 'table' AND p.name LIKE '%\_id' ESCAPE '\' ORDER BY m.name, p.name`)
+	if err != nil {
+		t.Fatalf("list id columns: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		if !covered[table+"."+column] && !otherIDColumns[column] {
+			t.Errorf("%s.%s is neither in ownedTables nor a known non-member ID; a merge would leave its rows on the duplicate", table, column)
+		}
+	}
+}
+
+// TestReassignRecords_ReportsConflicts verifies records the survivor already holds, such as
+// the same tag, are reported by the dry run and the merge alike, and stay on the duplicate
+// rather than being deleted.
+func TestReassignRecords_ReportsConflicts(t *testing.T) {
+	db := openMergeTestDB(t)
+	ctx := context.Background()
+	for _, q := range []string{
+		`INSERT INTO member_tag (member_id, tag, created_at) VALUES ('dup', 'competitor', '2026-01-01'), ('dup', 'kids-helper', '2026-01-01'), ('keep', 'competitor', '2026-01-01')`,
+		`INSERT INTO belt_size (member_id, size) VALUES ('dup', 'A2'), ('keep', 'A3')`,
+		`INSERT INTO member_milestone (id, member_id, milestone_id, earned_at) VALUES ('mm1', 'dup', '100-classes', '2026-01-01'), ('mm2', 'keep', '100-classes', '2025-06-01')`,
+		`INSERT INTO status_change (id, subject_type, subject_id, action, effective_date, created_by, created_at)
+			VALUES ('sc1', 'member', 'dup', 'freeze', '2026-11-01', 'admin-1', '2026-10-01'), ('sc2', 'account', 'dup', 'suspend', '2026-11-01', 'admin-1', '2026-10-01')`,
+	} {
+		if _, err := db.Exec(q); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+	store := NewMergeSQLiteStore(db)
+	want := map[string]int{KindTags: 1, KindBeltSize: 1, KindMilestones: 1}
+
+	preview, previewConflicts, err := store.CountRecords(ctx, "dup", "keep")
+	if err != nil {
+		t.Fatalf("CountRecords: %v", err)
+	}
+	moved, conflicts, err := store.ReassignRecords(ctx, "dup", "keep")
+	if err != nil {
+		t.Fatalf("ReassignRecords: %v", err)
+	}
+	for kind, n := range want {
+		if previewConflicts[kind] != n || conflicts[kind] != n {
+			t.Errorf("%s conflicts: dry run %d, merge %d; want %d", kind, previewConflicts[kind], conflicts[kind], n)
+		}
+	}
+	if len(conflicts) != len(want) {
+		t.Errorf("conflicts = %v, want %v", conflicts, want)
+	}
+	for kind, n := range moved {
+		if preview[kind] != n {
+			t.Errorf("%s: dry run reported %d moving, merge moved %d", kind, preview[kind], n)
+		}
+	}
+	if moved[KindTags] != 1 || moved[KindBeltSize] != 0 || moved[KindMilestones] != 0 || moved[KindStatusChanges] != 1 {
+		t.Errorf("moved = %v, want the new tag and the member freeze", moved)
+	}
+
+	var left int
+	db.QueryRow(`SELECT (SELECT COUNT(*) FROM member_tag WHERE member_id = 'dup') + (SELECT COUNT(*) FROM belt_size WHERE member_id = 'dup') + (SELECT COUNT(*) FROM member_milestone WHERE member_id = 'dup')`).Scan(&left)
+	if left != 3 {
+		t.Errorf("%d conflicting records left on the duplicate, want all 3 kept", left)
+	}
+	var size string
+	db.QueryRow(`SELECT size FROM belt_size WHERE member_id = 'keep'`).Scan(&size)
+	if size != "A3" {
+		t.Errorf("survivor belt size = %q, want their own A3", size)
+	}
+	var accountChange string
+	db.QueryRow(`SELECT subject_id FROM status_change WHERE id = 'sc2'`).Scan(&accountChange)
+	if accountChange != "dup" {
+		t.Errorf("account status change subject = %q, want it untouched", accountChange)
+	}
+}
@@ -164,10 +164,11 @@ func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
 }
 
 // SearchByName finds members whose name matches the query (case-insensitive LIKE).
+// Archived and frozen members are left out, since search feeds check-in.
 // PRE: query is non-empty, limit > 0
 // POST: Returns matching members ordered by name
 func (s *SQLiteStore) SearchByName(ctx context.Context, query string, limit int) ([]domain.Member, error) {
-	q := "SELECT id, account_id, email, fee, frequency, name, program, status, grading_metric FROM member WHERE name LIKE ? AND status != 'archived' ORDER BY name LIMIT ?"
+	q := "SELECT id, account_id, email, fee, frequency, name, program, status, grading_metric FROM member WHERE name LIKE ? AND status NOT IN ('archived', 'frozen') ORDER BY name LIMIT ?"
 	rows, err := s.db.QueryContext(ctx, q, "%"+query+"%", limit)
 	if err != nil {
 		return nil, err
@@ -217,6 +218,19 @@ func listWhereClause(filter ListFilter) (string, []any) {
 		term := "%" + filter.Search + "%"
 		args = append(args, term, term)
 	}
+	if filter.Tag != "" {
+		where += " AND id IN (SELECT member_id FROM member_tag WHERE tag = ?)"
+		args = append(args, filter.Tag)
+	}
+	if filter.IDs != nil {
+		if len(filter.IDs) == 0 {
+			return where + " AND 0", args
+		}
+		where += " AND id IN (?" + strings.Repeat(", ?", len(filter.IDs)-1) + ")"
+		for _, id := range filter.IDs {
+			args = append(args, id)
+		}
+	}
 	return where, args
 }
 
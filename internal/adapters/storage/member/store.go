@@ -18,13 +18,23 @@ type Store interface {
 	SearchByName(ctx context.Context, query string, limit int) ([]domain.Member, error)
 }
 
+// MergeStore moves a member's history onto another member record when duplicates are merged.
+// Counts are keyed by record kind (the Kind constants): those moved, and those left behind
+// because the survivor already holds the same record.
+type MergeStore interface {
+	CountRecords(ctx context.Context, fromID, toID string) (map[string]int, map[string]int, error)
+	ReassignRecords(ctx context.Context, fromID, toID string) (map[string]int, map[string]int, error)
+}
+
 // ListFilter carries filtering parameters for List operations.
 type ListFilter struct {
 	Limit   int
 	Offset  int
 	Program string
 	Status  string
-	Search  string // case-insensitive LIKE on name or email
-	Sort    string // column to sort by (name, email, program, status)
-	Dir     string // "asc" or "desc"
+	Search  string   // case-insensitive LIKE on name or email
+	Tag     string   // optional: members carrying this tag
+	IDs     []string // optional: only these members (a resolved segment); nil does not filter, empty matches none
+	Sort    string   // column to sort by (name, email, program, status)
+	Dir     string   // "asc" or "desc"
 }
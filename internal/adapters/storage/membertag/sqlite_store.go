@@ -0,0 +1,200 @@
+package membertag
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/membertag"
+)
+
+// segmentColumns is the shared column list for segment SELECTs; order matches scanSegment.
+const segmentColumns = "id, name, tags, program, status, attended_within_days, absent_for_days, created_by, created_at"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// AddTag puts a tag on a member; tagging twice is a no-op.
+// PRE: memberID is non-empty; tag is normalised
+// POST: The member carries the tag
+func (s *SQLiteStore) AddTag(ctx context.Context, memberID, tag string, at time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		"INSERT OR IGNORE INTO member_tag (member_id, tag, created_at) VALUES (?, ?, ?)",
+		memberID, tag, at.UTC().Format(time.RFC3339))
+	return err
+}
+
+// RemoveTag takes a tag off a member.
+// PRE: memberID and tag are non-empty
+// POST: The member no longer carries the tag
+func (s *SQLiteStore) RemoveTag(ctx context.Context, memberID, tag string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM member_tag WHERE member_id = ? AND tag = ?", memberID, tag)
+	return err
+}
+
+// ListByMember returns a member's tags.
+// PRE: memberID is non-empty
+// POST: Returns tags in alphabetical order, or an empty slice
+func (s *SQLiteStore) ListByMember(ctx context.Context, memberID string) ([]string, error) {
+	return s.queryStrings(ctx, "SELECT tag FROM member_tag WHERE member_id = ? ORDER BY tag", memberID)
+}
+
+// ListMemberIDs returns the IDs of members carrying a tag.
+// PRE: tag is normalised
+// POST: Returns member IDs, or an empty slice
+func (s *SQLiteStore) ListMemberIDs(ctx context.Context, tag string) ([]string, error) {
+	return s.queryStrings(ctx, "SELECT member_id FROM member_tag WHERE tag = ? ORDER BY member_id", tag)
+}
+
+// ListTags returns every tag in use with how many members carry it.
+// PRE: none
+// POST: Returns tags in alphabetical order, or an empty slice
+func (s *SQLiteStore) ListTags(ctx context.Context) ([]domain.TagCount, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT tag, COUNT(*) FROM member_tag GROUP BY tag ORDER BY tag")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.TagCount
+	for rows.Next() {
+		var tc domain.TagCount
+		if err := rows.Scan(&tc.Tag, &tc.Members); err != nil {
+			return nil, err
+		}
+		list = append(list, tc)
+	}
+	return list, rows.Err()
+}
+
+// RenameTag renames a tag on every member, merging into the new tag where a member has both.
+// PRE: from and to are normalised and differ
+// POST: No member carries from; everyone who did now carries to
+func (s *SQLiteStore) RenameTag(ctx context.Context, from, to string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		"INSERT OR IGNORE INTO member_tag (member_id, tag, created_at) SELECT member_id, ?, created_at FROM member_tag WHERE tag = ?",
+		to, from); err != nil {
+		return fmt.Errorf("rename member_tag: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM member_tag WHERE tag = ?", from); err != nil {
+		return fmt.Errorf("rename member_tag: %w", err)
+	}
+	return tx.Commit()
+}
+
+// DeleteTag removes a tag from every member.
+// PRE: tag is non-empty
+// POST: No member carries the tag
+func (s *SQLiteStore) DeleteTag(ctx context.Context, tag string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM member_tag WHERE tag = ?", tag)
+	return err
+}
+
+// GetSegment retrieves a saved segment by ID.
+// PRE: id is non-empty
+// POST: Returns the segment or an error if not found
+func (s *SQLiteStore) GetSegment(ctx context.Context, id string) (domain.Segment, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+segmentColumns+" FROM member_segment WHERE id = ?", id)
+	seg, err := scanSegment(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Segment{}, fmt.Errorf("member segment not found: %w", err)
+	}
+	return seg, err
+}
+
+// SaveSegment persists a segment (insert or update).
+// PRE: value has been validated
+// POST: The segment is persisted
+func (s *SQLiteStore) SaveSegment(ctx context.Context, value domain.Segment) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO member_segment (`+segmentColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET name = excluded.name, tags = excluded.tags, program = excluded.program, status = excluded.status,
+		attended_within_days = excluded.attended_within_days, absent_for_days = excluded.absent_for_days`,
+		value.ID, value.Name, strings.Join(value.Tags, ","), value.Program, value.Status,
+		value.AttendedWithinDays, value.AbsentForDays, value.CreatedBy, value.CreatedAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+// DeleteSegment removes a saved segment. Members and their tags are untouched.
+// PRE: id is non-empty
+// POST: The segment is gone
+func (s *SQLiteStore) DeleteSegment(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM member_segment WHERE id = ?", id)
+	return err
+}
+
+// ListSegments returns every saved segment.
+// PRE: none
+// POST: Returns segments ordered by name, or an empty slice
+func (s *SQLiteStore) ListSegments(ctx context.Context) ([]domain.Segment, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+segmentColumns+" FROM member_segment ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Segment
+	for rows.Next() {
+		seg, err := scanSegment(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, seg)
+	}
+	return list, rows.Err()
+}
+
+// queryStrings runs a single-column SELECT and scans every row.
+func (s *SQLiteStore) queryStrings(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, rows.Err()
+}
+
+// scanSegment extracts a Segment from a row scanner function.
+func scanSegment(scan func(dest ...interface{}) error) (domain.Segment, error) {
+	var seg domain.Segment
+	var tags, createdAt string
+	if err := scan(&seg.ID, &seg.Name, &tags, &seg.Program, &seg.Status,
+		&seg.AttendedWithinDays, &seg.AbsentForDays, &seg.CreatedBy, &createdAt); err != nil {
+		return domain.Segment{}, err
+	}
+	if tags != "" {
+		seg.Tags = strings.Split(tags, ",")
+	}
+	seg.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return seg, nil
+}
+
+// Ensure interface compliance at compile time.
+var _ Store = (*SQLiteStore)(nil)
@@ -0,0 +1,23 @@
+package membertag
+
+import (
+	"context"
+	"time"
+
+	domain "workshop/internal/domain/membertag"
+)
+
+// Store persists member tags and saved smart segments.
+type Store interface {
+	AddTag(ctx context.Context, memberID, tag string, at time.Time) error
+	RemoveTag(ctx context.Context, memberID, tag string) error
+	ListByMember(ctx context.Context, memberID string) ([]string, error)
+	ListMemberIDs(ctx context.Context, tag string) ([]string, error)
+	ListTags(ctx context.Context) ([]domain.TagCount, error)
+	RenameTag(ctx context.Context, from, to string) error
+	DeleteTag(ctx context.Context, tag string) error
+	GetSegment(ctx context.Context, id string) (domain.Segment, error)
+	SaveSegment(ctx context.Context, value domain.Segment) error
+	DeleteSegment(ctx context.Context, id string) error
+	ListSegments(ctx context.Context) ([]domain.Segment, error)
+}
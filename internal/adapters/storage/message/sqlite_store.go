@@ -3,6 +3,7 @@ package message
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"workshop/internal/adapters/storage"
@@ -11,6 +12,8 @@ import (
 
 const timeLayout = "2006-01-02T15:04:05Z07:00"
 
+const messageColumns = `id, parent_id, sender_id, receiver_id, from_member, subject, content, attachments, read_at, created_at`
+
 // SQLiteStore implements Store using SQLite.
 type SQLiteStore struct {
 	db storage.SQLDB
@@ -26,8 +29,7 @@ func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
 // POST: Returns the entity or an error if not found
 func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Message, error) {
 	row := s.db.QueryRowContext(ctx,
-		`SELECT id, sender_id, receiver_id, subject, content, read_at, created_at
-		 FROM message WHERE id = ?`, id)
+		`SELECT `+messageColumns+` FROM message WHERE id = ?`, id)
 	return scanMessage(row)
 }
 
@@ -35,14 +37,19 @@ func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Message, e
 // PRE: entity has been validated
 // POST: Entity is persisted (insert or update)
 func (s *SQLiteStore) Save(ctx context.Context, m domain.Message) error {
-	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO message (id, sender_id, receiver_id, subject, content, read_at, created_at)
-		 VALUES (?, ?, ?, ?, ?, ?, ?)
+	attachments, err := marshalAttachments(m.Attachments)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO message (`+messageColumns+`)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		 ON CONFLICT(id) DO UPDATE SET
-		   sender_id=excluded.sender_id, receiver_id=excluded.receiver_id,
-		   subject=excluded.subject, content=excluded.content,
+		   parent_id=excluded.parent_id, sender_id=excluded.sender_id,
+		   receiver_id=excluded.receiver_id, from_member=excluded.from_member,
+		   subject=excluded.subject, content=excluded.content, attachments=excluded.attachments,
 		   read_at=excluded.read_at, created_at=excluded.created_at`,
-		m.ID, m.SenderID, m.ReceiverID, nullStr(m.Subject), m.Content,
+		m.ID, m.ParentID, m.SenderID, m.ReceiverID, boolToInt(m.FromMember), nullStr(m.Subject), m.Content, attachments,
 		nullTime(m.ReadAt), m.CreatedAt.Format(timeLayout))
 	return err
 }
@@ -60,8 +67,7 @@ func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
 // POST: Returns messages for the given receiver
 func (s *SQLiteStore) ListByReceiverID(ctx context.Context, receiverID string) ([]domain.Message, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, sender_id, receiver_id, subject, content, read_at, created_at
-		 FROM message WHERE receiver_id = ? ORDER BY created_at DESC`, receiverID)
+		`SELECT `+messageColumns+` FROM message WHERE receiver_id = ? ORDER BY created_at DESC`, receiverID)
 	if err != nil {
 		return nil, err
 	}
@@ -69,24 +75,56 @@ func (s *SQLiteStore) ListByReceiverID(ctx context.Context, receiverID string) (
 	return scanMessages(rows)
 }
 
-// CountUnread counts unread messages for a receiver.
+// ListByThreadID retrieves a thread's first message and its replies.
+// PRE: threadID is non-empty
+// POST: Returns messages oldest first
+func (s *SQLiteStore) ListByThreadID(ctx context.Context, threadID string) ([]domain.Message, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+messageColumns+` FROM message WHERE id = ? OR parent_id = ? ORDER BY created_at ASC`, threadID, threadID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanMessages(rows)
+}
+
+// ListUnreadReplies retrieves member replies that staff have not read yet.
+// PRE: none
+// POST: Returns unread member-written messages, newest first
+func (s *SQLiteStore) ListUnreadReplies(ctx context.Context) ([]domain.Message, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+messageColumns+` FROM message WHERE from_member = 1 AND read_at IS NULL ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanMessages(rows)
+}
+
+// CountUnread counts unread staff messages for a receiver.
+// The member's own replies are never unread for them.
 // PRE: receiverID is non-empty
-// POST: Returns count of unread messages
+// POST: Returns count of unread messages written by staff
 func (s *SQLiteStore) CountUnread(ctx context.Context, receiverID string) (int, error) {
 	var count int
 	err := s.db.QueryRowContext(ctx,
-		`SELECT COUNT(*) FROM message WHERE receiver_id = ? AND read_at IS NULL`, receiverID).Scan(&count)
+		`SELECT COUNT(*) FROM message WHERE receiver_id = ? AND from_member = 0 AND read_at IS NULL`, receiverID).Scan(&count)
 	return count, err
 }
 
 func scanMessage(row *sql.Row) (domain.Message, error) {
 	var m domain.Message
 	var subject, readAt sql.NullString
-	var createdAt string
-	err := row.Scan(&m.ID, &m.SenderID, &m.ReceiverID, &subject, &m.Content, &readAt, &createdAt)
+	var attachments, createdAt string
+	var fromMember int
+	err := row.Scan(&m.ID, &m.ParentID, &m.SenderID, &m.ReceiverID, &fromMember, &subject, &m.Content, &attachments, &readAt, &createdAt)
 	if err != nil {
 		return domain.Message{}, err
 	}
+	if m.Attachments, err = unmarshalAttachments(attachments); err != nil {
+		return domain.Message{}, err
+	}
+	m.FromMember = fromMember == 1
 	m.CreatedAt, _ = time.Parse(timeLayout, createdAt)
 	if subject.Valid {
 		m.Subject = subject.String
@@ -102,11 +140,16 @@ func scanMessages(rows *sql.Rows) ([]domain.Message, error) {
 	for rows.Next() {
 		var m domain.Message
 		var subject, readAt sql.NullString
-		var createdAt string
-		err := rows.Scan(&m.ID, &m.SenderID, &m.ReceiverID, &subject, &m.Content, &readAt, &createdAt)
+		var attachments, createdAt string
+		var fromMember int
+		err := rows.Scan(&m.ID, &m.ParentID, &m.SenderID, &m.ReceiverID, &fromMember, &subject, &m.Content, &attachments, &readAt, &createdAt)
 		if err != nil {
 			return nil, err
 		}
+		if m.Attachments, err = unmarshalAttachments(attachments); err != nil {
+			return nil, err
+		}
+		m.FromMember = fromMember == 1
 		m.CreatedAt, _ = time.Parse(timeLayout, createdAt)
 		if subject.Valid {
 			m.Subject = subject.String
@@ -119,6 +162,25 @@ func scanMessages(rows *sql.Rows) ([]domain.Message, error) {
 	return messages, rows.Err()
 }
 
+// marshalAttachments encodes attachments as a JSON array, or "" when there are none.
+func marshalAttachments(attachments []domain.Attachment) (string, error) {
+	if len(attachments) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(attachments)
+	return string(b), err
+}
+
+// unmarshalAttachments decodes the attachments column; "" means none.
+func unmarshalAttachments(s string) ([]domain.Attachment, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var attachments []domain.Attachment
+	err := json.Unmarshal([]byte(s), &attachments)
+	return attachments, err
+}
+
 func nullStr(s string) interface{} {
 	if s == "" {
 		return nil
@@ -132,3 +194,10 @@ func nullTime(t time.Time) interface{} {
 	}
 	return t.Format(timeLayout)
 }
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -12,5 +12,7 @@ type Store interface {
 	Save(ctx context.Context, value domain.Message) error
 	Delete(ctx context.Context, id string) error
 	ListByReceiverID(ctx context.Context, receiverID string) ([]domain.Message, error)
+	ListByThreadID(ctx context.Context, threadID string) ([]domain.Message, error)
+	ListUnreadReplies(ctx context.Context) ([]domain.Message, error)
 	CountUnread(ctx context.Context, receiverID string) (int, error)
 }
@@ -120,6 +120,31 @@ func (s *SQLiteStore) ListPublished(ctx context.Context, noticeType string, now
 	return scanNotices(rows)
 }
 
+// ArchiveExpired moves published notices whose VisibleUntil has passed to archived, so the
+// published list only holds notices that can still be shown.
+// PRE: now is the current time
+// POST: Returns the IDs of the notices archived; UpdatedAt is now on each
+func (s *SQLiteStore) ArchiveExpired(ctx context.Context, now time.Time) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`UPDATE notice SET status = 'archived', updated_at = ?
+		 WHERE status = 'published' AND visible_until IS NOT NULL AND julianday(visible_until) < julianday(?)
+		 RETURNING id`,
+		now.Format(timeLayout), now.UTC().Format(timeLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 // scannedRow holds the raw scanned values from a notice row before conversion.
 type scannedRow struct {
 	publishedBy  sql.NullString
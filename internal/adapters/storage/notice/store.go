@@ -14,6 +14,7 @@ type Store interface {
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, filter ListFilter) ([]domain.Notice, error)
 	ListPublished(ctx context.Context, noticeType string, now time.Time) ([]domain.Notice, error)
+	ArchiveExpired(ctx context.Context, now time.Time) ([]string, error) // returns the archived notices' IDs
 }
 
 // ListFilter carries filtering parameters for List operations.
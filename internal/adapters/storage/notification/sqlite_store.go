@@ -0,0 +1,251 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/notification"
+)
+
+const notificationColumns = "id, account_id, kind, title, body, link, read_at, created_at"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new NotificationStore.
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// GetByID retrieves a Notification by its ID.
+// PRE: id is non-empty
+// POST: Returns the entity or an error if not found
+func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Notification, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+notificationColumns+" FROM notification WHERE id = ?", id)
+	n, err := scanNotification(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Notification{}, fmt.Errorf("notification not found: %w", err)
+	}
+	return n, err
+}
+
+// Save persists a Notification to the database.
+// PRE: entity has been validated
+// POST: Entity is persisted (insert or update)
+func (s *SQLiteStore) Save(ctx context.Context, n domain.Notification) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO notification (`+notificationColumns+`)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET
+		   title=excluded.title, body=excluded.body, link=excluded.link, read_at=excluded.read_at`,
+		n.ID, n.AccountID, n.Kind, n.Title, n.Body, n.Link, nullTime(n.ReadAt), n.CreatedAt.Format(time.RFC3339))
+	return err
+}
+
+// ListByAccountID retrieves the most recent Notifications for an account, newest first.
+// PRE: accountID is non-empty; limit <= 0 means no limit
+// POST: Returns notifications for the given account
+func (s *SQLiteStore) ListByAccountID(ctx context.Context, accountID string, limit int) ([]domain.Notification, error) {
+	query := "SELECT " + notificationColumns + " FROM notification WHERE account_id = ? ORDER BY created_at DESC, id"
+	args := []interface{}{accountID}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []domain.Notification
+	for rows.Next() {
+		n, err := scanNotification(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, n)
+	}
+	return results, rows.Err()
+}
+
+// CountUnread counts unread Notifications for an account.
+// PRE: accountID is non-empty
+// POST: Returns count of unread notifications
+func (s *SQLiteStore) CountUnread(ctx context.Context, accountID string) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM notification WHERE account_id = ? AND read_at IS NULL", accountID).Scan(&count)
+	return count, err
+}
+
+// MarkAllRead marks every unread Notification for an account as read.
+// PRE: accountID is non-empty
+// POST: No unread notifications remain for the account
+func (s *SQLiteStore) MarkAllRead(ctx context.Context, accountID string, readAt time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		"UPDATE notification SET read_at = ? WHERE account_id = ? AND read_at IS NULL",
+		readAt.Format(time.RFC3339), accountID)
+	return err
+}
+
+// ListPreferences retrieves the stored channel preferences for an account.
+// Kinds without a stored row are not returned; callers apply domain defaults.
+// PRE: accountID is non-empty
+// POST: Returns stored preferences
+func (s *SQLiteStore) ListPreferences(ctx context.Context, accountID string) ([]domain.Preference, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT account_id, kind, in_app, email, push FROM notification_preference WHERE account_id = ? ORDER BY kind", accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []domain.Preference
+	for rows.Next() {
+		var p domain.Preference
+		var inApp, email, push int
+		if err := rows.Scan(&p.AccountID, &p.Kind, &inApp, &email, &push); err != nil {
+			return nil, err
+		}
+		p.InApp = inApp == 1
+		p.Email = email == 1
+		p.Push = push == 1
+		results = append(results, p)
+	}
+	return results, rows.Err()
+}
+
+// SavePreference persists a channel preference for one account and kind.
+// PRE: entity has been validated
+// POST: Preference is persisted (insert or update)
+func (s *SQLiteStore) SavePreference(ctx context.Context, p domain.Preference) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO notification_preference (account_id, kind, in_app, email, push) VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT(account_id, kind) DO UPDATE SET in_app=excluded.in_app, email=excluded.email, push=excluded.push`,
+		p.AccountID, p.Kind, boolInt(p.InApp), boolInt(p.Email), boolInt(p.Push))
+	return err
+}
+
+// SavePushSubscription stores a browser's push subscription. A browser that subscribes
+// again, even from another account, replaces its earlier row.
+// PRE: entity has been validated
+// POST: Subscription is persisted (insert or update by endpoint)
+func (s *SQLiteStore) SavePushSubscription(ctx context.Context, p domain.PushSubscription) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO push_subscription (id, account_id, endpoint, p256dh, auth, user_agent, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(endpoint) DO UPDATE SET
+		   account_id=excluded.account_id, p256dh=excluded.p256dh, auth=excluded.auth,
+		   user_agent=excluded.user_agent, created_at=excluded.created_at`,
+		p.ID, p.AccountID, p.Endpoint, p.P256dh, p.Auth, p.UserAgent, p.CreatedAt.Format(time.RFC3339))
+	return err
+}
+
+// ListPushSubscriptions retrieves every browser subscribed for an account, oldest first.
+// PRE: accountID is non-empty
+// POST: Returns the account's subscriptions
+func (s *SQLiteStore) ListPushSubscriptions(ctx context.Context, accountID string) ([]domain.PushSubscription, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, account_id, endpoint, p256dh, auth, user_agent, created_at
+		 FROM push_subscription WHERE account_id = ? ORDER BY created_at, id`, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []domain.PushSubscription
+	for rows.Next() {
+		var p domain.PushSubscription
+		var createdAt string
+		if err := rows.Scan(&p.ID, &p.AccountID, &p.Endpoint, &p.P256dh, &p.Auth, &p.UserAgent, &createdAt); err != nil {
+			return nil, err
+		}
+		p.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		results = append(results, p)
+	}
+	return results, rows.Err()
+}
+
+// DeletePushSubscription removes the subscription for a push endpoint.
+// PRE: endpoint is non-empty
+// POST: No subscription remains for the endpoint
+func (s *SQLiteStore) DeletePushSubscription(ctx context.Context, endpoint string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM push_subscription WHERE endpoint = ?", endpoint)
+	return err
+}
+
+// MarkClassReminderSent records that an account was reminded of one class occurrence.
+// PRE: accountID, scheduleID and classDate (YYYY-MM-DD) are non-empty
+// POST: Returns true if this is the first reminder for the occurrence, false if one was already recorded
+func (s *SQLiteStore) MarkClassReminderSent(ctx context.Context, accountID, scheduleID, classDate string, sentAt time.Time) (bool, error) {
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO class_reminder_sent (account_id, schedule_id, class_date, sent_at) VALUES (?, ?, ?, ?)
+		 ON CONFLICT(account_id, schedule_id, class_date) DO NOTHING`,
+		accountID, scheduleID, classDate, sentAt.Format(time.RFC3339))
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// DeleteClassRemindersBefore forgets reminders for classes before a date.
+// PRE: classDate is YYYY-MM-DD
+// POST: No reminder records remain for earlier classes
+func (s *SQLiteStore) DeleteClassRemindersBefore(ctx context.Context, classDate string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM class_reminder_sent WHERE class_date < ?", classDate)
+	return err
+}
+
+// MarkCapacityAlertSent records that staff were alerted to an overcrowded class occurrence.
+// Alerts are kept as a record of each breach.
+// PRE: scheduleID and classDate (YYYY-MM-DD) are non-empty
+// POST: Returns true if this is the first alert for the occurrence, false if one was already recorded
+func (s *SQLiteStore) MarkCapacityAlertSent(ctx context.Context, scheduleID, classDate string, headcount int, sentAt time.Time) (bool, error) {
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO class_capacity_alert (schedule_id, class_date, headcount, sent_at) VALUES (?, ?, ?, ?)
+		 ON CONFLICT(schedule_id, class_date) DO NOTHING`,
+		scheduleID, classDate, headcount, sentAt.Format(time.RFC3339))
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// scanNotification reads one notification row using the given Scan func.
+func scanNotification(scan func(dest ...interface{}) error) (domain.Notification, error) {
+	var n domain.Notification
+	var readAt sql.NullString
+	var createdAt string
+	if err := scan(&n.ID, &n.AccountID, &n.Kind, &n.Title, &n.Body, &n.Link, &readAt, &createdAt); err != nil {
+		return domain.Notification{}, err
+	}
+	n.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	if readAt.Valid {
+		n.ReadAt, _ = time.Parse(time.RFC3339, readAt.String)
+	}
+	return n, nil
+}
+
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Format(time.RFC3339)
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+var _ Store = (*SQLiteStore)(nil)
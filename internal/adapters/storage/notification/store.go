@@ -0,0 +1,26 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	domain "workshop/internal/domain/notification"
+)
+
+// Store persists Notification state, per-account channel preferences, Web Push
+// subscriptions and the class reminders already sent.
+type Store interface {
+	GetByID(ctx context.Context, id string) (domain.Notification, error)
+	Save(ctx context.Context, value domain.Notification) error
+	ListByAccountID(ctx context.Context, accountID string, limit int) ([]domain.Notification, error)
+	CountUnread(ctx context.Context, accountID string) (int, error)
+	MarkAllRead(ctx context.Context, accountID string, readAt time.Time) error
+	ListPreferences(ctx context.Context, accountID string) ([]domain.Preference, error)
+	SavePreference(ctx context.Context, value domain.Preference) error
+	SavePushSubscription(ctx context.Context, value domain.PushSubscription) error
+	ListPushSubscriptions(ctx context.Context, accountID string) ([]domain.PushSubscription, error)
+	DeletePushSubscription(ctx context.Context, endpoint string) error
+	MarkClassReminderSent(ctx context.Context, accountID, scheduleID, classDate string, sentAt time.Time) (bool, error)
+	DeleteClassRemindersBefore(ctx context.Context, classDate string) error
+	MarkCapacityAlertSent(ctx context.Context, scheduleID, classDate string, headcount int, sentAt time.Time) (bool, error)
+}
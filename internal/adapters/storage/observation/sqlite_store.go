@@ -26,7 +26,7 @@ func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
 // POST: Returns the entity or an error if not found
 func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Observation, error) {
 	row := s.db.QueryRowContext(ctx,
-		`SELECT id, member_id, author_id, content, created_at, updated_at
+		`SELECT id, member_id, author_id, content, visibility, created_at, updated_at
 		 FROM coach_observation WHERE id = ?`, id)
 	return scanObservation(row)
 }
@@ -36,12 +36,12 @@ func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Observatio
 // POST: Entity is persisted (insert or update)
 func (s *SQLiteStore) Save(ctx context.Context, o domain.Observation) error {
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO coach_observation (id, member_id, author_id, content, created_at, updated_at)
-		 VALUES (?, ?, ?, ?, ?, ?)
+		`INSERT INTO coach_observation (id, member_id, author_id, content, visibility, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)
 		 ON CONFLICT(id) DO UPDATE SET
-		   member_id=excluded.member_id, author_id=excluded.author_id,
-		   content=excluded.content, created_at=excluded.created_at, updated_at=excluded.updated_at`,
-		o.ID, o.MemberID, o.AuthorID, o.Content,
+		   member_id=excluded.member_id, author_id=excluded.author_id, content=excluded.content,
+		   visibility=excluded.visibility, created_at=excluded.created_at, updated_at=excluded.updated_at`,
+		o.ID, o.MemberID, o.AuthorID, o.Content, visibilityOrDefault(o.Visibility),
 		o.CreatedAt.Format(timeLayout), nullTime(o.UpdatedAt))
 	return err
 }
@@ -59,7 +59,7 @@ func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
 // POST: Returns observations for the given member
 func (s *SQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]domain.Observation, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, member_id, author_id, content, created_at, updated_at
+		`SELECT id, member_id, author_id, content, visibility, created_at, updated_at
 		 FROM coach_observation WHERE member_id = ? ORDER BY created_at DESC`, memberID)
 	if err != nil {
 		return nil, err
@@ -71,7 +71,7 @@ func (s *SQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]do
 		var o domain.Observation
 		var createdAt string
 		var updatedAt sql.NullString
-		err := rows.Scan(&o.ID, &o.MemberID, &o.AuthorID, &o.Content, &createdAt, &updatedAt)
+		err := rows.Scan(&o.ID, &o.MemberID, &o.AuthorID, &o.Content, &o.Visibility, &createdAt, &updatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -88,7 +88,7 @@ func scanObservation(row *sql.Row) (domain.Observation, error) {
 	var o domain.Observation
 	var createdAt string
 	var updatedAt sql.NullString
-	err := row.Scan(&o.ID, &o.MemberID, &o.AuthorID, &o.Content, &createdAt, &updatedAt)
+	err := row.Scan(&o.ID, &o.MemberID, &o.AuthorID, &o.Content, &o.Visibility, &createdAt, &updatedAt)
 	if err != nil {
 		return domain.Observation{}, err
 	}
@@ -105,3 +105,11 @@ func nullTime(t time.Time) interface{} {
 	}
 	return t.Format(timeLayout)
 }
+
+// visibilityOrDefault stores an unset visibility as coaches-only.
+func visibilityOrDefault(v string) string {
+	if v == "" {
+		return domain.VisibilityCoaches
+	}
+	return v
+}
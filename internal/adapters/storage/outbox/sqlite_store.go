@@ -108,6 +108,27 @@ func (s *SQLiteStore) ListByActionType(ctx context.Context, actionType string, s
 	return scanEntries(rows)
 }
 
+// CountByStatus returns how many entries are in each status.
+// PRE: none
+// POST: Returns a count per status present in the table
+func (s *SQLiteStore) CountByStatus(ctx context.Context) (map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM outbox GROUP BY status`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	counts := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var n int
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, err
+		}
+		counts[status] = n
+	}
+	return counts, rows.Err()
+}
+
 // Delete removes an outbox entry (only for abandoned/terminal entries).
 // PRE: id is non-empty and entry is in terminal state
 // POST: Entry is removed from database
@@ -33,6 +33,11 @@ type Store interface {
 	// POST: Returns matching entries ordered by created_at
 	ListByActionType(ctx context.Context, actionType string, status string, limit int) ([]domain.Entry, error)
 
+	// CountByStatus returns how many entries are in each status.
+	// PRE: none
+	// POST: Returns a count per status present in the table
+	CountByStatus(ctx context.Context) (map[string]int, error)
+
 	// Delete removes an outbox entry (only for abandoned/terminal entries).
 	// PRE: id is non-empty and entry is in terminal state
 	// POST: Entry is removed from database
@@ -0,0 +1,119 @@
+package perfstats
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/perfstats"
+)
+
+// minuteLayout stores bucket minutes in UTC so they sort as text.
+const minuteLayout = "2006-01-02T15:04:05Z"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// Pass the plain database rather than the TimedDB, so flushing timings is not itself timed.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// SaveBuckets appends the buckets in one transaction.
+// PRE: each bucket has been validated
+// POST: Every bucket is persisted, or none is
+func (s *SQLiteStore) SaveBuckets(ctx context.Context, buckets []domain.Bucket) error {
+	if len(buckets) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO perf_bucket (minute, kind, name, count, sum_ms, max_ms, counts) VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, b := range buckets {
+		if _, err := stmt.ExecContext(ctx, b.Minute.UTC().Format(minuteLayout), b.Kind, b.Name, b.Count, b.SumMs, b.MaxMs, formatCounts(b.Counts)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ListBuckets returns the buckets whose minute starts in [since, until), oldest first.
+// PRE: since is before until
+// POST: Returns buckets or an empty slice
+func (s *SQLiteStore) ListBuckets(ctx context.Context, since, until time.Time) ([]domain.Bucket, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT minute, kind, name, count, sum_ms, max_ms, counts FROM perf_bucket
+		 WHERE minute >= ? AND minute < ? ORDER BY minute, kind, name`,
+		since.UTC().Format(minuteLayout), until.UTC().Format(minuteLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Bucket
+	for rows.Next() {
+		var b domain.Bucket
+		var minute, counts string
+		if err := rows.Scan(&minute, &b.Kind, &b.Name, &b.Count, &b.SumMs, &b.MaxMs, &counts); err != nil {
+			return nil, err
+		}
+		b.Minute, _ = time.Parse(minuteLayout, minute)
+		b.Counts = parseCounts(counts)
+		list = append(list, b)
+	}
+	return list, rows.Err()
+}
+
+// DeleteBefore removes buckets whose minute starts before cutoff.
+// PRE: none
+// POST: Returns the number of buckets removed
+func (s *SQLiteStore) DeleteBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM perf_bucket WHERE minute < ?", cutoff.UTC().Format(minuteLayout))
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+// formatCounts joins histogram counts with commas.
+func formatCounts(counts []uint64) string {
+	parts := make([]string, len(counts))
+	for i, n := range counts {
+		parts[i] = strconv.FormatUint(n, 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseCounts splits a comma-separated histogram; a malformed value yields nil, which
+// perfstats.Summarise skips.
+func parseCounts(s string) []uint64 {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	counts := make([]uint64, len(parts))
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return nil
+		}
+		counts[i] = n
+	}
+	return counts
+}
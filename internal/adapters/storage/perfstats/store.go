@@ -0,0 +1,15 @@
+package perfstats
+
+import (
+	"context"
+	"time"
+
+	domain "workshop/internal/domain/perfstats"
+)
+
+// Store persists per-minute request and query timing buckets.
+type Store interface {
+	SaveBuckets(ctx context.Context, buckets []domain.Bucket) error
+	ListBuckets(ctx context.Context, since, until time.Time) ([]domain.Bucket, error)
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int, error)
+}
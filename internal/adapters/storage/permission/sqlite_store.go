@@ -0,0 +1,113 @@
+package permission
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/permission"
+)
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new Permission store.
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// List returns all stored overrides.
+// PRE: none
+// POST: Returns overrides sorted by action
+// INVARIANT: Store state is not mutated
+func (s *SQLiteStore) List(ctx context.Context) ([]domain.Permission, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT action, allow_coach, allow_member, allow_trial, updated_by, updated_at
+		FROM permission_override
+		ORDER BY action
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Permission{}
+	for rows.Next() {
+		var p domain.Permission
+		var coach, member, trial int
+		var updatedAt string
+		if err := rows.Scan(&p.Action, &coach, &member, &trial, &p.UpdatedBy, &updatedAt); err != nil {
+			return nil, err
+		}
+		p.AllowCoach = coach != 0
+		p.AllowMember = member != 0
+		p.AllowTrial = trial != 0
+		p.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// Save upserts an override.
+// PRE: value passes Validate
+// POST: The override for value.Action is persisted
+// INVARIANT: No other overrides are modified
+func (s *SQLiteStore) Save(ctx context.Context, value domain.Permission) error {
+	if err := value.Validate(); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO permission_override (action, allow_coach, allow_member, allow_trial, updated_by, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(action) DO UPDATE SET
+			allow_coach=excluded.allow_coach,
+			allow_member=excluded.allow_member,
+			allow_trial=excluded.allow_trial,
+			updated_by=excluded.updated_by,
+			updated_at=excluded.updated_at
+	`,
+		value.Action,
+		boolToInt(value.AllowCoach),
+		boolToInt(value.AllowMember),
+		boolToInt(value.AllowTrial),
+		value.UpdatedBy,
+		value.UpdatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("save permission_override: %w", err)
+	}
+	return tx.Commit()
+}
+
+// Delete removes an override, restoring the default.
+// PRE: action is non-empty
+// POST: No override exists for action
+func (s *SQLiteStore) Delete(ctx context.Context, action string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM permission_override WHERE action = ?`, action); err != nil {
+		return fmt.Errorf("delete permission_override: %w", err)
+	}
+	return tx.Commit()
+}
+
+func boolToInt(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
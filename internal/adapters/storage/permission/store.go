@@ -0,0 +1,15 @@
+package permission
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/permission"
+)
+
+// Store persists admin overrides of the default permission matrix.
+// Only actions that differ from their default are stored.
+type Store interface {
+	List(ctx context.Context) ([]domain.Permission, error)
+	Save(ctx context.Context, value domain.Permission) error
+	Delete(ctx context.Context, action string) error
+}
@@ -0,0 +1,69 @@
+package personalgoal
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/personalgoal"
+)
+
+// AnnotationSQLiteStore implements AnnotationStore using SQLite.
+type AnnotationSQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewAnnotationSQLiteStore creates a new AnnotationSQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new AnnotationSQLiteStore instance
+func NewAnnotationSQLiteStore(db storage.SQLDB) *AnnotationSQLiteStore {
+	return &AnnotationSQLiteStore{db: db}
+}
+
+// annotationColumns is the shared column list for personal_goal_annotation SELECTs; order matches scanAnnotation.
+const annotationColumns = "id, goal_id, author_id, author_name, content, created_at"
+
+// Save inserts an annotation.
+// PRE: value has been validated
+// POST: The annotation is persisted
+func (s *AnnotationSQLiteStore) Save(ctx context.Context, value domain.Annotation) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO personal_goal_annotation (`+annotationColumns+`) VALUES (?, ?, ?, ?, ?, ?)`,
+		value.ID, value.GoalID, value.AuthorID, value.AuthorName, value.Content, value.CreatedAt.Format(timeLayout))
+	return err
+}
+
+// ListByGoalID returns a goal's annotations, newest first.
+// PRE: goalID is non-empty
+// POST: Returns annotations or an empty slice
+func (s *AnnotationSQLiteStore) ListByGoalID(ctx context.Context, goalID string) ([]domain.Annotation, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+annotationColumns+" FROM personal_goal_annotation WHERE goal_id = ? ORDER BY created_at DESC", goalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Annotation
+	for rows.Next() {
+		a, err := scanAnnotation(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, a)
+	}
+	return list, rows.Err()
+}
+
+// scanAnnotation extracts an Annotation from a row scanner function.
+func scanAnnotation(scan func(dest ...interface{}) error) (domain.Annotation, error) {
+	var a domain.Annotation
+	var createdAt string
+	if err := scan(&a.ID, &a.GoalID, &a.AuthorID, &a.AuthorName, &a.Content, &createdAt); err != nil {
+		return domain.Annotation{}, err
+	}
+	a.CreatedAt, _ = time.Parse(timeLayout, createdAt)
+	return a, nil
+}
+
+// Ensure interface compliance at compile time.
+var _ AnnotationStore = (*AnnotationSQLiteStore)(nil)
@@ -0,0 +1,69 @@
+package personalgoal
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/personalgoal"
+)
+
+// CheckInSQLiteStore implements CheckInStore using SQLite.
+type CheckInSQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewCheckInSQLiteStore creates a new CheckInSQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new CheckInSQLiteStore instance
+func NewCheckInSQLiteStore(db storage.SQLDB) *CheckInSQLiteStore {
+	return &CheckInSQLiteStore{db: db}
+}
+
+// checkInColumns is the shared column list for personal_goal_check_in SELECTs; order matches scanCheckIn.
+const checkInColumns = "id, goal_id, member_id, progress, status, note, created_at"
+
+// Save inserts a check-in.
+// PRE: value has been validated
+// POST: The check-in is persisted
+func (s *CheckInSQLiteStore) Save(ctx context.Context, value domain.CheckIn) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO personal_goal_check_in (`+checkInColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		value.ID, value.GoalID, value.MemberID, value.Progress, value.Status, value.Note, value.CreatedAt.Format(timeLayout))
+	return err
+}
+
+// ListByGoalID returns a goal's check-ins, newest first.
+// PRE: goalID is non-empty
+// POST: Returns check-ins or an empty slice
+func (s *CheckInSQLiteStore) ListByGoalID(ctx context.Context, goalID string) ([]domain.CheckIn, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+checkInColumns+" FROM personal_goal_check_in WHERE goal_id = ? ORDER BY created_at DESC", goalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.CheckIn
+	for rows.Next() {
+		c, err := scanCheckIn(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+	return list, rows.Err()
+}
+
+// scanCheckIn extracts a CheckIn from a row scanner function.
+func scanCheckIn(scan func(dest ...interface{}) error) (domain.CheckIn, error) {
+	var c domain.CheckIn
+	var createdAt string
+	if err := scan(&c.ID, &c.GoalID, &c.MemberID, &c.Progress, &c.Status, &c.Note, &createdAt); err != nil {
+		return domain.CheckIn{}, err
+	}
+	c.CreatedAt, _ = time.Parse(timeLayout, createdAt)
+	return c, nil
+}
+
+// Ensure interface compliance at compile time.
+var _ CheckInStore = (*CheckInSQLiteStore)(nil)
@@ -12,6 +12,9 @@ import (
 const timeLayout = "2006-01-02T15:04:05Z07:00"
 const dateLayout = "2006-01-02"
 
+// goalColumns is the shared column list for personal_goal SELECTs; order matches scanGoalRow.
+const goalColumns = "id, member_id, title, description, target, unit, type, start_date, end_date, color, progress, created_at, updated_at, status, completed_at, last_check_in_at"
+
 // SQLiteStore implements Store using SQLite.
 type SQLiteStore struct {
 	db storage.SQLDB
@@ -27,9 +30,8 @@ func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
 // POST: Returns the entity or an error if not found
 func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.PersonalGoal, error) {
 	row := s.db.QueryRowContext(ctx,
-		`SELECT id, member_id, title, description, target, unit, type, start_date, end_date, color, progress, created_at, updated_at
-		 FROM personal_goal WHERE id = ?`, id)
-	return scanGoal(row)
+		`SELECT `+goalColumns+` FROM personal_goal WHERE id = ?`, id)
+	return scanGoalRow(row.Scan)
 }
 
 // Save persists a PersonalGoal to the database.
@@ -37,16 +39,18 @@ func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.PersonalGo
 // POST: Entity is persisted (insert or update)
 func (s *SQLiteStore) Save(ctx context.Context, g domain.PersonalGoal) error {
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO personal_goal (id, member_id, title, description, target, unit, type, start_date, end_date, color, progress, created_at, updated_at)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`INSERT INTO personal_goal (`+goalColumns+`)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		 ON CONFLICT(id) DO UPDATE SET
 		   member_id=excluded.member_id, title=excluded.title, description=excluded.description,
 		   target=excluded.target, unit=excluded.unit, type=excluded.type, start_date=excluded.start_date,
 		   end_date=excluded.end_date, color=excluded.color, progress=excluded.progress,
-		   created_at=excluded.created_at, updated_at=excluded.updated_at`,
+		   created_at=excluded.created_at, updated_at=excluded.updated_at,
+		   status=excluded.status, completed_at=excluded.completed_at, last_check_in_at=excluded.last_check_in_at`,
 		g.ID, g.MemberID, g.Title, g.Description, g.Target, g.Unit, g.Type,
 		g.StartDate.Format(dateLayout), g.EndDate.Format(dateLayout), g.Color, g.Progress,
-		g.CreatedAt.Format(timeLayout), g.UpdatedAt.Format(timeLayout))
+		g.CreatedAt.Format(timeLayout), g.UpdatedAt.Format(timeLayout),
+		goalStatus(g), formatOptionalTime(g.CompletedAt), formatOptionalTime(g.LastCheckInAt))
 	return err
 }
 
@@ -63,8 +67,7 @@ func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
 // POST: Returns goals for the given member, ordered by start date desc
 func (s *SQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]domain.PersonalGoal, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, member_id, title, description, target, unit, type, start_date, end_date, color, progress, created_at, updated_at
-		 FROM personal_goal WHERE member_id = ? ORDER BY start_date DESC`, memberID)
+		`SELECT `+goalColumns+` FROM personal_goal WHERE member_id = ? ORDER BY start_date DESC`, memberID)
 	if err != nil {
 		return nil, err
 	}
@@ -78,8 +81,7 @@ func (s *SQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]do
 // POST: Returns goals that overlap with the date range
 func (s *SQLiteStore) ListByDateRange(ctx context.Context, memberID string, from, to string) ([]domain.PersonalGoal, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, member_id, title, description, target, unit, type, start_date, end_date, color, progress, created_at, updated_at
-		 FROM personal_goal WHERE member_id = ?
+		`SELECT `+goalColumns+` FROM personal_goal WHERE member_id = ?
 		 AND (start_date <= ? AND end_date >= ?)`,
 		memberID, to, from)
 	if err != nil {
@@ -90,40 +92,66 @@ func (s *SQLiteStore) ListByDateRange(ctx context.Context, memberID string, from
 	return scanGoals(rows)
 }
 
-func scanGoal(row *sql.Row) (domain.PersonalGoal, error) {
+// ListActive retrieves every goal not yet completed, across all members.
+// PRE: none
+// POST: Returns active goals ordered by member then start date
+func (s *SQLiteStore) ListActive(ctx context.Context) ([]domain.PersonalGoal, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+goalColumns+` FROM personal_goal WHERE status = ? ORDER BY member_id, start_date`, domain.StatusActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanGoals(rows)
+}
+
+// scanGoalRow extracts a PersonalGoal from a row scanner function.
+func scanGoalRow(scan func(dest ...interface{}) error) (domain.PersonalGoal, error) {
 	var g domain.PersonalGoal
-	var createdAt, updatedAt string
+	var createdAt, updatedAt, completedAt, lastCheckInAt string
 	var startDate, endDate string
-	err := row.Scan(&g.ID, &g.MemberID, &g.Title, &g.Description, &g.Target, &g.Unit, &g.Type,
-		&startDate, &endDate, &g.Color, &g.Progress, &createdAt, &updatedAt)
-	if err != nil {
+	if err := scan(&g.ID, &g.MemberID, &g.Title, &g.Description, &g.Target, &g.Unit, &g.Type,
+		&startDate, &endDate, &g.Color, &g.Progress, &createdAt, &updatedAt,
+		&g.Status, &completedAt, &lastCheckInAt); err != nil {
 		return domain.PersonalGoal{}, err
 	}
 	g.StartDate, _ = time.Parse(dateLayout, startDate)
 	g.EndDate, _ = time.Parse(dateLayout, endDate)
 	g.CreatedAt, _ = time.Parse(timeLayout, createdAt)
 	g.UpdatedAt, _ = time.Parse(timeLayout, updatedAt)
+	g.CompletedAt, _ = time.Parse(timeLayout, completedAt)
+	g.LastCheckInAt, _ = time.Parse(timeLayout, lastCheckInAt)
 	return g, nil
 }
 
 func scanGoals(rows *sql.Rows) ([]domain.PersonalGoal, error) {
 	var goals []domain.PersonalGoal
 	for rows.Next() {
-		var g domain.PersonalGoal
-		var createdAt, updatedAt string
-		var startDate, endDate string
-		if err := rows.Scan(&g.ID, &g.MemberID, &g.Title, &g.Description, &g.Target, &g.Unit, &g.Type,
-			&startDate, &endDate, &g.Color, &g.Progress, &createdAt, &updatedAt); err != nil {
+		g, err := scanGoalRow(rows.Scan)
+		if err != nil {
 			return nil, err
 		}
-		g.StartDate, _ = time.Parse(dateLayout, startDate)
-		g.EndDate, _ = time.Parse(dateLayout, endDate)
-		g.CreatedAt, _ = time.Parse(timeLayout, createdAt)
-		g.UpdatedAt, _ = time.Parse(timeLayout, updatedAt)
 		goals = append(goals, g)
 	}
 	return goals, rows.Err()
 }
 
+// goalStatus returns the goal's status, treating an unset status as active.
+func goalStatus(g domain.PersonalGoal) string {
+	if g.Status == "" {
+		return domain.StatusActive
+	}
+	return g.Status
+}
+
+// formatOptionalTime formats t for storage, leaving the zero time empty.
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(timeLayout)
+}
+
 // Ensure interface compliance at compile time.
 var _ Store = (*SQLiteStore)(nil)
@@ -13,4 +13,17 @@ type Store interface {
 	Delete(ctx context.Context, id string) error
 	ListByMemberID(ctx context.Context, memberID string) ([]domain.PersonalGoal, error)
 	ListByDateRange(ctx context.Context, memberID string, from, to string) ([]domain.PersonalGoal, error)
+	ListActive(ctx context.Context) ([]domain.PersonalGoal, error)
+}
+
+// CheckInStore persists members' goal check-ins.
+type CheckInStore interface {
+	Save(ctx context.Context, value domain.CheckIn) error
+	ListByGoalID(ctx context.Context, goalID string) ([]domain.CheckIn, error)
+}
+
+// AnnotationStore persists coach annotations on goals.
+type AnnotationStore interface {
+	Save(ctx context.Context, value domain.Annotation) error
+	ListByGoalID(ctx context.Context, goalID string) ([]domain.Annotation, error)
 }
@@ -0,0 +1,251 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"workshop/internal/adapters/http/perf"
+)
+
+// queryPlanMinRows turns the query plan guard on when above zero: NewTimedDB reads it when
+// the wrapper is built.
+var queryPlanMinRows int64
+
+// queryPlanTimeout bounds the EXPLAIN and row count run for one slow query.
+const queryPlanTimeout = 5 * time.Second
+
+// suggestedIndexMaxColumns caps the columns of a suggested index.
+const suggestedIndexMaxColumns = 3
+
+// SetQueryPlanGuard turns on the query plan guard, a development aid: slow queries are run
+// through EXPLAIN QUERY PLAN once each, and full scans of tables holding at least minRows
+// rows are logged and recorded in the perf collector with a suggested index. 0 turns it off.
+// Call before NewTimedDB.
+func SetQueryPlanGuard(minRows int) {
+	atomic.StoreInt64(&queryPlanMinRows, int64(minRows))
+}
+
+// planGuard explains slow queries and remembers what it found for each.
+type planGuard struct {
+	db        *sql.DB
+	collector *perf.Collector
+	minRows   int64
+
+	mu      sync.Mutex
+	checked map[string]*perf.QueryPlan // nil value: explained or being explained, nothing to flag
+	wg      sync.WaitGroup             // EXPLAINs in flight
+}
+
+// newPlanGuard returns the guard configured by SetQueryPlanGuard, or nil when it is off.
+func newPlanGuard(db *sql.DB, collector *perf.Collector) *planGuard {
+	minRows := atomic.LoadInt64(&queryPlanMinRows)
+	if minRows <= 0 {
+		return nil
+	}
+	return &planGuard{db: db, collector: collector, minRows: minRows, checked: make(map[string]*perf.QueryPlan)}
+}
+
+// observe handles one slow run of query. The first run is explained in the background, so an
+// open result set never waits on the EXPLAIN; later runs of a flagged query are recorded again.
+func (g *planGuard) observe(query string, args []any, durationMs float64, at time.Time) {
+	g.mu.Lock()
+	found, seen := g.checked[query]
+	if !seen {
+		g.checked[query] = nil
+	}
+	g.mu.Unlock()
+
+	if seen {
+		if found != nil {
+			g.record(*found, durationMs, at)
+		}
+		return
+	}
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), queryPlanTimeout)
+		defer cancel()
+		plan, err := g.explain(ctx, query, args)
+		if err != nil {
+			slog.DebugContext(ctx, "query_plan_failed", "query", query, "error", err)
+			return
+		}
+		if plan == nil {
+			return
+		}
+		g.mu.Lock()
+		g.checked[query] = plan
+		g.mu.Unlock()
+		slog.WarnContext(ctx, "query_plan_full_scan",
+			"table", plan.Table,
+			"rows", plan.Rows,
+			"detail", plan.Detail,
+			"suggestion", plan.Suggestion,
+			"query", query,
+		)
+		g.record(*plan, durationMs, at)
+	}()
+}
+
+// record passes one slow run of a flagged query to the collector.
+func (g *planGuard) record(plan perf.QueryPlan, durationMs float64, at time.Time) {
+	if g.collector == nil {
+		return
+	}
+	plan.Count, plan.MaxMs, plan.LastSeen = 1, durationMs, at
+	g.collector.RecordPlan(plan)
+}
+
+// wait blocks until the EXPLAINs in flight finish.
+func (g *planGuard) wait() {
+	g.wg.Wait()
+}
+
+// explain reads query's plan and returns the first full scan of a table holding at least
+// minRows rows, or nil when the plan has none.
+func (g *planGuard) explain(ctx context.Context, query string, args []any) (*perf.QueryPlan, error) {
+	rows, err := g.db.QueryContext(ctx, "EXPLAIN QUERY PLAN "+query, args...)
+	if err != nil {
+		return nil, err
+	}
+	var scans []string
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if isFullScan(detail) {
+			scans = append(scans, detail)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	aliases := tableAliases(query)
+	for _, detail := range scans {
+		name := strings.Fields(detail)[1]
+		table := name
+		if t, ok := aliases[strings.ToLower(name)]; ok {
+			table = t
+		}
+		columns, err := tableColumns(ctx, g.db, table)
+		if err != nil || len(columns) == 0 {
+			continue // a view, subquery or CTE rather than a table
+		}
+		var count int64
+		if err := g.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM "`+table+`"`).Scan(&count); err != nil {
+			return nil, err
+		}
+		if count < g.minRows {
+			continue
+		}
+		return &perf.QueryPlan{
+			Query:      query,
+			Table:      table,
+			Rows:       count,
+			Detail:     detail,
+			Suggestion: suggestIndex(query, table, name, columns),
+		}, nil
+	}
+	return nil, nil
+}
+
+// isFullScan reports whether an EXPLAIN QUERY PLAN step reads every row of a table.
+// Scans through a covering index read the smaller index instead and are left alone.
+func isFullScan(detail string) bool {
+	fields := strings.Fields(detail)
+	if len(fields) < 2 || fields[0] != "SCAN" {
+		return false
+	}
+	if fields[1] == "CONSTANT" || strings.HasPrefix(fields[1], "(") {
+		return false
+	}
+	return !strings.Contains(detail, "COVERING INDEX")
+}
+
+// tableRefPattern matches a table named after FROM, JOIN, UPDATE or INTO, and its alias.
+var tableRefPattern = regexp.MustCompile(`(?i)\b(?:FROM|JOIN|UPDATE|INTO)\s+"?(\w+)"?(?:\s+(?:AS\s+)?(\w+))?`)
+
+// sqlKeywords are words that can follow a table name without being its alias.
+var sqlKeywords = map[string]bool{
+	"where": true, "join": true, "left": true, "right": true, "inner": true, "outer": true, "cross": true,
+	"on": true, "set": true, "order": true, "group": true, "limit": true, "values": true, "union": true,
+	"having": true, "using": true, "natural": true, "returning": true, "select": true, "default": true,
+	"offset": true, "except": true, "intersect": true, "window": true,
+}
+
+// tableAliases maps each alias and table name in query, lower-cased, to its table.
+func tableAliases(query string) map[string]string {
+	aliases := map[string]string{}
+	for _, m := range tableRefPattern.FindAllStringSubmatch(query, -1) {
+		aliases[strings.ToLower(m[1])] = m[1]
+		if m[2] != "" && !sqlKeywords[strings.ToLower(m[2])] {
+			aliases[strings.ToLower(m[2])] = m[1]
+		}
+	}
+	return aliases
+}
+
+// tableColumns lists a table's columns; empty when no such table exists.
+func tableColumns(ctx context.Context, db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	columns := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns[strings.ToLower(name)] = true
+	}
+	return columns, rows.Err()
+}
+
+// filterPattern matches a possibly qualified column compared in a WHERE or ON condition.
+var filterPattern = regexp.MustCompile(`(?i)(?:(\w+)\.)?"?(\w+)"?\s*(?:=|<|>|!=|\bIN\b|\bIS\b|\bLIKE\b|\bBETWEEN\b)`)
+
+// filterStartPattern finds where a query's conditions begin.
+var filterStartPattern = regexp.MustCompile(`(?i)\b(?:WHERE|ON)\b`)
+
+// suggestIndex proposes an index on the columns of table the query filters on, in the order
+// they appear. name is how the plan refers to the table: the table itself or its alias.
+func suggestIndex(query, table, name string, columns map[string]bool) string {
+	loc := filterStartPattern.FindStringIndex(query)
+	if loc == nil {
+		return ""
+	}
+	var picked []string
+	seen := map[string]bool{}
+	for _, m := range filterPattern.FindAllStringSubmatch(query[loc[0]:], -1) {
+		qualifier, column := strings.ToLower(m[1]), strings.ToLower(m[2])
+		if qualifier != "" && qualifier != strings.ToLower(name) && qualifier != strings.ToLower(table) {
+			continue
+		}
+		if !columns[column] || seen[column] {
+			continue
+		}
+		seen[column] = true
+		picked = append(picked, column)
+		if len(picked) == suggestedIndexMaxColumns {
+			break
+		}
+	}
+	if len(picked) == 0 {
+		return ""
+	}
+	return "CREATE INDEX idx_" + table + "_" + strings.Join(picked, "_") + " ON " + table + "(" + strings.Join(picked, ", ") + ");"
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"workshop/internal/adapters/http/perf"
+)
+
+// openPlanTestDB opens a file database, so the guard's own connection sees the same tables,
+// with 20 members and 2 attendance rows.
+func openPlanTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "plans.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	for _, stmt := range []string{
+		"CREATE TABLE member (id TEXT PRIMARY KEY, email TEXT, status TEXT)",
+		"CREATE TABLE attendance (id TEXT PRIMARY KEY, member_id TEXT)",
+		"INSERT INTO attendance (id, member_id) VALUES ('a1', 'm1'), ('a2', 'm2')",
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	for i := 0; i < 20; i++ {
+		db.Exec("INSERT INTO member (id, email, status) VALUES (?, ?, 'active')", fmt.Sprintf("m%d", i), fmt.Sprintf("m%d@test.com", i))
+	}
+	return db
+}
+
+// TestQueryPlanGuard verifies slow full scans of large tables are recorded once per query
+// with an index on the filtered columns, and indexed or small-table queries are not.
+func TestQueryPlanGuard(t *testing.T) {
+	SetSlowQueryThreshold(0) // every query is slow
+	SetQueryPlanGuard(10)
+	defer SetSlowQueryThreshold(DefaultSlowQueryMs * time.Millisecond)
+	defer SetQueryPlanGuard(0)
+	db := openPlanTestDB(t)
+	collector := perf.NewCollector(100)
+	tdb := NewTimedDB(db, collector)
+	ctx := context.Background()
+
+	var id string
+	scan := "SELECT m.id FROM member AS m WHERE m.status = ? AND m.email = ?"
+	for i := 0; i < 2; i++ {
+		tdb.QueryRowContext(ctx, scan, "active", "m1@test.com").Scan(&id)
+		tdb.plans.wait()
+	}
+	tdb.QueryRowContext(ctx, "SELECT email FROM member WHERE id = ?", "m1").Scan(&id)
+	tdb.QueryRowContext(ctx, "SELECT id FROM attendance WHERE member_id = ?", "m1").Scan(&id)
+	tdb.plans.wait()
+
+	plans := collector.QueryPlans()
+	if len(plans) != 1 {
+		t.Fatalf("plans = %+v, want only the member scan", plans)
+	}
+	p := plans[0]
+	if p.Query != scan || p.Table != "member" || p.Rows != 20 || p.Detail != "SCAN m" || p.Count != 2 {
+		t.Errorf("plan = %+v", p)
+	}
+	if want := "CREATE INDEX idx_member_status_email ON member(status, email);"; p.Suggestion != want {
+		t.Errorf("suggestion = %q, want %q", p.Suggestion, want)
+	}
+
+	SetQueryPlanGuard(0)
+	if NewTimedDB(db, collector).plans != nil {
+		t.Error("guard should be off by default")
+	}
+}
+
+// TestSuggestIndex verifies columns are taken from conditions on the scanned table only.
+func TestSuggestIndex(t *testing.T) {
+	columns := map[string]bool{"id": true, "member_id": true, "class_date": true}
+	tests := []struct {
+		query, want string
+	}{
+		{"SELECT * FROM attendance a JOIN member m ON m.id = a.member_id WHERE a.class_date >= ?", "CREATE INDEX idx_attendance_class_date ON attendance(class_date);"},
+		{"UPDATE attendance SET class_date = ? WHERE member_id = ?", "CREATE INDEX idx_attendance_member_id ON attendance(member_id);"},
+		{"SELECT * FROM attendance ORDER BY class_date", ""},
+	}
+	for _, tt := range tests {
+		if got := suggestIndex(tt.query, "attendance", "a", columns); got != tt.want {
+			t.Errorf("suggestIndex(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
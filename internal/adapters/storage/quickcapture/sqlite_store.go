@@ -0,0 +1,109 @@
+package quickcapture
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/quickcapture"
+)
+
+// captureColumns is the shared column list for capture SELECTs; order matches scanCapture.
+const captureColumns = "id, author_id, client_id, member_ref, content, captured_at, status, member_id, observation_id, created_at, resolved_at"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// GetByID retrieves a capture by its ID.
+// PRE: id is non-empty
+// POST: Returns the capture or an error if not found
+func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Capture, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+captureColumns+" FROM observation_capture WHERE id = ?", id)
+	c, err := scanCapture(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Capture{}, fmt.Errorf("capture not found: %w", err)
+	}
+	return c, err
+}
+
+// GetByClientID retrieves the capture a coach's phone sent with the given client ID.
+// PRE: authorID and clientID are non-empty
+// POST: Returns the capture or an error if the phone has not sent it before
+func (s *SQLiteStore) GetByClientID(ctx context.Context, authorID, clientID string) (domain.Capture, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+captureColumns+" FROM observation_capture WHERE author_id = ? AND client_id = ?", authorID, clientID)
+	c, err := scanCapture(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Capture{}, fmt.Errorf("capture not found: %w", err)
+	}
+	return c, err
+}
+
+// Save persists a capture, updating its resolution if it exists.
+// PRE: value has been validated
+// POST: The capture is persisted
+func (s *SQLiteStore) Save(ctx context.Context, value domain.Capture) error {
+	resolvedAt := ""
+	if !value.ResolvedAt.IsZero() {
+		resolvedAt = value.ResolvedAt.UTC().Format(time.RFC3339)
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO observation_capture (`+captureColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET status = excluded.status, member_id = excluded.member_id,
+			observation_id = excluded.observation_id, resolved_at = excluded.resolved_at`,
+		value.ID, value.AuthorID, value.ClientID, value.MemberRef, value.Content,
+		value.CapturedAt.UTC().Format(time.RFC3339), value.Status, value.MemberID, value.ObservationID,
+		value.CreatedAt.UTC().Format(time.RFC3339), resolvedAt)
+	return err
+}
+
+// ListQueuedByAuthorID returns a coach's captures still waiting for a member.
+// PRE: authorID is non-empty
+// POST: Returns captures oldest first, or an empty slice
+func (s *SQLiteStore) ListQueuedByAuthorID(ctx context.Context, authorID string) ([]domain.Capture, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+captureColumns+" FROM observation_capture WHERE author_id = ? AND status = ? ORDER BY captured_at",
+		authorID, domain.StatusQueued)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Capture
+	for rows.Next() {
+		c, err := scanCapture(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+	return list, rows.Err()
+}
+
+// scanCapture extracts a Capture from a row scanner function.
+func scanCapture(scan func(dest ...interface{}) error) (domain.Capture, error) {
+	var c domain.Capture
+	var capturedAt, createdAt, resolvedAt string
+	if err := scan(&c.ID, &c.AuthorID, &c.ClientID, &c.MemberRef, &c.Content, &capturedAt, &c.Status,
+		&c.MemberID, &c.ObservationID, &createdAt, &resolvedAt); err != nil {
+		return domain.Capture{}, err
+	}
+	c.CapturedAt, _ = time.Parse(time.RFC3339, capturedAt)
+	c.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	if resolvedAt != "" {
+		c.ResolvedAt, _ = time.Parse(time.RFC3339, resolvedAt)
+	}
+	return c, nil
+}
+
+// Ensure interface compliance at compile time.
+var _ Store = (*SQLiteStore)(nil)
@@ -0,0 +1,15 @@
+package quickcapture
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/quickcapture"
+)
+
+// Store persists notes coaches capture mid-class.
+type Store interface {
+	GetByID(ctx context.Context, id string) (domain.Capture, error)
+	GetByClientID(ctx context.Context, authorID, clientID string) (domain.Capture, error)
+	Save(ctx context.Context, value domain.Capture) error
+	ListQueuedByAuthorID(ctx context.Context, authorID string) ([]domain.Capture, error)
+}
@@ -0,0 +1,290 @@
+package reengagement
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/reengagement"
+)
+
+// ruleColumns is the shared column list for rule SELECTs; order matches scanRule.
+const ruleColumns = "id, name, days_inactive, action, template_key, enabled, updated_by, updated_at"
+
+// actionColumns is the shared column list for action SELECTs; order matches scanAction.
+const actionColumns = "id, member_id, rule_id, rule_name, kind, days_inactive, last_check_in, email_id, outcome, note, recorded_by, recorded_at, returned_at, created_at"
+
+// suppressionColumns is the shared column list for suppression SELECTs; order matches scanSuppression.
+const suppressionColumns = "member_id, reason, until, created_by, created_at"
+
+// defaultActionLimit caps ListActions when the filter sets no limit.
+const defaultActionLimit = 200
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// GetRule retrieves a rule by ID.
+// PRE: id is non-empty
+// POST: Returns the rule or an error if not found
+func (s *SQLiteStore) GetRule(ctx context.Context, id string) (domain.Rule, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+ruleColumns+" FROM reengagement_rule WHERE id = ?", id)
+	r, err := scanRule(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Rule{}, fmt.Errorf("re-engagement rule not found: %w", err)
+	}
+	return r, err
+}
+
+// SaveRule persists a rule (insert or update).
+// PRE: value has been validated
+// POST: The rule is persisted
+func (s *SQLiteStore) SaveRule(ctx context.Context, value domain.Rule) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO reengagement_rule (`+ruleColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET name = excluded.name, days_inactive = excluded.days_inactive, action = excluded.action,
+			template_key = excluded.template_key, enabled = excluded.enabled, updated_by = excluded.updated_by, updated_at = excluded.updated_at`,
+		value.ID, value.Name, value.DaysInactive, value.Action, value.TemplateKey, boolInt(value.Enabled), value.UpdatedBy,
+		value.UpdatedAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+// DeleteRule removes a rule. Actions it already took are kept.
+// PRE: id is non-empty
+// POST: The rule is removed if it existed
+func (s *SQLiteStore) DeleteRule(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM reengagement_rule WHERE id = ?", id)
+	return err
+}
+
+// ListRules returns every rule, lowest threshold first.
+// PRE: none
+// POST: Returns rules or an empty slice
+func (s *SQLiteStore) ListRules(ctx context.Context) ([]domain.Rule, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+ruleColumns+" FROM reengagement_rule ORDER BY days_inactive, name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Rule
+	for rows.Next() {
+		r, err := scanRule(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, r)
+	}
+	return list, rows.Err()
+}
+
+// GetAction retrieves an action by ID.
+// PRE: id is non-empty
+// POST: Returns the action or an error if not found
+func (s *SQLiteStore) GetAction(ctx context.Context, id string) (domain.Action, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+actionColumns+" FROM reengagement_action WHERE id = ?", id)
+	a, err := scanAction(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Action{}, fmt.Errorf("re-engagement action not found: %w", err)
+	}
+	return a, err
+}
+
+// SaveAction persists an action (insert or update). Only the outcome, note and return can change.
+// PRE: value has been validated
+// POST: The action is persisted
+func (s *SQLiteStore) SaveAction(ctx context.Context, value domain.Action) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO reengagement_action (`+actionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET outcome = excluded.outcome, note = excluded.note, recorded_by = excluded.recorded_by,
+			recorded_at = excluded.recorded_at, returned_at = excluded.returned_at`,
+		value.ID, value.MemberID, value.RuleID, value.RuleName, value.Kind, value.DaysInactive, nullTime(value.LastCheckIn),
+		value.EmailID, value.Outcome, value.Note, value.RecordedBy, nullTime(value.RecordedAt), nullTime(value.ReturnedAt),
+		value.CreatedAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+// ListActions returns the actions matching the filter, most recent first.
+// PRE: none
+// POST: Returns at most filter.Limit actions, or an empty slice
+func (s *SQLiteStore) ListActions(ctx context.Context, filter ActionFilter) ([]domain.Action, error) {
+	query := "SELECT " + actionColumns + " FROM reengagement_action WHERE 1 = 1"
+	var args []any
+	if filter.MemberID != "" {
+		query += " AND member_id = ?"
+		args = append(args, filter.MemberID)
+	}
+	if filter.Outcome != "" {
+		query += " AND outcome = ?"
+		args = append(args, filter.Outcome)
+	}
+	if filter.Kind != "" {
+		query += " AND kind = ?"
+		args = append(args, filter.Kind)
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultActionLimit
+	}
+	query += " ORDER BY created_at DESC LIMIT ?"
+	args = append(args, limit)
+	return s.listActions(ctx, query, args...)
+}
+
+// ListAwaitingReturn returns the actions taken at or after since whose member has not
+// yet trained again, oldest first.
+// PRE: none
+// POST: Returns actions or an empty slice
+func (s *SQLiteStore) ListAwaitingReturn(ctx context.Context, since time.Time) ([]domain.Action, error) {
+	return s.listActions(ctx, "SELECT "+actionColumns+" FROM reengagement_action WHERE returned_at IS NULL AND created_at >= ? ORDER BY created_at",
+		since.UTC().Format(time.RFC3339))
+}
+
+// listActions runs an action query.
+func (s *SQLiteStore) listActions(ctx context.Context, query string, args ...any) ([]domain.Action, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Action
+	for rows.Next() {
+		a, err := scanAction(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, a)
+	}
+	return list, rows.Err()
+}
+
+// GetSuppression retrieves a member's pause, whether or not it has ended.
+// PRE: memberID is non-empty
+// POST: Returns the suppression or an error if the member has none
+func (s *SQLiteStore) GetSuppression(ctx context.Context, memberID string) (domain.Suppression, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+suppressionColumns+" FROM reengagement_suppression WHERE member_id = ?", memberID)
+	sup, err := scanSuppression(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Suppression{}, fmt.Errorf("re-engagement suppression not found: %w", err)
+	}
+	return sup, err
+}
+
+// SaveSuppression persists a member's pause, replacing any earlier one.
+// PRE: value has been validated
+// POST: The suppression is persisted
+func (s *SQLiteStore) SaveSuppression(ctx context.Context, value domain.Suppression) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO reengagement_suppression (`+suppressionColumns+`) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(member_id) DO UPDATE SET reason = excluded.reason, until = excluded.until,
+			created_by = excluded.created_by, created_at = excluded.created_at`,
+		value.MemberID, value.Reason, nullTime(value.Until), value.CreatedBy, value.CreatedAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+// DeleteSuppression lifts a member's pause.
+// PRE: memberID is non-empty
+// POST: The suppression is removed if it existed
+func (s *SQLiteStore) DeleteSuppression(ctx context.Context, memberID string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM reengagement_suppression WHERE member_id = ?", memberID)
+	return err
+}
+
+// ListSuppressions returns every pause, including ended ones, newest first.
+// PRE: none
+// POST: Returns suppressions or an empty slice
+func (s *SQLiteStore) ListSuppressions(ctx context.Context) ([]domain.Suppression, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+suppressionColumns+" FROM reengagement_suppression ORDER BY created_at DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Suppression
+	for rows.Next() {
+		sup, err := scanSuppression(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, sup)
+	}
+	return list, rows.Err()
+}
+
+// scanRule extracts a Rule from a row scanner function.
+func scanRule(scan func(dest ...interface{}) error) (domain.Rule, error) {
+	var r domain.Rule
+	var enabled int
+	var updatedAt string
+	if err := scan(&r.ID, &r.Name, &r.DaysInactive, &r.Action, &r.TemplateKey, &enabled, &r.UpdatedBy, &updatedAt); err != nil {
+		return domain.Rule{}, err
+	}
+	r.Enabled = enabled == 1
+	r.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	return r, nil
+}
+
+// scanAction extracts an Action from a row scanner function.
+func scanAction(scan func(dest ...interface{}) error) (domain.Action, error) {
+	var a domain.Action
+	var lastCheckIn, recordedAt, returnedAt sql.NullString
+	var createdAt string
+	if err := scan(&a.ID, &a.MemberID, &a.RuleID, &a.RuleName, &a.Kind, &a.DaysInactive, &lastCheckIn, &a.EmailID,
+		&a.Outcome, &a.Note, &a.RecordedBy, &recordedAt, &returnedAt, &createdAt); err != nil {
+		return domain.Action{}, err
+	}
+	a.LastCheckIn = parseNullTime(lastCheckIn)
+	a.RecordedAt = parseNullTime(recordedAt)
+	a.ReturnedAt = parseNullTime(returnedAt)
+	a.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return a, nil
+}
+
+// scanSuppression extracts a Suppression from a row scanner function.
+func scanSuppression(scan func(dest ...interface{}) error) (domain.Suppression, error) {
+	var sup domain.Suppression
+	var until sql.NullString
+	var createdAt string
+	if err := scan(&sup.MemberID, &sup.Reason, &until, &sup.CreatedBy, &createdAt); err != nil {
+		return domain.Suppression{}, err
+	}
+	sup.Until = parseNullTime(until)
+	sup.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return sup, nil
+}
+
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseNullTime(s sql.NullString) time.Time {
+	if !s.Valid {
+		return time.Time{}
+	}
+	t, _ := time.Parse(time.RFC3339, s.String)
+	return t
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Ensure interface compliance at compile time.
+var _ Store = (*SQLiteStore)(nil)
@@ -0,0 +1,32 @@
+package reengagement
+
+import (
+	"context"
+	"time"
+
+	domain "workshop/internal/domain/reengagement"
+)
+
+// ActionFilter selects re-engagement actions.
+type ActionFilter struct {
+	MemberID string // optional: empty lists every member's actions
+	Outcome  string // optional: empty lists every outcome
+	Kind     string // optional: empty lists emails and calls
+	Limit    int    // optional: 0 uses the default of 200
+}
+
+// Store persists re-engagement rules, actions and suppressions.
+type Store interface {
+	GetRule(ctx context.Context, id string) (domain.Rule, error)
+	SaveRule(ctx context.Context, value domain.Rule) error
+	DeleteRule(ctx context.Context, id string) error
+	ListRules(ctx context.Context) ([]domain.Rule, error)
+	GetAction(ctx context.Context, id string) (domain.Action, error)
+	SaveAction(ctx context.Context, value domain.Action) error
+	ListActions(ctx context.Context, filter ActionFilter) ([]domain.Action, error)
+	ListAwaitingReturn(ctx context.Context, since time.Time) ([]domain.Action, error)
+	GetSuppression(ctx context.Context, memberID string) (domain.Suppression, error)
+	SaveSuppression(ctx context.Context, value domain.Suppression) error
+	DeleteSuppression(ctx context.Context, memberID string) error
+	ListSuppressions(ctx context.Context) ([]domain.Suppression, error)
+}
@@ -0,0 +1,130 @@
+package referral
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/referral"
+)
+
+// referralColumns is the shared column list for referral SELECTs; order matches scanReferral.
+const referralColumns = "id, member_id, referrer_member_id, referrer_name, source, created_at, converted_at, reward, credit_amount"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// Save persists a referral (insert or update). Only the conversion and its reward can change.
+// PRE: value has been validated
+// POST: The referral is persisted
+func (s *SQLiteStore) Save(ctx context.Context, value domain.Referral) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO referral (`+referralColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET converted_at = excluded.converted_at, reward = excluded.reward,
+			credit_amount = excluded.credit_amount`,
+		value.ID, value.MemberID, value.ReferrerMemberID, value.ReferrerName, value.Source,
+		value.CreatedAt.UTC().Format(time.RFC3339), formatTime(value.ConvertedAt), value.Reward, value.CreditAmount)
+	return err
+}
+
+// GetByMemberID retrieves the referral for the person referred.
+// PRE: memberID is non-empty
+// POST: Returns the referral or an error wrapping sql.ErrNoRows if they were not referred
+func (s *SQLiteStore) GetByMemberID(ctx context.Context, memberID string) (domain.Referral, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+referralColumns+" FROM referral WHERE member_id = ?", memberID)
+	r, err := scanReferral(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Referral{}, fmt.Errorf("referral not found: %w", err)
+	}
+	return r, err
+}
+
+// List returns every referral, newest first.
+// PRE: none
+// POST: Returns referrals or an empty slice
+func (s *SQLiteStore) List(ctx context.Context) ([]domain.Referral, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+referralColumns+" FROM referral ORDER BY created_at DESC, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Referral
+	for rows.Next() {
+		r, err := scanReferral(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, r)
+	}
+	return list, rows.Err()
+}
+
+// GetSettings returns the club's referral reward settings.
+// PRE: none
+// POST: Returns the saved settings, or DefaultSettings when none have been saved
+func (s *SQLiteStore) GetSettings(ctx context.Context) (domain.Settings, error) {
+	var v domain.Settings
+	var enabled int
+	var updatedAt string
+	err := s.db.QueryRowContext(ctx,
+		"SELECT enabled, reward, credit_amount, updated_by, updated_at FROM referral_settings WHERE id = 1").
+		Scan(&enabled, &v.Reward, &v.CreditAmount, &v.UpdatedBy, &updatedAt)
+	if err == sql.ErrNoRows {
+		return domain.DefaultSettings, nil
+	}
+	if err != nil {
+		return domain.Settings{}, err
+	}
+	v.Enabled = enabled == 1
+	v.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	return v, nil
+}
+
+// SaveSettings replaces the club's referral reward settings.
+// PRE: value has been validated
+// POST: The settings are persisted
+func (s *SQLiteStore) SaveSettings(ctx context.Context, value domain.Settings) error {
+	enabled := 0
+	if value.Enabled {
+		enabled = 1
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO referral_settings (id, enabled, reward, credit_amount, updated_by, updated_at) VALUES (1, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET enabled = excluded.enabled, reward = excluded.reward,
+			credit_amount = excluded.credit_amount, updated_by = excluded.updated_by, updated_at = excluded.updated_at`,
+		enabled, value.Reward, value.CreditAmount, value.UpdatedBy, formatTime(value.UpdatedAt))
+	return err
+}
+
+// scanReferral extracts a Referral from a row scanner function.
+func scanReferral(scan func(dest ...interface{}) error) (domain.Referral, error) {
+	var r domain.Referral
+	var createdAt, convertedAt string
+	if err := scan(&r.ID, &r.MemberID, &r.ReferrerMemberID, &r.ReferrerName, &r.Source,
+		&createdAt, &convertedAt, &r.Reward, &r.CreditAmount); err != nil {
+		return domain.Referral{}, err
+	}
+	r.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	r.ConvertedAt, _ = time.Parse(time.RFC3339, convertedAt)
+	return r, nil
+}
+
+// formatTime stores a zero time as an empty string.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,16 @@
+package referral
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/referral"
+)
+
+// Store persists Referral state and the club's referral reward settings.
+type Store interface {
+	Save(ctx context.Context, value domain.Referral) error
+	GetByMemberID(ctx context.Context, memberID string) (domain.Referral, error)
+	List(ctx context.Context) ([]domain.Referral, error)
+	GetSettings(ctx context.Context) (domain.Settings, error)
+	SaveSettings(ctx context.Context, value domain.Settings) error
+}
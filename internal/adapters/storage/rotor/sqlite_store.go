@@ -45,14 +45,16 @@ func formatTime(t time.Time) string {
 // POST: rotor is persisted
 func (s *SQLiteStore) SaveRotor(ctx context.Context, r domain.Rotor) error {
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO rotor (id, class_type_id, name, version, status, preview_on, created_by, created_at, activated_at)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`INSERT INTO rotor (id, class_type_id, name, version, status, preview_on, manual_advance, vote_one_per_theme, vote_expiry_weeks, coach_vote_weight, created_by, created_at, activated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		 ON CONFLICT(id) DO UPDATE SET
 		   class_type_id=excluded.class_type_id, name=excluded.name, version=excluded.version,
-		   status=excluded.status, preview_on=excluded.preview_on, created_by=excluded.created_by,
-		   created_at=excluded.created_at, activated_at=excluded.activated_at`,
+		   status=excluded.status, preview_on=excluded.preview_on, manual_advance=excluded.manual_advance,
+		   vote_one_per_theme=excluded.vote_one_per_theme, vote_expiry_weeks=excluded.vote_expiry_weeks,
+		   coach_vote_weight=excluded.coach_vote_weight, created_by=excluded.created_by, created_at=excluded.created_at, activated_at=excluded.activated_at`,
 		r.ID, r.ClassTypeID, r.Name, r.Version, r.Status,
-		boolToInt(r.PreviewOn), r.CreatedBy, formatTime(r.CreatedAt), formatTime(r.ActivatedAt))
+		boolToInt(r.PreviewOn), boolToInt(r.ManualAdvance),
+		boolToInt(r.VoteRules.OnePerTheme), r.VoteRules.ExpiryWeeks, r.VoteRules.CoachWeight, r.CreatedBy, formatTime(r.CreatedAt), formatTime(r.ActivatedAt))
 	return err
 }
 
@@ -61,7 +63,7 @@ func (s *SQLiteStore) SaveRotor(ctx context.Context, r domain.Rotor) error {
 // POST: returns the rotor or error if not found
 func (s *SQLiteStore) GetRotor(ctx context.Context, id string) (domain.Rotor, error) {
 	row := s.db.QueryRowContext(ctx,
-		`SELECT id, class_type_id, name, version, status, preview_on, created_by, created_at, activated_at
+		`SELECT id, class_type_id, name, version, status, preview_on, manual_advance, vote_one_per_theme, vote_expiry_weeks, coach_vote_weight, created_by, created_at, activated_at
 		 FROM rotor WHERE id = ?`, id)
 	return scanRotor(row)
 }
@@ -71,7 +73,7 @@ func (s *SQLiteStore) GetRotor(ctx context.Context, id string) (domain.Rotor, er
 // POST: returns rotors or empty slice
 func (s *SQLiteStore) ListRotorsByClassType(ctx context.Context, classTypeID string) ([]domain.Rotor, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, class_type_id, name, version, status, preview_on, created_by, created_at, activated_at
+		`SELECT id, class_type_id, name, version, status, preview_on, manual_advance, vote_one_per_theme, vote_expiry_weeks, coach_vote_weight, created_by, created_at, activated_at
 		 FROM rotor WHERE class_type_id = ? ORDER BY version DESC`, classTypeID)
 	if err != nil {
 		return nil, err
@@ -93,7 +95,7 @@ func (s *SQLiteStore) ListRotorsByClassType(ctx context.Context, classTypeID str
 // POST: returns the active rotor or error if none
 func (s *SQLiteStore) GetActiveRotor(ctx context.Context, classTypeID string) (domain.Rotor, error) {
 	row := s.db.QueryRowContext(ctx,
-		`SELECT id, class_type_id, name, version, status, preview_on, created_by, created_at, activated_at
+		`SELECT id, class_type_id, name, version, status, preview_on, manual_advance, vote_one_per_theme, vote_expiry_weeks, coach_vote_weight, created_by, created_at, activated_at
 		 FROM rotor WHERE class_type_id = ? AND status = 'active' LIMIT 1`, classTypeID)
 	return scanRotor(row)
 }
@@ -106,16 +108,54 @@ func (s *SQLiteStore) DeleteRotor(ctx context.Context, id string) error {
 	return err
 }
 
+// ImportRotor inserts a rotor together with all of its themes and topics in one transaction.
+// PRE: r, themes and topics are valid and carry fresh IDs
+// POST: either the whole structure is persisted or nothing is
+func (s *SQLiteStore) ImportRotor(ctx context.Context, r domain.Rotor, themes []domain.RotorTheme, topics []domain.Topic) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO rotor (id, class_type_id, name, version, status, preview_on, manual_advance, vote_one_per_theme, vote_expiry_weeks, coach_vote_weight, created_by, created_at, activated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		r.ID, r.ClassTypeID, r.Name, r.Version, r.Status,
+		boolToInt(r.PreviewOn), boolToInt(r.ManualAdvance),
+		boolToInt(r.VoteRules.OnePerTheme), r.VoteRules.ExpiryWeeks, r.VoteRules.CoachWeight, r.CreatedBy, formatTime(r.CreatedAt), formatTime(r.ActivatedAt)); err != nil {
+		return err
+	}
+	for _, t := range themes {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO rotor_theme (id, rotor_id, name, position, hidden) VALUES (?, ?, ?, ?, ?)`,
+			t.ID, t.RotorID, t.Name, t.Position, boolToInt(t.Hidden)); err != nil {
+			return err
+		}
+	}
+	for _, t := range topics {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO topic (id, rotor_theme_id, name, description, duration_weeks, position, last_covered, shared_topic_id, min_belt)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			t.ID, t.RotorThemeID, t.Name, t.Description, t.DurationWeeks, t.Position, formatTime(t.LastCovered), t.SharedTopicID, t.MinBelt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 func scanRotor(row *sql.Row) (domain.Rotor, error) {
 	var r domain.Rotor
-	var previewOn int
+	var previewOn, manualAdvance, onePerTheme int
 	var createdAt, activatedAt string
 	err := row.Scan(&r.ID, &r.ClassTypeID, &r.Name, &r.Version, &r.Status,
-		&previewOn, &r.CreatedBy, &createdAt, &activatedAt)
+		&previewOn, &manualAdvance, &onePerTheme, &r.VoteRules.ExpiryWeeks, &r.VoteRules.CoachWeight, &r.CreatedBy, &createdAt, &activatedAt)
 	if err != nil {
 		return domain.Rotor{}, err
 	}
 	r.PreviewOn = previewOn == 1
+	r.ManualAdvance = manualAdvance == 1
+	r.VoteRules.OnePerTheme = onePerTheme == 1
 	r.CreatedAt = parseTime(createdAt)
 	r.ActivatedAt = parseTime(activatedAt)
 	return r, nil
@@ -123,14 +163,16 @@ func scanRotor(row *sql.Row) (domain.Rotor, error) {
 
 func scanRotorRows(rows *sql.Rows) (domain.Rotor, error) {
 	var r domain.Rotor
-	var previewOn int
+	var previewOn, manualAdvance, onePerTheme int
 	var createdAt, activatedAt string
 	err := rows.Scan(&r.ID, &r.ClassTypeID, &r.Name, &r.Version, &r.Status,
-		&previewOn, &r.CreatedBy, &createdAt, &activatedAt)
+		&previewOn, &manualAdvance, &onePerTheme, &r.VoteRules.ExpiryWeeks, &r.VoteRules.CoachWeight, &r.CreatedBy, &createdAt, &activatedAt)
 	if err != nil {
 		return domain.Rotor{}, err
 	}
 	r.PreviewOn = previewOn == 1
+	r.ManualAdvance = manualAdvance == 1
+	r.VoteRules.OnePerTheme = onePerTheme == 1
 	r.CreatedAt = parseTime(createdAt)
 	r.ActivatedAt = parseTime(activatedAt)
 	return r, nil
@@ -189,6 +231,48 @@ func (s *SQLiteStore) DeleteRotorTheme(ctx context.Context, id string) error {
 	return err
 }
 
+// GetRotorTheme retrieves a theme by ID.
+// PRE: id is non-empty
+// POST: returns the theme or error if not found
+func (s *SQLiteStore) GetRotorTheme(ctx context.Context, id string) (domain.RotorTheme, error) {
+	var t domain.RotorTheme
+	var hidden int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, rotor_id, name, position, hidden FROM rotor_theme WHERE id = ?`, id).
+		Scan(&t.ID, &t.RotorID, &t.Name, &t.Position, &hidden)
+	if err != nil {
+		return domain.RotorTheme{}, err
+	}
+	t.Hidden = hidden == 1
+	return t, nil
+}
+
+// SaveRotorThemeWithTopics inserts a theme together with its topics in one transaction.
+// PRE: theme and topics are valid and carry fresh IDs
+// POST: either the theme and every topic are persisted or nothing is
+func (s *SQLiteStore) SaveRotorThemeWithTopics(ctx context.Context, theme domain.RotorTheme, topics []domain.Topic) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO rotor_theme (id, rotor_id, name, position, hidden) VALUES (?, ?, ?, ?, ?)`,
+		theme.ID, theme.RotorID, theme.Name, theme.Position, boolToInt(theme.Hidden)); err != nil {
+		return err
+	}
+	for _, t := range topics {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO topic (id, rotor_theme_id, name, description, duration_weeks, position, last_covered, shared_topic_id, min_belt)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			t.ID, t.RotorThemeID, t.Name, t.Description, t.DurationWeeks, t.Position, formatTime(t.LastCovered), t.SharedTopicID, t.MinBelt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 // --- Topic CRUD ---
 
 // SaveTopic inserts or updates a topic.
@@ -196,12 +280,13 @@ func (s *SQLiteStore) DeleteRotorTheme(ctx context.Context, id string) error {
 // POST: topic is persisted
 func (s *SQLiteStore) SaveTopic(ctx context.Context, t domain.Topic) error {
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO topic (id, rotor_theme_id, name, description, duration_weeks, position, last_covered)
-		 VALUES (?, ?, ?, ?, ?, ?, ?)
+		`INSERT INTO topic (id, rotor_theme_id, name, description, duration_weeks, position, last_covered, shared_topic_id, min_belt)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 		 ON CONFLICT(id) DO UPDATE SET
 		   rotor_theme_id=excluded.rotor_theme_id, name=excluded.name, description=excluded.description,
-		   duration_weeks=excluded.duration_weeks, position=excluded.position, last_covered=excluded.last_covered`,
-		t.ID, t.RotorThemeID, t.Name, t.Description, t.DurationWeeks, t.Position, formatTime(t.LastCovered))
+		   duration_weeks=excluded.duration_weeks, position=excluded.position, last_covered=excluded.last_covered,
+		   shared_topic_id=excluded.shared_topic_id, min_belt=excluded.min_belt`,
+		t.ID, t.RotorThemeID, t.Name, t.Description, t.DurationWeeks, t.Position, formatTime(t.LastCovered), t.SharedTopicID, t.MinBelt)
 	return err
 }
 
@@ -212,9 +297,9 @@ func (s *SQLiteStore) GetTopic(ctx context.Context, id string) (domain.Topic, er
 	var t domain.Topic
 	var lastCovered string
 	err := s.db.QueryRowContext(ctx,
-		`SELECT id, rotor_theme_id, name, description, duration_weeks, position, last_covered
+		`SELECT id, rotor_theme_id, name, description, duration_weeks, position, last_covered, shared_topic_id, min_belt
 		 FROM topic WHERE id = ?`, id).
-		Scan(&t.ID, &t.RotorThemeID, &t.Name, &t.Description, &t.DurationWeeks, &t.Position, &lastCovered)
+		Scan(&t.ID, &t.RotorThemeID, &t.Name, &t.Description, &t.DurationWeeks, &t.Position, &lastCovered, &t.SharedTopicID, &t.MinBelt)
 	if err != nil {
 		return domain.Topic{}, err
 	}
@@ -227,7 +312,7 @@ func (s *SQLiteStore) GetTopic(ctx context.Context, id string) (domain.Topic, er
 // POST: returns topics or empty slice
 func (s *SQLiteStore) ListTopicsByTheme(ctx context.Context, rotorThemeID string) ([]domain.Topic, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, rotor_theme_id, name, description, duration_weeks, position, last_covered
+		`SELECT id, rotor_theme_id, name, description, duration_weeks, position, last_covered, shared_topic_id, min_belt
 		 FROM topic WHERE rotor_theme_id = ? ORDER BY position`, rotorThemeID)
 	if err != nil {
 		return nil, err
@@ -237,7 +322,7 @@ func (s *SQLiteStore) ListTopicsByTheme(ctx context.Context, rotorThemeID string
 	for rows.Next() {
 		var t domain.Topic
 		var lastCovered string
-		if err := rows.Scan(&t.ID, &t.RotorThemeID, &t.Name, &t.Description, &t.DurationWeeks, &t.Position, &lastCovered); err != nil {
+		if err := rows.Scan(&t.ID, &t.RotorThemeID, &t.Name, &t.Description, &t.DurationWeeks, &t.Position, &lastCovered, &t.SharedTopicID, &t.MinBelt); err != nil {
 			return nil, err
 		}
 		t.LastCovered = parseTime(lastCovered)
@@ -275,6 +360,117 @@ func (s *SQLiteStore) ReorderTopics(ctx context.Context, rotorThemeID string, to
 	return tx.Commit()
 }
 
+// --- Shared topic library ---
+
+// SaveSharedTopic inserts or updates a library topic and copies its name and description
+// to every topic linked to it, in one transaction.
+// PRE: st is a valid SharedTopic
+// POST: the library topic is persisted; linked topics match its wording
+func (s *SQLiteStore) SaveSharedTopic(ctx context.Context, st domain.SharedTopic) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO shared_topic (id, name, description, duration_weeks, created_by, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET
+		   name=excluded.name, description=excluded.description,
+		   duration_weeks=excluded.duration_weeks, updated_at=excluded.updated_at`,
+		st.ID, st.Name, st.Description, st.DurationWeeks, st.CreatedBy, formatTime(st.UpdatedAt)); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE topic SET name = ?, description = ? WHERE shared_topic_id = ?`,
+		st.Name, st.Description, st.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetSharedTopic retrieves a library topic by ID.
+// PRE: id is non-empty
+// POST: returns the library topic or error if not found
+func (s *SQLiteStore) GetSharedTopic(ctx context.Context, id string) (domain.SharedTopic, error) {
+	var st domain.SharedTopic
+	var updatedAt string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, name, description, duration_weeks, created_by, updated_at FROM shared_topic WHERE id = ?`, id).
+		Scan(&st.ID, &st.Name, &st.Description, &st.DurationWeeks, &st.CreatedBy, &updatedAt)
+	if err != nil {
+		return domain.SharedTopic{}, err
+	}
+	st.UpdatedAt = parseTime(updatedAt)
+	return st, nil
+}
+
+// ListSharedTopics returns the library, ordered by name.
+// PRE: none
+// POST: returns library topics or empty slice
+func (s *SQLiteStore) ListSharedTopics(ctx context.Context) ([]domain.SharedTopic, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, name, description, duration_weeks, created_by, updated_at FROM shared_topic ORDER BY name COLLATE NOCASE`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []domain.SharedTopic
+	for rows.Next() {
+		var st domain.SharedTopic
+		var updatedAt string
+		if err := rows.Scan(&st.ID, &st.Name, &st.Description, &st.DurationWeeks, &st.CreatedBy, &updatedAt); err != nil {
+			return nil, err
+		}
+		st.UpdatedAt = parseTime(updatedAt)
+		result = append(result, st)
+	}
+	return result, rows.Err()
+}
+
+// CountSharedTopicLinks returns how many topics follow each library topic.
+// PRE: none
+// POST: returns counts keyed by shared topic ID; unused topics are absent
+func (s *SQLiteStore) CountSharedTopicLinks(ctx context.Context) (map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT shared_topic_id, COUNT(*) FROM topic WHERE shared_topic_id != '' GROUP BY shared_topic_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	counts := map[string]int{}
+	for rows.Next() {
+		var id string
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, err
+		}
+		counts[id] = n
+	}
+	return counts, rows.Err()
+}
+
+// DeleteSharedTopic removes a library topic. Linked topics keep their current wording
+// and stop following it.
+// PRE: id is non-empty
+// POST: the library topic is deleted and no topic links to it
+func (s *SQLiteStore) DeleteSharedTopic(ctx context.Context, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE topic SET shared_topic_id = '' WHERE shared_topic_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM shared_topic WHERE id = ?`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
 // --- TopicSchedule ---
 
 // SaveTopicSchedule inserts or updates a schedule entry.
@@ -282,13 +478,14 @@ func (s *SQLiteStore) ReorderTopics(ctx context.Context, rotorThemeID string, to
 // POST: schedule is persisted
 func (s *SQLiteStore) SaveTopicSchedule(ctx context.Context, sched domain.TopicSchedule) error {
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO topic_schedule (id, topic_id, rotor_theme_id, start_date, end_date, status)
-		 VALUES (?, ?, ?, ?, ?, ?)
+		`INSERT INTO topic_schedule (id, topic_id, rotor_theme_id, start_date, end_date, status, bumped)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)
 		 ON CONFLICT(id) DO UPDATE SET
 		   topic_id=excluded.topic_id, rotor_theme_id=excluded.rotor_theme_id,
-		   start_date=excluded.start_date, end_date=excluded.end_date, status=excluded.status`,
+		   start_date=excluded.start_date, end_date=excluded.end_date, status=excluded.status,
+		   bumped=excluded.bumped`,
 		sched.ID, sched.TopicID, sched.RotorThemeID,
-		formatTime(sched.StartDate), formatTime(sched.EndDate), sched.Status)
+		formatTime(sched.StartDate), formatTime(sched.EndDate), sched.Status, boolToInt(sched.Bumped))
 	return err
 }
 
@@ -298,16 +495,18 @@ func (s *SQLiteStore) SaveTopicSchedule(ctx context.Context, sched domain.TopicS
 func (s *SQLiteStore) GetActiveScheduleForTheme(ctx context.Context, rotorThemeID string) (domain.TopicSchedule, error) {
 	var sched domain.TopicSchedule
 	var startDate, endDate string
+	var bumped int
 	err := s.db.QueryRowContext(ctx,
-		`SELECT id, topic_id, rotor_theme_id, start_date, end_date, status
+		`SELECT id, topic_id, rotor_theme_id, start_date, end_date, status, bumped
 		 FROM topic_schedule WHERE rotor_theme_id = ? AND status = 'active' LIMIT 1`,
 		rotorThemeID).
-		Scan(&sched.ID, &sched.TopicID, &sched.RotorThemeID, &startDate, &endDate, &sched.Status)
+		Scan(&sched.ID, &sched.TopicID, &sched.RotorThemeID, &startDate, &endDate, &sched.Status, &bumped)
 	if err != nil {
 		return domain.TopicSchedule{}, err
 	}
 	sched.StartDate = parseTime(startDate)
 	sched.EndDate = parseTime(endDate)
+	sched.Bumped = bumped == 1
 	return sched, nil
 }
 
@@ -316,7 +515,7 @@ func (s *SQLiteStore) GetActiveScheduleForTheme(ctx context.Context, rotorThemeI
 // POST: returns schedules or empty slice
 func (s *SQLiteStore) ListSchedulesByTheme(ctx context.Context, rotorThemeID string) ([]domain.TopicSchedule, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, topic_id, rotor_theme_id, start_date, end_date, status
+		`SELECT id, topic_id, rotor_theme_id, start_date, end_date, status, bumped
 		 FROM topic_schedule WHERE rotor_theme_id = ? ORDER BY start_date DESC`, rotorThemeID)
 	if err != nil {
 		return nil, err
@@ -326,11 +525,13 @@ func (s *SQLiteStore) ListSchedulesByTheme(ctx context.Context, rotorThemeID str
 	for rows.Next() {
 		var sched domain.TopicSchedule
 		var startDate, endDate string
-		if err := rows.Scan(&sched.ID, &sched.TopicID, &sched.RotorThemeID, &startDate, &endDate, &sched.Status); err != nil {
+		var bumped int
+		if err := rows.Scan(&sched.ID, &sched.TopicID, &sched.RotorThemeID, &startDate, &endDate, &sched.Status, &bumped); err != nil {
 			return nil, err
 		}
 		sched.StartDate = parseTime(startDate)
 		sched.EndDate = parseTime(endDate)
+		sched.Bumped = bumped == 1
 		result = append(result, sched)
 	}
 	return result, rows.Err()
@@ -343,23 +544,72 @@ func (s *SQLiteStore) ListSchedulesByTheme(ctx context.Context, rotorThemeID str
 // POST: vote is persisted (fails if duplicate topic_id+account_id)
 func (s *SQLiteStore) SaveVote(ctx context.Context, v domain.Vote) error {
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO vote (id, topic_id, account_id, created_at)
-		 VALUES (?, ?, ?, ?)`,
-		v.ID, v.TopicID, v.AccountID, formatTime(v.CreatedAt))
+		`INSERT INTO vote (id, topic_id, rotor_theme_id, account_id, weight, created_at, expires_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		v.ID, v.TopicID, v.RotorThemeID, v.AccountID, v.Weight, formatTime(v.CreatedAt), formatTime(v.ExpiresAt))
 	if err != nil && strings.Contains(err.Error(), "UNIQUE constraint") {
 		return domain.ErrAlreadyVoted
 	}
 	return err
 }
 
-// CountVotesForTopic returns the number of votes for a topic.
+// CountVotesForTopic returns the weighted total of a topic's votes that are still active.
 // PRE: topicID is non-empty
-// POST: returns the vote count
-func (s *SQLiteStore) CountVotesForTopic(ctx context.Context, topicID string) (int, error) {
-	var count int
-	err := s.db.QueryRowContext(ctx,
-		`SELECT COUNT(*) FROM vote WHERE topic_id = ?`, topicID).Scan(&count)
-	return count, err
+// POST: returns the sum of the weights of votes not expired at now
+func (s *SQLiteStore) CountVotesForTopic(ctx context.Context, topicID string, now time.Time) (int, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, topic_id, rotor_theme_id, account_id, weight, created_at, expires_at FROM vote WHERE topic_id = ?`, topicID)
+	if err != nil {
+		return 0, err
+	}
+	votes, err := scanVotes(rows)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, v := range votes {
+		if v.IsActive(now) {
+			total += v.Weight
+		}
+	}
+	return total, nil
+}
+
+// ListVotesByAccount returns an account's votes, expired or not, newest first.
+// PRE: accountID is non-empty
+// POST: returns the votes or an empty slice
+func (s *SQLiteStore) ListVotesByAccount(ctx context.Context, accountID string) ([]domain.Vote, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, topic_id, rotor_theme_id, account_id, weight, created_at, expires_at
+		 FROM vote WHERE account_id = ? ORDER BY created_at DESC`, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return scanVotes(rows)
+}
+
+// DeleteVote deletes one vote.
+// PRE: id is non-empty
+// POST: the vote no longer exists
+func (s *SQLiteStore) DeleteVote(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM vote WHERE id = ?`, id)
+	return err
+}
+
+func scanVotes(rows *sql.Rows) ([]domain.Vote, error) {
+	defer rows.Close()
+	var result []domain.Vote
+	for rows.Next() {
+		var v domain.Vote
+		var createdAt, expiresAt string
+		if err := rows.Scan(&v.ID, &v.TopicID, &v.RotorThemeID, &v.AccountID, &v.Weight, &createdAt, &expiresAt); err != nil {
+			return nil, err
+		}
+		v.CreatedAt = parseTime(createdAt)
+		v.ExpiresAt = parseTime(expiresAt)
+		result = append(result, v)
+	}
+	return result, rows.Err()
 }
 
 // HasVoted checks if an account has voted for a topic.
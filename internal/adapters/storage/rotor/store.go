@@ -2,11 +2,12 @@ package rotor
 
 import (
 	"context"
+	"time"
 
 	domain "workshop/internal/domain/rotor"
 )
 
-// Store persists Rotor, RotorTheme, Topic, TopicSchedule, and Vote state.
+// Store persists Rotor, RotorTheme, Topic, SharedTopic, TopicSchedule, and Vote state.
 type Store interface {
 	// Rotor CRUD
 	SaveRotor(ctx context.Context, r domain.Rotor) error
@@ -14,11 +15,14 @@ type Store interface {
 	ListRotorsByClassType(ctx context.Context, classTypeID string) ([]domain.Rotor, error)
 	GetActiveRotor(ctx context.Context, classTypeID string) (domain.Rotor, error)
 	DeleteRotor(ctx context.Context, id string) error
+	ImportRotor(ctx context.Context, r domain.Rotor, themes []domain.RotorTheme, topics []domain.Topic) error
 
 	// RotorTheme CRUD
 	SaveRotorTheme(ctx context.Context, t domain.RotorTheme) error
+	GetRotorTheme(ctx context.Context, id string) (domain.RotorTheme, error)
 	ListThemesByRotor(ctx context.Context, rotorID string) ([]domain.RotorTheme, error)
 	DeleteRotorTheme(ctx context.Context, id string) error
+	SaveRotorThemeWithTopics(ctx context.Context, theme domain.RotorTheme, topics []domain.Topic) error
 
 	// Topic CRUD
 	SaveTopic(ctx context.Context, t domain.Topic) error
@@ -27,6 +31,13 @@ type Store interface {
 	DeleteTopic(ctx context.Context, id string) error
 	ReorderTopics(ctx context.Context, rotorThemeID string, topicIDs []string) error
 
+	// Shared topic library
+	SaveSharedTopic(ctx context.Context, st domain.SharedTopic) error
+	GetSharedTopic(ctx context.Context, id string) (domain.SharedTopic, error)
+	ListSharedTopics(ctx context.Context) ([]domain.SharedTopic, error)
+	CountSharedTopicLinks(ctx context.Context) (map[string]int, error)
+	DeleteSharedTopic(ctx context.Context, id string) error
+
 	// TopicSchedule
 	SaveTopicSchedule(ctx context.Context, s domain.TopicSchedule) error
 	GetActiveScheduleForTheme(ctx context.Context, rotorThemeID string) (domain.TopicSchedule, error)
@@ -34,7 +45,9 @@ type Store interface {
 
 	// Votes
 	SaveVote(ctx context.Context, v domain.Vote) error
-	CountVotesForTopic(ctx context.Context, topicID string) (int, error)
+	CountVotesForTopic(ctx context.Context, topicID string, now time.Time) (int, error)
 	HasVoted(ctx context.Context, topicID, accountID string) (bool, error)
+	ListVotesByAccount(ctx context.Context, accountID string) ([]domain.Vote, error)
+	DeleteVote(ctx context.Context, id string) error
 	DeleteVotesForTopic(ctx context.Context, topicID string) error
 }
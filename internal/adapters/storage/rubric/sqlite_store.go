@@ -0,0 +1,153 @@
+package rubric
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/rubric"
+)
+
+// TemplateSQLiteStore implements TemplateStore using SQLite.
+type TemplateSQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewTemplateSQLiteStore creates a new TemplateSQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new TemplateSQLiteStore instance
+func NewTemplateSQLiteStore(db storage.SQLDB) *TemplateSQLiteStore {
+	return &TemplateSQLiteStore{db: db}
+}
+
+// templateColumns is the shared column list for rubric_template SELECTs; order matches scanTemplate.
+const templateColumns = "id, name, criteria, archived, created_by, created_at"
+
+// Save inserts or updates a rubric template.
+// PRE: value has been validated
+// POST: The template is persisted
+func (s *TemplateSQLiteStore) Save(ctx context.Context, value domain.Template) error {
+	criteria, err := json.Marshal(value.Criteria)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO rubric_template (`+templateColumns+`) VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET
+		   name=excluded.name, criteria=excluded.criteria, archived=excluded.archived`,
+		value.ID, value.Name, string(criteria), value.Archived, value.CreatedBy, value.CreatedAt.Format(time.RFC3339))
+	return err
+}
+
+// GetByID retrieves a rubric template by its ID.
+// PRE: id is non-empty
+// POST: Returns the template or sql.ErrNoRows
+func (s *TemplateSQLiteStore) GetByID(ctx context.Context, id string) (domain.Template, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+templateColumns+" FROM rubric_template WHERE id = ?", id)
+	return scanTemplate(row.Scan)
+}
+
+// List returns every rubric template, archived included, by name.
+// PRE: none
+// POST: Returns templates or an empty slice
+func (s *TemplateSQLiteStore) List(ctx context.Context) ([]domain.Template, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+templateColumns+" FROM rubric_template ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Template
+	for rows.Next() {
+		t, err := scanTemplate(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, t)
+	}
+	return list, rows.Err()
+}
+
+// scanTemplate extracts a Template from a row scanner function.
+func scanTemplate(scan func(dest ...interface{}) error) (domain.Template, error) {
+	var t domain.Template
+	var criteria, createdAt string
+	if err := scan(&t.ID, &t.Name, &criteria, &t.Archived, &t.CreatedBy, &createdAt); err != nil {
+		return domain.Template{}, err
+	}
+	if err := json.Unmarshal([]byte(criteria), &t.Criteria); err != nil {
+		return domain.Template{}, err
+	}
+	t.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return t, nil
+}
+
+// ScoreSQLiteStore implements ScoreStore using SQLite.
+type ScoreSQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewScoreSQLiteStore creates a new ScoreSQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new ScoreSQLiteStore instance
+func NewScoreSQLiteStore(db storage.SQLDB) *ScoreSQLiteStore {
+	return &ScoreSQLiteStore{db: db}
+}
+
+// scoreColumns is the shared column list for rubric_score SELECTs; order matches scanScore.
+const scoreColumns = "id, template_id, member_id, source, source_id, criterion_key, criterion_label, value, min_value, max_value, author_id, created_at"
+
+// SaveAll inserts a set of scores in one transaction, so a rubric is never half recorded.
+// PRE: values were built by Template.NewScores
+// POST: Every score is persisted, or none are
+func (s *ScoreSQLiteStore) SaveAll(ctx context.Context, values []domain.Score) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, v := range values {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO rubric_score (`+scoreColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			v.ID, v.TemplateID, v.MemberID, v.Source, v.SourceID, v.CriterionKey, v.CriterionLabel,
+			v.Value, v.Min, v.Max, v.AuthorID, v.CreatedAt.Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ListByMemberID returns a member's scores, newest first.
+// PRE: memberID is non-empty
+// POST: Returns scores or an empty slice
+func (s *ScoreSQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]domain.Score, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+scoreColumns+" FROM rubric_score WHERE member_id = ? ORDER BY created_at DESC, rowid", memberID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Score
+	for rows.Next() {
+		sc, err := scanScore(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, sc)
+	}
+	return list, rows.Err()
+}
+
+// scanScore extracts a Score from a row scanner function.
+func scanScore(scan func(dest ...interface{}) error) (domain.Score, error) {
+	var sc domain.Score
+	var createdAt string
+	if err := scan(&sc.ID, &sc.TemplateID, &sc.MemberID, &sc.Source, &sc.SourceID, &sc.CriterionKey, &sc.CriterionLabel,
+		&sc.Value, &sc.Min, &sc.Max, &sc.AuthorID, &createdAt); err != nil {
+		return domain.Score{}, err
+	}
+	sc.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return sc, nil
+}
@@ -0,0 +1,20 @@
+package rubric
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/rubric"
+)
+
+// TemplateStore persists rubric templates.
+type TemplateStore interface {
+	GetByID(ctx context.Context, id string) (domain.Template, error)
+	Save(ctx context.Context, value domain.Template) error
+	List(ctx context.Context) ([]domain.Template, error)
+}
+
+// ScoreStore persists rubric scores.
+type ScoreStore interface {
+	SaveAll(ctx context.Context, values []domain.Score) error
+	ListByMemberID(ctx context.Context, memberID string) ([]domain.Score, error)
+}
@@ -0,0 +1,99 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/schedule"
+)
+
+// OccurrenceChangeSQLiteStore implements OccurrenceChangeStore using SQLite.
+type OccurrenceChangeSQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewOccurrenceChangeSQLiteStore creates a new OccurrenceChangeSQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new OccurrenceChangeSQLiteStore instance
+func NewOccurrenceChangeSQLiteStore(db storage.SQLDB) *OccurrenceChangeSQLiteStore {
+	return &OccurrenceChangeSQLiteStore{db: db}
+}
+
+// occurrenceChangeColumns is the shared column list for class_occurrence_change SELECTs; order matches scanOccurrenceChange.
+const occurrenceChangeColumns = "id, schedule_id, class_date, kind, substitute, reason, class_type_id, start_time, end_time, location_id, mat, notice_id, email_id, created_by, created_at"
+
+// GetByID retrieves an occurrence change by its ID.
+// PRE: id is non-empty
+// POST: Returns the change or sql.ErrNoRows
+func (s *OccurrenceChangeSQLiteStore) GetByID(ctx context.Context, id string) (domain.OccurrenceChange, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+occurrenceChangeColumns+" FROM class_occurrence_change WHERE id = ?", id)
+	return scanOccurrenceChange(row.Scan)
+}
+
+// GetByOccurrence retrieves the change for one schedule on one date.
+// PRE: scheduleID is non-empty; classDate is YYYY-MM-DD
+// POST: Returns the change or sql.ErrNoRows
+func (s *OccurrenceChangeSQLiteStore) GetByOccurrence(ctx context.Context, scheduleID, classDate string) (domain.OccurrenceChange, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+occurrenceChangeColumns+" FROM class_occurrence_change WHERE schedule_id = ? AND class_date = ?", scheduleID, classDate)
+	return scanOccurrenceChange(row.Scan)
+}
+
+// Save inserts or updates an occurrence change.
+// PRE: value has been validated
+// POST: The change is persisted; a second change for the same occurrence fails the unique constraint
+func (s *OccurrenceChangeSQLiteStore) Save(ctx context.Context, value domain.OccurrenceChange) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO class_occurrence_change (`+occurrenceChangeColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET
+		   kind=excluded.kind, substitute=excluded.substitute, reason=excluded.reason,
+		   class_type_id=excluded.class_type_id, start_time=excluded.start_time, end_time=excluded.end_time,
+		   location_id=excluded.location_id, mat=excluded.mat,
+		   notice_id=excluded.notice_id, email_id=excluded.email_id`,
+		value.ID, value.ScheduleID, value.ClassDate, value.Kind, value.Substitute, value.Reason,
+		value.ClassTypeID, value.StartTime, value.EndTime, value.LocationID, value.Mat,
+		value.NoticeID, value.EmailID, value.CreatedBy, value.CreatedAt.Format(time.RFC3339))
+	return err
+}
+
+// Delete removes an occurrence change, restoring the class to its regular schedule.
+// PRE: id is non-empty
+// POST: The change is removed
+func (s *OccurrenceChangeSQLiteStore) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM class_occurrence_change WHERE id = ?", id)
+	return err
+}
+
+// ListByDateRange returns changes for class dates between from and to inclusive, soonest first.
+// PRE: from and to are YYYY-MM-DD
+// POST: Returns changes or an empty slice
+func (s *OccurrenceChangeSQLiteStore) ListByDateRange(ctx context.Context, from, to string) ([]domain.OccurrenceChange, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+occurrenceChangeColumns+" FROM class_occurrence_change WHERE class_date >= ? AND class_date <= ? ORDER BY class_date, created_at", from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.OccurrenceChange
+	for rows.Next() {
+		c, err := scanOccurrenceChange(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+	return list, rows.Err()
+}
+
+// scanOccurrenceChange extracts an OccurrenceChange from a row scanner function.
+func scanOccurrenceChange(scan func(dest ...interface{}) error) (domain.OccurrenceChange, error) {
+	var c domain.OccurrenceChange
+	var createdAt string
+	if err := scan(&c.ID, &c.ScheduleID, &c.ClassDate, &c.Kind, &c.Substitute, &c.Reason,
+		&c.ClassTypeID, &c.StartTime, &c.EndTime, &c.LocationID, &c.Mat, &c.NoticeID, &c.EmailID, &c.CreatedBy, &createdAt); err != nil {
+		return domain.OccurrenceChange{}, err
+	}
+	c.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return c, nil
+}
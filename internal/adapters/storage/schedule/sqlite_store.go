@@ -9,6 +9,12 @@ import (
 	domain "workshop/internal/domain/schedule"
 )
 
+// scheduleColumns lists the columns scanSchedule reads, in order.
+const scheduleColumns = "id, class_type_id, day, start_time, end_time, location_id, coach_id, mat"
+
+// saveScheduleSQL upserts one schedule.
+const saveScheduleSQL = "INSERT INTO schedule (id, class_type_id, day, start_time, end_time, location_id, coach_id, mat) VALUES (?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT(id) DO UPDATE SET class_type_id=excluded.class_type_id, day=excluded.day, start_time=excluded.start_time, end_time=excluded.end_time, location_id=excluded.location_id, coach_id=excluded.coach_id, mat=excluded.mat"
+
 // SQLiteStore implements Store using SQLite.
 type SQLiteStore struct {
 	db storage.SQLDB
@@ -23,9 +29,8 @@ func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
 // PRE: id is non-empty
 // POST: Returns the entity or an error if not found
 func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Schedule, error) {
-	row := s.db.QueryRowContext(ctx, "SELECT id, class_type_id, day, start_time, end_time FROM schedule WHERE id = ?", id)
-	var entity domain.Schedule
-	err := row.Scan(&entity.ID, &entity.ClassTypeID, &entity.Day, &entity.StartTime, &entity.EndTime)
+	row := s.db.QueryRowContext(ctx, "SELECT "+scheduleColumns+" FROM schedule WHERE id = ?", id)
+	entity, err := scanSchedule(row.Scan)
 	if err == sql.ErrNoRows {
 		return domain.Schedule{}, fmt.Errorf("schedule not found: %w", err)
 	}
@@ -36,13 +41,31 @@ func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Schedule,
 // PRE: entity has been validated
 // POST: Entity is persisted (insert or update)
 func (s *SQLiteStore) Save(ctx context.Context, entity domain.Schedule) error {
-	_, err := s.db.ExecContext(ctx,
-		"INSERT INTO schedule (id, class_type_id, day, start_time, end_time) VALUES (?, ?, ?, ?, ?) ON CONFLICT(id) DO UPDATE SET class_type_id=excluded.class_type_id, day=excluded.day, start_time=excluded.start_time, end_time=excluded.end_time",
-		entity.ID, entity.ClassTypeID, entity.Day, entity.StartTime, entity.EndTime,
+	_, err := s.db.ExecContext(ctx, saveScheduleSQL,
+		entity.ID, entity.ClassTypeID, entity.Day, entity.StartTime, entity.EndTime, entity.LocationID, entity.CoachID, entity.Mat,
 	)
 	return err
 }
 
+// SaveAll persists several Schedules in one transaction.
+// PRE: every entity has been validated
+// POST: Either every entity is persisted or none is
+func (s *SQLiteStore) SaveAll(ctx context.Context, entities []domain.Schedule) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, entity := range entities {
+		if _, err := tx.ExecContext(ctx, saveScheduleSQL,
+			entity.ID, entity.ClassTypeID, entity.Day, entity.StartTime, entity.EndTime, entity.LocationID, entity.CoachID, entity.Mat,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 // Delete removes a Schedule from the database.
 // PRE: id is non-empty
 // POST: Entity with given id is removed
@@ -55,21 +78,21 @@ func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
 // PRE: filter has valid parameters
 // POST: Returns matching entities
 func (s *SQLiteStore) List(ctx context.Context) ([]domain.Schedule, error) {
-	return s.querySchedules(ctx, "SELECT id, class_type_id, day, start_time, end_time FROM schedule ORDER BY day, start_time")
+	return s.querySchedules(ctx, "SELECT "+scheduleColumns+" FROM schedule ORDER BY day, start_time")
 }
 
 // ListByDay retrieves Schedules for a specific day.
 // PRE: day is a valid weekday
 // POST: Returns schedules for the given day
 func (s *SQLiteStore) ListByDay(ctx context.Context, day string) ([]domain.Schedule, error) {
-	return s.querySchedules(ctx, "SELECT id, class_type_id, day, start_time, end_time FROM schedule WHERE day = ? ORDER BY start_time", day)
+	return s.querySchedules(ctx, "SELECT "+scheduleColumns+" FROM schedule WHERE day = ? ORDER BY start_time", day)
 }
 
 // ListByClassTypeID retrieves Schedules for a specific class type.
 // PRE: classTypeID is non-empty
 // POST: Returns schedules for the given class type
 func (s *SQLiteStore) ListByClassTypeID(ctx context.Context, classTypeID string) ([]domain.Schedule, error) {
-	return s.querySchedules(ctx, "SELECT id, class_type_id, day, start_time, end_time FROM schedule WHERE class_type_id = ? ORDER BY day, start_time", classTypeID)
+	return s.querySchedules(ctx, "SELECT "+scheduleColumns+" FROM schedule WHERE class_type_id = ? ORDER BY day, start_time", classTypeID)
 }
 
 func (s *SQLiteStore) querySchedules(ctx context.Context, query string, args ...interface{}) ([]domain.Schedule, error) {
@@ -81,11 +104,18 @@ func (s *SQLiteStore) querySchedules(ctx context.Context, query string, args ...
 
 	var results []domain.Schedule
 	for rows.Next() {
-		var entity domain.Schedule
-		if err := rows.Scan(&entity.ID, &entity.ClassTypeID, &entity.Day, &entity.StartTime, &entity.EndTime); err != nil {
+		entity, err := scanSchedule(rows.Scan)
+		if err != nil {
 			return nil, err
 		}
 		results = append(results, entity)
 	}
-	return results, nil
+	return results, rows.Err()
+}
+
+// scanSchedule reads one row selected with scheduleColumns.
+func scanSchedule(scan func(dest ...interface{}) error) (domain.Schedule, error) {
+	var entity domain.Schedule
+	err := scan(&entity.ID, &entity.ClassTypeID, &entity.Day, &entity.StartTime, &entity.EndTime, &entity.LocationID, &entity.CoachID, &entity.Mat)
+	return entity, err
 }
@@ -10,8 +10,18 @@ import (
 type Store interface {
 	GetByID(ctx context.Context, id string) (domain.Schedule, error)
 	Save(ctx context.Context, value domain.Schedule) error
+	SaveAll(ctx context.Context, values []domain.Schedule) error // all or nothing
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context) ([]domain.Schedule, error)
 	ListByDay(ctx context.Context, day string) ([]domain.Schedule, error)
 	ListByClassTypeID(ctx context.Context, classTypeID string) ([]domain.Schedule, error)
 }
+
+// OccurrenceChangeStore persists cancellations and substitutions of single class occurrences.
+type OccurrenceChangeStore interface {
+	GetByID(ctx context.Context, id string) (domain.OccurrenceChange, error)
+	GetByOccurrence(ctx context.Context, scheduleID, classDate string) (domain.OccurrenceChange, error)
+	Save(ctx context.Context, value domain.OccurrenceChange) error
+	Delete(ctx context.Context, id string) error
+	ListByDateRange(ctx context.Context, from, to string) ([]domain.OccurrenceChange, error)
+}
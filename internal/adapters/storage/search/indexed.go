@@ -0,0 +1,231 @@
+package search
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	clipStore "workshop/internal/adapters/storage/clip"
+	memberStore "workshop/internal/adapters/storage/member"
+	messageStore "workshop/internal/adapters/storage/message"
+	noticeStore "workshop/internal/adapters/storage/notice"
+	rotorStore "workshop/internal/adapters/storage/rotor"
+	clipDomain "workshop/internal/domain/clip"
+	memberDomain "workshop/internal/domain/member"
+	messageDomain "workshop/internal/domain/message"
+	noticeDomain "workshop/internal/domain/notice"
+	rotorDomain "workshop/internal/domain/rotor"
+	domain "workshop/internal/domain/search"
+)
+
+// The Indexed* wrappers keep the search index in step with saves and deletes.
+// Index failures are logged, never returned: the source write already succeeded,
+// and the index is rebuilt from the source tables on startup.
+
+func reindex(ctx context.Context, index Store, kind, refID string) {
+	if err := index.Reindex(ctx, kind, refID); err != nil {
+		slog.WarnContext(ctx, "search_index_failed", "kind", kind, "ref_id", refID, "error", err)
+	}
+}
+
+func remove(ctx context.Context, index Store, kind, refID string) {
+	if err := index.Remove(ctx, kind, refID); err != nil {
+		slog.WarnContext(ctx, "search_index_failed", "kind", kind, "ref_id", refID, "error", err)
+	}
+}
+
+// IndexedMemberStore indexes members on save and delete.
+type IndexedMemberStore struct {
+	memberStore.Store
+	Index Store
+}
+
+// Save persists the member and reindexes it.
+// PRE: value has been validated
+// POST: Member is persisted and searchable
+func (s IndexedMemberStore) Save(ctx context.Context, value memberDomain.Member) error {
+	if err := s.Store.Save(ctx, value); err != nil {
+		return err
+	}
+	reindex(ctx, s.Index, domain.KindMember, value.ID)
+	return nil
+}
+
+// Delete removes the member and its index entry.
+// PRE: id is non-empty
+// POST: Member is gone from the store and the index
+func (s IndexedMemberStore) Delete(ctx context.Context, id string) error {
+	if err := s.Store.Delete(ctx, id); err != nil {
+		return err
+	}
+	remove(ctx, s.Index, domain.KindMember, id)
+	return nil
+}
+
+// IndexedNoticeStore indexes notices on save and delete.
+type IndexedNoticeStore struct {
+	noticeStore.Store
+	Index Store
+}
+
+// Save persists the notice and reindexes it.
+// PRE: value has been validated
+// POST: Notice is persisted; its visibility follows its status
+func (s IndexedNoticeStore) Save(ctx context.Context, value noticeDomain.Notice) error {
+	if err := s.Store.Save(ctx, value); err != nil {
+		return err
+	}
+	reindex(ctx, s.Index, domain.KindNotice, value.ID)
+	return nil
+}
+
+// Delete removes the notice and its index entry.
+// PRE: id is non-empty
+// POST: Notice is gone from the store and the index
+func (s IndexedNoticeStore) Delete(ctx context.Context, id string) error {
+	if err := s.Store.Delete(ctx, id); err != nil {
+		return err
+	}
+	remove(ctx, s.Index, domain.KindNotice, id)
+	return nil
+}
+
+// ArchiveExpired archives notices past their end date and reindexes them as staff-only.
+// PRE: now is the current time
+// POST: Archived notices no longer appear in members' search results
+func (s IndexedNoticeStore) ArchiveExpired(ctx context.Context, now time.Time) ([]string, error) {
+	ids, err := s.Store.ArchiveExpired(ctx, now)
+	for _, id := range ids {
+		reindex(ctx, s.Index, domain.KindNotice, id)
+	}
+	return ids, err
+}
+
+// IndexedClipStore indexes clips on save and delete.
+type IndexedClipStore struct {
+	clipStore.Store
+	Index Store
+}
+
+// Save persists the clip and reindexes it.
+// PRE: value has been validated
+// POST: Clip is persisted and searchable
+func (s IndexedClipStore) Save(ctx context.Context, value clipDomain.Clip) error {
+	if err := s.Store.Save(ctx, value); err != nil {
+		return err
+	}
+	reindex(ctx, s.Index, domain.KindClip, value.ID)
+	return nil
+}
+
+// Delete removes the clip and its index entry.
+// PRE: id is non-empty
+// POST: Clip is gone from the store and the index
+func (s IndexedClipStore) Delete(ctx context.Context, id string) error {
+	if err := s.Store.Delete(ctx, id); err != nil {
+		return err
+	}
+	remove(ctx, s.Index, domain.KindClip, id)
+	return nil
+}
+
+// IndexedMessageStore indexes messages on save and delete.
+type IndexedMessageStore struct {
+	messageStore.Store
+	Index Store
+}
+
+// Save persists the message and reindexes it.
+// PRE: value has been validated
+// POST: Message is persisted and searchable by its sender and receiver
+func (s IndexedMessageStore) Save(ctx context.Context, value messageDomain.Message) error {
+	if err := s.Store.Save(ctx, value); err != nil {
+		return err
+	}
+	reindex(ctx, s.Index, domain.KindMessage, value.ID)
+	return nil
+}
+
+// Delete removes the message and its index entry.
+// PRE: id is non-empty
+// POST: Message is gone from the store and the index
+func (s IndexedMessageStore) Delete(ctx context.Context, id string) error {
+	if err := s.Store.Delete(ctx, id); err != nil {
+		return err
+	}
+	remove(ctx, s.Index, domain.KindMessage, id)
+	return nil
+}
+
+// IndexedRotorStore indexes curriculum topics. Theme and rotor changes can change the
+// visibility of (or cascade-delete) many topics, so they reindex every topic.
+type IndexedRotorStore struct {
+	rotorStore.Store
+	Index Store
+}
+
+// SaveTopic persists the topic and reindexes it.
+// PRE: t has been validated
+// POST: Topic is persisted and searchable
+func (s IndexedRotorStore) SaveTopic(ctx context.Context, t rotorDomain.Topic) error {
+	if err := s.Store.SaveTopic(ctx, t); err != nil {
+		return err
+	}
+	reindex(ctx, s.Index, domain.KindTopic, t.ID)
+	return nil
+}
+
+// DeleteTopic removes the topic and its index entry.
+// PRE: id is non-empty
+// POST: Topic is gone from the store and the index
+func (s IndexedRotorStore) DeleteTopic(ctx context.Context, id string) error {
+	if err := s.Store.DeleteTopic(ctx, id); err != nil {
+		return err
+	}
+	remove(ctx, s.Index, domain.KindTopic, id)
+	return nil
+}
+
+// SaveRotorTheme persists the theme and reindexes topics (the hidden flag may have changed).
+// PRE: t has been validated
+// POST: Theme is persisted; topic visibility matches it
+func (s IndexedRotorStore) SaveRotorTheme(ctx context.Context, t rotorDomain.RotorTheme) error {
+	if err := s.Store.SaveRotorTheme(ctx, t); err != nil {
+		return err
+	}
+	reindex(ctx, s.Index, domain.KindTopic, "")
+	return nil
+}
+
+// DeleteRotorTheme removes the theme (and its topics) and reindexes topics.
+// PRE: id is non-empty
+// POST: No index entries remain for the theme's topics
+func (s IndexedRotorStore) DeleteRotorTheme(ctx context.Context, id string) error {
+	if err := s.Store.DeleteRotorTheme(ctx, id); err != nil {
+		return err
+	}
+	reindex(ctx, s.Index, domain.KindTopic, "")
+	return nil
+}
+
+// DeleteRotor removes the rotor (and its topics) and reindexes topics.
+// PRE: id is non-empty
+// POST: No index entries remain for the rotor's topics
+func (s IndexedRotorStore) DeleteRotor(ctx context.Context, id string) error {
+	if err := s.Store.DeleteRotor(ctx, id); err != nil {
+		return err
+	}
+	reindex(ctx, s.Index, domain.KindTopic, "")
+	return nil
+}
+
+// ImportRotor stores an imported rotor and indexes its topics.
+// PRE: r, themes and topics have been validated
+// POST: Imported topics are searchable
+func (s IndexedRotorStore) ImportRotor(ctx context.Context, r rotorDomain.Rotor, themes []rotorDomain.RotorTheme, topics []rotorDomain.Topic) error {
+	if err := s.Store.ImportRotor(ctx, r, themes, topics); err != nil {
+		return err
+	}
+	reindex(ctx, s.Index, domain.KindTopic, "")
+	return nil
+}
@@ -0,0 +1,201 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/search"
+)
+
+// source describes how to derive index rows for one kind from its table.
+// The select must yield ref_id, title, body, visibility, participants in that order.
+type source struct {
+	table    string // source table; the kind is skipped if it does not exist
+	selectQ  string
+	idColumn string
+}
+
+// sources maps each kind to its source query. Visibility rules live here:
+// members are staff-only, draft notices and topics of hidden (surprise) themes are
+// staff-only, belt-gated clips and topics are staff-only (search cannot tell a member's
+// belt), and messages are visible only to their sender and receiver.
+var sources = map[string]source{
+	domain.KindMember: {
+		table:    "member",
+		selectQ:  `SELECT id, name, email || ' ' || program, 'staff', '' FROM member`,
+		idColumn: "id",
+	},
+	domain.KindNotice: {
+		table:    "notice",
+		selectQ:  `SELECT id, title, content, CASE WHEN status = 'published' THEN 'all' ELSE 'staff' END, '' FROM notice`,
+		idColumn: "id",
+	},
+	domain.KindClip: {
+		table: "clips",
+		selectQ: `SELECT c.id, c.title, c.notes || ' ' || COALESCE(t.name, ''), CASE WHEN c.min_belt != '' OR COALESCE(t.min_belt, '') != '' THEN 'staff' ELSE 'all' END, ''
+			FROM clips c LEFT JOIN themes t ON t.id = c.theme_id`,
+		idColumn: "c.id",
+	},
+	domain.KindTopic: {
+		table: "topic",
+		selectQ: `SELECT tp.id, tp.name, tp.description || ' ' || rt.name, CASE WHEN rt.hidden = 1 OR tp.min_belt != '' THEN 'staff' ELSE 'all' END, ''
+			FROM topic tp JOIN rotor_theme rt ON rt.id = tp.rotor_theme_id`,
+		idColumn: "tp.id",
+	},
+	domain.KindMessage: {
+		table:    "message",
+		selectQ:  `SELECT id, COALESCE(subject, ''), content, 'participants', ' ' || sender_id || ' ' || receiver_id || ' ' FROM message`,
+		idColumn: "id",
+	},
+}
+
+// SQLiteStore implements Store using the FTS5 search_index table.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SearchStore.
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// Reindex refreshes one document (or every document of a kind) from its source table.
+// A document whose source row no longer exists is removed.
+// PRE: kind is a valid search kind
+// POST: The index matches the source for the given kind/refID
+func (s *SQLiteStore) Reindex(ctx context.Context, kind, refID string) error {
+	src, ok := sources[kind]
+	if !ok {
+		return fmt.Errorf("unknown search kind %q", kind)
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	del := "DELETE FROM search_index WHERE kind = ?"
+	ins := "INSERT INTO search_index (ref_id, title, body, visibility, participants, kind) SELECT q.*, ? FROM (" + src.selectQ
+	args := []interface{}{kind}
+	if refID != "" {
+		del += " AND ref_id = ?"
+		ins += " WHERE " + src.idColumn + " = ?"
+	}
+	ins += ") q"
+	if refID != "" {
+		args = append(args, refID)
+	}
+	if _, err := tx.ExecContext(ctx, del, args...); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, ins, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Remove deletes a document from the index.
+// PRE: kind and refID are non-empty
+// POST: No index row exists for kind/refID
+func (s *SQLiteStore) Remove(ctx context.Context, kind, refID string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM search_index WHERE kind = ? AND ref_id = ?", kind, refID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Rebuild repopulates the whole index from the source tables.
+// PRE: none
+// POST: The index contains exactly one row per indexed source row
+func (s *SQLiteStore) Rebuild(ctx context.Context) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM search_index"); err != nil {
+		return err
+	}
+	for _, kind := range domain.Kinds {
+		src := sources[kind]
+		var n int
+		if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", src.table).Scan(&n); err != nil {
+			return err
+		}
+		if n == 0 {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO search_index (ref_id, title, body, visibility, participants, kind) SELECT q.*, ? FROM ("+src.selectQ+") q", kind); err != nil {
+			return fmt.Errorf("index %s: %w", kind, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Search returns the best matches for each permitted kind, ranked by relevance.
+// Title matches are weighted ten times body matches.
+// PRE: q has been validated and Kinds already filtered for the caller's role
+// POST: Returns at most q.PerKind results per kind, in q.Kinds order
+func (s *SQLiteStore) Search(ctx context.Context, q domain.Query) ([]domain.Result, error) {
+	match := domain.MatchExpression(q.Text)
+	if match == "" {
+		return nil, nil
+	}
+	perKind := q.PerKind
+	if perKind <= 0 {
+		perKind = domain.DefaultPerKind
+	}
+
+	// Visibility: public rows, staff rows for staff, and participant rows naming the caller.
+	visible := []string{"visibility = 'all'"}
+	var visArgs []interface{}
+	if q.Staff {
+		visible = append(visible, "visibility = 'staff'")
+	}
+	for _, id := range q.Participants {
+		if id == "" {
+			continue
+		}
+		visible = append(visible, "(visibility = 'participants' AND instr(participants, ' ' || ? || ' ') > 0)")
+		visArgs = append(visArgs, id)
+	}
+	query := `SELECT kind, ref_id, title, snippet(search_index, -1, '[', ']', '…', 12)
+		FROM search_index
+		WHERE search_index MATCH ? AND kind = ? AND (` + strings.Join(visible, " OR ") + `)
+		ORDER BY bm25(search_index, 10.0, 1.0)
+		LIMIT ?`
+
+	var results []domain.Result
+	for _, kind := range q.Kinds {
+		args := append([]interface{}{match, kind}, visArgs...)
+		args = append(args, perKind)
+		rows, err := s.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var r domain.Result
+			if err := rows.Scan(&r.Kind, &r.RefID, &r.Title, &r.Snippet); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			results = append(results, r)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,16 @@
+package search
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/search"
+)
+
+// Store maintains and queries the full-text search index.
+// Documents are derived from their source tables, so callers only say what changed.
+type Store interface {
+	Reindex(ctx context.Context, kind, refID string) error // refID "" reindexes the whole kind
+	Remove(ctx context.Context, kind, refID string) error
+	Rebuild(ctx context.Context) error
+	Search(ctx context.Context, q domain.Query) ([]domain.Result, error)
+}
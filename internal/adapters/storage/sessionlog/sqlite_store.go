@@ -0,0 +1,216 @@
+package sessionlog
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/sessionlog"
+)
+
+const sessionLogColumns = "id, schedule_id, class_date, coach_id, round_structure, attendance_notes, notes, shareable, created_at, updated_at"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SessionLogStore.
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// GetByID retrieves a SessionLog by its ID.
+// PRE: id is non-empty
+// POST: Returns the entity with its topics or an error if not found
+func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.SessionLog, error) {
+	return s.getOne(ctx, "SELECT "+sessionLogColumns+" FROM session_log WHERE id = ?", id)
+}
+
+// GetByScheduleAndDate retrieves the SessionLog for one class occurrence.
+// PRE: scheduleID and classDate are non-empty
+// POST: Returns the entity with its topics or an error if not found
+func (s *SQLiteStore) GetByScheduleAndDate(ctx context.Context, scheduleID, classDate string) (domain.SessionLog, error) {
+	return s.getOne(ctx, "SELECT "+sessionLogColumns+" FROM session_log WHERE schedule_id = ? AND class_date = ?", scheduleID, classDate)
+}
+
+func (s *SQLiteStore) getOne(ctx context.Context, query string, args ...interface{}) (domain.SessionLog, error) {
+	l, err := scanSessionLog(s.db.QueryRowContext(ctx, query, args...).Scan)
+	if err == sql.ErrNoRows {
+		return domain.SessionLog{}, fmt.Errorf("session log not found: %w", err)
+	}
+	if err != nil {
+		return domain.SessionLog{}, err
+	}
+	logs := []domain.SessionLog{l}
+	if err := s.loadTopics(ctx, logs); err != nil {
+		return domain.SessionLog{}, err
+	}
+	return logs[0], nil
+}
+
+// Save persists a SessionLog and replaces its topic list.
+// PRE: entity has been validated
+// POST: Entity and topics are persisted (insert or update)
+func (s *SQLiteStore) Save(ctx context.Context, l domain.SessionLog) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO session_log (`+sessionLogColumns+`)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET
+		   coach_id=excluded.coach_id, round_structure=excluded.round_structure,
+		   attendance_notes=excluded.attendance_notes, notes=excluded.notes,
+		   shareable=excluded.shareable, updated_at=excluded.updated_at`,
+		l.ID, l.ScheduleID, l.ClassDate, l.CoachID, l.RoundStructure, l.AttendanceNotes, l.Notes, l.Shareable,
+		l.CreatedAt.Format(time.RFC3339), nullTime(l.UpdatedAt))
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM session_log_topic WHERE session_log_id = ?", l.ID); err != nil {
+		return err
+	}
+	for _, topicID := range l.TopicIDs {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT OR IGNORE INTO session_log_topic (session_log_id, topic_id) VALUES (?, ?)", l.ID, topicID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Delete removes a SessionLog and its topic links.
+// PRE: id is non-empty
+// POST: Entity with given id is removed
+func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, "DELETE FROM session_log_topic WHERE session_log_id = ?", id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM session_log WHERE id = ?", id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// List retrieves SessionLogs matching the filter, newest class first.
+// PRE: filter dates, if set, are YYYY-MM-DD
+// POST: Returns matching logs with their topics
+func (s *SQLiteStore) List(ctx context.Context, filter ListFilter) ([]domain.SessionLog, error) {
+	var where []string
+	var args []interface{}
+	if filter.ScheduleID != "" {
+		where = append(where, "schedule_id = ?")
+		args = append(args, filter.ScheduleID)
+	}
+	if filter.TopicID != "" {
+		where = append(where, "id IN (SELECT session_log_id FROM session_log_topic WHERE topic_id = ?)")
+		args = append(args, filter.TopicID)
+	}
+	if filter.From != "" {
+		where = append(where, "class_date >= ?")
+		args = append(args, filter.From)
+	}
+	if filter.To != "" {
+		where = append(where, "class_date <= ?")
+		args = append(args, filter.To)
+	}
+
+	query := "SELECT " + sessionLogColumns + " FROM session_log"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY class_date DESC, created_at DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []domain.SessionLog
+	for rows.Next() {
+		l, err := scanSessionLog(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := s.loadTopics(ctx, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// loadTopics fills TopicIDs on each log with a single query.
+func (s *SQLiteStore) loadTopics(ctx context.Context, logs []domain.SessionLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	index := make(map[string]int, len(logs))
+	placeholders := make([]string, len(logs))
+	args := make([]interface{}, len(logs))
+	for i, l := range logs {
+		index[l.ID] = i
+		placeholders[i] = "?"
+		args[i] = l.ID
+	}
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT session_log_id, topic_id FROM session_log_topic WHERE session_log_id IN ("+strings.Join(placeholders, ", ")+") ORDER BY rowid",
+		args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var logID, topicID string
+		if err := rows.Scan(&logID, &topicID); err != nil {
+			return err
+		}
+		i := index[logID]
+		logs[i].TopicIDs = append(logs[i].TopicIDs, topicID)
+	}
+	return rows.Err()
+}
+
+// scanSessionLog reads one session_log row using the given Scan func.
+func scanSessionLog(scan func(dest ...interface{}) error) (domain.SessionLog, error) {
+	var l domain.SessionLog
+	var createdAt string
+	var updatedAt sql.NullString
+	if err := scan(&l.ID, &l.ScheduleID, &l.ClassDate, &l.CoachID, &l.RoundStructure, &l.AttendanceNotes, &l.Notes, &l.Shareable, &createdAt, &updatedAt); err != nil {
+		return domain.SessionLog{}, err
+	}
+	l.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	if updatedAt.Valid {
+		l.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt.String)
+	}
+	return l, nil
+}
+
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Format(time.RFC3339)
+}
+
+var _ Store = (*SQLiteStore)(nil)
@@ -0,0 +1,26 @@
+package sessionlog
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/sessionlog"
+)
+
+// Store persists SessionLog state.
+type Store interface {
+	GetByID(ctx context.Context, id string) (domain.SessionLog, error)
+	GetByScheduleAndDate(ctx context.Context, scheduleID, classDate string) (domain.SessionLog, error)
+	Save(ctx context.Context, value domain.SessionLog) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, filter ListFilter) ([]domain.SessionLog, error)
+}
+
+// ListFilter carries filtering parameters for List operations.
+// Empty fields are ignored.
+type ListFilter struct {
+	ScheduleID string
+	TopicID    string
+	From       string // inclusive YYYY-MM-DD
+	To         string // inclusive YYYY-MM-DD
+	Limit      int
+}
@@ -0,0 +1,125 @@
+package statuschange
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/statuschange"
+)
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+const changeColumns = `id, subject_type, subject_id, action, reason, effective_date, created_by, created_at, applied_at, error`
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func parseTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+// Save inserts or updates a status change.
+// PRE: c has been validated
+// POST: change is persisted
+func (s *SQLiteStore) Save(ctx context.Context, c domain.Change) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO status_change (`+changeColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(id) DO UPDATE SET reason=excluded.reason, effective_date=excluded.effective_date,
+		   applied_at=excluded.applied_at, error=excluded.error`,
+		c.ID, c.SubjectType, c.SubjectID, c.Action, c.Reason, c.EffectiveDate,
+		c.CreatedBy, formatTime(c.CreatedAt), formatTime(c.AppliedAt), c.Error)
+	return err
+}
+
+// GetByID retrieves a status change by ID.
+// PRE: id is non-empty
+// POST: returns the change or sql.ErrNoRows
+func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Change, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+changeColumns+` FROM status_change WHERE id = ?`, id)
+	return scanChange(row.Scan)
+}
+
+// ListDue returns scheduled changes effective on or before today, oldest first.
+// PRE: today is YYYY-MM-DD
+// POST: returns unapplied, unfailed changes or empty slice
+func (s *SQLiteStore) ListDue(ctx context.Context, today string) ([]domain.Change, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+changeColumns+` FROM status_change
+		 WHERE applied_at = '' AND error = '' AND effective_date <= ? ORDER BY effective_date, created_at`, today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanChanges(rows)
+}
+
+// ListBySubject returns an account's or member's changes, newest effective date first.
+// PRE: subjectType and subjectID are non-empty
+// POST: returns scheduled and applied changes or empty slice
+func (s *SQLiteStore) ListBySubject(ctx context.Context, subjectType string, subjectID string) ([]domain.Change, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+changeColumns+` FROM status_change
+		 WHERE subject_type = ? AND subject_id = ? ORDER BY effective_date DESC, created_at DESC`, subjectType, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanChanges(rows)
+}
+
+// Delete removes a status change by ID.
+// PRE: id is non-empty
+// POST: change is deleted
+func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM status_change WHERE id = ?`, id)
+	return err
+}
+
+// scanChanges scans rows into a Change slice.
+func scanChanges(rows *sql.Rows) ([]domain.Change, error) {
+	var result []domain.Change
+	for rows.Next() {
+		c, err := scanChange(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
+
+// scanChange extracts a Change from a row scanner function.
+func scanChange(scan func(dest ...any) error) (domain.Change, error) {
+	var c domain.Change
+	var createdAt, appliedAt string
+	if err := scan(&c.ID, &c.SubjectType, &c.SubjectID, &c.Action, &c.Reason, &c.EffectiveDate,
+		&c.CreatedBy, &createdAt, &appliedAt, &c.Error); err != nil {
+		return domain.Change{}, err
+	}
+	c.CreatedAt = parseTime(createdAt)
+	c.AppliedAt = parseTime(appliedAt)
+	return c, nil
+}
+
+// Verify interface compliance at compile time.
+var _ Store = (*SQLiteStore)(nil)
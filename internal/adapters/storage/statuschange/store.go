@@ -0,0 +1,16 @@
+package statuschange
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/statuschange"
+)
+
+// Store persists scheduled and applied status changes.
+type Store interface {
+	Save(ctx context.Context, c domain.Change) error
+	GetByID(ctx context.Context, id string) (domain.Change, error)
+	ListDue(ctx context.Context, today string) ([]domain.Change, error)
+	ListBySubject(ctx context.Context, subjectType string, subjectID string) ([]domain.Change, error)
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,55 @@
+package term
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/term"
+)
+
+// ReportSQLiteStore implements ReportStore using SQLite.
+type ReportSQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewReportSQLiteStore creates a new ReportSQLiteStore.
+func NewReportSQLiteStore(db storage.SQLDB) *ReportSQLiteStore {
+	return &ReportSQLiteStore{db: db}
+}
+
+// SaveDelivery records a report being emailed, replacing an earlier delivery of the same report.
+// PRE: value has been validated
+// POST: The delivery is persisted
+func (s *ReportSQLiteStore) SaveDelivery(ctx context.Context, value domain.ReportDelivery) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO term_report_delivery (term_id, member_id, recipient, sent_by, sent_at) VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT(term_id, member_id) DO UPDATE SET recipient=excluded.recipient, sent_by=excluded.sent_by, sent_at=excluded.sent_at`,
+		value.TermID, value.MemberID, value.Recipient, value.SentBy, value.SentAt.UTC().Format(time.RFC3339),
+	)
+	return err
+}
+
+// ListDeliveries returns the reports emailed for a term, oldest first.
+// PRE: termID is non-empty
+// POST: Returns the deliveries (empty when none have been sent)
+func (s *ReportSQLiteStore) ListDeliveries(ctx context.Context, termID string) ([]domain.ReportDelivery, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT term_id, member_id, recipient, sent_by, sent_at FROM term_report_delivery WHERE term_id = ? ORDER BY sent_at, member_id", termID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []domain.ReportDelivery
+	for rows.Next() {
+		var d domain.ReportDelivery
+		var sentAt string
+		if err := rows.Scan(&d.TermID, &d.MemberID, &d.Recipient, &d.SentBy, &sentAt); err != nil {
+			return nil, err
+		}
+		d.SentAt, _ = time.Parse(time.RFC3339, sentAt)
+		results = append(results, d)
+	}
+	return results, rows.Err()
+}
@@ -0,0 +1,107 @@
+package term
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/term"
+)
+
+// RolloverSQLiteStore implements RolloverStore using SQLite.
+type RolloverSQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewRolloverSQLiteStore creates a new RolloverSQLiteStore.
+func NewRolloverSQLiteStore(db storage.SQLDB) *RolloverSQLiteStore {
+	return &RolloverSQLiteStore{db: db}
+}
+
+// SaveThresholds replaces a term's kids attendance thresholds in one transaction.
+// PRE: termID is non-empty; every value has been validated and belongs to termID
+// POST: The term has exactly the given thresholds
+func (s *RolloverSQLiteStore) SaveThresholds(ctx context.Context, termID string, values []domain.Threshold) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM term_threshold WHERE term_id = ?", termID); err != nil {
+		return err
+	}
+	for _, v := range values {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO term_threshold (term_id, belt, attendance_pct) VALUES (?, ?, ?)",
+			termID, v.Belt, v.AttendancePct,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ListThresholds returns a term's kids attendance thresholds ordered by belt.
+// PRE: termID is non-empty
+// POST: Returns the thresholds (empty when none are set)
+func (s *RolloverSQLiteStore) ListThresholds(ctx context.Context, termID string) ([]domain.Threshold, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT term_id, belt, attendance_pct FROM term_threshold WHERE term_id = ? ORDER BY belt", termID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []domain.Threshold
+	for rows.Next() {
+		var t domain.Threshold
+		if err := rows.Scan(&t.TermID, &t.Belt, &t.AttendancePct); err != nil {
+			return nil, err
+		}
+		results = append(results, t)
+	}
+	return results, rows.Err()
+}
+
+// SaveSnapshot stores a term's archived readiness, replacing any earlier snapshot.
+// PRE: value has been validated
+// POST: The snapshot is persisted
+func (s *RolloverSQLiteStore) SaveSnapshot(ctx context.Context, value domain.ReadinessSnapshot) error {
+	entries, err := json.Marshal(value.Entries)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO term_readiness_snapshot (term_id, term_name, entries, archived_by, archived_at) VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT(term_id) DO UPDATE SET term_name=excluded.term_name, entries=excluded.entries,
+		 archived_by=excluded.archived_by, archived_at=excluded.archived_at`,
+		value.TermID, value.TermName, string(entries), value.ArchivedBy, value.ArchivedAt.Format(time.RFC3339),
+	)
+	return err
+}
+
+// GetSnapshot returns a term's archived readiness.
+// PRE: termID is non-empty
+// POST: Returns the snapshot or an error wrapping sql.ErrNoRows when the term was never archived
+func (s *RolloverSQLiteStore) GetSnapshot(ctx context.Context, termID string) (domain.ReadinessSnapshot, error) {
+	row := s.db.QueryRowContext(ctx,
+		"SELECT term_id, term_name, entries, archived_by, archived_at FROM term_readiness_snapshot WHERE term_id = ?", termID)
+	var snap domain.ReadinessSnapshot
+	var entries, archivedAt string
+	err := row.Scan(&snap.TermID, &snap.TermName, &entries, &snap.ArchivedBy, &archivedAt)
+	if err == sql.ErrNoRows {
+		return domain.ReadinessSnapshot{}, fmt.Errorf("readiness snapshot not found: %w", err)
+	}
+	if err != nil {
+		return domain.ReadinessSnapshot{}, err
+	}
+	if err := json.Unmarshal([]byte(entries), &snap.Entries); err != nil {
+		return domain.ReadinessSnapshot{}, err
+	}
+	snap.ArchivedAt, _ = time.Parse(time.RFC3339, archivedAt)
+	return snap, nil
+}
@@ -13,3 +13,17 @@ type Store interface {
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context) ([]domain.Term, error)
 }
+
+// RolloverStore persists per-term kids thresholds and archived readiness snapshots.
+type RolloverStore interface {
+	SaveThresholds(ctx context.Context, termID string, values []domain.Threshold) error // replaces the term's thresholds
+	ListThresholds(ctx context.Context, termID string) ([]domain.Threshold, error)
+	SaveSnapshot(ctx context.Context, value domain.ReadinessSnapshot) error
+	GetSnapshot(ctx context.Context, termID string) (domain.ReadinessSnapshot, error)
+}
+
+// ReportStore records which kids' term reports have been emailed to their guardians.
+type ReportStore interface {
+	SaveDelivery(ctx context.Context, value domain.ReportDelivery) error // replaces any earlier delivery for the member and term
+	ListDeliveries(ctx context.Context, termID string) ([]domain.ReportDelivery, error)
+}
@@ -24,6 +24,8 @@ func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
 		start_date DATETIME NOT NULL,
 		end_date DATETIME NOT NULL,
 		created_by TEXT NOT NULL DEFAULT '',
+		min_belt TEXT NOT NULL DEFAULT '',
+		rotor_theme_id TEXT NOT NULL DEFAULT '',
 		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 	)`)
 	return &SQLiteStore{db: db}
@@ -35,8 +37,8 @@ func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
 func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Theme, error) {
 	var t domain.Theme
 	err := s.db.QueryRowContext(ctx,
-		`SELECT id, name, description, program, start_date, end_date, created_by, created_at FROM themes WHERE id = ?`, id,
-	).Scan(&t.ID, &t.Name, &t.Description, &t.Program, &t.StartDate, &t.EndDate, &t.CreatedBy, &t.CreatedAt)
+		`SELECT id, name, description, program, start_date, end_date, created_by, min_belt, rotor_theme_id, created_at FROM themes WHERE id = ?`, id,
+	).Scan(&t.ID, &t.Name, &t.Description, &t.Program, &t.StartDate, &t.EndDate, &t.CreatedBy, &t.MinBelt, &t.RotorThemeID, &t.CreatedAt)
 	return t, err
 }
 
@@ -45,11 +47,12 @@ func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Theme, err
 // POST: theme is persisted
 func (s *SQLiteStore) Save(ctx context.Context, value domain.Theme) error {
 	_, err := s.db.ExecContext(ctx,
-		`INSERT INTO themes (id, name, description, program, start_date, end_date, created_by, created_at)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`INSERT INTO themes (id, name, description, program, start_date, end_date, created_by, min_belt, rotor_theme_id, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		 ON CONFLICT(id) DO UPDATE SET name=excluded.name, description=excluded.description, program=excluded.program,
-		 start_date=excluded.start_date, end_date=excluded.end_date, created_by=excluded.created_by`,
-		value.ID, value.Name, value.Description, value.Program, value.StartDate, value.EndDate, value.CreatedBy, value.CreatedAt,
+		 start_date=excluded.start_date, end_date=excluded.end_date, created_by=excluded.created_by, min_belt=excluded.min_belt,
+		 rotor_theme_id=excluded.rotor_theme_id`,
+		value.ID, value.Name, value.Description, value.Program, value.StartDate, value.EndDate, value.CreatedBy, value.MinBelt, value.RotorThemeID, value.CreatedAt,
 	)
 	return err
 }
@@ -66,7 +69,7 @@ func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
 // PRE: none
 // POST: returns all themes or empty slice
 func (s *SQLiteStore) List(ctx context.Context) ([]domain.Theme, error) {
-	rows, err := s.db.QueryContext(ctx, `SELECT id, name, description, program, start_date, end_date, created_by, created_at FROM themes ORDER BY start_date DESC`)
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name, description, program, start_date, end_date, created_by, min_belt, rotor_theme_id, created_at FROM themes ORDER BY start_date DESC`)
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +77,7 @@ func (s *SQLiteStore) List(ctx context.Context) ([]domain.Theme, error) {
 	var list []domain.Theme
 	for rows.Next() {
 		var t domain.Theme
-		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.Program, &t.StartDate, &t.EndDate, &t.CreatedBy, &t.CreatedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.Program, &t.StartDate, &t.EndDate, &t.CreatedBy, &t.MinBelt, &t.RotorThemeID, &t.CreatedAt); err != nil {
 			return nil, err
 		}
 		list = append(list, t)
@@ -87,7 +90,7 @@ func (s *SQLiteStore) List(ctx context.Context) ([]domain.Theme, error) {
 // POST: returns matching themes or empty slice
 func (s *SQLiteStore) ListByProgram(ctx context.Context, program string) ([]domain.Theme, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT id, name, description, program, start_date, end_date, created_by, created_at FROM themes WHERE program = ? ORDER BY start_date DESC`, program)
+		`SELECT id, name, description, program, start_date, end_date, created_by, min_belt, rotor_theme_id, created_at FROM themes WHERE program = ? ORDER BY start_date DESC`, program)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +98,7 @@ func (s *SQLiteStore) ListByProgram(ctx context.Context, program string) ([]doma
 	var list []domain.Theme
 	for rows.Next() {
 		var t domain.Theme
-		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.Program, &t.StartDate, &t.EndDate, &t.CreatedBy, &t.CreatedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.Program, &t.StartDate, &t.EndDate, &t.CreatedBy, &t.MinBelt, &t.RotorThemeID, &t.CreatedAt); err != nil {
 			return nil, err
 		}
 		list = append(list, t)
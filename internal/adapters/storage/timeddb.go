@@ -4,13 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"log/slog"
-	"os"
-	"strconv"
-	"sync"
 	"sync/atomic"
 	"time"
 
 	"workshop/internal/adapters/http/perf"
+	"workshop/internal/application/trace"
 )
 
 // SQLDB is the database interface used by all stores.
@@ -28,20 +26,17 @@ var _ SQLDB = (*sql.DB)(nil)
 // DefaultSlowQueryMs is the default threshold for slow query warnings.
 const DefaultSlowQueryMs = 50
 
-var slowQueryMs int64
-var slowQueryOnce sync.Once
+// slowQueryMs is the slow-query threshold; NewTimedDB reads it when the wrapper is built.
+var slowQueryMs int64 = DefaultSlowQueryMs
+
+// SetSlowQueryThreshold changes the slow-query warning threshold.
+// Call before NewTimedDB.
+func SetSlowQueryThreshold(d time.Duration) {
+	atomic.StoreInt64(&slowQueryMs, d.Milliseconds())
+}
 
 // getSlowQueryThreshold returns the slow-query threshold in milliseconds.
 func getSlowQueryThreshold() float64 {
-	slowQueryOnce.Do(func() {
-		ms := DefaultSlowQueryMs
-		if v := os.Getenv("WORKSHOP_SLOW_QUERY_MS"); v != "" {
-			if n, err := strconv.Atoi(v); err == nil && n > 0 {
-				ms = n
-			}
-		}
-		atomic.StoreInt64(&slowQueryMs, int64(ms))
-	})
 	return float64(atomic.LoadInt64(&slowQueryMs))
 }
 
@@ -51,6 +46,7 @@ type TimedDB struct {
 	db        *sql.DB
 	collector *perf.Collector
 	threshold float64
+	plans     *planGuard // nil unless SetQueryPlanGuard turned the guard on
 }
 
 // Compile-time check that *TimedDB satisfies SQLDB.
@@ -64,6 +60,7 @@ func NewTimedDB(db *sql.DB, collector *perf.Collector) *TimedDB {
 		db:        db,
 		collector: collector,
 		threshold: getSlowQueryThreshold(),
+		plans:     newPlanGuard(db, collector),
 	}
 }
 
@@ -74,17 +71,21 @@ func (t *TimedDB) RawDB() *sql.DB {
 	return t.db
 }
 
-// logQuery logs and optionally records a query timing.
-func (t *TimedDB) logQuery(op string, start time.Time) {
+// logQuery logs and optionally records a query timing. Slow queries are also checked by the
+// query plan guard when it is on.
+func (t *TimedDB) logQuery(ctx context.Context, op, query string, args []any, start time.Time) {
 	durationMs := float64(time.Since(start).Microseconds()) / 1000.0
 
 	if durationMs >= t.threshold {
-		slog.Warn("slow_query",
+		slog.WarnContext(ctx, "slow_query",
 			"op", op,
 			"duration_ms", durationMs,
 		)
+		if t.plans != nil && query != "" {
+			t.plans.observe(query, args, durationMs, start)
+		}
 	} else {
-		slog.Debug("query",
+		slog.DebugContext(ctx, "query",
 			"op", op,
 			"duration_ms", durationMs,
 		)
@@ -94,6 +95,7 @@ func (t *TimedDB) logQuery(op string, start time.Time) {
 		t.collector.Record(perf.Entry{
 			Kind:       perf.KindQuery,
 			Path:       op,
+			RequestID:  trace.RequestID(ctx),
 			DurationMs: durationMs,
 			Timestamp:  start,
 		})
@@ -106,7 +108,7 @@ func (t *TimedDB) logQuery(op string, start time.Time) {
 func (t *TimedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
 	start := time.Now()
 	result, err := t.db.ExecContext(ctx, query, args...)
-	t.logQuery("ExecContext", start)
+	t.logQuery(ctx, "ExecContext", query, args, start)
 	return result, err
 }
 
@@ -116,7 +118,7 @@ func (t *TimedDB) ExecContext(ctx context.Context, query string, args ...any) (s
 func (t *TimedDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
 	start := time.Now()
 	rows, err := t.db.QueryContext(ctx, query, args...)
-	t.logQuery("QueryContext", start)
+	t.logQuery(ctx, "QueryContext", query, args, start)
 	return rows, err
 }
 
@@ -126,7 +128,7 @@ func (t *TimedDB) QueryContext(ctx context.Context, query string, args ...any) (
 func (t *TimedDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
 	start := time.Now()
 	row := t.db.QueryRowContext(ctx, query, args...)
-	t.logQuery("QueryRowContext", start)
+	t.logQuery(ctx, "QueryRowContext", query, args, start)
 	return row
 }
 
@@ -136,7 +138,7 @@ func (t *TimedDB) QueryRowContext(ctx context.Context, query string, args ...any
 func (t *TimedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
 	start := time.Now()
 	tx, err := t.db.BeginTx(ctx, opts)
-	t.logQuery("BeginTx", start)
+	t.logQuery(ctx, "BeginTx", "", nil, start)
 	return tx, err
 }
 
@@ -0,0 +1,150 @@
+package timesheet
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/timesheet"
+)
+
+// overrideColumns is the shared column list for override SELECTs; order matches scanOverride.
+const overrideColumns = "id, schedule_id, class_date, coach_id, set_by, set_at"
+
+// rateColumns is the shared column list for rate SELECTs; order matches scanRate.
+const rateColumns = "coach_id, hourly_rate, updated_by, updated_at"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// GetOverride retrieves the override for one occurrence.
+// PRE: scheduleID and classDate are non-empty
+// POST: Returns the override or an error if the occurrence has none
+func (s *SQLiteStore) GetOverride(ctx context.Context, scheduleID, classDate string) (domain.Override, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+overrideColumns+" FROM coach_session_override WHERE schedule_id = ? AND class_date = ?", scheduleID, classDate)
+	o, err := scanOverride(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Override{}, fmt.Errorf("coach override not found: %w", err)
+	}
+	return o, err
+}
+
+// SaveOverride persists an override, replacing any other override for the same occurrence.
+// PRE: value has been validated
+// POST: The occurrence's override is value
+func (s *SQLiteStore) SaveOverride(ctx context.Context, value domain.Override) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO coach_session_override (`+overrideColumns+`) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(schedule_id, class_date) DO UPDATE SET coach_id = excluded.coach_id, set_by = excluded.set_by, set_at = excluded.set_at`,
+		value.ID, value.ScheduleID, value.ClassDate, value.CoachID, value.SetBy, value.SetAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+// DeleteOverride removes the override for one occurrence, if any.
+// PRE: scheduleID and classDate are non-empty
+// POST: The occurrence falls back to its regular coach
+func (s *SQLiteStore) DeleteOverride(ctx context.Context, scheduleID, classDate string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM coach_session_override WHERE schedule_id = ? AND class_date = ?", scheduleID, classDate)
+	return err
+}
+
+// ListOverrides returns the overrides for classes between two dates, inclusive.
+// PRE: from and to are YYYY-MM-DD
+// POST: Returns overrides ordered by date, or an empty slice
+func (s *SQLiteStore) ListOverrides(ctx context.Context, from, to string) ([]domain.Override, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+overrideColumns+" FROM coach_session_override WHERE class_date BETWEEN ? AND ? ORDER BY class_date", from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Override
+	for rows.Next() {
+		o, err := scanOverride(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, o)
+	}
+	return list, rows.Err()
+}
+
+// GetRate retrieves a coach's hourly rate.
+// PRE: coachID is non-empty
+// POST: Returns the rate or an error if none is set
+func (s *SQLiteStore) GetRate(ctx context.Context, coachID string) (domain.Rate, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+rateColumns+" FROM coach_rate WHERE coach_id = ?", coachID)
+	r, err := scanRate(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Rate{}, fmt.Errorf("coach rate not found: %w", err)
+	}
+	return r, err
+}
+
+// SaveRate persists a coach's hourly rate (insert or update).
+// PRE: value has been validated
+// POST: The rate is persisted
+func (s *SQLiteStore) SaveRate(ctx context.Context, value domain.Rate) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO coach_rate (`+rateColumns+`) VALUES (?, ?, ?, ?)
+		ON CONFLICT(coach_id) DO UPDATE SET hourly_rate = excluded.hourly_rate, updated_by = excluded.updated_by, updated_at = excluded.updated_at`,
+		value.CoachID, value.HourlyRate, value.UpdatedBy, value.UpdatedAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+// ListRates returns every coach's hourly rate.
+// PRE: none
+// POST: Returns rates ordered by coach, or an empty slice
+func (s *SQLiteStore) ListRates(ctx context.Context) ([]domain.Rate, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+rateColumns+" FROM coach_rate ORDER BY coach_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Rate
+	for rows.Next() {
+		r, err := scanRate(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, r)
+	}
+	return list, rows.Err()
+}
+
+// scanOverride extracts an Override from a row scanner function.
+func scanOverride(scan func(dest ...interface{}) error) (domain.Override, error) {
+	var o domain.Override
+	var setAt string
+	if err := scan(&o.ID, &o.ScheduleID, &o.ClassDate, &o.CoachID, &o.SetBy, &setAt); err != nil {
+		return domain.Override{}, err
+	}
+	o.SetAt, _ = time.Parse(time.RFC3339, setAt)
+	return o, nil
+}
+
+// scanRate extracts a Rate from a row scanner function.
+func scanRate(scan func(dest ...interface{}) error) (domain.Rate, error) {
+	var r domain.Rate
+	var updatedAt string
+	if err := scan(&r.CoachID, &r.HourlyRate, &r.UpdatedBy, &updatedAt); err != nil {
+		return domain.Rate{}, err
+	}
+	r.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	return r, nil
+}
+
+// Ensure interface compliance at compile time.
+var _ Store = (*SQLiteStore)(nil)
@@ -0,0 +1,18 @@
+package timesheet
+
+import (
+	"context"
+
+	domain "workshop/internal/domain/timesheet"
+)
+
+// Store persists per-date coach overrides and coach hourly rates.
+type Store interface {
+	GetOverride(ctx context.Context, scheduleID, classDate string) (domain.Override, error)
+	SaveOverride(ctx context.Context, value domain.Override) error
+	DeleteOverride(ctx context.Context, scheduleID, classDate string) error
+	ListOverrides(ctx context.Context, from, to string) ([]domain.Override, error)
+	GetRate(ctx context.Context, coachID string) (domain.Rate, error)
+	SaveRate(ctx context.Context, value domain.Rate) error
+	ListRates(ctx context.Context) ([]domain.Rate, error)
+}
@@ -0,0 +1,209 @@
+package visitor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"workshop/internal/adapters/storage"
+	domain "workshop/internal/domain/visitor"
+)
+
+// visitorColumns is the shared column list for visitor SELECTs; order matches scanVisitor.
+const visitorColumns = "id, member_id, name, email, home_gym, drop_in_fee, status, first_visit, last_visit, visit_count, follow_up_email_id, converted_at, created_at"
+
+// visitColumns is the shared column list for visit SELECTs; order matches scanVisit.
+const visitColumns = "id, visitor_id, attendance_id, visited_at, fee, paid"
+
+// SQLiteStore implements Store using SQLite.
+type SQLiteStore struct {
+	db storage.SQLDB
+}
+
+// NewSQLiteStore creates a new SQLiteStore.
+// PRE: db is a valid database connection
+// POST: returns a new SQLiteStore instance
+func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
+	return &SQLiteStore{db: db}
+}
+
+// GetVisitor retrieves a visitor by ID.
+// PRE: id is non-empty
+// POST: Returns the visitor or an error if not found
+func (s *SQLiteStore) GetVisitor(ctx context.Context, id string) (domain.Visitor, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+visitorColumns+" FROM visitor WHERE id = ?", id)
+	v, err := scanVisitor(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Visitor{}, fmt.Errorf("visitor not found: %w", err)
+	}
+	return v, err
+}
+
+// GetVisitorByEmail retrieves the visitor who checked in with an email, ignoring case.
+// PRE: email is non-empty
+// POST: Returns the visitor or an error if none has used the email
+func (s *SQLiteStore) GetVisitorByEmail(ctx context.Context, email string) (domain.Visitor, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+visitorColumns+" FROM visitor WHERE email = ?", strings.ToLower(strings.TrimSpace(email)))
+	v, err := scanVisitor(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Visitor{}, fmt.Errorf("visitor not found: %w", err)
+	}
+	return v, err
+}
+
+// SaveVisitor persists a visitor (insert or update). Emails are stored lower-case so a
+// returning guest is found however they type it.
+// PRE: value has been validated
+// POST: The visitor is persisted
+func (s *SQLiteStore) SaveVisitor(ctx context.Context, value domain.Visitor) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO visitor (`+visitorColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET member_id = excluded.member_id, name = excluded.name, email = excluded.email,
+			home_gym = excluded.home_gym, drop_in_fee = excluded.drop_in_fee, status = excluded.status,
+			first_visit = excluded.first_visit, last_visit = excluded.last_visit, visit_count = excluded.visit_count,
+			follow_up_email_id = excluded.follow_up_email_id, converted_at = excluded.converted_at`,
+		value.ID, value.MemberID, value.Name, strings.ToLower(strings.TrimSpace(value.Email)), value.HomeGym, value.DropInFee, value.Status,
+		nullTime(value.FirstVisit), nullTime(value.LastVisit), value.VisitCount, value.FollowUpEmailID, nullTime(value.ConvertedAt),
+		value.CreatedAt.Format(time.RFC3339))
+	return err
+}
+
+// ListVisitors returns visitors with the given status, or all when status is empty,
+// most recent visit first.
+// PRE: none
+// POST: Returns visitors or an empty slice
+func (s *SQLiteStore) ListVisitors(ctx context.Context, status string) ([]domain.Visitor, error) {
+	query := "SELECT " + visitorColumns + " FROM visitor"
+	var args []any
+	if status != "" {
+		query += " WHERE status = ?"
+		args = append(args, status)
+	}
+	rows, err := s.db.QueryContext(ctx, query+" ORDER BY last_visit DESC, name", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Visitor
+	for rows.Next() {
+		v, err := scanVisitor(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, rows.Err()
+}
+
+// GetVisit retrieves a visit by ID.
+// PRE: id is non-empty
+// POST: Returns the visit or an error if not found
+func (s *SQLiteStore) GetVisit(ctx context.Context, id string) (domain.Visit, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+visitColumns+" FROM visit WHERE id = ?", id)
+	v, err := scanVisit(row.Scan)
+	if err == sql.ErrNoRows {
+		return domain.Visit{}, fmt.Errorf("visit not found: %w", err)
+	}
+	return v, err
+}
+
+// SaveVisit persists a visit (insert or update). Only whether it is paid can change.
+// PRE: value has been validated
+// POST: The visit is persisted
+func (s *SQLiteStore) SaveVisit(ctx context.Context, value domain.Visit) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO visit (`+visitColumns+`) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET paid = excluded.paid`,
+		value.ID, value.VisitorID, value.AttendanceID, value.VisitedAt.Format(time.RFC3339), value.Fee, boolInt(value.Paid))
+	return err
+}
+
+// ListVisitsByVisitor returns a visitor's visits, most recent first.
+// PRE: visitorID is non-empty
+// POST: Returns visits or an empty slice
+func (s *SQLiteStore) ListVisitsByVisitor(ctx context.Context, visitorID string) ([]domain.Visit, error) {
+	return s.listVisits(ctx, "SELECT "+visitColumns+" FROM visit WHERE visitor_id = ? ORDER BY visited_at DESC", visitorID)
+}
+
+// ListVisitsSince returns every visit at or after since, oldest first.
+// PRE: none
+// POST: Returns visits or an empty slice
+func (s *SQLiteStore) ListVisitsSince(ctx context.Context, since time.Time) ([]domain.Visit, error) {
+	return s.listVisits(ctx, "SELECT "+visitColumns+" FROM visit WHERE visited_at >= ? ORDER BY visited_at", since.UTC().Format(time.RFC3339))
+}
+
+// listVisits runs a visit query.
+func (s *SQLiteStore) listVisits(ctx context.Context, query string, args ...any) ([]domain.Visit, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []domain.Visit
+	for rows.Next() {
+		v, err := scanVisit(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, rows.Err()
+}
+
+// scanVisitor extracts a Visitor from a row scanner function.
+func scanVisitor(scan func(dest ...interface{}) error) (domain.Visitor, error) {
+	var v domain.Visitor
+	var firstVisit, lastVisit, convertedAt sql.NullString
+	var createdAt string
+	if err := scan(&v.ID, &v.MemberID, &v.Name, &v.Email, &v.HomeGym, &v.DropInFee, &v.Status,
+		&firstVisit, &lastVisit, &v.VisitCount, &v.FollowUpEmailID, &convertedAt, &createdAt); err != nil {
+		return domain.Visitor{}, err
+	}
+	v.FirstVisit = parseNullTime(firstVisit)
+	v.LastVisit = parseNullTime(lastVisit)
+	v.ConvertedAt = parseNullTime(convertedAt)
+	v.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return v, nil
+}
+
+// scanVisit extracts a Visit from a row scanner function.
+func scanVisit(scan func(dest ...interface{}) error) (domain.Visit, error) {
+	var v domain.Visit
+	var visitedAt string
+	var paid int
+	if err := scan(&v.ID, &v.VisitorID, &v.AttendanceID, &visitedAt, &v.Fee, &paid); err != nil {
+		return domain.Visit{}, err
+	}
+	v.VisitedAt, _ = time.Parse(time.RFC3339, visitedAt)
+	v.Paid = paid == 1
+	return v, nil
+}
+
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseNullTime(s sql.NullString) time.Time {
+	if !s.Valid {
+		return time.Time{}
+	}
+	t, _ := time.Parse(time.RFC3339, s.String)
+	return t
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Ensure interface compliance at compile time.
+var _ Store = (*SQLiteStore)(nil)
@@ -0,0 +1,20 @@
+package visitor
+
+import (
+	"context"
+	"time"
+
+	domain "workshop/internal/domain/visitor"
+)
+
+// Store persists Visitor and Visit state.
+type Store interface {
+	GetVisitor(ctx context.Context, id string) (domain.Visitor, error)
+	GetVisitorByEmail(ctx context.Context, email string) (domain.Visitor, error)
+	SaveVisitor(ctx context.Context, value domain.Visitor) error
+	ListVisitors(ctx context.Context, status string) ([]domain.Visitor, error)
+	GetVisit(ctx context.Context, id string) (domain.Visit, error)
+	SaveVisit(ctx context.Context, value domain.Visit) error
+	ListVisitsByVisitor(ctx context.Context, visitorID string) ([]domain.Visit, error)
+	ListVisitsSince(ctx context.Context, since time.Time) ([]domain.Visit, error)
+}
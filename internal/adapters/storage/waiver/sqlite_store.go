@@ -21,29 +21,46 @@ func NewSQLiteStore(db storage.SQLDB) *SQLiteStore {
 	return &SQLiteStore{db: db}
 }
 
-// GetByID retrieves a Waiver by its ID.
-// PRE: id is non-empty
-// POST: Returns the entity or an error if not found
-func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Waiver, error) {
-	query := "SELECT id, accepted_terms, ip_address, member_id, signed_at FROM waiver WHERE id = ?"
+// waiverColumns lists the waiver columns in the order scanWaiver reads them.
+const waiverColumns = "id, accepted_terms, ip_address, member_id, signed_at, signer_name, signature_image, guardian_name, guardian_relationship, guardian_email, guardian_phone, document, document_hash"
 
-	row := s.db.QueryRowContext(ctx, query, id)
+type rowScanner interface {
+	Scan(dest ...any) error
+}
 
+func scanWaiver(row rowScanner) (domain.Waiver, error) {
 	var entity domain.Waiver
 	var signedAtStr string
-	err := row.Scan(
+	if err := row.Scan(
 		&entity.ID,
 		&entity.AcceptedTerms,
 		&entity.IPAddress,
 		&entity.MemberID,
 		&signedAtStr,
-	)
-	if err == nil {
-		entity.SignedAt, err = parseStoredTime(signedAtStr)
-		if err != nil {
-			return domain.Waiver{}, fmt.Errorf("failed to parse signed_at: %w", err)
-		}
+		&entity.SignerName,
+		&entity.SignatureImage,
+		&entity.Guardian.Name,
+		&entity.Guardian.Relationship,
+		&entity.Guardian.Email,
+		&entity.Guardian.Phone,
+		&entity.Document,
+		&entity.DocumentHash,
+	); err != nil {
+		return domain.Waiver{}, err
+	}
+	signedAt, err := parseStoredTime(signedAtStr)
+	if err != nil {
+		return domain.Waiver{}, fmt.Errorf("failed to parse signed_at: %w", err)
 	}
+	entity.SignedAt = signedAt
+	return entity, nil
+}
+
+// GetByID retrieves a Waiver by its ID.
+// PRE: id is non-empty
+// POST: Returns the entity or an error if not found
+func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Waiver, error) {
+	entity, err := scanWaiver(s.db.QueryRowContext(ctx, "SELECT "+waiverColumns+" FROM waiver WHERE id = ?", id))
 	if err == sql.ErrNoRows {
 		return domain.Waiver{}, fmt.Errorf("waiver not found: %w", err)
 	}
@@ -54,30 +71,18 @@ func (s *SQLiteStore) GetByID(ctx context.Context, id string) (domain.Waiver, er
 // PRE: memberID is non-empty
 // POST: Returns the waiver or an error if not found
 func (s *SQLiteStore) GetByMemberID(ctx context.Context, memberID string) (domain.Waiver, error) {
-	query := "SELECT id, accepted_terms, ip_address, member_id, signed_at FROM waiver WHERE member_id = ? ORDER BY signed_at DESC LIMIT 1"
-
-	row := s.db.QueryRowContext(ctx, query, memberID)
-
-	var entity domain.Waiver
-	var signedAtStr string
-	err := row.Scan(
-		&entity.ID,
-		&entity.AcceptedTerms,
-		&entity.IPAddress,
-		&entity.MemberID,
-		&signedAtStr,
-	)
+	entity, err := scanWaiver(s.db.QueryRowContext(ctx, "SELECT "+waiverColumns+" FROM waiver WHERE member_id = ? ORDER BY signed_at DESC LIMIT 1", memberID))
 	if err == sql.ErrNoRows {
 		return domain.Waiver{}, fmt.Errorf("waiver not found: %w", err)
 	}
-	if err != nil {
-		return domain.Waiver{}, err
-	}
-	entity.SignedAt, err = parseStoredTime(signedAtStr)
-	if err != nil {
-		return domain.Waiver{}, fmt.Errorf("failed to parse signed_at: %w", err)
-	}
-	return entity, nil
+	return entity, err
+}
+
+// ListByMemberID retrieves every Waiver a member has signed, newest first.
+// PRE: memberID is non-empty
+// POST: Returns the member's waivers, or an empty slice when there are none
+func (s *SQLiteStore) ListByMemberID(ctx context.Context, memberID string) ([]domain.Waiver, error) {
+	return s.query(ctx, "SELECT "+waiverColumns+" FROM waiver WHERE member_id = ? ORDER BY signed_at DESC", memberID)
 }
 
 // Save persists a Waiver to the database.
@@ -91,9 +96,13 @@ func (s *SQLiteStore) Save(ctx context.Context, entity domain.Waiver) error {
 	defer tx.Rollback()
 
 	// Upsert implementation
-	fields := []string{"id", "accepted_terms", "ip_address", "member_id", "signed_at"}
-	placeholders := []string{"?", "?", "?", "?", "?"}
-	updates := []string{"id=excluded.id", "accepted_terms=excluded.accepted_terms", "ip_address=excluded.ip_address", "member_id=excluded.member_id", "signed_at=excluded.signed_at"}
+	fields := strings.Split(waiverColumns, ", ")
+	placeholders := make([]string, len(fields))
+	updates := make([]string, len(fields))
+	for i, f := range fields {
+		placeholders[i] = "?"
+		updates[i] = f + "=excluded." + f
+	}
 
 	query := fmt.Sprintf(
 		"INSERT INTO waiver (%s) VALUES (%s) ON CONFLICT(id) DO UPDATE SET %s",
@@ -108,6 +117,14 @@ func (s *SQLiteStore) Save(ctx context.Context, entity domain.Waiver) error {
 		entity.IPAddress,
 		entity.MemberID,
 		entity.SignedAt.Format(time.RFC3339Nano),
+		entity.SignerName,
+		entity.SignatureImage,
+		entity.Guardian.Name,
+		entity.Guardian.Relationship,
+		entity.Guardian.Email,
+		entity.Guardian.Phone,
+		entity.Document,
+		entity.DocumentHash,
 	)
 	if err != nil {
 		return err
@@ -128,34 +145,25 @@ func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
 // PRE: filter has valid parameters
 // POST: Returns matching entities
 func (s *SQLiteStore) List(ctx context.Context, filter ListFilter) ([]domain.Waiver, error) {
-	query := "SELECT id, accepted_terms, ip_address, member_id, signed_at FROM waiver LIMIT ? OFFSET ?"
+	return s.query(ctx, "SELECT "+waiverColumns+" FROM waiver LIMIT ? OFFSET ?", filter.Limit, filter.Offset)
+}
 
-	rows, err := s.db.QueryContext(ctx, query, filter.Limit, filter.Offset)
+func (s *SQLiteStore) query(ctx context.Context, query string, args ...any) ([]domain.Waiver, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var results []domain.Waiver
+	results := []domain.Waiver{}
 	for rows.Next() {
-		var entity domain.Waiver
-		var signedAtStr string
-		if err := rows.Scan(
-			&entity.ID,
-			&entity.AcceptedTerms,
-			&entity.IPAddress,
-			&entity.MemberID,
-			&signedAtStr,
-		); err != nil {
-			return nil, err
-		}
-		entity.SignedAt, err = parseStoredTime(signedAtStr)
+		entity, err := scanWaiver(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse signed_at: %w", err)
+			return nil, err
 		}
 		results = append(results, entity)
 	}
-	return results, nil
+	return results, rows.Err()
 }
 
 func parseStoredTime(value string) (time.Time, error) {
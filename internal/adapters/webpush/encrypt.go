@@ -0,0 +1,117 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// recordSize is the aes128gcm record size; a push message is sent as a single record.
+const recordSize = 4096
+
+// MaxPayloadSize is the largest payload that fits in one record: 4096 bytes less the
+// 86-byte header, the 16-byte GCM tag and the 1-byte padding delimiter.
+const MaxPayloadSize = recordSize - 86 - 16 - 1
+
+// ErrInvalidSubscriptionKeys means the browser's p256dh or auth key could not be used.
+var ErrInvalidSubscriptionKeys = errors.New("push subscription keys are invalid")
+
+// encrypt encrypts payload for the browser that owns the given keys using the
+// aes128gcm content encoding (RFC 8188) with Web Push key derivation (RFC 8291).
+// PRE: len(payload) <= MaxPayloadSize
+// POST: Returns the request body: salt, record size, sender public key, ciphertext
+func encrypt(payload []byte, p256dh, auth string) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, ErrPayloadTooLarge
+	}
+	uaBytes, err := decodeKey(p256dh)
+	if err != nil {
+		return nil, ErrInvalidSubscriptionKeys
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaBytes)
+	if err != nil {
+		return nil, ErrInvalidSubscriptionKeys
+	}
+	authSecret, err := decodeKey(auth)
+	if err != nil || len(authSecret) != 16 {
+		return nil, ErrInvalidSubscriptionKeys
+	}
+
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+	cek, nonce, err := deriveKeys(asPrivate, uaPublic, authSecret, salt, asPublic, uaBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := append(append([]byte{}, payload...), 0x02) // 0x02 marks the last record
+
+	header := make([]byte, 0, 21+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// deriveKeys runs the RFC 8291 key schedule: the ECDH secret is mixed with the auth
+// secret and both public keys, then the result is expanded into the content
+// encryption key and nonce for this message's salt.
+func deriveKeys(private *ecdh.PrivateKey, peer *ecdh.PublicKey, authSecret, salt, asPublic, uaPublic []byte) (cek, nonce []byte, err error) {
+	shared, err := private.ECDH(peer)
+	if err != nil {
+		return nil, nil, err
+	}
+	prkKey, err := hkdf.Extract(sha256.New, shared, authSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyInfo := "WebPush: info\x00" + string(uaPublic) + string(asPublic)
+	ikm, err := hkdf.Expand(sha256.New, prkKey, keyInfo, 32)
+	if err != nil {
+		return nil, nil, err
+	}
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cek, err = hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16); err != nil {
+		return nil, nil, err
+	}
+	if nonce, err = hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12); err != nil {
+		return nil, nil, err
+	}
+	return cek, nonce, nil
+}
+
+// decodeKey decodes a base64url key, with or without padding, as browsers send either.
+func decodeKey(s string) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, fmt.Errorf("decode key: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,45 @@
+// Package webpush delivers notifications to browsers through the Web Push protocol:
+// payloads are encrypted for the browser (RFC 8291) and requests are signed with the
+// server's VAPID key (RFC 8292), so no push-service account is needed.
+package webpush
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Urgency values understood by push services (RFC 8030 §5.3).
+const (
+	UrgencyNormal = "normal"
+	UrgencyHigh   = "high"
+)
+
+// DefaultTTL is how long a push service holds a message for an offline browser.
+const DefaultTTL = 24 * time.Hour
+
+// ErrSubscriptionGone means the browser unsubscribed or the subscription expired;
+// the caller should forget it.
+var ErrSubscriptionGone = errors.New("push subscription is no longer valid")
+
+// ErrPayloadTooLarge means the payload does not fit in a single 4 KB push message.
+var ErrPayloadTooLarge = errors.New("push payload exceeds 3993 bytes")
+
+// Subscription is where and how to reach one browser, as returned by PushManager.subscribe.
+type Subscription struct {
+	Endpoint string // push service URL
+	P256dh   string // browser's P-256 public key, base64url
+	Auth     string // browser's 16-byte auth secret, base64url
+}
+
+// Message is one push to deliver.
+type Message struct {
+	Payload []byte        // delivered to the service worker's push event; at most MaxPayloadSize bytes
+	TTL     time.Duration // zero uses DefaultTTL
+	Urgency string        // empty uses UrgencyNormal
+}
+
+// Sender is the interface for delivering Web Push messages.
+type Sender interface {
+	Send(ctx context.Context, sub Subscription, msg Message) error
+}
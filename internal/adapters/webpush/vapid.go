@@ -0,0 +1,141 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tokenLifetime is how long a VAPID token is valid; push services reject more than 24 hours.
+const tokenLifetime = 12 * time.Hour
+
+// VAPIDSender sends Web Push messages signed with the server's VAPID key.
+type VAPIDSender struct {
+	key     *ecdsa.PrivateKey
+	public  string // uncompressed public key, base64url; browsers subscribe with it
+	subject string
+	client  *http.Client
+	now     func() time.Time
+}
+
+// NewVAPIDSender creates a sender from a 32-byte P-256 private key.
+// subject identifies the operator to push services: a mailto: or https: URL.
+// PRE: privateKey is 32 bytes; subject starts with mailto: or https:
+// POST: Returns a ready-to-use sender, or an error if the key is not a valid P-256 scalar
+func NewVAPIDSender(privateKey []byte, subject string) (*VAPIDSender, error) {
+	key, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("vapid key: %w", err)
+	}
+	if !strings.HasPrefix(subject, "mailto:") && !strings.HasPrefix(subject, "https:") {
+		return nil, fmt.Errorf("vapid subject must be a mailto: or https: URL, got %q", subject)
+	}
+	public, err := key.PublicKey.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("vapid public key: %w", err)
+	}
+	return &VAPIDSender{
+		key:     key,
+		public:  base64.RawURLEncoding.EncodeToString(public),
+		subject: subject,
+		client:  &http.Client{Timeout: 15 * time.Second},
+		now:     time.Now,
+	}, nil
+}
+
+// PublicKey returns the application server key browsers pass to PushManager.subscribe.
+// PRE: none
+// POST: Returns the uncompressed P-256 public key, base64url without padding
+func (s *VAPIDSender) PublicKey() string {
+	return s.public
+}
+
+// Send encrypts msg for the subscribed browser and posts it to its push service.
+// PRE: sub came from a browser's PushManager.subscribe
+// POST: The push service accepted the message, or ErrSubscriptionGone if the
+// subscription has expired, or another error
+func (s *VAPIDSender) Send(ctx context.Context, sub Subscription, msg Message) error {
+	endpoint, err := url.Parse(sub.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return fmt.Errorf("push endpoint %q is not a URL", sub.Endpoint)
+	}
+	body, err := encrypt(msg.Payload, sub.P256dh, sub.Auth)
+	if err != nil {
+		return err
+	}
+	token, err := s.token(endpoint.Scheme + "://" + endpoint.Host)
+	if err != nil {
+		return err
+	}
+
+	ttl := msg.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	urgency := msg.Urgency
+	if urgency == "" {
+		urgency = UrgencyNormal
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "vapid t="+token+", k="+s.public)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(ttl.Seconds())))
+	req.Header.Set("Urgency", urgency)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("push send failed: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrSubscriptionGone
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		slog.ErrorContext(ctx, "webpush_send_failed", "host", endpoint.Host, "status", resp.StatusCode, "detail", string(detail))
+		return fmt.Errorf("push service %s returned %d", endpoint.Host, resp.StatusCode)
+	}
+	return nil
+}
+
+// token builds the ES256-signed VAPID JWT for one push service origin.
+func (s *VAPIDSender) token(audience string) (string, error) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`))
+	claims, err := json.Marshal(map[string]any{
+		"aud": audience,
+		"exp": s.now().Add(tokenLifetime).Unix(),
+		"sub": s.subject,
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, sig, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return "", err
+	}
+	raw := make([]byte, 64) // JWS wants r and s as fixed-width big-endian integers
+	r.FillBytes(raw[:32])
+	sig.FillBytes(raw[32:])
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+var _ Sender = (*VAPIDSender)(nil)
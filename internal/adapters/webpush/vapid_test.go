@@ -0,0 +1,174 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testBrowser holds the keys a browser generates when it subscribes.
+type testBrowser struct {
+	private *ecdh.PrivateKey
+	auth    []byte
+}
+
+func newTestBrowser(t *testing.T) testBrowser {
+	t.Helper()
+	private, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("browser key: %v", err)
+	}
+	auth := make([]byte, 16)
+	rand.Read(auth)
+	return testBrowser{private: private, auth: auth}
+}
+
+// subscription returns the browser's subscription for endpoint.
+func (b testBrowser) subscription(endpoint string) Subscription {
+	return Subscription{
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(b.private.PublicKey().Bytes()),
+		Auth:     base64.URLEncoding.EncodeToString(b.auth), // padded, as some browsers send it
+	}
+}
+
+// decrypt reverses encrypt the way the browser's push service client does.
+func (b testBrowser) decrypt(t *testing.T, body []byte) []byte {
+	t.Helper()
+	salt, rs, idLen := body[:16], binary.BigEndian.Uint32(body[16:20]), int(body[20])
+	if rs != recordSize || idLen != 65 {
+		t.Fatalf("header: rs=%d idlen=%d", rs, idLen)
+	}
+	asPublicBytes := body[21 : 21+idLen]
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	if err != nil {
+		t.Fatalf("sender key: %v", err)
+	}
+	cek, nonce, err := deriveKeys(b.private, asPublic, b.auth, salt, asPublicBytes, b.private.PublicKey().Bytes())
+	if err != nil {
+		t.Fatalf("derive: %v", err)
+	}
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+	plain, err := gcm.Open(nil, nonce, body[21+idLen:], nil)
+	if err != nil {
+		t.Fatalf("decrypt: %v", err)
+	}
+	if plain[len(plain)-1] != 0x02 {
+		t.Fatalf("missing last-record delimiter")
+	}
+	return plain[:len(plain)-1]
+}
+
+// verifyVAPID checks the Authorization header's JWT against the advertised public key.
+func verifyVAPID(t *testing.T, header, audience string) {
+	t.Helper()
+	token, key, ok := strings.Cut(strings.TrimPrefix(header, "vapid t="), ", k=")
+	if !ok {
+		t.Fatalf("authorization header %q", header)
+	}
+	keyBytes, _ := base64.RawURLEncoding.DecodeString(key)
+	public, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), keyBytes)
+	if err != nil {
+		t.Fatalf("public key: %v", err)
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token %q", token)
+	}
+	sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if len(sig) != 64 || !ecdsa.Verify(public, digest[:], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])) {
+		t.Fatal("VAPID signature does not verify")
+	}
+	var claims struct {
+		Aud string `json:"aud"`
+		Sub string `json:"sub"`
+	}
+	raw, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	json.Unmarshal(raw, &claims)
+	if claims.Aud != audience || claims.Sub != "mailto:info@example.com" {
+		t.Errorf("claims = %+v, want aud %s", claims, audience)
+	}
+}
+
+// TestVAPIDSender_Send verifies the push service receives a signed request whose body
+// only the subscribed browser can decrypt, and that expired subscriptions are reported.
+func TestVAPIDSender_Send(t *testing.T) {
+	key := make([]byte, 32)
+	key[31] = 7
+	sender, err := NewVAPIDSender(key, "mailto:info@example.com")
+	if err != nil {
+		t.Fatalf("NewVAPIDSender: %v", err)
+	}
+	browser := newTestBrowser(t)
+
+	var got *http.Request
+	var body []byte
+	status := http.StatusCreated
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	payload := []byte(`{"Title":"Fundamentals Gi starts at 18:00"}`)
+	if err := sender.Send(context.Background(), browser.subscription(srv.URL+"/push/abc"), Message{Payload: payload}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got.Header.Get("Content-Encoding") != "aes128gcm" || got.Header.Get("TTL") != "86400" || got.Header.Get("Urgency") != UrgencyNormal {
+		t.Errorf("headers = %v", got.Header)
+	}
+	verifyVAPID(t, got.Header.Get("Authorization"), srv.URL)
+	if plain := browser.decrypt(t, body); !bytes.Equal(plain, payload) {
+		t.Errorf("decrypted %q, want %q", plain, payload)
+	}
+
+	status = http.StatusGone
+	if err := sender.Send(context.Background(), browser.subscription(srv.URL+"/push/abc"), Message{Payload: payload}); !errors.Is(err, ErrSubscriptionGone) {
+		t.Errorf("410: err = %v, want ErrSubscriptionGone", err)
+	}
+	status = http.StatusInternalServerError
+	if err := sender.Send(context.Background(), browser.subscription(srv.URL+"/push/abc"), Message{Payload: payload}); err == nil || errors.Is(err, ErrSubscriptionGone) {
+		t.Errorf("500: err = %v, want a delivery error", err)
+	}
+
+	oversized := Message{Payload: bytes.Repeat([]byte("x"), MaxPayloadSize+1)}
+	if err := sender.Send(context.Background(), browser.subscription(srv.URL), oversized); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Errorf("oversized payload: err = %v, want ErrPayloadTooLarge", err)
+	}
+	bad := browser.subscription(srv.URL)
+	bad.Auth = "c2hvcnQ"
+	if err := sender.Send(context.Background(), bad, Message{Payload: payload}); !errors.Is(err, ErrInvalidSubscriptionKeys) {
+		t.Errorf("short auth secret: err = %v, want ErrInvalidSubscriptionKeys", err)
+	}
+}
+
+// TestNewVAPIDSender_RejectsBadConfig verifies invalid keys and subjects are refused.
+func TestNewVAPIDSender_RejectsBadConfig(t *testing.T) {
+	if _, err := NewVAPIDSender(make([]byte, 32), "mailto:info@example.com"); err == nil {
+		t.Error("zero key: expected an error")
+	}
+	key := make([]byte, 32)
+	key[31] = 1
+	if _, err := NewVAPIDSender(key, "info@example.com"); err == nil {
+		t.Error("bare address subject: expected an error")
+	}
+}
@@ -45,7 +45,7 @@ func ExecuteArchiveMember(ctx context.Context, input ArchiveMemberInput, deps Ar
 		return err
 	}
 
-	slog.Info("member_event", "event", "member_archived", "member_id", input.MemberID)
+	slog.InfoContext(ctx, "member_event", "event", "member_archived", "member_id", input.MemberID)
 	return nil
 }
 
@@ -80,6 +80,6 @@ func ExecuteRestoreMember(ctx context.Context, input RestoreMemberInput, deps Re
 		return err
 	}
 
-	slog.Info("member_event", "event", "member_restored", "member_id", input.MemberID)
+	slog.InfoContext(ctx, "member_event", "event", "member_restored", "member_id", input.MemberID)
 	return nil
 }
@@ -0,0 +1,268 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/audit"
+	"workshop/internal/domain/holiday"
+	"workshop/internal/domain/term"
+)
+
+// Backfill limits.
+const (
+	// MaxBackfillEntries caps members × dates in one request.
+	MaxBackfillEntries = 500
+	// MaxBackfillAge is how far back a coach may add attendance.
+	MaxBackfillAge = 90 * 24 * time.Hour
+)
+
+// Backfill errors returned before any entry is processed.
+var (
+	ErrBackfillTooLarge       = errors.New("too many entries: members × dates must not exceed 500")
+	ErrBackfillEmpty          = errors.New("select at least one member and one date")
+	ErrBackfillScheduleNeeded = errors.New("a class must be selected")
+	ErrBackfillScheduleAbsent = errors.New("class not found")
+)
+
+// BackfillAttendanceStore defines the attendance store interface needed for backfill.
+type BackfillAttendanceStore interface {
+	Save(ctx context.Context, a attendance.Attendance) error
+	ListByMemberIDAndDate(ctx context.Context, memberID string, date string) ([]attendance.Attendance, error)
+}
+
+// BackfillTermStore defines the term store interface needed for backfill.
+type BackfillTermStore interface {
+	List(ctx context.Context) ([]term.Term, error)
+}
+
+// BackfillHolidayStore defines the holiday store interface needed for backfill.
+type BackfillHolidayStore interface {
+	List(ctx context.Context) ([]holiday.Holiday, error)
+}
+
+// BackfillAuditStore defines the audit store interface needed for backfill.
+type BackfillAuditStore interface {
+	Save(ctx context.Context, event audit.Event) error
+}
+
+// BackfillActor identifies the coach or admin adding the attendance.
+type BackfillActor struct {
+	AccountID string
+	Email     string
+	Role      string
+	IPAddress string
+	UserAgent string
+}
+
+// BackfillAttendanceInput adds every member to the class on every date.
+type BackfillAttendanceInput struct {
+	ScheduleID string
+	MemberIDs  []string
+	Dates      []string // YYYY-MM-DD
+	Actor      BackfillActor
+}
+
+// BackfillEntryResult reports the outcome for one member on one date.
+type BackfillEntryResult struct {
+	MemberID     string
+	ClassDate    string
+	Status       string // created, duplicate, rejected (the bulk sync statuses)
+	AttendanceID string // new or existing attendance ID (empty when rejected)
+	Reason       string
+}
+
+// BackfillAttendanceResult carries per-entry outcomes, members within dates.
+type BackfillAttendanceResult struct {
+	Results   []BackfillEntryResult
+	Created   int
+	Duplicate int
+	Rejected  int
+}
+
+// BackfillAttendanceDeps holds dependencies for BackfillAttendance.
+type BackfillAttendanceDeps struct {
+	MemberStore     CheckInSearchStore
+	AttendanceStore BackfillAttendanceStore
+	ScheduleStore   ScheduleLookupStore
+	TermStore       BackfillTermStore
+	HolidayStore    BackfillHolidayStore
+	AuditStore      BackfillAuditStore
+	GenerateID      func() string
+	Now             func() time.Time
+}
+
+// ExecuteBackfillAttendance records attendance for past classes the kiosk missed.
+// Each date must fall on the class's weekday, inside a term, outside any holiday,
+// no later than today and no more than MaxBackfillAge ago. Every created record
+// gets an audit event naming the coach who added it.
+// PRE: input.Actor.AccountID is a coach or admin
+// POST: Each member/date pair is created, reported as a duplicate, or rejected with a reason
+// INVARIANT: A rejected date or member never aborts the rest of the request
+func ExecuteBackfillAttendance(ctx context.Context, input BackfillAttendanceInput, deps BackfillAttendanceDeps) (BackfillAttendanceResult, error) {
+	if input.ScheduleID == "" {
+		return BackfillAttendanceResult{}, ErrBackfillScheduleNeeded
+	}
+	if len(input.MemberIDs) == 0 || len(input.Dates) == 0 {
+		return BackfillAttendanceResult{}, ErrBackfillEmpty
+	}
+	if len(input.MemberIDs)*len(input.Dates) > MaxBackfillEntries {
+		return BackfillAttendanceResult{}, ErrBackfillTooLarge
+	}
+
+	sched, err := deps.ScheduleStore.GetByID(ctx, input.ScheduleID)
+	if err != nil {
+		return BackfillAttendanceResult{}, ErrBackfillScheduleAbsent
+	}
+	terms, err := deps.TermStore.List(ctx)
+	if err != nil {
+		return BackfillAttendanceResult{}, err
+	}
+	holidays, err := deps.HolidayStore.List(ctx)
+	if err != nil {
+		return BackfillAttendanceResult{}, err
+	}
+	start, err := time.Parse("15:04", sched.StartTime)
+	if err != nil {
+		return BackfillAttendanceResult{}, fmt.Errorf("class start time: %w", err)
+	}
+	matHours, _ := sched.DurationHours()
+
+	now := deps.Now()
+	today := now.Format("2006-01-02")
+	earliest := now.Add(-MaxBackfillAge).Format("2006-01-02")
+	result := BackfillAttendanceResult{Results: make([]BackfillEntryResult, 0, len(input.MemberIDs)*len(input.Dates))}
+	record := func(res BackfillEntryResult) {
+		switch res.Status {
+		case BulkSyncStatusCreated:
+			result.Created++
+		case BulkSyncStatusDuplicate:
+			result.Duplicate++
+		default:
+			result.Rejected++
+		}
+		result.Results = append(result.Results, res)
+	}
+
+	seen := make(map[string]bool)
+	for _, date := range input.Dates {
+		reason := ""
+		day, err := time.Parse("2006-01-02", date) // UTC midnight, like stored terms and holidays
+		switch {
+		case err != nil:
+			reason = "date must be YYYY-MM-DD"
+		case seen[date]:
+			continue
+		case date > today:
+			reason = "date is in the future"
+		case date < earliest:
+			reason = "date is too old to backfill"
+		case strings.ToLower(day.Weekday().String()) != sched.Day:
+			reason = "class does not run on " + day.Weekday().String()
+		case !inAnyTerm(terms, day):
+			reason = "date is outside term"
+		default:
+			if h, ok := holidayOn(holidays, day); ok {
+				reason = "date is a holiday: " + h.Name
+			}
+		}
+		seen[date] = true
+		if reason != "" {
+			for _, memberID := range input.MemberIDs {
+				record(BackfillEntryResult{MemberID: memberID, ClassDate: date, Status: BulkSyncStatusRejected, Reason: reason})
+			}
+			continue
+		}
+
+		checkIn := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, now.Location())
+		for _, memberID := range input.MemberIDs {
+			record(backfillOne(ctx, memberID, date, checkIn, matHours, sched.LocationID, input, deps))
+		}
+	}
+
+	slog.InfoContext(ctx, "checkin_event", "event", "backfill", "schedule_id", input.ScheduleID,
+		"members", len(input.MemberIDs), "dates", len(input.Dates),
+		"created", result.Created, "duplicate", result.Duplicate, "rejected", result.Rejected,
+		"actor", input.Actor.AccountID)
+	return result, nil
+}
+
+// backfillOne validates, de-duplicates, persists and audits one member on one date.
+func backfillOne(ctx context.Context, memberID, date string, checkIn time.Time, matHours float64, locationID string, input BackfillAttendanceInput, deps BackfillAttendanceDeps) BackfillEntryResult {
+	reject := func(reason string) BackfillEntryResult {
+		return BackfillEntryResult{MemberID: memberID, ClassDate: date, Status: BulkSyncStatusRejected, Reason: reason}
+	}
+
+	m, err := deps.MemberStore.GetByID(ctx, memberID)
+	if err != nil {
+		return reject("member not found")
+	}
+	if m.IsArchived() {
+		return reject("archived members cannot be backfilled")
+	}
+
+	existing, err := deps.AttendanceStore.ListByMemberIDAndDate(ctx, memberID, date)
+	if err != nil {
+		return reject("could not check existing attendance")
+	}
+	for _, a := range existing {
+		if a.ScheduleID == input.ScheduleID {
+			return BackfillEntryResult{MemberID: memberID, ClassDate: date, Status: BulkSyncStatusDuplicate, AttendanceID: a.ID, Reason: "already attended"}
+		}
+	}
+
+	a := attendance.Attendance{
+		ID:          deps.GenerateID(),
+		MemberID:    memberID,
+		CheckInTime: checkIn,
+		ScheduleID:  input.ScheduleID,
+		ClassDate:   date,
+		MatHours:    matHours,
+		LocationID:  locationID,
+	}
+	if err := a.Validate(); err != nil {
+		return reject(err.Error())
+	}
+	if err := deps.AttendanceStore.Save(ctx, a); err != nil {
+		slog.ErrorContext(ctx, "checkin_event", "event", "backfill_save_failed", "member_id", memberID, "error", err)
+		return reject("could not save attendance")
+	}
+
+	metadata, _ := json.Marshal(map[string]string{"member_id": memberID, "schedule_id": input.ScheduleID, "class_date": date})
+	event := audit.NewEvent(input.Actor.AccountID, input.Actor.Email, input.Actor.Role, audit.CategoryAttendance, audit.ActionCreate).
+		WithResource("attendance", a.ID).
+		WithDescription(fmt.Sprintf("Backfilled attendance for %s on %s", m.Name, date)).
+		WithRequest(input.Actor.IPAddress, input.Actor.UserAgent).
+		WithMetadata(string(metadata))
+	if err := deps.AuditStore.Save(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "checkin_event", "event", "backfill_audit_failed", "attendance_id", a.ID, "error", err)
+	}
+
+	return BackfillEntryResult{MemberID: memberID, ClassDate: date, Status: BulkSyncStatusCreated, AttendanceID: a.ID}
+}
+
+// inAnyTerm reports whether day falls inside one of the terms.
+func inAnyTerm(terms []term.Term, day time.Time) bool {
+	for _, t := range terms {
+		if t.Contains(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// holidayOn returns the holiday covering day, if any.
+func holidayOn(holidays []holiday.Holiday, day time.Time) (holiday.Holiday, bool) {
+	for _, h := range holidays {
+		if h.Contains(day) {
+			return h, true
+		}
+	}
+	return holiday.Holiday{}, false
+}
@@ -0,0 +1,151 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/audit"
+	"workshop/internal/domain/holiday"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/term"
+)
+
+// --- Mock stores for backfill tests ---
+
+type mockBackfillTermStore struct{}
+
+// List implements BackfillTermStore.
+// PRE: none
+// POST: returns one term covering February to April 2026
+func (m *mockBackfillTermStore) List(_ context.Context) ([]term.Term, error) {
+	return []term.Term{{ID: "t1", Name: "Term 1", StartDate: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)}}, nil
+}
+
+type mockBackfillHolidayStore struct{}
+
+// List implements BackfillHolidayStore.
+// PRE: none
+// POST: returns one holiday on 15 February 2026
+func (m *mockBackfillHolidayStore) List(_ context.Context) ([]holiday.Holiday, error) {
+	day := time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)
+	return []holiday.Holiday{{ID: "h1", Name: "Closed", StartDate: day, EndDate: day}}, nil
+}
+
+type mockBackfillAuditStore struct {
+	events []audit.Event
+}
+
+// Save implements BackfillAuditStore.
+// PRE: event is valid
+// POST: event is appended
+func (m *mockBackfillAuditStore) Save(_ context.Context, event audit.Event) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func newBackfillDeps(store *mockBulkSyncAttendanceStore, auditStore *mockBackfillAuditStore) BackfillAttendanceDeps {
+	n := 0
+	return BackfillAttendanceDeps{
+		MemberStore: &mockBulkSyncMemberStore{members: map[string]member.Member{
+			"m1": {ID: "m1", Name: "Alice", Status: member.StatusActive},
+			"m2": {ID: "m2", Name: "Bob", Status: member.StatusArchived},
+			"m3": {ID: "m3", Name: "Cara", Status: member.StatusActive},
+		}},
+		AttendanceStore: store,
+		ScheduleStore:   &mockBulkSyncScheduleStore{},
+		TermStore:       &mockBackfillTermStore{},
+		HolidayStore:    &mockBackfillHolidayStore{},
+		AuditStore:      auditStore,
+		GenerateID: func() string {
+			n++
+			return fmt.Sprintf("att-%d", n)
+		},
+		Now: fixedNow,
+	}
+}
+
+// TestExecuteBackfillAttendance_ValidatesDatesAndMembers verifies schedule, term, holiday and member checks.
+func TestExecuteBackfillAttendance_ValidatesDatesAndMembers(t *testing.T) {
+	store := &mockBulkSyncAttendanceStore{records: []attendance.Attendance{
+		{ID: "existing", MemberID: "m3", ScheduleID: "s1", ClassDate: "2026-02-22", CheckInTime: time.Date(2026, 2, 22, 10, 0, 0, 0, time.UTC)},
+	}}
+	auditStore := &mockBackfillAuditStore{}
+
+	result, err := ExecuteBackfillAttendance(context.Background(), BackfillAttendanceInput{
+		ScheduleID: "s1",
+		MemberIDs:  []string{"m1", "m2", "m3"},
+		Dates:      []string{"2026-02-22", "2026-02-21", "2026-02-15", "2026-01-25", "2026-03-08", "2025-11-02", "22/02/2026"},
+		Actor:      BackfillActor{AccountID: "coach-1", Email: "coach@test.com", Role: "coach"},
+	}, newBackfillDeps(store, auditStore))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		memberID, date, status, reason string
+	}{
+		{"m1", "2026-02-22", BulkSyncStatusCreated, ""},
+		{"m2", "2026-02-22", BulkSyncStatusRejected, "archived members cannot be backfilled"},
+		{"m3", "2026-02-22", BulkSyncStatusDuplicate, "already attended"},
+		{"m1", "2026-02-21", BulkSyncStatusRejected, "class does not run on Saturday"},
+		{"m1", "2026-02-15", BulkSyncStatusRejected, "date is a holiday: Closed"},
+		{"m1", "2026-01-25", BulkSyncStatusRejected, "date is outside term"},
+		{"m1", "2026-03-08", BulkSyncStatusRejected, "date is in the future"},
+		{"m1", "2025-11-02", BulkSyncStatusRejected, "date is too old to backfill"},
+		{"m1", "22/02/2026", BulkSyncStatusRejected, "date must be YYYY-MM-DD"},
+	}
+	byKey := make(map[string]BackfillEntryResult)
+	for _, r := range result.Results {
+		byKey[r.MemberID+"|"+r.ClassDate] = r
+	}
+	for _, w := range want {
+		got := byKey[w.memberID+"|"+w.date]
+		if got.Status != w.status || got.Reason != w.reason {
+			t.Errorf("%s on %s = %s (%q), want %s (%q)", w.memberID, w.date, got.Status, got.Reason, w.status, w.reason)
+		}
+	}
+	if result.Created != 1 || result.Duplicate != 1 || result.Rejected != 19 {
+		t.Errorf("counts = %d/%d/%d, want 1/1/19", result.Created, result.Duplicate, result.Rejected)
+	}
+
+	created := store.records[len(store.records)-1]
+	if created.ClassDate != "2026-02-22" || created.CheckInTime.Hour() != 10 || created.MatHours != 1 || created.LocationID != "central" {
+		t.Errorf("created attendance = %+v, want 10:00 on 2026-02-22 with 1h at central", created)
+	}
+	if len(auditStore.events) != 1 {
+		t.Fatalf("expected 1 audit event, got %d", len(auditStore.events))
+	}
+	ev := auditStore.events[0]
+	if ev.ActorID != "coach-1" || ev.Category != audit.CategoryAttendance || ev.ResourceID != created.ID {
+		t.Errorf("audit event = %+v, want coach-1 attendance event for %s", ev, created.ID)
+	}
+}
+
+// TestExecuteBackfillAttendance_RequestErrors verifies whole-request validation.
+func TestExecuteBackfillAttendance_RequestErrors(t *testing.T) {
+	manyMembers := make([]string, 101)
+	for i := range manyMembers {
+		manyMembers[i] = fmt.Sprintf("m%d", i)
+	}
+	tests := []struct {
+		name  string
+		input BackfillAttendanceInput
+		want  error
+	}{
+		{"no schedule", BackfillAttendanceInput{MemberIDs: []string{"m1"}, Dates: []string{"2026-02-22"}}, ErrBackfillScheduleNeeded},
+		{"no members", BackfillAttendanceInput{ScheduleID: "s1", Dates: []string{"2026-02-22"}}, ErrBackfillEmpty},
+		{"too many", BackfillAttendanceInput{ScheduleID: "s1", MemberIDs: manyMembers, Dates: []string{"a", "b", "c", "d", "e"}}, ErrBackfillTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExecuteBackfillAttendance(context.Background(), tt.input, newBackfillDeps(&mockBulkSyncAttendanceStore{}, &mockBackfillAuditStore{}))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	backupAdapter "workshop/internal/adapters/backup"
+	"workshop/internal/domain/backup"
+)
+
+// BackupDatabase defines the snapshot operations needed by the backup orchestrators.
+type BackupDatabase interface {
+	Snapshot(ctx context.Context, path string) error
+	Inspect(ctx context.Context, path string) (integrity string, schemaVersion int, err error)
+	Restore(ctx context.Context, path string) error
+}
+
+// BackupDeps holds dependencies for CreateBackup and RestoreBackup.
+type BackupDeps struct {
+	Database     BackupDatabase
+	Target       backupAdapter.Target
+	Retention    backup.Retention
+	LatestSchema int    // highest schema version this build can run
+	TempDir      string // scratch space for snapshots; "" uses the OS default
+	Now          func() time.Time
+}
+
+// CreateBackupInput carries input for the create backup orchestrator.
+type CreateBackupInput struct {
+	Trigger     string
+	RequestedBy string // AccountID of the admin; empty for scheduled backups
+}
+
+// CreateBackupResult reports the stored backup and any backups pruned by retention.
+type CreateBackupResult struct {
+	Backup backup.Backup
+	Pruned []string
+}
+
+// RestoreBackupInput carries input for the restore backup orchestrator.
+type RestoreBackupInput struct {
+	Name        string
+	Confirm     string // must repeat Name exactly
+	RequestedBy string // AccountID of the admin
+}
+
+// RestoreBackupResult reports what was restored and the safety copy taken beforehand.
+type RestoreBackupResult struct {
+	Restored      backup.Backup
+	SafetyBackup  backup.Backup
+	SchemaVersion int // schema version of the backup before migration
+}
+
+// backupMu serialises backups and restores so a scheduled run never snapshots mid-restore.
+var backupMu sync.Mutex
+
+// ExecuteCreateBackup snapshots the database, uploads it to the target and applies retention.
+// PRE: Trigger is a valid backup trigger; deps are non-nil
+// POST: A new backup is stored; backups outside the retention policy are deleted
+func ExecuteCreateBackup(ctx context.Context, input CreateBackupInput, deps BackupDeps) (CreateBackupResult, error) {
+	if !backup.ValidTrigger(input.Trigger) {
+		return CreateBackupResult{}, backup.ErrInvalidTrigger
+	}
+	backupMu.Lock()
+	defer backupMu.Unlock()
+	return createBackupLocked(ctx, input, deps)
+}
+
+// createBackupLocked does the work of ExecuteCreateBackup; the caller holds backupMu.
+func createBackupLocked(ctx context.Context, input CreateBackupInput, deps BackupDeps) (CreateBackupResult, error) {
+	start := deps.Now()
+	name := backup.NewName(start, input.Trigger)
+
+	dir, err := os.MkdirTemp(deps.TempDir, "workshop-backup-*")
+	if err != nil {
+		return CreateBackupResult{}, fmt.Errorf("create backup: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, name)
+	if err := deps.Database.Snapshot(ctx, path); err != nil {
+		return CreateBackupResult{}, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return CreateBackupResult{}, fmt.Errorf("create backup: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return CreateBackupResult{}, fmt.Errorf("create backup: %w", err)
+	}
+	if err := deps.Target.Put(ctx, name, f, info.Size()); err != nil {
+		slog.ErrorContext(ctx, "backup_event", "event", "backup_failed", "name", name, "target", deps.Target.Describe(), "error", err)
+		return CreateBackupResult{}, err
+	}
+
+	result := CreateBackupResult{Backup: backup.Backup{Name: name, Trigger: input.Trigger, CreatedAt: start.UTC().Truncate(time.Second), Size: info.Size()}}
+	slog.InfoContext(ctx, "backup_event", "event", "backup_created", "name", name, "trigger", input.Trigger, "by", input.RequestedBy,
+		"bytes", info.Size(), "target", deps.Target.Describe(), "duration", deps.Now().Sub(start).String())
+
+	// Retention failures are logged but do not fail a backup that was stored successfully.
+	existing, err := deps.Target.List(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "backup_event", "event", "retention_list_failed", "error", err)
+		return result, nil
+	}
+	for _, old := range deps.Retention.Expired(existing, deps.Now()) {
+		if err := deps.Target.Delete(ctx, old.Name); err != nil {
+			slog.ErrorContext(ctx, "backup_event", "event", "retention_delete_failed", "name", old.Name, "error", err)
+			continue
+		}
+		result.Pruned = append(result.Pruned, old.Name)
+	}
+	if len(result.Pruned) > 0 {
+		slog.InfoContext(ctx, "backup_event", "event", "backups_pruned", "count", len(result.Pruned), "names", result.Pruned)
+	}
+	return result, nil
+}
+
+// ExecuteRestoreBackup replaces the live database with a stored backup.
+// The backup is downloaded and checked before anything changes, and a pre_restore backup
+// of the current database is stored first so the restore itself can be undone.
+// PRE: Name is a stored backup; Confirm equals Name; RequestedBy is an admin
+// POST: The live database matches the backup (migrated to the latest schema)
+// INVARIANT: A corrupt or newer-schema backup never touches the live database
+func ExecuteRestoreBackup(ctx context.Context, input RestoreBackupInput, deps BackupDeps) (RestoreBackupResult, error) {
+	wanted, err := backup.ParseName(input.Name)
+	if err != nil {
+		return RestoreBackupResult{}, err
+	}
+	if input.Confirm != input.Name {
+		return RestoreBackupResult{}, backup.ErrConfirmMismatch
+	}
+
+	backupMu.Lock()
+	defer backupMu.Unlock()
+
+	existing, err := deps.Target.List(ctx)
+	if err != nil {
+		return RestoreBackupResult{}, err
+	}
+	found := false
+	for _, b := range existing {
+		if b.Name == wanted.Name {
+			wanted.Size = b.Size
+			found = true
+			break
+		}
+	}
+	if !found {
+		return RestoreBackupResult{}, backup.ErrNotFound
+	}
+
+	dir, err := os.MkdirTemp(deps.TempDir, "workshop-restore-*")
+	if err != nil {
+		return RestoreBackupResult{}, fmt.Errorf("restore backup: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, wanted.Name)
+	if err := downloadBackup(ctx, deps.Target, wanted.Name, path); err != nil {
+		return RestoreBackupResult{}, err
+	}
+	integrity, version, err := deps.Database.Inspect(ctx, path)
+	if err != nil {
+		return RestoreBackupResult{}, fmt.Errorf("%w: %v", backup.ErrCorruptBackup, err)
+	}
+	if err := backup.CheckRestorable(integrity, version, deps.LatestSchema); err != nil {
+		slog.WarnContext(ctx, "security_event", "event", "restore_rejected", "name", wanted.Name, "by", input.RequestedBy, "error", err)
+		return RestoreBackupResult{}, err
+	}
+
+	safety, err := createBackupLocked(ctx, CreateBackupInput{Trigger: backup.TriggerPreRestore, RequestedBy: input.RequestedBy}, deps)
+	if err != nil {
+		return RestoreBackupResult{}, fmt.Errorf("pre-restore backup failed, nothing was restored: %w", err)
+	}
+
+	if err := deps.Database.Restore(ctx, path); err != nil {
+		slog.ErrorContext(ctx, "security_event", "event", "restore_failed", "name", wanted.Name, "by", input.RequestedBy, "safety_backup", safety.Backup.Name, "error", err)
+		return RestoreBackupResult{}, err
+	}
+	slog.WarnContext(ctx, "security_event", "event", "database_restored", "name", wanted.Name, "by", input.RequestedBy,
+		"schema_version", version, "safety_backup", safety.Backup.Name)
+
+	return RestoreBackupResult{Restored: wanted, SafetyBackup: safety.Backup, SchemaVersion: version}, nil
+}
+
+// downloadBackup copies a backup from the target into a local file.
+func downloadBackup(ctx context.Context, target backupAdapter.Target, name, path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("restore backup: %w", err)
+	}
+	if err := target.Get(ctx, name, f); err != nil {
+		f.Close()
+		return err
+	}
+	return errors.Join(f.Sync(), f.Close())
+}
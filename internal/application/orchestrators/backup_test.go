@@ -0,0 +1,206 @@
+package orchestrators
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/backup"
+)
+
+// mockBackupDatabase records snapshot and restore calls.
+type mockBackupDatabase struct {
+	integrity string
+	version   int
+	restored  string // contents of the file passed to Restore
+}
+
+// Snapshot writes "live" to path.
+// PRE: none
+// POST: path exists
+func (m *mockBackupDatabase) Snapshot(ctx context.Context, path string) error {
+	return os.WriteFile(path, []byte("live"), 0o600)
+}
+
+// Inspect returns the configured integrity and version.
+// PRE: none
+// POST: Never fails
+func (m *mockBackupDatabase) Inspect(ctx context.Context, path string) (string, int, error) {
+	return m.integrity, m.version, nil
+}
+
+// Restore records the contents of the restored file.
+// PRE: path exists
+// POST: restored holds the file contents
+func (m *mockBackupDatabase) Restore(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path)
+	m.restored = string(data)
+	return err
+}
+
+// mockBackupTarget is an in-memory backup target.
+type mockBackupTarget struct {
+	objects map[string][]byte
+}
+
+// Put stores the object in memory.
+// PRE: none
+// POST: objects[name] holds the contents of r
+func (m *mockBackupTarget) Put(ctx context.Context, name string, r io.Reader, size int64) error {
+	data, err := io.ReadAll(r)
+	m.objects[name] = data
+	return err
+}
+
+// Get writes the stored object.
+// PRE: none
+// POST: Returns an error if name is missing
+func (m *mockBackupTarget) Get(ctx context.Context, name string, w io.Writer) error {
+	data, ok := m.objects[name]
+	if !ok {
+		return errors.New("not found")
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// List returns stored objects with valid backup names.
+// PRE: none
+// POST: Invalid names are skipped
+func (m *mockBackupTarget) List(ctx context.Context) ([]backup.Backup, error) {
+	var list []backup.Backup
+	for name, data := range m.objects {
+		b, err := backup.ParseName(name)
+		if err != nil {
+			continue
+		}
+		b.Size = int64(len(data))
+		list = append(list, b)
+	}
+	return list, nil
+}
+
+// Delete removes the object.
+// PRE: none
+// POST: objects[name] is gone
+func (m *mockBackupTarget) Delete(ctx context.Context, name string) error {
+	delete(m.objects, name)
+	return nil
+}
+
+// Describe names the mock.
+// PRE: none
+// POST: Returns "mock"
+func (m *mockBackupTarget) Describe() string { return "mock" }
+
+var backupFixedTime = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func newBackupDeps(t *testing.T) (BackupDeps, *mockBackupDatabase, *mockBackupTarget) {
+	db := &mockBackupDatabase{integrity: "ok", version: 30}
+	target := &mockBackupTarget{objects: map[string][]byte{}}
+	return BackupDeps{
+		Database:     db,
+		Target:       target,
+		Retention:    backup.Retention{KeepLast: 2, KeepDays: 7},
+		LatestSchema: 30,
+		TempDir:      t.TempDir(),
+		Now:          func() time.Time { return backupFixedTime },
+	}, db, target
+}
+
+// TestExecuteCreateBackup_StoresAndPrunes tests upload and retention.
+func TestExecuteCreateBackup_StoresAndPrunes(t *testing.T) {
+	deps, _, target := newBackupDeps(t)
+	old := []string{
+		backup.NewName(backupFixedTime.AddDate(0, 0, -30), backup.TriggerScheduled),
+		backup.NewName(backupFixedTime.AddDate(0, 0, -20), backup.TriggerScheduled),
+		backup.NewName(backupFixedTime.AddDate(0, 0, -10), backup.TriggerScheduled),
+	}
+	for _, name := range old {
+		target.objects[name] = []byte("old")
+	}
+
+	result, err := ExecuteCreateBackup(context.Background(), CreateBackupInput{Trigger: backup.TriggerManual, RequestedBy: "admin-1"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Backup.Name != "workshop-20260301T090000Z-manual.db" || result.Backup.Size != 4 {
+		t.Errorf("Backup = %+v", result.Backup)
+	}
+	if !bytes.Equal(target.objects[result.Backup.Name], []byte("live")) {
+		t.Errorf("stored contents = %q, want live", target.objects[result.Backup.Name])
+	}
+	// KeepLast 2 keeps the new backup and the 10-day-old one; the other two are past 7 days
+	if len(result.Pruned) != 2 {
+		t.Errorf("Pruned = %v, want 2 backups", result.Pruned)
+	}
+	if _, ok := target.objects[old[2]]; !ok {
+		t.Errorf("second newest backup should be kept")
+	}
+}
+
+// TestExecuteCreateBackup_InvalidTrigger tests trigger validation.
+func TestExecuteCreateBackup_InvalidTrigger(t *testing.T) {
+	deps, _, _ := newBackupDeps(t)
+	if _, err := ExecuteCreateBackup(context.Background(), CreateBackupInput{Trigger: "weekly"}, deps); !errors.Is(err, backup.ErrInvalidTrigger) {
+		t.Errorf("err = %v, want ErrInvalidTrigger", err)
+	}
+}
+
+// TestExecuteRestoreBackup tests the guarded restore flow.
+func TestExecuteRestoreBackup(t *testing.T) {
+	name := backup.NewName(backupFixedTime.AddDate(0, 0, -1), backup.TriggerScheduled)
+
+	tests := []struct {
+		name      string
+		input     RestoreBackupInput
+		integrity string
+		version   int
+		wantErr   error
+	}{
+		{name: "restores and keeps safety copy", input: RestoreBackupInput{Name: name, Confirm: name}, integrity: "ok", version: 28},
+		{name: "confirm mismatch", input: RestoreBackupInput{Name: name, Confirm: "yes"}, integrity: "ok", version: 30, wantErr: backup.ErrConfirmMismatch},
+		{name: "path traversal", input: RestoreBackupInput{Name: "../workshop.db", Confirm: "../workshop.db"}, integrity: "ok", version: 30, wantErr: backup.ErrInvalidName},
+		{name: "missing backup", input: RestoreBackupInput{Name: backup.NewName(backupFixedTime, backup.TriggerManual), Confirm: backup.NewName(backupFixedTime, backup.TriggerManual)}, integrity: "ok", version: 30, wantErr: backup.ErrNotFound},
+		{name: "newer schema", input: RestoreBackupInput{Name: name, Confirm: name}, integrity: "ok", version: 31, wantErr: backup.ErrNewerSchema},
+		{name: "corrupt", input: RestoreBackupInput{Name: name, Confirm: name}, integrity: "page 3 is never used", version: 30, wantErr: backup.ErrCorruptBackup},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, db, target := newBackupDeps(t)
+			db.integrity, db.version = tt.integrity, tt.version
+			target.objects[name] = []byte("yesterday")
+			tt.input.RequestedBy = "admin-1"
+
+			result, err := ExecuteRestoreBackup(context.Background(), tt.input, deps)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if db.restored != "" {
+					t.Errorf("live database was touched on a rejected restore")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if db.restored != "yesterday" {
+				t.Errorf("restored contents = %q, want yesterday", db.restored)
+			}
+			if result.SafetyBackup.Trigger != backup.TriggerPreRestore {
+				t.Errorf("SafetyBackup = %+v, want pre_restore", result.SafetyBackup)
+			}
+			if _, ok := target.objects[result.SafetyBackup.Name]; !ok {
+				t.Errorf("safety backup was not stored")
+			}
+			if result.SchemaVersion != 28 {
+				t.Errorf("SchemaVersion = %d, want 28", result.SchemaVersion)
+			}
+		})
+	}
+}
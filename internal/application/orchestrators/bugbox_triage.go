@@ -0,0 +1,165 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/bugbox"
+)
+
+// ErrAssigneeNotAdmin is returned when a bug report is assigned to an account that is not an admin.
+var ErrAssigneeNotAdmin = errors.New("bug reports can only be assigned to admins")
+
+// ErrBugReportNotFound is returned when a bug report does not exist.
+var ErrBugReportNotFound = errors.New("bug report not found")
+
+// BugTriageStore defines the bugbox store interface needed to triage reports.
+type BugTriageStore interface {
+	GetByID(ctx context.Context, id string) (bugbox.Submission, error)
+	Save(ctx context.Context, s bugbox.Submission) error
+}
+
+// BugCommentStore defines the bugbox store interface needed to comment on reports.
+type BugCommentStore interface {
+	GetByID(ctx context.Context, id string) (bugbox.Submission, error)
+	SaveComment(ctx context.Context, c bugbox.Comment) error
+}
+
+// BugAssigneeStore defines the account store interface needed to check an assignee.
+type BugAssigneeStore interface {
+	GetByID(ctx context.Context, id string) (account.Account, error)
+}
+
+// --- Triage Bug Report ---
+
+// TriageBugReportInput carries an admin's triage of one report. Empty fields are left as
+// they are; Assign applies AssigneeID even when it is empty, which unassigns the report.
+type TriageBugReportInput struct {
+	SubmissionID string
+	Status       string // new status; empty = unchanged
+	DuplicateOf  string // report this one repeats; closes it as won't fix
+	Assign       bool
+	AssigneeID   string // admin AccountID; empty with Assign unassigns
+	TriagedBy    string // AccountID of the admin
+}
+
+// TriageBugReportDeps holds dependencies for TriageBugReport.
+type TriageBugReportDeps struct {
+	BugBoxStore  BugTriageStore
+	AccountStore BugAssigneeStore
+	Now          func() time.Time
+}
+
+// TriageBugReportResult reports the triaged report and whether the reporter should be told.
+type TriageBugReportResult struct {
+	Submission    bugbox.Submission
+	StatusChanged bool
+}
+
+// ExecuteTriageBugReport changes a report's status, assignee or duplicate link. A duplicate is
+// linked to the original report at the root of any duplicate chain.
+// PRE: SubmissionID exists
+// POST: Report saved; StatusChanged is true when the reporter should be notified
+func ExecuteTriageBugReport(ctx context.Context, input TriageBugReportInput, deps TriageBugReportDeps) (TriageBugReportResult, error) {
+	sub, err := deps.BugBoxStore.GetByID(ctx, input.SubmissionID)
+	if err != nil {
+		return TriageBugReportResult{}, ErrBugReportNotFound
+	}
+	now := deps.Now()
+	before := sub.Status
+
+	if input.Assign {
+		if input.AssigneeID != "" {
+			a, err := deps.AccountStore.GetByID(ctx, input.AssigneeID)
+			if err != nil || a.Role != account.RoleAdmin {
+				return TriageBugReportResult{}, ErrAssigneeNotAdmin
+			}
+		}
+		sub.AssigneeID = input.AssigneeID
+		sub.UpdatedAt = now
+	}
+	if input.DuplicateOf != "" {
+		original, err := deps.BugBoxStore.GetByID(ctx, input.DuplicateOf)
+		if err != nil {
+			return TriageBugReportResult{}, ErrBugReportNotFound
+		}
+		rootID := original.ID
+		if original.DuplicateOf != "" {
+			rootID = original.DuplicateOf
+		}
+		if err := sub.MarkDuplicate(rootID, now); err != nil {
+			return TriageBugReportResult{}, err
+		}
+	} else if input.Status != "" {
+		if err := sub.SetStatus(input.Status, now); err != nil {
+			return TriageBugReportResult{}, err
+		}
+	}
+
+	if err := deps.BugBoxStore.Save(ctx, sub); err != nil {
+		return TriageBugReportResult{}, err
+	}
+	slog.InfoContext(ctx, "bugbox_event", "event", "triaged", "submission_id", sub.ID, "status", sub.Status,
+		"assignee_id", sub.AssigneeID, "duplicate_of", sub.DuplicateOf, "by", input.TriagedBy)
+	return TriageBugReportResult{Submission: sub, StatusChanged: sub.Status != before}, nil
+}
+
+// --- Comment On Bug Report ---
+
+// CommentOnBugReportInput carries a comment on a report.
+type CommentOnBugReportInput struct {
+	SubmissionID string
+	AuthorID     string // AccountID of an admin or the reporter
+	Body         string
+}
+
+// CommentOnBugReportDeps holds dependencies for CommentOnBugReport.
+type CommentOnBugReportDeps struct {
+	BugBoxStore BugCommentStore
+	GenerateID  func() string
+	Now         func() time.Time
+}
+
+// CommentOnBugReportResult reports the saved comment and who should hear about it.
+type CommentOnBugReportResult struct {
+	Comment    bugbox.Comment
+	Submission bugbox.Submission
+	NotifyID   string // the reporter when staff comment, the assignee when the reporter does; may be empty
+}
+
+// ExecuteCommentOnBugReport adds a comment to a report. The handler decides who may comment.
+// PRE: SubmissionID exists
+// POST: Comment saved
+func ExecuteCommentOnBugReport(ctx context.Context, input CommentOnBugReportInput, deps CommentOnBugReportDeps) (CommentOnBugReportResult, error) {
+	sub, err := deps.BugBoxStore.GetByID(ctx, input.SubmissionID)
+	if err != nil {
+		return CommentOnBugReportResult{}, ErrBugReportNotFound
+	}
+	c := bugbox.Comment{
+		ID:           deps.GenerateID(),
+		SubmissionID: sub.ID,
+		AuthorID:     input.AuthorID,
+		Body:         strings.TrimSpace(input.Body),
+		CreatedAt:    deps.Now(),
+	}
+	if err := c.Validate(); err != nil {
+		return CommentOnBugReportResult{}, err
+	}
+	if err := deps.BugBoxStore.SaveComment(ctx, c); err != nil {
+		return CommentOnBugReportResult{}, err
+	}
+
+	notifyID := sub.ReporterID
+	if input.AuthorID == sub.ReporterID {
+		notifyID = sub.AssigneeID
+	}
+	if notifyID == input.AuthorID {
+		notifyID = ""
+	}
+	slog.InfoContext(ctx, "bugbox_event", "event", "commented", "submission_id", sub.ID, "by", input.AuthorID)
+	return CommentOnBugReportResult{Comment: c, Submission: sub, NotifyID: notifyID}, nil
+}
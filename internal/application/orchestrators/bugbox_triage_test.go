@@ -0,0 +1,187 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/bugbox"
+)
+
+// mockBugTriageStore implements BugTriageStore and BugCommentStore for testing.
+type mockBugTriageStore struct {
+	submissions map[string]bugbox.Submission
+	comments    []bugbox.Comment
+}
+
+// GetByID implements BugTriageStore.
+// PRE: id is non-empty
+// POST: returns the submission or sql.ErrNoRows
+func (m *mockBugTriageStore) GetByID(_ context.Context, id string) (bugbox.Submission, error) {
+	s, ok := m.submissions[id]
+	if !ok {
+		return bugbox.Submission{}, sql.ErrNoRows
+	}
+	return s, nil
+}
+
+// Save implements BugTriageStore.
+// PRE: s is valid
+// POST: submission is stored by ID
+func (m *mockBugTriageStore) Save(_ context.Context, s bugbox.Submission) error {
+	m.submissions[s.ID] = s
+	return nil
+}
+
+// SaveComment implements BugCommentStore.
+// PRE: c is valid
+// POST: comment is appended
+func (m *mockBugTriageStore) SaveComment(_ context.Context, c bugbox.Comment) error {
+	m.comments = append(m.comments, c)
+	return nil
+}
+
+// mockBugAssigneeStore implements BugAssigneeStore for testing.
+type mockBugAssigneeStore struct{}
+
+// GetByID implements BugAssigneeStore.
+// PRE: id is non-empty
+// POST: admin-* IDs are admins, coach-* IDs are coaches, anything else is not found
+func (m *mockBugAssigneeStore) GetByID(_ context.Context, id string) (account.Account, error) {
+	switch {
+	case strings.HasPrefix(id, "admin-"):
+		return account.Account{ID: id, Role: account.RoleAdmin}, nil
+	case strings.HasPrefix(id, "coach-"):
+		return account.Account{ID: id, Role: account.RoleCoach}, nil
+	}
+	return account.Account{}, sql.ErrNoRows
+}
+
+// newBugTriageStore returns a store with three new reports from member-1; b2 is a duplicate of b1.
+func newBugTriageStore() *mockBugTriageStore {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	store := &mockBugTriageStore{submissions: map[string]bugbox.Submission{}}
+	for _, id := range []string{"b1", "b2", "b3"} {
+		store.submissions[id] = bugbox.Submission{ID: id, Summary: "Broken", ReporterID: "member-1", Status: bugbox.StatusNew, SubmittedAt: created}
+	}
+	b2 := store.submissions["b2"]
+	b2.DuplicateOf, b2.Status = "b1", bugbox.StatusWontFix
+	store.submissions["b2"] = b2
+	return store
+}
+
+// TestExecuteTriageBugReport verifies status changes, assignment and duplicate linking.
+func TestExecuteTriageBugReport(t *testing.T) {
+	store := newBugTriageStore()
+	deps := TriageBugReportDeps{
+		BugBoxStore:  store,
+		AccountStore: &mockBugAssigneeStore{},
+		Now:          func() time.Time { return time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) },
+	}
+	ctx := context.Background()
+
+	result, err := ExecuteTriageBugReport(ctx, TriageBugReportInput{SubmissionID: "b1", Status: bugbox.StatusAcknowledged, Assign: true, AssigneeID: "admin-1", TriagedBy: "admin-1"}, deps)
+	if err != nil {
+		t.Fatalf("ack: %v", err)
+	}
+	if !result.StatusChanged || store.submissions["b1"].Status != bugbox.StatusAcknowledged || store.submissions["b1"].AssigneeID != "admin-1" {
+		t.Errorf("expected b1 acknowledged and assigned, got %+v", store.submissions["b1"])
+	}
+
+	result, err = ExecuteTriageBugReport(ctx, TriageBugReportInput{SubmissionID: "b1", Assign: true, TriagedBy: "admin-1"}, deps)
+	if err != nil {
+		t.Fatalf("unassign: %v", err)
+	}
+	if result.StatusChanged || store.submissions["b1"].AssigneeID != "" {
+		t.Errorf("expected b1 unassigned without a status change, got %+v", result)
+	}
+
+	// b3 repeats b2, which already repeats b1, so b3 links straight to b1.
+	result, err = ExecuteTriageBugReport(ctx, TriageBugReportInput{SubmissionID: "b3", DuplicateOf: "b2", TriagedBy: "admin-1"}, deps)
+	if err != nil {
+		t.Fatalf("duplicate: %v", err)
+	}
+	if !result.StatusChanged || store.submissions["b3"].DuplicateOf != "b1" || store.submissions["b3"].Status != bugbox.StatusWontFix {
+		t.Errorf("expected b3 closed as a duplicate of b1, got %+v", store.submissions["b3"])
+	}
+}
+
+// TestExecuteTriageBugReport_Rejects verifies invalid triage is refused and nothing is saved.
+func TestExecuteTriageBugReport_Rejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		input TriageBugReportInput
+		want  error
+	}{
+		{"unknown report", TriageBugReportInput{SubmissionID: "b9", Status: bugbox.StatusFixed}, ErrBugReportNotFound},
+		{"unknown original", TriageBugReportInput{SubmissionID: "b1", DuplicateOf: "b9"}, ErrBugReportNotFound},
+		{"coach assignee", TriageBugReportInput{SubmissionID: "b1", Assign: true, AssigneeID: "coach-1"}, ErrAssigneeNotAdmin},
+		{"unknown assignee", TriageBugReportInput{SubmissionID: "b1", Assign: true, AssigneeID: "nobody"}, ErrAssigneeNotAdmin},
+		{"bad status", TriageBugReportInput{SubmissionID: "b1", Status: "closed"}, bugbox.ErrInvalidStatus},
+		{"same status", TriageBugReportInput{SubmissionID: "b1", Status: bugbox.StatusNew}, bugbox.ErrSameStatus},
+		{"own duplicate", TriageBugReportInput{SubmissionID: "b1", DuplicateOf: "b1"}, bugbox.ErrSelfDuplicate},
+		{"duplicate cycle", TriageBugReportInput{SubmissionID: "b1", DuplicateOf: "b2"}, bugbox.ErrSelfDuplicate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newBugTriageStore()
+			before := store.submissions["b1"]
+			deps := TriageBugReportDeps{BugBoxStore: store, AccountStore: &mockBugAssigneeStore{}, Now: time.Now}
+			if _, err := ExecuteTriageBugReport(context.Background(), tt.input, deps); err != tt.want {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+			if store.submissions["b1"] != before {
+				t.Errorf("b1 should be unchanged, got %+v", store.submissions["b1"])
+			}
+		})
+	}
+}
+
+// TestExecuteCommentOnBugReport verifies who is told about a comment.
+func TestExecuteCommentOnBugReport(t *testing.T) {
+	tests := []struct {
+		name       string
+		assignee   string
+		author     string
+		body       string
+		wantNotify string
+		wantErr    error
+	}{
+		{name: "admin tells the reporter", assignee: "admin-1", author: "admin-2", body: "Can you send a screenshot?", wantNotify: "member-1"},
+		{name: "reporter tells the assignee", assignee: "admin-1", author: "member-1", body: "Attached", wantNotify: "admin-1"},
+		{name: "reporter with no assignee", author: "member-1", body: "Still broken"},
+		{name: "empty comment", author: "admin-1", body: "   ", wantErr: bugbox.ErrEmptyComment},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newBugTriageStore()
+			b1 := store.submissions["b1"]
+			b1.AssigneeID = tt.assignee
+			store.submissions["b1"] = b1
+			deps := CommentOnBugReportDeps{
+				BugBoxStore: store,
+				GenerateID:  func() string { return "c1" },
+				Now:         func() time.Time { return time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) },
+			}
+			result, err := ExecuteCommentOnBugReport(context.Background(), CommentOnBugReportInput{SubmissionID: "b1", AuthorID: tt.author, Body: tt.body}, deps)
+			if err != tt.wantErr {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(store.comments) != 0 {
+					t.Error("no comment should be saved")
+				}
+				return
+			}
+			if result.NotifyID != tt.wantNotify {
+				t.Errorf("NotifyID = %q, want %q", result.NotifyID, tt.wantNotify)
+			}
+			if len(store.comments) != 1 || store.comments[0].Body != tt.body {
+				t.Errorf("expected the comment saved, got %+v", store.comments)
+			}
+		})
+	}
+}
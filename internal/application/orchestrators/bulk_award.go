@@ -0,0 +1,231 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/audit"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/member"
+)
+
+// ErrBulkAwardRejected is returned, naming the members, when a bulk award cannot be given to
+// every member selected. Nothing is saved.
+var ErrBulkAwardRejected = errors.New("award cannot be given to")
+
+// BulkAwardMemberStore defines the member store interface needed for bulk awards.
+type BulkAwardMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+}
+
+// BulkAwardRecordStore defines the grading record store interface needed for bulk awards.
+type BulkAwardRecordStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]grading.Record, error)
+	SaveAwards(ctx context.Context, records []grading.Record, notes []grading.Note) error
+}
+
+// BulkAwardInput gives every member the same award: one more stripe, or a new belt.
+type BulkAwardInput struct {
+	MemberIDs      []string
+	Kind           string    // grading.AwardStripe or grading.AwardBelt
+	Belt           string    // the belt awarded; only for grading.AwardBelt
+	Note           string    // optional: saved as a grading note on every member
+	NoteVisibility string    // coaches (default), admin or shared
+	Date           time.Time // zero awards today
+	Preview        bool      // true computes every member's new rank without saving
+	Actor          BackfillActor
+}
+
+// BulkAwardDeps holds dependencies for bulk awards.
+type BulkAwardDeps struct {
+	MemberStore BulkAwardMemberStore
+	RecordStore BulkAwardRecordStore
+	AuditStore  BackfillAuditStore
+	GenerateID  func() string
+	Now         func() time.Time
+}
+
+// BulkAwardRow is one member's part of a bulk award.
+type BulkAwardRow struct {
+	MemberID string
+	Name     string
+	Program  string
+	Current  grading.Rank
+	New      grading.Rank
+	Error    string // why this member cannot receive the award; empty when they can
+}
+
+// BulkAwardResult carries the output of a bulk award.
+type BulkAwardResult struct {
+	Preview bool
+	Rows    []BulkAwardRow
+	Records []grading.Record // the records saved; empty for a preview
+}
+
+// ExecuteBulkAward gives many members a stripe or a belt at once, as after a grading day.
+// Every member's current rank is read and their new rank worked out first; the records and
+// notes are then saved in one transaction, so either every member is awarded or none is.
+// A preview returns the same rows without saving.
+// PRE: input.Actor.AccountID is an admin
+// POST: Returns a row per member in input order; on ErrBulkAwardRejected or any other error nothing is saved
+func ExecuteBulkAward(ctx context.Context, input BulkAwardInput, deps BulkAwardDeps) (BulkAwardResult, error) {
+	result := BulkAwardResult{Preview: input.Preview, Rows: []BulkAwardRow{}, Records: []grading.Record{}}
+	if input.Kind != grading.AwardStripe && input.Kind != grading.AwardBelt {
+		return result, grading.ErrInvalidAwardKind
+	}
+	if input.Kind == grading.AwardBelt && !grading.IsValidBelt(input.Belt) {
+		return result, grading.ErrInvalidBelt
+	}
+	note := strings.TrimSpace(input.Note)
+	if len(note) > grading.MaxAwardNoteLength {
+		return result, grading.ErrAwardNoteTooLong
+	}
+	if !grading.IsValidNoteVisibility(input.NoteVisibility) {
+		return result, grading.ErrInvalidNoteVisibility
+	}
+	now := deps.Now()
+	date := input.Date
+	if date.IsZero() {
+		date = now
+	}
+	if date.After(now) {
+		return result, grading.ErrAwardInFuture
+	}
+	var memberIDs []string
+	seen := map[string]bool{}
+	for _, id := range input.MemberIDs {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			memberIDs = append(memberIDs, id)
+		}
+	}
+	if len(memberIDs) == 0 {
+		return result, grading.ErrNoAwardMembers
+	}
+	if len(memberIDs) > grading.MaxAwardMembers {
+		return result, grading.ErrTooManyAwards
+	}
+
+	var rejected []string
+	for _, id := range memberIDs {
+		row, err := bulkAwardRow(ctx, id, input, date, deps)
+		if err != nil {
+			return result, err
+		}
+		if row.Error != "" {
+			name := row.Name
+			if name == "" {
+				name = row.MemberID
+			}
+			rejected = append(rejected, name)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	if input.Preview {
+		return result, nil
+	}
+	if len(rejected) > 0 {
+		return result, fmt.Errorf("%w %s", ErrBulkAwardRejected, strings.Join(rejected, ", "))
+	}
+
+	var notes []grading.Note
+	for _, row := range result.Rows {
+		record := grading.Record{
+			ID:         deps.GenerateID(),
+			MemberID:   row.MemberID,
+			Belt:       row.New.Belt,
+			Stripe:     row.New.Stripe,
+			PromotedAt: date,
+			ProposedBy: input.Actor.AccountID,
+			ApprovedBy: input.Actor.AccountID,
+			Method:     grading.MethodStandard,
+		}
+		if err := record.Validate(); err != nil {
+			return result, err
+		}
+		result.Records = append(result.Records, record)
+		if note != "" {
+			notes = append(notes, grading.Note{
+				ID:         deps.GenerateID(),
+				MemberID:   row.MemberID,
+				Content:    note,
+				Visibility: input.NoteVisibility,
+				CreatedBy:  input.Actor.AccountID,
+				CreatedAt:  now,
+			})
+		}
+	}
+	if err := deps.RecordStore.SaveAwards(ctx, result.Records, notes); err != nil {
+		result.Records = []grading.Record{}
+		return result, err
+	}
+
+	bulkAwardAudit(ctx, input, result, date, deps)
+	slog.InfoContext(ctx, "grading_event", "event", "bulk_award", "kind", input.Kind, "belt", input.Belt, "members", len(result.Records), "admin_id", input.Actor.AccountID)
+	return result, nil
+}
+
+// bulkAwardRow works out one member's current and new rank. A member who cannot receive the
+// award gets a row with Error set; only store failures are returned.
+func bulkAwardRow(ctx context.Context, memberID string, input BulkAwardInput, date time.Time, deps BulkAwardDeps) (BulkAwardRow, error) {
+	row := BulkAwardRow{MemberID: memberID}
+	m, err := deps.MemberStore.GetByID(ctx, memberID)
+	if err != nil {
+		row.Error = "member not found"
+		return row, nil
+	}
+	row.Name, row.Program = m.Name, m.Program
+	records, err := deps.RecordStore.ListByMemberID(ctx, memberID)
+	if err != nil {
+		return row, err
+	}
+	row.Current = grading.CurrentRank(records)
+	if date.Before(row.Current.PromotedAt) {
+		row.Error = grading.ErrAwardBeforeRank.Error()
+		return row, nil
+	}
+	awarded, err := grading.AwardRank(m.Program, row.Current, input.Kind, input.Belt)
+	if err != nil {
+		row.Error = err.Error()
+		return row, nil
+	}
+	awarded.PromotedAt = date
+	row.New = awarded
+	return row, nil
+}
+
+// bulkAwardAudit records a bulk award in the audit log as one event. A failure is logged,
+// not returned: the award has already been saved.
+func bulkAwardAudit(ctx context.Context, input BulkAwardInput, result BulkAwardResult, date time.Time, deps BulkAwardDeps) {
+	if deps.AuditStore == nil {
+		return
+	}
+	award := input.Kind
+	if input.Kind == grading.AwardBelt {
+		award = input.Belt + " belt"
+	}
+	ids := make([]string, 0, len(result.Records))
+	for _, r := range result.Records {
+		ids = append(ids, r.ID)
+	}
+	metadata, _ := json.Marshal(map[string]string{
+		"kind":    input.Kind,
+		"belt":    input.Belt,
+		"date":    date.Format("2006-01-02"),
+		"records": strings.Join(ids, ","),
+	})
+	event := audit.NewEvent(input.Actor.AccountID, input.Actor.Email, input.Actor.Role, audit.CategoryMember, audit.ActionCreate).
+		WithResource("grading_record", ids[0]).
+		WithDescription("Awarded a "+award+" to "+strconv.Itoa(len(ids))+" members on "+date.Format("2006-01-02")).
+		WithRequest(input.Actor.IPAddress, input.Actor.UserAgent).
+		WithMetadata(string(metadata))
+	if err := deps.AuditStore.Save(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "grading_event", "event", "bulk_award_audit_failed", "error", err)
+	}
+}
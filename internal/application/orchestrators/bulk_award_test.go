@@ -0,0 +1,163 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/member"
+)
+
+type mockBulkAwardRecordStore struct {
+	records []grading.Record
+	notes   []grading.Note
+	saves   int
+}
+
+// ListByMemberID implements BulkAwardRecordStore.
+// PRE: none
+// POST: Returns the member's records
+func (m *mockBulkAwardRecordStore) ListByMemberID(_ context.Context, memberID string) ([]grading.Record, error) {
+	var list []grading.Record
+	for _, r := range m.records {
+		if r.MemberID == memberID {
+			list = append(list, r)
+		}
+	}
+	return list, nil
+}
+
+// SaveAwards implements BulkAwardRecordStore.
+// PRE: none
+// POST: The records and notes are appended
+func (m *mockBulkAwardRecordStore) SaveAwards(_ context.Context, records []grading.Record, notes []grading.Note) error {
+	m.saves++
+	m.records = append(m.records, records...)
+	m.notes = append(m.notes, notes...)
+	return nil
+}
+
+func newBulkAwardDeps() (BulkAwardDeps, *mockBulkAwardRecordStore, *mockBackfillAuditStore) {
+	graded := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	store := &mockBulkAwardRecordStore{records: []grading.Record{
+		{ID: "r1", MemberID: "m1", Belt: grading.BeltBlue, Stripe: 1, PromotedAt: graded},
+		{ID: "r2", MemberID: "m2", Belt: grading.BeltWhite, Stripe: 4, PromotedAt: graded},
+	}}
+	auditStore := &mockBackfillAuditStore{}
+	next := 0
+	return BulkAwardDeps{
+		MemberStore: &mockConvertMemberStore{members: map[string]member.Member{
+			"m1": {ID: "m1", Name: "Alex", Program: "adults"},
+			"m2": {ID: "m2", Name: "Kim", Program: "adults"},
+			"m3": {ID: "m3", Name: "Sam", Program: "adults"},
+		}},
+		RecordStore: store,
+		AuditStore:  auditStore,
+		GenerateID:  func() string { next++; return "id-" + strconv.Itoa(next) },
+		Now:         func() time.Time { return time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC) },
+	}, store, auditStore
+}
+
+// TestExecuteBulkAward_Stripes verifies the preview saves nothing, and the award saves every
+// member's record and the shared note in one call and is audited once.
+func TestExecuteBulkAward_Stripes(t *testing.T) {
+	deps, store, auditStore := newBulkAwardDeps()
+	input := BulkAwardInput{
+		MemberIDs: []string{"m1", "m3", "m1"},
+		Kind:      grading.AwardStripe,
+		Note:      " Great grading day ",
+		Date:      time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC),
+		Preview:   true,
+		Actor:     BackfillActor{AccountID: "admin-1", Role: "admin"},
+	}
+
+	preview, err := ExecuteBulkAward(context.Background(), input, deps)
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if len(preview.Rows) != 2 || store.saves != 0 {
+		t.Fatalf("preview rows = %+v, saves = %d; want two members and nothing saved", preview.Rows, store.saves)
+	}
+	if alex := preview.Rows[0]; alex.Name != "Alex" || alex.Current.Stripe != 1 || alex.New.Belt != grading.BeltBlue || alex.New.Stripe != 2 {
+		t.Errorf("Alex = %+v", alex)
+	}
+	if sam := preview.Rows[1]; sam.Current.Belt != grading.BeltWhite || sam.New.Stripe != 1 {
+		t.Errorf("Sam = %+v, want a white belt's first stripe", sam)
+	}
+
+	input.Preview = false
+	result, err := ExecuteBulkAward(context.Background(), input, deps)
+	if err != nil {
+		t.Fatalf("award: %v", err)
+	}
+	if store.saves != 1 || len(result.Records) != 2 || len(store.notes) != 2 {
+		t.Fatalf("saves = %d, records = %+v, notes = %+v", store.saves, result.Records, store.notes)
+	}
+	r := result.Records[0]
+	if r.MemberID != "m1" || r.Stripe != 2 || r.Method != grading.MethodStandard || r.ApprovedBy != "admin-1" || !r.PromotedAt.Equal(input.Date) {
+		t.Errorf("record = %+v", r)
+	}
+	if n := store.notes[0]; n.Content != "Great grading day" || n.CreatedBy != "admin-1" {
+		t.Errorf("note = %+v", n)
+	}
+	if len(auditStore.events) != 1 || !strings.Contains(auditStore.events[0].Description, "2 members") {
+		t.Errorf("audit = %+v, want one event", auditStore.events)
+	}
+}
+
+// TestExecuteBulkAward_Rejected verifies one member who cannot take the award stops the
+// whole award, and the preview says why.
+func TestExecuteBulkAward_Rejected(t *testing.T) {
+	deps, store, _ := newBulkAwardDeps()
+	input := BulkAwardInput{MemberIDs: []string{"m1", "m2", "missing"}, Kind: grading.AwardStripe, Actor: BackfillActor{AccountID: "admin-1"}}
+
+	_, err := ExecuteBulkAward(context.Background(), input, deps)
+	if !errors.Is(err, ErrBulkAwardRejected) || !strings.Contains(err.Error(), "Kim, missing") {
+		t.Fatalf("err = %v, want Kim and the missing member named", err)
+	}
+	if store.saves != 0 {
+		t.Errorf("saves = %d, want nothing saved", store.saves)
+	}
+
+	input.Preview = true
+	preview, err := ExecuteBulkAward(context.Background(), input, deps)
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if preview.Rows[0].Error != "" || preview.Rows[1].Error != grading.ErrStripesFull.Error() || preview.Rows[2].Error == "" {
+		t.Errorf("rows = %+v", preview.Rows)
+	}
+
+	// A white belt with four stripes takes their blue belt instead
+	result, err := ExecuteBulkAward(context.Background(), BulkAwardInput{MemberIDs: []string{"m2"}, Kind: grading.AwardBelt, Belt: grading.BeltBlue, Actor: BackfillActor{AccountID: "admin-1"}}, deps)
+	if err != nil || len(result.Records) != 1 || result.Records[0].Belt != grading.BeltBlue || result.Records[0].Stripe != 0 {
+		t.Errorf("belt award = %+v, %v", result.Records, err)
+	}
+}
+
+// TestExecuteBulkAward_Invalid verifies the award itself is checked before any member is read.
+func TestExecuteBulkAward_Invalid(t *testing.T) {
+	deps, _, _ := newBulkAwardDeps()
+	tests := []struct {
+		name  string
+		input BulkAwardInput
+		want  error
+	}{
+		{"no members", BulkAwardInput{Kind: grading.AwardStripe}, grading.ErrNoAwardMembers},
+		{"unknown kind", BulkAwardInput{MemberIDs: []string{"m1"}, Kind: "tip"}, grading.ErrInvalidAwardKind},
+		{"unknown belt", BulkAwardInput{MemberIDs: []string{"m1"}, Kind: grading.AwardBelt, Belt: "rainbow"}, grading.ErrInvalidBelt},
+		{"future date", BulkAwardInput{MemberIDs: []string{"m1"}, Kind: grading.AwardStripe, Date: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)}, grading.ErrAwardInFuture},
+		{"before last promotion", BulkAwardInput{MemberIDs: []string{"m1"}, Kind: grading.AwardStripe, Date: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)}, ErrBulkAwardRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ExecuteBulkAward(context.Background(), tt.input, deps); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/audit"
+	emailDomain "workshop/internal/domain/email"
+	"workshop/internal/domain/member"
+)
+
+// ProvisionStatusSkipped marks a member left without an account, in addition to the bulk sync created and rejected.
+const ProvisionStatusSkipped = "skipped"
+
+// ProvisionActivationSubject is the subject line of the queued activation email.
+const ProvisionActivationSubject = "Activate your Workshop account"
+
+// ProvisionMemberStore defines the member store interface needed for bulk provisioning.
+type ProvisionMemberStore interface {
+	List(ctx context.Context, filter memberStore.ListFilter) ([]member.Member, error)
+	Save(ctx context.Context, value member.Member) error
+}
+
+// ProvisionAccountStore defines the account store interface needed for bulk provisioning.
+type ProvisionAccountStore interface {
+	GetByEmail(ctx context.Context, email string) (account.Account, error)
+	Save(ctx context.Context, value account.Account) error
+	SaveActivationToken(ctx context.Context, token account.ActivationToken) error
+}
+
+// ProvisionEmailStore defines the email store interface needed to queue activation emails.
+type ProvisionEmailStore interface {
+	Save(ctx context.Context, e emailDomain.Email) error
+	SaveRecipients(ctx context.Context, emailID string, recipients []emailDomain.Recipient) error
+}
+
+// BulkProvisionInput creates accounts for members who have none.
+type BulkProvisionInput struct {
+	ActivationBaseURL string // scheme and host the activation link points at, e.g. https://gym.example
+	DryRun            bool   // report what would happen without writing
+	Actor             BackfillActor
+}
+
+// BulkProvisionMemberResult reports the outcome for one member.
+type BulkProvisionMemberResult struct {
+	MemberID   string
+	MemberName string
+	Email      string
+	Status     string // created, skipped or rejected
+	AccountID  string // account created (empty unless created)
+	EmailID    string // activation email queued (empty when queuing failed or on a dry run)
+	Reason     string
+}
+
+// BulkProvisionResult carries per-member outcomes, ordered by member name.
+type BulkProvisionResult struct {
+	DryRun   bool
+	Results  []BulkProvisionMemberResult
+	Created  int
+	Skipped  int
+	Rejected int
+}
+
+// BulkProvisionDeps holds dependencies for BulkProvision.
+type BulkProvisionDeps struct {
+	MemberStore  ProvisionMemberStore
+	AccountStore ProvisionAccountStore
+	EmailStore   ProvisionEmailStore
+	AuditStore   BackfillAuditStore
+	GenerateID   func() string
+	Now          func() time.Time
+}
+
+// ExecuteBulkProvisionAccounts gives every unarchived member without an account a
+// pending-activation member account and queues an activation email for the
+// scheduled email worker to send. Members whose email already belongs to an account
+// are skipped rather than linked, since that account may be someone else's.
+// PRE: Actor is an admin; deps are non-nil
+// POST: Each created account is linked to its member and has one activation token;
+// one audit event per created account. Nothing is written when DryRun is true.
+func ExecuteBulkProvisionAccounts(ctx context.Context, input BulkProvisionInput, deps BulkProvisionDeps) (BulkProvisionResult, error) {
+	result := BulkProvisionResult{DryRun: input.DryRun}
+
+	members, err := deps.MemberStore.List(ctx, memberStore.ListFilter{Limit: 10000, Sort: "name"})
+	if err != nil {
+		return result, err
+	}
+
+	claimed := map[string]bool{} // emails given an account in this run, e.g. a parent of two kids
+	for _, m := range members {
+		if m.AccountID != "" || m.IsArchived() {
+			continue
+		}
+		var res BulkProvisionMemberResult
+		email := strings.ToLower(strings.TrimSpace(m.Email))
+		if claimed[email] {
+			res = BulkProvisionMemberResult{MemberID: m.ID, MemberName: m.Name, Email: m.Email, Status: ProvisionStatusSkipped, Reason: "another member with this email was provisioned first"}
+		} else {
+			res = provisionMember(ctx, m, email, input, deps)
+		}
+		switch res.Status {
+		case BulkSyncStatusCreated:
+			claimed[email] = true
+			result.Created++
+		case ProvisionStatusSkipped:
+			result.Skipped++
+		default:
+			result.Rejected++
+		}
+		result.Results = append(result.Results, res)
+	}
+
+	slog.InfoContext(ctx, "auth_event", "event", "accounts_bulk_provisioned", "actor", input.Actor.AccountID, "created", result.Created, "skipped", result.Skipped, "rejected", result.Rejected, "dry_run", input.DryRun)
+	return result, nil
+}
+
+// provisionMember creates one member's account, token and activation email.
+func provisionMember(ctx context.Context, m member.Member, email string, input BulkProvisionInput, deps BulkProvisionDeps) BulkProvisionMemberResult {
+	res := BulkProvisionMemberResult{MemberID: m.ID, MemberName: m.Name, Email: m.Email}
+	reject := func(reason string) BulkProvisionMemberResult {
+		res.Status = BulkSyncStatusRejected
+		res.Reason = reason
+		return res
+	}
+
+	if _, err := deps.AccountStore.GetByEmail(ctx, email); err == nil {
+		res.Status = ProvisionStatusSkipped
+		res.Reason = "an account with this email already exists"
+		return res
+	}
+
+	now := deps.Now()
+	acct := account.Account{
+		ID:           deps.GenerateID(),
+		Email:        email,
+		Role:         account.RoleMember,
+		Status:       account.StatusPendingActivation,
+		PasswordHash: "pending_activation",
+		CreatedAt:    now,
+	}
+	if err := acct.Validate(); err != nil {
+		return reject(err.Error())
+	}
+	if input.DryRun {
+		res.Status = BulkSyncStatusCreated
+		return res
+	}
+
+	if err := deps.AccountStore.Save(ctx, acct); err != nil {
+		return reject("could not save account")
+	}
+	m.AccountID = acct.ID
+	if err := deps.MemberStore.Save(ctx, m); err != nil {
+		return reject("could not link account to member")
+	}
+	res.Status = BulkSyncStatusCreated
+	res.AccountID = acct.ID
+	provisionAudit(ctx, acct, m, input, deps)
+
+	tok := account.ActivationToken{
+		ID:        deps.GenerateID(),
+		AccountID: acct.ID,
+		Token:     deps.GenerateID(),
+		ExpiresAt: now.Add(account.ActivationTokenLifetime),
+		CreatedAt: now,
+	}
+	if err := deps.AccountStore.SaveActivationToken(ctx, tok); err != nil {
+		res.Reason = "account created but the activation token could not be saved; resend activation"
+		return res
+	}
+
+	emailID, err := queueActivationEmail(ctx, m, tok.Token, input, deps)
+	if err != nil {
+		slog.ErrorContext(ctx, "email_event", "event", "activation_email_queue_failed", "member_id", m.ID, "error", err)
+		res.Reason = "account created but the activation email could not be queued; resend activation"
+		return res
+	}
+	res.EmailID = emailID
+	return res
+}
+
+// queueActivationEmail schedules a single-recipient activation email to go out now.
+// The recipient is saved before the email is scheduled so the worker never sees it empty.
+func queueActivationEmail(ctx context.Context, m member.Member, token string, input BulkProvisionInput, deps BulkProvisionDeps) (string, error) {
+	now := deps.Now()
+	link := strings.TrimRight(input.ActivationBaseURL, "/") + "/activate?token=" + url.QueryEscape(token)
+	em := emailDomain.Email{
+		ID:        deps.GenerateID(),
+		Subject:   ProvisionActivationSubject,
+		Body:      activationEmailBody(m.Name, link),
+		Category:  emailDomain.CategoryAccount,
+		SenderID:  input.Actor.AccountID,
+		Status:    emailDomain.StatusDraft,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := deps.EmailStore.Save(ctx, em); err != nil {
+		return "", err
+	}
+	recipient := emailDomain.Recipient{EmailID: em.ID, MemberID: m.ID, MemberName: m.Name, MemberEmail: m.Email}
+	if err := deps.EmailStore.SaveRecipients(ctx, em.ID, []emailDomain.Recipient{recipient}); err != nil {
+		return "", err
+	}
+	if err := em.Schedule(now); err != nil {
+		return "", err
+	}
+	if err := deps.EmailStore.Save(ctx, em); err != nil {
+		return "", err
+	}
+	return em.ID, nil
+}
+
+// activationEmailBody renders the HTML body of an activation email.
+func activationEmailBody(name, link string) string {
+	hours := int(account.ActivationTokenLifetime.Hours())
+	return fmt.Sprintf(`<p>Kia ora %s,</p>
+<p>Your gym has set up a Workshop account for you. Choose a password to start using it:</p>
+<p><a href="%s">Activate your account</a></p>
+<p>This link expires in %d hours. If it has expired, ask the gym to send a new one.</p>`,
+		html.EscapeString(name), html.EscapeString(link), hours)
+}
+
+// provisionAudit records which admin created an account through bulk provisioning.
+func provisionAudit(ctx context.Context, acct account.Account, m member.Member, input BulkProvisionInput, deps BulkProvisionDeps) {
+	metadata, _ := json.Marshal(map[string]string{"member_id": m.ID, "source": "bulk_provision"})
+	event := audit.NewEvent(input.Actor.AccountID, input.Actor.Email, input.Actor.Role, audit.CategoryAccount, audit.ActionCreate).
+		WithResource("account", acct.ID).
+		WithDescription(fmt.Sprintf("Provisioned account for %s", m.Name)).
+		WithRequest(input.Actor.IPAddress, input.Actor.UserAgent).
+		WithMetadata(string(metadata))
+	if err := deps.AuditStore.Save(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "auth_event", "event", "bulk_provision_audit_failed", "account_id", acct.ID, "error", err)
+	}
+}
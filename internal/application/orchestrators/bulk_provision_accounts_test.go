@@ -0,0 +1,176 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/account"
+	emailDomain "workshop/internal/domain/email"
+	"workshop/internal/domain/member"
+)
+
+type mockProvisionMemberStore struct {
+	members []member.Member
+}
+
+// List implements ProvisionMemberStore.
+// PRE: filter is valid
+// POST: returns every stored member in insertion order
+func (m *mockProvisionMemberStore) List(_ context.Context, _ memberStore.ListFilter) ([]member.Member, error) {
+	return m.members, nil
+}
+
+// Save implements ProvisionMemberStore.
+// PRE: value has an ID
+// POST: the member with the same ID is replaced
+func (m *mockProvisionMemberStore) Save(_ context.Context, value member.Member) error {
+	for i := range m.members {
+		if m.members[i].ID == value.ID {
+			m.members[i] = value
+		}
+	}
+	return nil
+}
+
+type mockProvisionAccountStore struct {
+	accounts map[string]account.Account // by email
+	tokens   []account.ActivationToken
+}
+
+// GetByEmail implements ProvisionAccountStore.
+// PRE: email is non-empty
+// POST: returns the account or an error if none exists
+func (m *mockProvisionAccountStore) GetByEmail(_ context.Context, email string) (account.Account, error) {
+	a, ok := m.accounts[email]
+	if !ok {
+		return account.Account{}, errors.New("not found")
+	}
+	return a, nil
+}
+
+// Save implements ProvisionAccountStore.
+// PRE: value has an email
+// POST: the account is stored by email
+func (m *mockProvisionAccountStore) Save(_ context.Context, value account.Account) error {
+	m.accounts[value.Email] = value
+	return nil
+}
+
+// SaveActivationToken implements ProvisionAccountStore.
+// PRE: token has an AccountID
+// POST: the token is appended
+func (m *mockProvisionAccountStore) SaveActivationToken(_ context.Context, token account.ActivationToken) error {
+	m.tokens = append(m.tokens, token)
+	return nil
+}
+
+func newProvisionDeps(members *mockProvisionMemberStore, accounts *mockProvisionAccountStore, emails *mockEmailStore, auditStore *mockBackfillAuditStore) BulkProvisionDeps {
+	n := 0
+	return BulkProvisionDeps{
+		MemberStore:  members,
+		AccountStore: accounts,
+		EmailStore:   emails,
+		AuditStore:   auditStore,
+		GenerateID:   func() string { n++; return fmt.Sprintf("id-%d", n) },
+		Now:          fixedNow,
+	}
+}
+
+func provisionMembers() *mockProvisionMemberStore {
+	return &mockProvisionMemberStore{members: []member.Member{
+		{ID: "m1", Name: "Alice", Email: "Alice@Test.com", Status: member.StatusActive},
+		{ID: "m2", Name: "Bob", Email: "bob@test.com", Status: member.StatusActive, AccountID: "acct-bob"},
+		{ID: "m3", Name: "Cara", Email: "coach@test.com", Status: member.StatusActive},
+		{ID: "m4", Name: "Dan", Email: "dan@test.com", Status: member.StatusArchived},
+		{ID: "m5", Name: "Eve Kid", Email: "parent@test.com", Status: member.StatusInactive},
+		{ID: "m6", Name: "Finn Kid", Email: "parent@test.com", Status: member.StatusActive},
+	}}
+}
+
+// TestExecuteBulkProvisionAccounts verifies accounts, tokens and queued emails for members without accounts.
+func TestExecuteBulkProvisionAccounts(t *testing.T) {
+	members := provisionMembers()
+	accounts := &mockProvisionAccountStore{accounts: map[string]account.Account{"coach@test.com": {ID: "acct-coach", Email: "coach@test.com"}}}
+	emails := newMockEmailStore()
+	auditStore := &mockBackfillAuditStore{}
+
+	result, err := ExecuteBulkProvisionAccounts(context.Background(), BulkProvisionInput{
+		ActivationBaseURL: "https://gym.example/",
+		Actor:             BackfillActor{AccountID: "admin-1", Email: "admin@test.com", Role: "admin"},
+	}, newProvisionDeps(members, accounts, emails, auditStore))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		memberID, status string
+	}{
+		{"m1", BulkSyncStatusCreated},
+		{"m3", ProvisionStatusSkipped},
+		{"m5", BulkSyncStatusCreated},
+		{"m6", ProvisionStatusSkipped},
+	}
+	if len(result.Results) != len(want) {
+		t.Fatalf("expected %d results, got %+v", len(want), result.Results)
+	}
+	for i, w := range want {
+		if got := result.Results[i]; got.MemberID != w.memberID || got.Status != w.status {
+			t.Errorf("result[%d] = %s %s (%q), want %s %s", i, got.MemberID, got.Status, got.Reason, w.memberID, w.status)
+		}
+	}
+	if result.Created != 2 || result.Skipped != 2 || result.Rejected != 0 {
+		t.Errorf("counts = created %d skipped %d rejected %d, want 2/2/0", result.Created, result.Skipped, result.Rejected)
+	}
+
+	alice := accounts.accounts["alice@test.com"]
+	if alice.Status != account.StatusPendingActivation || alice.Role != account.RoleMember {
+		t.Errorf("alice's account = %+v, want a pending member account", alice)
+	}
+	if members.members[0].AccountID != alice.ID {
+		t.Errorf("expected alice's member record linked to %s, got %q", alice.ID, members.members[0].AccountID)
+	}
+	if len(accounts.tokens) != 2 || !accounts.tokens[0].ExpiresAt.Equal(fixedNow().Add(account.ActivationTokenLifetime)) {
+		t.Errorf("expected 2 activation tokens expiring after %s, got %+v", account.ActivationTokenLifetime, accounts.tokens)
+	}
+	if len(auditStore.events) != 2 {
+		t.Errorf("expected 2 audit events, got %d", len(auditStore.events))
+	}
+
+	em := emails.emails[result.Results[0].EmailID]
+	if em.Status != emailDomain.StatusScheduled || !em.IsDue(fixedNow()) || em.SenderID != "admin-1" {
+		t.Errorf("activation email = %+v, want scheduled now by the admin", em)
+	}
+	if !strings.Contains(em.Body, "https://gym.example/activate?token="+accounts.tokens[0].Token) {
+		t.Errorf("activation email body missing link: %s", em.Body)
+	}
+	if r := emails.recipients[em.ID]; len(r) != 1 || r[0].MemberID != "m1" {
+		t.Errorf("expected alice as sole recipient, got %+v", r)
+	}
+}
+
+// TestExecuteBulkProvisionAccounts_DryRun verifies a dry run reports outcomes without writing.
+func TestExecuteBulkProvisionAccounts_DryRun(t *testing.T) {
+	members := provisionMembers()
+	accounts := &mockProvisionAccountStore{accounts: map[string]account.Account{}}
+	emails := newMockEmailStore()
+	auditStore := &mockBackfillAuditStore{}
+
+	result, err := ExecuteBulkProvisionAccounts(context.Background(), BulkProvisionInput{DryRun: true},
+		newProvisionDeps(members, accounts, emails, auditStore))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.DryRun || result.Created != 3 || result.Skipped != 1 {
+		t.Errorf("dry run = %+v, want 3 created and 1 skipped", result)
+	}
+	if len(accounts.accounts) != 0 || len(accounts.tokens) != 0 || len(emails.emails) != 0 || len(auditStore.events) != 0 {
+		t.Error("dry run must not write")
+	}
+	if members.members[0].AccountID != "" {
+		t.Error("dry run must not link members")
+	}
+}
@@ -0,0 +1,202 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"workshop/internal/domain/attendance"
+)
+
+// Bulk sync limits.
+const (
+	// MaxBulkSyncRecords caps a single sync batch so a stale kiosk cannot flood the server.
+	MaxBulkSyncRecords = 500
+	// MaxOfflineCheckInAge is how far back a queued check-in may be replayed.
+	MaxOfflineCheckInAge = 7 * 24 * time.Hour
+	// MaxClientClockSkew tolerates kiosk clocks running slightly ahead of the server.
+	MaxClientClockSkew = 5 * time.Minute
+)
+
+// Bulk sync record statuses reported back to the kiosk.
+const (
+	BulkSyncStatusCreated   = "created"
+	BulkSyncStatusDuplicate = "duplicate"
+	BulkSyncStatusRejected  = "rejected"
+)
+
+// ErrBulkSyncTooLarge is returned when a batch exceeds MaxBulkSyncRecords.
+var ErrBulkSyncTooLarge = errors.New("too many records in sync batch")
+
+// BulkSyncAttendanceStore defines the attendance store interface needed for bulk sync.
+type BulkSyncAttendanceStore interface {
+	Save(ctx context.Context, a attendance.Attendance) error
+	ListByMemberIDAndDate(ctx context.Context, memberID string, date string) ([]attendance.Attendance, error)
+	ListByMemberScheduleAndClassDate(ctx context.Context, memberID string, scheduleID string, classDate string) ([]attendance.Attendance, error)
+}
+
+// BulkSyncRecord is a single check-in captured while the kiosk was offline.
+type BulkSyncRecord struct {
+	ClientID    string    // kiosk-generated ID, echoed back so the client can clear its queue
+	MemberID    string    // selected from the cached search shortlist
+	ScheduleID  string    // optional
+	ClassDate   string    // optional: YYYY-MM-DD, defaults to the CheckInTime date
+	CheckInTime time.Time // client timestamp of the original check-in
+}
+
+// BulkSyncInput carries a batch of queued check-ins.
+type BulkSyncInput struct {
+	Records    []BulkSyncRecord
+	LocationID string // optional: location of the syncing kiosk
+}
+
+// BulkSyncRecordResult reports the outcome for one queued check-in.
+type BulkSyncRecordResult struct {
+	ClientID     string
+	Status       string // created, duplicate, rejected
+	AttendanceID string // new or existing attendance ID (empty when rejected)
+	Reason       string // conflict or rejection reason
+}
+
+// BulkSyncResult carries per-record outcomes in request order.
+type BulkSyncResult struct {
+	Results   []BulkSyncRecordResult
+	Created   int
+	Duplicate int
+	Rejected  int
+}
+
+// BulkSyncDeps holds dependencies for BulkSyncCheckIns.
+type BulkSyncDeps struct {
+	MemberStore     CheckInSearchStore
+	AttendanceStore BulkSyncAttendanceStore
+	ScheduleStore   ScheduleLookupStore       // optional: used to compute mat hours and location
+	TopicDeps       *LinkAttendanceTopicsDeps // optional: nil skips linking check-ins to rotor topics
+	PackDeps        *UseClassPackDeps         // optional: nil uses no class packs
+	GenerateID      func() string
+	Now             func() time.Time
+}
+
+// ExecuteBulkSyncCheckIns replays check-ins queued by an offline kiosk.
+// Records are de-duplicated on (member, schedule, date) against both stored
+// attendance and earlier records in the same batch.
+// PRE: len(input.Records) <= MaxBulkSyncRecords
+// POST: Each record is created, reported as a duplicate, or rejected with a reason
+// INVARIANT: A failure on one record never aborts the rest of the batch
+func ExecuteBulkSyncCheckIns(ctx context.Context, input BulkSyncInput, deps BulkSyncDeps) (BulkSyncResult, error) {
+	if len(input.Records) > MaxBulkSyncRecords {
+		return BulkSyncResult{}, ErrBulkSyncTooLarge
+	}
+
+	now := deps.Now()
+	seen := make(map[string]string) // dedupe key -> attendance ID
+	result := BulkSyncResult{Results: make([]BulkSyncRecordResult, 0, len(input.Records))}
+
+	for _, rec := range input.Records {
+		res := syncOneCheckIn(ctx, rec, input.LocationID, now, seen, deps)
+		switch res.Status {
+		case BulkSyncStatusCreated:
+			result.Created++
+		case BulkSyncStatusDuplicate:
+			result.Duplicate++
+		default:
+			result.Rejected++
+		}
+		result.Results = append(result.Results, res)
+	}
+
+	slog.InfoContext(ctx, "checkin_event", "event", "bulk_sync", "records", len(input.Records),
+		"created", result.Created, "duplicate", result.Duplicate, "rejected", result.Rejected,
+		"location_id", input.LocationID)
+	return result, nil
+}
+
+// syncOneCheckIn validates, de-duplicates and persists a single queued check-in.
+func syncOneCheckIn(ctx context.Context, rec BulkSyncRecord, locationID string, now time.Time, seen map[string]string, deps BulkSyncDeps) BulkSyncRecordResult {
+	reject := func(reason string) BulkSyncRecordResult {
+		return BulkSyncRecordResult{ClientID: rec.ClientID, Status: BulkSyncStatusRejected, Reason: reason}
+	}
+
+	if rec.MemberID == "" {
+		return reject("member is required")
+	}
+	if rec.CheckInTime.IsZero() {
+		return reject("check-in time is required")
+	}
+	if rec.CheckInTime.After(now.Add(MaxClientClockSkew)) {
+		return reject("check-in time is in the future")
+	}
+	if now.Sub(rec.CheckInTime) > MaxOfflineCheckInAge {
+		return reject("check-in is too old to sync")
+	}
+
+	classDate := rec.ClassDate
+	if classDate == "" {
+		classDate = rec.CheckInTime.Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", classDate); err != nil {
+		return reject("class date must be YYYY-MM-DD")
+	}
+
+	m, err := deps.MemberStore.GetByID(ctx, rec.MemberID)
+	if err != nil {
+		return reject("member not found")
+	}
+	if m.IsArchived() {
+		return reject("archived members cannot check in")
+	}
+	if m.IsFrozen() {
+		return reject("frozen members cannot check in")
+	}
+
+	key := rec.MemberID + "|" + rec.ScheduleID + "|" + classDate
+	if existingID, ok := seen[key]; ok {
+		return BulkSyncRecordResult{ClientID: rec.ClientID, Status: BulkSyncStatusDuplicate, AttendanceID: existingID, Reason: "duplicate within batch"}
+	}
+
+	// Keyed on the class date, not the check-in date: a check-in queued just after midnight
+	// can belong to the previous evening's class.
+	existing, err := deps.AttendanceStore.ListByMemberScheduleAndClassDate(ctx, rec.MemberID, rec.ScheduleID, classDate)
+	if err != nil {
+		return reject("could not check existing attendance")
+	}
+	if len(existing) > 0 {
+		seen[key] = existing[0].ID
+		return BulkSyncRecordResult{ClientID: rec.ClientID, Status: BulkSyncStatusDuplicate, AttendanceID: existing[0].ID, Reason: "already checked in"}
+	}
+
+	var matHours float64
+	recordLocation := locationID
+	if rec.ScheduleID != "" && deps.ScheduleStore != nil {
+		if sched, err := deps.ScheduleStore.GetByID(ctx, rec.ScheduleID); err == nil {
+			if dur, err := sched.DurationHours(); err == nil {
+				matHours = dur
+			}
+			if sched.LocationID != "" {
+				recordLocation = sched.LocationID
+			}
+		}
+	}
+
+	a := attendance.Attendance{
+		ID:          deps.GenerateID(),
+		MemberID:    rec.MemberID,
+		CheckInTime: rec.CheckInTime,
+		ScheduleID:  rec.ScheduleID,
+		ClassDate:   classDate,
+		MatHours:    matHours,
+		LocationID:  recordLocation,
+	}
+	if err := a.Validate(); err != nil {
+		return reject(err.Error())
+	}
+	if err := deps.AttendanceStore.Save(ctx, a); err != nil {
+		slog.ErrorContext(ctx, "checkin_event", "event", "bulk_sync_save_failed", "member_id", rec.MemberID, "error", err)
+		return reject("could not save check-in")
+	}
+	linkAttendanceTopics(ctx, a, deps.TopicDeps)
+	useClassPack(ctx, a, deps.PackDeps)
+
+	seen[key] = a.ID
+	return BulkSyncRecordResult{ClientID: rec.ClientID, Status: BulkSyncStatusCreated, AttendanceID: a.ID}
+}
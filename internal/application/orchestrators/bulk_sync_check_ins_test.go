@@ -0,0 +1,212 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/schedule"
+)
+
+// --- Mock stores for bulk sync tests ---
+
+type mockBulkSyncMemberStore struct {
+	members map[string]member.Member
+}
+
+// GetByID implements CheckInSearchStore.
+// PRE: id is non-empty
+// POST: returns member or error
+func (m *mockBulkSyncMemberStore) GetByID(_ context.Context, id string) (member.Member, error) {
+	mem, ok := m.members[id]
+	if !ok {
+		return member.Member{}, errors.New("not found")
+	}
+	return mem, nil
+}
+
+// SearchByName implements CheckInSearchStore.
+// PRE: none
+// POST: returns no members
+func (m *mockBulkSyncMemberStore) SearchByName(_ context.Context, _ string, _ int) ([]member.Member, error) {
+	return nil, nil
+}
+
+type mockBulkSyncAttendanceStore struct {
+	records []attendance.Attendance
+}
+
+// Save implements BulkSyncAttendanceStore.
+// PRE: a is valid
+// POST: a is appended
+func (m *mockBulkSyncAttendanceStore) Save(_ context.Context, a attendance.Attendance) error {
+	m.records = append(m.records, a)
+	return nil
+}
+
+// ListByMemberIDAndDate implements BulkSyncAttendanceStore.
+// PRE: memberID and date are non-empty
+// POST: returns records for the member on the date
+func (m *mockBulkSyncAttendanceStore) ListByMemberIDAndDate(_ context.Context, memberID string, date string) ([]attendance.Attendance, error) {
+	var out []attendance.Attendance
+	for _, a := range m.records {
+		if a.MemberID == memberID && a.CheckInTime.Format("2006-01-02") == date {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+// ListByMemberScheduleAndClassDate implements BulkSyncAttendanceStore.
+// PRE: memberID and classDate are non-empty
+// POST: returns the member's records for the class on its class date
+func (m *mockBulkSyncAttendanceStore) ListByMemberScheduleAndClassDate(_ context.Context, memberID string, scheduleID string, classDate string) ([]attendance.Attendance, error) {
+	var out []attendance.Attendance
+	for _, a := range m.records {
+		if a.MemberID == memberID && a.ScheduleID == scheduleID && a.Date() == classDate {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+type mockBulkSyncScheduleStore struct{}
+
+// GetByID implements ScheduleLookupStore.
+// PRE: id is non-empty
+// POST: returns a one-hour schedule at location "central"
+func (m *mockBulkSyncScheduleStore) GetByID(_ context.Context, id string) (schedule.Schedule, error) {
+	return schedule.Schedule{ID: id, ClassTypeID: "ct1", Day: schedule.Sunday, StartTime: "10:00", EndTime: "11:00", LocationID: "central"}, nil
+}
+
+func newBulkSyncDeps(store *mockBulkSyncAttendanceStore) BulkSyncDeps {
+	n := 0
+	return BulkSyncDeps{
+		MemberStore: &mockBulkSyncMemberStore{members: map[string]member.Member{
+			"m1": {ID: "m1", Name: "Alice", Status: member.StatusActive},
+			"m2": {ID: "m2", Name: "Bob", Status: member.StatusArchived},
+		}},
+		AttendanceStore: store,
+		ScheduleStore:   &mockBulkSyncScheduleStore{},
+		GenerateID: func() string {
+			n++
+			return fmt.Sprintf("att-%d", n)
+		},
+		Now: fixedNow,
+	}
+}
+
+// TestExecuteBulkSyncCheckIns_CreatesAndDedupes verifies batch de-duplication on (member, schedule, date).
+func TestExecuteBulkSyncCheckIns_CreatesAndDedupes(t *testing.T) {
+	store := &mockBulkSyncAttendanceStore{records: []attendance.Attendance{
+		{ID: "existing", MemberID: "m1", ScheduleID: "s-early", CheckInTime: fixedTime.Add(-3 * time.Hour)},
+	}}
+	checkIn := fixedTime.Add(-time.Hour)
+
+	result, err := ExecuteBulkSyncCheckIns(context.Background(), BulkSyncInput{
+		Records: []BulkSyncRecord{
+			{ClientID: "c1", MemberID: "m1", ScheduleID: "s1", CheckInTime: checkIn},
+			{ClientID: "c2", MemberID: "m1", ScheduleID: "s1", CheckInTime: checkIn.Add(time.Minute)},
+			{ClientID: "c3", MemberID: "m1", ScheduleID: "s-early", CheckInTime: checkIn},
+		},
+	}, newBulkSyncDeps(store))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		status, attendanceID string
+	}{
+		{BulkSyncStatusCreated, "att-1"},
+		{BulkSyncStatusDuplicate, "att-1"},
+		{BulkSyncStatusDuplicate, "existing"},
+	}
+	for i, w := range want {
+		got := result.Results[i]
+		if got.Status != w.status || got.AttendanceID != w.attendanceID {
+			t.Errorf("result[%d] = %s/%s, want %s/%s", i, got.Status, got.AttendanceID, w.status, w.attendanceID)
+		}
+	}
+	if result.Created != 1 || result.Duplicate != 2 {
+		t.Errorf("counts = created %d duplicate %d, want 1 and 2", result.Created, result.Duplicate)
+	}
+
+	saved := store.records[len(store.records)-1]
+	if !saved.CheckInTime.Equal(checkIn) {
+		t.Errorf("expected client timestamp to be kept, got %v", saved.CheckInTime)
+	}
+	if saved.MatHours != 1 || saved.LocationID != "central" {
+		t.Errorf("expected mat hours and location from schedule, got %v / %q", saved.MatHours, saved.LocationID)
+	}
+}
+
+// TestExecuteBulkSyncCheckIns_DedupesOnClassDate verifies a check-in is matched on the class
+// date, not the check-in date, so a late class that runs past midnight is not synced twice.
+func TestExecuteBulkSyncCheckIns_DedupesOnClassDate(t *testing.T) {
+	midnight := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	store := &mockBulkSyncAttendanceStore{records: []attendance.Attendance{
+		{ID: "late", MemberID: "m1", ScheduleID: "s-night", ClassDate: "2026-02-28", CheckInTime: midnight.Add(10 * time.Minute)},
+	}}
+
+	result, err := ExecuteBulkSyncCheckIns(context.Background(), BulkSyncInput{
+		Records: []BulkSyncRecord{
+			{ClientID: "c1", MemberID: "m1", ScheduleID: "s-night", ClassDate: "2026-02-28", CheckInTime: midnight.Add(-5 * time.Minute)},
+			{ClientID: "c2", MemberID: "m1", ScheduleID: "s-night", ClassDate: "2026-03-01", CheckInTime: midnight.Add(5 * time.Minute)},
+		},
+	}, newBulkSyncDeps(store))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.Results[0]; got.Status != BulkSyncStatusDuplicate || got.AttendanceID != "late" {
+		t.Errorf("same class date: got %s/%s, want duplicate of late", got.Status, got.AttendanceID)
+	}
+	if got := result.Results[1]; got.Status != BulkSyncStatusCreated {
+		t.Errorf("next day's class: got %s, want created", got.Status)
+	}
+	if len(store.records) != 2 {
+		t.Errorf("records = %d, want 2", len(store.records))
+	}
+}
+
+// TestExecuteBulkSyncCheckIns_Rejections verifies per-record rejection reasons.
+func TestExecuteBulkSyncCheckIns_Rejections(t *testing.T) {
+	tests := []struct {
+		name string
+		rec  BulkSyncRecord
+	}{
+		{"missing member", BulkSyncRecord{ClientID: "c", CheckInTime: fixedTime}},
+		{"unknown member", BulkSyncRecord{ClientID: "c", MemberID: "nobody", CheckInTime: fixedTime}},
+		{"archived member", BulkSyncRecord{ClientID: "c", MemberID: "m2", CheckInTime: fixedTime}},
+		{"future timestamp", BulkSyncRecord{ClientID: "c", MemberID: "m1", CheckInTime: fixedTime.Add(time.Hour)}},
+		{"too old", BulkSyncRecord{ClientID: "c", MemberID: "m1", CheckInTime: fixedTime.Add(-8 * 24 * time.Hour)}},
+		{"bad class date", BulkSyncRecord{ClientID: "c", MemberID: "m1", CheckInTime: fixedTime, ClassDate: "01/03/2026"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockBulkSyncAttendanceStore{}
+			result, err := ExecuteBulkSyncCheckIns(context.Background(), BulkSyncInput{Records: []BulkSyncRecord{tt.rec}}, newBulkSyncDeps(store))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Results[0].Status != BulkSyncStatusRejected || result.Results[0].Reason == "" {
+				t.Errorf("expected rejection with reason, got %+v", result.Results[0])
+			}
+			if len(store.records) != 0 {
+				t.Error("rejected record must not be saved")
+			}
+		})
+	}
+}
+
+// TestExecuteBulkSyncCheckIns_TooLarge verifies oversized batches are refused.
+func TestExecuteBulkSyncCheckIns_TooLarge(t *testing.T) {
+	records := make([]BulkSyncRecord, MaxBulkSyncRecords+1)
+	_, err := ExecuteBulkSyncCheckIns(context.Background(), BulkSyncInput{Records: records}, newBulkSyncDeps(&mockBulkSyncAttendanceStore{}))
+	if !errors.Is(err, ErrBulkSyncTooLarge) {
+		t.Errorf("expected ErrBulkSyncTooLarge, got %v", err)
+	}
+}
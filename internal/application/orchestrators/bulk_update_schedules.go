@@ -0,0 +1,147 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"workshop/internal/domain/schedule"
+)
+
+// ErrScheduleNotFound is returned when a bulk update names a class that does not exist.
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// BulkScheduleStore defines the schedule store interface needed for bulk updates.
+type BulkScheduleStore interface {
+	List(ctx context.Context) ([]schedule.Schedule, error)
+	SaveAll(ctx context.Context, values []schedule.Schedule) error
+}
+
+// ScheduleChange moves one class on the timetable.
+type ScheduleChange struct {
+	ID        string
+	Day       string
+	StartTime string
+	EndTime   string
+	Mat       string
+}
+
+// BulkUpdateSchedulesInput carries the changes made in one timetable edit.
+type BulkUpdateSchedulesInput struct {
+	Changes []ScheduleChange
+	ActorID string // AccountID of the admin, for the log
+}
+
+// BulkUpdateSchedulesDeps holds dependencies for BulkUpdateSchedules.
+type BulkUpdateSchedulesDeps struct {
+	ScheduleStore BulkScheduleStore
+}
+
+// ScheduleChangeError reports a change that leaves a class invalid.
+type ScheduleChangeError struct {
+	ScheduleID string
+	Err        error
+}
+
+// Error implements the error interface.
+// PRE: e.Err is set
+// POST: Returns the schedule ID and the validation message
+func (e *ScheduleChangeError) Error() string {
+	return fmt.Sprintf("schedule %s: %v", e.ScheduleID, e.Err)
+}
+
+// Unwrap returns the validation error.
+// PRE: none
+// POST: Returns e.Err
+func (e *ScheduleChangeError) Unwrap() error {
+	return e.Err
+}
+
+// ScheduleConflictError reports the classes a bulk update would leave overlapping.
+type ScheduleConflictError struct {
+	Conflicts map[string][]string // changed schedule ID -> IDs of the classes it overlaps
+}
+
+// Error implements the error interface.
+// PRE: none
+// POST: Returns the domain conflict message
+func (e *ScheduleConflictError) Error() string {
+	return schedule.ErrConflict.Error()
+}
+
+// Unwrap returns schedule.ErrConflict so callers can match it with errors.Is.
+// PRE: none
+// POST: Returns schedule.ErrConflict
+func (e *ScheduleConflictError) Unwrap() error {
+	return schedule.ErrConflict
+}
+
+// Fields returns the conflicts as one message per changed class, for API error responses.
+// PRE: none
+// POST: Keys are schedule IDs; values list the overlapping classes
+func (e *ScheduleConflictError) Fields() map[string]string {
+	fields := make(map[string]string, len(e.Conflicts))
+	for id, others := range e.Conflicts {
+		fields[id] = "overlaps " + strings.Join(others, ", ")
+	}
+	return fields
+}
+
+// ExecuteBulkUpdateSchedules applies a set of timetable moves together. Each moved class is
+// checked against the timetable as it will be after every move, so two classes can swap
+// slots in one update.
+// PRE: deps are non-nil; each change names an existing schedule at most once
+// POST: All changes are saved, or none are; returns the updated schedules in input order
+func ExecuteBulkUpdateSchedules(ctx context.Context, input BulkUpdateSchedulesInput, deps BulkUpdateSchedulesDeps) ([]schedule.Schedule, error) {
+	all, err := deps.ScheduleStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(all))
+	for i, s := range all {
+		index[s.ID] = i
+	}
+
+	updated := make([]schedule.Schedule, 0, len(input.Changes))
+	for _, c := range input.Changes {
+		i, ok := index[c.ID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrScheduleNotFound, c.ID)
+		}
+		s := all[i]
+		s.Day = strings.ToLower(c.Day)
+		s.StartTime = c.StartTime
+		s.EndTime = c.EndTime
+		s.Mat = strings.TrimSpace(c.Mat)
+		if err := s.Validate(); err != nil {
+			return nil, &ScheduleChangeError{ScheduleID: s.ID, Err: err}
+		}
+		all[i] = s
+		updated = append(updated, s)
+	}
+
+	conflicts := map[string][]string{}
+	for _, s := range updated {
+		for _, o := range schedule.Conflicts(s, all) {
+			conflicts[s.ID] = append(conflicts[s.ID], o.ID)
+		}
+	}
+	if len(conflicts) > 0 {
+		for _, ids := range conflicts {
+			sort.Strings(ids)
+		}
+		return nil, &ScheduleConflictError{Conflicts: conflicts}
+	}
+
+	if len(updated) == 0 {
+		return updated, nil
+	}
+	if err := deps.ScheduleStore.SaveAll(ctx, updated); err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "schedule_event", "event", "timetable_updated", "count", len(updated), "actor_id", input.ActorID)
+	return updated, nil
+}
@@ -0,0 +1,132 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"workshop/internal/domain/schedule"
+)
+
+// --- Mock store for bulk schedule update tests ---
+
+type mockBulkScheduleStore struct {
+	schedules []schedule.Schedule
+	saves     int
+}
+
+// List returns all schedules.
+// PRE: none
+// POST: Returns a copy of the schedules
+func (m *mockBulkScheduleStore) List(_ context.Context) ([]schedule.Schedule, error) {
+	return append([]schedule.Schedule(nil), m.schedules...), nil
+}
+
+// SaveAll replaces the given schedules.
+// PRE: each value has an ID
+// POST: The schedules are stored
+func (m *mockBulkScheduleStore) SaveAll(_ context.Context, values []schedule.Schedule) error {
+	m.saves++
+	for _, v := range values {
+		for i := range m.schedules {
+			if m.schedules[i].ID == v.ID {
+				m.schedules[i] = v
+			}
+		}
+	}
+	return nil
+}
+
+// TestExecuteBulkUpdateSchedules verifies moves are checked against the timetable as it will
+// be after the whole update, and that nothing is saved when any move is rejected.
+func TestExecuteBulkUpdateSchedules(t *testing.T) {
+	base := func() []schedule.Schedule {
+		return []schedule.Schedule{
+			{ID: "a", ClassTypeID: "gi", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:00"},
+			{ID: "b", ClassTypeID: "nogi", Day: schedule.Monday, StartTime: "19:00", EndTime: "20:00"},
+			{ID: "c", ClassTypeID: "kids", Day: schedule.Tuesday, StartTime: "16:00", EndTime: "17:00", Mat: "Mat 1"},
+		}
+	}
+	tests := []struct {
+		name      string
+		changes   []ScheduleChange
+		wantErr   error
+		wantSaves int
+	}{
+		{
+			name:      "move to a free slot",
+			changes:   []ScheduleChange{{ID: "a", Day: "Wednesday", StartTime: "18:00", EndTime: "19:00"}},
+			wantSaves: 1,
+		},
+		{
+			name: "swap two classes",
+			changes: []ScheduleChange{
+				{ID: "a", Day: schedule.Monday, StartTime: "19:00", EndTime: "20:00"},
+				{ID: "b", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:00"},
+			},
+			wantSaves: 1,
+		},
+		{
+			name:      "onto another mat beside a class",
+			changes:   []ScheduleChange{{ID: "a", Day: schedule.Tuesday, StartTime: "16:00", EndTime: "17:00", Mat: "Mat 2"}},
+			wantSaves: 1,
+		},
+		{
+			name:    "onto an occupied slot",
+			changes: []ScheduleChange{{ID: "a", Day: schedule.Monday, StartTime: "19:30", EndTime: "20:30"}},
+			wantErr: schedule.ErrConflict,
+		},
+		{
+			name: "valid move alongside a conflicting one",
+			changes: []ScheduleChange{
+				{ID: "a", Day: schedule.Friday, StartTime: "18:00", EndTime: "19:00"},
+				{ID: "b", Day: schedule.Tuesday, StartTime: "16:30", EndTime: "17:30"},
+			},
+			wantErr: schedule.ErrConflict,
+		},
+		{
+			name:    "invalid time",
+			changes: []ScheduleChange{{ID: "a", Day: schedule.Monday, StartTime: "6pm", EndTime: "19:00"}},
+			wantErr: schedule.ErrInvalidTime,
+		},
+		{
+			name:    "unknown class",
+			changes: []ScheduleChange{{ID: "zzz", Day: schedule.Monday, StartTime: "06:00", EndTime: "07:00"}},
+			wantErr: ErrScheduleNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockBulkScheduleStore{schedules: base()}
+			updated, err := ExecuteBulkUpdateSchedules(context.Background(), BulkUpdateSchedulesInput{Changes: tt.changes}, BulkUpdateSchedulesDeps{ScheduleStore: store})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if store.saves != tt.wantSaves {
+				t.Errorf("saves = %d, want %d", store.saves, tt.wantSaves)
+			}
+			if tt.wantErr == nil && len(updated) != len(tt.changes) {
+				t.Errorf("expected %d updated schedules, got %d", len(tt.changes), len(updated))
+			}
+		})
+	}
+}
+
+// TestScheduleConflictError_Fields verifies a conflict names every class the moved one overlaps.
+func TestScheduleConflictError_Fields(t *testing.T) {
+	store := &mockBulkScheduleStore{schedules: []schedule.Schedule{
+		{ID: "a", ClassTypeID: "gi", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:00"},
+		{ID: "b", ClassTypeID: "gi", Day: schedule.Monday, StartTime: "19:00", EndTime: "20:00"},
+		{ID: "c", ClassTypeID: "gi", Day: schedule.Monday, StartTime: "20:00", EndTime: "21:00"},
+	}}
+	_, err := ExecuteBulkUpdateSchedules(context.Background(), BulkUpdateSchedulesInput{
+		Changes: []ScheduleChange{{ID: "c", Day: schedule.Monday, StartTime: "18:30", EndTime: "19:30"}},
+	}, BulkUpdateSchedulesDeps{ScheduleStore: store})
+	var ce *ScheduleConflictError
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected a ScheduleConflictError, got %v", err)
+	}
+	if got := ce.Fields()["c"]; got != "overlaps a, b" {
+		t.Errorf("Fields()[c] = %q", got)
+	}
+}
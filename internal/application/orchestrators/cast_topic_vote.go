@@ -0,0 +1,94 @@
+package orchestrators
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"workshop/internal/domain/rotor"
+)
+
+// CastTopicVoteStore defines the rotor store interface needed to cast topic votes.
+type CastTopicVoteStore interface {
+	GetTopic(ctx context.Context, id string) (rotor.Topic, error)
+	GetRotorTheme(ctx context.Context, id string) (rotor.RotorTheme, error)
+	GetRotor(ctx context.Context, id string) (rotor.Rotor, error)
+	ListVotesByAccount(ctx context.Context, accountID string) ([]rotor.Vote, error)
+	SaveVote(ctx context.Context, v rotor.Vote) error
+	DeleteVote(ctx context.Context, id string) error
+	CountVotesForTopic(ctx context.Context, topicID string, now time.Time) (int, error)
+}
+
+// CastTopicVoteInput carries input for the cast topic vote orchestrator.
+type CastTopicVoteInput struct {
+	TopicID   string
+	AccountID string
+	Staff     bool // coaches and admins; their votes carry the rotor's coach weight
+}
+
+// CastTopicVoteDeps holds dependencies for ExecuteCastTopicVote.
+type CastTopicVoteDeps struct {
+	RotorStore CastTopicVoteStore
+	GenerateID func() string
+	Now        func() time.Time
+}
+
+// CastTopicVoteResult reports the vote cast and the topic's new total.
+type CastTopicVoteResult struct {
+	Vote     rotor.Vote
+	Votes    int      // the topic's weighted total of active votes
+	Replaced []string // topics whose votes this one replaced under the one-per-theme rule
+}
+
+// ExecuteCastTopicVote casts an account's vote for a topic under its rotor's vote rules.
+// An expired vote for the same topic is cleared so the member can vote again, and with
+// OnePerTheme the account's other active votes in the theme are withdrawn.
+// PRE: TopicID and AccountID are non-empty
+// POST: The vote is saved; rotor.ErrAlreadyVoted if the account has an active vote for the topic
+func ExecuteCastTopicVote(ctx context.Context, input CastTopicVoteInput, deps CastTopicVoteDeps) (CastTopicVoteResult, error) {
+	var result CastTopicVoteResult
+	now := deps.Now()
+
+	topic, err := deps.RotorStore.GetTopic(ctx, input.TopicID)
+	if err != nil {
+		return result, fmt.Errorf("topic: %w", err)
+	}
+	theme, err := deps.RotorStore.GetRotorTheme(ctx, topic.RotorThemeID)
+	if err != nil {
+		return result, fmt.Errorf("theme: %w", err)
+	}
+	r, err := deps.RotorStore.GetRotor(ctx, theme.RotorID)
+	if err != nil {
+		return result, fmt.Errorf("rotor: %w", err)
+	}
+	rules := r.VoteRules
+
+	existing, err := deps.RotorStore.ListVotesByAccount(ctx, input.AccountID)
+	if err != nil {
+		return result, err
+	}
+	var withdraw []rotor.Vote
+	for _, v := range existing {
+		switch {
+		case v.TopicID == topic.ID && v.IsActive(now):
+			return result, rotor.ErrAlreadyVoted
+		case v.TopicID == topic.ID:
+			withdraw = append(withdraw, v)
+		case rules.OnePerTheme && v.RotorThemeID == topic.RotorThemeID && v.IsActive(now):
+			withdraw = append(withdraw, v)
+			result.Replaced = append(result.Replaced, v.TopicID)
+		}
+	}
+	for _, v := range withdraw {
+		if err := deps.RotorStore.DeleteVote(ctx, v.ID); err != nil {
+			return result, err
+		}
+	}
+
+	result.Vote = rules.NewVote(deps.GenerateID(), topic, input.AccountID, input.Staff, now)
+	if err := deps.RotorStore.SaveVote(ctx, result.Vote); err != nil {
+		return result, err
+	}
+	result.Votes, err = deps.RotorStore.CountVotesForTopic(ctx, topic.ID, now)
+	return result, err
+}
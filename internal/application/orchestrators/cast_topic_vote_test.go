@@ -0,0 +1,145 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/rotor"
+)
+
+type memVoteStore struct {
+	rotor  rotor.Rotor
+	themes map[string]rotor.RotorTheme
+	topics map[string]rotor.Topic
+	votes  []rotor.Vote
+}
+
+// GetTopic returns a topic by ID.
+// PRE: id is non-empty
+// POST: Returns the topic or an error
+func (m *memVoteStore) GetTopic(_ context.Context, id string) (rotor.Topic, error) {
+	if t, ok := m.topics[id]; ok {
+		return t, nil
+	}
+	return rotor.Topic{}, errors.New("topic not found")
+}
+
+// GetRotorTheme returns a theme by ID.
+// PRE: id is non-empty
+// POST: Returns the theme or an error
+func (m *memVoteStore) GetRotorTheme(_ context.Context, id string) (rotor.RotorTheme, error) {
+	if t, ok := m.themes[id]; ok {
+		return t, nil
+	}
+	return rotor.RotorTheme{}, errors.New("theme not found")
+}
+
+// GetRotor returns the store's rotor.
+// PRE: id is non-empty
+// POST: Returns the rotor
+func (m *memVoteStore) GetRotor(_ context.Context, _ string) (rotor.Rotor, error) {
+	return m.rotor, nil
+}
+
+// ListVotesByAccount returns an account's votes.
+// PRE: accountID is non-empty
+// POST: Returns the account's votes
+func (m *memVoteStore) ListVotesByAccount(_ context.Context, accountID string) ([]rotor.Vote, error) {
+	var result []rotor.Vote
+	for _, v := range m.votes {
+		if v.AccountID == accountID {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+// SaveVote appends a vote.
+// PRE: v is valid
+// POST: The vote is stored
+func (m *memVoteStore) SaveVote(_ context.Context, v rotor.Vote) error {
+	m.votes = append(m.votes, v)
+	return nil
+}
+
+// DeleteVote removes a vote.
+// PRE: id is non-empty
+// POST: The vote is gone
+func (m *memVoteStore) DeleteVote(_ context.Context, id string) error {
+	for i, v := range m.votes {
+		if v.ID == id {
+			m.votes = append(m.votes[:i], m.votes[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// CountVotesForTopic sums the weights of a topic's active votes.
+// PRE: topicID is non-empty
+// POST: Returns the weighted total
+func (m *memVoteStore) CountVotesForTopic(_ context.Context, topicID string, now time.Time) (int, error) {
+	total := 0
+	for _, v := range m.votes {
+		if v.TopicID == topicID && v.IsActive(now) {
+			total += v.Weight
+		}
+	}
+	return total, nil
+}
+
+// TestExecuteCastTopicVote verifies the one-vote-per-theme rule moves a member's vote, expired
+// votes can be cast again, and coach votes carry their weight.
+func TestExecuteCastTopicVote(t *testing.T) {
+	store := &memVoteStore{
+		rotor:  rotor.Rotor{ID: "r1", VoteRules: rotor.VoteRules{OnePerTheme: true, ExpiryWeeks: 2, CoachWeight: 3}},
+		themes: map[string]rotor.RotorTheme{"guard": {ID: "guard", RotorID: "r1"}, "passing": {ID: "passing", RotorID: "r1"}},
+		topics: map[string]rotor.Topic{
+			"dlr":       {ID: "dlr", RotorThemeID: "guard"},
+			"closed":    {ID: "closed", RotorThemeID: "guard"},
+			"torreando": {ID: "torreando", RotorThemeID: "passing"},
+		},
+	}
+	now := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	n := 0
+	deps := CastTopicVoteDeps{
+		RotorStore: store,
+		GenerateID: func() string { n++; return fmt.Sprintf("v%d", n) },
+		Now:        func() time.Time { return now },
+	}
+	ctx := context.Background()
+	cast := func(topicID, accountID string, staff bool) (CastTopicVoteResult, error) {
+		return ExecuteCastTopicVote(ctx, CastTopicVoteInput{TopicID: topicID, AccountID: accountID, Staff: staff}, deps)
+	}
+
+	if _, err := cast("dlr", "m1", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cast("torreando", "m1", false); err != nil {
+		t.Fatal(err)
+	}
+	result, err := cast("closed", "m1", false)
+	if err != nil || len(result.Replaced) != 1 || result.Replaced[0] != "dlr" {
+		t.Fatalf("second guard vote = %+v, %v; want it to replace the DLR vote", result, err)
+	}
+	if mine, _ := store.ListVotesByAccount(ctx, "m1"); len(mine) != 2 {
+		t.Errorf("member has %d votes, want one per theme", len(mine))
+	}
+	if _, err := cast("closed", "m1", false); !errors.Is(err, rotor.ErrAlreadyVoted) {
+		t.Errorf("duplicate vote err = %v, want ErrAlreadyVoted", err)
+	}
+
+	result, err = cast("closed", "coach", true)
+	if err != nil || result.Vote.Weight != 3 || result.Votes != 4 {
+		t.Fatalf("coach vote = %+v, %v; want weight 3 and a total of 4", result, err)
+	}
+
+	now = now.AddDate(0, 0, 14)
+	result, err = cast("closed", "m1", false)
+	if err != nil || result.Votes != 1 {
+		t.Fatalf("vote after expiry = %+v, %v; want the expired votes gone from the total", result, err)
+	}
+}
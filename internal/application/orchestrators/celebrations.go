@@ -0,0 +1,175 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/celebration"
+	emailDomain "workshop/internal/domain/email"
+	"workshop/internal/domain/notice"
+)
+
+// CelebrationNoticeIDPrefix starts the ID of each day's celebration notice; the date follows,
+// so a second run on the same day finds the notice already published.
+const CelebrationNoticeIDPrefix = "celebrations-"
+
+// FindCelebrations lists the celebrations falling on one day.
+type FindCelebrations func(ctx context.Context, day time.Time) ([]celebration.Celebration, error)
+
+// PublishCelebrationNoticeDeps holds dependencies for PublishCelebrationNotice.
+type PublishCelebrationNoticeDeps struct {
+	NoticeStore      NoticeStoreForOrchestrator
+	FindCelebrations FindCelebrations
+	Now              func() time.Time
+}
+
+// ExecutePublishCelebrationNotice publishes one school-wide notice naming today's birthdays,
+// training anniversaries and class milestones, visible until the day ends. Nothing is
+// published on a day without celebrations, or when today's notice already exists.
+// PRE: deps are complete
+// POST: Returns the published notice and true, or false when there was nothing to publish
+func ExecutePublishCelebrationNotice(ctx context.Context, deps PublishCelebrationNoticeDeps) (notice.Notice, bool, error) {
+	now := deps.Now()
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	id := CelebrationNoticeIDPrefix + today.Format("2006-01-02")
+	if _, err := deps.NoticeStore.GetByID(ctx, id); err == nil {
+		return notice.Notice{}, false, nil
+	}
+	list, err := deps.FindCelebrations(ctx, today)
+	if err != nil || len(list) == 0 {
+		return notice.Notice{}, false, err
+	}
+
+	lines := make([]string, 0, len(list))
+	for _, c := range list {
+		lines = append(lines, "- "+celebrationLine(c))
+	}
+	n := notice.Notice{
+		ID:           id,
+		Type:         notice.TypeSchoolWide,
+		Status:       notice.StatusDraft,
+		Title:        "Celebrating today",
+		Content:      truncateNoticeContent(strings.Join(lines, "\n")),
+		CreatedBy:    "system",
+		Color:        notice.ColorPurple,
+		VisibleUntil: today.AddDate(0, 0, 1).Add(-time.Second),
+		CreatedAt:    now,
+	}
+	if err := n.Validate(); err != nil {
+		return notice.Notice{}, false, err
+	}
+	if err := n.Publish("system", now); err != nil {
+		return notice.Notice{}, false, err
+	}
+	if err := deps.NoticeStore.Save(ctx, n); err != nil {
+		return notice.Notice{}, false, err
+	}
+	slog.InfoContext(ctx, "notice_event", "event", "celebration_notice_published", "notice_id", n.ID, "celebrations", len(list))
+	return n, true, nil
+}
+
+// celebrationLine words one celebration for the notice board.
+func celebrationLine(c celebration.Celebration) string {
+	switch c.Kind {
+	case celebration.KindBirthday:
+		return "Happy birthday, " + c.MemberName + "!"
+	case celebration.KindAnniversary:
+		return c.MemberName + " celebrates " + milestoneText(c)
+	}
+	return c.MemberName + " reached " + milestoneText(c)
+}
+
+// milestoneText names a training milestone in a sentence, e.g. "1 year of training" or "100 classes".
+func milestoneText(c celebration.Celebration) string {
+	if c.Kind == celebration.KindAnniversary {
+		return strings.TrimSuffix(c.Label(), " training") + " of training"
+	}
+	return c.Label()
+}
+
+// truncateNoticeContent keeps whole lines within notice.MaxContentLength.
+func truncateNoticeContent(content string) string {
+	for len(content) > notice.MaxContentLength {
+		cut := strings.LastIndex(content, "\n")
+		if cut < 0 {
+			return content[:notice.MaxContentLength]
+		}
+		content = content[:cut]
+	}
+	return content
+}
+
+// CelebrationEmailStore records which celebrations were emailed, so each goes out once.
+type CelebrationEmailStore interface {
+	MarkEmailSent(ctx context.Context, memberID, kind, date string, sentAt time.Time) (bool, error)
+}
+
+// SendCelebrationEmailsDeps holds dependencies for SendCelebrationEmails.
+type SendCelebrationEmailsDeps struct {
+	Store            CelebrationEmailStore
+	FindCelebrations FindCelebrations
+	NextClassDate    func(ctx context.Context, memberID string) string // optional: nil leaves {{NextClassDate}} blank
+	Send             SendTemplatedEmailDeps
+	Now              func() time.Time
+}
+
+// SendCelebrationEmailsResult summarises one run.
+type SendCelebrationEmailsResult struct {
+	Sent    int // emails sent
+	Skipped int // emails not sent: no address, suppressed address or unsubscribed
+}
+
+// ExecuteSendCelebrationEmails emails each member celebrating today: the birthday template on
+// their birthday, and the training milestone template on an anniversary or class milestone.
+// Each celebration is recorded before its email goes out, so a failed send is logged rather
+// than retried and a rerun never sends twice.
+// PRE: deps are complete
+// POST: Every celebration not yet emailed is sent or skipped; failures are joined
+func ExecuteSendCelebrationEmails(ctx context.Context, deps SendCelebrationEmailsDeps) (SendCelebrationEmailsResult, error) {
+	now := deps.Now()
+	y, m, d := now.Date()
+	var result SendCelebrationEmailsResult
+	list, err := deps.FindCelebrations(ctx, time.Date(y, m, d, 0, 0, 0, 0, now.Location()))
+	if err != nil {
+		return result, err
+	}
+
+	var errs []error
+	for _, c := range list {
+		first, err := deps.Store.MarkEmailSent(ctx, c.MemberID, c.Kind, c.Date, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !first {
+			continue
+		}
+		input := SendTemplatedEmailInput{TemplateKey: emailDomain.TemplateTrainingMilestone, MemberID: c.MemberID, Vars: emailDomain.Vars{emailDomain.VarMilestone: milestoneText(c)}}
+		if c.Kind == celebration.KindBirthday {
+			input = SendTemplatedEmailInput{TemplateKey: emailDomain.TemplateBirthday, MemberID: c.MemberID, Vars: emailDomain.Vars{}}
+		}
+		if deps.NextClassDate != nil {
+			input.Vars[emailDomain.VarNextClassDate] = deps.NextClassDate(ctx, c.MemberID)
+		}
+		res, err := ExecuteSendTemplatedEmail(ctx, input, deps.Send)
+		if err != nil {
+			slog.WarnContext(ctx, "email_event", "event", "celebration_email_failed", "member_id", c.MemberID, "kind", c.Kind, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		if res.Skipped != "" {
+			result.Skipped++
+		} else {
+			result.Sent++
+		}
+	}
+
+	if result.Sent+result.Skipped > 0 {
+		slog.InfoContext(ctx, "email_event", "event", "celebration_emails_sent", "sent", result.Sent, "skipped", result.Skipped)
+	}
+	return result, errors.Join(errs...)
+}
@@ -0,0 +1,123 @@
+package orchestrators
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/celebration"
+	emailDomain "workshop/internal/domain/email"
+	"workshop/internal/domain/notice"
+)
+
+type mockCelebrationEmailStore struct {
+	sent map[string]bool // member ID + kind + date
+}
+
+// MarkEmailSent implements CelebrationEmailStore.
+// PRE: memberID, kind and date are non-empty
+// POST: returns true the first time a celebration is marked
+func (m *mockCelebrationEmailStore) MarkEmailSent(_ context.Context, memberID, kind, date string, _ time.Time) (bool, error) {
+	key := memberID + "/" + kind + "/" + date
+	if m.sent[key] {
+		return false, nil
+	}
+	m.sent[key] = true
+	return true, nil
+}
+
+// todaysCelebrations finds the same celebrations whatever the day, recording the days asked for.
+func todaysCelebrations(days *[]string, list ...celebration.Celebration) FindCelebrations {
+	return func(_ context.Context, day time.Time) ([]celebration.Celebration, error) {
+		*days = append(*days, day.Format("2006-01-02"))
+		return list, nil
+	}
+}
+
+// TestPublishCelebrationNotice tests that one notice per day lists today's celebrations and
+// expires at the end of the day, and that a day without celebrations publishes nothing.
+func TestPublishCelebrationNotice(t *testing.T) {
+	store := newMockNoticeStore()
+	var days []string
+	deps := PublishCelebrationNoticeDeps{
+		NoticeStore: store,
+		FindCelebrations: todaysCelebrations(&days,
+			celebration.Celebration{MemberID: "member-1", MemberName: "Ana", Kind: celebration.KindBirthday, Date: "2026-03-01"},
+			celebration.Celebration{MemberID: "member-2", MemberName: "Ben", Kind: celebration.KindAnniversary, Date: "2026-03-01", Years: 1},
+			celebration.Celebration{MemberID: "member-3", MemberName: "Cal", Kind: celebration.KindClasses, Date: "2026-03-01", Classes: 100},
+		),
+		Now: fixedNow,
+	}
+
+	n, published, err := ExecutePublishCelebrationNotice(context.Background(), deps)
+	if err != nil || !published {
+		t.Fatalf("got published=%v, err=%v; want a notice", published, err)
+	}
+	if n.ID != "celebrations-2026-03-01" || n.Status != notice.StatusPublished || n.Type != notice.TypeSchoolWide {
+		t.Errorf("notice = %+v", n)
+	}
+	want := "- Happy birthday, Ana!\n- Ben celebrates 1 year of training\n- Cal reached 100 classes"
+	if n.Content != want {
+		t.Errorf("content = %q, want %q", n.Content, want)
+	}
+	if until := time.Date(2026, 3, 1, 23, 59, 59, 0, time.UTC); !n.VisibleUntil.Equal(until) {
+		t.Errorf("visible until %v, want %v", n.VisibleUntil, until)
+	}
+	if len(days) != 1 || days[0] != "2026-03-01" {
+		t.Errorf("looked up days %v, want today", days)
+	}
+
+	// A second run the same day finds the notice and does nothing
+	if _, published, err := ExecutePublishCelebrationNotice(context.Background(), deps); err != nil || published || len(days) != 1 {
+		t.Errorf("rerun: published=%v, err=%v, lookups=%d; want nothing", published, err, len(days))
+	}
+
+	empty := PublishCelebrationNoticeDeps{NoticeStore: newMockNoticeStore(), FindCelebrations: todaysCelebrations(&days), Now: fixedNow}
+	if _, published, err := ExecutePublishCelebrationNotice(context.Background(), empty); err != nil || published {
+		t.Errorf("no celebrations: published=%v, err=%v; want nothing", published, err)
+	}
+}
+
+// TestSendCelebrationEmails tests that each celebration is emailed once with its template.
+func TestSendCelebrationEmails(t *testing.T) {
+	emailStore := newMockEmailStore()
+	sender := newMockEmailSender()
+	var days []string
+	deps := SendCelebrationEmailsDeps{
+		Store: &mockCelebrationEmailStore{sent: map[string]bool{}},
+		FindCelebrations: todaysCelebrations(&days,
+			celebration.Celebration{MemberID: "member-1", MemberName: "Marcus", Kind: celebration.KindBirthday, Date: "2026-03-01"},
+			celebration.Celebration{MemberID: "member-2", MemberName: "Yuki", Kind: celebration.KindClasses, Date: "2026-03-01", Classes: 200},
+		),
+		NextClassDate: func(_ context.Context, memberID string) string { return "Monday (" + memberID + ")" },
+		Send:          newTemplatedEmailDeps(emailStore, sender),
+		Now:           fixedNow,
+	}
+
+	got, err := ExecuteSendCelebrationEmails(context.Background(), deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Sent != 2 || sender.sent != 2 {
+		t.Fatalf("result = %+v with %d sends, want 2", got, sender.sent)
+	}
+	templates := map[string]bool{}
+	for _, e := range emailStore.emails {
+		templates[e.TemplateKey] = true
+	}
+	if !templates[emailDomain.TemplateBirthday] || !templates[emailDomain.TemplateTrainingMilestone] {
+		t.Errorf("templates = %v, want a birthday and a training milestone", templates)
+	}
+	if subject := sender.sentReqs[1].Subject; !strings.Contains(subject, "200 classes") {
+		t.Errorf("milestone subject = %q, want the milestone", subject)
+	}
+	if html := sender.sentReqs[1].HTML; !strings.Contains(html, "Monday (member-2)") {
+		t.Errorf("milestone body missing the next class: %s", html)
+	}
+
+	// A rerun the same day sends nothing more
+	if got, _ := ExecuteSendCelebrationEmails(context.Background(), deps); got.Sent != 0 || sender.sent != 2 {
+		t.Errorf("rerun: got %+v with %d sends, want no more", got, sender.sent)
+	}
+}
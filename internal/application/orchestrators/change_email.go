@@ -0,0 +1,159 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"time"
+
+	emailAdapter "workshop/internal/adapters/email"
+	accountStore "workshop/internal/adapters/storage/account"
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/member"
+)
+
+// ErrEmailChangeFieldsRequired is returned when the password or new address is missing.
+var ErrEmailChangeFieldsRequired = errors.New("password and new email are required")
+
+// EmailChangeAccountStore defines the account store interface needed to change an email.
+type EmailChangeAccountStore interface {
+	GetByID(ctx context.Context, id string) (account.Account, error)
+	GetByEmail(ctx context.Context, email string) (account.Account, error)
+}
+
+// EmailChangeMemberStore defines the member store interface needed to change an email.
+type EmailChangeMemberStore interface {
+	GetByEmail(ctx context.Context, email string) (member.Member, error)
+}
+
+// EmailChangeStore defines the store interface that records and applies email changes.
+type EmailChangeStore interface {
+	SaveEmailChange(ctx context.Context, value account.EmailChange) error
+	GetEmailChangeByToken(ctx context.Context, token string) (account.EmailChange, error)
+	ApplyEmailChange(ctx context.Context, value account.EmailChange, confirmedAt time.Time) (accountStore.EmailChangeApplied, error)
+}
+
+// RequestEmailChangeInput carries input for the request email change orchestrator.
+type RequestEmailChangeInput struct {
+	AccountID string
+	Password  string // the account's current password, confirming it is really them
+	NewEmail  string
+}
+
+// ChangeEmailDeps holds dependencies for requesting and confirming email changes.
+type ChangeEmailDeps struct {
+	AccountStore  EmailChangeAccountStore
+	MemberStore   EmailChangeMemberStore
+	ChangeStore   EmailChangeStore
+	EmailSender   emailAdapter.Sender
+	FromAddress   string
+	ReplyTo       string
+	BaseURL       string // public site URL for the confirmation link
+	GenerateID    func() string
+	GenerateToken func() string
+	Now           func() time.Time
+}
+
+// ExecuteRequestEmailChange checks the account's password and emails a confirmation link to
+// the new address. Nothing changes until the link is confirmed.
+// PRE: AccountID is the signed-in account; EmailSender is configured
+// POST: A pending change is saved and its link sent; ErrCurrentPasswordWrong, account.ErrSameEmail
+// or account.ErrEmailTaken otherwise
+func ExecuteRequestEmailChange(ctx context.Context, input RequestEmailChangeInput, deps ChangeEmailDeps) (account.EmailChange, error) {
+	if input.Password == "" || input.NewEmail == "" {
+		return account.EmailChange{}, ErrEmailChangeFieldsRequired
+	}
+	acct, err := deps.AccountStore.GetByID(ctx, input.AccountID)
+	if err != nil {
+		return account.EmailChange{}, err
+	}
+	if err := acct.CheckPassword(input.Password); err != nil {
+		slog.WarnContext(ctx, "auth_event", "event", "email_change_wrong_password", "account_id", acct.ID)
+		return account.EmailChange{}, ErrCurrentPasswordWrong
+	}
+
+	now := deps.Now()
+	change, err := account.NewEmailChange(acct, input.NewEmail, deps.GenerateID(), deps.GenerateToken(), now)
+	if err != nil {
+		return account.EmailChange{}, err
+	}
+	if other, err := deps.AccountStore.GetByEmail(ctx, change.NewEmail); err == nil && other.ID != acct.ID {
+		return account.EmailChange{}, account.ErrEmailTaken
+	}
+	if m, err := deps.MemberStore.GetByEmail(ctx, change.NewEmail); err == nil && m.AccountID != acct.ID {
+		return account.EmailChange{}, account.ErrEmailTaken
+	}
+	if err := deps.ChangeStore.SaveEmailChange(ctx, change); err != nil {
+		return account.EmailChange{}, err
+	}
+
+	link := deps.BaseURL + "/account/email/confirm?token=" + change.Token
+	hours := int(account.EmailChangeLifetime.Hours())
+	if _, err := deps.EmailSender.Send(ctx, emailAdapter.SendRequest{
+		To:      []string{change.NewEmail},
+		From:    deps.FromAddress,
+		Subject: "Confirm your new Workshop email address",
+		HTML: fmt.Sprintf(`<p>Kia ora,</p>
+<p>You asked to use this address for your Workshop account instead of %s.</p>
+<p><a href="%s">Confirm your new email address</a></p>
+<p>The link works for %d hours. If you didn't ask for this, ignore this email and nothing will change.</p>`,
+			html.EscapeString(change.OldEmail), html.EscapeString(link), hours),
+		ReplyTo: deps.ReplyTo,
+	}); err != nil {
+		return account.EmailChange{}, fmt.Errorf("send confirmation: %w", err)
+	}
+
+	slog.InfoContext(ctx, "auth_event", "event", "email_change_requested", "account_id", acct.ID, "new_email", change.NewEmail)
+	return change, nil
+}
+
+// ConfirmEmailChangeResult reports a confirmed email change.
+type ConfirmEmailChangeResult struct {
+	Change  account.EmailChange
+	Applied accountStore.EmailChangeApplied
+}
+
+// ExecuteConfirmEmailChange applies the change a confirmation token belongs to, then tells
+// the old address the account has moved.
+// PRE: token came from a confirmation link
+// POST: The account, its members and unsent email recipients use the new address;
+// account.ErrEmailChangeInvalid, ErrEmailChangeUsed, ErrEmailChangeExpired or ErrEmailTaken otherwise
+func ExecuteConfirmEmailChange(ctx context.Context, token string, deps ChangeEmailDeps) (ConfirmEmailChangeResult, error) {
+	var result ConfirmEmailChangeResult
+	change, err := deps.ChangeStore.GetEmailChangeByToken(ctx, token)
+	if err != nil {
+		return result, account.ErrEmailChangeInvalid
+	}
+	now := deps.Now()
+	if err := change.CheckUsable(now); err != nil {
+		return result, err
+	}
+	applied, err := deps.ChangeStore.ApplyEmailChange(ctx, change, now)
+	if err != nil {
+		return result, err
+	}
+	change.Used = true
+	change.ConfirmedAt = now
+	result = ConfirmEmailChangeResult{Change: change, Applied: applied}
+	slog.InfoContext(ctx, "auth_event", "event", "email_changed", "account_id", change.AccountID,
+		"old_email", change.OldEmail, "new_email", change.NewEmail, "members", applied.Members, "recipients", applied.Recipients)
+
+	if deps.EmailSender != nil {
+		if _, err := deps.EmailSender.Send(ctx, emailAdapter.SendRequest{
+			To:      []string{change.OldEmail},
+			From:    deps.FromAddress,
+			Subject: "Your Workshop email address has changed",
+			HTML: fmt.Sprintf(`<p>Kia ora,</p>
+<p>Your Workshop account now uses %s instead of this address. You'll need to sign in with the new address.</p>
+<p>If you didn't make this change, reply to this email or talk to us at the gym straight away.</p>`,
+				html.EscapeString(change.NewEmail)),
+			ReplyTo: deps.ReplyTo,
+		}); err != nil {
+			// The change has been made; the notice is best effort.
+			slog.ErrorContext(ctx, "auth_event", "event", "email_change_notice_failed", "account_id", change.AccountID, "error", err)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,169 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	accountStore "workshop/internal/adapters/storage/account"
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/member"
+)
+
+type memEmailChangeStore struct {
+	accounts map[string]account.Account // by ID
+	members  []member.Member
+	changes  map[string]account.EmailChange // by token
+}
+
+// GetByID returns an account by ID.
+// PRE: id is non-empty
+// POST: Returns the account or an error
+func (m *memEmailChangeStore) GetByID(_ context.Context, id string) (account.Account, error) {
+	if a, ok := m.accounts[id]; ok {
+		return a, nil
+	}
+	return account.Account{}, errors.New("account not found")
+}
+
+// GetByEmail returns an account by email.
+// PRE: email is non-empty
+// POST: Returns the account or an error
+func (m *memEmailChangeStore) GetByEmail(_ context.Context, email string) (account.Account, error) {
+	for _, a := range m.accounts {
+		if a.Email == email {
+			return a, nil
+		}
+	}
+	return account.Account{}, errors.New("account not found")
+}
+
+// SaveEmailChange stores a pending change.
+// PRE: value has a token
+// POST: The change is stored by token
+func (m *memEmailChangeStore) SaveEmailChange(_ context.Context, value account.EmailChange) error {
+	m.changes[value.Token] = value
+	return nil
+}
+
+// GetEmailChangeByToken returns a change by token.
+// PRE: token is non-empty
+// POST: Returns the change or an error
+func (m *memEmailChangeStore) GetEmailChangeByToken(_ context.Context, token string) (account.EmailChange, error) {
+	if c, ok := m.changes[token]; ok {
+		return c, nil
+	}
+	return account.EmailChange{}, errors.New("change not found")
+}
+
+// ApplyEmailChange moves the account and its members to the new address.
+// PRE: value is usable
+// POST: The account and members use NewEmail and the change is used
+func (m *memEmailChangeStore) ApplyEmailChange(_ context.Context, value account.EmailChange, confirmedAt time.Time) (accountStore.EmailChangeApplied, error) {
+	var applied accountStore.EmailChangeApplied
+	a := m.accounts[value.AccountID]
+	a.Email = value.NewEmail
+	m.accounts[a.ID] = a
+	for i := range m.members {
+		if m.members[i].AccountID == value.AccountID {
+			m.members[i].Email = value.NewEmail
+			applied.Members++
+		}
+	}
+	value.Used = true
+	value.ConfirmedAt = confirmedAt
+	m.changes[value.Token] = value
+	return applied, nil
+}
+
+// memberByEmail adapts the store's members to EmailChangeMemberStore.
+type memberByEmail struct{ store *memEmailChangeStore }
+
+// GetByEmail returns a member by email.
+// PRE: email is non-empty
+// POST: Returns the member or an error
+func (m memberByEmail) GetByEmail(_ context.Context, email string) (member.Member, error) {
+	for _, mem := range m.store.members {
+		if mem.Email == email {
+			return mem, nil
+		}
+	}
+	return member.Member{}, errors.New("member not found")
+}
+
+// TestChangeEmail verifies the password is checked, taken addresses are refused, and a
+// confirmed link moves the account and its members while a second use is refused.
+func TestChangeEmail(t *testing.T) {
+	acct := account.Account{ID: "a1", Email: "kai@example.com", Role: account.RoleMember}
+	if err := acct.SetPassword("correct horse battery"); err != nil {
+		t.Fatal(err)
+	}
+	store := &memEmailChangeStore{
+		accounts: map[string]account.Account{"a1": acct, "a2": {ID: "a2", Email: "aroha@example.com"}},
+		members: []member.Member{
+			{ID: "m1", AccountID: "a1", Email: "kai@example.com"},
+			{ID: "m2", Email: "walkin@example.com"},
+		},
+		changes: map[string]account.EmailChange{},
+	}
+	sender := newMockEmailSender()
+	now := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	deps := ChangeEmailDeps{
+		AccountStore:  store,
+		MemberStore:   memberByEmail{store},
+		ChangeStore:   store,
+		EmailSender:   sender,
+		BaseURL:       "https://workshop.test",
+		GenerateID:    func() string { return "c1" },
+		GenerateToken: func() string { return "tok" },
+		Now:           func() time.Time { return now },
+	}
+	ctx := context.Background()
+	request := func(password, email string) error {
+		_, err := ExecuteRequestEmailChange(ctx, RequestEmailChangeInput{AccountID: "a1", Password: password, NewEmail: email}, deps)
+		return err
+	}
+
+	if err := request("wrong", "kai@new.example.com"); !errors.Is(err, ErrCurrentPasswordWrong) {
+		t.Errorf("wrong password err = %v", err)
+	}
+	if err := request("correct horse battery", "Aroha@example.com"); !errors.Is(err, account.ErrEmailTaken) {
+		t.Errorf("another account's email err = %v", err)
+	}
+	if err := request("correct horse battery", "walkin@example.com"); !errors.Is(err, account.ErrEmailTaken) {
+		t.Errorf("another member's email err = %v", err)
+	}
+	if sender.sent != 0 {
+		t.Fatalf("sent %d emails for refused requests", sender.sent)
+	}
+
+	if err := request("correct horse battery", " Kai@New.example.com "); err != nil {
+		t.Fatal(err)
+	}
+	if store.accounts["a1"].Email != "kai@example.com" {
+		t.Fatal("email changed before the link was confirmed")
+	}
+	if sender.sent != 1 || sender.sentReqs[0].To[0] != "kai@new.example.com" ||
+		!strings.Contains(sender.sentReqs[0].HTML, "https://workshop.test/account/email/confirm?token=tok") {
+		t.Fatalf("confirmation email = %+v", sender.sentReqs)
+	}
+
+	result, err := ExecuteConfirmEmailChange(ctx, "tok", deps)
+	if err != nil || result.Applied.Members != 1 {
+		t.Fatalf("confirm = %+v, %v", result, err)
+	}
+	if store.accounts["a1"].Email != "kai@new.example.com" || store.members[0].Email != "kai@new.example.com" {
+		t.Errorf("account and member emails not moved: %+v %+v", store.accounts["a1"], store.members[0])
+	}
+	if sender.sent != 2 || sender.sentReqs[1].To[0] != "kai@example.com" {
+		t.Errorf("old address was not told about the change: %+v", sender.sentReqs)
+	}
+	if _, err := ExecuteConfirmEmailChange(ctx, "tok", deps); !errors.Is(err, account.ErrEmailChangeUsed) {
+		t.Errorf("second confirm err = %v, want ErrEmailChangeUsed", err)
+	}
+	if _, err := ExecuteConfirmEmailChange(ctx, "nope", deps); !errors.Is(err, account.ErrEmailChangeInvalid) {
+		t.Errorf("unknown token err = %v, want ErrEmailChangeInvalid", err)
+	}
+}
@@ -66,6 +66,6 @@ func ExecuteChangePassword(ctx context.Context, input ChangePasswordInput, deps
 		return err
 	}
 
-	slog.Info("auth_event", "event", "password_changed", "account_id", input.AccountID)
+	slog.InfoContext(ctx, "auth_event", "event", "password_changed", "account_id", input.AccountID)
 	return nil
 }
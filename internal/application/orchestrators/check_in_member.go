@@ -16,6 +16,7 @@ import (
 // AttendanceStore defines the interface for attendance persistence.
 type AttendanceStore interface {
 	Save(ctx context.Context, a attendance.Attendance) error
+	ListByMemberIDAndDate(ctx context.Context, memberID string, date string) ([]attendance.Attendance, error)
 }
 
 // CheckInSearchStore defines the member store interface needed for name search.
@@ -70,6 +71,8 @@ type CheckInMemberInput struct {
 	MemberID   string // selected from search shortlist
 	ScheduleID string // optional: which class they're checking into
 	ClassDate  string // optional: date of the class (YYYY-MM-DD)
+	LocationID string // optional: location of the check-in device; the schedule's location wins
+	OverrideBy string // optional: coach or admin account letting the member in without the class's prerequisites
 }
 
 // ScheduleLookupStore defines the schedule store interface needed for mat hours.
@@ -81,13 +84,20 @@ type ScheduleLookupStore interface {
 type CheckInMemberDeps struct {
 	MemberStore     CheckInSearchStore
 	AttendanceStore AttendanceStore
-	ScheduleStore   ScheduleLookupStore // optional: used to compute mat hours
-	InferStripeDeps *InferStripeDeps    // optional: nil skips stripe inference
+	ScheduleStore   ScheduleLookupStore       // optional: used to compute mat hours
+	InferStripeDeps *InferStripeDeps          // optional: nil skips stripe inference
+	TopicDeps       *LinkAttendanceTopicsDeps // optional: nil skips linking the check-in to rotor topics
+	PackDeps        *UseClassPackDeps         // optional: nil uses no class packs
+	EligibilityDeps *ClassEligibilityDeps     // optional: nil skips class type prerequisites
+	ChangeStore     OccurrenceLookupStore     // optional: nil ignores cancelled, moved and added classes
 }
 
 // ExecuteCheckInMember coordinates member check-in.
 // PRE: MemberID is a valid member selected from the name-search shortlist
-// POST: Attendance record created with CheckInTime=now
+// POST: Attendance record created with CheckInTime=now, or attendance.ErrDuplicateCheckIn /
+// attendance.ErrOverlappingCheckIn when the member is already in this class or one at the same time,
+// or classtype.ErrBeltRequired / classtype.ErrApprovalRequired when they do not meet its prerequisites,
+// or ErrClassCancelled when the class is cancelled on ClassDate (today when empty)
 // INVARIANT: Cannot check in twice without checking out (enforced by UI/business logic)
 func ExecuteCheckInMember(ctx context.Context, input CheckInMemberInput, deps CheckInMemberDeps) error {
 	if input.MemberID == "" {
@@ -102,36 +112,70 @@ func ExecuteCheckInMember(ctx context.Context, input CheckInMemberInput, deps Ch
 	if m.IsArchived() {
 		return errors.New("archived members cannot check in")
 	}
+	if m.IsFrozen() {
+		return ErrCheckInFrozen
+	}
+	now := time.Now()
+	classDate := input.ClassDate
+	if classDate == "" {
+		classDate = now.Format("2006-01-02")
+	}
+	schedules, eligibility := withClassChanges(deps.ScheduleStore, deps.EligibilityDeps, deps.ChangeStore, classDate)
 
-	// Compute mat hours from schedule duration if available
+	// Compute mat hours from the class's duration on the day if available
 	var matHours float64
-	if input.ScheduleID != "" && deps.ScheduleStore != nil {
-		if sched, err := deps.ScheduleStore.GetByID(ctx, input.ScheduleID); err == nil {
+	locationID := input.LocationID
+	if input.ScheduleID != "" && schedules != nil {
+		sched, err := schedules.GetByID(ctx, input.ScheduleID)
+		if errors.Is(err, ErrClassCancelled) {
+			return err
+		}
+		if err == nil {
 			if dur, err := sched.DurationHours(); err == nil {
 				matHours = dur
 			}
+			if sched.LocationID != "" {
+				locationID = sched.LocationID
+			}
 		}
 	}
+	if err := checkClassEligibility(ctx, m, input.ScheduleID, input.OverrideBy, eligibility); err != nil {
+		return err
+	}
 
 	// Create attendance record
 	a := attendance.Attendance{
 		ID:          uuid.New().String(),
 		MemberID:    input.MemberID,
-		CheckInTime: time.Now(),
+		CheckInTime: now,
 		ScheduleID:  input.ScheduleID,
 		ClassDate:   input.ClassDate,
 		MatHours:    matHours,
+		LocationID:  locationID,
 	}
 
 	if err := a.Validate(); err != nil {
 		return err
 	}
 
+	existing, err := deps.AttendanceStore.ListByMemberIDAndDate(ctx, input.MemberID, now.Format("2006-01-02"))
+	if err != nil {
+		return err
+	}
+	slots := scheduleSlots(ctx, schedules, append(existing, a))
+	if clash, err := attendance.Conflict(existing, a, slots); err != nil {
+		slog.InfoContext(ctx, "checkin_event", "event", "check_in_rejected", "member_id", input.MemberID, "schedule_id", input.ScheduleID, "existing_id", clash.ID, "reason", err.Error())
+		return err
+	}
+
 	if err := deps.AttendanceStore.Save(ctx, a); err != nil {
 		return err
 	}
 
-	slog.Info("checkin_event", "event", "member_checked_in", "member_id", input.MemberID, "name", m.Name, "schedule_id", input.ScheduleID, "mat_hours", matHours)
+	slog.InfoContext(ctx, "checkin_event", "event", "member_checked_in", "member_id", input.MemberID, "name", m.Name, "schedule_id", input.ScheduleID, "mat_hours", matHours, "location_id", locationID)
+
+	linkAttendanceTopics(ctx, a, deps.TopicDeps)
+	useClassPack(ctx, a, deps.PackDeps)
 
 	// Best-effort stripe inference after check-in
 	if deps.InferStripeDeps != nil {
@@ -140,3 +184,24 @@ func ExecuteCheckInMember(ctx context.Context, input CheckInMemberInput, deps Ch
 
 	return nil
 }
+
+// scheduleSlots looks up the class times of the records' schedules. Schedules that cannot
+// be found are left out, so they are never treated as overlapping.
+func scheduleSlots(ctx context.Context, store ScheduleLookupStore, records []attendance.Attendance) map[string]attendance.Slot {
+	slots := map[string]attendance.Slot{}
+	if store == nil {
+		return slots
+	}
+	for _, r := range records {
+		if r.ScheduleID == "" {
+			continue
+		}
+		if _, ok := slots[r.ScheduleID]; ok {
+			continue
+		}
+		if sched, err := store.GetByID(ctx, r.ScheduleID); err == nil {
+			slots[r.ScheduleID] = attendance.Slot{Start: sched.StartTime, End: sched.EndTime}
+		}
+	}
+	return slots
+}
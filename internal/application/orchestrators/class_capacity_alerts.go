@@ -0,0 +1,136 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"workshop/internal/domain/notice"
+	"workshop/internal/domain/notification"
+)
+
+// LiveClass is a class running now with its headcount and mat capacity.
+type LiveClass struct {
+	ScheduleID    string
+	ClassTypeID   string
+	ClassTypeName string
+	StartTime     string // HH:MM, local time
+	EndTime       string // HH:MM, local time
+	CoachID       string // AccountID of the regular coach; empty = unassigned
+	Headcount     int
+	MatCapacity   int // 0 = no limit
+}
+
+// CapacityAlertStore records which class occurrences staff were alerted to, so each alert goes out once.
+type CapacityAlertStore interface {
+	MarkCapacityAlertSent(ctx context.Context, scheduleID, classDate string, headcount int, sentAt time.Time) (bool, error)
+}
+
+// SendCapacityAlertsDeps holds dependencies for SendCapacityAlerts.
+type SendCapacityAlertsDeps struct {
+	// LiveClasses lists the classes running at now with their headcounts.
+	LiveClasses func(ctx context.Context, now time.Time) ([]LiveClass, error)
+	// AdminAccountIDs lists the admins told about every overcrowded class.
+	AdminAccountIDs func(ctx context.Context) ([]string, error)
+	AlertStore      CapacityAlertStore
+	NoticeStore     NoticeStoreForOrchestrator
+	Notify          NotifyDeps
+	GenerateID      func() string
+	Now             func() time.Time
+}
+
+// SendCapacityAlertsResult summarises one run.
+type SendCapacityAlertsResult struct {
+	Overcrowded int // live classes over their mat capacity
+	Alerted     int // classes alerted for the first time this run
+}
+
+// ExecuteSendCapacityAlerts alerts staff when a live class's headcount goes over its class
+// type's mat capacity. Each occurrence raises one class-specific notice for its coaches,
+// visible until the class ends, and notifies the class's coach and every admin.
+// PRE: deps are complete
+// POST: Alerts are published and recorded; failures for one class are joined and the rest still run
+func ExecuteSendCapacityAlerts(ctx context.Context, deps SendCapacityAlertsDeps) (SendCapacityAlertsResult, error) {
+	now := deps.Now()
+	today := now.Format("2006-01-02")
+	var result SendCapacityAlertsResult
+	classes, err := deps.LiveClasses(ctx, now)
+	if err != nil {
+		return result, err
+	}
+
+	var errs []error
+	var admins []string
+	for _, c := range classes {
+		if c.MatCapacity <= 0 || c.Headcount <= c.MatCapacity {
+			continue
+		}
+		result.Overcrowded++
+		first, err := deps.AlertStore.MarkCapacityAlertSent(ctx, c.ScheduleID, today, c.Headcount, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !first {
+			continue
+		}
+
+		title := fmt.Sprintf("Over capacity: %s at %s", c.ClassTypeName, c.StartTime)
+		if len(title) > notice.MaxTitleLength {
+			title = "Class over capacity at " + c.StartTime
+		}
+		body := fmt.Sprintf("%d people are checked in to %s at %s and the mat holds %d. Late arrivals, please check with the coach before stepping on.",
+			c.Headcount, c.ClassTypeName, c.StartTime, c.MatCapacity)
+		visibleUntil := now.Add(time.Hour)
+		if end, err := time.ParseInLocation("2006-01-02 15:04", today+" "+c.EndTime, now.Location()); err == nil {
+			visibleUntil = end
+		}
+		n := notice.Notice{
+			ID:           deps.GenerateID(),
+			Type:         notice.TypeClassSpecific,
+			Status:       notice.StatusDraft,
+			Title:        title,
+			Content:      body,
+			CreatedBy:    "system",
+			TargetID:     c.ClassTypeID,
+			Color:        notice.ColorRed,
+			VisibleUntil: visibleUntil,
+			CreatedAt:    now,
+		}
+		if err := n.Validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := n.Publish("system", now); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := deps.NoticeStore.Save(ctx, n); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if admins == nil {
+			if admins, err = deps.AdminAccountIDs(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if _, err := ExecuteNotify(ctx, NotifyInput{
+			AccountIDs: append([]string{c.CoachID}, admins...),
+			Kind:       notification.KindClassOvercrowded,
+			Title:      title,
+			Body:       body,
+			Link:       "/dashboard",
+			PushTTL:    visibleUntil.Sub(now),
+		}, deps.Notify); err != nil {
+			errs = append(errs, err)
+		}
+		result.Alerted++
+		slog.WarnContext(ctx, "checkin_event", "event", "class_over_capacity", "schedule_id", c.ScheduleID,
+			"class_date", today, "headcount", c.Headcount, "mat_capacity", c.MatCapacity)
+	}
+
+	return result, errors.Join(errs...)
+}
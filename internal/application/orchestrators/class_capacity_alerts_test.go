@@ -0,0 +1,76 @@
+package orchestrators
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/notice"
+	"workshop/internal/domain/notification"
+)
+
+type mockCapacityAlertStore struct {
+	sent map[string]int // schedule ID|class date -> headcount
+}
+
+// MarkCapacityAlertSent implements CapacityAlertStore.
+// PRE: keys are non-empty
+// POST: returns true the first time an occurrence is marked
+func (m *mockCapacityAlertStore) MarkCapacityAlertSent(_ context.Context, scheduleID, classDate string, headcount int, _ time.Time) (bool, error) {
+	key := scheduleID + "|" + classDate
+	if _, ok := m.sent[key]; ok {
+		return false, nil
+	}
+	m.sent[key] = headcount
+	return true, nil
+}
+
+// TestExecuteSendCapacityAlerts verifies an overcrowded class gets one red notice until it ends
+// and its coach and the admins are notified once, while classes within or without a capacity
+// are left alone.
+func TestExecuteSendCapacityAlerts(t *testing.T) {
+	now := time.Date(2026, 3, 2, 18, 10, 0, 0, time.UTC)
+	notices := newMockNoticeStore()
+	notifications := &mockNotifyStore{}
+	n := 0
+	deps := SendCapacityAlertsDeps{
+		LiveClasses: func(_ context.Context, _ time.Time) ([]LiveClass, error) {
+			return []LiveClass{
+				{ScheduleID: "s-full", ClassTypeID: "ct-gi", ClassTypeName: "Fundamentals Gi", StartTime: "18:00", EndTime: "19:00", CoachID: "coach-1", Headcount: 31, MatCapacity: 30},
+				{ScheduleID: "s-ok", ClassTypeID: "ct-nogi", ClassTypeName: "No-Gi", StartTime: "18:00", EndTime: "19:00", CoachID: "coach-2", Headcount: 30, MatCapacity: 30},
+				{ScheduleID: "s-open", ClassTypeID: "ct-open", ClassTypeName: "Open Mat", StartTime: "18:00", EndTime: "20:00", Headcount: 80},
+			}, nil
+		},
+		AdminAccountIDs: func(_ context.Context) ([]string, error) { return []string{"admin-1", "coach-1"}, nil },
+		AlertStore:      &mockCapacityAlertStore{sent: map[string]int{}},
+		NoticeStore:     notices,
+		Notify:          NotifyDeps{NotificationStore: notifications, GenerateID: fixedID, Now: func() time.Time { return now }},
+		GenerateID:      func() string { n++; return fmt.Sprintf("id-%d", n) },
+		Now:             func() time.Time { return now },
+	}
+
+	result, err := ExecuteSendCapacityAlerts(context.Background(), deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Overcrowded != 1 || result.Alerted != 1 {
+		t.Errorf("result = %+v, want one class alerted", result)
+	}
+	if len(notices.notices) != 1 {
+		t.Fatalf("expected one notice, got %d", len(notices.notices))
+	}
+	for _, got := range notices.notices {
+		if got.Type != notice.TypeClassSpecific || got.TargetID != "ct-gi" || got.Status != notice.StatusPublished || got.VisibleUntil.Hour() != 19 {
+			t.Errorf("unexpected notice: %+v", got)
+		}
+	}
+	if len(notifications.saved) != 2 || notifications.saved[0].AccountID != "coach-1" || notifications.saved[0].Kind != notification.KindClassOvercrowded {
+		t.Errorf("expected coach-1 and admin-1 notified once each, got %+v", notifications.saved)
+	}
+
+	again, err := ExecuteSendCapacityAlerts(context.Background(), deps)
+	if err != nil || again.Alerted != 0 || len(notices.notices) != 1 || len(notifications.saved) != 2 {
+		t.Errorf("re-run: expected no second alert, got %+v, %v", again, err)
+	}
+}
@@ -0,0 +1,82 @@
+package orchestrators
+
+import (
+	"context"
+	"log/slog"
+
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/member"
+)
+
+// EligibilityClassTypeStore defines the class type store interface needed to read prerequisites.
+type EligibilityClassTypeStore interface {
+	GetByID(ctx context.Context, id string) (classtype.ClassType, error)
+}
+
+// EligibilityApprovalStore defines the approval store interface needed to honour coach approvals.
+type EligibilityApprovalStore interface {
+	IsApproved(ctx context.Context, classTypeID, memberID string) (bool, error)
+}
+
+// EligibilityRecordStore defines the grading record store interface needed to find a member's belt.
+type EligibilityRecordStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]grading.Record, error)
+}
+
+// ClassEligibilityDeps holds what is needed to check a class type's prerequisites at check-in.
+type ClassEligibilityDeps struct {
+	ScheduleStore      ScheduleLookupStore
+	ClassTypeStore     EligibilityClassTypeStore
+	ApprovalStore      EligibilityApprovalStore
+	GradingRecordStore EligibilityRecordStore
+}
+
+// checkClassEligibility enforces the prerequisites of the class type behind a schedule.
+// A class that cannot be looked up has no prerequisites, so a missing schedule never
+// blocks check-in. overrideBy is the coach or admin letting an ineligible member in anyway;
+// the override is logged.
+// PRE: m is the member checking in; overrideBy is empty or a staff account ID
+// POST: Returns nil when eligible or overridden, classtype.ErrBeltRequired /
+// classtype.ErrApprovalRequired when not, or a store error
+func checkClassEligibility(ctx context.Context, m member.Member, scheduleID, overrideBy string, deps *ClassEligibilityDeps) error {
+	if deps == nil || scheduleID == "" {
+		return nil
+	}
+	sched, err := deps.ScheduleStore.GetByID(ctx, scheduleID)
+	if err != nil || sched.ClassTypeID == "" {
+		return nil
+	}
+	ct, err := deps.ClassTypeStore.GetByID(ctx, sched.ClassTypeID)
+	if err != nil || !ct.HasPrerequisites() {
+		return nil
+	}
+	approved, err := deps.ApprovalStore.IsApproved(ctx, ct.ID, m.ID)
+	if err != nil {
+		return err
+	}
+	records, err := deps.GradingRecordStore.ListByMemberID(ctx, m.ID)
+	if err != nil {
+		return err
+	}
+	belt := ""
+	if len(records) > 0 {
+		latest := records[0]
+		for _, r := range records[1:] {
+			if r.PromotedAt.After(latest.PromotedAt) {
+				latest = r
+			}
+		}
+		belt = latest.Belt
+	}
+	reason := ct.CheckEligibility(grading.BeltAtLeast(m.Program, belt, ct.MinBelt), approved)
+	if reason == nil {
+		return nil
+	}
+	if overrideBy == "" {
+		slog.InfoContext(ctx, "checkin_event", "event", "check_in_ineligible", "member_id", m.ID, "schedule_id", scheduleID, "class_type_id", ct.ID, "reason", reason.Error())
+		return reason
+	}
+	slog.InfoContext(ctx, "checkin_event", "event", "check_in_prerequisite_overridden", "member_id", m.ID, "schedule_id", scheduleID, "class_type_id", ct.ID, "reason", reason.Error(), "override_by", overrideBy)
+	return nil
+}
@@ -0,0 +1,147 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/kiosk"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/schedule"
+)
+
+type mockEligibilityClassTypeStore struct {
+	classTypes map[string]classtype.ClassType
+}
+
+// GetByID implements EligibilityClassTypeStore.
+// PRE: id is non-empty
+// POST: returns the class type or an error
+func (m *mockEligibilityClassTypeStore) GetByID(_ context.Context, id string) (classtype.ClassType, error) {
+	ct, ok := m.classTypes[id]
+	if !ok {
+		return classtype.ClassType{}, errors.New("not found")
+	}
+	return ct, nil
+}
+
+type mockEligibilityApprovalStore struct {
+	approved map[string]bool // key: classTypeID + "/" + memberID
+}
+
+// IsApproved implements EligibilityApprovalStore.
+// PRE: classTypeID and memberID are non-empty
+// POST: returns whether the pair was approved
+func (m *mockEligibilityApprovalStore) IsApproved(_ context.Context, classTypeID, memberID string) (bool, error) {
+	return m.approved[classTypeID+"/"+memberID], nil
+}
+
+// newEligibilityDeps returns a competition class needing blue belt and an invite-only
+// class, with m-blue promoted to blue and m-invited approved for the invite-only class.
+func newEligibilityDeps() *ClassEligibilityDeps {
+	return &ClassEligibilityDeps{
+		ScheduleStore: &mockAnomalyScheduleStore{schedules: map[string]schedule.Schedule{
+			"s-comp":   {ID: "s-comp", ClassTypeID: "ct-comp", StartTime: "10:00", EndTime: "11:00"},
+			"s-invite": {ID: "s-invite", ClassTypeID: "ct-invite", StartTime: "12:00", EndTime: "13:00"},
+			"s-fund":   {ID: "s-fund", ClassTypeID: "ct-fund", StartTime: "14:00", EndTime: "15:00"},
+		}},
+		ClassTypeStore: &mockEligibilityClassTypeStore{classTypes: map[string]classtype.ClassType{
+			"ct-comp":   {ID: "ct-comp", Name: "Competition", MinBelt: grading.BeltBlue},
+			"ct-invite": {ID: "ct-invite", Name: "Fight team", RequiresApproval: true},
+			"ct-fund":   {ID: "ct-fund", Name: "Fundamentals"},
+		}},
+		ApprovalStore: &mockEligibilityApprovalStore{approved: map[string]bool{"ct-invite/m-invited": true}},
+		GradingRecordStore: &mockInferGradingRecordStore{records: map[string][]grading.Record{
+			"m-blue": {
+				{ID: "r1", MemberID: "m-blue", Belt: grading.BeltWhite, PromotedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: "r2", MemberID: "m-blue", Belt: grading.BeltBlue, PromotedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		}},
+	}
+}
+
+// TestCheckClassEligibility verifies belt minimums and approval-only classes are enforced,
+// an approval waives them and a coach's override lets anyone in.
+func TestCheckClassEligibility(t *testing.T) {
+	tests := []struct {
+		name       string
+		memberID   string
+		scheduleID string
+		overrideBy string
+		want       error
+	}{
+		{"blue belt into competition", "m-blue", "s-comp", "", nil},
+		{"white belt into competition", "m-white", "s-comp", "", classtype.ErrBeltRequired},
+		{"coach override", "m-white", "s-comp", "coach-1", nil},
+		{"not approved", "m-blue", "s-invite", "", classtype.ErrApprovalRequired},
+		{"approved", "m-invited", "s-invite", "", nil},
+		{"no prerequisites", "m-white", "s-fund", "", nil},
+		{"unknown schedule", "m-white", "s-gone", "", nil},
+		{"no class", "m-white", "", "", nil},
+	}
+	deps := newEligibilityDeps()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := member.Member{ID: tt.memberID, Program: member.ProgramAdults}
+			if err := checkClassEligibility(context.Background(), m, tt.scheduleID, tt.overrideBy, deps); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if err := checkClassEligibility(context.Background(), member.Member{ID: "m-white"}, "s-comp", "", nil); err != nil {
+		t.Errorf("no deps: err = %v, want nil", err)
+	}
+}
+
+// TestExecuteCheckInMember_EnforcesPrerequisites verifies an ineligible member is turned away
+// without a record and a coach's override checks them in.
+func TestExecuteCheckInMember_EnforcesPrerequisites(t *testing.T) {
+	store := &mockBulkSyncAttendanceStore{}
+	eligibility := newEligibilityDeps()
+	deps := CheckInMemberDeps{
+		MemberStore:     &mockBulkSyncMemberStore{members: map[string]member.Member{"m-white": {ID: "m-white", Name: "Wen", Program: member.ProgramAdults, Status: member.StatusActive}}},
+		AttendanceStore: store,
+		ScheduleStore:   eligibility.ScheduleStore,
+		EligibilityDeps: eligibility,
+	}
+	ctx := context.Background()
+
+	if err := ExecuteCheckInMember(ctx, CheckInMemberInput{MemberID: "m-white", ScheduleID: "s-comp"}, deps); !errors.Is(err, classtype.ErrBeltRequired) {
+		t.Fatalf("err = %v, want ErrBeltRequired", err)
+	}
+	if len(store.records) != 0 {
+		t.Fatalf("records = %d, want none after a refused check-in", len(store.records))
+	}
+	if err := ExecuteCheckInMember(ctx, CheckInMemberInput{MemberID: "m-white", ScheduleID: "s-comp", OverrideBy: "coach-1"}, deps); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if len(store.records) != 1 {
+		t.Errorf("records = %d, want the overridden check-in", len(store.records))
+	}
+}
+
+// TestExecuteQRCheckIn_EnforcesPrerequisites verifies a scan into a class the member is not
+// eligible for is refused with the reason.
+func TestExecuteQRCheckIn_EnforcesPrerequisites(t *testing.T) {
+	store := &mockBulkSyncAttendanceStore{}
+	deps := newQRCheckInDeps(store, time.Date(2026, 3, 1, 9, 40, 0, 0, time.UTC))
+	deps.EligibilityDeps = &ClassEligibilityDeps{
+		ScheduleStore: &mockAnomalyScheduleStore{schedules: map[string]schedule.Schedule{
+			"s-adults": {ID: "s-adults", ClassTypeID: "ct-invite"},
+		}},
+		ClassTypeStore:     &mockEligibilityClassTypeStore{classTypes: map[string]classtype.ClassType{"ct-invite": {ID: "ct-invite", RequiresApproval: true}}},
+		ApprovalStore:      &mockEligibilityApprovalStore{},
+		GradingRecordStore: &mockInferGradingRecordStore{},
+	}
+
+	result, err := ExecuteQRCheckIn(context.Background(), QRCheckInInput{Token: kiosk.SignCheckInToken(qrTestKey, "m1"), Classes: qrTestClasses}, deps)
+	if !errors.Is(err, classtype.ErrApprovalRequired) {
+		t.Fatalf("err = %v, want ErrApprovalRequired", err)
+	}
+	if result.Member.ID != "m1" || len(store.records) != 0 {
+		t.Errorf("result = %+v with %d records, want the member and no record", result, len(store.records))
+	}
+}
@@ -0,0 +1,318 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"strings"
+	"time"
+
+	emailDomain "workshop/internal/domain/email"
+	"workshop/internal/domain/notice"
+	"workshop/internal/domain/schedule"
+)
+
+// ErrOccurrenceAlreadyChanged is returned when a class occurrence is already cancelled or covered.
+var ErrOccurrenceAlreadyChanged = errors.New("this class has already been changed; undo the existing change first")
+
+// ErrOccurrenceInPast is returned when changing a class that has already happened.
+var ErrOccurrenceInPast = errors.New("cannot change a class that has already happened")
+
+// ErrUnknownClassType is returned when adding a session of a class type that does not exist.
+var ErrUnknownClassType = errors.New("class type not found")
+
+// OccurrenceChangeStore defines the store interface needed to record class occurrence changes.
+type OccurrenceChangeStore interface {
+	GetByID(ctx context.Context, id string) (schedule.OccurrenceChange, error)
+	GetByOccurrence(ctx context.Context, scheduleID, classDate string) (schedule.OccurrenceChange, error)
+	Save(ctx context.Context, value schedule.OccurrenceChange) error
+	Delete(ctx context.Context, id string) error
+}
+
+// OccurrenceAttendanceStore defines the attendance store interface needed to find a class's regulars.
+type OccurrenceAttendanceStore interface {
+	ListDistinctMemberIDsByScheduleIDsSince(ctx context.Context, scheduleIDs []string, since string) ([]string, error)
+}
+
+// OccurrenceEmailStore defines the email store interface needed to queue and withdraw change emails.
+type OccurrenceEmailStore interface {
+	GetByID(ctx context.Context, id string) (emailDomain.Email, error)
+	Save(ctx context.Context, e emailDomain.Email) error
+	SaveRecipients(ctx context.Context, emailID string, recipients []emailDomain.Recipient) error
+}
+
+// --- Change Class Occurrence ---
+
+// ChangeClassOccurrenceInput carries input for changing one date of the timetable.
+type ChangeClassOccurrenceInput struct {
+	ScheduleID  string // the weekly class; ignored when adding a session
+	ClassDate   string // YYYY-MM-DD
+	Kind        string // schedule.ChangeCancelled, ChangeSubstitute, ChangeMoved or ChangeAdded
+	Substitute  string
+	Reason      string
+	ClassTypeID string // added sessions only
+	StartTime   string // moved and added
+	EndTime     string // moved and added
+	LocationID  string // added sessions only
+	Mat         string // moved and added
+	CreatedBy   string // AccountID of the coach or admin making the change
+}
+
+// ChangeClassOccurrenceDeps holds dependencies for ChangeClassOccurrence.
+type ChangeClassOccurrenceDeps struct {
+	ChangeStore     OccurrenceChangeStore
+	ScheduleStore   ScheduleLookupStore
+	ClassTypeStore  NoticeAudienceClassTypeStore
+	AttendanceStore OccurrenceAttendanceStore
+	NoticeStore     NoticeStoreForOrchestrator
+	EmailStore      OccurrenceEmailStore
+	MemberLookup    MemberLookup
+	GenerateID      func() string
+	Now             func() time.Time
+}
+
+// ChangeClassOccurrenceResult reports the change and who was told about it.
+type ChangeClassOccurrenceResult struct {
+	Change     schedule.OccurrenceChange
+	Notice     notice.Notice
+	Recipients int // members emailed; 0 when nobody attended recently
+}
+
+// ExecuteChangeClassOccurrence changes one date of the timetable: it cancels a class, assigns it a
+// substitute coach, moves it to another time or mat, or adds a one-off session. A class-specific
+// notice is published until the end of the class date, and members who attended the slot in the
+// last schedule.RecentAttendeeDays days are emailed. Added sessions have no regulars, so only the
+// notice announces them.
+// PRE: ScheduleID exists and ClassDate falls on its day (ClassTypeID exists when adding); ClassDate is not in the past
+// POST: Change saved with its notice and email IDs; ErrOccurrenceAlreadyChanged if the occurrence already has one
+func ExecuteChangeClassOccurrence(ctx context.Context, input ChangeClassOccurrenceInput, deps ChangeClassOccurrenceDeps) (ChangeClassOccurrenceResult, error) {
+	now := deps.Now()
+	change := schedule.OccurrenceChange{
+		ID:         deps.GenerateID(),
+		ScheduleID: input.ScheduleID,
+		ClassDate:  input.ClassDate,
+		Kind:       input.Kind,
+		Substitute: strings.TrimSpace(input.Substitute),
+		Reason:     strings.TrimSpace(input.Reason),
+		CreatedBy:  input.CreatedBy,
+		CreatedAt:  now,
+	}
+	switch change.Kind {
+	case schedule.ChangeCancelled:
+		change.Substitute = ""
+	case schedule.ChangeMoved:
+		change.StartTime, change.EndTime, change.Mat = input.StartTime, input.EndTime, strings.TrimSpace(input.Mat)
+	case schedule.ChangeAdded:
+		change.ScheduleID = change.ID
+		change.ClassTypeID, change.LocationID = input.ClassTypeID, input.LocationID
+		change.StartTime, change.EndTime, change.Mat = input.StartTime, input.EndTime, strings.TrimSpace(input.Mat)
+	}
+	if err := change.Validate(); err != nil {
+		return ChangeClassOccurrenceResult{}, err
+	}
+	classDate, _ := time.Parse("2006-01-02", input.ClassDate)
+	if input.ClassDate < now.Format("2006-01-02") {
+		return ChangeClassOccurrenceResult{}, ErrOccurrenceInPast
+	}
+
+	var sched schedule.Schedule
+	if change.IsAdded() {
+		sched = change.Session()
+	} else {
+		var err error
+		if sched, err = deps.ScheduleStore.GetByID(ctx, input.ScheduleID); err != nil {
+			return ChangeClassOccurrenceResult{}, err
+		}
+		if !sched.OccursOn(classDate) {
+			return ChangeClassOccurrenceResult{}, schedule.ErrWrongDay
+		}
+		if _, err := deps.ChangeStore.GetByOccurrence(ctx, input.ScheduleID, input.ClassDate); err == nil {
+			return ChangeClassOccurrenceResult{}, ErrOccurrenceAlreadyChanged
+		}
+	}
+	className := sched.ClassTypeID
+	if ct, err := deps.ClassTypeStore.GetByID(ctx, sched.ClassTypeID); err == nil {
+		className = ct.Name
+	} else if change.IsAdded() {
+		return ChangeClassOccurrenceResult{}, ErrUnknownClassType
+	}
+
+	title, body := occurrenceChangeText(change, className, sched, classDate)
+	n := notice.Notice{
+		ID:           deps.GenerateID(),
+		Type:         notice.TypeClassSpecific,
+		Status:       notice.StatusDraft,
+		Title:        title,
+		Content:      body,
+		CreatedBy:    input.CreatedBy,
+		TargetID:     sched.ClassTypeID,
+		Color:        notice.ColorOrange,
+		VisibleUntil: classDate.AddDate(0, 0, 1),
+		CreatedAt:    now,
+	}
+	if change.IsCancelled() {
+		n.Color = notice.ColorRed
+	}
+	if err := n.Validate(); err != nil {
+		return ChangeClassOccurrenceResult{}, err
+	}
+	if err := n.Publish(input.CreatedBy, now); err != nil {
+		return ChangeClassOccurrenceResult{}, err
+	}
+	if err := deps.NoticeStore.Save(ctx, n); err != nil {
+		return ChangeClassOccurrenceResult{}, err
+	}
+	change.NoticeID = n.ID
+
+	var recipients int
+	if !change.IsAdded() {
+		since := classDate.AddDate(0, 0, -schedule.RecentAttendeeDays).Format("2006-01-02")
+		memberIDs, err := deps.AttendanceStore.ListDistinctMemberIDsByScheduleIDsSince(ctx, []string{sched.ID}, since)
+		if err != nil {
+			return ChangeClassOccurrenceResult{}, err
+		}
+		emailID, sent, err := queueOccurrenceEmail(ctx, title, body, memberIDs, input.CreatedBy, deps)
+		if err != nil {
+			return ChangeClassOccurrenceResult{}, err
+		}
+		change.EmailID, recipients = emailID, sent
+	}
+
+	if err := deps.ChangeStore.Save(ctx, change); err != nil {
+		return ChangeClassOccurrenceResult{}, err
+	}
+
+	slog.InfoContext(ctx, "schedule_event", "event", "class_occurrence_"+change.Kind, "change_id", change.ID,
+		"schedule_id", change.ScheduleID, "class_date", change.ClassDate, "recipients", recipients, "created_by", input.CreatedBy)
+	return ChangeClassOccurrenceResult{Change: change, Notice: n, Recipients: recipients}, nil
+}
+
+// occurrenceChangeText renders the notice title and plain-text body shared by the notice and email.
+// sched is the class as usually timetabled, or the session itself when one is added.
+func occurrenceChangeText(change schedule.OccurrenceChange, className string, sched schedule.Schedule, classDate time.Time) (string, string) {
+	when := classDate.Format("Monday 2 January") + " at " + sched.StartTime
+	day := classDate.Format("Mon 2 Jan")
+	var title, body string
+	switch change.Kind {
+	case schedule.ChangeCancelled:
+		title = fmt.Sprintf("Cancelled: %s, %s", className, day)
+		body = fmt.Sprintf("%s on %s is cancelled.", className, when)
+	case schedule.ChangeMoved:
+		title = fmt.Sprintf("Moved: %s, %s", className, day)
+		moved, _ := change.Apply(sched)
+		to := "runs " + moved.StartTime + "–" + moved.EndTime
+		if change.StartTime == "" {
+			to = "runs at the usual time"
+		}
+		if moved.Mat != "" {
+			to += " on " + moved.Mat
+		}
+		body = fmt.Sprintf("%s on %s %s instead.", className, when, to)
+	case schedule.ChangeAdded:
+		title = fmt.Sprintf("Extra class: %s, %s", className, day)
+		body = fmt.Sprintf("An extra %s runs on %s, %s–%s", className, classDate.Format("Monday 2 January"), sched.StartTime, sched.EndTime)
+		if sched.Mat != "" {
+			body += " on " + sched.Mat
+		}
+		body += "."
+	default:
+		title = fmt.Sprintf("Substitute coach: %s, %s", className, day)
+		body = fmt.Sprintf("%s will be taking %s on %s.", change.Substitute, className, when)
+	}
+	if change.Substitute != "" && change.Kind != schedule.ChangeSubstitute {
+		body += " Coached by " + change.Substitute + "."
+	}
+	if change.Reason != "" {
+		body += " " + change.Reason
+	}
+	return title, body
+}
+
+// queueOccurrenceEmail schedules the change email for immediate dispatch to the given members.
+// Members without an email address are skipped; no email is created when nobody is left.
+func queueOccurrenceEmail(ctx context.Context, subject, body string, memberIDs []string, senderID string, deps ChangeClassOccurrenceDeps) (string, int, error) {
+	now := deps.Now()
+	em := emailDomain.Email{
+		ID:        deps.GenerateID(),
+		Subject:   subject,
+		Body:      "<p>" + html.EscapeString(body) + "</p>",
+		Category:  emailDomain.CategoryAnnouncements,
+		SenderID:  senderID,
+		Status:    emailDomain.StatusDraft,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	recipients, _ := resolveRecipients(ctx, em.ID, memberIDs, deps.MemberLookup)
+	var addressed []emailDomain.Recipient
+	for _, r := range recipients {
+		if r.MemberEmail != "" {
+			addressed = append(addressed, r)
+		}
+	}
+	if len(addressed) == 0 {
+		return "", 0, nil
+	}
+
+	if err := deps.EmailStore.Save(ctx, em); err != nil {
+		return "", 0, err
+	}
+	if err := deps.EmailStore.SaveRecipients(ctx, em.ID, addressed); err != nil {
+		return "", 0, err
+	}
+	if err := em.Schedule(now); err != nil {
+		return "", 0, err
+	}
+	if err := deps.EmailStore.Save(ctx, em); err != nil {
+		return "", 0, err
+	}
+	return em.ID, len(addressed), nil
+}
+
+// --- Revert Class Occurrence ---
+
+// RevertClassOccurrenceDeps holds dependencies for RevertClassOccurrence.
+type RevertClassOccurrenceDeps struct {
+	ChangeStore OccurrenceChangeStore
+	NoticeStore NoticeStoreForOrchestrator
+	EmailStore  OccurrenceEmailStore
+	Now         func() time.Time
+}
+
+// ExecuteRevertClassOccurrence undoes a change, restoring the regular class or removing an added session.
+// The change's notice stops showing immediately and its email is withdrawn if it has not gone out yet.
+// PRE: changeID identifies an existing change
+// POST: Change deleted; its notice retired; its email cancelled when still scheduled
+func ExecuteRevertClassOccurrence(ctx context.Context, changeID string, deps RevertClassOccurrenceDeps) (schedule.OccurrenceChange, error) {
+	change, err := deps.ChangeStore.GetByID(ctx, changeID)
+	if err != nil {
+		return schedule.OccurrenceChange{}, err
+	}
+	if err := deps.ChangeStore.Delete(ctx, change.ID); err != nil {
+		return schedule.OccurrenceChange{}, err
+	}
+
+	now := deps.Now()
+	if change.NoticeID != "" {
+		if n, err := deps.NoticeStore.GetByID(ctx, change.NoticeID); err == nil {
+			n.VisibleUntil = now
+			n.UpdatedAt = now
+			if err := deps.NoticeStore.Save(ctx, n); err != nil {
+				slog.WarnContext(ctx, "class_occurrence_notice_retire_failed", "notice_id", n.ID, "error", err)
+			}
+		}
+	}
+	if change.EmailID != "" {
+		if em, err := deps.EmailStore.GetByID(ctx, change.EmailID); err == nil && em.Cancel() == nil {
+			em.UpdatedAt = now
+			if err := deps.EmailStore.Save(ctx, em); err != nil {
+				slog.WarnContext(ctx, "class_occurrence_email_cancel_failed", "email_id", em.ID, "error", err)
+			}
+		}
+	}
+
+	slog.InfoContext(ctx, "schedule_event", "event", "class_occurrence_reverted", "change_id", change.ID,
+		"schedule_id", change.ScheduleID, "class_date", change.ClassDate)
+	return change, nil
+}
@@ -0,0 +1,230 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	emailDomain "workshop/internal/domain/email"
+	"workshop/internal/domain/notice"
+	"workshop/internal/domain/schedule"
+)
+
+// mockOccurrenceChangeStore implements OccurrenceChangeStore for testing.
+type mockOccurrenceChangeStore struct {
+	changes map[string]schedule.OccurrenceChange
+}
+
+// GetByID implements OccurrenceChangeStore.
+// PRE: id is non-empty
+// POST: returns the change or sql.ErrNoRows
+func (m *mockOccurrenceChangeStore) GetByID(_ context.Context, id string) (schedule.OccurrenceChange, error) {
+	c, ok := m.changes[id]
+	if !ok {
+		return schedule.OccurrenceChange{}, sql.ErrNoRows
+	}
+	return c, nil
+}
+
+// GetByOccurrence implements OccurrenceChangeStore.
+// PRE: scheduleID and classDate are non-empty
+// POST: returns the change for the occurrence or sql.ErrNoRows
+func (m *mockOccurrenceChangeStore) GetByOccurrence(_ context.Context, scheduleID, classDate string) (schedule.OccurrenceChange, error) {
+	for _, c := range m.changes {
+		if c.ScheduleID == scheduleID && c.ClassDate == classDate {
+			return c, nil
+		}
+	}
+	return schedule.OccurrenceChange{}, sql.ErrNoRows
+}
+
+// Save implements OccurrenceChangeStore.
+// PRE: value is valid
+// POST: change is stored by ID
+func (m *mockOccurrenceChangeStore) Save(_ context.Context, value schedule.OccurrenceChange) error {
+	m.changes[value.ID] = value
+	return nil
+}
+
+// Delete implements OccurrenceChangeStore.
+// PRE: id is non-empty
+// POST: change is removed
+func (m *mockOccurrenceChangeStore) Delete(_ context.Context, id string) error {
+	delete(m.changes, id)
+	return nil
+}
+
+// mockOccurrenceAttendanceStore implements OccurrenceAttendanceStore for testing.
+type mockOccurrenceAttendanceStore struct {
+	since string // last since argument, for assertions
+}
+
+// ListDistinctMemberIDsByScheduleIDsSince implements OccurrenceAttendanceStore.
+// PRE: scheduleIDs is non-empty
+// POST: returns two known members and one unknown to the lookup
+func (m *mockOccurrenceAttendanceStore) ListDistinctMemberIDsByScheduleIDsSince(_ context.Context, _ []string, since string) ([]string, error) {
+	m.since = since
+	return []string{"member-1", "member-2", "member-gone"}, nil
+}
+
+func newOccurrenceDeps() (ChangeClassOccurrenceDeps, *mockOccurrenceChangeStore, *mockOccurrenceAttendanceStore) {
+	changes := &mockOccurrenceChangeStore{changes: map[string]schedule.OccurrenceChange{}}
+	attendance := &mockOccurrenceAttendanceStore{}
+	return ChangeClassOccurrenceDeps{
+		ChangeStore:     changes,
+		ScheduleStore:   &mockSessionLogScheduleStore{}, // Sundays at 10:00, class type ct1
+		ClassTypeStore:  &mockAudienceClassTypes{},
+		AttendanceStore: attendance,
+		NoticeStore:     newMockNoticeStore(),
+		EmailStore:      newMockEmailStore(),
+		MemberLookup:    newMockMemberLookup(),
+		GenerateID:      sequentialIDs(),
+		Now:             fixedNow,
+	}, changes, attendance
+}
+
+// TestExecuteChangeClassOccurrence_Cancel verifies a cancellation publishes a notice and emails recent attendees.
+func TestExecuteChangeClassOccurrence_Cancel(t *testing.T) {
+	deps, changes, attendance := newOccurrenceDeps()
+
+	result, err := ExecuteChangeClassOccurrence(context.Background(), ChangeClassOccurrenceInput{
+		ScheduleID: "s1",
+		ClassDate:  "2026-03-08",
+		Kind:       schedule.ChangeCancelled,
+		Substitute: "ignored",
+		Reason:     "Mats are being replaced.",
+		CreatedBy:  "coach-1",
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	saved, ok := changes.changes[result.Change.ID]
+	if !ok || saved.NoticeID == "" || saved.EmailID == "" || saved.Substitute != "" {
+		t.Fatalf("saved change = %+v, want notice and email linked and no substitute", saved)
+	}
+	n := deps.NoticeStore.(*mockNoticeStoreForOrch).notices[saved.NoticeID]
+	if n.Status != notice.StatusPublished || n.Type != notice.TypeClassSpecific || n.TargetID != "ct1" {
+		t.Errorf("notice = %+v, want a published class notice for ct1", n)
+	}
+	if n.Title != "Cancelled: Kids Gi, Sun 8 Mar" || n.VisibleUntil.Format("2006-01-02") != "2026-03-09" {
+		t.Errorf("notice title %q until %v, want the class named and hidden after the class date", n.Title, n.VisibleUntil)
+	}
+	if attendance.since != "2026-02-08" {
+		t.Errorf("attendees since %s, want four weeks before the class", attendance.since)
+	}
+
+	emails := deps.EmailStore.(*mockEmailStore)
+	if em := emails.emails[saved.EmailID]; em.Status != emailDomain.StatusScheduled || em.Subject != n.Title {
+		t.Errorf("email = %+v, want it scheduled with the notice title", em)
+	}
+	if result.Recipients != 2 || len(emails.recipients[saved.EmailID]) != 2 {
+		t.Errorf("recipients = %d, want the two members the lookup knows", result.Recipients)
+	}
+
+	if _, err := ExecuteChangeClassOccurrence(context.Background(), ChangeClassOccurrenceInput{
+		ScheduleID: "s1", ClassDate: "2026-03-08", Kind: schedule.ChangeSubstitute, Substitute: "Coach Sam", CreatedBy: "coach-1",
+	}, deps); err != ErrOccurrenceAlreadyChanged {
+		t.Errorf("second change: err = %v, want ErrOccurrenceAlreadyChanged", err)
+	}
+}
+
+// TestExecuteChangeClassOccurrence_MoveAndAdd verifies a moved class emails its regulars with the
+// new time, and an added session is announced by notice only.
+func TestExecuteChangeClassOccurrence_MoveAndAdd(t *testing.T) {
+	deps, changes, _ := newOccurrenceDeps()
+	ctx := context.Background()
+
+	moved, err := ExecuteChangeClassOccurrence(ctx, ChangeClassOccurrenceInput{
+		ScheduleID: "s1", ClassDate: "2026-03-08", Kind: schedule.ChangeMoved,
+		StartTime: "11:00", EndTime: "12:00", Mat: "Mat 2", ClassTypeID: "ignored", CreatedBy: "coach-1",
+	}, deps)
+	if err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if saved := changes.changes[moved.Change.ID]; saved.EmailID == "" || saved.ClassTypeID != "" || saved.Mat != "Mat 2" {
+		t.Errorf("moved change = %+v, want emailed with the mat and no class type", saved)
+	}
+	if moved.Notice.Title != "Moved: Kids Gi, Sun 8 Mar" || moved.Notice.Content != "Kids Gi on Sunday 8 March at 10:00 runs 11:00–12:00 on Mat 2 instead." {
+		t.Errorf("moved notice = %q / %q", moved.Notice.Title, moved.Notice.Content)
+	}
+
+	added, err := ExecuteChangeClassOccurrence(ctx, ChangeClassOccurrenceInput{
+		ClassDate: "2026-03-09", Kind: schedule.ChangeAdded, ClassTypeID: "ct1",
+		StartTime: "18:00", EndTime: "19:30", LocationID: "loc-1", CreatedBy: "coach-1",
+	}, deps)
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	saved := changes.changes[added.Change.ID]
+	if saved.ScheduleID != saved.ID || saved.EmailID != "" || added.Recipients != 0 || saved.NoticeID == "" {
+		t.Errorf("added change = %+v, want its own schedule ID, a notice and no email", saved)
+	}
+	if added.Notice.Title != "Extra class: Kids Gi, Mon 9 Mar" || added.Notice.Content != "An extra Kids Gi runs on Monday 9 March, 18:00–19:30." {
+		t.Errorf("added notice = %q / %q", added.Notice.Title, added.Notice.Content)
+	}
+
+	if _, err := ExecuteChangeClassOccurrence(ctx, ChangeClassOccurrenceInput{
+		ScheduleID: "s1", ClassDate: "2026-03-15", Kind: schedule.ChangeMoved, CreatedBy: "coach-1",
+	}, deps); err != schedule.ErrNothingMoved {
+		t.Errorf("move without a time or mat: err = %v, want ErrNothingMoved", err)
+	}
+}
+
+// TestExecuteChangeClassOccurrence_Rejects verifies invalid occurrences save nothing.
+func TestExecuteChangeClassOccurrence_Rejects(t *testing.T) {
+	deps, changes, _ := newOccurrenceDeps()
+	tests := []struct {
+		name    string
+		input   ChangeClassOccurrenceInput
+		wantErr error
+	}{
+		{"wrong weekday", ChangeClassOccurrenceInput{ScheduleID: "s1", ClassDate: "2026-03-09", Kind: schedule.ChangeCancelled, CreatedBy: "c"}, schedule.ErrWrongDay},
+		{"past class", ChangeClassOccurrenceInput{ScheduleID: "s1", ClassDate: "2026-02-22", Kind: schedule.ChangeCancelled, CreatedBy: "c"}, ErrOccurrenceInPast},
+		{"substitute without coach", ChangeClassOccurrenceInput{ScheduleID: "s1", ClassDate: "2026-03-08", Kind: schedule.ChangeSubstitute, CreatedBy: "c"}, schedule.ErrEmptySubstitute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ExecuteChangeClassOccurrence(context.Background(), tt.input, deps); err != tt.wantErr {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if len(changes.changes) != 0 || len(deps.NoticeStore.(*mockNoticeStoreForOrch).notices) != 0 {
+		t.Error("expected nothing saved")
+	}
+}
+
+// TestExecuteRevertClassOccurrence verifies undoing a substitution retires its notice and withdraws its email.
+func TestExecuteRevertClassOccurrence(t *testing.T) {
+	deps, changes, _ := newOccurrenceDeps()
+	result, err := ExecuteChangeClassOccurrence(context.Background(), ChangeClassOccurrenceInput{
+		ScheduleID: "s1", ClassDate: "2026-03-01", Kind: schedule.ChangeSubstitute, Substitute: "Coach Sam", CreatedBy: "coach-1",
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Notice.Content != "Coach Sam will be taking Kids Gi on Sunday 1 March at 10:00." {
+		t.Errorf("content = %q", result.Notice.Content)
+	}
+
+	notices := deps.NoticeStore.(*mockNoticeStoreForOrch)
+	emails := deps.EmailStore.(*mockEmailStore)
+	if _, err := ExecuteRevertClassOccurrence(context.Background(), result.Change.ID, RevertClassOccurrenceDeps{
+		ChangeStore: changes,
+		NoticeStore: notices,
+		EmailStore:  emails,
+		Now:         fixedNow,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes.changes) != 0 {
+		t.Error("expected the change deleted")
+	}
+	if n := notices.notices[result.Notice.ID]; n.IsVisible(fixedTime.Add(1)) {
+		t.Error("expected the notice hidden")
+	}
+	if em := emails.emails[result.Change.EmailID]; em.Status != emailDomain.StatusCancelled {
+		t.Errorf("email status = %s, want cancelled", em.Status)
+	}
+}
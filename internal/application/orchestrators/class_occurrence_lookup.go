@@ -0,0 +1,60 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+
+	"workshop/internal/domain/schedule"
+)
+
+// ErrClassCancelled is returned when checking into a class that has been cancelled for the day.
+var ErrClassCancelled = errors.New("this class has been cancelled")
+
+// OccurrenceLookupStore defines the occurrence change store interface needed to resolve a class on a date.
+type OccurrenceLookupStore interface {
+	GetByOccurrence(ctx context.Context, scheduleID, classDate string) (schedule.OccurrenceChange, error)
+}
+
+// datedScheduleLookup resolves schedules as they run on one date: moved classes take their new
+// time and mat, added sessions are found by their own ID, and cancelled classes are refused.
+type datedScheduleLookup struct {
+	schedules ScheduleLookupStore // optional: nil resolves added sessions only
+	changes   OccurrenceLookupStore
+	date      string // YYYY-MM-DD
+}
+
+// GetByID implements ScheduleLookupStore.
+// PRE: id is non-empty
+// POST: Returns the class as it runs on the date, or ErrClassCancelled
+func (l datedScheduleLookup) GetByID(ctx context.Context, id string) (schedule.Schedule, error) {
+	change, changeErr := l.changes.GetByOccurrence(ctx, id, l.date)
+	if changeErr == nil && change.IsAdded() {
+		return change.Session(), nil
+	}
+	if l.schedules == nil {
+		return schedule.Schedule{}, errors.New("schedule not found")
+	}
+	s, err := l.schedules.GetByID(ctx, id)
+	if err != nil || changeErr != nil {
+		return s, err
+	}
+	runs, ok := change.Apply(s)
+	if !ok {
+		return schedule.Schedule{}, ErrClassCancelled
+	}
+	return runs, nil
+}
+
+// withClassChanges returns schedule lookups that honour the class changes recorded for date,
+// for check-in and for its eligibility check. Both are returned unchanged when changes is nil.
+func withClassChanges(schedules ScheduleLookupStore, eligibility *ClassEligibilityDeps, changes OccurrenceLookupStore, date string) (ScheduleLookupStore, *ClassEligibilityDeps) {
+	if changes == nil {
+		return schedules, eligibility
+	}
+	if eligibility != nil {
+		e := *eligibility
+		e.ScheduleStore = datedScheduleLookup{schedules: e.ScheduleStore, changes: changes, date: date}
+		eligibility = &e
+	}
+	return datedScheduleLookup{schedules: schedules, changes: changes, date: date}, eligibility
+}
@@ -0,0 +1,47 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/schedule"
+)
+
+// TestExecuteCheckInMember_FollowsClassChanges verifies check-in refuses a cancelled class,
+// times a moved class by its new slot and accepts a one-off session.
+func TestExecuteCheckInMember_FollowsClassChanges(t *testing.T) {
+	today := time.Now().Format("2006-01-02")
+	store := &mockBulkSyncAttendanceStore{}
+	deps := CheckInMemberDeps{
+		MemberStore:     &mockBulkSyncMemberStore{members: map[string]member.Member{"m1": {ID: "m1", Name: "Alice", Status: member.StatusActive}}},
+		AttendanceStore: store,
+		ScheduleStore:   newAnomalyScheduleStore(),
+		ChangeStore: &mockOccurrenceChangeStore{changes: map[string]schedule.OccurrenceChange{
+			"c1": {ID: "c1", ScheduleID: "fundamentals", ClassDate: today, Kind: schedule.ChangeCancelled},
+			"c2": {ID: "c2", ScheduleID: "open-mat", ClassDate: today, Kind: schedule.ChangeMoved, StartTime: "20:00", EndTime: "21:30"},
+			"x1": {ID: "x1", ScheduleID: "x1", ClassDate: today, Kind: schedule.ChangeAdded, ClassTypeID: "ct1", StartTime: "06:00", EndTime: "07:00", LocationID: "north"},
+		}},
+	}
+	ctx := context.Background()
+
+	if err := ExecuteCheckInMember(ctx, CheckInMemberInput{MemberID: "m1", ScheduleID: "fundamentals", ClassDate: today}, deps); !errors.Is(err, ErrClassCancelled) {
+		t.Fatalf("cancelled class: err = %v, want ErrClassCancelled", err)
+	}
+	for _, id := range []string{"x1", "advanced", "open-mat"} {
+		if err := ExecuteCheckInMember(ctx, CheckInMemberInput{MemberID: "m1", ScheduleID: id, ClassDate: today}, deps); err != nil {
+			t.Fatalf("check into %s: %v", id, err)
+		}
+	}
+	if len(store.records) != 3 {
+		t.Fatalf("records = %d, want 3", len(store.records))
+	}
+	if a := store.records[0]; a.MatHours != 1 || a.LocationID != "north" {
+		t.Errorf("added session record = %+v, want an hour at north", a)
+	}
+	if a := store.records[2]; a.MatHours != 1.5 {
+		t.Errorf("moved class mat hours = %v, want 1.5 from its new times", a.MatHours)
+	}
+}
@@ -0,0 +1,212 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/audit"
+	"workshop/internal/domain/classpack"
+	"workshop/internal/domain/member"
+)
+
+// Class pack errors
+var (
+	ErrClassPackMemberNotFound = errors.New("member not found")
+	ErrClassPackMemberArchived = errors.New("archived members cannot buy a class pack")
+)
+
+// ClassPackStore defines the class pack store interface needed to sell and use packs.
+type ClassPackStore interface {
+	GetByID(ctx context.Context, id string) (classpack.ClassPack, error)
+	Save(ctx context.Context, value classpack.ClassPack) error
+	ListByMemberID(ctx context.Context, memberID string) ([]classpack.ClassPack, error)
+	SaveUse(ctx context.Context, value classpack.Use) error
+	GetUse(ctx context.Context, attendanceID string) (classpack.Use, error)
+	DeleteUse(ctx context.Context, attendanceID string) error
+	UseClass(ctx context.Context, value classpack.Use) error
+	RefundClass(ctx context.Context, attendanceID string) (classpack.Use, bool, error)
+}
+
+// maxPackUseAttempts bounds the retries when another check-in takes a pack's last class first.
+const maxPackUseAttempts = 3
+
+// ClassPackMemberStore defines the member store interface needed to sell a pack.
+type ClassPackMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+}
+
+// AddClassPackInput carries a pack sold to a member.
+type AddClassPackInput struct {
+	MemberID   string
+	Classes    int
+	PriceCents int
+	ExpiresOn  string // optional YYYY-MM-DD; empty never expires
+	Note       string
+	Actor      BackfillActor
+}
+
+// AddClassPackDeps holds dependencies for AddClassPack.
+type AddClassPackDeps struct {
+	MemberStore ClassPackMemberStore
+	PackStore   ClassPackStore
+	AuditStore  BackfillAuditStore
+	GenerateID  func() string
+	Now         func() time.Time
+}
+
+// ExecuteAddClassPack records a class pack sold to a member and audits the sale.
+// PRE: input.Actor.AccountID is an admin
+// POST: Returns the saved pack; a classpack error when invalid, or ErrClassPackMemberNotFound /
+// ErrClassPackMemberArchived when the member cannot buy one
+func ExecuteAddClassPack(ctx context.Context, input AddClassPackInput, deps AddClassPackDeps) (classpack.ClassPack, error) {
+	if input.MemberID == "" {
+		return classpack.ClassPack{}, classpack.ErrEmptyMemberID
+	}
+	m, err := deps.MemberStore.GetByID(ctx, input.MemberID)
+	if err != nil {
+		return classpack.ClassPack{}, ErrClassPackMemberNotFound
+	}
+	if m.IsArchived() {
+		return classpack.ClassPack{}, ErrClassPackMemberArchived
+	}
+	p := classpack.ClassPack{
+		ID:          deps.GenerateID(),
+		MemberID:    m.ID,
+		Classes:     input.Classes,
+		PriceCents:  input.PriceCents,
+		PurchasedAt: deps.Now(),
+		ExpiresOn:   input.ExpiresOn,
+		CreatedBy:   input.Actor.AccountID,
+		Note:        strings.TrimSpace(input.Note),
+	}
+	if err := p.Validate(); err != nil {
+		return classpack.ClassPack{}, err
+	}
+	if err := deps.PackStore.Save(ctx, p); err != nil {
+		return classpack.ClassPack{}, err
+	}
+
+	metadata, _ := json.Marshal(map[string]string{
+		"member_id":   p.MemberID,
+		"classes":     strconv.Itoa(p.Classes),
+		"price_cents": strconv.Itoa(p.PriceCents),
+		"expires_on":  p.ExpiresOn,
+	})
+	event := audit.NewEvent(input.Actor.AccountID, input.Actor.Email, input.Actor.Role, audit.CategoryBilling, audit.ActionCreate).
+		WithResource("class_pack", p.ID).
+		WithDescription("Sold a "+strconv.Itoa(p.Classes)+"-class pack to "+m.Name).
+		WithRequest(input.Actor.IPAddress, input.Actor.UserAgent).
+		WithMetadata(string(metadata))
+	if err := deps.AuditStore.Save(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "billing_event", "event", "class_pack_audit_failed", "pack_id", p.ID, "error", err)
+	}
+	slog.InfoContext(ctx, "billing_event", "event", "class_pack_sold", "pack_id", p.ID, "member_id", p.MemberID, "classes", p.Classes)
+	return p, nil
+}
+
+// UseClassPackDeps holds dependencies for UseClassPack.
+type UseClassPackDeps struct {
+	PackStore ClassPackStore
+}
+
+// ExecuteUseClassPack takes one class off the member's current pack for a check-in. A member
+// with no active pack checks in as usual; nothing is used. The class is taken and the use
+// recorded together, and only while the pack has a class left; if another check-in takes the
+// last class first, the next pack is tried.
+// PRE: a has been saved
+// POST: Returns the pack used and true, or false when no pack was active or the check-in
+// already used one
+func ExecuteUseClassPack(ctx context.Context, a attendance.Attendance, deps UseClassPackDeps) (classpack.ClassPack, bool, error) {
+	if _, err := deps.PackStore.GetUse(ctx, a.ID); err == nil {
+		return classpack.ClassPack{}, false, nil // replayed check-in
+	}
+	for attempt := 0; attempt < maxPackUseAttempts; attempt++ {
+		packs, err := deps.PackStore.ListByMemberID(ctx, a.MemberID)
+		if err != nil {
+			return classpack.ClassPack{}, false, err
+		}
+		p, ok := classpack.Current(packs, a.CheckInTime)
+		if !ok {
+			return classpack.ClassPack{}, false, nil
+		}
+		if err := p.Use(a.CheckInTime); err != nil {
+			return classpack.ClassPack{}, false, err
+		}
+		err = deps.PackStore.UseClass(ctx, classpack.Use{AttendanceID: a.ID, PackID: p.ID, MemberID: a.MemberID, UsedAt: a.CheckInTime})
+		switch {
+		case errors.Is(err, classpack.ErrAlreadyUsed):
+			return classpack.ClassPack{}, false, nil // replayed at the same time
+		case errors.Is(err, classpack.ErrPackUsedUp):
+			continue // the last class went to another check-in
+		case err != nil:
+			return classpack.ClassPack{}, false, err
+		}
+		slog.InfoContext(ctx, "checkin_event", "event", "class_pack_used", "attendance_id", a.ID, "pack_id", p.ID, "remaining", p.Remaining())
+		return p, true, nil
+	}
+	return classpack.ClassPack{}, false, nil
+}
+
+// ExecuteRefundClassPack gives back the class a check-in used, when the check-in is undone.
+// PRE: attendanceID is non-empty
+// POST: The pack has one more class left and the use is removed; no-op when the check-in used no pack
+func ExecuteRefundClassPack(ctx context.Context, attendanceID string, deps UseClassPackDeps) error {
+	use, ok, err := deps.PackStore.RefundClass(ctx, attendanceID)
+	if err != nil || !ok {
+		return err
+	}
+	slog.InfoContext(ctx, "checkin_event", "event", "class_pack_refunded", "attendance_id", attendanceID, "pack_id", use.PackID)
+	return nil
+}
+
+// useClassPack is the best-effort pack use after a check-in: a failure is logged, not
+// returned, so it never blocks the member getting on the mats.
+func useClassPack(ctx context.Context, a attendance.Attendance, deps *UseClassPackDeps) {
+	if deps == nil {
+		return
+	}
+	if _, _, err := ExecuteUseClassPack(ctx, a, *deps); err != nil {
+		slog.ErrorContext(ctx, "checkin_event", "event", "class_pack_use_failed", "attendance_id", a.ID, "error", err)
+	}
+}
+
+// refundClassPack is the best-effort refund after a check-in is removed.
+func refundClassPack(ctx context.Context, attendanceID string, deps *UseClassPackDeps) {
+	if deps == nil {
+		return
+	}
+	if err := ExecuteRefundClassPack(ctx, attendanceID, *deps); err != nil {
+		slog.ErrorContext(ctx, "checkin_event", "event", "class_pack_refund_failed", "attendance_id", attendanceID, "error", err)
+	}
+}
+
+// mergeClassPackUse is the best-effort pack fix after check-in fromID is merged into keepID:
+// the member came to one class, so one credit stays used. A use on fromID moves to keepID,
+// or is refunded when keepID already has its own.
+func mergeClassPackUse(ctx context.Context, keepID, fromID string, deps *UseClassPackDeps) {
+	if deps == nil {
+		return
+	}
+	use, err := deps.PackStore.GetUse(ctx, fromID)
+	if err != nil {
+		return // the merged check-in used no pack
+	}
+	if _, err := deps.PackStore.GetUse(ctx, keepID); err == nil {
+		refundClassPack(ctx, fromID, deps)
+		return
+	}
+	use.AttendanceID = keepID
+	if err := deps.PackStore.SaveUse(ctx, use); err != nil {
+		slog.ErrorContext(ctx, "checkin_event", "event", "class_pack_move_failed", "attendance_id", fromID, "error", err)
+		return
+	}
+	if err := deps.PackStore.DeleteUse(ctx, fromID); err != nil {
+		slog.ErrorContext(ctx, "checkin_event", "event", "class_pack_move_failed", "attendance_id", fromID, "error", err)
+	}
+}
@@ -0,0 +1,247 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/classpack"
+	"workshop/internal/domain/member"
+)
+
+type mockClassPackStore struct {
+	packs map[string]classpack.ClassPack
+	uses  map[string]classpack.Use
+}
+
+func newMockClassPackStore(packs ...classpack.ClassPack) *mockClassPackStore {
+	s := &mockClassPackStore{packs: map[string]classpack.ClassPack{}, uses: map[string]classpack.Use{}}
+	for _, p := range packs {
+		s.packs[p.ID] = p
+	}
+	return s
+}
+
+// GetByID implements ClassPackStore.
+// PRE: none
+// POST: Returns the pack or an error
+func (m *mockClassPackStore) GetByID(_ context.Context, id string) (classpack.ClassPack, error) {
+	p, ok := m.packs[id]
+	if !ok {
+		return classpack.ClassPack{}, errors.New("not found")
+	}
+	return p, nil
+}
+
+// Save implements ClassPackStore.
+// PRE: none
+// POST: The pack is stored
+func (m *mockClassPackStore) Save(_ context.Context, p classpack.ClassPack) error {
+	m.packs[p.ID] = p
+	return nil
+}
+
+// ListByMemberID implements ClassPackStore.
+// PRE: none
+// POST: Returns the member's packs
+func (m *mockClassPackStore) ListByMemberID(_ context.Context, memberID string) ([]classpack.ClassPack, error) {
+	var out []classpack.ClassPack
+	for _, p := range m.packs {
+		if p.MemberID == memberID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// SaveUse implements ClassPackStore.
+// PRE: none
+// POST: The use is stored
+func (m *mockClassPackStore) SaveUse(_ context.Context, u classpack.Use) error {
+	m.uses[u.AttendanceID] = u
+	return nil
+}
+
+// GetUse implements ClassPackStore.
+// PRE: none
+// POST: Returns the use or an error
+func (m *mockClassPackStore) GetUse(_ context.Context, attendanceID string) (classpack.Use, error) {
+	u, ok := m.uses[attendanceID]
+	if !ok {
+		return classpack.Use{}, errors.New("not found")
+	}
+	return u, nil
+}
+
+// DeleteUse implements ClassPackStore.
+// PRE: none
+// POST: The use is removed
+func (m *mockClassPackStore) DeleteUse(_ context.Context, attendanceID string) error {
+	delete(m.uses, attendanceID)
+	return nil
+}
+
+// UseClass implements ClassPackStore.
+// PRE: none
+// POST: The pack is debited and the use stored, unless the check-in already used one or the pack is used up
+func (m *mockClassPackStore) UseClass(_ context.Context, u classpack.Use) error {
+	if _, ok := m.uses[u.AttendanceID]; ok {
+		return classpack.ErrAlreadyUsed
+	}
+	p := m.packs[u.PackID]
+	if p.Used >= p.Classes {
+		return classpack.ErrPackUsedUp
+	}
+	p.Used++
+	m.packs[p.ID] = p
+	m.uses[u.AttendanceID] = u
+	return nil
+}
+
+// RefundClass implements ClassPackStore.
+// PRE: none
+// POST: The use is removed and its pack credited
+func (m *mockClassPackStore) RefundClass(_ context.Context, attendanceID string) (classpack.Use, bool, error) {
+	u, ok := m.uses[attendanceID]
+	if !ok {
+		return classpack.Use{}, false, nil
+	}
+	delete(m.uses, attendanceID)
+	p := m.packs[u.PackID]
+	p.Refund()
+	m.packs[p.ID] = p
+	return u, true, nil
+}
+
+// TestExecuteAddClassPack verifies a sale is saved and audited.
+func TestExecuteAddClassPack(t *testing.T) {
+	packs := newMockClassPackStore()
+	auditStore := &mockBackfillAuditStore{}
+	deps := AddClassPackDeps{
+		MemberStore: &mockBulkSyncMemberStore{members: map[string]member.Member{
+			"m1": {ID: "m1", Name: "Alice", Status: member.StatusActive},
+			"m2": {ID: "m2", Name: "Bob", Status: member.StatusArchived},
+		}},
+		PackStore:  packs,
+		AuditStore: auditStore,
+		GenerateID: func() string { return "p1" },
+		Now:        fixedNow,
+	}
+	actor := BackfillActor{AccountID: "admin-1", Email: "admin@example.com", Role: "admin"}
+
+	p, err := ExecuteAddClassPack(context.Background(), AddClassPackInput{MemberID: "m1", Classes: 3, PriceCents: 4500, ExpiresOn: "2099-01-01", Note: " trial ", Actor: actor}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Note != "trial" || p.CreatedBy != "admin-1" || !p.PurchasedAt.Equal(fixedTime) {
+		t.Errorf("pack = %+v", p)
+	}
+	if _, ok := packs.packs["p1"]; !ok {
+		t.Error("expected the pack to be saved")
+	}
+	if len(auditStore.events) != 1 || auditStore.events[0].ResourceID != "p1" {
+		t.Errorf("expected one audit event for p1, got %+v", auditStore.events)
+	}
+
+	if _, err := ExecuteAddClassPack(context.Background(), AddClassPackInput{MemberID: "m2", Classes: 3, Actor: actor}, deps); err != ErrClassPackMemberArchived {
+		t.Errorf("archived member: err = %v, want ErrClassPackMemberArchived", err)
+	}
+	if _, err := ExecuteAddClassPack(context.Background(), AddClassPackInput{MemberID: "m1", Classes: 0, Actor: actor}, deps); err != classpack.ErrInvalidClasses {
+		t.Errorf("no classes: err = %v, want ErrInvalidClasses", err)
+	}
+}
+
+// TestExecuteUseClassPack verifies a check-in uses the soonest-expiring pack once, and an
+// undo gives the class back.
+func TestExecuteUseClassPack(t *testing.T) {
+	packs := newMockClassPackStore(
+		classpack.ClassPack{ID: "later", MemberID: "m1", Classes: 10, PurchasedAt: fixedTime},
+		classpack.ClassPack{ID: "sooner", MemberID: "m1", Classes: 3, PurchasedAt: fixedTime, ExpiresOn: "2099-01-01"},
+	)
+	deps := UseClassPackDeps{PackStore: packs}
+	a := attendance.Attendance{ID: "att-1", MemberID: "m1", CheckInTime: fixedTime}
+
+	p, used, err := ExecuteUseClassPack(context.Background(), a, deps)
+	if err != nil || !used || p.ID != "sooner" {
+		t.Fatalf("ExecuteUseClassPack() = %q %v %v, want sooner", p.ID, used, err)
+	}
+	if _, used, _ := ExecuteUseClassPack(context.Background(), a, deps); used {
+		t.Error("a replayed check-in used a second class")
+	}
+	if packs.packs["sooner"].Used != 1 {
+		t.Errorf("Used = %d, want 1", packs.packs["sooner"].Used)
+	}
+
+	if err := ExecuteRefundClassPack(context.Background(), "att-1", deps); err != nil {
+		t.Fatalf("ExecuteRefundClassPack() error: %v", err)
+	}
+	if packs.packs["sooner"].Used != 0 || len(packs.uses) != 0 {
+		t.Errorf("after refund Used = %d uses = %d, want 0 and 0", packs.packs["sooner"].Used, len(packs.uses))
+	}
+	if err := ExecuteRefundClassPack(context.Background(), "att-2", deps); err != nil {
+		t.Errorf("refunding a check-in with no pack: %v", err)
+	}
+
+	if _, used, err := ExecuteUseClassPack(context.Background(), attendance.Attendance{ID: "att-3", MemberID: "m2", CheckInTime: fixedTime}, deps); used || err != nil {
+		t.Errorf("member without a pack: used = %v err = %v", used, err)
+	}
+}
+
+// racingClassPackStore lets another check-in take a pack's last class between the member's
+// packs being read and one being used.
+type racingClassPackStore struct {
+	*mockClassPackStore
+	raced bool
+}
+
+// UseClass implements ClassPackStore, letting another check-in win the first time.
+// PRE: none
+// POST: As mockClassPackStore.UseClass, after the first pack tried is used up elsewhere
+func (m *racingClassPackStore) UseClass(ctx context.Context, u classpack.Use) error {
+	if !m.raced {
+		m.raced = true
+		if err := m.mockClassPackStore.UseClass(ctx, classpack.Use{AttendanceID: "other-kiosk", PackID: u.PackID}); err != nil {
+			return err
+		}
+	}
+	return m.mockClassPackStore.UseClass(ctx, u)
+}
+
+// TestExecuteUseClassPack_LastClassRace verifies two check-ins cannot both take a pack's last
+// class: the one that loses moves on to the member's next pack.
+func TestExecuteUseClassPack_LastClassRace(t *testing.T) {
+	packs := &racingClassPackStore{mockClassPackStore: newMockClassPackStore(
+		classpack.ClassPack{ID: "sooner", MemberID: "m1", Classes: 3, Used: 2, PurchasedAt: fixedTime, ExpiresOn: "2099-01-01"},
+		classpack.ClassPack{ID: "later", MemberID: "m1", Classes: 10, PurchasedAt: fixedTime},
+	)}
+	a := attendance.Attendance{ID: "att-1", MemberID: "m1", CheckInTime: fixedTime}
+
+	p, used, err := ExecuteUseClassPack(context.Background(), a, UseClassPackDeps{PackStore: packs})
+	if err != nil || !used || p.ID != "later" {
+		t.Fatalf("ExecuteUseClassPack() = %q %v %v, want later", p.ID, used, err)
+	}
+	if packs.packs["sooner"].Used != 3 || packs.packs["later"].Used != 1 {
+		t.Errorf("used sooner %d later %d, want 3 and 1", packs.packs["sooner"].Used, packs.packs["later"].Used)
+	}
+	if packs.uses["att-1"].PackID != "later" || len(packs.uses) != 2 {
+		t.Errorf("uses = %+v, want att-1 on later", packs.uses)
+	}
+}
+
+// TestExecuteBulkSyncCheckIns_UsesClassPack verifies replayed kiosk check-ins use pack classes.
+func TestExecuteBulkSyncCheckIns_UsesClassPack(t *testing.T) {
+	packs := newMockClassPackStore(classpack.ClassPack{ID: "p1", MemberID: "m1", Classes: 3, PurchasedAt: fixedTime.AddDate(0, 0, -7)})
+	deps := newBulkSyncDeps(&mockBulkSyncAttendanceStore{})
+	deps.PackDeps = &UseClassPackDeps{PackStore: packs}
+
+	_, err := ExecuteBulkSyncCheckIns(context.Background(), BulkSyncInput{
+		Records: []BulkSyncRecord{{ClientID: "c1", MemberID: "m1", ScheduleID: "s1", CheckInTime: fixedTime}},
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if packs.packs["p1"].Used != 1 || packs.uses["att-1"].PackID != "p1" {
+		t.Errorf("expected att-1 to use a class from p1, got used %d uses %+v", packs.packs["p1"].Used, packs.uses)
+	}
+}
@@ -0,0 +1,148 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/notification"
+)
+
+// Class reminder timing and what makes a class one of a member's usual classes.
+const (
+	ClassReminderLead        = time.Hour // reminders go out this far ahead of the class
+	ClassReminderUsualDays   = 56        // attendance window, in days before today, for usual classes
+	ClassReminderUsualVisits = 3         // sessions of a class within the window that make it usual
+)
+
+// UpcomingClass is a class occurrence running today.
+type UpcomingClass struct {
+	ScheduleID    string
+	ClassTypeName string
+	StartTime     string // HH:MM, local time
+}
+
+// ClassReminderAttendanceStore counts how often each member attended a class.
+type ClassReminderAttendanceStore interface {
+	CountByScheduleIDAndDateRange(ctx context.Context, scheduleID string, startDate string, endDate string) (map[string]int, error)
+}
+
+// ClassReminderMemberStore resolves the account behind a member.
+type ClassReminderMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+}
+
+// ClassReminderStore records which reminders were sent so each goes out once.
+type ClassReminderStore interface {
+	MarkClassReminderSent(ctx context.Context, accountID, scheduleID, classDate string, sentAt time.Time) (bool, error)
+	DeleteClassRemindersBefore(ctx context.Context, classDate string) error
+}
+
+// SendClassRemindersDeps holds dependencies for SendClassReminders.
+type SendClassRemindersDeps struct {
+	// TodaysClasses lists the classes running on now's date, less holidays and cancellations.
+	TodaysClasses   func(ctx context.Context, now time.Time) ([]UpcomingClass, error)
+	AttendanceStore ClassReminderAttendanceStore
+	MemberStore     ClassReminderMemberStore
+	ReminderStore   ClassReminderStore
+	Notify          NotifyDeps
+	Now             func() time.Time
+}
+
+// SendClassRemindersResult summarises one run.
+type SendClassRemindersResult struct {
+	Classes  int // classes starting within the lead time
+	Reminded int // members reminded for the first time this run
+}
+
+// ExecuteSendClassReminders tells members that one of their usual classes starts within the hour.
+// A class is usual for a member who attended at least ClassReminderUsualVisits of its sessions
+// in the last ClassReminderUsualDays. Each member is reminded of an occurrence at most once,
+// and only on the channels they opted into for class reminders.
+// PRE: deps are complete
+// POST: Reminders are sent and recorded; failures for one class are joined and the rest still run
+func ExecuteSendClassReminders(ctx context.Context, deps SendClassRemindersDeps) (SendClassRemindersResult, error) {
+	now := deps.Now()
+	today := now.Format("2006-01-02")
+	var result SendClassRemindersResult
+	if err := deps.ReminderStore.DeleteClassRemindersBefore(ctx, today); err != nil {
+		return result, err
+	}
+	classes, err := deps.TodaysClasses(ctx, now)
+	if err != nil {
+		return result, err
+	}
+
+	from := now.AddDate(0, 0, -ClassReminderUsualDays).Format("2006-01-02")
+	to := now.AddDate(0, 0, -1).Format("2006-01-02")
+	var errs []error
+	for _, c := range classes {
+		start, err := time.ParseInLocation("2006-01-02 15:04", today+" "+c.StartTime, now.Location())
+		if err != nil {
+			continue
+		}
+		until := start.Sub(now)
+		if until <= 0 || until > ClassReminderLead {
+			continue
+		}
+		result.Classes++
+
+		counts, err := deps.AttendanceStore.CountByScheduleIDAndDateRange(ctx, c.ScheduleID, from, to)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var recipients []string
+		for memberID, n := range counts {
+			if n < ClassReminderUsualVisits {
+				continue
+			}
+			m, err := deps.MemberStore.GetByID(ctx, memberID)
+			if err != nil || m.AccountID == "" || !m.CanCheckIn() {
+				continue
+			}
+			first, err := deps.ReminderStore.MarkClassReminderSent(ctx, m.AccountID, c.ScheduleID, today, now)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if first {
+				recipients = append(recipients, m.AccountID)
+			}
+		}
+		if len(recipients) == 0 {
+			continue
+		}
+
+		if _, err := ExecuteNotify(ctx, NotifyInput{
+			AccountIDs: recipients,
+			Kind:       notification.KindClassReminder,
+			Title:      c.ClassTypeName + " at " + c.StartTime,
+			Body:       classReminderBody(until),
+			Link:       "/training-log",
+			PushTTL:    until, // a reminder is no use once the class has started
+		}, deps.Notify); err != nil {
+			errs = append(errs, err)
+		}
+		result.Reminded += len(recipients)
+	}
+
+	slog.InfoContext(ctx, "notification_event", "event", "class_reminders_sent", "classes", result.Classes, "reminded", result.Reminded)
+	return result, errors.Join(errs...)
+}
+
+// classReminderBody says how soon the class starts.
+func classReminderBody(until time.Duration) string {
+	minutes := int(math.Round(until.Minutes()))
+	if minutes >= 60 {
+		return "Starts in 1 hour"
+	}
+	if minutes <= 1 {
+		return "Starting now"
+	}
+	return fmt.Sprintf("Starts in %d minutes", minutes)
+}
@@ -0,0 +1,123 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/notification"
+)
+
+// --- Mock stores for class reminder tests ---
+
+type mockReminderAttendance struct {
+	counts map[string]map[string]int // schedule ID -> member ID -> sessions
+}
+
+// CountByScheduleIDAndDateRange implements ClassReminderAttendanceStore.
+// PRE: scheduleID is non-empty
+// POST: returns the schedule's counts
+func (m *mockReminderAttendance) CountByScheduleIDAndDateRange(_ context.Context, scheduleID, _, _ string) (map[string]int, error) {
+	return m.counts[scheduleID], nil
+}
+
+type mockReminderMembers struct {
+	members map[string]member.Member
+}
+
+// GetByID implements ClassReminderMemberStore.
+// PRE: id is non-empty
+// POST: returns the member or an error
+func (m *mockReminderMembers) GetByID(_ context.Context, id string) (member.Member, error) {
+	if mem, ok := m.members[id]; ok {
+		return mem, nil
+	}
+	return member.Member{}, fmt.Errorf("member %s not found", id)
+}
+
+type mockReminderStore struct {
+	sent map[string]bool
+}
+
+// MarkClassReminderSent implements ClassReminderStore.
+// PRE: keys are non-empty
+// POST: returns true the first time an occurrence is marked for an account
+func (m *mockReminderStore) MarkClassReminderSent(_ context.Context, accountID, scheduleID, classDate string, _ time.Time) (bool, error) {
+	key := accountID + "|" + scheduleID + "|" + classDate
+	if m.sent[key] {
+		return false, nil
+	}
+	m.sent[key] = true
+	return true, nil
+}
+
+// DeleteClassRemindersBefore implements ClassReminderStore.
+// PRE: classDate is YYYY-MM-DD
+// POST: no-op
+func (m *mockReminderStore) DeleteClassRemindersBefore(_ context.Context, _ string) error {
+	return nil
+}
+
+// TestExecuteSendClassReminders verifies regulars of a class starting within the hour get
+// one push, occasional visitors and later classes are skipped, and re-runs send nothing twice.
+func TestExecuteSendClassReminders(t *testing.T) {
+	now := time.Date(2026, 3, 2, 17, 5, 0, 0, time.UTC) // a Monday
+	pushStore := &mockNotifyPushStore{subs: []notification.PushSubscription{
+		{AccountID: "a1", Endpoint: "https://push.example.com/a1"},
+		{AccountID: "a2", Endpoint: "https://push.example.com/a2"},
+	}}
+	sender := &mockPushSender{}
+	store := &mockNotifyStore{prefs: map[string][]notification.Preference{
+		"a1": {{AccountID: "a1", Kind: notification.KindClassReminder, Push: true}},
+		"a2": {{AccountID: "a2", Kind: notification.KindClassReminder, Push: true}},
+	}}
+	deps := SendClassRemindersDeps{
+		TodaysClasses: func(_ context.Context, _ time.Time) ([]UpcomingClass, error) {
+			return []UpcomingClass{
+				{ScheduleID: "s-noon", ClassTypeName: "Open Mat", StartTime: "12:00"},
+				{ScheduleID: "s-6pm", ClassTypeName: "Fundamentals Gi", StartTime: "18:00"},
+				{ScheduleID: "s-730pm", ClassTypeName: "Advanced", StartTime: "19:30"},
+			}, nil
+		},
+		AttendanceStore: &mockReminderAttendance{counts: map[string]map[string]int{
+			"s-noon":  {"m1": 8},
+			"s-6pm":   {"m1": 5, "m2": 1, "m3": 4, "m4": 6},
+			"s-730pm": {"m2": 7},
+		}},
+		MemberStore: &mockReminderMembers{members: map[string]member.Member{
+			"m1": {ID: "m1", AccountID: "a1", Status: member.StatusActive},
+			"m2": {ID: "m2", AccountID: "a2", Status: member.StatusActive},
+			"m3": {ID: "m3", Status: member.StatusActive}, // no login, nobody to tell
+			"m4": {ID: "m4", AccountID: "a4", Status: member.StatusArchived},
+		}},
+		ReminderStore: &mockReminderStore{sent: map[string]bool{}},
+		Notify:        NotifyDeps{NotificationStore: store, PushStore: pushStore, PushSender: sender, GenerateID: fixedID, Now: func() time.Time { return now }},
+		Now:           func() time.Time { return now },
+	}
+
+	result, err := ExecuteSendClassReminders(context.Background(), deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Classes != 1 || result.Reminded != 1 {
+		t.Errorf("result = %+v, want only a1 reminded of the 18:00 class", result)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].TTL != 55*time.Minute {
+		t.Fatalf("expected one push that expires when class starts, got %+v", sender.sent)
+	}
+	var payload pushPayload
+	if err := json.Unmarshal(sender.sent[0].Payload, &payload); err != nil || payload.Title != "Fundamentals Gi at 18:00" || payload.Body != "Starts in 55 minutes" {
+		t.Errorf("payload = %+v (%v)", payload, err)
+	}
+	if len(store.saved) != 0 {
+		t.Errorf("expected no in-app notification for a push-only preference, got %d", len(store.saved))
+	}
+
+	again, err := ExecuteSendClassReminders(context.Background(), deps)
+	if err != nil || again.Reminded != 0 || len(sender.sent) != 1 {
+		t.Errorf("re-run: expected nothing sent twice, got %+v, %v", again, err)
+	}
+}
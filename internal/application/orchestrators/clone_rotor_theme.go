@@ -0,0 +1,78 @@
+package orchestrators
+
+import (
+	"context"
+	"log/slog"
+
+	"workshop/internal/domain/rotor"
+)
+
+// CloneRotorThemeStore defines the rotor store interface needed to clone a theme.
+type CloneRotorThemeStore interface {
+	GetRotor(ctx context.Context, id string) (rotor.Rotor, error)
+	GetRotorTheme(ctx context.Context, id string) (rotor.RotorTheme, error)
+	ListThemesByRotor(ctx context.Context, rotorID string) ([]rotor.RotorTheme, error)
+	ListTopicsByTheme(ctx context.Context, rotorThemeID string) ([]rotor.Topic, error)
+	SaveRotorThemeWithTopics(ctx context.Context, theme rotor.RotorTheme, topics []rotor.Topic) error
+}
+
+// CloneRotorThemeInput carries input for the clone rotor theme orchestrator.
+type CloneRotorThemeInput struct {
+	ThemeID  string // theme to copy, from any rotor
+	RotorID  string // draft rotor to copy it into
+	ClonedBy string
+}
+
+// CloneRotorThemeDeps holds dependencies for CloneRotorTheme.
+type CloneRotorThemeDeps struct {
+	RotorStore CloneRotorThemeStore
+	GenerateID func() string
+}
+
+// CloneRotorThemeResult carries the new theme and its topic queue.
+type CloneRotorThemeResult struct {
+	Theme  rotor.RotorTheme
+	Topics []rotor.Topic
+}
+
+// ExecuteCloneRotorTheme copies a theme and its topics into another rotor, after the
+// rotor's existing themes. Topics linked to the shared library stay linked.
+// PRE: ThemeID and RotorID reference existing records
+// POST: The theme and its topics are created atomically; returns rotor.ErrNotDraft if the target is not a draft
+func ExecuteCloneRotorTheme(ctx context.Context, input CloneRotorThemeInput, deps CloneRotorThemeDeps) (CloneRotorThemeResult, error) {
+	source, err := deps.RotorStore.GetRotorTheme(ctx, input.ThemeID)
+	if err != nil {
+		return CloneRotorThemeResult{}, err
+	}
+	target, err := deps.RotorStore.GetRotor(ctx, input.RotorID)
+	if err != nil {
+		return CloneRotorThemeResult{}, err
+	}
+	if !target.IsDraft() {
+		return CloneRotorThemeResult{}, rotor.ErrNotDraft
+	}
+
+	existing, err := deps.RotorStore.ListThemesByRotor(ctx, target.ID)
+	if err != nil {
+		return CloneRotorThemeResult{}, err
+	}
+	position := 0
+	for _, t := range existing {
+		if t.Position >= position {
+			position = t.Position + 1
+		}
+	}
+	topics, err := deps.RotorStore.ListTopicsByTheme(ctx, source.ID)
+	if err != nil {
+		return CloneRotorThemeResult{}, err
+	}
+
+	theme, cloned := rotor.CloneTheme(source, topics, target.ID, position, deps.GenerateID)
+	if err := deps.RotorStore.SaveRotorThemeWithTopics(ctx, theme, cloned); err != nil {
+		return CloneRotorThemeResult{}, err
+	}
+
+	slog.InfoContext(ctx, "rotor_event", "event", "theme_cloned", "theme_id", theme.ID, "source_theme_id", source.ID,
+		"rotor_id", target.ID, "topics", len(cloned), "by", input.ClonedBy)
+	return CloneRotorThemeResult{Theme: theme, Topics: cloned}, nil
+}
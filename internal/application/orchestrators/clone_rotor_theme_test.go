@@ -0,0 +1,119 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"workshop/internal/domain/rotor"
+)
+
+type mockCloneThemeStore struct {
+	rotors map[string]rotor.Rotor
+	themes map[string]rotor.RotorTheme
+	topics map[string][]rotor.Topic
+	saved  []rotor.Topic
+}
+
+// GetRotor implements CloneRotorThemeStore.
+// PRE: none
+// POST: returns the preset rotor or an error
+func (m *mockCloneThemeStore) GetRotor(_ context.Context, id string) (rotor.Rotor, error) {
+	r, ok := m.rotors[id]
+	if !ok {
+		return rotor.Rotor{}, errors.New("not found")
+	}
+	return r, nil
+}
+
+// GetRotorTheme implements CloneRotorThemeStore.
+// PRE: none
+// POST: returns the preset theme or an error
+func (m *mockCloneThemeStore) GetRotorTheme(_ context.Context, id string) (rotor.RotorTheme, error) {
+	t, ok := m.themes[id]
+	if !ok {
+		return rotor.RotorTheme{}, errors.New("not found")
+	}
+	return t, nil
+}
+
+// ListThemesByRotor implements CloneRotorThemeStore.
+// PRE: none
+// POST: returns the preset themes in the rotor
+func (m *mockCloneThemeStore) ListThemesByRotor(_ context.Context, rotorID string) ([]rotor.RotorTheme, error) {
+	var list []rotor.RotorTheme
+	for _, t := range m.themes {
+		if t.RotorID == rotorID {
+			list = append(list, t)
+		}
+	}
+	return list, nil
+}
+
+// ListTopicsByTheme implements CloneRotorThemeStore.
+// PRE: none
+// POST: returns the preset topics
+func (m *mockCloneThemeStore) ListTopicsByTheme(_ context.Context, themeID string) ([]rotor.Topic, error) {
+	return m.topics[themeID], nil
+}
+
+// SaveRotorThemeWithTopics implements CloneRotorThemeStore.
+// PRE: none
+// POST: records the theme and topics
+func (m *mockCloneThemeStore) SaveRotorThemeWithTopics(_ context.Context, theme rotor.RotorTheme, topics []rotor.Topic) error {
+	m.themes[theme.ID] = theme
+	m.saved = topics
+	return nil
+}
+
+func newMockCloneThemeStore() *mockCloneThemeStore {
+	return &mockCloneThemeStore{
+		rotors: map[string]rotor.Rotor{
+			"gi":   {ID: "gi", Status: rotor.StatusActive},
+			"nogi": {ID: "nogi", Status: rotor.StatusDraft},
+			"kids": {ID: "kids", Status: rotor.StatusActive},
+		},
+		themes: map[string]rotor.RotorTheme{
+			"takedowns": {ID: "takedowns", RotorID: "gi", Name: "Takedowns"},
+			"guard":     {ID: "guard", RotorID: "nogi", Name: "Guard", Position: 0},
+			"pins":      {ID: "pins", RotorID: "nogi", Name: "Pins", Position: 1},
+		},
+		topics: map[string][]rotor.Topic{
+			"takedowns": {
+				{ID: "t1", RotorThemeID: "takedowns", Name: "Double Leg", DurationWeeks: 2, SharedTopicID: "st1"},
+				{ID: "t2", RotorThemeID: "takedowns", Name: "Snap Down", DurationWeeks: 1, Position: 1},
+			},
+		},
+	}
+}
+
+// TestExecuteCloneRotorTheme_CopiesIntoDraft verifies the theme lands after the target's themes with its topics.
+func TestExecuteCloneRotorTheme_CopiesIntoDraft(t *testing.T) {
+	store := newMockCloneThemeStore()
+	n := 0
+	deps := CloneRotorThemeDeps{RotorStore: store, GenerateID: func() string { n++; return fmt.Sprintf("id-%d", n) }}
+
+	got, err := ExecuteCloneRotorTheme(context.Background(), CloneRotorThemeInput{ThemeID: "takedowns", RotorID: "nogi", ClonedBy: "coach-1"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Theme.RotorID != "nogi" || got.Theme.Name != "Takedowns" || got.Theme.Position != 2 {
+		t.Errorf("unexpected theme: %+v", got.Theme)
+	}
+	if len(store.saved) != 2 || store.saved[0].RotorThemeID != got.Theme.ID || store.saved[0].SharedTopicID != "st1" {
+		t.Errorf("unexpected topics: %+v", store.saved)
+	}
+}
+
+// TestExecuteCloneRotorTheme_RejectsActiveRotor verifies a live rotor cannot be changed by cloning into it.
+func TestExecuteCloneRotorTheme_RejectsActiveRotor(t *testing.T) {
+	store := newMockCloneThemeStore()
+	_, err := ExecuteCloneRotorTheme(context.Background(), CloneRotorThemeInput{ThemeID: "takedowns", RotorID: "kids"}, CloneRotorThemeDeps{RotorStore: store, GenerateID: func() string { return "x" }})
+	if !errors.Is(err, rotor.ErrNotDraft) {
+		t.Errorf("expected ErrNotDraft, got %v", err)
+	}
+	if store.saved != nil {
+		t.Error("expected nothing saved")
+	}
+}
@@ -0,0 +1,145 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/availability"
+)
+
+// ErrAvailabilityNotFound is returned when a window or exception does not exist or belongs to
+// another coach the caller cannot manage.
+var ErrAvailabilityNotFound = errors.New("availability entry not found")
+
+// AvailabilityStore defines the store interface needed by coach availability orchestrators.
+type AvailabilityStore interface {
+	GetWindow(ctx context.Context, id string) (availability.Window, error)
+	SaveWindow(ctx context.Context, value availability.Window) error
+	DeleteWindow(ctx context.Context, id string) error
+	GetException(ctx context.Context, id string) (availability.Exception, error)
+	SaveException(ctx context.Context, value availability.Exception) error
+	DeleteException(ctx context.Context, id string) error
+}
+
+// AvailabilityDeps holds dependencies for coach availability orchestrators.
+type AvailabilityDeps struct {
+	AvailabilityStore AvailabilityStore
+	AccountStore      CoachAccountStore
+	GenerateID        func() string
+	Now               func() time.Time
+}
+
+// AddAvailabilityWindowInput carries input for adding a recurring weekly window.
+type AddAvailabilityWindowInput struct {
+	CoachID   string
+	Day       string // monday, tuesday, etc.
+	StartTime string // HH:MM
+	EndTime   string // HH:MM
+	ActorID   string
+}
+
+// ExecuteAddAvailabilityWindow adds a recurring weekly window in which the coach can take classes.
+// PRE: CoachID is the caller, or the caller is an admin (checked by the handler)
+// POST: Window saved; ErrNotACoach if CoachID is not a coach or admin account
+func ExecuteAddAvailabilityWindow(ctx context.Context, input AddAvailabilityWindowInput, deps AvailabilityDeps) (availability.Window, error) {
+	window := availability.Window{
+		ID:        deps.GenerateID(),
+		CoachID:   input.CoachID,
+		Day:       strings.ToLower(strings.TrimSpace(input.Day)),
+		StartTime: strings.TrimSpace(input.StartTime),
+		EndTime:   strings.TrimSpace(input.EndTime),
+	}
+	if err := window.Validate(); err != nil {
+		return availability.Window{}, err
+	}
+	if err := requireCoachAccount(ctx, input.CoachID, deps.AccountStore); err != nil {
+		return availability.Window{}, err
+	}
+	if err := deps.AvailabilityStore.SaveWindow(ctx, window); err != nil {
+		return availability.Window{}, err
+	}
+	slog.InfoContext(ctx, "availability_event", "event", "window_added", "window_id", window.ID, "coach_id", window.CoachID, "day", window.Day, "by", input.ActorID)
+	return window, nil
+}
+
+// AddAvailabilityExceptionInput carries input for adding a one-off exception.
+type AddAvailabilityExceptionInput struct {
+	CoachID   string
+	Date      string // YYYY-MM-DD
+	Available bool   // true adds availability; false takes it away
+	StartTime string // optional: HH:MM; empty with EndTime for the whole day
+	EndTime   string
+	Reason    string // optional
+	ActorID   string
+}
+
+// ExecuteAddAvailabilityException records that a coach is away, or free, on one date.
+// PRE: CoachID is the caller, or the caller is an admin (checked by the handler)
+// POST: Exception saved; availability.ErrExceptionInPast for dates before today
+func ExecuteAddAvailabilityException(ctx context.Context, input AddAvailabilityExceptionInput, deps AvailabilityDeps) (availability.Exception, error) {
+	now := deps.Now()
+	exception := availability.Exception{
+		ID:        deps.GenerateID(),
+		CoachID:   input.CoachID,
+		Date:      strings.TrimSpace(input.Date),
+		Available: input.Available,
+		StartTime: strings.TrimSpace(input.StartTime),
+		EndTime:   strings.TrimSpace(input.EndTime),
+		Reason:    strings.TrimSpace(input.Reason),
+		CreatedAt: now,
+	}
+	if err := exception.Validate(); err != nil {
+		return availability.Exception{}, err
+	}
+	if exception.Date < now.Format("2006-01-02") {
+		return availability.Exception{}, availability.ErrExceptionInPast
+	}
+	if err := requireCoachAccount(ctx, input.CoachID, deps.AccountStore); err != nil {
+		return availability.Exception{}, err
+	}
+	if err := deps.AvailabilityStore.SaveException(ctx, exception); err != nil {
+		return availability.Exception{}, err
+	}
+	slog.InfoContext(ctx, "availability_event", "event", "exception_added", "exception_id", exception.ID, "coach_id", exception.CoachID, "date", exception.Date, "available", exception.Available, "by", input.ActorID)
+	return exception, nil
+}
+
+// DeleteAvailabilityInput carries input for removing a window or exception.
+type DeleteAvailabilityInput struct {
+	ID      string
+	ActorID string
+	IsAdmin bool // admins may remove any coach's entries; coaches only their own
+}
+
+// ExecuteDeleteAvailabilityWindow removes a recurring window.
+// PRE: ID is non-empty
+// POST: Window removed; ErrAvailabilityNotFound if missing or owned by another coach
+func ExecuteDeleteAvailabilityWindow(ctx context.Context, input DeleteAvailabilityInput, deps AvailabilityDeps) error {
+	window, err := deps.AvailabilityStore.GetWindow(ctx, input.ID)
+	if err != nil || (!input.IsAdmin && window.CoachID != input.ActorID) {
+		return ErrAvailabilityNotFound
+	}
+	if err := deps.AvailabilityStore.DeleteWindow(ctx, window.ID); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "availability_event", "event", "window_removed", "window_id", window.ID, "coach_id", window.CoachID, "by", input.ActorID)
+	return nil
+}
+
+// ExecuteDeleteAvailabilityException removes a one-off exception.
+// PRE: ID is non-empty
+// POST: Exception removed; ErrAvailabilityNotFound if missing or owned by another coach
+func ExecuteDeleteAvailabilityException(ctx context.Context, input DeleteAvailabilityInput, deps AvailabilityDeps) error {
+	exception, err := deps.AvailabilityStore.GetException(ctx, input.ID)
+	if err != nil || (!input.IsAdmin && exception.CoachID != input.ActorID) {
+		return ErrAvailabilityNotFound
+	}
+	if err := deps.AvailabilityStore.DeleteException(ctx, exception.ID); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "availability_event", "event", "exception_removed", "exception_id", exception.ID, "coach_id", exception.CoachID, "by", input.ActorID)
+	return nil
+}
@@ -0,0 +1,160 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/availability"
+)
+
+// --- Mock store for coach availability tests ---
+
+type mockAvailabilityStore struct {
+	windows    map[string]availability.Window
+	exceptions map[string]availability.Exception
+}
+
+// GetWindow returns a stored window.
+// PRE: none
+// POST: Returns the window or an error
+func (m *mockAvailabilityStore) GetWindow(_ context.Context, id string) (availability.Window, error) {
+	w, ok := m.windows[id]
+	if !ok {
+		return availability.Window{}, errors.New("not found")
+	}
+	return w, nil
+}
+
+// SaveWindow stores the window.
+// PRE: none
+// POST: window stored by ID
+func (m *mockAvailabilityStore) SaveWindow(_ context.Context, value availability.Window) error {
+	m.windows[value.ID] = value
+	return nil
+}
+
+// DeleteWindow removes the window.
+// PRE: none
+// POST: window removed
+func (m *mockAvailabilityStore) DeleteWindow(_ context.Context, id string) error {
+	delete(m.windows, id)
+	return nil
+}
+
+// GetException returns a stored exception.
+// PRE: none
+// POST: Returns the exception or an error
+func (m *mockAvailabilityStore) GetException(_ context.Context, id string) (availability.Exception, error) {
+	e, ok := m.exceptions[id]
+	if !ok {
+		return availability.Exception{}, errors.New("not found")
+	}
+	return e, nil
+}
+
+// SaveException stores the exception.
+// PRE: none
+// POST: exception stored by ID
+func (m *mockAvailabilityStore) SaveException(_ context.Context, value availability.Exception) error {
+	m.exceptions[value.ID] = value
+	return nil
+}
+
+// DeleteException removes the exception.
+// PRE: none
+// POST: exception removed
+func (m *mockAvailabilityStore) DeleteException(_ context.Context, id string) error {
+	delete(m.exceptions, id)
+	return nil
+}
+
+// newAvailabilityDeps returns empty availability deps on 2026-03-10.
+func newAvailabilityDeps() (AvailabilityDeps, *mockAvailabilityStore) {
+	store := &mockAvailabilityStore{windows: map[string]availability.Window{}, exceptions: map[string]availability.Exception{}}
+	n := 0
+	return AvailabilityDeps{
+		AvailabilityStore: store,
+		AccountStore:      &mockCoachAccountStore{},
+		GenerateID:        func() string { n++; return "av" + string(rune('0'+n)) },
+		Now:               func() time.Time { return time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC) },
+	}, store
+}
+
+// TestExecuteAddAvailabilityWindow verifies a coach's window is normalised and saved, and that
+// bad input and non-coaches are rejected.
+func TestExecuteAddAvailabilityWindow(t *testing.T) {
+	deps, store := newAvailabilityDeps()
+	w, err := ExecuteAddAvailabilityWindow(context.Background(), AddAvailabilityWindowInput{
+		CoachID: "coach-1", Day: " Monday ", StartTime: "17:00", EndTime: "21:00", ActorID: "coach-1",
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.Day != "monday" || store.windows[w.ID].CoachID != "coach-1" {
+		t.Errorf("window = %+v", store.windows[w.ID])
+	}
+
+	tests := []struct {
+		name  string
+		input AddAvailabilityWindowInput
+		want  error
+	}{
+		{"bad day", AddAvailabilityWindowInput{CoachID: "coach-1", Day: "someday", StartTime: "17:00", EndTime: "21:00"}, availability.ErrInvalidDay},
+		{"same times", AddAvailabilityWindowInput{CoachID: "coach-1", Day: "monday", StartTime: "17:00", EndTime: "17:00"}, availability.ErrInvalidTime},
+		{"member", AddAvailabilityWindowInput{CoachID: "member-1", Day: "monday", StartTime: "17:00", EndTime: "21:00"}, ErrNotACoach},
+	}
+	for _, tt := range tests {
+		if _, err := ExecuteAddAvailabilityWindow(context.Background(), tt.input, deps); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if len(store.windows) != 1 {
+		t.Errorf("%d windows saved, want 1", len(store.windows))
+	}
+}
+
+// TestExecuteAddAvailabilityException verifies exceptions can be added from today on but not in the past.
+func TestExecuteAddAvailabilityException(t *testing.T) {
+	deps, store := newAvailabilityDeps()
+	e, err := ExecuteAddAvailabilityException(context.Background(), AddAvailabilityExceptionInput{
+		CoachID: "coach-1", Date: "2026-03-10", Reason: " Seminar ", ActorID: "coach-1",
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !e.IsAllDay() || e.Reason != "Seminar" || store.exceptions[e.ID].Date != "2026-03-10" {
+		t.Errorf("exception = %+v", e)
+	}
+
+	_, err = ExecuteAddAvailabilityException(context.Background(), AddAvailabilityExceptionInput{CoachID: "coach-1", Date: "2026-03-09"}, deps)
+	if !errors.Is(err, availability.ErrExceptionInPast) {
+		t.Errorf("past date: err = %v, want ErrExceptionInPast", err)
+	}
+	_, err = ExecuteAddAvailabilityException(context.Background(), AddAvailabilityExceptionInput{CoachID: "coach-1", Date: "2026-03-12", StartTime: "18:00"}, deps)
+	if !errors.Is(err, availability.ErrPartialTimes) {
+		t.Errorf("partial times: err = %v, want ErrPartialTimes", err)
+	}
+}
+
+// TestExecuteDeleteAvailability verifies coaches can only remove their own entries while admins can remove any.
+func TestExecuteDeleteAvailability(t *testing.T) {
+	deps, store := newAvailabilityDeps()
+	store.windows["w1"] = availability.Window{ID: "w1", CoachID: "coach-1", Day: "monday", StartTime: "17:00", EndTime: "21:00"}
+	store.exceptions["e1"] = availability.Exception{ID: "e1", CoachID: "coach-1", Date: "2026-03-12"}
+	ctx := context.Background()
+
+	if err := ExecuteDeleteAvailabilityWindow(ctx, DeleteAvailabilityInput{ID: "w1", ActorID: "coach-2"}, deps); !errors.Is(err, ErrAvailabilityNotFound) {
+		t.Errorf("other coach: err = %v, want ErrAvailabilityNotFound", err)
+	}
+	if err := ExecuteDeleteAvailabilityWindow(ctx, DeleteAvailabilityInput{ID: "w1", ActorID: "coach-1"}, deps); err != nil {
+		t.Errorf("owner: unexpected error: %v", err)
+	}
+	if err := ExecuteDeleteAvailabilityException(ctx, DeleteAvailabilityInput{ID: "e1", ActorID: "admin-1", IsAdmin: true}, deps); err != nil {
+		t.Errorf("admin: unexpected error: %v", err)
+	}
+	if len(store.windows) != 0 || len(store.exceptions) != 0 {
+		t.Errorf("left behind: %+v %+v", store.windows, store.exceptions)
+	}
+}
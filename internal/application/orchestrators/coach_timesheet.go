@@ -0,0 +1,164 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/timesheet"
+)
+
+// ErrNotACoach is returned when a class or rate is given to an account that does not coach.
+var ErrNotACoach = errors.New("account must be a coach or admin")
+
+// AssignCoachScheduleStore defines the schedule store interface needed by AssignCoach.
+type AssignCoachScheduleStore interface {
+	GetByID(ctx context.Context, id string) (schedule.Schedule, error)
+	Save(ctx context.Context, value schedule.Schedule) error
+}
+
+// AssignCoachTimesheetStore defines the timesheet store interface needed by AssignCoach.
+type AssignCoachTimesheetStore interface {
+	SaveOverride(ctx context.Context, value timesheet.Override) error
+	DeleteOverride(ctx context.Context, scheduleID, classDate string) error
+}
+
+// CoachAccountStore defines the account store interface needed to check an account coaches.
+type CoachAccountStore interface {
+	GetByID(ctx context.Context, id string) (account.Account, error)
+}
+
+// --- Assign Coach ---
+
+// AssignCoachInput carries input for assigning a class's coach.
+type AssignCoachInput struct {
+	ScheduleID string
+	ClassDate  string // YYYY-MM-DD for one occurrence; empty sets the regular coach
+	CoachID    string // empty clears the regular coach, or removes the occurrence's override
+	SetBy      string // AccountID of the admin
+}
+
+// AssignCoachDeps holds dependencies for AssignCoach.
+type AssignCoachDeps struct {
+	ScheduleStore  AssignCoachScheduleStore
+	TimesheetStore AssignCoachTimesheetStore
+	AccountStore   CoachAccountStore
+	GenerateID     func() string
+	Now            func() time.Time
+}
+
+// ExecuteAssignCoach sets who coaches a class: the schedule's regular coach when no date is
+// given, otherwise an override for that one occurrence.
+// PRE: ScheduleID exists; ClassDate, if given, falls on the schedule's day
+// POST: Schedule or override saved; ErrNotACoach if CoachID is not a coach or admin account
+func ExecuteAssignCoach(ctx context.Context, input AssignCoachInput, deps AssignCoachDeps) error {
+	if input.ScheduleID == "" {
+		return timesheet.ErrEmptyScheduleID
+	}
+	if input.SetBy == "" {
+		return timesheet.ErrEmptySetBy
+	}
+	sched, err := deps.ScheduleStore.GetByID(ctx, input.ScheduleID)
+	if err != nil {
+		return err
+	}
+	if input.CoachID != "" {
+		if err := requireCoachAccount(ctx, input.CoachID, deps.AccountStore); err != nil {
+			return err
+		}
+	}
+
+	if input.ClassDate == "" {
+		sched.CoachID = input.CoachID
+		if err := deps.ScheduleStore.Save(ctx, sched); err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "timesheet_event", "event", "regular_coach_set", "schedule_id", sched.ID, "coach_id", input.CoachID, "by", input.SetBy)
+		return nil
+	}
+
+	classDate, err := time.Parse("2006-01-02", input.ClassDate)
+	if err != nil {
+		return timesheet.ErrInvalidClassDate
+	}
+	if !sched.OccursOn(classDate) {
+		return schedule.ErrWrongDay
+	}
+	if input.CoachID == "" {
+		if err := deps.TimesheetStore.DeleteOverride(ctx, sched.ID, input.ClassDate); err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "timesheet_event", "event", "override_removed", "schedule_id", sched.ID, "class_date", input.ClassDate, "by", input.SetBy)
+		return nil
+	}
+	override := timesheet.Override{
+		ID:         deps.GenerateID(),
+		ScheduleID: sched.ID,
+		ClassDate:  input.ClassDate,
+		CoachID:    input.CoachID,
+		SetBy:      input.SetBy,
+		SetAt:      deps.Now(),
+	}
+	if err := override.Validate(); err != nil {
+		return err
+	}
+	if err := deps.TimesheetStore.SaveOverride(ctx, override); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "timesheet_event", "event", "override_set", "schedule_id", sched.ID, "class_date", input.ClassDate, "coach_id", input.CoachID, "by", input.SetBy)
+	return nil
+}
+
+// --- Set Coach Rate ---
+
+// CoachRateStore defines the timesheet store interface needed by SetCoachRate.
+type CoachRateStore interface {
+	SaveRate(ctx context.Context, value timesheet.Rate) error
+}
+
+// SetCoachRateInput carries input for setting a coach's hourly rate.
+type SetCoachRateInput struct {
+	CoachID    string
+	HourlyRate int    // cents per hour
+	UpdatedBy  string // AccountID of the admin
+}
+
+// SetCoachRateDeps holds dependencies for SetCoachRate.
+type SetCoachRateDeps struct {
+	TimesheetStore CoachRateStore
+	AccountStore   CoachAccountStore
+	Now            func() time.Time
+}
+
+// ExecuteSetCoachRate sets the hourly rate a coach is paid at on the payroll export.
+// PRE: CoachID is a coach or admin account
+// POST: Rate saved, replacing any earlier rate
+func ExecuteSetCoachRate(ctx context.Context, input SetCoachRateInput, deps SetCoachRateDeps) (timesheet.Rate, error) {
+	rate := timesheet.Rate{CoachID: input.CoachID, HourlyRate: input.HourlyRate, UpdatedBy: input.UpdatedBy, UpdatedAt: deps.Now()}
+	if err := rate.Validate(); err != nil {
+		return timesheet.Rate{}, err
+	}
+	if err := requireCoachAccount(ctx, input.CoachID, deps.AccountStore); err != nil {
+		return timesheet.Rate{}, err
+	}
+	if err := deps.TimesheetStore.SaveRate(ctx, rate); err != nil {
+		return timesheet.Rate{}, err
+	}
+	slog.InfoContext(ctx, "timesheet_event", "event", "rate_set", "coach_id", rate.CoachID, "hourly_rate", rate.HourlyRate, "by", input.UpdatedBy)
+	return rate, nil
+}
+
+// requireCoachAccount returns ErrNotACoach unless accountID is a coach or admin account.
+func requireCoachAccount(ctx context.Context, accountID string, accounts CoachAccountStore) error {
+	a, err := accounts.GetByID(ctx, accountID)
+	if err != nil {
+		return ErrNotACoach
+	}
+	if a.Role != account.RoleCoach && a.Role != account.RoleAdmin {
+		return ErrNotACoach
+	}
+	return nil
+}
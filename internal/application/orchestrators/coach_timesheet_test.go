@@ -0,0 +1,174 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/timesheet"
+)
+
+// mockAssignCoachScheduleStore implements AssignCoachScheduleStore for testing.
+type mockAssignCoachScheduleStore struct {
+	schedules map[string]schedule.Schedule
+}
+
+// GetByID implements AssignCoachScheduleStore.
+// PRE: id is non-empty
+// POST: returns the schedule or sql.ErrNoRows
+func (m *mockAssignCoachScheduleStore) GetByID(_ context.Context, id string) (schedule.Schedule, error) {
+	s, ok := m.schedules[id]
+	if !ok {
+		return schedule.Schedule{}, sql.ErrNoRows
+	}
+	return s, nil
+}
+
+// Save implements AssignCoachScheduleStore.
+// PRE: value is valid
+// POST: schedule is stored by ID
+func (m *mockAssignCoachScheduleStore) Save(_ context.Context, value schedule.Schedule) error {
+	m.schedules[value.ID] = value
+	return nil
+}
+
+// mockTimesheetStore implements the timesheet store interfaces for testing.
+type mockTimesheetStore struct {
+	overrides map[string]timesheet.Override // keyed by schedule ID and date
+	rates     map[string]timesheet.Rate
+}
+
+// SaveOverride implements AssignCoachTimesheetStore.
+// PRE: value is valid
+// POST: override is stored for its occurrence
+func (m *mockTimesheetStore) SaveOverride(_ context.Context, value timesheet.Override) error {
+	m.overrides[value.ScheduleID+"|"+value.ClassDate] = value
+	return nil
+}
+
+// DeleteOverride implements AssignCoachTimesheetStore.
+// PRE: scheduleID and classDate are non-empty
+// POST: override is removed
+func (m *mockTimesheetStore) DeleteOverride(_ context.Context, scheduleID, classDate string) error {
+	delete(m.overrides, scheduleID+"|"+classDate)
+	return nil
+}
+
+// SaveRate implements CoachRateStore.
+// PRE: value is valid
+// POST: rate is stored by coach
+func (m *mockTimesheetStore) SaveRate(_ context.Context, value timesheet.Rate) error {
+	m.rates[value.CoachID] = value
+	return nil
+}
+
+// mockCoachAccountStore implements CoachAccountStore for testing.
+type mockCoachAccountStore struct{}
+
+// GetByID implements CoachAccountStore.
+// PRE: id is non-empty
+// POST: coach-* IDs are coaches, member-* IDs are members, anything else is not found
+func (m *mockCoachAccountStore) GetByID(_ context.Context, id string) (account.Account, error) {
+	switch {
+	case strings.HasPrefix(id, "coach-"):
+		return account.Account{ID: id, Role: account.RoleCoach}, nil
+	case strings.HasPrefix(id, "member-"):
+		return account.Account{ID: id, Role: account.RoleMember}, nil
+	}
+	return account.Account{}, sql.ErrNoRows
+}
+
+// newAssignCoachDeps returns deps with a Monday class, s1, that has no regular coach.
+func newAssignCoachDeps() (AssignCoachDeps, *mockAssignCoachScheduleStore, *mockTimesheetStore) {
+	schedules := &mockAssignCoachScheduleStore{schedules: map[string]schedule.Schedule{
+		"s1": {ID: "s1", ClassTypeID: "ct1", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:00"},
+	}}
+	sheets := &mockTimesheetStore{overrides: map[string]timesheet.Override{}, rates: map[string]timesheet.Rate{}}
+	return AssignCoachDeps{
+		ScheduleStore:  schedules,
+		TimesheetStore: sheets,
+		AccountStore:   &mockCoachAccountStore{},
+		GenerateID:     func() string { return "ov-1" },
+		Now:            func() time.Time { return time.Date(2026, 3, 20, 9, 0, 0, 0, time.UTC) },
+	}, schedules, sheets
+}
+
+// TestExecuteAssignCoach_RegularAndOverride verifies setting the regular coach, then
+// overriding and un-overriding one Monday.
+func TestExecuteAssignCoach_RegularAndOverride(t *testing.T) {
+	ctx := context.Background()
+	deps, schedules, sheets := newAssignCoachDeps()
+
+	if err := ExecuteAssignCoach(ctx, AssignCoachInput{ScheduleID: "s1", CoachID: "coach-1", SetBy: "admin-1"}, deps); err != nil {
+		t.Fatalf("regular coach: %v", err)
+	}
+	if got := schedules.schedules["s1"].CoachID; got != "coach-1" {
+		t.Errorf("regular coach = %q, want coach-1", got)
+	}
+
+	if err := ExecuteAssignCoach(ctx, AssignCoachInput{ScheduleID: "s1", ClassDate: "2026-03-16", CoachID: "coach-2", SetBy: "admin-1"}, deps); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if got := sheets.overrides["s1|2026-03-16"].CoachID; got != "coach-2" {
+		t.Errorf("override coach = %q, want coach-2", got)
+	}
+
+	if err := ExecuteAssignCoach(ctx, AssignCoachInput{ScheduleID: "s1", ClassDate: "2026-03-16", SetBy: "admin-1"}, deps); err != nil {
+		t.Fatalf("remove override: %v", err)
+	}
+	if _, ok := sheets.overrides["s1|2026-03-16"]; ok {
+		t.Error("override should have been removed")
+	}
+}
+
+// TestExecuteAssignCoach_Rejects verifies wrong days, non-coaches and unknown schedules are refused.
+func TestExecuteAssignCoach_Rejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		input AssignCoachInput
+		want  error
+	}{
+		{"wrong weekday", AssignCoachInput{ScheduleID: "s1", ClassDate: "2026-03-17", CoachID: "coach-2", SetBy: "admin-1"}, schedule.ErrWrongDay},
+		{"bad date", AssignCoachInput{ScheduleID: "s1", ClassDate: "16/03/2026", CoachID: "coach-2", SetBy: "admin-1"}, timesheet.ErrInvalidClassDate},
+		{"member is not a coach", AssignCoachInput{ScheduleID: "s1", CoachID: "member-1", SetBy: "admin-1"}, ErrNotACoach},
+		{"unknown account", AssignCoachInput{ScheduleID: "s1", CoachID: "nobody", SetBy: "admin-1"}, ErrNotACoach},
+		{"unknown schedule", AssignCoachInput{ScheduleID: "s9", CoachID: "coach-1", SetBy: "admin-1"}, sql.ErrNoRows},
+		{"no admin", AssignCoachInput{ScheduleID: "s1", CoachID: "coach-1"}, timesheet.ErrEmptySetBy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, _, _ := newAssignCoachDeps()
+			if err := ExecuteAssignCoach(context.Background(), tt.input, deps); err != tt.want {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestExecuteSetCoachRate verifies rates are saved for coaches and refused for members.
+func TestExecuteSetCoachRate(t *testing.T) {
+	sheets := &mockTimesheetStore{overrides: map[string]timesheet.Override{}, rates: map[string]timesheet.Rate{}}
+	deps := SetCoachRateDeps{
+		TimesheetStore: sheets,
+		AccountStore:   &mockCoachAccountStore{},
+		Now:            func() time.Time { return time.Date(2026, 3, 20, 9, 0, 0, 0, time.UTC) },
+	}
+	ctx := context.Background()
+
+	if _, err := ExecuteSetCoachRate(ctx, SetCoachRateInput{CoachID: "coach-1", HourlyRate: 4500, UpdatedBy: "admin-1"}, deps); err != nil {
+		t.Fatalf("set rate: %v", err)
+	}
+	if got := sheets.rates["coach-1"].HourlyRate; got != 4500 {
+		t.Errorf("rate = %d, want 4500", got)
+	}
+	if _, err := ExecuteSetCoachRate(ctx, SetCoachRateInput{CoachID: "member-1", HourlyRate: 4500, UpdatedBy: "admin-1"}, deps); err != ErrNotACoach {
+		t.Errorf("member rate error = %v, want ErrNotACoach", err)
+	}
+	if _, err := ExecuteSetCoachRate(ctx, SetCoachRateInput{CoachID: "coach-1", HourlyRate: -5, UpdatedBy: "admin-1"}, deps); err != timesheet.ErrInvalidRate {
+		t.Errorf("negative rate error = %v, want ErrInvalidRate", err)
+	}
+}
@@ -0,0 +1,62 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	emailDomain "workshop/internal/domain/email"
+)
+
+// CommunicationPreferenceStore defines the store interface needed to change communication preferences.
+type CommunicationPreferenceStore interface {
+	EmailPreferenceStore
+	Save(ctx context.Context, p emailDomain.Preferences) error
+}
+
+// UpdateCommunicationPreferencesInput carries input for changing a member's email categories.
+type UpdateCommunicationPreferencesInput struct {
+	MemberID string
+	Changes  map[string]bool // category -> whether the member receives it
+	Source   string          // where the change came from, e.g. "unsubscribe_link" or "settings"
+}
+
+// UpdateCommunicationPreferencesDeps holds dependencies for UpdateCommunicationPreferences.
+type UpdateCommunicationPreferencesDeps struct {
+	PreferenceStore CommunicationPreferenceStore
+	Now             func() time.Time
+}
+
+// ExecuteUpdateCommunicationPreferences turns a member's optional email categories on or off.
+// Categories not named in Changes keep their current setting; a member with no saved
+// preferences starts from everything on.
+// PRE: MemberID is non-empty
+// POST: Preferences saved, or nothing saved if any change names an invalid or required category
+func ExecuteUpdateCommunicationPreferences(ctx context.Context, input UpdateCommunicationPreferencesInput, deps UpdateCommunicationPreferencesDeps) (emailDomain.Preferences, error) {
+	if input.MemberID == "" {
+		return emailDomain.Preferences{}, errors.New("member ID is required")
+	}
+
+	prefs, err := deps.PreferenceStore.GetByMemberID(ctx, input.MemberID)
+	if errors.Is(err, sql.ErrNoRows) {
+		prefs = emailDomain.DefaultPreferences(input.MemberID)
+	} else if err != nil {
+		return emailDomain.Preferences{}, err
+	}
+
+	for category, on := range input.Changes {
+		if err := prefs.Set(category, on); err != nil {
+			return emailDomain.Preferences{}, err
+		}
+	}
+	prefs.UpdatedAt = deps.Now()
+	if err := deps.PreferenceStore.Save(ctx, prefs); err != nil {
+		return emailDomain.Preferences{}, err
+	}
+
+	slog.InfoContext(ctx, "email_event", "event", "communication_preferences_updated", "member_id", input.MemberID, "source", input.Source,
+		"announcements", prefs.Announcements, "grading", prefs.Grading, "billing", prefs.Billing)
+	return prefs, nil
+}
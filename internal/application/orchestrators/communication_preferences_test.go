@@ -0,0 +1,78 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	emailDomain "workshop/internal/domain/email"
+)
+
+// mockPreferenceStore implements CommunicationPreferenceStore for testing.
+type mockPreferenceStore struct {
+	prefs map[string]emailDomain.Preferences
+}
+
+// GetByMemberID implements CommunicationPreferenceStore.
+// PRE: memberID is non-empty
+// POST: returns the saved preferences or sql.ErrNoRows
+func (m *mockPreferenceStore) GetByMemberID(_ context.Context, memberID string) (emailDomain.Preferences, error) {
+	p, ok := m.prefs[memberID]
+	if !ok {
+		return emailDomain.Preferences{}, sql.ErrNoRows
+	}
+	return p, nil
+}
+
+// Save implements CommunicationPreferenceStore.
+// PRE: p.MemberID is non-empty
+// POST: preferences are stored by member
+func (m *mockPreferenceStore) Save(_ context.Context, p emailDomain.Preferences) error {
+	m.prefs[p.MemberID] = p
+	return nil
+}
+
+// TestUpdateCommunicationPreferences tests opting out starts from the defaults and keeps untouched categories.
+func TestUpdateCommunicationPreferences(t *testing.T) {
+	store := &mockPreferenceStore{prefs: map[string]emailDomain.Preferences{}}
+	deps := UpdateCommunicationPreferencesDeps{PreferenceStore: store, Now: fixedNow}
+
+	prefs, err := ExecuteUpdateCommunicationPreferences(context.Background(), UpdateCommunicationPreferencesInput{
+		MemberID: "member-1", Changes: map[string]bool{emailDomain.CategoryAnnouncements: false},
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prefs.Announcements || !prefs.Grading || !prefs.Billing || !prefs.UpdatedAt.Equal(fixedTime) {
+		t.Errorf("prefs = %+v, want only announcements off", prefs)
+	}
+
+	prefs, _ = ExecuteUpdateCommunicationPreferences(context.Background(), UpdateCommunicationPreferencesInput{
+		MemberID: "member-1", Changes: map[string]bool{emailDomain.CategoryBilling: false},
+	}, deps)
+	if prefs.Announcements || prefs.Billing || !prefs.Grading {
+		t.Errorf("prefs = %+v, want announcements and billing off", prefs)
+	}
+
+	tests := []struct {
+		name    string
+		changes map[string]bool
+		wantErr error
+	}{
+		{"account mail", map[string]bool{emailDomain.CategoryAccount: false}, emailDomain.ErrCategoryRequired},
+		{"unknown category", map[string]bool{"newsletter": false}, emailDomain.ErrInvalidCategory},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExecuteUpdateCommunicationPreferences(context.Background(), UpdateCommunicationPreferencesInput{
+				MemberID: "member-2", Changes: tt.changes,
+			}, deps)
+			if err != tt.wantErr {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if _, ok := store.prefs["member-2"]; ok {
+		t.Error("expected rejected changes not saved")
+	}
+}
@@ -0,0 +1,78 @@
+package orchestrators
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	domain "workshop/internal/domain/calendar"
+)
+
+// CompetitionEventStore defines the calendar event store interface needed to record competition responses.
+type CompetitionEventStore interface {
+	GetByID(ctx context.Context, id string) (domain.Event, error)
+}
+
+// CompetitionInterestStore defines the interest store interface needed to record competition responses.
+type CompetitionInterestStore interface {
+	SaveInterest(ctx context.Context, ci domain.CompetitionInterest) error
+	GetInterest(ctx context.Context, eventID, memberID string) (domain.CompetitionInterest, error)
+}
+
+// RespondToCompetitionInput carries a member's response to a competition.
+type RespondToCompetitionInput struct {
+	EventID     string
+	MemberID    string
+	Status      string // interested or registered; empty means interested
+	WeightClass string
+}
+
+// RespondToCompetitionDeps holds dependencies for RespondToCompetition.
+type RespondToCompetitionDeps struct {
+	EventStore    CompetitionEventStore
+	InterestStore CompetitionInterestStore
+	GenerateID    func() string
+	Now           func() time.Time
+}
+
+// ExecuteRespondToCompetition records that a member is interested in, or registered for, a competition.
+// Responding again updates the status and weight class in place.
+// PRE: EventID and MemberID are non-empty
+// POST: The member's interest record exists with the given status, or ErrNotCompetition
+func ExecuteRespondToCompetition(ctx context.Context, input RespondToCompetitionInput, deps RespondToCompetitionDeps) (domain.CompetitionInterest, error) {
+	ci := domain.CompetitionInterest{
+		EventID:     input.EventID,
+		MemberID:    input.MemberID,
+		Status:      input.Status,
+		WeightClass: strings.TrimSpace(input.WeightClass),
+	}
+	if ci.Status == "" {
+		ci.Status = domain.InterestStatusInterested
+	}
+	if err := ci.Validate(); err != nil {
+		return domain.CompetitionInterest{}, err
+	}
+
+	ev, err := deps.EventStore.GetByID(ctx, input.EventID)
+	if err != nil {
+		return domain.CompetitionInterest{}, err
+	}
+	if ev.Type != domain.TypeCompetition {
+		return domain.CompetitionInterest{}, domain.ErrNotCompetition
+	}
+
+	if existing, err := deps.InterestStore.GetInterest(ctx, input.EventID, input.MemberID); err == nil {
+		ci.ID = existing.ID
+		ci.CreatedAt = existing.CreatedAt
+	} else {
+		ci.ID = deps.GenerateID()
+		ci.CreatedAt = deps.Now()
+	}
+	if err := deps.InterestStore.SaveInterest(ctx, ci); err != nil {
+		return domain.CompetitionInterest{}, err
+	}
+
+	slog.InfoContext(ctx, "calendar_event", "event", "competition_response", "event_id", ci.EventID, "member_id", ci.MemberID, "status", ci.Status)
+	return ci, nil
+}
@@ -0,0 +1,83 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	domain "workshop/internal/domain/calendar"
+)
+
+type mockCompetitionEventStore struct{}
+
+// GetByID returns a competition for "comp" and a club event otherwise.
+// PRE: id is non-empty
+// POST: Returns the event
+func (m *mockCompetitionEventStore) GetByID(_ context.Context, id string) (domain.Event, error) {
+	if id == "comp" {
+		return domain.Event{ID: id, Type: domain.TypeCompetition}, nil
+	}
+	return domain.Event{ID: id, Type: domain.TypeEvent}, nil
+}
+
+type mockCompetitionInterestStore struct {
+	saved map[string]domain.CompetitionInterest
+}
+
+// SaveInterest stores the interest keyed by event and member.
+// PRE: ci is valid
+// POST: ci is stored
+func (m *mockCompetitionInterestStore) SaveInterest(_ context.Context, ci domain.CompetitionInterest) error {
+	m.saved[ci.EventID+"/"+ci.MemberID] = ci
+	return nil
+}
+
+// GetInterest returns a stored interest or sql.ErrNoRows.
+// PRE: eventID and memberID are non-empty
+// POST: Returns the interest or an error
+func (m *mockCompetitionInterestStore) GetInterest(_ context.Context, eventID, memberID string) (domain.CompetitionInterest, error) {
+	ci, ok := m.saved[eventID+"/"+memberID]
+	if !ok {
+		return domain.CompetitionInterest{}, sql.ErrNoRows
+	}
+	return ci, nil
+}
+
+// TestRespondToCompetition tests recording, upgrading and rejecting competition responses.
+func TestRespondToCompetition(t *testing.T) {
+	store := &mockCompetitionInterestStore{saved: map[string]domain.CompetitionInterest{}}
+	deps := RespondToCompetitionDeps{
+		EventStore:    &mockCompetitionEventStore{},
+		InterestStore: store,
+		GenerateID:    fixedID,
+		Now:           fixedNow,
+	}
+	ctx := context.Background()
+
+	ci, err := ExecuteRespondToCompetition(ctx, RespondToCompetitionInput{EventID: "comp", MemberID: "m1"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ci.Status != domain.InterestStatusInterested || ci.ID != "test-id-001" {
+		t.Errorf("unexpected interest: %+v", ci)
+	}
+
+	deps.GenerateID = func() string { return "other-id" }
+	ci, err = ExecuteRespondToCompetition(ctx, RespondToCompetitionInput{
+		EventID: "comp", MemberID: "m1", Status: domain.InterestStatusRegistered, WeightClass: "  -76kg ",
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ci.ID != "test-id-001" || !ci.IsRegistered() || ci.WeightClass != "-76kg" || !ci.CreatedAt.Equal(fixedTime) {
+		t.Errorf("expected in-place upgrade to registered, got %+v", ci)
+	}
+
+	if _, err := ExecuteRespondToCompetition(ctx, RespondToCompetitionInput{EventID: "bbq", MemberID: "m1"}, deps); !errors.Is(err, domain.ErrNotCompetition) {
+		t.Errorf("expected ErrNotCompetition, got %v", err)
+	}
+	if _, err := ExecuteRespondToCompetition(ctx, RespondToCompetitionInput{EventID: "comp", MemberID: "m1", Status: "maybe"}, deps); err == nil {
+		t.Error("expected invalid status to be rejected")
+	}
+}
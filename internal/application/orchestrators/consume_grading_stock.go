@@ -0,0 +1,103 @@
+package orchestrators
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/inventory"
+)
+
+// GradingStockStore defines the inventory store interface needed to take promotion stock.
+type GradingStockStore interface {
+	GetItem(ctx context.Context, kind, color, size string) (inventory.Item, error)
+	SaveItem(ctx context.Context, value inventory.Item) error
+	GetMemberSize(ctx context.Context, memberID string) (inventory.MemberSize, error)
+}
+
+// GradingStockRecordStore defines the grading record store interface needed to find what
+// a member held before a promotion.
+type GradingStockRecordStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]grading.Record, error)
+}
+
+// ConsumeGradingStockInput carries the grading record just created.
+type ConsumeGradingStockInput struct {
+	Record grading.Record
+}
+
+// ConsumeGradingStockDeps holds dependencies for ConsumeGradingStock.
+type ConsumeGradingStockDeps struct {
+	StockStore  GradingStockStore
+	RecordStore GradingStockRecordStore
+	Now         func() time.Time
+}
+
+// ConsumeGradingStockResult reports what a promotion took from stock.
+type ConsumeGradingStockResult struct {
+	Taken   []inventory.Use  // taken from the shelf
+	Missing []inventory.Use  // not stocked, short, or a belt for a member with no recorded size
+	Low     []inventory.Item // items taken from that are now at or below their low-stock level
+}
+
+// ExecuteConsumeGradingStock takes the belt and stripes a new grading record hands out
+// from stock. Stock never goes below zero; anything that could not be taken is reported
+// in Missing rather than failing, because the promotion has already happened.
+// PRE: input.Record is saved
+// POST: Stock items for the record's uses are reduced and saved
+func ExecuteConsumeGradingStock(ctx context.Context, input ConsumeGradingStockInput, deps ConsumeGradingStockDeps) (ConsumeGradingStockResult, error) {
+	var result ConsumeGradingStockResult
+	record := input.Record
+
+	records, err := deps.RecordStore.ListByMemberID(ctx, record.MemberID)
+	if err != nil {
+		return result, err
+	}
+	previous := previousRecord(records, record)
+
+	size := ""
+	if ms, err := deps.StockStore.GetMemberSize(ctx, record.MemberID); err == nil {
+		size = ms.Size
+	}
+
+	for _, use := range inventory.UsesForPromotion(inventory.Grade{Belt: record.Belt, Stripe: record.Stripe}, inventory.Grade{Belt: previous.Belt, Stripe: previous.Stripe}, size) {
+		if use.Kind == inventory.KindBelt && use.Size == "" {
+			result.Missing = append(result.Missing, use)
+			continue
+		}
+		item, err := deps.StockStore.GetItem(ctx, use.Kind, use.Color, use.Size)
+		if err != nil {
+			result.Missing = append(result.Missing, use)
+			continue
+		}
+		short := item.Take(use.Quantity)
+		item.UpdatedAt = deps.Now()
+		if err := deps.StockStore.SaveItem(ctx, item); err != nil {
+			return result, err
+		}
+		if taken := use.Quantity - short; taken > 0 {
+			result.Taken = append(result.Taken, inventory.Use{Kind: use.Kind, Color: use.Color, Size: use.Size, Quantity: taken})
+		}
+		if short > 0 {
+			result.Missing = append(result.Missing, inventory.Use{Kind: use.Kind, Color: use.Color, Size: use.Size, Quantity: short})
+		}
+		if item.IsLow() {
+			result.Low = append(result.Low, item)
+		}
+	}
+	return result, nil
+}
+
+// previousRecord returns the member's latest record before r, or the zero Record.
+func previousRecord(records []grading.Record, r grading.Record) grading.Record {
+	var prev grading.Record
+	for _, rec := range records {
+		if rec.ID == r.ID || rec.PromotedAt.After(r.PromotedAt) {
+			continue
+		}
+		if prev.ID == "" || rec.PromotedAt.After(prev.PromotedAt) {
+			prev = rec
+		}
+	}
+	return prev
+}
@@ -0,0 +1,126 @@
+package orchestrators
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/inventory"
+)
+
+// mockGSStockStore implements GradingStockStore for testing.
+type mockGSStockStore struct {
+	items map[string]inventory.Item // key: kind/color/size
+	sizes map[string]string         // member ID -> belt size
+}
+
+// GetItem returns the stock for a kind, colour and size.
+// PRE: kind and color are non-empty
+// POST: Returns the item or an error if not stocked
+func (m *mockGSStockStore) GetItem(_ context.Context, kind, color, size string) (inventory.Item, error) {
+	if item, ok := m.items[kind+"/"+color+"/"+size]; ok {
+		return item, nil
+	}
+	return inventory.Item{}, fmt.Errorf("not found")
+}
+
+// SaveItem stores an item under its kind, colour and size.
+// PRE: value has been validated
+// POST: The item replaces any earlier one
+func (m *mockGSStockStore) SaveItem(_ context.Context, value inventory.Item) error {
+	m.items[value.Kind+"/"+value.Color+"/"+value.Size] = value
+	return nil
+}
+
+// GetMemberSize returns a member's belt size.
+// PRE: memberID is non-empty
+// POST: Returns the size or an error if none is recorded
+func (m *mockGSStockStore) GetMemberSize(_ context.Context, memberID string) (inventory.MemberSize, error) {
+	if size, ok := m.sizes[memberID]; ok {
+		return inventory.MemberSize{MemberID: memberID, Size: size}, nil
+	}
+	return inventory.MemberSize{}, fmt.Errorf("not found")
+}
+
+// mockGSRecordStore implements GradingStockRecordStore for testing.
+type mockGSRecordStore struct {
+	records []grading.Record
+}
+
+// ListByMemberID returns every record; tests use one member.
+// PRE: memberID is non-empty
+// POST: Returns the records
+func (m *mockGSRecordStore) ListByMemberID(_ context.Context, _ string) ([]grading.Record, error) {
+	return m.records, nil
+}
+
+// TestExecuteConsumeGradingStock verifies a belt promotion takes a belt in the member's
+// size and reports stock that has run low or out.
+func TestExecuteConsumeGradingStock(t *testing.T) {
+	now := time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+	stock := &mockGSStockStore{
+		items: map[string]inventory.Item{
+			"belt/blue/A2":   {ID: "i1", Kind: inventory.KindBelt, Color: grading.BeltBlue, Size: "A2", Quantity: 2, LowStock: 1},
+			"stripe/white/":  {ID: "i2", Kind: inventory.KindStripe, Color: inventory.StripeColor, Quantity: 1},
+			"belt/purple/A2": {ID: "i3", Kind: inventory.KindBelt, Color: grading.BeltPurple, Size: "A2", Quantity: 5},
+		},
+		sizes: map[string]string{"m1": "A2"},
+	}
+	records := &mockGSRecordStore{records: []grading.Record{
+		{ID: "r1", MemberID: "m1", Belt: grading.BeltWhite, Stripe: 4, PromotedAt: now.AddDate(-1, 0, 0)},
+	}}
+	deps := ConsumeGradingStockDeps{StockStore: stock, RecordStore: records, Now: func() time.Time { return now }}
+
+	blue := grading.Record{ID: "r2", MemberID: "m1", Belt: grading.BeltBlue, Stripe: 2, PromotedAt: now}
+	records.records = append(records.records, blue)
+	result, err := ExecuteConsumeGradingStock(context.Background(), ConsumeGradingStockInput{Record: blue}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := stock.items["belt/blue/A2"]; got.Quantity != 1 || !got.UpdatedAt.Equal(now) {
+		t.Errorf("blue A2 = %+v, want 1 left, updated now", got)
+	}
+	if got := stock.items["stripe/white/"]; got.Quantity != 0 {
+		t.Errorf("stripes = %d, want 0", got.Quantity)
+	}
+	if len(result.Taken) != 2 || result.Taken[1].Quantity != 1 {
+		t.Errorf("Taken = %+v, want the belt and one stripe", result.Taken)
+	}
+	if len(result.Missing) != 1 || result.Missing[0].Kind != inventory.KindStripe || result.Missing[0].Quantity != 1 {
+		t.Errorf("Missing = %+v, want one stripe short", result.Missing)
+	}
+	if len(result.Low) != 2 {
+		t.Errorf("Low = %+v, want the blue belts and the stripes", result.Low)
+	}
+	if got := stock.items["belt/purple/A2"]; got.Quantity != 5 {
+		t.Errorf("purple A2 = %d, want untouched", got.Quantity)
+	}
+}
+
+// TestExecuteConsumeGradingStock_NoSize verifies a belt for a member without a recorded
+// size is reported missing and takes nothing.
+func TestExecuteConsumeGradingStock_NoSize(t *testing.T) {
+	now := time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+	stock := &mockGSStockStore{
+		items: map[string]inventory.Item{"belt/blue/A2": {Kind: inventory.KindBelt, Color: grading.BeltBlue, Size: "A2", Quantity: 2}},
+		sizes: map[string]string{},
+	}
+	record := grading.Record{ID: "r1", MemberID: "m2", Belt: grading.BeltBlue, PromotedAt: now}
+	result, err := ExecuteConsumeGradingStock(context.Background(), ConsumeGradingStockInput{Record: record}, ConsumeGradingStockDeps{
+		StockStore:  stock,
+		RecordStore: &mockGSRecordStore{},
+		Now:         func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Taken) != 0 || len(result.Missing) != 1 || result.Missing[0].Size != "" {
+		t.Errorf("result = %+v, want the unsized belt missing", result)
+	}
+	if stock.items["belt/blue/A2"].Quantity != 2 {
+		t.Error("expected stock untouched")
+	}
+}
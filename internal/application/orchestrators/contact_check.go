@@ -0,0 +1,126 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/contactcheck"
+	"workshop/internal/domain/member"
+)
+
+// ErrNoContactCheck is returned when the kiosk answers a check the member was never asked.
+var ErrNoContactCheck = errors.New("no email check is waiting for this member")
+
+// ContactCheckMemberStore defines the member store interface needed by contact checks.
+type ContactCheckMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+	GetByEmail(ctx context.Context, email string) (member.Member, error)
+	Save(ctx context.Context, value member.Member) error
+}
+
+// ContactCheckStore defines the contact check store interface needed by contact checks.
+type ContactCheckStore interface {
+	Get(ctx context.Context, memberID string) (contactcheck.Check, error)
+	Save(ctx context.Context, value contactcheck.Check) error
+}
+
+// ContactCheckDeps holds dependencies for requesting and answering contact checks.
+type ContactCheckDeps struct {
+	MemberStore ContactCheckMemberStore
+	CheckStore  ContactCheckStore
+	Now         func() time.Time
+}
+
+// RequestContactChecksInput carries input for asking members to confirm their email.
+type RequestContactChecksInput struct {
+	MemberIDs   []string
+	RequestedBy string // AccountID of the admin asking
+}
+
+// ExecuteRequestContactChecks asks each member to confirm the email address on file at their
+// next kiosk check-in. Asking again replaces an earlier check, answered or not.
+// PRE: RequestedBy is an admin
+// POST: Returns the number of members asked; ErrNoMembers, ErrTooManyMembers or the first
+// unknown member's lookup error otherwise, with nothing saved
+func ExecuteRequestContactChecks(ctx context.Context, input RequestContactChecksInput, deps ContactCheckDeps) (int, error) {
+	if len(input.MemberIDs) == 0 {
+		return 0, contactcheck.ErrNoMembers
+	}
+	if len(input.MemberIDs) > contactcheck.MaxRequestMembers {
+		return 0, contactcheck.ErrTooManyMembers
+	}
+	now := deps.Now()
+	var checks []contactcheck.Check
+	seen := map[string]bool{}
+	for _, id := range input.MemberIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		m, err := deps.MemberStore.GetByID(ctx, id)
+		if err != nil {
+			return 0, fmt.Errorf("member %s: %w", id, err)
+		}
+		c := contactcheck.Check{MemberID: m.ID, Address: m.Email, RequestedBy: input.RequestedBy, RequestedAt: now}
+		if err := c.Validate(); err != nil {
+			return 0, err
+		}
+		checks = append(checks, c)
+	}
+	for _, c := range checks {
+		if err := deps.CheckStore.Save(ctx, c); err != nil {
+			return 0, err
+		}
+	}
+	slog.InfoContext(ctx, "email_event", "event", "contact_checks_requested", "members", len(checks), "by", input.RequestedBy)
+	return len(checks), nil
+}
+
+// ResolveContactCheckInput carries a member's answer at the kiosk.
+type ResolveContactCheckInput struct {
+	MemberID string
+	Address  string // empty confirms the address on file
+}
+
+// ExecuteResolveContactCheck records a member's answer to their open contact check. A new
+// address replaces the one on a member without an account; for a member with an account it
+// is only recorded, because changing a sign-in needs the member's password.
+// PRE: MemberID has an open check
+// POST: Returns the answered check; ErrNoContactCheck, contactcheck.ErrAlreadyResolved,
+// contactcheck.ErrInvalidAddress or account.ErrEmailTaken otherwise
+func ExecuteResolveContactCheck(ctx context.Context, input ResolveContactCheckInput, deps ContactCheckDeps) (contactcheck.Check, error) {
+	c, err := deps.CheckStore.Get(ctx, input.MemberID)
+	if err != nil {
+		return contactcheck.Check{}, ErrNoContactCheck
+	}
+	m, err := deps.MemberStore.GetByID(ctx, input.MemberID)
+	if err != nil {
+		return contactcheck.Check{}, err
+	}
+	if err := c.Resolve(input.Address, m.AccountID != "", deps.Now()); err != nil {
+		return contactcheck.Check{}, err
+	}
+	if c.NewAddress != "" {
+		if other, err := deps.MemberStore.GetByEmail(ctx, c.NewAddress); err == nil && other.ID != m.ID {
+			return contactcheck.Check{}, account.ErrEmailTaken
+		}
+	}
+	if c.Resolution == contactcheck.ResolutionCorrected {
+		m.Email = c.NewAddress
+		if err := m.Validate(); err != nil {
+			return contactcheck.Check{}, err
+		}
+		if err := deps.MemberStore.Save(ctx, m); err != nil {
+			return contactcheck.Check{}, err
+		}
+	}
+	if err := deps.CheckStore.Save(ctx, c); err != nil {
+		return contactcheck.Check{}, err
+	}
+	slog.InfoContext(ctx, "email_event", "event", "contact_check_resolved", "member_id", m.ID, "resolution", c.Resolution)
+	return c, nil
+}
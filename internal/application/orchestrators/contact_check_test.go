@@ -0,0 +1,124 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/contactcheck"
+	"workshop/internal/domain/member"
+)
+
+type mockContactCheckMemberStore struct {
+	members map[string]member.Member
+}
+
+// GetByID implements ContactCheckMemberStore.
+// PRE: id is non-empty
+// POST: returns the member or an error if none exists
+func (m *mockContactCheckMemberStore) GetByID(_ context.Context, id string) (member.Member, error) {
+	mem, ok := m.members[id]
+	if !ok {
+		return member.Member{}, errors.New("not found")
+	}
+	return mem, nil
+}
+
+// GetByEmail implements ContactCheckMemberStore.
+// PRE: email is non-empty
+// POST: returns the member with the address or an error if none has it
+func (m *mockContactCheckMemberStore) GetByEmail(_ context.Context, email string) (member.Member, error) {
+	for _, mem := range m.members {
+		if mem.Email == email {
+			return mem, nil
+		}
+	}
+	return member.Member{}, errors.New("not found")
+}
+
+// Save implements ContactCheckMemberStore.
+// PRE: value has an ID
+// POST: the member is stored by ID
+func (m *mockContactCheckMemberStore) Save(_ context.Context, value member.Member) error {
+	m.members[value.ID] = value
+	return nil
+}
+
+type mockContactCheckStore struct {
+	checks map[string]contactcheck.Check
+}
+
+// Get implements ContactCheckStore.
+// PRE: memberID is non-empty
+// POST: returns the member's check or an error if none exists
+func (m *mockContactCheckStore) Get(_ context.Context, memberID string) (contactcheck.Check, error) {
+	c, ok := m.checks[memberID]
+	if !ok {
+		return contactcheck.Check{}, errors.New("not found")
+	}
+	return c, nil
+}
+
+// Save implements ContactCheckStore.
+// PRE: value has a MemberID
+// POST: the check replaces the member's earlier one
+func (m *mockContactCheckStore) Save(_ context.Context, value contactcheck.Check) error {
+	m.checks[value.MemberID] = value
+	return nil
+}
+
+// TestContactChecks verifies admins ask members to confirm their email, and the kiosk answer
+// confirms it, corrects it, or only records it for a member who signs in with it.
+func TestContactChecks(t *testing.T) {
+	now := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	members := &mockContactCheckMemberStore{members: map[string]member.Member{
+		"m1": {ID: "m1", Name: "Jordan", Email: "jordan@example.com", Program: member.ProgramAdults, Status: member.StatusActive},
+		"m2": {ID: "m2", Name: "Kim", Email: "kim@example.com", AccountID: "acct-kim", Program: member.ProgramAdults, Status: member.StatusActive},
+		"m3": {ID: "m3", Name: "Sam", Email: "sam@example.com", Program: member.ProgramAdults, Status: member.StatusActive},
+	}}
+	checks := &mockContactCheckStore{checks: map[string]contactcheck.Check{}}
+	deps := ContactCheckDeps{MemberStore: members, CheckStore: checks, Now: func() time.Time { return now }}
+	ctx := context.Background()
+
+	if _, err := ExecuteRequestContactChecks(ctx, RequestContactChecksInput{}, deps); err != contactcheck.ErrNoMembers {
+		t.Errorf("no members: got %v", err)
+	}
+	if _, err := ExecuteRequestContactChecks(ctx, RequestContactChecksInput{MemberIDs: []string{"m1", "missing"}}, deps); err == nil || len(checks.checks) != 0 {
+		t.Errorf("unknown member: got %v with %d checks saved, want an error and nothing saved", err, len(checks.checks))
+	}
+	n, err := ExecuteRequestContactChecks(ctx, RequestContactChecksInput{MemberIDs: []string{"m1", "m2", "m3", "m1"}, RequestedBy: "admin"}, deps)
+	if err != nil || n != 3 {
+		t.Fatalf("request: got %d, %v", n, err)
+	}
+	if c := checks.checks["m1"]; !c.Open() || c.Address != "jordan@example.com" || c.RequestedBy != "admin" {
+		t.Errorf("m1 check = %+v", c)
+	}
+
+	if _, err := ExecuteResolveContactCheck(ctx, ResolveContactCheckInput{MemberID: "m1", Address: "sam@example.com"}, deps); err != account.ErrEmailTaken {
+		t.Errorf("taken address: got %v", err)
+	}
+	if !checks.checks["m1"].Open() {
+		t.Error("a refused answer should leave the check open")
+	}
+	c, err := ExecuteResolveContactCheck(ctx, ResolveContactCheckInput{MemberID: "m1", Address: "Jordan@Example.org"}, deps)
+	if err != nil || c.Resolution != contactcheck.ResolutionCorrected || members.members["m1"].Email != "jordan@example.org" {
+		t.Errorf("correct: got %+v, %v; member email %q", c, err, members.members["m1"].Email)
+	}
+	if _, err := ExecuteResolveContactCheck(ctx, ResolveContactCheckInput{MemberID: "m1"}, deps); err != contactcheck.ErrAlreadyResolved {
+		t.Errorf("answer twice: got %v", err)
+	}
+
+	c, err = ExecuteResolveContactCheck(ctx, ResolveContactCheckInput{MemberID: "m2", Address: "kim@example.org"}, deps)
+	if err != nil || c.Resolution != contactcheck.ResolutionProposed || c.NewAddress != "kim@example.org" || members.members["m2"].Email != "kim@example.com" {
+		t.Errorf("account holder: got %+v, %v; member email %q", c, err, members.members["m2"].Email)
+	}
+	if c, err := ExecuteResolveContactCheck(ctx, ResolveContactCheckInput{MemberID: "m3"}, deps); err != nil || c.Resolution != contactcheck.ResolutionConfirmed {
+		t.Errorf("confirm: got %+v, %v", c, err)
+	}
+	delete(checks.checks, "m3")
+	if _, err := ExecuteResolveContactCheck(ctx, ResolveContactCheckInput{MemberID: "m3"}, deps); err != ErrNoContactCheck {
+		t.Errorf("never asked: got %v", err)
+	}
+}
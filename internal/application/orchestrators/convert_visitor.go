@@ -0,0 +1,97 @@
+package orchestrators
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"workshop/internal/domain/account"
+	emailDomain "workshop/internal/domain/email"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/visitor"
+)
+
+// ConvertVisitorStore defines the visitor store interface needed by ConvertVisitor.
+type ConvertVisitorStore interface {
+	GetVisitor(ctx context.Context, id string) (visitor.Visitor, error)
+	SaveVisitor(ctx context.Context, v visitor.Visitor) error
+}
+
+// ConvertVisitorMemberStore defines the member store interface needed by ConvertVisitor.
+type ConvertVisitorMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+}
+
+// ConvertVisitorAccountStore defines the account store interface needed by ConvertVisitor.
+type ConvertVisitorAccountStore interface {
+	GetByID(ctx context.Context, id string) (account.Account, error)
+	Save(ctx context.Context, a account.Account) error
+}
+
+// ConvertVisitorEmailStore defines the email store interface needed to withdraw a pending follow-up.
+type ConvertVisitorEmailStore interface {
+	GetByID(ctx context.Context, id string) (emailDomain.Email, error)
+	Save(ctx context.Context, e emailDomain.Email) error
+}
+
+// ConvertVisitorInput carries input for signing a visitor up.
+type ConvertVisitorInput struct {
+	VisitorID   string
+	To          string // visitor.StatusTrial or visitor.StatusMember
+	ConvertedBy string // AccountID of the admin
+}
+
+// ConvertVisitorDeps holds dependencies for ConvertVisitor.
+type ConvertVisitorDeps struct {
+	VisitorStore ConvertVisitorStore
+	MemberStore  ConvertVisitorMemberStore
+	AccountStore ConvertVisitorAccountStore
+	EmailStore   ConvertVisitorEmailStore // optional: nil leaves any pending follow-up in place
+	Now          func() time.Time
+}
+
+// ExecuteConvertVisitor signs a visitor up as a trial or member. When the visitor's member
+// record already has a guest or trial account, its role moves with them; staff accounts are
+// never changed. A follow-up email that has not gone out yet is withdrawn, since there is
+// nobody left to invite back.
+// PRE: VisitorID identifies an existing visitor
+// POST: Visitor status and ConvertedAt updated; visitor.ErrAlreadyConverted or
+// visitor.ErrInvalidConversion and nothing changes otherwise
+func ExecuteConvertVisitor(ctx context.Context, input ConvertVisitorInput, deps ConvertVisitorDeps) (visitor.Visitor, error) {
+	v, err := deps.VisitorStore.GetVisitor(ctx, input.VisitorID)
+	if err != nil {
+		return visitor.Visitor{}, err
+	}
+	if err := v.Convert(input.To, deps.Now()); err != nil {
+		return visitor.Visitor{}, err
+	}
+	if err := deps.VisitorStore.SaveVisitor(ctx, v); err != nil {
+		return visitor.Visitor{}, err
+	}
+
+	if m, err := deps.MemberStore.GetByID(ctx, v.MemberID); err == nil && m.AccountID != "" {
+		if acct, err := deps.AccountStore.GetByID(ctx, m.AccountID); err == nil && (acct.Role == account.RoleGuest || acct.Role == account.RoleTrial) {
+			acct.Role = account.RoleMember
+			if v.Status == visitor.StatusTrial {
+				acct.Role = account.RoleTrial
+			}
+			if err := deps.AccountStore.Save(ctx, acct); err != nil {
+				return v, err
+			}
+		}
+	}
+
+	if deps.EmailStore != nil && v.FollowUpEmailID != "" {
+		if em, err := deps.EmailStore.GetByID(ctx, v.FollowUpEmailID); err == nil && em.IsScheduled() {
+			if err := em.Cancel(); err == nil {
+				em.UpdatedAt = deps.Now()
+				if err := deps.EmailStore.Save(ctx, em); err != nil {
+					return v, err
+				}
+			}
+		}
+	}
+
+	slog.InfoContext(ctx, "guest_event", "event", "visitor_converted", "visitor_id", v.ID, "to", v.Status, "by", input.ConvertedBy)
+	return v, nil
+}
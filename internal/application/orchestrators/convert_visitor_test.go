@@ -0,0 +1,123 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/account"
+	emailDomain "workshop/internal/domain/email"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/visitor"
+)
+
+// --- Mock stores for visitor conversion tests ---
+
+type mockConvertVisitorStore struct{ visitors map[string]visitor.Visitor }
+
+// GetVisitor returns a stored visitor.
+// PRE: none
+// POST: Returns the visitor or an error
+func (m *mockConvertVisitorStore) GetVisitor(_ context.Context, id string) (visitor.Visitor, error) {
+	v, ok := m.visitors[id]
+	if !ok {
+		return visitor.Visitor{}, errors.New("not found")
+	}
+	return v, nil
+}
+
+// SaveVisitor stores the visitor.
+// PRE: none
+// POST: visitor stored by ID
+func (m *mockConvertVisitorStore) SaveVisitor(_ context.Context, v visitor.Visitor) error {
+	m.visitors[v.ID] = v
+	return nil
+}
+
+type mockConvertMemberStore struct{ members map[string]member.Member }
+
+// GetByID returns a stored member.
+// PRE: none
+// POST: Returns the member or an error
+func (m *mockConvertMemberStore) GetByID(_ context.Context, id string) (member.Member, error) {
+	v, ok := m.members[id]
+	if !ok {
+		return member.Member{}, errors.New("not found")
+	}
+	return v, nil
+}
+
+type mockConvertAccountStore struct{ accounts map[string]account.Account }
+
+// GetByID returns a stored account.
+// PRE: none
+// POST: Returns the account or an error
+func (m *mockConvertAccountStore) GetByID(_ context.Context, id string) (account.Account, error) {
+	a, ok := m.accounts[id]
+	if !ok {
+		return account.Account{}, errors.New("not found")
+	}
+	return a, nil
+}
+
+// Save stores the account.
+// PRE: none
+// POST: account stored by ID
+func (m *mockConvertAccountStore) Save(_ context.Context, a account.Account) error {
+	m.accounts[a.ID] = a
+	return nil
+}
+
+// TestExecuteConvertVisitor verifies converting moves the guest account's role and withdraws
+// the unsent follow-up email.
+func TestExecuteConvertVisitor(t *testing.T) {
+	visitors := &mockConvertVisitorStore{visitors: map[string]visitor.Visitor{
+		"v1": {ID: "v1", MemberID: "m1", Status: visitor.StatusVisiting, FollowUpEmailID: "e1"},
+	}}
+	accounts := &mockConvertAccountStore{accounts: map[string]account.Account{"a1": {ID: "a1", Role: account.RoleGuest}}}
+	emails := newMockEmailStore()
+	emails.emails["e1"] = emailDomain.Email{ID: "e1", Status: emailDomain.StatusScheduled}
+	deps := ConvertVisitorDeps{
+		VisitorStore: visitors,
+		MemberStore:  &mockConvertMemberStore{members: map[string]member.Member{"m1": {ID: "m1", AccountID: "a1"}}},
+		AccountStore: accounts,
+		EmailStore:   emails,
+		Now:          fixedNow,
+	}
+
+	got, err := ExecuteConvertVisitor(context.Background(), ConvertVisitorInput{VisitorID: "v1", To: visitor.StatusTrial, ConvertedBy: "admin-1"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != visitor.StatusTrial || !got.ConvertedAt.Equal(fixedNow()) || visitors.visitors["v1"].Status != visitor.StatusTrial {
+		t.Errorf("visitor after conversion: %+v", got)
+	}
+	if accounts.accounts["a1"].Role != account.RoleTrial {
+		t.Errorf("account role = %q, want trial", accounts.accounts["a1"].Role)
+	}
+	if emails.emails["e1"].Status != emailDomain.StatusCancelled {
+		t.Errorf("follow-up status = %q, want cancelled", emails.emails["e1"].Status)
+	}
+
+	if _, err := ExecuteConvertVisitor(context.Background(), ConvertVisitorInput{VisitorID: "v1", To: visitor.StatusTrial}, deps); !errors.Is(err, visitor.ErrAlreadyConverted) {
+		t.Errorf("converting twice: got %v, want ErrAlreadyConverted", err)
+	}
+}
+
+// TestExecuteConvertVisitor_LeavesStaffAccounts verifies a coach who once checked in as a guest keeps their role.
+func TestExecuteConvertVisitor_LeavesStaffAccounts(t *testing.T) {
+	accounts := &mockConvertAccountStore{accounts: map[string]account.Account{"a1": {ID: "a1", Role: account.RoleCoach}}}
+	deps := ConvertVisitorDeps{
+		VisitorStore: &mockConvertVisitorStore{visitors: map[string]visitor.Visitor{"v1": {ID: "v1", MemberID: "m1", Status: visitor.StatusVisiting}}},
+		MemberStore:  &mockConvertMemberStore{members: map[string]member.Member{"m1": {ID: "m1", AccountID: "a1"}}},
+		AccountStore: accounts,
+		Now:          func() time.Time { return fixedTime },
+	}
+	if _, err := ExecuteConvertVisitor(context.Background(), ConvertVisitorInput{VisitorID: "v1", To: visitor.StatusMember}, deps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if accounts.accounts["a1"].Role != account.RoleCoach {
+		t.Errorf("account role = %q, want coach", accounts.accounts["a1"].Role)
+	}
+}
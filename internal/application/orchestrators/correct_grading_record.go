@@ -0,0 +1,158 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/audit"
+	"workshop/internal/domain/grading"
+)
+
+// ErrGradingRecordNotFound is returned when the record to correct does not exist.
+var ErrGradingRecordNotFound = errors.New("grading record not found")
+
+// CorrectGradingRecordStore defines the grading record store interface needed for corrections.
+type CorrectGradingRecordStore interface {
+	GetByID(ctx context.Context, id string) (grading.Record, error)
+	Save(ctx context.Context, value grading.Record) error
+}
+
+// AmendGradingRecordInput carries the corrected belt, stripe and date for a grading record.
+type AmendGradingRecordInput struct {
+	RecordID   string
+	Belt       string
+	Stripe     int
+	PromotedAt time.Time // zero keeps the original date
+	Reason     string
+	Actor      BackfillActor
+}
+
+// VoidGradingRecordInput names a grading record that should never have been recorded.
+type VoidGradingRecordInput struct {
+	RecordID string
+	Reason   string
+	Actor    BackfillActor
+}
+
+// CorrectGradingRecordDeps holds dependencies for grading record corrections.
+type CorrectGradingRecordDeps struct {
+	RecordStore CorrectGradingRecordStore
+	AuditStore  BackfillAuditStore
+	GenerateID  func() string
+	Now         func() time.Time
+}
+
+// ExecuteAmendGradingRecord corrects a grading record by saving a new record that supersedes
+// it. The original is kept, marked as superseded, so the history shows what changed and why.
+// PRE: input.Actor.AccountID is an admin
+// POST: Returns the new effective record; the original is superseded and the change audited
+func ExecuteAmendGradingRecord(ctx context.Context, input AmendGradingRecordInput, deps CorrectGradingRecordDeps) (grading.Record, error) {
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return grading.Record{}, grading.ErrEmptyCorrectionReason
+	}
+	original, err := effectiveGradingRecord(ctx, input.RecordID, deps)
+	if err != nil {
+		return grading.Record{}, err
+	}
+
+	amended := original
+	amended.ID = deps.GenerateID()
+	amended.Belt = input.Belt
+	amended.Stripe = input.Stripe
+	if !input.PromotedAt.IsZero() {
+		amended.PromotedAt = input.PromotedAt
+	}
+	if amended.Belt == original.Belt && amended.Stripe == original.Stripe && amended.PromotedAt.Equal(original.PromotedAt) {
+		return grading.Record{}, grading.ErrCorrectionUnchanged
+	}
+	if err := amended.Validate(); err != nil {
+		return grading.Record{}, err
+	}
+	amended.Supersedes = original.ID
+	amended.CorrectedBy = input.Actor.AccountID
+	amended.CorrectedAt = deps.Now()
+	amended.CorrectionReason = reason
+	if err := deps.RecordStore.Save(ctx, amended); err != nil {
+		return grading.Record{}, err
+	}
+	original.SupersededBy = amended.ID
+	if err := deps.RecordStore.Save(ctx, original); err != nil {
+		return grading.Record{}, err
+	}
+
+	description := "Amended grading record from " + describeGradingRecord(original) + " to " + describeGradingRecord(amended) + ": " + reason
+	gradingCorrectionAudit(ctx, audit.ActionUpdate, amended, original, description, input.Actor, deps)
+	slog.InfoContext(ctx, "grading_event", "event", "record_amended", "record_id", amended.ID, "supersedes", original.ID, "member_id", original.MemberID)
+	return amended, nil
+}
+
+// ExecuteVoidGradingRecord marks a grading record recorded in error as void. The record is
+// kept for the audit trail but no longer counts towards the member's belt or readiness.
+// PRE: input.Actor.AccountID is an admin
+// POST: Returns the voided record; the change is audited
+func ExecuteVoidGradingRecord(ctx context.Context, input VoidGradingRecordInput, deps CorrectGradingRecordDeps) (grading.Record, error) {
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return grading.Record{}, grading.ErrEmptyCorrectionReason
+	}
+	record, err := effectiveGradingRecord(ctx, input.RecordID, deps)
+	if err != nil {
+		return grading.Record{}, err
+	}
+	record.Voided = true
+	record.CorrectedBy = input.Actor.AccountID
+	record.CorrectedAt = deps.Now()
+	record.CorrectionReason = reason
+	if err := deps.RecordStore.Save(ctx, record); err != nil {
+		return grading.Record{}, err
+	}
+
+	description := "Voided grading record " + describeGradingRecord(record) + ": " + reason
+	gradingCorrectionAudit(ctx, audit.ActionDelete, record, record, description, input.Actor, deps)
+	slog.InfoContext(ctx, "grading_event", "event", "record_voided", "record_id", record.ID, "member_id", record.MemberID)
+	return record, nil
+}
+
+// effectiveGradingRecord loads a record that can still be corrected.
+func effectiveGradingRecord(ctx context.Context, id string, deps CorrectGradingRecordDeps) (grading.Record, error) {
+	record, err := deps.RecordStore.GetByID(ctx, id)
+	if err != nil {
+		return grading.Record{}, ErrGradingRecordNotFound
+	}
+	if !record.IsEffective() {
+		return grading.Record{}, grading.ErrRecordNotEffective
+	}
+	return record, nil
+}
+
+// describeGradingRecord renders a record as e.g. "blue belt, 2 stripes on 2026-03-01".
+func describeGradingRecord(r grading.Record) string {
+	return r.Belt + " belt, " + strconv.Itoa(r.Stripe) + " stripes on " + r.PromotedAt.Format("2006-01-02")
+}
+
+// gradingCorrectionAudit records a correction in the audit log. A failure is logged, not
+// returned: the correction has already been saved.
+func gradingCorrectionAudit(ctx context.Context, action audit.Action, record, original grading.Record, description string, actor BackfillActor, deps CorrectGradingRecordDeps) {
+	metadata, _ := json.Marshal(map[string]string{
+		"member_id":       record.MemberID,
+		"original_id":     original.ID,
+		"original_belt":   original.Belt,
+		"original_stripe": strconv.Itoa(original.Stripe),
+		"original_date":   original.PromotedAt.Format("2006-01-02"),
+		"reason":          record.CorrectionReason,
+	})
+	event := audit.NewEvent(actor.AccountID, actor.Email, actor.Role, audit.CategoryMember, action).
+		WithResource("grading_record", record.ID).
+		WithDescription(description).
+		WithRequest(actor.IPAddress, actor.UserAgent).
+		WithMetadata(string(metadata))
+	if err := deps.AuditStore.Save(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "grading_event", "event", "correction_audit_failed", "record_id", record.ID, "error", err)
+	}
+}
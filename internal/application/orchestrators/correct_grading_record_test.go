@@ -0,0 +1,121 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/audit"
+	"workshop/internal/domain/grading"
+)
+
+type mockCorrectGradingRecordStore struct {
+	records map[string]grading.Record
+}
+
+// GetByID implements CorrectGradingRecordStore.
+// PRE: none
+// POST: Returns the record or sql.ErrNoRows
+func (m *mockCorrectGradingRecordStore) GetByID(_ context.Context, id string) (grading.Record, error) {
+	r, ok := m.records[id]
+	if !ok {
+		return grading.Record{}, sql.ErrNoRows
+	}
+	return r, nil
+}
+
+// Save implements CorrectGradingRecordStore.
+// PRE: none
+// POST: The record is upserted
+func (m *mockCorrectGradingRecordStore) Save(_ context.Context, value grading.Record) error {
+	m.records[value.ID] = value
+	return nil
+}
+
+func newCorrectGradingDeps() (CorrectGradingRecordDeps, *mockCorrectGradingRecordStore, *mockBackfillAuditStore) {
+	store := &mockCorrectGradingRecordStore{records: map[string]grading.Record{
+		"r1": {ID: "r1", MemberID: "m1", Belt: grading.BeltBlue, Stripe: 2, PromotedAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Method: grading.MethodStandard},
+	}}
+	auditStore := &mockBackfillAuditStore{}
+	return CorrectGradingRecordDeps{
+		RecordStore: store,
+		AuditStore:  auditStore,
+		GenerateID:  func() string { return "r2" },
+		Now:         func() time.Time { return time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC) },
+	}, store, auditStore
+}
+
+// TestExecuteAmendGradingRecord verifies an amendment supersedes the original, keeps it, and
+// is audited with the original values.
+func TestExecuteAmendGradingRecord(t *testing.T) {
+	deps, store, auditStore := newCorrectGradingDeps()
+	actor := BackfillActor{AccountID: "admin-1", Email: "admin@test.com", Role: "admin"}
+
+	amended, err := ExecuteAmendGradingRecord(context.Background(), AmendGradingRecordInput{
+		RecordID: "r1", Belt: grading.BeltPurple, Stripe: 0, Reason: "Wrong belt entered", Actor: actor,
+	}, deps)
+	if err != nil {
+		t.Fatalf("amend: %v", err)
+	}
+	if amended.ID != "r2" || amended.Supersedes != "r1" || amended.Belt != grading.BeltPurple || !amended.PromotedAt.Equal(store.records["r1"].PromotedAt) {
+		t.Errorf("unexpected amended record: %+v", amended)
+	}
+	if original := store.records["r1"]; original.SupersededBy != "r2" || original.Belt != grading.BeltBlue || original.IsEffective() {
+		t.Errorf("original should be kept and superseded, got %+v", original)
+	}
+	if len(auditStore.events) != 1 || auditStore.events[0].Category != audit.CategoryMember || auditStore.events[0].ResourceID != "r2" {
+		t.Fatalf("expected one member audit event for r2, got %+v", auditStore.events)
+	}
+
+	_, err = ExecuteAmendGradingRecord(context.Background(), AmendGradingRecordInput{
+		RecordID: "r1", Belt: grading.BeltBrown, Reason: "Again", Actor: actor,
+	}, deps)
+	if !errors.Is(err, grading.ErrRecordNotEffective) {
+		t.Errorf("amending a superseded record: expected ErrRecordNotEffective, got %v", err)
+	}
+}
+
+// TestExecuteAmendGradingRecord_Rejects verifies the reason, change and record checks.
+func TestExecuteAmendGradingRecord_Rejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		input AmendGradingRecordInput
+		want  error
+	}{
+		{"no reason", AmendGradingRecordInput{RecordID: "r1", Belt: grading.BeltPurple, Reason: "  "}, grading.ErrEmptyCorrectionReason},
+		{"missing record", AmendGradingRecordInput{RecordID: "nope", Belt: grading.BeltPurple, Reason: "typo"}, ErrGradingRecordNotFound},
+		{"unchanged", AmendGradingRecordInput{RecordID: "r1", Belt: grading.BeltBlue, Stripe: 2, Reason: "typo"}, grading.ErrCorrectionUnchanged},
+		{"invalid belt", AmendGradingRecordInput{RecordID: "r1", Belt: "pink", Reason: "typo"}, grading.ErrInvalidBelt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, store, _ := newCorrectGradingDeps()
+			if _, err := ExecuteAmendGradingRecord(context.Background(), tt.input, deps); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if len(store.records) != 1 {
+				t.Errorf("no record should be saved, got %d", len(store.records))
+			}
+		})
+	}
+}
+
+// TestExecuteVoidGradingRecord verifies a void keeps the record but takes it out of the history.
+func TestExecuteVoidGradingRecord(t *testing.T) {
+	deps, store, auditStore := newCorrectGradingDeps()
+
+	voided, err := ExecuteVoidGradingRecord(context.Background(), VoidGradingRecordInput{
+		RecordID: "r1", Reason: "Recorded against the wrong member", Actor: BackfillActor{AccountID: "admin-1"},
+	}, deps)
+	if err != nil {
+		t.Fatalf("void: %v", err)
+	}
+	if !voided.Voided || voided.CorrectedBy != "admin-1" || store.records["r1"].IsEffective() {
+		t.Errorf("expected r1 voided, got %+v", store.records["r1"])
+	}
+	if len(auditStore.events) != 1 || auditStore.events[0].Action != audit.ActionDelete {
+		t.Errorf("expected one delete audit event, got %+v", auditStore.events)
+	}
+}
@@ -77,7 +77,7 @@ func ExecuteCreateAccount(ctx context.Context, input CreateAccountInput, deps Cr
 		return "", err
 	}
 
-	slog.Info("auth_event", "event", "account_created", "email", input.Email, "role", input.Role)
+	slog.InfoContext(ctx, "auth_event", "event", "account_created", "email", input.Email, "role", input.Role)
 
 	return acct.ID, nil
 }
@@ -104,6 +104,6 @@ func ExecuteSeedAdmin(ctx context.Context, deps CreateAccountDeps, email, passwo
 		return err
 	}
 
-	slog.Info("auth_event", "event", "admin_seeded", "email", email)
+	slog.InfoContext(ctx, "auth_event", "event", "admin_seeded", "email", email)
 	return nil
 }
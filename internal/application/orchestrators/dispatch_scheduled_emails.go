@@ -0,0 +1,189 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	emailAdapter "workshop/internal/adapters/email"
+	emailStore "workshop/internal/adapters/storage/email"
+	emailDomain "workshop/internal/domain/email"
+)
+
+// Defaults for per-recipient send retries when dispatching scheduled emails.
+const (
+	DefaultScheduledSendAttempts   = 3
+	DefaultScheduledSendRetryDelay = 2 * time.Second
+)
+
+// ScheduledEmailStore defines the store interface needed to dispatch scheduled emails.
+type ScheduledEmailStore interface {
+	EmailStoreForOrchestrator
+	List(ctx context.Context, filter emailStore.ListFilter) ([]emailDomain.Email, error)
+}
+
+// DispatchScheduledEmailsDeps holds dependencies for DispatchScheduledEmails.
+type DispatchScheduledEmailsDeps struct {
+	EmailStore  ScheduledEmailStore
+	EmailSender emailAdapter.Sender
+	Now         func() time.Time
+	Sleep       func(time.Duration) // waits between retries; nil uses time.Sleep
+	FromAddress string
+	ReplyTo     string
+	MaxAttempts int           // per recipient; 0 uses DefaultScheduledSendAttempts
+	RetryDelay  time.Duration // doubles after each failed attempt; 0 uses DefaultScheduledSendRetryDelay
+	// PreferenceStore skips members who unsubscribed from an email's category; nil sends to everyone.
+	PreferenceStore EmailPreferenceStore
+	// UnsubscribeURL builds each recipient's unsubscribe link; nil omits the links.
+	UnsubscribeURL func(memberID, category string) string
+}
+
+// DispatchScheduledEmailsResult summarises one dispatch run.
+type DispatchScheduledEmailsResult struct {
+	Emails       int // scheduled emails that were due
+	Sent         int // recipient copies accepted by the provider
+	Failed       int // recipient copies that exhausted their retries
+	Unsubscribed int // recipients skipped because they opted out of the email's category
+}
+
+// ExecuteDispatchScheduledEmails sends every scheduled email whose time has arrived.
+// Each recipient is sent individually and retried with backoff, so one rejected address
+// does not hold up the rest. An email is marked sent if any copy went out, failed otherwise.
+// PRE: deps are non-nil
+// POST: Due emails leave the scheduled state; recipient delivery statuses are recorded
+func ExecuteDispatchScheduledEmails(ctx context.Context, deps DispatchScheduledEmailsDeps) (DispatchScheduledEmailsResult, error) {
+	var result DispatchScheduledEmailsResult
+
+	scheduled, err := deps.EmailStore.List(ctx, emailStore.ListFilter{Status: emailDomain.StatusScheduled})
+	if err != nil {
+		return result, err
+	}
+
+	var errs []error
+	for _, em := range scheduled {
+		if !em.IsDue(deps.Now()) {
+			continue
+		}
+		result.Emails++
+		outcome, err := dispatchScheduledEmail(ctx, em, deps)
+		result.Sent += outcome.Sent
+		result.Failed += outcome.Failed
+		result.Unsubscribed += outcome.Unsubscribed
+		if err != nil {
+			slog.ErrorContext(ctx, "email_event", "event", "scheduled_dispatch_failed", "email_id", em.ID, "error", err)
+			errs = append(errs, err)
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// dispatchScheduledEmail sends one due email and records the outcome.
+// The returned result counts this email's recipients only; Emails is left zero.
+func dispatchScheduledEmail(ctx context.Context, em emailDomain.Email, deps DispatchScheduledEmailsDeps) (DispatchScheduledEmailsResult, error) {
+	var out DispatchScheduledEmailsResult
+	if err := em.MarkDue(deps.Now()); err != nil {
+		return out, err
+	}
+	// Save the queued state first so a crash mid-send cannot dispatch the email twice.
+	if err := deps.EmailStore.Save(ctx, em); err != nil {
+		return out, err
+	}
+
+	recipients, err := deps.EmailStore.GetRecipients(ctx, em.ID)
+	if err != nil {
+		em.MarkFailed()
+		deps.EmailStore.Save(ctx, em)
+		return out, err
+	}
+
+	var tpl emailDomain.EmailTemplate
+	if active, tplErr := deps.EmailStore.GetActiveTemplate(ctx); tplErr == nil {
+		tpl = active
+		em.TemplateVersionID = tpl.ID
+	}
+	category := em.CategoryFor()
+
+	firstID := ""
+	for i, r := range recipients {
+		if r.MemberEmail == "" {
+			continue
+		}
+		if blocked, err := deps.EmailStore.IsSuppressed(ctx, emailDomain.NormalizeAddress(r.MemberEmail)); err == nil && blocked {
+			recipients[i].DeliveryStatus = emailDomain.DeliverySuppressed
+			continue
+		}
+		if optedOut(ctx, deps.PreferenceStore, r.MemberID, category) {
+			recipients[i].DeliveryStatus = emailDomain.DeliveryUnsubscribed
+			out.Unsubscribed++
+			continue
+		}
+		res, err := sendWithRetry(ctx, emailAdapter.SendRequest{
+			To:      []string{r.MemberEmail},
+			From:    deps.FromAddress,
+			Subject: em.Subject,
+			HTML:    tpl.WrapBodyFor(recipientBody(em.Body, r), unsubscribeLink(deps.UnsubscribeURL, r.MemberID, category)),
+			ReplyTo: deps.ReplyTo,
+		}, deps)
+		recipients[i].DeliveryUpdatedAt = deps.Now()
+		if err != nil {
+			recipients[i].DeliveryStatus = emailDomain.DeliveryFailed
+			out.Failed++
+			slog.WarnContext(ctx, "email_event", "event", "recipient_send_failed", "email_id", em.ID, "member_id", r.MemberID, "error", err)
+			continue
+		}
+		recipients[i].DeliveryStatus = emailDomain.DeliverySent
+		recipients[i].ResendMessageID = res.MessageID
+		if firstID == "" {
+			firstID = res.MessageID
+		}
+		out.Sent++
+	}
+
+	if out.Sent > 0 {
+		em.MarkSent(deps.Now(), firstID)
+	} else {
+		em.MarkFailed()
+	}
+	if err := deps.EmailStore.Save(ctx, em); err != nil {
+		return out, err
+	}
+	if err := deps.EmailStore.SaveRecipients(ctx, em.ID, recipients); err != nil {
+		slog.ErrorContext(ctx, "email_event", "event", "recipient_tracking_failed", "email_id", em.ID, "error", err)
+	}
+
+	slog.InfoContext(ctx, "email_event", "event", "scheduled_email_dispatched", "email_id", em.ID, "status", em.Status,
+		"sent", out.Sent, "failed", out.Failed, "unsubscribed", out.Unsubscribed)
+	return out, nil
+}
+
+// sendWithRetry sends req, retrying with exponential backoff until MaxAttempts is reached.
+func sendWithRetry(ctx context.Context, req emailAdapter.SendRequest, deps DispatchScheduledEmailsDeps) (emailAdapter.SendResult, error) {
+	attempts := deps.MaxAttempts
+	if attempts <= 0 {
+		attempts = DefaultScheduledSendAttempts
+	}
+	delay := deps.RetryDelay
+	if delay <= 0 {
+		delay = DefaultScheduledSendRetryDelay
+	}
+	sleep := deps.Sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		res, err := deps.EmailSender.Send(ctx, req)
+		if err == nil {
+			return res, nil
+		}
+		lastErr = err
+		if attempt == attempts || ctx.Err() != nil {
+			break
+		}
+		sleep(delay)
+		delay *= 2
+	}
+	return emailAdapter.SendResult{}, lastErr
+}
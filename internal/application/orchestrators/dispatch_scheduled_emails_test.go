@@ -0,0 +1,165 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	emailAdapter "workshop/internal/adapters/email"
+	emailDomain "workshop/internal/domain/email"
+)
+
+// flakySender fails the first failures[address] sends to each address.
+type flakySender struct {
+	failures map[string]int
+	attempts map[string]int
+}
+
+// Send fails while the address still has failures left.
+// PRE: req has one recipient
+// POST: Increments the address's attempt count
+func (f *flakySender) Send(_ context.Context, req emailAdapter.SendRequest) (emailAdapter.SendResult, error) {
+	addr := req.To[0]
+	f.attempts[addr]++
+	if f.attempts[addr] <= f.failures[addr] {
+		return emailAdapter.SendResult{}, errors.New("provider unavailable")
+	}
+	return emailAdapter.SendResult{MessageID: "msg-" + addr}, nil
+}
+
+// SendBatch is unused by the scheduled dispatcher.
+// PRE: none
+// POST: Returns an error
+func (f *flakySender) SendBatch(_ context.Context, _ []emailAdapter.SendRequest) ([]emailAdapter.SendResult, error) {
+	return nil, errors.New("not supported")
+}
+
+func seedScheduledEmail(store *mockEmailStore, id string, at time.Time, addrs ...string) {
+	store.emails[id] = emailDomain.Email{
+		ID: id, Subject: "Grading day", Body: "See you there", SenderID: "admin-1",
+		Status: emailDomain.StatusScheduled, ScheduledAt: at,
+	}
+	var recs []emailDomain.Recipient
+	for i, a := range addrs {
+		recs = append(recs, emailDomain.Recipient{EmailID: id, MemberID: "member-" + string(rune('1'+i)), MemberEmail: a})
+	}
+	store.recipients[id] = recs
+}
+
+// TestDispatchScheduledEmails tests due emails are sent with per-recipient retry and not-yet-due ones are left alone.
+func TestDispatchScheduledEmails(t *testing.T) {
+	store := newMockEmailStore()
+	seedScheduledEmail(store, "due", emailFixedTime.Add(-time.Minute), "a@x.com", "b@x.com", "c@x.com")
+	seedScheduledEmail(store, "later", emailFixedTime.Add(time.Hour), "a@x.com")
+	store.suppressions["c@x.com"] = emailDomain.Suppression{Address: "c@x.com"}
+
+	sender := &flakySender{
+		failures: map[string]int{"a@x.com": 1, "b@x.com": 5},
+		attempts: map[string]int{},
+	}
+	var slept []time.Duration
+	res, err := ExecuteDispatchScheduledEmails(context.Background(), DispatchScheduledEmailsDeps{
+		EmailStore:  store,
+		EmailSender: sender,
+		Now:         testNow,
+		Sleep:       func(d time.Duration) { slept = append(slept, d) },
+		MaxAttempts: 3,
+		RetryDelay:  time.Second,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Emails != 1 || res.Sent != 1 || res.Failed != 1 {
+		t.Errorf("result = %+v, want 1 email, 1 sent, 1 failed", res)
+	}
+	if sender.attempts["a@x.com"] != 2 || sender.attempts["b@x.com"] != 3 || sender.attempts["c@x.com"] != 0 {
+		t.Errorf("attempts = %v", sender.attempts)
+	}
+	if len(slept) != 3 || slept[1] != time.Second || slept[2] != 2*time.Second {
+		t.Errorf("backoff = %v, want [1s 1s 2s]", slept)
+	}
+
+	if em := store.emails["due"]; em.Status != emailDomain.StatusSent || em.ResendMessageID != "msg-a@x.com" {
+		t.Errorf("due email = %q/%q, want sent/msg-a@x.com", em.Status, em.ResendMessageID)
+	}
+	if em := store.emails["later"]; em.Status != emailDomain.StatusScheduled {
+		t.Errorf("later email status = %q, want scheduled", em.Status)
+	}
+	want := map[string]string{
+		"a@x.com": emailDomain.DeliverySent,
+		"b@x.com": emailDomain.DeliveryFailed,
+		"c@x.com": emailDomain.DeliverySuppressed,
+	}
+	for _, r := range store.recipients["due"] {
+		if r.DeliveryStatus != want[r.MemberEmail] {
+			t.Errorf("%s delivery = %q, want %q", r.MemberEmail, r.DeliveryStatus, want[r.MemberEmail])
+		}
+	}
+}
+
+// TestDispatchScheduledEmails_AllFail tests an email is marked failed when no copy could be sent.
+func TestDispatchScheduledEmails_AllFail(t *testing.T) {
+	store := newMockEmailStore()
+	seedScheduledEmail(store, "due", emailFixedTime, "a@x.com")
+	sender := &flakySender{failures: map[string]int{"a@x.com": 10}, attempts: map[string]int{}}
+
+	res, err := ExecuteDispatchScheduledEmails(context.Background(), DispatchScheduledEmailsDeps{
+		EmailStore:  store,
+		EmailSender: sender,
+		Now:         testNow,
+		Sleep:       func(time.Duration) {},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Failed != 1 || sender.attempts["a@x.com"] != DefaultScheduledSendAttempts {
+		t.Errorf("result = %+v, attempts = %d", res, sender.attempts["a@x.com"])
+	}
+	if em := store.emails["due"]; em.Status != emailDomain.StatusFailed {
+		t.Errorf("status = %q, want failed", em.Status)
+	}
+}
+
+// TestDispatchScheduledEmails_SkipsUnsubscribed tests members opted out of an email's category are
+// skipped and counted, while account emails reach everyone without an unsubscribe link.
+func TestDispatchScheduledEmails_SkipsUnsubscribed(t *testing.T) {
+	store := newMockEmailStore()
+	seedScheduledEmail(store, "news", emailFixedTime, "a@x.com", "b@x.com")
+	seedScheduledEmail(store, "activation", emailFixedTime, "a@x.com")
+	activation := store.emails["activation"]
+	activation.Category = emailDomain.CategoryAccount
+	store.emails["activation"] = activation
+	optedOut := emailDomain.DefaultPreferences("member-1")
+	optedOut.Announcements = false
+
+	sender := newMockEmailSender()
+	res, err := ExecuteDispatchScheduledEmails(context.Background(), DispatchScheduledEmailsDeps{
+		EmailStore:      store,
+		EmailSender:     sender,
+		Now:             testNow,
+		PreferenceStore: &mockPreferenceStore{prefs: map[string]emailDomain.Preferences{"member-1": optedOut}},
+		UnsubscribeURL: func(memberID, category string) string {
+			return emailDomain.UnsubscribeURL("https://gym.test", []byte("k"), memberID, category)
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Sent != 2 || res.Unsubscribed != 1 {
+		t.Errorf("result = %+v, want 2 sent and 1 unsubscribed", res)
+	}
+	for _, req := range sender.sentReqs {
+		hasLink := strings.Contains(req.HTML, "/unsubscribe?token=")
+		if req.To[0] == "b@x.com" && !hasLink {
+			t.Errorf("expected an unsubscribe link in the announcement, got %q", req.HTML)
+		}
+		if req.To[0] == "a@x.com" && hasLink {
+			t.Errorf("expected no unsubscribe link in the activation email, got %q", req.HTML)
+		}
+	}
+	if r := store.recipients["news"][0]; r.DeliveryStatus != emailDomain.DeliveryUnsubscribed {
+		t.Errorf("a@x.com delivery = %q, want unsubscribed", r.DeliveryStatus)
+	}
+}
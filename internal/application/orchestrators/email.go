@@ -2,6 +2,7 @@ package orchestrators
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"log/slog"
 	"time"
@@ -17,6 +18,12 @@ type EmailStoreForOrchestrator interface {
 	SaveRecipients(ctx context.Context, emailID string, recipients []emailDomain.Recipient) error
 	GetRecipients(ctx context.Context, emailID string) ([]emailDomain.Recipient, error)
 	GetActiveTemplate(ctx context.Context) (emailDomain.EmailTemplate, error)
+	IsSuppressed(ctx context.Context, address string) (bool, error)
+}
+
+// EmailPreferenceStore defines the preference lookup used to honour unsubscribes at send time.
+type EmailPreferenceStore interface {
+	GetByMemberID(ctx context.Context, memberID string) (emailDomain.Preferences, error)
 }
 
 // MemberLookup defines the interface for looking up member details for recipient resolution.
@@ -31,6 +38,7 @@ type ComposeEmailInput struct {
 	EmailID   string // Empty for new, set for updating existing draft
 	Subject   string
 	Body      string
+	Category  string // Empty means announcements
 	SenderID  string
 	MemberIDs []string // Selected recipient member IDs
 }
@@ -50,6 +58,13 @@ func ExecuteComposeEmail(ctx context.Context, input ComposeEmailInput, deps Comp
 	if input.SenderID == "" {
 		return emailDomain.Email{}, errors.New("sender ID is required")
 	}
+	category := input.Category
+	if category == "" {
+		category = emailDomain.CategoryAnnouncements
+	}
+	if !emailDomain.IsCategory(category) {
+		return emailDomain.Email{}, emailDomain.ErrInvalidCategory
+	}
 
 	now := deps.Now()
 	var em emailDomain.Email
@@ -69,6 +84,7 @@ func ExecuteComposeEmail(ctx context.Context, input ComposeEmailInput, deps Comp
 		em = existing
 		em.Subject = input.Subject
 		em.Body = input.Body
+		em.Category = category
 		em.UpdatedAt = now
 	} else {
 		// New draft
@@ -76,6 +92,7 @@ func ExecuteComposeEmail(ctx context.Context, input ComposeEmailInput, deps Comp
 			ID:        deps.GenerateID(),
 			Subject:   input.Subject,
 			Body:      input.Body,
+			Category:  category,
 			SenderID:  input.SenderID,
 			Status:    emailDomain.StatusDraft,
 			CreatedAt: now,
@@ -98,7 +115,7 @@ func ExecuteComposeEmail(ctx context.Context, input ComposeEmailInput, deps Comp
 		}
 	}
 
-	slog.Info("email_event", "event", "email_draft_saved", "email_id", em.ID, "sender_id", em.SenderID, "recipient_count", len(input.MemberIDs))
+	slog.InfoContext(ctx, "email_event", "event", "email_draft_saved", "email_id", em.ID, "sender_id", em.SenderID, "recipient_count", len(input.MemberIDs))
 	return em, nil
 }
 
@@ -112,15 +129,18 @@ type SendEmailInput struct {
 
 // SendEmailDeps holds dependencies for SendEmail.
 type SendEmailDeps struct {
-	EmailStore   EmailStoreForOrchestrator
-	EmailSender  emailAdapter.Sender
-	MemberLookup MemberLookup
-	Now          func() time.Time
-	FromAddress  string // Default from address
-	ReplyTo      string // Reply-to address
+	EmailStore      EmailStoreForOrchestrator
+	EmailSender     emailAdapter.Sender
+	MemberLookup    MemberLookup
+	PreferenceStore EmailPreferenceStore                   // optional: nil sends to every recipient
+	UnsubscribeURL  func(memberID, category string) string // optional: nil omits unsubscribe links
+	Now             func() time.Time
+	FromAddress     string // Default from address
+	ReplyTo         string // Reply-to address
 }
 
 // ExecuteSendEmail sends a draft email to all its recipients via the email provider.
+// Recipients who opted out of the email's category are skipped and marked unsubscribed.
 // PRE: EmailID exists and is in draft status; has at least one recipient
 // POST: Email sent via provider, status updated to sent, in-app messages created
 func ExecuteSendEmail(ctx context.Context, input SendEmailInput, deps SendEmailDeps) (emailDomain.Email, error) {
@@ -151,34 +171,49 @@ func ExecuteSendEmail(ctx context.Context, input SendEmailInput, deps SendEmailD
 		return emailDomain.Email{}, emailDomain.ErrNoRecipients
 	}
 
-	// Collect email addresses for sending
+	// Collect deliverable addresses, skipping suppressed ones (hard bounces, complaints)
+	// and members who unsubscribed from this kind of email
+	category := em.CategoryFor()
 	var toAddresses []string
-	for _, r := range recipients {
-		if r.MemberEmail != "" {
-			toAddresses = append(toAddresses, r.MemberEmail)
+	var sendIdx []int // recipients[sendIdx[i]] receives toAddresses[i]
+	suppressed, unsubscribed := 0, 0
+	for i, r := range recipients {
+		if r.MemberEmail == "" {
+			continue
 		}
+		if blocked, err := deps.EmailStore.IsSuppressed(ctx, emailDomain.NormalizeAddress(r.MemberEmail)); err == nil && blocked {
+			recipients[i].DeliveryStatus = emailDomain.DeliverySuppressed
+			suppressed++
+			continue
+		}
+		if optedOut(ctx, deps.PreferenceStore, r.MemberID, category) {
+			recipients[i].DeliveryStatus = emailDomain.DeliveryUnsubscribed
+			unsubscribed++
+			continue
+		}
+		toAddresses = append(toAddresses, r.MemberEmail)
+		sendIdx = append(sendIdx, i)
 	}
 
 	if len(toAddresses) == 0 {
 		return emailDomain.Email{}, emailDomain.ErrNoRecipients
 	}
 
-	// Apply active template if one exists
-	htmlBody := em.Body
-	tpl, tplErr := deps.EmailStore.GetActiveTemplate(ctx)
-	if tplErr == nil {
-		htmlBody = tpl.WrapBody(em.Body)
+	// Apply active template if one exists; without one the body goes out unwrapped
+	var tpl emailDomain.EmailTemplate
+	if active, tplErr := deps.EmailStore.GetActiveTemplate(ctx); tplErr == nil {
+		tpl = active
 		em.TemplateVersionID = tpl.ID
 	}
 
-	// Send via provider — one email per recipient for individual delivery
+	// Send via provider — one email per recipient for individual delivery, each with its own unsubscribe link
 	var sendReqs []emailAdapter.SendRequest
-	for _, addr := range toAddresses {
+	for i, addr := range toAddresses {
 		sendReqs = append(sendReqs, emailAdapter.SendRequest{
 			To:      []string{addr},
 			From:    deps.FromAddress,
 			Subject: em.Subject,
-			HTML:    htmlBody,
+			HTML:    tpl.WrapBodyFor(recipientBody(em.Body, recipients[sendIdx[i]]), unsubscribeLink(deps.UnsubscribeURL, recipients[sendIdx[i]].MemberID, category)),
 			ReplyTo: deps.ReplyTo,
 		})
 	}
@@ -196,15 +231,65 @@ func ExecuteSendEmail(ctx context.Context, input SendEmailInput, deps SendEmailD
 		resendID = results[0].MessageID
 	}
 
-	em.MarkSent(deps.Now(), resendID)
+	now := deps.Now()
+	em.MarkSent(now, resendID)
 	if err := deps.EmailStore.Save(ctx, em); err != nil {
 		return emailDomain.Email{}, err
 	}
 
-	slog.Info("email_event", "event", "email_sent", "email_id", em.ID, "recipient_count", len(toAddresses), "resend_id", resendID)
+	// Record each copy's message ID so delivery webhooks can be matched to the recipient.
+	// Results come back in request order.
+	for i, idx := range sendIdx {
+		recipients[idx].DeliveryStatus = emailDomain.DeliverySent
+		recipients[idx].DeliveryUpdatedAt = now
+		if i < len(results) {
+			recipients[idx].ResendMessageID = results[i].MessageID
+		}
+	}
+	if err := deps.EmailStore.SaveRecipients(ctx, em.ID, recipients); err != nil {
+		slog.ErrorContext(ctx, "email_event", "event", "recipient_tracking_failed", "email_id", em.ID, "error", err)
+	}
+
+	if suppressed > 0 {
+		slog.InfoContext(ctx, "email_event", "event", "email_recipients_suppressed", "email_id", em.ID, "suppressed", suppressed)
+	}
+	if unsubscribed > 0 {
+		slog.InfoContext(ctx, "email_event", "event", "email_recipients_unsubscribed", "email_id", em.ID, "category", category, "unsubscribed", unsubscribed)
+	}
+	slog.InfoContext(ctx, "email_event", "event", "email_sent", "email_id", em.ID, "recipient_count", len(toAddresses), "resend_id", resendID)
 	return em, nil
 }
 
+// optedOut reports whether the member has unsubscribed from category.
+// Members without saved preferences get the defaults, which leave out only the opt-in
+// digest; lookups that fail receive the email.
+func optedOut(ctx context.Context, store EmailPreferenceStore, memberID, category string) bool {
+	if store == nil || memberID == "" {
+		return false
+	}
+	prefs, err := store.GetByMemberID(ctx, memberID)
+	if errors.Is(err, sql.ErrNoRows) {
+		prefs = emailDomain.DefaultPreferences(memberID)
+	} else if err != nil {
+		return false
+	}
+	return !prefs.Allows(category)
+}
+
+// recipientBody fills in the recipient's merge variables, so a composed email can greet each
+// member by name.
+func recipientBody(body string, r emailDomain.Recipient) string {
+	return emailDomain.RenderBody(body, emailDomain.Vars{emailDomain.VarMemberName: r.MemberName})
+}
+
+// unsubscribeLink returns the recipient's unsubscribe link, or "" when links are not configured.
+func unsubscribeLink(build func(memberID, category string) string, memberID, category string) string {
+	if build == nil || memberID == "" {
+		return ""
+	}
+	return build(memberID, category)
+}
+
 // --- Test Send Email ---
 
 // TestSendEmailInput carries input for sending a test email to a single address.
@@ -263,7 +348,7 @@ func ExecuteTestSendEmail(ctx context.Context, input TestSendEmailInput, deps Te
 		return err
 	}
 
-	slog.Info("email_event", "event", "test_email_sent", "email_id", em.ID, "test_address", input.TestAddress)
+	slog.InfoContext(ctx, "email_event", "event", "test_email_sent", "email_id", em.ID, "test_address", input.TestAddress)
 	return nil
 }
 
@@ -312,7 +397,7 @@ func ExecuteScheduleEmail(ctx context.Context, input ScheduleEmailInput, deps Sc
 		return emailDomain.Email{}, err
 	}
 
-	slog.Info("email_event", "event", "email_scheduled", "email_id", em.ID, "scheduled_at", input.ScheduledAt)
+	slog.InfoContext(ctx, "email_event", "event", "email_scheduled", "email_id", em.ID, "scheduled_at", input.ScheduledAt)
 	return em, nil
 }
 
@@ -351,7 +436,7 @@ func ExecuteCancelEmail(ctx context.Context, input CancelEmailInput, deps Cancel
 		return emailDomain.Email{}, err
 	}
 
-	slog.Info("email_event", "event", "email_cancelled", "email_id", em.ID)
+	slog.InfoContext(ctx, "email_event", "event", "email_cancelled", "email_id", em.ID)
 	return em, nil
 }
 
@@ -391,7 +476,7 @@ func ExecuteRescheduleEmail(ctx context.Context, input RescheduleEmailInput, dep
 		return emailDomain.Email{}, err
 	}
 
-	slog.Info("email_event", "event", "email_rescheduled", "email_id", em.ID, "scheduled_at", input.ScheduledAt)
+	slog.InfoContext(ctx, "email_event", "event", "email_rescheduled", "email_id", em.ID, "scheduled_at", input.ScheduledAt)
 	return em, nil
 }
 
@@ -401,7 +486,7 @@ func resolveRecipients(ctx context.Context, emailID string, memberIDs []string,
 	for _, mid := range memberIDs {
 		name, email, err := lookup.GetEmailByMemberID(ctx, mid)
 		if err != nil {
-			slog.Warn("email_recipient_lookup_failed", "member_id", mid, "error", err)
+			slog.WarnContext(ctx, "email_recipient_lookup_failed", "member_id", mid, "error", err)
 			continue
 		}
 		recipients = append(recipients, emailDomain.Recipient{
@@ -0,0 +1,92 @@
+package orchestrators
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	emailDomain "workshop/internal/domain/email"
+)
+
+// EmailDeliveryStore defines the store interface needed to apply provider delivery events.
+type EmailDeliveryStore interface {
+	GetRecipientByMessageID(ctx context.Context, messageID string) (emailDomain.Recipient, error)
+	UpdateRecipientDelivery(ctx context.Context, r emailDomain.Recipient) error
+	SaveSuppression(ctx context.Context, s emailDomain.Suppression) error
+}
+
+// ApplyEmailDeliveryInput carries one provider webhook event.
+type ApplyEmailDeliveryInput struct {
+	EventType  string    // e.g. "email.delivered"
+	MessageID  string    // provider message ID recorded at send time
+	OccurredAt time.Time // provider event time; zero means now
+	BounceType string    // "Permanent" or "Transient" for bounces
+	Detail     string    // provider bounce/complaint message
+}
+
+// ApplyEmailDeliveryDeps holds dependencies for ApplyEmailDelivery.
+type ApplyEmailDeliveryDeps struct {
+	EmailStore EmailDeliveryStore
+	Now        func() time.Time
+}
+
+// ApplyEmailDeliveryResult reports what the event changed.
+type ApplyEmailDeliveryResult struct {
+	Matched    bool   // a recipient with this message ID exists
+	Status     string // the recipient's delivery status after the event
+	Suppressed bool   // the address was added to the suppression list
+}
+
+// ExecuteApplyEmailDelivery updates a recipient's delivery status from a provider webhook.
+// Hard bounces and spam complaints add the address to the suppression list so later sends skip it.
+// Unknown event types and message IDs (e.g. test sends) are ignored without error.
+// PRE: none
+// POST: The matching recipient's status only moves forward; duplicate events are no-ops
+func ExecuteApplyEmailDelivery(ctx context.Context, input ApplyEmailDeliveryInput, deps ApplyEmailDeliveryDeps) (ApplyEmailDeliveryResult, error) {
+	status, ok := emailDomain.DeliveryStatusForEvent(input.EventType)
+	if !ok || input.MessageID == "" {
+		return ApplyEmailDeliveryResult{}, nil
+	}
+	rec, err := deps.EmailStore.GetRecipientByMessageID(ctx, input.MessageID)
+	if err != nil {
+		slog.InfoContext(ctx, "email_event", "event", "delivery_event_unmatched", "type", input.EventType, "message_id", input.MessageID)
+		return ApplyEmailDeliveryResult{}, nil
+	}
+
+	at := input.OccurredAt
+	if at.IsZero() {
+		at = deps.Now()
+	}
+	result := ApplyEmailDeliveryResult{Matched: true}
+	if rec.ApplyDeliveryEvent(status, at, input.Detail) {
+		if err := deps.EmailStore.UpdateRecipientDelivery(ctx, rec); err != nil {
+			return ApplyEmailDeliveryResult{}, err
+		}
+	}
+	result.Status = rec.DeliveryStatus
+
+	reason := ""
+	switch {
+	case status == emailDomain.DeliveryBounced && !strings.EqualFold(input.BounceType, "Transient"):
+		reason = emailDomain.SuppressionHardBounce
+	case status == emailDomain.DeliveryComplained:
+		reason = emailDomain.SuppressionComplaint
+	}
+	if reason != "" && rec.MemberEmail != "" {
+		if err := deps.EmailStore.SaveSuppression(ctx, emailDomain.Suppression{
+			Address:   emailDomain.NormalizeAddress(rec.MemberEmail),
+			Reason:    reason,
+			EmailID:   rec.EmailID,
+			Detail:    input.Detail,
+			CreatedAt: deps.Now(),
+		}); err != nil {
+			return ApplyEmailDeliveryResult{}, err
+		}
+		result.Suppressed = true
+	}
+
+	slog.InfoContext(ctx, "email_event", "event", "delivery_event_applied", "email_id", rec.EmailID, "member_id", rec.MemberID,
+		"type", input.EventType, "status", result.Status, "suppressed", result.Suppressed)
+	return result, nil
+}
@@ -3,6 +3,7 @@ package orchestrators
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,16 +15,18 @@ import (
 // --- Mock email store ---
 
 type mockEmailStore struct {
-	emails     map[string]emailDomain.Email
-	recipients map[string][]emailDomain.Recipient
-	templates  map[string]emailDomain.EmailTemplate
+	emails       map[string]emailDomain.Email
+	recipients   map[string][]emailDomain.Recipient
+	templates    map[string]emailDomain.EmailTemplate
+	suppressions map[string]emailDomain.Suppression
 }
 
 func newMockEmailStore() *mockEmailStore {
 	return &mockEmailStore{
-		emails:     make(map[string]emailDomain.Email),
-		recipients: make(map[string][]emailDomain.Recipient),
-		templates:  make(map[string]emailDomain.EmailTemplate),
+		emails:       make(map[string]emailDomain.Email),
+		recipients:   make(map[string][]emailDomain.Recipient),
+		templates:    make(map[string]emailDomain.EmailTemplate),
+		suppressions: make(map[string]emailDomain.Suppression),
 	}
 }
 
@@ -80,11 +83,20 @@ func (m *mockEmailStore) List(_ context.Context, _ emailStore.ListFilter) ([]ema
 	return result, nil
 }
 
-// ListByRecipientMemberID returns emails for a member (stub).
+// ListByRecipientMemberID returns the mock emails sent to a member.
 // PRE: memberID is non-empty
-// POST: Returns nil (stub)
+// POST: Returns emails with the member among their recipients
 func (m *mockEmailStore) ListByRecipientMemberID(_ context.Context, memberID string) ([]emailDomain.Email, error) {
-	return nil, nil
+	var out []emailDomain.Email
+	for id, recs := range m.recipients {
+		for _, r := range recs {
+			if r.MemberID == memberID {
+				out = append(out, m.emails[id])
+				break
+			}
+		}
+	}
+	return out, nil
 }
 
 // SaveTemplate persists a mock template.
@@ -118,6 +130,68 @@ func (m *mockEmailStore) GetTemplateByID(_ context.Context, id string) (emailDom
 	return t, nil
 }
 
+// GetRecipientByMessageID finds a mock recipient by provider message ID.
+// PRE: messageID is non-empty
+// POST: Returns the recipient or error
+func (m *mockEmailStore) GetRecipientByMessageID(_ context.Context, messageID string) (emailDomain.Recipient, error) {
+	for _, recs := range m.recipients {
+		for _, r := range recs {
+			if r.ResendMessageID == messageID {
+				return r, nil
+			}
+		}
+	}
+	return emailDomain.Recipient{}, errors.New("not found")
+}
+
+// UpdateRecipientDelivery replaces the matching mock recipient.
+// PRE: recipient exists
+// POST: Recipient delivery fields are updated
+func (m *mockEmailStore) UpdateRecipientDelivery(_ context.Context, r emailDomain.Recipient) error {
+	recs := m.recipients[r.EmailID]
+	for i := range recs {
+		if recs[i].MemberID == r.MemberID {
+			recs[i] = r
+		}
+	}
+	return nil
+}
+
+// SaveSuppression stores a mock suppression.
+// PRE: s.Address is non-empty
+// POST: Address is suppressed
+func (m *mockEmailStore) SaveSuppression(_ context.Context, s emailDomain.Suppression) error {
+	m.suppressions[s.Address] = s
+	return nil
+}
+
+// IsSuppressed reports whether a mock address is suppressed.
+// PRE: none
+// POST: Returns true if suppressed
+func (m *mockEmailStore) IsSuppressed(_ context.Context, address string) (bool, error) {
+	_, ok := m.suppressions[address]
+	return ok, nil
+}
+
+// ListSuppressions returns all mock suppressions.
+// PRE: none
+// POST: Returns suppressions
+func (m *mockEmailStore) ListSuppressions(_ context.Context) ([]emailDomain.Suppression, error) {
+	var out []emailDomain.Suppression
+	for _, s := range m.suppressions {
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// DeleteSuppression removes a mock suppression.
+// PRE: none
+// POST: Address is no longer suppressed
+func (m *mockEmailStore) DeleteSuppression(_ context.Context, address string) error {
+	delete(m.suppressions, address)
+	return nil
+}
+
 // --- Mock member lookup ---
 
 type mockMemberLookup struct {
@@ -455,6 +529,156 @@ func TestSendEmail_Success(t *testing.T) {
 	}
 }
 
+// TestSendEmail_MergesMemberName tests that each copy of a composed email greets its recipient.
+func TestSendEmail_MergesMemberName(t *testing.T) {
+	store := newMockEmailStore()
+	sender := newMockEmailSender()
+	store.emails["draft-1"] = emailDomain.Email{
+		ID: "draft-1", Subject: "Grading Day", Body: "<p>Hi {{MemberName}}, grading is on Saturday.</p>",
+		SenderID: "admin-1", Status: emailDomain.StatusDraft, CreatedAt: fixedTime,
+	}
+	store.recipients["draft-1"] = []emailDomain.Recipient{
+		{EmailID: "draft-1", MemberID: "member-1", MemberName: "Marcus", MemberEmail: "marcus@email.com"},
+		{EmailID: "draft-1", MemberID: "member-2", MemberName: "Yuki <Y>", MemberEmail: "yuki@email.com"},
+	}
+
+	if _, err := ExecuteSendEmail(context.Background(), SendEmailInput{EmailID: "draft-1"}, SendEmailDeps{
+		EmailStore: store, EmailSender: sender, Now: testNow,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(sender.sentReqs[0].HTML, "Hi Marcus,") || !strings.Contains(sender.sentReqs[1].HTML, "Hi Yuki &lt;Y&gt;,") {
+		t.Errorf("HTML = %q / %q, want each recipient's escaped name", sender.sentReqs[0].HTML, sender.sentReqs[1].HTML)
+	}
+}
+
+// TestSendEmail_SkipsSuppressedAndTracksRecipients tests that suppressed addresses are not sent to
+// and each delivered copy records its provider message ID.
+func TestSendEmail_SkipsSuppressedAndTracksRecipients(t *testing.T) {
+	store := newMockEmailStore()
+	sender := newMockEmailSender()
+	store.emails["draft-1"] = emailDomain.Email{
+		ID: "draft-1", Subject: "Grading Day", Body: "<p>Saturday</p>",
+		SenderID: "admin-1", Status: emailDomain.StatusDraft, CreatedAt: fixedTime,
+	}
+	store.recipients["draft-1"] = []emailDomain.Recipient{
+		{EmailID: "draft-1", MemberID: "member-1", MemberEmail: "Marcus@Email.com"},
+		{EmailID: "draft-1", MemberID: "member-2", MemberEmail: "yuki@email.com"},
+	}
+	store.suppressions["marcus@email.com"] = emailDomain.Suppression{Address: "marcus@email.com", Reason: emailDomain.SuppressionHardBounce}
+
+	_, err := ExecuteSendEmail(context.Background(), SendEmailInput{EmailID: "draft-1"}, SendEmailDeps{
+		EmailStore: store, EmailSender: sender, Now: testNow,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sender.sent != 1 || sender.sentReqs[0].To[0] != "yuki@email.com" {
+		t.Fatalf("expected only yuki to be sent to, got %d sends", sender.sent)
+	}
+	recs := store.recipients["draft-1"]
+	if recs[0].DeliveryStatus != emailDomain.DeliverySuppressed || recs[0].ResendMessageID != "" {
+		t.Errorf("recipient[0] = %+v, want suppressed", recs[0])
+	}
+	if recs[1].DeliveryStatus != emailDomain.DeliverySent || recs[1].ResendMessageID != "mock-batch-id" {
+		t.Errorf("recipient[1] = %+v, want sent with message ID", recs[1])
+	}
+}
+
+// TestSendEmail_SkipsUnsubscribedAndLinksUnsubscribe tests that members who opted out of the email's
+// category are skipped and everyone else gets their own unsubscribe link.
+func TestSendEmail_SkipsUnsubscribedAndLinksUnsubscribe(t *testing.T) {
+	store := newMockEmailStore()
+	sender := newMockEmailSender()
+	store.emails["draft-1"] = emailDomain.Email{
+		ID: "draft-1", Subject: "Grading Day", Body: "<p>Saturday</p>", Category: emailDomain.CategoryGrading,
+		SenderID: "admin-1", Status: emailDomain.StatusDraft, CreatedAt: fixedTime,
+	}
+	store.recipients["draft-1"] = []emailDomain.Recipient{
+		{EmailID: "draft-1", MemberID: "member-1", MemberEmail: "marcus@email.com"},
+		{EmailID: "draft-1", MemberID: "member-2", MemberEmail: "yuki@email.com"},
+		{EmailID: "draft-1", MemberID: "member-3", MemberEmail: "sam@email.com"},
+	}
+	noGrading := emailDomain.DefaultPreferences("member-1")
+	noGrading.Grading = false
+	noAnnouncements := emailDomain.DefaultPreferences("member-2")
+	noAnnouncements.Announcements = false
+	prefs := &mockPreferenceStore{prefs: map[string]emailDomain.Preferences{"member-1": noGrading, "member-2": noAnnouncements}}
+
+	_, err := ExecuteSendEmail(context.Background(), SendEmailInput{EmailID: "draft-1"}, SendEmailDeps{
+		EmailStore: store, EmailSender: sender, Now: testNow, PreferenceStore: prefs,
+		UnsubscribeURL: func(memberID, category string) string {
+			return "https://gym.test/unsubscribe?m=" + memberID + "&c=" + category
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sender.sent != 2 || sender.sentReqs[0].To[0] != "yuki@email.com" {
+		t.Fatalf("expected yuki and sam to be sent to, got %d sends", sender.sent)
+	}
+	if !strings.Contains(sender.sentReqs[1].HTML, "m=member-3&amp;c=grading") {
+		t.Errorf("expected sam's own unsubscribe link, got %q", sender.sentReqs[1].HTML)
+	}
+	recs := store.recipients["draft-1"]
+	if recs[0].DeliveryStatus != emailDomain.DeliveryUnsubscribed || recs[1].DeliveryStatus != emailDomain.DeliverySent {
+		t.Errorf("recipients = %+v, want marcus unsubscribed and yuki sent", recs)
+	}
+	if got := emailDomain.SummarizeDelivery(recs)[emailDomain.DeliveryUnsubscribed]; got != 1 {
+		t.Errorf("expected the delivery report to count 1 unsubscribed, got %d", got)
+	}
+}
+
+// TestApplyEmailDelivery_HardBounceSuppresses tests that a permanent bounce updates the
+// recipient and suppresses the address, while a transient bounce does not suppress.
+func TestApplyEmailDelivery_HardBounceSuppresses(t *testing.T) {
+	store := newMockEmailStore()
+	store.recipients["e1"] = []emailDomain.Recipient{
+		{EmailID: "e1", MemberID: "m1", MemberEmail: "Jo@Example.com", DeliveryStatus: emailDomain.DeliverySent, ResendMessageID: "msg-1"},
+		{EmailID: "e1", MemberID: "m2", MemberEmail: "sam@example.com", DeliveryStatus: emailDomain.DeliverySent, ResendMessageID: "msg-2"},
+	}
+	deps := ApplyEmailDeliveryDeps{EmailStore: store, Now: testNow}
+
+	res, err := ExecuteApplyEmailDelivery(context.Background(), ApplyEmailDeliveryInput{
+		EventType: "email.bounced", MessageID: "msg-1", BounceType: "Permanent", Detail: "No such user",
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Matched || !res.Suppressed || res.Status != emailDomain.DeliveryBounced {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	if got := store.recipients["e1"][0]; got.BounceReason != "No such user" || !got.DeliveryUpdatedAt.Equal(emailFixedTime) {
+		t.Errorf("recipient not updated: %+v", got)
+	}
+	if _, ok := store.suppressions["jo@example.com"]; !ok {
+		t.Error("expected jo@example.com to be suppressed")
+	}
+
+	res, _ = ExecuteApplyEmailDelivery(context.Background(), ApplyEmailDeliveryInput{
+		EventType: "email.bounced", MessageID: "msg-2", BounceType: "Transient",
+	}, deps)
+	if res.Suppressed {
+		t.Error("transient bounce should not suppress")
+	}
+}
+
+// TestApplyEmailDelivery_IgnoresUnknown tests that unmatched messages and other event types are no-ops.
+func TestApplyEmailDelivery_IgnoresUnknown(t *testing.T) {
+	store := newMockEmailStore()
+	deps := ApplyEmailDeliveryDeps{EmailStore: store, Now: testNow}
+
+	for _, in := range []ApplyEmailDeliveryInput{
+		{EventType: "email.delivered", MessageID: "test-send"},
+		{EventType: "contact.created", MessageID: "msg-1"},
+	} {
+		res, err := ExecuteApplyEmailDelivery(context.Background(), in, deps)
+		if err != nil || res.Matched {
+			t.Errorf("%s: expected ignored, got %+v, %v", in.EventType, res, err)
+		}
+	}
+}
+
 // TestSendEmail_NoRecipients tests that emails without recipients cannot be sent.
 func TestSendEmail_NoRecipients(t *testing.T) {
 	store := newMockEmailStore()
@@ -0,0 +1,144 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/audit"
+)
+
+// Anomaly fix actions
+const (
+	AnomalyFixMerge    = "merge"    // fold OtherID into AttendanceID
+	AnomalyFixDelete   = "delete"   // remove AttendanceID
+	AnomalyFixReassign = "reassign" // move AttendanceID to ScheduleID
+)
+
+// Anomaly fix errors.
+var (
+	ErrAnomalyFixAction     = errors.New("action must be merge, delete or reassign")
+	ErrAnomalyFixNotFound   = errors.New("attendance record not found")
+	ErrAnomalyFixNoOther    = errors.New("merge needs the check-in to merge in")
+	ErrAnomalyFixNoSchedule = errors.New("reassign needs an existing class")
+)
+
+// FixAnomalyAttendanceStore defines the attendance store interface needed to fix anomalies.
+type FixAnomalyAttendanceStore interface {
+	GetByID(ctx context.Context, id string) (attendance.Attendance, error)
+	Save(ctx context.Context, a attendance.Attendance) error
+	Delete(ctx context.Context, id string) error
+	ListByMemberIDAndDate(ctx context.Context, memberID string, date string) ([]attendance.Attendance, error)
+}
+
+// FixCheckInAnomalyInput carries one fix chosen on the anomalies report.
+type FixCheckInAnomalyInput struct {
+	Action       string
+	AttendanceID string // the check-in kept, deleted or reassigned
+	OtherID      string // merge: the check-in folded into AttendanceID
+	ScheduleID   string // reassign: the class the check-in was really for
+	Actor        BackfillActor
+}
+
+// FixCheckInAnomalyDeps holds dependencies for FixCheckInAnomaly.
+type FixCheckInAnomalyDeps struct {
+	AttendanceStore FixAnomalyAttendanceStore
+	ScheduleStore   ScheduleLookupStore
+	AuditStore      BackfillAuditStore
+	PackDeps        *UseClassPackDeps // optional: nil gives back no class pack
+}
+
+// ExecuteFixCheckInAnomaly merges, deletes or reassigns a check-in flagged on the anomalies
+// report. A reassigned check-in takes the new class's mat hours and location, and is
+// rejected if it would duplicate or overlap another of the member's check-ins. A deleted
+// check-in gives back the class pack credit it used; a merge leaves one credit on the kept
+// check-in.
+// PRE: input.Actor.AccountID is an admin
+// POST: The fix is applied and audited; returns the kept check-in (zero after a delete)
+func ExecuteFixCheckInAnomaly(ctx context.Context, input FixCheckInAnomalyInput, deps FixCheckInAnomalyDeps) (attendance.Attendance, error) {
+	if input.Action != AnomalyFixMerge && input.Action != AnomalyFixDelete && input.Action != AnomalyFixReassign {
+		return attendance.Attendance{}, ErrAnomalyFixAction
+	}
+	a, err := deps.AttendanceStore.GetByID(ctx, input.AttendanceID)
+	if err != nil {
+		return attendance.Attendance{}, ErrAnomalyFixNotFound
+	}
+
+	switch input.Action {
+	case AnomalyFixDelete:
+		if err := deps.AttendanceStore.Delete(ctx, a.ID); err != nil {
+			return attendance.Attendance{}, err
+		}
+		refundClassPack(ctx, a.ID, deps.PackDeps)
+		anomalyFixAudit(ctx, audit.ActionDelete, a, "Deleted check-in flagged as an anomaly", input, deps)
+		slog.InfoContext(ctx, "checkin_event", "event", "anomaly_deleted", "attendance_id", a.ID, "member_id", a.MemberID)
+		return attendance.Attendance{}, nil
+
+	case AnomalyFixMerge:
+		if input.OtherID == "" {
+			return attendance.Attendance{}, ErrAnomalyFixNoOther
+		}
+		other, err := deps.AttendanceStore.GetByID(ctx, input.OtherID)
+		if err != nil {
+			return attendance.Attendance{}, ErrAnomalyFixNotFound
+		}
+		merged, err := attendance.Merge(a, other)
+		if err != nil {
+			return attendance.Attendance{}, err
+		}
+		if err := deps.AttendanceStore.Save(ctx, merged); err != nil {
+			return attendance.Attendance{}, err
+		}
+		if err := deps.AttendanceStore.Delete(ctx, other.ID); err != nil {
+			return attendance.Attendance{}, err
+		}
+		mergeClassPackUse(ctx, merged.ID, other.ID, deps.PackDeps)
+		anomalyFixAudit(ctx, audit.ActionUpdate, merged, "Merged duplicate check-in "+other.ID, input, deps)
+		anomalyFixAudit(ctx, audit.ActionDelete, other, "Merged into check-in "+merged.ID, input, deps)
+		slog.InfoContext(ctx, "checkin_event", "event", "anomaly_merged", "attendance_id", merged.ID, "merged_id", other.ID, "member_id", a.MemberID)
+		return merged, nil
+	}
+
+	sched, err := deps.ScheduleStore.GetByID(ctx, input.ScheduleID)
+	if err != nil {
+		return attendance.Attendance{}, ErrAnomalyFixNoSchedule
+	}
+	from := a.ScheduleID
+	a.ScheduleID = sched.ID
+	a.MatHours = 0
+	if dur, err := sched.DurationHours(); err == nil {
+		a.MatHours = dur
+	}
+	if sched.LocationID != "" {
+		a.LocationID = sched.LocationID
+	}
+	existing, err := deps.AttendanceStore.ListByMemberIDAndDate(ctx, a.MemberID, a.CheckInTime.Format("2006-01-02"))
+	if err != nil {
+		return attendance.Attendance{}, err
+	}
+	if _, err := attendance.Conflict(existing, a, scheduleSlots(ctx, deps.ScheduleStore, append(existing, a))); err != nil {
+		return attendance.Attendance{}, err
+	}
+	if err := deps.AttendanceStore.Save(ctx, a); err != nil {
+		return attendance.Attendance{}, err
+	}
+	anomalyFixAudit(ctx, audit.ActionUpdate, a, "Reassigned check-in from class "+from, input, deps)
+	slog.InfoContext(ctx, "checkin_event", "event", "anomaly_reassigned", "attendance_id", a.ID, "member_id", a.MemberID, "from", from, "to", a.ScheduleID)
+	return a, nil
+}
+
+// anomalyFixAudit records a fix in the audit log. A failure is logged, not returned: the
+// fix has already been saved.
+func anomalyFixAudit(ctx context.Context, action audit.Action, a attendance.Attendance, description string, input FixCheckInAnomalyInput, deps FixCheckInAnomalyDeps) {
+	metadata, _ := json.Marshal(map[string]string{"member_id": a.MemberID, "schedule_id": a.ScheduleID, "class_date": a.Date(), "source": "anomaly_" + input.Action})
+	event := audit.NewEvent(input.Actor.AccountID, input.Actor.Email, input.Actor.Role, audit.CategoryAttendance, action).
+		WithResource("attendance", a.ID).
+		WithDescription(description).
+		WithRequest(input.Actor.IPAddress, input.Actor.UserAgent).
+		WithMetadata(string(metadata))
+	if err := deps.AuditStore.Save(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "checkin_event", "event", "anomaly_audit_failed", "attendance_id", a.ID, "error", err)
+	}
+}
@@ -0,0 +1,205 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/classpack"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/schedule"
+)
+
+type mockAnomalyAttendanceStore struct {
+	mockRollCallAttendanceStore
+}
+
+// GetByID implements FixAnomalyAttendanceStore.
+// PRE: id is non-empty
+// POST: returns the record or an error
+func (m *mockAnomalyAttendanceStore) GetByID(_ context.Context, id string) (attendance.Attendance, error) {
+	for _, a := range m.records {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return attendance.Attendance{}, errors.New("not found")
+}
+
+// Save implements FixAnomalyAttendanceStore, replacing a record with the same ID.
+// PRE: a is valid
+// POST: a is stored
+func (m *mockAnomalyAttendanceStore) Save(_ context.Context, a attendance.Attendance) error {
+	for i, r := range m.records {
+		if r.ID == a.ID {
+			m.records[i] = a
+			return nil
+		}
+	}
+	m.records = append(m.records, a)
+	return nil
+}
+
+type mockAnomalyScheduleStore struct {
+	schedules map[string]schedule.Schedule
+}
+
+// GetByID implements ScheduleLookupStore.
+// PRE: id is non-empty
+// POST: returns the schedule or an error
+func (m *mockAnomalyScheduleStore) GetByID(_ context.Context, id string) (schedule.Schedule, error) {
+	s, ok := m.schedules[id]
+	if !ok {
+		return schedule.Schedule{}, errors.New("not found")
+	}
+	return s, nil
+}
+
+func newAnomalyScheduleStore() *mockAnomalyScheduleStore {
+	return &mockAnomalyScheduleStore{schedules: map[string]schedule.Schedule{
+		"fundamentals": {ID: "fundamentals", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:00"},
+		"advanced":     {ID: "advanced", Day: schedule.Monday, StartTime: "18:30", EndTime: "20:00", LocationID: "central"},
+		"open-mat":     {ID: "open-mat", Day: schedule.Monday, StartTime: "19:00", EndTime: "20:00"},
+	}}
+}
+
+// TestExecuteCheckInMember_RejectsDuplicatesAndOverlaps verifies a second check-in into the
+// same class, or one at the same time, is rejected while the next class is allowed.
+func TestExecuteCheckInMember_RejectsDuplicatesAndOverlaps(t *testing.T) {
+	store := &mockBulkSyncAttendanceStore{}
+	deps := CheckInMemberDeps{
+		MemberStore:     &mockBulkSyncMemberStore{members: map[string]member.Member{"m1": {ID: "m1", Name: "Alice", Status: member.StatusActive}}},
+		AttendanceStore: store,
+		ScheduleStore:   newAnomalyScheduleStore(),
+	}
+	ctx := context.Background()
+
+	if err := ExecuteCheckInMember(ctx, CheckInMemberInput{MemberID: "m1", ScheduleID: "fundamentals"}, deps); err != nil {
+		t.Fatalf("first check-in: %v", err)
+	}
+	if err := ExecuteCheckInMember(ctx, CheckInMemberInput{MemberID: "m1", ScheduleID: "fundamentals"}, deps); !errors.Is(err, attendance.ErrDuplicateCheckIn) {
+		t.Errorf("same class: err = %v, want ErrDuplicateCheckIn", err)
+	}
+	if err := ExecuteCheckInMember(ctx, CheckInMemberInput{MemberID: "m1", ScheduleID: "advanced"}, deps); !errors.Is(err, attendance.ErrOverlappingCheckIn) {
+		t.Errorf("overlapping class: err = %v, want ErrOverlappingCheckIn", err)
+	}
+	if err := ExecuteCheckInMember(ctx, CheckInMemberInput{MemberID: "m1", ScheduleID: "open-mat"}, deps); err != nil {
+		t.Errorf("next class: %v", err)
+	}
+	if len(store.records) != 2 {
+		t.Errorf("records = %d, want 2", len(store.records))
+	}
+}
+
+// TestExecuteFixCheckInAnomaly verifies merge, reassign and delete, each audited.
+func TestExecuteFixCheckInAnomaly(t *testing.T) {
+	at := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	store := &mockAnomalyAttendanceStore{mockRollCallAttendanceStore{mockBulkSyncAttendanceStore{records: []attendance.Attendance{
+		{ID: "a1", MemberID: "m1", ScheduleID: "fundamentals", CheckInTime: at.Add(5 * time.Minute), MatHours: 1},
+		{ID: "a2", MemberID: "m1", ScheduleID: "fundamentals", CheckInTime: at, CheckOutTime: at.Add(time.Hour), MatHours: 1},
+		{ID: "b1", MemberID: "m2", ScheduleID: "fundamentals", CheckInTime: at, MatHours: 1},
+		{ID: "b2", MemberID: "m2", ScheduleID: "advanced", CheckInTime: at.Add(30 * time.Minute), MatHours: 1.5},
+	}}}}
+	auditStore := &mockBackfillAuditStore{}
+	deps := FixCheckInAnomalyDeps{AttendanceStore: store, ScheduleStore: newAnomalyScheduleStore(), AuditStore: auditStore}
+	actor := BackfillActor{AccountID: "admin-1", Email: "admin@example.com", Role: "admin"}
+	ctx := context.Background()
+
+	merged, err := ExecuteFixCheckInAnomaly(ctx, FixCheckInAnomalyInput{Action: AnomalyFixMerge, AttendanceID: "a1", OtherID: "a2", Actor: actor}, deps)
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if !merged.CheckInTime.Equal(at) || !merged.CheckOutTime.Equal(at.Add(time.Hour)) {
+		t.Errorf("merged = %+v, want the earliest check-in and latest check-out", merged)
+	}
+	if _, err := store.GetByID(ctx, "a2"); err == nil {
+		t.Error("expected the merged check-in to be removed")
+	}
+
+	if _, err := ExecuteFixCheckInAnomaly(ctx, FixCheckInAnomalyInput{Action: AnomalyFixReassign, AttendanceID: "b1", ScheduleID: "advanced", Actor: actor}, deps); !errors.Is(err, attendance.ErrDuplicateCheckIn) {
+		t.Errorf("reassign onto a duplicate: err = %v, want ErrDuplicateCheckIn", err)
+	}
+	moved, err := ExecuteFixCheckInAnomaly(ctx, FixCheckInAnomalyInput{Action: AnomalyFixReassign, AttendanceID: "b2", ScheduleID: "open-mat", Actor: actor}, deps)
+	if err != nil {
+		t.Fatalf("reassign: %v", err)
+	}
+	if moved.ScheduleID != "open-mat" || moved.MatHours != 1 {
+		t.Errorf("moved = %+v, want open mat with one mat hour", moved)
+	}
+
+	if _, err := ExecuteFixCheckInAnomaly(ctx, FixCheckInAnomalyInput{Action: AnomalyFixDelete, AttendanceID: "b1", Actor: actor}, deps); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if len(store.records) != 2 {
+		t.Errorf("records = %d, want 2", len(store.records))
+	}
+	if len(auditStore.events) != 4 {
+		t.Errorf("audit events = %d, want 4", len(auditStore.events))
+	}
+
+	if _, err := ExecuteFixCheckInAnomaly(ctx, FixCheckInAnomalyInput{Action: "split", AttendanceID: "a1"}, deps); !errors.Is(err, ErrAnomalyFixAction) {
+		t.Errorf("unknown action: err = %v, want ErrAnomalyFixAction", err)
+	}
+}
+
+// TestExecuteFixCheckInAnomaly_ClassPack verifies a deleted check-in gives back its pack
+// credit, and a merge leaves exactly one credit used for the class attended.
+func TestExecuteFixCheckInAnomaly_ClassPack(t *testing.T) {
+	at := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	store := &mockAnomalyAttendanceStore{mockRollCallAttendanceStore{mockBulkSyncAttendanceStore{records: []attendance.Attendance{
+		{ID: "a1", MemberID: "m1", ScheduleID: "fundamentals", CheckInTime: at.Add(5 * time.Minute), MatHours: 1},
+		{ID: "a2", MemberID: "m1", ScheduleID: "fundamentals", CheckInTime: at, MatHours: 1},
+		{ID: "a3", MemberID: "m1", ScheduleID: "advanced", CheckInTime: at.Add(2 * time.Hour), MatHours: 1.5},
+		{ID: "b1", MemberID: "m2", ScheduleID: "fundamentals", CheckInTime: at, MatHours: 1},
+		{ID: "b2", MemberID: "m2", ScheduleID: "fundamentals", CheckInTime: at.Add(time.Minute), MatHours: 1},
+	}}}}
+	packs := newMockClassPackStore(
+		classpack.ClassPack{ID: "p1", MemberID: "m1", Classes: 10, Used: 3, PurchasedAt: at},
+		classpack.ClassPack{ID: "p2", MemberID: "m2", Classes: 10, Used: 1, PurchasedAt: at},
+	)
+	for _, u := range []classpack.Use{
+		{AttendanceID: "a1", PackID: "p1", MemberID: "m1"},
+		{AttendanceID: "a2", PackID: "p1", MemberID: "m1"},
+		{AttendanceID: "a3", PackID: "p1", MemberID: "m1"},
+		{AttendanceID: "b2", PackID: "p2", MemberID: "m2"},
+	} {
+		packs.uses[u.AttendanceID] = u
+	}
+	deps := FixCheckInAnomalyDeps{AttendanceStore: store, ScheduleStore: newAnomalyScheduleStore(), AuditStore: &mockBackfillAuditStore{}, PackDeps: &UseClassPackDeps{PackStore: packs}}
+	actor := BackfillActor{AccountID: "admin-1", Role: "admin"}
+	ctx := context.Background()
+
+	if _, err := ExecuteFixCheckInAnomaly(ctx, FixCheckInAnomalyInput{Action: AnomalyFixDelete, AttendanceID: "a3", Actor: actor}, deps); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if got := packs.packs["p1"].Remaining(); got != 8 {
+		t.Errorf("after delete remaining = %d, want 8", got)
+	}
+
+	// Both check-ins used a credit: the duplicate's is given back.
+	if _, err := ExecuteFixCheckInAnomaly(ctx, FixCheckInAnomalyInput{Action: AnomalyFixMerge, AttendanceID: "a1", OtherID: "a2", Actor: actor}, deps); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if got := packs.packs["p1"].Remaining(); got != 9 {
+		t.Errorf("after merge remaining = %d, want 9", got)
+	}
+	if _, ok := packs.uses["a1"]; !ok || len(packs.uses) != 2 {
+		t.Errorf("uses = %v, want the kept check-in a1 still on the pack", packs.uses)
+	}
+
+	// Only the merged-in check-in used a credit: it moves to the kept one.
+	if _, err := ExecuteFixCheckInAnomaly(ctx, FixCheckInAnomalyInput{Action: AnomalyFixMerge, AttendanceID: "b1", OtherID: "b2", Actor: actor}, deps); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if got := packs.packs["p2"].Remaining(); got != 9 {
+		t.Errorf("after merge remaining = %d, want 9", got)
+	}
+	if use, ok := packs.uses["b1"]; !ok || use.PackID != "p2" {
+		t.Errorf("b1 use = %+v, want the credit moved from b2", use)
+	}
+	if _, ok := packs.uses["b2"]; ok {
+		t.Error("the merged-in check-in still holds a pack use")
+	}
+}
@@ -0,0 +1,313 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"workshop/internal/domain/calendar"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/notice"
+)
+
+// Ceremony lookup errors.
+var (
+	ErrCeremonyNotFound         = errors.New("ceremony not found")
+	ErrCeremonyProposalNotFound = errors.New("proposal not found")
+)
+
+// GradingCeremonyStore defines the ceremony store interface needed by the ceremony orchestrators.
+type GradingCeremonyStore interface {
+	GetByID(ctx context.Context, id string) (grading.Ceremony, error)
+	Save(ctx context.Context, value grading.Ceremony) error
+}
+
+// GradingCeremonyProposalStore defines the proposal store interface needed by the ceremony orchestrators.
+type GradingCeremonyProposalStore interface {
+	GetByID(ctx context.Context, id string) (grading.Proposal, error)
+	Save(ctx context.Context, value grading.Proposal) error
+	ListByEventID(ctx context.Context, eventID string) ([]grading.Proposal, error)
+}
+
+// GradingCeremonyCalendarStore defines the calendar store interface needed to create a ceremony's event.
+type GradingCeremonyCalendarStore interface {
+	Save(ctx context.Context, e calendar.Event) error
+}
+
+// GradingCeremonyRecordStore defines the grading record store interface needed to record promotions.
+type GradingCeremonyRecordStore interface {
+	Save(ctx context.Context, value grading.Record) error
+}
+
+// GradingCeremonyDeps holds dependencies for the ceremony orchestrators.
+type GradingCeremonyDeps struct {
+	CeremonyStore GradingCeremonyStore
+	ProposalStore GradingCeremonyProposalStore
+	CalendarStore GradingCeremonyCalendarStore
+	NoticeStore   NoticeStoreForOrchestrator
+	RecordStore   GradingCeremonyRecordStore
+	// Invite tells a member their promotion has been booked onto the ceremony.
+	Invite func(ctx context.Context, c grading.Ceremony, p grading.Proposal)
+	// Award follows up a promotion recorded at the ceremony: stock, notification and email.
+	Award      func(ctx context.Context, c grading.Ceremony, r grading.Record)
+	GenerateID func() string
+	Now        func() time.Time
+}
+
+// PlanGradingCeremonyInput carries input for planning a promotion ceremony.
+type PlanGradingCeremonyInput struct {
+	Title       string
+	Location    string
+	Date        time.Time // the day of the ceremony
+	ProposalIDs []string  // open proposals to be awarded on the day
+	CreatedBy   string    // AccountID of the admin
+}
+
+// ExecutePlanGradingCeremony plans a promotion ceremony: it creates the ceremony's calendar
+// event, publishes a grading notice that only the invited members see, books each proposal
+// onto the event and invites its member. Every proposal is checked before anything is saved.
+// PRE: input.CreatedBy is an admin
+// POST: Returns the planned ceremony with its EventID and NoticeID set; each proposal is scheduled onto the event
+func ExecutePlanGradingCeremony(ctx context.Context, input PlanGradingCeremonyInput, deps GradingCeremonyDeps) (grading.Ceremony, error) {
+	if input.CreatedBy == "" {
+		return grading.Ceremony{}, errors.New("creator account ID is required")
+	}
+	now := deps.Now()
+	c := grading.Ceremony{
+		ID:        deps.GenerateID(),
+		Title:     input.Title,
+		Location:  input.Location,
+		Date:      input.Date,
+		Status:    grading.CeremonyPlanned,
+		CreatedBy: input.CreatedBy,
+		CreatedAt: now,
+	}
+	if err := c.Validate(); err != nil {
+		return grading.Ceremony{}, err
+	}
+
+	var proposals []grading.Proposal
+	seen := map[string]bool{}
+	for _, id := range input.ProposalIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		p, err := attachableProposal(ctx, id, deps)
+		if err != nil {
+			return grading.Ceremony{}, err
+		}
+		proposals = append(proposals, p)
+	}
+
+	event := calendar.Event{
+		ID:          deps.GenerateID(),
+		Title:       c.Title,
+		Type:        calendar.TypeEvent,
+		Description: "Promotion ceremony: belts are presented to the members invited.",
+		Location:    c.Location,
+		StartDate:   c.Date,
+		CreatedBy:   c.CreatedBy,
+		CreatedAt:   now,
+	}
+	if err := event.Validate(); err != nil {
+		return grading.Ceremony{}, err
+	}
+	if err := deps.CalendarStore.Save(ctx, event); err != nil {
+		return grading.Ceremony{}, err
+	}
+	c.EventID = event.ID
+
+	n := notice.Notice{
+		ID:           deps.GenerateID(),
+		Type:         notice.TypeGrading,
+		Status:       notice.StatusPublished,
+		Title:        c.Title,
+		Content:      ceremonyNoticeContent(c),
+		CreatedBy:    c.CreatedBy,
+		PublishedBy:  c.CreatedBy,
+		TargetID:     event.ID,
+		Color:        notice.ColorPurple,
+		VisibleUntil: c.Date.AddDate(0, 0, 1),
+		CreatedAt:    now,
+		PublishedAt:  now,
+	}
+	if err := n.Validate(); err != nil {
+		return grading.Ceremony{}, err
+	}
+	if err := deps.NoticeStore.Save(ctx, n); err != nil {
+		return grading.Ceremony{}, err
+	}
+	c.NoticeID = n.ID
+
+	if err := deps.CeremonyStore.Save(ctx, c); err != nil {
+		return grading.Ceremony{}, err
+	}
+	for _, p := range proposals {
+		if _, err := bookOntoCeremony(ctx, c, p, deps); err != nil {
+			return c, err
+		}
+	}
+
+	slog.InfoContext(ctx, "grading_event", "event", "ceremony_planned", "ceremony_id", c.ID, "event_id", c.EventID, "date", c.Date.Format("2006-01-02"), "invited", len(proposals))
+	return c, nil
+}
+
+// AttachCeremonyProposalInput names a proposal to add to a planned ceremony.
+type AttachCeremonyProposalInput struct {
+	CeremonyID string
+	ProposalID string
+}
+
+// ExecuteAttachCeremonyProposal books one more open proposal onto a planned ceremony and
+// invites its member. A proposal already on the ceremony is returned unchanged.
+// PRE: CeremonyID and ProposalID are non-empty
+// POST: Returns the proposal, scheduled onto the ceremony's calendar event
+func ExecuteAttachCeremonyProposal(ctx context.Context, input AttachCeremonyProposalInput, deps GradingCeremonyDeps) (grading.Proposal, error) {
+	c, err := plannedCeremony(ctx, input.CeremonyID, deps)
+	if err != nil {
+		return grading.Proposal{}, err
+	}
+	p, err := attachableProposal(ctx, input.ProposalID, deps)
+	if err != nil {
+		return grading.Proposal{}, err
+	}
+	if p.EventID == c.EventID {
+		return p, nil
+	}
+	p, err = bookOntoCeremony(ctx, c, p, deps)
+	if err != nil {
+		return grading.Proposal{}, err
+	}
+	slog.InfoContext(ctx, "grading_event", "event", "ceremony_proposal_attached", "ceremony_id", c.ID, "proposal_id", p.ID)
+	return p, nil
+}
+
+// CompleteGradingCeremonyInput names the ceremony that has been held.
+type CompleteGradingCeremonyInput struct {
+	CeremonyID string
+	AdminID    string
+}
+
+// CompleteGradingCeremonyResult carries the completed ceremony and the promotions recorded.
+type CompleteGradingCeremonyResult struct {
+	Ceremony grading.Ceremony
+	Records  []grading.Record
+}
+
+// ExecuteCompleteGradingCeremony records the ceremony's promotions in one action: every
+// proposal still open on it is approved, and each approved proposal gets a grading record
+// dated the ceremony day. Rejected proposals are left as they are. Each record takes its
+// proposal's ID, so retrying after a failure overwrites rather than duplicates; Award runs
+// only once the ceremony is marked completed.
+// PRE: input.AdminID is an admin
+// POST: The ceremony is completed and Records holds one record per approved proposal
+func ExecuteCompleteGradingCeremony(ctx context.Context, input CompleteGradingCeremonyInput, deps GradingCeremonyDeps) (CompleteGradingCeremonyResult, error) {
+	c, err := plannedCeremony(ctx, input.CeremonyID, deps)
+	if err != nil {
+		return CompleteGradingCeremonyResult{}, err
+	}
+	if input.AdminID == "" {
+		return CompleteGradingCeremonyResult{}, errors.New("admin ID is required to complete a ceremony")
+	}
+	proposals, err := deps.ProposalStore.ListByEventID(ctx, c.EventID)
+	if err != nil {
+		return CompleteGradingCeremonyResult{}, err
+	}
+
+	result := CompleteGradingCeremonyResult{Records: []grading.Record{}}
+	for _, p := range proposals {
+		if p.IsOpen() {
+			if err := p.Approve(input.AdminID); err != nil {
+				return result, err
+			}
+			if err := deps.ProposalStore.Save(ctx, p); err != nil {
+				return result, err
+			}
+		}
+		if p.Status != grading.ProposalApproved {
+			continue
+		}
+		record := grading.Record{
+			ID:         p.ID,
+			MemberID:   p.MemberID,
+			Belt:       p.TargetBelt,
+			PromotedAt: c.Date,
+			ProposedBy: p.ProposedBy,
+			ApprovedBy: p.ApprovedBy,
+			Method:     grading.MethodStandard,
+		}
+		if err := deps.RecordStore.Save(ctx, record); err != nil {
+			return result, err
+		}
+		result.Records = append(result.Records, record)
+	}
+
+	if err := c.Complete(input.AdminID, deps.Now()); err != nil {
+		return result, err
+	}
+	if err := deps.CeremonyStore.Save(ctx, c); err != nil {
+		return result, err
+	}
+	result.Ceremony = c
+	for _, r := range result.Records {
+		if deps.Award != nil {
+			deps.Award(ctx, c, r)
+		}
+	}
+
+	slog.InfoContext(ctx, "grading_event", "event", "ceremony_completed", "ceremony_id", c.ID, "completed_by", input.AdminID, "promotions", len(result.Records))
+	return result, nil
+}
+
+// plannedCeremony loads a ceremony that has not been completed yet.
+func plannedCeremony(ctx context.Context, id string, deps GradingCeremonyDeps) (grading.Ceremony, error) {
+	c, err := deps.CeremonyStore.GetByID(ctx, id)
+	if err != nil {
+		return grading.Ceremony{}, ErrCeremonyNotFound
+	}
+	if !c.IsPlanned() {
+		return c, grading.ErrCeremonyCompleted
+	}
+	return c, nil
+}
+
+// attachableProposal loads a proposal that may still be booked onto a ceremony.
+func attachableProposal(ctx context.Context, id string, deps GradingCeremonyDeps) (grading.Proposal, error) {
+	if id == "" {
+		return grading.Proposal{}, grading.ErrEmptyProposalID
+	}
+	p, err := deps.ProposalStore.GetByID(ctx, id)
+	if err != nil {
+		return grading.Proposal{}, ErrCeremonyProposalNotFound
+	}
+	if !p.IsOpen() {
+		return p, grading.ErrNotAttachable
+	}
+	return p, nil
+}
+
+// bookOntoCeremony schedules a proposal onto the ceremony's calendar event and invites its member.
+func bookOntoCeremony(ctx context.Context, c grading.Ceremony, p grading.Proposal, deps GradingCeremonyDeps) (grading.Proposal, error) {
+	if err := p.Schedule(c.EventID); err != nil {
+		return p, err
+	}
+	if err := deps.ProposalStore.Save(ctx, p); err != nil {
+		return p, err
+	}
+	if deps.Invite != nil {
+		deps.Invite(ctx, c, p)
+	}
+	return p, nil
+}
+
+// ceremonyNoticeContent is the Markdown of the notice invited members see.
+func ceremonyNoticeContent(c grading.Ceremony) string {
+	when := c.Date.Format("Monday 2 January")
+	if c.Location != "" {
+		when += " at " + c.Location
+	}
+	return fmt.Sprintf("You're invited to **%s** on %s. Your coaches will present your new belt on the day; family and friends are welcome.", c.Title, when)
+}
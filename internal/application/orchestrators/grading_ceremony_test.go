@@ -0,0 +1,252 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/calendar"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/notice"
+)
+
+type mockCeremonyStore struct {
+	ceremonies map[string]grading.Ceremony
+}
+
+// GetByID implements GradingCeremonyStore.
+// PRE: none
+// POST: Returns the ceremony or sql.ErrNoRows
+func (m *mockCeremonyStore) GetByID(_ context.Context, id string) (grading.Ceremony, error) {
+	c, ok := m.ceremonies[id]
+	if !ok {
+		return grading.Ceremony{}, sql.ErrNoRows
+	}
+	return c, nil
+}
+
+// Save implements GradingCeremonyStore.
+// PRE: none
+// POST: The ceremony is upserted
+func (m *mockCeremonyStore) Save(_ context.Context, value grading.Ceremony) error {
+	m.ceremonies[value.ID] = value
+	return nil
+}
+
+type mockCeremonyProposalStore struct {
+	proposals map[string]grading.Proposal
+}
+
+// GetByID implements GradingCeremonyProposalStore.
+// PRE: none
+// POST: Returns the proposal or sql.ErrNoRows
+func (m *mockCeremonyProposalStore) GetByID(_ context.Context, id string) (grading.Proposal, error) {
+	p, ok := m.proposals[id]
+	if !ok {
+		return grading.Proposal{}, sql.ErrNoRows
+	}
+	return p, nil
+}
+
+// Save implements GradingCeremonyProposalStore.
+// PRE: none
+// POST: The proposal is upserted
+func (m *mockCeremonyProposalStore) Save(_ context.Context, value grading.Proposal) error {
+	m.proposals[value.ID] = value
+	return nil
+}
+
+// ListByEventID implements GradingCeremonyProposalStore.
+// PRE: none
+// POST: Returns the proposals on the event, by ID
+func (m *mockCeremonyProposalStore) ListByEventID(_ context.Context, eventID string) ([]grading.Proposal, error) {
+	var out []grading.Proposal
+	for _, id := range []string{"p1", "p2", "p3", "p4"} {
+		if p, ok := m.proposals[id]; ok && p.EventID == eventID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+type mockCeremonyCalendarStore struct {
+	events []calendar.Event
+}
+
+// Save implements GradingCeremonyCalendarStore.
+// PRE: none
+// POST: The event is recorded
+func (m *mockCeremonyCalendarStore) Save(_ context.Context, e calendar.Event) error {
+	m.events = append(m.events, e)
+	return nil
+}
+
+type mockCeremonyRecordStore struct {
+	records map[string]grading.Record
+	fail    bool
+}
+
+// Save implements GradingCeremonyRecordStore.
+// PRE: none
+// POST: The record is upserted, or an error when fail is set
+func (m *mockCeremonyRecordStore) Save(_ context.Context, value grading.Record) error {
+	if m.fail {
+		return errors.New("disk full")
+	}
+	m.records[value.ID] = value
+	return nil
+}
+
+type ceremonyFixture struct {
+	deps      GradingCeremonyDeps
+	proposals *mockCeremonyProposalStore
+	calendar  *mockCeremonyCalendarStore
+	notices   *mockNoticeStoreForOrch
+	records   *mockCeremonyRecordStore
+	invited   []string
+	awarded   []string
+}
+
+func newCeremonyFixture() *ceremonyFixture {
+	f := &ceremonyFixture{
+		proposals: &mockCeremonyProposalStore{proposals: map[string]grading.Proposal{
+			"p1": {ID: "p1", MemberID: "m1", TargetBelt: grading.BeltBlue, ProposedBy: "coach-1", Status: grading.ProposalPending},
+			"p2": {ID: "p2", MemberID: "m2", TargetBelt: grading.BeltGrey, ProposedBy: "coach-1", Status: grading.ProposalScheduled, EventID: "ev-old"},
+			"p3": {ID: "p3", MemberID: "m3", TargetBelt: grading.BeltPurple, ProposedBy: "coach-1", Status: grading.ProposalPending},
+			"p4": {ID: "p4", MemberID: "m4", TargetBelt: grading.BeltBlue, ProposedBy: "coach-1", Status: grading.ProposalApproved},
+		}},
+		calendar: &mockCeremonyCalendarStore{},
+		notices:  newMockNoticeStore(),
+		records:  &mockCeremonyRecordStore{records: map[string]grading.Record{}},
+	}
+	n := 0
+	f.deps = GradingCeremonyDeps{
+		CeremonyStore: &mockCeremonyStore{ceremonies: map[string]grading.Ceremony{}},
+		ProposalStore: f.proposals,
+		CalendarStore: f.calendar,
+		NoticeStore:   f.notices,
+		RecordStore:   f.records,
+		Invite: func(_ context.Context, _ grading.Ceremony, p grading.Proposal) {
+			f.invited = append(f.invited, p.MemberID)
+		},
+		Award: func(_ context.Context, _ grading.Ceremony, r grading.Record) {
+			f.awarded = append(f.awarded, r.MemberID)
+		},
+		GenerateID: func() string { n++; return fmt.Sprintf("id-%d", n) },
+		Now:        fixedNow,
+	}
+	return f
+}
+
+// TestPlanGradingCeremony tests that planning creates the calendar event and a grading notice
+// aimed at it, books the proposals onto the event and invites their members.
+func TestPlanGradingCeremony(t *testing.T) {
+	f := newCeremonyFixture()
+	day := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	// An approved proposal cannot be attached, and nothing is created
+	_, err := ExecutePlanGradingCeremony(context.Background(), PlanGradingCeremonyInput{
+		Title: "Term 1 promotions", Date: day, ProposalIDs: []string{"p1", "p4"}, CreatedBy: "admin-1",
+	}, f.deps)
+	if !errors.Is(err, grading.ErrNotAttachable) || len(f.calendar.events) != 0 {
+		t.Fatalf("approved proposal: err = %v with %d events, want ErrNotAttachable and none", err, len(f.calendar.events))
+	}
+
+	c, err := ExecutePlanGradingCeremony(context.Background(), PlanGradingCeremonyInput{
+		Title: "Term 1 promotions", Location: "Main mat", Date: day, ProposalIDs: []string{"p1", "p2", "p1"}, CreatedBy: "admin-1",
+	}, f.deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(f.calendar.events) != 1 || f.calendar.events[0].ID != c.EventID || !f.calendar.events[0].StartDate.Equal(day) || f.calendar.events[0].Type != calendar.TypeEvent {
+		t.Errorf("calendar events = %+v, want one club event on the day", f.calendar.events)
+	}
+	n, ok := f.notices.notices[c.NoticeID]
+	if !ok || n.Type != notice.TypeGrading || n.TargetID != c.EventID || !n.IsPublished() || !n.IsVisible(day.Add(12*time.Hour)) || n.IsVisible(day.AddDate(0, 0, 2)) {
+		t.Errorf("notice = %+v, want a published grading notice aimed at the event until the day ends", n)
+	}
+	for _, id := range []string{"p1", "p2"} {
+		if p := f.proposals.proposals[id]; p.Status != grading.ProposalScheduled || p.EventID != c.EventID {
+			t.Errorf("%s = %+v, want scheduled onto the ceremony", id, p)
+		}
+	}
+	if len(f.invited) != 2 {
+		t.Errorf("invited = %v, want m1 and m2 once each", f.invited)
+	}
+
+	p, err := ExecuteAttachCeremonyProposal(context.Background(), AttachCeremonyProposalInput{CeremonyID: c.ID, ProposalID: "p3"}, f.deps)
+	if err != nil || p.EventID != c.EventID || len(f.invited) != 3 {
+		t.Errorf("attach p3: %+v, %v; invited %v", p, err, f.invited)
+	}
+	if _, err := ExecuteAttachCeremonyProposal(context.Background(), AttachCeremonyProposalInput{CeremonyID: c.ID, ProposalID: "p3"}, f.deps); err != nil || len(f.invited) != 3 {
+		t.Errorf("re-attach p3: %v; invited %v, want no second invitation", err, f.invited)
+	}
+	if _, err := ExecuteAttachCeremonyProposal(context.Background(), AttachCeremonyProposalInput{CeremonyID: "nope", ProposalID: "p3"}, f.deps); !errors.Is(err, ErrCeremonyNotFound) {
+		t.Errorf("unknown ceremony: err = %v", err)
+	}
+}
+
+// TestCompleteGradingCeremony tests that completing approves what is still open, records each
+// approved proposal on the ceremony day, skips rejections and can only happen once.
+func TestCompleteGradingCeremony(t *testing.T) {
+	f := newCeremonyFixture()
+	day := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	c, err := ExecutePlanGradingCeremony(context.Background(), PlanGradingCeremonyInput{
+		Title: "Term 1 promotions", Date: day, ProposalIDs: []string{"p1", "p2", "p3"}, CreatedBy: "admin-1",
+	}, f.deps)
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	// p2 was approved ahead of the day and p3 turned down
+	p2 := f.proposals.proposals["p2"]
+	p2.Approve("admin-2")
+	f.proposals.proposals["p2"] = p2
+	p3 := f.proposals.proposals["p3"]
+	p3.Reject("admin-2")
+	f.proposals.proposals["p3"] = p3
+
+	// A failed save leaves the ceremony planned and awards nothing
+	f.records.fail = true
+	if _, err := ExecuteCompleteGradingCeremony(context.Background(), CompleteGradingCeremonyInput{CeremonyID: c.ID, AdminID: "admin-1"}, f.deps); err == nil || len(f.awarded) != 0 {
+		t.Fatalf("failed save: err = %v, awarded %v", err, f.awarded)
+	}
+	f.records.fail = false
+
+	result, err := ExecuteCompleteGradingCeremony(context.Background(), CompleteGradingCeremonyInput{CeremonyID: c.ID, AdminID: "admin-1"}, f.deps)
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if result.Ceremony.IsPlanned() || result.Ceremony.CompletedBy != "admin-1" {
+		t.Errorf("ceremony = %+v, want completed by admin-1", result.Ceremony)
+	}
+	if len(result.Records) != 2 || len(f.records.records) != 2 {
+		t.Fatalf("records = %+v, want one each for p1 and p2 with no duplicates from the retry", result.Records)
+	}
+	for _, r := range result.Records {
+		if !r.PromotedAt.Equal(day) || r.Method != grading.MethodStandard {
+			t.Errorf("record = %+v, want a standard promotion on the ceremony day", r)
+		}
+	}
+	if r := f.records.records["p1"]; r.MemberID != "m1" || r.Belt != grading.BeltBlue || r.ApprovedBy != "admin-1" {
+		t.Errorf("p1 record = %+v, want m1 to blue approved at completion", r)
+	}
+	if r := f.records.records["p2"]; r.ApprovedBy != "admin-2" {
+		t.Errorf("p2 record = %+v, want the earlier approval kept", r)
+	}
+	if p := f.proposals.proposals["p1"]; p.Status != grading.ProposalApproved {
+		t.Errorf("p1 = %+v, want approved", p)
+	}
+	if len(f.awarded) != 2 {
+		t.Errorf("awarded = %v, want m1 and m2", f.awarded)
+	}
+
+	if _, err := ExecuteCompleteGradingCeremony(context.Background(), CompleteGradingCeremonyInput{CeremonyID: c.ID, AdminID: "admin-1"}, f.deps); !errors.Is(err, grading.ErrCeremonyCompleted) {
+		t.Errorf("second completion: err = %v, want ErrCeremonyCompleted", err)
+	}
+	if _, err := ExecuteAttachCeremonyProposal(context.Background(), AttachCeremonyProposalInput{CeremonyID: c.ID, ProposalID: "p1"}, f.deps); !errors.Is(err, grading.ErrCeremonyCompleted) {
+		t.Errorf("attach after completion: err = %v, want ErrCeremonyCompleted", err)
+	}
+}
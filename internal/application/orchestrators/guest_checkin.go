@@ -2,12 +2,19 @@ package orchestrators
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"html"
 	"log/slog"
+	"strings"
 	"time"
 
 	"workshop/internal/domain/attendance"
+	emailDomain "workshop/internal/domain/email"
 	"workshop/internal/domain/member"
+	"workshop/internal/domain/referral"
+	"workshop/internal/domain/visitor"
 	"workshop/internal/domain/waiver"
 
 	"github.com/google/uuid"
@@ -28,14 +35,33 @@ type GuestAttendanceStore interface {
 	Save(ctx context.Context, a attendance.Attendance) error
 }
 
+// GuestVisitorStore defines the visitor store interface needed to recognise returning guests.
+type GuestVisitorStore interface {
+	GetVisitorByEmail(ctx context.Context, email string) (visitor.Visitor, error)
+	SaveVisitor(ctx context.Context, v visitor.Visitor) error
+	SaveVisit(ctx context.Context, v visitor.Visit) error
+}
+
+// GuestFollowUpEmailStore defines the email store interface needed to schedule a visitor's follow-up.
+type GuestFollowUpEmailStore interface {
+	Save(ctx context.Context, e emailDomain.Email) error
+	SaveRecipients(ctx context.Context, emailID string, recipients []emailDomain.Recipient) error
+}
+
+// GuestFollowUpSubject is the subject line of the email a visitor gets after their first visit.
+const GuestFollowUpSubject = "Thanks for training with us"
+
 // GuestCheckInInput carries input for the guest check-in flow.
 type GuestCheckInInput struct {
 	Name          string
 	Email         string
+	HomeGym       string // optional: the club the guest usually trains at
 	AcceptedTerms bool
 	IPAddress     string
 	ScheduleID    string
 	ClassDate     string
+	SenderID      string     // set by the handler: the staff account the follow-up email is sent as; empty sends none
+	ReferredBy    ReferredBy // optional: who sent them; kept only if nobody is on record yet
 }
 
 // GuestCheckInDeps holds dependencies for GuestCheckIn.
@@ -43,6 +69,9 @@ type GuestCheckInDeps struct {
 	MemberStore     GuestMemberStore
 	WaiverStore     GuestWaiverStore
 	AttendanceStore GuestAttendanceStore
+	VisitorStore    GuestVisitorStore       // optional: nil checks in without remembering the visitor
+	EmailStore      GuestFollowUpEmailStore // optional: nil schedules no follow-up email
+	ReferralStore   ReferralStore           // optional: nil records no referral
 }
 
 // GuestCheckInResult holds the output of the guest check-in flow.
@@ -50,11 +79,20 @@ type GuestCheckInResult struct {
 	MemberID     string
 	WaiverID     string
 	AttendanceID string
+	VisitorID    string // empty when visitors are not tracked
+	Returning    bool   // the guest has visited before; prompt them to convert
+	VisitCount   int
+	Fee          int // drop-in fee charged for this visit, in dollars
 }
 
 // ExecuteGuestCheckIn creates a guest member, signs a waiver, and checks them in.
+// A guest who has visited before under the same email is recognised: the visit is added
+// to their history against the same member record. After a first visit a follow-up email
+// is scheduled for visitor.FollowUpDelay later. A referrer named at the kiosk is kept the
+// first time one is given.
 // PRE: Name and Email must be non-empty; AcceptedTerms must be true
-// POST: Guest member, waiver, and attendance records created
+// POST: Waiver and attendance records created; the guest member and visitor are created on
+// the first visit; a visit is recorded when VisitorStore is set
 func ExecuteGuestCheckIn(ctx context.Context, input GuestCheckInInput, deps GuestCheckInDeps) (GuestCheckInResult, error) {
 	if input.Name == "" {
 		return GuestCheckInResult{}, errors.New("guest name is required")
@@ -71,19 +109,43 @@ func ExecuteGuestCheckIn(ctx context.Context, input GuestCheckInInput, deps Gues
 	waiverID := uuid.New().String()
 	attendanceID := uuid.New().String()
 
-	// Step 1: Create guest member
-	guestMember := member.Member{
-		ID:      memberID,
-		Name:    input.Name,
-		Email:   input.Email,
-		Program: member.ProgramAdults,
-		Status:  member.StatusActive,
+	var guest visitor.Visitor
+	returning := false
+	if deps.VisitorStore != nil {
+		if v, err := deps.VisitorStore.GetVisitorByEmail(ctx, input.Email); err == nil {
+			guest, returning = v, true
+			memberID = v.MemberID
+		}
 	}
-	if err := guestMember.Validate(); err != nil {
-		return GuestCheckInResult{}, err
+	if len(input.HomeGym) > visitor.MaxHomeGymLength {
+		return GuestCheckInResult{}, visitor.ErrHomeGymTooLong
 	}
-	if err := deps.MemberStore.Save(ctx, guestMember); err != nil {
-		return GuestCheckInResult{}, err
+	var ref referral.Referral
+	withReferral := false
+	if deps.ReferralStore != nil && !input.ReferredBy.isEmpty() {
+		if _, err := deps.ReferralStore.GetByMemberID(ctx, memberID); errors.Is(err, sql.ErrNoRows) {
+			if ref, err = newReferral(memberID, input.ReferredBy, referral.SourceGuestCheckIn, now); err != nil {
+				return GuestCheckInResult{}, err
+			}
+			withReferral = true
+		}
+	}
+
+	// Step 1: Create guest member, unless they have been before
+	if !returning {
+		guestMember := member.Member{
+			ID:      memberID,
+			Name:    input.Name,
+			Email:   input.Email,
+			Program: member.ProgramAdults,
+			Status:  member.StatusActive,
+		}
+		if err := guestMember.Validate(); err != nil {
+			return GuestCheckInResult{}, err
+		}
+		if err := deps.MemberStore.Save(ctx, guestMember); err != nil {
+			return GuestCheckInResult{}, err
+		}
 	}
 
 	// Step 2: Sign waiver
@@ -116,11 +178,118 @@ func ExecuteGuestCheckIn(ctx context.Context, input GuestCheckInInput, deps Gues
 		return GuestCheckInResult{}, err
 	}
 
-	slog.Info("guest_event", "event", "guest_checked_in", "member_id", memberID, "name", input.Name)
-
-	return GuestCheckInResult{
+	result := GuestCheckInResult{
 		MemberID:     memberID,
 		WaiverID:     waiverID,
 		AttendanceID: attendanceID,
-	}, nil
+	}
+
+	// Step 4: Remember who referred them
+	if withReferral {
+		if err := deps.ReferralStore.Save(ctx, ref); err != nil {
+			return GuestCheckInResult{}, err
+		}
+	}
+
+	// Step 5: Remember the visit
+	if deps.VisitorStore != nil {
+		if err := recordGuestVisit(ctx, &guest, returning, memberID, attendanceID, now, input, deps); err != nil {
+			return GuestCheckInResult{}, err
+		}
+		result.VisitorID = guest.ID
+		result.Returning = guest.IsReturning()
+		result.VisitCount = guest.VisitCount
+		result.Fee = guest.Fee()
+	}
+
+	slog.InfoContext(ctx, "guest_event", "event", "guest_checked_in", "member_id", memberID, "name", input.Name, "returning", result.Returning)
+
+	return result, nil
+}
+
+// recordGuestVisit adds the visit to the visitor's history, creating the visitor on a first
+// visit and scheduling their follow-up email.
+func recordGuestVisit(ctx context.Context, guest *visitor.Visitor, returning bool, memberID, attendanceID string, now time.Time, input GuestCheckInInput, deps GuestCheckInDeps) error {
+	if !returning {
+		*guest = visitor.Visitor{
+			ID:        uuid.New().String(),
+			MemberID:  memberID,
+			Name:      input.Name,
+			Email:     strings.ToLower(strings.TrimSpace(input.Email)),
+			Status:    visitor.StatusVisiting,
+			CreatedAt: now,
+		}
+	}
+	if home := strings.TrimSpace(input.HomeGym); home != "" {
+		guest.HomeGym = home
+	}
+	guest.RecordVisit(now)
+	if err := guest.Validate(); err != nil {
+		return err
+	}
+
+	if !returning && deps.EmailStore != nil && input.SenderID != "" {
+		emailID, err := scheduleGuestFollowUp(ctx, *guest, input.SenderID, now, deps.EmailStore)
+		if err != nil {
+			// The guest is on the mat either way; staff can follow up by hand.
+			slog.ErrorContext(ctx, "guest_event", "event", "follow_up_schedule_failed", "member_id", memberID, "error", err)
+		}
+		guest.FollowUpEmailID = emailID
+	}
+	if err := deps.VisitorStore.SaveVisitor(ctx, *guest); err != nil {
+		return err
+	}
+
+	visit := visitor.Visit{
+		ID:           uuid.New().String(),
+		VisitorID:    guest.ID,
+		AttendanceID: attendanceID,
+		VisitedAt:    now,
+		Fee:          guest.Fee(),
+	}
+	if err := visit.Validate(); err != nil {
+		return err
+	}
+	return deps.VisitorStore.SaveVisit(ctx, visit)
+}
+
+// scheduleGuestFollowUp schedules the email thanking a visitor for their first visit and
+// inviting them back. The recipient is saved before the email is scheduled so the worker
+// never sees it empty.
+func scheduleGuestFollowUp(ctx context.Context, guest visitor.Visitor, senderID string, now time.Time, store GuestFollowUpEmailStore) (string, error) {
+	em := emailDomain.Email{
+		ID:        uuid.New().String(),
+		Subject:   GuestFollowUpSubject,
+		Body:      guestFollowUpBody(guest.Name, guest.Fee()),
+		Category:  emailDomain.CategoryAnnouncements,
+		SenderID:  senderID,
+		Status:    emailDomain.StatusDraft,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := em.Validate(); err != nil {
+		return "", err
+	}
+	if err := store.Save(ctx, em); err != nil {
+		return "", err
+	}
+	recipient := emailDomain.Recipient{EmailID: em.ID, MemberID: guest.MemberID, MemberName: guest.Name, MemberEmail: guest.Email}
+	if err := store.SaveRecipients(ctx, em.ID, []emailDomain.Recipient{recipient}); err != nil {
+		return "", err
+	}
+	if err := em.Schedule(guest.FollowUpAt()); err != nil {
+		return "", err
+	}
+	if err := store.Save(ctx, em); err != nil {
+		return "", err
+	}
+	return em.ID, nil
+}
+
+// guestFollowUpBody renders the HTML body of a visitor's follow-up email.
+func guestFollowUpBody(name string, fee int) string {
+	return fmt.Sprintf(`<p>Kia ora %s,</p>
+<p>Thanks for training with us. We hope you enjoyed the class and would love to see you on the mats again.</p>
+<p>Drop-ins are $%d a session. If you are thinking about training regularly, ask a coach about a free trial or a membership next time you are in.</p>`,
+		html.EscapeString(name), fee)
 }
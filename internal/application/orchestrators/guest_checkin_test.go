@@ -0,0 +1,139 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"workshop/internal/domain/attendance"
+	emailDomain "workshop/internal/domain/email"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/visitor"
+	"workshop/internal/domain/waiver"
+)
+
+// --- Mock stores for guest check-in tests ---
+
+type mockGuestMemberStore struct{ saved []member.Member }
+
+// Save records the guest member.
+// PRE: none
+// POST: member appended
+func (m *mockGuestMemberStore) Save(_ context.Context, v member.Member) error {
+	m.saved = append(m.saved, v)
+	return nil
+}
+
+type mockGuestWaiverStore struct{}
+
+// Save accepts the waiver.
+// PRE: none
+// POST: none
+func (m *mockGuestWaiverStore) Save(_ context.Context, _ waiver.Waiver) error { return nil }
+
+type mockGuestAttendanceStore struct{ saved []attendance.Attendance }
+
+// Save records the check-in.
+// PRE: none
+// POST: attendance appended
+func (m *mockGuestAttendanceStore) Save(_ context.Context, a attendance.Attendance) error {
+	m.saved = append(m.saved, a)
+	return nil
+}
+
+type mockGuestVisitorStore struct {
+	visitors map[string]visitor.Visitor
+	visits   []visitor.Visit
+}
+
+// GetVisitorByEmail finds a visitor by email, ignoring case like the SQLite store.
+// PRE: none
+// POST: Returns the visitor or an error
+func (m *mockGuestVisitorStore) GetVisitorByEmail(_ context.Context, email string) (visitor.Visitor, error) {
+	for _, v := range m.visitors {
+		if strings.EqualFold(v.Email, email) {
+			return v, nil
+		}
+	}
+	return visitor.Visitor{}, errors.New("not found")
+}
+
+// SaveVisitor stores the visitor.
+// PRE: none
+// POST: visitor stored by ID
+func (m *mockGuestVisitorStore) SaveVisitor(_ context.Context, v visitor.Visitor) error {
+	m.visitors[v.ID] = v
+	return nil
+}
+
+// SaveVisit records the visit.
+// PRE: none
+// POST: visit appended
+func (m *mockGuestVisitorStore) SaveVisit(_ context.Context, v visitor.Visit) error {
+	m.visits = append(m.visits, v)
+	return nil
+}
+
+// TestExecuteGuestCheckIn_ReturningVisitor verifies a second check-in under the same email is
+// the same visitor, and only the first visit schedules a follow-up email.
+func TestExecuteGuestCheckIn_ReturningVisitor(t *testing.T) {
+	members := &mockGuestMemberStore{}
+	visitors := &mockGuestVisitorStore{visitors: map[string]visitor.Visitor{}}
+	emails := newMockEmailStore()
+	deps := GuestCheckInDeps{
+		MemberStore:     members,
+		WaiverStore:     &mockGuestWaiverStore{},
+		AttendanceStore: &mockGuestAttendanceStore{},
+		VisitorStore:    visitors,
+		EmailStore:      emails,
+	}
+	input := GuestCheckInInput{Name: "Kai", Email: "Kai@Example.com", HomeGym: "City BJJ", AcceptedTerms: true, SenderID: "coach-1"}
+
+	first, err := ExecuteGuestCheckIn(context.Background(), input, deps)
+	if err != nil {
+		t.Fatalf("first visit: %v", err)
+	}
+	if first.Returning || first.VisitCount != 1 || first.Fee != visitor.DefaultDropInFee || first.VisitorID == "" {
+		t.Errorf("first visit result: %+v", first)
+	}
+	v := visitors.visitors[first.VisitorID]
+	if v.HomeGym != "City BJJ" || v.Email != "kai@example.com" || v.FollowUpEmailID == "" {
+		t.Errorf("visitor after first visit: %+v", v)
+	}
+	em := emails.emails[v.FollowUpEmailID]
+	if em.Status != emailDomain.StatusScheduled || !em.ScheduledAt.Equal(v.FollowUpAt()) || len(emails.recipients[em.ID]) != 1 {
+		t.Errorf("follow-up email: %+v", em)
+	}
+
+	input.Email = "kai@example.com"
+	input.HomeGym = ""
+	second, err := ExecuteGuestCheckIn(context.Background(), input, deps)
+	if err != nil {
+		t.Fatalf("second visit: %v", err)
+	}
+	if !second.Returning || second.VisitCount != 2 || second.VisitorID != first.VisitorID || second.MemberID != first.MemberID {
+		t.Errorf("second visit result: %+v", second)
+	}
+	if len(members.saved) != 1 {
+		t.Errorf("expected one guest member, got %d", len(members.saved))
+	}
+	if len(emails.emails) != 1 {
+		t.Errorf("expected one follow-up email, got %d", len(emails.emails))
+	}
+	if len(visitors.visits) != 2 || visitors.visitors[first.VisitorID].HomeGym != "City BJJ" {
+		t.Errorf("visits %d, visitor %+v", len(visitors.visits), visitors.visitors[first.VisitorID])
+	}
+}
+
+// TestExecuteGuestCheckIn_WithoutVisitorStore verifies check-in still works when visitors are not tracked.
+func TestExecuteGuestCheckIn_WithoutVisitorStore(t *testing.T) {
+	deps := GuestCheckInDeps{MemberStore: &mockGuestMemberStore{}, WaiverStore: &mockGuestWaiverStore{}, AttendanceStore: &mockGuestAttendanceStore{}}
+	got, err := ExecuteGuestCheckIn(context.Background(), GuestCheckInInput{Name: "Kai", Email: "kai@example.com", AcceptedTerms: true}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.MemberID == "" || got.VisitorID != "" {
+		t.Errorf("unexpected result: %+v", got)
+	}
+}
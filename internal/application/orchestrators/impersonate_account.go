@@ -0,0 +1,149 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/audit"
+	"workshop/internal/domain/authsession"
+)
+
+// Account impersonation errors
+var (
+	ErrImpersonateAccountRequired = errors.New("account ID is required")
+	ErrImpersonateNotFound        = errors.New("account not found")
+	ErrImpersonateNested          = errors.New("stop impersonating before signing in as another account")
+	ErrImpersonateSelf            = errors.New("you are already signed in as this account")
+	ErrImpersonateAdmin           = errors.New("admin accounts cannot be impersonated")
+	ErrImpersonateInactive        = errors.New("only active accounts can be impersonated")
+)
+
+// ImpersonateAccountStore defines the account store interface needed to impersonate an account.
+type ImpersonateAccountStore interface {
+	GetByID(ctx context.Context, id string) (account.Account, error)
+}
+
+// ImpersonateAccountInput names the account an admin wants to act as.
+type ImpersonateAccountInput struct {
+	AccountID     string
+	Actor         BackfillActor // the admin, from their own session
+	Impersonating bool          // the admin's session is already impersonating a role or account
+}
+
+// ImpersonateAccountDeps holds dependencies for ImpersonateAccount.
+type ImpersonateAccountDeps struct {
+	AccountStore ImpersonateAccountStore
+	AuditStore   BackfillAuditStore
+	Now          func() time.Time
+}
+
+// ImpersonateAccountResult carries the identity the session takes on.
+type ImpersonateAccountResult struct {
+	AccountID string
+	Email     string
+	Role      string
+	ExpiresAt time.Time // authsession.ImpersonationLimit from now
+}
+
+// ExecuteImpersonateAccount checks that an admin may sign in as another account and
+// returns that account's identity for the session. Admin and inactive accounts can't
+// be impersonated, and an admin already impersonating must stop first.
+// PRE: Caller is a real admin (checked by the handler)
+// POST: Returns the target identity and when the impersonation ends; the start is audited
+func ExecuteImpersonateAccount(ctx context.Context, input ImpersonateAccountInput, deps ImpersonateAccountDeps) (ImpersonateAccountResult, error) {
+	if input.AccountID == "" {
+		return ImpersonateAccountResult{}, ErrImpersonateAccountRequired
+	}
+	if input.Impersonating {
+		return ImpersonateAccountResult{}, ErrImpersonateNested
+	}
+	if input.AccountID == input.Actor.AccountID {
+		return ImpersonateAccountResult{}, ErrImpersonateSelf
+	}
+	target, err := deps.AccountStore.GetByID(ctx, input.AccountID)
+	if err != nil {
+		return ImpersonateAccountResult{}, ErrImpersonateNotFound
+	}
+	if target.Role == account.RoleAdmin {
+		return ImpersonateAccountResult{}, ErrImpersonateAdmin
+	}
+	if target.Status != account.StatusActive {
+		return ImpersonateAccountResult{}, ErrImpersonateInactive
+	}
+
+	result := ImpersonateAccountResult{
+		AccountID: target.ID,
+		Email:     target.Email,
+		Role:      target.Role,
+		ExpiresAt: deps.Now().Add(authsession.ImpersonationLimit),
+	}
+	impersonationAudit(ctx, input.Actor, target.ID, "Started impersonating "+target.Email, map[string]any{
+		"email":      target.Email,
+		"role":       target.Role,
+		"expires_at": result.ExpiresAt,
+	}, deps.AuditStore)
+	return result, nil
+}
+
+// EndAccountImpersonationInput describes an account impersonation that has finished.
+type EndAccountImpersonationInput struct {
+	AccountID string // the impersonated account
+	Email     string
+	Expired   bool // ended by the time limit rather than by the admin
+	Actor     BackfillActor
+}
+
+// ExecuteEndAccountImpersonation records the end of an account impersonation in the audit log.
+// The session itself is restored by the caller.
+// PRE: Actor is the admin who was impersonating
+// POST: The end is audited; a failure to audit is logged, not returned
+func ExecuteEndAccountImpersonation(ctx context.Context, input EndAccountImpersonationInput, auditStore BackfillAuditStore) {
+	desc := "Stopped impersonating " + input.Email
+	if input.Expired {
+		desc = "Impersonation of " + input.Email + " reached its time limit"
+	}
+	impersonationAudit(ctx, input.Actor, input.AccountID, desc, map[string]any{
+		"email":   input.Email,
+		"expired": input.Expired,
+	}, auditStore)
+}
+
+// ImpersonatedRequestInput describes a change an admin made while signed in as another account.
+type ImpersonatedRequestInput struct {
+	AccountID string // the impersonated account
+	Email     string
+	Method    string
+	Path      string
+	Actor     BackfillActor
+}
+
+// ExecuteRecordImpersonatedRequest records a change made under an account impersonation in
+// the audit log, so every write can be traced to the admin behind it.
+// PRE: Actor is the admin who is impersonating
+// POST: The request is audited; a failure to audit is logged, not returned
+func ExecuteRecordImpersonatedRequest(ctx context.Context, input ImpersonatedRequestInput, auditStore BackfillAuditStore) {
+	impersonationAudit(ctx, input.Actor, input.AccountID, input.Method+" "+input.Path+" as "+input.Email, map[string]any{
+		"email":  input.Email,
+		"method": input.Method,
+		"path":   input.Path,
+	}, auditStore)
+}
+
+// impersonationAudit records an impersonation event against the impersonated account.
+// A failure is logged, not returned: the session change goes ahead either way.
+func impersonationAudit(ctx context.Context, actor BackfillActor, accountID, description string, details map[string]any, auditStore BackfillAuditStore) {
+	metadata, _ := json.Marshal(details)
+	event := audit.NewEvent(actor.AccountID, actor.Email, actor.Role, audit.CategorySecurity, audit.ActionImpersonate).
+		WithSeverity(audit.SeverityWarning).
+		WithResource("account", accountID).
+		WithDescription(description).
+		WithRequest(actor.IPAddress, actor.UserAgent).
+		WithMetadata(string(metadata))
+	if err := auditStore.Save(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "devmode_event", "event", "impersonation_audit_failed", "account_id", accountID, "error", err)
+	}
+}
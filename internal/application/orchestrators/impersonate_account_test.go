@@ -0,0 +1,70 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/audit"
+	"workshop/internal/domain/authsession"
+)
+
+// TestExecuteImpersonateAccount verifies an admin can act as an active, non-admin account for
+// the impersonation limit, and that the start is audited against that account.
+func TestExecuteImpersonateAccount(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	accounts := &mockUnlockAccountStore{accounts: map[string]account.Account{
+		"m1":     {ID: "m1", Email: "marcus@example.com", Role: account.RoleMember, Status: account.StatusActive},
+		"a2":     {ID: "a2", Email: "other-admin@example.com", Role: account.RoleAdmin, Status: account.StatusActive},
+		"s1":     {ID: "s1", Email: "suspended@example.com", Role: account.RoleMember, Status: account.StatusSuspended},
+		"admin1": {ID: "admin1", Email: "admin@example.com", Role: account.RoleAdmin, Status: account.StatusActive},
+	}}
+	auditStore := &mockBackfillAuditStore{}
+	deps := ImpersonateAccountDeps{AccountStore: accounts, AuditStore: auditStore, Now: func() time.Time { return now }}
+	actor := BackfillActor{AccountID: "admin1", Email: "admin@example.com", Role: account.RoleAdmin}
+
+	tests := []struct {
+		name  string
+		input ImpersonateAccountInput
+		want  error
+	}{
+		{"no account", ImpersonateAccountInput{Actor: actor}, ErrImpersonateAccountRequired},
+		{"unknown account", ImpersonateAccountInput{AccountID: "nope", Actor: actor}, ErrImpersonateNotFound},
+		{"already impersonating", ImpersonateAccountInput{AccountID: "m1", Actor: actor, Impersonating: true}, ErrImpersonateNested},
+		{"self", ImpersonateAccountInput{AccountID: "admin1", Actor: actor}, ErrImpersonateSelf},
+		{"another admin", ImpersonateAccountInput{AccountID: "a2", Actor: actor}, ErrImpersonateAdmin},
+		{"suspended", ImpersonateAccountInput{AccountID: "s1", Actor: actor}, ErrImpersonateInactive},
+	}
+	for _, tt := range tests {
+		if _, err := ExecuteImpersonateAccount(context.Background(), tt.input, deps); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if len(auditStore.events) != 0 {
+		t.Fatalf("refused impersonations were audited: %+v", auditStore.events)
+	}
+
+	result, err := ExecuteImpersonateAccount(context.Background(), ImpersonateAccountInput{AccountID: "m1", Actor: actor}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.AccountID != "m1" || result.Email != "marcus@example.com" || result.Role != account.RoleMember {
+		t.Errorf("result = %+v, want the member's identity", result)
+	}
+	if !result.ExpiresAt.Equal(now.Add(authsession.ImpersonationLimit)) {
+		t.Errorf("ExpiresAt = %v, want %v", result.ExpiresAt, now.Add(authsession.ImpersonationLimit))
+	}
+	if len(auditStore.events) != 1 {
+		t.Fatalf("audit events = %d, want 1", len(auditStore.events))
+	}
+	if e := auditStore.events[0]; e.Action != audit.ActionImpersonate || e.ActorID != "admin1" || e.ResourceID != "m1" {
+		t.Errorf("audit event = %+v, want admin1 impersonating m1", e)
+	}
+
+	ExecuteEndAccountImpersonation(context.Background(), EndAccountImpersonationInput{AccountID: "m1", Email: "marcus@example.com", Expired: true, Actor: actor}, auditStore)
+	if len(auditStore.events) != 2 || auditStore.events[1].Description != "Impersonation of marcus@example.com reached its time limit" {
+		t.Errorf("end event = %+v", auditStore.events[len(auditStore.events)-1])
+	}
+}
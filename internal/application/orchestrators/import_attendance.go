@@ -0,0 +1,413 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/application/textmatch"
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/schedule"
+)
+
+// Attendance import limits and match thresholds.
+const (
+	// MaxImportAttendanceRows caps one import; three years of a busy club fits comfortably.
+	MaxImportAttendanceRows = 50000
+	// ImportAutoMatchSimilarity is how close a legacy name must be to exactly one member to be matched without review.
+	ImportAutoMatchSimilarity = 0.85
+	// ImportCandidateSimilarity is how close a member's name must be to be offered in review.
+	ImportCandidateSimilarity = 0.5
+	// ImportMaxCandidates caps the members offered for each unmatched name.
+	ImportMaxCandidates = 3
+)
+
+// ImportStatusUnmatched marks a row whose name still needs a member chosen in review.
+// Other rows use the bulk sync statuses.
+const ImportStatusUnmatched = "unmatched"
+
+// ErrInvalidAttendanceImport is returned when the CSV as a whole cannot be imported.
+var ErrInvalidAttendanceImport = errors.New("invalid attendance import")
+
+// ImportAttendanceMemberStore defines the member store interface needed for an attendance import.
+type ImportAttendanceMemberStore interface {
+	List(ctx context.Context, filter memberStore.ListFilter) ([]member.Member, error)
+}
+
+// ImportAttendanceClassTypeStore defines the class type store interface needed for an attendance import.
+type ImportAttendanceClassTypeStore interface {
+	List(ctx context.Context) ([]classtype.ClassType, error)
+}
+
+// ImportAttendanceScheduleStore defines the schedule store interface needed for an attendance import.
+type ImportAttendanceScheduleStore interface {
+	List(ctx context.Context) ([]schedule.Schedule, error)
+}
+
+// ImportAttendanceInput carries a legacy attendance CSV and the names resolved in review.
+// The CSV needs NAME, DATE (YYYY-MM-DD or D/M/YYYY) and CLASS columns; an optional TIME (HH:MM)
+// picks between two classes of the same type on one day.
+type ImportAttendanceInput struct {
+	Reader   io.Reader
+	Mappings map[string]string // legacy name -> member ID chosen in review; an empty ID skips the name
+	DryRun   bool
+	ActorID  string // AccountID of the admin
+}
+
+// ImportAttendanceCandidate is a member offered for an unmatched legacy name.
+type ImportAttendanceCandidate struct {
+	MemberID   string
+	Name       string
+	Similarity float64
+}
+
+// ImportAttendanceReview lists a legacy name that matched no member, with the closest members.
+type ImportAttendanceReview struct {
+	Name       string
+	Rows       int
+	Candidates []ImportAttendanceCandidate
+}
+
+// ImportAttendanceMatch reports a legacy name matched to a member by spelling similarity.
+type ImportAttendanceMatch struct {
+	Name       string
+	MemberID   string
+	MemberName string
+	Similarity float64
+	Rows       int
+}
+
+// ImportAttendanceRowIssue reports a row that was not imported.
+type ImportAttendanceRowIssue struct {
+	Row    int // 1-based line number in the file, header included
+	Name   string
+	Status string // rejected or unmatched
+	Reason string
+}
+
+// ImportAttendanceResult summarises an import. Re-running the same file only adds rows
+// that were unmatched or rejected before.
+type ImportAttendanceResult struct {
+	Total     int
+	Created   int
+	Duplicate int
+	Rejected  int
+	Unmatched int
+	DryRun    bool
+	Review    []ImportAttendanceReview // names needing a member chosen, by descending row count
+	Matches   []ImportAttendanceMatch  // names matched by similarity rather than exactly
+	Issues    []ImportAttendanceRowIssue
+}
+
+// ImportAttendanceDeps holds dependencies for ImportAttendance.
+type ImportAttendanceDeps struct {
+	MemberStore     ImportAttendanceMemberStore
+	ClassTypeStore  ImportAttendanceClassTypeStore
+	ScheduleStore   ImportAttendanceScheduleStore
+	AttendanceStore BulkSyncAttendanceStore
+	GenerateID      func() string
+	Now             func() time.Time
+}
+
+// memberMatch is how one legacy name resolved.
+type memberMatch struct {
+	memberID   string
+	memberName string
+	similarity float64
+	skipped    bool // the admin chose to skip the name in review
+	candidates []ImportAttendanceCandidate
+}
+
+// ExecuteImportAttendance imports attendance from a legacy spreadsheet export.
+// Names are matched to members by the review mappings, then exactly, then by similarity;
+// classes by class type name and weekday. Rows are keyed on (member, class, date), so
+// re-running an import never duplicates attendance.
+// PRE: deps are non-nil; input.ActorID is an admin
+// POST: Matched rows are saved unless DryRun; unmatched names are returned for review
+// INVARIANT: A bad row never aborts the rest of the import
+func ExecuteImportAttendance(ctx context.Context, input ImportAttendanceInput, deps ImportAttendanceDeps) (ImportAttendanceResult, error) {
+	rows, err := readAttendanceCSV(input.Reader)
+	if err != nil {
+		return ImportAttendanceResult{}, err
+	}
+	members, err := deps.MemberStore.List(ctx, memberStore.ListFilter{Limit: 10000})
+	if err != nil {
+		return ImportAttendanceResult{}, err
+	}
+	classTypes, err := deps.ClassTypeStore.List(ctx)
+	if err != nil {
+		return ImportAttendanceResult{}, err
+	}
+	schedules, err := deps.ScheduleStore.List(ctx)
+	if err != nil {
+		return ImportAttendanceResult{}, err
+	}
+
+	mappings := make(map[string]string, len(input.Mappings))
+	for name, id := range input.Mappings {
+		mappings[normaliseName(name)] = id
+	}
+	classTypeIDs := make(map[string]string, len(classTypes))
+	for _, ct := range classTypes {
+		classTypeIDs[normaliseName(ct.Name)] = ct.ID
+	}
+
+	result := ImportAttendanceResult{DryRun: input.DryRun, Total: len(rows)}
+	matches := make(map[string]*memberMatch)
+	review := make(map[string]*ImportAttendanceReview)
+	fuzzy := make(map[string]*ImportAttendanceMatch)
+	seen := make(map[string]bool)
+	loc := deps.Now().Location()
+
+	for _, row := range rows {
+		issue := func(status, reason string) {
+			result.Issues = append(result.Issues, ImportAttendanceRowIssue{Row: row.line, Name: row.name, Status: status, Reason: reason})
+			if status == ImportStatusUnmatched {
+				result.Unmatched++
+			} else {
+				result.Rejected++
+			}
+		}
+
+		key := normaliseName(row.name)
+		if key == "" {
+			issue(BulkSyncStatusRejected, "name is required")
+			continue
+		}
+		m, ok := matches[key]
+		if !ok {
+			m = matchMember(key, members, mappings)
+			matches[key] = m
+		}
+		switch {
+		case m.skipped:
+			issue(BulkSyncStatusRejected, "skipped in review")
+			continue
+		case m.memberID == "":
+			r, ok := review[key]
+			if !ok {
+				r = &ImportAttendanceReview{Name: row.name, Candidates: m.candidates}
+				review[key] = r
+			}
+			r.Rows++
+			issue(ImportStatusUnmatched, "no member matches this name")
+			continue
+		case m.similarity < 1:
+			f, ok := fuzzy[key]
+			if !ok {
+				f = &ImportAttendanceMatch{Name: row.name, MemberID: m.memberID, MemberName: m.memberName, Similarity: m.similarity}
+				fuzzy[key] = f
+			}
+			f.Rows++
+		}
+
+		day, err := parseImportDate(row.date)
+		if err != nil {
+			issue(BulkSyncStatusRejected, "date must be YYYY-MM-DD or D/M/YYYY")
+			continue
+		}
+		classTypeID, ok := classTypeIDs[normaliseName(row.class)]
+		if !ok {
+			issue(BulkSyncStatusRejected, fmt.Sprintf("unknown class %q", row.class))
+			continue
+		}
+		sched, reason := findImportSchedule(schedules, classTypeID, day, row.time)
+		if reason != "" {
+			issue(BulkSyncStatusRejected, reason)
+			continue
+		}
+
+		classDate := day.Format("2006-01-02")
+		dedupe := m.memberID + "|" + sched.ID + "|" + classDate
+		if seen[dedupe] {
+			result.Duplicate++
+			continue
+		}
+		seen[dedupe] = true
+		existing, err := deps.AttendanceStore.ListByMemberIDAndDate(ctx, m.memberID, classDate)
+		if err != nil {
+			return ImportAttendanceResult{}, err
+		}
+		if attendedSchedule(existing, sched.ID) {
+			result.Duplicate++
+			continue
+		}
+
+		start, _ := time.Parse("15:04", sched.StartTime)
+		matHours, _ := sched.DurationHours()
+		a := attendance.Attendance{
+			ID:          deps.GenerateID(),
+			MemberID:    m.memberID,
+			CheckInTime: time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, loc),
+			ScheduleID:  sched.ID,
+			ClassDate:   classDate,
+			MatHours:    matHours,
+			LocationID:  sched.LocationID,
+		}
+		if err := a.Validate(); err != nil {
+			issue(BulkSyncStatusRejected, err.Error())
+			continue
+		}
+		if !input.DryRun {
+			if err := deps.AttendanceStore.Save(ctx, a); err != nil {
+				return ImportAttendanceResult{}, err
+			}
+		}
+		result.Created++
+	}
+
+	for _, r := range review {
+		result.Review = append(result.Review, *r)
+	}
+	sort.Slice(result.Review, func(i, j int) bool {
+		if result.Review[i].Rows != result.Review[j].Rows {
+			return result.Review[i].Rows > result.Review[j].Rows
+		}
+		return result.Review[i].Name < result.Review[j].Name
+	})
+	for _, f := range fuzzy {
+		result.Matches = append(result.Matches, *f)
+	}
+	sort.Slice(result.Matches, func(i, j int) bool { return result.Matches[i].Name < result.Matches[j].Name })
+
+	slog.InfoContext(ctx, "attendance_import", "admin", input.ActorID, "dry_run", input.DryRun, "total", result.Total,
+		"created", result.Created, "duplicate", result.Duplicate, "rejected", result.Rejected, "unmatched", result.Unmatched)
+	return result, nil
+}
+
+// importAttendanceRow is one data row of the legacy CSV.
+type importAttendanceRow struct {
+	line                    int
+	name, date, class, time string
+}
+
+// readAttendanceCSV reads the legacy CSV, checking its columns and size.
+func readAttendanceCSV(r io.Reader) ([]importAttendanceRow, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: the file has no header row", ErrInvalidAttendanceImport)
+	}
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.ToUpper(strings.TrimSpace(h))] = i
+	}
+	for _, required := range []string{"NAME", "DATE", "CLASS"} {
+		if _, ok := cols[required]; !ok {
+			return nil, fmt.Errorf("%w: CSV missing required column: %s", ErrInvalidAttendanceImport, required)
+		}
+	}
+	get := func(record []string, col string) string {
+		i, ok := cols[col]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []importAttendanceRow
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidAttendanceImport, line, err)
+		}
+		if len(rows) == MaxImportAttendanceRows {
+			return nil, fmt.Errorf("%w: more than %d rows; split the file", ErrInvalidAttendanceImport, MaxImportAttendanceRows)
+		}
+		rows = append(rows, importAttendanceRow{line: line, name: get(record, "NAME"), date: get(record, "DATE"), class: get(record, "CLASS"), time: get(record, "TIME")})
+	}
+	return rows, nil
+}
+
+// normaliseName lower-cases a name and collapses its whitespace.
+func normaliseName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// matchMember resolves a normalised legacy name: a review mapping wins, then a unique exact
+// name, then the only member at least ImportAutoMatchSimilarity alike. Anything else is
+// left unmatched with the closest members as candidates.
+func matchMember(name string, members []member.Member, mappings map[string]string) *memberMatch {
+	if id, ok := mappings[name]; ok {
+		if id == "" {
+			return &memberMatch{skipped: true}
+		}
+		for _, m := range members {
+			if m.ID == id {
+				return &memberMatch{memberID: m.ID, memberName: m.Name, similarity: 1}
+			}
+		}
+	}
+
+	var candidates []ImportAttendanceCandidate
+	for _, m := range members {
+		score := textmatch.Similarity(name, normaliseName(m.Name))
+		if score >= ImportCandidateSimilarity {
+			candidates = append(candidates, ImportAttendanceCandidate{MemberID: m.ID, Name: m.Name, Similarity: score})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Similarity > candidates[j].Similarity })
+
+	if len(candidates) > 0 && candidates[0].Similarity >= ImportAutoMatchSimilarity &&
+		(len(candidates) == 1 || candidates[1].Similarity < ImportAutoMatchSimilarity) {
+		best := candidates[0]
+		return &memberMatch{memberID: best.MemberID, memberName: best.Name, similarity: best.Similarity}
+	}
+	if len(candidates) > ImportMaxCandidates {
+		candidates = candidates[:ImportMaxCandidates]
+	}
+	return &memberMatch{candidates: candidates}
+}
+
+// parseImportDate accepts ISO dates and the D/M/YYYY dates NZ spreadsheets export.
+func parseImportDate(value string) (time.Time, error) {
+	if day, err := time.Parse("2006-01-02", value); err == nil {
+		return day, nil
+	}
+	return time.Parse("2/1/2006", value)
+}
+
+// findImportSchedule finds the weekly class of the class type on the date's weekday,
+// using startTime to choose when the class runs more than once that day. It returns a
+// reason when there is no single such class.
+func findImportSchedule(schedules []schedule.Schedule, classTypeID string, day time.Time, startTime string) (schedule.Schedule, string) {
+	weekday := strings.ToLower(day.Weekday().String())
+	var found []schedule.Schedule
+	for _, s := range schedules {
+		if s.ClassTypeID == classTypeID && s.Day == weekday && (startTime == "" || s.StartTime == startTime) {
+			found = append(found, s)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return schedule.Schedule{}, "no such class on " + day.Weekday().String()
+	case 1:
+		return found[0], ""
+	default:
+		return schedule.Schedule{}, "the class runs more than once on " + day.Weekday().String() + "; add a TIME column"
+	}
+}
+
+// attendedSchedule reports whether any of the attendance is for the schedule.
+func attendedSchedule(existing []attendance.Attendance, scheduleID string) bool {
+	for _, a := range existing {
+		if a.ScheduleID == scheduleID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/schedule"
+)
+
+// --- Mock stores for attendance import tests ---
+
+type mockImportMemberStore struct {
+	members []member.Member
+}
+
+// List returns all members.
+// PRE: none
+// POST: Returns the members
+func (m *mockImportMemberStore) List(_ context.Context, _ memberStore.ListFilter) ([]member.Member, error) {
+	return m.members, nil
+}
+
+type mockImportClassTypeStore struct {
+	classTypes []classtype.ClassType
+}
+
+// List returns all class types.
+// PRE: none
+// POST: Returns the class types
+func (m *mockImportClassTypeStore) List(_ context.Context) ([]classtype.ClassType, error) {
+	return m.classTypes, nil
+}
+
+type mockImportScheduleStore struct {
+	schedules []schedule.Schedule
+}
+
+// List returns all schedules.
+// PRE: none
+// POST: Returns the schedules
+func (m *mockImportScheduleStore) List(_ context.Context) ([]schedule.Schedule, error) {
+	return m.schedules, nil
+}
+
+type mockImportAttendanceStore struct {
+	saved []attendance.Attendance
+}
+
+// Save stores the attendance.
+// PRE: a is valid
+// POST: The attendance is stored
+func (m *mockImportAttendanceStore) Save(_ context.Context, a attendance.Attendance) error {
+	m.saved = append(m.saved, a)
+	return nil
+}
+
+// ListByMemberIDAndDate returns the member's attendance on the date.
+// PRE: memberID and date are non-empty
+// POST: Returns matching attendance
+func (m *mockImportAttendanceStore) ListByMemberIDAndDate(_ context.Context, memberID, date string) ([]attendance.Attendance, error) {
+	var out []attendance.Attendance
+	for _, a := range m.saved {
+		if a.MemberID == memberID && a.ClassDate == date {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+// ListByMemberScheduleAndClassDate returns the member's attendance for the class on the date.
+// PRE: memberID and classDate are non-empty
+// POST: Returns matching attendance
+func (m *mockImportAttendanceStore) ListByMemberScheduleAndClassDate(_ context.Context, memberID, scheduleID, classDate string) ([]attendance.Attendance, error) {
+	var out []attendance.Attendance
+	for _, a := range m.saved {
+		if a.MemberID == memberID && a.ScheduleID == scheduleID && a.ClassDate == classDate {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+// TestExecuteImportAttendance verifies exact and similar names are matched, unknown names are
+// held for review, review mappings resolve them, and re-running the import adds nothing twice.
+func TestExecuteImportAttendance(t *testing.T) {
+	ctx := context.Background()
+	n := 0
+	store := &mockImportAttendanceStore{}
+	deps := ImportAttendanceDeps{
+		MemberStore: &mockImportMemberStore{members: []member.Member{
+			{ID: "m1", Name: "John Smith"},
+			{ID: "m2", Name: "Aroha Ngata"},
+			{ID: "m3", Name: "Mere Walker"},
+			{ID: "m4", Name: "Mere Walters"},
+		}},
+		ClassTypeStore: &mockImportClassTypeStore{classTypes: []classtype.ClassType{{ID: "ct-gi", Name: "Fundamentals Gi"}}},
+		ScheduleStore: &mockImportScheduleStore{schedules: []schedule.Schedule{
+			{ID: "s-mon", ClassTypeID: "ct-gi", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:30", LocationID: "loc-1"},
+			{ID: "s-wed-am", ClassTypeID: "ct-gi", Day: schedule.Wednesday, StartTime: "06:30", EndTime: "07:30"},
+			{ID: "s-wed-pm", ClassTypeID: "ct-gi", Day: schedule.Wednesday, StartTime: "18:00", EndTime: "19:00"},
+		}},
+		AttendanceStore: store,
+		GenerateID:      func() string { n++; return fmt.Sprintf("id-%d", n) },
+		Now:             func() time.Time { return time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC) },
+	}
+	csv := `Name,Date,Class,Time
+john smith,2024-03-04,Fundamentals Gi,
+Jon Smith,11/3/2024,fundamentals gi,
+Aroha Ngata,2024-03-04,Fundamentals Gi,
+Aroha Ngata,2024-03-04,Fundamentals Gi,
+M Walker,2024-03-04,Fundamentals Gi,
+Aroha Ngata,2024-03-06,Fundamentals Gi,
+Aroha Ngata,2024-03-06,Fundamentals Gi,06:30
+Aroha Ngata,2024-03-05,Fundamentals Gi,
+Aroha Ngata,2024-03-04,Yoga,
+`
+
+	dry, err := ExecuteImportAttendance(ctx, ImportAttendanceInput{Reader: strings.NewReader(csv), DryRun: true}, deps)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(store.saved) != 0 {
+		t.Fatalf("dry run saved %d rows", len(store.saved))
+	}
+	if dry.Total != 9 || dry.Created != 4 || dry.Duplicate != 1 || dry.Unmatched != 1 || dry.Rejected != 3 {
+		t.Errorf("dry run counts = %+v", dry)
+	}
+	if len(dry.Matches) != 1 || dry.Matches[0].Name != "Jon Smith" || dry.Matches[0].MemberID != "m1" {
+		t.Errorf("expected Jon Smith matched to m1 by similarity, got %+v", dry.Matches)
+	}
+	if len(dry.Review) != 1 || dry.Review[0].Name != "M Walker" || len(dry.Review[0].Candidates) != 2 {
+		t.Fatalf("expected M Walker held for review between two members, got %+v", dry.Review)
+	}
+
+	res, err := ExecuteImportAttendance(ctx, ImportAttendanceInput{
+		Reader:   strings.NewReader(csv),
+		Mappings: map[string]string{"m  walker": "m3"},
+	}, deps)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if res.Created != 5 || res.Unmatched != 0 || len(store.saved) != 5 {
+		t.Errorf("expected the reviewed row imported too, got %+v with %d saved", res, len(store.saved))
+	}
+	for _, a := range store.saved {
+		if a.MemberID == "m1" && a.ClassDate == "2024-03-04" && (a.ScheduleID != "s-mon" || a.MatHours != 1.5 || a.LocationID != "loc-1" || a.CheckInTime.Hour() != 18) {
+			t.Errorf("unexpected attendance %+v", a)
+		}
+	}
+
+	again, err := ExecuteImportAttendance(ctx, ImportAttendanceInput{Reader: strings.NewReader(csv), Mappings: map[string]string{"M Walker": "m3"}}, deps)
+	if err != nil || again.Created != 0 || again.Duplicate != 6 || len(store.saved) != 5 {
+		t.Errorf("re-run: expected everything a duplicate, got %+v, %v", again, err)
+	}
+
+	t.Run("a name skipped in review is rejected", func(t *testing.T) {
+		res, _ := ExecuteImportAttendance(ctx, ImportAttendanceInput{Reader: strings.NewReader(csv), Mappings: map[string]string{"M Walker": ""}, DryRun: true}, deps)
+		if res.Unmatched != 0 || res.Rejected != 4 {
+			t.Errorf("expected the skipped row rejected, got %+v", res)
+		}
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		_, err := ExecuteImportAttendance(ctx, ImportAttendanceInput{Reader: strings.NewReader("Name,Date\nx,2024-01-01\n")}, deps)
+		if !errors.Is(err, ErrInvalidAttendanceImport) {
+			t.Errorf("err = %v, want ErrInvalidAttendanceImport", err)
+		}
+	})
+}
@@ -0,0 +1,444 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/audit"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/member"
+)
+
+// MaxImportBeltRows caps one belt import; every belt and stripe of a large club fits.
+const MaxImportBeltRows = 5000
+
+// importBeltVoidReason is recorded on inferred stripes an import replaces.
+const importBeltVoidReason = "Replaced by historic belt import"
+
+// ErrInvalidBeltImport is returned when the CSV as a whole cannot be imported.
+var ErrInvalidBeltImport = errors.New("invalid belt import")
+
+// ImportBeltsMemberStore defines the member store interface needed for a belt import.
+type ImportBeltsMemberStore interface {
+	List(ctx context.Context, filter memberStore.ListFilter) ([]member.Member, error)
+}
+
+// ImportBeltsRecordStore defines the grading record store interface needed for a belt import.
+type ImportBeltsRecordStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]grading.Record, error)
+	Save(ctx context.Context, r grading.Record) error
+}
+
+// ImportBeltsRow is one belt or stripe a member earned before the club used the app.
+type ImportBeltsRow struct {
+	Row      int    // 1-based line number in the file, header included
+	MemberID string // optional: wins over Email and Name
+	Email    string // optional: wins over Name
+	Name     string
+	Belt     string
+	Stripe   int
+	Date     string // YYYY-MM-DD or D/M/YYYY
+}
+
+// ImportBeltsInput carries the rows of a belt import.
+type ImportBeltsInput struct {
+	Rows   []ImportBeltsRow
+	DryRun bool
+	Actor  BackfillActor
+}
+
+// ImportBeltsDeps holds dependencies for ImportBelts.
+type ImportBeltsDeps struct {
+	MemberStore ImportBeltsMemberStore
+	RecordStore ImportBeltsRecordStore
+	AuditStore  BackfillAuditStore // optional: nil skips the audit event
+	GenerateID  func() string
+	Now         func() time.Time
+}
+
+// ImportBeltsRowResult reports how one row was imported.
+type ImportBeltsRowResult struct {
+	Row        int
+	MemberID   string
+	MemberName string
+	Belt       string
+	Stripe     int
+	Date       string // YYYY-MM-DD once parsed
+	Status     string // created, duplicate or rejected
+	Reason     string
+}
+
+// ImportBeltsResult summarises a belt import. Re-running the same file reports every row
+// already imported as a duplicate.
+type ImportBeltsResult struct {
+	Total     int
+	Created   int
+	Duplicate int
+	Rejected  int
+	DryRun    bool
+	Rows      []ImportBeltsRowResult   // in file order
+	Voided    []grading.Record         // inferred stripes the import replaces
+	Records   []grading.Record         // records created; empty for a dry run
+	byMember  map[string][]int         // member ID -> indexes into Rows of matched rows
+	members   map[string]member.Member // matched members
+}
+
+// ExecuteImportBelts imports the belts and stripes members earned before the club used the
+// app as backdated grading records with method import. Rows are matched to members by ID,
+// email or exact name. Each member's imported ranks, together with the records they already
+// have, must rise through their program's belt order as the dates go on. Stripes inferred
+// from mat hours at or below a member's highest imported rank are voided, so the member's
+// current rank is the imported one and inference never proposes stripes they already hold.
+// PRE: input.Actor.AccountID is an admin
+// POST: Valid rows are saved unless DryRun; every row is reported as created, duplicate or rejected
+// INVARIANT: A bad row never aborts the rest of the import
+func ExecuteImportBelts(ctx context.Context, input ImportBeltsInput, deps ImportBeltsDeps) (ImportBeltsResult, error) {
+	if len(input.Rows) > MaxImportBeltRows {
+		return ImportBeltsResult{}, fmt.Errorf("%w: more than %d rows; split the file", ErrInvalidBeltImport, MaxImportBeltRows)
+	}
+	members, err := deps.MemberStore.List(ctx, memberStore.ListFilter{Limit: 10000})
+	if err != nil {
+		return ImportBeltsResult{}, err
+	}
+	now := deps.Now()
+	result := ImportBeltsResult{
+		Total: len(input.Rows), DryRun: input.DryRun,
+		Rows: make([]ImportBeltsRowResult, 0, len(input.Rows)), Voided: []grading.Record{}, Records: []grading.Record{},
+		byMember: map[string][]int{}, members: map[string]member.Member{},
+	}
+	dates := make([]time.Time, len(input.Rows))
+	lookup := newBeltImportMembers(members)
+	for i, row := range input.Rows {
+		res := ImportBeltsRowResult{Row: row.Row, Belt: strings.ToLower(strings.TrimSpace(row.Belt)), Stripe: row.Stripe, Status: BulkSyncStatusCreated}
+		m, reason := lookup.match(row)
+		switch {
+		case reason != "":
+			res.Status, res.Reason = BulkSyncStatusRejected, reason
+		default:
+			res.MemberID, res.MemberName = m.ID, m.Name
+			dates[i], res.Reason = parseBeltImportRow(res, row.Date, m.Program, now)
+			if res.Reason != "" {
+				res.Status = BulkSyncStatusRejected
+				break
+			}
+			res.Date = dates[i].Format("2006-01-02")
+			result.byMember[m.ID] = append(result.byMember[m.ID], i)
+			result.members[m.ID] = m
+		}
+		result.Rows = append(result.Rows, res)
+	}
+
+	memberIDs := make([]string, 0, len(result.byMember))
+	for id := range result.byMember {
+		memberIDs = append(memberIDs, id)
+	}
+	sort.Strings(memberIDs)
+	for _, id := range memberIDs {
+		if err := importMemberBelts(ctx, id, dates, input, now, &result, deps); err != nil {
+			return ImportBeltsResult{}, err
+		}
+	}
+	for _, res := range result.Rows {
+		switch res.Status {
+		case BulkSyncStatusCreated:
+			result.Created++
+		case BulkSyncStatusDuplicate:
+			result.Duplicate++
+		default:
+			result.Rejected++
+		}
+	}
+
+	if !input.DryRun && result.Created > 0 {
+		importBeltsAudit(ctx, input, result, deps)
+	}
+	slog.InfoContext(ctx, "grading_event", "event", "belt_import", "admin_id", input.Actor.AccountID, "dry_run", input.DryRun, "total", result.Total,
+		"created", result.Created, "duplicate", result.Duplicate, "rejected", result.Rejected, "voided", len(result.Voided))
+	return result, nil
+}
+
+// importMemberBelts checks one member's rows against each other and their existing records,
+// then saves the new records and voids the inferred stripes they replace.
+func importMemberBelts(ctx context.Context, memberID string, dates []time.Time, input ImportBeltsInput, now time.Time, result *ImportBeltsResult, deps ImportBeltsDeps) error {
+	m := result.members[memberID]
+	existing, err := deps.RecordStore.ListByMemberID(ctx, memberID)
+	if err != nil {
+		return err
+	}
+	var fixed, inferred []grading.Record
+	for _, r := range existing {
+		if r.Method == grading.MethodInferred {
+			inferred = append(inferred, r)
+		} else {
+			fixed = append(fixed, r)
+		}
+	}
+
+	// Rows are checked in file order, so a mistyped row is the one rejected rather than the
+	// correct rows around it.
+	rows := result.byMember[memberID]
+	var created []grading.Record
+	for _, i := range rows {
+		res := &result.Rows[i]
+		record := grading.Record{
+			ID:         deps.GenerateID(),
+			MemberID:   memberID,
+			Belt:       res.Belt,
+			Stripe:     res.Stripe,
+			PromotedAt: dates[i],
+			ProposedBy: input.Actor.AccountID,
+			ApprovedBy: input.Actor.AccountID,
+			Method:     grading.MethodImport,
+		}
+		rank := grading.Rank{Belt: record.Belt, Stripe: record.Stripe}
+		if dup, ok := sameRank(m.Program, rank, fixed, created); ok {
+			res.Status, res.Reason = BulkSyncStatusDuplicate, "already recorded as "+describeGradingRecord(dup)
+			continue
+		}
+		if clash, ok := outOfOrderRank(m.Program, record, fixed, created); ok {
+			res.Status, res.Reason = BulkSyncStatusRejected, "out of order with "+describeGradingRecord(clash)
+			continue
+		}
+		if err := record.Validate(); err != nil {
+			res.Status, res.Reason = BulkSyncStatusRejected, err.Error()
+			continue
+		}
+		created = append(created, record)
+	}
+	if len(created) == 0 {
+		return nil
+	}
+
+	top := grading.Rank{Belt: created[0].Belt, Stripe: created[0].Stripe}
+	for _, r := range created[1:] {
+		if rank := (grading.Rank{Belt: r.Belt, Stripe: r.Stripe}); grading.CompareRanks(m.Program, rank, top) > 0 {
+			top = rank
+		}
+	}
+	var voided []grading.Record
+	for _, r := range inferred {
+		if grading.CompareRanks(m.Program, grading.Rank{Belt: r.Belt, Stripe: r.Stripe}, top) <= 0 {
+			r.Voided = true
+			r.CorrectedBy = input.Actor.AccountID
+			r.CorrectedAt = now
+			r.CorrectionReason = importBeltVoidReason
+			voided = append(voided, r)
+		}
+	}
+
+	if !input.DryRun {
+		for _, r := range created {
+			if err := deps.RecordStore.Save(ctx, r); err != nil {
+				return err
+			}
+		}
+		for _, r := range voided {
+			if err := deps.RecordStore.Save(ctx, r); err != nil {
+				return err
+			}
+		}
+		result.Records = append(result.Records, created...)
+	}
+	result.Voided = append(result.Voided, voided...)
+	return nil
+}
+
+// sameRank returns the record, existing or imported earlier in the file, already holding rank.
+func sameRank(program string, rank grading.Rank, groups ...[]grading.Record) (grading.Record, bool) {
+	for _, records := range groups {
+		for _, r := range records {
+			if grading.CompareRanks(program, grading.Rank{Belt: r.Belt, Stripe: r.Stripe}, rank) == 0 {
+				return r, true
+			}
+		}
+	}
+	return grading.Record{}, false
+}
+
+// outOfOrderRank returns a record that contradicts record: a higher rank earned earlier or a
+// lower rank earned later. Records on the same day never clash.
+func outOfOrderRank(program string, record grading.Record, groups ...[]grading.Record) (grading.Record, bool) {
+	rank := grading.Rank{Belt: record.Belt, Stripe: record.Stripe}
+	for _, records := range groups {
+		for _, r := range records {
+			cmp := grading.CompareRanks(program, grading.Rank{Belt: r.Belt, Stripe: r.Stripe}, rank)
+			if (r.PromotedAt.Before(record.PromotedAt) && cmp > 0) || (r.PromotedAt.After(record.PromotedAt) && cmp < 0) {
+				return r, true
+			}
+		}
+	}
+	return grading.Record{}, false
+}
+
+// parseBeltImportRow checks a row's belt, stripe and date, returning the date or why the row
+// is rejected.
+func parseBeltImportRow(res ImportBeltsRowResult, date, program string, now time.Time) (time.Time, string) {
+	if !grading.IsValidBelt(res.Belt) {
+		return time.Time{}, fmt.Sprintf("unknown belt %q", res.Belt)
+	}
+	if !grading.InProgression(program, res.Belt) {
+		return time.Time{}, res.Belt + " is not a belt in the " + program + " program"
+	}
+	if res.Stripe < 0 || res.Stripe > grading.MaxStripes {
+		return time.Time{}, grading.ErrInvalidStripe.Error()
+	}
+	day, err := parseImportDate(strings.TrimSpace(date))
+	if err != nil {
+		return time.Time{}, "date must be YYYY-MM-DD or D/M/YYYY"
+	}
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, now.Location())
+	if day.After(now) {
+		return time.Time{}, "date is in the future"
+	}
+	return day, ""
+}
+
+// beltImportMembers finds the member a row names.
+type beltImportMembers struct {
+	byID    map[string]member.Member
+	byEmail map[string][]member.Member
+	byName  map[string][]member.Member
+}
+
+// newBeltImportMembers indexes members by ID, email and normalised name.
+func newBeltImportMembers(members []member.Member) beltImportMembers {
+	l := beltImportMembers{byID: map[string]member.Member{}, byEmail: map[string][]member.Member{}, byName: map[string][]member.Member{}}
+	for _, m := range members {
+		l.byID[m.ID] = m
+		if m.Email != "" {
+			email := strings.ToLower(m.Email)
+			l.byEmail[email] = append(l.byEmail[email], m)
+		}
+		name := normaliseName(m.Name)
+		l.byName[name] = append(l.byName[name], m)
+	}
+	return l
+}
+
+// match resolves a row to one member by ID, then email, then exact name. Belts are too
+// important to guess, so names are not matched by similarity.
+func (l beltImportMembers) match(row ImportBeltsRow) (member.Member, string) {
+	if id := strings.TrimSpace(row.MemberID); id != "" {
+		if m, ok := l.byID[id]; ok {
+			return m, ""
+		}
+		return member.Member{}, "no member has ID " + id
+	}
+	if email := strings.ToLower(strings.TrimSpace(row.Email)); email != "" {
+		if found := l.byEmail[email]; len(found) == 1 {
+			return found[0], ""
+		}
+		return member.Member{}, "no single member has email " + email
+	}
+	name := normaliseName(row.Name)
+	if name == "" {
+		return member.Member{}, "name is required"
+	}
+	switch found := l.byName[name]; len(found) {
+	case 0:
+		return member.Member{}, "no member is called " + strings.TrimSpace(row.Name)
+	case 1:
+		return found[0], ""
+	default:
+		return member.Member{}, "more than one member is called " + strings.TrimSpace(row.Name) + "; add an EMAIL column"
+	}
+}
+
+// ReadBeltImportCSV reads a belt import CSV. It needs BELT and DATE columns and one of
+// MEMBER_ID, EMAIL or NAME; STRIPE is optional and blank means no stripes.
+// PRE: r is a CSV with a header row
+// POST: Returns the rows, or ErrInvalidBeltImport describing what is wrong with the file
+func ReadBeltImportCSV(r io.Reader) ([]ImportBeltsRow, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: the file has no header row", ErrInvalidBeltImport)
+	}
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.ToUpper(strings.TrimSpace(h))] = i
+	}
+	for _, required := range []string{"BELT", "DATE"} {
+		if _, ok := cols[required]; !ok {
+			return nil, fmt.Errorf("%w: CSV missing required column: %s", ErrInvalidBeltImport, required)
+		}
+	}
+	_, hasID := cols["MEMBER_ID"]
+	_, hasEmail := cols["EMAIL"]
+	_, hasName := cols["NAME"]
+	if !hasID && !hasEmail && !hasName {
+		return nil, fmt.Errorf("%w: CSV needs a NAME, EMAIL or MEMBER_ID column", ErrInvalidBeltImport)
+	}
+	get := func(record []string, col string) string {
+		i, ok := cols[col]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []ImportBeltsRow
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidBeltImport, line, err)
+		}
+		if len(rows) == MaxImportBeltRows {
+			return nil, fmt.Errorf("%w: more than %d rows; split the file", ErrInvalidBeltImport, MaxImportBeltRows)
+		}
+		stripe := 0
+		if s := get(record, "STRIPE"); s != "" {
+			if stripe, err = strconv.Atoi(s); err != nil {
+				stripe = -1 // rejected with the row
+			}
+		}
+		rows = append(rows, ImportBeltsRow{
+			Row: line, MemberID: get(record, "MEMBER_ID"), Email: get(record, "EMAIL"), Name: get(record, "NAME"),
+			Belt: get(record, "BELT"), Stripe: stripe, Date: get(record, "DATE"),
+		})
+	}
+	return rows, nil
+}
+
+// importBeltsAudit records a belt import in the audit log as one event. A failure is logged,
+// not returned: the records have already been saved.
+func importBeltsAudit(ctx context.Context, input ImportBeltsInput, result ImportBeltsResult, deps ImportBeltsDeps) {
+	if deps.AuditStore == nil {
+		return
+	}
+	ids := make([]string, 0, len(result.Records))
+	for _, r := range result.Records {
+		ids = append(ids, r.ID)
+	}
+	voided := make([]string, 0, len(result.Voided))
+	for _, r := range result.Voided {
+		voided = append(voided, r.ID)
+	}
+	metadata, _ := json.Marshal(map[string]string{
+		"records": strings.Join(ids, ","),
+		"voided":  strings.Join(voided, ","),
+	})
+	event := audit.NewEvent(input.Actor.AccountID, input.Actor.Email, input.Actor.Role, audit.CategoryMember, audit.ActionCreate).
+		WithResource("grading_record", ids[0]).
+		WithDescription("Imported "+strconv.Itoa(len(ids))+" historic grading records for "+strconv.Itoa(len(result.members))+" members; voided "+strconv.Itoa(len(voided))+" inferred stripes").
+		WithRequest(input.Actor.IPAddress, input.Actor.UserAgent).
+		WithMetadata(string(metadata))
+	if err := deps.AuditStore.Save(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "grading_event", "event", "belt_import_audit_failed", "error", err)
+	}
+}
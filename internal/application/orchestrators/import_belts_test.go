@@ -0,0 +1,148 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/member"
+)
+
+type mockImportBeltsRecordStore struct {
+	records map[string]grading.Record
+}
+
+// ListByMemberID implements ImportBeltsRecordStore.
+// PRE: none
+// POST: Returns the member's effective records
+func (m *mockImportBeltsRecordStore) ListByMemberID(_ context.Context, memberID string) ([]grading.Record, error) {
+	var list []grading.Record
+	for _, r := range m.records {
+		if r.MemberID == memberID && r.IsEffective() {
+			list = append(list, r)
+		}
+	}
+	return list, nil
+}
+
+// Save implements ImportBeltsRecordStore.
+// PRE: none
+// POST: The record is upserted
+func (m *mockImportBeltsRecordStore) Save(_ context.Context, value grading.Record) error {
+	m.records[value.ID] = value
+	return nil
+}
+
+func newImportBeltsDeps(records *mockImportBeltsRecordStore, auditStore *mockBackfillAuditStore) ImportBeltsDeps {
+	n := 0
+	return ImportBeltsDeps{
+		MemberStore: &mockImportMemberStore{members: []member.Member{
+			{ID: "m1", Name: "Sam Smith", Email: "sam@example.com", Program: "adults", Status: member.StatusActive},
+			{ID: "m2", Name: "Alex Jones", Email: "alex.j@example.com", Program: "adults", Status: member.StatusActive},
+			{ID: "m3", Name: "Alex Jones", Email: "alex.k@example.com", Program: "adults", Status: member.StatusArchived},
+			{ID: "m4", Name: "Kai Kid", Program: "kids", Status: member.StatusActive},
+		}},
+		RecordStore: records,
+		AuditStore:  auditStore,
+		GenerateID:  func() string { n++; return fmt.Sprintf("imp-%d", n) },
+		Now:         func() time.Time { return time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC) },
+	}
+}
+
+const importBeltsCSV = `NAME,EMAIL,BELT,STRIPE,DATE
+Sam Smith,,white,2,2019-06-01
+sam smith,,blue,,1/3/2021
+Sam Smith,,blue,2,2022-08-15
+Sam Smith,,purple,0,2020-01-01
+Alex Jones,,blue,0,2023-01-01
+Alex Jones,ALEX.K@example.com,blue,1,2023-05-01
+Kai Kid,,purple,0,2023-01-01
+Nobody,,white,1,2023-01-01
+Sam Smith,,blue,1,2030-01-01
+Sam Smith,,blue,x,2022-01-01
+`
+
+// TestExecuteImportBelts verifies rows are matched by name or email, checked against the
+// member's program and belt order, saved as import records, and replace inferred stripes.
+func TestExecuteImportBelts(t *testing.T) {
+	rows, err := ReadBeltImportCSV(strings.NewReader(importBeltsCSV))
+	if err != nil {
+		t.Fatalf("ReadBeltImportCSV: %v", err)
+	}
+	records := &mockImportBeltsRecordStore{records: map[string]grading.Record{
+		"inf-1": {ID: "inf-1", MemberID: "m1", Belt: grading.BeltWhite, Stripe: 3, PromotedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Method: grading.MethodInferred},
+		"inf-2": {ID: "inf-2", MemberID: "m1", Belt: grading.BeltBlue, Stripe: 3, PromotedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Method: grading.MethodInferred},
+	}}
+	auditStore := &mockBackfillAuditStore{}
+	deps := newImportBeltsDeps(records, auditStore)
+	actor := BackfillActor{AccountID: "admin-1", Role: "admin"}
+
+	preview, err := ExecuteImportBelts(context.Background(), ImportBeltsInput{Rows: rows, DryRun: true, Actor: actor}, deps)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if preview.Created != 4 || len(records.records) != 2 || len(auditStore.events) != 0 {
+		t.Fatalf("dry run created %d with %d records and %d audit events; want 4 previewed and nothing saved", preview.Created, len(records.records), len(auditStore.events))
+	}
+
+	res, err := ExecuteImportBelts(context.Background(), ImportBeltsInput{Rows: rows, Actor: actor}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []struct {
+		status, memberID, reason string
+	}{
+		{BulkSyncStatusCreated, "m1", ""},
+		{BulkSyncStatusCreated, "m1", ""},
+		{BulkSyncStatusCreated, "m1", ""},
+		{BulkSyncStatusRejected, "m1", "out of order"},
+		{BulkSyncStatusRejected, "", "more than one member"},
+		{BulkSyncStatusCreated, "m3", ""},
+		{BulkSyncStatusRejected, "m4", "not a belt in the kids program"},
+		{BulkSyncStatusRejected, "", "no member is called"},
+		{BulkSyncStatusRejected, "m1", "future"},
+		{BulkSyncStatusRejected, "m1", "stripe"},
+	}
+	for i, w := range want {
+		got := res.Rows[i]
+		if got.Row != i+2 || got.Status != w.status || got.MemberID != w.memberID || !strings.Contains(got.Reason, w.reason) {
+			t.Errorf("Rows[%d] = %+v, want %s for %q (%q)", i, got, w.status, w.memberID, w.reason)
+		}
+	}
+	if res.Created != 4 || res.Rejected != 6 || len(res.Records) != 4 {
+		t.Fatalf("created %d rejected %d records %d, want 4, 6 and 4", res.Created, res.Rejected, len(res.Records))
+	}
+	blue := records.records[res.Records[1].ID]
+	if blue.Method != grading.MethodImport || blue.ApprovedBy != "admin-1" || !blue.PromotedAt.Equal(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("blue belt record = %+v, want an import approved by the admin on 2021-03-01", blue)
+	}
+	if !records.records["inf-1"].Voided || records.records["inf-2"].Voided {
+		t.Errorf("inferred white 3 voided %v, blue 3 voided %v; want only the stripe below blue 2 replaced",
+			records.records["inf-1"].Voided, records.records["inf-2"].Voided)
+	}
+	if len(auditStore.events) != 1 {
+		t.Errorf("audit events = %d, want 1", len(auditStore.events))
+	}
+
+	// Running the same file again changes nothing.
+	again, err := ExecuteImportBelts(context.Background(), ImportBeltsInput{Rows: rows, Actor: actor}, deps)
+	if err != nil {
+		t.Fatalf("re-run: %v", err)
+	}
+	if again.Created != 0 || again.Duplicate != 4 || len(records.records) != 6 {
+		t.Errorf("re-run created %d duplicate %d with %d records; want 0, 4 and 6", again.Created, again.Duplicate, len(records.records))
+	}
+}
+
+// TestReadBeltImportCSV_MissingColumns verifies a file without the columns needed is refused.
+func TestReadBeltImportCSV_MissingColumns(t *testing.T) {
+	for _, csv := range []string{"NAME,DATE\nSam,2020-01-01\n", "BELT,DATE\nblue,2020-01-01\n", ""} {
+		if _, err := ReadBeltImportCSV(strings.NewReader(csv)); !errors.Is(err, ErrInvalidBeltImport) {
+			t.Errorf("ReadBeltImportCSV(%q) err = %v, want ErrInvalidBeltImport", csv, err)
+		}
+	}
+}
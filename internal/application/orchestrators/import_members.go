@@ -125,7 +125,7 @@ func ExecuteImportMembers(ctx context.Context, input ImportMembersInput, deps Im
 			program = domain.ProgramAdults
 		}
 		status := strings.ToLower(getCol(row, "STATUS"))
-		if status != domain.StatusActive && status != domain.StatusInactive && status != domain.StatusArchived {
+		if status != domain.StatusActive && status != domain.StatusInactive && status != domain.StatusFrozen && status != domain.StatusArchived {
 			status = domain.StatusActive
 		}
 		fee, _ := strconv.Atoi(getCol(row, "FEE"))
@@ -166,7 +166,7 @@ func ExecuteImportMembers(ctx context.Context, input ImportMembersInput, deps Im
 				existing.GradingMetric = gradingMetric
 			}
 			if err := deps.MemberStore.Save(ctx, existing); err != nil {
-				slog.Error("members_import_save_failed", "row", rowNum, "email", email, "err", err)
+				slog.ErrorContext(ctx, "members_import_save_failed", "row", rowNum, "email", email, "err", err)
 				result.Errors = append(result.Errors, ImportMembersRowError{Row: rowNum, Message: "save failed (see server log)"})
 				continue
 			}
@@ -183,7 +183,7 @@ func ExecuteImportMembers(ctx context.Context, input ImportMembersInput, deps Im
 				GradingMetric: gradingMetric,
 			}
 			if err := deps.MemberStore.Save(ctx, m); err != nil {
-				slog.Error("members_import_save_failed", "row", rowNum, "email", email, "err", err)
+				slog.ErrorContext(ctx, "members_import_save_failed", "row", rowNum, "email", email, "err", err)
 				result.Errors = append(result.Errors, ImportMembersRowError{Row: rowNum, Message: "save failed (see server log)"})
 				continue
 			}
@@ -191,7 +191,7 @@ func ExecuteImportMembers(ctx context.Context, input ImportMembersInput, deps Im
 		}
 	}
 
-	slog.Info("members_import",
+	slog.InfoContext(ctx, "members_import",
 		"admin", input.AdminAccountID,
 		"dry_run", input.DryRun,
 		"update_mode", input.UpdateMode,
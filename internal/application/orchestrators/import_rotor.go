@@ -0,0 +1,67 @@
+package orchestrators
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"workshop/internal/domain/rotor"
+)
+
+// ImportRotorStore defines the rotor store interface needed to import a portable rotor.
+type ImportRotorStore interface {
+	ListRotorsByClassType(ctx context.Context, classTypeID string) ([]rotor.Rotor, error)
+	ImportRotor(ctx context.Context, r rotor.Rotor, themes []rotor.RotorTheme, topics []rotor.Topic) error
+}
+
+// ImportRotorInput carries input for the import rotor orchestrator.
+type ImportRotorInput struct {
+	ClassTypeID string // target class type; the document's own class type is ignored
+	CreatedBy   string
+	Document    rotor.Document
+}
+
+// ImportRotorDeps holds dependencies for ImportRotor.
+type ImportRotorDeps struct {
+	RotorStore ImportRotorStore
+	GenerateID func() string
+	Now        func() time.Time
+}
+
+// ExecuteImportRotor creates a new draft rotor from a portable document.
+// PRE: ClassTypeID references an existing class type; CreatedBy is non-empty
+// POST: The rotor, its themes and topics are created atomically as the class type's next version
+func ExecuteImportRotor(ctx context.Context, input ImportRotorInput, deps ImportRotorDeps) (rotor.Rotor, error) {
+	if err := input.Document.Validate(); err != nil {
+		return rotor.Rotor{}, err
+	}
+
+	existing, err := deps.RotorStore.ListRotorsByClassType(ctx, input.ClassTypeID)
+	if err != nil {
+		return rotor.Rotor{}, err
+	}
+	nextVersion := 1
+	for _, r := range existing {
+		if r.Version >= nextVersion {
+			nextVersion = r.Version + 1
+		}
+	}
+
+	r, themes, topics := input.Document.Build(rotor.Rotor{
+		ID:          deps.GenerateID(),
+		ClassTypeID: input.ClassTypeID,
+		Version:     nextVersion,
+		CreatedBy:   input.CreatedBy,
+		CreatedAt:   deps.Now(),
+	}, deps.GenerateID)
+	if err := r.Validate(); err != nil {
+		return rotor.Rotor{}, err
+	}
+	if err := deps.RotorStore.ImportRotor(ctx, r, themes, topics); err != nil {
+		return rotor.Rotor{}, err
+	}
+
+	slog.InfoContext(ctx, "rotor_event", "event", "rotor_imported", "rotor_id", r.ID, "class_type_id", r.ClassTypeID,
+		"version", r.Version, "themes", len(themes), "topics", len(topics), "by", input.CreatedBy)
+	return r, nil
+}
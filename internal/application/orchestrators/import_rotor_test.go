@@ -0,0 +1,91 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"workshop/internal/domain/rotor"
+)
+
+type mockImportRotorStore struct {
+	existing []rotor.Rotor
+	rotor    rotor.Rotor
+	themes   []rotor.RotorTheme
+	topics   []rotor.Topic
+	calls    int
+}
+
+// ListRotorsByClassType implements ImportRotorStore.
+// PRE: none
+// POST: returns the preset rotors
+func (m *mockImportRotorStore) ListRotorsByClassType(_ context.Context, _ string) ([]rotor.Rotor, error) {
+	return m.existing, nil
+}
+
+// ImportRotor implements ImportRotorStore.
+// PRE: none
+// POST: records the imported structure
+func (m *mockImportRotorStore) ImportRotor(_ context.Context, r rotor.Rotor, themes []rotor.RotorTheme, topics []rotor.Topic) error {
+	m.calls++
+	m.rotor, m.themes, m.topics = r, themes, topics
+	return nil
+}
+
+func importRotorDeps(store *mockImportRotorStore) ImportRotorDeps {
+	n := 0
+	return ImportRotorDeps{
+		RotorStore: store,
+		GenerateID: func() string { n++; return fmt.Sprintf("id-%d", n) },
+		Now:        fixedNow,
+	}
+}
+
+// TestExecuteImportRotor_CreatesNextDraftVersion verifies the import lands as a draft with the next version.
+func TestExecuteImportRotor_CreatesNextDraftVersion(t *testing.T) {
+	store := &mockImportRotorStore{existing: []rotor.Rotor{{ID: "old", Version: 3, Status: rotor.StatusActive}}}
+	doc := rotor.Document{
+		FormatVersion: rotor.DocumentFormatVersion,
+		Name:          "Imported",
+		ClassTypeID:   "elsewhere",
+		Themes: []rotor.DocumentTheme{{Name: "Guard", Topics: []rotor.DocumentTopic{
+			{Name: "Scissor Sweep", DurationWeeks: 2},
+		}}},
+	}
+
+	r, err := ExecuteImportRotor(context.Background(), ImportRotorInput{ClassTypeID: "ct1", CreatedBy: "coach-1", Document: doc}, importRotorDeps(store))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Version != 4 || r.Status != rotor.StatusDraft || r.ClassTypeID != "ct1" || !r.CreatedAt.Equal(fixedTime) {
+		t.Errorf("unexpected rotor: %+v", r)
+	}
+	if store.calls != 1 || len(store.themes) != 1 || len(store.topics) != 1 {
+		t.Fatalf("expected one atomic import, got calls=%d themes=%d topics=%d", store.calls, len(store.themes), len(store.topics))
+	}
+	if store.themes[0].RotorID != r.ID || store.topics[0].RotorThemeID != store.themes[0].ID {
+		t.Errorf("structure not linked: %+v %+v", store.themes, store.topics)
+	}
+}
+
+// TestExecuteImportRotor_InvalidDocumentWritesNothing verifies validation happens before any write.
+func TestExecuteImportRotor_InvalidDocumentWritesNothing(t *testing.T) {
+	store := &mockImportRotorStore{}
+	doc := rotor.Document{
+		FormatVersion: rotor.DocumentFormatVersion,
+		Name:          "Broken",
+		Themes: []rotor.DocumentTheme{{Name: "Guard", Topics: []rotor.DocumentTopic{
+			{Name: "Ok", DurationWeeks: 1},
+			{Name: "Bad", DurationWeeks: 0},
+		}}},
+	}
+
+	_, err := ExecuteImportRotor(context.Background(), ImportRotorInput{ClassTypeID: "ct1", CreatedBy: "coach-1", Document: doc}, importRotorDeps(store))
+	if !errors.Is(err, rotor.ErrInvalidDuration) {
+		t.Errorf("expected ErrInvalidDuration, got %v", err)
+	}
+	if store.calls != 0 {
+		t.Errorf("expected no writes, got %d", store.calls)
+	}
+}
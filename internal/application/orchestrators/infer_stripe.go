@@ -44,6 +44,7 @@ type InferStripeDeps struct {
 	EstimatedHoursStore InferStripeEstimatedHoursStore // optional: nil skips bulk estimates
 	GradingRecordStore  InferStripeGradingRecordStore
 	GradingConfigStore  InferStripeGradingConfigStore
+	StockStore          GradingStockStore // optional: nil leaves belt inventory untouched
 }
 
 // ExecuteInferStripe checks whether a member's stripe count should increase
@@ -125,17 +126,28 @@ func ExecuteInferStripe(ctx context.Context, memberID string, deps InferStripeDe
 		return nil // invalid record — skip
 	}
 	if err := deps.GradingRecordStore.Save(ctx, record); err != nil {
-		slog.Error("infer_stripe_error", "error", err, "member_id", memberID)
+		slog.ErrorContext(ctx, "infer_stripe_error", "error", err, "member_id", memberID)
 		return nil // best-effort, don't fail the check-in
 	}
 
-	slog.Info("grading_event", "event", "stripe_inferred",
+	slog.InfoContext(ctx, "grading_event", "event", "stripe_inferred",
 		"member_id", memberID,
 		"belt", currentBelt,
 		"old_stripe", currentStripe,
 		"new_stripe", inferredStripe,
 		"total_hours", totalHours,
 	)
+
+	if deps.StockStore != nil {
+		_, err := ExecuteConsumeGradingStock(ctx, ConsumeGradingStockInput{Record: record}, ConsumeGradingStockDeps{
+			StockStore:  deps.StockStore,
+			RecordStore: deps.GradingRecordStore,
+			Now:         time.Now,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "infer_stripe_error", "event", "stock_failed", "error", err, "member_id", memberID)
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,104 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/injury"
+)
+
+// InjuryLifecycleStore defines the store interface needed to update injuries.
+type InjuryLifecycleStore interface {
+	GetByID(ctx context.Context, id string) (injury.Injury, error)
+	Save(ctx context.Context, i injury.Injury) error
+}
+
+// InjuryRestrictionStore defines the store interface needed to check grading restrictions.
+type InjuryRestrictionStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]injury.Injury, error)
+}
+
+// UpdateInjuryInput carries input for the update injury orchestrator.
+// Nil pointer fields are left unchanged.
+type UpdateInjuryInput struct {
+	InjuryID          string
+	Status            string  // optional: active, recovering, resolved
+	Severity          *string // optional
+	ResolutionNotes   *string // optional
+	GradingRestricted *bool   // optional
+	UpdatedBy         string  // AccountID of the coach/admin making the change
+}
+
+// UpdateInjuryDeps holds dependencies for UpdateInjury.
+type UpdateInjuryDeps struct {
+	InjuryStore InjuryLifecycleStore
+	Now         func() time.Time
+}
+
+// ExecuteUpdateInjury moves an injury through its lifecycle and updates coach-managed fields.
+// PRE: InjuryID must reference an existing injury; UpdatedBy must be non-empty
+// POST: Injury status, severity, resolution notes and grading restriction updated
+func ExecuteUpdateInjury(ctx context.Context, input UpdateInjuryInput, deps UpdateInjuryDeps) (injury.Injury, error) {
+	if input.InjuryID == "" {
+		return injury.Injury{}, errors.New("injury ID is required")
+	}
+	if input.UpdatedBy == "" {
+		return injury.Injury{}, errors.New("updater is required")
+	}
+
+	inj, err := deps.InjuryStore.GetByID(ctx, input.InjuryID)
+	if err != nil {
+		return injury.Injury{}, err
+	}
+
+	now := deps.Now()
+	if input.Status != "" {
+		if err := inj.SetStatus(input.Status, now); err != nil {
+			return injury.Injury{}, err
+		}
+	} else if inj.Status == "" {
+		// Legacy injuries enter the lifecycle on their first update.
+		inj.Status = injury.StatusActive
+	}
+	if input.Severity != nil {
+		inj.Severity = strings.ToLower(strings.TrimSpace(*input.Severity))
+	}
+	if input.ResolutionNotes != nil {
+		inj.ResolutionNotes = strings.TrimSpace(*input.ResolutionNotes)
+	}
+	if input.GradingRestricted != nil {
+		inj.GradingRestricted = *input.GradingRestricted
+	}
+	inj.UpdatedAt = now
+
+	if err := inj.Validate(); err != nil {
+		return injury.Injury{}, err
+	}
+	if err := deps.InjuryStore.Save(ctx, inj); err != nil {
+		return injury.Injury{}, err
+	}
+
+	slog.InfoContext(ctx, "injury_event", "event", "injury_updated", "injury_id", inj.ID, "member_id", inj.MemberID,
+		"status", inj.Status, "severity", inj.Severity, "grading_restricted", inj.GradingRestricted, "updated_by", input.UpdatedBy)
+	return inj, nil
+}
+
+// CheckGradingInjuryRestriction returns injury.ErrGradingRestricted if the member
+// has an unresolved injury that a coach has marked as grading-restricting.
+// PRE: memberID is non-empty
+// POST: Returns nil when grading may proceed
+func CheckGradingInjuryRestriction(ctx context.Context, memberID string, store InjuryRestrictionStore) error {
+	injuries, err := store.ListByMemberID(ctx, memberID)
+	if err != nil {
+		return err
+	}
+	for _, inj := range injuries {
+		if inj.BlocksGrading() {
+			return injury.ErrGradingRestricted
+		}
+	}
+	return nil
+}
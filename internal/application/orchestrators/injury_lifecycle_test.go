@@ -0,0 +1,110 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"workshop/internal/domain/injury"
+)
+
+// mockInjuryLifecycleStore implements InjuryLifecycleStore and InjuryRestrictionStore for testing.
+type mockInjuryLifecycleStore struct {
+	injuries map[string]injury.Injury
+}
+
+// GetByID implements InjuryLifecycleStore.
+// PRE: id is non-empty
+// POST: returns injury or error
+func (m *mockInjuryLifecycleStore) GetByID(_ context.Context, id string) (injury.Injury, error) {
+	inj, ok := m.injuries[id]
+	if !ok {
+		return injury.Injury{}, errors.New("not found")
+	}
+	return inj, nil
+}
+
+// Save implements InjuryLifecycleStore.
+// PRE: injury is valid
+// POST: injury is persisted
+func (m *mockInjuryLifecycleStore) Save(_ context.Context, i injury.Injury) error {
+	m.injuries[i.ID] = i
+	return nil
+}
+
+// ListByMemberID implements InjuryRestrictionStore.
+// PRE: memberID is non-empty
+// POST: returns the member's injuries
+func (m *mockInjuryLifecycleStore) ListByMemberID(_ context.Context, memberID string) ([]injury.Injury, error) {
+	var out []injury.Injury
+	for _, i := range m.injuries {
+		if i.MemberID == memberID {
+			out = append(out, i)
+		}
+	}
+	return out, nil
+}
+
+func newMockInjuryLifecycleStore() *mockInjuryLifecycleStore {
+	return &mockInjuryLifecycleStore{injuries: map[string]injury.Injury{
+		"inj-1": {ID: "inj-1", MemberID: "member-001", BodyPart: injury.BodyPartKnee, ReportedAt: fixedTime},
+	}}
+}
+
+// TestExecuteUpdateInjury_RestrictThenResolve tests the active → resolved flow with a grading restriction.
+func TestExecuteUpdateInjury_RestrictThenResolve(t *testing.T) {
+	store := newMockInjuryLifecycleStore()
+	deps := UpdateInjuryDeps{InjuryStore: store, Now: fixedNow}
+	restricted := true
+	severity := "High"
+
+	inj, err := ExecuteUpdateInjury(context.Background(), UpdateInjuryInput{
+		InjuryID: "inj-1", Severity: &severity, GradingRestricted: &restricted, UpdatedBy: "coach-001",
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inj.Status != injury.StatusActive || inj.Severity != injury.SeverityHigh {
+		t.Errorf("expected legacy injury to become active/high, got %s/%s", inj.Status, inj.Severity)
+	}
+	if err := CheckGradingInjuryRestriction(context.Background(), "member-001", store); !errors.Is(err, injury.ErrGradingRestricted) {
+		t.Errorf("expected ErrGradingRestricted, got %v", err)
+	}
+
+	notes := "Cleared by physio"
+	inj, err = ExecuteUpdateInjury(context.Background(), UpdateInjuryInput{
+		InjuryID: "inj-1", Status: injury.StatusResolved, ResolutionNotes: &notes, UpdatedBy: "coach-001",
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inj.ResolvedAt != fixedTime || inj.ResolutionNotes != notes {
+		t.Errorf("expected resolution recorded, got %+v", inj)
+	}
+	if err := CheckGradingInjuryRestriction(context.Background(), "member-001", store); err != nil {
+		t.Errorf("expected resolved injury not to block grading, got %v", err)
+	}
+}
+
+// TestExecuteUpdateInjury_Invalid tests rejected updates.
+func TestExecuteUpdateInjury_Invalid(t *testing.T) {
+	bad := "extreme"
+	tests := []struct {
+		name  string
+		input UpdateInjuryInput
+	}{
+		{"missing ID", UpdateInjuryInput{UpdatedBy: "coach-001"}},
+		{"missing updater", UpdateInjuryInput{InjuryID: "inj-1"}},
+		{"not found", UpdateInjuryInput{InjuryID: "nope", UpdatedBy: "coach-001"}},
+		{"invalid status", UpdateInjuryInput{InjuryID: "inj-1", Status: "healed", UpdatedBy: "coach-001"}},
+		{"invalid severity", UpdateInjuryInput{InjuryID: "inj-1", Severity: &bad, UpdatedBy: "coach-001"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExecuteUpdateInjury(context.Background(), tt.input, UpdateInjuryDeps{InjuryStore: newMockInjuryLifecycleStore(), Now: fixedNow})
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
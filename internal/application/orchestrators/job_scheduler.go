@@ -0,0 +1,361 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"workshop/internal/application/trace"
+	"workshop/internal/domain/job"
+)
+
+// JobStore defines the store interface the scheduler needs to persist schedules and runs.
+type JobStore interface {
+	GetByName(ctx context.Context, name string) (job.Job, error)
+	List(ctx context.Context) ([]job.Job, error)
+	Save(ctx context.Context, value job.Job) error
+	Claim(ctx context.Context, name string, now, leaseUntil time.Time) (bool, error)
+	RecordRun(ctx context.Context, value job.Job) error
+}
+
+// JobDefinition is a background task the scheduler can run.
+type JobDefinition struct {
+	Name            string
+	Description     string
+	DefaultSchedule string        // used until an admin sets one
+	Timeout         time.Duration // bounds each run's context and its lease
+	DefaultDisabled bool          // the job stays off until an admin enables it, for opt-in sends
+	Run             func(ctx context.Context) error
+}
+
+// JobStatus is a registered job's definition with its stored schedule and run status.
+type JobStatus struct {
+	job.Job
+	Description     string
+	DefaultSchedule string
+	Running         bool
+}
+
+// JobScheduler runs registered jobs on the cron schedules stored for them, reporting each
+// run to the worker monitor. Runs of one job never overlap: a run holds a lease in the
+// store, so a job still running when it next falls due is skipped until it finishes.
+// INVARIANT: safe for concurrent use by the scheduler loop and HTTP handlers.
+type JobScheduler struct {
+	store   JobStore
+	monitor *WorkerMonitor
+	now     func() time.Time
+
+	mu      sync.Mutex
+	defs    map[string]JobDefinition
+	running map[string]bool // runs in progress in this process
+}
+
+// NewJobScheduler creates a scheduler with no jobs.
+// PRE: store, monitor and now are non-nil
+// POST: Returns a scheduler ready for Register
+func NewJobScheduler(store JobStore, monitor *WorkerMonitor, now func() time.Time) *JobScheduler {
+	return &JobScheduler{store: store, monitor: monitor, now: now, defs: make(map[string]JobDefinition), running: make(map[string]bool)}
+}
+
+// Register adds a job the scheduler can run.
+// PRE: def.Name is non-empty and unique; def.Timeout > 0; def.Run is non-nil
+// POST: Returns an error wrapping job.ErrInvalidSchedule if the default schedule is invalid
+func (s *JobScheduler) Register(def JobDefinition) error {
+	if _, err := job.ParseSchedule(def.DefaultSchedule); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defs[def.Name] = def
+	return nil
+}
+
+// Sync saves a schedule for every registered job that has none, and reports enabled jobs
+// to the monitor. A job still on its default follows the default if the code changes it.
+// PRE: jobs have been registered
+// POST: Every registered job has a stored, valid schedule
+func (s *JobScheduler) Sync(ctx context.Context) error {
+	now := s.now()
+	for _, def := range s.definitions() {
+		j, err := s.store.GetByName(ctx, def.Name)
+		save := false
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			j, save = job.Job{Name: def.Name, Schedule: def.DefaultSchedule, Enabled: !def.DefaultDisabled}, true
+		case err != nil:
+			return err
+		}
+		if _, err := job.ParseSchedule(j.Schedule); err != nil || (j.UpdatedBy == "" && j.Schedule != def.DefaultSchedule) {
+			slog.InfoContext(ctx, "job_event", "event", "job_schedule_defaulted", "job", def.Name, "old_schedule", j.Schedule, "schedule", def.DefaultSchedule)
+			j.Schedule, j.UpdatedBy, j.NextRunAt = def.DefaultSchedule, "", time.Time{}
+			save = true
+		}
+		if save || (j.Enabled && j.NextRunAt.IsZero()) {
+			if err := j.Reschedule(now); err != nil {
+				return err
+			}
+			if err := s.store.Save(ctx, j); err != nil {
+				return err
+			}
+		}
+		s.watch(j, now)
+	}
+	return nil
+}
+
+// Tick starts every registered job that is due. Jobs run in their own goroutines.
+// PRE: Sync has run
+// POST: Returns the names of the jobs started
+func (s *JobScheduler) Tick(ctx context.Context) ([]string, error) {
+	jobs, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	var started []string
+	for _, j := range jobs {
+		def, ok := s.definition(j.Name)
+		if !ok || !j.IsDue(now) {
+			continue
+		}
+		ok, err := s.start(ctx, def)
+		if err != nil {
+			slog.ErrorContext(ctx, "job_claim_failed", "job", j.Name, "error", err.Error())
+			continue
+		}
+		if ok {
+			started = append(started, j.Name)
+		}
+	}
+	return started, nil
+}
+
+// Start syncs schedules, then checks for due jobs every interval until stopCh closes.
+// The loop and its runs are tracked by the monitor, so monitor.Wait returns once they
+// have finished.
+// PRE: jobs have been registered; interval > 0
+// POST: The loop runs in a goroutine; returns Sync's error without starting it
+func (s *JobScheduler) Start(stopCh <-chan struct{}, interval time.Duration) error {
+	if err := s.Sync(context.Background()); err != nil {
+		return err
+	}
+	s.monitor.loops.Add(1)
+	go func() {
+		defer s.monitor.loops.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				// A tick and a stop can be ready together; never start a run once stopping.
+				select {
+				case <-stopCh:
+					s.stopped()
+					return
+				default:
+				}
+				if _, err := s.Tick(context.Background()); err != nil {
+					slog.Error("job_tick_failed", "error", err.Error())
+				}
+			case <-stopCh:
+				s.stopped()
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// RunNow starts a job immediately, outside its schedule. Its next scheduled run is
+// counted from when this run finishes.
+// PRE: by is the account asking
+// POST: Returns job.ErrNotFound for unknown jobs, job.ErrAlreadyRunning if a run is in progress
+func (s *JobScheduler) RunNow(ctx context.Context, name, by string) error {
+	def, ok := s.definition(name)
+	if !ok {
+		return job.ErrNotFound
+	}
+	started, err := s.start(ctx, def)
+	if err != nil {
+		return err
+	}
+	if !started {
+		return job.ErrAlreadyRunning
+	}
+	slog.InfoContext(ctx, "job_event", "event", "job_run_requested", "job", name, "requested_by", by)
+	return nil
+}
+
+// Update changes a job's schedule and whether it runs, and schedules its next run.
+// PRE: by is the admin making the change
+// POST: Returns the updated status, job.ErrNotFound for unknown jobs, or an error
+// wrapping job.ErrInvalidSchedule
+func (s *JobScheduler) Update(ctx context.Context, name, schedule string, enabled bool, by string) (JobStatus, error) {
+	def, ok := s.definition(name)
+	if !ok {
+		return JobStatus{}, job.ErrNotFound
+	}
+	j, err := s.store.GetByName(ctx, name)
+	if err != nil {
+		return JobStatus{}, err
+	}
+	now := s.now()
+	j.Schedule, j.Enabled, j.UpdatedBy, j.UpdatedAt = schedule, enabled, by, now
+	if err := j.Validate(); err != nil {
+		return JobStatus{}, err
+	}
+	if err := j.Reschedule(now); err != nil {
+		return JobStatus{}, err
+	}
+	if err := s.store.Save(ctx, j); err != nil {
+		return JobStatus{}, err
+	}
+	s.watch(j, now)
+	slog.InfoContext(ctx, "job_event", "event", "job_schedule_updated", "job", name, "schedule", j.Schedule, "enabled", j.Enabled, "updated_by", by)
+	return s.status(def, j, now), nil
+}
+
+// List returns every registered job with its stored schedule and status, ordered by name.
+// PRE: none
+// POST: Jobs not yet synced are listed on their default schedule
+func (s *JobScheduler) List(ctx context.Context) ([]JobStatus, error) {
+	stored, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]job.Job, len(stored))
+	for _, j := range stored {
+		byName[j.Name] = j
+	}
+	now := s.now()
+	defs := s.definitions()
+	out := make([]JobStatus, 0, len(defs))
+	for _, def := range defs {
+		j, ok := byName[def.Name]
+		if !ok {
+			j = job.Job{Name: def.Name, Schedule: def.DefaultSchedule, Enabled: !def.DefaultDisabled}
+		}
+		out = append(out, s.status(def, j, now))
+	}
+	return out, nil
+}
+
+// start claims a job's lease and runs it in a goroutine tracked by the monitor.
+// Returns false without error when a run is already in progress here or elsewhere.
+func (s *JobScheduler) start(ctx context.Context, def JobDefinition) (bool, error) {
+	s.mu.Lock()
+	if s.running[def.Name] {
+		s.mu.Unlock()
+		return false, nil
+	}
+	s.running[def.Name] = true
+	s.mu.Unlock()
+
+	startedAt := s.now()
+	claimed, err := s.store.Claim(ctx, def.Name, startedAt, startedAt.Add(def.Timeout))
+	if err != nil || !claimed {
+		s.finish(def.Name)
+		return false, err
+	}
+	s.monitor.loops.Add(1)
+	go func() {
+		defer s.monitor.loops.Done()
+		defer s.finish(def.Name)
+		s.execute(def, startedAt)
+	}()
+	return true, nil
+}
+
+// execute runs a claimed job and records its outcome and next run.
+func (s *JobScheduler) execute(def JobDefinition, startedAt time.Time) {
+	// Each run gets its own ID so its log lines and queries can be traced like a request's.
+	base := trace.WithRequestID(context.Background(), trace.NewRequestID())
+	ctx, cancel := context.WithTimeout(base, def.Timeout)
+	defer cancel()
+
+	s.monitor.RecordStart(def.Name)
+	err := def.Run(ctx)
+	s.monitor.RecordResult(def.Name, err)
+
+	finishedAt := s.now()
+	result := job.Job{Name: def.Name, LastDuration: finishedAt.Sub(startedAt), LastStatus: job.StatusOK}
+	if err != nil {
+		result.LastStatus = job.StatusFailed
+		result.LastError = err.Error()
+		if len(result.LastError) > job.MaxErrorLength {
+			result.LastError = result.LastError[:job.MaxErrorLength]
+		}
+		slog.ErrorContext(ctx, "worker_run_failed", "worker", def.Name, "error", err.Error())
+	}
+	// Re-read the schedule: an admin may have changed it while the job ran.
+	if current, err := s.store.GetByName(base, def.Name); err == nil {
+		if err := current.Reschedule(finishedAt); err == nil {
+			result.NextRunAt = current.NextRunAt
+		}
+	}
+	if err := s.store.RecordRun(base, result); err != nil {
+		slog.ErrorContext(base, "job_record_failed", "job", def.Name, "error", err.Error())
+	}
+}
+
+// finish marks a job as no longer running in this process.
+func (s *JobScheduler) finish(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, name)
+}
+
+// watch registers an enabled job with the monitor at its approximate interval, and
+// removes a disabled one so it is not reported as unhealthy.
+func (s *JobScheduler) watch(j job.Job, now time.Time) {
+	if !j.Enabled {
+		s.monitor.Remove(j.Name)
+		return
+	}
+	sched, err := job.ParseSchedule(j.Schedule)
+	if err != nil {
+		return
+	}
+	first := sched.Next(now)
+	s.monitor.Register(j.Name, sched.Next(first).Sub(first))
+}
+
+// stopped reports every job's loop as exited to the monitor.
+func (s *JobScheduler) stopped() {
+	for _, def := range s.definitions() {
+		s.monitor.RecordStopped(def.Name)
+	}
+	slog.Info("job_scheduler_stopped")
+}
+
+// status joins a definition with its stored job.
+func (s *JobScheduler) status(def JobDefinition, j job.Job, now time.Time) JobStatus {
+	s.mu.Lock()
+	running := s.running[def.Name]
+	s.mu.Unlock()
+	return JobStatus{Job: j, Description: def.Description, DefaultSchedule: def.DefaultSchedule, Running: running || j.IsRunning(now)}
+}
+
+// definition returns the registered job named name.
+func (s *JobScheduler) definition(name string) (JobDefinition, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	def, ok := s.defs[name]
+	return def, ok
+}
+
+// definitions returns the registered jobs ordered by name.
+func (s *JobScheduler) definitions() []JobDefinition {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]JobDefinition, 0, len(s.defs))
+	for _, def := range s.defs {
+		out = append(out, def)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
@@ -0,0 +1,281 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/job"
+)
+
+type mockJobStore struct {
+	mu   sync.Mutex
+	jobs map[string]job.Job
+}
+
+// GetByName implements JobStore.
+// PRE: name is non-empty
+// POST: returns the job or an error wrapping sql.ErrNoRows
+func (m *mockJobStore) GetByName(_ context.Context, name string) (job.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[name]
+	if !ok {
+		return job.Job{}, fmt.Errorf("job not found: %w", sql.ErrNoRows)
+	}
+	return j, nil
+}
+
+// List implements JobStore.
+// PRE: none
+// POST: returns every job ordered by name
+func (m *mockJobStore) List(_ context.Context) ([]job.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []job.Job
+	for _, j := range m.jobs {
+		out = append(out, j)
+	}
+	sort.Slice(out, func(i, k int) bool { return out[i].Name < out[k].Name })
+	return out, nil
+}
+
+// Save implements JobStore, keeping run status as the SQLite store does.
+// PRE: value has been validated
+// POST: the schedule is stored
+func (m *mockJobStore) Save(_ context.Context, value job.Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.jobs == nil {
+		m.jobs = map[string]job.Job{}
+	}
+	if old, ok := m.jobs[value.Name]; ok {
+		value.LastRunAt, value.LastDuration, value.LastStatus, value.LastError, value.RunningUntil =
+			old.LastRunAt, old.LastDuration, old.LastStatus, old.LastError, old.RunningUntil
+	}
+	m.jobs[value.Name] = value
+	return nil
+}
+
+// Claim implements JobStore.
+// PRE: none
+// POST: takes the lease unless one is held
+func (m *mockJobStore) Claim(_ context.Context, name string, now, leaseUntil time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[name]
+	if !ok || j.IsRunning(now) {
+		return false, nil
+	}
+	j.RunningUntil, j.LastRunAt = leaseUntil, now
+	m.jobs[name] = j
+	return true, nil
+}
+
+// RecordRun implements JobStore.
+// PRE: the run was claimed
+// POST: stores the outcome and releases the lease
+func (m *mockJobStore) RecordRun(_ context.Context, value job.Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j := m.jobs[value.Name]
+	j.LastDuration, j.LastStatus, j.LastError, j.NextRunAt, j.RunningUntil =
+		value.LastDuration, value.LastStatus, value.LastError, value.NextRunAt, time.Time{}
+	m.jobs[value.Name] = j
+	return nil
+}
+
+// jobClock is a settable clock shared by the scheduler and its runs.
+type jobClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+// Now returns the clock's time.
+// PRE: none
+// POST: Returns the time last set
+func (c *jobClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+// Set moves the clock to t.
+// PRE: none
+// POST: Now returns t
+func (c *jobClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = t
+}
+
+// newTestScheduler returns a scheduler at 10:17 with an hourly "tidy" job that runs fn.
+func newTestScheduler(t *testing.T, fn func(ctx context.Context) error) (*JobScheduler, *mockJobStore, *WorkerMonitor, *jobClock) {
+	t.Helper()
+	clock := &jobClock{t: time.Date(2026, 3, 4, 10, 17, 0, 0, time.UTC)}
+	store := &mockJobStore{}
+	monitor := NewWorkerMonitor(clock.Now)
+	s := NewJobScheduler(store, monitor, clock.Now)
+	if err := s.Register(JobDefinition{Name: "tidy", DefaultSchedule: "@hourly", Timeout: time.Minute, Run: fn}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return s, store, monitor, clock
+}
+
+// waitRuns waits for the scheduler's runs to finish.
+func waitRuns(t *testing.T, m *WorkerMonitor) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := m.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestJobScheduler_RunsDueJobs verifies a synced job waits for its schedule, runs once
+// due, and records the outcome and its next run.
+func TestJobScheduler_RunsDueJobs(t *testing.T) {
+	var runs atomic.Int32
+	s, store, monitor, clock := newTestScheduler(t, func(context.Context) error { runs.Add(1); return nil })
+	ctx := context.Background()
+
+	j, _ := store.GetByName(ctx, "tidy")
+	if want := time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC); !j.Enabled || !j.NextRunAt.Equal(want) {
+		t.Fatalf("synced job = %+v, want enabled and next run at %s", j, want)
+	}
+	if started, _ := s.Tick(ctx); len(started) != 0 {
+		t.Fatalf("started %v before the job was due", started)
+	}
+
+	clock.Set(time.Date(2026, 3, 4, 11, 0, 5, 0, time.UTC))
+	if started, _ := s.Tick(ctx); len(started) != 1 {
+		t.Fatalf("started %v, want tidy", started)
+	}
+	waitRuns(t, monitor)
+	j, _ = store.GetByName(ctx, "tidy")
+	if runs.Load() != 1 || j.LastStatus != job.StatusOK || j.IsRunning(clock.Now()) {
+		t.Errorf("runs = %d, job = %+v, want one successful run with the lease released", runs.Load(), j)
+	}
+	if want := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC); !j.NextRunAt.Equal(want) {
+		t.Errorf("NextRunAt = %s, want %s", j.NextRunAt, want)
+	}
+	if snap := monitor.Snapshot(); len(snap) != 1 || snap[0].Runs != 1 || snap[0].Interval != time.Hour {
+		t.Errorf("monitor = %+v, want one hourly run", snap)
+	}
+}
+
+// TestJobScheduler_PreventsOverlap verifies a job still running is not started again,
+// whether the run is in this process or holds the lease from another.
+func TestJobScheduler_PreventsOverlap(t *testing.T) {
+	release := make(chan struct{})
+	s, store, monitor, clock := newTestScheduler(t, func(context.Context) error { <-release; return nil })
+	ctx := context.Background()
+
+	if err := s.RunNow(ctx, "tidy", "admin-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RunNow(ctx, "tidy", "admin-1"); !errors.Is(err, job.ErrAlreadyRunning) {
+		t.Errorf("second run: err = %v, want ErrAlreadyRunning", err)
+	}
+	clock.Set(clock.Now().Add(time.Hour))
+	if started, _ := s.Tick(ctx); len(started) != 0 {
+		t.Errorf("started %v while the previous run was going", started)
+	}
+	close(release)
+	waitRuns(t, monitor)
+
+	// Another process holds the lease.
+	store.Claim(ctx, "tidy", clock.Now(), clock.Now().Add(time.Minute))
+	if err := s.RunNow(ctx, "tidy", "admin-1"); !errors.Is(err, job.ErrAlreadyRunning) {
+		t.Errorf("leased elsewhere: err = %v, want ErrAlreadyRunning", err)
+	}
+	if err := s.RunNow(ctx, "nope", "admin-1"); !errors.Is(err, job.ErrNotFound) {
+		t.Errorf("unknown job: err = %v, want ErrNotFound", err)
+	}
+}
+
+// TestJobScheduler_RecordsFailure verifies a failed run keeps its error and is rescheduled.
+func TestJobScheduler_RecordsFailure(t *testing.T) {
+	s, store, monitor, _ := newTestScheduler(t, func(context.Context) error { return errors.New("smtp down") })
+	ctx := context.Background()
+	if err := s.RunNow(ctx, "tidy", "admin-1"); err != nil {
+		t.Fatal(err)
+	}
+	waitRuns(t, monitor)
+	j, _ := store.GetByName(ctx, "tidy")
+	if j.LastStatus != job.StatusFailed || j.LastError != "smtp down" || j.NextRunAt.IsZero() {
+		t.Errorf("job = %+v, want the failure recorded and a next run", j)
+	}
+}
+
+// TestJobScheduler_Update verifies schedules are validated, disabling stops runs and
+// removes the job from the monitor, and re-enabling schedules it again.
+func TestJobScheduler_Update(t *testing.T) {
+	s, _, monitor, clock := newTestScheduler(t, func(context.Context) error { return nil })
+	ctx := context.Background()
+
+	if _, err := s.Update(ctx, "tidy", "whenever", true, "admin-1"); !errors.Is(err, job.ErrInvalidSchedule) {
+		t.Errorf("bad schedule: err = %v, want ErrInvalidSchedule", err)
+	}
+	if _, err := s.Update(ctx, "nope", "@daily", true, "admin-1"); !errors.Is(err, job.ErrNotFound) {
+		t.Errorf("unknown job: err = %v, want ErrNotFound", err)
+	}
+
+	st, err := s.Update(ctx, "tidy", "@hourly", false, "admin-1")
+	if err != nil || !st.NextRunAt.IsZero() || len(monitor.Snapshot()) != 0 {
+		t.Fatalf("disable: %+v, %v; want no next run and no monitored worker", st, err)
+	}
+	clock.Set(clock.Now().Add(2 * time.Hour))
+	if started, _ := s.Tick(ctx); len(started) != 0 {
+		t.Errorf("disabled job started: %v", started)
+	}
+
+	st, err = s.Update(ctx, "tidy", "30 6 * * mon", true, "admin-1")
+	if want := time.Date(2026, 3, 9, 6, 30, 0, 0, time.UTC); err != nil || !st.NextRunAt.Equal(want) || st.UpdatedBy != "admin-1" {
+		t.Errorf("re-enable: %+v, %v; want next run %s", st, err, want)
+	}
+	list, _ := s.List(ctx)
+	if len(list) != 1 || list[0].Schedule != "30 6 * * mon" || list[0].DefaultSchedule != "@hourly" {
+		t.Errorf("list = %+v", list)
+	}
+}
+
+// TestJobScheduler_SyncFollowsDefaults verifies a job an admin never changed picks up a
+// new default schedule, while an admin's schedule is kept.
+func TestJobScheduler_SyncFollowsDefaults(t *testing.T) {
+	s, store, _, _ := newTestScheduler(t, func(context.Context) error { return nil })
+	ctx := context.Background()
+	s.Register(JobDefinition{Name: "tidy", DefaultSchedule: "@daily", Timeout: time.Minute, Run: func(context.Context) error { return nil }})
+	if err := s.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if j, _ := store.GetByName(ctx, "tidy"); j.Schedule != "@daily" {
+		t.Errorf("schedule = %q, want the new default", j.Schedule)
+	}
+
+	s.Update(ctx, "tidy", "0 3 * * *", true, "admin-1")
+	s.Register(JobDefinition{Name: "tidy", DefaultSchedule: "@weekly", Timeout: time.Minute, Run: func(context.Context) error { return nil }})
+	if err := s.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if j, _ := store.GetByName(ctx, "tidy"); j.Schedule != "0 3 * * *" {
+		t.Errorf("schedule = %q, want the admin's", j.Schedule)
+	}
+
+	s.Register(JobDefinition{Name: "opt_in", DefaultSchedule: "@daily", Timeout: time.Minute, DefaultDisabled: true, Run: func(context.Context) error { return nil }})
+	if err := s.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if j, _ := store.GetByName(ctx, "opt_in"); j.Enabled || !j.NextRunAt.IsZero() {
+		t.Errorf("opt-in job = %+v, want it stored disabled", j)
+	}
+}
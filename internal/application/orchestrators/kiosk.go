@@ -2,8 +2,10 @@ package orchestrators
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"log/slog"
+	"strings"
 	"time"
 
 	"workshop/internal/domain/account"
@@ -17,19 +19,33 @@ type KioskAccountStore interface {
 	GetByID(ctx context.Context, id string) (account.Account, error)
 }
 
+// KioskDeviceStore defines the device store interface needed by kiosk orchestrators.
+type KioskDeviceStore interface {
+	GetDevice(ctx context.Context, id string) (kiosk.Device, error)
+	SaveDevice(ctx context.Context, value kiosk.Device) error
+}
+
 // LaunchKioskInput carries input for launching kiosk mode.
 type LaunchKioskInput struct {
-	AccountID string
+	AccountID     string
+	LocationID    string // optional: location the kiosk will serve
+	DeviceID      string // optional: registered device relaunching kiosk mode
+	DeviceName    string // optional: registers a new device under this name when DeviceID is empty or unknown
+	AuthSessionID string // sign-in session kiosk mode runs under; recorded on the device
 }
 
 // LaunchKioskDeps holds dependencies for LaunchKiosk.
 type LaunchKioskDeps struct {
 	AccountStore KioskAccountStore
+	DeviceStore  KioskDeviceStore // optional: nil disables device registration
+	Now          func() time.Time
 }
 
 // ExecuteLaunchKiosk creates a new kiosk session tied to the launching account.
+// With a device ID or name it also registers the device (or reuses its registration)
+// and records the sign-in session on it, so the device can exit with its PIN and be ended remotely.
 // PRE: AccountID must be non-empty and belong to an admin or coach
-// POST: Returns a new active kiosk Session
+// POST: Returns a new active kiosk Session; DeviceID is set when a device was registered
 func ExecuteLaunchKiosk(ctx context.Context, input LaunchKioskInput, deps LaunchKioskDeps) (kiosk.Session, error) {
 	if input.AccountID == "" {
 		return kiosk.Session{}, errors.New("account ID is required")
@@ -44,35 +60,95 @@ func ExecuteLaunchKiosk(ctx context.Context, input LaunchKioskInput, deps Launch
 		return kiosk.Session{}, errors.New("only admin or coach can launch kiosk mode")
 	}
 
+	if input.LocationID != "" && !acct.CanAccessLocation(input.LocationID) {
+		return kiosk.Session{}, errors.New("account cannot launch kiosk mode at this location")
+	}
+
+	now := deps.Now()
 	session := kiosk.Session{
-		ID:        uuid.New().String(),
-		AccountID: input.AccountID,
-		StartedAt: time.Now(),
+		ID:         uuid.New().String(),
+		AccountID:  input.AccountID,
+		LocationID: input.LocationID,
+		StartedAt:  now,
 	}
 
 	if err := session.Validate(); err != nil {
 		return kiosk.Session{}, err
 	}
 
-	slog.Info("kiosk_event", "event", "kiosk_launched", "account_id", input.AccountID)
+	if deps.DeviceStore != nil && (input.DeviceID != "" || strings.TrimSpace(input.DeviceName) != "") {
+		device, err := registerKioskDevice(ctx, input, deps.DeviceStore, now)
+		if err != nil {
+			return kiosk.Session{}, err
+		}
+		session.DeviceID = device.ID
+	}
+
+	slog.InfoContext(ctx, "kiosk_event", "event", "kiosk_launched", "account_id", input.AccountID, "location_id", input.LocationID, "device_id", session.DeviceID)
 	return session, nil
 }
 
+// registerKioskDevice loads the launching device, or registers a new one with a fresh PIN secret,
+// and starts its kiosk session.
+func registerKioskDevice(ctx context.Context, input LaunchKioskInput, store KioskDeviceStore, now time.Time) (kiosk.Device, error) {
+	var device kiosk.Device
+	found := false
+	if input.DeviceID != "" {
+		if d, err := store.GetDevice(ctx, input.DeviceID); err == nil {
+			device, found = d, true
+		}
+	}
+	if !found {
+		if strings.TrimSpace(input.DeviceName) == "" {
+			return kiosk.Device{}, errors.New("kiosk device not found")
+		}
+		secret := make([]byte, kiosk.PINSecretLength)
+		if _, err := rand.Read(secret); err != nil {
+			return kiosk.Device{}, err
+		}
+		device = kiosk.Device{
+			ID:           uuid.New().String(),
+			Name:         strings.TrimSpace(input.DeviceName),
+			RegisteredBy: input.AccountID,
+			PINSecret:    secret,
+			RegisteredAt: now,
+		}
+		slog.InfoContext(ctx, "kiosk_event", "event", "kiosk_device_registered", "account_id", input.AccountID, "device_id", device.ID)
+	}
+	device.LocationID = input.LocationID
+	device.StartKiosk(input.AuthSessionID, now)
+	if err := device.Validate(); err != nil {
+		return kiosk.Device{}, err
+	}
+	if err := store.SaveDevice(ctx, device); err != nil {
+		return kiosk.Device{}, err
+	}
+	return device, nil
+}
+
 // ExitKioskInput carries input for exiting kiosk mode.
+// Set Password, or DeviceID and PIN to exit with the device's rotating PIN.
 type ExitKioskInput struct {
 	AccountID string
 	Password  string
+	DeviceID  string // optional: registered device leaving kiosk mode
+	PIN       string // optional: the device's current exit PIN, instead of Password
 }
 
 // ExitKioskDeps holds dependencies for ExitKiosk.
 type ExitKioskDeps struct {
 	AccountStore KioskAccountStore
+	DeviceStore  KioskDeviceStore // optional: nil disables PIN exit
+	Now          func() time.Time
 }
 
-// ExecuteExitKiosk verifies the password to exit kiosk mode.
-// PRE: AccountID and Password must be non-empty
-// POST: Returns nil if password is correct, error otherwise
+// ExecuteExitKiosk verifies the password, or the device's exit PIN, to exit kiosk mode.
+// PRE: Password and AccountID, or PIN and DeviceID, must be non-empty
+// POST: Returns nil if the credential is correct, error otherwise; the device leaves kiosk mode
 func ExecuteExitKiosk(ctx context.Context, input ExitKioskInput, deps ExitKioskDeps) error {
+	if input.PIN != "" && input.Password == "" {
+		return exitKioskWithPIN(ctx, input, deps)
+	}
 	if input.AccountID == "" {
 		return errors.New("account ID is required")
 	}
@@ -89,6 +165,75 @@ func ExecuteExitKiosk(ctx context.Context, input ExitKioskInput, deps ExitKioskD
 		return errors.New("invalid password")
 	}
 
-	slog.Info("kiosk_event", "event", "kiosk_exited", "account_id", input.AccountID)
+	if input.DeviceID != "" && deps.DeviceStore != nil {
+		if device, err := deps.DeviceStore.GetDevice(ctx, input.DeviceID); err == nil {
+			if _, err := device.EndKiosk(); err == nil {
+				if err := deps.DeviceStore.SaveDevice(ctx, device); err != nil {
+					slog.ErrorContext(ctx, "kiosk_event", "event", "kiosk_device_save_failed", "device_id", device.ID, "error", err)
+				}
+			}
+		}
+	}
+
+	slog.InfoContext(ctx, "kiosk_event", "event", "kiosk_exited", "account_id", input.AccountID, "device_id", input.DeviceID)
+	return nil
+}
+
+// exitKioskWithPIN verifies the device's rotating PIN and takes the device out of kiosk mode.
+func exitKioskWithPIN(ctx context.Context, input ExitKioskInput, deps ExitKioskDeps) error {
+	if input.DeviceID == "" || deps.DeviceStore == nil {
+		return errors.New("PIN exit is only available on a registered kiosk device")
+	}
+	device, err := deps.DeviceStore.GetDevice(ctx, input.DeviceID)
+	if err != nil {
+		return errors.New("kiosk device not found")
+	}
+	if err := device.CheckPIN(input.PIN, deps.Now()); err != nil {
+		slog.WarnContext(ctx, "security_event", "event", "kiosk_pin_rejected", "device_id", device.ID)
+		return err
+	}
+	if _, err := device.EndKiosk(); err != nil && !errors.Is(err, kiosk.ErrNotActive) {
+		return err
+	}
+	if err := deps.DeviceStore.SaveDevice(ctx, device); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "kiosk_event", "event", "kiosk_exited", "device_id", device.ID, "method", "pin")
+	return nil
+}
+
+// EndKioskSessionInput carries input for ending a device's kiosk session remotely.
+type EndKioskSessionInput struct {
+	DeviceID  string
+	AccountID string // admin ending the session
+}
+
+// EndKioskSessionDeps holds dependencies for EndKioskSession.
+type EndKioskSessionDeps struct {
+	DeviceStore   KioskDeviceStore
+	RevokeSession func(ctx context.Context, sessionID string) error
+}
+
+// ExecuteEndKioskSession takes a device out of kiosk mode from elsewhere (e.g. a lost or
+// unattended tablet) by signing out the session it runs under.
+// PRE: DeviceID is non-empty
+// POST: the device is not in kiosk mode and its session is revoked; returns kiosk.ErrNotActive if it was not in kiosk mode
+func ExecuteEndKioskSession(ctx context.Context, input EndKioskSessionInput, deps EndKioskSessionDeps) error {
+	device, err := deps.DeviceStore.GetDevice(ctx, input.DeviceID)
+	if err != nil {
+		return err
+	}
+	sessionID, err := device.EndKiosk()
+	if err != nil {
+		return err
+	}
+	if err := deps.RevokeSession(ctx, sessionID); err != nil {
+		// The session may already have expired or been signed out; the device is still released.
+		slog.WarnContext(ctx, "kiosk_event", "event", "kiosk_session_revoke_failed", "device_id", device.ID, "error", err)
+	}
+	if err := deps.DeviceStore.SaveDevice(ctx, device); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "security_event", "event", "kiosk_session_ended", "admin_account_id", input.AccountID, "device_id", device.ID)
 	return nil
 }
@@ -0,0 +1,131 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/kiosk"
+)
+
+// --- Mock stores for kiosk tests ---
+
+type mockKioskDeviceStore struct {
+	devices map[string]kiosk.Device
+}
+
+// GetDevice implements KioskDeviceStore.
+// PRE: id is non-empty
+// POST: returns the device or an error if unknown
+func (m *mockKioskDeviceStore) GetDevice(_ context.Context, id string) (kiosk.Device, error) {
+	d, ok := m.devices[id]
+	if !ok {
+		return kiosk.Device{}, errors.New("kiosk device not found")
+	}
+	return d, nil
+}
+
+// SaveDevice implements KioskDeviceStore.
+// PRE: value has been validated
+// POST: device is upserted
+func (m *mockKioskDeviceStore) SaveDevice(_ context.Context, value kiosk.Device) error {
+	m.devices[value.ID] = value
+	return nil
+}
+
+// TestExecuteLaunchKiosk_RegistersDevice verifies launching with a device name registers the
+// device and relaunching with its ID reuses the registration under the new session.
+func TestExecuteLaunchKiosk_RegistersDevice(t *testing.T) {
+	store := &mockKioskDeviceStore{devices: map[string]kiosk.Device{}}
+	deps := LaunchKioskDeps{AccountStore: &mockCoachAccountStore{}, DeviceStore: store, Now: func() time.Time { return fixedTime }}
+
+	first, err := ExecuteLaunchKiosk(context.Background(), LaunchKioskInput{AccountID: "coach-1", DeviceName: " Front desk ", AuthSessionID: "sess-1"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d := store.devices[first.DeviceID]
+	if first.DeviceID == "" || d.Name != "Front desk" || d.RegisteredBy != "coach-1" || d.AuthSessionID != "sess-1" || len(d.PINSecret) != kiosk.PINSecretLength {
+		t.Fatalf("device not registered: session %+v, device %+v", first, d)
+	}
+
+	second, err := ExecuteLaunchKiosk(context.Background(), LaunchKioskInput{AccountID: "coach-2", DeviceID: first.DeviceID, AuthSessionID: "sess-2"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.DeviceID != first.DeviceID || len(store.devices) != 1 || store.devices[first.DeviceID].AuthSessionID != "sess-2" {
+		t.Errorf("relaunch did not reuse the device: %+v", store.devices)
+	}
+
+	tests := []struct {
+		name  string
+		input LaunchKioskInput
+	}{
+		{"unknown device without name", LaunchKioskInput{AccountID: "coach-1", DeviceID: "gone", AuthSessionID: "sess-3"}},
+		{"member", LaunchKioskInput{AccountID: "member-1", DeviceName: "Front desk", AuthSessionID: "sess-3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ExecuteLaunchKiosk(context.Background(), tt.input, deps); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+// TestExecuteExitKiosk_PIN verifies a registered device exits kiosk mode with its current PIN.
+func TestExecuteExitKiosk_PIN(t *testing.T) {
+	device := kiosk.Device{ID: "d1", Name: "Front desk", RegisteredBy: "coach-1", PINSecret: make([]byte, kiosk.PINSecretLength)}
+	device.StartKiosk("sess-1", fixedTime)
+	wrong := "000000"
+	if device.PIN(fixedTime) == wrong {
+		wrong = "111111"
+	}
+
+	tests := []struct {
+		name    string
+		input   ExitKioskInput
+		wantErr bool
+	}{
+		{"wrong PIN", ExitKioskInput{DeviceID: "d1", PIN: wrong}, true},
+		{"unknown device", ExitKioskInput{DeviceID: "d2", PIN: device.PIN(fixedTime)}, true},
+		{"no device", ExitKioskInput{PIN: device.PIN(fixedTime)}, true},
+		{"current PIN", ExitKioskInput{DeviceID: "d1", PIN: device.PIN(fixedTime)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockKioskDeviceStore{devices: map[string]kiosk.Device{"d1": device}}
+			deps := ExitKioskDeps{AccountStore: &mockCoachAccountStore{}, DeviceStore: store, Now: func() time.Time { return fixedTime.Add(time.Minute) }}
+			err := ExecuteExitKiosk(context.Background(), tt.input, deps)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExecuteExitKiosk() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			d := store.devices["d1"]
+			if inKiosk := d.InKiosk(); inKiosk == !tt.wantErr {
+				t.Errorf("device in kiosk = %v after exit error %v", inKiosk, err)
+			}
+		})
+	}
+}
+
+// TestExecuteEndKioskSession verifies ending a device's kiosk session remotely signs out its session.
+func TestExecuteEndKioskSession(t *testing.T) {
+	device := kiosk.Device{ID: "d1", Name: "Front desk", RegisteredBy: "coach-1", PINSecret: make([]byte, kiosk.PINSecretLength)}
+	device.StartKiosk("sess-1", fixedTime)
+	store := &mockKioskDeviceStore{devices: map[string]kiosk.Device{"d1": device}}
+	var revoked []string
+	deps := EndKioskSessionDeps{DeviceStore: store, RevokeSession: func(_ context.Context, id string) error {
+		revoked = append(revoked, id)
+		return nil
+	}}
+
+	if err := ExecuteEndKioskSession(context.Background(), EndKioskSessionInput{DeviceID: "d1", AccountID: "admin-1"}, deps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := store.devices["d1"]; d.InKiosk() || len(revoked) != 1 || revoked[0] != "sess-1" {
+		t.Errorf("device %+v, revoked %v; want released device and sess-1 revoked", d, revoked)
+	}
+	if err := ExecuteEndKioskSession(context.Background(), EndKioskSessionInput{DeviceID: "d1", AccountID: "admin-1"}, deps); !errors.Is(err, kiosk.ErrNotActive) {
+		t.Errorf("second end = %v, want ErrNotActive", err)
+	}
+}
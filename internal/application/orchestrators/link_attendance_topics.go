@@ -0,0 +1,79 @@
+package orchestrators
+
+import (
+	"context"
+	"log/slog"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/rotor"
+)
+
+// AttendanceTopicRotorStore defines the rotor store interface needed to find the topics being taught.
+type AttendanceTopicRotorStore interface {
+	GetActiveRotor(ctx context.Context, classTypeID string) (rotor.Rotor, error)
+	ListThemesByRotor(ctx context.Context, rotorID string) ([]rotor.RotorTheme, error)
+	ListSchedulesByTheme(ctx context.Context, rotorThemeID string) ([]rotor.TopicSchedule, error)
+}
+
+// AttendanceTopicStore defines the store interface needed to record attendance topic links.
+type AttendanceTopicStore interface {
+	SaveTopicLinks(ctx context.Context, links []attendance.TopicLink) error
+}
+
+// LinkAttendanceTopicsDeps holds dependencies for LinkAttendanceTopics.
+type LinkAttendanceTopicsDeps struct {
+	ScheduleStore ScheduleLookupStore
+	RotorStore    AttendanceTopicRotorStore
+	LinkStore     AttendanceTopicStore
+}
+
+// ExecuteLinkAttendanceTopics records which rotor topics were being taught in the class an
+// attendance is for: in each theme of the class type's active rotor, the run covering the
+// check-in time. Attendance without a class, or for a class with no active rotor, links nothing.
+// PRE: a has been saved
+// POST: one link per theme with a topic running at check-in; returns the links saved
+func ExecuteLinkAttendanceTopics(ctx context.Context, a attendance.Attendance, deps LinkAttendanceTopicsDeps) ([]attendance.TopicLink, error) {
+	if a.ScheduleID == "" {
+		return nil, nil
+	}
+	sched, err := deps.ScheduleStore.GetByID(ctx, a.ScheduleID)
+	if err != nil || sched.ClassTypeID == "" {
+		return nil, nil
+	}
+	active, err := deps.RotorStore.GetActiveRotor(ctx, sched.ClassTypeID)
+	if err != nil {
+		return nil, nil // no active rotor for this class type
+	}
+	themes, err := deps.RotorStore.ListThemesByRotor(ctx, active.ID)
+	if err != nil {
+		return nil, err
+	}
+	var links []attendance.TopicLink
+	for _, th := range themes {
+		history, err := deps.RotorStore.ListSchedulesByTheme(ctx, th.ID)
+		if err != nil {
+			return nil, err
+		}
+		if run := rotor.ScheduleAt(history, a.CheckInTime); run != nil {
+			links = append(links, attendance.TopicLink{AttendanceID: a.ID, TopicID: run.TopicID, RotorThemeID: th.ID, RotorID: active.ID})
+		}
+	}
+	if len(links) == 0 {
+		return nil, nil
+	}
+	if err := deps.LinkStore.SaveTopicLinks(ctx, links); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// linkAttendanceTopics links a new attendance to its topics, best effort: a failure is logged
+// and never undoes the check-in.
+func linkAttendanceTopics(ctx context.Context, a attendance.Attendance, deps *LinkAttendanceTopicsDeps) {
+	if deps == nil {
+		return
+	}
+	if _, err := ExecuteLinkAttendanceTopics(ctx, a, *deps); err != nil {
+		slog.ErrorContext(ctx, "checkin_event", "event", "attendance_topic_link_failed", "attendance_id", a.ID, "error", err)
+	}
+}
@@ -0,0 +1,125 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/rotor"
+)
+
+// --- Mock stores for attendance topic tests ---
+
+type mockAttendanceTopicRotorStore struct {
+	active  map[string]rotor.Rotor           // key: classTypeID
+	themes  map[string][]rotor.RotorTheme    // key: rotorID
+	history map[string][]rotor.TopicSchedule // key: rotorThemeID
+}
+
+// GetActiveRotor implements AttendanceTopicRotorStore.
+// PRE: classTypeID is non-empty
+// POST: returns the active rotor or an error if none
+func (m *mockAttendanceTopicRotorStore) GetActiveRotor(_ context.Context, classTypeID string) (rotor.Rotor, error) {
+	r, ok := m.active[classTypeID]
+	if !ok {
+		return rotor.Rotor{}, errors.New("no active rotor")
+	}
+	return r, nil
+}
+
+// ListThemesByRotor implements AttendanceTopicRotorStore.
+// PRE: rotorID is non-empty
+// POST: returns the rotor's themes
+func (m *mockAttendanceTopicRotorStore) ListThemesByRotor(_ context.Context, rotorID string) ([]rotor.RotorTheme, error) {
+	return m.themes[rotorID], nil
+}
+
+// ListSchedulesByTheme implements AttendanceTopicRotorStore.
+// PRE: rotorThemeID is non-empty
+// POST: returns the theme's schedules
+func (m *mockAttendanceTopicRotorStore) ListSchedulesByTheme(_ context.Context, rotorThemeID string) ([]rotor.TopicSchedule, error) {
+	return m.history[rotorThemeID], nil
+}
+
+type mockAttendanceTopicLinkStore struct {
+	links []attendance.TopicLink
+}
+
+// SaveTopicLinks implements AttendanceTopicStore.
+// PRE: links are complete
+// POST: links are appended
+func (m *mockAttendanceTopicLinkStore) SaveTopicLinks(_ context.Context, links []attendance.TopicLink) error {
+	m.links = append(m.links, links...)
+	return nil
+}
+
+// newAttendanceTopicDeps returns a class type ct1 whose active rotor teaches Closed Guard in its
+// Guard theme around fixedTime and has an Escapes theme that is between topics.
+func newAttendanceTopicDeps(links *mockAttendanceTopicLinkStore) *LinkAttendanceTopicsDeps {
+	return &LinkAttendanceTopicsDeps{
+		ScheduleStore: &mockBulkSyncScheduleStore{},
+		RotorStore: &mockAttendanceTopicRotorStore{
+			active: map[string]rotor.Rotor{"ct1": {ID: "r1"}},
+			themes: map[string][]rotor.RotorTheme{"r1": {{ID: "th-guard"}, {ID: "th-escapes"}}},
+			history: map[string][]rotor.TopicSchedule{
+				"th-guard": {
+					{TopicID: "t-half", StartDate: fixedTime.AddDate(0, 0, -14), EndDate: fixedTime.AddDate(0, 0, -7), Status: rotor.ScheduleStatusCompleted},
+					{TopicID: "t-closed", StartDate: fixedTime.AddDate(0, 0, -7), EndDate: fixedTime.AddDate(0, 0, 7), Status: rotor.ScheduleStatusActive},
+				},
+				"th-escapes": {
+					{TopicID: "t-mount", StartDate: fixedTime.AddDate(0, 0, -14), EndDate: fixedTime.AddDate(0, 0, -1), Status: rotor.ScheduleStatusCompleted},
+				},
+			},
+		},
+		LinkStore: links,
+	}
+}
+
+// TestExecuteLinkAttendanceTopics verifies a check-in links the topic running in each theme at check-in time.
+func TestExecuteLinkAttendanceTopics(t *testing.T) {
+	tests := []struct {
+		name       string
+		attendance attendance.Attendance
+		want       []string // topic IDs
+	}{
+		{"running topic", attendance.Attendance{ID: "a1", ScheduleID: "s1", CheckInTime: fixedTime}, []string{"t-closed"}},
+		{"earlier run", attendance.Attendance{ID: "a2", ScheduleID: "s1", CheckInTime: fixedTime.AddDate(0, 0, -10)}, []string{"t-half", "t-mount"}},
+		{"no class", attendance.Attendance{ID: "a3", CheckInTime: fixedTime}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockAttendanceTopicLinkStore{}
+			links, err := ExecuteLinkAttendanceTopics(context.Background(), tt.attendance, *newAttendanceTopicDeps(store))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(links) != len(tt.want) || len(store.links) != len(tt.want) {
+				t.Fatalf("got %+v, want topics %v", links, tt.want)
+			}
+			for i, l := range links {
+				if l.TopicID != tt.want[i] || l.AttendanceID != tt.attendance.ID || l.RotorID != "r1" {
+					t.Errorf("link %d = %+v, want topic %s", i, l, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestExecuteBulkSyncCheckIns_LinksTopics verifies synced check-ins are linked to the topic taught when they happened.
+func TestExecuteBulkSyncCheckIns_LinksTopics(t *testing.T) {
+	links := &mockAttendanceTopicLinkStore{}
+	deps := newBulkSyncDeps(&mockBulkSyncAttendanceStore{})
+	deps.TopicDeps = newAttendanceTopicDeps(links)
+
+	_, err := ExecuteBulkSyncCheckIns(context.Background(), BulkSyncInput{
+		Records: []BulkSyncRecord{{ClientID: "c1", MemberID: "m1", ScheduleID: "s1", CheckInTime: fixedTime.Add(-time.Hour)}},
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(links.links) != 1 || links.links[0].AttendanceID != "att-1" || links.links[0].TopicID != "t-closed" {
+		t.Errorf("links = %+v, want att-1 linked to t-closed", links.links)
+	}
+}
@@ -3,7 +3,9 @@ package orchestrators
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
 
 	"workshop/internal/domain/account"
 )
@@ -37,6 +39,9 @@ var (
 	ErrInvalidCredentials = errors.New("invalid email or password")
 	ErrAccountLocked      = errors.New("account is locked due to too many failed attempts")
 	ErrPendingActivation  = errors.New("account is pending activation — check your email for the activation link")
+	ErrAccountSuspended   = errors.New("account is suspended")
+	ErrSSOEmailUnverified = errors.New("your sign-in provider has not verified this email address")
+	ErrSSONoAccount       = errors.New("no account uses this email address; sign in with your password or ask the gym for an invitation")
 )
 
 // ExecuteLogin validates credentials and returns account info for session creation.
@@ -50,19 +55,19 @@ func ExecuteLogin(ctx context.Context, input LoginInput, deps LoginDeps) (LoginR
 
 	acct, err := deps.AccountStore.GetByEmail(ctx, input.Email)
 	if err != nil {
-		slog.Info("auth_event", "event", "login_failed", "email", input.Email, "reason", "not_found")
+		slog.InfoContext(ctx, "auth_event", "event", "login_failed", "email", input.Email, "reason", "not_found")
 		return LoginResult{}, ErrInvalidCredentials
 	}
 
 	// Check if account is pending activation
 	if acct.IsPendingActivation() {
-		slog.Info("auth_event", "event", "login_blocked", "email", input.Email, "reason", "pending_activation")
+		slog.InfoContext(ctx, "auth_event", "event", "login_blocked", "email", input.Email, "reason", "pending_activation")
 		return LoginResult{}, ErrPendingActivation
 	}
 
 	// Check if account is locked
 	if acct.IsLocked() {
-		slog.Info("auth_event", "event", "login_blocked", "email", input.Email, "reason", "locked")
+		slog.WarnContext(ctx, "security_event", "event", "login_blocked", "email", input.Email, "reason", "locked", "locked_until", acct.LockedUntil)
 		return LoginResult{}, ErrAccountLocked
 	}
 
@@ -70,15 +75,26 @@ func ExecuteLogin(ctx context.Context, input LoginInput, deps LoginDeps) (LoginR
 	if err := acct.CheckPassword(input.Password); err != nil {
 		acct.RecordFailedLogin()
 		_ = deps.AccountStore.Save(ctx, acct)
-		slog.Info("auth_event", "event", "login_failed", "email", input.Email, "reason", "wrong_password", "failed_logins", acct.FailedLogins)
+		slog.InfoContext(ctx, "auth_event", "event", "login_failed", "email", input.Email, "reason", "wrong_password", "failed_logins", acct.FailedLogins)
+		if acct.IsLocked() {
+			slog.WarnContext(ctx, "security_event", "event", "account_locked", "account_id", acct.ID, "email", acct.Email, "failed_logins", acct.FailedLogins, "locked_until", acct.LockedUntil)
+			return LoginResult{}, ErrAccountLocked
+		}
 		return LoginResult{}, ErrInvalidCredentials
 	}
 
+	// Check if account is suspended, only once the password is right so the reason is not
+	// shown to anyone who merely knows the email
+	if acct.IsSuspended() {
+		slog.WarnContext(ctx, "security_event", "event", "login_blocked", "email", input.Email, "reason", "suspended")
+		return LoginResult{}, fmt.Errorf("%w: %s", ErrAccountSuspended, acct.SuspendedReason)
+	}
+
 	// Successful login — reset failed attempts
 	acct.ResetFailedLogins()
 	_ = deps.AccountStore.Save(ctx, acct)
 
-	slog.Info("auth_event", "event", "login_success", "email", input.Email, "role", acct.Role)
+	slog.InfoContext(ctx, "auth_event", "event", "login_success", "email", input.Email, "role", acct.Role)
 
 	return LoginResult{
 		AccountID:              acct.ID,
@@ -87,3 +103,63 @@ func ExecuteLogin(ctx context.Context, input LoginInput, deps LoginDeps) (LoginR
 		PasswordChangeRequired: acct.PasswordChangeRequired,
 	}, nil
 }
+
+// SSOLoginInput carries the identity an external sign-in provider verified.
+type SSOLoginInput struct {
+	Provider      string // display name, for logs
+	Subject       string // the provider's user ID, for logs
+	Email         string
+	EmailVerified bool
+}
+
+// ExecuteSSOLogin signs in the account whose email the provider has verified. Accounts are
+// never created this way and the role always comes from the account, so single sign-on only
+// opens accounts the gym already set up. The checks match password login; a sign-in
+// password change, if required, still applies to the next password login.
+// PRE: input came from a verified ID token
+// POST: Returns account info on success; failed password attempts are reset
+// INVARIANT: Account must be active, unlocked and not suspended
+func ExecuteSSOLogin(ctx context.Context, input SSOLoginInput, deps LoginDeps) (LoginResult, error) {
+	if !input.EmailVerified || input.Email == "" {
+		slog.InfoContext(ctx, "auth_event", "event", "sso_login_failed", "provider", input.Provider, "subject", input.Subject, "reason", "email_unverified")
+		return LoginResult{}, ErrSSOEmailUnverified
+	}
+
+	acct, err := deps.AccountStore.GetByEmail(ctx, input.Email)
+	if err != nil {
+		// Accounts are stored as typed; providers usually report lower case.
+		if lower := strings.ToLower(input.Email); lower != input.Email {
+			acct, err = deps.AccountStore.GetByEmail(ctx, lower)
+		}
+	}
+	if err != nil {
+		slog.InfoContext(ctx, "auth_event", "event", "sso_login_failed", "provider", input.Provider, "email", input.Email, "reason", "not_found")
+		return LoginResult{}, ErrSSONoAccount
+	}
+
+	if acct.IsPendingActivation() {
+		slog.InfoContext(ctx, "auth_event", "event", "login_blocked", "email", acct.Email, "reason", "pending_activation", "provider", input.Provider)
+		return LoginResult{}, ErrPendingActivation
+	}
+	if acct.IsLocked() {
+		slog.WarnContext(ctx, "security_event", "event", "login_blocked", "email", acct.Email, "reason", "locked", "provider", input.Provider, "locked_until", acct.LockedUntil)
+		return LoginResult{}, ErrAccountLocked
+	}
+	if acct.IsSuspended() {
+		slog.WarnContext(ctx, "security_event", "event", "login_blocked", "email", acct.Email, "reason", "suspended", "provider", input.Provider)
+		return LoginResult{}, fmt.Errorf("%w: %s", ErrAccountSuspended, acct.SuspendedReason)
+	}
+
+	if acct.FailedLogins > 0 {
+		acct.ResetFailedLogins()
+		_ = deps.AccountStore.Save(ctx, acct)
+	}
+
+	slog.InfoContext(ctx, "auth_event", "event", "login_success", "email", acct.Email, "role", acct.Role, "provider", input.Provider, "subject", input.Subject)
+
+	return LoginResult{
+		AccountID: acct.ID,
+		Email:     acct.Email,
+		Role:      acct.Role,
+	}, nil
+}
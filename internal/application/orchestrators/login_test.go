@@ -0,0 +1,69 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"workshop/internal/domain/account"
+)
+
+// TestExecuteLogin_Suspended verifies a suspended account is turned away with its reason,
+// but only once the password is right.
+func TestExecuteLogin_Suspended(t *testing.T) {
+	acct := account.Account{ID: "a1", Email: "ana@test.com", Role: account.RoleMember, Status: account.StatusActive}
+	if err := acct.SetPassword("correct-horse-battery"); err != nil {
+		t.Fatal(err)
+	}
+	if err := acct.Suspend("Unpaid fees"); err != nil {
+		t.Fatal(err)
+	}
+	deps := LoginDeps{AccountStore: &mockProvisionAccountStore{accounts: map[string]account.Account{acct.Email: acct}}}
+
+	_, err := ExecuteLogin(context.Background(), LoginInput{Email: acct.Email, Password: "wrong-password-here"}, deps)
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("wrong password: err = %v, want ErrInvalidCredentials", err)
+	}
+	_, err = ExecuteLogin(context.Background(), LoginInput{Email: acct.Email, Password: "correct-horse-battery"}, deps)
+	if !errors.Is(err, ErrAccountSuspended) || !strings.Contains(err.Error(), "Unpaid fees") {
+		t.Errorf("right password: err = %v, want ErrAccountSuspended with reason", err)
+	}
+}
+
+// TestExecuteSSOLogin verifies single sign-on links to an existing account by verified email,
+// keeps the account's role, and applies the same blocks as password login.
+func TestExecuteSSOLogin(t *testing.T) {
+	coach := account.Account{ID: "a1", Email: "Coach@Test.com", Role: account.RoleCoach, Status: account.StatusActive, FailedLogins: 2, PasswordChangeRequired: true}
+	pending := account.Account{ID: "a2", Email: "new@test.com", Role: account.RoleMember, Status: account.StatusPendingActivation}
+	store := &mockProvisionAccountStore{accounts: map[string]account.Account{"coach@test.com": coach, pending.Email: pending}}
+	deps := LoginDeps{AccountStore: store}
+
+	result, err := ExecuteSSOLogin(context.Background(), SSOLoginInput{Provider: "Google", Subject: "123", Email: "COACH@test.com", EmailVerified: true}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.AccountID != "a1" || result.Role != account.RoleCoach || result.PasswordChangeRequired {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if store.accounts["Coach@Test.com"].FailedLogins != 0 {
+		t.Error("failed password attempts should be reset")
+	}
+
+	tests := []struct {
+		name    string
+		input   SSOLoginInput
+		wantErr error
+	}{
+		{"unverified email", SSOLoginInput{Email: "coach@test.com"}, ErrSSOEmailUnverified},
+		{"no account", SSOLoginInput{Email: "stranger@test.com", EmailVerified: true}, ErrSSONoAccount},
+		{"pending activation", SSOLoginInput{Email: "new@test.com", EmailVerified: true}, ErrPendingActivation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ExecuteSSOLogin(context.Background(), tt.input, deps); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package orchestrators
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"time"
+
+	emailAdapter "workshop/internal/adapters/email"
+	"workshop/internal/domain/export"
+	"workshop/internal/domain/outbox"
+)
+
+// MemberExportStore defines the export request store interface needed by member data exports.
+type MemberExportStore interface {
+	GetByID(ctx context.Context, id string) (export.Request, error)
+	Save(ctx context.Context, r export.Request) error
+	ListByMemberID(ctx context.Context, memberID string, limit int) ([]export.Request, error)
+}
+
+// MemberExportOutboxStore defines the outbox store interface needed to queue an export.
+type MemberExportOutboxStore interface {
+	Save(ctx context.Context, e outbox.Entry) error
+}
+
+// MemberExportPayload is the outbox payload of a member_export entry.
+type MemberExportPayload struct {
+	ExportID string `json:"export_id"`
+}
+
+// RequestMemberExportInput carries a member's request for a copy of their data.
+type RequestMemberExportInput struct {
+	MemberID  string
+	IPAddress string
+	UserAgent string
+}
+
+// RequestMemberExportDeps holds dependencies for RequestMemberExport.
+type RequestMemberExportDeps struct {
+	ExportStore MemberExportStore
+	OutboxStore MemberExportOutboxStore
+	GenerateID  func() string
+	Now         func() time.Time
+}
+
+// ExecuteRequestMemberExport records a pending export and queues it on the outbox, where
+// MemberExportExecutor builds the file and emails the member a download link.
+// PRE: MemberID is non-empty
+// POST: A pending request and a member_export outbox entry are saved, or export.ErrInProgress
+// when the member's previous export is still being prepared
+func ExecuteRequestMemberExport(ctx context.Context, input RequestMemberExportInput, deps RequestMemberExportDeps) (export.Request, error) {
+	now := deps.Now()
+	recent, err := deps.ExportStore.ListByMemberID(ctx, input.MemberID, 1)
+	if err != nil {
+		return export.Request{}, err
+	}
+	if len(recent) > 0 && recent[0].InProgress(now) {
+		return export.Request{}, export.ErrInProgress
+	}
+
+	req := export.NewRequest(deps.GenerateID(), input.MemberID, export.FormatJSON, input.IPAddress, input.UserAgent)
+	req.RequestedAt = now
+	if err := req.Validate(); err != nil {
+		return export.Request{}, err
+	}
+	if err := deps.ExportStore.Save(ctx, *req); err != nil {
+		return export.Request{}, err
+	}
+
+	payload, err := json.Marshal(MemberExportPayload{ExportID: req.ID})
+	if err != nil {
+		return export.Request{}, err
+	}
+	entry := outbox.Entry{
+		ID:         deps.GenerateID(),
+		ActionType: outbox.ActionTypeMemberExport,
+		Payload:    string(payload),
+		Status:     outbox.StatusPending,
+		CreatedAt:  now,
+	}
+	if err := entry.Validate(); err != nil {
+		return export.Request{}, err
+	}
+	if err := deps.OutboxStore.Save(ctx, entry); err != nil {
+		return export.Request{}, err
+	}
+
+	slog.InfoContext(ctx, "privacy_event", "event", "export_requested", "export_id", req.ID, "member_id", req.MemberID)
+	return *req, nil
+}
+
+// MemberExportExecutor builds member data exports queued on the outbox.
+type MemberExportExecutor struct {
+	ExportStore MemberExportStore
+	// Assemble gathers everything held about a member.
+	Assemble func(ctx context.Context, memberID string) (export.Data, error)
+	// WriteFile stores the finished archive under a relative path.
+	WriteFile   func(path string, data []byte) error
+	GenerateID  func() string
+	EmailSender emailAdapter.Sender // optional: nil skips the email; the page still lists the export
+	FromAddress string
+	ReplyTo     string
+	BaseURL     string // public site URL the download link points at
+}
+
+// Execute builds the export named in the payload as a ZIP holding data.json, marks it
+// ready for export.DownloadWindow and emails the member a link. A request that is already
+// finished is left alone, so a replayed entry does not build a second file.
+// PRE: payload is valid JSON matching MemberExportPayload
+// POST: The request is ready and its file written, returns the export ID
+// INVARIANT: outbox entry status managed by caller
+func (e *MemberExportExecutor) Execute(ctx context.Context, payload string) (string, error) {
+	var p MemberExportPayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return "", fmt.Errorf("unmarshal payload: %w", err)
+	}
+	req, err := e.ExportStore.GetByID(ctx, p.ExportID)
+	if err != nil {
+		return "", fmt.Errorf("get export %s: %w", p.ExportID, err)
+	}
+	switch req.Status {
+	case export.StatusPending:
+		if err := req.MarkProcessing(); err != nil {
+			return "", err
+		}
+		if err := e.ExportStore.Save(ctx, req); err != nil {
+			return "", err
+		}
+	case export.StatusProcessing:
+		// An earlier attempt failed part way; build it again.
+	default:
+		return req.ID, nil
+	}
+
+	data, err := e.Assemble(ctx, req.MemberID)
+	if err != nil {
+		return "", fmt.Errorf("assemble export: %w", err)
+	}
+	archive, err := memberExportArchive(data)
+	if err != nil {
+		return "", err
+	}
+	path := "exports/" + e.GenerateID() + ".zip"
+	if err := e.WriteFile(path, archive); err != nil {
+		return "", fmt.Errorf("write export: %w", err)
+	}
+	if err := req.MarkReady(path, int64(len(archive))); err != nil {
+		return "", err
+	}
+	if err := e.ExportStore.Save(ctx, req); err != nil {
+		return "", err
+	}
+	slog.InfoContext(ctx, "privacy_event", "event", "export_ready", "export_id", req.ID, "member_id", req.MemberID, "bytes", req.FileSize)
+
+	if e.EmailSender != nil && data.Member.Email != "" {
+		days := int(export.DownloadWindow.Hours() / 24)
+		if _, err := e.EmailSender.Send(ctx, emailAdapter.SendRequest{
+			To:      []string{data.Member.Email},
+			From:    e.FromAddress,
+			Subject: "Your Workshop data export is ready",
+			HTML: fmt.Sprintf(`<p>Kia ora %s,</p>
+<p>The copy of your data you asked for is ready:</p>
+<p><a href="%s">Download your data</a></p>
+<p>You'll need to sign in. The link works for %d days.</p>`,
+				html.EscapeString(data.Member.Name), html.EscapeString(e.BaseURL+"/privacy/export"), days),
+			ReplyTo: e.ReplyTo,
+		}); err != nil {
+			// The export is ready either way; the member can still find it on the page.
+			slog.ErrorContext(ctx, "privacy_event", "event", "export_email_failed", "export_id", req.ID, "error", err)
+		}
+	}
+	return req.ID, nil
+}
+
+// memberExportArchive zips the export as data.json.
+func memberExportArchive(data export.Data) ([]byte, error) {
+	body, err := data.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: "data.json", Method: zip.Deflate, Modified: data.ExportMetadata.ExportDate})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExpiredMemberExportStore defines the export request store interface needed to expire exports.
+type ExpiredMemberExportStore interface {
+	Save(ctx context.Context, r export.Request) error
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]export.Request, error)
+}
+
+// ExpireMemberExportsDeps holds dependencies for ExpireMemberExports.
+type ExpireMemberExportsDeps struct {
+	ExportStore ExpiredMemberExportStore
+	// RemoveFile deletes a stored archive; a file that is already gone is not an error.
+	RemoveFile func(path string) error
+	Now        func() time.Time
+}
+
+// ExecuteExpireMemberExports deletes the files of exports whose download window has closed
+// and marks them expired. A file that cannot be removed is logged and retried next run.
+// PRE: deps are complete
+// POST: Returns how many exports were expired; failures are joined
+func ExecuteExpireMemberExports(ctx context.Context, deps ExpireMemberExportsDeps) (int, error) {
+	due, err := deps.ExportStore.ListExpired(ctx, deps.Now(), 500)
+	if err != nil {
+		return 0, err
+	}
+	expired := 0
+	var errs []error
+	for _, req := range due {
+		if req.FilePath != "" {
+			if err := deps.RemoveFile(req.FilePath); err != nil {
+				errs = append(errs, fmt.Errorf("remove export %s: %w", req.ID, err))
+				continue
+			}
+		}
+		if err := req.MarkExpired(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := deps.ExportStore.Save(ctx, req); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		expired++
+		slog.InfoContext(ctx, "privacy_event", "event", "export_expired", "export_id", req.ID, "member_id", req.MemberID)
+	}
+	return expired, errors.Join(errs...)
+}
@@ -0,0 +1,151 @@
+package orchestrators
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/export"
+	"workshop/internal/domain/outbox"
+)
+
+// --- Mock stores for member export tests ---
+
+type mockMemberExportStore struct {
+	requests map[string]export.Request
+}
+
+// GetByID returns the export request.
+// PRE: id is non-empty
+// POST: Returns the request or an error
+func (m *mockMemberExportStore) GetByID(_ context.Context, id string) (export.Request, error) {
+	r, ok := m.requests[id]
+	if !ok {
+		return export.Request{}, errors.New("not found")
+	}
+	return r, nil
+}
+
+// Save stores the export request.
+// PRE: r is valid
+// POST: The request is stored
+func (m *mockMemberExportStore) Save(_ context.Context, r export.Request) error {
+	m.requests[r.ID] = r
+	return nil
+}
+
+// ListByMemberID returns the member's requests, newest first.
+// PRE: memberID is non-empty
+// POST: Returns up to limit requests
+func (m *mockMemberExportStore) ListByMemberID(_ context.Context, memberID string, limit int) ([]export.Request, error) {
+	var latest *export.Request
+	for _, r := range m.requests {
+		if r.MemberID == memberID && (latest == nil || r.RequestedAt.After(latest.RequestedAt)) {
+			r := r
+			latest = &r
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	return []export.Request{*latest}, nil
+}
+
+// ListExpired returns finished requests whose window closed before now.
+// PRE: limit > 0
+// POST: Returns the expired requests
+func (m *mockMemberExportStore) ListExpired(_ context.Context, now time.Time, _ int) ([]export.Request, error) {
+	var list []export.Request
+	for _, r := range m.requests {
+		if (r.Status == export.StatusReady || r.Status == export.StatusDownloaded) && r.ExpiredAt != nil && !r.ExpiredAt.After(now) {
+			list = append(list, r)
+		}
+	}
+	return list, nil
+}
+
+type mockMemberExportOutboxStore struct {
+	entries []outbox.Entry
+}
+
+// Save records the outbox entry.
+// PRE: e is valid
+// POST: The entry is recorded
+func (m *mockMemberExportOutboxStore) Save(_ context.Context, e outbox.Entry) error {
+	m.entries = append(m.entries, e)
+	return nil
+}
+
+// TestMemberExport_RequestBuildAndExpire verifies a requested export is queued, blocks a
+// second request while it is built, is zipped and emailed once, and is removed on expiry.
+func TestMemberExport_RequestBuildAndExpire(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	n := 0
+	genID := func() string { n++; return fmt.Sprintf("id-%d", n) }
+	store := &mockMemberExportStore{requests: map[string]export.Request{}}
+	outboxStore := &mockMemberExportOutboxStore{}
+	deps := RequestMemberExportDeps{ExportStore: store, OutboxStore: outboxStore, GenerateID: genID, Now: func() time.Time { return now }}
+
+	req, err := ExecuteRequestMemberExport(ctx, RequestMemberExportInput{MemberID: "m1"}, deps)
+	if err != nil {
+		t.Fatalf("ExecuteRequestMemberExport: %v", err)
+	}
+	if len(outboxStore.entries) != 1 || outboxStore.entries[0].ActionType != outbox.ActionTypeMemberExport {
+		t.Fatalf("expected one member_export outbox entry, got %+v", outboxStore.entries)
+	}
+	if _, err := ExecuteRequestMemberExport(ctx, RequestMemberExportInput{MemberID: "m1"}, deps); !errors.Is(err, export.ErrInProgress) {
+		t.Errorf("second request = %v, want ErrInProgress", err)
+	}
+
+	files := map[string][]byte{}
+	sender := newMockEmailSender()
+	executor := &MemberExportExecutor{
+		ExportStore: store,
+		Assemble: func(_ context.Context, memberID string) (export.Data, error) {
+			return export.Data{Member: export.MemberData{ID: memberID, Name: "Marcus", Email: "marcus@example.com"}}, nil
+		},
+		WriteFile:   func(path string, data []byte) error { files[path] = data; return nil },
+		GenerateID:  genID,
+		EmailSender: sender,
+		BaseURL:     "https://gym.example",
+	}
+	for i := 0; i < 2; i++ {
+		if id, err := executor.Execute(ctx, outboxStore.entries[0].Payload); err != nil || id != req.ID {
+			t.Fatalf("Execute #%d = %q, %v", i+1, id, err)
+		}
+	}
+	built := store.requests[req.ID]
+	if !built.CanDownload() || len(files) != 1 || sender.sent != 1 {
+		t.Fatalf("expected one downloadable file and one email, got %+v, %d files, %d emails", built, len(files), sender.sent)
+	}
+	if !strings.Contains(sender.sentReqs[0].HTML, "https://gym.example/privacy/export") {
+		t.Errorf("expected the email to link to the export page, got %s", sender.sentReqs[0].HTML)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(files[built.FilePath]), int64(len(files[built.FilePath])))
+	if err != nil || len(zr.File) != 1 || zr.File[0].Name != "data.json" {
+		t.Fatalf("expected a ZIP holding data.json, got %v", err)
+	}
+	rc, _ := zr.File[0].Open()
+	body, _ := io.ReadAll(rc)
+	if !strings.Contains(string(body), `"name": "Marcus"`) {
+		t.Errorf("unexpected data.json: %s", body)
+	}
+
+	// Once the window has closed the file is removed and the request expired.
+	var removed []string
+	expired, err := ExecuteExpireMemberExports(ctx, ExpireMemberExportsDeps{
+		ExportStore: store,
+		RemoveFile:  func(path string) error { removed = append(removed, path); return nil },
+		Now:         func() time.Time { return built.ExpiredAt.Add(time.Minute) },
+	})
+	if err != nil || expired != 1 || len(removed) != 1 || store.requests[req.ID].Status != export.StatusExpired {
+		t.Errorf("expected one export expired and its file removed, got %d, %v, %v", expired, removed, err)
+	}
+}
@@ -0,0 +1,239 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/membertag"
+)
+
+// Member tag errors
+var (
+	ErrTagInSegment     = errors.New("tag is used by a saved segment; edit the segment first")
+	ErrSameTag          = errors.New("new tag name is the same as the old one")
+	ErrSegmentNotFound  = errors.New("segment not found")
+	ErrTagMemberMissing = errors.New("member not found")
+)
+
+// MemberTagStore defines the store interface needed by member tag orchestrators.
+type MemberTagStore interface {
+	AddTag(ctx context.Context, memberID, tag string, at time.Time) error
+	RemoveTag(ctx context.Context, memberID, tag string) error
+	RenameTag(ctx context.Context, from, to string) error
+	DeleteTag(ctx context.Context, tag string) error
+	GetSegment(ctx context.Context, id string) (membertag.Segment, error)
+	SaveSegment(ctx context.Context, value membertag.Segment) error
+	DeleteSegment(ctx context.Context, id string) error
+	ListSegments(ctx context.Context) ([]membertag.Segment, error)
+}
+
+// MemberTagMemberStore defines the member store interface needed by member tag orchestrators.
+type MemberTagMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+}
+
+// MemberTagDeps holds dependencies for member tag orchestrators.
+type MemberTagDeps struct {
+	TagStore    MemberTagStore
+	MemberStore MemberTagMemberStore
+	GenerateID  func() string
+	Now         func() time.Time
+}
+
+// TagMemberInput carries input for tagging or untagging a member.
+type TagMemberInput struct {
+	MemberID string
+	Tag      string
+	ActorID  string
+}
+
+// ExecuteTagMember puts a tag on a member. Tags are created by first use.
+// PRE: Caller is an admin (checked by the handler)
+// POST: Member carries the normalised tag, which is returned; ErrTagMemberMissing if no such member
+func ExecuteTagMember(ctx context.Context, input TagMemberInput, deps MemberTagDeps) (string, error) {
+	tag, err := membertag.NormalizeTag(input.Tag)
+	if err != nil {
+		return "", err
+	}
+	if _, err := deps.MemberStore.GetByID(ctx, input.MemberID); err != nil {
+		return "", ErrTagMemberMissing
+	}
+	if err := deps.TagStore.AddTag(ctx, input.MemberID, tag, deps.Now()); err != nil {
+		return "", err
+	}
+	slog.InfoContext(ctx, "member_tag_event", "event", "tagged", "member_id", input.MemberID, "tag", tag, "by", input.ActorID)
+	return tag, nil
+}
+
+// ExecuteUntagMember takes a tag off a member.
+// PRE: Caller is an admin (checked by the handler)
+// POST: Member no longer carries the tag; untagging a tag the member lacks is a no-op
+func ExecuteUntagMember(ctx context.Context, input TagMemberInput, deps MemberTagDeps) error {
+	tag, err := membertag.NormalizeTag(input.Tag)
+	if err != nil {
+		return err
+	}
+	if err := deps.TagStore.RemoveTag(ctx, input.MemberID, tag); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "member_tag_event", "event", "untagged", "member_id", input.MemberID, "tag", tag, "by", input.ActorID)
+	return nil
+}
+
+// RenameTagInput carries input for renaming a tag across all members.
+type RenameTagInput struct {
+	From    string
+	To      string
+	ActorID string
+}
+
+// ExecuteRenameTag renames a tag on every member and in every saved segment. Renaming onto an
+// existing tag merges the two.
+// PRE: Caller is an admin (checked by the handler)
+// POST: Members and segments carry To instead of From; ErrSameTag if they normalise alike
+func ExecuteRenameTag(ctx context.Context, input RenameTagInput, deps MemberTagDeps) (string, error) {
+	from, err := membertag.NormalizeTag(input.From)
+	if err != nil {
+		return "", err
+	}
+	to, err := membertag.NormalizeTag(input.To)
+	if err != nil {
+		return "", err
+	}
+	if from == to {
+		return "", ErrSameTag
+	}
+	if err := deps.TagStore.RenameTag(ctx, from, to); err != nil {
+		return "", err
+	}
+	segments, err := deps.TagStore.ListSegments(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, seg := range segments {
+		if !containsTag(seg.Tags, from) {
+			continue
+		}
+		for i, t := range seg.Tags {
+			if t == from {
+				seg.Tags[i] = to
+			}
+		}
+		if err := seg.Validate(); err != nil {
+			return "", err
+		}
+		if err := deps.TagStore.SaveSegment(ctx, seg); err != nil {
+			return "", err
+		}
+	}
+	slog.InfoContext(ctx, "member_tag_event", "event", "tag_renamed", "from", from, "to", to, "by", input.ActorID)
+	return to, nil
+}
+
+// DeleteTagInput carries input for removing a tag from all members.
+type DeleteTagInput struct {
+	Tag     string
+	ActorID string
+}
+
+// ExecuteDeleteTag removes a tag from every member.
+// PRE: Caller is an admin (checked by the handler)
+// POST: No member carries the tag; ErrTagInSegment if a saved segment still filters on it
+func ExecuteDeleteTag(ctx context.Context, input DeleteTagInput, deps MemberTagDeps) error {
+	tag, err := membertag.NormalizeTag(input.Tag)
+	if err != nil {
+		return err
+	}
+	segments, err := deps.TagStore.ListSegments(ctx)
+	if err != nil {
+		return err
+	}
+	for _, seg := range segments {
+		if containsTag(seg.Tags, tag) {
+			return ErrTagInSegment
+		}
+	}
+	if err := deps.TagStore.DeleteTag(ctx, tag); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "member_tag_event", "event", "tag_deleted", "tag", tag, "by", input.ActorID)
+	return nil
+}
+
+// SaveSegmentInput carries input for creating or editing a smart segment.
+type SaveSegmentInput struct {
+	ID                 string // optional: empty creates a new segment
+	Name               string
+	Tags               []string
+	Program            string
+	Status             string
+	AttendedWithinDays int
+	AbsentForDays      int
+	ActorID            string
+}
+
+// ExecuteSaveSegment creates or updates a saved segment.
+// PRE: Caller is an admin (checked by the handler)
+// POST: Segment saved with normalised tags; ErrSegmentNotFound when editing a missing segment
+func ExecuteSaveSegment(ctx context.Context, input SaveSegmentInput, deps MemberTagDeps) (membertag.Segment, error) {
+	seg := membertag.Segment{
+		ID:        deps.GenerateID(),
+		CreatedBy: input.ActorID,
+		CreatedAt: deps.Now(),
+	}
+	if input.ID != "" {
+		existing, err := deps.TagStore.GetSegment(ctx, input.ID)
+		if err != nil {
+			return membertag.Segment{}, ErrSegmentNotFound
+		}
+		seg = existing
+	}
+	seg.Name = input.Name
+	seg.Tags = input.Tags
+	seg.Program = strings.TrimSpace(input.Program)
+	seg.Status = strings.TrimSpace(input.Status)
+	seg.AttendedWithinDays = input.AttendedWithinDays
+	seg.AbsentForDays = input.AbsentForDays
+	if err := seg.Validate(); err != nil {
+		return membertag.Segment{}, err
+	}
+	if err := deps.TagStore.SaveSegment(ctx, seg); err != nil {
+		return membertag.Segment{}, err
+	}
+	slog.InfoContext(ctx, "member_tag_event", "event", "segment_saved", "segment_id", seg.ID, "name", seg.Name, "by", input.ActorID)
+	return seg, nil
+}
+
+// DeleteSegmentInput carries input for removing a saved segment.
+type DeleteSegmentInput struct {
+	ID      string
+	ActorID string
+}
+
+// ExecuteDeleteSegment removes a saved segment; the members and their tags are untouched.
+// PRE: Caller is an admin (checked by the handler)
+// POST: Segment removed; ErrSegmentNotFound if missing
+func ExecuteDeleteSegment(ctx context.Context, input DeleteSegmentInput, deps MemberTagDeps) error {
+	if _, err := deps.TagStore.GetSegment(ctx, input.ID); err != nil {
+		return ErrSegmentNotFound
+	}
+	if err := deps.TagStore.DeleteSegment(ctx, input.ID); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "member_tag_event", "event", "segment_deleted", "segment_id", input.ID, "by", input.ActorID)
+	return nil
+}
+
+// containsTag reports whether tags includes tag.
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/membertag"
+)
+
+// --- Mock stores for member tag tests ---
+
+type mockMemberTagStore struct {
+	tags     map[string]map[string]bool // tag -> member IDs
+	segments map[string]membertag.Segment
+}
+
+func newMockMemberTagStore() *mockMemberTagStore {
+	return &mockMemberTagStore{tags: map[string]map[string]bool{}, segments: map[string]membertag.Segment{}}
+}
+
+// AddTag tags the member.
+// PRE: none
+// POST: member carries the tag
+func (m *mockMemberTagStore) AddTag(_ context.Context, memberID, tag string, _ time.Time) error {
+	if m.tags[tag] == nil {
+		m.tags[tag] = map[string]bool{}
+	}
+	m.tags[tag][memberID] = true
+	return nil
+}
+
+// RemoveTag untags the member.
+// PRE: none
+// POST: member no longer carries the tag
+func (m *mockMemberTagStore) RemoveTag(_ context.Context, memberID, tag string) error {
+	delete(m.tags[tag], memberID)
+	return nil
+}
+
+// RenameTag moves every member from one tag to another.
+// PRE: none
+// POST: from is gone
+func (m *mockMemberTagStore) RenameTag(ctx context.Context, from, to string) error {
+	for id := range m.tags[from] {
+		m.AddTag(ctx, id, to, time.Time{})
+	}
+	delete(m.tags, from)
+	return nil
+}
+
+// DeleteTag removes the tag from everyone.
+// PRE: none
+// POST: tag is gone
+func (m *mockMemberTagStore) DeleteTag(_ context.Context, tag string) error {
+	delete(m.tags, tag)
+	return nil
+}
+
+// GetSegment returns a stored segment.
+// PRE: none
+// POST: Returns the segment or an error
+func (m *mockMemberTagStore) GetSegment(_ context.Context, id string) (membertag.Segment, error) {
+	s, ok := m.segments[id]
+	if !ok {
+		return membertag.Segment{}, errors.New("not found")
+	}
+	return s, nil
+}
+
+// SaveSegment stores the segment.
+// PRE: none
+// POST: segment stored by ID
+func (m *mockMemberTagStore) SaveSegment(_ context.Context, value membertag.Segment) error {
+	m.segments[value.ID] = value
+	return nil
+}
+
+// DeleteSegment removes the segment.
+// PRE: none
+// POST: segment removed
+func (m *mockMemberTagStore) DeleteSegment(_ context.Context, id string) error {
+	delete(m.segments, id)
+	return nil
+}
+
+// ListSegments returns every segment.
+// PRE: none
+// POST: Returns all segments
+func (m *mockMemberTagStore) ListSegments(_ context.Context) ([]membertag.Segment, error) {
+	var list []membertag.Segment
+	for _, s := range m.segments {
+		list = append(list, s)
+	}
+	return list, nil
+}
+
+type mockMemberTagMembers struct {
+	ids map[string]bool
+}
+
+// GetByID returns a member when the ID is known.
+// PRE: none
+// POST: Returns the member or an error
+func (m *mockMemberTagMembers) GetByID(_ context.Context, id string) (member.Member, error) {
+	if !m.ids[id] {
+		return member.Member{}, errors.New("not found")
+	}
+	return member.Member{ID: id}, nil
+}
+
+func newMemberTagDeps() (MemberTagDeps, *mockMemberTagStore) {
+	store := newMockMemberTagStore()
+	n := 0
+	return MemberTagDeps{
+		TagStore:    store,
+		MemberStore: &mockMemberTagMembers{ids: map[string]bool{"m1": true, "m2": true}},
+		GenerateID:  func() string { n++; return "seg-" + string(rune('0'+n)) },
+		Now:         func() time.Time { return time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) },
+	}, store
+}
+
+// TestExecuteTagMember_NormalisesAndChecksMember verifies tags are normalised on the way in
+// and unknown members are refused.
+func TestExecuteTagMember_NormalisesAndChecksMember(t *testing.T) {
+	deps, store := newMemberTagDeps()
+	ctx := context.Background()
+
+	tag, err := ExecuteTagMember(ctx, TagMemberInput{MemberID: "m1", Tag: "Needs Follow Up", ActorID: "admin-1"}, deps)
+	if err != nil || tag != "needs-follow-up" || !store.tags["needs-follow-up"]["m1"] {
+		t.Fatalf("expected m1 tagged needs-follow-up, got %q, %v", tag, err)
+	}
+	if _, err := ExecuteTagMember(ctx, TagMemberInput{MemberID: "nobody", Tag: "injured"}, deps); !errors.Is(err, ErrTagMemberMissing) {
+		t.Errorf("unknown member: expected ErrTagMemberMissing, got %v", err)
+	}
+	if _, err := ExecuteTagMember(ctx, TagMemberInput{MemberID: "m1", Tag: "  "}, deps); !errors.Is(err, membertag.ErrEmptyTag) {
+		t.Errorf("blank tag: expected ErrEmptyTag, got %v", err)
+	}
+	if err := ExecuteUntagMember(ctx, TagMemberInput{MemberID: "m1", Tag: "NEEDS-follow-up"}, deps); err != nil || store.tags["needs-follow-up"]["m1"] {
+		t.Errorf("untag: expected tag removed, got %v", err)
+	}
+}
+
+// TestExecuteRenameTag_UpdatesSegments verifies a rename reaches members and saved segments,
+// and a tag a segment uses cannot be deleted.
+func TestExecuteRenameTag_UpdatesSegments(t *testing.T) {
+	deps, store := newMemberTagDeps()
+	ctx := context.Background()
+	ExecuteTagMember(ctx, TagMemberInput{MemberID: "m1", Tag: "comp"}, deps)
+	seg, err := ExecuteSaveSegment(ctx, SaveSegmentInput{Name: "Comp team", Tags: []string{"Comp"}, ActorID: "admin-1"}, deps)
+	if err != nil {
+		t.Fatalf("save segment: %v", err)
+	}
+
+	if err := ExecuteDeleteTag(ctx, DeleteTagInput{Tag: "comp"}, deps); !errors.Is(err, ErrTagInSegment) {
+		t.Errorf("delete tag in use: expected ErrTagInSegment, got %v", err)
+	}
+	if _, err := ExecuteRenameTag(ctx, RenameTagInput{From: "comp", To: "Comp"}, deps); !errors.Is(err, ErrSameTag) {
+		t.Errorf("same name: expected ErrSameTag, got %v", err)
+	}
+	to, err := ExecuteRenameTag(ctx, RenameTagInput{From: "comp", To: "competitor"}, deps)
+	if err != nil || to != "competitor" {
+		t.Fatalf("rename: got %q, %v", to, err)
+	}
+	if !store.tags["competitor"]["m1"] || store.tags["comp"] != nil {
+		t.Errorf("expected m1 moved to competitor, got %v", store.tags)
+	}
+	if got := store.segments[seg.ID].Tags; len(got) != 1 || got[0] != "competitor" {
+		t.Errorf("expected segment tags renamed, got %v", got)
+	}
+}
+
+// TestExecuteSaveSegment_CreateEditDelete verifies segments are validated, edits keep the
+// creator, and missing segments are reported.
+func TestExecuteSaveSegment_CreateEditDelete(t *testing.T) {
+	deps, store := newMemberTagDeps()
+	ctx := context.Background()
+
+	if _, err := ExecuteSaveSegment(ctx, SaveSegmentInput{Name: "Everyone"}, deps); !errors.Is(err, membertag.ErrEmptySegment) {
+		t.Errorf("no filters: expected ErrEmptySegment, got %v", err)
+	}
+	seg, err := ExecuteSaveSegment(ctx, SaveSegmentInput{Name: "Lapsed", AbsentForDays: 30, ActorID: "admin-1"}, deps)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	edited, err := ExecuteSaveSegment(ctx, SaveSegmentInput{ID: seg.ID, Name: "Lapsed kids", Program: "kids", AbsentForDays: 30, ActorID: "admin-2"}, deps)
+	if err != nil || edited.ID != seg.ID || edited.CreatedBy != "admin-1" || edited.Program != "kids" {
+		t.Fatalf("edit: expected same segment with new program, got %+v, %v", edited, err)
+	}
+	if _, err := ExecuteSaveSegment(ctx, SaveSegmentInput{ID: "missing", Name: "X", Program: "kids"}, deps); !errors.Is(err, ErrSegmentNotFound) {
+		t.Errorf("edit missing: expected ErrSegmentNotFound, got %v", err)
+	}
+	if err := ExecuteDeleteSegment(ctx, DeleteSegmentInput{ID: seg.ID}, deps); err != nil || len(store.segments) != 0 {
+		t.Errorf("delete: expected segment removed, got %v", err)
+	}
+	if err := ExecuteDeleteSegment(ctx, DeleteSegmentInput{ID: seg.ID}, deps); !errors.Is(err, ErrSegmentNotFound) {
+		t.Errorf("delete again: expected ErrSegmentNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"workshop/internal/domain/audit"
+	"workshop/internal/domain/member"
+)
+
+// Member merge errors
+var (
+	ErrMergeIDsRequired    = errors.New("survivor and duplicate member IDs are required")
+	ErrMergeMemberNotFound = errors.New("member not found")
+)
+
+// MergeMemberStore defines the member store interface needed by the merge.
+type MergeMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+	Save(ctx context.Context, m member.Member) error
+}
+
+// MergeRecordStore defines the store interface that moves a member's history.
+type MergeRecordStore interface {
+	CountRecords(ctx context.Context, fromID, toID string) (map[string]int, map[string]int, error)
+	ReassignRecords(ctx context.Context, fromID, toID string) (map[string]int, map[string]int, error)
+}
+
+// MergeMembersInput names the record to keep and the duplicate folded into it.
+type MergeMembersInput struct {
+	SurvivorID  string
+	DuplicateID string
+	DryRun      bool // report what would move without changing anything
+	Actor       BackfillActor
+}
+
+// MergeMembersDeps holds dependencies for MergeMembers.
+type MergeMembersDeps struct {
+	MemberStore MergeMemberStore
+	RecordStore MergeRecordStore
+	AuditStore  BackfillAuditStore
+}
+
+// MergeMembersResult reports what a merge moved, or would move on a dry run.
+type MergeMembersResult struct {
+	Survivor     member.Member
+	Duplicate    member.Member
+	Records      map[string]int // record kind -> count moved to the survivor
+	Conflicts    map[string]int // record kind -> count the survivor already holds; left on the archived duplicate
+	AccountMoved bool           // the duplicate's login now belongs to the survivor
+	DryRun       bool
+}
+
+// ExecuteMergeMembers folds a duplicate member record into the surviving one. Attendance,
+// make-up credits, waivers, grading records, proposals and notes, messages, and goals are
+// re-parented to the survivor; a login account on the duplicate moves across when the
+// survivor has none. A record the survivor already holds, such as the same tag or a check-in
+// for the same class, is reported as a conflict and left on the duplicate, never deleted.
+// The duplicate is then archived, so it drops out of lists and check-in but can still be
+// looked up. A dry run returns the same report without changing anything.
+// PRE: Caller is an admin (checked by the handler)
+// POST: The duplicate owns only conflicting records and is archived; the merge is audited (not on a dry run)
+func ExecuteMergeMembers(ctx context.Context, input MergeMembersInput, deps MergeMembersDeps) (MergeMembersResult, error) {
+	if input.SurvivorID == "" || input.DuplicateID == "" {
+		return MergeMembersResult{}, ErrMergeIDsRequired
+	}
+	if input.SurvivorID == input.DuplicateID {
+		return MergeMembersResult{}, member.ErrMergeSameMember
+	}
+	survivor, err := deps.MemberStore.GetByID(ctx, input.SurvivorID)
+	if err != nil {
+		return MergeMembersResult{}, ErrMergeMemberNotFound
+	}
+	duplicate, err := deps.MemberStore.GetByID(ctx, input.DuplicateID)
+	if err != nil {
+		return MergeMembersResult{}, ErrMergeMemberNotFound
+	}
+	if survivor.IsArchived() {
+		return MergeMembersResult{}, member.ErrMergeArchivedSurvivor
+	}
+
+	result := MergeMembersResult{
+		Survivor:     survivor,
+		Duplicate:    duplicate,
+		AccountMoved: survivor.AccountID == "" && duplicate.AccountID != "",
+		DryRun:       input.DryRun,
+	}
+	if input.DryRun {
+		result.Records, result.Conflicts, err = deps.RecordStore.CountRecords(ctx, duplicate.ID, survivor.ID)
+		return result, err
+	}
+
+	result.Records, result.Conflicts, err = deps.RecordStore.ReassignRecords(ctx, duplicate.ID, survivor.ID)
+	if err != nil {
+		return MergeMembersResult{}, err
+	}
+
+	// Clear the duplicate's account first so the login never resolves to two members.
+	accountID := duplicate.AccountID
+	if result.AccountMoved {
+		duplicate.AccountID = ""
+	}
+	if !duplicate.IsArchived() {
+		if err := duplicate.Archive(); err != nil {
+			return MergeMembersResult{}, err
+		}
+	}
+	if err := deps.MemberStore.Save(ctx, duplicate); err != nil {
+		return MergeMembersResult{}, err
+	}
+	if result.AccountMoved {
+		survivor.AccountID = accountID
+		if err := deps.MemberStore.Save(ctx, survivor); err != nil {
+			return MergeMembersResult{}, err
+		}
+	}
+	result.Survivor, result.Duplicate = survivor, duplicate
+
+	slog.InfoContext(ctx, "member_event", "event", "member_merged",
+		"survivor_id", survivor.ID, "duplicate_id", duplicate.ID, "account_moved", result.AccountMoved, "by", input.Actor.AccountID)
+	mergeMembersAudit(ctx, result, input.Actor, deps)
+	return result, nil
+}
+
+// mergeMembersAudit records a merge in the audit log. A failure is logged, not returned:
+// the merge has already been saved.
+func mergeMembersAudit(ctx context.Context, result MergeMembersResult, actor BackfillActor, deps MergeMembersDeps) {
+	metadata, _ := json.Marshal(map[string]any{
+		"duplicate_id":    result.Duplicate.ID,
+		"duplicate_email": result.Duplicate.Email,
+		"account_moved":   result.AccountMoved,
+		"records":         result.Records,
+		"conflicts":       result.Conflicts,
+	})
+	event := audit.NewEvent(actor.AccountID, actor.Email, actor.Role, audit.CategoryMember, audit.ActionUpdate).
+		WithResource("member", result.Survivor.ID).
+		WithDescription("Merged duplicate "+result.Duplicate.Name+" <"+result.Duplicate.Email+"> into "+result.Survivor.Name).
+		WithRequest(actor.IPAddress, actor.UserAgent).
+		WithMetadata(string(metadata))
+	if err := deps.AuditStore.Save(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "member_event", "event", "merge_audit_failed", "survivor_id", result.Survivor.ID, "error", err)
+	}
+}
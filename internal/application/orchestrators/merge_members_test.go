@@ -0,0 +1,150 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"workshop/internal/domain/member"
+)
+
+type mockMergeRecordStore struct {
+	owned     map[string]map[string]int // member ID -> record kind -> count
+	conflicts map[string]int            // record kind -> count the survivor already holds
+}
+
+// CountRecords implements MergeRecordStore.
+// PRE: none
+// POST: Returns what would move and the conflicts without changing anything
+func (m *mockMergeRecordStore) CountRecords(_ context.Context, fromID, _ string) (map[string]int, map[string]int, error) {
+	out := map[string]int{}
+	for kind, n := range m.owned[fromID] {
+		out[kind] = n - m.conflicts[kind]
+	}
+	return out, m.conflicts, nil
+}
+
+// ReassignRecords implements MergeRecordStore.
+// PRE: none
+// POST: fromID's counts, less the conflicts, are added to toID's
+func (m *mockMergeRecordStore) ReassignRecords(ctx context.Context, fromID, toID string) (map[string]int, map[string]int, error) {
+	moved, conflicts, _ := m.CountRecords(ctx, fromID, toID)
+	if m.owned[toID] == nil {
+		m.owned[toID] = map[string]int{}
+	}
+	left := map[string]int{}
+	for kind, n := range moved {
+		m.owned[toID][kind] += n
+		if conflicts[kind] > 0 {
+			left[kind] = conflicts[kind]
+		}
+	}
+	m.owned[fromID] = left
+	return moved, conflicts, nil
+}
+
+func newMergeDeps() (MergeMembersDeps, *mockStatusMemberStore, *mockMergeRecordStore, *mockBackfillAuditStore) {
+	members := &mockStatusMemberStore{members: map[string]member.Member{
+		"keep":  {ID: "keep", Name: "John Smith", Email: "john@example.com", Program: member.ProgramAdults, Status: member.StatusActive},
+		"guest": {ID: "guest", AccountID: "acct-guest", Name: "Jon Smith", Email: "john+guest@example.com", Program: member.ProgramAdults, Status: member.StatusActive},
+		"gone":  {ID: "gone", Name: "Old Smith", Email: "old@example.com", Program: member.ProgramAdults, Status: member.StatusArchived},
+	}}
+	records := &mockMergeRecordStore{owned: map[string]map[string]int{
+		"keep":  {"attendance": 40, "waivers": 1},
+		"guest": {"attendance": 3, "waivers": 1, "messages": 2},
+	}}
+	auditStore := &mockBackfillAuditStore{}
+	return MergeMembersDeps{MemberStore: members, RecordStore: records, AuditStore: auditStore}, members, records, auditStore
+}
+
+// TestExecuteMergeMembers_DryRun verifies a dry run reports what would move and changes nothing.
+func TestExecuteMergeMembers_DryRun(t *testing.T) {
+	deps, members, records, auditStore := newMergeDeps()
+	result, err := ExecuteMergeMembers(context.Background(), MergeMembersInput{SurvivorID: "keep", DuplicateID: "guest", DryRun: true}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.DryRun || result.Records["attendance"] != 3 || result.Records["messages"] != 2 || !result.AccountMoved {
+		t.Errorf("unexpected report: %+v", result)
+	}
+	if records.owned["guest"]["attendance"] != 3 || members.members["guest"].Status != member.StatusActive || len(auditStore.events) != 0 {
+		t.Error("dry run should not change anything")
+	}
+}
+
+// TestExecuteMergeMembers verifies records and the login move to the survivor, the duplicate
+// is archived, and the merge is audited.
+func TestExecuteMergeMembers(t *testing.T) {
+	deps, members, records, auditStore := newMergeDeps()
+	result, err := ExecuteMergeMembers(context.Background(), MergeMembersInput{SurvivorID: "keep", DuplicateID: "guest", Actor: BackfillActor{AccountID: "admin-1"}}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.DryRun || result.Records["attendance"] != 3 {
+		t.Errorf("unexpected report: %+v", result)
+	}
+	if records.owned["keep"]["attendance"] != 43 || records.owned["keep"]["messages"] != 2 || len(records.owned["guest"]) != 0 {
+		t.Errorf("records not re-parented: %+v", records.owned)
+	}
+	if got := members.members["guest"]; got.Status != member.StatusArchived || got.AccountID != "" {
+		t.Errorf("duplicate should be archived without a login, got %+v", got)
+	}
+	if got := members.members["keep"]; got.AccountID != "acct-guest" || got.Status != member.StatusActive {
+		t.Errorf("survivor should take the login, got %+v", got)
+	}
+	if len(auditStore.events) != 1 || auditStore.events[0].ResourceID != "keep" {
+		t.Errorf("expected one audit event on the survivor, got %+v", auditStore.events)
+	}
+}
+
+// TestExecuteMergeMembers_Conflicts verifies records the survivor already holds are reported
+// by the dry run and the merge, and stay on the archived duplicate.
+func TestExecuteMergeMembers_Conflicts(t *testing.T) {
+	deps, _, records, auditStore := newMergeDeps()
+	records.conflicts = map[string]int{"waivers": 1}
+
+	preview, err := ExecuteMergeMembers(context.Background(), MergeMembersInput{SurvivorID: "keep", DuplicateID: "guest", DryRun: true}, deps)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	result, err := ExecuteMergeMembers(context.Background(), MergeMembersInput{SurvivorID: "keep", DuplicateID: "guest"}, deps)
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	for _, r := range []MergeMembersResult{preview, result} {
+		if r.Conflicts["waivers"] != 1 || r.Records["waivers"] != 0 || r.Records["attendance"] != 3 {
+			t.Errorf("dry run %v: records %v conflicts %v, want the waiver reported as a conflict", r.DryRun, r.Records, r.Conflicts)
+		}
+	}
+	if records.owned["guest"]["waivers"] != 1 || records.owned["keep"]["waivers"] != 1 {
+		t.Errorf("records = %+v, want each member to keep their own waiver", records.owned)
+	}
+	if len(auditStore.events) != 1 || !strings.Contains(auditStore.events[0].Metadata, `"conflicts":{"waivers":1}`) {
+		t.Errorf("expected the conflicts in the audit metadata, got %+v", auditStore.events)
+	}
+}
+
+// TestExecuteMergeMembers_Rejects verifies bad pairs are refused before anything moves.
+func TestExecuteMergeMembers_Rejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   MergeMembersInput
+		wantErr error
+	}{
+		{"same member", MergeMembersInput{SurvivorID: "keep", DuplicateID: "keep"}, member.ErrMergeSameMember},
+		{"missing duplicate", MergeMembersInput{SurvivorID: "keep", DuplicateID: "nobody"}, ErrMergeMemberNotFound},
+		{"archived survivor", MergeMembersInput{SurvivorID: "gone", DuplicateID: "guest"}, member.ErrMergeArchivedSurvivor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, _, records, _ := newMergeDeps()
+			if _, err := ExecuteMergeMembers(context.Background(), tt.input, deps); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if records.owned["guest"]["attendance"] != 3 {
+				t.Error("records should not move")
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/clip"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/message"
+)
+
+// Message errors.
+var (
+	ErrMessageThreadNotFound  = errors.New("message thread not found")
+	ErrMessageNotParticipant  = errors.New("you are not part of this conversation")
+	ErrBroadcastProgramNeeded = errors.New("program must be adults or kids")
+	ErrMessageClipNotFound    = errors.New("attached clip not found")
+	ErrMessageImageNotFound   = errors.New("attached image not found; upload it first")
+	ErrMemberAttachment       = errors.New("only coaches and admins can send attachments")
+)
+
+// MessageStoreForOrchestrator defines the message store interface needed by message orchestrators.
+type MessageStoreForOrchestrator interface {
+	GetByID(ctx context.Context, id string) (message.Message, error)
+	Save(ctx context.Context, m message.Message) error
+}
+
+// BroadcastMemberStore defines the member store interface needed to resolve a broadcast audience.
+type BroadcastMemberStore interface {
+	List(ctx context.Context, filter memberStore.ListFilter) ([]member.Member, error)
+}
+
+// MessageClipStore defines the clip store interface needed to attach library clips.
+type MessageClipStore interface {
+	GetByID(ctx context.Context, id string) (clip.Clip, error)
+}
+
+// --- Resolve Message Attachments ---
+
+// MessageAttachmentInput is one attachment as the sender asked for it.
+type MessageAttachmentInput struct {
+	Kind    string // clip, image or link
+	ClipID  string // clip
+	ImageID string // image: returned by the image upload
+	URL     string // link
+	Title   string // link: optional preview title; defaults to the URL's host
+}
+
+// ResolveMessageAttachmentsDeps holds dependencies for ExecuteResolveMessageAttachments.
+type ResolveMessageAttachmentsDeps struct {
+	ClipStore   MessageClipStore
+	ImageExists func(imageID string) bool
+}
+
+// ExecuteResolveMessageAttachments turns requested attachments into message attachments.
+// Clips are copied from the library so the embed survives later edits; images must already be uploaded.
+// PRE: none
+// POST: Returns validated attachments, or the first problem (unknown clip or image, or a validation error)
+func ExecuteResolveMessageAttachments(ctx context.Context, inputs []MessageAttachmentInput, deps ResolveMessageAttachmentsDeps) ([]message.Attachment, error) {
+	if len(inputs) > message.MaxAttachments {
+		return nil, message.ErrTooManyAttachments
+	}
+	var attachments []message.Attachment
+	for _, in := range inputs {
+		a := message.Attachment{Kind: in.Kind}
+		switch in.Kind {
+		case message.AttachmentClip:
+			c, err := deps.ClipStore.GetByID(ctx, in.ClipID)
+			if err != nil {
+				return nil, ErrMessageClipNotFound
+			}
+			a.ClipID, a.YouTubeID, a.StartSeconds, a.EndSeconds, a.Title = c.ID, c.YouTubeID, c.StartSeconds, c.EndSeconds, c.Title
+		case message.AttachmentImage:
+			if in.ImageID == "" || !deps.ImageExists(in.ImageID) {
+				return nil, ErrMessageImageNotFound
+			}
+			a.ImageID = in.ImageID
+		case message.AttachmentLink:
+			a.URL = strings.TrimSpace(in.URL)
+			a.Title = strings.TrimSpace(in.Title)
+		}
+		if err := a.Validate(); err != nil {
+			return nil, err
+		}
+		if a.Kind == message.AttachmentLink && a.Title == "" {
+			if u, err := url.Parse(a.URL); err == nil {
+				a.Title = u.Host
+			}
+		}
+		attachments = append(attachments, a)
+	}
+	return attachments, nil
+}
+
+// --- Reply To Message ---
+
+// ReplyToMessageInput carries input for the reply orchestrator.
+type ReplyToMessageInput struct {
+	ParentID string // any message in the thread
+	SenderID string // AccountID of the author
+	MemberID string // author's member ID when a member replies; empty for coach/admin
+	Content  string
+	// Attachments come from ExecuteResolveMessageAttachments; members cannot send them.
+	Attachments []message.Attachment
+}
+
+// ReplyToMessageDeps holds dependencies for ExecuteReplyToMessage.
+type ReplyToMessageDeps struct {
+	MessageStore MessageStoreForOrchestrator
+	GenerateID   func() string
+	Now          func() time.Time
+}
+
+// ExecuteReplyToMessage adds a reply to an existing thread.
+// Members may only reply to threads addressed to them; staff may reply to any thread.
+// PRE: ParentID and SenderID are non-empty
+// POST: Reply saved in the parent's thread; returns ErrMessageThreadNotFound or ErrMessageNotParticipant otherwise
+func ExecuteReplyToMessage(ctx context.Context, input ReplyToMessageInput, deps ReplyToMessageDeps) (message.Message, error) {
+	parent, err := deps.MessageStore.GetByID(ctx, input.ParentID)
+	if err != nil {
+		return message.Message{}, ErrMessageThreadNotFound
+	}
+	fromMember := input.MemberID != ""
+	if fromMember && parent.ReceiverID != input.MemberID {
+		return message.Message{}, ErrMessageNotParticipant
+	}
+	if fromMember && len(input.Attachments) > 0 {
+		return message.Message{}, ErrMemberAttachment
+	}
+
+	reply := parent.Reply(deps.GenerateID(), input.SenderID, fromMember, input.Content, deps.Now())
+	reply.Attachments = input.Attachments
+	if err := reply.Validate(); err != nil {
+		return message.Message{}, err
+	}
+	if err := deps.MessageStore.Save(ctx, reply); err != nil {
+		return message.Message{}, err
+	}
+
+	slog.InfoContext(ctx, "message_event", "event", "message_replied", "message_id", reply.ID, "thread_id", reply.ParentID, "sender_id", reply.SenderID, "from_member", fromMember, "attachments", len(reply.Attachments))
+	return reply, nil
+}
+
+// --- Broadcast Message ---
+
+// BroadcastMessageInput carries input for the broadcast orchestrator.
+type BroadcastMessageInput struct {
+	SenderID string // AccountID of the coach/admin
+	Program  string // member program: adults or kids
+	Subject  string
+	Content  string
+	// Attachments come from ExecuteResolveMessageAttachments and go to every recipient.
+	Attachments []message.Attachment
+}
+
+// BroadcastMessageDeps holds dependencies for ExecuteBroadcastMessage.
+type BroadcastMessageDeps struct {
+	MemberStore  BroadcastMemberStore
+	MessageStore MessageStoreForOrchestrator
+	GenerateID   func() string
+	Now          func() time.Time
+}
+
+// BroadcastMessageResult reports who a broadcast reached.
+type BroadcastMessageResult struct {
+	Sent       int
+	AccountIDs []string // linked accounts of recipients, for notifications
+}
+
+// ExecuteBroadcastMessage sends the same message to every active member in a program.
+// Each member gets their own thread, so replies stay private to that member.
+// PRE: SenderID is non-empty
+// POST: One message saved per active member; nothing saved if the message is invalid
+func ExecuteBroadcastMessage(ctx context.Context, input BroadcastMessageInput, deps BroadcastMessageDeps) (BroadcastMessageResult, error) {
+	if input.Program != member.ProgramAdults && input.Program != member.ProgramKids {
+		return BroadcastMessageResult{}, ErrBroadcastProgramNeeded
+	}
+	now := deps.Now()
+	// Validate once up front with a placeholder receiver so a bad message fails before any fan-out.
+	probe := message.Message{ID: "broadcast", SenderID: input.SenderID, ReceiverID: "broadcast", Subject: input.Subject, Content: input.Content, Attachments: input.Attachments, CreatedAt: now}
+	if err := probe.Validate(); err != nil {
+		return BroadcastMessageResult{}, err
+	}
+
+	members, err := deps.MemberStore.List(ctx, memberStore.ListFilter{Program: input.Program, Status: member.StatusActive, Limit: 10000})
+	if err != nil {
+		return BroadcastMessageResult{}, err
+	}
+
+	var result BroadcastMessageResult
+	for _, m := range members {
+		msg := message.Message{
+			ID:          deps.GenerateID(),
+			SenderID:    input.SenderID,
+			ReceiverID:  m.ID,
+			Subject:     input.Subject,
+			Content:     input.Content,
+			Attachments: input.Attachments,
+			CreatedAt:   now,
+		}
+		if err := deps.MessageStore.Save(ctx, msg); err != nil {
+			return result, err
+		}
+		result.Sent++
+		if m.AccountID != "" {
+			result.AccountIDs = append(result.AccountIDs, m.AccountID)
+		}
+	}
+
+	slog.InfoContext(ctx, "message_event", "event", "message_broadcast", "sender_id", input.SenderID, "program", input.Program, "sent", result.Sent)
+	return result, nil
+}
@@ -0,0 +1,198 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/clip"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/message"
+)
+
+// mockMessageStore implements MessageStoreForOrchestrator for testing.
+type mockMessageStore struct {
+	messages map[string]message.Message
+}
+
+// GetByID implements MessageStoreForOrchestrator.
+// PRE: id is non-empty
+// POST: returns message or error
+func (m *mockMessageStore) GetByID(_ context.Context, id string) (message.Message, error) {
+	msg, ok := m.messages[id]
+	if !ok {
+		return message.Message{}, errors.New("not found")
+	}
+	return msg, nil
+}
+
+// Save implements MessageStoreForOrchestrator.
+// PRE: msg is valid
+// POST: message is stored
+func (m *mockMessageStore) Save(_ context.Context, msg message.Message) error {
+	m.messages[msg.ID] = msg
+	return nil
+}
+
+// mockBroadcastMemberStore implements BroadcastMemberStore for testing.
+type mockBroadcastMemberStore struct {
+	members []member.Member
+}
+
+// List implements BroadcastMemberStore.
+// PRE: none
+// POST: returns members matching program and status
+func (m *mockBroadcastMemberStore) List(_ context.Context, filter memberStore.ListFilter) ([]member.Member, error) {
+	var out []member.Member
+	for _, mem := range m.members {
+		if mem.Program == filter.Program && mem.Status == filter.Status {
+			out = append(out, mem)
+		}
+	}
+	return out, nil
+}
+
+// mockMessageClipStore implements MessageClipStore for testing.
+type mockMessageClipStore struct {
+	clips map[string]clip.Clip
+}
+
+// GetByID implements MessageClipStore.
+// PRE: id is non-empty
+// POST: returns the clip or an error
+func (m *mockMessageClipStore) GetByID(_ context.Context, id string) (clip.Clip, error) {
+	c, ok := m.clips[id]
+	if !ok {
+		return clip.Clip{}, errors.New("not found")
+	}
+	return c, nil
+}
+
+func sequentialIDs() func() string {
+	n := 0
+	return func() string {
+		n++
+		return fmt.Sprintf("id-%d", n)
+	}
+}
+
+// TestExecuteReplyToMessage verifies who may reply and that replies join the thread.
+func TestExecuteReplyToMessage(t *testing.T) {
+	store := &mockMessageStore{messages: map[string]message.Message{
+		"root": {ID: "root", SenderID: "coach1", ReceiverID: "m1", Subject: "Comp prep", Content: "Ready?", CreatedAt: fixedTime},
+		"r1":   {ID: "r1", ParentID: "root", SenderID: "acct1", ReceiverID: "m1", FromMember: true, Content: "Yes", CreatedAt: fixedTime},
+	}}
+	deps := ReplyToMessageDeps{MessageStore: store, GenerateID: sequentialIDs(), Now: fixedNow}
+
+	tests := []struct {
+		name    string
+		input   ReplyToMessageInput
+		wantErr error
+	}{
+		{"member replies to own thread", ReplyToMessageInput{ParentID: "root", SenderID: "acct1", MemberID: "m1", Content: "See you"}, nil},
+		{"coach replies to a reply", ReplyToMessageInput{ParentID: "r1", SenderID: "coach2", Content: "Good"}, nil},
+		{"other member refused", ReplyToMessageInput{ParentID: "root", SenderID: "acct2", MemberID: "m2", Content: "Hi"}, ErrMessageNotParticipant},
+		{"missing thread", ReplyToMessageInput{ParentID: "nope", SenderID: "coach1", Content: "Hi"}, ErrMessageThreadNotFound},
+		{"empty content", ReplyToMessageInput{ParentID: "root", SenderID: "coach1"}, message.ErrEmptyContent},
+		{"coach attaches a link without text", ReplyToMessageInput{ParentID: "root", SenderID: "coach1", Attachments: []message.Attachment{{Kind: message.AttachmentLink, URL: "https://example.com"}}}, nil},
+		{"member attachment refused", ReplyToMessageInput{ParentID: "root", SenderID: "acct1", MemberID: "m1", Content: "Look", Attachments: []message.Attachment{{Kind: message.AttachmentLink, URL: "https://example.com"}}}, ErrMemberAttachment},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply, err := ExecuteReplyToMessage(context.Background(), tt.input, deps)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if reply.ParentID != "root" || reply.ReceiverID != "m1" || reply.FromMember != (tt.input.MemberID != "") {
+				t.Errorf("unexpected reply: %+v", reply)
+			}
+			if _, ok := store.messages[reply.ID]; !ok {
+				t.Error("reply was not saved")
+			}
+		})
+	}
+}
+
+// TestExecuteBroadcastMessage verifies only active members of the program receive a thread each.
+func TestExecuteBroadcastMessage(t *testing.T) {
+	store := &mockMessageStore{messages: map[string]message.Message{}}
+	members := &mockBroadcastMemberStore{members: []member.Member{
+		{ID: "m1", AccountID: "a1", Program: member.ProgramAdults, Status: member.StatusActive},
+		{ID: "m2", Program: member.ProgramAdults, Status: member.StatusActive},
+		{ID: "m3", AccountID: "a3", Program: member.ProgramAdults, Status: member.StatusInactive},
+		{ID: "m4", AccountID: "a4", Program: member.ProgramKids, Status: member.StatusActive},
+	}}
+	deps := BroadcastMessageDeps{MemberStore: members, MessageStore: store, GenerateID: sequentialIDs(), Now: fixedNow}
+
+	result, err := ExecuteBroadcastMessage(context.Background(), BroadcastMessageInput{SenderID: "coach1", Program: member.ProgramAdults, Subject: "Grading", Content: "Saturday 10am"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Sent != 2 || len(result.AccountIDs) != 1 || result.AccountIDs[0] != "a1" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	for _, msg := range store.messages {
+		if msg.ParentID != "" || (msg.ReceiverID != "m1" && msg.ReceiverID != "m2") {
+			t.Errorf("unexpected broadcast message: %+v", msg)
+		}
+	}
+
+	if _, err := ExecuteBroadcastMessage(context.Background(), BroadcastMessageInput{SenderID: "coach1", Program: "seniors", Content: "x"}, deps); !errors.Is(err, ErrBroadcastProgramNeeded) {
+		t.Errorf("expected ErrBroadcastProgramNeeded, got %v", err)
+	}
+	before := len(store.messages)
+	if _, err := ExecuteBroadcastMessage(context.Background(), BroadcastMessageInput{SenderID: "coach1", Program: member.ProgramAdults}, deps); !errors.Is(err, message.ErrEmptyContent) {
+		t.Errorf("expected ErrEmptyContent, got %v", err)
+	}
+	if len(store.messages) != before {
+		t.Error("invalid broadcast should not save any message")
+	}
+}
+
+// TestExecuteResolveMessageAttachments verifies clips are copied from the library, images must be
+// uploaded, and links get a default title.
+func TestExecuteResolveMessageAttachments(t *testing.T) {
+	deps := ResolveMessageAttachmentsDeps{
+		ClipStore:   &mockMessageClipStore{clips: map[string]clip.Clip{"c1": {ID: "c1", Title: "Armbar from guard", YouTubeID: "dQw4w9WgXcQ", StartSeconds: 30, EndSeconds: 45}}},
+		ImageExists: func(id string) bool { return id == "img1" },
+	}
+	got, err := ExecuteResolveMessageAttachments(context.Background(), []MessageAttachmentInput{
+		{Kind: message.AttachmentClip, ClipID: "c1"},
+		{Kind: message.AttachmentImage, ImageID: "img1"},
+		{Kind: message.AttachmentLink, URL: " https://example.com/guide "},
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []message.Attachment{
+		{Kind: message.AttachmentClip, ClipID: "c1", YouTubeID: "dQw4w9WgXcQ", StartSeconds: 30, EndSeconds: 45, Title: "Armbar from guard"},
+		{Kind: message.AttachmentImage, ImageID: "img1"},
+		{Kind: message.AttachmentLink, URL: "https://example.com/guide", Title: "example.com"},
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		input   MessageAttachmentInput
+		wantErr error
+	}{
+		{"unknown clip", MessageAttachmentInput{Kind: message.AttachmentClip, ClipID: "c9"}, ErrMessageClipNotFound},
+		{"image not uploaded", MessageAttachmentInput{Kind: message.AttachmentImage, ImageID: "img9"}, ErrMessageImageNotFound},
+		{"script link", MessageAttachmentInput{Kind: message.AttachmentLink, URL: "javascript:alert(1)"}, message.ErrInvalidLinkURL},
+		{"unknown kind", MessageAttachmentInput{Kind: "video"}, message.ErrUnknownAttachmentKind},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ExecuteResolveMessageAttachments(context.Background(), []MessageAttachmentInput{tt.input}, deps); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -75,7 +75,7 @@ func ExecuteCreateNotice(ctx context.Context, input CreateNoticeInput, deps Crea
 		return notice.Notice{}, err
 	}
 
-	slog.Info("notice_event", "event", "notice_created", "notice_id", n.ID, "type", n.Type, "created_by", input.CreatedBy)
+	slog.InfoContext(ctx, "notice_event", "event", "notice_created", "notice_id", n.ID, "type", n.Type, "created_by", input.CreatedBy)
 	return n, nil
 }
 
@@ -146,7 +146,7 @@ func ExecuteEditNotice(ctx context.Context, input EditNoticeInput, deps EditNoti
 		return notice.Notice{}, err
 	}
 
-	slog.Info("notice_event", "event", "notice_edited", "notice_id", n.ID, "title", n.Title)
+	slog.InfoContext(ctx, "notice_event", "event", "notice_edited", "notice_id", n.ID, "title", n.Title)
 	return n, nil
 }
 
@@ -188,7 +188,7 @@ func ExecutePublishNotice(ctx context.Context, input PublishNoticeInput, deps Pu
 		return notice.Notice{}, err
 	}
 
-	slog.Info("notice_event", "event", "notice_published", "notice_id", n.ID, "published_by", input.PublisherID)
+	slog.InfoContext(ctx, "notice_event", "event", "notice_published", "notice_id", n.ID, "published_by", input.PublisherID)
 	return n, nil
 }
 
@@ -238,6 +238,6 @@ func ExecutePinNotice(ctx context.Context, input PinNoticeInput, deps PinNoticeD
 	if !input.Pinned {
 		action = "notice_unpinned"
 	}
-	slog.Info("notice_event", "event", action, "notice_id", n.ID)
+	slog.InfoContext(ctx, "notice_event", "event", action, "notice_id", n.ID)
 	return n, nil
 }
@@ -0,0 +1,274 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html"
+	"log/slog"
+	"time"
+
+	emailAdapter "workshop/internal/adapters/email"
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/adapters/webpush"
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/notice"
+	"workshop/internal/domain/notification"
+	"workshop/internal/domain/program"
+)
+
+// NotificationStoreForOrchestrator defines the store interface needed to deliver notifications.
+type NotificationStoreForOrchestrator interface {
+	Save(ctx context.Context, n notification.Notification) error
+	ListPreferences(ctx context.Context, accountID string) ([]notification.Preference, error)
+}
+
+// NotifyAccountLookup resolves an account's email address for the email channel.
+type NotifyAccountLookup interface {
+	GetByID(ctx context.Context, id string) (account.Account, error)
+}
+
+// NotifyPushStore lists and prunes an account's Web Push subscriptions for the push channel.
+type NotifyPushStore interface {
+	ListPushSubscriptions(ctx context.Context, accountID string) ([]notification.PushSubscription, error)
+	DeletePushSubscription(ctx context.Context, endpoint string) error
+}
+
+// NotifyInput carries a single domain event to fan out to recipients.
+type NotifyInput struct {
+	AccountIDs []string // recipients; empty and duplicate IDs are skipped
+	Kind       string   // notification.Kind*
+	Title      string
+	Body       string
+	Link       string        // optional in-app URL
+	PushTTL    time.Duration // optional: how long a push stays worth delivering; zero uses webpush.DefaultTTL
+}
+
+// NotifyDeps holds dependencies for Notify.
+type NotifyDeps struct {
+	NotificationStore NotificationStoreForOrchestrator
+	AccountStore      NotifyAccountLookup // optional: required for the email channel
+	EmailSender       emailAdapter.Sender // optional: nil disables the email channel
+	EmailFrom         string
+	EmailReplyTo      string
+	PushStore         NotifyPushStore // optional: required for the push channel
+	PushSender        webpush.Sender  // optional: nil disables the push channel
+	GenerateID        func() string
+	Now               func() time.Time
+}
+
+// NotifyResult reports how many recipients were reached on each channel.
+type NotifyResult struct {
+	InApp  int
+	Emails int
+	Pushes int // recipients reached on at least one browser
+}
+
+// ExecuteNotify delivers a notification to each recipient according to their channel preferences.
+// PRE: input.Kind is a valid notification kind; input.Title is non-empty
+// POST: An in-app notification is saved for recipients with in-app enabled;
+// an email is sent to recipients with email enabled; a push goes to every
+// subscribed browser of recipients with push enabled
+// INVARIANT: Email and push delivery failures are logged and never fail the call
+func ExecuteNotify(ctx context.Context, input NotifyInput, deps NotifyDeps) (NotifyResult, error) {
+	if !notification.IsValidKind(input.Kind) {
+		return NotifyResult{}, notification.ErrInvalidKind
+	}
+	if input.Title == "" {
+		return NotifyResult{}, notification.ErrEmptyTitle
+	}
+
+	now := deps.Now()
+	seen := make(map[string]bool, len(input.AccountIDs))
+	var result NotifyResult
+	var errs []error
+
+	for _, accountID := range input.AccountIDs {
+		if accountID == "" || seen[accountID] {
+			continue
+		}
+		seen[accountID] = true
+
+		pref, err := preferenceFor(ctx, accountID, input.Kind, deps.NotificationStore)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if pref.InApp {
+			n := notification.Notification{
+				ID:        deps.GenerateID(),
+				AccountID: accountID,
+				Kind:      input.Kind,
+				Title:     input.Title,
+				Body:      input.Body,
+				Link:      input.Link,
+				CreatedAt: now,
+			}
+			if err := n.Validate(); err != nil {
+				return result, err
+			}
+			if err := deps.NotificationStore.Save(ctx, n); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			result.InApp++
+		}
+
+		if pref.Email && deps.EmailSender != nil && deps.AccountStore != nil {
+			if sendNotificationEmail(ctx, accountID, input, deps) {
+				result.Emails++
+			}
+		}
+
+		if pref.Push && deps.PushSender != nil && deps.PushStore != nil {
+			if sendNotificationPush(ctx, accountID, input, deps) {
+				result.Pushes++
+			}
+		}
+	}
+
+	slog.InfoContext(ctx, "notification_event", "event", "notified", "kind", input.Kind,
+		"recipients", len(seen), "in_app", result.InApp, "emails", result.Emails, "pushes", result.Pushes)
+	return result, errors.Join(errs...)
+}
+
+// preferenceFor returns the account's stored preference for kind, or the default.
+func preferenceFor(ctx context.Context, accountID, kind string, store NotificationStoreForOrchestrator) (notification.Preference, error) {
+	stored, err := store.ListPreferences(ctx, accountID)
+	if err != nil {
+		return notification.Preference{}, err
+	}
+	for _, p := range stored {
+		if p.Kind == kind {
+			return p, nil
+		}
+	}
+	return notification.DefaultPreference(accountID, kind), nil
+}
+
+// sendNotificationEmail mirrors a notification to the recipient's inbox. Returns true on success.
+func sendNotificationEmail(ctx context.Context, accountID string, input NotifyInput, deps NotifyDeps) bool {
+	acct, err := deps.AccountStore.GetByID(ctx, accountID)
+	if err != nil || acct.Email == "" {
+		slog.WarnContext(ctx, "notification_event", "event", "email_skipped", "account_id", accountID, "reason", "no email address")
+		return false
+	}
+	body := "<p><strong>" + html.EscapeString(input.Title) + "</strong></p>"
+	if input.Body != "" {
+		body += "<p>" + html.EscapeString(input.Body) + "</p>"
+	}
+	_, err = deps.EmailSender.Send(ctx, emailAdapter.SendRequest{
+		To:      []string{acct.Email},
+		From:    deps.EmailFrom,
+		Subject: input.Title,
+		HTML:    body,
+		ReplyTo: deps.EmailReplyTo,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "notification_event", "event", "email_failed", "account_id", accountID, "kind", input.Kind, "error", err)
+		return false
+	}
+	return true
+}
+
+// pushPayload is the JSON the service worker receives in its push event.
+type pushPayload struct {
+	Kind  string
+	Title string
+	Body  string
+	Link  string
+}
+
+// sendNotificationPush sends a notification to each of the recipient's subscribed browsers,
+// forgetting subscriptions the push service reports as gone. Returns true if any accepted it.
+func sendNotificationPush(ctx context.Context, accountID string, input NotifyInput, deps NotifyDeps) bool {
+	subs, err := deps.PushStore.ListPushSubscriptions(ctx, accountID)
+	if err != nil {
+		slog.ErrorContext(ctx, "notification_event", "event", "push_failed", "account_id", accountID, "error", err)
+		return false
+	}
+	payload, err := json.Marshal(pushPayload{Kind: input.Kind, Title: input.Title, Body: input.Body, Link: input.Link})
+	if err != nil {
+		return false
+	}
+	delivered := false
+	for _, sub := range subs {
+		err := deps.PushSender.Send(ctx, webpush.Subscription{Endpoint: sub.Endpoint, P256dh: sub.P256dh, Auth: sub.Auth},
+			webpush.Message{Payload: payload, TTL: input.PushTTL})
+		switch {
+		case errors.Is(err, webpush.ErrSubscriptionGone):
+			if err := deps.PushStore.DeletePushSubscription(ctx, sub.Endpoint); err != nil {
+				slog.ErrorContext(ctx, "notification_event", "event", "push_prune_failed", "account_id", accountID, "error", err)
+			}
+			slog.InfoContext(ctx, "notification_event", "event", "push_subscription_gone", "account_id", accountID)
+		case err != nil:
+			slog.ErrorContext(ctx, "notification_event", "event", "push_failed", "account_id", accountID, "kind", input.Kind, "error", err)
+		default:
+			delivered = true
+		}
+	}
+	return delivered
+}
+
+// --- Notice audience ---
+
+// NoticeAudienceMemberStore defines the member store interface needed to resolve notice recipients.
+type NoticeAudienceMemberStore interface {
+	List(ctx context.Context, filter memberStore.ListFilter) ([]member.Member, error)
+}
+
+// NoticeAudienceClassTypeStore resolves the class type targeted by a class-specific notice.
+type NoticeAudienceClassTypeStore interface {
+	GetByID(ctx context.Context, id string) (classtype.ClassType, error)
+}
+
+// NoticeAudienceProgramStore resolves the program a class type belongs to.
+type NoticeAudienceProgramStore interface {
+	GetByID(ctx context.Context, id string) (program.Program, error)
+}
+
+// NoticeAudienceDeps holds dependencies for ResolveNoticeAudience.
+type NoticeAudienceDeps struct {
+	MemberStore    NoticeAudienceMemberStore
+	ClassTypeStore NoticeAudienceClassTypeStore
+	ProgramStore   NoticeAudienceProgramStore
+}
+
+// ResolveNoticeAudience returns the account IDs of members who should be told about a published notice.
+// Class-specific notices reach members of the class type's program; grading notices reach no one
+// here, because the ceremony planner tells its invitees itself; all other notices reach every member.
+// PRE: n is published
+// POST: Returns account IDs of non-archived members with a linked account
+func ResolveNoticeAudience(ctx context.Context, n notice.Notice, deps NoticeAudienceDeps) ([]string, error) {
+	if n.Type == notice.TypeGrading {
+		return nil, nil
+	}
+	filter := memberStore.ListFilter{Limit: 10000}
+	if n.Type == notice.TypeClassSpecific && n.TargetID != "" {
+		ct, err := deps.ClassTypeStore.GetByID(ctx, n.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		prog, err := deps.ProgramStore.GetByID(ctx, ct.ProgramID)
+		if err != nil {
+			return nil, err
+		}
+		filter.Program = prog.Type
+	}
+
+	members, err := deps.MemberStore.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, m := range members {
+		if m.AccountID == "" || m.IsArchived() {
+			continue
+		}
+		ids = append(ids, m.AccountID)
+	}
+	return ids, nil
+}
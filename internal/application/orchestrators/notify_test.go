@@ -0,0 +1,261 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	emailAdapter "workshop/internal/adapters/email"
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/adapters/webpush"
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/notice"
+	"workshop/internal/domain/notification"
+	"workshop/internal/domain/program"
+)
+
+// --- Mock stores for notify tests ---
+
+type mockNotifyStore struct {
+	saved []notification.Notification
+	prefs map[string][]notification.Preference
+}
+
+// Save implements NotificationStoreForOrchestrator.
+// PRE: n is valid
+// POST: n is appended
+func (m *mockNotifyStore) Save(_ context.Context, n notification.Notification) error {
+	m.saved = append(m.saved, n)
+	return nil
+}
+
+// ListPreferences implements NotificationStoreForOrchestrator.
+// PRE: accountID is non-empty
+// POST: returns stored preferences for the account
+func (m *mockNotifyStore) ListPreferences(_ context.Context, accountID string) ([]notification.Preference, error) {
+	return m.prefs[accountID], nil
+}
+
+type mockNotifyAccounts struct{}
+
+// GetByID implements NotifyAccountLookup.
+// PRE: id is non-empty
+// POST: returns an account with a derived email address
+func (m *mockNotifyAccounts) GetByID(_ context.Context, id string) (account.Account, error) {
+	return account.Account{ID: id, Email: id + "@example.com"}, nil
+}
+
+type mockNotifySender struct {
+	sent []emailAdapter.SendRequest
+}
+
+// Send implements emailAdapter.Sender.
+// PRE: req has at least one recipient
+// POST: req is recorded
+func (m *mockNotifySender) Send(_ context.Context, req emailAdapter.SendRequest) (emailAdapter.SendResult, error) {
+	m.sent = append(m.sent, req)
+	return emailAdapter.SendResult{}, nil
+}
+
+// SendBatch implements emailAdapter.Sender.
+// PRE: none
+// POST: each request is recorded
+func (m *mockNotifySender) SendBatch(ctx context.Context, reqs []emailAdapter.SendRequest) ([]emailAdapter.SendResult, error) {
+	for _, r := range reqs {
+		m.Send(ctx, r)
+	}
+	return make([]emailAdapter.SendResult, len(reqs)), nil
+}
+
+// TestExecuteNotify_RespectsPreferences verifies per-account channel preferences and recipient de-duplication.
+func TestExecuteNotify_RespectsPreferences(t *testing.T) {
+	store := &mockNotifyStore{prefs: map[string][]notification.Preference{
+		"a2": {{AccountID: "a2", Kind: notification.KindGradingApproved, InApp: false, Email: true}},
+	}}
+	sender := &mockNotifySender{}
+	n := 0
+
+	result, err := ExecuteNotify(context.Background(), NotifyInput{
+		AccountIDs: []string{"a1", "a2", "a1", ""},
+		Kind:       notification.KindGradingApproved,
+		Title:      "Grading approved: blue belt",
+	}, NotifyDeps{
+		NotificationStore: store,
+		AccountStore:      &mockNotifyAccounts{},
+		EmailSender:       sender,
+		GenerateID:        func() string { n++; return fmt.Sprintf("n-%d", n) },
+		Now:               fixedNow,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.InApp != 1 || result.Emails != 1 {
+		t.Errorf("result = %+v, want 1 in-app and 1 email", result)
+	}
+	if len(store.saved) != 1 || store.saved[0].AccountID != "a1" || !store.saved[0].CreatedAt.Equal(fixedTime) {
+		t.Errorf("expected a single in-app notification for a1, got %+v", store.saved)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].To[0] != "a2@example.com" {
+		t.Errorf("expected one email to a2, got %+v", sender.sent)
+	}
+}
+
+type mockNotifyPushStore struct {
+	subs    []notification.PushSubscription
+	deleted []string
+}
+
+// ListPushSubscriptions implements NotifyPushStore.
+// PRE: accountID is non-empty
+// POST: returns the account's subscriptions
+func (m *mockNotifyPushStore) ListPushSubscriptions(_ context.Context, accountID string) ([]notification.PushSubscription, error) {
+	var out []notification.PushSubscription
+	for _, s := range m.subs {
+		if s.AccountID == accountID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// DeletePushSubscription implements NotifyPushStore.
+// PRE: endpoint is non-empty
+// POST: the endpoint is recorded as deleted
+func (m *mockNotifyPushStore) DeletePushSubscription(_ context.Context, endpoint string) error {
+	m.deleted = append(m.deleted, endpoint)
+	return nil
+}
+
+type mockPushSender struct {
+	gone map[string]bool
+	sent []webpush.Message
+}
+
+// Send implements webpush.Sender.
+// PRE: none
+// POST: records the message, or reports the subscription gone
+func (m *mockPushSender) Send(_ context.Context, sub webpush.Subscription, msg webpush.Message) error {
+	if m.gone[sub.Endpoint] {
+		return webpush.ErrSubscriptionGone
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// TestExecuteNotify_Push verifies the push channel reaches every subscribed browser of
+// opted-in accounts and forgets subscriptions the push service reports as gone.
+func TestExecuteNotify_Push(t *testing.T) {
+	store := &mockNotifyStore{prefs: map[string][]notification.Preference{
+		"a1": {{AccountID: "a1", Kind: notification.KindMessageReceived, InApp: false, Push: true}},
+	}}
+	pushStore := &mockNotifyPushStore{subs: []notification.PushSubscription{
+		{AccountID: "a1", Endpoint: "https://push.example.com/phone"},
+		{AccountID: "a1", Endpoint: "https://push.example.com/old-laptop"},
+		{AccountID: "a2", Endpoint: "https://push.example.com/a2"},
+	}}
+	sender := &mockPushSender{gone: map[string]bool{"https://push.example.com/old-laptop": true}}
+
+	result, err := ExecuteNotify(context.Background(), NotifyInput{
+		AccountIDs: []string{"a1", "a2"},
+		Kind:       notification.KindMessageReceived,
+		Title:      "New message from Coach Sam",
+		Link:       "/messages",
+	}, NotifyDeps{NotificationStore: store, PushStore: pushStore, PushSender: sender, GenerateID: fixedID, Now: fixedNow})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Pushes != 1 || result.InApp != 1 || len(sender.sent) != 1 {
+		t.Errorf("result = %+v with %d pushes sent, want a1 pushed once and a2 in-app only", result, len(sender.sent))
+	}
+	if len(pushStore.deleted) != 1 || pushStore.deleted[0] != "https://push.example.com/old-laptop" {
+		t.Errorf("expected the gone subscription deleted, got %v", pushStore.deleted)
+	}
+	var payload pushPayload
+	if len(sender.sent) == 1 {
+		json.Unmarshal(sender.sent[0].Payload, &payload)
+	}
+	if payload.Title != "New message from Coach Sam" || payload.Link != "/messages" || payload.Kind != notification.KindMessageReceived {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+// TestExecuteNotify_InvalidKind verifies unknown kinds are refused.
+func TestExecuteNotify_InvalidKind(t *testing.T) {
+	_, err := ExecuteNotify(context.Background(), NotifyInput{AccountIDs: []string{"a1"}, Kind: "birthday", Title: "x"},
+		NotifyDeps{NotificationStore: &mockNotifyStore{}, GenerateID: fixedID, Now: fixedNow})
+	if err != notification.ErrInvalidKind {
+		t.Errorf("expected ErrInvalidKind, got %v", err)
+	}
+}
+
+type mockAudienceMembers struct {
+	members []member.Member
+}
+
+// List implements NoticeAudienceMemberStore.
+// PRE: none
+// POST: returns members matching the program filter
+func (m *mockAudienceMembers) List(_ context.Context, filter memberStore.ListFilter) ([]member.Member, error) {
+	var out []member.Member
+	for _, mem := range m.members {
+		if filter.Program == "" || mem.Program == filter.Program {
+			out = append(out, mem)
+		}
+	}
+	return out, nil
+}
+
+type mockAudienceClassTypes struct{}
+
+// GetByID implements NoticeAudienceClassTypeStore.
+// PRE: id is non-empty
+// POST: returns a class type in the kids program
+func (m *mockAudienceClassTypes) GetByID(_ context.Context, id string) (classtype.ClassType, error) {
+	return classtype.ClassType{ID: id, ProgramID: "p-kids", Name: "Kids Gi"}, nil
+}
+
+type mockAudiencePrograms struct{}
+
+// GetByID implements NoticeAudienceProgramStore.
+// PRE: id is non-empty
+// POST: returns the kids program
+func (m *mockAudiencePrograms) GetByID(_ context.Context, id string) (program.Program, error) {
+	return program.Program{ID: id, Name: "Kids", Type: program.TypeKids}, nil
+}
+
+// TestResolveNoticeAudience verifies school-wide, class-specific and grading notice targeting.
+func TestResolveNoticeAudience(t *testing.T) {
+	deps := NoticeAudienceDeps{
+		MemberStore: &mockAudienceMembers{members: []member.Member{
+			{ID: "m1", AccountID: "a1", Program: program.TypeAdults, Status: member.StatusActive},
+			{ID: "m2", AccountID: "a2", Program: program.TypeKids, Status: member.StatusActive},
+			{ID: "m3", AccountID: "a3", Program: program.TypeKids, Status: member.StatusArchived},
+			{ID: "m4", Program: program.TypeKids, Status: member.StatusActive},
+		}},
+		ClassTypeStore: &mockAudienceClassTypes{},
+		ProgramStore:   &mockAudiencePrograms{},
+	}
+
+	all, err := ResolveNoticeAudience(context.Background(), notice.Notice{Type: notice.TypeSchoolWide}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("school-wide audience = %v, want a1 and a2", all)
+	}
+
+	kids, err := ResolveNoticeAudience(context.Background(), notice.Notice{Type: notice.TypeClassSpecific, TargetID: "ct-kids"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(kids) != 1 || kids[0] != "a2" {
+		t.Errorf("class-specific audience = %v, want [a2]", kids)
+	}
+
+	if ceremony, err := ResolveNoticeAudience(context.Background(), notice.Notice{Type: notice.TypeGrading, TargetID: "ev-1"}, deps); err != nil || len(ceremony) != 0 {
+		t.Errorf("grading audience = %v, %v; want none", ceremony, err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"workshop/internal/domain/observation"
+	"workshop/internal/domain/rubric"
 )
 
 // ObservationStoreForOrchestrator defines the store interface needed by observation orchestrators.
@@ -19,43 +20,69 @@ type ObservationStoreForOrchestrator interface {
 
 // CreateObservationInput carries input for the create observation orchestrator.
 type CreateObservationInput struct {
-	MemberID string
-	Content  string
-	AuthorID string // AccountID of coach/admin creating the observation
+	MemberID   string
+	Content    string
+	Visibility string // optional: defaults to coaches-only
+	AuthorID   string // AccountID of coach/admin creating the observation
+	Rubric     RubricScoresInput
 }
 
 // CreateObservationDeps holds dependencies for CreateObservation.
 type CreateObservationDeps struct {
-	ObservationStore ObservationStoreForOrchestrator
-	GenerateID       func() string
-	Now              func() time.Time
+	ObservationStore    ObservationStoreForOrchestrator
+	RubricTemplateStore RubricTemplateStore // optional: nil rejects scored observations
+	RubricScoreStore    RubricScoreStore    // optional: nil rejects scored observations
+	GenerateID          func() string
+	Now                 func() time.Time
 }
 
-// ExecuteCreateObservation creates a new private observation on a member's profile.
-// PRE: MemberID, Content, and AuthorID must be non-empty
-// POST: Observation created with generated ID and timestamps
+// ExecuteCreateObservation creates a new observation on a member's profile, with optional rubric scores.
+// PRE: MemberID, Content, and AuthorID must be non-empty; Rubric.Scores match Rubric.TemplateID's criteria when given
+// POST: Observation created with generated ID and timestamps, then its scores; nothing is saved when validation fails
 func ExecuteCreateObservation(ctx context.Context, input CreateObservationInput, deps CreateObservationDeps) (observation.Observation, error) {
 	if input.AuthorID == "" {
 		return observation.Observation{}, errors.New("author ID is required")
 	}
 
 	obs := observation.Observation{
-		ID:        deps.GenerateID(),
-		MemberID:  input.MemberID,
-		AuthorID:  input.AuthorID,
-		Content:   input.Content,
-		CreatedAt: deps.Now(),
+		ID:         deps.GenerateID(),
+		MemberID:   input.MemberID,
+		AuthorID:   input.AuthorID,
+		Content:    input.Content,
+		Visibility: input.Visibility,
+		CreatedAt:  deps.Now(),
+	}
+	if obs.Visibility == "" {
+		obs.Visibility = observation.VisibilityCoaches
 	}
 
 	if err := obs.Validate(); err != nil {
 		return observation.Observation{}, err
 	}
+	scores, err := prepareRubricScores(ctx, input.Rubric, rubric.Score{
+		MemberID:  obs.MemberID,
+		Source:    rubric.SourceObservation,
+		SourceID:  obs.ID,
+		AuthorID:  obs.AuthorID,
+		CreatedAt: obs.CreatedAt,
+	}, deps.RubricTemplateStore, deps.GenerateID)
+	if err != nil {
+		return observation.Observation{}, err
+	}
+	if scores != nil && deps.RubricScoreStore == nil {
+		return observation.Observation{}, errRubricUnavailable
+	}
 
 	if err := deps.ObservationStore.Save(ctx, obs); err != nil {
 		return observation.Observation{}, err
 	}
+	if scores != nil {
+		if err := deps.RubricScoreStore.SaveAll(ctx, scores); err != nil {
+			return observation.Observation{}, err
+		}
+	}
 
-	slog.Info("observation_event", "event", "observation_created", "observation_id", obs.ID, "member_id", obs.MemberID, "author_id", obs.AuthorID)
+	slog.InfoContext(ctx, "observation_event", "event", "observation_created", "observation_id", obs.ID, "member_id", obs.MemberID, "author_id", obs.AuthorID, "visibility", obs.Visibility, "rubric_scores", len(scores))
 	return obs, nil
 }
 
@@ -100,6 +127,48 @@ func ExecuteEditObservation(ctx context.Context, input EditObservationInput, dep
 		return observation.Observation{}, err
 	}
 
-	slog.Info("observation_event", "event", "observation_edited", "observation_id", obs.ID)
+	slog.InfoContext(ctx, "observation_event", "event", "observation_edited", "observation_id", obs.ID)
+	return obs, nil
+}
+
+// --- Set Observation Visibility ---
+
+// ErrObservationNotFound is returned when the observation is missing or hidden from the actor.
+var ErrObservationNotFound = errors.New("observation not found")
+
+// SetObservationVisibilityInput carries input for the set observation visibility orchestrator.
+type SetObservationVisibilityInput struct {
+	ObservationID string
+	Visibility    string
+	ActorRole     string // coach or admin
+	ActorID       string // AccountID of the coach or admin
+}
+
+// SetObservationVisibilityDeps holds dependencies for SetObservationVisibility.
+type SetObservationVisibilityDeps struct {
+	ObservationStore ObservationStoreForOrchestrator
+	Now              func() time.Time
+}
+
+// ExecuteSetObservationVisibility changes who may read an observation, e.g. sharing it
+// with the member as feedback. Coaches cannot change observations they cannot see.
+// PRE: ActorRole is coach or admin
+// POST: Observation visibility and UpdatedAt updated
+func ExecuteSetObservationVisibility(ctx context.Context, input SetObservationVisibilityInput, deps SetObservationVisibilityDeps) (observation.Observation, error) {
+	if input.Visibility == "" || !observation.IsValidVisibility(input.Visibility) {
+		return observation.Observation{}, observation.ErrInvalidVisibility
+	}
+	obs, err := deps.ObservationStore.GetByID(ctx, input.ObservationID)
+	if err != nil || !obs.VisibleTo(input.ActorRole, false) {
+		return observation.Observation{}, ErrObservationNotFound
+	}
+
+	obs.Visibility = input.Visibility
+	obs.UpdatedAt = deps.Now()
+	if err := deps.ObservationStore.Save(ctx, obs); err != nil {
+		return observation.Observation{}, err
+	}
+
+	slog.InfoContext(ctx, "observation_event", "event", "observation_visibility_changed", "observation_id", obs.ID, "visibility", obs.Visibility, "actor_id", input.ActorID)
 	return obs, nil
 }
@@ -182,3 +182,39 @@ func TestExecuteEditObservation_NotFound(t *testing.T) {
 		t.Error("expected error for not found observation")
 	}
 }
+
+// TestExecuteCreateObservation_DefaultVisibility verifies new observations are coaches-only unless shared.
+func TestExecuteCreateObservation_DefaultVisibility(t *testing.T) {
+	deps := CreateObservationDeps{ObservationStore: newMockObservationStore(), GenerateID: fixedID, Now: fixedNow}
+	obs, err := ExecuteCreateObservation(context.Background(), CreateObservationInput{MemberID: "member-001", Content: "Hips low", AuthorID: "coach-001"}, deps)
+	if err != nil || obs.Visibility != observation.VisibilityCoaches {
+		t.Errorf("expected coaches-only, got %q, %v", obs.Visibility, err)
+	}
+	_, err = ExecuteCreateObservation(context.Background(), CreateObservationInput{MemberID: "member-001", Content: "Hips low", AuthorID: "coach-001", Visibility: "everyone"}, deps)
+	if !errors.Is(err, observation.ErrInvalidVisibility) {
+		t.Errorf("err = %v, want ErrInvalidVisibility", err)
+	}
+}
+
+// TestExecuteSetObservationVisibility verifies coaches can share observations they can see
+// but cannot touch admin-only ones, and unknown levels are rejected.
+func TestExecuteSetObservationVisibility(t *testing.T) {
+	store := newMockObservationStore()
+	store.observations["obs-1"] = observation.Observation{ID: "obs-1", MemberID: "m1", AuthorID: "coach-001", Content: "Great frames", Visibility: observation.VisibilityCoaches}
+	store.observations["obs-2"] = observation.Observation{ID: "obs-2", MemberID: "m1", AuthorID: "admin-001", Content: "Fees overdue", Visibility: observation.VisibilityAdmin}
+	deps := SetObservationVisibilityDeps{ObservationStore: store, Now: fixedNow}
+
+	obs, err := ExecuteSetObservationVisibility(context.Background(), SetObservationVisibilityInput{ObservationID: "obs-1", Visibility: observation.VisibilityShared, ActorRole: "coach"}, deps)
+	if err != nil || obs.Visibility != observation.VisibilityShared || store.observations["obs-1"].UpdatedAt.IsZero() {
+		t.Errorf("expected obs-1 shared, got %+v, %v", obs, err)
+	}
+	if _, err := ExecuteSetObservationVisibility(context.Background(), SetObservationVisibilityInput{ObservationID: "obs-2", Visibility: observation.VisibilityShared, ActorRole: "coach"}, deps); !errors.Is(err, ErrObservationNotFound) {
+		t.Errorf("coach on admin-only: err = %v, want ErrObservationNotFound", err)
+	}
+	if _, err := ExecuteSetObservationVisibility(context.Background(), SetObservationVisibilityInput{ObservationID: "obs-2", Visibility: observation.VisibilityCoaches, ActorRole: "admin"}, deps); err != nil {
+		t.Errorf("admin on admin-only: %v", err)
+	}
+	if _, err := ExecuteSetObservationVisibility(context.Background(), SetObservationVisibilityInput{ObservationID: "obs-1", Visibility: "", ActorRole: "admin"}, deps); !errors.Is(err, observation.ErrInvalidVisibility) {
+		t.Errorf("empty visibility: err = %v, want ErrInvalidVisibility", err)
+	}
+}
@@ -31,7 +31,7 @@ func ExecuteOutboxRetry(ctx context.Context, deps OutboxRetryDeps) error {
 		return nil
 	}
 
-	slog.Info("outbox_retry_start", "count", len(entries))
+	slog.InfoContext(ctx, "outbox_retry_start", "count", len(entries))
 
 	var processed, succeeded, failed int
 	baseDelay := 1 * time.Minute
@@ -44,7 +44,7 @@ func ExecuteOutboxRetry(ctx context.Context, deps OutboxRetryDeps) error {
 		if !entry.LastAttemptedAt.IsZero() {
 			nextRetry := entry.LastAttemptedAt.Add(entry.NextRetryDelay(baseDelay, maxDelay))
 			if time.Now().Before(nextRetry) {
-				slog.Debug("outbox_retry_skipped_backoff", "entry_id", entry.ID, "next_retry", nextRetry)
+				slog.DebugContext(ctx, "outbox_retry_skipped_backoff", "entry_id", entry.ID, "next_retry", nextRetry)
 				continue
 			}
 		}
@@ -66,20 +66,20 @@ func ExecuteOutboxRetry(ctx context.Context, deps OutboxRetryDeps) error {
 		if err != nil {
 			entry.MarkFailed(err)
 			failed++
-			slog.Error("outbox_retry_failed", "entry_id", entry.ID, "action", entry.ActionType, "attempt", entry.Attempts, "error", err)
+			slog.ErrorContext(ctx, "outbox_retry_failed", "entry_id", entry.ID, "action", entry.ActionType, "attempt", entry.Attempts, "error", err)
 		} else {
 			entry.MarkSuccess("") // External ID set by specific handler
 			succeeded++
-			slog.Info("outbox_retry_succeeded", "entry_id", entry.ID, "action", entry.ActionType, "attempt", entry.Attempts)
+			slog.InfoContext(ctx, "outbox_retry_succeeded", "entry_id", entry.ID, "action", entry.ActionType, "attempt", entry.Attempts)
 		}
 
 		// Save updated entry
 		if saveErr := deps.OutboxStore.Save(ctx, entry); saveErr != nil {
-			slog.Error("outbox_retry_save_failed", "entry_id", entry.ID, "error", saveErr)
+			slog.ErrorContext(ctx, "outbox_retry_save_failed", "entry_id", entry.ID, "error", saveErr)
 		}
 	}
 
-	slog.Info("outbox_retry_complete", "processed", processed, "succeeded", succeeded, "failed", failed)
+	slog.InfoContext(ctx, "outbox_retry_complete", "processed", processed, "succeeded", succeeded, "failed", failed)
 	return nil
 }
 
@@ -100,7 +100,7 @@ func retryGitHubIssue(ctx context.Context, entry domainOutbox.Entry) error {
 
 	// GitHub integration would be implemented here
 	// For now, log that we would create the issue
-	slog.Info("outbox_retry_github_issue", "title", payload.Title, "repository", payload.Repository)
+	slog.InfoContext(ctx, "outbox_retry_github_issue", "title", payload.Title, "repository", payload.Repository)
 
 	// TODO: Implement actual GitHub API call
 	// This requires GitHub token configuration (issue #298)
@@ -125,7 +125,7 @@ func retryEmail(ctx context.Context, entry domainOutbox.Entry) error {
 
 	// Email sending would be implemented here
 	// This requires email sender configuration
-	slog.Info("outbox_retry_email", "to_count", len(payload.To), "subject", payload.Subject)
+	slog.InfoContext(ctx, "outbox_retry_email", "to_count", len(payload.To), "subject", payload.Subject)
 
 	// TODO: Implement actual email sending using configured email sender
 
@@ -168,7 +168,7 @@ func StartOutboxRetryScheduler(ctx context.Context, deps OutboxRetryDeps, config
 				return
 			case <-ticker.C:
 				if err := ExecuteOutboxRetry(ctx, deps); err != nil {
-					slog.Error("outbox_retry_scheduler_error", "error", err)
+					slog.ErrorContext(ctx, "outbox_retry_scheduler_error", "error", err)
 				}
 			}
 		}
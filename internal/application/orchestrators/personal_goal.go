@@ -0,0 +1,259 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	attendanceDomain "workshop/internal/domain/attendance"
+	"workshop/internal/domain/personalgoal"
+)
+
+// ErrGoalNotOwned is returned when a member checks in on someone else's goal.
+var ErrGoalNotOwned = errors.New("goal belongs to another member")
+
+// PersonalGoalStoreForOrchestrator defines the store interface needed by personal goal orchestrators.
+type PersonalGoalStoreForOrchestrator interface {
+	GetByID(ctx context.Context, id string) (personalgoal.PersonalGoal, error)
+	Save(ctx context.Context, g personalgoal.PersonalGoal) error
+	ListActive(ctx context.Context) ([]personalgoal.PersonalGoal, error)
+}
+
+// GoalCheckInStore defines the store interface needed to record check-ins.
+type GoalCheckInStore interface {
+	Save(ctx context.Context, c personalgoal.CheckIn) error
+}
+
+// GoalAnnotationStore defines the store interface needed to record coach annotations.
+type GoalAnnotationStore interface {
+	Save(ctx context.Context, a personalgoal.Annotation) error
+}
+
+// GoalAttendanceStore defines the attendance queries that drive auto-tracked goals.
+type GoalAttendanceStore interface {
+	ListByMemberIDAndDateRange(ctx context.Context, memberID string, startDate string, endDate string) ([]attendanceDomain.Attendance, error)
+	SumMatHoursByMemberIDAndDateRange(ctx context.Context, memberID string, startDate string, endDate string) (float64, error)
+}
+
+// GoalAutoProgress returns an auto-tracked goal's progress from attendance within its period:
+// whole mat hours for hours goals, check-ins for sessions goals.
+// PRE: g.IsAutoTracked()
+// POST: returns the attendance-derived progress
+func GoalAutoProgress(ctx context.Context, g personalgoal.PersonalGoal, attendance GoalAttendanceStore) (int, error) {
+	from, to := g.StartDate.Format("2006-01-02"), g.EndDate.Format("2006-01-02")
+	if g.Type == personalgoal.TypeSessions {
+		records, err := attendance.ListByMemberIDAndDateRange(ctx, g.MemberID, from, to)
+		return len(records), err
+	}
+	hours, err := attendance.SumMatHoursByMemberIDAndDateRange(ctx, g.MemberID, from, to)
+	return int(hours), err
+}
+
+// --- Goal Check-In ---
+
+// GoalCheckInInput carries input for the goal check-in orchestrator.
+type GoalCheckInInput struct {
+	GoalID   string
+	MemberID string // the member checking in; must own the goal
+	Progress int    // ignored for auto-tracked goals
+	Status   string // on_track, at_risk or off_track
+	Note     string // optional
+}
+
+// GoalCheckInDeps holds dependencies for GoalCheckIn.
+type GoalCheckInDeps struct {
+	GoalStore       PersonalGoalStoreForOrchestrator
+	CheckInStore    GoalCheckInStore
+	AttendanceStore GoalAttendanceStore
+	GenerateID      func() string
+	Now             func() time.Time
+}
+
+// GoalCheckInResult is the recorded check-in and the goal as it stands afterwards.
+type GoalCheckInResult struct {
+	Goal    personalgoal.PersonalGoal
+	CheckIn personalgoal.CheckIn
+}
+
+// ExecuteGoalCheckIn records a member's periodic check-in on their goal.
+// Auto-tracked goals take their progress from attendance rather than the member's figure.
+// A check-in that reaches the target completes the goal.
+// PRE: GoalID references an active goal owned by MemberID
+// POST: Check-in saved; goal progress and LastCheckInAt updated, and Status completed if the target is reached
+func ExecuteGoalCheckIn(ctx context.Context, input GoalCheckInInput, deps GoalCheckInDeps) (GoalCheckInResult, error) {
+	if input.GoalID == "" {
+		return GoalCheckInResult{}, personalgoal.ErrEmptyGoalID
+	}
+	goal, err := deps.GoalStore.GetByID(ctx, input.GoalID)
+	if err != nil {
+		return GoalCheckInResult{}, err
+	}
+	if goal.MemberID != input.MemberID {
+		return GoalCheckInResult{}, ErrGoalNotOwned
+	}
+	if goal.IsCompleted() {
+		return GoalCheckInResult{}, personalgoal.ErrGoalCompleted
+	}
+
+	progress := input.Progress
+	if goal.IsAutoTracked() {
+		if progress, err = GoalAutoProgress(ctx, goal, deps.AttendanceStore); err != nil {
+			return GoalCheckInResult{}, err
+		}
+	}
+
+	now := deps.Now()
+	checkIn := personalgoal.CheckIn{
+		ID:        deps.GenerateID(),
+		GoalID:    goal.ID,
+		MemberID:  goal.MemberID,
+		Progress:  progress,
+		Status:    input.Status,
+		Note:      strings.TrimSpace(input.Note),
+		CreatedAt: now,
+	}
+	if err := checkIn.Validate(); err != nil {
+		return GoalCheckInResult{}, err
+	}
+
+	goal.Progress = progress
+	goal.LastCheckInAt = now
+	goal.UpdatedAt = now
+	if goal.Reached() {
+		if err := goal.Complete(now); err != nil {
+			return GoalCheckInResult{}, err
+		}
+	}
+
+	if err := deps.CheckInStore.Save(ctx, checkIn); err != nil {
+		return GoalCheckInResult{}, err
+	}
+	if err := deps.GoalStore.Save(ctx, goal); err != nil {
+		return GoalCheckInResult{}, err
+	}
+
+	slog.InfoContext(ctx, "goal_event", "event", "goal_checked_in", "goal_id", goal.ID, "member_id", goal.MemberID, "status", checkIn.Status, "completed", goal.IsCompleted())
+	return GoalCheckInResult{Goal: goal, CheckIn: checkIn}, nil
+}
+
+// --- Annotate Goal ---
+
+// AnnotateGoalInput carries input for the annotate goal orchestrator.
+type AnnotateGoalInput struct {
+	GoalID     string
+	AuthorID   string // AccountID of the coach/admin
+	AuthorName string
+	Content    string
+}
+
+// AnnotateGoalDeps holds dependencies for AnnotateGoal.
+type AnnotateGoalDeps struct {
+	GoalStore       PersonalGoalStoreForOrchestrator
+	AnnotationStore GoalAnnotationStore
+	GenerateID      func() string
+	Now             func() time.Time
+}
+
+// ExecuteAnnotateGoal adds a coach's comment to a member's goal.
+// PRE: GoalID references an existing goal; AuthorID and Content are non-empty
+// POST: Annotation saved with generated ID and timestamp
+func ExecuteAnnotateGoal(ctx context.Context, input AnnotateGoalInput, deps AnnotateGoalDeps) (personalgoal.Annotation, error) {
+	if input.GoalID == "" {
+		return personalgoal.Annotation{}, personalgoal.ErrEmptyGoalID
+	}
+	if _, err := deps.GoalStore.GetByID(ctx, input.GoalID); err != nil {
+		return personalgoal.Annotation{}, err
+	}
+
+	a := personalgoal.Annotation{
+		ID:         deps.GenerateID(),
+		GoalID:     input.GoalID,
+		AuthorID:   input.AuthorID,
+		AuthorName: input.AuthorName,
+		Content:    strings.TrimSpace(input.Content),
+		CreatedAt:  deps.Now(),
+	}
+	if err := a.Validate(); err != nil {
+		return personalgoal.Annotation{}, err
+	}
+	if err := deps.AnnotationStore.Save(ctx, a); err != nil {
+		return personalgoal.Annotation{}, err
+	}
+
+	slog.InfoContext(ctx, "goal_event", "event", "goal_annotated", "goal_id", a.GoalID, "author_id", a.AuthorID)
+	return a, nil
+}
+
+// --- Close Reached Goals ---
+
+// CloseReachedGoalsDeps holds dependencies for CloseReachedGoals.
+type CloseReachedGoalsDeps struct {
+	GoalStore       PersonalGoalStoreForOrchestrator
+	AttendanceStore GoalAttendanceStore
+	Now             func() time.Time
+}
+
+// CloseReachedGoalsResult summarises one run.
+type CloseReachedGoalsResult struct {
+	Checked int // active goals examined
+	Updated int // goals whose stored progress changed
+	Closed  int // goals completed this run
+}
+
+// ExecuteCloseReachedGoals refreshes the stored progress of auto-tracked goals from attendance
+// and completes every active goal whose progress has reached its target.
+// A goal that fails to refresh is logged and skipped so the rest still close.
+// PRE: deps are non-nil
+// POST: Reached goals are completed; auto-tracked progress is current
+func ExecuteCloseReachedGoals(ctx context.Context, deps CloseReachedGoalsDeps) (CloseReachedGoalsResult, error) {
+	var result CloseReachedGoalsResult
+
+	goals, err := deps.GoalStore.ListActive(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	now := deps.Now()
+	var errs []error
+	for _, g := range goals {
+		result.Checked++
+		changed := false
+		if g.IsAutoTracked() {
+			progress, err := GoalAutoProgress(ctx, g, deps.AttendanceStore)
+			if err != nil {
+				slog.ErrorContext(ctx, "goal_event", "event", "goal_progress_failed", "goal_id", g.ID, "error", err)
+				errs = append(errs, err)
+				continue
+			}
+			if progress != g.Progress {
+				g.Progress = progress
+				g.UpdatedAt = now
+				changed = true
+				result.Updated++
+			}
+		}
+		closing := g.Reached()
+		if closing {
+			if err := g.Complete(now); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		if err := deps.GoalStore.Save(ctx, g); err != nil {
+			slog.ErrorContext(ctx, "goal_event", "event", "goal_save_failed", "goal_id", g.ID, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		if closing {
+			result.Closed++
+			slog.InfoContext(ctx, "goal_event", "event", "goal_completed", "goal_id", g.ID, "member_id", g.MemberID, "progress", g.Progress, "target", g.Target)
+		}
+	}
+	return result, errors.Join(errs...)
+}
@@ -0,0 +1,220 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	attendanceDomain "workshop/internal/domain/attendance"
+	"workshop/internal/domain/personalgoal"
+)
+
+// mockPersonalGoalStore implements PersonalGoalStoreForOrchestrator for testing.
+type mockPersonalGoalStore struct {
+	goals map[string]personalgoal.PersonalGoal
+}
+
+// GetByID implements PersonalGoalStoreForOrchestrator.
+// PRE: id is non-empty
+// POST: returns the goal or sql.ErrNoRows
+func (m *mockPersonalGoalStore) GetByID(_ context.Context, id string) (personalgoal.PersonalGoal, error) {
+	g, ok := m.goals[id]
+	if !ok {
+		return personalgoal.PersonalGoal{}, sql.ErrNoRows
+	}
+	return g, nil
+}
+
+// Save implements PersonalGoalStoreForOrchestrator.
+// PRE: g is valid
+// POST: goal is stored by ID
+func (m *mockPersonalGoalStore) Save(_ context.Context, g personalgoal.PersonalGoal) error {
+	m.goals[g.ID] = g
+	return nil
+}
+
+// ListActive implements PersonalGoalStoreForOrchestrator.
+// PRE: none
+// POST: returns goals that are not completed
+func (m *mockPersonalGoalStore) ListActive(_ context.Context) ([]personalgoal.PersonalGoal, error) {
+	var list []personalgoal.PersonalGoal
+	for _, g := range m.goals {
+		if !g.IsCompleted() {
+			list = append(list, g)
+		}
+	}
+	return list, nil
+}
+
+// mockGoalCheckInStore implements GoalCheckInStore for testing.
+type mockGoalCheckInStore struct {
+	checkIns []personalgoal.CheckIn
+}
+
+// Save implements GoalCheckInStore.
+// PRE: c is valid
+// POST: check-in is appended
+func (m *mockGoalCheckInStore) Save(_ context.Context, c personalgoal.CheckIn) error {
+	m.checkIns = append(m.checkIns, c)
+	return nil
+}
+
+// mockGoalAnnotationStore implements GoalAnnotationStore for testing.
+type mockGoalAnnotationStore struct {
+	annotations []personalgoal.Annotation
+}
+
+// Save implements GoalAnnotationStore.
+// PRE: a is valid
+// POST: annotation is appended
+func (m *mockGoalAnnotationStore) Save(_ context.Context, a personalgoal.Annotation) error {
+	m.annotations = append(m.annotations, a)
+	return nil
+}
+
+// mockGoalAttendanceStore implements GoalAttendanceStore with fixed per-member totals.
+type mockGoalAttendanceStore struct {
+	sessions map[string]int     // check-ins per member
+	hours    map[string]float64 // mat hours per member
+}
+
+// ListByMemberIDAndDateRange implements GoalAttendanceStore.
+// PRE: memberID is non-empty
+// POST: returns the member's configured number of check-ins
+func (m *mockGoalAttendanceStore) ListByMemberIDAndDateRange(_ context.Context, memberID, _, _ string) ([]attendanceDomain.Attendance, error) {
+	return make([]attendanceDomain.Attendance, m.sessions[memberID]), nil
+}
+
+// SumMatHoursByMemberIDAndDateRange implements GoalAttendanceStore.
+// PRE: memberID is non-empty
+// POST: returns the member's configured mat hours
+func (m *mockGoalAttendanceStore) SumMatHoursByMemberIDAndDateRange(_ context.Context, memberID, _, _ string) (float64, error) {
+	return m.hours[memberID], nil
+}
+
+// testGoal returns an active goal for member-1 running through March 2026.
+func testGoal(id, goalType string, target int) personalgoal.PersonalGoal {
+	return personalgoal.PersonalGoal{
+		ID: id, MemberID: "member-1", Title: "Train more", Type: goalType, Target: target,
+		StartDate: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		Status:    personalgoal.StatusActive,
+	}
+}
+
+// TestExecuteGoalCheckIn verifies check-ins record progress and complete goals that reach their target.
+func TestExecuteGoalCheckIn(t *testing.T) {
+	goals := &mockPersonalGoalStore{goals: map[string]personalgoal.PersonalGoal{
+		"manual":   testGoal("manual", personalgoal.TypeManual, 50),
+		"sessions": testGoal("sessions", personalgoal.TypeSessions, 12),
+	}}
+	checkIns := &mockGoalCheckInStore{}
+	deps := GoalCheckInDeps{
+		GoalStore:       goals,
+		CheckInStore:    checkIns,
+		AttendanceStore: &mockGoalAttendanceStore{sessions: map[string]int{"member-1": 12}},
+		GenerateID:      sequentialIDs(),
+		Now:             fixedNow,
+	}
+
+	result, err := ExecuteGoalCheckIn(context.Background(), GoalCheckInInput{
+		GoalID: "manual", MemberID: "member-1", Progress: 20, Status: personalgoal.CheckInAtRisk, Note: "  Sore shoulder  ",
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g := goals.goals["manual"]; g.Progress != 20 || !g.LastCheckInAt.Equal(fixedTime) || g.IsCompleted() {
+		t.Errorf("manual goal = %+v, want progress 20, checked in now, still active", g)
+	}
+	if result.CheckIn.Note != "Sore shoulder" || len(checkIns.checkIns) != 1 {
+		t.Errorf("check-in = %+v, want the trimmed note saved", result.CheckIn)
+	}
+
+	result, err = ExecuteGoalCheckIn(context.Background(), GoalCheckInInput{
+		GoalID: "sessions", MemberID: "member-1", Progress: 3, Status: personalgoal.CheckInOnTrack,
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g := goals.goals["sessions"]; result.CheckIn.Progress != 12 || !g.IsCompleted() {
+		t.Errorf("sessions goal = %+v, want attendance progress and completed", g)
+	}
+
+	tests := []struct {
+		name    string
+		input   GoalCheckInInput
+		wantErr error
+	}{
+		{"completed goal", GoalCheckInInput{GoalID: "sessions", MemberID: "member-1", Status: personalgoal.CheckInOnTrack}, personalgoal.ErrGoalCompleted},
+		{"someone else's goal", GoalCheckInInput{GoalID: "manual", MemberID: "member-2", Status: personalgoal.CheckInOnTrack}, ErrGoalNotOwned},
+		{"bad status", GoalCheckInInput{GoalID: "manual", MemberID: "member-1", Status: "fine"}, personalgoal.ErrInvalidCheckInState},
+		{"unknown goal", GoalCheckInInput{GoalID: "nope", MemberID: "member-1", Status: personalgoal.CheckInOnTrack}, sql.ErrNoRows},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ExecuteGoalCheckIn(context.Background(), tt.input, deps); err != tt.wantErr {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if len(checkIns.checkIns) != 2 {
+		t.Errorf("expected rejected check-ins not saved, got %d", len(checkIns.checkIns))
+	}
+}
+
+// TestExecuteAnnotateGoal verifies coaches can comment on existing goals only.
+func TestExecuteAnnotateGoal(t *testing.T) {
+	annotations := &mockGoalAnnotationStore{}
+	deps := AnnotateGoalDeps{
+		GoalStore:       &mockPersonalGoalStore{goals: map[string]personalgoal.PersonalGoal{"g1": testGoal("g1", personalgoal.TypeManual, 10)}},
+		AnnotationStore: annotations,
+		GenerateID:      sequentialIDs(),
+		Now:             fixedNow,
+	}
+
+	a, err := ExecuteAnnotateGoal(context.Background(), AnnotateGoalInput{GoalID: "g1", AuthorID: "coach-1", AuthorName: "Coach Sam", Content: "Work the entries. "}, deps)
+	if err != nil || a.Content != "Work the entries." || len(annotations.annotations) != 1 {
+		t.Fatalf("annotation = %+v (%v), want it saved trimmed", a, err)
+	}
+	if _, err := ExecuteAnnotateGoal(context.Background(), AnnotateGoalInput{GoalID: "g1", AuthorID: "coach-1", Content: " "}, deps); err != personalgoal.ErrEmptyContent {
+		t.Errorf("blank content: err = %v, want ErrEmptyContent", err)
+	}
+	if _, err := ExecuteAnnotateGoal(context.Background(), AnnotateGoalInput{GoalID: "nope", AuthorID: "coach-1", Content: "x"}, deps); err != sql.ErrNoRows {
+		t.Errorf("unknown goal: err = %v, want sql.ErrNoRows", err)
+	}
+}
+
+// TestExecuteCloseReachedGoals verifies auto-tracked progress is refreshed and reached goals are closed.
+func TestExecuteCloseReachedGoals(t *testing.T) {
+	manualDone := testGoal("manual-done", personalgoal.TypeManual, 5)
+	manualDone.Progress = 5
+	goals := &mockPersonalGoalStore{goals: map[string]personalgoal.PersonalGoal{
+		"hours":       testGoal("hours", personalgoal.TypeHours, 20),
+		"sessions":    testGoal("sessions", personalgoal.TypeSessions, 30),
+		"manual":      testGoal("manual", personalgoal.TypeManual, 5),
+		"manual-done": manualDone,
+	}}
+
+	result, err := ExecuteCloseReachedGoals(context.Background(), CloseReachedGoalsDeps{
+		GoalStore:       goals,
+		AttendanceStore: &mockGoalAttendanceStore{sessions: map[string]int{"member-1": 14}, hours: map[string]float64{"member-1": 21.5}},
+		Now:             fixedNow,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != (CloseReachedGoalsResult{Checked: 4, Updated: 2, Closed: 2}) {
+		t.Errorf("result = %+v, want 4 checked, 2 updated, 2 closed", result)
+	}
+	if g := goals.goals["hours"]; !g.IsCompleted() || g.Progress != 21 || !g.CompletedAt.Equal(fixedTime) {
+		t.Errorf("hours goal = %+v, want completed at 21 hours", g)
+	}
+	if g := goals.goals["sessions"]; g.IsCompleted() || g.Progress != 14 {
+		t.Errorf("sessions goal = %+v, want progress 14 and still active", g)
+	}
+	done, open := goals.goals["manual-done"], goals.goals["manual"]
+	if !done.IsCompleted() || open.IsCompleted() {
+		t.Error("expected only the manual goal at its target closed")
+	}
+}
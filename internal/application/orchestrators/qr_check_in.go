@@ -0,0 +1,206 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"time"
+
+	emailAdapter "workshop/internal/adapters/email"
+	"workshop/internal/adapters/qrcode"
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/kiosk"
+	"workshop/internal/domain/member"
+)
+
+// QR check-in errors.
+var (
+	ErrQRCheckInArchived = errors.New("archived members cannot check in")
+	ErrCheckInFrozen     = errors.New("membership is frozen — ask the front desk to unfreeze it")
+	ErrQRCheckInNoClass  = errors.New("no class is open for check-in right now")
+	ErrQRCheckInNoEmail  = errors.New("member has no email address")
+)
+
+// --- QR Check-In ---
+
+// QRCheckInInput carries a scanned token and the classes the kiosk can check into today.
+type QRCheckInInput struct {
+	Token      string
+	Classes    []kiosk.ClassSlot // today's classes at the kiosk's location
+	LocationID string            // optional: location of the scanning kiosk
+}
+
+// QRCheckInDeps holds dependencies for ExecuteQRCheckIn.
+type QRCheckInDeps struct {
+	Key             []byte // signs and verifies check-in tokens
+	MemberStore     CheckInSearchStore
+	AttendanceStore BulkSyncAttendanceStore
+	ScheduleStore   ScheduleLookupStore       // optional: used to compute mat hours and location
+	InferStripeDeps *InferStripeDeps          // optional: nil skips stripe inference
+	TopicDeps       *LinkAttendanceTopicsDeps // optional: nil skips linking the check-in to rotor topics
+	PackDeps        *UseClassPackDeps         // optional: nil uses no class packs
+	EligibilityDeps *ClassEligibilityDeps     // optional: nil skips class type prerequisites
+	ChangeStore     OccurrenceLookupStore     // optional: nil times moved and added classes by the weekly schedule
+	GenerateID      func() string
+	Now             func() time.Time
+}
+
+// QRCheckInResult reports who scanned in and the attendance record for the matched class.
+type QRCheckInResult struct {
+	Member           member.Member
+	ClassSlot        kiosk.ClassSlot
+	Attendance       attendance.Attendance
+	AlreadyCheckedIn bool // the member was already checked into this class today
+}
+
+// ExecuteQRCheckIn checks a member into today's matching class from a scanned QR token.
+// Scanning twice for the same class returns the existing record instead of a duplicate.
+// An ineligible member is turned away with the reason; a coach can check them in by name.
+// PRE: Token was read from a member's check-in QR code
+// POST: Attendance exists for the member and matched class; Member is set whenever the token is valid
+func ExecuteQRCheckIn(ctx context.Context, input QRCheckInInput, deps QRCheckInDeps) (QRCheckInResult, error) {
+	memberID, err := kiosk.VerifyCheckInToken(deps.Key, input.Token)
+	if err != nil {
+		return QRCheckInResult{}, err
+	}
+	m, err := deps.MemberStore.GetByID(ctx, memberID)
+	if err != nil {
+		return QRCheckInResult{}, kiosk.ErrInvalidCheckInToken
+	}
+	result := QRCheckInResult{Member: m}
+	if m.IsArchived() {
+		return result, ErrQRCheckInArchived
+	}
+	if m.IsFrozen() {
+		return result, ErrCheckInFrozen
+	}
+
+	now := deps.Now()
+	slot, ok := kiosk.MatchClass(input.Classes, m.Program, now)
+	if !ok {
+		return result, ErrQRCheckInNoClass
+	}
+	result.ClassSlot = slot
+	classDate := now.Format("2006-01-02")
+
+	existing, err := deps.AttendanceStore.ListByMemberIDAndDate(ctx, m.ID, classDate)
+	if err != nil {
+		return result, err
+	}
+	for _, a := range existing {
+		if a.ScheduleID == slot.ScheduleID {
+			result.Attendance = a
+			result.AlreadyCheckedIn = true
+			return result, nil
+		}
+	}
+	schedules, eligibility := withClassChanges(deps.ScheduleStore, deps.EligibilityDeps, deps.ChangeStore, classDate)
+	if err := checkClassEligibility(ctx, m, slot.ScheduleID, "", eligibility); err != nil {
+		return result, err
+	}
+
+	var matHours float64
+	locationID := input.LocationID
+	if schedules != nil {
+		if sched, err := schedules.GetByID(ctx, slot.ScheduleID); err == nil {
+			if dur, err := sched.DurationHours(); err == nil {
+				matHours = dur
+			}
+			if sched.LocationID != "" {
+				locationID = sched.LocationID
+			}
+		}
+	}
+	a := attendance.Attendance{
+		ID:          deps.GenerateID(),
+		MemberID:    m.ID,
+		CheckInTime: now,
+		ScheduleID:  slot.ScheduleID,
+		ClassDate:   classDate,
+		MatHours:    matHours,
+		LocationID:  locationID,
+	}
+	if err := a.Validate(); err != nil {
+		return result, err
+	}
+	if err := deps.AttendanceStore.Save(ctx, a); err != nil {
+		return result, err
+	}
+	result.Attendance = a
+
+	slog.InfoContext(ctx, "checkin_event", "event", "member_checked_in_qr", "member_id", m.ID, "schedule_id", slot.ScheduleID, "mat_hours", matHours, "location_id", locationID)
+
+	linkAttendanceTopics(ctx, a, deps.TopicDeps)
+	useClassPack(ctx, a, deps.PackDeps)
+
+	if deps.InferStripeDeps != nil {
+		_ = ExecuteInferStripe(ctx, m.ID, *deps.InferStripeDeps)
+	}
+	return result, nil
+}
+
+// --- Email Check-In QR ---
+
+// checkInQRContentID is the inline attachment ID the email body refers to.
+const checkInQRContentID = "checkin-qr"
+
+// EmailCheckInQRInput identifies the member whose code is sent.
+type EmailCheckInQRInput struct {
+	MemberID string
+}
+
+// EmailCheckInQRDeps holds dependencies for ExecuteEmailCheckInQR.
+type EmailCheckInQRDeps struct {
+	Key         []byte
+	MemberStore CheckInSearchStore
+	EmailSender emailAdapter.Sender
+	FromAddress string
+	ReplyTo     string
+}
+
+// ExecuteEmailCheckInQR emails a member their check-in QR code as an inline PNG.
+// PRE: MemberID is non-empty; EmailSender is configured
+// POST: One email sent to the member's address, or ErrQRCheckInNoEmail
+func ExecuteEmailCheckInQR(ctx context.Context, input EmailCheckInQRInput, deps EmailCheckInQRDeps) error {
+	m, err := deps.MemberStore.GetByID(ctx, input.MemberID)
+	if err != nil {
+		return err
+	}
+	if m.Email == "" {
+		return ErrQRCheckInNoEmail
+	}
+	code, err := qrcode.Encode(kiosk.SignCheckInToken(deps.Key, m.ID))
+	if err != nil {
+		return err
+	}
+	qrImage, err := code.PNG(8)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(`<p>Hi %s,</p>
+<p>Here is your check-in code. Show it to the kiosk camera when you arrive and you'll be checked into your class — no need to type your name.</p>
+<p><img src="cid:%s" alt="Check-in QR code" width="240" height="240"></p>
+<p>Save this email or take a screenshot so you have it at the gym.</p>`, html.EscapeString(m.Name), checkInQRContentID)
+
+	if _, err := deps.EmailSender.Send(ctx, emailAdapter.SendRequest{
+		To:      []string{m.Email},
+		From:    deps.FromAddress,
+		Subject: "Your Workshop check-in code",
+		HTML:    body,
+		ReplyTo: deps.ReplyTo,
+		Attachments: []emailAdapter.Attachment{{
+			Filename:    "checkin-qr.png",
+			ContentType: "image/png",
+			Content:     qrImage,
+			ContentID:   checkInQRContentID,
+		}},
+	}); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "checkin_event", "event", "checkin_qr_emailed", "member_id", m.ID)
+	return nil
+}
@@ -0,0 +1,114 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/kiosk"
+	"workshop/internal/domain/member"
+)
+
+var qrTestKey = []byte("0123456789abcdef0123456789abcdef")
+
+func newQRCheckInDeps(store *mockBulkSyncAttendanceStore, now time.Time) QRCheckInDeps {
+	return QRCheckInDeps{
+		Key: qrTestKey,
+		MemberStore: &mockBulkSyncMemberStore{members: map[string]member.Member{
+			"m1": {ID: "m1", Name: "Alice", Email: "alice@test.com", Program: member.ProgramAdults, Status: member.StatusActive},
+			"m2": {ID: "m2", Name: "Bob", Program: member.ProgramAdults, Status: member.StatusArchived},
+			"m3": {ID: "m3", Name: "Cara", Program: member.ProgramAdults, Status: member.StatusFrozen},
+		}},
+		AttendanceStore: store,
+		ScheduleStore:   &mockBulkSyncScheduleStore{},
+		GenerateID:      sequentialIDs(),
+		Now:             func() time.Time { return now },
+	}
+}
+
+var qrTestClasses = []kiosk.ClassSlot{
+	{ScheduleID: "s-adults", ProgramType: "adults", StartTime: "10:00", EndTime: "11:00"},
+	{ScheduleID: "s-kids", ProgramType: "kids", StartTime: "10:00", EndTime: "11:00"},
+}
+
+// TestExecuteQRCheckIn_ChecksIntoMatchingClass tests a scan checks the member into their program's class once.
+func TestExecuteQRCheckIn_ChecksIntoMatchingClass(t *testing.T) {
+	store := &mockBulkSyncAttendanceStore{}
+	deps := newQRCheckInDeps(store, time.Date(2026, 3, 1, 9, 40, 0, 0, time.UTC))
+	input := QRCheckInInput{Token: kiosk.SignCheckInToken(qrTestKey, "m1"), Classes: qrTestClasses}
+
+	result, err := ExecuteQRCheckIn(context.Background(), input, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Member.ID != "m1" || result.ClassSlot.ScheduleID != "s-adults" || result.AlreadyCheckedIn {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(store.records) != 1 || store.records[0].MatHours != 1 || store.records[0].LocationID != "central" {
+		t.Fatalf("expected one one-hour record at central, got %+v", store.records)
+	}
+
+	again, err := ExecuteQRCheckIn(context.Background(), input, deps)
+	if err != nil {
+		t.Fatalf("unexpected error on second scan: %v", err)
+	}
+	if !again.AlreadyCheckedIn || again.Attendance.ID != result.Attendance.ID || len(store.records) != 1 {
+		t.Errorf("expected second scan to reuse the record, got %+v with %d records", again, len(store.records))
+	}
+}
+
+// TestExecuteQRCheckIn_Errors tests rejected scans.
+func TestExecuteQRCheckIn_Errors(t *testing.T) {
+	open := time.Date(2026, 3, 1, 10, 15, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		token      string
+		now        time.Time
+		wantErr    error
+		wantMember string
+	}{
+		{"forged token", "wsci1.m1.forged", open, kiosk.ErrInvalidCheckInToken, ""},
+		{"unknown member", kiosk.SignCheckInToken(qrTestKey, "ghost"), open, kiosk.ErrInvalidCheckInToken, ""},
+		{"archived member", kiosk.SignCheckInToken(qrTestKey, "m2"), open, ErrQRCheckInArchived, "m2"},
+		{"frozen member", kiosk.SignCheckInToken(qrTestKey, "m3"), open, ErrCheckInFrozen, "m3"},
+		{"no open class", kiosk.SignCheckInToken(qrTestKey, "m1"), open.Add(2 * time.Hour), ErrQRCheckInNoClass, "m1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockBulkSyncAttendanceStore{}
+			result, err := ExecuteQRCheckIn(context.Background(), QRCheckInInput{Token: tt.token, Classes: qrTestClasses}, newQRCheckInDeps(store, tt.now))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if result.Member.ID != tt.wantMember {
+				t.Errorf("expected member %q in result, got %q", tt.wantMember, result.Member.ID)
+			}
+			if len(store.records) != 0 {
+				t.Errorf("expected no attendance, got %d", len(store.records))
+			}
+		})
+	}
+}
+
+// TestExecuteEmailCheckInQR tests the QR code is sent inline to the member.
+func TestExecuteEmailCheckInQR(t *testing.T) {
+	sender := newMockEmailSender()
+	deps := EmailCheckInQRDeps{Key: qrTestKey, MemberStore: newQRCheckInDeps(nil, fixedTime).MemberStore, EmailSender: sender}
+
+	if err := ExecuteEmailCheckInQR(context.Background(), EmailCheckInQRInput{MemberID: "m1"}, deps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sender.sentReqs) != 1 {
+		t.Fatalf("expected one email, got %d", len(sender.sentReqs))
+	}
+	req := sender.sentReqs[0]
+	if req.To[0] != "alice@test.com" || len(req.Attachments) != 1 || !strings.Contains(req.HTML, "cid:"+req.Attachments[0].ContentID) {
+		t.Errorf("unexpected email: %+v", req)
+	}
+
+	if err := ExecuteEmailCheckInQR(context.Background(), EmailCheckInQRInput{MemberID: "m2"}, deps); !errors.Is(err, ErrQRCheckInNoEmail) {
+		t.Errorf("expected ErrQRCheckInNoEmail, got %v", err)
+	}
+}
@@ -0,0 +1,316 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/application/textmatch"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/observation"
+	"workshop/internal/domain/quickcapture"
+)
+
+// Quick capture item statuses reported back to the coach's phone.
+const (
+	QuickCaptureStatusSaved     = "saved"     // matched one member and saved as an observation
+	QuickCaptureStatusQueued    = "queued"    // waiting for the coach to choose the member
+	QuickCaptureStatusDuplicate = "duplicate" // the phone sent this note before
+	QuickCaptureStatusRejected  = "rejected"
+)
+
+// Quick capture errors.
+var (
+	ErrQuickCaptureTooLarge       = errors.New("too many notes in capture batch")
+	ErrQuickCaptureNotFound       = errors.New("captured note not found")
+	ErrQuickCaptureMemberNotFound = errors.New("member not found")
+)
+
+// QuickCaptureStore defines the capture store interface needed by the quick capture orchestrators.
+type QuickCaptureStore interface {
+	GetByID(ctx context.Context, id string) (quickcapture.Capture, error)
+	GetByClientID(ctx context.Context, authorID, clientID string) (quickcapture.Capture, error)
+	Save(ctx context.Context, value quickcapture.Capture) error
+}
+
+// QuickCaptureMemberStore defines the member store interface needed to match captured names.
+type QuickCaptureMemberStore interface {
+	List(ctx context.Context, filter memberStore.ListFilter) ([]member.Member, error)
+}
+
+// QuickCaptureMemberLookupStore defines the member store interface needed to resolve a queued note.
+type QuickCaptureMemberLookupStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+}
+
+// QuickCaptureItem is one note captured on a coach's phone.
+type QuickCaptureItem struct {
+	ClientID   string // phone-generated ID, echoed back so the phone can clear its queue
+	Member     string // the member's name as typed or dictated, e.g. "Sam" or "sam smith"
+	Content    string
+	CapturedAt time.Time // phone timestamp of when the note was taken
+}
+
+// QuickCaptureInput carries a batch of captured notes.
+type QuickCaptureInput struct {
+	Items    []QuickCaptureItem
+	AuthorID string // AccountID of the coach or admin
+}
+
+// QuickCaptureCandidate is a member who may be the one a queued note names.
+type QuickCaptureCandidate struct {
+	MemberID   string
+	Name       string
+	Similarity float64
+}
+
+// QuickCaptureItemResult reports how one captured note was resolved.
+type QuickCaptureItemResult struct {
+	ClientID      string
+	Status        string // saved, queued, duplicate, rejected
+	CaptureID     string // the stored capture (empty when rejected)
+	ObservationID string // set when saved, or a duplicate of a saved note
+	MemberID      string
+	MemberName    string
+	Candidates    []QuickCaptureCandidate // closest members when queued
+	Reason        string                  // rejection reason
+}
+
+// QuickCaptureResult carries per-item outcomes in request order.
+type QuickCaptureResult struct {
+	Results   []QuickCaptureItemResult
+	Saved     int
+	Queued    int
+	Duplicate int
+	Rejected  int
+}
+
+// QuickCaptureDeps holds dependencies for QuickCapture.
+type QuickCaptureDeps struct {
+	MemberStore      QuickCaptureMemberStore
+	CaptureStore     QuickCaptureStore
+	ObservationStore ObservationStoreForOrchestrator
+	GenerateID       func() string
+	Now              func() time.Time
+}
+
+// ExecuteQuickCapture saves a batch of notes a coach captured mid-class. Each note's member
+// name is matched against current members by full or first name, allowing for typos; a
+// note naming exactly one member becomes a coaches-only observation dated when it was
+// captured, and the rest are queued with the closest members for the coach to choose from.
+// Notes are keyed on the phone's client ID, so resending a batch never duplicates them.
+// PRE: len(input.Items) <= quickcapture.MaxBatchItems; input.AuthorID is a coach or admin
+// POST: Each note is saved, queued, reported as a duplicate, or rejected with a reason
+// INVARIANT: A bad note never aborts the rest of the batch
+func ExecuteQuickCapture(ctx context.Context, input QuickCaptureInput, deps QuickCaptureDeps) (QuickCaptureResult, error) {
+	if len(input.Items) > quickcapture.MaxBatchItems {
+		return QuickCaptureResult{}, ErrQuickCaptureTooLarge
+	}
+	if input.AuthorID == "" {
+		return QuickCaptureResult{}, quickcapture.ErrEmptyAuthorID
+	}
+	all, err := deps.MemberStore.List(ctx, memberStore.ListFilter{Limit: 10000})
+	if err != nil {
+		return QuickCaptureResult{}, err
+	}
+	members := make([]member.Member, 0, len(all))
+	for _, m := range all {
+		if !m.IsArchived() {
+			members = append(members, m)
+		}
+	}
+
+	now := deps.Now()
+	seen := make(map[string]QuickCaptureItemResult) // client ID -> result earlier in the batch
+	result := QuickCaptureResult{Results: make([]QuickCaptureItemResult, 0, len(input.Items))}
+	for _, item := range input.Items {
+		res, ok := seen[item.ClientID]
+		if ok && item.ClientID != "" && res.Status != QuickCaptureStatusRejected {
+			res.Status = QuickCaptureStatusDuplicate
+			res.Candidates = nil
+		} else {
+			res = captureOne(ctx, item, input.AuthorID, members, now, deps)
+			seen[item.ClientID] = res
+		}
+		switch res.Status {
+		case QuickCaptureStatusSaved:
+			result.Saved++
+		case QuickCaptureStatusQueued:
+			result.Queued++
+		case QuickCaptureStatusDuplicate:
+			result.Duplicate++
+		default:
+			result.Rejected++
+		}
+		result.Results = append(result.Results, res)
+	}
+
+	slog.InfoContext(ctx, "observation_event", "event", "quick_capture", "author_id", input.AuthorID, "notes", len(input.Items),
+		"saved", result.Saved, "queued", result.Queued, "duplicate", result.Duplicate, "rejected", result.Rejected)
+	return result, nil
+}
+
+// captureOne validates, de-duplicates and saves or queues a single captured note.
+func captureOne(ctx context.Context, item QuickCaptureItem, authorID string, members []member.Member, now time.Time, deps QuickCaptureDeps) QuickCaptureItemResult {
+	reject := func(reason string) QuickCaptureItemResult {
+		return QuickCaptureItemResult{ClientID: item.ClientID, Status: QuickCaptureStatusRejected, Reason: reason}
+	}
+	if item.CapturedAt.After(now.Add(quickcapture.MaxClockSkew)) {
+		return reject("captured time is in the future")
+	}
+	if !item.CapturedAt.IsZero() && now.Sub(item.CapturedAt) > quickcapture.MaxAge {
+		return reject("note is too old to send")
+	}
+
+	c := quickcapture.Capture{
+		ID:         deps.GenerateID(),
+		AuthorID:   authorID,
+		ClientID:   strings.TrimSpace(item.ClientID),
+		MemberRef:  strings.TrimSpace(item.Member),
+		Content:    strings.TrimSpace(item.Content),
+		CapturedAt: item.CapturedAt,
+		Status:     quickcapture.StatusQueued,
+		CreatedAt:  now,
+	}
+	if err := c.Validate(); err != nil {
+		return reject(err.Error())
+	}
+	if existing, err := deps.CaptureStore.GetByClientID(ctx, authorID, c.ClientID); err == nil {
+		return QuickCaptureItemResult{ClientID: item.ClientID, Status: QuickCaptureStatusDuplicate, CaptureID: existing.ID,
+			ObservationID: existing.ObservationID, MemberID: existing.MemberID}
+	}
+
+	match, candidates := matchCaptureMember(c.MemberRef, members)
+	if match == nil {
+		if err := deps.CaptureStore.Save(ctx, c); err != nil {
+			slog.ErrorContext(ctx, "observation_event", "event", "quick_capture_save_failed", "author_id", authorID, "error", err)
+			return reject("could not save note")
+		}
+		return QuickCaptureItemResult{ClientID: item.ClientID, Status: QuickCaptureStatusQueued, CaptureID: c.ID, Candidates: candidates}
+	}
+
+	obs, err := saveCapturedObservation(ctx, &c, *match, now, deps.ObservationStore, deps.CaptureStore, deps.GenerateID)
+	if err != nil {
+		slog.ErrorContext(ctx, "observation_event", "event", "quick_capture_save_failed", "author_id", authorID, "error", err)
+		return reject("could not save note")
+	}
+	return QuickCaptureItemResult{ClientID: item.ClientID, Status: QuickCaptureStatusSaved, CaptureID: c.ID,
+		ObservationID: obs.ID, MemberID: match.ID, MemberName: match.Name}
+}
+
+// saveCapturedObservation saves a capture as a coaches-only observation about the member,
+// dated when it was captured, and marks the capture resolved.
+func saveCapturedObservation(ctx context.Context, c *quickcapture.Capture, m member.Member, now time.Time, observations ObservationStoreForOrchestrator, captures QuickCaptureStore, generateID func() string) (observation.Observation, error) {
+	obs := observation.Observation{
+		ID:         generateID(),
+		MemberID:   m.ID,
+		AuthorID:   c.AuthorID,
+		Content:    c.Content,
+		Visibility: observation.VisibilityCoaches,
+		CreatedAt:  c.CapturedAt,
+	}
+	if err := obs.Validate(); err != nil {
+		return observation.Observation{}, err
+	}
+	if err := c.Resolve(m.ID, obs.ID, now); err != nil {
+		return observation.Observation{}, err
+	}
+	if err := observations.Save(ctx, obs); err != nil {
+		return observation.Observation{}, err
+	}
+	if err := captures.Save(ctx, *c); err != nil {
+		return observation.Observation{}, err
+	}
+	slog.InfoContext(ctx, "observation_event", "event", "observation_created", "observation_id", obs.ID, "member_id", obs.MemberID,
+		"author_id", obs.AuthorID, "visibility", obs.Visibility, "capture_id", c.ID)
+	return obs, nil
+}
+
+// matchCaptureMember matches a captured name to a member. Each member scores the better of
+// their full name's and first name's similarity to the name, so "Sam" and "sam smiht" both
+// find Sam Smith. The only member at least ImportAutoMatchSimilarity alike is the match;
+// otherwise the closest members are returned as candidates.
+func matchCaptureMember(ref string, members []member.Member) (*member.Member, []QuickCaptureCandidate) {
+	name := normaliseName(ref)
+	var candidates []QuickCaptureCandidate
+	byID := make(map[string]*member.Member, len(members))
+	for i, m := range members {
+		full := normaliseName(m.Name)
+		score := textmatch.Similarity(name, full)
+		if first, _, ok := strings.Cut(full, " "); ok {
+			score = max(score, textmatch.Similarity(name, first))
+		}
+		if score >= ImportCandidateSimilarity {
+			candidates = append(candidates, QuickCaptureCandidate{MemberID: m.ID, Name: m.Name, Similarity: score})
+			byID[m.ID] = &members[i]
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Similarity > candidates[j].Similarity })
+
+	if len(candidates) > 0 && candidates[0].Similarity >= ImportAutoMatchSimilarity &&
+		(len(candidates) == 1 || candidates[1].Similarity < ImportAutoMatchSimilarity) {
+		return byID[candidates[0].MemberID], nil
+	}
+	if len(candidates) > ImportMaxCandidates {
+		candidates = candidates[:ImportMaxCandidates]
+	}
+	return nil, candidates
+}
+
+// --- Resolve Quick Capture ---
+
+// ResolveQuickCaptureInput carries input for the resolve quick capture orchestrator.
+type ResolveQuickCaptureInput struct {
+	CaptureID string
+	MemberID  string // the member the note is about; empty discards the note
+	AuthorID  string // AccountID of the coach resolving their own note
+}
+
+// ResolveQuickCaptureDeps holds dependencies for ResolveQuickCapture.
+type ResolveQuickCaptureDeps struct {
+	MemberStore      QuickCaptureMemberLookupStore
+	CaptureStore     QuickCaptureStore
+	ObservationStore ObservationStoreForOrchestrator
+	GenerateID       func() string
+	Now              func() time.Time
+}
+
+// ExecuteResolveQuickCapture settles a queued note: the coach chooses the member it is about,
+// saving it as an observation dated when it was captured, or discards it. Coaches can only
+// resolve their own notes.
+// PRE: CaptureID and AuthorID are non-empty
+// POST: The capture is resolved with its observation saved, or discarded
+func ExecuteResolveQuickCapture(ctx context.Context, input ResolveQuickCaptureInput, deps ResolveQuickCaptureDeps) (quickcapture.Capture, error) {
+	c, err := deps.CaptureStore.GetByID(ctx, input.CaptureID)
+	if err != nil || c.AuthorID != input.AuthorID {
+		return quickcapture.Capture{}, ErrQuickCaptureNotFound
+	}
+	now := deps.Now()
+
+	if input.MemberID == "" {
+		if err := c.Discard(now); err != nil {
+			return quickcapture.Capture{}, err
+		}
+		if err := deps.CaptureStore.Save(ctx, c); err != nil {
+			return quickcapture.Capture{}, err
+		}
+		slog.InfoContext(ctx, "observation_event", "event", "quick_capture_discarded", "capture_id", c.ID, "author_id", c.AuthorID)
+		return c, nil
+	}
+
+	if c.Status != quickcapture.StatusQueued {
+		return quickcapture.Capture{}, quickcapture.ErrNotQueued
+	}
+	m, err := deps.MemberStore.GetByID(ctx, input.MemberID)
+	if err != nil || m.IsArchived() {
+		return quickcapture.Capture{}, ErrQuickCaptureMemberNotFound
+	}
+	if _, err := saveCapturedObservation(ctx, &c, m, now, deps.ObservationStore, deps.CaptureStore, deps.GenerateID); err != nil {
+		return quickcapture.Capture{}, err
+	}
+	return c, nil
+}
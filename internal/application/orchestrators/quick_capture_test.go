@@ -0,0 +1,153 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/quickcapture"
+)
+
+// mockQuickCaptureStore implements QuickCaptureStore for testing.
+type mockQuickCaptureStore struct {
+	captures map[string]quickcapture.Capture
+}
+
+// GetByID implements QuickCaptureStore for testing.
+// PRE: none
+// POST: Returns the capture or an error
+func (m *mockQuickCaptureStore) GetByID(_ context.Context, id string) (quickcapture.Capture, error) {
+	c, ok := m.captures[id]
+	if !ok {
+		return quickcapture.Capture{}, errors.New("not found")
+	}
+	return c, nil
+}
+
+// GetByClientID implements QuickCaptureStore for testing.
+// PRE: none
+// POST: Returns the author's capture with the client ID or an error
+func (m *mockQuickCaptureStore) GetByClientID(_ context.Context, authorID, clientID string) (quickcapture.Capture, error) {
+	for _, c := range m.captures {
+		if c.AuthorID == authorID && c.ClientID == clientID {
+			return c, nil
+		}
+	}
+	return quickcapture.Capture{}, errors.New("not found")
+}
+
+// Save implements QuickCaptureStore for testing.
+// PRE: none
+// POST: The capture is stored
+func (m *mockQuickCaptureStore) Save(_ context.Context, value quickcapture.Capture) error {
+	m.captures[value.ID] = value
+	return nil
+}
+
+// TestExecuteQuickCapture verifies captured names are matched by full or first name with
+// typos, unclear names are queued with candidates, and resent notes are not saved twice.
+func TestExecuteQuickCapture(t *testing.T) {
+	now := time.Date(2026, 3, 2, 19, 30, 0, 0, time.UTC)
+	captured := now.Add(-time.Hour)
+	members := &mockImportMemberStore{members: []member.Member{
+		{ID: "m1", Name: "Sam Smith", Status: member.StatusActive},
+		{ID: "m2", Name: "Alex Jones", Status: member.StatusActive},
+		{ID: "m3", Name: "Alex Brown", Status: member.StatusActive},
+		{ID: "m4", Name: "Riley Archived", Status: member.StatusArchived},
+	}}
+	captures := &mockQuickCaptureStore{captures: map[string]quickcapture.Capture{}}
+	observations := newMockObservationStore()
+	n := 0
+	deps := QuickCaptureDeps{
+		MemberStore:      members,
+		CaptureStore:     captures,
+		ObservationStore: observations,
+		GenerateID:       func() string { n++; return fmt.Sprintf("id-%d", n) },
+		Now:              func() time.Time { return now },
+	}
+	items := []QuickCaptureItem{
+		{ClientID: "p1", Member: "sam", Content: "Flaring elbows in closed guard", CapturedAt: captured},
+		{ClientID: "p2", Member: "Alex Jnes", Content: "Great framing", CapturedAt: captured},
+		{ClientID: "p3", Member: "Alex", Content: "Needs to breathe", CapturedAt: captured},
+		{ClientID: "p4", Member: "Riley", Content: "Left early", CapturedAt: captured},
+		{ClientID: "p1", Member: "sam", Content: "Flaring elbows in closed guard", CapturedAt: captured},
+		{ClientID: "p5", Member: "Sam", Content: "", CapturedAt: captured},
+		{ClientID: "p6", Member: "Sam", Content: "From the future", CapturedAt: now.Add(time.Hour)},
+	}
+
+	res, err := ExecuteQuickCapture(context.Background(), QuickCaptureInput{Items: items, AuthorID: "coach-1"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Saved != 2 || res.Queued != 2 || res.Duplicate != 1 || res.Rejected != 2 {
+		t.Fatalf("saved %d queued %d duplicate %d rejected %d, want 2, 2, 1, 2: %+v", res.Saved, res.Queued, res.Duplicate, res.Rejected, res.Results)
+	}
+	want := []struct{ status, memberID string }{
+		{QuickCaptureStatusSaved, "m1"},
+		{QuickCaptureStatusSaved, "m2"},
+		{QuickCaptureStatusQueued, ""},
+		{QuickCaptureStatusQueued, ""},
+		{QuickCaptureStatusDuplicate, "m1"},
+		{QuickCaptureStatusRejected, ""},
+		{QuickCaptureStatusRejected, ""},
+	}
+	for i, w := range want {
+		if got := res.Results[i]; got.ClientID != items[i].ClientID || got.Status != w.status || got.MemberID != w.memberID {
+			t.Errorf("Results[%d] = %+v, want %s for %q", i, got, w.status, w.memberID)
+		}
+	}
+	if c := res.Results[2].Candidates; len(c) != 2 || c[0].Similarity != 1 || c[1].Similarity != 1 {
+		t.Errorf("Alex candidates = %+v, want both Alexes", c)
+	}
+	if len(res.Results[3].Candidates) != 0 {
+		t.Errorf("Riley candidates = %+v, want none: archived members are not matched", res.Results[3].Candidates)
+	}
+	obs := observations.observations[res.Results[0].ObservationID]
+	if obs.MemberID != "m1" || obs.AuthorID != "coach-1" || !obs.CreatedAt.Equal(captured) {
+		t.Errorf("observation = %+v, want Sam's note dated when captured", obs)
+	}
+
+	// The phone lost the response and sends the same batch again.
+	again, err := ExecuteQuickCapture(context.Background(), QuickCaptureInput{Items: items[:4], AuthorID: "coach-1"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again.Duplicate != 4 || len(observations.observations) != 2 {
+		t.Errorf("resend: %d duplicates, %d observations; want 4 and 2", again.Duplicate, len(observations.observations))
+	}
+
+	// The coach says the queued Alex note is about Alex Brown, and throws away the Riley note.
+	resolveDeps := ResolveQuickCaptureDeps{
+		MemberStore:      &mockBulkSyncMemberStore{members: map[string]member.Member{"m3": {ID: "m3", Name: "Alex Brown", Status: member.StatusActive}}},
+		CaptureStore:     captures,
+		ObservationStore: observations,
+		GenerateID:       deps.GenerateID,
+		Now:              deps.Now,
+	}
+	if _, err := ExecuteResolveQuickCapture(context.Background(), ResolveQuickCaptureInput{CaptureID: res.Results[2].CaptureID, MemberID: "m3", AuthorID: "coach-2"}, resolveDeps); err != ErrQuickCaptureNotFound {
+		t.Errorf("another coach resolving: err = %v, want ErrQuickCaptureNotFound", err)
+	}
+	c, err := ExecuteResolveQuickCapture(context.Background(), ResolveQuickCaptureInput{CaptureID: res.Results[2].CaptureID, MemberID: "m3", AuthorID: "coach-1"}, resolveDeps)
+	if err != nil || c.Status != quickcapture.StatusResolved || observations.observations[c.ObservationID].MemberID != "m3" {
+		t.Fatalf("resolve: capture %+v, err %v", c, err)
+	}
+	if _, err := ExecuteResolveQuickCapture(context.Background(), ResolveQuickCaptureInput{CaptureID: res.Results[2].CaptureID, MemberID: "m3", AuthorID: "coach-1"}, resolveDeps); err != quickcapture.ErrNotQueued {
+		t.Errorf("resolving twice: err = %v, want ErrNotQueued", err)
+	}
+	c, err = ExecuteResolveQuickCapture(context.Background(), ResolveQuickCaptureInput{CaptureID: res.Results[3].CaptureID, AuthorID: "coach-1"}, resolveDeps)
+	if err != nil || c.Status != quickcapture.StatusDiscarded || len(observations.observations) != 3 {
+		t.Errorf("discard: capture %+v, err %v, %d observations", c, err, len(observations.observations))
+	}
+}
+
+// TestExecuteQuickCapture_TooLarge verifies an oversized batch is refused outright.
+func TestExecuteQuickCapture_TooLarge(t *testing.T) {
+	items := make([]QuickCaptureItem, quickcapture.MaxBatchItems+1)
+	_, err := ExecuteQuickCapture(context.Background(), QuickCaptureInput{Items: items, AuthorID: "coach-1"}, QuickCaptureDeps{})
+	if err != ErrQuickCaptureTooLarge {
+		t.Errorf("err = %v, want ErrQuickCaptureTooLarge", err)
+	}
+}
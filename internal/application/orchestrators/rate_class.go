@@ -0,0 +1,87 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/feedback"
+	"workshop/internal/domain/schedule"
+)
+
+// ErrRatingAttendanceNotFound is returned when the check-in being rated isn't the member's.
+var ErrRatingAttendanceNotFound = errors.New("check-in not found")
+
+// RateClassAttendanceStore defines the attendance store interface needed to rate a class.
+type RateClassAttendanceStore interface {
+	GetByID(ctx context.Context, id string) (attendance.Attendance, error)
+}
+
+// RateClassOccurrenceStore defines the occurrence change store interface needed to spot substitutes.
+type RateClassOccurrenceStore interface {
+	GetByOccurrence(ctx context.Context, scheduleID, classDate string) (schedule.OccurrenceChange, error)
+}
+
+// RateClassFeedbackStore defines the feedback store interface needed to rate a class.
+type RateClassFeedbackStore interface {
+	Save(ctx context.Context, value feedback.Rating) error
+}
+
+// RateClassInput carries input for the rate class orchestrator.
+type RateClassInput struct {
+	MemberID     string // the signed-in member; only their own check-ins can be rated
+	AttendanceID string
+	Score        int
+	Comment      string
+}
+
+// RateClassDeps holds dependencies for the rate class orchestrator.
+type RateClassDeps struct {
+	AttendanceStore RateClassAttendanceStore
+	ScheduleStore   ScheduleLookupStore
+	OccurrenceStore RateClassOccurrenceStore // optional; nil credits the regular coach even on substitute days
+	FeedbackStore   RateClassFeedbackStore
+	GenerateID      func() string
+	Now             func() time.Time
+}
+
+// ExecuteRateClass records a member's rating of a class they checked in to. The rating keeps
+// the class's type, coach and date but not the member.
+// PRE: MemberID is the signed-in member
+// POST: The rating is saved; ErrRatingAttendanceNotFound, feedback.ErrNoClass,
+// feedback.ErrRatingClosed, feedback.ErrAlreadyRated or a validation error otherwise
+func ExecuteRateClass(ctx context.Context, input RateClassInput, deps RateClassDeps) (feedback.Rating, error) {
+	a, err := deps.AttendanceStore.GetByID(ctx, input.AttendanceID)
+	if err != nil || input.MemberID == "" || a.MemberID != input.MemberID {
+		return feedback.Rating{}, ErrRatingAttendanceNotFound
+	}
+	if a.ScheduleID == "" {
+		return feedback.Rating{}, feedback.ErrNoClass
+	}
+	r, err := feedback.NewRating(deps.GenerateID(), a.ID, a.CheckInTime, input.Score, input.Comment, deps.Now())
+	if err != nil {
+		return feedback.Rating{}, err
+	}
+	if a.ClassDate != "" {
+		r.ClassDate = a.ClassDate
+	}
+	sched, err := deps.ScheduleStore.GetByID(ctx, a.ScheduleID)
+	if err != nil {
+		return feedback.Rating{}, feedback.ErrNoClass
+	}
+	r.ScheduleID = sched.ID
+	r.ClassTypeID = sched.ClassTypeID
+	r.CoachID = sched.CoachID
+	if deps.OccurrenceStore != nil {
+		if change, err := deps.OccurrenceStore.GetByOccurrence(ctx, sched.ID, r.ClassDate); err == nil && change.Kind == schedule.ChangeSubstitute {
+			r.CoachID = "" // substitutes are named, not linked to an account
+		}
+	}
+	if err := deps.FeedbackStore.Save(ctx, r); err != nil {
+		return feedback.Rating{}, err
+	}
+	slog.InfoContext(ctx, "feedback_event", "event", "class_rated", "schedule_id", r.ScheduleID, "class_date", r.ClassDate)
+	return r, nil
+}
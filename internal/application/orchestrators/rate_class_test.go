@@ -0,0 +1,119 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/feedback"
+	"workshop/internal/domain/schedule"
+)
+
+type memRateClassStore struct {
+	attendance map[string]attendance.Attendance
+	changes    map[string]schedule.OccurrenceChange // by schedule ID + date
+	ratings    []feedback.Rating
+}
+
+// GetByID returns an attendance by ID.
+// PRE: id is non-empty
+// POST: Returns the attendance or an error
+func (m *memRateClassStore) GetByID(_ context.Context, id string) (attendance.Attendance, error) {
+	if a, ok := m.attendance[id]; ok {
+		return a, nil
+	}
+	return attendance.Attendance{}, errors.New("attendance not found")
+}
+
+// GetByOccurrence returns the change made to one class occurrence.
+// PRE: scheduleID and classDate are non-empty
+// POST: Returns the change or an error
+func (m *memRateClassStore) GetByOccurrence(_ context.Context, scheduleID, classDate string) (schedule.OccurrenceChange, error) {
+	if c, ok := m.changes[scheduleID+classDate]; ok {
+		return c, nil
+	}
+	return schedule.OccurrenceChange{}, errors.New("no change")
+}
+
+// Save appends a rating, refusing a second for the same attendance.
+// PRE: value is valid
+// POST: The rating is stored
+func (m *memRateClassStore) Save(_ context.Context, value feedback.Rating) error {
+	for _, r := range m.ratings {
+		if r.AttendanceID == value.AttendanceID {
+			return feedback.ErrAlreadyRated
+		}
+	}
+	m.ratings = append(m.ratings, value)
+	return nil
+}
+
+type rateClassSchedules map[string]schedule.Schedule
+
+// GetByID returns a schedule by ID.
+// PRE: id is non-empty
+// POST: Returns the schedule or an error
+func (s rateClassSchedules) GetByID(_ context.Context, id string) (schedule.Schedule, error) {
+	if sched, ok := s[id]; ok {
+		return sched, nil
+	}
+	return schedule.Schedule{}, errors.New("schedule not found")
+}
+
+// TestExecuteRateClass verifies a member can rate their own class once within 24 hours, the
+// rating carries the class's type and coach, and substitute days aren't credited to the coach.
+func TestExecuteRateClass(t *testing.T) {
+	checkIn := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	store := &memRateClassStore{
+		attendance: map[string]attendance.Attendance{
+			"a1":     {ID: "a1", MemberID: "m1", ScheduleID: "s1", ClassDate: "2026-03-02", CheckInTime: checkIn},
+			"a2":     {ID: "a2", MemberID: "m1", ScheduleID: "s1", ClassDate: "2026-02-23", CheckInTime: checkIn.AddDate(0, 0, -7)},
+			"a3":     {ID: "a3", MemberID: "m1", CheckInTime: checkIn},
+			"a4":     {ID: "a4", MemberID: "m1", ScheduleID: "s2", ClassDate: "2026-03-02", CheckInTime: checkIn},
+			"theirs": {ID: "theirs", MemberID: "m2", ScheduleID: "s1", ClassDate: "2026-03-02", CheckInTime: checkIn},
+		},
+		changes: map[string]schedule.OccurrenceChange{
+			"s22026-03-02": {ScheduleID: "s2", ClassDate: "2026-03-02", Kind: schedule.ChangeSubstitute, Substitute: "Aroha"},
+		},
+	}
+	deps := RateClassDeps{
+		AttendanceStore: store,
+		ScheduleStore: rateClassSchedules{
+			"s1": {ID: "s1", ClassTypeID: "fundamentals", CoachID: "coach-1"},
+			"s2": {ID: "s2", ClassTypeID: "nogi", CoachID: "coach-2"},
+		},
+		OccurrenceStore: store,
+		FeedbackStore:   store,
+		GenerateID:      func() string { return "r" },
+		Now:             func() time.Time { return checkIn.Add(3 * time.Hour) },
+	}
+	ctx := context.Background()
+	rate := func(attendanceID string, score int) (feedback.Rating, error) {
+		return ExecuteRateClass(ctx, RateClassInput{MemberID: "m1", AttendanceID: attendanceID, Score: score, Comment: "Great pace"}, deps)
+	}
+
+	r, err := rate("a1", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ClassTypeID != "fundamentals" || r.CoachID != "coach-1" || r.ClassDate != "2026-03-02" || r.Comment != "Great pace" {
+		t.Errorf("rating = %+v", r)
+	}
+	if _, err := rate("a1", 4); !errors.Is(err, feedback.ErrAlreadyRated) {
+		t.Errorf("second rating err = %v, want ErrAlreadyRated", err)
+	}
+	if _, err := rate("a2", 4); !errors.Is(err, feedback.ErrRatingClosed) {
+		t.Errorf("last week's class err = %v, want ErrRatingClosed", err)
+	}
+	if _, err := rate("a3", 4); !errors.Is(err, feedback.ErrNoClass) {
+		t.Errorf("open mat err = %v, want ErrNoClass", err)
+	}
+	if _, err := rate("theirs", 4); !errors.Is(err, ErrRatingAttendanceNotFound) {
+		t.Errorf("someone else's check-in err = %v, want ErrRatingAttendanceNotFound", err)
+	}
+	if r, err := rate("a4", 3); err != nil || r.CoachID != "" {
+		t.Errorf("substitute day = %+v, %v; want no coach credited", r, err)
+	}
+}
@@ -0,0 +1,216 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	reengagementStore "workshop/internal/adapters/storage/reengagement"
+	"workshop/internal/domain/attendance"
+	emailDomain "workshop/internal/domain/email"
+	"workshop/internal/domain/reengagement"
+)
+
+// ReengagementStore defines the re-engagement store interface needed by the automation.
+type ReengagementStore interface {
+	ListRules(ctx context.Context) ([]reengagement.Rule, error)
+	SaveAction(ctx context.Context, value reengagement.Action) error
+	ListActions(ctx context.Context, filter reengagementStore.ActionFilter) ([]reengagement.Action, error)
+	ListAwaitingReturn(ctx context.Context, since time.Time) ([]reengagement.Action, error)
+	ListSuppressions(ctx context.Context) ([]reengagement.Suppression, error)
+}
+
+// ReengagementAttendanceStore defines the attendance store interface needed to notice members coming back.
+type ReengagementAttendanceStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]attendance.Attendance, error)
+}
+
+// ReengagementCandidate is a member who has not checked in for a while.
+type ReengagementCandidate struct {
+	MemberID    string
+	Program     string
+	Status      string
+	LastCheckIn time.Time // zero when the member has never checked in
+}
+
+// RunReengagementDeps holds dependencies for RunReengagement.
+type RunReengagementDeps struct {
+	Store           ReengagementStore
+	AttendanceStore ReengagementAttendanceStore
+	// FindInactive returns the members who have not checked in for at least days days.
+	FindInactive  func(ctx context.Context, days int) ([]ReengagementCandidate, error)
+	NextClassDate func(ctx context.Context, program string) string // optional: nil leaves {{NextClassDate}} blank
+	Send          SendTemplatedEmailDeps
+	GenerateID    func() string
+	Now           func() time.Time
+}
+
+// RunReengagementResult summarises one run.
+type RunReengagementResult struct {
+	Emailed    int // emails sent
+	Skipped    int // emails not sent: no address, suppressed address or unsubscribed
+	Flagged    int // coach calls flagged
+	Suppressed int // inactive members left alone while paused
+	Returned   int // members who trained again after an action
+}
+
+// ExecuteRunReengagement takes the re-engagement steps members have reached. First it marks
+// the actions of members who have trained again as returned; then, for each active member
+// inactive long enough for an enabled rule and not paused, it sends the rule's email or
+// flags a coach call, once per rule per absence. A step that fails is logged and retried
+// on the next run.
+// PRE: deps are complete
+// POST: Each due rule has an action recorded for the member; failures are joined
+func ExecuteRunReengagement(ctx context.Context, deps RunReengagementDeps) (RunReengagementResult, error) {
+	now := deps.Now()
+	var result RunReengagementResult
+	var errs []error
+
+	returned, err := markReturned(ctx, deps, now)
+	result.Returned = returned
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	all, err := deps.Store.ListRules(ctx)
+	if err != nil {
+		return result, err
+	}
+	var rules []reengagement.Rule
+	minDays := 0
+	for _, r := range all {
+		if !r.Enabled {
+			continue
+		}
+		rules = append(rules, r)
+		if minDays == 0 || r.DaysInactive < minDays {
+			minDays = r.DaysInactive
+		}
+	}
+	if len(rules) == 0 {
+		return result, errors.Join(errs...)
+	}
+
+	candidates, err := deps.FindInactive(ctx, minDays)
+	if err != nil {
+		return result, err
+	}
+	suppressions, err := deps.Store.ListSuppressions(ctx)
+	if err != nil {
+		return result, err
+	}
+	paused := map[string]bool{}
+	for _, s := range suppressions {
+		if s.Active(now) {
+			paused[s.MemberID] = true
+		}
+	}
+
+	for _, c := range candidates {
+		if c.Status != "active" || c.LastCheckIn.IsZero() {
+			continue
+		}
+		if paused[c.MemberID] {
+			result.Suppressed++
+			continue
+		}
+		history, err := deps.Store.ListActions(ctx, reengagementStore.ActionFilter{MemberID: c.MemberID})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, rule := range reengagement.Due(rules, c.LastCheckIn, now, history) {
+			a := reengagement.Action{
+				ID:           deps.GenerateID(),
+				MemberID:     c.MemberID,
+				RuleID:       rule.ID,
+				RuleName:     rule.Name,
+				Kind:         rule.Action,
+				DaysInactive: reengagement.DaysSince(c.LastCheckIn, now),
+				LastCheckIn:  c.LastCheckIn,
+				Outcome:      reengagement.OutcomePending,
+				CreatedAt:    now,
+			}
+			if rule.Action == reengagement.ActionEmail {
+				vars := emailDomain.Vars{}
+				if deps.NextClassDate != nil {
+					vars[emailDomain.VarNextClassDate] = deps.NextClassDate(ctx, c.Program)
+				}
+				res, err := ExecuteSendTemplatedEmail(ctx, SendTemplatedEmailInput{
+					TemplateKey: rule.TemplateKey,
+					MemberID:    c.MemberID,
+					Vars:        vars,
+				}, deps.Send)
+				if err != nil {
+					slog.WarnContext(ctx, "reengagement_event", "event", "email_failed", "member_id", c.MemberID, "rule_id", rule.ID, "error", err)
+					errs = append(errs, err)
+					continue
+				}
+				if res.Skipped != "" {
+					a.Outcome = reengagement.OutcomeSkipped
+					a.Note = res.Skipped
+					result.Skipped++
+				} else {
+					a.Outcome = reengagement.OutcomeSent
+					a.EmailID = res.Email.ID
+					result.Emailed++
+				}
+			} else {
+				result.Flagged++
+			}
+			if err := deps.Store.SaveAction(ctx, a); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			slog.InfoContext(ctx, "reengagement_event", "event", "action_taken", "member_id", c.MemberID, "rule_id", rule.ID, "kind", a.Kind, "outcome", a.Outcome)
+		}
+	}
+
+	if result.Emailed+result.Skipped+result.Flagged+result.Returned > 0 {
+		slog.InfoContext(ctx, "reengagement_event", "event", "run_complete", "emailed", result.Emailed, "skipped", result.Skipped,
+			"flagged", result.Flagged, "suppressed", result.Suppressed, "returned", result.Returned)
+	}
+	return result, errors.Join(errs...)
+}
+
+// markReturned sets ReturnedAt on recent actions whose member has checked in since, and
+// returns how many members came back.
+func markReturned(ctx context.Context, deps RunReengagementDeps, now time.Time) (int, error) {
+	open, err := deps.Store.ListAwaitingReturn(ctx, now.AddDate(0, 0, -reengagement.LapsedAfterDays))
+	if err != nil {
+		return 0, err
+	}
+	checkIns := map[string][]attendance.Attendance{}
+	members := map[string]bool{}
+	var errs []error
+	for _, a := range open {
+		records, ok := checkIns[a.MemberID]
+		if !ok {
+			records, err = deps.AttendanceStore.ListByMemberID(ctx, a.MemberID)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			checkIns[a.MemberID] = records
+		}
+		var first time.Time
+		for _, r := range records {
+			if r.CheckInTime.After(a.CreatedAt) && (first.IsZero() || r.CheckInTime.Before(first)) {
+				first = r.CheckInTime
+			}
+		}
+		if first.IsZero() || !a.MarkReturned(first) {
+			continue
+		}
+		if err := deps.Store.SaveAction(ctx, a); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !members[a.MemberID] {
+			members[a.MemberID] = true
+			slog.InfoContext(ctx, "reengagement_event", "event", "member_returned", "member_id", a.MemberID)
+		}
+	}
+	return len(members), errors.Join(errs...)
+}
@@ -0,0 +1,160 @@
+package orchestrators
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	reengagementStore "workshop/internal/adapters/storage/reengagement"
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/reengagement"
+)
+
+// --- Mock stores for re-engagement tests ---
+
+type mockReengagementStore struct {
+	rules        []reengagement.Rule
+	actions      []reengagement.Action
+	suppressions []reengagement.Suppression
+}
+
+// ListRules returns the rules.
+// PRE: none
+// POST: Returns the rules
+func (m *mockReengagementStore) ListRules(_ context.Context) ([]reengagement.Rule, error) {
+	return m.rules, nil
+}
+
+// SaveAction inserts or replaces an action.
+// PRE: value has an ID
+// POST: The action is stored
+func (m *mockReengagementStore) SaveAction(_ context.Context, value reengagement.Action) error {
+	for i, a := range m.actions {
+		if a.ID == value.ID {
+			m.actions[i] = value
+			return nil
+		}
+	}
+	m.actions = append(m.actions, value)
+	return nil
+}
+
+// ListActions returns a member's actions.
+// PRE: none
+// POST: Returns the matching actions
+func (m *mockReengagementStore) ListActions(_ context.Context, filter reengagementStore.ActionFilter) ([]reengagement.Action, error) {
+	var list []reengagement.Action
+	for _, a := range m.actions {
+		if filter.MemberID == "" || a.MemberID == filter.MemberID {
+			list = append(list, a)
+		}
+	}
+	return list, nil
+}
+
+// ListAwaitingReturn returns actions whose member has not come back.
+// PRE: none
+// POST: Returns the open actions
+func (m *mockReengagementStore) ListAwaitingReturn(_ context.Context, since time.Time) ([]reengagement.Action, error) {
+	var list []reengagement.Action
+	for _, a := range m.actions {
+		if a.ReturnedAt.IsZero() && !a.CreatedAt.Before(since) {
+			list = append(list, a)
+		}
+	}
+	return list, nil
+}
+
+// ListSuppressions returns the pauses.
+// PRE: none
+// POST: Returns the suppressions
+func (m *mockReengagementStore) ListSuppressions(_ context.Context) ([]reengagement.Suppression, error) {
+	return m.suppressions, nil
+}
+
+type mockReengagementAttendanceStore struct {
+	records map[string][]attendance.Attendance
+}
+
+// ListByMemberID returns a member's check-ins.
+// PRE: memberID is non-empty
+// POST: Returns the check-ins
+func (m *mockReengagementAttendanceStore) ListByMemberID(_ context.Context, memberID string) ([]attendance.Attendance, error) {
+	return m.records[memberID], nil
+}
+
+// TestRunReengagement tests that each rule fires once per absence, paused and long-lapsed
+// members are left alone, and a member who trains again is marked returned.
+func TestRunReengagement(t *testing.T) {
+	emailStore := newMockEmailStore()
+	sender := newMockEmailSender()
+	store := &mockReengagementStore{
+		rules: []reengagement.Rule{
+			{ID: "email", Name: "We miss you", DaysInactive: 21, Action: reengagement.ActionEmail, TemplateKey: "inactive_follow_up", Enabled: true},
+			{ID: "call", Name: "Coach call", DaysInactive: 45, Action: reengagement.ActionCoachCall, Enabled: true},
+		},
+		suppressions: []reengagement.Suppression{{MemberID: "member-3", Reason: "Knee surgery"}},
+	}
+	attendanceStore := &mockReengagementAttendanceStore{records: map[string][]attendance.Attendance{}}
+	now := testNow()
+	candidates := []ReengagementCandidate{
+		{MemberID: "member-1", Program: "adults", Status: "active", LastCheckIn: now.AddDate(0, 0, -25)},
+		{MemberID: "member-2", Program: "adults", Status: "active", LastCheckIn: now.AddDate(0, 0, -50)},
+		{MemberID: "member-3", Program: "adults", Status: "active", LastCheckIn: now.AddDate(0, 0, -30)},
+		{MemberID: "member-4", Program: "adults", Status: "active", LastCheckIn: now.AddDate(0, 0, -400)},
+		{MemberID: "member-5", Program: "adults", Status: "inactive", LastCheckIn: now.AddDate(0, 0, -30)},
+	}
+	deps := RunReengagementDeps{
+		Store:           store,
+		AttendanceStore: attendanceStore,
+		FindInactive: func(_ context.Context, days int) ([]ReengagementCandidate, error) {
+			if days != 21 {
+				t.Errorf("FindInactive days = %d, want the lowest threshold", days)
+			}
+			return candidates, nil
+		},
+		NextClassDate: func(_ context.Context, program string) string { return "Tuesday 3 February (" + program + ")" },
+		Send:          newTemplatedEmailDeps(emailStore, sender),
+		GenerateID:    testGenerateID,
+		Now:           testNow,
+	}
+
+	got, err := ExecuteRunReengagement(context.Background(), deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := RunReengagementResult{Emailed: 2, Flagged: 1, Suppressed: 1}
+	if got != want {
+		t.Fatalf("result = %+v, want %+v", got, want)
+	}
+	if sender.sent != 2 || !strings.Contains(sender.sentReqs[0].HTML, "Tuesday 3 February (adults)") {
+		t.Errorf("sent = %d, want 2 emails with the next class date", sender.sent)
+	}
+	var call reengagement.Action
+	for _, a := range store.actions {
+		if a.Kind == reengagement.ActionCoachCall {
+			call = a
+		}
+	}
+	if call.MemberID != "member-2" || call.Outcome != reengagement.OutcomePending || call.DaysInactive != 50 {
+		t.Errorf("call = %+v, want a pending call for Yuki at 50 days", call)
+	}
+
+	// The next day nothing repeats, and Marcus is back on the mat.
+	back := now.Add(20 * time.Hour)
+	attendanceStore.records["member-1"] = []attendance.Attendance{{MemberID: "member-1", CheckInTime: back}}
+	deps.Now = func() time.Time { return now.AddDate(0, 0, 1) }
+	got, err = ExecuteRunReengagement(context.Background(), deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Emailed != 0 || got.Flagged != 0 || got.Returned != 1 || sender.sent != 2 {
+		t.Errorf("second run = %+v, want only Marcus returned", got)
+	}
+	for _, a := range store.actions {
+		if a.MemberID == "member-1" && !a.ReturnedAt.Equal(back) {
+			t.Errorf("Marcus's action ReturnedAt = %v, want %v", a.ReturnedAt, back)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/referral"
+
+	"github.com/google/uuid"
+)
+
+// ReferralStore defines the referral store interface needed to capture and convert referrals.
+type ReferralStore interface {
+	Save(ctx context.Context, value referral.Referral) error
+	GetByMemberID(ctx context.Context, memberID string) (referral.Referral, error)
+	GetSettings(ctx context.Context) (referral.Settings, error)
+}
+
+// ReferredBy names who referred a new person: a member picked from the list, or free text
+// for someone outside the system. Both empty means nobody.
+type ReferredBy struct {
+	MemberID string
+	Name     string
+}
+
+// isEmpty reports whether no referrer was given.
+func (r ReferredBy) isEmpty() bool {
+	return r.MemberID == "" && strings.TrimSpace(r.Name) == ""
+}
+
+// newReferral builds the referral for a new member, ready to save once the member is.
+// PRE: by is not empty
+// POST: Returns a validated referral or the validation error
+func newReferral(memberID string, by ReferredBy, source string, now time.Time) (referral.Referral, error) {
+	r := referral.Referral{
+		ID:               uuid.New().String(),
+		MemberID:         memberID,
+		ReferrerMemberID: by.MemberID,
+		ReferrerName:     strings.TrimSpace(by.Name),
+		Source:           source,
+		CreatedAt:        now,
+	}
+	return r, r.Validate()
+}
+
+// ConvertReferralInput carries input for ConvertReferral.
+type ConvertReferralInput struct {
+	MemberID string // the referred person, now an active member
+}
+
+// ConvertReferralDeps holds dependencies for ConvertReferral.
+type ConvertReferralDeps struct {
+	ReferralStore ReferralStore
+	Reward        func(ctx context.Context, r referral.Referral) // sends the reward recorded on r; called only when there is one
+	Now           func() time.Time
+}
+
+// ExecuteConvertReferral records that a referred person has become an active member and
+// rewards their referrer as the club's settings say. People nobody referred, and referrals
+// already converted, are left alone so callers need not check first.
+// PRE: MemberID is non-empty
+// POST: Returns the converted referral and true, or false when there was nothing to convert
+// INVARIANT: A referrer is rewarded at most once per referred member
+func ExecuteConvertReferral(ctx context.Context, input ConvertReferralInput, deps ConvertReferralDeps) (referral.Referral, bool, error) {
+	r, err := deps.ReferralStore.GetByMemberID(ctx, input.MemberID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && r.IsConverted()) {
+		return referral.Referral{}, false, nil
+	}
+	if err != nil {
+		return referral.Referral{}, false, err
+	}
+	settings, err := deps.ReferralStore.GetSettings(ctx)
+	if err != nil {
+		return referral.Referral{}, false, err
+	}
+	if err := r.Convert(settings, deps.Now()); err != nil {
+		return referral.Referral{}, false, err
+	}
+	if err := deps.ReferralStore.Save(ctx, r); err != nil {
+		return referral.Referral{}, false, err
+	}
+	if r.Reward != "" && deps.Reward != nil {
+		deps.Reward(ctx, r)
+	}
+	slog.InfoContext(ctx, "referral_event", "event", "referral_converted", "member_id", r.MemberID, "referrer_member_id", r.ReferrerMemberID, "reward", r.Reward)
+	return r, true, nil
+}
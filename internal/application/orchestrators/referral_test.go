@@ -0,0 +1,143 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	domain "workshop/internal/domain/member"
+	"workshop/internal/domain/referral"
+	"workshop/internal/domain/visitor"
+)
+
+type mockReferralStore struct {
+	referrals map[string]referral.Referral // by member ID
+	settings  referral.Settings
+}
+
+func newMockReferralStore() *mockReferralStore {
+	return &mockReferralStore{referrals: map[string]referral.Referral{}, settings: referral.DefaultSettings}
+}
+
+// Save implements ReferralStore.
+// PRE: none
+// POST: The referral is stored by member ID
+func (m *mockReferralStore) Save(_ context.Context, value referral.Referral) error {
+	m.referrals[value.MemberID] = value
+	return nil
+}
+
+// GetByMemberID implements ReferralStore.
+// PRE: none
+// POST: Returns the referral or sql.ErrNoRows
+func (m *mockReferralStore) GetByMemberID(_ context.Context, memberID string) (referral.Referral, error) {
+	r, ok := m.referrals[memberID]
+	if !ok {
+		return referral.Referral{}, sql.ErrNoRows
+	}
+	return r, nil
+}
+
+// GetSettings implements ReferralStore.
+// PRE: none
+// POST: Returns the configured settings
+func (m *mockReferralStore) GetSettings(_ context.Context) (referral.Settings, error) {
+	return m.settings, nil
+}
+
+// TestExecuteRegisterMember_Referral tests that a registration records who referred the member,
+// and that an invalid referral stops the member being saved.
+func TestExecuteRegisterMember_Referral(t *testing.T) {
+	members := &mockMemberStoreForImport{byEmail: map[string]domain.Member{}, byID: map[string]domain.Member{}}
+	referrals := newMockReferralStore()
+	deps := RegisterMemberDeps{MemberStore: members, ReferralStore: referrals}
+
+	_, err := ExecuteRegisterMember(context.Background(), RegisterMemberInput{
+		Email: "ana@example.com", Name: "Ana", Program: domain.ProgramAdults,
+		ReferredBy: ReferredBy{MemberID: "m-ref", Name: "Mere"},
+	}, deps)
+	if !errors.Is(err, referral.ErrBothReferrers) || len(members.byID) != 0 {
+		t.Fatalf("both referrers: err = %v with %d members, want ErrBothReferrers and nobody saved", err, len(members.byID))
+	}
+
+	id, err := ExecuteRegisterMember(context.Background(), RegisterMemberInput{
+		Email: "ana@example.com", Name: "Ana", Program: domain.ProgramAdults, ReferredBy: ReferredBy{MemberID: "m-ref"},
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r := referrals.referrals[id]; r.ReferrerMemberID != "m-ref" || r.Source != referral.SourceRegistration || r.IsConverted() {
+		t.Errorf("referral = %+v, want an unconverted registration referral from m-ref", r)
+	}
+
+	id, _ = ExecuteRegisterMember(context.Background(), RegisterMemberInput{Email: "bo@example.com", Name: "Bo", Program: domain.ProgramAdults}, deps)
+	if _, ok := referrals.referrals[id]; ok {
+		t.Errorf("member registered without a referrer has a referral")
+	}
+}
+
+// TestExecuteGuestCheckIn_Referral tests that the referrer a guest names is kept, and a
+// different name on a later visit does not replace it.
+func TestExecuteGuestCheckIn_Referral(t *testing.T) {
+	referrals := newMockReferralStore()
+	deps := GuestCheckInDeps{
+		MemberStore:     &mockGuestMemberStore{},
+		WaiverStore:     &mockGuestWaiverStore{},
+		AttendanceStore: &mockGuestAttendanceStore{},
+		VisitorStore:    &mockGuestVisitorStore{visitors: map[string]visitor.Visitor{}},
+		ReferralStore:   referrals,
+	}
+	input := GuestCheckInInput{Name: "Kai", Email: "kai@example.com", AcceptedTerms: true, ReferredBy: ReferredBy{Name: " Aunty Mere "}}
+	first, err := ExecuteGuestCheckIn(context.Background(), input, deps)
+	if err != nil {
+		t.Fatalf("first check-in: %v", err)
+	}
+	if r := referrals.referrals[first.MemberID]; r.ReferrerName != "Aunty Mere" || r.Source != referral.SourceGuestCheckIn {
+		t.Errorf("referral = %+v, want Aunty Mere from the kiosk", r)
+	}
+
+	input.ReferredBy = ReferredBy{MemberID: "m-ref"}
+	if _, err := ExecuteGuestCheckIn(context.Background(), input, deps); err != nil {
+		t.Fatalf("second check-in: %v", err)
+	}
+	if r := referrals.referrals[first.MemberID]; r.ReferrerName != "Aunty Mere" || len(referrals.referrals) != 1 {
+		t.Errorf("referrals = %+v, want the first referrer kept", referrals.referrals)
+	}
+
+	long := GuestCheckInInput{Name: "Tui", Email: "tui@example.com", AcceptedTerms: true, ReferredBy: ReferredBy{Name: strings.Repeat("x", 101)}}
+	if _, err := ExecuteGuestCheckIn(context.Background(), long, deps); !errors.Is(err, referral.ErrReferrerNameTooLong) {
+		t.Errorf("long referrer name: err = %v, want ErrReferrerNameTooLong", err)
+	}
+}
+
+// TestExecuteConvertReferral tests that conversion rewards a member referrer once, records
+// conversions without rewards for free-text referrers, and ignores people nobody referred.
+func TestExecuteConvertReferral(t *testing.T) {
+	referrals := newMockReferralStore()
+	referrals.settings = referral.Settings{Enabled: true, Reward: referral.RewardCredit, CreditAmount: 25}
+	referrals.referrals["m1"] = referral.Referral{ID: "r1", MemberID: "m1", ReferrerMemberID: "m-ref", Source: referral.SourceRegistration}
+	referrals.referrals["m2"] = referral.Referral{ID: "r2", MemberID: "m2", ReferrerName: "Mere", Source: referral.SourceGuestCheckIn}
+	var rewarded []referral.Referral
+	deps := ConvertReferralDeps{
+		ReferralStore: referrals,
+		Reward:        func(_ context.Context, r referral.Referral) { rewarded = append(rewarded, r) },
+		Now:           fixedNow,
+	}
+
+	r, ok, err := ExecuteConvertReferral(context.Background(), ConvertReferralInput{MemberID: "m1"}, deps)
+	if err != nil || !ok || !r.ConvertedAt.Equal(fixedTime) || r.Reward != referral.RewardCredit || r.CreditAmount != 25 {
+		t.Fatalf("convert m1 = %+v, %v, %v; want a $25 credit", r, ok, err)
+	}
+	if _, ok, err := ExecuteConvertReferral(context.Background(), ConvertReferralInput{MemberID: "m1"}, deps); ok || err != nil || len(rewarded) != 1 {
+		t.Errorf("second conversion: ok = %v, err = %v, rewarded %d times; want a no-op", ok, err, len(rewarded))
+	}
+
+	if r, ok, err := ExecuteConvertReferral(context.Background(), ConvertReferralInput{MemberID: "m2"}, deps); err != nil || !ok || r.Reward != "" || len(rewarded) != 1 {
+		t.Errorf("free-text referrer = %+v, %v, %v; want converted without a reward", r, ok, err)
+	}
+	if _, ok, err := ExecuteConvertReferral(context.Background(), ConvertReferralInput{MemberID: "m9"}, deps); ok || err != nil {
+		t.Errorf("unreferred member: ok = %v, err = %v; want a no-op", ok, err)
+	}
+}
@@ -3,8 +3,10 @@ package orchestrators
 import (
 	"context"
 	"errors"
+	"time"
 
 	"workshop/internal/domain/member"
+	"workshop/internal/domain/referral"
 
 	"github.com/google/uuid"
 )
@@ -18,19 +20,21 @@ type MemberStore interface {
 
 // RegisterMemberInput carries input for the orchestrator.
 type RegisterMemberInput struct {
-	Email   string
-	Name    string
-	Program string
+	Email      string
+	Name       string
+	Program    string
+	ReferredBy ReferredBy // optional: who sent them to the club
 }
 
 // RegisterMemberDeps holds dependencies for RegisterMember.
 type RegisterMemberDeps struct {
-	MemberStore MemberStore
+	MemberStore   MemberStore
+	ReferralStore ReferralStore // optional: nil records no referral
 }
 
 // ExecuteRegisterMember coordinates member registration.
 // PRE: Valid email, non-empty name, valid program
-// POST: Member created with ID, Status=active
+// POST: Member created with ID, Status=active; their referral recorded when ReferredBy is set
 // INVARIANT: Email must be unique (enforced by store)
 func ExecuteRegisterMember(ctx context.Context, input RegisterMemberInput, deps RegisterMemberDeps) (string, error) {
 	// Validate input
@@ -59,10 +63,25 @@ func ExecuteRegisterMember(ctx context.Context, input RegisterMemberInput, deps
 		return "", err
 	}
 
+	// Check the referral before anything is saved
+	var ref referral.Referral
+	withReferral := deps.ReferralStore != nil && !input.ReferredBy.isEmpty()
+	if withReferral {
+		var err error
+		if ref, err = newReferral(m.ID, input.ReferredBy, referral.SourceRegistration, time.Now()); err != nil {
+			return "", err
+		}
+	}
+
 	// Save to store
 	if err := deps.MemberStore.Save(ctx, m); err != nil {
 		return "", err
 	}
+	if withReferral {
+		if err := deps.ReferralStore.Save(ctx, ref); err != nil {
+			return m.ID, err
+		}
+	}
 
 	return m.ID, nil
 }
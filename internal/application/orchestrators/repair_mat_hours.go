@@ -0,0 +1,132 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/audit"
+)
+
+// ErrRepairMatHoursRange is returned when the repair's dates are out of order.
+var ErrRepairMatHoursRange = errors.New("from must not be after to")
+
+// RepairMatHoursAttendanceStore defines the attendance store interface needed to repair mat hours.
+type RepairMatHoursAttendanceStore interface {
+	ListByDateRange(ctx context.Context, startDate string, endDate string) ([]attendance.Attendance, error)
+	Save(ctx context.Context, a attendance.Attendance) error
+}
+
+// RepairMatHoursInput carries the check-ins to scan and whether to write the corrections.
+type RepairMatHoursInput struct {
+	From   string // optional YYYY-MM-DD; empty scans from the first check-in
+	To     string // optional YYYY-MM-DD; empty scans to today
+	DryRun bool   // report the corrections without saving them
+	Actor  BackfillActor
+}
+
+// RepairMatHoursDeps holds dependencies for RepairMatHours.
+type RepairMatHoursDeps struct {
+	AttendanceStore RepairMatHoursAttendanceStore
+	ScheduleStore   ScheduleLookupStore
+	ChangeStore     OccurrenceLookupStore // optional: nil ignores moved and rescheduled classes
+	AuditStore      BackfillAuditStore
+	Now             func() time.Time
+}
+
+// MatHoursCorrection is one check-in whose mat hours are wrong.
+type MatHoursCorrection struct {
+	AttendanceID string
+	MemberID     string
+	ScheduleID   string
+	ClassDate    string // YYYY-MM-DD
+	Problem      string // attendance.MatHoursNegative, MatHoursAbsurd, MatHoursMissing or MatHoursStale
+	From         float64
+	To           float64
+}
+
+// RepairMatHoursResult reports what the repair found and whether it was written.
+type RepairMatHoursResult struct {
+	Scanned     int
+	DryRun      bool
+	Corrections []MatHoursCorrection
+}
+
+// ExecuteRepairMatHours recomputes mat hours from class durations, as each class ran on the
+// day, for check-ins between From and To. It corrects negative, absurd and missing values
+// and, for check-ins never checked out, values that no longer match the class. A dry run
+// only reports.
+// PRE: input.Actor.AccountID is an admin
+// POST: Returns every correction found; unless DryRun each is saved and audited
+func ExecuteRepairMatHours(ctx context.Context, input RepairMatHoursInput, deps RepairMatHoursDeps) (RepairMatHoursResult, error) {
+	from, to := input.From, input.To
+	if from == "" {
+		from = "0000-01-01"
+	}
+	if to == "" {
+		to = deps.Now().Format("2006-01-02")
+	}
+	if from > to {
+		return RepairMatHoursResult{}, ErrRepairMatHoursRange
+	}
+	records, err := deps.AttendanceStore.ListByDateRange(ctx, from, to)
+	if err != nil {
+		return RepairMatHoursResult{}, err
+	}
+
+	result := RepairMatHoursResult{Scanned: len(records), DryRun: input.DryRun, Corrections: []MatHoursCorrection{}}
+	classHours := map[string]float64{} // schedule ID and class date -> hours the class ran
+	for _, a := range records {
+		key := a.ScheduleID + "|" + a.Date()
+		hours, seen := classHours[key]
+		if !seen && a.ScheduleID != "" {
+			schedules, _ := withClassChanges(deps.ScheduleStore, nil, deps.ChangeStore, a.Date())
+			if sched, err := schedules.GetByID(ctx, a.ScheduleID); err == nil {
+				hours, _ = sched.DurationHours()
+			}
+			classHours[key] = hours
+		}
+		corrected, problem := attendance.CorrectMatHours(a, hours)
+		if problem == "" {
+			continue
+		}
+		c := MatHoursCorrection{AttendanceID: a.ID, MemberID: a.MemberID, ScheduleID: a.ScheduleID, ClassDate: a.Date(), Problem: problem, From: a.MatHours, To: corrected}
+		result.Corrections = append(result.Corrections, c)
+		if input.DryRun {
+			continue
+		}
+		a.MatHours = corrected
+		if err := deps.AttendanceStore.Save(ctx, a); err != nil {
+			return result, err
+		}
+		matHoursRepairAudit(ctx, c, input.Actor, deps)
+	}
+	slog.InfoContext(ctx, "checkin_event", "event", "mat_hours_repaired", "scanned", result.Scanned, "corrections", len(result.Corrections), "dry_run", input.DryRun)
+	return result, nil
+}
+
+// matHoursRepairAudit records a correction in the audit log. A failure is logged, not
+// returned: the correction has already been saved.
+func matHoursRepairAudit(ctx context.Context, c MatHoursCorrection, actor BackfillActor, deps RepairMatHoursDeps) {
+	metadata, _ := json.Marshal(map[string]string{
+		"member_id":   c.MemberID,
+		"schedule_id": c.ScheduleID,
+		"class_date":  c.ClassDate,
+		"problem":     c.Problem,
+		"from":        strconv.FormatFloat(c.From, 'f', -1, 64),
+		"to":          strconv.FormatFloat(c.To, 'f', -1, 64),
+		"source":      "mat_hours_repair",
+	})
+	event := audit.NewEvent(actor.AccountID, actor.Email, actor.Role, audit.CategoryAttendance, audit.ActionUpdate).
+		WithResource("attendance", c.AttendanceID).
+		WithDescription("Corrected "+c.Problem+" mat hours").
+		WithRequest(actor.IPAddress, actor.UserAgent).
+		WithMetadata(string(metadata))
+	if err := deps.AuditStore.Save(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "checkin_event", "event", "mat_hours_audit_failed", "attendance_id", c.AttendanceID, "error", err)
+	}
+}
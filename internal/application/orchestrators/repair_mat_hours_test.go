@@ -0,0 +1,120 @@
+package orchestrators
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/schedule"
+)
+
+type mockRepairMatHoursStore struct {
+	mockAnomalyAttendanceStore
+}
+
+// ListByDateRange implements RepairMatHoursAttendanceStore.
+// PRE: startDate <= endDate
+// POST: returns records checked in between the dates
+func (m *mockRepairMatHoursStore) ListByDateRange(_ context.Context, startDate string, endDate string) ([]attendance.Attendance, error) {
+	var out []attendance.Attendance
+	for _, a := range m.records {
+		if d := a.CheckInTime.Format("2006-01-02"); d >= startDate && d <= endDate {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func newRepairMatHoursStore() *mockRepairMatHoursStore {
+	in := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	store := &mockRepairMatHoursStore{}
+	store.records = []attendance.Attendance{
+		{ID: "ok", MemberID: "m1", ScheduleID: "fundamentals", CheckInTime: in, MatHours: 1},
+		{ID: "negative", MemberID: "m1", ScheduleID: "advanced", CheckInTime: in, MatHours: -3},
+		{ID: "absurd", MemberID: "m2", ScheduleID: "open-mat", CheckInTime: in, CheckOutTime: in.Add(time.Hour), MatHours: 40},
+		{ID: "missing", MemberID: "m2", ScheduleID: "fundamentals", CheckInTime: in.AddDate(0, 0, 7)},
+	}
+	return store
+}
+
+// TestExecuteRepairMatHours_DryRun verifies a dry run reports every bad check-in without
+// saving or auditing anything.
+func TestExecuteRepairMatHours_DryRun(t *testing.T) {
+	store := newRepairMatHoursStore()
+	auditStore := &mockBackfillAuditStore{}
+	result, err := ExecuteRepairMatHours(context.Background(), RepairMatHoursInput{DryRun: true}, RepairMatHoursDeps{
+		AttendanceStore: store,
+		ScheduleStore:   newAnomalyScheduleStore(),
+		AuditStore:      auditStore,
+		Now:             func() time.Time { return time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC) },
+	})
+	if err != nil {
+		t.Fatalf("repair: %v", err)
+	}
+	if result.Scanned != 4 || len(result.Corrections) != 3 || !result.DryRun {
+		t.Fatalf("result = %+v, want 3 corrections of 4", result)
+	}
+	if c := result.Corrections[0]; c.AttendanceID != "negative" || c.Problem != attendance.MatHoursNegative || c.From != -3 || c.To != 1.5 {
+		t.Errorf("first correction = %+v", c)
+	}
+	if store.records[1].MatHours != -3 || len(auditStore.events) != 0 {
+		t.Errorf("dry run saved: %+v, %d audit events", store.records[1], len(auditStore.events))
+	}
+}
+
+// TestExecuteRepairMatHours_Apply verifies corrections are saved and audited, and only
+// check-ins inside the range are touched.
+func TestExecuteRepairMatHours_Apply(t *testing.T) {
+	store := newRepairMatHoursStore()
+	auditStore := &mockBackfillAuditStore{}
+	result, err := ExecuteRepairMatHours(context.Background(), RepairMatHoursInput{From: "2026-03-01", To: "2026-03-05", Actor: BackfillActor{AccountID: "admin1"}}, RepairMatHoursDeps{
+		AttendanceStore: store,
+		ScheduleStore:   newAnomalyScheduleStore(),
+		AuditStore:      auditStore,
+		Now:             time.Now,
+	})
+	if err != nil {
+		t.Fatalf("repair: %v", err)
+	}
+	if len(result.Corrections) != 2 {
+		t.Fatalf("corrections = %+v, want negative and absurd", result.Corrections)
+	}
+	if store.records[1].MatHours != 1.5 || store.records[2].MatHours != 1 || store.records[3].MatHours != 0 {
+		t.Errorf("records = %+v", store.records)
+	}
+	if len(auditStore.events) != 2 || auditStore.events[0].ResourceID != "negative" {
+		t.Errorf("audit = %+v", auditStore.events)
+	}
+
+	if _, err := ExecuteRepairMatHours(context.Background(), RepairMatHoursInput{From: "2026-04-01", To: "2026-03-01"}, RepairMatHoursDeps{AttendanceStore: store, Now: time.Now}); err != ErrRepairMatHoursRange {
+		t.Errorf("reversed range: err = %v", err)
+	}
+}
+
+// TestExecuteRepairMatHours_MovedClass verifies a check-in at a class moved to a longer slot
+// that day keeps the hours it ran, while the usual class a week later is still checked.
+func TestExecuteRepairMatHours_MovedClass(t *testing.T) {
+	in := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	store := &mockRepairMatHoursStore{}
+	store.records = []attendance.Attendance{
+		{ID: "moved", MemberID: "m1", ScheduleID: "fundamentals", CheckInTime: in, MatHours: 2},
+		{ID: "usual", MemberID: "m1", ScheduleID: "fundamentals", CheckInTime: in.AddDate(0, 0, 7), MatHours: 2},
+	}
+	changes := &mockOccurrenceChangeStore{changes: map[string]schedule.OccurrenceChange{
+		"c1": {ID: "c1", ScheduleID: "fundamentals", ClassDate: "2026-03-02", Kind: schedule.ChangeMoved, StartTime: "18:00", EndTime: "20:00"},
+	}}
+	result, err := ExecuteRepairMatHours(context.Background(), RepairMatHoursInput{DryRun: true}, RepairMatHoursDeps{
+		AttendanceStore: store,
+		ScheduleStore:   newAnomalyScheduleStore(),
+		ChangeStore:     changes,
+		AuditStore:      &mockBackfillAuditStore{},
+		Now:             func() time.Time { return time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC) },
+	})
+	if err != nil {
+		t.Fatalf("repair: %v", err)
+	}
+	if len(result.Corrections) != 1 || result.Corrections[0].AttendanceID != "usual" || result.Corrections[0].To != 1 {
+		t.Errorf("corrections = %+v, want only the usual class corrected to 1 hour", result.Corrections)
+	}
+}
@@ -20,6 +20,7 @@ type ReportInjuryInput struct {
 	BodyPart    string
 	Description string
 	MemberID    string
+	Severity    string // optional: low, medium, high
 }
 
 // ReportInjuryDeps holds dependencies for ReportInjury.
@@ -30,8 +31,8 @@ type ReportInjuryDeps struct {
 
 // ExecuteReportInjury coordinates injury reporting.
 // PRE: Member exists, BodyPart specified
-// POST: Injury flag created
-// INVARIANT: Injury visible for 7 days
+// POST: Injury flag created with status active
+// INVARIANT: Injury visible until a coach resolves it
 func ExecuteReportInjury(ctx context.Context, input ReportInjuryInput, deps ReportInjuryDeps) error {
 	// Validate input
 	if input.MemberID == "" {
@@ -54,6 +55,8 @@ func ExecuteReportInjury(ctx context.Context, input ReportInjuryInput, deps Repo
 		BodyPart:    input.BodyPart,
 		Description: input.Description,
 		ReportedAt:  time.Now(),
+		Status:      injury.StatusActive,
+		Severity:    input.Severity,
 	}
 
 	// Validate domain rules
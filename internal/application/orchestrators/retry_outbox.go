@@ -50,7 +50,7 @@ func (p *OutboxProcessor) ProcessPending(ctx context.Context) error {
 
 	for _, entry := range entries {
 		if err := p.processEntry(ctx, entry); err != nil {
-			slog.Error("outbox_process_failed", "entry_id", entry.ID, "action_type", entry.ActionType, "error", err.Error())
+			slog.ErrorContext(ctx, "outbox_process_failed", "entry_id", entry.ID, "action_type", entry.ActionType, "error", err.Error())
 		}
 	}
 
@@ -77,10 +77,10 @@ func (p *OutboxProcessor) processEntry(ctx context.Context, entry domain.Entry)
 	externalID, err := executor.Execute(ctx, entry.Payload)
 	if err != nil {
 		entry.MarkFailed(err)
-		slog.Warn("outbox_action_failed", "entry_id", entry.ID, "attempt", entry.Attempts, "error", err.Error())
+		slog.WarnContext(ctx, "outbox_action_failed", "entry_id", entry.ID, "attempt", entry.Attempts, "error", err.Error())
 	} else {
 		entry.MarkSuccess(externalID)
-		slog.Info("outbox_action_succeeded", "entry_id", entry.ID, "action_type", entry.ActionType, "external_id", externalID)
+		slog.InfoContext(ctx, "outbox_action_succeeded", "entry_id", entry.ID, "action_type", entry.ActionType, "external_id", externalID)
 	}
 
 	return p.store.Save(ctx, entry)
@@ -202,7 +202,7 @@ func StartBackgroundWorker(processor *OutboxProcessor, interval time.Duration, s
 			case <-ticker.C:
 				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 				if err := processor.ProcessPending(ctx); err != nil {
-					slog.Error("outbox_background_process_failed", "error", err.Error())
+					slog.ErrorContext(ctx, "outbox_background_process_failed", "error", err.Error())
 				}
 				cancel()
 			case <-stopCh:
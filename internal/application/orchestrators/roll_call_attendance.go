@@ -0,0 +1,247 @@
+package orchestrators
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/audit"
+)
+
+// MaxRollCallMarks caps the members marked in one roll call.
+const MaxRollCallMarks = 200
+
+// Roll call statuses, in addition to the bulk sync created and rejected.
+const (
+	RollCallStatusRemoved   = "removed"
+	RollCallStatusUnchanged = "unchanged"
+)
+
+// Roll call errors returned before any mark is processed.
+var (
+	ErrRollCallTooLarge       = errors.New("too many members: a roll call must not exceed 200")
+	ErrRollCallEmpty          = errors.New("mark at least one member")
+	ErrRollCallScheduleNeeded = errors.New("a class must be selected")
+	ErrRollCallScheduleAbsent = errors.New("class not found")
+	ErrRollCallDate           = errors.New("invalid class date")
+)
+
+// RollCallAttendanceStore defines the attendance store interface needed for roll call.
+type RollCallAttendanceStore interface {
+	Save(ctx context.Context, a attendance.Attendance) error
+	Delete(ctx context.Context, id string) error
+	ListByMemberIDAndDate(ctx context.Context, memberID string, date string) ([]attendance.Attendance, error)
+}
+
+// RollCallMark records whether one member was on the mat.
+type RollCallMark struct {
+	MemberID string
+	Present  bool
+}
+
+// RollCallInput marks members present or absent for one class session.
+type RollCallInput struct {
+	ScheduleID string
+	ClassDate  string // YYYY-MM-DD
+	Marks      []RollCallMark
+	Actor      BackfillActor
+}
+
+// RollCallMarkResult reports the outcome for one member.
+type RollCallMarkResult struct {
+	MemberID     string
+	Present      bool
+	Status       string // created, removed, unchanged or rejected
+	AttendanceID string // attendance created, kept or removed (empty when rejected or already absent)
+	Reason       string
+}
+
+// RollCallResult carries per-member outcomes in request order.
+type RollCallResult struct {
+	ScheduleID string
+	ClassDate  string
+	Results    []RollCallMarkResult
+	Created    int
+	Removed    int
+	Unchanged  int
+	Rejected   int
+}
+
+// RollCallDeps holds dependencies for RollCall.
+type RollCallDeps struct {
+	MemberStore     CheckInSearchStore
+	AttendanceStore RollCallAttendanceStore
+	ScheduleStore   ScheduleLookupStore
+	AuditStore      BackfillAuditStore
+	TopicDeps       *LinkAttendanceTopicsDeps // optional: nil skips linking attendance to rotor topics
+	PackDeps        *UseClassPackDeps         // optional: nil uses no class packs
+	GenerateID      func() string
+	Now             func() time.Time
+}
+
+// ExecuteRollCall writes a coach's roll call for one class session. Present members
+// without attendance for the session get a record at the class start time; absent
+// members lose theirs, including kiosk check-ins, because the coach's count wins.
+// The date must fall on the class's weekday, no later than today and no more than
+// MaxBackfillAge ago. Every change gets an audit event naming the coach.
+// PRE: input.Actor.AccountID is a coach or admin
+// POST: Each mark is created, removed, unchanged or rejected with a reason
+// INVARIANT: A rejected member never aborts the rest of the roll call
+func ExecuteRollCall(ctx context.Context, input RollCallInput, deps RollCallDeps) (RollCallResult, error) {
+	if input.ScheduleID == "" {
+		return RollCallResult{}, ErrRollCallScheduleNeeded
+	}
+	if len(input.Marks) == 0 {
+		return RollCallResult{}, ErrRollCallEmpty
+	}
+	if len(input.Marks) > MaxRollCallMarks {
+		return RollCallResult{}, ErrRollCallTooLarge
+	}
+
+	sched, err := deps.ScheduleStore.GetByID(ctx, input.ScheduleID)
+	if err != nil {
+		return RollCallResult{}, ErrRollCallScheduleAbsent
+	}
+	now := deps.Now()
+	day, err := time.Parse("2006-01-02", input.ClassDate)
+	switch {
+	case err != nil:
+		return RollCallResult{}, fmt.Errorf("%w: date must be YYYY-MM-DD", ErrRollCallDate)
+	case input.ClassDate > now.Format("2006-01-02"):
+		return RollCallResult{}, fmt.Errorf("%w: date is in the future", ErrRollCallDate)
+	case input.ClassDate < now.Add(-MaxBackfillAge).Format("2006-01-02"):
+		return RollCallResult{}, fmt.Errorf("%w: date is too old", ErrRollCallDate)
+	case strings.ToLower(day.Weekday().String()) != sched.Day:
+		return RollCallResult{}, fmt.Errorf("%w: class does not run on %s", ErrRollCallDate, day.Weekday())
+	}
+	start, err := time.Parse("15:04", sched.StartTime)
+	if err != nil {
+		return RollCallResult{}, fmt.Errorf("class start time: %w", err)
+	}
+	matHours, _ := sched.DurationHours()
+	checkIn := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, now.Location())
+
+	result := RollCallResult{ScheduleID: input.ScheduleID, ClassDate: input.ClassDate, Results: make([]RollCallMarkResult, 0, len(input.Marks))}
+	seen := make(map[string]bool, len(input.Marks))
+	for _, mark := range input.Marks {
+		var res RollCallMarkResult
+		switch {
+		case mark.MemberID == "":
+			res = RollCallMarkResult{Present: mark.Present, Status: BulkSyncStatusRejected, Reason: "member is required"}
+		case seen[mark.MemberID]:
+			res = RollCallMarkResult{MemberID: mark.MemberID, Present: mark.Present, Status: BulkSyncStatusRejected, Reason: "member is marked twice"}
+		default:
+			res = rollCallOne(ctx, mark, checkIn, matHours, sched.LocationID, input, deps)
+		}
+		seen[mark.MemberID] = true
+
+		switch res.Status {
+		case BulkSyncStatusCreated:
+			result.Created++
+		case RollCallStatusRemoved:
+			result.Removed++
+		case RollCallStatusUnchanged:
+			result.Unchanged++
+		default:
+			result.Rejected++
+		}
+		result.Results = append(result.Results, res)
+	}
+
+	slog.InfoContext(ctx, "checkin_event", "event", "roll_call", "schedule_id", input.ScheduleID, "class_date", input.ClassDate,
+		"created", result.Created, "removed", result.Removed, "unchanged", result.Unchanged, "rejected", result.Rejected,
+		"actor", input.Actor.AccountID)
+	return result, nil
+}
+
+// rollCallOne applies one mark: creates the session's attendance or removes it.
+func rollCallOne(ctx context.Context, mark RollCallMark, checkIn time.Time, matHours float64, locationID string, input RollCallInput, deps RollCallDeps) RollCallMarkResult {
+	res := RollCallMarkResult{MemberID: mark.MemberID, Present: mark.Present}
+	reject := func(reason string) RollCallMarkResult {
+		res.Status = BulkSyncStatusRejected
+		res.Reason = reason
+		return res
+	}
+
+	m, err := deps.MemberStore.GetByID(ctx, mark.MemberID)
+	if err != nil {
+		return reject("member not found")
+	}
+	existing, err := deps.AttendanceStore.ListByMemberIDAndDate(ctx, mark.MemberID, input.ClassDate)
+	if err != nil {
+		return reject("could not check existing attendance")
+	}
+	var session []attendance.Attendance
+	for _, a := range existing {
+		if a.ScheduleID == input.ScheduleID {
+			session = append(session, a)
+		}
+	}
+
+	if !mark.Present {
+		res.Status = RollCallStatusUnchanged
+		for _, a := range session {
+			if err := deps.AttendanceStore.Delete(ctx, a.ID); err != nil {
+				slog.ErrorContext(ctx, "checkin_event", "event", "roll_call_delete_failed", "attendance_id", a.ID, "error", err)
+				return reject("could not remove attendance")
+			}
+			rollCallAudit(ctx, audit.ActionDelete, a.ID, fmt.Sprintf("Marked %s absent on %s", m.Name, input.ClassDate), input, deps)
+			refundClassPack(ctx, a.ID, deps.PackDeps)
+			res.Status = RollCallStatusRemoved
+			res.AttendanceID = a.ID
+		}
+		return res
+	}
+
+	if len(session) > 0 {
+		res.Status = RollCallStatusUnchanged
+		res.AttendanceID = session[0].ID
+		return res
+	}
+	if m.IsArchived() {
+		return reject("archived members cannot be marked present")
+	}
+	if m.IsFrozen() {
+		return reject("frozen members cannot be marked present")
+	}
+	a := attendance.Attendance{
+		ID:          deps.GenerateID(),
+		MemberID:    mark.MemberID,
+		CheckInTime: checkIn,
+		ScheduleID:  input.ScheduleID,
+		ClassDate:   input.ClassDate,
+		MatHours:    matHours,
+		LocationID:  locationID,
+	}
+	if err := a.Validate(); err != nil {
+		return reject(err.Error())
+	}
+	if err := deps.AttendanceStore.Save(ctx, a); err != nil {
+		slog.ErrorContext(ctx, "checkin_event", "event", "roll_call_save_failed", "member_id", mark.MemberID, "error", err)
+		return reject("could not save attendance")
+	}
+	rollCallAudit(ctx, audit.ActionCreate, a.ID, fmt.Sprintf("Marked %s present on %s", m.Name, input.ClassDate), input, deps)
+	linkAttendanceTopics(ctx, a, deps.TopicDeps)
+	useClassPack(ctx, a, deps.PackDeps)
+	res.Status = BulkSyncStatusCreated
+	res.AttendanceID = a.ID
+	return res
+}
+
+// rollCallAudit records who changed an attendance record through roll call.
+func rollCallAudit(ctx context.Context, action audit.Action, attendanceID, description string, input RollCallInput, deps RollCallDeps) {
+	metadata, _ := json.Marshal(map[string]string{"schedule_id": input.ScheduleID, "class_date": input.ClassDate, "source": "roll_call"})
+	event := audit.NewEvent(input.Actor.AccountID, input.Actor.Email, input.Actor.Role, audit.CategoryAttendance, action).
+		WithResource("attendance", attendanceID).
+		WithDescription(description).
+		WithRequest(input.Actor.IPAddress, input.Actor.UserAgent).
+		WithMetadata(string(metadata))
+	if err := deps.AuditStore.Save(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "checkin_event", "event", "roll_call_audit_failed", "attendance_id", attendanceID, "error", err)
+	}
+}
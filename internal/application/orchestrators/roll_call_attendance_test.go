@@ -0,0 +1,139 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/audit"
+)
+
+type mockRollCallAttendanceStore struct {
+	mockBulkSyncAttendanceStore
+}
+
+// Delete implements RollCallAttendanceStore.
+// PRE: id is non-empty
+// POST: the record with id is removed
+func (m *mockRollCallAttendanceStore) Delete(_ context.Context, id string) error {
+	for i, a := range m.records {
+		if a.ID == id {
+			m.records = append(m.records[:i], m.records[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func newRollCallDeps(store *mockRollCallAttendanceStore, auditStore *mockBackfillAuditStore) RollCallDeps {
+	backfill := newBackfillDeps(&store.mockBulkSyncAttendanceStore, auditStore)
+	return RollCallDeps{
+		MemberStore:     backfill.MemberStore,
+		AttendanceStore: store,
+		ScheduleStore:   backfill.ScheduleStore,
+		AuditStore:      auditStore,
+		GenerateID:      backfill.GenerateID,
+		Now:             fixedNow,
+	}
+}
+
+// TestExecuteRollCall_MarksPresentAndAbsent verifies creation, removal and per-member rejections.
+func TestExecuteRollCall_MarksPresentAndAbsent(t *testing.T) {
+	kioskCheckIn := time.Date(2026, 3, 1, 9, 55, 0, 0, time.UTC)
+	store := &mockRollCallAttendanceStore{mockBulkSyncAttendanceStore{records: []attendance.Attendance{
+		{ID: "kiosk-m3", MemberID: "m3", ScheduleID: "s1", ClassDate: "2026-03-01", CheckInTime: kioskCheckIn},
+	}}}
+	auditStore := &mockBackfillAuditStore{}
+
+	result, err := ExecuteRollCall(context.Background(), RollCallInput{
+		ScheduleID: "s1",
+		ClassDate:  "2026-03-01",
+		Marks: []RollCallMark{
+			{MemberID: "m1", Present: true},
+			{MemberID: "m3", Present: false},
+			{MemberID: "m2", Present: true},
+			{MemberID: "m2", Present: false},
+			{MemberID: "ghost", Present: true},
+		},
+		Actor: BackfillActor{AccountID: "coach-1", Email: "coach@test.com", Role: "coach"},
+	}, newRollCallDeps(store, auditStore))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		status, reason string
+	}{
+		{BulkSyncStatusCreated, ""},
+		{RollCallStatusRemoved, ""},
+		{BulkSyncStatusRejected, "archived members cannot be marked present"},
+		{BulkSyncStatusRejected, "member is marked twice"},
+		{BulkSyncStatusRejected, "member not found"},
+	}
+	for i, w := range want {
+		got := result.Results[i]
+		if got.Status != w.status || got.Reason != w.reason {
+			t.Errorf("result[%d] %s = %s (%q), want %s (%q)", i, got.MemberID, got.Status, got.Reason, w.status, w.reason)
+		}
+	}
+	if result.Created != 1 || result.Removed != 1 || result.Rejected != 3 {
+		t.Errorf("counts = created %d removed %d rejected %d, want 1/1/3", result.Created, result.Removed, result.Rejected)
+	}
+
+	if len(store.records) != 1 || store.records[0].MemberID != "m1" {
+		t.Fatalf("expected only m1's new attendance to remain, got %+v", store.records)
+	}
+	created := store.records[0]
+	if created.CheckInTime.Hour() != 10 || created.MatHours != 1 || created.LocationID != "central" {
+		t.Errorf("created attendance = %+v, want 10:00 with 1h at central", created)
+	}
+	if len(auditStore.events) != 2 || auditStore.events[0].Action != audit.ActionCreate || auditStore.events[1].Action != audit.ActionDelete {
+		t.Errorf("expected create and delete audit events, got %+v", auditStore.events)
+	}
+
+	// Taking the same roll call again changes nothing.
+	again, err := ExecuteRollCall(context.Background(), RollCallInput{
+		ScheduleID: "s1",
+		ClassDate:  "2026-03-01",
+		Marks:      []RollCallMark{{MemberID: "m1", Present: true}, {MemberID: "m3", Present: false}},
+	}, newRollCallDeps(store, auditStore))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again.Unchanged != 2 || again.Results[0].AttendanceID != created.ID {
+		t.Errorf("repeat roll call = %+v, want both unchanged", again)
+	}
+}
+
+// TestExecuteRollCall_RequestErrors verifies whole-request validation.
+func TestExecuteRollCall_RequestErrors(t *testing.T) {
+	marks := []RollCallMark{{MemberID: "m1", Present: true}}
+	tooMany := make([]RollCallMark, MaxRollCallMarks+1)
+	for i := range tooMany {
+		tooMany[i] = RollCallMark{MemberID: fmt.Sprintf("m%d", i), Present: true}
+	}
+	tests := []struct {
+		name  string
+		input RollCallInput
+		want  error
+	}{
+		{"no schedule", RollCallInput{ClassDate: "2026-03-01", Marks: marks}, ErrRollCallScheduleNeeded},
+		{"no marks", RollCallInput{ScheduleID: "s1", ClassDate: "2026-03-01"}, ErrRollCallEmpty},
+		{"too many", RollCallInput{ScheduleID: "s1", ClassDate: "2026-03-01", Marks: tooMany}, ErrRollCallTooLarge},
+		{"bad date", RollCallInput{ScheduleID: "s1", ClassDate: "01/03/2026", Marks: marks}, ErrRollCallDate},
+		{"future", RollCallInput{ScheduleID: "s1", ClassDate: "2026-03-08", Marks: marks}, ErrRollCallDate},
+		{"too old", RollCallInput{ScheduleID: "s1", ClassDate: "2025-11-02", Marks: marks}, ErrRollCallDate},
+		{"wrong weekday", RollCallInput{ScheduleID: "s1", ClassDate: "2026-02-28", Marks: marks}, ErrRollCallDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExecuteRollCall(context.Background(), tt.input, newRollCallDeps(&mockRollCallAttendanceStore{}, &mockBackfillAuditStore{}))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/notice"
+	"workshop/internal/domain/rotor"
+)
+
+// ErrNoActiveTopic is returned when a theme has no active topic to advance from.
+var ErrNoActiveTopic = errors.New("no active schedule for theme")
+
+// RotorAdvanceStore defines the rotor store interface needed to advance topics.
+type RotorAdvanceStore interface {
+	GetActiveRotor(ctx context.Context, classTypeID string) (rotor.Rotor, error)
+	ListThemesByRotor(ctx context.Context, rotorID string) ([]rotor.RotorTheme, error)
+	GetTopic(ctx context.Context, id string) (rotor.Topic, error)
+	SaveTopic(ctx context.Context, t rotor.Topic) error
+	ListTopicsByTheme(ctx context.Context, rotorThemeID string) ([]rotor.Topic, error)
+	SaveTopicSchedule(ctx context.Context, s rotor.TopicSchedule) error
+	GetActiveScheduleForTheme(ctx context.Context, rotorThemeID string) (rotor.TopicSchedule, error)
+	ListSchedulesByTheme(ctx context.Context, rotorThemeID string) ([]rotor.TopicSchedule, error)
+	CountVotesForTopic(ctx context.Context, topicID string, now time.Time) (int, error)
+	DeleteVotesForTopic(ctx context.Context, topicID string) error
+}
+
+// RotorAdvanceClassTypeStore defines the class type store interface needed to find active rotors.
+type RotorAdvanceClassTypeStore interface {
+	List(ctx context.Context) ([]classtype.ClassType, error)
+}
+
+// RotorAdvanceNoticeStore defines the notice store interface needed to announce new topics.
+type RotorAdvanceNoticeStore interface {
+	Save(ctx context.Context, n notice.Notice) error
+}
+
+// --- Advance Topic ---
+
+// AdvanceTopicInput carries input for the advance orchestrator.
+type AdvanceTopicInput struct {
+	RotorThemeID string
+}
+
+// AdvanceTopicDeps holds dependencies for ExecuteAdvanceTopic.
+type AdvanceTopicDeps struct {
+	RotorStore RotorAdvanceStore
+	GenerateID func() string
+	Now        func() time.Time
+}
+
+// AdvanceTopicResult reports the completed schedule and what started next.
+type AdvanceTopicResult struct {
+	Completed rotor.TopicSchedule
+	Next      *rotor.TopicSchedule // nil when the theme has nothing to run next
+	NextTopic *rotor.Topic
+}
+
+// ExecuteAdvanceTopic completes a theme's active topic and starts the one that follows it.
+// The next topic is chosen by rotor.PlanAdvance, so vote bumps and topics displaced by a bump are honoured.
+// PRE: RotorThemeID is non-empty
+// POST: Active schedule completed and its votes cleared; the next topic (if any) is active; ErrNoActiveTopic if none was active
+func ExecuteAdvanceTopic(ctx context.Context, input AdvanceTopicInput, deps AdvanceTopicDeps) (AdvanceTopicResult, error) {
+	now := deps.Now()
+	active, err := deps.RotorStore.GetActiveScheduleForTheme(ctx, input.RotorThemeID)
+	if err != nil {
+		return AdvanceTopicResult{}, ErrNoActiveTopic
+	}
+
+	active.Status = rotor.ScheduleStatusCompleted
+	if active.EndDate.IsZero() || now.Before(active.EndDate) {
+		active.EndDate = now
+	}
+	if err := deps.RotorStore.SaveTopicSchedule(ctx, active); err != nil {
+		return AdvanceTopicResult{}, err
+	}
+	result := AdvanceTopicResult{Completed: active}
+	if topic, err := deps.RotorStore.GetTopic(ctx, active.TopicID); err == nil {
+		topic.LastCovered = now
+		if err := deps.RotorStore.SaveTopic(ctx, topic); err != nil {
+			return result, err
+		}
+	}
+	if err := deps.RotorStore.DeleteVotesForTopic(ctx, active.TopicID); err != nil {
+		return result, err
+	}
+
+	topics, err := deps.RotorStore.ListTopicsByTheme(ctx, input.RotorThemeID)
+	if err != nil {
+		return result, err
+	}
+	history, err := deps.RotorStore.ListSchedulesByTheme(ctx, input.RotorThemeID)
+	if err != nil {
+		return result, err
+	}
+	votes := make(map[string]int, len(topics))
+	for _, t := range topics {
+		n, err := deps.RotorStore.CountVotesForTopic(ctx, t.ID, now)
+		if err != nil {
+			return result, err
+		}
+		votes[t.ID] = n
+	}
+
+	plan := rotor.PlanAdvance(topics, history, votes, active.TopicID)
+	if plan.Topic == nil {
+		slog.InfoContext(ctx, "rotor_event", "event", "topic_completed", "rotor_theme_id", input.RotorThemeID, "topic_id", active.TopicID)
+		return result, nil
+	}
+	next := rotor.TopicSchedule{ID: deps.GenerateID(), TopicID: plan.Topic.ID, RotorThemeID: input.RotorThemeID, Bumped: plan.Bumped}
+	if plan.Resume != nil {
+		next = *plan.Resume
+	}
+	next.Status = rotor.ScheduleStatusActive
+	next.StartDate = now
+	next.EndDate = now.AddDate(0, 0, plan.Topic.DurationWeeks*7)
+	if err := deps.RotorStore.SaveTopicSchedule(ctx, next); err != nil {
+		return result, err
+	}
+	if plan.Bumped {
+		if err := deps.RotorStore.DeleteVotesForTopic(ctx, plan.Topic.ID); err != nil {
+			return result, err
+		}
+	}
+	result.Next = &next
+	result.NextTopic = plan.Topic
+
+	slog.InfoContext(ctx, "rotor_event", "event", "topic_advanced", "rotor_theme_id", input.RotorThemeID,
+		"completed_topic_id", active.TopicID, "next_topic_id", plan.Topic.ID, "bumped", plan.Bumped, "resumed", plan.Resume != nil)
+	return result, nil
+}
+
+// --- Auto Advance Rotors ---
+
+// AutoAdvanceRotorsDeps holds dependencies for ExecuteAutoAdvanceRotors.
+type AutoAdvanceRotorsDeps struct {
+	ClassTypeStore RotorAdvanceClassTypeStore
+	RotorStore     RotorAdvanceStore
+	NoticeStore    RotorAdvanceNoticeStore // optional: nil skips announcements
+	GenerateID     func() string
+	Now            func() time.Time
+}
+
+// AutoAdvanceRotorsResult summarises one auto-advance run.
+type AutoAdvanceRotorsResult struct {
+	Advanced int // themes whose expired topic was completed
+	Notices  int // announcements published for newly started topics
+}
+
+// ExecuteAutoAdvanceRotors advances every expired topic in active rotors that are in auto mode.
+// Each newly started topic is announced with a published class notice that runs until the topic ends.
+// A failure in one theme does not stop the others.
+// PRE: deps are non-nil except NoticeStore
+// POST: No active auto-mode rotor has an expired active topic; errors are joined
+func ExecuteAutoAdvanceRotors(ctx context.Context, deps AutoAdvanceRotorsDeps) (AutoAdvanceRotorsResult, error) {
+	var result AutoAdvanceRotorsResult
+	classTypes, err := deps.ClassTypeStore.List(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	var errs []error
+	for _, ct := range classTypes {
+		r, err := deps.RotorStore.GetActiveRotor(ctx, ct.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if r.ManualAdvance {
+			continue
+		}
+		themes, err := deps.RotorStore.ListThemesByRotor(ctx, r.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, th := range themes {
+			active, err := deps.RotorStore.GetActiveScheduleForTheme(ctx, th.ID)
+			if err != nil || !active.IsExpired(deps.Now()) {
+				continue
+			}
+			advanced, err := ExecuteAdvanceTopic(ctx, AdvanceTopicInput{RotorThemeID: th.ID}, AdvanceTopicDeps{
+				RotorStore: deps.RotorStore,
+				GenerateID: deps.GenerateID,
+				Now:        deps.Now,
+			})
+			if err != nil {
+				slog.ErrorContext(ctx, "rotor_event", "event", "auto_advance_failed", "rotor_id", r.ID, "rotor_theme_id", th.ID, "error", err)
+				errs = append(errs, err)
+				continue
+			}
+			result.Advanced++
+			if advanced.NextTopic == nil || deps.NoticeStore == nil {
+				continue
+			}
+			if err := deps.NoticeStore.Save(ctx, topicNotice(ct, th, *advanced.NextTopic, *advanced.Next, deps)); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			result.Notices++
+		}
+	}
+
+	if result.Advanced > 0 {
+		slog.InfoContext(ctx, "rotor_event", "event", "rotors_auto_advanced", "themes", result.Advanced, "notices", result.Notices)
+	}
+	return result, errors.Join(errs...)
+}
+
+// topicNotice builds the published class notice announcing a newly started topic.
+func topicNotice(ct classtype.ClassType, th rotor.RotorTheme, topic rotor.Topic, sched rotor.TopicSchedule, deps AutoAdvanceRotorsDeps) notice.Notice {
+	now := deps.Now()
+	content := fmt.Sprintf("%s moves on to **%s** in the %s theme, running until %s.",
+		ct.Name, topic.Name, th.Name, sched.EndDate.Format("Mon 2 Jan"))
+	if topic.Description != "" {
+		content += "\n\n" + topic.Description
+	}
+	title := fmt.Sprintf("New %s topic: %s", th.Name, topic.Name)
+	if len(title) > notice.MaxTitleLength {
+		title = "New topic: " + topic.Name
+	}
+	return notice.Notice{
+		ID:           deps.GenerateID(),
+		Type:         notice.TypeClassSpecific,
+		Status:       notice.StatusPublished,
+		Title:        title,
+		Content:      content,
+		CreatedBy:    "system",
+		PublishedBy:  "system",
+		TargetID:     ct.ID,
+		Color:        notice.ColorBlue,
+		VisibleFrom:  now,
+		VisibleUntil: sched.EndDate,
+		CreatedAt:    now,
+		PublishedAt:  now,
+	}
+}
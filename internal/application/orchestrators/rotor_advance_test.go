@@ -0,0 +1,278 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/rotor"
+)
+
+// memAdvanceRotorStore implements RotorAdvanceStore in memory for testing.
+type memAdvanceRotorStore struct {
+	rotors    map[string]rotor.Rotor // keyed by class type ID
+	themes    []rotor.RotorTheme
+	topics    map[string]rotor.Topic
+	order     []string // topic IDs in insertion order
+	schedules map[string]rotor.TopicSchedule
+	votes     map[string]int
+}
+
+func newMemAdvanceRotorStore() *memAdvanceRotorStore {
+	return &memAdvanceRotorStore{
+		rotors:    make(map[string]rotor.Rotor),
+		topics:    make(map[string]rotor.Topic),
+		schedules: make(map[string]rotor.TopicSchedule),
+		votes:     make(map[string]int),
+	}
+}
+
+// GetActiveRotor implements RotorAdvanceStore.
+// PRE: classTypeID is non-empty
+// POST: returns the rotor or sql.ErrNoRows
+func (m *memAdvanceRotorStore) GetActiveRotor(_ context.Context, classTypeID string) (rotor.Rotor, error) {
+	r, ok := m.rotors[classTypeID]
+	if !ok {
+		return rotor.Rotor{}, sql.ErrNoRows
+	}
+	return r, nil
+}
+
+// ListThemesByRotor implements RotorAdvanceStore.
+// PRE: rotorID is non-empty
+// POST: returns the rotor's themes
+func (m *memAdvanceRotorStore) ListThemesByRotor(_ context.Context, rotorID string) ([]rotor.RotorTheme, error) {
+	var out []rotor.RotorTheme
+	for _, th := range m.themes {
+		if th.RotorID == rotorID {
+			out = append(out, th)
+		}
+	}
+	return out, nil
+}
+
+// GetTopic implements RotorAdvanceStore.
+// PRE: id is non-empty
+// POST: returns the topic or sql.ErrNoRows
+func (m *memAdvanceRotorStore) GetTopic(_ context.Context, id string) (rotor.Topic, error) {
+	t, ok := m.topics[id]
+	if !ok {
+		return rotor.Topic{}, sql.ErrNoRows
+	}
+	return t, nil
+}
+
+// SaveTopic implements RotorAdvanceStore.
+// PRE: t has an ID
+// POST: topic is stored
+func (m *memAdvanceRotorStore) SaveTopic(_ context.Context, t rotor.Topic) error {
+	if _, ok := m.topics[t.ID]; !ok {
+		m.order = append(m.order, t.ID)
+	}
+	m.topics[t.ID] = t
+	return nil
+}
+
+// ListTopicsByTheme implements RotorAdvanceStore.
+// PRE: rotorThemeID is non-empty
+// POST: returns the theme's topics in insertion order
+func (m *memAdvanceRotorStore) ListTopicsByTheme(_ context.Context, rotorThemeID string) ([]rotor.Topic, error) {
+	var out []rotor.Topic
+	for _, id := range m.order {
+		if t := m.topics[id]; t.RotorThemeID == rotorThemeID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// SaveTopicSchedule implements RotorAdvanceStore.
+// PRE: s has an ID
+// POST: schedule is stored
+func (m *memAdvanceRotorStore) SaveTopicSchedule(_ context.Context, s rotor.TopicSchedule) error {
+	m.schedules[s.ID] = s
+	return nil
+}
+
+// GetActiveScheduleForTheme implements RotorAdvanceStore.
+// PRE: rotorThemeID is non-empty
+// POST: returns the active schedule or sql.ErrNoRows
+func (m *memAdvanceRotorStore) GetActiveScheduleForTheme(_ context.Context, rotorThemeID string) (rotor.TopicSchedule, error) {
+	for _, s := range m.schedules {
+		if s.RotorThemeID == rotorThemeID && s.Status == rotor.ScheduleStatusActive {
+			return s, nil
+		}
+	}
+	return rotor.TopicSchedule{}, sql.ErrNoRows
+}
+
+// ListSchedulesByTheme implements RotorAdvanceStore.
+// PRE: rotorThemeID is non-empty
+// POST: returns the theme's schedules
+func (m *memAdvanceRotorStore) ListSchedulesByTheme(_ context.Context, rotorThemeID string) ([]rotor.TopicSchedule, error) {
+	var out []rotor.TopicSchedule
+	for _, s := range m.schedules {
+		if s.RotorThemeID == rotorThemeID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// CountVotesForTopic implements RotorAdvanceStore.
+// PRE: topicID is non-empty
+// POST: returns the vote count
+func (m *memAdvanceRotorStore) CountVotesForTopic(_ context.Context, topicID string, _ time.Time) (int, error) {
+	return m.votes[topicID], nil
+}
+
+// DeleteVotesForTopic implements RotorAdvanceStore.
+// PRE: topicID is non-empty
+// POST: the topic has no votes
+func (m *memAdvanceRotorStore) DeleteVotesForTopic(_ context.Context, topicID string) error {
+	delete(m.votes, topicID)
+	return nil
+}
+
+// memAdvanceClassTypeStore implements RotorAdvanceClassTypeStore for testing.
+type memAdvanceClassTypeStore struct {
+	classTypes []classtype.ClassType
+}
+
+// List implements RotorAdvanceClassTypeStore.
+// PRE: none
+// POST: returns all class types
+func (m *memAdvanceClassTypeStore) List(_ context.Context) ([]classtype.ClassType, error) {
+	return m.classTypes, nil
+}
+
+// seedAdvanceRotor builds a class type with one rotor, one theme and topics a, b and c.
+// Topic a is active and ended the day before fixedTime.
+func seedAdvanceRotor(manual bool) (*memAdvanceRotorStore, *memAdvanceClassTypeStore) {
+	store := newMemAdvanceRotorStore()
+	store.rotors["ct1"] = rotor.Rotor{ID: "r1", ClassTypeID: "ct1", Status: rotor.StatusActive, ManualAdvance: manual}
+	store.themes = []rotor.RotorTheme{{ID: "th1", RotorID: "r1", Name: "Guard"}}
+	for _, id := range []string{"a", "b", "c"} {
+		store.SaveTopic(context.Background(), rotor.Topic{ID: id, RotorThemeID: "th1", Name: "Topic " + id, DurationWeeks: 1})
+	}
+	store.schedules["s1"] = rotor.TopicSchedule{
+		ID: "s1", TopicID: "a", RotorThemeID: "th1", Status: rotor.ScheduleStatusActive,
+		StartDate: fixedTime.AddDate(0, 0, -8), EndDate: fixedTime.AddDate(0, 0, -1),
+	}
+	return store, &memAdvanceClassTypeStore{classTypes: []classtype.ClassType{{ID: "ct1", Name: "Fundamentals"}}}
+}
+
+// TestExecuteAutoAdvanceRotors_AdvancesExpiredTopic tests that an expired topic is completed and the next started.
+func TestExecuteAutoAdvanceRotors_AdvancesExpiredTopic(t *testing.T) {
+	store, ctStore := seedAdvanceRotor(false)
+	notices := newMockNoticeStore()
+	result, err := ExecuteAutoAdvanceRotors(context.Background(), AutoAdvanceRotorsDeps{
+		ClassTypeStore: ctStore,
+		RotorStore:     store,
+		NoticeStore:    notices,
+		GenerateID:     sequentialIDs(),
+		Now:            fixedNow,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Advanced != 1 || result.Notices != 1 {
+		t.Fatalf("got %+v, want 1 advanced and 1 notice", result)
+	}
+	if got := store.schedules["s1"]; got.Status != rotor.ScheduleStatusCompleted {
+		t.Errorf("expected s1 completed, got %s", got.Status)
+	}
+	if !store.topics["a"].LastCovered.Equal(fixedTime) {
+		t.Errorf("expected topic a LastCovered set to now, got %v", store.topics["a"].LastCovered)
+	}
+	active, err := store.GetActiveScheduleForTheme(context.Background(), "th1")
+	if err != nil {
+		t.Fatalf("expected an active schedule: %v", err)
+	}
+	if active.TopicID != "b" {
+		t.Errorf("expected topic b active, got %s", active.TopicID)
+	}
+	if want := fixedTime.AddDate(0, 0, 7); !active.EndDate.Equal(want) {
+		t.Errorf("expected end date %v, got %v", want, active.EndDate)
+	}
+	for _, n := range notices.notices {
+		if n.TargetID != "ct1" || n.Title != "New Guard topic: Topic b" || !n.VisibleUntil.Equal(active.EndDate) {
+			t.Errorf("unexpected notice: %+v", n)
+		}
+	}
+}
+
+// TestExecuteAutoAdvanceRotors_SkipsManualRotor tests that manual-mode rotors are left for a coach.
+func TestExecuteAutoAdvanceRotors_SkipsManualRotor(t *testing.T) {
+	store, ctStore := seedAdvanceRotor(true)
+	result, err := ExecuteAutoAdvanceRotors(context.Background(), AutoAdvanceRotorsDeps{
+		ClassTypeStore: ctStore,
+		RotorStore:     store,
+		GenerateID:     sequentialIDs(),
+		Now:            fixedNow,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Advanced != 0 {
+		t.Errorf("expected nothing advanced, got %d", result.Advanced)
+	}
+	if store.schedules["s1"].Status != rotor.ScheduleStatusActive {
+		t.Errorf("expected s1 still active")
+	}
+}
+
+// TestExecuteAutoAdvanceRotors_LeavesRunningTopic tests that a topic before its end date is untouched.
+func TestExecuteAutoAdvanceRotors_LeavesRunningTopic(t *testing.T) {
+	store, ctStore := seedAdvanceRotor(false)
+	s := store.schedules["s1"]
+	s.EndDate = fixedTime.AddDate(0, 0, 3)
+	store.schedules["s1"] = s
+	result, err := ExecuteAutoAdvanceRotors(context.Background(), AutoAdvanceRotorsDeps{
+		ClassTypeStore: ctStore,
+		RotorStore:     store,
+		GenerateID:     sequentialIDs(),
+		Now:            fixedNow,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Advanced != 0 || len(store.schedules) != 1 {
+		t.Errorf("expected no change, got %+v with %d schedules", result, len(store.schedules))
+	}
+}
+
+// TestExecuteAdvanceTopic_VoteBump tests that a topic with enough votes jumps the queue.
+func TestExecuteAdvanceTopic_VoteBump(t *testing.T) {
+	store, _ := seedAdvanceRotor(false)
+	store.votes["c"] = rotor.MinVotesForAutoBump
+	result, err := ExecuteAdvanceTopic(context.Background(), AdvanceTopicInput{RotorThemeID: "th1"}, AdvanceTopicDeps{
+		RotorStore: store,
+		GenerateID: sequentialIDs(),
+		Now:        fixedNow,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Next == nil || result.Next.TopicID != "c" || !result.Next.Bumped {
+		t.Fatalf("expected bumped topic c, got %+v", result.Next)
+	}
+	if store.votes["c"] != 0 {
+		t.Errorf("expected votes for c cleared, got %d", store.votes["c"])
+	}
+}
+
+// TestExecuteAdvanceTopic_NoActive tests that a theme without an active topic reports ErrNoActiveTopic.
+func TestExecuteAdvanceTopic_NoActive(t *testing.T) {
+	store := newMemAdvanceRotorStore()
+	_, err := ExecuteAdvanceTopic(context.Background(), AdvanceTopicInput{RotorThemeID: "th1"}, AdvanceTopicDeps{
+		RotorStore: store,
+		GenerateID: sequentialIDs(),
+		Now:        func() time.Time { return fixedTime },
+	})
+	if err != ErrNoActiveTopic {
+		t.Errorf("expected ErrNoActiveTopic, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/rubric"
+)
+
+// RubricTemplateStore defines the rubric template store interface needed by rubric orchestrators.
+type RubricTemplateStore interface {
+	GetByID(ctx context.Context, id string) (rubric.Template, error)
+	Save(ctx context.Context, value rubric.Template) error
+}
+
+// RubricScoreStore defines the rubric score store interface needed by rubric orchestrators.
+type RubricScoreStore interface {
+	SaveAll(ctx context.Context, values []rubric.Score) error
+}
+
+// RubricScoresInput carries the structured scores a coach filled in alongside a note.
+type RubricScoresInput struct {
+	TemplateID string         // empty when the note is freeform only
+	Scores     map[string]int // criterion key -> value
+}
+
+// errRubricUnavailable is returned when scores are submitted but no rubric stores are wired.
+var errRubricUnavailable = errors.New("rubric scoring is not available")
+
+// prepareRubricScores validates the submitted scores against their template before anything is saved.
+// Returns nil scores when input names no template.
+func prepareRubricScores(ctx context.Context, input RubricScoresInput, base rubric.Score, templates RubricTemplateStore, generateID func() string) ([]rubric.Score, error) {
+	if input.TemplateID == "" {
+		if len(input.Scores) > 0 {
+			return nil, errors.New("scores require a rubric template")
+		}
+		return nil, nil
+	}
+	if templates == nil {
+		return nil, errRubricUnavailable
+	}
+	tmpl, err := templates.GetByID(ctx, input.TemplateID)
+	if err != nil {
+		return nil, errors.New("rubric template not found")
+	}
+	return tmpl.NewScores(input.Scores, base, generateID)
+}
+
+// --- Save Rubric Template ---
+
+// SaveRubricTemplateInput carries input for the save rubric template orchestrator.
+type SaveRubricTemplateInput struct {
+	ID        string // empty creates a new template
+	Name      string
+	Criteria  []rubric.Criterion // criteria without a Key are new and get one generated
+	Archived  bool
+	CreatedBy string // AccountID; ignored when updating
+}
+
+// SaveRubricTemplateDeps holds dependencies for SaveRubricTemplate.
+type SaveRubricTemplateDeps struct {
+	TemplateStore RubricTemplateStore
+	GenerateID    func() string
+	Now           func() time.Time
+}
+
+// ExecuteSaveRubricTemplate creates or updates a rubric template.
+// Existing criteria keep their keys, so scores already recorded against them stay linked after a relabel.
+// PRE: input.CreatedBy is set when creating; input.ID names an existing template when updating
+// POST: Template is validated and persisted
+func ExecuteSaveRubricTemplate(ctx context.Context, input SaveRubricTemplateInput, deps SaveRubricTemplateDeps) (rubric.Template, error) {
+	tmpl := rubric.Template{ID: input.ID, CreatedBy: input.CreatedBy, CreatedAt: deps.Now()}
+	if input.ID != "" {
+		existing, err := deps.TemplateStore.GetByID(ctx, input.ID)
+		if err != nil {
+			return rubric.Template{}, err
+		}
+		tmpl = existing
+	} else {
+		tmpl.ID = deps.GenerateID()
+	}
+	tmpl.Name = input.Name
+	tmpl.Archived = input.Archived
+	tmpl.Criteria = make([]rubric.Criterion, len(input.Criteria))
+	for i, c := range input.Criteria {
+		if c.Key == "" {
+			c.Key = deps.GenerateID()
+		}
+		tmpl.Criteria[i] = c
+	}
+
+	if err := tmpl.Validate(); err != nil {
+		return rubric.Template{}, err
+	}
+	if err := deps.TemplateStore.Save(ctx, tmpl); err != nil {
+		return rubric.Template{}, err
+	}
+
+	slog.InfoContext(ctx, "rubric_event", "event", "rubric_template_saved", "template_id", tmpl.ID, "archived", tmpl.Archived)
+	return tmpl, nil
+}
+
+// --- Create Grading Note ---
+
+// GradingNoteStoreForOrchestrator defines the grading note store interface needed by CreateGradingNote.
+type GradingNoteStoreForOrchestrator interface {
+	Save(ctx context.Context, value grading.Note) error
+}
+
+// CreateGradingNoteInput carries input for the create grading note orchestrator.
+type CreateGradingNoteInput struct {
+	MemberID   string
+	Content    string
+	Visibility string // optional: defaults to coaches-only
+	AuthorID   string // AccountID of the coach or admin writing the note
+	Rubric     RubricScoresInput
+}
+
+// CreateGradingNoteDeps holds dependencies for CreateGradingNote.
+type CreateGradingNoteDeps struct {
+	NoteStore           GradingNoteStoreForOrchestrator
+	RubricTemplateStore RubricTemplateStore // optional: nil rejects scored notes
+	RubricScoreStore    RubricScoreStore    // optional: nil rejects scored notes
+	GenerateID          func() string
+	Now                 func() time.Time
+}
+
+// ExecuteCreateGradingNote records a coach's grading note, with optional rubric scores.
+// PRE: AuthorID is set; Rubric.Scores match Rubric.TemplateID's criteria when given
+// POST: Note persisted, then its scores; nothing is saved when validation fails
+func ExecuteCreateGradingNote(ctx context.Context, input CreateGradingNoteInput, deps CreateGradingNoteDeps) (grading.Note, error) {
+	note := grading.Note{
+		ID:         deps.GenerateID(),
+		MemberID:   input.MemberID,
+		Content:    input.Content,
+		Visibility: input.Visibility,
+		CreatedBy:  input.AuthorID,
+		CreatedAt:  deps.Now(),
+	}
+	if note.Visibility == "" {
+		note.Visibility = grading.NoteVisibilityCoaches
+	}
+	if err := note.Validate(); err != nil {
+		return grading.Note{}, err
+	}
+	scores, err := prepareRubricScores(ctx, input.Rubric, rubric.Score{
+		MemberID:  note.MemberID,
+		Source:    rubric.SourceGradingNote,
+		SourceID:  note.ID,
+		AuthorID:  note.CreatedBy,
+		CreatedAt: note.CreatedAt,
+	}, deps.RubricTemplateStore, deps.GenerateID)
+	if err != nil {
+		return grading.Note{}, err
+	}
+	if scores != nil && deps.RubricScoreStore == nil {
+		return grading.Note{}, errRubricUnavailable
+	}
+
+	if err := deps.NoteStore.Save(ctx, note); err != nil {
+		return grading.Note{}, err
+	}
+	if scores != nil {
+		if err := deps.RubricScoreStore.SaveAll(ctx, scores); err != nil {
+			return grading.Note{}, err
+		}
+	}
+	slog.InfoContext(ctx, "grading_event", "event", "grading_note_created", "note_id", note.ID, "member_id", note.MemberID, "visibility", note.Visibility, "rubric_scores", len(scores))
+	return note, nil
+}
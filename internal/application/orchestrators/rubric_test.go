@@ -0,0 +1,175 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/rubric"
+)
+
+// mockRubricTemplateStore implements RubricTemplateStore for testing.
+type mockRubricTemplateStore struct {
+	templates map[string]rubric.Template
+}
+
+// GetByID implements RubricTemplateStore.
+// PRE: id is non-empty
+// POST: returns the template or sql.ErrNoRows
+func (m *mockRubricTemplateStore) GetByID(_ context.Context, id string) (rubric.Template, error) {
+	t, ok := m.templates[id]
+	if !ok {
+		return rubric.Template{}, sql.ErrNoRows
+	}
+	return t, nil
+}
+
+// Save implements RubricTemplateStore.
+// PRE: template is valid
+// POST: template is stored by ID
+func (m *mockRubricTemplateStore) Save(_ context.Context, t rubric.Template) error {
+	m.templates[t.ID] = t
+	return nil
+}
+
+// mockRubricScoreStore implements RubricScoreStore for testing.
+type mockRubricScoreStore struct {
+	scores []rubric.Score
+}
+
+// SaveAll implements RubricScoreStore.
+// PRE: scores are valid
+// POST: scores are appended
+func (m *mockRubricScoreStore) SaveAll(_ context.Context, values []rubric.Score) error {
+	m.scores = append(m.scores, values...)
+	return nil
+}
+
+// mockGradingNoteStore implements GradingNoteStoreForOrchestrator for testing.
+type mockGradingNoteStore struct {
+	notes []grading.Note
+}
+
+// Save implements GradingNoteStoreForOrchestrator.
+// PRE: note is valid
+// POST: note is appended
+func (m *mockGradingNoteStore) Save(_ context.Context, n grading.Note) error {
+	m.notes = append(m.notes, n)
+	return nil
+}
+
+func guardRubricStore() *mockRubricTemplateStore {
+	return &mockRubricTemplateStore{templates: map[string]rubric.Template{"guard": {
+		ID:   "guard",
+		Name: "Guard game",
+		Criteria: []rubric.Criterion{
+			{Key: "retention", Label: "Guard retention", Min: 1, Max: 5},
+			{Key: "escapes", Label: "Escapes", Min: 1, Max: 5},
+		},
+	}}}
+}
+
+// TestExecuteSaveRubricTemplate verifies new criteria get keys and existing keys survive an edit.
+func TestExecuteSaveRubricTemplate(t *testing.T) {
+	store := &mockRubricTemplateStore{templates: make(map[string]rubric.Template)}
+	deps := SaveRubricTemplateDeps{TemplateStore: store, GenerateID: sequentialIDs(), Now: fixedNow}
+
+	created, err := ExecuteSaveRubricTemplate(context.Background(), SaveRubricTemplateInput{
+		Name:      "Guard game",
+		Criteria:  []rubric.Criterion{{Label: "Guard retention", Min: 1, Max: 5}},
+		CreatedBy: "admin-1",
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.ID == "" || created.Criteria[0].Key == "" || created.CreatedBy != "admin-1" {
+		t.Fatalf("created = %+v, want generated ID and criterion key", created)
+	}
+	key := created.Criteria[0].Key
+
+	updated, err := ExecuteSaveRubricTemplate(context.Background(), SaveRubricTemplateInput{
+		ID:   created.ID,
+		Name: "Guard game",
+		Criteria: []rubric.Criterion{
+			{Key: key, Label: "Retention", Min: 1, Max: 5},
+			{Label: "Escapes", Min: 1, Max: 5},
+		},
+		CreatedBy: "someone-else",
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Criteria[0].Key != key || updated.Criteria[1].Key == "" || updated.CreatedBy != "admin-1" {
+		t.Errorf("updated = %+v, want key %q kept, a new key added and the creator unchanged", updated, key)
+	}
+
+	if _, err := ExecuteSaveRubricTemplate(context.Background(), SaveRubricTemplateInput{Name: "Empty"}, deps); err != rubric.ErrNoCriteria {
+		t.Errorf("no criteria: err = %v, want ErrNoCriteria", err)
+	}
+}
+
+// TestExecuteCreateGradingNote_WithRubric verifies a scored note saves the note and one row per criterion.
+func TestExecuteCreateGradingNote_WithRubric(t *testing.T) {
+	notes := &mockGradingNoteStore{}
+	scores := &mockRubricScoreStore{}
+	deps := CreateGradingNoteDeps{
+		NoteStore:           notes,
+		RubricTemplateStore: guardRubricStore(),
+		RubricScoreStore:    scores,
+		GenerateID:          sequentialIDs(),
+		Now:                 fixedNow,
+	}
+
+	note, err := ExecuteCreateGradingNote(context.Background(), CreateGradingNoteInput{
+		MemberID: "m1",
+		Content:  "Retention much improved",
+		AuthorID: "coach-1",
+		Rubric:   RubricScoresInput{TemplateID: "guard", Scores: map[string]int{"retention": 4, "escapes": 3}},
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notes.notes) != 1 || len(scores.scores) != 2 {
+		t.Fatalf("saved %d notes and %d scores, want 1 and 2", len(notes.notes), len(scores.scores))
+	}
+	if s := scores.scores[0]; s.Source != rubric.SourceGradingNote || s.SourceID != note.ID || s.MemberID != "m1" || s.AuthorID != "coach-1" {
+		t.Errorf("score = %+v, want it linked to note %s", s, note.ID)
+	}
+}
+
+// TestExecuteCreateObservation_InvalidRubric verifies a bad score saves neither the observation nor any scores.
+func TestExecuteCreateObservation_InvalidRubric(t *testing.T) {
+	observations := newMockObservationStore()
+	scores := &mockRubricScoreStore{}
+	deps := CreateObservationDeps{
+		ObservationStore:    observations,
+		RubricTemplateStore: guardRubricStore(),
+		RubricScoreStore:    scores,
+		GenerateID:          sequentialIDs(),
+		Now:                 fixedNow,
+	}
+	input := CreateObservationInput{MemberID: "m1", Content: "Rolled well", AuthorID: "coach-1"}
+
+	for name, r := range map[string]RubricScoresInput{
+		"out of range":     {TemplateID: "guard", Scores: map[string]int{"escapes": 9}},
+		"unknown template": {TemplateID: "nope", Scores: map[string]int{"escapes": 3}},
+		"no template":      {Scores: map[string]int{"escapes": 3}},
+	} {
+		input.Rubric = r
+		if _, err := ExecuteCreateObservation(context.Background(), input, deps); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if len(observations.observations) != 0 || len(scores.scores) != 0 {
+		t.Errorf("saved %d observations and %d scores, want none", len(observations.observations), len(scores.scores))
+	}
+
+	input.Rubric = RubricScoresInput{TemplateID: "guard", Scores: map[string]int{"escapes": 2}}
+	if _, err := ExecuteCreateObservation(context.Background(), input, deps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scores.scores) != 1 || scores.scores[0].Source != rubric.SourceObservation {
+		t.Errorf("scores = %+v, want one observation score", scores.scores)
+	}
+}
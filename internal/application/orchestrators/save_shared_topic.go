@@ -0,0 +1,87 @@
+package orchestrators
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"workshop/internal/domain/rotor"
+)
+
+// SaveSharedTopicStore defines the rotor store interface needed to maintain the shared topic library.
+type SaveSharedTopicStore interface {
+	GetSharedTopic(ctx context.Context, id string) (rotor.SharedTopic, error)
+	SaveSharedTopic(ctx context.Context, st rotor.SharedTopic) error
+	GetTopic(ctx context.Context, id string) (rotor.Topic, error)
+	SaveTopic(ctx context.Context, t rotor.Topic) error
+}
+
+// SaveSharedTopicInput carries input for the save shared topic orchestrator.
+type SaveSharedTopicInput struct {
+	ID            string // empty adds a new library topic
+	Name          string // empty keeps the current name
+	Description   string // replaces the current description, unless FromTopicID supplies it
+	DurationWeeks int    // 0 keeps the current value, or 1 for a new topic
+	FromTopicID   string // optional: a class topic to share; fills blank fields and is linked
+	SavedBy       string
+}
+
+// SaveSharedTopicDeps holds dependencies for SaveSharedTopic.
+type SaveSharedTopicDeps struct {
+	RotorStore SaveSharedTopicStore
+	GenerateID func() string
+	Now        func() time.Time
+}
+
+// ExecuteSaveSharedTopic adds or edits a library topic. Saving copies its name and
+// description to every class topic linked to it, so one edit updates all classes.
+// PRE: ID, if set, references an existing library topic; FromTopicID, if set, an existing topic
+// POST: The library topic is persisted and linked topics follow it
+func ExecuteSaveSharedTopic(ctx context.Context, input SaveSharedTopicInput, deps SaveSharedTopicDeps) (rotor.SharedTopic, error) {
+	st := rotor.SharedTopic{ID: input.ID, CreatedBy: input.SavedBy, DurationWeeks: 1}
+	if input.ID != "" {
+		existing, err := deps.RotorStore.GetSharedTopic(ctx, input.ID)
+		if err != nil {
+			return rotor.SharedTopic{}, err
+		}
+		st = existing
+	} else {
+		st.ID = deps.GenerateID()
+	}
+
+	var from rotor.Topic
+	if input.FromTopicID != "" {
+		topic, err := deps.RotorStore.GetTopic(ctx, input.FromTopicID)
+		if err != nil {
+			return rotor.SharedTopic{}, err
+		}
+		from = topic
+		st.Name, st.Description, st.DurationWeeks = topic.Name, topic.Description, topic.DurationWeeks
+	}
+	if input.Name != "" {
+		st.Name = input.Name
+	}
+	if input.Description != "" || input.FromTopicID == "" {
+		st.Description = input.Description
+	}
+	if input.DurationWeeks != 0 {
+		st.DurationWeeks = input.DurationWeeks
+	}
+	st.UpdatedAt = deps.Now()
+	if err := st.Validate(); err != nil {
+		return rotor.SharedTopic{}, err
+	}
+	if err := deps.RotorStore.SaveSharedTopic(ctx, st); err != nil {
+		return rotor.SharedTopic{}, err
+	}
+
+	if from.ID != "" && from.SharedTopicID != st.ID {
+		from.Link(st)
+		if err := deps.RotorStore.SaveTopic(ctx, from); err != nil {
+			return st, err
+		}
+	}
+
+	slog.InfoContext(ctx, "rotor_event", "event", "shared_topic_saved", "shared_topic_id", st.ID, "from_topic_id", input.FromTopicID, "by", input.SavedBy)
+	return st, nil
+}
@@ -0,0 +1,110 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"workshop/internal/domain/rotor"
+)
+
+type mockSharedTopicStore struct {
+	shared map[string]rotor.SharedTopic
+	topics map[string]rotor.Topic
+}
+
+// GetSharedTopic implements SaveSharedTopicStore.
+// PRE: none
+// POST: returns the library topic or an error
+func (m *mockSharedTopicStore) GetSharedTopic(_ context.Context, id string) (rotor.SharedTopic, error) {
+	st, ok := m.shared[id]
+	if !ok {
+		return rotor.SharedTopic{}, errors.New("not found")
+	}
+	return st, nil
+}
+
+// SaveSharedTopic implements SaveSharedTopicStore, copying the wording to linked topics like the SQLite store.
+// PRE: none
+// POST: the library topic is stored and linked topics follow it
+func (m *mockSharedTopicStore) SaveSharedTopic(_ context.Context, st rotor.SharedTopic) error {
+	m.shared[st.ID] = st
+	for id, t := range m.topics {
+		if t.SharedTopicID == st.ID {
+			t.Name, t.Description = st.Name, st.Description
+			m.topics[id] = t
+		}
+	}
+	return nil
+}
+
+// GetTopic implements SaveSharedTopicStore.
+// PRE: none
+// POST: returns the topic or an error
+func (m *mockSharedTopicStore) GetTopic(_ context.Context, id string) (rotor.Topic, error) {
+	t, ok := m.topics[id]
+	if !ok {
+		return rotor.Topic{}, errors.New("not found")
+	}
+	return t, nil
+}
+
+// SaveTopic implements SaveSharedTopicStore.
+// PRE: none
+// POST: the topic is stored
+func (m *mockSharedTopicStore) SaveTopic(_ context.Context, t rotor.Topic) error {
+	m.topics[t.ID] = t
+	return nil
+}
+
+// TestExecuteSaveSharedTopic_ShareThenEdit verifies sharing a class topic links it, and
+// editing the library entry rewrites every linked topic.
+func TestExecuteSaveSharedTopic_ShareThenEdit(t *testing.T) {
+	store := &mockSharedTopicStore{
+		shared: map[string]rotor.SharedTopic{},
+		topics: map[string]rotor.Topic{
+			"gi-dl":   {ID: "gi-dl", RotorThemeID: "gi", Name: "Double Leg", Description: "Level change", DurationWeeks: 2},
+			"nogi-dl": {ID: "nogi-dl", RotorThemeID: "nogi", Name: "Double leg", DurationWeeks: 1},
+		},
+	}
+	deps := SaveSharedTopicDeps{RotorStore: store, GenerateID: func() string { return "st1" }, Now: fixedNow}
+
+	st, err := ExecuteSaveSharedTopic(context.Background(), SaveSharedTopicInput{FromTopicID: "gi-dl", SavedBy: "coach-1"}, deps)
+	if err != nil {
+		t.Fatalf("share: unexpected error: %v", err)
+	}
+	if st.ID != "st1" || st.Name != "Double Leg" || st.Description != "Level change" || st.DurationWeeks != 2 || st.CreatedBy != "coach-1" {
+		t.Errorf("unexpected library topic: %+v", st)
+	}
+	if store.topics["gi-dl"].SharedTopicID != "st1" {
+		t.Error("expected the source topic to be linked")
+	}
+
+	// Another class links to it, then the description is edited once.
+	other := store.topics["nogi-dl"]
+	other.Link(st)
+	store.topics["nogi-dl"] = other
+	if _, err := ExecuteSaveSharedTopic(context.Background(), SaveSharedTopicInput{ID: "st1", Description: "Level change, then drive through"}, deps); err != nil {
+		t.Fatalf("edit: unexpected error: %v", err)
+	}
+	for _, id := range []string{"gi-dl", "nogi-dl"} {
+		if got := store.topics[id]; got.Description != "Level change, then drive through" || got.Name != "Double Leg" {
+			t.Errorf("%s not updated: %+v", id, got)
+		}
+	}
+	if store.topics["nogi-dl"].DurationWeeks != 1 {
+		t.Error("expected each class to keep its own duration")
+	}
+}
+
+// TestExecuteSaveSharedTopic_Validation verifies an unnamed library topic is refused.
+func TestExecuteSaveSharedTopic_Validation(t *testing.T) {
+	store := &mockSharedTopicStore{shared: map[string]rotor.SharedTopic{}, topics: map[string]rotor.Topic{}}
+	deps := SaveSharedTopicDeps{RotorStore: store, GenerateID: func() string { return "st1" }, Now: fixedNow}
+	if _, err := ExecuteSaveSharedTopic(context.Background(), SaveSharedTopicInput{Description: "no name"}, deps); !errors.Is(err, rotor.ErrEmptyTopicName) {
+		t.Errorf("expected ErrEmptyTopicName, got %v", err)
+	}
+	if len(store.shared) != 0 {
+		t.Error("expected nothing saved")
+	}
+}
@@ -0,0 +1,76 @@
+package orchestrators
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"workshop/internal/domain/clip"
+)
+
+// ClipTagStoreForSeed defines the store interface needed by SeedClipTaxonomy.
+type ClipTagStoreForSeed interface {
+	SaveTag(ctx context.Context, tag clip.Tag) error
+	ListTags(ctx context.Context) ([]clip.Tag, error)
+}
+
+// SeedClipTaxonomyDeps holds dependencies for SeedClipTaxonomy.
+type SeedClipTaxonomyDeps struct {
+	TagStore ClipTagStoreForSeed
+}
+
+// DefaultClipTaxonomy is the starting set of library tags, keyed by category.
+var DefaultClipTaxonomy = map[string][]string{
+	clip.CategoryPosition: {
+		"Closed Guard", "Open Guard", "Half Guard", "Butterfly Guard", "De La Riva", "Mount",
+		"Side Control", "Back", "North-South", "Turtle", "Knee on Belly", "Standing",
+	},
+	clip.CategoryTechnique: {
+		"Sweep", "Submission", "Escape", "Guard Pass", "Takedown", "Guard Retention", "Transition", "Drill",
+	},
+	clip.CategoryAttire: {"Gi", "Nogi"},
+}
+
+// ExecuteSeedClipTaxonomy creates the default position, technique and attire tags.
+// Tags are matched by name, case-insensitively; an existing general tag with a taxonomy
+// name is moved into its category so older libraries join the taxonomy.
+// PRE: deps.TagStore is non-nil
+// POST: every DefaultClipTaxonomy name exists as a tag in its category
+func ExecuteSeedClipTaxonomy(ctx context.Context, deps SeedClipTaxonomyDeps) error {
+	existing, err := deps.TagStore.ListTags(ctx)
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]clip.Tag)
+	for _, t := range existing {
+		byName[strings.ToLower(t.Name)] = t
+	}
+
+	var seeded, moved int
+	for _, category := range clip.Categories {
+		for _, name := range DefaultClipTaxonomy[category] {
+			tag, ok := byName[strings.ToLower(name)]
+			switch {
+			case !ok:
+				tag = clip.Tag{ID: uuid.New().String(), Name: name, Category: category, CreatedBy: "system", CreatedAt: time.Now()}
+				seeded++
+			case tag.Category == clip.CategoryGeneral || tag.Category == "":
+				tag.Category = category
+				moved++
+			default:
+				continue // Already categorised; leave the admin's choice alone
+			}
+			if err := deps.TagStore.SaveTag(ctx, tag); err != nil {
+				return err
+			}
+		}
+	}
+
+	if seeded > 0 || moved > 0 {
+		slog.InfoContext(ctx, "seed_event", "event", "clip_taxonomy_seeded", "created", seeded, "categorised", moved)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package orchestrators
+
+import (
+	"context"
+	"testing"
+
+	"workshop/internal/domain/clip"
+)
+
+type memClipTagStore struct {
+	tags map[string]clip.Tag
+}
+
+// SaveTag persists a tag in memory.
+// PRE: tag has a non-empty ID
+// POST: tag is stored, replacing any tag with the same ID
+func (s *memClipTagStore) SaveTag(_ context.Context, tag clip.Tag) error {
+	s.tags[tag.ID] = tag
+	return nil
+}
+
+// ListTags returns all tags in memory.
+// PRE: none
+// POST: returns every stored tag
+func (s *memClipTagStore) ListTags(_ context.Context) ([]clip.Tag, error) {
+	var list []clip.Tag
+	for _, t := range s.tags {
+		list = append(list, t)
+	}
+	return list, nil
+}
+
+// TestExecuteSeedClipTaxonomy_Idempotent verifies defaults are created once and legacy tags are categorised.
+func TestExecuteSeedClipTaxonomy_Idempotent(t *testing.T) {
+	store := &memClipTagStore{tags: map[string]clip.Tag{
+		"legacy": {ID: "legacy", Name: "half guard", Category: clip.CategoryGeneral},
+		"custom": {ID: "custom", Name: "Mount", Category: clip.CategoryTechnique},
+	}}
+	deps := SeedClipTaxonomyDeps{TagStore: store}
+
+	if err := ExecuteSeedClipTaxonomy(context.Background(), deps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := 0
+	for _, names := range DefaultClipTaxonomy {
+		want += len(names)
+	}
+	if len(store.tags) != want {
+		t.Fatalf("expected %d tags, got %d", want, len(store.tags))
+	}
+	if got := store.tags["legacy"].Category; got != clip.CategoryPosition {
+		t.Errorf("legacy half guard category = %q, want position", got)
+	}
+	if got := store.tags["custom"].Category; got != clip.CategoryTechnique {
+		t.Errorf("admin-categorised Mount changed to %q", got)
+	}
+
+	if err := ExecuteSeedClipTaxonomy(context.Background(), deps); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if len(store.tags) != want {
+		t.Errorf("second run created duplicates: %d tags", len(store.tags))
+	}
+}
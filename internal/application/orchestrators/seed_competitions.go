@@ -122,7 +122,7 @@ func ExecuteSeedCompetitions(ctx context.Context, deps SeedCompetitionsDeps) err
 	}
 
 	if seeded > 0 {
-		slog.Info("seed_event", "event", "competitions_seeded", "count", seeded)
+		slog.InfoContext(ctx, "seed_event", "event", "competitions_seeded", "count", seeded)
 	}
 	return nil
 }
@@ -69,6 +69,6 @@ func ExecuteSeedPrograms(ctx context.Context, deps SeedProgramsDeps) error {
 		}
 	}
 
-	slog.Info("seed_event", "event", "programs_seeded", "programs", len(programs), "class_types", len(classTypes))
+	slog.InfoContext(ctx, "seed_event", "event", "programs_seeded", "programs", len(programs), "class_types", len(classTypes))
 	return nil
 }
@@ -144,7 +144,7 @@ func ExecuteSeedSynthetic(ctx context.Context, deps SyntheticSeedDeps, adminAcco
 	}
 
 	if len(existing) > 5 {
-		slog.Info("seed_event", "event", "synthetic_skip", "reason", "already_seeded")
+		slog.InfoContext(ctx, "seed_event", "event", "synthetic_skip", "reason", "already_seeded")
 		return nil
 	}
 
@@ -165,7 +165,7 @@ func ExecuteSeedSynthetic(ctx context.Context, deps SyntheticSeedDeps, adminAcco
 			return fmt.Errorf("seed coach account: %w", err)
 		}
 		coachAccountID = coachAcct.ID
-		slog.Info("seed_event", "event", "coach_account_created", "email", "coach@workshop.co.nz")
+		slog.InfoContext(ctx, "seed_event", "event", "coach_account_created", "email", "coach@workshop.co.nz")
 	} else {
 		coachAccountID = existingCoach.ID
 	}
@@ -185,7 +185,7 @@ func ExecuteSeedSynthetic(ctx context.Context, deps SyntheticSeedDeps, adminAcco
 		if err := deps.AccountStore.Save(ctx, memberAcct); err != nil {
 			return fmt.Errorf("seed member account: %w", err)
 		}
-		slog.Info("seed_event", "event", "member_account_created", "email", "marcus@email.com")
+		slog.InfoContext(ctx, "seed_event", "event", "member_account_created", "email", "marcus@email.com")
 	}
 
 	// --- Class types (already seeded, fetch IDs) ---
@@ -644,7 +644,7 @@ func ExecuteSeedSynthetic(ctx context.Context, deps SyntheticSeedDeps, adminAcco
 		}
 	}
 
-	slog.Info("seed_event", "event", "synthetic_seeded",
+	slog.InfoContext(ctx, "seed_event", "event", "synthetic_seeded",
 		"members", len(roster),
 		"attendance_records", "~800",
 		"observations", len(obs),
@@ -746,6 +746,6 @@ func seedThemesAndClips(ctx context.Context, deps SyntheticSeedDeps, now time.Ti
 			return fmt.Errorf("seed clip: %w", err)
 		}
 	}
-	slog.Info("seed_event", "event", "themes_clips_seeded", "themes", len(themeData), "clips", len(clipData))
+	slog.InfoContext(ctx, "seed_event", "event", "themes_clips_seeded", "themes", len(themeData), "clips", len(clipData))
 	return nil
 }
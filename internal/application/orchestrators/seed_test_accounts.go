@@ -105,11 +105,11 @@ func ExecuteSeedTestAccounts(ctx context.Context, deps TestAccountSeedDeps) erro
 		}
 
 		created++
-		slog.Info("seed_event", "event", "test_account_created", "email", def.Email, "role", def.Role)
+		slog.InfoContext(ctx, "seed_event", "event", "test_account_created", "email", def.Email, "role", def.Role)
 	}
 
 	if created > 0 {
-		slog.Info("seed_event", "event", "test_accounts_seeded", "created", created)
+		slog.InfoContext(ctx, "seed_event", "event", "test_accounts_seeded", "created", created)
 	}
 	return nil
 }
@@ -2,6 +2,7 @@ package orchestrators
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"time"
 
@@ -14,6 +15,28 @@ type EstimatedHoursStoreForSelfEstimate interface {
 	GetByID(ctx context.Context, id string) (domain.EstimatedHours, error)
 }
 
+// SelfEstimateReviewLog records the steps of a self-estimate's review.
+type SelfEstimateReviewLog interface {
+	SaveReviewEvent(ctx context.Context, ev domain.ReviewEvent) error
+}
+
+// ErrSelfEstimateNotFound is returned when a member answers a self-estimate that is not theirs.
+var ErrSelfEstimateNotFound = errors.New("self-estimate not found")
+
+// logSelfEstimateReview appends a step to the entry's review history, if a log is configured.
+// PRE: entry has been saved
+// POST: event is persisted when log is non-nil
+func logSelfEstimateReview(ctx context.Context, log SelfEstimateReviewLog, generateID func() string, ev domain.ReviewEvent) error {
+	if log == nil {
+		return nil
+	}
+	ev.ID = generateID()
+	if err := ev.Validate(); err != nil {
+		return err
+	}
+	return log.SaveReviewEvent(ctx, ev)
+}
+
 // SubmitSelfEstimateInput carries input for the self-estimate submission orchestrator.
 type SubmitSelfEstimateInput struct {
 	MemberID    string
@@ -26,6 +49,7 @@ type SubmitSelfEstimateInput struct {
 // SubmitSelfEstimateDeps holds dependencies for the self-estimate submission orchestrator.
 type SubmitSelfEstimateDeps struct {
 	EstimatedHoursStore EstimatedHoursStoreForSelfEstimate
+	ReviewLog           SelfEstimateReviewLog // optional: records the submission in the review history
 	GenerateID          func() string
 	Now                 func() time.Time
 }
@@ -58,29 +82,36 @@ func ExecuteSubmitSelfEstimate(ctx context.Context, input SubmitSelfEstimateInpu
 	if err := deps.EstimatedHoursStore.Save(ctx, entry); err != nil {
 		return domain.EstimatedHours{}, err
 	}
+	if err := logSelfEstimateReview(ctx, deps.ReviewLog, deps.GenerateID, domain.ReviewEvent{
+		EntryID: entry.ID, ActorID: entry.MemberID, Action: domain.ReviewSubmitted, Note: entry.Note, Hours: entry.TotalHours, CreatedAt: entry.CreatedAt,
+	}); err != nil {
+		return domain.EstimatedHours{}, err
+	}
 
-	slog.Info("self_estimate_event", "event", "self_estimate_submitted", "entry_id", entry.ID, "member_id", entry.MemberID, "total_hours", entry.TotalHours)
+	slog.InfoContext(ctx, "self_estimate_event", "event", "self_estimate_submitted", "entry_id", entry.ID, "member_id", entry.MemberID, "total_hours", entry.TotalHours)
 	return entry, nil
 }
 
 // ReviewSelfEstimateInput carries input for the review orchestrator.
 type ReviewSelfEstimateInput struct {
 	ID            string  // estimated hours entry ID
-	Action        string  // "approve" or "reject"
+	Action        string  // "approve", "reject" or "needs_info"
 	AdjustedHours float64 // optional: override total hours on approve (0 = keep original)
-	ReviewNote    string  // required for reject, optional for approve
+	ReviewNote    string  // required for reject and needs_info (the question), optional for approve
 	ReviewerID    string  // account ID of admin/coach
 }
 
 // ReviewSelfEstimateDeps holds dependencies for the review orchestrator.
 type ReviewSelfEstimateDeps struct {
 	EstimatedHoursStore EstimatedHoursStoreForSelfEstimate
+	ReviewLog           SelfEstimateReviewLog // optional: records the decision in the review history
+	GenerateID          func() string         // required with ReviewLog
 	Now                 func() time.Time
 }
 
-// ExecuteReviewSelfEstimate approves or rejects a pending self-estimate.
-// PRE: input.ID refers to a pending entry, input.Action is "approve" or "reject"
-// POST: entry status is updated, review fields populated
+// ExecuteReviewSelfEstimate approves, rejects or asks the member about a pending self-estimate.
+// PRE: input.ID refers to a pending entry, input.Action is "approve", "reject" or "needs_info"
+// POST: entry status is updated, review fields populated, and the step is logged
 func ExecuteReviewSelfEstimate(ctx context.Context, input ReviewSelfEstimateInput, deps ReviewSelfEstimateDeps) (domain.EstimatedHours, error) {
 	entry, err := deps.EstimatedHoursStore.GetByID(ctx, input.ID)
 	if err != nil {
@@ -89,15 +120,26 @@ func ExecuteReviewSelfEstimate(ctx context.Context, input ReviewSelfEstimateInpu
 
 	now := deps.Now()
 
+	var logged string
 	switch input.Action {
 	case "approve":
 		if err := entry.Approve(input.ReviewerID, input.AdjustedHours, input.ReviewNote, now); err != nil {
 			return domain.EstimatedHours{}, err
 		}
+		logged = domain.ReviewApproved
+		if entry.Adjusted() {
+			logged = domain.ReviewAdjusted
+		}
 	case "reject":
 		if err := entry.Reject(input.ReviewerID, input.ReviewNote, now); err != nil {
 			return domain.EstimatedHours{}, err
 		}
+		logged = domain.ReviewRejected
+	case "needs_info":
+		if err := entry.RequestInfo(input.ReviewerID, input.ReviewNote, now); err != nil {
+			return domain.EstimatedHours{}, err
+		}
+		logged = domain.ReviewNeedsInfo
 	default:
 		return domain.EstimatedHours{}, domain.ErrInvalidStatus
 	}
@@ -105,7 +147,51 @@ func ExecuteReviewSelfEstimate(ctx context.Context, input ReviewSelfEstimateInpu
 	if err := deps.EstimatedHoursStore.Save(ctx, entry); err != nil {
 		return domain.EstimatedHours{}, err
 	}
+	if err := logSelfEstimateReview(ctx, deps.ReviewLog, deps.GenerateID, domain.ReviewEvent{
+		EntryID: entry.ID, ActorID: input.ReviewerID, Action: logged, Note: input.ReviewNote, Hours: entry.TotalHours, CreatedAt: now,
+	}); err != nil {
+		return domain.EstimatedHours{}, err
+	}
+
+	slog.InfoContext(ctx, "self_estimate_event", "event", "self_estimate_reviewed", "entry_id", entry.ID, "action", input.Action, "reviewer_id", input.ReviewerID, "member_id", entry.MemberID)
+	return entry, nil
+}
+
+// RespondSelfEstimateInput carries a member's answer to a reviewer's question.
+type RespondSelfEstimateInput struct {
+	ID       string // estimated hours entry ID
+	MemberID string // the answering member; must own the entry
+	Response string
+}
+
+// RespondSelfEstimateDeps holds dependencies for the respond orchestrator.
+type RespondSelfEstimateDeps struct {
+	EstimatedHoursStore EstimatedHoursStoreForSelfEstimate
+	ReviewLog           SelfEstimateReviewLog // optional: records the answer in the review history
+	GenerateID          func() string         // required with ReviewLog
+	Now                 func() time.Time
+}
+
+// ExecuteRespondSelfEstimate records a member's answer and puts their estimate back in the review queue.
+// PRE: input.ID refers to the member's own self-estimate in needs_info
+// POST: entry status is pending again and the answer is logged
+func ExecuteRespondSelfEstimate(ctx context.Context, input RespondSelfEstimateInput, deps RespondSelfEstimateDeps) (domain.EstimatedHours, error) {
+	entry, err := deps.EstimatedHoursStore.GetByID(ctx, input.ID)
+	if err != nil || entry.MemberID != input.MemberID || entry.Source != domain.SourceSelfEstimate {
+		return domain.EstimatedHours{}, ErrSelfEstimateNotFound
+	}
+	if err := entry.ProvideInfo(input.Response); err != nil {
+		return domain.EstimatedHours{}, err
+	}
+	if err := deps.EstimatedHoursStore.Save(ctx, entry); err != nil {
+		return domain.EstimatedHours{}, err
+	}
+	if err := logSelfEstimateReview(ctx, deps.ReviewLog, deps.GenerateID, domain.ReviewEvent{
+		EntryID: entry.ID, ActorID: input.MemberID, Action: domain.ReviewResponded, Note: input.Response, Hours: entry.TotalHours, CreatedAt: deps.Now(),
+	}); err != nil {
+		return domain.EstimatedHours{}, err
+	}
 
-	slog.Info("self_estimate_event", "event", "self_estimate_reviewed", "entry_id", entry.ID, "action", input.Action, "reviewer_id", input.ReviewerID, "member_id", entry.MemberID)
+	slog.InfoContext(ctx, "self_estimate_event", "event", "self_estimate_responded", "entry_id", entry.ID, "member_id", entry.MemberID)
 	return entry, nil
 }
@@ -2,6 +2,7 @@ package orchestrators
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -240,3 +241,69 @@ func TestExecuteReviewSelfEstimate_NotPending(t *testing.T) {
 		t.Fatal("expected error for reviewing non-pending entry")
 	}
 }
+
+// mockSelfEstimateReviewLog implements SelfEstimateReviewLog for testing.
+type mockSelfEstimateReviewLog struct {
+	events []domain.ReviewEvent
+}
+
+// SaveReviewEvent implements SelfEstimateReviewLog.
+// PRE: ev is a valid ReviewEvent
+// POST: event is appended to events
+func (m *mockSelfEstimateReviewLog) SaveReviewEvent(_ context.Context, ev domain.ReviewEvent) error {
+	m.events = append(m.events, ev)
+	return nil
+}
+
+// TestSelfEstimate_NeedsInfoRoundTrip tests a reviewer question, the member's answer and an
+// adjusted approval, each recorded in the review history.
+func TestSelfEstimate_NeedsInfoRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := &mockEstHoursStoreForSelfEstimate{}
+	log := &mockSelfEstimateReviewLog{}
+	ids := 0
+	genID := func() string { ids++; return fmt.Sprintf("id-%d", ids) }
+
+	entry, err := ExecuteSubmitSelfEstimate(ctx, SubmitSelfEstimateInput{
+		MemberID: "m1", StartDate: "2026-01-01", EndDate: "2026-02-01", WeeklyHours: 3, Note: "Travelling",
+	}, SubmitSelfEstimateDeps{EstimatedHoursStore: store, ReviewLog: log, GenerateID: genID, Now: testNowSelfEst})
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	reviewDeps := ReviewSelfEstimateDeps{EstimatedHoursStore: store, ReviewLog: log, GenerateID: genID, Now: testNowSelfEst}
+	if _, err := ExecuteReviewSelfEstimate(ctx, ReviewSelfEstimateInput{ID: entry.ID, Action: "needs_info", ReviewerID: "admin-1"}, reviewDeps); err != domain.ErrEmptyInfoRequest {
+		t.Errorf("needs_info without a question: got %v, want ErrEmptyInfoRequest", err)
+	}
+	asked, err := ExecuteReviewSelfEstimate(ctx, ReviewSelfEstimateInput{ID: entry.ID, Action: "needs_info", ReviewNote: "Which gym?", ReviewerID: "admin-1"}, reviewDeps)
+	if err != nil || asked.Status != domain.StatusNeedsInfo {
+		t.Fatalf("needs_info: err %v, status %q", err, asked.Status)
+	}
+
+	respondDeps := RespondSelfEstimateDeps{EstimatedHoursStore: store, ReviewLog: log, GenerateID: genID, Now: testNowSelfEst}
+	if _, err := ExecuteRespondSelfEstimate(ctx, RespondSelfEstimateInput{ID: entry.ID, MemberID: "m2", Response: "Not mine"}, respondDeps); err != ErrSelfEstimateNotFound {
+		t.Errorf("another member answering: got %v, want ErrSelfEstimateNotFound", err)
+	}
+	answered, err := ExecuteRespondSelfEstimate(ctx, RespondSelfEstimateInput{ID: entry.ID, MemberID: "m1", Response: "Checkmat Lisbon"}, respondDeps)
+	if err != nil || answered.Status != domain.StatusPending {
+		t.Fatalf("respond: err %v, status %q", err, answered.Status)
+	}
+
+	approved, err := ExecuteReviewSelfEstimate(ctx, ReviewSelfEstimateInput{ID: entry.ID, Action: "approve", AdjustedHours: 10, ReviewerID: "admin-1"}, reviewDeps)
+	if err != nil || !approved.Adjusted() {
+		t.Fatalf("approve: err %v, adjusted %v", err, approved.Adjusted())
+	}
+
+	want := []string{domain.ReviewSubmitted, domain.ReviewNeedsInfo, domain.ReviewResponded, domain.ReviewAdjusted}
+	if len(log.events) != len(want) {
+		t.Fatalf("history = %+v, want actions %v", log.events, want)
+	}
+	for i, ev := range log.events {
+		if ev.Action != want[i] || ev.EntryID != entry.ID {
+			t.Errorf("event %d = %s for %s, want %s", i, ev.Action, ev.EntryID, want[i])
+		}
+	}
+	if log.events[2].Note != "Checkmat Lisbon" || log.events[3].Hours != 10 {
+		t.Errorf("answer %q, adjusted hours %v", log.events[2].Note, log.events[3].Hours)
+	}
+}
@@ -0,0 +1,151 @@
+package orchestrators
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/rotor"
+	"workshop/internal/domain/sessionlog"
+)
+
+// SessionLogStoreForOrchestrator defines the session log store interface needed to save logs.
+type SessionLogStoreForOrchestrator interface {
+	GetByScheduleAndDate(ctx context.Context, scheduleID, classDate string) (sessionlog.SessionLog, error)
+	Save(ctx context.Context, l sessionlog.SessionLog) error
+}
+
+// SessionLogRotorStore defines the rotor store interface needed to link logs to curriculum topics.
+type SessionLogRotorStore interface {
+	GetActiveRotor(ctx context.Context, classTypeID string) (rotor.Rotor, error)
+	ListThemesByRotor(ctx context.Context, rotorID string) ([]rotor.RotorTheme, error)
+	GetActiveScheduleForTheme(ctx context.Context, rotorThemeID string) (rotor.TopicSchedule, error)
+	GetTopic(ctx context.Context, id string) (rotor.Topic, error)
+	SaveTopic(ctx context.Context, t rotor.Topic) error
+}
+
+// SaveSessionLogInput carries input for the save session log orchestrator.
+type SaveSessionLogInput struct {
+	ScheduleID      string
+	ClassDate       string   // YYYY-MM-DD
+	CoachID         string   // AccountID of the coach writing the log
+	TopicIDs        []string // nil = the class's active rotor topics; empty = no topics
+	RoundStructure  string
+	AttendanceNotes string
+	Notes           string
+	Shareable       bool // members may read Notes in the curriculum history
+}
+
+// SaveSessionLogDeps holds dependencies for SaveSessionLog.
+type SaveSessionLogDeps struct {
+	SessionLogStore SessionLogStoreForOrchestrator
+	ScheduleStore   ScheduleLookupStore
+	RotorStore      SessionLogRotorStore
+	GenerateID      func() string
+	Now             func() time.Time
+}
+
+// ExecuteSaveSessionLog records what was taught in one class occurrence.
+// Saving again for the same schedule and date updates the existing log.
+// PRE: ScheduleID references an existing schedule; ClassDate is YYYY-MM-DD and not in the future
+// POST: Log is persisted; each covered topic's LastCovered is advanced to ClassDate if later
+func ExecuteSaveSessionLog(ctx context.Context, input SaveSessionLogInput, deps SaveSessionLogDeps) (sessionlog.SessionLog, error) {
+	sched, err := deps.ScheduleStore.GetByID(ctx, input.ScheduleID)
+	if err != nil {
+		return sessionlog.SessionLog{}, err
+	}
+
+	now := deps.Now()
+	l := sessionlog.SessionLog{
+		ID:              deps.GenerateID(),
+		ScheduleID:      input.ScheduleID,
+		ClassDate:       input.ClassDate,
+		CoachID:         input.CoachID,
+		RoundStructure:  strings.TrimSpace(input.RoundStructure),
+		AttendanceNotes: strings.TrimSpace(input.AttendanceNotes),
+		Notes:           strings.TrimSpace(input.Notes),
+		Shareable:       input.Shareable,
+		CreatedAt:       now,
+	}
+	if existing, err := deps.SessionLogStore.GetByScheduleAndDate(ctx, input.ScheduleID, input.ClassDate); err == nil {
+		l.ID = existing.ID
+		l.CreatedAt = existing.CreatedAt
+		l.UpdatedAt = now
+	}
+
+	if input.TopicIDs == nil {
+		l.TopicIDs = activeTopicIDs(ctx, sched.ClassTypeID, deps.RotorStore)
+	} else {
+		l.TopicIDs = dedupeIDs(input.TopicIDs)
+	}
+
+	if err := l.Validate(); err != nil {
+		return sessionlog.SessionLog{}, err
+	}
+	if l.Date().After(now) {
+		return sessionlog.SessionLog{}, sessionlog.ErrClassDateInFuture
+	}
+
+	topics := make([]rotor.Topic, 0, len(l.TopicIDs))
+	for _, id := range l.TopicIDs {
+		t, err := deps.RotorStore.GetTopic(ctx, id)
+		if err != nil {
+			return sessionlog.SessionLog{}, sessionlog.ErrUnknownTopic
+		}
+		topics = append(topics, t)
+	}
+
+	if err := deps.SessionLogStore.Save(ctx, l); err != nil {
+		return sessionlog.SessionLog{}, err
+	}
+
+	// Feed the rotor's last_covered data from what was actually taught.
+	covered := l.Date()
+	for _, t := range topics {
+		if covered.After(t.LastCovered) {
+			t.LastCovered = covered
+			if err := deps.RotorStore.SaveTopic(ctx, t); err != nil {
+				slog.ErrorContext(ctx, "session_log_event", "event", "last_covered_failed", "topic_id", t.ID, "error", err)
+			}
+		}
+	}
+
+	slog.InfoContext(ctx, "session_log_event", "event", "session_log_saved", "session_log_id", l.ID,
+		"schedule_id", l.ScheduleID, "class_date", l.ClassDate, "topics", len(l.TopicIDs), "coach_id", l.CoachID)
+	return l, nil
+}
+
+// activeTopicIDs returns the topics currently active across the class type's active rotor themes.
+func activeTopicIDs(ctx context.Context, classTypeID string, store SessionLogRotorStore) []string {
+	r, err := store.GetActiveRotor(ctx, classTypeID)
+	if err != nil {
+		return []string{}
+	}
+	themes, err := store.ListThemesByRotor(ctx, r.ID)
+	if err != nil {
+		return []string{}
+	}
+	ids := []string{}
+	for _, th := range themes {
+		if ts, err := store.GetActiveScheduleForTheme(ctx, th.ID); err == nil {
+			ids = append(ids, ts.TopicID)
+		}
+	}
+	return ids
+}
+
+// dedupeIDs drops empty and repeated IDs, preserving order.
+func dedupeIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
@@ -0,0 +1,188 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/rotor"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/sessionlog"
+)
+
+// --- Mock stores for session log tests ---
+
+type mockSessionLogStore struct {
+	logs map[string]sessionlog.SessionLog
+}
+
+// GetByScheduleAndDate implements SessionLogStoreForOrchestrator.
+// PRE: scheduleID and classDate are non-empty
+// POST: returns the log for the class occurrence or an error
+func (m *mockSessionLogStore) GetByScheduleAndDate(_ context.Context, scheduleID, classDate string) (sessionlog.SessionLog, error) {
+	for _, l := range m.logs {
+		if l.ScheduleID == scheduleID && l.ClassDate == classDate {
+			return l, nil
+		}
+	}
+	return sessionlog.SessionLog{}, errors.New("not found")
+}
+
+// Save implements SessionLogStoreForOrchestrator.
+// PRE: l is valid
+// POST: l is stored by ID
+func (m *mockSessionLogStore) Save(_ context.Context, l sessionlog.SessionLog) error {
+	m.logs[l.ID] = l
+	return nil
+}
+
+type mockSessionLogRotorStore struct {
+	topics map[string]rotor.Topic
+}
+
+// GetActiveRotor implements SessionLogRotorStore.
+// PRE: classTypeID is non-empty
+// POST: returns an active rotor for ct1 only
+func (m *mockSessionLogRotorStore) GetActiveRotor(_ context.Context, classTypeID string) (rotor.Rotor, error) {
+	if classTypeID != "ct1" {
+		return rotor.Rotor{}, errors.New("no active rotor")
+	}
+	return rotor.Rotor{ID: "r1", ClassTypeID: classTypeID, Status: rotor.StatusActive}, nil
+}
+
+// ListThemesByRotor implements SessionLogRotorStore.
+// PRE: rotorID is non-empty
+// POST: returns two themes
+func (m *mockSessionLogRotorStore) ListThemesByRotor(_ context.Context, rotorID string) ([]rotor.RotorTheme, error) {
+	return []rotor.RotorTheme{{ID: "th1", RotorID: rotorID}, {ID: "th2", RotorID: rotorID}}, nil
+}
+
+// GetActiveScheduleForTheme implements SessionLogRotorStore.
+// PRE: rotorThemeID is non-empty
+// POST: only th1 has an active topic
+func (m *mockSessionLogRotorStore) GetActiveScheduleForTheme(_ context.Context, rotorThemeID string) (rotor.TopicSchedule, error) {
+	if rotorThemeID != "th1" {
+		return rotor.TopicSchedule{}, errors.New("none active")
+	}
+	return rotor.TopicSchedule{ID: "ts1", TopicID: "t1", RotorThemeID: rotorThemeID, Status: rotor.ScheduleStatusActive}, nil
+}
+
+// GetTopic implements SessionLogRotorStore.
+// PRE: id is non-empty
+// POST: returns the topic or an error
+func (m *mockSessionLogRotorStore) GetTopic(_ context.Context, id string) (rotor.Topic, error) {
+	t, ok := m.topics[id]
+	if !ok {
+		return rotor.Topic{}, errors.New("not found")
+	}
+	return t, nil
+}
+
+// SaveTopic implements SessionLogRotorStore.
+// PRE: t is valid
+// POST: t is stored by ID
+func (m *mockSessionLogRotorStore) SaveTopic(_ context.Context, t rotor.Topic) error {
+	m.topics[t.ID] = t
+	return nil
+}
+
+type mockSessionLogScheduleStore struct{}
+
+// GetByID implements ScheduleLookupStore.
+// PRE: id is non-empty
+// POST: returns a schedule of class type ct1
+func (m *mockSessionLogScheduleStore) GetByID(_ context.Context, id string) (schedule.Schedule, error) {
+	return schedule.Schedule{ID: id, ClassTypeID: "ct1", Day: schedule.Sunday, StartTime: "10:00", EndTime: "11:00"}, nil
+}
+
+func newSessionLogDeps() (SaveSessionLogDeps, *mockSessionLogStore, *mockSessionLogRotorStore) {
+	logs := &mockSessionLogStore{logs: map[string]sessionlog.SessionLog{}}
+	rotors := &mockSessionLogRotorStore{topics: map[string]rotor.Topic{
+		"t1": {ID: "t1", RotorThemeID: "th1", Name: "Single Leg", DurationWeeks: 1},
+		"t2": {ID: "t2", RotorThemeID: "th2", Name: "Armbar", DurationWeeks: 1, LastCovered: fixedTime},
+	}}
+	return SaveSessionLogDeps{
+		SessionLogStore: logs,
+		ScheduleStore:   &mockSessionLogScheduleStore{},
+		RotorStore:      rotors,
+		GenerateID:      fixedID,
+		Now:             fixedNow,
+	}, logs, rotors
+}
+
+// TestExecuteSaveSessionLog_DefaultsToActiveTopics verifies active rotor topics are linked and last_covered advanced.
+func TestExecuteSaveSessionLog_DefaultsToActiveTopics(t *testing.T) {
+	deps, logs, rotors := newSessionLogDeps()
+
+	l, err := ExecuteSaveSessionLog(context.Background(), SaveSessionLogInput{
+		ScheduleID: "s1", ClassDate: "2026-02-28", CoachID: "coach-1", RoundStructure: " 5x5 ",
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(l.TopicIDs) != 1 || l.TopicIDs[0] != "t1" {
+		t.Errorf("expected active topic t1, got %v", l.TopicIDs)
+	}
+	if l.RoundStructure != "5x5" {
+		t.Errorf("expected trimmed round structure, got %q", l.RoundStructure)
+	}
+	if len(logs.logs) != 1 {
+		t.Errorf("expected 1 saved log, got %d", len(logs.logs))
+	}
+	want := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+	if got := rotors.topics["t1"].LastCovered; !got.Equal(want) {
+		t.Errorf("expected LastCovered %v, got %v", want, got)
+	}
+}
+
+// TestExecuteSaveSessionLog_UpdatesSameOccurrence verifies re-saving keeps ID and never moves last_covered backwards.
+func TestExecuteSaveSessionLog_UpdatesSameOccurrence(t *testing.T) {
+	deps, logs, rotors := newSessionLogDeps()
+	input := SaveSessionLogInput{ScheduleID: "s1", ClassDate: "2026-02-28", CoachID: "coach-1", TopicIDs: []string{"t2", "t2"}}
+
+	first, err := ExecuteSaveSessionLog(context.Background(), input, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deps.GenerateID = func() string { return "other-id" }
+	input.Notes = "Good energy"
+	second, err := ExecuteSaveSessionLog(context.Background(), input, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.ID != first.ID || second.UpdatedAt.IsZero() || len(logs.logs) != 1 {
+		t.Errorf("expected in-place update of %s, got %+v (%d logs)", first.ID, second, len(logs.logs))
+	}
+	if len(second.TopicIDs) != 1 {
+		t.Errorf("expected duplicate topic IDs to be collapsed, got %v", second.TopicIDs)
+	}
+	if got := rotors.topics["t2"].LastCovered; !got.Equal(fixedTime) {
+		t.Errorf("LastCovered moved backwards to %v", got)
+	}
+}
+
+// TestExecuteSaveSessionLog_Rejections verifies invalid logs are refused.
+func TestExecuteSaveSessionLog_Rejections(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   SaveSessionLogInput
+		wantErr error
+	}{
+		{"future class", SaveSessionLogInput{ScheduleID: "s1", ClassDate: "2026-03-02", CoachID: "c"}, sessionlog.ErrClassDateInFuture},
+		{"unknown topic", SaveSessionLogInput{ScheduleID: "s1", ClassDate: "2026-03-01", CoachID: "c", TopicIDs: []string{"nope"}}, sessionlog.ErrUnknownTopic},
+		{"bad date", SaveSessionLogInput{ScheduleID: "s1", ClassDate: "yesterday", CoachID: "c"}, sessionlog.ErrInvalidClassDate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, logs, _ := newSessionLogDeps()
+			_, err := ExecuteSaveSessionLog(context.Background(), tt.input, deps)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if len(logs.logs) != 0 {
+				t.Error("rejected log must not be saved")
+			}
+		})
+	}
+}
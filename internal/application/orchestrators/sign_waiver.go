@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"html"
+	"log/slog"
+	"strings"
 	"time"
 
 	"workshop/internal/domain/member"
@@ -19,10 +22,17 @@ type WaiverStore interface {
 
 // SignWaiverInput carries input for the orchestrator.
 type SignWaiverInput struct {
-	AcceptedTerms bool
-	Email         string
-	MemberName    string
-	IPAddress     string // Passed from HTTP context
+	AcceptedTerms  bool
+	Email          string
+	MemberName     string
+	IPAddress      string // Passed from HTTP context
+	SignatureImage string // drawn signature as a PNG data URL; required when a guardian signs
+	// Guardian fields are filled in when a parent or guardian signs for a minor. Kids
+	// members must have one; a new member signed for by a guardian joins the kids program.
+	GuardianName         string
+	GuardianRelationship string
+	GuardianEmail        string
+	GuardianPhone        string
 }
 
 // SignWaiverDeps holds dependencies for SignWaiver.
@@ -33,8 +43,8 @@ type SignWaiverDeps struct {
 
 // ExecuteSignWaiver coordinates digital waiver signing.
 // PRE: Valid email, AcceptedTerms=true
-// POST: Waiver created with timestamp and IP
-// INVARIANT: One active waiver per member
+// POST: Waiver created with timestamp, IP, signer and a sealed copy of the signed document
+// INVARIANT: One active waiver per member; kids waivers are signed by a guardian
 func ExecuteSignWaiver(ctx context.Context, input SignWaiverInput, deps SignWaiverDeps) error {
 	// Validate input
 	if input.MemberName == "" {
@@ -53,14 +63,30 @@ func ExecuteSignWaiver(ctx context.Context, input SignWaiverInput, deps SignWaiv
 		return err
 	}
 
+	guardian := waiver.Guardian{
+		Name:         strings.TrimSpace(input.GuardianName),
+		Relationship: strings.TrimSpace(input.GuardianRelationship),
+		Email:        strings.TrimSpace(input.GuardianEmail),
+		Phone:        strings.TrimSpace(input.GuardianPhone),
+	}
+	if err == nil && existing.Program == member.ProgramKids && guardian.Name == "" {
+		return waiver.ErrGuardianRequired
+	}
+
 	memberID := existing.ID
+	memberName := existing.Name
 	if errors.Is(err, sql.ErrNoRows) {
 		memberID = uuid.New().String()
+		memberName = input.MemberName
+		program := member.ProgramAdults // Default
+		if guardian.Name != "" {
+			program = member.ProgramKids
+		}
 		m := member.Member{
 			ID:      memberID,
 			Name:    input.MemberName,
 			Email:   input.Email,
-			Program: member.ProgramAdults, // Default
+			Program: program,
 			Status:  member.StatusActive,
 		}
 
@@ -75,12 +101,19 @@ func ExecuteSignWaiver(ctx context.Context, input SignWaiverInput, deps SignWaiv
 
 	// Create waiver
 	w := waiver.Waiver{
-		ID:            uuid.New().String(),
-		MemberID:      memberID,
-		AcceptedTerms: input.AcceptedTerms,
-		IPAddress:     input.IPAddress,
-		SignedAt:      time.Now(),
+		ID:             uuid.New().String(),
+		MemberID:       memberID,
+		AcceptedTerms:  input.AcceptedTerms,
+		IPAddress:      input.IPAddress,
+		SignedAt:       time.Now(),
+		SignerName:     input.MemberName,
+		SignatureImage: input.SignatureImage,
+		Guardian:       guardian,
+	}
+	if guardian.Name != "" {
+		w.SignerName = guardian.Name
 	}
+	w.Seal(renderWaiverDocument(w, memberName))
 
 	// Validate domain rules
 	if err := w.Validate(); err != nil {
@@ -92,5 +125,41 @@ func ExecuteSignWaiver(ctx context.Context, input SignWaiverInput, deps SignWaiv
 		return err
 	}
 
+	slog.InfoContext(ctx, "waiver_event", "event", "waiver_signed", "waiver_id", w.ID, "member_id", memberID, "guardian_signed", w.IsGuardianSigned())
 	return nil
 }
+
+// renderWaiverDocument renders the standalone HTML copy of a signed waiver: the terms as
+// they read when signed, who signed for whom, when and from where, and the drawn signature.
+func renderWaiverDocument(w waiver.Waiver, memberName string) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head><meta charset=\"UTF-8\"><title>Signed waiver — ")
+	b.WriteString(html.EscapeString(memberName))
+	b.WriteString("</title></head>\n<body style=\"font-family:system-ui,sans-serif;max-width:720px;margin:2rem auto;line-height:1.6;\">\n")
+	b.WriteString("<h1>" + html.EscapeString(waiver.Title) + "</h1>\n")
+	for _, p := range waiver.Terms {
+		b.WriteString("<p>" + html.EscapeString(p) + "</p>\n")
+	}
+	b.WriteString("<p>I have read and accept the Waiver &amp; Code of Conduct.</p>\n<table>\n")
+	row := func(label, value string) {
+		if value != "" {
+			b.WriteString("<tr><th style=\"text-align:left;padding-right:1rem;\">" + label + "</th><td>" + html.EscapeString(value) + "</td></tr>\n")
+		}
+	}
+	row("Member", memberName)
+	row("Signed by", w.SignerName)
+	if w.IsGuardianSigned() {
+		row("Relationship", w.Guardian.Relationship)
+		row("Guardian email", w.Guardian.Email)
+		row("Guardian phone", w.Guardian.Phone)
+	}
+	row("Signed at", w.SignedAt.UTC().Format(time.RFC3339))
+	row("IP address", w.IPAddress)
+	row("Waiver ID", w.ID)
+	b.WriteString("</table>\n")
+	if w.SignatureImage != "" {
+		b.WriteString("<p><img src=\"" + html.EscapeString(w.SignatureImage) + "\" alt=\"Signature of " + html.EscapeString(w.SignerName) + "\" style=\"max-width:400px;border-bottom:1px solid #333;\"></p>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
@@ -0,0 +1,115 @@
+package orchestrators
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/waiver"
+)
+
+type mockSignWaiverMemberStore struct {
+	members map[string]member.Member // keyed by email
+}
+
+// Save implements MemberStore for testing.
+// PRE: m has been validated
+// POST: The member is stored by email
+func (s *mockSignWaiverMemberStore) Save(_ context.Context, m member.Member) error {
+	s.members[m.Email] = m
+	return nil
+}
+
+// GetByID implements MemberStore for testing.
+// PRE: id is non-empty
+// POST: Returns the member or sql.ErrNoRows
+func (s *mockSignWaiverMemberStore) GetByID(_ context.Context, id string) (member.Member, error) {
+	for _, m := range s.members {
+		if m.ID == id {
+			return m, nil
+		}
+	}
+	return member.Member{}, sql.ErrNoRows
+}
+
+// GetByEmail implements MemberStore for testing.
+// PRE: email is non-empty
+// POST: Returns the member or sql.ErrNoRows
+func (s *mockSignWaiverMemberStore) GetByEmail(_ context.Context, email string) (member.Member, error) {
+	if m, ok := s.members[email]; ok {
+		return m, nil
+	}
+	return member.Member{}, sql.ErrNoRows
+}
+
+type mockSignWaiverStore struct {
+	saved []waiver.Waiver
+}
+
+// Save implements WaiverStore for testing.
+// PRE: w has been validated
+// POST: The waiver is appended
+func (s *mockSignWaiverStore) Save(_ context.Context, w waiver.Waiver) error {
+	s.saved = append(s.saved, w)
+	return nil
+}
+
+var testSignature = waiver.SignatureImagePrefix + base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n\x1a\nstrokes"))
+
+// TestExecuteSignWaiver_GuardianSignsForKid verifies a kids member needs a guardian
+// signature and the sealed document names both the child and the guardian.
+func TestExecuteSignWaiver_GuardianSignsForKid(t *testing.T) {
+	members := &mockSignWaiverMemberStore{members: map[string]member.Member{
+		"kid@test.com": {ID: "k1", Name: "Tama <Kid>", Email: "kid@test.com", Program: member.ProgramKids, Status: member.StatusActive},
+	}}
+	waivers := &mockSignWaiverStore{}
+	deps := SignWaiverDeps{MemberStore: members, WaiverStore: waivers}
+	ctx := context.Background()
+	input := SignWaiverInput{AcceptedTerms: true, Email: "kid@test.com", MemberName: "Tama", IPAddress: "10.0.0.1"}
+
+	if err := ExecuteSignWaiver(ctx, input, deps); !errors.Is(err, waiver.ErrGuardianRequired) {
+		t.Fatalf("kid without guardian: err = %v, want ErrGuardianRequired", err)
+	}
+	input.GuardianName, input.GuardianRelationship, input.GuardianPhone = "Mere Parata", "mother", "021 555 0101"
+	if err := ExecuteSignWaiver(ctx, input, deps); !errors.Is(err, waiver.ErrSignatureRequired) {
+		t.Fatalf("guardian without signature: err = %v, want ErrSignatureRequired", err)
+	}
+	input.SignatureImage = testSignature
+	if err := ExecuteSignWaiver(ctx, input, deps); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(waivers.saved) != 1 {
+		t.Fatalf("saved %d waivers, want 1", len(waivers.saved))
+	}
+	w := waivers.saved[0]
+	if w.MemberID != "k1" || w.SignerName != "Mere Parata" || !w.IsGuardianSigned() || !w.DocumentIntact() {
+		t.Errorf("waiver = %+v, want guardian-signed for k1 with an intact document", w)
+	}
+	for _, want := range []string{"Tama &lt;Kid&gt;", "Mere Parata", "mother", "10.0.0.1", testSignature, waiver.Terms[0][:40]} {
+		if !strings.Contains(w.Document, want) {
+			t.Errorf("document missing %q", want)
+		}
+	}
+}
+
+// TestExecuteSignWaiver_GuardianForNewMemberJoinsKids verifies a guardian signing for
+// someone without a member record creates them in the kids program.
+func TestExecuteSignWaiver_GuardianForNewMemberJoinsKids(t *testing.T) {
+	members := &mockSignWaiverMemberStore{members: map[string]member.Member{}}
+	waivers := &mockSignWaiverStore{}
+	err := ExecuteSignWaiver(context.Background(), SignWaiverInput{
+		AcceptedTerms: true, Email: "new@test.com", MemberName: "Aria",
+		SignatureImage: testSignature, GuardianName: "Hemi", GuardianRelationship: "father", GuardianEmail: "hemi@test.com",
+	}, SignWaiverDeps{MemberStore: members, WaiverStore: waivers})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := members.members["new@test.com"]; m.Program != member.ProgramKids || m.Name != "Aria" {
+		t.Errorf("member = %+v, want Aria in the kids program", m)
+	}
+}
@@ -0,0 +1,227 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/statuschange"
+)
+
+// StatusChangeStore persists scheduled and applied status changes.
+type StatusChangeStore interface {
+	Save(ctx context.Context, c statuschange.Change) error
+	ListDue(ctx context.Context, today string) ([]statuschange.Change, error)
+}
+
+// AccountStoreForStatusChange defines the account store interface needed to suspend and reinstate.
+type AccountStoreForStatusChange interface {
+	GetByID(ctx context.Context, id string) (account.Account, error)
+	Save(ctx context.Context, a account.Account) error
+}
+
+// StatusChangeDeps holds dependencies for the status change orchestrators.
+type StatusChangeDeps struct {
+	ChangeStore    StatusChangeStore
+	AccountStore   AccountStoreForStatusChange
+	MemberStore    MemberStoreForArchive
+	RevokeSessions func(ctx context.Context, accountID string) (int, error) // optional: signs a suspended account out everywhere
+	GenerateID     func() string
+	Now            func() time.Time
+}
+
+// ChangeStatusInput carries input for the change status orchestrator.
+type ChangeStatusInput struct {
+	Action        string // suspend, reinstate, freeze or unfreeze
+	SubjectID     string // account ID to suspend or reinstate, member ID to freeze or unfreeze
+	Reason        string // required to suspend; shown at sign-in
+	EffectiveDate string // optional: YYYY-MM-DD; empty or today applies straight away
+	UntilDate     string // optional: suspend and freeze only; schedules the undo on this date
+	ActorID       string // account ID of the admin
+}
+
+// ChangeStatusResult carries the recorded change and, with an end date, its scheduled undo.
+type ChangeStatusResult struct {
+	Change  statuschange.Change
+	Reverse *statuschange.Change
+}
+
+// Status change errors.
+var (
+	ErrEffectiveDateInPast   = errors.New("effective date cannot be in the past")
+	ErrUntilNotAllowed       = errors.New("only suspensions and freezes can have an end date")
+	ErrUntilBeforeEffective  = errors.New("end date must be after the effective date")
+	ErrStatusSubjectNotFound = errors.New("account or member not found")
+)
+
+// ExecuteChangeStatus suspends, reinstates, freezes or unfreezes now, or schedules it for a later date.
+// PRE: input.Action is a statuschange action; input.SubjectID names an existing account or member
+// POST: A due change is applied and recorded as applied; a future one is saved as scheduled.
+// With UntilDate, the reverse change is scheduled for that date.
+func ExecuteChangeStatus(ctx context.Context, input ChangeStatusInput, deps StatusChangeDeps) (ChangeStatusResult, error) {
+	now := deps.Now()
+	today := now.Format("2006-01-02")
+	effective := input.EffectiveDate
+	if effective == "" {
+		effective = today
+	}
+	c := statuschange.Change{
+		ID:            deps.GenerateID(),
+		SubjectType:   statuschange.SubjectFor(input.Action),
+		SubjectID:     input.SubjectID,
+		Action:        input.Action,
+		Reason:        strings.TrimSpace(input.Reason),
+		EffectiveDate: effective,
+		CreatedBy:     input.ActorID,
+		CreatedAt:     now,
+	}
+	if err := c.Validate(); err != nil {
+		return ChangeStatusResult{}, err
+	}
+	if effective < today {
+		return ChangeStatusResult{}, ErrEffectiveDateInPast
+	}
+	if c.Action == statuschange.ActionSuspend && c.Reason == "" {
+		return ChangeStatusResult{}, account.ErrEmptySuspendReason
+	}
+	if err := checkStatusChangeSubject(ctx, c, deps); err != nil {
+		return ChangeStatusResult{}, err
+	}
+
+	var result ChangeStatusResult
+	if input.UntilDate != "" {
+		if c.Action != statuschange.ActionSuspend && c.Action != statuschange.ActionFreeze {
+			return ChangeStatusResult{}, ErrUntilNotAllowed
+		}
+		rev := statuschange.Change{
+			ID:            deps.GenerateID(),
+			SubjectType:   c.SubjectType,
+			SubjectID:     c.SubjectID,
+			Action:        statuschange.Reverse(c.Action),
+			EffectiveDate: input.UntilDate,
+			CreatedBy:     input.ActorID,
+			CreatedAt:     now,
+		}
+		if err := rev.Validate(); err != nil {
+			return ChangeStatusResult{}, err
+		}
+		if rev.EffectiveDate <= effective {
+			return ChangeStatusResult{}, ErrUntilBeforeEffective
+		}
+		result.Reverse = &rev
+	}
+
+	if c.IsDue(today) {
+		if err := applyStatusChange(ctx, c, deps); err != nil {
+			return ChangeStatusResult{}, err
+		}
+		if err := c.MarkApplied(now); err != nil {
+			return ChangeStatusResult{}, err
+		}
+	}
+	if err := deps.ChangeStore.Save(ctx, c); err != nil {
+		return ChangeStatusResult{}, err
+	}
+	if result.Reverse != nil {
+		if err := deps.ChangeStore.Save(ctx, *result.Reverse); err != nil {
+			return ChangeStatusResult{}, err
+		}
+	}
+	result.Change = c
+
+	slog.InfoContext(ctx, "status_event", "event", "status_change_recorded", "change_id", c.ID, "action", c.Action, "subject_id", c.SubjectID, "effective_date", c.EffectiveDate, "applied", !c.AppliedAt.IsZero(), "until", input.UntilDate, "by", input.ActorID)
+	return result, nil
+}
+
+// ExecuteApplyDueStatusChanges applies every scheduled change whose effective date has arrived.
+// A change that no longer fits (say, unfreezing a member who was already unfrozen by hand)
+// is marked failed with the reason and not retried.
+// PRE: deps are valid
+// POST: Returns the number of changes applied; failures are recorded on their change
+func ExecuteApplyDueStatusChanges(ctx context.Context, deps StatusChangeDeps) (int, error) {
+	now := deps.Now()
+	due, err := deps.ChangeStore.ListDue(ctx, now.Format("2006-01-02"))
+	if err != nil {
+		return 0, err
+	}
+	applied := 0
+	var errs []error
+	for _, c := range due {
+		if err := applyStatusChange(ctx, c, deps); err != nil {
+			c.MarkFailed(err.Error())
+			slog.WarnContext(ctx, "status_event", "event", "status_change_failed", "change_id", c.ID, "action", c.Action, "subject_id", c.SubjectID, "error", err)
+		} else if err := c.MarkApplied(now); err != nil {
+			errs = append(errs, err)
+			continue
+		} else {
+			applied++
+			slog.InfoContext(ctx, "status_event", "event", "status_change_applied", "change_id", c.ID, "action", c.Action, "subject_id", c.SubjectID)
+		}
+		if err := deps.ChangeStore.Save(ctx, c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return applied, errors.Join(errs...)
+}
+
+// checkStatusChangeSubject confirms the account or member being changed exists.
+func checkStatusChangeSubject(ctx context.Context, c statuschange.Change, deps StatusChangeDeps) error {
+	var err error
+	if c.SubjectType == statuschange.SubjectAccount {
+		_, err = deps.AccountStore.GetByID(ctx, c.SubjectID)
+	} else {
+		_, err = deps.MemberStore.GetByID(ctx, c.SubjectID)
+	}
+	if err != nil {
+		return ErrStatusSubjectNotFound
+	}
+	return nil
+}
+
+// applyStatusChange makes the change to its account or member.
+// PRE: c is valid
+// POST: The subject's status is updated; a suspended account's sessions are revoked
+func applyStatusChange(ctx context.Context, c statuschange.Change, deps StatusChangeDeps) error {
+	if c.SubjectType == statuschange.SubjectAccount {
+		acct, err := deps.AccountStore.GetByID(ctx, c.SubjectID)
+		if err != nil {
+			return err
+		}
+		if c.Action == statuschange.ActionSuspend {
+			err = acct.Suspend(c.Reason)
+		} else {
+			err = acct.Reinstate()
+		}
+		if err != nil {
+			return err
+		}
+		if err := deps.AccountStore.Save(ctx, acct); err != nil {
+			return err
+		}
+		if c.Action == statuschange.ActionSuspend && deps.RevokeSessions != nil {
+			revoked, err := deps.RevokeSessions(ctx, acct.ID)
+			if err != nil {
+				return err
+			}
+			slog.WarnContext(ctx, "security_event", "event", "account_suspended", "account_id", acct.ID, "sessions", revoked)
+		}
+		return nil
+	}
+
+	m, err := deps.MemberStore.GetByID(ctx, c.SubjectID)
+	if err != nil {
+		return err
+	}
+	if c.Action == statuschange.ActionFreeze {
+		err = m.Freeze()
+	} else {
+		err = m.Unfreeze()
+	}
+	if err != nil {
+		return err
+	}
+	return deps.MemberStore.Save(ctx, m)
+}
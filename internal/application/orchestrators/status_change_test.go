@@ -0,0 +1,179 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/statuschange"
+)
+
+// --- Mock stores for status change tests ---
+
+type mockStatusChangeStore struct {
+	changes map[string]statuschange.Change
+}
+
+// Save stores the change.
+// PRE: none
+// POST: change stored by ID
+func (m *mockStatusChangeStore) Save(_ context.Context, c statuschange.Change) error {
+	m.changes[c.ID] = c
+	return nil
+}
+
+// ListDue returns unapplied, unfailed changes effective on or before today.
+// PRE: none
+// POST: Returns the due changes
+func (m *mockStatusChangeStore) ListDue(_ context.Context, today string) ([]statuschange.Change, error) {
+	var due []statuschange.Change
+	for _, c := range m.changes {
+		if c.IsDue(today) && c.Error == "" {
+			due = append(due, c)
+		}
+	}
+	return due, nil
+}
+
+type mockStatusMemberStore struct{ members map[string]member.Member }
+
+// GetByID returns a stored member.
+// PRE: none
+// POST: Returns the member or an error
+func (m *mockStatusMemberStore) GetByID(_ context.Context, id string) (member.Member, error) {
+	v, ok := m.members[id]
+	if !ok {
+		return member.Member{}, errors.New("not found")
+	}
+	return v, nil
+}
+
+// Save stores the member.
+// PRE: none
+// POST: member stored by ID
+func (m *mockStatusMemberStore) Save(_ context.Context, v member.Member) error {
+	m.members[v.ID] = v
+	return nil
+}
+
+// newStatusChangeDeps returns deps with one active account and one active member, on 2026-03-10.
+func newStatusChangeDeps(revoked *[]string) StatusChangeDeps {
+	n := 0
+	return StatusChangeDeps{
+		ChangeStore:  &mockStatusChangeStore{changes: map[string]statuschange.Change{}},
+		AccountStore: &mockConvertAccountStore{accounts: map[string]account.Account{"a1": {ID: "a1", Role: account.RoleMember, Status: account.StatusActive}}},
+		MemberStore:  &mockStatusMemberStore{members: map[string]member.Member{"m1": {ID: "m1", Name: "Ana", Status: member.StatusActive}}},
+		RevokeSessions: func(_ context.Context, accountID string) (int, error) {
+			*revoked = append(*revoked, accountID)
+			return 1, nil
+		},
+		GenerateID: func() string { n++; return "sc" + string(rune('0'+n)) },
+		Now:        func() time.Time { return time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC) },
+	}
+}
+
+// TestExecuteChangeStatus_SuspendNow verifies suspending applies straight away, records the reason
+// and signs the account out.
+func TestExecuteChangeStatus_SuspendNow(t *testing.T) {
+	var revoked []string
+	deps := newStatusChangeDeps(&revoked)
+	result, err := ExecuteChangeStatus(context.Background(), ChangeStatusInput{
+		Action: statuschange.ActionSuspend, SubjectID: "a1", Reason: " Unpaid fees ", ActorID: "admin1",
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Change.AppliedAt.IsZero() || result.Reverse != nil {
+		t.Errorf("result = %+v", result)
+	}
+	acct, _ := deps.AccountStore.GetByID(context.Background(), "a1")
+	if !acct.IsSuspended() || acct.SuspendedReason != "Unpaid fees" {
+		t.Errorf("account = %+v", acct)
+	}
+	if len(revoked) != 1 || revoked[0] != "a1" {
+		t.Errorf("revoked = %v", revoked)
+	}
+}
+
+// TestExecuteChangeStatus_ScheduledFreeze verifies a future freeze with an end date is only scheduled,
+// then the worker freezes and later unfreezes the member.
+func TestExecuteChangeStatus_ScheduledFreeze(t *testing.T) {
+	var revoked []string
+	deps := newStatusChangeDeps(&revoked)
+	ctx := context.Background()
+	result, err := ExecuteChangeStatus(ctx, ChangeStatusInput{
+		Action: statuschange.ActionFreeze, SubjectID: "m1", Reason: "Overseas", EffectiveDate: "2026-04-01", UntilDate: "2026-07-01", ActorID: "admin1",
+	}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Change.IsScheduled() || result.Reverse == nil || result.Reverse.Action != statuschange.ActionUnfreeze {
+		t.Fatalf("result = %+v", result)
+	}
+	if m, _ := deps.MemberStore.GetByID(ctx, "m1"); m.IsFrozen() {
+		t.Fatal("member frozen before the effective date")
+	}
+
+	for _, step := range []struct {
+		date   string
+		frozen bool
+	}{{"2026-04-01", true}, {"2026-06-30", true}, {"2026-07-01", false}} {
+		day, _ := time.Parse("2006-01-02", step.date)
+		deps.Now = func() time.Time { return day }
+		if _, err := ExecuteApplyDueStatusChanges(ctx, deps); err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.date, err)
+		}
+		if m, _ := deps.MemberStore.GetByID(ctx, "m1"); m.IsFrozen() != step.frozen {
+			t.Errorf("%s: frozen = %v, want %v", step.date, m.IsFrozen(), step.frozen)
+		}
+	}
+}
+
+// TestExecuteApplyDueStatusChanges_Failure verifies a change that no longer fits is marked failed and not retried.
+func TestExecuteApplyDueStatusChanges_Failure(t *testing.T) {
+	var revoked []string
+	deps := newStatusChangeDeps(&revoked)
+	ctx := context.Background()
+	deps.ChangeStore.Save(ctx, statuschange.Change{ID: "x", SubjectType: statuschange.SubjectMember, SubjectID: "m1", Action: statuschange.ActionUnfreeze, EffectiveDate: "2026-03-10", CreatedBy: "admin1"})
+
+	applied, err := ExecuteApplyDueStatusChanges(ctx, deps)
+	if err != nil || applied != 0 {
+		t.Fatalf("applied = %d, err = %v", applied, err)
+	}
+	due, _ := deps.ChangeStore.ListDue(ctx, "2026-03-10")
+	if len(due) != 0 {
+		t.Errorf("failed change still due: %+v", due)
+	}
+}
+
+// TestExecuteChangeStatus_Validation verifies rejected inputs leave nothing behind.
+func TestExecuteChangeStatus_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input ChangeStatusInput
+		want  error
+	}{
+		{"suspend without reason", ChangeStatusInput{Action: statuschange.ActionSuspend, SubjectID: "a1", ActorID: "admin1"}, account.ErrEmptySuspendReason},
+		{"past date", ChangeStatusInput{Action: statuschange.ActionFreeze, SubjectID: "m1", EffectiveDate: "2026-03-09", ActorID: "admin1"}, ErrEffectiveDateInPast},
+		{"until on unfreeze", ChangeStatusInput{Action: statuschange.ActionUnfreeze, SubjectID: "m1", UntilDate: "2026-04-01", ActorID: "admin1"}, ErrUntilNotAllowed},
+		{"until before start", ChangeStatusInput{Action: statuschange.ActionFreeze, SubjectID: "m1", EffectiveDate: "2026-04-01", UntilDate: "2026-04-01", ActorID: "admin1"}, ErrUntilBeforeEffective},
+		{"unknown member", ChangeStatusInput{Action: statuschange.ActionFreeze, SubjectID: "m9", ActorID: "admin1"}, ErrStatusSubjectNotFound},
+		{"unknown action", ChangeStatusInput{Action: "delete", SubjectID: "m1", ActorID: "admin1"}, statuschange.ErrInvalidAction},
+		{"reinstate active account", ChangeStatusInput{Action: statuschange.ActionReinstate, SubjectID: "a1", ActorID: "admin1"}, account.ErrNotSuspended},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var revoked []string
+			deps := newStatusChangeDeps(&revoked)
+			if _, err := ExecuteChangeStatus(context.Background(), tt.input, deps); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+			if n := len(deps.ChangeStore.(*mockStatusChangeStore).changes); n != 0 {
+				t.Errorf("%d changes saved", n)
+			}
+		})
+	}
+}
@@ -32,6 +32,7 @@ type SubmitBugBoxCommand struct {
 	Role             string
 	ImpersonatedRole string
 	ScreenshotPath   string
+	ReporterID       string // AccountID told about triage; the real account when impersonating
 
 	// GitHub integration config (injected from env vars — never hardcoded)
 	GitHubToken string
@@ -69,6 +70,8 @@ func ExecuteSubmitBugBox(ctx context.Context, cmd SubmitBugBoxCommand, deps Subm
 		ImpersonatedRole: cmd.ImpersonatedRole,
 		ScreenshotPath:   cmd.ScreenshotPath,
 		SubmittedAt:      time.Now().UTC(),
+		ReporterID:       cmd.ReporterID,
+		Status:           domain.StatusNew,
 	}
 
 	if err := sub.Validate(); err != nil {
@@ -81,7 +84,7 @@ func ExecuteSubmitBugBox(ctx context.Context, cmd SubmitBugBoxCommand, deps Subm
 
 	issueNum, issueURL, err := createGitHubIssue(ctx, cmd, deps.HTTPClient)
 	if err != nil {
-		slog.Error("bugbox_github_issue_failed", "error", err.Error(), "submission_id", cmd.ID)
+		slog.ErrorContext(ctx, "bugbox_github_issue_failed", "error", err.Error(), "submission_id", cmd.ID)
 		return SubmitBugBoxResult{}, fmt.Errorf("failed to create GitHub issue: %w", err)
 	}
 
@@ -89,11 +92,11 @@ func ExecuteSubmitBugBox(ctx context.Context, cmd SubmitBugBoxCommand, deps Subm
 	sub.GitHubIssueURL = issueURL
 
 	if err := deps.BugBoxStore.Save(ctx, sub); err != nil {
-		slog.Error("bugbox_save_failed", "error", err.Error(), "submission_id", cmd.ID)
+		slog.ErrorContext(ctx, "bugbox_save_failed", "error", err.Error(), "submission_id", cmd.ID)
 		return SubmitBugBoxResult{}, fmt.Errorf("failed to save submission: %w", err)
 	}
 
-	slog.Info("bugbox_submitted", "submission_id", cmd.ID, "github_issue", issueNum)
+	slog.InfoContext(ctx, "bugbox_submitted", "submission_id", cmd.ID, "github_issue", issueNum)
 	return SubmitBugBoxResult{
 		SubmissionID:      cmd.ID,
 		GitHubIssueNumber: issueNum,
@@ -0,0 +1,159 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	emailAdapter "workshop/internal/adapters/email"
+	emailDomain "workshop/internal/domain/email"
+)
+
+// TemplateLibraryStore defines the template library lookup used to send library emails.
+type TemplateLibraryStore interface {
+	GetByKey(ctx context.Context, key string) (emailDomain.MessageTemplate, error)
+}
+
+// TemplatedEmailStore defines the email store interface needed to send and record library emails.
+type TemplatedEmailStore interface {
+	Save(ctx context.Context, e emailDomain.Email) error
+	SaveRecipients(ctx context.Context, emailID string, recipients []emailDomain.Recipient) error
+	GetActiveTemplate(ctx context.Context) (emailDomain.EmailTemplate, error)
+	IsSuppressed(ctx context.Context, address string) (bool, error)
+}
+
+// --- Send Templated Email ---
+
+// SendTemplatedEmailInput carries input for sending one library email to a member.
+type SendTemplatedEmailInput struct {
+	TemplateKey string
+	MemberID    string
+	Vars        emailDomain.Vars // MemberName defaults to the member's name
+}
+
+// SendTemplatedEmailDeps holds dependencies for SendTemplatedEmail.
+type SendTemplatedEmailDeps struct {
+	LibraryStore    TemplateLibraryStore
+	EmailStore      TemplatedEmailStore
+	MemberLookup    MemberLookup
+	EmailSender     emailAdapter.Sender
+	PreferenceStore EmailPreferenceStore                   // optional: nil sends regardless of unsubscribes
+	UnsubscribeURL  func(memberID, category string) string // optional: nil omits unsubscribe links
+	GenerateID      func() string
+	Now             func() time.Time
+	FromAddress     string
+	ReplyTo         string
+}
+
+// Reasons a library email was not sent.
+const (
+	SkipNoAddress    = "no_address"
+	SkipSuppressed   = "suppressed"
+	SkipUnsubscribed = "unsubscribed"
+)
+
+// SendTemplatedEmailResult reports the email sent, or why none was.
+type SendTemplatedEmailResult struct {
+	Email   emailDomain.Email
+	Skipped string // empty when sent; otherwise one of the Skip* reasons
+}
+
+// ExecuteSendTemplatedEmail renders a library template for one member and sends it inside
+// the active header and footer. The saved wording is used, or the built-in wording for a
+// built-in key nobody has edited. Sent emails are recorded in the email history with the
+// template's key.
+// PRE: TemplateKey and MemberID are non-empty
+// POST: The email is sent and recorded, or skipped for a member without an address, on the
+// suppression list, or unsubscribed from the template's category
+func ExecuteSendTemplatedEmail(ctx context.Context, input SendTemplatedEmailInput, deps SendTemplatedEmailDeps) (SendTemplatedEmailResult, error) {
+	if input.TemplateKey == "" || input.MemberID == "" {
+		return SendTemplatedEmailResult{}, errors.New("template key and member ID are required")
+	}
+	tmpl, err := deps.LibraryStore.GetByKey(ctx, input.TemplateKey)
+	if err != nil {
+		builtIn, ok := emailDomain.BuiltInTemplate(input.TemplateKey)
+		if !ok {
+			return SendTemplatedEmailResult{}, emailDomain.ErrTemplateNotFound
+		}
+		tmpl = builtIn
+	}
+
+	name, address, err := deps.MemberLookup.GetEmailByMemberID(ctx, input.MemberID)
+	if err != nil {
+		return SendTemplatedEmailResult{}, err
+	}
+	if address == "" {
+		return SendTemplatedEmailResult{Skipped: SkipNoAddress}, nil
+	}
+	if blocked, err := deps.EmailStore.IsSuppressed(ctx, emailDomain.NormalizeAddress(address)); err == nil && blocked {
+		return SendTemplatedEmailResult{Skipped: SkipSuppressed}, nil
+	}
+	if optedOut(ctx, deps.PreferenceStore, input.MemberID, tmpl.Category) {
+		return SendTemplatedEmailResult{Skipped: SkipUnsubscribed}, nil
+	}
+
+	vars := emailDomain.Vars{emailDomain.VarMemberName: name}
+	for k, v := range input.Vars {
+		if v != "" {
+			vars[k] = v
+		}
+	}
+	subject, body := tmpl.Render(vars)
+
+	now := deps.Now()
+	em := emailDomain.Email{
+		ID:          deps.GenerateID(),
+		Subject:     subject,
+		Body:        body,
+		SenderID:    emailDomain.SystemSenderID,
+		Status:      emailDomain.StatusQueued,
+		Category:    tmpl.Category,
+		TemplateKey: tmpl.Key,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := em.Validate(); err != nil {
+		return SendTemplatedEmailResult{}, err
+	}
+	var wrapper emailDomain.EmailTemplate
+	if active, err := deps.EmailStore.GetActiveTemplate(ctx); err == nil {
+		wrapper = active
+		em.TemplateVersionID = active.ID
+	}
+
+	link := "" // account emails cannot be unsubscribed from
+	if tmpl.Category != emailDomain.CategoryAccount {
+		link = unsubscribeLink(deps.UnsubscribeURL, input.MemberID, tmpl.Category)
+	}
+	res, err := deps.EmailSender.Send(ctx, emailAdapter.SendRequest{
+		To:      []string{address},
+		From:    deps.FromAddress,
+		Subject: subject,
+		HTML:    wrapper.WrapBodyFor(body, link),
+		ReplyTo: deps.ReplyTo,
+	})
+	if err != nil {
+		return SendTemplatedEmailResult{}, err
+	}
+
+	em.MarkSent(now, res.MessageID)
+	if err := deps.EmailStore.Save(ctx, em); err != nil {
+		return SendTemplatedEmailResult{Email: em}, err
+	}
+	recipient := emailDomain.Recipient{
+		EmailID:           em.ID,
+		MemberID:          input.MemberID,
+		MemberName:        name,
+		MemberEmail:       address,
+		DeliveryStatus:    emailDomain.DeliverySent,
+		ResendMessageID:   res.MessageID,
+		DeliveryUpdatedAt: now,
+	}
+	if err := deps.EmailStore.SaveRecipients(ctx, em.ID, []emailDomain.Recipient{recipient}); err != nil {
+		slog.ErrorContext(ctx, "email_event", "event", "recipient_tracking_failed", "email_id", em.ID, "error", err)
+	}
+
+	slog.InfoContext(ctx, "email_event", "event", "templated_email_sent", "email_id", em.ID, "template", tmpl.Key, "member_id", input.MemberID)
+	return SendTemplatedEmailResult{Email: em}, nil
+}
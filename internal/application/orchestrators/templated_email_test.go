@@ -0,0 +1,109 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	emailDomain "workshop/internal/domain/email"
+)
+
+// --- Mock stores for templated email tests ---
+
+type mockLibraryStore struct {
+	templates map[string]emailDomain.MessageTemplate
+}
+
+// GetByKey returns a saved library template.
+// PRE: key is non-empty
+// POST: Returns the template or an error
+func (m *mockLibraryStore) GetByKey(_ context.Context, key string) (emailDomain.MessageTemplate, error) {
+	t, ok := m.templates[key]
+	if !ok {
+		return emailDomain.MessageTemplate{}, errors.New("not found")
+	}
+	return t, nil
+}
+
+// newTemplatedEmailDeps returns send deps over the mock email store, member lookup and sender.
+func newTemplatedEmailDeps(store *mockEmailStore, sender *mockEmailSender) SendTemplatedEmailDeps {
+	return SendTemplatedEmailDeps{
+		LibraryStore: &mockLibraryStore{templates: map[string]emailDomain.MessageTemplate{}},
+		EmailStore:   store,
+		MemberLookup: newMockMemberLookup(),
+		EmailSender:  sender,
+		GenerateID:   testGenerateID,
+		Now:          testNow,
+		FromAddress:  "Workshop <noreply@test.com>",
+	}
+}
+
+// TestSendTemplatedEmail_BuiltIn tests that an unedited built-in is rendered for the member,
+// wrapped in the active template and recorded with its key.
+func TestSendTemplatedEmail_BuiltIn(t *testing.T) {
+	store := newMockEmailStore()
+	store.templates["tpl-1"] = emailDomain.EmailTemplate{ID: "tpl-1", Header: "<header>", Footer: "<footer>", Active: true}
+	sender := newMockEmailSender()
+
+	res, err := ExecuteSendTemplatedEmail(context.Background(), SendTemplatedEmailInput{
+		TemplateKey: emailDomain.TemplateGradingCongratulation,
+		MemberID:    "member-1",
+		Vars:        emailDomain.Vars{emailDomain.VarBelt: "blue"},
+	}, newTemplatedEmailDeps(store, sender))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Skipped != "" || sender.sent != 1 {
+		t.Fatalf("result = %+v with %d sends, want one send", res, sender.sent)
+	}
+	req := sender.sentReqs[0]
+	if req.Subject != "Congratulations on your blue belt, Marcus Almeida!" {
+		t.Errorf("Subject = %q", req.Subject)
+	}
+	if !strings.HasPrefix(req.HTML, "<header>") || !strings.Contains(req.HTML, "Hi Marcus Almeida,") {
+		t.Errorf("HTML = %q, want the rendered body inside the active template", req.HTML)
+	}
+	saved := store.emails[res.Email.ID]
+	if saved.Status != emailDomain.StatusSent || saved.SenderID != emailDomain.SystemSenderID ||
+		saved.TemplateKey != emailDomain.TemplateGradingCongratulation || saved.Category != emailDomain.CategoryGrading {
+		t.Errorf("recorded email = %+v", saved)
+	}
+	if recs := store.recipients[res.Email.ID]; len(recs) != 1 || recs[0].MemberID != "member-1" {
+		t.Errorf("recipients = %+v, want member-1", recs)
+	}
+}
+
+// TestSendTemplatedEmail_SavedWordingAndSkips tests that admin wording replaces the built-in,
+// and that unsubscribed members and unknown keys are not sent to.
+func TestSendTemplatedEmail_SavedWordingAndSkips(t *testing.T) {
+	store := newMockEmailStore()
+	sender := newMockEmailSender()
+	deps := newTemplatedEmailDeps(store, sender)
+	deps.LibraryStore = &mockLibraryStore{templates: map[string]emailDomain.MessageTemplate{
+		emailDomain.TemplateWelcome: {Key: emailDomain.TemplateWelcome, Name: "Welcome", Category: emailDomain.CategoryAccount, Subject: "Kia ora {{MemberName}}", Body: "<p>Welcome</p>"},
+	}}
+	noGrading := emailDomain.DefaultPreferences("member-2")
+	noGrading.Grading = false
+	deps.PreferenceStore = &mockPreferenceStore{prefs: map[string]emailDomain.Preferences{"member-2": noGrading}}
+
+	res, err := ExecuteSendTemplatedEmail(context.Background(), SendTemplatedEmailInput{TemplateKey: emailDomain.TemplateWelcome, MemberID: "member-1"}, deps)
+	if err != nil || res.Skipped != "" {
+		t.Fatalf("result = %+v, err = %v", res, err)
+	}
+	if sender.sentReqs[0].Subject != "Kia ora Marcus Almeida" {
+		t.Errorf("Subject = %q, want the saved wording", sender.sentReqs[0].Subject)
+	}
+
+	res, err = ExecuteSendTemplatedEmail(context.Background(), SendTemplatedEmailInput{TemplateKey: emailDomain.TemplateGradingCongratulation, MemberID: "member-2"}, deps)
+	if err != nil || res.Skipped != SkipUnsubscribed {
+		t.Errorf("result = %+v, err = %v; want skipped as unsubscribed", res, err)
+	}
+
+	if _, err := ExecuteSendTemplatedEmail(context.Background(), SendTemplatedEmailInput{TemplateKey: "no_such", MemberID: "member-1"}, deps); !errors.Is(err, emailDomain.ErrTemplateNotFound) {
+		t.Errorf("err = %v, want ErrTemplateNotFound", err)
+	}
+	if sender.sent != 1 {
+		t.Errorf("sent = %d, want 1", sender.sent)
+	}
+}
@@ -0,0 +1,196 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"time"
+
+	emailAdapter "workshop/internal/adapters/email"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/term"
+	"workshop/internal/domain/waiver"
+)
+
+// TermReportDocument is one kid's term report rendered as a PDF.
+type TermReportDocument struct {
+	MemberID   string
+	MemberName string
+	PDF        []byte
+}
+
+// TermReportTermStore defines the term store interface needed to send term reports.
+type TermReportTermStore interface {
+	GetByID(ctx context.Context, id string) (term.Term, error)
+	List(ctx context.Context) ([]term.Term, error)
+}
+
+// TermReportDeliveryStore defines the delivery store interface needed to send term reports.
+type TermReportDeliveryStore interface {
+	SaveDelivery(ctx context.Context, value term.ReportDelivery) error
+	ListDeliveries(ctx context.Context, termID string) ([]term.ReportDelivery, error)
+}
+
+// TermReportMemberStore defines the member store interface needed to address term reports.
+type TermReportMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+}
+
+// TermReportWaiverStore defines the waiver store interface needed to find a kid's guardian.
+type TermReportWaiverStore interface {
+	GetByMemberID(ctx context.Context, memberID string) (waiver.Waiver, error)
+}
+
+// SendTermReportsInput identifies the reports to email.
+type SendTermReportsInput struct {
+	TermID   string
+	MemberID string // optional: if empty, sends every kid's report
+	Resend   bool   // send again to kids whose report has already gone out
+	SentBy   string // AccountID of the admin sending; empty for the end-of-term run
+}
+
+// SendTermReportsDeps holds dependencies for ExecuteSendTermReports.
+type SendTermReportsDeps struct {
+	TermStore     TermReportTermStore
+	DeliveryStore TermReportDeliveryStore
+	MemberStore   TermReportMemberStore
+	WaiverStore   TermReportWaiverStore // optional: nil sends to the member's own address
+	// Render builds the term's reports; an empty memberID renders every kid's.
+	Render      func(ctx context.Context, termID, memberID string) ([]TermReportDocument, error)
+	EmailSender emailAdapter.Sender
+	FromAddress string
+	ReplyTo     string
+	Now         func() time.Time
+}
+
+// SendTermReportsResult summarises one send.
+type SendTermReportsResult struct {
+	Sent        int
+	AlreadySent int      // reports skipped because they went out earlier
+	NoAddress   []string // names of kids with no guardian or member email
+}
+
+// ExecuteSendTermReports emails each kid's term report PDF to the guardian who signed their
+// waiver, or to the member's own address when no guardian email is on file. A report is sent
+// once per term unless Resend is set; failed sends are not recorded, so they go out next time.
+// PRE: TermID is non-empty; EmailSender is configured
+// POST: Each report not yet delivered is emailed and recorded, or counted as unaddressable; failures are joined
+func ExecuteSendTermReports(ctx context.Context, input SendTermReportsInput, deps SendTermReportsDeps) (SendTermReportsResult, error) {
+	var result SendTermReportsResult
+	t, err := deps.TermStore.GetByID(ctx, input.TermID)
+	if err != nil {
+		return result, err
+	}
+	delivered := make(map[string]bool)
+	if !input.Resend {
+		list, err := deps.DeliveryStore.ListDeliveries(ctx, t.ID)
+		if err != nil {
+			return result, err
+		}
+		for _, d := range list {
+			delivered[d.MemberID] = true
+		}
+	}
+	docs, err := deps.Render(ctx, t.ID, input.MemberID)
+	if err != nil {
+		return result, err
+	}
+
+	var errs []error
+	for _, doc := range docs {
+		if delivered[doc.MemberID] {
+			result.AlreadySent++
+			continue
+		}
+		to := termReportRecipient(ctx, doc.MemberID, deps)
+		if to == "" {
+			result.NoAddress = append(result.NoAddress, doc.MemberName)
+			continue
+		}
+		if err := sendTermReport(ctx, t, doc, to, deps); err != nil {
+			slog.WarnContext(ctx, "term_event", "event", "term_report_failed", "term_id", t.ID, "member_id", doc.MemberID, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		delivery := term.ReportDelivery{TermID: t.ID, MemberID: doc.MemberID, Recipient: to, SentBy: input.SentBy, SentAt: deps.Now()}
+		if err := delivery.Validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := deps.DeliveryStore.SaveDelivery(ctx, delivery); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result.Sent++
+	}
+
+	slog.InfoContext(ctx, "term_event", "event", "term_reports_sent", "term_id", t.ID, "sent", result.Sent,
+		"already_sent", result.AlreadySent, "no_address", len(result.NoAddress), "sent_by", input.SentBy)
+	return result, errors.Join(errs...)
+}
+
+// ExecuteSendDueTermReports emails the reports of every term that has just finished. It runs as
+// an opt-in scheduled job; terms are due for ReportEmailWindowDays after they end.
+// PRE: deps are complete
+// POST: Every due term's undelivered reports are sent; failures are joined
+func ExecuteSendDueTermReports(ctx context.Context, deps SendTermReportsDeps) (SendTermReportsResult, error) {
+	var total SendTermReportsResult
+	terms, err := deps.TermStore.List(ctx)
+	if err != nil {
+		return total, err
+	}
+	now := deps.Now()
+	var errs []error
+	for _, t := range terms {
+		if !t.ReportsDue(now) {
+			continue
+		}
+		result, err := ExecuteSendTermReports(ctx, SendTermReportsInput{TermID: t.ID}, deps)
+		total.Sent += result.Sent
+		total.AlreadySent += result.AlreadySent
+		total.NoAddress = append(total.NoAddress, result.NoAddress...)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return total, errors.Join(errs...)
+}
+
+// termReportRecipient returns the guardian email from the kid's latest waiver, falling back
+// to the member's address; "" when there is neither.
+func termReportRecipient(ctx context.Context, memberID string, deps SendTermReportsDeps) string {
+	if deps.WaiverStore != nil {
+		if w, err := deps.WaiverStore.GetByMemberID(ctx, memberID); err == nil && w.Guardian.Email != "" {
+			return w.Guardian.Email
+		}
+	}
+	m, err := deps.MemberStore.GetByID(ctx, memberID)
+	if err != nil {
+		return ""
+	}
+	return m.Email
+}
+
+// sendTermReport emails one report with its PDF attached.
+func sendTermReport(ctx context.Context, t term.Term, doc TermReportDocument, to string, deps SendTermReportsDeps) error {
+	body := fmt.Sprintf(`<p>Kia ora,</p>
+<p>Attached is %s's progress report for %s: their attendance, belt progress, feedback from their coaches and the goals they are working on next term.</p>
+<p>If you would like to talk about it, reply to this email or catch a coach after class.</p>`,
+		html.EscapeString(doc.MemberName), html.EscapeString(t.Name))
+
+	_, err := deps.EmailSender.Send(ctx, emailAdapter.SendRequest{
+		To:      []string{to},
+		From:    deps.FromAddress,
+		Subject: fmt.Sprintf("%s's %s progress report", doc.MemberName, t.Name),
+		HTML:    body,
+		ReplyTo: deps.ReplyTo,
+		Attachments: []emailAdapter.Attachment{{
+			Filename:    term.ReportFilename(t.Name, doc.MemberName),
+			ContentType: "application/pdf",
+			Content:     doc.PDF,
+		}},
+	})
+	return err
+}
@@ -0,0 +1,147 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/term"
+	"workshop/internal/domain/waiver"
+)
+
+type mockTermReportStore struct {
+	terms      []term.Term
+	deliveries []term.ReportDelivery
+}
+
+// GetByID returns a term by ID.
+// PRE: id is non-empty
+// POST: Returns the term or an error
+func (m *mockTermReportStore) GetByID(_ context.Context, id string) (term.Term, error) {
+	for _, t := range m.terms {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return term.Term{}, errors.New("term not found")
+}
+
+// List returns all terms.
+// PRE: none
+// POST: Returns the terms
+func (m *mockTermReportStore) List(_ context.Context) ([]term.Term, error) {
+	return m.terms, nil
+}
+
+// SaveDelivery records a delivery.
+// PRE: value is valid
+// POST: The delivery is appended
+func (m *mockTermReportStore) SaveDelivery(_ context.Context, value term.ReportDelivery) error {
+	m.deliveries = append(m.deliveries, value)
+	return nil
+}
+
+// ListDeliveries returns a term's deliveries.
+// PRE: termID is non-empty
+// POST: Returns the term's deliveries
+func (m *mockTermReportStore) ListDeliveries(_ context.Context, termID string) ([]term.ReportDelivery, error) {
+	var result []term.ReportDelivery
+	for _, d := range m.deliveries {
+		if d.TermID == termID {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+type mockTermReportPeople struct {
+	members map[string]member.Member
+	waivers map[string]waiver.Waiver
+}
+
+// GetByID returns a member by ID.
+// PRE: id is non-empty
+// POST: Returns the member or an error
+func (m *mockTermReportPeople) GetByID(_ context.Context, id string) (member.Member, error) {
+	if mem, ok := m.members[id]; ok {
+		return mem, nil
+	}
+	return member.Member{}, errors.New("member not found")
+}
+
+// GetByMemberID returns a member's latest waiver.
+// PRE: memberID is non-empty
+// POST: Returns the waiver or an error
+func (m *mockTermReportPeople) GetByMemberID(_ context.Context, memberID string) (waiver.Waiver, error) {
+	if w, ok := m.waivers[memberID]; ok {
+		return w, nil
+	}
+	return waiver.Waiver{}, errors.New("waiver not found")
+}
+
+// TestExecuteSendTermReports verifies reports go to the guardian, fall back to the member's
+// address, go out once per term, and are sent for terms that have just ended.
+func TestExecuteSendTermReports(t *testing.T) {
+	store := &mockTermReportStore{terms: []term.Term{
+		{ID: "t1", Name: "Term 1 2026", StartDate: time.Date(2026, 1, 27, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC)},
+	}}
+	people := &mockTermReportPeople{
+		members: map[string]member.Member{
+			"k1": {ID: "k1", Name: "Aroha", Email: "aroha@test.com"},
+			"k2": {ID: "k2", Name: "Nikau", Email: "nikau@test.com"},
+			"k3": {ID: "k3", Name: "Tui"},
+		},
+		waivers: map[string]waiver.Waiver{"k1": {MemberID: "k1", Guardian: waiver.Guardian{Name: "Mere", Email: "mere@test.com"}}},
+	}
+	sender := newMockEmailSender()
+	now := time.Date(2026, 4, 12, 9, 0, 0, 0, time.UTC)
+	deps := SendTermReportsDeps{
+		TermStore:     store,
+		DeliveryStore: store,
+		MemberStore:   people,
+		WaiverStore:   people,
+		Render: func(_ context.Context, termID, memberID string) ([]TermReportDocument, error) {
+			var docs []TermReportDocument
+			for _, id := range []string{"k1", "k2", "k3"} {
+				if memberID == "" || memberID == id {
+					docs = append(docs, TermReportDocument{MemberID: id, MemberName: people.members[id].Name, PDF: []byte("%PDF-1.4")})
+				}
+			}
+			return docs, nil
+		},
+		EmailSender: sender,
+		Now:         func() time.Time { return now },
+	}
+	ctx := context.Background()
+
+	result, err := ExecuteSendTermReports(ctx, SendTermReportsInput{TermID: "t1", MemberID: "k1", SentBy: "admin-1"}, deps)
+	if err != nil || result.Sent != 1 {
+		t.Fatalf("send one = %+v, %v", result, err)
+	}
+	req := sender.sentReqs[0]
+	if req.To[0] != "mere@test.com" || len(req.Attachments) != 1 || req.Attachments[0].ContentType != "application/pdf" ||
+		req.Attachments[0].Filename != "term-1-2026-aroha-report.pdf" {
+		t.Errorf("email = %+v, want the PDF sent to the guardian", req)
+	}
+	if store.deliveries[0].SentBy != "admin-1" || store.deliveries[0].Recipient != "mere@test.com" {
+		t.Errorf("delivery = %+v", store.deliveries[0])
+	}
+
+	result, err = ExecuteSendDueTermReports(ctx, deps)
+	if err != nil || result.Sent != 1 || result.AlreadySent != 1 || len(result.NoAddress) != 1 || result.NoAddress[0] != "Tui" {
+		t.Fatalf("end-of-term run = %+v, %v; want Nikau sent, Aroha skipped and Tui unaddressable", result, err)
+	}
+	if to := sender.sentReqs[1].To[0]; to != "nikau@test.com" {
+		t.Errorf("fallback recipient = %q, want the member's address", to)
+	}
+
+	if result, _ := ExecuteSendTermReports(ctx, SendTermReportsInput{TermID: "t1", MemberID: "k1", Resend: true}, deps); result.Sent != 1 {
+		t.Errorf("resend = %+v, want the report sent again", result)
+	}
+	now = now.AddDate(0, 1, 0)
+	if result, _ := ExecuteSendDueTermReports(ctx, deps); result.Sent+result.AlreadySent != 0 {
+		t.Errorf("run after the window = %+v, want nothing", result)
+	}
+}
@@ -0,0 +1,139 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/holiday"
+	"workshop/internal/domain/term"
+)
+
+// ErrInvalidRollover wraps a validation failure in the confirmed term, holidays or thresholds.
+var ErrInvalidRollover = errors.New("term rollover")
+
+// RolloverTermStore defines the term store interface needed for a term rollover.
+type RolloverTermStore interface {
+	List(ctx context.Context) ([]term.Term, error)
+	Save(ctx context.Context, value term.Term) error
+}
+
+// RolloverHolidayStore defines the holiday store interface needed for a term rollover.
+type RolloverHolidayStore interface {
+	Save(ctx context.Context, value holiday.Holiday) error
+}
+
+// RolloverStore defines the threshold and snapshot store interface needed for a term rollover.
+type RolloverStore interface {
+	SaveThresholds(ctx context.Context, termID string, values []term.Threshold) error
+	GetSnapshot(ctx context.Context, termID string) (term.ReadinessSnapshot, error)
+	SaveSnapshot(ctx context.Context, value term.ReadinessSnapshot) error
+}
+
+// TermRolloverInput carries the term the admin confirmed in the rollover wizard.
+type TermRolloverInput struct {
+	Term       term.Term         // ID is generated
+	Holidays   []holiday.Holiday // IDs are generated
+	Thresholds []term.Threshold  // TermID is set to the new term
+	ActorID    string            // AccountID of the admin
+}
+
+// TermRolloverDeps holds dependencies for TermRollover.
+type TermRolloverDeps struct {
+	TermStore     RolloverTermStore
+	HolidayStore  RolloverHolidayStore
+	RolloverStore RolloverStore
+	Readiness     func(ctx context.Context, termID string) ([]term.ReadinessEntry, error) // live kids readiness for a term
+	GenerateID    func() string
+	Now           func() time.Time
+}
+
+// TermRolloverResult reports what the rollover created.
+type TermRolloverResult struct {
+	Term           term.Term
+	Holidays       []holiday.Holiday
+	Thresholds     []term.Threshold
+	ArchivedTermID string // previous term whose readiness was archived; empty when none was
+}
+
+// ExecuteTermRollover creates the next term with its holidays and kids attendance thresholds,
+// first archiving the readiness of the term before it so historical eligibility survives.
+// Everything is validated before anything is written.
+// PRE: deps are non-nil; input.ActorID is an admin
+// POST: The term, holidays and thresholds are saved; the previous term has a readiness
+// snapshot unless it already had one
+func ExecuteTermRollover(ctx context.Context, input TermRolloverInput, deps TermRolloverDeps) (TermRolloverResult, error) {
+	next := input.Term
+	next.ID = deps.GenerateID()
+	next.Name = strings.TrimSpace(next.Name)
+	if err := next.Validate(); err != nil {
+		return TermRolloverResult{}, fmt.Errorf("%w: %w", ErrInvalidRollover, err)
+	}
+	terms, err := deps.TermStore.List(ctx)
+	if err != nil {
+		return TermRolloverResult{}, err
+	}
+	var prev *term.Term
+	for i, t := range terms {
+		if next.Overlaps(t) {
+			return TermRolloverResult{}, term.ErrOverlap
+		}
+		if t.EndDate.Before(next.StartDate) && (prev == nil || t.EndDate.After(prev.EndDate)) {
+			prev = &terms[i]
+		}
+	}
+
+	holidays := make([]holiday.Holiday, 0, len(input.Holidays))
+	for _, h := range input.Holidays {
+		h.ID = deps.GenerateID()
+		h.Name = strings.TrimSpace(h.Name)
+		if err := h.Validate(); err != nil {
+			return TermRolloverResult{}, fmt.Errorf("%w: %w", ErrInvalidRollover, err)
+		}
+		holidays = append(holidays, h)
+	}
+	thresholds := make([]term.Threshold, 0, len(input.Thresholds))
+	for _, th := range input.Thresholds {
+		th.TermID = next.ID
+		if err := th.Validate(); err != nil {
+			return TermRolloverResult{}, fmt.Errorf("%w: %w", ErrInvalidRollover, err)
+		}
+		thresholds = append(thresholds, th)
+	}
+
+	result := TermRolloverResult{Term: next, Holidays: holidays, Thresholds: thresholds}
+	if prev != nil {
+		if _, err := deps.RolloverStore.GetSnapshot(ctx, prev.ID); err != nil {
+			entries, err := deps.Readiness(ctx, prev.ID)
+			if err != nil {
+				return TermRolloverResult{}, err
+			}
+			snap := term.ReadinessSnapshot{TermID: prev.ID, TermName: prev.Name, Entries: entries, ArchivedBy: input.ActorID, ArchivedAt: deps.Now()}
+			if err := snap.Validate(); err != nil {
+				return TermRolloverResult{}, err
+			}
+			if err := deps.RolloverStore.SaveSnapshot(ctx, snap); err != nil {
+				return TermRolloverResult{}, err
+			}
+			result.ArchivedTermID = prev.ID
+		}
+	}
+
+	if err := deps.TermStore.Save(ctx, next); err != nil {
+		return TermRolloverResult{}, err
+	}
+	for _, h := range holidays {
+		if err := deps.HolidayStore.Save(ctx, h); err != nil {
+			return TermRolloverResult{}, err
+		}
+	}
+	if err := deps.RolloverStore.SaveThresholds(ctx, next.ID, thresholds); err != nil {
+		return TermRolloverResult{}, err
+	}
+	slog.InfoContext(ctx, "term_event", "event", "rolled_over", "term_id", next.ID, "name", next.Name,
+		"holidays", len(holidays), "thresholds", len(thresholds), "archived_term_id", result.ArchivedTermID, "actor_id", input.ActorID)
+	return result, nil
+}
@@ -0,0 +1,149 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/holiday"
+	"workshop/internal/domain/term"
+)
+
+// --- Mock stores for term rollover tests ---
+
+type mockRolloverTermStore struct {
+	terms []term.Term
+}
+
+// List returns all terms.
+// PRE: none
+// POST: Returns the terms
+func (m *mockRolloverTermStore) List(_ context.Context) ([]term.Term, error) {
+	return m.terms, nil
+}
+
+// Save stores the term.
+// PRE: value is valid
+// POST: The term is stored
+func (m *mockRolloverTermStore) Save(_ context.Context, value term.Term) error {
+	m.terms = append(m.terms, value)
+	return nil
+}
+
+type mockRolloverHolidayStore struct {
+	holidays []holiday.Holiday
+}
+
+// Save stores the holiday.
+// PRE: value is valid
+// POST: The holiday is stored
+func (m *mockRolloverHolidayStore) Save(_ context.Context, value holiday.Holiday) error {
+	m.holidays = append(m.holidays, value)
+	return nil
+}
+
+type mockRolloverStore struct {
+	thresholds map[string][]term.Threshold
+	snapshots  map[string]term.ReadinessSnapshot
+}
+
+// SaveThresholds replaces the term's thresholds.
+// PRE: termID is non-empty
+// POST: The thresholds are stored
+func (m *mockRolloverStore) SaveThresholds(_ context.Context, termID string, values []term.Threshold) error {
+	m.thresholds[termID] = values
+	return nil
+}
+
+// GetSnapshot returns the term's archived readiness.
+// PRE: termID is non-empty
+// POST: Returns the snapshot or an error
+func (m *mockRolloverStore) GetSnapshot(_ context.Context, termID string) (term.ReadinessSnapshot, error) {
+	s, ok := m.snapshots[termID]
+	if !ok {
+		return term.ReadinessSnapshot{}, errors.New("not found")
+	}
+	return s, nil
+}
+
+// SaveSnapshot stores the snapshot.
+// PRE: value is valid
+// POST: The snapshot is stored
+func (m *mockRolloverStore) SaveSnapshot(_ context.Context, value term.ReadinessSnapshot) error {
+	m.snapshots[value.TermID] = value
+	return nil
+}
+
+// TestExecuteTermRollover verifies the new term, its holidays and thresholds are saved and
+// the previous term's readiness archived once, and that bad input writes nothing.
+func TestExecuteTermRollover(t *testing.T) {
+	ctx := context.Background()
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 0, 0, 0, 0, time.UTC) }
+	n := 0
+	terms := &mockRolloverTermStore{terms: []term.Term{{ID: "t1", Name: "Term 1 2026", StartDate: day(time.February, 2), EndDate: day(time.April, 2)}}}
+	holidays := &mockRolloverHolidayStore{}
+	rollover := &mockRolloverStore{thresholds: map[string][]term.Threshold{}, snapshots: map[string]term.ReadinessSnapshot{}}
+	readinessCalls := 0
+	deps := TermRolloverDeps{
+		TermStore:     terms,
+		HolidayStore:  holidays,
+		RolloverStore: rollover,
+		Readiness: func(_ context.Context, termID string) ([]term.ReadinessEntry, error) {
+			readinessCalls++
+			return []term.ReadinessEntry{{MemberID: "kid1", TargetBelt: "grey", AttendancePct: 85, ThresholdPct: 80, Eligible: true}}, nil
+		},
+		GenerateID: func() string { n++; return fmt.Sprintf("id-%d", n) },
+		Now:        func() time.Time { return day(time.April, 10) },
+	}
+	input := TermRolloverInput{
+		Term:       term.Term{Name: " Term 2 2026 ", StartDate: day(time.April, 20), EndDate: day(time.June, 26)},
+		Holidays:   []holiday.Holiday{{Name: "King's Birthday", StartDate: day(time.June, 1), EndDate: day(time.June, 1)}},
+		Thresholds: []term.Threshold{{Belt: "grey", AttendancePct: 70}},
+		ActorID:    "admin-1",
+	}
+
+	t.Run("invalid threshold writes nothing", func(t *testing.T) {
+		bad := input
+		bad.Thresholds = []term.Threshold{{Belt: "grey", AttendancePct: 150}}
+		if _, err := ExecuteTermRollover(ctx, bad, deps); !errors.Is(err, term.ErrInvalidThreshold) {
+			t.Fatalf("err = %v, want ErrInvalidThreshold", err)
+		}
+		if len(terms.terms) != 1 || len(rollover.snapshots) != 0 {
+			t.Errorf("expected nothing written, got %d terms and %d snapshots", len(terms.terms), len(rollover.snapshots))
+		}
+	})
+
+	res, err := ExecuteTermRollover(ctx, input, deps)
+	if err != nil {
+		t.Fatalf("ExecuteTermRollover: %v", err)
+	}
+	if res.Term.Name != "Term 2 2026" || res.ArchivedTermID != "t1" || len(terms.terms) != 2 || len(holidays.holidays) != 1 {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if got := rollover.thresholds[res.Term.ID]; len(got) != 1 || got[0].TermID != res.Term.ID || got[0].AttendancePct != 70 {
+		t.Errorf("expected the grey threshold on the new term, got %+v", got)
+	}
+	if snap := rollover.snapshots["t1"]; len(snap.Entries) != 1 || snap.TermName != "Term 1 2026" || snap.ArchivedBy != "admin-1" {
+		t.Errorf("expected Term 1's readiness archived, got %+v", snap)
+	}
+
+	t.Run("overlapping term is refused", func(t *testing.T) {
+		again := input
+		again.Term.StartDate = day(time.June, 1)
+		again.Term.EndDate = day(time.August, 1)
+		if _, err := ExecuteTermRollover(ctx, again, deps); !errors.Is(err, term.ErrOverlap) {
+			t.Errorf("err = %v, want ErrOverlap", err)
+		}
+	})
+
+	t.Run("archived terms are not archived again", func(t *testing.T) {
+		third := TermRolloverInput{Term: term.Term{Name: "Term 3 2026", StartDate: day(time.July, 20), EndDate: day(time.September, 25)}}
+		rollover.snapshots[res.Term.ID] = term.ReadinessSnapshot{TermID: res.Term.ID}
+		res, err := ExecuteTermRollover(ctx, third, deps)
+		if err != nil || res.ArchivedTermID != "" || readinessCalls != 1 {
+			t.Errorf("expected no second archive, got %+v, %v, %d readiness calls", res, err, readinessCalls)
+		}
+	})
+}
@@ -23,12 +23,13 @@ type UndoCheckInInput struct {
 // UndoCheckInDeps holds dependencies for UndoCheckIn.
 type UndoCheckInDeps struct {
 	AttendanceStore UndoCheckInStore
-	Now             func() time.Time // injectable for testing
+	PackDeps        *UseClassPackDeps // optional: nil gives back no class pack
+	Now             func() time.Time  // injectable for testing
 }
 
 // ExecuteUndoCheckIn removes an attendance record (un-check-in).
 // PRE: AttendanceID is non-empty and refers to an existing record
-// POST: Attendance record is deleted
+// POST: Attendance record is deleted and any class pack class it used is given back
 // INVARIANT: Only today's check-ins can be undone (#38)
 func ExecuteUndoCheckIn(ctx context.Context, input UndoCheckInInput, deps UndoCheckInDeps) error {
 	if input.AttendanceID == "" {
@@ -54,7 +55,8 @@ func ExecuteUndoCheckIn(ctx context.Context, input UndoCheckInInput, deps UndoCh
 	if err := deps.AttendanceStore.Delete(ctx, input.AttendanceID); err != nil {
 		return err
 	}
+	refundClassPack(ctx, input.AttendanceID, deps.PackDeps)
 
-	slog.Info("checkin_event", "event", "member_unchecked_in", "attendance_id", input.AttendanceID, "member_id", a.MemberID)
+	slog.InfoContext(ctx, "checkin_event", "event", "member_unchecked_in", "attendance_id", input.AttendanceID, "member_id", a.MemberID)
 	return nil
 }
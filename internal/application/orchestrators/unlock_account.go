@@ -0,0 +1,46 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"workshop/internal/domain/account"
+)
+
+// AccountStoreForUnlock defines the store interface needed by UnlockAccount.
+type AccountStoreForUnlock interface {
+	GetByID(ctx context.Context, id string) (account.Account, error)
+	Save(ctx context.Context, a account.Account) error
+}
+
+// UnlockAccountInput carries input for the unlock account orchestrator.
+type UnlockAccountInput struct {
+	AccountID  string
+	UnlockedBy string // AccountID of the admin
+}
+
+// UnlockAccountDeps holds dependencies for UnlockAccount.
+type UnlockAccountDeps struct {
+	AccountStore AccountStoreForUnlock
+}
+
+// ExecuteUnlockAccount clears a lockout so the member can try again immediately.
+// PRE: AccountID and UnlockedBy are non-empty
+// POST: FailedLogins is 0 and LockedUntil is zero; the unlock is logged
+func ExecuteUnlockAccount(ctx context.Context, input UnlockAccountInput, deps UnlockAccountDeps) (account.Account, error) {
+	if input.AccountID == "" {
+		return account.Account{}, errors.New("account ID is required")
+	}
+	acct, err := deps.AccountStore.GetByID(ctx, input.AccountID)
+	if err != nil {
+		return account.Account{}, err
+	}
+	wasLocked := acct.IsLocked()
+	acct.ResetFailedLogins()
+	if err := deps.AccountStore.Save(ctx, acct); err != nil {
+		return account.Account{}, err
+	}
+	slog.WarnContext(ctx, "security_event", "event", "account_unlocked", "account_id", acct.ID, "email", acct.Email, "by", input.UnlockedBy, "was_locked", wasLocked)
+	return acct, nil
+}
@@ -0,0 +1,53 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/account"
+)
+
+type mockUnlockAccountStore struct {
+	accounts map[string]account.Account
+}
+
+// GetByID returns the stored account.
+// PRE: id is non-empty
+// POST: Returns the account or an error
+func (m *mockUnlockAccountStore) GetByID(_ context.Context, id string) (account.Account, error) {
+	a, ok := m.accounts[id]
+	if !ok {
+		return account.Account{}, errors.New("not found")
+	}
+	return a, nil
+}
+
+// Save stores the account.
+// PRE: a is valid
+// POST: a is stored
+func (m *mockUnlockAccountStore) Save(_ context.Context, a account.Account) error {
+	m.accounts[a.ID] = a
+	return nil
+}
+
+// TestUnlockAccount tests that an admin unlock clears the failure count and lock.
+func TestUnlockAccount(t *testing.T) {
+	store := &mockUnlockAccountStore{accounts: map[string]account.Account{
+		"a1": {ID: "a1", Email: "yuki@example.com", FailedLogins: account.MaxFailedLogins, LockedUntil: time.Now().Add(account.LockoutDuration)},
+	}}
+	deps := UnlockAccountDeps{AccountStore: store}
+
+	acct, err := ExecuteUnlockAccount(context.Background(), UnlockAccountInput{AccountID: "a1", UnlockedBy: "admin-1"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if acct.IsLocked() || store.accounts["a1"].FailedLogins != 0 {
+		t.Errorf("expected account unlocked, got %+v", store.accounts["a1"])
+	}
+
+	if _, err := ExecuteUnlockAccount(context.Background(), UnlockAccountInput{AccountID: "missing", UnlockedBy: "admin-1"}, deps); err == nil {
+		t.Error("expected error for unknown account")
+	}
+}
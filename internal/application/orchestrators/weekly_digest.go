@@ -0,0 +1,167 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	emailDomain "workshop/internal/domain/email"
+	"workshop/internal/domain/member"
+)
+
+// WeeklyDigestSendHour is the local hour on Monday from which last week's digests go out.
+const WeeklyDigestSendHour = 7
+
+// WeeklyDigest is one member's training over a Monday-to-Sunday week.
+type WeeklyDigest struct {
+	Classes       int
+	MatHours      float64
+	Streak        int // consecutive weeks trained, ending with this one
+	Belt          string
+	NextBelt      string  // empty at the top of the progression
+	ProgressPct   float64 // toward NextBelt; 0 when no requirement is configured
+	RequiredHours float64 // for NextBelt; 0 when no requirement is configured
+	Topics        []WeeklyDigestClassTopics
+}
+
+// WeeklyDigestClassTopics is what one of the member's classes is working on.
+type WeeklyDigestClassTopics struct {
+	ClassTypeName string
+	Topics        []string // running topics first, then upcoming ones
+}
+
+// WeeklyDigestPreferenceStore lists who opted in to the digest and records each week sent.
+type WeeklyDigestPreferenceStore interface {
+	ListDigestSubscribers(ctx context.Context) ([]string, error)
+	MarkDigestSent(ctx context.Context, memberID, weekStart string, sentAt time.Time) (bool, error)
+}
+
+// WeeklyDigestMemberStore resolves a subscriber's membership.
+type WeeklyDigestMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+}
+
+// SendWeeklyDigestsDeps holds dependencies for SendWeeklyDigests.
+type SendWeeklyDigestsDeps struct {
+	PreferenceStore WeeklyDigestPreferenceStore
+	MemberStore     WeeklyDigestMemberStore
+	// Summarise builds a member's digest for the week starting at weekStart (Monday, local midnight).
+	Summarise     func(ctx context.Context, memberID string, weekStart time.Time) (WeeklyDigest, error)
+	NextClassDate func(ctx context.Context, program string) string // optional: nil leaves {{NextClassDate}} blank
+	Send          SendTemplatedEmailDeps
+	Now           func() time.Time
+}
+
+// SendWeeklyDigestsResult summarises one run.
+type SendWeeklyDigestsResult struct {
+	WeekStart string // YYYY-MM-DD, the Monday of the latest week due
+	Sent      int    // digests sent
+	Skipped   int    // digests not sent: no address, suppressed address or unsubscribed
+}
+
+// ExecuteSendWeeklyDigests emails each active member who opted in to the digest a summary of
+// last week's training, once WeeklyDigestSendHour has passed on Monday. Each member gets a
+// week's digest at most once: the week is recorded before the email goes out, so a failed
+// send is logged rather than retried.
+// PRE: deps are complete
+// POST: Every subscriber not yet sent last week's digest is sent it or skipped; failures are joined
+func ExecuteSendWeeklyDigests(ctx context.Context, deps SendWeeklyDigestsDeps) (SendWeeklyDigestsResult, error) {
+	now := deps.Now()
+	y, m, d := now.Date()
+	monday := time.Date(y, m, d-(int(now.Weekday())+6)%7, 0, 0, 0, 0, now.Location())
+	if now.Before(monday.Add(WeeklyDigestSendHour * time.Hour)) {
+		monday = monday.AddDate(0, 0, -7) // the week before last is the latest one due
+	}
+	weekStart := monday.AddDate(0, 0, -7)
+	result := SendWeeklyDigestsResult{WeekStart: weekStart.Format("2006-01-02")}
+
+	subscribers, err := deps.PreferenceStore.ListDigestSubscribers(ctx)
+	if err != nil {
+		return result, err
+	}
+	var errs []error
+	for _, memberID := range subscribers {
+		mem, err := deps.MemberStore.GetByID(ctx, memberID)
+		if err != nil || mem.Status != member.StatusActive {
+			continue
+		}
+		first, err := deps.PreferenceStore.MarkDigestSent(ctx, memberID, result.WeekStart, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !first {
+			continue
+		}
+		digest, err := deps.Summarise(ctx, memberID, weekStart)
+		if err != nil {
+			slog.WarnContext(ctx, "email_event", "event", "weekly_digest_failed", "member_id", memberID, "week_start", result.WeekStart, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+
+		vars := digest.vars()
+		if deps.NextClassDate != nil {
+			vars[emailDomain.VarNextClassDate] = deps.NextClassDate(ctx, mem.Program)
+		}
+		res, err := ExecuteSendTemplatedEmail(ctx, SendTemplatedEmailInput{
+			TemplateKey: emailDomain.TemplateWeeklyDigest,
+			MemberID:    memberID,
+			Vars:        vars,
+		}, deps.Send)
+		if err != nil {
+			slog.WarnContext(ctx, "email_event", "event", "weekly_digest_failed", "member_id", memberID, "week_start", result.WeekStart, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		if res.Skipped != "" {
+			result.Skipped++
+		} else {
+			result.Sent++
+		}
+	}
+
+	if result.Sent+result.Skipped > 0 {
+		slog.InfoContext(ctx, "email_event", "event", "weekly_digests_sent", "week_start", result.WeekStart, "sent", result.Sent, "skipped", result.Skipped)
+	}
+	return result, errors.Join(errs...)
+}
+
+// vars fills in the digest's merge variables.
+func (d WeeklyDigest) vars() emailDomain.Vars {
+	return emailDomain.Vars{
+		emailDomain.VarBelt:           d.Belt,
+		emailDomain.VarWeekClasses:    strconv.Itoa(d.Classes),
+		emailDomain.VarWeekHours:      strconv.FormatFloat(d.MatHours, 'f', 1, 64),
+		emailDomain.VarStreak:         strconv.Itoa(d.Streak),
+		emailDomain.VarBeltProgress:   d.beltProgress(),
+		emailDomain.VarUpcomingTopics: d.upcomingTopics(),
+	}
+}
+
+// beltProgress describes progress toward the next belt in a sentence.
+func (d WeeklyDigest) beltProgress() string {
+	switch {
+	case d.NextBelt == "":
+		return "You're at the top of the belt progression."
+	case d.RequiredHours == 0:
+		return fmt.Sprintf("You're working toward %s belt.", d.NextBelt)
+	}
+	return fmt.Sprintf("You're %.0f%% of the way to %s belt.", d.ProgressPct, d.NextBelt)
+}
+
+// upcomingTopics lists each class's topics, e.g. "Fundamentals: Closed guard, then Half guard".
+func (d WeeklyDigest) upcomingTopics() string {
+	if len(d.Topics) == 0 {
+		return "nothing announced yet"
+	}
+	parts := make([]string, 0, len(d.Topics))
+	for _, c := range d.Topics {
+		parts = append(parts, c.ClassTypeName+": "+strings.Join(c.Topics, ", then "))
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,102 @@
+package orchestrators
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	emailDomain "workshop/internal/domain/email"
+	"workshop/internal/domain/member"
+)
+
+type mockDigestPreferenceStore struct {
+	subscribers []string
+	sent        map[string]bool // member ID + week start
+}
+
+// ListDigestSubscribers implements WeeklyDigestPreferenceStore.
+// PRE: none
+// POST: returns the subscribers
+func (m *mockDigestPreferenceStore) ListDigestSubscribers(_ context.Context) ([]string, error) {
+	return m.subscribers, nil
+}
+
+// MarkDigestSent implements WeeklyDigestPreferenceStore.
+// PRE: memberID and weekStart are non-empty
+// POST: returns true the first time a member's week is marked
+func (m *mockDigestPreferenceStore) MarkDigestSent(_ context.Context, memberID, weekStart string, _ time.Time) (bool, error) {
+	key := memberID + "/" + weekStart
+	if m.sent[key] {
+		return false, nil
+	}
+	m.sent[key] = true
+	return true, nil
+}
+
+// TestSendWeeklyDigests tests that last week's digest goes to active subscribers once, from
+// Monday's send hour, rendered with their week.
+func TestSendWeeklyDigests(t *testing.T) {
+	emailStore := newMockEmailStore()
+	sender := newMockEmailSender()
+	prefs := &mockDigestPreferenceStore{subscribers: []string{"member-1", "member-2", "member-3"}, sent: map[string]bool{}}
+	now := time.Date(2026, 3, 9, 6, 30, 0, 0, time.UTC) // Monday, before the send hour
+	var weeks []string
+	deps := SendWeeklyDigestsDeps{
+		PreferenceStore: prefs,
+		MemberStore: &mockReminderMembers{members: map[string]member.Member{
+			"member-1": {ID: "member-1", Program: "adults", Status: member.StatusActive},
+			"member-2": {ID: "member-2", Program: "adults", Status: member.StatusFrozen},
+			"member-3": {ID: "member-3", Program: "kids", Status: member.StatusActive},
+		}},
+		Summarise: func(_ context.Context, memberID string, weekStart time.Time) (WeeklyDigest, error) {
+			weeks = append(weeks, weekStart.Format("2006-01-02"))
+			return WeeklyDigest{
+				Classes: 3, MatHours: 4.5, Streak: 6, Belt: "white", NextBelt: "blue", ProgressPct: 62.4, RequiredHours: 100,
+				Topics: []WeeklyDigestClassTopics{{ClassTypeName: "Fundamentals", Topics: []string{"Closed guard", "Half guard"}}},
+			}, nil
+		},
+		NextClassDate: func(_ context.Context, program string) string { return "Tuesday (" + program + ")" },
+		Send:          newTemplatedEmailDeps(emailStore, sender),
+		Now:           func() time.Time { return now },
+	}
+	// Two weeks ago was already sent, so nothing is due before the send hour
+	prefs.sent["member-1/2026-02-23"], prefs.sent["member-3/2026-02-23"] = true, true
+
+	got, err := ExecuteSendWeeklyDigests(context.Background(), deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.WeekStart != "2026-02-23" || got.Sent != 0 || sender.sent != 0 {
+		t.Fatalf("before the send hour: got %+v with %d sends", got, sender.sent)
+	}
+
+	now = now.Add(time.Hour)
+	got, err = ExecuteSendWeeklyDigests(context.Background(), deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (SendWeeklyDigestsResult{WeekStart: "2026-03-02", Sent: 2}); got != want {
+		t.Fatalf("result = %+v, want %+v", got, want)
+	}
+	if len(weeks) != 2 || weeks[0] != "2026-03-02" {
+		t.Errorf("summarised weeks = %v, want last week for each active subscriber", weeks)
+	}
+	html := sender.sentReqs[0].HTML
+	for _, want := range []string{"Classes: 3", "Mat hours: 4.5", "Weeks in a row: 6", "62% of the way to blue belt", "Fundamentals: Closed guard, then Half guard", "Tuesday (adults)"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("digest body missing %q: %s", want, html)
+		}
+	}
+	for _, e := range emailStore.emails {
+		if e.Category != emailDomain.CategoryDigest || e.TemplateKey != emailDomain.TemplateWeeklyDigest {
+			t.Errorf("recorded email = %+v", e)
+		}
+	}
+
+	// A later run the same week sends nothing more
+	now = now.AddDate(0, 0, 2)
+	if got, _ := ExecuteSendWeeklyDigests(context.Background(), deps); got.Sent != 0 || sender.sent != 2 {
+		t.Errorf("rerun: got %+v with %d sends, want no more", got, sender.sent)
+	}
+}
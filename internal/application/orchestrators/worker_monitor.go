@@ -0,0 +1,190 @@
+package orchestrators
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"workshop/internal/application/trace"
+)
+
+// WorkerStatus is a point-in-time health snapshot of one background worker.
+type WorkerStatus struct {
+	Name          string
+	Interval      time.Duration
+	StartedAt     time.Time
+	Running       bool // a run is in progress right now
+	Stopped       bool // the worker loop has exited
+	Runs          int
+	Failures      int
+	LastRunAt     time.Time
+	LastSuccessAt time.Time
+	LastDuration  time.Duration
+	LastError     string
+	Healthy       bool
+}
+
+// WorkerMonitor records run outcomes for background workers so admins can see their health.
+// INVARIANT: safe for concurrent use by workers and HTTP handlers.
+type WorkerMonitor struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	workers map[string]*WorkerStatus
+	loops   sync.WaitGroup // worker goroutines started by StartMonitoredWorker or a JobScheduler
+}
+
+// NewWorkerMonitor creates an empty monitor.
+// PRE: now is non-nil
+// POST: Returns a monitor with no registered workers
+func NewWorkerMonitor(now func() time.Time) *WorkerMonitor {
+	return &WorkerMonitor{now: now, workers: make(map[string]*WorkerStatus)}
+}
+
+// Register adds a worker to the monitor, resetting any previous state under the same name.
+// PRE: name is non-empty; interval > 0
+// POST: The worker appears in Snapshot
+func (m *WorkerMonitor) Register(name string, interval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.workers[name] = &WorkerStatus{Name: name, Interval: interval, StartedAt: m.now()}
+}
+
+// Remove drops a worker from the monitor, e.g. when its job is disabled.
+// PRE: none
+// POST: The worker no longer appears in Snapshot
+func (m *WorkerMonitor) Remove(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.workers, name)
+}
+
+// RecordStart marks a run as in progress.
+// PRE: name was registered
+// POST: Running is true
+func (m *WorkerMonitor) RecordStart(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if w, ok := m.workers[name]; ok {
+		w.Running = true
+		w.LastRunAt = m.now()
+	}
+}
+
+// RecordResult records the outcome of the run started by RecordStart.
+// PRE: name was registered
+// POST: Run counters, timings and LastError are updated
+func (m *WorkerMonitor) RecordResult(name string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w, ok := m.workers[name]
+	if !ok {
+		return
+	}
+	now := m.now()
+	w.Running = false
+	w.Runs++
+	w.LastDuration = now.Sub(w.LastRunAt)
+	if err != nil {
+		w.Failures++
+		w.LastError = err.Error()
+		return
+	}
+	w.LastSuccessAt = now
+	w.LastError = ""
+}
+
+// RecordStopped marks the worker loop as exited.
+// PRE: name was registered
+// POST: Stopped is true
+func (m *WorkerMonitor) RecordStopped(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if w, ok := m.workers[name]; ok {
+		w.Stopped = true
+		w.Running = false
+	}
+}
+
+// Snapshot returns every worker's status ordered by name.
+// A worker is healthy while it is running its loop and has succeeded (or started)
+// within the last three intervals.
+// PRE: none
+// POST: Returns copies; callers may not mutate monitor state through them
+func (m *WorkerMonitor) Snapshot() []WorkerStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	out := make([]WorkerStatus, 0, len(m.workers))
+	for _, w := range m.workers {
+		s := *w
+		last := s.LastSuccessAt
+		if last.IsZero() {
+			last = s.StartedAt
+		}
+		s.Healthy = !s.Stopped && now.Sub(last) <= 3*s.Interval
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Wait blocks until every worker started by StartMonitoredWorker or a JobScheduler has exited.
+// A run in progress when stopCh closes is allowed to finish first.
+// PRE: the workers' stopCh has been closed, or will be
+// POST: Returns nil once all loops have exited, or ctx.Err() if ctx ends first
+func (m *WorkerMonitor) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		m.loops.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// StartMonitoredWorker runs fn every interval in a goroutine, reporting each run to monitor.
+// Each run gets its own context bounded by timeout.
+// PRE: monitor, fn and stopCh are non-nil; interval > 0
+// POST: Worker runs until stopCh is closed; monitor.Wait reports when it has exited
+func StartMonitoredWorker(monitor *WorkerMonitor, name string, interval, timeout time.Duration, stopCh <-chan struct{}, fn func(ctx context.Context) error) {
+	monitor.Register(name, interval)
+	monitor.loops.Add(1)
+	go func() {
+		defer monitor.loops.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				// A tick and a stop can be ready together; never start a run once stopping.
+				select {
+				case <-stopCh:
+					monitor.RecordStopped(name)
+					slog.Info("worker_stopped", "worker", name)
+					return
+				default:
+				}
+				// Each run gets its own ID so its log lines and queries can be traced like a request's.
+				ctx, cancel := context.WithTimeout(trace.WithRequestID(context.Background(), trace.NewRequestID()), timeout)
+				monitor.RecordStart(name)
+				err := fn(ctx)
+				monitor.RecordResult(name, err)
+				cancel()
+				if err != nil {
+					slog.ErrorContext(ctx, "worker_run_failed", "worker", name, "error", err.Error())
+				}
+			case <-stopCh:
+				monitor.RecordStopped(name)
+				slog.Info("worker_stopped", "worker", name)
+				return
+			}
+		}
+	}()
+}
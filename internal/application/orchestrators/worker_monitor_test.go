@@ -0,0 +1,79 @@
+package orchestrators
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestWorkerMonitor tests run recording and the health rule.
+func TestWorkerMonitor(t *testing.T) {
+	now := emailFixedTime
+	m := NewWorkerMonitor(func() time.Time { return now })
+	m.Register("scheduled_emails", time.Minute)
+	m.Register("outbox", time.Minute)
+
+	m.RecordStart("scheduled_emails")
+	now = now.Add(2 * time.Second)
+	m.RecordResult("scheduled_emails", errors.New("store unavailable"))
+
+	snap := m.Snapshot()
+	if len(snap) != 2 || snap[0].Name != "outbox" {
+		t.Fatalf("snapshot not ordered by name: %+v", snap)
+	}
+	s := snap[1]
+	if s.Runs != 1 || s.Failures != 1 || s.LastError != "store unavailable" || s.LastDuration != 2*time.Second {
+		t.Errorf("unexpected status: %+v", s)
+	}
+	if !s.Healthy {
+		t.Error("expected healthy within three intervals of start")
+	}
+
+	now = now.Add(5 * time.Minute)
+	if m.Snapshot()[1].Healthy {
+		t.Error("expected unhealthy after three intervals without success")
+	}
+
+	m.RecordStart("scheduled_emails")
+	m.RecordResult("scheduled_emails", nil)
+	if s := m.Snapshot()[1]; !s.Healthy || s.LastError != "" || s.Runs != 2 {
+		t.Errorf("expected recovery after success: %+v", s)
+	}
+
+	m.RecordStopped("outbox")
+	if m.Snapshot()[0].Healthy {
+		t.Error("stopped worker must not be healthy")
+	}
+}
+
+// TestStartMonitoredWorker_Wait verifies Wait lets an in-flight run finish and then returns.
+func TestStartMonitoredWorker_Wait(t *testing.T) {
+	m := NewWorkerMonitor(time.Now)
+	stopCh := make(chan struct{})
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	StartMonitoredWorker(m, "slow", time.Millisecond, time.Second, stopCh, func(ctx context.Context) error {
+		once.Do(func() { close(started) })
+		<-release
+		return nil
+	})
+
+	<-started
+	close(stopCh)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := m.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected Wait to time out while the run is in flight, got %v", err)
+	}
+
+	close(release)
+	if err := m.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if s := m.Snapshot()[0]; !s.Stopped || s.Runs != 1 {
+		t.Errorf("expected one run then stop, got %+v", s)
+	}
+}
@@ -0,0 +1,128 @@
+package projections
+
+import (
+	"context"
+
+	"workshop/internal/domain/clip"
+	domainGrading "workshop/internal/domain/grading"
+	domainMember "workshop/internal/domain/member"
+	"workshop/internal/domain/rotor"
+	"workshop/internal/domain/theme"
+)
+
+// BeltGateMemberStore defines the member store interface needed to resolve a viewer's belt.
+type BeltGateMemberStore interface {
+	GetByAccountID(ctx context.Context, accountID string) (domainMember.Member, error)
+}
+
+// BeltGateGradingRecordStore defines the grading record store interface needed to resolve a viewer's belt.
+type BeltGateGradingRecordStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]domainGrading.Record, error)
+}
+
+// ResolveBeltGateDeps holds dependencies for resolving a viewer's belt gate.
+type ResolveBeltGateDeps struct {
+	MemberStore        BeltGateMemberStore
+	GradingRecordStore BeltGateGradingRecordStore
+}
+
+// BeltGate decides which belt-gated content a viewer may open.
+type BeltGate struct {
+	Staff   bool   // admins and coaches see everything
+	Program string // the viewer's program, which picks the belt progression
+	Belt    string // the viewer's current belt; empty means white
+}
+
+// Allows reports whether the viewer has reached minBelt.
+// PRE: none
+// POST: Returns true for staff, for an empty minBelt, or when the viewer's belt is at or above it
+func (g BeltGate) Allows(minBelt string) bool {
+	return g.Staff || domainGrading.BeltAtLeast(g.Program, g.Belt, minBelt)
+}
+
+// ResolveBeltGate works out the viewer's belt from their latest grading record.
+// PRE: role is the session role; accountID is the session account
+// POST: Staff get an open gate; anyone without a member record is treated as an adult white belt
+func ResolveBeltGate(ctx context.Context, role, accountID string, deps ResolveBeltGateDeps) BeltGate {
+	if role == "admin" || role == "coach" {
+		return BeltGate{Staff: true}
+	}
+	gate := BeltGate{Program: domainMember.ProgramAdults}
+	m, err := deps.MemberStore.GetByAccountID(ctx, accountID)
+	if err != nil || m.ID == "" {
+		return gate
+	}
+	gate.Program = m.Program
+	if records, err := deps.GradingRecordStore.ListByMemberID(ctx, m.ID); err == nil {
+		gate.Belt, _ = latestBeltAndStripe(records)
+	}
+	return gate
+}
+
+// GatedTheme is a theme as shown to one viewer.
+type GatedTheme struct {
+	theme.Theme
+	Locked bool
+}
+
+// GatedClip is a clip as shown to one viewer. A locked clip keeps its title but not the
+// video or notes.
+type GatedClip struct {
+	clip.Clip
+	Locked bool
+}
+
+// GateThemes locks the themes the viewer has not reached.
+// PRE: none
+// POST: Returns one entry per theme, in order; locked themes have no description
+func GateThemes(themes []theme.Theme, gate BeltGate) []GatedTheme {
+	result := make([]GatedTheme, 0, len(themes))
+	for _, t := range themes {
+		gated := GatedTheme{Theme: t}
+		if !gate.Allows(t.MinBelt) {
+			gated.Locked = true
+			gated.Description = ""
+		}
+		result = append(result, gated)
+	}
+	return result
+}
+
+// GateClips locks the clips the viewer has not reached. A clip is gated by its own minimum
+// belt and by its theme's; themeMinBelts maps theme ID to the theme's minimum.
+// PRE: none
+// POST: Returns one entry per clip, in order; locked clips have no video or notes
+func GateClips(clips []clip.Clip, themeMinBelts map[string]string, gate BeltGate) []GatedClip {
+	result := make([]GatedClip, 0, len(clips))
+	for _, c := range clips {
+		gated := GatedClip{Clip: c}
+		if !gate.Allows(c.MinBelt) || !gate.Allows(themeMinBelts[c.ThemeID]) {
+			gated.Locked = true
+			gated.YouTubeURL = ""
+			gated.YouTubeID = ""
+			gated.StartSeconds = 0
+			gated.EndSeconds = 0
+			gated.Notes = ""
+		}
+		result = append(result, gated)
+	}
+	return result
+}
+
+// GatedTopic is a rotor topic as shown to one viewer.
+type GatedTopic struct {
+	rotor.Topic
+	Locked bool
+}
+
+// GateTopic locks a topic the viewer has not reached.
+// PRE: none
+// POST: A locked topic keeps its name but not its description
+func GateTopic(t rotor.Topic, gate BeltGate) GatedTopic {
+	gated := GatedTopic{Topic: t}
+	if !gate.Allows(t.MinBelt) {
+		gated.Locked = true
+		gated.Description = ""
+	}
+	return gated
+}
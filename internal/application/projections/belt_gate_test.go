@@ -0,0 +1,88 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/clip"
+	domainGrading "workshop/internal/domain/grading"
+	domainMember "workshop/internal/domain/member"
+	"workshop/internal/domain/theme"
+)
+
+type mockBeltGateMemberStore struct{}
+
+// GetByAccountID returns a kids member for acc-kid and an adult for acc-adult.
+// PRE: accountID is non-empty
+// POST: Returns the member or an error
+func (m *mockBeltGateMemberStore) GetByAccountID(_ context.Context, accountID string) (domainMember.Member, error) {
+	switch accountID {
+	case "acc-adult":
+		return domainMember.Member{ID: "m1", Program: "adults"}, nil
+	case "acc-kid":
+		return domainMember.Member{ID: "m2", Program: "kids"}, nil
+	}
+	return domainMember.Member{}, errors.New("not found")
+}
+
+type mockBeltGateRecordStore struct{}
+
+// ListByMemberID returns a white then blue promotion for m1 and nothing for anyone else.
+// PRE: memberID is non-empty
+// POST: Returns the records, not in date order
+func (m *mockBeltGateRecordStore) ListByMemberID(_ context.Context, memberID string) ([]domainGrading.Record, error) {
+	if memberID != "m1" {
+		return nil, nil
+	}
+	return []domainGrading.Record{
+		{MemberID: "m1", Belt: "blue", PromotedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{MemberID: "m1", Belt: "white", PromotedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil
+}
+
+// TestResolveBeltGate verifies staff are never gated and members are gated by their latest belt.
+func TestResolveBeltGate(t *testing.T) {
+	deps := ResolveBeltGateDeps{MemberStore: &mockBeltGateMemberStore{}, GradingRecordStore: &mockBeltGateRecordStore{}}
+	ctx := context.Background()
+
+	if g := ResolveBeltGate(ctx, "coach", "acc-coach", deps); !g.Allows("black") {
+		t.Error("coach should see black belt content")
+	}
+	adult := ResolveBeltGate(ctx, "member", "acc-adult", deps)
+	if adult.Belt != "blue" || !adult.Allows("blue") || adult.Allows("purple") {
+		t.Errorf("adult gate = %+v, want blue", adult)
+	}
+	kid := ResolveBeltGate(ctx, "member", "acc-kid", deps)
+	if kid.Program != "kids" || !kid.Allows("white") || kid.Allows("grey") || kid.Allows("blue") {
+		t.Errorf("kid gate = %+v, want a kids white belt", kid)
+	}
+	if g := ResolveBeltGate(ctx, "trial", "acc-unknown", deps); g.Allows("blue") || !g.Allows("") {
+		t.Errorf("unknown gate = %+v, want an adult white belt", g)
+	}
+}
+
+// TestGateClips verifies a clip is locked by its own minimum or its theme's, and locked clips
+// lose the video and notes but keep their title.
+func TestGateClips(t *testing.T) {
+	clips := []clip.Clip{
+		{ID: "c1", ThemeID: "t1", Title: "Armbar", YouTubeID: "abcdefghijk", Notes: "Pinch knees"},
+		{ID: "c2", ThemeID: "t1", Title: "Heel Hook", YouTubeID: "abcdefghijk", Notes: "Hide the heel", MinBelt: "brown"},
+		{ID: "c3", ThemeID: "t2", Title: "Toe Hold", YouTubeID: "abcdefghijk"},
+	}
+	got := GateClips(clips, map[string]string{"t2": "purple"}, BeltGate{Program: "adults", Belt: "blue"})
+	if got[0].Locked || got[0].YouTubeID == "" {
+		t.Errorf("c1 = %+v, want open", got[0])
+	}
+	for _, c := range got[1:] {
+		if !c.Locked || c.YouTubeID != "" || c.Notes != "" || c.Title == "" {
+			t.Errorf("%s = %+v, want locked with only its title", c.ID, c)
+		}
+	}
+
+	themes := GateThemes([]theme.Theme{{ID: "t2", Name: "Leg Locks", Description: "Entries", MinBelt: "purple"}}, BeltGate{Staff: true})
+	if themes[0].Locked || themes[0].Description == "" {
+		t.Errorf("staff theme = %+v, want open", themes[0])
+	}
+}
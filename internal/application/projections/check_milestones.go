@@ -31,6 +31,7 @@ type EarnedMilestone struct {
 	Milestone milestone.Milestone
 	EarnedAt  time.Time
 	Notified  bool
+	New       bool // true when awarded by this check
 }
 
 // CheckMilestonesInput holds the training stats to check against milestones.
@@ -101,6 +102,7 @@ func QueryCheckMilestones(ctx context.Context, input CheckMilestonesInput, deps
 					Milestone: ms,
 					EarnedAt:  now,
 					Notified:  false,
+					New:       true,
 				})
 			}
 		}
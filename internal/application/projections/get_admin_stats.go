@@ -0,0 +1,179 @@
+package projections
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"time"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/kpi"
+	"workshop/internal/domain/member"
+)
+
+// AdminStatsMemberStore defines the member store interface needed by the admin stats.
+type AdminStatsMemberStore interface {
+	List(ctx context.Context, filter memberStore.ListFilter) ([]member.Member, error)
+}
+
+// AdminStatsAttendanceStore defines the attendance store interface needed by the admin stats.
+type AdminStatsAttendanceStore interface {
+	ListByDateRange(ctx context.Context, startDate string, endDate string) ([]attendance.Attendance, error)
+}
+
+// AdminStatsSnapshotStore defines the snapshot store interface needed by the admin stats.
+type AdminStatsSnapshotStore interface {
+	GetLatestBefore(ctx context.Context, date string) (kpi.Snapshot, error)
+	ListByDateRange(ctx context.Context, startDate string, endDate string) ([]kpi.Snapshot, error)
+}
+
+// GetAdminStatsQuery carries input for the admin stats projection.
+type GetAdminStatsQuery struct {
+	Date string // YYYY-MM-DD; the last day of the active window
+}
+
+// GetAdminStatsDeps holds dependencies for the admin stats projection.
+type GetAdminStatsDeps struct {
+	MemberStore     AdminStatsMemberStore
+	AttendanceStore AdminStatsAttendanceStore
+	SnapshotStore   AdminStatsSnapshotStore // optional: nil skips signups and churn
+	Now             func() time.Time
+}
+
+// QueryGetAdminStats computes the gym's headline numbers as of query.Date from live data.
+// Signups and churn are the change since the latest snapshot before that date; with no
+// earlier snapshot there is nothing to compare against and both are zero.
+// PRE: query.Date is YYYY-MM-DD
+// POST: Returns a snapshot ready to save; nothing is written
+func QueryGetAdminStats(ctx context.Context, query GetAdminStatsQuery, deps GetAdminStatsDeps) (kpi.Snapshot, error) {
+	day, err := time.Parse("2006-01-02", query.Date)
+	if err != nil {
+		return kpi.Snapshot{}, kpi.ErrInvalidDate
+	}
+	snap := kpi.Snapshot{Date: query.Date, ComputedAt: deps.Now()}
+
+	members, err := deps.MemberStore.List(ctx, memberStore.ListFilter{Limit: 10000})
+	if err != nil {
+		return kpi.Snapshot{}, err
+	}
+	snap.TotalMembers = len(members)
+	for _, m := range members {
+		switch {
+		case m.IsArchived():
+			snap.ArchivedMembers++
+		case m.IsActive():
+			snap.ActiveMembers++
+			snap.MonthlyRevenue += m.MonthlyFee()
+		}
+	}
+
+	windowStart := day.AddDate(0, 0, -(kpi.ActiveWindowDays - 1)).Format("2006-01-02")
+	records, err := deps.AttendanceStore.ListByDateRange(ctx, windowStart, query.Date)
+	if err != nil {
+		return kpi.Snapshot{}, err
+	}
+	trained := make(map[string]bool)
+	classSizes := make(map[string]int) // schedule ID + date -> check-ins
+	checkIns := 0
+	for _, a := range records {
+		trained[a.MemberID] = true
+		if a.ScheduleID == "" {
+			continue // open mat and other unscheduled check-ins are not classes
+		}
+		classSizes[a.ScheduleID+"|"+a.ClassDate]++
+		checkIns++
+	}
+	snap.WeeklyActiveMembers = len(trained)
+	snap.ClassesHeld = len(classSizes)
+	if snap.ClassesHeld > 0 {
+		snap.AvgClassSize = math.Round(float64(checkIns)/float64(snap.ClassesHeld)*10) / 10
+	}
+
+	if deps.SnapshotStore != nil {
+		prev, err := deps.SnapshotStore.GetLatestBefore(ctx, query.Date)
+		switch {
+		case err == nil:
+			snap.NewMembers, snap.ChurnedMembers = snap.ChangeSince(prev)
+		case !errors.Is(err, sql.ErrNoRows):
+			return kpi.Snapshot{}, err
+		}
+	}
+	return snap, nil
+}
+
+// KPITrendPoint summarises one week of snapshots.
+type KPITrendPoint struct {
+	WeekStart           string // YYYY-MM-DD, Monday
+	WeekEnd             string // YYYY-MM-DD, Sunday or the last day with data
+	ActiveMembers       int    // as of the week's last snapshot
+	WeeklyActiveMembers int    // as of the week's last snapshot
+	NewMembers          int    // summed over the week
+	ChurnedMembers      int    // summed over the week
+	AvgClassSize        float64
+	MonthlyRevenue      int
+	HasData             bool // false when no snapshot was taken that week
+}
+
+// GetKPITrendsQuery carries input for the KPI trends projection.
+type GetKPITrendsQuery struct {
+	Date  string // YYYY-MM-DD; the last day charted
+	Weeks int    // 0 uses kpi.TrendWeeks
+}
+
+// GetKPITrendsResult carries one point per week, oldest first.
+type GetKPITrendsResult struct {
+	Weeks []KPITrendPoint
+}
+
+// QueryGetKPITrends groups daily snapshots into Monday-to-Sunday weeks, ending with the week containing query.Date.
+// Weeks without a snapshot are returned empty so charts keep an even time axis.
+// PRE: query.Date is YYYY-MM-DD; deps.SnapshotStore is non-nil
+// POST: Returns exactly query.Weeks points
+func QueryGetKPITrends(ctx context.Context, query GetKPITrendsQuery, deps GetAdminStatsDeps) (GetKPITrendsResult, error) {
+	day, err := time.Parse("2006-01-02", query.Date)
+	if err != nil {
+		return GetKPITrendsResult{}, kpi.ErrInvalidDate
+	}
+	weeks := query.Weeks
+	if weeks <= 0 {
+		weeks = kpi.TrendWeeks
+	}
+
+	daysSinceMonday := (int(day.Weekday()) + 6) % 7
+	firstMonday := day.AddDate(0, 0, -daysSinceMonday-7*(weeks-1))
+	snapshots, err := deps.SnapshotStore.ListByDateRange(ctx, firstMonday.Format("2006-01-02"), query.Date)
+	if err != nil {
+		return GetKPITrendsResult{}, err
+	}
+
+	result := GetKPITrendsResult{Weeks: make([]KPITrendPoint, weeks)}
+	for i := range result.Weeks {
+		start := firstMonday.AddDate(0, 0, 7*i)
+		result.Weeks[i] = KPITrendPoint{WeekStart: start.Format("2006-01-02"), WeekEnd: start.AddDate(0, 0, 6).Format("2006-01-02")}
+	}
+	for _, snap := range snapshots {
+		d, err := time.Parse("2006-01-02", snap.Date)
+		if err != nil {
+			continue
+		}
+		i := int(d.Sub(firstMonday).Hours()/24) / 7
+		if i < 0 || i >= weeks {
+			continue
+		}
+		p := &result.Weeks[i]
+		// Snapshots arrive oldest first, so the last one seen wins for point-in-time values.
+		p.HasData = true
+		p.ActiveMembers = snap.ActiveMembers
+		p.WeeklyActiveMembers = snap.WeeklyActiveMembers
+		p.AvgClassSize = snap.AvgClassSize
+		p.MonthlyRevenue = snap.MonthlyRevenue
+		p.NewMembers += snap.NewMembers
+		p.ChurnedMembers += snap.ChurnedMembers
+	}
+	if last := &result.Weeks[weeks-1]; last.WeekEnd > query.Date {
+		last.WeekEnd = query.Date
+	}
+	return result, nil
+}
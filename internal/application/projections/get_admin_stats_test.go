@@ -0,0 +1,165 @@
+package projections
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/kpi"
+	"workshop/internal/domain/member"
+)
+
+type mockASMemberStore struct {
+	members []member.Member
+}
+
+// List implements AdminStatsMemberStore for testing.
+// PRE: filter is valid
+// POST: returns every member, archived included
+func (m *mockASMemberStore) List(_ context.Context, _ memberStore.ListFilter) ([]member.Member, error) {
+	return m.members, nil
+}
+
+type mockASAttendanceStore struct {
+	records []attendance.Attendance
+}
+
+// ListByDateRange implements AdminStatsAttendanceStore for testing.
+// PRE: dates are YYYY-MM-DD
+// POST: returns records dated within the inclusive range
+func (m *mockASAttendanceStore) ListByDateRange(_ context.Context, startDate, endDate string) ([]attendance.Attendance, error) {
+	var out []attendance.Attendance
+	for _, a := range m.records {
+		if a.ClassDate >= startDate && a.ClassDate <= endDate {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+type mockASSnapshotStore struct {
+	snapshots []kpi.Snapshot // oldest first
+}
+
+// GetLatestBefore implements AdminStatsSnapshotStore for testing.
+// PRE: date is YYYY-MM-DD
+// POST: returns the newest snapshot before date or sql.ErrNoRows
+func (m *mockASSnapshotStore) GetLatestBefore(_ context.Context, date string) (kpi.Snapshot, error) {
+	for i := len(m.snapshots) - 1; i >= 0; i-- {
+		if m.snapshots[i].Date < date {
+			return m.snapshots[i], nil
+		}
+	}
+	return kpi.Snapshot{}, sql.ErrNoRows
+}
+
+// ListByDateRange implements AdminStatsSnapshotStore for testing.
+// PRE: dates are YYYY-MM-DD
+// POST: returns snapshots within the inclusive range, oldest first
+func (m *mockASSnapshotStore) ListByDateRange(_ context.Context, startDate, endDate string) ([]kpi.Snapshot, error) {
+	var out []kpi.Snapshot
+	for _, s := range m.snapshots {
+		if s.Date >= startDate && s.Date <= endDate {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func adminStatsNow() time.Time { return time.Date(2026, 3, 11, 20, 0, 0, 0, time.UTC) }
+
+// TestQueryGetAdminStats verifies member counts, weekly activity, class size and revenue.
+func TestQueryGetAdminStats(t *testing.T) {
+	deps := GetAdminStatsDeps{
+		MemberStore: &mockASMemberStore{members: []member.Member{
+			{ID: "m1", Status: member.StatusActive, Fee: 120, Frequency: "monthly"},
+			{ID: "m2", Status: member.StatusActive, Fee: 30, Frequency: "weekly"},
+			{ID: "m3", Status: member.StatusInactive, Fee: 120},
+			{ID: "m4", Status: member.StatusArchived, Fee: 120},
+		}},
+		AttendanceStore: &mockASAttendanceStore{records: []attendance.Attendance{
+			{MemberID: "m1", ScheduleID: "s1", ClassDate: "2026-03-10"},
+			{MemberID: "m2", ScheduleID: "s1", ClassDate: "2026-03-10"},
+			{MemberID: "m1", ScheduleID: "s2", ClassDate: "2026-03-05"},
+			{MemberID: "m3", ClassDate: "2026-03-09"},                   // open mat: active, but not a class
+			{MemberID: "m4", ScheduleID: "s1", ClassDate: "2026-03-04"}, // outside the 7-day window
+		}},
+		SnapshotStore: &mockASSnapshotStore{snapshots: []kpi.Snapshot{
+			{Date: "2026-03-09", TotalMembers: 2, ArchivedMembers: 0},
+			{Date: "2026-03-10", TotalMembers: 3, ArchivedMembers: 0},
+		}},
+		Now: adminStatsNow,
+	}
+
+	snap, err := QueryGetAdminStats(context.Background(), GetAdminStatsQuery{Date: "2026-03-11"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := kpi.Snapshot{
+		Date:                "2026-03-11",
+		TotalMembers:        4,
+		ActiveMembers:       2,
+		ArchivedMembers:     1,
+		NewMembers:          1,
+		ChurnedMembers:      1,
+		WeeklyActiveMembers: 3,
+		ClassesHeld:         2,
+		AvgClassSize:        1.5,
+		MonthlyRevenue:      250,
+		ComputedAt:          adminStatsNow(),
+	}
+	if snap != want {
+		t.Errorf("snapshot =\n %+v\nwant\n %+v", snap, want)
+	}
+
+	// Without an earlier snapshot there is nothing to compare signups and churn against.
+	deps.SnapshotStore = &mockASSnapshotStore{}
+	snap, _ = QueryGetAdminStats(context.Background(), GetAdminStatsQuery{Date: "2026-03-11"}, deps)
+	if snap.NewMembers != 0 || snap.ChurnedMembers != 0 {
+		t.Errorf("first snapshot: new %d churned %d, want 0/0", snap.NewMembers, snap.ChurnedMembers)
+	}
+
+	if _, err := QueryGetAdminStats(context.Background(), GetAdminStatsQuery{Date: "today"}, deps); err != kpi.ErrInvalidDate {
+		t.Errorf("bad date: err = %v, want ErrInvalidDate", err)
+	}
+}
+
+// TestQueryGetKPITrends verifies daily snapshots are grouped into Monday-to-Sunday weeks.
+func TestQueryGetKPITrends(t *testing.T) {
+	deps := GetAdminStatsDeps{SnapshotStore: &mockASSnapshotStore{snapshots: []kpi.Snapshot{
+		{Date: "2026-02-20", ActiveMembers: 99, NewMembers: 9}, // before the first charted week
+		{Date: "2026-02-23", ActiveMembers: 40, NewMembers: 2, WeeklyActiveMembers: 30},
+		{Date: "2026-02-27", ActiveMembers: 41, NewMembers: 1, ChurnedMembers: 1, WeeklyActiveMembers: 32},
+		{Date: "2026-03-10", ActiveMembers: 43, NewMembers: 3, AvgClassSize: 8.5},
+	}}}
+
+	// 2026-03-11 is a Wednesday; three weeks start on 23 Feb, 2 Mar and 9 Mar.
+	result, err := QueryGetKPITrends(context.Background(), GetKPITrendsQuery{Date: "2026-03-11", Weeks: 3}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Weeks) != 3 {
+		t.Fatalf("expected 3 weeks, got %d", len(result.Weeks))
+	}
+	first, gap, last := result.Weeks[0], result.Weeks[1], result.Weeks[2]
+	if first.WeekStart != "2026-02-23" || first.WeekEnd != "2026-03-01" || !first.HasData {
+		t.Errorf("first week = %+v, want 23 Feb to 1 Mar with data", first)
+	}
+	if first.ActiveMembers != 41 || first.WeeklyActiveMembers != 32 || first.NewMembers != 3 || first.ChurnedMembers != 1 {
+		t.Errorf("first week = %+v, want the last snapshot's levels and summed signups and churn", first)
+	}
+	if gap.HasData || gap.WeekStart != "2026-03-02" {
+		t.Errorf("second week = %+v, want an empty week starting 2 Mar", gap)
+	}
+	if last.WeekEnd != "2026-03-11" || last.ActiveMembers != 43 || last.AvgClassSize != 8.5 {
+		t.Errorf("last week = %+v, want data up to 11 Mar", last)
+	}
+
+	result, _ = QueryGetKPITrends(context.Background(), GetKPITrendsQuery{Date: "2026-03-11"}, deps)
+	if len(result.Weeks) != kpi.TrendWeeks {
+		t.Errorf("default weeks = %d, want %d", len(result.Weeks), kpi.TrendWeeks)
+	}
+}
@@ -9,27 +9,33 @@ import (
 	domainAttendance "workshop/internal/domain/attendance"
 	domainClassType "workshop/internal/domain/classtype"
 	domainInjury "workshop/internal/domain/injury"
+	"workshop/internal/domain/location"
 	domainSchedule "workshop/internal/domain/schedule"
 )
 
 // GetAttendanceTodayQuery carries query parameters.
 type GetAttendanceTodayQuery struct {
-	Date string // Optional, defaults to today
+	Date       string // Optional, defaults to today
+	LocationID string // Optional, empty shows all locations
 }
 
 // AttendanceWithMember represents attendance with member details.
 type AttendanceWithMember struct {
-	MemberID       string
-	MemberName     string
-	CheckInTime    time.Time
-	CheckOutTime   time.Time
-	HasInjury      bool
-	InjuryBodyPart string
-	Belt           string
-	Stripe         int
-	MatHours       float64
-	ScheduleID     string
-	ClassName      string
+	MemberID          string
+	MemberName        string
+	CheckInTime       time.Time
+	CheckOutTime      time.Time
+	HasInjury         bool
+	InjuryBodyPart    string
+	InjuryStatus      string
+	InjurySeverity    string
+	GradingRestricted bool
+	Belt              string
+	Stripe            int
+	MatHours          float64
+	ScheduleID        string
+	ClassName         string
+	LocationID        string
 }
 
 // GetAttendanceTodayResult carries the query result.
@@ -69,10 +75,10 @@ func QueryGetAttendanceToday(ctx context.Context, query GetAttendanceTodayQuery,
 		}
 	}
 
-	// Get all attendance records (filter by date in production)
+	// Check-ins either side of the target date, in case stored times carry another offset
 	attendances, err := deps.AttendanceStore.List(ctx, attendance.ListFilter{
-		Limit:  1000,
-		Offset: 0,
+		From: targetDate.AddDate(0, 0, -1).Format("2006-01-02"),
+		To:   targetDate.AddDate(0, 0, 1).Format("2006-01-02"),
 	})
 	if err != nil {
 		return GetAttendanceTodayResult{}, err
@@ -81,13 +87,12 @@ func QueryGetAttendanceToday(ctx context.Context, query GetAttendanceTodayQuery,
 	// Filter to target date's records
 	var todayAttendances []domainAttendance.Attendance
 	for _, a := range attendances {
-		if a.CheckInTime.Truncate(24 * time.Hour).Equal(targetDate) {
+		if a.CheckInTime.Truncate(24*time.Hour).Equal(targetDate) && location.Matches(a.LocationID, query.LocationID) {
 			todayAttendances = append(todayAttendances, a)
 		}
 	}
 
 	// Get active injuries
-	sevenDaysAgo := time.Now().Add(-7 * 24 * time.Hour)
 	injuries, err := deps.InjuryStore.List(ctx, injury.ListFilter{
 		Limit:  1000,
 		Offset: 0,
@@ -98,7 +103,7 @@ func QueryGetAttendanceToday(ctx context.Context, query GetAttendanceTodayQuery,
 
 	injuryMap := make(map[string]domainInjury.Injury)
 	for _, inj := range injuries {
-		if inj.ReportedAt.After(sevenDaysAgo) {
+		if inj.IsActive() {
 			injuryMap[inj.MemberID] = inj
 		}
 	}
@@ -119,12 +124,16 @@ func QueryGetAttendanceToday(ctx context.Context, query GetAttendanceTodayQuery,
 			CheckOutTime: a.CheckOutTime,
 			MatHours:     a.MatHours,
 			ScheduleID:   a.ScheduleID,
+			LocationID:   a.LocationID,
 		}
 
 		// Check for injury
 		if inj, hasInjury := injuryMap[m.ID]; hasInjury {
 			awm.HasInjury = true
 			awm.InjuryBodyPart = inj.BodyPart
+			awm.InjuryStatus = inj.Status
+			awm.InjurySeverity = inj.GetSeverity()
+			awm.GradingRestricted = inj.GradingRestricted
 		}
 
 		// Look up latest belt
@@ -0,0 +1,126 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	attendanceStore "workshop/internal/adapters/storage/attendance"
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/celebration"
+	"workshop/internal/domain/member"
+)
+
+// CelebrationMemberStore defines the member store interface needed to find celebrations.
+type CelebrationMemberStore interface {
+	List(ctx context.Context, filter memberStore.ListFilter) ([]member.Member, error)
+}
+
+// CelebrationProfileStore defines the celebration store interface needed to find celebrations.
+type CelebrationProfileStore interface {
+	ListProfiles(ctx context.Context) ([]celebration.Profile, error)
+}
+
+// CelebrationAttendanceStore defines the attendance store interface needed to find join
+// dates and class milestones.
+type CelebrationAttendanceStore interface {
+	TotalsByMemberID(ctx context.Context, memberID string) (attendance.Totals, error)
+	List(ctx context.Context, filter attendanceStore.ListFilter) ([]attendance.Attendance, error)
+}
+
+// GetCelebrationsQuery selects the days to look at.
+type GetCelebrationsQuery struct {
+	From time.Time // first day; its location decides what "a day" is
+	Days int       // 1 to celebration.MaxWindowDays; 0 means just From
+}
+
+// GetCelebrationsDeps holds dependencies for GetCelebrations.
+type GetCelebrationsDeps struct {
+	MemberStore     CelebrationMemberStore
+	ProfileStore    CelebrationProfileStore
+	AttendanceStore CelebrationAttendanceStore
+}
+
+// QueryGetCelebrations lists active members' birthdays, training anniversaries and class
+// milestones falling on the query's days. Anniversaries count from the member's join date,
+// or their first check-in when none is recorded. Members who opted out are left out.
+// Class milestones are reached on a check-in, so they only ever fall on past days or today.
+// PRE: From is set
+// POST: Returns celebrations ordered by date, then member name
+func QueryGetCelebrations(ctx context.Context, query GetCelebrationsQuery, deps GetCelebrationsDeps) ([]celebration.Celebration, error) {
+	if query.Days == 0 {
+		query.Days = 1
+	}
+	if query.Days < 1 || query.Days > celebration.MaxWindowDays {
+		return nil, celebration.ErrInvalidWindow
+	}
+	loc := query.From.Location()
+	y, m, d := query.From.Date()
+	days := make([]time.Time, query.Days)
+	for i := range days {
+		days[i] = time.Date(y, m, d+i, 0, 0, 0, 0, loc)
+	}
+	first, last := days[0].Format("2006-01-02"), days[len(days)-1].Format("2006-01-02")
+
+	profileList, err := deps.ProfileStore.ListProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	profiles := make(map[string]celebration.Profile, len(profileList))
+	for _, p := range profileList {
+		profiles[p.MemberID] = p
+	}
+	members, err := deps.MemberStore.List(ctx, memberStore.ListFilter{Status: member.StatusActive, Limit: 10000})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []celebration.Celebration
+	for _, mem := range members {
+		profile := profiles[mem.ID]
+		if profile.OptOut {
+			continue
+		}
+		totals, err := deps.AttendanceStore.TotalsByMemberID(ctx, mem.ID)
+		if err != nil {
+			return nil, err
+		}
+		joined := profile.JoinedOn
+		if joined.IsZero() && !totals.FirstCheckIn.IsZero() {
+			joined = totals.FirstCheckIn.In(loc)
+		}
+		for _, day := range days {
+			date := day.Format("2006-01-02")
+			if !profile.DateOfBirth.IsZero() && day.Year() > profile.DateOfBirth.Year() && celebration.OccursOn(profile.DateOfBirth, day) {
+				result = append(result, celebration.Celebration{MemberID: mem.ID, MemberName: mem.Name, Kind: celebration.KindBirthday, Date: date})
+			}
+			if !joined.IsZero() && day.Year() > joined.Year() && celebration.OccursOn(joined, day) {
+				result = append(result, celebration.Celebration{MemberID: mem.ID, MemberName: mem.Name, Kind: celebration.KindAnniversary, Date: date, Years: day.Year() - joined.Year()})
+			}
+		}
+
+		reached := celebration.ClassesReached(totals.Classes)
+		if reached == 0 {
+			continue
+		}
+		nth, err := deps.AttendanceStore.List(ctx, attendanceStore.ListFilter{MemberID: mem.ID, Dir: "asc", Offset: reached - 1, Limit: 1})
+		if err != nil {
+			return nil, err
+		}
+		if len(nth) == 1 {
+			date := nth[0].CheckInTime.In(loc).Format("2006-01-02")
+			if date >= first && date <= last {
+				result = append(result, celebration.Celebration{MemberID: mem.ID, MemberName: mem.Name, Kind: celebration.KindClasses, Date: date, Classes: reached})
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Date != result[j].Date {
+			return result[i].Date < result[j].Date
+		}
+		return result[i].MemberName < result[j].MemberName
+	})
+	return result, nil
+}
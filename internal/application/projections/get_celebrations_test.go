@@ -0,0 +1,127 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	attendanceStore "workshop/internal/adapters/storage/attendance"
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/celebration"
+	"workshop/internal/domain/member"
+)
+
+type mockCelebrationStores struct {
+	members  []member.Member
+	profiles []celebration.Profile
+	checkIns map[string][]time.Time // by member ID, oldest first
+}
+
+// List returns the members with the filter's status.
+// PRE: none
+// POST: Returns matching members
+func (m *mockCelebrationStores) List(_ context.Context, filter memberStore.ListFilter) ([]member.Member, error) {
+	var result []member.Member
+	for _, mem := range m.members {
+		if filter.Status == "" || mem.Status == filter.Status {
+			result = append(result, mem)
+		}
+	}
+	return result, nil
+}
+
+// ListProfiles returns every profile.
+// PRE: none
+// POST: Returns the profiles
+func (m *mockCelebrationStores) ListProfiles(_ context.Context) ([]celebration.Profile, error) {
+	return m.profiles, nil
+}
+
+// TotalsByMemberID counts a member's check-ins.
+// PRE: memberID is non-empty
+// POST: Returns the class count and first check-in
+func (m *mockCelebrationStores) TotalsByMemberID(_ context.Context, memberID string) (attendance.Totals, error) {
+	checkIns := m.checkIns[memberID]
+	if len(checkIns) == 0 {
+		return attendance.Totals{}, nil
+	}
+	return attendance.Totals{Classes: len(checkIns), FirstCheckIn: checkIns[0]}, nil
+}
+
+// listAttendance returns the check-in at the filter's offset, oldest first.
+func (m *mockCelebrationStores) listAttendance(filter attendanceStore.ListFilter) []attendance.Attendance {
+	checkIns := m.checkIns[filter.MemberID]
+	if filter.Offset >= len(checkIns) {
+		return nil
+	}
+	return []attendance.Attendance{{MemberID: filter.MemberID, CheckInTime: checkIns[filter.Offset]}}
+}
+
+// celebrationAttendance adapts the mock to CelebrationAttendanceStore, whose List clashes with the member store's.
+type celebrationAttendance struct{ *mockCelebrationStores }
+
+// List pages through a member's check-ins, oldest first.
+// PRE: filter.MemberID is non-empty
+// POST: Returns the page
+func (a celebrationAttendance) List(_ context.Context, filter attendanceStore.ListFilter) ([]attendance.Attendance, error) {
+	return a.listAttendance(filter), nil
+}
+
+// TestQueryGetCelebrations verifies birthdays, anniversaries and class milestones in the window
+// are listed by date, and that members who opted out or left are not.
+func TestQueryGetCelebrations(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	hundred := make([]time.Time, 100)
+	for i := range hundred {
+		hundred[i] = date(2025, time.May, 11).AddDate(0, 0, i*3) // the 100th is 2026-03-04
+	}
+	stores := &mockCelebrationStores{
+		members: []member.Member{
+			{ID: "m1", Name: "Ana", Status: member.StatusActive},
+			{ID: "m2", Name: "Ben", Status: member.StatusActive},
+			{ID: "m3", Name: "Cal", Status: member.StatusActive},
+			{ID: "m4", Name: "Dee", Status: member.StatusActive},
+			{ID: "m5", Name: "Eli", Status: member.StatusArchived},
+		},
+		profiles: []celebration.Profile{
+			{MemberID: "m1", DateOfBirth: date(1990, time.March, 5)},
+			{MemberID: "m2", JoinedOn: date(2023, time.March, 3)},
+			{MemberID: "m4", DateOfBirth: date(1988, time.March, 2), OptOut: true},
+			{MemberID: "m5", DateOfBirth: date(1991, time.March, 2)},
+		},
+		checkIns: map[string][]time.Time{
+			"m1": {date(2025, time.March, 2)}, // first check-in a year before the window: anniversary on its first day
+			"m3": hundred,
+			"m4": {date(2025, time.March, 2)},
+		},
+	}
+	deps := GetCelebrationsDeps{MemberStore: stores, ProfileStore: stores, AttendanceStore: celebrationAttendance{stores}}
+
+	got, err := QueryGetCelebrations(context.Background(), GetCelebrationsQuery{From: date(2026, time.March, 2), Days: 7}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []celebration.Celebration{
+		{MemberID: "m1", MemberName: "Ana", Kind: celebration.KindAnniversary, Date: "2026-03-02", Years: 1},
+		{MemberID: "m2", MemberName: "Ben", Kind: celebration.KindAnniversary, Date: "2026-03-03", Years: 3},
+		{MemberID: "m3", MemberName: "Cal", Kind: celebration.KindClasses, Date: "2026-03-04", Classes: 100},
+		{MemberID: "m1", MemberName: "Ana", Kind: celebration.KindBirthday, Date: "2026-03-05"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d celebrations, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("celebration %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	got, err = QueryGetCelebrations(context.Background(), GetCelebrationsQuery{From: date(2026, time.March, 5)}, deps)
+	if err != nil || len(got) != 1 || got[0].Kind != celebration.KindBirthday {
+		t.Errorf("one day: got %+v, %v; want Ana's birthday", got, err)
+	}
+	if _, err := QueryGetCelebrations(context.Background(), GetCelebrationsQuery{From: date(2026, time.March, 5), Days: 60}, deps); err != celebration.ErrInvalidWindow {
+		t.Errorf("60 days: err = %v, want ErrInvalidWindow", err)
+	}
+}
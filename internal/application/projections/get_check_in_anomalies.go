@@ -0,0 +1,154 @@
+package projections
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	domainAttendance "workshop/internal/domain/attendance"
+	domainClassType "workshop/internal/domain/classtype"
+	domainMember "workshop/internal/domain/member"
+	domainSchedule "workshop/internal/domain/schedule"
+)
+
+// CheckInAnomalyWindowDays is how far back the anomalies report looks by default.
+const CheckInAnomalyWindowDays = 30
+
+// CheckInAnomalyAttendanceStore defines the attendance store interface needed by the anomalies report.
+type CheckInAnomalyAttendanceStore interface {
+	ListByDateRange(ctx context.Context, startDate string, endDate string) ([]domainAttendance.Attendance, error)
+}
+
+// CheckInAnomalyMemberStore defines the member store interface needed by the anomalies report.
+type CheckInAnomalyMemberStore interface {
+	GetByID(ctx context.Context, id string) (domainMember.Member, error)
+}
+
+// CheckInAnomalyScheduleStore defines the schedule store interface needed by the anomalies report.
+type CheckInAnomalyScheduleStore interface {
+	List(ctx context.Context) ([]domainSchedule.Schedule, error)
+}
+
+// CheckInAnomalyClassTypeStore defines the class type store interface needed by the anomalies report.
+type CheckInAnomalyClassTypeStore interface {
+	List(ctx context.Context) ([]domainClassType.ClassType, error)
+}
+
+// GetCheckInAnomaliesQuery carries query parameters.
+type GetCheckInAnomaliesQuery struct {
+	From string // optional YYYY-MM-DD, defaults to CheckInAnomalyWindowDays ago
+	To   string // optional YYYY-MM-DD, defaults to today
+}
+
+// GetCheckInAnomaliesDeps holds dependencies for the anomalies report.
+type GetCheckInAnomaliesDeps struct {
+	AttendanceStore CheckInAnomalyAttendanceStore
+	MemberStore     CheckInAnomalyMemberStore
+	ScheduleStore   CheckInAnomalyScheduleStore
+	ClassTypeStore  CheckInAnomalyClassTypeStore
+}
+
+// AnomalyClass is a class on the timetable, as shown on the anomalies report.
+type AnomalyClass struct {
+	ScheduleID string
+	ClassName  string
+	StartTime  string
+	EndTime    string
+}
+
+// AnomalyCheckIn is one of the check-ins in an anomaly.
+type AnomalyCheckIn struct {
+	AttendanceID string
+	AnomalyClass
+	CheckInTime  time.Time
+	CheckOutTime time.Time
+	MatHours     float64
+}
+
+// CheckInAnomaly is a duplicate or overlap with everything needed to fix it.
+type CheckInAnomaly struct {
+	Kind       string // attendance.AnomalyDuplicate or attendance.AnomalyOverlap
+	MemberID   string
+	MemberName string
+	Date       string
+	CheckIns   []AnomalyCheckIn // earliest first
+	ReassignTo []AnomalyClass   // the classes on the timetable that day
+}
+
+// GetCheckInAnomaliesResult carries the anomalies report.
+type GetCheckInAnomaliesResult struct {
+	From      string
+	To        string
+	Anomalies []CheckInAnomaly
+}
+
+// QueryGetCheckInAnomalies finds check-ins that cannot all be right: a member in the same
+// class twice on one date, or in two classes that run at the same time.
+// PRE: now is the current time
+// POST: Returns the anomalies, most recent first; nothing is written
+func QueryGetCheckInAnomalies(ctx context.Context, query GetCheckInAnomaliesQuery, now time.Time, deps GetCheckInAnomaliesDeps) (GetCheckInAnomaliesResult, error) {
+	result := GetCheckInAnomaliesResult{From: query.From, To: query.To, Anomalies: []CheckInAnomaly{}}
+	if result.To == "" {
+		result.To = now.Format("2006-01-02")
+	}
+	if result.From == "" {
+		result.From = now.AddDate(0, 0, -CheckInAnomalyWindowDays).Format("2006-01-02")
+	}
+
+	records, err := deps.AttendanceStore.ListByDateRange(ctx, result.From, result.To)
+	if err != nil {
+		return result, err
+	}
+	schedules, err := deps.ScheduleStore.List(ctx)
+	if err != nil {
+		return result, err
+	}
+	classTypes, err := deps.ClassTypeStore.List(ctx)
+	if err != nil {
+		return result, err
+	}
+	classNames := map[string]string{}
+	for _, ct := range classTypes {
+		classNames[ct.ID] = ct.Name
+	}
+	slots := map[string]domainAttendance.Slot{}
+	classes := map[string]AnomalyClass{}
+	byDay := map[string][]AnomalyClass{}
+	for _, s := range schedules {
+		slots[s.ID] = domainAttendance.Slot{Start: s.StartTime, End: s.EndTime}
+		c := AnomalyClass{ScheduleID: s.ID, ClassName: classNames[s.ClassTypeID], StartTime: s.StartTime, EndTime: s.EndTime}
+		classes[s.ID] = c
+		byDay[s.Day] = append(byDay[s.Day], c)
+	}
+
+	byID := map[string]domainAttendance.Attendance{}
+	for _, r := range records {
+		byID[r.ID] = r
+	}
+	names := map[string]string{}
+	for _, found := range domainAttendance.FindAnomalies(records, slots) {
+		name, ok := names[found.MemberID]
+		if !ok {
+			if m, err := deps.MemberStore.GetByID(ctx, found.MemberID); err == nil {
+				name = m.Name
+			}
+			names[found.MemberID] = name
+		}
+		a := CheckInAnomaly{Kind: found.Kind, MemberID: found.MemberID, MemberName: name, Date: found.Date, ReassignTo: []AnomalyClass{}}
+		for _, id := range found.AttendanceIDs {
+			r := byID[id]
+			c, ok := classes[r.ScheduleID]
+			if !ok {
+				c = AnomalyClass{ScheduleID: r.ScheduleID}
+			}
+			a.CheckIns = append(a.CheckIns, AnomalyCheckIn{AttendanceID: id, AnomalyClass: c, CheckInTime: r.CheckInTime, CheckOutTime: r.CheckOutTime, MatHours: r.MatHours})
+		}
+		if day, err := time.Parse("2006-01-02", found.Date); err == nil {
+			if options := byDay[strings.ToLower(day.Weekday().String())]; options != nil {
+				a.ReassignTo = options
+			}
+		}
+		result.Anomalies = append(result.Anomalies, a)
+	}
+	return result, nil
+}
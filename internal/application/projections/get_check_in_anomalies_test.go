@@ -0,0 +1,97 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainAttendance "workshop/internal/domain/attendance"
+	domainClassType "workshop/internal/domain/classtype"
+	domainMember "workshop/internal/domain/member"
+	domainSchedule "workshop/internal/domain/schedule"
+)
+
+// --- Mock stores for anomalies report tests ---
+
+type mockCIAStore struct {
+	records []domainAttendance.Attendance
+}
+
+// ListByDateRange returns the check-ins between the dates.
+// PRE: dates are YYYY-MM-DD
+// POST: Returns the check-ins
+func (m *mockCIAStore) ListByDateRange(_ context.Context, startDate, endDate string) ([]domainAttendance.Attendance, error) {
+	var list []domainAttendance.Attendance
+	for _, r := range m.records {
+		if d := r.CheckInTime.Format("2006-01-02"); d >= startDate && d <= endDate {
+			list = append(list, r)
+		}
+	}
+	return list, nil
+}
+
+type mockCIAMemberStore struct{}
+
+// GetByID returns Marcus for m1.
+// PRE: id is non-empty
+// POST: Returns the member or an error
+func (m *mockCIAMemberStore) GetByID(_ context.Context, id string) (domainMember.Member, error) {
+	if id != "m1" {
+		return domainMember.Member{}, errors.New("not found")
+	}
+	return domainMember.Member{ID: "m1", Name: "Marcus Almeida"}, nil
+}
+
+type mockCIAScheduleStore struct{}
+
+// List returns two overlapping Monday classes and one on Tuesday.
+// PRE: none
+// POST: Returns the schedules
+func (m *mockCIAScheduleStore) List(_ context.Context) ([]domainSchedule.Schedule, error) {
+	return []domainSchedule.Schedule{
+		{ID: "s1", ClassTypeID: "fundamentals", Day: domainSchedule.Monday, StartTime: "18:00", EndTime: "19:00"},
+		{ID: "s2", ClassTypeID: "nogi", Day: domainSchedule.Monday, StartTime: "18:30", EndTime: "19:30"},
+		{ID: "s3", ClassTypeID: "nogi", Day: domainSchedule.Tuesday, StartTime: "18:00", EndTime: "19:00"},
+	}, nil
+}
+
+type mockCIAClassTypeStore struct{}
+
+// List returns the class types.
+// PRE: none
+// POST: Returns the class types
+func (m *mockCIAClassTypeStore) List(_ context.Context) ([]domainClassType.ClassType, error) {
+	return []domainClassType.ClassType{{ID: "fundamentals", Name: "Fundamentals"}, {ID: "nogi", Name: "No-Gi"}}, nil
+}
+
+// TestQueryGetCheckInAnomalies verifies overlaps inside the window are reported with names
+// and the classes the check-in could be moved to.
+func TestQueryGetCheckInAnomalies(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	store := &mockCIAStore{records: []domainAttendance.Attendance{
+		{ID: "a1", MemberID: "m1", ScheduleID: "s1", CheckInTime: monday},
+		{ID: "a2", MemberID: "m1", ScheduleID: "s2", CheckInTime: monday.Add(30 * time.Minute)},
+		{ID: "old1", MemberID: "m1", ScheduleID: "s1", CheckInTime: monday.AddDate(0, -3, 0)},
+		{ID: "old2", MemberID: "m1", ScheduleID: "s1", CheckInTime: monday.AddDate(0, -3, 0)},
+	}}
+	deps := GetCheckInAnomaliesDeps{AttendanceStore: store, MemberStore: &mockCIAMemberStore{}, ScheduleStore: &mockCIAScheduleStore{}, ClassTypeStore: &mockCIAClassTypeStore{}}
+
+	got, err := QueryGetCheckInAnomalies(context.Background(), GetCheckInAnomaliesQuery{}, monday.AddDate(0, 0, 1), deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.From != "2026-02-01" || got.To != "2026-03-03" {
+		t.Errorf("window = %s..%s", got.From, got.To)
+	}
+	if len(got.Anomalies) != 1 {
+		t.Fatalf("anomalies = %+v, want one", got.Anomalies)
+	}
+	a := got.Anomalies[0]
+	if a.Kind != domainAttendance.AnomalyOverlap || a.MemberName != "Marcus Almeida" || len(a.CheckIns) != 2 || a.CheckIns[1].ClassName != "No-Gi" {
+		t.Errorf("anomaly = %+v", a)
+	}
+	if len(a.ReassignTo) != 2 {
+		t.Errorf("ReassignTo = %+v, want Monday's two classes", a.ReassignTo)
+	}
+}
@@ -0,0 +1,332 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	accountStore "workshop/internal/adapters/storage/account"
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/availability"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/holiday"
+	domainMember "workshop/internal/domain/member"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/term"
+	"workshop/internal/domain/timesheet"
+)
+
+// Reasons a class is uncovered.
+const (
+	CoverageNoCoach     = "no_coach"          // the class has no regular coach and nobody assigned for the day
+	CoverageUnavailable = "coach_unavailable" // the assigned coach is away or outside their availability
+)
+
+// CoverageScheduleStore defines the schedule store interface needed by the coverage planner.
+type CoverageScheduleStore interface {
+	List(ctx context.Context) ([]schedule.Schedule, error)
+}
+
+// CoverageTermStore defines the term store interface needed by the coverage planner.
+type CoverageTermStore interface {
+	List(ctx context.Context) ([]term.Term, error)
+}
+
+// CoverageHolidayStore defines the holiday store interface needed by the coverage planner.
+type CoverageHolidayStore interface {
+	List(ctx context.Context) ([]holiday.Holiday, error)
+}
+
+// CoverageChangeStore defines the occurrence change store interface needed by the coverage planner.
+type CoverageChangeStore interface {
+	ListByDateRange(ctx context.Context, from, to string) ([]schedule.OccurrenceChange, error)
+}
+
+// CoverageClassTypeStore defines the class type store interface needed by the coverage planner.
+type CoverageClassTypeStore interface {
+	GetByID(ctx context.Context, id string) (classtype.ClassType, error)
+}
+
+// CoverageOverrideStore defines the timesheet store interface needed by the coverage planner.
+type CoverageOverrideStore interface {
+	ListOverrides(ctx context.Context, from, to string) ([]timesheet.Override, error)
+}
+
+// CoverageAvailabilityStore defines the availability store interface needed by the coverage planner.
+type CoverageAvailabilityStore interface {
+	ListWindows(ctx context.Context) ([]availability.Window, error)
+	ListExceptions(ctx context.Context, from, to string) ([]availability.Exception, error)
+}
+
+// CoverageAccountStore defines the account store interface needed by the coverage planner.
+type CoverageAccountStore interface {
+	List(ctx context.Context, filter accountStore.ListFilter) ([]account.Account, error)
+}
+
+// CoverageMemberStore defines the member store interface needed by the coverage planner.
+type CoverageMemberStore interface {
+	GetByAccountID(ctx context.Context, accountID string) (domainMember.Member, error)
+}
+
+// GetClassCoverageDeps holds dependencies for the coverage planner.
+type GetClassCoverageDeps struct {
+	ScheduleStore     CoverageScheduleStore
+	TermStore         CoverageTermStore
+	HolidayStore      CoverageHolidayStore
+	ChangeStore       CoverageChangeStore // optional: nil ignores cancellations and substitutes
+	ClassTypeStore    CoverageClassTypeStore
+	OverrideStore     CoverageOverrideStore
+	AvailabilityStore CoverageAvailabilityStore
+	AccountStore      CoverageAccountStore
+	MemberStore       CoverageMemberStore // optional: nil shows coaches by email only
+}
+
+// CoverageCoach is a coach who could take a class.
+type CoverageCoach struct {
+	CoachID string
+	Name    string
+}
+
+// UncoveredClass is a class in the planning window that nobody is able to coach.
+type UncoveredClass struct {
+	ScheduleID    string
+	ClassDate     string // YYYY-MM-DD
+	StartTime     string // HH:MM
+	EndTime       string // HH:MM
+	ClassTypeName string
+	CoachID       string // the coach who is unavailable; empty when nobody is assigned
+	CoachName     string
+	Reason        string          // CoverageNoCoach or CoverageUnavailable
+	Suggestions   []CoverageCoach // available coaches who are not teaching at the time
+}
+
+// ClassCoverageResult carries the output of the coverage planner.
+type ClassCoverageResult struct {
+	From      string // YYYY-MM-DD, today
+	To        string // YYYY-MM-DD, the last day planned
+	Classes   int    // classes running in the window
+	Uncovered []UncoveredClass
+}
+
+// coverageSlot is one class occurrence and who is down to coach it.
+type coverageSlot struct {
+	sched   schedule.Schedule
+	date    time.Time
+	coachID string
+	covered bool // a substitute is named on the class change
+}
+
+// QueryGetClassCoverage finds the classes in the next availability.PlanningDays days that
+// nobody can coach, and suggests coaches who can.
+// Algorithm: 1) Walk the dates, keeping schedules that run inside a term and outside holidays,
+// 2) drop cancelled occurrences, take moved times, add one-off sessions and treat a named
+// substitute as cover, 3) take each
+// occurrence's override coach or its regular coach, 4) flag it when there is no coach or the
+// coach is unavailable, 5) suggest coaches whose availability covers the class and who are not
+// teaching another class that overlaps it.
+// PRE: now is the current time
+// POST: Returns uncovered classes ordered by date then start time; nothing is written
+func QueryGetClassCoverage(ctx context.Context, now time.Time, deps GetClassCoverageDeps) (ClassCoverageResult, error) {
+	first := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 0, availability.PlanningDays-1)
+	result := ClassCoverageResult{From: first.Format("2006-01-02"), To: last.Format("2006-01-02"), Uncovered: []UncoveredClass{}}
+
+	schedules, err := deps.ScheduleStore.List(ctx)
+	if err != nil {
+		return result, err
+	}
+	sort.SliceStable(schedules, func(i, j int) bool { return schedules[i].StartTime < schedules[j].StartTime })
+	terms, err := deps.TermStore.List(ctx)
+	if err != nil {
+		return result, err
+	}
+	holidays, err := deps.HolidayStore.List(ctx)
+	if err != nil {
+		return result, err
+	}
+	changes := make(map[string]schedule.OccurrenceChange)
+	added := make(map[string][]schedule.Schedule) // one-off sessions by date
+	if deps.ChangeStore != nil {
+		list, err := deps.ChangeStore.ListByDateRange(ctx, result.From, result.To)
+		if err != nil {
+			return result, err
+		}
+		for _, c := range list {
+			changes[c.ScheduleID+"|"+c.ClassDate] = c
+			if c.IsAdded() {
+				added[c.ClassDate] = append(added[c.ClassDate], c.Session())
+			}
+		}
+	}
+	overrides, err := deps.OverrideStore.ListOverrides(ctx, result.From, result.To)
+	if err != nil {
+		return result, err
+	}
+	overrideCoach := make(map[string]string, len(overrides))
+	for _, o := range overrides {
+		overrideCoach[o.ScheduleID+"|"+o.ClassDate] = o.CoachID
+	}
+	calendars, err := coverageCalendars(ctx, result.From, result.To, deps.AvailabilityStore)
+	if err != nil {
+		return result, err
+	}
+
+	var slots []coverageSlot
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		var daySessions []schedule.Schedule
+		if inAnyTerm(terms, d) && !onAnyHoliday(holidays, d) {
+			for _, s := range schedules {
+				change := changes[s.ID+"|"+date]
+				if moved, ok := change.Apply(s); ok && s.OccursOn(d) {
+					daySessions = append(daySessions, moved)
+				}
+			}
+		}
+		daySessions = append(daySessions, added[date]...)
+		sort.SliceStable(daySessions, func(i, j int) bool { return daySessions[i].StartTime < daySessions[j].StartTime })
+		for _, s := range daySessions {
+			key := s.ID + "|" + date
+			change := changes[key]
+			slot := coverageSlot{sched: s, date: d, coachID: overrideCoach[key]}
+			if slot.coachID == "" {
+				if change.Substitute != "" {
+					slot.covered = true
+				} else {
+					slot.coachID = s.CoachID
+				}
+			}
+			slots = append(slots, slot)
+		}
+	}
+	result.Classes = len(slots)
+
+	coaches, err := coverageCoaches(ctx, deps)
+	if err != nil {
+		return result, err
+	}
+	names := make(map[string]string, len(coaches))
+	for _, c := range coaches {
+		names[c.CoachID] = c.Name
+	}
+	classNames := map[string]string{}
+	for _, slot := range slots {
+		if slot.covered {
+			continue
+		}
+		reason := CoverageNoCoach
+		if slot.coachID != "" {
+			cal := calendarOf(calendars, slot.coachID)
+			if cal.StatusAt(slot.date, slot.sched.StartTime, slot.sched.EndTime) != availability.Unavailable {
+				continue
+			}
+			reason = CoverageUnavailable
+		}
+		if _, ok := classNames[slot.sched.ClassTypeID]; !ok {
+			classNames[slot.sched.ClassTypeID] = ""
+			if ct, err := deps.ClassTypeStore.GetByID(ctx, slot.sched.ClassTypeID); err == nil {
+				classNames[slot.sched.ClassTypeID] = ct.Name
+			}
+		}
+		u := UncoveredClass{
+			ScheduleID:    slot.sched.ID,
+			ClassDate:     slot.date.Format("2006-01-02"),
+			StartTime:     slot.sched.StartTime,
+			EndTime:       slot.sched.EndTime,
+			ClassTypeName: classNames[slot.sched.ClassTypeID],
+			CoachID:       slot.coachID,
+			CoachName:     names[slot.coachID],
+			Reason:        reason,
+			Suggestions:   []CoverageCoach{},
+		}
+		for _, c := range coaches {
+			cal := calendarOf(calendars, c.CoachID)
+			if c.CoachID == slot.coachID || cal.StatusAt(slot.date, slot.sched.StartTime, slot.sched.EndTime) != availability.Available {
+				continue
+			}
+			if teachingAt(slots, c.CoachID, slot) {
+				continue
+			}
+			u.Suggestions = append(u.Suggestions, c)
+		}
+		result.Uncovered = append(result.Uncovered, u)
+	}
+	return result, nil
+}
+
+// coverageCalendars groups every coach's windows, and their exceptions within the dates, by coach.
+func coverageCalendars(ctx context.Context, from, to string, store CoverageAvailabilityStore) (map[string]*availability.Calendar, error) {
+	windows, err := store.ListWindows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exceptions, err := store.ListExceptions(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	calendars := map[string]*availability.Calendar{}
+	get := func(coachID string) *availability.Calendar {
+		if calendars[coachID] == nil {
+			calendars[coachID] = &availability.Calendar{CoachID: coachID}
+		}
+		return calendars[coachID]
+	}
+	for _, w := range windows {
+		get(w.CoachID).Windows = append(get(w.CoachID).Windows, w)
+	}
+	for _, e := range exceptions {
+		get(e.CoachID).Exceptions = append(get(e.CoachID).Exceptions, e)
+	}
+	return calendars, nil
+}
+
+// calendarOf returns the coach's calendar, or an empty one for a coach who has set no availability.
+func calendarOf(calendars map[string]*availability.Calendar, coachID string) *availability.Calendar {
+	if cal, ok := calendars[coachID]; ok {
+		return cal
+	}
+	return &availability.Calendar{CoachID: coachID}
+}
+
+// coverageCoaches lists coach and admin accounts by name, falling back to email.
+func coverageCoaches(ctx context.Context, deps GetClassCoverageDeps) ([]CoverageCoach, error) {
+	var coaches []CoverageCoach
+	for _, role := range []string{account.RoleCoach, account.RoleAdmin} {
+		accounts, err := deps.AccountStore.List(ctx, accountStore.ListFilter{Role: role, Limit: 1000})
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range accounts {
+			if a.Role != role || a.IsSuspended() {
+				continue
+			}
+			name := a.Email
+			if deps.MemberStore != nil {
+				if m, err := deps.MemberStore.GetByAccountID(ctx, a.ID); err == nil && m.Name != "" {
+					name = m.Name
+				}
+			}
+			coaches = append(coaches, CoverageCoach{CoachID: a.ID, Name: name})
+		}
+	}
+	sort.SliceStable(coaches, func(i, j int) bool { return strings.ToLower(coaches[i].Name) < strings.ToLower(coaches[j].Name) })
+	return coaches, nil
+}
+
+// teachingAt reports whether the coach is down to teach another class overlapping the slot.
+func teachingAt(slots []coverageSlot, coachID string, slot coverageSlot) bool {
+	start, end, ok := slot.sched.Minutes()
+	if !ok {
+		return false
+	}
+	for _, other := range slots {
+		if other.coachID != coachID || !other.date.Equal(slot.date) || other.sched.ID == slot.sched.ID {
+			continue
+		}
+		if os, oe, ok := other.sched.Minutes(); ok && os < end && start < oe {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	accountStore "workshop/internal/adapters/storage/account"
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/availability"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/term"
+	"workshop/internal/domain/timesheet"
+)
+
+// --- Mock stores for class coverage tests ---
+
+type mockCoverageAvailabilityStore struct {
+	windows    []availability.Window
+	exceptions []availability.Exception
+}
+
+// ListWindows returns all windows.
+// PRE: none
+// POST: Returns the windows
+func (m *mockCoverageAvailabilityStore) ListWindows(_ context.Context) ([]availability.Window, error) {
+	return m.windows, nil
+}
+
+// ListExceptions returns exceptions whose date falls in the range.
+// PRE: from and to are YYYY-MM-DD
+// POST: Returns matching exceptions
+func (m *mockCoverageAvailabilityStore) ListExceptions(_ context.Context, from, to string) ([]availability.Exception, error) {
+	var out []availability.Exception
+	for _, e := range m.exceptions {
+		if e.Date >= from && e.Date <= to {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+type mockCoverageAccountStore struct {
+	accounts []account.Account
+}
+
+// List returns the accounts with the filter's role.
+// PRE: none
+// POST: Returns matching accounts
+func (m *mockCoverageAccountStore) List(_ context.Context, filter accountStore.ListFilter) ([]account.Account, error) {
+	var out []account.Account
+	for _, a := range m.accounts {
+		if a.Role == filter.Role {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+// TestQueryGetClassCoverage verifies classes with no coach or an unavailable coach are flagged
+// with available, free coaches suggested, while substitutes, overrides and cancellations count.
+func TestQueryGetClassCoverage(t *testing.T) {
+	deps := GetClassCoverageDeps{
+		ScheduleStore: &mockCTScheduleStore{schedules: []schedule.Schedule{
+			{ID: "s-mon", ClassTypeID: "ct1", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:30", CoachID: "coach-1"},
+			{ID: "s-kids", ClassTypeID: "ct1", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:00", CoachID: "coach-3"},
+			{ID: "s-wed", ClassTypeID: "ct1", Day: schedule.Wednesday, StartTime: "18:00", EndTime: "19:00", CoachID: "coach-2"},
+			{ID: "s-sat", ClassTypeID: "ct2", Day: schedule.Saturday, StartTime: "10:00", EndTime: "11:00"},
+		}},
+		TermStore: &mockTCTermStore{terms: []term.Term{
+			{ID: "t1", Name: "Term 1", StartDate: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)},
+		}},
+		HolidayStore: &mockTCHolidayStore{},
+		ChangeStore: &mockTCChangeStore{changes: []schedule.OccurrenceChange{
+			{ScheduleID: "s-sat", ClassDate: "2026-03-14", Kind: schedule.ChangeSubstitute, Substitute: "Guest Coach"},
+			{ScheduleID: "s-mon", ClassDate: "2026-03-23", Kind: schedule.ChangeCancelled},
+		}},
+		ClassTypeStore: &mockTCClassTypeStore{},
+		OverrideStore: &mockCTTimesheetStore{overrides: []timesheet.Override{
+			{ScheduleID: "s-sat", ClassDate: "2026-03-21", CoachID: "coach-3"},
+		}},
+		AvailabilityStore: &mockCoverageAvailabilityStore{
+			windows: []availability.Window{
+				{CoachID: "coach-1", Day: "monday", StartTime: "17:00", EndTime: "21:00"},
+				{CoachID: "coach-3", Day: "monday", StartTime: "17:00", EndTime: "21:00"},
+				{CoachID: "coach-3", Day: "saturday", StartTime: "09:00", EndTime: "12:00"},
+			},
+			exceptions: []availability.Exception{{CoachID: "coach-1", Date: "2026-03-09", Reason: "Seminar"}},
+		},
+		AccountStore: &mockCoverageAccountStore{accounts: []account.Account{
+			{ID: "coach-1", Email: "one@example.com", Role: account.RoleCoach},
+			{ID: "coach-2", Email: "two@example.com", Role: account.RoleCoach},
+			{ID: "coach-3", Email: "three@example.com", Role: account.RoleCoach},
+		}},
+	}
+
+	now := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC) // a Monday
+	result, err := QueryGetClassCoverage(context.Background(), now, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.From != "2026-03-02" || result.To != "2026-03-29" {
+		t.Errorf("window = %s..%s, want four weeks from today", result.From, result.To)
+	}
+
+	var got []string
+	for _, u := range result.Uncovered {
+		var suggested []string
+		for _, s := range u.Suggestions {
+			suggested = append(suggested, s.CoachID)
+		}
+		got = append(got, fmt.Sprintf("%s %s %s %v", u.ClassDate, u.ScheduleID, u.Reason, suggested))
+	}
+	want := []string{
+		"2026-03-07 s-sat no_coach [coach-3]",
+		"2026-03-09 s-mon coach_unavailable []", // coach-3 is teaching kids at the time
+		"2026-03-28 s-sat no_coach [coach-3]",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("uncovered = %v, want %v", got, want)
+	}
+	if result.Uncovered[1].CoachName != "one@example.com" {
+		t.Errorf("CoachName = %q, want the unavailable coach's email", result.Uncovered[1].CoachName)
+	}
+}
@@ -0,0 +1,231 @@
+package projections
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	feedbackStore "workshop/internal/adapters/storage/feedback"
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/feedback"
+	domainMember "workshop/internal/domain/member"
+	"workshop/internal/domain/rotor"
+)
+
+// FeedbackReportStore defines the feedback store interface needed by the class feedback report.
+type FeedbackReportStore interface {
+	List(ctx context.Context, filter feedbackStore.ListFilter) ([]feedback.Rating, error)
+}
+
+// FeedbackReportAttendanceStore defines the attendance store interface needed to size classes.
+type FeedbackReportAttendanceStore interface {
+	ListDistinctMemberIDsByScheduleAndDate(ctx context.Context, scheduleID string, classDate string) ([]string, error)
+}
+
+// FeedbackReportClassTypeStore defines the class type store interface needed to name classes.
+type FeedbackReportClassTypeStore interface {
+	GetByID(ctx context.Context, id string) (classtype.ClassType, error)
+}
+
+// FeedbackReportAccountStore defines the account store interface needed to name coaches.
+type FeedbackReportAccountStore interface {
+	GetByID(ctx context.Context, id string) (account.Account, error)
+}
+
+// FeedbackReportMemberStore defines the member store interface needed to name coaches.
+type FeedbackReportMemberStore interface {
+	GetByAccountID(ctx context.Context, accountID string) (domainMember.Member, error)
+}
+
+// FeedbackReportTopicStore defines the rotor store interface needed to name topics.
+type FeedbackReportTopicStore interface {
+	GetTopic(ctx context.Context, id string) (rotor.Topic, error)
+}
+
+// GetClassFeedbackReportQuery carries input for the class feedback report.
+type GetClassFeedbackReportQuery struct {
+	From    string // YYYY-MM-DD; empty = no lower bound
+	To      string // YYYY-MM-DD; empty = no upper bound
+	CoachID string // only this coach's classes; empty = every coach
+}
+
+// GetClassFeedbackReportDeps holds dependencies for the class feedback report.
+type GetClassFeedbackReportDeps struct {
+	FeedbackStore   FeedbackReportStore
+	AttendanceStore FeedbackReportAttendanceStore
+	ClassTypeStore  FeedbackReportClassTypeStore
+	AccountStore    FeedbackReportAccountStore
+	MemberStore     FeedbackReportMemberStore
+	TopicStore      FeedbackReportTopicStore
+}
+
+// FeedbackGroup aggregates the ratings for one class type, coach or topic.
+type FeedbackGroup struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Ratings int      `json:"ratings"`
+	Average *float64 `json:"average"` // nil below feedback.MinRatingsForAverage ratings
+}
+
+// FeedbackComment is one comment, shown without its date, score or author.
+type FeedbackComment struct {
+	ClassType string `json:"class_type"`
+	Text      string `json:"text"`
+}
+
+// ClassFeedbackReport summarises class ratings without identifying who gave them.
+type ClassFeedbackReport struct {
+	From             string            `json:"from"`
+	To               string            `json:"to"`
+	Ratings          int               `json:"ratings"`
+	Average          *float64          `json:"average"`
+	ByClassType      []FeedbackGroup   `json:"by_class_type"`
+	ByCoach          []FeedbackGroup   `json:"by_coach"`
+	ByTopic          []FeedbackGroup   `json:"by_topic"`
+	Comments         []FeedbackComment `json:"comments"`
+	WithheldComments int               `json:"withheld_comments"` // from classes below feedback.MinClassSizeForComments
+	MinRatings       int               `json:"min_ratings"`
+	MinClassSize     int               `json:"min_class_size"`
+}
+
+// QueryGetClassFeedbackReport aggregates class ratings by class type, coach and rotor topic.
+// Averages need feedback.MinRatingsForAverage ratings, and comments are only shown for classes
+// at least feedback.MinClassSizeForComments members checked in to, grouped by class type and
+// sorted so neither the class date nor the order they were written gives the author away.
+// PRE: From and To, when set, are YYYY-MM-DD
+// POST: Returns the report; groups are sorted by name
+func QueryGetClassFeedbackReport(ctx context.Context, query GetClassFeedbackReportQuery, deps GetClassFeedbackReportDeps) (ClassFeedbackReport, error) {
+	report := ClassFeedbackReport{
+		From:         query.From,
+		To:           query.To,
+		ByClassType:  []FeedbackGroup{},
+		ByCoach:      []FeedbackGroup{},
+		ByTopic:      []FeedbackGroup{},
+		Comments:     []FeedbackComment{},
+		MinRatings:   feedback.MinRatingsForAverage,
+		MinClassSize: feedback.MinClassSizeForComments,
+	}
+	ratings, err := deps.FeedbackStore.List(ctx, feedbackStore.ListFilter{From: query.From, To: query.To, CoachID: query.CoachID})
+	if err != nil {
+		return report, err
+	}
+
+	classTypes := newFeedbackTally()
+	coaches := newFeedbackTally()
+	topics := newFeedbackTally()
+	total := 0
+	classSizes := map[string]int{}
+	for _, r := range ratings {
+		report.Ratings++
+		total += r.Score
+		classTypes.add(r.ClassTypeID, r.Score)
+		if r.CoachID != "" {
+			coaches.add(r.CoachID, r.Score)
+		}
+		for _, id := range r.TopicIDs {
+			topics.add(id, r.Score)
+		}
+		if r.Comment == "" {
+			continue
+		}
+		key := r.ScheduleID + "|" + r.ClassDate
+		size, ok := classSizes[key]
+		if !ok {
+			ids, err := deps.AttendanceStore.ListDistinctMemberIDsByScheduleAndDate(ctx, r.ScheduleID, r.ClassDate)
+			if err != nil {
+				return report, err
+			}
+			size = len(ids)
+			classSizes[key] = size
+		}
+		if size < feedback.MinClassSizeForComments {
+			report.WithheldComments++
+			continue
+		}
+		report.Comments = append(report.Comments, FeedbackComment{ClassType: r.ClassTypeID, Text: r.Comment})
+	}
+	report.Average = feedbackAverage(report.Ratings, total)
+
+	classNames := map[string]string{}
+	report.ByClassType = classTypes.groups(func(id string) string {
+		if ct, err := deps.ClassTypeStore.GetByID(ctx, id); err == nil {
+			classNames[id] = ct.Name
+		}
+		return classNames[id]
+	})
+	report.ByCoach = coaches.groups(func(id string) string {
+		if deps.MemberStore != nil {
+			if m, err := deps.MemberStore.GetByAccountID(ctx, id); err == nil && m.Name != "" {
+				return m.Name
+			}
+		}
+		if a, err := deps.AccountStore.GetByID(ctx, id); err == nil {
+			return a.Email
+		}
+		return ""
+	})
+	report.ByTopic = topics.groups(func(id string) string {
+		if t, err := deps.TopicStore.GetTopic(ctx, id); err == nil {
+			return t.Name
+		}
+		return ""
+	})
+
+	for i := range report.Comments {
+		if name := classNames[report.Comments[i].ClassType]; name != "" {
+			report.Comments[i].ClassType = name
+		}
+	}
+	sort.Slice(report.Comments, func(i, j int) bool {
+		if report.Comments[i].ClassType != report.Comments[j].ClassType {
+			return report.Comments[i].ClassType < report.Comments[j].ClassType
+		}
+		return report.Comments[i].Text < report.Comments[j].Text
+	})
+	return report, nil
+}
+
+// feedbackTally sums scores per group ID.
+type feedbackTally struct {
+	counts map[string]int
+	totals map[string]int
+}
+
+func newFeedbackTally() *feedbackTally {
+	return &feedbackTally{counts: map[string]int{}, totals: map[string]int{}}
+}
+
+func (t *feedbackTally) add(id string, score int) {
+	t.counts[id]++
+	t.totals[id] += score
+}
+
+// groups names each group and hides averages below the minimum, sorted by name then ID.
+func (t *feedbackTally) groups(name func(id string) string) []FeedbackGroup {
+	result := make([]FeedbackGroup, 0, len(t.counts))
+	for id, n := range t.counts {
+		g := FeedbackGroup{ID: id, Name: name(id), Ratings: n, Average: feedbackAverage(n, t.totals[id])}
+		if g.Name == "" {
+			g.Name = id
+		}
+		result = append(result, g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// feedbackAverage is the mean score to one decimal place, or nil when too few ratings would
+// let a single member's score be worked out.
+func feedbackAverage(n, total int) *float64 {
+	if n < feedback.MinRatingsForAverage {
+		return nil
+	}
+	avg := math.Round(float64(total)/float64(n)*10) / 10
+	return &avg
+}
@@ -0,0 +1,121 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	feedbackStore "workshop/internal/adapters/storage/feedback"
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/feedback"
+	"workshop/internal/domain/rotor"
+)
+
+type mockFeedbackReportStore struct {
+	ratings    []feedback.Rating
+	classSizes map[string]int // by schedule ID + date
+}
+
+// List returns the ratings for the filter's coach.
+// PRE: none
+// POST: Returns matching ratings
+func (m *mockFeedbackReportStore) List(_ context.Context, filter feedbackStore.ListFilter) ([]feedback.Rating, error) {
+	var result []feedback.Rating
+	for _, r := range m.ratings {
+		if filter.CoachID == "" || r.CoachID == filter.CoachID {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// ListDistinctMemberIDsByScheduleAndDate returns one ID per member in the class.
+// PRE: scheduleID and classDate are non-empty
+// POST: Returns the class's members
+func (m *mockFeedbackReportStore) ListDistinctMemberIDsByScheduleAndDate(_ context.Context, scheduleID, classDate string) ([]string, error) {
+	ids := make([]string, m.classSizes[scheduleID+classDate])
+	for i := range ids {
+		ids[i] = fmt.Sprintf("m%d", i)
+	}
+	return ids, nil
+}
+
+// GetByID returns a class type named after its ID.
+// PRE: id is non-empty
+// POST: Returns the class type
+func (m *mockFeedbackReportStore) GetByID(_ context.Context, id string) (classtype.ClassType, error) {
+	return classtype.ClassType{ID: id, Name: "Class " + id}, nil
+}
+
+// GetTopic returns a topic named after its ID.
+// PRE: id is non-empty
+// POST: Returns the topic
+func (m *mockFeedbackReportStore) GetTopic(_ context.Context, id string) (rotor.Topic, error) {
+	return rotor.Topic{ID: id, Name: "Topic " + id}, nil
+}
+
+type feedbackReportAccounts map[string]account.Account
+
+// GetByID returns an account by ID.
+// PRE: id is non-empty
+// POST: Returns the account or an error
+func (a feedbackReportAccounts) GetByID(_ context.Context, id string) (account.Account, error) {
+	if acct, ok := a[id]; ok {
+		return acct, nil
+	}
+	return account.Account{}, errors.New("account not found")
+}
+
+// TestQueryGetClassFeedbackReport verifies ratings are averaged per class type, coach and topic,
+// small groups show no average, and comments from small classes are withheld.
+func TestQueryGetClassFeedbackReport(t *testing.T) {
+	store := &mockFeedbackReportStore{
+		ratings: []feedback.Rating{
+			{ScheduleID: "s1", ClassTypeID: "fun", CoachID: "c1", ClassDate: "2026-03-02", Score: 5, Comment: "Zippy warm-up", TopicIDs: []string{"dlr"}},
+			{ScheduleID: "s1", ClassTypeID: "fun", CoachID: "c1", ClassDate: "2026-03-02", Score: 4, Comment: "Clear demo", TopicIDs: []string{"dlr"}},
+			{ScheduleID: "s1", ClassTypeID: "fun", CoachID: "c1", ClassDate: "2026-03-02", Score: 4, TopicIDs: []string{"dlr"}},
+			{ScheduleID: "s2", ClassTypeID: "comp", CoachID: "c2", ClassDate: "2026-03-03", Score: 2, Comment: "Too many rounds"},
+		},
+		classSizes: map[string]int{"s12026-03-02": 6, "s22026-03-03": 2},
+	}
+	deps := GetClassFeedbackReportDeps{
+		FeedbackStore:   store,
+		AttendanceStore: store,
+		ClassTypeStore:  store,
+		AccountStore:    feedbackReportAccounts{"c1": {ID: "c1", Email: "coach@example.com"}},
+		TopicStore:      store,
+	}
+
+	report, err := QueryGetClassFeedbackReport(context.Background(), GetClassFeedbackReportQuery{}, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Ratings != 4 || report.Average == nil || *report.Average != 3.8 {
+		t.Errorf("overall = %d ratings, average %v; want 4 and 3.8", report.Ratings, report.Average)
+	}
+	if len(report.ByClassType) != 2 || report.ByClassType[0].Name != "Class comp" || report.ByClassType[0].Average != nil {
+		t.Errorf("by class type = %+v; want comp first with its single rating's average hidden", report.ByClassType)
+	}
+	if fun := report.ByClassType[1]; fun.Ratings != 3 || fun.Average == nil || *fun.Average != 4.3 {
+		t.Errorf("fun = %+v; want 3 ratings averaging 4.3", fun)
+	}
+	if len(report.ByCoach) != 2 || report.ByCoach[1].Name != "coach@example.com" {
+		t.Errorf("by coach = %+v", report.ByCoach)
+	}
+	if len(report.ByTopic) != 1 || report.ByTopic[0].Name != "Topic dlr" || report.ByTopic[0].Ratings != 3 {
+		t.Errorf("by topic = %+v", report.ByTopic)
+	}
+	if len(report.Comments) != 2 || report.Comments[0].Text != "Clear demo" || report.Comments[0].ClassType != "Class fun" {
+		t.Errorf("comments = %+v; want the two from the large class in text order", report.Comments)
+	}
+	if report.WithheldComments != 1 {
+		t.Errorf("withheld = %d, want the small class's comment withheld", report.WithheldComments)
+	}
+
+	mine, err := QueryGetClassFeedbackReport(context.Background(), GetClassFeedbackReportQuery{CoachID: "c2"}, deps)
+	if err != nil || mine.Ratings != 1 || len(mine.Comments) != 0 {
+		t.Errorf("coach c2 report = %+v, %v", mine, err)
+	}
+}
@@ -0,0 +1,197 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/classpack"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/schedule"
+)
+
+// ClassPackReportPackStore defines the class pack store interface needed for the class pack report.
+type ClassPackReportPackStore interface {
+	List(ctx context.Context) ([]classpack.ClassPack, error)
+	ListUses(ctx context.Context) ([]classpack.Use, error)
+}
+
+// ClassPackReportMemberStore defines the member store interface needed for the class pack report.
+type ClassPackReportMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+}
+
+// ClassPackReportAttendanceStore defines the attendance store interface needed to attribute pack revenue.
+type ClassPackReportAttendanceStore interface {
+	GetByID(ctx context.Context, id string) (attendance.Attendance, error)
+}
+
+// ClassPackReportScheduleStore defines the schedule store interface needed to attribute pack revenue.
+type ClassPackReportScheduleStore interface {
+	GetByID(ctx context.Context, id string) (schedule.Schedule, error)
+}
+
+// ClassPackReportClassTypeStore defines the class type store interface needed to attribute pack revenue.
+type ClassPackReportClassTypeStore interface {
+	GetByID(ctx context.Context, id string) (classtype.ClassType, error)
+}
+
+// GetClassPackReportDeps holds dependencies for the class pack report.
+type GetClassPackReportDeps struct {
+	PackStore       ClassPackReportPackStore
+	MemberStore     ClassPackReportMemberStore
+	AttendanceStore ClassPackReportAttendanceStore
+	ScheduleStore   ClassPackReportScheduleStore
+	ClassTypeStore  ClassPackReportClassTypeStore
+}
+
+// ClassPackRow is one pack still to be used up, or expired with classes left.
+type ClassPackRow struct {
+	PackID           string
+	MemberID         string
+	MemberName       string
+	Status           string // classpack.StatusActive or StatusExpired
+	Classes          int
+	Used             int
+	Remaining        int
+	PriceCents       int
+	EarnedCents      int
+	OutstandingCents int // owed to the member while active; forfeited once expired
+	PurchasedAt      time.Time
+	ExpiresOn        string
+	Note             string
+}
+
+// ClassPackRevenue is the pack revenue earned by classes of one type.
+type ClassPackRevenue struct {
+	ClassTypeID   string // empty for check-ins with no class, or whose class is gone
+	ClassTypeName string
+	Classes       int
+	EarnedCents   int
+}
+
+// ClassPackReport lists outstanding and expired packs and where pack revenue was earned.
+type ClassPackReport struct {
+	Packs          []ClassPackRow
+	Sold           int // packs sold, of every status
+	Exhausted      int // packs fully used
+	SoldCents      int
+	EarnedCents    int // revenue for classes used
+	UnearnedCents  int // revenue for classes left on active packs
+	ForfeitedCents int // revenue for classes left on expired packs
+	ByClassType    []ClassPackRevenue
+}
+
+// QueryGetClassPackReport builds the class pack report: every active pack and every expired
+// pack with classes left, and the revenue sold, earned, still owed and forfeited. Each class
+// used earns its share of the pack's price, attributed to the class type it was used for.
+// PRE: now is the current time
+// POST: Returns active packs soonest expiry first, then expired packs; revenue by class type
+// most earned first
+func QueryGetClassPackReport(ctx context.Context, now time.Time, deps GetClassPackReportDeps) (ClassPackReport, error) {
+	packs, err := deps.PackStore.List(ctx)
+	if err != nil {
+		return ClassPackReport{}, err
+	}
+	uses, err := deps.PackStore.ListUses(ctx)
+	if err != nil {
+		return ClassPackReport{}, err
+	}
+
+	report := ClassPackReport{Packs: []ClassPackRow{}, ByClassType: []ClassPackRevenue{}}
+	byID := make(map[string]classpack.ClassPack, len(packs))
+	for _, p := range packs {
+		byID[p.ID] = p
+		report.Sold++
+		report.SoldCents += p.PriceCents
+		report.EarnedCents += p.EarnedCents()
+		status := p.Status(now)
+		switch status {
+		case classpack.StatusExhausted:
+			report.Exhausted++
+			continue
+		case classpack.StatusActive:
+			report.UnearnedCents += p.OutstandingCents()
+		case classpack.StatusExpired:
+			report.ForfeitedCents += p.OutstandingCents()
+		}
+		row := ClassPackRow{
+			PackID: p.ID, MemberID: p.MemberID, MemberName: p.MemberID, Status: status,
+			Classes: p.Classes, Used: p.Used, Remaining: p.Remaining(),
+			PriceCents: p.PriceCents, EarnedCents: p.EarnedCents(), OutstandingCents: p.OutstandingCents(),
+			PurchasedAt: p.PurchasedAt, ExpiresOn: p.ExpiresOn, Note: p.Note,
+		}
+		if m, err := deps.MemberStore.GetByID(ctx, p.MemberID); err == nil {
+			row.MemberName = m.Name
+		}
+		report.Packs = append(report.Packs, row)
+	}
+	sort.SliceStable(report.Packs, func(i, j int) bool {
+		a, b := report.Packs[i], report.Packs[j]
+		if a.Status != b.Status {
+			return a.Status == classpack.StatusActive
+		}
+		if a.ExpiresOn != b.ExpiresOn {
+			if a.ExpiresOn == "" || b.ExpiresOn == "" {
+				return b.ExpiresOn == ""
+			}
+			return a.ExpiresOn < b.ExpiresOn
+		}
+		return a.MemberName < b.MemberName
+	})
+
+	report.ByClassType = classPackRevenueByClassType(ctx, byID, uses, deps)
+	return report, nil
+}
+
+// classPackRevenueByClassType attributes each class used to the class type of its check-in.
+// Uses are numbered per pack in the order they were made, so the shares add up to each pack's
+// earned revenue.
+func classPackRevenueByClassType(ctx context.Context, packs map[string]classpack.ClassPack, uses []classpack.Use, deps GetClassPackReportDeps) []ClassPackRevenue {
+	sort.SliceStable(uses, func(i, j int) bool { return uses[i].UsedAt.Before(uses[j].UsedAt) })
+	nth := map[string]int{}
+	classTypeOf := map[string]string{} // schedule ID -> class type ID
+	revenue := map[string]*ClassPackRevenue{}
+	var order []string
+	for _, u := range uses {
+		p, ok := packs[u.PackID]
+		if !ok {
+			continue
+		}
+		nth[u.PackID]++
+		classTypeID := ""
+		if a, err := deps.AttendanceStore.GetByID(ctx, u.AttendanceID); err == nil && a.ScheduleID != "" {
+			id, seen := classTypeOf[a.ScheduleID]
+			if !seen {
+				if sched, err := deps.ScheduleStore.GetByID(ctx, a.ScheduleID); err == nil {
+					id = sched.ClassTypeID
+				}
+				classTypeOf[a.ScheduleID] = id
+			}
+			classTypeID = id
+		}
+		r, ok := revenue[classTypeID]
+		if !ok {
+			r = &ClassPackRevenue{ClassTypeID: classTypeID, ClassTypeName: "No class"}
+			if classTypeID != "" {
+				r.ClassTypeName = classTypeID
+				if ct, err := deps.ClassTypeStore.GetByID(ctx, classTypeID); err == nil {
+					r.ClassTypeName = ct.Name
+				}
+			}
+			revenue[classTypeID] = r
+			order = append(order, classTypeID)
+		}
+		r.Classes++
+		r.EarnedCents += p.UseCents(nth[u.PackID])
+	}
+
+	out := make([]ClassPackRevenue, 0, len(order))
+	for _, id := range order {
+		out = append(out, *revenue[id])
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].EarnedCents > out[j].EarnedCents })
+	return out
+}
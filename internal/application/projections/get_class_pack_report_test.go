@@ -0,0 +1,163 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/classpack"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/schedule"
+)
+
+// mockPackReportStore implements the class pack report's stores for testing.
+type mockPackReportStore struct {
+	packs      []classpack.ClassPack
+	uses       []classpack.Use
+	members    map[string]member.Member
+	attendance map[string]attendance.Attendance
+	schedules  map[string]schedule.Schedule
+	classTypes map[string]classtype.ClassType
+}
+
+// List implements ClassPackReportPackStore for testing.
+// PRE: none
+// POST: Returns the stored packs
+func (m *mockPackReportStore) List(_ context.Context) ([]classpack.ClassPack, error) {
+	return m.packs, nil
+}
+
+// ListUses implements ClassPackReportPackStore for testing.
+// PRE: none
+// POST: Returns the stored uses
+func (m *mockPackReportStore) ListUses(_ context.Context) ([]classpack.Use, error) {
+	return m.uses, nil
+}
+
+// mockPackReportMembers implements ClassPackReportMemberStore for testing.
+type mockPackReportMembers struct{ s *mockPackReportStore }
+
+// GetByID implements ClassPackReportMemberStore for testing.
+// PRE: none
+// POST: Returns the stored member or an error
+func (m mockPackReportMembers) GetByID(_ context.Context, id string) (member.Member, error) {
+	if mem, ok := m.s.members[id]; ok {
+		return mem, nil
+	}
+	return member.Member{}, errors.New("not found")
+}
+
+// mockPackReportAttendance implements ClassPackReportAttendanceStore for testing.
+type mockPackReportAttendance struct{ s *mockPackReportStore }
+
+// GetByID implements ClassPackReportAttendanceStore for testing.
+// PRE: none
+// POST: Returns the stored attendance or an error
+func (m mockPackReportAttendance) GetByID(_ context.Context, id string) (attendance.Attendance, error) {
+	if a, ok := m.s.attendance[id]; ok {
+		return a, nil
+	}
+	return attendance.Attendance{}, errors.New("not found")
+}
+
+// mockPackReportSchedules implements ClassPackReportScheduleStore for testing.
+type mockPackReportSchedules struct{ s *mockPackReportStore }
+
+// GetByID implements ClassPackReportScheduleStore for testing.
+// PRE: none
+// POST: Returns the stored schedule or an error
+func (m mockPackReportSchedules) GetByID(_ context.Context, id string) (schedule.Schedule, error) {
+	if sched, ok := m.s.schedules[id]; ok {
+		return sched, nil
+	}
+	return schedule.Schedule{}, errors.New("not found")
+}
+
+// mockPackReportClassTypes implements ClassPackReportClassTypeStore for testing.
+type mockPackReportClassTypes struct{ s *mockPackReportStore }
+
+// GetByID implements ClassPackReportClassTypeStore for testing.
+// PRE: none
+// POST: Returns the stored class type or an error
+func (m mockPackReportClassTypes) GetByID(_ context.Context, id string) (classtype.ClassType, error) {
+	if ct, ok := m.s.classTypes[id]; ok {
+		return ct, nil
+	}
+	return classtype.ClassType{}, errors.New("not found")
+}
+
+// TestQueryGetClassPackReport verifies outstanding and expired packs are listed, used-up packs
+// only counted, and earned revenue is split by the class type each class was used for.
+func TestQueryGetClassPackReport(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	bought := now.AddDate(0, 0, -30)
+	s := &mockPackReportStore{
+		packs: []classpack.ClassPack{
+			{ID: "active", MemberID: "m1", Classes: 3, Used: 2, PriceCents: 5000, PurchasedAt: bought, ExpiresOn: "2026-04-01"},
+			{ID: "expired", MemberID: "m2", Classes: 3, Used: 1, PriceCents: 4500, PurchasedAt: bought, ExpiresOn: "2026-03-01"},
+			{ID: "done", MemberID: "m3", Classes: 1, Used: 1, PriceCents: 2000, PurchasedAt: bought},
+		},
+		uses: []classpack.Use{
+			{AttendanceID: "a2", PackID: "active", UsedAt: bought.AddDate(0, 0, 2)},
+			{AttendanceID: "a1", PackID: "active", UsedAt: bought.AddDate(0, 0, 1)},
+			{AttendanceID: "a3", PackID: "expired", UsedAt: bought.AddDate(0, 0, 1)},
+			{AttendanceID: "a4", PackID: "done", UsedAt: bought.AddDate(0, 0, 1)},
+		},
+		members: map[string]member.Member{"m1": {ID: "m1", Name: "Alice"}, "m2": {ID: "m2", Name: "Bob"}},
+		attendance: map[string]attendance.Attendance{
+			"a1": {ID: "a1", ScheduleID: "fundamentals"},
+			"a2": {ID: "a2", ScheduleID: "nogi"},
+			"a3": {ID: "a3", ScheduleID: "fundamentals"},
+			"a4": {ID: "a4"},
+		},
+		schedules: map[string]schedule.Schedule{
+			"fundamentals": {ID: "fundamentals", ClassTypeID: "ct-fund"},
+			"nogi":         {ID: "nogi", ClassTypeID: "ct-nogi"},
+		},
+		classTypes: map[string]classtype.ClassType{
+			"ct-fund": {ID: "ct-fund", Name: "Fundamentals"},
+			"ct-nogi": {ID: "ct-nogi", Name: "No-Gi"},
+		},
+	}
+	report, err := QueryGetClassPackReport(context.Background(), now, GetClassPackReportDeps{
+		PackStore:       s,
+		MemberStore:     mockPackReportMembers{s},
+		AttendanceStore: mockPackReportAttendance{s},
+		ScheduleStore:   mockPackReportSchedules{s},
+		ClassTypeStore:  mockPackReportClassTypes{s},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(report.Packs) != 2 || report.Packs[0].PackID != "active" || report.Packs[1].PackID != "expired" {
+		t.Fatalf("Packs = %+v, want active then expired", report.Packs)
+	}
+	if report.Packs[0].MemberName != "Alice" || report.Packs[0].Remaining != 1 {
+		t.Errorf("active row = %+v", report.Packs[0])
+	}
+	if report.Sold != 3 || report.Exhausted != 1 || report.SoldCents != 11500 {
+		t.Errorf("sold %d exhausted %d sold cents %d, want 3, 1, 11500", report.Sold, report.Exhausted, report.SoldCents)
+	}
+	// active earns 3333 of 5000, expired 1500 of 4500, done all 2000.
+	if report.EarnedCents != 6833 || report.UnearnedCents != 1667 || report.ForfeitedCents != 3000 {
+		t.Errorf("earned %d unearned %d forfeited %d, want 6833, 1667, 3000", report.EarnedCents, report.UnearnedCents, report.ForfeitedCents)
+	}
+
+	want := []ClassPackRevenue{
+		{ClassTypeID: "ct-fund", ClassTypeName: "Fundamentals", Classes: 2, EarnedCents: 1666 + 1500},
+		{ClassTypeID: "", ClassTypeName: "No class", Classes: 1, EarnedCents: 2000},
+		{ClassTypeID: "ct-nogi", ClassTypeName: "No-Gi", Classes: 1, EarnedCents: 1667},
+	}
+	if len(report.ByClassType) != len(want) {
+		t.Fatalf("ByClassType = %+v, want %+v", report.ByClassType, want)
+	}
+	for i, w := range want {
+		if report.ByClassType[i] != w {
+			t.Errorf("ByClassType[%d] = %+v, want %+v", i, report.ByClassType[i], w)
+		}
+	}
+}
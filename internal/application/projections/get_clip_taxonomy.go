@@ -0,0 +1,67 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"workshop/internal/domain/clip"
+)
+
+// ClipTaxonomyStore defines the clip tag store interface needed by this projection.
+type ClipTaxonomyStore interface {
+	ListTags(ctx context.Context) ([]clip.Tag, error)
+	CountClipsByTag(ctx context.Context) (map[string]int, error)
+}
+
+// GetClipTaxonomyDeps holds dependencies for the projection.
+type GetClipTaxonomyDeps struct {
+	TagStore ClipTaxonomyStore
+}
+
+// TaxonomyTag is a tag with the number of clips carrying it.
+type TaxonomyTag struct {
+	ID        string
+	Name      string
+	ClipCount int
+}
+
+// TaxonomyCategory groups the tags of one category for browsing.
+type TaxonomyCategory struct {
+	Category string
+	Tags     []TaxonomyTag
+}
+
+// QueryGetClipTaxonomy groups clip tags by category with clip counts, for the browse-by-position library view.
+// PRE: none
+// POST: Returns one entry per category in clip.Categories order, each with tags sorted by name
+func QueryGetClipTaxonomy(ctx context.Context, deps GetClipTaxonomyDeps) ([]TaxonomyCategory, error) {
+	tags, err := deps.TagStore.ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := deps.TagStore.CountClipsByTag(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byCategory := make(map[string][]TaxonomyTag)
+	for _, t := range tags {
+		category := t.Category
+		if !clip.IsValidCategory(category) {
+			category = clip.CategoryGeneral
+		}
+		byCategory[category] = append(byCategory[category], TaxonomyTag{ID: t.ID, Name: t.Name, ClipCount: counts[t.ID]})
+	}
+
+	result := make([]TaxonomyCategory, 0, len(clip.Categories))
+	for _, category := range clip.Categories {
+		list := byCategory[category]
+		if list == nil {
+			list = []TaxonomyTag{}
+		}
+		sort.SliceStable(list, func(i, j int) bool { return strings.ToLower(list[i].Name) < strings.ToLower(list[j].Name) })
+		result = append(result, TaxonomyCategory{Category: category, Tags: list})
+	}
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package projections
+
+import (
+	"context"
+	"testing"
+
+	"workshop/internal/domain/clip"
+)
+
+type mockClipTaxonomyStore struct {
+	tags   []clip.Tag
+	counts map[string]int
+}
+
+// ListTags returns the seeded tags.
+// PRE: none
+// POST: Returns the seeded tags
+func (m *mockClipTaxonomyStore) ListTags(_ context.Context) ([]clip.Tag, error) {
+	return m.tags, nil
+}
+
+// CountClipsByTag returns the seeded counts.
+// PRE: none
+// POST: Returns the seeded counts
+func (m *mockClipTaxonomyStore) CountClipsByTag(_ context.Context) (map[string]int, error) {
+	return m.counts, nil
+}
+
+// TestQueryGetClipTaxonomy groups tags by category in display order with clip counts.
+func TestQueryGetClipTaxonomy(t *testing.T) {
+	deps := GetClipTaxonomyDeps{TagStore: &mockClipTaxonomyStore{
+		tags: []clip.Tag{
+			{ID: "t1", Name: "Mount", Category: clip.CategoryPosition},
+			{ID: "t2", Name: "half guard", Category: clip.CategoryPosition},
+			{ID: "t3", Name: "Sweep", Category: clip.CategoryTechnique},
+			{ID: "t4", Name: "Legacy", Category: ""},
+		},
+		counts: map[string]int{"t2": 4, "t3": 1},
+	}}
+
+	got, err := QueryGetClipTaxonomy(context.Background(), deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(clip.Categories) {
+		t.Fatalf("expected %d categories, got %d", len(clip.Categories), len(got))
+	}
+	position := got[0]
+	if position.Category != clip.CategoryPosition || len(position.Tags) != 2 {
+		t.Fatalf("unexpected position group: %+v", position)
+	}
+	if position.Tags[0].Name != "half guard" || position.Tags[0].ClipCount != 4 || position.Tags[1].ClipCount != 0 {
+		t.Errorf("expected half guard (4) before Mount (0), got %+v", position.Tags)
+	}
+	if got[2].Category != clip.CategoryAttire || len(got[2].Tags) != 0 {
+		t.Errorf("expected empty attire group, got %+v", got[2])
+	}
+	if general := got[3]; len(general.Tags) != 1 || general.Tags[0].ID != "t4" {
+		t.Errorf("expected uncategorised tag under general, got %+v", general)
+	}
+}
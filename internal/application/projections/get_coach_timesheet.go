@@ -0,0 +1,258 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/holiday"
+	domainMember "workshop/internal/domain/member"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/term"
+	"workshop/internal/domain/timesheet"
+)
+
+// CoachTimesheetScheduleStore defines the schedule store interface needed by the coach timesheet.
+type CoachTimesheetScheduleStore interface {
+	List(ctx context.Context) ([]schedule.Schedule, error)
+}
+
+// CoachTimesheetTermStore defines the term store interface needed by the coach timesheet.
+type CoachTimesheetTermStore interface {
+	List(ctx context.Context) ([]term.Term, error)
+}
+
+// CoachTimesheetHolidayStore defines the holiday store interface needed by the coach timesheet.
+type CoachTimesheetHolidayStore interface {
+	List(ctx context.Context) ([]holiday.Holiday, error)
+}
+
+// CoachTimesheetChangeStore defines the occurrence change store interface needed by the coach timesheet.
+type CoachTimesheetChangeStore interface {
+	ListByDateRange(ctx context.Context, from, to string) ([]schedule.OccurrenceChange, error)
+}
+
+// CoachTimesheetClassTypeStore defines the class type store interface needed by the coach timesheet.
+type CoachTimesheetClassTypeStore interface {
+	GetByID(ctx context.Context, id string) (classtype.ClassType, error)
+}
+
+// CoachTimesheetStore defines the timesheet store interface needed by the coach timesheet.
+type CoachTimesheetStore interface {
+	ListOverrides(ctx context.Context, from, to string) ([]timesheet.Override, error)
+	ListRates(ctx context.Context) ([]timesheet.Rate, error)
+}
+
+// CoachTimesheetAccountStore defines the account store interface needed by the coach timesheet.
+type CoachTimesheetAccountStore interface {
+	GetByID(ctx context.Context, id string) (account.Account, error)
+}
+
+// CoachTimesheetMemberStore defines the member store interface needed by the coach timesheet.
+type CoachTimesheetMemberStore interface {
+	GetByAccountID(ctx context.Context, accountID string) (domainMember.Member, error)
+}
+
+// GetCoachTimesheetDeps holds dependencies for the coach timesheet.
+type GetCoachTimesheetDeps struct {
+	ScheduleStore  CoachTimesheetScheduleStore
+	TermStore      CoachTimesheetTermStore
+	HolidayStore   CoachTimesheetHolidayStore
+	ChangeStore    CoachTimesheetChangeStore // optional: nil ignores cancellations and substitutes
+	ClassTypeStore CoachTimesheetClassTypeStore
+	TimesheetStore CoachTimesheetStore
+	AccountStore   CoachTimesheetAccountStore
+	MemberStore    CoachTimesheetMemberStore // optional: nil shows coaches by email only
+}
+
+// GetCoachTimesheetQuery selects the month to total.
+type GetCoachTimesheetQuery struct {
+	Month string // YYYY-MM
+}
+
+// CoachTimesheetSession is one class occurrence on the timesheet.
+type CoachTimesheetSession struct {
+	timesheet.CoachSession
+	ClassTypeName string
+	CoachName     string
+}
+
+// CoachTimesheetTotal is one coach's line on the payroll.
+type CoachTimesheetTotal struct {
+	timesheet.Total
+	Name  string
+	Email string
+}
+
+// CoachTimesheetResult carries the output of the coach timesheet.
+type CoachTimesheetResult struct {
+	Month      string
+	From       string // first day counted, YYYY-MM-DD
+	To         string // last day counted: the month's end, or today for the current month
+	Sessions   []CoachTimesheetSession
+	Totals     []CoachTimesheetTotal
+	Unassigned int // sessions nobody will be paid for until a coach is assigned
+}
+
+// QueryGetCoachTimesheet resolves every class that ran in a month and who coached it,
+// then totals hours and pay per coach.
+// Algorithm: 1) Walk the month's dates up to today, 2) keep schedules that run on the date
+// inside a term and outside holidays, 3) drop cancelled occurrences, take moved times and add
+// one-off sessions, 4) assign each
+// occurrence its override coach or the schedule's regular coach, 5) total per coach.
+// PRE: query.Month is YYYY-MM; now is the current time
+// POST: Returns sessions ordered by date then start time; nothing is written
+func QueryGetCoachTimesheet(ctx context.Context, query GetCoachTimesheetQuery, now time.Time, deps GetCoachTimesheetDeps) (CoachTimesheetResult, error) {
+	first, last, err := timesheet.ParseMonth(query.Month)
+	if err != nil {
+		return CoachTimesheetResult{}, err
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if last.After(today) {
+		last = today // classes later this month have not run yet
+	}
+	result := CoachTimesheetResult{
+		Month:    query.Month,
+		From:     first.Format("2006-01-02"),
+		To:       last.Format("2006-01-02"),
+		Sessions: []CoachTimesheetSession{},
+		Totals:   []CoachTimesheetTotal{},
+	}
+	if last.Before(first) {
+		return result, nil
+	}
+
+	schedules, err := deps.ScheduleStore.List(ctx)
+	if err != nil {
+		return result, err
+	}
+	sort.SliceStable(schedules, func(i, j int) bool { return schedules[i].StartTime < schedules[j].StartTime })
+	terms, err := deps.TermStore.List(ctx)
+	if err != nil {
+		return result, err
+	}
+	holidays, err := deps.HolidayStore.List(ctx)
+	if err != nil {
+		return result, err
+	}
+	changes := make(map[string]schedule.OccurrenceChange)
+	added := make(map[string][]schedule.Schedule) // one-off sessions by date
+	if deps.ChangeStore != nil {
+		list, err := deps.ChangeStore.ListByDateRange(ctx, result.From, result.To)
+		if err != nil {
+			return result, err
+		}
+		for _, c := range list {
+			changes[c.ScheduleID+"|"+c.ClassDate] = c
+			if c.IsAdded() {
+				added[c.ClassDate] = append(added[c.ClassDate], c.Session())
+			}
+		}
+	}
+	overrides, err := deps.TimesheetStore.ListOverrides(ctx, result.From, result.To)
+	if err != nil {
+		return result, err
+	}
+	overrideCoach := make(map[string]string, len(overrides))
+	for _, o := range overrides {
+		overrideCoach[o.ScheduleID+"|"+o.ClassDate] = o.CoachID
+	}
+	rateList, err := deps.TimesheetStore.ListRates(ctx)
+	if err != nil {
+		return result, err
+	}
+	rates := make(map[string]int, len(rateList))
+	for _, r := range rateList {
+		rates[r.CoachID] = r.HourlyRate
+	}
+
+	classNames := map[string]string{}
+	var sessions []timesheet.CoachSession
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		var daySessions []schedule.Schedule
+		if inAnyTerm(terms, d) && !onAnyHoliday(holidays, d) {
+			for _, s := range schedules {
+				change := changes[s.ID+"|"+date]
+				if moved, ok := change.Apply(s); ok && s.OccursOn(d) {
+					daySessions = append(daySessions, moved)
+				}
+			}
+		}
+		daySessions = append(daySessions, added[date]...)
+		sort.SliceStable(daySessions, func(i, j int) bool { return daySessions[i].StartTime < daySessions[j].StartTime })
+		for _, s := range daySessions {
+			change := changes[s.ID+"|"+date]
+			hours, err := s.DurationHours()
+			if err != nil {
+				continue // Skip schedules with unreadable times
+			}
+			session := timesheet.CoachSession{ScheduleID: s.ID, ClassDate: date, StartTime: s.StartTime, EndTime: s.EndTime, Hours: hours}
+			session.Assign(s.CoachID, overrideCoach[s.ID+"|"+date], change.Substitute)
+			if session.CoachID == "" {
+				result.Unassigned++
+			}
+			if _, ok := classNames[s.ClassTypeID]; !ok {
+				if ct, err := deps.ClassTypeStore.GetByID(ctx, s.ClassTypeID); err == nil {
+					classNames[s.ClassTypeID] = ct.Name
+				} else {
+					classNames[s.ClassTypeID] = ""
+				}
+			}
+			sessions = append(sessions, session)
+			result.Sessions = append(result.Sessions, CoachTimesheetSession{CoachSession: session, ClassTypeName: classNames[s.ClassTypeID]})
+		}
+	}
+
+	names := map[string]string{}
+	emails := map[string]string{}
+	for _, t := range timesheet.Summarise(sessions, rates) {
+		name, email := coachIdentity(ctx, t.CoachID, deps)
+		names[t.CoachID], emails[t.CoachID] = name, email
+		result.Totals = append(result.Totals, CoachTimesheetTotal{Total: t, Name: name, Email: email})
+	}
+	for i := range result.Sessions {
+		if id := result.Sessions[i].CoachID; id != "" {
+			result.Sessions[i].CoachName = names[id]
+			if result.Sessions[i].CoachName == "" {
+				result.Sessions[i].CoachName = emails[id]
+			}
+		}
+	}
+	return result, nil
+}
+
+// coachIdentity looks up a coach's name and email; either may be empty if the account is gone.
+func coachIdentity(ctx context.Context, coachID string, deps GetCoachTimesheetDeps) (name, email string) {
+	if a, err := deps.AccountStore.GetByID(ctx, coachID); err == nil {
+		email = a.Email
+	}
+	if deps.MemberStore != nil {
+		if m, err := deps.MemberStore.GetByAccountID(ctx, coachID); err == nil {
+			name = m.Name
+		}
+	}
+	return name, email
+}
+
+// inAnyTerm reports whether date falls inside one of the terms.
+func inAnyTerm(terms []term.Term, date time.Time) bool {
+	for _, t := range terms {
+		if t.Contains(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// onAnyHoliday reports whether date falls on one of the holidays.
+func onAnyHoliday(holidays []holiday.Holiday, date time.Time) bool {
+	for _, h := range holidays {
+		if h.Contains(date) {
+			return true
+		}
+	}
+	return false
+}
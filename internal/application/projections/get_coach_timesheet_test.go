@@ -0,0 +1,148 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/term"
+	"workshop/internal/domain/timesheet"
+)
+
+// --- Mock stores for coach timesheet tests ---
+
+type mockCTScheduleStore struct {
+	schedules []schedule.Schedule
+}
+
+// List returns all schedules.
+// PRE: none
+// POST: Returns a copy of the schedules
+func (m *mockCTScheduleStore) List(_ context.Context) ([]schedule.Schedule, error) {
+	return append([]schedule.Schedule(nil), m.schedules...), nil
+}
+
+type mockCTTimesheetStore struct {
+	overrides []timesheet.Override
+	rates     []timesheet.Rate
+}
+
+// ListOverrides returns overrides whose class date falls in the range.
+// PRE: from and to are YYYY-MM-DD
+// POST: Returns matching overrides
+func (m *mockCTTimesheetStore) ListOverrides(_ context.Context, from, to string) ([]timesheet.Override, error) {
+	var out []timesheet.Override
+	for _, o := range m.overrides {
+		if o.ClassDate >= from && o.ClassDate <= to {
+			out = append(out, o)
+		}
+	}
+	return out, nil
+}
+
+// ListRates returns all rates.
+// PRE: none
+// POST: Returns rates
+func (m *mockCTTimesheetStore) ListRates(_ context.Context) ([]timesheet.Rate, error) {
+	return m.rates, nil
+}
+
+type mockCTAccountStore struct{}
+
+// GetByID returns an account whose email is derived from the ID.
+// PRE: id is non-empty
+// POST: Returns the account or an error for unknown IDs
+func (m *mockCTAccountStore) GetByID(_ context.Context, id string) (account.Account, error) {
+	if id == "" {
+		return account.Account{}, fmt.Errorf("account not found")
+	}
+	return account.Account{ID: id, Email: id + "@example.com", Role: account.RoleCoach}, nil
+}
+
+// newCoachTimesheetDeps builds March 2026 with a Monday evening class run by coach-1
+// and a Saturday class with no regular coach.
+func newCoachTimesheetDeps() GetCoachTimesheetDeps {
+	return GetCoachTimesheetDeps{
+		ScheduleStore: &mockCTScheduleStore{schedules: []schedule.Schedule{
+			{ID: "s-mon", ClassTypeID: "ct1", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:30", CoachID: "coach-1"},
+			{ID: "s-sat", ClassTypeID: "ct2", Day: schedule.Saturday, StartTime: "10:00", EndTime: "11:00"},
+		}},
+		TermStore: &mockTCTermStore{terms: []term.Term{
+			{ID: "t1", Name: "Term 1", StartDate: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)},
+		}},
+		HolidayStore: &mockTCHolidayStore{},
+		ChangeStore: &mockTCChangeStore{changes: []schedule.OccurrenceChange{
+			{ScheduleID: "s-mon", ClassDate: "2026-03-09", Kind: schedule.ChangeCancelled},
+		}},
+		ClassTypeStore: &mockTCClassTypeStore{},
+		TimesheetStore: &mockCTTimesheetStore{
+			overrides: []timesheet.Override{
+				{ScheduleID: "s-mon", ClassDate: "2026-03-16", CoachID: "coach-2"},
+				{ScheduleID: "s-sat", ClassDate: "2026-03-07", CoachID: "coach-1"},
+			},
+			rates: []timesheet.Rate{{CoachID: "coach-1", HourlyRate: 4000}},
+		},
+		AccountStore: &mockCTAccountStore{},
+	}
+}
+
+// TestQueryGetCoachTimesheet_TotalsPerCoach verifies cancellations are skipped, overrides
+// move sessions between coaches and classes after today are not counted.
+func TestQueryGetCoachTimesheet_TotalsPerCoach(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	result, err := QueryGetCoachTimesheet(context.Background(), GetCoachTimesheetQuery{Month: "2026-03"}, now, newCoachTimesheetDeps())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.To != "2026-03-20" {
+		t.Errorf("To = %q, want today", result.To)
+	}
+
+	var got []string
+	for _, s := range result.Sessions {
+		got = append(got, s.ClassDate+" "+s.ScheduleID+" "+s.CoachID)
+	}
+	want := []string{
+		"2026-03-02 s-mon coach-1",
+		"2026-03-07 s-sat coach-1",
+		"2026-03-14 s-sat ",
+		"2026-03-16 s-mon coach-2",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("sessions = %v, want %v", got, want)
+	}
+	if result.Unassigned != 1 {
+		t.Errorf("Unassigned = %d, want 1", result.Unassigned)
+	}
+
+	if len(result.Totals) != 2 {
+		t.Fatalf("totals = %+v, want two coaches", result.Totals)
+	}
+	c1 := result.Totals[0]
+	if c1.CoachID != "coach-1" || c1.Sessions != 2 || c1.Hours != 2.5 || c1.Pay != 10000 || c1.Email != "coach-1@example.com" {
+		t.Errorf("coach-1 total = %+v, want 2 sessions, 2.5h, 10000 cents", c1)
+	}
+	if c2 := result.Totals[1]; c2.CoachID != "coach-2" || c2.Hours != 1.5 || c2.Pay != 0 {
+		t.Errorf("coach-2 total = %+v, want 1.5h and no pay without a rate", c2)
+	}
+}
+
+// TestQueryGetCoachTimesheet_FutureAndInvalidMonths verifies a month that has not started
+// is empty and a malformed month is rejected.
+func TestQueryGetCoachTimesheet_FutureAndInvalidMonths(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	result, err := QueryGetCoachTimesheet(context.Background(), GetCoachTimesheetQuery{Month: "2026-04"}, now, newCoachTimesheetDeps())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Sessions) != 0 || len(result.Totals) != 0 {
+		t.Errorf("future month = %+v, want no sessions", result)
+	}
+
+	if _, err := QueryGetCoachTimesheet(context.Background(), GetCoachTimesheetQuery{Month: "March"}, now, newCoachTimesheetDeps()); err != timesheet.ErrInvalidMonth {
+		t.Errorf("error = %v, want ErrInvalidMonth", err)
+	}
+}
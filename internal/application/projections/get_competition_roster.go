@@ -0,0 +1,136 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"workshop/internal/domain/calendar"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/member"
+)
+
+// CompetitionRosterEventStore defines the calendar event store interface needed by this projection.
+type CompetitionRosterEventStore interface {
+	GetByID(ctx context.Context, id string) (calendar.Event, error)
+}
+
+// CompetitionRosterInterestStore defines the competition interest store interface needed by this projection.
+type CompetitionRosterInterestStore interface {
+	GetInterestsByEvent(ctx context.Context, eventID string) ([]calendar.CompetitionInterest, error)
+}
+
+// CompetitionRosterMemberStore defines the member store interface needed by this projection.
+type CompetitionRosterMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+}
+
+// CompetitionRosterGradingRecordStore defines the grading record store interface needed by this projection.
+type CompetitionRosterGradingRecordStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]grading.Record, error)
+}
+
+// GetCompetitionRosterDeps holds dependencies for the competition roster projection.
+type GetCompetitionRosterDeps struct {
+	EventStore         CompetitionRosterEventStore
+	InterestStore      CompetitionRosterInterestStore
+	MemberStore        CompetitionRosterMemberStore
+	GradingRecordStore CompetitionRosterGradingRecordStore
+}
+
+// CompetitionRosterEntry is one member on a competition roster.
+type CompetitionRosterEntry struct {
+	MemberID    string
+	MemberName  string
+	MemberEmail string
+	Program     string
+	Belt        string // latest promotion; empty if never graded
+	Stripe      int
+	WeightClass string
+	Status      string // interested or registered
+	RespondedAt string // YYYY-MM-DD
+}
+
+// CompetitionRosterResult carries the output of the competition roster projection.
+type CompetitionRosterResult struct {
+	EventID    string
+	EventTitle string
+	EventDate  string // YYYY-MM-DD
+	Interested int
+	Registered int
+	Entries    []CompetitionRosterEntry
+}
+
+// QueryGetCompetitionRoster aggregates member responses for a competition into a team roster.
+// Registered members come first, then by weight class and name, so the list reads like a
+// registration-day sheet. Members that no longer exist are skipped.
+// PRE: eventID is non-empty
+// POST: Returns the roster with per-status counts, or calendar.ErrNotCompetition
+func QueryGetCompetitionRoster(ctx context.Context, eventID string, deps GetCompetitionRosterDeps) (CompetitionRosterResult, error) {
+	ev, err := deps.EventStore.GetByID(ctx, eventID)
+	if err != nil {
+		return CompetitionRosterResult{}, err
+	}
+	if ev.Type != calendar.TypeCompetition {
+		return CompetitionRosterResult{}, calendar.ErrNotCompetition
+	}
+
+	interests, err := deps.InterestStore.GetInterestsByEvent(ctx, eventID)
+	if err != nil {
+		return CompetitionRosterResult{}, err
+	}
+
+	result := CompetitionRosterResult{
+		EventID:    ev.ID,
+		EventTitle: ev.Title,
+		EventDate:  ev.StartDate.Format("2006-01-02"),
+		Entries:    []CompetitionRosterEntry{},
+	}
+	for _, ci := range interests {
+		m, err := deps.MemberStore.GetByID(ctx, ci.MemberID)
+		if err != nil {
+			continue
+		}
+		entry := CompetitionRosterEntry{
+			MemberID:    m.ID,
+			MemberName:  m.Name,
+			MemberEmail: m.Email,
+			Program:     m.Program,
+			WeightClass: ci.WeightClass,
+			Status:      calendar.InterestStatusInterested,
+			RespondedAt: ci.CreatedAt.Format("2006-01-02"),
+		}
+		if ci.IsRegistered() {
+			entry.Status = calendar.InterestStatusRegistered
+			result.Registered++
+		} else {
+			result.Interested++
+		}
+
+		if deps.GradingRecordStore != nil {
+			if records, err := deps.GradingRecordStore.ListByMemberID(ctx, m.ID); err == nil && len(records) > 0 {
+				latest := records[0]
+				for _, r := range records[1:] {
+					if r.PromotedAt.After(latest.PromotedAt) {
+						latest = r
+					}
+				}
+				entry.Belt = latest.Belt
+				entry.Stripe = latest.Stripe
+			}
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	sort.SliceStable(result.Entries, func(i, j int) bool {
+		a, b := result.Entries[i], result.Entries[j]
+		if a.Status != b.Status {
+			return a.Status == calendar.InterestStatusRegistered
+		}
+		if wa, wb := strings.ToLower(a.WeightClass), strings.ToLower(b.WeightClass); wa != wb {
+			return wa < wb
+		}
+		return strings.ToLower(a.MemberName) < strings.ToLower(b.MemberName)
+	})
+	return result, nil
+}
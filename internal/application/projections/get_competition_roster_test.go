@@ -0,0 +1,106 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/calendar"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/member"
+)
+
+type mockCREventStore struct{}
+
+// GetByID implements CompetitionRosterEventStore.
+// PRE: id is non-empty
+// POST: returns a competition for "comp", a club event for "bbq", otherwise an error
+func (m *mockCREventStore) GetByID(_ context.Context, id string) (calendar.Event, error) {
+	switch id {
+	case "comp":
+		return calendar.Event{ID: id, Title: "NZ Grappling Nationals", Type: calendar.TypeCompetition, StartDate: time.Date(2026, 5, 9, 0, 0, 0, 0, time.UTC)}, nil
+	case "bbq":
+		return calendar.Event{ID: id, Title: "BBQ", Type: calendar.TypeEvent}, nil
+	}
+	return calendar.Event{}, errors.New("not found")
+}
+
+type mockCRInterestStore struct {
+	interests []calendar.CompetitionInterest
+}
+
+// GetInterestsByEvent implements CompetitionRosterInterestStore.
+// PRE: eventID is non-empty
+// POST: returns all seeded interests
+func (m *mockCRInterestStore) GetInterestsByEvent(_ context.Context, _ string) ([]calendar.CompetitionInterest, error) {
+	return m.interests, nil
+}
+
+type mockCRMemberStore struct{}
+
+// GetByID implements CompetitionRosterMemberStore.
+// PRE: id is non-empty
+// POST: returns a member for m1..m3, otherwise an error
+func (m *mockCRMemberStore) GetByID(_ context.Context, id string) (member.Member, error) {
+	names := map[string]string{"m1": "Yuki", "m2": "Marcus", "m3": "Ana"}
+	name, ok := names[id]
+	if !ok {
+		return member.Member{}, errors.New("not found")
+	}
+	return member.Member{ID: id, Name: name, Program: member.ProgramAdults}, nil
+}
+
+type mockCRGradingRecordStore struct{}
+
+// ListByMemberID implements CompetitionRosterGradingRecordStore.
+// PRE: memberID is non-empty
+// POST: returns two promotions for m1, none otherwise
+func (m *mockCRGradingRecordStore) ListByMemberID(_ context.Context, memberID string) ([]grading.Record, error) {
+	if memberID != "m1" {
+		return nil, nil
+	}
+	return []grading.Record{
+		{MemberID: "m1", Belt: "white", Stripe: 4, PromotedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{MemberID: "m1", Belt: "blue", Stripe: 1, PromotedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil
+}
+
+// TestQueryGetCompetitionRoster verifies counts, latest belt and registration-day ordering.
+func TestQueryGetCompetitionRoster(t *testing.T) {
+	deps := GetCompetitionRosterDeps{
+		EventStore: &mockCREventStore{},
+		InterestStore: &mockCRInterestStore{interests: []calendar.CompetitionInterest{
+			{EventID: "comp", MemberID: "m1", Status: calendar.InterestStatusRegistered, WeightClass: "-82kg"},
+			{EventID: "comp", MemberID: "m2", Status: calendar.InterestStatusInterested},
+			{EventID: "comp", MemberID: "m3", Status: calendar.InterestStatusRegistered, WeightClass: "-64kg"},
+			{EventID: "comp", MemberID: "gone", Status: calendar.InterestStatusRegistered},
+		}},
+		MemberStore:        &mockCRMemberStore{},
+		GradingRecordStore: &mockCRGradingRecordStore{},
+	}
+
+	got, err := QueryGetCompetitionRoster(context.Background(), "comp", deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Registered != 2 || got.Interested != 1 || got.EventDate != "2026-05-09" {
+		t.Errorf("unexpected summary: %+v", got)
+	}
+	order := []string{"Ana", "Yuki", "Marcus"}
+	if len(got.Entries) != len(order) {
+		t.Fatalf("expected %d entries, got %+v", len(order), got.Entries)
+	}
+	for i, name := range order {
+		if got.Entries[i].MemberName != name {
+			t.Errorf("entry %d = %s, want %s", i, got.Entries[i].MemberName, name)
+		}
+	}
+	if e := got.Entries[1]; e.Belt != "blue" || e.Stripe != 1 {
+		t.Errorf("expected latest belt blue/1, got %s/%d", e.Belt, e.Stripe)
+	}
+
+	if _, err := QueryGetCompetitionRoster(context.Background(), "bbq", deps); !errors.Is(err, calendar.ErrNotCompetition) {
+		t.Errorf("expected ErrNotCompetition for club event, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"workshop/internal/domain/contactcheck"
+	emailDomain "workshop/internal/domain/email"
+	"workshop/internal/domain/member"
+)
+
+// ContactHygieneEmailStore defines the email store interface needed for the bad contact details report.
+type ContactHygieneEmailStore interface {
+	ListUndeliveredRecipients(ctx context.Context) ([]emailDomain.Recipient, error)
+	ListSuppressions(ctx context.Context) ([]emailDomain.Suppression, error)
+}
+
+// ContactHygieneMemberStore defines the member store interface needed for the bad contact details report.
+type ContactHygieneMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+	GetByEmail(ctx context.Context, email string) (member.Member, error)
+}
+
+// ContactHygieneCheckStore defines the contact check store interface needed for the bad contact details report.
+type ContactHygieneCheckStore interface {
+	List(ctx context.Context) ([]contactcheck.Check, error)
+}
+
+// GetContactHygieneDeps holds dependencies for the bad contact details report.
+type GetContactHygieneDeps struct {
+	EmailStore  ContactHygieneEmailStore
+	MemberStore ContactHygieneMemberStore
+	CheckStore  ContactHygieneCheckStore
+}
+
+// ContactHygieneMember is one member whose email address on file is not getting mail.
+type ContactHygieneMember struct {
+	MemberID     string
+	Name         string
+	Email        string
+	MemberStatus string
+	HasAccount   bool                // the address is also the member's sign-in
+	Status       string              // latest failed delivery to the address: bounced, complained or failed; empty when only suppressed
+	Detail       string              // provider message for the latest bounce, or the suppression's
+	Failures     int                 // undelivered emails to the address
+	LastFailedAt time.Time           // latest failure or suppression
+	Suppression  string              // hard_bounce or complaint while the address is suppressed
+	Check        *contactcheck.Check // the kiosk confirmation asked for this address, if any
+}
+
+// QueryGetContactHygiene combines failed email deliveries and the suppression list with member
+// records into the bad contact details report. Only failures to the address a member has now
+// count, so a member drops off once their email is corrected. Archived members are left out.
+// PRE: none
+// POST: Returns members latest failure first; an empty list when every address is getting mail
+func QueryGetContactHygiene(ctx context.Context, deps GetContactHygieneDeps) ([]ContactHygieneMember, error) {
+	recipients, err := deps.EmailStore.ListUndeliveredRecipients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	suppressions, err := deps.EmailStore.ListSuppressions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	suppressed := map[string]emailDomain.Suppression{}
+	for _, s := range suppressions {
+		suppressed[s.Address] = s
+	}
+	checks := map[string]contactcheck.Check{}
+	if deps.CheckStore != nil {
+		list, err := deps.CheckStore.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range list {
+			checks[c.MemberID] = c
+		}
+	}
+
+	rows := map[string]*ContactHygieneMember{}
+	reported := map[string]bool{} // suppressed addresses already on a member's row
+	add := func(m member.Member) *ContactHygieneMember {
+		if row, ok := rows[m.ID]; ok {
+			return row
+		}
+		row := &ContactHygieneMember{MemberID: m.ID, Name: m.Name, Email: m.Email, MemberStatus: m.Status, HasAccount: m.AccountID != ""}
+		address := emailDomain.NormalizeAddress(m.Email)
+		if s, ok := suppressed[address]; ok {
+			row.Suppression, row.Detail, row.LastFailedAt = s.Reason, s.Detail, s.CreatedAt
+			reported[address] = true
+		}
+		if c, ok := checks[m.ID]; ok && emailDomain.NormalizeAddress(c.Address) == address {
+			row.Check = &c
+		}
+		rows[m.ID] = row
+		return row
+	}
+
+	members := map[string]member.Member{}
+	for _, r := range recipients { // newest first
+		m, ok := members[r.MemberID]
+		if !ok {
+			if m, err = deps.MemberStore.GetByID(ctx, r.MemberID); err != nil {
+				continue // deleted since
+			}
+			members[r.MemberID] = m
+		}
+		if m.IsArchived() || emailDomain.NormalizeAddress(r.MemberEmail) != emailDomain.NormalizeAddress(m.Email) {
+			continue
+		}
+		row := add(m)
+		row.Failures++
+		if row.Status == "" {
+			row.Status = r.DeliveryStatus
+			if r.BounceReason != "" {
+				row.Detail = r.BounceReason
+			}
+			if r.DeliveryUpdatedAt.After(row.LastFailedAt) {
+				row.LastFailedAt = r.DeliveryUpdatedAt
+			}
+		}
+	}
+	for _, s := range suppressions {
+		if reported[s.Address] {
+			continue
+		}
+		m, err := deps.MemberStore.GetByEmail(ctx, s.Address)
+		if err != nil || m.IsArchived() {
+			continue
+		}
+		add(m)
+	}
+
+	result := make([]ContactHygieneMember, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *row)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].LastFailedAt.Equal(result[j].LastFailedAt) {
+			return result[i].LastFailedAt.After(result[j].LastFailedAt)
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
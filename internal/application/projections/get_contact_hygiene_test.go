@@ -0,0 +1,123 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/contactcheck"
+	emailDomain "workshop/internal/domain/email"
+	"workshop/internal/domain/member"
+)
+
+// mockHygieneEmailStore implements ContactHygieneEmailStore for testing.
+type mockHygieneEmailStore struct {
+	recipients   []emailDomain.Recipient
+	suppressions []emailDomain.Suppression
+}
+
+// ListUndeliveredRecipients implements ContactHygieneEmailStore for testing.
+// PRE: none
+// POST: Returns the stored recipients in order
+func (m *mockHygieneEmailStore) ListUndeliveredRecipients(_ context.Context) ([]emailDomain.Recipient, error) {
+	return m.recipients, nil
+}
+
+// ListSuppressions implements ContactHygieneEmailStore for testing.
+// PRE: none
+// POST: Returns the stored suppressions in order
+func (m *mockHygieneEmailStore) ListSuppressions(_ context.Context) ([]emailDomain.Suppression, error) {
+	return m.suppressions, nil
+}
+
+// mockHygieneMemberStore implements ContactHygieneMemberStore for testing.
+type mockHygieneMemberStore struct {
+	members []member.Member
+}
+
+// GetByID implements ContactHygieneMemberStore for testing.
+// PRE: id is non-empty
+// POST: Returns the stored member or an error
+func (m *mockHygieneMemberStore) GetByID(_ context.Context, id string) (member.Member, error) {
+	for _, mem := range m.members {
+		if mem.ID == id {
+			return mem, nil
+		}
+	}
+	return member.Member{}, errors.New("not found")
+}
+
+// GetByEmail implements ContactHygieneMemberStore for testing.
+// PRE: email is non-empty
+// POST: Returns the member with the address or an error
+func (m *mockHygieneMemberStore) GetByEmail(_ context.Context, email string) (member.Member, error) {
+	for _, mem := range m.members {
+		if mem.Email == email {
+			return mem, nil
+		}
+	}
+	return member.Member{}, errors.New("not found")
+}
+
+// mockHygieneCheckStore implements ContactHygieneCheckStore for testing.
+type mockHygieneCheckStore struct {
+	checks []contactcheck.Check
+}
+
+// List implements ContactHygieneCheckStore for testing.
+// PRE: none
+// POST: Returns the stored checks
+func (m *mockHygieneCheckStore) List(_ context.Context) ([]contactcheck.Check, error) {
+	return m.checks, nil
+}
+
+// TestQueryGetContactHygiene verifies the report lists members whose current address bounced,
+// failed or is suppressed, ignores failures to addresses since corrected and archived members,
+// and carries the kiosk check asked for the address.
+func TestQueryGetContactHygiene(t *testing.T) {
+	at := func(day int) time.Time { return time.Date(2026, 3, day, 9, 0, 0, 0, time.UTC) }
+	deps := GetContactHygieneDeps{
+		EmailStore: &mockHygieneEmailStore{
+			recipients: []emailDomain.Recipient{
+				{EmailID: "e3", MemberID: "m1", MemberEmail: "jordan@example.com", DeliveryStatus: emailDomain.DeliveryFailed, DeliveryUpdatedAt: at(5)},
+				{EmailID: "e2", MemberID: "m1", MemberEmail: "jordan@example.com", DeliveryStatus: emailDomain.DeliveryBounced, DeliveryUpdatedAt: at(3), BounceReason: "mailbox full"},
+				{EmailID: "e2", MemberID: "m2", MemberEmail: "kim@old.example.com", DeliveryStatus: emailDomain.DeliveryBounced, DeliveryUpdatedAt: at(3)}, // corrected since
+				{EmailID: "e2", MemberID: "m4", MemberEmail: "lee@example.com", DeliveryStatus: emailDomain.DeliveryBounced, DeliveryUpdatedAt: at(3)},     // archived
+				{EmailID: "e1", MemberID: "gone", MemberEmail: "gone@example.com", DeliveryStatus: emailDomain.DeliveryBounced, DeliveryUpdatedAt: at(1)},
+			},
+			suppressions: []emailDomain.Suppression{
+				{Address: "sam@example.com", Reason: emailDomain.SuppressionHardBounce, Detail: "no such user", CreatedAt: at(4)},
+				{Address: "nobody@example.com", Reason: emailDomain.SuppressionComplaint, CreatedAt: at(2)},
+			},
+		},
+		MemberStore: &mockHygieneMemberStore{members: []member.Member{
+			{ID: "m1", Name: "Jordan", Email: "Jordan@Example.com", Status: member.StatusActive},
+			{ID: "m2", Name: "Kim", Email: "kim@example.com", Status: member.StatusActive},
+			{ID: "m3", Name: "Sam", Email: "sam@example.com", AccountID: "acct-sam", Status: member.StatusInactive},
+			{ID: "m4", Name: "Lee", Email: "lee@example.com", Status: member.StatusArchived},
+		}},
+		CheckStore: &mockHygieneCheckStore{checks: []contactcheck.Check{
+			{MemberID: "m1", Address: "jordan@example.com", RequestedAt: at(6)},
+			{MemberID: "m3", Address: "sam@old.example.com", RequestedAt: at(1), Resolution: contactcheck.ResolutionCorrected},
+		}},
+	}
+
+	got, err := QueryGetContactHygiene(context.Background(), deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected Jordan and Sam, got %+v", got)
+	}
+	jordan, sam := got[0], got[1]
+	if jordan.MemberID != "m1" || jordan.Failures != 2 || jordan.Status != emailDomain.DeliveryFailed || !jordan.LastFailedAt.Equal(at(5)) || jordan.Suppression != "" {
+		t.Errorf("jordan = %+v", jordan)
+	}
+	if jordan.Check == nil || !jordan.Check.Open() {
+		t.Errorf("jordan's check = %+v, want the open one", jordan.Check)
+	}
+	if sam.MemberID != "m3" || sam.Suppression != emailDomain.SuppressionHardBounce || sam.Detail != "no such user" || sam.Failures != 0 || !sam.HasAccount || sam.Check != nil {
+		t.Errorf("sam = %+v; a check for an older address should not show", sam)
+	}
+}
@@ -0,0 +1,322 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	attendanceStore "workshop/internal/adapters/storage/attendance"
+	sessionLogStore "workshop/internal/adapters/storage/sessionlog"
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/clip"
+	"workshop/internal/domain/rotor"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/sessionlog"
+	"workshop/internal/domain/theme"
+)
+
+// CurriculumHistoryDefaultDays is how far back the curriculum history looks when no start date is given.
+const CurriculumHistoryDefaultDays = 90
+
+// curriculumHistoryMaxCheckIns caps the check-ins read for the attendance overlay.
+const curriculumHistoryMaxCheckIns = 1000
+
+// ErrCurriculumHistoryClassType is returned for a class type the viewer may not see: one that
+// does not exist, or, for members, one outside their program.
+var ErrCurriculumHistoryClassType = errors.New("class type not found")
+
+// CurriculumHistoryClassTypeStore defines the class type store interface needed by the curriculum history projection.
+type CurriculumHistoryClassTypeStore interface {
+	GetByID(ctx context.Context, id string) (classtype.ClassType, error)
+}
+
+// CurriculumHistoryRotorStore defines the rotor store interface needed by the curriculum history projection.
+type CurriculumHistoryRotorStore interface {
+	ListRotorsByClassType(ctx context.Context, classTypeID string) ([]rotor.Rotor, error)
+	ListThemesByRotor(ctx context.Context, rotorID string) ([]rotor.RotorTheme, error)
+	ListTopicsByTheme(ctx context.Context, rotorThemeID string) ([]rotor.Topic, error)
+	ListSchedulesByTheme(ctx context.Context, rotorThemeID string) ([]rotor.TopicSchedule, error)
+}
+
+// CurriculumHistoryScheduleStore defines the schedule store interface needed by the curriculum history projection.
+type CurriculumHistoryScheduleStore interface {
+	ListByClassTypeID(ctx context.Context, classTypeID string) ([]schedule.Schedule, error)
+}
+
+// CurriculumHistorySessionLogStore defines the session log store interface needed by the curriculum history projection.
+type CurriculumHistorySessionLogStore interface {
+	List(ctx context.Context, filter sessionLogStore.ListFilter) ([]sessionlog.SessionLog, error)
+}
+
+// CurriculumHistoryClipStore defines the clip store interface needed by the curriculum history projection.
+type CurriculumHistoryClipStore interface {
+	ListByThemeID(ctx context.Context, themeID string) ([]clip.Clip, error)
+}
+
+// CurriculumHistoryAttendanceStore defines the attendance store interface needed for the member's overlay.
+type CurriculumHistoryAttendanceStore interface {
+	List(ctx context.Context, filter attendanceStore.ListFilter) ([]attendance.Attendance, error)
+}
+
+// GetCurriculumHistoryQuery carries input for the curriculum history projection.
+type GetCurriculumHistoryQuery struct {
+	ClassTypeID string
+	From        string    // YYYY-MM-DD; runs that ended before it are left out
+	Now         time.Time // bounds runs still recorded as open
+	Gate        BeltGate  // viewer's belt; members only see their own program's classes
+	MemberID    string    // optional: the viewer's member record, for the attendance overlay
+}
+
+// GetCurriculumHistoryDeps holds dependencies for the curriculum history projection.
+type GetCurriculumHistoryDeps struct {
+	ClassTypeStore  CurriculumHistoryClassTypeStore
+	ProgramStore    ThemeCarouselProgramStore
+	RotorStore      CurriculumHistoryRotorStore
+	ScheduleStore   CurriculumHistoryScheduleStore
+	SessionLogStore CurriculumHistorySessionLogStore
+	ThemeStore      ThemeCarouselThemeStore          // optional: nil lists no clips
+	ClipStore       CurriculumHistoryClipStore       // optional: nil lists no clips
+	AttendanceStore CurriculumHistoryAttendanceStore // optional: nil leaves the overlay out
+}
+
+// CurriculumHistoryResult carries the output of the curriculum history projection.
+type CurriculumHistoryResult struct {
+	ClassTypeID   string
+	ClassTypeName string
+	From          string
+	Personal      bool // Attended and Missed are filled for the viewer
+	Attended      int  // logged sessions the viewer attended
+	Missed        int  // logged sessions the viewer did not
+	Topics        []CurriculumHistoryTopic
+}
+
+// CurriculumHistoryTopic is one completed run of a topic.
+type CurriculumHistoryTopic struct {
+	TopicID     string
+	Name        string
+	Description string // empty when locked
+	Locked      bool
+	ThemeName   string
+	StartDate   string // YYYY-MM-DD
+	EndDate     string // YYYY-MM-DD
+	Clips       []GatedClip
+	Sessions    []CurriculumHistorySession
+	Attended    int // sessions the viewer attended
+}
+
+// CurriculumHistorySession is one logged class during a topic's run.
+type CurriculumHistorySession struct {
+	Date       string // YYYY-MM-DD
+	ScheduleID string
+	StartTime  string
+	Notes      string // the coach's notes when shared with members; empty for a locked topic
+	Attended   bool
+}
+
+// QueryGetCurriculumHistory lists a class type's completed topic runs, newest first, with the
+// clips linked to each topic's theme and the classes logged while it ran. A logged class
+// belongs to a run when its date falls inside the run and it covered the topic, or listed no
+// topics. When MemberID is set, each class says whether the viewer attended it, so members
+// can catch up on what they missed.
+// PRE: From is YYYY-MM-DD; Now is set
+// POST: Returns ErrCurriculumHistoryClassType for an unknown class type or, for members, one outside their program
+func QueryGetCurriculumHistory(ctx context.Context, query GetCurriculumHistoryQuery, deps GetCurriculumHistoryDeps) (CurriculumHistoryResult, error) {
+	result := CurriculumHistoryResult{ClassTypeID: query.ClassTypeID, From: query.From, Topics: []CurriculumHistoryTopic{}}
+	ct, err := deps.ClassTypeStore.GetByID(ctx, query.ClassTypeID)
+	if err != nil {
+		return result, ErrCurriculumHistoryClassType
+	}
+	if !query.Gate.Staff {
+		programs, err := deps.ProgramStore.List(ctx)
+		if err != nil {
+			return result, err
+		}
+		visible := false
+		for _, p := range programs {
+			visible = visible || (p.ID == ct.ProgramID && p.Type == query.Gate.Program)
+		}
+		if !visible {
+			return result, ErrCurriculumHistoryClassType
+		}
+	}
+	result.ClassTypeName = ct.Name
+
+	library := map[string][]theme.Theme{}
+	if deps.ThemeStore != nil && deps.ClipStore != nil {
+		themes, err := deps.ThemeStore.List(ctx)
+		if err != nil {
+			return result, err
+		}
+		for _, t := range themes {
+			if t.RotorThemeID != "" {
+				library[t.RotorThemeID] = append(library[t.RotorThemeID], t)
+			}
+		}
+	}
+
+	today := query.Now.Format("2006-01-02")
+	earliest := ""
+	rotors, err := deps.RotorStore.ListRotorsByClassType(ctx, ct.ID)
+	if err != nil {
+		return result, err
+	}
+	for _, r := range rotors {
+		themes, err := deps.RotorStore.ListThemesByRotor(ctx, r.ID)
+		if err != nil {
+			return result, err
+		}
+		for _, rt := range themes {
+			runs, err := curriculumHistoryRuns(ctx, rt, library[rt.ID], query, today, deps)
+			if err != nil {
+				return result, err
+			}
+			for _, run := range runs {
+				if earliest == "" || run.StartDate < earliest {
+					earliest = run.StartDate
+				}
+			}
+			result.Topics = append(result.Topics, runs...)
+		}
+	}
+	sort.SliceStable(result.Topics, func(i, j int) bool { return result.Topics[i].StartDate > result.Topics[j].StartDate })
+	if len(result.Topics) == 0 {
+		return result, nil
+	}
+
+	schedules, err := deps.ScheduleStore.ListByClassTypeID(ctx, ct.ID)
+	if err != nil {
+		return result, err
+	}
+	var logs []sessionlog.SessionLog
+	startTimes := map[string]string{}
+	for _, s := range schedules {
+		startTimes[s.ID] = s.StartTime
+		found, err := deps.SessionLogStore.List(ctx, sessionLogStore.ListFilter{ScheduleID: s.ID, From: earliest, To: today})
+		if err != nil {
+			return result, err
+		}
+		logs = append(logs, found...)
+	}
+	sort.SliceStable(logs, func(i, j int) bool { return logs[i].ClassDate < logs[j].ClassDate })
+
+	var attended map[string]bool // schedule ID + date of each check-in
+	if query.MemberID != "" && deps.AttendanceStore != nil {
+		checkIns, err := deps.AttendanceStore.List(ctx, attendanceStore.ListFilter{MemberID: query.MemberID, From: earliest, To: today, Limit: curriculumHistoryMaxCheckIns})
+		if err != nil {
+			return result, err
+		}
+		attended = map[string]bool{}
+		for _, a := range checkIns {
+			attended[a.ScheduleID+"|"+a.CheckInTime.Format("2006-01-02")] = true
+		}
+		result.Personal = true
+	}
+
+	for i := range result.Topics {
+		t := &result.Topics[i]
+		for _, l := range logs {
+			if l.ClassDate < t.StartDate || l.ClassDate > t.EndDate || (len(l.TopicIDs) > 0 && !l.CoversTopic(t.TopicID)) {
+				continue
+			}
+			session := CurriculumHistorySession{Date: l.ClassDate, ScheduleID: l.ScheduleID, StartTime: startTimes[l.ScheduleID]}
+			if l.Shareable && !t.Locked {
+				session.Notes = l.Notes
+			}
+			if attended != nil {
+				session.Attended = attended[l.ScheduleID+"|"+l.ClassDate]
+				if session.Attended {
+					t.Attended++
+					result.Attended++
+				} else {
+					result.Missed++
+				}
+			}
+			t.Sessions = append(t.Sessions, session)
+		}
+	}
+	return result, nil
+}
+
+// curriculumHistoryRuns lists one rotor theme's completed runs that ended on or after From,
+// with the clips of the library themes linked to it.
+func curriculumHistoryRuns(ctx context.Context, rt rotor.RotorTheme, linked []theme.Theme, query GetCurriculumHistoryQuery, today string, deps GetCurriculumHistoryDeps) ([]CurriculumHistoryTopic, error) {
+	history, err := deps.RotorStore.ListSchedulesByTheme(ctx, rt.ID)
+	if err != nil {
+		return nil, err
+	}
+	var completed []rotor.TopicSchedule
+	for _, h := range history {
+		if h.Status != rotor.ScheduleStatusCompleted {
+			continue
+		}
+		if end := curriculumHistoryEnd(h, today); end >= query.From {
+			completed = append(completed, h)
+		}
+	}
+	if len(completed) == 0 {
+		return nil, nil
+	}
+
+	topics, err := deps.RotorStore.ListTopicsByTheme(ctx, rt.ID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]rotor.Topic, len(topics))
+	for _, tp := range topics {
+		byID[tp.ID] = tp
+	}
+	clips, err := curriculumHistoryClips(ctx, linked, query.Gate, deps.ClipStore)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]CurriculumHistoryTopic, 0, len(completed))
+	for _, h := range completed {
+		tp, ok := byID[h.TopicID]
+		if !ok {
+			continue // the topic has since been deleted
+		}
+		gated := GateTopic(tp, query.Gate)
+		run := CurriculumHistoryTopic{
+			TopicID:     tp.ID,
+			Name:        tp.Name,
+			Description: gated.Description,
+			Locked:      gated.Locked,
+			ThemeName:   rt.Name,
+			StartDate:   h.StartDate.Format("2006-01-02"),
+			EndDate:     curriculumHistoryEnd(h, today),
+			Clips:       []GatedClip{},
+			Sessions:    []CurriculumHistorySession{},
+		}
+		if !gated.Locked {
+			run.Clips = clips
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// curriculumHistoryEnd returns the last day of a run as YYYY-MM-DD; a run with no end date counts until today.
+func curriculumHistoryEnd(h rotor.TopicSchedule, today string) string {
+	if h.EndDate.IsZero() {
+		return today
+	}
+	return h.EndDate.Format("2006-01-02")
+}
+
+// curriculumHistoryClips lists the gated clips of a rotor theme's linked library themes.
+func curriculumHistoryClips(ctx context.Context, linked []theme.Theme, gate BeltGate, store CurriculumHistoryClipStore) ([]GatedClip, error) {
+	var clips []clip.Clip
+	minBelts := make(map[string]string, len(linked))
+	for _, t := range linked {
+		minBelts[t.ID] = t.MinBelt
+		found, err := store.ListByThemeID(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		clips = append(clips, found...)
+	}
+	return GateClips(clips, minBelts, gate), nil
+}
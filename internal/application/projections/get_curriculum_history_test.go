@@ -0,0 +1,157 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/clip"
+	"workshop/internal/domain/program"
+	"workshop/internal/domain/rotor"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/sessionlog"
+	"workshop/internal/domain/theme"
+)
+
+// mockCHRotorStore implements CurriculumHistoryRotorStore for testing.
+type mockCHRotorStore struct {
+	rotors    []rotor.Rotor
+	themes    map[string][]rotor.RotorTheme    // key: rotorID
+	topics    map[string][]rotor.Topic         // key: rotorThemeID
+	schedules map[string][]rotor.TopicSchedule // key: rotorThemeID
+}
+
+// ListRotorsByClassType implements CurriculumHistoryRotorStore.
+// PRE: none
+// POST: returns the class type's rotors
+func (m *mockCHRotorStore) ListRotorsByClassType(_ context.Context, classTypeID string) ([]rotor.Rotor, error) {
+	var list []rotor.Rotor
+	for _, r := range m.rotors {
+		if r.ClassTypeID == classTypeID {
+			list = append(list, r)
+		}
+	}
+	return list, nil
+}
+
+// ListThemesByRotor implements CurriculumHistoryRotorStore.
+// PRE: none
+// POST: returns the rotor's themes
+func (m *mockCHRotorStore) ListThemesByRotor(_ context.Context, rotorID string) ([]rotor.RotorTheme, error) {
+	return m.themes[rotorID], nil
+}
+
+// ListTopicsByTheme implements CurriculumHistoryRotorStore.
+// PRE: none
+// POST: returns the theme's topics
+func (m *mockCHRotorStore) ListTopicsByTheme(_ context.Context, rotorThemeID string) ([]rotor.Topic, error) {
+	return m.topics[rotorThemeID], nil
+}
+
+// ListSchedulesByTheme implements CurriculumHistoryRotorStore.
+// PRE: none
+// POST: returns the theme's schedule history
+func (m *mockCHRotorStore) ListSchedulesByTheme(_ context.Context, rotorThemeID string) ([]rotor.TopicSchedule, error) {
+	return m.schedules[rotorThemeID], nil
+}
+
+// mockCHClipStore implements CurriculumHistoryClipStore for testing.
+type mockCHClipStore struct {
+	clips []clip.Clip
+}
+
+// ListByThemeID implements CurriculumHistoryClipStore.
+// PRE: none
+// POST: returns the theme's clips
+func (m *mockCHClipStore) ListByThemeID(_ context.Context, themeID string) ([]clip.Clip, error) {
+	var list []clip.Clip
+	for _, c := range m.clips {
+		if c.ThemeID == themeID {
+			list = append(list, c)
+		}
+	}
+	return list, nil
+}
+
+// TestQueryGetCurriculumHistory verifies completed runs are listed newest first with their
+// clips and logged classes, only shared notes reach members, and the overlay marks the
+// classes the member missed.
+func TestQueryGetCurriculumHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	deps := GetCurriculumHistoryDeps{
+		ClassTypeStore: &mockMWClassTypeStore{classTypes: map[string]classtype.ClassType{
+			"ct-fund": {ID: "ct-fund", ProgramID: "p-adults", Name: "Fundamentals"},
+		}},
+		ProgramStore: &mockCarouselProgramStore{programs: []program.Program{{ID: "p-adults", Type: program.TypeAdults}}},
+		RotorStore: &mockCHRotorStore{
+			rotors: []rotor.Rotor{{ID: "r1", ClassTypeID: "ct-fund"}},
+			themes: map[string][]rotor.RotorTheme{"r1": {{ID: "th-guard", RotorID: "r1", Name: "Guard"}}},
+			topics: map[string][]rotor.Topic{"th-guard": {
+				{ID: "tp-closed", RotorThemeID: "th-guard", Name: "Closed Guard", Description: "Posture", DurationWeeks: 1},
+				{ID: "tp-lasso", RotorThemeID: "th-guard", Name: "Lasso", Description: "Sleeve and ankle", DurationWeeks: 1, MinBelt: "blue"},
+				{ID: "tp-dlr", RotorThemeID: "th-guard", Name: "De La Riva", DurationWeeks: 1},
+			}},
+			schedules: map[string][]rotor.TopicSchedule{"th-guard": {
+				{ID: "s0", TopicID: "tp-dlr", StartDate: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 12, 7, 0, 0, 0, 0, time.UTC), Status: rotor.ScheduleStatusCompleted},
+				{ID: "s1", TopicID: "tp-closed", StartDate: day(2), EndDate: day(8), Status: rotor.ScheduleStatusCompleted},
+				{ID: "s2", TopicID: "tp-lasso", StartDate: day(9), EndDate: day(15), Status: rotor.ScheduleStatusCompleted},
+				{ID: "s3", TopicID: "tp-dlr", StartDate: day(16), Status: rotor.ScheduleStatusActive},
+			}},
+		},
+		ScheduleStore: &mockKRScheduleStore{schedules: map[string][]schedule.Schedule{
+			"ct-fund": {{ID: "sch-mon", ClassTypeID: "ct-fund", Day: schedule.Monday, StartTime: "18:00"}},
+		}},
+		SessionLogStore: &mockSLHSessionLogStore{logs: []sessionlog.SessionLog{
+			{ID: "l1", ScheduleID: "sch-mon", ClassDate: "2026-03-02", TopicIDs: []string{"tp-closed"}, Notes: "Posture drills", Shareable: true},
+			{ID: "l2", ScheduleID: "sch-mon", ClassDate: "2026-03-09", TopicIDs: []string{"tp-lasso"}, Notes: "Lasso sweeps", Shareable: true},
+			{ID: "l3", ScheduleID: "sch-mon", ClassDate: "2026-03-04", Notes: "Sam sat out with a sore knee"},
+		}},
+		ThemeStore: &mockCarouselThemeStore{themes: []theme.Theme{{ID: "lib-guard", RotorThemeID: "th-guard"}}},
+		ClipStore: &mockCHClipStore{clips: []clip.Clip{
+			{ID: "c1", ThemeID: "lib-guard", Title: "Hip bump", YouTubeID: "abc"},
+		}},
+		AttendanceStore: &mockTrainingLogAttendanceStore{records: map[string][]attendance.Attendance{
+			"m1": {{ID: "a1", MemberID: "m1", ScheduleID: "sch-mon", CheckInTime: time.Date(2026, 3, 2, 18, 5, 0, 0, time.UTC)}},
+		}},
+	}
+	query := GetCurriculumHistoryQuery{ClassTypeID: "ct-fund", From: "2026-02-01", Now: day(20), Gate: BeltGate{Program: "adults", Belt: "white"}, MemberID: "m1"}
+
+	result, err := QueryGetCurriculumHistory(context.Background(), query, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Topics) != 2 {
+		t.Fatalf("topics = %+v, want the Lasso and Closed Guard runs only", result.Topics)
+	}
+	lasso, closed := result.Topics[0], result.Topics[1]
+	if lasso.TopicID != "tp-lasso" || !lasso.Locked || lasso.Description != "" || len(lasso.Clips) != 0 {
+		t.Errorf("lasso = %+v, want it locked for a white belt", lasso)
+	}
+	if len(lasso.Sessions) != 1 || lasso.Sessions[0].Notes != "" || lasso.Sessions[0].Attended {
+		t.Errorf("lasso sessions = %+v, want one missed class without notes", lasso.Sessions)
+	}
+	if closed.StartDate != "2026-03-02" || closed.EndDate != "2026-03-08" || closed.ThemeName != "Guard" || len(closed.Clips) != 1 {
+		t.Errorf("closed = %+v", closed)
+	}
+	if len(closed.Sessions) != 2 || closed.Sessions[0].Notes != "Posture drills" || !closed.Sessions[0].Attended || closed.Sessions[0].StartTime != "18:00" {
+		t.Fatalf("closed sessions = %+v", closed.Sessions)
+	}
+	if closed.Sessions[1].Notes != "" {
+		t.Errorf("unshared notes leaked: %+v", closed.Sessions[1])
+	}
+	if !result.Personal || result.Attended != 1 || result.Missed != 2 || closed.Attended != 1 {
+		t.Errorf("overlay = personal %v, attended %d, missed %d", result.Personal, result.Attended, result.Missed)
+	}
+
+	// Without a member there is no overlay; a kids member may not see an adults class
+	query.MemberID = ""
+	if result, err := QueryGetCurriculumHistory(context.Background(), query, deps); err != nil || result.Personal || result.Missed != 0 {
+		t.Errorf("no member: got %+v, %v", result, err)
+	}
+	query.Gate = BeltGate{Program: "kids"}
+	if _, err := QueryGetCurriculumHistory(context.Background(), query, deps); err != ErrCurriculumHistoryClassType {
+		t.Errorf("kids member: err = %v, want ErrCurriculumHistoryClassType", err)
+	}
+}
@@ -2,6 +2,7 @@ package projections
 
 import (
 	"context"
+	"time"
 
 	"workshop/internal/domain/classtype"
 	"workshop/internal/domain/rotor"
@@ -18,12 +19,14 @@ type CurriculumOverviewRotorStore interface {
 	ListThemesByRotor(ctx context.Context, rotorID string) ([]rotor.RotorTheme, error)
 	ListTopicsByTheme(ctx context.Context, rotorThemeID string) ([]rotor.Topic, error)
 	GetActiveScheduleForTheme(ctx context.Context, rotorThemeID string) (rotor.TopicSchedule, error)
-	CountVotesForTopic(ctx context.Context, topicID string) (int, error)
+	CountVotesForTopic(ctx context.Context, topicID string, now time.Time) (int, error)
 }
 
 // GetCurriculumOverviewQuery carries input for the curriculum overview projection.
 type GetCurriculumOverviewQuery struct {
-	Role string // viewer's role: admin, coach, member, trial
+	Role string    // viewer's role: admin, coach, member, trial
+	Gate BeltGate  // viewer's belt; topics above it are locked for members
+	Now  time.Time // votes that have expired by now are not counted
 }
 
 // GetCurriculumOverviewDeps holds dependencies for the curriculum overview projection.
@@ -65,6 +68,8 @@ type CurriculumTopicView struct {
 	DurationWeeks int    `json:"duration_weeks"`
 	Votes         int    `json:"votes"`
 	Position      int    `json:"position"`
+	MinBelt       string `json:"min_belt"`
+	Locked        bool   `json:"locked"`
 }
 
 // QueryGetCurriculumOverview aggregates the active curriculum across all class types.
@@ -106,7 +111,7 @@ func QueryGetCurriculumOverview(ctx context.Context, query GetCurriculumOverview
 			activeSched, schedErr := deps.RotorStore.GetActiveScheduleForTheme(ctx, th.ID)
 
 			for _, tp := range topics {
-				votes, _ := deps.RotorStore.CountVotesForTopic(ctx, tp.ID)
+				votes, _ := deps.RotorStore.CountVotesForTopic(ctx, tp.ID, query.Now)
 				topicView := CurriculumTopicView{
 					TopicID:       tp.ID,
 					TopicName:     tp.Name,
@@ -114,6 +119,13 @@ func QueryGetCurriculumOverview(ctx context.Context, query GetCurriculumOverview
 					DurationWeeks: tp.DurationWeeks,
 					Votes:         votes,
 					Position:      tp.Position,
+					MinBelt:       tp.MinBelt,
+				}
+				if query.Role != "admin" && query.Role != "coach" {
+					if gated := GateTopic(tp, query.Gate); gated.Locked {
+						topicView.Locked = true
+						topicView.Description = ""
+					}
 				}
 
 				if schedErr == nil && activeSched.TopicID == tp.ID {
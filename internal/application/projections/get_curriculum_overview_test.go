@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"workshop/internal/domain/classtype"
 	"workshop/internal/domain/rotor"
@@ -69,7 +70,7 @@ func (m *mockCurriculumRotorStore) GetActiveScheduleForTheme(_ context.Context,
 // CountVotesForTopic implements CurriculumOverviewRotorStore.
 // PRE: topicID is non-empty
 // POST: returns vote count for the topic
-func (m *mockCurriculumRotorStore) CountVotesForTopic(_ context.Context, topicID string) (int, error) {
+func (m *mockCurriculumRotorStore) CountVotesForTopic(_ context.Context, topicID string, _ time.Time) (int, error) {
 	return m.votes[topicID], nil
 }
 
@@ -332,3 +333,41 @@ func TestQueryCurriculumOverview_EmptyClassTypes(t *testing.T) {
 		t.Errorf("expected 0 class curriculums, got %d", len(result.ClassCurriculums))
 	}
 }
+
+// TestQueryCurriculumOverview_BeltGatedTopics verifies members below a topic's minimum belt
+// see it locked without its description, and coaches see it open.
+func TestQueryCurriculumOverview_BeltGatedTopics(t *testing.T) {
+	classTypeStore := &mockCurriculumClassTypeStore{
+		classTypes: []classtype.ClassType{{ID: "ct1", ProgramID: "p1", Name: "Gi Express"}},
+	}
+	rotorStore := &mockCurriculumRotorStore{
+		activeRotors: map[string]rotor.Rotor{"ct1": {ID: "r1", ClassTypeID: "ct1", Name: "v1", Status: "active", PreviewOn: true}},
+		themes:       map[string][]rotor.RotorTheme{"r1": {{ID: "th1", RotorID: "r1", Name: "Leg Locks", Position: 0}}},
+		topics: map[string][]rotor.Topic{
+			"th1": {
+				{ID: "tp1", RotorThemeID: "th1", Name: "Straight Ankle Lock", Description: "Finish details", DurationWeeks: 1, Position: 0},
+				{ID: "tp2", RotorThemeID: "th1", Name: "Heel Hook", Description: "Rotate the hips", DurationWeeks: 1, Position: 1, MinBelt: "brown"},
+			},
+		},
+		schedules: map[string]rotor.TopicSchedule{"th1": {ID: "s1", TopicID: "tp1", RotorThemeID: "th1", Status: "active"}},
+		votes:     map[string]int{},
+	}
+	deps := GetCurriculumOverviewDeps{ClassTypeStore: classTypeStore, RotorStore: rotorStore}
+
+	result, err := QueryGetCurriculumOverview(context.Background(), GetCurriculumOverviewQuery{Role: "member", Gate: BeltGate{Program: "adults", Belt: "blue"}}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	th := result.ClassCurriculums[0].Themes[0]
+	if th.ActiveTopic.Locked {
+		t.Error("ungated active topic should be open")
+	}
+	if len(th.Upcoming) != 1 || !th.Upcoming[0].Locked || th.Upcoming[0].Description != "" || th.Upcoming[0].TopicName != "Heel Hook" {
+		t.Errorf("upcoming = %+v, want Heel Hook locked without its description", th.Upcoming)
+	}
+
+	result, _ = QueryGetCurriculumOverview(context.Background(), GetCurriculumOverviewQuery{Role: "coach"}, deps)
+	if up := result.ClassCurriculums[0].Themes[0].Upcoming; len(up) != 1 || up[0].Locked {
+		t.Errorf("coach upcoming = %+v, want Heel Hook open", up)
+	}
+}
@@ -2,11 +2,14 @@ package projections
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"workshop/internal/domain/classtype"
 	"workshop/internal/domain/grading"
 	"workshop/internal/domain/member"
 	"workshop/internal/domain/notice"
+	"workshop/internal/domain/personalgoal"
 	"workshop/internal/domain/traininggoal"
 	"workshop/internal/domain/waiver"
 )
@@ -18,7 +21,13 @@ type DashboardNoticeStore interface {
 
 // DashboardProposalStore defines the grading proposal store interface needed by the dashboard projection.
 type DashboardProposalStore interface {
-	ListPending(ctx context.Context) ([]grading.Proposal, error)
+	ListOpen(ctx context.Context) ([]grading.Proposal, error)
+}
+
+// DashboardMemberProposalStore defines the grading proposal store interface needed to find a
+// member's promotion ceremonies.
+type DashboardMemberProposalStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]grading.Proposal, error)
 }
 
 // DashboardMessageStore defines the message store interface needed by the dashboard projection.
@@ -36,6 +45,12 @@ type DashboardMemberStore interface {
 	GetByEmail(ctx context.Context, email string) (member.Member, error)
 }
 
+// DashboardApprovalStore defines the class type approval store interface needed to show a
+// member which of today's classes they may attend.
+type DashboardApprovalStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]classtype.Approval, error)
+}
+
 // DashboardWaiverStore defines the waiver store interface needed by the dashboard projection.
 type DashboardWaiverStore interface {
 	GetByMemberID(ctx context.Context, memberID string) (waiver.Waiver, error)
@@ -49,17 +64,20 @@ type GetDashboardQuery struct {
 
 // GetDashboardDeps holds dependencies for the dashboard projection.
 type GetDashboardDeps struct {
-	TodaysClassesDeps  GetTodaysClassesDeps
-	AttendanceDeps     GetAttendanceTodayDeps
-	InactiveDeps       GetInactiveMembersDeps
-	TrainingLogDeps    GetTrainingLogDeps
-	NoticeStore        DashboardNoticeStore
-	ProposalStore      DashboardProposalStore
-	MessageStore       DashboardMessageStore
-	TrainingGoalStore  DashboardTrainingGoalStore
-	MemberStore        DashboardMemberStore
-	GradingRecordStore GradingRecordStore   // optional: nil skips belt lookup
-	WaiverStore        DashboardWaiverStore // optional: nil skips waiver check
+	TodaysClassesDeps   GetTodaysClassesDeps
+	AttendanceDeps      GetAttendanceTodayDeps
+	InactiveDeps        GetInactiveMembersDeps
+	TrainingLogDeps     GetTrainingLogDeps
+	NoticeStore         DashboardNoticeStore
+	ProposalStore       DashboardProposalStore
+	MessageStore        DashboardMessageStore
+	TrainingGoalStore   DashboardTrainingGoalStore
+	MemberStore         DashboardMemberStore
+	GradingRecordStore  GradingRecordStore           // optional: nil skips belt lookup
+	WaiverStore         DashboardWaiverStore         // optional: nil skips waiver check
+	PersonalGoalStore   PersonalGoalListStore        // optional: nil skips goal check-in prompts
+	MemberProposalStore DashboardMemberProposalStore // optional: nil hides promotion ceremony notices
+	ApprovalStore       DashboardApprovalStore       // optional: nil leaves class prerequisites unmarked
 }
 
 // DashboardResult carries the output of the dashboard projection.
@@ -79,12 +97,14 @@ type DashboardResult struct {
 	Attendees []AttendanceWithMember
 
 	// Member
+	MemberID     string
 	TrainingLog  *TrainingLogResult
 	UnreadCount  int
 	TrainingGoal *traininggoal.TrainingGoal
 	Belt         string
 	Stripe       int
 	IsTrial      bool
+	GoalCheckIns []personalgoal.PersonalGoal // personal goals awaiting a check-in
 }
 
 // QueryGetDashboard aggregates dashboard data based on the user's role.
@@ -105,8 +125,8 @@ func QueryGetDashboard(ctx context.Context, query GetDashboardQuery, deps GetDas
 
 	switch query.Role {
 	case "admin":
-		// Pending grading proposals
-		proposals, err := deps.ProposalStore.ListPending(ctx)
+		// Grading proposals awaiting a decision, scheduled or not
+		proposals, err := deps.ProposalStore.ListOpen(ctx)
 		if err == nil {
 			result.PendingProposals = len(proposals)
 		}
@@ -131,12 +151,17 @@ func QueryGetDashboard(ctx context.Context, query GetDashboardQuery, deps GetDas
 			memberRecord, err := deps.MemberStore.GetByEmail(ctx, query.AccountEmail)
 			if err == nil && memberRecord.ID != "" {
 				memberID := memberRecord.ID
+				result.MemberID = memberID
 				// Training log summary
 				logQuery := GetTrainingLogQuery{MemberID: memberID}
 				logResult, err := QueryGetTrainingLog(ctx, logQuery, deps.TrainingLogDeps)
 				if err == nil {
 					result.TrainingLog = &logResult
 				}
+				// Notices for the promotion ceremonies the member is invited to
+				if deps.MemberProposalStore != nil {
+					result.Notices = append(result.Notices, ceremonyNotices(ctx, memberID, deps.NoticeStore, deps.MemberProposalStore, now)...)
+				}
 				// Unread messages
 				count, err := deps.MessageStore.CountUnread(ctx, memberID)
 				if err == nil {
@@ -147,6 +172,12 @@ func QueryGetDashboard(ctx context.Context, query GetDashboardQuery, deps GetDas
 				if err == nil && goal.ID != "" {
 					result.TrainingGoal = &goal
 				}
+				// Personal goals awaiting a check-in
+				if deps.PersonalGoalStore != nil {
+					if due, err := QueryGetGoalCheckInsDue(ctx, memberID, now, deps.PersonalGoalStore); err == nil {
+						result.GoalCheckIns = due
+					}
+				}
 				// Latest belt
 				if deps.GradingRecordStore != nil {
 					if records, err := deps.GradingRecordStore.ListByMemberID(ctx, memberID); err == nil && len(records) > 0 {
@@ -160,6 +191,12 @@ func QueryGetDashboard(ctx context.Context, query GetDashboardQuery, deps GetDas
 						result.Stripe = latest.Stripe
 					}
 				}
+				// What the member still needs for today's classes with prerequisites
+				if deps.ApprovalStore != nil {
+					if approvals, err := deps.ApprovalStore.ListByMemberID(ctx, memberID); err == nil {
+						markClassNeeds(result.TodaysClasses, memberRecord.Program, result.Belt, approvals)
+					}
+				}
 				// Waiver status for trial users
 				if query.Role == "trial" {
 					result.IsTrial = true
@@ -176,3 +213,51 @@ func QueryGetDashboard(ctx context.Context, query GetDashboardQuery, deps GetDas
 
 	return result, nil
 }
+
+// markClassNeeds records on each class what the member lacks to attend it.
+// PRE: belt is the member's current belt (empty = white); approvals are the member's own
+// POST: NeedsApproval / NeedsBelt are set on ineligible classes and cleared on the rest
+func markClassNeeds(classes []TodaysClassResult, program, belt string, approvals []classtype.Approval) {
+	approved := map[string]bool{}
+	for _, a := range approvals {
+		approved[a.ClassTypeID] = true
+	}
+	for i, c := range classes {
+		ct := classtype.ClassType{MinBelt: c.MinBelt, RequiresApproval: c.RequiresApproval}
+		err := ct.CheckEligibility(grading.BeltAtLeast(program, belt, c.MinBelt), approved[c.ClassTypeID])
+		classes[i].NeedsApproval = errors.Is(err, classtype.ErrApprovalRequired)
+		classes[i].NeedsBelt = ""
+		if errors.Is(err, classtype.ErrBeltRequired) {
+			classes[i].NeedsBelt = c.MinBelt
+		}
+	}
+}
+
+// ceremonyNotices returns the published grading notices for the ceremonies a member's
+// proposals are booked onto. A grading notice's TargetID is its ceremony's calendar event.
+func ceremonyNotices(ctx context.Context, memberID string, notices DashboardNoticeStore, proposals DashboardMemberProposalStore, now time.Time) []notice.Notice {
+	mine, err := proposals.ListByMemberID(ctx, memberID)
+	if err != nil {
+		return nil
+	}
+	events := map[string]bool{}
+	for _, p := range mine {
+		if p.EventID != "" && p.Status != grading.ProposalRejected {
+			events[p.EventID] = true
+		}
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	published, err := notices.ListPublished(ctx, notice.TypeGrading, now)
+	if err != nil {
+		return nil
+	}
+	var out []notice.Notice
+	for _, n := range published {
+		if events[n.TargetID] {
+			out = append(out, n)
+		}
+	}
+	return out
+}
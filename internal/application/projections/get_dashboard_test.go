@@ -0,0 +1,94 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/notice"
+)
+
+type mockDashboardNoticeStore struct {
+	notices []notice.Notice
+}
+
+// ListPublished returns the stored notices of the given type.
+// PRE: noticeType is a valid type
+// POST: Returns the notices of that type
+func (m *mockDashboardNoticeStore) ListPublished(_ context.Context, noticeType string, _ time.Time) ([]notice.Notice, error) {
+	var out []notice.Notice
+	for _, n := range m.notices {
+		if n.Type == noticeType {
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
+
+type mockDashboardProposalStore struct {
+	proposals []grading.Proposal
+}
+
+// ListByMemberID returns the member's proposals.
+// PRE: memberID is non-empty
+// POST: Returns the proposals for the member
+func (m *mockDashboardProposalStore) ListByMemberID(_ context.Context, memberID string) ([]grading.Proposal, error) {
+	var out []grading.Proposal
+	for _, p := range m.proposals {
+		if p.MemberID == memberID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// TestCeremonyNotices verifies a member sees only the grading notices for ceremonies their
+// proposals are booked onto, and not once the proposal is rejected.
+func TestCeremonyNotices(t *testing.T) {
+	notices := &mockDashboardNoticeStore{notices: []notice.Notice{
+		{ID: "n-mine", Type: notice.TypeGrading, TargetID: "ev-1"},
+		{ID: "n-rejected", Type: notice.TypeGrading, TargetID: "ev-2"},
+		{ID: "n-other", Type: notice.TypeGrading, TargetID: "ev-3"},
+		{ID: "n-school", Type: notice.TypeSchoolWide},
+	}}
+	proposals := &mockDashboardProposalStore{proposals: []grading.Proposal{
+		{ID: "p1", MemberID: "m1", Status: grading.ProposalScheduled, EventID: "ev-1"},
+		{ID: "p2", MemberID: "m1", Status: grading.ProposalRejected, EventID: "ev-2"},
+		{ID: "p3", MemberID: "m2", Status: grading.ProposalScheduled, EventID: "ev-3"},
+	}}
+
+	got := ceremonyNotices(context.Background(), "m1", notices, proposals, time.Now())
+	if len(got) != 1 || got[0].ID != "n-mine" {
+		t.Errorf("notices = %+v, want only n-mine", got)
+	}
+	if got := ceremonyNotices(context.Background(), "m9", notices, proposals, time.Now()); len(got) != 0 {
+		t.Errorf("member without proposals: notices = %+v, want none", got)
+	}
+}
+
+// TestMarkClassNeeds verifies a member sees what they lack for classes with prerequisites,
+// and nothing once they hold the belt or a coach's approval.
+func TestMarkClassNeeds(t *testing.T) {
+	classes := []TodaysClassResult{
+		{ClassTypeID: "ct-fund"},
+		{ClassTypeID: "ct-comp", MinBelt: grading.BeltBlue},
+		{ClassTypeID: "ct-team", RequiresApproval: true},
+		{ClassTypeID: "ct-adv", MinBelt: grading.BeltPurple},
+	}
+	markClassNeeds(classes, "adults", grading.BeltBlue, []classtype.Approval{{ClassTypeID: "ct-adv", MemberID: "m1"}})
+
+	for i, want := range []struct {
+		approval bool
+		belt     string
+	}{{false, ""}, {false, ""}, {true, ""}, {false, ""}} {
+		if c := classes[i]; c.NeedsApproval != want.approval || c.NeedsBelt != want.belt {
+			t.Errorf("%s: needs approval %v belt %q, want %v %q", c.ClassTypeID, c.NeedsApproval, c.NeedsBelt, want.approval, want.belt)
+		}
+	}
+	markClassNeeds(classes, "adults", "", nil)
+	if classes[1].NeedsBelt != grading.BeltBlue || classes[3].NeedsBelt != grading.BeltPurple {
+		t.Errorf("white belt: NeedsBelt = %q / %q, want blue / purple", classes[1].NeedsBelt, classes[3].NeedsBelt)
+	}
+}
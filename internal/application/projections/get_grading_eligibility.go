@@ -0,0 +1,274 @@
+package projections
+
+import (
+	"context"
+	"time"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/sessionlog"
+)
+
+// GradingEligibilityMemberStore defines the member store interface needed by the eligibility projection.
+type GradingEligibilityMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+	List(ctx context.Context, filter memberStore.ListFilter) ([]member.Member, error)
+}
+
+// GradingEligibilityRuleStore defines the rule store interface needed by the eligibility projection.
+type GradingEligibilityRuleStore interface {
+	List(ctx context.Context) ([]grading.Rule, error)
+}
+
+// GradingEligibilityConfigStore defines the grading config store interface needed by the eligibility projection.
+type GradingEligibilityConfigStore interface {
+	List(ctx context.Context) ([]grading.Config, error)
+}
+
+// GradingEligibilityMemberConfigStore defines the per-member override store interface needed by the eligibility projection.
+type GradingEligibilityMemberConfigStore interface {
+	GetByMemberAndBelt(ctx context.Context, memberID, belt string) (grading.MemberConfig, error)
+}
+
+// GradingEligibilityRecordStore defines the grading record store interface needed by the eligibility projection.
+type GradingEligibilityRecordStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]grading.Record, error)
+}
+
+// GradingEligibilityAttendanceStore defines the attendance store interface needed by the eligibility projection.
+type GradingEligibilityAttendanceStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]attendance.Attendance, error)
+}
+
+// GradingEligibilitySessionLogStore defines the session log store interface needed to find who taught a class.
+type GradingEligibilitySessionLogStore interface {
+	GetByScheduleAndDate(ctx context.Context, scheduleID, classDate string) (sessionlog.SessionLog, error)
+}
+
+// GetGradingEligibilityDeps holds dependencies for the grading eligibility projection.
+type GetGradingEligibilityDeps struct {
+	MemberStore       GradingEligibilityMemberStore
+	RuleStore         GradingEligibilityRuleStore // optional: nil uses the grading config only
+	ConfigStore       GradingEligibilityConfigStore
+	MemberConfigStore GradingEligibilityMemberConfigStore // optional: nil ignores per-member overrides
+	RecordStore       GradingEligibilityRecordStore
+	AttendanceStore   GradingEligibilityAttendanceStore
+	SessionLogStore   GradingEligibilitySessionLogStore // optional: nil counts no classes with any coach
+	TrainingLogDeps   GetTrainingLogDeps
+	KidsTermDeps      GetKidsTermReadinessDeps // optional: a nil TermStore skips term attendance
+}
+
+// GetGradingEligibilityQuery selects the members and belt to evaluate.
+type GetGradingEligibilityQuery struct {
+	MemberID   string // optional: empty evaluates every active member for their next belt
+	TargetBelt string // optional: empty uses the member's next belt; needs MemberID
+	Now        time.Time
+}
+
+// GradingEligibilityEntry is one member's standing against the rule for a belt.
+type GradingEligibilityEntry struct {
+	MemberID     string
+	MemberName   string
+	Program      string
+	ByAttendance bool // a kid graded by term attendance rather than mat hours
+	CurrentBelt  string
+	TargetBelt   string
+	RuleID       string // empty when the belt's thresholds come from its grading config
+	Checks       []grading.Check
+	Eligible     bool
+	Progress     float64                 // 0–100, the least-met criterion
+	Term         *KidsTermReadinessEntry // term attendance, for kids graded by sessions
+}
+
+// GradingEligibilityResult carries the output of the grading eligibility projection.
+type GradingEligibilityResult struct {
+	TermName string
+	Entries  []GradingEligibilityEntry
+}
+
+// QueryGetGradingEligibility evaluates members against the eligibility rule for a belt:
+// the admin-composed Rule for the program and belt when there is one, otherwise the
+// thresholds in its grading config, adjusted by the member's overrides and grading metric.
+// Only the facts the rule's criteria need are gathered.
+// PRE: TargetBelt is only set together with MemberID
+// POST: For one member, returns their entry even when no criteria apply (eligible); for
+// every member, returns only those with a next belt and at least one criterion
+func QueryGetGradingEligibility(ctx context.Context, query GetGradingEligibilityQuery, deps GetGradingEligibilityDeps) (GradingEligibilityResult, error) {
+	var members []member.Member
+	if query.MemberID != "" {
+		m, err := deps.MemberStore.GetByID(ctx, query.MemberID)
+		if err != nil {
+			return GradingEligibilityResult{}, err
+		}
+		members = []member.Member{m}
+	} else {
+		list, err := deps.MemberStore.List(ctx, memberStore.ListFilter{Limit: 10000, Status: "active"})
+		if err != nil {
+			return GradingEligibilityResult{}, err
+		}
+		members = list
+	}
+
+	rules := map[[2]string]grading.Rule{}
+	if deps.RuleStore != nil {
+		list, err := deps.RuleStore.List(ctx)
+		if err != nil {
+			return GradingEligibilityResult{}, err
+		}
+		for _, r := range list {
+			rules[[2]string{r.Program, r.Belt}] = r
+		}
+	}
+	configList, err := deps.ConfigStore.List(ctx)
+	if err != nil {
+		return GradingEligibilityResult{}, err
+	}
+	configs := map[[2]string]grading.Config{}
+	for _, c := range configList {
+		configs[[2]string{c.Program, c.Belt}] = c
+	}
+
+	var result GradingEligibilityResult
+	terms := map[string]KidsTermReadinessEntry{}
+	if deps.KidsTermDeps.TermStore != nil && anyByAttendance(members) {
+		kids, err := QueryGetKidsTermReadiness(ctx, GetKidsTermReadinessQuery{Now: query.Now}, deps.KidsTermDeps)
+		if err != nil {
+			return GradingEligibilityResult{}, err
+		}
+		result.TermName = kids.TermName
+		for _, e := range kids.Entries {
+			terms[e.MemberID] = e
+		}
+	}
+
+	for _, m := range members {
+		records, err := deps.RecordStore.ListByMemberID(ctx, m.ID)
+		if err != nil {
+			return result, err
+		}
+		currentBelt, promotedAt := grading.BeltWhite, time.Time{}
+		for _, r := range records {
+			if promotedAt.IsZero() || r.PromotedAt.After(promotedAt) {
+				currentBelt, promotedAt = r.Belt, r.PromotedAt
+			}
+		}
+		target := query.TargetBelt
+		if target == "" {
+			target = nextBeltInProgression(currentBelt, m.Program)
+		}
+		if target == "" {
+			continue // already at the highest belt
+		}
+
+		entry := GradingEligibilityEntry{
+			MemberID:     m.ID,
+			MemberName:   m.Name,
+			Program:      m.Program,
+			ByAttendance: isGradedByAttendance(m),
+			CurrentBelt:  currentBelt,
+			TargetBelt:   target,
+		}
+		rule, ok := rules[[2]string{m.Program, target}]
+		if ok {
+			entry.RuleID = rule.ID
+		} else {
+			config := configs[[2]string{m.Program, target}]
+			config.Program, config.Belt = m.Program, target
+			rule = grading.ConfigRule(config, entry.ByAttendance)
+		}
+		var override grading.MemberConfig
+		if deps.MemberConfigStore != nil {
+			override, _ = deps.MemberConfigStore.GetByMemberAndBelt(ctx, m.ID, target)
+		}
+		rule = rule.ForMember(entry.ByAttendance, override)
+		if len(rule.Criteria) == 0 && query.MemberID == "" {
+			continue // nothing to measure the member against
+		}
+		if t, ok := terms[m.ID]; ok {
+			entry.Term = &t
+		}
+		if entry.ByAttendance && entry.Term == nil && query.MemberID == "" && rule.Needs(grading.CriterionAttendancePct) {
+			continue // no term attendance to show
+		}
+
+		facts, err := gatherEligibilityFacts(ctx, m.ID, rule, promotedAt, query.Now, deps)
+		if err != nil {
+			return result, err
+		}
+		if entry.Term != nil {
+			facts.AttendancePct = entry.Term.AttendancePct
+		}
+		eval := rule.Evaluate(facts)
+		entry.Checks = eval.Checks
+		entry.Eligible = eval.Eligible
+		entry.Progress = eval.Progress
+		result.Entries = append(result.Entries, entry)
+	}
+	return result, nil
+}
+
+// gatherEligibilityFacts collects the measures the rule's criteria need. Time at belt and
+// classes with a coach count from the last promotion, or from the first class attended
+// for a member never promoted.
+func gatherEligibilityFacts(ctx context.Context, memberID string, rule grading.Rule, promotedAt, now time.Time, deps GetGradingEligibilityDeps) (grading.Facts, error) {
+	var facts grading.Facts
+	if rule.Needs(grading.CriterionMatHours) {
+		log, err := QueryGetTrainingLog(ctx, GetTrainingLogQuery{MemberID: memberID}, deps.TrainingLogDeps)
+		if err != nil {
+			return facts, err
+		}
+		facts.MatHours = log.TotalMatHours
+	}
+	if !rule.Needs(grading.CriterionMonthsAtBelt) && !rule.Needs(grading.CriterionSessionsWithCoach) {
+		return facts, nil
+	}
+
+	records, err := deps.AttendanceStore.ListByMemberID(ctx, memberID)
+	if err != nil {
+		return facts, err
+	}
+	since := promotedAt
+	if since.IsZero() {
+		for _, a := range records {
+			if since.IsZero() || a.CheckInTime.Before(since) {
+				since = a.CheckInTime
+			}
+		}
+	}
+	if !since.IsZero() {
+		facts.MonthsAtBelt = grading.MonthsBetween(since, now)
+	}
+
+	if rule.Needs(grading.CriterionSessionsWithCoach) && deps.SessionLogStore != nil {
+		facts.SessionsWithCoach = map[string]int{}
+		sinceDate := since.Format("2006-01-02")
+		for _, a := range records {
+			if a.ScheduleID == "" || a.ClassDate < sinceDate {
+				continue
+			}
+			log, err := deps.SessionLogStore.GetByScheduleAndDate(ctx, a.ScheduleID, a.ClassDate)
+			if err != nil {
+				continue // nobody logged the class
+			}
+			facts.SessionsWithCoach[log.CoachID]++
+		}
+	}
+	return facts, nil
+}
+
+// isGradedByAttendance reports whether a member is a kid graded by term attendance.
+func isGradedByAttendance(m member.Member) bool {
+	return m.Program == "kids" && m.GradingMetric != member.MetricHours
+}
+
+// anyByAttendance reports whether any of the members is graded by term attendance.
+func anyByAttendance(members []member.Member) bool {
+	for _, m := range members {
+		if isGradedByAttendance(m) {
+			return true
+		}
+	}
+	return false
+}
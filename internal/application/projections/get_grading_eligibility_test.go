@@ -0,0 +1,200 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/sessionlog"
+)
+
+// --- Mock stores for grading eligibility tests ---
+
+type mockGEMemberStore struct {
+	members []member.Member
+}
+
+// GetByID returns the member with the ID.
+// PRE: id is non-empty
+// POST: Returns the member or an error
+func (m *mockGEMemberStore) GetByID(_ context.Context, id string) (member.Member, error) {
+	for _, mem := range m.members {
+		if mem.ID == id {
+			return mem, nil
+		}
+	}
+	return member.Member{}, errors.New("not found")
+}
+
+// List returns every member; tests only hold active ones.
+// PRE: none
+// POST: Returns the members
+func (m *mockGEMemberStore) List(_ context.Context, _ memberStore.ListFilter) ([]member.Member, error) {
+	return m.members, nil
+}
+
+type mockGERuleStore struct {
+	rules []grading.Rule
+}
+
+// List returns the rules.
+// PRE: none
+// POST: Returns the rules
+func (m *mockGERuleStore) List(_ context.Context) ([]grading.Rule, error) {
+	return m.rules, nil
+}
+
+type mockGEConfigStore struct {
+	configs []grading.Config
+}
+
+// List returns the configs.
+// PRE: none
+// POST: Returns the configs
+func (m *mockGEConfigStore) List(_ context.Context) ([]grading.Config, error) {
+	return m.configs, nil
+}
+
+type mockGEMemberConfigStore struct {
+	overrides map[string]grading.MemberConfig // key = memberID+belt
+}
+
+// GetByMemberAndBelt returns a member's override for a belt.
+// PRE: memberID and belt are non-empty
+// POST: Returns the override or an error
+func (m *mockGEMemberConfigStore) GetByMemberAndBelt(_ context.Context, memberID, belt string) (grading.MemberConfig, error) {
+	mc, ok := m.overrides[memberID+belt]
+	if !ok {
+		return grading.MemberConfig{}, errors.New("not found")
+	}
+	return mc, nil
+}
+
+type mockGESessionLogStore struct {
+	coaches map[string]string // key = scheduleID+classDate
+}
+
+// GetByScheduleAndDate returns a log naming the coach who taught the class.
+// PRE: scheduleID and classDate are non-empty
+// POST: Returns the log or an error if the class was not logged
+func (m *mockGESessionLogStore) GetByScheduleAndDate(_ context.Context, scheduleID, classDate string) (sessionlog.SessionLog, error) {
+	coach, ok := m.coaches[scheduleID+classDate]
+	if !ok {
+		return sessionlog.SessionLog{}, errors.New("not found")
+	}
+	return sessionlog.SessionLog{ScheduleID: scheduleID, ClassDate: classDate, CoachID: coach}, nil
+}
+
+// newGEDeps returns eligibility deps for three members: Ana, an adult white belt with 12
+// classes since promotion, 8 of them taught by coach-1; Ben, an adult white belt with an
+// hours override; and Cy, a kid graded by attendance.
+func newGEDeps() GetGradingEligibilityDeps {
+	members := &mockGEMemberStore{members: []member.Member{
+		{ID: "ana", Name: "Ana", Program: member.ProgramAdults, Status: "active"},
+		{ID: "ben", Name: "Ben", Program: member.ProgramAdults, Status: "active"},
+		{ID: "cy", Name: "Cy", Program: "kids", Status: "active"},
+	}}
+	promoted := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	var anaClasses []attendance.Attendance
+	logs := map[string]string{}
+	for i := 0; i < 12; i++ {
+		day := promoted.AddDate(0, 0, 7*(i+1))
+		date := day.Format("2006-01-02")
+		anaClasses = append(anaClasses, attendance.Attendance{MemberID: "ana", ScheduleID: "s1", ClassDate: date, CheckInTime: day, CheckOutTime: day.Add(2 * time.Hour)})
+		if i < 8 {
+			logs["s1"+date] = "coach-1"
+		}
+	}
+	// A class before the promotion does not count towards the coach.
+	logs["s1"+"2024-12-01"] = "coach-1"
+	anaClasses = append(anaClasses, attendance.Attendance{MemberID: "ana", ScheduleID: "s1", ClassDate: "2024-12-01", CheckInTime: time.Date(2024, 12, 1, 18, 0, 0, 0, time.UTC)})
+
+	att := &mockTrainingLogAttendanceStore{records: map[string][]attendance.Attendance{"ana": anaClasses}}
+	return GetGradingEligibilityDeps{
+		MemberStore: members,
+		RuleStore: &mockGERuleStore{rules: []grading.Rule{{ID: "rule-blue", Program: member.ProgramAdults, Belt: grading.BeltBlue, Criteria: []grading.Criterion{
+			{Kind: grading.CriterionMatHours, Min: 20},
+			{Kind: grading.CriterionMonthsAtBelt, Min: 3},
+			{Kind: grading.CriterionSessionsWithCoach, Min: 10, CoachID: "coach-1"},
+		}}}},
+		ConfigStore:       &mockGEConfigStore{configs: []grading.Config{{Program: "kids", Belt: grading.BeltGrey, AttendancePct: 75}}},
+		MemberConfigStore: &mockGEMemberConfigStore{overrides: map[string]grading.MemberConfig{"ben" + grading.BeltBlue: {MemberID: "ben", Belt: grading.BeltBlue, FlightTimeHours: 5}}},
+		RecordStore: &mockTrainingLogGradingRecordStore{records: map[string][]grading.Record{
+			"ana": {{MemberID: "ana", Belt: grading.BeltWhite, PromotedAt: promoted}},
+		}},
+		AttendanceStore: att,
+		SessionLogStore: &mockGESessionLogStore{coaches: logs},
+		TrainingLogDeps: GetTrainingLogDeps{AttendanceStore: att, MemberStore: members},
+	}
+}
+
+// TestQueryGetGradingEligibility verifies every criterion of the rule is checked, the
+// member's override replaces the rule's hours, and kids without term data are left out.
+func TestQueryGetGradingEligibility(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	got, err := QueryGetGradingEligibility(context.Background(), GetGradingEligibilityQuery{Now: now}, newGEDeps())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Entries) != 2 {
+		t.Fatalf("Entries = %+v, want Ana and Ben", got.Entries)
+	}
+
+	ana := got.Entries[0]
+	if ana.RuleID != "rule-blue" || ana.TargetBelt != grading.BeltBlue || ana.Eligible {
+		t.Errorf("Ana = %+v, want the blue rule, not yet eligible", ana)
+	}
+	want := []grading.Check{
+		{Kind: grading.CriterionMatHours, Min: 20, Actual: 25.5, Met: true}, // 12 two-hour classes and one without checkout
+		{Kind: grading.CriterionMonthsAtBelt, Min: 3, Actual: 4, Met: true},
+		{Kind: grading.CriterionSessionsWithCoach, CoachID: "coach-1", Min: 10, Actual: 8},
+	}
+	if len(ana.Checks) != len(want) {
+		t.Fatalf("Ana checks = %+v, want %+v", ana.Checks, want)
+	}
+	for i := range want {
+		if ana.Checks[i] != want[i] {
+			t.Errorf("Ana check %d = %+v, want %+v", i, ana.Checks[i], want[i])
+		}
+	}
+	if ana.Progress != 80 {
+		t.Errorf("Ana progress = %v, want 80 (8 of 10 classes with the coach)", ana.Progress)
+	}
+
+	ben := got.Entries[1]
+	if len(ben.Checks) != 3 || ben.Checks[0].Min != 5 || ben.Checks[1].Actual != 0 {
+		t.Errorf("Ben checks = %+v, want the 5h override and no time at belt", ben.Checks)
+	}
+}
+
+// TestQueryGetGradingEligibility_OneMember verifies a single member is always returned,
+// for a chosen belt, and a belt with nothing to measure is eligible.
+func TestQueryGetGradingEligibility_OneMember(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	deps := newGEDeps()
+
+	got, err := QueryGetGradingEligibility(context.Background(), GetGradingEligibilityQuery{MemberID: "cy", Now: now}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Entries) != 1 || !got.Entries[0].ByAttendance || got.Entries[0].Eligible || got.Entries[0].Checks[0].Min != 75 {
+		t.Errorf("Cy = %+v, want 75%% attendance unmet", got.Entries)
+	}
+
+	got, err = QueryGetGradingEligibility(context.Background(), GetGradingEligibilityQuery{MemberID: "ana", TargetBelt: grading.BeltPurple, Now: now}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Entries) != 1 || !got.Entries[0].Eligible || len(got.Entries[0].Checks) != 0 {
+		t.Errorf("Ana for purple = %+v, want eligible with nothing to check", got.Entries)
+	}
+
+	if _, err := QueryGetGradingEligibility(context.Background(), GetGradingEligibilityQuery{MemberID: "nobody", Now: now}, deps); err == nil {
+		t.Error("expected an error for an unknown member")
+	}
+}
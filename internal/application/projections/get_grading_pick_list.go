@@ -0,0 +1,156 @@
+package projections
+
+import (
+	"context"
+	"sort"
+
+	"workshop/internal/domain/calendar"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/inventory"
+	"workshop/internal/domain/member"
+)
+
+// GradingPickListEventStore defines the calendar event store interface needed by the pick list projection.
+type GradingPickListEventStore interface {
+	GetByID(ctx context.Context, id string) (calendar.Event, error)
+}
+
+// GradingPickListProposalStore defines the grading proposal store interface needed by the pick list projection.
+type GradingPickListProposalStore interface {
+	ListByEventID(ctx context.Context, eventID string) ([]grading.Proposal, error)
+}
+
+// GradingPickListMemberStore defines the member store interface needed by the pick list projection.
+type GradingPickListMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+}
+
+// GradingPickListStockStore defines the inventory store interface needed by the pick list projection.
+type GradingPickListStockStore interface {
+	ListItems(ctx context.Context) ([]inventory.Item, error)
+	GetMemberSize(ctx context.Context, memberID string) (inventory.MemberSize, error)
+}
+
+// GetGradingPickListDeps holds dependencies for the grading pick list projection.
+type GetGradingPickListDeps struct {
+	EventStore    GradingPickListEventStore
+	ProposalStore GradingPickListProposalStore
+	MemberStore   GradingPickListMemberStore
+	StockStore    GradingPickListStockStore
+}
+
+// GradingPickListMember is one member receiving a belt on the day.
+type GradingPickListMember struct {
+	MemberID string
+	Name     string
+	Program  string
+	Belt     string
+	Size     string // empty when the member's size is not recorded
+}
+
+// GradingPickListLine is one belt colour and size to take to the grading.
+type GradingPickListLine struct {
+	Color     string
+	Size      string // empty collects members with no recorded size
+	Count     int
+	Remaining int  // stock left on the record once these are handed out
+	Stocked   bool // the colour and size is in the inventory at all
+	Low       bool // Remaining is at or below the item's low-stock level
+}
+
+// GradingPickListResult carries the output of the grading pick list projection.
+type GradingPickListResult struct {
+	EventID    string
+	EventTitle string
+	EventDate  string // YYYY-MM-DD
+	Members    []GradingPickListMember
+	Lines      []GradingPickListLine
+	Unsized    int // members whose belt size must be found before the day
+}
+
+// QueryGetGradingPickList lists the belts to bring to a grading day: one per approved
+// proposal on the day, in the member's size, grouped by colour and size. Approving a
+// proposal has already taken its belt off the stock count, so Remaining is what the
+// shelf holds after the day; on a promotion ceremony the belts come off the count when
+// the ceremony is completed instead.
+// PRE: eventID is non-empty
+// POST: Returns the pick list; members that no longer exist are skipped
+func QueryGetGradingPickList(ctx context.Context, eventID string, deps GetGradingPickListDeps) (GradingPickListResult, error) {
+	ev, err := deps.EventStore.GetByID(ctx, eventID)
+	if err != nil {
+		return GradingPickListResult{}, err
+	}
+	result := GradingPickListResult{
+		EventID:    ev.ID,
+		EventTitle: ev.Title,
+		EventDate:  ev.StartDate.Format("2006-01-02"),
+		Members:    []GradingPickListMember{},
+		Lines:      []GradingPickListLine{},
+	}
+
+	proposals, err := deps.ProposalStore.ListByEventID(ctx, eventID)
+	if err != nil {
+		return result, err
+	}
+	items, err := deps.StockStore.ListItems(ctx)
+	if err != nil {
+		return result, err
+	}
+	stock := map[[2]string]inventory.Item{}
+	for _, item := range items {
+		if item.Kind == inventory.KindBelt {
+			stock[[2]string{item.Color, item.Size}] = item
+		}
+	}
+
+	lines := map[[2]string]*GradingPickListLine{}
+	for _, p := range proposals {
+		if p.Status != grading.ProposalApproved {
+			continue
+		}
+		m, err := deps.MemberStore.GetByID(ctx, p.MemberID)
+		if err != nil {
+			continue
+		}
+		size := ""
+		if ms, err := deps.StockStore.GetMemberSize(ctx, p.MemberID); err == nil {
+			size = ms.Size
+		}
+		if size == "" {
+			result.Unsized++
+		}
+		result.Members = append(result.Members, GradingPickListMember{MemberID: m.ID, Name: m.Name, Program: m.Program, Belt: p.TargetBelt, Size: size})
+
+		key := [2]string{p.TargetBelt, size}
+		line, ok := lines[key]
+		if !ok {
+			line = &GradingPickListLine{Color: p.TargetBelt, Size: size}
+			if item, stocked := stock[key]; stocked {
+				line.Stocked = true
+				line.Remaining = item.Quantity
+				line.Low = item.IsLow()
+			}
+			lines[key] = line
+		}
+		line.Count++
+	}
+
+	for _, line := range lines {
+		result.Lines = append(result.Lines, *line)
+	}
+	sort.Slice(result.Lines, func(i, j int) bool {
+		a, b := result.Lines[i], result.Lines[j]
+		if a.Color != b.Color {
+			return inventory.ColorRank(a.Color) < inventory.ColorRank(b.Color)
+		}
+		return inventory.SizeRank(a.Size) < inventory.SizeRank(b.Size)
+	})
+	sort.Slice(result.Members, func(i, j int) bool {
+		a, b := result.Members[i], result.Members[j]
+		if a.Belt != b.Belt {
+			return inventory.ColorRank(a.Belt) < inventory.ColorRank(b.Belt)
+		}
+		return a.Name < b.Name
+	})
+	return result, nil
+}
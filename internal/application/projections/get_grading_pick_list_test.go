@@ -0,0 +1,135 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/calendar"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/inventory"
+	"workshop/internal/domain/member"
+)
+
+// --- Mock stores for grading pick list tests ---
+
+type mockPLEventStore struct{}
+
+// GetByID returns the grading day for "ev1".
+// PRE: id is non-empty
+// POST: Returns the event or an error
+func (m *mockPLEventStore) GetByID(_ context.Context, id string) (calendar.Event, error) {
+	if id != "ev1" {
+		return calendar.Event{}, errors.New("not found")
+	}
+	return calendar.Event{ID: "ev1", Title: "June Grading", StartDate: time.Date(2026, 6, 20, 10, 0, 0, 0, time.UTC)}, nil
+}
+
+type mockPLProposalStore struct {
+	proposals []grading.Proposal
+}
+
+// ListByEventID returns every proposal; tests use one grading day.
+// PRE: eventID is non-empty
+// POST: Returns the proposals
+func (m *mockPLProposalStore) ListByEventID(_ context.Context, _ string) ([]grading.Proposal, error) {
+	return m.proposals, nil
+}
+
+type mockPLMemberStore struct{}
+
+// GetByID returns a member named after the ID, or an error for "gone".
+// PRE: id is non-empty
+// POST: Returns the member or an error
+func (m *mockPLMemberStore) GetByID(_ context.Context, id string) (member.Member, error) {
+	if id == "gone" {
+		return member.Member{}, errors.New("not found")
+	}
+	return member.Member{ID: id, Name: id, Program: member.ProgramAdults}, nil
+}
+
+type mockPLStockStore struct {
+	items []inventory.Item
+	sizes map[string]string
+}
+
+// ListItems returns the stock.
+// PRE: none
+// POST: Returns the items
+func (m *mockPLStockStore) ListItems(_ context.Context) ([]inventory.Item, error) {
+	return m.items, nil
+}
+
+// GetMemberSize returns a member's belt size.
+// PRE: memberID is non-empty
+// POST: Returns the size or an error if none is recorded
+func (m *mockPLStockStore) GetMemberSize(_ context.Context, memberID string) (inventory.MemberSize, error) {
+	if size, ok := m.sizes[memberID]; ok {
+		return inventory.MemberSize{MemberID: memberID, Size: size}, nil
+	}
+	return inventory.MemberSize{}, errors.New("not found")
+}
+
+// TestQueryGetGradingPickList verifies approved proposals are grouped into belts by
+// colour and size, with what stock remains.
+func TestQueryGetGradingPickList(t *testing.T) {
+	approved := func(memberID, belt string) grading.Proposal {
+		return grading.Proposal{ID: "p-" + memberID, MemberID: memberID, TargetBelt: belt, Status: grading.ProposalApproved, EventID: "ev1"}
+	}
+	deps := GetGradingPickListDeps{
+		EventStore: &mockPLEventStore{},
+		ProposalStore: &mockPLProposalStore{proposals: []grading.Proposal{
+			approved("Zoe", grading.BeltBlue),
+			approved("Ana", grading.BeltBlue),
+			approved("Tom", grading.BeltPurple),
+			approved("Kim", grading.BeltBlue),
+			approved("gone", grading.BeltBlue),
+			{ID: "p-open", MemberID: "Sam", TargetBelt: grading.BeltBlue, Status: grading.ProposalScheduled, EventID: "ev1"},
+		}},
+		MemberStore: &mockPLMemberStore{},
+		StockStore: &mockPLStockStore{
+			items: []inventory.Item{
+				{Kind: inventory.KindBelt, Color: grading.BeltBlue, Size: "A2", Quantity: 1, LowStock: 2},
+				{Kind: inventory.KindBelt, Color: grading.BeltBlue, Size: "A3", Quantity: 5},
+				{Kind: inventory.KindStripe, Color: inventory.StripeColor, Quantity: 50},
+			},
+			sizes: map[string]string{"Zoe": "A2", "Ana": "A2", "Tom": "A3"},
+		},
+	}
+
+	got, err := QueryGetGradingPickList(context.Background(), "ev1", deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.EventTitle != "June Grading" || got.EventDate != "2026-06-20" {
+		t.Errorf("event = %q %q", got.EventTitle, got.EventDate)
+	}
+	if len(got.Members) != 4 || got.Members[0].Name != "Ana" || got.Members[3].Belt != grading.BeltPurple {
+		t.Errorf("Members = %+v, want approved blues by name then the purple", got.Members)
+	}
+	if got.Unsized != 1 {
+		t.Errorf("Unsized = %d, want 1", got.Unsized)
+	}
+	want := []GradingPickListLine{
+		{Color: grading.BeltBlue, Size: "A2", Count: 2, Remaining: 1, Stocked: true, Low: true},
+		{Color: grading.BeltBlue, Size: "", Count: 1},
+		{Color: grading.BeltPurple, Size: "A3", Count: 1},
+	}
+	if len(got.Lines) != len(want) {
+		t.Fatalf("Lines = %+v, want %+v", got.Lines, want)
+	}
+	for i := range want {
+		if got.Lines[i] != want[i] {
+			t.Errorf("Lines[%d] = %+v, want %+v", i, got.Lines[i], want[i])
+		}
+	}
+}
+
+// TestQueryGetGradingPickList_UnknownEvent verifies an unknown grading day is an error.
+func TestQueryGetGradingPickList_UnknownEvent(t *testing.T) {
+	_, err := QueryGetGradingPickList(context.Background(), "nope", GetGradingPickListDeps{EventStore: &mockPLEventStore{}})
+	if err == nil {
+		t.Error("expected an error for an unknown event")
+	}
+}
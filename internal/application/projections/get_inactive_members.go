@@ -21,7 +21,9 @@ type InactiveAttendanceStore interface {
 
 // GetInactiveMembersQuery carries input for the inactive radar projection.
 type GetInactiveMembersQuery struct {
-	DaysSinceLastCheckIn int // members inactive for at least this many days
+	DaysSinceLastCheckIn int      // members inactive for at least this many days
+	Tag                  string   // optional: members carrying this tag
+	IDs                  []string // optional: a resolved segment; nil does not filter, empty matches none
 }
 
 // GetInactiveMembersDeps holds dependencies for the inactive radar.
@@ -49,8 +51,8 @@ func QueryGetInactiveMembers(ctx context.Context, query GetInactiveMembersQuery,
 
 	cutoff := time.Now().AddDate(0, 0, -query.DaysSinceLastCheckIn)
 
-	// Get all non-archived members
-	members, err := deps.MemberStore.List(ctx, memberStore.ListFilter{Limit: 10000})
+	// Get all members, skipping archived ones and frozen ones (who are away on purpose)
+	members, err := deps.MemberStore.List(ctx, memberStore.ListFilter{Tag: query.Tag, IDs: query.IDs, Limit: 10000})
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +60,7 @@ func QueryGetInactiveMembers(ctx context.Context, query GetInactiveMembersQuery,
 	var results []InactiveMemberResult
 
 	for _, m := range members {
-		if m.IsArchived() {
+		if !m.CanCheckIn() {
 			continue
 		}
 
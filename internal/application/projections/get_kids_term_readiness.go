@@ -61,6 +61,16 @@ type KidsReadinessGradingConfigStore interface {
 	GetByProgramAndBelt(ctx context.Context, program, belt string) (grading.Config, error)
 }
 
+// KidsReadinessMakeupCreditStore defines the makeup credit store interface needed by this projection.
+type KidsReadinessMakeupCreditStore interface {
+	ListByTermID(ctx context.Context, termID string) ([]attendance.MakeupCredit, error)
+}
+
+// KidsReadinessThresholdStore defines the per-term threshold store interface needed by this projection.
+type KidsReadinessThresholdStore interface {
+	ListThresholds(ctx context.Context, termID string) ([]term.Threshold, error)
+}
+
 // GetKidsTermReadinessDeps holds dependencies for the kids term readiness projection.
 type GetKidsTermReadinessDeps struct {
 	TermStore          KidsReadinessTermStore
@@ -72,6 +82,8 @@ type GetKidsTermReadinessDeps struct {
 	AttendanceStore    KidsReadinessAttendanceStore
 	GradingRecordStore KidsReadinessGradingRecordStore
 	GradingConfigStore KidsReadinessGradingConfigStore
+	MakeupCreditStore  KidsReadinessMakeupCreditStore // optional: nil counts no makeup credits
+	ThresholdStore     KidsReadinessThresholdStore    // optional: nil uses the grading config only
 }
 
 // GetKidsTermReadinessQuery carries input for the kids term readiness projection.
@@ -81,32 +93,50 @@ type GetKidsTermReadinessQuery struct {
 }
 
 // KidsTermReadinessEntry represents a single kid's readiness for belt promotion.
+// AttendancePct is (Attended + MakeupCredits) / TotalSessions.
 type KidsTermReadinessEntry struct {
-	MemberID      string
-	MemberName    string
-	CurrentBelt   string
-	TargetBelt    string
-	Attended      int
-	TotalSessions int
-	AttendancePct float64
-	ThresholdPct  float64
-	Eligible      bool
+	MemberID          string
+	MemberName        string
+	CurrentBelt       string
+	TargetBelt        string
+	Attended          int
+	MakeupCredits     int
+	TotalSessions     int // sessions held on the kid's own schedules
+	CancelledSessions int // sessions on those schedules lost to holidays
+	AttendancePct     float64
+	ThresholdPct      float64
+	Eligible          bool
+}
+
+// KidsScheduleSessions counts one kids schedule's sessions in the term.
+type KidsScheduleSessions struct {
+	ScheduleID string
+	Day        string
+	StartTime  string
+	Scheduled  int // weekday occurrences in the term
+	Cancelled  int // occurrences that fall on a holiday
+	Held       int // Scheduled - Cancelled
 }
 
 // KidsTermReadinessResult carries the output of the kids term readiness projection.
 type KidsTermReadinessResult struct {
-	TermName string
-	TermID   string
-	Entries  []KidsTermReadinessEntry
+	TermName  string
+	TermID    string
+	Schedules []KidsScheduleSessions
+	Entries   []KidsTermReadinessEntry
 }
 
 // QueryGetKidsTermReadiness computes kids grading readiness by term attendance percentage.
 // Algorithm:
-// 1. Find the target term (by ID or current date)
-// 2. Find all kids program schedules
-// 3. Count available sessions in the term (schedule occurrences minus holidays)
-// 4. For each active kids member, count attendance in the term for kids schedules
-// 5. Calculate attendance percentage and eligibility against the config threshold
+//  1. Find the target term (by ID or current date)
+//  2. Find all kids program schedules
+//  3. Count each schedule's sessions in the term, less those cancelled by holidays
+//  4. For each active kids member, count attendance in the term for kids schedules
+//     and the makeup credits awarded for the term
+//  5. Expect only the sessions of the schedules the kid trains in (all kids schedules
+//     if they have not trained yet), so a Monday-only kid is not measured against Wednesday
+//  6. Calculate attendance percentage and eligibility against the term's threshold for the
+//     belt, falling back to the grading config
 func QueryGetKidsTermReadiness(ctx context.Context, query GetKidsTermReadinessQuery, deps GetKidsTermReadinessDeps) (KidsTermReadinessResult, error) {
 	// Step 1: Find the target term
 	terms, err := deps.TermStore.List(ctx)
@@ -169,24 +199,53 @@ func QueryGetKidsTermReadiness(ctx context.Context, query GetKidsTermReadinessQu
 		return KidsTermReadinessResult{TermName: targetTerm.Name, TermID: targetTerm.ID}, nil
 	}
 
-	// Step 3: Count available sessions in the term (schedule day occurrences minus holidays)
+	// Step 3: Count each schedule's sessions in the term, less holiday-cancelled ones
 	holidays, err := deps.HolidayStore.List(ctx)
 	if err != nil {
 		return KidsTermReadinessResult{}, err
 	}
 
-	totalSessions := countSessionsInTerm(kidsSchedules, targetTerm, holidays)
-	if totalSessions == 0 {
-		return KidsTermReadinessResult{TermName: targetTerm.Name, TermID: targetTerm.ID}, nil
+	result := KidsTermReadinessResult{
+		TermName: targetTerm.Name,
+		TermID:   targetTerm.ID,
+	}
+	sessionsBySchedule := make(map[string]KidsScheduleSessions, len(kidsSchedules))
+	allHeld, allCancelled := 0, 0
+	for _, s := range kidsSchedules {
+		sessions := countScheduleSessions(s, targetTerm, holidays)
+		sessionsBySchedule[s.ID] = sessions
+		result.Schedules = append(result.Schedules, sessions)
+		allHeld += sessions.Held
+		allCancelled += sessions.Cancelled
+	}
+	if allHeld == 0 {
+		return result, nil
 	}
 
-	// Step 4: For each active kids member, count attendance
+	// Step 4: For each active kids member, count attendance and makeup credits
 	startDate := targetTerm.StartDate.Format("2006-01-02")
 	endDate := targetTerm.EndDate.Format("2006-01-02")
 
-	result := KidsTermReadinessResult{
-		TermName: targetTerm.Name,
-		TermID:   targetTerm.ID,
+	makeupByMember := make(map[string]int)
+	if deps.MakeupCreditStore != nil {
+		credits, err := deps.MakeupCreditStore.ListByTermID(ctx, targetTerm.ID)
+		if err != nil {
+			return KidsTermReadinessResult{}, err
+		}
+		for _, c := range credits {
+			makeupByMember[c.MemberID]++
+		}
+	}
+
+	termThresholds := make(map[string]float64)
+	if deps.ThresholdStore != nil {
+		list, err := deps.ThresholdStore.ListThresholds(ctx, targetTerm.ID)
+		if err != nil {
+			return KidsTermReadinessResult{}, err
+		}
+		for _, t := range list {
+			termThresholds[t.Belt] = t.AttendancePct
+		}
 	}
 
 	members, err := deps.MemberStore.List(ctx, memberStore.ListFilter{
@@ -223,11 +282,14 @@ func QueryGetKidsTermReadiness(ctx context.Context, query GetKidsTermReadinessQu
 			continue // at highest kids belt
 		}
 
-		thresholdPct := 80.0 // default
+		thresholdPct := grading.DefaultKidsAttendancePct
 		config, err := deps.GradingConfigStore.GetByProgramAndBelt(ctx, "kids", nextBelt)
 		if err == nil && config.AttendancePct > 0 {
 			thresholdPct = config.AttendancePct
 		}
+		if pct, ok := termThresholds[nextBelt]; ok && pct > 0 {
+			thresholdPct = pct // the term's own threshold wins over the grading config
+		}
 
 		// Count attendance in term for kids schedules
 		attendanceRecords, err := deps.AttendanceStore.ListByMemberIDAndDateRange(ctx, m.ID, startDate, endDate)
@@ -235,67 +297,69 @@ func QueryGetKidsTermReadiness(ctx context.Context, query GetKidsTermReadinessQu
 			continue
 		}
 		attended := 0
+		ownSchedules := make(map[string]bool)
 		for _, a := range attendanceRecords {
 			if kidsScheduleIDs[a.ScheduleID] {
 				attended++
+				ownSchedules[a.ScheduleID] = true
 			}
 		}
 
+		// Step 5: Expect only the sessions of the kid's own schedules
+		totalSessions, cancelled := allHeld, allCancelled
+		if len(ownSchedules) > 0 {
+			totalSessions, cancelled = 0, 0
+			for id := range ownSchedules {
+				totalSessions += sessionsBySchedule[id].Held
+				cancelled += sessionsBySchedule[id].Cancelled
+			}
+		}
+
+		makeup := makeupByMember[m.ID]
 		pct := 0.0
 		if totalSessions > 0 {
-			pct = (float64(attended) / float64(totalSessions)) * 100
+			pct = (float64(attended+makeup) / float64(totalSessions)) * 100
 		}
 
 		result.Entries = append(result.Entries, KidsTermReadinessEntry{
-			MemberID:      m.ID,
-			MemberName:    m.Name,
-			CurrentBelt:   currentBelt,
-			TargetBelt:    nextBelt,
-			Attended:      attended,
-			TotalSessions: totalSessions,
-			AttendancePct: pct,
-			ThresholdPct:  thresholdPct,
-			Eligible:      pct >= thresholdPct,
+			MemberID:          m.ID,
+			MemberName:        m.Name,
+			CurrentBelt:       currentBelt,
+			TargetBelt:        nextBelt,
+			Attended:          attended,
+			MakeupCredits:     makeup,
+			TotalSessions:     totalSessions,
+			CancelledSessions: cancelled,
+			AttendancePct:     pct,
+			ThresholdPct:      thresholdPct,
+			Eligible:          pct >= thresholdPct,
 		})
 	}
 
 	return result, nil
 }
 
-// countSessionsInTerm counts how many schedule occurrences fall within the term,
-// excluding dates that overlap with holidays.
-func countSessionsInTerm(schedules []schedule.Schedule, t term.Term, holidays []holiday.Holiday) int {
-	// Build a set of days-of-week that have kids schedules
-	dayCount := make(map[string]int)
-	for _, s := range schedules {
-		dayCount[s.Day]++
-	}
-
-	count := 0
+// countScheduleSessions counts a schedule's weekday occurrences within the term
+// and how many of them fall on a holiday.
+func countScheduleSessions(s schedule.Schedule, t term.Term, holidays []holiday.Holiday) KidsScheduleSessions {
+	sessions := KidsScheduleSessions{ScheduleID: s.ID, Day: s.Day, StartTime: s.StartTime}
 	start := t.StartDate.Truncate(24 * time.Hour)
 	end := t.EndDate.Truncate(24 * time.Hour)
 
 	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
-		dayName := strings.ToLower(d.Weekday().String())
-		n, ok := dayCount[dayName]
-		if !ok || n == 0 {
+		if strings.ToLower(d.Weekday().String()) != s.Day {
 			continue
 		}
-
-		// Check if this date is a holiday
-		isHoliday := false
+		sessions.Scheduled++
 		for _, h := range holidays {
 			if h.Contains(d) {
-				isHoliday = true
+				sessions.Cancelled++
 				break
 			}
 		}
-		if !isHoliday {
-			count += n // add number of kids sessions on this day
-		}
 	}
-
-	return count
+	sessions.Held = sessions.Scheduled - sessions.Cancelled
+	return sessions
 }
 
 // nextKidsBelt returns the next belt in the kids progression, or "" if at highest.
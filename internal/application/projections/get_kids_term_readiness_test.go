@@ -436,3 +436,136 @@ func TestKidsTermReadiness_CrossTermIsolation(t *testing.T) {
 		}
 	}
 }
+
+type mockKRMakeupCreditStore struct {
+	credits []attendance.MakeupCredit
+}
+
+// ListByTermID returns makeup credits for a term.
+// PRE: termID is non-empty
+// POST: Returns the seeded credits for the term
+func (m *mockKRMakeupCreditStore) ListByTermID(_ context.Context, termID string) ([]attendance.MakeupCredit, error) {
+	var result []attendance.MakeupCredit
+	for _, c := range m.credits {
+		if c.TermID == termID {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+// TestKidsTermReadiness_PerScheduleExpectation verifies a kid who trains on one schedule is
+// measured against that schedule's sessions only, less the ones a holiday cancelled.
+func TestKidsTermReadiness_PerScheduleExpectation(t *testing.T) {
+	deps := newKidsReadinessTestDeps()
+	// Anniversary Day closes the gym on a Monday.
+	deps.HolidayStore = &mockKRHolidayStore{holidays: []holiday.Holiday{
+		{ID: "h1", Name: "Anniversary Day", StartDate: time.Date(2026, 1, 26, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 1, 26, 0, 0, 0, 0, time.UTC)},
+	}}
+	// kid1 comes to every Monday class that runs.
+	var records []attendance.Attendance
+	for d := time.Date(2026, 1, 19, 16, 0, 0, 0, time.UTC); d.Before(time.Date(2026, 4, 11, 0, 0, 0, 0, time.UTC)); d = d.AddDate(0, 0, 7) {
+		if d.Day() == 26 && d.Month() == time.January {
+			continue
+		}
+		records = append(records, attendance.Attendance{ID: "att-" + d.Format("0102"), MemberID: "kid1", ScheduleID: "sched-mon", CheckInTime: d, ClassDate: d.Format("2006-01-02")})
+	}
+	deps.AttendanceStore = &mockKRAttendanceStore{records: records}
+
+	result, err := QueryGetKidsTermReadiness(context.Background(), GetKidsTermReadinessQuery{TermID: "term1"}, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sessions := map[string]KidsScheduleSessions{}
+	for _, s := range result.Schedules {
+		sessions[s.ScheduleID] = s
+	}
+	// 12 Mondays and 12 Wednesdays from 19 Jan to 10 Apr 2026.
+	if mon := sessions["sched-mon"]; mon.Scheduled != 12 || mon.Cancelled != 1 || mon.Held != 11 {
+		t.Errorf("monday sessions = %+v, want 12 scheduled, 1 cancelled, 11 held", mon)
+	}
+	if wed := sessions["sched-wed"]; wed.Scheduled != 12 || wed.Cancelled != 0 || wed.Held != 12 {
+		t.Errorf("wednesday sessions = %+v, want 12 scheduled, none cancelled", wed)
+	}
+
+	for _, e := range result.Entries {
+		switch e.MemberID {
+		case "kid1":
+			if e.Attended != 11 || e.TotalSessions != 11 || e.CancelledSessions != 1 || !e.Eligible {
+				t.Errorf("kid1 = %d/%d (%d cancelled, eligible %v), want 11/11, 1 cancelled, eligible", e.Attended, e.TotalSessions, e.CancelledSessions, e.Eligible)
+			}
+		case "kid2":
+			if e.TotalSessions != 23 {
+				t.Errorf("kid2 has not trained yet, expected every kids session (23), got %d", e.TotalSessions)
+			}
+		}
+	}
+}
+
+// TestKidsTermReadiness_MakeupCredits verifies makeup credits for the term count as attended sessions.
+func TestKidsTermReadiness_MakeupCredits(t *testing.T) {
+	deps := newKidsReadinessTestDeps()
+	// kid1 made 9 of 12 Mondays: 75%, below the 80% threshold.
+	var records []attendance.Attendance
+	d := time.Date(2026, 1, 19, 16, 0, 0, 0, time.UTC)
+	for i := 0; i < 9; i++ {
+		records = append(records, attendance.Attendance{ID: fmt.Sprintf("att-%d", i), MemberID: "kid1", ScheduleID: "sched-mon", CheckInTime: d, ClassDate: d.Format("2006-01-02")})
+		d = d.AddDate(0, 0, 7)
+	}
+	deps.AttendanceStore = &mockKRAttendanceStore{records: records}
+	query := GetKidsTermReadinessQuery{TermID: "term1"}
+
+	before, _ := QueryGetKidsTermReadiness(context.Background(), query, deps)
+	if before.Entries[0].Eligible {
+		t.Fatalf("kid1 should not be eligible at %.0f%% before makeup credits", before.Entries[0].AttendancePct)
+	}
+
+	deps.MakeupCreditStore = &mockKRMakeupCreditStore{credits: []attendance.MakeupCredit{
+		{ID: "mc1", MemberID: "kid1", TermID: "term1", Reason: "Private lesson"},
+		{ID: "mc2", MemberID: "kid1", TermID: "term1", Reason: "Open mat"},
+		{ID: "mc3", MemberID: "kid1", TermID: "term0", Reason: "Last term"},
+	}}
+	after, err := QueryGetKidsTermReadiness(context.Background(), query, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid1 := after.Entries[0]
+	if kid1.MemberID != "kid1" || kid1.Attended != 9 || kid1.MakeupCredits != 2 || !kid1.Eligible {
+		t.Errorf("kid1 = %d attended + %d makeup of %d (%.0f%%), want 9 + 2 of 12 and eligible", kid1.Attended, kid1.MakeupCredits, kid1.TotalSessions, kid1.AttendancePct)
+	}
+}
+
+type mockKRThresholdStore struct {
+	thresholds []term.Threshold
+}
+
+// ListThresholds returns the term's thresholds.
+// PRE: termID is non-empty
+// POST: Returns the thresholds set for the term
+func (m *mockKRThresholdStore) ListThresholds(_ context.Context, termID string) ([]term.Threshold, error) {
+	var out []term.Threshold
+	for _, t := range m.thresholds {
+		if t.TermID == termID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// TestKidsTermReadiness_TermThreshold verifies a threshold set on the term overrides the
+// grading config for that term only.
+func TestKidsTermReadiness_TermThreshold(t *testing.T) {
+	deps := newKidsReadinessTestDeps()
+	deps.ThresholdStore = &mockKRThresholdStore{thresholds: []term.Threshold{
+		{TermID: "term1", Belt: grading.BeltGrey, AttendancePct: 60},
+		{TermID: "term2", Belt: grading.BeltGrey, AttendancePct: 95},
+	}}
+	result, err := QueryGetKidsTermReadiness(context.Background(), GetKidsTermReadinessQuery{TermID: "term1"}, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Entries) == 0 || result.Entries[0].ThresholdPct != 60 {
+		t.Errorf("expected the term's 60%% threshold, got %+v", result.Entries)
+	}
+}
@@ -0,0 +1,193 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"workshop/internal/domain/kiosk"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/notice"
+	"workshop/internal/domain/rotor"
+)
+
+// KioskBoardAttendanceStore defines the attendance store interface needed by the kiosk board projection.
+type KioskBoardAttendanceStore interface {
+	ListDistinctMemberIDsByScheduleAndDate(ctx context.Context, scheduleID string, classDate string) ([]string, error)
+}
+
+// KioskBoardMemberStore defines the member store interface needed by the kiosk board projection.
+type KioskBoardMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+}
+
+// KioskBoardRotorStore defines the rotor store interface needed by the kiosk board projection.
+type KioskBoardRotorStore interface {
+	GetActiveRotor(ctx context.Context, classTypeID string) (rotor.Rotor, error)
+	ListThemesByRotor(ctx context.Context, rotorID string) ([]rotor.RotorTheme, error)
+	GetActiveScheduleForTheme(ctx context.Context, rotorThemeID string) (rotor.TopicSchedule, error)
+	GetTopic(ctx context.Context, id string) (rotor.Topic, error)
+}
+
+// KioskBoardNoticeStore defines the notice store interface needed by the kiosk board projection.
+type KioskBoardNoticeStore interface {
+	ListPublished(ctx context.Context, noticeType string, now time.Time) ([]notice.Notice, error)
+}
+
+// GetKioskBoardDeps holds dependencies for the kiosk board projection.
+type GetKioskBoardDeps struct {
+	TodaysClassesDeps GetTodaysClassesDeps
+	AttendanceStore   KioskBoardAttendanceStore
+	MemberStore       KioskBoardMemberStore
+	RotorStore        KioskBoardRotorStore  // optional: nil omits rotor topics
+	NoticeStore       KioskBoardNoticeStore // optional: nil omits notices
+}
+
+// GetKioskBoardQuery selects the location the board stands in.
+type GetKioskBoardQuery struct {
+	LocationID string // empty shows every location's classes
+}
+
+// KioskBoardClass is one of today's classes on the board.
+type KioskBoardClass struct {
+	ScheduleID  string
+	ClassName   string
+	ProgramName string
+	StartTime   string
+	EndTime     string
+	Substitute  string
+	Topics      []string // the active rotor topic of each theme, as "Theme: Topic"
+}
+
+// KioskBoardNotice is a published notice shown on the board.
+type KioskBoardNotice struct {
+	ID      string
+	Title   string
+	Content string
+	Color   string
+}
+
+// KioskBoardResult is everything the display board shows.
+type KioskBoardResult struct {
+	Current   *KioskBoardClass // open for check-in, or next to start; nil when today's classes are over
+	Open      bool             // Current is open for check-in
+	StartsAt  time.Time        // when Current starts; the board counts down to it
+	CheckedIn []string         // members checked in to Current, as board names
+	Classes   []KioskBoardClass
+	Notices   []KioskBoardNotice
+}
+
+// QueryGetKioskBoard builds the wall display for a location: today's classes with their
+// rotor topics, the class members are arriving for and who has checked in to it, and
+// published school-wide notices plus notices for today's classes.
+// PRE: now is in the gym's local time zone
+// POST: Returns the board; store errors other than today's classes and check-ins are skipped
+func QueryGetKioskBoard(ctx context.Context, query GetKioskBoardQuery, deps GetKioskBoardDeps, now time.Time) (KioskBoardResult, error) {
+	result := KioskBoardResult{CheckedIn: []string{}, Classes: []KioskBoardClass{}, Notices: []KioskBoardNotice{}}
+
+	classes, err := QueryGetTodaysClassesAtLocation(ctx, now, query.LocationID, deps.TodaysClassesDeps)
+	if err != nil {
+		return result, err
+	}
+	sort.SliceStable(classes, func(i, j int) bool { return classes[i].StartTime < classes[j].StartTime })
+
+	topics := map[string][]string{}
+	slots := make([]kiosk.ClassSlot, 0, len(classes))
+	classTypes := map[string]bool{}
+	for _, c := range classes {
+		if _, ok := topics[c.ClassTypeID]; !ok {
+			topics[c.ClassTypeID] = activeTopics(ctx, deps.RotorStore, c.ClassTypeID)
+		}
+		classTypes[c.ClassTypeID] = true
+		result.Classes = append(result.Classes, KioskBoardClass{
+			ScheduleID:  c.ScheduleID,
+			ClassName:   c.ClassTypeName,
+			ProgramName: c.ProgramName,
+			StartTime:   c.StartTime,
+			EndTime:     c.EndTime,
+			Substitute:  c.Substitute,
+			Topics:      topics[c.ClassTypeID],
+		})
+		slots = append(slots, kiosk.ClassSlot{ScheduleID: c.ScheduleID, StartTime: c.StartTime, EndTime: c.EndTime})
+	}
+
+	if slot, open, ok := kiosk.BoardClass(slots, now); ok {
+		for i := range result.Classes {
+			if result.Classes[i].ScheduleID == slot.ScheduleID {
+				result.Current = &result.Classes[i]
+				break
+			}
+		}
+		result.Open = open
+		result.StartsAt = kiosk.ClassStart(slot, now)
+
+		ids, err := deps.AttendanceStore.ListDistinctMemberIDsByScheduleAndDate(ctx, slot.ScheduleID, now.Format("2006-01-02"))
+		if err != nil {
+			return result, err
+		}
+		for _, id := range ids {
+			if m, err := deps.MemberStore.GetByID(ctx, id); err == nil {
+				result.CheckedIn = append(result.CheckedIn, kiosk.BoardName(m.Name))
+			}
+		}
+		sort.Strings(result.CheckedIn)
+	}
+
+	if deps.NoticeStore != nil {
+		result.Notices = boardNotices(ctx, deps.NoticeStore, classTypes, now)
+	}
+	return result, nil
+}
+
+// activeTopics lists "Theme: Topic" for each theme of a class type's active rotor with a
+// topic running now. Hidden themes appear only once active, as they do for members.
+func activeTopics(ctx context.Context, store KioskBoardRotorStore, classTypeID string) []string {
+	out := []string{}
+	if store == nil {
+		return out
+	}
+	active, err := store.GetActiveRotor(ctx, classTypeID)
+	if err != nil {
+		return out
+	}
+	themes, err := store.ListThemesByRotor(ctx, active.ID)
+	if err != nil {
+		return out
+	}
+	sort.SliceStable(themes, func(i, j int) bool { return themes[i].Position < themes[j].Position })
+	for _, th := range themes {
+		sched, err := store.GetActiveScheduleForTheme(ctx, th.ID)
+		if err != nil {
+			continue
+		}
+		tp, err := store.GetTopic(ctx, sched.TopicID)
+		if err != nil {
+			continue
+		}
+		out = append(out, th.Name+": "+tp.Name)
+	}
+	return out
+}
+
+// boardNotices returns published school-wide notices and class notices for today's
+// class types, pinned notices first.
+func boardNotices(ctx context.Context, store KioskBoardNoticeStore, classTypes map[string]bool, now time.Time) []KioskBoardNotice {
+	var list []notice.Notice
+	if school, err := store.ListPublished(ctx, notice.TypeSchoolWide, now); err == nil {
+		list = append(list, school...)
+	}
+	if class, err := store.ListPublished(ctx, notice.TypeClassSpecific, now); err == nil {
+		for _, n := range class {
+			if classTypes[n.TargetID] {
+				list = append(list, n)
+			}
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Pinned && !list[j].Pinned })
+
+	out := make([]KioskBoardNotice, 0, len(list))
+	for _, n := range list {
+		out = append(out, KioskBoardNotice{ID: n.ID, Title: n.Title, Content: n.Content, Color: n.Color})
+	}
+	return out
+}
@@ -0,0 +1,164 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/notice"
+	"workshop/internal/domain/rotor"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/term"
+)
+
+// --- Mock stores for kiosk board tests ---
+
+type mockKBAttendanceStore struct {
+	present map[string][]string // scheduleID -> member IDs
+}
+
+// ListDistinctMemberIDsByScheduleAndDate returns the members checked in to a class.
+// PRE: scheduleID is non-empty
+// POST: Returns member IDs for the schedule
+func (m *mockKBAttendanceStore) ListDistinctMemberIDsByScheduleAndDate(_ context.Context, scheduleID, _ string) ([]string, error) {
+	return m.present[scheduleID], nil
+}
+
+type mockKBMemberStore struct{}
+
+// GetByID returns a member whose name is built from the ID.
+// PRE: id is non-empty
+// POST: Returns the member, or an error for "gone"
+func (m *mockKBMemberStore) GetByID(_ context.Context, id string) (member.Member, error) {
+	if id == "gone" {
+		return member.Member{}, errors.New("not found")
+	}
+	return member.Member{ID: id, Name: id + " Smith"}, nil
+}
+
+type mockKBRotorStore struct{}
+
+// GetActiveRotor returns a rotor for ct-evening only.
+// PRE: classTypeID is non-empty
+// POST: Returns the rotor or an error
+func (m *mockKBRotorStore) GetActiveRotor(_ context.Context, classTypeID string) (rotor.Rotor, error) {
+	if classTypeID != "ct-evening" {
+		return rotor.Rotor{}, errors.New("no active rotor")
+	}
+	return rotor.Rotor{ID: "r1"}, nil
+}
+
+// ListThemesByRotor returns two themes out of position order.
+// PRE: rotorID is non-empty
+// POST: Returns the themes
+func (m *mockKBRotorStore) ListThemesByRotor(_ context.Context, _ string) ([]rotor.RotorTheme, error) {
+	return []rotor.RotorTheme{
+		{ID: "th-guard", Name: "Guard", Position: 1},
+		{ID: "th-stand", Name: "Standing", Position: 0},
+		{ID: "th-idle", Name: "Idle", Position: 2},
+	}, nil
+}
+
+// GetActiveScheduleForTheme returns a running topic for every theme but th-idle.
+// PRE: rotorThemeID is non-empty
+// POST: Returns the schedule or an error
+func (m *mockKBRotorStore) GetActiveScheduleForTheme(_ context.Context, rotorThemeID string) (rotor.TopicSchedule, error) {
+	if rotorThemeID == "th-idle" {
+		return rotor.TopicSchedule{}, errors.New("none active")
+	}
+	return rotor.TopicSchedule{TopicID: "tp-" + rotorThemeID}, nil
+}
+
+// GetTopic returns a topic named after its ID.
+// PRE: id is non-empty
+// POST: Returns the topic
+func (m *mockKBRotorStore) GetTopic(_ context.Context, id string) (rotor.Topic, error) {
+	names := map[string]string{"tp-th-guard": "Closed Guard", "tp-th-stand": "Single Leg"}
+	return rotor.Topic{ID: id, Name: names[id]}, nil
+}
+
+type mockKBNoticeStore struct{}
+
+// ListPublished returns one notice of each kind.
+// PRE: noticeType is a valid type
+// POST: Returns the notices of that type
+func (m *mockKBNoticeStore) ListPublished(_ context.Context, noticeType string, _ time.Time) ([]notice.Notice, error) {
+	if noticeType == notice.TypeSchoolWide {
+		return []notice.Notice{{ID: "n-school", Title: "Gym closed Friday"}}, nil
+	}
+	return []notice.Notice{
+		{ID: "n-evening", TargetID: "ct-evening", Title: "Bring a gi", Pinned: true},
+		{ID: "n-other", TargetID: "ct-elsewhere", Title: "Not today"},
+	}, nil
+}
+
+// kioskBoardDeps returns deps with a morning and an evening class on Monday 2 March 2026.
+func kioskBoardDeps() GetKioskBoardDeps {
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	return GetKioskBoardDeps{
+		TodaysClassesDeps: GetTodaysClassesDeps{
+			ScheduleStore: &mockTCScheduleStore{schedules: []schedule.Schedule{
+				{ID: "s-evening", ClassTypeID: "ct-evening", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:30"},
+				{ID: "s-morning", ClassTypeID: "ct-morning", Day: schedule.Monday, StartTime: "06:00", EndTime: "07:00"},
+			}},
+			TermStore: &mockTCTermStore{terms: []term.Term{
+				{ID: "t1", Name: "Term 1", StartDate: monday.AddDate(0, -1, 0), EndDate: monday.AddDate(0, 1, 0)},
+			}},
+			HolidayStore:   &mockTCHolidayStore{},
+			ClassTypeStore: &mockTCClassTypeStore{},
+			ProgramStore:   &mockTCProgramStore{},
+		},
+		AttendanceStore: &mockKBAttendanceStore{present: map[string][]string{"s-evening": {"Zara", "Ben", "gone"}}},
+		MemberStore:     &mockKBMemberStore{},
+		RotorStore:      &mockKBRotorStore{},
+		NoticeStore:     &mockKBNoticeStore{},
+	}
+}
+
+// TestQueryGetKioskBoard verifies the board features the class members are arriving for,
+// with its check-ins, rotor topics and notices.
+func TestQueryGetKioskBoard(t *testing.T) {
+	now := time.Date(2026, 3, 2, 17, 30, 0, 0, time.UTC)
+	got, err := QueryGetKioskBoard(context.Background(), GetKioskBoardQuery{}, kioskBoardDeps(), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got.Classes) != 2 || got.Classes[0].ScheduleID != "s-morning" {
+		t.Fatalf("expected classes in start order, got %+v", got.Classes)
+	}
+	if got.Current == nil || got.Current.ScheduleID != "s-evening" || !got.Open {
+		t.Fatalf("expected the evening class open for check-in, got %+v open=%v", got.Current, got.Open)
+	}
+	if want := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC); !got.StartsAt.Equal(want) {
+		t.Errorf("StartsAt = %v, want %v", got.StartsAt, want)
+	}
+	if len(got.CheckedIn) != 2 || got.CheckedIn[0] != "Ben S." || got.CheckedIn[1] != "Zara S." {
+		t.Errorf("CheckedIn = %v, want [Ben S. Zara S.]", got.CheckedIn)
+	}
+	if topics := got.Current.Topics; len(topics) != 2 || topics[0] != "Standing: Single Leg" || topics[1] != "Guard: Closed Guard" {
+		t.Errorf("Topics = %v", topics)
+	}
+	if len(got.Classes[0].Topics) != 0 {
+		t.Errorf("expected no topics without an active rotor, got %v", got.Classes[0].Topics)
+	}
+	if len(got.Notices) != 2 || got.Notices[0].ID != "n-evening" || got.Notices[1].ID != "n-school" {
+		t.Errorf("Notices = %+v, want pinned class notice then school notice", got.Notices)
+	}
+}
+
+// TestQueryGetKioskBoard_DayOver verifies the board has no current class after the last one ends.
+func TestQueryGetKioskBoard_DayOver(t *testing.T) {
+	deps := kioskBoardDeps()
+	deps.RotorStore = nil
+	deps.NoticeStore = nil
+	got, err := QueryGetKioskBoard(context.Background(), GetKioskBoardQuery{}, deps, time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Current != nil || len(got.CheckedIn) != 0 || len(got.Notices) != 0 || len(got.Classes) != 2 {
+		t.Errorf("unexpected board %+v", got)
+	}
+}
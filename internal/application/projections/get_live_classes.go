@@ -0,0 +1,79 @@
+package projections
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/domain/attendance"
+)
+
+// LiveClassLead is how long before a class starts it counts as live, so early check-ins show.
+const LiveClassLead = 15 * time.Minute
+
+// LiveClassesAttendanceStore defines the attendance store interface needed for live headcounts.
+type LiveClassesAttendanceStore interface {
+	ListByDateRange(ctx context.Context, startDate string, endDate string) ([]attendance.Attendance, error)
+}
+
+// GetLiveClassesDeps holds dependencies for QueryGetLiveClasses.
+type GetLiveClassesDeps struct {
+	TodaysClasses   GetTodaysClassesDeps
+	AttendanceStore LiveClassesAttendanceStore
+}
+
+// LiveClassResult is a class running now with the people on the mat.
+type LiveClassResult struct {
+	TodaysClassResult
+	Headcount    int  // members checked in and not yet checked out, guests included
+	OverCapacity bool // Headcount exceeds MatCapacity
+}
+
+// QueryGetLiveClasses returns the classes running at now, from LiveClassLead before they start
+// until they end, with a live headcount from today's check-ins.
+// PRE: now is a valid time
+// POST: Returns classes at the location ordered as today's classes; a member counts once per class
+func QueryGetLiveClasses(ctx context.Context, now time.Time, locationID string, deps GetLiveClassesDeps) ([]LiveClassResult, error) {
+	classes, err := QueryGetTodaysClassesAtLocation(ctx, now, locationID, deps.TodaysClasses)
+	if err != nil {
+		return nil, err
+	}
+	today := now.Format("2006-01-02")
+	var live []LiveClassResult
+	for _, c := range classes {
+		start, err := time.ParseInLocation("2006-01-02 15:04", today+" "+c.StartTime, now.Location())
+		if err != nil {
+			continue
+		}
+		end, err := time.ParseInLocation("2006-01-02 15:04", today+" "+c.EndTime, now.Location())
+		if err != nil {
+			continue
+		}
+		if now.Before(start.Add(-LiveClassLead)) || !now.Before(end) {
+			continue
+		}
+		live = append(live, LiveClassResult{TodaysClassResult: c})
+	}
+	if len(live) == 0 {
+		return []LiveClassResult{}, nil
+	}
+
+	records, err := deps.AttendanceStore.ListByDateRange(ctx, today, today)
+	if err != nil {
+		return nil, err
+	}
+	onMat := make(map[string]map[string]bool)
+	for _, a := range records {
+		if a.ClassDate != today || a.ScheduleID == "" || a.IsCheckedOut() {
+			continue
+		}
+		if onMat[a.ScheduleID] == nil {
+			onMat[a.ScheduleID] = make(map[string]bool)
+		}
+		onMat[a.ScheduleID][a.MemberID] = true
+	}
+	for i := range live {
+		live[i].Headcount = len(onMat[live[i].ScheduleID])
+		live[i].OverCapacity = live[i].MatCapacity > 0 && live[i].Headcount > live[i].MatCapacity
+	}
+	return live, nil
+}
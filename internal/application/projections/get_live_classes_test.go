@@ -0,0 +1,72 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/term"
+)
+
+type mockLCClassTypeStore struct{}
+
+// GetByID returns a class type whose mat holds two people.
+// PRE: id is non-empty
+// POST: Returns a class type in program p1 with MatCapacity 2
+func (m *mockLCClassTypeStore) GetByID(_ context.Context, id string) (classtype.ClassType, error) {
+	return classtype.ClassType{ID: id, ProgramID: "p1", Name: "Class " + id, MatCapacity: 2}, nil
+}
+
+// TestQueryGetLiveClasses verifies the live window opens LiveClassLead early and closes at the
+// end time, and that the headcount skips checked-out members and counts each member once.
+func TestQueryGetLiveClasses(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 17, 50, 0, 0, time.UTC)
+	checkedIn := monday.Add(-5 * time.Minute)
+	deps := GetLiveClassesDeps{
+		TodaysClasses: GetTodaysClassesDeps{
+			ScheduleStore: &mockTCScheduleStore{schedules: []schedule.Schedule{
+				{ID: "s-ended", ClassTypeID: "ct1", Day: schedule.Monday, StartTime: "16:50", EndTime: "17:50"},
+				{ID: "s-running", ClassTypeID: "ct2", Day: schedule.Monday, StartTime: "17:00", EndTime: "18:00"},
+				{ID: "s-soon", ClassTypeID: "ct3", Day: schedule.Monday, StartTime: "18:05", EndTime: "19:00"},
+				{ID: "s-later", ClassTypeID: "ct4", Day: schedule.Monday, StartTime: "18:30", EndTime: "19:30"},
+			}},
+			TermStore: &mockTCTermStore{terms: []term.Term{
+				{ID: "t1", Name: "Term 1", StartDate: monday.AddDate(0, -1, 0), EndDate: monday.AddDate(0, 1, 0)},
+			}},
+			HolidayStore:   &mockTCHolidayStore{},
+			ClassTypeStore: &mockLCClassTypeStore{},
+			ProgramStore:   &mockTCProgramStore{},
+		},
+		AttendanceStore: &mockASAttendanceStore{records: []attendance.Attendance{
+			{ID: "a1", MemberID: "m1", ScheduleID: "s-running", ClassDate: "2026-03-02", CheckInTime: checkedIn},
+			{ID: "a2", MemberID: "m1", ScheduleID: "s-running", ClassDate: "2026-03-02", CheckInTime: checkedIn},
+			{ID: "a3", MemberID: "m2", ScheduleID: "s-running", ClassDate: "2026-03-02", CheckInTime: checkedIn, CheckOutTime: monday},
+			{ID: "a4", MemberID: "m1", ScheduleID: "s-soon", ClassDate: "2026-03-02", CheckInTime: checkedIn},
+			{ID: "a5", MemberID: "m2", ScheduleID: "s-soon", ClassDate: "2026-03-02", CheckInTime: checkedIn},
+			{ID: "a6", MemberID: "m3", ScheduleID: "s-soon", ClassDate: "2026-03-02", CheckInTime: checkedIn},
+			{ID: "a7", MemberID: "m4", ScheduleID: "s-soon", ClassDate: "2026-02-23", CheckInTime: checkedIn.AddDate(0, 0, -7)},
+		}},
+	}
+
+	live, err := QueryGetLiveClasses(context.Background(), monday, "", deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(live) != 2 || live[0].ScheduleID != "s-running" || live[1].ScheduleID != "s-soon" {
+		t.Fatalf("expected s-running and s-soon live, got %+v", live)
+	}
+	if live[0].Headcount != 1 || live[0].OverCapacity {
+		t.Errorf("s-running: got headcount %d over=%v, want 1 and not over", live[0].Headcount, live[0].OverCapacity)
+	}
+	if live[1].Headcount != 3 || !live[1].OverCapacity {
+		t.Errorf("s-soon: got headcount %d over=%v, want 3 and over", live[1].Headcount, live[1].OverCapacity)
+	}
+
+	none, err := QueryGetLiveClasses(context.Background(), monday.Add(-6*time.Hour), "", deps)
+	if err != nil || none == nil || len(none) != 0 {
+		t.Errorf("expected an empty list outside class times, got %+v, %v", none, err)
+	}
+}
@@ -0,0 +1,96 @@
+package projections
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	domainInjury "workshop/internal/domain/injury"
+	domainMember "workshop/internal/domain/member"
+)
+
+// MemberCardSearchStore defines the member store interface needed to find members by name.
+type MemberCardSearchStore interface {
+	SearchByName(ctx context.Context, query string, limit int) ([]domainMember.Member, error)
+}
+
+// MemberCardInjuryStore defines the injury store interface needed to flag active injuries.
+type MemberCardInjuryStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]domainInjury.Injury, error)
+}
+
+// GetMemberCardsQuery carries query parameters.
+type GetMemberCardsQuery struct {
+	Name            string
+	Limit           int
+	IncludeInjuries bool // only staff may see who is injured
+}
+
+// GetMemberCardsDeps holds dependencies for GetMemberCards.
+type GetMemberCardsDeps struct {
+	MemberStore        MemberCardSearchStore
+	GradingRecordStore GradingRecordStore    // optional: nil leaves belts blank
+	InjuryStore        MemberCardInjuryStore // optional: nil never flags injuries
+}
+
+// MemberCard is a member search result with what a coach needs to recognise them at a
+// glance: belt and stripes, program, initials for the avatar and an injury flag.
+type MemberCard struct {
+	domainMember.Member
+	Belt      string // "" before the first grading record
+	Stripe    int
+	Initials  string
+	HasInjury bool
+}
+
+// QueryGetMemberCards finds members by name and builds a profile card for each.
+// PRE: query.Name is non-empty; query.Limit > 0
+// POST: Returns cards in the store's search order; belts come from the latest effective
+// grading record
+func QueryGetMemberCards(ctx context.Context, query GetMemberCardsQuery, deps GetMemberCardsDeps) ([]MemberCard, error) {
+	members, err := deps.MemberStore.SearchByName(ctx, query.Name, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	cards := make([]MemberCard, 0, len(members))
+	for _, m := range members {
+		card := MemberCard{Member: m, Initials: initials(m.Name)}
+		if deps.GradingRecordStore != nil {
+			records, err := deps.GradingRecordStore.ListByMemberID(ctx, m.ID)
+			if err != nil {
+				return nil, err
+			}
+			card.Belt, card.Stripe = latestBeltAndStripe(records)
+		}
+		if query.IncludeInjuries && deps.InjuryStore != nil {
+			injuries, err := deps.InjuryStore.ListByMemberID(ctx, m.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, inj := range injuries {
+				if inj.IsActive() {
+					card.HasInjury = true
+					break
+				}
+			}
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// initials returns the first letter of the first and last words of a name, upper-cased.
+func initials(name string) string {
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		return ""
+	}
+	first, _ := utf8.DecodeRuneInString(words[0])
+	out := string(unicode.ToUpper(first))
+	if len(words) > 1 {
+		last, _ := utf8.DecodeRuneInString(words[len(words)-1])
+		out += string(unicode.ToUpper(last))
+	}
+	return out
+}
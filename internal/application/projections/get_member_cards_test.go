@@ -0,0 +1,78 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainGrading "workshop/internal/domain/grading"
+	domainInjury "workshop/internal/domain/injury"
+	domainMember "workshop/internal/domain/member"
+)
+
+type mockMemberCardSearchStore struct {
+	members []domainMember.Member
+}
+
+// SearchByName returns every seeded member.
+// PRE: query is non-empty
+// POST: Returns at most limit members
+func (m *mockMemberCardSearchStore) SearchByName(_ context.Context, _ string, limit int) ([]domainMember.Member, error) {
+	if len(m.members) > limit {
+		return m.members[:limit], nil
+	}
+	return m.members, nil
+}
+
+type mockMemberCardInjuryStore struct {
+	injuries map[string][]domainInjury.Injury
+}
+
+// ListByMemberID returns the member's seeded injuries.
+// PRE: memberID is non-empty
+// POST: Returns any seeded injuries
+func (m *mockMemberCardInjuryStore) ListByMemberID(_ context.Context, memberID string) ([]domainInjury.Injury, error) {
+	return m.injuries[memberID], nil
+}
+
+// TestQueryGetMemberCards verifies cards carry the latest belt and stripe, initials and an
+// active-injury flag that only staff queries see.
+func TestQueryGetMemberCards(t *testing.T) {
+	now := time.Now()
+	deps := GetMemberCardsDeps{
+		MemberStore: &mockMemberCardSearchStore{members: []domainMember.Member{
+			{ID: "m1", Name: "Aroha te Whare", Program: domainMember.ProgramAdults},
+			{ID: "m2", Name: "sam", Program: domainMember.ProgramKids},
+		}},
+		GradingRecordStore: &mockGetMemberListGradingRecordStore{records: map[string][]domainGrading.Record{
+			"m1": {
+				{ID: "r1", MemberID: "m1", Belt: domainGrading.BeltWhite, Stripe: 4, PromotedAt: now.AddDate(-2, 0, 0)},
+				{ID: "r2", MemberID: "m1", Belt: domainGrading.BeltBlue, Stripe: 1, PromotedAt: now.AddDate(0, -3, 0)},
+			},
+		}},
+		InjuryStore: &mockMemberCardInjuryStore{injuries: map[string][]domainInjury.Injury{
+			"m1": {{ID: "i1", MemberID: "m1", Status: domainInjury.StatusResolved, ReportedAt: now}},
+			"m2": {{ID: "i2", MemberID: "m2", Status: domainInjury.StatusActive, ReportedAt: now}},
+		}},
+	}
+	ctx := context.Background()
+
+	cards, err := QueryGetMemberCards(ctx, GetMemberCardsQuery{Name: "a", Limit: 10, IncludeInjuries: true}, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) != 2 {
+		t.Fatalf("cards = %d, want 2", len(cards))
+	}
+	if c := cards[0]; c.Belt != domainGrading.BeltBlue || c.Stripe != 1 || c.Initials != "AW" || c.HasInjury || c.Name != "Aroha te Whare" {
+		t.Errorf("card 1 = %+v, want blue belt 1 stripe, AW, no active injury", c)
+	}
+	if c := cards[1]; c.Belt != "" || c.Initials != "S" || !c.HasInjury {
+		t.Errorf("card 2 = %+v, want no belt, S, injured", c)
+	}
+
+	cards, _ = QueryGetMemberCards(ctx, GetMemberCardsQuery{Name: "a", Limit: 1}, deps)
+	if len(cards) != 1 || cards[0].HasInjury {
+		t.Errorf("non-staff cards = %+v, want one card without injury flags", cards)
+	}
+}
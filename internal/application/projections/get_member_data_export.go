@@ -0,0 +1,305 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	bugboxStore "workshop/internal/adapters/storage/bugbox"
+	"workshop/internal/domain/account"
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/bugbox"
+	"workshop/internal/domain/celebration"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/consent"
+	"workshop/internal/domain/export"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/injury"
+	domainMember "workshop/internal/domain/member"
+	"workshop/internal/domain/message"
+	"workshop/internal/domain/milestone"
+	"workshop/internal/domain/personalgoal"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/traininggoal"
+	"workshop/internal/domain/waiver"
+)
+
+// MemberDataExportVersion is bumped when the export's JSON layout changes.
+const MemberDataExportVersion = "1"
+
+// MemberExportMemberStore defines the member store interface needed by the data export.
+type MemberExportMemberStore interface {
+	GetByID(ctx context.Context, id string) (domainMember.Member, error)
+}
+
+// MemberExportAccountStore defines the account store interface needed by the data export.
+type MemberExportAccountStore interface {
+	GetByID(ctx context.Context, id string) (account.Account, error)
+}
+
+// MemberExportAttendanceStore defines the attendance store interface needed by the data export.
+type MemberExportAttendanceStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]attendance.Attendance, error)
+}
+
+// MemberExportScheduleStore defines the schedule store interface needed to name classes.
+type MemberExportScheduleStore interface {
+	GetByID(ctx context.Context, id string) (schedule.Schedule, error)
+}
+
+// MemberExportClassTypeStore defines the class type store interface needed to name classes.
+type MemberExportClassTypeStore interface {
+	GetByID(ctx context.Context, id string) (classtype.ClassType, error)
+}
+
+// MemberExportInjuryStore defines the injury store interface needed by the data export.
+type MemberExportInjuryStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]injury.Injury, error)
+}
+
+// MemberExportWaiverStore defines the waiver store interface needed by the data export.
+type MemberExportWaiverStore interface {
+	GetByMemberID(ctx context.Context, memberID string) (waiver.Waiver, error)
+}
+
+// MemberExportConsentStore defines the consent store interface needed by the data export.
+type MemberExportConsentStore interface {
+	GetByMemberID(ctx context.Context, memberID string) ([]consent.Consent, error)
+}
+
+// MemberExportGradingStore defines the grading record store interface needed by the data export.
+type MemberExportGradingStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]grading.Record, error)
+}
+
+// MemberExportMessageStore defines the message store interface needed by the data export.
+type MemberExportMessageStore interface {
+	ListByReceiverID(ctx context.Context, receiverID string) ([]message.Message, error)
+}
+
+// MemberExportMilestoneStore defines the milestone store interface needed to name earned milestones.
+type MemberExportMilestoneStore interface {
+	List(ctx context.Context) ([]milestone.Milestone, error)
+}
+
+// MemberExportEarnedMilestoneStore defines the member milestone store interface needed by the data export.
+type MemberExportEarnedMilestoneStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]milestone.MemberMilestone, error)
+}
+
+// MemberExportTrainingGoalStore defines the training goal store interface needed by the data export.
+type MemberExportTrainingGoalStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]traininggoal.TrainingGoal, error)
+}
+
+// MemberExportPersonalGoalStore defines the personal goal store interface needed by the data export.
+type MemberExportPersonalGoalStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]personalgoal.PersonalGoal, error)
+}
+
+// MemberExportBugReportStore defines the bug box store interface needed by the data export.
+type MemberExportBugReportStore interface {
+	List(ctx context.Context, filter bugboxStore.ListFilter) ([]bugbox.Submission, error)
+}
+
+// MemberExportCelebrationStore defines the celebration store interface needed by the data export.
+type MemberExportCelebrationStore interface {
+	GetProfile(ctx context.Context, memberID string) (celebration.Profile, error)
+}
+
+// GetMemberDataExportDeps holds dependencies for the member data export.
+type GetMemberDataExportDeps struct {
+	MemberStore          MemberExportMemberStore
+	AccountStore         MemberExportAccountStore // optional: nil leaves out the account
+	AttendanceStore      MemberExportAttendanceStore
+	ScheduleStore        MemberExportScheduleStore  // optional: nil leaves class names blank
+	ClassTypeStore       MemberExportClassTypeStore // optional: nil leaves class names blank
+	InjuryStore          MemberExportInjuryStore
+	WaiverStore          MemberExportWaiverStore
+	ConsentStore         MemberExportConsentStore
+	GradingStore         MemberExportGradingStore
+	MessageStore         MemberExportMessageStore
+	MilestoneStore       MemberExportMilestoneStore
+	EarnedMilestoneStore MemberExportEarnedMilestoneStore
+	TrainingGoalStore    MemberExportTrainingGoalStore
+	PersonalGoalStore    MemberExportPersonalGoalStore
+	BugReportStore       MemberExportBugReportStore   // optional: nil leaves out bug reports
+	CelebrationStore     MemberExportCelebrationStore // optional: nil leaves out the date of birth and join date
+}
+
+// GetMemberDataExportQuery selects the member to export.
+type GetMemberDataExportQuery struct {
+	MemberID string
+}
+
+// QueryGetMemberDataExport assembles everything held about a member for a portability export:
+// profile (with any date of birth and join date), account, attendance, injuries, waiver,
+// consents, grading history, messages, milestones, goals and bug reports. Coach observations
+// and grading notes are staff-only (§8.3) and are left out.
+// PRE: MemberID exists
+// POST: Returns the member's data with ExportMetadata filled in
+func QueryGetMemberDataExport(ctx context.Context, query GetMemberDataExportQuery, now time.Time, deps GetMemberDataExportDeps) (export.Data, error) {
+	m, err := deps.MemberStore.GetByID(ctx, query.MemberID)
+	if err != nil {
+		return export.Data{}, err
+	}
+	data := export.Data{Member: export.MemberData{
+		ID: m.ID, Name: m.Name, Email: m.Email, Program: m.Program, Fee: m.Fee,
+		Frequency: m.Frequency, Status: m.Status, GradingMetric: m.GradingMetric,
+	}}
+	if deps.CelebrationStore != nil {
+		if p, err := deps.CelebrationStore.GetProfile(ctx, m.ID); err == nil {
+			if !p.DateOfBirth.IsZero() {
+				data.Member.DateOfBirth = p.DateOfBirth.Format("2006-01-02")
+			}
+			if !p.JoinedOn.IsZero() {
+				data.Member.JoinedOn = p.JoinedOn.Format("2006-01-02")
+			}
+		}
+	}
+
+	if deps.AccountStore != nil && m.AccountID != "" {
+		if a, err := deps.AccountStore.GetByID(ctx, m.AccountID); err == nil {
+			data.Account = export.AccountData{ID: a.ID, Email: a.Email, Role: a.Role, CreatedAt: a.CreatedAt, Status: a.Status}
+		}
+	}
+
+	attendances, err := deps.AttendanceStore.ListByMemberID(ctx, m.ID)
+	if err != nil {
+		return export.Data{}, err
+	}
+	classNames := map[string]string{}
+	for _, a := range attendances {
+		rec := export.AttendanceRecord{ID: a.ID, ClassDate: a.ClassDate, CheckInTime: a.CheckInTime, MatHours: a.MatHours, ClassType: exportClassName(ctx, a.ScheduleID, classNames, deps)}
+		if !a.CheckOutTime.IsZero() {
+			out := a.CheckOutTime
+			rec.CheckOutTime = &out
+		}
+		data.Attendance = append(data.Attendance, rec)
+	}
+	sort.Slice(data.Attendance, func(i, j int) bool { return data.Attendance[i].CheckInTime.Before(data.Attendance[j].CheckInTime) })
+
+	injuries, err := deps.InjuryStore.ListByMemberID(ctx, m.ID)
+	if err != nil {
+		return export.Data{}, err
+	}
+	for _, inj := range injuries {
+		data.Injuries = append(data.Injuries, export.InjuryRecord{ID: inj.ID, BodyPart: inj.BodyPart, Description: inj.Description, ReportedAt: inj.ReportedAt, Status: inj.Status})
+	}
+
+	if w, err := deps.WaiverStore.GetByMemberID(ctx, m.ID); err == nil {
+		data.Waivers = append(data.Waivers, export.WaiverRecord{
+			ID: w.ID, AcceptedTerms: w.AcceptedTerms, SignedAt: w.SignedAt, IPaddress: w.IPAddress, SignerName: w.SignerName,
+			GuardianName: w.Guardian.Name, GuardianRelationship: w.Guardian.Relationship,
+			GuardianEmail: w.Guardian.Email, GuardianPhone: w.Guardian.Phone, DocumentHash: w.DocumentHash,
+		})
+	}
+
+	consents, err := deps.ConsentStore.GetByMemberID(ctx, m.ID)
+	if err != nil {
+		return export.Data{}, err
+	}
+	for _, c := range consents {
+		data.Consents = append(data.Consents, export.ConsentRecord{Type: string(c.Type), Granted: c.Granted, GrantedAt: c.GrantedAt, RevokedAt: c.RevokedAt, Version: c.Version})
+	}
+
+	records, err := deps.GradingStore.ListByMemberID(ctx, m.ID)
+	if err != nil {
+		return export.Data{}, err
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].PromotedAt.Before(records[j].PromotedAt) })
+	for _, r := range records {
+		data.GradingHistory = append(data.GradingHistory, export.GradingRecord{ID: r.ID, Belt: r.Belt, Stripe: r.Stripe, PromotedAt: r.PromotedAt, Method: r.Method})
+	}
+	if n := len(records); n > 0 {
+		data.Member.Belt, data.Member.Stripe = records[n-1].Belt, records[n-1].Stripe
+	}
+
+	messages, err := deps.MessageStore.ListByReceiverID(ctx, m.ID)
+	if err != nil {
+		return export.Data{}, err
+	}
+	for _, msg := range messages {
+		rec := export.MessageRecord{ID: msg.ID, Subject: msg.Subject, Content: msg.Content, CreatedAt: msg.CreatedAt}
+		if !msg.ReadAt.IsZero() {
+			read := msg.ReadAt
+			rec.ReadAt = &read
+		}
+		data.Messages = append(data.Messages, rec)
+	}
+
+	earned, err := deps.EarnedMilestoneStore.ListByMemberID(ctx, m.ID)
+	if err != nil {
+		return export.Data{}, err
+	}
+	if len(earned) > 0 {
+		all, err := deps.MilestoneStore.List(ctx)
+		if err != nil {
+			return export.Data{}, err
+		}
+		names := make(map[string]string, len(all))
+		for _, ms := range all {
+			names[ms.ID] = ms.Name
+		}
+		for _, e := range earned {
+			data.Milestones = append(data.Milestones, export.MilestoneRecord{ID: e.ID, Name: names[e.MilestoneID], EarnedAt: e.EarnedAt})
+		}
+	}
+
+	trainingGoals, err := deps.TrainingGoalStore.ListByMemberID(ctx, m.ID)
+	if err != nil {
+		return export.Data{}, err
+	}
+	for _, g := range trainingGoals {
+		data.TrainingGoals = append(data.TrainingGoals, export.TrainingGoalRecord{ID: g.ID, Target: g.Target, Period: g.Period, CreatedAt: g.CreatedAt, Active: g.Active})
+	}
+
+	personalGoals, err := deps.PersonalGoalStore.ListByMemberID(ctx, m.ID)
+	if err != nil {
+		return export.Data{}, err
+	}
+	for _, g := range personalGoals {
+		data.PersonalGoals = append(data.PersonalGoals, export.PersonalGoalRecord{
+			ID: g.ID, Title: g.Title, Description: g.Description, Target: g.Target, Unit: g.Unit,
+			StartDate: g.StartDate.Format("2006-01-02"), EndDate: g.EndDate.Format("2006-01-02"), Progress: g.Progress, CreatedAt: g.CreatedAt,
+		})
+	}
+
+	if deps.BugReportStore != nil && m.AccountID != "" {
+		reports, err := deps.BugReportStore.List(ctx, bugboxStore.ListFilter{ReporterID: m.AccountID})
+		if err != nil {
+			return export.Data{}, err
+		}
+		for _, b := range reports {
+			data.BugReports = append(data.BugReports, export.BugReportRecord{ID: b.ID, Summary: b.Summary, Route: b.Route, SubmittedAt: b.SubmittedAt})
+		}
+	}
+
+	data.ExportMetadata = export.Metadata{
+		ExportDate: now,
+		Format:     export.FormatJSON,
+		Version:    MemberDataExportVersion,
+		RecordCount: 1 + len(data.Attendance) + len(data.Injuries) + len(data.Waivers) + len(data.Consents) +
+			len(data.GradingHistory) + len(data.Messages) + len(data.Milestones) + len(data.TrainingGoals) +
+			len(data.PersonalGoals) + len(data.BugReports),
+	}
+	return data, nil
+}
+
+// exportClassName returns the class type name for a schedule, caching lookups by schedule ID.
+func exportClassName(ctx context.Context, scheduleID string, cache map[string]string, deps GetMemberDataExportDeps) string {
+	if deps.ScheduleStore == nil || deps.ClassTypeStore == nil || scheduleID == "" {
+		return ""
+	}
+	if name, ok := cache[scheduleID]; ok {
+		return name
+	}
+	name := ""
+	if s, err := deps.ScheduleStore.GetByID(ctx, scheduleID); err == nil {
+		if ct, err := deps.ClassTypeStore.GetByID(ctx, s.ClassTypeID); err == nil {
+			name = ct.Name
+		}
+	}
+	cache[scheduleID] = name
+	return name
+}
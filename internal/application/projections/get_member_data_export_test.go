@@ -0,0 +1,203 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainAttendance "workshop/internal/domain/attendance"
+	domainCelebration "workshop/internal/domain/celebration"
+	domainConsent "workshop/internal/domain/consent"
+	domainGrading "workshop/internal/domain/grading"
+	domainInjury "workshop/internal/domain/injury"
+	domainMember "workshop/internal/domain/member"
+	domainMessage "workshop/internal/domain/message"
+	domainMilestone "workshop/internal/domain/milestone"
+	domainPersonalGoal "workshop/internal/domain/personalgoal"
+	domainTrainingGoal "workshop/internal/domain/traininggoal"
+	domainWaiver "workshop/internal/domain/waiver"
+)
+
+// --- Mock stores for member data export tests ---
+
+type mockMDEMemberStore struct{}
+
+// GetByID returns Marcus for m1.
+// PRE: id is non-empty
+// POST: Returns the member or an error
+func (m *mockMDEMemberStore) GetByID(_ context.Context, id string) (domainMember.Member, error) {
+	if id != "m1" {
+		return domainMember.Member{}, errors.New("not found")
+	}
+	return domainMember.Member{ID: "m1", Name: "Marcus Almeida", Email: "marcus@example.com", Program: "adults", Status: "active"}, nil
+}
+
+type mockMDEAttendanceStore struct{}
+
+// ListByMemberID returns two check-ins, newest first.
+// PRE: memberID is non-empty
+// POST: Returns the check-ins
+func (m *mockMDEAttendanceStore) ListByMemberID(_ context.Context, _ string) ([]domainAttendance.Attendance, error) {
+	day := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	return []domainAttendance.Attendance{
+		{ID: "a2", MemberID: "m1", CheckInTime: day.AddDate(0, 0, 2), MatHours: 1},
+		{ID: "a1", MemberID: "m1", CheckInTime: day, CheckOutTime: day.Add(time.Hour), MatHours: 1},
+	}, nil
+}
+
+type mockMDEInjuryStore struct{}
+
+// ListByMemberID returns one injury.
+// PRE: memberID is non-empty
+// POST: Returns the injuries
+func (m *mockMDEInjuryStore) ListByMemberID(_ context.Context, _ string) ([]domainInjury.Injury, error) {
+	return []domainInjury.Injury{{ID: "i1", MemberID: "m1", BodyPart: "knee", Status: "active"}}, nil
+}
+
+type mockMDEWaiverStore struct{}
+
+// GetByMemberID reports no waiver on file.
+// PRE: memberID is non-empty
+// POST: Returns an error
+func (m *mockMDEWaiverStore) GetByMemberID(_ context.Context, _ string) (domainWaiver.Waiver, error) {
+	return domainWaiver.Waiver{}, errors.New("not found")
+}
+
+type mockMDEConsentStore struct{}
+
+// GetByMemberID returns one consent.
+// PRE: memberID is non-empty
+// POST: Returns the consents
+func (m *mockMDEConsentStore) GetByMemberID(_ context.Context, _ string) ([]domainConsent.Consent, error) {
+	return []domainConsent.Consent{{ID: "c1", MemberID: "m1", Type: domainConsent.TypeDataProcessing, Granted: true, Version: "1"}}, nil
+}
+
+type mockMDEGradingStore struct{}
+
+// ListByMemberID returns two promotions, newest first.
+// PRE: memberID is non-empty
+// POST: Returns the grading records
+func (m *mockMDEGradingStore) ListByMemberID(_ context.Context, _ string) ([]domainGrading.Record, error) {
+	return []domainGrading.Record{
+		{ID: "g2", MemberID: "m1", Belt: "blue", Stripe: 1, PromotedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), ProposedBy: "coach-1"},
+		{ID: "g1", MemberID: "m1", Belt: "blue", PromotedAt: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil
+}
+
+type mockMDEMessageStore struct{}
+
+// ListByReceiverID returns one message.
+// PRE: receiverID is non-empty
+// POST: Returns the messages
+func (m *mockMDEMessageStore) ListByReceiverID(_ context.Context, _ string) ([]domainMessage.Message, error) {
+	return []domainMessage.Message{{ID: "msg1", ReceiverID: "m1", Subject: "Welcome", Content: "Hi"}}, nil
+}
+
+type mockMDEMilestoneStore struct{}
+
+// List returns the milestone definitions.
+// PRE: none
+// POST: Returns the milestones
+func (m *mockMDEMilestoneStore) List(_ context.Context) ([]domainMilestone.Milestone, error) {
+	return []domainMilestone.Milestone{{ID: "ms1", Name: "100 Classes"}}, nil
+}
+
+type mockMDEEarnedMilestoneStore struct{}
+
+// ListByMemberID returns one earned milestone.
+// PRE: memberID is non-empty
+// POST: Returns the earned milestones
+func (m *mockMDEEarnedMilestoneStore) ListByMemberID(_ context.Context, _ string) ([]domainMilestone.MemberMilestone, error) {
+	return []domainMilestone.MemberMilestone{{ID: "mm1", MemberID: "m1", MilestoneID: "ms1"}}, nil
+}
+
+type mockMDETrainingGoalStore struct{}
+
+// ListByMemberID returns no training goals.
+// PRE: memberID is non-empty
+// POST: Returns an empty list
+func (m *mockMDETrainingGoalStore) ListByMemberID(_ context.Context, _ string) ([]domainTrainingGoal.TrainingGoal, error) {
+	return nil, nil
+}
+
+type mockMDEPersonalGoalStore struct{}
+
+// ListByMemberID returns one personal goal.
+// PRE: memberID is non-empty
+// POST: Returns the personal goals
+func (m *mockMDEPersonalGoalStore) ListByMemberID(_ context.Context, _ string) ([]domainPersonalGoal.PersonalGoal, error) {
+	return []domainPersonalGoal.PersonalGoal{{ID: "pg1", MemberID: "m1", Title: "50 armbars", Target: 50,
+		StartDate: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)}}, nil
+}
+
+type mockMDECelebrationStore struct{}
+
+// GetProfile returns a date of birth with no join date.
+// PRE: memberID is non-empty
+// POST: Returns the profile
+func (m *mockMDECelebrationStore) GetProfile(_ context.Context, memberID string) (domainCelebration.Profile, error) {
+	return domainCelebration.Profile{MemberID: memberID, DateOfBirth: time.Date(1990, 5, 4, 0, 0, 0, 0, time.UTC)}, nil
+}
+
+// mdeDeps returns the export dependencies with every optional store left nil.
+func mdeDeps() GetMemberDataExportDeps {
+	return GetMemberDataExportDeps{
+		MemberStore:          &mockMDEMemberStore{},
+		AttendanceStore:      &mockMDEAttendanceStore{},
+		InjuryStore:          &mockMDEInjuryStore{},
+		WaiverStore:          &mockMDEWaiverStore{},
+		ConsentStore:         &mockMDEConsentStore{},
+		GradingStore:         &mockMDEGradingStore{},
+		MessageStore:         &mockMDEMessageStore{},
+		MilestoneStore:       &mockMDEMilestoneStore{},
+		EarnedMilestoneStore: &mockMDEEarnedMilestoneStore{},
+		TrainingGoalStore:    &mockMDETrainingGoalStore{},
+		PersonalGoalStore:    &mockMDEPersonalGoalStore{},
+	}
+}
+
+// TestQueryGetMemberDataExport verifies the export gathers every section in date order,
+// takes the current belt from the latest promotion and counts the records.
+func TestQueryGetMemberDataExport(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	data, err := QueryGetMemberDataExport(context.Background(), GetMemberDataExportQuery{MemberID: "m1"}, now, mdeDeps())
+	if err != nil {
+		t.Fatalf("QueryGetMemberDataExport: %v", err)
+	}
+	if data.Member.Name != "Marcus Almeida" || data.Member.Belt != "blue" || data.Member.Stripe != 1 {
+		t.Errorf("unexpected member section: %+v", data.Member)
+	}
+	if len(data.Attendance) != 2 || data.Attendance[0].ID != "a1" || data.Attendance[0].CheckOutTime == nil || data.Attendance[1].CheckOutTime != nil {
+		t.Errorf("expected attendance oldest first with check-out only on a1, got %+v", data.Attendance)
+	}
+	if len(data.GradingHistory) != 2 || data.GradingHistory[0].ID != "g1" {
+		t.Errorf("expected grading history oldest first, got %+v", data.GradingHistory)
+	}
+	if len(data.Milestones) != 1 || data.Milestones[0].Name != "100 Classes" {
+		t.Errorf("expected the earned milestone by name, got %+v", data.Milestones)
+	}
+	if len(data.PersonalGoals) != 1 || data.PersonalGoals[0].StartDate != "2026-04-01" {
+		t.Errorf("unexpected personal goals: %+v", data.PersonalGoals)
+	}
+	if len(data.Waivers) != 0 || len(data.Observations) != 0 {
+		t.Errorf("expected no waiver and no observations, got %d and %d", len(data.Waivers), len(data.Observations))
+	}
+	// member + 2 attendance + 1 injury + 1 consent + 2 gradings + 1 message + 1 milestone + 1 personal goal
+	if data.ExportMetadata.RecordCount != 10 || !data.ExportMetadata.ExportDate.Equal(now) {
+		t.Errorf("unexpected metadata: %+v", data.ExportMetadata)
+	}
+
+	if data.Member.DateOfBirth != "" {
+		t.Errorf("date of birth without a celebration store = %q, want none", data.Member.DateOfBirth)
+	}
+	deps := mdeDeps()
+	deps.CelebrationStore = &mockMDECelebrationStore{}
+	if data, _ := QueryGetMemberDataExport(context.Background(), GetMemberDataExportQuery{MemberID: "m1"}, now, deps); data.Member.DateOfBirth != "1990-05-04" || data.Member.JoinedOn != "" {
+		t.Errorf("celebration dates = %q/%q, want the date of birth only", data.Member.DateOfBirth, data.Member.JoinedOn)
+	}
+
+	if _, err := QueryGetMemberDataExport(context.Background(), GetMemberDataExportQuery{MemberID: "m9"}, now, mdeDeps()); err == nil {
+		t.Error("expected an error for an unknown member")
+	}
+}
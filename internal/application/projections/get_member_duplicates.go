@@ -0,0 +1,104 @@
+package projections
+
+import (
+	"context"
+	"sort"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/member"
+)
+
+// DuplicateMemberStore defines the member store interface needed by duplicate detection.
+type DuplicateMemberStore interface {
+	List(ctx context.Context, filter memberStore.ListFilter) ([]member.Member, error)
+}
+
+// GetMemberDuplicatesDeps holds dependencies for duplicate detection.
+type GetMemberDuplicatesDeps struct {
+	MemberStore DuplicateMemberStore
+}
+
+// DuplicateMember describes one side of a likely duplicate pair.
+type DuplicateMember struct {
+	ID         string
+	Name       string
+	Email      string
+	Program    string
+	Status     string
+	HasAccount bool
+}
+
+// DuplicateMemberPair is two member records that look like the same person. Survivor is the
+// suggested record to keep: the one with a login account, then the active one.
+type DuplicateMemberPair struct {
+	Survivor  DuplicateMember
+	Duplicate DuplicateMember
+	Reason    string // member.MatchEmail or member.MatchName
+}
+
+// QueryGetMemberDuplicates finds pairs of non-archived members that share an email address
+// (ignoring case and "+tag" suffixes) or have the same or nearly the same name.
+// PRE: Caller is an admin (checked by the handler)
+// POST: Returns each likely pair once, email matches first, then by survivor name; never nil
+func QueryGetMemberDuplicates(ctx context.Context, deps GetMemberDuplicatesDeps) ([]DuplicateMemberPair, error) {
+	members, err := deps.MemberStore.List(ctx, memberStore.ListFilter{Limit: 10000, Sort: "name"})
+	if err != nil {
+		return nil, err
+	}
+	var candidates []member.Member
+	for _, m := range members {
+		if !m.IsArchived() {
+			candidates = append(candidates, m)
+		}
+	}
+
+	pairs := []DuplicateMemberPair{}
+	for i, a := range candidates {
+		for _, b := range candidates[i+1:] {
+			reason := member.DuplicateReason(a, b)
+			if reason == "" {
+				continue
+			}
+			survivor, duplicate := a, b
+			if survivorRank(b) > survivorRank(a) {
+				survivor, duplicate = b, a
+			}
+			pairs = append(pairs, DuplicateMemberPair{
+				Survivor:  toDuplicateMember(survivor),
+				Duplicate: toDuplicateMember(duplicate),
+				Reason:    reason,
+			})
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		if pairs[i].Reason != pairs[j].Reason {
+			return pairs[i].Reason == member.MatchEmail
+		}
+		return pairs[i].Survivor.Name < pairs[j].Survivor.Name
+	})
+	return pairs, nil
+}
+
+// survivorRank scores how good a record is to keep: a login account counts most, then
+// being active.
+func survivorRank(m member.Member) int {
+	rank := 0
+	if m.AccountID != "" {
+		rank += 2
+	}
+	if m.IsActive() {
+		rank++
+	}
+	return rank
+}
+
+func toDuplicateMember(m member.Member) DuplicateMember {
+	return DuplicateMember{
+		ID:         m.ID,
+		Name:       m.Name,
+		Email:      m.Email,
+		Program:    m.Program,
+		Status:     m.Status,
+		HasAccount: m.AccountID != "",
+	}
+}
@@ -0,0 +1,40 @@
+package projections
+
+import (
+	"context"
+	"testing"
+
+	"workshop/internal/domain/member"
+)
+
+// TestQueryGetMemberDuplicates verifies email and name matches are paired once, archived
+// members are skipped, and the record with a login account is suggested as the survivor.
+func TestQueryGetMemberDuplicates(t *testing.T) {
+	deps := GetMemberDuplicatesDeps{MemberStore: &mockGetMemberListMemberStore{members: []member.Member{
+		{ID: "guest-1", Name: "Jon Smith", Email: "jon.smith+guest@example.com", Status: member.StatusInactive},
+		{ID: "m-1", AccountID: "acct-1", Name: "John Smith", Email: "Jon.Smith@example.com", Status: member.StatusActive},
+		{ID: "m-2", Name: "Aroha Ngata", Email: "aroha@example.com", Status: member.StatusActive},
+		{ID: "guest-2", Name: "Ngata, Aroha", Email: "aroha.n@guest.local", Status: member.StatusActive},
+		{ID: "m-3", Name: "Mere Ngata", Email: "mere@example.com", Status: member.StatusActive},
+		{ID: "old", Name: "John Smith", Email: "old@example.com", Status: member.StatusArchived},
+	}}}
+
+	pairs, err := QueryGetMemberDuplicates(context.Background(), deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pairs) != 2 {
+		t.Fatalf("expected 2 pairs, got %+v", pairs)
+	}
+	if pairs[0].Reason != member.MatchEmail || pairs[0].Survivor.ID != "m-1" || pairs[0].Duplicate.ID != "guest-1" || !pairs[0].Survivor.HasAccount {
+		t.Errorf("email pair = %+v, want m-1 surviving guest-1", pairs[0])
+	}
+	if pairs[1].Reason != member.MatchName || pairs[1].Survivor.ID != "m-2" || pairs[1].Duplicate.ID != "guest-2" {
+		t.Errorf("name pair = %+v, want m-2 surviving guest-2", pairs[1])
+	}
+
+	empty, err := QueryGetMemberDuplicates(context.Background(), GetMemberDuplicatesDeps{MemberStore: &mockGetMemberListMemberStore{}})
+	if err != nil || empty == nil || len(empty) != 0 {
+		t.Errorf("expected an empty list, got %+v, %v", empty, err)
+	}
+}
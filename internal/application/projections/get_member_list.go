@@ -2,7 +2,6 @@ package projections
 
 import (
 	"context"
-	"time"
 
 	"workshop/internal/adapters/storage/injury"
 	"workshop/internal/adapters/storage/member"
@@ -16,6 +15,8 @@ type GetMemberListQuery struct {
 	Program string
 	Search  string
 	Status  string
+	Tag     string   // optional: members carrying this tag
+	IDs     []string // optional: a resolved segment; nil does not filter, empty matches none
 	Sort    string
 	Dir     string
 	Page    int
@@ -71,6 +72,8 @@ func QueryGetMemberList(ctx context.Context, query GetMemberListQuery, deps GetM
 		Program: query.Program,
 		Status:  query.Status,
 		Search:  query.Search,
+		Tag:     query.Tag,
+		IDs:     query.IDs,
 		Sort:    query.Sort,
 		Dir:     query.Dir,
 	}
@@ -98,8 +101,7 @@ func QueryGetMemberList(ctx context.Context, query GetMemberListQuery, deps GetM
 		return GetMemberListResult{}, err
 	}
 
-	// Get all active injuries (last 7 days)
-	sevenDaysAgo := time.Now().Add(-7 * 24 * time.Hour)
+	// Get all active (unresolved) injuries
 	injuries, err := deps.InjuryStore.List(ctx, injury.ListFilter{
 		Limit:  1000,
 		Offset: 0,
@@ -111,8 +113,8 @@ func QueryGetMemberList(ctx context.Context, query GetMemberListQuery, deps GetM
 	// Build injury map for quick lookup
 	injuryMap := make(map[string]domainInjury.Injury)
 	for _, inj := range injuries {
-		// Only include active injuries (within 7 days)
-		if inj.ReportedAt.After(sevenDaysAgo) {
+		// Only include active injuries
+		if inj.IsActive() {
 			injuryMap[inj.MemberID] = inj
 		}
 	}
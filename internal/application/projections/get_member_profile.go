@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"workshop/internal/adapters/storage/attendance"
+	gradingStore "workshop/internal/adapters/storage/grading"
 	"workshop/internal/adapters/storage/injury"
 	"workshop/internal/adapters/storage/waiver"
 	domainGrading "workshop/internal/domain/grading"
@@ -31,26 +32,18 @@ type GetMemberProfileResult struct {
 	RecentAttendance int      // Count of check-ins in last 30 days
 }
 
+// MemberProfileGradingRecordStore defines the grading record store interface needed by the profile.
+type MemberProfileGradingRecordStore interface {
+	List(ctx context.Context, filter gradingStore.RecordFilter) ([]domainGrading.Record, error)
+}
+
 // GetMemberProfileDeps holds dependencies for GetMemberProfile.
 type GetMemberProfileDeps struct {
 	MemberStore        MemberStore
 	WaiverStore        WaiverStore
 	InjuryStore        InjuryStore
 	AttendanceStore    AttendanceStore
-	GradingRecordStore GradingRecordStore // optional: nil skips belt lookup
-}
-
-func latestMemberBeltAndStripe(records []domainGrading.Record) (belt string, stripe int) {
-	if len(records) == 0 {
-		return "", 0
-	}
-	latest := records[0]
-	for _, r := range records[1:] {
-		if r.PromotedAt.After(latest.PromotedAt) {
-			latest = r
-		}
-	}
-	return latest.Belt, latest.Stripe
+	GradingRecordStore MemberProfileGradingRecordStore // optional: nil skips belt lookup
 }
 
 // QueryGetMemberProfile retrieves complete member profile.
@@ -73,8 +66,8 @@ func QueryGetMemberProfile(ctx context.Context, query GetMemberProfileQuery, dep
 
 	// Latest belt (optional)
 	if deps.GradingRecordStore != nil {
-		if records, err := deps.GradingRecordStore.ListByMemberID(ctx, query.MemberID); err == nil {
-			result.Belt, result.Stripe = latestMemberBeltAndStripe(records)
+		if records, err := deps.GradingRecordStore.List(ctx, gradingStore.RecordFilter{MemberID: query.MemberID, Limit: 1}); err == nil && len(records) > 0 {
+			result.Belt, result.Stripe = records[0].Belt, records[0].Stripe
 		}
 	}
 
@@ -102,14 +95,13 @@ func QueryGetMemberProfile(ctx context.Context, query GetMemberProfileQuery, dep
 	}
 
 	// Get active injuries
-	sevenDaysAgo := time.Now().Add(-7 * 24 * time.Hour)
 	injuries, err := deps.InjuryStore.List(ctx, injury.ListFilter{
 		Limit:  100,
 		Offset: 0,
 	})
 	if err == nil {
 		for _, inj := range injuries {
-			if inj.MemberID == query.MemberID && inj.ReportedAt.After(sevenDaysAgo) {
+			if inj.MemberID == query.MemberID && inj.IsActive() {
 				result.ActiveInjuries = append(result.ActiveInjuries, inj.BodyPart)
 			}
 		}
@@ -118,12 +110,12 @@ func QueryGetMemberProfile(ctx context.Context, query GetMemberProfileQuery, dep
 	// Get recent attendance (last 30 days)
 	thirtyDaysAgo := time.Now().Add(-30 * 24 * time.Hour)
 	attendances, err := deps.AttendanceStore.List(ctx, attendance.ListFilter{
-		Limit:  100,
-		Offset: 0,
+		MemberID: query.MemberID,
+		From:     thirtyDaysAgo.Format("2006-01-02"),
 	})
 	if err == nil {
 		for _, a := range attendances {
-			if a.MemberID == query.MemberID && a.CheckInTime.After(thirtyDaysAgo) {
+			if a.CheckInTime.After(thirtyDaysAgo) {
 				result.RecentAttendance++
 			}
 		}
@@ -2,10 +2,12 @@ package projections
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
 	"workshop/internal/adapters/storage/attendance"
+	gradingStore "workshop/internal/adapters/storage/grading"
 	"workshop/internal/adapters/storage/injury"
 	"workshop/internal/adapters/storage/member"
 	"workshop/internal/adapters/storage/waiver"
@@ -59,13 +61,23 @@ func (m *mockGetMemberProfileInjuryStore) List(_ context.Context, _ injury.ListF
 	return nil, nil
 }
 
-type mockGetMemberProfileAttendanceStore struct{}
+type mockGetMemberProfileAttendanceStore struct {
+	records []domainAttendance.Attendance
+	filter  attendance.ListFilter
+}
 
-// List returns no attendance records.
+// List returns the seeded records for the filter's member and records the filter.
 // PRE: filter is valid
-// POST: Returns an empty attendance list
-func (m *mockGetMemberProfileAttendanceStore) List(_ context.Context, _ attendance.ListFilter) ([]domainAttendance.Attendance, error) {
-	return nil, nil
+// POST: Returns the member's seeded records
+func (m *mockGetMemberProfileAttendanceStore) List(_ context.Context, filter attendance.ListFilter) ([]domainAttendance.Attendance, error) {
+	m.filter = filter
+	var result []domainAttendance.Attendance
+	for _, a := range m.records {
+		if a.MemberID == filter.MemberID {
+			result = append(result, a)
+		}
+	}
+	return result, nil
 }
 
 type mockGetMemberProfileGradingRecordStore struct {
@@ -79,6 +91,18 @@ func (m *mockGetMemberProfileGradingRecordStore) ListByMemberID(_ context.Contex
 	return m.records, nil
 }
 
+// List returns the seeded grading records newest first, up to the filter's limit.
+// PRE: filter.MemberID is non-empty
+// POST: Returns at most filter.Limit records
+func (m *mockGetMemberProfileGradingRecordStore) List(_ context.Context, filter gradingStore.RecordFilter) ([]domainGrading.Record, error) {
+	records := append([]domainGrading.Record(nil), m.records...)
+	sort.Slice(records, func(i, j int) bool { return records[i].PromotedAt.After(records[j].PromotedAt) })
+	if filter.Limit > 0 && filter.Limit < len(records) {
+		records = records[:filter.Limit]
+	}
+	return records, nil
+}
+
 // TestQueryGetMemberProfile_IncludesLatestBeltAndStripe verifies the profile projection uses the latest grading record.
 func TestQueryGetMemberProfile_IncludesLatestBeltAndStripe(t *testing.T) {
 	now := time.Now()
@@ -117,3 +141,31 @@ func TestQueryGetMemberProfile_GradingStoreNil_ReturnsEmptyBelt(t *testing.T) {
 		t.Fatalf("belt/stripe=%q/%d want empty/0", res.Belt, res.Stripe)
 	}
 }
+
+// TestQueryGetMemberProfile_RecentAttendanceUsesBoundedQuery verifies recent attendance asks
+// the store for the member's last 30 days rather than scanning every check-in.
+func TestQueryGetMemberProfile_RecentAttendanceUsesBoundedQuery(t *testing.T) {
+	now := time.Now()
+	att := &mockGetMemberProfileAttendanceStore{records: []domainAttendance.Attendance{
+		{ID: "a1", MemberID: "m1", CheckInTime: now.Add(-24 * time.Hour)},
+		{ID: "a2", MemberID: "m1", CheckInTime: now.Add(-48 * time.Hour)},
+		{ID: "a3", MemberID: "m2", CheckInTime: now.Add(-24 * time.Hour)},
+	}}
+	deps := GetMemberProfileDeps{
+		MemberStore:     &mockGetMemberProfileMemberStore{member: domainMember.Member{ID: "m1", Name: "Alice", Status: "active"}},
+		WaiverStore:     &mockGetMemberProfileWaiverStore{},
+		InjuryStore:     &mockGetMemberProfileInjuryStore{},
+		AttendanceStore: att,
+	}
+
+	res, err := QueryGetMemberProfile(context.Background(), GetMemberProfileQuery{MemberID: "m1"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.RecentAttendance != 2 {
+		t.Errorf("RecentAttendance = %d, want 2", res.RecentAttendance)
+	}
+	if want := now.Add(-30 * 24 * time.Hour).Format("2006-01-02"); att.filter.MemberID != "m1" || att.filter.From != want {
+		t.Errorf("filter = %+v, want member m1 from %s", att.filter, want)
+	}
+}
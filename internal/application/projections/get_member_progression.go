@@ -0,0 +1,197 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	domainEstimatedHours "workshop/internal/domain/estimatedhours"
+	domainGrading "workshop/internal/domain/grading"
+	domainMember "workshop/internal/domain/member"
+	domainMilestone "workshop/internal/domain/milestone"
+)
+
+// Progression event kinds, in the order they sort when they share a date.
+const (
+	ProgressionPromotion      = "promotion"
+	ProgressionStripe         = "stripe"
+	ProgressionInferredStripe = "inferred_stripe"
+	ProgressionMilestone      = "milestone"
+	ProgressionHoursCredit    = "hours_credit"
+)
+
+// ProgressionMemberStore defines the member store interface needed by this projection.
+type ProgressionMemberStore interface {
+	GetByID(ctx context.Context, id string) (domainMember.Member, error)
+}
+
+// ProgressionMilestoneStore defines the milestone store interface needed to name earned milestones.
+type ProgressionMilestoneStore interface {
+	List(ctx context.Context) ([]domainMilestone.Milestone, error)
+}
+
+// ProgressionMemberMilestoneStore defines the earned milestone store interface needed by this projection.
+type ProgressionMemberMilestoneStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]domainMilestone.MemberMilestone, error)
+}
+
+// ProgressionEstimatedHoursStore defines the estimated hours store interface needed by this projection.
+type ProgressionEstimatedHoursStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]domainEstimatedHours.EstimatedHours, error)
+}
+
+// GetMemberProgressionDeps holds dependencies for the projection.
+type GetMemberProgressionDeps struct {
+	MemberStore          ProgressionMemberStore
+	GradingRecordStore   GradingRecordStore
+	MilestoneStore       ProgressionMilestoneStore       // optional: nil skips milestones
+	MemberMilestoneStore ProgressionMemberMilestoneStore // optional: nil skips milestones
+	EstimatedHoursStore  ProgressionEstimatedHoursStore  // optional: nil skips hours credits
+}
+
+// GetMemberProgressionQuery carries query parameters.
+type GetMemberProgressionQuery struct {
+	MemberID string
+	Now      time.Time // end of the current belt's time-at-belt
+}
+
+// ProgressionEvent is one point on the timeline.
+type ProgressionEvent struct {
+	Date   time.Time
+	Kind   string // promotion, stripe, inferred_stripe, milestone, hours_credit
+	Title  string
+	Belt   string  // belt held after the event (grading events only)
+	Stripe int     // stripe held after the event (grading events only)
+	Hours  float64 // credited hours (hours_credit only)
+	Detail string
+	RefID  string
+}
+
+// BeltStat summarises the time spent at one belt.
+type BeltStat struct {
+	Belt    string
+	Since   time.Time
+	Until   time.Time // zero for the current belt
+	Days    int
+	Stripes int // highest stripe reached at this belt
+}
+
+// MemberProgressionResult carries the timeline and time-at-belt statistics.
+type MemberProgressionResult struct {
+	MemberID          string
+	Program           string
+	CurrentBelt       string
+	CurrentStripe     int
+	DaysAtCurrentBelt int
+	CreditedHours     float64
+	Events            []ProgressionEvent
+	Belts             []BeltStat
+}
+
+// QueryGetMemberProgression builds a member's belt and stripe timeline.
+// Grading records become promotions (belt changed), stripes, or inferred stripes;
+// earned milestones and approved estimated-hours credits are interleaved by date.
+// PRE: MemberID is non-empty; Now is set
+// POST: Events are oldest first; Belts are in the order the member held them
+func QueryGetMemberProgression(ctx context.Context, query GetMemberProgressionQuery, deps GetMemberProgressionDeps) (MemberProgressionResult, error) {
+	m, err := deps.MemberStore.GetByID(ctx, query.MemberID)
+	if err != nil {
+		return MemberProgressionResult{}, err
+	}
+	result := MemberProgressionResult{MemberID: m.ID, Program: m.Program, Events: []ProgressionEvent{}, Belts: []BeltStat{}}
+
+	records, err := deps.GradingRecordStore.ListByMemberID(ctx, query.MemberID)
+	if err != nil {
+		return MemberProgressionResult{}, err
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].PromotedAt.Before(records[j].PromotedAt) })
+	for i, r := range records {
+		event := ProgressionEvent{Date: r.PromotedAt, Belt: r.Belt, Stripe: r.Stripe, RefID: r.ID, Detail: r.Method}
+		switch {
+		case i == 0 || r.Belt != records[i-1].Belt:
+			event.Kind = ProgressionPromotion
+			event.Title = "Promoted to " + r.Belt + " belt"
+			result.Belts = append(result.Belts, BeltStat{Belt: r.Belt, Since: r.PromotedAt})
+		case r.Method == domainGrading.MethodInferred:
+			event.Kind = ProgressionInferredStripe
+			event.Title = fmt.Sprintf("Stripe %d (from mat hours)", r.Stripe)
+		default:
+			event.Kind = ProgressionStripe
+			event.Title = fmt.Sprintf("Stripe %d", r.Stripe)
+		}
+		current := &result.Belts[len(result.Belts)-1]
+		if r.Stripe > current.Stripes {
+			current.Stripes = r.Stripe
+		}
+		result.Events = append(result.Events, event)
+	}
+	for i := range result.Belts {
+		end := query.Now
+		if i+1 < len(result.Belts) {
+			result.Belts[i].Until = result.Belts[i+1].Since
+			end = result.Belts[i].Until
+		}
+		result.Belts[i].Days = daysBetween(result.Belts[i].Since, end)
+	}
+	if n := len(records); n > 0 {
+		result.CurrentBelt, result.CurrentStripe = records[n-1].Belt, records[n-1].Stripe
+		result.DaysAtCurrentBelt = result.Belts[len(result.Belts)-1].Days
+	}
+
+	if deps.MemberMilestoneStore != nil && deps.MilestoneStore != nil {
+		earned, err := deps.MemberMilestoneStore.ListByMemberID(ctx, query.MemberID)
+		if err != nil {
+			return MemberProgressionResult{}, err
+		}
+		names := make(map[string]string)
+		if milestones, err := deps.MilestoneStore.List(ctx); err == nil {
+			for _, ms := range milestones {
+				names[ms.ID] = ms.Name
+			}
+		}
+		for _, e := range earned {
+			title := names[e.MilestoneID]
+			if title == "" {
+				title = "Milestone"
+			}
+			result.Events = append(result.Events, ProgressionEvent{Date: e.EarnedAt, Kind: ProgressionMilestone, Title: title, RefID: e.ID})
+		}
+	}
+
+	if deps.EstimatedHoursStore != nil {
+		estimates, err := deps.EstimatedHoursStore.ListByMemberID(ctx, query.MemberID)
+		if err != nil {
+			return MemberProgressionResult{}, err
+		}
+		for _, e := range estimates {
+			if e.Status != domainEstimatedHours.StatusApproved {
+				continue
+			}
+			date, err := time.Parse("2006-01-02", e.EndDate)
+			if err != nil {
+				date = e.CreatedAt
+			}
+			result.CreditedHours += e.TotalHours
+			result.Events = append(result.Events, ProgressionEvent{
+				Date:   date,
+				Kind:   ProgressionHoursCredit,
+				Title:  fmt.Sprintf("%.1f mat hours credited", e.TotalHours),
+				Hours:  e.TotalHours,
+				Detail: e.StartDate + " to " + e.EndDate,
+				RefID:  e.ID,
+			})
+		}
+	}
+
+	sort.SliceStable(result.Events, func(i, j int) bool { return result.Events[i].Date.Before(result.Events[j].Date) })
+	return result, nil
+}
+
+// daysBetween counts whole days from since to until, never negative.
+func daysBetween(since, until time.Time) int {
+	if until.Before(since) {
+		return 0
+	}
+	return int(until.Sub(since).Hours() / 24)
+}
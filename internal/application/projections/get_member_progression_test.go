@@ -0,0 +1,111 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainEstimatedHours "workshop/internal/domain/estimatedhours"
+	domainGrading "workshop/internal/domain/grading"
+	domainMember "workshop/internal/domain/member"
+	domainMilestone "workshop/internal/domain/milestone"
+)
+
+type mockProgressionMilestoneStore struct{}
+
+// List returns one named milestone.
+// PRE: none
+// POST: Returns the Century Club milestone
+func (m *mockProgressionMilestoneStore) List(_ context.Context) ([]domainMilestone.Milestone, error) {
+	return []domainMilestone.Milestone{{ID: "ms1", Name: "Century Club", Metric: domainMilestone.MetricClasses, Threshold: 100}}, nil
+}
+
+type mockProgressionMemberMilestoneStore struct {
+	earned []domainMilestone.MemberMilestone
+}
+
+// ListByMemberID returns seeded earned milestones.
+// PRE: memberID is non-empty
+// POST: Returns any seeded milestones
+func (m *mockProgressionMemberMilestoneStore) ListByMemberID(_ context.Context, _ string) ([]domainMilestone.MemberMilestone, error) {
+	return m.earned, nil
+}
+
+type mockProgressionEstimatedHoursStore struct {
+	estimates []domainEstimatedHours.EstimatedHours
+}
+
+// ListByMemberID returns seeded estimates.
+// PRE: memberID is non-empty
+// POST: Returns any seeded estimates
+func (m *mockProgressionEstimatedHoursStore) ListByMemberID(_ context.Context, _ string) ([]domainEstimatedHours.EstimatedHours, error) {
+	return m.estimates, nil
+}
+
+// TestQueryGetMemberProgression builds a timeline across all sources and computes time at each belt.
+func TestQueryGetMemberProgression(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	deps := GetMemberProgressionDeps{
+		MemberStore: &mockGetMemberProfileMemberStore{member: domainMember.Member{ID: "m1", Name: "Alice", Program: domainMember.ProgramAdults, Status: domainMember.StatusActive}},
+		GradingRecordStore: &mockGetMemberProfileGradingRecordStore{records: []domainGrading.Record{
+			{ID: "g3", MemberID: "m1", Belt: "blue", Stripe: 0, PromotedAt: day("2025-01-01"), Method: domainGrading.MethodStandard},
+			{ID: "g1", MemberID: "m1", Belt: "white", Stripe: 0, PromotedAt: day("2024-01-01"), Method: domainGrading.MethodStandard},
+			{ID: "g2", MemberID: "m1", Belt: "white", Stripe: 2, PromotedAt: day("2024-06-01"), Method: domainGrading.MethodInferred},
+			{ID: "g4", MemberID: "m1", Belt: "blue", Stripe: 1, PromotedAt: day("2025-04-01"), Method: domainGrading.MethodStandard},
+		}},
+		MilestoneStore:       &mockProgressionMilestoneStore{},
+		MemberMilestoneStore: &mockProgressionMemberMilestoneStore{earned: []domainMilestone.MemberMilestone{{ID: "e1", MemberID: "m1", MilestoneID: "ms1", EarnedAt: day("2024-09-01")}}},
+		EstimatedHoursStore: &mockProgressionEstimatedHoursStore{estimates: []domainEstimatedHours.EstimatedHours{
+			{ID: "h1", MemberID: "m1", StartDate: "2023-01-01", EndDate: "2023-12-31", TotalHours: 120, Status: domainEstimatedHours.StatusApproved},
+			{ID: "h2", MemberID: "m1", StartDate: "2024-01-01", EndDate: "2024-02-01", TotalHours: 10, Status: domainEstimatedHours.StatusPending},
+		}},
+	}
+
+	res, err := QueryGetMemberProgression(context.Background(), GetMemberProgressionQuery{MemberID: "m1", Now: day("2025-07-01")}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantKinds := []string{ProgressionHoursCredit, ProgressionPromotion, ProgressionInferredStripe, ProgressionMilestone, ProgressionPromotion, ProgressionStripe}
+	if len(res.Events) != len(wantKinds) {
+		t.Fatalf("expected %d events, got %+v", len(wantKinds), res.Events)
+	}
+	for i, want := range wantKinds {
+		if res.Events[i].Kind != want {
+			t.Errorf("event %d: kind %q, want %q", i, res.Events[i].Kind, want)
+		}
+	}
+	if res.Events[3].Title != "Century Club" {
+		t.Errorf("milestone title = %q", res.Events[3].Title)
+	}
+	if res.CurrentBelt != "blue" || res.CurrentStripe != 1 || res.CreditedHours != 120 {
+		t.Errorf("current = %s/%d hours %.0f", res.CurrentBelt, res.CurrentStripe, res.CreditedHours)
+	}
+	if len(res.Belts) != 2 {
+		t.Fatalf("expected 2 belts, got %+v", res.Belts)
+	}
+	if res.Belts[0].Belt != "white" || res.Belts[0].Days != 366 || res.Belts[0].Stripes != 2 || !res.Belts[0].Until.Equal(day("2025-01-01")) {
+		t.Errorf("white belt stat = %+v", res.Belts[0])
+	}
+	if res.Belts[1].Days != 181 || !res.Belts[1].Until.IsZero() || res.DaysAtCurrentBelt != 181 {
+		t.Errorf("blue belt stat = %+v, current days %d", res.Belts[1], res.DaysAtCurrentBelt)
+	}
+}
+
+// TestQueryGetMemberProgression_NoRecords returns an empty timeline for an ungraded member.
+func TestQueryGetMemberProgression_NoRecords(t *testing.T) {
+	deps := GetMemberProgressionDeps{
+		MemberStore:        &mockGetMemberProfileMemberStore{member: domainMember.Member{ID: "m1", Program: domainMember.ProgramKids}},
+		GradingRecordStore: &mockGetMemberProfileGradingRecordStore{},
+	}
+	res, err := QueryGetMemberProgression(context.Background(), GetMemberProgressionQuery{MemberID: "m1", Now: time.Now()}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.CurrentBelt != "" || len(res.Events) != 0 || len(res.Belts) != 0 {
+		t.Errorf("expected empty progression, got %+v", res)
+	}
+}
@@ -0,0 +1,121 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"workshop/internal/domain/message"
+)
+
+// messagePreviewLength caps the last-message preview shown in thread lists.
+const messagePreviewLength = 140
+
+// MessageThreadStore defines the message store interface needed by this projection.
+type MessageThreadStore interface {
+	ListByReceiverID(ctx context.Context, receiverID string) ([]message.Message, error)
+	ListByThreadID(ctx context.Context, threadID string) ([]message.Message, error)
+	ListUnreadReplies(ctx context.Context) ([]message.Message, error)
+}
+
+// GetMessageThreadsDeps holds dependencies for the projection.
+type GetMessageThreadsDeps struct {
+	MessageStore MessageThreadStore
+}
+
+// GetMessageThreadsQuery selects whose threads to list and from which side.
+// With a MemberID, every thread with that member is listed. Without one (staff only),
+// only threads with unread member replies are listed.
+type GetMessageThreadsQuery struct {
+	MemberID  string
+	ForMember bool // count unread from the member's side (staff messages) instead of staff's (member replies)
+}
+
+// MessageThread summarises one conversation.
+type MessageThread struct {
+	ThreadID       string
+	MemberID       string
+	Subject        string
+	LastMessage    string
+	LastMessageAt  time.Time
+	LastFromMember bool
+	MessageCount   int
+	Unread         int
+}
+
+// QueryGetMessageThreads groups messages into threads with per-thread unread counts.
+// PRE: ForMember implies MemberID is set
+// POST: Returns threads, most recently active first
+func QueryGetMessageThreads(ctx context.Context, query GetMessageThreadsQuery, deps GetMessageThreadsDeps) ([]MessageThread, error) {
+	var messages []message.Message
+	if query.MemberID != "" {
+		list, err := deps.MessageStore.ListByReceiverID(ctx, query.MemberID)
+		if err != nil {
+			return nil, err
+		}
+		messages = list
+	} else {
+		unread, err := deps.MessageStore.ListUnreadReplies(ctx)
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[string]bool)
+		for _, m := range unread {
+			id := m.ThreadID()
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			thread, err := deps.MessageStore.ListByThreadID(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			messages = append(messages, thread...)
+		}
+	}
+
+	byThread := make(map[string]*MessageThread)
+	var order []string
+	for _, m := range messages {
+		id := m.ThreadID()
+		t, ok := byThread[id]
+		if !ok {
+			t = &MessageThread{ThreadID: id, MemberID: m.ReceiverID, Subject: m.Subject}
+			byThread[id] = t
+			order = append(order, id)
+		}
+		t.MessageCount++
+		if m.ParentID == "" {
+			t.Subject = m.Subject
+		}
+		if !m.CreatedAt.Before(t.LastMessageAt) {
+			t.LastMessageAt = m.CreatedAt
+			t.LastMessage = messagePreview(m.Content)
+			if t.LastMessage == "" && len(m.Attachments) > 0 {
+				t.LastMessage = "(attachment)"
+			}
+			t.LastFromMember = m.FromMember
+		}
+		if m.IsUnreadBy(query.ForMember) {
+			t.Unread++
+		}
+	}
+
+	threads := make([]MessageThread, 0, len(order))
+	for _, id := range order {
+		threads = append(threads, *byThread[id])
+	}
+	sort.SliceStable(threads, func(i, j int) bool {
+		return threads[i].LastMessageAt.After(threads[j].LastMessageAt)
+	})
+	return threads, nil
+}
+
+// messagePreview shortens message content for thread lists.
+func messagePreview(content string) string {
+	runes := []rune(content)
+	if len(runes) <= messagePreviewLength {
+		return content
+	}
+	return string(runes[:messagePreviewLength]) + "…"
+}
@@ -0,0 +1,93 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/message"
+)
+
+type mockMTMessageStore struct {
+	messages []message.Message
+}
+
+// ListByReceiverID implements MessageThreadStore.
+// PRE: receiverID is non-empty
+// POST: returns the member's messages
+func (m *mockMTMessageStore) ListByReceiverID(_ context.Context, receiverID string) ([]message.Message, error) {
+	var out []message.Message
+	for _, msg := range m.messages {
+		if msg.ReceiverID == receiverID {
+			out = append(out, msg)
+		}
+	}
+	return out, nil
+}
+
+// ListByThreadID implements MessageThreadStore.
+// PRE: threadID is non-empty
+// POST: returns the thread's messages
+func (m *mockMTMessageStore) ListByThreadID(_ context.Context, threadID string) ([]message.Message, error) {
+	var out []message.Message
+	for _, msg := range m.messages {
+		if msg.ThreadID() == threadID {
+			out = append(out, msg)
+		}
+	}
+	return out, nil
+}
+
+// ListUnreadReplies implements MessageThreadStore.
+// PRE: none
+// POST: returns unread member replies
+func (m *mockMTMessageStore) ListUnreadReplies(_ context.Context) ([]message.Message, error) {
+	var out []message.Message
+	for _, msg := range m.messages {
+		if msg.FromMember && !msg.IsRead() {
+			out = append(out, msg)
+		}
+	}
+	return out, nil
+}
+
+func threadFixture() *mockMTMessageStore {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	read := base.Add(time.Hour)
+	return &mockMTMessageStore{messages: []message.Message{
+		{ID: "t1", SenderID: "coach1", ReceiverID: "m1", Subject: "Comp prep", Content: "Ready?", CreatedAt: base, ReadAt: read},
+		{ID: "r1", ParentID: "t1", SenderID: "acct1", ReceiverID: "m1", FromMember: true, Subject: "Comp prep", Content: "Yes", CreatedAt: base.Add(2 * time.Hour)},
+		{ID: "r2", ParentID: "t1", SenderID: "coach1", ReceiverID: "m1", Subject: "Comp prep", Content: "Great", CreatedAt: base.Add(3 * time.Hour)},
+		{ID: "t2", SenderID: "coach1", ReceiverID: "m1", Subject: "Fees", Content: "Due", CreatedAt: base.Add(-time.Hour)},
+		{ID: "t3", SenderID: "coach1", ReceiverID: "m2", Subject: "Other", Content: "Hi", CreatedAt: base},
+	}}
+}
+
+// TestQueryGetMessageThreads_Member verifies threads are grouped and unread counts exclude the member's own replies.
+func TestQueryGetMessageThreads_Member(t *testing.T) {
+	threads, err := QueryGetMessageThreads(context.Background(), GetMessageThreadsQuery{MemberID: "m1", ForMember: true}, GetMessageThreadsDeps{MessageStore: threadFixture()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(threads) != 2 {
+		t.Fatalf("expected 2 threads, got %d", len(threads))
+	}
+	first := threads[0]
+	if first.ThreadID != "t1" || first.MessageCount != 3 || first.Unread != 1 || first.LastMessage != "Great" {
+		t.Errorf("unexpected first thread: %+v", first)
+	}
+	if threads[1].ThreadID != "t2" || threads[1].Unread != 1 {
+		t.Errorf("unexpected second thread: %+v", threads[1])
+	}
+}
+
+// TestQueryGetMessageThreads_StaffOverview verifies staff see only threads awaiting their reply.
+func TestQueryGetMessageThreads_StaffOverview(t *testing.T) {
+	threads, err := QueryGetMessageThreads(context.Background(), GetMessageThreadsQuery{}, GetMessageThreadsDeps{MessageStore: threadFixture()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(threads) != 1 || threads[0].ThreadID != "t1" || threads[0].Unread != 1 || threads[0].Subject != "Comp prep" {
+		t.Fatalf("expected t1 with one unread reply, got %+v", threads)
+	}
+}
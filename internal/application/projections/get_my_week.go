@@ -0,0 +1,170 @@
+package projections
+
+import (
+	"context"
+	"time"
+
+	attendanceStore "workshop/internal/adapters/storage/attendance"
+	"workshop/internal/domain/attendance"
+	domainMember "workshop/internal/domain/member"
+)
+
+// What makes a class one of a member's likely classes for the week ahead.
+const (
+	MyWeekHistoryDays = 56 // attendance window, in days before today
+	MyWeekUsualVisits = 2  // sessions of a class within the window that make it likely
+	MyWeekDays        = 7  // today and the six days after
+)
+
+// myWeekMaxEntries caps the check-ins read for the history window.
+const myWeekMaxEntries = 500
+
+// MyWeekAttendanceStore defines the attendance store interface needed to find a member's usual classes.
+type MyWeekAttendanceStore interface {
+	List(ctx context.Context, filter attendanceStore.ListFilter) ([]attendance.Attendance, error)
+}
+
+// MyWeekMemberStore defines the member store interface needed for the member and their coaches' names.
+type MyWeekMemberStore interface {
+	GetByID(ctx context.Context, id string) (domainMember.Member, error)
+	GetByAccountID(ctx context.Context, accountID string) (domainMember.Member, error)
+}
+
+// GetMyWeekQuery carries input for the my week projection.
+type GetMyWeekQuery struct {
+	MemberID string
+	Gate     BeltGate  // the member's belt, for the curriculum
+	Now      time.Time // today's classes that have finished are left out
+}
+
+// GetMyWeekDeps holds dependencies for the my week projection.
+type GetMyWeekDeps struct {
+	AttendanceStore MyWeekAttendanceStore
+	MemberStore     MyWeekMemberStore
+	Classes         GetTodaysClassesDeps
+	Curriculum      GetThemeCarouselDeps // optional: a nil RotorStore leaves topics out
+}
+
+// MyWeekResult carries the output of the my week projection.
+type MyWeekResult struct {
+	MemberID string
+	From     string // YYYY-MM-DD, today
+	To       string // YYYY-MM-DD, the last day covered
+	Classes  []MyWeekClass
+}
+
+// MyWeekClass is one upcoming session of a class the member usually attends.
+type MyWeekClass struct {
+	Date          string // YYYY-MM-DD
+	ScheduleID    string
+	ClassTypeName string
+	ProgramName   string
+	StartTime     string
+	EndTime       string
+	Attire        string   // gi, nogi or both; empty when the class type doesn't say
+	Mat           string   // empty = the usual mat
+	Coach         string   // the covering coach, or the regular coach's name; empty when unassigned
+	Change        string   // schedule.ChangeMoved when the session is not at its usual time
+	Topics        []string // what the class's rotor is running now
+	Visits        int      // sessions the member attended in the history window
+}
+
+// QueryGetMyWeek lists the sessions over the next MyWeekDays of the classes a member usually
+// attends, with what to wear, where, who is coaching and what is being taught. A class is
+// usual once the member attended MyWeekUsualVisits of its sessions in the last
+// MyWeekHistoryDays. Holidays, term breaks, cancellations and moves are applied as on the
+// timetable.
+// PRE: MemberID exists; Now is set
+// POST: Returns sessions in date and start time order; an empty list for a member with no usual classes
+func QueryGetMyWeek(ctx context.Context, query GetMyWeekQuery, deps GetMyWeekDeps) (MyWeekResult, error) {
+	now := query.Now
+	result := MyWeekResult{
+		MemberID: query.MemberID,
+		From:     now.Format("2006-01-02"),
+		To:       now.AddDate(0, 0, MyWeekDays-1).Format("2006-01-02"),
+		Classes:  []MyWeekClass{},
+	}
+	if _, err := deps.MemberStore.GetByID(ctx, query.MemberID); err != nil {
+		return result, err
+	}
+
+	checkIns, err := deps.AttendanceStore.List(ctx, attendanceStore.ListFilter{
+		MemberID: query.MemberID,
+		From:     now.AddDate(0, 0, -MyWeekHistoryDays).Format("2006-01-02"),
+		To:       now.AddDate(0, 0, -1).Format("2006-01-02"),
+		Limit:    myWeekMaxEntries,
+	})
+	if err != nil {
+		return result, err
+	}
+	visits := map[string]int{}
+	for _, a := range checkIns {
+		if a.ScheduleID != "" {
+			visits[a.ScheduleID]++
+		}
+	}
+
+	var topics map[string][]string // read from the curriculum at the first likely class
+	coaches := map[string]string{}
+	for i := 0; i < MyWeekDays; i++ {
+		day := now.AddDate(0, 0, i)
+		classes, err := QueryGetTodaysClasses(ctx, day, deps.Classes)
+		if err != nil {
+			return result, err
+		}
+		for _, c := range classes {
+			if visits[c.ScheduleID] < MyWeekUsualVisits {
+				continue
+			}
+			if i == 0 && c.EndTime <= now.Format("15:04") {
+				continue
+			}
+			if topics == nil && deps.Curriculum.RotorStore != nil {
+				if topics, err = myWeekTopics(ctx, query, deps.Curriculum); err != nil {
+					return result, err
+				}
+			}
+			coach := c.Substitute
+			if coach == "" && c.CoachID != "" {
+				if _, ok := coaches[c.CoachID]; !ok {
+					if m, err := deps.MemberStore.GetByAccountID(ctx, c.CoachID); err == nil {
+						coaches[c.CoachID] = m.Name
+					}
+				}
+				coach = coaches[c.CoachID]
+			}
+			result.Classes = append(result.Classes, MyWeekClass{
+				Date:          day.Format("2006-01-02"),
+				ScheduleID:    c.ScheduleID,
+				ClassTypeName: c.ClassTypeName,
+				ProgramName:   c.ProgramName,
+				StartTime:     c.StartTime,
+				EndTime:       c.EndTime,
+				Attire:        c.Attire,
+				Mat:           c.Mat,
+				Coach:         coach,
+				Change:        c.Change,
+				Topics:        append([]string{}, topics[c.ClassTypeID]...),
+				Visits:        visits[c.ScheduleID],
+			})
+		}
+	}
+	return result, nil
+}
+
+// myWeekTopics maps each class type to the topics its active rotor is running now.
+func myWeekTopics(ctx context.Context, query GetMyWeekQuery, deps GetThemeCarouselDeps) (map[string][]string, error) {
+	carousel, err := QueryGetThemeCarousel(ctx, GetThemeCarouselQuery{Gate: query.Gate, Now: query.Now}, deps)
+	if err != nil {
+		return nil, err
+	}
+	topics := map[string][]string{}
+	for _, c := range carousel.Classes {
+		for _, slide := range c.Slides {
+			if slide.Topic != nil {
+				topics[c.ClassTypeID] = append(topics[c.ClassTypeID], slide.Topic.Name)
+			}
+		}
+	}
+	return topics, nil
+}
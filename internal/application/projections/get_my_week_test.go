@@ -0,0 +1,137 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/term"
+)
+
+// mockMWMemberStore implements MyWeekMemberStore for testing.
+type mockMWMemberStore struct {
+	members []member.Member
+}
+
+// GetByID implements MyWeekMemberStore for testing.
+// PRE: id is non-empty
+// POST: Returns the stored member or an error
+func (m *mockMWMemberStore) GetByID(_ context.Context, id string) (member.Member, error) {
+	for _, mem := range m.members {
+		if mem.ID == id {
+			return mem, nil
+		}
+	}
+	return member.Member{}, context.DeadlineExceeded
+}
+
+// GetByAccountID implements MyWeekMemberStore for testing.
+// PRE: accountID is non-empty
+// POST: Returns the member linked to the account or an error
+func (m *mockMWMemberStore) GetByAccountID(_ context.Context, accountID string) (member.Member, error) {
+	for _, mem := range m.members {
+		if mem.AccountID == accountID {
+			return mem, nil
+		}
+	}
+	return member.Member{}, context.DeadlineExceeded
+}
+
+// mockMWClassTypeStore implements TodaysClassesClassTypeStore for testing.
+type mockMWClassTypeStore struct {
+	classTypes map[string]classtype.ClassType
+}
+
+// GetByID implements TodaysClassesClassTypeStore for testing.
+// PRE: id is non-empty
+// POST: Returns the stored class type or an error
+func (m *mockMWClassTypeStore) GetByID(_ context.Context, id string) (classtype.ClassType, error) {
+	ct, ok := m.classTypes[id]
+	if !ok {
+		return classtype.ClassType{}, context.DeadlineExceeded
+	}
+	return ct, nil
+}
+
+// TestQueryGetMyWeek verifies the week lists only classes the member keeps coming to, leaves
+// out today's finished classes, and carries attire, coach and the rotor's running topics.
+func TestQueryGetMyWeek(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC) // Monday
+	feb := func(day int) time.Time { return time.Date(2026, 2, day, 18, 0, 0, 0, time.UTC) }
+	records := []attendance.Attendance{
+		{ID: "a1", MemberID: "m1", ScheduleID: "sch-evening", CheckInTime: feb(16)},
+		{ID: "a2", MemberID: "m1", ScheduleID: "sch-evening", CheckInTime: feb(23)},
+		{ID: "a3", MemberID: "m1", ScheduleID: "sch-morning", CheckInTime: feb(16)},
+		{ID: "a4", MemberID: "m1", ScheduleID: "sch-morning", CheckInTime: feb(23)},
+		{ID: "a5", MemberID: "m1", ScheduleID: "sch-nogi", CheckInTime: feb(18)},
+		{ID: "a6", MemberID: "m1", ScheduleID: "sch-nogi", CheckInTime: feb(25)},
+		{ID: "a7", MemberID: "m1", ScheduleID: "sch-open", CheckInTime: feb(20)},                                       // once is not a habit
+		{ID: "a8", MemberID: "m1", ScheduleID: "sch-open", CheckInTime: time.Date(2025, 12, 5, 18, 0, 0, 0, time.UTC)}, // before the window
+	}
+	deps := GetMyWeekDeps{
+		AttendanceStore: &mockTrainingLogAttendanceStore{records: map[string][]attendance.Attendance{"m1": records}},
+		MemberStore: &mockMWMemberStore{members: []member.Member{
+			{ID: "m1", Name: "Alex", Program: "adults"},
+			{ID: "m2", Name: "Kim", AccountID: "acct-kim", Program: "adults"},
+		}},
+		Classes: GetTodaysClassesDeps{
+			ScheduleStore: &mockTCScheduleStore{schedules: []schedule.Schedule{
+				{ID: "sch-morning", ClassTypeID: "ct-fund", Day: schedule.Monday, StartTime: "06:00", EndTime: "07:00"},
+				{ID: "sch-evening", ClassTypeID: "ct-fund", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:00", Mat: "Mat 2", CoachID: "acct-kim"},
+				{ID: "sch-nogi", ClassTypeID: "ct-nogi", Day: schedule.Wednesday, StartTime: "18:00", EndTime: "19:00"},
+				{ID: "sch-open", ClassTypeID: "ct-nogi", Day: schedule.Friday, StartTime: "18:00", EndTime: "19:00"},
+			}},
+			TermStore:    &mockTCTermStore{terms: []term.Term{{ID: "t1", Name: "Term 1", StartDate: now.AddDate(0, -1, 0), EndDate: now.AddDate(0, 1, 0)}}},
+			HolidayStore: &mockTCHolidayStore{},
+			ClassTypeStore: &mockMWClassTypeStore{classTypes: map[string]classtype.ClassType{
+				"ct-fund": {ID: "ct-fund", ProgramID: "p1", Name: "Fundamentals", Attire: classtype.AttireGi},
+				"ct-nogi": {ID: "ct-nogi", ProgramID: "p1", Name: "No-Gi", Attire: classtype.AttireNoGi},
+			}},
+			ProgramStore: &mockTCProgramStore{},
+		},
+		Curriculum: carouselDeps(true),
+	}
+	query := GetMyWeekQuery{MemberID: "m1", Gate: BeltGate{Program: "adults", Belt: "white"}, Now: now}
+
+	result, err := QueryGetMyWeek(context.Background(), query, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.From != "2026-03-02" || result.To != "2026-03-08" {
+		t.Errorf("week = %s to %s", result.From, result.To)
+	}
+	if len(result.Classes) != 2 {
+		t.Fatalf("expected tonight's Fundamentals and Wednesday's No-Gi, got %+v", result.Classes)
+	}
+	first, second := result.Classes[0], result.Classes[1]
+	if first.ScheduleID != "sch-evening" || first.Date != "2026-03-02" || first.Attire != classtype.AttireGi || first.Mat != "Mat 2" || first.Coach != "Kim" || first.Visits != 2 {
+		t.Errorf("first = %+v", first)
+	}
+	if len(first.Topics) != 1 || first.Topics[0] != "Lasso" {
+		t.Errorf("topics = %v, want the running Fundamentals topic", first.Topics)
+	}
+	if second.ScheduleID != "sch-nogi" || second.Date != "2026-03-04" || second.Attire != classtype.AttireNoGi || second.Coach != "" || len(second.Topics) != 0 {
+		t.Errorf("second = %+v", second)
+	}
+
+	// Without curriculum stores the week still lists the classes
+	deps.Curriculum = GetThemeCarouselDeps{}
+	result, err = QueryGetMyWeek(context.Background(), query, deps)
+	if err != nil || len(result.Classes) != 2 || len(result.Classes[0].Topics) != 0 {
+		t.Errorf("without curriculum: got %+v, %v", result, err)
+	}
+
+	// A member with no history has nothing to show; an unknown member is an error
+	query.MemberID = "m2"
+	if result, err := QueryGetMyWeek(context.Background(), query, deps); err != nil || len(result.Classes) != 0 {
+		t.Errorf("no history: got %+v, %v", result, err)
+	}
+	query.MemberID = "missing"
+	if _, err := QueryGetMyWeek(context.Background(), query, deps); err == nil {
+		t.Error("expected an error for an unknown member")
+	}
+}
@@ -0,0 +1,157 @@
+package projections
+
+import (
+	domainAccount "workshop/internal/domain/account"
+)
+
+// More menu groups, in the order they are shown
+const (
+	NavGroupTraining = "Training"
+	NavGroupContent  = "Content"
+	NavGroupSettings = "Settings"
+)
+
+// navGroups orders the groups under More.
+var navGroups = []string{NavGroupTraining, NavGroupContent, NavGroupSettings}
+
+// NavItem is one link in the global navigation.
+type NavItem struct {
+	Key      string // stable identifier, e.g. "members"
+	Label    string
+	LabelKey string // translation key for the label; empty when the label is not translated
+	Href     string
+}
+
+// NavGroup is a labelled set of links under More.
+type NavGroup struct {
+	Label string
+	Items []NavItem
+}
+
+// Navigation is the menu for one session.
+type Navigation struct {
+	Role          string // the role the menu is built for; the impersonated role while impersonating
+	RealRole      string // the admin's own role while impersonating; empty otherwise
+	Impersonating bool
+	Kiosk         bool // the session runs kiosk mode, so only the way back to check-in is offered
+	Primary       []NavItem
+	More          []NavGroup
+}
+
+// navEntry registers a page in the global navigation.
+type navEntry struct {
+	NavItem
+	Roles   []string // roles the link is laid out for
+	Top     []string // of Roles, those who see it on the bar rather than under More
+	Group   string   // group under More for the other Roles
+	Feature string   // feature flag the link sits behind; empty for none
+}
+
+// navRegistry is every page in the global navigation, in the order links are shown. A new
+// page registers itself here; the route's own policy still decides who may follow the link.
+var navRegistry = []navEntry{
+	{NavItem: NavItem{Key: "training_log", LabelKey: "nav.training_log", Label: "Training Log", Href: "/training-log"}, Roles: navMembers, Top: navMembers, Feature: "training_log"},
+	{NavItem: NavItem{Key: "members", Label: "Members", Href: "/members"}, Roles: navStaff, Top: navStaff, Feature: "member_mgmt"},
+	{NavItem: NavItem{Key: "attendance", Label: "Attendance", Href: "/attendance"}, Roles: navStaff, Top: navStaff, Feature: "attendance"},
+	{NavItem: NavItem{Key: "messages", LabelKey: "nav.messages", Label: "Messages", Href: "/messages"}, Roles: navEveryone, Top: navMembers, Group: NavGroupContent, Feature: "messages"},
+	{NavItem: NavItem{Key: "curriculum", LabelKey: "nav.curriculum", Label: "Curriculum", Href: "/curriculum"}, Roles: navAdminCoachMember, Top: []string{domainAccount.RoleCoach, domainAccount.RoleMember}, Group: NavGroupContent, Feature: "curriculum"},
+	{NavItem: NavItem{Key: "calendar", LabelKey: "nav.calendar", Label: "Calendar", Href: "/calendar"}, Roles: navAdminCoachMember, Top: navAdminCoachMember, Feature: "calendar"},
+	{NavItem: NavItem{Key: "grading", Label: "Grading", Href: "/admin/grading"}, Roles: navStaff, Top: navStaff, Feature: "grading"},
+	{NavItem: NavItem{Key: "emails", Label: "Emails", Href: "/admin/emails"}, Roles: navAdmin, Top: navAdmin, Feature: "emails"},
+	{NavItem: NavItem{Key: "schedules", Label: "Schedules", Href: "/admin/schedules"}, Roles: navStaff, Group: NavGroupTraining},
+	{NavItem: NavItem{Key: "class_types", Label: "Class Types", Href: "/admin/class-types"}, Roles: navAdmin, Group: NavGroupTraining},
+	{NavItem: NavItem{Key: "milestones", Label: "Grading Goals", Href: "/admin/milestones"}, Roles: navAdmin, Group: NavGroupTraining},
+	{NavItem: NavItem{Key: "self_estimates", Label: "Training Hours", Href: "/admin/self-estimates"}, Roles: navAdmin, Group: NavGroupTraining},
+	{NavItem: NavItem{Key: "session_logs", Label: "Session Logs", Href: "/session-logs"}, Roles: navStaff, Group: NavGroupTraining, Feature: "curriculum"},
+	{NavItem: NavItem{Key: "feedback", Label: "Class Feedback", Href: "/feedback"}, Roles: navStaff, Group: NavGroupTraining, Feature: "class_feedback"},
+	{NavItem: NavItem{Key: "kiosk", Label: "Kiosk", Href: "/kiosk"}, Roles: navStaff, Group: NavGroupTraining, Feature: "kiosk"},
+	{NavItem: NavItem{Key: "class_packs", Label: "Class Packs", Href: "/admin/class-packs"}, Roles: navAdmin, Group: NavGroupTraining, Feature: "class_packs"},
+	{NavItem: NavItem{Key: "themes", LabelKey: "nav.themes", Label: "Themes", Href: "/themes"}, Roles: navAdminCoachMember, Top: []string{domainAccount.RoleMember}, Group: NavGroupContent, Feature: "library"},
+	{NavItem: NavItem{Key: "library", LabelKey: "nav.library", Label: "Library", Href: "/library"}, Roles: navAdminCoachMember, Top: []string{domainAccount.RoleMember}, Group: NavGroupContent, Feature: "library"},
+	{NavItem: NavItem{Key: "notices", Label: "Notices", Href: "/admin/notices"}, Roles: navStaff, Group: NavGroupContent},
+	{NavItem: NavItem{Key: "accounts", Label: "Accounts", Href: "/admin/accounts"}, Roles: navAdmin, Group: NavGroupSettings},
+	{NavItem: NavItem{Key: "features", Label: "System Options", Href: "/admin/features"}, Roles: navAdmin, Group: NavGroupSettings},
+	{NavItem: NavItem{Key: "permissions", Label: "Permissions", Href: "/admin/permissions"}, Roles: navAdmin, Group: NavGroupSettings, Feature: "permissions"},
+	{NavItem: NavItem{Key: "terms", Label: "Terms", Href: "/admin/terms"}, Roles: navAdmin, Group: NavGroupSettings},
+	{NavItem: NavItem{Key: "holidays", Label: "Holidays", Href: "/admin/holidays"}, Roles: navAdmin, Group: NavGroupSettings},
+	{NavItem: NavItem{Key: "inactive", Label: "Inactive Members", Href: "/admin/inactive"}, Roles: navAdmin, Group: NavGroupSettings},
+	{NavItem: NavItem{Key: "backups", Label: "Backups", Href: "/admin/backups"}, Roles: navAdmin, Group: NavGroupSettings, Feature: "backups"},
+	{NavItem: NavItem{Key: "sessions", Label: "Sessions", Href: "/admin/sessions"}, Roles: navAdmin, Group: NavGroupSettings, Feature: "sessions"},
+	{NavItem: NavItem{Key: "api_docs", Label: "API Docs", Href: "/admin/api-docs"}, Roles: navAdmin, Group: NavGroupSettings, Feature: "api_docs"},
+}
+
+// Role sets used by navRegistry
+var (
+	navAdmin            = []string{domainAccount.RoleAdmin}
+	navStaff            = []string{domainAccount.RoleAdmin, domainAccount.RoleCoach}
+	navMembers          = []string{domainAccount.RoleMember, domainAccount.RoleTrial}
+	navAdminCoachMember = []string{domainAccount.RoleAdmin, domainAccount.RoleCoach, domainAccount.RoleMember}
+	navEveryone         = []string{domainAccount.RoleAdmin, domainAccount.RoleCoach, domainAccount.RoleMember, domainAccount.RoleTrial}
+)
+
+// NavHrefs returns the href of every registered page, so the adapter can check each has a
+// route.
+// PRE: none
+// POST: Returns the hrefs in registry order
+func NavHrefs() []string {
+	hrefs := make([]string, len(navRegistry))
+	for i, e := range navRegistry {
+		hrefs[i] = e.Href
+	}
+	return hrefs
+}
+
+// GetNavigationQuery describes the session the menu is for.
+type GetNavigationQuery struct {
+	Role     string // the session's role; the impersonated role while impersonating
+	RealRole string // the admin's own role while impersonating; empty otherwise
+	Kiosk    bool   // the session runs kiosk mode on a registered device
+}
+
+// GetNavigationDeps holds the session's checks.
+type GetNavigationDeps struct {
+	FeatureEnabled func(key string) bool  // whether a feature flag is on for the session
+	CanVisit       func(href string) bool // whether the session clears the route's policy
+}
+
+// QueryGetNavigation builds the menu for a session: the registered pages laid out for its
+// role, less those behind a feature flag that is off or a route the session may not reach.
+// A kiosk session gets only the way back to check-in.
+// PRE: deps funcs are set
+// POST: Returns the menu; Primary and More are empty for an unknown role
+func QueryGetNavigation(q GetNavigationQuery, deps GetNavigationDeps) Navigation {
+	nav := Navigation{Role: q.Role, RealRole: q.RealRole, Impersonating: q.RealRole != "", Kiosk: q.Kiosk, Primary: []NavItem{}, More: []NavGroup{}}
+	groups := map[string][]NavItem{}
+	for _, e := range navRegistry {
+		if !navHasRole(e.Roles, q.Role) || (q.Kiosk && e.Key != "kiosk") {
+			continue
+		}
+		if e.Feature != "" && !deps.FeatureEnabled(e.Feature) {
+			continue
+		}
+		if !deps.CanVisit(e.Href) {
+			continue
+		}
+		if q.Kiosk || navHasRole(e.Top, q.Role) {
+			nav.Primary = append(nav.Primary, e.NavItem)
+		} else {
+			groups[e.Group] = append(groups[e.Group], e.NavItem)
+		}
+	}
+	for _, g := range navGroups {
+		if len(groups[g]) > 0 {
+			nav.More = append(nav.More, NavGroup{Label: g, Items: groups[g]})
+		}
+	}
+	return nav
+}
+
+// navHasRole reports whether role is one of roles.
+func navHasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package projections
+
+import (
+	"strings"
+	"testing"
+)
+
+// navKeys flattens a menu to "key" for the bar and "Group/key" under More.
+func navKeys(nav Navigation) string {
+	var keys []string
+	for _, i := range nav.Primary {
+		keys = append(keys, i.Key)
+	}
+	for _, g := range nav.More {
+		for _, i := range g.Items {
+			keys = append(keys, g.Label+"/"+i.Key)
+		}
+	}
+	return strings.Join(keys, " ")
+}
+
+func allowAll(string) bool { return true }
+
+// TestQueryGetNavigation_Roles verifies each role gets its own layout of the registry.
+func TestQueryGetNavigation_Roles(t *testing.T) {
+	deps := GetNavigationDeps{FeatureEnabled: allowAll, CanVisit: allowAll}
+	tests := []struct {
+		role string
+		want string
+	}{
+		{"member", "training_log messages curriculum calendar themes library"},
+		{"trial", "training_log messages"},
+		{"coach", "members attendance curriculum calendar grading Training/schedules Training/session_logs Training/feedback Training/kiosk Content/messages Content/themes Content/library Content/notices"},
+		{"guest", ""},
+	}
+	for _, tt := range tests {
+		if got := navKeys(QueryGetNavigation(GetNavigationQuery{Role: tt.role}, deps)); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.role, got, tt.want)
+		}
+	}
+	admin := QueryGetNavigation(GetNavigationQuery{Role: "admin"}, deps)
+	if len(admin.More) != 3 || admin.More[2].Label != NavGroupSettings || navKeys(Navigation{Primary: admin.Primary}) != "members attendance calendar grading emails" {
+		t.Errorf("admin = %s", navKeys(admin))
+	}
+}
+
+// TestQueryGetNavigation_Filters verifies feature flags and route policies hide links, a
+// kiosk session only gets the kiosk, and impersonation is reported.
+func TestQueryGetNavigation_Filters(t *testing.T) {
+	nav := QueryGetNavigation(GetNavigationQuery{Role: "member", RealRole: "admin"}, GetNavigationDeps{
+		FeatureEnabled: func(key string) bool { return key != "library" },
+		CanVisit:       func(href string) bool { return href != "/calendar" },
+	})
+	if got := navKeys(nav); got != "training_log messages curriculum" {
+		t.Errorf("filtered member = %q", got)
+	}
+	if !nav.Impersonating || nav.RealRole != "admin" || nav.Role != "member" {
+		t.Errorf("impersonation = %+v", nav)
+	}
+
+	kiosk := QueryGetNavigation(GetNavigationQuery{Role: "coach", Kiosk: true}, GetNavigationDeps{FeatureEnabled: allowAll, CanVisit: allowAll})
+	if got := navKeys(kiosk); got != "kiosk" || !kiosk.Kiosk {
+		t.Errorf("kiosk = %q", got)
+	}
+}
@@ -0,0 +1,93 @@
+package projections
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/feedback"
+	"workshop/internal/domain/schedule"
+)
+
+// PendingRatingsAttendanceStore defines the attendance store interface needed to find classes to rate.
+type PendingRatingsAttendanceStore interface {
+	ListByMemberIDAndDateRange(ctx context.Context, memberID string, startDate string, endDate string) ([]attendance.Attendance, error)
+}
+
+// PendingRatingsFeedbackStore defines the feedback store interface needed to skip rated classes.
+type PendingRatingsFeedbackStore interface {
+	GetByAttendanceID(ctx context.Context, attendanceID string) (feedback.Rating, error)
+}
+
+// PendingRatingsScheduleStore defines the schedule store interface needed to name classes.
+type PendingRatingsScheduleStore interface {
+	GetByID(ctx context.Context, id string) (schedule.Schedule, error)
+}
+
+// PendingRatingsClassTypeStore defines the class type store interface needed to name classes.
+type PendingRatingsClassTypeStore interface {
+	GetByID(ctx context.Context, id string) (classtype.ClassType, error)
+}
+
+// GetPendingClassRatingsQuery carries input for the pending class ratings projection.
+type GetPendingClassRatingsQuery struct {
+	MemberID string
+	Now      time.Time
+}
+
+// GetPendingClassRatingsDeps holds dependencies for the pending class ratings projection.
+type GetPendingClassRatingsDeps struct {
+	AttendanceStore PendingRatingsAttendanceStore
+	FeedbackStore   PendingRatingsFeedbackStore
+	ScheduleStore   PendingRatingsScheduleStore
+	ClassTypeStore  PendingRatingsClassTypeStore
+}
+
+// PendingClassRating is a class the member can still rate.
+type PendingClassRating struct {
+	AttendanceID string    `json:"attendance_id"`
+	ClassName    string    `json:"class_name"`
+	ClassDate    string    `json:"class_date"`
+	StartTime    string    `json:"start_time"`
+	ClosesAt     time.Time `json:"closes_at"` // feedback.RatingWindow after check-in
+}
+
+// QueryGetPendingClassRatings lists the member's classes checked in to within
+// feedback.RatingWindow that they haven't rated yet, newest first.
+// PRE: MemberID is non-empty
+// POST: Returns the classes, or an empty slice; check-ins without a class are left out
+func QueryGetPendingClassRatings(ctx context.Context, query GetPendingClassRatingsQuery, deps GetPendingClassRatingsDeps) ([]PendingClassRating, error) {
+	from := query.Now.Add(-feedback.RatingWindow).Format("2006-01-02")
+	records, err := deps.AttendanceStore.ListByMemberIDAndDateRange(ctx, query.MemberID, from, query.Now.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	result := []PendingClassRating{}
+	for _, a := range records { // newest first
+		if a.ScheduleID == "" || !feedback.CanRate(a.CheckInTime, query.Now) {
+			continue
+		}
+		if _, err := deps.FeedbackStore.GetByAttendanceID(ctx, a.ID); err == nil {
+			continue
+		}
+		sched, err := deps.ScheduleStore.GetByID(ctx, a.ScheduleID)
+		if err != nil {
+			continue
+		}
+		view := PendingClassRating{
+			AttendanceID: a.ID,
+			ClassDate:    a.ClassDate,
+			StartTime:    sched.StartTime,
+			ClosesAt:     a.CheckInTime.Add(feedback.RatingWindow),
+		}
+		if view.ClassDate == "" {
+			view.ClassDate = a.CheckInTime.Format("2006-01-02")
+		}
+		if ct, err := deps.ClassTypeStore.GetByID(ctx, sched.ClassTypeID); err == nil {
+			view.ClassName = ct.Name
+		}
+		result = append(result, view)
+	}
+	return result, nil
+}
@@ -0,0 +1,101 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"workshop/internal/domain/personalgoal"
+)
+
+// PersonalGoalListStore defines the personal goal store interface needed by the goal feedback projections.
+type PersonalGoalListStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]personalgoal.PersonalGoal, error)
+}
+
+// GoalCheckInListStore defines the check-in store interface needed by the goal feedback projection.
+type GoalCheckInListStore interface {
+	ListByGoalID(ctx context.Context, goalID string) ([]personalgoal.CheckIn, error)
+}
+
+// GoalAnnotationListStore defines the annotation store interface needed by the goal feedback projection.
+type GoalAnnotationListStore interface {
+	ListByGoalID(ctx context.Context, goalID string) ([]personalgoal.Annotation, error)
+}
+
+// GetPersonalGoalFeedbackQuery carries input for the goal feedback projection.
+type GetPersonalGoalFeedbackQuery struct {
+	MemberID string
+	Now      time.Time
+}
+
+// GetPersonalGoalFeedbackDeps holds dependencies for the goal feedback projection.
+type GetPersonalGoalFeedbackDeps struct {
+	GoalStore       PersonalGoalListStore
+	CheckInStore    GoalCheckInListStore
+	AnnotationStore GoalAnnotationListStore
+}
+
+// PersonalGoalFeedback is one goal with its check-in history and coach annotations, newest first.
+type PersonalGoalFeedback struct {
+	Goal        personalgoal.PersonalGoal
+	CheckInDue  bool
+	CheckIns    []personalgoal.CheckIn
+	Annotations []personalgoal.Annotation
+}
+
+// QueryGetPersonalGoalFeedback returns a member's goals with their check-ins and coach annotations.
+// Progress is as stored; the personal goals worker keeps auto-tracked goals current.
+// PRE: query.MemberID is non-empty
+// POST: Returns every goal of the member, most recent period first
+func QueryGetPersonalGoalFeedback(ctx context.Context, query GetPersonalGoalFeedbackQuery, deps GetPersonalGoalFeedbackDeps) ([]PersonalGoalFeedback, error) {
+	if query.MemberID == "" {
+		return nil, fmt.Errorf("member_id is required")
+	}
+	goals, err := deps.GoalStore.ListByMemberID(ctx, query.MemberID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]PersonalGoalFeedback, 0, len(goals))
+	for _, g := range goals {
+		checkIns, err := deps.CheckInStore.ListByGoalID(ctx, g.ID)
+		if err != nil {
+			return nil, err
+		}
+		annotations, err := deps.AnnotationStore.ListByGoalID(ctx, g.ID)
+		if err != nil {
+			return nil, err
+		}
+		if checkIns == nil {
+			checkIns = []personalgoal.CheckIn{}
+		}
+		if annotations == nil {
+			annotations = []personalgoal.Annotation{}
+		}
+		result = append(result, PersonalGoalFeedback{
+			Goal:        g,
+			CheckInDue:  g.CheckInDue(query.Now),
+			CheckIns:    checkIns,
+			Annotations: annotations,
+		})
+	}
+	return result, nil
+}
+
+// QueryGetGoalCheckInsDue returns the member's goals awaiting a check-in at now.
+// PRE: memberID is non-empty
+// POST: Returns open goals whose check-in interval has elapsed, in store order
+func QueryGetGoalCheckInsDue(ctx context.Context, memberID string, now time.Time, store PersonalGoalListStore) ([]personalgoal.PersonalGoal, error) {
+	goals, err := store.ListByMemberID(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+	due := []personalgoal.PersonalGoal{}
+	for _, g := range goals {
+		if g.CheckInDue(now) {
+			due = append(due, g)
+		}
+	}
+	return due, nil
+}
@@ -0,0 +1,102 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/personalgoal"
+)
+
+type mockPGFGoalStore struct {
+	goals []personalgoal.PersonalGoal
+}
+
+// ListByMemberID implements PersonalGoalListStore for testing.
+// PRE: memberID is non-empty
+// POST: returns the member's goals
+func (m *mockPGFGoalStore) ListByMemberID(_ context.Context, memberID string) ([]personalgoal.PersonalGoal, error) {
+	var out []personalgoal.PersonalGoal
+	for _, g := range m.goals {
+		if g.MemberID == memberID {
+			out = append(out, g)
+		}
+	}
+	return out, nil
+}
+
+type mockPGFCheckInStore struct {
+	checkIns []personalgoal.CheckIn
+}
+
+// ListByGoalID implements GoalCheckInListStore for testing.
+// PRE: goalID is non-empty
+// POST: returns the goal's check-ins
+func (m *mockPGFCheckInStore) ListByGoalID(_ context.Context, goalID string) ([]personalgoal.CheckIn, error) {
+	var out []personalgoal.CheckIn
+	for _, c := range m.checkIns {
+		if c.GoalID == goalID {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+type mockPGFAnnotationStore struct {
+	annotations []personalgoal.Annotation
+}
+
+// ListByGoalID implements GoalAnnotationListStore for testing.
+// PRE: goalID is non-empty
+// POST: returns the goal's annotations
+func (m *mockPGFAnnotationStore) ListByGoalID(_ context.Context, goalID string) ([]personalgoal.Annotation, error) {
+	var out []personalgoal.Annotation
+	for _, a := range m.annotations {
+		if a.GoalID == goalID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+// TestQueryGetPersonalGoalFeedback verifies goals carry their own check-ins and annotations and flag due check-ins.
+func TestQueryGetPersonalGoalFeedback(t *testing.T) {
+	now := time.Date(2026, 4, 20, 9, 0, 0, 0, time.UTC)
+	april := func(id string, lastCheckIn time.Time) personalgoal.PersonalGoal {
+		return personalgoal.PersonalGoal{ID: id, MemberID: "m1", Title: id, Target: 10, Status: personalgoal.StatusActive,
+			StartDate: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC), LastCheckInAt: lastCheckIn}
+	}
+	store := &mockPGFGoalStore{goals: []personalgoal.PersonalGoal{
+		april("stale", time.Date(2026, 4, 8, 0, 0, 0, 0, time.UTC)),
+		april("fresh", time.Date(2026, 4, 18, 0, 0, 0, 0, time.UTC)),
+		{ID: "other", MemberID: "m2", Title: "other", StartDate: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)},
+	}}
+
+	result, err := QueryGetPersonalGoalFeedback(context.Background(), GetPersonalGoalFeedbackQuery{MemberID: "m1", Now: now}, GetPersonalGoalFeedbackDeps{
+		GoalStore:       store,
+		CheckInStore:    &mockPGFCheckInStore{checkIns: []personalgoal.CheckIn{{ID: "c1", GoalID: "fresh"}, {ID: "c2", GoalID: "other"}}},
+		AnnotationStore: &mockPGFAnnotationStore{annotations: []personalgoal.Annotation{{ID: "a1", GoalID: "stale", Content: "Keep going"}}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("expected m1's 2 goals, got %d", len(result))
+	}
+	stale, fresh := result[0], result[1]
+	if !stale.CheckInDue || len(stale.CheckIns) != 0 || len(stale.Annotations) != 1 {
+		t.Errorf("stale goal = %+v, want due with one annotation", stale)
+	}
+	if fresh.CheckInDue || len(fresh.CheckIns) != 1 || fresh.Annotations == nil {
+		t.Errorf("fresh goal = %+v, want not due with its own check-in and an empty annotation list", fresh)
+	}
+
+	due, err := QueryGetGoalCheckInsDue(context.Background(), "m1", now, store)
+	if err != nil || len(due) != 1 || due[0].ID != "stale" {
+		t.Errorf("due = %+v (%v), want only the stale goal", due, err)
+	}
+
+	if _, err := QueryGetPersonalGoalFeedback(context.Background(), GetPersonalGoalFeedbackQuery{}, GetPersonalGoalFeedbackDeps{}); err == nil {
+		t.Error("expected an error without a member")
+	}
+}
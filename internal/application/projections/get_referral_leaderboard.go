@@ -0,0 +1,129 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/referral"
+)
+
+// ReferralLeaderboardRecent is how many of the latest referrals the leaderboard lists.
+const ReferralLeaderboardRecent = 20
+
+// ReferralListStore defines the referral store interface needed by the leaderboard.
+type ReferralListStore interface {
+	List(ctx context.Context) ([]referral.Referral, error)
+}
+
+// ReferralMemberStore defines the member store interface needed to name referrers and referred people.
+type ReferralMemberStore interface {
+	GetByID(ctx context.Context, id string) (member.Member, error)
+}
+
+// GetReferralLeaderboardDeps holds dependencies for the referral leaderboard.
+type GetReferralLeaderboardDeps struct {
+	ReferralStore ReferralListStore
+	MemberStore   ReferralMemberStore
+}
+
+// ReferralLeader is one referrer's tally. Free-text referrers are grouped by name, ignoring case.
+type ReferralLeader struct {
+	ReferrerMemberID string // empty for a free-text referrer
+	ReferrerName     string
+	Referred         int
+	Converted        int // referred people who became active members
+	Credit           int // dollars of referral credit earned
+}
+
+// ReferralRow is one referral with the people involved named.
+type ReferralRow struct {
+	MemberID     string
+	MemberName   string
+	ReferrerName string
+	Source       string
+	CreatedAt    time.Time
+	ConvertedAt  time.Time
+	Reward       string
+}
+
+// ReferralLeaderboardResult carries the output of the referral leaderboard.
+type ReferralLeaderboardResult struct {
+	Referred  int
+	Converted int
+	Leaders   []ReferralLeader // most conversions first, then most referrals
+	Recent    []ReferralRow    // newest first, at most ReferralLeaderboardRecent
+}
+
+// QueryGetReferralLeaderboard ranks referrers by how many people they brought to the club
+// who went on to join, and lists the latest referrals. Members who have since been removed
+// are shown by ID.
+// PRE: none
+// POST: Returns the leaderboard; nothing is written
+func QueryGetReferralLeaderboard(ctx context.Context, deps GetReferralLeaderboardDeps) (ReferralLeaderboardResult, error) {
+	result := ReferralLeaderboardResult{Leaders: []ReferralLeader{}, Recent: []ReferralRow{}}
+	referrals, err := deps.ReferralStore.List(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	names := map[string]string{}
+	nameOf := func(memberID string) string {
+		if n, ok := names[memberID]; ok {
+			return n
+		}
+		n := memberID
+		if m, err := deps.MemberStore.GetByID(ctx, memberID); err == nil {
+			n = m.Name
+		}
+		names[memberID] = n
+		return n
+	}
+
+	leaders := map[string]*ReferralLeader{}
+	for _, r := range referrals {
+		key, name := "m:"+r.ReferrerMemberID, r.ReferrerName
+		if r.ReferrerMemberID != "" {
+			name = nameOf(r.ReferrerMemberID)
+		} else {
+			key = "n:" + strings.ToLower(r.ReferrerName)
+		}
+		l, ok := leaders[key]
+		if !ok {
+			l = &ReferralLeader{ReferrerMemberID: r.ReferrerMemberID, ReferrerName: name}
+			leaders[key] = l
+		}
+		l.Referred++
+		result.Referred++
+		if r.IsConverted() {
+			l.Converted++
+			result.Converted++
+		}
+		if r.Reward == referral.RewardCredit {
+			l.Credit += r.CreditAmount
+		}
+		if len(result.Recent) < ReferralLeaderboardRecent {
+			result.Recent = append(result.Recent, ReferralRow{
+				MemberID: r.MemberID, MemberName: nameOf(r.MemberID), ReferrerName: name,
+				Source: r.Source, CreatedAt: r.CreatedAt, ConvertedAt: r.ConvertedAt, Reward: r.Reward,
+			})
+		}
+	}
+
+	for _, l := range leaders {
+		result.Leaders = append(result.Leaders, *l)
+	}
+	sort.Slice(result.Leaders, func(i, j int) bool {
+		a, b := result.Leaders[i], result.Leaders[j]
+		if a.Converted != b.Converted {
+			return a.Converted > b.Converted
+		}
+		if a.Referred != b.Referred {
+			return a.Referred > b.Referred
+		}
+		return a.ReferrerName < b.ReferrerName
+	})
+	return result, nil
+}
@@ -0,0 +1,77 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/referral"
+)
+
+type mockReferralListStore struct {
+	referrals []referral.Referral
+}
+
+// List returns the referrals in the order given.
+// PRE: none
+// POST: Returns the referrals
+func (m *mockReferralListStore) List(_ context.Context) ([]referral.Referral, error) {
+	return m.referrals, nil
+}
+
+type mockReferralMemberStore struct {
+	members map[string]member.Member
+}
+
+// GetByID returns the member or an error.
+// PRE: none
+// POST: Returns the member or an error if not found
+func (m *mockReferralMemberStore) GetByID(_ context.Context, id string) (member.Member, error) {
+	v, ok := m.members[id]
+	if !ok {
+		return member.Member{}, errors.New("not found")
+	}
+	return v, nil
+}
+
+// TestQueryGetReferralLeaderboard verifies referrers are ranked by conversions then referrals,
+// free-text names are grouped ignoring case and credit is totalled.
+func TestQueryGetReferralLeaderboard(t *testing.T) {
+	day := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	deps := GetReferralLeaderboardDeps{
+		ReferralStore: &mockReferralListStore{referrals: []referral.Referral{
+			{MemberID: "m4", ReferrerName: "aunty mere", Source: referral.SourceGuestCheckIn, CreatedAt: day},
+			{MemberID: "m3", ReferrerName: "Aunty Mere", Source: referral.SourceGuestCheckIn, CreatedAt: day},
+			{MemberID: "m2", ReferrerMemberID: "m1", Source: referral.SourceRegistration, CreatedAt: day, ConvertedAt: day, Reward: referral.RewardCredit, CreditAmount: 20},
+			{MemberID: "m5", ReferrerMemberID: "gone", Source: referral.SourceRegistration, CreatedAt: day},
+		}},
+		MemberStore: &mockReferralMemberStore{members: map[string]member.Member{
+			"m1": {ID: "m1", Name: "Hemi"}, "m2": {ID: "m2", Name: "Ana"},
+		}},
+	}
+	got, err := QueryGetReferralLeaderboard(context.Background(), deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Referred != 4 || got.Converted != 1 || len(got.Recent) != 4 {
+		t.Errorf("totals = %d referred, %d converted, %d recent; want 4, 1, 4", got.Referred, got.Converted, len(got.Recent))
+	}
+	want := []ReferralLeader{
+		{ReferrerMemberID: "m1", ReferrerName: "Hemi", Referred: 1, Converted: 1, Credit: 20},
+		{ReferrerName: "aunty mere", Referred: 2},
+		{ReferrerMemberID: "gone", ReferrerName: "gone", Referred: 1},
+	}
+	if len(got.Leaders) != len(want) {
+		t.Fatalf("leaders = %+v, want %+v", got.Leaders, want)
+	}
+	for i := range want {
+		if got.Leaders[i] != want[i] {
+			t.Errorf("leader %d = %+v, want %+v", i, got.Leaders[i], want[i])
+		}
+	}
+	if r := got.Recent[2]; r.MemberName != "Ana" || r.ReferrerName != "Hemi" {
+		t.Errorf("recent row = %+v, want Ana referred by Hemi", r)
+	}
+}
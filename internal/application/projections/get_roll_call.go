@@ -0,0 +1,165 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	domainClassType "workshop/internal/domain/classtype"
+	domainMember "workshop/internal/domain/member"
+	domainSchedule "workshop/internal/domain/schedule"
+)
+
+// Roll call roster rules.
+const (
+	// RollCallLookbackWeeks is how far back attendance counts towards being a regular.
+	RollCallLookbackWeeks = 8
+	// RollCallMinSessions is how many of those sessions make a member a regular.
+	RollCallMinSessions = 2
+)
+
+// RollCallScheduleStore defines the schedule store interface needed by this projection.
+type RollCallScheduleStore interface {
+	GetByID(ctx context.Context, id string) (domainSchedule.Schedule, error)
+}
+
+// RollCallClassTypeStore defines the class type store interface needed by this projection.
+type RollCallClassTypeStore interface {
+	GetByID(ctx context.Context, id string) (domainClassType.ClassType, error)
+}
+
+// RollCallAttendanceStore defines the attendance store interface needed by this projection.
+type RollCallAttendanceStore interface {
+	CountByScheduleIDAndDateRange(ctx context.Context, scheduleID string, startDate string, endDate string) (map[string]int, error)
+	ListDistinctMemberIDsByScheduleAndDate(ctx context.Context, scheduleID string, classDate string) ([]string, error)
+}
+
+// RollCallMemberStore defines the member store interface needed by this projection.
+type RollCallMemberStore interface {
+	GetByID(ctx context.Context, id string) (domainMember.Member, error)
+}
+
+// GetRollCallDeps holds dependencies for the roll call projection.
+type GetRollCallDeps struct {
+	ScheduleStore      RollCallScheduleStore
+	ClassTypeStore     RollCallClassTypeStore // optional: nil skips class name
+	AttendanceStore    RollCallAttendanceStore
+	MemberStore        RollCallMemberStore
+	GradingRecordStore GradingRecordStore // optional: nil skips belt lookup
+}
+
+// GetRollCallQuery selects one class session.
+type GetRollCallQuery struct {
+	ScheduleID string
+	ClassDate  string // YYYY-MM-DD
+}
+
+// RollCallEntry is one member on a roll call.
+type RollCallEntry struct {
+	MemberID   string
+	MemberName string
+	Program    string
+	Belt       string // latest promotion; empty if never graded
+	Stripe     int
+	Sessions   int  // sessions of this class in the lookback window
+	Regular    bool // Sessions >= RollCallMinSessions
+	Present    bool // already has attendance for this session
+}
+
+// GetRollCallResult carries the roster for one class session.
+type GetRollCallResult struct {
+	ScheduleID string
+	ClassName  string
+	ClassDate  string
+	StartTime  string
+	EndTime    string
+	Present    int
+	Entries    []RollCallEntry
+}
+
+// QueryGetRollCall lists the members a coach should expect at a class session: the class's
+// regulars over the previous RollCallLookbackWeeks, plus anyone already checked in.
+// Archived and frozen members are left off unless they are already marked present.
+// Entries are ordered by name so the list reads like a register.
+// PRE: query.ScheduleID is non-empty; query.ClassDate is YYYY-MM-DD
+// POST: Returns the roster, or the schedule store's error if the class does not exist
+func QueryGetRollCall(ctx context.Context, query GetRollCallQuery, deps GetRollCallDeps) (GetRollCallResult, error) {
+	sched, err := deps.ScheduleStore.GetByID(ctx, query.ScheduleID)
+	if err != nil {
+		return GetRollCallResult{}, err
+	}
+	day, err := time.Parse("2006-01-02", query.ClassDate)
+	if err != nil {
+		return GetRollCallResult{}, err
+	}
+
+	result := GetRollCallResult{
+		ScheduleID: sched.ID,
+		ClassName:  sched.ClassTypeID,
+		ClassDate:  query.ClassDate,
+		StartTime:  sched.StartTime,
+		EndTime:    sched.EndTime,
+		Entries:    []RollCallEntry{},
+	}
+	if deps.ClassTypeStore != nil {
+		if ct, err := deps.ClassTypeStore.GetByID(ctx, sched.ClassTypeID); err == nil {
+			result.ClassName = ct.Name
+		}
+	}
+
+	counts, err := deps.AttendanceStore.CountByScheduleIDAndDateRange(ctx, sched.ID,
+		day.AddDate(0, 0, -7*RollCallLookbackWeeks).Format("2006-01-02"),
+		day.AddDate(0, 0, -1).Format("2006-01-02"))
+	if err != nil {
+		return GetRollCallResult{}, err
+	}
+	presentIDs, err := deps.AttendanceStore.ListDistinctMemberIDsByScheduleAndDate(ctx, sched.ID, query.ClassDate)
+	if err != nil {
+		return GetRollCallResult{}, err
+	}
+	present := make(map[string]bool, len(presentIDs))
+	for _, id := range presentIDs {
+		present[id] = true
+	}
+
+	candidates := make([]string, 0, len(counts)+len(presentIDs))
+	for id, n := range counts {
+		if n >= RollCallMinSessions && !present[id] {
+			candidates = append(candidates, id)
+		}
+	}
+	candidates = append(candidates, presentIDs...)
+
+	for _, id := range candidates {
+		m, err := deps.MemberStore.GetByID(ctx, id)
+		if err != nil {
+			continue
+		}
+		if !m.CanCheckIn() && !present[id] {
+			continue
+		}
+		entry := RollCallEntry{
+			MemberID:   m.ID,
+			MemberName: m.Name,
+			Program:    m.Program,
+			Sessions:   counts[id],
+			Regular:    counts[id] >= RollCallMinSessions,
+			Present:    present[id],
+		}
+		if deps.GradingRecordStore != nil {
+			if records, err := deps.GradingRecordStore.ListByMemberID(ctx, m.ID); err == nil {
+				entry.Belt, entry.Stripe = latestBeltAndStripe(records)
+			}
+		}
+		if entry.Present {
+			result.Present++
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	sort.Slice(result.Entries, func(i, j int) bool {
+		return strings.ToLower(result.Entries[i].MemberName) < strings.ToLower(result.Entries[j].MemberName)
+	})
+	return result, nil
+}
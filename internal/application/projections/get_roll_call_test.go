@@ -0,0 +1,108 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/schedule"
+)
+
+type mockRCScheduleStore struct{}
+
+// GetByID implements RollCallScheduleStore.
+// PRE: id is non-empty
+// POST: returns a Monday class for "s1", otherwise an error
+func (m *mockRCScheduleStore) GetByID(_ context.Context, id string) (schedule.Schedule, error) {
+	if id != "s1" {
+		return schedule.Schedule{}, errors.New("not found")
+	}
+	return schedule.Schedule{ID: id, ClassTypeID: "ct1", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:30"}, nil
+}
+
+type mockRCAttendanceStore struct {
+	counts  map[string]int
+	present []string
+	ranges  [][2]string
+}
+
+// CountByScheduleIDAndDateRange implements RollCallAttendanceStore.
+// PRE: scheduleID is non-empty
+// POST: records the range and returns the seeded counts
+func (m *mockRCAttendanceStore) CountByScheduleIDAndDateRange(_ context.Context, _ string, startDate string, endDate string) (map[string]int, error) {
+	m.ranges = append(m.ranges, [2]string{startDate, endDate})
+	return m.counts, nil
+}
+
+// ListDistinctMemberIDsByScheduleAndDate implements RollCallAttendanceStore.
+// PRE: scheduleID and classDate are non-empty
+// POST: returns the seeded present members
+func (m *mockRCAttendanceStore) ListDistinctMemberIDsByScheduleAndDate(_ context.Context, _ string, _ string) ([]string, error) {
+	return m.present, nil
+}
+
+type mockRCMemberStore struct{}
+
+// GetByID implements RollCallMemberStore.
+// PRE: id is non-empty
+// POST: returns a member for m1..m5 (m4 archived), otherwise an error
+func (m *mockRCMemberStore) GetByID(_ context.Context, id string) (member.Member, error) {
+	names := map[string]string{"m1": "yuki", "m2": "Marcus", "m3": "Ana", "m4": "Old Timer", "m5": "Drop In"}
+	name, ok := names[id]
+	if !ok {
+		return member.Member{}, errors.New("not found")
+	}
+	status := member.StatusActive
+	if id == "m4" {
+		status = member.StatusArchived
+	}
+	return member.Member{ID: id, Name: name, Program: member.ProgramAdults, Status: status}, nil
+}
+
+// TestQueryGetRollCall verifies regulars and present members are listed, one-off visitors are not.
+func TestQueryGetRollCall(t *testing.T) {
+	attendance := &mockRCAttendanceStore{
+		counts:  map[string]int{"m1": 5, "m2": 1, "m3": 2, "m4": 6, "gone": 4},
+		present: []string{"m1", "m5"},
+	}
+	result, err := QueryGetRollCall(context.Background(), GetRollCallQuery{ScheduleID: "s1", ClassDate: "2026-03-02"}, GetRollCallDeps{
+		ScheduleStore:   &mockRCScheduleStore{},
+		AttendanceStore: attendance,
+		MemberStore:     &mockRCMemberStore{},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		id      string
+		regular bool
+		present bool
+	}{
+		{"m3", true, false},
+		{"m5", false, true},
+		{"m1", true, true},
+	}
+	if len(result.Entries) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(result.Entries), len(want), result.Entries)
+	}
+	for i, w := range want {
+		got := result.Entries[i]
+		if got.MemberID != w.id || got.Regular != w.regular || got.Present != w.present {
+			t.Errorf("entry %d = %s regular=%v present=%v, want %s regular=%v present=%v", i, got.MemberID, got.Regular, got.Present, w.id, w.regular, w.present)
+		}
+	}
+	if result.Present != 2 || result.ClassName != "ct1" {
+		t.Errorf("present = %d, class = %q; want 2 and the class type ID", result.Present, result.ClassName)
+	}
+	if r := attendance.ranges[0]; r[0] != "2026-01-05" || r[1] != "2026-03-01" {
+		t.Errorf("lookback range = %v, want 2026-01-05..2026-03-01", r)
+	}
+
+	if _, err := QueryGetRollCall(context.Background(), GetRollCallQuery{ScheduleID: "missing", ClassDate: "2026-03-02"}, GetRollCallDeps{
+		ScheduleStore: &mockRCScheduleStore{}, AttendanceStore: attendance, MemberStore: &mockRCMemberStore{},
+	}); err == nil {
+		t.Error("expected an error for an unknown class")
+	}
+}
@@ -0,0 +1,42 @@
+package projections
+
+import (
+	"context"
+
+	"workshop/internal/domain/rotor"
+)
+
+// RotorDocumentStore defines the rotor store interface needed to export a rotor.
+type RotorDocumentStore interface {
+	GetRotor(ctx context.Context, id string) (rotor.Rotor, error)
+	ListThemesByRotor(ctx context.Context, rotorID string) ([]rotor.RotorTheme, error)
+	ListTopicsByTheme(ctx context.Context, rotorThemeID string) ([]rotor.Topic, error)
+}
+
+// GetRotorDocumentDeps holds dependencies for the projection.
+type GetRotorDocumentDeps struct {
+	RotorStore RotorDocumentStore
+}
+
+// QueryGetRotorDocument builds the portable document for a rotor.
+// PRE: rotorID is non-empty
+// POST: Returns the rotor's full theme/topic structure, or the store error if the rotor does not exist
+func QueryGetRotorDocument(ctx context.Context, rotorID string, deps GetRotorDocumentDeps) (rotor.Document, error) {
+	r, err := deps.RotorStore.GetRotor(ctx, rotorID)
+	if err != nil {
+		return rotor.Document{}, err
+	}
+	themes, err := deps.RotorStore.ListThemesByRotor(ctx, rotorID)
+	if err != nil {
+		return rotor.Document{}, err
+	}
+	topicsByTheme := make(map[string][]rotor.Topic, len(themes))
+	for _, th := range themes {
+		topics, err := deps.RotorStore.ListTopicsByTheme(ctx, th.ID)
+		if err != nil {
+			return rotor.Document{}, err
+		}
+		topicsByTheme[th.ID] = topics
+	}
+	return rotor.NewDocument(r, themes, topicsByTheme), nil
+}
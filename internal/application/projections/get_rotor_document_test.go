@@ -0,0 +1,67 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"workshop/internal/domain/rotor"
+)
+
+type mockRDRotorStore struct{}
+
+// GetRotor implements RotorDocumentStore.
+// PRE: id is non-empty
+// POST: returns r1 or an error
+func (m *mockRDRotorStore) GetRotor(_ context.Context, id string) (rotor.Rotor, error) {
+	if id != "r1" {
+		return rotor.Rotor{}, errors.New("not found")
+	}
+	return rotor.Rotor{ID: "r1", ClassTypeID: "ct1", Name: "Adults v2", Version: 2}, nil
+}
+
+// ListThemesByRotor implements RotorDocumentStore.
+// PRE: rotorID is non-empty
+// POST: returns two themes out of position order
+func (m *mockRDRotorStore) ListThemesByRotor(_ context.Context, _ string) ([]rotor.RotorTheme, error) {
+	return []rotor.RotorTheme{
+		{ID: "th2", RotorID: "r1", Name: "Guard", Position: 1},
+		{ID: "th1", RotorID: "r1", Name: "Standing", Position: 0},
+	}, nil
+}
+
+// ListTopicsByTheme implements RotorDocumentStore.
+// PRE: rotorThemeID is non-empty
+// POST: returns one topic for th1 and none otherwise
+func (m *mockRDRotorStore) ListTopicsByTheme(_ context.Context, themeID string) ([]rotor.Topic, error) {
+	if themeID != "th1" {
+		return nil, nil
+	}
+	return []rotor.Topic{{ID: "t1", RotorThemeID: "th1", Name: "Single Leg", DurationWeeks: 2}}, nil
+}
+
+// TestQueryGetRotorDocument_ExportsStructure verifies themes are ordered and topics carried without IDs.
+func TestQueryGetRotorDocument_ExportsStructure(t *testing.T) {
+	deps := GetRotorDocumentDeps{RotorStore: &mockRDRotorStore{}}
+
+	doc, err := QueryGetRotorDocument(context.Background(), "r1", deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc.FormatVersion != rotor.DocumentFormatVersion || doc.Name != "Adults v2" || len(doc.Themes) != 2 {
+		t.Fatalf("unexpected document: %+v", doc)
+	}
+	if doc.Themes[0].Name != "Standing" || len(doc.Themes[0].Topics) != 1 || doc.Themes[0].Topics[0].DurationWeeks != 2 {
+		t.Errorf("unexpected first theme: %+v", doc.Themes[0])
+	}
+	if doc.Themes[1].Topics == nil {
+		t.Error("expected empty topic list, got nil")
+	}
+	if err := doc.Validate(); err != nil {
+		t.Errorf("exported document should validate: %v", err)
+	}
+
+	if _, err := QueryGetRotorDocument(context.Background(), "missing", deps); err == nil {
+		t.Error("expected error for missing rotor")
+	}
+}
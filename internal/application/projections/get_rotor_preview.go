@@ -0,0 +1,241 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"workshop/internal/domain/holiday"
+	"workshop/internal/domain/rotor"
+)
+
+// Rotor preview limits on how many upcoming topics are projected per theme.
+const (
+	RotorPreviewDefaultTopics = 4
+	RotorPreviewMaxTopics     = 12
+)
+
+// RotorPreviewRotorStore defines the rotor store interface needed by the rotor preview projection.
+type RotorPreviewRotorStore interface {
+	GetActiveRotor(ctx context.Context, classTypeID string) (rotor.Rotor, error)
+	ListThemesByRotor(ctx context.Context, rotorID string) ([]rotor.RotorTheme, error)
+	ListTopicsByTheme(ctx context.Context, rotorThemeID string) ([]rotor.Topic, error)
+	GetActiveScheduleForTheme(ctx context.Context, rotorThemeID string) (rotor.TopicSchedule, error)
+	ListSchedulesByTheme(ctx context.Context, rotorThemeID string) ([]rotor.TopicSchedule, error)
+	CountVotesForTopic(ctx context.Context, topicID string, now time.Time) (int, error)
+}
+
+// RotorPreviewHolidayStore defines the holiday store interface needed by the rotor preview projection.
+type RotorPreviewHolidayStore interface {
+	List(ctx context.Context) ([]holiday.Holiday, error)
+}
+
+// GetRotorPreviewQuery carries input for the rotor preview projection.
+type GetRotorPreviewQuery struct {
+	ClassTypeID string
+	Topics      int       // upcoming topics per theme; 0 means RotorPreviewDefaultTopics
+	Gate        BeltGate  // viewer's belt; members only see what the preview toggle allows
+	Now         time.Time // votes that have expired by now are not counted
+}
+
+// GetRotorPreviewDeps holds dependencies for the rotor preview projection.
+type GetRotorPreviewDeps struct {
+	RotorStore   RotorPreviewRotorStore
+	HolidayStore RotorPreviewHolidayStore
+}
+
+// RotorPreviewResult carries the output of the rotor preview projection.
+type RotorPreviewResult struct {
+	ClassTypeID string              `json:"class_type_id"`
+	RotorID     string              `json:"rotor_id"` // empty when the class type has no active rotor
+	RotorName   string              `json:"rotor_name"`
+	PreviewOn   bool                `json:"preview_on"`
+	Themes      []RotorPreviewTheme `json:"themes"`
+}
+
+// RotorPreviewTheme is one theme's running topic followed by its projected queue.
+type RotorPreviewTheme struct {
+	ThemeID   string              `json:"theme_id"`
+	ThemeName string              `json:"theme_name"`
+	Hidden    bool                `json:"hidden"`
+	Topics    []RotorPreviewTopic `json:"topics"`
+}
+
+// RotorPreviewTopic is a topic with the dates it is running or projected to run.
+// EndsOn is the day the following topic takes over, matching the schedule's end date.
+type RotorPreviewTopic struct {
+	TopicID       string `json:"topic_id"`
+	TopicName     string `json:"topic_name"`
+	Description   string `json:"description"`
+	DurationWeeks int    `json:"duration_weeks"`
+	MinBelt       string `json:"min_belt"`
+	Locked        bool   `json:"locked"`
+	Current       bool   `json:"current"`   // running now; later entries are projections
+	Bumped        bool   `json:"bumped"`    // projected ahead of the queue on current votes
+	StartsOn      string `json:"starts_on"` // YYYY-MM-DD
+	EndsOn        string `json:"ends_on"`   // YYYY-MM-DD; empty for an open-ended run
+}
+
+// QueryGetRotorPreview projects when upcoming topics will run in each theme of a class type's
+// active rotor. Starting from the running topic's end date, it replays auto-advance: current
+// votes can bump a topic once, displaced topics resume, then the queue continues in order.
+// Each topic runs for its duration, and weeks closed for holidays do not count towards it.
+// Members see projections only when the rotor has preview on, and never for hidden themes.
+// PRE: query.ClassTypeID is non-empty; deps are valid
+// POST: returns an empty result (no RotorID) if the class type has no active rotor
+func QueryGetRotorPreview(ctx context.Context, query GetRotorPreviewQuery, deps GetRotorPreviewDeps) (RotorPreviewResult, error) {
+	result := RotorPreviewResult{ClassTypeID: query.ClassTypeID, Themes: []RotorPreviewTheme{}}
+	limit := query.Topics
+	if limit <= 0 {
+		limit = RotorPreviewDefaultTopics
+	}
+	if limit > RotorPreviewMaxTopics {
+		limit = RotorPreviewMaxTopics
+	}
+
+	active, err := deps.RotorStore.GetActiveRotor(ctx, query.ClassTypeID)
+	if err != nil {
+		return result, nil // no active rotor for this class type
+	}
+	result.RotorID = active.ID
+	result.RotorName = active.Name
+	result.PreviewOn = active.PreviewOn
+
+	holidays, err := deps.HolidayStore.List(ctx)
+	if err != nil {
+		return result, fmt.Errorf("list holidays: %w", err)
+	}
+	closed := func(day time.Time) bool {
+		for i := range holidays {
+			if holidays[i].Contains(day) {
+				return true
+			}
+		}
+		return false
+	}
+
+	themes, err := deps.RotorStore.ListThemesByRotor(ctx, active.ID)
+	if err != nil {
+		return result, err
+	}
+	sort.SliceStable(themes, func(i, j int) bool { return themes[i].Position < themes[j].Position })
+	for _, rt := range themes {
+		upcoming := limit
+		if !query.Gate.Staff && (!active.PreviewOn || rt.Hidden) {
+			upcoming = 0
+		}
+		view, err := rotorPreviewTheme(ctx, rt, upcoming, query.Gate, query.Now, closed, deps.RotorStore)
+		if err != nil {
+			return result, err
+		}
+		if len(view.Topics) == 0 && rt.Hidden && !query.Gate.Staff {
+			continue
+		}
+		result.Themes = append(result.Themes, view)
+	}
+	return result, nil
+}
+
+// rotorPreviewTheme lists a theme's running topic and projects up to upcoming topics after it.
+// An idle theme has nothing to project from, and an open-ended run has no date to project from.
+func rotorPreviewTheme(ctx context.Context, rt rotor.RotorTheme, upcoming int, gate BeltGate, now time.Time, closed func(time.Time) bool, store RotorPreviewRotorStore) (RotorPreviewTheme, error) {
+	view := RotorPreviewTheme{ThemeID: rt.ID, ThemeName: rt.Name, Hidden: rt.Hidden, Topics: []RotorPreviewTopic{}}
+	sched, err := store.GetActiveScheduleForTheme(ctx, rt.ID)
+	if err != nil {
+		return view, nil // nothing running in this theme
+	}
+	topics, err := store.ListTopicsByTheme(ctx, rt.ID)
+	if err != nil {
+		return view, err
+	}
+	sort.SliceStable(topics, func(i, j int) bool { return topics[i].Position < topics[j].Position })
+	byID := make(map[string]rotor.Topic, len(topics))
+	for _, tp := range topics {
+		byID[tp.ID] = tp
+	}
+	running, ok := byID[sched.TopicID]
+	if !ok {
+		return view, nil
+	}
+	view.Topics = append(view.Topics, rotorPreviewTopic(running, sched, true, gate))
+	if upcoming == 0 || sched.EndDate.IsZero() {
+		return view, nil
+	}
+
+	history, err := store.ListSchedulesByTheme(ctx, rt.ID)
+	if err != nil {
+		return view, err
+	}
+	votes := make(map[string]int, len(topics))
+	for _, tp := range topics {
+		n, err := store.CountVotesForTopic(ctx, tp.ID, now)
+		if err != nil {
+			return view, err
+		}
+		votes[tp.ID] = n
+	}
+
+	// Replay auto-advance on a copy of the history, one projected run at a time
+	current := rotorPreviewIndex(history, sched.ID)
+	if current < 0 {
+		history = append(history, sched)
+		current = len(history) - 1
+	}
+	for i := 0; i < upcoming; i++ {
+		ending := &history[current]
+		plan := rotor.PlanAdvance(topics, history, votes, ending.TopicID)
+		if plan.Topic == nil {
+			break
+		}
+		ending.Status = rotor.ScheduleStatusCompleted
+		next := rotor.TopicSchedule{ID: fmt.Sprintf("projected-%d", i), TopicID: plan.Topic.ID, RotorThemeID: rt.ID, Bumped: plan.Bumped}
+		if plan.Resume != nil {
+			next = *plan.Resume
+		}
+		next.Status = rotor.ScheduleStatusActive
+		next.StartDate = ending.EndDate
+		next.EndDate = rotor.ProjectEnd(next.StartDate, plan.Topic.DurationWeeks, closed)
+		if plan.Bumped {
+			votes[plan.Topic.ID] = 0
+		}
+		if current = rotorPreviewIndex(history, next.ID); current >= 0 {
+			history[current] = next
+		} else {
+			history = append(history, next)
+			current = len(history) - 1
+		}
+		view.Topics = append(view.Topics, rotorPreviewTopic(*plan.Topic, next, false, gate))
+	}
+	return view, nil
+}
+
+// rotorPreviewIndex returns the index of the schedule with the given ID, or -1.
+func rotorPreviewIndex(history []rotor.TopicSchedule, id string) int {
+	for i := range history {
+		if history[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// rotorPreviewTopic gates a topic for the viewer and dates it from its schedule.
+func rotorPreviewTopic(tp rotor.Topic, sched rotor.TopicSchedule, current bool, gate BeltGate) RotorPreviewTopic {
+	gated := GateTopic(tp, gate)
+	view := RotorPreviewTopic{
+		TopicID:       tp.ID,
+		TopicName:     tp.Name,
+		Description:   gated.Description,
+		DurationWeeks: tp.DurationWeeks,
+		MinBelt:       tp.MinBelt,
+		Locked:        gated.Locked,
+		Current:       current,
+		Bumped:        sched.Bumped && !current,
+		StartsOn:      sched.StartDate.Format("2006-01-02"),
+	}
+	if !sched.EndDate.IsZero() {
+		view.EndsOn = sched.EndDate.Format("2006-01-02")
+	}
+	return view
+}
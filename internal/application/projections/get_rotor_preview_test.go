@@ -0,0 +1,167 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/holiday"
+	"workshop/internal/domain/rotor"
+)
+
+// mockRotorPreviewStore adds schedule history to mockCurriculumRotorStore.
+type mockRotorPreviewStore struct {
+	mockCurriculumRotorStore
+	history map[string][]rotor.TopicSchedule // key: rotorThemeID
+}
+
+// ListSchedulesByTheme implements RotorPreviewRotorStore.
+// PRE: rotorThemeID is non-empty
+// POST: returns the theme's schedules
+func (m *mockRotorPreviewStore) ListSchedulesByTheme(_ context.Context, rotorThemeID string) ([]rotor.TopicSchedule, error) {
+	return m.history[rotorThemeID], nil
+}
+
+// mockRotorPreviewHolidayStore implements RotorPreviewHolidayStore for testing.
+type mockRotorPreviewHolidayStore struct {
+	holidays []holiday.Holiday
+}
+
+// List implements RotorPreviewHolidayStore.
+// PRE: none
+// POST: returns stored holidays
+func (m *mockRotorPreviewHolidayStore) List(_ context.Context) ([]holiday.Holiday, error) {
+	return m.holidays, nil
+}
+
+// newRotorPreviewStore returns a rotor with a running theme, an idle hidden theme and a running hidden theme.
+// Topic C has enough votes to be bumped ahead of the queue.
+func newRotorPreviewStore(previewOn bool) *mockRotorPreviewStore {
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 0, 0, 0, 0, time.UTC) }
+	running := rotor.TopicSchedule{ID: "s1", TopicID: "t1", RotorThemeID: "th1", StartDate: day(3, 2), EndDate: day(3, 9), Status: rotor.ScheduleStatusActive}
+	secret := rotor.TopicSchedule{ID: "s2", TopicID: "t4", RotorThemeID: "th3", StartDate: day(3, 2), EndDate: day(3, 9), Status: rotor.ScheduleStatusActive}
+	return &mockRotorPreviewStore{
+		mockCurriculumRotorStore: mockCurriculumRotorStore{
+			activeRotors: map[string]rotor.Rotor{"ct1": {ID: "r1", Name: "Term 1", PreviewOn: previewOn}},
+			themes: map[string][]rotor.RotorTheme{"r1": {
+				{ID: "th1", Name: "Guard", Position: 0},
+				{ID: "th2", Name: "Surprise", Position: 1, Hidden: true},
+				{ID: "th3", Name: "Secret", Position: 2, Hidden: true},
+			}},
+			topics: map[string][]rotor.Topic{
+				"th1": {
+					{ID: "t1", Name: "Topic A", Position: 0, DurationWeeks: 1},
+					{ID: "t2", Name: "Topic B", Position: 1, DurationWeeks: 2, MinBelt: "blue"},
+					{ID: "t3", Name: "Topic C", Position: 2, DurationWeeks: 1},
+				},
+				"th2": {{ID: "t5", Name: "Topic E", DurationWeeks: 1}},
+				"th3": {{ID: "t4", Name: "Topic D", DurationWeeks: 1}, {ID: "t6", Name: "Topic F", Position: 1, DurationWeeks: 1}},
+			},
+			schedules: map[string]rotor.TopicSchedule{"th1": running, "th3": secret},
+			votes:     map[string]int{"t3": rotor.MinVotesForAutoBump},
+		},
+		history: map[string][]rotor.TopicSchedule{"th1": {running}, "th3": {secret}},
+	}
+}
+
+// TestQueryGetRotorPreview verifies projected dates follow votes, the queue, durations and holiday breaks,
+// and that members only see projections the preview toggle allows.
+func TestQueryGetRotorPreview(t *testing.T) {
+	breakWeek := holiday.Holiday{ID: "h1", Name: "Mid-term break", StartDate: time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 3, 22, 0, 0, 0, 0, time.UTC)}
+	type dated struct{ topic, starts, ends string }
+	tests := []struct {
+		name      string
+		previewOn bool
+		gate      BeltGate
+		want      map[string][]dated // theme ID -> topics; missing themes are not shown
+	}{
+		{
+			name:      "staff see projections with preview off",
+			previewOn: false,
+			gate:      BeltGate{Staff: true},
+			want: map[string][]dated{
+				"th1": {{"t1", "2026-03-02", "2026-03-09"}, {"t3", "2026-03-09", "2026-03-16"}, {"t2", "2026-03-16", "2026-04-06"}, {"t3", "2026-04-06", "2026-04-13"}, {"t1", "2026-04-13", "2026-04-20"}},
+				"th2": {},
+				"th3": {{"t4", "2026-03-02", "2026-03-09"}, {"t6", "2026-03-09", "2026-03-16"}, {"t4", "2026-03-16", "2026-03-30"}, {"t6", "2026-03-30", "2026-04-06"}, {"t4", "2026-04-06", "2026-04-13"}},
+			},
+		},
+		{
+			name:      "members see running topics only with preview off",
+			previewOn: false,
+			gate:      BeltGate{Program: "adults"},
+			want: map[string][]dated{
+				"th1": {{"t1", "2026-03-02", "2026-03-09"}},
+				"th3": {{"t4", "2026-03-02", "2026-03-09"}},
+			},
+		},
+		{
+			name:      "members see projections with preview on except in hidden themes",
+			previewOn: true,
+			gate:      BeltGate{Program: "adults"},
+			want: map[string][]dated{
+				"th1": {{"t1", "2026-03-02", "2026-03-09"}, {"t3", "2026-03-09", "2026-03-16"}, {"t2", "2026-03-16", "2026-04-06"}, {"t3", "2026-04-06", "2026-04-13"}, {"t1", "2026-04-13", "2026-04-20"}},
+				"th3": {{"t4", "2026-03-02", "2026-03-09"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := GetRotorPreviewDeps{
+				RotorStore:   newRotorPreviewStore(tt.previewOn),
+				HolidayStore: &mockRotorPreviewHolidayStore{holidays: []holiday.Holiday{breakWeek}},
+			}
+			result, err := QueryGetRotorPreview(context.Background(), GetRotorPreviewQuery{ClassTypeID: "ct1", Gate: tt.gate}, deps)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.RotorID != "r1" || result.PreviewOn != tt.previewOn {
+				t.Fatalf("result = %+v", result)
+			}
+			if len(result.Themes) != len(tt.want) {
+				t.Fatalf("got %d themes, want %d: %+v", len(result.Themes), len(tt.want), result.Themes)
+			}
+			for _, th := range result.Themes {
+				want, ok := tt.want[th.ThemeID]
+				if !ok {
+					t.Fatalf("theme %s should not be shown", th.ThemeID)
+				}
+				if len(th.Topics) != len(want) {
+					t.Fatalf("theme %s: got %d topics, want %d: %+v", th.ThemeID, len(th.Topics), len(want), th.Topics)
+				}
+				for i, tp := range th.Topics {
+					got := dated{tp.TopicID, tp.StartsOn, tp.EndsOn}
+					if got != want[i] {
+						t.Errorf("theme %s topic %d = %+v, want %+v", th.ThemeID, i, got, want[i])
+					}
+					if tp.Current != (i == 0) {
+						t.Errorf("theme %s topic %d: Current = %v", th.ThemeID, i, tp.Current)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestQueryGetRotorPreview_Details verifies bumps are flagged, belt gating applies and the topic count is honoured.
+func TestQueryGetRotorPreview_Details(t *testing.T) {
+	deps := GetRotorPreviewDeps{RotorStore: newRotorPreviewStore(true), HolidayStore: &mockRotorPreviewHolidayStore{}}
+	result, err := QueryGetRotorPreview(context.Background(), GetRotorPreviewQuery{ClassTypeID: "ct1", Topics: 2, Gate: BeltGate{Program: "adults"}}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	topics := result.Themes[0].Topics
+	if len(topics) != 3 {
+		t.Fatalf("expected the running topic and 2 projections, got %+v", topics)
+	}
+	if !topics[1].Bumped || topics[2].Bumped {
+		t.Errorf("expected only Topic C flagged as bumped, got %+v", topics)
+	}
+	if !topics[2].Locked || topics[2].EndsOn != "2026-03-30" {
+		t.Errorf("expected Topic B locked for a white belt and two weeks long with no holidays, got %+v", topics[2])
+	}
+
+	none, err := QueryGetRotorPreview(context.Background(), GetRotorPreviewQuery{ClassTypeID: "ct-none", Gate: BeltGate{Staff: true}}, deps)
+	if err != nil || none.RotorID != "" || len(none.Themes) != 0 {
+		t.Errorf("no active rotor: got %+v, %v", none, err)
+	}
+}
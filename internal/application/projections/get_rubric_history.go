@@ -0,0 +1,172 @@
+package projections
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"workshop/internal/domain/rubric"
+)
+
+// RubricHistoryScoreStore defines the score store interface needed by the rubric history.
+type RubricHistoryScoreStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]rubric.Score, error)
+}
+
+// RubricHistoryTemplateStore defines the template store interface needed by the rubric history.
+type RubricHistoryTemplateStore interface {
+	List(ctx context.Context) ([]rubric.Template, error)
+}
+
+// GetRubricHistoryQuery carries input for the rubric history projection.
+type GetRubricHistoryQuery struct {
+	MemberID string
+	Since    time.Time // zero includes every score
+}
+
+// GetRubricHistoryDeps holds dependencies for the rubric history projection.
+type GetRubricHistoryDeps struct {
+	ScoreStore    RubricHistoryScoreStore
+	TemplateStore RubricHistoryTemplateStore // optional: nil leaves template names blank
+}
+
+// RubricScoreView is one criterion's score within an assessment.
+type RubricScoreView struct {
+	Key   string
+	Label string
+	Value int
+	Min   int
+	Max   int
+}
+
+// RubricAssessment is one filled-in rubric: the scores attached to a single observation or grading note.
+type RubricAssessment struct {
+	TemplateID   string
+	TemplateName string
+	Source       string // rubric.SourceObservation or rubric.SourceGradingNote
+	SourceID     string
+	AuthorID     string
+	CreatedAt    time.Time
+	Scores       []RubricScoreView
+}
+
+// RubricCriterionAverage is a member's mean score on one criterion.
+type RubricCriterionAverage struct {
+	TemplateID   string
+	TemplateName string
+	Key          string
+	Label        string // as of the latest score
+	Average      float64
+	Min          int
+	Max          int
+	Count        int
+}
+
+// GetRubricHistoryResult carries a member's assessments, newest first, and their averages.
+type GetRubricHistoryResult struct {
+	Assessments []RubricAssessment
+	Averages    []RubricCriterionAverage // grouped by template, in criterion order
+	OverallPct  float64                  // mean of every score as a percentage of its scale; 0 when unscored
+}
+
+// QueryGetRubricHistory returns a member's rubric assessments and per-criterion averages.
+// PRE: query.MemberID is non-empty
+// POST: Returns assessments newest first; averages cover the same window
+func QueryGetRubricHistory(ctx context.Context, query GetRubricHistoryQuery, deps GetRubricHistoryDeps) (GetRubricHistoryResult, error) {
+	scores, err := deps.ScoreStore.ListByMemberID(ctx, query.MemberID)
+	if err != nil {
+		return GetRubricHistoryResult{}, err
+	}
+	templates := make(map[string]rubric.Template)
+	if deps.TemplateStore != nil {
+		list, err := deps.TemplateStore.List(ctx)
+		if err != nil {
+			return GetRubricHistoryResult{}, err
+		}
+		for _, t := range list {
+			templates[t.ID] = t
+		}
+	}
+
+	result := GetRubricHistoryResult{Assessments: []RubricAssessment{}, Averages: []RubricCriterionAverage{}}
+	assessmentIndex := make(map[string]int) // source + ID -> index in Assessments
+	averageIndex := make(map[string]int)    // template + criterion key -> index in Averages
+	sums := make(map[string]float64)        // template + criterion key -> sum of values
+	var normalizedSum float64
+	scored := 0
+	for _, s := range scores {
+		if !query.Since.IsZero() && s.CreatedAt.Before(query.Since) {
+			continue
+		}
+		sourceKey := s.Source + "|" + s.SourceID
+		i, ok := assessmentIndex[sourceKey]
+		if !ok {
+			i = len(result.Assessments)
+			assessmentIndex[sourceKey] = i
+			result.Assessments = append(result.Assessments, RubricAssessment{
+				TemplateID:   s.TemplateID,
+				TemplateName: templates[s.TemplateID].Name,
+				Source:       s.Source,
+				SourceID:     s.SourceID,
+				AuthorID:     s.AuthorID,
+				CreatedAt:    s.CreatedAt,
+			})
+		}
+		result.Assessments[i].Scores = append(result.Assessments[i].Scores, RubricScoreView{
+			Key: s.CriterionKey, Label: s.CriterionLabel, Value: s.Value, Min: s.Min, Max: s.Max,
+		})
+
+		criterionKey := s.TemplateID + "|" + s.CriterionKey
+		j, ok := averageIndex[criterionKey]
+		if !ok {
+			// Scores arrive newest first, so the first one seen carries the current label and scale.
+			j = len(result.Averages)
+			averageIndex[criterionKey] = j
+			result.Averages = append(result.Averages, RubricCriterionAverage{
+				TemplateID:   s.TemplateID,
+				TemplateName: templates[s.TemplateID].Name,
+				Key:          s.CriterionKey,
+				Label:        s.CriterionLabel,
+				Min:          s.Min,
+				Max:          s.Max,
+			})
+		}
+		result.Averages[j].Count++
+		sums[criterionKey] += float64(s.Value)
+		normalizedSum += s.Normalized()
+		scored++
+	}
+
+	for i := range result.Averages {
+		a := &result.Averages[i]
+		a.Average = math.Round(sums[a.TemplateID+"|"+a.Key]/float64(a.Count)*10) / 10
+	}
+	sortRubricAverages(result.Averages, templates)
+	if scored > 0 {
+		result.OverallPct = math.Round(normalizedSum/float64(scored)*1000) / 10
+	}
+	return result, nil
+}
+
+// sortRubricAverages orders averages by template name, then by the template's criterion order.
+// Criteria since removed from their template sort after the current ones.
+func sortRubricAverages(averages []RubricCriterionAverage, templates map[string]rubric.Template) {
+	position := func(a RubricCriterionAverage) int {
+		for i, c := range templates[a.TemplateID].Criteria {
+			if c.Key == a.Key {
+				return i
+			}
+		}
+		return rubric.MaxCriteria
+	}
+	sort.SliceStable(averages, func(i, j int) bool {
+		if averages[i].TemplateName != averages[j].TemplateName {
+			return averages[i].TemplateName < averages[j].TemplateName
+		}
+		if averages[i].TemplateID != averages[j].TemplateID {
+			return averages[i].TemplateID < averages[j].TemplateID
+		}
+		return position(averages[i]) < position(averages[j])
+	})
+}
@@ -0,0 +1,95 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/rubric"
+)
+
+type mockRHScoreStore struct {
+	scores []rubric.Score // newest first
+}
+
+// ListByMemberID implements RubricHistoryScoreStore for testing.
+// PRE: memberID is non-empty
+// POST: returns the member's scores, newest first
+func (m *mockRHScoreStore) ListByMemberID(_ context.Context, memberID string) ([]rubric.Score, error) {
+	var out []rubric.Score
+	for _, s := range m.scores {
+		if s.MemberID == memberID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+type mockRHTemplateStore struct {
+	templates []rubric.Template
+}
+
+// List implements RubricHistoryTemplateStore for testing.
+// PRE: none
+// POST: returns every template
+func (m *mockRHTemplateStore) List(_ context.Context) ([]rubric.Template, error) {
+	return m.templates, nil
+}
+
+// TestQueryGetRubricHistory verifies scores are grouped into assessments and averaged per criterion.
+func TestQueryGetRubricHistory(t *testing.T) {
+	march := time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)
+	jan := time.Date(2026, 1, 12, 18, 0, 0, 0, time.UTC)
+	score := func(source, sourceID, key, label string, value int, at time.Time) rubric.Score {
+		return rubric.Score{TemplateID: "guard", MemberID: "m1", Source: source, SourceID: sourceID,
+			CriterionKey: key, CriterionLabel: label, Value: value, Min: 1, Max: 5, CreatedAt: at}
+	}
+	deps := GetRubricHistoryDeps{
+		ScoreStore: &mockRHScoreStore{scores: []rubric.Score{
+			score(rubric.SourceObservation, "o2", "retention", "Retention", 5, march),
+			score(rubric.SourceObservation, "o2", "escapes", "Escapes", 3, march),
+			score(rubric.SourceGradingNote, "n1", "escapes", "Escapes", 2, jan),
+			score(rubric.SourceGradingNote, "n1", "retention", "Guard retention", 2, jan),
+			{TemplateID: "guard", MemberID: "m2", Source: rubric.SourceObservation, SourceID: "o9", CriterionKey: "escapes", Value: 1, Min: 1, Max: 5},
+		}},
+		TemplateStore: &mockRHTemplateStore{templates: []rubric.Template{{ID: "guard", Name: "Guard game", Criteria: []rubric.Criterion{
+			{Key: "retention", Label: "Retention", Min: 1, Max: 5},
+			{Key: "escapes", Label: "Escapes", Min: 1, Max: 5},
+		}}}},
+	}
+
+	result, err := QueryGetRubricHistory(context.Background(), GetRubricHistoryQuery{MemberID: "m1"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Assessments) != 2 || result.Assessments[0].SourceID != "o2" || len(result.Assessments[1].Scores) != 2 {
+		t.Fatalf("assessments = %+v, want o2 then n1 with two scores each", result.Assessments)
+	}
+	if result.Assessments[0].TemplateName != "Guard game" {
+		t.Errorf("template name = %q, want Guard game", result.Assessments[0].TemplateName)
+	}
+	if len(result.Averages) != 2 {
+		t.Fatalf("averages = %+v, want two criteria", result.Averages)
+	}
+	retention, escapes := result.Averages[0], result.Averages[1]
+	if retention.Key != "retention" || retention.Label != "Retention" || retention.Average != 3.5 || retention.Count != 2 {
+		t.Errorf("retention = %+v, want the current label and an average of 3.5 over 2", retention)
+	}
+	if escapes.Key != "escapes" || escapes.Average != 2.5 {
+		t.Errorf("escapes = %+v, want an average of 2.5", escapes)
+	}
+	// Normalised: (1 + 0.5 + 0.25 + 0.25) / 4 = 50%.
+	if result.OverallPct != 50 {
+		t.Errorf("OverallPct = %v, want 50", result.OverallPct)
+	}
+
+	recent, _ := QueryGetRubricHistory(context.Background(), GetRubricHistoryQuery{MemberID: "m1", Since: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}, deps)
+	if len(recent.Assessments) != 1 || recent.OverallPct != 75 {
+		t.Errorf("since March: %d assessments at %v%%, want 1 at 75%%", len(recent.Assessments), recent.OverallPct)
+	}
+
+	empty, _ := QueryGetRubricHistory(context.Background(), GetRubricHistoryQuery{MemberID: "nobody"}, GetRubricHistoryDeps{ScoreStore: deps.ScoreStore})
+	if empty.Assessments == nil || empty.Averages == nil || empty.OverallPct != 0 {
+		t.Errorf("unscored member = %+v, want empty lists and 0%%", empty)
+	}
+}
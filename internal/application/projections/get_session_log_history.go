@@ -0,0 +1,119 @@
+package projections
+
+import (
+	"context"
+
+	sessionLogStore "workshop/internal/adapters/storage/sessionlog"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/rotor"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/sessionlog"
+)
+
+// SessionLogHistoryStore defines the session log store interface needed by this projection.
+type SessionLogHistoryStore interface {
+	List(ctx context.Context, filter sessionLogStore.ListFilter) ([]sessionlog.SessionLog, error)
+}
+
+// SessionLogHistoryScheduleStore defines the schedule store interface needed by this projection.
+type SessionLogHistoryScheduleStore interface {
+	GetByID(ctx context.Context, id string) (schedule.Schedule, error)
+}
+
+// SessionLogHistoryClassTypeStore defines the class type store interface needed by this projection.
+type SessionLogHistoryClassTypeStore interface {
+	GetByID(ctx context.Context, id string) (classtype.ClassType, error)
+}
+
+// SessionLogHistoryTopicStore defines the rotor store interface needed by this projection.
+type SessionLogHistoryTopicStore interface {
+	GetTopic(ctx context.Context, id string) (rotor.Topic, error)
+}
+
+// GetSessionLogHistoryDeps holds dependencies for the projection.
+type GetSessionLogHistoryDeps struct {
+	SessionLogStore SessionLogHistoryStore
+	ScheduleStore   SessionLogHistoryScheduleStore
+	ClassTypeStore  SessionLogHistoryClassTypeStore
+	RotorStore      SessionLogHistoryTopicStore
+}
+
+// GetSessionLogHistoryQuery filters the history. Empty fields are ignored.
+type GetSessionLogHistoryQuery struct {
+	ScheduleID string
+	TopicID    string
+	From       string // YYYY-MM-DD
+	To         string // YYYY-MM-DD
+	Limit      int
+}
+
+// SessionLogTopic names a topic covered in a session.
+type SessionLogTopic struct {
+	ID   string
+	Name string
+}
+
+// SessionLogHistoryEntry is a session log with its class and topic names resolved.
+type SessionLogHistoryEntry struct {
+	sessionlog.SessionLog
+	ClassTypeName string
+	Day           string
+	StartTime     string
+	Topics        []SessionLogTopic
+}
+
+// QueryGetSessionLogHistory lists session logs newest first with display names resolved.
+// PRE: query dates, if set, are YYYY-MM-DD
+// POST: Returns matching entries; deleted schedules, class types or topics are shown without names
+func QueryGetSessionLogHistory(ctx context.Context, query GetSessionLogHistoryQuery, deps GetSessionLogHistoryDeps) ([]SessionLogHistoryEntry, error) {
+	logs, err := deps.SessionLogStore.List(ctx, sessionLogStore.ListFilter{
+		ScheduleID: query.ScheduleID,
+		TopicID:    query.TopicID,
+		From:       query.From,
+		To:         query.To,
+		Limit:      query.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make(map[string]schedule.Schedule)
+	classNames := make(map[string]string)
+	topicNames := make(map[string]string)
+
+	entries := make([]SessionLogHistoryEntry, 0, len(logs))
+	for _, l := range logs {
+		entry := SessionLogHistoryEntry{SessionLog: l, Topics: []SessionLogTopic{}}
+
+		sched, ok := schedules[l.ScheduleID]
+		if !ok {
+			sched, _ = deps.ScheduleStore.GetByID(ctx, l.ScheduleID)
+			schedules[l.ScheduleID] = sched
+		}
+		entry.Day = sched.Day
+		entry.StartTime = sched.StartTime
+		if sched.ClassTypeID != "" {
+			name, ok := classNames[sched.ClassTypeID]
+			if !ok {
+				if ct, err := deps.ClassTypeStore.GetByID(ctx, sched.ClassTypeID); err == nil {
+					name = ct.Name
+				}
+				classNames[sched.ClassTypeID] = name
+			}
+			entry.ClassTypeName = name
+		}
+
+		for _, id := range l.TopicIDs {
+			name, ok := topicNames[id]
+			if !ok {
+				if t, err := deps.RotorStore.GetTopic(ctx, id); err == nil {
+					name = t.Name
+				}
+				topicNames[id] = name
+			}
+			entry.Topics = append(entry.Topics, SessionLogTopic{ID: id, Name: name})
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,94 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sessionLogStore "workshop/internal/adapters/storage/sessionlog"
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/rotor"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/sessionlog"
+)
+
+type mockSLHSessionLogStore struct {
+	logs []sessionlog.SessionLog
+}
+
+// List implements SessionLogHistoryStore.
+// PRE: none
+// POST: returns logs matching the schedule filter
+func (m *mockSLHSessionLogStore) List(_ context.Context, filter sessionLogStore.ListFilter) ([]sessionlog.SessionLog, error) {
+	var out []sessionlog.SessionLog
+	for _, l := range m.logs {
+		if filter.ScheduleID == "" || l.ScheduleID == filter.ScheduleID {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+type mockSLHScheduleStore struct{}
+
+// GetByID implements SessionLogHistoryScheduleStore.
+// PRE: id is non-empty
+// POST: returns s1 or an error
+func (m *mockSLHScheduleStore) GetByID(_ context.Context, id string) (schedule.Schedule, error) {
+	if id != "s1" {
+		return schedule.Schedule{}, errors.New("not found")
+	}
+	return schedule.Schedule{ID: "s1", ClassTypeID: "ct1", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:00"}, nil
+}
+
+type mockSLHClassTypeStore struct{}
+
+// GetByID implements SessionLogHistoryClassTypeStore.
+// PRE: id is non-empty
+// POST: returns a named class type
+func (m *mockSLHClassTypeStore) GetByID(_ context.Context, id string) (classtype.ClassType, error) {
+	return classtype.ClassType{ID: id, Name: "Adults Gi"}, nil
+}
+
+type mockSLHTopicStore struct{}
+
+// GetTopic implements SessionLogHistoryTopicStore.
+// PRE: id is non-empty
+// POST: returns t1 or an error
+func (m *mockSLHTopicStore) GetTopic(_ context.Context, id string) (rotor.Topic, error) {
+	if id != "t1" {
+		return rotor.Topic{}, errors.New("not found")
+	}
+	return rotor.Topic{ID: "t1", Name: "Single Leg"}, nil
+}
+
+// TestQueryGetSessionLogHistory_ResolvesNames verifies class and topic names are resolved and missing ones tolerated.
+func TestQueryGetSessionLogHistory_ResolvesNames(t *testing.T) {
+	deps := GetSessionLogHistoryDeps{
+		SessionLogStore: &mockSLHSessionLogStore{logs: []sessionlog.SessionLog{
+			{ID: "l1", ScheduleID: "s1", ClassDate: "2026-03-02", TopicIDs: []string{"t1", "gone"}},
+			{ID: "l2", ScheduleID: "deleted", ClassDate: "2026-03-01"},
+		}},
+		ScheduleStore:  &mockSLHScheduleStore{},
+		ClassTypeStore: &mockSLHClassTypeStore{},
+		RotorStore:     &mockSLHTopicStore{},
+	}
+
+	entries, err := QueryGetSessionLogHistory(context.Background(), GetSessionLogHistoryQuery{}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	first := entries[0]
+	if first.ClassTypeName != "Adults Gi" || first.StartTime != "18:00" {
+		t.Errorf("expected class details resolved, got %+v", first)
+	}
+	if len(first.Topics) != 2 || first.Topics[0].Name != "Single Leg" || first.Topics[1].Name != "" {
+		t.Errorf("unexpected topics: %+v", first.Topics)
+	}
+	if entries[1].ClassTypeName != "" {
+		t.Errorf("expected no class name for deleted schedule, got %q", entries[1].ClassTypeName)
+	}
+}
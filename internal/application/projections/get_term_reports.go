@@ -0,0 +1,214 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/milestone"
+	"workshop/internal/domain/observation"
+	"workshop/internal/domain/personalgoal"
+	"workshop/internal/domain/term"
+)
+
+// TermReportObservationStore defines the observation store interface needed by this projection.
+type TermReportObservationStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]observation.Observation, error)
+}
+
+// TermReportMilestoneStore defines the milestone store interface needed by this projection.
+type TermReportMilestoneStore interface {
+	List(ctx context.Context) ([]milestone.Milestone, error)
+}
+
+// TermReportMemberMilestoneStore defines the member milestone store interface needed by this projection.
+type TermReportMemberMilestoneStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]milestone.MemberMilestone, error)
+}
+
+// TermReportGoalStore defines the personal goal store interface needed by this projection.
+type TermReportGoalStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]personalgoal.PersonalGoal, error)
+}
+
+// GetTermReportsDeps holds dependencies for the term reports projection.
+type GetTermReportsDeps struct {
+	Readiness            GetKidsTermReadinessDeps
+	ObservationStore     TermReportObservationStore     // optional: nil leaves out coach feedback
+	MilestoneStore       TermReportMilestoneStore       // optional: nil leaves out milestones
+	MemberMilestoneStore TermReportMemberMilestoneStore // optional: nil leaves out milestones
+	GoalStore            TermReportGoalStore            // optional: nil leaves out goals
+}
+
+// GetTermReportsQuery carries input for the term reports projection.
+type GetTermReportsQuery struct {
+	TermID   string
+	MemberID string // optional: if empty, reports on every kid in the term
+}
+
+// TermReportMilestone is a milestone a kid earned during the term.
+type TermReportMilestone struct {
+	Name      string
+	BadgeIcon string
+	EarnedAt  time.Time
+}
+
+// TermReport is one kid's end-of-term progress report for their whānau.
+type TermReport struct {
+	MemberID      string
+	MemberName    string
+	Belt          string
+	Stripe        int
+	TargetBelt    string
+	Attended      int
+	MakeupCredits int
+	TotalSessions int
+	AttendancePct float64
+	ThresholdPct  float64
+	GradingReady  bool                      // attendance meets the threshold for TargetBelt
+	Promotions    []grading.Record          // belts and stripes awarded during the term, oldest first
+	Observations  []observation.Observation // coach feedback shared with the member, oldest first
+	Milestones    []TermReportMilestone     // earned during the term, oldest first
+	Goals         []personalgoal.PersonalGoal
+}
+
+// TermReportsResult carries the output of the term reports projection.
+type TermReportsResult struct {
+	TermID    string
+	TermName  string
+	StartDate time.Time
+	EndDate   time.Time
+	Reports   []TermReport // by member name
+}
+
+// QueryGetTermReports builds the end-of-term progress reports for the kids program.
+// Algorithm:
+//  1. Find the term; an unknown term gives an empty result
+//  2. Take each kid's attendance and grading readiness from the kids term readiness projection
+//  3. Add their current belt and stripes and the promotions made during the term
+//  4. Add shared coach observations made from the start of the term until the report window
+//     closes, so end-of-term notes written after the last class are included
+//  5. Add milestones earned during the term and open goals that run into the next term
+func QueryGetTermReports(ctx context.Context, query GetTermReportsQuery, deps GetTermReportsDeps) (TermReportsResult, error) {
+	// Step 1: Find the term
+	terms, err := deps.Readiness.TermStore.List(ctx)
+	if err != nil {
+		return TermReportsResult{}, err
+	}
+	var target term.Term
+	for _, t := range terms {
+		if t.ID == query.TermID {
+			target = t
+			break
+		}
+	}
+	if target.ID == "" {
+		return TermReportsResult{}, nil
+	}
+	result := TermReportsResult{TermID: target.ID, TermName: target.Name, StartDate: target.StartDate, EndDate: target.EndDate}
+
+	// Step 2: Attendance and grading readiness
+	readiness, err := QueryGetKidsTermReadiness(ctx, GetKidsTermReadinessQuery{TermID: target.ID}, deps.Readiness)
+	if err != nil {
+		return TermReportsResult{}, err
+	}
+
+	start := target.StartDate.Truncate(24 * time.Hour)
+	termEnd := target.EndDate.Truncate(24*time.Hour).AddDate(0, 0, 1)
+	notesEnd := termEnd.AddDate(0, 0, term.ReportEmailWindowDays)
+	during := func(at, end time.Time) bool { return !at.Before(start) && at.Before(end) }
+
+	milestoneByID := make(map[string]milestone.Milestone)
+	if deps.MilestoneStore != nil && deps.MemberMilestoneStore != nil {
+		list, err := deps.MilestoneStore.List(ctx)
+		if err != nil {
+			return TermReportsResult{}, err
+		}
+		for _, m := range list {
+			milestoneByID[m.ID] = m
+		}
+	}
+
+	for _, e := range readiness.Entries {
+		if query.MemberID != "" && e.MemberID != query.MemberID {
+			continue
+		}
+		report := TermReport{
+			MemberID:      e.MemberID,
+			MemberName:    e.MemberName,
+			Belt:          e.CurrentBelt,
+			TargetBelt:    e.TargetBelt,
+			Attended:      e.Attended,
+			MakeupCredits: e.MakeupCredits,
+			TotalSessions: e.TotalSessions,
+			AttendancePct: e.AttendancePct,
+			ThresholdPct:  e.ThresholdPct,
+			GradingReady:  e.Eligible,
+		}
+
+		// Step 3: Current stripes and promotions during the term
+		records, err := deps.Readiness.GradingRecordStore.ListByMemberID(ctx, e.MemberID)
+		if err != nil {
+			return TermReportsResult{}, err
+		}
+		var latest grading.Record
+		for _, r := range records {
+			if r.PromotedAt.After(latest.PromotedAt) {
+				latest = r
+			}
+			if during(r.PromotedAt, termEnd) {
+				report.Promotions = append(report.Promotions, r)
+			}
+		}
+		report.Stripe = latest.Stripe
+		sort.Slice(report.Promotions, func(i, j int) bool { return report.Promotions[i].PromotedAt.Before(report.Promotions[j].PromotedAt) })
+
+		// Step 4: Shared coach feedback
+		if deps.ObservationStore != nil {
+			notes, err := deps.ObservationStore.ListByMemberID(ctx, e.MemberID)
+			if err != nil {
+				return TermReportsResult{}, err
+			}
+			for _, o := range notes {
+				if o.Visibility == observation.VisibilityShared && during(o.CreatedAt, notesEnd) {
+					report.Observations = append(report.Observations, o)
+				}
+			}
+			sort.Slice(report.Observations, func(i, j int) bool {
+				return report.Observations[i].CreatedAt.Before(report.Observations[j].CreatedAt)
+			})
+		}
+
+		// Step 5: Milestones and goals
+		if len(milestoneByID) > 0 {
+			earned, err := deps.MemberMilestoneStore.ListByMemberID(ctx, e.MemberID)
+			if err != nil {
+				return TermReportsResult{}, err
+			}
+			for _, mm := range earned {
+				m, ok := milestoneByID[mm.MilestoneID]
+				if ok && during(mm.EarnedAt, termEnd) {
+					report.Milestones = append(report.Milestones, TermReportMilestone{Name: m.Name, BadgeIcon: m.BadgeIcon, EarnedAt: mm.EarnedAt})
+				}
+			}
+			sort.Slice(report.Milestones, func(i, j int) bool { return report.Milestones[i].EarnedAt.Before(report.Milestones[j].EarnedAt) })
+		}
+		if deps.GoalStore != nil {
+			goals, err := deps.GoalStore.ListByMemberID(ctx, e.MemberID)
+			if err != nil {
+				return TermReportsResult{}, err
+			}
+			for _, g := range goals {
+				if !g.IsCompleted() && !g.EndDate.Before(termEnd) {
+					report.Goals = append(report.Goals, g)
+				}
+			}
+		}
+
+		result.Reports = append(result.Reports, report)
+	}
+
+	sort.Slice(result.Reports, func(i, j int) bool { return result.Reports[i].MemberName < result.Reports[j].MemberName })
+	return result, nil
+}
@@ -0,0 +1,144 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/milestone"
+	"workshop/internal/domain/observation"
+	"workshop/internal/domain/personalgoal"
+)
+
+type mockTRObservationStore struct {
+	notes []observation.Observation
+}
+
+// ListByMemberID returns a member's observations.
+// PRE: memberID is non-empty
+// POST: Returns the member's observations
+func (m *mockTRObservationStore) ListByMemberID(_ context.Context, memberID string) ([]observation.Observation, error) {
+	var result []observation.Observation
+	for _, o := range m.notes {
+		if o.MemberID == memberID {
+			result = append(result, o)
+		}
+	}
+	return result, nil
+}
+
+type mockTRMilestoneStore struct {
+	milestones []milestone.Milestone
+	earned     []milestone.MemberMilestone
+}
+
+// List returns all milestones.
+// PRE: none
+// POST: Returns the milestones
+func (m *mockTRMilestoneStore) List(_ context.Context) ([]milestone.Milestone, error) {
+	return m.milestones, nil
+}
+
+// ListByMemberID returns the milestones a member has earned.
+// PRE: memberID is non-empty
+// POST: Returns the member's earned milestones
+func (m *mockTRMilestoneStore) ListByMemberID(_ context.Context, memberID string) ([]milestone.MemberMilestone, error) {
+	var result []milestone.MemberMilestone
+	for _, e := range m.earned {
+		if e.MemberID == memberID {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+type mockTRGoalStore struct {
+	goals []personalgoal.PersonalGoal
+}
+
+// ListByMemberID returns a member's personal goals.
+// PRE: memberID is non-empty
+// POST: Returns the member's goals
+func (m *mockTRGoalStore) ListByMemberID(_ context.Context, memberID string) ([]personalgoal.PersonalGoal, error) {
+	var result []personalgoal.PersonalGoal
+	for _, g := range m.goals {
+		if g.MemberID == memberID {
+			result = append(result, g)
+		}
+	}
+	return result, nil
+}
+
+// TestQueryGetTermReports verifies a report gathers the term's attendance, promotions, shared
+// feedback, milestones and ongoing goals, and leaves out what falls outside the term.
+func TestQueryGetTermReports(t *testing.T) {
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 16, 0, 0, 0, time.UTC) }
+	readiness := newKidsReadinessTestDeps()
+	readiness.AttendanceStore = &mockKRAttendanceStore{records: []attendance.Attendance{
+		{ID: "a1", MemberID: "kid1", ScheduleID: "sched-mon", CheckInTime: day(1, 19)},
+		{ID: "a2", MemberID: "kid1", ScheduleID: "sched-mon", CheckInTime: day(1, 26)},
+	}}
+	readiness.GradingRecordStore = &mockKRGradingRecordStore{records: map[string][]grading.Record{
+		"kid1": {
+			{ID: "g2", MemberID: "kid1", Belt: grading.BeltWhite, Stripe: 2, PromotedAt: day(3, 2)},
+			{ID: "g1", MemberID: "kid1", Belt: grading.BeltWhite, Stripe: 1, PromotedAt: day(2, 2)},
+			{ID: "g0", MemberID: "kid1", Belt: grading.BeltWhite, PromotedAt: day(1, 5)},
+		},
+	}}
+	milestones := &mockTRMilestoneStore{
+		milestones: []milestone.Milestone{{ID: "ms1", Name: "First ten"}},
+		earned: []milestone.MemberMilestone{
+			{ID: "e1", MemberID: "kid1", MilestoneID: "ms1", EarnedAt: day(3, 10)},
+			{ID: "e2", MemberID: "kid1", MilestoneID: "ms1", EarnedAt: day(5, 1)},
+		},
+	}
+	deps := GetTermReportsDeps{
+		Readiness: readiness,
+		ObservationStore: &mockTRObservationStore{notes: []observation.Observation{
+			{ID: "o1", MemberID: "kid1", Content: "Great guard retention", Visibility: observation.VisibilityShared, CreatedAt: day(4, 14)},
+			{ID: "o2", MemberID: "kid1", Content: "Coaches only", Visibility: observation.VisibilityCoaches, CreatedAt: day(3, 1)},
+			{ID: "o3", MemberID: "kid1", Content: "Last term", Visibility: observation.VisibilityShared, CreatedAt: day(1, 1)},
+		}},
+		MilestoneStore:       milestones,
+		MemberMilestoneStore: milestones,
+		GoalStore: &mockTRGoalStore{goals: []personalgoal.PersonalGoal{
+			{ID: "p1", MemberID: "kid1", Title: "Learn the triangle", EndDate: day(6, 30)},
+			{ID: "p2", MemberID: "kid1", Title: "Done", EndDate: day(6, 30), Status: personalgoal.StatusCompleted},
+			{ID: "p3", MemberID: "kid1", Title: "Ended", EndDate: day(3, 1)},
+		}},
+	}
+
+	result, err := QueryGetTermReports(context.Background(), GetTermReportsQuery{TermID: "term1"}, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TermName != "Term 1 2026" || len(result.Reports) != 2 || result.Reports[0].MemberName != "Alice Kid" {
+		t.Fatalf("result = %+v, want both kids sorted by name", result)
+	}
+	r := result.Reports[0]
+	if r.Attended != 2 || r.TotalSessions == 0 || r.Belt != grading.BeltWhite || r.Stripe != 2 || r.TargetBelt != grading.BeltGrey {
+		t.Errorf("attendance and belt = %+v", r)
+	}
+	if len(r.Promotions) != 2 || r.Promotions[0].ID != "g1" {
+		t.Errorf("promotions = %+v, want g1 then g2", r.Promotions)
+	}
+	if len(r.Observations) != 1 || r.Observations[0].ID != "o1" {
+		t.Errorf("observations = %+v, want the shared end-of-term note only", r.Observations)
+	}
+	if len(r.Milestones) != 1 || r.Milestones[0].Name != "First ten" {
+		t.Errorf("milestones = %+v", r.Milestones)
+	}
+	if len(r.Goals) != 1 || r.Goals[0].ID != "p1" {
+		t.Errorf("goals = %+v, want the open goal running into next term", r.Goals)
+	}
+
+	one, _ := QueryGetTermReports(context.Background(), GetTermReportsQuery{TermID: "term1", MemberID: "kid2"}, deps)
+	if len(one.Reports) != 1 || one.Reports[0].MemberID != "kid2" || len(one.Reports[0].Observations) != 0 {
+		t.Errorf("single report = %+v", one.Reports)
+	}
+	if none, _ := QueryGetTermReports(context.Background(), GetTermReportsQuery{TermID: "missing"}, deps); none.TermID != "" {
+		t.Errorf("unknown term = %+v, want empty", none)
+	}
+}
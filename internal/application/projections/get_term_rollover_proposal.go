@@ -0,0 +1,144 @@
+package projections
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/holiday"
+	"workshop/internal/domain/term"
+)
+
+// Threshold sources, from most to least specific.
+const (
+	ThresholdSourceTerm    = "term"    // set on the previous term
+	ThresholdSourceConfig  = "config"  // the kids grading config
+	ThresholdSourceDefault = "default" // grading.DefaultKidsAttendancePct
+)
+
+// TermRolloverTermStore defines the term store interface needed by this projection.
+type TermRolloverTermStore interface {
+	List(ctx context.Context) ([]term.Term, error)
+}
+
+// TermRolloverHolidayStore defines the holiday store interface needed by this projection.
+type TermRolloverHolidayStore interface {
+	List(ctx context.Context) ([]holiday.Holiday, error)
+}
+
+// TermRolloverStore defines the threshold and snapshot store interface needed by this projection.
+type TermRolloverStore interface {
+	ListThresholds(ctx context.Context, termID string) ([]term.Threshold, error)
+	GetSnapshot(ctx context.Context, termID string) (term.ReadinessSnapshot, error)
+}
+
+// TermRolloverConfigStore defines the grading config store interface needed by this projection.
+type TermRolloverConfigStore interface {
+	GetByProgramAndBelt(ctx context.Context, program, belt string) (grading.Config, error)
+}
+
+// GetTermRolloverProposalDeps holds dependencies for the projection.
+type GetTermRolloverProposalDeps struct {
+	TermStore          TermRolloverTermStore
+	HolidayStore       TermRolloverHolidayStore
+	RolloverStore      TermRolloverStore
+	GradingConfigStore TermRolloverConfigStore
+}
+
+// TermRolloverThreshold is the attendance kids need for a belt, as carried into the next term.
+type TermRolloverThreshold struct {
+	Belt          string
+	AttendancePct float64
+	Source        string // term, config or default
+}
+
+// TermRolloverProposal is what the rollover wizard pre-fills for the admin to confirm.
+type TermRolloverProposal struct {
+	PreviousTerm     term.Term
+	PreviousArchived bool              // the previous term's readiness is already archived
+	Term             term.Term         // proposed dates; no ID until confirmed
+	Holidays         []holiday.Holiday // last year's holidays in the same term, a year on; no IDs
+	Thresholds       []TermRolloverThreshold
+}
+
+// QueryGetTermRolloverProposal proposes the term after the latest one: NZ school term dates,
+// the holidays that fell in the same term last year moved forward a year, and the kids
+// attendance thresholds in force for the previous term, one per kids belt.
+// PRE: none
+// POST: Returns term.ErrNoPreviousTerm when no term has been entered
+func QueryGetTermRolloverProposal(ctx context.Context, deps GetTermRolloverProposalDeps) (TermRolloverProposal, error) {
+	terms, err := deps.TermStore.List(ctx)
+	if err != nil {
+		return TermRolloverProposal{}, err
+	}
+	if len(terms) == 0 {
+		return TermRolloverProposal{}, term.ErrNoPreviousTerm
+	}
+	prev := terms[0]
+	for _, t := range terms[1:] {
+		if t.EndDate.After(prev.EndDate) {
+			prev = t
+		}
+	}
+	proposal := TermRolloverProposal{PreviousTerm: prev, Term: term.ProposeNext(prev)}
+	if _, err := deps.RolloverStore.GetSnapshot(ctx, prev.ID); err == nil {
+		proposal.PreviousArchived = true
+	}
+
+	holidays, err := deps.HolidayStore.List(ctx)
+	if err != nil {
+		return TermRolloverProposal{}, err
+	}
+	proposal.Holidays = carryHolidays(proposal.Term, holidays)
+
+	set, err := deps.RolloverStore.ListThresholds(ctx, prev.ID)
+	if err != nil {
+		return TermRolloverProposal{}, err
+	}
+	byBelt := make(map[string]float64, len(set))
+	for _, t := range set {
+		byBelt[t.Belt] = t.AttendancePct
+	}
+	for _, belt := range grading.KidsBelts[1:] {
+		th := TermRolloverThreshold{Belt: belt, AttendancePct: grading.DefaultKidsAttendancePct, Source: ThresholdSourceDefault}
+		if pct, ok := byBelt[belt]; ok && pct > 0 {
+			th.AttendancePct, th.Source = pct, ThresholdSourceTerm
+		} else if c, err := deps.GradingConfigStore.GetByProgramAndBelt(ctx, "kids", belt); err == nil && c.AttendancePct > 0 {
+			th.AttendancePct, th.Source = c.AttendancePct, ThresholdSourceConfig
+		}
+		proposal.Thresholds = append(proposal.Thresholds, th)
+	}
+	return proposal, nil
+}
+
+// carryHolidays moves the holidays that overlapped next's dates a year earlier forward by a
+// year, leaving out any already entered for the new dates under the same name.
+func carryHolidays(next term.Term, holidays []holiday.Holiday) []holiday.Holiday {
+	out := []holiday.Holiday{}
+	for _, h := range holidays {
+		if !datesOverlap(h.StartDate, h.EndDate, next.StartDate.AddDate(-1, 0, 0), next.EndDate.AddDate(-1, 0, 0)) {
+			continue
+		}
+		moved := holiday.Holiday{Name: h.Name, StartDate: h.StartDate.AddDate(1, 0, 0), EndDate: h.EndDate.AddDate(1, 0, 0)}
+		if !datesOverlap(moved.StartDate, moved.EndDate, next.StartDate, next.EndDate) || holidayEntered(moved, holidays) {
+			continue
+		}
+		out = append(out, moved)
+	}
+	return out
+}
+
+// holidayEntered reports whether a holiday with h's name already overlaps h's dates.
+func holidayEntered(h holiday.Holiday, holidays []holiday.Holiday) bool {
+	for _, o := range holidays {
+		if o.Name == h.Name && datesOverlap(o.StartDate, o.EndDate, h.StartDate, h.EndDate) {
+			return true
+		}
+	}
+	return false
+}
+
+// datesOverlap reports whether two inclusive date ranges share a day.
+func datesOverlap(aStart, aEnd, bStart, bEnd time.Time) bool {
+	return !aStart.After(bEnd) && !bStart.After(aEnd)
+}
@@ -0,0 +1,138 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/grading"
+	"workshop/internal/domain/holiday"
+	"workshop/internal/domain/term"
+)
+
+// --- Mock stores for term rollover proposal tests ---
+
+type mockTRTermStore struct {
+	terms []term.Term
+}
+
+// List returns all terms.
+// PRE: none
+// POST: Returns the terms
+func (m *mockTRTermStore) List(_ context.Context) ([]term.Term, error) {
+	return m.terms, nil
+}
+
+type mockTRHolidayStore struct {
+	holidays []holiday.Holiday
+}
+
+// List returns all holidays.
+// PRE: none
+// POST: Returns the holidays
+func (m *mockTRHolidayStore) List(_ context.Context) ([]holiday.Holiday, error) {
+	return m.holidays, nil
+}
+
+type mockTRRolloverStore struct {
+	thresholds []term.Threshold
+	snapshots  map[string]term.ReadinessSnapshot
+}
+
+// ListThresholds returns the term's thresholds.
+// PRE: termID is non-empty
+// POST: Returns the thresholds set for the term
+func (m *mockTRRolloverStore) ListThresholds(_ context.Context, termID string) ([]term.Threshold, error) {
+	var out []term.Threshold
+	for _, t := range m.thresholds {
+		if t.TermID == termID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// GetSnapshot returns the term's archived readiness.
+// PRE: termID is non-empty
+// POST: Returns the snapshot or an error
+func (m *mockTRRolloverStore) GetSnapshot(_ context.Context, termID string) (term.ReadinessSnapshot, error) {
+	s, ok := m.snapshots[termID]
+	if !ok {
+		return term.ReadinessSnapshot{}, errors.New("not found")
+	}
+	return s, nil
+}
+
+type mockTRConfigStore struct{}
+
+// GetByProgramAndBelt returns a 75% config for kids yellow only.
+// PRE: program and belt are non-empty
+// POST: Returns the config or an error
+func (m *mockTRConfigStore) GetByProgramAndBelt(_ context.Context, program, belt string) (grading.Config, error) {
+	if program == "kids" && belt == grading.BeltYellow {
+		return grading.Config{Program: program, Belt: belt, AttendancePct: 75}, nil
+	}
+	return grading.Config{}, errors.New("not found")
+}
+
+// TestQueryGetTermRolloverProposal verifies the next term is proposed after the latest one,
+// last year's holidays in that term are carried forward once, and thresholds are rolled over.
+func TestQueryGetTermRolloverProposal(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	rollover := &mockTRRolloverStore{
+		thresholds: []term.Threshold{{TermID: "t1-2026", Belt: grading.BeltGrey, AttendancePct: 70}},
+		snapshots:  map[string]term.ReadinessSnapshot{},
+	}
+	deps := GetTermRolloverProposalDeps{
+		TermStore: &mockTRTermStore{terms: []term.Term{
+			{ID: "t2-2025", Name: "Term 2 2025", StartDate: day(2025, time.April, 28), EndDate: day(2025, time.July, 4)},
+			{ID: "t1-2026", Name: "Term 1 2026", StartDate: day(2026, time.February, 2), EndDate: day(2026, time.April, 2)},
+		}},
+		HolidayStore: &mockTRHolidayStore{holidays: []holiday.Holiday{
+			{ID: "h1", Name: "King's Birthday", StartDate: day(2025, time.June, 2), EndDate: day(2025, time.June, 2)},
+			{ID: "h2", Name: "Matariki", StartDate: day(2025, time.June, 20), EndDate: day(2025, time.June, 20)},
+			{ID: "h3", Name: "Matariki", StartDate: day(2026, time.June, 20), EndDate: day(2026, time.June, 20)},
+			{ID: "h4", Name: "Waitangi Day", StartDate: day(2025, time.February, 6), EndDate: day(2025, time.February, 6)},
+		}},
+		RolloverStore:      rollover,
+		GradingConfigStore: &mockTRConfigStore{},
+	}
+
+	p, err := QueryGetTermRolloverProposal(context.Background(), deps)
+	if err != nil {
+		t.Fatalf("QueryGetTermRolloverProposal: %v", err)
+	}
+	if p.PreviousTerm.ID != "t1-2026" || p.Term.Name != "Term 2 2026" || !p.Term.StartDate.Equal(day(2026, time.April, 20)) {
+		t.Errorf("expected Term 2 2026 from 20 April after Term 1, got %+v", p)
+	}
+	if p.PreviousArchived {
+		t.Error("expected the previous term not yet archived")
+	}
+	if len(p.Holidays) != 1 || p.Holidays[0].Name != "King's Birthday" || !p.Holidays[0].StartDate.Equal(day(2026, time.June, 2)) || p.Holidays[0].ID != "" {
+		t.Errorf("expected only King's Birthday carried forward, got %+v", p.Holidays)
+	}
+	want := map[string]TermRolloverThreshold{
+		grading.BeltGrey:   {Belt: grading.BeltGrey, AttendancePct: 70, Source: ThresholdSourceTerm},
+		grading.BeltYellow: {Belt: grading.BeltYellow, AttendancePct: 75, Source: ThresholdSourceConfig},
+		grading.BeltBlue:   {Belt: grading.BeltBlue, AttendancePct: grading.DefaultKidsAttendancePct, Source: ThresholdSourceDefault},
+	}
+	if len(p.Thresholds) != len(grading.KidsBelts)-1 {
+		t.Fatalf("expected one threshold per kids belt above white, got %+v", p.Thresholds)
+	}
+	for _, th := range p.Thresholds {
+		if w, ok := want[th.Belt]; ok && th != w {
+			t.Errorf("threshold %s = %+v, want %+v", th.Belt, th, w)
+		}
+	}
+
+	rollover.snapshots["t1-2026"] = term.ReadinessSnapshot{TermID: "t1-2026"}
+	if p, _ := QueryGetTermRolloverProposal(context.Background(), deps); !p.PreviousArchived {
+		t.Error("expected the archived previous term reported")
+	}
+
+	deps.TermStore = &mockTRTermStore{}
+	if _, err := QueryGetTermRolloverProposal(context.Background(), deps); !errors.Is(err, term.ErrNoPreviousTerm) {
+		t.Errorf("no terms: err = %v, want ErrNoPreviousTerm", err)
+	}
+}
@@ -0,0 +1,216 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"workshop/internal/domain/program"
+	"workshop/internal/domain/rotor"
+	"workshop/internal/domain/theme"
+)
+
+// ThemeCarouselPreviewLimit is how many upcoming topics a slide shows.
+const ThemeCarouselPreviewLimit = 3
+
+// ThemeCarouselProgramStore defines the program store interface needed by the theme carousel projection.
+type ThemeCarouselProgramStore interface {
+	List(ctx context.Context) ([]program.Program, error)
+}
+
+// ThemeCarouselRotorStore defines the rotor store interface needed by the theme carousel projection.
+type ThemeCarouselRotorStore interface {
+	GetActiveRotor(ctx context.Context, classTypeID string) (rotor.Rotor, error)
+	ListThemesByRotor(ctx context.Context, rotorID string) ([]rotor.RotorTheme, error)
+	ListTopicsByTheme(ctx context.Context, rotorThemeID string) ([]rotor.Topic, error)
+	GetActiveScheduleForTheme(ctx context.Context, rotorThemeID string) (rotor.TopicSchedule, error)
+}
+
+// ThemeCarouselThemeStore defines the theme store interface needed to link library themes.
+type ThemeCarouselThemeStore interface {
+	List(ctx context.Context) ([]theme.Theme, error)
+}
+
+// GetThemeCarouselQuery carries input for the theme carousel projection.
+type GetThemeCarouselQuery struct {
+	Gate BeltGate  // viewer's belt; members only see their own program's classes
+	Now  time.Time // picks the week of each running topic
+}
+
+// GetThemeCarouselDeps holds dependencies for the theme carousel projection.
+type GetThemeCarouselDeps struct {
+	ClassTypeStore CurriculumOverviewClassTypeStore
+	ProgramStore   ThemeCarouselProgramStore
+	RotorStore     ThemeCarouselRotorStore
+	ThemeStore     ThemeCarouselThemeStore // optional: nil shows no library links
+}
+
+// ThemeCarouselResult carries the output of the theme carousel projection.
+type ThemeCarouselResult struct {
+	Classes []ThemeCarouselClass
+}
+
+// ThemeCarouselClass is one class type's slides, drawn from its active rotor.
+type ThemeCarouselClass struct {
+	ClassTypeID   string
+	ClassTypeName string
+	Program       string // adults or kids
+	PreviewOn     bool   // the rotor shows members what is coming up
+	Slides        []ThemeCarouselSlide
+}
+
+// ThemeCarouselSlide is one rotor theme: what is being taught now and, with preview on, next.
+type ThemeCarouselSlide struct {
+	RotorThemeID string
+	ThemeName    string
+	Topic        *ThemeCarouselTopic // nil when nothing is running
+	Week         int                 // week of the running topic, from 1
+	Weeks        int                 // the running topic's length in weeks
+	EndsOn       string              // YYYY-MM-DD; empty for an open-ended run
+	Upcoming     []ThemeCarouselTopic
+	Library      []GatedTheme // library themes whose clips belong to this rotor theme
+}
+
+// ThemeCarouselTopic is a topic as shown on a slide.
+type ThemeCarouselTopic struct {
+	TopicID     string
+	Name        string
+	Description string
+	MinBelt     string
+	Locked      bool
+}
+
+// QueryGetThemeCarousel builds the member-facing theme carousel from the active rotors.
+// Members see only classes in their program; hidden themes appear once they are running.
+// Upcoming topics are shown only when the rotor has preview on, in queue order after the
+// running topic.
+// PRE: deps are valid; ThemeStore may be nil
+// POST: returns one entry per class type whose active rotor has something to show, in class type order
+func QueryGetThemeCarousel(ctx context.Context, query GetThemeCarouselQuery, deps GetThemeCarouselDeps) (ThemeCarouselResult, error) {
+	result := ThemeCarouselResult{Classes: []ThemeCarouselClass{}}
+
+	programs, err := deps.ProgramStore.List(ctx)
+	if err != nil {
+		return result, err
+	}
+	programTypes := make(map[string]string, len(programs))
+	for _, p := range programs {
+		programTypes[p.ID] = p.Type
+	}
+
+	library := map[string][]theme.Theme{}
+	if deps.ThemeStore != nil {
+		themes, err := deps.ThemeStore.List(ctx)
+		if err != nil {
+			return result, err
+		}
+		for _, t := range themes {
+			if t.RotorThemeID != "" {
+				library[t.RotorThemeID] = append(library[t.RotorThemeID], t)
+			}
+		}
+	}
+
+	classTypes, err := deps.ClassTypeStore.List(ctx)
+	if err != nil {
+		return result, err
+	}
+	for _, ct := range classTypes {
+		programType := programTypes[ct.ProgramID]
+		if !query.Gate.Staff && programType != query.Gate.Program {
+			continue
+		}
+		active, err := deps.RotorStore.GetActiveRotor(ctx, ct.ID)
+		if err != nil {
+			continue // no active rotor for this class type
+		}
+		class := ThemeCarouselClass{
+			ClassTypeID:   ct.ID,
+			ClassTypeName: ct.Name,
+			Program:       programType,
+			PreviewOn:     active.PreviewOn,
+			Slides:        []ThemeCarouselSlide{},
+		}
+
+		rotorThemes, err := deps.RotorStore.ListThemesByRotor(ctx, active.ID)
+		if err != nil {
+			return result, err
+		}
+		sort.SliceStable(rotorThemes, func(i, j int) bool { return rotorThemes[i].Position < rotorThemes[j].Position })
+		for _, rt := range rotorThemes {
+			slide, ok, err := themeCarouselSlide(ctx, rt, active.PreviewOn, query, deps.RotorStore)
+			if err != nil {
+				return result, err
+			}
+			if !ok {
+				continue
+			}
+			if linked := library[rt.ID]; len(linked) > 0 {
+				slide.Library = GateThemes(linked, query.Gate)
+			}
+			class.Slides = append(class.Slides, slide)
+		}
+		if len(class.Slides) > 0 {
+			result.Classes = append(result.Classes, class)
+		}
+	}
+	return result, nil
+}
+
+// themeCarouselSlide builds the slide for one rotor theme.
+// It reports false for a theme with nothing to show: hidden and not running, or idle with no preview.
+func themeCarouselSlide(ctx context.Context, rt rotor.RotorTheme, previewOn bool, query GetThemeCarouselQuery, store ThemeCarouselRotorStore) (ThemeCarouselSlide, bool, error) {
+	slide := ThemeCarouselSlide{RotorThemeID: rt.ID, ThemeName: rt.Name, Upcoming: []ThemeCarouselTopic{}}
+	topics, err := store.ListTopicsByTheme(ctx, rt.ID)
+	if err != nil {
+		return slide, false, err
+	}
+	sort.SliceStable(topics, func(i, j int) bool { return topics[i].Position < topics[j].Position })
+
+	running := -1
+	if sched, err := store.GetActiveScheduleForTheme(ctx, rt.ID); err == nil {
+		for i, tp := range topics {
+			if tp.ID == sched.TopicID {
+				running = i
+				view := themeCarouselTopic(tp, query.Gate)
+				slide.Topic = &view
+				slide.Weeks = tp.DurationWeeks
+				slide.Week = sched.WeekNumber(query.Now, tp.DurationWeeks)
+				if !sched.EndDate.IsZero() {
+					slide.EndsOn = sched.EndDate.Format("2006-01-02")
+				}
+				break
+			}
+		}
+	}
+	if rt.Hidden && slide.Topic == nil {
+		return slide, false, nil
+	}
+
+	if previewOn {
+		// Walk the queue from the topic after the running one, wrapping, and stop before it comes round again
+		for i := 0; i < len(topics) && len(slide.Upcoming) < ThemeCarouselPreviewLimit; i++ {
+			next := (running + 1 + i) % len(topics)
+			if next == running {
+				break
+			}
+			slide.Upcoming = append(slide.Upcoming, themeCarouselTopic(topics[next], query.Gate))
+		}
+	}
+	if slide.Topic == nil && len(slide.Upcoming) == 0 {
+		return slide, false, nil
+	}
+	return slide, true, nil
+}
+
+// themeCarouselTopic gates a topic for the viewer.
+func themeCarouselTopic(tp rotor.Topic, gate BeltGate) ThemeCarouselTopic {
+	gated := GateTopic(tp, gate)
+	return ThemeCarouselTopic{
+		TopicID:     tp.ID,
+		Name:        tp.Name,
+		Description: gated.Description,
+		MinBelt:     tp.MinBelt,
+		Locked:      gated.Locked,
+	}
+}
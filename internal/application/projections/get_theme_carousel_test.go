@@ -0,0 +1,136 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/program"
+	"workshop/internal/domain/rotor"
+	"workshop/internal/domain/theme"
+)
+
+// mockCarouselProgramStore implements ThemeCarouselProgramStore for testing.
+type mockCarouselProgramStore struct {
+	programs []program.Program
+}
+
+// List implements ThemeCarouselProgramStore.
+// PRE: none
+// POST: returns stored programs
+func (m *mockCarouselProgramStore) List(_ context.Context) ([]program.Program, error) {
+	return m.programs, nil
+}
+
+// mockCarouselThemeStore implements ThemeCarouselThemeStore for testing.
+type mockCarouselThemeStore struct {
+	themes []theme.Theme
+}
+
+// List implements ThemeCarouselThemeStore.
+// PRE: none
+// POST: returns stored themes
+func (m *mockCarouselThemeStore) List(_ context.Context) ([]theme.Theme, error) {
+	return m.themes, nil
+}
+
+// carouselDeps builds an adults Fundamentals rotor with a running Guard theme, an idle hidden
+// theme, and a kids class the adult members should not see.
+func carouselDeps(previewOn bool) GetThemeCarouselDeps {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	return GetThemeCarouselDeps{
+		ClassTypeStore: &mockCurriculumClassTypeStore{classTypes: []classtype.ClassType{
+			{ID: "ct-fund", ProgramID: "p-adults", Name: "Fundamentals"},
+			{ID: "ct-kids", ProgramID: "p-kids", Name: "Kids BJJ"},
+		}},
+		ProgramStore: &mockCarouselProgramStore{programs: []program.Program{
+			{ID: "p-adults", Name: "Adults", Type: program.TypeAdults},
+			{ID: "p-kids", Name: "Kids", Type: program.TypeKids},
+		}},
+		RotorStore: &mockCurriculumRotorStore{
+			activeRotors: map[string]rotor.Rotor{
+				"ct-fund": {ID: "r1", ClassTypeID: "ct-fund", Name: "v2", Status: rotor.StatusActive, PreviewOn: previewOn},
+				"ct-kids": {ID: "r2", ClassTypeID: "ct-kids", Name: "v1", Status: rotor.StatusActive},
+			},
+			themes: map[string][]rotor.RotorTheme{
+				"r1": {
+					{ID: "th-surprise", RotorID: "r1", Name: "Surprise", Position: 1, Hidden: true},
+					{ID: "th-guard", RotorID: "r1", Name: "Guard", Position: 0},
+				},
+				"r2": {{ID: "th-kids", RotorID: "r2", Name: "Games"}},
+			},
+			topics: map[string][]rotor.Topic{
+				"th-guard": {
+					{ID: "tp-closed", RotorThemeID: "th-guard", Name: "Closed Guard", DurationWeeks: 2, Position: 0},
+					{ID: "tp-lasso", RotorThemeID: "th-guard", Name: "Lasso", Description: "Sleeve and ankle", DurationWeeks: 1, Position: 1, MinBelt: "blue"},
+					{ID: "tp-dlr", RotorThemeID: "th-guard", Name: "De La Riva", DurationWeeks: 1, Position: 2},
+				},
+				"th-surprise": {{ID: "tp-wrestle", RotorThemeID: "th-surprise", Name: "Wrestling", DurationWeeks: 1}},
+				"th-kids":     {{ID: "tp-bear", RotorThemeID: "th-kids", Name: "Bear Crawls", DurationWeeks: 1}},
+			},
+			schedules: map[string]rotor.TopicSchedule{
+				"th-guard": {ID: "s1", TopicID: "tp-lasso", RotorThemeID: "th-guard", StartDate: start, EndDate: start.AddDate(0, 0, 6), Status: rotor.ScheduleStatusActive},
+				"th-kids":  {ID: "s2", TopicID: "tp-bear", RotorThemeID: "th-kids", StartDate: start, Status: rotor.ScheduleStatusActive},
+			},
+		},
+		ThemeStore: &mockCarouselThemeStore{themes: []theme.Theme{
+			{ID: "lib-lasso", Name: "Leg Lasso Series", RotorThemeID: "th-guard", Description: "Clips"},
+			{ID: "lib-other", Name: "Unlinked"},
+		}},
+	}
+}
+
+// TestQueryGetThemeCarousel_MemberView verifies a white belt adult sees only adult classes,
+// the running topic locked above their belt, linked library themes, and no hidden idle themes.
+func TestQueryGetThemeCarousel_MemberView(t *testing.T) {
+	query := GetThemeCarouselQuery{Gate: BeltGate{Program: "adults", Belt: "white"}, Now: time.Date(2026, 3, 4, 18, 0, 0, 0, time.UTC)}
+	result, err := QueryGetThemeCarousel(context.Background(), query, carouselDeps(false))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Classes) != 1 || result.Classes[0].ClassTypeName != "Fundamentals" {
+		t.Fatalf("expected only Fundamentals, got %+v", result.Classes)
+	}
+	slides := result.Classes[0].Slides
+	if len(slides) != 1 || slides[0].ThemeName != "Guard" {
+		t.Fatalf("expected only the Guard slide, got %+v", slides)
+	}
+	s := slides[0]
+	if s.Topic == nil || s.Topic.Name != "Lasso" || !s.Topic.Locked || s.Topic.Description != "" {
+		t.Errorf("running topic = %+v, want Lasso locked without its description", s.Topic)
+	}
+	if s.Week != 1 || s.Weeks != 1 || s.EndsOn != "2026-03-08" {
+		t.Errorf("week %d of %d ending %q, want 1 of 1 ending 2026-03-08", s.Week, s.Weeks, s.EndsOn)
+	}
+	if len(s.Upcoming) != 0 {
+		t.Errorf("expected no upcoming topics without preview, got %+v", s.Upcoming)
+	}
+	if len(s.Library) != 1 || s.Library[0].ID != "lib-lasso" {
+		t.Errorf("library = %+v, want the linked Leg Lasso Series", s.Library)
+	}
+}
+
+// TestQueryGetThemeCarousel_Preview verifies preview lists the queue after the running topic,
+// wrapping round, and staff see every program.
+func TestQueryGetThemeCarousel_Preview(t *testing.T) {
+	query := GetThemeCarouselQuery{Gate: BeltGate{Staff: true}, Now: time.Date(2026, 3, 4, 18, 0, 0, 0, time.UTC)}
+	result, err := QueryGetThemeCarousel(context.Background(), query, carouselDeps(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Classes) != 2 {
+		t.Fatalf("expected both programs for staff, got %d classes", len(result.Classes))
+	}
+	slides := result.Classes[0].Slides
+	if len(slides) != 1 {
+		t.Fatalf("expected the hidden idle theme left out, got %d slides", len(slides))
+	}
+	var names []string
+	for _, tp := range slides[0].Upcoming {
+		names = append(names, tp.Name)
+	}
+	if len(names) != 2 || names[0] != "De La Riva" || names[1] != "Closed Guard" {
+		t.Errorf("upcoming = %v, want [De La Riva Closed Guard]", names)
+	}
+}
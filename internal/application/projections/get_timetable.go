@@ -0,0 +1,152 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/location"
+	"workshop/internal/domain/schedule"
+)
+
+// TimetableScheduleStore defines the store interface needed by this projection.
+type TimetableScheduleStore interface {
+	List(ctx context.Context) ([]schedule.Schedule, error)
+}
+
+// TimetableClassTypeStore defines the store interface needed by this projection.
+type TimetableClassTypeStore interface {
+	List(ctx context.Context) ([]classtype.ClassType, error)
+}
+
+// GetTimetableDeps holds dependencies for the projection.
+type GetTimetableDeps struct {
+	ScheduleStore  TimetableScheduleStore
+	ClassTypeStore TimetableClassTypeStore
+}
+
+// GetTimetableQuery selects the timetable to show.
+type GetTimetableQuery struct {
+	LocationID string // empty = every location
+}
+
+// TimetableSlot is one weekly class placed on the grid.
+type TimetableSlot struct {
+	ScheduleID    string
+	ClassTypeID   string
+	ClassTypeName string
+	StartTime     string
+	EndTime       string
+	StartMinute   int // minutes after midnight
+	EndMinute     int // past 1440 for a class that runs over midnight
+	LocationID    string
+	Mat           string
+	CoachID       string
+	ConflictsWith []string // IDs of classes on the same day overlapping in the same space
+}
+
+// TimetableDay is one column of the week grid.
+type TimetableDay struct {
+	Day   string
+	Slots []TimetableSlot // ordered by start time, then mat
+}
+
+// Timetable is the week grid behind the admin timetable editor.
+type Timetable struct {
+	Days        []TimetableDay // Monday to Sunday, always seven
+	Mats        []string       // named mats in use, sorted; classes on no mat take the whole floor
+	StartMinute int            // earliest start, rounded down to the hour; 0 when empty
+	EndMinute   int            // latest end, rounded up to the hour; 0 when empty
+	Conflicts   int            // number of conflicting pairs
+}
+
+// QueryGetTimetable lays the weekly schedule out as a grid, one column per day, and flags
+// classes that overlap in the same space.
+// PRE: none
+// POST: Returns seven days; slots with unparseable times are left out
+func QueryGetTimetable(ctx context.Context, query GetTimetableQuery, deps GetTimetableDeps) (Timetable, error) {
+	schedules, err := deps.ScheduleStore.List(ctx)
+	if err != nil {
+		return Timetable{}, err
+	}
+	types, err := deps.ClassTypeStore.List(ctx)
+	if err != nil {
+		return Timetable{}, err
+	}
+	typeNames := make(map[string]string, len(types))
+	for _, ct := range types {
+		typeNames[ct.ID] = ct.Name
+	}
+
+	var shown []schedule.Schedule
+	for _, s := range schedules {
+		if location.Matches(s.LocationID, query.LocationID) {
+			shown = append(shown, s)
+		}
+	}
+
+	tt := Timetable{Days: make([]TimetableDay, len(schedule.ValidDays))}
+	dayIndex := make(map[string]int, len(schedule.ValidDays))
+	for i, d := range schedule.ValidDays {
+		tt.Days[i] = TimetableDay{Day: d, Slots: []TimetableSlot{}}
+		dayIndex[d] = i
+	}
+	mats := make(map[string]string) // lower-case name -> first spelling seen
+	for _, s := range shown {
+		i, ok := dayIndex[s.Day]
+		if !ok {
+			continue
+		}
+		start, end, ok := s.Minutes()
+		if !ok {
+			continue
+		}
+		slot := TimetableSlot{
+			ScheduleID:    s.ID,
+			ClassTypeID:   s.ClassTypeID,
+			ClassTypeName: typeNames[s.ClassTypeID],
+			StartTime:     s.StartTime,
+			EndTime:       s.EndTime,
+			StartMinute:   start,
+			EndMinute:     end,
+			LocationID:    s.LocationID,
+			Mat:           s.Mat,
+			CoachID:       s.CoachID,
+			ConflictsWith: []string{},
+		}
+		for _, o := range schedule.Conflicts(s, shown) {
+			slot.ConflictsWith = append(slot.ConflictsWith, o.ID)
+		}
+		tt.Conflicts += len(slot.ConflictsWith)
+		tt.Days[i].Slots = append(tt.Days[i].Slots, slot)
+
+		if s.Mat != "" {
+			if _, seen := mats[strings.ToLower(s.Mat)]; !seen {
+				mats[strings.ToLower(s.Mat)] = s.Mat
+			}
+		}
+		if first := start / 60 * 60; tt.EndMinute == 0 || first < tt.StartMinute {
+			tt.StartMinute = first
+		}
+		if last := (end + 59) / 60 * 60; last > tt.EndMinute {
+			tt.EndMinute = last
+		}
+	}
+	tt.Conflicts /= 2 // each pair was counted from both sides
+
+	for _, d := range tt.Days {
+		sort.SliceStable(d.Slots, func(a, b int) bool {
+			if d.Slots[a].StartMinute != d.Slots[b].StartMinute {
+				return d.Slots[a].StartMinute < d.Slots[b].StartMinute
+			}
+			return d.Slots[a].Mat < d.Slots[b].Mat
+		})
+	}
+	tt.Mats = make([]string, 0, len(mats))
+	for _, m := range mats {
+		tt.Mats = append(tt.Mats, m)
+	}
+	sort.Strings(tt.Mats)
+	return tt, nil
+}
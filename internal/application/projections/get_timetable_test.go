@@ -0,0 +1,80 @@
+package projections
+
+import (
+	"context"
+	"testing"
+
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/schedule"
+)
+
+// --- Mock stores for timetable tests ---
+
+type mockTTScheduleStore struct {
+	schedules []schedule.Schedule
+}
+
+// List returns all schedules.
+// PRE: none
+// POST: Returns the schedules
+func (m *mockTTScheduleStore) List(_ context.Context) ([]schedule.Schedule, error) {
+	return m.schedules, nil
+}
+
+type mockTTClassTypeStore struct{}
+
+// List returns the class types used in the tests.
+// PRE: none
+// POST: Returns the class types
+func (m *mockTTClassTypeStore) List(_ context.Context) ([]classtype.ClassType, error) {
+	return []classtype.ClassType{{ID: "gi", Name: "Gi"}, {ID: "nogi", Name: "No-Gi"}}, nil
+}
+
+// TestQueryGetTimetable verifies classes are placed by day and start time, the grid bounds
+// cover every class, and overlaps in the same space are flagged on both classes.
+func TestQueryGetTimetable(t *testing.T) {
+	store := &mockTTScheduleStore{schedules: []schedule.Schedule{
+		{ID: "mon-late", ClassTypeID: "nogi", Day: schedule.Monday, StartTime: "19:00", EndTime: "20:30", LocationID: "wgtn"},
+		{ID: "mon-early", ClassTypeID: "gi", Day: schedule.Monday, StartTime: "06:15", EndTime: "07:15", LocationID: "wgtn"},
+		{ID: "mon-clash", ClassTypeID: "gi", Day: schedule.Monday, StartTime: "20:00", EndTime: "21:00", LocationID: "wgtn"},
+		{ID: "wed-mat1", ClassTypeID: "gi", Day: schedule.Wednesday, StartTime: "18:00", EndTime: "19:00", Mat: "Mat 1"},
+		{ID: "wed-mat2", ClassTypeID: "nogi", Day: schedule.Wednesday, StartTime: "18:00", EndTime: "19:00", Mat: "Mat 2"},
+		{ID: "akl", ClassTypeID: "gi", Day: schedule.Friday, StartTime: "12:00", EndTime: "13:00", LocationID: "akl"},
+	}}
+	deps := GetTimetableDeps{ScheduleStore: store, ClassTypeStore: &mockTTClassTypeStore{}}
+
+	tt, err := QueryGetTimetable(context.Background(), GetTimetableQuery{LocationID: "wgtn"}, deps)
+	if err != nil {
+		t.Fatalf("QueryGetTimetable: %v", err)
+	}
+	if len(tt.Days) != 7 || tt.Days[0].Day != schedule.Monday || tt.Days[6].Day != schedule.Sunday {
+		t.Fatalf("expected Monday to Sunday, got %+v", tt.Days)
+	}
+	mon := tt.Days[0].Slots
+	if len(mon) != 3 || mon[0].ScheduleID != "mon-early" || mon[0].ClassTypeName != "Gi" || mon[0].StartMinute != 375 {
+		t.Fatalf("expected Monday ordered by start time, got %+v", mon)
+	}
+	if len(mon[1].ConflictsWith) != 1 || mon[1].ConflictsWith[0] != "mon-clash" || len(mon[2].ConflictsWith) != 1 {
+		t.Errorf("expected the late classes flagged against each other, got %+v", mon)
+	}
+	if len(mon[0].ConflictsWith) != 0 {
+		t.Errorf("expected the early class clear, got %v", mon[0].ConflictsWith)
+	}
+	if wed := tt.Days[2].Slots; len(wed) != 2 || len(wed[0].ConflictsWith) != 0 || wed[0].Mat != "Mat 1" {
+		t.Errorf("expected side-by-side mats without conflicts, got %+v", wed)
+	}
+	if len(tt.Days[4].Slots) != 0 {
+		t.Errorf("expected the other location's class hidden, got %+v", tt.Days[4].Slots)
+	}
+	if tt.StartMinute != 6*60 || tt.EndMinute != 21*60 || tt.Conflicts != 1 {
+		t.Errorf("expected 06:00-21:00 with one conflict, got %d-%d with %d", tt.StartMinute, tt.EndMinute, tt.Conflicts)
+	}
+	if len(tt.Mats) != 2 || tt.Mats[0] != "Mat 1" {
+		t.Errorf("expected both mats listed, got %v", tt.Mats)
+	}
+
+	all, err := QueryGetTimetable(context.Background(), GetTimetableQuery{}, deps)
+	if err != nil || len(all.Days[4].Slots) != 1 {
+		t.Errorf("expected every location without a filter, got %+v, %v", all.Days[4], err)
+	}
+}
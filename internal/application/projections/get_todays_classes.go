@@ -2,11 +2,13 @@ package projections
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 
 	"workshop/internal/domain/classtype"
 	"workshop/internal/domain/holiday"
+	"workshop/internal/domain/location"
 	"workshop/internal/domain/program"
 	"workshop/internal/domain/schedule"
 	"workshop/internal/domain/term"
@@ -37,6 +39,11 @@ type TodaysClassesProgramStore interface {
 	GetByID(ctx context.Context, id string) (program.Program, error)
 }
 
+// TodaysClassesChangeStore defines the occurrence change store interface needed by this projection.
+type TodaysClassesChangeStore interface {
+	ListByDateRange(ctx context.Context, from, to string) ([]schedule.OccurrenceChange, error)
+}
+
 // GetTodaysClassesDeps holds dependencies for the projection.
 type GetTodaysClassesDeps struct {
 	ScheduleStore  TodaysClassesScheduleStore
@@ -44,6 +51,7 @@ type GetTodaysClassesDeps struct {
 	HolidayStore   TodaysClassesHolidayStore
 	ClassTypeStore TodaysClassesClassTypeStore
 	ProgramStore   TodaysClassesProgramStore
+	ChangeStore    TodaysClassesChangeStore // optional: nil ignores today's class changes
 }
 
 // TodaysClassResult represents a single class session resolved for today.
@@ -57,52 +65,85 @@ type TodaysClassResult struct {
 	Day           string
 	StartTime     string
 	EndTime       string
+	LocationID    string
+	Mat           string // empty = the usual mat
+	CoachID       string // AccountID of the regular coach; empty = unassigned
+	Substitute    string // coach covering this occurrence; empty when the regular coach takes it
+	Change        string // schedule.ChangeMoved or ChangeAdded when today differs from the timetable
+	MatCapacity   int    // from the class type; 0 = no limit
+	Attire        string // from the class type: gi, nogi or both; empty = not specified
+
+	// Prerequisites from the class type, checked at check-in.
+	MinBelt          string // empty = no minimum
+	RequiresApproval bool
+	// Filled in for a member who does not meet them; both empty when they may attend.
+	NeedsApproval bool   // only members a coach has approved may attend
+	NeedsBelt     string // the belt the member has not reached; a coach's approval also lets them in
 }
 
 // QueryGetTodaysClasses resolves today's classes on-the-fly from Schedule + Terms - Holidays.
 // Algorithm: 1) Get today's day-of-week, 2) Check if today is within a term,
-// 3) Check if today is a holiday, 4) If in-term and not-holiday, return matching schedules.
+// 3) Check if today is a holiday, 4) If in-term and not-holiday, return matching schedules
+// less any occurrence cancelled for today, at their moved time and mat, 5) Add one-off
+// sessions for today, which run whether or not the regular timetable does.
 func QueryGetTodaysClasses(ctx context.Context, now time.Time, deps GetTodaysClassesDeps) ([]TodaysClassResult, error) {
-	// Step 1: Check if today falls within any term
-	terms, err := deps.TermStore.List(ctx)
-	if err != nil {
-		return nil, err
-	}
+	return QueryGetTodaysClassesAtLocation(ctx, now, "", deps)
+}
 
-	inTerm := false
-	for _, t := range terms {
-		if t.Contains(now) {
-			inTerm = true
-			break
+// QueryGetTodaysClassesAtLocation resolves today's classes offered at a single location.
+// Schedules with no location are offered everywhere; an empty locationID returns all classes.
+// PRE: now is a valid time
+// POST: Returns only classes whose schedule matches the location filter
+func QueryGetTodaysClassesAtLocation(ctx context.Context, now time.Time, locationID string, deps GetTodaysClassesDeps) ([]TodaysClassResult, error) {
+	// Step 1: Load today's class changes
+	today := now.Format("2006-01-02")
+	changes := make(map[string]schedule.OccurrenceChange)
+	if deps.ChangeStore != nil {
+		list, err := deps.ChangeStore.ListByDateRange(ctx, today, today)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range list {
+			changes[c.ScheduleID] = c
 		}
 	}
 
-	if !inTerm {
-		return nil, nil // No classes outside of term time
-	}
-
-	// Step 2: Check if today is a holiday
-	holidays, err := deps.HolidayStore.List(ctx)
+	// Step 2: Resolve the regular timetable, if it runs today
+	runs, err := regularClassesRun(ctx, now, deps)
 	if err != nil {
 		return nil, err
 	}
-
-	for _, h := range holidays {
-		if h.Contains(now) {
-			return nil, nil // No classes on holidays
+	var sessions []schedule.Schedule
+	if runs {
+		dayName := strings.ToLower(now.Weekday().String())
+		schedules, err := deps.ScheduleStore.ListByDay(ctx, dayName)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range schedules {
+			change := changes[s.ID]
+			if moved, ok := change.Apply(s); ok {
+				sessions = append(sessions, moved)
+			}
 		}
 	}
 
-	// Step 3: Get schedules for today's day of week
-	dayName := strings.ToLower(now.Weekday().String())
-	schedules, err := deps.ScheduleStore.ListByDay(ctx, dayName)
-	if err != nil {
-		return nil, err
+	// Step 3: Add today's one-off sessions
+	for _, c := range changes {
+		if c.IsAdded() {
+			sessions = append(sessions, c.Session())
+		}
 	}
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].StartTime < sessions[j].StartTime })
 
 	// Step 4: Enrich with class type and program info
 	var results []TodaysClassResult
-	for _, s := range schedules {
+	for _, s := range sessions {
+		if !location.Matches(s.LocationID, locationID) {
+			continue
+		}
+		change := changes[s.ID]
+
 		ct, err := deps.ClassTypeStore.GetByID(ctx, s.ClassTypeID)
 		if err != nil {
 			continue // Skip if class type not found
@@ -123,8 +164,55 @@ func QueryGetTodaysClasses(ctx context.Context, now time.Time, deps GetTodaysCla
 			Day:           s.Day,
 			StartTime:     s.StartTime,
 			EndTime:       s.EndTime,
+			LocationID:    s.LocationID,
+			Mat:           s.Mat,
+			CoachID:       s.CoachID,
+			Substitute:    change.Substitute,
+			Change:        todaysChangeKind(change),
+			MatCapacity:   ct.MatCapacity,
+			Attire:        ct.Attire,
+
+			MinBelt:          ct.MinBelt,
+			RequiresApproval: ct.RequiresApproval,
 		})
 	}
 
 	return results, nil
 }
+
+// regularClassesRun reports whether the weekly timetable runs on now: within a term and not on a holiday.
+func regularClassesRun(ctx context.Context, now time.Time, deps GetTodaysClassesDeps) (bool, error) {
+	terms, err := deps.TermStore.List(ctx)
+	if err != nil {
+		return false, err
+	}
+	inTerm := false
+	for _, t := range terms {
+		if t.Contains(now) {
+			inTerm = true
+			break
+		}
+	}
+	if !inTerm {
+		return false, nil // No classes outside of term time
+	}
+
+	holidays, err := deps.HolidayStore.List(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, h := range holidays {
+		if h.Contains(now) {
+			return false, nil // No classes on holidays
+		}
+	}
+	return true, nil
+}
+
+// todaysChangeKind returns the change kind worth showing beside a class; substitutes are shown by name instead.
+func todaysChangeKind(c schedule.OccurrenceChange) string {
+	if c.Kind == schedule.ChangeMoved || c.Kind == schedule.ChangeAdded {
+		return c.Kind
+	}
+	return ""
+}
@@ -0,0 +1,214 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/classtype"
+	"workshop/internal/domain/holiday"
+	"workshop/internal/domain/program"
+	"workshop/internal/domain/schedule"
+	"workshop/internal/domain/term"
+)
+
+// --- Mock stores for today's classes tests ---
+
+type mockTCScheduleStore struct {
+	schedules []schedule.Schedule
+}
+
+// ListByDay returns schedules for the given day.
+// PRE: day is a valid weekday
+// POST: Returns schedules matching the day
+func (m *mockTCScheduleStore) ListByDay(_ context.Context, day string) ([]schedule.Schedule, error) {
+	var out []schedule.Schedule
+	for _, s := range m.schedules {
+		if s.Day == day {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+type mockTCClassTypeStore struct{}
+
+// GetByID returns a class type named after its ID.
+// PRE: id is non-empty
+// POST: Returns a class type in program p1
+func (m *mockTCClassTypeStore) GetByID(_ context.Context, id string) (classtype.ClassType, error) {
+	return classtype.ClassType{ID: id, ProgramID: "p1", Name: "Class " + id}, nil
+}
+
+type mockTCProgramStore struct{}
+
+// GetByID returns program p1.
+// PRE: id is non-empty
+// POST: Returns the program or an error
+func (m *mockTCProgramStore) GetByID(_ context.Context, id string) (program.Program, error) {
+	if id != "p1" {
+		return program.Program{}, fmt.Errorf("program not found")
+	}
+	return program.Program{ID: "p1", Name: "Adults", Type: program.TypeAdults}, nil
+}
+
+type mockTCTermStore struct {
+	terms []term.Term
+}
+
+// List returns all terms.
+// PRE: none
+// POST: Returns terms list
+func (m *mockTCTermStore) List(_ context.Context) ([]term.Term, error) {
+	return m.terms, nil
+}
+
+type mockTCHolidayStore struct{}
+
+// List returns no holidays.
+// PRE: none
+// POST: Returns empty list
+func (m *mockTCHolidayStore) List(_ context.Context) ([]holiday.Holiday, error) {
+	return nil, nil
+}
+
+// TestQueryGetTodaysClassesAtLocation_FiltersByLocation verifies location scoping of today's classes.
+func TestQueryGetTodaysClassesAtLocation_FiltersByLocation(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	deps := GetTodaysClassesDeps{
+		ScheduleStore: &mockTCScheduleStore{schedules: []schedule.Schedule{
+			{ID: "s-shared", ClassTypeID: "ct1", Day: schedule.Monday, StartTime: "06:00", EndTime: "07:00"},
+			{ID: "s-central", ClassTypeID: "ct2", Day: schedule.Monday, StartTime: "12:00", EndTime: "13:00", LocationID: "central"},
+			{ID: "s-north", ClassTypeID: "ct3", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:00", LocationID: "north"},
+		}},
+		TermStore: &mockTCTermStore{terms: []term.Term{
+			{ID: "t1", Name: "Term 1", StartDate: monday.AddDate(0, -1, 0), EndDate: monday.AddDate(0, 1, 0)},
+		}},
+		HolidayStore:   &mockTCHolidayStore{},
+		ClassTypeStore: &mockTCClassTypeStore{},
+		ProgramStore:   &mockTCProgramStore{},
+	}
+
+	tests := []struct {
+		name     string
+		location string
+		want     []string
+	}{
+		{"no filter returns all", "", []string{"s-shared", "s-central", "s-north"}},
+		{"central includes shared", "central", []string{"s-shared", "s-central"}},
+		{"north includes shared", "north", []string{"s-shared", "s-north"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := QueryGetTodaysClassesAtLocation(context.Background(), monday, tt.location, deps)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(results) != len(tt.want) {
+				t.Fatalf("got %d classes, want %d", len(results), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if results[i].ScheduleID != id {
+					t.Errorf("results[%d].ScheduleID = %q, want %q", i, results[i].ScheduleID, id)
+				}
+			}
+		})
+	}
+}
+
+type mockTCChangeStore struct {
+	changes []schedule.OccurrenceChange
+}
+
+// ListByDateRange returns changes whose class date falls in the range.
+// PRE: from and to are YYYY-MM-DD
+// POST: Returns matching changes
+func (m *mockTCChangeStore) ListByDateRange(_ context.Context, from, to string) ([]schedule.OccurrenceChange, error) {
+	var out []schedule.OccurrenceChange
+	for _, c := range m.changes {
+		if c.ClassDate >= from && c.ClassDate <= to {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// TestQueryGetTodaysClasses_OccurrenceChanges verifies cancelled classes are hidden and substitutes shown.
+func TestQueryGetTodaysClasses_OccurrenceChanges(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	deps := GetTodaysClassesDeps{
+		ScheduleStore: &mockTCScheduleStore{schedules: []schedule.Schedule{
+			{ID: "s-am", ClassTypeID: "ct1", Day: schedule.Monday, StartTime: "06:00", EndTime: "07:00"},
+			{ID: "s-noon", ClassTypeID: "ct1", Day: schedule.Monday, StartTime: "12:00", EndTime: "13:00"},
+			{ID: "s-pm", ClassTypeID: "ct2", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:00"},
+		}},
+		TermStore: &mockTCTermStore{terms: []term.Term{
+			{ID: "t1", Name: "Term 1", StartDate: monday.AddDate(0, -1, 0), EndDate: monday.AddDate(0, 1, 0)},
+		}},
+		HolidayStore:   &mockTCHolidayStore{},
+		ClassTypeStore: &mockTCClassTypeStore{},
+		ProgramStore:   &mockTCProgramStore{},
+		ChangeStore: &mockTCChangeStore{changes: []schedule.OccurrenceChange{
+			{ScheduleID: "s-am", ClassDate: "2026-03-02", Kind: schedule.ChangeCancelled},
+			{ScheduleID: "s-pm", ClassDate: "2026-03-02", Kind: schedule.ChangeSubstitute, Substitute: "Coach Sam"},
+			{ScheduleID: "s-noon", ClassDate: "2026-03-09", Kind: schedule.ChangeCancelled}, // next week
+		}},
+	}
+
+	results, err := QueryGetTodaysClasses(context.Background(), monday, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 || results[0].ScheduleID != "s-noon" || results[1].ScheduleID != "s-pm" {
+		t.Fatalf("results = %+v, want s-noon and s-pm", results)
+	}
+	if results[0].Substitute != "" || results[1].Substitute != "Coach Sam" {
+		t.Errorf("substitutes = %q, %q, want only s-pm covered", results[0].Substitute, results[1].Substitute)
+	}
+}
+
+// TestQueryGetTodaysClasses_MovedAndAddedSessions verifies moved classes take their new time and
+// mat, and one-off sessions are listed in time order even outside term.
+func TestQueryGetTodaysClasses_MovedAndAddedSessions(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	deps := GetTodaysClassesDeps{
+		ScheduleStore: &mockTCScheduleStore{schedules: []schedule.Schedule{
+			{ID: "s-am", ClassTypeID: "ct1", Day: schedule.Monday, StartTime: "06:00", EndTime: "07:00", Mat: "Mat 1"},
+			{ID: "s-pm", ClassTypeID: "ct2", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:00"},
+		}},
+		TermStore: &mockTCTermStore{terms: []term.Term{
+			{ID: "t1", Name: "Term 1", StartDate: monday.AddDate(0, -1, 0), EndDate: monday.AddDate(0, 1, 0)},
+		}},
+		HolidayStore:   &mockTCHolidayStore{},
+		ClassTypeStore: &mockTCClassTypeStore{},
+		ProgramStore:   &mockTCProgramStore{},
+		ChangeStore: &mockTCChangeStore{changes: []schedule.OccurrenceChange{
+			{ScheduleID: "s-am", ClassDate: "2026-03-02", Kind: schedule.ChangeMoved, StartTime: "20:00", EndTime: "21:00"},
+			{ID: "x1", ScheduleID: "x1", ClassDate: "2026-03-02", Kind: schedule.ChangeAdded, ClassTypeID: "ct3", StartTime: "12:00", EndTime: "13:00", Mat: "Mat 2"},
+		}},
+	}
+
+	results, err := QueryGetTodaysClasses(context.Background(), monday, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 3 || results[0].ScheduleID != "x1" || results[1].ScheduleID != "s-pm" || results[2].ScheduleID != "s-am" {
+		t.Fatalf("results = %+v, want x1, s-pm then the moved s-am", results)
+	}
+	if r := results[2]; r.StartTime != "20:00" || r.Mat != "Mat 1" || r.Change != schedule.ChangeMoved {
+		t.Errorf("moved class = %+v, want 20:00 on its usual mat", r)
+	}
+	if r := results[0]; r.ClassTypeName != "Class ct3" || r.Mat != "Mat 2" || r.Change != schedule.ChangeAdded {
+		t.Errorf("added session = %+v", r)
+	}
+
+	deps.TermStore = &mockTCTermStore{}
+	results, err = QueryGetTodaysClasses(context.Background(), monday, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].ScheduleID != "x1" {
+		t.Errorf("outside term = %+v, want only the added session", results)
+	}
+}
@@ -0,0 +1,155 @@
+package projections
+
+import (
+	"context"
+	"sort"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/rotor"
+)
+
+// Coverage gaps: why a topic reached nobody.
+const (
+	CoverageGapNotRun       = "not_run"       // the topic has not been taught in this rotor
+	CoverageGapNoAttendance = "no_attendance" // taught, but no check-ins were linked to it
+)
+
+// TopicCoverageRotorStore defines the rotor store interface needed by the topic coverage projection.
+type TopicCoverageRotorStore interface {
+	GetRotor(ctx context.Context, id string) (rotor.Rotor, error)
+	ListThemesByRotor(ctx context.Context, rotorID string) ([]rotor.RotorTheme, error)
+	ListTopicsByTheme(ctx context.Context, rotorThemeID string) ([]rotor.Topic, error)
+	ListSchedulesByTheme(ctx context.Context, rotorThemeID string) ([]rotor.TopicSchedule, error)
+}
+
+// TopicCoverageLinkStore defines the attendance store interface needed by the topic coverage projection.
+type TopicCoverageLinkStore interface {
+	ListTopicLinksByRotor(ctx context.Context, rotorID string) ([]attendance.TopicLink, error)
+}
+
+// GetTopicCoverageQuery carries input for the topic coverage projection.
+type GetTopicCoverageQuery struct {
+	RotorID string
+}
+
+// GetTopicCoverageDeps holds dependencies for the topic coverage projection.
+type GetTopicCoverageDeps struct {
+	RotorStore TopicCoverageRotorStore
+	LinkStore  TopicCoverageLinkStore
+}
+
+// TopicCoverageResult is one rotor cycle's coverage: who was on the mat for each topic.
+type TopicCoverageResult struct {
+	RotorID   string               `json:"rotor_id"`
+	RotorName string               `json:"rotor_name"`
+	Status    string               `json:"status"`
+	Cohort    int                  `json:"cohort"` // members linked to at least one topic in the rotor
+	Gaps      int                  `json:"gaps"`   // topics that reached nobody
+	Themes    []TopicCoverageTheme `json:"themes"`
+}
+
+// TopicCoverageTheme is one theme's topics in queue order.
+type TopicCoverageTheme struct {
+	ThemeID   string               `json:"theme_id"`
+	ThemeName string               `json:"theme_name"`
+	Topics    []TopicCoverageTopic `json:"topics"`
+}
+
+// TopicCoverageTopic counts attendance while a topic was being taught.
+type TopicCoverageTopic struct {
+	TopicID     string `json:"topic_id"`
+	TopicName   string `json:"topic_name"`
+	Runs        int    `json:"runs"`        // times the topic was taught, including a run in progress
+	LastRun     string `json:"last_run"`    // YYYY-MM-DD start of the latest run; empty if never run
+	Sessions    int    `json:"sessions"`    // distinct class dates with linked attendance
+	Attendances int    `json:"attendances"` // linked check-ins
+	Headcount   int    `json:"headcount"`   // distinct members exposed to the topic
+	Missed      int    `json:"missed"`      // members of the cohort not exposed to the topic
+	Gap         string `json:"gap"`         // CoverageGapNotRun, CoverageGapNoAttendance, or empty
+}
+
+// QueryGetTopicCoverage reports, for one rotor cycle, how many members were on the mat for each
+// topic, from the attendance linked to topics at check-in. A topic with no headcount is a gap.
+// PRE: query.RotorID is non-empty; deps are valid
+// POST: returns topics in theme and queue order, or the rotor lookup error
+func QueryGetTopicCoverage(ctx context.Context, query GetTopicCoverageQuery, deps GetTopicCoverageDeps) (TopicCoverageResult, error) {
+	r, err := deps.RotorStore.GetRotor(ctx, query.RotorID)
+	if err != nil {
+		return TopicCoverageResult{}, err
+	}
+	result := TopicCoverageResult{RotorID: r.ID, RotorName: r.Name, Status: r.Status, Themes: []TopicCoverageTheme{}}
+
+	links, err := deps.LinkStore.ListTopicLinksByRotor(ctx, r.ID)
+	if err != nil {
+		return result, err
+	}
+	type tally struct {
+		attendances int
+		members     map[string]bool
+		dates       map[string]bool
+	}
+	byTopic := map[string]*tally{}
+	cohort := map[string]bool{}
+	for _, l := range links {
+		t := byTopic[l.TopicID]
+		if t == nil {
+			t = &tally{members: map[string]bool{}, dates: map[string]bool{}}
+			byTopic[l.TopicID] = t
+		}
+		t.attendances++
+		t.members[l.MemberID] = true
+		t.dates[l.ClassDate] = true
+		cohort[l.MemberID] = true
+	}
+	result.Cohort = len(cohort)
+
+	themes, err := deps.RotorStore.ListThemesByRotor(ctx, r.ID)
+	if err != nil {
+		return result, err
+	}
+	sort.SliceStable(themes, func(i, j int) bool { return themes[i].Position < themes[j].Position })
+	for _, th := range themes {
+		topics, err := deps.RotorStore.ListTopicsByTheme(ctx, th.ID)
+		if err != nil {
+			return result, err
+		}
+		sort.SliceStable(topics, func(i, j int) bool { return topics[i].Position < topics[j].Position })
+		history, err := deps.RotorStore.ListSchedulesByTheme(ctx, th.ID)
+		if err != nil {
+			return result, err
+		}
+
+		view := TopicCoverageTheme{ThemeID: th.ID, ThemeName: th.Name, Topics: []TopicCoverageTopic{}}
+		for _, tp := range topics {
+			row := TopicCoverageTopic{TopicID: tp.ID, TopicName: tp.Name}
+			for _, h := range history {
+				if h.TopicID != tp.ID || (h.Status != rotor.ScheduleStatusActive && h.Status != rotor.ScheduleStatusCompleted) {
+					continue
+				}
+				row.Runs++
+				if start := h.StartDate.Format("2006-01-02"); start > row.LastRun {
+					row.LastRun = start
+				}
+			}
+			if t := byTopic[tp.ID]; t != nil {
+				row.Sessions = len(t.dates)
+				row.Attendances = t.attendances
+				row.Headcount = len(t.members)
+			}
+			row.Missed = result.Cohort - row.Headcount
+			switch {
+			case row.Headcount > 0:
+			case row.Runs == 0:
+				row.Gap = CoverageGapNotRun
+			default:
+				row.Gap = CoverageGapNoAttendance
+			}
+			if row.Gap != "" {
+				result.Gaps++
+			}
+			view.Topics = append(view.Topics, row)
+		}
+		result.Themes = append(result.Themes, view)
+	}
+	return result, nil
+}
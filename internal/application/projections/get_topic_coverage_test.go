@@ -0,0 +1,105 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/rotor"
+)
+
+// mockCoverageRotorStore implements TopicCoverageRotorStore for testing.
+type mockCoverageRotorStore struct {
+	mockRotorPreviewStore
+	rotors map[string]rotor.Rotor
+}
+
+// GetRotor implements TopicCoverageRotorStore.
+// PRE: id is non-empty
+// POST: returns the rotor or an error if unknown
+func (m *mockCoverageRotorStore) GetRotor(_ context.Context, id string) (rotor.Rotor, error) {
+	r, ok := m.rotors[id]
+	if !ok {
+		return rotor.Rotor{}, errors.New("rotor not found")
+	}
+	return r, nil
+}
+
+// mockCoverageLinkStore implements TopicCoverageLinkStore for testing.
+type mockCoverageLinkStore struct {
+	links []attendance.TopicLink
+}
+
+// ListTopicLinksByRotor implements TopicCoverageLinkStore.
+// PRE: rotorID is non-empty
+// POST: returns the rotor's links
+func (m *mockCoverageLinkStore) ListTopicLinksByRotor(_ context.Context, rotorID string) ([]attendance.TopicLink, error) {
+	var out []attendance.TopicLink
+	for _, l := range m.links {
+		if l.RotorID == rotorID {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+// TestQueryGetTopicCoverage verifies headcounts, sessions and gaps per topic across a rotor cycle.
+func TestQueryGetTopicCoverage(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	store := &mockCoverageRotorStore{rotors: map[string]rotor.Rotor{"r1": {ID: "r1", Name: "Term 1", Status: rotor.StatusActive}}}
+	store.themes = map[string][]rotor.RotorTheme{"r1": {{ID: "th2", Name: "Escapes", Position: 1}, {ID: "th1", Name: "Guard", Position: 0}}}
+	store.topics = map[string][]rotor.Topic{
+		"th1": {{ID: "t1", Name: "Closed Guard", Position: 0}, {ID: "t2", Name: "Half Guard", Position: 1}},
+		"th2": {{ID: "t3", Name: "Triangle Escapes", Position: 0}, {ID: "t4", Name: "Mount Escapes", Position: 1}},
+	}
+	store.history = map[string][]rotor.TopicSchedule{
+		"th1": {
+			{TopicID: "t2", StartDate: day(2), Status: rotor.ScheduleStatusSkipped},
+			{TopicID: "t1", StartDate: day(2), Status: rotor.ScheduleStatusActive},
+		},
+		"th2": {
+			{TopicID: "t3", StartDate: day(2), Status: rotor.ScheduleStatusCompleted},
+			{TopicID: "t4", StartDate: day(9), Status: rotor.ScheduleStatusCompleted},
+			{TopicID: "t3", StartDate: day(16), Status: rotor.ScheduleStatusActive},
+		},
+	}
+	links := &mockCoverageLinkStore{links: []attendance.TopicLink{
+		{AttendanceID: "a1", TopicID: "t1", RotorID: "r1", MemberID: "m1", ClassDate: "2026-03-02"},
+		{AttendanceID: "a1", TopicID: "t3", RotorID: "r1", MemberID: "m1", ClassDate: "2026-03-02"},
+		{AttendanceID: "a2", TopicID: "t3", RotorID: "r1", MemberID: "m2", ClassDate: "2026-03-02"},
+		{AttendanceID: "a3", TopicID: "t3", RotorID: "r1", MemberID: "m1", ClassDate: "2026-03-16"},
+		{AttendanceID: "a4", TopicID: "t3", RotorID: "r1", MemberID: "m3", ClassDate: "2026-03-16"},
+		{AttendanceID: "a5", TopicID: "t3", RotorID: "r-old", MemberID: "m4", ClassDate: "2025-11-03"},
+	}}
+
+	result, err := QueryGetTopicCoverage(context.Background(), GetTopicCoverageQuery{RotorID: "r1"}, GetTopicCoverageDeps{RotorStore: store, LinkStore: links})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Cohort != 3 || result.Gaps != 2 {
+		t.Errorf("cohort = %d, gaps = %d; want 3 members and 2 gaps", result.Cohort, result.Gaps)
+	}
+	if len(result.Themes) != 2 || result.Themes[0].ThemeID != "th1" {
+		t.Fatalf("expected themes in position order, got %+v", result.Themes)
+	}
+	tests := []struct {
+		theme, topic int
+		want         TopicCoverageTopic
+	}{
+		{0, 0, TopicCoverageTopic{TopicID: "t1", TopicName: "Closed Guard", Runs: 1, LastRun: "2026-03-02", Sessions: 1, Attendances: 1, Headcount: 1, Missed: 2}},
+		{0, 1, TopicCoverageTopic{TopicID: "t2", TopicName: "Half Guard", Missed: 3, Gap: CoverageGapNotRun}},
+		{1, 0, TopicCoverageTopic{TopicID: "t3", TopicName: "Triangle Escapes", Runs: 2, LastRun: "2026-03-16", Sessions: 2, Attendances: 4, Headcount: 3}},
+		{1, 1, TopicCoverageTopic{TopicID: "t4", TopicName: "Mount Escapes", Runs: 1, LastRun: "2026-03-09", Missed: 3, Gap: CoverageGapNoAttendance}},
+	}
+	for _, tt := range tests {
+		if got := result.Themes[tt.theme].Topics[tt.topic]; got != tt.want {
+			t.Errorf("topic %s = %+v, want %+v", tt.want.TopicID, got, tt.want)
+		}
+	}
+
+	if _, err := QueryGetTopicCoverage(context.Background(), GetTopicCoverageQuery{RotorID: "missing"}, GetTopicCoverageDeps{RotorStore: store, LinkStore: links}); err == nil {
+		t.Error("expected an error for an unknown rotor")
+	}
+}
@@ -0,0 +1,77 @@
+package projections
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/domain/rotor"
+)
+
+// TopicVotesRotorStore defines the rotor store interface needed by the topic votes projection.
+type TopicVotesRotorStore interface {
+	ListVotesByAccount(ctx context.Context, accountID string) ([]rotor.Vote, error)
+	GetTopic(ctx context.Context, id string) (rotor.Topic, error)
+	GetRotorTheme(ctx context.Context, id string) (rotor.RotorTheme, error)
+}
+
+// GetTopicVotesQuery carries input for the topic votes projection.
+type GetTopicVotesQuery struct {
+	AccountID string
+	Now       time.Time // votes that have expired by now are left out
+}
+
+// GetTopicVotesDeps holds dependencies for the topic votes projection.
+type GetTopicVotesDeps struct {
+	RotorStore TopicVotesRotorStore
+}
+
+// TopicVoteView is one of an account's active votes.
+type TopicVoteView struct {
+	TopicID   string     `json:"topic_id"`
+	TopicName string     `json:"topic_name"`
+	ThemeID   string     `json:"theme_id"`
+	ThemeName string     `json:"theme_name"`
+	Weight    int        `json:"weight"`
+	CastAt    time.Time  `json:"cast_at"`
+	ExpiresAt *time.Time `json:"expires_at"` // nil if the vote never expires
+}
+
+// QueryGetTopicVotes returns an account's votes that still count, newest first.
+// Votes for topics that have since been deleted are left out.
+// PRE: AccountID is non-empty
+// POST: returns the active votes, or an empty slice
+func QueryGetTopicVotes(ctx context.Context, query GetTopicVotesQuery, deps GetTopicVotesDeps) ([]TopicVoteView, error) {
+	votes, err := deps.RotorStore.ListVotesByAccount(ctx, query.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	result := []TopicVoteView{}
+	themeNames := map[string]string{}
+	for _, v := range votes {
+		if !v.IsActive(query.Now) {
+			continue
+		}
+		topic, err := deps.RotorStore.GetTopic(ctx, v.TopicID)
+		if err != nil {
+			continue
+		}
+		if _, ok := themeNames[topic.RotorThemeID]; !ok {
+			theme, _ := deps.RotorStore.GetRotorTheme(ctx, topic.RotorThemeID)
+			themeNames[topic.RotorThemeID] = theme.Name
+		}
+		view := TopicVoteView{
+			TopicID:   topic.ID,
+			TopicName: topic.Name,
+			ThemeID:   topic.RotorThemeID,
+			ThemeName: themeNames[topic.RotorThemeID],
+			Weight:    v.Weight,
+			CastAt:    v.CreatedAt,
+		}
+		if !v.ExpiresAt.IsZero() {
+			expires := v.ExpiresAt
+			view.ExpiresAt = &expires
+		}
+		result = append(result, view)
+	}
+	return result, nil
+}
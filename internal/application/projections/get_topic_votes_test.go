@@ -0,0 +1,70 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/rotor"
+)
+
+type mockTopicVotesStore struct {
+	votes  []rotor.Vote
+	topics map[string]rotor.Topic
+}
+
+// ListVotesByAccount returns an account's votes.
+// PRE: accountID is non-empty
+// POST: Returns the account's votes
+func (m *mockTopicVotesStore) ListVotesByAccount(_ context.Context, accountID string) ([]rotor.Vote, error) {
+	var result []rotor.Vote
+	for _, v := range m.votes {
+		if v.AccountID == accountID {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+// GetTopic returns a topic by ID.
+// PRE: id is non-empty
+// POST: Returns the topic or an error
+func (m *mockTopicVotesStore) GetTopic(_ context.Context, id string) (rotor.Topic, error) {
+	if t, ok := m.topics[id]; ok {
+		return t, nil
+	}
+	return rotor.Topic{}, errors.New("topic not found")
+}
+
+// GetRotorTheme returns a theme named after its ID.
+// PRE: id is non-empty
+// POST: Returns the theme
+func (m *mockTopicVotesStore) GetRotorTheme(_ context.Context, id string) (rotor.RotorTheme, error) {
+	return rotor.RotorTheme{ID: id, Name: "Guard"}, nil
+}
+
+// TestQueryGetTopicVotes verifies only the account's active votes for existing topics are listed.
+func TestQueryGetTopicVotes(t *testing.T) {
+	now := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	store := &mockTopicVotesStore{
+		topics: map[string]rotor.Topic{
+			"dlr":    {ID: "dlr", RotorThemeID: "guard", Name: "DLR Sweeps"},
+			"closed": {ID: "closed", RotorThemeID: "guard", Name: "Closed Guard"},
+		},
+		votes: []rotor.Vote{
+			{ID: "v1", TopicID: "dlr", AccountID: "m1", Weight: 1, CreatedAt: now.AddDate(0, 0, -1), ExpiresAt: now.AddDate(0, 0, 13)},
+			{ID: "v2", TopicID: "closed", AccountID: "m1", Weight: 1, CreatedAt: now.AddDate(0, 0, -20), ExpiresAt: now.AddDate(0, 0, -6)},
+			{ID: "v3", TopicID: "deleted", AccountID: "m1", Weight: 1},
+			{ID: "v4", TopicID: "closed", AccountID: "m2", Weight: 3},
+		},
+	}
+
+	votes, err := QueryGetTopicVotes(context.Background(), GetTopicVotesQuery{AccountID: "m1", Now: now}, GetTopicVotesDeps{RotorStore: store})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(votes) != 1 || votes[0].TopicName != "DLR Sweeps" || votes[0].ThemeName != "Guard" || votes[0].ExpiresAt == nil {
+		t.Fatalf("votes = %+v, want only the active DLR vote", votes)
+	}
+}
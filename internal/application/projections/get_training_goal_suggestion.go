@@ -0,0 +1,118 @@
+package projections
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	domainAttendance "workshop/internal/domain/attendance"
+	"workshop/internal/domain/traininggoal"
+)
+
+// GoalSuggestionAttendanceStore defines the attendance store interface needed by the training goal suggestion projection.
+type GoalSuggestionAttendanceStore interface {
+	ListByMemberIDAndDateRange(ctx context.Context, memberID string, startDate string, endDate string) ([]domainAttendance.Attendance, error)
+}
+
+// GoalSuggestionGoalStore defines the training goal store interface needed by the training goal suggestion projection.
+type GoalSuggestionGoalStore interface {
+	GetActiveByMemberID(ctx context.Context, memberID string) (traininggoal.TrainingGoal, error)
+}
+
+// GetTrainingGoalSuggestionQuery carries input for the training goal suggestion projection.
+type GetTrainingGoalSuggestionQuery struct {
+	MemberID string
+	Now      time.Time // optional: if zero, time.Now() is used
+}
+
+// GetTrainingGoalSuggestionDeps holds dependencies for the training goal suggestion projection.
+type GetTrainingGoalSuggestionDeps struct {
+	AttendanceStore GoalSuggestionAttendanceStore
+	GoalStore       GoalSuggestionGoalStore // optional: nil leaves CurrentTarget at 0
+}
+
+// TrainingGoalWeek is one past week of attendance.
+type TrainingGoalWeek struct {
+	WeekStart string // YYYY-MM-DD, Monday
+	Sessions  int
+}
+
+// GetTrainingGoalSuggestionResult carries the output of the training goal suggestion projection.
+type GetTrainingGoalSuggestionResult struct {
+	MemberID      string
+	Weeks         []TrainingGoalWeek // oldest first
+	Average       float64            // sessions per week, one decimal place
+	ActiveWeeks   int                // weeks with at least one session
+	Target        int                // suggested sessions per week
+	Period        string             // always weekly
+	CurrentTarget int                // the member's active weekly goal, or 0
+}
+
+// QueryGetTrainingGoalSuggestion suggests a weekly training goal from the member's last
+// eight full weeks of attendance. The current week is left out, since a half-finished
+// week would pull the suggestion down.
+// PRE: query.MemberID is non-empty
+// POST: Returns traininggoal.SuggestionWeeks weeks and a target of at least 1
+func QueryGetTrainingGoalSuggestion(ctx context.Context, query GetTrainingGoalSuggestionQuery, deps GetTrainingGoalSuggestionDeps) (GetTrainingGoalSuggestionResult, error) {
+	if query.MemberID == "" {
+		return GetTrainingGoalSuggestionResult{}, fmt.Errorf("member_id is required")
+	}
+	now := query.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	thisMonday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	firstMonday := thisMonday.AddDate(0, 0, -7*traininggoal.SuggestionWeeks)
+	lastSunday := thisMonday.AddDate(0, 0, -1)
+
+	records, err := deps.AttendanceStore.ListByMemberIDAndDateRange(ctx, query.MemberID, firstMonday.Format("2006-01-02"), lastSunday.Format("2006-01-02"))
+	if err != nil {
+		return GetTrainingGoalSuggestionResult{}, err
+	}
+
+	result := GetTrainingGoalSuggestionResult{
+		MemberID: query.MemberID,
+		Weeks:    make([]TrainingGoalWeek, traininggoal.SuggestionWeeks),
+		Period:   traininggoal.PeriodWeekly,
+	}
+	for i := range result.Weeks {
+		result.Weeks[i].WeekStart = firstMonday.AddDate(0, 0, 7*i).Format("2006-01-02")
+	}
+	for _, a := range records {
+		day := a.ClassDate
+		if day == "" {
+			day = a.CheckInTime.Format("2006-01-02")
+		}
+		d, err := time.Parse("2006-01-02", day)
+		if err != nil {
+			continue
+		}
+		i := int(d.Sub(firstMonday).Hours()/24) / 7
+		if i < 0 || i >= len(result.Weeks) {
+			continue
+		}
+		result.Weeks[i].Sessions++
+	}
+
+	weekly := make([]int, len(result.Weeks))
+	total := 0
+	for i, w := range result.Weeks {
+		weekly[i] = w.Sessions
+		total += w.Sessions
+		if w.Sessions > 0 {
+			result.ActiveWeeks++
+		}
+	}
+	result.Average = math.Round(float64(total)/float64(len(weekly))*10) / 10
+	result.Target = traininggoal.SuggestWeeklyTarget(weekly)
+
+	if deps.GoalStore != nil {
+		if goal, err := deps.GoalStore.GetActiveByMemberID(ctx, query.MemberID); err == nil && goal.Period == traininggoal.PeriodWeekly {
+			result.CurrentTarget = goal.Target
+		}
+	}
+	return result, nil
+}
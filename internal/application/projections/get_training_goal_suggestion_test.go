@@ -0,0 +1,99 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainAttendance "workshop/internal/domain/attendance"
+	"workshop/internal/domain/traininggoal"
+)
+
+type mockGSugAttendanceStore struct {
+	records []domainAttendance.Attendance
+	from    string
+	to      string
+}
+
+// ListByMemberIDAndDateRange returns the seeded attendance and records the range asked for.
+// PRE: memberID, startDate, endDate are non-empty
+// POST: Returns the member's records
+func (m *mockGSugAttendanceStore) ListByMemberIDAndDateRange(_ context.Context, memberID string, startDate string, endDate string) ([]domainAttendance.Attendance, error) {
+	m.from, m.to = startDate, endDate
+	var out []domainAttendance.Attendance
+	for _, a := range m.records {
+		if a.MemberID == memberID && a.ClassDate >= startDate && a.ClassDate <= endDate {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+type mockGSugGoalStore struct {
+	goal *traininggoal.TrainingGoal
+}
+
+// GetActiveByMemberID returns the seeded goal, if any.
+// PRE: memberID is non-empty
+// POST: Returns the goal or an error
+func (m *mockGSugGoalStore) GetActiveByMemberID(_ context.Context, _ string) (traininggoal.TrainingGoal, error) {
+	if m.goal == nil {
+		return traininggoal.TrainingGoal{}, errors.New("not found")
+	}
+	return *m.goal, nil
+}
+
+// TestQueryGetTrainingGoalSuggestion verifies the last eight full weeks are counted and
+// a target drawn from them.
+func TestQueryGetTrainingGoalSuggestion(t *testing.T) {
+	// Wednesday 2026-06-17: the full weeks run Monday 2026-04-20 to Sunday 2026-06-14.
+	now := time.Date(2026, 6, 17, 9, 0, 0, 0, time.UTC)
+	att := &mockGSugAttendanceStore{}
+	add := func(dates ...string) {
+		for _, d := range dates {
+			att.records = append(att.records, domainAttendance.Attendance{MemberID: "m1", ClassDate: d})
+		}
+	}
+	add("2026-04-19")                             // the week before the window
+	add("2026-04-20", "2026-04-22")               // week 1: 2
+	add("2026-04-27", "2026-04-29", "2026-05-01") // week 2: 3
+	add("2026-05-04", "2026-05-06")               // week 3: 2
+	add("2026-05-11", "2026-05-13", "2026-05-15") // week 4: 3
+	add("2026-05-18", "2026-05-20")               // week 5: 2
+	// week 6 away
+	add("2026-06-01", "2026-06-03", "2026-06-07") // week 7: 3
+	add("2026-06-08", "2026-06-14")               // week 8: 2
+	add("2026-06-15", "2026-06-16")               // this week, not counted
+
+	got, err := QueryGetTrainingGoalSuggestion(context.Background(), GetTrainingGoalSuggestionQuery{MemberID: "m1", Now: now}, GetTrainingGoalSuggestionDeps{
+		AttendanceStore: att,
+		GoalStore:       &mockGSugGoalStore{goal: &traininggoal.TrainingGoal{Target: 2, Period: traininggoal.PeriodWeekly}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if att.from != "2026-04-20" || att.to != "2026-06-14" {
+		t.Errorf("range = %s..%s, want 2026-04-20..2026-06-14", att.from, att.to)
+	}
+	if len(got.Weeks) != 8 || got.Weeks[0].WeekStart != "2026-04-20" || got.Weeks[5].Sessions != 0 || got.Weeks[7].Sessions != 2 {
+		t.Errorf("Weeks = %+v", got.Weeks)
+	}
+	if got.ActiveWeeks != 7 || got.Average != 2.1 {
+		t.Errorf("ActiveWeeks = %d, Average = %v; want 7 and 2.1", got.ActiveWeeks, got.Average)
+	}
+	if got.Target != 3 || got.Period != traininggoal.PeriodWeekly || got.CurrentTarget != 2 {
+		t.Errorf("Target = %d %s, CurrentTarget = %d; want 3 weekly and 2", got.Target, got.Period, got.CurrentTarget)
+	}
+}
+
+// TestQueryGetTrainingGoalSuggestion_NoHistory verifies a new member is offered one session a week.
+func TestQueryGetTrainingGoalSuggestion_NoHistory(t *testing.T) {
+	got, err := QueryGetTrainingGoalSuggestion(context.Background(), GetTrainingGoalSuggestionQuery{MemberID: "m1"}, GetTrainingGoalSuggestionDeps{AttendanceStore: &mockGSugAttendanceStore{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Target != 1 || got.ActiveWeeks != 0 || got.CurrentTarget != 0 {
+		t.Errorf("got %+v, want a target of 1 and no history", got)
+	}
+}
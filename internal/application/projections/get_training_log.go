@@ -2,18 +2,24 @@ package projections
 
 import (
 	"context"
-	"fmt"
 	"sort"
 	"time"
 
+	attendanceStore "workshop/internal/adapters/storage/attendance"
+	gradingStore "workshop/internal/adapters/storage/grading"
 	"workshop/internal/domain/attendance"
 	"workshop/internal/domain/grading"
 	"workshop/internal/domain/member"
+	"workshop/internal/domain/observation"
 )
 
+// TrainingLogPageSize is how many entries the training log lists when no limit is given.
+const TrainingLogPageSize = 50
+
 // TrainingLogAttendanceStore defines the attendance store interface needed by the training log projection.
 type TrainingLogAttendanceStore interface {
-	ListByMemberID(ctx context.Context, memberID string) ([]attendance.Attendance, error)
+	List(ctx context.Context, filter attendanceStore.ListFilter) ([]attendance.Attendance, error)
+	TotalsByMemberID(ctx context.Context, memberID string) (attendance.Totals, error)
 }
 
 // TrainingLogMemberStore defines the member store interface needed by the training log projection.
@@ -21,14 +27,19 @@ type TrainingLogMemberStore interface {
 	GetByID(ctx context.Context, id string) (member.Member, error)
 }
 
-// GetTrainingLogQuery carries input for the training log projection.
+// GetTrainingLogQuery carries input for the training log projection. Totals always cover the
+// member's whole history; only Entries are paged and filtered.
 type GetTrainingLogQuery struct {
 	MemberID string
+	From     string // entries checked in on or after this YYYY-MM-DD; empty = no lower bound
+	To       string // entries checked in on or before this YYYY-MM-DD; empty = no upper bound
+	Limit    int    // entries per page; 0 = TrainingLogPageSize
+	Offset   int    // entries to skip, newest first
 }
 
 // TrainingLogGradingRecordStore defines the grading record store interface.
 type TrainingLogGradingRecordStore interface {
-	ListByMemberID(ctx context.Context, memberID string) ([]grading.Record, error)
+	List(ctx context.Context, filter gradingStore.RecordFilter) ([]grading.Record, error)
 }
 
 // TrainingLogGradingConfigStore defines the grading config store interface.
@@ -41,6 +52,11 @@ type TrainingLogEstimatedHoursStore interface {
 	SumApprovedByMemberID(ctx context.Context, memberID string) (float64, error)
 }
 
+// TrainingLogObservationStore defines the observation store interface needed by the training log projection.
+type TrainingLogObservationStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]observation.Observation, error)
+}
+
 // GetTrainingLogDeps holds dependencies for the training log projection.
 type GetTrainingLogDeps struct {
 	AttendanceStore     TrainingLogAttendanceStore
@@ -48,6 +64,13 @@ type GetTrainingLogDeps struct {
 	GradingRecordStore  TrainingLogGradingRecordStore  // optional: nil skips belt lookup
 	GradingConfigStore  TrainingLogGradingConfigStore  // optional: nil skips progress bar
 	EstimatedHoursStore TrainingLogEstimatedHoursStore // optional: nil skips bulk estimates
+	ObservationStore    TrainingLogObservationStore    // optional: nil skips shared feedback
+}
+
+// TrainingLogFeedback is a coach observation shared with the member.
+type TrainingLogFeedback struct {
+	Date    string // YYYY-MM-DD
+	Content string
 }
 
 // TrainingLogEntry represents a single attendance entry in the training log.
@@ -66,44 +89,44 @@ type TrainingLogResult struct {
 	MemberName         string
 	Program            string
 	TotalClasses       int
-	TotalMatHours      float64 // "flight time" (recorded + session-estimated + bulk-estimated)
-	RecordedHours      float64 // hours from checked-out sessions
-	EstimatedHours     float64 // hours from default estimate (no checkout)
-	BulkEstimatedHours float64 // hours from coach/admin bulk estimates
-	CurrentStreak      int     // consecutive weeks with at least one check-in
-	LastCheckIn        string  // date of most recent check-in
-	Belt               string  // current belt
-	Stripe             int     // current stripes
-	NextBelt           string  // next belt in progression (empty if at highest)
-	ProgressPct        float64 // percentage progress toward next belt (0-100)
-	RequiredHours      float64 // hours required for next belt
-	GradingMetric      string  // "sessions" or "hours"
-	TermName           string  // current term name (kids sessions mode only)
-	TermAttended       int     // sessions attended this term
-	TermTotal          int     // total available sessions this term
-	TermAttendancePct  float64 // attendance percentage this term
-	TermThresholdPct   float64 // required attendance percentage
-	TermEligible       bool    // whether eligible for promotion
-	Entries            []TrainingLogEntry
+	TotalMatHours      float64               // "flight time" (recorded + session-estimated + bulk-estimated)
+	RecordedHours      float64               // hours from checked-out sessions
+	EstimatedHours     float64               // hours from default estimate (no checkout)
+	BulkEstimatedHours float64               // hours from coach/admin bulk estimates
+	CurrentStreak      int                   // consecutive weeks with at least one check-in
+	LastCheckIn        string                // date of most recent check-in
+	Belt               string                // current belt
+	Stripe             int                   // current stripes
+	NextBelt           string                // next belt in progression (empty if at highest)
+	ProgressPct        float64               // percentage progress toward next belt (0-100)
+	RequiredHours      float64               // hours required for next belt
+	GradingMetric      string                // "sessions" or "hours"
+	TermName           string                // current term name (kids sessions mode only)
+	TermAttended       int                   // sessions attended this term
+	TermMakeupCredits  int                   // makeup credits awarded this term
+	TermTotal          int                   // sessions held this term on the member's schedules
+	TermAttendancePct  float64               // attendance percentage this term
+	TermThresholdPct   float64               // required attendance percentage
+	TermEligible       bool                  // whether eligible for promotion
+	Feedback           []TrainingLogFeedback // observations shared with the member, newest first
+	Entries            []TrainingLogEntry    // one page of check-ins, newest first
 }
 
-// QueryGetTrainingLog computes the training log for a member from their attendance history.
+// QueryGetTrainingLog computes the training log for a member. Totals and the streak come from
+// bounded store queries rather than the full history; Entries is one page of it.
+// PRE: MemberID is non-empty; From and To, when set, are YYYY-MM-DD
+// POST: Returns the totals, streak, belt progress and the requested page of entries
 func QueryGetTrainingLog(ctx context.Context, query GetTrainingLogQuery, deps GetTrainingLogDeps) (TrainingLogResult, error) {
 	m, err := deps.MemberStore.GetByID(ctx, query.MemberID)
 	if err != nil {
 		return TrainingLogResult{}, err
 	}
 
-	records, err := deps.AttendanceStore.ListByMemberID(ctx, query.MemberID)
+	totals, err := deps.AttendanceStore.TotalsByMemberID(ctx, query.MemberID)
 	if err != nil {
 		return TrainingLogResult{}, err
 	}
 
-	// Sort by check-in time ascending for streak calculation
-	sort.Slice(records, func(i, j int) bool {
-		return records[i].CheckInTime.Before(records[j].CheckInTime)
-	})
-
 	result := TrainingLogResult{
 		MemberID:      m.ID,
 		MemberName:    m.Name,
@@ -111,14 +134,39 @@ func QueryGetTrainingLog(ctx context.Context, query GetTrainingLogQuery, deps Ge
 		GradingMetric: m.GradingMetric,
 	}
 
-	if len(records) == 0 {
+	if deps.ObservationStore != nil {
+		observations, err := deps.ObservationStore.ListByMemberID(ctx, query.MemberID)
+		if err != nil {
+			return TrainingLogResult{}, err
+		}
+		for _, o := range observations {
+			if o.Visibility == observation.VisibilityShared {
+				result.Feedback = append(result.Feedback, TrainingLogFeedback{Date: o.CreatedAt.Format("2006-01-02"), Content: o.Content})
+			}
+		}
+		sort.SliceStable(result.Feedback, func(i, j int) bool { return result.Feedback[i].Date > result.Feedback[j].Date })
+	}
+
+	if totals.Classes == 0 {
 		return result, nil
 	}
 
-	var recordedHours, estimatedHours float64
+	limit := query.Limit
+	if limit <= 0 {
+		limit = TrainingLogPageSize
+	}
+	records, err := deps.AttendanceStore.List(ctx, attendanceStore.ListFilter{
+		MemberID: query.MemberID,
+		From:     query.From,
+		To:       query.To,
+		Limit:    limit,
+		Offset:   query.Offset,
+	})
+	if err != nil {
+		return TrainingLogResult{}, err
+	}
 	entries := make([]TrainingLogEntry, 0, len(records))
-
-	for _, r := range records {
+	for _, r := range records { // newest first
 		entry := TrainingLogEntry{
 			Date:       r.CheckInTime.Format("2006-01-02"),
 			CheckIn:    r.CheckInTime.Format("15:04"),
@@ -128,29 +176,20 @@ func QueryGetTrainingLog(ctx context.Context, query GetTrainingLogQuery, deps Ge
 
 		if !r.CheckOutTime.IsZero() {
 			entry.CheckOut = r.CheckOutTime.Format("15:04")
-			duration := r.CheckOutTime.Sub(r.CheckInTime).Hours()
-			if duration > 0 {
+			if duration := r.CheckOutTime.Sub(r.CheckInTime).Hours(); duration > 0 {
 				entry.DurationH = duration
-				recordedHours += duration
 			}
 		} else {
-			// Default 1.5h per class if no checkout
-			entry.DurationH = 1.5
-			estimatedHours += 1.5
+			entry.DurationH = attendance.EstimatedSessionHours
 		}
 
 		entries = append(entries, entry)
 	}
 
-	// Reverse entries so most recent is first for display
-	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
-		entries[i], entries[j] = entries[j], entries[i]
-	}
-
-	result.TotalClasses = len(records)
-	result.RecordedHours = recordedHours
-	result.EstimatedHours = estimatedHours
-	result.TotalMatHours = recordedHours + estimatedHours
+	result.TotalClasses = totals.Classes
+	result.RecordedHours = totals.RecordedHours
+	result.EstimatedHours = totals.EstimatedHours()
+	result.TotalMatHours = result.RecordedHours + result.EstimatedHours
 
 	// Add bulk-estimated hours (from §3.4)
 	if deps.EstimatedHoursStore != nil {
@@ -161,21 +200,18 @@ func QueryGetTrainingLog(ctx context.Context, query GetTrainingLogQuery, deps Ge
 		}
 	}
 	result.Entries = entries
-	result.LastCheckIn = records[len(records)-1].CheckInTime.Format("2006-01-02")
-	result.CurrentStreak = calculateWeekStreak(records)
+	result.LastCheckIn = totals.LastCheckIn.Format("2006-01-02")
+	result.CurrentStreak, err = attendanceWeekStreak(ctx, deps.AttendanceStore, query.MemberID, time.Now())
+	if err != nil {
+		return TrainingLogResult{}, err
+	}
 
 	// Belt and progress bar (optional deps)
 	if deps.GradingRecordStore != nil {
-		gradingRecords, err := deps.GradingRecordStore.ListByMemberID(ctx, query.MemberID)
+		gradingRecords, err := deps.GradingRecordStore.List(ctx, gradingStore.RecordFilter{MemberID: query.MemberID, Limit: 1})
 		if err == nil && len(gradingRecords) > 0 {
-			latest := gradingRecords[0]
-			for _, r := range gradingRecords[1:] {
-				if r.PromotedAt.After(latest.PromotedAt) {
-					latest = r
-				}
-			}
-			result.Belt = latest.Belt
-			result.Stripe = latest.Stripe
+			result.Belt = gradingRecords[0].Belt
+			result.Stripe = gradingRecords[0].Stripe
 		}
 	}
 
@@ -201,39 +237,36 @@ func QueryGetTrainingLog(ctx context.Context, query GetTrainingLogQuery, deps Ge
 	return result, nil
 }
 
-// calculateWeekStreak counts consecutive weeks (ending with this week) that have
-// at least one check-in. A "week" runs Monday–Sunday.
-func calculateWeekStreak(records []attendance.Attendance) int {
-	if len(records) == 0 {
-		return 0
-	}
-
-	// Collect unique ISO weeks that have check-ins
-	weekSet := make(map[string]bool)
-	for _, r := range records {
-		y, w := r.CheckInTime.ISOWeek()
-		key := time.Date(y, 1, 1, 0, 0, 0, 0, time.Local).Format("2006") + "-" + padWeek(w)
-		weekSet[key] = true
-	}
+// attendanceWeekStreakPage is how many check-ins attendanceWeekStreak reads at a time.
+const attendanceWeekStreakPage = 100
 
-	// Walk backwards from current week
-	now := time.Now()
+// attendanceWeekStreak counts consecutive weeks, ending with the week containing asOf, that
+// have at least one check-in. A week runs Monday–Sunday. Check-ins are read newest first a
+// page at a time, stopping at the first week without one, so long histories aren't loaded.
+func attendanceWeekStreak(ctx context.Context, store TrainingLogAttendanceStore, memberID string, asOf time.Time) (int, error) {
+	want := mondayOf(asOf).Format("2006-01-02")
 	streak := 0
-	for {
-		y, w := now.ISOWeek()
-		key := time.Date(y, 1, 1, 0, 0, 0, 0, time.Local).Format("2006") + "-" + padWeek(w)
-		if !weekSet[key] {
-			break
+	for offset := 0; ; offset += attendanceWeekStreakPage {
+		records, err := store.List(ctx, attendanceStore.ListFilter{MemberID: memberID, Limit: attendanceWeekStreakPage, Offset: offset})
+		if err != nil {
+			return 0, err
+		}
+		for _, r := range records {
+			week := mondayOf(r.CheckInTime).Format("2006-01-02")
+			switch {
+			case week > want: // after asOf, or a week already counted
+				continue
+			case week < want: // a week without a check-in ends the streak
+				return streak, nil
+			}
+			streak++
+			day, _ := time.Parse("2006-01-02", want)
+			want = day.AddDate(0, 0, -7).Format("2006-01-02")
+		}
+		if len(records) < attendanceWeekStreakPage {
+			return streak, nil
 		}
-		streak++
-		now = now.AddDate(0, 0, -7)
 	}
-
-	return streak
-}
-
-func padWeek(w int) string {
-	return fmt.Sprintf("%02d", w)
 }
 
 // nextBeltInProgression returns the next belt in the progression, or "" if at highest.
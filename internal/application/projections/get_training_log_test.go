@@ -2,12 +2,17 @@ package projections
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"testing"
 	"time"
 
+	attendanceStore "workshop/internal/adapters/storage/attendance"
+	gradingStore "workshop/internal/adapters/storage/grading"
 	"workshop/internal/domain/attendance"
 	"workshop/internal/domain/grading"
 	"workshop/internal/domain/member"
+	"workshop/internal/domain/observation"
 )
 
 // mockTrainingLogAttendanceStore implements TrainingLogAttendanceStore for testing.
@@ -17,13 +22,42 @@ type mockTrainingLogAttendanceStore struct {
 	records map[string][]attendance.Attendance
 }
 
-// ListByMemberID implements TrainingLogAttendanceStore for testing.
+// ListByMemberID returns the member's stored attendance records.
 // PRE: memberID is non-empty
 // POST: Returns stored attendance records
 func (m *mockTrainingLogAttendanceStore) ListByMemberID(_ context.Context, memberID string) ([]attendance.Attendance, error) {
 	return m.records[memberID], nil
 }
 
+// List implements TrainingLogAttendanceStore for testing.
+// PRE: filter.MemberID is non-empty
+// POST: Returns one page of the member's records in the date range, newest first
+func (m *mockTrainingLogAttendanceStore) List(_ context.Context, filter attendanceStore.ListFilter) ([]attendance.Attendance, error) {
+	var list []attendance.Attendance
+	for _, a := range m.records[filter.MemberID] {
+		d := a.CheckInTime.Format("2006-01-02")
+		if (filter.From == "" || d >= filter.From) && (filter.To == "" || d <= filter.To) {
+			list = append(list, a)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CheckInTime.After(list[j].CheckInTime) })
+	if filter.Offset >= len(list) {
+		return nil, nil
+	}
+	list = list[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(list) {
+		list = list[:filter.Limit]
+	}
+	return list, nil
+}
+
+// TotalsByMemberID implements TrainingLogAttendanceStore for testing.
+// PRE: memberID is non-empty
+// POST: Returns the totals of the member's stored records
+func (m *mockTrainingLogAttendanceStore) TotalsByMemberID(_ context.Context, memberID string) (attendance.Totals, error) {
+	return attendance.Summarise(m.records[memberID]), nil
+}
+
 // mockTrainingLogMemberStore implements TrainingLogMemberStore for testing.
 // PRE: id is non-empty
 // POST: Returns the stored member or an error
@@ -49,13 +83,25 @@ type mockTrainingLogGradingRecordStore struct {
 	records map[string][]grading.Record
 }
 
-// ListByMemberID implements TrainingLogGradingRecordStore for testing.
+// ListByMemberID returns the member's stored grading records.
 // PRE: memberID is non-empty
 // POST: Returns stored grading records
 func (m *mockTrainingLogGradingRecordStore) ListByMemberID(_ context.Context, memberID string) ([]grading.Record, error) {
 	return m.records[memberID], nil
 }
 
+// List implements TrainingLogGradingRecordStore for testing.
+// PRE: filter.MemberID is non-empty
+// POST: Returns the member's stored grading records newest first, up to the limit
+func (m *mockTrainingLogGradingRecordStore) List(_ context.Context, filter gradingStore.RecordFilter) ([]grading.Record, error) {
+	records := append([]grading.Record(nil), m.records[filter.MemberID]...)
+	sort.Slice(records, func(i, j int) bool { return records[i].PromotedAt.After(records[j].PromotedAt) })
+	if filter.Limit > 0 && filter.Limit < len(records) {
+		records = records[:filter.Limit]
+	}
+	return records, nil
+}
+
 // mockTrainingLogGradingConfigStore implements TrainingLogGradingConfigStore for testing.
 // PRE: program and belt are non-empty
 // POST: Returns the stored config or an error
@@ -199,6 +245,49 @@ func TestQueryGetTrainingLog_EmptyRecords(t *testing.T) {
 	}
 }
 
+// mockTrainingLogObservationStore implements TrainingLogObservationStore for testing.
+type mockTrainingLogObservationStore struct {
+	observations []observation.Observation
+}
+
+// ListByMemberID implements TrainingLogObservationStore for testing.
+// PRE: memberID is non-empty
+// POST: Returns the member's stored observations
+func (m *mockTrainingLogObservationStore) ListByMemberID(_ context.Context, memberID string) ([]observation.Observation, error) {
+	var out []observation.Observation
+	for _, o := range m.observations {
+		if o.MemberID == memberID {
+			out = append(out, o)
+		}
+	}
+	return out, nil
+}
+
+// TestQueryGetTrainingLog_SharedFeedback verifies only observations shared with the member
+// appear as feedback, newest first, even before their first class.
+func TestQueryGetTrainingLog_SharedFeedback(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.UTC) }
+	deps := GetTrainingLogDeps{
+		AttendanceStore: &mockTrainingLogAttendanceStore{records: map[string][]attendance.Attendance{}},
+		MemberStore:     &mockTrainingLogMemberStore{members: map[string]member.Member{"m1": {ID: "m1", Name: "Bob", Program: "adults"}}},
+		ObservationStore: &mockTrainingLogObservationStore{observations: []observation.Observation{
+			{MemberID: "m1", Content: "Keep elbows in", Visibility: observation.VisibilityShared, CreatedAt: day(2)},
+			{MemberID: "m1", Content: "Talk to parents", Visibility: observation.VisibilityCoaches, CreatedAt: day(3)},
+			{MemberID: "m1", Content: "Fees overdue", Visibility: observation.VisibilityAdmin, CreatedAt: day(4)},
+			{MemberID: "m1", Content: "Great hip escapes", Visibility: observation.VisibilityShared, CreatedAt: day(5)},
+			{MemberID: "m2", Content: "Someone else", Visibility: observation.VisibilityShared, CreatedAt: day(6)},
+		}},
+	}
+
+	result, err := QueryGetTrainingLog(context.Background(), GetTrainingLogQuery{MemberID: "m1"}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Feedback) != 2 || result.Feedback[0].Content != "Great hip escapes" || result.Feedback[1].Date != "2026-03-02" {
+		t.Errorf("expected the two shared observations newest first, got %+v", result.Feedback)
+	}
+}
+
 // TestQueryGetTrainingLog_WhiteBeltDefault verifies default belt is white when no grading records exist.
 func TestQueryGetTrainingLog_WhiteBeltDefault(t *testing.T) {
 	now := time.Now()
@@ -267,3 +356,34 @@ func TestNextBeltInProgression(t *testing.T) {
 		}
 	}
 }
+
+// TestQueryGetTrainingLog_PagesEntries verifies entries come a page at a time while totals and
+// the streak still cover the whole history, including a streak longer than one store page.
+func TestQueryGetTrainingLog_PagesEntries(t *testing.T) {
+	now := time.Now()
+	var records []attendance.Attendance
+	for week := 0; week < 120; week++ {
+		records = append(records, attendance.Attendance{ID: fmt.Sprintf("a%d", week), MemberID: "m1", CheckInTime: now.AddDate(0, 0, -7*week)})
+	}
+	deps := GetTrainingLogDeps{
+		AttendanceStore: &mockTrainingLogAttendanceStore{records: map[string][]attendance.Attendance{"m1": records}},
+		MemberStore:     &mockTrainingLogMemberStore{members: map[string]member.Member{"m1": {ID: "m1", Name: "Alex", Program: "adults"}}},
+	}
+
+	result, err := QueryGetTrainingLog(context.Background(), GetTrainingLogQuery{MemberID: "m1", Limit: 10, Offset: 5}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Entries) != 10 || result.Entries[0].Date != now.AddDate(0, 0, -35).Format("2006-01-02") {
+		t.Errorf("entries = %d starting %+v, want 10 starting five weeks back", len(result.Entries), result.Entries)
+	}
+	if result.TotalClasses != 120 || result.EstimatedHours != 180 {
+		t.Errorf("totals = %d classes, %.1f estimated hours; want 120 and 180", result.TotalClasses, result.EstimatedHours)
+	}
+	if result.CurrentStreak != 120 {
+		t.Errorf("streak = %d, want 120", result.CurrentStreak)
+	}
+	if result.LastCheckIn != now.Format("2006-01-02") {
+		t.Errorf("last check-in = %s", result.LastCheckIn)
+	}
+}
@@ -0,0 +1,117 @@
+package projections
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"workshop/internal/domain/visitor"
+)
+
+// VisitorReportWindowDays is how far back the visitor report counts visits and conversions.
+const VisitorReportWindowDays = 30
+
+// VisitorReportStore defines the visitor store interface needed by the visitor report.
+type VisitorReportStore interface {
+	ListVisitors(ctx context.Context, status string) ([]visitor.Visitor, error)
+	ListVisitsSince(ctx context.Context, since time.Time) ([]visitor.Visit, error)
+}
+
+// GetVisitorReportDeps holds dependencies for the visitor report.
+type GetVisitorReportDeps struct {
+	VisitorStore VisitorReportStore
+}
+
+// VisitorHomeGym counts recent visitors from one club.
+type VisitorHomeGym struct {
+	Name     string
+	Visitors int
+}
+
+// VisitorReportResult carries the output of the visitor report.
+type VisitorReportResult struct {
+	WindowDays        int
+	Visits            int // drop-ins in the window
+	NewVisitors       int // first visit in the window
+	ReturningVisitors int // visited in the window and had been before
+	Converted         int // signed up as trial or member in the window
+	ConversionRate    int // percent of all visitors ever who have signed up
+	DropInRevenue     int // dollars paid for visits in the window
+	Unpaid            int // dollars still owed for visits in the window
+	HomeGyms          []VisitorHomeGym
+	Recent            []visitor.Visitor // visited in the window, most recent first
+}
+
+// QueryGetVisitorReport summarises drop-in visitors over the last VisitorReportWindowDays
+// days: how many came, how many came back, what they paid and how many signed up.
+// PRE: now is the current time
+// POST: Returns the report; nothing is written
+func QueryGetVisitorReport(ctx context.Context, now time.Time, deps GetVisitorReportDeps) (VisitorReportResult, error) {
+	since := now.AddDate(0, 0, -VisitorReportWindowDays)
+	result := VisitorReportResult{WindowDays: VisitorReportWindowDays, HomeGyms: []VisitorHomeGym{}, Recent: []visitor.Visitor{}}
+
+	visitors, err := deps.VisitorStore.ListVisitors(ctx, "")
+	if err != nil {
+		return result, err
+	}
+	visits, err := deps.VisitorStore.ListVisitsSince(ctx, since)
+	if err != nil {
+		return result, err
+	}
+
+	for _, v := range visits {
+		result.Visits++
+		if v.Paid {
+			result.DropInRevenue += v.Fee
+		} else {
+			result.Unpaid += v.Fee
+		}
+	}
+
+	converted := 0
+	gyms := map[string]*VisitorHomeGym{}
+	for _, v := range visitors {
+		if v.IsConverted() {
+			converted++
+			if !v.ConvertedAt.Before(since) {
+				result.Converted++
+			}
+		}
+		if v.LastVisit.Before(since) {
+			continue
+		}
+		result.Recent = append(result.Recent, v)
+		if v.FirstVisit.Before(since) || v.IsReturning() {
+			result.ReturningVisitors++
+		}
+		if !v.FirstVisit.Before(since) {
+			result.NewVisitors++
+		}
+		if name := strings.TrimSpace(v.HomeGym); name != "" {
+			key := strings.ToLower(name)
+			if gyms[key] == nil {
+				gyms[key] = &VisitorHomeGym{Name: name}
+			}
+			gyms[key].Visitors++
+		}
+	}
+	if len(visitors) > 0 {
+		result.ConversionRate = converted * 100 / len(visitors)
+	}
+
+	for _, g := range gyms {
+		result.HomeGyms = append(result.HomeGyms, *g)
+	}
+	sort.Slice(result.HomeGyms, func(i, j int) bool {
+		a, b := result.HomeGyms[i], result.HomeGyms[j]
+		if a.Visitors != b.Visitors {
+			return a.Visitors > b.Visitors
+		}
+		return a.Name < b.Name
+	})
+	sort.SliceStable(result.Recent, func(i, j int) bool {
+		return result.Recent[i].LastVisit.After(result.Recent[j].LastVisit)
+	})
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/visitor"
+)
+
+// --- Mock store for visitor report tests ---
+
+type mockVRStore struct {
+	visitors []visitor.Visitor
+	visits   []visitor.Visit
+}
+
+// ListVisitors returns every visitor.
+// PRE: none
+// POST: Returns the visitors
+func (m *mockVRStore) ListVisitors(_ context.Context, _ string) ([]visitor.Visitor, error) {
+	return m.visitors, nil
+}
+
+// ListVisitsSince returns the visits at or after since.
+// PRE: none
+// POST: Returns the visits
+func (m *mockVRStore) ListVisitsSince(_ context.Context, since time.Time) ([]visitor.Visit, error) {
+	var list []visitor.Visit
+	for _, v := range m.visits {
+		if !v.VisitedAt.Before(since) {
+			list = append(list, v)
+		}
+	}
+	return list, nil
+}
+
+// TestQueryGetVisitorReport verifies visits, returns, fees and conversions are counted
+// over the report window.
+func TestQueryGetVisitorReport(t *testing.T) {
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	store := &mockVRStore{
+		visitors: []visitor.Visitor{
+			{ID: "new", Name: "Ana", HomeGym: "City BJJ", Status: visitor.StatusVisiting, FirstVisit: day(3), LastVisit: day(3), VisitCount: 1},
+			{ID: "back", Name: "Ben", HomeGym: "city bjj", Status: visitor.StatusVisiting, FirstVisit: day(60), LastVisit: day(1), VisitCount: 3},
+			{ID: "signed", Name: "Cat", Status: visitor.StatusTrial, FirstVisit: day(20), LastVisit: day(10), VisitCount: 2, ConvertedAt: day(5)},
+			{ID: "old", Name: "Dan", HomeGym: "Far Away", Status: visitor.StatusMember, FirstVisit: day(200), LastVisit: day(190), VisitCount: 1, ConvertedAt: day(180)},
+		},
+		visits: []visitor.Visit{
+			{VisitorID: "new", VisitedAt: day(3), Fee: 25, Paid: true},
+			{VisitorID: "back", VisitedAt: day(1), Fee: 15},
+			{VisitorID: "signed", VisitedAt: day(10), Fee: 25, Paid: true},
+			{VisitorID: "signed", VisitedAt: day(20), Fee: 25, Paid: true},
+			{VisitorID: "old", VisitedAt: day(190), Fee: 25, Paid: true},
+		},
+	}
+
+	got, err := QueryGetVisitorReport(context.Background(), now, GetVisitorReportDeps{VisitorStore: store})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Visits != 4 || got.DropInRevenue != 75 || got.Unpaid != 15 {
+		t.Errorf("visits %d revenue %d unpaid %d, want 4, 75, 15", got.Visits, got.DropInRevenue, got.Unpaid)
+	}
+	if got.NewVisitors != 2 || got.ReturningVisitors != 2 {
+		t.Errorf("new %d returning %d, want 2 and 2", got.NewVisitors, got.ReturningVisitors)
+	}
+	if got.Converted != 1 || got.ConversionRate != 50 {
+		t.Errorf("converted %d rate %d, want 1 and 50", got.Converted, got.ConversionRate)
+	}
+	if len(got.HomeGyms) != 1 || got.HomeGyms[0].Visitors != 2 {
+		t.Errorf("HomeGyms = %+v, want City BJJ twice", got.HomeGyms)
+	}
+	if len(got.Recent) != 3 || got.Recent[0].ID != "back" || got.Recent[2].ID != "signed" {
+		t.Errorf("Recent = %+v, want back, new, signed", got.Recent)
+	}
+}
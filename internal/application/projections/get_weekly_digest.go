@@ -0,0 +1,151 @@
+package projections
+
+import (
+	"context"
+	"time"
+
+	"workshop/internal/domain/schedule"
+)
+
+// WeeklyDigestClassWindowDays is how far back, from the end of the digest's week, the
+// classes a member attended decide which curriculum they hear about.
+const WeeklyDigestClassWindowDays = 28
+
+// weeklyDigestMaxEntries caps the check-ins read for the class window; at several classes a
+// day it still covers WeeklyDigestClassWindowDays.
+const weeklyDigestMaxEntries = 500
+
+// WeeklyDigestScheduleStore defines the schedule store interface needed to find a member's classes.
+type WeeklyDigestScheduleStore interface {
+	GetByID(ctx context.Context, id string) (schedule.Schedule, error)
+}
+
+// GetWeeklyDigestQuery carries input for the weekly digest projection.
+type GetWeeklyDigestQuery struct {
+	MemberID  string
+	WeekStart time.Time // midnight on the Monday the summarised week starts
+	Now       time.Time // picks the week of each running topic
+}
+
+// GetWeeklyDigestDeps holds dependencies for the weekly digest projection.
+type GetWeeklyDigestDeps struct {
+	TrainingLog   GetTrainingLogDeps
+	ScheduleStore WeeklyDigestScheduleStore // optional: nil skips upcoming topics
+	Curriculum    GetThemeCarouselDeps      // optional: a nil RotorStore skips upcoming topics
+}
+
+// WeeklyDigestResult carries the output of the weekly digest projection.
+type WeeklyDigestResult struct {
+	MemberID      string
+	MemberName    string
+	Program       string
+	WeekStart     string  // YYYY-MM-DD, the Monday
+	WeekEnd       string  // YYYY-MM-DD, the Sunday
+	Classes       int     // classes attended in the week
+	MatHours      float64 // hours on the mats in the week, estimated where there was no checkout
+	Streak        int     // consecutive weeks with a check-in, ending with this week
+	Belt          string
+	NextBelt      string  // empty at the top of the progression
+	ProgressPct   float64 // toward NextBelt, 0-100; 0 when no requirement is configured
+	RequiredHours float64 // for NextBelt; 0 when no requirement is configured
+	Topics        []WeeklyDigestClass
+}
+
+// WeeklyDigestClass is what one of the member's classes is working on.
+type WeeklyDigestClass struct {
+	ClassTypeName string
+	Topics        []string // the running topics, then upcoming ones where the rotor previews them
+}
+
+// QueryGetWeeklyDigest summarises one week of a member's training from their training log,
+// with what the classes they have been attending are working on next.
+// PRE: WeekStart is a Monday at local midnight
+// POST: Returns the week's totals, streak and belt progress; Topics lists only class types
+// the member attended in the WeeklyDigestClassWindowDays before the week ends
+func QueryGetWeeklyDigest(ctx context.Context, query GetWeeklyDigestQuery, deps GetWeeklyDigestDeps) (WeeklyDigestResult, error) {
+	weekEnd := query.WeekStart.AddDate(0, 0, 7)
+	windowStart := weekEnd.AddDate(0, 0, -WeeklyDigestClassWindowDays).Format("2006-01-02")
+	log, err := QueryGetTrainingLog(ctx, GetTrainingLogQuery{
+		MemberID: query.MemberID,
+		From:     windowStart,
+		To:       weekEnd.AddDate(0, 0, -1).Format("2006-01-02"),
+		Limit:    weeklyDigestMaxEntries,
+	}, deps.TrainingLog)
+	if err != nil {
+		return WeeklyDigestResult{}, err
+	}
+	result := WeeklyDigestResult{
+		MemberID:      log.MemberID,
+		MemberName:    log.MemberName,
+		Program:       log.Program,
+		WeekStart:     query.WeekStart.Format("2006-01-02"),
+		WeekEnd:       weekEnd.AddDate(0, 0, -1).Format("2006-01-02"),
+		Belt:          log.Belt,
+		NextBelt:      log.NextBelt,
+		ProgressPct:   log.ProgressPct,
+		RequiredHours: log.RequiredHours,
+		Topics:        []WeeklyDigestClass{},
+	}
+
+	recentSchedules := map[string]bool{}
+	for _, e := range log.Entries { // only the class window, by the query's dates
+		day, err := time.ParseInLocation("2006-01-02", e.Date, query.WeekStart.Location())
+		if err != nil {
+			continue
+		}
+		if !day.Before(query.WeekStart) {
+			result.Classes++
+			result.MatHours += e.DurationH
+		}
+		if e.ScheduleID != "" {
+			recentSchedules[e.ScheduleID] = true
+		}
+	}
+	result.Streak, err = attendanceWeekStreak(ctx, deps.TrainingLog.AttendanceStore, query.MemberID, query.WeekStart)
+	if err != nil {
+		return WeeklyDigestResult{}, err
+	}
+
+	if deps.ScheduleStore == nil || deps.Curriculum.RotorStore == nil || len(recentSchedules) == 0 {
+		return result, nil
+	}
+	classTypes := map[string]bool{}
+	for id := range recentSchedules {
+		if s, err := deps.ScheduleStore.GetByID(ctx, id); err == nil {
+			classTypes[s.ClassTypeID] = true
+		}
+	}
+	carousel, err := QueryGetThemeCarousel(ctx, GetThemeCarouselQuery{
+		Gate: BeltGate{Program: log.Program, Belt: log.Belt},
+		Now:  query.Now,
+	}, deps.Curriculum)
+	if err != nil {
+		return result, err
+	}
+	for _, c := range carousel.Classes {
+		if !classTypes[c.ClassTypeID] {
+			continue
+		}
+		class := WeeklyDigestClass{ClassTypeName: c.ClassTypeName}
+		for _, slide := range c.Slides {
+			if slide.Topic != nil {
+				class.Topics = append(class.Topics, slide.Topic.Name)
+			}
+		}
+		for _, slide := range c.Slides {
+			for _, tp := range slide.Upcoming {
+				class.Topics = append(class.Topics, tp.Name)
+			}
+		}
+		if len(class.Topics) > 0 {
+			result.Topics = append(result.Topics, class)
+		}
+	}
+	return result, nil
+}
+
+// mondayOf returns midnight on the Monday of day's week.
+func mondayOf(day time.Time) time.Time {
+	y, m, d := day.Date()
+	return time.Date(y, m, d-(int(day.Weekday())+6)%7, 0, 0, 0, 0, day.Location())
+}
@@ -0,0 +1,89 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/schedule"
+)
+
+// mockWDScheduleStore implements WeeklyDigestScheduleStore for testing.
+type mockWDScheduleStore struct {
+	schedules map[string]schedule.Schedule
+}
+
+// GetByID implements WeeklyDigestScheduleStore for testing.
+// PRE: id is non-empty
+// POST: Returns the stored schedule or an error
+func (m *mockWDScheduleStore) GetByID(_ context.Context, id string) (schedule.Schedule, error) {
+	s, ok := m.schedules[id]
+	if !ok {
+		return schedule.Schedule{}, context.DeadlineExceeded
+	}
+	return s, nil
+}
+
+// TestQueryGetWeeklyDigest verifies the week's totals count only that week, the streak runs
+// back from it, and topics come from the classes the member has been attending.
+func TestQueryGetWeeklyDigest(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC) }
+	feb := func(day int) time.Time { return time.Date(2026, 2, day, 18, 0, 0, 0, time.UTC) }
+	records := []attendance.Attendance{
+		{ID: "a1", MemberID: "m1", ScheduleID: "sch-fund", CheckInTime: at(3, 18), CheckOutTime: at(3, 19)},
+		{ID: "a2", MemberID: "m1", ScheduleID: "sch-fund", CheckInTime: at(5, 18)},
+		{ID: "a3", MemberID: "m1", ScheduleID: "sch-fund", CheckInTime: feb(24)},
+		{ID: "a4", MemberID: "m1", ScheduleID: "sch-fund", CheckInTime: feb(10)},    // a week off breaks the streak
+		{ID: "a5", MemberID: "m1", ScheduleID: "sch-kids", CheckInTime: at(10, 18)}, // after the week
+	}
+	deps := GetWeeklyDigestDeps{
+		TrainingLog: GetTrainingLogDeps{
+			AttendanceStore: &mockTrainingLogAttendanceStore{records: map[string][]attendance.Attendance{"m1": records}},
+			MemberStore:     &mockTrainingLogMemberStore{members: map[string]member.Member{"m1": {ID: "m1", Name: "Alex", Program: "adults"}}},
+		},
+		ScheduleStore: &mockWDScheduleStore{schedules: map[string]schedule.Schedule{
+			"sch-fund": {ID: "sch-fund", ClassTypeID: "ct-fund"},
+			"sch-kids": {ID: "sch-kids", ClassTypeID: "ct-kids"},
+		}},
+		Curriculum: carouselDeps(true),
+	}
+
+	result, err := QueryGetWeeklyDigest(context.Background(), GetWeeklyDigestQuery{MemberID: "m1", WeekStart: at(2, 0), Now: at(9, 7)}, deps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.WeekStart != "2026-03-02" || result.WeekEnd != "2026-03-08" {
+		t.Errorf("week = %s to %s", result.WeekStart, result.WeekEnd)
+	}
+	if result.Classes != 2 || result.MatHours != 2.5 {
+		t.Errorf("week totals = %d classes, %.1f hours; want 2, 2.5", result.Classes, result.MatHours)
+	}
+	if result.Streak != 2 {
+		t.Errorf("streak = %d, want 2", result.Streak)
+	}
+	if result.Belt != "white" || result.NextBelt != "blue" {
+		t.Errorf("belt = %s -> %s", result.Belt, result.NextBelt)
+	}
+	if len(result.Topics) != 1 || result.Topics[0].ClassTypeName != "Fundamentals" {
+		t.Fatalf("expected only Fundamentals topics, got %+v", result.Topics)
+	}
+	want := []string{"Lasso", "De La Riva", "Closed Guard"}
+	got := result.Topics[0].Topics
+	if len(got) != len(want) {
+		t.Fatalf("topics = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("topics = %v, want %v", got, want)
+		}
+	}
+
+	// Without curriculum stores the digest still has its totals
+	deps.ScheduleStore = nil
+	result, err = QueryGetWeeklyDigest(context.Background(), GetWeeklyDigestQuery{MemberID: "m1", WeekStart: at(2, 0), Now: at(9, 7)}, deps)
+	if err != nil || result.Classes != 2 || len(result.Topics) != 0 {
+		t.Errorf("without curriculum: got %+v, %v", result, err)
+	}
+}
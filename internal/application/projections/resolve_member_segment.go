@@ -0,0 +1,111 @@
+package projections
+
+import (
+	"context"
+	"time"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/membertag"
+)
+
+// SegmentMemberStore defines the member store interface needed to resolve a segment.
+type SegmentMemberStore interface {
+	List(ctx context.Context, filter memberStore.ListFilter) ([]member.Member, error)
+}
+
+// SegmentTagStore defines the tag store interface needed to resolve a segment.
+type SegmentTagStore interface {
+	ListMemberIDs(ctx context.Context, tag string) ([]string, error)
+}
+
+// SegmentAttendanceStore defines the attendance store interface needed to resolve a segment.
+type SegmentAttendanceStore interface {
+	ListByMemberID(ctx context.Context, memberID string) ([]attendance.Attendance, error)
+}
+
+// ResolveSegmentDeps holds dependencies for QueryResolveSegment.
+type ResolveSegmentDeps struct {
+	MemberStore     SegmentMemberStore
+	TagStore        SegmentTagStore
+	AttendanceStore SegmentAttendanceStore
+	Now             func() time.Time
+}
+
+// QueryResolveSegment returns the members a saved segment matches right now: carrying every
+// tag, in the segment's program and status, and passing its attendance filter.
+// PRE: segment has been validated
+// POST: Returns members ordered by name; a segment without a status never matches archived members
+func QueryResolveSegment(ctx context.Context, segment membertag.Segment, deps ResolveSegmentDeps) ([]member.Member, error) {
+	filter := memberStore.ListFilter{Program: segment.Program, Status: segment.Status, Limit: 10000}
+	for i, tag := range segment.Tags {
+		ids, err := deps.TagStore.ListMemberIDs(ctx, tag)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			ids = intersectIDs(filter.IDs, ids)
+		}
+		if len(ids) == 0 {
+			return []member.Member{}, nil
+		}
+		filter.IDs = ids
+	}
+
+	members, err := deps.MemberStore.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	now := deps.Now()
+	result := []member.Member{}
+	for _, m := range members {
+		if segment.Status == "" && m.IsArchived() {
+			continue
+		}
+		if segment.AttendedWithinDays > 0 || segment.AbsentForDays > 0 {
+			records, err := deps.AttendanceStore.ListByMemberID(ctx, m.ID)
+			if err != nil {
+				return nil, err
+			}
+			var last time.Time
+			if len(records) > 0 {
+				// Records are ordered DESC by check-in time from ListByMemberID
+				last = records[0].CheckInTime
+			}
+			if !segment.MatchesAttendance(last, now) {
+				continue
+			}
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// SegmentMemberIDs returns the IDs of the given members, never nil, for use as a
+// memberStore.ListFilter IDs restriction.
+// PRE: none
+// POST: Returns a non-nil slice
+func SegmentMemberIDs(members []member.Member) []string {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+// intersectIDs returns the IDs present in both slices, in the order of a.
+func intersectIDs(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, id := range b {
+		in[id] = true
+	}
+	out := []string{}
+	for _, id := range a {
+		if in[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
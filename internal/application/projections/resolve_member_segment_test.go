@@ -0,0 +1,122 @@
+package projections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	memberStore "workshop/internal/adapters/storage/member"
+	"workshop/internal/domain/attendance"
+	"workshop/internal/domain/member"
+	"workshop/internal/domain/membertag"
+)
+
+// --- Mocks for segment resolution tests ---
+
+type mockSegmentMemberStore struct {
+	members []member.Member
+}
+
+// List honours the program, status and IDs filters.
+// PRE: none
+// POST: Returns matching members
+func (m *mockSegmentMemberStore) List(_ context.Context, filter memberStore.ListFilter) ([]member.Member, error) {
+	var allowed map[string]bool
+	if filter.IDs != nil {
+		allowed = map[string]bool{}
+		for _, id := range filter.IDs {
+			allowed[id] = true
+		}
+	}
+	var out []member.Member
+	for _, mem := range m.members {
+		if (filter.Program != "" && mem.Program != filter.Program) || (filter.Status != "" && mem.Status != filter.Status) {
+			continue
+		}
+		if allowed != nil && !allowed[mem.ID] {
+			continue
+		}
+		out = append(out, mem)
+	}
+	return out, nil
+}
+
+type mockSegmentTagStore struct {
+	byTag map[string][]string
+}
+
+// ListMemberIDs returns the members carrying a tag.
+// PRE: none
+// POST: Returns member IDs
+func (m *mockSegmentTagStore) ListMemberIDs(_ context.Context, tag string) ([]string, error) {
+	return m.byTag[tag], nil
+}
+
+type mockSegmentAttendanceStore struct {
+	last map[string]time.Time
+}
+
+// ListByMemberID returns the member's most recent check-in, if any.
+// PRE: none
+// POST: Returns zero or one record
+func (m *mockSegmentAttendanceStore) ListByMemberID(_ context.Context, memberID string) ([]attendance.Attendance, error) {
+	t, ok := m.last[memberID]
+	if !ok {
+		return nil, nil
+	}
+	return []attendance.Attendance{{MemberID: memberID, CheckInTime: t}}, nil
+}
+
+// TestQueryResolveSegment verifies tags, program, archived members and attendance filters
+// combine to pick a segment's members.
+func TestQueryResolveSegment(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	deps := ResolveSegmentDeps{
+		MemberStore: &mockSegmentMemberStore{members: []member.Member{
+			{ID: "m1", Name: "Ana", Program: "adults", Status: member.StatusActive},
+			{ID: "m2", Name: "Ben", Program: "adults", Status: member.StatusActive},
+			{ID: "m3", Name: "Cy", Program: "kids", Status: member.StatusActive},
+			{ID: "m4", Name: "Di", Program: "adults", Status: member.StatusArchived},
+		}},
+		TagStore: &mockSegmentTagStore{byTag: map[string][]string{
+			"competitor": {"m1", "m2", "m3", "m4"},
+			"injured":    {"m2"},
+		}},
+		AttendanceStore: &mockSegmentAttendanceStore{last: map[string]time.Time{
+			"m1": now.AddDate(0, 0, -2),
+			"m2": now.AddDate(0, 0, -45),
+		}},
+		Now: func() time.Time { return now },
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		segment membertag.Segment
+		want    []string
+	}{
+		{"tag skips archived", membertag.Segment{Tags: []string{"competitor"}}, []string{"m1", "m2", "m3"}},
+		{"all tags must match", membertag.Segment{Tags: []string{"competitor", "injured"}}, []string{"m2"}},
+		{"unused tag matches none", membertag.Segment{Tags: []string{"unused"}}, nil},
+		{"tag and program", membertag.Segment{Tags: []string{"competitor"}, Program: "kids"}, []string{"m3"}},
+		{"recent attendance", membertag.Segment{Program: "adults", AttendedWithinDays: 7}, []string{"m1"}},
+		{"absent includes never", membertag.Segment{Tags: []string{"competitor"}, AbsentForDays: 30}, []string{"m2", "m3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := QueryResolveSegment(ctx, tt.segment, deps)
+			if err != nil {
+				t.Fatalf("QueryResolveSegment: %v", err)
+			}
+			ids := SegmentMemberIDs(got)
+			if len(ids) != len(tt.want) {
+				t.Fatalf("got %v, want %v", ids, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Errorf("got %v, want %v", ids, tt.want)
+				}
+			}
+		})
+	}
+}
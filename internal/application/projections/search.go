@@ -0,0 +1,85 @@
+package projections
+
+import (
+	"context"
+
+	"workshop/internal/domain/search"
+)
+
+// SearchIndexStore defines the search store interface needed by this projection.
+type SearchIndexStore interface {
+	Search(ctx context.Context, q search.Query) ([]search.Result, error)
+}
+
+// SearchDeps holds dependencies for the global search projection.
+type SearchDeps struct {
+	SearchStore SearchIndexStore
+}
+
+// SearchInput carries the caller's query and what they are allowed to see.
+type SearchInput struct {
+	Text         string
+	Staff        bool     // coach or admin
+	Kinds        []string // kinds whose feature is enabled for the caller
+	Participants []string // account ID and member ID, for messages
+	PerKind      int      // 0 uses search.DefaultPerKind
+}
+
+// SearchGroup is the results for one kind.
+type SearchGroup struct {
+	Kind    string
+	Results []search.Result
+}
+
+// SearchResult carries the output of the global search projection.
+type SearchResult struct {
+	Query  string
+	Groups []SearchGroup // in search.Kinds order; kinds without hits are omitted
+}
+
+// QuerySearch runs a global full-text search and groups the hits by kind.
+// Members are never searchable by non-staff, whatever Kinds says.
+// PRE: input.Text passes search.Query validation
+// POST: Returns only results the caller may see
+func QuerySearch(ctx context.Context, input SearchInput, deps SearchDeps) (SearchResult, error) {
+	q := search.Query{Text: input.Text, Staff: input.Staff, Participants: input.Participants, PerKind: input.PerKind}
+	if err := q.Validate(); err != nil {
+		return SearchResult{}, err
+	}
+	if q.PerKind <= 0 || q.PerKind > search.MaxResultsLimit {
+		q.PerKind = search.DefaultPerKind
+	}
+
+	allowed := make(map[string]bool, len(input.Kinds))
+	for _, k := range input.Kinds {
+		allowed[k] = true
+	}
+	if !input.Staff {
+		allowed[search.KindMember] = false
+	}
+	for _, k := range search.Kinds {
+		if allowed[k] {
+			q.Kinds = append(q.Kinds, k)
+		}
+	}
+
+	result := SearchResult{Query: input.Text, Groups: []SearchGroup{}}
+	if len(q.Kinds) == 0 {
+		return result, nil
+	}
+	hits, err := deps.SearchStore.Search(ctx, q)
+	if err != nil {
+		return SearchResult{}, err
+	}
+
+	byKind := make(map[string][]search.Result)
+	for _, h := range hits {
+		byKind[h.Kind] = append(byKind[h.Kind], h)
+	}
+	for _, k := range q.Kinds {
+		if len(byKind[k]) > 0 {
+			result.Groups = append(result.Groups, SearchGroup{Kind: k, Results: byKind[k]})
+		}
+	}
+	return result, nil
+}
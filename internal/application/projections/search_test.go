@@ -0,0 +1,82 @@
+package projections
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"workshop/internal/domain/search"
+)
+
+type mockSearchStore struct {
+	got search.Query
+}
+
+// Search implements SearchIndexStore.
+// PRE: none
+// POST: records the query and returns one hit per requested kind
+func (m *mockSearchStore) Search(_ context.Context, q search.Query) ([]search.Result, error) {
+	m.got = q
+	var hits []search.Result
+	for _, k := range q.Kinds {
+		hits = append(hits, search.Result{Kind: k, RefID: k + "-1", Title: "Armbar"})
+	}
+	return hits, nil
+}
+
+// TestQuerySearch tests role filtering and grouping of search results.
+func TestQuerySearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     SearchInput
+		wantKinds []string
+		wantErr   error
+	}{
+		{
+			name:      "staff sees every enabled kind in display order",
+			input:     SearchInput{Text: "armbar", Staff: true, Kinds: []string{search.KindMessage, search.KindMember, search.KindClip}},
+			wantKinds: []string{search.KindMember, search.KindClip, search.KindMessage},
+		},
+		{
+			name:      "members never search members",
+			input:     SearchInput{Text: "armbar", Kinds: []string{search.KindMember, search.KindNotice}},
+			wantKinds: []string{search.KindNotice},
+		},
+		{
+			name:      "no permitted kinds",
+			input:     SearchInput{Text: "armbar", Kinds: []string{search.KindMember}},
+			wantKinds: nil,
+		},
+		{
+			name:    "query too short",
+			input:   SearchInput{Text: "a", Staff: true, Kinds: search.Kinds},
+			wantErr: search.ErrQueryTooShort,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockSearchStore{}
+			got, err := QuerySearch(context.Background(), tt.input, SearchDeps{SearchStore: store})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got.Groups) != len(tt.wantKinds) {
+				t.Fatalf("got %d groups, want %d", len(got.Groups), len(tt.wantKinds))
+			}
+			for i, k := range tt.wantKinds {
+				if got.Groups[i].Kind != k || len(got.Groups[i].Results) != 1 {
+					t.Errorf("group %d = %+v, want kind %s", i, got.Groups[i], k)
+				}
+			}
+			if len(tt.wantKinds) > 0 && store.got.PerKind != search.DefaultPerKind {
+				t.Errorf("PerKind = %d, want default %d", store.got.PerKind, search.DefaultPerKind)
+			}
+		})
+	}
+}
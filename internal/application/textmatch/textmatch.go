@@ -0,0 +1,56 @@
+package textmatch
+
+import "strings"
+
+// Similarity scores how alike two strings are, case-insensitively, from 0 (nothing in
+// common) to 1 (equal): one minus the edit distance over the longer length.
+func Similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	la := strings.ToLower(a)
+	lb := strings.ToLower(b)
+	dist := Levenshtein(la, lb)
+	maxLen := len(la)
+	if len(lb) > maxLen {
+		maxLen = len(lb)
+	}
+	if maxLen == 0 {
+		return 1
+	}
+	return 1 - float64(dist)/float64(maxLen)
+}
+
+// Levenshtein returns the number of single-byte insertions, deletions and substitutions
+// needed to turn a into b.
+func Levenshtein(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if len(a) == 0 {
+		return len(b)
+	}
+	if len(b) == 0 {
+		return len(a)
+	}
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := 0; j <= len(b); j++ {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 0
+			if a[i-1] != b[j-1] {
+				cost = 1
+			}
+			del := prev[j] + 1
+			ins := curr[j-1] + 1
+			sub := prev[j-1] + cost
+			curr[j] = min(del, ins, sub)
+		}
+		copy(prev, curr)
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,109 @@
+// Package trace carries a request ID through a request's context so every log line, perf
+// entry and error it produces can be tied back to the request that caused it.
+//
+// The HTTP middleware assigns the ID; orchestrators, projections and stores receive it through
+// the ctx they are already given and log with slog's *Context functions.
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+)
+
+// MaxRequestIDLength bounds request IDs accepted from clients, so a caller cannot bloat every log line.
+const MaxRequestIDLength = 64
+
+// LogKey is the log attribute the request ID is written under.
+const LogKey = "request_id"
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the request ID.
+// PRE: id is non-empty
+// POST: RequestID(result) == id
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, or "" outside a request.
+// INVARIANT: ctx is not modified
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// NewRequestID returns a random 16-character hex ID.
+// PRE: none
+// POST: Returns a fresh ID that passes ValidRequestID
+func NewRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// ValidRequestID reports whether id is safe to reuse from a client: 1 to MaxRequestIDLength
+// letters, digits, dots, dashes or underscores.
+// INVARIANT: Pure function, no side effects
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > MaxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '.', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Handler is a slog.Handler that adds the context's request ID to every record.
+type Handler struct {
+	next slog.Handler
+}
+
+// NewHandler wraps next so records logged with a request's ctx carry its request ID.
+// PRE: next is non-nil
+// POST: Returns a handler that delegates to next
+func NewHandler(next slog.Handler) *Handler {
+	return &Handler{next: next}
+}
+
+// Enabled implements slog.Handler.
+// INVARIANT: Delegates to the wrapped handler
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler, adding the request ID when ctx carries one.
+// PRE: none
+// POST: record written by the wrapped handler
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		record = record.Clone()
+		record.AddAttrs(slog.String(LogKey, id))
+	}
+	return h.next.Handle(ctx, record)
+}
+
+// WithAttrs implements slog.Handler.
+// PRE: none
+// POST: Returns a wrapped handler with the attributes added
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Handler{next: h.next.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler.
+// PRE: none
+// POST: Returns a wrapped handler that nests later attributes under name
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name)}
+}
+
+var _ slog.Handler = (*Handler)(nil)
@@ -0,0 +1,52 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestHandler_AddsRequestID verifies records logged with a request's ctx carry its ID and others do not.
+func TestHandler_AddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewTextHandler(&buf, nil))).With("app", "workshop")
+
+	logger.InfoContext(WithRequestID(context.Background(), "abc123"), "member_event", "event", "saved")
+	logger.Info("worker_stopped")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines: %q", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], "request_id=abc123") || !strings.Contains(lines[0], "app=workshop") {
+		t.Errorf("request line = %q", lines[0])
+	}
+	if strings.Contains(lines[1], "request_id") {
+		t.Errorf("line outside a request has an ID: %q", lines[1])
+	}
+}
+
+// TestValidRequestID verifies client IDs are only reused when short and plain.
+func TestValidRequestID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{NewRequestID(), true},
+		{"lb-7f3a.0001_x", true},
+		{"", false},
+		{"has space", false},
+		{"line\nbreak", false},
+		{strings.Repeat("a", MaxRequestIDLength+1), false},
+	}
+	for _, tt := range tests {
+		if got := ValidRequestID(tt.id); got != tt.want {
+			t.Errorf("ValidRequestID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+	if RequestID(context.Background()) != "" {
+		t.Error("background context has a request ID")
+	}
+}
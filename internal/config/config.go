@@ -0,0 +1,484 @@
+// Package config loads and validates every server setting in one place.
+// Values come from the process environment, optionally layered over a KEY=VALUE file
+// named by WORKSHOP_CONFIG_FILE; the environment always wins.
+package config
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"workshop/internal/adapters/http/middleware"
+	"workshop/internal/adapters/storage"
+	backupDomain "workshop/internal/domain/backup"
+)
+
+// Environment names accepted in WORKSHOP_ENV.
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+)
+
+// Setting sources reported by Settings.
+const (
+	SourceEnv     = "env"
+	SourceFile    = "file"
+	SourceDefault = "default"
+)
+
+// defaultPerfRetentionDays is how long timing history for /admin/perf is kept by default.
+const defaultPerfRetentionDays = 30
+
+// defaultHSTSDays is how long browsers are told to insist on HTTPS in production (two years).
+const defaultHSTSDays = 730
+
+// minMetricsTokenLength keeps the /metrics bearer token out of guessing range.
+const minMetricsTokenLength = 16
+
+// Config is the validated server configuration.
+type Config struct {
+	Env           string // EnvDevelopment or EnvProduction
+	Addr          string
+	DBPath        string
+	AdminEmail    string // seeded admin account, created only when no accounts exist
+	AdminPassword string
+	CSRFKey       []byte // 32 bytes; random per start in development when unset
+	QRKey         []byte // 32 bytes; random per start in development when unset
+	MetricsToken  string // empty disables GET /metrics
+	Email         Email
+	Push          Push
+	Backup        Backup
+	OIDC          OIDC
+	AuthLimit     middleware.AuthLimitConfig
+	Server        Server
+	Security      Security
+	SlowRequest   time.Duration
+	SlowQuery     time.Duration
+	PerfRetention time.Duration // how long per-minute timing history is kept
+	// QueryPlanRows turns on the query plan guard: slow queries scanning a whole table of at
+	// least this many rows are flagged on /admin/perf. 0 = off.
+	QueryPlanRows int
+	// Warnings are non-fatal problems worth logging at startup.
+	Warnings []string
+
+	settings []Setting
+}
+
+// Email configures outbound mail through Resend.
+type Email struct {
+	ResendKey      string // empty selects the no-op sender
+	From           string
+	ReplyTo        string
+	WebhookSecret  string // empty disables /api/webhooks/resend
+	UnsubscribeKey []byte // 32 bytes; signs unsubscribe links, random per start in development when unset
+	PublicURL      string // origin used in links of emails sent by background workers; empty omits them
+}
+
+// Push configures Web Push notifications.
+type Push struct {
+	VAPIDKey []byte // 32-byte P-256 private key; nil disables push
+	Subject  string // mailto: or https: contact push services can reach
+}
+
+// OIDC configures signing in with an OpenID Connect provider such as Google.
+type OIDC struct {
+	Issuer       string
+	ClientID     string // empty disables single sign-on; password login is always available
+	ClientSecret string
+	RedirectURL  string // defaults to WORKSHOP_PUBLIC_URL + "/auth/oidc/callback"
+	Name         string // shown on the sign-in button
+}
+
+// Enabled reports whether single sign-on is configured.
+// PRE: none
+// POST: Returns true when a client ID is set
+func (o OIDC) Enabled() bool {
+	return o.ClientID != ""
+}
+
+// Backup configures scheduled database backups.
+type Backup struct {
+	Interval  time.Duration
+	Retention backupDomain.Retention
+	Dir       string // used when S3.Bucket is empty
+	S3        S3
+}
+
+// S3 configures an S3-compatible backup bucket.
+type S3 struct {
+	Bucket    string
+	Endpoint  string
+	Region    string
+	Prefix    string
+	AccessKey string
+	SecretKey string
+}
+
+// Server configures HTTP server limits and shutdown.
+type Server struct {
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	ShutdownTimeout   time.Duration
+}
+
+// Security configures the security headers.
+type Security struct {
+	HSTSMaxAge     time.Duration // 0 leaves Strict-Transport-Security off; on by default in production
+	FrameAncestors []string      // CSP sources allowed to embed the kiosk and TV board
+	// TrustedProxies are the reverse proxies whose X-Forwarded-For names the client; without
+	// them every request through a local proxy shares one rate limit bucket.
+	TrustedProxies []netip.Prefix
+}
+
+// Setting is one configuration value as shown on the admin diagnostics view.
+type Setting struct {
+	Key    string
+	Value  string // secrets are shown as "(set)" or "(unset)", never their value
+	Source string // SourceEnv, SourceFile or SourceDefault
+	Secret bool
+}
+
+// IsProduction reports whether the server runs with production safeguards.
+// PRE: none
+// POST: Returns true only for WORKSHOP_ENV=production
+func (c Config) IsProduction() bool {
+	return c.Env == EnvProduction
+}
+
+// Settings returns every setting in load order with secrets redacted.
+// PRE: c was returned by Load or Parse
+// POST: Returns a copy; no secret values are included
+func (c Config) Settings() []Setting {
+	return append([]Setting(nil), c.settings...)
+}
+
+// Load reads the environment, layered over WORKSHOP_CONFIG_FILE when set, and validates it.
+// PRE: none
+// POST: Returns the config, or every validation problem joined into one error
+func Load() (Config, error) {
+	var file map[string]string
+	if path := os.Getenv("WORKSHOP_CONFIG_FILE"); path != "" {
+		values, err := ReadFile(path)
+		if err != nil {
+			return Config{}, err
+		}
+		file = values
+	}
+	return Parse(os.LookupEnv, file)
+}
+
+// ReadFile parses a KEY=VALUE file in the same format as a systemd EnvironmentFile.
+// Blank lines and # comments are skipped; an "export " prefix and surrounding quotes are stripped.
+// PRE: path names a readable file
+// POST: Returns the values by key, or an error naming the bad line
+func ReadFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("config file: %w", err)
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(text, "export "), "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("config file %s:%d: expected KEY=VALUE", path, line)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("config file: %w", err)
+	}
+	return values, nil
+}
+
+// Parse builds and validates a Config from an environment lookup and optional file values.
+// PRE: lookup is non-nil (os.LookupEnv in production); file may be nil
+// POST: Returns the config, or every validation problem joined into one error
+func Parse(lookup func(key string) (string, bool), file map[string]string) (Config, error) {
+	l := &loader{lookup: lookup, file: file}
+	var c Config
+
+	c.Env = l.text("WORKSHOP_ENV", EnvDevelopment, false)
+	if c.Env != EnvDevelopment && c.Env != EnvProduction {
+		l.fail("WORKSHOP_ENV", "must be %q or %q, got %q", EnvDevelopment, EnvProduction, c.Env)
+	}
+	c.Addr = l.text("WORKSHOP_ADDR", ":8080", false)
+	c.DBPath = l.text("WORKSHOP_DB_PATH", "workshop.db", false)
+
+	c.AdminEmail = l.text("WORKSHOP_ADMIN_EMAIL", "info@workshopjiujitsu.co.nz", false)
+	if !strings.Contains(c.AdminEmail, "@") {
+		l.fail("WORKSHOP_ADMIN_EMAIL", "must be an email address")
+	}
+	c.AdminPassword = l.text("WORKSHOP_ADMIN_PASSWORD", "Umami monster", true)
+	if c.IsProduction() && l.source("WORKSHOP_ADMIN_PASSWORD") == SourceDefault {
+		c.Warnings = append(c.Warnings, "WORKSHOP_ADMIN_PASSWORD is not set; a new database would seed the admin with the default password")
+	}
+
+	c.CSRFKey = l.key("WORKSHOP_CSRF_KEY", c.IsProduction(), &c.Warnings, "sessions won't survive restart")
+	c.QRKey = l.key("WORKSHOP_QR_KEY", c.IsProduction(), &c.Warnings, "check-in codes won't survive restart")
+	c.MetricsToken = l.text("WORKSHOP_METRICS_TOKEN", "", true)
+	if c.MetricsToken != "" && len(c.MetricsToken) < minMetricsTokenLength {
+		l.fail("WORKSHOP_METRICS_TOKEN", "must be at least %d characters", minMetricsTokenLength)
+	}
+
+	c.Email = Email{
+		ResendKey:     l.text("WORKSHOP_RESEND_KEY", "", true),
+		From:          l.text("WORKSHOP_RESEND_FROM", "Workshop Jiu Jitsu <noreply@workshopjiujitsu.co.nz>", false),
+		ReplyTo:       l.text("WORKSHOP_REPLY_TO", "info@workshopjiujitsu.co.nz", false),
+		WebhookSecret: l.text("WORKSHOP_RESEND_WEBHOOK_SECRET", "", true),
+	}
+	if c.IsProduction() && c.Email.ResendKey == "" {
+		c.Warnings = append(c.Warnings, "WORKSHOP_RESEND_KEY is not set; email delivery is DISABLED in production")
+	}
+	c.Email.UnsubscribeKey = l.key("WORKSHOP_UNSUBSCRIBE_KEY", c.IsProduction(), &c.Warnings, "unsubscribe links in sent emails won't survive restart")
+	c.Email.PublicURL = strings.TrimRight(l.text("WORKSHOP_PUBLIC_URL", "", false), "/")
+	if c.Email.PublicURL != "" && !strings.HasPrefix(c.Email.PublicURL, "http://") && !strings.HasPrefix(c.Email.PublicURL, "https://") {
+		l.fail("WORKSHOP_PUBLIC_URL", "must start with http:// or https://")
+	}
+	if c.IsProduction() && c.Email.PublicURL == "" {
+		c.Warnings = append(c.Warnings, "WORKSHOP_PUBLIC_URL is not set; scheduled emails go out without unsubscribe links")
+	}
+
+	c.Push.Subject = l.text("WORKSHOP_VAPID_SUBJECT", "mailto:"+c.Email.ReplyTo, false)
+	if !strings.HasPrefix(c.Push.Subject, "mailto:") && !strings.HasPrefix(c.Push.Subject, "https:") {
+		l.fail("WORKSHOP_VAPID_SUBJECT", "must start with mailto: or https:")
+	}
+	if v := l.text("WORKSHOP_VAPID_KEY", "", true); v != "" {
+		decoded, err := hex.DecodeString(v)
+		if err != nil || len(decoded) != 32 {
+			l.fail("WORKSHOP_VAPID_KEY", "must be 64 hex characters (32 bytes)")
+		}
+		c.Push.VAPIDKey = decoded
+	} else if c.IsProduction() {
+		c.Warnings = append(c.Warnings, "WORKSHOP_VAPID_KEY is not set; push notifications are DISABLED (generate with: openssl rand -hex 32)")
+	}
+
+	c.OIDC = OIDC{
+		Issuer:       strings.TrimRight(l.text("WORKSHOP_OIDC_ISSUER", "https://accounts.google.com", false), "/"),
+		ClientID:     l.text("WORKSHOP_OIDC_CLIENT_ID", "", false),
+		ClientSecret: l.text("WORKSHOP_OIDC_CLIENT_SECRET", "", true),
+		Name:         l.text("WORKSHOP_OIDC_NAME", "Google", false),
+	}
+	defaultRedirect := ""
+	if c.Email.PublicURL != "" {
+		defaultRedirect = c.Email.PublicURL + "/auth/oidc/callback"
+	}
+	c.OIDC.RedirectURL = l.text("WORKSHOP_OIDC_REDIRECT_URL", defaultRedirect, false)
+	if c.OIDC.Enabled() {
+		if c.OIDC.ClientSecret == "" {
+			l.fail("WORKSHOP_OIDC_CLIENT_SECRET", "is required when WORKSHOP_OIDC_CLIENT_ID is set")
+		}
+		if c.OIDC.RedirectURL == "" {
+			l.fail("WORKSHOP_OIDC_REDIRECT_URL", "is required when WORKSHOP_OIDC_CLIENT_ID is set (or set WORKSHOP_PUBLIC_URL)")
+		}
+		if !strings.HasPrefix(c.OIDC.Issuer, "https://") && c.IsProduction() {
+			l.fail("WORKSHOP_OIDC_ISSUER", "must start with https:// in production")
+		}
+	}
+
+	c.Backup = Backup{
+		Interval: l.duration("WORKSHOP_BACKUP_INTERVAL", 24*time.Hour),
+		Retention: backupDomain.Retention{
+			KeepLast: l.integer("WORKSHOP_BACKUP_KEEP_LAST", backupDomain.DefaultRetention.KeepLast),
+			KeepDays: l.integer("WORKSHOP_BACKUP_KEEP_DAYS", backupDomain.DefaultRetention.KeepDays),
+		},
+		Dir: l.text("WORKSHOP_BACKUP_DIR", "backups", false),
+		S3: S3{
+			Bucket:    l.text("WORKSHOP_BACKUP_S3_BUCKET", "", false),
+			Endpoint:  l.text("WORKSHOP_BACKUP_S3_ENDPOINT", "", false),
+			Region:    l.text("WORKSHOP_BACKUP_S3_REGION", "us-east-1", false),
+			Prefix:    l.text("WORKSHOP_BACKUP_S3_PREFIX", "", false),
+			AccessKey: l.text("WORKSHOP_BACKUP_S3_ACCESS_KEY", "", true),
+			SecretKey: l.text("WORKSHOP_BACKUP_S3_SECRET_KEY", "", true),
+		},
+	}
+
+	defaultLimits := middleware.DefaultAuthLimitConfig
+	c.AuthLimit = middleware.AuthLimitConfig{
+		PerIP:    l.integer("WORKSHOP_AUTH_LIMIT_PER_IP", defaultLimits.PerIP),
+		PerEmail: l.integer("WORKSHOP_AUTH_LIMIT_PER_EMAIL", defaultLimits.PerEmail),
+		Window:   l.duration("WORKSHOP_AUTH_LIMIT_WINDOW", defaultLimits.Window),
+	}
+
+	c.Server = Server{
+		ReadHeaderTimeout: l.duration("WORKSHOP_READ_HEADER_TIMEOUT", 10*time.Second),
+		ReadTimeout:       l.duration("WORKSHOP_READ_TIMEOUT", 30*time.Second),
+		WriteTimeout:      l.duration("WORKSHOP_WRITE_TIMEOUT", 2*time.Minute),
+		IdleTimeout:       l.duration("WORKSHOP_IDLE_TIMEOUT", 2*time.Minute),
+		MaxHeaderBytes:    l.integer("WORKSHOP_MAX_HEADER_BYTES", http.DefaultMaxHeaderBytes),
+		ShutdownTimeout:   l.duration("WORKSHOP_SHUTDOWN_TIMEOUT", 30*time.Second),
+	}
+	hstsDays := 0
+	if c.IsProduction() {
+		hstsDays = defaultHSTSDays
+	}
+	c.Security = Security{
+		HSTSMaxAge:     time.Duration(l.integer("WORKSHOP_HSTS_MAX_AGE_DAYS", hstsDays)) * 24 * time.Hour,
+		FrameAncestors: strings.Fields(l.text("WORKSHOP_FRAME_ANCESTORS", "'self'", false)),
+	}
+	for _, src := range c.Security.FrameAncestors {
+		if src != "'self'" && !strings.HasPrefix(src, "https://") && (c.IsProduction() || !strings.HasPrefix(src, "http://")) {
+			l.fail("WORKSHOP_FRAME_ANCESTORS", "must list 'self' or https:// origins, got %q", src)
+		}
+	}
+
+	for _, v := range strings.Fields(l.text("WORKSHOP_TRUSTED_PROXIES", "", false)) {
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(v)
+			if addrErr != nil {
+				l.fail("WORKSHOP_TRUSTED_PROXIES", "must list IP addresses or CIDR ranges, got %q", v)
+				continue
+			}
+			prefix = netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen())
+		}
+		c.Security.TrustedProxies = append(c.Security.TrustedProxies, prefix.Masked())
+	}
+	if c.IsProduction() && len(c.Security.TrustedProxies) == 0 && (strings.HasPrefix(c.Addr, "127.0.0.1:") || strings.HasPrefix(c.Addr, "localhost:")) {
+		c.Warnings = append(c.Warnings, "WORKSHOP_TRUSTED_PROXIES is not set but WORKSHOP_ADDR is local; every client behind the proxy shares one login rate limit")
+	}
+
+	c.SlowRequest = time.Duration(l.integer("WORKSHOP_SLOW_REQUEST_MS", middleware.DefaultSlowRequestMs)) * time.Millisecond
+	c.SlowQuery = time.Duration(l.integer("WORKSHOP_SLOW_QUERY_MS", storage.DefaultSlowQueryMs)) * time.Millisecond
+	c.PerfRetention = time.Duration(l.integer("WORKSHOP_PERF_RETENTION_DAYS", defaultPerfRetentionDays)) * 24 * time.Hour
+	c.QueryPlanRows = l.integer("WORKSHOP_QUERY_PLAN_ROWS", 0)
+	if c.IsProduction() && c.QueryPlanRows > 0 {
+		c.Warnings = append(c.Warnings, "WORKSHOP_QUERY_PLAN_ROWS is set; slow queries are explained and tables counted, which adds load in production")
+	}
+
+	c.settings = l.settings
+	if len(l.errs) > 0 {
+		return Config{}, errors.Join(l.errs...)
+	}
+	return c, nil
+}
+
+// loader reads typed values, recording each setting and collecting every error.
+type loader struct {
+	lookup   func(key string) (string, bool)
+	file     map[string]string
+	errs     []error
+	settings []Setting
+}
+
+// raw returns the value for key and where it came from; the environment wins over the file.
+func (l *loader) raw(key string) (string, string) {
+	if v, ok := l.lookup(key); ok && v != "" {
+		return v, SourceEnv
+	}
+	if v, ok := l.file[key]; ok && v != "" {
+		return v, SourceFile
+	}
+	return "", SourceDefault
+}
+
+// source reports where key's value came from.
+func (l *loader) source(key string) string {
+	_, src := l.raw(key)
+	return src
+}
+
+// record adds key to the diagnostics view, redacting secrets.
+func (l *loader) record(key, value, source string, secret bool) {
+	if secret {
+		value = "(unset)"
+		if source != SourceDefault {
+			value = "(set)"
+		}
+	}
+	l.settings = append(l.settings, Setting{Key: key, Value: value, Source: source, Secret: secret})
+}
+
+// fail records a validation error for key.
+func (l *loader) fail(key, format string, args ...any) {
+	l.errs = append(l.errs, fmt.Errorf("%s "+format, append([]any{key}, args...)...))
+}
+
+// text returns a string setting, or fallback when unset.
+func (l *loader) text(key, fallback string, secret bool) string {
+	v, src := l.raw(key)
+	if src == SourceDefault {
+		v = fallback
+	}
+	l.record(key, v, src, secret)
+	return v
+}
+
+// integer returns a positive integer setting, or fallback when unset.
+func (l *loader) integer(key string, fallback int) int {
+	v, src := l.raw(key)
+	if src == SourceDefault {
+		l.record(key, strconv.Itoa(fallback), src, false)
+		return fallback
+	}
+	l.record(key, v, src, false)
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		l.fail(key, "must be a positive integer, got %q", v)
+		return fallback
+	}
+	return n
+}
+
+// duration returns a positive duration setting such as "30s", or fallback when unset.
+func (l *loader) duration(key string, fallback time.Duration) time.Duration {
+	v, src := l.raw(key)
+	if src == SourceDefault {
+		l.record(key, fallback.String(), src, false)
+		return fallback
+	}
+	l.record(key, v, src, false)
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		l.fail(key, "must be a positive duration such as 30s or 5m, got %q", v)
+		return fallback
+	}
+	return d
+}
+
+// key returns a 32-byte hex-encoded secret. When unset it is required in production;
+// in development a random key is generated and a warning explains the consequence.
+func (l *loader) key(key string, required bool, warnings *[]string, consequence string) []byte {
+	v, src := l.raw(key)
+	l.record(key, v, src, true)
+	if src != SourceDefault {
+		decoded, err := hex.DecodeString(v)
+		if err != nil || len(decoded) != 32 {
+			l.fail(key, "must be 64 hex characters (32 bytes)")
+			return nil
+		}
+		return decoded
+	}
+	if required {
+		l.fail(key, "is required in production (generate with: openssl rand -hex 32)")
+		return nil
+	}
+	generated := make([]byte, 32)
+	if _, err := rand.Read(generated); err != nil {
+		l.fail(key, "could not be generated: %v", err)
+		return nil
+	}
+	*warnings = append(*warnings, fmt.Sprintf("using random %s (%s); set it for production", key, consequence))
+	return generated
+}
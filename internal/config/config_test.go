@@ -0,0 +1,193 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// envFrom returns a lookup over a fixed map.
+func envFrom(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := values[key]
+		return v, ok
+	}
+}
+
+const testKey = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// TestParse_Defaults verifies an empty environment yields a usable development config.
+func TestParse_Defaults(t *testing.T) {
+	c, err := Parse(envFrom(nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Env != EnvDevelopment || c.Addr != ":8080" || c.DBPath != "workshop.db" {
+		t.Errorf("unexpected defaults: env=%q addr=%q db=%q", c.Env, c.Addr, c.DBPath)
+	}
+	if len(c.CSRFKey) != 32 || len(c.QRKey) != 32 || len(c.Email.UnsubscribeKey) != 32 {
+		t.Error("expected random keys in development")
+	}
+	if c.AuthLimit.PerIP != 20 || c.Server.ShutdownTimeout != 30*time.Second || c.SlowQuery != 50*time.Millisecond || c.PerfRetention != 30*24*time.Hour || c.QueryPlanRows != 0 {
+		t.Errorf("unexpected numeric defaults: %+v %+v %v %v %d", c.AuthLimit, c.Server, c.SlowQuery, c.PerfRetention, c.QueryPlanRows)
+	}
+	if len(c.Warnings) != 3 {
+		t.Errorf("expected random-key warnings, got %v", c.Warnings)
+	}
+	if c.Security.HSTSMaxAge != 0 || len(c.Security.FrameAncestors) != 1 || c.Security.FrameAncestors[0] != "'self'" {
+		t.Errorf("expected no HSTS and same-site framing in development, got %+v", c.Security)
+	}
+}
+
+// TestParse_Errors verifies every invalid setting is reported together.
+func TestParse_Errors(t *testing.T) {
+	_, err := Parse(envFrom(map[string]string{
+		"WORKSHOP_ENV":               "production",
+		"WORKSHOP_QR_KEY":            "abc",
+		"WORKSHOP_AUTH_LIMIT_PER_IP": "-1",
+		"WORKSHOP_READ_TIMEOUT":      "soon",
+		"WORKSHOP_METRICS_TOKEN":     "short",
+		"WORKSHOP_ADMIN_EMAIL":       "admin",
+		"WORKSHOP_PUBLIC_URL":        "gym.example.com",
+		"WORKSHOP_VAPID_KEY":         "abc",
+		"WORKSHOP_VAPID_SUBJECT":     "info@example.com",
+		"WORKSHOP_FRAME_ANCESTORS":   "'self' http://signage.local",
+		"WORKSHOP_TRUSTED_PROXIES":   "127.0.0.1 caddy",
+	}), nil)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{
+		"WORKSHOP_CSRF_KEY is required in production",
+		"WORKSHOP_QR_KEY must be 64 hex characters",
+		"WORKSHOP_AUTH_LIMIT_PER_IP must be a positive integer",
+		"WORKSHOP_READ_TIMEOUT must be a positive duration",
+		"WORKSHOP_METRICS_TOKEN must be at least 16 characters",
+		"WORKSHOP_ADMIN_EMAIL must be an email address",
+		"WORKSHOP_UNSUBSCRIBE_KEY is required in production",
+		"WORKSHOP_PUBLIC_URL must start with http:// or https://",
+		"WORKSHOP_VAPID_KEY must be 64 hex characters",
+		"WORKSHOP_VAPID_SUBJECT must start with mailto: or https:",
+		`WORKSHOP_FRAME_ANCESTORS must list 'self' or https:// origins, got "http://signage.local"`,
+		`WORKSHOP_TRUSTED_PROXIES must list IP addresses or CIDR ranges, got "caddy"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing %q in:\n%v", want, err)
+		}
+	}
+
+	if _, err := Parse(envFrom(map[string]string{"WORKSHOP_ENV": "prod"}), nil); err == nil || !strings.Contains(err.Error(), "WORKSHOP_ENV must be") {
+		t.Errorf("expected unknown environment to be rejected, got %v", err)
+	}
+}
+
+// TestParse_SourcesAndRedaction verifies env beats file, and secrets never reach Settings.
+func TestParse_SourcesAndRedaction(t *testing.T) {
+	c, err := Parse(envFrom(map[string]string{
+		"WORKSHOP_ADDR":       "127.0.0.1:9000",
+		"WORKSHOP_RESEND_KEY": "re_live_secret",
+	}), map[string]string{
+		"WORKSHOP_ADDR":     "0.0.0.0:80",
+		"WORKSHOP_CSRF_KEY": testKey,
+		"WORKSHOP_DB_PATH":  "/data/workshop.db",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Addr != "127.0.0.1:9000" || c.DBPath != "/data/workshop.db" || c.Email.ResendKey != "re_live_secret" {
+		t.Errorf("unexpected values: addr=%q db=%q", c.Addr, c.DBPath)
+	}
+
+	settings := map[string]Setting{}
+	for _, s := range c.Settings() {
+		settings[s.Key] = s
+		if strings.Contains(s.Value, "secret") || strings.Contains(s.Value, testKey) {
+			t.Errorf("%s leaks its value: %q", s.Key, s.Value)
+		}
+	}
+	tests := []struct {
+		key, value, source string
+	}{
+		{"WORKSHOP_ADDR", "127.0.0.1:9000", SourceEnv},
+		{"WORKSHOP_DB_PATH", "/data/workshop.db", SourceFile},
+		{"WORKSHOP_CSRF_KEY", "(set)", SourceFile},
+		{"WORKSHOP_RESEND_KEY", "(set)", SourceEnv},
+		{"WORKSHOP_QR_KEY", "(unset)", SourceDefault},
+		{"WORKSHOP_IDLE_TIMEOUT", "2m0s", SourceDefault},
+	}
+	for _, tt := range tests {
+		if s := settings[tt.key]; s.Value != tt.value || s.Source != tt.source {
+			t.Errorf("%s = %q from %s, want %q from %s", tt.key, s.Value, s.Source, tt.value, tt.source)
+		}
+	}
+}
+
+// TestParse_OIDC verifies single sign-on is off by default, takes its redirect from the public
+// URL, and needs a secret once a client ID is set.
+func TestParse_OIDC(t *testing.T) {
+	c, err := Parse(envFrom(nil), nil)
+	if err != nil || c.OIDC.Enabled() {
+		t.Fatalf("expected SSO disabled by default, got %+v, %v", c.OIDC, err)
+	}
+
+	c, err = Parse(envFrom(map[string]string{
+		"WORKSHOP_PUBLIC_URL":         "https://gym.example.com/",
+		"WORKSHOP_OIDC_CLIENT_ID":     "client-1.apps.googleusercontent.com",
+		"WORKSHOP_OIDC_CLIENT_SECRET": "oidc-secret",
+	}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.OIDC.Enabled() || c.OIDC.Issuer != "https://accounts.google.com" || c.OIDC.Name != "Google" ||
+		c.OIDC.RedirectURL != "https://gym.example.com/auth/oidc/callback" {
+		t.Errorf("unexpected OIDC config: %+v", c.OIDC)
+	}
+
+	_, err = Parse(envFrom(map[string]string{"WORKSHOP_OIDC_CLIENT_ID": "client-1"}), nil)
+	if err == nil || !strings.Contains(err.Error(), "WORKSHOP_OIDC_CLIENT_SECRET is required") ||
+		!strings.Contains(err.Error(), "WORKSHOP_OIDC_REDIRECT_URL is required") {
+		t.Errorf("expected missing secret and redirect errors, got %v", err)
+	}
+}
+
+// TestReadFile verifies comments, export prefixes and quotes are handled.
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workshop.env")
+	content := "# production\n\nWORKSHOP_ENV=production\nexport WORKSHOP_RESEND_FROM=\"Workshop <noreply@example.com>\"\nWORKSHOP_REPLY_TO='info@example.com'\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	values, err := ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["WORKSHOP_ENV"] != "production" || values["WORKSHOP_RESEND_FROM"] != "Workshop <noreply@example.com>" || values["WORKSHOP_REPLY_TO"] != "info@example.com" {
+		t.Errorf("unexpected values: %v", values)
+	}
+
+	if err := os.WriteFile(path, []byte("WORKSHOP_ENV\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadFile(path); err == nil || !strings.Contains(err.Error(), ":1:") {
+		t.Errorf("expected line-numbered error, got %v", err)
+	}
+}
+
+// TestParse_TrustedProxies verifies proxies are read as addresses or ranges.
+func TestParse_TrustedProxies(t *testing.T) {
+	c, err := Parse(envFrom(map[string]string{"WORKSHOP_TRUSTED_PROXIES": "127.0.0.1 ::1 10.0.0.0/8"}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"127.0.0.1/32", "::1/128", "10.0.0.0/8"}
+	if len(c.Security.TrustedProxies) != len(want) {
+		t.Fatalf("TrustedProxies = %v, want %v", c.Security.TrustedProxies, want)
+	}
+	for i, p := range c.Security.TrustedProxies {
+		if p.String() != want[i] {
+			t.Errorf("TrustedProxies[%d] = %s, want %s", i, p, want[i])
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package account
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// EmailChangeLifetime is how long the link confirming a new email address stays valid.
+const EmailChangeLifetime = 24 * time.Hour
+
+// Email change errors
+var (
+	ErrSameEmail          = errors.New("that is already your email address")
+	ErrEmailTaken         = errors.New("that email address is already in use")
+	ErrEmailChangeInvalid = errors.New("email change link is invalid")
+	ErrEmailChangeUsed    = errors.New("email change link has already been used")
+	ErrEmailChangeExpired = errors.New("email change link has expired")
+)
+
+// EmailChange is a request to move an account to a new email address. It takes effect only
+// once the token emailed to the new address is confirmed.
+// INVARIANT: NewEmail is normalised and differs from OldEmail
+type EmailChange struct {
+	ID          string
+	AccountID   string
+	OldEmail    string
+	NewEmail    string
+	Token       string
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+	Used        bool      // confirmed, or replaced by a later request
+	ConfirmedAt time.Time // zero until confirmed
+}
+
+// NewEmailChange starts a change of the account's email to newEmail.
+// PRE: id and token are non-empty and unguessable
+// POST: Returns a change expiring EmailChangeLifetime after now, or ErrSameEmail or the
+// validation error for newEmail
+func NewEmailChange(acct Account, newEmail, id, token string, now time.Time) (EmailChange, error) {
+	newEmail = strings.ToLower(strings.TrimSpace(newEmail))
+	if strings.EqualFold(newEmail, acct.Email) {
+		return EmailChange{}, ErrSameEmail
+	}
+	candidate := acct
+	candidate.Email = newEmail
+	if err := candidate.Validate(); err != nil {
+		return EmailChange{}, err
+	}
+	return EmailChange{
+		ID:        id,
+		AccountID: acct.ID,
+		OldEmail:  acct.Email,
+		NewEmail:  newEmail,
+		Token:     token,
+		ExpiresAt: now.Add(EmailChangeLifetime),
+		CreatedAt: now,
+	}, nil
+}
+
+// CheckUsable reports whether the change can still be confirmed.
+// PRE: none
+// POST: Returns nil, ErrEmailChangeUsed or ErrEmailChangeExpired
+func (c EmailChange) CheckUsable(now time.Time) error {
+	if c.Used {
+		return ErrEmailChangeUsed
+	}
+	if now.After(c.ExpiresAt) {
+		return ErrEmailChangeExpired
+	}
+	return nil
+}
@@ -22,27 +22,54 @@ const (
 	RoleGuest  = "guest"
 )
 
+// Lockout policy: repeated wrong passwords lock the account temporarily.
+const (
+	MaxFailedLogins = 5
+	LockoutDuration = 15 * time.Minute
+)
+
+// ActivationTokenLifetime is how long an activation link stays valid.
+const ActivationTokenLifetime = 72 * time.Hour
+
 // Account status constants
 const (
 	StatusActive            = "active"
 	StatusPendingActivation = "pending_activation"
+	StatusSuspended         = "suspended" // cannot sign in; SuspendedReason is shown instead
+)
+
+// MaxSuspendedReasonLength caps the reason shown to a suspended account.
+const MaxSuspendedReasonLength = 500
+
+// Locale constants: the languages pages and messages are offered in.
+const (
+	LocaleEnglishNZ = "en-NZ" // default
+	LocaleMaori     = "mi"    // te reo Māori
 )
 
+// ValidLocales contains every locale an account may choose.
+var ValidLocales = []string{LocaleEnglishNZ, LocaleMaori}
+
 // ValidRoles contains all valid role values.
 var ValidRoles = []string{RoleAdmin, RoleCoach, RoleMember, RoleTrial, RoleGuest}
 
 // Domain errors
 var (
-	ErrInvalidEmail     = errors.New("email must contain '@'")
-	ErrEmptyEmail       = errors.New("email cannot be empty")
-	ErrInvalidRole      = errors.New("role must be one of: admin, coach, member, trial, guest")
-	ErrEmptyPassword    = errors.New("password cannot be empty")
-	ErrPasswordTooShort = errors.New("password must be at least 12 characters")
-	ErrWrongPassword    = errors.New("incorrect password")
-	ErrTokenExpired     = errors.New("activation link has expired")
-	ErrTokenInvalid     = errors.New("activation token is invalid")
-	ErrAlreadyActivated = errors.New("account is already activated")
-	ErrNotPending       = errors.New("account is not pending activation")
+	ErrInvalidEmail         = errors.New("email must contain '@'")
+	ErrEmptyEmail           = errors.New("email cannot be empty")
+	ErrInvalidRole          = errors.New("role must be one of: admin, coach, member, trial, guest")
+	ErrEmptyPassword        = errors.New("password cannot be empty")
+	ErrPasswordTooShort     = errors.New("password must be at least 12 characters")
+	ErrWrongPassword        = errors.New("incorrect password")
+	ErrTokenExpired         = errors.New("activation link has expired")
+	ErrTokenInvalid         = errors.New("activation token is invalid")
+	ErrAlreadyActivated     = errors.New("account is already activated")
+	ErrNotPending           = errors.New("account is not pending activation")
+	ErrAlreadySuspended     = errors.New("account is already suspended")
+	ErrNotSuspended         = errors.New("account is not suspended")
+	ErrEmptySuspendReason   = errors.New("a suspension needs a reason")
+	ErrSuspendReasonTooLong = errors.New("suspension reason cannot exceed 500 characters")
+	ErrInvalidLocale        = errors.New("locale must be one of: en-NZ, mi")
 )
 
 // Account holds state for the Account concept.
@@ -51,12 +78,15 @@ type Account struct {
 	Email                  string
 	PasswordHash           string
 	Role                   string
-	Status                 string // active, pending_activation
+	Status                 string // active, pending_activation, suspended
 	CreatedAt              time.Time
 	FailedLogins           int
 	LockedUntil            time.Time
 	PasswordChangeRequired bool
 	BetaTester             bool
+	LocationID             string // empty = may work at all locations
+	SuspendedReason        string // shown at sign-in while suspended
+	Locale                 string // chosen language (see ValidLocales); empty = follow the browser
 }
 
 // ActivationToken represents a time-limited token for account activation.
@@ -85,6 +115,20 @@ func (a *Account) Validate() error {
 	if !isValidRole(a.Role) {
 		return ErrInvalidRole
 	}
+	if a.Locale != "" && !isValidLocale(a.Locale) {
+		return ErrInvalidLocale
+	}
+	return nil
+}
+
+// SetLocale records the account's chosen language. An empty locale goes back to following the browser.
+// PRE: none
+// POST: Locale is set, or ErrInvalidLocale is returned and Locale is unchanged
+func (a *Account) SetLocale(locale string) error {
+	if locale != "" && !isValidLocale(locale) {
+		return ErrInvalidLocale
+	}
+	a.Locale = locale
 	return nil
 }
 
@@ -129,13 +173,13 @@ func (a *Account) IsLocked() bool {
 	return time.Now().Before(a.LockedUntil)
 }
 
-// RecordFailedLogin increments the failed login counter and locks the account after 5 failures.
+// RecordFailedLogin increments the failed login counter and locks the account after MaxFailedLogins failures.
 // PRE: Account exists
-// POST: FailedLogins incremented; LockedUntil set if >= 5 failures
+// POST: FailedLogins incremented; LockedUntil set if >= MaxFailedLogins failures
 func (a *Account) RecordFailedLogin() {
 	a.FailedLogins++
-	if a.FailedLogins >= 5 {
-		a.LockedUntil = time.Now().Add(15 * time.Minute)
+	if a.FailedLogins >= MaxFailedLogins {
+		a.LockedUntil = time.Now().Add(LockoutDuration)
 	}
 }
 
@@ -159,6 +203,13 @@ func (a *Account) IsCoachOrAdmin() bool {
 	return a.Role == RoleAdmin || a.Role == RoleCoach
 }
 
+// CanAccessLocation returns true if the account may work at the given location.
+// Accounts with no LocationID are unscoped and may access every location.
+// INVARIANT: Account fields are not mutated
+func (a *Account) CanAccessLocation(locationID string) bool {
+	return a.LocationID == "" || a.LocationID == locationID
+}
+
 // IsPendingActivation returns true if the account is pending activation.
 // INVARIANT: Account fields are not mutated
 func (a *Account) IsPendingActivation() bool {
@@ -179,6 +230,43 @@ func (a *Account) Activate() error {
 	return nil
 }
 
+// IsSuspended returns true if the account is suspended.
+// INVARIANT: Account fields are not mutated
+func (a *Account) IsSuspended() bool {
+	return a.Status == StatusSuspended
+}
+
+// Suspend blocks the account from signing in, recording the reason it will be shown.
+// PRE: reason is non-empty and at most MaxSuspendedReasonLength
+// POST: Status is suspended and SuspendedReason is set
+func (a *Account) Suspend(reason string) error {
+	if a.Status == StatusSuspended {
+		return ErrAlreadySuspended
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrEmptySuspendReason
+	}
+	if len(reason) > MaxSuspendedReasonLength {
+		return ErrSuspendReasonTooLong
+	}
+	a.Status = StatusSuspended
+	a.SuspendedReason = reason
+	return nil
+}
+
+// Reinstate lifts a suspension.
+// PRE: Account is suspended
+// POST: Status is active and SuspendedReason is cleared
+func (a *Account) Reinstate() error {
+	if a.Status != StatusSuspended {
+		return ErrNotSuspended
+	}
+	a.Status = StatusActive
+	a.SuspendedReason = ""
+	return nil
+}
+
 // IsExpired returns true if the activation token has expired.
 // INVARIANT: Token fields are not mutated
 func (t *ActivationToken) IsExpired(now time.Time) bool {
@@ -200,3 +288,13 @@ func isValidRole(role string) bool {
 	}
 	return false
 }
+
+// isValidLocale checks if the locale is one of ValidLocales.
+func isValidLocale(locale string) bool {
+	for _, l := range ValidLocales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
@@ -267,6 +267,60 @@ func TestAccount_RoleChecks(t *testing.T) {
 	}
 }
 
+// TestAccount_CanAccessLocation tests location-scoped access.
+func TestAccount_CanAccessLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		scope    string
+		location string
+		want     bool
+	}{
+		{"unscoped account any location", "", "loc-a", true},
+		{"scoped account own location", "loc-a", "loc-a", true},
+		{"scoped account other location", "loc-a", "loc-b", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &account.Account{LocationID: tt.scope}
+			if got := a.CanAccessLocation(tt.location); got != tt.want {
+				t.Errorf("CanAccessLocation(%q) = %v, want %v", tt.location, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAccount_SetLocale tests choosing and clearing a language.
+func TestAccount_SetLocale(t *testing.T) {
+	tests := []struct {
+		name    string
+		locale  string
+		wantErr error
+	}{
+		{"default", account.LocaleEnglishNZ, nil},
+		{"te reo Māori", account.LocaleMaori, nil},
+		{"follow the browser", "", nil},
+		{"unsupported", "fr", account.ErrInvalidLocale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &account.Account{Email: "a@example.com", Role: account.RoleMember, Locale: account.LocaleMaori}
+			err := a.SetLocale(tt.locale)
+			if err != tt.wantErr {
+				t.Fatalf("SetLocale(%q) error = %v, want %v", tt.locale, err, tt.wantErr)
+			}
+			if err == nil && a.Locale != tt.locale {
+				t.Errorf("Locale = %q, want %q", a.Locale, tt.locale)
+			}
+			if err != nil && a.Locale != account.LocaleMaori {
+				t.Errorf("Locale changed to %q on error", a.Locale)
+			}
+			if verr := a.Validate(); verr != nil {
+				t.Errorf("Validate() = %v after SetLocale", verr)
+			}
+		})
+	}
+}
+
 // TestAccount_Activate tests the Activate state transition.
 func TestAccount_Activate(t *testing.T) {
 	t.Run("pending to active", func(t *testing.T) {
@@ -331,3 +385,58 @@ func TestActivationToken_Invalidate(t *testing.T) {
 		t.Error("expected Used to be true after Invalidate()")
 	}
 }
+
+// TestAccount_SuspendReinstate tests suspending needs a reason and reinstating clears it.
+func TestAccount_SuspendReinstate(t *testing.T) {
+	a := &account.Account{Status: account.StatusActive}
+	if err := a.Reinstate(); err != account.ErrNotSuspended {
+		t.Errorf("reinstate active: got %v, want ErrNotSuspended", err)
+	}
+	if err := a.Suspend("  "); err != account.ErrEmptySuspendReason {
+		t.Errorf("blank reason: got %v, want ErrEmptySuspendReason", err)
+	}
+	if err := a.Suspend("Unpaid fees since March"); err != nil {
+		t.Fatalf("Suspend: %v", err)
+	}
+	if !a.IsSuspended() || a.SuspendedReason != "Unpaid fees since March" {
+		t.Errorf("after Suspend: status %q, reason %q", a.Status, a.SuspendedReason)
+	}
+	if err := a.Suspend("again"); err != account.ErrAlreadySuspended {
+		t.Errorf("suspend twice: got %v, want ErrAlreadySuspended", err)
+	}
+	if err := a.Reinstate(); err != nil || a.Status != account.StatusActive || a.SuspendedReason != "" {
+		t.Errorf("Reinstate: err %v, status %q, reason %q", err, a.Status, a.SuspendedReason)
+	}
+}
+
+// TestNewEmailChange tests that a change normalises the new address, rejects the current one,
+// and can only be confirmed once within its lifetime.
+func TestNewEmailChange(t *testing.T) {
+	now := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	acct := account.Account{ID: "a1", Email: "aroha@example.com", Role: account.RoleMember}
+
+	if _, err := account.NewEmailChange(acct, " AROHA@example.com ", "c1", "tok", now); err != account.ErrSameEmail {
+		t.Errorf("same address: got %v, want ErrSameEmail", err)
+	}
+	if _, err := account.NewEmailChange(acct, "not-an-address", "c1", "tok", now); err != account.ErrInvalidEmail {
+		t.Errorf("bad address: got %v, want ErrInvalidEmail", err)
+	}
+
+	change, err := account.NewEmailChange(acct, " Aroha.Smith@Example.com", "c1", "tok", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if change.NewEmail != "aroha.smith@example.com" || change.OldEmail != "aroha@example.com" || change.AccountID != "a1" {
+		t.Errorf("unexpected change: %+v", change)
+	}
+	if err := change.CheckUsable(now.Add(account.EmailChangeLifetime)); err != nil {
+		t.Errorf("within lifetime: got %v", err)
+	}
+	if err := change.CheckUsable(now.Add(account.EmailChangeLifetime + time.Second)); err != account.ErrEmailChangeExpired {
+		t.Errorf("after lifetime: got %v, want ErrEmailChangeExpired", err)
+	}
+	change.Used = true
+	if err := change.CheckUsable(now); err != account.ErrEmailChangeUsed {
+		t.Errorf("used: got %v, want ErrEmailChangeUsed", err)
+	}
+}
@@ -0,0 +1,166 @@
+package attendance
+
+import (
+	"errors"
+	"sort"
+)
+
+// Anomaly kinds
+const (
+	AnomalyDuplicate = "duplicate" // the same member checked into the same class twice on one date
+	AnomalyOverlap   = "overlap"   // the same member checked into two classes that run at the same time
+)
+
+// Anomaly errors.
+var (
+	ErrDuplicateCheckIn   = errors.New("member is already checked into this class")
+	ErrOverlappingCheckIn = errors.New("member is already checked into a class at the same time")
+	ErrMergeMismatch      = errors.New("only check-ins of the same member on the same date can be merged")
+)
+
+// Slot is the time a class runs, as HH:MM strings.
+type Slot struct {
+	Start string
+	End   string
+}
+
+// Overlaps reports whether two slots share any time. Back-to-back classes do not overlap.
+// INVARIANT: Slot is not mutated
+func (s Slot) Overlaps(other Slot) bool {
+	if s.Start == "" || s.End == "" || other.Start == "" || other.End == "" {
+		return false
+	}
+	return s.Start < other.End && other.Start < s.End
+}
+
+// Anomaly is a set of one member's check-ins on one date that cannot all be right.
+type Anomaly struct {
+	Kind          string
+	MemberID      string
+	Date          string   // YYYY-MM-DD
+	AttendanceIDs []string // earliest check-in first
+}
+
+// Date returns the date the check-in counts for: the class date, or the check-in day when
+// no class date was recorded.
+// PRE: CheckInTime is set
+// POST: Returns a YYYY-MM-DD date
+func (a *Attendance) Date() string {
+	if a.ClassDate != "" {
+		return a.ClassDate
+	}
+	return a.CheckInTime.Format("2006-01-02")
+}
+
+// Conflict checks a new or changed check-in against the member's other check-ins. It is a
+// duplicate when another check-in is for the same class (or both have no class) on the same
+// date, and an overlap when another class on that date runs at the same time.
+// PRE: existing are the member's check-ins; slots maps schedule IDs to their times
+// POST: Returns the conflicting check-in with ErrDuplicateCheckIn or ErrOverlappingCheckIn,
+// or nil when there is no conflict
+func Conflict(existing []Attendance, a Attendance, slots map[string]Slot) (Attendance, error) {
+	date := a.Date()
+	for _, e := range existing {
+		if e.ID == a.ID || e.MemberID != a.MemberID || e.Date() != date {
+			continue
+		}
+		if e.ScheduleID == a.ScheduleID {
+			return e, ErrDuplicateCheckIn
+		}
+	}
+	if a.ScheduleID == "" {
+		return Attendance{}, nil
+	}
+	slot, ok := slots[a.ScheduleID]
+	if !ok {
+		return Attendance{}, nil
+	}
+	for _, e := range existing {
+		if e.ID == a.ID || e.MemberID != a.MemberID || e.Date() != date || e.ScheduleID == "" {
+			continue
+		}
+		if other, ok := slots[e.ScheduleID]; ok && slot.Overlaps(other) {
+			return e, ErrOverlappingCheckIn
+		}
+	}
+	return Attendance{}, nil
+}
+
+// FindAnomalies groups check-ins that are duplicates or overlap, per member and date.
+// Duplicates of one class form one anomaly; each overlapping pair of classes forms another.
+// PRE: slots maps schedule IDs to their times
+// POST: Returns anomalies, most recent date first, then by member
+// INVARIANT: records are not mutated
+func FindAnomalies(records []Attendance, slots map[string]Slot) []Anomaly {
+	sorted := make([]Attendance, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CheckInTime.Before(sorted[j].CheckInTime) })
+
+	type dayKey struct{ memberID, date string }
+	days := map[dayKey][]Attendance{}
+	var order []dayKey
+	for _, r := range sorted {
+		k := dayKey{r.MemberID, r.Date()}
+		if _, ok := days[k]; !ok {
+			order = append(order, k)
+		}
+		days[k] = append(days[k], r)
+	}
+
+	var anomalies []Anomaly
+	for _, k := range order {
+		day := days[k]
+		bySchedule := map[string][]string{}
+		var schedules []string
+		for _, r := range day {
+			if _, ok := bySchedule[r.ScheduleID]; !ok {
+				schedules = append(schedules, r.ScheduleID)
+			}
+			bySchedule[r.ScheduleID] = append(bySchedule[r.ScheduleID], r.ID)
+		}
+		for _, id := range schedules {
+			if ids := bySchedule[id]; len(ids) > 1 {
+				anomalies = append(anomalies, Anomaly{Kind: AnomalyDuplicate, MemberID: k.memberID, Date: k.date, AttendanceIDs: ids})
+			}
+		}
+		for i, a := range schedules {
+			for _, b := range schedules[i+1:] {
+				sa, okA := slots[a]
+				sb, okB := slots[b]
+				if a == "" || b == "" || !okA || !okB || !sa.Overlaps(sb) {
+					continue
+				}
+				anomalies = append(anomalies, Anomaly{Kind: AnomalyOverlap, MemberID: k.memberID, Date: k.date,
+					AttendanceIDs: []string{bySchedule[a][0], bySchedule[b][0]}})
+			}
+		}
+	}
+	sort.SliceStable(anomalies, func(i, j int) bool {
+		if anomalies[i].Date != anomalies[j].Date {
+			return anomalies[i].Date > anomalies[j].Date
+		}
+		return anomalies[i].MemberID < anomalies[j].MemberID
+	})
+	return anomalies
+}
+
+// Merge folds other into keep: the earliest check-in, the latest check-out and the larger
+// mat hours are kept, so merging never loses training time.
+// PRE: keep and other are distinct check-ins
+// POST: Returns the merged check-in with keep's ID and class, or ErrMergeMismatch
+func Merge(keep, other Attendance) (Attendance, error) {
+	if keep.ID == other.ID || keep.MemberID != other.MemberID || keep.Date() != other.Date() {
+		return Attendance{}, ErrMergeMismatch
+	}
+	merged := keep
+	if other.CheckInTime.Before(merged.CheckInTime) {
+		merged.CheckInTime = other.CheckInTime
+	}
+	if other.CheckOutTime.After(merged.CheckOutTime) {
+		merged.CheckOutTime = other.CheckOutTime
+	}
+	if other.MatHours > merged.MatHours {
+		merged.MatHours = other.MatHours
+	}
+	return merged, nil
+}
@@ -0,0 +1,118 @@
+package attendance_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+)
+
+var anomalySlots = map[string]attendance.Slot{
+	"fundamentals": {Start: "18:00", End: "19:00"},
+	"advanced":     {Start: "18:30", End: "19:30"},
+	"open-mat":     {Start: "19:00", End: "20:00"},
+}
+
+func checkIn(id, memberID, scheduleID string, at time.Time) attendance.Attendance {
+	return attendance.Attendance{ID: id, MemberID: memberID, ScheduleID: scheduleID, CheckInTime: at, MatHours: 1}
+}
+
+// TestSlot_Overlaps verifies overlapping, back-to-back and unknown slots.
+func TestSlot_Overlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"overlap", "fundamentals", "advanced", true},
+		{"back to back", "fundamentals", "open-mat", false},
+		{"same class", "advanced", "advanced", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := anomalySlots[tt.a].Overlaps(anomalySlots[tt.b]); got != tt.want {
+				t.Errorf("Overlaps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if (attendance.Slot{}).Overlaps(anomalySlots["advanced"]) {
+		t.Error("an empty slot should not overlap")
+	}
+}
+
+// TestConflict verifies duplicates and overlaps are found on the same date only.
+func TestConflict(t *testing.T) {
+	day := time.Date(2026, 3, 2, 18, 5, 0, 0, time.UTC)
+	existing := []attendance.Attendance{checkIn("a1", "m1", "fundamentals", day)}
+	tests := []struct {
+		name  string
+		next  attendance.Attendance
+		want  error
+		clash string
+	}{
+		{"same class", checkIn("a2", "m1", "fundamentals", day.Add(time.Minute)), attendance.ErrDuplicateCheckIn, "a1"},
+		{"overlapping class", checkIn("a2", "m1", "advanced", day.Add(20*time.Minute)), attendance.ErrOverlappingCheckIn, "a1"},
+		{"next class", checkIn("a2", "m1", "open-mat", day.Add(time.Hour)), nil, ""},
+		{"next day", checkIn("a2", "m1", "fundamentals", day.AddDate(0, 0, 1)), nil, ""},
+		{"other member", checkIn("a2", "m2", "fundamentals", day), nil, ""},
+		{"itself", checkIn("a1", "m1", "fundamentals", day), nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := attendance.Conflict(existing, tt.next, anomalySlots)
+			if !errors.Is(err, tt.want) || got.ID != tt.clash {
+				t.Errorf("Conflict() = %q, %v; want %q, %v", got.ID, err, tt.clash, tt.want)
+			}
+		})
+	}
+}
+
+// TestFindAnomalies verifies duplicates and overlapping pairs are reported per member and date.
+func TestFindAnomalies(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	records := []attendance.Attendance{
+		checkIn("a2", "m1", "fundamentals", monday.Add(2*time.Minute)),
+		checkIn("a1", "m1", "fundamentals", monday),
+		checkIn("a3", "m1", "open-mat", monday.Add(time.Hour)),
+		checkIn("b1", "m2", "fundamentals", tuesday),
+		checkIn("b2", "m2", "advanced", tuesday.Add(30*time.Minute)),
+		checkIn("c1", "m3", "fundamentals", monday),
+	}
+
+	got := attendance.FindAnomalies(records, anomalySlots)
+	if len(got) != 2 {
+		t.Fatalf("got %d anomalies, want 2: %+v", len(got), got)
+	}
+	if got[0].Kind != attendance.AnomalyOverlap || got[0].MemberID != "m2" || got[0].Date != "2026-03-03" {
+		t.Errorf("first = %+v, want m2's overlap on Tuesday", got[0])
+	}
+	if got[1].Kind != attendance.AnomalyDuplicate || len(got[1].AttendanceIDs) != 2 || got[1].AttendanceIDs[0] != "a1" {
+		t.Errorf("second = %+v, want m1's duplicate, earliest first", got[1])
+	}
+}
+
+// TestMerge verifies merging keeps the widest session and rejects other members' check-ins.
+func TestMerge(t *testing.T) {
+	at := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	keep := checkIn("a1", "m1", "fundamentals", at.Add(5*time.Minute))
+	other := checkIn("a2", "m1", "fundamentals", at)
+	other.CheckOutTime = at.Add(90 * time.Minute)
+	other.MatHours = 1.5
+
+	merged, err := attendance.Merge(keep, other)
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if merged.ID != "a1" || !merged.CheckInTime.Equal(at) || !merged.CheckOutTime.Equal(other.CheckOutTime) || merged.MatHours != 1.5 {
+		t.Errorf("merged = %+v", merged)
+	}
+
+	if _, err := attendance.Merge(keep, checkIn("b1", "m2", "fundamentals", at)); !errors.Is(err, attendance.ErrMergeMismatch) {
+		t.Errorf("other member: err = %v, want ErrMergeMismatch", err)
+	}
+	if _, err := attendance.Merge(keep, keep); !errors.Is(err, attendance.ErrMergeMismatch) {
+		t.Errorf("itself: err = %v, want ErrMergeMismatch", err)
+	}
+}
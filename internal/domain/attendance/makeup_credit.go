@@ -0,0 +1,61 @@
+package attendance
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// MaxMakeupReasonLength caps the free-text reason on a makeup credit.
+const MaxMakeupReasonLength = 500
+
+// Makeup credit errors.
+var (
+	ErrMakeupMemberRequired = errors.New("makeup credit must be associated with a member")
+	ErrMakeupTermRequired   = errors.New("makeup credit must be associated with a term")
+	ErrMakeupAwarderNeeded  = errors.New("makeup credit must record who awarded it")
+	ErrMakeupReasonRequired = errors.New("makeup credit needs a reason")
+	ErrMakeupReasonTooLong  = errors.New("makeup credit reason cannot exceed 500 characters")
+	ErrMakeupDateInvalid    = errors.New("missed class date must be YYYY-MM-DD")
+)
+
+// MakeupCredit counts as one attended session towards a member's term attendance.
+// Coaches award it when a member makes up a missed class some other way,
+// e.g. a private lesson or a session at another gym.
+type MakeupCredit struct {
+	ID        string
+	MemberID  string
+	TermID    string
+	ClassDate string // YYYY-MM-DD of the missed class; optional
+	Reason    string
+	AwardedBy string // account ID
+	AwardedAt time.Time
+}
+
+// Validate checks the credit's invariants.
+// PRE: MakeupCredit struct is populated
+// POST: Returns nil if valid, a Makeup error otherwise
+// INVARIANT: MemberID, TermID, AwardedBy and Reason must be non-empty
+func (c *MakeupCredit) Validate() error {
+	if c.MemberID == "" {
+		return ErrMakeupMemberRequired
+	}
+	if c.TermID == "" {
+		return ErrMakeupTermRequired
+	}
+	if c.AwardedBy == "" {
+		return ErrMakeupAwarderNeeded
+	}
+	if strings.TrimSpace(c.Reason) == "" {
+		return ErrMakeupReasonRequired
+	}
+	if len(c.Reason) > MaxMakeupReasonLength {
+		return ErrMakeupReasonTooLong
+	}
+	if c.ClassDate != "" {
+		if _, err := time.Parse("2006-01-02", c.ClassDate); err != nil {
+			return ErrMakeupDateInvalid
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package attendance_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"workshop/internal/domain/attendance"
+)
+
+// TestMakeupCredit_Validate verifies the credit's required fields and limits.
+func TestMakeupCredit_Validate(t *testing.T) {
+	valid := attendance.MakeupCredit{MemberID: "m1", TermID: "t1", AwardedBy: "coach-1", Reason: "Private lesson"}
+	tests := []struct {
+		name   string
+		modify func(c *attendance.MakeupCredit)
+		want   error
+	}{
+		{"valid", func(c *attendance.MakeupCredit) {}, nil},
+		{"valid with missed date", func(c *attendance.MakeupCredit) { c.ClassDate = "2026-03-02" }, nil},
+		{"no member", func(c *attendance.MakeupCredit) { c.MemberID = "" }, attendance.ErrMakeupMemberRequired},
+		{"no term", func(c *attendance.MakeupCredit) { c.TermID = "" }, attendance.ErrMakeupTermRequired},
+		{"no awarder", func(c *attendance.MakeupCredit) { c.AwardedBy = "" }, attendance.ErrMakeupAwarderNeeded},
+		{"blank reason", func(c *attendance.MakeupCredit) { c.Reason = "   " }, attendance.ErrMakeupReasonRequired},
+		{"long reason", func(c *attendance.MakeupCredit) { c.Reason = strings.Repeat("x", attendance.MaxMakeupReasonLength+1) }, attendance.ErrMakeupReasonTooLong},
+		{"bad date", func(c *attendance.MakeupCredit) { c.ClassDate = "02/03/2026" }, attendance.ErrMakeupDateInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.modify(&c)
+			if err := c.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
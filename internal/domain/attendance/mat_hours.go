@@ -0,0 +1,46 @@
+package attendance
+
+import "math"
+
+// Mat hours problems found by CorrectMatHours
+const (
+	MatHoursNegative = "negative" // credited less than nothing
+	MatHoursAbsurd   = "absurd"   // longer than any session could run
+	MatHoursMissing  = "missing"  // nothing credited although the session's length is known
+	MatHoursStale    = "stale"    // never checked out and not the class's duration
+)
+
+// MaxMatHours is the longest session a check-in can be credited with; anything above it is a
+// data error.
+const MaxMatHours = 8.0
+
+// CorrectMatHours works out the hours a check-in should be credited. The class's duration
+// wins, as it does at check-in; a check-in without a class falls back to the time between
+// check-in and checkout when that is plausible, as does one whose class is itself longer
+// than MaxMatHours. Values recorded against a checked-out
+// session are trusted unless negative, absurd or missing.
+// PRE: classHours is the class's duration, or 0 when there is no class or it is unknown
+// POST: Returns the corrected hours and the problem found, or a.MatHours and "" when right
+// INVARIANT: a is not mutated
+func CorrectMatHours(a Attendance, classHours float64) (float64, string) {
+	if classHours > MaxMatHours {
+		classHours = 0
+	}
+	expected := classHours
+	if expected <= 0 && a.IsCheckedOut() {
+		if span := a.CheckOutTime.Sub(a.CheckInTime).Hours(); span <= MaxMatHours {
+			expected = math.Round(span*100) / 100
+		}
+	}
+	switch {
+	case a.MatHours < 0:
+		return expected, MatHoursNegative
+	case a.MatHours > MaxMatHours:
+		return expected, MatHoursAbsurd
+	case a.MatHours == 0 && expected > 0:
+		return expected, MatHoursMissing
+	case !a.IsCheckedOut() && classHours > 0 && math.Abs(a.MatHours-classHours) > 0.01:
+		return classHours, MatHoursStale
+	}
+	return a.MatHours, ""
+}
@@ -0,0 +1,39 @@
+package attendance_test
+
+import (
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+)
+
+// TestCorrectMatHours verifies each problem is caught and corrected to the class's duration,
+// or the checkout span when there is no class, and right values are left alone.
+func TestCorrectMatHours(t *testing.T) {
+	in := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	out := in.Add(90 * time.Minute)
+	tests := []struct {
+		name       string
+		a          attendance.Attendance
+		classHours float64
+		want       float64
+		problem    string
+	}{
+		{"right", attendance.Attendance{CheckInTime: in, MatHours: 1}, 1, 1, ""},
+		{"negative", attendance.Attendance{CheckInTime: in, MatHours: -1}, 1, 1, attendance.MatHoursNegative},
+		{"absurd", attendance.Attendance{CheckInTime: in, MatHours: 23}, 1.5, 1.5, attendance.MatHoursAbsurd},
+		{"missing", attendance.Attendance{CheckInTime: in}, 1, 1, attendance.MatHoursMissing},
+		{"stale without checkout", attendance.Attendance{CheckInTime: in, MatHours: 1}, 2, 2, attendance.MatHoursStale},
+		{"checked out keeps its value", attendance.Attendance{CheckInTime: in, CheckOutTime: out, MatHours: 1.5}, 1, 1.5, ""},
+		{"no class uses checkout span", attendance.Attendance{CheckInTime: in, CheckOutTime: out}, 0, 1.5, attendance.MatHoursMissing},
+		{"no class and a day-long checkout", attendance.Attendance{CheckInTime: in, CheckOutTime: in.Add(20 * time.Hour), MatHours: 20}, 0, 0, attendance.MatHoursAbsurd},
+		{"class longer than any session", attendance.Attendance{CheckInTime: in, MatHours: 1}, 23, 1, ""},
+		{"no class and no checkout", attendance.Attendance{CheckInTime: in}, 0, 0, ""},
+	}
+	for _, tt := range tests {
+		got, problem := attendance.CorrectMatHours(tt.a, tt.classHours)
+		if got != tt.want || problem != tt.problem {
+			t.Errorf("%s: got %v %q, want %v %q", tt.name, got, problem, tt.want, tt.problem)
+		}
+	}
+}
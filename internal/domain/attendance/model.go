@@ -14,6 +14,7 @@ type Attendance struct {
 	ScheduleID   string
 	ClassDate    string  // YYYY-MM-DD format
 	MatHours     float64 // hours credited from session duration
+	LocationID   string  // location where the member checked in; empty if unscoped
 }
 
 // Validate checks if the Attendance has valid data.
@@ -0,0 +1,12 @@
+package attendance
+
+// TopicLink records that an attendance was for a class while a rotor topic was being taught.
+// A class with several rotor themes links one topic per theme.
+type TopicLink struct {
+	AttendanceID string
+	TopicID      string
+	RotorThemeID string
+	RotorID      string
+	MemberID     string // from the attendance record; filled when listing
+	ClassDate    string // from the attendance record; filled when listing (YYYY-MM-DD)
+}
@@ -0,0 +1,46 @@
+package attendance
+
+import "time"
+
+// EstimatedSessionHours is credited for a check-in with no check-out.
+const EstimatedSessionHours = 1.5
+
+// Totals summarises a member's whole attendance history without loading it.
+type Totals struct {
+	Classes       int
+	RecordedHours float64   // from check-ins checked out after they checked in
+	Unrecorded    int       // check-ins without a check-out, credited EstimatedSessionHours each
+	FirstCheckIn  time.Time // zero when Classes is 0
+	LastCheckIn   time.Time // zero when Classes is 0
+}
+
+// EstimatedHours is the time credited to check-ins without a check-out.
+// PRE: none
+// POST: Returns Unrecorded × EstimatedSessionHours
+func (t Totals) EstimatedHours() float64 {
+	return float64(t.Unrecorded) * EstimatedSessionHours
+}
+
+// Summarise totals records the way the attendance store's TotalsByMemberID does in SQL.
+// PRE: none
+// POST: Returns the totals; an empty slice gives zero totals
+func Summarise(records []Attendance) Totals {
+	var t Totals
+	for _, r := range records {
+		t.Classes++
+		if r.IsCheckedOut() {
+			if d := r.CheckOutTime.Sub(r.CheckInTime).Hours(); d > 0 {
+				t.RecordedHours += d
+			}
+		} else {
+			t.Unrecorded++
+		}
+		if t.FirstCheckIn.IsZero() || r.CheckInTime.Before(t.FirstCheckIn) {
+			t.FirstCheckIn = r.CheckInTime
+		}
+		if r.CheckInTime.After(t.LastCheckIn) {
+			t.LastCheckIn = r.CheckInTime
+		}
+	}
+	return t
+}
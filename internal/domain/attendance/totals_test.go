@@ -0,0 +1,28 @@
+package attendance_test
+
+import (
+	"testing"
+	"time"
+
+	"workshop/internal/domain/attendance"
+)
+
+// TestSummarise verifies checked-out sessions count their duration, open ones are estimated,
+// and the first and last check-ins are found whatever the order.
+func TestSummarise(t *testing.T) {
+	at := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	totals := attendance.Summarise([]attendance.Attendance{
+		{ID: "a2", CheckInTime: at.AddDate(0, 0, 7), CheckOutTime: at.AddDate(0, 0, 7).Add(2 * time.Hour)},
+		{ID: "a1", CheckInTime: at},
+		{ID: "a3", CheckInTime: at.AddDate(0, 0, 3), CheckOutTime: at.AddDate(0, 0, 3).Add(30 * time.Minute)},
+	})
+	if totals.Classes != 3 || totals.RecordedHours != 2.5 || totals.Unrecorded != 1 || totals.EstimatedHours() != 1.5 {
+		t.Errorf("totals = %+v", totals)
+	}
+	if !totals.FirstCheckIn.Equal(at) || !totals.LastCheckIn.Equal(at.AddDate(0, 0, 7)) {
+		t.Errorf("first %v, last %v", totals.FirstCheckIn, totals.LastCheckIn)
+	}
+	if empty := attendance.Summarise(nil); empty.Classes != 0 || !empty.LastCheckIn.IsZero() {
+		t.Errorf("empty = %+v", empty)
+	}
+}
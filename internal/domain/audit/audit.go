@@ -29,6 +29,10 @@ const (
 	ActionExport   Action = "export"
 	ActionDownload Action = "download"
 	ActionView     Action = "view"
+
+	// ActionImpersonate marks an admin starting or ending a session as another account,
+	// and every change they make while in it.
+	ActionImpersonate Action = "impersonate"
 )
 
 // Severity represents the severity level of an audit event.
@@ -0,0 +1,146 @@
+package authsession
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// Expiry rules. A session slides forward while it is used but never outlives MaxLifetime.
+const (
+	IdleTimeout   = 24 * time.Hour
+	MaxLifetime   = 30 * 24 * time.Hour
+	TouchInterval = time.Minute // LastSeenAt is only rewritten this often, to avoid a write per request
+)
+
+// ImpersonationLimit is how long an admin may act as another account before
+// their own identity is restored.
+const ImpersonationLimit = time.Hour
+
+// Domain errors
+var (
+	ErrEmptyID        = errors.New("session ID cannot be empty")
+	ErrEmptyTokenHash = errors.New("session token hash cannot be empty")
+	ErrEmptyAccountID = errors.New("session account ID cannot be empty")
+	ErrEmptyRole      = errors.New("session role cannot be empty")
+	ErrNotFound       = errors.New("session not found")
+)
+
+// Session is a signed-in browser. The cookie holds a random token; only its
+// SHA-256 hash is stored, so a leaked database cannot be replayed as cookies.
+// While an admin impersonates another role, Real* hold the admin's identity.
+// Impersonating a specific account also sets ImpersonationExpiresAt.
+type Session struct {
+	ID                     string
+	TokenHash              string
+	AccountID              string
+	Email                  string
+	Role                   string
+	BetaTester             bool
+	PasswordChangeRequired bool
+	LocationID             string
+	Locale                 string // language tag for pages and messages; empty = negotiate from the browser
+	RealAccountID          string
+	RealEmail              string
+	RealRole               string
+	ImpersonationExpiresAt time.Time // zero unless acting as another account
+	CreatedAt              time.Time
+	LastSeenAt             time.Time
+	ExpiresAt              time.Time
+}
+
+// Validate checks if the Session has valid data.
+// PRE: Session struct is populated
+// POST: Returns nil if valid, error otherwise
+func (s *Session) Validate() error {
+	if s.ID == "" {
+		return ErrEmptyID
+	}
+	if s.TokenHash == "" {
+		return ErrEmptyTokenHash
+	}
+	if s.AccountID == "" {
+		return ErrEmptyAccountID
+	}
+	if s.Role == "" {
+		return ErrEmptyRole
+	}
+	return nil
+}
+
+// Start stamps a new session as created and last seen at now.
+// PRE: now is non-zero
+// POST: CreatedAt and LastSeenAt are now; ExpiresAt is one idle timeout away
+func (s *Session) Start(now time.Time) {
+	s.CreatedAt = now
+	s.LastSeenAt = now
+	s.ExpiresAt = s.expiry(now)
+}
+
+// Expired reports whether the session can no longer be used at now.
+// INVARIANT: Session fields are not mutated
+func (s Session) Expired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// Touch records activity at now, sliding ExpiresAt forward.
+// Returns false (and changes nothing) when the last recorded activity is within TouchInterval.
+// PRE: session is not expired at now
+// POST: when true, LastSeenAt is now and ExpiresAt is min(now+IdleTimeout, CreatedAt+MaxLifetime)
+func (s *Session) Touch(now time.Time) bool {
+	if now.Sub(s.LastSeenAt) < TouchInterval {
+		return false
+	}
+	s.LastSeenAt = now
+	s.ExpiresAt = s.expiry(now)
+	return true
+}
+
+// IsImpersonating returns true if an admin is using this session as another role.
+// INVARIANT: Session fields are not mutated
+func (s Session) IsImpersonating() bool {
+	return s.RealRole != ""
+}
+
+// IsImpersonatingAccount returns true if an admin is signed in as another account,
+// not just viewing the app as another role.
+// INVARIANT: Session fields are not mutated
+func (s Session) IsImpersonatingAccount() bool {
+	return s.IsImpersonating() && !s.ImpersonationExpiresAt.IsZero()
+}
+
+// ImpersonationExpired reports whether an account impersonation has run past its limit at now.
+// INVARIANT: Session fields are not mutated
+func (s Session) ImpersonationExpired(now time.Time) bool {
+	return s.IsImpersonatingAccount() && !now.Before(s.ImpersonationExpiresAt)
+}
+
+// EndImpersonation restores the admin's own identity.
+// PRE: IsImpersonating is true
+// POST: AccountID, Email and Role are the admin's; Real* and ImpersonationExpiresAt are cleared
+func (s *Session) EndImpersonation() {
+	s.AccountID = s.RealAccountID
+	s.Email = s.RealEmail
+	s.Role = s.RealRole
+	s.RealAccountID = ""
+	s.RealEmail = ""
+	s.RealRole = ""
+	s.ImpersonationExpiresAt = time.Time{}
+}
+
+// expiry caps the idle deadline at the absolute lifetime.
+func (s Session) expiry(lastSeen time.Time) time.Time {
+	idle := lastSeen.Add(IdleTimeout)
+	if limit := s.CreatedAt.Add(MaxLifetime); idle.After(limit) {
+		return limit
+	}
+	return idle
+}
+
+// HashToken returns the stored form of a session cookie token.
+// INVARIANT: Pure function, no side effects
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
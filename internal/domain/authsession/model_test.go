@@ -0,0 +1,115 @@
+package authsession_test
+
+import (
+	"testing"
+	"time"
+
+	"workshop/internal/domain/authsession"
+)
+
+// TestSession_Validate tests validation of Session.
+func TestSession_Validate(t *testing.T) {
+	valid := authsession.Session{ID: "s1", TokenHash: "h", AccountID: "a1", Role: "member"}
+	tests := []struct {
+		name    string
+		mutate  func(*authsession.Session)
+		wantErr error
+	}{
+		{name: "valid session", mutate: func(*authsession.Session) {}, wantErr: nil},
+		{name: "missing ID", mutate: func(s *authsession.Session) { s.ID = "" }, wantErr: authsession.ErrEmptyID},
+		{name: "missing token hash", mutate: func(s *authsession.Session) { s.TokenHash = "" }, wantErr: authsession.ErrEmptyTokenHash},
+		{name: "missing account", mutate: func(s *authsession.Session) { s.AccountID = "" }, wantErr: authsession.ErrEmptyAccountID},
+		{name: "missing role", mutate: func(s *authsession.Session) { s.Role = "" }, wantErr: authsession.ErrEmptyRole},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid
+			tt.mutate(&s)
+			if err := s.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestSession_SlidingExpiry tests that activity extends a session up to its maximum lifetime.
+func TestSession_SlidingExpiry(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		touchAt     time.Duration // after start
+		wantTouched bool
+		wantExpires time.Duration // after start
+	}{
+		{name: "within touch interval is not written", touchAt: 30 * time.Second, wantTouched: false, wantExpires: authsession.IdleTimeout},
+		{name: "later activity slides expiry", touchAt: 2 * time.Hour, wantTouched: true, wantExpires: 2*time.Hour + authsession.IdleTimeout},
+		{name: "expiry is capped at max lifetime", touchAt: authsession.MaxLifetime - time.Hour, wantTouched: true, wantExpires: authsession.MaxLifetime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s authsession.Session
+			s.Start(start)
+			if got := s.Touch(start.Add(tt.touchAt)); got != tt.wantTouched {
+				t.Errorf("Touch() = %v, want %v", got, tt.wantTouched)
+			}
+			if want := start.Add(tt.wantExpires); !s.ExpiresAt.Equal(want) {
+				t.Errorf("ExpiresAt = %v, want %v", s.ExpiresAt, want)
+			}
+		})
+	}
+}
+
+// TestSession_Expired tests the expiry boundary.
+func TestSession_Expired(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	var s authsession.Session
+	s.Start(start)
+	if s.Expired(start.Add(authsession.IdleTimeout - time.Second)) {
+		t.Error("session expired before its idle timeout")
+	}
+	if !s.Expired(start.Add(authsession.IdleTimeout)) {
+		t.Error("session still valid at its idle timeout")
+	}
+}
+
+// TestSession_ImpersonationExpired tests the account impersonation limit and restoring the admin.
+func TestSession_ImpersonationExpired(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	roleOnly := authsession.Session{AccountID: "admin1", Role: "member", RealAccountID: "admin1", RealEmail: "admin@example.com", RealRole: "admin"}
+	if roleOnly.IsImpersonatingAccount() || roleOnly.ImpersonationExpired(start.Add(48*time.Hour)) {
+		t.Error("role-only DevMode has no time limit")
+	}
+
+	s := authsession.Session{
+		AccountID: "m1", Email: "member@example.com", Role: "member",
+		RealAccountID: "admin1", RealEmail: "admin@example.com", RealRole: "admin",
+		ImpersonationExpiresAt: start.Add(authsession.ImpersonationLimit),
+	}
+	if !s.IsImpersonatingAccount() {
+		t.Fatal("expected an account impersonation")
+	}
+	if s.ImpersonationExpired(start.Add(authsession.ImpersonationLimit - time.Second)) {
+		t.Error("impersonation expired before its limit")
+	}
+	if !s.ImpersonationExpired(start.Add(authsession.ImpersonationLimit)) {
+		t.Error("impersonation still valid at its limit")
+	}
+
+	s.EndImpersonation()
+	if s.AccountID != "admin1" || s.Email != "admin@example.com" || s.Role != "admin" || s.IsImpersonating() || !s.ImpersonationExpiresAt.IsZero() {
+		t.Errorf("after EndImpersonation = %+v, want the admin's own identity", s)
+	}
+}
+
+// TestHashToken tests that tokens hash deterministically and distinctly.
+func TestHashToken(t *testing.T) {
+	if authsession.HashToken("abc") != authsession.HashToken("abc") {
+		t.Error("HashToken is not deterministic")
+	}
+	if authsession.HashToken("abc") == authsession.HashToken("abd") {
+		t.Error("different tokens share a hash")
+	}
+	if authsession.HashToken("abc") == "abc" {
+		t.Error("HashToken returned the token itself")
+	}
+}
@@ -0,0 +1,185 @@
+package availability
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Business rule constants
+const (
+	MaxReasonLength = 200
+	PlanningDays    = 28 // how far ahead the coverage planner looks
+)
+
+// Domain errors
+var (
+	ErrEmptyCoachID    = errors.New("coach is required")
+	ErrInvalidDay      = errors.New("day must be a valid day of the week")
+	ErrInvalidTime     = errors.New("start and end times must be different HH:MM times")
+	ErrInvalidDate     = errors.New("date must be YYYY-MM-DD")
+	ErrPartialTimes    = errors.New("give both a start and an end time, or neither for the whole day")
+	ErrReasonTooLong   = errors.New("reason cannot exceed 200 characters")
+	ErrExceptionInPast = errors.New("exceptions cannot be added for past dates")
+)
+
+var validDays = map[string]bool{
+	"monday": true, "tuesday": true, "wednesday": true, "thursday": true,
+	"friday": true, "saturday": true, "sunday": true,
+}
+
+// Window is a recurring weekly slot in which a coach can take classes.
+type Window struct {
+	ID        string
+	CoachID   string // AccountID
+	Day       string // monday, tuesday, etc.
+	StartTime string // HH:MM
+	EndTime   string // HH:MM
+}
+
+// Validate checks if the Window has valid data.
+// PRE: Window struct is populated
+// POST: Returns nil if valid, error otherwise
+func (w *Window) Validate() error {
+	if w.CoachID == "" {
+		return ErrEmptyCoachID
+	}
+	if !validDays[w.Day] {
+		return ErrInvalidDay
+	}
+	if _, _, ok := minutes(w.StartTime, w.EndTime); !ok || w.StartTime == w.EndTime {
+		return ErrInvalidTime
+	}
+	return nil
+}
+
+// Exception changes a coach's availability on one date: away for the day or part of it,
+// or free outside their usual windows.
+type Exception struct {
+	ID        string
+	CoachID   string // AccountID
+	Date      string // YYYY-MM-DD
+	Available bool   // true adds availability; false takes it away
+	StartTime string // HH:MM; empty with EndTime for the whole day
+	EndTime   string // HH:MM
+	Reason    string // optional, e.g. "Away at a seminar"
+	CreatedAt time.Time
+}
+
+// Validate checks if the Exception has valid data.
+// PRE: Exception struct is populated
+// POST: Returns nil if valid, error otherwise
+func (e *Exception) Validate() error {
+	if e.CoachID == "" {
+		return ErrEmptyCoachID
+	}
+	if _, err := time.Parse("2006-01-02", e.Date); err != nil {
+		return ErrInvalidDate
+	}
+	if (e.StartTime == "") != (e.EndTime == "") {
+		return ErrPartialTimes
+	}
+	if e.StartTime != "" {
+		if _, _, ok := minutes(e.StartTime, e.EndTime); !ok || e.StartTime == e.EndTime {
+			return ErrInvalidTime
+		}
+	}
+	if len(e.Reason) > MaxReasonLength {
+		return ErrReasonTooLong
+	}
+	return nil
+}
+
+// IsAllDay reports whether the exception covers the whole date.
+// INVARIANT: Exception is not mutated
+func (e *Exception) IsAllDay() bool {
+	return e.StartTime == ""
+}
+
+// Calendar is one coach's recurring availability and exceptions.
+type Calendar struct {
+	CoachID    string
+	Windows    []Window
+	Exceptions []Exception
+}
+
+// Declared reports whether the coach has set any recurring availability. A coach who has not
+// is assumed to be able to take their own regular classes, but is never suggested for others.
+// INVARIANT: Calendar is not mutated
+func (c *Calendar) Declared() bool {
+	return len(c.Windows) > 0
+}
+
+// Status says whether a coach can take a class.
+type Status int
+
+// Availability statuses.
+const (
+	Unknown     Status = iota // no recurring availability declared and no exception that day
+	Available                 // inside a window or an "available" exception
+	Unavailable               // outside every window, or during an "unavailable" exception
+)
+
+// StatusAt decides whether the coach can take a class on date from start to end (HH:MM).
+// An "unavailable" exception overlapping the class wins; then an "available" exception or a
+// recurring window covering the whole class; otherwise a coach with declared windows is
+// unavailable and one without is Unknown.
+// PRE: none
+// POST: Returns Unavailable for unreadable class times
+func (c *Calendar) StatusAt(date time.Time, start, end string) Status {
+	cs, ce, ok := minutes(start, end)
+	if !ok {
+		return Unavailable
+	}
+	day := date.Format("2006-01-02")
+	available := false
+	for _, e := range c.Exceptions {
+		if e.Date != day {
+			continue
+		}
+		es, ee := 0, 48*60
+		if !e.IsAllDay() {
+			es, ee, _ = minutes(e.StartTime, e.EndTime)
+		}
+		if !e.Available && es < ce && cs < ee {
+			return Unavailable
+		}
+		if e.Available && es <= cs && ce <= ee {
+			available = true
+		}
+	}
+	if available {
+		return Available
+	}
+	weekday := strings.ToLower(date.Weekday().String())
+	for _, w := range c.Windows {
+		if w.Day != weekday {
+			continue
+		}
+		if ws, we, ok := minutes(w.StartTime, w.EndTime); ok && ws <= cs && ce <= we {
+			return Available
+		}
+	}
+	if c.Declared() {
+		return Unavailable
+	}
+	return Unknown
+}
+
+// minutes returns start and end as minutes after midnight. An end at or before the start
+// runs past midnight, so it is pushed into the next day.
+func minutes(start, end string) (int, int, bool) {
+	st, err := time.Parse("15:04", start)
+	if err != nil {
+		return 0, 0, false
+	}
+	et, err := time.Parse("15:04", end)
+	if err != nil {
+		return 0, 0, false
+	}
+	s, e := st.Hour()*60+st.Minute(), et.Hour()*60+et.Minute()
+	if e <= s {
+		e += 24 * 60
+	}
+	return s, e, true
+}
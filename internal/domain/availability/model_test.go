@@ -0,0 +1,93 @@
+package availability_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/availability"
+)
+
+// TestWindow_Validate tests validation of recurring availability.
+func TestWindow_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		w    availability.Window
+		want error
+	}{
+		{"valid", availability.Window{CoachID: "c1", Day: "monday", StartTime: "17:00", EndTime: "21:00"}, nil},
+		{"no coach", availability.Window{Day: "monday", StartTime: "17:00", EndTime: "21:00"}, availability.ErrEmptyCoachID},
+		{"bad day", availability.Window{CoachID: "c1", Day: "Mon", StartTime: "17:00", EndTime: "21:00"}, availability.ErrInvalidDay},
+		{"bad time", availability.Window{CoachID: "c1", Day: "monday", StartTime: "5pm", EndTime: "21:00"}, availability.ErrInvalidTime},
+		{"empty window", availability.Window{CoachID: "c1", Day: "monday", StartTime: "17:00", EndTime: "17:00"}, availability.ErrInvalidTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.w.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestException_Validate tests validation of one-off availability changes.
+func TestException_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		e    availability.Exception
+		want error
+	}{
+		{"whole day", availability.Exception{CoachID: "c1", Date: "2026-03-09"}, nil},
+		{"part day", availability.Exception{CoachID: "c1", Date: "2026-03-09", Available: true, StartTime: "06:00", EndTime: "08:00"}, nil},
+		{"bad date", availability.Exception{CoachID: "c1", Date: "9 March"}, availability.ErrInvalidDate},
+		{"start only", availability.Exception{CoachID: "c1", Date: "2026-03-09", StartTime: "06:00"}, availability.ErrPartialTimes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestCalendar_StatusAt tests windows, exceptions and coaches who never set availability.
+func TestCalendar_StatusAt(t *testing.T) {
+	monday := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	cal := availability.Calendar{
+		CoachID: "c1",
+		Windows: []availability.Window{{CoachID: "c1", Day: "monday", StartTime: "17:00", EndTime: "21:00"}},
+		Exceptions: []availability.Exception{
+			{CoachID: "c1", Date: "2026-03-16", StartTime: "18:30", EndTime: "19:00"},
+			{CoachID: "c1", Date: "2026-03-10", Available: true, StartTime: "06:00", EndTime: "08:00"},
+		},
+	}
+	tests := []struct {
+		name       string
+		date       time.Time
+		start, end string
+		want       availability.Status
+	}{
+		{"inside window", monday, "18:00", "19:30", availability.Available},
+		{"runs past window", monday, "20:00", "21:30", availability.Unavailable},
+		{"other day", tuesday, "18:00", "19:30", availability.Unavailable},
+		{"away part of the class", monday.AddDate(0, 0, 7), "18:00", "19:30", availability.Unavailable},
+		{"free by exception", tuesday, "06:30", "07:30", availability.Available},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cal.StatusAt(tt.date, tt.start, tt.end); got != tt.want {
+				t.Errorf("StatusAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	undeclared := availability.Calendar{CoachID: "c2", Exceptions: []availability.Exception{{CoachID: "c2", Date: "2026-03-10"}}}
+	if got := undeclared.StatusAt(monday, "18:00", "19:30"); got != availability.Unknown {
+		t.Errorf("undeclared coach = %v, want Unknown", got)
+	}
+	if got := undeclared.StatusAt(tuesday, "18:00", "19:30"); got != availability.Unavailable {
+		t.Errorf("undeclared coach away = %v, want Unavailable", got)
+	}
+}
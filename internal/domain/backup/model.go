@@ -0,0 +1,139 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Trigger constants record why a backup was taken.
+const (
+	TriggerManual     = "manual"      // an admin pressed "Back up now"
+	TriggerScheduled  = "scheduled"   // the background backup worker
+	TriggerPreRestore = "pre_restore" // safety copy taken just before a restore
+)
+
+// Name format: workshop-20260301T090000Z-manual.db
+const (
+	namePrefix   = "workshop-"
+	nameSuffix   = ".db"
+	nameTimeForm = "20060102T150405Z"
+)
+
+// Domain errors.
+var (
+	ErrInvalidName      = errors.New("invalid backup name")
+	ErrInvalidTrigger   = errors.New("invalid backup trigger")
+	ErrNotFound         = errors.New("backup not found")
+	ErrConfirmMismatch  = errors.New("type the backup name exactly to confirm the restore")
+	ErrNewerSchema      = errors.New("backup was taken by a newer version of Workshop")
+	ErrCorruptBackup    = errors.New("backup failed its integrity check")
+	ErrInvalidRetention = errors.New("retention must keep at least one backup")
+)
+
+// Backup describes one stored database snapshot.
+type Backup struct {
+	Name      string
+	Trigger   string
+	CreatedAt time.Time
+	Size      int64 // bytes; 0 if unknown
+}
+
+// ValidTrigger reports whether trigger is one of the Trigger constants.
+func ValidTrigger(trigger string) bool {
+	switch trigger {
+	case TriggerManual, TriggerScheduled, TriggerPreRestore:
+		return true
+	}
+	return false
+}
+
+// NewName returns the object name for a backup taken at t.
+// PRE: trigger is one of the Trigger constants
+// POST: Returns a name that ParseName accepts
+func NewName(t time.Time, trigger string) string {
+	return namePrefix + t.UTC().Format(nameTimeForm) + "-" + trigger + nameSuffix
+}
+
+// ParseName extracts the timestamp and trigger from a backup name.
+// Anything that is not a name produced by NewName (including paths) is rejected,
+// so names from requests are safe to join onto a directory or object key.
+// PRE: none
+// POST: Returns the backup (without Size) or ErrInvalidName
+func ParseName(name string) (Backup, error) {
+	if !strings.HasPrefix(name, namePrefix) || !strings.HasSuffix(name, nameSuffix) {
+		return Backup{}, ErrInvalidName
+	}
+	stem := strings.TrimSuffix(strings.TrimPrefix(name, namePrefix), nameSuffix)
+	stamp, trigger, ok := strings.Cut(stem, "-")
+	if !ok {
+		return Backup{}, ErrInvalidName
+	}
+	if !ValidTrigger(trigger) {
+		return Backup{}, ErrInvalidName
+	}
+	t, err := time.Parse(nameTimeForm, stamp)
+	if err != nil {
+		return Backup{}, ErrInvalidName
+	}
+	return Backup{Name: name, Trigger: trigger, CreatedAt: t}, nil
+}
+
+// Retention decides which backups to keep: the newest KeepLast, plus any younger than KeepDays.
+type Retention struct {
+	KeepLast int
+	KeepDays int
+}
+
+// DefaultRetention matches the deploy script: last 5 backups plus anything under 30 days old.
+var DefaultRetention = Retention{KeepLast: 5, KeepDays: 30}
+
+// Validate checks the retention policy.
+// PRE: none
+// POST: Returns nil if at least one backup is always kept
+// INVARIANT: KeepLast >= 1; KeepDays >= 0
+func (r Retention) Validate() error {
+	if r.KeepLast < 1 || r.KeepDays < 0 {
+		return ErrInvalidRetention
+	}
+	return nil
+}
+
+// Expired returns the backups the policy no longer keeps, oldest first.
+// PRE: r is valid
+// POST: The newest KeepLast backups are never returned
+func (r Retention) Expired(backups []Backup, now time.Time) []Backup {
+	sorted := append([]Backup(nil), backups...)
+	SortNewestFirst(sorted)
+	cutoff := now.AddDate(0, 0, -r.KeepDays)
+
+	var expired []Backup
+	for i := len(sorted) - 1; i >= r.KeepLast; i-- {
+		if sorted[i].CreatedAt.Before(cutoff) {
+			expired = append(expired, sorted[i])
+		}
+	}
+	return expired
+}
+
+// SortNewestFirst orders backups by creation time, newest first.
+// PRE: none
+// POST: backups is sorted in place
+func SortNewestFirst(backups []Backup) {
+	sort.SliceStable(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+}
+
+// CheckRestorable verifies a downloaded backup may replace the live database.
+// PRE: integrity is the result of PRAGMA quick_check; schemaVersion is the backup's schema version
+// POST: Returns nil only for an intact backup no newer than latestSchema
+func CheckRestorable(integrity string, schemaVersion, latestSchema int) error {
+	if integrity != "ok" {
+		return fmt.Errorf("%w: %s", ErrCorruptBackup, integrity)
+	}
+	if schemaVersion > latestSchema {
+		return fmt.Errorf("%w (schema %d, this build supports %d)", ErrNewerSchema, schemaVersion, latestSchema)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package backup_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/backup"
+)
+
+// TestParseName tests round-tripping and rejecting backup names.
+func TestParseName(t *testing.T) {
+	at := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	name := backup.NewName(at, backup.TriggerPreRestore)
+	if name != "workshop-20260301T090000Z-pre_restore.db" {
+		t.Fatalf("NewName = %q", name)
+	}
+	b, err := backup.ParseName(name)
+	if err != nil {
+		t.Fatalf("ParseName(%q): %v", name, err)
+	}
+	if !b.CreatedAt.Equal(at) || b.Trigger != backup.TriggerPreRestore {
+		t.Errorf("ParseName = %+v", b)
+	}
+
+	for _, bad := range []string{
+		"",
+		"workshop.db",
+		"../workshop-20260301T090000Z-manual.db",
+		"workshop-20260301T090000Z-manual.db/../../etc",
+		"workshop-20260301T090000Z-weekly.db",
+		"workshop-2026-03-01-manual.db",
+		"workshop-20260301T090000Z-manual.sql",
+	} {
+		if _, err := backup.ParseName(bad); !errors.Is(err, backup.ErrInvalidName) {
+			t.Errorf("ParseName(%q) err = %v, want ErrInvalidName", bad, err)
+		}
+	}
+}
+
+// TestRetention_Expired tests that the newest KeepLast and recent backups survive.
+func TestRetention_Expired(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	var backups []backup.Backup
+	for _, daysAgo := range []int{0, 1, 40, 41, 42, 60, 90} {
+		at := now.AddDate(0, 0, -daysAgo)
+		backups = append(backups, backup.Backup{Name: backup.NewName(at, backup.TriggerScheduled), CreatedAt: at})
+	}
+
+	tests := []struct {
+		name   string
+		policy backup.Retention
+		want   int
+	}{
+		{name: "default keeps last 5", policy: backup.DefaultRetention, want: 2},
+		{name: "keep last 1 and 30 days", policy: backup.Retention{KeepLast: 1, KeepDays: 30}, want: 5},
+		{name: "keep last 10", policy: backup.Retention{KeepLast: 10, KeepDays: 0}, want: 0},
+		{name: "keep 100 days", policy: backup.Retention{KeepLast: 1, KeepDays: 100}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expired := tt.policy.Expired(backups, now)
+			if len(expired) != tt.want {
+				t.Fatalf("Expired = %d backups, want %d", len(expired), tt.want)
+			}
+			if len(expired) > 0 && !expired[0].CreatedAt.Equal(now.AddDate(0, 0, -90)) {
+				t.Errorf("oldest should be expired first, got %v", expired[0].CreatedAt)
+			}
+		})
+	}
+}
+
+// TestRetention_Validate tests retention policy validation.
+func TestRetention_Validate(t *testing.T) {
+	if err := backup.DefaultRetention.Validate(); err != nil {
+		t.Errorf("DefaultRetention invalid: %v", err)
+	}
+	if err := (backup.Retention{KeepLast: 0, KeepDays: 30}).Validate(); !errors.Is(err, backup.ErrInvalidRetention) {
+		t.Errorf("KeepLast 0 err = %v, want ErrInvalidRetention", err)
+	}
+	if err := (backup.Retention{KeepLast: 1, KeepDays: -1}).Validate(); !errors.Is(err, backup.ErrInvalidRetention) {
+		t.Errorf("KeepDays -1 err = %v, want ErrInvalidRetention", err)
+	}
+}
+
+// TestCheckRestorable tests the integrity and schema guards.
+func TestCheckRestorable(t *testing.T) {
+	if err := backup.CheckRestorable("ok", 30, 30); err != nil {
+		t.Errorf("same schema: %v", err)
+	}
+	if err := backup.CheckRestorable("ok", 12, 30); err != nil {
+		t.Errorf("older schema: %v", err)
+	}
+	if err := backup.CheckRestorable("ok", 31, 30); !errors.Is(err, backup.ErrNewerSchema) {
+		t.Errorf("newer schema err = %v, want ErrNewerSchema", err)
+	}
+	if err := backup.CheckRestorable("*** in database main ***", 30, 30); !errors.Is(err, backup.ErrCorruptBackup) {
+		t.Errorf("corrupt err = %v, want ErrCorruptBackup", err)
+	}
+}
@@ -2,9 +2,39 @@ package bugbox
 
 import (
 	"errors"
+	"strings"
 	"time"
 )
 
+// Triage statuses, in the order a report usually moves through them.
+const (
+	StatusNew          = "new"
+	StatusAcknowledged = "ack"
+	StatusInProgress   = "in_progress"
+	StatusFixed        = "fixed"
+	StatusWontFix      = "wont_fix"
+)
+
+// ValidStatuses contains all valid triage statuses.
+var ValidStatuses = []string{StatusNew, StatusAcknowledged, StatusInProgress, StatusFixed, StatusWontFix}
+
+// Business rule constants
+const (
+	MaxCommentLength = 2000
+)
+
+// Domain errors
+var (
+	ErrInvalidStatus     = errors.New("status must be one of: new, ack, in_progress, fixed, wont_fix")
+	ErrSameStatus        = errors.New("the report already has that status")
+	ErrBackToNew         = errors.New("a triaged report cannot go back to new")
+	ErrSelfDuplicate     = errors.New("a report cannot be a duplicate of itself")
+	ErrEmptySubmissionID = errors.New("submission ID is required")
+	ErrEmptyAuthorID     = errors.New("comment author is required")
+	ErrEmptyComment      = errors.New("comment cannot be empty")
+	ErrCommentTooLong    = errors.New("comment cannot exceed 2000 characters")
+)
+
 // Submission represents a bug report submitted via the Bug Box.
 // PRE: Summary and Description are non-empty; Route is the page path at time of submission.
 // POST: A GitHub issue is created and linked via GitHubIssueNumber/GitHubIssueURL.
@@ -25,11 +55,16 @@ type Submission struct {
 	ScreenshotPath    string
 	GitHubIssueNumber int
 	GitHubIssueURL    string
+	ReporterID        string // AccountID of whoever filed it; told when the status changes
+	Status            string // StatusNew until an admin triages it
+	AssigneeID        string // AccountID of the admin looking into it; empty = unassigned
+	DuplicateOf       string // ID of the report this one repeats; empty = not a duplicate
+	UpdatedAt         time.Time
 }
 
 // Validate checks that the required fields are present.
 // PRE: none
-// POST: returns error if Summary or Description is empty
+// POST: returns error if Summary or Description is empty, or Status is unknown
 func (s *Submission) Validate() error {
 	if s.Summary == "" {
 		return errors.New("summary is required")
@@ -37,5 +72,109 @@ func (s *Submission) Validate() error {
 	if s.Description == "" {
 		return errors.New("description is required")
 	}
+	if s.Status != "" && !IsValidStatus(s.Status) {
+		return ErrInvalidStatus
+	}
+	return nil
+}
+
+// SetStatus moves the report through triage. Any status may follow any other, so a fixed
+// report can be reopened, but nothing goes back to new once triaged.
+// PRE: status is a triage status
+// POST: Status and UpdatedAt are set; returns an error and leaves the report unchanged otherwise
+func (s *Submission) SetStatus(status string, now time.Time) error {
+	if !IsValidStatus(status) {
+		return ErrInvalidStatus
+	}
+	if status == s.Status {
+		return ErrSameStatus
+	}
+	if status == StatusNew {
+		return ErrBackToNew
+	}
+	s.Status = status
+	s.UpdatedAt = now
+	return nil
+}
+
+// MarkDuplicate links the report to the one it repeats and closes it as won't fix.
+// PRE: originalID is the ID of another report
+// POST: DuplicateOf is set and Status is StatusWontFix
+func (s *Submission) MarkDuplicate(originalID string, now time.Time) error {
+	if originalID == "" {
+		return ErrEmptySubmissionID
+	}
+	if originalID == s.ID {
+		return ErrSelfDuplicate
+	}
+	s.DuplicateOf = originalID
+	s.Status = StatusWontFix
+	s.UpdatedAt = now
+	return nil
+}
+
+// IsOpen reports whether the report still needs work.
+// PRE: none
+// POST: Returns false for fixed and won't-fix reports
+func (s *Submission) IsOpen() bool {
+	return s.Status != StatusFixed && s.Status != StatusWontFix
+}
+
+// IsValidStatus reports whether status is a triage status.
+// PRE: none
+// POST: Returns true if status is in ValidStatuses
+func IsValidStatus(status string) bool {
+	for _, v := range ValidStatuses {
+		if v == status {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusLabel returns the wording shown to reporters for a status.
+// PRE: none
+// POST: Returns the status itself when it is unknown
+func StatusLabel(status string) string {
+	switch status {
+	case StatusNew:
+		return "New"
+	case StatusAcknowledged:
+		return "Acknowledged"
+	case StatusInProgress:
+		return "In progress"
+	case StatusFixed:
+		return "Fixed"
+	case StatusWontFix:
+		return "Won't fix"
+	}
+	return status
+}
+
+// Comment is a note on a bug report, visible to admins and the reporter.
+type Comment struct {
+	ID           string
+	SubmissionID string
+	AuthorID     string // AccountID
+	Body         string
+	CreatedAt    time.Time
+}
+
+// Validate checks if the Comment has valid data.
+// PRE: Comment struct is populated
+// POST: Returns nil if valid, error otherwise
+func (c *Comment) Validate() error {
+	if c.SubmissionID == "" {
+		return ErrEmptySubmissionID
+	}
+	if c.AuthorID == "" {
+		return ErrEmptyAuthorID
+	}
+	if strings.TrimSpace(c.Body) == "" {
+		return ErrEmptyComment
+	}
+	if len(c.Body) > MaxCommentLength {
+		return ErrCommentTooLong
+	}
 	return nil
 }
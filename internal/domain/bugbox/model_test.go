@@ -0,0 +1,81 @@
+package bugbox_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/bugbox"
+)
+
+// TestSubmission_SetStatus tests the triage lifecycle.
+func TestSubmission_SetStatus(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want error
+	}{
+		{"acknowledge", bugbox.StatusNew, bugbox.StatusAcknowledged, nil},
+		{"straight to fixed", bugbox.StatusNew, bugbox.StatusFixed, nil},
+		{"reopen", bugbox.StatusFixed, bugbox.StatusInProgress, nil},
+		{"same status", bugbox.StatusAcknowledged, bugbox.StatusAcknowledged, bugbox.ErrSameStatus},
+		{"back to new", bugbox.StatusInProgress, bugbox.StatusNew, bugbox.ErrBackToNew},
+		{"unknown", bugbox.StatusNew, "closed", bugbox.ErrInvalidStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := bugbox.Submission{ID: "b1", Status: tt.from}
+			err := s.SetStatus(tt.to, now)
+			if err != tt.want {
+				t.Fatalf("SetStatus() = %v, want %v", err, tt.want)
+			}
+			if err == nil && (s.Status != tt.to || !s.UpdatedAt.Equal(now)) {
+				t.Errorf("status = %q at %v, want %q at %v", s.Status, s.UpdatedAt, tt.to, now)
+			}
+			if err != nil && s.Status != tt.from {
+				t.Errorf("status changed to %q on error", s.Status)
+			}
+		})
+	}
+}
+
+// TestSubmission_MarkDuplicate tests that duplicates are linked and closed.
+func TestSubmission_MarkDuplicate(t *testing.T) {
+	s := bugbox.Submission{ID: "b2", Status: bugbox.StatusNew}
+	if err := s.MarkDuplicate("b2", time.Now()); err != bugbox.ErrSelfDuplicate {
+		t.Errorf("self duplicate error = %v, want ErrSelfDuplicate", err)
+	}
+	if err := s.MarkDuplicate("b1", time.Now()); err != nil {
+		t.Fatalf("MarkDuplicate() = %v", err)
+	}
+	if s.DuplicateOf != "b1" || s.Status != bugbox.StatusWontFix || s.IsOpen() {
+		t.Errorf("duplicate = %+v, want closed as won't fix and linked to b1", s)
+	}
+}
+
+// TestComment_Validate tests validation of report comments.
+func TestComment_Validate(t *testing.T) {
+	valid := bugbox.Comment{SubmissionID: "b1", AuthorID: "admin-1", Body: "Can you share the page you were on?"}
+	tests := []struct {
+		name   string
+		modify func(c *bugbox.Comment)
+		want   error
+	}{
+		{"valid", func(c *bugbox.Comment) {}, nil},
+		{"no report", func(c *bugbox.Comment) { c.SubmissionID = "" }, bugbox.ErrEmptySubmissionID},
+		{"no author", func(c *bugbox.Comment) { c.AuthorID = "" }, bugbox.ErrEmptyAuthorID},
+		{"blank", func(c *bugbox.Comment) { c.Body = "  " }, bugbox.ErrEmptyComment},
+		{"too long", func(c *bugbox.Comment) { c.Body = strings.Repeat("a", bugbox.MaxCommentLength+1) }, bugbox.ErrCommentTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.modify(&c)
+			if got := c.Validate(); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,21 +2,36 @@ package calendar
 
 import (
 	"errors"
+	"strings"
 	"time"
 )
 
+// Competition interest status constants.
+const (
+	InterestStatusInterested = "interested" // thinking about going
+	InterestStatusRegistered = "registered" // entered with the organiser
+)
+
+// ErrNotCompetition is returned when interest is recorded against a non-competition event.
+var ErrNotCompetition = errors.New("event is not a competition")
+
+// MaxWeightClassLength caps the free-text weight class (e.g. "-76kg", "Middleweight").
+const MaxWeightClassLength = 40
+
 // CompetitionInterest tracks which members are interested in attending a competition.
 type CompetitionInterest struct {
-	ID        string
-	EventID   string
-	MemberID  string
-	CreatedAt time.Time
+	ID          string
+	EventID     string
+	MemberID    string
+	Status      string // interested or registered; empty is treated as interested
+	WeightClass string // as entered with the organiser; optional
+	CreatedAt   time.Time
 }
 
 // Validate checks the interest record's invariants.
 // PRE: ci fields may be empty (validation will catch this).
 // POST: Returns nil if valid, error with descriptive message otherwise.
-// INVARIANT: EventID and MemberID must be non-empty.
+// INVARIANT: EventID and MemberID must be non-empty; Status is interested or registered.
 func (ci *CompetitionInterest) Validate() error {
 	if ci.EventID == "" {
 		return errors.New("event_id is required")
@@ -24,5 +39,18 @@ func (ci *CompetitionInterest) Validate() error {
 	if ci.MemberID == "" {
 		return errors.New("member_id is required")
 	}
+	if ci.Status != "" && ci.Status != InterestStatusInterested && ci.Status != InterestStatusRegistered {
+		return errors.New("status must be 'interested' or 'registered'")
+	}
+	if len(strings.TrimSpace(ci.WeightClass)) > MaxWeightClassLength {
+		return errors.New("weight_class cannot exceed 40 characters")
+	}
 	return nil
 }
+
+// IsRegistered reports whether the member has entered the competition.
+// PRE: none
+// POST: Returns true only for registered status
+func (ci *CompetitionInterest) IsRegistered() bool {
+	return ci.Status == InterestStatusRegistered
+}
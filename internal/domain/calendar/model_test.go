@@ -98,3 +98,27 @@ func searchSubstring(s, sub string) bool {
 	}
 	return false
 }
+
+// TestCompetitionInterest_Validate tests interest status and weight class rules.
+func TestCompetitionInterest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ci      CompetitionInterest
+		wantErr bool
+	}{
+		{"interested", CompetitionInterest{EventID: "e1", MemberID: "m1", Status: InterestStatusInterested}, false},
+		{"registered with weight", CompetitionInterest{EventID: "e1", MemberID: "m1", Status: InterestStatusRegistered, WeightClass: "-76kg"}, false},
+		{"legacy empty status", CompetitionInterest{EventID: "e1", MemberID: "m1"}, false},
+		{"missing event", CompetitionInterest{MemberID: "m1"}, true},
+		{"unknown status", CompetitionInterest{EventID: "e1", MemberID: "m1", Status: "maybe"}, true},
+		{"weight class too long", CompetitionInterest{EventID: "e1", MemberID: "m1", WeightClass: string(make([]byte, MaxWeightClassLength+1))}, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.ci.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
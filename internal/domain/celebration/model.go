@@ -0,0 +1,116 @@
+package celebration
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Celebration kinds
+const (
+	KindBirthday    = "birthday"
+	KindAnniversary = "anniversary" // whole years since the member joined
+	KindClasses     = "classes"     // every ClassesMilestone classes attended
+)
+
+// Business rule constants
+const (
+	ClassesMilestone  = 100  // a member's 100th, 200th, ... class is celebrated
+	EarliestBirthYear = 1900 // dates of birth before this are typos
+	MaxWindowDays     = 31   // longest look-ahead the dashboard asks for
+)
+
+// Domain errors
+var (
+	ErrEmptyMemberID  = errors.New("member ID is required")
+	ErrBirthInFuture  = errors.New("date of birth cannot be in the future")
+	ErrBirthTooEarly  = errors.New("date of birth must be after 1900")
+	ErrJoinedInFuture = errors.New("join date cannot be in the future")
+	ErrJoinedTooEarly = errors.New("join date must be after 1900")
+	ErrInvalidWindow  = errors.New("days must be between 1 and 31")
+)
+
+// Profile holds the dates a member's celebrations come from and whether they want them
+// surfaced at all. A member without a profile is celebrated from their first check-in.
+type Profile struct {
+	MemberID    string
+	DateOfBirth time.Time // zero when not given; only the day and month are ever shown
+	JoinedOn    time.Time // zero falls back to the member's first check-in
+	OptOut      bool      // keeps the member off dashboards, notices and celebration emails
+	UpdatedBy   string    // AccountID of whoever last changed it
+	UpdatedAt   time.Time
+}
+
+// Validate checks if the Profile has valid data.
+// PRE: Profile struct is populated; now is the current time
+// POST: Returns nil if valid, error otherwise
+func (p *Profile) Validate(now time.Time) error {
+	if p.MemberID == "" {
+		return ErrEmptyMemberID
+	}
+	if !p.DateOfBirth.IsZero() {
+		if p.DateOfBirth.After(now) {
+			return ErrBirthInFuture
+		}
+		if p.DateOfBirth.Year() < EarliestBirthYear {
+			return ErrBirthTooEarly
+		}
+	}
+	if !p.JoinedOn.IsZero() {
+		if p.JoinedOn.After(now) {
+			return ErrJoinedInFuture
+		}
+		if p.JoinedOn.Year() < EarliestBirthYear {
+			return ErrJoinedTooEarly
+		}
+	}
+	return nil
+}
+
+// Celebration is one member's birthday, training anniversary or class milestone on a day.
+type Celebration struct {
+	MemberID   string
+	MemberName string
+	Kind       string
+	Date       string // YYYY-MM-DD
+	Years      int    // anniversary: years since joining; 0 otherwise (ages are never surfaced)
+	Classes    int    // classes: the milestone reached; 0 otherwise
+}
+
+// Label describes the celebration, e.g. "Birthday", "2 years training" or "100 classes".
+// INVARIANT: Celebration is not mutated
+func (c Celebration) Label() string {
+	switch c.Kind {
+	case KindBirthday:
+		return "Birthday"
+	case KindAnniversary:
+		if c.Years == 1 {
+			return "1 year training"
+		}
+		return fmt.Sprintf("%d years training", c.Years)
+	case KindClasses:
+		return fmt.Sprintf("%d classes", c.Classes)
+	}
+	return c.Kind
+}
+
+// OccursOn reports whether the yearly date falls on day. A 29 February date is
+// celebrated on 28 February in other years.
+// PRE: date is non-zero
+func OccursOn(date, day time.Time) bool {
+	month, d := date.Month(), date.Day()
+	if month == time.February && d == 29 && !isLeapYear(day.Year()) {
+		d = 28
+	}
+	return day.Month() == month && day.Day() == d
+}
+
+// ClassesReached returns the latest class milestone a member with this many classes has
+// reached, or 0 before their first.
+func ClassesReached(classes int) int {
+	return classes / ClassesMilestone * ClassesMilestone
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
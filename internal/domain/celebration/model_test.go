@@ -0,0 +1,83 @@
+package celebration_test
+
+import (
+	"testing"
+	"time"
+
+	"workshop/internal/domain/celebration"
+)
+
+// day returns local midnight on a date, as the projection walks days.
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// TestProfile_Validate tests validation of celebration profiles.
+func TestProfile_Validate(t *testing.T) {
+	now := day(2026, time.March, 2)
+	tests := []struct {
+		name    string
+		profile celebration.Profile
+		want    error
+	}{
+		{"dates", celebration.Profile{MemberID: "m1", DateOfBirth: day(1990, time.May, 4), JoinedOn: day(2020, time.January, 6)}, nil},
+		{"opt-out only", celebration.Profile{MemberID: "m1", OptOut: true}, nil},
+		{"no member", celebration.Profile{}, celebration.ErrEmptyMemberID},
+		{"born tomorrow", celebration.Profile{MemberID: "m1", DateOfBirth: now.AddDate(0, 0, 1)}, celebration.ErrBirthInFuture},
+		{"born 1890", celebration.Profile{MemberID: "m1", DateOfBirth: day(1890, time.May, 4)}, celebration.ErrBirthTooEarly},
+		{"joins next week", celebration.Profile{MemberID: "m1", JoinedOn: now.AddDate(0, 0, 7)}, celebration.ErrJoinedInFuture},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.profile.Validate(now); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestOccursOn tests that yearly dates match their day, with leap-day birthdays moved to 28 February.
+func TestOccursOn(t *testing.T) {
+	leapling := day(2000, time.February, 29)
+	tests := []struct {
+		name string
+		date time.Time
+		on   time.Time
+		want bool
+	}{
+		{"same day", day(1990, time.May, 4), day(2026, time.May, 4), true},
+		{"day before", day(1990, time.May, 4), day(2026, time.May, 3), false},
+		{"leap day in a leap year", leapling, day(2028, time.February, 29), true},
+		{"leap day moved in other years", leapling, day(2026, time.February, 28), true},
+		{"not moved in a leap year", leapling, day(2028, time.February, 28), false},
+	}
+	for _, tt := range tests {
+		if got := celebration.OccursOn(tt.date, tt.on); got != tt.want {
+			t.Errorf("%s: OccursOn = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestCelebration_Label tests the wording shown on dashboards and notices.
+func TestCelebration_Label(t *testing.T) {
+	tests := []struct {
+		c    celebration.Celebration
+		want string
+	}{
+		{celebration.Celebration{Kind: celebration.KindBirthday}, "Birthday"},
+		{celebration.Celebration{Kind: celebration.KindAnniversary, Years: 1}, "1 year training"},
+		{celebration.Celebration{Kind: celebration.KindAnniversary, Years: 5}, "5 years training"},
+		{celebration.Celebration{Kind: celebration.KindClasses, Classes: 200}, "200 classes"},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Label(); got != tt.want {
+			t.Errorf("Label(%+v) = %q, want %q", tt.c, got, tt.want)
+		}
+	}
+	if got := celebration.ClassesReached(99); got != 0 {
+		t.Errorf("ClassesReached(99) = %d, want 0", got)
+	}
+	if got := celebration.ClassesReached(257); got != 200 {
+		t.Errorf("ClassesReached(257) = %d, want 200", got)
+	}
+}
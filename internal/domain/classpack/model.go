@@ -0,0 +1,245 @@
+package classpack
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Statuses of a class pack
+const (
+	StatusActive    = "active"    // classes left and not expired
+	StatusExhausted = "exhausted" // every class used
+	StatusExpired   = "expired"   // past its expiry with classes unused
+)
+
+// Business rule constants
+const (
+	MaxClasses    = 100 // largest pack that can be sold
+	MaxNoteLength = 500
+	LowRemaining  = 1 // the kiosk warns when this many classes or fewer are left
+)
+
+// Domain errors
+var (
+	ErrEmptyID          = errors.New("class pack ID is required")
+	ErrEmptyMemberID    = errors.New("member ID is required")
+	ErrInvalidClasses   = errors.New("a pack must have between 1 and 100 classes")
+	ErrInvalidUsed      = errors.New("used classes must be between 0 and the pack size")
+	ErrNegativePrice    = errors.New("price cannot be negative")
+	ErrInvalidExpiry    = errors.New("expiry must be YYYY-MM-DD")
+	ErrExpiryBeforeSale = errors.New("expiry must be on or after the purchase date")
+	ErrNoteTooLong      = errors.New("note must be 500 characters or fewer")
+	ErrPackUsedUp       = errors.New("every class on the pack has been used")
+	ErrPackExpired      = errors.New("the class pack has expired")
+	ErrAlreadyUsed      = errors.New("the check-in has already used a class")
+)
+
+// ClassPack is a set of classes a member paid for up front, such as a trial's 3-class pack or
+// a 10-class punch card. Each check-in uses one class until the pack is used up or expires.
+type ClassPack struct {
+	ID          string
+	MemberID    string
+	Classes     int // classes bought
+	Used        int // classes checked in against the pack
+	PriceCents  int // what the member paid for the whole pack
+	PurchasedAt time.Time
+	ExpiresOn   string // YYYY-MM-DD, the last day the pack can be used; empty never expires
+	CreatedBy   string // AccountID of the admin who recorded the sale
+	Note        string
+}
+
+// Validate checks if the ClassPack has valid data.
+// PRE: ClassPack struct is populated
+// POST: Returns nil if valid, error otherwise
+func (p *ClassPack) Validate() error {
+	if p.ID == "" {
+		return ErrEmptyID
+	}
+	if p.MemberID == "" {
+		return ErrEmptyMemberID
+	}
+	if p.Classes < 1 || p.Classes > MaxClasses {
+		return ErrInvalidClasses
+	}
+	if p.Used < 0 || p.Used > p.Classes {
+		return ErrInvalidUsed
+	}
+	if p.PriceCents < 0 {
+		return ErrNegativePrice
+	}
+	if p.ExpiresOn != "" {
+		if _, err := time.Parse("2006-01-02", p.ExpiresOn); err != nil {
+			return ErrInvalidExpiry
+		}
+		if !p.PurchasedAt.IsZero() && p.ExpiresOn < p.PurchasedAt.Format("2006-01-02") {
+			return ErrExpiryBeforeSale
+		}
+	}
+	if len(strings.TrimSpace(p.Note)) > MaxNoteLength {
+		return ErrNoteTooLong
+	}
+	return nil
+}
+
+// Remaining returns the classes not yet used.
+// INVARIANT: ClassPack is not mutated
+func (p ClassPack) Remaining() int {
+	return p.Classes - p.Used
+}
+
+// Expired reports whether the pack's last day has passed.
+// INVARIANT: ClassPack is not mutated
+func (p ClassPack) Expired(now time.Time) bool {
+	return p.ExpiresOn != "" && now.Format("2006-01-02") > p.ExpiresOn
+}
+
+// Status returns active, exhausted or expired. A used-up pack counts as exhausted even after
+// its expiry, since nothing was left to lose.
+// INVARIANT: ClassPack is not mutated
+func (p ClassPack) Status(now time.Time) string {
+	switch {
+	case p.Remaining() <= 0:
+		return StatusExhausted
+	case p.Expired(now):
+		return StatusExpired
+	default:
+		return StatusActive
+	}
+}
+
+// Use takes one class off the pack.
+// PRE: now is the time of the check-in
+// POST: Used is one higher; ErrPackUsedUp or ErrPackExpired when the pack cannot be used
+func (p *ClassPack) Use(now time.Time) error {
+	if p.Remaining() <= 0 {
+		return ErrPackUsedUp
+	}
+	if p.Expired(now) {
+		return ErrPackExpired
+	}
+	p.Used++
+	return nil
+}
+
+// Refund gives back a class whose check-in was undone.
+// PRE: none
+// POST: Used is one lower, never below zero
+func (p *ClassPack) Refund() {
+	if p.Used > 0 {
+		p.Used--
+	}
+}
+
+// UseCents returns the revenue earned by the n-th class used (1-based). The price is spread
+// across the classes so the shares always add up to exactly PriceCents.
+// INVARIANT: ClassPack is not mutated
+func (p ClassPack) UseCents(n int) int {
+	if n < 1 || n > p.Classes {
+		return 0
+	}
+	return p.PriceCents*n/p.Classes - p.PriceCents*(n-1)/p.Classes
+}
+
+// EarnedCents returns the revenue earned by the classes used so far.
+// INVARIANT: ClassPack is not mutated
+func (p ClassPack) EarnedCents() int {
+	if p.Classes <= 0 {
+		return 0
+	}
+	return p.PriceCents * p.Used / p.Classes
+}
+
+// OutstandingCents returns the revenue for classes not yet used: still owed to the member
+// while the pack is active, forfeited once it has expired.
+// INVARIANT: ClassPack is not mutated
+func (p ClassPack) OutstandingCents() int {
+	return p.PriceCents - p.EarnedCents()
+}
+
+// Current picks the pack a check-in should use: the active pack that expires soonest, so
+// classes are not lost to an expiry. Packs that never expire come last, and ties go to the
+// oldest purchase.
+// PRE: packs belong to one member
+// POST: Returns the pack and true, or false when no pack is active
+func Current(packs []ClassPack, now time.Time) (ClassPack, bool) {
+	var best ClassPack
+	found := false
+	for _, p := range packs {
+		if p.Status(now) != StatusActive {
+			continue
+		}
+		if !found || usedBefore(p, best) {
+			best, found = p, true
+		}
+	}
+	return best, found
+}
+
+// usedBefore reports whether a should be used before b.
+func usedBefore(a, b ClassPack) bool {
+	if a.ExpiresOn != b.ExpiresOn {
+		if a.ExpiresOn == "" || b.ExpiresOn == "" {
+			return b.ExpiresOn == ""
+		}
+		return a.ExpiresOn < b.ExpiresOn
+	}
+	return a.PurchasedAt.Before(b.PurchasedAt)
+}
+
+// Balance is what a member has left across their packs, as shown at the kiosk.
+type Balance struct {
+	Status    string // active, exhausted or expired; empty for a member who never bought a pack
+	Remaining int    // classes left on active packs
+	ExpiresOn string // the soonest expiry among active packs; empty when none expire
+}
+
+// Warn reports whether the kiosk should warn the member: their packs are used up or have
+// expired, or they are on their last class.
+// INVARIANT: Balance is not mutated
+func (b Balance) Warn() bool {
+	switch b.Status {
+	case StatusExhausted, StatusExpired:
+		return true
+	case StatusActive:
+		return b.Remaining <= LowRemaining
+	default:
+		return false
+	}
+}
+
+// MemberBalance sums a member's active packs. With none active, the status is that of the
+// most recent purchase, so a member whose pack has just run out is told so.
+// PRE: packs belong to one member
+// POST: Returns an empty Balance when packs is empty
+func MemberBalance(packs []ClassPack, now time.Time) Balance {
+	var b Balance
+	var latest ClassPack
+	for i, p := range packs {
+		if i == 0 || p.PurchasedAt.After(latest.PurchasedAt) {
+			latest = p
+		}
+		if p.Status(now) == StatusActive {
+			b.Status = StatusActive
+			b.Remaining += p.Remaining()
+		}
+	}
+	if b.Status == StatusActive {
+		if cur, ok := Current(packs, now); ok {
+			b.ExpiresOn = cur.ExpiresOn
+		}
+		return b
+	}
+	if len(packs) > 0 {
+		b.Status = latest.Status(now)
+	}
+	return b
+}
+
+// Use records that a check-in took a class off a pack, so undoing the check-in can give it back.
+type Use struct {
+	AttendanceID string
+	PackID       string
+	MemberID     string
+	UsedAt       time.Time
+}
@@ -0,0 +1,153 @@
+package classpack_test
+
+import (
+	"testing"
+	"time"
+
+	"workshop/internal/domain/classpack"
+)
+
+var purchased = time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+
+func validPack() classpack.ClassPack {
+	return classpack.ClassPack{ID: "p1", MemberID: "m1", Classes: 3, PriceCents: 4500, PurchasedAt: purchased, ExpiresOn: "2026-04-01"}
+}
+
+// TestClassPack_Validate tests the pack's invariants.
+func TestClassPack_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *classpack.ClassPack)
+		err    error
+	}{
+		{"valid", func(p *classpack.ClassPack) {}, nil},
+		{"never expires", func(p *classpack.ClassPack) { p.ExpiresOn = "" }, nil},
+		{"no member", func(p *classpack.ClassPack) { p.MemberID = "" }, classpack.ErrEmptyMemberID},
+		{"no classes", func(p *classpack.ClassPack) { p.Classes = 0 }, classpack.ErrInvalidClasses},
+		{"too many classes", func(p *classpack.ClassPack) { p.Classes = 101 }, classpack.ErrInvalidClasses},
+		{"overused", func(p *classpack.ClassPack) { p.Used = 4 }, classpack.ErrInvalidUsed},
+		{"negative price", func(p *classpack.ClassPack) { p.PriceCents = -1 }, classpack.ErrNegativePrice},
+		{"bad expiry", func(p *classpack.ClassPack) { p.ExpiresOn = "01/04/2026" }, classpack.ErrInvalidExpiry},
+		{"expiry before sale", func(p *classpack.ClassPack) { p.ExpiresOn = "2026-03-01" }, classpack.ErrExpiryBeforeSale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := validPack()
+			tt.modify(&p)
+			if err := p.Validate(); err != tt.err {
+				t.Errorf("Validate() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+// TestClassPack_Use tests using classes up to exhaustion and past expiry.
+func TestClassPack_Use(t *testing.T) {
+	p := validPack()
+	for i := 0; i < 3; i++ {
+		if err := p.Use(purchased); err != nil {
+			t.Fatalf("Use() #%d: %v", i+1, err)
+		}
+	}
+	if p.Status(purchased) != classpack.StatusExhausted {
+		t.Errorf("Status() = %q, want exhausted", p.Status(purchased))
+	}
+	if err := p.Use(purchased); err != classpack.ErrPackUsedUp {
+		t.Errorf("Use() on a used-up pack = %v, want ErrPackUsedUp", err)
+	}
+
+	p = validPack()
+	lastDay := time.Date(2026, 4, 1, 20, 0, 0, 0, time.UTC)
+	if err := p.Use(lastDay); err != nil {
+		t.Errorf("Use() on the last day: %v", err)
+	}
+	if err := p.Use(lastDay.AddDate(0, 0, 1)); err != classpack.ErrPackExpired {
+		t.Errorf("Use() after expiry = %v, want ErrPackExpired", err)
+	}
+	if got := p.Status(lastDay.AddDate(0, 0, 1)); got != classpack.StatusExpired {
+		t.Errorf("Status() after expiry = %q, want expired", got)
+	}
+
+	p.Refund()
+	p.Refund()
+	if p.Used != 0 {
+		t.Errorf("Used after refunds = %d, want 0", p.Used)
+	}
+}
+
+// TestClassPack_Revenue tests that class shares add up to the price.
+func TestClassPack_Revenue(t *testing.T) {
+	p := classpack.ClassPack{Classes: 3, PriceCents: 5000}
+	total := 0
+	for n := 1; n <= 3; n++ {
+		total += p.UseCents(n)
+	}
+	if total != 5000 {
+		t.Errorf("UseCents total = %d, want 5000", total)
+	}
+	p.Used = 2
+	if p.EarnedCents() != p.UseCents(1)+p.UseCents(2) {
+		t.Errorf("EarnedCents() = %d, want %d", p.EarnedCents(), p.UseCents(1)+p.UseCents(2))
+	}
+	if p.EarnedCents()+p.OutstandingCents() != 5000 {
+		t.Errorf("earned %d + outstanding %d != 5000", p.EarnedCents(), p.OutstandingCents())
+	}
+}
+
+// TestCurrent tests which pack a check-in uses.
+func TestCurrent(t *testing.T) {
+	never := classpack.ClassPack{ID: "never", Classes: 10, PurchasedAt: purchased}
+	later := classpack.ClassPack{ID: "later", Classes: 3, PurchasedAt: purchased, ExpiresOn: "2026-05-01"}
+	sooner := classpack.ClassPack{ID: "sooner", Classes: 3, PurchasedAt: purchased.AddDate(0, 0, 1), ExpiresOn: "2026-04-01"}
+	usedUp := classpack.ClassPack{ID: "used", Classes: 3, Used: 3, PurchasedAt: purchased, ExpiresOn: "2026-03-10"}
+
+	if got, _ := classpack.Current([]classpack.ClassPack{never, later, sooner, usedUp}, purchased); got.ID != "sooner" {
+		t.Errorf("Current() = %q, want sooner", got.ID)
+	}
+	if got, _ := classpack.Current([]classpack.ClassPack{never, later}, purchased); got.ID != "later" {
+		t.Errorf("Current() = %q, want later", got.ID)
+	}
+	if _, ok := classpack.Current([]classpack.ClassPack{usedUp}, purchased); ok {
+		t.Error("Current() found a used-up pack")
+	}
+}
+
+// TestMemberBalance tests the kiosk's view of a member's packs.
+func TestMemberBalance(t *testing.T) {
+	p := validPack()
+	tests := []struct {
+		name  string
+		packs func() []classpack.ClassPack
+		now   time.Time
+		want  classpack.Balance
+		warn  bool
+	}{
+		{"no packs", func() []classpack.ClassPack { return nil }, purchased, classpack.Balance{}, false},
+		{"plenty left", func() []classpack.ClassPack { return []classpack.ClassPack{p} }, purchased,
+			classpack.Balance{Status: classpack.StatusActive, Remaining: 3, ExpiresOn: "2026-04-01"}, false},
+		{"last class", func() []classpack.ClassPack { q := p; q.Used = 2; return []classpack.ClassPack{q} }, purchased,
+			classpack.Balance{Status: classpack.StatusActive, Remaining: 1, ExpiresOn: "2026-04-01"}, true},
+		{"used up", func() []classpack.ClassPack { q := p; q.Used = 3; return []classpack.ClassPack{q} }, purchased,
+			classpack.Balance{Status: classpack.StatusExhausted}, true},
+		{"expired", func() []classpack.ClassPack { return []classpack.ClassPack{p} }, purchased.AddDate(0, 2, 0),
+			classpack.Balance{Status: classpack.StatusExpired}, true},
+		{"renewed", func() []classpack.ClassPack {
+			old := p
+			old.Used = 3
+			renewed := p
+			renewed.ID, renewed.PurchasedAt = "p2", purchased.AddDate(0, 0, 7)
+			return []classpack.ClassPack{old, renewed}
+		}, purchased.AddDate(0, 0, 7), classpack.Balance{Status: classpack.StatusActive, Remaining: 3, ExpiresOn: "2026-04-01"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classpack.MemberBalance(tt.packs(), tt.now)
+			if got != tt.want {
+				t.Errorf("MemberBalance() = %+v, want %+v", got, tt.want)
+			}
+			if got.Warn() != tt.warn {
+				t.Errorf("Warn() = %v, want %v", got.Warn(), tt.warn)
+			}
+		})
+	}
+}
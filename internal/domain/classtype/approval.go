@@ -0,0 +1,39 @@
+package classtype
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Approval errors
+var (
+	ErrEmptyClassTypeID = errors.New("class type ID cannot be empty")
+	ErrEmptyMemberID    = errors.New("member ID cannot be empty")
+	ErrEmptyApprovedBy  = errors.New("approving coach cannot be empty")
+)
+
+// Approval is a coach's standing permission for one member to attend a class type whose
+// prerequisites they do not otherwise meet. It lasts until a coach withdraws it.
+type Approval struct {
+	ClassTypeID string
+	MemberID    string
+	ApprovedBy  string // account ID of the coach or admin
+	ApprovedAt  time.Time
+}
+
+// Validate checks the approval names a class type, a member and who approved it.
+// PRE: Approval struct is populated
+// POST: Returns nil if valid, error otherwise
+func (a *Approval) Validate() error {
+	if strings.TrimSpace(a.ClassTypeID) == "" {
+		return ErrEmptyClassTypeID
+	}
+	if strings.TrimSpace(a.MemberID) == "" {
+		return ErrEmptyMemberID
+	}
+	if strings.TrimSpace(a.ApprovedBy) == "" {
+		return ErrEmptyApprovedBy
+	}
+	return nil
+}
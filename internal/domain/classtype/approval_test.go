@@ -0,0 +1,30 @@
+package classtype_test
+
+import (
+	"testing"
+	"time"
+
+	"workshop/internal/domain/classtype"
+)
+
+// TestApproval_Validate tests validation of Approval.
+func TestApproval_Validate(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		a       classtype.Approval
+		wantErr bool
+	}{
+		{"valid", classtype.Approval{ClassTypeID: "ct-1", MemberID: "m-1", ApprovedBy: "coach-1", ApprovedAt: now}, false},
+		{"no class type", classtype.Approval{MemberID: "m-1", ApprovedBy: "coach-1"}, true},
+		{"no member", classtype.Approval{ClassTypeID: "ct-1", ApprovedBy: "coach-1"}, true},
+		{"no approver", classtype.Approval{ClassTypeID: "ct-1", MemberID: "m-1"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.a.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Approval.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -8,9 +8,14 @@ import (
 
 // Domain errors
 var (
-	ErrEmptyName      = errors.New("class type name cannot be empty")
-	ErrEmptyProgramID = errors.New("program ID cannot be empty")
-	ErrInvalidAttire  = errors.New("attire must be 'gi', 'nogi', or 'both'")
+	ErrEmptyName       = errors.New("class type name cannot be empty")
+	ErrEmptyProgramID  = errors.New("program ID cannot be empty")
+	ErrInvalidAttire   = errors.New("attire must be 'gi', 'nogi', or 'both'")
+	ErrInvalidCapacity = fmt.Errorf("mat capacity must be between 0 and %d", MaxMatCapacity)
+
+	// Check-in eligibility errors; the message is shown to the member at the kiosk.
+	ErrBeltRequired     = errors.New("this class needs a higher belt — ask a coach")
+	ErrApprovalRequired = errors.New("this class needs a coach's approval — ask a coach")
 )
 
 // Attire constants.
@@ -25,6 +30,7 @@ const (
 	MaxNameLength        = 200
 	MaxLevelLength       = 100
 	MaxDescriptionLength = 2000
+	MaxMatCapacity       = 500
 )
 
 // ClassType represents a specific class within a program (e.g. Fundamentals, No-Gi, Competition).
@@ -37,6 +43,48 @@ type ClassType struct {
 	Description string // optional, markdown/plain text
 	Attire      string // "gi", "nogi", or "both" (optional)
 	Level       string // optional free-form label (e.g. Beginner, All-levels)
+
+	LocationID string // empty = shared by all locations
+
+	// MatCapacity is the most people allowed on the mat at once, e.g. for insurance.
+	// Staff are alerted when a class's headcount goes over it; 0 = no limit.
+	MatCapacity int
+
+	// Prerequisites checked at check-in. A coach's standing approval satisfies both.
+	MinBelt          string // optional: members below this belt need approval to attend
+	RequiresApproval bool   // only members a coach has approved may attend
+}
+
+// HasPrerequisites reports whether attending needs a minimum belt or a coach's approval.
+// PRE: none
+// POST: Returns false when anyone may attend
+func (c ClassType) HasPrerequisites() bool {
+	return c.MinBelt != "" || c.RequiresApproval
+}
+
+// CheckEligibility decides whether a member may attend. beltReached reports whether the
+// member's belt is at or above MinBelt (the caller owns the belt progression); approved
+// reports whether a coach has approved them for this class type.
+// PRE: none
+// POST: Returns nil when eligible, ErrApprovalRequired or ErrBeltRequired otherwise
+func (c ClassType) CheckEligibility(beltReached, approved bool) error {
+	if approved {
+		return nil
+	}
+	if c.RequiresApproval {
+		return ErrApprovalRequired
+	}
+	if c.MinBelt != "" && !beltReached {
+		return ErrBeltRequired
+	}
+	return nil
+}
+
+// IsOverCapacity reports whether headcount exceeds the class type's mat capacity.
+// PRE: none
+// POST: Always false when no capacity is set
+func (c ClassType) IsOverCapacity(headcount int) bool {
+	return c.MatCapacity > 0 && headcount > c.MatCapacity
 }
 
 // Validate checks if the ClassType has valid data.
@@ -61,5 +109,8 @@ func (c *ClassType) Validate() error {
 	if strings.TrimSpace(c.Attire) != "" && c.Attire != AttireGi && c.Attire != AttireNoGi && c.Attire != AttireBoth {
 		return ErrInvalidAttire
 	}
+	if c.MatCapacity < 0 || c.MatCapacity > MaxMatCapacity {
+		return ErrInvalidCapacity
+	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package classtype_test
 
 import (
+	"errors"
 	"testing"
 
 	"workshop/internal/domain/classtype"
@@ -33,6 +34,11 @@ func TestClassType_Validate(t *testing.T) {
 			ct:      classtype.ClassType{ID: "4", ProgramID: "", Name: "No-Gi"},
 			wantErr: true,
 		},
+		{
+			name:    "negative mat capacity",
+			ct:      classtype.ClassType{ID: "5", ProgramID: "prog-1", Name: "Open Mat", MatCapacity: -1},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -44,3 +50,43 @@ func TestClassType_Validate(t *testing.T) {
 		})
 	}
 }
+
+// TestClassType_IsOverCapacity verifies only a set capacity can be exceeded.
+func TestClassType_IsOverCapacity(t *testing.T) {
+	ct := classtype.ClassType{MatCapacity: 20}
+	if ct.IsOverCapacity(20) || !ct.IsOverCapacity(21) {
+		t.Error("expected capacity 20 to be exceeded by 21, not 20")
+	}
+	if (classtype.ClassType{}).IsOverCapacity(1000) {
+		t.Error("no capacity should never be exceeded")
+	}
+}
+
+// TestClassType_CheckEligibility verifies approval overrides both prerequisites and the
+// belt is only checked when a minimum is set.
+func TestClassType_CheckEligibility(t *testing.T) {
+	tests := []struct {
+		name        string
+		ct          classtype.ClassType
+		beltReached bool
+		approved    bool
+		want        error
+	}{
+		{"no prerequisites", classtype.ClassType{}, false, false, nil},
+		{"belt reached", classtype.ClassType{MinBelt: "blue"}, true, false, nil},
+		{"belt not reached", classtype.ClassType{MinBelt: "blue"}, false, false, classtype.ErrBeltRequired},
+		{"belt waived by approval", classtype.ClassType{MinBelt: "blue"}, false, true, nil},
+		{"approval missing", classtype.ClassType{RequiresApproval: true}, true, false, classtype.ErrApprovalRequired},
+		{"approval given", classtype.ClassType{MinBelt: "blue", RequiresApproval: true}, false, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.ct.CheckEligibility(tt.beltReached, tt.approved); !errors.Is(err, tt.want) {
+				t.Errorf("CheckEligibility() = %v, want %v", err, tt.want)
+			}
+			if tt.ct.HasPrerequisites() != (tt.ct.MinBelt != "" || tt.ct.RequiresApproval) {
+				t.Errorf("HasPrerequisites() = %v", tt.ct.HasPrerequisites())
+			}
+		})
+	}
+}
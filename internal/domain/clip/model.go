@@ -28,6 +28,7 @@ type Clip struct {
 	CreatedBy    string // account ID of the creator
 	Promoted     bool   // true if promoted to the main library by coach/admin
 	PromotedBy   string // account ID who promoted it
+	MinBelt      string // optional: members below this belt see a locked placeholder
 	CreatedAt    time.Time
 }
 
@@ -8,10 +8,26 @@ import (
 // MaxTagLength is the maximum length for a tag name.
 const MaxTagLength = 50
 
+// Tag categories form the library taxonomy. General tags are free-form labels outside it.
+const (
+	CategoryGeneral   = "general"
+	CategoryPosition  = "position"  // e.g. half guard, mount
+	CategoryTechnique = "technique" // e.g. sweep, submission
+	CategoryAttire    = "attire"    // gi or nogi
+)
+
+// Categories lists the tag categories in library display order.
+var Categories = []string{CategoryPosition, CategoryTechnique, CategoryAttire, CategoryGeneral}
+
+// ErrInvalidTagCategory is returned when a tag's category is not in the taxonomy.
+var ErrInvalidTagCategory = errors.New("tag category must be position, technique, attire or general")
+
 // Tag represents a label that can be applied to clips for organization and search.
+// INVARIANT: Category is one of Categories.
 type Tag struct {
 	ID        string
 	Name      string
+	Category  string
 	CreatedBy string
 	CreatedAt time.Time
 }
@@ -26,9 +42,24 @@ func (t *Tag) Validate() error {
 	if len(t.Name) > MaxTagLength {
 		return errors.New("tag name cannot exceed 50 characters")
 	}
+	if !IsValidCategory(t.Category) {
+		return ErrInvalidTagCategory
+	}
 	return nil
 }
 
+// IsValidCategory reports whether category is part of the tag taxonomy.
+// PRE: none
+// POST: returns true for any value in Categories
+func IsValidCategory(category string) bool {
+	for _, c := range Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
 // ClipTag represents the many-to-many relationship between clips and tags.
 type ClipTag struct {
 	ClipID    string
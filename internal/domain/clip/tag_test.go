@@ -0,0 +1,41 @@
+package clip
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestTag_Validate covers name and taxonomy category rules.
+func TestTag_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     Tag
+		wantErr bool
+	}{
+		{"position tag", Tag{Name: "Half Guard", Category: CategoryPosition}, false},
+		{"technique tag", Tag{Name: "Sweep", Category: CategoryTechnique}, false},
+		{"attire tag", Tag{Name: "Nogi", Category: CategoryAttire}, false},
+		{"general tag", Tag{Name: "Competition", Category: CategoryGeneral}, false},
+		{"empty name", Tag{Category: CategoryGeneral}, true},
+		{"long name", Tag{Name: strings.Repeat("a", MaxTagLength+1), Category: CategoryGeneral}, true},
+		{"empty category", Tag{Name: "Mount"}, true},
+		{"unknown category", Tag{Name: "Mount", Category: "belt"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tag.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestTag_Validate_CategoryError verifies the category sentinel error is returned.
+func TestTag_Validate_CategoryError(t *testing.T) {
+	tag := Tag{Name: "Mount", Category: "belt"}
+	if err := tag.Validate(); !errors.Is(err, ErrInvalidTagCategory) {
+		t.Errorf("got %v, want ErrInvalidTagCategory", err)
+	}
+}
@@ -0,0 +1,123 @@
+package contactcheck
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Resolutions of a contact check
+const (
+	ResolutionConfirmed = "confirmed" // the member says the address on file is right
+	ResolutionCorrected = "corrected" // the member's record now has the address they gave
+	ResolutionProposed  = "proposed"  // the member has an account: the address they gave waits for it to change
+)
+
+// Business rule constants
+const (
+	MaxAddressLength  = 254 // longest address typed at the kiosk
+	MaxRequestMembers = 500 // members asked in one request
+)
+
+// Domain errors
+var (
+	ErrEmptyMemberID   = errors.New("member ID is required")
+	ErrEmptyAddress    = errors.New("address is required")
+	ErrInvalidAddress  = errors.New("enter a valid email address")
+	ErrAlreadyResolved = errors.New("the member has already confirmed their email")
+	ErrNoMembers       = errors.New("choose at least one member")
+	ErrTooManyMembers  = errors.New("at most 500 members can be asked at once")
+)
+
+// Check asks a member to confirm their email address on the kiosk screen at their next
+// check-in, after mail to it bounced or failed. A member has at most one check; asking again
+// replaces it.
+type Check struct {
+	MemberID    string
+	Address     string // the address on file when the check was requested
+	RequestedBy string // AccountID of the admin who asked
+	RequestedAt time.Time
+	Resolution  string // empty while open
+	NewAddress  string // corrected or proposed: the address the member gave
+	ResolvedAt  time.Time
+}
+
+// Validate checks if the Check has valid data.
+// PRE: Check struct is populated
+// POST: Returns nil if valid, error otherwise
+func (c *Check) Validate() error {
+	if c.MemberID == "" {
+		return ErrEmptyMemberID
+	}
+	if c.Address == "" {
+		return ErrEmptyAddress
+	}
+	return nil
+}
+
+// Open reports whether the member has yet to answer the check.
+// INVARIANT: Check is not mutated
+func (c Check) Open() bool {
+	return c.Resolution == ""
+}
+
+// Resolve records the member's answer. An empty address, or the address on file, confirms
+// it; anything else is a correction, applied straight away unless hasAccount, when it is only
+// proposed because the address is also the member's sign-in.
+// PRE: now is the current time
+// POST: The check is resolved; ErrAlreadyResolved or ErrInvalidAddress otherwise
+func (c *Check) Resolve(address string, hasAccount bool, now time.Time) error {
+	if !c.Open() {
+		return ErrAlreadyResolved
+	}
+	address = NormalizeAddress(address)
+	switch {
+	case address == "" || address == NormalizeAddress(c.Address):
+		c.Resolution = ResolutionConfirmed
+	case !ValidAddress(address):
+		return ErrInvalidAddress
+	case hasAccount:
+		c.Resolution, c.NewAddress = ResolutionProposed, address
+	default:
+		c.Resolution, c.NewAddress = ResolutionCorrected, address
+	}
+	c.ResolvedAt = now
+	return nil
+}
+
+// NormalizeAddress lower-cases and trims an email address.
+// PRE: none
+// POST: Returns the normalized address
+func NormalizeAddress(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// ValidAddress reports whether a typed address looks deliverable: one @ with text on both
+// sides, a dot in the domain and no spaces.
+func ValidAddress(address string) bool {
+	if len(address) > MaxAddressLength || strings.ContainsAny(address, " \t\r\n") {
+		return false
+	}
+	local, domain, ok := strings.Cut(address, "@")
+	if !ok || local == "" || strings.Contains(domain, "@") {
+		return false
+	}
+	dot := strings.LastIndex(domain, ".")
+	return dot > 0 && dot < len(domain)-1
+}
+
+// MaskAddress hides most of an address for the shared kiosk screen, keeping the first two
+// characters of the name and the whole domain: "jo•••@example.com".
+func MaskAddress(address string) string {
+	local, domain, ok := strings.Cut(address, "@")
+	if !ok {
+		return "•••"
+	}
+	runes := []rune(local)
+	if len(runes) > 2 {
+		runes = runes[:2]
+	} else if len(runes) > 1 {
+		runes = runes[:1]
+	}
+	return string(runes) + "•••@" + domain
+}
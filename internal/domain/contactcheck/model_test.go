@@ -0,0 +1,73 @@
+package contactcheck_test
+
+import (
+	"testing"
+	"time"
+
+	"workshop/internal/domain/contactcheck"
+)
+
+// TestCheck_Resolve tests confirming, correcting and proposing an address.
+func TestCheck_Resolve(t *testing.T) {
+	now := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		address    string
+		hasAccount bool
+		want       string
+		newAddress string
+		err        error
+	}{
+		{"blank confirms", "", false, contactcheck.ResolutionConfirmed, "", nil},
+		{"same address confirms", " Jo@Example.com ", false, contactcheck.ResolutionConfirmed, "", nil},
+		{"new address corrects", "jo@example.org", false, contactcheck.ResolutionCorrected, "jo@example.org", nil},
+		{"account proposes", "Jo@Example.org", true, contactcheck.ResolutionProposed, "jo@example.org", nil},
+		{"invalid", "jo@example", false, "", "", contactcheck.ErrInvalidAddress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := contactcheck.Check{MemberID: "m1", Address: "jo@example.com"}
+			err := c.Resolve(tt.address, tt.hasAccount, now)
+			if err != tt.err {
+				t.Fatalf("Resolve() error = %v, want %v", err, tt.err)
+			}
+			if c.Resolution != tt.want || c.NewAddress != tt.newAddress {
+				t.Errorf("Resolve() = %q %q, want %q %q", c.Resolution, c.NewAddress, tt.want, tt.newAddress)
+			}
+			if err == nil && c.Resolve("", false, now) != contactcheck.ErrAlreadyResolved {
+				t.Error("expected a second answer to be refused")
+			}
+		})
+	}
+}
+
+// TestValidAddress tests the shape check on typed addresses.
+func TestValidAddress(t *testing.T) {
+	for address, want := range map[string]bool{
+		"jo@example.com":  true,
+		"jo@mail.co.nz":   true,
+		"jo@example":      false,
+		"@example.com":    false,
+		"jo@@example.com": false,
+		"jo @example.com": false,
+		"jo@example.":     false,
+	} {
+		if got := contactcheck.ValidAddress(address); got != want {
+			t.Errorf("ValidAddress(%q) = %v, want %v", address, got, want)
+		}
+	}
+}
+
+// TestMaskAddress tests that the kiosk shows only the start of the name.
+func TestMaskAddress(t *testing.T) {
+	for address, want := range map[string]string{
+		"jordan@example.com": "jo•••@example.com",
+		"jo@example.com":     "j•••@example.com",
+		"j@example.com":      "j•••@example.com",
+		"not-an-address":     "•••",
+	} {
+		if got := contactcheck.MaskAddress(address); got != want {
+			t.Errorf("MaskAddress(%q) = %q, want %q", address, got, want)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// Built-in library template keys. Each is sent automatically by the system; admins can
+// reword them, and removing the saved wording restores the built-in text.
+const (
+	TemplateWelcome               = "welcome"                // sent when a member activates their account
+	TemplateInactiveFollowUp      = "inactive_follow_up"     // sent once to a member who stops training
+	TemplateGradingCongratulation = "grading_congratulation" // sent when a promotion is approved
+	TemplateWeeklyDigest          = "weekly_digest"          // sent each week to members who opted in to the digest
+	TemplateCeremonyInvite        = "ceremony_invite"        // sent when a member's promotion is booked onto a ceremony
+	TemplateReferralThanks        = "referral_thanks"        // sent to a referrer when the person they referred joins
+	TemplateReferralCredit        = "referral_credit"        // sent to a referrer with the credit they earned for a referral
+	TemplateBirthday              = "birthday"               // sent on a member's birthday when celebration emails are on
+	TemplateTrainingMilestone     = "training_milestone"     // sent on a training anniversary or class milestone when celebration emails are on
+)
+
+// Merge variables, written {{Name}} in a template's subject or body.
+const (
+	VarMemberName     = "MemberName"
+	VarBelt           = "Belt"
+	VarNextClassDate  = "NextClassDate"
+	VarWeekClasses    = "WeekClasses"    // classes attended in the digest's week
+	VarWeekHours      = "WeekHours"      // mat hours in the digest's week
+	VarStreak         = "Streak"         // consecutive weeks trained, ending with the digest's week
+	VarBeltProgress   = "BeltProgress"   // progress toward the next belt, as a sentence
+	VarUpcomingTopics = "UpcomingTopics" // what the member's classes are working on next
+	VarCeremony       = "Ceremony"       // when and where the member's promotion ceremony is
+	VarReferredName   = "ReferredName"   // the person the member referred
+	VarCredit         = "Credit"         // referral credit earned, e.g. "$20"
+	VarMilestone      = "Milestone"      // the training milestone reached, e.g. "1 year of training"
+)
+
+// Variables lists every merge variable in display order.
+var Variables = []string{VarMemberName, VarBelt, VarNextClassDate, VarWeekClasses, VarWeekHours, VarStreak, VarBeltProgress, VarUpcomingTopics, VarCeremony, VarReferredName, VarCredit, VarMilestone}
+
+// SystemSenderID is the SenderID of emails the system sends on its own.
+const SystemSenderID = "system"
+
+// MaxTemplateNameLength caps a library template's display name.
+const MaxTemplateNameLength = 100
+
+// Library errors
+var (
+	ErrInvalidTemplateKey = errors.New("template key must be 1-50 lowercase letters, digits or underscores")
+	ErrEmptyTemplateName  = errors.New("template name is required")
+	ErrTemplateNameLength = errors.New("template name cannot exceed 100 characters")
+	ErrUnknownVariable    = errors.New("unknown merge variable")
+	ErrTemplateNotFound   = errors.New("email template not found")
+)
+
+var (
+	templateKeyPattern = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+	variablePattern    = regexp.MustCompile(`\{\{\s*([A-Za-z]+)\s*\}\}`)
+)
+
+// MessageTemplate is a named, reusable email in the template library. Its subject and
+// body may use merge variables; the body is HTML and goes out inside the active
+// header/footer EmailTemplate.
+type MessageTemplate struct {
+	ID        string
+	Key       string // stable identifier, e.g. "welcome"
+	Name      string
+	Category  string // announcements, grading, billing or account
+	Subject   string
+	Body      string
+	Default   bool // the category's starting point for a new email; at most one per category
+	BuiltIn   bool // one of the system's templates; derived from Key, not stored
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// Vars holds merge variable values by name (VarMemberName etc.).
+type Vars map[string]string
+
+// Validate checks if the MessageTemplate has valid data.
+// PRE: MessageTemplate struct is populated
+// POST: Returns nil if valid, error otherwise; an unknown variable is named in the error
+func (t *MessageTemplate) Validate() error {
+	if !templateKeyPattern.MatchString(t.Key) {
+		return ErrInvalidTemplateKey
+	}
+	if t.Name == "" {
+		return ErrEmptyTemplateName
+	}
+	if len(t.Name) > MaxTemplateNameLength {
+		return ErrTemplateNameLength
+	}
+	if !IsCategory(t.Category) {
+		return ErrInvalidCategory
+	}
+	if t.Subject == "" {
+		return ErrEmptySubject
+	}
+	if len(t.Subject) > MaxSubjectLength {
+		return errors.New("email subject cannot exceed 200 characters")
+	}
+	if t.Body == "" {
+		return ErrEmptyBody
+	}
+	if len(t.Body) > MaxBodyLength {
+		return errors.New("email body cannot exceed 50000 characters")
+	}
+	for _, text := range []string{t.Subject, t.Body} {
+		for _, m := range variablePattern.FindAllStringSubmatch(text, -1) {
+			if !isVariable(m[1]) {
+				return fmt.Errorf("%w: {{%s}}", ErrUnknownVariable, m[1])
+			}
+		}
+	}
+	return nil
+}
+
+// Render fills in the merge variables. Values are HTML-escaped in the body; a variable
+// without a value renders empty.
+// PRE: t has been validated
+// POST: Returns the subject and body with every known variable replaced
+// INVARIANT: MessageTemplate is not mutated
+func (t MessageTemplate) Render(v Vars) (subject, body string) {
+	return merge(t.Subject, v, false), merge(t.Body, v, true)
+}
+
+// RenderBody fills in the merge variables of a composed email's body for one recipient.
+// PRE: none
+// POST: Returns body with known variables replaced, HTML-escaped; unknown ones are left as written
+func RenderBody(body string, v Vars) string {
+	return merge(body, v, true)
+}
+
+// merge replaces each known {{Name}} in text with its value.
+func merge(text string, v Vars, escape bool) string {
+	return variablePattern.ReplaceAllStringFunc(text, func(token string) string {
+		name := variablePattern.FindStringSubmatch(token)[1]
+		if !isVariable(name) {
+			return token
+		}
+		if escape {
+			return html.EscapeString(v[name])
+		}
+		return v[name]
+	})
+}
+
+// isVariable reports whether name is one of the merge variables.
+func isVariable(name string) bool {
+	for _, v := range Variables {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SampleVars returns the values used to preview a template.
+// POST: Returns a value for every merge variable
+func SampleVars() Vars {
+	return Vars{
+		VarMemberName:     "Alex Taylor",
+		VarBelt:           "blue",
+		VarNextClassDate:  "Monday 6 January at 18:00",
+		VarWeekClasses:    "3",
+		VarWeekHours:      "4.5",
+		VarStreak:         "6",
+		VarBeltProgress:   "62% of the way to purple belt",
+		VarUpcomingTopics: "Fundamentals: Closed guard sweeps, then Half guard passing",
+		VarCeremony:       "Saturday 14 November at the main gym",
+		VarReferredName:   "Sam Rivera",
+		VarCredit:         "$20",
+		VarMilestone:      "100 classes",
+	}
+}
+
+// builtInTemplates holds the system's wording for each built-in key.
+var builtInTemplates = []MessageTemplate{
+	{
+		Key:      TemplateWelcome,
+		Name:     "Welcome",
+		Category: CategoryAccount,
+		Subject:  "Welcome to the gym, {{MemberName}}",
+		Body:     "<p>Hi {{MemberName}},</p><p>Your account is ready. You can now check your training log, book classes and see notices from your coaches.</p><p>Your next class is {{NextClassDate}}. See you on the mats!</p>",
+	},
+	{
+		Key:      TemplateInactiveFollowUp,
+		Name:     "Inactive follow-up",
+		Category: CategoryAnnouncements,
+		Subject:  "We miss you on the mats, {{MemberName}}",
+		Body:     "<p>Hi {{MemberName}},</p><p>We haven't seen you for a while. Life gets busy, and the mats will be here when you're ready.</p><p>The next class is {{NextClassDate}}. Reply to this email if there's anything we can help with.</p>",
+	},
+	{
+		Key:      TemplateGradingCongratulation,
+		Name:     "Grading congratulation",
+		Category: CategoryGrading,
+		Subject:  "Congratulations on your {{Belt}} belt, {{MemberName}}!",
+		Body:     "<p>Hi {{MemberName}},</p><p>Congratulations on your promotion to {{Belt}} belt. It's recorded in your training log.</p><p>Keep showing up. Your next class is {{NextClassDate}}.</p>",
+	},
+	{
+		Key:      TemplateWeeklyDigest,
+		Name:     "Weekly digest",
+		Category: CategoryDigest,
+		Subject:  "Your training week, {{MemberName}}",
+		Body:     "<p>Hi {{MemberName}},</p><p>Here's your week on the mats:</p><ul><li>Classes: {{WeekClasses}}</li><li>Mat hours: {{WeekHours}}</li><li>Weeks in a row: {{Streak}}</li></ul><p>{{BeltProgress}}</p><p>Coming up in your classes: {{UpcomingTopics}}</p><p>Your next class is {{NextClassDate}}.</p>",
+	},
+	{
+		Key:      TemplateCeremonyInvite,
+		Name:     "Promotion ceremony invitation",
+		Category: CategoryGrading,
+		Subject:  "You're invited: {{Belt}} belt promotion, {{MemberName}}",
+		Body:     "<p>Hi {{MemberName}},</p><p>Your coaches have put you forward for {{Belt}} belt, and we'd like to present it at our promotion ceremony on {{Ceremony}}.</p><p>Family and friends are welcome. See you there!</p>",
+	},
+	{
+		Key:      TemplateReferralThanks,
+		Name:     "Referral thank-you",
+		Category: CategoryAnnouncements,
+		Subject:  "Thanks for bringing {{ReferredName}} to the club",
+		Body:     "<p>Hi {{MemberName}},</p><p>{{ReferredName}} has joined the club, and they told us you sent them our way. Thank you!</p><p>Word of mouth is how our club grows. See you on the mats.</p>",
+	},
+	{
+		Key:      TemplateReferralCredit,
+		Name:     "Referral credit",
+		Category: CategoryBilling,
+		Subject:  "You've earned {{Credit}} for referring {{ReferredName}}",
+		Body:     "<p>Hi {{MemberName}},</p><p>{{ReferredName}} has joined the club, and they told us you sent them our way. Thank you!</p><p>We've put a credit of {{Credit}} towards your fees. It will come off your next payment.</p>",
+	},
+	{
+		Key:      TemplateBirthday,
+		Name:     "Birthday",
+		Category: CategoryAnnouncements,
+		Subject:  "Happy birthday, {{MemberName}}!",
+		Body:     "<p>Hi {{MemberName}},</p><p>Happy birthday from everyone at the club! We hope you have a great day.</p><p>Your next class is {{NextClassDate}}.</p>",
+	},
+	{
+		Key:      TemplateTrainingMilestone,
+		Name:     "Training milestone",
+		Category: CategoryAnnouncements,
+		Subject:  "Congratulations on {{Milestone}}, {{MemberName}}!",
+		Body:     "<p>Hi {{MemberName}},</p><p>Today marks {{Milestone}} with us. That takes real commitment, and we're proud to have you on the mats.</p><p>Your next class is {{NextClassDate}}. Here's to the next milestone!</p>",
+	},
+}
+
+// BuiltInTemplate returns the system's wording for a built-in key.
+// POST: Returns the template and true, or false for a key that is not built in
+func BuiltInTemplate(key string) (MessageTemplate, bool) {
+	for _, t := range builtInTemplates {
+		if t.Key == key {
+			t.BuiltIn = true
+			return t, true
+		}
+	}
+	return MessageTemplate{}, false
+}
+
+// Library merges the saved templates with the built-ins: a saved template replaces the
+// built-in with its key, and built-ins nobody has edited appear with the system's wording.
+// POST: Returns built-ins first in their fixed order, then the rest by name
+func Library(saved []MessageTemplate) []MessageTemplate {
+	byKey := make(map[string]MessageTemplate, len(saved))
+	for _, t := range saved {
+		byKey[t.Key] = t
+	}
+	var out, custom []MessageTemplate
+	for _, b := range builtInTemplates {
+		t, ok := byKey[b.Key]
+		if !ok {
+			t = b
+		}
+		t.BuiltIn = true
+		out = append(out, t)
+		delete(byKey, b.Key)
+	}
+	for _, t := range byKey {
+		custom = append(custom, t)
+	}
+	sort.Slice(custom, func(i, j int) bool { return custom[i].Name < custom[j].Name })
+	return append(out, custom...)
+}
@@ -0,0 +1,89 @@
+package email
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestMessageTemplate_Validate tests validation of library templates and their variables.
+func TestMessageTemplate_Validate(t *testing.T) {
+	valid := MessageTemplate{Key: "open_mat", Name: "Open mat", Category: CategoryAnnouncements, Subject: "Open mat, {{MemberName}}", Body: "<p>See you {{ NextClassDate }}</p>"}
+	tests := []struct {
+		name   string
+		modify func(*MessageTemplate)
+		want   error
+	}{
+		{"valid", func(*MessageTemplate) {}, nil},
+		{"bad key", func(m *MessageTemplate) { m.Key = "Open Mat" }, ErrInvalidTemplateKey},
+		{"no name", func(m *MessageTemplate) { m.Name = "" }, ErrEmptyTemplateName},
+		{"bad category", func(m *MessageTemplate) { m.Category = "newsletter" }, ErrInvalidCategory},
+		{"no subject", func(m *MessageTemplate) { m.Subject = "" }, ErrEmptySubject},
+		{"no body", func(m *MessageTemplate) { m.Body = "" }, ErrEmptyBody},
+		{"unknown variable", func(m *MessageTemplate) { m.Body = "Hi {{FirstName}}" }, ErrUnknownVariable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := valid
+			tt.modify(&m)
+			if got := m.Validate(); !errors.Is(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMessageTemplate_Render tests that variables are filled in and escaped in the body only.
+func TestMessageTemplate_Render(t *testing.T) {
+	m := MessageTemplate{Subject: "Well done {{MemberName}}", Body: "<p>{{MemberName}}: {{Belt}} belt{{NextClassDate}}</p>"}
+	subject, body := m.Render(Vars{VarMemberName: "Sam <Jr>", VarBelt: "blue"})
+	if subject != "Well done Sam <Jr>" {
+		t.Errorf("subject = %q", subject)
+	}
+	if body != "<p>Sam &lt;Jr&gt;: blue belt</p>" {
+		t.Errorf("body = %q", body)
+	}
+	if got := RenderBody("Hi {{MemberName}} {{Other}}", Vars{VarMemberName: "Sam"}); got != "Hi Sam {{Other}}" {
+		t.Errorf("RenderBody() = %q, want unknown variables left alone", got)
+	}
+}
+
+// TestBuiltInTemplates_Valid tests that the system's wording passes validation.
+func TestBuiltInTemplates_Valid(t *testing.T) {
+	for _, key := range []string{TemplateWelcome, TemplateInactiveFollowUp, TemplateGradingCongratulation, TemplateWeeklyDigest, TemplateCeremonyInvite, TemplateReferralThanks, TemplateReferralCredit, TemplateBirthday, TemplateTrainingMilestone} {
+		b, ok := BuiltInTemplate(key)
+		if !ok || !b.BuiltIn {
+			t.Fatalf("BuiltInTemplate(%q) missing", key)
+		}
+		if err := b.Validate(); err != nil {
+			t.Errorf("%s: %v", key, err)
+		}
+	}
+	if _, ok := BuiltInTemplate("open_mat"); ok {
+		t.Error("open_mat should not be built in")
+	}
+}
+
+// TestLibrary tests that saved wording replaces a built-in and custom templates follow by name.
+func TestLibrary(t *testing.T) {
+	got := Library([]MessageTemplate{
+		{Key: "seminar", Name: "Seminar"},
+		{Key: TemplateWelcome, Name: "Welcome", Subject: "Kia ora"},
+		{Key: "open_mat", Name: "Open mat"},
+	})
+	keys := make([]string, len(got))
+	for i, m := range got {
+		keys[i] = m.Key
+	}
+	want := []string{TemplateWelcome, TemplateInactiveFollowUp, TemplateGradingCongratulation, TemplateWeeklyDigest, TemplateCeremonyInvite, TemplateReferralThanks, TemplateReferralCredit, TemplateBirthday, TemplateTrainingMilestone, "open_mat", "seminar"}
+	if len(keys) != len(want) {
+		t.Fatalf("keys = %v, want %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("keys = %v, want %v", keys, want)
+		}
+	}
+	if got[0].Subject != "Kia ora" || !got[0].BuiltIn || got[9].BuiltIn {
+		t.Errorf("got %+v", got[:10])
+	}
+}
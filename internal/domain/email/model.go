@@ -2,6 +2,8 @@ package email
 
 import (
 	"errors"
+	"html"
+	"strings"
 	"time"
 )
 
@@ -21,6 +23,57 @@ const (
 	StatusFailed    = "failed"
 )
 
+// Delivery status constants for recipients, advanced by Resend webhook events.
+const (
+	DeliverySent         = "sent"
+	DeliverySuppressed   = "suppressed"   // not sent: address is on the suppression list
+	DeliveryFailed       = "failed"       // not sent: the provider rejected every attempt
+	DeliveryUnsubscribed = "unsubscribed" // not sent: the member opted out of the email's category
+	DeliveryDelayed      = "delayed"
+	DeliveryDelivered    = "delivered"
+	DeliveryOpened       = "opened"
+	DeliveryClicked      = "clicked"
+	DeliveryBounced      = "bounced"
+	DeliveryComplained   = "complained"
+)
+
+// deliveryRank orders delivery statuses so out-of-order webhooks never move a recipient backwards.
+var deliveryRank = map[string]int{
+	"":                 0,
+	DeliverySent:       1,
+	DeliveryDelayed:    2,
+	DeliveryDelivered:  3,
+	DeliveryOpened:     4,
+	DeliveryClicked:    5,
+	DeliveryBounced:    6,
+	DeliveryComplained: 7,
+}
+
+// providerEventStatus maps Resend webhook event types to recipient delivery statuses.
+var providerEventStatus = map[string]string{
+	"email.sent":             DeliverySent,
+	"email.delivery_delayed": DeliveryDelayed,
+	"email.delivered":        DeliveryDelivered,
+	"email.opened":           DeliveryOpened,
+	"email.clicked":          DeliveryClicked,
+	"email.bounced":          DeliveryBounced,
+	"email.complained":       DeliveryComplained,
+}
+
+// DeliveryStatusForEvent returns the delivery status for a provider webhook event type.
+// PRE: none
+// POST: Returns the status and true, or "" and false for events that don't affect delivery
+func DeliveryStatusForEvent(eventType string) (string, bool) {
+	status, ok := providerEventStatus[eventType]
+	return status, ok
+}
+
+// Suppression reasons.
+const (
+	SuppressionHardBounce = "hard_bounce"
+	SuppressionComplaint  = "complaint"
+)
+
 // Domain errors
 var (
 	ErrEmptySubject   = errors.New("email subject is required")
@@ -31,6 +84,7 @@ var (
 	ErrNotScheduled   = errors.New("email is not in scheduled status")
 	ErrNotDraft       = errors.New("email is not in draft status")
 	ErrNotCancellable = errors.New("email cannot be cancelled in its current status")
+	ErrNotDue         = errors.New("scheduled email is not yet due")
 )
 
 // Email represents a composed email that can be sent via Resend.
@@ -46,6 +100,8 @@ type Email struct {
 	UpdatedAt         time.Time
 	ResendMessageID   string // Resend API message ID for tracking
 	TemplateVersionID string // Snapshot of template used at send time
+	Category          string // announcements, grading, billing or account; see CategoryFor
+	TemplateKey       string // library template the email was rendered from; empty when composed
 }
 
 // EmailTemplate holds versioned header/footer content for email branding.
@@ -64,6 +120,18 @@ func (t *EmailTemplate) WrapBody(body string) string {
 	return t.Header + body + t.Footer
 }
 
+// WrapBodyFor wraps the body for one recipient, adding an unsubscribe link above the footer.
+// An empty unsubscribeURL (transactional mail, or no public URL configured) leaves the link out.
+// PRE: body is non-empty
+// POST: Returns the wrapped HTML string
+func (t *EmailTemplate) WrapBodyFor(body, unsubscribeURL string) string {
+	if unsubscribeURL == "" {
+		return t.WrapBody(body)
+	}
+	link := `<p style="font-size:12px;color:#666">Don't want these emails? <a href="` + html.EscapeString(unsubscribeURL) + `">Unsubscribe</a></p>`
+	return t.Header + body + link + t.Footer
+}
+
 // Recipient links an email to a member.
 type Recipient struct {
 	EmailID        string
@@ -71,6 +139,71 @@ type Recipient struct {
 	MemberName     string // Denormalized for display
 	MemberEmail    string // The actual email address for delivery
 	DeliveryStatus string // sent, delivered, bounced, opened (from Resend webhooks)
+
+	ResendMessageID   string    // Provider message ID for this recipient's copy
+	DeliveryUpdatedAt time.Time // When DeliveryStatus last changed
+	OpenedAt          time.Time // First open, if tracked
+	ClickedAt         time.Time // First click, if tracked
+	BounceReason      string    // Provider bounce message, if bounced
+}
+
+// ApplyDeliveryEvent records a provider delivery event for this recipient.
+// Statuses only move forward (sent < delayed < delivered < opened < clicked < bounced < complained),
+// so late or duplicate webhooks are harmless. First open and click times are kept.
+// PRE: status is one of the Delivery* constants
+// POST: Returns true if any field changed
+func (r *Recipient) ApplyDeliveryEvent(status string, at time.Time, reason string) bool {
+	changed := false
+	if status == DeliveryOpened && r.OpenedAt.IsZero() {
+		r.OpenedAt = at
+		changed = true
+	}
+	if status == DeliveryClicked && r.ClickedAt.IsZero() {
+		r.ClickedAt = at
+		changed = true
+	}
+	if status == DeliveryBounced && reason != "" && r.BounceReason == "" {
+		r.BounceReason = reason
+		changed = true
+	}
+	if deliveryRank[status] > deliveryRank[r.DeliveryStatus] {
+		r.DeliveryStatus = status
+		r.DeliveryUpdatedAt = at
+		changed = true
+	}
+	return changed
+}
+
+// SummarizeDelivery counts recipients by delivery status for the email delivery report.
+// Recipients without a status are counted as "pending".
+// PRE: none
+// POST: Returns a map of status to count
+func SummarizeDelivery(recipients []Recipient) map[string]int {
+	summary := make(map[string]int)
+	for _, r := range recipients {
+		status := r.DeliveryStatus
+		if status == "" {
+			status = "pending"
+		}
+		summary[status]++
+	}
+	return summary
+}
+
+// Suppression is an address that must not be emailed again, e.g. after a hard bounce.
+type Suppression struct {
+	Address   string // normalized with NormalizeAddress
+	Reason    string // hard_bounce or complaint
+	EmailID   string // email whose delivery triggered the suppression
+	Detail    string // provider message
+	CreatedAt time.Time
+}
+
+// NormalizeAddress lower-cases and trims an email address for suppression lookups.
+// PRE: none
+// POST: Returns the normalized address
+func NormalizeAddress(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
 }
 
 // Validate checks that the Email has valid data.
@@ -92,6 +225,9 @@ func (e *Email) Validate() error {
 	if e.SenderID == "" {
 		return ErrEmptySenderID
 	}
+	if e.Category != "" && !IsCategory(e.Category) {
+		return ErrInvalidCategory
+	}
 	if e.CreatedAt.IsZero() {
 		return errors.New("created_at must be set")
 	}
@@ -155,6 +291,26 @@ func (e *Email) Schedule(scheduledAt time.Time) error {
 	return nil
 }
 
+// IsDue reports whether a scheduled email should be dispatched at now.
+// INVARIANT: Status field is not mutated
+func (e *Email) IsDue(now time.Time) bool {
+	return e.Status == StatusScheduled && !e.ScheduledAt.After(now)
+}
+
+// MarkDue releases a due scheduled email for sending.
+// PRE: Email is in scheduled status and ScheduledAt is not after now
+// POST: Status is queued
+func (e *Email) MarkDue(now time.Time) error {
+	if e.Status != StatusScheduled {
+		return ErrNotScheduled
+	}
+	if e.ScheduledAt.After(now) {
+		return ErrNotDue
+	}
+	e.Status = StatusQueued
+	return nil
+}
+
 // Reschedule changes the scheduled delivery time.
 // PRE: Email is in scheduled status
 // POST: ScheduledAt is updated
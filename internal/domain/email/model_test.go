@@ -211,3 +211,64 @@ func TestEmailTemplate_WrapBody_Empty(t *testing.T) {
 		t.Errorf("WrapBody with empty template = %q, want %q", result, "<p>Body</p>")
 	}
 }
+
+// TestRecipient_ApplyDeliveryEvent tests that delivery statuses only move forward.
+func TestRecipient_ApplyDeliveryEvent(t *testing.T) {
+	later := fixedTime.Add(time.Hour)
+	r := Recipient{DeliveryStatus: DeliverySent}
+
+	if !r.ApplyDeliveryEvent(DeliveryOpened, fixedTime, "") || r.DeliveryStatus != DeliveryOpened || !r.OpenedAt.Equal(fixedTime) {
+		t.Fatalf("expected opened, got %+v", r)
+	}
+	// A late "delivered" webhook must not move the recipient backwards.
+	if r.ApplyDeliveryEvent(DeliveryDelivered, later, "") || r.DeliveryStatus != DeliveryOpened {
+		t.Errorf("expected status to stay opened, got %q", r.DeliveryStatus)
+	}
+	// A second open keeps the first open time.
+	if r.ApplyDeliveryEvent(DeliveryOpened, later, "") || !r.OpenedAt.Equal(fixedTime) {
+		t.Errorf("expected first open time kept, got %v", r.OpenedAt)
+	}
+	if !r.ApplyDeliveryEvent(DeliveryBounced, later, "mailbox full") || r.BounceReason != "mailbox full" || !r.DeliveryUpdatedAt.Equal(later) {
+		t.Errorf("expected bounce recorded, got %+v", r)
+	}
+}
+
+// TestSummarizeDelivery tests counting recipients per delivery status.
+func TestSummarizeDelivery(t *testing.T) {
+	got := SummarizeDelivery([]Recipient{
+		{DeliveryStatus: DeliveryDelivered},
+		{DeliveryStatus: DeliveryDelivered},
+		{DeliveryStatus: DeliveryBounced},
+		{},
+	})
+	if got[DeliveryDelivered] != 2 || got[DeliveryBounced] != 1 || got["pending"] != 1 {
+		t.Errorf("unexpected summary: %v", got)
+	}
+}
+
+// TestNormalizeAddress tests address normalization for suppression lookups.
+func TestNormalizeAddress(t *testing.T) {
+	if got := NormalizeAddress("  Jo@Example.COM "); got != "jo@example.com" {
+		t.Errorf("NormalizeAddress = %q", got)
+	}
+}
+
+// TestEmail_MarkDue tests releasing scheduled emails only once they are due.
+func TestEmail_MarkDue(t *testing.T) {
+	e := Email{Status: StatusScheduled, ScheduledAt: fixedTime}
+	if e.IsDue(fixedTime.Add(-time.Minute)) {
+		t.Error("expected not due before ScheduledAt")
+	}
+	if err := e.MarkDue(fixedTime.Add(-time.Minute)); err != ErrNotDue {
+		t.Errorf("expected ErrNotDue, got %v", err)
+	}
+	if !e.IsDue(fixedTime) {
+		t.Error("expected due at ScheduledAt")
+	}
+	if err := e.MarkDue(fixedTime); err != nil || e.Status != StatusQueued {
+		t.Errorf("expected queued, got %q (%v)", e.Status, err)
+	}
+	if err := e.MarkDue(fixedTime); err != ErrNotScheduled {
+		t.Errorf("expected ErrNotScheduled once released, got %v", err)
+	}
+}
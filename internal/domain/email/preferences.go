@@ -0,0 +1,162 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Email categories. Members can opt out of every category except account mail,
+// which carries activation links and password resets and is always delivered.
+// The weekly digest is opt-in: members receive it only after switching it on.
+const (
+	CategoryAnnouncements = "announcements"
+	CategoryGrading       = "grading"
+	CategoryBilling       = "billing"
+	CategoryDigest        = "digest"
+	CategoryAccount       = "account"
+)
+
+// OptionalCategories are the categories a member can unsubscribe from, in display order.
+var OptionalCategories = []string{CategoryAnnouncements, CategoryGrading, CategoryBilling, CategoryDigest}
+
+// UnsubscribeTokenPrefix marks an unsubscribe link token and its format version.
+const UnsubscribeTokenPrefix = "wsun1"
+
+// unsubscribeSignatureBytes is how much of the HMAC-SHA256 is kept in the link.
+const unsubscribeSignatureBytes = 16
+
+// Preference errors
+var (
+	ErrInvalidCategory         = errors.New("invalid email category")
+	ErrCategoryRequired        = errors.New("account emails cannot be unsubscribed from")
+	ErrInvalidUnsubscribeToken = errors.New("invalid unsubscribe link")
+)
+
+// IsCategory reports whether category is one of the Category* constants.
+// PRE: none
+// POST: Returns true for a known category
+func IsCategory(category string) bool {
+	return category == CategoryAccount || isOptional(category)
+}
+
+// isOptional reports whether members may opt out of category.
+func isOptional(category string) bool {
+	for _, c := range OptionalCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// CategoryFor returns the category an email is sent under; emails saved before
+// categories existed are announcements.
+// PRE: none
+// POST: Returns a non-empty category
+func (e *Email) CategoryFor() string {
+	if e.Category == "" {
+		return CategoryAnnouncements
+	}
+	return e.Category
+}
+
+// Preferences records which optional email categories a member receives.
+type Preferences struct {
+	MemberID      string
+	Announcements bool
+	Grading       bool
+	Billing       bool
+	Digest        bool // weekly training summary; off until the member opts in
+	UpdatedAt     time.Time
+}
+
+// DefaultPreferences returns the preferences of a member who has never changed them:
+// everything on except the opt-in weekly digest.
+// PRE: memberID is non-empty
+// POST: Returns preferences with every category but the digest enabled
+func DefaultPreferences(memberID string) Preferences {
+	return Preferences{MemberID: memberID, Announcements: true, Grading: true, Billing: true}
+}
+
+// Allows reports whether the member receives emails of category.
+// Account emails and unknown categories are always allowed.
+// INVARIANT: Preferences are not mutated
+func (p *Preferences) Allows(category string) bool {
+	switch category {
+	case CategoryAnnouncements:
+		return p.Announcements
+	case CategoryGrading:
+		return p.Grading
+	case CategoryBilling:
+		return p.Billing
+	case CategoryDigest:
+		return p.Digest
+	}
+	return true
+}
+
+// Set turns one optional category on or off.
+// PRE: none
+// POST: The category is updated, or ErrCategoryRequired / ErrInvalidCategory is returned
+func (p *Preferences) Set(category string, on bool) error {
+	switch category {
+	case CategoryAnnouncements:
+		p.Announcements = on
+	case CategoryGrading:
+		p.Grading = on
+	case CategoryBilling:
+		p.Billing = on
+	case CategoryDigest:
+		p.Digest = on
+	case CategoryAccount:
+		return ErrCategoryRequired
+	default:
+		return ErrInvalidCategory
+	}
+	return nil
+}
+
+// SignUnsubscribeToken builds the token carried by an email's unsubscribe link.
+// The token is "wsun1.<memberID>.<category>.<signature>" so the link works without logging in.
+// PRE: key is non-empty; memberID and category are non-empty and contain no '.'
+// POST: Returns a token that VerifyUnsubscribeToken accepts with the same key
+func SignUnsubscribeToken(key []byte, memberID, category string) string {
+	return UnsubscribeTokenPrefix + "." + memberID + "." + category + "." + unsubscribeSignature(key, memberID, category)
+}
+
+// VerifyUnsubscribeToken checks a link token's signature and returns the member and category it names.
+// PRE: key is the one the token was signed with
+// POST: Returns the member ID and category, or ErrInvalidUnsubscribeToken
+func VerifyUnsubscribeToken(key []byte, token string) (memberID, category string, err error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 4 || parts[0] != UnsubscribeTokenPrefix || parts[1] == "" || !isOptional(parts[2]) {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+	if !hmac.Equal([]byte(parts[3]), []byte(unsubscribeSignature(key, parts[1], parts[2]))) {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+	return parts[1], parts[2], nil
+}
+
+// UnsubscribeURL returns the link appended to an email of category sent to memberID.
+// Account emails get no link.
+// PRE: baseURL is the public origin, e.g. https://gym.example.com
+// POST: Returns the absolute link, or "" for a category that cannot be unsubscribed from
+func UnsubscribeURL(baseURL string, key []byte, memberID, category string) string {
+	if !isOptional(category) {
+		return ""
+	}
+	return strings.TrimRight(baseURL, "/") + "/unsubscribe?token=" + url.QueryEscape(SignUnsubscribeToken(key, memberID, category))
+}
+
+// unsubscribeSignature returns the truncated, URL-safe HMAC of a member and category.
+func unsubscribeSignature(key []byte, memberID, category string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(UnsubscribeTokenPrefix + "." + memberID + "." + category))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:unsubscribeSignatureBytes])
+}
@@ -0,0 +1,108 @@
+package email
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestPreferences_AllowsAndSet tests opting in and out of categories; account mail is always on
+// and the weekly digest starts off.
+func TestPreferences_AllowsAndSet(t *testing.T) {
+	p := DefaultPreferences("member-1")
+	for _, c := range OptionalCategories {
+		if c != CategoryDigest && !p.Allows(c) {
+			t.Errorf("default preferences should allow %s", c)
+		}
+	}
+	if p.Allows(CategoryDigest) {
+		t.Error("the weekly digest should be opt-in")
+	}
+	if err := p.Set(CategoryDigest, true); err != nil || !p.Allows(CategoryDigest) {
+		t.Errorf("opting in to the digest: got %+v, %v", p, err)
+	}
+
+	if err := p.Set(CategoryGrading, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Allows(CategoryGrading) || !p.Allows(CategoryAnnouncements) || !p.Allows(CategoryAccount) {
+		t.Errorf("after opting out of grading got %+v", p)
+	}
+	if err := p.Set(CategoryAccount, false); err != ErrCategoryRequired {
+		t.Errorf("expected ErrCategoryRequired, got %v", err)
+	}
+	if err := p.Set("newsletter", false); err != ErrInvalidCategory {
+		t.Errorf("expected ErrInvalidCategory, got %v", err)
+	}
+}
+
+// TestEmail_CategoryFor tests that uncategorised emails are treated as announcements.
+func TestEmail_CategoryFor(t *testing.T) {
+	e := Email{}
+	if got := e.CategoryFor(); got != CategoryAnnouncements {
+		t.Errorf("expected announcements, got %s", got)
+	}
+	e.Category = CategoryBilling
+	if got := e.CategoryFor(); got != CategoryBilling {
+		t.Errorf("expected billing, got %s", got)
+	}
+	e = Email{Subject: "s", Body: "b", SenderID: "a", CreatedAt: fixedTime, Category: "spam"}
+	if err := e.Validate(); err != ErrInvalidCategory {
+		t.Errorf("expected ErrInvalidCategory, got %v", err)
+	}
+}
+
+// TestUnsubscribeToken_RoundTrip tests that a signed token verifies with the same key only.
+func TestUnsubscribeToken_RoundTrip(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	token := SignUnsubscribeToken(key, "member-1", CategoryGrading)
+
+	memberID, category, err := VerifyUnsubscribeToken(key, token)
+	if err != nil || memberID != "member-1" || category != CategoryGrading {
+		t.Fatalf("VerifyUnsubscribeToken() = %q, %q, %v; want member-1, grading", memberID, category, err)
+	}
+	if _, _, err := VerifyUnsubscribeToken([]byte("another key"), token); !errors.Is(err, ErrInvalidUnsubscribeToken) {
+		t.Errorf("expected ErrInvalidUnsubscribeToken for wrong key, got %v", err)
+	}
+
+	sig := token[strings.LastIndex(token, ".")+1:]
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"other member", "wsun1.member-2.grading." + sig},
+		{"other category", "wsun1.member-1.billing." + sig},
+		{"account category", SignUnsubscribeToken(key, "member-1", CategoryAccount)},
+		{"wrong prefix", "wsci1.member-1.grading." + sig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := VerifyUnsubscribeToken(key, tt.token); !errors.Is(err, ErrInvalidUnsubscribeToken) {
+				t.Errorf("expected ErrInvalidUnsubscribeToken, got %v", err)
+			}
+		})
+	}
+}
+
+// TestWrapBodyFor tests the unsubscribe link sits between the body and the footer.
+func TestWrapBodyFor(t *testing.T) {
+	key := []byte("k")
+	tpl := EmailTemplate{Header: "<h1>Gym</h1>", Footer: "<footer>Address</footer>"}
+
+	link := UnsubscribeURL("https://gym.example.com/", key, "member-1", CategoryAnnouncements)
+	if !strings.HasPrefix(link, "https://gym.example.com/unsubscribe?token=wsun1.member-1.announcements.") {
+		t.Fatalf("unexpected link %q", link)
+	}
+	got := tpl.WrapBodyFor("<p>Hi</p>", link)
+	if !strings.HasPrefix(got, "<h1>Gym</h1><p>Hi</p>") || !strings.HasSuffix(got, "<footer>Address</footer>") || !strings.Contains(got, "Unsubscribe</a>") {
+		t.Errorf("unexpected body %q", got)
+	}
+
+	if UnsubscribeURL("https://gym.example.com", key, "member-1", CategoryAccount) != "" {
+		t.Error("account emails should have no unsubscribe link")
+	}
+	if got := tpl.WrapBodyFor("<p>Hi</p>", ""); got != tpl.WrapBody("<p>Hi</p>") {
+		t.Errorf("expected no link without a URL, got %q", got)
+	}
+}
@@ -3,6 +3,7 @@ package estimatedhours
 import (
 	"errors"
 	"math"
+	"strings"
 	"time"
 )
 
@@ -20,9 +21,10 @@ const (
 
 // Status constants.
 const (
-	StatusApproved = "approved"
-	StatusPending  = "pending"
-	StatusRejected = "rejected"
+	StatusApproved  = "approved"
+	StatusPending   = "pending"
+	StatusRejected  = "rejected"
+	StatusNeedsInfo = "needs_info" // reviewer asked the member for more information
 )
 
 // Domain errors.
@@ -33,12 +35,15 @@ var (
 	ErrStartAfterEnd      = errors.New("start date cannot be after end date")
 	ErrInvalidWeekly      = errors.New("weekly hours must be greater than zero")
 	ErrInvalidSource      = errors.New("source must be 'estimate' or 'self_estimate'")
-	ErrInvalidStatus      = errors.New("status must be 'approved', 'pending', or 'rejected'")
+	ErrInvalidStatus      = errors.New("status must be 'approved', 'pending', 'needs_info', or 'rejected'")
 	ErrNoteTooLong        = errors.New("note cannot exceed 500 characters")
 	ErrEmptyCreatedBy     = errors.New("created by cannot be empty")
 	ErrWeeklyHoursTooHigh = errors.New("weekly hours cannot exceed 40")
 	ErrNotPending         = errors.New("only pending estimates can be reviewed")
 	ErrReviewNoteTooLong  = errors.New("review note cannot exceed 500 characters")
+	ErrEmptyInfoRequest   = errors.New("say what information is needed")
+	ErrNotAwaitingInfo    = errors.New("only estimates waiting on more information can be answered")
+	ErrEmptyInfoResponse  = errors.New("response cannot be empty")
 )
 
 // EstimatedHours represents a bulk-estimated mat hours entry for a member.
@@ -52,13 +57,13 @@ type EstimatedHours struct {
 	WeeklyHours float64 // hours per week
 	TotalHours  float64 // computed total
 	Source      string  // estimate or self_estimate
-	Status      string  // approved, pending, or rejected
+	Status      string  // approved, pending, needs_info, or rejected
 	Note        string
 	CreatedBy   string // account ID
 	CreatedAt   time.Time
 	ReviewedBy  string // account ID of reviewer (admin/coach)
 	ReviewedAt  time.Time
-	ReviewNote  string // reason for rejection or adjustment note
+	ReviewNote  string // reason for rejection, adjustment note, or the question asked of the member
 }
 
 // Validate checks the estimated hours invariants.
@@ -94,7 +99,7 @@ func (e *EstimatedHours) Validate() error {
 	if e.Source != SourceEstimate && e.Source != SourceSelfEstimate && e.Source != SourceCredit {
 		return ErrInvalidSource
 	}
-	if e.Status != StatusApproved && e.Status != StatusPending && e.Status != StatusRejected {
+	if e.Status != StatusApproved && e.Status != StatusPending && e.Status != StatusRejected && e.Status != StatusNeedsInfo {
 		return ErrInvalidStatus
 	}
 	if len(e.Note) > MaxNoteLength {
@@ -143,6 +148,60 @@ func (e *EstimatedHours) Reject(reviewerID string, reason string, now time.Time)
 	return nil
 }
 
+// RequestInfo sends a pending estimate back to the member with a question.
+// PRE: Status must be pending. reviewerID is non-empty. question is non-empty.
+// POST: Status becomes needs_info, ReviewedBy/ReviewedAt/ReviewNote set.
+func (e *EstimatedHours) RequestInfo(reviewerID string, question string, now time.Time) error {
+	if e.Status != StatusPending {
+		return ErrNotPending
+	}
+	if strings.TrimSpace(question) == "" {
+		return ErrEmptyInfoRequest
+	}
+	if len(question) > MaxNoteLength {
+		return ErrReviewNoteTooLong
+	}
+	e.Status = StatusNeedsInfo
+	e.ReviewedBy = reviewerID
+	e.ReviewedAt = now
+	e.ReviewNote = question
+	return nil
+}
+
+// ProvideInfo records the member's answer and returns the estimate to the review queue.
+// PRE: Status must be needs_info. response is non-empty.
+// POST: Status becomes pending; the answer itself is kept in the review history.
+func (e *EstimatedHours) ProvideInfo(response string) error {
+	if e.Status != StatusNeedsInfo {
+		return ErrNotAwaitingInfo
+	}
+	if strings.TrimSpace(response) == "" {
+		return ErrEmptyInfoResponse
+	}
+	if len(response) > MaxNoteLength {
+		return ErrNoteTooLong
+	}
+	e.Status = StatusPending
+	return nil
+}
+
+// SubmittedHours returns the total the entry's dates and weekly hours add up to,
+// which is what the member claimed before any reviewer adjustment.
+// INVARIANT: EstimatedHours is not mutated
+func (e *EstimatedHours) SubmittedHours() float64 {
+	claimed := *e
+	if err := claimed.CalculateTotalHours(); err != nil {
+		return e.TotalHours
+	}
+	return claimed.TotalHours
+}
+
+// Adjusted reports whether the estimate was approved for a different total than was submitted.
+// INVARIANT: EstimatedHours is not mutated
+func (e *EstimatedHours) Adjusted() bool {
+	return e.Status == StatusApproved && e.TotalHours != e.SubmittedHours()
+}
+
 // CalculateTotalHours computes the total hours from the date range and weekly hours.
 // PRE: StartDate and EndDate are valid YYYY-MM-DD dates, WeeklyHours > 0
 // POST: sets TotalHours = ceil(weeks) × WeeklyHours
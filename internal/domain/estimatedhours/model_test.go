@@ -133,3 +133,47 @@ func TestEstimatedHours_Validate_InvalidSource(t *testing.T) {
 		t.Errorf("expected ErrInvalidSource, got %v", err)
 	}
 }
+
+// TestEstimatedHours_RequestInfo tests the needs-info round trip back to pending.
+func TestEstimatedHours_RequestInfo(t *testing.T) {
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+	e := EstimatedHours{StartDate: "2026-01-01", EndDate: "2026-01-14", WeeklyHours: 4, TotalHours: 8, Status: StatusPending}
+	if err := e.RequestInfo("coach-1", "  ", now); err != ErrEmptyInfoRequest {
+		t.Errorf("blank question: got %v, want ErrEmptyInfoRequest", err)
+	}
+	if err := e.ProvideInfo("I was in Lisbon"); err != ErrNotAwaitingInfo {
+		t.Errorf("answer while pending: got %v, want ErrNotAwaitingInfo", err)
+	}
+	if err := e.RequestInfo("coach-1", "Which gym?", now); err != nil {
+		t.Fatalf("RequestInfo: %v", err)
+	}
+	if e.Status != StatusNeedsInfo || e.ReviewNote != "Which gym?" || e.ReviewedBy != "coach-1" {
+		t.Errorf("after RequestInfo: %+v", e)
+	}
+	if err := e.Approve("coach-1", 0, "", now); err != ErrNotPending {
+		t.Errorf("approve while waiting: got %v, want ErrNotPending", err)
+	}
+	if err := e.ProvideInfo(""); err != ErrEmptyInfoResponse {
+		t.Errorf("blank answer: got %v, want ErrEmptyInfoResponse", err)
+	}
+	if err := e.ProvideInfo("Checkmat Lisbon"); err != nil || e.Status != StatusPending {
+		t.Errorf("ProvideInfo: err %v, status %q", err, e.Status)
+	}
+}
+
+// TestEstimatedHours_Adjusted tests adjustment is measured against the submitted dates and weekly hours.
+func TestEstimatedHours_Adjusted(t *testing.T) {
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+	e := EstimatedHours{StartDate: "2026-01-01", EndDate: "2026-01-14", WeeklyHours: 4, Status: StatusPending}
+	e.CalculateTotalHours()
+	same := e
+	if err := same.Approve("coach-1", 8, "", now); err != nil || same.Adjusted() {
+		t.Errorf("approved for the submitted 8h: err %v, Adjusted() = %v", err, same.Adjusted())
+	}
+	if err := e.Approve("coach-1", 6, "Two sessions were open mat", now); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if !e.Adjusted() || e.SubmittedHours() != 8 || e.TotalHours != 6 {
+		t.Errorf("Adjusted() = %v, SubmittedHours() = %v, TotalHours = %v", e.Adjusted(), e.SubmittedHours(), e.TotalHours)
+	}
+}
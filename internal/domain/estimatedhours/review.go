@@ -0,0 +1,52 @@
+package estimatedhours
+
+import (
+	"errors"
+	"time"
+)
+
+// Review history actions.
+const (
+	ReviewSubmitted = "submitted"  // member submitted the estimate
+	ReviewNeedsInfo = "needs_info" // reviewer asked the member a question
+	ReviewResponded = "responded"  // member answered the question
+	ReviewApproved  = "approved"   // approved as submitted
+	ReviewAdjusted  = "adjusted"   // approved for a different total
+	ReviewRejected  = "rejected"
+)
+
+// Review event errors.
+var (
+	ErrEmptyEntryID       = errors.New("review event entry ID cannot be empty")
+	ErrInvalidReviewEvent = errors.New("review action must be submitted, needs_info, responded, approved, adjusted or rejected")
+)
+
+// ReviewEvent is one step in a self-estimate's review: a submission, question, answer or decision.
+// Events are append-only and listed oldest first, so the member and reviewers see the whole exchange.
+type ReviewEvent struct {
+	ID        string
+	EntryID   string // the estimated hours entry
+	ActorID   string // member ID for submissions and answers, reviewer account ID otherwise
+	Action    string // one of the Review* constants
+	Note      string // the member's note or answer, or the reviewer's question or reason
+	Hours     float64
+	CreatedAt time.Time
+}
+
+// Validate checks the review event invariants.
+// PRE: none
+// POST: returns nil if valid, error describing the first violation otherwise
+func (ev *ReviewEvent) Validate() error {
+	if ev.EntryID == "" {
+		return ErrEmptyEntryID
+	}
+	switch ev.Action {
+	case ReviewSubmitted, ReviewNeedsInfo, ReviewResponded, ReviewApproved, ReviewAdjusted, ReviewRejected:
+	default:
+		return ErrInvalidReviewEvent
+	}
+	if len(ev.Note) > MaxNoteLength {
+		return ErrNoteTooLong
+	}
+	return nil
+}
@@ -27,6 +27,13 @@ var (
 	ErrEmptyRequestID = errors.New("request_id is required")
 	ErrInvalidStatus  = errors.New("invalid status transition")
 	ErrNotReady       = errors.New("export not ready for download")
+	ErrInProgress     = errors.New("an export is already being prepared")
+)
+
+// Lifetime constants.
+const (
+	DownloadWindow = 7 * 24 * time.Hour // how long a finished export can be downloaded
+	StaleAfter     = 24 * time.Hour     // an unfinished export older than this no longer blocks a new one
 )
 
 // Request represents a member's data export request (GDPR Article 20).
@@ -53,6 +60,7 @@ type Data struct {
 	Attendance          []AttendanceRecord   `json:"attendance,omitempty"`
 	Injuries            []InjuryRecord       `json:"injuries,omitempty"`
 	Waivers             []WaiverRecord       `json:"waivers,omitempty"`
+	Consents            []ConsentRecord      `json:"consents,omitempty"`
 	GradingHistory      []GradingRecord      `json:"grading_history,omitempty"`
 	Messages            []MessageRecord      `json:"messages,omitempty"`
 	Observations        []ObservationRecord  `json:"observations,omitempty"`
@@ -77,6 +85,8 @@ type MemberData struct {
 	Belt          string    `json:"belt"`
 	Stripe        int       `json:"stripe"`
 	GradingMetric string    `json:"grading_metric"`
+	DateOfBirth   string    `json:"date_of_birth,omitempty"` // YYYY-MM-DD, when given for celebrations
+	JoinedOn      string    `json:"joined_on,omitempty"`     // YYYY-MM-DD, when recorded for celebrations
 }
 
 // AccountData represents account-level information.
@@ -113,6 +123,22 @@ type WaiverRecord struct {
 	AcceptedTerms bool      `json:"accepted_terms"`
 	SignedAt      time.Time `json:"signed_at"`
 	IPaddress     string    `json:"ip_address,omitempty"`
+	SignerName    string    `json:"signer_name,omitempty"`
+	// Guardian fields are set when a parent or guardian signed for the member.
+	GuardianName         string `json:"guardian_name,omitempty"`
+	GuardianRelationship string `json:"guardian_relationship,omitempty"`
+	GuardianEmail        string `json:"guardian_email,omitempty"`
+	GuardianPhone        string `json:"guardian_phone,omitempty"`
+	DocumentHash         string `json:"document_sha256,omitempty"`
+}
+
+// ConsentRecord represents a consent the member granted or revoked.
+type ConsentRecord struct {
+	Type      string     `json:"type"`
+	Granted   bool       `json:"granted"`
+	GrantedAt time.Time  `json:"granted_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	Version   string     `json:"version"`
 }
 
 // GradingRecord represents a belt promotion.
@@ -242,18 +268,18 @@ func (r *Request) MarkReady(filePath string, fileSize int64) error {
 	r.FilePath = filePath
 	r.FileSize = fileSize
 	r.CompletedAt = &now
-	// Set expiration (7 days from now)
-	expiredAt := now.Add(7 * 24 * time.Hour)
+	expiredAt := now.Add(DownloadWindow)
 	r.ExpiredAt = &expiredAt
 	return nil
 }
 
-// MarkDownloaded records that the export was downloaded.
-// PRE: Status is ready
+// MarkDownloaded records that the export was downloaded. It stays downloadable until it
+// expires, so a member can fetch it again from another device.
+// PRE: Status is ready or downloaded
 // POST: Status set to downloaded, DownloadedAt set to now
-// INVARIANT: Request must be in ready status
+// INVARIANT: Request must be in ready or downloaded status
 func (r *Request) MarkDownloaded() error {
-	if r.Status != StatusReady {
+	if r.Status != StatusReady && r.Status != StatusDownloaded {
 		return ErrNotReady
 	}
 	now := time.Now()
@@ -275,10 +301,32 @@ func (r *Request) IsExpired() bool {
 
 // CanDownload returns true if the export is ready and not expired.
 // PRE: Status and ExpiredAt are known
-// POST: Returns true if status is ready and not expired
-// INVARIANT: Status must be ready, not past expiration
+// POST: Returns true if status is ready or downloaded and not expired
+// INVARIANT: Status must be ready or downloaded, not past expiration
 func (r *Request) CanDownload() bool {
-	return r.Status == StatusReady && !r.IsExpired()
+	return (r.Status == StatusReady || r.Status == StatusDownloaded) && !r.IsExpired()
+}
+
+// MarkExpired closes a finished export once its download window has passed.
+// PRE: Status is ready or downloaded
+// POST: Status set to expired, FilePath cleared (the caller removes the file)
+// INVARIANT: Request must be in ready or downloaded status
+func (r *Request) MarkExpired() error {
+	if r.Status != StatusReady && r.Status != StatusDownloaded {
+		return ErrInvalidStatus
+	}
+	r.Status = StatusExpired
+	r.FilePath = ""
+	return nil
+}
+
+// InProgress returns true while the export is still being prepared. An export stuck for
+// longer than StaleAfter is treated as abandoned so the member can ask again.
+// PRE: Status and RequestedAt are set
+// POST: Returns true for pending or processing requests younger than StaleAfter
+// INVARIANT: None
+func (r *Request) InProgress(now time.Time) bool {
+	return (r.Status == StatusPending || r.Status == StatusProcessing) && now.Sub(r.RequestedAt) < StaleAfter
 }
 
 // ToJSON serializes the Data to JSON format.
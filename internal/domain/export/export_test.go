@@ -0,0 +1,71 @@
+package export_test
+
+import (
+	"testing"
+	"time"
+
+	"workshop/internal/domain/export"
+)
+
+// TestRequest_Lifecycle verifies an export moves from pending to ready, can be downloaded
+// more than once, and expires.
+func TestRequest_Lifecycle(t *testing.T) {
+	r := export.NewRequest("exp-1", "m1", "", "127.0.0.1", "test")
+	if err := r.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if r.CanDownload() {
+		t.Error("a pending export should not be downloadable")
+	}
+	if err := r.MarkReady("exports/exp-1.zip", 10); err != export.ErrInvalidStatus {
+		t.Errorf("MarkReady before processing = %v, want ErrInvalidStatus", err)
+	}
+	if err := r.MarkProcessing(); err != nil {
+		t.Fatalf("MarkProcessing: %v", err)
+	}
+	if err := r.MarkReady("exports/exp-1.zip", 10); err != nil {
+		t.Fatalf("MarkReady: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := r.MarkDownloaded(); err != nil {
+			t.Fatalf("MarkDownloaded #%d: %v", i+1, err)
+		}
+		if !r.CanDownload() {
+			t.Errorf("export should stay downloadable after download #%d", i+1)
+		}
+	}
+	if err := r.MarkExpired(); err != nil {
+		t.Fatalf("MarkExpired: %v", err)
+	}
+	if r.CanDownload() || r.FilePath != "" {
+		t.Errorf("an expired export should have no file and not be downloadable, got %+v", r)
+	}
+	if err := r.MarkExpired(); err != export.ErrInvalidStatus {
+		t.Errorf("MarkExpired twice = %v, want ErrInvalidStatus", err)
+	}
+}
+
+// TestRequest_InProgress verifies only recent unfinished exports block a new request.
+func TestRequest_InProgress(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		modify func(r *export.Request)
+		want   bool
+	}{
+		{name: "just requested", modify: func(r *export.Request) {}, want: true},
+		{name: "processing", modify: func(r *export.Request) { r.Status = export.StatusProcessing }, want: true},
+		{name: "stale", modify: func(r *export.Request) { r.RequestedAt = now.Add(-export.StaleAfter) }, want: false},
+		{name: "ready", modify: func(r *export.Request) { r.Status = export.StatusReady }, want: false},
+		{name: "expired", modify: func(r *export.Request) { r.Status = export.StatusExpired }, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := export.Request{ID: "exp-1", MemberID: "m1", Status: export.StatusPending, RequestedAt: now.Add(-time.Hour)}
+			tt.modify(&r)
+			if got := r.InProgress(now); got != tt.want {
+				t.Errorf("InProgress = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
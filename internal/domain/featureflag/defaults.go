@@ -94,5 +94,197 @@ func DefaultFlags() []FeatureFlag {
 			EnabledMember: false,
 			EnabledTrial:  false,
 		},
+		{
+			Key:           "locations",
+			Description:   "Multi-location (branch) support and location picker",
+			EnabledAdmin:  true,
+			EnabledCoach:  true,
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "notifications",
+			Description:   "Notification center (bell, unread counts, channel preferences)",
+			EnabledAdmin:  true,
+			EnabledCoach:  true,
+			EnabledMember: true,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "search",
+			Description:   "Global search bar (members, notices, clips, topics, messages)",
+			EnabledAdmin:  true,
+			EnabledCoach:  true,
+			EnabledMember: true,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "backups",
+			Description:   "Database backups and restore (admin)",
+			EnabledAdmin:  true,
+			EnabledCoach:  false,
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "sessions",
+			Description:   "Signed-in session list and force sign-out (admin)",
+			EnabledAdmin:  true,
+			EnabledCoach:  false,
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "api_docs",
+			Description:   "OpenAPI document and API reference page (admin)",
+			EnabledAdmin:  true,
+			EnabledCoach:  false,
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "dashboard_stats",
+			Description:   "Dashboard KPI widgets and 12-week trends (admin)",
+			EnabledAdmin:  true,
+			EnabledCoach:  false,
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "live_updates",
+			Description:   "Live updates over server-sent events (check-ins, messages, notices)",
+			EnabledAdmin:  true,
+			EnabledCoach:  true,
+			EnabledMember: true,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "belt_inventory",
+			Description:   "Belt and stripe stock, belt sizes and grading-day pick lists (admin)",
+			EnabledAdmin:  true,
+			EnabledCoach:  false,
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "visitors",
+			Description:   "Remember drop-in guests across visits; history, fees, follow-ups and conversion (admin)",
+			EnabledAdmin:  true,
+			EnabledCoach:  true, // kiosks launched by coaches record visits
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "reengagement",
+			Description:   "Automatic emails and coach calls for members who stop training, with pauses and outcomes (admin, coach)",
+			EnabledAdmin:  true,
+			EnabledCoach:  true, // coaches make the calls and record how they went
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "timesheets",
+			Description:   "Who coached each class, monthly hours per coach and the payroll CSV (admin)",
+			EnabledAdmin:  true,
+			EnabledCoach:  false,
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "timetable",
+			Description:   "Week-grid timetable editor with drag-and-drop moves and clash checks (admin)",
+			EnabledAdmin:  true,
+			EnabledCoach:  false,
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "coverage",
+			Description:   "Coach availability calendar and uncovered-class planner with one-click assign (admin, coach)",
+			EnabledAdmin:  true,
+			EnabledCoach:  true,
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "member_tags",
+			Description:   "Member tags and saved smart segments for the members list, inactive report and email recipients (admin)",
+			EnabledAdmin:  true,
+			EnabledCoach:  false,
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "referrals",
+			Description:   "Referred-by capture at registration and guest check-in, referral leaderboard and referrer rewards (admin)",
+			EnabledAdmin:  true,
+			EnabledCoach:  true, // kiosks launched by coaches capture who referred a guest
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "jobs",
+			Description:   "Background job schedules: cron timing, pause and run now (admin)",
+			EnabledAdmin:  true,
+			EnabledCoach:  false,
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "languages",
+			Description:   "Language picker and translated member pages: English (NZ) and te reo Māori (all roles)",
+			EnabledAdmin:  true,
+			EnabledCoach:  true,
+			EnabledMember: true,
+			EnabledTrial:  true,
+		},
+		{
+			Key:           "email_change",
+			Description:   "Change an account's email from the Security page, confirmed by a link sent to the new address (all roles)",
+			EnabledAdmin:  true,
+			EnabledCoach:  true,
+			EnabledMember: true,
+			EnabledTrial:  true,
+		},
+		{
+			Key:           "navigation",
+			Description:   "The role-aware menu as JSON at /api/nav, for apps and scripts; the page menu is always shown (all roles)",
+			EnabledAdmin:  true,
+			EnabledCoach:  true,
+			EnabledMember: true,
+			EnabledTrial:  true,
+		},
+		{
+			Key:           "quick_capture",
+			Description:   "Coaches jot notes by member name on their phones mid-class, queued offline and saved as observations (admin, coach)",
+			EnabledAdmin:  true,
+			EnabledCoach:  true,
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "class_feedback",
+			Description:   "Members rate a class within 24 hours of checking in; coaches see an anonymised feedback report (all roles)",
+			EnabledAdmin:  true,
+			EnabledCoach:  true,
+			EnabledMember: true,
+			EnabledTrial:  true,
+		},
+		{
+			Key:           "celebrations",
+			Description:   "Birthdays, training anniversaries and 100-class milestones on dashboards, with a per-member opt-out (admin, coach, member)",
+			EnabledAdmin:  true,
+			EnabledCoach:  true,
+			EnabledMember: true,
+			EnabledTrial:  false,
+		},
+		{
+			Key:           "class_packs",
+			Description:   "Class packs and punch cards used up by check-ins, with kiosk warnings and an outstanding and expired packs report (admin)",
+			EnabledAdmin:  true,
+			EnabledCoach:  true, // kiosks launched by coaches warn members whose pack has run out
+			EnabledMember: false,
+			EnabledTrial:  false,
+		},
 	}
 }
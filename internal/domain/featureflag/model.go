@@ -1,6 +1,9 @@
 package featureflag
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // FeatureFlag holds server-enforced availability controls for a feature.
 //
@@ -20,10 +23,25 @@ type FeatureFlag struct {
 	// BetaOverride enables the feature for beta testers even if it is disabled
 	// for their role.
 	BetaOverride bool
+
+	// Targeting narrows or widens the role toggles for individual accounts.
+	// See Evaluate for the order the rules are applied in.
+	//
+	// RolloutPercent limits the feature to a stable share of the accounts whose
+	// role is enabled; 0 means no limit.
+	RolloutPercent int
+	AllowAccounts  []string
+	DenyAccounts   []string
+	// StartsAt and EndsAt bound when the feature is on; zero means unbounded.
+	StartsAt time.Time
+	EndsAt   time.Time
 }
 
 var (
-	ErrMissingKey = errors.New("feature flag key is required")
+	ErrMissingKey            = errors.New("feature flag key is required")
+	ErrInvalidRolloutPercent = errors.New("rollout percent must be between 0 and 100")
+	ErrInvalidWindow         = errors.New("feature flag must end after it starts")
+	ErrAllowedAndDenied      = errors.New("an account cannot be both allowed and denied")
 )
 
 // Validate checks required fields for a FeatureFlag.
@@ -33,11 +51,23 @@ func (f *FeatureFlag) Validate() error {
 	if f.Key == "" {
 		return ErrMissingKey
 	}
+	if f.RolloutPercent < 0 || f.RolloutPercent > 100 {
+		return ErrInvalidRolloutPercent
+	}
+	if !f.StartsAt.IsZero() && !f.EndsAt.IsZero() && !f.EndsAt.After(f.StartsAt) {
+		return ErrInvalidWindow
+	}
+	for _, id := range f.AllowAccounts {
+		if contains(f.DenyAccounts, id) {
+			return ErrAllowedAndDenied
+		}
+	}
 	return nil
 }
 
 // EnabledForRole returns true if the feature is enabled for the given role,
-// considering the beta override.
+// considering the beta override. It ignores account targeting; use Evaluate
+// to decide for a specific account.
 //
 // PRE: role is a valid session role string
 // INVARIANT: f is not mutated
@@ -45,16 +75,5 @@ func (f FeatureFlag) EnabledForRole(role string, isBetaTester bool) bool {
 	if isBetaTester && f.BetaOverride {
 		return true
 	}
-	switch role {
-	case "admin":
-		return f.EnabledAdmin
-	case "coach":
-		return f.EnabledCoach
-	case "member":
-		return f.EnabledMember
-	case "trial":
-		return f.EnabledTrial
-	default:
-		return false
-	}
+	return f.roleEnabled(role)
 }
@@ -0,0 +1,144 @@
+package featureflag
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+// Rules recorded in an Evaluation trace, in the order Evaluate applies them.
+const (
+	RuleDenyList = "deny_list"
+	RuleWindow   = "window"
+	RuleAllow    = "allow_list"
+	RuleBeta     = "beta_override"
+	RuleRole     = "role"
+	RuleRollout  = "rollout"
+)
+
+// Subject is the account a flag is evaluated for.
+type Subject struct {
+	AccountID  string
+	Role       string
+	BetaTester bool
+}
+
+// TraceStep records one rule checked while evaluating a flag.
+type TraceStep struct {
+	Rule    string
+	Matched bool
+	Detail  string
+}
+
+// Evaluation is the outcome of a flag for one account, with the rules that led to it.
+// The last step is the one that decided.
+type Evaluation struct {
+	Key     string
+	Enabled bool
+	Reason  string
+	Steps   []TraceStep
+}
+
+// Evaluate decides whether the feature is on for subject at now. Rules are
+// applied in order and the first that matches decides:
+//
+//  1. an account on the deny list is off;
+//  2. outside the StartsAt/EndsAt window the feature is off for everyone else;
+//  3. an account on the allow list is on;
+//  4. a beta tester is on when BetaOverride is set;
+//  5. an account whose role is disabled is off;
+//  6. otherwise the account is on if it falls inside RolloutPercent.
+//
+// PRE: subject.Role is a valid session role string
+// POST: Returns the decision and every rule checked to reach it
+// INVARIANT: f is not mutated
+func (f FeatureFlag) Evaluate(subject Subject, now time.Time) Evaluation {
+	e := Evaluation{Key: f.Key}
+	decide := func(rule string, on bool, detail string) Evaluation {
+		e.Steps = append(e.Steps, TraceStep{Rule: rule, Matched: true, Detail: detail})
+		e.Enabled = on
+		e.Reason = detail
+		return e
+	}
+	skip := func(rule, detail string) {
+		e.Steps = append(e.Steps, TraceStep{Rule: rule, Detail: detail})
+	}
+
+	if subject.AccountID != "" && contains(f.DenyAccounts, subject.AccountID) {
+		return decide(RuleDenyList, false, "account is on the deny list")
+	}
+	skip(RuleDenyList, "account is not on the deny list")
+
+	if !f.StartsAt.IsZero() && now.Before(f.StartsAt) {
+		return decide(RuleWindow, false, "feature starts at "+f.StartsAt.UTC().Format(time.RFC3339))
+	}
+	if !f.EndsAt.IsZero() && !now.Before(f.EndsAt) {
+		return decide(RuleWindow, false, "feature ended at "+f.EndsAt.UTC().Format(time.RFC3339))
+	}
+	skip(RuleWindow, "within the activation window")
+
+	if subject.AccountID != "" && contains(f.AllowAccounts, subject.AccountID) {
+		return decide(RuleAllow, true, "account is on the allow list")
+	}
+	skip(RuleAllow, "account is not on the allow list")
+
+	if subject.BetaTester && f.BetaOverride {
+		return decide(RuleBeta, true, "beta tester with beta override")
+	}
+	if f.BetaOverride {
+		skip(RuleBeta, "not a beta tester")
+	} else {
+		skip(RuleBeta, "beta override is off")
+	}
+
+	if !f.roleEnabled(subject.Role) {
+		return decide(RuleRole, false, fmt.Sprintf("disabled for role %q", subject.Role))
+	}
+	skip(RuleRole, fmt.Sprintf("enabled for role %q", subject.Role))
+
+	if f.RolloutPercent == 0 || f.RolloutPercent >= 100 {
+		return decide(RuleRollout, true, "no percentage rollout")
+	}
+	bucket := RolloutBucket(f.Key, subject.AccountID)
+	if bucket < f.RolloutPercent {
+		return decide(RuleRollout, true, fmt.Sprintf("bucket %d is inside the %d%% rollout", bucket, f.RolloutPercent))
+	}
+	return decide(RuleRollout, false, fmt.Sprintf("bucket %d is outside the %d%% rollout", bucket, f.RolloutPercent))
+}
+
+// RolloutBucket places an account in one of 100 buckets for a flag. The bucket
+// is stable, so raising a rollout only ever adds accounts, and it is salted with
+// the key so the same accounts are not always first into every rollout.
+// PRE: none
+// POST: Returns a value in [0, 100)
+func RolloutBucket(key, accountID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key + ":" + accountID))
+	return int(h.Sum32() % 100)
+}
+
+// roleEnabled reports the role toggle for role, ignoring the beta override.
+func (f FeatureFlag) roleEnabled(role string) bool {
+	switch role {
+	case "admin":
+		return f.EnabledAdmin
+	case "coach":
+		return f.EnabledCoach
+	case "member":
+		return f.EnabledMember
+	case "trial":
+		return f.EnabledTrial
+	default:
+		return false
+	}
+}
+
+// contains reports whether list holds id.
+func contains(list []string, id string) bool {
+	for _, v := range list {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package featureflag
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+var fixedNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// TestFeatureFlag_Evaluate verifies the order targeting rules are applied in.
+func TestFeatureFlag_Evaluate(t *testing.T) {
+	base := FeatureFlag{Key: "library", EnabledAdmin: true, EnabledCoach: true}
+	member := Subject{AccountID: "acct-1", Role: "member"}
+
+	tests := []struct {
+		name     string
+		modify   func(f *FeatureFlag)
+		subject  Subject
+		want     bool
+		wantRule string
+	}{
+		{"role disabled", func(f *FeatureFlag) {}, member, false, RuleRole},
+		{"role enabled without rollout", func(f *FeatureFlag) { f.EnabledMember = true }, member, true, RuleRollout},
+		{"allow list beats role", func(f *FeatureFlag) { f.AllowAccounts = []string{"acct-1"} }, member, true, RuleAllow},
+		{"deny list beats role", func(f *FeatureFlag) { f.EnabledMember = true; f.DenyAccounts = []string{"acct-1"} }, member, false, RuleDenyList},
+		{"deny list beats beta", func(f *FeatureFlag) { f.BetaOverride = true; f.DenyAccounts = []string{"acct-1"} }, Subject{AccountID: "acct-1", Role: "member", BetaTester: true}, false, RuleDenyList},
+		{"beta override", func(f *FeatureFlag) { f.BetaOverride = true }, Subject{AccountID: "acct-1", Role: "member", BetaTester: true}, true, RuleBeta},
+		{"not started", func(f *FeatureFlag) { f.StartsAt = fixedNow.Add(time.Hour) }, Subject{AccountID: "a", Role: "admin"}, false, RuleWindow},
+		{"ended", func(f *FeatureFlag) { f.EndsAt = fixedNow }, Subject{AccountID: "a", Role: "admin"}, false, RuleWindow},
+		{"window closes allow list too", func(f *FeatureFlag) { f.EndsAt = fixedNow; f.AllowAccounts = []string{"acct-1"} }, member, false, RuleWindow},
+		{"inside window", func(f *FeatureFlag) { f.StartsAt = fixedNow; f.EndsAt = fixedNow.Add(time.Hour) }, Subject{AccountID: "a", Role: "admin"}, true, RuleRollout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := base
+			tt.modify(&f)
+			got := f.Evaluate(tt.subject, fixedNow)
+			if got.Enabled != tt.want {
+				t.Errorf("Enabled = %v, want %v (%s)", got.Enabled, tt.want, got.Reason)
+			}
+			last := got.Steps[len(got.Steps)-1]
+			if last.Rule != tt.wantRule || !last.Matched {
+				t.Errorf("decided by %+v, want %s", last, tt.wantRule)
+			}
+			if got.Reason != last.Detail {
+				t.Errorf("Reason = %q, want the deciding step's detail %q", got.Reason, last.Detail)
+			}
+		})
+	}
+}
+
+// TestFeatureFlag_Evaluate_Rollout verifies a percentage rollout admits roughly that share
+// of accounts and that raising it keeps everyone already admitted.
+func TestFeatureFlag_Evaluate_Rollout(t *testing.T) {
+	f := FeatureFlag{Key: "library", EnabledMember: true, RolloutPercent: 25}
+	wider := f
+	wider.RolloutPercent = 60
+
+	on := 0
+	for i := 0; i < 1000; i++ {
+		s := Subject{AccountID: fmt.Sprintf("acct-%d", i), Role: "member"}
+		narrow := f.Evaluate(s, fixedNow).Enabled
+		if narrow {
+			on++
+		}
+		if narrow && !wider.Evaluate(s, fixedNow).Enabled {
+			t.Fatalf("%s lost the feature when the rollout was raised", s.AccountID)
+		}
+	}
+	if on < 180 || on > 320 {
+		t.Errorf("expected about 250 of 1000 accounts in a 25%% rollout, got %d", on)
+	}
+}
+
+// TestRolloutBucket verifies buckets are stable and salted by flag key.
+func TestRolloutBucket(t *testing.T) {
+	if RolloutBucket("library", "acct-1") != RolloutBucket("library", "acct-1") {
+		t.Fatal("bucket is not stable")
+	}
+	differs := false
+	for i := 0; i < 20; i++ {
+		id := fmt.Sprintf("acct-%d", i)
+		b := RolloutBucket("library", id)
+		if b < 0 || b >= 100 {
+			t.Fatalf("bucket %d out of range", b)
+		}
+		if b != RolloutBucket("messages", id) {
+			differs = true
+		}
+	}
+	if !differs {
+		t.Error("expected buckets to differ between flags")
+	}
+}
+
+// TestFeatureFlag_Validate_Targeting verifies targeting fields are validated.
+func TestFeatureFlag_Validate_Targeting(t *testing.T) {
+	tests := []struct {
+		name string
+		ff   FeatureFlag
+		want error
+	}{
+		{"valid", FeatureFlag{Key: "k", RolloutPercent: 50, StartsAt: fixedNow, EndsAt: fixedNow.Add(time.Hour)}, nil},
+		{"negative percent", FeatureFlag{Key: "k", RolloutPercent: -1}, ErrInvalidRolloutPercent},
+		{"percent over 100", FeatureFlag{Key: "k", RolloutPercent: 101}, ErrInvalidRolloutPercent},
+		{"ends before start", FeatureFlag{Key: "k", StartsAt: fixedNow, EndsAt: fixedNow}, ErrInvalidWindow},
+		{"allowed and denied", FeatureFlag{Key: "k", AllowAccounts: []string{"a"}, DenyAccounts: []string{"a"}}, ErrAllowedAndDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.ff.Validate(); err != tt.want {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
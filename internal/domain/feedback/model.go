@@ -0,0 +1,94 @@
+package feedback
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Rating limits and the thresholds that keep feedback anonymous.
+const (
+	MinScore         = 1
+	MaxScore         = 5
+	MaxCommentLength = 500
+	RatingWindow     = 24 * time.Hour // a class can be rated this long after checking in
+
+	// MinRatingsForAverage is the fewest ratings a class type, coach or topic needs before its
+	// average is shown; below it one member's score could be worked out.
+	MinRatingsForAverage = 3
+	// MinClassSizeForComments is the fewest members who must have checked in to a class before
+	// its comments are shown; comments from smaller classes are withheld.
+	MinClassSizeForComments = 5
+)
+
+// Domain errors
+var (
+	ErrAttendanceRequired = errors.New("a rating must be for an attendance")
+	ErrInvalidScore       = errors.New("score must be between 1 and 5")
+	ErrCommentTooLong     = errors.New("comment cannot exceed 500 characters")
+	ErrRatingClosed       = errors.New("classes can only be rated within 24 hours of checking in")
+	ErrAlreadyRated       = errors.New("you have already rated this class")
+	ErrNoClass            = errors.New("only check-ins to a scheduled class can be rated")
+)
+
+// Rating is a member's 1-5 score for a class they checked in to, with an optional comment.
+// It refers to the attendance rather than the member, and reports only ever show ratings in
+// aggregate, so coaches see how classes land without seeing who said what.
+// INVARIANT: at most one rating per attendance
+type Rating struct {
+	ID           string
+	AttendanceID string
+	ScheduleID   string
+	ClassTypeID  string
+	CoachID      string // the class's regular coach at the time of rating; empty if unassigned
+	ClassDate    string // YYYY-MM-DD
+	Score        int
+	Comment      string
+	CreatedAt    time.Time
+	TopicIDs     []string // rotor topics taught in the class; filled when listing
+}
+
+// NewRating scores the class an attendance was for.
+// PRE: checkIn is the attendance's check-in time
+// POST: Returns a valid rating with a trimmed comment, or ErrRatingClosed once RatingWindow
+// has passed, or a validation error
+func NewRating(id, attendanceID string, checkIn time.Time, score int, comment string, now time.Time) (Rating, error) {
+	if now.Sub(checkIn) > RatingWindow {
+		return Rating{}, ErrRatingClosed
+	}
+	r := Rating{
+		ID:           id,
+		AttendanceID: attendanceID,
+		ClassDate:    checkIn.Format("2006-01-02"),
+		Score:        score,
+		Comment:      strings.TrimSpace(comment),
+		CreatedAt:    now,
+	}
+	if err := r.Validate(); err != nil {
+		return Rating{}, err
+	}
+	return r, nil
+}
+
+// Validate checks if the Rating has valid data.
+// PRE: Rating struct is populated
+// POST: Returns nil if valid, error otherwise
+func (r *Rating) Validate() error {
+	if r.AttendanceID == "" {
+		return ErrAttendanceRequired
+	}
+	if r.Score < MinScore || r.Score > MaxScore {
+		return ErrInvalidScore
+	}
+	if len(r.Comment) > MaxCommentLength {
+		return ErrCommentTooLong
+	}
+	return nil
+}
+
+// CanRate reports whether an attendance checked in at checkIn can still be rated at now.
+// PRE: none
+// POST: Returns true within RatingWindow of checkIn
+func CanRate(checkIn, now time.Time) bool {
+	return !checkIn.IsZero() && now.Sub(checkIn) <= RatingWindow
+}
@@ -0,0 +1,41 @@
+package feedback_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/feedback"
+)
+
+// TestNewRating verifies scores and comments are checked and the rating window closes 24 hours
+// after check-in.
+func TestNewRating(t *testing.T) {
+	checkIn := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		score   int
+		comment string
+		now     time.Time
+		wantErr error
+	}{
+		{name: "valid", score: 5, comment: "  Loved the drilling  ", now: checkIn.Add(2 * time.Hour)},
+		{name: "last minute", score: 1, now: checkIn.Add(feedback.RatingWindow)},
+		{name: "too late", score: 4, now: checkIn.Add(feedback.RatingWindow + time.Minute), wantErr: feedback.ErrRatingClosed},
+		{name: "score too low", score: 0, now: checkIn, wantErr: feedback.ErrInvalidScore},
+		{name: "score too high", score: 6, now: checkIn, wantErr: feedback.ErrInvalidScore},
+		{name: "long comment", score: 3, comment: strings.Repeat("x", feedback.MaxCommentLength+1), now: checkIn, wantErr: feedback.ErrCommentTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := feedback.NewRating("r1", "a1", checkIn, tt.score, tt.comment, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewRating() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (r.ClassDate != "2026-03-02" || r.Comment != strings.TrimSpace(tt.comment)) {
+				t.Errorf("NewRating() = %+v", r)
+			}
+		})
+	}
+}
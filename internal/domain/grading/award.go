@@ -0,0 +1,82 @@
+package grading
+
+import (
+	"errors"
+	"time"
+)
+
+// Award kinds: what a bulk award gives each member on grading day.
+const (
+	AwardStripe = "stripe" // one more stripe on the member's current belt
+	AwardBelt   = "belt"   // a new belt, starting with no stripes
+)
+
+// MaxStripes is the most stripes a belt carries.
+const MaxStripes = 4
+
+// MaxAwardMembers caps how many members one bulk award may cover.
+const MaxAwardMembers = 200
+
+// MaxAwardNoteLength caps the note shared by every member of a bulk award.
+const MaxAwardNoteLength = 2000
+
+// Award errors.
+var (
+	ErrInvalidAwardKind = errors.New("award must be stripe or belt")
+	ErrNoAwardMembers   = errors.New("choose at least one member")
+	ErrTooManyAwards    = errors.New("too many members in one award")
+	ErrAwardNoteTooLong = errors.New("note cannot exceed 2000 characters")
+	ErrStripesFull      = errors.New("already has 4 stripes; award a belt instead")
+	ErrNotPromotion     = errors.New("belt is not above the member's current belt")
+	ErrAwardInFuture    = errors.New("award date cannot be in the future")
+	ErrAwardBeforeRank  = errors.New("award date is before the member's last promotion")
+)
+
+// Rank is a member's belt and stripes.
+type Rank struct {
+	Belt       string
+	Stripe     int
+	PromotedAt time.Time // when the rank was awarded; zero for a member with no records
+}
+
+// CurrentRank returns the rank given by a member's latest effective record: a white belt
+// with no stripes when there is none.
+// PRE: none
+// POST: Superseded and voided records are ignored
+func CurrentRank(records []Record) Rank {
+	var latest *Record
+	for i, r := range records {
+		if r.IsEffective() && (latest == nil || r.PromotedAt.After(latest.PromotedAt)) {
+			latest = &records[i]
+		}
+	}
+	if latest == nil {
+		return Rank{Belt: BeltWhite}
+	}
+	return Rank{Belt: latest.Belt, Stripe: latest.Stripe, PromotedAt: latest.PromotedAt}
+}
+
+// AwardRank returns the rank a member of program holding current reaches with an award of
+// kind: one more stripe, or belt with no stripes.
+// The returned rank carries no date.
+// PRE: program is "adults" or "kids"
+// POST: Returns ErrStripesFull for a stripe past MaxStripes and ErrNotPromotion for a belt
+// outside the program's progression or not above the current one
+func AwardRank(program string, current Rank, kind, belt string) (Rank, error) {
+	switch kind {
+	case AwardStripe:
+		if current.Stripe >= MaxStripes {
+			return Rank{}, ErrStripesFull
+		}
+		return Rank{Belt: current.Belt, Stripe: current.Stripe + 1}, nil
+	case AwardBelt:
+		if !isValidBelt(belt) {
+			return Rank{}, ErrInvalidBelt
+		}
+		if !BeltAtLeast(program, belt, current.Belt) || BeltAtLeast(program, current.Belt, belt) {
+			return Rank{}, ErrNotPromotion
+		}
+		return Rank{Belt: belt}, nil
+	}
+	return Rank{}, ErrInvalidAwardKind
+}
@@ -0,0 +1,60 @@
+package grading_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/grading"
+)
+
+// TestCurrentRank verifies the latest effective record sets the rank and no records mean white.
+func TestCurrentRank(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	records := []grading.Record{
+		{ID: "r1", Belt: grading.BeltBlue, Stripe: 2, PromotedAt: day(1)},
+		{ID: "r2", Belt: grading.BeltBlue, Stripe: 3, PromotedAt: day(20), Voided: true},
+		{ID: "r3", Belt: grading.BeltWhite, Stripe: 4, PromotedAt: day(0)},
+	}
+	if got := grading.CurrentRank(records); got != (grading.Rank{Belt: grading.BeltBlue, Stripe: 2, PromotedAt: day(1)}) {
+		t.Errorf("CurrentRank = %+v, want blue with 2 stripes", got)
+	}
+	if got := grading.CurrentRank(nil); got != (grading.Rank{Belt: grading.BeltWhite}) {
+		t.Errorf("CurrentRank(nil) = %+v, want white", got)
+	}
+}
+
+// TestAwardRank verifies stripes stop at four and belts must move up the member's progression.
+func TestAwardRank(t *testing.T) {
+	tests := []struct {
+		name    string
+		program string
+		current grading.Rank
+		kind    string
+		belt    string
+		want    grading.Rank
+		wantErr error
+	}{
+		{"stripe", "adults", grading.Rank{Belt: grading.BeltBlue, Stripe: 1}, grading.AwardStripe, "", grading.Rank{Belt: grading.BeltBlue, Stripe: 2}, nil},
+		{"fifth stripe", "adults", grading.Rank{Belt: grading.BeltBlue, Stripe: 4}, grading.AwardStripe, "", grading.Rank{}, grading.ErrStripesFull},
+		{"next belt", "adults", grading.Rank{Belt: grading.BeltWhite, Stripe: 4}, grading.AwardBelt, grading.BeltBlue, grading.Rank{Belt: grading.BeltBlue}, nil},
+		{"skip a belt", "adults", grading.Rank{Belt: grading.BeltWhite}, grading.AwardBelt, grading.BeltPurple, grading.Rank{Belt: grading.BeltPurple}, nil},
+		{"same belt", "adults", grading.Rank{Belt: grading.BeltBlue}, grading.AwardBelt, grading.BeltBlue, grading.Rank{}, grading.ErrNotPromotion},
+		{"lower belt", "adults", grading.Rank{Belt: grading.BeltPurple}, grading.AwardBelt, grading.BeltBlue, grading.Rank{}, grading.ErrNotPromotion},
+		{"kids belt for an adult", "adults", grading.Rank{Belt: grading.BeltWhite}, grading.AwardBelt, grading.BeltGrey, grading.Rank{}, grading.ErrNotPromotion},
+		{"kids belt", "kids", grading.Rank{Belt: grading.BeltWhite}, grading.AwardBelt, grading.BeltGrey, grading.Rank{Belt: grading.BeltGrey}, nil},
+		{"unknown belt", "adults", grading.Rank{Belt: grading.BeltWhite}, grading.AwardBelt, "rainbow", grading.Rank{}, grading.ErrInvalidBelt},
+		{"unknown kind", "adults", grading.Rank{Belt: grading.BeltWhite}, "tip", "", grading.Rank{}, grading.ErrInvalidAwardKind},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := grading.AwardRank(tt.program, tt.current, tt.kind, tt.belt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("rank = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package grading
+
+import (
+	"errors"
+	"time"
+)
+
+// Ceremony statuses
+const (
+	CeremonyPlanned   = "planned"
+	CeremonyCompleted = "completed" // promotions recorded
+)
+
+// MaxCeremonyTitleLength caps a ceremony's title, matching the calendar event it creates.
+const MaxCeremonyTitleLength = 200
+
+// Ceremony errors
+var (
+	ErrEmptyCeremonyTitle    = errors.New("ceremony title is required")
+	ErrCeremonyTitleLength   = errors.New("ceremony title cannot exceed 200 characters")
+	ErrEmptyCeremonyDate     = errors.New("ceremony date is required")
+	ErrInvalidCeremonyStatus = errors.New("ceremony status must be one of: planned, completed")
+	ErrCeremonyCompleted     = errors.New("ceremony has already been completed")
+	ErrNotAttachable         = errors.New("only open proposals can be attached to a ceremony")
+)
+
+// Ceremony is a grading event: the day promotions are presented. Planning one creates its
+// calendar event and a notice for the invited members; the proposals being awarded are
+// scheduled onto that calendar event, and completing the ceremony records their promotions.
+type Ceremony struct {
+	ID          string
+	Title       string
+	Location    string
+	Date        time.Time // the day of the ceremony; promotions are recorded on it
+	EventID     string    // the calendar event created for the ceremony
+	NoticeID    string    // the notice telling invited members about it
+	Status      string    // planned, completed
+	CreatedBy   string    // AccountID of the admin who planned it
+	CreatedAt   time.Time
+	CompletedBy string // AccountID of the admin who completed it
+	CompletedAt time.Time
+}
+
+// Validate checks if the Ceremony has valid data.
+// PRE: Ceremony struct is populated
+// POST: Returns nil if valid, error otherwise
+func (c *Ceremony) Validate() error {
+	if c.Title == "" {
+		return ErrEmptyCeremonyTitle
+	}
+	if len(c.Title) > MaxCeremonyTitleLength {
+		return ErrCeremonyTitleLength
+	}
+	if c.Date.IsZero() {
+		return ErrEmptyCeremonyDate
+	}
+	if c.Status != CeremonyPlanned && c.Status != CeremonyCompleted {
+		return ErrInvalidCeremonyStatus
+	}
+	return nil
+}
+
+// IsPlanned returns true until the ceremony is completed.
+// INVARIANT: Status field is not mutated
+func (c *Ceremony) IsPlanned() bool {
+	return c.Status == CeremonyPlanned
+}
+
+// Complete marks the ceremony as held.
+// PRE: Ceremony is planned, adminID is non-empty
+// POST: Status is completed, CompletedBy and CompletedAt are set
+func (c *Ceremony) Complete(adminID string, now time.Time) error {
+	if !c.IsPlanned() {
+		return ErrCeremonyCompleted
+	}
+	if adminID == "" {
+		return errors.New("admin ID is required to complete a ceremony")
+	}
+	c.Status = CeremonyCompleted
+	c.CompletedBy = adminID
+	c.CompletedAt = now
+	return nil
+}
@@ -0,0 +1,53 @@
+package grading_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/grading"
+)
+
+// TestCeremony_Validate tests validation of a promotion ceremony.
+func TestCeremony_Validate(t *testing.T) {
+	valid := grading.Ceremony{ID: "c1", Title: "End of term promotions", Date: time.Date(2026, 11, 14, 0, 0, 0, 0, time.UTC), Status: grading.CeremonyPlanned}
+	tests := []struct {
+		name   string
+		modify func(*grading.Ceremony)
+		want   error
+	}{
+		{"valid", func(*grading.Ceremony) {}, nil},
+		{"no title", func(c *grading.Ceremony) { c.Title = "" }, grading.ErrEmptyCeremonyTitle},
+		{"long title", func(c *grading.Ceremony) { c.Title = strings.Repeat("x", 201) }, grading.ErrCeremonyTitleLength},
+		{"no date", func(c *grading.Ceremony) { c.Date = time.Time{} }, grading.ErrEmptyCeremonyDate},
+		{"bad status", func(c *grading.Ceremony) { c.Status = "cancelled" }, grading.ErrInvalidCeremonyStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.modify(&c)
+			if err := c.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestCeremony_Complete tests that a ceremony completes once, by an admin.
+func TestCeremony_Complete(t *testing.T) {
+	c := grading.Ceremony{Status: grading.CeremonyPlanned}
+	if err := c.Complete("", time.Now()); err == nil {
+		t.Error("expected an error without an admin")
+	}
+	now := time.Date(2026, 11, 14, 12, 0, 0, 0, time.UTC)
+	if err := c.Complete("admin-1", now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.IsPlanned() || c.CompletedBy != "admin-1" || !c.CompletedAt.Equal(now) {
+		t.Errorf("after Complete: %+v", c)
+	}
+	if err := c.Complete("admin-1", now); !errors.Is(err, grading.ErrCeremonyCompleted) {
+		t.Errorf("second Complete = %v, want ErrCeremonyCompleted", err)
+	}
+}
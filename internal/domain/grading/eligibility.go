@@ -0,0 +1,261 @@
+package grading
+
+import (
+	"errors"
+	"time"
+)
+
+// Eligibility criterion kinds. Every criterion in a Rule must be met.
+const (
+	CriterionMatHours          = "mat_hours"           // flight time, in hours
+	CriterionAttendancePct     = "attendance_pct"      // current term attendance; kids graded by sessions
+	CriterionMonthsAtBelt      = "months_at_belt"      // whole months since the last promotion
+	CriterionSessionsWithCoach = "sessions_with_coach" // classes on the current belt taught by CoachID
+)
+
+// DefaultKidsAttendancePct is the term attendance kids need when no rule or config sets one.
+const DefaultKidsAttendancePct = 80.0
+
+// MaxCriteria caps the criteria in one rule.
+const MaxCriteria = 10
+
+// Eligibility errors
+var (
+	ErrEmptyProgram        = errors.New("program is required")
+	ErrNoCriteria          = errors.New("a rule needs at least one criterion")
+	ErrTooManyCriteria     = errors.New("a rule cannot have more than 10 criteria")
+	ErrInvalidCriterion    = errors.New("criterion must be one of: mat_hours, attendance_pct, months_at_belt, sessions_with_coach")
+	ErrInvalidMinimum      = errors.New("criterion minimum must be greater than zero")
+	ErrAttendanceOver100   = errors.New("attendance percentage cannot exceed 100")
+	ErrAttendanceForAdults = errors.New("attendance_pct applies to the kids program only")
+	ErrEmptyCoachID        = errors.New("sessions_with_coach needs a coach")
+	ErrUnexpectedCoachID   = errors.New("only sessions_with_coach names a coach")
+	ErrDuplicateCriterion  = errors.New("each criterion can appear once, or once per coach")
+)
+
+// Criterion is one requirement of a Rule: the member's measure must reach Min.
+type Criterion struct {
+	Kind    string
+	Min     float64
+	CoachID string // AccountID of the coach; sessions_with_coach only
+}
+
+// Rule is the set of criteria a member of a program must all meet to be eligible for a belt.
+// A belt without a Rule falls back to the thresholds in its Config.
+type Rule struct {
+	ID        string
+	Program   string // "adults" or "kids"
+	Belt      string // target belt
+	Criteria  []Criterion
+	UpdatedBy string // AccountID of the admin
+	UpdatedAt time.Time
+}
+
+// Validate checks if the Rule has valid data.
+// PRE: Rule struct is populated
+// POST: Returns nil if valid, error otherwise
+func (r *Rule) Validate() error {
+	if r.Program == "" {
+		return ErrEmptyProgram
+	}
+	if !isValidBelt(r.Belt) {
+		return ErrInvalidBelt
+	}
+	if len(r.Criteria) == 0 {
+		return ErrNoCriteria
+	}
+	if len(r.Criteria) > MaxCriteria {
+		return ErrTooManyCriteria
+	}
+	seen := map[[2]string]bool{}
+	for _, c := range r.Criteria {
+		if err := c.validate(r.Program); err != nil {
+			return err
+		}
+		key := [2]string{c.Kind, c.CoachID}
+		if seen[key] {
+			return ErrDuplicateCriterion
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// validate checks one criterion of a rule for the given program.
+func (c Criterion) validate(program string) error {
+	switch c.Kind {
+	case CriterionMatHours, CriterionMonthsAtBelt:
+	case CriterionAttendancePct:
+		if program != "kids" {
+			return ErrAttendanceForAdults
+		}
+		if c.Min > 100 {
+			return ErrAttendanceOver100
+		}
+	case CriterionSessionsWithCoach:
+		if c.CoachID == "" {
+			return ErrEmptyCoachID
+		}
+	default:
+		return ErrInvalidCriterion
+	}
+	if c.Kind != CriterionSessionsWithCoach && c.CoachID != "" {
+		return ErrUnexpectedCoachID
+	}
+	if c.Min <= 0 {
+		return ErrInvalidMinimum
+	}
+	return nil
+}
+
+// Needs reports whether the rule has a criterion of the given kind.
+// INVARIANT: Rule is not mutated
+func (r Rule) Needs(kind string) bool {
+	for _, c := range r.Criteria {
+		if c.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfigRule builds the rule a belt's Config implies: its mat hours, or for kids graded
+// by sessions its term attendance (DefaultKidsAttendancePct when unset).
+// POST: Returns a rule with at most one criterion, or none when the config sets no hours
+func ConfigRule(c Config, byAttendance bool) Rule {
+	rule := Rule{Program: c.Program, Belt: c.Belt}
+	if byAttendance {
+		pct := c.AttendancePct
+		if pct <= 0 {
+			pct = DefaultKidsAttendancePct
+		}
+		rule.Criteria = []Criterion{{Kind: CriterionAttendancePct, Min: pct}}
+	} else if c.FlightTimeHours > 0 {
+		rule.Criteria = []Criterion{{Kind: CriterionMatHours, Min: c.FlightTimeHours}}
+	}
+	return rule
+}
+
+// ForMember returns the rule as it applies to one member. A kid's grading metric picks
+// the measure: attendance_pct is dropped for members graded by hours and mat_hours for
+// kids graded by sessions. The member's overrides then replace, or add, that measure.
+// PRE: mc is the member's override for r.Belt, or the zero MemberConfig
+// POST: Returns a copy; r is not mutated
+func (r Rule) ForMember(byAttendance bool, mc MemberConfig) Rule {
+	measure, override := CriterionMatHours, mc.FlightTimeHours
+	if byAttendance {
+		measure, override = CriterionAttendancePct, mc.AttendancePct
+	}
+	out := r
+	out.Criteria = nil
+	found := false
+	for _, c := range r.Criteria {
+		if c.Kind == CriterionMatHours || c.Kind == CriterionAttendancePct {
+			if c.Kind != measure {
+				continue
+			}
+			found = true
+			if override > 0 {
+				c.Min = override
+			}
+		}
+		out.Criteria = append(out.Criteria, c)
+	}
+	if !found && override > 0 {
+		out.Criteria = append(out.Criteria, Criterion{Kind: measure, Min: override})
+	}
+	return out
+}
+
+// Facts are a member's measures, gathered for the criteria of the rule being evaluated.
+type Facts struct {
+	MatHours          float64
+	AttendancePct     float64
+	MonthsAtBelt      int
+	SessionsWithCoach map[string]int // classes on the current belt, by coach AccountID
+}
+
+// Check is the outcome of one criterion.
+type Check struct {
+	Kind    string
+	CoachID string
+	Min     float64
+	Actual  float64
+	Met     bool
+}
+
+// Evaluation is the outcome of a rule for one member.
+type Evaluation struct {
+	Checks   []Check
+	Eligible bool    // every check met; a rule without criteria is always met
+	Progress float64 // 0–100: the least-met criterion's share of its minimum
+}
+
+// Evaluate checks every criterion of the rule against the member's facts.
+// POST: Eligible only when all checks are met; Progress is 100 for a rule without criteria
+// INVARIANT: Rule is not mutated
+func (r Rule) Evaluate(f Facts) Evaluation {
+	eval := Evaluation{Eligible: true, Progress: 100}
+	for _, c := range r.Criteria {
+		check := Check{Kind: c.Kind, CoachID: c.CoachID, Min: c.Min}
+		switch c.Kind {
+		case CriterionMatHours:
+			check.Actual = f.MatHours
+		case CriterionAttendancePct:
+			check.Actual = f.AttendancePct
+		case CriterionMonthsAtBelt:
+			check.Actual = float64(f.MonthsAtBelt)
+		case CriterionSessionsWithCoach:
+			check.Actual = float64(f.SessionsWithCoach[c.CoachID])
+		}
+		check.Met = check.Actual >= c.Min
+		if !check.Met {
+			eval.Eligible = false
+		}
+		if pct := progressPct(check.Actual, c.Min); pct < eval.Progress {
+			eval.Progress = pct
+		}
+		eval.Checks = append(eval.Checks, check)
+	}
+	return eval
+}
+
+// Unmet returns the checks that were not met.
+// INVARIANT: Evaluation is not mutated
+func (e Evaluation) Unmet() []Check {
+	var unmet []Check
+	for _, c := range e.Checks {
+		if !c.Met {
+			unmet = append(unmet, c)
+		}
+	}
+	return unmet
+}
+
+// MonthsBetween counts the whole calendar months from since to now.
+// POST: Returns 0 when now is before since
+func MonthsBetween(since, now time.Time) int {
+	if now.Before(since) {
+		return 0
+	}
+	months := (now.Year()-since.Year())*12 + int(now.Month()-since.Month())
+	if now.Day() < since.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
+
+// progressPct is actual as a percentage of min, capped at 100.
+func progressPct(actual, min float64) float64 {
+	if min <= 0 {
+		return 100
+	}
+	pct := actual / min * 100
+	if pct > 100 {
+		return 100
+	}
+	return pct
+}
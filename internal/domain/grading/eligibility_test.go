@@ -0,0 +1,145 @@
+package grading_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/grading"
+)
+
+// TestRule_Validate tests validation of eligibility rules.
+func TestRule_Validate(t *testing.T) {
+	hours := grading.Criterion{Kind: grading.CriterionMatHours, Min: 150}
+	months := grading.Criterion{Kind: grading.CriterionMonthsAtBelt, Min: 24}
+	coach := grading.Criterion{Kind: grading.CriterionSessionsWithCoach, Min: 10, CoachID: "coach-1"}
+	tests := []struct {
+		name string
+		rule grading.Rule
+		want error
+	}{
+		{"hours, months and coach", grading.Rule{Program: "adults", Belt: grading.BeltBlue, Criteria: []grading.Criterion{hours, months, coach}}, nil},
+		{"two coaches", grading.Rule{Program: "adults", Belt: grading.BeltBlue, Criteria: []grading.Criterion{coach, {Kind: grading.CriterionSessionsWithCoach, Min: 5, CoachID: "coach-2"}}}, nil},
+		{"kids attendance", grading.Rule{Program: "kids", Belt: grading.BeltGrey, Criteria: []grading.Criterion{{Kind: grading.CriterionAttendancePct, Min: 75}}}, nil},
+		{"no program", grading.Rule{Belt: grading.BeltBlue, Criteria: []grading.Criterion{hours}}, grading.ErrEmptyProgram},
+		{"bad belt", grading.Rule{Program: "adults", Belt: "pink", Criteria: []grading.Criterion{hours}}, grading.ErrInvalidBelt},
+		{"no criteria", grading.Rule{Program: "adults", Belt: grading.BeltBlue}, grading.ErrNoCriteria},
+		{"unknown kind", grading.Rule{Program: "adults", Belt: grading.BeltBlue, Criteria: []grading.Criterion{{Kind: "age", Min: 16}}}, grading.ErrInvalidCriterion},
+		{"zero minimum", grading.Rule{Program: "adults", Belt: grading.BeltBlue, Criteria: []grading.Criterion{{Kind: grading.CriterionMatHours}}}, grading.ErrInvalidMinimum},
+		{"adult attendance", grading.Rule{Program: "adults", Belt: grading.BeltBlue, Criteria: []grading.Criterion{{Kind: grading.CriterionAttendancePct, Min: 80}}}, grading.ErrAttendanceForAdults},
+		{"attendance over 100", grading.Rule{Program: "kids", Belt: grading.BeltGrey, Criteria: []grading.Criterion{{Kind: grading.CriterionAttendancePct, Min: 120}}}, grading.ErrAttendanceOver100},
+		{"coach missing", grading.Rule{Program: "adults", Belt: grading.BeltBlue, Criteria: []grading.Criterion{{Kind: grading.CriterionSessionsWithCoach, Min: 10}}}, grading.ErrEmptyCoachID},
+		{"coach on hours", grading.Rule{Program: "adults", Belt: grading.BeltBlue, Criteria: []grading.Criterion{{Kind: grading.CriterionMatHours, Min: 10, CoachID: "coach-1"}}}, grading.ErrUnexpectedCoachID},
+		{"duplicate", grading.Rule{Program: "adults", Belt: grading.BeltBlue, Criteria: []grading.Criterion{hours, hours}}, grading.ErrDuplicateCriterion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Validate(); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRule_Evaluate tests that every criterion must be met and progress follows the weakest.
+func TestRule_Evaluate(t *testing.T) {
+	rule := grading.Rule{Program: "adults", Belt: grading.BeltBlue, Criteria: []grading.Criterion{
+		{Kind: grading.CriterionMatHours, Min: 100},
+		{Kind: grading.CriterionMonthsAtBelt, Min: 12},
+		{Kind: grading.CriterionSessionsWithCoach, Min: 10, CoachID: "coach-1"},
+	}}
+	tests := []struct {
+		name         string
+		facts        grading.Facts
+		wantEligible bool
+		wantProgress float64
+		wantUnmet    int
+	}{
+		{"all met", grading.Facts{MatHours: 120, MonthsAtBelt: 14, SessionsWithCoach: map[string]int{"coach-1": 10}}, true, 100, 0},
+		{"too few sessions with the coach", grading.Facts{MatHours: 120, MonthsAtBelt: 14, SessionsWithCoach: map[string]int{"coach-1": 4, "coach-2": 30}}, false, 40, 1},
+		{"nothing yet", grading.Facts{}, false, 0, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rule.Evaluate(tt.facts)
+			if got.Eligible != tt.wantEligible || got.Progress != tt.wantProgress || len(got.Unmet()) != tt.wantUnmet {
+				t.Errorf("Evaluate() = eligible %v, progress %v, %d unmet; want %v, %v, %d", got.Eligible, got.Progress, len(got.Unmet()), tt.wantEligible, tt.wantProgress, tt.wantUnmet)
+			}
+		})
+	}
+
+	if got := (grading.Rule{}).Evaluate(grading.Facts{}); !got.Eligible || got.Progress != 100 {
+		t.Errorf("empty rule = %+v, want eligible at 100", got)
+	}
+}
+
+// TestConfigRule tests the rule implied by a belt's thresholds.
+func TestConfigRule(t *testing.T) {
+	config := grading.Config{Program: "kids", Belt: grading.BeltGrey, FlightTimeHours: 40}
+	tests := []struct {
+		name         string
+		config       grading.Config
+		byAttendance bool
+		want         []grading.Criterion
+	}{
+		{"hours", config, false, []grading.Criterion{{Kind: grading.CriterionMatHours, Min: 40}}},
+		{"default attendance", config, true, []grading.Criterion{{Kind: grading.CriterionAttendancePct, Min: grading.DefaultKidsAttendancePct}}},
+		{"no hours set", grading.Config{Program: "adults", Belt: grading.BeltBlue}, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := grading.ConfigRule(tt.config, tt.byAttendance); !reflect.DeepEqual(got.Criteria, tt.want) {
+				t.Errorf("ConfigRule() = %+v, want %+v", got.Criteria, tt.want)
+			}
+		})
+	}
+}
+
+// TestRule_ForMember tests that a member's metric picks the measure and overrides replace it.
+func TestRule_ForMember(t *testing.T) {
+	months := grading.Criterion{Kind: grading.CriterionMonthsAtBelt, Min: 6}
+	rule := grading.Rule{Program: "kids", Belt: grading.BeltGrey, Criteria: []grading.Criterion{
+		{Kind: grading.CriterionMatHours, Min: 40}, {Kind: grading.CriterionAttendancePct, Min: 80}, months,
+	}}
+	tests := []struct {
+		name         string
+		rule         grading.Rule
+		byAttendance bool
+		override     grading.MemberConfig
+		want         []grading.Criterion
+	}{
+		{"graded by hours", rule, false, grading.MemberConfig{}, []grading.Criterion{{Kind: grading.CriterionMatHours, Min: 40}, months}},
+		{"graded by sessions", rule, true, grading.MemberConfig{}, []grading.Criterion{{Kind: grading.CriterionAttendancePct, Min: 80}, months}},
+		{"hours override", rule, false, grading.MemberConfig{FlightTimeHours: 30, AttendancePct: 50}, []grading.Criterion{{Kind: grading.CriterionMatHours, Min: 30}, months}},
+		{"override adds the measure", grading.Rule{Criteria: []grading.Criterion{months}}, true, grading.MemberConfig{AttendancePct: 60}, []grading.Criterion{months, {Kind: grading.CriterionAttendancePct, Min: 60}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.ForMember(tt.byAttendance, tt.override); !reflect.DeepEqual(got.Criteria, tt.want) {
+				t.Errorf("ForMember() = %+v, want %+v", got.Criteria, tt.want)
+			}
+		})
+	}
+	if len(rule.Criteria) != 3 {
+		t.Error("ForMember mutated the rule")
+	}
+}
+
+// TestMonthsBetween tests whole calendar months.
+func TestMonthsBetween(t *testing.T) {
+	since := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		now  time.Time
+		want int
+	}{
+		{time.Date(2025, 2, 14, 0, 0, 0, 0, time.UTC), 0},
+		{time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC), 1},
+		{time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC), 12},
+		{time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), 0},
+	}
+	for _, tt := range tests {
+		if got := grading.MonthsBetween(since, tt.now); got != tt.want {
+			t.Errorf("MonthsBetween(%s) = %d, want %d", tt.now.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
@@ -25,16 +25,21 @@ const (
 
 // Proposal statuses
 const (
-	ProposalPending  = "pending"
-	ProposalApproved = "approved"
-	ProposalRejected = "rejected"
+	ProposalPending   = "pending"
+	ProposalScheduled = "scheduled" // awaiting decision on a grading day
+	ProposalApproved  = "approved"
+	ProposalRejected  = "rejected"
 )
 
+// MaxCommentLength caps a proposal comment.
+const MaxCommentLength = 2000
+
 // Promotion methods
 const (
 	MethodStandard = "standard"
 	MethodOverride = "override"
 	MethodInferred = "inferred"
+	MethodImport   = "import" // a belt earned before the club used this app, entered from its old records
 )
 
 // AdultBelts defines the adult belt progression order.
@@ -48,8 +53,15 @@ var (
 	ErrEmptyMemberID         = errors.New("member ID is required")
 	ErrInvalidBelt           = errors.New("invalid belt value")
 	ErrEmptyProposedBy       = errors.New("proposed_by is required")
-	ErrInvalidProposalStatus = errors.New("proposal status must be one of: pending, approved, rejected")
+	ErrInvalidProposalStatus = errors.New("proposal status must be one of: pending, scheduled, approved, rejected")
 	ErrAlreadyDecided        = errors.New("proposal has already been decided")
+	ErrEmptyEventID          = errors.New("grading day event ID is required")
+	ErrNotScheduled          = errors.New("proposal is not scheduled for a grading day")
+	ErrEmptyProposalID       = errors.New("proposal ID is required")
+	ErrInvalidStripe         = errors.New("stripe must be between 0 and 4")
+	ErrEmptyCorrectionReason = errors.New("a reason is required to amend or void a grading record")
+	ErrRecordNotEffective    = errors.New("grading record has already been amended or voided")
+	ErrCorrectionUnchanged   = errors.New("amendment must change the belt, stripe or date")
 )
 
 // Record represents an official belt promotion in a member's history.
@@ -61,7 +73,38 @@ type Record struct {
 	PromotedAt time.Time
 	ProposedBy string // AccountID of coach who proposed
 	ApprovedBy string // AccountID of admin who approved
-	Method     string // standard or override
+	Method     string // standard, override, inferred or import
+
+	// Corrections never edit a record in place. An amendment saves a new record that
+	// Supersedes the original and stamps the original's SupersededBy; a void marks the
+	// record Voided. CorrectedBy, CorrectedAt and CorrectionReason describe the amendment
+	// on the new record, or the void on the voided one.
+	Supersedes       string
+	SupersededBy     string
+	Voided           bool
+	CorrectedBy      string
+	CorrectedAt      time.Time
+	CorrectionReason string
+}
+
+// IsEffective reports whether the record still counts towards the member's belt history.
+// PRE: none
+// POST: Returns false once the record has been amended or voided
+func (r Record) IsEffective() bool {
+	return r.SupersededBy == "" && !r.Voided
+}
+
+// EffectiveRecords returns the records that still count, preserving order.
+// PRE: none
+// POST: Returns a subset of records; superseded and voided records are dropped
+func EffectiveRecords(records []Record) []Record {
+	var out []Record
+	for _, r := range records {
+		if r.IsEffective() {
+			out = append(out, r)
+		}
+	}
+	return out
 }
 
 // Validate checks if the Record has valid data.
@@ -75,7 +118,7 @@ func (r *Record) Validate() error {
 		return ErrInvalidBelt
 	}
 	if r.Stripe < 0 || r.Stripe > 4 {
-		return errors.New("stripe must be between 0 and 4")
+		return ErrInvalidStripe
 	}
 	if r.PromotedAt.IsZero() {
 		return errors.New("promoted_at must be set")
@@ -146,7 +189,8 @@ type Proposal struct {
 	Notes      string
 	ProposedBy string // Coach AccountID
 	ApprovedBy string // Admin AccountID (empty until decided)
-	Status     string // pending, approved, rejected
+	Status     string // pending, scheduled, approved, rejected
+	EventID    string // calendar event of the grading day (set while scheduled, kept once decided)
 	CreatedAt  time.Time
 	DecidedAt  time.Time
 }
@@ -167,6 +211,9 @@ func (p *Proposal) Validate() error {
 	if !isValidProposalStatus(p.Status) {
 		return ErrInvalidProposalStatus
 	}
+	if p.Status == ProposalScheduled && p.EventID == "" {
+		return ErrEmptyEventID
+	}
 	return nil
 }
 
@@ -176,11 +223,44 @@ func (p *Proposal) IsPending() bool {
 	return p.Status == ProposalPending
 }
 
+// IsOpen returns true if the proposal is pending or scheduled, i.e. not yet decided.
+// INVARIANT: Status field is not mutated
+func (p *Proposal) IsOpen() bool {
+	return p.Status == ProposalPending || p.Status == ProposalScheduled
+}
+
+// Schedule books the proposal for decision on a grading day, replacing any earlier booking.
+// PRE: Proposal is open, eventID is non-empty
+// POST: Status is scheduled and EventID is set
+func (p *Proposal) Schedule(eventID string) error {
+	if !p.IsOpen() {
+		return ErrAlreadyDecided
+	}
+	if eventID == "" {
+		return ErrEmptyEventID
+	}
+	p.Status = ProposalScheduled
+	p.EventID = eventID
+	return nil
+}
+
+// Unschedule takes the proposal off its grading day.
+// PRE: Proposal is scheduled
+// POST: Status is pending and EventID is cleared
+func (p *Proposal) Unschedule() error {
+	if p.Status != ProposalScheduled {
+		return ErrNotScheduled
+	}
+	p.Status = ProposalPending
+	p.EventID = ""
+	return nil
+}
+
 // Approve moves the proposal to approved status.
-// PRE: Proposal is pending, adminID is non-empty
+// PRE: Proposal is open, adminID is non-empty
 // POST: Status is approved, ApprovedBy and DecidedAt are set
 func (p *Proposal) Approve(adminID string) error {
-	if !p.IsPending() {
+	if !p.IsOpen() {
 		return ErrAlreadyDecided
 	}
 	if adminID == "" {
@@ -193,10 +273,10 @@ func (p *Proposal) Approve(adminID string) error {
 }
 
 // Reject moves the proposal to rejected status.
-// PRE: Proposal is pending, adminID is non-empty
+// PRE: Proposal is open, adminID is non-empty
 // POST: Status is rejected, ApprovedBy and DecidedAt are set
 func (p *Proposal) Reject(adminID string) error {
-	if !p.IsPending() {
+	if !p.IsOpen() {
 		return ErrAlreadyDecided
 	}
 	if adminID == "" {
@@ -208,13 +288,52 @@ func (p *Proposal) Reject(adminID string) error {
 	return nil
 }
 
+// ProposalComment is one message in the coach/admin discussion of a proposal.
+type ProposalComment struct {
+	ID         string
+	ProposalID string
+	AuthorID   string // AccountID of the coach or admin
+	Content    string
+	CreatedAt  time.Time
+}
+
+// Validate checks if the ProposalComment has valid data.
+// PRE: ProposalComment struct is populated
+// POST: Returns nil if valid, error otherwise
+func (c *ProposalComment) Validate() error {
+	if c.ProposalID == "" {
+		return ErrEmptyProposalID
+	}
+	if c.AuthorID == "" {
+		return errors.New("comment author is required")
+	}
+	if c.Content == "" {
+		return errors.New("comment content is required")
+	}
+	if len(c.Content) > MaxCommentLength {
+		return errors.New("comment cannot exceed 2000 characters")
+	}
+	return nil
+}
+
+// Note visibility levels. An empty visibility is read as NoteVisibilityCoaches.
+const (
+	NoteVisibilityCoaches = "coaches" // coaches and admins
+	NoteVisibilityAdmin   = "admin"   // admins only
+	NoteVisibilityShared  = "shared"  // coaches, admins and the member
+)
+
+// ErrInvalidNoteVisibility is returned for an unknown note visibility level.
+var ErrInvalidNoteVisibility = errors.New("visibility must be coaches, admin or shared")
+
 // Note represents a coach/admin note attached to a member's grading readiness.
 type Note struct {
-	ID        string
-	MemberID  string
-	Content   string
-	CreatedBy string // AccountID of author
-	CreatedAt time.Time
+	ID         string
+	MemberID   string
+	Content    string
+	Visibility string // coaches, admin or shared
+	CreatedBy  string // AccountID of author
+	CreatedAt  time.Time
 }
 
 // Validate checks if the Note has valid data.
@@ -230,9 +349,32 @@ func (n *Note) Validate() error {
 	if n.CreatedBy == "" {
 		return errors.New("created_by is required")
 	}
+	if !IsValidNoteVisibility(n.Visibility) {
+		return ErrInvalidNoteVisibility
+	}
 	return nil
 }
 
+// IsValidNoteVisibility reports whether v is a note visibility level; empty counts as the default.
+func IsValidNoteVisibility(v string) bool {
+	return v == "" || v == NoteVisibilityCoaches || v == NoteVisibilityAdmin || v == NoteVisibilityShared
+}
+
+// VisibleTo reports whether an account with the given role may read the note.
+// ownMember is true when the reader is the member the note is about.
+// PRE: Note is initialized
+// POST: Admins see everything, coaches everything but admin-only, others only their own shared notes
+func (n *Note) VisibleTo(role string, ownMember bool) bool {
+	switch role {
+	case "admin":
+		return true
+	case "coach":
+		return n.Visibility != NoteVisibilityAdmin
+	default:
+		return ownMember && n.Visibility == NoteVisibilityShared
+	}
+}
+
 // InferStripe calculates the stripe count a member should have on their current belt
 // based on accumulated mat hours and the config for the next belt in progression.
 // PRE: config.FlightTimeHours > 0 and config.StripeCount > 0
@@ -252,6 +394,61 @@ func InferStripe(totalMatHours float64, config Config) int {
 	return stripe
 }
 
+// IsValidBelt reports whether belt is an adult or kids belt.
+// POST: Returns true for any belt in AdultBelts or KidsBelts
+func IsValidBelt(belt string) bool {
+	return isValidBelt(belt)
+}
+
+// BeltAtLeast reports whether a member of program holding belt has reached minimum in
+// their program's progression. No minimum always passes; a member with no belt is a white
+// belt; a minimum outside the program's progression is never reached.
+// PRE: program is "adults" or "kids"
+// POST: Returns true when minimum is empty or belt is at or above it
+func BeltAtLeast(program, belt, minimum string) bool {
+	if minimum == "" {
+		return true
+	}
+	if belt == "" {
+		belt = BeltWhite
+	}
+	needed := beltIndex(program, minimum)
+	return needed >= 0 && beltIndex(program, belt) >= needed
+}
+
+// InProgression reports whether belt is part of program's belt progression.
+// PRE: program is "adults" or "kids"
+// POST: Returns false for kids-only belts in the adults program and vice versa
+func InProgression(program, belt string) bool {
+	return beltIndex(program, belt) >= 0
+}
+
+// CompareRanks orders two ranks in program's progression by belt, then stripes. Belts
+// outside the progression sort below white.
+// PRE: program is "adults" or "kids"
+// POST: Returns a negative number when a is below b, zero when they are the same rank and a
+// positive number when a is above b; dates are ignored
+func CompareRanks(program string, a, b Rank) int {
+	if d := beltIndex(program, a.Belt) - beltIndex(program, b.Belt); d != 0 {
+		return d
+	}
+	return a.Stripe - b.Stripe
+}
+
+// beltIndex returns belt's position in program's progression, or -1 when it is not in it.
+func beltIndex(program, belt string) int {
+	progression := AdultBelts
+	if program == "kids" {
+		progression = KidsBelts
+	}
+	for i, b := range progression {
+		if b == belt {
+			return i
+		}
+	}
+	return -1
+}
+
 func isValidBelt(belt string) bool {
 	all := []string{BeltWhite, BeltBlue, BeltPurple, BeltBrown, BeltBlack, BeltGrey, BeltYellow, BeltOrange, BeltGreen}
 	for _, b := range all {
@@ -263,7 +460,7 @@ func isValidBelt(belt string) bool {
 }
 
 func isValidProposalStatus(s string) bool {
-	for _, v := range []string{ProposalPending, ProposalApproved, ProposalRejected} {
+	for _, v := range []string{ProposalPending, ProposalScheduled, ProposalApproved, ProposalRejected} {
 		if v == s {
 			return true
 		}
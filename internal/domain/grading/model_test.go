@@ -1,6 +1,7 @@
 package grading_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -51,6 +52,20 @@ func TestRecord_Validate(t *testing.T) {
 	}
 }
 
+// TestEffectiveRecords verifies superseded and voided records drop out of the history.
+func TestEffectiveRecords(t *testing.T) {
+	records := []grading.Record{
+		{ID: "r3", Supersedes: "r1"},
+		{ID: "r2", Voided: true},
+		{ID: "r1", SupersededBy: "r3"},
+		{ID: "r0"},
+	}
+	got := grading.EffectiveRecords(records)
+	if len(got) != 2 || got[0].ID != "r3" || got[1].ID != "r0" {
+		t.Errorf("expected r3 and r0, got %+v", got)
+	}
+}
+
 // TestConfig_Validate tests validation of grading Config.
 func TestConfig_Validate(t *testing.T) {
 	tests := []struct {
@@ -144,6 +159,16 @@ func TestProposal_Validate(t *testing.T) {
 			proposal: grading.Proposal{ID: "5", MemberID: "m1", TargetBelt: grading.BeltBlue, ProposedBy: "coach1", Status: "bogus"},
 			wantErr:  true,
 		},
+		{
+			name:     "scheduled with event",
+			proposal: grading.Proposal{ID: "6", MemberID: "m1", TargetBelt: grading.BeltBlue, ProposedBy: "coach1", Status: grading.ProposalScheduled, EventID: "ev1"},
+			wantErr:  false,
+		},
+		{
+			name:     "scheduled without event",
+			proposal: grading.Proposal{ID: "7", MemberID: "m1", TargetBelt: grading.BeltBlue, ProposedBy: "coach1", Status: grading.ProposalScheduled},
+			wantErr:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -234,3 +259,159 @@ func TestProposal_Reject(t *testing.T) {
 		}
 	})
 }
+
+// TestProposal_Schedule tests booking proposals onto a grading day and deciding them there.
+func TestProposal_Schedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		eventID string
+		wantErr error
+	}{
+		{name: "pending", status: grading.ProposalPending, eventID: "ev1"},
+		{name: "reschedule", status: grading.ProposalScheduled, eventID: "ev2"},
+		{name: "no event", status: grading.ProposalPending, wantErr: grading.ErrEmptyEventID},
+		{name: "already approved", status: grading.ProposalApproved, eventID: "ev1", wantErr: grading.ErrAlreadyDecided},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := grading.Proposal{Status: tt.status, EventID: "ev1"}
+			err := p.Schedule(tt.eventID)
+			if err != tt.wantErr {
+				t.Fatalf("Schedule() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (p.Status != grading.ProposalScheduled || p.EventID != tt.eventID) {
+				t.Errorf("expected scheduled for %s, got %s/%s", tt.eventID, p.Status, p.EventID)
+			}
+		})
+	}
+
+	t.Run("approve scheduled keeps event", func(t *testing.T) {
+		p := grading.Proposal{Status: grading.ProposalScheduled, EventID: "ev1"}
+		if err := p.Approve("admin1"); err != nil {
+			t.Fatalf("Approve() unexpected error: %v", err)
+		}
+		if p.EventID != "ev1" {
+			t.Errorf("expected EventID kept, got %q", p.EventID)
+		}
+	})
+
+	t.Run("unschedule", func(t *testing.T) {
+		p := grading.Proposal{Status: grading.ProposalScheduled, EventID: "ev1"}
+		if err := p.Unschedule(); err != nil {
+			t.Fatalf("Unschedule() unexpected error: %v", err)
+		}
+		if p.Status != grading.ProposalPending || p.EventID != "" {
+			t.Errorf("expected pending without event, got %s/%s", p.Status, p.EventID)
+		}
+		if err := p.Unschedule(); err != grading.ErrNotScheduled {
+			t.Errorf("expected ErrNotScheduled, got %v", err)
+		}
+	})
+}
+
+// TestProposalComment_Validate tests validation of proposal comments.
+func TestProposalComment_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment grading.ProposalComment
+		wantErr bool
+	}{
+		{name: "valid", comment: grading.ProposalComment{ProposalID: "p1", AuthorID: "a1", Content: "Ready for it"}},
+		{name: "no proposal", comment: grading.ProposalComment{AuthorID: "a1", Content: "x"}, wantErr: true},
+		{name: "no author", comment: grading.ProposalComment{ProposalID: "p1", Content: "x"}, wantErr: true},
+		{name: "empty content", comment: grading.ProposalComment{ProposalID: "p1", AuthorID: "a1"}, wantErr: true},
+		{name: "too long", comment: grading.ProposalComment{ProposalID: "p1", AuthorID: "a1", Content: strings.Repeat("x", grading.MaxCommentLength+1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.comment.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProposalComment.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestBeltAtLeast tests the minimum belt check within each program's progression.
+func TestBeltAtLeast(t *testing.T) {
+	tests := []struct {
+		name                   string
+		program, belt, minimum string
+		want                   bool
+	}{
+		{"no minimum", "adults", "", "", true},
+		{"no belt is white", "adults", "", grading.BeltWhite, true},
+		{"no belt below blue", "adults", "", grading.BeltBlue, false},
+		{"at the minimum", "adults", grading.BeltBlue, grading.BeltBlue, true},
+		{"above the minimum", "adults", grading.BeltBrown, grading.BeltPurple, true},
+		{"below the minimum", "adults", grading.BeltBlue, grading.BeltPurple, false},
+		{"kids progression", "kids", grading.BeltOrange, grading.BeltYellow, true},
+		{"kids blue is the top", "kids", grading.BeltBlue, grading.BeltGreen, true},
+		{"adult belt outside kids progression", "kids", grading.BeltBlue, grading.BeltPurple, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := grading.BeltAtLeast(tt.program, tt.belt, tt.minimum); got != tt.want {
+				t.Errorf("BeltAtLeast(%q, %q, %q) = %v, want %v", tt.program, tt.belt, tt.minimum, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCompareRanks verifies ranks order by belt within the program's progression, then stripes.
+func TestCompareRanks(t *testing.T) {
+	tests := []struct {
+		name    string
+		program string
+		a, b    grading.Rank
+		want    int // sign of the result
+	}{
+		{"same rank", "adults", grading.Rank{Belt: grading.BeltBlue, Stripe: 2}, grading.Rank{Belt: grading.BeltBlue, Stripe: 2}, 0},
+		{"more stripes", "adults", grading.Rank{Belt: grading.BeltBlue, Stripe: 3}, grading.Rank{Belt: grading.BeltBlue, Stripe: 1}, 1},
+		{"higher belt beats stripes", "adults", grading.Rank{Belt: grading.BeltPurple}, grading.Rank{Belt: grading.BeltBlue, Stripe: 4}, 1},
+		{"lower belt", "adults", grading.Rank{Belt: grading.BeltWhite, Stripe: 4}, grading.Rank{Belt: grading.BeltBlue}, -1},
+		{"kids blue above green", "kids", grading.Rank{Belt: grading.BeltBlue}, grading.Rank{Belt: grading.BeltGreen}, 1},
+		{"outside the progression", "adults", grading.Rank{Belt: grading.BeltGrey}, grading.Rank{Belt: grading.BeltWhite}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := grading.CompareRanks(tt.program, tt.a, tt.b)
+			if (got > 0) != (tt.want > 0) || (got < 0) != (tt.want < 0) {
+				t.Errorf("CompareRanks(%q, %+v, %+v) = %d, want sign %d", tt.program, tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+	if grading.InProgression("adults", grading.BeltGrey) || !grading.InProgression("kids", grading.BeltGrey) {
+		t.Error("grey is a kids belt only")
+	}
+}
+
+// TestNote_VisibleTo verifies each role sees only the note visibility levels meant for it,
+// and that unknown levels fail validation.
+func TestNote_VisibleTo(t *testing.T) {
+	tests := []struct {
+		visibility string
+		role       string
+		ownMember  bool
+		want       bool
+	}{
+		{"", "coach", false, true},
+		{grading.NoteVisibilityAdmin, "coach", false, false},
+		{grading.NoteVisibilityAdmin, "admin", false, true},
+		{grading.NoteVisibilityCoaches, "member", true, false},
+		{grading.NoteVisibilityShared, "member", true, true},
+		{grading.NoteVisibilityShared, "member", false, false},
+	}
+	for _, tt := range tests {
+		n := grading.Note{Visibility: tt.visibility}
+		if got := n.VisibleTo(tt.role, tt.ownMember); got != tt.want {
+			t.Errorf("%q VisibleTo(%s, own=%v) = %v, want %v", tt.visibility, tt.role, tt.ownMember, got, tt.want)
+		}
+	}
+
+	n := grading.Note{MemberID: "m1", Content: "ready", CreatedBy: "coach1", Visibility: "public"}
+	if err := n.Validate(); err != grading.ErrInvalidNoteVisibility {
+		t.Errorf("Validate() = %v, want ErrInvalidNoteVisibility", err)
+	}
+}
@@ -7,7 +7,39 @@ import (
 
 // Max length constants for user-editable fields.
 const (
-	MaxDescriptionLength = 1000
+	MaxDescriptionLength     = 1000
+	MaxResolutionNotesLength = 2000
+)
+
+// Status constants describe where an injury is in its lifecycle.
+const (
+	StatusActive     = "active"
+	StatusRecovering = "recovering"
+	StatusResolved   = "resolved"
+)
+
+// ValidStatuses contains all valid status values.
+var ValidStatuses = []string{StatusActive, StatusRecovering, StatusResolved}
+
+// Severity constants
+const (
+	SeverityLow    = "low"
+	SeverityMedium = "medium"
+	SeverityHigh   = "high"
+)
+
+// ValidSeverities contains all valid severity values.
+var ValidSeverities = []string{SeverityLow, SeverityMedium, SeverityHigh}
+
+// LegacyActiveWindow is how long an injury without a lifecycle status is treated as active.
+const LegacyActiveWindow = 7 * 24 * time.Hour
+
+// Domain errors
+var (
+	ErrInvalidStatus          = errors.New("status must be one of: active, recovering, resolved")
+	ErrInvalidSeverity        = errors.New("severity must be one of: low, medium, high")
+	ErrResolutionNotesTooLong = errors.New("resolution notes cannot exceed 2000 characters")
+	ErrGradingRestricted      = errors.New("member has an injury that restricts grading")
 )
 
 // Body part constants
@@ -29,6 +61,14 @@ type Injury struct {
 	Description string
 	MemberID    string
 	ReportedAt  time.Time
+
+	// Lifecycle fields. Injuries reported before the lifecycle existed have an empty Status.
+	Status            string // active, recovering, resolved
+	Severity          string // low, medium, high (empty = derived from body part)
+	ResolutionNotes   string
+	ResolvedAt        time.Time
+	UpdatedAt         time.Time
+	GradingRestricted bool // set by a coach; blocks grading proposals while the injury is unresolved
 }
 
 // Validate checks if the Injury has valid data.
@@ -48,21 +88,61 @@ func (i *Injury) Validate() error {
 	if i.ReportedAt.IsZero() {
 		return errors.New("reported date must be set")
 	}
+	if i.Status != "" && !isValidStatus(i.Status) {
+		return ErrInvalidStatus
+	}
+	if i.Severity != "" && !isValidSeverity(i.Severity) {
+		return ErrInvalidSeverity
+	}
+	if len(i.ResolutionNotes) > MaxResolutionNotesLength {
+		return ErrResolutionNotesTooLong
+	}
 	return nil
 }
 
-// IsActive returns true if the injury is still active (within 7 days).
+// IsActive returns true if the injury is unresolved.
+// Injuries without a lifecycle status fall back to the legacy 7-day window.
 // PRE: Injury is initialized
 // POST: Returns boolean indicating active status
-// INVARIANT: Injuries are considered active for 7 days
 func (i *Injury) IsActive() bool {
-	return time.Since(i.ReportedAt) < 7*24*time.Hour
+	if i.Status == "" {
+		return time.Since(i.ReportedAt) < LegacyActiveWindow
+	}
+	return i.Status != StatusResolved
+}
+
+// BlocksGrading returns true if a coach has restricted grading and the injury is unresolved.
+// INVARIANT: Injury fields are not mutated
+func (i *Injury) BlocksGrading() bool {
+	return i.GradingRestricted && i.IsActive()
+}
+
+// SetStatus moves the injury through its lifecycle.
+// PRE: status is one of ValidStatuses
+// POST: Status and UpdatedAt set; ResolvedAt set when resolved, cleared when reopened
+func (i *Injury) SetStatus(status string, now time.Time) error {
+	if !isValidStatus(status) {
+		return ErrInvalidStatus
+	}
+	i.Status = status
+	i.UpdatedAt = now
+	if status == StatusResolved {
+		if i.ResolvedAt.IsZero() {
+			i.ResolvedAt = now
+		}
+	} else {
+		i.ResolvedAt = time.Time{}
+	}
+	return nil
 }
 
-// GetSeverity returns a severity indicator based on the injury.
+// GetSeverity returns the recorded severity, or a heuristic based on the body part.
 // PRE: Injury is initialized
 // POST: Returns severity string ("high", "medium", "low")
 func (i *Injury) GetSeverity() string {
+	if i.Severity != "" {
+		return i.Severity
+	}
 	// Simple heuristic: neck/back are high severity
 	if i.BodyPart == BodyPartNeck || i.BodyPart == BodyPartBack {
 		return "high"
@@ -73,3 +153,21 @@ func (i *Injury) GetSeverity() string {
 	}
 	return "low"
 }
+
+func isValidStatus(s string) bool {
+	for _, v := range ValidStatuses {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidSeverity(s string) bool {
+	for _, v := range ValidSeverities {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package injury_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/injury"
+)
+
+// TestInjury_Validate tests validation of Injury.
+func TestInjury_Validate(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	base := injury.Injury{ID: "1", MemberID: "m1", BodyPart: injury.BodyPartKnee, ReportedAt: now}
+
+	tests := []struct {
+		name    string
+		mutate  func(i *injury.Injury)
+		wantErr bool
+	}{
+		{"valid legacy injury", func(i *injury.Injury) {}, false},
+		{"valid with lifecycle", func(i *injury.Injury) { i.Status = injury.StatusRecovering; i.Severity = injury.SeverityHigh }, false},
+		{"missing member", func(i *injury.Injury) { i.MemberID = "" }, true},
+		{"missing body part", func(i *injury.Injury) { i.BodyPart = "" }, true},
+		{"zero reported at", func(i *injury.Injury) { i.ReportedAt = time.Time{} }, true},
+		{"invalid status", func(i *injury.Injury) { i.Status = "healed" }, true},
+		{"invalid severity", func(i *injury.Injury) { i.Severity = "extreme" }, true},
+		{"resolution notes too long", func(i *injury.Injury) { i.ResolutionNotes = strings.Repeat("a", injury.MaxResolutionNotesLength+1) }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inj := base
+			tt.mutate(&inj)
+			if err := inj.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestInjury_SetStatus tests lifecycle transitions.
+func TestInjury_SetStatus(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	inj := injury.Injury{MemberID: "m1", BodyPart: injury.BodyPartKnee, ReportedAt: now, Status: injury.StatusActive, GradingRestricted: true}
+
+	if !inj.BlocksGrading() {
+		t.Fatal("expected active grading-restricted injury to block grading")
+	}
+
+	if err := inj.SetStatus(injury.StatusResolved, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inj.ResolvedAt != now || inj.IsActive() || inj.BlocksGrading() {
+		t.Errorf("expected resolved injury to be inactive with ResolvedAt set, got %+v", inj)
+	}
+
+	if err := inj.SetStatus(injury.StatusRecovering, now.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !inj.ResolvedAt.IsZero() || !inj.IsActive() {
+		t.Errorf("expected reopened injury to be active with ResolvedAt cleared, got %+v", inj)
+	}
+
+	if err := inj.SetStatus("gone", now); err != injury.ErrInvalidStatus {
+		t.Errorf("expected ErrInvalidStatus, got %v", err)
+	}
+}
+
+// TestInjury_IsActive_LegacyWindow tests that injuries without a status use the 7-day window.
+func TestInjury_IsActive_LegacyWindow(t *testing.T) {
+	recent := injury.Injury{ReportedAt: time.Now().Add(-24 * time.Hour)}
+	old := injury.Injury{ReportedAt: time.Now().Add(-8 * 24 * time.Hour)}
+	if !recent.IsActive() {
+		t.Error("expected recent legacy injury to be active")
+	}
+	if old.IsActive() {
+		t.Error("expected old legacy injury to be inactive")
+	}
+}
+
+// TestInjury_GetSeverity tests recorded severity overrides the body-part heuristic.
+func TestInjury_GetSeverity(t *testing.T) {
+	inj := injury.Injury{BodyPart: injury.BodyPartNeck}
+	if got := inj.GetSeverity(); got != injury.SeverityHigh {
+		t.Errorf("heuristic severity = %q, want high", got)
+	}
+	inj.Severity = injury.SeverityLow
+	if got := inj.GetSeverity(); got != injury.SeverityLow {
+		t.Errorf("recorded severity = %q, want low", got)
+	}
+}
@@ -0,0 +1,194 @@
+package inventory
+
+import (
+	"errors"
+	"time"
+)
+
+// Item kinds
+const (
+	KindBelt   = "belt"
+	KindStripe = "stripe"
+)
+
+// BeltColors are the belts stocked, lowest first: kids colours sit between white and blue.
+var BeltColors = []string{"white", "grey", "yellow", "orange", "green", "blue", "purple", "brown", "black"}
+
+// StripeColor is the tape colour awarded stripes use; every belt takes white tape.
+const StripeColor = "white"
+
+// AdultSizes and KidsSizes are the belt sizes stocked, smallest first.
+var (
+	AdultSizes = []string{"A0", "A1", "A2", "A3", "A4", "A5", "A6"}
+	KidsSizes  = []string{"M000", "M00", "M0", "M1", "M2", "M3", "M4"}
+)
+
+// Domain errors
+var (
+	ErrInvalidKind     = errors.New("kind must be belt or stripe")
+	ErrInvalidColor    = errors.New("invalid belt colour")
+	ErrEmptyColor      = errors.New("stripe colour is required")
+	ErrInvalidSize     = errors.New("invalid belt size")
+	ErrSizeOnStripe    = errors.New("stripes have no size")
+	ErrNegativeStock   = errors.New("quantity cannot be negative")
+	ErrNegativeWarning = errors.New("low-stock level cannot be negative")
+	ErrEmptyMemberID   = errors.New("member ID is required")
+)
+
+// Item is the stock of one belt colour and size, or of one colour of stripe tape.
+type Item struct {
+	ID        string
+	Kind      string // belt or stripe
+	Color     string // belt colour, or tape colour for stripes
+	Size      string // belts only
+	Quantity  int
+	LowStock  int // warn when Quantity is at or below this; 0 warns only when none are left
+	UpdatedAt time.Time
+}
+
+// Validate checks if the Item has valid data.
+// PRE: Item struct is populated
+// POST: Returns nil if valid, error otherwise
+func (i *Item) Validate() error {
+	switch i.Kind {
+	case KindBelt:
+		if !IsBeltColor(i.Color) {
+			return ErrInvalidColor
+		}
+		if !IsSize(i.Size) {
+			return ErrInvalidSize
+		}
+	case KindStripe:
+		if i.Color == "" {
+			return ErrEmptyColor
+		}
+		if i.Size != "" {
+			return ErrSizeOnStripe
+		}
+	default:
+		return ErrInvalidKind
+	}
+	if i.Quantity < 0 {
+		return ErrNegativeStock
+	}
+	if i.LowStock < 0 {
+		return ErrNegativeWarning
+	}
+	return nil
+}
+
+// IsLow reports whether the item has fallen to its low-stock level.
+// INVARIANT: Item is not mutated
+func (i *Item) IsLow() bool {
+	return i.Quantity <= i.LowStock
+}
+
+// Take removes n from stock and returns how many were short. Stock never goes below zero:
+// a belt handed out from elsewhere still counts against the shelf, not beyond it.
+// PRE: n >= 0
+// POST: Quantity is reduced by n, to no less than zero
+func (i *Item) Take(n int) int {
+	if n <= i.Quantity {
+		i.Quantity -= n
+		return 0
+	}
+	short := n - i.Quantity
+	i.Quantity = 0
+	return short
+}
+
+// MemberSize records the belt size a member wears.
+type MemberSize struct {
+	MemberID string
+	Size     string
+}
+
+// Validate checks if the MemberSize has valid data.
+// PRE: MemberSize struct is populated
+// POST: Returns nil if valid, error otherwise
+func (m *MemberSize) Validate() error {
+	if m.MemberID == "" {
+		return ErrEmptyMemberID
+	}
+	if !IsSize(m.Size) {
+		return ErrInvalidSize
+	}
+	return nil
+}
+
+// Use is stock a promotion takes from the shelf.
+type Use struct {
+	Kind     string
+	Color    string
+	Size     string // empty for a belt when the member's size is not recorded
+	Quantity int
+}
+
+// Grade is a belt and stripe count a member holds.
+type Grade struct {
+	Belt   string
+	Stripe int
+}
+
+// UsesForPromotion returns the stock a promotion takes, given the member's grade before
+// it: a belt in the member's size when the belt changes, plus one stripe for each stripe
+// gained.
+// PRE: to is a valid grade; from is the zero Grade for a member's first record
+// POST: Returns the uses, or none when nothing changed
+func UsesForPromotion(to, from Grade, size string) []Use {
+	var uses []Use
+	stripes := to.Stripe - from.Stripe
+	if to.Belt != from.Belt {
+		uses = append(uses, Use{Kind: KindBelt, Color: to.Belt, Size: size, Quantity: 1})
+		stripes = to.Stripe
+	}
+	if stripes > 0 {
+		uses = append(uses, Use{Kind: KindStripe, Color: StripeColor, Quantity: stripes})
+	}
+	return uses
+}
+
+// IsBeltColor reports whether color is an adult or kids belt.
+func IsBeltColor(color string) bool {
+	return contains(BeltColors, color)
+}
+
+// IsSize reports whether size is a stocked belt size.
+func IsSize(size string) bool {
+	return contains(AdultSizes, size) || contains(KidsSizes, size)
+}
+
+// ColorRank orders belt colours lowest first; other colours (stripe tape) sort last.
+func ColorRank(color string) int {
+	for i, c := range BeltColors {
+		if c == color {
+			return i
+		}
+	}
+	return len(BeltColors)
+}
+
+// SizeRank orders sizes kids first, smallest to largest; unknown sizes sort last.
+func SizeRank(size string) int {
+	for i, s := range KidsSizes {
+		if s == size {
+			return i
+		}
+	}
+	for i, s := range AdultSizes {
+		if s == size {
+			return len(KidsSizes) + i
+		}
+	}
+	return len(KidsSizes) + len(AdultSizes)
+}
+
+// contains reports whether list holds v.
+func contains(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package inventory_test
+
+import (
+	"reflect"
+	"testing"
+
+	"workshop/internal/domain/inventory"
+)
+
+// TestItem_Validate tests validation of stock items.
+func TestItem_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		item inventory.Item
+		want error
+	}{
+		{"adult belt", inventory.Item{Kind: inventory.KindBelt, Color: "blue", Size: "A2", Quantity: 3}, nil},
+		{"kids belt", inventory.Item{Kind: inventory.KindBelt, Color: "grey", Size: "M1"}, nil},
+		{"stripe tape", inventory.Item{Kind: inventory.KindStripe, Color: "white", Quantity: 40, LowStock: 10}, nil},
+		{"unknown kind", inventory.Item{Kind: "gi", Color: "white"}, inventory.ErrInvalidKind},
+		{"unknown colour", inventory.Item{Kind: inventory.KindBelt, Color: "red", Size: "A2"}, inventory.ErrInvalidColor},
+		{"unknown size", inventory.Item{Kind: inventory.KindBelt, Color: "blue", Size: "XL"}, inventory.ErrInvalidSize},
+		{"stripe without colour", inventory.Item{Kind: inventory.KindStripe}, inventory.ErrEmptyColor},
+		{"stripe with size", inventory.Item{Kind: inventory.KindStripe, Color: "white", Size: "A2"}, inventory.ErrSizeOnStripe},
+		{"negative stock", inventory.Item{Kind: inventory.KindStripe, Color: "white", Quantity: -1}, inventory.ErrNegativeStock},
+		{"negative warning", inventory.Item{Kind: inventory.KindStripe, Color: "white", LowStock: -1}, inventory.ErrNegativeWarning},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.Validate(); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestItem_Take tests that stock runs down to zero and reports any shortfall.
+func TestItem_Take(t *testing.T) {
+	item := inventory.Item{Quantity: 3, LowStock: 1}
+	if short := item.Take(2); short != 0 || item.Quantity != 1 || !item.IsLow() {
+		t.Fatalf("Take(2) short %d, item %+v; want 1 left and low", short, item)
+	}
+	if short := item.Take(3); short != 2 || item.Quantity != 0 {
+		t.Errorf("Take(3) short %d, item %+v; want 2 short and none left", short, item)
+	}
+}
+
+// TestUsesForPromotion tests the stock a promotion takes.
+func TestUsesForPromotion(t *testing.T) {
+	white2 := inventory.Grade{Belt: "white", Stripe: 2}
+	tests := []struct {
+		name string
+		to   inventory.Grade
+		from inventory.Grade
+		size string
+		want []inventory.Use
+	}{
+		{"new belt", inventory.Grade{Belt: "blue"}, white2, "A2",
+			[]inventory.Use{{Kind: inventory.KindBelt, Color: "blue", Size: "A2", Quantity: 1}}},
+		{"stripes gained", inventory.Grade{Belt: "white", Stripe: 4}, white2, "A2",
+			[]inventory.Use{{Kind: inventory.KindStripe, Color: inventory.StripeColor, Quantity: 2}}},
+		{"belt with stripes, size unknown", inventory.Grade{Belt: "blue", Stripe: 1}, white2, "",
+			[]inventory.Use{{Kind: inventory.KindBelt, Color: "blue", Quantity: 1}, {Kind: inventory.KindStripe, Color: inventory.StripeColor, Quantity: 1}}},
+		{"first record", inventory.Grade{Belt: "white"}, inventory.Grade{}, "M1",
+			[]inventory.Use{{Kind: inventory.KindBelt, Color: "white", Size: "M1", Quantity: 1}}},
+		{"no change", white2, white2, "A2", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inventory.UsesForPromotion(tt.to, tt.from, tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UsesForPromotion() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestColorRank tests that belts sort lowest first, kids colours between white and blue.
+func TestColorRank(t *testing.T) {
+	if !(inventory.ColorRank("white") < inventory.ColorRank("green") && inventory.ColorRank("green") < inventory.ColorRank("blue") && inventory.ColorRank("black") < inventory.ColorRank("red")) {
+		t.Error("expected white < green < blue < black < unknown")
+	}
+}
+
+// TestSizeRank tests that sizes sort kids first, smallest to largest.
+func TestSizeRank(t *testing.T) {
+	if !(inventory.SizeRank("M000") < inventory.SizeRank("M4") && inventory.SizeRank("M4") < inventory.SizeRank("A0") && inventory.SizeRank("A6") < inventory.SizeRank("")) {
+		t.Error("expected M000 < M4 < A0 < A6 < unknown")
+	}
+}
@@ -0,0 +1,207 @@
+package job
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearch bounds how far ahead Next looks; every valid schedule fires within it
+// (29 February at least once every eight years).
+const maxSearch = 8 * 366 * 24 * time.Hour
+
+// minEvery is the shortest @every interval; the scheduler wakes up about once a minute.
+const minEvery = time.Minute
+
+// descriptors are the shorthand schedules accepted in place of five fields.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field describes one of the five cron fields.
+type field struct {
+	name     string
+	min, max int
+	names    []string // three-letter names indexed from min; nil when the field has none
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	// Day of week accepts 7 as well as 0 for Sunday.
+	dowField = field{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+// Schedule is a parsed cron expression: five fields (minute hour day-of-month month
+// day-of-week), a descriptor such as @daily, or "@every <duration>".
+// As in cron, when both day fields are restricted a day matching either one fires.
+type Schedule struct {
+	every                        time.Duration // non-zero for @every schedules
+	minute, hour, dom, month     uint64        // bit n set when value n matches
+	dow                          uint64
+	domRestricted, dowRestricted bool
+}
+
+// ParseSchedule parses a cron expression.
+// PRE: none
+// POST: Returns the schedule, or an error wrapping ErrInvalidSchedule that names the bad field
+func ParseSchedule(expr string) (Schedule, error) {
+	expr = strings.ToLower(strings.TrimSpace(expr))
+	if rest, ok := strings.CutPrefix(expr, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return Schedule{}, fmt.Errorf("%w: %q is not a duration", ErrInvalidSchedule, rest)
+		}
+		if d < minEvery {
+			return Schedule{}, fmt.Errorf("%w: @every must be at least %s", ErrInvalidSchedule, minEvery)
+		}
+		return Schedule{every: d}, nil
+	}
+	if d, ok := descriptors[expr]; ok {
+		expr = d
+	}
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return Schedule{}, fmt.Errorf("%w: want five fields (minute hour day month weekday) or a descriptor such as @daily", ErrInvalidSchedule)
+	}
+	var s Schedule
+	var err error
+	if s.minute, err = parseField(parts[0], minuteField); err != nil {
+		return Schedule{}, err
+	}
+	if s.hour, err = parseField(parts[1], hourField); err != nil {
+		return Schedule{}, err
+	}
+	if s.dom, err = parseField(parts[2], domField); err != nil {
+		return Schedule{}, err
+	}
+	if s.month, err = parseField(parts[3], monthField); err != nil {
+		return Schedule{}, err
+	}
+	if s.dow, err = parseField(parts[4], dowField); err != nil {
+		return Schedule{}, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domRestricted = parts[2] != "*" && !strings.HasPrefix(parts[2], "*/")
+	s.dowRestricted = parts[4] != "*" && !strings.HasPrefix(parts[4], "*/")
+	if s.Next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return Schedule{}, fmt.Errorf("%w: the day and month never occur together", ErrInvalidSchedule)
+	}
+	return s, nil
+}
+
+// parseField parses a comma-separated list of *, values, ranges and /steps into a bitset.
+func parseField(text string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(text, ",") {
+		rangeText, stepText, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("%w: bad step %q in %s", ErrInvalidSchedule, stepText, f.name)
+			}
+			step = n
+		}
+		lo, hi := f.min, f.max
+		switch {
+		case rangeText == "*":
+		case strings.Contains(rangeText, "-"):
+			loText, hiText, _ := strings.Cut(rangeText, "-")
+			var err error
+			if lo, err = parseValue(loText, f); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(hiText, f); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("%w: range %q in %s runs backwards", ErrInvalidSchedule, rangeText, f.name)
+			}
+		default:
+			v, err := parseValue(rangeText, f)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			if hasStep {
+				hi = f.max // "5/15" means from 5 every 15
+			} else {
+				hi = v
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// parseValue parses a number or three-letter name within the field's bounds.
+func parseValue(text string, f field) (int, error) {
+	for i, name := range f.names {
+		if text == name {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%w: %s must be %d-%d, got %q", ErrInvalidSchedule, f.name, f.min, f.max, text)
+	}
+	return v, nil
+}
+
+// Next returns the first time the schedule fires strictly after t, in t's location.
+// PRE: s came from ParseSchedule
+// POST: Returns a time after t, or the zero time if the schedule never fires
+func (s Schedule) Next(t time.Time) time.Time {
+	if s.every > 0 {
+		return t.Add(s.every)
+	}
+	loc := t.Location()
+	// Cron fires on whole minutes; start at the minute after t.
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxSearch)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches applies cron's rule for the two day fields: when both are restricted
+// either may match, otherwise both must.
+func (s Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
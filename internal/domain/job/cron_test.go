@@ -0,0 +1,79 @@
+package job
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestParseSchedule_Rejects verifies malformed expressions are refused with ErrInvalidSchedule.
+func TestParseSchedule_Rejects(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"0 0 30 feb *",
+		"@every 30s",
+		"@every soon",
+		"@fortnightly",
+	} {
+		if _, err := ParseSchedule(expr); !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("ParseSchedule(%q) err = %v, want ErrInvalidSchedule", expr, err)
+		}
+	}
+}
+
+// TestScheduleNext verifies the next firing for fields, lists, ranges, steps, names,
+// descriptors and @every.
+func TestScheduleNext(t *testing.T) {
+	// Wednesday 2026-03-04 10:17:30
+	from := time.Date(2026, 3, 4, 10, 17, 30, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 3, 4, 10, 18, 0, 0, time.UTC)},
+		{"*/5 * * * *", time.Date(2026, 3, 4, 10, 20, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC)},
+		{"15,45 9-17 * * *", time.Date(2026, 3, 4, 10, 45, 0, 0, time.UTC)},
+		{"0 7 * * mon", time.Date(2026, 3, 9, 7, 0, 0, 0, time.UTC)},
+		{"0 7 * * 1-5", time.Date(2026, 3, 5, 7, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{"30 2 1 * *", time.Date(2026, 4, 1, 2, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 9 1 * fri", time.Date(2026, 3, 6, 9, 0, 0, 0, time.UTC)}, // either day field
+		{"5/20 * * * *", time.Date(2026, 3, 4, 10, 25, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"@HOURLY", time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC)},
+		{"@every 90m", from.Add(90 * time.Minute)},
+	}
+	for _, tt := range tests {
+		s, err := ParseSchedule(tt.expr)
+		if err != nil {
+			t.Errorf("ParseSchedule(%q): %v", tt.expr, err)
+			continue
+		}
+		if got := s.Next(from); !got.Equal(tt.want) {
+			t.Errorf("%q: Next = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+}
+
+// TestScheduleNext_Location verifies cron fields are read in the caller's time zone.
+func TestScheduleNext_Location(t *testing.T) {
+	loc := time.FixedZone("NZDT", 13*60*60)
+	s, err := ParseSchedule("0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := s.Next(time.Date(2026, 1, 10, 8, 0, 0, 0, loc))
+	if want := time.Date(2026, 1, 10, 9, 0, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("Next = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,90 @@
+package job
+
+import (
+	"errors"
+	"time"
+)
+
+// Run status constants record how a job's last run ended.
+const (
+	StatusOK     = "ok"
+	StatusFailed = "failed"
+)
+
+// MaxErrorLength caps the stored error of a failed run.
+const MaxErrorLength = 500
+
+// Domain errors.
+var (
+	ErrEmptyName       = errors.New("job name cannot be empty")
+	ErrInvalidSchedule = errors.New("invalid schedule")
+	ErrInvalidStatus   = errors.New("invalid run status")
+	ErrNotFound        = errors.New("job not found")
+	ErrAlreadyRunning  = errors.New("job is already running")
+)
+
+// Job is a background task's schedule and the outcome of its last run.
+// The task itself is code registered under Name; only its timing is stored.
+// INVARIANT: NextRunAt is zero while the job is disabled
+type Job struct {
+	Name         string
+	Schedule     string // cron expression, descriptor (@daily) or "@every <duration>"
+	Enabled      bool
+	NextRunAt    time.Time
+	LastRunAt    time.Time // start of the last run
+	LastDuration time.Duration
+	LastStatus   string // "" before the first run, else a Status constant
+	LastError    string
+	RunningUntil time.Time // lease held by a run in progress; zero or past when idle
+	UpdatedBy    string    // admin who last changed the schedule; "" for the default
+	UpdatedAt    time.Time
+}
+
+// Validate checks the job's name, schedule and last status.
+// PRE: none
+// POST: Returns nil if valid, or the first domain error found
+func (j Job) Validate() error {
+	if j.Name == "" {
+		return ErrEmptyName
+	}
+	if _, err := ParseSchedule(j.Schedule); err != nil {
+		return err
+	}
+	switch j.LastStatus {
+	case "", StatusOK, StatusFailed:
+	default:
+		return ErrInvalidStatus
+	}
+	return nil
+}
+
+// IsRunning reports whether a run holds the job's lease at now.
+// PRE: none
+// POST: Returns true until the lease is released or expires
+func (j Job) IsRunning(now time.Time) bool {
+	return j.RunningUntil.After(now)
+}
+
+// IsDue reports whether an enabled, idle job should start at now.
+// A job whose time passed while the server was down is due once, not once per missed slot.
+// PRE: none
+// POST: Returns true when the job should be started
+func (j Job) IsDue(now time.Time) bool {
+	return j.Enabled && !j.NextRunAt.IsZero() && !j.NextRunAt.After(now) && !j.IsRunning(now)
+}
+
+// Reschedule sets NextRunAt from the schedule, or clears it when the job is disabled.
+// PRE: j.Schedule is valid
+// POST: NextRunAt is the first firing after now, or zero when disabled
+func (j *Job) Reschedule(now time.Time) error {
+	if !j.Enabled {
+		j.NextRunAt = time.Time{}
+		return nil
+	}
+	s, err := ParseSchedule(j.Schedule)
+	if err != nil {
+		return err
+	}
+	j.NextRunAt = s.Next(now)
+	return nil
+}
@@ -0,0 +1,66 @@
+package job
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestJob_Validate verifies a job needs a name, a valid schedule and a known status.
+func TestJob_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		job  Job
+		want error
+	}{
+		{"valid", Job{Name: "outbox", Schedule: "* * * * *"}, nil},
+		{"ran", Job{Name: "outbox", Schedule: "@hourly", LastStatus: StatusFailed}, nil},
+		{"no name", Job{Schedule: "* * * * *"}, ErrEmptyName},
+		{"bad schedule", Job{Name: "outbox", Schedule: "often"}, ErrInvalidSchedule},
+		{"bad status", Job{Name: "outbox", Schedule: "@daily", LastStatus: "maybe"}, ErrInvalidStatus},
+	}
+	for _, tt := range tests {
+		if err := tt.job.Validate(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+// TestJob_IsDue verifies a job is due once its time has come, unless disabled or running.
+func TestJob_IsDue(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		job  Job
+		want bool
+	}{
+		{"due", Job{Enabled: true, NextRunAt: now}, true},
+		{"overdue", Job{Enabled: true, NextRunAt: now.Add(-48 * time.Hour)}, true},
+		{"not yet", Job{Enabled: true, NextRunAt: now.Add(time.Second)}, false},
+		{"disabled", Job{NextRunAt: now}, false},
+		{"unscheduled", Job{Enabled: true}, false},
+		{"running", Job{Enabled: true, NextRunAt: now, RunningUntil: now.Add(time.Minute)}, false},
+		{"lease expired", Job{Enabled: true, NextRunAt: now, RunningUntil: now.Add(-time.Minute)}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.job.IsDue(now); got != tt.want {
+			t.Errorf("%s: IsDue = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestJob_Reschedule verifies enabling schedules the next run and disabling clears it.
+func TestJob_Reschedule(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 17, 0, 0, time.UTC)
+	j := Job{Name: "outbox", Schedule: "@hourly", Enabled: true}
+	if err := j.Reschedule(now); err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC); !j.NextRunAt.Equal(want) {
+		t.Errorf("NextRunAt = %s, want %s", j.NextRunAt, want)
+	}
+	j.Enabled = false
+	if err := j.Reschedule(now); err != nil || !j.NextRunAt.IsZero() {
+		t.Errorf("disabled: NextRunAt = %s, err = %v, want zero", j.NextRunAt, err)
+	}
+}
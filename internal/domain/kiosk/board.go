@@ -0,0 +1,68 @@
+package kiosk
+
+import (
+	"strings"
+	"time"
+)
+
+// BoardClass picks the class the display board features at now: a class open for
+// check-in (see MatchClass) in any program, the one starting closest to now; otherwise
+// the next class to start today so the board can count down to it.
+// PRE: now is in the gym's local time zone
+// POST: Returns the slot, whether it is open for check-in, and false if no class is left today
+func BoardClass(slots []ClassSlot, now time.Time) (slot ClassSlot, open bool, ok bool) {
+	var best, next ClassSlot
+	var bestGap time.Duration = -1
+	var nextStart time.Time
+	for _, s := range slots {
+		start, errStart := clockOn(now, s.StartTime)
+		end, errEnd := clockOn(now, s.EndTime)
+		if errStart != nil || errEnd != nil || !now.Before(end) {
+			continue
+		}
+		if now.Before(start.Add(-CheckInOpensBefore)) {
+			if nextStart.IsZero() || start.Before(nextStart) {
+				next, nextStart = s, start
+			}
+			continue
+		}
+		gap := now.Sub(start)
+		if gap < 0 {
+			gap = -gap
+		}
+		if bestGap < 0 || gap < bestGap {
+			best, bestGap = s, gap
+		}
+	}
+	if bestGap >= 0 {
+		return best, true, true
+	}
+	return next, false, !nextStart.IsZero()
+}
+
+// ClassStart returns when slot starts on now's date.
+// PRE: slot.StartTime is HH:MM
+// POST: Returns the start time in now's location, or the zero time if StartTime is malformed
+func ClassStart(slot ClassSlot, now time.Time) time.Time {
+	t, err := clockOn(now, slot.StartTime)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// BoardName shortens a member's name for the wall display: first name and last initial,
+// so the board does not show full names to everyone in the gym.
+// PRE: none
+// POST: Returns e.g. "Marcus B." for "Marcus Buffett", or the single name unchanged
+func BoardName(name string) string {
+	parts := strings.Fields(name)
+	if len(parts) == 0 {
+		return ""
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	last := []rune(parts[len(parts)-1])
+	return parts[0] + " " + strings.ToUpper(string(last[0])) + "."
+}
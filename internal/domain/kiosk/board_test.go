@@ -0,0 +1,64 @@
+package kiosk_test
+
+import (
+	"testing"
+	"time"
+
+	"workshop/internal/domain/kiosk"
+)
+
+// TestBoardClass tests which class the display board features through the day.
+func TestBoardClass(t *testing.T) {
+	slots := []kiosk.ClassSlot{
+		{ScheduleID: "kids", ProgramType: "kids", StartTime: "16:00", EndTime: "17:00"},
+		{ScheduleID: "adults", ProgramType: "adults", StartTime: "18:00", EndTime: "19:30"},
+		{ScheduleID: "bad", StartTime: "late", EndTime: "later"},
+	}
+	at := func(hhmm string) time.Time {
+		c, _ := time.Parse("15:04", hhmm)
+		return time.Date(2026, 3, 2, c.Hour(), c.Minute(), 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		wantID   string
+		wantOpen bool
+		wantOK   bool
+	}{
+		{"morning counts down to first class", at("09:00"), "kids", false, true},
+		{"kids class open for check-in", at("15:30"), "kids", true, true},
+		{"kids class in progress", at("16:45"), "kids", true, true},
+		{"between classes counts down", at("17:00"), "adults", false, true},
+		{"adults open", at("17:20"), "adults", true, true},
+		{"after the last class", at("19:30"), "", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, open, ok := kiosk.BoardClass(slots, tt.now)
+			if got.ScheduleID != tt.wantID || open != tt.wantOpen || ok != tt.wantOK {
+				t.Errorf("BoardClass() = %q, %v, %v; want %q, %v, %v", got.ScheduleID, open, ok, tt.wantID, tt.wantOpen, tt.wantOK)
+			}
+		})
+	}
+
+	if got := kiosk.ClassStart(slots[1], at("09:00")); !got.Equal(at("18:00")) {
+		t.Errorf("ClassStart() = %v, want 18:00", got)
+	}
+}
+
+// TestBoardName tests that the board shows first names and last initials only.
+func TestBoardName(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Marcus Buffett", "Marcus B."},
+		{"  ana  de la rosa ", "ana R."},
+		{"Prince", "Prince"},
+		{"", ""},
+		{"Zoë Ólafsdóttir", "Zoë Ó."},
+	}
+	for _, tt := range tests {
+		if got := kiosk.BoardName(tt.in); got != tt.want {
+			t.Errorf("BoardName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
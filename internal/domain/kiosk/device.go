@@ -0,0 +1,139 @@
+package kiosk
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Exit PIN shape and rotation. A PIN is accepted during its own period and the one after,
+// so a PIN read off the admin page just before it rotates still works.
+const (
+	PINDigits           = 6
+	PINPeriod           = 10 * time.Minute
+	PINSecretLength     = 32
+	MaxDeviceNameLength = 50
+)
+
+// Device errors
+var (
+	ErrEmptyDeviceName   = errors.New("kiosk device name cannot be empty")
+	ErrDeviceNameTooLong = fmt.Errorf("kiosk device name cannot exceed %d characters", MaxDeviceNameLength)
+	ErrEmptyRegisteredBy = errors.New("kiosk device must record who registered it")
+	ErrInvalidPINSecret  = fmt.Errorf("kiosk device PIN secret must be %d bytes", PINSecretLength)
+	ErrWrongPIN          = errors.New("invalid kiosk PIN")
+)
+
+// Device is a tablet registered to run kiosk mode.
+// While in kiosk mode it holds the sign-in session it runs under, so an admin can end it remotely.
+type Device struct {
+	ID             string
+	Name           string // e.g. "Front desk iPad"
+	LocationID     string // location the kiosk serves; empty if unscoped
+	RegisteredBy   string // account that first launched kiosk mode on the device
+	PINSecret      []byte // seeds the rotating exit PIN
+	RegisteredAt   time.Time
+	LastSeenAt     time.Time
+	AuthSessionID  string    // sign-in session running kiosk mode; empty when not in kiosk mode
+	KioskStartedAt time.Time // when the current kiosk session started; zero when not in kiosk mode
+}
+
+// Validate checks if the Device has valid data.
+// PRE: Device struct is populated
+// POST: Returns nil if valid, error otherwise
+func (d *Device) Validate() error {
+	name := strings.TrimSpace(d.Name)
+	if name == "" {
+		return ErrEmptyDeviceName
+	}
+	if len(name) > MaxDeviceNameLength {
+		return ErrDeviceNameTooLong
+	}
+	if d.RegisteredBy == "" {
+		return ErrEmptyRegisteredBy
+	}
+	if len(d.PINSecret) != PINSecretLength {
+		return ErrInvalidPINSecret
+	}
+	return nil
+}
+
+// InKiosk returns true while the device is running a kiosk session.
+// INVARIANT: Device fields are not mutated
+func (d *Device) InKiosk() bool {
+	return d.AuthSessionID != ""
+}
+
+// StartKiosk records that the device entered kiosk mode under a sign-in session.
+// PRE: authSessionID is non-empty
+// POST: the device is in kiosk mode and was last seen at now
+func (d *Device) StartKiosk(authSessionID string, now time.Time) {
+	d.AuthSessionID = authSessionID
+	d.KioskStartedAt = now
+	d.LastSeenAt = now
+}
+
+// EndKiosk records that the device left kiosk mode and returns the session it ran under.
+// PRE: none
+// POST: the device is not in kiosk mode; returns ErrNotActive if it was not in kiosk mode
+func (d *Device) EndKiosk() (string, error) {
+	if !d.InKiosk() {
+		return "", ErrNotActive
+	}
+	sessionID := d.AuthSessionID
+	d.AuthSessionID = ""
+	d.KioskStartedAt = time.Time{}
+	return sessionID, nil
+}
+
+// PIN returns the exit PIN for the period containing at.
+// PRE: PINSecret is set
+// POST: returns PINDigits digits, zero-padded
+func (d *Device) PIN(at time.Time) string {
+	return devicePIN(d.PINSecret, at.Unix()/int64(PINPeriod/time.Second))
+}
+
+// PINExpiresAt returns when the PIN shown at the given time stops being shown.
+// PRE: none
+// POST: returns the start of the next period
+func PINExpiresAt(at time.Time) time.Time {
+	period := int64(PINPeriod / time.Second)
+	return time.Unix((at.Unix()/period+1)*period, 0).In(at.Location())
+}
+
+// CheckPIN verifies an exit PIN against the current and previous periods.
+// PRE: PINSecret is set
+// POST: returns nil if pin matches, ErrWrongPIN otherwise
+func (d *Device) CheckPIN(pin string, now time.Time) error {
+	pin = strings.TrimSpace(pin)
+	counter := now.Unix() / int64(PINPeriod/time.Second)
+	ok := 0
+	for _, c := range []int64{counter, counter - 1} {
+		ok |= subtle.ConstantTimeCompare([]byte(pin), []byte(devicePIN(d.PINSecret, c)))
+	}
+	if ok != 1 {
+		return ErrWrongPIN
+	}
+	return nil
+}
+
+// devicePIN derives a PIN from the secret and a period counter, truncating an HMAC as HOTP does.
+func devicePIN(secret []byte, counter int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < PINDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", PINDigits, code%mod)
+}
@@ -0,0 +1,105 @@
+package kiosk_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/kiosk"
+)
+
+func newDevice() kiosk.Device {
+	return kiosk.Device{ID: "d1", Name: "Front desk iPad", RegisteredBy: "acct-1", PINSecret: bytes.Repeat([]byte{7}, kiosk.PINSecretLength)}
+}
+
+// TestDevice_Validate tests validation of a kiosk Device.
+func TestDevice_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*kiosk.Device)
+		want   error
+	}{
+		{"valid device", func(*kiosk.Device) {}, nil},
+		{"empty name", func(d *kiosk.Device) { d.Name = "  " }, kiosk.ErrEmptyDeviceName},
+		{"long name", func(d *kiosk.Device) { d.Name = strings.Repeat("x", kiosk.MaxDeviceNameLength+1) }, kiosk.ErrDeviceNameTooLong},
+		{"no registrant", func(d *kiosk.Device) { d.RegisteredBy = "" }, kiosk.ErrEmptyRegisteredBy},
+		{"short secret", func(d *kiosk.Device) { d.PINSecret = []byte("short") }, kiosk.ErrInvalidPINSecret},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDevice()
+			tt.mutate(&d)
+			if err := d.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestDevice_Kiosk tests entering and leaving kiosk mode on a device.
+func TestDevice_Kiosk(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	d := newDevice()
+	if d.InKiosk() {
+		t.Fatal("new device should not be in kiosk mode")
+	}
+	d.StartKiosk("sess-1", now)
+	if !d.InKiosk() || !d.LastSeenAt.Equal(now) || !d.KioskStartedAt.Equal(now) {
+		t.Fatalf("StartKiosk did not record the session: %+v", d)
+	}
+	sessionID, err := d.EndKiosk()
+	if err != nil || sessionID != "sess-1" {
+		t.Fatalf("EndKiosk() = %q, %v; want sess-1", sessionID, err)
+	}
+	if d.InKiosk() || !d.KioskStartedAt.IsZero() {
+		t.Errorf("EndKiosk left the device in kiosk mode: %+v", d)
+	}
+	if _, err := d.EndKiosk(); !errors.Is(err, kiosk.ErrNotActive) {
+		t.Errorf("second EndKiosk() = %v, want ErrNotActive", err)
+	}
+}
+
+// TestDevice_CheckPIN tests the rotating exit PIN is accepted for its own period and the next only.
+func TestDevice_CheckPIN(t *testing.T) {
+	issued := time.Date(2026, 3, 2, 9, 5, 0, 0, time.UTC)
+	d := newDevice()
+	pin := d.PIN(issued)
+	if len(pin) != kiosk.PINDigits {
+		t.Fatalf("PIN() = %q, want %d digits", pin, kiosk.PINDigits)
+	}
+	other := newDevice()
+	other.PINSecret = bytes.Repeat([]byte{8}, kiosk.PINSecretLength)
+
+	tests := []struct {
+		name   string
+		device kiosk.Device
+		pin    string
+		at     time.Time
+		want   error
+	}{
+		{"same period", d, pin, issued.Add(4 * time.Minute), nil},
+		{"surrounding spaces", d, " " + pin + " ", issued, nil},
+		{"next period", d, pin, issued.Add(kiosk.PINPeriod), nil},
+		{"two periods later", d, pin, issued.Add(2 * kiosk.PINPeriod), kiosk.ErrWrongPIN},
+		{"before issue", d, pin, issued.Add(-kiosk.PINPeriod), kiosk.ErrWrongPIN},
+		{"other device", other, pin, issued, kiosk.ErrWrongPIN},
+		{"empty", d, "", issued, kiosk.ErrWrongPIN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.device.CheckPIN(tt.pin, tt.at); !errors.Is(err, tt.want) {
+				t.Errorf("CheckPIN() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestPINExpiresAt tests the PIN shown at a time stops being shown at the next period boundary.
+func TestPINExpiresAt(t *testing.T) {
+	got := kiosk.PINExpiresAt(time.Date(2026, 3, 2, 9, 5, 30, 0, time.UTC))
+	if want := time.Date(2026, 3, 2, 9, 10, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("PINExpiresAt() = %v, want %v", got, want)
+	}
+}
@@ -16,10 +16,12 @@ var (
 // Kiosk mode locks the tablet to check-in only; exiting requires the
 // launching account's password or another coach/admin login.
 type Session struct {
-	ID        string
-	AccountID string // The account that launched kiosk mode
-	StartedAt time.Time
-	EndedAt   time.Time
+	ID         string
+	AccountID  string // The account that launched kiosk mode
+	LocationID string // The location the kiosk is serving; empty if unscoped
+	DeviceID   string // The registered device running the session; empty if unregistered
+	StartedAt  time.Time
+	EndedAt    time.Time
 }
 
 // Validate checks if the Session has valid data.
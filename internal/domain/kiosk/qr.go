@@ -0,0 +1,99 @@
+package kiosk
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+// CheckInTokenPrefix marks a member check-in QR token and its format version.
+const CheckInTokenPrefix = "wsci1"
+
+// checkInSignatureBytes is how much of the HMAC-SHA256 is kept; 128 bits keeps the QR code small.
+const checkInSignatureBytes = 16
+
+// CheckInOpensBefore is how long before a class starts a QR scan checks the member into it.
+const CheckInOpensBefore = 45 * time.Minute
+
+// ErrInvalidCheckInToken is returned for tokens that are malformed or not signed with the kiosk key.
+var ErrInvalidCheckInToken = errors.New("invalid check-in code")
+
+// SignCheckInToken builds the token printed in a member's check-in QR code.
+// The token is "wsci1.<memberID>.<signature>" so it can be checked without a database lookup.
+// PRE: key is non-empty; memberID is non-empty and contains no '.'
+// POST: Returns a token that VerifyCheckInToken accepts with the same key
+func SignCheckInToken(key []byte, memberID string) string {
+	return CheckInTokenPrefix + "." + memberID + "." + checkInSignature(key, memberID)
+}
+
+// VerifyCheckInToken checks a scanned token's signature and returns the member ID it carries.
+// PRE: key is the one the token was signed with
+// POST: Returns the member ID, or ErrInvalidCheckInToken
+func VerifyCheckInToken(key []byte, token string) (string, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 || parts[0] != CheckInTokenPrefix || parts[1] == "" {
+		return "", ErrInvalidCheckInToken
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(checkInSignature(key, parts[1]))) {
+		return "", ErrInvalidCheckInToken
+	}
+	return parts[1], nil
+}
+
+// checkInSignature returns the truncated, URL-safe HMAC of a member ID.
+func checkInSignature(key []byte, memberID string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(CheckInTokenPrefix + "." + memberID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:checkInSignatureBytes])
+}
+
+// ClassSlot is one of today's classes a scanned member could be checked into.
+type ClassSlot struct {
+	ScheduleID  string
+	ProgramType string // "adults" or "kids"; empty means any program
+	StartTime   string // HH:MM
+	EndTime     string // HH:MM
+}
+
+// MatchClass picks the class a member scanning in at now is arriving for.
+// A class is open from CheckInOpensBefore its start until its end; among open classes
+// for the member's program, the one starting closest to now wins.
+// PRE: now is in the gym's local time zone
+// POST: Returns the matching slot and true, or false if no class is open
+func MatchClass(slots []ClassSlot, program string, now time.Time) (ClassSlot, bool) {
+	var best ClassSlot
+	var bestGap time.Duration = -1
+	for _, s := range slots {
+		if s.ProgramType != "" && s.ProgramType != program {
+			continue
+		}
+		start, errStart := clockOn(now, s.StartTime)
+		end, errEnd := clockOn(now, s.EndTime)
+		if errStart != nil || errEnd != nil {
+			continue
+		}
+		if now.Before(start.Add(-CheckInOpensBefore)) || !now.Before(end) {
+			continue
+		}
+		gap := now.Sub(start)
+		if gap < 0 {
+			gap = -gap
+		}
+		if bestGap < 0 || gap < bestGap {
+			best, bestGap = s, gap
+		}
+	}
+	return best, bestGap >= 0
+}
+
+// clockOn returns the HH:MM wall-clock time on now's date.
+func clockOn(now time.Time, hhmm string) (time.Time, error) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location()), nil
+}
@@ -0,0 +1,87 @@
+package kiosk_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/kiosk"
+)
+
+// TestCheckInToken_RoundTrip tests that a signed token verifies with the same key only.
+func TestCheckInToken_RoundTrip(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	token := kiosk.SignCheckInToken(key, "member-1")
+
+	got, err := kiosk.VerifyCheckInToken(key, " "+token+"\n")
+	if err != nil || got != "member-1" {
+		t.Fatalf("VerifyCheckInToken() = %q, %v; want member-1", got, err)
+	}
+	if _, err := kiosk.VerifyCheckInToken([]byte("another key"), token); !errors.Is(err, kiosk.ErrInvalidCheckInToken) {
+		t.Errorf("expected ErrInvalidCheckInToken for wrong key, got %v", err)
+	}
+}
+
+// TestVerifyCheckInToken_Rejects tests malformed and tampered tokens.
+func TestVerifyCheckInToken_Rejects(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	valid := kiosk.SignCheckInToken(key, "member-1")
+	forged := "wsci1.member-2." + valid[len("wsci1.member-1."):]
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"member ID only", "member-1"},
+		{"wrong prefix", "wsci9" + valid[len("wsci1"):]},
+		{"missing member", "wsci1.." + valid[len("wsci1.member-1."):]},
+		{"signature of another member", forged},
+		{"extra part", valid + ".x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := kiosk.VerifyCheckInToken(key, tt.token); !errors.Is(err, kiosk.ErrInvalidCheckInToken) {
+				t.Errorf("expected ErrInvalidCheckInToken, got %v", err)
+			}
+		})
+	}
+}
+
+// TestMatchClass tests which of today's classes a scan lands in.
+func TestMatchClass(t *testing.T) {
+	slots := []kiosk.ClassSlot{
+		{ScheduleID: "adults-6", ProgramType: "adults", StartTime: "06:00", EndTime: "07:00"},
+		{ScheduleID: "adults-7", ProgramType: "adults", StartTime: "07:00", EndTime: "08:00"},
+		{ScheduleID: "kids-16", ProgramType: "kids", StartTime: "16:00", EndTime: "17:00"},
+		{ScheduleID: "open-18", StartTime: "18:00", EndTime: "19:30"},
+	}
+	at := func(hhmm string) time.Time {
+		t, _ := time.Parse("2006-01-02 15:04", "2026-03-02 "+hhmm)
+		return t
+	}
+
+	tests := []struct {
+		name    string
+		program string
+		now     string
+		want    string
+	}{
+		{"early arrival", "adults", "05:20", "adults-6"},
+		{"too early", "adults", "05:10", ""},
+		{"late arrival", "adults", "06:20", "adults-6"},
+		{"between back-to-back classes", "adults", "06:50", "adults-7"},
+		{"other program's class", "adults", "16:10", ""},
+		{"kids class", "kids", "15:30", "kids-16"},
+		{"class open to all programs", "kids", "18:05", "open-18"},
+		{"after the last class", "adults", "19:30", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := kiosk.MatchClass(slots, tt.program, at(tt.now))
+			if ok != (tt.want != "") || got.ScheduleID != tt.want {
+				t.Errorf("MatchClass() = %q, %v; want %q", got.ScheduleID, ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package kpi
+
+import (
+	"errors"
+	"time"
+)
+
+// Snapshot windows.
+const (
+	ActiveWindowDays = 7  // a member is weekly-active if they trained in the 7 days ending on the snapshot date
+	TrendWeeks       = 12 // weeks of history charted on the admin dashboard
+)
+
+// Domain errors
+var (
+	ErrInvalidDate   = errors.New("snapshot date must be YYYY-MM-DD")
+	ErrNegativeCount = errors.New("snapshot counts cannot be negative")
+)
+
+// Snapshot records the gym's headline numbers at the end of one day.
+// Members carry no join or archive dates, so NewMembers and ChurnedMembers are
+// measured against the previous snapshot: member records added, and members archived, since then.
+type Snapshot struct {
+	Date                string // YYYY-MM-DD; one snapshot per day
+	TotalMembers        int    // every member record, including archived
+	ActiveMembers       int    // status active
+	ArchivedMembers     int
+	NewMembers          int     // member records added since the previous snapshot
+	ChurnedMembers      int     // members archived since the previous snapshot
+	WeeklyActiveMembers int     // distinct members who checked in during the active window
+	ClassesHeld         int     // scheduled class sessions with at least one check-in during the active window
+	AvgClassSize        float64 // check-ins per class held during the active window
+	MonthlyRevenue      int     // active members' fees normalised to a month
+	ComputedAt          time.Time
+}
+
+// Validate checks if the Snapshot has valid data.
+// PRE: Snapshot struct is populated
+// POST: Returns nil if valid, error otherwise
+func (s *Snapshot) Validate() error {
+	if _, err := time.Parse("2006-01-02", s.Date); err != nil {
+		return ErrInvalidDate
+	}
+	for _, n := range []int{s.TotalMembers, s.ActiveMembers, s.ArchivedMembers, s.NewMembers, s.ChurnedMembers, s.WeeklyActiveMembers, s.ClassesHeld, s.MonthlyRevenue} {
+		if n < 0 {
+			return ErrNegativeCount
+		}
+	}
+	if s.AvgClassSize < 0 {
+		return ErrNegativeCount
+	}
+	return nil
+}
+
+// ChangeSince returns how many member records were added and archived between prev and s.
+// Counts never go negative: deleted or restored members are not reported as negative signups or churn.
+// PRE: prev is the snapshot immediately before s
+// POST: Returns non-negative counts
+func (s *Snapshot) ChangeSince(prev Snapshot) (added, archived int) {
+	return max(0, s.TotalMembers-prev.TotalMembers), max(0, s.ArchivedMembers-prev.ArchivedMembers)
+}
@@ -0,0 +1,50 @@
+package kpi_test
+
+import (
+	"testing"
+
+	"workshop/internal/domain/kpi"
+)
+
+// TestSnapshotValidate tests validation of Snapshot.
+func TestSnapshotValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       kpi.Snapshot
+		wantErr error
+	}{
+		{"valid", kpi.Snapshot{Date: "2026-03-01", TotalMembers: 10, ActiveMembers: 8, AvgClassSize: 6.5}, nil},
+		{"bad date", kpi.Snapshot{Date: "March 1"}, kpi.ErrInvalidDate},
+		{"negative count", kpi.Snapshot{Date: "2026-03-01", NewMembers: -1}, kpi.ErrNegativeCount},
+		{"negative class size", kpi.Snapshot{Date: "2026-03-01", AvgClassSize: -0.5}, kpi.ErrNegativeCount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.s.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestSnapshotChangeSince tests signup and churn counts between snapshots.
+func TestSnapshotChangeSince(t *testing.T) {
+	prev := kpi.Snapshot{TotalMembers: 50, ArchivedMembers: 5}
+	tests := []struct {
+		name                    string
+		s                       kpi.Snapshot
+		wantAdded, wantArchived int
+	}{
+		{"growth and churn", kpi.Snapshot{TotalMembers: 53, ArchivedMembers: 7}, 3, 2},
+		{"no change", kpi.Snapshot{TotalMembers: 50, ArchivedMembers: 5}, 0, 0},
+		{"deletions and restores are not negative", kpi.Snapshot{TotalMembers: 48, ArchivedMembers: 4}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, archived := tt.s.ChangeSince(prev)
+			if added != tt.wantAdded || archived != tt.wantArchived {
+				t.Errorf("ChangeSince() = %d, %d, want %d, %d", added, archived, tt.wantAdded, tt.wantArchived)
+			}
+		})
+	}
+}
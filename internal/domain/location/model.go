@@ -0,0 +1,55 @@
+package location
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Max length constants for user-editable fields.
+const (
+	MaxNameLength    = 100
+	MaxAddressLength = 500
+)
+
+// Domain errors
+var (
+	ErrEmptyName       = errors.New("location name cannot be empty")
+	ErrNameTooLong     = errors.New("location name cannot exceed 100 characters")
+	ErrAddressTooLong  = errors.New("location address cannot exceed 500 characters")
+	ErrOutsideLocation = errors.New("account is not permitted at this location")
+)
+
+// Location represents a physical gym branch served by this deployment.
+// Schedules, class types, attendance, kiosk sessions and accounts may reference
+// a Location by ID. An empty LocationID on those records means "all locations".
+type Location struct {
+	ID        string
+	Name      string
+	Address   string
+	CreatedAt time.Time
+}
+
+// Validate checks if the Location has valid data.
+// PRE: Location struct is populated
+// POST: Returns nil if valid, error otherwise
+func (l *Location) Validate() error {
+	if strings.TrimSpace(l.Name) == "" {
+		return ErrEmptyName
+	}
+	if len(l.Name) > MaxNameLength {
+		return ErrNameTooLong
+	}
+	if len(l.Address) > MaxAddressLength {
+		return ErrAddressTooLong
+	}
+	return nil
+}
+
+// Matches reports whether a record scoped to recordLocationID is visible
+// when the caller has selected filterLocationID.
+// An empty filter shows everything; an empty record location is shared by all branches.
+// INVARIANT: Pure function, no side effects
+func Matches(recordLocationID, filterLocationID string) bool {
+	return filterLocationID == "" || recordLocationID == "" || recordLocationID == filterLocationID
+}
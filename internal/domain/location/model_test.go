@@ -0,0 +1,74 @@
+package location_test
+
+import (
+	"strings"
+	"testing"
+
+	"workshop/internal/domain/location"
+)
+
+// TestLocation_Validate tests validation of Location.
+func TestLocation_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		loc     location.Location
+		wantErr error
+	}{
+		{
+			name:    "valid location",
+			loc:     location.Location{ID: "1", Name: "Central", Address: "1 Queen St"},
+			wantErr: nil,
+		},
+		{
+			name:    "valid without address",
+			loc:     location.Location{ID: "2", Name: "North Shore"},
+			wantErr: nil,
+		},
+		{
+			name:    "empty name",
+			loc:     location.Location{ID: "3", Name: "   "},
+			wantErr: location.ErrEmptyName,
+		},
+		{
+			name:    "name too long",
+			loc:     location.Location{ID: "4", Name: strings.Repeat("a", location.MaxNameLength+1)},
+			wantErr: location.ErrNameTooLong,
+		},
+		{
+			name:    "address too long",
+			loc:     location.Location{ID: "5", Name: "Central", Address: strings.Repeat("a", location.MaxAddressLength+1)},
+			wantErr: location.ErrAddressTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.loc.Validate()
+			if err != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestMatches tests location filter matching.
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		record string
+		filter string
+		want   bool
+	}{
+		{"no filter shows all", "loc-a", "", true},
+		{"shared record shown everywhere", "", "loc-a", true},
+		{"same location", "loc-a", "loc-a", true},
+		{"other location hidden", "loc-b", "loc-a", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := location.Matches(tt.record, tt.filter); got != tt.want {
+				t.Errorf("Matches(%q, %q) = %v, want %v", tt.record, tt.filter, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package member
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Duplicate match reasons
+const (
+	MatchEmail = "email" // same address once case, spaces and "+tag" suffixes are ignored
+	MatchName  = "name"  // same or nearly the same name
+)
+
+// MaxNameDistance is how many single-letter edits two names may differ by and still be
+// flagged as a likely duplicate ("Jon Smith" and "John Smith").
+const MaxNameDistance = 2
+
+// minFuzzyNameLength stops short names ("Al", "Bo") matching almost anything within
+// MaxNameDistance; shorter names must match exactly.
+const minFuzzyNameLength = 6
+
+// Merge errors
+var (
+	ErrMergeSameMember       = errors.New("cannot merge a member into itself")
+	ErrMergeArchivedSurvivor = errors.New("the surviving member is archived; restore them first")
+)
+
+// NormalizeEmail reduces an email address to the form used to spot duplicates: trimmed,
+// lower-cased, and without a "+tag" on the local part.
+// INVARIANT: Pure function, no side effects
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return email
+	}
+	local, _, _ = strings.Cut(local, "+")
+	return local + "@" + domain
+}
+
+// NormalizeName reduces a name to the form used to spot duplicates: lower-cased letters and
+// digits with punctuation dropped ("O'Brien" is "obrien"), and words sorted so "Smith, John"
+// matches "John Smith".
+// INVARIANT: Pure function, no side effects
+func NormalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == ',':
+			b.WriteRune(' ')
+		}
+	}
+	words := strings.Fields(b.String())
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// DuplicateReason reports why two members look like the same person: MatchEmail, MatchName,
+// or "" when they do not.
+// INVARIANT: Pure function, no side effects
+func DuplicateReason(a, b Member) string {
+	if a.ID == b.ID {
+		return ""
+	}
+	if NormalizeEmail(a.Email) == NormalizeEmail(b.Email) {
+		return MatchEmail
+	}
+	nameA, nameB := NormalizeName(a.Name), NormalizeName(b.Name)
+	if nameA == "" || nameB == "" {
+		return ""
+	}
+	if nameA == nameB {
+		return MatchName
+	}
+	if len([]rune(nameA)) >= minFuzzyNameLength && len([]rune(nameB)) >= minFuzzyNameLength &&
+		editDistance(nameA, nameB) <= MaxNameDistance {
+		return MatchName
+	}
+	return ""
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,66 @@
+package member_test
+
+import (
+	"testing"
+
+	"workshop/internal/domain/member"
+)
+
+// TestNormalizeEmail tests reducing addresses to their duplicate-matching form.
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  string
+	}{
+		{"jane@example.com", "jane@example.com"},
+		{"  Jane@Example.COM ", "jane@example.com"},
+		{"jane+guest@example.com", "jane@example.com"},
+		{"not-an-email", "not-an-email"},
+	}
+	for _, tt := range tests {
+		if got := member.NormalizeEmail(tt.email); got != tt.want {
+			t.Errorf("NormalizeEmail(%q) = %q, want %q", tt.email, got, tt.want)
+		}
+	}
+}
+
+// TestNormalizeName tests reducing names to their duplicate-matching form.
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"John Smith", "john smith"},
+		{"  smith,  JOHN ", "john smith"},
+		{"Aroha O'Brien", "aroha obrien"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := member.NormalizeName(tt.name); got != tt.want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestDuplicateReason tests which member pairs are flagged as likely duplicates.
+func TestDuplicateReason(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b member.Member
+		want string
+	}{
+		{"same email in another case", member.Member{ID: "1", Name: "Jane Doe", Email: "jane@example.com"}, member.Member{ID: "2", Name: "J Doe", Email: "JANE+kiosk@example.com"}, member.MatchEmail},
+		{"reordered name", member.Member{ID: "1", Name: "John Smith", Email: "john@example.com"}, member.Member{ID: "2", Name: "Smith, John", Email: "js@guest.local"}, member.MatchName},
+		{"misspelt name", member.Member{ID: "1", Name: "John Smith", Email: "john@example.com"}, member.Member{ID: "2", Name: "Jon Smyth", Email: "jon@example.com"}, member.MatchName},
+		{"different people", member.Member{ID: "1", Name: "John Smith", Email: "john@example.com"}, member.Member{ID: "2", Name: "Jane Smithers", Email: "jane@example.com"}, ""},
+		{"short names must match exactly", member.Member{ID: "1", Name: "Al Li", Email: "al@example.com"}, member.Member{ID: "2", Name: "Bo Li", Email: "bo@example.com"}, ""},
+		{"same member", member.Member{ID: "1", Name: "John Smith", Email: "john@example.com"}, member.Member{ID: "1", Name: "John Smith", Email: "john@example.com"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := member.DuplicateReason(tt.a, tt.b); got != tt.want {
+				t.Errorf("DuplicateReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ const (
 	StatusActive   = "active"
 	StatusInactive = "inactive"
 	StatusArchived = "archived"
+	StatusFrozen   = "frozen" // membership paused: history kept, fees paused, hidden from check-in
 	ProgramAdults  = "adults"
 	ProgramKids    = "kids"
 	MetricSessions = "sessions"
@@ -26,6 +27,9 @@ var (
 	ErrAlreadyArchived = errors.New("member is already archived")
 	ErrNotArchived     = errors.New("member is not archived")
 	ErrAlreadyActive   = errors.New("member is already active")
+	ErrAlreadyFrozen   = errors.New("membership is already frozen")
+	ErrNotFrozen       = errors.New("membership is not frozen")
+	ErrFreezeArchived  = errors.New("archived members cannot be frozen")
 )
 
 // Member holds state for the concept.
@@ -58,8 +62,8 @@ func (m *Member) Validate() error {
 	if m.Program != ProgramAdults && m.Program != ProgramKids {
 		return errors.New("program must be 'adults' or 'kids'")
 	}
-	if m.Status != StatusActive && m.Status != StatusInactive && m.Status != StatusArchived {
-		return errors.New("status must be 'active', 'inactive', or 'archived'")
+	if m.Status != StatusActive && m.Status != StatusInactive && m.Status != StatusArchived && m.Status != StatusFrozen {
+		return errors.New("status must be 'active', 'inactive', 'frozen', or 'archived'")
 	}
 	return nil
 }
@@ -76,6 +80,43 @@ func (m *Member) IsArchived() bool {
 	return m.Status == StatusArchived
 }
 
+// IsFrozen returns true if the membership is frozen.
+// INVARIANT: Status field is not mutated
+func (m *Member) IsFrozen() bool {
+	return m.Status == StatusFrozen
+}
+
+// CanCheckIn returns true unless the member is archived or their membership is frozen.
+// INVARIANT: Status field is not mutated
+func (m *Member) CanCheckIn() bool {
+	return m.Status != StatusArchived && m.Status != StatusFrozen
+}
+
+// Freeze pauses the membership.
+// PRE: Member is neither archived nor already frozen
+// POST: Status is set to frozen
+func (m *Member) Freeze() error {
+	switch m.Status {
+	case StatusFrozen:
+		return ErrAlreadyFrozen
+	case StatusArchived:
+		return ErrFreezeArchived
+	}
+	m.Status = StatusFrozen
+	return nil
+}
+
+// Unfreeze resumes a frozen membership.
+// PRE: Member is frozen
+// POST: Status is set to active
+func (m *Member) Unfreeze() error {
+	if m.Status != StatusFrozen {
+		return ErrNotFrozen
+	}
+	m.Status = StatusActive
+	return nil
+}
+
 // Archive sets the member status to archived.
 // PRE: Member is not already archived
 // POST: Status is set to archived
@@ -97,3 +138,25 @@ func (m *Member) Restore() error {
 	m.Status = StatusActive
 	return nil
 }
+
+// MonthlyFee returns the member's fee converted to a monthly amount.
+// Frequency is free text; weekly, fortnightly, quarterly and annual fees are converted,
+// anything else (including blank) is taken to be monthly. Fees are paused while frozen.
+// INVARIANT: Fee and Frequency fields are not mutated
+func (m *Member) MonthlyFee() int {
+	if m.IsFrozen() {
+		return 0
+	}
+	switch strings.ToLower(strings.TrimSpace(m.Frequency)) {
+	case "weekly":
+		return m.Fee * 52 / 12
+	case "fortnightly":
+		return m.Fee * 26 / 12
+	case "quarterly":
+		return m.Fee / 3
+	case "annual", "annually", "yearly":
+		return m.Fee / 12
+	default:
+		return m.Fee
+	}
+}
@@ -193,3 +193,52 @@ func TestMemberIsArchived(t *testing.T) {
 		})
 	}
 }
+
+// TestMemberMonthlyFee tests conversion of fees to a monthly amount.
+func TestMemberMonthlyFee(t *testing.T) {
+	tests := []struct {
+		name      string
+		fee       int
+		frequency string
+		want      int
+	}{
+		{"monthly", 120, "monthly", 120},
+		{"weekly", 30, "Weekly", 130},
+		{"fortnightly", 60, "fortnightly", 130},
+		{"quarterly", 300, "quarterly", 100},
+		{"annual", 1200, "annual", 100},
+		{"blank is monthly", 90, "", 90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := member.Member{Fee: tt.fee, Frequency: tt.frequency}
+			if got := m.MonthlyFee(); got != tt.want {
+				t.Errorf("MonthlyFee() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMemberFreeze tests freezing pauses check-in and fees, and unfreezing resumes them.
+func TestMemberFreeze(t *testing.T) {
+	m := &member.Member{Status: member.StatusActive, Fee: 120}
+	if err := m.Unfreeze(); err != member.ErrNotFrozen {
+		t.Errorf("unfreeze active: got %v, want ErrNotFrozen", err)
+	}
+	if err := m.Freeze(); err != nil {
+		t.Fatalf("Freeze: %v", err)
+	}
+	if !m.IsFrozen() || m.CanCheckIn() || m.MonthlyFee() != 0 {
+		t.Errorf("frozen: IsFrozen %v, CanCheckIn %v, MonthlyFee %d", m.IsFrozen(), m.CanCheckIn(), m.MonthlyFee())
+	}
+	if err := m.Freeze(); err != member.ErrAlreadyFrozen {
+		t.Errorf("freeze twice: got %v, want ErrAlreadyFrozen", err)
+	}
+	if err := m.Unfreeze(); err != nil || m.Status != member.StatusActive || m.MonthlyFee() != 120 {
+		t.Errorf("Unfreeze: err %v, status %q, fee %d", err, m.Status, m.MonthlyFee())
+	}
+	archived := &member.Member{Status: member.StatusArchived}
+	if err := archived.Freeze(); err != member.ErrFreezeArchived {
+		t.Errorf("freeze archived: got %v, want ErrFreezeArchived", err)
+	}
+}
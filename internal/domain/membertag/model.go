@@ -0,0 +1,124 @@
+package membertag
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Business rule constants
+const (
+	MaxTagLength         = 32
+	MaxSegmentNameLength = 100
+	MaxSegmentTags       = 10
+	MaxAttendanceDays    = 3650
+)
+
+// Domain errors
+var (
+	ErrEmptyTag           = errors.New("tag is required")
+	ErrTagTooLong         = errors.New("tag cannot exceed 32 characters")
+	ErrInvalidTag         = errors.New("tags may only contain letters, digits and dashes")
+	ErrEmptySegmentName   = errors.New("segment name is required")
+	ErrSegmentNameTooLong = errors.New("segment name cannot exceed 100 characters")
+	ErrTooManyTags        = errors.New("a segment can match at most 10 tags")
+	ErrInvalidDays        = errors.New("attendance days must be between 0 and 3650")
+	ErrConflictingDays    = errors.New("a segment cannot require both recent attendance and absence")
+	ErrEmptySegment       = errors.New("a segment needs at least one tag, program, status or attendance filter")
+)
+
+// NormalizeTag lowercases a tag and turns spaces and underscores into dashes, so
+// "Needs Follow Up" and "needs-follow-up" are the same tag.
+// PRE: none
+// POST: Returns the normalised tag, or an error if it is empty, too long or has other characters
+// INVARIANT: Pure function, no side effects
+func NormalizeTag(raw string) (string, error) {
+	tag := strings.ToLower(strings.TrimSpace(raw))
+	tag = strings.Join(strings.FieldsFunc(tag, func(c rune) bool { return c == ' ' || c == '_' }), "-")
+	if tag == "" {
+		return "", ErrEmptyTag
+	}
+	if len(tag) > MaxTagLength {
+		return "", ErrTagTooLong
+	}
+	for _, c := range tag {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+			return "", ErrInvalidTag
+		}
+	}
+	return tag, nil
+}
+
+// TagCount is a tag in use and how many members carry it.
+type TagCount struct {
+	Tag     string
+	Members int
+}
+
+// Segment is a saved member filter: members carrying every tag, in the program and status
+// given, and matching the attendance filter. Empty fields do not filter.
+type Segment struct {
+	ID                 string
+	Name               string
+	Tags               []string // normalised; members must carry all of them
+	Program            string   // optional: adults, kids, etc.
+	Status             string   // optional: active, inactive, frozen; empty excludes archived
+	AttendedWithinDays int      // optional: checked in within this many days
+	AbsentForDays      int      // optional: no check-in for at least this many days
+	CreatedBy          string   // AccountID
+	CreatedAt          time.Time
+}
+
+// Validate normalises the segment's tags and checks it has valid data.
+// PRE: Segment struct is populated
+// POST: Returns nil if valid, error otherwise; Tags are normalised and de-duplicated
+func (s *Segment) Validate() error {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" {
+		return ErrEmptySegmentName
+	}
+	if len(s.Name) > MaxSegmentNameLength {
+		return ErrSegmentNameTooLong
+	}
+	if len(s.Tags) > MaxSegmentTags {
+		return ErrTooManyTags
+	}
+	seen := map[string]bool{}
+	tags := make([]string, 0, len(s.Tags))
+	for _, raw := range s.Tags {
+		tag, err := NormalizeTag(raw)
+		if err != nil {
+			return err
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	s.Tags = tags
+	if s.AttendedWithinDays < 0 || s.AttendedWithinDays > MaxAttendanceDays || s.AbsentForDays < 0 || s.AbsentForDays > MaxAttendanceDays {
+		return ErrInvalidDays
+	}
+	if s.AttendedWithinDays > 0 && s.AbsentForDays > 0 {
+		return ErrConflictingDays
+	}
+	if len(s.Tags) == 0 && s.Program == "" && s.Status == "" && s.AttendedWithinDays == 0 && s.AbsentForDays == 0 {
+		return ErrEmptySegment
+	}
+	return nil
+}
+
+// MatchesAttendance reports whether a member whose last check-in was at lastCheckIn (zero
+// for never) passes the segment's attendance filter at now.
+// PRE: none
+// POST: Returns true when the segment has no attendance filter
+// INVARIANT: Pure function, no side effects
+func (s Segment) MatchesAttendance(lastCheckIn, now time.Time) bool {
+	if s.AttendedWithinDays > 0 {
+		return !lastCheckIn.IsZero() && !lastCheckIn.Before(now.AddDate(0, 0, -s.AttendedWithinDays))
+	}
+	if s.AbsentForDays > 0 {
+		return lastCheckIn.IsZero() || lastCheckIn.Before(now.AddDate(0, 0, -s.AbsentForDays))
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package membertag_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/membertag"
+)
+
+// TestNormalizeTag tests tags are lowercased and dashed, and bad characters rejected.
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr error
+	}{
+		{"competitor", "competitor", nil},
+		{"  Needs Follow_Up ", "needs-follow-up", nil},
+		{"", "", membertag.ErrEmptyTag},
+		{"   ", "", membertag.ErrEmptyTag},
+		{"injured!", "", membertag.ErrInvalidTag},
+		{"a-very-long-tag-name-that-goes-past-the-limit", "", membertag.ErrTagTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := membertag.NormalizeTag(tt.raw)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("NormalizeTag(%q) = %q, %v; want %q, %v", tt.raw, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestSegment_Validate tests validation of saved segments.
+func TestSegment_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		s    membertag.Segment
+		want error
+	}{
+		{"tags only", membertag.Segment{Name: "Comp team", Tags: []string{"Competitor"}}, nil},
+		{"program and absence", membertag.Segment{Name: "Lapsed kids", Program: "kids", AbsentForDays: 30}, nil},
+		{"no name", membertag.Segment{Tags: []string{"competitor"}}, membertag.ErrEmptySegmentName},
+		{"no filters", membertag.Segment{Name: "Everyone"}, membertag.ErrEmptySegment},
+		{"bad tag", membertag.Segment{Name: "X", Tags: []string{"a/b"}}, membertag.ErrInvalidTag},
+		{"both day filters", membertag.Segment{Name: "X", AttendedWithinDays: 7, AbsentForDays: 30}, membertag.ErrConflictingDays},
+		{"negative days", membertag.Segment{Name: "X", AbsentForDays: -1}, membertag.ErrInvalidDays},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.s.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	s := membertag.Segment{Name: "X", Tags: []string{"Injured", "injured", "needs follow up"}}
+	if err := s.Validate(); err != nil || len(s.Tags) != 2 || s.Tags[1] != "needs-follow-up" {
+		t.Errorf("expected normalised, de-duplicated tags, got %v (%v)", s.Tags, err)
+	}
+}
+
+// TestSegment_MatchesAttendance tests the recent-attendance and absence filters.
+func TestSegment_MatchesAttendance(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	recent := now.AddDate(0, 0, -3)
+	old := now.AddDate(0, 0, -60)
+
+	attended := membertag.Segment{AttendedWithinDays: 7}
+	if !attended.MatchesAttendance(recent, now) || attended.MatchesAttendance(old, now) || attended.MatchesAttendance(time.Time{}, now) {
+		t.Error("attended-within filter should match only recent check-ins")
+	}
+	absent := membertag.Segment{AbsentForDays: 30}
+	if absent.MatchesAttendance(recent, now) || !absent.MatchesAttendance(old, now) || !absent.MatchesAttendance(time.Time{}, now) {
+		t.Error("absent-for filter should match old check-ins and members who never came")
+	}
+	if !(membertag.Segment{}).MatchesAttendance(time.Time{}, now) {
+		t.Error("no attendance filter should match everyone")
+	}
+}
@@ -0,0 +1,113 @@
+package message
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Attachment kinds.
+const (
+	AttachmentClip  = "clip"  // a library clip, embedded as a YouTube loop
+	AttachmentImage = "image" // an uploaded image
+	AttachmentLink  = "link"  // a web link shown as a preview card
+)
+
+// Attachment limits.
+const (
+	MaxAttachments     = 3
+	MaxImageBytes      = 5 << 20 // 5 MB
+	MaxLinkURLLength   = 2048
+	MaxLinkTitleLength = 200
+)
+
+// Attachment errors.
+var (
+	ErrTooManyAttachments    = errors.New("a message can have at most 3 attachments")
+	ErrUnknownAttachmentKind = errors.New("attachment kind must be clip, image or link")
+	ErrInvalidClipAttachment = errors.New("clip attachment needs a clip with a YouTube video")
+	ErrEmptyImageAttachment  = errors.New("image attachment needs an uploaded image")
+	ErrInvalidLinkURL        = errors.New("link must be an http or https URL of at most 2048 characters")
+	ErrLinkTitleTooLong      = errors.New("link title cannot exceed 200 characters")
+	ErrUnsupportedImageType  = errors.New("image must be a png, jpeg, webp or gif")
+	ErrImageTooLarge         = errors.New("image must be under 5 MB")
+)
+
+// imageTypes are the content types accepted for image attachments.
+var imageTypes = map[string]bool{"image/png": true, "image/jpeg": true, "image/webp": true, "image/gif": true}
+
+var youTubeIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{11}$`)
+
+// Attachment is a clip, image or link sent with a message.
+// Clip fields are copied from the library when the message is sent, so the embed
+// keeps working if the clip is later edited or removed.
+type Attachment struct {
+	Kind         string
+	ClipID       string `json:",omitempty"` // clip: the library clip
+	YouTubeID    string `json:",omitempty"` // clip: video to embed
+	StartSeconds int    `json:",omitempty"` // clip: loop start
+	EndSeconds   int    `json:",omitempty"` // clip: loop end
+	ImageID      string `json:",omitempty"` // image: the uploaded file
+	URL          string `json:",omitempty"` // link: http or https URL
+	Title        string `json:",omitempty"` // clip title, or the link's preview title
+}
+
+// Validate checks the attachment has what its kind needs.
+// PRE: none
+// POST: Returns nil if valid, the first violation otherwise
+func (a *Attachment) Validate() error {
+	switch a.Kind {
+	case AttachmentClip:
+		if a.ClipID == "" || !youTubeIDPattern.MatchString(a.YouTubeID) || a.StartSeconds < 0 || a.EndSeconds < a.StartSeconds {
+			return ErrInvalidClipAttachment
+		}
+	case AttachmentImage:
+		if a.ImageID == "" {
+			return ErrEmptyImageAttachment
+		}
+	case AttachmentLink:
+		if !validLinkURL(a.URL) {
+			return ErrInvalidLinkURL
+		}
+		if len(a.Title) > MaxLinkTitleLength {
+			return ErrLinkTitleTooLong
+		}
+	default:
+		return ErrUnknownAttachmentKind
+	}
+	return nil
+}
+
+// validLinkURL reports whether s is an absolute http or https URL with a host.
+func validLinkURL(s string) bool {
+	if s == "" || len(s) > MaxLinkURLLength || strings.ContainsAny(s, " \t\r\n") {
+		return false
+	}
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// CheckImage checks an uploaded image's sniffed content type and size.
+// PRE: contentType is sniffed from the file's bytes, not taken from the client
+// POST: Returns nil if the image may be attached, ErrUnsupportedImageType or ErrImageTooLarge otherwise
+func CheckImage(contentType string, size int64) error {
+	if !imageTypes[contentType] {
+		return ErrUnsupportedImageType
+	}
+	if size > MaxImageBytes {
+		return ErrImageTooLarge
+	}
+	return nil
+}
+
+// HasImage reports whether the message carries the uploaded image.
+// INVARIANT: Message is not mutated
+func (m *Message) HasImage(imageID string) bool {
+	for _, a := range m.Attachments {
+		if a.Kind == AttachmentImage && a.ImageID == imageID {
+			return true
+		}
+	}
+	return false
+}
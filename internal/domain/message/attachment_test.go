@@ -0,0 +1,77 @@
+package message_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/message"
+)
+
+// TestAttachment_Validate tests each attachment kind's requirements.
+func TestAttachment_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		a    message.Attachment
+		want error
+	}{
+		{"clip", message.Attachment{Kind: message.AttachmentClip, ClipID: "c1", YouTubeID: "dQw4w9WgXcQ", StartSeconds: 5, EndSeconds: 20}, nil},
+		{"clip without video", message.Attachment{Kind: message.AttachmentClip, ClipID: "c1"}, message.ErrInvalidClipAttachment},
+		{"clip with bad video ID", message.Attachment{Kind: message.AttachmentClip, ClipID: "c1", YouTubeID: "x\"><script>"}, message.ErrInvalidClipAttachment},
+		{"image", message.Attachment{Kind: message.AttachmentImage, ImageID: "img1"}, nil},
+		{"image without upload", message.Attachment{Kind: message.AttachmentImage}, message.ErrEmptyImageAttachment},
+		{"link", message.Attachment{Kind: message.AttachmentLink, URL: "https://example.com/a?b=c", Title: "Guide"}, nil},
+		{"relative link", message.Attachment{Kind: message.AttachmentLink, URL: "/admin"}, message.ErrInvalidLinkURL},
+		{"javascript link", message.Attachment{Kind: message.AttachmentLink, URL: "javascript:alert(1)"}, message.ErrInvalidLinkURL},
+		{"long link title", message.Attachment{Kind: message.AttachmentLink, URL: "https://example.com", Title: strings.Repeat("x", message.MaxLinkTitleLength+1)}, message.ErrLinkTitleTooLong},
+		{"unknown kind", message.Attachment{Kind: "file"}, message.ErrUnknownAttachmentKind},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.a.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestMessage_ValidateAttachments tests attachments stand in for content and are capped.
+func TestMessage_ValidateAttachments(t *testing.T) {
+	link := message.Attachment{Kind: message.AttachmentLink, URL: "https://example.com"}
+	msg := message.Message{ID: "1", SenderID: "coach1", ReceiverID: "m1", Attachments: []message.Attachment{link}, CreatedAt: time.Now()}
+	if err := msg.Validate(); err != nil {
+		t.Errorf("attachment without text: %v", err)
+	}
+	msg.Attachments = []message.Attachment{link, link, link, link}
+	if err := msg.Validate(); !errors.Is(err, message.ErrTooManyAttachments) {
+		t.Errorf("four attachments: got %v, want ErrTooManyAttachments", err)
+	}
+	msg.Attachments = []message.Attachment{{Kind: message.AttachmentImage}}
+	if err := msg.Validate(); !errors.Is(err, message.ErrEmptyImageAttachment) {
+		t.Errorf("invalid attachment: got %v, want ErrEmptyImageAttachment", err)
+	}
+	if !(&message.Message{Attachments: []message.Attachment{{Kind: message.AttachmentImage, ImageID: "img1"}}}).HasImage("img1") {
+		t.Error("HasImage(img1) = false")
+	}
+}
+
+// TestCheckImage tests the accepted image types and size limit.
+func TestCheckImage(t *testing.T) {
+	tests := []struct {
+		contentType string
+		size        int64
+		want        error
+	}{
+		{"image/png", 1024, nil},
+		{"image/webp", message.MaxImageBytes, nil},
+		{"image/svg+xml", 1024, message.ErrUnsupportedImageType},
+		{"text/html; charset=utf-8", 1024, message.ErrUnsupportedImageType},
+		{"image/jpeg", message.MaxImageBytes + 1, message.ErrImageTooLarge},
+	}
+	for _, tt := range tests {
+		if err := message.CheckImage(tt.contentType, tt.size); !errors.Is(err, tt.want) {
+			t.Errorf("CheckImage(%q, %d) = %v, want %v", tt.contentType, tt.size, err, tt.want)
+		}
+	}
+}
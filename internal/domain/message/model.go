@@ -16,17 +16,26 @@ var (
 	ErrEmptySenderID   = errors.New("sender ID is required")
 	ErrEmptyReceiverID = errors.New("receiver ID (member) is required")
 	ErrEmptyContent    = errors.New("message content cannot be empty")
+	ErrParentIsSelf    = errors.New("a message cannot reply to itself")
+	ErrSubjectTooLong  = errors.New("message subject cannot exceed 200 characters")
+	ErrContentTooLong  = errors.New("message content cannot exceed 5000 characters")
 )
 
-// Message represents a direct in-app message from Admin to a member.
+// Message represents a direct in-app message between staff and a member.
+// Messages form flat threads: replies point at the message that started the thread.
+// INVARIANT: ReceiverID is the member the thread is with, whichever side wrote the message
 type Message struct {
 	ID         string
-	SenderID   string // Admin AccountID
+	ParentID   string // ID of the thread's first message; empty when this message starts a thread
+	SenderID   string // AccountID of the author (coach/admin, or the member for replies)
 	ReceiverID string // Member ID
+	FromMember bool   // true when the member wrote it; staff read it, not the member
 	Subject    string
 	Content    string
-	ReadAt     time.Time
-	CreatedAt  time.Time
+	// Attachments are clips, images and links sent with the message; only staff attach them.
+	Attachments []Attachment
+	ReadAt      time.Time
+	CreatedAt   time.Time
 }
 
 // Validate checks if the Message has valid data.
@@ -39,14 +48,25 @@ func (m *Message) Validate() error {
 	if m.ReceiverID == "" {
 		return ErrEmptyReceiverID
 	}
-	if m.Content == "" {
+	if m.Content == "" && len(m.Attachments) == 0 {
 		return ErrEmptyContent
 	}
+	if m.ParentID != "" && m.ParentID == m.ID {
+		return ErrParentIsSelf
+	}
 	if len(m.Subject) > MaxSubjectLength {
-		return errors.New("message subject cannot exceed 200 characters")
+		return ErrSubjectTooLong
 	}
 	if len(m.Content) > MaxContentLength {
-		return errors.New("message content cannot exceed 5000 characters")
+		return ErrContentTooLong
+	}
+	if len(m.Attachments) > MaxAttachments {
+		return ErrTooManyAttachments
+	}
+	for i := range m.Attachments {
+		if err := m.Attachments[i].Validate(); err != nil {
+			return err
+		}
 	}
 	if m.CreatedAt.IsZero() {
 		return errors.New("created_at must be set")
@@ -68,3 +88,36 @@ func (m *Message) MarkRead() {
 		m.ReadAt = time.Now()
 	}
 }
+
+// ThreadID returns the ID of the thread the message belongs to.
+// INVARIANT: Message is not mutated
+func (m *Message) ThreadID() string {
+	if m.ParentID != "" {
+		return m.ParentID
+	}
+	return m.ID
+}
+
+// Reply builds a reply in the same thread, addressed to the same member.
+// Replies to a reply attach to the thread's first message, keeping threads flat.
+// PRE: m is a persisted message; id is unique
+// POST: Returns an unsaved message with ParentID set to m's thread and m's subject
+func (m *Message) Reply(id, senderID string, fromMember bool, content string, now time.Time) Message {
+	return Message{
+		ID:         id,
+		ParentID:   m.ThreadID(),
+		SenderID:   senderID,
+		ReceiverID: m.ReceiverID,
+		FromMember: fromMember,
+		Subject:    m.Subject,
+		Content:    content,
+		CreatedAt:  now,
+	}
+}
+
+// IsUnreadBy reports whether the message is waiting to be read by one side of the thread.
+// Members read staff messages; staff read member replies.
+// INVARIANT: Message is not mutated
+func (m *Message) IsUnreadBy(member bool) bool {
+	return m.FromMember != member && !m.IsRead()
+}
@@ -39,6 +39,16 @@ func TestMessage_Validate(t *testing.T) {
 			msg:     message.Message{ID: "5", SenderID: "admin1", ReceiverID: "m1", Content: "Hello!"},
 			wantErr: true,
 		},
+		{
+			name:    "valid reply",
+			msg:     message.Message{ID: "6", ParentID: "1", SenderID: "acct1", ReceiverID: "m1", FromMember: true, Content: "Thanks", CreatedAt: time.Now()},
+			wantErr: false,
+		},
+		{
+			name:    "reply to itself",
+			msg:     message.Message{ID: "7", ParentID: "7", SenderID: "admin1", ReceiverID: "m1", Content: "Hello!", CreatedAt: time.Now()},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -78,3 +88,53 @@ func TestMessage_ReadStatus(t *testing.T) {
 		}
 	})
 }
+
+// TestMessage_Reply tests that replies stay in a flat thread.
+func TestMessage_Reply(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	root := message.Message{ID: "root", SenderID: "coach1", ReceiverID: "m1", Subject: "Comp prep", Content: "Ready?", CreatedAt: now}
+
+	first := root.Reply("r1", "acct1", true, "Yes", now)
+	if first.ParentID != "root" || first.ReceiverID != "m1" || first.Subject != "Comp prep" || !first.FromMember {
+		t.Errorf("unexpected reply: %+v", first)
+	}
+	if err := first.Validate(); err != nil {
+		t.Errorf("reply should be valid: %v", err)
+	}
+
+	second := first.Reply("r2", "coach1", false, "Great", now)
+	if second.ParentID != "root" {
+		t.Errorf("reply to a reply should attach to the thread root, got %q", second.ParentID)
+	}
+	if root.ThreadID() != "root" || second.ThreadID() != "root" {
+		t.Errorf("ThreadID: root=%q second=%q", root.ThreadID(), second.ThreadID())
+	}
+}
+
+// TestMessage_IsUnreadBy tests which side of a thread an unread message is waiting for.
+func TestMessage_IsUnreadBy(t *testing.T) {
+	tests := []struct {
+		name       string
+		fromMember bool
+		read       bool
+		member     bool
+		want       bool
+	}{
+		{"staff message unread by member", false, false, true, true},
+		{"staff message not waiting for staff", false, false, false, false},
+		{"member reply unread by staff", true, false, false, true},
+		{"member reply not waiting for member", true, false, true, false},
+		{"read staff message", false, true, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := message.Message{ID: "1", SenderID: "a", ReceiverID: "m1", FromMember: tt.fromMember, Content: "c", CreatedAt: time.Now()}
+			if tt.read {
+				m.MarkRead()
+			}
+			if got := m.IsUnreadBy(tt.member); got != tt.want {
+				t.Errorf("IsUnreadBy(%v) = %v, want %v", tt.member, got, tt.want)
+			}
+		})
+	}
+}
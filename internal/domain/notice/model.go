@@ -9,6 +9,7 @@ import (
 const (
 	MaxTitleLength   = 200
 	MaxContentLength = 10000
+	MaxImageBytes    = 5 << 20 // 5 MB per uploaded image
 )
 
 // Notice types
@@ -16,12 +17,14 @@ const (
 	TypeSchoolWide    = "school_wide"
 	TypeClassSpecific = "class_specific"
 	TypeHoliday       = "holiday"
+	TypeGrading       = "grading" // promotion ceremony invitation, shown only to the invited members
 )
 
 // Notice statuses
 const (
 	StatusDraft     = "draft"
 	StatusPublished = "published"
+	StatusArchived  = "archived" // published, then past VisibleUntil; kept for the record
 )
 
 // Color presets — 7 predefined highlight colours for notices.
@@ -53,32 +56,38 @@ var ValidColors = []string{ColorOrange, ColorRed, ColorGreen, ColorBlue, ColorPu
 var (
 	ErrEmptyTitle    = errors.New("notice title cannot be empty")
 	ErrEmptyContent  = errors.New("notice content cannot be empty")
-	ErrInvalidType   = errors.New("notice type must be one of: school_wide, class_specific, holiday")
-	ErrInvalidStatus = errors.New("notice status must be one of: draft, published")
+	ErrInvalidType   = errors.New("notice type must be one of: school_wide, class_specific, holiday, grading")
+	ErrInvalidStatus = errors.New("notice status must be one of: draft, published, archived")
 	ErrInvalidColor  = errors.New("notice color must be one of: orange, red, green, blue, purple, teal, grey")
 	ErrAlreadyPinned = errors.New("notice is already pinned")
 	ErrNotPinned     = errors.New("notice is not pinned")
+
+	ErrUnsupportedImageType = errors.New("image must be a png, jpeg, webp or gif")
+	ErrImageTooLarge        = errors.New("image must be under 5 MB")
 )
 
+// imageTypes are the content types accepted for images in notice content.
+var imageTypes = map[string]bool{"image/png": true, "image/jpeg": true, "image/webp": true, "image/gif": true}
+
 // ValidTypes contains all valid notice types.
-var ValidTypes = []string{TypeSchoolWide, TypeClassSpecific, TypeHoliday}
+var ValidTypes = []string{TypeSchoolWide, TypeClassSpecific, TypeHoliday, TypeGrading}
 
 // ValidStatuses contains all valid notice statuses.
-var ValidStatuses = []string{StatusDraft, StatusPublished}
+var ValidStatuses = []string{StatusDraft, StatusPublished, StatusArchived}
 
 // Notice represents a notification in the system.
 // Types: school_wide (general announcements), class_specific (coach reminders),
-// holiday (auto-generated from Holiday entries).
+// holiday (auto-generated from Holiday entries), grading (auto-generated for a promotion ceremony).
 // Content supports Markdown formatting.
 type Notice struct {
 	ID           string
 	Type         string // school_wide, class_specific, holiday
-	Status       string // draft, published
+	Status       string // draft, published, archived
 	Title        string
 	Content      string // Markdown content
 	CreatedBy    string // AccountID of creator
 	PublishedBy  string // AccountID of publisher (empty if draft)
-	TargetID     string // ClassType ID for class_specific, Holiday ID for holiday, empty for school_wide
+	TargetID     string // ClassType ID for class_specific, Holiday ID for holiday, ceremony's calendar event ID for grading, empty for school_wide
 	AuthorName   string // Display name of the author
 	ShowAuthor   bool   // Whether to show author name when displayed
 	Color        string // Highlight colour preset (orange, red, green, blue, purple, teal, grey)
@@ -146,6 +155,26 @@ func (n *Notice) IsVisible(now time.Time) bool {
 	return true
 }
 
+// IsExpired returns true if the notice's scheduled window has closed.
+// PRE: now is the current time
+// POST: Returns true only when VisibleUntil is set and now is after it
+func (n *Notice) IsExpired(now time.Time) bool {
+	return !n.VisibleUntil.IsZero() && now.After(n.VisibleUntil)
+}
+
+// CheckImage checks an image uploaded for notice content by its sniffed content type and size.
+// PRE: contentType is sniffed from the file's bytes, not taken from the client
+// POST: Returns nil if the image may be used, ErrUnsupportedImageType or ErrImageTooLarge otherwise
+func CheckImage(contentType string, size int64) error {
+	if !imageTypes[contentType] {
+		return ErrUnsupportedImageType
+	}
+	if size > MaxImageBytes {
+		return ErrImageTooLarge
+	}
+	return nil
+}
+
 // Pin marks the notice as pinned.
 // PRE: Notice is not already pinned
 // POST: Pinned is true, PinnedAt is set
@@ -266,3 +266,36 @@ func TestNotice_EffectiveColor(t *testing.T) {
 		}
 	})
 }
+
+// TestNotice_IsExpired tests that only a closed scheduled window counts as expired.
+func TestNotice_IsExpired(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		until time.Time
+		want  bool
+	}{
+		{"no end date", time.Time{}, false},
+		{"ends later", now.Add(time.Hour), false},
+		{"ended", now.Add(-time.Second), true},
+	}
+	for _, tt := range tests {
+		n := notice.Notice{VisibleUntil: tt.until}
+		if got := n.IsExpired(now); got != tt.want {
+			t.Errorf("%s: IsExpired() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestCheckImage tests the accepted image types and the size limit.
+func TestCheckImage(t *testing.T) {
+	if err := notice.CheckImage("image/png", 1024); err != nil {
+		t.Errorf("png: %v", err)
+	}
+	if err := notice.CheckImage("text/html; charset=utf-8", 1024); err != notice.ErrUnsupportedImageType {
+		t.Errorf("html: got %v, want ErrUnsupportedImageType", err)
+	}
+	if err := notice.CheckImage("image/jpeg", notice.MaxImageBytes+1); err != notice.ErrImageTooLarge {
+		t.Errorf("oversized: got %v, want ErrImageTooLarge", err)
+	}
+}
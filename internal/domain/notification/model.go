@@ -0,0 +1,199 @@
+package notification
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+// Kind constants identify the domain event that produced a notification.
+const (
+	KindMessageReceived  = "message_received"
+	KindGradingApproved  = "grading_approved"
+	KindGradingProposed  = "grading_proposed" // proposed for promotion, or booked onto a grading day
+	KindMilestoneEarned  = "milestone_earned"
+	KindNoticePublished  = "notice_published"
+	KindBugReportUpdated = "bug_report_updated" // a member's bug report changed status or got a reply
+	KindClassReminder    = "class_reminder"     // one of the member's usual classes starts within the hour
+	KindHoursReviewed    = "hours_reviewed"     // a reviewer asked about, or adjusted, the member's self-estimate
+	KindClassOvercrowded = "class_overcrowded"  // staff: a class's headcount went over its mat capacity
+)
+
+// ValidKinds contains all valid notification kinds.
+var ValidKinds = []string{KindMessageReceived, KindGradingProposed, KindGradingApproved, KindMilestoneEarned, KindNoticePublished, KindBugReportUpdated, KindClassReminder, KindHoursReviewed, KindClassOvercrowded}
+
+// Channel constants for delivery preferences.
+const (
+	ChannelInApp = "in_app"
+	ChannelEmail = "email"
+	ChannelPush  = "push"
+)
+
+// Max length constants for generated fields.
+const (
+	MaxTitleLength = 200
+	MaxBodyLength  = 1000
+
+	MaxEndpointLength = 2000 // push service URLs are long but bounded
+	MaxKeyLength      = 200
+)
+
+// Domain errors
+var (
+	ErrEmptyAccountID = errors.New("notification account ID is required")
+	ErrInvalidKind    = errors.New("notification kind must be one of: message_received, grading_proposed, grading_approved, milestone_earned, notice_published, bug_report_updated, class_reminder, hours_reviewed, class_overcrowded")
+	ErrEmptyTitle     = errors.New("notification title cannot be empty")
+	ErrTitleTooLong   = errors.New("notification title cannot exceed 200 characters")
+	ErrBodyTooLong    = errors.New("notification body cannot exceed 1000 characters")
+
+	ErrInvalidEndpoint = errors.New("push endpoint must be an https URL of at most 2000 characters")
+	ErrInvalidPushKeys = errors.New("push subscription needs its p256dh and auth keys")
+)
+
+// Notification is an in-app alert delivered to a single account.
+type Notification struct {
+	ID        string
+	AccountID string // recipient
+	Kind      string // message_received, grading_proposed, grading_approved, milestone_earned, notice_published, bug_report_updated, class_reminder, hours_reviewed, class_overcrowded
+	Title     string
+	Body      string
+	Link      string // optional in-app URL to open when clicked
+	ReadAt    time.Time
+	CreatedAt time.Time
+}
+
+// Validate checks if the Notification has valid data.
+// PRE: Notification struct is populated
+// POST: Returns nil if valid, error otherwise
+func (n *Notification) Validate() error {
+	if n.AccountID == "" {
+		return ErrEmptyAccountID
+	}
+	if !IsValidKind(n.Kind) {
+		return ErrInvalidKind
+	}
+	if n.Title == "" {
+		return ErrEmptyTitle
+	}
+	if len(n.Title) > MaxTitleLength {
+		return ErrTitleTooLong
+	}
+	if len(n.Body) > MaxBodyLength {
+		return ErrBodyTooLong
+	}
+	if n.CreatedAt.IsZero() {
+		return errors.New("created_at must be set")
+	}
+	return nil
+}
+
+// IsRead returns true if the notification has been read.
+// INVARIANT: ReadAt field is not mutated
+func (n *Notification) IsRead() bool {
+	return !n.ReadAt.IsZero()
+}
+
+// MarkRead records when the notification was read.
+// PRE: Notification exists
+// POST: ReadAt is set to now if previously zero
+func (n *Notification) MarkRead(now time.Time) {
+	if n.ReadAt.IsZero() {
+		n.ReadAt = now
+	}
+}
+
+// Preference controls how an account is told about one kind of notification.
+// Accounts without a stored preference get DefaultPreference.
+type Preference struct {
+	AccountID string
+	Kind      string
+	InApp     bool
+	Email     bool
+	Push      bool // sent to every browser the account subscribed for Web Push
+}
+
+// Validate checks if the Preference has valid data.
+// PRE: Preference struct is populated
+// POST: Returns nil if valid, error otherwise
+func (p *Preference) Validate() error {
+	if p.AccountID == "" {
+		return ErrEmptyAccountID
+	}
+	if !IsValidKind(p.Kind) {
+		return ErrInvalidKind
+	}
+	return nil
+}
+
+// DefaultPreference returns the preference used when an account has not chosen one:
+// in-app on, email and push off. Class reminders are only worth having as a push,
+// so they start with every channel off.
+// INVARIANT: Pure function, no side effects
+func DefaultPreference(accountID, kind string) Preference {
+	return Preference{AccountID: accountID, Kind: kind, InApp: kind != KindClassReminder}
+}
+
+// ResolvePreferences fills in defaults for every kind the account has not configured.
+// The result is ordered as ValidKinds.
+// INVARIANT: Pure function, no side effects
+func ResolvePreferences(accountID string, stored []Preference) []Preference {
+	byKind := make(map[string]Preference, len(stored))
+	for _, p := range stored {
+		byKind[p.Kind] = p
+	}
+	out := make([]Preference, 0, len(ValidKinds))
+	for _, k := range ValidKinds {
+		if p, ok := byKind[k]; ok {
+			out = append(out, p)
+			continue
+		}
+		out = append(out, DefaultPreference(accountID, k))
+	}
+	return out
+}
+
+// IsValidKind reports whether kind is a known notification kind.
+func IsValidKind(kind string) bool {
+	for _, k := range ValidKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// PushSubscription is one browser's Web Push subscription for an account.
+// Endpoint is the push service URL; P256dh and Auth are the browser's
+// base64url-encoded encryption keys.
+type PushSubscription struct {
+	ID        string
+	AccountID string
+	Endpoint  string
+	P256dh    string
+	Auth      string
+	UserAgent string
+	CreatedAt time.Time
+}
+
+// Validate checks if the PushSubscription has valid data.
+// PRE: PushSubscription struct is populated
+// POST: Returns nil if valid, error otherwise
+func (p *PushSubscription) Validate() error {
+	if p.AccountID == "" {
+		return ErrEmptyAccountID
+	}
+	if len(p.Endpoint) > MaxEndpointLength {
+		return ErrInvalidEndpoint
+	}
+	u, err := url.Parse(p.Endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return ErrInvalidEndpoint
+	}
+	if p.P256dh == "" || p.Auth == "" || len(p.P256dh) > MaxKeyLength || len(p.Auth) > MaxKeyLength {
+		return ErrInvalidPushKeys
+	}
+	if p.CreatedAt.IsZero() {
+		return errors.New("created_at must be set")
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package notification_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/notification"
+)
+
+// TestNotification_Validate tests validation of Notification.
+func TestNotification_Validate(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	valid := func() notification.Notification {
+		return notification.Notification{ID: "n1", AccountID: "a1", Kind: notification.KindMessageReceived, Title: "New message", CreatedAt: now}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(n *notification.Notification)
+		wantErr error
+	}{
+		{"valid", func(n *notification.Notification) {}, nil},
+		{"missing account", func(n *notification.Notification) { n.AccountID = "" }, notification.ErrEmptyAccountID},
+		{"unknown kind", func(n *notification.Notification) { n.Kind = "birthday" }, notification.ErrInvalidKind},
+		{"empty title", func(n *notification.Notification) { n.Title = "" }, notification.ErrEmptyTitle},
+		{"title too long", func(n *notification.Notification) { n.Title = strings.Repeat("a", notification.MaxTitleLength+1) }, notification.ErrTitleTooLong},
+		{"body too long", func(n *notification.Notification) { n.Body = strings.Repeat("a", notification.MaxBodyLength+1) }, notification.ErrBodyTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := valid()
+			tt.mutate(&n)
+			if err := n.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestNotification_MarkRead verifies MarkRead keeps the first read time.
+func TestNotification_MarkRead(t *testing.T) {
+	first := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	n := notification.Notification{}
+	n.MarkRead(first)
+	n.MarkRead(first.Add(time.Hour))
+	if !n.IsRead() || !n.ReadAt.Equal(first) {
+		t.Errorf("expected ReadAt=%v, got %v", first, n.ReadAt)
+	}
+}
+
+// TestResolvePreferences verifies stored preferences override defaults for every kind.
+func TestResolvePreferences(t *testing.T) {
+	stored := []notification.Preference{
+		{AccountID: "a1", Kind: notification.KindGradingApproved, InApp: false, Email: true},
+	}
+	got := notification.ResolvePreferences("a1", stored)
+	if len(got) != len(notification.ValidKinds) {
+		t.Fatalf("expected %d preferences, got %d", len(notification.ValidKinds), len(got))
+	}
+	for _, p := range got {
+		if p.Kind == notification.KindGradingApproved {
+			if p.InApp || !p.Email {
+				t.Errorf("stored preference not applied: %+v", p)
+			}
+			continue
+		}
+		if p.Kind == notification.KindClassReminder {
+			if p.InApp || p.Email || p.Push {
+				t.Errorf("expected class reminders off by default, got %+v", p)
+			}
+			continue
+		}
+		if !p.InApp || p.Email || p.Push {
+			t.Errorf("expected default in-app only for %s, got %+v", p.Kind, p)
+		}
+	}
+}
+
+// TestPushSubscription_Validate tests validation of PushSubscription.
+func TestPushSubscription_Validate(t *testing.T) {
+	valid := func() notification.PushSubscription {
+		return notification.PushSubscription{
+			AccountID: "a1", Endpoint: "https://fcm.googleapis.com/fcm/send/abc",
+			P256dh: "BPk", Auth: "c2VjcmV0", CreatedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(p *notification.PushSubscription)
+		wantErr error
+	}{
+		{"valid", func(p *notification.PushSubscription) {}, nil},
+		{"missing account", func(p *notification.PushSubscription) { p.AccountID = "" }, notification.ErrEmptyAccountID},
+		{"plain http", func(p *notification.PushSubscription) { p.Endpoint = "http://push.example.com/x" }, notification.ErrInvalidEndpoint},
+		{"not a URL", func(p *notification.PushSubscription) { p.Endpoint = "fcm" }, notification.ErrInvalidEndpoint},
+		{"endpoint too long", func(p *notification.PushSubscription) {
+			p.Endpoint = "https://push.example.com/" + strings.Repeat("a", notification.MaxEndpointLength)
+		}, notification.ErrInvalidEndpoint},
+		{"missing auth", func(p *notification.PushSubscription) { p.Auth = "" }, notification.ErrInvalidPushKeys},
+		{"missing p256dh", func(p *notification.PushSubscription) { p.P256dh = "" }, notification.ErrInvalidPushKeys},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid()
+			tt.mutate(&p)
+			if err := p.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -10,23 +10,32 @@ const (
 	MaxContentLength = 1000
 )
 
+// Visibility levels. An empty visibility is read as VisibilityCoaches.
+const (
+	VisibilityCoaches = "coaches" // coaches and admins
+	VisibilityAdmin   = "admin"   // admins only
+	VisibilityShared  = "shared"  // coaches, admins and the member, as feedback in their training log
+)
+
 // Domain errors
 var (
-	ErrEmptyMemberID = errors.New("member ID is required")
-	ErrEmptyAuthorID = errors.New("author ID is required")
-	ErrEmptyContent  = errors.New("observation content cannot be empty")
+	ErrEmptyMemberID     = errors.New("member ID is required")
+	ErrEmptyAuthorID     = errors.New("author ID is required")
+	ErrEmptyContent      = errors.New("observation content cannot be empty")
+	ErrInvalidVisibility = errors.New("visibility must be coaches, admin or shared")
 )
 
-// Observation represents a private per-student note written by a Coach or Admin.
-// Not visible to the student. Used for technique feedback, grading observations,
-// and behavioural notes.
+// Observation represents a per-student note written by a Coach or Admin.
+// Used for technique feedback, grading observations, and behavioural notes.
+// The student only sees observations shared with them.
 type Observation struct {
-	ID        string
-	MemberID  string // The student being observed
-	AuthorID  string // Coach or Admin AccountID
-	Content   string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID         string
+	MemberID   string // The student being observed
+	AuthorID   string // Coach or Admin AccountID
+	Content    string
+	Visibility string // coaches, admin or shared
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 // Validate checks if the Observation has valid data.
@@ -48,5 +57,28 @@ func (o *Observation) Validate() error {
 	if o.CreatedAt.IsZero() {
 		return errors.New("created_at must be set")
 	}
+	if !IsValidVisibility(o.Visibility) {
+		return ErrInvalidVisibility
+	}
 	return nil
 }
+
+// IsValidVisibility reports whether v is a visibility level; empty counts as the default.
+func IsValidVisibility(v string) bool {
+	return v == "" || v == VisibilityCoaches || v == VisibilityAdmin || v == VisibilityShared
+}
+
+// VisibleTo reports whether an account with the given role may read the observation.
+// ownMember is true when the reader is the observed member.
+// PRE: Observation is initialized
+// POST: Admins see everything, coaches everything but admin-only, others only their own shared observations
+func (o *Observation) VisibleTo(role string, ownMember bool) bool {
+	switch role {
+	case "admin":
+		return true
+	case "coach":
+		return o.Visibility != VisibilityAdmin
+	default:
+		return ownMember && o.Visibility == VisibilityShared
+	}
+}
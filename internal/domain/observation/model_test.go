@@ -50,3 +50,41 @@ func TestObservation_Validate(t *testing.T) {
 		})
 	}
 }
+
+// TestObservation_VisibleTo verifies each role sees only the visibility levels meant for it.
+func TestObservation_VisibleTo(t *testing.T) {
+	tests := []struct {
+		visibility string
+		role       string
+		ownMember  bool
+		want       bool
+	}{
+		{"", "coach", false, true},
+		{observation.VisibilityCoaches, "admin", false, true},
+		{observation.VisibilityCoaches, "member", true, false},
+		{observation.VisibilityAdmin, "admin", false, true},
+		{observation.VisibilityAdmin, "coach", false, false},
+		{observation.VisibilityShared, "coach", false, true},
+		{observation.VisibilityShared, "member", true, true},
+		{observation.VisibilityShared, "trial", true, true},
+		{observation.VisibilityShared, "member", false, false},
+	}
+	for _, tt := range tests {
+		o := observation.Observation{Visibility: tt.visibility}
+		if got := o.VisibleTo(tt.role, tt.ownMember); got != tt.want {
+			t.Errorf("%q VisibleTo(%s, own=%v) = %v, want %v", tt.visibility, tt.role, tt.ownMember, got, tt.want)
+		}
+	}
+}
+
+// TestObservation_Validate_Visibility verifies unknown visibility levels are rejected.
+func TestObservation_Validate_Visibility(t *testing.T) {
+	o := observation.Observation{MemberID: "m1", AuthorID: "coach1", Content: "note", CreatedAt: time.Now(), Visibility: "everyone"}
+	if err := o.Validate(); err != observation.ErrInvalidVisibility {
+		t.Errorf("Validate() = %v, want ErrInvalidVisibility", err)
+	}
+	o.Visibility = observation.VisibilityShared
+	if err := o.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
@@ -16,8 +16,9 @@ const (
 
 // Action type constants for different external integrations.
 const (
-	ActionTypeGitHubIssue = "github_issue"
-	ActionTypeEmail       = "email"
+	ActionTypeGitHubIssue  = "github_issue"
+	ActionTypeEmail        = "email"
+	ActionTypeMemberExport = "member_export"
 )
 
 // Domain errors.
@@ -0,0 +1,216 @@
+// Package perfstats holds request and query timings aggregated per minute, which outlive
+// the in-memory perf collector, and estimates latency percentiles over any window from them.
+package perfstats
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// BucketWidth is the period one timing bucket covers.
+const BucketWidth = time.Minute
+
+// Bucket kinds.
+const (
+	KindRequest = "request"
+	KindQuery   = "query"
+)
+
+// LatencyBoundsMs are the histogram upper bounds, in milliseconds, used to estimate
+// percentiles. Roughly logarithmic, so the estimate stays within a few percent from
+// sub-millisecond queries up to multi-second requests.
+var LatencyBoundsMs = []float64{
+	0.25, 0.5, 1, 2, 3, 5, 7.5, 10, 15, 20, 30, 50, 75, 100, 150, 200, 300, 500, 750,
+	1000, 1500, 2000, 3000, 5000, 7500, 10000,
+}
+
+// Domain errors
+var (
+	ErrInvalidKind   = errors.New("bucket kind must be request or query")
+	ErrEmptyName     = errors.New("bucket name is required")
+	ErrInvalidCounts = errors.New("bucket histogram does not match the latency bounds")
+)
+
+// Bucket aggregates the timings of one route or query operation over one minute.
+// Percentiles over any window are estimated by merging bucket histograms.
+type Bucket struct {
+	Kind   string    // KindRequest or KindQuery
+	Name   string    // "METHOD /route/pattern" for requests, the TimedDB operation for queries
+	Minute time.Time // start of the minute, UTC
+	Count  int64
+	SumMs  float64
+	MaxMs  float64
+	Counts []uint64 // non-cumulative count per LatencyBoundsMs bound; last slot is above the highest bound
+}
+
+// NewCounts returns an empty histogram for a bucket.
+// PRE: none
+// POST: Returns one zero count per bound plus the overflow slot
+func NewCounts() []uint64 {
+	return make([]uint64, len(LatencyBoundsMs)+1)
+}
+
+// Validate checks if the Bucket has valid data.
+// PRE: Bucket struct is populated
+// POST: Returns nil if valid, error otherwise
+func (b *Bucket) Validate() error {
+	if b.Kind != KindRequest && b.Kind != KindQuery {
+		return ErrInvalidKind
+	}
+	if b.Name == "" {
+		return ErrEmptyName
+	}
+	if len(b.Counts) != len(LatencyBoundsMs)+1 {
+		return ErrInvalidCounts
+	}
+	return nil
+}
+
+// WindowStat summarises one route or operation, or all of them, over a window.
+type WindowStat struct {
+	Name  string
+	Count int64
+	AvgMs float64
+	P50Ms float64
+	P95Ms float64
+	P99Ms float64
+	MaxMs float64
+}
+
+// Point is one step of a latency chart.
+type Point struct {
+	Start time.Time
+	Count int64
+	P50Ms float64
+	P95Ms float64
+	P99Ms float64
+}
+
+// merged accumulates buckets into one histogram.
+type merged struct {
+	count  int64
+	sumMs  float64
+	maxMs  float64
+	counts []uint64
+}
+
+// add folds one bucket into the histogram. Buckets stored with other bounds are skipped.
+func (m *merged) add(b Bucket) {
+	if len(b.Counts) != len(LatencyBoundsMs)+1 {
+		return
+	}
+	if m.counts == nil {
+		m.counts = NewCounts()
+	}
+	for i, n := range b.Counts {
+		m.counts[i] += n
+	}
+	m.count += b.Count
+	m.sumMs += b.SumMs
+	if b.MaxMs > m.maxMs {
+		m.maxMs = b.MaxMs
+	}
+}
+
+// percentile estimates the p-th percentile by interpolating inside the bucket that holds it.
+// The open-ended top bucket is capped by the largest duration seen.
+func (m *merged) percentile(p float64) float64 {
+	if m.count == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(m.count)
+	var seen float64
+	for i, n := range m.counts {
+		if n == 0 {
+			continue
+		}
+		if seen+float64(n) >= rank {
+			lower := 0.0
+			if i > 0 {
+				lower = LatencyBoundsMs[i-1]
+			}
+			upper := m.maxMs
+			if i < len(LatencyBoundsMs) && LatencyBoundsMs[i] < upper {
+				upper = LatencyBoundsMs[i]
+			}
+			if upper < lower {
+				return upper
+			}
+			return lower + (upper-lower)*(rank-seen)/float64(n)
+		}
+		seen += float64(n)
+	}
+	return m.maxMs
+}
+
+// stat converts the histogram to a summary.
+func (m *merged) stat(name string) WindowStat {
+	s := WindowStat{Name: name, Count: m.count, MaxMs: m.maxMs}
+	if m.count > 0 {
+		s.AvgMs = m.sumMs / float64(m.count)
+		s.P50Ms = m.percentile(50)
+		s.P95Ms = m.percentile(95)
+		s.P99Ms = m.percentile(99)
+	}
+	return s
+}
+
+// Summarise merges the buckets of one kind into an overall summary and one per name,
+// the names sorted by P95 descending and cut to topN.
+// PRE: topN > 0
+// POST: overall.Count is the total count of the kind; byName has at most topN entries
+func Summarise(buckets []Bucket, kind string, topN int) (overall WindowStat, byName []WindowStat) {
+	var all merged
+	names := make(map[string]*merged)
+	for _, b := range buckets {
+		if b.Kind != kind {
+			continue
+		}
+		all.add(b)
+		m, ok := names[b.Name]
+		if !ok {
+			m = &merged{}
+			names[b.Name] = m
+		}
+		m.add(b)
+	}
+	byName = make([]WindowStat, 0, len(names))
+	for name, m := range names {
+		byName = append(byName, m.stat(name))
+	}
+	sort.Slice(byName, func(i, j int) bool {
+		if byName[i].P95Ms != byName[j].P95Ms {
+			return byName[i].P95Ms > byName[j].P95Ms
+		}
+		return byName[i].Name < byName[j].Name
+	})
+	if len(byName) > topN {
+		byName = byName[:topN]
+	}
+	return all.stat(""), byName
+}
+
+// Series splits [since, until) into steps and estimates the percentiles of one kind in each,
+// for charting. Steps without traffic are included with a zero Count.
+// PRE: step >= BucketWidth; since is before until
+// POST: Returns one point per step, oldest first
+func Series(buckets []Bucket, kind string, since, until time.Time, step time.Duration) []Point {
+	if step < BucketWidth || !since.Before(until) {
+		return nil
+	}
+	steps := int((until.Sub(since) + step - 1) / step)
+	hists := make([]merged, steps)
+	for _, b := range buckets {
+		if b.Kind != kind || b.Minute.Before(since) || !b.Minute.Before(until) {
+			continue
+		}
+		hists[int(b.Minute.Sub(since)/step)].add(b)
+	}
+	points := make([]Point, steps)
+	for i := range hists {
+		s := hists[i].stat("")
+		points[i] = Point{Start: since.Add(time.Duration(i) * step), Count: s.Count, P50Ms: s.P50Ms, P95Ms: s.P95Ms, P99Ms: s.P99Ms}
+	}
+	return points
+}
@@ -0,0 +1,106 @@
+package perfstats_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/perfstats"
+)
+
+// observe adds one duration to a bucket the way the perf collector does.
+func observe(b *perfstats.Bucket, durationMs float64) {
+	b.Counts[sort.SearchFloat64s(perfstats.LatencyBoundsMs, durationMs)]++
+	b.Count++
+	b.SumMs += durationMs
+	if durationMs > b.MaxMs {
+		b.MaxMs = durationMs
+	}
+}
+
+// TestBucketValidate tests validation of Bucket.
+func TestBucketValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       perfstats.Bucket
+		wantErr error
+	}{
+		{"valid", perfstats.Bucket{Kind: perfstats.KindQuery, Name: "QueryContext", Counts: perfstats.NewCounts()}, nil},
+		{"bad kind", perfstats.Bucket{Kind: "job", Name: "x", Counts: perfstats.NewCounts()}, perfstats.ErrInvalidKind},
+		{"no name", perfstats.Bucket{Kind: perfstats.KindRequest, Counts: perfstats.NewCounts()}, perfstats.ErrEmptyName},
+		{"short histogram", perfstats.Bucket{Kind: perfstats.KindRequest, Name: "GET /", Counts: []uint64{1}}, perfstats.ErrInvalidCounts},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.b.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestSummarise_Percentiles verifies percentiles estimated from merged buckets land close to
+// the exact values, and routes are ranked by P95.
+func TestSummarise_Percentiles(t *testing.T) {
+	start := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	// 1..100ms spread over three minutes, plus a fast route
+	var buckets []perfstats.Bucket
+	for m := 0; m < 3; m++ {
+		buckets = append(buckets, perfstats.Bucket{Kind: perfstats.KindRequest, Name: "GET /slow", Minute: start.Add(time.Duration(m) * time.Minute), Counts: perfstats.NewCounts()})
+	}
+	for i := 1; i <= 100; i++ {
+		observe(&buckets[i%3], float64(i))
+	}
+	fast := perfstats.Bucket{Kind: perfstats.KindRequest, Name: "GET /fast", Minute: start, Counts: perfstats.NewCounts()}
+	for i := 0; i < 10; i++ {
+		observe(&fast, 1)
+	}
+	buckets = append(buckets, fast)
+
+	_, routes := perfstats.Summarise(buckets, perfstats.KindRequest, 10)
+	if len(routes) != 2 || routes[0].Name != "GET /slow" {
+		t.Fatalf("routes = %+v, want /slow ranked first", routes)
+	}
+	slow := routes[0]
+	for _, tt := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"P50", slow.P50Ms, 50},
+		{"P95", slow.P95Ms, 95},
+		{"P99", slow.P99Ms, 99},
+	} {
+		if tt.got < tt.want*0.9 || tt.got > tt.want*1.1 {
+			t.Errorf("%s = %.1f, want within 10%% of %.0f", tt.name, tt.got, tt.want)
+		}
+	}
+	if slow.Count != 100 || slow.MaxMs != 100 || slow.AvgMs != 50.5 {
+		t.Errorf("slow = %+v", slow)
+	}
+	if _, top := perfstats.Summarise(buckets, perfstats.KindRequest, 1); len(top) != 1 {
+		t.Errorf("topN = 1 returned %d routes", len(top))
+	}
+}
+
+// TestSeries verifies points cover the window in steps, with empty steps left at zero.
+func TestSeries(t *testing.T) {
+	start := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	counts := perfstats.NewCounts()
+	counts[7] = 4 // (7.5, 10]
+	buckets := []perfstats.Bucket{
+		{Kind: perfstats.KindRequest, Name: "GET /a", Minute: start, Count: 4, SumMs: 36, MaxMs: 10, Counts: counts},
+		{Kind: perfstats.KindRequest, Name: "GET /a", Minute: start.Add(20 * time.Minute), Count: 4, SumMs: 36, MaxMs: 10, Counts: counts},
+		{Kind: perfstats.KindQuery, Name: "QueryContext", Minute: start.Add(10 * time.Minute), Count: 4, SumMs: 36, MaxMs: 10, Counts: counts},
+	}
+
+	points := perfstats.Series(buckets, perfstats.KindRequest, start, start.Add(30*time.Minute), 10*time.Minute)
+	if len(points) != 3 {
+		t.Fatalf("points = %+v, want three 10-minute steps", points)
+	}
+	if points[0].Count != 4 || points[1].Count != 0 || points[2].Count != 4 || !points[2].Start.Equal(start.Add(20*time.Minute)) {
+		t.Errorf("points = %+v, want traffic in the first and last steps only", points)
+	}
+	if points[0].P50Ms <= 7.5 || points[0].P99Ms > 10 {
+		t.Errorf("first point = %+v, want percentiles inside (7.5, 10]", points[0])
+	}
+}
@@ -0,0 +1,33 @@
+package permission
+
+// Defaults returns every configurable action with its default roles.
+//
+// The defaults reproduce the role checks the handlers made before the matrix
+// existed. System administration (accounts, feature flags, backups, this matrix)
+// is not listed: it is always admin-only.
+func Defaults() []Permission {
+	return []Permission{
+		{Action: ActionMembersView, Description: "View member list, profiles and inactive members; search members", AllowCoach: true},
+		{Action: ActionMembersExport, Description: "Export members to CSV or Excel", AllowCoach: true},
+		{Action: ActionReportsExport, Description: "Export attendance, grading records and estimated hours to CSV or Excel", AllowCoach: true},
+		{Action: ActionAttendanceKiosk, Description: "Launch the kiosk and sync offline check-ins", AllowCoach: true},
+		{Action: ActionAttendanceBackfill, Description: "Add attendance for past classes", AllowCoach: true},
+		{Action: ActionAttendanceRollCall, Description: "Take roll call for a class", AllowCoach: true},
+		{Action: ActionClassesChange, Description: "Cancel a class or assign a substitute coach, notifying recent attendees", AllowCoach: true},
+		{Action: ActionTrainingHoursReview, Description: "Record estimated hours and review member self-estimates", AllowCoach: true},
+		{Action: ActionGradingManage, Description: "View grading readiness and adjust member grading settings", AllowCoach: true},
+		{Action: ActionInjuriesView, Description: "View and update reported injuries", AllowCoach: true},
+		{Action: ActionLibraryView, Description: "Browse the technical library, clips, tags and comparisons", AllowCoach: true, AllowMember: true},
+		{Action: ActionLibraryEdit, Description: "Create themes and promote clips", AllowCoach: true},
+		{Action: ActionCurriculumView, Description: "View the curriculum, rotors and topics", AllowCoach: true, AllowMember: true},
+		{Action: ActionCurriculumEdit, Description: "Edit, activate, import and export rotors and topics", AllowCoach: true},
+		{Action: ActionSessionLogsManage, Description: "Record and review session logs", AllowCoach: true},
+		{Action: ActionCompetitionsRoster, Description: "View and export competition rosters", AllowCoach: true},
+		{Action: ActionLocationsView, Description: "View locations and pick the working location", AllowCoach: true},
+		{Action: ActionBugBoxSubmit, Description: "Submit Bug Box reports", AllowCoach: true},
+		{Action: ActionGoalsCoach, Description: "View members' personal goals and check-ins and annotate them", AllowCoach: true},
+		{Action: ActionMessagesBroadcast, Description: "Message every active member in a program", AllowCoach: true},
+		{Action: ActionCalendarView, Description: "View the calendar", AllowCoach: true, AllowMember: true, AllowTrial: true},
+		{Action: ActionFeedbackView, Description: "See the anonymised class feedback report for classes they coach", AllowCoach: true},
+	}
+}
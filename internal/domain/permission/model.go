@@ -0,0 +1,130 @@
+package permission
+
+import (
+	"errors"
+	"time"
+)
+
+// Action constants name the things a role can be allowed to do.
+// They are stable and referenced by handlers; the admin matrix is keyed by them.
+const (
+	ActionMembersView         = "members.view"
+	ActionMembersExport       = "members.export"
+	ActionReportsExport       = "reports.export"
+	ActionAttendanceKiosk     = "attendance.kiosk"
+	ActionAttendanceBackfill  = "attendance.backfill"
+	ActionAttendanceRollCall  = "attendance.rollcall"
+	ActionClassesChange       = "classes.change"
+	ActionTrainingHoursReview = "training_hours.review"
+	ActionGradingManage       = "grading.manage"
+	ActionInjuriesView        = "injuries.view"
+	ActionLibraryView         = "library.view"
+	ActionLibraryEdit         = "library.edit"
+	ActionCurriculumView      = "curriculum.view"
+	ActionCurriculumEdit      = "curriculum.edit"
+	ActionSessionLogsManage   = "session_logs.manage"
+	ActionCompetitionsRoster  = "competitions.roster"
+	ActionLocationsView       = "locations.view"
+	ActionBugBoxSubmit        = "bugbox.submit"
+	ActionCalendarView        = "calendar.view"
+	ActionMessagesBroadcast   = "messages.broadcast"
+	ActionGoalsCoach          = "goals.coach"
+	ActionFeedbackView        = "feedback.view"
+)
+
+// Role constants mirror account roles. Admin is not configurable.
+const (
+	RoleAdmin  = "admin"
+	RoleCoach  = "coach"
+	RoleMember = "member"
+	RoleTrial  = "trial"
+)
+
+// Domain errors.
+var (
+	ErrUnknownAction = errors.New("unknown permission action")
+)
+
+// Permission says which roles may perform an action.
+// Admins may always perform every action, so the matrix can never lock them out
+// of the page that edits it.
+// INVARIANT: Action is one of the Action constants
+type Permission struct {
+	Action      string
+	Description string
+	AllowCoach  bool
+	AllowMember bool
+	AllowTrial  bool
+	UpdatedBy   string    // account that last overrode the default; empty for defaults
+	UpdatedAt   time.Time // zero for defaults
+}
+
+// Validate checks that the action is known.
+// PRE: none
+// POST: Returns ErrUnknownAction if Action is not in Defaults
+func (p Permission) Validate() error {
+	if _, ok := DefaultByAction(p.Action); !ok {
+		return ErrUnknownAction
+	}
+	return nil
+}
+
+// Allows reports whether role may perform the action.
+// PRE: role is a session role string
+// POST: Returns true for admin; otherwise the role's flag; false for unknown roles
+func (p Permission) Allows(role string) bool {
+	switch role {
+	case RoleAdmin:
+		return true
+	case RoleCoach:
+		return p.AllowCoach
+	case RoleMember:
+		return p.AllowMember
+	case RoleTrial:
+		return p.AllowTrial
+	default:
+		return false
+	}
+}
+
+// IsDefault reports whether the role flags match the action's default.
+// PRE: p.Action is known
+// POST: Returns true if no override is needed to store p
+func (p Permission) IsDefault() bool {
+	d, ok := DefaultByAction(p.Action)
+	return ok && d.AllowCoach == p.AllowCoach && d.AllowMember == p.AllowMember && d.AllowTrial == p.AllowTrial
+}
+
+// DefaultByAction returns the default permission for action.
+// PRE: none
+// POST: Returns false if action is unknown
+func DefaultByAction(action string) (Permission, bool) {
+	for _, d := range Defaults() {
+		if d.Action == action {
+			return d, true
+		}
+	}
+	return Permission{}, false
+}
+
+// Merge applies stored overrides to the defaults.
+// Overrides for actions that no longer exist are ignored.
+// PRE: none
+// POST: Returns one permission per default action, in Defaults order
+func Merge(overrides []Permission) []Permission {
+	byAction := make(map[string]Permission, len(overrides))
+	for _, o := range overrides {
+		byAction[o.Action] = o
+	}
+	merged := Defaults()
+	for i, d := range merged {
+		if o, ok := byAction[d.Action]; ok {
+			merged[i].AllowCoach = o.AllowCoach
+			merged[i].AllowMember = o.AllowMember
+			merged[i].AllowTrial = o.AllowTrial
+			merged[i].UpdatedBy = o.UpdatedBy
+			merged[i].UpdatedAt = o.UpdatedAt
+		}
+	}
+	return merged
+}
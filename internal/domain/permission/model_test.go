@@ -0,0 +1,71 @@
+package permission_test
+
+import (
+	"errors"
+	"testing"
+
+	"workshop/internal/domain/permission"
+)
+
+// TestPermission_Allows tests role checks, including the admin invariant.
+func TestPermission_Allows(t *testing.T) {
+	p := permission.Permission{Action: permission.ActionLibraryView, AllowMember: true}
+	tests := []struct {
+		role string
+		want bool
+	}{
+		{permission.RoleAdmin, true},
+		{permission.RoleCoach, false},
+		{permission.RoleMember, true},
+		{permission.RoleTrial, false},
+		{"guest", false},
+	}
+	for _, tt := range tests {
+		if got := p.Allows(tt.role); got != tt.want {
+			t.Errorf("Allows(%q) = %v, want %v", tt.role, got, tt.want)
+		}
+	}
+}
+
+// TestMerge tests that overrides replace role flags and unknown actions are ignored.
+func TestMerge(t *testing.T) {
+	merged := permission.Merge([]permission.Permission{
+		{Action: permission.ActionMembersExport, AllowCoach: false, UpdatedBy: "admin-1"},
+		{Action: "retired.action", AllowMember: true},
+	})
+	if len(merged) != len(permission.Defaults()) {
+		t.Fatalf("got %d permissions, want %d", len(merged), len(permission.Defaults()))
+	}
+	for _, p := range merged {
+		switch p.Action {
+		case permission.ActionMembersExport:
+			if p.AllowCoach || p.UpdatedBy != "admin-1" || p.IsDefault() {
+				t.Errorf("override not applied: %+v", p)
+			}
+			if p.Description == "" {
+				t.Error("description should come from the default")
+			}
+		case permission.ActionMembersView:
+			if !p.AllowCoach || !p.IsDefault() {
+				t.Errorf("default changed: %+v", p)
+			}
+		}
+	}
+}
+
+// TestDefaults tests that every default action is unique and valid.
+func TestDefaults(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, d := range permission.Defaults() {
+		if seen[d.Action] {
+			t.Errorf("duplicate action %q", d.Action)
+		}
+		seen[d.Action] = true
+		if err := d.Validate(); err != nil {
+			t.Errorf("%s: %v", d.Action, err)
+		}
+	}
+	if err := (permission.Permission{Action: "nope"}).Validate(); !errors.Is(err, permission.ErrUnknownAction) {
+		t.Errorf("unknown action err = %v", err)
+	}
+}
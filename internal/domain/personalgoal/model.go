@@ -2,17 +2,51 @@ package personalgoal
 
 import (
 	"errors"
+	"strings"
 	"time"
 )
 
 // Domain errors
 var (
-	ErrEmptyTitle    = errors.New("title is required")
-	ErrEmptyMemberID = errors.New("member ID is required")
-	ErrZeroTarget    = errors.New("target must be greater than zero")
-	ErrInvalidDates  = errors.New("end date must be after start date")
+	ErrEmptyTitle          = errors.New("title is required")
+	ErrEmptyMemberID       = errors.New("member ID is required")
+	ErrZeroTarget          = errors.New("target must be greater than zero")
+	ErrInvalidDates        = errors.New("end date must be after start date")
+	ErrEmptyGoalID         = errors.New("goal ID is required")
+	ErrGoalCompleted       = errors.New("goal is already completed")
+	ErrInvalidCheckInState = errors.New("check-in status must be one of: on_track, at_risk, off_track")
+	ErrNegativeProgress    = errors.New("progress cannot be negative")
+	ErrNoteTooLong         = errors.New("note cannot exceed 500 characters")
+	ErrEmptyContent        = errors.New("annotation content is required")
+	ErrEmptyAuthorID       = errors.New("author ID is required")
 )
 
+// Goal types. Hours and sessions goals are auto-tracked from attendance.
+const (
+	TypeManual   = "manual"
+	TypeHours    = "hours"
+	TypeSessions = "sessions"
+)
+
+// Goal statuses. A goal is completed once its progress reaches the target.
+const (
+	StatusActive    = "active"
+	StatusCompleted = "completed"
+)
+
+// Check-in statuses a member reports against their goal.
+const (
+	CheckInOnTrack  = "on_track"
+	CheckInAtRisk   = "at_risk"
+	CheckInOffTrack = "off_track"
+)
+
+// CheckInInterval is how long after the last check-in (or the goal's start) a member is prompted again.
+const CheckInInterval = 7 * 24 * time.Hour
+
+// MaxNoteLength caps check-in notes and coach annotations.
+const MaxNoteLength = 500
+
 // PersonalGoal represents a member's personal training goal (e.g., "50 rear naked chokes in April").
 type PersonalGoal struct {
 	ID          string
@@ -28,6 +62,10 @@ type PersonalGoal struct {
 	Progress    int       // current progress (0 initially, auto-calculated for hours type)
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+
+	Status        string    // StatusActive or StatusCompleted; empty is treated as active
+	CompletedAt   time.Time // zero until completed
+	LastCheckInAt time.Time // zero until the member first checks in
 }
 
 // Validate checks if the PersonalGoal has valid data.
@@ -63,15 +101,58 @@ func (g *PersonalGoal) SetDefaultColor() {
 // POST: Type field is set to "manual" if empty
 func (g *PersonalGoal) SetDefaultType() {
 	if g.Type == "" {
-		g.Type = "manual"
+		g.Type = TypeManual
 	}
 }
 
 // IsAutoTracked returns true if the goal is auto-tracked from attendance.
 // PRE: Type field is set
-// POST: returns true for "hours" type goals
+// POST: returns true for "hours" and "sessions" type goals
 func (g *PersonalGoal) IsAutoTracked() bool {
-	return g.Type == "hours"
+	return g.Type == TypeHours || g.Type == TypeSessions
+}
+
+// IsCompleted reports whether the goal has been closed.
+// PRE: none
+// POST: returns true when Status is completed
+func (g *PersonalGoal) IsCompleted() bool {
+	return g.Status == StatusCompleted
+}
+
+// Reached reports whether progress has met the target.
+// PRE: Target > 0
+// POST: returns true when Progress >= Target
+func (g *PersonalGoal) Reached() bool {
+	return g.Target > 0 && g.Progress >= g.Target
+}
+
+// Complete closes the goal.
+// PRE: goal is not already completed
+// POST: Status is completed and CompletedAt is now; ErrGoalCompleted if it already was
+func (g *PersonalGoal) Complete(now time.Time) error {
+	if g.IsCompleted() {
+		return ErrGoalCompleted
+	}
+	g.Status = StatusCompleted
+	g.CompletedAt = now
+	g.UpdatedAt = now
+	return nil
+}
+
+// CheckInDue reports whether the member should be prompted to check in on the goal at now.
+// Open goals are due once CheckInInterval has passed since the last check-in, or since the start
+// if there has been none. Goals that are completed, not yet started or already over are never due.
+// PRE: StartDate and EndDate are set
+// POST: returns true when a check-in prompt should be shown
+func (g *PersonalGoal) CheckInDue(now time.Time) bool {
+	if g.IsCompleted() || !g.IsActiveForDate(now) {
+		return false
+	}
+	last := g.StartDate
+	if g.LastCheckInAt.After(last) {
+		last = g.LastCheckInAt
+	}
+	return !now.Before(last.Add(CheckInInterval))
 }
 
 // UpdateProgress updates the progress value.
@@ -108,3 +189,67 @@ func (g *PersonalGoal) ProgressPercentage() int {
 	}
 	return pct
 }
+
+// CheckIn is a member's periodic progress report on one of their goals.
+type CheckIn struct {
+	ID        string
+	GoalID    string
+	MemberID  string
+	Progress  int    // progress at the time of the check-in
+	Status    string // CheckInOnTrack, CheckInAtRisk or CheckInOffTrack
+	Note      string // optional
+	CreatedAt time.Time
+}
+
+// Validate checks if the CheckIn has valid data.
+// PRE: CheckIn struct is populated
+// POST: Returns nil if valid, error otherwise
+func (c *CheckIn) Validate() error {
+	if c.GoalID == "" {
+		return ErrEmptyGoalID
+	}
+	if c.MemberID == "" {
+		return ErrEmptyMemberID
+	}
+	if c.Progress < 0 {
+		return ErrNegativeProgress
+	}
+	switch c.Status {
+	case CheckInOnTrack, CheckInAtRisk, CheckInOffTrack:
+	default:
+		return ErrInvalidCheckInState
+	}
+	if len(c.Note) > MaxNoteLength {
+		return ErrNoteTooLong
+	}
+	return nil
+}
+
+// Annotation is a coach's comment on a member's goal, visible to the member.
+type Annotation struct {
+	ID         string
+	GoalID     string
+	AuthorID   string // account ID of the coach or admin
+	AuthorName string
+	Content    string
+	CreatedAt  time.Time
+}
+
+// Validate checks if the Annotation has valid data.
+// PRE: Annotation struct is populated
+// POST: Returns nil if valid, error otherwise
+func (a *Annotation) Validate() error {
+	if a.GoalID == "" {
+		return ErrEmptyGoalID
+	}
+	if a.AuthorID == "" {
+		return ErrEmptyAuthorID
+	}
+	if strings.TrimSpace(a.Content) == "" {
+		return ErrEmptyContent
+	}
+	if len(a.Content) > MaxNoteLength {
+		return ErrNoteTooLong
+	}
+	return nil
+}
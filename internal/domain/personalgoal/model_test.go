@@ -0,0 +1,99 @@
+package personalgoal_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/personalgoal"
+)
+
+// TestPersonalGoal_CheckInDue tests when a member is prompted to check in.
+func TestPersonalGoal_CheckInDue(t *testing.T) {
+	start := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	goal := func(modify func(g *personalgoal.PersonalGoal)) personalgoal.PersonalGoal {
+		g := personalgoal.PersonalGoal{StartDate: start, EndDate: start.AddDate(0, 1, 0), Status: personalgoal.StatusActive}
+		modify(&g)
+		return g
+	}
+	tests := []struct {
+		name string
+		goal personalgoal.PersonalGoal
+		now  time.Time
+		want bool
+	}{
+		{"first week", goal(func(g *personalgoal.PersonalGoal) {}), start.AddDate(0, 0, 6), false},
+		{"a week after start", goal(func(g *personalgoal.PersonalGoal) {}), start.AddDate(0, 0, 7), true},
+		{"recent check-in", goal(func(g *personalgoal.PersonalGoal) { g.LastCheckInAt = start.AddDate(0, 0, 10) }), start.AddDate(0, 0, 14), false},
+		{"stale check-in", goal(func(g *personalgoal.PersonalGoal) { g.LastCheckInAt = start.AddDate(0, 0, 10) }), start.AddDate(0, 0, 17), true},
+		{"completed", goal(func(g *personalgoal.PersonalGoal) { g.Status = personalgoal.StatusCompleted }), start.AddDate(0, 0, 20), false},
+		{"period over", goal(func(g *personalgoal.PersonalGoal) {}), start.AddDate(0, 2, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.goal.CheckInDue(tt.now); got != tt.want {
+				t.Errorf("CheckInDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPersonalGoal_Complete tests closing a goal once.
+func TestPersonalGoal_Complete(t *testing.T) {
+	now := time.Date(2026, 4, 20, 9, 0, 0, 0, time.UTC)
+	g := personalgoal.PersonalGoal{Target: 10, Progress: 10, Type: personalgoal.TypeSessions}
+	if !g.Reached() || !g.IsAutoTracked() {
+		t.Fatal("expected a reached, auto-tracked goal")
+	}
+	if err := g.Complete(now); err != nil || !g.IsCompleted() || !g.CompletedAt.Equal(now) {
+		t.Fatalf("Complete() = %v, goal %+v", err, g)
+	}
+	if err := g.Complete(now); err != personalgoal.ErrGoalCompleted {
+		t.Errorf("second Complete() = %v, want ErrGoalCompleted", err)
+	}
+}
+
+// TestCheckIn_Validate tests validation of CheckIn.
+func TestCheckIn_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		checkIn personalgoal.CheckIn
+		wantErr error
+	}{
+		{"valid", personalgoal.CheckIn{GoalID: "g1", MemberID: "m1", Progress: 3, Status: personalgoal.CheckInAtRisk}, nil},
+		{"missing goal", personalgoal.CheckIn{MemberID: "m1", Status: personalgoal.CheckInOnTrack}, personalgoal.ErrEmptyGoalID},
+		{"missing member", personalgoal.CheckIn{GoalID: "g1", Status: personalgoal.CheckInOnTrack}, personalgoal.ErrEmptyMemberID},
+		{"negative progress", personalgoal.CheckIn{GoalID: "g1", MemberID: "m1", Progress: -1, Status: personalgoal.CheckInOnTrack}, personalgoal.ErrNegativeProgress},
+		{"unknown status", personalgoal.CheckIn{GoalID: "g1", MemberID: "m1", Status: "great"}, personalgoal.ErrInvalidCheckInState},
+		{"long note", personalgoal.CheckIn{GoalID: "g1", MemberID: "m1", Status: personalgoal.CheckInOnTrack, Note: strings.Repeat("x", personalgoal.MaxNoteLength+1)}, personalgoal.ErrNoteTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.checkIn.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestAnnotation_Validate tests validation of Annotation.
+func TestAnnotation_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation personalgoal.Annotation
+		wantErr    error
+	}{
+		{"valid", personalgoal.Annotation{GoalID: "g1", AuthorID: "coach-1", Content: "Drill the entry from closed guard."}, nil},
+		{"missing goal", personalgoal.Annotation{AuthorID: "coach-1", Content: "x"}, personalgoal.ErrEmptyGoalID},
+		{"missing author", personalgoal.Annotation{GoalID: "g1", Content: "x"}, personalgoal.ErrEmptyAuthorID},
+		{"blank content", personalgoal.Annotation{GoalID: "g1", AuthorID: "coach-1", Content: "  "}, personalgoal.ErrEmptyContent},
+		{"long content", personalgoal.Annotation{GoalID: "g1", AuthorID: "coach-1", Content: strings.Repeat("x", personalgoal.MaxNoteLength+1)}, personalgoal.ErrNoteTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.annotation.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package quickcapture
+
+import (
+	"errors"
+	"time"
+)
+
+// Quick capture limits.
+const (
+	MaxBatchItems      = 100
+	MaxClientIDLength  = 100
+	MaxMemberRefLength = 100
+	// MaxContentLength matches the longest observation, so a resolved note always saves.
+	MaxContentLength = 1000
+	// MaxAge is how far back a note queued on a coach's phone may be sent.
+	MaxAge = 7 * 24 * time.Hour
+	// MaxClockSkew tolerates phone clocks running slightly ahead of the server.
+	MaxClockSkew = 5 * time.Minute
+)
+
+// Capture statuses.
+const (
+	StatusQueued    = "queued"    // the name matched no single member; waiting for the coach to choose
+	StatusResolved  = "resolved"  // saved as an observation
+	StatusDiscarded = "discarded" // the coach threw the note away
+)
+
+// Domain errors
+var (
+	ErrEmptyID          = errors.New("capture ID is required")
+	ErrEmptyAuthorID    = errors.New("author ID is required")
+	ErrEmptyClientID    = errors.New("client ID is required")
+	ErrClientIDTooLong  = errors.New("client ID cannot exceed 100 characters")
+	ErrEmptyMemberRef   = errors.New("member name is required")
+	ErrMemberRefTooLong = errors.New("member name cannot exceed 100 characters")
+	ErrEmptyContent     = errors.New("note cannot be empty")
+	ErrContentTooLong   = errors.New("note cannot exceed 1000 characters")
+	ErrEmptyCapturedAt  = errors.New("captured time is required")
+	ErrInvalidStatus    = errors.New("status must be queued, resolved or discarded")
+	ErrNotQueued        = errors.New("note has already been resolved or discarded")
+)
+
+// Capture is a note a coach jotted down mid-class, naming the member as they said or typed
+// it. A capture whose name matches one member becomes an observation straight away; the
+// rest wait in the coach's queue until they choose the member or discard the note.
+// The client ID makes resending a batch from a phone with a flaky connection harmless.
+type Capture struct {
+	ID            string
+	AuthorID      string // Coach or Admin AccountID
+	ClientID      string // generated on the phone; unique per author
+	MemberRef     string // the member's name as captured
+	Content       string
+	CapturedAt    time.Time // when the coach took the note, from the phone's clock
+	Status        string
+	MemberID      string // set once resolved
+	ObservationID string // set once resolved
+	CreatedAt     time.Time
+	ResolvedAt    time.Time // when resolved or discarded
+}
+
+// Validate checks if the Capture has valid data.
+// PRE: Capture struct is populated
+// POST: Returns nil if valid, error otherwise
+func (c *Capture) Validate() error {
+	if c.ID == "" {
+		return ErrEmptyID
+	}
+	if c.AuthorID == "" {
+		return ErrEmptyAuthorID
+	}
+	if c.ClientID == "" {
+		return ErrEmptyClientID
+	}
+	if len(c.ClientID) > MaxClientIDLength {
+		return ErrClientIDTooLong
+	}
+	if c.MemberRef == "" {
+		return ErrEmptyMemberRef
+	}
+	if len(c.MemberRef) > MaxMemberRefLength {
+		return ErrMemberRefTooLong
+	}
+	if c.Content == "" {
+		return ErrEmptyContent
+	}
+	if len(c.Content) > MaxContentLength {
+		return ErrContentTooLong
+	}
+	if c.CapturedAt.IsZero() {
+		return ErrEmptyCapturedAt
+	}
+	if c.Status != StatusQueued && c.Status != StatusResolved && c.Status != StatusDiscarded {
+		return ErrInvalidStatus
+	}
+	return nil
+}
+
+// Resolve records that the capture was saved as an observation about the member.
+// PRE: memberID and observationID are non-empty
+// POST: Status is resolved; returns ErrNotQueued if it was already resolved or discarded
+func (c *Capture) Resolve(memberID, observationID string, now time.Time) error {
+	if c.Status != StatusQueued {
+		return ErrNotQueued
+	}
+	c.Status = StatusResolved
+	c.MemberID = memberID
+	c.ObservationID = observationID
+	c.ResolvedAt = now
+	return nil
+}
+
+// Discard throws the capture away without saving an observation.
+// PRE: none
+// POST: Status is discarded; returns ErrNotQueued if it was already resolved or discarded
+func (c *Capture) Discard(now time.Time) error {
+	if c.Status != StatusQueued {
+		return ErrNotQueued
+	}
+	c.Status = StatusDiscarded
+	c.ResolvedAt = now
+	return nil
+}
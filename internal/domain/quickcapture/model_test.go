@@ -0,0 +1,67 @@
+package quickcapture_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/quickcapture"
+)
+
+var captured = time.Date(2026, 3, 2, 18, 30, 0, 0, time.UTC)
+
+func validCapture() quickcapture.Capture {
+	return quickcapture.Capture{ID: "c1", AuthorID: "coach-1", ClientID: "phone-1", MemberRef: "Sam", Content: "Flaring elbows in closed guard", CapturedAt: captured, Status: quickcapture.StatusQueued}
+}
+
+// TestCapture_Validate tests the capture's invariants.
+func TestCapture_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *quickcapture.Capture)
+		err    error
+	}{
+		{"valid", func(c *quickcapture.Capture) {}, nil},
+		{"no author", func(c *quickcapture.Capture) { c.AuthorID = "" }, quickcapture.ErrEmptyAuthorID},
+		{"no client ID", func(c *quickcapture.Capture) { c.ClientID = "" }, quickcapture.ErrEmptyClientID},
+		{"long client ID", func(c *quickcapture.Capture) { c.ClientID = strings.Repeat("x", 101) }, quickcapture.ErrClientIDTooLong},
+		{"no member", func(c *quickcapture.Capture) { c.MemberRef = "" }, quickcapture.ErrEmptyMemberRef},
+		{"long member", func(c *quickcapture.Capture) { c.MemberRef = strings.Repeat("x", 101) }, quickcapture.ErrMemberRefTooLong},
+		{"no content", func(c *quickcapture.Capture) { c.Content = "" }, quickcapture.ErrEmptyContent},
+		{"long content", func(c *quickcapture.Capture) { c.Content = strings.Repeat("x", 1001) }, quickcapture.ErrContentTooLong},
+		{"no time", func(c *quickcapture.Capture) { c.CapturedAt = time.Time{} }, quickcapture.ErrEmptyCapturedAt},
+		{"bad status", func(c *quickcapture.Capture) { c.Status = "pending" }, quickcapture.ErrInvalidStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validCapture()
+			tt.modify(&c)
+			if err := c.Validate(); err != tt.err {
+				t.Errorf("Validate() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+// TestCapture_ResolveAndDiscard tests that a capture leaves the queue only once.
+func TestCapture_ResolveAndDiscard(t *testing.T) {
+	now := captured.Add(time.Hour)
+	c := validCapture()
+	if err := c.Resolve("m1", "o1", now); err != nil {
+		t.Fatalf("Resolve() = %v", err)
+	}
+	if c.Status != quickcapture.StatusResolved || c.MemberID != "m1" || c.ObservationID != "o1" || !c.ResolvedAt.Equal(now) {
+		t.Errorf("resolved capture = %+v", c)
+	}
+	if err := c.Discard(now); err != quickcapture.ErrNotQueued {
+		t.Errorf("Discard() after resolve = %v, want ErrNotQueued", err)
+	}
+
+	c = validCapture()
+	if err := c.Discard(now); err != nil || c.Status != quickcapture.StatusDiscarded {
+		t.Fatalf("Discard() = %v, status %s", err, c.Status)
+	}
+	if err := c.Resolve("m1", "o1", now); err != quickcapture.ErrNotQueued {
+		t.Errorf("Resolve() after discard = %v, want ErrNotQueued", err)
+	}
+}
@@ -0,0 +1,228 @@
+package reengagement
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Rule actions
+const (
+	ActionEmail     = "email"      // send a library email template
+	ActionCoachCall = "coach_call" // flag the member for a coach to call
+)
+
+// Action outcomes. Emails are recorded as sent or skipped; calls start pending until a
+// coach records how the call went.
+const (
+	OutcomeSent     = "sent"
+	OutcomeSkipped  = "skipped" // no address, suppressed address or unsubscribed
+	OutcomePending  = "pending"
+	OutcomeReached  = "reached"
+	OutcomeNoAnswer = "no_answer"
+	OutcomeLeaving  = "leaving" // the member said they are not coming back
+)
+
+// Business rule constants
+const (
+	MaxDaysInactive = 365
+	LapsedAfterDays = 180 // members away longer are left alone rather than re-engaged
+	MaxNameLength   = 100
+	MaxReasonLength = 200
+	MaxNoteLength   = 1000
+)
+
+// Domain errors
+var (
+	ErrEmptyName         = errors.New("rule name is required")
+	ErrNameTooLong       = errors.New("rule name cannot exceed 100 characters")
+	ErrInvalidDays       = errors.New("days inactive must be between 1 and 365")
+	ErrInvalidAction     = errors.New("action must be email or coach_call")
+	ErrEmptyTemplateKey  = errors.New("an email rule needs a template")
+	ErrUnexpectedKey     = errors.New("only email rules send a template")
+	ErrEmptyMemberID     = errors.New("member ID is required")
+	ErrInvalidOutcome    = errors.New("outcome must be reached, no_answer or leaving")
+	ErrNotACall          = errors.New("only coach calls record an outcome")
+	ErrNoteTooLong       = errors.New("note cannot exceed 1000 characters")
+	ErrEmptyReason       = errors.New("a reason is required")
+	ErrReasonTooLong     = errors.New("reason cannot exceed 200 characters")
+	ErrUntilInThePast    = errors.New("pause end date must be in the future")
+	ErrInvalidActionKind = errors.New("action kind must be email or coach_call")
+)
+
+// Rule is one step of the re-engagement automation: once a member has been away for
+// DaysInactive days, the Action is taken, once per absence.
+type Rule struct {
+	ID           string
+	Name         string
+	DaysInactive int
+	Action       string
+	TemplateKey  string // library template sent by an email rule
+	Enabled      bool
+	UpdatedBy    string
+	UpdatedAt    time.Time
+}
+
+// Validate checks if the Rule has valid data.
+// PRE: Rule struct is populated
+// POST: Returns nil if valid, error otherwise
+func (r *Rule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return ErrEmptyName
+	}
+	if len(r.Name) > MaxNameLength {
+		return ErrNameTooLong
+	}
+	if r.DaysInactive < 1 || r.DaysInactive > MaxDaysInactive {
+		return ErrInvalidDays
+	}
+	switch r.Action {
+	case ActionEmail:
+		if r.TemplateKey == "" {
+			return ErrEmptyTemplateKey
+		}
+	case ActionCoachCall:
+		if r.TemplateKey != "" {
+			return ErrUnexpectedKey
+		}
+	default:
+		return ErrInvalidAction
+	}
+	return nil
+}
+
+// Action is one step taken for a member: an email sent or a call flagged. A call's
+// outcome is recorded by the coach; ReturnedAt is set when the member trains again.
+type Action struct {
+	ID           string
+	MemberID     string
+	RuleID       string
+	RuleName     string // the rule's name when it fired
+	Kind         string // ActionEmail or ActionCoachCall
+	DaysInactive int    // days since the member's last check-in when it fired
+	LastCheckIn  time.Time
+	EmailID      string // the email sent; empty for calls and skipped emails
+	Outcome      string
+	Note         string
+	RecordedBy   string // AccountID of the coach who recorded the call's outcome
+	RecordedAt   time.Time
+	ReturnedAt   time.Time // first check-in after the action; zero while still away
+	CreatedAt    time.Time
+}
+
+// Validate checks if the Action has valid data.
+// PRE: Action struct is populated
+// POST: Returns nil if valid, error otherwise
+func (a *Action) Validate() error {
+	if a.MemberID == "" {
+		return ErrEmptyMemberID
+	}
+	if a.Kind != ActionEmail && a.Kind != ActionCoachCall {
+		return ErrInvalidActionKind
+	}
+	if len(a.Note) > MaxNoteLength {
+		return ErrNoteTooLong
+	}
+	return nil
+}
+
+// RecordOutcome records how a coach's call went.
+// PRE: by is the AccountID of the coach
+// POST: Outcome, Note, RecordedBy and RecordedAt are set, or an error is returned
+func (a *Action) RecordOutcome(outcome, note, by string, now time.Time) error {
+	if a.Kind != ActionCoachCall {
+		return ErrNotACall
+	}
+	if outcome != OutcomeReached && outcome != OutcomeNoAnswer && outcome != OutcomeLeaving {
+		return ErrInvalidOutcome
+	}
+	note = strings.TrimSpace(note)
+	if len(note) > MaxNoteLength {
+		return ErrNoteTooLong
+	}
+	a.Outcome = outcome
+	a.Note = note
+	a.RecordedBy = by
+	a.RecordedAt = now
+	return nil
+}
+
+// MarkReturned records the member's first check-in after the action.
+// PRE: checkIn is the member's first check-in after the action
+// POST: ReturnedAt is set when checkIn is after the action and it was not already set;
+// returns whether it changed
+func (a *Action) MarkReturned(checkIn time.Time) bool {
+	if !a.ReturnedAt.IsZero() || !checkIn.After(a.CreatedAt) {
+		return false
+	}
+	a.ReturnedAt = checkIn
+	return true
+}
+
+// Suppression pauses re-engagement for one member, e.g. while injured or travelling.
+type Suppression struct {
+	MemberID  string
+	Reason    string
+	Until     time.Time // zero pauses until lifted
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// Validate checks if the Suppression has valid data.
+// PRE: Suppression struct is populated; now is the current time
+// POST: Returns nil if valid, error otherwise
+func (s *Suppression) Validate(now time.Time) error {
+	if s.MemberID == "" {
+		return ErrEmptyMemberID
+	}
+	if strings.TrimSpace(s.Reason) == "" {
+		return ErrEmptyReason
+	}
+	if len(s.Reason) > MaxReasonLength {
+		return ErrReasonTooLong
+	}
+	if !s.Until.IsZero() && !s.Until.After(now) {
+		return ErrUntilInThePast
+	}
+	return nil
+}
+
+// Active reports whether the suppression still applies.
+// INVARIANT: Suppression is not mutated
+func (s Suppression) Active(now time.Time) bool {
+	return s.Until.IsZero() || now.Before(s.Until)
+}
+
+// DaysSince counts the whole days from lastCheckIn to now.
+// POST: Returns 0 when now is before lastCheckIn
+func DaysSince(lastCheckIn, now time.Time) int {
+	if now.Before(lastCheckIn) {
+		return 0
+	}
+	return int(now.Sub(lastCheckIn).Hours() / 24)
+}
+
+// Due returns the enabled rules a member has reached in their current absence that have
+// not been actioned since their last check-in, lowest threshold first. A member away for
+// longer than LapsedAfterDays is due nothing.
+// PRE: history is the member's actions; rules are sorted by DaysInactive
+// POST: Each returned rule fires at most once per absence
+func Due(rules []Rule, lastCheckIn, now time.Time, history []Action) []Rule {
+	days := DaysSince(lastCheckIn, now)
+	if lastCheckIn.IsZero() || days > LapsedAfterDays {
+		return nil
+	}
+	taken := map[string]bool{}
+	for _, a := range history {
+		if a.CreatedAt.After(lastCheckIn) {
+			taken[a.RuleID] = true
+		}
+	}
+	var due []Rule
+	for _, r := range rules {
+		if r.Enabled && days >= r.DaysInactive && !taken[r.ID] {
+			due = append(due, r)
+		}
+	}
+	return due
+}
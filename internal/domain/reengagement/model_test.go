@@ -0,0 +1,132 @@
+package reengagement_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/reengagement"
+)
+
+// TestRule_Validate tests validation of re-engagement rules.
+func TestRule_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		rule reengagement.Rule
+		want error
+	}{
+		{"email", reengagement.Rule{Name: "We miss you", DaysInactive: 21, Action: reengagement.ActionEmail, TemplateKey: "inactive_follow_up"}, nil},
+		{"call", reengagement.Rule{Name: "Coach call", DaysInactive: 45, Action: reengagement.ActionCoachCall}, nil},
+		{"no name", reengagement.Rule{Name: " ", DaysInactive: 21, Action: reengagement.ActionCoachCall}, reengagement.ErrEmptyName},
+		{"long name", reengagement.Rule{Name: strings.Repeat("a", 101), DaysInactive: 21, Action: reengagement.ActionCoachCall}, reengagement.ErrNameTooLong},
+		{"zero days", reengagement.Rule{Name: "Call", Action: reengagement.ActionCoachCall}, reengagement.ErrInvalidDays},
+		{"over a year", reengagement.Rule{Name: "Call", DaysInactive: 400, Action: reengagement.ActionCoachCall}, reengagement.ErrInvalidDays},
+		{"unknown action", reengagement.Rule{Name: "Text", DaysInactive: 21, Action: "sms"}, reengagement.ErrInvalidAction},
+		{"email without template", reengagement.Rule{Name: "Email", DaysInactive: 21, Action: reengagement.ActionEmail}, reengagement.ErrEmptyTemplateKey},
+		{"call with template", reengagement.Rule{Name: "Call", DaysInactive: 21, Action: reengagement.ActionCoachCall, TemplateKey: "welcome"}, reengagement.ErrUnexpectedKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Validate(); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAction_RecordOutcome tests that only calls record an outcome, and only a known one.
+func TestAction_RecordOutcome(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	call := reengagement.Action{MemberID: "m1", Kind: reengagement.ActionCoachCall, Outcome: reengagement.OutcomePending}
+	if err := call.RecordOutcome("maybe", "", "coach-1", now); err != reengagement.ErrInvalidOutcome {
+		t.Errorf("unknown outcome: got %v", err)
+	}
+	if err := call.RecordOutcome(reengagement.OutcomeReached, "  Back next week after exams ", "coach-1", now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if call.Outcome != reengagement.OutcomeReached || call.Note != "Back next week after exams" || call.RecordedBy != "coach-1" || !call.RecordedAt.Equal(now) {
+		t.Errorf("call = %+v", call)
+	}
+
+	email := reengagement.Action{MemberID: "m1", Kind: reengagement.ActionEmail, Outcome: reengagement.OutcomeSent}
+	if err := email.RecordOutcome(reengagement.OutcomeReached, "", "coach-1", now); err != reengagement.ErrNotACall {
+		t.Errorf("email: got %v, want ErrNotACall", err)
+	}
+}
+
+// TestAction_MarkReturned tests that only the first check-in after the action counts.
+func TestAction_MarkReturned(t *testing.T) {
+	created := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	a := reengagement.Action{CreatedAt: created}
+	if a.MarkReturned(created.Add(-time.Hour)) {
+		t.Error("a check-in before the action should not count")
+	}
+	back := created.AddDate(0, 0, 3)
+	if !a.MarkReturned(back) || !a.ReturnedAt.Equal(back) {
+		t.Errorf("ReturnedAt = %v, want %v", a.ReturnedAt, back)
+	}
+	if a.MarkReturned(back.AddDate(0, 0, 2)) || !a.ReturnedAt.Equal(back) {
+		t.Error("a later check-in should not move ReturnedAt")
+	}
+}
+
+// TestSuppression tests pausing re-engagement for a member.
+func TestSuppression(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	s := reengagement.Suppression{MemberID: "m1", Reason: "Knee surgery", Until: now.AddDate(0, 2, 0)}
+	if err := s.Validate(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !s.Active(now) || s.Active(now.AddDate(0, 3, 0)) {
+		t.Error("expected the pause to end after two months")
+	}
+	if !(reengagement.Suppression{MemberID: "m1", Reason: "Moved away"}).Active(now.AddDate(5, 0, 0)) {
+		t.Error("a pause without an end date should stay active")
+	}
+	past := reengagement.Suppression{MemberID: "m1", Reason: "Travel", Until: now.AddDate(0, 0, -1)}
+	if err := past.Validate(now); err != reengagement.ErrUntilInThePast {
+		t.Errorf("past end date: got %v", err)
+	}
+	if err := (&reengagement.Suppression{MemberID: "m1"}).Validate(now); err != reengagement.ErrEmptyReason {
+		t.Errorf("no reason: got %v", err)
+	}
+}
+
+// TestDue tests which rules fire for a member in their current absence.
+func TestDue(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	rules := []reengagement.Rule{
+		{ID: "email", DaysInactive: 21, Action: reengagement.ActionEmail, Enabled: true},
+		{ID: "call", DaysInactive: 45, Action: reengagement.ActionCoachCall, Enabled: true},
+		{ID: "off", DaysInactive: 30, Action: reengagement.ActionCoachCall},
+	}
+	last := now.AddDate(0, 0, -50)
+	earlier := reengagement.Action{RuleID: "email", CreatedAt: last.AddDate(0, 0, -30)} // a previous absence
+	sent := reengagement.Action{RuleID: "email", CreatedAt: last.AddDate(0, 0, 21)}
+
+	tests := []struct {
+		name    string
+		last    time.Time
+		history []reengagement.Action
+		want    []string
+	}{
+		{"both thresholds reached", last, []reengagement.Action{earlier}, []string{"email", "call"}},
+		{"email already sent this absence", last, []reengagement.Action{sent}, []string{"call"}},
+		{"only the first threshold", now.AddDate(0, 0, -25), nil, []string{"email"}},
+		{"still training", now.AddDate(0, 0, -3), nil, nil},
+		{"long lapsed", now.AddDate(0, 0, -200), nil, nil},
+		{"never trained", time.Time{}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reengagement.Due(rules, tt.last, now, tt.history)
+			var ids []string
+			for _, r := range got {
+				ids = append(ids, r.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Due() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
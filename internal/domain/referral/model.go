@@ -0,0 +1,129 @@
+package referral
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Where a referral was captured.
+const (
+	SourceRegistration = "registration"  // staff registered the member
+	SourceGuestCheckIn = "guest_checkin" // the guest named who sent them at the kiosk
+)
+
+// Rewards sent to a referrer when the person they referred becomes an active member.
+const (
+	RewardThankYou = "thank_you" // a thank-you email
+	RewardCredit   = "credit"    // a credit note towards the referrer's fees
+)
+
+// Business rule constants
+const (
+	MaxReferrerNameLength = 100
+	MaxCreditAmount       = 1000 // dollars
+	DefaultCreditAmount   = 20   // dollars
+)
+
+// Domain errors
+var (
+	ErrEmptyMemberID       = errors.New("member ID is required")
+	ErrNoReferrer          = errors.New("a referrer member or name is required")
+	ErrBothReferrers       = errors.New("give either a referrer member or a name, not both")
+	ErrReferrerNameTooLong = errors.New("referrer name cannot exceed 100 characters")
+	ErrSelfReferral        = errors.New("a member cannot refer themselves")
+	ErrInvalidSource       = errors.New("source must be registration or guest_checkin")
+	ErrAlreadyConverted    = errors.New("referral has already converted")
+	ErrInvalidReward       = errors.New("reward must be thank_you or credit")
+	ErrInvalidCredit       = errors.New("credit must be between 1 and 1000 dollars")
+)
+
+// Referral records who sent a new member to the club. The referrer is either an existing
+// member, who can be thanked, or a free-text name for someone outside the system.
+type Referral struct {
+	ID               string
+	MemberID         string // the person referred; one referral each
+	ReferrerMemberID string // empty when the referrer is named in free text
+	ReferrerName     string // free text; empty when ReferrerMemberID is set
+	Source           string
+	CreatedAt        time.Time
+	ConvertedAt      time.Time // when the referred person became an active member
+	Reward           string    // reward sent to the referrer at conversion; empty when none was sent
+	CreditAmount     int       // dollars, for RewardCredit
+}
+
+// Validate checks if the Referral has valid data.
+// PRE: Referral struct is populated
+// POST: Returns nil if valid, error otherwise
+func (r *Referral) Validate() error {
+	if r.MemberID == "" {
+		return ErrEmptyMemberID
+	}
+	name := strings.TrimSpace(r.ReferrerName)
+	if r.ReferrerMemberID == "" && name == "" {
+		return ErrNoReferrer
+	}
+	if r.ReferrerMemberID != "" && name != "" {
+		return ErrBothReferrers
+	}
+	if len(r.ReferrerName) > MaxReferrerNameLength {
+		return ErrReferrerNameTooLong
+	}
+	if r.ReferrerMemberID == r.MemberID {
+		return ErrSelfReferral
+	}
+	if r.Source != SourceRegistration && r.Source != SourceGuestCheckIn {
+		return ErrInvalidSource
+	}
+	return nil
+}
+
+// IsConverted reports whether the referred person has become an active member.
+// INVARIANT: Referral is not mutated
+func (r *Referral) IsConverted() bool {
+	return !r.ConvertedAt.IsZero()
+}
+
+// Convert marks the referred person as an active member and records the reward the
+// settings call for. A referrer named in free text cannot be reached, so gets none.
+// PRE: settings has been validated
+// POST: ConvertedAt is now and Reward is set for a member referrer, or ErrAlreadyConverted and nothing changes
+func (r *Referral) Convert(settings Settings, now time.Time) error {
+	if r.IsConverted() {
+		return ErrAlreadyConverted
+	}
+	r.ConvertedAt = now
+	if r.ReferrerMemberID == "" || !settings.Enabled {
+		return nil
+	}
+	r.Reward = settings.Reward
+	if settings.Reward == RewardCredit {
+		r.CreditAmount = settings.CreditAmount
+	}
+	return nil
+}
+
+// Settings choose how referrers are rewarded. There is one set per club.
+type Settings struct {
+	Enabled      bool   // false records conversions without rewarding anyone
+	Reward       string // RewardThankYou or RewardCredit
+	CreditAmount int    // dollars per converted referral, for RewardCredit
+	UpdatedBy    string
+	UpdatedAt    time.Time
+}
+
+// DefaultSettings thank referrers by email until an admin chooses otherwise.
+var DefaultSettings = Settings{Enabled: true, Reward: RewardThankYou, CreditAmount: DefaultCreditAmount}
+
+// Validate checks if the Settings have valid data.
+// PRE: Settings struct is populated
+// POST: Returns nil if valid, error otherwise
+func (s *Settings) Validate() error {
+	if s.Reward != RewardThankYou && s.Reward != RewardCredit {
+		return ErrInvalidReward
+	}
+	if s.Reward == RewardCredit && (s.CreditAmount < 1 || s.CreditAmount > MaxCreditAmount) {
+		return ErrInvalidCredit
+	}
+	return nil
+}
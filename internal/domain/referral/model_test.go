@@ -0,0 +1,81 @@
+package referral_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/referral"
+)
+
+// TestReferralValidate tests validation of Referral.
+func TestReferralValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       referral.Referral
+		wantErr error
+	}{
+		{"member referrer", referral.Referral{MemberID: "m1", ReferrerMemberID: "m2", Source: referral.SourceRegistration}, nil},
+		{"named referrer", referral.Referral{MemberID: "m1", ReferrerName: "Aunty Mere", Source: referral.SourceGuestCheckIn}, nil},
+		{"no member", referral.Referral{ReferrerMemberID: "m2", Source: referral.SourceRegistration}, referral.ErrEmptyMemberID},
+		{"no referrer", referral.Referral{MemberID: "m1", ReferrerName: "  ", Source: referral.SourceRegistration}, referral.ErrNoReferrer},
+		{"both referrers", referral.Referral{MemberID: "m1", ReferrerMemberID: "m2", ReferrerName: "Mere", Source: referral.SourceRegistration}, referral.ErrBothReferrers},
+		{"long name", referral.Referral{MemberID: "m1", ReferrerName: strings.Repeat("a", 101), Source: referral.SourceRegistration}, referral.ErrReferrerNameTooLong},
+		{"self", referral.Referral{MemberID: "m1", ReferrerMemberID: "m1", Source: referral.SourceRegistration}, referral.ErrSelfReferral},
+		{"bad source", referral.Referral{MemberID: "m1", ReferrerMemberID: "m2", Source: "flyer"}, referral.ErrInvalidSource},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.r.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestReferralConvert tests that conversion records the reward the settings call for, only
+// for member referrers, and only once.
+func TestReferralConvert(t *testing.T) {
+	now := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	credit := referral.Settings{Enabled: true, Reward: referral.RewardCredit, CreditAmount: 30}
+
+	r := referral.Referral{MemberID: "m1", ReferrerMemberID: "m2", Source: referral.SourceRegistration}
+	if err := r.Convert(credit, now); err != nil || !r.IsConverted() || r.Reward != referral.RewardCredit || r.CreditAmount != 30 {
+		t.Errorf("Convert() = %v, referral %+v; want a $30 credit", err, r)
+	}
+	if err := r.Convert(credit, now); err != referral.ErrAlreadyConverted {
+		t.Errorf("second Convert() = %v, want ErrAlreadyConverted", err)
+	}
+
+	named := referral.Referral{MemberID: "m1", ReferrerName: "Mere", Source: referral.SourceGuestCheckIn}
+	if err := named.Convert(credit, now); err != nil || !named.IsConverted() || named.Reward != "" {
+		t.Errorf("named referrer: %v, %+v; want converted without a reward", err, named)
+	}
+
+	off := referral.Referral{MemberID: "m1", ReferrerMemberID: "m2", Source: referral.SourceRegistration}
+	if err := off.Convert(referral.Settings{Reward: referral.RewardThankYou}, now); err != nil || off.Reward != "" {
+		t.Errorf("rewards disabled: %v, %+v; want no reward", err, off)
+	}
+}
+
+// TestSettingsValidate tests validation of Settings.
+func TestSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       referral.Settings
+		wantErr error
+	}{
+		{"defaults", referral.DefaultSettings, nil},
+		{"credit", referral.Settings{Reward: referral.RewardCredit, CreditAmount: 50}, nil},
+		{"bad reward", referral.Settings{Reward: "voucher"}, referral.ErrInvalidReward},
+		{"zero credit", referral.Settings{Reward: referral.RewardCredit}, referral.ErrInvalidCredit},
+		{"huge credit", referral.Settings{Reward: referral.RewardCredit, CreditAmount: 5000}, referral.ErrInvalidCredit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.s.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
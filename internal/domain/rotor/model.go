@@ -38,6 +38,8 @@ var (
 	ErrInvalidDuration   = errors.New("duration must be at least 1 week")
 	ErrTopicNotScheduled = errors.New("topic is not currently scheduled")
 	ErrAlreadyVoted      = errors.New("already voted for this topic in current cycle")
+	ErrInvalidVoteExpiry = errors.New("vote expiry must be between 0 and 52 weeks")
+	ErrInvalidVoteWeight = errors.New("coach vote weight must be between 1 and 10")
 
 	ErrRotorNameTooLong        = errors.New("rotor name cannot exceed 100 characters")
 	ErrThemeNameTooLong        = errors.New("theme name cannot exceed 100 characters")
@@ -49,15 +51,17 @@ var (
 // PRE: ClassTypeID and Name are non-empty.
 // INVARIANT: Only one rotor per ClassTypeID can be active at a time.
 type Rotor struct {
-	ID          string
-	ClassTypeID string
-	Name        string
-	Version     int
-	Status      string // draft, active, archived
-	PreviewOn   bool   // whether members can see upcoming topics
-	CreatedBy   string // account ID
-	CreatedAt   time.Time
-	ActivatedAt time.Time
+	ID            string
+	ClassTypeID   string
+	Name          string
+	Version       int
+	Status        string // draft, active, archived
+	PreviewOn     bool   // whether members can see upcoming topics
+	ManualAdvance bool   // expired topics wait for a coach instead of auto-advancing
+	VoteRules     VoteRules
+	CreatedBy     string // account ID
+	CreatedAt     time.Time
+	ActivatedAt   time.Time
 }
 
 // Validate checks the rotor's invariants.
@@ -175,6 +179,8 @@ type Topic struct {
 	DurationWeeks int    // how many weeks this topic runs (default 1)
 	Position      int    // order in the queue (0-indexed)
 	LastCovered   time.Time
+	SharedTopicID string // optional: the library topic whose name and description this follows
+	MinBelt       string // optional: members below this belt see the topic locked
 }
 
 // Validate checks the topic's invariants.
@@ -199,6 +205,75 @@ func (t *Topic) Validate() error {
 	return nil
 }
 
+// Link makes the topic follow a library topic, taking its name and description.
+// PRE: st is a valid SharedTopic
+// POST: SharedTopicID, Name and Description come from st; DurationWeeks is unchanged
+func (t *Topic) Link(st SharedTopic) {
+	t.SharedTopicID = st.ID
+	t.Name = st.Name
+	t.Description = st.Description
+}
+
+// SharedTopic is a topic kept in the curriculum library so several class rotors can
+// teach it from one write-up. Topics linked to it follow its name and description;
+// each class keeps its own duration and queue position.
+// PRE: Name is non-empty.
+type SharedTopic struct {
+	ID            string
+	Name          string
+	Description   string
+	DurationWeeks int // default for topics added from the library
+	CreatedBy     string
+	UpdatedAt     time.Time
+}
+
+// Validate checks the shared topic's invariants.
+// PRE: none
+// POST: returns nil if valid, error describing the first violation otherwise
+func (s *SharedTopic) Validate() error {
+	if s.Name == "" {
+		return ErrEmptyTopicName
+	}
+	if len(s.Name) > MaxTopicNameLength {
+		return ErrTopicNameTooLong
+	}
+	if s.DurationWeeks < 1 {
+		return ErrInvalidDuration
+	}
+	if len(s.Description) > MaxTopicDescriptionLength {
+		return ErrTopicDescriptionTooLong
+	}
+	return nil
+}
+
+// CloneTheme copies a theme and its topic queue into another rotor, with fresh IDs.
+// Topics keep their library links; coverage history stays with the original.
+// PRE: topics belong to theme and are sorted by Position; rotorID is non-empty
+// POST: returns the new theme at position and its topics in the same order
+func CloneTheme(theme RotorTheme, topics []Topic, rotorID string, position int, newID func() string) (RotorTheme, []Topic) {
+	clone := RotorTheme{
+		ID:       newID(),
+		RotorID:  rotorID,
+		Name:     theme.Name,
+		Position: position,
+		Hidden:   theme.Hidden,
+	}
+	cloned := make([]Topic, 0, len(topics))
+	for i, t := range topics {
+		cloned = append(cloned, Topic{
+			ID:            newID(),
+			RotorThemeID:  clone.ID,
+			Name:          t.Name,
+			Description:   t.Description,
+			DurationWeeks: t.DurationWeeks,
+			Position:      i,
+			SharedTopicID: t.SharedTopicID,
+			MinBelt:       t.MinBelt,
+		})
+	}
+	return clone, cloned
+}
+
 // TopicSchedule status constants.
 const (
 	ScheduleStatusScheduled = "scheduled"
@@ -217,6 +292,7 @@ type TopicSchedule struct {
 	StartDate    time.Time
 	EndDate      time.Time
 	Status       string // scheduled, active, completed, skipped
+	Bumped       bool   // inserted ahead of the queue by a vote bump
 }
 
 // IsActive returns true if the schedule entry is currently active.
@@ -228,6 +304,78 @@ func (s *TopicSchedule) IsActive(now time.Time) bool {
 		(s.EndDate.IsZero() || !now.After(s.EndDate))
 }
 
+// IsExpired returns true if the schedule entry is active but its end date has passed.
+// PRE: now is a valid time
+// POST: returns false for schedules without an end date
+func (s *TopicSchedule) IsExpired(now time.Time) bool {
+	return s.Status == ScheduleStatusActive && !s.EndDate.IsZero() && now.After(s.EndDate)
+}
+
+// WeekNumber returns which week of the schedule the given time falls in, counting from 1.
+// PRE: now is a valid time
+// POST: returns 0 before StartDate; never exceeds weeks when weeks is positive
+func (s *TopicSchedule) WeekNumber(now time.Time, weeks int) int {
+	if now.Before(s.StartDate) {
+		return 0
+	}
+	week := int(now.Sub(s.StartDate).Hours()/24)/7 + 1
+	if weeks > 0 && week > weeks {
+		week = weeks
+	}
+	return week
+}
+
+// ScheduleAt returns the schedule that was running at the given time, or nil.
+// Only active and completed runs count; skipped and waiting schedules were never taught.
+// PRE: history holds one theme's schedules
+// POST: prefers the latest start when runs overlap; an open-ended run covers everything after its start
+func ScheduleAt(history []TopicSchedule, at time.Time) *TopicSchedule {
+	var found *TopicSchedule
+	for i := range history {
+		h := &history[i]
+		if h.Status != ScheduleStatusActive && h.Status != ScheduleStatusCompleted {
+			continue
+		}
+		if at.Before(h.StartDate) || (!h.EndDate.IsZero() && at.After(h.EndDate)) {
+			continue
+		}
+		if found == nil || h.StartDate.After(found.StartDate) {
+			found = h
+		}
+	}
+	return found
+}
+
+// MaxClosedWeeks caps how many closed weeks ProjectEnd will skip over in one run.
+const MaxClosedWeeks = 52
+
+// ProjectEnd returns when a run of the given number of weeks starting at start would end.
+// A week in which the club is closed every day does not count towards the run, so a
+// topic spanning a holiday break is taught for its full length either side of it.
+// PRE: weeks >= 0; closed reports whether the club is closed on a day
+// POST: returns start plus whole weeks; skips at most MaxClosedWeeks closed weeks
+func ProjectEnd(start time.Time, weeks int, closed func(day time.Time) bool) time.Time {
+	end := start
+	skipped := 0
+	for taught := 0; taught < weeks; {
+		next := end.AddDate(0, 0, 7)
+		open := false
+		for day := end; day.Before(next); day = day.AddDate(0, 0, 1) {
+			if !closed(day) {
+				open = true
+				break
+			}
+		}
+		if open || skipped >= MaxClosedWeeks {
+			taught++
+		} else {
+			skipped++
+		}
+		end = next
+	}
+	return end
+}
+
 // NextTopicInQueue returns the next topic in position order after currentTopicID,
 // wrapping around to the first topic when the end of the queue is reached.
 // PRE: topics is sorted by Position ascending, currentTopicID is non-empty.
@@ -246,12 +394,124 @@ func NextTopicInQueue(topics []Topic, currentTopicID string) *Topic {
 	return &topics[0]
 }
 
+// MinVotesForAutoBump is how many votes a topic needs before auto-advance runs it ahead of the queue.
+const MinVotesForAutoBump = 3
+
+// AdvancePlan describes what runs after a theme's current topic ends.
+type AdvancePlan struct {
+	Topic  *Topic         // topic to run next; nil leaves the theme idle
+	Resume *TopicSchedule // waiting schedule to reactivate (a topic displaced by a bump); nil otherwise
+	Bumped bool           // Topic jumps the queue on member votes
+}
+
+// PlanAdvance decides which topic follows endingTopicID in a theme.
+// In priority order: the most-voted topic with at least MinVotesForAutoBump votes (ties go to
+// queue position); a topic displaced by an earlier bump; the next topic in queue after the last
+// topic that ran in queue order, so a bump never moves the queue forward.
+// PRE: topics is sorted by Position ascending; history holds the theme's schedules, including the ending one
+// POST: returns an empty plan if nothing should run next
+func PlanAdvance(topics []Topic, history []TopicSchedule, votes map[string]int, endingTopicID string) AdvancePlan {
+	var winner *Topic
+	for i := range topics {
+		t := &topics[i]
+		if t.ID == endingTopicID || votes[t.ID] < MinVotesForAutoBump {
+			continue
+		}
+		if winner == nil || votes[t.ID] > votes[winner.ID] {
+			winner = t
+		}
+	}
+	if winner != nil {
+		return AdvancePlan{Topic: winner, Bumped: true}
+	}
+
+	var cursor *TopicSchedule
+	for i := range history {
+		h := &history[i]
+		if h.Status == ScheduleStatusScheduled {
+			for j := range topics {
+				if topics[j].ID == h.TopicID {
+					return AdvancePlan{Topic: &topics[j], Resume: h}
+				}
+			}
+			continue
+		}
+		if !h.Bumped && (cursor == nil || h.StartDate.After(cursor.StartDate)) {
+			cursor = h
+		}
+	}
+	current := endingTopicID
+	if cursor != nil {
+		current = cursor.TopicID
+	}
+	return AdvancePlan{Topic: NextTopicInQueue(topics, current)}
+}
+
+// Vote rule limits.
+const (
+	MaxVoteExpiryWeeks = 52
+	MaxCoachVoteWeight = 10
+)
+
+// VoteRules configures how topic votes count on a rotor. The zero value keeps every vote,
+// counts each once and lets a member vote for any number of topics.
+type VoteRules struct {
+	OnePerTheme bool // a vote replaces the member's other active vote in the same theme
+	ExpiryWeeks int  // votes stop counting after this many weeks; 0 keeps them until the topic runs
+	CoachWeight int  // what a coach's or admin's vote counts as; 0 counts as 1
+}
+
+// Validate checks the rules are within bounds.
+// PRE: none
+// POST: returns nil if valid, ErrInvalidVoteExpiry or ErrInvalidVoteWeight otherwise
+func (v VoteRules) Validate() error {
+	if v.ExpiryWeeks < 0 || v.ExpiryWeeks > MaxVoteExpiryWeeks {
+		return ErrInvalidVoteExpiry
+	}
+	if v.CoachWeight < 0 || v.CoachWeight > MaxCoachVoteWeight {
+		return ErrInvalidVoteWeight
+	}
+	return nil
+}
+
+// NewVote casts a vote under the rules: staff votes carry the coach weight and the vote
+// expires ExpiryWeeks after now.
+// PRE: id, topic and accountID are non-empty
+// POST: returns a vote with Weight >= 1, and ExpiresAt set only when ExpiryWeeks > 0
+func (v VoteRules) NewVote(id string, topic Topic, accountID string, staff bool, now time.Time) Vote {
+	vote := Vote{
+		ID:           id,
+		TopicID:      topic.ID,
+		RotorThemeID: topic.RotorThemeID,
+		AccountID:    accountID,
+		Weight:       1,
+		CreatedAt:    now,
+	}
+	if staff && v.CoachWeight > 1 {
+		vote.Weight = v.CoachWeight
+	}
+	if v.ExpiryWeeks > 0 {
+		vote.ExpiresAt = now.AddDate(0, 0, v.ExpiryWeeks*7)
+	}
+	return vote
+}
+
 // Vote represents a member's vote for a topic.
 // PRE: TopicID and AccountID are non-empty.
 // INVARIANT: One vote per member per topic per rotation cycle.
 type Vote struct {
-	ID        string
-	TopicID   string
-	AccountID string
-	CreatedAt time.Time
+	ID           string
+	TopicID      string
+	RotorThemeID string // theme of the topic when the vote was cast
+	AccountID    string
+	Weight       int // how many votes this counts as; coaches may count for more
+	CreatedAt    time.Time
+	ExpiresAt    time.Time // zero if the vote never expires
+}
+
+// IsActive reports whether the vote still counts.
+// PRE: none
+// POST: returns false once ExpiresAt has passed
+func (v Vote) IsActive(now time.Time) bool {
+	return v.ExpiresAt.IsZero() || now.Before(v.ExpiresAt)
 }
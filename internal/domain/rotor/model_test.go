@@ -1,6 +1,8 @@
 package rotor_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -282,3 +284,252 @@ func TestNextTopicInQueue(t *testing.T) {
 		}
 	})
 }
+
+// TestTopicSchedule_IsExpired tests end-date expiry of active schedules.
+func TestTopicSchedule_IsExpired(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		sched rotor.TopicSchedule
+		want  bool
+	}{
+		{"active past end", rotor.TopicSchedule{Status: rotor.ScheduleStatusActive, EndDate: now.Add(-time.Hour)}, true},
+		{"active before end", rotor.TopicSchedule{Status: rotor.ScheduleStatusActive, EndDate: now.Add(time.Hour)}, false},
+		{"active open-ended", rotor.TopicSchedule{Status: rotor.ScheduleStatusActive}, false},
+		{"completed past end", rotor.TopicSchedule{Status: rotor.ScheduleStatusCompleted, EndDate: now.Add(-time.Hour)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sched.IsExpired(now); got != tt.want {
+				t.Errorf("IsExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTopicSchedule_WeekNumber tests which week of a topic's run a date falls in.
+func TestTopicSchedule_WeekNumber(t *testing.T) {
+	sched := rotor.TopicSchedule{StartDate: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)}
+	tests := []struct {
+		name  string
+		now   time.Time
+		weeks int
+		want  int
+	}{
+		{"before start", time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), 2, 0},
+		{"first day", time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC), 2, 1},
+		{"second week", time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC), 2, 2},
+		{"overrun capped", time.Date(2026, 3, 20, 18, 0, 0, 0, time.UTC), 2, 2},
+		{"no duration uncapped", time.Date(2026, 3, 20, 18, 0, 0, 0, time.UTC), 0, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sched.WeekNumber(tt.now, tt.weeks); got != tt.want {
+				t.Errorf("WeekNumber() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestScheduleAt tests which run was being taught at a moment, ignoring skipped and waiting runs.
+func TestScheduleAt(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	history := []rotor.TopicSchedule{
+		{ID: "s1", StartDate: day(2), EndDate: day(9), Status: rotor.ScheduleStatusCompleted},
+		{ID: "s2", StartDate: day(9), EndDate: day(16), Status: rotor.ScheduleStatusSkipped},
+		{ID: "s3", StartDate: day(9), EndDate: day(23), Status: rotor.ScheduleStatusActive},
+		{ID: "s4", StartDate: day(2), Status: rotor.ScheduleStatusScheduled},
+	}
+	tests := []struct {
+		name string
+		at   time.Time
+		want string
+	}{
+		{"before any run", day(1), ""},
+		{"first run", day(4), "s1"},
+		{"handover goes to the later run", day(9), "s3"},
+		{"skipped run ignored", day(12), "s3"},
+		{"after the last run", day(24), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if s := rotor.ScheduleAt(history, tt.at); s != nil {
+				got = s.ID
+			}
+			if got != tt.want {
+				t.Errorf("ScheduleAt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestProjectEnd tests that whole weeks the club is closed are skipped and partly closed weeks count.
+func TestProjectEnd(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC) // a Monday
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 0, 0, 0, 0, time.UTC) }
+	between := func(from, to time.Time) func(time.Time) bool {
+		return func(d time.Time) bool { return !d.Before(from) && !d.After(to) }
+	}
+	tests := []struct {
+		name   string
+		weeks  int
+		closed func(time.Time) bool
+		want   time.Time
+	}{
+		{"no holidays", 2, func(time.Time) bool { return false }, day(3, 16)},
+		{"zero weeks", 0, func(time.Time) bool { return true }, start},
+		{"public holiday still counts", 2, between(day(3, 9), day(3, 9)), day(3, 16)},
+		{"closed week skipped", 2, between(day(3, 9), day(3, 15)), day(3, 23)},
+		{"two week break skipped", 1, between(day(3, 2), day(3, 15)), day(3, 23)},
+		{"closed forever is capped", 1, func(time.Time) bool { return true }, start.AddDate(0, 0, 7*(rotor.MaxClosedWeeks+1))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rotor.ProjectEnd(start, tt.weeks, tt.closed); !got.Equal(tt.want) {
+				t.Errorf("ProjectEnd() = %s, want %s", got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+// TestPlanAdvance tests which topic follows the ending one, honouring votes and bumps.
+func TestPlanAdvance(t *testing.T) {
+	topics := []rotor.Topic{
+		{ID: "t1", Name: "Topic A", Position: 0, DurationWeeks: 1},
+		{ID: "t2", Name: "Topic B", Position: 1, DurationWeeks: 1},
+		{ID: "t3", Name: "Topic C", Position: 2, DurationWeeks: 1},
+	}
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("next in queue without votes", func(t *testing.T) {
+		history := []rotor.TopicSchedule{{TopicID: "t1", StartDate: day(1), Status: rotor.ScheduleStatusCompleted}}
+		plan := rotor.PlanAdvance(topics, history, nil, "t1")
+		if plan.Topic == nil || plan.Topic.ID != "t2" || plan.Bumped || plan.Resume != nil {
+			t.Errorf("expected t2 from queue, got %+v", plan)
+		}
+	})
+
+	t.Run("votes below threshold are ignored", func(t *testing.T) {
+		history := []rotor.TopicSchedule{{TopicID: "t1", StartDate: day(1), Status: rotor.ScheduleStatusCompleted}}
+		plan := rotor.PlanAdvance(topics, history, map[string]int{"t3": rotor.MinVotesForAutoBump - 1}, "t1")
+		if plan.Topic == nil || plan.Topic.ID != "t2" || plan.Bumped {
+			t.Errorf("expected t2 from queue, got %+v", plan)
+		}
+	})
+
+	t.Run("most voted topic is bumped", func(t *testing.T) {
+		history := []rotor.TopicSchedule{{TopicID: "t1", StartDate: day(1), Status: rotor.ScheduleStatusCompleted}}
+		votes := map[string]int{"t2": rotor.MinVotesForAutoBump, "t3": rotor.MinVotesForAutoBump + 2}
+		plan := rotor.PlanAdvance(topics, history, votes, "t1")
+		if plan.Topic == nil || plan.Topic.ID != "t3" || !plan.Bumped {
+			t.Errorf("expected t3 bumped, got %+v", plan)
+		}
+	})
+
+	t.Run("displaced topic resumes after a bump", func(t *testing.T) {
+		history := []rotor.TopicSchedule{
+			{ID: "s2", TopicID: "t3", StartDate: day(8), Status: rotor.ScheduleStatusCompleted, Bumped: true},
+			{ID: "s1", TopicID: "t1", StartDate: day(1), Status: rotor.ScheduleStatusScheduled},
+		}
+		plan := rotor.PlanAdvance(topics, history, nil, "t3")
+		if plan.Topic == nil || plan.Topic.ID != "t1" || plan.Resume == nil || plan.Resume.ID != "s1" {
+			t.Errorf("expected t1 resumed from s1, got %+v", plan)
+		}
+	})
+
+	t.Run("queue continues from last non-bumped topic", func(t *testing.T) {
+		history := []rotor.TopicSchedule{
+			{TopicID: "t3", StartDate: day(8), Status: rotor.ScheduleStatusCompleted, Bumped: true},
+			{TopicID: "t1", StartDate: day(1), Status: rotor.ScheduleStatusCompleted},
+		}
+		plan := rotor.PlanAdvance(topics, history, nil, "t3")
+		if plan.Topic == nil || plan.Topic.ID != "t2" || plan.Bumped {
+			t.Errorf("expected t2 after bumped t3, got %+v", plan)
+		}
+	})
+}
+
+// TestSharedTopic_Validate tests validation of library topics.
+func TestSharedTopic_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		topic rotor.SharedTopic
+		want  error
+	}{
+		{"valid", rotor.SharedTopic{Name: "Double Leg", DurationWeeks: 1}, nil},
+		{"empty name", rotor.SharedTopic{DurationWeeks: 1}, rotor.ErrEmptyTopicName},
+		{"zero duration", rotor.SharedTopic{Name: "Double Leg"}, rotor.ErrInvalidDuration},
+		{"long description", rotor.SharedTopic{Name: "Double Leg", DurationWeeks: 1, Description: strings.Repeat("x", rotor.MaxTopicDescriptionLength+1)}, rotor.ErrTopicDescriptionTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.topic.Validate(); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCloneTheme tests that a cloned theme gets fresh IDs, keeps library links and drops coverage.
+func TestCloneTheme(t *testing.T) {
+	n := 0
+	newID := func() string { n++; return fmt.Sprintf("new-%d", n) }
+	theme := rotor.RotorTheme{ID: "th1", RotorID: "r1", Name: "Takedowns", Position: 3, Hidden: true}
+	topics := []rotor.Topic{
+		{ID: "t1", RotorThemeID: "th1", Name: "Double Leg", DurationWeeks: 2, Position: 0, SharedTopicID: "st1", LastCovered: time.Now()},
+		{ID: "t2", RotorThemeID: "th1", Name: "Snap Down", DurationWeeks: 1, Position: 4},
+	}
+
+	clone, cloned := rotor.CloneTheme(theme, topics, "r2", 0, newID)
+	if clone.ID != "new-1" || clone.RotorID != "r2" || clone.Name != "Takedowns" || clone.Position != 0 || !clone.Hidden {
+		t.Errorf("unexpected theme: %+v", clone)
+	}
+	if len(cloned) != 2 {
+		t.Fatalf("expected 2 topics, got %d", len(cloned))
+	}
+	if c := cloned[0]; c.ID != "new-2" || c.RotorThemeID != "new-1" || c.SharedTopicID != "st1" || c.DurationWeeks != 2 || !c.LastCovered.IsZero() {
+		t.Errorf("unexpected first topic: %+v", c)
+	}
+	if c := cloned[1]; c.Position != 1 || c.SharedTopicID != "" {
+		t.Errorf("unexpected second topic: %+v", c)
+	}
+}
+
+// TestTopic_Link tests that linking takes the library topic's wording but not its duration.
+func TestTopic_Link(t *testing.T) {
+	topic := rotor.Topic{Name: "Old", Description: "old notes", DurationWeeks: 3}
+	topic.Link(rotor.SharedTopic{ID: "st1", Name: "Double Leg", Description: "Level change first", DurationWeeks: 1})
+	if topic.SharedTopicID != "st1" || topic.Name != "Double Leg" || topic.Description != "Level change first" || topic.DurationWeeks != 3 {
+		t.Errorf("unexpected topic: %+v", topic)
+	}
+}
+
+// TestVoteRules_NewVote tests that coach votes carry their weight and votes expire when set.
+func TestVoteRules_NewVote(t *testing.T) {
+	now := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	topic := rotor.Topic{ID: "t1", RotorThemeID: "th1"}
+
+	vote := rotor.VoteRules{}.NewVote("v1", topic, "a1", true, now)
+	if vote.Weight != 1 || !vote.ExpiresAt.IsZero() || vote.RotorThemeID != "th1" || !vote.IsActive(now.AddDate(5, 0, 0)) {
+		t.Errorf("default rules: unexpected vote %+v", vote)
+	}
+
+	rules := rotor.VoteRules{ExpiryWeeks: 4, CoachWeight: 3}
+	if v := rules.NewVote("v2", topic, "a1", false, now); v.Weight != 1 {
+		t.Errorf("member vote weight = %d, want 1", v.Weight)
+	}
+	vote = rules.NewVote("v3", topic, "a2", true, now)
+	if vote.Weight != 3 || !vote.ExpiresAt.Equal(now.AddDate(0, 0, 28)) {
+		t.Errorf("coach vote: unexpected %+v", vote)
+	}
+	if !vote.IsActive(now.AddDate(0, 0, 27)) || vote.IsActive(now.AddDate(0, 0, 28)) {
+		t.Error("vote should count for 4 weeks and then expire")
+	}
+
+	for _, bad := range []rotor.VoteRules{{ExpiryWeeks: -1}, {ExpiryWeeks: 53}, {CoachWeight: 11}} {
+		if bad.Validate() == nil {
+			t.Errorf("%+v should be invalid", bad)
+		}
+	}
+}
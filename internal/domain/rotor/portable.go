@@ -0,0 +1,163 @@
+package rotor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DocumentFormatVersion is the portable rotor document format written by export.
+const DocumentFormatVersion = 1
+
+// Portable document limits keep imports bounded.
+const (
+	MaxDocumentThemes         = 50
+	MaxDocumentTopicsPerTheme = 200
+)
+
+// Portable document errors.
+var (
+	ErrUnsupportedDocumentVersion = errors.New("unsupported rotor document format version")
+	ErrTooManyDocumentThemes      = errors.New("rotor document cannot have more than 50 themes")
+	ErrTooManyDocumentTopics      = errors.New("theme cannot have more than 200 topics")
+)
+
+// Document is a portable, ID-free representation of a rotor and its full
+// theme/topic structure, used to move curricula between class types or clubs.
+// INVARIANT: contains no database IDs, schedules, votes or coverage history.
+type Document struct {
+	FormatVersion int             `json:"format_version"`
+	Name          string          `json:"name"`
+	ClassTypeID   string          `json:"class_type_id,omitempty"` // source class type; informational on import
+	PreviewOn     bool            `json:"preview_on"`
+	ManualAdvance bool            `json:"manual_advance,omitempty"`
+	Themes        []DocumentTheme `json:"themes"`
+}
+
+// DocumentTheme is a theme within a portable rotor document.
+type DocumentTheme struct {
+	Name     string          `json:"name"`
+	Position int             `json:"position"`
+	Hidden   bool            `json:"hidden"`
+	Topics   []DocumentTopic `json:"topics"`
+}
+
+// DocumentTopic is a topic within a portable rotor document.
+type DocumentTopic struct {
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	DurationWeeks int    `json:"duration_weeks"`
+	Position      int    `json:"position"`
+}
+
+// NewDocument builds a portable document from a rotor, its themes and each theme's topics.
+// PRE: topicsByTheme is keyed by RotorTheme.ID
+// POST: returns a document ordered by theme and topic position
+func NewDocument(r Rotor, themes []RotorTheme, topicsByTheme map[string][]Topic) Document {
+	doc := Document{
+		FormatVersion: DocumentFormatVersion,
+		Name:          r.Name,
+		ClassTypeID:   r.ClassTypeID,
+		PreviewOn:     r.PreviewOn,
+		ManualAdvance: r.ManualAdvance,
+		Themes:        make([]DocumentTheme, 0, len(themes)),
+	}
+	for _, th := range themes {
+		dt := DocumentTheme{Name: th.Name, Position: th.Position, Hidden: th.Hidden, Topics: []DocumentTopic{}}
+		for _, tp := range topicsByTheme[th.ID] {
+			dt.Topics = append(dt.Topics, DocumentTopic{
+				Name:          tp.Name,
+				Description:   tp.Description,
+				DurationWeeks: tp.DurationWeeks,
+				Position:      tp.Position,
+			})
+		}
+		sort.SliceStable(dt.Topics, func(i, j int) bool { return dt.Topics[i].Position < dt.Topics[j].Position })
+		doc.Themes = append(doc.Themes, dt)
+	}
+	sort.SliceStable(doc.Themes, func(i, j int) bool { return doc.Themes[i].Position < doc.Themes[j].Position })
+	return doc
+}
+
+// Validate checks the whole document so an import either succeeds completely or not at all.
+// PRE: none
+// POST: returns nil if valid; otherwise the first violation, prefixed with its theme/topic location
+func (d *Document) Validate() error {
+	if d.FormatVersion != DocumentFormatVersion {
+		return ErrUnsupportedDocumentVersion
+	}
+	name := strings.TrimSpace(d.Name)
+	if name == "" {
+		return ErrEmptyName
+	}
+	if len(name) > MaxRotorNameLength {
+		return ErrRotorNameTooLong
+	}
+	if len(d.Themes) > MaxDocumentThemes {
+		return ErrTooManyDocumentThemes
+	}
+	for i, th := range d.Themes {
+		theme := RotorTheme{RotorID: "import", Name: strings.TrimSpace(th.Name)}
+		if err := theme.Validate(); err != nil {
+			return fmt.Errorf("theme %d: %w", i+1, err)
+		}
+		if len(th.Topics) > MaxDocumentTopicsPerTheme {
+			return fmt.Errorf("theme %q: %w", theme.Name, ErrTooManyDocumentTopics)
+		}
+		for j, tp := range th.Topics {
+			topic := Topic{
+				RotorThemeID:  "import",
+				Name:          strings.TrimSpace(tp.Name),
+				Description:   tp.Description,
+				DurationWeeks: tp.DurationWeeks,
+			}
+			if err := topic.Validate(); err != nil {
+				return fmt.Errorf("theme %q topic %d: %w", theme.Name, j+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Build expands the document into a new draft rotor with fresh IDs.
+// Themes and topics are ordered by their document position and renumbered from 0.
+// PRE: Validate() returned nil; newID returns unique IDs
+// POST: returns a draft rotor with its themes and topics; nothing is persisted
+func (d *Document) Build(base Rotor, newID func() string) (Rotor, []RotorTheme, []Topic) {
+	r := base
+	r.Name = strings.TrimSpace(d.Name)
+	r.PreviewOn = d.PreviewOn
+	r.ManualAdvance = d.ManualAdvance
+	r.Status = StatusDraft
+
+	docThemes := append([]DocumentTheme(nil), d.Themes...)
+	sort.SliceStable(docThemes, func(i, j int) bool { return docThemes[i].Position < docThemes[j].Position })
+
+	var themes []RotorTheme
+	var topics []Topic
+	for i, dt := range docThemes {
+		theme := RotorTheme{
+			ID:       newID(),
+			RotorID:  r.ID,
+			Name:     strings.TrimSpace(dt.Name),
+			Position: i,
+			Hidden:   dt.Hidden,
+		}
+		themes = append(themes, theme)
+
+		docTopics := append([]DocumentTopic(nil), dt.Topics...)
+		sort.SliceStable(docTopics, func(a, b int) bool { return docTopics[a].Position < docTopics[b].Position })
+		for j, dp := range docTopics {
+			topics = append(topics, Topic{
+				ID:            newID(),
+				RotorThemeID:  theme.ID,
+				Name:          strings.TrimSpace(dp.Name),
+				Description:   dp.Description,
+				DurationWeeks: dp.DurationWeeks,
+				Position:      j,
+			})
+		}
+	}
+	return r, themes, topics
+}
@@ -0,0 +1,141 @@
+package rotor_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"workshop/internal/domain/rotor"
+)
+
+func sampleDocument() rotor.Document {
+	return rotor.Document{
+		FormatVersion: rotor.DocumentFormatVersion,
+		Name:          "Gi: Fundamentals # term 1",
+		ClassTypeID:   "ct1",
+		PreviewOn:     true,
+		ManualAdvance: true,
+		Themes: []rotor.DocumentTheme{
+			{Name: "Standing", Position: 0, Topics: []rotor.DocumentTopic{
+				{Name: "Single Leg", Description: "Head inside,\n\"drive\" through", DurationWeeks: 2, Position: 0},
+				{Name: "Osoto Gari", DurationWeeks: 1, Position: 1},
+			}},
+			{Name: "Guard", Position: 1, Hidden: true, Topics: []rotor.DocumentTopic{}},
+		},
+	}
+}
+
+// TestDocument_Validate tests whole-document validation of portable rotors.
+func TestDocument_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(d *rotor.Document)
+		wantErr error
+	}{
+		{name: "valid", mutate: func(d *rotor.Document) {}},
+		{name: "wrong version", mutate: func(d *rotor.Document) { d.FormatVersion = 2 }, wantErr: rotor.ErrUnsupportedDocumentVersion},
+		{name: "empty name", mutate: func(d *rotor.Document) { d.Name = "  " }, wantErr: rotor.ErrEmptyName},
+		{name: "empty theme name", mutate: func(d *rotor.Document) { d.Themes[1].Name = "" }, wantErr: rotor.ErrEmptyThemeName},
+		{name: "zero duration", mutate: func(d *rotor.Document) { d.Themes[0].Topics[1].DurationWeeks = 0 }, wantErr: rotor.ErrInvalidDuration},
+		{name: "too many themes", mutate: func(d *rotor.Document) {
+			for i := 0; i <= rotor.MaxDocumentThemes; i++ {
+				d.Themes = append(d.Themes, rotor.DocumentTheme{Name: fmt.Sprintf("T%d", i)})
+			}
+		}, wantErr: rotor.ErrTooManyDocumentThemes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := sampleDocument()
+			tt.mutate(&d)
+			err := d.Validate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestDocument_Build verifies IDs are fresh and positions follow document order.
+func TestDocument_Build(t *testing.T) {
+	d := sampleDocument()
+	d.Themes[0].Position, d.Themes[1].Position = 5, 2
+	n := 0
+	newID := func() string { n++; return fmt.Sprintf("id-%d", n) }
+
+	r, themes, topics := d.Build(rotor.Rotor{ID: "r1", ClassTypeID: "ct2", Status: rotor.StatusActive}, newID)
+	if r.Status != rotor.StatusDraft || r.ClassTypeID != "ct2" || !r.PreviewOn {
+		t.Errorf("unexpected rotor: %+v", r)
+	}
+	if len(themes) != 2 || themes[0].Name != "Guard" || themes[0].Position != 0 || themes[1].Position != 1 {
+		t.Fatalf("unexpected themes: %+v", themes)
+	}
+	if len(topics) != 2 || topics[0].RotorThemeID != themes[1].ID || topics[1].Position != 1 {
+		t.Errorf("unexpected topics: %+v", topics)
+	}
+}
+
+// TestDocument_YAMLRoundTrip verifies export output decodes back to the same document.
+func TestDocument_YAMLRoundTrip(t *testing.T) {
+	d := sampleDocument()
+	got, err := rotor.DecodeDocumentYAML(d.EncodeYAML())
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !reflect.DeepEqual(got, d) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", got, d)
+	}
+}
+
+// TestDecodeDocumentYAML_HandWritten verifies common hand-written YAML is accepted.
+func TestDecodeDocumentYAML_HandWritten(t *testing.T) {
+	src := `# exported curriculum
+format_version: 1
+name: Adults Gi
+preview_on: false
+themes:
+- name: 'Pinning'   # theme comment
+  position: 0
+  hidden: false
+  topics:
+    - name: Mount
+      description: "High mount"
+      duration_weeks: 3
+      position: 0
+`
+	d, err := rotor.DecodeDocumentYAML([]byte(src))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if d.Name != "Adults Gi" || len(d.Themes) != 1 || d.Themes[0].Name != "Pinning" {
+		t.Fatalf("unexpected document: %+v", d)
+	}
+	if tp := d.Themes[0].Topics; len(tp) != 1 || tp[0].Name != "Mount" || tp[0].DurationWeeks != 3 {
+		t.Errorf("unexpected topics: %+v", tp)
+	}
+}
+
+// TestDecodeDocumentYAML_Invalid verifies malformed or unknown input is rejected.
+func TestDecodeDocumentYAML_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":    "format_version: 1\nname: x\ncolour: red\n",
+		"bad indent":     "format_version: 1\n  name: x\n",
+		"tab indent":     "themes:\n\t- name: x\n",
+		"flow mapping":   "format_version: 1\nthemes: [{name: x}]\n",
+		"duplicate key":  "name: a\nname: b\n",
+		"wrong type":     "format_version: one\n",
+		"unclosed quote": "name: \"abc\n",
+	}
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := rotor.DecodeDocumentYAML([]byte(src))
+			if !errors.Is(err, rotor.ErrInvalidYAML) {
+				t.Errorf("expected ErrInvalidYAML, got %v", err)
+			}
+			if err != nil && !strings.Contains(err.Error(), "invalid YAML") {
+				t.Errorf("unexpected message: %v", err)
+			}
+		})
+	}
+}
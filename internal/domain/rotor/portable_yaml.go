@@ -0,0 +1,271 @@
+package rotor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidYAML is returned when a YAML rotor document cannot be parsed.
+var ErrInvalidYAML = errors.New("invalid YAML rotor document")
+
+// EncodeYAML renders the document as block-style YAML. Strings are always
+// double-quoted so names containing ':' or '#' survive a round trip.
+// PRE: none
+// POST: returns a YAML document that DecodeDocumentYAML accepts
+func (d Document) EncodeYAML() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "format_version: %d\n", d.FormatVersion)
+	fmt.Fprintf(&b, "name: %s\n", strconv.Quote(d.Name))
+	if d.ClassTypeID != "" {
+		fmt.Fprintf(&b, "class_type_id: %s\n", strconv.Quote(d.ClassTypeID))
+	}
+	fmt.Fprintf(&b, "preview_on: %t\n", d.PreviewOn)
+	if d.ManualAdvance {
+		b.WriteString("manual_advance: true\n")
+	}
+	if len(d.Themes) == 0 {
+		b.WriteString("themes: []\n")
+		return b.Bytes()
+	}
+	b.WriteString("themes:\n")
+	for _, th := range d.Themes {
+		fmt.Fprintf(&b, "  - name: %s\n", strconv.Quote(th.Name))
+		fmt.Fprintf(&b, "    position: %d\n", th.Position)
+		fmt.Fprintf(&b, "    hidden: %t\n", th.Hidden)
+		if len(th.Topics) == 0 {
+			b.WriteString("    topics: []\n")
+			continue
+		}
+		b.WriteString("    topics:\n")
+		for _, tp := range th.Topics {
+			fmt.Fprintf(&b, "      - name: %s\n", strconv.Quote(tp.Name))
+			fmt.Fprintf(&b, "        description: %s\n", strconv.Quote(tp.Description))
+			fmt.Fprintf(&b, "        duration_weeks: %d\n", tp.DurationWeeks)
+			fmt.Fprintf(&b, "        position: %d\n", tp.Position)
+		}
+	}
+	return b.Bytes()
+}
+
+// DecodeDocumentYAML parses a YAML rotor document.
+// Only the block-style subset needed for rotor documents is supported:
+// nested mappings, sequences of mappings, scalars, comments and empty [] / {}.
+// PRE: none
+// POST: returns the decoded document or an error wrapping ErrInvalidYAML; unknown keys are rejected
+func DecodeDocumentYAML(data []byte) (Document, error) {
+	lines, err := yamlLines(data)
+	if err != nil {
+		return Document{}, err
+	}
+	var tree interface{} = map[string]interface{}{}
+	if len(lines) > 0 {
+		var next int
+		tree, next, err = yamlParseBlock(lines, 0, lines[0].indent)
+		if err != nil {
+			return Document{}, err
+		}
+		if next != len(lines) {
+			return Document{}, fmt.Errorf("%w: line %d: unexpected indentation", ErrInvalidYAML, lines[next].number)
+		}
+	}
+
+	raw, err := json.Marshal(tree)
+	if err != nil {
+		return Document{}, fmt.Errorf("%w: %v", ErrInvalidYAML, err)
+	}
+	var doc Document
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&doc); err != nil {
+		return Document{}, fmt.Errorf("%w: %v", ErrInvalidYAML, err)
+	}
+	return doc, nil
+}
+
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// yamlLines strips comments and blank lines and records each line's indentation.
+func yamlLines(data []byte) ([]yamlLine, error) {
+	var out []yamlLine
+	for i, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		text := strings.TrimRight(yamlStripComment(line), " \t")
+		trimmed := strings.TrimLeft(text, " \t")
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		if strings.Contains(text[:len(text)-len(trimmed)], "\t") {
+			return nil, fmt.Errorf("%w: line %d: tabs are not allowed for indentation", ErrInvalidYAML, i+1)
+		}
+		out = append(out, yamlLine{number: i + 1, indent: len(text) - len(trimmed), text: trimmed})
+	}
+	return out, nil
+}
+
+// yamlStripComment removes a trailing "# comment" that is not inside quotes.
+func yamlStripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// yamlParseBlock parses the mapping or sequence starting at lines[i] with the given indent.
+func yamlParseBlock(lines []yamlLine, i, indent int) (interface{}, int, error) {
+	if yamlIsSeqItem(lines[i].text) {
+		return yamlParseSeq(lines, i, indent)
+	}
+	return yamlParseMap(lines, i, indent)
+}
+
+func yamlIsSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func yamlParseSeq(lines []yamlLine, i, indent int) (interface{}, int, error) {
+	items := []interface{}{}
+	for i < len(lines) && lines[i].indent == indent && yamlIsSeqItem(lines[i].text) {
+		rest := strings.TrimLeft(strings.TrimPrefix(lines[i].text, "-"), " ")
+		if rest == "" {
+			if i+1 >= len(lines) || lines[i+1].indent <= indent {
+				items = append(items, nil)
+				i++
+				continue
+			}
+			v, next, err := yamlParseBlock(lines, i+1, lines[i+1].indent)
+			if err != nil {
+				return nil, 0, err
+			}
+			items = append(items, v)
+			i = next
+			continue
+		}
+		if _, _, isKey := yamlSplitKey(rest); !isKey {
+			v, err := yamlScalar(rest, lines[i].number)
+			if err != nil {
+				return nil, 0, err
+			}
+			items = append(items, v)
+			i++
+			continue
+		}
+		// "- key: value" opens a mapping whose keys align with the text after the dash.
+		// The parse is single-pass, so the dash line can be rewritten in place.
+		itemIndent := indent + len(lines[i].text) - len(rest)
+		lines[i] = yamlLine{number: lines[i].number, indent: itemIndent, text: rest}
+		v, next, err := yamlParseMap(lines, i, itemIndent)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, v)
+		i = next
+	}
+	return items, i, nil
+}
+
+func yamlParseMap(lines []yamlLine, i, indent int) (interface{}, int, error) {
+	m := map[string]interface{}{}
+	for i < len(lines) && lines[i].indent == indent {
+		if yamlIsSeqItem(lines[i].text) {
+			return nil, 0, fmt.Errorf("%w: line %d: unexpected sequence item", ErrInvalidYAML, lines[i].number)
+		}
+		key, value, isKey := yamlSplitKey(lines[i].text)
+		if !isKey {
+			return nil, 0, fmt.Errorf("%w: line %d: expected key: value", ErrInvalidYAML, lines[i].number)
+		}
+		if _, dup := m[key]; dup {
+			return nil, 0, fmt.Errorf("%w: line %d: duplicate key %q", ErrInvalidYAML, lines[i].number, key)
+		}
+		if value != "" {
+			v, err := yamlScalar(value, lines[i].number)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[key] = v
+			i++
+			continue
+		}
+		// Nested block: deeper indentation, or a sequence at the same indentation.
+		if i+1 < len(lines) && (lines[i+1].indent > indent || (lines[i+1].indent == indent && yamlIsSeqItem(lines[i+1].text))) {
+			v, next, err := yamlParseBlock(lines, i+1, lines[i+1].indent)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[key] = v
+			i = next
+			continue
+		}
+		m[key] = nil
+		i++
+	}
+	if i < len(lines) && lines[i].indent > indent {
+		return nil, 0, fmt.Errorf("%w: line %d: unexpected indentation", ErrInvalidYAML, lines[i].number)
+	}
+	return m, i, nil
+}
+
+// yamlSplitKey splits "key: value" outside quotes. isKey is false for plain scalars.
+func yamlSplitKey(text string) (key, value string, isKey bool) {
+	if text[0] == '"' || text[0] == '\'' {
+		return "", "", false
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i == len(text)-1 || text[i+1] == ' ') {
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// yamlScalar converts a scalar token to a JSON-compatible value.
+func yamlScalar(s string, line int) (interface{}, error) {
+	switch {
+	case s == "[]":
+		return []interface{}{}, nil
+	case s == "{}":
+		return map[string]interface{}{}, nil
+	case s == "~" || s == "null":
+		return nil, nil
+	case s == "true":
+		return true, nil
+	case s == "false":
+		return false, nil
+	case s[0] == '"':
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: bad double-quoted string", ErrInvalidYAML, line)
+		}
+		return v, nil
+	case s[0] == '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' {
+			return nil, fmt.Errorf("%w: line %d: bad single-quoted string", ErrInvalidYAML, line)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	case s[0] == '[' || s[0] == '{' || s[0] == '|' || s[0] == '>' || s[0] == '&' || s[0] == '*':
+		return nil, fmt.Errorf("%w: line %d: flow collections, block scalars and anchors are not supported", ErrInvalidYAML, line)
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, nil
+	}
+	return s, nil
+}
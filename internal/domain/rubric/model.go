@@ -0,0 +1,168 @@
+package rubric
+
+import (
+	"errors"
+	"time"
+)
+
+// Max length and size constants for user-editable fields.
+const (
+	MaxNameLength  = 100
+	MaxLabelLength = 60
+	MaxCriteria    = 12
+	MaxScaleValue  = 10
+)
+
+// ReadinessWindow is how far back scores are averaged for the grading readiness view,
+// so a member's early scores stop dragging down their current level.
+const ReadinessWindow = 180 * 24 * time.Hour
+
+// Sources a set of scores can be attached to.
+const (
+	SourceObservation = "observation"
+	SourceGradingNote = "grading_note"
+)
+
+// Domain errors
+var (
+	ErrEmptyName          = errors.New("rubric name is required")
+	ErrNameTooLong        = errors.New("rubric name cannot exceed 100 characters")
+	ErrNoCriteria         = errors.New("rubric must have at least one criterion")
+	ErrTooManyCriteria    = errors.New("rubric cannot have more than 12 criteria")
+	ErrInvalidCriterion   = errors.New("each criterion needs a label of at most 60 characters")
+	ErrDuplicateCriterion = errors.New("criterion labels must be unique within a rubric")
+	ErrInvalidScale       = errors.New("criterion scale must run from 0 or more up to at most 10, with min below max")
+	ErrArchived           = errors.New("rubric has been archived")
+	ErrNoScores           = errors.New("at least one criterion must be scored")
+	ErrUnknownCriterion   = errors.New("score refers to a criterion not in the rubric")
+	ErrScoreOutOfRange    = errors.New("score is outside the criterion's scale")
+	ErrInvalidSource      = errors.New("source must be observation or grading_note")
+)
+
+// Criterion is one scored line of a rubric, e.g. "Guard retention" from 1 to 5.
+type Criterion struct {
+	Key   string // stable identifier scores refer to; survives label edits
+	Label string
+	Min   int
+	Max   int
+}
+
+// Template is a configurable rubric coaches fill in alongside an observation or grading note.
+// The note's free text serves as the rubric's comments.
+type Template struct {
+	ID        string
+	Name      string
+	Criteria  []Criterion
+	Archived  bool // archived templates keep their history but cannot be scored against
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// Validate checks if the Template has valid data.
+// PRE: Template struct is populated; every criterion has a Key
+// POST: Returns nil if valid, error otherwise
+func (t *Template) Validate() error {
+	if t.Name == "" {
+		return ErrEmptyName
+	}
+	if len(t.Name) > MaxNameLength {
+		return ErrNameTooLong
+	}
+	if len(t.Criteria) == 0 {
+		return ErrNoCriteria
+	}
+	if len(t.Criteria) > MaxCriteria {
+		return ErrTooManyCriteria
+	}
+	labels := make(map[string]bool, len(t.Criteria))
+	keys := make(map[string]bool, len(t.Criteria))
+	for _, c := range t.Criteria {
+		if c.Key == "" || c.Label == "" || len(c.Label) > MaxLabelLength {
+			return ErrInvalidCriterion
+		}
+		if labels[c.Label] || keys[c.Key] {
+			return ErrDuplicateCriterion
+		}
+		labels[c.Label], keys[c.Key] = true, true
+		if c.Min < 0 || c.Max > MaxScaleValue || c.Min >= c.Max {
+			return ErrInvalidScale
+		}
+	}
+	return nil
+}
+
+// Criterion returns the criterion with the given key.
+// PRE: none
+// POST: Returns the criterion and true, or false when the rubric has no such key
+func (t *Template) Criterion(key string) (Criterion, bool) {
+	for _, c := range t.Criteria {
+		if c.Key == key {
+			return c, true
+		}
+	}
+	return Criterion{}, false
+}
+
+// Score is one criterion's value recorded against a member, attached to an observation or grading note.
+// The criterion's label and scale are copied so history still reads correctly after the template is edited.
+type Score struct {
+	ID             string
+	TemplateID     string
+	MemberID       string
+	Source         string // SourceObservation or SourceGradingNote
+	SourceID       string
+	CriterionKey   string
+	CriterionLabel string
+	Value          int
+	Min            int
+	Max            int
+	AuthorID       string
+	CreatedAt      time.Time
+}
+
+// Normalized returns the score as a fraction of its scale, 0 at Min and 1 at Max,
+// so criteria on different scales can be averaged together.
+// PRE: Min < Max
+// POST: Returns a value in [0, 1]
+func (s *Score) Normalized() float64 {
+	return float64(s.Value-s.Min) / float64(s.Max-s.Min)
+}
+
+// NewScores checks values against the template and builds one Score per scored criterion,
+// in the template's criterion order. Criteria left out of values are simply unscored.
+// PRE: t has been validated; base carries MemberID, Source, SourceID, AuthorID and CreatedAt
+// POST: Returns the scores, or an error and no scores if any value is invalid
+func (t *Template) NewScores(values map[string]int, base Score, generateID func() string) ([]Score, error) {
+	if t.Archived {
+		return nil, ErrArchived
+	}
+	if base.Source != SourceObservation && base.Source != SourceGradingNote {
+		return nil, ErrInvalidSource
+	}
+	if len(values) == 0 {
+		return nil, ErrNoScores
+	}
+	for key, v := range values {
+		c, ok := t.Criterion(key)
+		if !ok {
+			return nil, ErrUnknownCriterion
+		}
+		if v < c.Min || v > c.Max {
+			return nil, ErrScoreOutOfRange
+		}
+	}
+	scores := make([]Score, 0, len(values))
+	for _, c := range t.Criteria {
+		v, ok := values[c.Key]
+		if !ok {
+			continue
+		}
+		s := base
+		s.ID = generateID()
+		s.TemplateID = t.ID
+		s.CriterionKey, s.CriterionLabel = c.Key, c.Label
+		s.Value, s.Min, s.Max = v, c.Min, c.Max
+		scores = append(scores, s)
+	}
+	return scores, nil
+}
@@ -0,0 +1,106 @@
+package rubric_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"workshop/internal/domain/rubric"
+)
+
+func guardTemplate() rubric.Template {
+	return rubric.Template{
+		ID:   "t1",
+		Name: "Guard game",
+		Criteria: []rubric.Criterion{
+			{Key: "retention", Label: "Guard retention", Min: 1, Max: 5},
+			{Key: "escapes", Label: "Escapes", Min: 1, Max: 5},
+		},
+	}
+}
+
+// TestTemplateValidate tests validation of Template.
+func TestTemplateValidate(t *testing.T) {
+	tooMany := guardTemplate()
+	tooMany.Criteria = nil
+	for i := 0; i <= rubric.MaxCriteria; i++ {
+		tooMany.Criteria = append(tooMany.Criteria, rubric.Criterion{Key: fmt.Sprint(i), Label: fmt.Sprint("c", i), Min: 1, Max: 5})
+	}
+	with := func(c rubric.Criterion) rubric.Template {
+		tmpl := guardTemplate()
+		tmpl.Criteria = append(tmpl.Criteria, c)
+		return tmpl
+	}
+	tests := []struct {
+		name    string
+		t       rubric.Template
+		wantErr error
+	}{
+		{"valid", guardTemplate(), nil},
+		{"empty name", rubric.Template{Criteria: guardTemplate().Criteria}, rubric.ErrEmptyName},
+		{"long name", rubric.Template{Name: strings.Repeat("x", rubric.MaxNameLength+1), Criteria: guardTemplate().Criteria}, rubric.ErrNameTooLong},
+		{"no criteria", rubric.Template{Name: "Empty"}, rubric.ErrNoCriteria},
+		{"too many criteria", tooMany, rubric.ErrTooManyCriteria},
+		{"blank label", with(rubric.Criterion{Key: "k", Min: 1, Max: 5}), rubric.ErrInvalidCriterion},
+		{"duplicate label", with(rubric.Criterion{Key: "k", Label: "Escapes", Min: 1, Max: 5}), rubric.ErrDuplicateCriterion},
+		{"duplicate key", with(rubric.Criterion{Key: "escapes", Label: "Sweeps", Min: 1, Max: 5}), rubric.ErrDuplicateCriterion},
+		{"inverted scale", with(rubric.Criterion{Key: "k", Label: "Sweeps", Min: 5, Max: 1}), rubric.ErrInvalidScale},
+		{"scale too large", with(rubric.Criterion{Key: "k", Label: "Sweeps", Min: 0, Max: 11}), rubric.ErrInvalidScale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.t.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestTemplateNewScores tests building scores from submitted values.
+func TestTemplateNewScores(t *testing.T) {
+	base := rubric.Score{MemberID: "m1", Source: rubric.SourceObservation, SourceID: "o1", AuthorID: "coach"}
+	n := 0
+	generateID := func() string { n++; return fmt.Sprint("s", n) }
+
+	tmpl := guardTemplate()
+	scores, err := tmpl.NewScores(map[string]int{"escapes": 2, "retention": 4}, base, generateID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scores) != 2 || scores[0].CriterionKey != "retention" || scores[1].CriterionLabel != "Escapes" {
+		t.Fatalf("scores = %+v, want retention then escapes", scores)
+	}
+	if s := scores[0]; s.TemplateID != "t1" || s.MemberID != "m1" || s.SourceID != "o1" || s.Value != 4 || s.Max != 5 || s.ID == "" {
+		t.Errorf("score = %+v, want the template, source, value and scale copied", s)
+	}
+	if got := scores[0].Normalized(); got != 0.75 {
+		t.Errorf("Normalized() = %v, want 0.75", got)
+	}
+
+	archived := guardTemplate()
+	archived.Archived = true
+	tests := []struct {
+		name    string
+		t       rubric.Template
+		values  map[string]int
+		source  string
+		wantErr error
+	}{
+		{"partial scoring", guardTemplate(), map[string]int{"escapes": 1}, rubric.SourceGradingNote, nil},
+		{"nothing scored", guardTemplate(), nil, rubric.SourceObservation, rubric.ErrNoScores},
+		{"unknown criterion", guardTemplate(), map[string]int{"sweeps": 3}, rubric.SourceObservation, rubric.ErrUnknownCriterion},
+		{"below scale", guardTemplate(), map[string]int{"escapes": 0}, rubric.SourceObservation, rubric.ErrScoreOutOfRange},
+		{"above scale", guardTemplate(), map[string]int{"escapes": 6}, rubric.SourceObservation, rubric.ErrScoreOutOfRange},
+		{"archived", archived, map[string]int{"escapes": 3}, rubric.SourceObservation, rubric.ErrArchived},
+		{"bad source", guardTemplate(), map[string]int{"escapes": 3}, "message", rubric.ErrInvalidSource},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := base
+			b.Source = tt.source
+			if _, err := tt.t.NewScores(tt.values, b, generateID); err != tt.wantErr {
+				t.Errorf("NewScores() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -23,10 +23,40 @@ var ValidDays = []string{Monday, Tuesday, Wednesday, Thursday, Friday, Saturday,
 
 // Domain errors
 var (
-	ErrEmptyClassTypeID = errors.New("class type ID cannot be empty")
-	ErrInvalidDay       = errors.New("day must be a valid day of the week")
-	ErrEmptyStartTime   = errors.New("start time cannot be empty")
-	ErrEmptyEndTime     = errors.New("end time cannot be empty")
+	ErrEmptyClassTypeID  = errors.New("class type ID cannot be empty")
+	ErrInvalidDay        = errors.New("day must be a valid day of the week")
+	ErrEmptyStartTime    = errors.New("start time cannot be empty")
+	ErrEmptyEndTime      = errors.New("end time cannot be empty")
+	ErrEmptyScheduleID   = errors.New("schedule ID cannot be empty")
+	ErrInvalidClassDate  = errors.New("class date must be YYYY-MM-DD")
+	ErrWrongDay          = errors.New("the class does not run on that date")
+	ErrInvalidChange     = errors.New("change must be cancelled, substitute, moved or added")
+	ErrNothingMoved      = errors.New("a moved class needs a new time or mat")
+	ErrEmptySubstitute   = errors.New("substitute coach is required")
+	ErrSubstituteTooLong = errors.New("substitute cannot exceed 100 characters")
+	ErrReasonTooLong     = errors.New("reason cannot exceed 500 characters")
+	ErrEmptyCreatedBy    = errors.New("created_by is required")
+	ErrInvalidTime       = errors.New("start and end times must be HH:MM")
+	ErrMatTooLong        = errors.New("mat cannot exceed 50 characters")
+	ErrConflict          = errors.New("the class overlaps another class in the same space")
+)
+
+// MaxMatLength is the longest mat name accepted.
+const MaxMatLength = 50
+
+// Occurrence change kinds.
+const (
+	ChangeCancelled  = "cancelled"
+	ChangeSubstitute = "substitute"
+	ChangeMoved      = "moved" // runs at a different time and/or on a different mat that day
+	ChangeAdded      = "added" // a one-off extra session with no weekly schedule
+)
+
+// Occurrence change limits.
+const (
+	MaxReasonLength     = 500
+	MaxSubstituteLength = 100
+	RecentAttendeeDays  = 28 // members who attended the slot this recently are told about a change
 )
 
 // Schedule represents a recurring weekly class slot.
@@ -37,6 +67,9 @@ type Schedule struct {
 	Day         string // monday, tuesday, etc.
 	StartTime   string // HH:MM format
 	EndTime     string // HH:MM format
+	LocationID  string // empty = offered at all locations
+	CoachID     string // AccountID of the regular coach; empty = unassigned
+	Mat         string // mat space within the location, e.g. "Mat 2"; empty = the whole floor
 }
 
 // Validate checks if the Schedule has valid data.
@@ -55,9 +88,79 @@ func (s *Schedule) Validate() error {
 	if strings.TrimSpace(s.EndTime) == "" {
 		return ErrEmptyEndTime
 	}
+	if _, _, ok := s.Minutes(); !ok {
+		return ErrInvalidTime
+	}
+	if len(s.Mat) > MaxMatLength {
+		return ErrMatTooLong
+	}
 	return nil
 }
 
+// Minutes returns the class's start and end as minutes after midnight. A class that ends
+// at or before it starts runs past midnight, so its end is pushed into the next day.
+// PRE: none
+// POST: ok is false when either time is not HH:MM
+func (s *Schedule) Minutes() (start, end int, ok bool) {
+	st, err := time.Parse("15:04", s.StartTime)
+	if err != nil {
+		return 0, 0, false
+	}
+	et, err := time.Parse("15:04", s.EndTime)
+	if err != nil {
+		return 0, 0, false
+	}
+	start, end = st.Hour()*60+st.Minute(), et.Hour()*60+et.Minute()
+	if end <= start {
+		end += 24 * 60
+	}
+	return start, end, true
+}
+
+// SharesSpace reports whether two classes need the same floor space: they are at the same
+// location (or one is offered at every location) and on the same mat (or one takes the
+// whole floor).
+// PRE: none
+// POST: Returns true when the classes cannot run side by side
+func (s *Schedule) SharesSpace(other Schedule) bool {
+	sameLocation := s.LocationID == "" || other.LocationID == "" || s.LocationID == other.LocationID
+	sameMat := s.Mat == "" || other.Mat == "" || strings.EqualFold(s.Mat, other.Mat)
+	return sameLocation && sameMat
+}
+
+// ConflictsWith reports whether two different classes run on the same day at overlapping
+// times in the same space. Classes that only touch (one ends as the next starts) do not
+// conflict.
+// PRE: none
+// POST: Returns false for the same schedule or unparseable times
+func (s *Schedule) ConflictsWith(other Schedule) bool {
+	if s.ID == other.ID || s.Day != other.Day || !s.SharesSpace(other) {
+		return false
+	}
+	start, end, ok := s.Minutes()
+	if !ok {
+		return false
+	}
+	oStart, oEnd, ok := other.Minutes()
+	if !ok {
+		return false
+	}
+	return start < oEnd && oStart < end
+}
+
+// Conflicts returns the schedules in others that conflict with s.
+// PRE: none
+// POST: Returns the conflicting schedules in their original order
+func Conflicts(s Schedule, others []Schedule) []Schedule {
+	var out []Schedule
+	for _, o := range others {
+		if s.ConflictsWith(o) {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
 // DurationHours returns the session duration in hours.
 // PRE: StartTime and EndTime are in HH:MM format
 // POST: Returns duration as float64 hours, or error if times can't be parsed
@@ -85,3 +188,145 @@ func isValidDay(day string) bool {
 	}
 	return false
 }
+
+// OccursOn reports whether the schedule runs on the weekday of date.
+// PRE: date is a valid time
+// POST: Returns true if date's weekday matches Day
+func (s *Schedule) OccursOn(date time.Time) bool {
+	return strings.ToLower(date.Weekday().String()) == s.Day
+}
+
+// OccurrenceChange is an exception to the weekly timetable on one date: a class cancelled,
+// handed to a substitute coach, or moved to another time or mat, or a one-off extra session.
+// At most one change exists per schedule and date. An added session has no weekly schedule,
+// so its ScheduleID is its own ID; check-ins refer to it like any other class.
+type OccurrenceChange struct {
+	ID          string
+	ScheduleID  string
+	ClassDate   string // YYYY-MM-DD
+	Kind        string // ChangeCancelled, ChangeSubstitute, ChangeMoved or ChangeAdded
+	Substitute  string // coach name shown to members; required for substitute changes, optional otherwise
+	Reason      string // optional, shown to members
+	ClassTypeID string // added sessions only
+	StartTime   string // moved and added: HH:MM; empty on a moved class keeps its usual times
+	EndTime     string // moved and added: HH:MM
+	LocationID  string // added sessions only; empty = offered at all locations
+	Mat         string // moved and added; empty on a moved class keeps its usual mat
+	NoticeID    string // notice published about the change
+	EmailID     string // email queued to recent attendees; empty when nobody had attended
+	CreatedBy   string // AccountID
+	CreatedAt   time.Time
+}
+
+// Validate checks if the OccurrenceChange has valid data.
+// PRE: OccurrenceChange struct is populated
+// POST: Returns nil if valid, error otherwise
+func (c *OccurrenceChange) Validate() error {
+	if c.ScheduleID == "" {
+		return ErrEmptyScheduleID
+	}
+	if _, err := time.Parse("2006-01-02", c.ClassDate); err != nil {
+		return ErrInvalidClassDate
+	}
+	switch c.Kind {
+	case ChangeCancelled:
+	case ChangeSubstitute:
+		if strings.TrimSpace(c.Substitute) == "" {
+			return ErrEmptySubstitute
+		}
+	case ChangeMoved:
+		if c.StartTime == "" && c.EndTime == "" && strings.TrimSpace(c.Mat) == "" {
+			return ErrNothingMoved
+		}
+		if c.StartTime != "" || c.EndTime != "" {
+			if err := c.validTimes(); err != nil {
+				return err
+			}
+		}
+	case ChangeAdded:
+		if strings.TrimSpace(c.ClassTypeID) == "" {
+			return ErrEmptyClassTypeID
+		}
+		if err := c.validTimes(); err != nil {
+			return err
+		}
+	default:
+		return ErrInvalidChange
+	}
+	if len(c.Substitute) > MaxSubstituteLength {
+		return ErrSubstituteTooLong
+	}
+	if len(c.Mat) > MaxMatLength {
+		return ErrMatTooLong
+	}
+	if len(c.Reason) > MaxReasonLength {
+		return ErrReasonTooLong
+	}
+	if c.CreatedBy == "" {
+		return ErrEmptyCreatedBy
+	}
+	return nil
+}
+
+// validTimes checks the change carries a start and end time in HH:MM.
+func (c *OccurrenceChange) validTimes() error {
+	s := Schedule{StartTime: c.StartTime, EndTime: c.EndTime}
+	if _, _, ok := s.Minutes(); !ok {
+		return ErrInvalidTime
+	}
+	return nil
+}
+
+// IsCancelled reports whether the occurrence will not run.
+// PRE: none
+// POST: Returns true for cancellations
+func (c *OccurrenceChange) IsCancelled() bool {
+	return c.Kind == ChangeCancelled
+}
+
+// IsAdded reports whether the change is a one-off extra session rather than a change to a
+// weekly class.
+// PRE: none
+// POST: Returns true for added sessions
+func (c *OccurrenceChange) IsAdded() bool {
+	return c.Kind == ChangeAdded
+}
+
+// Apply returns the class as it runs on the change's date: moved classes take the new
+// times and mat. ok is false when the class is cancelled.
+// PRE: s is the schedule the change belongs to
+// POST: s is returned unchanged for substitutes
+func (c *OccurrenceChange) Apply(s Schedule) (runs Schedule, ok bool) {
+	switch c.Kind {
+	case ChangeCancelled:
+		return s, false
+	case ChangeMoved:
+		if c.StartTime != "" {
+			s.StartTime, s.EndTime = c.StartTime, c.EndTime
+		}
+		if c.Mat != "" {
+			s.Mat = c.Mat
+		}
+	}
+	return s, true
+}
+
+// Session returns an added session as a schedule for its date, so it can be listed, checked
+// into and timed like a weekly class.
+// PRE: c.IsAdded() and ClassDate is YYYY-MM-DD
+// POST: The schedule's ID is the change's ScheduleID and its Day is the date's weekday
+func (c *OccurrenceChange) Session() Schedule {
+	day := ""
+	if d, err := time.Parse("2006-01-02", c.ClassDate); err == nil {
+		day = strings.ToLower(d.Weekday().String())
+	}
+	return Schedule{
+		ID:          c.ScheduleID,
+		ClassTypeID: c.ClassTypeID,
+		Day:         day,
+		StartTime:   c.StartTime,
+		EndTime:     c.EndTime,
+		LocationID:  c.LocationID,
+		Mat:         c.Mat,
+	}
+}
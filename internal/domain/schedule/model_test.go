@@ -1,7 +1,9 @@
 package schedule_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"workshop/internal/domain/schedule"
 )
@@ -48,6 +50,16 @@ func TestSchedule_Validate(t *testing.T) {
 			sched:   schedule.Schedule{ID: "7", ClassTypeID: "ct-1", Day: schedule.Monday, StartTime: "18:00", EndTime: ""},
 			wantErr: true,
 		},
+		{
+			name:    "time not HH:MM",
+			sched:   schedule.Schedule{ID: "8", ClassTypeID: "ct-1", Day: schedule.Monday, StartTime: "6pm", EndTime: "19:30"},
+			wantErr: true,
+		},
+		{
+			name:    "long mat name",
+			sched:   schedule.Schedule{ID: "9", ClassTypeID: "ct-1", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:30", Mat: strings.Repeat("m", schedule.MaxMatLength+1)},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -59,3 +71,131 @@ func TestSchedule_Validate(t *testing.T) {
 		})
 	}
 }
+
+// TestOccurrenceChange_Validate tests validation of OccurrenceChange.
+func TestOccurrenceChange_Validate(t *testing.T) {
+	valid := func() schedule.OccurrenceChange {
+		return schedule.OccurrenceChange{ScheduleID: "s1", ClassDate: "2026-03-10", Kind: schedule.ChangeCancelled, CreatedBy: "admin"}
+	}
+	tests := []struct {
+		name    string
+		modify  func(c *schedule.OccurrenceChange)
+		wantErr error
+	}{
+		{"valid cancellation", func(c *schedule.OccurrenceChange) {}, nil},
+		{"valid substitute", func(c *schedule.OccurrenceChange) { c.Kind, c.Substitute = schedule.ChangeSubstitute, "Coach Sam" }, nil},
+		{"missing schedule", func(c *schedule.OccurrenceChange) { c.ScheduleID = "" }, schedule.ErrEmptyScheduleID},
+		{"bad date", func(c *schedule.OccurrenceChange) { c.ClassDate = "10/03/2026" }, schedule.ErrInvalidClassDate},
+		{"unknown kind", func(c *schedule.OccurrenceChange) { c.Kind = "postponed" }, schedule.ErrInvalidChange},
+		{"valid move", func(c *schedule.OccurrenceChange) {
+			c.Kind, c.StartTime, c.EndTime = schedule.ChangeMoved, "19:00", "20:00"
+		}, nil},
+		{"valid mat move", func(c *schedule.OccurrenceChange) { c.Kind, c.Mat = schedule.ChangeMoved, "Mat 2" }, nil},
+		{"move with nothing moved", func(c *schedule.OccurrenceChange) { c.Kind = schedule.ChangeMoved }, schedule.ErrNothingMoved},
+		{"move to a bad time", func(c *schedule.OccurrenceChange) { c.Kind, c.StartTime = schedule.ChangeMoved, "7pm" }, schedule.ErrInvalidTime},
+		{"valid added session", func(c *schedule.OccurrenceChange) {
+			c.Kind, c.ClassTypeID, c.StartTime, c.EndTime = schedule.ChangeAdded, "ct-1", "10:00", "11:30"
+		}, nil},
+		{"added without class type", func(c *schedule.OccurrenceChange) {
+			c.Kind, c.StartTime, c.EndTime = schedule.ChangeAdded, "10:00", "11:30"
+		}, schedule.ErrEmptyClassTypeID},
+		{"added without times", func(c *schedule.OccurrenceChange) { c.Kind, c.ClassTypeID = schedule.ChangeAdded, "ct-1" }, schedule.ErrInvalidTime},
+		{"long mat", func(c *schedule.OccurrenceChange) {
+			c.Kind, c.Mat = schedule.ChangeMoved, strings.Repeat("m", schedule.MaxMatLength+1)
+		}, schedule.ErrMatTooLong},
+		{"substitute without coach", func(c *schedule.OccurrenceChange) { c.Kind, c.Substitute = schedule.ChangeSubstitute, " " }, schedule.ErrEmptySubstitute},
+		{"long substitute", func(c *schedule.OccurrenceChange) {
+			c.Kind, c.Substitute = schedule.ChangeSubstitute, strings.Repeat("x", schedule.MaxSubstituteLength+1)
+		}, schedule.ErrSubstituteTooLong},
+		{"long reason", func(c *schedule.OccurrenceChange) { c.Reason = strings.Repeat("x", schedule.MaxReasonLength+1) }, schedule.ErrReasonTooLong},
+		{"missing creator", func(c *schedule.OccurrenceChange) { c.CreatedBy = "" }, schedule.ErrEmptyCreatedBy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			tt.modify(&c)
+			if err := c.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestOccurrenceChange_Apply tests how each kind of change alters the class on its date.
+func TestOccurrenceChange_Apply(t *testing.T) {
+	s := schedule.Schedule{ID: "s1", ClassTypeID: "ct-1", Day: schedule.Tuesday, StartTime: "18:00", EndTime: "19:00", Mat: "Mat 1"}
+
+	if _, ok := (&schedule.OccurrenceChange{Kind: schedule.ChangeCancelled}).Apply(s); ok {
+		t.Error("a cancelled class should not run")
+	}
+	got, ok := (&schedule.OccurrenceChange{Kind: schedule.ChangeMoved, StartTime: "19:30", EndTime: "20:30"}).Apply(s)
+	if !ok || got.StartTime != "19:30" || got.EndTime != "20:30" || got.Mat != "Mat 1" {
+		t.Errorf("moved time: %+v, want 19:30-20:30 on Mat 1", got)
+	}
+	got, _ = (&schedule.OccurrenceChange{Kind: schedule.ChangeMoved, Mat: "Mat 3"}).Apply(s)
+	if got.StartTime != "18:00" || got.Mat != "Mat 3" {
+		t.Errorf("moved mat: %+v, want 18:00 on Mat 3", got)
+	}
+
+	added := schedule.OccurrenceChange{ScheduleID: "x1", ClassDate: "2026-03-14", Kind: schedule.ChangeAdded, ClassTypeID: "ct-2", StartTime: "10:00", EndTime: "12:00", Mat: "Mat 2"}
+	session := added.Session()
+	if session.ID != "x1" || session.Day != schedule.Saturday || session.ClassTypeID != "ct-2" || session.StartTime != "10:00" || session.Mat != "Mat 2" {
+		t.Errorf("session = %+v, want x1 on Saturday 10:00 on Mat 2", session)
+	}
+}
+
+// TestSchedule_OccursOn tests matching a date to the schedule's weekday.
+func TestSchedule_OccursOn(t *testing.T) {
+	s := schedule.Schedule{Day: schedule.Tuesday}
+	if !s.OccursOn(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected Tuesday 10 March to match")
+	}
+	if s.OccursOn(time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected Wednesday 11 March not to match")
+	}
+}
+
+// TestSchedule_ConflictsWith tests overlap detection by day, time window, location and mat.
+func TestSchedule_ConflictsWith(t *testing.T) {
+	base := schedule.Schedule{ID: "a", ClassTypeID: "ct-1", Day: schedule.Monday, StartTime: "18:00", EndTime: "19:00", LocationID: "wgtn"}
+	tests := []struct {
+		name   string
+		mat    string // mat of the first class
+		modify func(o *schedule.Schedule)
+		want   bool
+	}{
+		{"same slot", "", func(o *schedule.Schedule) {}, true},
+		{"overlapping start", "", func(o *schedule.Schedule) { o.StartTime, o.EndTime = "18:30", "19:30" }, true},
+		{"inside", "", func(o *schedule.Schedule) { o.StartTime, o.EndTime = "18:15", "18:45" }, true},
+		{"back to back", "", func(o *schedule.Schedule) { o.StartTime, o.EndTime = "19:00", "20:00" }, false},
+		{"other day", "", func(o *schedule.Schedule) { o.Day = schedule.Tuesday }, false},
+		{"other location", "", func(o *schedule.Schedule) { o.LocationID = "akl" }, false},
+		{"offered everywhere", "", func(o *schedule.Schedule) { o.LocationID = "" }, true},
+		{"whole floor against a mat", "", func(o *schedule.Schedule) { o.Mat = "Mat 2" }, true},
+		{"different mats", "Mat 1", func(o *schedule.Schedule) { o.Mat = "Mat 2" }, false},
+		{"same mat", "Mat 1", func(o *schedule.Schedule) { o.Mat = "mat 1" }, true},
+		{"same schedule", "", func(o *schedule.Schedule) { o.ID = "a" }, false},
+		{"bad times", "", func(o *schedule.Schedule) { o.StartTime = "late" }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := base
+			s.Mat = tt.mat
+			o := base
+			o.ID = "b"
+			tt.modify(&o)
+			if got := s.ConflictsWith(o); got != tt.want {
+				t.Errorf("ConflictsWith = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSchedule_Minutes tests classes that run past midnight end the next day.
+func TestSchedule_Minutes(t *testing.T) {
+	s := schedule.Schedule{StartTime: "23:00", EndTime: "00:30"}
+	start, end, ok := s.Minutes()
+	if !ok || start != 23*60 || end != 24*60+30 {
+		t.Errorf("Minutes = %d, %d, %v; want 1380, 1470, true", start, end, ok)
+	}
+}
@@ -0,0 +1,102 @@
+package search
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// Kind constants identify the entity a search result points to.
+const (
+	KindMember  = "member"
+	KindNotice  = "notice"
+	KindClip    = "clip"
+	KindTopic   = "topic"
+	KindMessage = "message"
+)
+
+// Kinds lists every searchable kind in display order.
+var Kinds = []string{KindMember, KindNotice, KindClip, KindTopic, KindMessage}
+
+// Visibility constants control who may see an indexed document.
+const (
+	VisibilityAll          = "all"          // every signed-in role
+	VisibilityStaff        = "staff"        // coaches and admins only
+	VisibilityParticipants = "participants" // only the listed participants (messages)
+)
+
+// Query limits.
+const (
+	MinQueryLength  = 2
+	MaxQueryLength  = 100
+	MaxTerms        = 8
+	DefaultPerKind  = 5
+	MaxResultsLimit = 20
+)
+
+// Domain errors.
+var (
+	ErrQueryTooShort = errors.New("search query must be at least 2 characters")
+	ErrQueryTooLong  = errors.New("search query cannot exceed 100 characters")
+)
+
+// Query describes a search request after role filtering has been decided.
+// INVARIANT: Kinds is non-empty; at least one of Staff or Participants restricts private results
+type Query struct {
+	Text         string
+	Kinds        []string // kinds the caller may see
+	Staff        bool     // caller is a coach or admin
+	Participants []string // IDs (account and member) that identify the caller on messages
+	PerKind      int      // max results per kind
+}
+
+// Result is one search hit.
+type Result struct {
+	Kind    string
+	RefID   string
+	Title   string
+	Snippet string // matched text with terms wrapped in [ and ]
+}
+
+// Validate checks the query text length.
+// PRE: none
+// POST: Returns nil if Text is within the length limits
+func (q Query) Validate() error {
+	n := len([]rune(strings.TrimSpace(q.Text)))
+	if n < MinQueryLength {
+		return ErrQueryTooShort
+	}
+	if n > MaxQueryLength {
+		return ErrQueryTooLong
+	}
+	return nil
+}
+
+// MatchExpression turns free text into a safe FTS5 MATCH expression.
+// Each word becomes a quoted prefix term, so punctuation and FTS operators in user
+// input are treated as plain text and "arm" matches "armbar".
+// PRE: none
+// POST: Returns "" if the text has no letters or digits
+func MatchExpression(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > MaxTerms {
+		words = words[:MaxTerms]
+	}
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		terms = append(terms, `"`+strings.ToLower(w)+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
+
+// ValidKind reports whether kind is one of the Kind constants.
+func ValidKind(kind string) bool {
+	for _, k := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
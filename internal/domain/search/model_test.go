@@ -0,0 +1,42 @@
+package search_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"workshop/internal/domain/search"
+)
+
+// TestMatchExpression tests that user input becomes quoted prefix terms.
+func TestMatchExpression(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "armbar", want: `"armbar"*`},
+		{in: "Arm Bar", want: `"arm"* "bar"*`},
+		{in: `kimura" OR title:*`, want: `"kimura"* "or"* "title"*`},
+		{in: "de la riva", want: `"de"* "la"* "riva"*`},
+		{in: "Māori", want: `"māori"*`},
+		{in: "!!! ---", want: ""},
+	}
+	for _, tt := range tests {
+		if got := search.MatchExpression(tt.in); got != tt.want {
+			t.Errorf("MatchExpression(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestQuery_Validate tests query length limits.
+func TestQuery_Validate(t *testing.T) {
+	if err := (search.Query{Text: " a "}).Validate(); !errors.Is(err, search.ErrQueryTooShort) {
+		t.Errorf("short query err = %v, want ErrQueryTooShort", err)
+	}
+	if err := (search.Query{Text: strings.Repeat("x", 101)}).Validate(); !errors.Is(err, search.ErrQueryTooLong) {
+		t.Errorf("long query err = %v, want ErrQueryTooLong", err)
+	}
+	if err := (search.Query{Text: "guard"}).Validate(); err != nil {
+		t.Errorf("valid query err = %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package sessionlog
+
+import (
+	"errors"
+	"time"
+)
+
+// Max length constants for user-editable fields.
+const (
+	MaxRoundStructureLength  = 1000
+	MaxAttendanceNotesLength = 2000
+	MaxNotesLength           = 5000
+	MaxTopics                = 20
+)
+
+// DateLayout is the format of ClassDate.
+const DateLayout = "2006-01-02"
+
+// Domain errors
+var (
+	ErrEmptyScheduleID        = errors.New("session log schedule ID is required")
+	ErrInvalidClassDate       = errors.New("session log class date must be YYYY-MM-DD")
+	ErrEmptyCoachID           = errors.New("session log coach is required")
+	ErrTooManyTopics          = errors.New("session log cannot list more than 20 topics")
+	ErrRoundStructureTooLong  = errors.New("round structure cannot exceed 1000 characters")
+	ErrAttendanceNotesTooLong = errors.New("attendance notes cannot exceed 2000 characters")
+	ErrNotesTooLong           = errors.New("session notes cannot exceed 5000 characters")
+	ErrClassDateInFuture      = errors.New("cannot log a class that has not happened yet")
+	ErrUnknownTopic           = errors.New("session log references an unknown topic")
+)
+
+// SessionLog records what a coach actually taught in one class occurrence.
+// INVARIANT: At most one SessionLog exists per (ScheduleID, ClassDate).
+type SessionLog struct {
+	ID              string
+	ScheduleID      string
+	ClassDate       string   // YYYY-MM-DD
+	CoachID         string   // AccountID of the coach who wrote the log
+	TopicIDs        []string // rotor topics covered (defaults to the active rotor topics)
+	RoundStructure  string   // e.g. "3x5min positional, 4x6min rolls"
+	AttendanceNotes string   // e.g. "two new visitors, Sam sat out with a sore knee"
+	Notes           string   // free-form coach notes
+	Shareable       bool     // members may read Notes in the curriculum history
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Validate checks if the SessionLog has valid data.
+// PRE: SessionLog struct is populated
+// POST: Returns nil if valid, error otherwise
+func (s *SessionLog) Validate() error {
+	if s.ScheduleID == "" {
+		return ErrEmptyScheduleID
+	}
+	if _, err := time.Parse(DateLayout, s.ClassDate); err != nil {
+		return ErrInvalidClassDate
+	}
+	if s.CoachID == "" {
+		return ErrEmptyCoachID
+	}
+	if len(s.TopicIDs) > MaxTopics {
+		return ErrTooManyTopics
+	}
+	if len(s.RoundStructure) > MaxRoundStructureLength {
+		return ErrRoundStructureTooLong
+	}
+	if len(s.AttendanceNotes) > MaxAttendanceNotesLength {
+		return ErrAttendanceNotesTooLong
+	}
+	if len(s.Notes) > MaxNotesLength {
+		return ErrNotesTooLong
+	}
+	if s.CreatedAt.IsZero() {
+		return errors.New("created_at must be set")
+	}
+	return nil
+}
+
+// Date returns ClassDate as a time at midnight UTC.
+// PRE: Validate has passed
+// INVARIANT: ClassDate is not mutated
+func (s *SessionLog) Date() time.Time {
+	d, _ := time.Parse(DateLayout, s.ClassDate)
+	return d
+}
+
+// CoversTopic reports whether topicID is among the topics covered.
+// INVARIANT: TopicIDs is not mutated
+func (s *SessionLog) CoversTopic(topicID string) bool {
+	for _, id := range s.TopicIDs {
+		if id == topicID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package sessionlog_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/sessionlog"
+)
+
+// TestSessionLog_Validate tests validation of SessionLog.
+func TestSessionLog_Validate(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	valid := func() sessionlog.SessionLog {
+		return sessionlog.SessionLog{ID: "l1", ScheduleID: "s1", ClassDate: "2026-03-01", CoachID: "c1", CreatedAt: now}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(l *sessionlog.SessionLog)
+		wantErr error
+	}{
+		{"valid", func(l *sessionlog.SessionLog) {}, nil},
+		{"missing schedule", func(l *sessionlog.SessionLog) { l.ScheduleID = "" }, sessionlog.ErrEmptyScheduleID},
+		{"bad date", func(l *sessionlog.SessionLog) { l.ClassDate = "01/03/2026" }, sessionlog.ErrInvalidClassDate},
+		{"missing coach", func(l *sessionlog.SessionLog) { l.CoachID = "" }, sessionlog.ErrEmptyCoachID},
+		{"too many topics", func(l *sessionlog.SessionLog) { l.TopicIDs = make([]string, sessionlog.MaxTopics+1) }, sessionlog.ErrTooManyTopics},
+		{"round structure too long", func(l *sessionlog.SessionLog) {
+			l.RoundStructure = strings.Repeat("a", sessionlog.MaxRoundStructureLength+1)
+		}, sessionlog.ErrRoundStructureTooLong},
+		{"attendance notes too long", func(l *sessionlog.SessionLog) {
+			l.AttendanceNotes = strings.Repeat("a", sessionlog.MaxAttendanceNotesLength+1)
+		}, sessionlog.ErrAttendanceNotesTooLong},
+		{"notes too long", func(l *sessionlog.SessionLog) { l.Notes = strings.Repeat("a", sessionlog.MaxNotesLength+1) }, sessionlog.ErrNotesTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := valid()
+			tt.mutate(&l)
+			if err := l.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestSessionLog_CoversTopic tests topic membership.
+func TestSessionLog_CoversTopic(t *testing.T) {
+	l := sessionlog.SessionLog{TopicIDs: []string{"t1", "t2"}}
+	if !l.CoversTopic("t2") || l.CoversTopic("t3") {
+		t.Errorf("CoversTopic gave wrong answer for %v", l.TopicIDs)
+	}
+}
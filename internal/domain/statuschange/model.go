@@ -0,0 +1,126 @@
+package statuschange
+
+import (
+	"errors"
+	"time"
+)
+
+// Subject types: what a change applies to.
+const (
+	SubjectAccount = "account"
+	SubjectMember  = "member"
+)
+
+// Actions. Suspend and reinstate apply to accounts; freeze and unfreeze to memberships.
+const (
+	ActionSuspend   = "suspend"   // block sign-in, showing the reason
+	ActionReinstate = "reinstate" // lift a suspension
+	ActionFreeze    = "freeze"    // pause a membership
+	ActionUnfreeze  = "unfreeze"  // resume a frozen membership
+)
+
+// MaxReasonLength caps the reason recorded with a change.
+const MaxReasonLength = 500
+
+// Domain errors
+var (
+	ErrEmptySubjectID       = errors.New("a status change needs an account or member")
+	ErrInvalidAction        = errors.New("action must be suspend, reinstate, freeze or unfreeze")
+	ErrInvalidEffectiveDate = errors.New("effective date must be YYYY-MM-DD")
+	ErrReasonTooLong        = errors.New("reason cannot exceed 500 characters")
+	ErrEmptyCreatedBy       = errors.New("created by cannot be empty")
+	ErrAlreadyApplied       = errors.New("this change has already been applied")
+)
+
+// Change is a suspension, reinstatement, freeze or unfreeze, applied straight away or
+// scheduled for a later date. Applied changes are kept as the subject's status history.
+type Change struct {
+	ID            string
+	SubjectType   string // account or member; follows from Action
+	SubjectID     string
+	Action        string
+	Reason        string
+	EffectiveDate string // YYYY-MM-DD; applied at the start of this day
+	CreatedBy     string // account ID
+	CreatedAt     time.Time
+	AppliedAt     time.Time // zero while scheduled
+	Error         string    // why applying failed, if it did
+}
+
+// SubjectFor returns the subject type an action applies to, or "" for an unknown action.
+func SubjectFor(action string) string {
+	switch action {
+	case ActionSuspend, ActionReinstate:
+		return SubjectAccount
+	case ActionFreeze, ActionUnfreeze:
+		return SubjectMember
+	}
+	return ""
+}
+
+// Reverse returns the action that undoes action: freeze and unfreeze, suspend and reinstate.
+func Reverse(action string) string {
+	switch action {
+	case ActionSuspend:
+		return ActionReinstate
+	case ActionReinstate:
+		return ActionSuspend
+	case ActionFreeze:
+		return ActionUnfreeze
+	case ActionUnfreeze:
+		return ActionFreeze
+	}
+	return ""
+}
+
+// Validate checks the change invariants.
+// PRE: none
+// POST: returns nil if valid, error describing the first violation otherwise
+func (c *Change) Validate() error {
+	if c.SubjectID == "" {
+		return ErrEmptySubjectID
+	}
+	if SubjectFor(c.Action) == "" || c.SubjectType != SubjectFor(c.Action) {
+		return ErrInvalidAction
+	}
+	if _, err := time.Parse("2006-01-02", c.EffectiveDate); err != nil {
+		return ErrInvalidEffectiveDate
+	}
+	if len(c.Reason) > MaxReasonLength {
+		return ErrReasonTooLong
+	}
+	if c.CreatedBy == "" {
+		return ErrEmptyCreatedBy
+	}
+	return nil
+}
+
+// IsScheduled reports whether the change is still waiting for its effective date.
+// INVARIANT: Change is not mutated
+func (c *Change) IsScheduled() bool {
+	return c.AppliedAt.IsZero() && c.Error == ""
+}
+
+// IsDue reports whether a scheduled change should be applied on today (YYYY-MM-DD).
+// INVARIANT: Change is not mutated
+func (c *Change) IsDue(today string) bool {
+	return c.IsScheduled() && c.EffectiveDate <= today
+}
+
+// MarkApplied records that the change took effect.
+// PRE: change is scheduled
+// POST: AppliedAt is now
+func (c *Change) MarkApplied(now time.Time) error {
+	if !c.IsScheduled() {
+		return ErrAlreadyApplied
+	}
+	c.AppliedAt = now
+	return nil
+}
+
+// MarkFailed records why the change could not be applied, so it is not retried.
+// PRE: change is scheduled
+// POST: Error is set
+func (c *Change) MarkFailed(reason string) {
+	c.Error = reason
+}
@@ -0,0 +1,52 @@
+package statuschange
+
+import (
+	"testing"
+	"time"
+)
+
+// TestChange_Validate tests each action must match its subject type and carry a valid date.
+func TestChange_Validate(t *testing.T) {
+	valid := Change{SubjectType: SubjectMember, SubjectID: "m1", Action: ActionFreeze, EffectiveDate: "2026-11-01", CreatedBy: "admin-1"}
+	tests := []struct {
+		name   string
+		change func(c *Change)
+		want   error
+	}{
+		{"valid", func(c *Change) {}, nil},
+		{"no subject", func(c *Change) { c.SubjectID = "" }, ErrEmptySubjectID},
+		{"unknown action", func(c *Change) { c.Action = "delete" }, ErrInvalidAction},
+		{"account action on member", func(c *Change) { c.Action = ActionSuspend }, ErrInvalidAction},
+		{"bad date", func(c *Change) { c.EffectiveDate = "1 November" }, ErrInvalidEffectiveDate},
+		{"no creator", func(c *Change) { c.CreatedBy = "" }, ErrEmptyCreatedBy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.change(&c)
+			if err := c.Validate(); err != tt.want {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestChange_IsDue tests a change is due from its effective date until applied or failed.
+func TestChange_IsDue(t *testing.T) {
+	c := Change{EffectiveDate: "2026-11-01"}
+	if c.IsDue("2026-10-31") || !c.IsDue("2026-11-01") || !c.IsDue("2026-11-02") {
+		t.Error("IsDue should start on the effective date")
+	}
+	if err := c.MarkApplied(time.Date(2026, 11, 1, 0, 5, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("MarkApplied: %v", err)
+	}
+	if c.IsDue("2026-11-02") {
+		t.Error("an applied change is not due")
+	}
+	if err := c.MarkApplied(time.Now()); err != ErrAlreadyApplied {
+		t.Errorf("apply twice: got %v, want ErrAlreadyApplied", err)
+	}
+	if Reverse(ActionFreeze) != ActionUnfreeze || Reverse(ActionSuspend) != ActionReinstate {
+		t.Error("Reverse should pair freeze/unfreeze and suspend/reinstate")
+	}
+}
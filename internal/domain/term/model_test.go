@@ -88,3 +88,118 @@ func TestTerm_Contains(t *testing.T) {
 		})
 	}
 }
+
+// TestProposeNext tests the NZ term dates proposed after each term.
+func TestProposeNext(t *testing.T) {
+	day := func(m time.Month, d, y int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name      string
+		prev      term.Term
+		wantName  string
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{"term 1 to 2", term.Term{StartDate: day(time.February, 2, 2026), EndDate: day(time.April, 2, 2026)}, "Term 2 2026", day(time.April, 20, 2026), day(time.June, 26, 2026)},
+		{"term 2 to 3", term.Term{StartDate: day(time.April, 20, 2026), EndDate: day(time.July, 3, 2026)}, "Term 3 2026", day(time.July, 20, 2026), day(time.September, 25, 2026)},
+		{"term 3 to 4 stops before Christmas", term.Term{StartDate: day(time.July, 20, 2026), EndDate: day(time.October, 2, 2026)}, "Term 4 2026", day(time.October, 19, 2026), day(time.December, 18, 2026)},
+		{"term 4 to next year", term.Term{StartDate: day(time.October, 12, 2026), EndDate: day(time.December, 16, 2026)}, "Term 1 2027", day(time.February, 1, 2027), day(time.April, 9, 2027)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := term.ProposeNext(tt.prev)
+			if got.Name != tt.wantName || !got.StartDate.Equal(tt.wantStart) || !got.EndDate.Equal(tt.wantEnd) {
+				t.Errorf("ProposeNext = %s %s-%s, want %s %s-%s", got.Name, got.StartDate.Format("2006-01-02"), got.EndDate.Format("2006-01-02"),
+					tt.wantName, tt.wantStart.Format("2006-01-02"), tt.wantEnd.Format("2006-01-02"))
+			}
+			if err := got.Validate(); err != nil {
+				t.Errorf("proposed term is invalid: %v", err)
+			}
+		})
+	}
+}
+
+// TestThreshold_Validate tests validation of a term's kids attendance threshold.
+func TestThreshold_Validate(t *testing.T) {
+	valid := term.Threshold{TermID: "t1", Belt: "grey", AttendancePct: 80}
+	tests := []struct {
+		name    string
+		modify  func(th *term.Threshold)
+		wantErr error
+	}{
+		{"valid", func(th *term.Threshold) {}, nil},
+		{"no term", func(th *term.Threshold) { th.TermID = "" }, term.ErrEmptyTermID},
+		{"no belt", func(th *term.Threshold) { th.Belt = "" }, term.ErrEmptyBelt},
+		{"zero", func(th *term.Threshold) { th.AttendancePct = 0 }, term.ErrInvalidThreshold},
+		{"over 100", func(th *term.Threshold) { th.AttendancePct = 101 }, term.ErrInvalidThreshold},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := valid
+			tt.modify(&th)
+			if err := th.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestReportDelivery_Validate tests validation of a term report delivery.
+func TestReportDelivery_Validate(t *testing.T) {
+	valid := term.ReportDelivery{TermID: "t1", MemberID: "k1", Recipient: "parent@test.com", SentAt: time.Date(2026, 4, 12, 8, 0, 0, 0, time.UTC)}
+	tests := []struct {
+		name    string
+		modify  func(d *term.ReportDelivery)
+		wantErr error
+	}{
+		{"valid", func(d *term.ReportDelivery) {}, nil},
+		{"no term", func(d *term.ReportDelivery) { d.TermID = "" }, term.ErrEmptyTermID},
+		{"no member", func(d *term.ReportDelivery) { d.MemberID = "" }, term.ErrEmptyReportMember},
+		{"bad address", func(d *term.ReportDelivery) { d.Recipient = "parent" }, term.ErrInvalidReportAddress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := valid
+			tt.modify(&d)
+			if err := d.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestTerm_ReportsDue tests the window in which a finished term's reports are emailed.
+func TestTerm_ReportsDue(t *testing.T) {
+	tm := term.Term{ID: "1", StartDate: time.Date(2026, 1, 27, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC)}
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"last day", time.Date(2026, 4, 10, 18, 0, 0, 0, time.UTC), false},
+		{"day after", time.Date(2026, 4, 11, 8, 0, 0, 0, time.UTC), true},
+		{"end of window", time.Date(2026, 4, 24, 8, 0, 0, 0, time.UTC), true},
+		{"too late", time.Date(2026, 4, 25, 8, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tm.ReportsDue(tt.now); got != tt.want {
+				t.Errorf("ReportsDue(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestReportFilename tests report file names are safe to download.
+func TestReportFilename(t *testing.T) {
+	tests := []struct{ term, member, want string }{
+		{"Term 1 2026", "Aroha Smith", "term-1-2026-aroha-smith-report.pdf"},
+		{"Term 1 2026", "", "term-1-2026-report.pdf"},
+		{"Term 2", "Mōkai O'Neil", "term-2-mokai-o-neil-report.pdf"},
+		{"", "", "term-report.pdf"},
+	}
+	for _, tt := range tests {
+		if got := term.ReportFilename(tt.term, tt.member); got != tt.want {
+			t.Errorf("ReportFilename(%q, %q) = %q, want %q", tt.term, tt.member, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package term
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Report delivery errors
+var (
+	ErrEmptyReportMember    = errors.New("report member ID cannot be empty")
+	ErrInvalidReportAddress = errors.New("report recipient must be an email address")
+)
+
+// ReportEmailWindowDays is how long after a term ends the end-of-term run still emails its
+// reports, so a server that was down on the day catches up without mailing old terms.
+const ReportEmailWindowDays = 14
+
+// ReportDelivery records a kid's term report being emailed to their guardian, so each
+// report is sent once however often the end-of-term run fires.
+type ReportDelivery struct {
+	TermID    string
+	MemberID  string
+	Recipient string // address the report was sent to
+	SentBy    string // AccountID of the admin who sent it; empty for the end-of-term run
+	SentAt    time.Time
+}
+
+// Validate checks if the ReportDelivery has valid data.
+// PRE: ReportDelivery struct is populated
+// POST: Returns nil if valid, error otherwise
+func (d *ReportDelivery) Validate() error {
+	if d.TermID == "" {
+		return ErrEmptyTermID
+	}
+	if d.MemberID == "" {
+		return ErrEmptyReportMember
+	}
+	if !strings.Contains(d.Recipient, "@") {
+		return ErrInvalidReportAddress
+	}
+	if d.SentAt.IsZero() {
+		return errors.New("sent at cannot be zero")
+	}
+	return nil
+}
+
+// ReportsDue reports whether the end-of-term run should email t's reports on now: the term
+// has finished and ended no more than ReportEmailWindowDays ago.
+// PRE: t has an end date
+// POST: Returns false while the term is running
+func (t *Term) ReportsDue(now time.Time) bool {
+	end := time.Date(t.EndDate.Year(), t.EndDate.Month(), t.EndDate.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return today.After(end) && !today.After(end.AddDate(0, 0, ReportEmailWindowDays))
+}
+
+// macronFree writes te reo Māori vowels without their macrons, for ASCII file names.
+var macronFree = strings.NewReplacer("ā", "a", "ē", "e", "ī", "i", "ō", "o", "ū", "u")
+
+// ReportFilename names a term report PDF, e.g. "term-1-2026-aroha-smith-report.pdf". memberName is
+// empty for a batch of reports.
+// PRE: none
+// POST: Returns a lowercase file name of letters, digits and hyphens ending in ".pdf"
+func ReportFilename(termName, memberName string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range macronFree.Replace(strings.ToLower(termName + " " + memberName)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			hyphen = false
+		} else if !hyphen && b.Len() > 0 {
+			b.WriteByte('-')
+			hyphen = true
+		}
+	}
+	name := strings.TrimSuffix(b.String(), "-")
+	if name == "" {
+		name = "term"
+	}
+	return name + "-report.pdf"
+}
@@ -0,0 +1,140 @@
+package term
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Rollover errors
+var (
+	ErrOverlap           = errors.New("term overlaps an existing term")
+	ErrNoPreviousTerm    = errors.New("add the first term by hand before rolling over")
+	ErrEmptyTermID       = errors.New("term ID cannot be empty")
+	ErrEmptyBelt         = errors.New("belt cannot be empty")
+	ErrInvalidThreshold  = errors.New("attendance threshold must be between 1 and 100")
+	ErrEmptySnapshotTerm = errors.New("snapshot term ID cannot be empty")
+)
+
+// NZ school terms run four a year: three of ten weeks with two-week breaks between them,
+// and a shorter fourth ending before Christmas.
+const (
+	TermsPerYear   = 4
+	termWeeks      = 10
+	breakDays      = 14
+	firstTermMonth = time.February // term 1 starts on the first Monday in February
+	lastTermEndDay = 18            // term 4 ends on the last Friday on or before 18 December
+)
+
+// Number returns which of the year's four terms t is, judged by the quarter it starts in.
+// PRE: StartDate is set
+// POST: Returns 1-4
+func (t *Term) Number() int {
+	return (int(t.StartDate.Month())-1)/3 + 1
+}
+
+// Overlaps reports whether two terms share at least one day.
+// PRE: both terms have start and end dates
+// POST: Returns true when the date ranges intersect
+func (t *Term) Overlaps(other Term) bool {
+	return !t.StartDate.After(other.EndDate) && !other.StartDate.After(t.EndDate)
+}
+
+// ProposeNext proposes the NZ school term after prev. Terms 2-4 start on the Monday after
+// a two-week break; term 1 starts on the first Monday in February. Each runs ten weeks to a
+// Friday, except term 4 which stops before Christmas. The dates are a starting point for
+// the admin to confirm: schools may shift them by a few days.
+// PRE: prev has a start and end date
+// POST: Returns a term named "Term N YYYY" with no ID
+func ProposeNext(prev Term) Term {
+	n := prev.Number()%TermsPerYear + 1
+	var start time.Time
+	if n == 1 {
+		start = onOrAfter(time.Date(prev.StartDate.Year()+1, firstTermMonth, 1, 0, 0, 0, 0, time.UTC), time.Monday)
+	} else {
+		end := prev.EndDate.Truncate(24 * time.Hour)
+		start = onOrAfter(end.AddDate(0, 0, breakDays), time.Monday)
+	}
+	end := start.AddDate(0, 0, (termWeeks-1)*7+4) // Friday of the tenth week
+	if n == TermsPerYear {
+		if last := onOrBefore(time.Date(start.Year(), time.December, lastTermEndDay, 0, 0, 0, 0, time.UTC), time.Friday); last.Before(end) {
+			end = last
+		}
+	}
+	return Term{
+		Name:      fmt.Sprintf("Term %d %d", n, start.Year()),
+		StartDate: start,
+		EndDate:   end,
+	}
+}
+
+// onOrAfter returns the first date on or after d that falls on day.
+func onOrAfter(d time.Time, day time.Weekday) time.Time {
+	return d.AddDate(0, 0, (int(day)-int(d.Weekday())+7)%7)
+}
+
+// onOrBefore returns the last date on or before d that falls on day.
+func onOrBefore(d time.Time, day time.Weekday) time.Time {
+	return d.AddDate(0, 0, -((int(d.Weekday()) - int(day) + 7) % 7))
+}
+
+// Threshold is the term attendance a kid needs for a belt in one term. Thresholds are set
+// per term so a rollover can carry them forward; without one the grading config applies.
+type Threshold struct {
+	TermID        string
+	Belt          string  // target belt
+	AttendancePct float64 // required attendance %
+}
+
+// Validate checks if the Threshold has valid data.
+// PRE: Threshold struct is populated
+// POST: Returns nil if valid, error otherwise
+func (t *Threshold) Validate() error {
+	if t.TermID == "" {
+		return ErrEmptyTermID
+	}
+	if t.Belt == "" {
+		return ErrEmptyBelt
+	}
+	if t.AttendancePct <= 0 || t.AttendancePct > 100 {
+		return ErrInvalidThreshold
+	}
+	return nil
+}
+
+// ReadinessEntry is one kid's grading readiness as it stood when the term was archived.
+type ReadinessEntry struct {
+	MemberID      string
+	MemberName    string
+	CurrentBelt   string
+	TargetBelt    string
+	Attended      int
+	MakeupCredits int
+	TotalSessions int
+	AttendancePct float64
+	ThresholdPct  float64
+	Eligible      bool
+}
+
+// ReadinessSnapshot freezes a finished term's kids readiness so it can still be looked up
+// after later gradings and attendance fixes would change the live figures.
+type ReadinessSnapshot struct {
+	TermID     string
+	TermName   string
+	Entries    []ReadinessEntry
+	ArchivedBy string // AccountID
+	ArchivedAt time.Time
+}
+
+// Validate checks if the ReadinessSnapshot has valid data.
+// PRE: ReadinessSnapshot struct is populated
+// POST: Returns nil if valid, error otherwise
+func (s *ReadinessSnapshot) Validate() error {
+	if s.TermID == "" {
+		return ErrEmptySnapshotTerm
+	}
+	if s.ArchivedAt.IsZero() {
+		return errors.New("archived at cannot be zero")
+	}
+	return nil
+}
@@ -15,14 +15,16 @@ const (
 // PRE: Name and Program are non-empty.
 // INVARIANT: StartDate is before EndDate.
 type Theme struct {
-	ID          string
-	Name        string    // e.g. "Leg Lasso Series"
-	Description string    // brief summary of the technical focus
-	Program     string    // "adults" or "kids"
-	StartDate   time.Time // first day of the 4-week block
-	EndDate     time.Time // last day of the 4-week block
-	CreatedBy   string    // account ID of the creator
-	CreatedAt   time.Time
+	ID           string
+	Name         string    // e.g. "Leg Lasso Series"
+	Description  string    // brief summary of the technical focus
+	Program      string    // "adults" or "kids"
+	StartDate    time.Time // first day of the 4-week block
+	EndDate      time.Time // last day of the 4-week block
+	CreatedBy    string    // account ID of the creator
+	MinBelt      string    // optional: members below this belt see the theme and its clips locked
+	RotorThemeID string    // optional: the rotor theme this block's clips belong to
+	CreatedAt    time.Time
 }
 
 // StatusActive indicates the theme is currently running.
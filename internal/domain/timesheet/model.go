@@ -0,0 +1,170 @@
+package timesheet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Coach session sources: how the coach of an occurrence was decided.
+const (
+	SourceDefault    = "default"    // the schedule's regular coach
+	SourceOverride   = "override"   // assigned to this one date
+	SourceUnassigned = "unassigned" // no coach known; not paid until assigned
+)
+
+// Business rule constants
+const (
+	MaxHourlyRate = 100000 // cents; $1,000 an hour catches a rate typed in cents twice
+)
+
+// Domain errors
+var (
+	ErrEmptyScheduleID  = errors.New("schedule ID is required")
+	ErrInvalidClassDate = errors.New("class date must be YYYY-MM-DD")
+	ErrEmptyCoachID     = errors.New("coach is required")
+	ErrEmptySetBy       = errors.New("set_by is required")
+	ErrInvalidMonth     = errors.New("month must be YYYY-MM")
+	ErrInvalidRate      = errors.New("hourly rate must be between 0 and 1000.00")
+)
+
+// Override hands one occurrence of a class to a coach other than its regular one.
+// At most one override exists per schedule and date.
+type Override struct {
+	ID         string
+	ScheduleID string
+	ClassDate  string // YYYY-MM-DD
+	CoachID    string // AccountID of the coach who ran the class
+	SetBy      string // AccountID of the admin
+	SetAt      time.Time
+}
+
+// Validate checks if the Override has valid data.
+// PRE: Override struct is populated
+// POST: Returns nil if valid, error otherwise
+func (o *Override) Validate() error {
+	if o.ScheduleID == "" {
+		return ErrEmptyScheduleID
+	}
+	if _, err := time.Parse("2006-01-02", o.ClassDate); err != nil {
+		return ErrInvalidClassDate
+	}
+	if o.CoachID == "" {
+		return ErrEmptyCoachID
+	}
+	if o.SetBy == "" {
+		return ErrEmptySetBy
+	}
+	return nil
+}
+
+// Rate is what a coach is paid per hour on the mats.
+type Rate struct {
+	CoachID    string // AccountID
+	HourlyRate int    // cents per hour
+	UpdatedBy  string // AccountID of the admin
+	UpdatedAt  time.Time
+}
+
+// Validate checks if the Rate has valid data.
+// PRE: Rate struct is populated
+// POST: Returns nil if valid, error otherwise
+func (r *Rate) Validate() error {
+	if r.CoachID == "" {
+		return ErrEmptyCoachID
+	}
+	if r.HourlyRate < 0 || r.HourlyRate > MaxHourlyRate {
+		return ErrInvalidRate
+	}
+	if r.UpdatedBy == "" {
+		return ErrEmptySetBy
+	}
+	return nil
+}
+
+// CoachSession is one class occurrence and the coach who ran it.
+type CoachSession struct {
+	ScheduleID string
+	ClassDate  string // YYYY-MM-DD
+	StartTime  string // HH:MM
+	EndTime    string // HH:MM
+	Hours      float64
+	CoachID    string // empty when unassigned
+	Source     string // SourceDefault, SourceOverride or SourceUnassigned
+	Substitute string // substitute named on the occurrence change, if any
+}
+
+// Assign decides who ran an occurrence. An override wins; a substitute named on the class
+// change without an override leaves the session unassigned, since the name does not say
+// which account to pay; otherwise the schedule's regular coach ran it.
+// PRE: session has ScheduleID and ClassDate set
+// POST: CoachID and Source are set
+func (s *CoachSession) Assign(defaultCoachID, overrideCoachID, substitute string) {
+	s.Substitute = substitute
+	switch {
+	case overrideCoachID != "":
+		s.CoachID, s.Source = overrideCoachID, SourceOverride
+	case substitute == "" && defaultCoachID != "":
+		s.CoachID, s.Source = defaultCoachID, SourceDefault
+	default:
+		s.CoachID, s.Source = "", SourceUnassigned
+	}
+}
+
+// Total is one coach's hours and pay for a period.
+type Total struct {
+	CoachID    string
+	Sessions   int
+	Hours      float64
+	HourlyRate int // cents per hour; 0 when no rate is set
+	Pay        int // cents, rounded to the nearest cent
+}
+
+// Summarise totals the assigned sessions per coach at their hourly rates.
+// PRE: rates maps CoachID to cents per hour
+// POST: Returns one total per coach, ordered by CoachID; unassigned sessions are left out
+func Summarise(sessions []CoachSession, rates map[string]int) []Total {
+	byCoach := map[string]*Total{}
+	for _, s := range sessions {
+		if s.CoachID == "" {
+			continue
+		}
+		t, ok := byCoach[s.CoachID]
+		if !ok {
+			t = &Total{CoachID: s.CoachID, HourlyRate: rates[s.CoachID]}
+			byCoach[s.CoachID] = t
+		}
+		t.Sessions++
+		t.Hours += s.Hours
+	}
+	totals := make([]Total, 0, len(byCoach))
+	for _, t := range byCoach {
+		t.Pay = int(t.Hours*float64(t.HourlyRate) + 0.5)
+		totals = append(totals, *t)
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].CoachID < totals[j].CoachID })
+	return totals
+}
+
+// ParseMonth returns the first and last day of a YYYY-MM month.
+// PRE: none
+// POST: Returns ErrInvalidMonth when month is not YYYY-MM
+func ParseMonth(month string) (first, last time.Time, err error) {
+	first, err = time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidMonth
+	}
+	return first, first.AddDate(0, 1, -1), nil
+}
+
+// FormatCents renders cents as dollars with two decimals, e.g. 1250 as "12.50".
+// PRE: none
+// POST: Returns the amount without a currency sign
+func FormatCents(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
@@ -0,0 +1,123 @@
+package timesheet_test
+
+import (
+	"testing"
+
+	"workshop/internal/domain/timesheet"
+)
+
+// TestOverride_Validate tests validation of per-day coach overrides.
+func TestOverride_Validate(t *testing.T) {
+	valid := timesheet.Override{ScheduleID: "s1", ClassDate: "2026-03-02", CoachID: "coach-1", SetBy: "admin-1"}
+	tests := []struct {
+		name   string
+		modify func(o *timesheet.Override)
+		want   error
+	}{
+		{"valid", func(o *timesheet.Override) {}, nil},
+		{"no schedule", func(o *timesheet.Override) { o.ScheduleID = "" }, timesheet.ErrEmptyScheduleID},
+		{"bad date", func(o *timesheet.Override) { o.ClassDate = "2026-3-2" }, timesheet.ErrInvalidClassDate},
+		{"no coach", func(o *timesheet.Override) { o.CoachID = "" }, timesheet.ErrEmptyCoachID},
+		{"no admin", func(o *timesheet.Override) { o.SetBy = "" }, timesheet.ErrEmptySetBy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := valid
+			tt.modify(&o)
+			if got := o.Validate(); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRate_Validate tests validation of hourly rates.
+func TestRate_Validate(t *testing.T) {
+	valid := timesheet.Rate{CoachID: "coach-1", HourlyRate: 4250, UpdatedBy: "admin-1"}
+	tests := []struct {
+		name   string
+		modify func(r *timesheet.Rate)
+		want   error
+	}{
+		{"valid", func(r *timesheet.Rate) {}, nil},
+		{"volunteer", func(r *timesheet.Rate) { r.HourlyRate = 0 }, nil},
+		{"negative", func(r *timesheet.Rate) { r.HourlyRate = -1 }, timesheet.ErrInvalidRate},
+		{"too high", func(r *timesheet.Rate) { r.HourlyRate = timesheet.MaxHourlyRate + 1 }, timesheet.ErrInvalidRate},
+		{"no coach", func(r *timesheet.Rate) { r.CoachID = "" }, timesheet.ErrEmptyCoachID},
+		{"no admin", func(r *timesheet.Rate) { r.UpdatedBy = "" }, timesheet.ErrEmptySetBy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid
+			tt.modify(&r)
+			if got := r.Validate(); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCoachSession_Assign tests that overrides beat the regular coach and a named
+// substitute without an override leaves the session unassigned.
+func TestCoachSession_Assign(t *testing.T) {
+	tests := []struct {
+		name                        string
+		defaultCoach, override, sub string
+		wantCoach, wantSource       string
+	}{
+		{"regular coach", "coach-1", "", "", "coach-1", timesheet.SourceDefault},
+		{"override", "coach-1", "coach-2", "", "coach-2", timesheet.SourceOverride},
+		{"override for a substitute", "coach-1", "coach-2", "Sam", "coach-2", timesheet.SourceOverride},
+		{"substitute only", "coach-1", "", "Sam", "", timesheet.SourceUnassigned},
+		{"no regular coach", "", "", "", "", timesheet.SourceUnassigned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := timesheet.CoachSession{ScheduleID: "s1", ClassDate: "2026-03-02"}
+			s.Assign(tt.defaultCoach, tt.override, tt.sub)
+			if s.CoachID != tt.wantCoach || s.Source != tt.wantSource {
+				t.Errorf("Assign() = %q/%q, want %q/%q", s.CoachID, s.Source, tt.wantCoach, tt.wantSource)
+			}
+		})
+	}
+}
+
+// TestSummarise tests per-coach totals, pay rounding and that unassigned sessions are skipped.
+func TestSummarise(t *testing.T) {
+	sessions := []timesheet.CoachSession{
+		{CoachID: "coach-2", Hours: 1},
+		{CoachID: "coach-1", Hours: 1.5},
+		{CoachID: "coach-1", Hours: 1},
+		{CoachID: "", Hours: 1},
+	}
+	got := timesheet.Summarise(sessions, map[string]int{"coach-1": 3333})
+	if len(got) != 2 {
+		t.Fatalf("totals = %+v, want two coaches", got)
+	}
+	if got[0].CoachID != "coach-1" || got[0].Sessions != 2 || got[0].Hours != 2.5 || got[0].Pay != 8333 {
+		t.Errorf("coach-1 = %+v, want 2 sessions, 2.5h, 8333 cents", got[0])
+	}
+	if got[1].CoachID != "coach-2" || got[1].HourlyRate != 0 || got[1].Pay != 0 {
+		t.Errorf("coach-2 = %+v, want no rate and no pay", got[1])
+	}
+}
+
+// TestParseMonth tests month bounds, including February in a leap year.
+func TestParseMonth(t *testing.T) {
+	first, last, err := timesheet.ParseMonth("2028-02")
+	if err != nil || first.Format("2006-01-02") != "2028-02-01" || last.Format("2006-01-02") != "2028-02-29" {
+		t.Errorf("ParseMonth(2028-02) = %v..%v, %v", first, last, err)
+	}
+	if _, _, err := timesheet.ParseMonth("2028-13"); err != timesheet.ErrInvalidMonth {
+		t.Errorf("ParseMonth(2028-13) error = %v, want ErrInvalidMonth", err)
+	}
+}
+
+// TestFormatCents tests dollar formatting.
+func TestFormatCents(t *testing.T) {
+	for cents, want := range map[int]string{0: "0.00", 5: "0.05", 1250: "12.50", -199: "-1.99"} {
+		if got := timesheet.FormatCents(cents); got != want {
+			t.Errorf("FormatCents(%d) = %q, want %q", cents, got, want)
+		}
+	}
+}
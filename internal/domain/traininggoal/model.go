@@ -2,6 +2,7 @@ package traininggoal
 
 import (
 	"errors"
+	"sort"
 	"time"
 )
 
@@ -43,3 +44,40 @@ func (g *TrainingGoal) Validate() error {
 	}
 	return nil
 }
+
+// SuggestionWeeks is how many past weeks of attendance a suggested target is drawn from.
+const SuggestionWeeks = 8
+
+// maxWeeklyTarget caps suggestions at one session a day.
+const maxWeeklyTarget = 7
+
+// SuggestWeeklyTarget picks an achievable weekly target from a member's sessions per
+// week. It starts from the median week, so one big or missed week doesn't skew it, and
+// adds one when the member already beat the median in at least a quarter of the weeks.
+// PRE: weekly holds one session count per week
+// POST: Returns a target between 1 and 7
+func SuggestWeeklyTarget(weekly []int) int {
+	if len(weekly) == 0 {
+		return 1
+	}
+	sorted := append([]int{}, weekly...)
+	sort.Ints(sorted)
+	target := sorted[(len(sorted)-1)/2]
+
+	above := 0
+	for _, n := range weekly {
+		if n > target {
+			above++
+		}
+	}
+	if above*4 >= len(weekly) {
+		target++
+	}
+	if target < 1 {
+		target = 1
+	}
+	if target > maxWeeklyTarget {
+		target = maxWeeklyTarget
+	}
+	return target
+}
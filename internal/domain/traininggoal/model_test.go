@@ -50,3 +50,26 @@ func TestTrainingGoal_Validate(t *testing.T) {
 		})
 	}
 }
+
+// TestSuggestWeeklyTarget tests that suggestions follow the typical week with a small stretch.
+func TestSuggestWeeklyTarget(t *testing.T) {
+	tests := []struct {
+		name   string
+		weekly []int
+		want   int
+	}{
+		{"steady twice a week", []int{2, 2, 2, 2, 2, 2, 2, 2}, 2},
+		{"often three", []int{2, 3, 2, 3, 2, 2, 1, 2}, 3},
+		{"one big week", []int{2, 2, 2, 6, 2, 2, 2, 2}, 2},
+		{"mostly away", []int{0, 0, 0, 0, 0, 0, 1, 0}, 1},
+		{"no history", nil, 1},
+		{"every day and more", []int{9, 9, 9, 9, 9, 9, 9, 9}, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := traininggoal.SuggestWeeklyTarget(tt.weekly); got != tt.want {
+				t.Errorf("SuggestWeeklyTarget(%v) = %d, want %d", tt.weekly, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package visitor
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Visitor statuses
+const (
+	StatusVisiting = "visiting" // drop-in guest, not yet signed up
+	StatusTrial    = "trial"
+	StatusMember   = "member"
+)
+
+// Business rule constants
+const (
+	MaxHomeGymLength = 100
+	DefaultDropInFee = 25 // dollars per visit, unless the visitor has their own rate
+	FollowUpDelay    = 48 * time.Hour
+)
+
+// Domain errors
+var (
+	ErrEmptyMemberID      = errors.New("member ID is required")
+	ErrEmptyName          = errors.New("visitor name is required")
+	ErrInvalidEmail       = errors.New("visitor email must be valid")
+	ErrHomeGymTooLong     = errors.New("home gym cannot exceed 100 characters")
+	ErrNegativeFee        = errors.New("drop-in fee cannot be negative")
+	ErrInvalidStatus      = errors.New("status must be visiting, trial or member")
+	ErrInvalidConversion  = errors.New("a visitor can only convert to trial or member")
+	ErrAlreadyConverted   = errors.New("visitor has already converted")
+	ErrEmptyVisitorID     = errors.New("visitor ID is required")
+	ErrVisitAlreadyPaid   = errors.New("visit is already paid")
+	ErrVisitAlreadyUnpaid = errors.New("visit is not paid")
+)
+
+// Visitor is a drop-in guest remembered across visits. Each guest check-in by the same
+// email is another visit by the same visitor, not a new person.
+type Visitor struct {
+	ID              string
+	MemberID        string // the member record created at the first guest check-in
+	Name            string
+	Email           string
+	HomeGym         string // the club they usually train at, if any
+	DropInFee       int    // dollars per visit; 0 charges DefaultDropInFee
+	Status          string
+	FirstVisit      time.Time
+	LastVisit       time.Time
+	VisitCount      int
+	FollowUpEmailID string // follow-up scheduled after the first visit; empty when none was queued
+	ConvertedAt     time.Time
+	CreatedAt       time.Time
+}
+
+// Validate checks if the Visitor has valid data.
+// PRE: Visitor struct is populated
+// POST: Returns nil if valid, error otherwise
+func (v *Visitor) Validate() error {
+	if v.MemberID == "" {
+		return ErrEmptyMemberID
+	}
+	if strings.TrimSpace(v.Name) == "" {
+		return ErrEmptyName
+	}
+	if !strings.Contains(v.Email, "@") {
+		return ErrInvalidEmail
+	}
+	if len(v.HomeGym) > MaxHomeGymLength {
+		return ErrHomeGymTooLong
+	}
+	if v.DropInFee < 0 {
+		return ErrNegativeFee
+	}
+	if v.Status != StatusVisiting && v.Status != StatusTrial && v.Status != StatusMember {
+		return ErrInvalidStatus
+	}
+	return nil
+}
+
+// Fee returns what one visit costs this visitor.
+// INVARIANT: Visitor is not mutated
+func (v *Visitor) Fee() int {
+	if v.DropInFee > 0 {
+		return v.DropInFee
+	}
+	return DefaultDropInFee
+}
+
+// RecordVisit counts a visit at the given time.
+// PRE: at is not before LastVisit
+// POST: VisitCount is incremented; FirstVisit is set on the first visit; LastVisit is at
+func (v *Visitor) RecordVisit(at time.Time) {
+	if v.FirstVisit.IsZero() {
+		v.FirstVisit = at
+	}
+	v.LastVisit = at
+	v.VisitCount++
+}
+
+// IsReturning reports whether the visitor has been more than once.
+// INVARIANT: Visitor is not mutated
+func (v *Visitor) IsReturning() bool {
+	return v.VisitCount > 1
+}
+
+// IsConverted reports whether the visitor has signed up as a trial or member.
+// INVARIANT: Visitor is not mutated
+func (v *Visitor) IsConverted() bool {
+	return v.Status != StatusVisiting
+}
+
+// FollowUpAt returns when the follow-up email after the first visit goes out.
+// PRE: FirstVisit is set
+// INVARIANT: Visitor is not mutated
+func (v *Visitor) FollowUpAt() time.Time {
+	return v.FirstVisit.Add(FollowUpDelay)
+}
+
+// Convert signs the visitor up as a trial or member. A trial can later become a member;
+// nothing moves back.
+// PRE: to is StatusTrial or StatusMember
+// POST: Status is to and ConvertedAt is now, or an error and nothing changes
+func (v *Visitor) Convert(to string, now time.Time) error {
+	if to != StatusTrial && to != StatusMember {
+		return ErrInvalidConversion
+	}
+	if v.Status == StatusMember || v.Status == to {
+		return ErrAlreadyConverted
+	}
+	v.Status = to
+	v.ConvertedAt = now
+	return nil
+}
+
+// Visit is one drop-in by a visitor and what it cost.
+type Visit struct {
+	ID           string
+	VisitorID    string
+	AttendanceID string // the check-in the visit came from
+	VisitedAt    time.Time
+	Fee          int // dollars charged, fixed at check-in
+	Paid         bool
+}
+
+// Validate checks if the Visit has valid data.
+// PRE: Visit struct is populated
+// POST: Returns nil if valid, error otherwise
+func (v *Visit) Validate() error {
+	if v.VisitorID == "" {
+		return ErrEmptyVisitorID
+	}
+	if v.Fee < 0 {
+		return ErrNegativeFee
+	}
+	return nil
+}
+
+// SetPaid records whether the drop-in fee has been taken.
+// POST: Paid is paid, or an error when it already was
+func (v *Visit) SetPaid(paid bool) error {
+	if v.Paid == paid {
+		if paid {
+			return ErrVisitAlreadyPaid
+		}
+		return ErrVisitAlreadyUnpaid
+	}
+	v.Paid = paid
+	return nil
+}
@@ -0,0 +1,117 @@
+package visitor_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/visitor"
+)
+
+// TestVisitor_Validate tests validation of visitors.
+func TestVisitor_Validate(t *testing.T) {
+	valid := visitor.Visitor{MemberID: "m1", Name: "Kai", Email: "kai@example.com", Status: visitor.StatusVisiting}
+	tests := []struct {
+		name   string
+		modify func(v *visitor.Visitor)
+		want   error
+	}{
+		{"valid", func(v *visitor.Visitor) {}, nil},
+		{"with home gym and rate", func(v *visitor.Visitor) { v.HomeGym = "City BJJ"; v.DropInFee = 15 }, nil},
+		{"no member", func(v *visitor.Visitor) { v.MemberID = "" }, visitor.ErrEmptyMemberID},
+		{"blank name", func(v *visitor.Visitor) { v.Name = "  " }, visitor.ErrEmptyName},
+		{"bad email", func(v *visitor.Visitor) { v.Email = "kai" }, visitor.ErrInvalidEmail},
+		{"long home gym", func(v *visitor.Visitor) { v.HomeGym = strings.Repeat("x", 101) }, visitor.ErrHomeGymTooLong},
+		{"negative fee", func(v *visitor.Visitor) { v.DropInFee = -1 }, visitor.ErrNegativeFee},
+		{"unknown status", func(v *visitor.Visitor) { v.Status = "lead" }, visitor.ErrInvalidStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valid
+			tt.modify(&v)
+			if got := v.Validate(); got != tt.want {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestVisitor_RecordVisit tests that visits are counted and the first one is kept.
+func TestVisitor_RecordVisit(t *testing.T) {
+	first := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 0, 7)
+	v := visitor.Visitor{}
+	v.RecordVisit(first)
+	if v.IsReturning() || v.FollowUpAt() != first.Add(visitor.FollowUpDelay) {
+		t.Fatalf("after one visit: %+v", v)
+	}
+	v.RecordVisit(second)
+	if !v.IsReturning() || v.VisitCount != 2 || v.FirstVisit != first || v.LastVisit != second {
+		t.Errorf("after two visits: %+v", v)
+	}
+}
+
+// TestVisitor_Fee tests the default and per-visitor drop-in rates.
+func TestVisitor_Fee(t *testing.T) {
+	v := visitor.Visitor{}
+	if v.Fee() != visitor.DefaultDropInFee {
+		t.Errorf("Fee() = %d, want default %d", v.Fee(), visitor.DefaultDropInFee)
+	}
+	v.DropInFee = 10
+	if v.Fee() != 10 {
+		t.Errorf("Fee() = %d, want 10", v.Fee())
+	}
+}
+
+// TestVisitor_Convert tests the allowed conversions.
+func TestVisitor_Convert(t *testing.T) {
+	now := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want error
+	}{
+		{"visitor to trial", visitor.StatusVisiting, visitor.StatusTrial, nil},
+		{"visitor to member", visitor.StatusVisiting, visitor.StatusMember, nil},
+		{"trial to member", visitor.StatusTrial, visitor.StatusMember, nil},
+		{"trial again", visitor.StatusTrial, visitor.StatusTrial, visitor.ErrAlreadyConverted},
+		{"member to trial", visitor.StatusMember, visitor.StatusTrial, visitor.ErrAlreadyConverted},
+		{"back to visiting", visitor.StatusTrial, visitor.StatusVisiting, visitor.ErrInvalidConversion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := visitor.Visitor{Status: tt.from}
+			err := v.Convert(tt.to, now)
+			if err != tt.want {
+				t.Fatalf("Convert() = %v, want %v", err, tt.want)
+			}
+			if err == nil && (v.Status != tt.to || !v.ConvertedAt.Equal(now) || !v.IsConverted()) {
+				t.Errorf("after Convert: %+v", v)
+			}
+			if err != nil && v.Status != tt.from {
+				t.Errorf("status changed on error: %q", v.Status)
+			}
+		})
+	}
+}
+
+// TestVisit_SetPaid tests taking and refunding a drop-in fee.
+func TestVisit_SetPaid(t *testing.T) {
+	v := visitor.Visit{VisitorID: "v1", Fee: 25}
+	if err := v.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+	if err := v.SetPaid(false); err != visitor.ErrVisitAlreadyUnpaid {
+		t.Errorf("SetPaid(false) on unpaid = %v", err)
+	}
+	if err := v.SetPaid(true); err != nil || !v.Paid {
+		t.Errorf("SetPaid(true) = %v, paid %v", err, v.Paid)
+	}
+	if err := v.SetPaid(true); err != visitor.ErrVisitAlreadyPaid {
+		t.Errorf("SetPaid(true) twice = %v", err)
+	}
+	if err := (&visitor.Visit{Fee: 5}).Validate(); err != visitor.ErrEmptyVisitorID {
+		t.Errorf("Validate() without visitor = %v", err)
+	}
+}
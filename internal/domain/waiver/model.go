@@ -1,17 +1,58 @@
 package waiver
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"strings"
 	"time"
 )
 
+// Title and Terms are the waiver wording shown on the form and sealed into each signed
+// document. Changing them only affects waivers signed afterwards.
+const Title = "Workshop Jiu Jitsu — Waiver & Code of Conduct"
+
+// Terms are the waiver's paragraphs, in order.
+var Terms = []string{
+	"By signing this waiver, I acknowledge the inherent risks associated with martial arts training including but not limited to sprains, strains, fractures, and other physical injuries. I voluntarily assume all risks and release Workshop Jiu Jitsu, its instructors, and staff from any liability.",
+	"I agree to follow the school's Code of Conduct, train respectfully with all partners, maintain personal hygiene standards, and report any injuries promptly using the Red Flag system.",
+}
+
+// SignatureImagePrefix starts every captured signature: a PNG as a data URL.
+const SignatureImagePrefix = "data:image/png;base64,"
+
+// MaxSignatureImageLength bounds a signature data URL; a drawn signature is a few KB.
+const MaxSignatureImageLength = 200_000
+
+var (
+	ErrGuardianRequired   = errors.New("a parent or guardian must sign for a kids member")
+	ErrGuardianIncomplete = errors.New("guardian name, relationship and an email or phone are required")
+	ErrSignatureRequired  = errors.New("a drawn signature is required")
+	ErrInvalidSignature   = errors.New("signature must be a PNG image")
+	ErrDocumentTampered   = errors.New("signed document does not match its hash")
+)
+
+// Guardian identifies the parent or guardian who signed on a minor's behalf.
+type Guardian struct {
+	Name         string
+	Relationship string // e.g. "mother", "legal guardian"
+	Email        string
+	Phone        string
+}
+
 // Waiver holds state for the concept.
 type Waiver struct {
-	ID            string
-	AcceptedTerms bool
-	IPAddress     string
-	MemberID      string
-	SignedAt      time.Time
+	ID             string
+	AcceptedTerms  bool
+	IPAddress      string
+	MemberID       string
+	SignedAt       time.Time
+	SignerName     string   // typed name of whoever signed: the member or their guardian
+	SignatureImage string   // drawn signature as a PNG data URL; "" on waivers from before capture
+	Guardian       Guardian // zero when the member signed for themselves
+	Document       string   // HTML snapshot of exactly what was signed
+	DocumentHash   string   // hex SHA-256 of Document
 }
 
 // Validate checks if the Waiver has valid data.
@@ -28,6 +69,66 @@ func (w *Waiver) Validate() error {
 	if w.SignedAt.IsZero() {
 		return errors.New("signed date must be set")
 	}
+	if w.SignatureImage != "" {
+		if err := ValidateSignatureImage(w.SignatureImage); err != nil {
+			return err
+		}
+	}
+	if w.IsGuardianSigned() {
+		if w.Guardian.Relationship == "" || (w.Guardian.Email == "" && w.Guardian.Phone == "") {
+			return ErrGuardianIncomplete
+		}
+		if w.SignatureImage == "" {
+			return ErrSignatureRequired
+		}
+	}
+	if w.Document != "" && !w.DocumentIntact() {
+		return ErrDocumentTampered
+	}
+	return nil
+}
+
+// IsGuardianSigned reports whether a parent or guardian signed on the member's behalf.
+// PRE: Waiver is initialized
+// POST: Returns true when guardian details are present
+func (w *Waiver) IsGuardianSigned() bool {
+	return w.Guardian.Name != ""
+}
+
+// Seal stores the signed document and its hash so later edits can be detected.
+// PRE: document is the complete rendered waiver
+// POST: Document and DocumentHash are set
+func (w *Waiver) Seal(document string) {
+	w.Document = document
+	w.DocumentHash = HashDocument(document)
+}
+
+// DocumentIntact reports whether the stored document still matches its hash.
+// PRE: Waiver is initialized
+// POST: Returns false when there is no document or it was altered after sealing
+func (w *Waiver) DocumentIntact() bool {
+	return w.Document != "" && HashDocument(w.Document) == w.DocumentHash
+}
+
+// HashDocument returns the hex SHA-256 of a signed document.
+// PRE: none
+// POST: Returns a 64-character lowercase hex string
+func HashDocument(document string) string {
+	sum := sha256.Sum256([]byte(document))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidateSignatureImage checks a drawn signature is a base64 PNG data URL of bounded size.
+// PRE: none
+// POST: Returns ErrInvalidSignature unless image is a decodable PNG data URL
+func ValidateSignatureImage(image string) error {
+	if !strings.HasPrefix(image, SignatureImagePrefix) || len(image) > MaxSignatureImageLength {
+		return ErrInvalidSignature
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(image, SignatureImagePrefix))
+	if err != nil || !strings.HasPrefix(string(data), "\x89PNG\r\n\x1a\n") {
+		return ErrInvalidSignature
+	}
 	return nil
 }
 
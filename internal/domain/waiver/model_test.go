@@ -0,0 +1,70 @@
+package waiver_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"workshop/internal/domain/waiver"
+)
+
+var pngSignature = waiver.SignatureImagePrefix + base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n\x1a\nstrokes"))
+
+// TestWaiver_Validate tests validation of Waiver, including guardian signatures.
+func TestWaiver_Validate(t *testing.T) {
+	base := waiver.Waiver{ID: "1", MemberID: "m1", AcceptedTerms: true, SignedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	guardian := waiver.Guardian{Name: "Mere Parata", Relationship: "mother", Phone: "021 555 0101"}
+
+	tests := []struct {
+		name    string
+		mutate  func(w *waiver.Waiver)
+		wantErr error
+	}{
+		{"valid legacy waiver", func(w *waiver.Waiver) {}, nil},
+		{"valid guardian waiver", func(w *waiver.Waiver) {
+			w.Guardian = guardian
+			w.SignatureImage = pngSignature
+			w.Seal("<p>signed</p>")
+		}, nil},
+		{"guardian without signature", func(w *waiver.Waiver) { w.Guardian = guardian }, waiver.ErrSignatureRequired},
+		{"guardian without relationship", func(w *waiver.Waiver) {
+			w.Guardian = guardian
+			w.Guardian.Relationship = ""
+			w.SignatureImage = pngSignature
+		}, waiver.ErrGuardianIncomplete},
+		{"guardian without contact", func(w *waiver.Waiver) {
+			w.Guardian = guardian
+			w.Guardian.Phone = ""
+			w.SignatureImage = pngSignature
+		}, waiver.ErrGuardianIncomplete},
+		{"signature not a png", func(w *waiver.Waiver) { w.SignatureImage = "data:image/svg+xml;base64,PHN2Zz4=" }, waiver.ErrInvalidSignature},
+		{"signature not base64", func(w *waiver.Waiver) { w.SignatureImage = waiver.SignatureImagePrefix + "%%%" }, waiver.ErrInvalidSignature},
+		{"document edited after sealing", func(w *waiver.Waiver) { w.Seal("<p>signed</p>"); w.Document += "<p>extra</p>" }, waiver.ErrDocumentTampered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := base
+			tt.mutate(&w)
+			if err := w.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestWaiver_Seal verifies sealing hashes the document and detects later changes.
+func TestWaiver_Seal(t *testing.T) {
+	var w waiver.Waiver
+	if w.DocumentIntact() {
+		t.Error("a waiver without a document should not be intact")
+	}
+	w.Seal("<p>signed</p>")
+	if len(w.DocumentHash) != 64 || !w.DocumentIntact() {
+		t.Fatalf("sealed waiver hash %q, intact %v", w.DocumentHash, w.DocumentIntact())
+	}
+	w.Document = "<p>changed</p>"
+	if w.DocumentIntact() {
+		t.Error("an altered document should not be intact")
+	}
+}
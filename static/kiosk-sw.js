@@ -0,0 +1,46 @@
+/* Workshop kiosk service worker.
+ *
+ * Keeps the kiosk usable when WiFi drops: the kiosk page, today's classes and
+ * member search results are served network-first with a cached fallback.
+ * Check-ins made while offline are queued by the page itself (localStorage)
+ * and replayed through POST /api/attendance/bulk-sync once back online.
+ */
+const CACHE_NAME = 'workshop-kiosk-v1';
+const CACHEABLE = ['/kiosk', '/api/classes/today', '/api/members/search'];
+
+self.addEventListener('install', (event) => {
+    event.waitUntil(caches.open(CACHE_NAME).then((cache) => cache.add('/kiosk')).catch(() => {}));
+    self.skipWaiting();
+});
+
+self.addEventListener('activate', (event) => {
+    event.waitUntil(
+        caches.keys().then((keys) => Promise.all(
+            keys.filter((k) => k !== CACHE_NAME).map((k) => caches.delete(k))
+        )).then(() => self.clients.claim())
+    );
+});
+
+self.addEventListener('fetch', (event) => {
+    const req = event.request;
+    if (req.method !== 'GET') return;
+    const url = new URL(req.url);
+    if (url.origin !== self.location.origin) return;
+    if (!CACHEABLE.some((p) => url.pathname === p)) return;
+
+    event.respondWith(
+        fetch(req).then((resp) => {
+            if (resp.ok) {
+                const copy = resp.clone();
+                caches.open(CACHE_NAME).then((cache) => cache.put(req, copy));
+            }
+            return resp;
+        }).catch(() => caches.match(req).then((cached) => {
+            if (cached) return cached;
+            if (url.pathname === '/api/members/search') {
+                return new Response('[]', { headers: { 'Content-Type': 'application/json' } });
+            }
+            return new Response('offline', { status: 503, statusText: 'Offline' });
+        }))
+    );
+});
@@ -0,0 +1,36 @@
+/* Workshop push service worker.
+ *
+ * Shows Web Push notifications sent by the server (class reminders, messages,
+ * notices) and opens the notification's link when it is tapped. Payloads are
+ * JSON: {Kind, Title, Body, Link}. Registered from the inbox page once the
+ * member turns push on; it caches nothing.
+ */
+self.addEventListener('push', (event) => {
+    let data = {};
+    try {
+        data = event.data ? event.data.json() : {};
+    } catch (e) {
+        data = { Title: event.data.text() };
+    }
+    event.waitUntil(self.registration.showNotification(data.Title || 'Workshop', {
+        body: data.Body || '',
+        icon: '/favicon.svg',
+        tag: data.Kind || undefined,
+        data: { link: data.Link || '/dashboard' },
+    }));
+});
+
+self.addEventListener('notificationclick', (event) => {
+    event.notification.close();
+    const link = event.notification.data && event.notification.data.link;
+    const url = new URL(link || '/dashboard', self.location.origin);
+    if (url.origin !== self.location.origin) return;
+    event.waitUntil(
+        self.clients.matchAll({ type: 'window', includeUncontrolled: true }).then((windows) => {
+            for (const w of windows) {
+                if (w.url === url.href && 'focus' in w) return w.focus();
+            }
+            return self.clients.openWindow(url.href);
+        })
+    );
+});
@@ -102,7 +102,6 @@ func TestNavAudit_CoachNavLinks(t *testing.T) {
 	for _, link := range []struct{ text, href string }{
 		{"Members", "/members"},
 		{"Attendance", "/attendance"},
-		{"Grading", "/admin/grading"},
 		{"Curriculum", "/curriculum"},
 	} {
 		loc := nav.Locator(fmt.Sprintf("a[href='%s']", link.href))
@@ -122,8 +121,7 @@ func TestNavAudit_CoachNavLinks(t *testing.T) {
 
 	// Links inside More should now be visible
 	for _, link := range []struct{ text, href string }{
-		{"Schedules", "/admin/schedules"},
-		{"Notices", "/admin/notices"},
+		{"Session Logs", "/session-logs"},
 		{"Themes", "/themes"},
 		{"Library", "/library"},
 		{"Kiosk", "/kiosk"},
@@ -134,8 +132,8 @@ func TestNavAudit_CoachNavLinks(t *testing.T) {
 		}
 	}
 
-	// Coach should NOT have admin-only links
-	for _, href := range []string{"/admin/emails", "/admin/accounts", "/admin/terms"} {
+	// Coach should NOT have links to admin-only routes
+	for _, href := range []string{"/admin/emails", "/admin/accounts", "/admin/terms", "/admin/grading", "/admin/schedules", "/admin/notices"} {
 		loc := nav.Locator(fmt.Sprintf("a[href='%s']", href))
 		count, _ := loc.Count()
 		if count > 0 {
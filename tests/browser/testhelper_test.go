@@ -22,6 +22,7 @@ import (
 	"workshop/internal/adapters/storage"
 	accountStore "workshop/internal/adapters/storage/account"
 	attendanceStore "workshop/internal/adapters/storage/attendance"
+	authSessionStore "workshop/internal/adapters/storage/authsession"
 	bugboxStorePkg "workshop/internal/adapters/storage/bugbox"
 	calendarStorePkg "workshop/internal/adapters/storage/calendar"
 	classTypeStore "workshop/internal/adapters/storage/classtype"
@@ -32,6 +33,7 @@ import (
 	gradingStore "workshop/internal/adapters/storage/grading"
 	holidayStore "workshop/internal/adapters/storage/holiday"
 	injuryStore "workshop/internal/adapters/storage/injury"
+	kpiStore "workshop/internal/adapters/storage/kpi"
 	memberStore "workshop/internal/adapters/storage/member"
 	messageStore "workshop/internal/adapters/storage/message"
 	milestoneStore "workshop/internal/adapters/storage/milestone"
@@ -39,6 +41,7 @@ import (
 	observationStore "workshop/internal/adapters/storage/observation"
 	programStore "workshop/internal/adapters/storage/program"
 	rotorStorePkg "workshop/internal/adapters/storage/rotor"
+	rubricStore "workshop/internal/adapters/storage/rubric"
 	scheduleStore "workshop/internal/adapters/storage/schedule"
 	termStore "workshop/internal/adapters/storage/term"
 	themeStore "workshop/internal/adapters/storage/theme"
@@ -90,9 +93,11 @@ func newTestApp(t *testing.T) *testApp {
 		WaiverStore:              waiverStore.NewSQLiteStore(db),
 		InjuryStore:              injuryStore.NewSQLiteStore(db),
 		AttendanceStore:          attendanceStore.NewSQLiteStore(db),
+		MakeupCreditStore:        attendanceStore.NewMakeupCreditSQLiteStore(db),
 		ProgramStore:             progStore,
 		ClassTypeStore:           ctStore,
 		ScheduleStore:            scheduleStore.NewSQLiteStore(db),
+		OccurrenceChangeStore:    scheduleStore.NewOccurrenceChangeSQLiteStore(db),
 		TermStore:                termStore.NewSQLiteStore(db),
 		HolidayStore:             holidayStore.NewSQLiteStore(db),
 		NoticeStore:              noticeStore.NewSQLiteStore(db),
@@ -100,6 +105,7 @@ func newTestApp(t *testing.T) *testApp {
 		GradingConfigStore:       gradingStore.NewConfigSQLiteStore(db),
 		GradingProposalStore:     gradingStore.NewProposalSQLiteStore(db),
 		GradingNoteStore:         gradingStore.NewNoteSQLiteStore(db),
+		ProposalCommentStore:     gradingStore.NewProposalCommentSQLiteStore(db),
 		GradingMemberConfigStore: gradingStore.NewMemberConfigSQLiteStore(db),
 		MessageStore:             messageStore.NewSQLiteStore(db),
 		ObservationStore:         observationStore.NewSQLiteStore(db),
@@ -109,10 +115,15 @@ func newTestApp(t *testing.T) *testApp {
 		ThemeStore:               themeStore.NewSQLiteStore(db),
 		ClipStore:                clipStore.NewSQLiteStore(db),
 		EmailStore:               emailStore.NewSQLiteStore(db),
+		EmailPreferenceStore:     emailStore.NewPreferenceSQLiteStore(db),
 		RotorStore:               rotorStorePkg.NewSQLiteStore(db),
 		EstimatedHoursStore:      estimatedHoursStore.NewSQLiteStore(db),
 		CalendarEventStore:       calendarStorePkg.NewSQLiteStore(db),
 		BugBoxStore:              bugboxStorePkg.NewSQLiteStore(db),
+		AuthSessionStore:         authSessionStore.NewSQLiteStore(db),
+		KPISnapshotStore:         kpiStore.NewSQLiteStore(db),
+		RubricTemplateStore:      rubricStore.NewTemplateSQLiteStore(db),
+		RubricScoreStore:         rubricStore.NewScoreSQLiteStore(db),
 	}
 
 	// Seed admin (without PasswordChangeRequired so login goes straight to dashboard)
@@ -160,9 +171,10 @@ func newTestApp(t *testing.T) *testApp {
 
 	// Increase rate limit for tests (many rapid API calls)
 	web.RateLimitPerSecond = 1000
+	web.AuthLimitConfig = middleware.AuthLimitConfig{PerIP: 100000, PerEmail: 100000, Window: time.Minute}
 
 	// Start HTTP server
-	mux := web.NewMux("static", stores, nil)
+	mux := web.NewMux(os.DirFS("static"), stores, nil)
 	srv := &http.Server{
 		Addr:    fmt.Sprintf("127.0.0.1:%d", port),
 		Handler: mux,
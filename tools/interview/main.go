@@ -13,6 +13,8 @@ import (
 	"strings"
 	"time"
 	"unicode"
+
+	"workshop/internal/application/textmatch"
 )
 
 const (
@@ -258,7 +260,7 @@ func (i *interviewer) askQueryRoute(owner string) *route {
 
 func (i *interviewer) disambiguate(kind, candidate string, existing []string) (string, bool) {
 	for _, other := range existing {
-		if similarity(candidate, other) >= i.threshold {
+		if textmatch.Similarity(candidate, other) >= i.threshold {
 			yes, _ := i.askYesNo(fmt.Sprintf("Is %s %q the same as %q", kind, candidate, other))
 			if yes {
 				return other, true
@@ -507,55 +509,6 @@ func splitWords(value string) []string {
 	return words
 }
 
-func similarity(a, b string) float64 {
-	if a == b {
-		return 1
-	}
-	la := strings.ToLower(a)
-	lb := strings.ToLower(b)
-	dist := levenshtein(la, lb)
-	maxLen := len(la)
-	if len(lb) > maxLen {
-		maxLen = len(lb)
-	}
-	if maxLen == 0 {
-		return 1
-	}
-	return 1 - float64(dist)/float64(maxLen)
-}
-
-func levenshtein(a, b string) int {
-	if a == b {
-		return 0
-	}
-	if len(a) == 0 {
-		return len(b)
-	}
-	if len(b) == 0 {
-		return len(a)
-	}
-	prev := make([]int, len(b)+1)
-	curr := make([]int, len(b)+1)
-	for j := 0; j <= len(b); j++ {
-		prev[j] = j
-	}
-	for i := 1; i <= len(a); i++ {
-		curr[0] = i
-		for j := 1; j <= len(b); j++ {
-			cost := 0
-			if a[i-1] != b[j-1] {
-				cost = 1
-			}
-			del := prev[j] + 1
-			ins := curr[j-1] + 1
-			sub := prev[j-1] + cost
-			curr[j] = min(del, ins, sub)
-		}
-		copy(prev, curr)
-	}
-	return prev[len(b)]
-}
-
 func conceptNames(concepts []concept) []string {
 	var names []string
 	for _, c := range concepts {
@@ -0,0 +1,27 @@
+// Command openapigen writes the OpenAPI document for the web adapter's JSON API.
+// It runs from `go generate ./internal/adapters/http`.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	web "workshop/internal/adapters/http"
+)
+
+func main() {
+	var out string
+	flag.StringVar(&out, "out", "openapi.json", "output file")
+	flag.Parse()
+
+	spec, err := web.OpenAPIDocument()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "openapigen:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(out, spec, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "openapigen:", err)
+		os.Exit(1)
+	}
+}